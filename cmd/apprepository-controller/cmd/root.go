@@ -97,6 +97,10 @@ func setFlags(c *cobra.Command) {
 	c.Flags().StringSliceVar(&serveOpts.CustomLabels, "custom-labels", []string{""}, "Optional labels to be passed to the generated CronJobs, Jobs and Pods objects. For example: my/label=foo")
 	c.Flags().BoolVar(&serveOpts.V1Beta1CronJobs, "v1-beta1-cron-jobs", false, "Defaults to false and so using the v1 cronjobs.")
 	c.Flags().StringVar(&serveOpts.OciCatalogUrl, "oci-catalog-url", "", "URL for gRPC OCI Catalog service")
+	c.Flags().IntVar(&serveOpts.SyncImportWorkers, "sync-import-workers", 0, "Number of chart icons and files imported at the same time by the sync jobs. The sync job default if 0")
+	c.Flags().IntVar(&serveOpts.SyncImportMaxAttempts, "sync-import-max-attempts", 0, "Number of attempts of the sync jobs to import a chart icon or the files of a chart version. The sync job default if 0")
+	c.Flags().DurationVar(&serveOpts.SyncImportInitialBackoff, "sync-import-initial-backoff", 0, "Delay before the sync jobs retry a failed import, doubled after each attempt. The sync job default if 0")
+	c.Flags().StringSliceVar(&serveOpts.OCICredentialProviderAllowedRegistries, "oci-credential-provider-allowed-registries", nil, "Registries whose global OCI repositories can be synced with the cloud identity of Kubeapps, as per their credential provider. No repository can use a credential provider if empty")
 }
 
 // initConfig reads in config file and ENV variables if set.
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/vmware-tanzu/kubeapps/cmd/apprepository-controller/server"
@@ -115,33 +116,41 @@ func TestParseFlagsCorrect(t *testing.T) {
 				"--successful-jobs-history-limit", "33",
 				"--failed-jobs-history-limit", "11",
 				"--concurrency-policy", "Allow",
+				"--sync-import-workers", "20",
+				"--sync-import-max-attempts", "5",
+				"--sync-import-initial-backoff", "2s",
+				"--oci-credential-provider-allowed-registries", "123456789012.dkr.ecr.us-east-1.amazonaws.com",
 			},
 			server.Config{
-				Kubeconfig:                 "foo01",
-				APIServerURL:               "foo02",
-				RepoSyncImage:              "foo03",
-				RepoSyncImagePullSecrets:   []string{"s1", "s2", "s3"},
-				ImagePullSecretsRefs:       []v1.LocalObjectReference{{Name: "s1"}, {Name: "s2"}, {Name: "s3"}},
-				RepoSyncCommand:            "foo04",
-				KubeappsNamespace:          "foo05",
-				GlobalPackagingNamespace:   "kubeapps-repos-global",
-				ReposPerNamespace:          false,
-				DBURL:                      "foo06",
-				DBUser:                     "foo07",
-				DBName:                     "foo08",
-				DBSecretName:               "foo09",
-				DBSecretKey:                "foo10",
-				UserAgentComment:           "foo11",
-				Crontab:                    "foo12",
-				TTLSecondsAfterFinished:    "1200",
-				CustomAnnotations:          []string{"foo13=bar13", "foo13x=bar13x", "extra13=extra13"},
-				CustomLabels:               []string{"foo14=bar14", "foo14x=bar14x"},
-				ParsedCustomAnnotations:    map[string]string{"foo13": "bar13", "foo13x": "bar13x", "extra13": "extra13"},
-				ParsedCustomLabels:         map[string]string{"foo14": "bar14", "foo14x": "bar14x"},
-				V1Beta1CronJobs:            true,
-				SuccessfulJobsHistoryLimit: 33,
-				FailedJobsHistoryLimit:     11,
-				ConcurrencyPolicy:          "Allow",
+				Kubeconfig:                             "foo01",
+				APIServerURL:                           "foo02",
+				RepoSyncImage:                          "foo03",
+				RepoSyncImagePullSecrets:               []string{"s1", "s2", "s3"},
+				ImagePullSecretsRefs:                   []v1.LocalObjectReference{{Name: "s1"}, {Name: "s2"}, {Name: "s3"}},
+				RepoSyncCommand:                        "foo04",
+				KubeappsNamespace:                      "foo05",
+				GlobalPackagingNamespace:               "kubeapps-repos-global",
+				ReposPerNamespace:                      false,
+				DBURL:                                  "foo06",
+				DBUser:                                 "foo07",
+				DBName:                                 "foo08",
+				DBSecretName:                           "foo09",
+				DBSecretKey:                            "foo10",
+				UserAgentComment:                       "foo11",
+				Crontab:                                "foo12",
+				TTLSecondsAfterFinished:                "1200",
+				CustomAnnotations:                      []string{"foo13=bar13", "foo13x=bar13x", "extra13=extra13"},
+				CustomLabels:                           []string{"foo14=bar14", "foo14x=bar14x"},
+				ParsedCustomAnnotations:                map[string]string{"foo13": "bar13", "foo13x": "bar13x", "extra13": "extra13"},
+				ParsedCustomLabels:                     map[string]string{"foo14": "bar14", "foo14x": "bar14x"},
+				V1Beta1CronJobs:                        true,
+				SuccessfulJobsHistoryLimit:             33,
+				FailedJobsHistoryLimit:                 11,
+				ConcurrencyPolicy:                      "Allow",
+				SyncImportWorkers:                      20,
+				SyncImportMaxAttempts:                  5,
+				SyncImportInitialBackoff:               2 * time.Second,
+				OCICredentialProviderAllowedRegistries: []string{"123456789012.dkr.ecr.us-east-1.amazonaws.com"},
 			},
 			true,
 		},
@@ -35,6 +35,13 @@ type AppRepositorySpec struct {
 	// In case of an OCI type, the list of repositories is needed
 	// as there is no API for the index
 	OCIRepositories []string `json:"ociRepositories,omitempty"`
+	// In case of an OCI type, OCITagFilter restricts the tags of the
+	// repositories which are synced
+	OCITagFilter *OCITagFilterSpec `json:"ociTagFilter,omitempty"`
+	// In case of an OCI type, OCICredentialProvider is the cloud provider
+	// (aws, azure or gcp) whose short-lived registry credentials are obtained
+	// from the identity of the workload, rather than from Auth
+	OCICredentialProvider string `json:"ociCredentialProvider,omitempty"`
 	// TLSInsecureSkipVerify skips TLS verification
 	TLSInsecureSkipVerify bool `json:"tlsInsecureSkipVerify,omitempty"`
 	// FilterRule allows to filter packages based on a JQuery
@@ -45,6 +52,13 @@ type AppRepositorySpec struct {
 	PassCredentials bool `json:"passCredentials,omitempty"`
 	// Interval is the time between resyncs of the repository
 	Interval string `json:"interval,omitempty"`
+	// AllowedNamespaces restricts the namespaces into which packages from
+	// this repository can be installed. When both AllowedNamespaces and
+	// AllowedNamespaceSelector are empty, any namespace is allowed.
+	AllowedNamespaces []string `json:"allowedNamespaces,omitempty"`
+	// AllowedNamespaceSelector restricts the namespaces into which packages
+	// from this repository can be installed to those matching the selector.
+	AllowedNamespaceSelector *metav1.LabelSelector `json:"allowedNamespaceSelector,omitempty"`
 }
 
 // AppRepositoryAuth is the auth for an AppRepository resource
@@ -71,6 +85,17 @@ type FilterRuleSpec struct {
 	Variables map[string]string `json:"variables,omitempty"`
 }
 
+// OCITagFilterSpec defines which tags of the OCI repositories are synced
+type OCITagFilterSpec struct {
+	// Regex is a regular expression which the synced tags match
+	Regex string `json:"regex,omitempty"`
+	// Constraint is a semver constraint which the synced tags satisfy
+	Constraint string `json:"constraint,omitempty"`
+	// MaxTags is the maximum number of tags synced for each repository, the
+	// latest ones. Unlimited if 0
+	MaxTags int `json:"maxTags,omitempty"`
+}
+
 // AppRepositoryStatus is the status for an AppRepository resource
 type AppRepositoryStatus struct {
 	Status string `json:"status"`
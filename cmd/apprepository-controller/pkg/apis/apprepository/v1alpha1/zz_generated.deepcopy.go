@@ -9,6 +9,7 @@
 package v1alpha1
 
 import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -143,6 +144,21 @@ func (in *AppRepositorySpec) DeepCopyInto(out *AppRepositorySpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.OCITagFilter != nil {
+		in, out := &in.OCITagFilter, &out.OCITagFilter
+		*out = new(OCITagFilterSpec)
+		**out = **in
+	}
+	if in.AllowedNamespaces != nil {
+		in, out := &in.AllowedNamespaces, &out.AllowedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedNamespaceSelector != nil {
+		in, out := &in.AllowedNamespaceSelector, &out.AllowedNamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -171,3 +187,19 @@ func (in *AppRepositoryStatus) DeepCopy() *AppRepositoryStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OCITagFilterSpec) DeepCopyInto(out *OCITagFilterSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCITagFilterSpec.
+func (in *OCITagFilterSpec) DeepCopy() *OCITagFilterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OCITagFilterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
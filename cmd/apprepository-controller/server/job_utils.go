@@ -12,6 +12,7 @@ import (
 	"github.com/adhocore/gronx"
 	apprepov1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/apprepository-controller/pkg/apis/apprepository/v1alpha1"
 	"github.com/vmware-tanzu/kubeapps/pkg/helm"
+	"github.com/vmware-tanzu/kubeapps/pkg/ocicredentials"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -131,6 +132,38 @@ func apprepoSyncJobArgs(apprepo *apprepov1alpha1.AppRepository, config Config) [
 		args = append(args, "--oci-repositories", strings.Join(apprepo.Spec.OCIRepositories, ","))
 	}
 
+	if tagFilter := apprepo.Spec.OCITagFilter; tagFilter != nil {
+		if tagFilter.Regex != "" {
+			args = append(args, "--oci-tag-regex", tagFilter.Regex)
+		}
+		if tagFilter.Constraint != "" {
+			args = append(args, "--oci-tag-constraint", tagFilter.Constraint)
+		}
+		if tagFilter.MaxTags > 0 {
+			args = append(args, "--oci-max-tags", strconv.Itoa(tagFilter.MaxTags))
+		}
+	}
+
+	if apprepo.Spec.OCICredentialProvider != "" {
+		// The sync job would pull with the cloud identity of Kubeapps, so
+		// only the repositories allowed by the administrators can use it.
+		if err := ocicredentials.CheckAllowed(config.OCICredentialProviderAllowedRegistries, apprepo.GetNamespace(), config.GlobalPackagingNamespace, apprepo.Spec.URL); err != nil {
+			log.Errorf("Ignoring the OCI credential provider of the AppRepository %s/%s: %v", apprepo.GetNamespace(), apprepo.GetName(), err)
+		} else {
+			args = append(args, "--oci-credential-provider", apprepo.Spec.OCICredentialProvider)
+		}
+	}
+
+	if config.SyncImportWorkers > 0 {
+		args = append(args, "--import-workers", strconv.Itoa(config.SyncImportWorkers))
+	}
+	if config.SyncImportMaxAttempts > 0 {
+		args = append(args, "--import-max-attempts", strconv.Itoa(config.SyncImportMaxAttempts))
+	}
+	if config.SyncImportInitialBackoff > 0 {
+		args = append(args, "--import-initial-backoff", config.SyncImportInitialBackoff.String())
+	}
+
 	if apprepo.Spec.TLSInsecureSkipVerify {
 		args = append(args, "--tls-insecure-skip-verify")
 	}
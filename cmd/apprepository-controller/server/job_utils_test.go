@@ -5,6 +5,7 @@ package server
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	apprepov1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/apprepository-controller/pkg/apis/apprepository/v1alpha1"
@@ -1522,6 +1523,188 @@ func Test_newSyncJob(t *testing.T) {
 				},
 			},
 		},
+		{
+			"OCI repo with tag filter",
+			"",
+			&apprepov1alpha1.AppRepository{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "AppRepository",
+					APIVersion: "kubeapps.com/v1alpha1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-charts",
+					Namespace: "kubeapps",
+					Labels: map[string]string{
+						"name":       "my-charts",
+						"created-by": "kubeapps",
+					},
+				},
+				Spec: apprepov1alpha1.AppRepositorySpec{
+					Type:            "oci",
+					URL:             "https://charts.acme.com/my-charts",
+					OCIRepositories: []string{"apache", "jenkins"},
+					OCITagFilter: &apprepov1alpha1.OCITagFilterSpec{
+						Regex:      `^\d+\.\d+\.\d+$`,
+						Constraint: ">=1.0.0",
+						MaxTags:    10,
+					},
+				},
+			},
+			batchv1.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					GenerateName: "apprepo-kubeapps-sync-my-charts-",
+					OwnerReferences: []metav1.OwnerReference{
+						*metav1.NewControllerRef(
+							&apprepov1alpha1.AppRepository{ObjectMeta: metav1.ObjectMeta{Name: "my-charts"}},
+							schema.GroupVersionKind{
+								Group:   apprepov1alpha1.SchemeGroupVersion.Group,
+								Version: apprepov1alpha1.SchemeGroupVersion.Version,
+								Kind:    "AppRepository",
+							},
+						),
+					},
+					Annotations: map[string]string{},
+					Labels:      map[string]string{},
+				},
+				Spec: batchv1.JobSpec{
+					TTLSecondsAfterFinished: &defaultTTL,
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: map[string]string{
+								LabelRepoName:      "my-charts",
+								LabelRepoNamespace: "kubeapps",
+							},
+							Annotations: map[string]string{},
+						},
+						Spec: corev1.PodSpec{
+							RestartPolicy: corev1.RestartPolicyOnFailure,
+							Containers: []corev1.Container{
+								{
+									Name:            "sync",
+									Image:           repoSyncImage,
+									ImagePullPolicy: corev1.PullIfNotPresent,
+									Command:         []string{"/chart-repo"},
+									Args: []string{
+										"sync",
+										"--database-url=postgresql.kubeapps",
+										"--database-user=admin",
+										"--database-name=assets",
+										"--global-repos-namespace=kubeapps-global",
+										"--namespace=kubeapps",
+										"my-charts",
+										"https://charts.acme.com/my-charts",
+										"oci",
+										"--oci-repositories",
+										"apache,jenkins",
+										"--oci-tag-regex",
+										`^\d+\.\d+\.\d+$`,
+										"--oci-tag-constraint",
+										">=1.0.0",
+										"--oci-max-tags",
+										"10",
+									},
+									Env: []corev1.EnvVar{
+										{
+											Name: "DB_PASSWORD",
+											ValueFrom: &corev1.EnvVarSource{
+												SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "postgresql"}, Key: "postgresql-root-password"}},
+										},
+									},
+									VolumeMounts: nil,
+								},
+							},
+							Volumes: nil,
+						},
+					},
+				},
+			},
+		},
+		{
+			"OCI repo with a credential provider not allowed",
+			"",
+			&apprepov1alpha1.AppRepository{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "AppRepository",
+					APIVersion: "kubeapps.com/v1alpha1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-charts",
+					Namespace: "kubeapps",
+					Labels: map[string]string{
+						"name":       "my-charts",
+						"created-by": "kubeapps",
+					},
+				},
+				Spec: apprepov1alpha1.AppRepositorySpec{
+					Type:                  "oci",
+					URL:                   "https://charts.acme.com/my-charts",
+					OCIRepositories:       []string{"apache", "jenkins"},
+					OCICredentialProvider: "aws",
+				},
+			},
+			batchv1.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					GenerateName: "apprepo-kubeapps-sync-my-charts-",
+					OwnerReferences: []metav1.OwnerReference{
+						*metav1.NewControllerRef(
+							&apprepov1alpha1.AppRepository{ObjectMeta: metav1.ObjectMeta{Name: "my-charts"}},
+							schema.GroupVersionKind{
+								Group:   apprepov1alpha1.SchemeGroupVersion.Group,
+								Version: apprepov1alpha1.SchemeGroupVersion.Version,
+								Kind:    "AppRepository",
+							},
+						),
+					},
+					Annotations: map[string]string{},
+					Labels:      map[string]string{},
+				},
+				Spec: batchv1.JobSpec{
+					TTLSecondsAfterFinished: &defaultTTL,
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: map[string]string{
+								LabelRepoName:      "my-charts",
+								LabelRepoNamespace: "kubeapps",
+							},
+							Annotations: map[string]string{},
+						},
+						Spec: corev1.PodSpec{
+							RestartPolicy: corev1.RestartPolicyOnFailure,
+							Containers: []corev1.Container{
+								{
+									Name:            "sync",
+									Image:           repoSyncImage,
+									ImagePullPolicy: corev1.PullIfNotPresent,
+									Command:         []string{"/chart-repo"},
+									Args: []string{
+										"sync",
+										"--database-url=postgresql.kubeapps",
+										"--database-user=admin",
+										"--database-name=assets",
+										"--global-repos-namespace=kubeapps-global",
+										"--namespace=kubeapps",
+										"my-charts",
+										"https://charts.acme.com/my-charts",
+										"oci",
+										"--oci-repositories",
+										"apache,jenkins",
+									},
+									Env: []corev1.EnvVar{
+										{
+											Name: "DB_PASSWORD",
+											ValueFrom: &corev1.EnvVarSource{
+												SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "postgresql"}, Key: "postgresql-root-password"}},
+										},
+									},
+									VolumeMounts: nil,
+								},
+							},
+							Volumes: nil,
+						},
+					},
+				},
+			},
+		},
 		{
 			"Paas credentials",
 			"",
@@ -1711,6 +1894,91 @@ func Test_newSyncJob(t *testing.T) {
 	}
 }
 
+func Test_apprepoSyncJobArgsImportOptions(t *testing.T) {
+	apprepo := &apprepov1alpha1.AppRepository{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-charts", Namespace: "kubeapps"},
+		Spec:       apprepov1alpha1.AppRepositorySpec{Type: "helm", URL: "https://charts.acme.com/my-charts"},
+	}
+	config := makeDefaultConfig()
+	config.SyncImportWorkers = 20
+	config.SyncImportMaxAttempts = 5
+	config.SyncImportInitialBackoff = 2 * time.Second
+
+	expected := []string{
+		"sync",
+		"--database-url=postgresql.kubeapps",
+		"--database-user=admin",
+		"--database-name=assets",
+		"--global-repos-namespace=kubeapps-global",
+		"--namespace=kubeapps",
+		"my-charts",
+		"https://charts.acme.com/my-charts",
+		"helm",
+		"--import-workers", "20",
+		"--import-max-attempts", "5",
+		"--import-initial-backoff", "2s",
+	}
+	if got, want := apprepoSyncJobArgs(apprepo, config), expected; !cmp.Equal(want, got) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+	}
+}
+
+func Test_apprepoSyncJobArgsOCICredentialProvider(t *testing.T) {
+	registry := "123456789012.dkr.ecr.us-east-1.amazonaws.com"
+	tests := []struct {
+		name              string
+		namespace         string
+		allowedRegistries []string
+		expectedArgs      []string
+	}{
+		{
+			name:              "passes the credential provider of a global repository of an allowed registry",
+			namespace:         "kubeapps-global",
+			allowedRegistries: []string{registry},
+			expectedArgs:      []string{"--oci-credential-provider", "aws"},
+		},
+		{
+			name:      "omits the credential provider if no registry is allowed",
+			namespace: "kubeapps-global",
+		},
+		{
+			name:              "omits the credential provider of a namespaced repository",
+			namespace:         "team-a",
+			allowedRegistries: []string{registry},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apprepo := &apprepov1alpha1.AppRepository{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-charts", Namespace: tt.namespace},
+				Spec: apprepov1alpha1.AppRepositorySpec{
+					Type:                  "oci",
+					URL:                   "oci://" + registry + "/charts",
+					OCICredentialProvider: "aws",
+				},
+			}
+			config := makeDefaultConfig()
+			config.OCICredentialProviderAllowedRegistries = tt.allowedRegistries
+
+			expected := append([]string{
+				"sync",
+				"--database-url=postgresql.kubeapps",
+				"--database-user=admin",
+				"--database-name=assets",
+				"--global-repos-namespace=kubeapps-global",
+				"--namespace=" + tt.namespace,
+				"my-charts",
+				"oci://" + registry + "/charts",
+				"oci",
+			}, tt.expectedArgs...)
+			if got, want := apprepoSyncJobArgs(apprepo, config), expected; !cmp.Equal(want, got) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
 func Test_newCleanupJob(t *testing.T) {
 	tests := []struct {
 		name              string
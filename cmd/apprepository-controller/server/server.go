@@ -5,6 +5,7 @@ package server
 
 import (
 	"fmt"
+	"time"
 
 	clientset "github.com/vmware-tanzu/kubeapps/cmd/apprepository-controller/pkg/client/clientset/versioned"
 	informers "github.com/vmware-tanzu/kubeapps/cmd/apprepository-controller/pkg/client/informers/externalversions"
@@ -42,6 +43,12 @@ type Config struct {
 	ParsedCustomLabels         map[string]string
 	V1Beta1CronJobs            bool
 	OciCatalogUrl              string
+	SyncImportWorkers          int
+	SyncImportMaxAttempts      int
+	SyncImportInitialBackoff   time.Duration
+	// OCICredentialProviderAllowedRegistries are the registries whose global
+	// OCI repositories are synced with the cloud identity of Kubeapps.
+	OCICredentialProviderAllowedRegistries []string
 }
 
 func Serve(serveOpts Config) error {
@@ -6,6 +6,7 @@ package cmd
 import (
 	"flag"
 	"os"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -133,6 +134,13 @@ func setRootFlags(c *cobra.Command) {
 
 func setSyncFlags(c *cobra.Command) {
 	c.Flags().StringSliceVar(&serveOpts.OciRepositories, "oci-repositories", []string{}, "List of OCI Repositories in case the type is OCI")
+	c.Flags().StringVar(&serveOpts.OCITagRegex, "oci-tag-regex", "", "Regular expression which the synced tags of the OCI repositories match")
+	c.Flags().StringVar(&serveOpts.OCITagConstraint, "oci-tag-constraint", "", "Semver constraint which the synced tags of the OCI repositories satisfy")
+	c.Flags().IntVar(&serveOpts.OCIMaxTags, "oci-max-tags", 0, "Maximum number of tags synced for each OCI repository, the latest ones. Unlimited if 0")
+	c.Flags().StringVar(&serveOpts.OCICredentialProvider, "oci-credential-provider", "", "Cloud provider (aws, azure or gcp) whose registry credentials are obtained from the identity of the workload, rather than from the auth header, in case the type is OCI")
+	c.Flags().IntVar(&serveOpts.ImportWorkers, "import-workers", 10, "Number of chart icons and files imported at the same time")
+	c.Flags().IntVar(&serveOpts.ImportMaxAttempts, "import-max-attempts", 3, "Number of attempts to import a chart icon or the files of a chart version")
+	c.Flags().DurationVar(&serveOpts.ImportInitialBackoff, "import-initial-backoff", time.Second, "Delay before retrying a failed import, doubled after each attempt")
 }
 
 // initConfig reads in config file and ENV variables if set.
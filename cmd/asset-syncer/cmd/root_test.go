@@ -6,6 +6,7 @@ package cmd
 import (
 	"bytes"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/vmware-tanzu/kubeapps/cmd/asset-syncer/server"
@@ -46,6 +47,9 @@ func TestParseFlagsCorrect(t *testing.T) {
 				PassCredentials:          true,
 				UserAgent:                "asset-syncer/devel (foo05)",
 				UserAgentComment:         "foo05",
+				ImportWorkers:            10,
+				ImportMaxAttempts:        3,
+				ImportInitialBackoff:     time.Second,
 			},
 			true,
 		},
@@ -64,6 +68,13 @@ func TestParseFlagsCorrect(t *testing.T) {
 				"--filter-rules", "foo06",
 				"--pass-credentials", "true",
 				"--oci-repositories", "foo07",
+				"--oci-tag-regex", "^foo08",
+				"--oci-tag-constraint", ">=1.0.0",
+				"--oci-max-tags", "10",
+				"--oci-credential-provider", "gcp",
+				"--import-workers", "20",
+				"--import-max-attempts", "5",
+				"--import-initial-backoff", "2s",
 			},
 			server.Config{
 				DatabaseURL:              "foo01",
@@ -73,6 +84,13 @@ func TestParseFlagsCorrect(t *testing.T) {
 				Namespace:                "foo04",
 				GlobalPackagingNamespace: "kubeapps-global",
 				OciRepositories:          []string{"foo07"},
+				OCITagRegex:              "^foo08",
+				OCITagConstraint:         ">=1.0.0",
+				OCIMaxTags:               10,
+				OCICredentialProvider:    "gcp",
+				ImportWorkers:            20,
+				ImportMaxAttempts:        5,
+				ImportInitialBackoff:     2 * time.Second,
 				TlsInsecureSkipVerify:    true,
 				FilterRules:              "foo06",
 				PassCredentials:          true,
@@ -109,6 +127,9 @@ func TestParseFlagsCorrect(t *testing.T) {
 				PassCredentials:          true,
 				UserAgent:                "asset-syncer/devel (foo05)",
 				UserAgentComment:         "foo05",
+				ImportWorkers:            10,
+				ImportMaxAttempts:        3,
+				ImportInitialBackoff:     time.Second,
 			},
 			true,
 		},
@@ -0,0 +1,325 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	semver "github.com/Masterminds/semver/v3"
+	"github.com/vmware-tanzu/kubeapps/pkg/chart/models"
+	log "k8s.io/klog/v2"
+	"oras.land/oras-go/v2/registry/remote"
+	"oras.land/oras-go/v2/registry/remote/auth"
+	"oras.land/oras-go/v2/registry/remote/errcode"
+)
+
+const (
+	// ociTagListPageSize is the number of tags requested for each page of the
+	// tag list of an OCI repository.
+	ociTagListPageSize = 100
+	// maxOCITagListPages is the maximum number of pages of the tag list of an
+	// OCI repository which are followed, protecting against registries
+	// returning a next link endlessly.
+	maxOCITagListPages = 1000
+)
+
+// ociTagFilter restricts the tags of the OCI repositories which are synced.
+// The zero value syncs all the semver tags.
+type ociTagFilter struct {
+	// regex is the regular expression which the synced tags match, if any
+	regex *regexp.Regexp
+	// constraint is the semver constraint which the synced tags satisfy, if any
+	constraint *semver.Constraints
+	// maxTags is the maximum number of tags synced, the latest ones, if positive
+	maxTags int
+}
+
+// newOCITagFilter returns the tag filter for the given options, which are
+// optional.
+func newOCITagFilter(regex, constraint string, maxTags int) (ociTagFilter, error) {
+	filter := ociTagFilter{maxTags: maxTags}
+	if regex != "" {
+		compiled, err := regexp.Compile(regex)
+		if err != nil {
+			return ociTagFilter{}, fmt.Errorf("invalid OCI tag regex %q: %w", regex, err)
+		}
+		filter.regex = compiled
+	}
+	if constraint != "" {
+		compiled, err := semver.NewConstraint(constraint)
+		if err != nil {
+			return ociTagFilter{}, fmt.Errorf("invalid OCI tag constraint %q: %w", constraint, err)
+		}
+		filter.constraint = compiled
+	}
+	return filter, nil
+}
+
+// apply returns the tags passing the filter, ordered by descending semver and
+// capped to the maximum number of tags. The tags which are not semver, such as
+// "latest", are skipped since they cannot be chart versions.
+func (f ociTagFilter) apply(tags []string) ([]string, error) {
+	filtered := []string{}
+	for _, tag := range tags {
+		if f.regex != nil && !f.regex.MatchString(tag) {
+			continue
+		}
+		version, err := semver.NewVersion(tag)
+		if err != nil {
+			log.V(4).Infof("Skipping the tag %q which is not semver: %v", tag, err)
+			continue
+		}
+		if f.constraint != nil && !f.constraint.Check(version) {
+			continue
+		}
+		filtered = append(filtered, tag)
+	}
+	ordered, err := orderVersions(filtered)
+	if err != nil {
+		return nil, err
+	}
+	if f.maxTags > 0 && len(ordered) > f.maxTags {
+		ordered = ordered[:f.maxTags]
+	}
+	return ordered, nil
+}
+
+// tagListPage is a page of the tag list of an OCI repository, as stored in
+// the cache to be requested again only if its ETag changed.
+type tagListPage struct {
+	// URL is the URL from which the page was requested
+	URL string `json:"url"`
+	// ETag is the entity tag of the page returned by the registry, if any
+	ETag string `json:"etag,omitempty"`
+	// Tags are the tags of the page
+	Tags []string `json:"tags"`
+	// Next is the URL of the next page, empty for the last page
+	Next string `json:"next,omitempty"`
+}
+
+// tagListCache stores the pages of the tag lists of the apps of an OCI
+// repository between syncs.
+type tagListCache interface {
+	TagListPages(appName string) ([]tagListPage, error)
+	UpdateTagListPages(appName string, pages []tagListPage) error
+}
+
+// repoTagListCache is the tag list cache of an OCI repository stored with
+// the asset manager.
+type repoTagListCache struct {
+	manager assetManager
+	repo    models.AppRepository
+}
+
+func (c *repoTagListCache) TagListPages(appName string) ([]tagListPage, error) {
+	return c.manager.tagListPages(c.repo, appName)
+}
+
+func (c *repoTagListCache) UpdateTagListPages(appName string, pages []tagListPage) error {
+	return c.manager.updateTagListPages(c.repo, appName, pages)
+}
+
+// TagList retrieves the list of tags for an asset, following the pages of the
+// list and only downloading again the pages whose ETag changed since the
+// previous sync when a cache is configured.
+func (o *OciAPIClient) TagList(appName string, userAgent string) (*TagList, error) {
+	orasRepoClient, err := o.getOrasRepoClient(appName, userAgent)
+	if err != nil {
+		return nil, err
+	}
+	ctx := auth.AppendScopes(context.TODO(), auth.ScopeRepository(orasRepoClient.Reference.Repository, auth.ActionPull))
+
+	cachedPages := map[string]tagListPage{}
+	if o.TagListCache != nil {
+		pages, err := o.TagListCache.TagListPages(appName)
+		if err != nil {
+			log.Errorf("Unable to read the cached tag list of %q: %v", appName, err)
+		}
+		for _, page := range pages {
+			cachedPages[page.URL] = page
+		}
+	}
+
+	scheme := "https"
+	if orasRepoClient.PlainHTTP {
+		scheme = "http"
+	}
+	pageURL := fmt.Sprintf("%s://%s/v2/%s/tags/list", scheme, orasRepoClient.Reference.Host(), orasRepoClient.Reference.Repository)
+	if o.TagListPageSize > 0 {
+		pageURL += "?n=" + strconv.Itoa(o.TagListPageSize)
+	}
+
+	pages := []tagListPage{}
+	changed := false
+	for pageURL != "" {
+		if len(pages) == maxOCITagListPages {
+			return nil, fmt.Errorf("the tag list of %q has more than %d pages", appName, maxOCITagListPages)
+		}
+		cached := cachedPages[pageURL]
+		page, err := o.tagListPage(ctx, orasRepoClient.Client, pageURL, cached.ETag)
+		if err != nil {
+			return nil, err
+		}
+		if page == nil {
+			log.V(4).Infof("Tag list page %q not modified", pageURL)
+			page = &cached
+		} else {
+			changed = true
+		}
+		pages = append(pages, *page)
+		pageURL = page.Next
+	}
+
+	if o.TagListCache != nil && changed {
+		if err := o.TagListCache.UpdateTagListPages(appName, pages); err != nil {
+			log.Errorf("Unable to cache the tag list of %q: %v", appName, err)
+		}
+	}
+
+	tags := []string{}
+	for _, page := range pages {
+		tags = append(tags, page.Tags...)
+	}
+	return &TagList{
+		Name: orasRepoClient.Reference.Repository,
+		Tags: tags,
+	}, nil
+}
+
+// tagListPage requests a page of a tag list, returning nil if the page did not
+// change since it had the given ETag.
+func (o *OciAPIClient) tagListPage(ctx context.Context, client remote.Client, pageURL, etag string) (*tagListPage, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotModified:
+		if etag != "" {
+			return nil, nil
+		}
+		fallthrough
+	default:
+		return nil, tagListErrorResponse(resp)
+	}
+
+	var tagList TagList
+	if err := json.NewDecoder(resp.Body).Decode(&tagList); err != nil {
+		return nil, fmt.Errorf("%s %q: failed to decode response: %w", resp.Request.Method, resp.Request.URL, err)
+	}
+	next, err := nextPageURL(resp)
+	if err != nil {
+		return nil, err
+	}
+	return &tagListPage{
+		URL:  pageURL,
+		ETag: resp.Header.Get("ETag"),
+		Tags: tagList.Tags,
+		Next: next,
+	}, nil
+}
+
+// tagListErrorResponse returns the error for an unexpected response of the
+// registry, with the errors of its body when the registry returns them.
+func tagListErrorResponse(resp *http.Response) error {
+	errResponse := &errcode.ErrorResponse{
+		Method:     resp.Request.Method,
+		URL:        resp.Request.URL,
+		StatusCode: resp.StatusCode,
+	}
+	var body struct {
+		Errors errcode.Errors `json:"errors"`
+	}
+	if data, err := io.ReadAll(io.LimitReader(resp.Body, 8*1024)); err == nil && json.Unmarshal(data, &body) == nil {
+		errResponse.Errors = body.Errors
+	}
+	return errResponse
+}
+
+// nextPageURL returns the absolute URL of the next page from the Link header
+// of a paginated response, or an empty string for the last page.
+func nextPageURL(resp *http.Response) (string, error) {
+	link := resp.Header.Get("Link")
+	if link == "" {
+		return "", nil
+	}
+	start, end := strings.IndexByte(link, '<'), strings.IndexByte(link, '>')
+	if start != 0 || end == -1 {
+		return "", fmt.Errorf("invalid next link %q", link)
+	}
+	nextURL, err := resp.Request.URL.Parse(link[start+1 : end])
+	if err != nil {
+		return "", err
+	}
+	return nextURL.String(), nil
+}
+
+// tagListResult is the filtered list of tags of an app of an OCI repository.
+type tagListResult struct {
+	appName string
+	tags    []string
+	err     error
+}
+
+// listTags lists and filters the tags of the apps in parallel, with
+// numWorkersOCI workers, sending the results as they are received until the
+// context is done.
+func (r *OCIRegistry) listTags(ctx context.Context, appNames []string) <-chan tagListResult {
+	appNamesChan := make(chan string)
+	results := make(chan tagListResult)
+	var wg sync.WaitGroup
+	for i := 0; i < numWorkersOCI; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for appName := range appNamesChan {
+				result := tagListResult{appName: appName}
+				tagList, err := r.ociCli.TagList(appName, GetUserAgent("", ""))
+				if err != nil {
+					result.err = fmt.Errorf("unable to list tags of %q: %w", appName, err)
+				} else {
+					result.tags, result.err = r.tagFilter.apply(tagList.Tags)
+				}
+				select {
+				case results <- result:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		defer close(appNamesChan)
+		for _, appName := range appNames {
+			select {
+			case appNamesChan <- appName:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+	return results
+}
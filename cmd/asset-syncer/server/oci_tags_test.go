@@ -0,0 +1,149 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_ociTagFilter(t *testing.T) {
+	tags := []string{"1.0.0", "latest", "2.0.0-rc.1", "1.2.0", "2.0.0", "1.10.0"}
+
+	tests := []struct {
+		name         string
+		regex        string
+		constraint   string
+		maxTags      int
+		expectedTags []string
+	}{
+		{
+			name:         "it orders the semver tags, skipping the others",
+			expectedTags: []string{"2.0.0", "2.0.0-rc.1", "1.10.0", "1.2.0", "1.0.0"},
+		},
+		{
+			name:         "it keeps the tags matching the regex",
+			regex:        `^\d+\.\d+\.\d+$`,
+			expectedTags: []string{"2.0.0", "1.10.0", "1.2.0", "1.0.0"},
+		},
+		{
+			name:         "it keeps the tags satisfying the constraint",
+			constraint:   ">=1.2.0 <2.0.0",
+			expectedTags: []string{"1.10.0", "1.2.0"},
+		},
+		{
+			name:         "it keeps the latest tags up to the cap",
+			maxTags:      2,
+			expectedTags: []string{"2.0.0", "2.0.0-rc.1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := newOCITagFilter(tt.regex, tt.constraint, tt.maxTags)
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			got, err := filter.apply(tags)
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if want := tt.expectedTags; !cmp.Equal(want, got) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+
+	t.Run("it returns an error for an invalid regex or constraint", func(t *testing.T) {
+		if _, err := newOCITagFilter("(", "", 0); err == nil {
+			t.Errorf("got: nil, want: error for an invalid regex")
+		}
+		if _, err := newOCITagFilter("", "not a constraint", 0); err == nil {
+			t.Errorf("got: nil, want: error for an invalid constraint")
+		}
+	})
+}
+
+type fakeTagListCache struct {
+	pages map[string][]tagListPage
+}
+
+func (c *fakeTagListCache) TagListPages(appName string) ([]tagListPage, error) {
+	return c.pages[appName], nil
+}
+
+func (c *fakeTagListCache) UpdateTagListPages(appName string, pages []tagListPage) error {
+	c.pages[appName] = pages
+	return nil
+}
+
+func Test_ociAPICliTagListPages(t *testing.T) {
+	// The tag list has two pages of two tags, the second page with an ETag.
+	pages := map[string]string{
+		"":      `{"name":"apache","tags":["7.5.1","8.1.1"]}`,
+		"8.1.1": `{"name":"apache","tags":["9.0.0","9.1.0"]}`,
+	}
+	requests := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/apache/tags/list" || r.URL.Query().Get("n") != "2" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		last := r.URL.Query().Get("last")
+		requests = append(requests, fmt.Sprintf("last=%s if-none-match=%s", last, r.Header.Get("If-None-Match")))
+		if last == "" {
+			w.Header().Set("Link", `</v2/apache/tags/list?n=2&last=8.1.1>; rel="next"`)
+		} else {
+			w.Header().Set("ETag", `"page-2"`)
+			if r.Header.Get("If-None-Match") == `"page-2"` {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+		}
+		_, err := w.Write([]byte(pages[last]))
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+	}))
+	defer server.Close()
+	url, err := parseRepoURL(server.URL)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	cache := &fakeTagListCache{pages: map[string][]tagListPage{}}
+	apiCli := &OciAPIClient{
+		RegistryNamespaceUrl: url,
+		HttpClient:           server.Client(),
+		TagListPageSize:      2,
+		TagListCache:         cache,
+	}
+
+	expectedTagList := &TagList{Name: "apache", Tags: []string{"7.5.1", "8.1.1", "9.0.0", "9.1.0"}}
+	for i := 0; i < 2; i++ {
+		result, err := apiCli.TagList("apache", "my-user-agent")
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		if !cmp.Equal(result, expectedTagList) {
+			t.Errorf("Unexpected result %v", cmp.Diff(expectedTagList, result))
+		}
+	}
+
+	// The second listing only downloads the page whose ETag changed.
+	expectedRequests := []string{
+		"last= if-none-match=",
+		"last=8.1.1 if-none-match=",
+		"last= if-none-match=",
+		`last=8.1.1 if-none-match="page-2"`,
+	}
+	if got, want := requests, expectedRequests; !cmp.Equal(want, got) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+	}
+	if got, want := len(cache.pages["apache"]), 2; got != want {
+		t.Errorf("got: %d cached pages, want: %d", got, want)
+	}
+}
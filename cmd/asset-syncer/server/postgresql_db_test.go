@@ -14,6 +14,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/go-cmp/cmp"
 	_ "github.com/lib/pq"
 	"github.com/vmware-tanzu/kubeapps/pkg/chart/models"
 	"github.com/vmware-tanzu/kubeapps/pkg/dbutils"
@@ -435,6 +436,101 @@ func TestRepoAlreadyProcessed(t *testing.T) {
 	}
 }
 
+func TestSyncedCharts(t *testing.T) {
+	pgtest.SkipIfNoDB(t)
+
+	repo := models.AppRepository{Namespace: "my-namespace", Name: "my-repo"}
+	chart := models.Chart{
+		Name:          "my-chart",
+		Repo:          &repo,
+		ID:            "my-repo/my-chart",
+		Icon:          "https://example.com/icon.png",
+		ChartVersions: []models.ChartVersion{{Version: "1.0.0", Digest: "abc"}},
+	}
+	pam, cleanup := getInitializedManager(t)
+	defer cleanup()
+
+	// empty when no chart has been synced
+	charts, err := pam.SyncedCharts(repo)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if got, want := len(charts), 0; got != want {
+		t.Errorf("got: %d, want: %d", got, want)
+	}
+
+	// not returned until the chart is checkpointed as imported
+	err = pam.Sync(repo, chart)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	charts, err = pam.SyncedCharts(repo)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if got, want := len(charts), 0; got != want {
+		t.Errorf("got: %d, want: %d", got, want)
+	}
+
+	// the digests of the chart entry once imported, without icon until imported
+	digest, err := chartDigest(chart)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	err = pam.markChartImported(repo, chart.ID, digest)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	charts, err = pam.SyncedCharts(repo)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	want := map[string]syncedChart{
+		chart.ID: {Digest: digest, VersionDigests: map[string]string{"1.0.0": "abc"}},
+	}
+	if got := charts; !cmp.Equal(got, want) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+	}
+
+	// the imported icon is kept when syncing a new version with the same icon
+	err = pam.updateIcon(repo, []byte("icon"), "image/png", chart.ID)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	chart.ChartVersions = append([]models.ChartVersion{{Version: "1.1.0", Digest: "def"}}, chart.ChartVersions...)
+	err = pam.Sync(repo, chart)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	digest, err = chartDigest(chart)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	err = pam.markChartImported(repo, chart.ID, digest)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	charts, err = pam.SyncedCharts(repo)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	want = map[string]syncedChart{
+		chart.ID: {Digest: digest, Icon: chart.Icon, VersionDigests: map[string]string{"1.1.0": "def", "1.0.0": "abc"}},
+	}
+	if got := charts; !cmp.Equal(got, want) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+	}
+
+	// empty for another repo
+	charts, err = pam.SyncedCharts(models.AppRepository{Namespace: "other-namespace", Name: repo.Name})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if got, want := len(charts), 0; got != want {
+		t.Errorf("got: %d, want: %d", got, want)
+	}
+}
+
 func TestFilesExist(t *testing.T) {
 	pgtest.SkipIfNoDB(t)
 
@@ -50,14 +50,66 @@ func (m *postgresAssetManager) Sync(repo models.AppRepository, chart models.Char
 	if err != nil {
 		return err
 	}
-	_, err = m.DB.Exec(fmt.Sprintf(`INSERT INTO %s (repo_namespace, repo_name, chart_id, info)
+	// The previously imported icon is kept as long as the icon URL does not
+	// change, so that it does not need to be imported again. The digest is
+	// reset until the icon and the files of the chart are imported, so that
+	// the chart is synced again if they are not.
+	_, err = m.DB.Exec(fmt.Sprintf(`INSERT INTO %[1]s (repo_namespace, repo_name, chart_id, info)
 		VALUES ($1, $2, $3, $4)
 		ON CONFLICT (chart_id, repo_namespace, repo_name)
-		DO UPDATE SET info = $4
+		DO UPDATE SET info = CASE WHEN %[1]s.info->>'icon' = $4::jsonb->>'icon'
+			THEN $4::jsonb || jsonb_build_object('raw_icon', %[1]s.info->'raw_icon', 'icon_content_type', %[1]s.info->'icon_content_type')
+			ELSE $4::jsonb END,
+		digest = NULL
 		`, dbutils.ChartTable), repo.Namespace, repo.Name, chart.ID, string(d))
 	return err
 }
 
+// markChartImported records the digest of the synced chart once its icon and
+// files have been imported, which checkpoints the progress of the sync.
+func (m *postgresAssetManager) markChartImported(repo models.AppRepository, chartID, digest string) error {
+	_, err := m.DB.Exec(fmt.Sprintf(`UPDATE %s SET digest = $1
+		WHERE chart_id = $2 AND repo_namespace = $3 AND repo_name = $4`, dbutils.ChartTable), digest, chartID, repo.Namespace, repo.Name)
+	return err
+}
+
+// SyncedCharts returns the state of the previously synced charts in the repo,
+// indexed by chart ID, so that only the changes in the index are synced.
+func (m *postgresAssetManager) SyncedCharts(repo models.AppRepository) (map[string]syncedChart, error) {
+	rows, err := m.DB.Query(fmt.Sprintf(`SELECT chart_id, digest,
+		CASE WHEN info->>'raw_icon' <> '' THEN info->>'icon' ELSE '' END,
+		info->'chartVersions'
+		FROM %s WHERE repo_namespace = $1 AND repo_name = $2 AND digest IS NOT NULL`, dbutils.ChartTable), repo.Namespace, repo.Name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	charts := map[string]syncedChart{}
+	for rows.Next() {
+		var chartID string
+		var chart syncedChart
+		var icon sql.NullString
+		var versions []byte
+		if err := rows.Scan(&chartID, &chart.Digest, &icon, &versions); err != nil {
+			return nil, err
+		}
+		chart.Icon = icon.String
+		var chartVersions []models.ChartVersion
+		if len(versions) > 0 {
+			if err := json.Unmarshal(versions, &chartVersions); err != nil {
+				return nil, err
+			}
+		}
+		chart.VersionDigests = make(map[string]string, len(chartVersions))
+		for _, cv := range chartVersions {
+			chart.VersionDigests[cv.Version] = cv.Digest
+		}
+		charts[chartID] = chart
+	}
+	return charts, rows.Err()
+}
+
 func (m *postgresAssetManager) LastChecksum(repo models.AppRepository) string {
 	var lastChecksum string
 	row := m.DB.QueryRow(fmt.Sprintf("SELECT checksum FROM %s WHERE name = $1 AND namespace = $2", dbutils.RepositoryTable), repo.Name, repo.Namespace)
@@ -178,3 +230,40 @@ func (m *postgresAssetManager) ChartsForRepo(repo models.AppRepository) (map[str
 	}
 	return chartsByName, nil
 }
+
+// tagListPages returns the cached pages of the tag list of an app of an OCI
+// repository, or nil if the tag list has not been cached yet.
+func (m *postgresAssetManager) tagListPages(repo models.AppRepository, appName string) ([]tagListPage, error) {
+	var pagesJSON []byte
+	err := m.DB.QueryRow(fmt.Sprintf("SELECT pages FROM %s WHERE repo_namespace = $1 AND repo_name = $2 AND app_name = $3", dbutils.OCITagListTable), repo.Namespace, repo.Name, appName).Scan(&pagesJSON)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var pages []tagListPage
+	if err := json.Unmarshal(pagesJSON, &pages); err != nil {
+		return nil, err
+	}
+	return pages, nil
+}
+
+// updateTagListPages caches the pages of the tag list of an app of an OCI
+// repository.
+func (m *postgresAssetManager) updateTagListPages(repo models.AppRepository, appName string, pages []tagListPage) error {
+	_, err := m.EnsureRepoExists(repo.Namespace, repo.Name)
+	if err != nil {
+		return err
+	}
+	pagesJSON, err := json.Marshal(pages)
+	if err != nil {
+		return err
+	}
+	_, err = m.DB.Exec(fmt.Sprintf(`INSERT INTO %s (repo_namespace, repo_name, app_name, pages)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (repo_namespace, repo_name, app_name)
+		DO UPDATE SET pages = $4
+		`, dbutils.OCITagListTable), repo.Namespace, repo.Name, appName, string(pagesJSON))
+	return err
+}
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/go-cmp/cmp"
 	"github.com/vmware-tanzu/kubeapps/pkg/chart/models"
 	"github.com/vmware-tanzu/kubeapps/pkg/dbutils"
 )
@@ -75,6 +76,54 @@ func Test_PGUpdateLastCheck(t *testing.T) {
 	}
 }
 
+func Test_PGSyncedCharts(t *testing.T) {
+	pgManager, mock, cleanup := getMockManager(t)
+	defer cleanup()
+
+	repo := models.AppRepository{Namespace: "repo-namespace", Name: "repo-name"}
+	mock.ExpectQuery(`^SELECT chart_id, digest,.* FROM charts WHERE repo_namespace = \$1 AND repo_name = \$2 AND digest IS NOT NULL`).
+		WithArgs(repo.Namespace, repo.Name).
+		WillReturnRows(sqlmock.NewRows([]string{"chart_id", "digest", "icon", "chartVersions"}).
+			AddRow("repo-name/foo", "123", "https://example.com/foo.png", `[{"version": "1.1.0", "digest": "abc"}, {"version": "1.0.0", "digest": "def"}]`).
+			AddRow("repo-name/bar", "456", nil, nil))
+
+	got, err := pgManager.SyncedCharts(repo)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	want := map[string]syncedChart{
+		"repo-name/foo": {
+			Digest:         "123",
+			Icon:           "https://example.com/foo.png",
+			VersionDigests: map[string]string{"1.1.0": "abc", "1.0.0": "def"},
+		},
+		"repo-name/bar": {
+			Digest:         "456",
+			VersionDigests: map[string]string{},
+		},
+	}
+	if !cmp.Equal(got, want) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+	}
+}
+
+func Test_PGmarkChartImported(t *testing.T) {
+	pgManager, mock, cleanup := getMockManager(t)
+	defer cleanup()
+
+	repo := models.AppRepository{Namespace: "repo-namespace", Name: "repo-name"}
+	mock.ExpectExec(`^UPDATE charts SET digest = \$1\s+WHERE chart_id = \$2 AND repo_namespace = \$3 AND repo_name = \$4$`).
+		WithArgs("123", "repo-name/foo", repo.Namespace, repo.Name).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := pgManager.markChartImported(repo, "repo-name/foo", "123"); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%+v", err)
+	}
+}
+
 func Test_PGremoveMissingCharts(t *testing.T) {
 	pgManager, mock, cleanup := getMockManager(t)
 	defer cleanup()
@@ -184,3 +233,44 @@ func Test_PGinsertFiles(t *testing.T) {
 		t.Errorf("%+v", err)
 	}
 }
+
+func Test_PGtagListPages(t *testing.T) {
+	pgManager, mock, cleanup := getMockManager(t)
+	defer cleanup()
+	repo := models.AppRepository{Namespace: "my-namespace", Name: "my-repo"}
+	pages := []tagListPage{{URL: "https://registry/v2/apache/tags/list", ETag: `"123"`, Tags: []string{"7.5.1"}}}
+	pagesJSON := `[{"url":"https://registry/v2/apache/tags/list","etag":"\"123\"","tags":["7.5.1"]}]`
+
+	mock.ExpectQuery(`SELECT pages FROM oci_tag_lists *`).
+		WithArgs(repo.Namespace, repo.Name, "apache").
+		WillReturnRows(sqlmock.NewRows([]string{"pages"}).AddRow(pagesJSON))
+	mock.ExpectQuery(`SELECT pages FROM oci_tag_lists *`).
+		WithArgs(repo.Namespace, repo.Name, "nginx").
+		WillReturnRows(sqlmock.NewRows([]string{"pages"}))
+	mock.ExpectQuery("WITH new_repo AS *").
+		WillReturnRows(sqlmock.NewRows([]string{"ID"}).AddRow(1))
+	mock.ExpectExec(`INSERT INTO oci_tag_lists \(repo_namespace, repo_name, app_name, pages\)*`).
+		WithArgs(repo.Namespace, repo.Name, "apache", pagesJSON).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	got, err := pgManager.tagListPages(repo, "apache")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if want := pages; !cmp.Equal(want, got) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+	}
+	got, err = pgManager.tagListPages(repo, "nginx")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if got != nil {
+		t.Errorf("got: %+v, want: nil for an app without cached tag list", got)
+	}
+	if err := pgManager.updateTagListPages(repo, "apache", pages); err != nil {
+		t.Errorf("%+v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%+v", err)
+	}
+}
@@ -15,6 +15,7 @@ import (
 	httpclient "github.com/vmware-tanzu/kubeapps/pkg/http-client"
 	"github.com/vmware-tanzu/kubeapps/pkg/kube"
 	"github.com/vmware-tanzu/kubeapps/pkg/ocicatalog_client"
+	"github.com/vmware-tanzu/kubeapps/pkg/ocicredentials"
 	log "k8s.io/klog/v2"
 )
 
@@ -65,6 +66,10 @@ func Sync(serveOpts Config, version string, args []string) error {
 	if args[2] == "helm" {
 		repoIface, err = getHelmRepo(serveOpts.Namespace, args[0], args[1], authorizationHeader, filters, netClient, serveOpts.UserAgent, manager)
 	} else {
+		tagFilter, err := newOCITagFilter(serveOpts.OCITagRegex, serveOpts.OCITagConstraint, serveOpts.OCIMaxTags)
+		if err != nil {
+			return fmt.Errorf("error: %v", err)
+		}
 		var grpcClient ocicatalog.OCICatalogServiceClient
 		if serveOpts.OCICatalogURL != "" {
 			var closer func()
@@ -74,7 +79,16 @@ func Sync(serveOpts Config, version string, args []string) error {
 			}
 			defer closer()
 		}
-		repoIface, err = getOCIRepo(serveOpts.Namespace, args[0], args[1], authorizationHeader, filters, serveOpts.OciRepositories, netClient, &grpcClient, manager)
+		// The credentials of cloud registries are exchanged for the identity of
+		// the workload, overriding any static credentials.
+		if serveOpts.OCICredentialProvider != "" {
+			tokenCache := ocicredentials.NewTokenCache(ocicredentials.DefaultHelpers())
+			authorizationHeader, err = tokenCache.AuthorizationHeader(ctx, serveOpts.OCICredentialProvider, args[1])
+			if err != nil {
+				return fmt.Errorf("error: %v", err)
+			}
+		}
+		repoIface, err = getOCIRepo(serveOpts.Namespace, args[0], args[1], authorizationHeader, filters, tagFilter, serveOpts.OciRepositories, netClient, &grpcClient, manager)
 	}
 	if err != nil {
 		return fmt.Errorf("error: %v", err)
@@ -103,9 +117,9 @@ func Sync(serveOpts Config, version string, args []string) error {
 	for _, fetchLatestOnly := range fetchLatestOnlySlice {
 		// Create the file importer to handle the icon and chart file imports.
 		fImporter := fileImporter{manager, netClient}
-		fileImporterJobs := make(chan models.Chart)
+		fileImporterJobs := make(chan importChartJob)
 		fileImportsDone := make(chan bool)
-		go fImporter.fetchFiles(fileImporterJobs, repoIface, serveOpts.UserAgent, serveOpts.PassCredentials, fileImportsDone)
+		go fImporter.fetchFiles(fileImporterJobs, repoIface, serveOpts.UserAgent, serveOpts.PassCredentials, newImportOptions(serveOpts), fileImportsDone)
 
 		// We want to receive results per app so that we can sync that app
 		// immediately and have a more responsive UX experience. A channel
@@ -117,6 +131,17 @@ func Sync(serveOpts Config, version string, args []string) error {
 			return fmt.Errorf("error: %v", err)
 		}
 
+		// Only the charts which changed since the previous sync are upserted,
+		// which is usually a handful of them even for huge repositories. For
+		// those, only the files of the new or changed versions are imported,
+		// as well as the icon if it changed. Their digest is recorded once
+		// their files are imported.
+		syncedCharts, err := manager.SyncedCharts(models.AppRepository{Name: repo.Name, Namespace: repo.Namespace})
+		if err != nil {
+			return fmt.Errorf("error while retrieving the synced charts: %w", err)
+		}
+		unchangedCharts := 0
+
 		// Also need to collect the apps to be deleted, rather than simply
 		// deleting everything that's not in the set of charts being synced
 		// (as it does today).
@@ -138,12 +163,29 @@ func Sync(serveOpts Config, version string, args []string) error {
 				continue
 			}
 
+			digest, err := chartDigest(chart.Chart)
+			if err != nil {
+				return fmt.Errorf("error while computing the digest of chart %q: %w", chart.Chart.Name, err)
+			}
+			var synced *syncedChart
+			if s, ok := syncedCharts[chart.Chart.ID]; ok {
+				if s.Digest == digest {
+					unchangedCharts++
+					log.V(4).Infof("Chart %q unchanged, skipping it", chart.Chart.Name)
+					continue
+				}
+				synced = &s
+			}
+
 			if err = manager.Sync(models.AppRepository{Name: repo.Name, Namespace: repo.Namespace}, chart.Chart); err != nil {
 				return fmt.Errorf("can't add chart repository to database: %v", err)
 			}
 
-			// Fetch and store chart icons
-			fileImporterJobs <- chart.Chart
+			// Fetch and store the chart icon and files which changed, the
+			// digest being recorded once they are imported
+			job := newImportChartJob(chart.Chart, synced)
+			job.Digest = digest
+			fileImporterJobs <- job
 
 			log.V(4).Infof("Chart %q synced, shallow=%v", chart.Chart.Name, fetchLatestOnly)
 		}
@@ -162,7 +204,7 @@ func Sync(serveOpts Config, version string, args []string) error {
 		log.V(4).Infof("Chart data syncing complete. Waiting for file imports to complete.")
 		<-fileImportsDone
 
-		log.V(4).Infof("Repository synced, shallow=%v", fetchLatestOnly)
+		log.V(4).Infof("Repository synced, shallow=%v, unchanged charts=%d", fetchLatestOnly, unchangedCharts)
 	}
 
 	// Update cache in the database
@@ -47,10 +47,16 @@ import (
 
 const (
 	additionalCAFile = "/usr/local/share/ca-certificates/ca.crt"
-	// numWorkersFiles is the number of workers used when pulling non-OCI charts
-	// to extract files (Readme, values, etc.), as well as chart icons
-	// generally.
+	// numWorkersFiles is the default number of workers used when pulling
+	// non-OCI charts to extract files (Readme, values, etc.), as well as chart
+	// icons generally.
 	numWorkersFiles = 10
+	// defaultImportMaxAttempts is the default number of attempts to import the
+	// icon or the files of a chart version before giving up.
+	defaultImportMaxAttempts = 3
+	// defaultImportInitialBackoff is the default delay before retrying a failed
+	// import, doubled after each attempt.
+	defaultImportInitialBackoff = time.Second
 
 	// numWorkersOCI is the number of workers used when pulling charts from OCI
 	// registries. This may need to be adjusted depending on public
@@ -79,6 +85,67 @@ type Config struct {
 	AuthorizationHeader      string
 	DockerConfigJson         string
 	OCICatalogURL            string
+	OCITagRegex              string
+	OCITagConstraint         string
+	OCIMaxTags               int
+	OCICredentialProvider    string
+	ImportWorkers            int
+	ImportMaxAttempts        int
+	ImportInitialBackoff     time.Duration
+}
+
+// importOptions are the options of the file importer, which fetches the icons
+// and the files of the synced charts.
+type importOptions struct {
+	// Workers is the number of icons and chart versions imported at the same time
+	Workers int
+	// MaxAttempts is the number of attempts to import an icon or the files of a
+	// chart version
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry, doubled for each
+	// following one
+	InitialBackoff time.Duration
+}
+
+// newImportOptions returns the import options of the config, using the
+// defaults for those which are not set.
+func newImportOptions(config Config) importOptions {
+	opts := importOptions{
+		Workers:        config.ImportWorkers,
+		MaxAttempts:    config.ImportMaxAttempts,
+		InitialBackoff: config.ImportInitialBackoff,
+	}
+	if opts.Workers <= 0 {
+		opts.Workers = numWorkersFiles
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = defaultImportMaxAttempts
+	}
+	if opts.InitialBackoff <= 0 {
+		opts.InitialBackoff = defaultImportInitialBackoff
+	}
+	return opts
+}
+
+// importChartJob is a synced chart with the icon and files to be imported
+type importChartJob struct {
+	Chart models.Chart
+	// ImportIcon is false when the icon has been imported by a previous sync
+	ImportIcon bool
+	// ChartVersions are the new or changed versions, whose files have to be imported
+	ChartVersions []models.ChartVersion
+	// Digest is the digest of the chart entry, recorded once the import is done
+	Digest string
+}
+
+// syncedChart is the state of a chart as stored by a previous sync
+type syncedChart struct {
+	// Digest is the digest of the whole chart entry
+	Digest string
+	// Icon is the URL of the imported icon, empty if it was not imported
+	Icon string
+	// VersionDigests are the digests of the synced versions, indexed by version
+	VersionDigests map[string]string
 }
 
 type importChartFilesJob struct {
@@ -109,6 +176,8 @@ type assetManager interface {
 	LastChecksum(repo models.AppRepository) string
 	UpdateLastCheck(repoNamespace, repoName, checksum string, now time.Time) error
 	ChartsForRepo(repo models.AppRepository) (map[string]*models.Chart, error)
+	SyncedCharts(repo models.AppRepository) (map[string]syncedChart, error)
+	markChartImported(repo models.AppRepository, chartID, digest string) error
 	Init() error
 	Close() error
 	InvalidateCache() error
@@ -116,6 +185,8 @@ type assetManager interface {
 	updateIcon(repo models.AppRepository, data []byte, contentType, ID string) error
 	filesExist(repo models.AppRepository, chartFilesID, digest string) bool
 	insertFiles(chartID string, files models.ChartFiles) error
+	tagListPages(repo models.AppRepository, appName string) ([]tagListPage, error)
+	updateTagListPages(repo models.AppRepository, appName string, pages []tagListPage) error
 }
 
 func newManager(config dbutils.Config, globalPackagingNamespace string) (assetManager, error) {
@@ -131,6 +202,32 @@ func getSha256(src []byte) (string, error) {
 	return fmt.Sprintf("%x", h.Sum(nil)), nil
 }
 
+// chartDigest returns the digest of the chart entry, as stored when syncing it,
+// which changes whenever the chart or any of its versions changes in the index.
+func chartDigest(chart models.Chart) (string, error) {
+	d, err := json.Marshal(chart)
+	if err != nil {
+		return "", err
+	}
+	return getSha256(d)
+}
+
+// newImportChartJob compares a chart from the index with its previously synced
+// state, if any, so that only the icon and the files of the versions which
+// changed since then get imported.
+func newImportChartJob(chart models.Chart, synced *syncedChart) importChartJob {
+	if synced == nil {
+		return importChartJob{Chart: chart, ImportIcon: true, ChartVersions: chart.ChartVersions}
+	}
+	job := importChartJob{Chart: chart, ImportIcon: chart.Icon != synced.Icon}
+	for _, cv := range chart.ChartVersions {
+		if digest, ok := synced.VersionDigests[cv.Version]; !ok || digest != cv.Digest {
+			job.ChartVersions = append(job.ChartVersions, cv)
+		}
+	}
+	return job
+}
+
 // ChartCatalog defines the methods to retrieve information from the given repository
 type ChartCatalog interface {
 	Checksum(ctx context.Context) (string, error)
@@ -324,10 +421,11 @@ type TagList struct {
 type OCIRegistry struct {
 	repositories []string
 	*models.AppRepositoryInternal
-	puller  helm.ChartPuller
-	ociCli  ociAPI
-	filter  *apprepov1alpha1.FilterRuleSpec
-	manager assetManager
+	puller    helm.ChartPuller
+	ociCli    ociAPI
+	filter    *apprepov1alpha1.FilterRuleSpec
+	tagFilter ociTagFilter
+	manager   assetManager
 }
 
 func doReq(url string, cli *http.Client, headers map[string]string, userAgent string) ([]byte, error) {
@@ -374,6 +472,11 @@ type OciAPIClient struct {
 	// aims to work around some of the shortfalls of the OCI Distribution spec
 	// API
 	GrpcClient ocicatalog.OCICatalogServiceClient
+	// TagListPageSize is the number of tags requested for each page of a tag
+	// list. The page size is chosen by the registry if 0.
+	TagListPageSize int
+	// TagListCache stores the tag lists between syncs, if not nil.
+	TagListCache tagListCache
 }
 
 func (o *OciAPIClient) getOrasRepoClient(appName string, userAgent string) (*remote.Repository, error) {
@@ -401,29 +504,6 @@ func (o *OciAPIClient) getOrasRepoClient(appName string, userAgent string) (*rem
 	return orasRepoClient, nil
 }
 
-// TagList retrieves the list of tags for an asset
-func (o *OciAPIClient) TagList(appName string, userAgent string) (*TagList, error) {
-	orasRepoClient, err := o.getOrasRepoClient(appName, userAgent)
-	if err != nil {
-		return nil, err
-	}
-
-	tags := []string{}
-
-	err = orasRepoClient.Tags(context.TODO(), "", func(ts []string) error {
-		tags = append(tags, ts...)
-		return nil
-	})
-	if err != nil {
-		return nil, err
-	}
-
-	return &TagList{
-		Name: orasRepoClient.Reference.Repository,
-		Tags: tags,
-	}, nil
-}
-
 func (o *OciAPIClient) IsHelmChart(appName, tag, userAgent string) (bool, error) {
 	orasRepoClient, err := o.getOrasRepoClient(appName, userAgent)
 	if err != nil {
@@ -764,21 +844,17 @@ func (r *OCIRegistry) Charts(ctx context.Context, fetchLatestOnly bool, chartRes
 
 	log.V(4).Infof("Starting %d workers for importing OCI charts", numWorkersOCI)
 	go func() {
-		for _, appName := range r.repositories {
-			// Get the list of tags for the app
-			tagList, err := r.ociCli.TagList(appName, GetUserAgent("", ""))
-			if err != nil {
-				log.V(3).ErrorS(err, "unable to list tags")
-				log.Errorf("unable to list tags: %+v", err)
-				close(chartJobs)
-				return
-			}
-			tags, err := orderVersions(tagList.Tags)
-			if err != nil {
-				log.V(3).ErrorS(err, "Error parsing version")
-				close(chartJobs)
+		defer close(chartJobs)
+		// The tags of the apps are listed in parallel, stopping at the first
+		// error.
+		listCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		for result := range r.listTags(listCtx, r.repositories) {
+			if result.err != nil {
+				log.Errorf("unable to list tags: %+v", result.err)
 				return
 			}
+			appName, tags := result.appName, result.tags
 			// Find the tags present in DB, in order verify the difference.
 			syncedChart := syncedChartsForRepo[appName]
 			syncedVersions := []string{}
@@ -825,7 +901,6 @@ func (r *OCIRegistry) Charts(ctx context.Context, fetchLatestOnly bool, chartRes
 				}
 			}
 		}
-		close(chartJobs)
 	}()
 
 	go func() {
@@ -893,7 +968,7 @@ func getHelmRepo(namespace, name, repoURL, authorizationHeader string, filter *a
 	}, nil
 }
 
-func getOCIRepo(namespace, name, repoURL, authorizationHeader string, filter *apprepov1alpha1.FilterRuleSpec, ociRepos []string, netClient *http.Client, grpcClient *ocicatalog.OCICatalogServiceClient, manager assetManager) (ChartCatalog, error) {
+func getOCIRepo(namespace, name, repoURL, authorizationHeader string, filter *apprepov1alpha1.FilterRuleSpec, tagFilter ociTagFilter, ociRepos []string, netClient *http.Client, grpcClient *ocicatalog.OCICatalogServiceClient, manager assetManager) (ChartCatalog, error) {
 	url, err := parseRepoURL(repoURL)
 	if err != nil {
 		log.Errorf("Failed to parse URL, url=%s: %v", repoURL, err)
@@ -916,9 +991,16 @@ func getOCIRepo(namespace, name, repoURL, authorizationHeader string, filter *ap
 		repositories:          ociRepos,
 		AppRepositoryInternal: &models.AppRepositoryInternal{Namespace: namespace, Name: name, URL: url.String(), AuthorizationHeader: authorizationHeader},
 		puller:                &helm.OCIPuller{Resolver: ociResolver},
-		ociCli:                &OciAPIClient{RegistryNamespaceUrl: url, HttpClient: netClient, GrpcClient: *grpcClient},
-		filter:                filter,
-		manager:               manager,
+		ociCli: &OciAPIClient{
+			RegistryNamespaceUrl: url,
+			HttpClient:           netClient,
+			GrpcClient:           *grpcClient,
+			TagListPageSize:      ociTagListPageSize,
+			TagListCache:         &repoTagListCache{manager: manager, repo: models.AppRepository{Namespace: namespace, Name: name}},
+		},
+		filter:    filter,
+		tagFilter: tagFilter,
+		manager:   manager,
 	}, nil
 }
 
@@ -954,23 +1036,99 @@ type fileImporter struct {
 	netClient *http.Client
 }
 
-func (f *fileImporter) fetchFiles(inputCharts chan models.Chart, repo ChartCatalog, userAgent string, passCredentials bool, done chan bool) {
-	iconJobs := make(chan models.Chart, numWorkersFiles)
-	chartFilesJobs := make(chan importChartFilesJob, numWorkersFiles)
+// importTracker counts the pending icon and file imports of each chart, so that
+// a chart is checkpointed as imported only once all of them succeeded.
+type importTracker struct {
+	mu      sync.Mutex
+	pending map[string]int
+	digests map[string]string
+	failed  map[string]bool
+}
+
+func newImportTracker() *importTracker {
+	return &importTracker{pending: map[string]int{}, digests: map[string]string{}, failed: map[string]bool{}}
+}
+
+// add records the imports of the job, to be done before enqueuing them.
+func (t *importTracker) add(chartID, digest string, imports int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.pending[chartID] += imports
+	t.digests[chartID] = digest
+}
+
+// done records that an import of the chart has been processed and returns the
+// digest of the chart when it was the last one and none of them failed.
+func (t *importTracker) done(chartID string, failed bool) (string, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.pending[chartID]--
+	if failed {
+		t.failed[chartID] = true
+	}
+	if t.pending[chartID] > 0 {
+		return "", false
+	}
+	digest, succeeded := t.digests[chartID], !t.failed[chartID]
+	delete(t.pending, chartID)
+	delete(t.digests, chartID)
+	delete(t.failed, chartID)
+	return digest, succeeded
+}
+
+// withBackoff runs the operation until it succeeds, up to maxAttempts times,
+// waiting between attempts a delay which starts at initialBackoff and doubles
+// each time.
+func withBackoff(maxAttempts int, initialBackoff time.Duration, operation func() error) error {
+	backoff := initialBackoff
+	for attempt := 1; ; attempt++ {
+		err := operation()
+		if err == nil || attempt >= maxAttempts {
+			return err
+		}
+		log.V(4).Infof("Attempt %d of %d failed, retrying in %v: %v", attempt, maxAttempts, backoff, err)
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+// fetchFiles imports the icons and the files of the charts received from
+// inputCharts with a pool of workers. Since the job queues are bounded by the
+// number of workers, the sync of the charts waits for the imports rather than
+// piling them up. Each chart is checkpointed as imported once all its imports
+// are processed, so that a sync interrupted halfway does not import again the
+// charts already done.
+func (f *fileImporter) fetchFiles(inputCharts chan importChartJob, repo ChartCatalog, userAgent string, passCredentials bool, opts importOptions, done chan bool) {
+	iconJobs := make(chan models.Chart, opts.Workers)
+	chartFilesJobs := make(chan importChartFilesJob, opts.Workers)
+	tracker := newImportTracker()
 	var wg sync.WaitGroup
 
-	log.V(4).Infof("Starting %d file importer workers", numWorkersFiles)
-	for i := 0; i < numWorkersFiles; i++ {
+	log.V(4).Infof("Starting %d file importer workers", opts.Workers)
+	for i := 0; i < opts.Workers; i++ {
 		wg.Add(1)
-		go f.importWorker(&wg, iconJobs, chartFilesJobs, repo, userAgent, passCredentials)
+		go f.importWorker(&wg, iconJobs, chartFilesJobs, repo, userAgent, passCredentials, opts, tracker)
 	}
 
 	// Enqueue jobs to process chart icons and record the charts for further
 	// processing.
-	charts := []models.Chart{}
-	for c := range inputCharts {
-		iconJobs <- c
-		charts = append(charts, c)
+	jobs := []importChartJob{}
+	for j := range inputCharts {
+		imports := len(j.ChartVersions)
+		if j.ImportIcon {
+			imports++
+		}
+		if imports == 0 {
+			f.checkpoint(repo, j.Chart.ID, j.Digest)
+			continue
+		}
+		tracker.add(j.Chart.ID, j.Digest, imports)
+		if j.ImportIcon {
+			iconJobs <- j.Chart
+		} else {
+			log.V(4).Infof("Skipping unchanged icon, name=%s", j.Chart.Name)
+		}
+		jobs = append(jobs, j)
 	}
 	log.V(4).Infof("Finished queueing icon jobs")
 	// Close the iconJobs channel to signal the worker pools to move on to the
@@ -982,10 +1140,13 @@ func (f *fileImporter) fetchFiles(inputCharts chan models.Chart, repo ChartCatal
 	// enqueued later
 	var toEnqueue []importChartFilesJob
 	log.V(4).Infof("Enqueuing chart file imports for first versions")
-	for _, c := range charts {
-		chartFilesJobs <- importChartFilesJob{c.ID, c.Repo, c.ChartVersions[0]}
-		for _, cv := range c.ChartVersions[1:] {
-			toEnqueue = append(toEnqueue, importChartFilesJob{c.ID, c.Repo, cv})
+	for _, j := range jobs {
+		if len(j.ChartVersions) == 0 {
+			continue
+		}
+		chartFilesJobs <- importChartFilesJob{j.Chart.ID, j.Chart.Repo, j.ChartVersions[0]}
+		for _, cv := range j.ChartVersions[1:] {
+			toEnqueue = append(toEnqueue, importChartFilesJob{j.Chart.ID, j.Chart.Repo, cv})
 		}
 	}
 
@@ -1006,19 +1167,45 @@ func (f *fileImporter) fetchFiles(inputCharts chan models.Chart, repo ChartCatal
 	done <- true
 }
 
-func (f *fileImporter) importWorker(wg *sync.WaitGroup, icons <-chan models.Chart, chartFiles <-chan importChartFilesJob, repo ChartCatalog, userAgent string, passCredentials bool) {
+func (f *fileImporter) importWorker(wg *sync.WaitGroup, icons <-chan models.Chart, chartFiles <-chan importChartFilesJob, repo ChartCatalog, userAgent string, passCredentials bool, opts importOptions, tracker *importTracker) {
 	defer wg.Done()
 	for c := range icons {
 		log.V(4).Infof("Importing icon, name=%s", c.Name)
-		if err := f.fetchAndImportIcon(c, repo.AppRepository(), userAgent, passCredentials); err != nil {
+		err := withBackoff(opts.MaxAttempts, opts.InitialBackoff, func() error {
+			return f.fetchAndImportIcon(c, repo.AppRepository(), userAgent, passCredentials)
+		})
+		if err != nil {
 			log.Errorf("Failed to import icon, name=%s: %v", c.Name, err)
 		}
+		f.importDone(tracker, repo, c.ID, err != nil)
 	}
 	for j := range chartFiles {
 		log.V(4).Infof("Importing readme and values, ID=%s, version=%s", j.ID, j.ChartVersion.Version)
-		if err := f.fetchAndImportFiles(j.ID, repo, j.ChartVersion, userAgent, passCredentials); err != nil {
+		err := withBackoff(opts.MaxAttempts, opts.InitialBackoff, func() error {
+			return f.fetchAndImportFiles(j.ID, repo, j.ChartVersion, userAgent, passCredentials)
+		})
+		if err != nil {
 			log.Errorf("Failed to import files, ID=%s, version=%s: %v", j.ID, j.ChartVersion.Version, err)
 		}
+		f.importDone(tracker, repo, j.ID, err != nil)
+	}
+}
+
+// importDone checkpoints the chart once its last import has been processed,
+// unless one of them still failed after retrying, so that the chart is synced
+// again on the next run.
+func (f *fileImporter) importDone(tracker *importTracker, repo ChartCatalog, chartID string, failed bool) {
+	if digest, ok := tracker.done(chartID, failed); ok {
+		f.checkpoint(repo, chartID, digest)
+	}
+}
+
+// checkpoint records the chart as imported, so that it is skipped by the
+// following syncs until it changes.
+func (f *fileImporter) checkpoint(repo ChartCatalog, chartID, digest string) {
+	r := repo.AppRepository()
+	if err := f.manager.markChartImported(models.AppRepository{Namespace: r.Namespace, Name: r.Name}, chartID, digest); err != nil {
+		log.Errorf("Failed to checkpoint the chart, ID=%s: %v", chartID, err)
 	}
 }
 
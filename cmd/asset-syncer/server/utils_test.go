@@ -20,6 +20,7 @@ import (
 	"path"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/disintegration/imaging"
@@ -194,13 +195,13 @@ func Test_getOCIRepo(t *testing.T) {
 	assert.NoError(t, err)
 	defer f()
 	t.Run("it should add the auth header to the resolver", func(t *testing.T) {
-		repo, err := getOCIRepo("namespace", "test", "https://test", "Basic auth", nil, []string{}, &http.Client{}, &grpcClient, nil)
+		repo, err := getOCIRepo("namespace", "test", "https://test", "Basic auth", nil, ociTagFilter{}, []string{}, &http.Client{}, &grpcClient, nil)
 		assert.NoError(t, err)
 		helmtest.CheckHeader(t, repo.(*OCIRegistry).puller, "Authorization", "Basic auth")
 	})
 
 	t.Run("it should use https for distribution spec API calls if protocol is oci", func(t *testing.T) {
-		repo, err := getOCIRepo("namespace", "test", "oci://test", "Basic auth", nil, []string{}, &http.Client{}, &grpcClient, nil)
+		repo, err := getOCIRepo("namespace", "test", "oci://test", "Basic auth", nil, ociTagFilter{}, []string{}, &http.Client{}, &grpcClient, nil)
 		assert.NoError(t, err)
 
 		client := repo.(*OCIRegistry).ociCli
@@ -593,6 +594,113 @@ func (r *fakeRepo) FetchFiles(cv models.ChartVersion, userAgent string, passCred
 	}, nil
 }
 
+func Test_withBackoff(t *testing.T) {
+	testCases := []struct {
+		name             string
+		failures         int
+		expectedAttempts int
+		expectedErr      bool
+	}{
+		{
+			name:             "it does not retry a successful operation",
+			expectedAttempts: 1,
+		},
+		{
+			name:             "it retries a failing operation until it succeeds",
+			failures:         2,
+			expectedAttempts: 3,
+		},
+		{
+			name:             "it returns the error of the last attempt",
+			failures:         5,
+			expectedAttempts: 3,
+			expectedErr:      true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			attempts := 0
+			err := withBackoff(3, time.Millisecond, func() error {
+				attempts++
+				if attempts <= tc.failures {
+					return fmt.Errorf("attempt %d failed", attempts)
+				}
+				return nil
+			})
+			if got, want := err != nil, tc.expectedErr; got != want {
+				t.Errorf("got error: %v, want error: %t", err, want)
+			}
+			if got, want := attempts, tc.expectedAttempts; got != want {
+				t.Errorf("got: %d, want: %d", got, want)
+			}
+		})
+	}
+}
+
+func Test_importTracker(t *testing.T) {
+	tracker := newImportTracker()
+	tracker.add("repo-name/succeeded", "digest-succeeded", 2)
+	tracker.add("repo-name/failed", "digest-failed", 2)
+
+	if _, ok := tracker.done("repo-name/succeeded", false); ok {
+		t.Errorf("the chart is done before its last import")
+	}
+	if digest, ok := tracker.done("repo-name/succeeded", false); !ok || digest != "digest-succeeded" {
+		t.Errorf("got: %q, %t, want: %q, true", digest, ok, "digest-succeeded")
+	}
+
+	// a chart with a failed import is not checkpointed, to be synced again
+	if _, ok := tracker.done("repo-name/failed", true); ok {
+		t.Errorf("the chart is done before its last import")
+	}
+	if _, ok := tracker.done("repo-name/failed", false); ok {
+		t.Errorf("the chart is done despite a failed import")
+	}
+}
+
+func Test_fetchFilesCheckpoints(t *testing.T) {
+	pgManager, mock, cleanup := getMockManager(t)
+	defer cleanup()
+	mock.MatchExpectationsInOrder(false)
+
+	internalRepo := &models.AppRepositoryInternal{Name: "repo-name", Namespace: "repo-namespace"}
+	repo := &models.AppRepository{Name: internalRepo.Name, Namespace: internalRepo.Namespace}
+	fRepo := &fakeRepo{AppRepositoryInternal: internalRepo}
+
+	// a chart without imports is checkpointed straight away
+	unchanged := importChartJob{Chart: models.Chart{ID: "repo-name/unchanged", Repo: repo}, Digest: "digest-unchanged"}
+	mock.ExpectExec("UPDATE charts SET digest *").
+		WithArgs("digest-unchanged", "repo-name/unchanged", repo.Namespace, repo.Name).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	// a chart is checkpointed once both its icon and its files are imported
+	changed := importChartJob{
+		Chart:         models.Chart{ID: "repo-name/changed", Name: "changed", Repo: repo},
+		ImportIcon:    true,
+		ChartVersions: []models.ChartVersion{{Version: "1.0.0", Digest: "abc"}},
+		Digest:        "digest-changed",
+	}
+	mock.ExpectQuery("SELECT EXISTS*").
+		WithArgs("repo-name/changed-1.0.0", repo.Name, repo.Namespace, "abc").
+		WillReturnRows(sqlmock.NewRows([]string{"info"}).AddRow(`true`))
+	mock.ExpectExec("UPDATE charts SET digest *").
+		WithArgs("digest-changed", "repo-name/changed", repo.Namespace, repo.Name).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	fImporter := fileImporter{pgManager, http.DefaultClient}
+	jobs := make(chan importChartJob)
+	done := make(chan bool)
+	go fImporter.fetchFiles(jobs, fRepo, "my-user-agent", false, importOptions{Workers: 2, MaxAttempts: 1, InitialBackoff: time.Millisecond}, done)
+	jobs <- unchanged
+	jobs <- changed
+	close(jobs)
+	<-done
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("%+v", err)
+	}
+}
+
 func Test_fetchAndImportFiles(t *testing.T) {
 	validAuthHeader := "Bearer ThisSecretAccessTokenAuthenticatesTheClient"
 
@@ -2064,3 +2172,60 @@ func TestOrderedChartVersions(t *testing.T) {
 		})
 	}
 }
+
+func TestNewImportChartJob(t *testing.T) {
+	chart := models.Chart{
+		ID:   "my-repo/my-chart",
+		Icon: "https://example.com/icon.png",
+		ChartVersions: []models.ChartVersion{
+			{Version: "1.2.0", Digest: "new"},
+			{Version: "1.1.0", Digest: "changed"},
+			{Version: "1.0.0", Digest: "unchanged"},
+		},
+	}
+
+	testCases := []struct {
+		name     string
+		synced   *syncedChart
+		expected importChartJob
+	}{
+		{
+			name: "imports the icon and every version of a new chart",
+			expected: importChartJob{
+				Chart:         chart,
+				ImportIcon:    true,
+				ChartVersions: chart.ChartVersions,
+			},
+		},
+		{
+			name: "imports only the new or changed versions",
+			synced: &syncedChart{
+				Icon:           chart.Icon,
+				VersionDigests: map[string]string{"1.1.0": "previous", "1.0.0": "unchanged"},
+			},
+			expected: importChartJob{
+				Chart:         chart,
+				ChartVersions: chart.ChartVersions[:2],
+			},
+		},
+		{
+			name: "imports the icon when it changed",
+			synced: &syncedChart{
+				Icon:           "https://example.com/previous-icon.png",
+				VersionDigests: map[string]string{"1.2.0": "new", "1.1.0": "changed", "1.0.0": "unchanged"},
+			},
+			expected: importChartJob{
+				Chart:      chart,
+				ImportIcon: true,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got, want := newImportChartJob(chart, tc.synced), tc.expected; !cmp.Equal(want, got) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
@@ -5,10 +5,12 @@ package cmd
 
 import (
 	"flag"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core/icons"
 	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/server"
 	log "k8s.io/klog/v2"
 )
@@ -67,12 +69,39 @@ func setFlags(c *cobra.Command) {
 	c.Flags().StringSliceVar(&serveOpts.PluginDirs, "plugin-dir", []string{"."}, "A directory to be scanned for .so plugins. May be specified multiple times.")
 	c.Flags().StringVar(&serveOpts.ClustersConfigPath, "clusters-config-path", "", "Configuration for clusters")
 	c.Flags().StringVar(&serveOpts.PluginConfigPath, "plugin-config-path", "", "Configuration for plugins")
+	c.Flags().DurationVar(&serveOpts.PluginConfigReloadInterval, "plugin-config-reload-interval", 30*time.Second, "The interval at which the plugin configuration file is checked for changes, reloading the configuration of the plugins supporting it without a restart. Disabled if 0.")
 	c.Flags().StringVar(&serveOpts.PinnipedProxyURL, "pinniped-proxy-url", "http://kubeapps-internal-pinniped-proxy.kubeapps:3333", "internal url to be used for requests to clusters configured for credential proxying via pinniped")
 	c.Flags().StringVar(&serveOpts.PinnipedProxyCACert, "pinniped-proxy-ca-cert", "", "Path to certificate authority to use with requests to pinniped-proxy service")
 	c.Flags().StringVar(&serveOpts.GlobalHelmReposNamespace, "global-repos-namespace", "kubeapps", "Namespace of global repositories for the helm plugin")
 	c.Flags().BoolVar(&serveOpts.UnsafeLocalDevKubeconfig, "unsafe-local-dev-kubeconfig", false, "if true, it will use the local kubeconfig at the KUBECONFIG env var instead of using the inCluster configuration.")
 	c.Flags().Float32Var(&serveOpts.QPS, "kube-api-qps", 10.0, "set Kubernetes API client QPS limit")
 	c.Flags().IntVar(&serveOpts.Burst, "kube-api-burst", 15, "set Kubernetes API client Burst limit")
+	c.Flags().IntVar(&serveOpts.MetricsPort, "metrics-port", 0, "The port on which to serve the Prometheus metrics at /metrics. Disabled if 0.")
+	c.Flags().StringVar(&serveOpts.FeaturesSigningKeyPath, "features-signing-key-path", "", "Path to a PEM-encoded Ed25519 private key used to sign the features descriptor. The descriptor is not signed if empty.")
+	c.Flags().BoolVar(&serveOpts.AuditLogEnabled, "audit-log", false, "if true, an audit event is written as JSON to the standard output for every mutating package or repository request.")
+	c.Flags().StringVar(&serveOpts.AuditWebhookURL, "audit-webhook-url", "", "URL to which an audit event is posted as JSON for every mutating package or repository request. Disabled if empty.")
+	c.Flags().IntVar(&serveOpts.CompressMinBytes, "compress-min-bytes", 1024, "The size, in bytes, below which the responses are not compressed, even if the client supports it (gzip or deflate).")
+	c.Flags().IntVar(&serveOpts.MaxMessageBytes, "max-message-bytes", 32*1024*1024, "The maximum size, in bytes, of a request or response message. Unlimited if 0.")
+	c.Flags().StringVar(&serveOpts.TracingOTLPEndpoint, "tracing-otlp-endpoint", "", "Host and port of the OTLP/HTTP collector to which the OpenTelemetry traces are exported, such as otel-collector:4318. Tracing is disabled if empty.")
+	c.Flags().BoolVar(&serveOpts.TracingOTLPInsecure, "tracing-otlp-insecure", false, "Export the traces to the OTLP collector without TLS.")
+	c.Flags().Float64Var(&serveOpts.TracingSampleRatio, "tracing-sample-ratio", 1.0, "Ratio of the requests traced when the caller has not made a sampling decision, between 0 and 1.")
+	c.Flags().StringVar(&serveOpts.RepoHealthWebhookURL, "repo-health-webhook-url", "", "URL to which a Slack-compatible JSON notification is posted when a kapp-controller PackageRepository or Flux HelmRepository starts failing. Disabled if empty.")
+	c.Flags().DurationVar(&serveOpts.RepoHealthCheckInterval, "repo-health-check-interval", 5*time.Minute, "The interval at which the reconciliation status of the package repositories is checked.")
+	c.Flags().DurationVar(&serveOpts.RepoHealthFailingWindow, "repo-health-failing-window", time.Hour, "The time after which a package repository still failing is notified again. Disabled if 0.")
+	c.Flags().BoolVar(&serveOpts.ImpersonationEnabled, "enable-impersonation", false, "if true, the Impersonate-User and Impersonate-Group headers of the requests are forwarded to the Kubernetes API servers, which check that the user is allowed to impersonate.")
+	c.Flags().Float64Var(&serveOpts.RateLimitQPS, "rate-limit-qps", 0, "The sustained rate of requests per second allowed to each user, identified by their token. Unlimited if 0.")
+	c.Flags().IntVar(&serveOpts.RateLimitBurst, "rate-limit-burst", 0, "The number of requests allowed at once to each user above the sustained rate. Defaults to the rate if 0.")
+	c.Flags().IntVar(&serveOpts.MaxConcurrentRequests, "max-concurrent-requests-per-user", 0, "The maximum number of requests of each user, identified by their token, handled at once. Unlimited if 0.")
+	c.Flags().DurationVar(&serveOpts.UpgradePoliciesInterval, "upgrade-policies-check-interval", 0, "The interval at which the upgrade policies are checked, upgrading the installed packages whose maintenance window is open. Requires the impersonation to be enabled. Disabled if 0.")
+	c.Flags().IntVar(&serveOpts.IconsMaxBytes, "icons-max-bytes", icons.DefaultMaxBytes, "The maximum size of the icons of the available packages, the bigger icons being dropped.")
+	c.Flags().StringVar(&serveOpts.IconsProxyPrefix, "icons-proxy-prefix", "", "The prefix of the URLs at which the browsers reach the kubeapps-apis service, such as /apis, to proxy and cache the external icons of the available packages through it. The external icons are not proxied if empty.")
+	c.Flags().StringSliceVar(&serveOpts.IconsAllowedDomains, "icons-allowed-domains", nil, "The domains, including their subdomains, of the external icons and readme images of the available packages which are kept, the others being dropped. May be specified multiple times. All the domains are allowed if empty.")
+	c.Flags().StringSliceVar(&serveOpts.IconsAllowedNetworks, "icons-allowed-networks", nil, "The networks, in CIDR notation, from which the external icons and readme images of the available packages can be proxied despite being blocked by default (loopback and link-local addresses). May be specified multiple times.")
+	c.Flags().DurationVar(&serveOpts.PluginTimeout, "plugin-timeout", 30*time.Second, "The time each plugin has to respond when aggregating the results of several plugins, such as when listing the available packages, the plugins not responding in time being reported as partial results. Unbounded if 0.")
+	c.Flags().DurationVar(&serveOpts.NotificationsInterval, "notifications-check-interval", 0, "The interval at which the status of the installs, upgrades and repository syncs started by the users watching their notifications is checked, to notify their outcome. Disabled if 0.")
+	c.Flags().DurationVar(&serveOpts.ClustersSyncInterval, "clusters-sync-interval", 0, "The interval at which the clusters registered at runtime are synced from the Secret storing them, so that the clusters registered through other replicas are picked up. The clusters cannot be registered at runtime if 0.")
+	c.Flags().DurationVar(&serveOpts.SearchIndexInterval, "search-index-interval", 0, "The interval at which the global available packages of each cluster are crawled, with the service account, and indexed in memory to serve the search suggestions. Disabled if 0.")
+	c.Flags().DurationVar(&serveOpts.JobsRetention, "jobs-retention", 0, "The time during which the installs and upgrades started by the users, recorded as jobs in a ConfigMap so that their progress is followed across restarts, are kept once finished. Disabled if 0.")
 }
 
 // initConfig reads in config file and ENV variables if set.
@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"runtime"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core"
@@ -31,20 +32,72 @@ func TestParseFlagsCorrect(t *testing.T) {
 				"--global-repos-namespace", "kubeapps-global",
 				"--unsafe-local-dev-kubeconfig", "true",
 				"--plugin-config-path", "foo05",
+				"--plugin-config-reload-interval", "1m",
 				"--kube-api-qps", "1.0",
 				"--kube-api-burst", "1",
+				"--metrics-port", "9090",
+				"--features-signing-key-path", "foo07",
+				"--audit-log", "true",
+				"--audit-webhook-url", "foo08",
+				"--compress-min-bytes", "512",
+				"--max-message-bytes", "1048576",
+				"--tracing-otlp-endpoint", "foo09:4318",
+				"--tracing-otlp-insecure", "true",
+				"--tracing-sample-ratio", "0.5",
+				"--repo-health-webhook-url", "foo10",
+				"--repo-health-check-interval", "2m",
+				"--repo-health-failing-window", "30m",
+				"--enable-impersonation", "true",
+				"--rate-limit-qps", "5",
+				"--rate-limit-burst", "10",
+				"--max-concurrent-requests-per-user", "4",
+				"--upgrade-policies-check-interval", "1m",
+				"--icons-max-bytes", "2048",
+				"--icons-proxy-prefix", "/apis",
+				"--icons-allowed-domains", "example.com,charts.internal",
+				"--icons-allowed-networks", "10.0.0.0/8",
+				"--plugin-timeout", "10s",
+				"--notifications-check-interval", "5s",
+				"--search-index-interval", "10m",
+				"--jobs-retention", "24h",
 			},
 			core.ServeOptions{
-				Port:                     901,
-				PluginDirs:               []string{"foo01"},
-				ClustersConfigPath:       "foo02",
-				PinnipedProxyURL:         "foo03",
-				PinnipedProxyCACert:      "foo06",
-				UnsafeLocalDevKubeconfig: true,
-				GlobalHelmReposNamespace: "kubeapps-global",
-				PluginConfigPath:         "foo05",
-				QPS:                      1.0,
-				Burst:                    1,
+				Port:                       901,
+				PluginDirs:                 []string{"foo01"},
+				ClustersConfigPath:         "foo02",
+				PinnipedProxyURL:           "foo03",
+				PinnipedProxyCACert:        "foo06",
+				UnsafeLocalDevKubeconfig:   true,
+				GlobalHelmReposNamespace:   "kubeapps-global",
+				PluginConfigPath:           "foo05",
+				PluginConfigReloadInterval: time.Minute,
+				QPS:                        1.0,
+				Burst:                      1,
+				MetricsPort:                9090,
+				FeaturesSigningKeyPath:     "foo07",
+				AuditLogEnabled:            true,
+				AuditWebhookURL:            "foo08",
+				CompressMinBytes:           512,
+				MaxMessageBytes:            1048576,
+				TracingOTLPEndpoint:        "foo09:4318",
+				TracingOTLPInsecure:        true,
+				TracingSampleRatio:         0.5,
+				RepoHealthWebhookURL:       "foo10",
+				RepoHealthCheckInterval:    2 * time.Minute,
+				RepoHealthFailingWindow:    30 * time.Minute,
+				ImpersonationEnabled:       true,
+				RateLimitQPS:               5,
+				RateLimitBurst:             10,
+				MaxConcurrentRequests:      4,
+				UpgradePoliciesInterval:    time.Minute,
+				IconsMaxBytes:              2048,
+				IconsProxyPrefix:           "/apis",
+				IconsAllowedDomains:        []string{"example.com", "charts.internal"},
+				IconsAllowedNetworks:       []string{"10.0.0.0/8"},
+				PluginTimeout:              10 * time.Second,
+				NotificationsInterval:      5 * time.Second,
+				SearchIndexInterval:        10 * time.Minute,
+				JobsRetention:              24 * time.Hour,
 			},
 			true,
 		},
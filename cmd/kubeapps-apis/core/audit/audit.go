@@ -0,0 +1,412 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package audit emits a structured event for every mutating package or
+// repository RPC handled by the kubeapps-apis service, recording who changed
+// what and where, for compliance purposes.
+package audit
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core/authn"
+	packagesv1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1"
+	packagesv1connect "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1/v1connect"
+	packages "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	packagesv1alpha1connect "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1/v1alpha1connect"
+	fluxv2connect "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/plugins/fluxv2/packages/v1alpha1/v1alpha1connect"
+	helmconnect "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/plugins/helm/packages/v1alpha1/v1alpha1connect"
+	kappconnect "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/plugins/kapp_controller/packages/v1alpha1/v1alpha1connect"
+	ocicatalogconnect "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/plugins/oci_catalog/packages/v1alpha1/v1alpha1connect"
+	operatorsconnect "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/plugins/operators/packages/v1alpha1/v1alpha1connect"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/transport"
+	log "k8s.io/klog/v2"
+)
+
+// webhookTimeout is the maximum time spent sending an event to the webhook.
+const webhookTimeout = 5 * time.Second
+
+// auditedProcedures are the mutating procedures for which an event is emitted,
+// for both versions of the core packages API and for the services of the
+// plugins, which can be requested directly and include their own operations,
+// such as the rollback of the Helm plugin.
+var auditedProcedures = map[string]bool{
+	packagesv1connect.PackagesServiceCreateInstalledPackageProcedure:                 true,
+	packagesv1connect.PackagesServiceUpdateInstalledPackageProcedure:                 true,
+	packagesv1connect.PackagesServiceDeleteInstalledPackageProcedure:                 true,
+	packagesv1connect.PackagesServiceBulkUpdateInstalledPackagesProcedure:            true,
+	packagesv1connect.PackagesServiceBulkDeleteInstalledPackagesProcedure:            true,
+	packagesv1connect.RepositoriesServiceAddPackageRepositoryProcedure:               true,
+	packagesv1connect.RepositoriesServiceUpdatePackageRepositoryProcedure:            true,
+	packagesv1connect.RepositoriesServiceDeletePackageRepositoryProcedure:            true,
+	packagesv1alpha1connect.PackagesServiceCreateInstalledPackageProcedure:           true,
+	packagesv1alpha1connect.PackagesServiceUpdateInstalledPackageProcedure:           true,
+	packagesv1alpha1connect.PackagesServiceDeleteInstalledPackageProcedure:           true,
+	packagesv1alpha1connect.RepositoriesServiceAddPackageRepositoryProcedure:         true,
+	packagesv1alpha1connect.RepositoriesServiceUpdatePackageRepositoryProcedure:      true,
+	packagesv1alpha1connect.RepositoriesServiceDeletePackageRepositoryProcedure:      true,
+	fluxv2connect.FluxV2PackagesServiceCreateInstalledPackageProcedure:               true,
+	fluxv2connect.FluxV2PackagesServiceUpdateInstalledPackageProcedure:               true,
+	fluxv2connect.FluxV2PackagesServiceDeleteInstalledPackageProcedure:               true,
+	fluxv2connect.FluxV2PackagesServiceConvertInstalledPackageProcedure:              true,
+	fluxv2connect.FluxV2RepositoriesServiceAddPackageRepositoryProcedure:             true,
+	fluxv2connect.FluxV2RepositoriesServiceUpdatePackageRepositoryProcedure:          true,
+	fluxv2connect.FluxV2RepositoriesServiceDeletePackageRepositoryProcedure:          true,
+	helmconnect.HelmPackagesServiceCreateInstalledPackageProcedure:                   true,
+	helmconnect.HelmPackagesServiceUpdateInstalledPackageProcedure:                   true,
+	helmconnect.HelmPackagesServiceDeleteInstalledPackageProcedure:                   true,
+	helmconnect.HelmPackagesServiceRollbackInstalledPackageProcedure:                 true,
+	helmconnect.HelmRepositoriesServiceAddPackageRepositoryProcedure:                 true,
+	helmconnect.HelmRepositoriesServiceUpdatePackageRepositoryProcedure:              true,
+	helmconnect.HelmRepositoriesServiceDeletePackageRepositoryProcedure:              true,
+	kappconnect.KappControllerPackagesServiceCreateInstalledPackageProcedure:         true,
+	kappconnect.KappControllerPackagesServiceUpdateInstalledPackageProcedure:         true,
+	kappconnect.KappControllerPackagesServiceDeleteInstalledPackageProcedure:         true,
+	kappconnect.KappControllerPackagesServiceUpdateInstalledPackageManifestProcedure: true,
+	kappconnect.KappControllerRepositoriesServiceAddPackageRepositoryProcedure:       true,
+	kappconnect.KappControllerRepositoriesServiceUpdatePackageRepositoryProcedure:    true,
+	kappconnect.KappControllerRepositoriesServiceDeletePackageRepositoryProcedure:    true,
+	ocicatalogconnect.OciCatalogPackagesServiceCreateInstalledPackageProcedure:       true,
+	ocicatalogconnect.OciCatalogPackagesServiceUpdateInstalledPackageProcedure:       true,
+	ocicatalogconnect.OciCatalogPackagesServiceDeleteInstalledPackageProcedure:       true,
+	operatorsconnect.OperatorsPackagesServiceCreateInstalledPackageProcedure:         true,
+	operatorsconnect.OperatorsPackagesServiceUpdateInstalledPackageProcedure:         true,
+	operatorsconnect.OperatorsPackagesServiceDeleteInstalledPackageProcedure:         true,
+}
+
+// redactedFields are the request fields which may contain credentials or
+// other sensitive data, so only a digest of their value is recorded.
+var redactedFields = map[protoreflect.Name]bool{
+	"values":        true,
+	"auth":          true,
+	"tls_config":    true,
+	"custom_detail": true,
+	"manifest":      true,
+}
+
+// ignoredPaths are the request fields already recorded in the event itself.
+var ignoredPaths = map[string]bool{
+	"context":                    true,
+	"targetContext":              true,
+	"name":                       true,
+	"plugin":                     true,
+	"installedPackageRef":        true,
+	"packageRepoRef":             true,
+	"availablePackageRef.plugin": true,
+}
+
+// Event is the audit record of a mutating RPC.
+type Event struct {
+	Time      time.Time `json:"time"`
+	Operation string    `json:"operation"`
+	Plugin    string    `json:"plugin,omitempty"`
+	User      User      `json:"user"`
+	// Impersonated is the user impersonated by the user performing the
+	// operation, if any.
+	Impersonated *User  `json:"impersonated,omitempty"`
+	Cluster      string `json:"cluster,omitempty"`
+	Namespace    string `json:"namespace,omitempty"`
+	Name         string `json:"name,omitempty"`
+	// Changes summarizes the fields set in the request, such as
+	// `pkgVersionReference.version=1.2.3`, with sensitive values redacted.
+	Changes []string `json:"changes,omitempty"`
+	// Code is "ok" or the code of the error returned to the user.
+	Code  string `json:"code"`
+	Error string `json:"error,omitempty"`
+}
+
+// User is the identity of the user performing the operation, as resolved by
+// the API server from the request token.
+type User struct {
+	Username string   `json:"username,omitempty"`
+	Groups   []string `json:"groups,omitempty"`
+	// Error explains why the user could not be identified, if so.
+	Error string `json:"error,omitempty"`
+}
+
+// Sink is the destination of the audit events.
+type Sink interface {
+	Emit(ctx context.Context, event Event) error
+}
+
+// UserResolver identifies the user from the headers of a request.
+type UserResolver func(ctx context.Context, headers http.Header) (User, error)
+
+type writerSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewWriterSink returns a sink writing each event as a line of JSON, such as
+// the standard output, so that it can be collected along with the logs.
+func NewWriterSink(w io.Writer) Sink {
+	return &writerSink{w: w}
+}
+
+func (s *writerSink) Emit(ctx context.Context, event Event) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.w.Write(append(line, '\n'))
+	return err
+}
+
+type webhookSink struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookSink returns a sink posting each event as JSON to the given URL.
+func NewWebhookSink(url string, client *http.Client) Sink {
+	if client == nil {
+		client = &http.Client{Timeout: webhookTimeout}
+	}
+	return &webhookSink{url: url, client: client}
+}
+
+func (s *webhookSink) Emit(ctx context.Context, event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status from the audit webhook: %s", res.Status)
+	}
+	return nil
+}
+
+type multiSink []Sink
+
+// NewMultiSink returns a sink emitting each event to all the given sinks.
+func NewMultiSink(sinks ...Sink) Sink {
+	return multiSink(sinks)
+}
+
+func (s multiSink) Emit(ctx context.Context, event Event) error {
+	errs := []string{}
+	for _, sink := range s {
+		if err := sink.Emit(ctx, event); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// NewTokenReviewUserResolver returns a resolver identifying the user by
+// reviewing the request token with the API server, using the given client.
+func NewTokenReviewUserResolver(clientSet kubernetes.Interface) UserResolver {
+	return func(ctx context.Context, headers http.Header) (User, error) {
+		user, err := authn.ReviewUser(ctx, clientSet, headers)
+		if err != nil {
+			return User{}, err
+		}
+		return User{
+			Username: user.Username,
+			Groups:   user.Groups,
+		}, nil
+	}
+}
+
+// NewInterceptor returns a connect interceptor emitting an event to the sink
+// once each audited RPC has been handled, whether it succeeded or not. A
+// failure to emit the event is logged but not returned to the user.
+func NewInterceptor(sink Sink, resolveUser UserResolver) connect.Interceptor {
+	return connect.UnaryInterceptorFunc(func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			if !auditedProcedures[req.Spec().Procedure] {
+				return next(ctx, req)
+			}
+
+			res, err := next(ctx, req)
+
+			// The event is emitted even if the request was cancelled
+			// by the user once the operation started.
+			emitCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), webhookTimeout)
+			defer cancel()
+			event := newEvent(req, err)
+			user, userErr := resolveUser(emitCtx, req.Header())
+			if userErr != nil {
+				user.Error = userErr.Error()
+			}
+			event.User = user
+			if username := req.Header().Get(transport.ImpersonateUserHeader); username != "" {
+				event.Impersonated = &User{Username: username, Groups: req.Header().Values(transport.ImpersonateGroupHeader)}
+			}
+			if emitErr := sink.Emit(emitCtx, event); emitErr != nil {
+				log.Errorf("Unable to emit the audit event for %s: %v", event.Operation, emitErr)
+			}
+			return res, err
+		}
+	})
+}
+
+// installedPackageRequest is a request operating on an installed package.
+type installedPackageRequest interface {
+	GetInstalledPackageRef() *packages.InstalledPackageReference
+}
+
+// packageRepositoryRequest is a request operating on a package repository.
+type packageRepositoryRequest interface {
+	GetPackageRepoRef() *packages.PackageRepositoryReference
+}
+
+// installedPackageRequestV1 is a request of the v1 API operating on an
+// installed package.
+type installedPackageRequestV1 interface {
+	GetInstalledPackageRef() *packagesv1.InstalledPackageReference
+}
+
+// packageRepositoryRequestV1 is a request of the v1 API operating on a
+// package repository.
+type packageRepositoryRequestV1 interface {
+	GetPackageRepoRef() *packagesv1.PackageRepositoryReference
+}
+
+// packageContext is the context of a package or a repository, in any
+// version of the core packages API.
+type packageContext interface {
+	GetCluster() string
+	GetNamespace() string
+}
+
+// newEvent returns the event for the given request and its outcome, without
+// the user identity.
+func newEvent(req connect.AnyRequest, err error) Event {
+	procedure := req.Spec().Procedure
+	event := Event{
+		Time:      time.Now().UTC(),
+		Operation: procedure[strings.LastIndex(procedure, "/")+1:],
+		Code:      "ok",
+	}
+	if err != nil {
+		event.Code = connect.CodeOf(err).String()
+		event.Error = err.Error()
+	}
+
+	// The requests of the plugin operations, such as a rollback, reference
+	// the installed package as the core ones.
+	var pkgContext packageContext
+	switch msg := req.Any().(type) {
+	case *packages.CreateInstalledPackageRequest:
+		pkgContext, event.Name = msg.GetTargetContext(), msg.GetName()
+		event.Plugin = msg.GetAvailablePackageRef().GetPlugin().GetName()
+	case *packages.AddPackageRepositoryRequest:
+		pkgContext, event.Name = msg.GetContext(), msg.GetName()
+		event.Plugin = msg.GetPlugin().GetName()
+	case installedPackageRequest:
+		pkgContext, event.Name = msg.GetInstalledPackageRef().GetContext(), msg.GetInstalledPackageRef().GetIdentifier()
+		event.Plugin = msg.GetInstalledPackageRef().GetPlugin().GetName()
+	case packageRepositoryRequest:
+		pkgContext, event.Name = msg.GetPackageRepoRef().GetContext(), msg.GetPackageRepoRef().GetIdentifier()
+		event.Plugin = msg.GetPackageRepoRef().GetPlugin().GetName()
+	case *packagesv1.CreateInstalledPackageRequest:
+		pkgContext, event.Name = msg.GetTargetContext(), msg.GetName()
+		event.Plugin = msg.GetAvailablePackageRef().GetPlugin().GetName()
+	case *packagesv1.AddPackageRepositoryRequest:
+		pkgContext, event.Name = msg.GetContext(), msg.GetName()
+		event.Plugin = msg.GetPlugin().GetName()
+	case installedPackageRequestV1:
+		pkgContext, event.Name = msg.GetInstalledPackageRef().GetContext(), msg.GetInstalledPackageRef().GetIdentifier()
+		event.Plugin = msg.GetInstalledPackageRef().GetPlugin().GetName()
+	case packageRepositoryRequestV1:
+		pkgContext, event.Name = msg.GetPackageRepoRef().GetContext(), msg.GetPackageRepoRef().GetIdentifier()
+		event.Plugin = msg.GetPackageRepoRef().GetPlugin().GetName()
+	}
+	if pkgContext != nil {
+		event.Cluster, event.Namespace = pkgContext.GetCluster(), pkgContext.GetNamespace()
+	}
+
+	if msg, ok := req.Any().(proto.Message); ok {
+		event.Changes = summarizeChanges(msg.ProtoReflect(), "")
+	}
+	return event
+}
+
+// summarizeChanges returns a line for every scalar field set in the message,
+// recursing into the nested messages.
+func summarizeChanges(msg protoreflect.Message, prefix string) []string {
+	changes := []string{}
+	fields := msg.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		path := prefix + field.JSONName()
+		if !msg.Has(field) || ignoredPaths[path] {
+			continue
+		}
+		value := msg.Get(field)
+		switch {
+		case redactedFields[field.Name()]:
+			changes = append(changes, fmt.Sprintf("%s=<redacted %s>", path, digest(field, value)))
+		case field.IsList():
+			changes = append(changes, fmt.Sprintf("%s=<%d items>", path, value.List().Len()))
+		case field.IsMap():
+			changes = append(changes, fmt.Sprintf("%s=<%d entries>", path, value.Map().Len()))
+		case field.Kind() == protoreflect.MessageKind:
+			changes = append(changes, summarizeChanges(value.Message(), path+".")...)
+		case field.Kind() == protoreflect.EnumKind:
+			enumValue := field.Enum().Values().ByNumber(value.Enum())
+			if enumValue != nil {
+				changes = append(changes, fmt.Sprintf("%s=%s", path, enumValue.Name()))
+			} else {
+				changes = append(changes, fmt.Sprintf("%s=%d", path, value.Enum()))
+			}
+		default:
+			changes = append(changes, fmt.Sprintf("%s=%v", path, value.Interface()))
+		}
+	}
+	return changes
+}
+
+// digest returns a short digest of the value of a redacted field, so that
+// changes of the value can be tracked without recording it.
+func digest(field protoreflect.FieldDescriptor, value protoreflect.Value) string {
+	var data []byte
+	switch {
+	case field.IsList() || field.IsMap():
+		return "value"
+	case field.Kind() == protoreflect.StringKind:
+		data = []byte(value.String())
+	case field.Kind() == protoreflect.BytesKind:
+		data = value.Bytes()
+	case field.Kind() == protoreflect.MessageKind:
+		var err error
+		data, err = proto.MarshalOptions{Deterministic: true}.Marshal(value.Message().Interface())
+		if err != nil {
+			return "value"
+		}
+	default:
+		return "value"
+	}
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])[:12]
+}
@@ -0,0 +1,377 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core/connecttest"
+	packagesv1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1"
+	packagesv1connect "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1/v1connect"
+	packages "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	packagesv1alpha1connect "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1/v1alpha1connect"
+	plugins "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/plugins/v1alpha1"
+	helmv1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/plugins/helm/packages/v1alpha1"
+	helmconnect "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/plugins/helm/packages/v1alpha1/v1alpha1connect"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	typfake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+type recordingSink struct {
+	events []Event
+}
+
+func (s *recordingSink) Emit(ctx context.Context, event Event) error {
+	s.events = append(s.events, event)
+	return nil
+}
+
+var ignoreTime = cmpopts.IgnoreFields(Event{}, "Time")
+
+func TestInterceptor(t *testing.T) {
+	helmPlugin := &plugins.Plugin{Name: "helm.packages", Version: "v1alpha1"}
+	user := User{Username: "kubeapps-user", Groups: []string{"system:authenticated"}}
+
+	testCases := []struct {
+		name          string
+		procedure     string
+		request       connect.AnyRequest
+		handlerErr    error
+		userErr       error
+		expectedEvent *Event
+	}{
+		{
+			name:      "it records the creation of an installed package, redacting the values",
+			procedure: packagesv1alpha1connect.PackagesServiceCreateInstalledPackageProcedure,
+			request: connect.NewRequest(&packages.CreateInstalledPackageRequest{
+				AvailablePackageRef: &packages.AvailablePackageReference{
+					Context:    &packages.Context{Cluster: "default", Namespace: "kubeapps"},
+					Identifier: "bitnami/apache",
+					Plugin:     helmPlugin,
+				},
+				TargetContext:       &packages.Context{Cluster: "default", Namespace: "web"},
+				Name:                "my-apache",
+				PkgVersionReference: &packages.VersionReference{Version: "1.2.3"},
+				Values:              "password: secret",
+			}),
+			expectedEvent: &Event{
+				Operation: "CreateInstalledPackage",
+				Plugin:    "helm.packages",
+				User:      user,
+				Cluster:   "default",
+				Namespace: "web",
+				Name:      "my-apache",
+				Changes: []string{
+					"availablePackageRef.context.cluster=default",
+					"availablePackageRef.context.namespace=kubeapps",
+					"availablePackageRef.identifier=bitnami/apache",
+					"pkgVersionReference.version=1.2.3",
+					"values=<redacted sha256:090b62523f03>",
+				},
+				Code: "ok",
+			},
+		},
+		{
+			name:      "it records a failed repository deletion",
+			procedure: packagesv1alpha1connect.RepositoriesServiceDeletePackageRepositoryProcedure,
+			request: connect.NewRequest(&packages.DeletePackageRepositoryRequest{
+				PackageRepoRef: &packages.PackageRepositoryReference{
+					Context:    &packages.Context{Cluster: "default", Namespace: "kubeapps"},
+					Identifier: "bitnami",
+					Plugin:     helmPlugin,
+				},
+			}),
+			handlerErr: connect.NewError(connect.CodePermissionDenied, fmt.Errorf("Forbidden")),
+			expectedEvent: &Event{
+				Operation: "DeletePackageRepository",
+				Plugin:    "helm.packages",
+				User:      user,
+				Cluster:   "default",
+				Namespace: "kubeapps",
+				Name:      "bitnami",
+				Changes:   []string{},
+				Code:      "permission_denied",
+				Error:     "permission_denied: Forbidden",
+			},
+		},
+		{
+			name:      "it records the event even if the user cannot be identified",
+			procedure: packagesv1alpha1connect.RepositoriesServiceAddPackageRepositoryProcedure,
+			request: connect.NewRequest(&packages.AddPackageRepositoryRequest{
+				Context: &packages.Context{Cluster: "default", Namespace: "kubeapps"},
+				Name:    "bitnami",
+				Url:     "https://charts.bitnami.com/bitnami",
+				Plugin:  helmPlugin,
+			}),
+			userErr: fmt.Errorf("unauthenticated token"),
+			expectedEvent: &Event{
+				Operation: "AddPackageRepository",
+				Plugin:    "helm.packages",
+				User:      User{Error: "unauthenticated token"},
+				Cluster:   "default",
+				Namespace: "kubeapps",
+				Name:      "bitnami",
+				Changes:   []string{"url=https://charts.bitnami.com/bitnami"},
+				Code:      "ok",
+			},
+		},
+		{
+			name:      "it records the user impersonated",
+			procedure: packagesv1alpha1connect.PackagesServiceDeleteInstalledPackageProcedure,
+			request: func() connect.AnyRequest {
+				req := connect.NewRequest(&packages.DeleteInstalledPackageRequest{
+					InstalledPackageRef: &packages.InstalledPackageReference{
+						Context:    &packages.Context{Cluster: "default", Namespace: "web"},
+						Identifier: "my-apache",
+						Plugin:     helmPlugin,
+					},
+				})
+				req.Header().Set("Impersonate-User", "jane")
+				req.Header().Add("Impersonate-Group", "developers")
+				return req
+			}(),
+			expectedEvent: &Event{
+				Operation:    "DeleteInstalledPackage",
+				Plugin:       "helm.packages",
+				User:         user,
+				Impersonated: &User{Username: "jane", Groups: []string{"developers"}},
+				Cluster:      "default",
+				Namespace:    "web",
+				Name:         "my-apache",
+				Changes:      []string{},
+				Code:         "ok",
+			},
+		},
+		{
+			name:      "it records the operations of the plugins, such as a helm rollback",
+			procedure: helmconnect.HelmPackagesServiceRollbackInstalledPackageProcedure,
+			request: connect.NewRequest(&helmv1alpha1.RollbackInstalledPackageRequest{
+				InstalledPackageRef: &packages.InstalledPackageReference{
+					Context:    &packages.Context{Cluster: "default", Namespace: "web"},
+					Identifier: "my-apache",
+					Plugin:     helmPlugin,
+				},
+				ReleaseRevision: 2,
+			}),
+			expectedEvent: &Event{
+				Operation: "RollbackInstalledPackage",
+				Plugin:    "helm.packages",
+				User:      user,
+				Cluster:   "default",
+				Namespace: "web",
+				Name:      "my-apache",
+				Changes:   []string{"releaseRevision=2"},
+				Code:      "ok",
+			},
+		},
+		{
+			name:      "it records the creation of an installed package with the v1 API",
+			procedure: packagesv1connect.PackagesServiceCreateInstalledPackageProcedure,
+			request: connect.NewRequest(&packagesv1.CreateInstalledPackageRequest{
+				AvailablePackageRef: &packagesv1.AvailablePackageReference{
+					Context:    &packagesv1.Context{Cluster: "default", Namespace: "kubeapps"},
+					Identifier: "bitnami/apache",
+					Plugin:     helmPlugin,
+				},
+				TargetContext: &packagesv1.Context{Cluster: "default", Namespace: "web"},
+				Name:          "my-apache",
+				Values:        "password: secret",
+			}),
+			expectedEvent: &Event{
+				Operation: "CreateInstalledPackage",
+				Plugin:    "helm.packages",
+				User:      user,
+				Cluster:   "default",
+				Namespace: "web",
+				Name:      "my-apache",
+				Changes: []string{
+					"availablePackageRef.context.cluster=default",
+					"availablePackageRef.context.namespace=kubeapps",
+					"availablePackageRef.identifier=bitnami/apache",
+					"values=<redacted sha256:090b62523f03>",
+				},
+				Code: "ok",
+			},
+		},
+		{
+			name:      "it records the update of a package repository with the v1 API",
+			procedure: packagesv1connect.RepositoriesServiceUpdatePackageRepositoryProcedure,
+			request: connect.NewRequest(&packagesv1.UpdatePackageRepositoryRequest{
+				PackageRepoRef: &packagesv1.PackageRepositoryReference{
+					Context:    &packagesv1.Context{Cluster: "default", Namespace: "kubeapps"},
+					Identifier: "bitnami",
+					Plugin:     helmPlugin,
+				},
+				Url: "https://charts.bitnami.com/bitnami",
+			}),
+			expectedEvent: &Event{
+				Operation: "UpdatePackageRepository",
+				Plugin:    "helm.packages",
+				User:      user,
+				Cluster:   "default",
+				Namespace: "kubeapps",
+				Name:      "bitnami",
+				Changes:   []string{"url=https://charts.bitnami.com/bitnami"},
+				Code:      "ok",
+			},
+		},
+		{
+			name:      "it records the deletion of an installed package with the v1 API",
+			procedure: packagesv1connect.PackagesServiceDeleteInstalledPackageProcedure,
+			request: connect.NewRequest(&packagesv1.DeleteInstalledPackageRequest{
+				InstalledPackageRef: &packagesv1.InstalledPackageReference{
+					Context:    &packagesv1.Context{Cluster: "default", Namespace: "web"},
+					Identifier: "my-apache",
+					Plugin:     helmPlugin,
+				},
+			}),
+			expectedEvent: &Event{
+				Operation: "DeleteInstalledPackage",
+				Plugin:    "helm.packages",
+				User:      user,
+				Cluster:   "default",
+				Namespace: "web",
+				Name:      "my-apache",
+				Changes:   []string{},
+				Code:      "ok",
+			},
+		},
+		{
+			name:      "it does not record read-only requests",
+			procedure: packagesv1alpha1connect.PackagesServiceGetInstalledPackageDetailProcedure,
+			request:   connect.NewRequest(&packages.GetInstalledPackageDetailRequest{}),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sink := &recordingSink{}
+			resolveUser := func(ctx context.Context, headers http.Header) (User, error) {
+				if tc.userErr != nil {
+					return User{}, tc.userErr
+				}
+				return user, nil
+			}
+			handler := NewInterceptor(sink, resolveUser).WrapUnary(func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+				return nil, tc.handlerErr
+			})
+
+			_, err := handler(context.Background(), connecttest.Request{
+				AnyRequest: tc.request,
+				Procedure:  tc.procedure,
+			})
+			if got, want := err, tc.handlerErr; got != want {
+				t.Fatalf("got: %+v, want: %+v", got, want)
+			}
+
+			if tc.expectedEvent == nil {
+				if len(sink.events) != 0 {
+					t.Fatalf("got: %+v, want: no event", sink.events)
+				}
+				return
+			}
+			if len(sink.events) != 1 {
+				t.Fatalf("got: %d events, want: 1", len(sink.events))
+			}
+			if got, want := sink.events[0], *tc.expectedEvent; !cmp.Equal(want, got, ignoreTime) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreTime))
+			}
+		})
+	}
+}
+
+func TestTokenReviewUserResolver(t *testing.T) {
+	clientSet := typfake.NewSimpleClientset()
+	clientSet.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authenticationv1.TokenReview)
+		if review.Spec.Token == "valid-token" {
+			review.Status = authenticationv1.TokenReviewStatus{
+				Authenticated: true,
+				User:          authenticationv1.UserInfo{Username: "kubeapps-user", Groups: []string{"admins"}},
+			}
+		}
+		return true, review, nil
+	})
+	resolveUser := NewTokenReviewUserResolver(clientSet)
+
+	testCases := []struct {
+		name          string
+		authorization string
+		expectedUser  User
+		expectedError bool
+	}{
+		{
+			name:          "it returns the identity of a valid token",
+			authorization: "Bearer valid-token",
+			expectedUser:  User{Username: "kubeapps-user", Groups: []string{"admins"}},
+		},
+		{
+			name:          "it errors for an invalid token",
+			authorization: "Bearer invalid-token",
+			expectedError: true,
+		},
+		{
+			name:          "it errors without a token",
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			headers := http.Header{}
+			if tc.authorization != "" {
+				headers.Set("Authorization", tc.authorization)
+			}
+			user, err := resolveUser(context.Background(), headers)
+			if got, want := err != nil, tc.expectedError; got != want {
+				t.Fatalf("got error: %+v, want error: %t", err, want)
+			}
+			if got, want := user, tc.expectedUser; !cmp.Equal(want, got) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
+func TestWebhookSink(t *testing.T) {
+	received := []Event{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		event := Event{}
+		if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received = append(received, event)
+		if event.Name == "rejected" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+	sink := NewWebhookSink(server.URL, server.Client())
+
+	if err := sink.Emit(context.Background(), Event{Operation: "DeleteInstalledPackage", Name: "my-apache", Code: "ok"}); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if err := sink.Emit(context.Background(), Event{Operation: "DeleteInstalledPackage", Name: "rejected", Code: "ok"}); err == nil {
+		t.Errorf("got: nil, want: error")
+	}
+
+	expected := []Event{
+		{Operation: "DeleteInstalledPackage", Name: "my-apache", Code: "ok"},
+		{Operation: "DeleteInstalledPackage", Name: "rejected", Code: "ok"},
+	}
+	if got, want := received, expected; !cmp.Equal(want, got, ignoreTime) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreTime))
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package authn identifies the users of the requests to the core services,
+// by reviewing their token with the API server, and forwards their
+// credentials to the requests made on their behalf.
+package authn
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/bufbuild/connect-go"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// ExtractToken returns the bearer token passed in the "authorization" header.
+func ExtractToken(headers http.Header) (string, error) {
+	bearerToken := headers.Get("Authorization")
+	if bearerToken == "" {
+		return "", fmt.Errorf("missing authorization metadata")
+	}
+	if !strings.HasPrefix(bearerToken, "Bearer ") {
+		return "", fmt.Errorf("malformed authorization metadata")
+	}
+	return strings.TrimPrefix(bearerToken, "Bearer "), nil
+}
+
+// ReviewUser returns the user authenticated by the token of the request, as
+// resolved by the API server with a TokenReview created with the given
+// client. An Unauthenticated error is returned if the token is missing or
+// not authenticated.
+func ReviewUser(ctx context.Context, clientSet kubernetes.Interface, headers http.Header) (*authenticationv1.UserInfo, error) {
+	token, err := ExtractToken(headers)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("Invalid authorization metadata: %w", err))
+	}
+
+	review, err := clientSet.AuthenticationV1().TokenReviews().Create(ctx, &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{Token: token},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to review the user token: %w", err))
+	}
+	if !review.Status.Authenticated || review.Status.User.Username == "" {
+		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("Unable to identify the user: %s", review.Status.Error))
+	}
+	return &review.Status.User, nil
+}
+
+// UserKey returns the key identifying the user authenticated by the token of
+// the request: a hash of the username, as resolved by the API server, since
+// the names and keys of the Kubernetes objects cannot contain arbitrary
+// characters.
+func UserKey(ctx context.Context, clientSet kubernetes.Interface, headers http.Header) (string, error) {
+	user, err := ReviewUser(ctx, clientSet, headers)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256([]byte(user.Username))
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// CopyHeaders copies the headers of a request, including the credentials of
+// its user, to a request made on their behalf.
+func CopyHeaders(dst, src http.Header) {
+	for key, values := range src {
+		dst[key] = values
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package authn
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/bufbuild/connect-go"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func newFakeClientSet() *fake.Clientset {
+	clientSet := fake.NewSimpleClientset()
+	clientSet.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (handled bool, ret runtime.Object, err error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authenticationv1.TokenReview)
+		switch review.Spec.Token {
+		case "token-a", "token-a-2":
+			review.Status.Authenticated = true
+			review.Status.User.Username = "user-a"
+		case "token-b":
+			review.Status.Authenticated = true
+			review.Status.User.Username = "user-b"
+		case "unreviewable":
+			return true, nil, errors.New("unavailable")
+		default:
+			review.Status.Error = "invalid token"
+		}
+		return true, review, nil
+	})
+	return clientSet
+}
+
+func headers(authorization string) http.Header {
+	headers := http.Header{}
+	if authorization != "" {
+		headers.Set("Authorization", authorization)
+	}
+	return headers
+}
+
+func TestExtractToken(t *testing.T) {
+	testCases := []struct {
+		name          string
+		authorization string
+		expectedToken string
+		expectedErr   bool
+	}{
+		{
+			name:          "it returns the bearer token",
+			authorization: "Bearer abc",
+			expectedToken: "abc",
+		},
+		{
+			name:        "it returns an error without authorization",
+			expectedErr: true,
+		},
+		{
+			name:          "it returns an error for a malformed authorization",
+			authorization: "Basic abc",
+			expectedErr:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			token, err := ExtractToken(headers(tc.authorization))
+
+			if got, want := err != nil, tc.expectedErr; got != want {
+				t.Fatalf("got: %+v, want error: %t", err, want)
+			}
+			if got, want := token, tc.expectedToken; got != want {
+				t.Errorf("got: %q, want: %q", got, want)
+			}
+		})
+	}
+}
+
+func TestReviewUser(t *testing.T) {
+	testCases := []struct {
+		name          string
+		authorization string
+		expectedUser  string
+		expectedCode  connect.Code
+	}{
+		{
+			name:          "it returns the user of the token",
+			authorization: "Bearer token-a",
+			expectedUser:  "user-a",
+		},
+		{
+			name:         "it returns unauthenticated without token",
+			expectedCode: connect.CodeUnauthenticated,
+		},
+		{
+			name:          "it returns unauthenticated for an invalid token",
+			authorization: "Bearer invalid",
+			expectedCode:  connect.CodeUnauthenticated,
+		},
+		{
+			name:          "it returns internal if the token cannot be reviewed",
+			authorization: "Bearer unreviewable",
+			expectedCode:  connect.CodeInternal,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			user, err := ReviewUser(context.Background(), newFakeClientSet(), headers(tc.authorization))
+
+			if tc.expectedCode != 0 {
+				if got, want := connect.CodeOf(err), tc.expectedCode; err == nil || got != want {
+					t.Fatalf("got: %+v, want: %+v", err, want)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if got, want := user.Username, tc.expectedUser; got != want {
+				t.Errorf("got: %q, want: %q", got, want)
+			}
+		})
+	}
+}
+
+func TestUserKey(t *testing.T) {
+	clientSet := newFakeClientSet()
+	keyA, err := UserKey(context.Background(), clientSet, headers("Bearer token-a"))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	keyA2, err := UserKey(context.Background(), clientSet, headers("Bearer token-a-2"))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	keyB, err := UserKey(context.Background(), clientSet, headers("Bearer token-b"))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	// The key identifies the user rather than the token.
+	if keyA != keyA2 {
+		t.Errorf("got: %q and %q, want: the same key for the tokens of a user", keyA, keyA2)
+	}
+	if keyA == keyB {
+		t.Errorf("got: %q, want: distinct keys for distinct users", keyA)
+	}
+}
@@ -0,0 +1,353 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core/authn"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core/storage"
+	clusters "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/clusters/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/pkg/kube"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	log "k8s.io/klog/v2"
+)
+
+const (
+	// RegisteredClustersSecretName is the name of the Secret, in the Kubeapps
+	// namespace, which stores the clusters registered at runtime. The users
+	// allowed to update it are the ones allowed to register clusters.
+	RegisteredClustersSecretName = "kubeapps-registered-clusters"
+
+	// clustersDataKey is the Secret key holding the registered clusters,
+	// serialized as JSON in the format of the clusters config.
+	clustersDataKey = "clusters.json"
+)
+
+// clustersServer implements the API defined in proto/kubeappsapis/core/clusters/v1alpha1/clusters.proto
+type clustersServer struct {
+	clusters.UnimplementedClustersServiceServer
+
+	// clientSet checks, with access reviews, whether the users may register
+	// clusters and keeps the Secret of the registered clusters, which holds
+	// their service tokens.
+	clientSet kubernetes.Interface
+
+	// namespace in which the registered clusters are stored.
+	namespace string
+
+	// clustersConfig is the clusters config parsed at startup, whose registry
+	// is updated with the registered clusters. The clusters cannot be
+	// registered if it has no registry.
+	clustersConfig kube.ClustersConfig
+}
+
+func NewClustersServer(clientSet kubernetes.Interface, namespace string, clustersConfig kube.ClustersConfig) (*clustersServer, error) {
+	if namespace == "" {
+		return nil, fmt.Errorf("a namespace is required to store the registered clusters")
+	}
+	return &clustersServer{
+		clientSet:      clientSet,
+		namespace:      namespace,
+		clustersConfig: clustersConfig,
+	}, nil
+}
+
+// GetClusters returns the configured and the registered clusters.
+func (s *clustersServer) GetClusters(ctx context.Context, request *connect.Request[clusters.GetClustersRequest]) (*connect.Response[clusters.GetClustersResponse], error) {
+	log.InfoS("+core GetClusters")
+
+	if err := s.checkAccess(ctx, request.Header(), "get"); err != nil {
+		return nil, err
+	}
+
+	result := []*clusters.Cluster{}
+	for _, config := range s.clustersConfig.Clusters {
+		cluster := clusterFromConfig(config)
+		cluster.Static = true
+		result = append(result, cluster)
+	}
+	for _, name := range s.clustersConfig.Registered.Names() {
+		if _, ok := s.clustersConfig.Clusters[name]; ok {
+			continue
+		}
+		if config, ok := s.clustersConfig.Registered.Get(name); ok {
+			result = append(result, clusterFromConfig(config))
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].GetName() < result[j].GetName() })
+
+	return connect.NewResponse(&clusters.GetClustersResponse{
+		Clusters: result,
+	}), nil
+}
+
+// RegisterCluster registers an additional cluster, replacing its previous
+// registration if any.
+func (s *clustersServer) RegisterCluster(ctx context.Context, request *connect.Request[clusters.RegisterClusterRequest]) (*connect.Response[clusters.RegisterClusterResponse], error) {
+	log.InfoS("+core RegisterCluster", "cluster", request.Msg.GetCluster().GetName())
+
+	if s.clustersConfig.Registered == nil {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("The registration of clusters is not enabled"))
+	}
+	config, err := s.validateCluster(request.Msg.GetCluster())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkAccess(ctx, request.Header(), "update"); err != nil {
+		return nil, err
+	}
+
+	err = s.updateRegisteredClusters(ctx, func(registered []kube.ClusterConfig) ([]kube.ClusterConfig, error) {
+		updated := []kube.ClusterConfig{}
+		for _, c := range registered {
+			if c.Name != config.Name {
+				updated = append(updated, c)
+			}
+		}
+		return append(updated, config), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return connect.NewResponse(&clusters.RegisterClusterResponse{
+		Cluster: clusterFromConfig(config),
+	}), nil
+}
+
+// DeregisterCluster deregisters a cluster registered at runtime.
+func (s *clustersServer) DeregisterCluster(ctx context.Context, request *connect.Request[clusters.DeregisterClusterRequest]) (*connect.Response[clusters.DeregisterClusterResponse], error) {
+	name := request.Msg.GetName()
+	log.InfoS("+core DeregisterCluster", "cluster", name)
+
+	if s.clustersConfig.Registered == nil {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("The registration of clusters is not enabled"))
+	}
+	if _, ok := s.clustersConfig.Clusters[name]; ok {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("The cluster %q is defined in the clusters config, it cannot be deregistered", name))
+	}
+	if err := s.checkAccess(ctx, request.Header(), "update"); err != nil {
+		return nil, err
+	}
+
+	err := s.updateRegisteredClusters(ctx, func(registered []kube.ClusterConfig) ([]kube.ClusterConfig, error) {
+		updated := []kube.ClusterConfig{}
+		for _, c := range registered {
+			if c.Name != name {
+				updated = append(updated, c)
+			}
+		}
+		if len(updated) == len(registered) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("The cluster %q is not registered", name))
+		}
+		return updated, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return connect.NewResponse(&clusters.DeregisterClusterResponse{}), nil
+}
+
+// Run syncs the registered clusters at the given interval until the context
+// is done, so that the clusters registered through other replicas are picked
+// up.
+func (s *clustersServer) Run(ctx context.Context, interval time.Duration) {
+	log.Infof("Syncing the registered clusters every %s", interval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := s.Sync(ctx); err != nil {
+			log.Errorf("Unable to sync the registered clusters: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sync replaces the clusters of the registry with the ones stored in the
+// Secret.
+func (s *clustersServer) Sync(ctx context.Context) error {
+	if s.clustersConfig.Registered == nil {
+		return nil
+	}
+	secret, err := s.clientSet.CoreV1().Secrets(s.namespace).Get(ctx, RegisteredClustersSecretName, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return s.clustersConfig.Registered.Replace(nil)
+	} else if err != nil {
+		return err
+	}
+	registered, err := registeredClustersFromSecret(secret)
+	if err != nil {
+		return err
+	}
+	return s.clustersConfig.Registered.Replace(registered)
+}
+
+// updateRegisteredClusters updates the registered clusters stored in the
+// Secret, retrying if it is updated concurrently, and then the registry.
+func (s *clustersServer) updateRegisteredClusters(ctx context.Context, update func([]kube.ClusterConfig) ([]kube.ClusterConfig, error)) error {
+	var updated []kube.ClusterConfig
+	err := storage.Update(ctx, s.clientSet.CoreV1().Secrets(s.namespace), "secrets", RegisteredClustersSecretName, func(secret *corev1.Secret) (*corev1.Secret, error) {
+		var registered []kube.ClusterConfig
+		var err error
+		if secret != nil {
+			if registered, err = registeredClustersFromSecret(secret); err != nil {
+				return nil, err
+			}
+		}
+		if updated, err = update(registered); err != nil {
+			return nil, err
+		}
+		data, err := json.Marshal(updated)
+		if err != nil {
+			return nil, err
+		}
+
+		if secret == nil {
+			secret = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      RegisteredClustersSecretName,
+					Namespace: s.namespace,
+				},
+			}
+		}
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+		secret.Data[clustersDataKey] = data
+		return secret, nil
+	})
+	if err != nil {
+		var connectErr *connect.Error
+		if errors.As(err, &connectErr) {
+			return connectErr
+		}
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to store the registered clusters: %w", err))
+	}
+	if err := s.clustersConfig.Registered.Replace(updated); err != nil {
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to update the registered clusters: %w", err))
+	}
+	return nil
+}
+
+// validateCluster returns the config of a cluster to register.
+func (s *clustersServer) validateCluster(cluster *clusters.Cluster) (kube.ClusterConfig, error) {
+	if cluster.GetName() == "" {
+		return kube.ClusterConfig{}, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Unable to register the cluster (missing cluster.name)"))
+	}
+	if kube.IsKubeappsClusterRef(cluster.GetName()) {
+		return kube.ClusterConfig{}, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("The cluster name %q is reserved", cluster.GetName()))
+	}
+	if _, ok := s.clustersConfig.Clusters[cluster.GetName()]; ok {
+		return kube.ClusterConfig{}, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("The cluster %q is defined in the clusters config, it cannot be replaced", cluster.GetName()))
+	}
+	apiServiceURL, err := url.Parse(cluster.GetApiServiceUrl())
+	if err != nil || apiServiceURL.Scheme != "https" || apiServiceURL.Host == "" {
+		return kube.ClusterConfig{}, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Invalid API service URL %q, an https URL is required", cluster.GetApiServiceUrl()))
+	}
+
+	config := kube.ClusterConfig{
+		Name:                     cluster.GetName(),
+		APIServiceURL:            cluster.GetApiServiceUrl(),
+		CertificateAuthorityData: cluster.GetCertificateAuthorityData(),
+		CABundle:                 cluster.GetCaBundle(),
+		ServiceToken:             cluster.GetServiceToken(),
+		Insecure:                 cluster.GetInsecure(),
+		PinnipedConfig: kube.PinnipedConciergeConfig{
+			Enabled:           cluster.GetPinnipedConfig().GetEnabled(),
+			Namespace:         cluster.GetPinnipedConfig().GetNamespace(),
+			AuthenticatorType: cluster.GetPinnipedConfig().GetAuthenticatorType(),
+			AuthenticatorName: cluster.GetPinnipedConfig().GetAuthenticatorName(),
+		},
+	}
+	// The decoded config is not stored, but its certificate authorities must
+	// be valid to be registered.
+	decoded := config
+	if err := kube.DecodeClusterConfig(&decoded); err != nil {
+		return kube.ClusterConfig{}, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	return config, nil
+}
+
+// checkAccess checks that the user is allowed to perform the verb on the
+// Secret storing the registered clusters.
+func (s *clustersServer) checkAccess(ctx context.Context, headers http.Header, verb string) error {
+	user, err := authn.ReviewUser(ctx, s.clientSet, headers)
+	if err != nil {
+		return err
+	}
+
+	extra := map[string]authorizationv1.ExtraValue{}
+	for key, value := range user.Extra {
+		extra[key] = authorizationv1.ExtraValue(value)
+	}
+	accessReview, err := s.clientSet.AuthorizationV1().SubjectAccessReviews().Create(ctx, &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   user.Username,
+			Groups: user.Groups,
+			UID:    user.UID,
+			Extra:  extra,
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: s.namespace,
+				Verb:      verb,
+				Resource:  "secrets",
+				Name:      RegisteredClustersSecretName,
+			},
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to review the access of the user: %w", err))
+	}
+	if !accessReview.Status.Allowed {
+		return connect.NewError(connect.CodePermissionDenied, fmt.Errorf("The user %q is not allowed to %s the registered clusters", user.Username, verb))
+	}
+	return nil
+}
+
+// registeredClustersFromSecret returns the registered clusters stored in the
+// Secret.
+func registeredClustersFromSecret(secret *corev1.Secret) ([]kube.ClusterConfig, error) {
+	registered := []kube.ClusterConfig{}
+	if data := secret.Data[clustersDataKey]; len(data) > 0 {
+		if err := json.Unmarshal(data, &registered); err != nil {
+			return nil, fmt.Errorf("unable to parse the %q key: %w", clustersDataKey, err)
+		}
+	}
+	return registered, nil
+}
+
+// clusterFromConfig returns the cluster of a cluster config, without its
+// service token.
+func clusterFromConfig(config kube.ClusterConfig) *clusters.Cluster {
+	return &clusters.Cluster{
+		Name:                     config.Name,
+		ApiServiceUrl:            config.APIServiceURL,
+		CertificateAuthorityData: config.CertificateAuthorityData,
+		CaBundle:                 config.CABundle,
+		Insecure:                 config.Insecure,
+		PinnipedConfig: &clusters.PinnipedConfig{
+			Enabled:           config.PinnipedConfig.Enabled || config.PinnipedConfig.Enable,
+			Namespace:         config.PinnipedConfig.Namespace,
+			AuthenticatorType: config.PinnipedConfig.AuthenticatorType,
+			AuthenticatorName: config.PinnipedConfig.AuthenticatorName,
+		},
+	}
+}
@@ -0,0 +1,245 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/google/go-cmp/cmp"
+	clusters "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/clusters/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/pkg/kube"
+	"google.golang.org/protobuf/testing/protocmp"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+var stagingCluster = &clusters.Cluster{
+	Name:                     "staging",
+	ApiServiceUrl:            "https://staging.example.com:6443",
+	CertificateAuthorityData: "Y2EtY2VydC1kYXRhCg==",
+	ServiceToken:             "staging-token",
+	PinnipedConfig:           &clusters.PinnipedConfig{Enabled: true, Namespace: "pinniped-concierge"},
+}
+
+// newTestClustersServer returns a server whose token reviews authenticate
+// the "admin-token" and "user-token" tokens, only the "admin" user being
+// allowed to access the Secret storing the registered clusters.
+func newTestClustersServer(t *testing.T, registry *kube.ClusterRegistry) (*clustersServer, *fake.Clientset) {
+	clientSet := fake.NewSimpleClientset()
+	clientSet.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (handled bool, ret runtime.Object, err error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authenticationv1.TokenReview)
+		switch review.Spec.Token {
+		case "admin-token":
+			review.Status.Authenticated = true
+			review.Status.User.Username = "admin"
+		case "user-token":
+			review.Status.Authenticated = true
+			review.Status.User.Username = "user"
+		default:
+			review.Status.Error = "invalid token"
+		}
+		return true, review, nil
+	})
+	clientSet.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (handled bool, ret runtime.Object, err error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+		review.Status.Allowed = review.Spec.User == "admin" && review.Spec.ResourceAttributes.Name == RegisteredClustersSecretName
+		return true, review, nil
+	})
+	server, err := NewClustersServer(clientSet, "kubeapps", kube.ClustersConfig{
+		KubeappsClusterName: "default",
+		Clusters: map[string]kube.ClusterConfig{
+			"default": {Name: "default", ServiceToken: "default-token"},
+		},
+		Registered: registry,
+	})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	return server, clientSet
+}
+
+func newRequest[T any](msg *T, token string) *connect.Request[T] {
+	request := connect.NewRequest(msg)
+	if token != "" {
+		request.Header().Set("Authorization", "Bearer "+token)
+	}
+	return request
+}
+
+func TestRegisterCluster(t *testing.T) {
+	testCases := []struct {
+		name         string
+		cluster      *clusters.Cluster
+		token        string
+		disabled     bool
+		expectedCode connect.Code
+	}{
+		{
+			name:    "it registers a cluster",
+			cluster: stagingCluster,
+			token:   "admin-token",
+		},
+		{
+			name:         "it returns unauthenticated without token",
+			cluster:      stagingCluster,
+			expectedCode: connect.CodeUnauthenticated,
+		},
+		{
+			name:         "it returns permission denied if the user cannot update the registered clusters",
+			cluster:      stagingCluster,
+			token:        "user-token",
+			expectedCode: connect.CodePermissionDenied,
+		},
+		{
+			name:         "it returns failed precondition if the registration is not enabled",
+			cluster:      stagingCluster,
+			token:        "admin-token",
+			disabled:     true,
+			expectedCode: connect.CodeFailedPrecondition,
+		},
+		{
+			name:         "it returns failed precondition for a cluster of the clusters config",
+			cluster:      &clusters.Cluster{Name: "default", ApiServiceUrl: "https://default.example.com"},
+			token:        "admin-token",
+			expectedCode: connect.CodeFailedPrecondition,
+		},
+		{
+			name:         "it returns invalid argument without an https API service URL",
+			cluster:      &clusters.Cluster{Name: "staging", ApiServiceUrl: "http://staging.example.com"},
+			token:        "admin-token",
+			expectedCode: connect.CodeInvalidArgument,
+		},
+		{
+			name:         "it returns invalid argument for an invalid certificate authority",
+			cluster:      &clusters.Cluster{Name: "staging", ApiServiceUrl: "https://staging.example.com", CertificateAuthorityData: "not-base64-encoded"},
+			token:        "admin-token",
+			expectedCode: connect.CodeInvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			registry := kube.NewClusterRegistry()
+			if tc.disabled {
+				registry = nil
+			}
+			server, clientSet := newTestClustersServer(t, registry)
+
+			response, err := server.RegisterCluster(context.Background(), newRequest(&clusters.RegisterClusterRequest{Cluster: tc.cluster}, tc.token))
+			if got, want := connect.CodeOf(err), tc.expectedCode; err != nil && got != want {
+				t.Fatalf("got: %v, want: %v, err: %+v", got, want, err)
+			}
+			if tc.expectedCode != 0 {
+				if err == nil {
+					t.Fatalf("got: nil, want: error with code %v", tc.expectedCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+
+			// The service token is never returned.
+			if got, want := response.Msg.GetCluster().GetServiceToken(), ""; got != want {
+				t.Errorf("got: %q, want: %q", got, want)
+			}
+
+			registered, ok := registry.Get("staging")
+			if !ok {
+				t.Fatalf("got: not registered, want: registered")
+			}
+			if got, want := registered.ServiceToken, "staging-token"; got != want {
+				t.Errorf("got: %q, want: %q", got, want)
+			}
+			if got, want := registered.CertificateAuthorityDataDecoded, "ca-cert-data\n"; got != want {
+				t.Errorf("got: %q, want: %q", got, want)
+			}
+
+			// The registration is stored for the other replicas.
+			otherRegistry := kube.NewClusterRegistry()
+			other, _ := newTestClustersServer(t, otherRegistry)
+			other.clientSet = clientSet
+			if err := other.Sync(context.Background()); err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if got, want := otherRegistry.Names(), []string{"staging"}; !cmp.Equal(want, got) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
+func TestDeregisterCluster(t *testing.T) {
+	registry := kube.NewClusterRegistry()
+	server, clientSet := newTestClustersServer(t, registry)
+	if _, err := server.RegisterCluster(context.Background(), newRequest(&clusters.RegisterClusterRequest{Cluster: stagingCluster}, "admin-token")); err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	if _, err := server.DeregisterCluster(context.Background(), newRequest(&clusters.DeregisterClusterRequest{Name: "staging"}, "user-token")); connect.CodeOf(err) != connect.CodePermissionDenied {
+		t.Errorf("got: %+v, want: permission denied", err)
+	}
+	if _, err := server.DeregisterCluster(context.Background(), newRequest(&clusters.DeregisterClusterRequest{Name: "default"}, "admin-token")); connect.CodeOf(err) != connect.CodeFailedPrecondition {
+		t.Errorf("got: %+v, want: failed precondition", err)
+	}
+
+	if _, err := server.DeregisterCluster(context.Background(), newRequest(&clusters.DeregisterClusterRequest{Name: "staging"}, "admin-token")); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if _, ok := registry.Get("staging"); ok {
+		t.Errorf("got: registered, want: deregistered")
+	}
+	secret, err := clientSet.CoreV1().Secrets("kubeapps").Get(context.Background(), RegisteredClustersSecretName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if got, want := string(secret.Data[clustersDataKey]), "[]"; got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+
+	if _, err := server.DeregisterCluster(context.Background(), newRequest(&clusters.DeregisterClusterRequest{Name: "staging"}, "admin-token")); connect.CodeOf(err) != connect.CodeNotFound {
+		t.Errorf("got: %+v, want: not found", err)
+	}
+}
+
+func TestGetClusters(t *testing.T) {
+	registry := kube.NewClusterRegistry()
+	server, _ := newTestClustersServer(t, registry)
+	if _, err := server.RegisterCluster(context.Background(), newRequest(&clusters.RegisterClusterRequest{Cluster: stagingCluster}, "admin-token")); err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	if _, err := server.GetClusters(context.Background(), newRequest(&clusters.GetClustersRequest{}, "user-token")); connect.CodeOf(err) != connect.CodePermissionDenied {
+		t.Errorf("got: %+v, want: permission denied", err)
+	}
+
+	response, err := server.GetClusters(context.Background(), newRequest(&clusters.GetClustersRequest{}, "admin-token"))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	expected := &clusters.GetClustersResponse{
+		Clusters: []*clusters.Cluster{
+			{
+				Name:           "default",
+				PinnipedConfig: &clusters.PinnipedConfig{},
+				Static:         true,
+			},
+			{
+				Name:                     "staging",
+				ApiServiceUrl:            "https://staging.example.com:6443",
+				CertificateAuthorityData: "Y2EtY2VydC1kYXRhCg==",
+				PinnipedConfig:           &clusters.PinnipedConfig{Enabled: true, Namespace: "pinniped-concierge"},
+			},
+		},
+	}
+	if got, want := response.Msg, expected; !cmp.Equal(want, got, protocmp.Transform()) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, protocmp.Transform()))
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"compress/flate"
+	"io"
+
+	"github.com/bufbuild/connect-go"
+)
+
+// CompressionDeflate is the name of the deflate compression, supported by the
+// handlers along with the gzip one built into connect-go.
+const CompressionDeflate = "deflate"
+
+// HandlerOptions returns the connect-go handler options configured with the
+// serve options, shared by the core and the plugins' handlers: the supported
+// compressions and the limits of the messages.
+func HandlerOptions(serveOpts ServeOptions) []connect.HandlerOption {
+	return []connect.HandlerOption{
+		connect.WithCompression(CompressionDeflate, newDeflateDecompressor, newDeflateCompressor),
+		connect.WithCompressMinBytes(serveOpts.CompressMinBytes),
+		connect.WithReadMaxBytes(serveOpts.MaxMessageBytes),
+		connect.WithSendMaxBytes(serveOpts.MaxMessageBytes),
+	}
+}
+
+func newDeflateCompressor() connect.Compressor {
+	// flate.NewWriter only returns an error for an invalid level.
+	w, _ := flate.NewWriter(nil, flate.DefaultCompression)
+	return w
+}
+
+func newDeflateDecompressor() connect.Decompressor {
+	return &deflateDecompressor{ReadCloser: flate.NewReader(nil)}
+}
+
+// deflateDecompressor adapts the flate reader, whose Reset also takes a
+// dictionary, to the connect.Decompressor interface.
+type deflateDecompressor struct {
+	io.ReadCloser
+}
+
+func (d *deflateDecompressor) Reset(r io.Reader) error {
+	return d.ReadCloser.(flate.Resetter).Reset(r, nil)
+}
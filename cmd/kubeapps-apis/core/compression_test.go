@@ -0,0 +1,44 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestDeflateRoundTrip(t *testing.T) {
+	payload := strings.Repeat(`{"availablePackageSummaries": []}`, 100)
+
+	compressed := &bytes.Buffer{}
+	compressor := newDeflateCompressor()
+	// The compressors and decompressors are pooled and reset by connect-go.
+	for i := 0; i < 2; i++ {
+		compressed.Reset()
+		compressor.Reset(compressed)
+		if _, err := compressor.Write([]byte(payload)); err != nil {
+			t.Fatalf("%+v", err)
+		}
+		if err := compressor.Close(); err != nil {
+			t.Fatalf("%+v", err)
+		}
+		if got, want := compressed.Len(), len(payload); got >= want {
+			t.Errorf("got: %d bytes, want: less than %d", got, want)
+		}
+
+		decompressor := newDeflateDecompressor()
+		if err := decompressor.Reset(bytes.NewReader(compressed.Bytes())); err != nil {
+			t.Fatalf("%+v", err)
+		}
+		decompressed, err := io.ReadAll(decompressor)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		if got, want := string(decompressed), payload; got != want {
+			t.Errorf("got: %q, want: %q", got, want)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package connecttest provides helpers to test the connect interceptors of
+// the core services.
+package connecttest
+
+import (
+	"github.com/bufbuild/connect-go"
+)
+
+// Request is a connect request whose procedure can be set, as the procedure
+// is only populated by connect when handling an actual request.
+type Request struct {
+	connect.AnyRequest
+	Procedure string
+}
+
+// Spec returns the specification of the request, with its procedure.
+func (r Request) Spec() connect.Spec {
+	return connect.Spec{Procedure: r.Procedure}
+}
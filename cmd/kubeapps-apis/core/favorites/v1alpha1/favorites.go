@@ -0,0 +1,294 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core/authn"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core/storage"
+	favorites "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/favorites/v1alpha1"
+	packages "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	log "k8s.io/klog/v2"
+)
+
+const (
+	// FavoritesConfigMapPrefix is the prefix of the names of the ConfigMaps,
+	// in the Kubeapps namespace, in which the favorites of each user are
+	// stored, followed by the key of the user.
+	FavoritesConfigMapPrefix = "kubeapps-user-favorites-"
+
+	// FavoritesLabel is the label identifying the ConfigMaps which store the
+	// favorites of a user.
+	FavoritesLabel = "kubeapps.dev/user-favorites"
+
+	// favoritesKey is the ConfigMap key under which the favorites are stored.
+	favoritesKey = "favorites"
+
+	// maxStarredPackages and maxRecentInstalls bound the favorites of each
+	// user, so that their ConfigMap stays small.
+	maxStarredPackages = 100
+	maxRecentInstalls  = 10
+)
+
+// favoritesServer implements the API defined in proto/kubeappsapis/core/favorites/v1alpha1/favorites.proto
+type favoritesServer struct {
+	favorites.UnimplementedFavoritesServiceServer
+
+	// clientSet identifies the calling user, whose starred packages and
+	// recent installs it reads from and writes to their favorites ConfigMap.
+	clientSet kubernetes.Interface
+
+	// namespace in which the favorites ConfigMaps are stored.
+	namespace string
+
+	// clock returns the time at which the favorites are recorded.
+	clock func() time.Time
+}
+
+func NewFavoritesServer(clientSet kubernetes.Interface, namespace string) (*favoritesServer, error) {
+	if namespace == "" {
+		return nil, fmt.Errorf("a namespace is required to store the user favorites")
+	}
+	return &favoritesServer{
+		clientSet: clientSet,
+		namespace: namespace,
+		clock:     time.Now,
+	}, nil
+}
+
+// GetUserFavorites returns the favorites stored for the calling user.
+func (s *favoritesServer) GetUserFavorites(ctx context.Context, request *connect.Request[favorites.GetUserFavoritesRequest]) (*connect.Response[favorites.GetUserFavoritesResponse], error) {
+	log.InfoS("+core GetUserFavorites")
+
+	key, err := authn.UserKey(ctx, s.clientSet, request.Header())
+	if err != nil {
+		return nil, err
+	}
+
+	cm, err := s.clientSet.CoreV1().ConfigMaps(s.namespace).Get(ctx, configMapName(key), metav1.GetOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to get the user favorites: %w", err))
+	}
+
+	userFavorites, err := parseFavorites(cm)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&favorites.GetUserFavoritesResponse{
+		Favorites: userFavorites,
+	}), nil
+}
+
+// AddStarredPackage stars an available package for the calling user, at the
+// top of the starred packages.
+func (s *favoritesServer) AddStarredPackage(ctx context.Context, request *connect.Request[favorites.AddStarredPackageRequest]) (*connect.Response[favorites.AddStarredPackageResponse], error) {
+	log.InfoS("+core AddStarredPackage", "identifier", request.Msg.GetStarredPackage().GetAvailablePackageRef().GetIdentifier())
+
+	starred := request.Msg.GetStarredPackage()
+	if err := validateAvailablePackageRef(starred.GetAvailablePackageRef()); err != nil {
+		return nil, err
+	}
+	starred = proto.Clone(starred).(*favorites.StarredPackage)
+	starred.Time = s.clock().UTC().Format(time.RFC3339)
+
+	userFavorites, err := s.updateFavorites(ctx, request.Header(), func(userFavorites *favorites.UserFavorites) error {
+		remaining := removeStarredPackage(userFavorites.StarredPackages, starred.GetAvailablePackageRef())
+		if len(remaining) >= maxStarredPackages {
+			return connect.NewError(connect.CodeResourceExhausted, fmt.Errorf("Unable to star the package: at most %d packages can be starred", maxStarredPackages))
+		}
+		userFavorites.StarredPackages = append([]*favorites.StarredPackage{starred}, remaining...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&favorites.AddStarredPackageResponse{
+		StarredPackages: userFavorites.GetStarredPackages(),
+	}), nil
+}
+
+// RemoveStarredPackage unstars an available package for the calling user. It
+// is not an error to unstar a package which is not starred.
+func (s *favoritesServer) RemoveStarredPackage(ctx context.Context, request *connect.Request[favorites.RemoveStarredPackageRequest]) (*connect.Response[favorites.RemoveStarredPackageResponse], error) {
+	log.InfoS("+core RemoveStarredPackage", "identifier", request.Msg.GetAvailablePackageRef().GetIdentifier())
+
+	if err := validateAvailablePackageRef(request.Msg.GetAvailablePackageRef()); err != nil {
+		return nil, err
+	}
+
+	userFavorites, err := s.updateFavorites(ctx, request.Header(), func(userFavorites *favorites.UserFavorites) error {
+		userFavorites.StarredPackages = removeStarredPackage(userFavorites.StarredPackages, request.Msg.GetAvailablePackageRef())
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&favorites.RemoveStarredPackageResponse{
+		StarredPackages: userFavorites.GetStarredPackages(),
+	}), nil
+}
+
+// AddRecentInstall records a package installed by the calling user, at the top
+// of the recent installs, keeping only the latest ones.
+func (s *favoritesServer) AddRecentInstall(ctx context.Context, request *connect.Request[favorites.AddRecentInstallRequest]) (*connect.Response[favorites.AddRecentInstallResponse], error) {
+	log.InfoS("+core AddRecentInstall", "identifier", request.Msg.GetRecentInstall().GetInstalledPackageRef().GetIdentifier())
+
+	recent := request.Msg.GetRecentInstall()
+	if err := validateInstalledPackageRef(recent.GetInstalledPackageRef()); err != nil {
+		return nil, err
+	}
+	recent = proto.Clone(recent).(*favorites.RecentInstall)
+	recent.Time = s.clock().UTC().Format(time.RFC3339)
+
+	userFavorites, err := s.updateFavorites(ctx, request.Header(), func(userFavorites *favorites.UserFavorites) error {
+		recentInstalls := append([]*favorites.RecentInstall{recent}, removeRecentInstall(userFavorites.RecentInstalls, recent.GetInstalledPackageRef())...)
+		if len(recentInstalls) > maxRecentInstalls {
+			recentInstalls = recentInstalls[:maxRecentInstalls]
+		}
+		userFavorites.RecentInstalls = recentInstalls
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&favorites.AddRecentInstallResponse{
+		RecentInstalls: userFavorites.GetRecentInstalls(),
+	}), nil
+}
+
+// RemoveRecentInstall removes an installed package from the recent installs
+// of the calling user, such as once it is deleted.
+func (s *favoritesServer) RemoveRecentInstall(ctx context.Context, request *connect.Request[favorites.RemoveRecentInstallRequest]) (*connect.Response[favorites.RemoveRecentInstallResponse], error) {
+	log.InfoS("+core RemoveRecentInstall", "identifier", request.Msg.GetInstalledPackageRef().GetIdentifier())
+
+	if err := validateInstalledPackageRef(request.Msg.GetInstalledPackageRef()); err != nil {
+		return nil, err
+	}
+
+	userFavorites, err := s.updateFavorites(ctx, request.Header(), func(userFavorites *favorites.UserFavorites) error {
+		userFavorites.RecentInstalls = removeRecentInstall(userFavorites.RecentInstalls, request.Msg.GetInstalledPackageRef())
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&favorites.RemoveRecentInstallResponse{
+		RecentInstalls: userFavorites.GetRecentInstalls(),
+	}), nil
+}
+
+// updateFavorites applies the change to the favorites stored for the calling
+// user and returns the resulting favorites. The ConfigMap of the user is
+// deleted once they have no favorites left.
+func (s *favoritesServer) updateFavorites(ctx context.Context, headers http.Header, change func(*favorites.UserFavorites) error) (*favorites.UserFavorites, error) {
+	key, err := authn.UserKey(ctx, s.clientSet, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	var userFavorites *favorites.UserFavorites
+	name := configMapName(key)
+	err = storage.Update(ctx, s.clientSet.CoreV1().ConfigMaps(s.namespace), "configmaps", name, func(cm *corev1.ConfigMap) (*corev1.ConfigMap, error) {
+		userFavorites, err = parseFavorites(cm)
+		if err != nil {
+			return nil, err
+		}
+		if err := change(userFavorites); err != nil {
+			return nil, err
+		}
+		if len(userFavorites.GetStarredPackages()) == 0 && len(userFavorites.GetRecentInstalls()) == 0 {
+			return nil, nil
+		}
+		value, err := protojson.Marshal(userFavorites)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to serialize the user favorites: %w", err))
+		}
+
+		if cm == nil {
+			cm = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: s.namespace,
+					Labels:    map[string]string{FavoritesLabel: "true"},
+				},
+			}
+		}
+		cm.Data = map[string]string{favoritesKey: string(value)}
+		return cm, nil
+	})
+	if err != nil {
+		// The errors of the change are returned as they are.
+		if connectErr := new(connect.Error); errors.As(err, &connectErr) {
+			return nil, connectErr
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to store the user favorites: %w", err))
+	}
+	return userFavorites, nil
+}
+
+// configMapName returns the name of the ConfigMap storing the favorites of
+// the user, identified by their key.
+func configMapName(user string) string {
+	return FavoritesConfigMapPrefix + user
+}
+
+// parseFavorites returns the favorites stored in the ConfigMap of a user,
+// which are empty if the ConfigMap does not exist yet.
+func parseFavorites(cm *corev1.ConfigMap) (*favorites.UserFavorites, error) {
+	userFavorites := &favorites.UserFavorites{}
+	if cm != nil && cm.Data[favoritesKey] != "" {
+		if err := protojson.Unmarshal([]byte(cm.Data[favoritesKey]), userFavorites); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to parse the stored user favorites: %w", err))
+		}
+	}
+	return userFavorites, nil
+}
+
+// removeStarredPackage returns the starred packages without the referenced one.
+func removeStarredPackage(starred []*favorites.StarredPackage, ref *packages.AvailablePackageReference) []*favorites.StarredPackage {
+	remaining := []*favorites.StarredPackage{}
+	for _, p := range starred {
+		if !proto.Equal(p.GetAvailablePackageRef(), ref) {
+			remaining = append(remaining, p)
+		}
+	}
+	return remaining
+}
+
+// removeRecentInstall returns the recent installs without the referenced one.
+func removeRecentInstall(recent []*favorites.RecentInstall, ref *packages.InstalledPackageReference) []*favorites.RecentInstall {
+	remaining := []*favorites.RecentInstall{}
+	for _, p := range recent {
+		if !proto.Equal(p.GetInstalledPackageRef(), ref) {
+			remaining = append(remaining, p)
+		}
+	}
+	return remaining
+}
+
+func validateAvailablePackageRef(ref *packages.AvailablePackageReference) error {
+	if ref.GetPlugin() == nil || ref.GetContext() == nil || ref.GetIdentifier() == "" {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Unable to update the user favorites: the available package requires a plugin, a context and an identifier"))
+	}
+	return nil
+}
+
+func validateInstalledPackageRef(ref *packages.InstalledPackageReference) error {
+	if ref.GetPlugin() == nil || ref.GetContext().GetNamespace() == "" || ref.GetIdentifier() == "" {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Unable to update the user favorites: the installed package requires a plugin, a namespace and an identifier"))
+	}
+	return nil
+}
@@ -0,0 +1,288 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/google/go-cmp/cmp"
+	favorites "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/favorites/v1alpha1"
+	packages "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1"
+	plugins "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/plugins/v1alpha1"
+	"google.golang.org/protobuf/testing/protocmp"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+var helmPlugin = &plugins.Plugin{Name: "helm.packages", Version: "v1alpha1"}
+
+// newTestFavoritesServer returns a server whose token reviews authenticate
+// the tokens in the users map as the corresponding username.
+func newTestFavoritesServer(t *testing.T, users map[string]string) (*favoritesServer, *fake.Clientset) {
+	clientSet := fake.NewSimpleClientset()
+	clientSet.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (handled bool, ret runtime.Object, err error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authenticationv1.TokenReview)
+		if username, ok := users[review.Spec.Token]; ok {
+			review.Status.Authenticated = true
+			review.Status.User.Username = username
+		} else {
+			review.Status.Error = "invalid token"
+		}
+		return true, review, nil
+	})
+	server, err := NewFavoritesServer(clientSet, "kubeapps")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	server.clock = func() time.Time { return time.Date(2023, 6, 3, 2, 0, 0, 0, time.UTC) }
+	return server, clientSet
+}
+
+func newRequestWithToken[T any](msg *T, token string) *connect.Request[T] {
+	request := connect.NewRequest(msg)
+	if token != "" {
+		request.Header().Set("Authorization", "Bearer "+token)
+	}
+	return request
+}
+
+func availableRef(identifier string) *packages.AvailablePackageReference {
+	return &packages.AvailablePackageReference{
+		Context:    &packages.Context{Cluster: "default", Namespace: "kubeapps"},
+		Identifier: identifier,
+		Plugin:     helmPlugin,
+	}
+}
+
+func installedRef(namespace, identifier string) *packages.InstalledPackageReference {
+	return &packages.InstalledPackageReference{
+		Context:    &packages.Context{Cluster: "default", Namespace: namespace},
+		Identifier: identifier,
+		Plugin:     helmPlugin,
+	}
+}
+
+func TestStarredPackages(t *testing.T) {
+	users := map[string]string{"token-a": "user-a", "token-b": "user-b"}
+	server, clientSet := newTestFavoritesServer(t, users)
+	ctx := context.Background()
+
+	star := func(token, identifier string) ([]*favorites.StarredPackage, error) {
+		response, err := server.AddStarredPackage(ctx, newRequestWithToken(&favorites.AddStarredPackageRequest{
+			StarredPackage: &favorites.StarredPackage{AvailablePackageRef: availableRef(identifier), DisplayName: identifier},
+		}, token))
+		if err != nil {
+			return nil, err
+		}
+		return response.Msg.GetStarredPackages(), nil
+	}
+	starred := func(identifier string) *favorites.StarredPackage {
+		return &favorites.StarredPackage{AvailablePackageRef: availableRef(identifier), DisplayName: identifier, Time: "2023-06-03T02:00:00Z"}
+	}
+
+	// The configmap is created on the first update and updated afterwards.
+	for _, identifier := range []string{"bitnami/apache", "bitnami/nginx", "bitnami/apache"} {
+		if _, err := star("token-a", identifier); err != nil {
+			t.Fatalf("%+v", err)
+		}
+	}
+	if _, err := star("token-b", "bitnami/redis"); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	// Each user has their own ConfigMap.
+	cms, err := clientSet.CoreV1().ConfigMaps("kubeapps").List(ctx, metav1.ListOptions{LabelSelector: FavoritesLabel})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if got, want := len(cms.Items), 2; got != want {
+		t.Errorf("got: %d, want: %d", got, want)
+	}
+
+	// A package starred again is moved to the top rather than duplicated.
+	response, err := server.GetUserFavorites(ctx, newRequestWithToken(&favorites.GetUserFavoritesRequest{}, "token-a"))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	want := &favorites.UserFavorites{StarredPackages: []*favorites.StarredPackage{starred("bitnami/apache"), starred("bitnami/nginx")}}
+	if got := response.Msg.GetFavorites(); !cmp.Equal(want, got, protocmp.Transform()) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, protocmp.Transform()))
+	}
+
+	// Unstarring a package, even if not starred, leaves the others.
+	for _, identifier := range []string{"bitnami/apache", "bitnami/unknown"} {
+		removed, err := server.RemoveStarredPackage(ctx, newRequestWithToken(&favorites.RemoveStarredPackageRequest{AvailablePackageRef: availableRef(identifier)}, "token-a"))
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		if got, want := removed.Msg.GetStarredPackages(), []*favorites.StarredPackage{starred("bitnami/nginx")}; !cmp.Equal(want, got, protocmp.Transform()) {
+			t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, protocmp.Transform()))
+		}
+	}
+
+	// The other users are not affected.
+	response, err = server.GetUserFavorites(ctx, newRequestWithToken(&favorites.GetUserFavoritesRequest{}, "token-b"))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	want = &favorites.UserFavorites{StarredPackages: []*favorites.StarredPackage{starred("bitnami/redis")}}
+	if got := response.Msg.GetFavorites(); !cmp.Equal(want, got, protocmp.Transform()) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, protocmp.Transform()))
+	}
+
+	// The starred packages are bounded.
+	for i := 1; i < maxStarredPackages; i++ {
+		if _, err := star("token-b", fmt.Sprintf("bitnami/chart-%d", i)); err != nil {
+			t.Fatalf("%+v", err)
+		}
+	}
+	if _, err := star("token-b", "bitnami/redis"); err != nil {
+		t.Errorf("got: %+v, want: nil", err)
+	}
+	if _, err := star("token-b", "bitnami/one-too-many"); connect.CodeOf(err) != connect.CodeResourceExhausted {
+		t.Errorf("got: %+v, want: %+v", connect.CodeOf(err), connect.CodeResourceExhausted)
+	}
+}
+
+func TestRecentInstalls(t *testing.T) {
+	server, _ := newTestFavoritesServer(t, map[string]string{"token-a": "user-a"})
+	ctx := context.Background()
+
+	var recentInstalls []*favorites.RecentInstall
+	for i := 0; i < maxRecentInstalls+2; i++ {
+		response, err := server.AddRecentInstall(ctx, newRequestWithToken(&favorites.AddRecentInstallRequest{
+			RecentInstall: &favorites.RecentInstall{
+				InstalledPackageRef: installedRef("team-a", fmt.Sprintf("my-apache-%d", i)),
+				AvailablePackageRef: availableRef("bitnami/apache"),
+				DisplayName:         "apache",
+			},
+		}, "token-a"))
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		recentInstalls = response.Msg.GetRecentInstalls()
+	}
+
+	// Only the latest installs are kept, the most recent first.
+	if got, want := len(recentInstalls), maxRecentInstalls; got != want {
+		t.Fatalf("got: %d, want: %d", got, want)
+	}
+	if got, want := recentInstalls[0].GetInstalledPackageRef().GetIdentifier(), fmt.Sprintf("my-apache-%d", maxRecentInstalls+1); got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+	if got, want := recentInstalls[0].GetTime(), "2023-06-03T02:00:00Z"; got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+
+	response, err := server.RemoveRecentInstall(ctx, newRequestWithToken(&favorites.RemoveRecentInstallRequest{
+		InstalledPackageRef: installedRef("team-a", fmt.Sprintf("my-apache-%d", maxRecentInstalls+1)),
+	}, "token-a"))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if got, want := len(response.Msg.GetRecentInstalls()), maxRecentInstalls-1; got != want {
+		t.Errorf("got: %d, want: %d", got, want)
+	}
+	if got, want := response.Msg.GetRecentInstalls()[0].GetInstalledPackageRef().GetIdentifier(), fmt.Sprintf("my-apache-%d", maxRecentInstalls); got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+}
+
+func TestFavoritesConfigMapDeletedOnceEmpty(t *testing.T) {
+	server, clientSet := newTestFavoritesServer(t, map[string]string{"token-a": "user-a"})
+	ctx := context.Background()
+	ref := installedRef("team-a", "my-apache")
+
+	if _, err := server.AddRecentInstall(ctx, newRequestWithToken(&favorites.AddRecentInstallRequest{
+		RecentInstall: &favorites.RecentInstall{InstalledPackageRef: ref, AvailablePackageRef: availableRef("bitnami/apache")},
+	}, "token-a")); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if _, err := server.RemoveRecentInstall(ctx, newRequestWithToken(&favorites.RemoveRecentInstallRequest{InstalledPackageRef: ref}, "token-a")); err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	cms, err := clientSet.CoreV1().ConfigMaps("kubeapps").List(ctx, metav1.ListOptions{LabelSelector: FavoritesLabel})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if got, want := len(cms.Items), 0; got != want {
+		t.Errorf("got: %d, want: %d", got, want)
+	}
+}
+
+func TestFavoritesErrors(t *testing.T) {
+	server, _ := newTestFavoritesServer(t, map[string]string{"token-a": "user-a"})
+	ctx := context.Background()
+
+	testCases := []struct {
+		name      string
+		call      func() error
+		errorCode connect.Code
+	}{
+		{
+			name: "returns unauthenticated if the token is missing",
+			call: func() error {
+				_, err := server.GetUserFavorites(ctx, newRequestWithToken(&favorites.GetUserFavoritesRequest{}, ""))
+				return err
+			},
+			errorCode: connect.CodeUnauthenticated,
+		},
+		{
+			name: "returns unauthenticated if the token cannot be reviewed",
+			call: func() error {
+				_, err := server.AddStarredPackage(ctx, newRequestWithToken(&favorites.AddStarredPackageRequest{
+					StarredPackage: &favorites.StarredPackage{AvailablePackageRef: availableRef("bitnami/apache")},
+				}, "token-unknown"))
+				return err
+			},
+			errorCode: connect.CodeUnauthenticated,
+		},
+		{
+			name: "returns invalid argument for a starred package without a plugin",
+			call: func() error {
+				_, err := server.AddStarredPackage(ctx, newRequestWithToken(&favorites.AddStarredPackageRequest{
+					StarredPackage: &favorites.StarredPackage{AvailablePackageRef: &packages.AvailablePackageReference{
+						Context:    &packages.Context{Cluster: "default", Namespace: "kubeapps"},
+						Identifier: "bitnami/apache",
+					}},
+				}, "token-a"))
+				return err
+			},
+			errorCode: connect.CodeInvalidArgument,
+		},
+		{
+			name: "returns invalid argument for a recent install without a namespace",
+			call: func() error {
+				_, err := server.AddRecentInstall(ctx, newRequestWithToken(&favorites.AddRecentInstallRequest{
+					RecentInstall: &favorites.RecentInstall{InstalledPackageRef: installedRef("", "my-apache")},
+				}, "token-a"))
+				return err
+			},
+			errorCode: connect.CodeInvalidArgument,
+		},
+		{
+			name: "returns invalid argument for removing a recent install without a reference",
+			call: func() error {
+				_, err := server.RemoveRecentInstall(ctx, newRequestWithToken(&favorites.RemoveRecentInstallRequest{}, "token-a"))
+				return err
+			},
+			errorCode: connect.CodeInvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got, want := connect.CodeOf(tc.call()), tc.errorCode; got != want {
+				t.Errorf("got: %+v, want: %+v", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,463 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package icons normalizes the icons of the available packages, which the
+// plugins return either inlined as data URLs or as external URLs, into a
+// full-size and a thumbnail variant, optionally proxying and caching the
+// external icons so that the browsers do not fetch them from third parties.
+// The images of the readmes are proxied the same way.
+package icons
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/disintegration/imaging"
+	packages "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/netrestrict"
+	log "k8s.io/klog/v2"
+)
+
+const (
+	// DefaultMaxBytes is the default maximum size of an icon.
+	DefaultMaxBytes = 1024 * 1024
+
+	// ThumbnailSize is the maximum width and height of the thumbnails.
+	ThumbnailSize = 64
+
+	// ProxyPath is the path, relative to the proxy prefix, at which the
+	// external icons are served.
+	ProxyPath = "/core/packages/v1alpha1/icons/"
+
+	// fetchTimeout is the maximum time spent fetching an external icon.
+	fetchTimeout = 10 * time.Second
+
+	// defaultCacheSize is the default number of icons kept in the cache.
+	defaultCacheSize = 512
+
+	svgMediaType = "image/svg+xml"
+)
+
+// Options configure the normalization of the icons.
+type Options struct {
+	// MaxBytes is the maximum size of an icon, the bigger icons being
+	// dropped. DefaultMaxBytes if 0.
+	MaxBytes int
+	// ProxyPrefix is the prefix of the URLs at which the kubeapps-apis
+	// service is reached by the browsers, such as "/apis". The external icons
+	// are returned as is if empty.
+	ProxyPrefix string
+	// CacheSize is the number of proxied icons kept in memory.
+	// defaultCacheSize if 0.
+	CacheSize int
+	// AllowedDomains are the domains, including their subdomains, of the
+	// external icons and readme images which are kept. The others are
+	// dropped rather than fetched, avoiding the timeouts on unreachable hosts
+	// of air-gapped installs. All the domains are allowed if empty.
+	AllowedDomains []string
+	// AllowedNetworks are the networks, in CIDR notation, from which the
+	// external icons and readme images can be fetched despite being blocked
+	// by default, such as the loopback addresses. The URLs of the icons are
+	// chosen by the authors of the packages, so the proxy cannot reach the
+	// addresses only exposed to the pod otherwise.
+	AllowedNetworks []string
+}
+
+// icon is the content of a normalized icon.
+type icon struct {
+	mediaType          string
+	data               []byte
+	thumbnailMediaType string
+	thumbnail          []byte
+}
+
+// Normalizer normalizes the icons of the available packages and serves the
+// external icons it proxies.
+type Normalizer struct {
+	maxBytes       int
+	proxyPrefix    string
+	allowedDomains []string
+	client         *http.Client
+
+	// signingKey signs the external URLs embedded in the proxy URLs, so that
+	// the proxy cannot be used to fetch arbitrary URLs: only the URLs of the
+	// icons and images returned by the plugins are signed.
+	signingKey []byte
+
+	mu    sync.Mutex
+	cache *lruCache
+}
+
+// NewNormalizer returns a normalizer of icons with the given options.
+func NewNormalizer(opts Options) (*Normalizer, error) {
+	if opts.MaxBytes <= 0 {
+		opts.MaxBytes = DefaultMaxBytes
+	}
+	if opts.CacheSize <= 0 {
+		opts.CacheSize = defaultCacheSize
+	}
+	allowedDomains := []string{}
+	for _, domain := range opts.AllowedDomains {
+		domain = strings.TrimPrefix(strings.TrimPrefix(strings.ToLower(strings.TrimSpace(domain)), "*"), ".")
+		if domain != "" {
+			allowedDomains = append(allowedDomains, domain)
+		}
+	}
+	restrictions, err := netrestrict.New(opts.AllowedNetworks)
+	if err != nil {
+		return nil, err
+	}
+	signingKey := make([]byte, 32)
+	if _, err := rand.Read(signingKey); err != nil {
+		return nil, fmt.Errorf("unable to generate the signing key of the proxied icons: %w", err)
+	}
+	return &Normalizer{
+		maxBytes:       opts.MaxBytes,
+		proxyPrefix:    strings.TrimSuffix(opts.ProxyPrefix, "/"),
+		allowedDomains: allowedDomains,
+		client:         restrictions.Client(&http.Client{Timeout: fetchTimeout}),
+		signingKey:     signingKey,
+		cache:          newLRUCache(opts.CacheSize),
+	}, nil
+}
+
+// ProxyEnabled returns whether the external icons are proxied.
+func (n *Normalizer) ProxyEnabled() bool {
+	return n.proxyPrefix != ""
+}
+
+// Normalize returns the variants of the icon at the given URL, or nil if the
+// icon is missing, or is inlined but not a supported image or too big. The
+// external icons are not fetched here but when the browsers request them from
+// the proxy, which checks them the same way.
+func (n *Normalizer) Normalize(iconURL string) *packages.PackageIcon {
+	switch {
+	case iconURL == "":
+		return nil
+	case strings.HasPrefix(iconURL, "data:"):
+		data, err := decodeDataURL(iconURL)
+		if err != nil {
+			log.V(4).Infof("Ignoring the invalid inline icon: %v", err)
+			return nil
+		}
+		i, err := n.newIcon(data)
+		if err != nil {
+			log.V(4).Infof("Ignoring the inline icon: %v", err)
+			return nil
+		}
+		return &packages.PackageIcon{
+			Url:          dataURL(i.mediaType, i.data),
+			ThumbnailUrl: dataURL(i.thumbnailMediaType, i.thumbnail),
+			MediaType:    i.mediaType,
+		}
+	}
+
+	if !n.allowed(iconURL) {
+		return nil
+	}
+	if !n.ProxyEnabled() {
+		return &packages.PackageIcon{
+			Url:          iconURL,
+			ThumbnailUrl: iconURL,
+		}
+	}
+
+	proxyURL := n.proxyURL(iconURL)
+	return &packages.PackageIcon{
+		Url:          proxyURL,
+		ThumbnailUrl: proxyURL + "?size=thumbnail",
+	}
+}
+
+var (
+	// markdownImageRegexp matches the markdown images with an external URL,
+	// such as ![alt](https://example.com/image.png "title").
+	markdownImageRegexp = regexp.MustCompile(`!\[([^\]]*)\]\(\s*(https?://[^\s)]+)((?:\s+"[^"]*")?\s*)\)`)
+	// htmlImageRegexp matches the HTML images with an external source.
+	htmlImageRegexp = regexp.MustCompile(`(?i)<img\b[^>]*?\bsrc\s*=\s*["'](https?://[^"']+)["'][^>]*>`)
+)
+
+// RewriteReadmeImages rewrites the external images of the readme, in markdown
+// or HTML, to be fetched through the proxy if enabled, and drops the images
+// of the domains which are not allowed, keeping their alternative text. The
+// proxied images are fetched when the browsers request them.
+func (n *Normalizer) RewriteReadmeImages(readme string) string {
+	if !n.ProxyEnabled() && len(n.allowedDomains) == 0 {
+		return readme
+	}
+	readme = markdownImageRegexp.ReplaceAllStringFunc(readme, func(image string) string {
+		match := markdownImageRegexp.FindStringSubmatch(image)
+		alt, imageURL, title := match[1], match[2], match[3]
+		if !n.allowed(imageURL) {
+			return alt
+		}
+		return fmt.Sprintf("![%s](%s%s)", alt, n.proxyURL(imageURL), title)
+	})
+	return htmlImageRegexp.ReplaceAllStringFunc(readme, func(image string) string {
+		imageURL := htmlImageRegexp.FindStringSubmatch(image)[1]
+		if !n.allowed(imageURL) {
+			return ""
+		}
+		return strings.Replace(image, imageURL, n.proxyURL(imageURL), 1)
+	})
+}
+
+// proxyURL returns the URL at which the external image is proxied, or the
+// image URL itself if the proxy is not enabled.
+func (n *Normalizer) proxyURL(imageURL string) string {
+	if !n.ProxyEnabled() {
+		return imageURL
+	}
+	return n.proxyPrefix + ProxyPath + n.proxyKey(imageURL)
+}
+
+// proxyKey returns the key at which the external URL is proxied, which embeds
+// the URL together with its signature.
+func (n *Normalizer) proxyKey(externalURL string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(externalURL)) + "." + n.sign(externalURL)
+}
+
+// externalURL returns the external URL embedded in the proxy key, failing if
+// it was not signed by this normalizer.
+func (n *Normalizer) externalURL(key string) (string, error) {
+	encodedURL, signature, ok := strings.Cut(key, ".")
+	if !ok {
+		return "", fmt.Errorf("missing signature in the icon key %q", key)
+	}
+	externalURL, err := base64.RawURLEncoding.DecodeString(encodedURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid icon key %q: %w", key, err)
+	}
+	if !hmac.Equal([]byte(signature), []byte(n.sign(string(externalURL)))) {
+		return "", fmt.Errorf("invalid signature in the icon key %q", key)
+	}
+	return string(externalURL), nil
+}
+
+func (n *Normalizer) sign(externalURL string) string {
+	mac := hmac.New(sha256.New, n.signingKey)
+	mac.Write([]byte(externalURL))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// allowed returns whether the external URL is a valid http(s) URL of one of
+// the allowed domains.
+func (n *Normalizer) allowed(externalURL string) bool {
+	u, err := url.Parse(externalURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return false
+	}
+	if len(n.allowedDomains) == 0 {
+		return true
+	}
+	host := strings.ToLower(u.Hostname())
+	for _, domain := range n.allowedDomains {
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// ServeHTTP serves the external icons previously returned by Normalize, at
+// ProxyPath followed by their signed key. The thumbnail is served when
+// the size query parameter is "thumbnail".
+func (n *Normalizer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	key := strings.TrimPrefix(r.URL.Path, ProxyPath)
+	i, err := n.get(r.Context(), key)
+	if err != nil {
+		log.V(4).Infof("Unable to serve the icon %q: %v", key, err)
+		http.NotFound(w, r)
+		return
+	}
+
+	mediaType, data := i.mediaType, i.data
+	if r.URL.Query().Get("size") == "thumbnail" {
+		mediaType, data = i.thumbnailMediaType, i.thumbnail
+	}
+	w.Header().Set("Content-Type", mediaType)
+	w.Header().Set("Content-Length", fmt.Sprint(len(data)))
+	w.Header().Set("Cache-Control", "public, max-age=86400")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	// The SVG icons may contain scripts, which must not run if the icon is
+	// opened directly.
+	w.Header().Set("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'; sandbox")
+	if r.Method == http.MethodGet {
+		if _, err := w.Write(data); err != nil {
+			log.Errorf("Failed to write the icon %q: %v", key, err)
+		}
+	}
+}
+
+// get returns the icon of the external URL with the given key, fetching it
+// if it is not cached.
+func (n *Normalizer) get(ctx context.Context, key string) (*icon, error) {
+	iconURL, err := n.externalURL(key)
+	if err != nil {
+		return nil, err
+	}
+	n.mu.Lock()
+	i, ok := n.cache.get(key)
+	n.mu.Unlock()
+	if ok {
+		return i, nil
+	}
+
+	i, err = n.fetch(ctx, iconURL)
+	if err != nil {
+		return nil, err
+	}
+	n.mu.Lock()
+	n.cache.add(key, i)
+	n.mu.Unlock()
+	return i, nil
+}
+
+// fetch retrieves and normalizes the icon at the given external URL.
+func (n *Normalizer) fetch(ctx context.Context, iconURL string) (*icon, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, iconURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := n.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", res.StatusCode)
+	}
+	// Read one more byte than allowed to detect the icons which are too big.
+	data, err := io.ReadAll(io.LimitReader(res.Body, int64(n.maxBytes)+1))
+	if err != nil {
+		return nil, err
+	}
+	return n.newIcon(data)
+}
+
+// newIcon sniffs the type of the icon and generates its thumbnail, failing if
+// it is too big or is not a supported image.
+func (n *Normalizer) newIcon(data []byte) (*icon, error) {
+	if len(data) > n.maxBytes {
+		return nil, fmt.Errorf("the icon is bigger than %d bytes", n.maxBytes)
+	}
+	mediaType := sniffMediaType(data)
+	if mediaType == svgMediaType {
+		// Vector icons scale without loss and are their own thumbnail.
+		return &icon{mediaType: mediaType, data: data, thumbnailMediaType: mediaType, thumbnail: data}, nil
+	}
+	if !strings.HasPrefix(mediaType, "image/") {
+		return nil, fmt.Errorf("unsupported media type %q", mediaType)
+	}
+
+	img, err := imaging.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode the %s icon: %w", mediaType, err)
+	}
+	bounds := img.Bounds()
+	if bounds.Dx() <= ThumbnailSize && bounds.Dy() <= ThumbnailSize {
+		return &icon{mediaType: mediaType, data: data, thumbnailMediaType: mediaType, thumbnail: data}, nil
+	}
+	var buf bytes.Buffer
+	if err := imaging.Encode(&buf, imaging.Fit(img, ThumbnailSize, ThumbnailSize, imaging.Lanczos), imaging.PNG); err != nil {
+		return nil, fmt.Errorf("unable to encode the thumbnail: %w", err)
+	}
+	return &icon{mediaType: mediaType, data: data, thumbnailMediaType: "image/png", thumbnail: buf.Bytes()}, nil
+}
+
+// sniffMediaType returns the media type of the content, detecting the SVG
+// images which http.DetectContentType reports as XML or text.
+func sniffMediaType(data []byte) string {
+	mediaType := http.DetectContentType(data)
+	if strings.HasPrefix(mediaType, "text/xml") || strings.HasPrefix(mediaType, "text/plain") {
+		head := data
+		if len(head) > 1024 {
+			head = head[:1024]
+		}
+		if bytes.Contains(head, []byte("<svg")) {
+			return svgMediaType
+		}
+	}
+	return mediaType
+}
+
+// decodeDataURL returns the content of a data URL, as defined by RFC 2397.
+func decodeDataURL(dataURL string) ([]byte, error) {
+	header, payload, ok := strings.Cut(strings.TrimPrefix(dataURL, "data:"), ",")
+	if !ok {
+		return nil, fmt.Errorf("missing data in the data URL")
+	}
+	if strings.HasSuffix(header, ";base64") {
+		return base64.StdEncoding.DecodeString(payload)
+	}
+	data, err := url.PathUnescape(payload)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(data), nil
+}
+
+func dataURL(mediaType string, data []byte) string {
+	return fmt.Sprintf("data:%s;base64,%s", mediaType, base64.StdEncoding.EncodeToString(data))
+}
+
+// lruCache is a cache of icons evicting the least recently used ones, which
+// is not safe for concurrent use.
+type lruCache struct {
+	size    int
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type lruEntry struct {
+	key  string
+	icon *icon
+}
+
+func newLRUCache(size int) *lruCache {
+	return &lruCache{
+		size:    size,
+		order:   list.New(),
+		entries: map[string]*list.Element{},
+	}
+}
+
+func (c *lruCache) get(key string) (*icon, bool) {
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+	return elem.Value.(*lruEntry).icon, true
+}
+
+func (c *lruCache) add(key string, i *icon) {
+	if elem, ok := c.entries[key]; ok {
+		elem.Value.(*lruEntry).icon = i
+		c.order.MoveToFront(elem)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&lruEntry{key: key, icon: i})
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruEntry).key)
+	}
+}
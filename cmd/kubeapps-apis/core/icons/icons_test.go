@@ -0,0 +1,393 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package icons
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/disintegration/imaging"
+	"github.com/google/go-cmp/cmp"
+	packages "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+const svgIcon = `<?xml version="1.0" encoding="UTF-8"?><svg xmlns="http://www.w3.org/2000/svg" width="10" height="10"></svg>`
+
+func makePNG(t *testing.T, width, height int) []byte {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for x := 0; x < width; x++ {
+		for y := 0; y < height; y++ {
+			img.Set(x, y, color.NRGBA{R: 255, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	return buf.Bytes()
+}
+
+func newNormalizer(t *testing.T, opts Options) *Normalizer {
+	normalizer, err := NewNormalizer(opts)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	return normalizer
+}
+
+func base64DataURL(mediaType string, data []byte) string {
+	return "data:" + mediaType + ";base64," + base64.StdEncoding.EncodeToString(data)
+}
+
+func TestNormalizeInlineIcons(t *testing.T) {
+	smallPNG := makePNG(t, 32, 32)
+	bigPNG := makePNG(t, 256, 128)
+
+	testCases := []struct {
+		name              string
+		maxBytes          int
+		iconURL           string
+		expectedIcon      *packages.PackageIcon
+		expectedThumbnail image.Point
+	}{
+		{
+			name:         "no icon",
+			iconURL:      "",
+			expectedIcon: nil,
+		},
+		{
+			name:    "svg icon is its own thumbnail",
+			iconURL: base64DataURL("image/svg+xml", []byte(svgIcon)),
+			expectedIcon: &packages.PackageIcon{
+				Url:          base64DataURL("image/svg+xml", []byte(svgIcon)),
+				ThumbnailUrl: base64DataURL("image/svg+xml", []byte(svgIcon)),
+				MediaType:    "image/svg+xml",
+			},
+		},
+		{
+			name:    "non-base64 svg icon",
+			iconURL: "data:image/svg+xml," + strings.ReplaceAll(svgIcon, " ", "%20"),
+			expectedIcon: &packages.PackageIcon{
+				Url:          base64DataURL("image/svg+xml", []byte(svgIcon)),
+				ThumbnailUrl: base64DataURL("image/svg+xml", []byte(svgIcon)),
+				MediaType:    "image/svg+xml",
+			},
+		},
+		{
+			name:    "media type is sniffed",
+			iconURL: base64DataURL("image/jpeg", smallPNG),
+			expectedIcon: &packages.PackageIcon{
+				Url:          base64DataURL("image/png", smallPNG),
+				ThumbnailUrl: base64DataURL("image/png", smallPNG),
+				MediaType:    "image/png",
+			},
+		},
+		{
+			name:              "big raster icon is downscaled",
+			iconURL:           base64DataURL("image/png", bigPNG),
+			expectedThumbnail: image.Pt(64, 32),
+		},
+		{
+			name:         "icon too big",
+			maxBytes:     len(smallPNG) - 1,
+			iconURL:      base64DataURL("image/png", smallPNG),
+			expectedIcon: nil,
+		},
+		{
+			name:         "not an image",
+			iconURL:      base64DataURL("image/png", []byte("not an image")),
+			expectedIcon: nil,
+		},
+		{
+			name:         "invalid data URL",
+			iconURL:      "data:image/png;base64,!!!",
+			expectedIcon: nil,
+		},
+		{
+			name:         "unsupported scheme",
+			iconURL:      "file:///etc/passwd",
+			expectedIcon: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			normalizer := newNormalizer(t, Options{MaxBytes: tc.maxBytes})
+
+			icon := normalizer.Normalize(tc.iconURL)
+
+			if tc.expectedThumbnail != (image.Point{}) {
+				if got, want := icon.GetUrl(), tc.iconURL; got != want {
+					t.Errorf("got: %q, want: %q", got, want)
+				}
+				data, err := decodeDataURL(icon.GetThumbnailUrl())
+				if err != nil {
+					t.Fatalf("%+v", err)
+				}
+				img, err := imaging.Decode(bytes.NewReader(data))
+				if err != nil {
+					t.Fatalf("%+v", err)
+				}
+				if got, want := img.Bounds().Size(), tc.expectedThumbnail; got != want {
+					t.Errorf("got: %v, want: %v", got, want)
+				}
+				return
+			}
+			if got, want := icon, tc.expectedIcon; !cmp.Equal(want, got, protocmp.Transform()) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, protocmp.Transform()))
+			}
+		})
+	}
+}
+
+func TestNormalizeExternalIcons(t *testing.T) {
+	bigPNG := makePNG(t, 128, 128)
+	fetches := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches++
+		switch r.URL.Path {
+		case "/icon.png":
+			if _, err := w.Write(bigPNG); err != nil {
+				t.Errorf("%+v", err)
+			}
+		case "/page.html":
+			if _, err := w.Write([]byte("<html><body>not an icon</body></html>")); err != nil {
+				t.Errorf("%+v", err)
+			}
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	iconURL := server.URL + "/icon.png"
+
+	t.Run("external icons are returned as is without proxy", func(t *testing.T) {
+		icon := newNormalizer(t, Options{}).Normalize(iconURL)
+
+		expected := &packages.PackageIcon{Url: iconURL, ThumbnailUrl: iconURL}
+		if got, want := icon, expected; !cmp.Equal(want, got, protocmp.Transform()) {
+			t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, protocmp.Transform()))
+		}
+		if got, want := fetches, 0; got != want {
+			t.Errorf("got: %d, want: %d", got, want)
+		}
+	})
+
+	normalizer := newNormalizer(t, Options{ProxyPrefix: "/apis/", AllowedNetworks: []string{"127.0.0.0/8"}})
+
+	t.Run("external icons are proxied without being fetched", func(t *testing.T) {
+		icon := normalizer.Normalize(iconURL)
+
+		proxyURL := "/apis" + ProxyPath + normalizer.proxyKey(iconURL)
+		expected := &packages.PackageIcon{
+			Url:          proxyURL,
+			ThumbnailUrl: proxyURL + "?size=thumbnail",
+		}
+		if got, want := icon, expected; !cmp.Equal(want, got, protocmp.Transform()) {
+			t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, protocmp.Transform()))
+		}
+		if got, want := fetches, 0; got != want {
+			t.Errorf("got: %d, want: %d", got, want)
+		}
+	})
+
+	t.Run("proxied icons are fetched once and served from the cache", func(t *testing.T) {
+		for _, size := range []string{"", "thumbnail"} {
+			req := httptest.NewRequest(http.MethodGet, ProxyPath+normalizer.proxyKey(iconURL)+"?size="+size, nil)
+			rec := httptest.NewRecorder()
+			normalizer.ServeHTTP(rec, req)
+
+			if got, want := rec.Code, http.StatusOK; got != want {
+				t.Fatalf("got: %d, want: %d", got, want)
+			}
+			if got, want := rec.Header().Get("Content-Type"), "image/png"; got != want {
+				t.Errorf("got: %q, want: %q", got, want)
+			}
+			if got, want := rec.Header().Get("X-Content-Type-Options"), "nosniff"; got != want {
+				t.Errorf("got: %q, want: %q", got, want)
+			}
+			body, err := io.ReadAll(rec.Body)
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if size == "" && !bytes.Equal(body, bigPNG) {
+				t.Errorf("got a different full-size icon")
+			}
+			if size == "thumbnail" && len(body) >= len(bigPNG) {
+				t.Errorf("got a thumbnail of %d bytes, want less than %d", len(body), len(bigPNG))
+			}
+		}
+		if got, want := fetches, 1; got != want {
+			t.Errorf("got: %d, want: %d", got, want)
+		}
+	})
+
+	t.Run("unknown icons are not proxied", func(t *testing.T) {
+		otherURL := server.URL + "/other.png"
+		keys := []string{
+			base64.RawURLEncoding.EncodeToString([]byte(otherURL)),
+			newNormalizer(t, Options{ProxyPrefix: "/apis/"}).proxyKey(otherURL),
+			strings.Replace(normalizer.proxyKey(iconURL), base64.RawURLEncoding.EncodeToString([]byte(iconURL)), base64.RawURLEncoding.EncodeToString([]byte(otherURL)), 1),
+		}
+		for _, key := range keys {
+			req := httptest.NewRequest(http.MethodGet, ProxyPath+key, nil)
+			rec := httptest.NewRecorder()
+			normalizer.ServeHTTP(rec, req)
+
+			if got, want := rec.Code, http.StatusNotFound; got != want {
+				t.Errorf("got: %d, want: %d", got, want)
+			}
+		}
+		if got, want := fetches, 1; got != want {
+			t.Errorf("got: %d, want: %d", got, want)
+		}
+	})
+
+	t.Run("external icons are not fetched from the blocked networks", func(t *testing.T) {
+		blockingNormalizer := newNormalizer(t, Options{ProxyPrefix: "/apis/"})
+		req := httptest.NewRequest(http.MethodGet, ProxyPath+blockingNormalizer.proxyKey(iconURL), nil)
+		rec := httptest.NewRecorder()
+		blockingNormalizer.ServeHTTP(rec, req)
+
+		if got, want := rec.Code, http.StatusNotFound; got != want {
+			t.Errorf("got: %d, want: %d", got, want)
+		}
+		if got, want := fetches, 1; got != want {
+			t.Errorf("got: %d, want: %d", got, want)
+		}
+	})
+
+	t.Run("external resources which are not images are not served", func(t *testing.T) {
+		for _, u := range []string{server.URL + "/page.html", server.URL + "/missing.png"} {
+			req := httptest.NewRequest(http.MethodGet, ProxyPath+normalizer.proxyKey(u), nil)
+			rec := httptest.NewRecorder()
+			normalizer.ServeHTTP(rec, req)
+
+			if got, want := rec.Code, http.StatusNotFound; got != want {
+				t.Errorf("%s: got: %d, want: %d", u, got, want)
+			}
+		}
+	})
+}
+
+func TestAllowedDomains(t *testing.T) {
+	normalizer := newNormalizer(t, Options{AllowedDomains: []string{"Example.com", "*.charts.internal", " "}})
+
+	testCases := []struct {
+		url     string
+		allowed bool
+	}{
+		{url: "https://example.com/icon.png", allowed: true},
+		{url: "https://assets.example.com/icon.png", allowed: true},
+		{url: "http://repo.charts.internal:8080/icon.png", allowed: true},
+		{url: "https://charts.internal/icon.png", allowed: true},
+		{url: "https://notexample.com/icon.png", allowed: false},
+		{url: "https://example.com.attacker.io/icon.png", allowed: false},
+		{url: "ftp://example.com/icon.png", allowed: false},
+	}
+	for _, tc := range testCases {
+		if got, want := normalizer.allowed(tc.url), tc.allowed; got != want {
+			t.Errorf("%s: got: %t, want: %t", tc.url, got, want)
+		}
+	}
+
+	t.Run("the external icons of other domains are dropped", func(t *testing.T) {
+		if got := normalizer.Normalize("https://notexample.com/icon.png"); got != nil {
+			t.Errorf("got: %+v, want: nil", got)
+		}
+		expected := &packages.PackageIcon{Url: "https://example.com/icon.png", ThumbnailUrl: "https://example.com/icon.png"}
+		if got, want := normalizer.Normalize("https://example.com/icon.png"), expected; !cmp.Equal(want, got, protocmp.Transform()) {
+			t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, protocmp.Transform()))
+		}
+	})
+}
+
+func TestNewNormalizerInvalidNetwork(t *testing.T) {
+	if _, err := NewNormalizer(Options{AllowedNetworks: []string{"127.0.0.1"}}); err == nil {
+		t.Errorf("got: nil, want: error")
+	}
+}
+
+func TestRewriteReadmeImages(t *testing.T) {
+	badge := makePNG(t, 16, 16)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := w.Write(badge); err != nil {
+			t.Errorf("%+v", err)
+		}
+	}))
+	defer server.Close()
+	imageURL := server.URL + "/badge.png"
+	readme := "# Apache\n\n" +
+		"![build](" + imageURL + " \"Build\") ![external](https://external.example.com/logo.png)\n\n" +
+		"<img alt=\"logo\" src=\"" + imageURL + "\" width=\"64\"> <IMG src='https://external.example.com/logo.png'>\n"
+
+	t.Run("the readme is returned as is without proxy nor allowed domains", func(t *testing.T) {
+		if got, want := newNormalizer(t, Options{}).RewriteReadmeImages(readme), readme; got != want {
+			t.Errorf("got: %q, want: %q", got, want)
+		}
+	})
+
+	t.Run("the images of other domains are dropped", func(t *testing.T) {
+		normalizer := newNormalizer(t, Options{AllowedDomains: []string{"127.0.0.1"}})
+
+		expected := "# Apache\n\n" +
+			"![build](" + imageURL + " \"Build\") external\n\n" +
+			"<img alt=\"logo\" src=\"" + imageURL + "\" width=\"64\"> \n"
+		if got, want := normalizer.RewriteReadmeImages(readme), expected; got != want {
+			t.Errorf("got: %q, want: %q", got, want)
+		}
+	})
+
+	t.Run("the images of the allowed domains are proxied", func(t *testing.T) {
+		normalizer := newNormalizer(t, Options{ProxyPrefix: "/apis", AllowedDomains: []string{"127.0.0.1"}, AllowedNetworks: []string{"127.0.0.0/8"}})
+
+		proxyURL := "/apis" + ProxyPath + normalizer.proxyKey(imageURL)
+		expected := "# Apache\n\n" +
+			"![build](" + proxyURL + " \"Build\") external\n\n" +
+			"<img alt=\"logo\" src=\"" + proxyURL + "\" width=\"64\"> \n"
+		if got, want := normalizer.RewriteReadmeImages(readme), expected; got != want {
+			t.Errorf("got: %q, want: %q", got, want)
+		}
+
+		req := httptest.NewRequest(http.MethodGet, ProxyPath+normalizer.proxyKey(imageURL), nil)
+		rec := httptest.NewRecorder()
+		normalizer.ServeHTTP(rec, req)
+		if got, want := rec.Code, http.StatusOK; got != want {
+			t.Fatalf("got: %d, want: %d", got, want)
+		}
+		if got, want := rec.Body.Bytes(), badge; !bytes.Equal(got, want) {
+			t.Errorf("got a different image")
+		}
+	})
+}
+
+func TestLRUCache(t *testing.T) {
+	cache := newLRUCache(2)
+	first, second, third := &icon{mediaType: "1"}, &icon{mediaType: "2"}, &icon{mediaType: "3"}
+
+	cache.add("first", first)
+	cache.add("second", second)
+	if _, ok := cache.get("first"); !ok {
+		t.Fatalf("got: false, want: true")
+	}
+	cache.add("third", third)
+
+	if _, ok := cache.get("second"); ok {
+		t.Errorf("got: true, want: false")
+	}
+	for key, want := range map[string]*icon{"first": first, "third": third} {
+		if got, ok := cache.get(key); !ok || got != want {
+			t.Errorf("got: %v, want: %v", got, want)
+		}
+	}
+}
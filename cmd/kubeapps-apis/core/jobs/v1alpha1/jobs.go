@@ -0,0 +1,216 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core/authn"
+	jobs "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/jobs/v1alpha1"
+	packages "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1"
+	packagesconnect "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1/v1connect"
+	"google.golang.org/protobuf/proto"
+	log "k8s.io/klog/v2"
+)
+
+// settleDelay is the time after the creation of a job during which an
+// installed status is not trusted to complete it, unless the job was first
+// seen pending or failed: right after an upgrade request, the installed
+// package may still be reported with the status of its previous revision.
+const settleDelay = 10 * time.Second
+
+// jobsServer implements the API defined in proto/kubeappsapis/core/jobs/v1alpha1/jobs.proto
+type jobsServer struct {
+	jobs.UnimplementedJobsServiceServer
+
+	// store records the jobs of the users, nil if the jobs are disabled.
+	store *Store
+
+	// packagesServer is used to refresh the state of the pending jobs, with
+	// the token of the user.
+	packagesServer packagesconnect.PackagesServiceHandler
+}
+
+// NewJobsServer returns a server of the jobs recorded in the store, whose
+// state is refreshed with the given packages server. The jobs are disabled if
+// the store is nil.
+func NewJobsServer(store *Store, packagesServer packagesconnect.PackagesServiceHandler) *jobsServer {
+	return &jobsServer{
+		store:          store,
+		packagesServer: packagesServer,
+	}
+}
+
+// ListJobs returns the jobs of the calling user, refreshing the pending ones.
+func (s *jobsServer) ListJobs(ctx context.Context, request *connect.Request[jobs.ListJobsRequest]) (*connect.Response[jobs.ListJobsResponse], error) {
+	log.InfoS("+core ListJobs", "pendingOnly", request.Msg.GetPendingOnly())
+
+	userJobs, err := s.refreshedJobs(ctx, request.Header())
+	if err != nil {
+		return nil, err
+	}
+	if request.Msg.GetPendingOnly() {
+		pending := []*jobs.Job{}
+		for _, job := range userJobs {
+			if job.GetState() == jobs.JobState_JOB_STATE_PENDING {
+				pending = append(pending, job)
+			}
+		}
+		userJobs = pending
+	}
+	return connect.NewResponse(&jobs.ListJobsResponse{
+		Jobs: userJobs,
+	}), nil
+}
+
+// GetJob returns a job of the calling user, refreshed if pending.
+func (s *jobsServer) GetJob(ctx context.Context, request *connect.Request[jobs.GetJobRequest]) (*connect.Response[jobs.GetJobResponse], error) {
+	log.InfoS("+core GetJob", "id", request.Msg.GetId())
+
+	userJobs, err := s.refreshedJobs(ctx, request.Header())
+	if err != nil {
+		return nil, err
+	}
+	for _, job := range userJobs {
+		if job.GetId() == request.Msg.GetId() {
+			return connect.NewResponse(&jobs.GetJobResponse{
+				Job: job,
+			}), nil
+		}
+	}
+	return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("Unable to find the job %q", request.Msg.GetId()))
+}
+
+// DeleteJob forgets a job of the calling user.
+func (s *jobsServer) DeleteJob(ctx context.Context, request *connect.Request[jobs.DeleteJobRequest]) (*connect.Response[jobs.DeleteJobResponse], error) {
+	log.InfoS("+core DeleteJob", "id", request.Msg.GetId())
+
+	if s.store == nil {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("The jobs are not enabled"))
+	}
+	user, err := authn.UserKey(ctx, s.store.clientSet, request.Header())
+	if err != nil {
+		return nil, err
+	}
+	_, err = s.store.update(ctx, user, func(userJobs *jobs.UserJobs) error {
+		remaining := []*jobs.Job{}
+		for _, job := range userJobs.Jobs {
+			if job.GetId() != request.Msg.GetId() {
+				remaining = append(remaining, job)
+			}
+		}
+		if len(remaining) == len(userJobs.Jobs) {
+			return connect.NewError(connect.CodeNotFound, fmt.Errorf("Unable to find the job %q", request.Msg.GetId()))
+		}
+		userJobs.Jobs = remaining
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&jobs.DeleteJobResponse{}), nil
+}
+
+// refreshedJobs returns the jobs of the calling user, refreshing those still
+// followed with their token and storing the changes.
+func (s *jobsServer) refreshedJobs(ctx context.Context, headers http.Header) ([]*jobs.Job, error) {
+	if s.store == nil {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("The jobs are not enabled"))
+	}
+	user, err := authn.UserKey(ctx, s.store.clientSet, headers)
+	if err != nil {
+		return nil, err
+	}
+	userJobs, err := s.store.list(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshed := map[string]*jobs.Job{}
+	for i, job := range userJobs {
+		if updated := s.refresh(ctx, headers, job); updated != nil {
+			userJobs[i] = updated
+			refreshed[updated.GetId()] = updated
+		}
+	}
+	if len(refreshed) == 0 {
+		return userJobs, nil
+	}
+	// The refreshed jobs replace the stored ones, unless they were forgotten
+	// in the meantime.
+	stored, err := s.store.update(ctx, user, func(stored *jobs.UserJobs) error {
+		for i, job := range stored.Jobs {
+			if updated, ok := refreshed[job.GetId()]; ok {
+				stored.Jobs[i] = updated
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return stored.GetJobs(), nil
+}
+
+// refresh returns the job updated with the operation status of its installed
+// package, or nil if it did not change. A failure to get the status is logged
+// and the job left unchanged.
+func (s *jobsServer) refresh(ctx context.Context, headers http.Header, job *jobs.Job) *jobs.Job {
+	now := s.store.now()
+	if !followed(job, now) {
+		if job.GetState() != jobs.JobState_JOB_STATE_PENDING {
+			return nil
+		}
+		updated := proto.Clone(job).(*jobs.Job)
+		updated.State = jobs.JobState_JOB_STATE_EXPIRED
+		updated.UpdateTime = now.UTC().Format(time.RFC3339)
+		return updated
+	}
+
+	statusRequest := connect.NewRequest(&packages.GetInstalledPackageOperationStatusRequest{
+		InstalledPackageRef: job.GetInstalledPackageRef(),
+	})
+	authn.CopyHeaders(statusRequest.Header(), headers)
+	statusResponse, err := s.packagesServer.GetInstalledPackageOperationStatus(ctx, statusRequest)
+	updated := proto.Clone(job).(*jobs.Job)
+	switch {
+	case connect.CodeOf(err) == connect.CodeNotFound:
+		// The installed package was deleted in the meantime.
+		updated.State = jobs.JobState_JOB_STATE_CANCELLED
+	case err != nil:
+		log.Errorf("Unable to refresh the job %q of the installed package %q: %v", job.GetId(), job.GetInstalledPackageRef().GetIdentifier(), err)
+		return nil
+	default:
+		status := statusResponse.Msg.GetStatus()
+		updated.Status = status
+		updated.Phases = statusResponse.Msg.GetPhases()
+		switch status.GetReason() {
+		case packages.InstalledPackageStatus_STATUS_REASON_INSTALLED:
+			if settled(job, now) {
+				updated.State = jobs.JobState_JOB_STATE_SUCCEEDED
+			}
+		case packages.InstalledPackageStatus_STATUS_REASON_FAILED:
+			updated.State = jobs.JobState_JOB_STATE_FAILED
+		default:
+			updated.State = jobs.JobState_JOB_STATE_PENDING
+		}
+	}
+	if proto.Equal(updated, job) {
+		return nil
+	}
+	updated.UpdateTime = now.UTC().Format(time.RFC3339)
+	return updated
+}
+
+// settled returns whether the success reported for the job can be trusted:
+// once it was observed in progress or failed, or after the settle delay.
+func settled(job *jobs.Job, now time.Time) bool {
+	return job.GetStatus().GetReason() == packages.InstalledPackageStatus_STATUS_REASON_PENDING ||
+		job.GetState() == jobs.JobState_JOB_STATE_FAILED ||
+		now.Sub(parseTime(job.GetCreateTime())) >= settleDelay
+}
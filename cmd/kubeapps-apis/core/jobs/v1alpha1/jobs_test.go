@@ -0,0 +1,486 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core/authn"
+	notifications "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core/notifications/v1alpha1"
+	jobs "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/jobs/v1alpha1"
+	packages "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1"
+	packagesconnect "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1/v1connect"
+	plugins "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/plugins/v1alpha1"
+	"google.golang.org/protobuf/testing/protocmp"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+const testNamespace = "kubeapps"
+
+var kappPlugin = &plugins.Plugin{Name: "kapp_controller.packages", Version: "v1alpha1"}
+
+// newTestClientSet returns a client set whose token reviews authenticate the
+// "token-a" and "token-b" tokens as "user-a" and "user-b".
+func newTestClientSet() *fake.Clientset {
+	clientSet := fake.NewSimpleClientset()
+	clientSet.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (handled bool, ret runtime.Object, err error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authenticationv1.TokenReview)
+		if review.Spec.Token == "token-a" || review.Spec.Token == "token-b" {
+			review.Status.Authenticated = true
+			review.Status.User.Username = "user-" + strings.TrimPrefix(review.Spec.Token, "token-")
+		} else {
+			review.Status.Error = "invalid token"
+		}
+		return true, review, nil
+	})
+	return clientSet
+}
+
+func newTestStore(t *testing.T, clientSet *fake.Clientset, now *time.Time) *Store {
+	store, err := NewStore(clientSet, testNamespace, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	store.now = func() time.Time { return *now }
+	return store
+}
+
+func userHeaders(token string) http.Header {
+	headers := http.Header{}
+	headers.Set("Authorization", "Bearer "+token)
+	return headers
+}
+
+func installedRef(identifier string) *packages.InstalledPackageReference {
+	return &packages.InstalledPackageReference{
+		Context:    &packages.Context{Cluster: "default", Namespace: "apps"},
+		Identifier: identifier,
+		Plugin:     kappPlugin,
+	}
+}
+
+// fakePackagesServer returns the operation status of the installed packages
+// by identifier, as set by the test.
+type fakePackagesServer struct {
+	packagesconnect.UnimplementedPackagesServiceHandler
+	statuses map[string]*packages.GetInstalledPackageOperationStatusResponse
+}
+
+func (s *fakePackagesServer) GetInstalledPackageOperationStatus(ctx context.Context, request *connect.Request[packages.GetInstalledPackageOperationStatusRequest]) (*connect.Response[packages.GetInstalledPackageOperationStatusResponse], error) {
+	status, ok := s.statuses[request.Msg.GetInstalledPackageRef().GetIdentifier()]
+	if !ok {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("not found"))
+	}
+	return connect.NewResponse(status), nil
+}
+
+func TestStoreRecord(t *testing.T) {
+	now := time.Date(2023, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name         string
+		operation    notifications.RecordedOperation
+		expectedJobs []*jobs.Job
+	}{
+		{
+			name:      "it records an install",
+			operation: notifications.RecordedOperation{Kind: notifications.OperationInstall, InstalledPackageRef: installedRef("my-apache"), StartedAt: now},
+			expectedJobs: []*jobs.Job{{
+				Type:                jobs.JobType_JOB_TYPE_INSTALL,
+				InstalledPackageRef: installedRef("my-apache"),
+				State:               jobs.JobState_JOB_STATE_PENDING,
+				CreateTime:          "2023-06-01T10:00:00Z",
+				UpdateTime:          "2023-06-01T10:00:00Z",
+			}},
+		},
+		{
+			name:      "it records an upgrade",
+			operation: notifications.RecordedOperation{Kind: notifications.OperationUpgrade, InstalledPackageRef: installedRef("my-apache"), StartedAt: now},
+			expectedJobs: []*jobs.Job{{
+				Type:                jobs.JobType_JOB_TYPE_UPGRADE,
+				InstalledPackageRef: installedRef("my-apache"),
+				State:               jobs.JobState_JOB_STATE_PENDING,
+				CreateTime:          "2023-06-01T10:00:00Z",
+				UpdateTime:          "2023-06-01T10:00:00Z",
+			}},
+		},
+		{
+			name: "it does not record the sync of a repository",
+			operation: notifications.RecordedOperation{Kind: notifications.OperationRepositorySync, PackageRepoRef: &packages.PackageRepositoryReference{
+				Context:    &packages.Context{Cluster: "default", Namespace: "apps"},
+				Identifier: "bitnami",
+				Plugin:     kappPlugin,
+			}, StartedAt: now},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			clientSet := newTestClientSet()
+			store := newTestStore(t, clientSet, &now)
+			user, err := authn.UserKey(context.Background(), clientSet, userHeaders("token-a"))
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+
+			store.Record(context.Background(), user, tc.operation)
+
+			// The jobs are read again from the ConfigMap, as after a restart.
+			restarted := newTestStore(t, clientSet, &now)
+			storedJobs, err := restarted.list(context.Background(), user)
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			opts := []cmp.Option{protocmp.Transform(), protocmp.IgnoreFields(&jobs.Job{}, "id"), cmpopts.EquateEmpty()}
+			if got, want := storedJobs, tc.expectedJobs; !cmp.Equal(want, got, opts...) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, opts...))
+			}
+			for _, job := range storedJobs {
+				if job.GetId() == "" {
+					t.Errorf("the job %+v has no identifier", job)
+				}
+			}
+		})
+	}
+}
+
+func TestStoreUserConfigMaps(t *testing.T) {
+	now := time.Date(2023, 6, 1, 10, 0, 0, 0, time.UTC)
+	clientSet := newTestClientSet()
+	store := newTestStore(t, clientSet, &now)
+	ctx := context.Background()
+
+	jobA := &jobs.Job{Id: "1", InstalledPackageRef: installedRef("my-apache"), State: jobs.JobState_JOB_STATE_PENDING, CreateTime: "2023-06-01T10:00:00Z", UpdateTime: "2023-06-01T10:00:00Z"}
+	jobB := &jobs.Job{Id: "2", InstalledPackageRef: installedRef("my-nginx"), State: jobs.JobState_JOB_STATE_PENDING, CreateTime: "2023-06-01T10:00:00Z", UpdateTime: "2023-06-01T10:00:00Z"}
+	if err := store.record(ctx, "user-a", jobA); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if err := store.record(ctx, "user-b", jobB); err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	// The jobs of each user are stored in their own labelled ConfigMap.
+	for user, job := range map[string]*jobs.Job{"user-a": jobA, "user-b": jobB} {
+		cm, err := clientSet.CoreV1().ConfigMaps(testNamespace).Get(ctx, JobsConfigMapPrefix+user, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		if got, want := cm.Labels[JobsLabel], "true"; got != want {
+			t.Errorf("got: %q, want: %q", got, want)
+		}
+		storedJobs, err := store.list(ctx, user)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		if got, want := storedJobs, []*jobs.Job{job}; !cmp.Equal(want, got, protocmp.Transform()) {
+			t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, protocmp.Transform()))
+		}
+	}
+
+	// The ConfigMap of a user is deleted once they have no jobs left.
+	_, err := store.update(ctx, "user-a", func(userJobs *jobs.UserJobs) error {
+		userJobs.Jobs = nil
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	_, err = clientSet.CoreV1().ConfigMaps(testNamespace).Get(ctx, JobsConfigMapPrefix+"user-a", metav1.GetOptions{})
+	if !k8serrors.IsNotFound(err) {
+		t.Errorf("got: %+v, want: not found", err)
+	}
+	if _, err := clientSet.CoreV1().ConfigMaps(testNamespace).Get(ctx, JobsConfigMapPrefix+"user-b", metav1.GetOptions{}); err != nil {
+		t.Errorf("%+v", err)
+	}
+}
+
+func TestStoreRecordReplacesJobs(t *testing.T) {
+	now := time.Date(2023, 6, 1, 10, 0, 0, 0, time.UTC)
+	store := newTestStore(t, newTestClientSet(), &now)
+	ctx := context.Background()
+
+	install := &jobs.Job{Id: "1", InstalledPackageRef: installedRef("my-apache"), State: jobs.JobState_JOB_STATE_SUCCEEDED, CreateTime: "2023-06-01T09:00:00Z", UpdateTime: "2023-06-01T09:01:00Z"}
+	other := &jobs.Job{Id: "2", InstalledPackageRef: installedRef("my-nginx"), State: jobs.JobState_JOB_STATE_PENDING, CreateTime: "2023-06-01T09:30:00Z", UpdateTime: "2023-06-01T09:30:00Z"}
+	for _, job := range []*jobs.Job{install, other} {
+		if err := store.record(ctx, "user-a", job); err != nil {
+			t.Fatalf("%+v", err)
+		}
+	}
+
+	// A new job on the same installed package replaces the previous one.
+	upgrade := &jobs.Job{Id: "3", InstalledPackageRef: installedRef("my-apache"), State: jobs.JobState_JOB_STATE_PENDING, CreateTime: "2023-06-01T10:00:00Z", UpdateTime: "2023-06-01T10:00:00Z"}
+	if err := store.record(ctx, "user-a", upgrade); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	storedJobs, err := store.list(ctx, "user-a")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if got, want := storedJobs, []*jobs.Job{upgrade, other}; !cmp.Equal(want, got, protocmp.Transform()) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, protocmp.Transform()))
+	}
+
+	// The jobs no longer followed are dropped after the retention.
+	now = now.Add(25 * time.Hour)
+	storedJobs, err = store.list(ctx, "user-a")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if len(storedJobs) != 0 {
+		t.Errorf("got: %+v, want: no jobs", storedJobs)
+	}
+}
+
+func TestJobsServerRefresh(t *testing.T) {
+	created := time.Date(2023, 6, 1, 10, 0, 0, 0, time.UTC)
+	pendingStatus := &packages.InstalledPackageStatus{Reason: packages.InstalledPackageStatus_STATUS_REASON_PENDING, UserReason: "Deploying"}
+	installedStatus := &packages.InstalledPackageStatus{Ready: true, Reason: packages.InstalledPackageStatus_STATUS_REASON_INSTALLED}
+	failedStatus := &packages.InstalledPackageStatus{Reason: packages.InstalledPackageStatus_STATUS_REASON_FAILED, UserReason: "Deploy failed"}
+	deployPhases := []*packages.InstalledPackageOperationPhase{
+		{Name: "fetch", State: packages.InstalledPackageOperationPhase_PHASE_STATE_SUCCEEDED},
+		{Name: "deploy", State: packages.InstalledPackageOperationPhase_PHASE_STATE_IN_PROGRESS},
+	}
+
+	testCases := []struct {
+		name          string
+		job           *jobs.Job
+		elapsed       time.Duration
+		status        *packages.GetInstalledPackageOperationStatusResponse
+		expectedState jobs.JobState
+		expectedJob   *jobs.Job
+	}{
+		{
+			name:    "it records the progress of a pending job",
+			job:     &jobs.Job{State: jobs.JobState_JOB_STATE_PENDING},
+			elapsed: time.Minute,
+			status:  &packages.GetInstalledPackageOperationStatusResponse{Status: pendingStatus, Phases: deployPhases},
+			expectedJob: &jobs.Job{
+				State:      jobs.JobState_JOB_STATE_PENDING,
+				Status:     pendingStatus,
+				Phases:     deployPhases,
+				UpdateTime: "2023-06-01T10:01:00Z",
+			},
+		},
+		{
+			name:    "it succeeds a job observed in progress",
+			job:     &jobs.Job{State: jobs.JobState_JOB_STATE_PENDING, Status: pendingStatus},
+			elapsed: 5 * time.Second,
+			status:  &packages.GetInstalledPackageOperationStatusResponse{Status: installedStatus},
+			expectedJob: &jobs.Job{
+				State:      jobs.JobState_JOB_STATE_SUCCEEDED,
+				Status:     installedStatus,
+				UpdateTime: "2023-06-01T10:00:05Z",
+			},
+		},
+		{
+			name:    "it keeps pending a job reported as succeeded before the settle delay",
+			job:     &jobs.Job{State: jobs.JobState_JOB_STATE_PENDING},
+			elapsed: 5 * time.Second,
+			status:  &packages.GetInstalledPackageOperationStatusResponse{Status: installedStatus},
+			expectedJob: &jobs.Job{
+				State:      jobs.JobState_JOB_STATE_PENDING,
+				Status:     installedStatus,
+				UpdateTime: "2023-06-01T10:00:05Z",
+			},
+		},
+		{
+			name:    "it fails a job whose reconciliation failed",
+			job:     &jobs.Job{State: jobs.JobState_JOB_STATE_PENDING, Status: pendingStatus},
+			elapsed: time.Minute,
+			status:  &packages.GetInstalledPackageOperationStatusResponse{Status: failedStatus},
+			expectedJob: &jobs.Job{
+				State:      jobs.JobState_JOB_STATE_FAILED,
+				Status:     failedStatus,
+				UpdateTime: "2023-06-01T10:01:00Z",
+			},
+		},
+		{
+			name:    "it cancels a job whose installed package was deleted",
+			job:     &jobs.Job{State: jobs.JobState_JOB_STATE_PENDING, Status: pendingStatus},
+			elapsed: time.Minute,
+			expectedJob: &jobs.Job{
+				State:      jobs.JobState_JOB_STATE_CANCELLED,
+				Status:     pendingStatus,
+				UpdateTime: "2023-06-01T10:01:00Z",
+			},
+		},
+		{
+			name:    "it expires a job still pending after the timeout",
+			job:     &jobs.Job{State: jobs.JobState_JOB_STATE_PENDING, Status: pendingStatus},
+			elapsed: jobTimeout,
+			status:  &packages.GetInstalledPackageOperationStatusResponse{Status: pendingStatus},
+			expectedJob: &jobs.Job{
+				State:      jobs.JobState_JOB_STATE_EXPIRED,
+				Status:     pendingStatus,
+				UpdateTime: "2023-06-01T11:00:00Z",
+			},
+		},
+		{
+			name:    "it does not refresh a finished job",
+			job:     &jobs.Job{State: jobs.JobState_JOB_STATE_SUCCEEDED, Status: installedStatus, UpdateTime: "2023-06-01T10:00:30Z"},
+			elapsed: time.Minute,
+			status:  &packages.GetInstalledPackageOperationStatusResponse{Status: failedStatus},
+			expectedJob: &jobs.Job{
+				State:      jobs.JobState_JOB_STATE_SUCCEEDED,
+				Status:     installedStatus,
+				UpdateTime: "2023-06-01T10:00:30Z",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			now := created
+			store := newTestStore(t, newTestClientSet(), &now)
+			packagesServer := &fakePackagesServer{statuses: map[string]*packages.GetInstalledPackageOperationStatusResponse{}}
+			if tc.status != nil {
+				packagesServer.statuses["my-apache"] = tc.status
+			}
+			server := NewJobsServer(store, packagesServer)
+
+			tc.job.Id = "1"
+			tc.job.Type = jobs.JobType_JOB_TYPE_UPGRADE
+			tc.job.InstalledPackageRef = installedRef("my-apache")
+			tc.job.CreateTime = created.Format(time.RFC3339)
+			if tc.job.UpdateTime == "" {
+				tc.job.UpdateTime = tc.job.CreateTime
+			}
+			user, err := authn.UserKey(context.Background(), store.clientSet, userHeaders("token-a"))
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if err := store.record(context.Background(), user, tc.job); err != nil {
+				t.Fatalf("%+v", err)
+			}
+
+			now = created.Add(tc.elapsed)
+			request := connect.NewRequest(&jobs.GetJobRequest{Id: "1"})
+			request.Header().Set("Authorization", "Bearer token-a")
+			response, err := server.GetJob(context.Background(), request)
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+
+			tc.expectedJob.Id = "1"
+			tc.expectedJob.Type = jobs.JobType_JOB_TYPE_UPGRADE
+			tc.expectedJob.InstalledPackageRef = installedRef("my-apache")
+			tc.expectedJob.CreateTime = tc.job.CreateTime
+			if got, want := response.Msg.GetJob(), tc.expectedJob; !cmp.Equal(want, got, protocmp.Transform()) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, protocmp.Transform()))
+			}
+			// The refreshed job is stored, so that it is kept after a restart.
+			storedJobs, err := store.list(context.Background(), user)
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if got, want := storedJobs, []*jobs.Job{tc.expectedJob}; !cmp.Equal(want, got, protocmp.Transform()) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, protocmp.Transform()))
+			}
+		})
+	}
+}
+
+func TestJobsServer(t *testing.T) {
+	now := time.Date(2023, 6, 1, 10, 0, 0, 0, time.UTC)
+	store := newTestStore(t, newTestClientSet(), &now)
+	packagesServer := &fakePackagesServer{statuses: map[string]*packages.GetInstalledPackageOperationStatusResponse{
+		"my-apache": {Status: &packages.InstalledPackageStatus{Reason: packages.InstalledPackageStatus_STATUS_REASON_PENDING}},
+	}}
+	server := NewJobsServer(store, packagesServer)
+	ctx := context.Background()
+	user, err := authn.UserKey(ctx, store.clientSet, userHeaders("token-a"))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	for _, job := range []*jobs.Job{
+		{Id: "1", InstalledPackageRef: installedRef("my-nginx"), State: jobs.JobState_JOB_STATE_SUCCEEDED, CreateTime: "2023-06-01T09:00:00Z", UpdateTime: "2023-06-01T09:01:00Z"},
+		{Id: "2", InstalledPackageRef: installedRef("my-apache"), State: jobs.JobState_JOB_STATE_PENDING, CreateTime: "2023-06-01T09:59:00Z", UpdateTime: "2023-06-01T09:59:00Z"},
+	} {
+		if err := store.record(ctx, user, job); err != nil {
+			t.Fatalf("%+v", err)
+		}
+	}
+
+	t.Run("it lists the jobs of the user, the most recent first", func(t *testing.T) {
+		request := connect.NewRequest(&jobs.ListJobsRequest{})
+		request.Header().Set("Authorization", "Bearer token-a")
+		response, err := server.ListJobs(ctx, request)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		ids := []string{}
+		for _, job := range response.Msg.GetJobs() {
+			ids = append(ids, job.GetId())
+		}
+		if got, want := ids, []string{"2", "1"}; !cmp.Equal(want, got) {
+			t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+		}
+	})
+
+	t.Run("it lists the pending jobs only", func(t *testing.T) {
+		request := connect.NewRequest(&jobs.ListJobsRequest{PendingOnly: true})
+		request.Header().Set("Authorization", "Bearer token-a")
+		response, err := server.ListJobs(ctx, request)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		if got, want := len(response.Msg.GetJobs()), 1; got != want {
+			t.Errorf("got: %d, want: %d", got, want)
+		}
+	})
+
+	t.Run("it does not return the jobs of an unidentified user", func(t *testing.T) {
+		request := connect.NewRequest(&jobs.ListJobsRequest{})
+		request.Header().Set("Authorization", "Bearer unknown-token")
+		_, err := server.ListJobs(ctx, request)
+		if got, want := connect.CodeOf(err), connect.CodeUnauthenticated; got != want {
+			t.Errorf("got: %s, want: %s", got, want)
+		}
+	})
+
+	t.Run("it deletes a job", func(t *testing.T) {
+		request := connect.NewRequest(&jobs.DeleteJobRequest{Id: "1"})
+		request.Header().Set("Authorization", "Bearer token-a")
+		if _, err := server.DeleteJob(ctx, request); err != nil {
+			t.Fatalf("%+v", err)
+		}
+		getRequest := connect.NewRequest(&jobs.GetJobRequest{Id: "1"})
+		getRequest.Header().Set("Authorization", "Bearer token-a")
+		_, err := server.GetJob(ctx, getRequest)
+		if got, want := connect.CodeOf(err), connect.CodeNotFound; got != want {
+			t.Errorf("got: %s, want: %s", got, want)
+		}
+	})
+
+	t.Run("it returns not found when deleting an unknown job", func(t *testing.T) {
+		request := connect.NewRequest(&jobs.DeleteJobRequest{Id: "unknown"})
+		request.Header().Set("Authorization", "Bearer token-a")
+		_, err := server.DeleteJob(ctx, request)
+		if got, want := connect.CodeOf(err), connect.CodeNotFound; got != want {
+			t.Errorf("got: %s, want: %s", got, want)
+		}
+	})
+
+	t.Run("it fails when the jobs are disabled", func(t *testing.T) {
+		request := connect.NewRequest(&jobs.ListJobsRequest{})
+		request.Header().Set("Authorization", "Bearer token-a")
+		_, err := NewJobsServer(nil, packagesServer).ListJobs(ctx, request)
+		if got, want := connect.CodeOf(err), connect.CodeFailedPrecondition; got != want {
+			t.Errorf("got: %s, want: %s", got, want)
+		}
+	})
+}
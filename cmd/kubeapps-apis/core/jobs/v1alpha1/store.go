@@ -0,0 +1,261 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bufbuild/connect-go"
+	notifications "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core/notifications/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core/storage"
+	jobs "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/jobs/v1alpha1"
+	packages "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	log "k8s.io/klog/v2"
+)
+
+const (
+	// JobsConfigMapPrefix is the prefix of the names of the ConfigMaps, in
+	// the Kubeapps namespace, in which the jobs of each user are stored,
+	// followed by the key of the user.
+	JobsConfigMapPrefix = "kubeapps-user-jobs-"
+
+	// JobsLabel is the label identifying the ConfigMaps which store the jobs
+	// of a user.
+	JobsLabel = "kubeapps.dev/user-jobs"
+
+	// jobsKey is the ConfigMap key under which the jobs are stored.
+	jobsKey = "jobs"
+
+	// maxJobs bounds the jobs kept for each user.
+	maxJobs = 20
+
+	// jobTimeout is the time after which a job is no longer followed, a
+	// pending job being then expired.
+	jobTimeout = time.Hour
+)
+
+// Store records the jobs of each user in their own ConfigMap, so that they
+// are kept across restarts and shared by the replicas, and that the updates
+// of the jobs of distinct users do not conflict.
+type Store struct {
+	// clientSet reads and writes the jobs ConfigMaps and identifies the users
+	// listing their jobs. A user only ever sees their jobs through this
+	// service, not through the ConfigMaps.
+	clientSet kubernetes.Interface
+
+	// namespace in which the jobs ConfigMaps are stored.
+	namespace string
+
+	// retention is the time during which a job no longer followed is kept.
+	retention time.Duration
+
+	now func() time.Time
+}
+
+// NewStore returns a store of the jobs in the given namespace, keeping them
+// for the given retention once they are no longer followed.
+func NewStore(clientSet kubernetes.Interface, namespace string, retention time.Duration) (*Store, error) {
+	if namespace == "" {
+		return nil, fmt.Errorf("a namespace is required to store the user jobs")
+	}
+	return &Store{
+		clientSet: clientSet,
+		namespace: namespace,
+		retention: retention,
+		now:       time.Now,
+	}, nil
+}
+
+// Record records the pending job of an install or upgrade started by the
+// user. It is registered as a listener of the notifications tracker, which
+// identifies the user of the requests starting the operations and records
+// them once the responses are returned, so a failure to record the job is
+// only logged.
+func (s *Store) Record(ctx context.Context, user string, op notifications.RecordedOperation) {
+	var jobType jobs.JobType
+	switch op.Kind {
+	case notifications.OperationInstall:
+		jobType = jobs.JobType_JOB_TYPE_INSTALL
+	case notifications.OperationUpgrade:
+		jobType = jobs.JobType_JOB_TYPE_UPGRADE
+	default:
+		return
+	}
+
+	job, err := s.newJob(jobType, op.InstalledPackageRef)
+	if err != nil {
+		log.Errorf("Unable to build the job of the installed package %q: %v", op.InstalledPackageRef.GetIdentifier(), err)
+		return
+	}
+	if err := s.record(ctx, user, job); err != nil {
+		log.Errorf("Unable to record the job of the installed package %q: %v", op.InstalledPackageRef.GetIdentifier(), err)
+	}
+}
+
+// newJob returns the pending job of an operation on the installed package.
+func (s *Store) newJob(jobType jobs.JobType, ref *packages.InstalledPackageReference) (*jobs.Job, error) {
+	id, err := newJobId()
+	if err != nil {
+		return nil, err
+	}
+	now := s.now().UTC().Format(time.RFC3339)
+	return &jobs.Job{
+		Id:                  id,
+		Type:                jobType,
+		InstalledPackageRef: ref,
+		State:               jobs.JobState_JOB_STATE_PENDING,
+		CreateTime:          now,
+		UpdateTime:          now,
+	}, nil
+}
+
+// newJobId returns a random identifier for a job.
+func newJobId() (string, error) {
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(id), nil
+}
+
+// record records the job of the user, replacing any previous job on the same
+// installed package.
+func (s *Store) record(ctx context.Context, user string, job *jobs.Job) error {
+	_, err := s.update(ctx, user, func(userJobs *jobs.UserJobs) error {
+		remaining := []*jobs.Job{job}
+		for _, existing := range userJobs.Jobs {
+			if !proto.Equal(existing.GetInstalledPackageRef(), job.GetInstalledPackageRef()) {
+				remaining = append(remaining, existing)
+			}
+		}
+		userJobs.Jobs = remaining
+		return nil
+	})
+	return err
+}
+
+// list returns the stored jobs of the user, the most recent first.
+func (s *Store) list(ctx context.Context, user string) ([]*jobs.Job, error) {
+	cm, err := s.clientSet.CoreV1().ConfigMaps(s.namespace).Get(ctx, configMapName(user), metav1.GetOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to get the user jobs: %w", err))
+	}
+	userJobs, err := parseJobs(cm)
+	if err != nil {
+		return nil, err
+	}
+	return s.prune(userJobs.Jobs), nil
+}
+
+// update applies the change to the jobs stored for the user, dropping those
+// which are no longer retained, and returns the resulting jobs. The
+// ConfigMap of the user is deleted once they have no jobs left.
+func (s *Store) update(ctx context.Context, user string, change func(*jobs.UserJobs) error) (*jobs.UserJobs, error) {
+	var userJobs *jobs.UserJobs
+	name := configMapName(user)
+	err := storage.Update(ctx, s.clientSet.CoreV1().ConfigMaps(s.namespace), "configmaps", name, func(cm *corev1.ConfigMap) (*corev1.ConfigMap, error) {
+		var err error
+		userJobs, err = parseJobs(cm)
+		if err != nil {
+			return nil, err
+		}
+		if err := change(userJobs); err != nil {
+			return nil, err
+		}
+		userJobs.Jobs = s.prune(userJobs.Jobs)
+		if len(userJobs.Jobs) == 0 {
+			return nil, nil
+		}
+
+		bytes, err := protojson.Marshal(userJobs)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to serialize the user jobs: %w", err))
+		}
+		if cm == nil {
+			cm = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: s.namespace,
+					Labels:    map[string]string{JobsLabel: "true"},
+				},
+			}
+		}
+		cm.Data = map[string]string{jobsKey: string(bytes)}
+		return cm, nil
+	})
+	if err != nil {
+		// The errors of the change are returned as they are.
+		if connectErr := new(connect.Error); errors.As(err, &connectErr) {
+			return nil, connectErr
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to store the user jobs: %w", err))
+	}
+	return userJobs, nil
+}
+
+// configMapName returns the name of the ConfigMap storing the jobs of the
+// user, identified by their key.
+func configMapName(user string) string {
+	return JobsConfigMapPrefix + user
+}
+
+// prune returns the jobs which are still retained, at most maxJobs: those
+// still followed and those which last changed within the retention.
+func (s *Store) prune(userJobs []*jobs.Job) []*jobs.Job {
+	now := s.now()
+	retained := []*jobs.Job{}
+	for _, job := range userJobs {
+		if len(retained) == maxJobs {
+			break
+		}
+		if followed(job, now) || now.Sub(parseTime(job.GetUpdateTime())) < s.retention {
+			retained = append(retained, job)
+		}
+	}
+	return retained
+}
+
+// followed returns whether the state of the job may still change, that is,
+// whether it is pending or failed and has not timed out.
+func followed(job *jobs.Job, now time.Time) bool {
+	switch job.GetState() {
+	case jobs.JobState_JOB_STATE_PENDING, jobs.JobState_JOB_STATE_FAILED:
+		return now.Sub(parseTime(job.GetCreateTime())) < jobTimeout
+	default:
+		return false
+	}
+}
+
+// parseTime returns the time of an RFC3339 timestamp, the zero time if it is
+// invalid.
+func parseTime(value string) time.Time {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// parseJobs returns the jobs stored in the ConfigMap of a user, which are
+// empty if the ConfigMap does not exist yet.
+func parseJobs(cm *corev1.ConfigMap) (*jobs.UserJobs, error) {
+	userJobs := &jobs.UserJobs{}
+	if cm != nil && cm.Data[jobsKey] != "" {
+		if err := protojson.Unmarshal([]byte(cm.Data[jobsKey]), userJobs); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to parse the stored user jobs: %w", err))
+		}
+	}
+	return userJobs, nil
+}
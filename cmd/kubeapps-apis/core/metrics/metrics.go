@@ -0,0 +1,157 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package metrics defines the Prometheus metrics exposed by the kubeapps-apis
+// service, as well as the helpers used by the core and the plugins to record them.
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	packages "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	plugins "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/plugins/v1alpha1"
+)
+
+const (
+	metricsNamespace = "kubeapps"
+	metricsSubsystem = "apis"
+
+	// corePluginLabel is the plugin label used for the RPCs which are not
+	// handled by a single plugin, such as the aggregated summaries.
+	corePluginLabel = "core"
+
+	pluginsProtoPackagePrefix = "kubeappsapis.plugins."
+)
+
+var (
+	rpcRequestsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "rpc_requests_total",
+			Help:      "Total number of RPCs handled, by plugin, procedure and code.",
+		},
+		[]string{"plugin", "procedure", "code"},
+	)
+
+	rpcDurationSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "rpc_duration_seconds",
+			Help:      "Latency of the RPCs handled, by plugin and procedure.",
+			Buckets:   prometheus.DefBuckets,
+		},
+		[]string{"plugin", "procedure"},
+	)
+
+	cacheRequestsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "plugin_cache_requests_total",
+			Help:      "Total number of plugin cache lookups, by plugin, cache and result (hit or miss).",
+		},
+		[]string{"plugin", "cache", "result"},
+	)
+
+	rpcThrottledTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "rpc_throttled_total",
+			Help:      "Total number of RPCs rejected for exceeding the limits of their user, by plugin, procedure and reason (rate or concurrency).",
+		},
+		[]string{"plugin", "procedure", "reason"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(rpcRequestsTotal, rpcDurationSeconds, cacheRequestsTotal, rpcThrottledTotal)
+}
+
+// NewInterceptor returns a connect interceptor recording the number of
+// requests, their latency and resulting code for every unary RPC.
+func NewInterceptor() connect.Interceptor {
+	return connect.UnaryInterceptorFunc(func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			start := time.Now()
+			res, err := next(ctx, req)
+
+			plugin := pluginForRequest(req)
+			procedure := req.Spec().Procedure
+			code := "ok"
+			if err != nil {
+				code = connect.CodeOf(err).String()
+			}
+			rpcRequestsTotal.WithLabelValues(plugin, procedure, code).Inc()
+			rpcDurationSeconds.WithLabelValues(plugin, procedure).Observe(time.Since(start).Seconds())
+			return res, err
+		}
+	})
+}
+
+// ObserveCacheLookup records a lookup in the given plugin cache, so that
+// the cache hit ratio can be calculated.
+func ObserveCacheLookup(plugin, cache string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	cacheRequestsTotal.WithLabelValues(plugin, cache, result).Inc()
+}
+
+// ObserveThrottledRequest records a request rejected for exceeding the limits
+// of its user for the given reason.
+func ObserveThrottledRequest(req connect.AnyRequest, reason string) {
+	rpcThrottledTotal.WithLabelValues(pluginForRequest(req), req.Spec().Procedure, reason).Inc()
+}
+
+// Handler returns the http handler serving the metrics in the Prometheus format.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// pluginForRequest returns the name of the plugin handling the request, either
+// based on the plugin-specific service being called or on the plugin referenced
+// by the core request.
+func pluginForRequest(req connect.AnyRequest) string {
+	// Plugin-specific procedures have the form
+	// /kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/GetAvailablePackageSummaries
+	service := strings.Split(strings.TrimPrefix(req.Spec().Procedure, "/"), "/")[0]
+	if strings.HasPrefix(service, pluginsProtoPackagePrefix) {
+		segments := strings.Split(strings.TrimPrefix(service, pluginsProtoPackagePrefix), ".")
+		// drop both the version and the service name
+		if len(segments) > 2 {
+			return strings.Join(segments[:len(segments)-2], ".")
+		}
+	}
+
+	var plugin *plugins.Plugin
+	switch msg := req.Any().(type) {
+	case interface {
+		GetAvailablePackageRef() *packages.AvailablePackageReference
+	}:
+		plugin = msg.GetAvailablePackageRef().GetPlugin()
+	case interface {
+		GetInstalledPackageRef() *packages.InstalledPackageReference
+	}:
+		plugin = msg.GetInstalledPackageRef().GetPlugin()
+	case interface {
+		GetPackageRepoRef() *packages.PackageRepositoryReference
+	}:
+		plugin = msg.GetPackageRepoRef().GetPlugin()
+	case interface{ GetPlugin() *plugins.Plugin }:
+		plugin = msg.GetPlugin()
+	}
+	if plugin.GetName() == "" {
+		return corePluginLabel
+	}
+	return plugin.GetName()
+}
@@ -0,0 +1,133 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core/connecttest"
+	packages "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	plugins "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/plugins/v1alpha1"
+)
+
+func TestPluginForRequest(t *testing.T) {
+	helmPlugin := &plugins.Plugin{Name: "helm.packages", Version: "v1alpha1"}
+
+	testCases := []struct {
+		name      string
+		procedure string
+		request   connect.AnyRequest
+		expected  string
+	}{
+		{
+			name:      "plugin-specific procedure",
+			procedure: "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/RollbackInstalledPackage",
+			request:   connect.NewRequest(&packages.GetAvailablePackageSummariesRequest{}),
+			expected:  "helm.packages",
+		},
+		{
+			name:      "plugin-specific procedure for a plugin without a sub-package",
+			procedure: "/kubeappsapis.plugins.resources.v1alpha1.ResourcesService/GetResources",
+			request:   connect.NewRequest(&packages.GetAvailablePackageSummariesRequest{}),
+			expected:  "resources",
+		},
+		{
+			name:      "core procedure with an available package reference",
+			procedure: "/kubeappsapis.core.packages.v1alpha1.PackagesService/GetAvailablePackageDetail",
+			request: connect.NewRequest(&packages.GetAvailablePackageDetailRequest{
+				AvailablePackageRef: &packages.AvailablePackageReference{Plugin: helmPlugin},
+			}),
+			expected: "helm.packages",
+		},
+		{
+			name:      "core procedure with an installed package reference",
+			procedure: "/kubeappsapis.core.packages.v1alpha1.PackagesService/DeleteInstalledPackage",
+			request: connect.NewRequest(&packages.DeleteInstalledPackageRequest{
+				InstalledPackageRef: &packages.InstalledPackageReference{Plugin: helmPlugin},
+			}),
+			expected: "helm.packages",
+		},
+		{
+			name:      "core procedure with a repository reference",
+			procedure: "/kubeappsapis.core.packages.v1alpha1.RepositoriesService/DeletePackageRepository",
+			request: connect.NewRequest(&packages.DeletePackageRepositoryRequest{
+				PackageRepoRef: &packages.PackageRepositoryReference{Plugin: helmPlugin},
+			}),
+			expected: "helm.packages",
+		},
+		{
+			name:      "core procedure with a plugin",
+			procedure: "/kubeappsapis.core.packages.v1alpha1.RepositoriesService/AddPackageRepository",
+			request:   connect.NewRequest(&packages.AddPackageRepositoryRequest{Plugin: helmPlugin}),
+			expected:  "helm.packages",
+		},
+		{
+			name:      "core procedure aggregating all the plugins",
+			procedure: "/kubeappsapis.core.packages.v1alpha1.PackagesService/GetAvailablePackageSummaries",
+			request:   connect.NewRequest(&packages.GetAvailablePackageSummariesRequest{}),
+			expected:  corePluginLabel,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := connecttest.Request{AnyRequest: tc.request, Procedure: tc.procedure}
+			if got, want := pluginForRequest(req), tc.expected; got != want {
+				t.Errorf("got: %q, want: %q", got, want)
+			}
+		})
+	}
+}
+
+func TestInterceptor(t *testing.T) {
+	procedure := "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/RollbackInstalledPackage"
+	req := connecttest.Request{AnyRequest: connect.NewRequest(&packages.GetAvailablePackageSummariesRequest{}), Procedure: procedure}
+
+	handler := NewInterceptor().WrapUnary(func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		return nil, connect.NewError(connect.CodeNotFound, errors.New("not found"))
+	})
+	if _, err := handler(context.Background(), req); connect.CodeOf(err) != connect.CodeNotFound {
+		t.Fatalf("got: %v, want: %v", connect.CodeOf(err), connect.CodeNotFound)
+	}
+
+	if got, want := testutil.ToFloat64(rpcRequestsTotal.WithLabelValues("helm.packages", procedure, "not_found")), 1.0; got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+	if got, want := testutil.CollectAndCount(rpcDurationSeconds), 1; got != want {
+		t.Errorf("got: %d, want: %d", got, want)
+	}
+}
+
+func TestObserveCacheLookup(t *testing.T) {
+	ObserveCacheLookup("fluxv2.packages", "charts", true)
+	ObserveCacheLookup("fluxv2.packages", "charts", true)
+	ObserveCacheLookup("fluxv2.packages", "charts", false)
+
+	if got, want := testutil.ToFloat64(cacheRequestsTotal.WithLabelValues("fluxv2.packages", "charts", "hit")), 2.0; got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+	if got, want := testutil.ToFloat64(cacheRequestsTotal.WithLabelValues("fluxv2.packages", "charts", "miss")), 1.0; got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+}
+
+func TestObserveThrottledRequest(t *testing.T) {
+	procedure := "/kubeappsapis.core.packages.v1alpha1.PackagesService/GetAvailablePackageSummaries"
+	req := connecttest.Request{AnyRequest: connect.NewRequest(&packages.GetAvailablePackageSummariesRequest{}), Procedure: procedure}
+
+	ObserveThrottledRequest(req, "rate")
+	ObserveThrottledRequest(req, "rate")
+	ObserveThrottledRequest(req, "concurrency")
+
+	if got, want := testutil.ToFloat64(rpcThrottledTotal.WithLabelValues(corePluginLabel, procedure, "rate")), 2.0; got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+	if got, want := testutil.ToFloat64(rpcThrottledTotal.WithLabelValues(corePluginLabel, procedure, "concurrency")), 1.0; got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+}
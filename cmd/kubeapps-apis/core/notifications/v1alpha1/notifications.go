@@ -0,0 +1,385 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core/authn"
+	notifications "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/notifications/v1alpha1"
+	packages "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1"
+	packagesconnect "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1/v1connect"
+	resourcesconnect "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/plugins/resources/v1alpha1/v1alpha1connect"
+	log "k8s.io/klog/v2"
+)
+
+const (
+	// settleDelay is the time during which an operation reported as
+	// succeeded is not notified unless it was observed in progress, since
+	// the plugins reconciling the packages may still report the status of
+	// the previous reconciliation right after the request.
+	settleDelay = 10 * time.Second
+
+	// maxRecentNotifications bounds the notifications kept for each user, so
+	// that they can be sent again to a client reconnecting to the stream.
+	maxRecentNotifications = 20
+
+	// subscriberBufferSize bounds the notifications waiting to be sent to a
+	// client. The notifications for a client too slow to receive them are
+	// dropped.
+	subscriberBufferSize = 16
+)
+
+// subscriber is a stream of the notifications of a user.
+type subscriber struct {
+	// headers are those of the request of the stream, which are used to
+	// follow the operations of the user while the stream is open.
+	headers       http.Header
+	notifications chan *notifications.Notification
+}
+
+// notificationsServer implements the API defined in proto/kubeappsapis/core/notifications/v1alpha1/notifications.proto
+type notificationsServer struct {
+	notifications.UnimplementedNotificationsServiceServer
+
+	// tracker records the operations of the users, nil if the notifications
+	// are disabled.
+	tracker *Tracker
+
+	// packagesServer and repositoriesServer are used to follow the status
+	// of the operations, with the token of the user.
+	packagesServer     packagesconnect.PackagesServiceHandler
+	repositoriesServer packagesconnect.RepositoriesServiceHandler
+
+	// resourcesClient watches the resources of the installed packages, so
+	// that their operations are checked as soon as they change. The
+	// operations are checked at the interval if nil.
+	resourcesClient resourcesconnect.ResourcesServiceClient
+
+	// interval is the interval at which the operations which are not
+	// watched are checked.
+	interval time.Duration
+	now      func() time.Time
+
+	// checkMutex serializes the checks of the operations, which are run
+	// both at the interval and by the watches.
+	checkMutex sync.Mutex
+
+	mutex       sync.Mutex
+	subscribers map[string][]*subscriber
+	recent      map[string][]*notifications.Notification
+	watches     map[string]*resourceWatch
+	lastId      uint64
+}
+
+// NewNotificationsServer returns a server notifying the outcome of the
+// operations recorded by the tracker for the users watching their
+// notifications. The operations on the installed packages are checked when
+// their resources change, as watched with the resources client, and the other
+// ones at the given interval. The notifications are disabled if the tracker is
+// nil.
+func NewNotificationsServer(tracker *Tracker, packagesServer packagesconnect.PackagesServiceHandler, repositoriesServer packagesconnect.RepositoriesServiceHandler, resourcesClient resourcesconnect.ResourcesServiceClient, interval time.Duration) *notificationsServer {
+	return &notificationsServer{
+		tracker:            tracker,
+		packagesServer:     packagesServer,
+		repositoriesServer: repositoriesServer,
+		resourcesClient:    resourcesClient,
+		interval:           interval,
+		now:                time.Now,
+		subscribers:        map[string][]*subscriber{},
+		recent:             map[string][]*notifications.Notification{},
+		watches:            map[string]*resourceWatch{},
+	}
+}
+
+// WatchNotifications streams the notifications of the calling user until the
+// request is cancelled.
+func (s *notificationsServer) WatchNotifications(ctx context.Context, request *connect.Request[notifications.WatchNotificationsRequest], stream *connect.ServerStream[notifications.WatchNotificationsResponse]) error {
+	log.InfoS("+core WatchNotifications", "afterId", request.Msg.GetAfterId())
+
+	if s.tracker == nil {
+		return connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("The notifications are not enabled"))
+	}
+	user, err := authn.UserKey(ctx, s.tracker.clientSet, request.Header())
+	if err != nil {
+		return err
+	}
+
+	sub, missed := s.subscribe(user, request.Header(), request.Msg.GetAfterId())
+	defer s.unsubscribe(user, sub)
+	for _, notification := range missed {
+		if err := stream.Send(&notifications.WatchNotificationsResponse{Notification: notification}); err != nil {
+			return err
+		}
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case notification := <-sub.notifications:
+			if err := stream.Send(&notifications.WatchNotificationsResponse{Notification: notification}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// subscribe registers a stream of the notifications of the user, returning
+// the recent notifications following the given one.
+func (s *notificationsServer) subscribe(user string, headers http.Header, afterId uint64) (*subscriber, []*notifications.Notification) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	sub := &subscriber{
+		headers:       headers,
+		notifications: make(chan *notifications.Notification, subscriberBufferSize),
+	}
+	s.subscribers[user] = append(s.subscribers[user], sub)
+
+	missed := []*notifications.Notification{}
+	if afterId > 0 {
+		for _, notification := range s.recent[user] {
+			if notification.GetId() > afterId {
+				missed = append(missed, notification)
+			}
+		}
+	}
+	return sub, missed
+}
+
+func (s *notificationsServer) unsubscribe(user string, sub *subscriber) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	subscribers := []*subscriber{}
+	for _, existing := range s.subscribers[user] {
+		if existing != sub {
+			subscribers = append(subscribers, existing)
+		}
+	}
+	if len(subscribers) == 0 {
+		delete(s.subscribers, user)
+		return
+	}
+	s.subscribers[user] = subscribers
+}
+
+// Run checks the operations at the configured interval, and watches the
+// resources of the installed packages, until the context is done.
+func (s *notificationsServer) Run(ctx context.Context) {
+	log.Infof("Checking the operations to notify every %s", s.interval)
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		s.Check(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check follows once the operations of the users watching their
+// notifications, with the token of their last stream, and notifies those
+// whose outcome changed. The operations on installed packages whose resources
+// are watched are checked when they change instead, once settled. The
+// operations of the other users are checked once they watch their
+// notifications again, until they expire.
+func (s *notificationsServer) Check(ctx context.Context) {
+	s.mutex.Lock()
+	users := map[string]http.Header{}
+	for user, subscribers := range s.subscribers {
+		users[user] = subscribers[len(subscribers)-1].headers
+	}
+	s.mutex.Unlock()
+
+	active := map[string]bool{}
+	for user, headers := range users {
+		for _, op := range s.tracker.pending(user) {
+			if s.watch(ctx, user, op, headers) {
+				active[watchKey(user, op)] = true
+				// A success reported before the settle delay may be the
+				// one of the previous reconciliation, after which the
+				// resources may not change again.
+				s.checkMutex.Lock()
+				settled := s.settled(op)
+				s.checkMutex.Unlock()
+				if settled {
+					continue
+				}
+			}
+			if !s.checkAndNotify(ctx, user, op, headers) {
+				delete(active, watchKey(user, op))
+			}
+		}
+	}
+	s.stopWatches(active)
+}
+
+// checkAndNotify checks the operation of the user, notifying its outcome if
+// it changed, and returns whether the operation is still pending.
+func (s *notificationsServer) checkAndNotify(ctx context.Context, user string, op *operation, headers http.Header) bool {
+	s.checkMutex.Lock()
+	defer s.checkMutex.Unlock()
+	// The operation may have been notified by a watch in the meantime.
+	if !s.tracker.has(user, op) {
+		return false
+	}
+	notification, done, err := s.checkOperation(ctx, op, headers)
+	if err != nil {
+		log.Errorf("Unable to check the status of the %s: %v", op.resourceKey(), err)
+		return true
+	}
+	if notification != nil {
+		s.notify(user, notification)
+	}
+	if done {
+		s.tracker.done(user, op)
+	}
+	return !done
+}
+
+// checkOperation returns the notification of the outcome of the operation if
+// it changed, and whether the operation is done.
+func (s *notificationsServer) checkOperation(ctx context.Context, op *operation, headers http.Header) (*notifications.Notification, bool, error) {
+	if op.kind == OperationRepositorySync {
+		return s.checkRepositorySync(ctx, op, headers)
+	}
+	return s.checkInstalledPackage(ctx, op, headers)
+}
+
+func (s *notificationsServer) checkInstalledPackage(ctx context.Context, op *operation, headers http.Header) (*notifications.Notification, bool, error) {
+	detailRequest := connect.NewRequest(&packages.GetInstalledPackageDetailRequest{
+		InstalledPackageRef: op.installedPackageRef,
+	})
+	authn.CopyHeaders(detailRequest.Header(), headers)
+	detailResponse, err := s.packagesServer.GetInstalledPackageDetail(ctx, detailRequest)
+	if err != nil {
+		// The installed package was deleted in the meantime.
+		if connect.CodeOf(err) == connect.CodeNotFound {
+			return nil, true, nil
+		}
+		return nil, false, err
+	}
+	status := detailResponse.Msg.GetInstalledPackageDetail().GetStatus()
+	name := fmt.Sprintf("%q in the namespace %q", op.installedPackageRef.GetIdentifier(), op.installedPackageRef.GetContext().GetNamespace())
+
+	switch status.GetReason() {
+	case packages.InstalledPackageStatus_STATUS_REASON_INSTALLED:
+		if !s.settled(op) {
+			return nil, false, nil
+		}
+		if op.kind == OperationInstall {
+			return s.newNotification(op, notifications.NotificationType_NOTIFICATION_TYPE_INSTALL_COMPLETED, fmt.Sprintf("The package %s was installed", name), ""), true, nil
+		}
+		return s.newNotification(op, notifications.NotificationType_NOTIFICATION_TYPE_UPGRADE_COMPLETED, fmt.Sprintf("The package %s was upgraded", name), ""), true, nil
+	case packages.InstalledPackageStatus_STATUS_REASON_FAILED:
+		if op.failed && op.failedReason == status.GetUserReason() {
+			return nil, false, nil
+		}
+		op.failed, op.failedReason = true, status.GetUserReason()
+		// The failed operations are still followed, so that a later
+		// success is notified.
+		if op.kind == OperationInstall {
+			return s.newNotification(op, notifications.NotificationType_NOTIFICATION_TYPE_INSTALL_FAILED, fmt.Sprintf("The installation of the package %s failed", name), status.GetUserReason()), false, nil
+		}
+		return s.newNotification(op, notifications.NotificationType_NOTIFICATION_TYPE_UPGRADE_FAILED, fmt.Sprintf("The upgrade of the package %s failed", name), status.GetUserReason()), false, nil
+	default:
+		op.pending = true
+		return nil, false, nil
+	}
+}
+
+func (s *notificationsServer) checkRepositorySync(ctx context.Context, op *operation, headers http.Header) (*notifications.Notification, bool, error) {
+	detailRequest := connect.NewRequest(&packages.GetPackageRepositoryDetailRequest{
+		PackageRepoRef: op.packageRepoRef,
+	})
+	authn.CopyHeaders(detailRequest.Header(), headers)
+	detailResponse, err := s.repositoriesServer.GetPackageRepositoryDetail(ctx, detailRequest)
+	if err != nil {
+		// The package repository was deleted in the meantime.
+		if connect.CodeOf(err) == connect.CodeNotFound {
+			return nil, true, nil
+		}
+		return nil, false, err
+	}
+	status := detailResponse.Msg.GetDetail().GetStatus()
+	// The plugins not syncing the repositories do not report any status.
+	if status == nil {
+		return nil, true, nil
+	}
+	name := fmt.Sprintf("%q in the namespace %q", op.packageRepoRef.GetIdentifier(), op.packageRepoRef.GetContext().GetNamespace())
+
+	switch status.GetReason() {
+	case packages.PackageRepositoryStatus_STATUS_REASON_SUCCESS:
+		if op.failed {
+			return s.newNotification(op, notifications.NotificationType_NOTIFICATION_TYPE_REPOSITORY_SYNC_RECOVERED, fmt.Sprintf("The sync of the package repository %s recovered", name), ""), true, nil
+		}
+		if !s.settled(op) {
+			return nil, false, nil
+		}
+		return s.newNotification(op, notifications.NotificationType_NOTIFICATION_TYPE_REPOSITORY_SYNC_COMPLETED, fmt.Sprintf("The package repository %s was synced", name), ""), true, nil
+	case packages.PackageRepositoryStatus_STATUS_REASON_FAILED:
+		if op.failed && op.failedReason == status.GetUserReason() {
+			return nil, false, nil
+		}
+		op.failed, op.failedReason = true, status.GetUserReason()
+		return s.newNotification(op, notifications.NotificationType_NOTIFICATION_TYPE_REPOSITORY_SYNC_FAILED, fmt.Sprintf("The sync of the package repository %s failed", name), status.GetUserReason()), false, nil
+	default:
+		op.pending = true
+		return nil, false, nil
+	}
+}
+
+// settled returns whether the success reported for the operation can be
+// trusted: once observed in progress or failed, or after the settle delay.
+func (s *notificationsServer) settled(op *operation) bool {
+	return op.pending || op.failed || s.now().Sub(op.startedAt) >= settleDelay
+}
+
+// newNotification returns a notification of the operation, without
+// identifier.
+func (s *notificationsServer) newNotification(op *operation, notificationType notifications.NotificationType, message, reason string) *notifications.Notification {
+	return &notifications.Notification{
+		Type:                notificationType,
+		Message:             message,
+		Reason:              reason,
+		Time:                s.now().UTC().Format(time.RFC3339),
+		InstalledPackageRef: op.installedPackageRef,
+		PackageRepoRef:      op.packageRepoRef,
+	}
+}
+
+// notify records the notification of the user and sends it to their streams.
+func (s *notificationsServer) notify(user string, notification *notifications.Notification) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	// The identifiers increase with the time, so that they keep increasing
+	// after a restart.
+	id := uint64(s.now().UnixNano())
+	if id <= s.lastId {
+		id = s.lastId + 1
+	}
+	s.lastId = id
+	notification.Id = id
+
+	recent := append(s.recent[user], notification)
+	if len(recent) > maxRecentNotifications {
+		recent = recent[len(recent)-maxRecentNotifications:]
+	}
+	s.recent[user] = recent
+
+	for _, sub := range s.subscribers[user] {
+		select {
+		case sub.notifications <- notification:
+		default:
+			log.Errorf("Dropping the notification %d for a slow client", id)
+		}
+	}
+}
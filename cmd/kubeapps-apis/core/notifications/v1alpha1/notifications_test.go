@@ -0,0 +1,662 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/google/go-cmp/cmp"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core/authn"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core/connecttest"
+	notifications "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/notifications/v1alpha1"
+	notificationsconnect "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/notifications/v1alpha1/v1alpha1connect"
+	packages "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1"
+	packagesconnect "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1/v1connect"
+	packagesv1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	packagesv1alpha1connect "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1/v1alpha1connect"
+	plugins "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/plugins/v1alpha1"
+	resources "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/plugins/resources/v1alpha1"
+	resourcesconnect "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/plugins/resources/v1alpha1/v1alpha1connect"
+	"google.golang.org/protobuf/testing/protocmp"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+var helmPlugin = &plugins.Plugin{Name: "helm.packages", Version: "v1alpha1"}
+
+// newTestTracker returns a tracker whose token reviews authenticate the tokens
+// in the users map as the corresponding username.
+func newTestTracker(users map[string]string, now *time.Time) *Tracker {
+	clientSet := fake.NewSimpleClientset()
+	clientSet.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (handled bool, ret runtime.Object, err error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authenticationv1.TokenReview)
+		if username, ok := users[review.Spec.Token]; ok {
+			review.Status.Authenticated = true
+			review.Status.User.Username = username
+		} else {
+			review.Status.Error = "invalid token"
+		}
+		return true, review, nil
+	})
+	tracker := NewTracker(clientSet)
+	tracker.now = func() time.Time { return *now }
+	tracker.async = func(f func()) { f() }
+	return tracker
+}
+
+func userHeaders(token string) http.Header {
+	headers := http.Header{}
+	headers.Set("Authorization", "Bearer "+token)
+	return headers
+}
+
+func installedRef(identifier string) *packages.InstalledPackageReference {
+	return &packages.InstalledPackageReference{
+		Context:    &packages.Context{Cluster: "default", Namespace: "apps"},
+		Identifier: identifier,
+		Plugin:     helmPlugin,
+	}
+}
+
+func repoRef(identifier string) *packages.PackageRepositoryReference {
+	return &packages.PackageRepositoryReference{
+		Context:    &packages.Context{Cluster: "default", Namespace: "apps"},
+		Identifier: identifier,
+		Plugin:     helmPlugin,
+	}
+}
+
+func TestTrackerInterceptor(t *testing.T) {
+	now := time.Date(2023, 6, 3, 2, 0, 0, 0, time.UTC)
+	alphaInstalledRef := &packagesv1alpha1.InstalledPackageReference{
+		Context:    &packagesv1alpha1.Context{Cluster: "default", Namespace: "apps"},
+		Identifier: "my-apache",
+		Plugin:     helmPlugin,
+	}
+
+	testCases := []struct {
+		name               string
+		procedure          string
+		response           connect.AnyResponse
+		handlerErr         error
+		token              string
+		expectedOperations []*operation
+	}{
+		{
+			name:      "it records an install",
+			procedure: packagesv1alpha1connect.PackagesServiceCreateInstalledPackageProcedure,
+			response:  connect.NewResponse(&packagesv1alpha1.CreateInstalledPackageResponse{InstalledPackageRef: alphaInstalledRef}),
+			token:     "token-a",
+			expectedOperations: []*operation{
+				{kind: OperationInstall, installedPackageRef: installedRef("my-apache"), startedAt: now},
+			},
+		},
+		{
+			name:      "it records an upgrade of the v1 API",
+			procedure: packagesconnect.PackagesServiceUpdateInstalledPackageProcedure,
+			response:  connect.NewResponse(&packages.UpdateInstalledPackageResponse{InstalledPackageRef: installedRef("my-apache")}),
+			token:     "token-a",
+			expectedOperations: []*operation{
+				{kind: OperationUpgrade, installedPackageRef: installedRef("my-apache"), startedAt: now},
+			},
+		},
+		{
+			name:      "it records the sync of an added repository",
+			procedure: packagesv1alpha1connect.RepositoriesServiceAddPackageRepositoryProcedure,
+			response: connect.NewResponse(&packagesv1alpha1.AddPackageRepositoryResponse{PackageRepoRef: &packagesv1alpha1.PackageRepositoryReference{
+				Context:    &packagesv1alpha1.Context{Cluster: "default", Namespace: "apps"},
+				Identifier: "bitnami",
+				Plugin:     helmPlugin,
+			}}),
+			token: "token-a",
+			expectedOperations: []*operation{
+				{kind: OperationRepositorySync, packageRepoRef: repoRef("bitnami"), startedAt: now},
+			},
+		},
+		{
+			name:       "it does not record a failed request",
+			procedure:  packagesv1alpha1connect.PackagesServiceCreateInstalledPackageProcedure,
+			handlerErr: connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("boom")),
+			token:      "token-a",
+		},
+		{
+			name:      "it does not record an operation which is not tracked",
+			procedure: packagesv1alpha1connect.PackagesServiceDeleteInstalledPackageProcedure,
+			response:  connect.NewResponse(&packagesv1alpha1.DeleteInstalledPackageResponse{}),
+			token:     "token-a",
+		},
+		{
+			name:      "it does not record the operation of an unidentified user",
+			procedure: packagesv1alpha1connect.PackagesServiceCreateInstalledPackageProcedure,
+			response:  connect.NewResponse(&packagesv1alpha1.CreateInstalledPackageResponse{InstalledPackageRef: alphaInstalledRef}),
+			token:     "unknown-token",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tracker := newTestTracker(map[string]string{"token-a": "user-a"}, &now)
+			recorded := []RecordedOperation{}
+			tracker.OnRecord(func(ctx context.Context, user string, op RecordedOperation) {
+				recorded = append(recorded, op)
+			})
+			handler := tracker.Interceptor().WrapUnary(func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+				if tc.handlerErr != nil {
+					return nil, tc.handlerErr
+				}
+				return tc.response, nil
+			})
+
+			request := connect.NewRequest(&packagesv1alpha1.CreateInstalledPackageRequest{})
+			request.Header().Set("Authorization", "Bearer "+tc.token)
+			_, err := handler(context.Background(), connecttest.Request{AnyRequest: request, Procedure: tc.procedure})
+			if got, want := err, tc.handlerErr; got != want {
+				t.Fatalf("got: %+v, want: %+v", got, want)
+			}
+
+			user, _ := authn.UserKey(context.Background(), tracker.clientSet, userHeaders("token-a"))
+			opts := []cmp.Option{cmp.AllowUnexported(operation{}), protocmp.Transform()}
+			if got, want := tracker.pending(user), tc.expectedOperations; !cmp.Equal(want, got, opts...) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, opts...))
+			}
+			// The listeners are called with the recorded operations.
+			expectedRecorded := []RecordedOperation{}
+			for _, op := range tc.expectedOperations {
+				expectedRecorded = append(expectedRecorded, RecordedOperation{
+					Kind:                op.kind,
+					InstalledPackageRef: op.installedPackageRef,
+					PackageRepoRef:      op.packageRepoRef,
+					StartedAt:           op.startedAt,
+				})
+			}
+			if got, want := recorded, expectedRecorded; !cmp.Equal(want, got, protocmp.Transform()) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, protocmp.Transform()))
+			}
+		})
+	}
+}
+
+func TestTrackerRecordsInTheBackground(t *testing.T) {
+	now := time.Date(2023, 6, 3, 2, 0, 0, 0, time.UTC)
+	tracker := newTestTracker(map[string]string{"token-a": "user-a"}, &now)
+	recording := sync.WaitGroup{}
+	tracker.async = func(f func()) {
+		recording.Add(1)
+		go func() {
+			defer recording.Done()
+			f()
+		}()
+	}
+	// The listener blocks until the response is returned.
+	responded := make(chan struct{})
+	recorded := []RecordedOperation{}
+	tracker.OnRecord(func(ctx context.Context, user string, op RecordedOperation) {
+		<-responded
+		recorded = append(recorded, op)
+	})
+	handler := tracker.Interceptor().WrapUnary(func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		return connect.NewResponse(&packages.CreateInstalledPackageResponse{InstalledPackageRef: installedRef("my-apache")}), nil
+	})
+
+	// The request is cancelled once it succeeded.
+	ctx, cancel := context.WithCancel(context.Background())
+	request := connect.NewRequest(&packages.CreateInstalledPackageRequest{})
+	request.Header().Set("Authorization", "Bearer token-a")
+	if _, err := handler(ctx, connecttest.Request{AnyRequest: request, Procedure: packagesconnect.PackagesServiceCreateInstalledPackageProcedure}); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	cancel()
+	close(responded)
+	recording.Wait()
+
+	if got, want := len(recorded), 1; got != want {
+		t.Errorf("got: %d, want: %d", got, want)
+	}
+}
+
+func TestTrackerReplacesOperations(t *testing.T) {
+	now := time.Date(2023, 6, 3, 2, 0, 0, 0, time.UTC)
+	tracker := newTestTracker(nil, &now)
+
+	install := &operation{kind: OperationInstall, installedPackageRef: installedRef("my-apache"), startedAt: now}
+	sync := &operation{kind: OperationRepositorySync, packageRepoRef: repoRef("bitnami"), startedAt: now}
+	tracker.record("user-a", install)
+	tracker.record("user-a", sync)
+
+	// A new operation on the same installed package replaces the previous one.
+	now = now.Add(time.Minute)
+	upgrade := &operation{kind: OperationUpgrade, installedPackageRef: installedRef("my-apache"), startedAt: now}
+	tracker.record("user-a", upgrade)
+	if got, want := tracker.pending("user-a"), []*operation{upgrade, sync}; !cmp.Equal(want, got, cmp.Comparer(func(a, b *operation) bool { return a == b })) {
+		t.Errorf("got: %+v, want: %+v", got, want)
+	}
+
+	// The operations are forgotten once done or expired.
+	tracker.done("user-a", sync)
+	now = now.Add(operationTTL)
+	if got := tracker.pending("user-a"); len(got) != 0 {
+		t.Errorf("got: %+v, want: no operations", got)
+	}
+
+	// The expired operations of the other users are forgotten when an
+	// operation is recorded, even if they never watch their notifications.
+	tracker.record("user-b", &operation{kind: OperationInstall, installedPackageRef: installedRef("my-nginx"), startedAt: now})
+	now = now.Add(operationTTL)
+	tracker.record("user-a", &operation{kind: OperationInstall, installedPackageRef: installedRef("my-apache"), startedAt: now})
+	if _, ok := tracker.operations["user-b"]; ok {
+		t.Errorf("got: %+v, want: no operations for user-b", tracker.operations["user-b"])
+	}
+}
+
+// fakePackagesServer returns the status and resource references of the
+// installed packages by identifier, as set by the test.
+type fakePackagesServer struct {
+	packagesconnect.UnimplementedPackagesServiceHandler
+	mutex        sync.Mutex
+	statuses     map[string]*packages.InstalledPackageStatus
+	resourceRefs map[string][]*packages.ResourceRef
+}
+
+func (s *fakePackagesServer) setStatus(identifier string, status *packages.InstalledPackageStatus) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.statuses[identifier] = status
+}
+
+func (s *fakePackagesServer) GetInstalledPackageDetail(ctx context.Context, request *connect.Request[packages.GetInstalledPackageDetailRequest]) (*connect.Response[packages.GetInstalledPackageDetailResponse], error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	status, ok := s.statuses[request.Msg.GetInstalledPackageRef().GetIdentifier()]
+	if !ok {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("not found"))
+	}
+	return connect.NewResponse(&packages.GetInstalledPackageDetailResponse{
+		InstalledPackageDetail: &packages.InstalledPackageDetail{Status: status},
+	}), nil
+}
+
+func (s *fakePackagesServer) GetInstalledPackageResourceRefs(ctx context.Context, request *connect.Request[packages.GetInstalledPackageResourceRefsRequest]) (*connect.Response[packages.GetInstalledPackageResourceRefsResponse], error) {
+	return connect.NewResponse(&packages.GetInstalledPackageResourceRefsResponse{
+		Context:      request.Msg.GetInstalledPackageRef().GetContext(),
+		ResourceRefs: s.resourceRefs[request.Msg.GetInstalledPackageRef().GetIdentifier()],
+	}), nil
+}
+
+// fakeResourcesServer streams a change of the watched resources for each
+// value sent on the changes channel, after the initial state.
+type fakeResourcesServer struct {
+	resourcesconnect.UnimplementedResourcesServiceHandler
+	changes chan struct{}
+}
+
+func (s *fakeResourcesServer) GetResources(ctx context.Context, request *connect.Request[resources.GetResourcesRequest], stream *connect.ServerStream[resources.GetResourcesResponse]) error {
+	if !request.Msg.GetWatch() || len(request.Msg.GetResourceRefs()) == 0 {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("unexpected request %v", request.Msg))
+	}
+	response := &resources.GetResourcesResponse{ResourceRef: request.Msg.GetResourceRefs()[0]}
+	if err := stream.Send(response); err != nil {
+		return err
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-s.changes:
+			if err := stream.Send(response); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// fakeRepositoriesServer returns the status of the package repositories by
+// identifier, as set by the test.
+type fakeRepositoriesServer struct {
+	packagesconnect.UnimplementedRepositoriesServiceHandler
+	statuses map[string]*packages.PackageRepositoryStatus
+}
+
+func (s *fakeRepositoriesServer) GetPackageRepositoryDetail(ctx context.Context, request *connect.Request[packages.GetPackageRepositoryDetailRequest]) (*connect.Response[packages.GetPackageRepositoryDetailResponse], error) {
+	status, ok := s.statuses[request.Msg.GetPackageRepoRef().GetIdentifier()]
+	if !ok {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("not found"))
+	}
+	return connect.NewResponse(&packages.GetPackageRepositoryDetailResponse{
+		Detail: &packages.PackageRepositoryDetail{Status: status},
+	}), nil
+}
+
+func installedStatus(reason packages.InstalledPackageStatus_StatusReason, userReason string) *packages.InstalledPackageStatus {
+	return &packages.InstalledPackageStatus{
+		Ready:      reason == packages.InstalledPackageStatus_STATUS_REASON_INSTALLED,
+		Reason:     reason,
+		UserReason: userReason,
+	}
+}
+
+func repoStatus(reason packages.PackageRepositoryStatus_StatusReason, userReason string) *packages.PackageRepositoryStatus {
+	return &packages.PackageRepositoryStatus{
+		Ready:      reason == packages.PackageRepositoryStatus_STATUS_REASON_SUCCESS,
+		Reason:     reason,
+		UserReason: userReason,
+	}
+}
+
+func TestCheck(t *testing.T) {
+	type step struct {
+		// elapsed is the time elapsed since the start of the operation.
+		elapsed                   time.Duration
+		installedStatus           *packages.InstalledPackageStatus
+		repoStatus                *packages.PackageRepositoryStatus
+		expectedNotifications     []notifications.NotificationType
+		expectedPendingAfterwards bool
+	}
+
+	testCases := []struct {
+		name      string
+		operation *operation
+		steps     []step
+	}{
+		{
+			name:      "it notifies a completed install once observed in progress",
+			operation: &operation{kind: OperationInstall, installedPackageRef: installedRef("my-apache")},
+			steps: []step{
+				{
+					installedStatus:           installedStatus(packages.InstalledPackageStatus_STATUS_REASON_PENDING, ""),
+					expectedPendingAfterwards: true,
+				},
+				{
+					elapsed:               5 * time.Second,
+					installedStatus:       installedStatus(packages.InstalledPackageStatus_STATUS_REASON_INSTALLED, ""),
+					expectedNotifications: []notifications.NotificationType{notifications.NotificationType_NOTIFICATION_TYPE_INSTALL_COMPLETED},
+				},
+			},
+		},
+		{
+			name:      "it waits for the settle delay before notifying a completed upgrade never observed in progress",
+			operation: &operation{kind: OperationUpgrade, installedPackageRef: installedRef("my-apache")},
+			steps: []step{
+				{
+					installedStatus:           installedStatus(packages.InstalledPackageStatus_STATUS_REASON_INSTALLED, ""),
+					expectedPendingAfterwards: true,
+				},
+				{
+					elapsed:               settleDelay,
+					installedStatus:       installedStatus(packages.InstalledPackageStatus_STATUS_REASON_INSTALLED, ""),
+					expectedNotifications: []notifications.NotificationType{notifications.NotificationType_NOTIFICATION_TYPE_UPGRADE_COMPLETED},
+				},
+			},
+		},
+		{
+			name:      "it notifies a failed upgrade once, then its success",
+			operation: &operation{kind: OperationUpgrade, installedPackageRef: installedRef("my-apache")},
+			steps: []step{
+				{
+					installedStatus:           installedStatus(packages.InstalledPackageStatus_STATUS_REASON_FAILED, "timed out"),
+					expectedNotifications:     []notifications.NotificationType{notifications.NotificationType_NOTIFICATION_TYPE_UPGRADE_FAILED},
+					expectedPendingAfterwards: true,
+				},
+				{
+					elapsed:                   time.Second,
+					installedStatus:           installedStatus(packages.InstalledPackageStatus_STATUS_REASON_FAILED, "timed out"),
+					expectedPendingAfterwards: true,
+				},
+				{
+					elapsed:               2 * time.Second,
+					installedStatus:       installedStatus(packages.InstalledPackageStatus_STATUS_REASON_INSTALLED, ""),
+					expectedNotifications: []notifications.NotificationType{notifications.NotificationType_NOTIFICATION_TYPE_UPGRADE_COMPLETED},
+				},
+			},
+		},
+		{
+			name:      "it forgets an installed package deleted in the meantime",
+			operation: &operation{kind: OperationInstall, installedPackageRef: installedRef("my-apache")},
+			steps:     []step{{}},
+		},
+		{
+			name:      "it notifies a recovered repository sync",
+			operation: &operation{kind: OperationRepositorySync, packageRepoRef: repoRef("bitnami")},
+			steps: []step{
+				{
+					repoStatus:                repoStatus(packages.PackageRepositoryStatus_STATUS_REASON_FAILED, "unable to fetch the index"),
+					expectedNotifications:     []notifications.NotificationType{notifications.NotificationType_NOTIFICATION_TYPE_REPOSITORY_SYNC_FAILED},
+					expectedPendingAfterwards: true,
+				},
+				{
+					elapsed:               time.Minute,
+					repoStatus:            repoStatus(packages.PackageRepositoryStatus_STATUS_REASON_SUCCESS, ""),
+					expectedNotifications: []notifications.NotificationType{notifications.NotificationType_NOTIFICATION_TYPE_REPOSITORY_SYNC_RECOVERED},
+				},
+			},
+		},
+		{
+			name:      "it notifies a completed repository sync",
+			operation: &operation{kind: OperationRepositorySync, packageRepoRef: repoRef("bitnami")},
+			steps: []step{
+				{
+					repoStatus:                repoStatus(packages.PackageRepositoryStatus_STATUS_REASON_PENDING, ""),
+					expectedPendingAfterwards: true,
+				},
+				{
+					elapsed:               time.Second,
+					repoStatus:            repoStatus(packages.PackageRepositoryStatus_STATUS_REASON_SUCCESS, ""),
+					expectedNotifications: []notifications.NotificationType{notifications.NotificationType_NOTIFICATION_TYPE_REPOSITORY_SYNC_COMPLETED},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			start := time.Date(2023, 6, 3, 2, 0, 0, 0, time.UTC)
+			now := start
+			tracker := newTestTracker(nil, &now)
+			packagesServer := &fakePackagesServer{statuses: map[string]*packages.InstalledPackageStatus{}}
+			repositoriesServer := &fakeRepositoriesServer{statuses: map[string]*packages.PackageRepositoryStatus{}}
+			server := NewNotificationsServer(tracker, packagesServer, repositoriesServer, nil, time.Second)
+			server.now = func() time.Time { return now }
+
+			tc.operation.startedAt = start
+			tracker.record("user-a", tc.operation)
+			sub, _ := server.subscribe("user-a", userHeaders("token-a"), 0)
+
+			for i, step := range tc.steps {
+				now = start.Add(step.elapsed)
+				if step.installedStatus != nil {
+					packagesServer.statuses["my-apache"] = step.installedStatus
+				}
+				if step.repoStatus != nil {
+					repositoriesServer.statuses["bitnami"] = step.repoStatus
+				}
+
+				server.Check(context.Background())
+
+				got := []notifications.NotificationType{}
+				for len(sub.notifications) > 0 {
+					got = append(got, (<-sub.notifications).GetType())
+				}
+				if want := step.expectedNotifications; !cmp.Equal(want, got, cmp.Comparer(func(a, b []notifications.NotificationType) bool { return fmt.Sprint(a) == fmt.Sprint(b) })) {
+					t.Errorf("step %d: got: %v, want: %v", i, got, want)
+				}
+				if got, want := len(tracker.pending("user-a")) > 0, step.expectedPendingAfterwards; got != want {
+					t.Errorf("step %d: got pending: %t, want: %t", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckWatchesResources(t *testing.T) {
+	start := time.Date(2023, 6, 3, 2, 0, 0, 0, time.UTC)
+	now := start
+	tracker := newTestTracker(nil, &now)
+	packagesServer := &fakePackagesServer{
+		statuses: map[string]*packages.InstalledPackageStatus{
+			"my-apache": installedStatus(packages.InstalledPackageStatus_STATUS_REASON_PENDING, ""),
+		},
+		resourceRefs: map[string][]*packages.ResourceRef{
+			"my-apache": {{ApiVersion: "apps/v1", Kind: "Deployment", Name: "my-apache", Namespace: "apps"}},
+		},
+	}
+	resourcesServer := &fakeResourcesServer{changes: make(chan struct{})}
+	mux := http.NewServeMux()
+	mux.Handle(resourcesconnect.NewResourcesServiceHandler(resourcesServer))
+	httpServer := httptest.NewServer(mux)
+	defer httpServer.Close()
+	resourcesClient := resourcesconnect.NewResourcesServiceClient(httpServer.Client(), httpServer.URL)
+
+	server := NewNotificationsServer(tracker, packagesServer, &fakeRepositoriesServer{}, resourcesClient, time.Hour)
+	server.now = func() time.Time { return now }
+	tracker.record("user-a", &operation{kind: OperationInstall, installedPackageRef: installedRef("my-apache"), startedAt: start})
+	sub, _ := server.subscribe("user-a", userHeaders("token-a"), 0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	// The check watches the resources of the installed package, whose
+	// initial state is checked as soon as received.
+	server.Check(ctx)
+	server.mutex.Lock()
+	watches := len(server.watches)
+	server.mutex.Unlock()
+	if got, want := watches, 1; got != want {
+		t.Fatalf("got: %d, want: %d", got, want)
+	}
+
+	// The completed install is notified once its resources change, without
+	// waiting for the next check.
+	packagesServer.setStatus("my-apache", installedStatus(packages.InstalledPackageStatus_STATUS_REASON_INSTALLED, ""))
+	resourcesServer.changes <- struct{}{}
+	select {
+	case notification := <-sub.notifications:
+		if got, want := notification.GetType(), notifications.NotificationType_NOTIFICATION_TYPE_INSTALL_COMPLETED; got != want {
+			t.Errorf("got: %v, want: %v", got, want)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("the completed install was not notified")
+	}
+
+	// The watch is stopped once the operation is done.
+	for deadline := time.Now().Add(5 * time.Second); ; {
+		server.mutex.Lock()
+		watches := len(server.watches)
+		server.mutex.Unlock()
+		if watches == 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("the watch was not stopped")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if got, want := len(tracker.pending("user-a")), 0; got != want {
+		t.Errorf("got: %d, want: %d", got, want)
+	}
+}
+
+func TestWatchNotifications(t *testing.T) {
+	now := time.Date(2023, 6, 3, 2, 0, 0, 0, time.UTC)
+	tracker := newTestTracker(map[string]string{"token-a": "user-a", "token-b": "user-b"}, &now)
+	server := NewNotificationsServer(tracker, &fakePackagesServer{}, &fakeRepositoriesServer{}, nil, time.Second)
+	server.now = func() time.Time { return now }
+	userA, _ := authn.UserKey(context.Background(), tracker.clientSet, userHeaders("token-a"))
+
+	// A notification before the client connects.
+	missed := &notifications.Notification{Type: notifications.NotificationType_NOTIFICATION_TYPE_INSTALL_COMPLETED, InstalledPackageRef: installedRef("my-apache")}
+	server.notify(userA, missed)
+
+	mux := http.NewServeMux()
+	mux.Handle(notificationsconnect.NewNotificationsServiceHandler(server))
+	httpServer := httptest.NewUnstartedServer(mux)
+	httpServer.EnableHTTP2 = true
+	httpServer.StartTLS()
+	defer httpServer.Close()
+	client := notificationsconnect.NewNotificationsServiceClient(httpServer.Client(), httpServer.URL)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	request := connect.NewRequest(&notifications.WatchNotificationsRequest{AfterId: missed.GetId() - 1})
+	request.Header().Set("Authorization", "Bearer token-a")
+	stream, err := client.WatchNotifications(ctx, request)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	// The missed notification is sent first.
+	if !stream.Receive() {
+		t.Fatalf("%+v", stream.Err())
+	}
+	if got, want := stream.Msg().GetNotification(), missed; !cmp.Equal(want, got, protocmp.Transform()) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, protocmp.Transform()))
+	}
+
+	// Wait for the subscription before notifying.
+	for deadline := time.Now().Add(5 * time.Second); ; {
+		server.mutex.Lock()
+		subscribed := len(server.subscribers[userA]) > 0
+		server.mutex.Unlock()
+		if subscribed {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("the stream did not subscribe")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	failed := &notifications.Notification{Type: notifications.NotificationType_NOTIFICATION_TYPE_UPGRADE_FAILED, Reason: "timed out", InstalledPackageRef: installedRef("my-apache")}
+	server.notify(userA, failed)
+	if !stream.Receive() {
+		t.Fatalf("%+v", stream.Err())
+	}
+	if got, want := stream.Msg().GetNotification(), failed; !cmp.Equal(want, got, protocmp.Transform()) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, protocmp.Transform()))
+	}
+	if got, want := failed.GetId() > missed.GetId(), true; got != want {
+		t.Errorf("got: %t, want: %t", got, want)
+	}
+}
+
+func TestWatchNotificationsErrors(t *testing.T) {
+	now := time.Date(2023, 6, 3, 2, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name         string
+		tracker      *Tracker
+		token        string
+		expectedCode connect.Code
+	}{
+		{
+			name:         "it returns failed precondition when the notifications are disabled",
+			token:        "token-a",
+			expectedCode: connect.CodeFailedPrecondition,
+		},
+		{
+			name:         "it returns unauthenticated for an unknown user",
+			tracker:      newTestTracker(map[string]string{"token-a": "user-a"}, &now),
+			token:        "unknown-token",
+			expectedCode: connect.CodeUnauthenticated,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := NewNotificationsServer(tc.tracker, &fakePackagesServer{}, &fakeRepositoriesServer{}, nil, time.Second)
+			request := connect.NewRequest(&notifications.WatchNotificationsRequest{})
+			request.Header().Set("Authorization", "Bearer "+tc.token)
+
+			err := server.WatchNotifications(context.Background(), request, nil)
+			if got, want := connect.CodeOf(err), tc.expectedCode; got != want {
+				t.Errorf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,305 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core/authn"
+	packages "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1"
+	packagesv1connect "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1/v1connect"
+	packagesv1alpha1connect "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1/v1alpha1connect"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"k8s.io/client-go/kubernetes"
+	log "k8s.io/klog/v2"
+)
+
+const (
+	// operationTTL is the time after which an operation whose outcome is
+	// still unknown is forgotten.
+	operationTTL = time.Hour
+
+	// recordTimeout is the maximum time spent identifying the user of an
+	// operation and passing it to the listeners, once the request starting
+	// it succeeded.
+	recordTimeout = 5 * time.Second
+)
+
+// OperationKind is a kind of operation started by a user.
+type OperationKind int
+
+const (
+	OperationInstall OperationKind = iota
+	OperationUpgrade
+	OperationRepositorySync
+)
+
+// RecordedOperation is an operation started by a user, as passed to the
+// listeners of the tracker. Only one of the references is set, depending on
+// the kind of the operation.
+type RecordedOperation struct {
+	Kind                OperationKind
+	InstalledPackageRef *packages.InstalledPackageReference
+	PackageRepoRef      *packages.PackageRepositoryReference
+	StartedAt           time.Time
+}
+
+// Listener is called with the key of the user, as returned by
+// authn.UserKey, once an operation they started is recorded. It is called in
+// the background, with a context bounded by the record timeout.
+type Listener func(ctx context.Context, user string, op RecordedOperation)
+
+// trackedProcedures are the procedures starting the operations whose outcome
+// is notified, for both versions of the core packages API. The requests to
+// the v1 API are forwarded to the v1alpha1 server without being intercepted
+// again, so each operation is recorded once.
+var trackedProcedures = map[string]OperationKind{
+	packagesv1alpha1connect.PackagesServiceCreateInstalledPackageProcedure:      OperationInstall,
+	packagesv1alpha1connect.PackagesServiceUpdateInstalledPackageProcedure:      OperationUpgrade,
+	packagesv1alpha1connect.RepositoriesServiceAddPackageRepositoryProcedure:    OperationRepositorySync,
+	packagesv1alpha1connect.RepositoriesServiceUpdatePackageRepositoryProcedure: OperationRepositorySync,
+	packagesv1connect.PackagesServiceCreateInstalledPackageProcedure:            OperationInstall,
+	packagesv1connect.PackagesServiceUpdateInstalledPackageProcedure:            OperationUpgrade,
+	packagesv1connect.RepositoriesServiceAddPackageRepositoryProcedure:          OperationRepositorySync,
+	packagesv1connect.RepositoriesServiceUpdatePackageRepositoryProcedure:       OperationRepositorySync,
+}
+
+// operation is an operation started by a user, followed until its outcome is
+// notified or it expires.
+type operation struct {
+	kind                OperationKind
+	installedPackageRef *packages.InstalledPackageReference
+	packageRepoRef      *packages.PackageRepositoryReference
+	startedAt           time.Time
+
+	// pending is whether the operation was observed in progress, and
+	// failedReason the reason of the failure last notified, if any, so that
+	// a failure is notified once and a recovery is detected.
+	pending      bool
+	failed       bool
+	failedReason string
+}
+
+// resourceKey identifies the resource of the operation, so that a new
+// operation on the same resource replaces the previous one.
+func (o *operation) resourceKey() string {
+	if o.kind == OperationRepositorySync {
+		ref := o.packageRepoRef
+		return fmt.Sprintf("repository/%s/%s/%s/%s", ref.GetPlugin().GetName(), ref.GetContext().GetCluster(), ref.GetContext().GetNamespace(), ref.GetIdentifier())
+	}
+	ref := o.installedPackageRef
+	return fmt.Sprintf("package/%s/%s/%s/%s", ref.GetPlugin().GetName(), ref.GetContext().GetCluster(), ref.GetContext().GetNamespace(), ref.GetIdentifier())
+}
+
+// Tracker records the operations started by each user through the core
+// packages API, so that their outcome can be notified, and passes them to its
+// listeners. The operations are only kept in memory: those started before a
+// restart are not notified.
+type Tracker struct {
+	// clientSet reviews the tokens of the tracked requests, to attribute the
+	// operations to their users.
+	clientSet kubernetes.Interface
+	now       func() time.Time
+
+	// async runs the recording of an operation once its request succeeded,
+	// in a goroutine so that the response is not delayed by the token review
+	// and the listeners. It can be switched in tests.
+	async func(func())
+
+	// listeners are registered before the tracker intercepts any request,
+	// so they are read without the mutex.
+	listeners []Listener
+
+	mutex      sync.Mutex
+	operations map[string][]*operation
+}
+
+// NewTracker returns a tracker identifying the users with the given client.
+func NewTracker(clientSet kubernetes.Interface) *Tracker {
+	return &Tracker{
+		clientSet:  clientSet,
+		now:        time.Now,
+		async:      func(f func()) { go f() },
+		operations: map[string][]*operation{},
+	}
+}
+
+// OnRecord registers a listener called with each operation recorded by the
+// tracker. It must be called before the interceptor is used.
+func (t *Tracker) OnRecord(listener Listener) {
+	t.listeners = append(t.listeners, listener)
+}
+
+// Interceptor returns a connect interceptor recording the operation started
+// by each tracked request which succeeded. The operation is recorded in the
+// background, after the response is returned.
+func (t *Tracker) Interceptor() connect.Interceptor {
+	return connect.UnaryInterceptorFunc(func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			kind, ok := trackedProcedures[req.Spec().Procedure]
+			if !ok {
+				return next(ctx, req)
+			}
+
+			res, err := next(ctx, req)
+			if err != nil {
+				return res, err
+			}
+
+			op, opErr := newOperation(kind, res.Any(), t.now())
+			if opErr != nil {
+				log.Errorf("Unable to record the operation of %s: %v", req.Spec().Procedure, opErr)
+				return res, err
+			}
+			// The operation is recorded even if the request was cancelled by
+			// the user once it succeeded.
+			recordCtx := context.WithoutCancel(ctx)
+			headers := req.Header().Clone()
+			t.async(func() {
+				t.track(recordCtx, req.Spec().Procedure, headers, op)
+			})
+			return res, err
+		}
+	})
+}
+
+// track identifies the user who started the operation, records it and passes
+// it to the listeners. A failure to identify the user is only logged, as the
+// request already succeeded.
+func (t *Tracker) track(ctx context.Context, procedure string, headers http.Header, op *operation) {
+	ctx, cancel := context.WithTimeout(ctx, recordTimeout)
+	defer cancel()
+	user, err := authn.UserKey(ctx, t.clientSet, headers)
+	if err != nil {
+		log.V(4).Infof("Unable to identify the user of %s: %v", procedure, err)
+		return
+	}
+	t.record(user, op)
+	for _, listener := range t.listeners {
+		listener(ctx, user, RecordedOperation{
+			Kind:                op.kind,
+			InstalledPackageRef: op.installedPackageRef,
+			PackageRepoRef:      op.packageRepoRef,
+			StartedAt:           op.startedAt,
+		})
+	}
+}
+
+// newOperation returns the operation started by a request from its response,
+// which references the installed package or the package repository.
+func newOperation(kind OperationKind, response any, now time.Time) (*operation, error) {
+	msg, ok := response.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("unexpected response %T", response)
+	}
+	op := &operation{kind: kind, startedAt: now}
+	if kind == OperationRepositorySync {
+		op.packageRepoRef = &packages.PackageRepositoryReference{}
+		if err := translateField(msg, "package_repo_ref", op.packageRepoRef); err != nil {
+			return nil, err
+		}
+	} else {
+		op.installedPackageRef = &packages.InstalledPackageReference{}
+		if err := translateField(msg, "installed_package_ref", op.installedPackageRef); err != nil {
+			return nil, err
+		}
+	}
+	return op, nil
+}
+
+// translateField sets the message to the value of the given field of the
+// response. Both versions of the core packages API share the same wire format,
+// so the field is simply encoded and decoded again as a v1 message.
+func translateField(response proto.Message, name string, to proto.Message) error {
+	field := response.ProtoReflect().Descriptor().Fields().ByName(protoreflect.Name(name))
+	if field == nil || !response.ProtoReflect().Has(field) {
+		return fmt.Errorf("missing %s in %T", name, response)
+	}
+	return translate(response.ProtoReflect().Get(field).Message().Interface(), to)
+}
+
+// record records the operation of the user, replacing any previous operation
+// on the same resource. The expired operations of every user are forgotten,
+// so that those of the users who never watch their notifications do not
+// accumulate.
+func (t *Tracker) record(user string, op *operation) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	now := t.now()
+	for key, existing := range t.operations {
+		operations := []*operation{}
+		for _, existingOp := range existing {
+			if now.Sub(existingOp.startedAt) < operationTTL {
+				operations = append(operations, existingOp)
+			}
+		}
+		if len(operations) == 0 {
+			delete(t.operations, key)
+		} else {
+			t.operations[key] = operations
+		}
+	}
+	operations := []*operation{op}
+	for _, existing := range t.operations[user] {
+		if existing.resourceKey() != op.resourceKey() {
+			operations = append(operations, existing)
+		}
+	}
+	t.operations[user] = operations
+}
+
+// pending returns the operations of the user whose outcome is still to be
+// notified, forgetting the expired ones.
+func (t *Tracker) pending(user string) []*operation {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	now := t.now()
+	operations := []*operation{}
+	for _, op := range t.operations[user] {
+		if now.Sub(op.startedAt) < operationTTL {
+			operations = append(operations, op)
+		}
+	}
+	if len(operations) == 0 {
+		delete(t.operations, user)
+		return nil
+	}
+	t.operations[user] = operations
+	return append([]*operation{}, operations...)
+}
+
+// has returns whether the operation of the user is still recorded.
+func (t *Tracker) has(user string, op *operation) bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	for _, existing := range t.operations[user] {
+		if existing == op {
+			return true
+		}
+	}
+	return false
+}
+
+// done forgets an operation of the user once its outcome is notified.
+func (t *Tracker) done(user string, op *operation) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	operations := []*operation{}
+	for _, existing := range t.operations[user] {
+		if existing != op {
+			operations = append(operations, existing)
+		}
+	}
+	if len(operations) == 0 {
+		delete(t.operations, user)
+		return
+	}
+	t.operations[user] = operations
+}
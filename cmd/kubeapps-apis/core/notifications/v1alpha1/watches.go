@@ -0,0 +1,172 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core/authn"
+	packages "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1"
+	packagesv1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	resources "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/plugins/resources/v1alpha1"
+	"google.golang.org/protobuf/proto"
+	log "k8s.io/klog/v2"
+)
+
+// resourceWatch is a watch of the resources of an installed package, through
+// the stream of the resources plugin.
+type resourceWatch struct {
+	cancel context.CancelFunc
+}
+
+// watchKey identifies the watch of the resource of an operation of a user.
+func watchKey(user string, op *operation) string {
+	return user + "/" + op.resourceKey()
+}
+
+// watch watches the resources of the installed package of the operation, if
+// not already watched, so that the operation is checked each time they
+// change. It returns false if they cannot be watched, for instance before
+// the plugin created them, in which case the operation is checked at the
+// interval.
+func (s *notificationsServer) watch(ctx context.Context, user string, op *operation, headers http.Header) bool {
+	if s.resourcesClient == nil || op.kind == OperationRepositorySync {
+		return false
+	}
+	key := watchKey(user, op)
+	s.mutex.Lock()
+	_, watched := s.watches[key]
+	s.mutex.Unlock()
+	if watched {
+		return true
+	}
+
+	request, err := s.resourcesRequest(ctx, op, headers)
+	if err != nil {
+		log.V(4).Infof("Unable to watch the resources of the %s: %v", op.resourceKey(), err)
+		return false
+	}
+	if request == nil {
+		return false
+	}
+	watchCtx, cancel := context.WithCancel(ctx)
+	stream, err := s.resourcesClient.GetResources(watchCtx, request)
+	if err != nil {
+		cancel()
+		log.V(4).Infof("Unable to watch the resources of the %s: %v", op.resourceKey(), err)
+		return false
+	}
+
+	w := &resourceWatch{cancel: cancel}
+	s.mutex.Lock()
+	s.watches[key] = w
+	s.mutex.Unlock()
+
+	go func() {
+		defer s.unwatch(key, w)
+		defer stream.Close()
+		for stream.Receive() {
+			s.checkWatched(watchCtx, user, op.resourceKey(), headers)
+		}
+		if err := stream.Err(); err != nil && watchCtx.Err() == nil {
+			log.V(4).Infof("Stopped watching the resources of the %s: %v", op.resourceKey(), err)
+		}
+	}()
+	return true
+}
+
+// resourcesRequest returns the request watching the resources of the
+// installed package of the operation, or nil if it has no resources yet.
+func (s *notificationsServer) resourcesRequest(ctx context.Context, op *operation, headers http.Header) (*connect.Request[resources.GetResourcesRequest], error) {
+	refsRequest := connect.NewRequest(&packages.GetInstalledPackageResourceRefsRequest{
+		InstalledPackageRef: op.installedPackageRef,
+	})
+	authn.CopyHeaders(refsRequest.Header(), headers)
+	refsResponse, err := s.packagesServer.GetInstalledPackageResourceRefs(ctx, refsRequest)
+	if err != nil {
+		return nil, err
+	}
+	if len(refsResponse.Msg.GetResourceRefs()) == 0 {
+		return nil, nil
+	}
+
+	// The resources plugin serves the v1alpha1 messages, which share the
+	// wire format of the v1 ones.
+	msg := &resources.GetResourcesRequest{
+		InstalledPackageRef: &packagesv1alpha1.InstalledPackageReference{},
+		Watch:               true,
+	}
+	if err := translate(op.installedPackageRef, msg.InstalledPackageRef); err != nil {
+		return nil, err
+	}
+	for _, ref := range refsResponse.Msg.GetResourceRefs() {
+		alphaRef := &packagesv1alpha1.ResourceRef{}
+		if err := translate(ref, alphaRef); err != nil {
+			return nil, err
+		}
+		msg.ResourceRefs = append(msg.ResourceRefs, alphaRef)
+	}
+	request := connect.NewRequest(msg)
+	authn.CopyHeaders(request.Header(), headers)
+	return request, nil
+}
+
+// checkWatched checks the operation of the user on the given resource, after
+// a change of its resources, stopping the watch once the operation is done.
+func (s *notificationsServer) checkWatched(ctx context.Context, user, resourceKey string, headers http.Header) {
+	for _, op := range s.tracker.pending(user) {
+		if op.resourceKey() == resourceKey {
+			if !s.checkAndNotify(ctx, user, op, headers) {
+				return
+			}
+			break
+		}
+	}
+	s.stopWatch(user + "/" + resourceKey)
+}
+
+// stopWatches stops the watches other than the given ones, whose user is no
+// longer watching their notifications or whose operation is done.
+func (s *notificationsServer) stopWatches(active map[string]bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for key, w := range s.watches {
+		if !active[key] {
+			w.cancel()
+			delete(s.watches, key)
+		}
+	}
+}
+
+func (s *notificationsServer) stopWatch(key string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if w, ok := s.watches[key]; ok {
+		w.cancel()
+		delete(s.watches, key)
+	}
+}
+
+// unwatch forgets the watch once its stream ended, unless it was replaced.
+func (s *notificationsServer) unwatch(key string, w *resourceWatch) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	w.cancel()
+	if s.watches[key] == w {
+		delete(s.watches, key)
+	}
+}
+
+// translate converts a message between its v1 and v1alpha1 versions, which
+// share the same wire format.
+func translate(from, to proto.Message) error {
+	bytes, err := proto.Marshal(from)
+	if err != nil {
+		return fmt.Errorf("unable to translate %T: %w", from, err)
+	}
+	return proto.Unmarshal(bytes, to)
+}
@@ -0,0 +1,91 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import (
+	"context"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core/pins"
+	packages "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1"
+	packagesv1alpha1connect "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1/v1alpha1connect"
+)
+
+// packagesServer implements the API defined in proto/kubeappsapis/core/packages/v1/packages.proto
+// by translating each request to the deprecated core.packages.v1alpha1 API, which
+// aggregates the results of the packaging plugins.
+type packagesServer struct {
+	packages.UnimplementedPackagesServiceServer
+
+	// v1alpha1Server is the core.packages.v1alpha1 server the requests are
+	// translated to.
+	v1alpha1Server packagesv1alpha1connect.PackagesServiceHandler
+
+	// pins checks the installs and updates made on behalf of the bulk and
+	// clone requests, which are not intercepted as the others.
+	pins *pins.Checker
+}
+
+func NewPackagesServer(v1alpha1Server packagesv1alpha1connect.PackagesServiceHandler, pinsChecker *pins.Checker) *packagesServer {
+	return &packagesServer{
+		v1alpha1Server: v1alpha1Server,
+		pins:           pinsChecker,
+	}
+}
+
+func (s packagesServer) GetAvailablePackageSummaries(ctx context.Context, request *connect.Request[packages.GetAvailablePackageSummariesRequest]) (*connect.Response[packages.GetAvailablePackageSummariesResponse], error) {
+	return forward[packages.GetAvailablePackageSummariesResponse](ctx, request, s.v1alpha1Server.GetAvailablePackageSummaries)
+}
+
+func (s packagesServer) GetAvailablePackageDetail(ctx context.Context, request *connect.Request[packages.GetAvailablePackageDetailRequest]) (*connect.Response[packages.GetAvailablePackageDetailResponse], error) {
+	return forward[packages.GetAvailablePackageDetailResponse](ctx, request, s.v1alpha1Server.GetAvailablePackageDetail)
+}
+
+func (s packagesServer) GetAvailablePackageVersions(ctx context.Context, request *connect.Request[packages.GetAvailablePackageVersionsRequest]) (*connect.Response[packages.GetAvailablePackageVersionsResponse], error) {
+	return forward[packages.GetAvailablePackageVersionsResponse](ctx, request, s.v1alpha1Server.GetAvailablePackageVersions)
+}
+
+func (s packagesServer) GetAvailablePackageTextAsset(ctx context.Context, request *connect.Request[packages.GetAvailablePackageTextAssetRequest]) (*connect.Response[packages.GetAvailablePackageTextAssetResponse], error) {
+	return forward[packages.GetAvailablePackageTextAssetResponse](ctx, request, s.v1alpha1Server.GetAvailablePackageTextAsset)
+}
+
+func (s packagesServer) GetAvailablePackageChangelog(ctx context.Context, request *connect.Request[packages.GetAvailablePackageChangelogRequest]) (*connect.Response[packages.GetAvailablePackageChangelogResponse], error) {
+	return forward[packages.GetAvailablePackageChangelogResponse](ctx, request, s.v1alpha1Server.GetAvailablePackageChangelog)
+}
+
+func (s packagesServer) GetAvailablePackageFilters(ctx context.Context, request *connect.Request[packages.GetAvailablePackageFiltersRequest]) (*connect.Response[packages.GetAvailablePackageFiltersResponse], error) {
+	return forward[packages.GetAvailablePackageFiltersResponse](ctx, request, s.v1alpha1Server.GetAvailablePackageFilters)
+}
+
+func (s packagesServer) GetInstalledPackageSummaries(ctx context.Context, request *connect.Request[packages.GetInstalledPackageSummariesRequest]) (*connect.Response[packages.GetInstalledPackageSummariesResponse], error) {
+	return forward[packages.GetInstalledPackageSummariesResponse](ctx, request, s.v1alpha1Server.GetInstalledPackageSummaries)
+}
+
+func (s packagesServer) GetInstalledPackageDetail(ctx context.Context, request *connect.Request[packages.GetInstalledPackageDetailRequest]) (*connect.Response[packages.GetInstalledPackageDetailResponse], error) {
+	return forward[packages.GetInstalledPackageDetailResponse](ctx, request, s.v1alpha1Server.GetInstalledPackageDetail)
+}
+
+func (s packagesServer) CheckInstalledPackageNameAvailability(ctx context.Context, request *connect.Request[packages.CheckInstalledPackageNameAvailabilityRequest]) (*connect.Response[packages.CheckInstalledPackageNameAvailabilityResponse], error) {
+	return forward[packages.CheckInstalledPackageNameAvailabilityResponse](ctx, request, s.v1alpha1Server.CheckInstalledPackageNameAvailability)
+}
+
+func (s packagesServer) CreateInstalledPackage(ctx context.Context, request *connect.Request[packages.CreateInstalledPackageRequest]) (*connect.Response[packages.CreateInstalledPackageResponse], error) {
+	return forward[packages.CreateInstalledPackageResponse](ctx, request, s.v1alpha1Server.CreateInstalledPackage)
+}
+
+func (s packagesServer) UpdateInstalledPackage(ctx context.Context, request *connect.Request[packages.UpdateInstalledPackageRequest]) (*connect.Response[packages.UpdateInstalledPackageResponse], error) {
+	return forward[packages.UpdateInstalledPackageResponse](ctx, request, s.v1alpha1Server.UpdateInstalledPackage)
+}
+
+func (s packagesServer) DeleteInstalledPackage(ctx context.Context, request *connect.Request[packages.DeleteInstalledPackageRequest]) (*connect.Response[packages.DeleteInstalledPackageResponse], error) {
+	return forward[packages.DeleteInstalledPackageResponse](ctx, request, s.v1alpha1Server.DeleteInstalledPackage)
+}
+
+func (s packagesServer) GetInstalledPackageResourceRefs(ctx context.Context, request *connect.Request[packages.GetInstalledPackageResourceRefsRequest]) (*connect.Response[packages.GetInstalledPackageResourceRefsResponse], error) {
+	return forward[packages.GetInstalledPackageResourceRefsResponse](ctx, request, s.v1alpha1Server.GetInstalledPackageResourceRefs)
+}
+
+func (s packagesServer) GetInstalledPackageOperationStatus(ctx context.Context, request *connect.Request[packages.GetInstalledPackageOperationStatusRequest]) (*connect.Response[packages.GetInstalledPackageOperationStatusResponse], error) {
+	return forward[packages.GetInstalledPackageOperationStatusResponse](ctx, request, s.v1alpha1Server.GetInstalledPackageOperationStatus)
+}
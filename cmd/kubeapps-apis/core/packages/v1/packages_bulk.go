@@ -0,0 +1,148 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/bufbuild/connect-go"
+	packages "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1"
+	log "k8s.io/klog/v2"
+)
+
+// defaultBulkConcurrency is the number of operations of a bulk request
+// performed at the same time when the request does not set it.
+const defaultBulkConcurrency = 10
+
+// BulkUpdateInstalledPackages updates each installed package to the requested
+// version, keeping its values and reconciliation options.
+func (s packagesServer) BulkUpdateInstalledPackages(ctx context.Context, request *connect.Request[packages.BulkUpdateInstalledPackagesRequest]) (*connect.Response[packages.BulkUpdateInstalledPackagesResponse], error) {
+	log.InfoS("+core BulkUpdateInstalledPackages", "count", len(request.Msg.GetInstalledPackageRefs()), "version", request.Msg.GetPkgVersionReference().GetVersion())
+
+	if err := validateBulkRequest(request.Msg.GetInstalledPackageRefs(), request.Msg.GetMaxConcurrency()); err != nil {
+		return nil, err
+	}
+	if request.Msg.GetPkgVersionReference().GetVersion() == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Unable to update the installed packages: a version is required"))
+	}
+
+	// The header is read once, since its lazy initialization is not safe for
+	// concurrent use.
+	header := request.Header()
+	results := runBulk(request.Msg.GetInstalledPackageRefs(), request.Msg.GetMaxConcurrency(), func(ref *packages.InstalledPackageReference) error {
+		return s.updateToVersion(ctx, ref, request.Msg.GetPkgVersionReference(), header)
+	})
+	return connect.NewResponse(&packages.BulkUpdateInstalledPackagesResponse{
+		Results: results,
+	}), nil
+}
+
+// BulkDeleteInstalledPackages deletes each installed package with the
+// requested custom detail.
+func (s packagesServer) BulkDeleteInstalledPackages(ctx context.Context, request *connect.Request[packages.BulkDeleteInstalledPackagesRequest]) (*connect.Response[packages.BulkDeleteInstalledPackagesResponse], error) {
+	log.InfoS("+core BulkDeleteInstalledPackages", "count", len(request.Msg.GetInstalledPackageRefs()))
+
+	if err := validateBulkRequest(request.Msg.GetInstalledPackageRefs(), request.Msg.GetMaxConcurrency()); err != nil {
+		return nil, err
+	}
+
+	header := request.Header()
+	results := runBulk(request.Msg.GetInstalledPackageRefs(), request.Msg.GetMaxConcurrency(), func(ref *packages.InstalledPackageReference) error {
+		_, err := s.DeleteInstalledPackage(ctx, newRequest(&packages.DeleteInstalledPackageRequest{
+			InstalledPackageRef: ref,
+			CustomDetail:        request.Msg.GetCustomDetail(),
+		}, header))
+		return err
+	})
+	return connect.NewResponse(&packages.BulkDeleteInstalledPackagesResponse{
+		Results: results,
+	}), nil
+}
+
+// updateToVersion updates the installed package to the given version with the
+// reconciliation options currently applied, since an update otherwise resets
+// them. The plugin keeps the values it stores for the installed package, which
+// may differ from the values applied returned in its detail, and without a
+// custom detail, it leaves its own settings unchanged.
+func (s packagesServer) updateToVersion(ctx context.Context, ref *packages.InstalledPackageReference, version *packages.VersionReference, header http.Header) error {
+	detail, err := s.GetInstalledPackageDetail(ctx, newRequest(&packages.GetInstalledPackageDetailRequest{
+		InstalledPackageRef: ref,
+	}, header))
+	if err != nil {
+		return err
+	}
+	updateRequest := &packages.UpdateInstalledPackageRequest{
+		InstalledPackageRef:   ref,
+		PkgVersionReference:   version,
+		ReuseValues:           true,
+		ReconciliationOptions: detail.Msg.GetInstalledPackageDetail().GetReconciliationOptions(),
+	}
+	if err := s.pins.CheckRequest(ctx, updateRequest); err != nil {
+		return err
+	}
+	_, err = s.UpdateInstalledPackage(ctx, newRequest(updateRequest, header))
+	return err
+}
+
+// validateBulkRequest checks that every installed package of a bulk request
+// is fully referenced and appears only once, so that no package is operated on
+// concurrently with itself.
+func validateBulkRequest(refs []*packages.InstalledPackageReference, maxConcurrency int32) error {
+	if len(refs) == 0 {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Unable to perform the bulk operation: at least one installed package is required"))
+	}
+	if maxConcurrency < 0 {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Unable to perform the bulk operation: invalid max concurrency %d", maxConcurrency))
+	}
+	keys := map[string]bool{}
+	for i, ref := range refs {
+		if ref.GetPlugin() == nil || ref.GetContext().GetNamespace() == "" || ref.GetIdentifier() == "" {
+			return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Unable to perform the bulk operation: the installed package %d requires a plugin, a namespace and an identifier", i))
+		}
+		key := fmt.Sprintf("%s/%s/%s/%s", ref.GetPlugin().GetName(), ref.GetContext().GetCluster(), ref.GetContext().GetNamespace(), ref.GetIdentifier())
+		if keys[key] {
+			return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Unable to perform the bulk operation: the installed package %q of the namespace %q is duplicated", ref.GetIdentifier(), ref.GetContext().GetNamespace()))
+		}
+		keys[key] = true
+	}
+	return nil
+}
+
+// runBulk runs the operation on each installed package, with at most
+// maxConcurrency of them at the same time, and returns their results in the
+// order of the references.
+func runBulk(refs []*packages.InstalledPackageReference, maxConcurrency int32, operation func(*packages.InstalledPackageReference) error) []*packages.BulkInstalledPackageResult {
+	if maxConcurrency == 0 {
+		maxConcurrency = defaultBulkConcurrency
+	}
+
+	results := make([]*packages.BulkInstalledPackageResult, len(refs))
+	semaphore := make(chan struct{}, maxConcurrency)
+	var wg sync.WaitGroup
+	for i, ref := range refs {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(i int, ref *packages.InstalledPackageReference) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			result := &packages.BulkInstalledPackageResult{InstalledPackageRef: ref}
+			if err := operation(ref); err != nil {
+				log.Errorf("Bulk operation failed for the installed package %q of the namespace %q: %v", ref.GetIdentifier(), ref.GetContext().GetNamespace(), err)
+				result.ErrorCode = connect.CodeOf(err).String()
+				result.ErrorMessage = err.Error()
+				if connectErr := new(connect.Error); errors.As(err, &connectErr) {
+					result.ErrorMessage = connectErr.Message()
+				}
+			}
+			results[i] = result
+		}(i, ref)
+	}
+	wg.Wait()
+	return results
+}
@@ -0,0 +1,306 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/google/go-cmp/cmp"
+	packagesv1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core/packages/v1alpha1"
+	pluginsv1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core/plugins/v1alpha1"
+	packages "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1"
+	corev1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	plugins "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/plugins/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugin_test"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+func bulkRef(namespace string, plugin *plugins.Plugin) *packages.InstalledPackageReference {
+	return &packages.InstalledPackageReference{
+		Context:    &packages.Context{Cluster: "default", Namespace: namespace},
+		Identifier: "my-apache",
+		Plugin:     plugin,
+	}
+}
+
+func TestBulkUpdateInstalledPackages(t *testing.T) {
+	unknownPlugin := &plugins.Plugin{Name: "unknown", Version: "v1alpha1"}
+
+	testCases := []struct {
+		name              string
+		request           *packages.BulkUpdateInstalledPackagesRequest
+		pluginErrorCode   connect.Code
+		pin               string
+		expectedResults   []*packages.BulkInstalledPackageResult
+		expectedErrorCode connect.Code
+	}{
+		{
+			name: "it updates every installed package, in the order of the request",
+			request: &packages.BulkUpdateInstalledPackagesRequest{
+				InstalledPackageRefs: []*packages.InstalledPackageReference{
+					bulkRef("ns-1", mockPlugin),
+					bulkRef("ns-2", mockPlugin),
+					bulkRef("ns-3", mockPlugin),
+				},
+				PkgVersionReference: &packages.VersionReference{Version: "1.2.3"},
+				MaxConcurrency:      2,
+			},
+			expectedResults: []*packages.BulkInstalledPackageResult{
+				{InstalledPackageRef: bulkRef("ns-1", mockPlugin)},
+				{InstalledPackageRef: bulkRef("ns-2", mockPlugin)},
+				{InstalledPackageRef: bulkRef("ns-3", mockPlugin)},
+			},
+		},
+		{
+			name: "it reports the failed updates without preventing the others",
+			request: &packages.BulkUpdateInstalledPackagesRequest{
+				InstalledPackageRefs: []*packages.InstalledPackageReference{
+					bulkRef("ns-1", unknownPlugin),
+					bulkRef("ns-2", mockPlugin),
+				},
+				PkgVersionReference: &packages.VersionReference{Version: "1.2.3"},
+			},
+			expectedResults: []*packages.BulkInstalledPackageResult{
+				{
+					InstalledPackageRef: bulkRef("ns-1", unknownPlugin),
+					ErrorCode:           "internal",
+				},
+				{InstalledPackageRef: bulkRef("ns-2", mockPlugin)},
+			},
+		},
+		{
+			name: "it reports the updates rejected by a package pin",
+			request: &packages.BulkUpdateInstalledPackagesRequest{
+				InstalledPackageRefs: []*packages.InstalledPackageReference{
+					bulkRef("ns-1", mockPlugin),
+					bulkRef("ns-2", mockPlugin),
+				},
+				PkgVersionReference: &packages.VersionReference{Version: "1.2.3"},
+			},
+			pin: `{"namespace": "ns-2", "name": "my-apache", "versionConstraint": "^2.0.0"}`,
+			expectedResults: []*packages.BulkInstalledPackageResult{
+				{InstalledPackageRef: bulkRef("ns-1", mockPlugin)},
+				{
+					InstalledPackageRef: bulkRef("ns-2", mockPlugin),
+					ErrorCode:           "failed_precondition",
+				},
+			},
+		},
+		{
+			name: "it reports the error code of the plugin",
+			request: &packages.BulkUpdateInstalledPackagesRequest{
+				InstalledPackageRefs: []*packages.InstalledPackageReference{
+					bulkRef("ns-1", mockPlugin),
+				},
+				PkgVersionReference: &packages.VersionReference{Version: "1.2.3"},
+			},
+			pluginErrorCode: connect.CodePermissionDenied,
+			expectedResults: []*packages.BulkInstalledPackageResult{
+				{
+					InstalledPackageRef: bulkRef("ns-1", mockPlugin),
+					ErrorCode:           "permission_denied",
+				},
+			},
+		},
+		{
+			name: "it returns an invalid argument error without a version",
+			request: &packages.BulkUpdateInstalledPackagesRequest{
+				InstalledPackageRefs: []*packages.InstalledPackageReference{
+					bulkRef("ns-1", mockPlugin),
+				},
+			},
+			expectedErrorCode: connect.CodeInvalidArgument,
+		},
+		{
+			name: "it returns an invalid argument error for duplicated installed packages",
+			request: &packages.BulkUpdateInstalledPackagesRequest{
+				InstalledPackageRefs: []*packages.InstalledPackageReference{
+					bulkRef("ns-1", mockPlugin),
+					bulkRef("ns-1", mockPlugin),
+				},
+				PkgVersionReference: &packages.VersionReference{Version: "1.2.3"},
+			},
+			expectedErrorCode: connect.CodeInvalidArgument,
+		},
+		{
+			name: "it returns an invalid argument error without installed packages",
+			request: &packages.BulkUpdateInstalledPackagesRequest{
+				PkgVersionReference: &packages.VersionReference{Version: "1.2.3"},
+			},
+			expectedErrorCode: connect.CodeInvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestPackagesServer(t, tc.pluginErrorCode)
+			if tc.pin != "" {
+				server.pins = newTestPinsChecker(t, tc.pin)
+			}
+
+			response, err := server.BulkUpdateInstalledPackages(context.Background(), connect.NewRequest(tc.request))
+
+			if got, want := connect.CodeOf(err), tc.expectedErrorCode; err != nil && got != want {
+				t.Fatalf("got: %v, want: %v", got, want)
+			}
+			if tc.expectedErrorCode != 0 {
+				if err == nil {
+					t.Fatalf("got: nil, want: %v", tc.expectedErrorCode)
+				}
+				return
+			}
+
+			// The error messages of the plugins are checked by their own tests.
+			opts := cmp.Options{protocmp.Transform(), protocmp.IgnoreFields(&packages.BulkInstalledPackageResult{}, "error_message")}
+			if got, want := response.Msg.GetResults(), tc.expectedResults; !cmp.Equal(want, got, opts) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, opts))
+			}
+		})
+	}
+}
+
+// recordingPackagingPluginServer is a test plugin recording the update
+// requests it receives.
+type recordingPackagingPluginServer struct {
+	*plugin_test.TestPackagingPluginServer
+	mutex   sync.Mutex
+	updates []*corev1alpha1.UpdateInstalledPackageRequest
+}
+
+func (s *recordingPackagingPluginServer) UpdateInstalledPackage(ctx context.Context, request *connect.Request[corev1alpha1.UpdateInstalledPackageRequest]) (*connect.Response[corev1alpha1.UpdateInstalledPackageResponse], error) {
+	s.mutex.Lock()
+	s.updates = append(s.updates, request.Msg)
+	s.mutex.Unlock()
+	return s.TestPackagingPluginServer.UpdateInstalledPackage(ctx, request)
+}
+
+func TestBulkUpdateInstalledPackagesReusesValues(t *testing.T) {
+	pluginServer := &recordingPackagingPluginServer{TestPackagingPluginServer: plugin_test.NewTestPackagingPlugin(mockPlugin)}
+	pluginServer.InstalledPackageDetail = plugin_test.MakeInstalledPackageDetail("pkg-1", mockPlugin)
+	pluginServer.InstalledPackageDetail.ReconciliationOptions = &corev1alpha1.ReconciliationOptions{Interval: "10m"}
+	v1alpha1Server, err := packagesv1alpha1.NewPackagesServer([]pluginsv1alpha1.PluginWithServer{
+		{Plugin: mockPlugin, Server: pluginServer},
+	}, func() []string { return []string{"default"} }, nil, nil, 0)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	server := NewPackagesServer(v1alpha1Server, nil)
+
+	_, err = server.BulkUpdateInstalledPackages(context.Background(), connect.NewRequest(&packages.BulkUpdateInstalledPackagesRequest{
+		InstalledPackageRefs: []*packages.InstalledPackageReference{bulkRef("ns-1", mockPlugin)},
+		PkgVersionReference:  &packages.VersionReference{Version: "1.2.3"},
+	}))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	// The values applied of the detail are not sent back, the plugin keeping
+	// the values it stores.
+	expectedUpdates := []*corev1alpha1.UpdateInstalledPackageRequest{
+		{
+			InstalledPackageRef: &corev1alpha1.InstalledPackageReference{
+				Context:    &corev1alpha1.Context{Cluster: "default", Namespace: "ns-1"},
+				Identifier: "my-apache",
+				Plugin:     mockPlugin,
+			},
+			PkgVersionReference:   &corev1alpha1.VersionReference{Version: "1.2.3"},
+			ReuseValues:           true,
+			ReconciliationOptions: &corev1alpha1.ReconciliationOptions{Interval: "10m"},
+		},
+	}
+	if got, want := pluginServer.updates, expectedUpdates; !cmp.Equal(want, got, protocmp.Transform()) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, protocmp.Transform()))
+	}
+}
+
+func TestBulkDeleteInstalledPackages(t *testing.T) {
+	testCases := []struct {
+		name              string
+		request           *packages.BulkDeleteInstalledPackagesRequest
+		pluginErrorCode   connect.Code
+		expectedResults   []*packages.BulkInstalledPackageResult
+		expectedErrorCode connect.Code
+	}{
+		{
+			name: "it deletes every installed package, in the order of the request",
+			request: &packages.BulkDeleteInstalledPackagesRequest{
+				InstalledPackageRefs: []*packages.InstalledPackageReference{
+					bulkRef("ns-1", mockPlugin),
+					bulkRef("ns-2", mockPlugin),
+				},
+				MaxConcurrency: 1,
+			},
+			expectedResults: []*packages.BulkInstalledPackageResult{
+				{InstalledPackageRef: bulkRef("ns-1", mockPlugin)},
+				{InstalledPackageRef: bulkRef("ns-2", mockPlugin)},
+			},
+		},
+		{
+			name: "it reports the error code of the plugin for each installed package",
+			request: &packages.BulkDeleteInstalledPackagesRequest{
+				InstalledPackageRefs: []*packages.InstalledPackageReference{
+					bulkRef("ns-1", mockPlugin),
+					bulkRef("ns-2", mockPlugin),
+				},
+			},
+			pluginErrorCode: connect.CodeNotFound,
+			expectedResults: []*packages.BulkInstalledPackageResult{
+				{
+					InstalledPackageRef: bulkRef("ns-1", mockPlugin),
+					ErrorCode:           "not_found",
+					ErrorMessage:        `Unable to delete the installed packagefor the package "my-apache" using the plugin "mock1": not_found: Non-OK response`,
+				},
+				{
+					InstalledPackageRef: bulkRef("ns-2", mockPlugin),
+					ErrorCode:           "not_found",
+					ErrorMessage:        `Unable to delete the installed packagefor the package "my-apache" using the plugin "mock1": not_found: Non-OK response`,
+				},
+			},
+		},
+		{
+			name: "it returns an invalid argument error for a negative max concurrency",
+			request: &packages.BulkDeleteInstalledPackagesRequest{
+				InstalledPackageRefs: []*packages.InstalledPackageReference{
+					bulkRef("ns-1", mockPlugin),
+				},
+				MaxConcurrency: -1,
+			},
+			expectedErrorCode: connect.CodeInvalidArgument,
+		},
+		{
+			name: "it returns an invalid argument error for an incomplete reference",
+			request: &packages.BulkDeleteInstalledPackagesRequest{
+				InstalledPackageRefs: []*packages.InstalledPackageReference{
+					{Identifier: "my-apache", Plugin: mockPlugin},
+				},
+			},
+			expectedErrorCode: connect.CodeInvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestPackagesServer(t, tc.pluginErrorCode)
+
+			response, err := server.BulkDeleteInstalledPackages(context.Background(), connect.NewRequest(tc.request))
+
+			if got, want := connect.CodeOf(err), tc.expectedErrorCode; err != nil && got != want {
+				t.Fatalf("got: %v, want: %v", got, want)
+			}
+			if tc.expectedErrorCode != 0 {
+				if err == nil {
+					t.Fatalf("got: nil, want: %v", tc.expectedErrorCode)
+				}
+				return
+			}
+
+			if got, want := response.Msg.GetResults(), tc.expectedResults; !cmp.Equal(want, got, protocmp.Transform()) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, protocmp.Transform()))
+			}
+		})
+	}
+}
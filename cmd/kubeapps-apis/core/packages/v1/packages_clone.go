@@ -0,0 +1,106 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bufbuild/connect-go"
+	packages "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1"
+	log "k8s.io/klog/v2"
+)
+
+// CloneInstalledPackage re-creates an installed package in another namespace
+// or cluster, from its detail, and deletes the cloned one when requested.
+func (s packagesServer) CloneInstalledPackage(ctx context.Context, request *connect.Request[packages.CloneInstalledPackageRequest]) (*connect.Response[packages.CloneInstalledPackageResponse], error) {
+	ref := request.Msg.GetInstalledPackageRef()
+	target := request.Msg.GetTargetContext()
+	log.InfoS("+core CloneInstalledPackage", "cluster", ref.GetContext().GetCluster(), "namespace", ref.GetContext().GetNamespace(), "id", ref.GetIdentifier(), "targetCluster", target.GetCluster(), "targetNamespace", target.GetNamespace())
+
+	if ref.GetPlugin() == nil || ref.GetContext().GetNamespace() == "" || ref.GetIdentifier() == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Unable to clone the installed package: a plugin, a namespace and an identifier are required"))
+	}
+	if target.GetNamespace() == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Unable to clone the installed package %q: a target namespace is required", ref.GetIdentifier()))
+	}
+
+	header := request.Header()
+	detailResponse, err := s.GetInstalledPackageDetail(ctx, newRequest(&packages.GetInstalledPackageDetailRequest{
+		InstalledPackageRef: ref,
+	}, header))
+	if err != nil {
+		return nil, err
+	}
+
+	createRequest, err := cloneRequest(request.Msg, detailResponse.Msg.GetInstalledPackageDetail())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.pins.CheckRequest(ctx, createRequest); err != nil {
+		return nil, err
+	}
+	createResponse, err := s.CreateInstalledPackage(ctx, newRequest(createRequest, header))
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Msg.GetDeleteSource() {
+		// The clone is kept if the cloned installed package cannot be deleted,
+		// the error telling the caller to delete it.
+		if _, err := s.DeleteInstalledPackage(ctx, newRequest(&packages.DeleteInstalledPackageRequest{
+			InstalledPackageRef: ref,
+		}, header)); err != nil {
+			return nil, connect.NewError(connect.CodeOf(err), fmt.Errorf("The installed package %q was cloned as %q, but could not be deleted: %w", ref.GetIdentifier(), createRequest.GetName(), err))
+		}
+	}
+
+	return connect.NewResponse(&packages.CloneInstalledPackageResponse{
+		InstalledPackageRef: createResponse.Msg.GetInstalledPackageRef(),
+		Warnings:            createResponse.Msg.GetWarnings(),
+	}), nil
+}
+
+// cloneRequest returns the request creating the clone of the installed
+// package with the given detail. The values applied and the custom detail of
+// the installed package are sent as is, for the plugin to re-create the
+// objects it manages, such as the Secrets holding the values.
+func cloneRequest(request *packages.CloneInstalledPackageRequest, detail *packages.InstalledPackageDetail) (*packages.CreateInstalledPackageRequest, error) {
+	ref := request.GetInstalledPackageRef()
+	target := request.GetTargetContext()
+	if target.GetCluster() == "" {
+		target = &packages.Context{Cluster: ref.GetContext().GetCluster(), Namespace: target.GetNamespace()}
+	}
+	name := request.GetName()
+	if name == "" {
+		name = detail.GetName()
+	}
+	if target.GetCluster() == ref.GetContext().GetCluster() && target.GetNamespace() == ref.GetContext().GetNamespace() && name == detail.GetName() {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Unable to clone the installed package %q: the clone requires another name, namespace or cluster", ref.GetIdentifier()))
+	}
+	if detail.GetAvailablePackageRef() == nil {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("Unable to clone the installed package %q: its available package is unknown", ref.GetIdentifier()))
+	}
+
+	reconciliationOptions := request.GetReconciliationOptions()
+	if reconciliationOptions == nil {
+		reconciliationOptions = detail.GetReconciliationOptions()
+	}
+	customDetail := request.GetCustomDetail()
+	if customDetail == nil {
+		customDetail = detail.GetCustomDetail()
+	}
+
+	return &packages.CreateInstalledPackageRequest{
+		AvailablePackageRef:   detail.GetAvailablePackageRef(),
+		TargetContext:         target,
+		Name:                  name,
+		PkgVersionReference:   detail.GetPkgVersionReference(),
+		Values:                detail.GetValuesApplied(),
+		ReconciliationOptions: reconciliationOptions,
+		CustomDetail:          customDetail,
+		CreateNamespace:       request.GetCreateNamespace(),
+		Metadata:              detail.GetMetadata(),
+	}, nil
+}
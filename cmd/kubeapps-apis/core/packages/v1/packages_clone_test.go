@@ -0,0 +1,234 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/google/go-cmp/cmp"
+	packages "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func cloneSourceRef() *packages.InstalledPackageReference {
+	return &packages.InstalledPackageReference{
+		Context:    &packages.Context{Cluster: "default", Namespace: "dev"},
+		Identifier: "my-apache",
+		Plugin:     mockPlugin,
+	}
+}
+
+func TestCloneInstalledPackage(t *testing.T) {
+	testCases := []struct {
+		name              string
+		request           *packages.CloneInstalledPackageRequest
+		pluginErrorCode   connect.Code
+		pin               string
+		expectedRef       *packages.InstalledPackageReference
+		expectedErrorCode connect.Code
+	}{
+		{
+			name: "it creates the clone in the target namespace",
+			request: &packages.CloneInstalledPackageRequest{
+				InstalledPackageRef: cloneSourceRef(),
+				TargetContext:       &packages.Context{Namespace: "staging"},
+			},
+			expectedRef: &packages.InstalledPackageReference{
+				Context:    &packages.Context{Cluster: "default", Namespace: "staging"},
+				Identifier: "my-release-1",
+				Plugin:     mockPlugin,
+			},
+		},
+		{
+			name: "it creates the clone with another name and deletes the source",
+			request: &packages.CloneInstalledPackageRequest{
+				InstalledPackageRef: cloneSourceRef(),
+				TargetContext:       &packages.Context{Cluster: "default", Namespace: "staging"},
+				Name:                "my-apache-staging",
+				DeleteSource:        true,
+			},
+			expectedRef: &packages.InstalledPackageReference{
+				Context:    &packages.Context{Cluster: "default", Namespace: "staging"},
+				Identifier: "my-apache-staging",
+				Plugin:     mockPlugin,
+			},
+		},
+		{
+			name: "it returns a failed precondition error if a package pin rejects the clone",
+			request: &packages.CloneInstalledPackageRequest{
+				InstalledPackageRef: cloneSourceRef(),
+				TargetContext:       &packages.Context{Namespace: "staging"},
+			},
+			pin:               `{"namespace": "staging", "name": "*", "versionConstraint": "^2.0.0"}`,
+			expectedErrorCode: connect.CodeFailedPrecondition,
+		},
+		{
+			name: "it returns the error of the plugin",
+			request: &packages.CloneInstalledPackageRequest{
+				InstalledPackageRef: cloneSourceRef(),
+				TargetContext:       &packages.Context{Namespace: "staging"},
+			},
+			pluginErrorCode:   connect.CodeNotFound,
+			expectedErrorCode: connect.CodeNotFound,
+		},
+		{
+			name: "it returns an invalid argument error without a target namespace",
+			request: &packages.CloneInstalledPackageRequest{
+				InstalledPackageRef: cloneSourceRef(),
+				TargetContext:       &packages.Context{Cluster: "default"},
+			},
+			expectedErrorCode: connect.CodeInvalidArgument,
+		},
+		{
+			name: "it returns an invalid argument error for an incomplete reference",
+			request: &packages.CloneInstalledPackageRequest{
+				InstalledPackageRef: &packages.InstalledPackageReference{Identifier: "my-apache", Plugin: mockPlugin},
+				TargetContext:       &packages.Context{Namespace: "staging"},
+			},
+			expectedErrorCode: connect.CodeInvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestPackagesServer(t, tc.pluginErrorCode)
+			if tc.pin != "" {
+				server.pins = newTestPinsChecker(t, tc.pin)
+			}
+
+			response, err := server.CloneInstalledPackage(context.Background(), connect.NewRequest(tc.request))
+
+			if got, want := connect.CodeOf(err), tc.expectedErrorCode; err != nil && got != want {
+				t.Fatalf("got: %v, want: %v", got, want)
+			}
+			if tc.expectedErrorCode != 0 {
+				if err == nil {
+					t.Fatalf("got: nil, want: %v", tc.expectedErrorCode)
+				}
+				return
+			}
+
+			if got, want := response.Msg.GetInstalledPackageRef(), tc.expectedRef; !cmp.Equal(want, got, protocmp.Transform()) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, protocmp.Transform()))
+			}
+		})
+	}
+}
+
+func TestCloneRequest(t *testing.T) {
+	sourceDetail, err := anypb.New(wrapperspb.String("source detail"))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	requestDetail, err := anypb.New(wrapperspb.String("request detail"))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	availablePackageRef := &packages.AvailablePackageReference{
+		Context:    &packages.Context{Cluster: "default", Namespace: "kubeapps"},
+		Identifier: "bitnami/apache",
+		Plugin:     mockPlugin,
+	}
+	detail := &packages.InstalledPackageDetail{
+		InstalledPackageRef:   cloneSourceRef(),
+		Name:                  "my-apache",
+		AvailablePackageRef:   availablePackageRef,
+		PkgVersionReference:   &packages.VersionReference{Version: "~9.0"},
+		ValuesApplied:         "replicaCount: 2",
+		ReconciliationOptions: &packages.ReconciliationOptions{ServiceAccountName: "dev-sa"},
+		CustomDetail:          sourceDetail,
+		Metadata:              &packages.InstalledPackageMetadata{Labels: map[string]string{"team": "web"}},
+	}
+
+	testCases := []struct {
+		name              string
+		request           *packages.CloneInstalledPackageRequest
+		detail            *packages.InstalledPackageDetail
+		expectedRequest   *packages.CreateInstalledPackageRequest
+		expectedErrorCode connect.Code
+	}{
+		{
+			name: "it keeps the package, version reference, values and custom detail",
+			request: &packages.CloneInstalledPackageRequest{
+				InstalledPackageRef: cloneSourceRef(),
+				TargetContext:       &packages.Context{Namespace: "staging"},
+				CreateNamespace:     true,
+			},
+			detail: detail,
+			expectedRequest: &packages.CreateInstalledPackageRequest{
+				AvailablePackageRef:   availablePackageRef,
+				TargetContext:         &packages.Context{Cluster: "default", Namespace: "staging"},
+				Name:                  "my-apache",
+				PkgVersionReference:   &packages.VersionReference{Version: "~9.0"},
+				Values:                "replicaCount: 2",
+				ReconciliationOptions: &packages.ReconciliationOptions{ServiceAccountName: "dev-sa"},
+				CustomDetail:          sourceDetail,
+				CreateNamespace:       true,
+				Metadata:              &packages.InstalledPackageMetadata{Labels: map[string]string{"team": "web"}},
+			},
+		},
+		{
+			name: "it replaces the reconciliation options and custom detail with the requested ones",
+			request: &packages.CloneInstalledPackageRequest{
+				InstalledPackageRef:   cloneSourceRef(),
+				TargetContext:         &packages.Context{Cluster: "staging", Namespace: "web"},
+				ReconciliationOptions: &packages.ReconciliationOptions{ServiceAccountName: "staging-sa"},
+				CustomDetail:          requestDetail,
+			},
+			detail: detail,
+			expectedRequest: &packages.CreateInstalledPackageRequest{
+				AvailablePackageRef:   availablePackageRef,
+				TargetContext:         &packages.Context{Cluster: "staging", Namespace: "web"},
+				Name:                  "my-apache",
+				PkgVersionReference:   &packages.VersionReference{Version: "~9.0"},
+				Values:                "replicaCount: 2",
+				ReconciliationOptions: &packages.ReconciliationOptions{ServiceAccountName: "staging-sa"},
+				CustomDetail:          requestDetail,
+				Metadata:              &packages.InstalledPackageMetadata{Labels: map[string]string{"team": "web"}},
+			},
+		},
+		{
+			name: "it returns an invalid argument error when cloning onto itself",
+			request: &packages.CloneInstalledPackageRequest{
+				InstalledPackageRef: cloneSourceRef(),
+				TargetContext:       &packages.Context{Namespace: "dev"},
+			},
+			detail:            detail,
+			expectedErrorCode: connect.CodeInvalidArgument,
+		},
+		{
+			name: "it returns a failed precondition error without an available package",
+			request: &packages.CloneInstalledPackageRequest{
+				InstalledPackageRef: cloneSourceRef(),
+				TargetContext:       &packages.Context{Namespace: "staging"},
+			},
+			detail:            &packages.InstalledPackageDetail{Name: "my-apache"},
+			expectedErrorCode: connect.CodeFailedPrecondition,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			request, err := cloneRequest(tc.request, tc.detail)
+
+			if got, want := connect.CodeOf(err), tc.expectedErrorCode; err != nil && got != want {
+				t.Fatalf("got: %v, want: %v", got, want)
+			}
+			if tc.expectedErrorCode != 0 {
+				if err == nil {
+					t.Fatalf("got: nil, want: %v", tc.expectedErrorCode)
+				}
+				return
+			}
+
+			if got, want := request, tc.expectedRequest; !cmp.Equal(want, got, protocmp.Transform()) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, protocmp.Transform()))
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	packagesv1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core/packages/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core/pins"
+	pluginsv1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core/plugins/v1alpha1"
+	packages "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1"
+	corev1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	plugins "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/plugins/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugin_test"
+	"google.golang.org/protobuf/testing/protocmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+var mockPlugin = &plugins.Plugin{Name: "mock1", Version: "v1alpha1"}
+
+func newTestPackagesServer(t *testing.T, errorCode connect.Code) *packagesServer {
+	pluginServer := plugin_test.NewTestPackagingPlugin(mockPlugin)
+	pluginServer.AvailablePackageDetail = plugin_test.MakeAvailablePackageDetail("pkg-1", mockPlugin)
+	pluginServer.InstalledPackageDetail = plugin_test.MakeInstalledPackageDetail("pkg-1", mockPlugin)
+	pluginServer.InstalledPackageSummaries = []*corev1alpha1.InstalledPackageSummary{
+		plugin_test.MakeInstalledPackageSummary("pkg-1", mockPlugin),
+		plugin_test.MakeInstalledPackageSummary("pkg-2", mockPlugin),
+	}
+	pluginServer.ErrorCode = errorCode
+
+	v1alpha1Server, err := packagesv1alpha1.NewPackagesServer([]pluginsv1alpha1.PluginWithServer{
+		{Plugin: mockPlugin, Server: pluginServer},
+	}, func() []string { return []string{"default"} }, nil, nil, 0)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	return NewPackagesServer(v1alpha1Server, nil)
+}
+
+// newTestPinsChecker returns a checker of the given package pin, stored in
+// the kubeapps namespace.
+func newTestPinsChecker(t *testing.T, pin string) *pins.Checker {
+	checker, err := pins.NewChecker(fake.NewSimpleClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pin",
+			Namespace: "kubeapps",
+			Labels:    map[string]string{pins.PackagePinLabel: ""},
+		},
+		Data: map[string]string{pins.PackagePinDataKey: pin},
+	}), "kubeapps")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	return checker
+}
+
+func TestGetAvailablePackageDetail(t *testing.T) {
+	testCases := []struct {
+		name              string
+		pluginErrorCode   connect.Code
+		expectedDetail    *corev1alpha1.AvailablePackageDetail
+		expectedErrorCode connect.Code
+	}{
+		{
+			name:           "it translates the request and the response of the v1alpha1 API",
+			expectedDetail: plugin_test.MakeAvailablePackageDetail("pkg-1", mockPlugin),
+		},
+		{
+			name:              "it returns the error code of the v1alpha1 API",
+			pluginErrorCode:   connect.CodeNotFound,
+			expectedErrorCode: connect.CodeNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestPackagesServer(t, tc.pluginErrorCode)
+
+			response, err := server.GetAvailablePackageDetail(context.Background(), connect.NewRequest(&packages.GetAvailablePackageDetailRequest{
+				AvailablePackageRef: &packages.AvailablePackageReference{
+					Context:    &packages.Context{Cluster: "default", Namespace: "my-ns"},
+					Identifier: "pkg-1",
+					Plugin:     mockPlugin,
+				},
+			}))
+
+			if got, want := connect.CodeOf(err), tc.expectedErrorCode; err != nil && got != want {
+				t.Fatalf("got: %v, want: %v", got, want)
+			}
+			if tc.expectedErrorCode != 0 {
+				return
+			}
+
+			// The v1 detail is wire compatible with the v1alpha1 one.
+			got := &corev1alpha1.AvailablePackageDetail{}
+			if err := translate(response.Msg.GetAvailablePackageDetail(), got); err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if !cmp.Equal(tc.expectedDetail, got, protocmp.Transform()) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(tc.expectedDetail, got, protocmp.Transform()))
+			}
+		})
+	}
+}
+
+func TestGetInstalledPackageSummaries(t *testing.T) {
+	server := newTestPackagesServer(t, 0)
+
+	response, err := server.GetInstalledPackageSummaries(context.Background(), connect.NewRequest(&packages.GetInstalledPackageSummariesRequest{
+		Context: &packages.Context{Cluster: "default", Namespace: "my-ns"},
+		PaginationOptions: &packages.PaginationOptions{
+			PageSize: 1,
+		},
+	}))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	// The pagination options are translated to v1alpha1, so that only the
+	// first page of installed packages is returned.
+	names := []string{}
+	for _, summary := range response.Msg.GetInstalledPackageSummaries() {
+		names = append(names, summary.GetName())
+	}
+	if got, want := names, []string{"pkg-1"}; !cmp.Equal(want, got, cmpopts.EquateEmpty()) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+	}
+	if got, want := response.Msg.GetNextPageToken(), `{"mock1":1}`; got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import (
+	"context"
+
+	"github.com/bufbuild/connect-go"
+	packages "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1"
+	packagesv1alpha1connect "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1/v1alpha1connect"
+)
+
+// repositoriesServer implements the API defined in proto/kubeappsapis/core/packages/v1/repositories.proto
+// by translating each request to the deprecated core.packages.v1alpha1 API, which
+// aggregates the results of the repositories plugins.
+type repositoriesServer struct {
+	packages.UnimplementedRepositoriesServiceServer
+
+	// v1alpha1Server is the core.packages.v1alpha1 server the requests are
+	// translated to.
+	v1alpha1Server packagesv1alpha1connect.RepositoriesServiceHandler
+}
+
+func NewRepositoriesServer(v1alpha1Server packagesv1alpha1connect.RepositoriesServiceHandler) *repositoriesServer {
+	return &repositoriesServer{
+		v1alpha1Server: v1alpha1Server,
+	}
+}
+
+func (s repositoriesServer) AddPackageRepository(ctx context.Context, request *connect.Request[packages.AddPackageRepositoryRequest]) (*connect.Response[packages.AddPackageRepositoryResponse], error) {
+	return forward[packages.AddPackageRepositoryResponse](ctx, request, s.v1alpha1Server.AddPackageRepository)
+}
+
+func (s repositoriesServer) GetPackageRepositoryDetail(ctx context.Context, request *connect.Request[packages.GetPackageRepositoryDetailRequest]) (*connect.Response[packages.GetPackageRepositoryDetailResponse], error) {
+	return forward[packages.GetPackageRepositoryDetailResponse](ctx, request, s.v1alpha1Server.GetPackageRepositoryDetail)
+}
+
+func (s repositoriesServer) GetPackageRepositorySummaries(ctx context.Context, request *connect.Request[packages.GetPackageRepositorySummariesRequest]) (*connect.Response[packages.GetPackageRepositorySummariesResponse], error) {
+	return forward[packages.GetPackageRepositorySummariesResponse](ctx, request, s.v1alpha1Server.GetPackageRepositorySummaries)
+}
+
+func (s repositoriesServer) UpdatePackageRepository(ctx context.Context, request *connect.Request[packages.UpdatePackageRepositoryRequest]) (*connect.Response[packages.UpdatePackageRepositoryResponse], error) {
+	return forward[packages.UpdatePackageRepositoryResponse](ctx, request, s.v1alpha1Server.UpdatePackageRepository)
+}
+
+func (s repositoriesServer) DeletePackageRepository(ctx context.Context, request *connect.Request[packages.DeletePackageRepositoryRequest]) (*connect.Response[packages.DeletePackageRepositoryResponse], error) {
+	return forward[packages.DeletePackageRepositoryResponse](ctx, request, s.v1alpha1Server.DeletePackageRepository)
+}
+
+func (s repositoriesServer) GetPackageRepositoryPermissions(ctx context.Context, request *connect.Request[packages.GetPackageRepositoryPermissionsRequest]) (*connect.Response[packages.GetPackageRepositoryPermissionsResponse], error) {
+	return forward[packages.GetPackageRepositoryPermissionsResponse](ctx, request, s.v1alpha1Server.GetPackageRepositoryPermissions)
+}
+
+func (s repositoriesServer) TestPackageRepositoryConnection(ctx context.Context, request *connect.Request[packages.TestPackageRepositoryConnectionRequest]) (*connect.Response[packages.TestPackageRepositoryConnectionResponse], error) {
+	return forward[packages.TestPackageRepositoryConnectionResponse](ctx, request, s.v1alpha1Server.TestPackageRepositoryConnection)
+}
+
+func (s repositoriesServer) ListPackageRepositoryPackages(ctx context.Context, request *connect.Request[packages.ListPackageRepositoryPackagesRequest]) (*connect.Response[packages.ListPackageRepositoryPackagesResponse], error) {
+	return forward[packages.ListPackageRepositoryPackagesResponse](ctx, request, s.v1alpha1Server.ListPackageRepositoryPackages)
+}
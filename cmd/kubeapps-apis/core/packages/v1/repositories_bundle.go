@@ -0,0 +1,277 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/bufbuild/connect-go"
+	packages "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	log "k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// RepositoryBundleAPIVersion and RepositoryBundleKind identify the YAML
+	// documents exported by ExportPackageRepositories.
+	RepositoryBundleAPIVersion = "kubeapps.dev/v1"
+	RepositoryBundleKind       = "PackageRepositoryBundle"
+
+	// redactedCredential is the value returned by the plugins instead of the
+	// credentials of a package repository.
+	redactedCredential = "REDACTED"
+)
+
+// repositoryBundle is the YAML document of the exported package repositories,
+// each one being an AddPackageRepositoryRequest serialized with protojson.
+type repositoryBundle struct {
+	APIVersion   string            `json:"apiVersion"`
+	Kind         string            `json:"kind"`
+	Repositories []json.RawMessage `json:"repositories"`
+}
+
+// ExportPackageRepositories exports the package repositories of every plugin
+// as a single YAML bundle.
+func (s repositoriesServer) ExportPackageRepositories(ctx context.Context, request *connect.Request[packages.ExportPackageRepositoriesRequest]) (*connect.Response[packages.ExportPackageRepositoriesResponse], error) {
+	log.InfoS("+core ExportPackageRepositories", "cluster", request.Msg.GetContext().GetCluster(), "namespace", request.Msg.GetContext().GetNamespace())
+
+	summaries, err := s.GetPackageRepositorySummaries(ctx, newRequest(&packages.GetPackageRepositorySummariesRequest{
+		Context: request.Msg.GetContext(),
+	}, request.Header()))
+	if err != nil {
+		return nil, err
+	}
+
+	bundle := repositoryBundle{
+		APIVersion:   RepositoryBundleAPIVersion,
+		Kind:         RepositoryBundleKind,
+		Repositories: []json.RawMessage{},
+	}
+	for _, summary := range summaries.Msg.GetPackageRepositorySummaries() {
+		repo, err := s.getBundleRepository(ctx, summary.GetPackageRepoRef(), request.Header())
+		if err != nil {
+			return nil, err
+		}
+		repoJSON, err := protojson.Marshal(repo)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to serialize the package repository %q: %w", repo.GetName(), err))
+		}
+		bundle.Repositories = append(bundle.Repositories, repoJSON)
+	}
+
+	bundleYAML, err := yaml.Marshal(bundle)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to serialize the package repositories: %w", err))
+	}
+
+	return connect.NewResponse(&packages.ExportPackageRepositoriesResponse{
+		Bundle: string(bundleYAML),
+	}), nil
+}
+
+// ImportPackageRepositories applies a bundle of package repositories, so that
+// importing the same bundle twice leaves the package repositories unchanged.
+// The whole bundle is validated before applying any of its repositories.
+func (s repositoriesServer) ImportPackageRepositories(ctx context.Context, request *connect.Request[packages.ImportPackageRepositoriesRequest]) (*connect.Response[packages.ImportPackageRepositoriesResponse], error) {
+	log.InfoS("+core ImportPackageRepositories", "cluster", request.Msg.GetContext().GetCluster(), "namespace", request.Msg.GetContext().GetNamespace(), "prune", request.Msg.GetPrune())
+
+	repos, err := parseRepositoryBundle(request.Msg.GetBundle(), request.Msg.GetContext())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	summaries, err := s.GetPackageRepositorySummaries(ctx, newRequest(&packages.GetPackageRepositorySummariesRequest{
+		Context: request.Msg.GetContext(),
+	}, request.Header()))
+	if err != nil {
+		return nil, err
+	}
+	existingRefs := map[string]*packages.PackageRepositoryReference{}
+	for _, summary := range summaries.Msg.GetPackageRepositorySummaries() {
+		ref := summary.GetPackageRepoRef()
+		existingRefs[bundleKey(ref.GetPlugin().GetName(), ref.GetContext().GetNamespace(), ref.GetIdentifier())] = ref
+	}
+
+	// The plugins keep the existing credentials of a package repository when
+	// updated with the redacted ones, but cannot create it without them.
+	for _, repo := range repos {
+		if _, ok := existingRefs[bundleKey(repo.GetPlugin().GetName(), repo.GetContext().GetNamespace(), repo.GetName())]; !ok && hasRedactedCredentials(repo) {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Invalid repository %q of the bundle: its credentials are redacted and must be replaced to create it", repo.GetName()))
+		}
+	}
+
+	response := &packages.ImportPackageRepositoriesResponse{}
+	for _, repo := range repos {
+		key := bundleKey(repo.GetPlugin().GetName(), repo.GetContext().GetNamespace(), repo.GetName())
+		ref, ok := existingRefs[key]
+		if !ok {
+			added, err := s.AddPackageRepository(ctx, newRequest(repo, request.Header()))
+			if err != nil {
+				return nil, err
+			}
+			response.Created = append(response.Created, added.Msg.GetPackageRepoRef())
+			continue
+		}
+		delete(existingRefs, key)
+
+		existing, err := s.getBundleRepository(ctx, ref, request.Header())
+		if err != nil {
+			return nil, err
+		}
+		existing.Context.Cluster = repo.GetContext().GetCluster()
+		if proto.Equal(existing, repo) {
+			response.Unchanged = append(response.Unchanged, ref)
+			continue
+		}
+		if existing.GetType() != repo.GetType() || existing.GetNamespaceScoped() != repo.GetNamespaceScoped() {
+			return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("Unable to update the package repository %q: its type and scope cannot be changed", repo.GetName()))
+		}
+		updated, err := s.UpdatePackageRepository(ctx, newRequest(&packages.UpdatePackageRepositoryRequest{
+			PackageRepoRef: ref,
+			Url:            repo.GetUrl(),
+			Description:    repo.GetDescription(),
+			Interval:       repo.GetInterval(),
+			TlsConfig:      repo.GetTlsConfig(),
+			Auth:           repo.GetAuth(),
+			CustomDetail:   repo.GetCustomDetail(),
+		}, request.Header()))
+		if err != nil {
+			return nil, err
+		}
+		response.Updated = append(response.Updated, updated.Msg.GetPackageRepoRef())
+	}
+
+	if request.Msg.GetPrune() {
+		// The remaining package repositories are those not in the bundle, in
+		// the same order as the summaries.
+		for _, summary := range summaries.Msg.GetPackageRepositorySummaries() {
+			ref := summary.GetPackageRepoRef()
+			if _, ok := existingRefs[bundleKey(ref.GetPlugin().GetName(), ref.GetContext().GetNamespace(), ref.GetIdentifier())]; !ok {
+				continue
+			}
+			if _, err := s.DeletePackageRepository(ctx, newRequest(&packages.DeletePackageRepositoryRequest{
+				PackageRepoRef: ref,
+			}, request.Header())); err != nil {
+				return nil, err
+			}
+			response.Deleted = append(response.Deleted, ref)
+		}
+	}
+
+	return connect.NewResponse(response), nil
+}
+
+// getBundleRepository returns the package repository as it is exported in a
+// bundle, that is, in the format of an AddPackageRepositoryRequest without
+// cluster, so that the bundle can be imported in another cluster.
+func (s repositoriesServer) getBundleRepository(ctx context.Context, ref *packages.PackageRepositoryReference, header http.Header) (*packages.AddPackageRepositoryRequest, error) {
+	response, err := s.GetPackageRepositoryDetail(ctx, newRequest(&packages.GetPackageRepositoryDetailRequest{
+		PackageRepoRef: ref,
+	}, header))
+	if err != nil {
+		return nil, err
+	}
+	detail := response.Msg.GetDetail()
+	return &packages.AddPackageRepositoryRequest{
+		Context:         &packages.Context{Namespace: detail.GetPackageRepoRef().GetContext().GetNamespace()},
+		Name:            detail.GetName(),
+		Description:     detail.GetDescription(),
+		NamespaceScoped: detail.GetNamespaceScoped(),
+		Type:            detail.GetType(),
+		Url:             detail.GetUrl(),
+		Interval:        detail.GetInterval(),
+		TlsConfig:       detail.GetTlsConfig(),
+		Auth:            detail.GetAuth(),
+		Plugin:          ref.GetPlugin(),
+		CustomDetail:    detail.GetCustomDetail(),
+	}, nil
+}
+
+// parseRepositoryBundle returns the package repositories of the bundle,
+// targeting the cluster of the given context.
+func parseRepositoryBundle(bundleYAML string, target *packages.Context) ([]*packages.AddPackageRepositoryRequest, error) {
+	bundle := repositoryBundle{}
+	if err := yaml.Unmarshal([]byte(bundleYAML), &bundle); err != nil {
+		return nil, fmt.Errorf("Unable to parse the bundle: %w", err)
+	}
+	if bundle.APIVersion != RepositoryBundleAPIVersion || bundle.Kind != RepositoryBundleKind {
+		return nil, fmt.Errorf("Invalid bundle: expected a %s %s, got a %s %s", RepositoryBundleAPIVersion, RepositoryBundleKind, bundle.APIVersion, bundle.Kind)
+	}
+
+	repos := make([]*packages.AddPackageRepositoryRequest, len(bundle.Repositories))
+	keys := map[string]bool{}
+	for i, repoJSON := range bundle.Repositories {
+		repo := &packages.AddPackageRepositoryRequest{}
+		if err := protojson.Unmarshal(repoJSON, repo); err != nil {
+			return nil, fmt.Errorf("Unable to parse the repository %d of the bundle: %w", i, err)
+		}
+		if repo.GetName() == "" || repo.GetPlugin().GetName() == "" || repo.GetUrl() == "" {
+			return nil, fmt.Errorf("Invalid repository %d of the bundle: a name, a plugin and a url are required", i)
+		}
+		if repo.GetContext().GetNamespace() == "" {
+			return nil, fmt.Errorf("Invalid repository %q of the bundle: a namespace is required", repo.GetName())
+		}
+		if target.GetNamespace() != "" && repo.GetContext().GetNamespace() != target.GetNamespace() {
+			return nil, fmt.Errorf("Invalid repository %q of the bundle: it does not belong to the namespace %q", repo.GetName(), target.GetNamespace())
+		}
+		key := bundleKey(repo.GetPlugin().GetName(), repo.GetContext().GetNamespace(), repo.GetName())
+		if keys[key] {
+			return nil, fmt.Errorf("Invalid bundle: the repository %q is duplicated", repo.GetName())
+		}
+		keys[key] = true
+
+		repo.Context.Cluster = target.GetCluster()
+		repos[i] = repo
+	}
+	return repos, nil
+}
+
+// hasRedactedCredentials returns whether any of the credentials of the package
+// repository, or its certificate authority, is redacted.
+func hasRedactedCredentials(repo *packages.AddPackageRepositoryRequest) bool {
+	auth := repo.GetAuth()
+	values := []string{
+		repo.GetTlsConfig().GetCertAuthority(),
+		auth.GetHeader(),
+		auth.GetUsernamePassword().GetUsername(),
+		auth.GetUsernamePassword().GetPassword(),
+		auth.GetTlsCertKey().GetCert(),
+		auth.GetTlsCertKey().GetKey(),
+		auth.GetDockerCreds().GetServer(),
+		auth.GetDockerCreds().GetUsername(),
+		auth.GetDockerCreds().GetPassword(),
+		auth.GetDockerCreds().GetEmail(),
+		auth.GetSshCreds().GetPrivateKey(),
+		auth.GetSshCreds().GetKnownHosts(),
+	}
+	for _, value := range auth.GetOpaqueCreds().GetData() {
+		values = append(values, value)
+	}
+	for _, value := range values {
+		if value == redactedCredential {
+			return true
+		}
+	}
+	return false
+}
+
+// bundleKey identifies a package repository within a bundle.
+func bundleKey(plugin, namespace, name string) string {
+	return fmt.Sprintf("%s/%s/%s", plugin, namespace, name)
+}
+
+// newRequest returns a request with the given headers, so that the calls made
+// on behalf of the user keep their credentials.
+func newRequest[T any](msg *T, header http.Header) *connect.Request[T] {
+	request := connect.NewRequest(msg)
+	for key, values := range header {
+		request.Header()[key] = values
+	}
+	return request
+}
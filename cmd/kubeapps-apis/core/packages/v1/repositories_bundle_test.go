@@ -0,0 +1,285 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/google/go-cmp/cmp"
+	packages "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1"
+	packagesv1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	packagesv1alpha1connect "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1/v1alpha1connect"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+const bitnamiBundle = `apiVersion: kubeapps.dev/v1
+kind: PackageRepositoryBundle
+repositories:
+- context:
+    namespace: kubeapps
+  name: bitnami
+  plugin:
+    name: mock1
+    version: v1alpha1
+  type: helm
+  url: https://charts.bitnami.com/bitnami
+`
+
+// fakeRepositoriesServer is a v1alpha1 repositories server storing the package
+// repositories in memory, keyed by name.
+type fakeRepositoriesServer struct {
+	packagesv1alpha1connect.UnimplementedRepositoriesServiceHandler
+
+	repos map[string]*packagesv1alpha1.PackageRepositoryDetail
+	// calls records the mutating calls, such as "update bitnami".
+	calls []string
+}
+
+func newFakeRepositoriesServer(repos ...*packagesv1alpha1.PackageRepositoryDetail) *fakeRepositoriesServer {
+	s := &fakeRepositoriesServer{repos: map[string]*packagesv1alpha1.PackageRepositoryDetail{}}
+	for _, repo := range repos {
+		s.repos[repo.GetName()] = repo
+	}
+	return s
+}
+
+func fakeRepoDetail(name, url string) *packagesv1alpha1.PackageRepositoryDetail {
+	return &packagesv1alpha1.PackageRepositoryDetail{
+		PackageRepoRef: fakeRepoRef(name),
+		Name:           name,
+		Type:           "helm",
+		Url:            url,
+	}
+}
+
+func fakeRepoRef(name string) *packagesv1alpha1.PackageRepositoryReference {
+	return &packagesv1alpha1.PackageRepositoryReference{
+		Context:    &packagesv1alpha1.Context{Cluster: "default", Namespace: "kubeapps"},
+		Identifier: name,
+		Plugin:     mockPlugin,
+	}
+}
+
+func (s *fakeRepositoriesServer) GetPackageRepositorySummaries(ctx context.Context, request *connect.Request[packagesv1alpha1.GetPackageRepositorySummariesRequest]) (*connect.Response[packagesv1alpha1.GetPackageRepositorySummariesResponse], error) {
+	summaries := []*packagesv1alpha1.PackageRepositorySummary{}
+	for _, repo := range s.repos {
+		summaries = append(summaries, &packagesv1alpha1.PackageRepositorySummary{
+			PackageRepoRef: repo.GetPackageRepoRef(),
+			Name:           repo.GetName(),
+			Type:           repo.GetType(),
+			Url:            repo.GetUrl(),
+		})
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].GetName() < summaries[j].GetName()
+	})
+	return connect.NewResponse(&packagesv1alpha1.GetPackageRepositorySummariesResponse{
+		PackageRepositorySummaries: summaries,
+	}), nil
+}
+
+func (s *fakeRepositoriesServer) GetPackageRepositoryDetail(ctx context.Context, request *connect.Request[packagesv1alpha1.GetPackageRepositoryDetailRequest]) (*connect.Response[packagesv1alpha1.GetPackageRepositoryDetailResponse], error) {
+	repo, ok := s.repos[request.Msg.GetPackageRepoRef().GetIdentifier()]
+	if !ok {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("not found"))
+	}
+	return connect.NewResponse(&packagesv1alpha1.GetPackageRepositoryDetailResponse{
+		Detail: repo,
+	}), nil
+}
+
+func (s *fakeRepositoriesServer) AddPackageRepository(ctx context.Context, request *connect.Request[packagesv1alpha1.AddPackageRepositoryRequest]) (*connect.Response[packagesv1alpha1.AddPackageRepositoryResponse], error) {
+	s.calls = append(s.calls, "add "+request.Msg.GetName())
+	return connect.NewResponse(&packagesv1alpha1.AddPackageRepositoryResponse{
+		PackageRepoRef: fakeRepoRef(request.Msg.GetName()),
+	}), nil
+}
+
+func (s *fakeRepositoriesServer) UpdatePackageRepository(ctx context.Context, request *connect.Request[packagesv1alpha1.UpdatePackageRepositoryRequest]) (*connect.Response[packagesv1alpha1.UpdatePackageRepositoryResponse], error) {
+	s.calls = append(s.calls, "update "+request.Msg.GetPackageRepoRef().GetIdentifier()+" "+request.Msg.GetUrl())
+	return connect.NewResponse(&packagesv1alpha1.UpdatePackageRepositoryResponse{
+		PackageRepoRef: request.Msg.GetPackageRepoRef(),
+	}), nil
+}
+
+func (s *fakeRepositoriesServer) DeletePackageRepository(ctx context.Context, request *connect.Request[packagesv1alpha1.DeletePackageRepositoryRequest]) (*connect.Response[packagesv1alpha1.DeletePackageRepositoryResponse], error) {
+	s.calls = append(s.calls, "delete "+request.Msg.GetPackageRepoRef().GetIdentifier())
+	return connect.NewResponse(&packagesv1alpha1.DeletePackageRepositoryResponse{}), nil
+}
+
+func TestExportPackageRepositories(t *testing.T) {
+	server := NewRepositoriesServer(newFakeRepositoriesServer(fakeRepoDetail("bitnami", "https://charts.bitnami.com/bitnami")))
+
+	response, err := server.ExportPackageRepositories(context.Background(), connect.NewRequest(&packages.ExportPackageRepositoriesRequest{
+		Context: &packages.Context{Cluster: "default"},
+	}))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	if got, want := response.Msg.GetBundle(), bitnamiBundle; got != want {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+	}
+}
+
+func TestImportPackageRepositories(t *testing.T) {
+	bitnamiRef := &packages.PackageRepositoryReference{
+		Context:    &packages.Context{Cluster: "default", Namespace: "kubeapps"},
+		Identifier: "bitnami",
+		Plugin:     mockPlugin,
+	}
+	otherRef := &packages.PackageRepositoryReference{
+		Context:    &packages.Context{Cluster: "default", Namespace: "kubeapps"},
+		Identifier: "other",
+		Plugin:     mockPlugin,
+	}
+
+	redactedAuth := `  auth:
+    type: PACKAGE_REPOSITORY_AUTH_TYPE_BASIC_AUTH
+    usernamePassword:
+      username: REDACTED
+      password: REDACTED
+`
+
+	testCases := []struct {
+		name              string
+		existingRepos     []*packagesv1alpha1.PackageRepositoryDetail
+		request           *packages.ImportPackageRepositoriesRequest
+		expectedResponse  *packages.ImportPackageRepositoriesResponse
+		expectedCalls     []string
+		expectedErrorCode connect.Code
+	}{
+		{
+			name: "it creates the missing repositories",
+			request: &packages.ImportPackageRepositoriesRequest{
+				Context: &packages.Context{Cluster: "default"},
+				Bundle:  bitnamiBundle,
+			},
+			expectedResponse: &packages.ImportPackageRepositoriesResponse{
+				Created: []*packages.PackageRepositoryReference{bitnamiRef},
+			},
+			expectedCalls: []string{"add bitnami"},
+		},
+		{
+			name:          "it leaves the repositories already up to date unchanged",
+			existingRepos: []*packagesv1alpha1.PackageRepositoryDetail{fakeRepoDetail("bitnami", "https://charts.bitnami.com/bitnami")},
+			request: &packages.ImportPackageRepositoriesRequest{
+				Context: &packages.Context{Cluster: "default"},
+				Bundle:  bitnamiBundle,
+			},
+			expectedResponse: &packages.ImportPackageRepositoriesResponse{
+				Unchanged: []*packages.PackageRepositoryReference{bitnamiRef},
+			},
+		},
+		{
+			name: "it updates the changed repositories and keeps the others without pruning",
+			existingRepos: []*packagesv1alpha1.PackageRepositoryDetail{
+				fakeRepoDetail("bitnami", "https://example.com/bitnami"),
+				fakeRepoDetail("other", "https://example.com/other"),
+			},
+			request: &packages.ImportPackageRepositoriesRequest{
+				Context: &packages.Context{Cluster: "default"},
+				Bundle:  bitnamiBundle,
+			},
+			expectedResponse: &packages.ImportPackageRepositoriesResponse{
+				Updated: []*packages.PackageRepositoryReference{bitnamiRef},
+			},
+			expectedCalls: []string{"update bitnami https://charts.bitnami.com/bitnami"},
+		},
+		{
+			name: "it deletes the repositories not in the bundle when pruning",
+			existingRepos: []*packagesv1alpha1.PackageRepositoryDetail{
+				fakeRepoDetail("bitnami", "https://charts.bitnami.com/bitnami"),
+				fakeRepoDetail("other", "https://example.com/other"),
+			},
+			request: &packages.ImportPackageRepositoriesRequest{
+				Context: &packages.Context{Cluster: "default"},
+				Bundle:  bitnamiBundle,
+				Prune:   true,
+			},
+			expectedResponse: &packages.ImportPackageRepositoriesResponse{
+				Unchanged: []*packages.PackageRepositoryReference{bitnamiRef},
+				Deleted:   []*packages.PackageRepositoryReference{otherRef},
+			},
+			expectedCalls: []string{"delete other"},
+		},
+		{
+			name:          "it keeps the credentials of the existing repositories when redacted",
+			existingRepos: []*packagesv1alpha1.PackageRepositoryDetail{fakeRepoDetail("bitnami", "https://example.com/bitnami")},
+			request: &packages.ImportPackageRepositoriesRequest{
+				Context: &packages.Context{Cluster: "default"},
+				Bundle:  bitnamiBundle + redactedAuth,
+			},
+			expectedResponse: &packages.ImportPackageRepositoriesResponse{
+				Updated: []*packages.PackageRepositoryReference{bitnamiRef},
+			},
+			expectedCalls: []string{"update bitnami https://charts.bitnami.com/bitnami"},
+		},
+		{
+			name: "it returns invalid argument for a missing repository with redacted credentials",
+			request: &packages.ImportPackageRepositoriesRequest{
+				Context: &packages.Context{Cluster: "default"},
+				Bundle:  bitnamiBundle + redactedAuth,
+			},
+			expectedErrorCode: connect.CodeInvalidArgument,
+		},
+		{
+			name: "it returns invalid argument for a repository of another namespace",
+			request: &packages.ImportPackageRepositoriesRequest{
+				Context: &packages.Context{Cluster: "default", Namespace: "team-a"},
+				Bundle:  bitnamiBundle,
+			},
+			expectedErrorCode: connect.CodeInvalidArgument,
+		},
+		{
+			name: "it returns invalid argument for a document which is not a bundle",
+			request: &packages.ImportPackageRepositoriesRequest{
+				Context: &packages.Context{Cluster: "default"},
+				Bundle:  "apiVersion: v1\nkind: ConfigMap\n",
+			},
+			expectedErrorCode: connect.CodeInvalidArgument,
+		},
+		{
+			name: "it returns invalid argument for a duplicated repository",
+			request: &packages.ImportPackageRepositoriesRequest{
+				Context: &packages.Context{Cluster: "default"},
+				Bundle:  bitnamiBundle + bitnamiBundle[len("apiVersion: kubeapps.dev/v1\nkind: PackageRepositoryBundle\nrepositories:\n"):],
+			},
+			expectedErrorCode: connect.CodeInvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			v1alpha1Server := newFakeRepositoriesServer(tc.existingRepos...)
+			server := NewRepositoriesServer(v1alpha1Server)
+
+			response, err := server.ImportPackageRepositories(context.Background(), connect.NewRequest(tc.request))
+
+			if got, want := connect.CodeOf(err), tc.expectedErrorCode; err != nil && got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+			if tc.expectedErrorCode != 0 {
+				if err == nil {
+					t.Fatalf("got: nil, want: error with code %+v", tc.expectedErrorCode)
+				}
+				if len(v1alpha1Server.calls) != 0 {
+					t.Errorf("got: %+v, want: no changes", v1alpha1Server.calls)
+				}
+				return
+			}
+
+			if got, want := response.Msg, tc.expectedResponse; !cmp.Equal(got, want, protocmp.Transform()) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, protocmp.Transform()))
+			}
+			if got, want := v1alpha1Server.calls, tc.expectedCalls; !cmp.Equal(got, want) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
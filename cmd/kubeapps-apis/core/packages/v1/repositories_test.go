@@ -0,0 +1,73 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/google/go-cmp/cmp"
+	packagesv1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core/packages/v1alpha1"
+	pluginsv1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core/plugins/v1alpha1"
+	packages "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugin_test"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+func TestAddPackageRepository(t *testing.T) {
+	testCases := []struct {
+		name              string
+		pluginErrorCode   connect.Code
+		expectedResponse  *packages.AddPackageRepositoryResponse
+		expectedErrorCode connect.Code
+	}{
+		{
+			name: "it translates the request and the response of the v1alpha1 API",
+			expectedResponse: &packages.AddPackageRepositoryResponse{
+				PackageRepoRef: &packages.PackageRepositoryReference{
+					Context:    &packages.Context{Cluster: "default", Namespace: "my-ns"},
+					Identifier: "repo-1",
+					Plugin:     mockPlugin,
+				},
+			},
+		},
+		{
+			name:              "it returns the error code of the v1alpha1 API",
+			pluginErrorCode:   connect.CodeInvalidArgument,
+			expectedErrorCode: connect.CodeInvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pluginServer := plugin_test.NewTestRepositoriesPlugin(mockPlugin)
+			pluginServer.ErrorCode = tc.pluginErrorCode
+			v1alpha1Server, err := packagesv1alpha1.NewRepositoriesServer([]pluginsv1alpha1.PluginWithServer{
+				{Plugin: mockPlugin, Server: pluginServer},
+			})
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			server := NewRepositoriesServer(v1alpha1Server)
+
+			response, err := server.AddPackageRepository(context.Background(), connect.NewRequest(&packages.AddPackageRepositoryRequest{
+				Context: &packages.Context{Cluster: "default", Namespace: "my-ns"},
+				Name:    "repo-1",
+				Url:     "https://example.com/charts",
+				Plugin:  mockPlugin,
+			}))
+
+			if got, want := connect.CodeOf(err), tc.expectedErrorCode; err != nil && got != want {
+				t.Fatalf("got: %v, want: %v", got, want)
+			}
+			if tc.expectedErrorCode != 0 {
+				return
+			}
+			if got, want := response.Msg, tc.expectedResponse; !cmp.Equal(want, got, protocmp.Transform()) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, protocmp.Transform()))
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bufbuild/connect-go"
+	"google.golang.org/protobuf/proto"
+)
+
+// translate converts a message between its v1 and v1alpha1 versions. Both
+// versions of the core packages API share the same wire format, so the message
+// is simply encoded and decoded again as the other type.
+func translate(from, to any) error {
+	fromMsg, ok := from.(proto.Message)
+	if !ok {
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("unable to translate %T: not a protobuf message", from))
+	}
+	toMsg, ok := to.(proto.Message)
+	if !ok {
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("unable to translate to %T: not a protobuf message", to))
+	}
+	bytes, err := proto.Marshal(fromMsg)
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("unable to translate %T: %w", from, err))
+	}
+	if err := proto.Unmarshal(bytes, toMsg); err != nil {
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("unable to translate %T to %T: %w", from, to, err))
+	}
+	return nil
+}
+
+// forward translates the v1 request to v1alpha1, including its headers, calls
+// the given v1alpha1 method and translates its response back to v1. Errors
+// returned by the v1alpha1 method are returned as is, so that their code is
+// preserved.
+func forward[Res, Req, AlphaReq, AlphaRes any](ctx context.Context, request *connect.Request[Req], method func(context.Context, *connect.Request[AlphaReq]) (*connect.Response[AlphaRes], error)) (*connect.Response[Res], error) {
+	alphaMsg := new(AlphaReq)
+	if err := translate(request.Msg, alphaMsg); err != nil {
+		return nil, err
+	}
+	alphaRequest := connect.NewRequest(alphaMsg)
+	for key, values := range request.Header() {
+		alphaRequest.Header()[key] = values
+	}
+
+	alphaResponse, err := method(ctx, alphaRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := new(Res)
+	if err := translate(alphaResponse.Msg, msg); err != nil {
+		return nil, err
+	}
+	response := connect.NewResponse(msg)
+	for key, values := range alphaResponse.Header() {
+		response.Header()[key] = values
+	}
+	for key, values := range alphaResponse.Trailer() {
+		response.Trailer()[key] = values
+	}
+	return response, nil
+}
@@ -0,0 +1,43 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/bufbuild/connect-go"
+)
+
+const (
+	// deprecationHeader flags the responses of a deprecated API.
+	deprecationHeader = "Deprecation"
+	// successorLink points the clients of the deprecated core.packages.v1alpha1
+	// API to the stable core.packages.v1 one.
+	successorLink = `</core/packages/v1>; rel="successor-version"`
+)
+
+// NewDeprecationInterceptor returns an interceptor flagging the responses of
+// the core.packages.v1alpha1 API as deprecated, so that existing clients keep
+// working while being pointed to the stable core.packages.v1 API.
+func NewDeprecationInterceptor() connect.Interceptor {
+	return connect.UnaryInterceptorFunc(func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, request connect.AnyRequest) (connect.AnyResponse, error) {
+			response, err := next(ctx, request)
+			var connectErr *connect.Error
+			if err == nil {
+				setDeprecationHeaders(response.Header())
+			} else if errors.As(err, &connectErr) {
+				setDeprecationHeaders(connectErr.Meta())
+			}
+			return response, err
+		}
+	})
+}
+
+func setDeprecationHeaders(h http.Header) {
+	h.Set(deprecationHeader, "true")
+	h.Set("Link", successorLink)
+}
@@ -0,0 +1,67 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/bufbuild/connect-go"
+	corev1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+)
+
+func TestDeprecationInterceptor(t *testing.T) {
+	testCases := []struct {
+		name string
+		err  error
+	}{
+		{
+			name: "it flags a successful response as deprecated",
+		},
+		{
+			name: "it flags an error as deprecated",
+			err:  connect.NewError(connect.CodeNotFound, fmt.Errorf("Non-OK response")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			next := func(ctx context.Context, request connect.AnyRequest) (connect.AnyResponse, error) {
+				if tc.err != nil {
+					return nil, tc.err
+				}
+				return connect.NewResponse(&corev1.GetAvailablePackageFiltersResponse{}), nil
+			}
+			interceptor := NewDeprecationInterceptor()
+
+			response, err := interceptor.WrapUnary(next)(context.Background(), connect.NewRequest(&corev1.GetAvailablePackageFiltersRequest{}))
+
+			var header http.Header
+			if tc.err == nil {
+				if err != nil {
+					t.Fatalf("%+v", err)
+				}
+				header = response.Header()
+			} else {
+				var connectErr *connect.Error
+				if !errors.As(err, &connectErr) {
+					t.Fatalf("got: %+v, want: a connect error", err)
+				}
+				if got, want := connectErr.Code(), connect.CodeNotFound; got != want {
+					t.Errorf("got: %v, want: %v", got, want)
+				}
+				header = connectErr.Meta()
+			}
+			if got, want := header.Get(deprecationHeader), "true"; got != want {
+				t.Errorf("got: %q, want: %q", got, want)
+			}
+			if got, want := header.Get("Link"), successorLink; got != want {
+				t.Errorf("got: %q, want: %q", got, want)
+			}
+		})
+	}
+}
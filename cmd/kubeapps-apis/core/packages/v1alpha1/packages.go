@@ -8,13 +8,18 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
+	"time"
 
 	. "github.com/ahmetb/go-linq/v3"
 	"github.com/bufbuild/connect-go"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core/icons"
 	pluginsv1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core/plugins/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core/tracing"
 	packages "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
 	connectpackages "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1/v1alpha1connect"
 	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/plugins/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/pkgutils"
 
 	"google.golang.org/grpc/metadata"
 	log "k8s.io/klog/v2"
@@ -33,9 +38,25 @@ type packagesServer struct {
 	// pluginsWithServers is a slice of all registered pluginsWithServers which satisfy the core.packages.v1alpha1
 	// interface.
 	pluginsWithServers []pkgPluginWithServer
+
+	// clusters returns the configured clusters, which may be registered at
+	// runtime, across which the installed package summaries are aggregated
+	// when no cluster is requested.
+	clusters func() []string
+
+	// icons normalizes the icons of the available package details, which
+	// are returned as is when nil.
+	icons *icons.Normalizer
+
+	// aggregatedCalls bounds the calls made to each plugin when aggregating
+	// the results of several plugins and records their outcome.
+	aggregatedCalls aggregatedCalls
 }
 
-func NewPackagesServer(pkgingPlugins []pluginsv1alpha1.PluginWithServer) (*packagesServer, error) {
+// NewPackagesServer returns the server aggregating the given plugins, each call
+// made to a plugin when aggregating their results being bounded by the given
+// timeout (unbounded if 0) and its outcome recorded in the given health tracker.
+func NewPackagesServer(pkgingPlugins []pluginsv1alpha1.PluginWithServer, clusters func() []string, iconNormalizer *icons.Normalizer, health *pluginsv1alpha1.PluginHealthTracker, pluginTimeout time.Duration) (*packagesServer, error) {
 	// Verify that each plugin is indeed a packaging plugin while
 	// casting.
 	pluginsWithServer := make([]pkgPluginWithServer, len(pkgingPlugins))
@@ -52,6 +73,12 @@ func NewPackagesServer(pkgingPlugins []pluginsv1alpha1.PluginWithServer) (*packa
 	}
 	return &packagesServer{
 		pluginsWithServers: pluginsWithServer,
+		clusters:           clusters,
+		icons:              iconNormalizer,
+		aggregatedCalls: aggregatedCalls{
+			timeout: pluginTimeout,
+			health:  health,
+		},
 	}, nil
 }
 
@@ -61,7 +88,12 @@ func (s packagesServer) GetAvailablePackageSummaries(ctx context.Context, reques
 
 	pageSize := request.Msg.GetPaginationOptions().GetPageSize()
 
-	summariesWithOffsets, err := fanInAvailablePackageSummaries(ctx, s.pluginsWithServers, request)
+	// When several plugins are configured, the plugins failing or not
+	// responding in time are reported as partial results.
+	partialResults := newPartialResultsCollector()
+	totalSizes := newTotalSizesCollector()
+	failures := newSourceFailures()
+	summariesWithOffsets, err := fanInAvailablePackageSummaries(ctx, s.pluginsWithServers, request, s.aggregatedCalls, partialResults, totalSizes, failures)
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to request results from registered plugins: %w", err))
 	}
@@ -71,7 +103,7 @@ func (s packagesServer) GetAvailablePackageSummaries(ctx context.Context, reques
 	var pkgWithOffsets availableSummaryWithOffsets
 	for pkgWithOffsets = range summariesWithOffsets {
 		if pkgWithOffsets.err != nil {
-			return nil, err
+			return nil, pkgWithOffsets.err
 		}
 		pkgs = append(pkgs, pkgWithOffsets.availablePackageSummary)
 		categories = append(categories, pkgWithOffsets.categories...)
@@ -79,6 +111,9 @@ func (s packagesServer) GetAvailablePackageSummaries(ctx context.Context, reques
 			break
 		}
 	}
+	if err := failures.allFailed(pluginNames(s.pluginsWithServers)); err != nil {
+		return nil, connect.NewError(connect.CodeOf(err), fmt.Errorf("Unable to get the available packages from any plugin: %w", err))
+	}
 
 	// Only return a next page token of the combined plugin offsets if at least one
 	// plugin is not completely exhausted.
@@ -97,13 +132,29 @@ func (s packagesServer) GetAvailablePackageSummaries(ctx context.Context, reques
 	// Delete duplicate categories and sort by name
 	From(categories).Distinct().OrderBy(func(i interface{}) interface{} { return i }).ToSlice(&categories)
 
+	if request.Msg.IncludeIcons != nil && !request.Msg.GetIncludeIcons() {
+		stripInlineIcons(pkgs)
+	}
+
 	return connect.NewResponse(&packages.GetAvailablePackageSummariesResponse{
 		AvailablePackageSummaries: pkgs,
 		Categories:                categories,
 		NextPageToken:             nextPageToken,
+		PartialResults:            partialResults.partialResults(),
+		TotalSize:                 totalSizes.totalSize(s.pluginsWithServers),
 	}), nil
 }
 
+// stripInlineIcons removes the icons inlined as data URLs from the summaries,
+// which are usually much bigger than the rest of the summary.
+func stripInlineIcons(summaries []*packages.AvailablePackageSummary) {
+	for _, summary := range summaries {
+		if strings.HasPrefix(summary.IconUrl, "data:") {
+			summary.IconUrl = ""
+		}
+	}
+}
+
 // GetAvailablePackageDetail returns the package details based on the request.
 func (s packagesServer) GetAvailablePackageDetail(ctx context.Context, request *connect.Request[packages.GetAvailablePackageDetailRequest]) (*connect.Response[packages.GetAvailablePackageDetailResponse], error) {
 	log.InfoS("+core GetAvailablePackageDetail", "cluster", request.Msg.GetAvailablePackageRef().GetContext().GetCluster(), "namespace", request.Msg.GetAvailablePackageRef().GetContext().GetNamespace())
@@ -119,7 +170,7 @@ func (s packagesServer) GetAvailablePackageDetail(ctx context.Context, request *
 	}
 
 	// Get the response from the requested plugin
-	response, err := pluginWithServer.server.GetAvailablePackageDetail(ctx, request)
+	response, err := callPlugin(ctx, pluginWithServer.plugin, "GetAvailablePackageDetail", request, pluginWithServer.server.GetAvailablePackageDetail)
 	if err != nil {
 		return nil, connect.NewError(connect.CodeOf(err), fmt.Errorf("Unable to get the available package detail for the package %q using the plugin %q: %w", request.Msg.AvailablePackageRef.Identifier, request.Msg.AvailablePackageRef.Plugin.Name, err))
 	}
@@ -129,6 +180,17 @@ func (s packagesServer) GetAvailablePackageDetail(ctx context.Context, request *
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Invalid available package detail response from the plugin %v: %w", pluginWithServer.plugin.Name, err))
 	}
 
+	// Truncate the text assets which are too big, they can be retrieved in ranges
+	// with GetAvailablePackageTextAsset instead.
+	pkgutils.TruncateTextAssets(response.Msg.AvailablePackageDetail, pkgutils.MaxTextAssetSize)
+
+	// Provide the icon in both sizes, with its type checked, and the images of
+	// the readme from the allowed domains only.
+	if s.icons != nil {
+		response.Msg.AvailablePackageDetail.Icon = s.icons.Normalize(response.Msg.AvailablePackageDetail.GetIconUrl())
+		response.Msg.AvailablePackageDetail.Readme = s.icons.RewriteReadmeImages(response.Msg.AvailablePackageDetail.GetReadme())
+	}
+
 	// Build the response
 	return connect.NewResponse(&packages.GetAvailablePackageDetailResponse{
 		AvailablePackageDetail: response.Msg.AvailablePackageDetail,
@@ -141,7 +203,21 @@ func (s packagesServer) GetInstalledPackageSummaries(ctx context.Context, reques
 
 	pageSize := request.Msg.GetPaginationOptions().GetPageSize()
 
-	summariesWithOffsets, err := fanInInstalledPackageSummaries(ctx, s.pluginsWithServers, request)
+	// When no cluster is requested and several clusters are configured, the
+	// installed packages of every cluster are aggregated, reporting the
+	// clusters which could not be listed as partial results, as well as the
+	// plugins failing or not responding in time.
+	var clusters []string
+	if request.Msg.GetContext().GetCluster() == "" && s.clusters != nil {
+		if configured := s.clusters(); len(configured) > 1 {
+			clusters = configured
+		}
+	}
+	sources := installedSummariesSources(s.pluginsWithServers, clusters)
+
+	partialResults := newPartialResultsCollector()
+	failures := newSourceFailures()
+	summariesWithOffsets, err := fanInInstalledPackageSummaries(ctx, sources, request, s.aggregatedCalls, partialResults, failures)
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to request results from registered plugins: %w", err))
 	}
@@ -156,6 +232,9 @@ func (s packagesServer) GetInstalledPackageSummaries(ctx context.Context, reques
 			break
 		}
 	}
+	if err := failures.allFailed(installedSummariesSourceKeys(sources)); err != nil {
+		return nil, connect.NewError(connect.CodeOf(err), fmt.Errorf("Unable to get the installed packages in any cluster or plugin: %w", err))
+	}
 
 	// Only return a next page token of the combined plugin offsets if at least one
 	// plugin is not completely exhausted.
@@ -174,6 +253,7 @@ func (s packagesServer) GetInstalledPackageSummaries(ctx context.Context, reques
 	return connect.NewResponse(&packages.GetInstalledPackageSummariesResponse{
 		InstalledPackageSummaries: pkgs,
 		NextPageToken:             nextPageToken,
+		PartialResults:            partialResults.partialResults(),
 	}), nil
 }
 
@@ -192,7 +272,7 @@ func (s packagesServer) GetInstalledPackageDetail(ctx context.Context, request *
 	}
 
 	// Get the response from the requested plugin
-	response, err := pluginWithServer.server.GetInstalledPackageDetail(ctx, request)
+	response, err := callPlugin(ctx, pluginWithServer.plugin, "GetInstalledPackageDetail", request, pluginWithServer.server.GetInstalledPackageDetail)
 	if err != nil {
 		return nil, connect.NewError(connect.CodeOf(err), fmt.Errorf("Unable to get the installed package detail for the package %q using the plugin %q: %w", request.Msg.InstalledPackageRef.Identifier, request.Msg.InstalledPackageRef.Plugin.Name, err))
 	}
@@ -224,7 +304,7 @@ func (s packagesServer) GetAvailablePackageVersions(ctx context.Context, request
 
 	// Get the response from the requested plugin
 	ctxForPlugin := updateContextWithAuthz(ctx, request.Header())
-	response, err := pluginWithServer.server.GetAvailablePackageVersions(ctxForPlugin, request)
+	response, err := callPlugin(ctxForPlugin, pluginWithServer.plugin, "GetAvailablePackageVersions", request, pluginWithServer.server.GetAvailablePackageVersions)
 	if err != nil {
 		return nil, connect.NewError(connect.CodeOf(err), fmt.Errorf("Unable to get the available package versions for the package %q using the plugin %q: %w", request.Msg.AvailablePackageRef.Identifier, request.Msg.AvailablePackageRef.Plugin.Name, err))
 	}
@@ -240,6 +320,84 @@ func (s packagesServer) GetAvailablePackageVersions(ctx context.Context, request
 	}), nil
 }
 
+// GetAvailablePackageTextAsset returns a range of a text asset (e.g. the readme) of
+// an available package, so that big text assets can be retrieved lazily.
+func (s packagesServer) GetAvailablePackageTextAsset(ctx context.Context, request *connect.Request[packages.GetAvailablePackageTextAssetRequest]) (*connect.Response[packages.GetAvailablePackageTextAssetResponse], error) {
+	log.InfoS("+core GetAvailablePackageTextAsset", "cluster", request.Msg.GetAvailablePackageRef().GetContext().GetCluster(), "namespace", request.Msg.GetAvailablePackageRef().GetContext().GetNamespace(), "asset", request.Msg.GetAsset())
+
+	if request.Msg.GetAvailablePackageRef().GetPlugin() == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Unable to retrieve the plugin (missing AvailablePackageRef.Plugin)"))
+	}
+
+	// Retrieve the plugin with server matching the requested plugin name
+	pluginWithServer := s.getPluginWithServer(request.Msg.AvailablePackageRef.Plugin)
+	if pluginWithServer == nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to get the plugin %v", request.Msg.AvailablePackageRef.Plugin))
+	}
+
+	// Get the response from the requested plugin
+	ctxForPlugin := updateContextWithAuthz(ctx, request.Header())
+	response, err := callPlugin(ctxForPlugin, pluginWithServer.plugin, "GetAvailablePackageTextAsset", request, pluginWithServer.server.GetAvailablePackageTextAsset)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeOf(err), fmt.Errorf("Unable to get the text asset %v for the package %q using the plugin %q: %w", request.Msg.GetAsset(), request.Msg.AvailablePackageRef.Identifier, request.Msg.AvailablePackageRef.Plugin.Name, err))
+	}
+
+	return response, nil
+}
+
+// GetAvailablePackageChangelog returns the release notes of the versions of an
+// available package between two versions, eg. before upgrading an installed package.
+func (s packagesServer) GetAvailablePackageChangelog(ctx context.Context, request *connect.Request[packages.GetAvailablePackageChangelogRequest]) (*connect.Response[packages.GetAvailablePackageChangelogResponse], error) {
+	log.InfoS("+core GetAvailablePackageChangelog", "cluster", request.Msg.GetAvailablePackageRef().GetContext().GetCluster(), "namespace", request.Msg.GetAvailablePackageRef().GetContext().GetNamespace(), "from", request.Msg.GetFromVersion(), "to", request.Msg.GetToVersion())
+
+	if request.Msg.GetAvailablePackageRef().GetPlugin() == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Unable to retrieve the plugin (missing AvailablePackageRef.Plugin)"))
+	}
+
+	// Retrieve the plugin with server matching the requested plugin name
+	pluginWithServer := s.getPluginWithServer(request.Msg.AvailablePackageRef.Plugin)
+	if pluginWithServer == nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to get the plugin %v", request.Msg.AvailablePackageRef.Plugin))
+	}
+
+	// Get the response from the requested plugin
+	ctxForPlugin := updateContextWithAuthz(ctx, request.Header())
+	response, err := callPlugin(ctxForPlugin, pluginWithServer.plugin, "GetAvailablePackageChangelog", request, pluginWithServer.server.GetAvailablePackageChangelog)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeOf(err), fmt.Errorf("Unable to get the changelog for the package %q using the plugin %q: %w", request.Msg.AvailablePackageRef.Identifier, request.Msg.AvailablePackageRef.Plugin.Name, err))
+	}
+
+	return response, nil
+}
+
+// GetAvailablePackageFilters returns the distinct categories and providers of
+// the available packages of every configured plugin, so that clients can
+// offer the values supported in the FilterOptions.
+func (s packagesServer) GetAvailablePackageFilters(ctx context.Context, request *connect.Request[packages.GetAvailablePackageFiltersRequest]) (*connect.Response[packages.GetAvailablePackageFiltersResponse], error) {
+	log.InfoS("+core GetAvailablePackageFilters", "cluster", request.Msg.GetContext().GetCluster(), "namespace", request.Msg.GetContext().GetNamespace())
+
+	categories := []string{}
+	providers := []string{}
+	ctxForPlugin := updateContextWithAuthz(ctx, request.Header())
+	for _, p := range s.pluginsWithServers {
+		response, err := callAggregatedPlugin(ctxForPlugin, s.aggregatedCalls, p.plugin, "GetAvailablePackageFilters", request, p.server.GetAvailablePackageFilters)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeOf(err), fmt.Errorf("Unable to get the available package filters using the plugin %q: %w", p.plugin.Name, err))
+		}
+		categories = append(categories, response.Msg.GetCategories()...)
+		providers = append(providers, response.Msg.GetProviders()...)
+	}
+
+	// Delete duplicates and sort by name
+	From(categories).Distinct().OrderBy(func(i interface{}) interface{} { return i }).ToSlice(&categories)
+	From(providers).Distinct().OrderBy(func(i interface{}) interface{} { return i }).ToSlice(&providers)
+
+	return connect.NewResponse(&packages.GetAvailablePackageFiltersResponse{
+		Categories: categories,
+		Providers:  providers,
+	}), nil
+}
+
 // GetInstalledPackageResourceRefs returns the references for the Kubernetes resources created by
 // an installed package.
 func (s *packagesServer) GetInstalledPackageResourceRefs(ctx context.Context, request *connect.Request[packages.GetInstalledPackageResourceRefsRequest]) (*connect.Response[packages.GetInstalledPackageResourceRefsResponse], error) {
@@ -259,7 +417,7 @@ func (s *packagesServer) GetInstalledPackageResourceRefs(ctx context.Context, re
 
 	// Get the response from the requested plugin
 	ctxForPlugin := updateContextWithAuthz(ctx, request.Header())
-	response, err := pluginWithServer.server.GetInstalledPackageResourceRefs(ctxForPlugin, request)
+	response, err := callPlugin(ctxForPlugin, pluginWithServer.plugin, "GetInstalledPackageResourceRefs", request, pluginWithServer.server.GetInstalledPackageResourceRefs)
 	if err != nil {
 		log.Errorf("Unable to get the resource refs for the package %q using the plugin %q: %v", request.Msg.InstalledPackageRef.Identifier, request.Msg.InstalledPackageRef.Plugin.Name, err)
 
@@ -273,6 +431,62 @@ func (s *packagesServer) GetInstalledPackageResourceRefs(ctx context.Context, re
 	return response, nil
 }
 
+// GetInstalledPackageOperationStatus returns the progress of the operation in
+// course on an installed package, as reported by its plugin.
+func (s packagesServer) GetInstalledPackageOperationStatus(ctx context.Context, request *connect.Request[packages.GetInstalledPackageOperationStatusRequest]) (*connect.Response[packages.GetInstalledPackageOperationStatusResponse], error) {
+	log.InfoS("+core GetInstalledPackageOperationStatus", "cluster", request.Msg.GetInstalledPackageRef().GetContext().GetCluster(), "namespace", request.Msg.GetInstalledPackageRef().GetContext().GetNamespace(), "id", request.Msg.GetInstalledPackageRef().GetIdentifier())
+
+	if request.Msg.GetInstalledPackageRef().GetPlugin() == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Unable to retrieve the plugin (missing InstalledPackageRef.Plugin)"))
+	}
+
+	// Retrieve the plugin with server matching the requested plugin name
+	pluginWithServer := s.getPluginWithServer(request.Msg.InstalledPackageRef.Plugin)
+	if pluginWithServer == nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to get the plugin %v", request.Msg.InstalledPackageRef.Plugin))
+	}
+
+	// Get the response from the requested plugin
+	ctxForPlugin := updateContextWithAuthz(ctx, request.Header())
+	response, err := callPlugin(ctxForPlugin, pluginWithServer.plugin, "GetInstalledPackageOperationStatus", request, pluginWithServer.server.GetInstalledPackageOperationStatus)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeOf(err), fmt.Errorf("Unable to get the operation status for the package %q using the plugin %q: %w", request.Msg.InstalledPackageRef.Identifier, request.Msg.InstalledPackageRef.Plugin.Name, err))
+	}
+
+	// Validate the plugin response
+	if response.Msg.GetStatus() == nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Invalid GetInstalledPackageOperationStatus response from the plugin %v: missing status", pluginWithServer.plugin.Name))
+	}
+
+	return response, nil
+}
+
+// CheckInstalledPackageNameAvailability checks with every configured plugin
+// whether the name is already used by an installed package in the target
+// namespace, so that a conflict can be reported before creating a package.
+func (s packagesServer) CheckInstalledPackageNameAvailability(ctx context.Context, request *connect.Request[packages.CheckInstalledPackageNameAvailabilityRequest]) (*connect.Response[packages.CheckInstalledPackageNameAvailabilityResponse], error) {
+	log.InfoS("+core CheckInstalledPackageNameAvailability", "cluster", request.Msg.GetTargetContext().GetCluster(), "namespace", request.Msg.GetTargetContext().GetNamespace(), "name", request.Msg.GetName())
+
+	if request.Msg.GetName() == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Unable to check the availability of an empty name"))
+	}
+
+	conflicts := []*packages.InstalledPackageReference{}
+	ctxForPlugin := updateContextWithAuthz(ctx, request.Header())
+	for _, p := range s.pluginsWithServers {
+		response, err := callPlugin(ctxForPlugin, p.plugin, "CheckInstalledPackageNameAvailability", request, p.server.CheckInstalledPackageNameAvailability)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeOf(err), fmt.Errorf("Unable to check the availability of the name %q using the plugin %q: %w", request.Msg.GetName(), p.plugin.Name, err))
+		}
+		conflicts = append(conflicts, response.Msg.GetConflicts()...)
+	}
+
+	return connect.NewResponse(&packages.CheckInstalledPackageNameAvailabilityResponse{
+		Available: len(conflicts) == 0,
+		Conflicts: conflicts,
+	}), nil
+}
+
 // CreateInstalledPackage creates an installed package using configured plugins.
 func (s packagesServer) CreateInstalledPackage(ctx context.Context, request *connect.Request[packages.CreateInstalledPackageRequest]) (*connect.Response[packages.CreateInstalledPackageResponse], error) {
 	log.InfoS("+core CreateInstalledPackage", "cluster", request.Msg.GetTargetContext().GetCluster(), "namespace", request.Msg.GetTargetContext().GetNamespace())
@@ -289,7 +503,7 @@ func (s packagesServer) CreateInstalledPackage(ctx context.Context, request *con
 
 	// Get the response from the requested plugin
 	ctxForPlugin := updateContextWithAuthz(ctx, request.Header())
-	response, err := pluginWithServer.server.CreateInstalledPackage(ctxForPlugin, request)
+	response, err := callPlugin(ctxForPlugin, pluginWithServer.plugin, "CreateInstalledPackage", request, pluginWithServer.server.CreateInstalledPackage)
 	if err != nil {
 		return nil, connect.NewError(connect.CodeOf(err), fmt.Errorf("Unable to create the installed package for the package %q using the plugin %q: %w", request.Msg.AvailablePackageRef.Identifier, request.Msg.AvailablePackageRef.Plugin.Name, err))
 	}
@@ -309,6 +523,9 @@ func (s packagesServer) UpdateInstalledPackage(ctx context.Context, request *con
 	if request.Msg.GetInstalledPackageRef().GetPlugin() == nil {
 		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Unable to retrieve the plugin (missing InstalledPackageRef.Plugin)"))
 	}
+	if request.Msg.GetReuseValues() && request.Msg.GetValues() != "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("The values cannot be provided when reusing the current ones"))
+	}
 
 	// Retrieve the plugin with server matching the requested plugin name
 	pluginWithServer := s.getPluginWithServer(request.Msg.InstalledPackageRef.Plugin)
@@ -318,7 +535,7 @@ func (s packagesServer) UpdateInstalledPackage(ctx context.Context, request *con
 
 	// Get the response from the requested plugin
 	ctxForPlugin := updateContextWithAuthz(ctx, request.Header())
-	response, err := pluginWithServer.server.UpdateInstalledPackage(ctxForPlugin, request)
+	response, err := callPlugin(ctxForPlugin, pluginWithServer.plugin, "UpdateInstalledPackage", request, pluginWithServer.server.UpdateInstalledPackage)
 	if err != nil {
 		return nil, connect.NewError(connect.CodeOf(err), fmt.Errorf("Unable to update the installed package for the package %q using the plugin %q: %w", request.Msg.InstalledPackageRef.Identifier, request.Msg.InstalledPackageRef.Plugin.Name, err))
 	}
@@ -347,7 +564,7 @@ func (s packagesServer) DeleteInstalledPackage(ctx context.Context, request *con
 
 	// Get the response from the requested plugin
 	ctxForPlugin := updateContextWithAuthz(ctx, request.Header())
-	response, err := pluginWithServer.server.DeleteInstalledPackage(ctxForPlugin, request)
+	response, err := callPlugin(ctxForPlugin, pluginWithServer.plugin, "DeleteInstalledPackage", request, pluginWithServer.server.DeleteInstalledPackage)
 	if err != nil {
 		return nil, connect.NewError(connect.CodeOf(err), fmt.Errorf("Unable to delete the installed packagefor the package %q using the plugin %q: %w", request.Msg.InstalledPackageRef.Identifier, request.Msg.InstalledPackageRef.Plugin.Name, err))
 	}
@@ -366,6 +583,15 @@ func (s packagesServer) getPluginWithServer(plugin *v1alpha1.Plugin) *pkgPluginW
 	return nil
 }
 
+// callPlugin calls the RPC of a plugin within a span, so that the time spent
+// in each plugin can be told apart in the trace of the core request.
+func callPlugin[Req, Res any](ctx context.Context, plugin *v1alpha1.Plugin, method string, request *connect.Request[Req], rpc func(context.Context, *connect.Request[Req]) (*connect.Response[Res], error)) (*connect.Response[Res], error) {
+	ctx, span := tracing.StartPluginSpan(ctx, plugin.GetName(), method)
+	response, err := rpc(ctx, request)
+	tracing.EndSpan(span, err)
+	return response, err
+}
+
 func updateContextWithAuthz(ctx context.Context, h http.Header) context.Context {
 	// Add authz to context metadata for untransitioned plugins.
 	// TODO: Remove once plugins transitioned.
@@ -6,10 +6,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sync"
 
 	"github.com/bufbuild/connect-go"
+	pluginsv1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core/plugins/v1alpha1"
 	packages "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/plugins/v1alpha1"
 	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/paginate"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/pkgutils"
 )
 
 const CompleteToken = -1
@@ -42,7 +46,10 @@ type availableSummaryWithOffsets struct {
 }
 
 // fanInAvailablePackageSummaries fans in the results from the separate plugins
-// to the return channel.
+// to the return channel, recording any namespace failures reported by the
+// plugins in the given partial results collector. When several plugins are
+// queried, the plugins failing or not responding in time are left out of the
+// results and recorded as failed too.
 //
 // Each plugin handles the request in a separate go-routine while this function
 // uses the fan-in pattern to merge those results, sending the next result back
@@ -56,7 +63,7 @@ type availableSummaryWithOffsets struct {
 // pagination of individual plugins, it will be possible that this returns
 // duplicates or missing data if data is added or removed between paginated
 // requests.
-func fanInAvailablePackageSummaries(ctx context.Context, pkgPlugins []pkgPluginWithServer, request *connect.Request[packages.GetAvailablePackageSummariesRequest]) (<-chan availableSummaryWithOffsets, error) {
+func fanInAvailablePackageSummaries(ctx context.Context, pkgPlugins []pkgPluginWithServer, request *connect.Request[packages.GetAvailablePackageSummariesRequest], calls aggregatedCalls, partialResults *partialResultsCollector, totalSizes *totalSizesCollector, failures *sourceFailures) (<-chan availableSummaryWithOffsets, error) {
 	summariesCh := make(chan availableSummaryWithOffsets)
 
 	pluginPageOffsets, pluginPageSize, err := getPluginPageOffsets(request.Msg.GetPaginationOptions(), len(pkgPlugins))
@@ -79,7 +86,7 @@ func fanInAvailablePackageSummaries(ctx context.Context, pkgPlugins []pkgPluginW
 		connectRequest := connect.NewRequest(r)
 		connectRequest.Header().Set("Authorization", request.Header().Get("Authorization"))
 
-		ch, err := sendAvailablePackageSummariesForPlugin(ctx, pluginWithSrv, connectRequest)
+		ch, err := sendAvailablePackageSummariesForPlugin(ctx, pluginWithSrv, connectRequest, calls, len(pkgPlugins) > 1, partialResults, totalSizes, failures)
 		if err != nil {
 			return nil, err
 		}
@@ -172,7 +179,11 @@ type availableSummaryWithOffset struct {
 
 // sendAvailablePackageSummariesForPlugin returns a channel and sends the
 // available package summaries returned by the plugin for the given request.
-func sendAvailablePackageSummariesForPlugin(ctx context.Context, pkgPlugin pkgPluginWithServer, request *connect.Request[packages.GetAvailablePackageSummariesRequest]) (<-chan *availableSummaryWithOffset, error) {
+//
+// When partial is set, a failure of the plugin itself is recorded as a failure
+// for the plugin rather than failing the whole request, so that the results
+// from the other plugins are still returned.
+func sendAvailablePackageSummariesForPlugin(ctx context.Context, pkgPlugin pkgPluginWithServer, request *connect.Request[packages.GetAvailablePackageSummariesRequest], calls aggregatedCalls, partial bool, partialResults *partialResultsCollector, totalSizes *totalSizesCollector, failures *sourceFailures) (<-chan *availableSummaryWithOffset, error) {
 	summaryCh := make(chan *availableSummaryWithOffset)
 
 	itemOffset, err := paginate.ItemOffsetFromPageToken(request.Msg.GetPaginationOptions().GetPageToken())
@@ -192,12 +203,21 @@ func sendAvailablePackageSummariesForPlugin(ctx context.Context, pkgPlugin pkgPl
 	// improvement.
 	go func() {
 		for {
-			response, err := pkgPlugin.server.GetAvailablePackageSummaries(ctx, request)
+			response, err := callAggregatedPlugin(ctx, calls, pkgPlugin.plugin, "GetAvailablePackageSummaries", request, pkgPlugin.server.GetAvailablePackageSummaries)
 			if err != nil {
-				summaryCh <- &availableSummaryWithOffset{err: err}
+				if partial && pluginsv1alpha1.IsPluginFailure(err) {
+					failures.add(pkgPlugin.plugin.GetName(), err)
+					partialResults.addPluginFailure(pkgPlugin.plugin, err)
+				} else {
+					summaryCh <- &availableSummaryWithOffset{err: err}
+				}
 				close(summaryCh)
 				return
 			}
+			// Namespace failures are recorded before sending any item so that
+			// they are available once the items of this response are consumed.
+			partialResults.add(pkgPlugin.plugin, response.Msg.GetPartialResults())
+			totalSizes.add(pkgPlugin.plugin, response.Msg.TotalSize)
 			categories := response.Msg.Categories
 			for _, summary := range response.Msg.AvailablePackageSummaries {
 				itemOffset = itemOffset + 1
@@ -227,6 +247,47 @@ func sendAvailablePackageSummariesForPlugin(ctx context.Context, pkgPlugin pkgPl
 	return summaryCh, nil
 }
 
+// totalSizesCollector records the total number of available packages reported
+// by each plugin. Like the partial results, it is safe for concurrent use as
+// the plugins are queried in separate go-routines.
+type totalSizesCollector struct {
+	mu    sync.Mutex
+	sizes map[string]int32
+}
+
+func newTotalSizesCollector() *totalSizesCollector {
+	return &totalSizesCollector{
+		sizes: map[string]int32{},
+	}
+}
+
+// add records the total size reported by the given plugin, if any.
+func (c *totalSizesCollector) add(plugin *v1alpha1.Plugin, totalSize *int32) {
+	if totalSize == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sizes[plugin.GetName()] = *totalSize
+}
+
+// totalSize returns the sum of the total sizes reported by the given plugins,
+// or nil if any of them did not report one. Plugins already exhausted by a
+// previous page are not queried again, so the total is then unknown.
+func (c *totalSizesCollector) totalSize(pkgPlugins []pkgPluginWithServer) *int32 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var total int32
+	for _, pkgPlugin := range pkgPlugins {
+		size, ok := c.sizes[pkgPlugin.plugin.GetName()]
+		if !ok {
+			return nil
+		}
+		total += size
+	}
+	return &total
+}
+
 // TODO(minelson): See if we can reduce the duplication of functionality
 // here for available vs installed package summaries using generics and/or
 // interfaces.
@@ -240,7 +301,8 @@ type installedSummaryWithOffsets struct {
 }
 
 // fanInInstalledPackageSummaries fans in the results from the separate plugins
-// to the return channel.
+// to the return channel, recording any namespace failures reported by the
+// plugins in the given partial results collector.
 //
 // Each plugin handles the request in a separate go-routine while this function
 // uses the fan-in pattern to merge those results, sending the next result back
@@ -254,29 +316,36 @@ type installedSummaryWithOffsets struct {
 // pagination of individual plugins, it will be possible that this returns
 // duplicates or missing data if data is added or removed between paginated
 // requests.
-func fanInInstalledPackageSummaries(ctx context.Context, pkgPlugins []pkgPluginWithServer, request *connect.Request[packages.GetInstalledPackageSummariesRequest]) (<-chan installedSummaryWithOffsets, error) {
+func fanInInstalledPackageSummaries(ctx context.Context, sources []installedSummariesSource, request *connect.Request[packages.GetInstalledPackageSummariesRequest], calls aggregatedCalls, partialResults *partialResultsCollector, failures *sourceFailures) (<-chan installedSummaryWithOffsets, error) {
 	summariesCh := make(chan installedSummaryWithOffsets)
 
-	pluginPageOffsets, pluginPageSize, err := getPluginPageOffsets(request.Msg.GetPaginationOptions(), len(pkgPlugins))
+	pluginPageOffsets, pluginPageSize, err := getPluginPageOffsets(request.Msg.GetPaginationOptions(), len(sources))
 	if err != nil {
 		return nil, err
 	}
 
 	fanInput := []<-chan *installedSummaryWithOffset{}
-	for _, pluginWithSrv := range pkgPlugins {
+	for _, source := range sources {
 		// Importantly, each plugin needs its own request, with its own pagination
 		// options.
 		r := &packages.GetInstalledPackageSummariesRequest{
 			Context: request.Msg.Context,
 			PaginationOptions: &packages.PaginationOptions{
 				PageSize:  int32(pluginPageSize),
-				PageToken: fmt.Sprintf("%d", pluginPageOffsets[pluginWithSrv.plugin.Name]),
+				PageToken: fmt.Sprintf("%d", pluginPageOffsets[source.key]),
 			},
+			FilterOptions: request.Msg.FilterOptions,
+		}
+		if source.cluster != "" {
+			r.Context = &packages.Context{
+				Cluster:   source.cluster,
+				Namespace: request.Msg.GetContext().GetNamespace(),
+			}
 		}
 		connectRequest := connect.NewRequest(r)
 		connectRequest.Header().Set("Authorization", request.Header().Get("Authorization"))
 
-		ch, err := sendInstalledPackageSummariesForPlugin(ctx, pluginWithSrv, connectRequest)
+		ch, err := sendInstalledPackageSummariesForPlugin(ctx, source, connectRequest, calls, len(sources) > 1, partialResults, failures)
 		if err != nil {
 			return nil, err
 		}
@@ -301,8 +370,7 @@ func fanInInstalledPackageSummaries(ctx context.Context, pkgPlugins []pkgPluginW
 						// If the channel was closed, we reached the last item for that
 						// plugin. We need to recognise when all plugins have exhausted
 						// itemsoffsets
-						pluginName := pkgPlugins[i].plugin.Name
-						pluginPageOffsets[pluginName] = CompleteToken
+						pluginPageOffsets[sources[i].key] = CompleteToken
 					}
 
 					if nextItems[i] != nil && nextItems[i].err != nil {
@@ -337,8 +405,7 @@ func fanInInstalledPackageSummaries(ctx context.Context, pkgPlugins []pkgPluginW
 					minIndex = i
 				}
 			}
-			pluginName := nextItems[minIndex].installedPackageSummary.GetInstalledPackageRef().GetPlugin().GetName()
-			pluginPageOffsets[pluginName] = nextItems[minIndex].nextItemOffset
+			pluginPageOffsets[sources[minIndex].key] = nextItems[minIndex].nextItemOffset
 			summariesCh <- installedSummaryWithOffsets{
 				installedPackageSummary: nextItems[minIndex].installedPackageSummary,
 				nextItemOffsets:         pluginPageOffsets,
@@ -367,8 +434,15 @@ type installedSummaryWithOffset struct {
 
 // sendInstalledPackageSummariesForPlugin returns a channel and sends the
 // available package summaries returned by the plugin for the given request.
-func sendInstalledPackageSummariesForPlugin(ctx context.Context, pkgPlugin pkgPluginWithServer, request *connect.Request[packages.GetInstalledPackageSummariesRequest]) (<-chan *installedSummaryWithOffset, error) {
+//
+// When the source is for one of several clusters, an error from the plugin
+// is recorded as a failure for the cluster rather than failing the whole
+// request, so that the results from the other clusters are still returned.
+// Likewise, when partial is set, a failure of the plugin itself is recorded as
+// a failure for the plugin.
+func sendInstalledPackageSummariesForPlugin(ctx context.Context, source installedSummariesSource, request *connect.Request[packages.GetInstalledPackageSummariesRequest], calls aggregatedCalls, partial bool, partialResults *partialResultsCollector, failures *sourceFailures) (<-chan *installedSummaryWithOffset, error) {
 	summaryCh := make(chan *installedSummaryWithOffset)
+	pkgPlugin := source.pkgPluginWithServer
 
 	itemOffset, err := paginate.ItemOffsetFromPageToken(request.Msg.GetPaginationOptions().GetPageToken())
 	if err != nil {
@@ -387,12 +461,29 @@ func sendInstalledPackageSummariesForPlugin(ctx context.Context, pkgPlugin pkgPl
 	// improvement.
 	go func() {
 		for {
-			response, err := pkgPlugin.server.GetInstalledPackageSummaries(ctx, request)
+			response, err := callAggregatedPlugin(ctx, calls, pkgPlugin.plugin, "GetInstalledPackageSummaries", request, pkgPlugin.server.GetInstalledPackageSummaries)
 			if err != nil {
-				summaryCh <- &installedSummaryWithOffset{err: err}
+				if source.cluster != "" {
+					failures.add(source.key, err)
+					partialResults.add(pkgPlugin.plugin, pkgutils.NewPartialResults([]*packages.NamespaceFailure{
+						{
+							Context: request.Msg.GetContext(),
+							Code:    connect.CodeOf(err).String(),
+							Reason:  err.Error(),
+						},
+					}))
+				} else if partial && pluginsv1alpha1.IsPluginFailure(err) {
+					failures.add(source.key, err)
+					partialResults.addPluginFailure(pkgPlugin.plugin, err)
+				} else {
+					summaryCh <- &installedSummaryWithOffset{err: err}
+				}
 				close(summaryCh)
 				return
 			}
+			// Namespace failures are recorded before sending any item so that
+			// they are available once the items of this response are consumed.
+			partialResults.add(pkgPlugin.plugin, response.Msg.GetPartialResults())
 			for _, summary := range response.Msg.InstalledPackageSummaries {
 				itemOffset = itemOffset + 1
 				summaryCh <- &installedSummaryWithOffset{
@@ -417,3 +508,79 @@ func sendInstalledPackageSummariesForPlugin(ctx context.Context, pkgPlugin pkgPl
 
 	return summaryCh, nil
 }
+
+// installedSummariesSource is a plugin queried for the installed package
+// summaries, either for the requested context or for one of the configured
+// clusters when aggregating across clusters.
+type installedSummariesSource struct {
+	pkgPluginWithServer
+	// cluster replaces the cluster of the requested context, if set.
+	cluster string
+	// key identifies the source in the page token offsets.
+	key string
+}
+
+// installedSummariesSources returns a source for each plugin, or, when
+// clusters are given, for each plugin in each cluster.
+func installedSummariesSources(pkgPlugins []pkgPluginWithServer, clusters []string) []installedSummariesSource {
+	sources := []installedSummariesSource{}
+	if len(clusters) == 0 {
+		for _, p := range pkgPlugins {
+			sources = append(sources, installedSummariesSource{pkgPluginWithServer: p, key: p.plugin.GetName()})
+		}
+		return sources
+	}
+	for _, cluster := range clusters {
+		for _, p := range pkgPlugins {
+			sources = append(sources, installedSummariesSource{pkgPluginWithServer: p, cluster: cluster, key: cluster + "/" + p.plugin.GetName()})
+		}
+	}
+	return sources
+}
+
+// installedSummariesSourceKeys returns the keys of the given sources.
+func installedSummariesSourceKeys(sources []installedSummariesSource) []string {
+	keys := make([]string, len(sources))
+	for i, source := range sources {
+		keys[i] = source.key
+	}
+	return keys
+}
+
+// pluginNames returns the names of the given plugins, which are the keys of
+// the plugins in the page token offsets.
+func pluginNames(pkgPlugins []pkgPluginWithServer) []string {
+	names := make([]string, len(pkgPlugins))
+	for i, p := range pkgPlugins {
+		names[i] = p.plugin.GetName()
+	}
+	return names
+}
+
+// sourceFailures records the errors returned by each source when
+// aggregating across clusters or plugins.
+type sourceFailures struct {
+	mu     sync.Mutex
+	errors map[string]error
+}
+
+func newSourceFailures() *sourceFailures {
+	return &sourceFailures{errors: map[string]error{}}
+}
+
+func (f *sourceFailures) add(key string, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.errors[key] = err
+}
+
+// allFailed returns the error of the first of the sources with the given keys
+// if every one of them failed, or nil otherwise.
+func (f *sourceFailures) allFailed(keys []string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(keys) == 0 || len(f.errors) < len(keys) {
+		return nil
+	}
+	return f.errors[keys[0]]
+}
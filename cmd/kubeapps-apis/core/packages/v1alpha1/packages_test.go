@@ -5,14 +5,19 @@ package v1alpha1
 
 import (
 	"context"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/bufbuild/connect-go"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core/icons"
+	pluginsv1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core/plugins/v1alpha1"
 	corev1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
 	plugins "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/plugins/v1alpha1"
 	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugin_test"
+	"google.golang.org/protobuf/proto"
 )
 
 const (
@@ -32,9 +37,16 @@ var ignoreUnexportedOpts = cmpopts.IgnoreUnexported(
 	corev1.GetAvailablePackageDetailResponse{},
 	corev1.GetAvailablePackageSummariesResponse{},
 	corev1.GetAvailablePackageVersionsResponse{},
+	corev1.GetAvailablePackageTextAssetResponse{},
+	corev1.GetAvailablePackageChangelogResponse{},
+	corev1.GetAvailablePackageFiltersResponse{},
+	corev1.PackageIcon{},
+	corev1.PackageVersionChangelog{},
 	corev1.GetInstalledPackageResourceRefsResponse{},
 	corev1.GetInstalledPackageDetailResponse{},
+	corev1.GetInstalledPackageOperationStatusResponse{},
 	corev1.GetInstalledPackageSummariesResponse{},
+	corev1.CheckInstalledPackageNameAvailabilityResponse{},
 	corev1.CreateInstalledPackageResponse{},
 	corev1.UpdateInstalledPackageResponse{},
 	corev1.InstalledPackageDetail{},
@@ -42,7 +54,10 @@ var ignoreUnexportedOpts = cmpopts.IgnoreUnexported(
 	corev1.InstalledPackageStatus{},
 	corev1.InstalledPackageSummary{},
 	corev1.Maintainer{},
+	corev1.NamespaceFailure{},
 	corev1.PackageAppVersion{},
+	corev1.PartialResults{},
+	corev1.PluginFailure{},
 	corev1.VersionReference{},
 	corev1.ResourceRef{},
 	plugins.Plugin{},
@@ -75,6 +90,22 @@ func makeDefaultTestPackagingPlugin(pluginName string) pkgPluginWithServer {
 	}
 }
 
+// makePartialResultsTestPackagingPlugin returns a default test plugin which
+// reports that it could not list the given namespace.
+func makePartialResultsTestPackagingPlugin(pluginName, namespace string) pkgPluginWithServer {
+	pluginWithServer := makeDefaultTestPackagingPlugin(pluginName)
+	pluginWithServer.server.(*plugin_test.TestPackagingPluginServer).PartialResults = &corev1.PartialResults{
+		NamespaceFailures: []*corev1.NamespaceFailure{
+			{
+				Context: &corev1.Context{Cluster: plugin_test.GlobalPackagingCluster, Namespace: namespace},
+				Code:    connect.CodePermissionDenied.String(),
+				Reason:  "Forbidden",
+			},
+		},
+	}
+	return pluginWithServer
+}
+
 func makeOnlyStatusTestPackagingPlugin(pluginName string, errorCode connect.Code) pkgPluginWithServer {
 	pluginDetails := &plugins.Plugin{Name: pluginName, Version: "v1alpha1"}
 	packagingPluginServer := &plugin_test.TestPackagingPluginServer{Plugin: pluginDetails}
@@ -116,6 +147,7 @@ func TestGetAvailablePackageSummaries(t *testing.T) {
 					plugin_test.MakeAvailablePackageSummary("pkg-2", mockedPackagingPlugin2.plugin),
 				},
 				Categories: []string{"cat-1"},
+				TotalSize:  proto.Int32(4),
 			},
 		},
 		{
@@ -139,6 +171,7 @@ func TestGetAvailablePackageSummaries(t *testing.T) {
 				},
 				Categories:    []string{"cat-1"},
 				NextPageToken: `{"mock1":1,"mock2":1}`,
+				TotalSize:     proto.Int32(4),
 			},
 		},
 		{
@@ -163,6 +196,7 @@ func TestGetAvailablePackageSummaries(t *testing.T) {
 				},
 				Categories:    []string{"cat-1"},
 				NextPageToken: `{"mock1":2,"mock2":1}`,
+				TotalSize:     proto.Int32(4),
 			},
 		},
 		{
@@ -184,6 +218,7 @@ func TestGetAvailablePackageSummaries(t *testing.T) {
 				},
 				Categories:    []string{"cat-1"},
 				NextPageToken: "",
+				TotalSize:     proto.Int32(4),
 			},
 		},
 		{
@@ -205,6 +240,7 @@ func TestGetAvailablePackageSummaries(t *testing.T) {
 				},
 				Categories:    []string{"cat-1"},
 				NextPageToken: `{"mock1":-1,"mock2":2}`,
+				TotalSize:     proto.Int32(4),
 			},
 		},
 		{
@@ -228,6 +264,8 @@ func TestGetAvailablePackageSummaries(t *testing.T) {
 				AvailablePackageSummaries: []*corev1.AvailablePackageSummary{},
 				Categories:                []string{},
 				NextPageToken:             "",
+				// The total size is unknown as the exhausted plugin is not queried.
+				TotalSize: nil,
 			},
 		},
 		{
@@ -254,6 +292,7 @@ func TestGetAvailablePackageSummaries(t *testing.T) {
 				},
 				Categories:    []string{"cat-1"},
 				NextPageToken: `{"mock1":-1,"mock2":2,"mock3":1}`,
+				TotalSize:     proto.Int32(6),
 			},
 		},
 		{
@@ -300,10 +339,173 @@ func TestGetAvailablePackageSummaries(t *testing.T) {
 	}
 }
 
+// slowTestPackagingPluginServer is a test plugin which does not return the
+// available packages until the request is done.
+type slowTestPackagingPluginServer struct {
+	*plugin_test.TestPackagingPluginServer
+}
+
+func (s slowTestPackagingPluginServer) GetAvailablePackageSummaries(ctx context.Context, request *connect.Request[corev1.GetAvailablePackageSummariesRequest]) (*connect.Response[corev1.GetAvailablePackageSummariesResponse], error) {
+	<-ctx.Done()
+	return nil, connect.NewError(connect.CodeCanceled, ctx.Err())
+}
+
+func makeSlowTestPackagingPlugin(pluginName string) pkgPluginWithServer {
+	pluginDetails := &plugins.Plugin{Name: pluginName, Version: "v1alpha1"}
+	return pkgPluginWithServer{
+		plugin: pluginDetails,
+		server: slowTestPackagingPluginServer{
+			TestPackagingPluginServer: &plugin_test.TestPackagingPluginServer{Plugin: pluginDetails},
+		},
+	}
+}
+
+func TestGetAvailablePackageSummariesPartialResults(t *testing.T) {
+	unavailablePlugin := makeOnlyStatusTestPackagingPlugin("unavailable-plugin", connect.CodeUnavailable)
+	slowPlugin := makeSlowTestPackagingPlugin("slow-plugin")
+
+	testCases := []struct {
+		name              string
+		configuredPlugins []pkgPluginWithServer
+		errorCode         connect.Code
+		expectedResponse  *corev1.GetAvailablePackageSummariesResponse
+		expectedHealthy   []bool
+	}{
+		{
+			name: "it reports a failing plugin as a partial result",
+			configuredPlugins: []pkgPluginWithServer{
+				mockedPackagingPlugin1,
+				unavailablePlugin,
+			},
+			expectedResponse: &corev1.GetAvailablePackageSummariesResponse{
+				AvailablePackageSummaries: []*corev1.AvailablePackageSummary{
+					plugin_test.MakeAvailablePackageSummary("pkg-1", mockedPackagingPlugin1.plugin),
+					plugin_test.MakeAvailablePackageSummary("pkg-2", mockedPackagingPlugin1.plugin),
+				},
+				Categories: []string{"cat-1"},
+				PartialResults: &corev1.PartialResults{
+					PluginFailures: []*corev1.PluginFailure{
+						{
+							Plugin: unavailablePlugin.plugin,
+							Code:   connect.CodeUnavailable.String(),
+							Reason: "unavailable: Non-OK response",
+						},
+					},
+				},
+			},
+			expectedHealthy: []bool{true, false},
+		},
+		{
+			name: "it reports a plugin not responding in time as a partial result",
+			configuredPlugins: []pkgPluginWithServer{
+				mockedPackagingPlugin1,
+				slowPlugin,
+			},
+			expectedResponse: &corev1.GetAvailablePackageSummariesResponse{
+				AvailablePackageSummaries: []*corev1.AvailablePackageSummary{
+					plugin_test.MakeAvailablePackageSummary("pkg-1", mockedPackagingPlugin1.plugin),
+					plugin_test.MakeAvailablePackageSummary("pkg-2", mockedPackagingPlugin1.plugin),
+				},
+				Categories: []string{"cat-1"},
+				PartialResults: &corev1.PartialResults{
+					PluginFailures: []*corev1.PluginFailure{
+						{
+							Plugin: slowPlugin.plugin,
+							Code:   connect.CodeDeadlineExceeded.String(),
+							Reason: `deadline_exceeded: The plugin "slow-plugin" did not respond within 50ms`,
+						},
+					},
+				},
+			},
+			expectedHealthy: []bool{true, false},
+		},
+		{
+			name: "it returns an error if every plugin fails",
+			configuredPlugins: []pkgPluginWithServer{
+				unavailablePlugin,
+				slowPlugin,
+			},
+			errorCode: connect.CodeUnavailable,
+		},
+		{
+			name: "it returns the error of the only plugin",
+			configuredPlugins: []pkgPluginWithServer{
+				unavailablePlugin,
+			},
+			errorCode: connect.CodeUnavailable,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			health := pluginsv1alpha1.NewPluginHealthTracker()
+			server := &packagesServer{
+				pluginsWithServers: tc.configuredPlugins,
+				aggregatedCalls: aggregatedCalls{
+					timeout: 50 * time.Millisecond,
+					health:  health,
+				},
+			}
+			response, err := server.GetAvailablePackageSummaries(context.Background(), connect.NewRequest(&corev1.GetAvailablePackageSummariesRequest{
+				Context: &corev1.Context{Namespace: globalPackagingNamespace},
+			}))
+
+			if got, want := connect.CodeOf(err), tc.errorCode; err != nil && got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+			if tc.errorCode != 0 {
+				if err == nil {
+					t.Fatalf("got: nil, want: error with code %+v", tc.errorCode)
+				}
+				return
+			}
+
+			if got, want := response.Msg, tc.expectedResponse; !cmp.Equal(got, want, ignoreUnexportedOpts) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexportedOpts))
+			}
+
+			pluginDetails := []*plugins.Plugin{}
+			for _, p := range tc.configuredPlugins {
+				pluginDetails = append(pluginDetails, p.plugin)
+			}
+			for i, h := range health.Health(pluginDetails) {
+				if got, want := h.GetHealthy(), tc.expectedHealthy[i]; got != want {
+					t.Errorf("plugin %q: got healthy: %t, want: %t", h.GetPlugin().GetName(), got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestStripInlineIcons(t *testing.T) {
+	summaries := []*corev1.AvailablePackageSummary{
+		{Name: "inline", IconUrl: "data:image/svg+xml;base64,Tm90IHJlYWxseSBTVkcK"},
+		{Name: "url", IconUrl: "https://example.com/icon.svg"},
+		{Name: "none"},
+	}
+
+	stripInlineIcons(summaries)
+
+	expected := []*corev1.AvailablePackageSummary{
+		{Name: "inline"},
+		{Name: "url", IconUrl: "https://example.com/icon.svg"},
+		{Name: "none"},
+	}
+	if got, want := summaries, expected; !cmp.Equal(got, want, ignoreUnexportedOpts) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexportedOpts))
+	}
+}
+
 func TestGetAvailablePackageDetail(t *testing.T) {
+	iconNormalizer, err := icons.NewNormalizer(icons.Options{})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
 	testCases := []struct {
 		name              string
 		configuredPlugins []pkgPluginWithServer
+		iconNormalizer    *icons.Normalizer
 		errorCode         connect.Code
 		request           *corev1.GetAvailablePackageDetailRequest
 		expectedResponse  *corev1.GetAvailablePackageDetailResponse
@@ -330,6 +532,34 @@ func TestGetAvailablePackageDetail(t *testing.T) {
 				AvailablePackageDetail: plugin_test.MakeAvailablePackageDetail("pkg-1", mockedPackagingPlugin1.plugin),
 			},
 		},
+		{
+			name: "it should return the normalized icon of the package",
+			configuredPlugins: []pkgPluginWithServer{
+				mockedPackagingPlugin1,
+			},
+			iconNormalizer: iconNormalizer,
+			request: &corev1.GetAvailablePackageDetailRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context: &corev1.Context{
+						Cluster:   "",
+						Namespace: globalPackagingNamespace,
+					},
+					Identifier: "pkg-1",
+					Plugin:     mockedPackagingPlugin1.plugin,
+				},
+			},
+
+			expectedResponse: &corev1.GetAvailablePackageDetailResponse{
+				AvailablePackageDetail: func() *corev1.AvailablePackageDetail {
+					detail := plugin_test.MakeAvailablePackageDetail("pkg-1", mockedPackagingPlugin1.plugin)
+					detail.Icon = &corev1.PackageIcon{
+						Url:          plugin_test.DefaultIconURL,
+						ThumbnailUrl: plugin_test.DefaultIconURL,
+					}
+					return detail
+				}(),
+			},
+		},
 		{
 			name: "it should fail when calling the core GetAvailablePackageDetail operation when the package is not present in a plugin",
 			configuredPlugins: []pkgPluginWithServer{
@@ -357,6 +587,7 @@ func TestGetAvailablePackageDetail(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			server := &packagesServer{
 				pluginsWithServers: tc.configuredPlugins,
+				icons:              tc.iconNormalizer,
 			}
 			availablePackageDetail, err := server.GetAvailablePackageDetail(context.Background(), connect.NewRequest(tc.request))
 
@@ -421,6 +652,73 @@ func TestGetInstalledPackageSummaries(t *testing.T) {
 			},
 			statusCode: connect.CodeNotFound,
 		},
+		{
+			name: "it should aggregate the namespace failures reported by the plugins",
+			configuredPlugins: []pkgPluginWithServer{
+				makePartialResultsTestPackagingPlugin("mock1", "ns-1"),
+				makePartialResultsTestPackagingPlugin("mock2", "ns-2"),
+			},
+			request: &corev1.GetInstalledPackageSummariesRequest{
+				Context: &corev1.Context{
+					Cluster:   "",
+					Namespace: "",
+				},
+			},
+
+			expectedResponse: &corev1.GetInstalledPackageSummariesResponse{
+				InstalledPackageSummaries: []*corev1.InstalledPackageSummary{
+					plugin_test.MakeInstalledPackageSummary("pkg-1", mockedPackagingPlugin1.plugin),
+					plugin_test.MakeInstalledPackageSummary("pkg-1", mockedPackagingPlugin2.plugin),
+					plugin_test.MakeInstalledPackageSummary("pkg-2", mockedPackagingPlugin1.plugin),
+					plugin_test.MakeInstalledPackageSummary("pkg-2", mockedPackagingPlugin2.plugin),
+				},
+				PartialResults: &corev1.PartialResults{
+					NamespaceFailures: []*corev1.NamespaceFailure{
+						{
+							Context: &corev1.Context{Cluster: plugin_test.GlobalPackagingCluster, Namespace: "ns-1"},
+							Plugin:  mockedPackagingPlugin1.plugin,
+							Code:    connect.CodePermissionDenied.String(),
+							Reason:  "Forbidden",
+						},
+						{
+							Context: &corev1.Context{Cluster: plugin_test.GlobalPackagingCluster, Namespace: "ns-2"},
+							Plugin:  mockedPackagingPlugin2.plugin,
+							Code:    connect.CodePermissionDenied.String(),
+							Reason:  "Forbidden",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "it should report the plugins which are failing",
+			configuredPlugins: []pkgPluginWithServer{
+				mockedPackagingPlugin1,
+				makeOnlyStatusTestPackagingPlugin("unavailable-plugin", connect.CodeUnavailable),
+			},
+			request: &corev1.GetInstalledPackageSummariesRequest{
+				Context: &corev1.Context{
+					Cluster:   "",
+					Namespace: globalPackagingNamespace,
+				},
+			},
+
+			expectedResponse: &corev1.GetInstalledPackageSummariesResponse{
+				InstalledPackageSummaries: []*corev1.InstalledPackageSummary{
+					plugin_test.MakeInstalledPackageSummary("pkg-1", mockedPackagingPlugin1.plugin),
+					plugin_test.MakeInstalledPackageSummary("pkg-2", mockedPackagingPlugin1.plugin),
+				},
+				PartialResults: &corev1.PartialResults{
+					PluginFailures: []*corev1.PluginFailure{
+						{
+							Plugin: &plugins.Plugin{Name: "unavailable-plugin", Version: "v1alpha1"},
+							Code:   connect.CodeUnavailable.String(),
+							Reason: "unavailable: Non-OK response",
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -443,6 +741,213 @@ func TestGetInstalledPackageSummaries(t *testing.T) {
 	}
 }
 
+// multiClusterTestPackagingPluginServer is a test plugin returning installed
+// packages for the requested cluster, or an error for the failing clusters.
+type multiClusterTestPackagingPluginServer struct {
+	*plugin_test.TestPackagingPluginServer
+	installedPackages map[string][]string
+	failingClusters   map[string]connect.Code
+}
+
+func (s multiClusterTestPackagingPluginServer) GetInstalledPackageSummaries(ctx context.Context, request *connect.Request[corev1.GetInstalledPackageSummariesRequest]) (*connect.Response[corev1.GetInstalledPackageSummariesResponse], error) {
+	cluster := request.Msg.GetContext().GetCluster()
+	if code, ok := s.failingClusters[cluster]; ok {
+		return nil, connect.NewError(code, fmt.Errorf("Non-OK response"))
+	}
+	summaries := []*corev1.InstalledPackageSummary{}
+	for _, name := range s.installedPackages[cluster] {
+		summaries = append(summaries, makeClusterInstalledPackageSummary(name, cluster, s.Plugin))
+	}
+	return connect.NewResponse(&corev1.GetInstalledPackageSummariesResponse{
+		InstalledPackageSummaries: summaries,
+	}), nil
+}
+
+func makeClusterInstalledPackageSummary(name, cluster string, plugin *plugins.Plugin) *corev1.InstalledPackageSummary {
+	summary := plugin_test.MakeInstalledPackageSummary(name, plugin)
+	summary.InstalledPackageRef.Context.Cluster = cluster
+	return summary
+}
+
+func makeMultiClusterTestPackagingPlugin(pluginName string, installedPackages map[string][]string, failingClusters map[string]connect.Code) pkgPluginWithServer {
+	pluginDetails := &plugins.Plugin{Name: pluginName, Version: "v1alpha1"}
+	return pkgPluginWithServer{
+		plugin: pluginDetails,
+		server: multiClusterTestPackagingPluginServer{
+			TestPackagingPluginServer: &plugin_test.TestPackagingPluginServer{Plugin: pluginDetails},
+			installedPackages:         installedPackages,
+			failingClusters:           failingClusters,
+		},
+	}
+}
+
+func TestGetInstalledPackageSummariesAcrossClusters(t *testing.T) {
+	clusters := []string{"cluster-1", "cluster-2", "cluster-3"}
+	plugin1 := makeMultiClusterTestPackagingPlugin("mock1", map[string][]string{
+		"cluster-1": {"pkg-1", "pkg-3"},
+		"cluster-2": {"pkg-2"},
+		"cluster-3": {"pkg-4"},
+	}, map[string]connect.Code{"cluster-3": connect.CodeUnavailable})
+	plugin2 := makeMultiClusterTestPackagingPlugin("mock2", map[string][]string{
+		"cluster-1": {"pkg-2"},
+		"cluster-2": {"pkg-1"},
+		"cluster-3": {"pkg-5"},
+	}, map[string]connect.Code{"cluster-3": connect.CodeUnavailable})
+
+	testCases := []struct {
+		name             string
+		clusters         []string
+		request          *corev1.GetInstalledPackageSummariesRequest
+		statusCode       connect.Code
+		expectedResponse *corev1.GetInstalledPackageSummariesResponse
+	}{
+		{
+			name:     "it aggregates the installed packages of every cluster, reporting the failing clusters",
+			clusters: clusters,
+			request: &corev1.GetInstalledPackageSummariesRequest{
+				Context: &corev1.Context{Namespace: "default"},
+			},
+			expectedResponse: &corev1.GetInstalledPackageSummariesResponse{
+				InstalledPackageSummaries: []*corev1.InstalledPackageSummary{
+					makeClusterInstalledPackageSummary("pkg-1", "cluster-1", plugin1.plugin),
+					makeClusterInstalledPackageSummary("pkg-1", "cluster-2", plugin2.plugin),
+					makeClusterInstalledPackageSummary("pkg-2", "cluster-1", plugin2.plugin),
+					makeClusterInstalledPackageSummary("pkg-2", "cluster-2", plugin1.plugin),
+					makeClusterInstalledPackageSummary("pkg-3", "cluster-1", plugin1.plugin),
+				},
+				PartialResults: &corev1.PartialResults{
+					NamespaceFailures: []*corev1.NamespaceFailure{
+						{
+							Context: &corev1.Context{Cluster: "cluster-3", Namespace: "default"},
+							Plugin:  plugin1.plugin,
+							Code:    connect.CodeUnavailable.String(),
+							Reason:  "unavailable: Non-OK response",
+						},
+						{
+							Context: &corev1.Context{Cluster: "cluster-3", Namespace: "default"},
+							Plugin:  plugin2.plugin,
+							Code:    connect.CodeUnavailable.String(),
+							Reason:  "unavailable: Non-OK response",
+						},
+					},
+				},
+			},
+		},
+		{
+			name:     "it paginates across clusters",
+			clusters: clusters,
+			request: &corev1.GetInstalledPackageSummariesRequest{
+				Context:           &corev1.Context{Namespace: "default"},
+				PaginationOptions: &corev1.PaginationOptions{PageSize: 2},
+			},
+			expectedResponse: &corev1.GetInstalledPackageSummariesResponse{
+				InstalledPackageSummaries: []*corev1.InstalledPackageSummary{
+					makeClusterInstalledPackageSummary("pkg-1", "cluster-1", plugin1.plugin),
+					makeClusterInstalledPackageSummary("pkg-1", "cluster-2", plugin2.plugin),
+				},
+				NextPageToken: `{"cluster-1/mock1":1,"cluster-2/mock2":1,"cluster-3/mock1":-1,"cluster-3/mock2":-1}`,
+				PartialResults: &corev1.PartialResults{
+					NamespaceFailures: []*corev1.NamespaceFailure{
+						{
+							Context: &corev1.Context{Cluster: "cluster-3", Namespace: "default"},
+							Plugin:  plugin1.plugin,
+							Code:    connect.CodeUnavailable.String(),
+							Reason:  "unavailable: Non-OK response",
+						},
+						{
+							Context: &corev1.Context{Cluster: "cluster-3", Namespace: "default"},
+							Plugin:  plugin2.plugin,
+							Code:    connect.CodeUnavailable.String(),
+							Reason:  "unavailable: Non-OK response",
+						},
+					},
+				},
+			},
+		},
+		{
+			name:     "it only queries the requested cluster",
+			clusters: clusters,
+			request: &corev1.GetInstalledPackageSummariesRequest{
+				Context: &corev1.Context{Cluster: "cluster-2", Namespace: "default"},
+			},
+			expectedResponse: &corev1.GetInstalledPackageSummariesResponse{
+				InstalledPackageSummaries: []*corev1.InstalledPackageSummary{
+					makeClusterInstalledPackageSummary("pkg-1", "cluster-2", plugin2.plugin),
+					makeClusterInstalledPackageSummary("pkg-2", "cluster-2", plugin1.plugin),
+				},
+			},
+		},
+		{
+			name:     "it returns an error if every cluster fails",
+			clusters: []string{"cluster-3", "cluster-4"},
+			request: &corev1.GetInstalledPackageSummariesRequest{
+				Context: &corev1.Context{Namespace: "default"},
+			},
+			statusCode: connect.CodeUnavailable,
+		},
+	}
+
+	// Make every plugin fail for cluster-4 too.
+	plugin1.server.(multiClusterTestPackagingPluginServer).failingClusters["cluster-4"] = connect.CodeUnavailable
+	plugin2.server.(multiClusterTestPackagingPluginServer).failingClusters["cluster-4"] = connect.CodeUnavailable
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := &packagesServer{
+				pluginsWithServers: []pkgPluginWithServer{plugin1, plugin2},
+				clusters:           func() []string { return tc.clusters },
+			}
+			response, err := server.GetInstalledPackageSummaries(context.Background(), connect.NewRequest(tc.request))
+
+			if got, want := connect.CodeOf(err), tc.statusCode; err != nil && got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+			if tc.statusCode != 0 {
+				if err == nil {
+					t.Fatalf("got: nil, want: error with code %+v", tc.statusCode)
+				}
+				return
+			}
+
+			if got, want := response.Msg, tc.expectedResponse; !cmp.Equal(got, want, ignoreUnexportedOpts) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexportedOpts))
+			}
+		})
+	}
+}
+
+func TestGetInstalledPackageSummariesRegisteredCluster(t *testing.T) {
+	plugin := makeMultiClusterTestPackagingPlugin("mock1", map[string][]string{
+		"cluster-1": {"pkg-1"},
+		"cluster-2": {"pkg-2"},
+	}, nil)
+	clusters := []string{"cluster-1"}
+	server := &packagesServer{
+		pluginsWithServers: []pkgPluginWithServer{plugin},
+		clusters:           func() []string { return clusters },
+	}
+	request := &corev1.GetInstalledPackageSummariesRequest{
+		Context: &corev1.Context{Namespace: "default"},
+	}
+
+	// A cluster registered after the server is created is aggregated too.
+	clusters = append(clusters, "cluster-2")
+	response, err := server.GetInstalledPackageSummaries(context.Background(), connect.NewRequest(request))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	expectedResponse := &corev1.GetInstalledPackageSummariesResponse{
+		InstalledPackageSummaries: []*corev1.InstalledPackageSummary{
+			makeClusterInstalledPackageSummary("pkg-1", "cluster-1", plugin.plugin),
+			makeClusterInstalledPackageSummary("pkg-2", "cluster-2", plugin.plugin),
+		},
+	}
+	if got, want := response.Msg, expectedResponse; !cmp.Equal(got, want, ignoreUnexportedOpts) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexportedOpts))
+	}
+}
+
 func TestGetInstalledPackageDetail(t *testing.T) {
 	testCases := []struct {
 		name              string
@@ -514,34 +1019,119 @@ func TestGetInstalledPackageDetail(t *testing.T) {
 	}
 }
 
-func TestGetAvailablePackageVersions(t *testing.T) {
+func TestGetInstalledPackageOperationStatus(t *testing.T) {
 	testCases := []struct {
 		name              string
 		configuredPlugins []pkgPluginWithServer
 		statusCode        connect.Code
-		request           *corev1.GetAvailablePackageVersionsRequest
-		expectedResponse  *corev1.GetAvailablePackageVersionsResponse
+		request           *corev1.GetInstalledPackageOperationStatusRequest
+		expectedResponse  *corev1.GetInstalledPackageOperationStatusResponse
 	}{
 		{
-			name: "it should successfully call the core GetAvailablePackageVersions operation",
+			name: "it should successfully call the core GetInstalledPackageOperationStatus operation",
 			configuredPlugins: []pkgPluginWithServer{
 				mockedPackagingPlugin1,
 				mockedPackagingPlugin2,
 			},
-			request: &corev1.GetAvailablePackageVersionsRequest{
-				AvailablePackageRef: &corev1.AvailablePackageReference{
+			request: &corev1.GetInstalledPackageOperationStatusRequest{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
 					Context: &corev1.Context{
 						Cluster:   "",
 						Namespace: globalPackagingNamespace,
 					},
-					Identifier: "test",
+					Identifier: "pkg-1",
 					Plugin:     mockedPackagingPlugin1.plugin,
 				},
 			},
-
-			expectedResponse: &corev1.GetAvailablePackageVersionsResponse{
-				PackageAppVersions: []*corev1.PackageAppVersion{
-					plugin_test.MakePackageAppVersion(plugin_test.DefaultAppVersion, plugin_test.DefaultPkgUpdateVersion),
+			expectedResponse: &corev1.GetInstalledPackageOperationStatusResponse{
+				Status: &corev1.InstalledPackageStatus{
+					Ready:      true,
+					Reason:     corev1.InstalledPackageStatus_STATUS_REASON_INSTALLED,
+					UserReason: "ReconciliationSucceeded",
+				},
+				Phases: []*corev1.InstalledPackageOperationPhase{},
+			},
+		},
+		{
+			name: "it should fail when calling the core GetInstalledPackageOperationStatus operation when the package is not present in a plugin",
+			configuredPlugins: []pkgPluginWithServer{
+				mockedPackagingPlugin1,
+				mockedNotFoundPackagingPlugin,
+			},
+			request: &corev1.GetInstalledPackageOperationStatusRequest{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context: &corev1.Context{
+						Cluster:   "",
+						Namespace: globalPackagingNamespace,
+					},
+					Identifier: "pkg-1",
+					Plugin:     mockedNotFoundPackagingPlugin.plugin,
+				},
+			},
+			statusCode: connect.CodeNotFound,
+		},
+		{
+			name: "it should fail when the plugin is missing in the request",
+			configuredPlugins: []pkgPluginWithServer{
+				mockedPackagingPlugin1,
+			},
+			request: &corev1.GetInstalledPackageOperationStatusRequest{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Identifier: "pkg-1",
+				},
+			},
+			statusCode: connect.CodeInvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := &packagesServer{
+				pluginsWithServers: tc.configuredPlugins,
+			}
+			response, err := server.GetInstalledPackageOperationStatus(context.Background(), connect.NewRequest(tc.request))
+
+			if got, want := connect.CodeOf(err), tc.statusCode; err != nil && got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+
+			if tc.statusCode == 0 {
+				if got, want := response.Msg, tc.expectedResponse; !cmp.Equal(got, want, ignoreUnexportedOpts) {
+					t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexportedOpts))
+				}
+			}
+		})
+	}
+}
+
+func TestGetAvailablePackageVersions(t *testing.T) {
+	testCases := []struct {
+		name              string
+		configuredPlugins []pkgPluginWithServer
+		statusCode        connect.Code
+		request           *corev1.GetAvailablePackageVersionsRequest
+		expectedResponse  *corev1.GetAvailablePackageVersionsResponse
+	}{
+		{
+			name: "it should successfully call the core GetAvailablePackageVersions operation",
+			configuredPlugins: []pkgPluginWithServer{
+				mockedPackagingPlugin1,
+				mockedPackagingPlugin2,
+			},
+			request: &corev1.GetAvailablePackageVersionsRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context: &corev1.Context{
+						Cluster:   "",
+						Namespace: globalPackagingNamespace,
+					},
+					Identifier: "test",
+					Plugin:     mockedPackagingPlugin1.plugin,
+				},
+			},
+
+			expectedResponse: &corev1.GetAvailablePackageVersionsResponse{
+				PackageAppVersions: []*corev1.PackageAppVersion{
+					plugin_test.MakePackageAppVersion(plugin_test.DefaultAppVersion, plugin_test.DefaultPkgUpdateVersion),
 					plugin_test.MakePackageAppVersion(plugin_test.DefaultAppVersion, plugin_test.DefaultPkgVersion),
 				},
 			},
@@ -590,6 +1180,364 @@ func TestGetAvailablePackageVersions(t *testing.T) {
 	}
 }
 
+func TestGetAvailablePackageTextAsset(t *testing.T) {
+	testCases := []struct {
+		name              string
+		configuredPlugins []pkgPluginWithServer
+		statusCode        connect.Code
+		request           *corev1.GetAvailablePackageTextAssetRequest
+		expectedResponse  *corev1.GetAvailablePackageTextAssetResponse
+	}{
+		{
+			name: "it should successfully call the core GetAvailablePackageTextAsset operation",
+			configuredPlugins: []pkgPluginWithServer{
+				mockedPackagingPlugin1,
+				mockedPackagingPlugin2,
+			},
+			request: &corev1.GetAvailablePackageTextAssetRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context: &corev1.Context{
+						Cluster:   "",
+						Namespace: globalPackagingNamespace,
+					},
+					Identifier: "test",
+					Plugin:     mockedPackagingPlugin1.plugin,
+				},
+				Asset: corev1.TextAsset_TEXT_ASSET_README,
+				Limit: 4,
+			},
+
+			expectedResponse: &corev1.GetAvailablePackageTextAssetResponse{
+				Content:    plugin_test.DefaultReadme[:4],
+				NextOffset: 4,
+				TotalSize:  int64(len(plugin_test.DefaultReadme)),
+			},
+		},
+		{
+			name: "it should fail when calling the core GetAvailablePackageTextAsset operation when the package is not present in a plugin",
+			configuredPlugins: []pkgPluginWithServer{
+				mockedPackagingPlugin1,
+				mockedNotFoundPackagingPlugin,
+			},
+			request: &corev1.GetAvailablePackageTextAssetRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context: &corev1.Context{
+						Cluster:   "",
+						Namespace: globalPackagingNamespace,
+					},
+					Identifier: "test",
+					Plugin:     mockedNotFoundPackagingPlugin.plugin,
+				},
+				Asset: corev1.TextAsset_TEXT_ASSET_README,
+			},
+			statusCode: connect.CodeNotFound,
+		},
+		{
+			name: "it should fail when calling the core GetAvailablePackageTextAsset operation with an unspecified asset",
+			configuredPlugins: []pkgPluginWithServer{
+				mockedPackagingPlugin1,
+			},
+			request: &corev1.GetAvailablePackageTextAssetRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context: &corev1.Context{
+						Cluster:   "",
+						Namespace: globalPackagingNamespace,
+					},
+					Identifier: "test",
+					Plugin:     mockedPackagingPlugin1.plugin,
+				},
+			},
+			statusCode: connect.CodeInvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := &packagesServer{
+				pluginsWithServers: tc.configuredPlugins,
+			}
+			textAsset, err := server.GetAvailablePackageTextAsset(context.Background(), connect.NewRequest(tc.request))
+
+			if got, want := connect.CodeOf(err), tc.statusCode; err != nil && got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+
+			if tc.statusCode == 0 {
+				if got, want := textAsset.Msg, tc.expectedResponse; !cmp.Equal(got, want, ignoreUnexportedOpts) {
+					t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexportedOpts))
+				}
+			}
+		})
+	}
+}
+
+func TestGetAvailablePackageChangelog(t *testing.T) {
+	testCases := []struct {
+		name              string
+		configuredPlugins []pkgPluginWithServer
+		statusCode        connect.Code
+		request           *corev1.GetAvailablePackageChangelogRequest
+		expectedResponse  *corev1.GetAvailablePackageChangelogResponse
+	}{
+		{
+			name: "it should successfully call the core GetAvailablePackageChangelog operation",
+			configuredPlugins: []pkgPluginWithServer{
+				mockedPackagingPlugin1,
+				mockedPackagingPlugin2,
+			},
+			request: &corev1.GetAvailablePackageChangelogRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context: &corev1.Context{
+						Cluster:   "",
+						Namespace: globalPackagingNamespace,
+					},
+					Identifier: "test",
+					Plugin:     mockedPackagingPlugin1.plugin,
+				},
+				FromVersion: plugin_test.DefaultPkgVersion,
+			},
+
+			expectedResponse: &corev1.GetAvailablePackageChangelogResponse{
+				Changelogs: []*corev1.PackageVersionChangelog{
+					{
+						PkgVersion:   plugin_test.DefaultPkgUpdateVersion,
+						ReleaseNotes: plugin_test.DefaultReleaseNotes,
+					},
+				},
+			},
+		},
+		{
+			name: "it should fail when calling the core GetAvailablePackageChangelog operation when the package is not present in a plugin",
+			configuredPlugins: []pkgPluginWithServer{
+				mockedPackagingPlugin1,
+				mockedNotFoundPackagingPlugin,
+			},
+			request: &corev1.GetAvailablePackageChangelogRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context: &corev1.Context{
+						Cluster:   "",
+						Namespace: globalPackagingNamespace,
+					},
+					Identifier: "test",
+					Plugin:     mockedNotFoundPackagingPlugin.plugin,
+				},
+			},
+			statusCode: connect.CodeNotFound,
+		},
+		{
+			name: "it should fail when calling the core GetAvailablePackageChangelog operation without a plugin",
+			configuredPlugins: []pkgPluginWithServer{
+				mockedPackagingPlugin1,
+			},
+			request: &corev1.GetAvailablePackageChangelogRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context: &corev1.Context{
+						Cluster:   "",
+						Namespace: globalPackagingNamespace,
+					},
+					Identifier: "test",
+				},
+			},
+			statusCode: connect.CodeInvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := &packagesServer{
+				pluginsWithServers: tc.configuredPlugins,
+			}
+			changelog, err := server.GetAvailablePackageChangelog(context.Background(), connect.NewRequest(tc.request))
+
+			if got, want := connect.CodeOf(err), tc.statusCode; err != nil && got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+
+			if tc.statusCode == 0 {
+				if got, want := changelog.Msg, tc.expectedResponse; !cmp.Equal(got, want, ignoreUnexportedOpts) {
+					t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexportedOpts))
+				}
+			}
+		})
+	}
+}
+
+func TestGetAvailablePackageFilters(t *testing.T) {
+	pluginWithFilters := func(pluginName string, categories, providers []string) pkgPluginWithServer {
+		p := makeDefaultTestPackagingPlugin(pluginName)
+		server := p.server.(*plugin_test.TestPackagingPluginServer)
+		server.Categories = categories
+		server.Providers = providers
+		return p
+	}
+
+	testCases := []struct {
+		name              string
+		configuredPlugins []pkgPluginWithServer
+		statusCode        connect.Code
+		expectedResponse  *corev1.GetAvailablePackageFiltersResponse
+	}{
+		{
+			name: "it should merge the distinct filters of every plugin, sorted by name",
+			configuredPlugins: []pkgPluginWithServer{
+				pluginWithFilters("mock1", []string{"Networking", "Database"}, []string{"VMware"}),
+				pluginWithFilters("mock2", []string{"Database", "Analytics"}, []string{}),
+				pluginWithFilters("mock3", []string{}, []string{"Bitnami", "VMware"}),
+			},
+			expectedResponse: &corev1.GetAvailablePackageFiltersResponse{
+				Categories: []string{"Analytics", "Database", "Networking"},
+				Providers:  []string{"Bitnami", "VMware"},
+			},
+		},
+		{
+			name: "it should return empty filters when no plugin has any",
+			configuredPlugins: []pkgPluginWithServer{
+				pluginWithFilters("mock1", nil, nil),
+			},
+			expectedResponse: &corev1.GetAvailablePackageFiltersResponse{
+				Categories: []string{},
+				Providers:  []string{},
+			},
+		},
+		{
+			name: "it should fail if a plugin fails",
+			configuredPlugins: []pkgPluginWithServer{
+				mockedPackagingPlugin1,
+				makeOnlyStatusTestPackagingPlugin("bad-plugin", connect.CodePermissionDenied),
+			},
+			statusCode: connect.CodePermissionDenied,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := &packagesServer{
+				pluginsWithServers: tc.configuredPlugins,
+			}
+			response, err := server.GetAvailablePackageFilters(context.Background(), connect.NewRequest(&corev1.GetAvailablePackageFiltersRequest{
+				Context: &corev1.Context{Namespace: plugin_test.DefaultNamespace},
+			}))
+
+			if got, want := connect.CodeOf(err), tc.statusCode; err != nil && got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+			if tc.statusCode != 0 {
+				if err == nil {
+					t.Fatalf("got: nil, want: error with code %+v", tc.statusCode)
+				}
+				return
+			}
+
+			if got, want := response.Msg, tc.expectedResponse; !cmp.Equal(got, want, ignoreUnexportedOpts) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexportedOpts))
+			}
+		})
+	}
+}
+
+func TestCheckInstalledPackageNameAvailability(t *testing.T) {
+	testCases := []struct {
+		name              string
+		configuredPlugins []pkgPluginWithServer
+		statusCode        connect.Code
+		request           *corev1.CheckInstalledPackageNameAvailabilityRequest
+		expectedResponse  *corev1.CheckInstalledPackageNameAvailabilityResponse
+	}{
+		{
+			name: "it should report the name as available when no plugin uses it",
+			configuredPlugins: []pkgPluginWithServer{
+				mockedPackagingPlugin1,
+				mockedPackagingPlugin2,
+			},
+			request: &corev1.CheckInstalledPackageNameAvailabilityRequest{
+				TargetContext: &corev1.Context{Namespace: plugin_test.DefaultNamespace},
+				Name:          "pkg-3",
+			},
+			expectedResponse: &corev1.CheckInstalledPackageNameAvailabilityResponse{
+				Available: true,
+				Conflicts: []*corev1.InstalledPackageReference{},
+			},
+		},
+		{
+			name: "it should aggregate the conflicts across plugins",
+			configuredPlugins: []pkgPluginWithServer{
+				mockedPackagingPlugin1,
+				mockedPackagingPlugin2,
+			},
+			request: &corev1.CheckInstalledPackageNameAvailabilityRequest{
+				TargetContext: &corev1.Context{Namespace: plugin_test.DefaultNamespace},
+				Name:          "pkg-1",
+			},
+			expectedResponse: &corev1.CheckInstalledPackageNameAvailabilityResponse{
+				Available: false,
+				Conflicts: []*corev1.InstalledPackageReference{
+					plugin_test.MakeInstalledPackageSummary("pkg-1", mockedPackagingPlugin1.plugin).InstalledPackageRef,
+					plugin_test.MakeInstalledPackageSummary("pkg-1", mockedPackagingPlugin2.plugin).InstalledPackageRef,
+				},
+			},
+		},
+		{
+			name: "it should only report conflicts in the target namespace",
+			configuredPlugins: []pkgPluginWithServer{
+				mockedPackagingPlugin1,
+			},
+			request: &corev1.CheckInstalledPackageNameAvailabilityRequest{
+				TargetContext: &corev1.Context{Namespace: "other-namespace"},
+				Name:          "pkg-1",
+			},
+			expectedResponse: &corev1.CheckInstalledPackageNameAvailabilityResponse{
+				Available: true,
+				Conflicts: []*corev1.InstalledPackageReference{},
+			},
+		},
+		{
+			name: "it should fail if a plugin fails",
+			configuredPlugins: []pkgPluginWithServer{
+				mockedPackagingPlugin1,
+				makeOnlyStatusTestPackagingPlugin("bad-plugin", connect.CodePermissionDenied),
+			},
+			request: &corev1.CheckInstalledPackageNameAvailabilityRequest{
+				TargetContext: &corev1.Context{Namespace: plugin_test.DefaultNamespace},
+				Name:          "pkg-3",
+			},
+			statusCode: connect.CodePermissionDenied,
+		},
+		{
+			name: "it should fail without a name",
+			configuredPlugins: []pkgPluginWithServer{
+				mockedPackagingPlugin1,
+			},
+			request: &corev1.CheckInstalledPackageNameAvailabilityRequest{
+				TargetContext: &corev1.Context{Namespace: plugin_test.DefaultNamespace},
+			},
+			statusCode: connect.CodeInvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := &packagesServer{
+				pluginsWithServers: tc.configuredPlugins,
+			}
+			response, err := server.CheckInstalledPackageNameAvailability(context.Background(), connect.NewRequest(tc.request))
+
+			if got, want := connect.CodeOf(err), tc.statusCode; err != nil && got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+			if tc.statusCode != 0 {
+				if err == nil {
+					t.Fatalf("got: nil, want: error with code %+v", tc.statusCode)
+				}
+				return
+			}
+
+			if got, want := response.Msg, tc.expectedResponse; !cmp.Equal(got, want, ignoreUnexportedOpts) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexportedOpts))
+			}
+		})
+	}
+}
+
 func TestCreateInstalledPackage(t *testing.T) {
 
 	testCases := []struct {
@@ -723,6 +1671,22 @@ func TestUpdateInstalledPackage(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "returns invalid argument if values are provided while reusing them",
+			configuredPlugins: []*plugins.Plugin{
+				{Name: "plugin-1", Version: "v1alpha1"},
+			},
+			statusCode: connect.CodeInvalidArgument,
+			request: &corev1.UpdateInstalledPackageRequest{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context:    &corev1.Context{Cluster: "default", Namespace: "my-ns"},
+					Identifier: "installed-pkg-1",
+					Plugin:     &plugins.Plugin{Name: "plugin-1", Version: "v1alpha1"},
+				},
+				Values:      "foo: bar",
+				ReuseValues: true,
+			},
+		},
 		{
 			name:       "returns internal error if unable to find the plugin",
 			statusCode: connect.CodeInternal,
@@ -0,0 +1,104 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/bufbuild/connect-go"
+	packages "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/plugins/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/pkgutils"
+)
+
+// partialResultsCollector aggregates the namespace failures reported by
+// each plugin when listing across namespaces, along with the plugins which
+// could not be included at all.
+//
+// Plugins are queried in separate go-routines, possibly more than once per
+// request when paginating, so the collector is safe for concurrent use and
+// only keeps a single failure per plugin, cluster, namespace and code.
+type partialResultsCollector struct {
+	mu             sync.Mutex
+	seen           map[string]bool
+	failures       []*packages.NamespaceFailure
+	pluginFailures []*packages.PluginFailure
+}
+
+func newPartialResultsCollector() *partialResultsCollector {
+	return &partialResultsCollector{
+		seen: map[string]bool{},
+	}
+}
+
+// add records the namespace failures reported by the given plugin, setting
+// the plugin on those failures which do not include it.
+func (c *partialResultsCollector) add(plugin *v1alpha1.Plugin, partialResults *packages.PartialResults) {
+	if len(partialResults.GetNamespaceFailures()) == 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, failure := range partialResults.GetNamespaceFailures() {
+		if failure.Plugin == nil {
+			failure.Plugin = plugin
+		}
+		key := failure.GetPlugin().GetName() + "/" + failure.GetContext().GetCluster() + "/" + failure.GetContext().GetNamespace() + "/" + failure.GetCode()
+		if c.seen[key] {
+			continue
+		}
+		c.seen[key] = true
+		c.failures = append(c.failures, failure)
+	}
+}
+
+// addPluginFailure records that the given plugin could not be included in
+// the results because of the given error.
+func (c *partialResultsCollector) addPluginFailure(plugin *v1alpha1.Plugin, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key := plugin.GetName() + "/" + plugin.GetVersion()
+	if c.seen[key] {
+		return
+	}
+	c.seen[key] = true
+	c.pluginFailures = append(c.pluginFailures, &packages.PluginFailure{
+		Plugin: plugin,
+		Code:   connect.CodeOf(err).String(),
+		Reason: err.Error(),
+	})
+}
+
+// partialResults returns the aggregated partial results, ordered by context
+// and plugin regardless of the order in which plugins responded, or nil if no
+// plugin reported a namespace failure nor failed.
+func (c *partialResultsCollector) partialResults() *packages.PartialResults {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	failures := append([]*packages.NamespaceFailure{}, c.failures...)
+	sort.SliceStable(failures, func(i, j int) bool {
+		fi, fj := failures[i], failures[j]
+		if fi.GetContext().GetCluster() != fj.GetContext().GetCluster() {
+			return fi.GetContext().GetCluster() < fj.GetContext().GetCluster()
+		}
+		if fi.GetContext().GetNamespace() != fj.GetContext().GetNamespace() {
+			return fi.GetContext().GetNamespace() < fj.GetContext().GetNamespace()
+		}
+		return fi.GetPlugin().GetName() < fj.GetPlugin().GetName()
+	})
+	partialResults := pkgutils.NewPartialResults(failures)
+	if len(c.pluginFailures) == 0 {
+		return partialResults
+	}
+	pluginFailures := append([]*packages.PluginFailure{}, c.pluginFailures...)
+	sort.SliceStable(pluginFailures, func(i, j int) bool {
+		return pluginFailures[i].GetPlugin().GetName() < pluginFailures[j].GetPlugin().GetName()
+	})
+	if partialResults == nil {
+		partialResults = &packages.PartialResults{}
+	}
+	partialResults.PluginFailures = pluginFailures
+	return partialResults
+}
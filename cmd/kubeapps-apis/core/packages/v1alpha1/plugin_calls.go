@@ -0,0 +1,46 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bufbuild/connect-go"
+	pluginsv1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core/plugins/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/plugins/v1alpha1"
+)
+
+// aggregatedCalls bounds the calls made to each plugin when aggregating the
+// results of several plugins, so that a slow plugin cannot hang the whole
+// request, and records their outcome in the health of the plugins.
+type aggregatedCalls struct {
+	// timeout is the deadline of each call to a plugin, unbounded if 0.
+	timeout time.Duration
+	// health records the outcome of the calls, if not nil.
+	health *pluginsv1alpha1.PluginHealthTracker
+}
+
+// callAggregatedPlugin calls the RPC of a plugin as callPlugin does, within the
+// deadline of the aggregated calls. A plugin not responding in time results in
+// a DeadlineExceeded error, whatever the error returned by the plugin.
+func callAggregatedPlugin[Req, Res any](ctx context.Context, calls aggregatedCalls, plugin *v1alpha1.Plugin, method string, request *connect.Request[Req], rpc func(context.Context, *connect.Request[Req]) (*connect.Response[Res], error)) (*connect.Response[Res], error) {
+	pluginCtx := ctx
+	if calls.timeout > 0 {
+		var cancel context.CancelFunc
+		pluginCtx, cancel = context.WithTimeout(ctx, calls.timeout)
+		defer cancel()
+	}
+	response, err := callPlugin(pluginCtx, plugin, method, request, rpc)
+	if err != nil && ctx.Err() == nil && errors.Is(pluginCtx.Err(), context.DeadlineExceeded) {
+		err = connect.NewError(connect.CodeDeadlineExceeded, fmt.Errorf("The plugin %q did not respond within %s", plugin.GetName(), calls.timeout))
+	}
+	// The request being cancelled by the caller tells nothing about the plugin.
+	if ctx.Err() == nil {
+		calls.health.Record(plugin, err)
+	}
+	return response, err
+}
@@ -69,7 +69,7 @@ func (s repositoriesServer) AddPackageRepository(ctx context.Context, request *c
 	}
 
 	// Get the response from the requested plugin
-	response, err := pluginWithServer.server.AddPackageRepository(ctx, request)
+	response, err := callPlugin(ctx, pluginWithServer.plugin, "AddPackageRepository", request, pluginWithServer.server.AddPackageRepository)
 	if err != nil {
 		return nil, connect.NewError(connect.CodeOf(err), fmt.Errorf("Unable to add package repository %q using the plugin %q: %w", request.Msg.Name, request.Msg.Plugin.Name, err))
 	}
@@ -96,7 +96,7 @@ func (s repositoriesServer) GetPackageRepositoryDetail(ctx context.Context, requ
 	}
 
 	// Get the response from the requested plugin
-	response, err := pluginWithServer.server.GetPackageRepositoryDetail(ctx, request)
+	response, err := callPlugin(ctx, pluginWithServer.plugin, "GetPackageRepositoryDetail", request, pluginWithServer.server.GetPackageRepositoryDetail)
 	if err != nil {
 		return nil, connect.NewError(connect.CodeOf(err), fmt.Errorf("Unable to get the package repository detail for the repository %q using the plugin %q: %w", request.Msg.PackageRepoRef.Identifier, request.Msg.PackageRepoRef.Plugin.Name, err))
 	}
@@ -118,9 +118,10 @@ func (s repositoriesServer) GetPackageRepositorySummaries(ctx context.Context, r
 
 	// Aggregate the response for each plugin
 	summaries := []*packages.PackageRepositorySummary{}
+	partialResults := newPartialResultsCollector()
 	// TODO: We can do these in parallel in separate go routines.
 	for _, p := range s.pluginsWithServers {
-		response, err := p.server.GetPackageRepositorySummaries(ctx, request)
+		response, err := callPlugin(ctx, p.plugin, "GetPackageRepositorySummaries", request, p.server.GetPackageRepositorySummaries)
 		if err != nil {
 			return nil, connect.NewError(connect.CodeOf(err), fmt.Errorf("Invalid GetPackageRepositorySummaries response from the plugin %v: %w", p.plugin.Name, err))
 		}
@@ -138,6 +139,7 @@ func (s repositoriesServer) GetPackageRepositorySummaries(ctx context.Context, r
 			r.PackageRepoRef.Plugin = p.plugin
 		}
 		summaries = append(summaries, pluginSummaries...)
+		partialResults.add(p.plugin, response.Msg.GetPartialResults())
 	}
 
 	From(summaries).
@@ -149,6 +151,7 @@ func (s repositoriesServer) GetPackageRepositorySummaries(ctx context.Context, r
 	// Build the response
 	return connect.NewResponse(&packages.GetPackageRepositorySummariesResponse{
 		PackageRepositorySummaries: summaries,
+		PartialResults:             partialResults.partialResults(),
 	}), nil
 }
 
@@ -167,7 +170,7 @@ func (s repositoriesServer) UpdatePackageRepository(ctx context.Context, request
 	}
 
 	// Get the response from the requested plugin
-	response, err := pluginWithServer.server.UpdatePackageRepository(ctx, request)
+	response, err := callPlugin(ctx, pluginWithServer.plugin, "UpdatePackageRepository", request, pluginWithServer.server.UpdatePackageRepository)
 	if err != nil {
 		return nil, connect.NewError(connect.CodeOf(err), fmt.Errorf("Unable to update the package repository %q using the plugin %q: %w",
 			request.Msg.PackageRepoRef.Identifier, request.Msg.PackageRepoRef.Plugin.Name, err))
@@ -196,7 +199,7 @@ func (s repositoriesServer) DeletePackageRepository(ctx context.Context, request
 	}
 
 	// Get the response from the requested plugin
-	response, err := pluginWithServer.server.DeletePackageRepository(ctx, request)
+	response, err := callPlugin(ctx, pluginWithServer.plugin, "DeletePackageRepository", request, pluginWithServer.server.DeletePackageRepository)
 	if err != nil {
 		return nil, connect.NewError(connect.CodeOf(err), fmt.Errorf("Unable to delete the package repository %q using the plugin %q: %w",
 			request.Msg.PackageRepoRef.Identifier, request.Msg.PackageRepoRef.Plugin.Name, err))
@@ -215,7 +218,7 @@ func (s repositoriesServer) GetPackageRepositoryPermissions(ctx context.Context,
 		go func(repoPlugin repoPluginsWithServer) {
 			defer wg.Done()
 
-			response, err := repoPlugin.server.GetPackageRepositoryPermissions(ctx, request)
+			response, err := callPlugin(ctx, repoPlugin.plugin, "GetPackageRepositoryPermissions", request, repoPlugin.server.GetPackageRepositoryPermissions)
 			if err != nil {
 				log.Errorf("+core error finding repository permissions in plugin %s: [%v]", repoPlugin.plugin.Name, err)
 				return
@@ -241,6 +244,56 @@ func (s repositoriesServer) GetPackageRepositoryPermissions(ctx context.Context,
 	}), nil
 }
 
+// TestPackageRepositoryConnection checks that a package repository can be
+// reached with the given details using the requested plugin, without adding it.
+func (s repositoriesServer) TestPackageRepositoryConnection(ctx context.Context, request *connect.Request[packages.TestPackageRepositoryConnectionRequest]) (*connect.Response[packages.TestPackageRepositoryConnectionResponse], error) {
+	repo := request.Msg.GetPackageRepository()
+	log.InfoS("+core TestPackageRepositoryConnection", "cluster", repo.GetContext().GetCluster(), "namespace", repo.GetContext().GetNamespace(), "url", repo.GetUrl())
+
+	if repo.GetPlugin() == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Unable to retrieve the plugin (missing PackageRepository.Plugin)"))
+	}
+
+	// Retrieve the plugin with server matching the requested plugin name
+	pluginWithServer := s.getPluginWithServer(repo.Plugin)
+	if pluginWithServer == nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to get the plugin %v", repo.Plugin))
+	}
+
+	// Get the response from the requested plugin
+	response, err := callPlugin(ctx, pluginWithServer.plugin, "TestPackageRepositoryConnection", request, pluginWithServer.server.TestPackageRepositoryConnection)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeOf(err), fmt.Errorf("Unable to connect to the package repository %q using the plugin %q: %w", repo.GetUrl(), repo.Plugin.Name, err))
+	}
+
+	return response, nil
+}
+
+// ListPackageRepositoryPackages returns the available packages contributed by
+// a single package repository, using the plugin of the repository.
+func (s repositoriesServer) ListPackageRepositoryPackages(ctx context.Context, request *connect.Request[packages.ListPackageRepositoryPackagesRequest]) (*connect.Response[packages.ListPackageRepositoryPackagesResponse], error) {
+	repoRef := request.Msg.GetPackageRepoRef()
+	log.InfoS("+core ListPackageRepositoryPackages", "identifier", repoRef.GetIdentifier(), "cluster", repoRef.GetContext().GetCluster(), "namespace", repoRef.GetContext().GetNamespace())
+
+	if repoRef.GetPlugin() == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Unable to retrieve the plugin (missing PackageRepoRef.Plugin)"))
+	}
+
+	// Retrieve the plugin with server matching the requested plugin name
+	pluginWithServer := s.getPluginWithServer(repoRef.Plugin)
+	if pluginWithServer == nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to get the plugin %v", repoRef.Plugin))
+	}
+
+	// Get the response from the requested plugin
+	response, err := callPlugin(ctx, pluginWithServer.plugin, "ListPackageRepositoryPackages", request, pluginWithServer.server.ListPackageRepositoryPackages)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeOf(err), fmt.Errorf("Unable to list the packages of the repository %q using the plugin %q: %w", repoRef.Identifier, repoRef.Plugin.Name, err))
+	}
+
+	return response, nil
+}
+
 // getPluginWithServer returns the *pkgPluginsWithServer from a given packagesServer
 // matching the plugin name
 func (s repositoriesServer) getPluginWithServer(plugin *v1alpha1.Plugin) *repoPluginsWithServer {
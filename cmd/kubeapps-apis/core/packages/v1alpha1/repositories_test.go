@@ -13,6 +13,7 @@ import (
 	corev1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
 	plugins "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/plugins/v1alpha1"
 	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugin_test"
+	"google.golang.org/protobuf/proto"
 )
 
 var mockedRepoPlugin1 = makeDefaultTestRepositoriesPlugin("mock1")
@@ -29,9 +30,16 @@ var ignoreUnexportedRepoOpts = cmpopts.IgnoreUnexported(
 	corev1.PackageRepositoryStatus{},
 	corev1.GetPackageRepositorySummariesResponse{},
 	corev1.PackageRepositorySummary{},
+	corev1.PartialResults{},
+	corev1.NamespaceFailure{},
 	corev1.UpdatePackageRepositoryResponse{},
 	corev1.GetPackageRepositoryPermissionsResponse{},
 	corev1.PackageRepositoriesPermissions{},
+	corev1.TestPackageRepositoryConnectionResponse{},
+	corev1.ListPackageRepositoryPackagesResponse{},
+	corev1.AvailablePackageSummary{},
+	corev1.AvailablePackageReference{},
+	corev1.Context{},
 )
 
 func makeDefaultTestRepositoriesPlugin(pluginName string) repoPluginsWithServer {
@@ -52,6 +60,22 @@ func makeDefaultTestRepositoriesPlugin(pluginName string) repoPluginsWithServer
 	}
 }
 
+// makePartialResultsTestRepositoriesPlugin returns a default test plugin
+// which reports that it could not list the given namespace.
+func makePartialResultsTestRepositoriesPlugin(pluginName, namespace string) repoPluginsWithServer {
+	pluginWithServer := makeDefaultTestRepositoriesPlugin(pluginName)
+	pluginWithServer.server.(*plugin_test.TestRepositoriesPluginServer).PartialResults = &corev1.PartialResults{
+		NamespaceFailures: []*corev1.NamespaceFailure{
+			{
+				Context: &corev1.Context{Cluster: plugin_test.GlobalPackagingCluster, Namespace: namespace},
+				Code:    connect.CodePermissionDenied.String(),
+				Reason:  "Forbidden",
+			},
+		},
+	}
+	return pluginWithServer
+}
+
 func makeOnlyStatusTestRepositoriesPlugin(pluginName string, errorCode connect.Code) repoPluginsWithServer {
 	pluginDetails := &plugins.Plugin{Name: pluginName, Version: "v1alpha1"}
 	repositoriesPluginServer := &plugin_test.TestRepositoriesPluginServer{Plugin: pluginDetails}
@@ -151,6 +175,190 @@ func TestAddPackageRepository(t *testing.T) {
 	}
 }
 
+func TestTestPackageRepositoryConnection(t *testing.T) {
+	testCases := []struct {
+		name              string
+		configuredPlugins []repoPluginsWithServer
+		errorCode         connect.Code
+		request           *corev1.TestPackageRepositoryConnectionRequest
+		expectedResponse  *corev1.TestPackageRepositoryConnectionResponse
+	}{
+		{
+			name: "tests the connection using the correct plugin",
+			configuredPlugins: []repoPluginsWithServer{
+				mockedRepoPlugin1,
+				mockedRepoPlugin2,
+			},
+			request: &corev1.TestPackageRepositoryConnectionRequest{
+				PackageRepository: &corev1.AddPackageRepositoryRequest{
+					Plugin: mockedRepoPlugin2.plugin,
+					Context: &corev1.Context{
+						Cluster:   "default",
+						Namespace: "my-ns",
+					},
+					Name: "repo-1",
+					Url:  "https://example.com/charts",
+				},
+			},
+			expectedResponse: &corev1.TestPackageRepositoryConnectionResponse{
+				Message: "Connected to https://example.com/charts using the plugin mock2",
+			},
+		},
+		{
+			name:      "returns invalid argument if plugin not specified in request",
+			errorCode: connect.CodeInvalidArgument,
+			request: &corev1.TestPackageRepositoryConnectionRequest{
+				PackageRepository: &corev1.AddPackageRepositoryRequest{
+					Name: "repo-1",
+					Url:  "https://example.com/charts",
+				},
+			},
+		},
+		{
+			name:      "returns internal error if unable to find the plugin",
+			errorCode: connect.CodeInternal,
+			request: &corev1.TestPackageRepositoryConnectionRequest{
+				PackageRepository: &corev1.AddPackageRepositoryRequest{
+					Plugin: &plugins.Plugin{Name: "plugin-1", Version: "v1alpha1"},
+					Name:   "repo-1",
+					Url:    "https://example.com/charts",
+				},
+			},
+		},
+		{
+			name: "returns the error code from the plugin",
+			configuredPlugins: []repoPluginsWithServer{
+				mockedRepoPlugin1,
+				makeOnlyStatusTestRepositoriesPlugin("bad-plugin", connect.CodeUnauthenticated),
+			},
+			errorCode: connect.CodeUnauthenticated,
+			request: &corev1.TestPackageRepositoryConnectionRequest{
+				PackageRepository: &corev1.AddPackageRepositoryRequest{
+					Plugin: &plugins.Plugin{Name: "bad-plugin", Version: "v1alpha1"},
+					Name:   "repo-1",
+					Url:    "https://example.com/charts",
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := &repositoriesServer{
+				pluginsWithServers: tc.configuredPlugins,
+			}
+
+			response, err := server.TestPackageRepositoryConnection(context.Background(), connect.NewRequest(tc.request))
+
+			if got, want := connect.CodeOf(err), tc.errorCode; err != nil && got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+
+			if tc.errorCode == 0 {
+				if got, want := response.Msg, tc.expectedResponse; !cmp.Equal(got, want, ignoreUnexportedRepoOpts) {
+					t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexportedRepoOpts))
+				}
+			}
+		})
+	}
+}
+
+func TestListPackageRepositoryPackages(t *testing.T) {
+	repoRef := &corev1.PackageRepositoryReference{
+		Context: &corev1.Context{
+			Cluster:   "default",
+			Namespace: "my-ns",
+		},
+		Identifier: "repo-1",
+		Plugin:     mockedRepoPlugin2.plugin,
+	}
+
+	testCases := []struct {
+		name              string
+		configuredPlugins []repoPluginsWithServer
+		errorCode         connect.Code
+		request           *corev1.ListPackageRepositoryPackagesRequest
+		expectedResponse  *corev1.ListPackageRepositoryPackagesResponse
+	}{
+		{
+			name: "lists the packages using the plugin of the repository",
+			configuredPlugins: []repoPluginsWithServer{
+				mockedRepoPlugin1,
+				mockedRepoPlugin2,
+			},
+			request: &corev1.ListPackageRepositoryPackagesRequest{
+				PackageRepoRef: repoRef,
+			},
+			expectedResponse: &corev1.ListPackageRepositoryPackagesResponse{
+				AvailablePackageSummaries: []*corev1.AvailablePackageSummary{
+					{
+						AvailablePackageRef: &corev1.AvailablePackageReference{
+							Context:    repoRef.Context,
+							Identifier: "repo-1/pkg-1",
+							Plugin:     mockedRepoPlugin2.plugin,
+						},
+						Name: "pkg-1",
+					},
+				},
+				TotalCount: proto.Int32(1),
+			},
+		},
+		{
+			name:      "returns invalid argument if plugin not specified in request",
+			errorCode: connect.CodeInvalidArgument,
+			request: &corev1.ListPackageRepositoryPackagesRequest{
+				PackageRepoRef: &corev1.PackageRepositoryReference{
+					Identifier: "repo-1",
+				},
+			},
+		},
+		{
+			name:      "returns internal error if unable to find the plugin",
+			errorCode: connect.CodeInternal,
+			request: &corev1.ListPackageRepositoryPackagesRequest{
+				PackageRepoRef: &corev1.PackageRepositoryReference{
+					Identifier: "repo-1",
+					Plugin:     &plugins.Plugin{Name: "plugin-1", Version: "v1alpha1"},
+				},
+			},
+		},
+		{
+			name: "returns the error code from the plugin",
+			configuredPlugins: []repoPluginsWithServer{
+				mockedRepoPlugin1,
+				makeOnlyStatusTestRepositoriesPlugin("bad-plugin", connect.CodeNotFound),
+			},
+			errorCode: connect.CodeNotFound,
+			request: &corev1.ListPackageRepositoryPackagesRequest{
+				PackageRepoRef: &corev1.PackageRepositoryReference{
+					Identifier: "repo-1",
+					Plugin:     &plugins.Plugin{Name: "bad-plugin", Version: "v1alpha1"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := &repositoriesServer{
+				pluginsWithServers: tc.configuredPlugins,
+			}
+
+			response, err := server.ListPackageRepositoryPackages(context.Background(), connect.NewRequest(tc.request))
+
+			if got, want := connect.CodeOf(err), tc.errorCode; err != nil && got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+
+			if tc.errorCode == 0 {
+				if got, want := response.Msg, tc.expectedResponse; !cmp.Equal(got, want, ignoreUnexportedRepoOpts) {
+					t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexportedRepoOpts))
+				}
+			}
+		})
+	}
+}
+
 func TestGetPackageRepositoryDetail(t *testing.T) {
 	testCases := []struct {
 		name              string
@@ -263,6 +471,42 @@ func TestGetPackageRepositorySummaries(t *testing.T) {
 			},
 			errorCode: connect.CodeNotFound,
 		},
+		{
+			name: "it should aggregate the namespace failures reported by the plugins",
+			configuredPlugins: []repoPluginsWithServer{
+				makePartialResultsTestRepositoriesPlugin("mock2", "ns-1"),
+				makePartialResultsTestRepositoriesPlugin("mock1", "ns-1"),
+			},
+			request: &corev1.GetPackageRepositorySummariesRequest{
+				Context: &corev1.Context{
+					Cluster: plugin_test.GlobalPackagingCluster,
+				},
+			},
+			expectedResponse: &corev1.GetPackageRepositorySummariesResponse{
+				PackageRepositorySummaries: []*corev1.PackageRepositorySummary{
+					plugin_test.MakePackageRepositorySummary("repo-1", mockedPackagingPlugin1.plugin),
+					plugin_test.MakePackageRepositorySummary("repo-1", mockedPackagingPlugin2.plugin),
+					plugin_test.MakePackageRepositorySummary("repo-2", mockedPackagingPlugin1.plugin),
+					plugin_test.MakePackageRepositorySummary("repo-2", mockedPackagingPlugin2.plugin),
+				},
+				PartialResults: &corev1.PartialResults{
+					NamespaceFailures: []*corev1.NamespaceFailure{
+						{
+							Context: &corev1.Context{Cluster: plugin_test.GlobalPackagingCluster, Namespace: "ns-1"},
+							Plugin:  mockedPackagingPlugin1.plugin,
+							Code:    connect.CodePermissionDenied.String(),
+							Reason:  "Forbidden",
+						},
+						{
+							Context: &corev1.Context{Cluster: plugin_test.GlobalPackagingCluster, Namespace: "ns-1"},
+							Plugin:  mockedPackagingPlugin2.plugin,
+							Code:    connect.CodePermissionDenied.String(),
+							Reason:  "Forbidden",
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -0,0 +1,78 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package pins
+
+import (
+	"context"
+
+	"github.com/bufbuild/connect-go"
+	packages "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1"
+	packagesv1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	helm "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/plugins/helm/packages/v1alpha1"
+)
+
+// Interceptor returns an interceptor checking the requests creating, updating
+// or rolling back the installed packages against the package pins, whether
+// they are sent to the core packages services or directly to the services of
+// the plugins, which are created with the same interceptors.
+func (c *Checker) Interceptor() connect.Interceptor {
+	return connect.UnaryInterceptorFunc(func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			if err := c.CheckRequest(ctx, req.Any()); err != nil {
+				return nil, err
+			}
+			return next(ctx, req)
+		}
+	})
+}
+
+// CheckRequest checks the request if it creates, updates or rolls back an
+// installed package, allowing the other requests. It is called by the core
+// services sending such requests to the packages servers directly, which are
+// not intercepted. A nil checker allows everything.
+func (c *Checker) CheckRequest(ctx context.Context, message any) error {
+	switch m := message.(type) {
+	case *packages.CreateInstalledPackageRequest:
+		return c.CheckCreate(ctx, Target{
+			Plugin:    m.GetAvailablePackageRef().GetPlugin().GetName(),
+			Cluster:   m.GetTargetContext().GetCluster(),
+			Namespace: m.GetTargetContext().GetNamespace(),
+			Name:      m.GetName(),
+			Version:   m.GetPkgVersionReference().GetVersion(),
+		})
+	case *packagesv1alpha1.CreateInstalledPackageRequest:
+		return c.CheckCreate(ctx, Target{
+			Plugin:    m.GetAvailablePackageRef().GetPlugin().GetName(),
+			Cluster:   m.GetTargetContext().GetCluster(),
+			Namespace: m.GetTargetContext().GetNamespace(),
+			Name:      m.GetName(),
+			Version:   m.GetPkgVersionReference().GetVersion(),
+		})
+	case *packages.UpdateInstalledPackageRequest:
+		return c.CheckUpdate(ctx, Target{
+			Plugin:    m.GetInstalledPackageRef().GetPlugin().GetName(),
+			Cluster:   m.GetInstalledPackageRef().GetContext().GetCluster(),
+			Namespace: m.GetInstalledPackageRef().GetContext().GetNamespace(),
+			Name:      m.GetInstalledPackageRef().GetIdentifier(),
+			Version:   m.GetPkgVersionReference().GetVersion(),
+		})
+	case *packagesv1alpha1.UpdateInstalledPackageRequest:
+		return c.CheckUpdate(ctx, Target{
+			Plugin:    m.GetInstalledPackageRef().GetPlugin().GetName(),
+			Cluster:   m.GetInstalledPackageRef().GetContext().GetCluster(),
+			Namespace: m.GetInstalledPackageRef().GetContext().GetNamespace(),
+			Name:      m.GetInstalledPackageRef().GetIdentifier(),
+			Version:   m.GetPkgVersionReference().GetVersion(),
+		})
+	case *helm.RollbackInstalledPackageRequest:
+		return c.CheckRollback(ctx, Target{
+			Plugin:    m.GetInstalledPackageRef().GetPlugin().GetName(),
+			Cluster:   m.GetInstalledPackageRef().GetContext().GetCluster(),
+			Namespace: m.GetInstalledPackageRef().GetContext().GetNamespace(),
+			Name:      m.GetInstalledPackageRef().GetIdentifier(),
+			Revision:  m.GetReleaseRevision(),
+		})
+	}
+	return nil
+}
@@ -0,0 +1,239 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package pins enforces the package pins set by the Kubeapps administrators,
+// which block the updates of specific installed packages or restrict them to
+// an approved version range, whatever the plugin managing them. The requests
+// sent to the core services or the plugins are checked by the interceptor,
+// while the core services creating or updating installed packages on behalf
+// of a request, such as the bulk updates, the clones and the install presets,
+// check them themselves.
+//
+// The pins are stored by the administrators as ConfigMaps labelled with
+// "kubeapps.dev/package-pin" in the namespace where Kubeapps is installed,
+// and read with the kubeapps-apis service account.
+package pins
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/bufbuild/connect-go"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	log "k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// PackagePinLabel is the label identifying the ConfigMaps, in the
+	// Kubeapps namespace, which store a package pin.
+	PackagePinLabel = "kubeapps.dev/package-pin"
+
+	// PackagePinDataKey is the ConfigMap key holding the package pin,
+	// serialized as YAML or JSON.
+	PackagePinDataKey = "pin"
+)
+
+// Pin selects the installed packages whose updates are restricted.
+type Pin struct {
+	// Cluster, Namespace and Name are patterns, as matched by path.Match,
+	// of the context and name of the pinned installed packages. An empty
+	// cluster or namespace matches any of them.
+	Cluster   string `json:"cluster,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	// Plugin restricts the pin to the installed packages of the plugin, such
+	// as "helm.packages", if set.
+	Plugin string `json:"plugin,omitempty"`
+	// VersionConstraint is the semver constraint that the versions of the
+	// pinned installed packages must satisfy, when they are created or
+	// updated. Their updates are blocked altogether if empty, their creation
+	// being then allowed. An exact version must be requested for them, as the
+	// version a range expression resolves to is only known to the plugins.
+	VersionConstraint string `json:"versionConstraint,omitempty"`
+	// Reason is reported to the users whose requests are rejected.
+	Reason string `json:"reason,omitempty"`
+
+	constraint *semver.Constraints
+}
+
+// Target is an installed package being created or updated.
+type Target struct {
+	Plugin    string
+	Cluster   string
+	Namespace string
+	Name      string
+	// Version is the requested version, if any.
+	Version string
+	// Revision is the revision rolled back to, if any, whose version is
+	// not known.
+	Revision int32
+}
+
+// Checker checks the requests creating or updating the installed packages
+// against the package pins.
+type Checker struct {
+	clientSet kubernetes.Interface
+	namespace string
+}
+
+// NewChecker returns a checker of the package pins stored in the namespace,
+// read with the given client.
+func NewChecker(clientSet kubernetes.Interface, namespace string) (*Checker, error) {
+	// The pins of every namespace would be read otherwise.
+	if namespace == "" {
+		return nil, fmt.Errorf("a namespace is required to read the package pins")
+	}
+	return &Checker{
+		clientSet: clientSet,
+		namespace: namespace,
+	}, nil
+}
+
+// CheckCreate returns a FailedPrecondition error referencing the violated
+// pin if the installed package cannot be created with the requested version.
+// A nil checker allows everything.
+func (c *Checker) CheckCreate(ctx context.Context, target Target) error {
+	return c.check(ctx, target, operationCreate)
+}
+
+// CheckUpdate returns a FailedPrecondition error referencing the violated
+// pin if the installed package cannot be updated to the requested version.
+// A nil checker allows everything.
+func (c *Checker) CheckUpdate(ctx context.Context, target Target) error {
+	return c.check(ctx, target, operationUpdate)
+}
+
+// CheckRollback returns a FailedPrecondition error referencing the violated
+// pin if the installed package cannot be rolled back to the revision.
+// A nil checker allows everything.
+func (c *Checker) CheckRollback(ctx context.Context, target Target) error {
+	return c.check(ctx, target, operationRollback)
+}
+
+// operation is the change of an installed package being checked, as
+// reported to the users.
+type operation string
+
+const (
+	operationCreate   operation = "created"
+	operationUpdate   operation = "updated"
+	operationRollback operation = "rolled back"
+)
+
+func (c *Checker) check(ctx context.Context, target Target, op operation) error {
+	if c == nil {
+		return nil
+	}
+	cms, err := c.clientSet.CoreV1().ConfigMaps(c.namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: PackagePinLabel,
+	})
+	if err != nil {
+		// The pins are enforced by the administrators, so the requests are
+		// not allowed while they cannot be read.
+		return connect.NewError(connect.CodeUnavailable, fmt.Errorf("Unable to read the package pins: %w", err))
+	}
+	// The pins are checked in a stable order, so that the same pin is
+	// reported when several are violated.
+	sort.Slice(cms.Items, func(i, j int) bool {
+		return cms.Items[i].Name < cms.Items[j].Name
+	})
+
+	for i := range cms.Items {
+		cm := &cms.Items[i]
+		pin, err := pinFromConfigMap(cm)
+		if err != nil {
+			// A single invalid pin should not block every request.
+			log.Errorf("Ignoring the package pin %q: %v", cm.Name, err)
+			continue
+		}
+		if !pin.matches(target) {
+			continue
+		}
+		if reason := pin.violation(target, op); reason != "" {
+			message := fmt.Sprintf("The installed package %q cannot be %s: %s, as pinned by the policy %q", target.Name, op, reason, c.namespace+"/"+cm.Name)
+			if pin.Reason != "" {
+				message += ": " + pin.Reason
+			}
+			return connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("%s", message))
+		}
+	}
+	return nil
+}
+
+// matches returns whether the pin selects the installed package.
+func (p *Pin) matches(target Target) bool {
+	if p.Plugin != "" && p.Plugin != target.Plugin {
+		return false
+	}
+	return matchPattern(p.Cluster, target.Cluster) &&
+		matchPattern(p.Namespace, target.Namespace) &&
+		matchPattern(p.Name, target.Name)
+}
+
+// violation returns why the operation on the target violates the pin, or an
+// empty string if it is allowed.
+func (p *Pin) violation(target Target, op operation) string {
+	if p.constraint == nil {
+		if op == operationCreate {
+			return ""
+		}
+		return "its updates are blocked"
+	}
+	if target.Revision != 0 {
+		return fmt.Sprintf("the version of the revision %d cannot be checked against %q", target.Revision, p.VersionConstraint)
+	}
+	version := target.Version
+	if version == "" {
+		return fmt.Sprintf("a version matching %q must be requested", p.VersionConstraint)
+	}
+	v, err := semver.NewVersion(version)
+	if err != nil {
+		if _, rangeErr := semver.NewConstraint(version); rangeErr == nil {
+			return fmt.Sprintf("the version range %q cannot be checked against %q, an exact version must be requested", version, p.VersionConstraint)
+		}
+	}
+	if err != nil || !p.constraint.Check(v) {
+		return fmt.Sprintf("the version %q does not match %q", version, p.VersionConstraint)
+	}
+	return ""
+}
+
+// matchPattern returns whether the value matches the pattern, an empty
+// pattern matching any value.
+func matchPattern(pattern, value string) bool {
+	if pattern == "" {
+		return true
+	}
+	matched, err := path.Match(pattern, value)
+	return err == nil && matched
+}
+
+// pinFromConfigMap returns the package pin stored in the ConfigMap.
+func pinFromConfigMap(cm *corev1.ConfigMap) (*Pin, error) {
+	pin := &Pin{}
+	if err := yaml.UnmarshalStrict([]byte(cm.Data[PackagePinDataKey]), pin); err != nil {
+		return nil, fmt.Errorf("unable to parse the %q key: %w", PackagePinDataKey, err)
+	}
+	if pin.Name == "" {
+		return nil, fmt.Errorf("the pin must select the installed packages by name")
+	}
+	for _, pattern := range []string{pin.Cluster, pin.Namespace, pin.Name} {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+	}
+	if pin.VersionConstraint != "" {
+		constraint, err := semver.NewConstraint(pin.VersionConstraint)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version constraint %q: %w", pin.VersionConstraint, err)
+		}
+		pin.constraint = constraint
+	}
+	return pin, nil
+}
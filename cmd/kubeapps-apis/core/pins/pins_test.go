@@ -0,0 +1,321 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package pins
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bufbuild/connect-go"
+	packages "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1"
+	packagesv1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	plugins "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/plugins/v1alpha1"
+	helm "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/plugins/helm/packages/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func pinConfigMap(name, pin string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "kubeapps",
+			Labels:    map[string]string{PackagePinLabel: ""},
+		},
+		Data: map[string]string{PackagePinDataKey: pin},
+	}
+}
+
+func newTestChecker(t *testing.T, clientSet *fake.Clientset) *Checker {
+	checker, err := NewChecker(clientSet, "kubeapps")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	return checker
+}
+
+func TestCheckUpdate(t *testing.T) {
+	clientSet := fake.NewSimpleClientset(
+		pinConfigMap("prod-databases", `
+cluster: default
+namespace: prod-*
+name: "*-db"
+versionConstraint: ~12.1
+reason: The databases are upgraded by the DBA team.
+`),
+		pinConfigMap("frozen-ingress", `{"name": "ingress", "plugin": "helm.packages"}`),
+		pinConfigMap("invalid", `name: "[", versionConstraint: foo`),
+		// ConfigMaps without the label are not pins.
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "kubeapps"},
+			Data:       map[string]string{PackagePinDataKey: `name: "*"`},
+		},
+	)
+	checker := newTestChecker(t, clientSet)
+
+	testCases := []struct {
+		name          string
+		target        Target
+		expectedError string
+	}{
+		{
+			name:   "it allows a version matching the constraint",
+			target: Target{Plugin: "helm.packages", Cluster: "default", Namespace: "prod-eu", Name: "orders-db", Version: "12.1.9"},
+		},
+		{
+			name:          "it rejects a version not matching the constraint",
+			target:        Target{Plugin: "helm.packages", Cluster: "default", Namespace: "prod-eu", Name: "orders-db", Version: "13.0.0"},
+			expectedError: `The installed package "orders-db" cannot be updated: the version "13.0.0" does not match "~12.1", as pinned by the policy "kubeapps/prod-databases": The databases are upgraded by the DBA team.`,
+		},
+		{
+			name:          "it rejects a version range if constrained",
+			target:        Target{Plugin: "helm.packages", Cluster: "default", Namespace: "prod-eu", Name: "orders-db", Version: "~12.1.3"},
+			expectedError: `The installed package "orders-db" cannot be updated: the version range "~12.1.3" cannot be checked against "~12.1", an exact version must be requested, as pinned by the policy "kubeapps/prod-databases": The databases are upgraded by the DBA team.`,
+		},
+		{
+			name:          "it rejects an update without a version if constrained",
+			target:        Target{Plugin: "helm.packages", Cluster: "default", Namespace: "prod-eu", Name: "orders-db"},
+			expectedError: `The installed package "orders-db" cannot be updated: a version matching "~12.1" must be requested, as pinned by the policy "kubeapps/prod-databases": The databases are upgraded by the DBA team.`,
+		},
+		{
+			name:   "it allows the installed packages of other namespaces",
+			target: Target{Plugin: "helm.packages", Cluster: "default", Namespace: "staging", Name: "orders-db", Version: "13.0.0"},
+		},
+		{
+			name:   "it allows the installed packages of other clusters",
+			target: Target{Plugin: "helm.packages", Cluster: "other", Namespace: "prod-eu", Name: "orders-db", Version: "13.0.0"},
+		},
+		{
+			name:          "it rejects any update of a frozen installed package",
+			target:        Target{Plugin: "helm.packages", Cluster: "other", Namespace: "ingress", Name: "ingress", Version: "1.0.0"},
+			expectedError: `The installed package "ingress" cannot be updated: its updates are blocked, as pinned by the policy "kubeapps/frozen-ingress"`,
+		},
+		{
+			name:   "it allows the installed packages of other plugins",
+			target: Target{Plugin: "fluxv2.packages", Cluster: "default", Namespace: "ingress", Name: "ingress", Version: "1.0.0"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checker.CheckUpdate(context.Background(), tc.target)
+
+			if tc.expectedError == "" {
+				if err != nil {
+					t.Fatalf("%+v", err)
+				}
+				return
+			}
+			if got, want := connect.CodeOf(err), connect.CodeFailedPrecondition; got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+			var connectErr *connect.Error
+			if !errors.As(err, &connectErr) {
+				t.Fatalf("got: %T, want: *connect.Error", err)
+			}
+			if got, want := connectErr.Message(), tc.expectedError; got != want {
+				t.Errorf("got: %q, want: %q", got, want)
+			}
+		})
+	}
+}
+
+func TestCheckCreate(t *testing.T) {
+	checker := newTestChecker(t, fake.NewSimpleClientset(
+		pinConfigMap("frozen-ingress", `name: ingress`),
+		pinConfigMap("prod-db", `{"namespace": "prod", "name": "db", "versionConstraint": "^1.0.0"}`),
+	))
+
+	testCases := []struct {
+		name          string
+		target        Target
+		expectedError string
+	}{
+		{
+			name:   "it allows the creation of an installed package whose updates are blocked",
+			target: Target{Plugin: "helm.packages", Cluster: "default", Namespace: "ingress", Name: "ingress", Version: "1.0.0"},
+		},
+		{
+			name:   "it allows the creation with a version matching the constraint",
+			target: Target{Plugin: "helm.packages", Cluster: "default", Namespace: "prod", Name: "db", Version: "1.2.0"},
+		},
+		{
+			name:          "it rejects the creation with a version not matching the constraint",
+			target:        Target{Plugin: "helm.packages", Cluster: "default", Namespace: "prod", Name: "db", Version: "2.0.0"},
+			expectedError: `The installed package "db" cannot be created: the version "2.0.0" does not match "^1.0.0", as pinned by the policy "kubeapps/prod-db"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checker.CheckCreate(context.Background(), tc.target)
+
+			if tc.expectedError == "" {
+				if err != nil {
+					t.Fatalf("%+v", err)
+				}
+				return
+			}
+			var connectErr *connect.Error
+			if !errors.As(err, &connectErr) {
+				t.Fatalf("got: %+v, want: *connect.Error", err)
+			}
+			if got, want := connectErr.Code(), connect.CodeFailedPrecondition; got != want {
+				t.Errorf("got: %+v, want: %+v", got, want)
+			}
+			if got, want := connectErr.Message(), tc.expectedError; got != want {
+				t.Errorf("got: %q, want: %q", got, want)
+			}
+		})
+	}
+}
+
+func TestNewCheckerRequiresNamespace(t *testing.T) {
+	if _, err := NewChecker(fake.NewSimpleClientset(), ""); err == nil {
+		t.Errorf("got: nil, want: error")
+	}
+}
+
+func TestCheckRollback(t *testing.T) {
+	checker := newTestChecker(t, fake.NewSimpleClientset(pinConfigMap("prod-db", `{"name": "db", "versionConstraint": "^1.0.0"}`)))
+
+	err := checker.CheckRollback(context.Background(), Target{Plugin: "helm.packages", Cluster: "default", Namespace: "prod", Name: "db", Revision: 3})
+
+	var connectErr *connect.Error
+	if !errors.As(err, &connectErr) {
+		t.Fatalf("got: %+v, want: *connect.Error", err)
+	}
+	if got, want := connectErr.Message(), `The installed package "db" cannot be rolled back: the version of the revision 3 cannot be checked against "^1.0.0", as pinned by the policy "kubeapps/prod-db"`; got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+}
+
+func TestCheckUnreadablePins(t *testing.T) {
+	clientSet := fake.NewSimpleClientset()
+	clientSet.PrependReactor("list", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("forbidden")
+	})
+	checker := newTestChecker(t, clientSet)
+
+	err := checker.CheckUpdate(context.Background(), Target{Name: "ingress"})
+
+	if got, want := connect.CodeOf(err), connect.CodeUnavailable; got != want {
+		t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+	}
+}
+
+func TestNilChecker(t *testing.T) {
+	var checker *Checker
+	if err := checker.CheckUpdate(context.Background(), Target{Name: "ingress"}); err != nil {
+		t.Errorf("%+v", err)
+	}
+}
+
+func TestInterceptor(t *testing.T) {
+	checker := newTestChecker(t, fake.NewSimpleClientset(
+		pinConfigMap("frozen-ingress", `{"name": "ingress", "plugin": "helm.packages"}`),
+		pinConfigMap("prod-db", `{"namespace": "prod", "name": "db", "versionConstraint": "^1.0.0"}`),
+	))
+	helmPlugin := &plugins.Plugin{Name: "helm.packages", Version: "v1alpha1"}
+	fluxPlugin := &plugins.Plugin{Name: "fluxv2.packages", Version: "v1alpha1"}
+
+	testCases := []struct {
+		name         string
+		request      connect.AnyRequest
+		expectedCode connect.Code
+	}{
+		{
+			name: "it rejects the creation of a pinned installed package with another version",
+			request: connect.NewRequest(&packages.CreateInstalledPackageRequest{
+				AvailablePackageRef: &packages.AvailablePackageReference{Plugin: helmPlugin},
+				TargetContext:       &packages.Context{Cluster: "default", Namespace: "prod"},
+				Name:                "db",
+				PkgVersionReference: &packages.VersionReference{Version: "2.0.0"},
+			}),
+			expectedCode: connect.CodeFailedPrecondition,
+		},
+		{
+			name: "it rejects the update of a pinned installed package with the v1alpha1 API",
+			request: connect.NewRequest(&packagesv1alpha1.UpdateInstalledPackageRequest{
+				InstalledPackageRef: &packagesv1alpha1.InstalledPackageReference{
+					Context:    &packagesv1alpha1.Context{Cluster: "default", Namespace: "prod"},
+					Identifier: "db",
+					Plugin:     fluxPlugin,
+				},
+				PkgVersionReference: &packagesv1alpha1.VersionReference{Version: "2.0.0"},
+			}),
+			expectedCode: connect.CodeFailedPrecondition,
+		},
+		{
+			name: "it allows the update of a pinned installed package to a matching version",
+			request: connect.NewRequest(&packages.UpdateInstalledPackageRequest{
+				InstalledPackageRef: &packages.InstalledPackageReference{
+					Context:    &packages.Context{Cluster: "default", Namespace: "prod"},
+					Identifier: "db",
+					Plugin:     fluxPlugin,
+				},
+				PkgVersionReference: &packages.VersionReference{Version: "1.2.0"},
+			}),
+		},
+		{
+			name: "it rejects the rollback of a pinned installed package",
+			request: connect.NewRequest(&helm.RollbackInstalledPackageRequest{
+				InstalledPackageRef: &packagesv1alpha1.InstalledPackageReference{
+					Context:    &packagesv1alpha1.Context{Cluster: "default", Namespace: "prod"},
+					Identifier: "db",
+					Plugin:     helmPlugin,
+				},
+				ReleaseRevision: 3,
+			}),
+			expectedCode: connect.CodeFailedPrecondition,
+		},
+		{
+			name: "it allows the rollback of other installed packages",
+			request: connect.NewRequest(&helm.RollbackInstalledPackageRequest{
+				InstalledPackageRef: &packagesv1alpha1.InstalledPackageReference{
+					Context:    &packagesv1alpha1.Context{Cluster: "default", Namespace: "staging"},
+					Identifier: "db",
+					Plugin:     helmPlugin,
+				},
+				ReleaseRevision: 3,
+			}),
+		},
+		{
+			name: "it allows the other requests",
+			request: connect.NewRequest(&packages.DeleteInstalledPackageRequest{
+				InstalledPackageRef: &packages.InstalledPackageReference{
+					Context:    &packages.Context{Cluster: "default", Namespace: "ingress"},
+					Identifier: "ingress",
+					Plugin:     helmPlugin,
+				},
+			}),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			called := false
+			next := func(ctx context.Context, request connect.AnyRequest) (connect.AnyResponse, error) {
+				called = true
+				return nil, nil
+			}
+
+			_, err := checker.Interceptor().WrapUnary(next)(context.Background(), tc.request)
+
+			if got, want := connect.CodeOf(err), tc.expectedCode; err != nil && got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+			if tc.expectedCode != 0 && err == nil {
+				t.Fatalf("got: nil, want: %+v", tc.expectedCode)
+			}
+			if got, want := called, tc.expectedCode == 0; got != want {
+				t.Errorf("got: %t, want: %t", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	plugins "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/plugins/v1alpha1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	log "k8s.io/klog/v2"
+)
+
+// PluginConfigReloader parses the plugin configuration file, applies it to the
+// plugin server and returns the configuration now in effect. When an error is
+// returned, the plugin is expected to keep its previous configuration.
+type PluginConfigReloader func(pluginConfigPath string) (interface{}, error)
+
+// PluginConfigWatcher polls the plugin configuration file and, when its content
+// changes (such as when the mounted ConfigMap is updated), reloads the
+// configuration of the registered plugins without restarting the server.
+type PluginConfigWatcher struct {
+	pluginConfigPath string
+	interval         time.Duration
+
+	mutex         sync.RWMutex
+	checksum      [sha256.Size]byte
+	registrations []*pluginConfigRegistration
+}
+
+// pluginConfigRegistration records the configuration in effect for a plugin
+// along with the result of its last reload.
+type pluginConfigRegistration struct {
+	plugin          *plugins.Plugin
+	reload          PluginConfigReloader
+	effectiveConfig interface{}
+	lastReloadTime  time.Time
+	lastReloadError error
+}
+
+// NewPluginConfigWatcher returns a watcher checking the plugin configuration
+// file for changes at the given interval. No reload happens if the path is
+// empty or the interval is not positive.
+func NewPluginConfigWatcher(pluginConfigPath string, interval time.Duration) *PluginConfigWatcher {
+	return &PluginConfigWatcher{
+		pluginConfigPath: pluginConfigPath,
+		interval:         interval,
+	}
+}
+
+// Register records the configuration in effect for the plugin, which is
+// reloaded with the given function whenever the configuration file changes.
+func (w *PluginConfigWatcher) Register(plugin *plugins.Plugin, effectiveConfig interface{}, reload PluginConfigReloader) {
+	if w == nil {
+		return
+	}
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.registrations = append(w.registrations, &pluginConfigRegistration{
+		plugin:          plugin,
+		reload:          reload,
+		effectiveConfig: effectiveConfig,
+		lastReloadTime:  time.Now(),
+	})
+}
+
+// Start checks the configuration file for changes until the context is done.
+// The content of the file when starting is the one the plugins were created with.
+func (w *PluginConfigWatcher) Start(ctx context.Context) {
+	if w == nil || w.pluginConfigPath == "" || w.interval <= 0 {
+		return
+	}
+	checksum, err := w.readChecksum()
+	if err != nil {
+		log.Errorf("+core unable to watch the plugin config: %v", err)
+	}
+	w.mutex.Lock()
+	w.checksum = checksum
+	w.mutex.Unlock()
+
+	log.Infof("+core watching the plugin config at %q every %s", w.pluginConfigPath, w.interval)
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.checkForChanges()
+		}
+	}
+}
+
+// checkForChanges reloads the configuration of every registered plugin if the
+// content of the configuration file changed since the last check.
+func (w *PluginConfigWatcher) checkForChanges() {
+	checksum, err := w.readChecksum()
+	if err != nil {
+		// The file may be missing while the mounted ConfigMap is being updated,
+		// so it is checked again on the next tick.
+		log.Warningf("+core unable to check the plugin config for changes: %v", err)
+		return
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if checksum == w.checksum {
+		return
+	}
+	w.checksum = checksum
+
+	log.Infof("+core reloading the plugin config at %q", w.pluginConfigPath)
+	for _, registration := range w.registrations {
+		registration.lastReloadTime = time.Now()
+		effectiveConfig, err := registration.reload(w.pluginConfigPath)
+		if err != nil {
+			registration.lastReloadError = err
+			log.Errorf("+core unable to reload the config of the plugin %s, keeping the previous config: %v", registration.plugin.GetName(), err)
+			continue
+		}
+		registration.effectiveConfig = effectiveConfig
+		registration.lastReloadError = nil
+	}
+}
+
+// readChecksum returns the checksum of the content of the configuration file.
+func (w *PluginConfigWatcher) readChecksum() ([sha256.Size]byte, error) {
+	// #nosec G304
+	content, err := os.ReadFile(w.pluginConfigPath)
+	if err != nil {
+		return [sha256.Size]byte{}, fmt.Errorf("unable to open plugin config at %q: %w", w.pluginConfigPath, err)
+	}
+	return sha256.Sum256(content), nil
+}
+
+// PluginConfigs returns the configuration in effect for each registered plugin,
+// sorted by plugin name and version.
+func (w *PluginConfigWatcher) PluginConfigs() ([]*plugins.PluginConfig, error) {
+	if w == nil {
+		return []*plugins.PluginConfig{}, nil
+	}
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+
+	configs := make([]*plugins.PluginConfig, 0, len(w.registrations))
+	for _, registration := range w.registrations {
+		effectiveConfig, err := json.Marshal(registration.effectiveConfig)
+		if err != nil {
+			return nil, fmt.Errorf("unable to marshal the config of the plugin %s: %w", registration.plugin.GetName(), err)
+		}
+		config := &plugins.PluginConfig{
+			Plugin:         registration.plugin,
+			Config:         string(effectiveConfig),
+			LastReloadTime: timestamppb.New(registration.lastReloadTime),
+		}
+		if registration.lastReloadError != nil {
+			config.LastReloadError = registration.lastReloadError.Error()
+		}
+		configs = append(configs, config)
+	}
+	sort.Slice(configs, func(i, j int) bool { return ComparePlugin(configs[i].Plugin, configs[j].Plugin) })
+	return configs, nil
+}
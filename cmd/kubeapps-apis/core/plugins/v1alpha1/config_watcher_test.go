@@ -0,0 +1,127 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/google/go-cmp/cmp"
+	plugins "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/plugins/v1alpha1"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+type testPluginConfig struct {
+	TimeoutSeconds int `json:"timeoutSeconds"`
+}
+
+func TestPluginConfigWatcherCheckForChanges(t *testing.T) {
+	testCases := []struct {
+		name            string
+		newContent      string
+		reloadErr       error
+		expectedReloads int
+		expectedConfigs []*plugins.PluginConfig
+	}{
+		{
+			name:            "it does not reload the config when the file is unchanged",
+			newContent:      "initial",
+			expectedReloads: 0,
+			expectedConfigs: []*plugins.PluginConfig{
+				{
+					Plugin: &plugins.Plugin{Name: "kapp_controller.packages", Version: "v1alpha1"},
+					Config: `{"timeoutSeconds":300}`,
+				},
+			},
+		},
+		{
+			name:            "it reloads the config when the file changes",
+			newContent:      "updated",
+			expectedReloads: 1,
+			expectedConfigs: []*plugins.PluginConfig{
+				{
+					Plugin: &plugins.Plugin{Name: "kapp_controller.packages", Version: "v1alpha1"},
+					Config: `{"timeoutSeconds":60}`,
+				},
+			},
+		},
+		{
+			name:            "it keeps the previous config when the reload fails",
+			newContent:      "updated",
+			reloadErr:       fmt.Errorf("invalid config"),
+			expectedReloads: 1,
+			expectedConfigs: []*plugins.PluginConfig{
+				{
+					Plugin:          &plugins.Plugin{Name: "kapp_controller.packages", Version: "v1alpha1"},
+					Config:          `{"timeoutSeconds":300}`,
+					LastReloadError: "invalid config",
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pluginConfigPath := filepath.Join(t.TempDir(), "plugin-config.json")
+			if err := os.WriteFile(pluginConfigPath, []byte("initial"), 0600); err != nil {
+				t.Fatalf("%+v", err)
+			}
+			w := NewPluginConfigWatcher(pluginConfigPath, 0)
+			checksum, err := w.readChecksum()
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			w.checksum = checksum
+
+			reloads := 0
+			w.Register(&plugins.Plugin{Name: "kapp_controller.packages", Version: "v1alpha1"}, testPluginConfig{TimeoutSeconds: 300}, func(path string) (interface{}, error) {
+				reloads++
+				if got, want := path, pluginConfigPath; got != want {
+					t.Errorf("got: %q, want: %q", got, want)
+				}
+				if tc.reloadErr != nil {
+					return nil, tc.reloadErr
+				}
+				return testPluginConfig{TimeoutSeconds: 60}, nil
+			})
+
+			if err := os.WriteFile(pluginConfigPath, []byte(tc.newContent), 0600); err != nil {
+				t.Fatalf("%+v", err)
+			}
+			w.checkForChanges()
+
+			if got, want := reloads, tc.expectedReloads; got != want {
+				t.Errorf("got: %d, want: %d", got, want)
+			}
+
+			ps := PluginsServer{configWatcher: w, authenticate: func(ctx context.Context, headers http.Header) error { return nil }}
+			resp, err := ps.GetPluginConfigs(context.TODO(), connect.NewRequest(&plugins.GetPluginConfigsRequest{}))
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			opts := []cmp.Option{protocmp.Transform(), protocmp.IgnoreFields(&plugins.PluginConfig{}, "last_reload_time")}
+			if got, want := resp.Msg.GetConfigs(), tc.expectedConfigs; !cmp.Equal(want, got, opts...) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, opts...))
+			}
+		})
+	}
+}
+
+func TestPluginConfigWatcherNil(t *testing.T) {
+	var w *PluginConfigWatcher
+
+	w.Register(&plugins.Plugin{Name: "helm.packages", Version: "v1alpha1"}, testPluginConfig{}, nil)
+	configs, err := w.PluginConfigs()
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if got, want := len(configs), 0; got != want {
+		t.Errorf("got: %d, want: %d", got, want)
+	}
+}
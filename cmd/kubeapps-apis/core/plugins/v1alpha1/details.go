@@ -0,0 +1,130 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"context"
+	"plugin"
+	"reflect"
+	"sync"
+	"time"
+
+	packagesConnect "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1/v1alpha1connect"
+	plugins "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/plugins/v1alpha1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	log "k8s.io/klog/v2"
+)
+
+const (
+	pluginBuildVersionFunction = "GetPluginBuildVersion"
+	// pluginProbeTimeout bounds the time waited for the probe of a plugin.
+	pluginProbeTimeout = 10 * time.Second
+)
+
+var (
+	packagesHandlerType     = reflect.TypeOf((*packagesConnect.PackagesServiceHandler)(nil)).Elem()
+	repositoriesHandlerType = reflect.TypeOf((*packagesConnect.RepositoriesServiceHandler)(nil)).Elem()
+)
+
+// PluginProber is implemented by the plugin servers which can check that the
+// resources they need, such as their CRDs, can be reached.
+type PluginProber interface {
+	Probe(ctx context.Context) error
+}
+
+// ResourceRefsSupporter is implemented by the packages plugin servers which
+// may not return the references to the resources of their installed
+// packages, the packages plugins being assumed to return them otherwise.
+type ResourceRefsSupporter interface {
+	SupportsResourceRefs() bool
+}
+
+// pluginDetails returns the details of each registered plugin, in the same
+// order, probing the plugins concurrently if requested.
+func (s *PluginsServer) pluginDetails(ctx context.Context, probe bool) []*plugins.PluginDetails {
+	details := make([]*plugins.PluginDetails, len(s.pluginsWithServers))
+	var wg sync.WaitGroup
+	for i, p := range s.pluginsWithServers {
+		details[i] = &plugins.PluginDetails{
+			Plugin:       p.Plugin,
+			BuildVersion: p.BuildVersion,
+			Capabilities: pluginCapabilities(p.Server),
+		}
+		if !probe {
+			continue
+		}
+		wg.Add(1)
+		go func(detail *plugins.PluginDetails, server interface{}) {
+			defer wg.Done()
+			detail.Probe = probePlugin(ctx, server)
+		}(details[i], p.Server)
+	}
+	wg.Wait()
+	return details
+}
+
+// pluginCapabilities returns the optional functionality supported by the
+// plugin server, inferred from the core services it implements.
+func pluginCapabilities(server interface{}) *plugins.PluginCapabilities {
+	capabilities := &plugins.PluginCapabilities{}
+	serverType := reflect.TypeOf(server)
+	if serverType == nil {
+		return capabilities
+	}
+	capabilities.Repositories = serverType.Implements(repositoriesHandlerType)
+	capabilities.ResourceRefs = serverType.Implements(packagesHandlerType)
+	if supporter, ok := server.(ResourceRefsSupporter); ok && !supporter.SupportsResourceRefs() {
+		capabilities.ResourceRefs = false
+	}
+	return capabilities
+}
+
+// probePlugin probes the plugin server if it supports it, waiting at most
+// pluginProbeTimeout for the result.
+func probePlugin(ctx context.Context, server interface{}) *plugins.PluginProbe {
+	prober, ok := server.(PluginProber)
+	if !ok {
+		return &plugins.PluginProbe{Supported: false, Healthy: true}
+	}
+	ctx, cancel := context.WithTimeout(ctx, pluginProbeTimeout)
+	defer cancel()
+
+	// The probes may not honour the context, such as when using the discovery
+	// client, so the result is not waited for beyond the timeout.
+	result := make(chan error, 1)
+	go func() {
+		result <- prober.Probe(ctx)
+	}()
+	var err error
+	select {
+	case err = <-result:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	probe := &plugins.PluginProbe{
+		Supported: true,
+		Healthy:   err == nil,
+		ProbeTime: timestamppb.Now(),
+	}
+	if err != nil {
+		probe.Error = err.Error()
+	}
+	return probe
+}
+
+// getPluginBuildVersion returns the version the plugin reports it was built
+// from, or an empty string if the plugin does not report it.
+func getPluginBuildVersion(p *plugin.Plugin, pluginPath string) string {
+	buildVersionFn, err := p.Lookup(pluginBuildVersionFunction)
+	if err != nil {
+		return ""
+	}
+	fn, ok := buildVersionFn.(func() string)
+	if !ok {
+		log.Warningf("Unable to use %q in plugin %q due to a mismatched signature: %T", pluginBuildVersionFunction, pluginPath, buildVersionFn)
+		return ""
+	}
+	return fn()
+}
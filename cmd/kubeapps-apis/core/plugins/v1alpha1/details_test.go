@@ -0,0 +1,149 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	packagesConnect "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1/v1alpha1connect"
+	plugins "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/plugins/v1alpha1"
+)
+
+type fakeRepositoriesServer struct {
+	packagesConnect.UnimplementedPackagesServiceHandler
+	packagesConnect.UnimplementedRepositoriesServiceHandler
+	probeErr error
+}
+
+func (s fakeRepositoriesServer) Probe(ctx context.Context) error {
+	return s.probeErr
+}
+
+type fakePackagesServer struct {
+	packagesConnect.UnimplementedPackagesServiceHandler
+	resourceRefs bool
+}
+
+func (s fakePackagesServer) SupportsResourceRefs() bool {
+	return s.resourceRefs
+}
+
+type blockingProbeServer struct{}
+
+func (s blockingProbeServer) Probe(ctx context.Context) error {
+	time.Sleep(time.Minute)
+	return nil
+}
+
+func TestGetConfiguredPluginsDetails(t *testing.T) {
+	fluxPlugin := &plugins.Plugin{Name: "fluxv2.packages", Version: "v1alpha1"}
+	kappPlugin := &plugins.Plugin{Name: "kapp_controller.packages", Version: "v1alpha1"}
+	ociPlugin := &plugins.Plugin{Name: "oci_catalog.packages", Version: "v1alpha1"}
+	resourcesPlugin := &plugins.Plugin{Name: "resources", Version: "v1alpha1"}
+
+	ps := PluginsServer{
+		pluginsWithServers: []PluginWithServer{
+			{Plugin: fluxPlugin, Server: fakeRepositoriesServer{probeErr: errors.New("the server could not find the requested resource")}, BuildVersion: "v2.9.0"},
+			{Plugin: kappPlugin, Server: fakeRepositoriesServer{}, BuildVersion: "v2.9.0"},
+			{Plugin: ociPlugin, Server: fakePackagesServer{}},
+			{Plugin: resourcesPlugin, Server: struct{}{}, BuildVersion: "devel"},
+		},
+	}
+	opts := cmpopts.IgnoreUnexported(plugins.PluginDetails{}, plugins.Plugin{}, plugins.PluginCapabilities{}, plugins.PluginProbe{})
+
+	testCases := []struct {
+		name            string
+		probe           bool
+		expectedDetails []*plugins.PluginDetails
+	}{
+		{
+			name: "it returns the build version and capabilities of each plugin",
+			expectedDetails: []*plugins.PluginDetails{
+				{Plugin: fluxPlugin, BuildVersion: "v2.9.0", Capabilities: &plugins.PluginCapabilities{Repositories: true, ResourceRefs: true}},
+				{Plugin: kappPlugin, BuildVersion: "v2.9.0", Capabilities: &plugins.PluginCapabilities{Repositories: true, ResourceRefs: true}},
+				{Plugin: ociPlugin, Capabilities: &plugins.PluginCapabilities{}},
+				{Plugin: resourcesPlugin, BuildVersion: "devel", Capabilities: &plugins.PluginCapabilities{}},
+			},
+		},
+		{
+			name:  "it probes the plugins when requested",
+			probe: true,
+			expectedDetails: []*plugins.PluginDetails{
+				{
+					Plugin:       fluxPlugin,
+					BuildVersion: "v2.9.0",
+					Capabilities: &plugins.PluginCapabilities{Repositories: true, ResourceRefs: true},
+					Probe:        &plugins.PluginProbe{Supported: true, Healthy: false, Error: "the server could not find the requested resource"},
+				},
+				{
+					Plugin:       kappPlugin,
+					BuildVersion: "v2.9.0",
+					Capabilities: &plugins.PluginCapabilities{Repositories: true, ResourceRefs: true},
+					Probe:        &plugins.PluginProbe{Supported: true, Healthy: true},
+				},
+				{
+					Plugin:       ociPlugin,
+					Capabilities: &plugins.PluginCapabilities{},
+					Probe:        &plugins.PluginProbe{Supported: false, Healthy: true},
+				},
+				{
+					Plugin:       resourcesPlugin,
+					BuildVersion: "devel",
+					Capabilities: &plugins.PluginCapabilities{},
+					Probe:        &plugins.PluginProbe{Supported: false, Healthy: true},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := ps.GetConfiguredPlugins(context.Background(), connect.NewRequest(&plugins.GetConfiguredPluginsRequest{Probe: tc.probe}))
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+
+			for _, detail := range resp.Msg.Details {
+				if detail.Probe.GetSupported() && detail.Probe.GetProbeTime() == nil {
+					t.Errorf("the probe time of %q is not set", detail.Plugin.Name)
+				}
+				if detail.Probe != nil {
+					detail.Probe.ProbeTime = nil
+				}
+			}
+			if got, want := resp.Msg.Details, tc.expectedDetails; !cmp.Equal(want, got, opts) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, opts))
+			}
+		})
+	}
+}
+
+func TestPluginCapabilitiesResourceRefs(t *testing.T) {
+	if got, want := pluginCapabilities(fakePackagesServer{resourceRefs: true}).ResourceRefs, true; got != want {
+		t.Errorf("got: %t, want: %t", got, want)
+	}
+	if got, want := pluginCapabilities(nil).ResourceRefs, false; got != want {
+		t.Errorf("got: %t, want: %t", got, want)
+	}
+}
+
+func TestProbePluginTimeout(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	probe := probePlugin(ctx, blockingProbeServer{})
+
+	if probe.Healthy {
+		t.Errorf("got: healthy, want: unhealthy")
+	}
+	if got, want := probe.Error, context.DeadlineExceeded.Error(); got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+}
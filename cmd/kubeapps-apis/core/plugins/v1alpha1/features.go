@@ -0,0 +1,137 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"reflect"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core"
+	packagesConnect "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1/v1alpha1connect"
+	plugins "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/plugins/v1alpha1"
+	"google.golang.org/protobuf/proto"
+	log "k8s.io/klog/v2"
+)
+
+// FeaturesDescriptorVersion is the version of the features descriptor format,
+// to be incremented on changes which older dashboards cannot handle.
+const FeaturesDescriptorVersion = 1
+
+// coreServices maps the fully-qualified name of the core services which can be
+// implemented by plugins to the interface a plugin server must satisfy.
+var coreServices = []struct {
+	name          string
+	interfaceType reflect.Type
+}{
+	{packagesConnect.PackagesServiceName, reflect.TypeOf((*packagesConnect.PackagesServiceHandler)(nil)).Elem()},
+	{packagesConnect.RepositoriesServiceName, reflect.TypeOf((*packagesConnect.RepositoriesServiceHandler)(nil)).Elem()},
+}
+
+// GetFeatures returns the descriptor of the features enabled in the backend.
+func (s *PluginsServer) GetFeatures(ctx context.Context, in *connect.Request[plugins.GetFeaturesRequest]) (*connect.Response[plugins.GetFeaturesResponse], error) {
+	log.InfoS("+core GetFeatures")
+	if s.features == nil {
+		return nil, connect.NewError(connect.CodeUnavailable, fmt.Errorf("The features descriptor is not available yet"))
+	}
+	return connect.NewResponse(s.features), nil
+}
+
+// buildFeatures computes the features descriptor of the registered plugins
+// and configured clusters, serializes it and signs it with the configured
+// key, if any. As none of these change while the server runs, it is computed
+// once rather than on each request.
+func (s *PluginsServer) buildFeatures(serveOpts core.ServeOptions) (*plugins.GetFeaturesResponse, error) {
+	var signingKey ed25519.PrivateKey
+	if serveOpts.FeaturesSigningKeyPath != "" {
+		var err error
+		signingKey, err = loadFeaturesSigningKey(serveOpts.FeaturesSigningKeyPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return newFeaturesResponse(s.featuresDescriptor(serveOpts), signingKey)
+}
+
+// featuresDescriptor returns the descriptor of the features currently enabled.
+func (s *PluginsServer) featuresDescriptor(serveOpts core.ServeOptions) *plugins.FeaturesDescriptor {
+	operations := &plugins.OperationsPolicy{
+		GlobalReposNamespace: serveOpts.GlobalHelmReposNamespace,
+	}
+	pluginFeatures := make([]*plugins.PluginFeatures, len(s.pluginsWithServers))
+	for i, p := range s.pluginsWithServers {
+		services := []string{}
+		serverType := reflect.TypeOf(p.Server)
+		for _, service := range coreServices {
+			if serverType == nil || !serverType.Implements(service.interfaceType) {
+				continue
+			}
+			services = append(services, service.name)
+			switch service.name {
+			case packagesConnect.PackagesServiceName:
+				operations.PackagesEnabled = true
+			case packagesConnect.RepositoriesServiceName:
+				operations.RepositoriesEnabled = true
+			}
+		}
+		pluginFeatures[i] = &plugins.PluginFeatures{
+			Plugin:   p.Plugin,
+			Services: services,
+		}
+	}
+	return &plugins.FeaturesDescriptor{
+		Version:    FeaturesDescriptorVersion,
+		Plugins:    pluginFeatures,
+		Clusters:   s.GetClusterNames(),
+		Operations: operations,
+	}
+}
+
+// newFeaturesResponse serializes the descriptor deterministically and
+// computes the checksum and, if a key is given, the signature of the result.
+func newFeaturesResponse(descriptor *plugins.FeaturesDescriptor, signingKey ed25519.PrivateKey) (*plugins.GetFeaturesResponse, error) {
+	payload, err := proto.MarshalOptions{Deterministic: true}.Marshal(descriptor)
+	if err != nil {
+		return nil, fmt.Errorf("unable to serialize the features descriptor: %w", err)
+	}
+	digest := sha256.Sum256(payload)
+	response := &plugins.GetFeaturesResponse{
+		Features: descriptor,
+		Payload:  payload,
+		Checksum: "sha256:" + hex.EncodeToString(digest[:]),
+	}
+	if signingKey != nil {
+		response.Signature = ed25519.Sign(signingKey, payload)
+	}
+	return response, nil
+}
+
+// loadFeaturesSigningKey reads a PEM-encoded PKCS #8 Ed25519 private key, as
+// generated with `openssl genpkey -algorithm ed25519`.
+func loadFeaturesSigningKey(path string) (ed25519.PrivateKey, error) {
+	keyPEM, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the features signing key: %w", err)
+	}
+	block, _ := pem.Decode(keyPEM)
+	if block == nil {
+		return nil, fmt.Errorf("unable to decode the features signing key %q: no PEM data found", path)
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse the features signing key %q: %w", path, err)
+	}
+	signingKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("the features signing key %q is a %T, not an Ed25519 key", path, key)
+	}
+	return signingKey, nil
+}
@@ -0,0 +1,215 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/google/go-cmp/cmp"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core"
+	packagesConnect "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1/v1alpha1connect"
+	plugins "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/plugins/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/pkg/kube"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+type packagesOnlyPluginServer struct {
+	packagesConnect.UnimplementedPackagesServiceHandler
+}
+
+type packagesAndRepositoriesPluginServer struct {
+	packagesConnect.UnimplementedPackagesServiceHandler
+	packagesConnect.UnimplementedRepositoriesServiceHandler
+}
+
+func TestGetFeatures(t *testing.T) {
+	ps := PluginsServer{
+		pluginsWithServers: []PluginWithServer{
+			{
+				Plugin: &plugins.Plugin{Name: "helm.packages", Version: "v1alpha1"},
+				Server: &packagesAndRepositoriesPluginServer{},
+			},
+			{
+				Plugin: &plugins.Plugin{Name: "kapp_controller.packages", Version: "v1alpha1"},
+				Server: &packagesOnlyPluginServer{},
+			},
+			{
+				Plugin: &plugins.Plugin{Name: "resources", Version: "v1alpha1"},
+				Server: struct{}{},
+			},
+		},
+		clustersConfig: kube.ClustersConfig{
+			Clusters: map[string]kube.ClusterConfig{
+				"second":  {},
+				"default": {},
+			},
+		},
+	}
+
+	var err error
+	ps.features, err = ps.buildFeatures(core.ServeOptions{GlobalHelmReposNamespace: "kubeapps-repos-global"})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	resp, err := ps.GetFeatures(context.TODO(), connect.NewRequest(&plugins.GetFeaturesRequest{}))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	expected := &plugins.FeaturesDescriptor{
+		Version: FeaturesDescriptorVersion,
+		Plugins: []*plugins.PluginFeatures{
+			{
+				Plugin:   &plugins.Plugin{Name: "helm.packages", Version: "v1alpha1"},
+				Services: []string{packagesConnect.PackagesServiceName, packagesConnect.RepositoriesServiceName},
+			},
+			{
+				Plugin:   &plugins.Plugin{Name: "kapp_controller.packages", Version: "v1alpha1"},
+				Services: []string{packagesConnect.PackagesServiceName},
+			},
+			{
+				Plugin:   &plugins.Plugin{Name: "resources", Version: "v1alpha1"},
+				Services: []string{},
+			},
+		},
+		Clusters: []string{"default", "second"},
+		Operations: &plugins.OperationsPolicy{
+			PackagesEnabled:      true,
+			RepositoriesEnabled:  true,
+			GlobalReposNamespace: "kubeapps-repos-global",
+		},
+	}
+	if got, want := resp.Msg.Features, expected; !cmp.Equal(want, got, protocmp.Transform()) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, protocmp.Transform()))
+	}
+
+	// The payload is the authoritative descriptor.
+	decoded := &plugins.FeaturesDescriptor{}
+	if err := proto.Unmarshal(resp.Msg.Payload, decoded); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if got, want := decoded, expected; !cmp.Equal(want, got, protocmp.Transform()) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, protocmp.Transform()))
+	}
+	if got, want := len(resp.Msg.Signature), 0; got != want {
+		t.Errorf("got: %d, want: %d", got, want)
+	}
+}
+
+func TestNewFeaturesResponse(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	descriptor := &plugins.FeaturesDescriptor{
+		Version:  FeaturesDescriptorVersion,
+		Clusters: []string{"default"},
+	}
+
+	testCases := []struct {
+		name       string
+		signingKey ed25519.PrivateKey
+	}{
+		{
+			name: "it returns an unsigned descriptor without a signing key",
+		},
+		{
+			name:       "it signs the payload with the signing key",
+			signingKey: privateKey,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := newFeaturesResponse(descriptor, tc.signingKey)
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+
+			digest := sha256.Sum256(resp.Payload)
+			if got, want := resp.Checksum, "sha256:"+hex.EncodeToString(digest[:]); got != want {
+				t.Errorf("got: %q, want: %q", got, want)
+			}
+
+			if tc.signingKey == nil {
+				if len(resp.Signature) != 0 {
+					t.Errorf("got: %x, want: no signature", resp.Signature)
+				}
+				return
+			}
+			if !ed25519.Verify(publicKey, resp.Payload, resp.Signature) {
+				t.Errorf("got an invalid signature %x", resp.Signature)
+			}
+		})
+	}
+}
+
+func TestLoadFeaturesSigningKey(t *testing.T) {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	testCases := []struct {
+		name          string
+		content       []byte
+		expectedKey   ed25519.PrivateKey
+		expectedError bool
+	}{
+		{
+			name:        "it loads a PKCS #8 Ed25519 key",
+			content:     pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}),
+			expectedKey: privateKey,
+		},
+		{
+			name:          "it errors if the file is not PEM-encoded",
+			content:       []byte("not a key"),
+			expectedError: true,
+		},
+		{
+			name:          "it errors if the key is not a PKCS #8 key",
+			content:       pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("not a key")}),
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "features.key")
+			if err := os.WriteFile(path, tc.content, 0600); err != nil {
+				t.Fatalf("%+v", err)
+			}
+
+			key, err := loadFeaturesSigningKey(path)
+			if tc.expectedError {
+				if err == nil {
+					t.Fatalf("got: nil, want: error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if !key.Equal(tc.expectedKey) {
+				t.Errorf("got a different key than the one written")
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"sync"
+	"time"
+
+	"github.com/bufbuild/connect-go"
+	plugins "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/plugins/v1alpha1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// PluginHealthTracker records the outcome of the calls made to each plugin when
+// aggregating the results of several plugins, so that a plugin which is slow or
+// failing (such as when its controller is down) can be reported as unhealthy.
+type PluginHealthTracker struct {
+	mutex    sync.RWMutex
+	statuses map[string]*pluginHealthStatus
+}
+
+// pluginHealthStatus records the outcome of the last calls to a plugin.
+type pluginHealthStatus struct {
+	consecutiveFailures uint32
+	lastError           error
+	lastFailureTime     time.Time
+	lastSuccessTime     time.Time
+}
+
+func NewPluginHealthTracker() *PluginHealthTracker {
+	return &PluginHealthTracker{
+		statuses: map[string]*pluginHealthStatus{},
+	}
+}
+
+// IsPluginFailure returns whether the error returned by a plugin denotes that
+// the plugin itself is failing, rather than the request being invalid or not
+// allowed, in which case the plugin is still healthy.
+func IsPluginFailure(err error) bool {
+	switch connect.CodeOf(err) {
+	case connect.CodeDeadlineExceeded, connect.CodeUnavailable, connect.CodeInternal, connect.CodeUnknown:
+		return true
+	default:
+		return false
+	}
+}
+
+// Record records the outcome of a call to the given plugin, the plugin being
+// unhealthy while its calls fail as per IsPluginFailure.
+func (t *PluginHealthTracker) Record(plugin *plugins.Plugin, err error) {
+	if t == nil {
+		return
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	key := pluginHealthKey(plugin)
+	status, ok := t.statuses[key]
+	if !ok {
+		status = &pluginHealthStatus{}
+		t.statuses[key] = status
+	}
+	if err != nil && IsPluginFailure(err) {
+		status.consecutiveFailures++
+		status.lastError = err
+		status.lastFailureTime = time.Now()
+		return
+	}
+	status.consecutiveFailures = 0
+	status.lastSuccessTime = time.Now()
+}
+
+// Health returns the health of each of the given plugins, in the same order.
+func (t *PluginHealthTracker) Health(pluginDetails []*plugins.Plugin) []*plugins.PluginHealth {
+	health := make([]*plugins.PluginHealth, len(pluginDetails))
+	if t != nil {
+		t.mutex.RLock()
+		defer t.mutex.RUnlock()
+	}
+	for i, plugin := range pluginDetails {
+		health[i] = &plugins.PluginHealth{Plugin: plugin, Healthy: true}
+		if t == nil {
+			continue
+		}
+		status, ok := t.statuses[pluginHealthKey(plugin)]
+		if !ok {
+			continue
+		}
+		health[i].Healthy = status.consecutiveFailures == 0
+		health[i].ConsecutiveFailures = status.consecutiveFailures
+		if status.lastError != nil {
+			health[i].LastError = status.lastError.Error()
+			health[i].LastFailureTime = timestamppb.New(status.lastFailureTime)
+		}
+		if !status.lastSuccessTime.IsZero() {
+			health[i].LastSuccessTime = timestamppb.New(status.lastSuccessTime)
+		}
+	}
+	return health
+}
+
+func pluginHealthKey(plugin *plugins.Plugin) string {
+	return plugin.GetName() + "/" + plugin.GetVersion()
+}
@@ -0,0 +1,63 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bufbuild/connect-go"
+	plugins "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/plugins/v1alpha1"
+)
+
+func TestPluginHealthTracker(t *testing.T) {
+	fluxPlugin := &plugins.Plugin{Name: "fluxv2.packages", Version: "v1alpha1"}
+	helmPlugin := &plugins.Plugin{Name: "helm.packages", Version: "v1alpha1"}
+	kappPlugin := &plugins.Plugin{Name: "kapp_controller.packages", Version: "v1alpha1"}
+
+	tracker := NewPluginHealthTracker()
+	tracker.Record(fluxPlugin, nil)
+	tracker.Record(fluxPlugin, connect.NewError(connect.CodeDeadlineExceeded, errors.New("timed out")))
+	tracker.Record(fluxPlugin, connect.NewError(connect.CodeUnavailable, errors.New("connection refused")))
+	// errors of the request do not make the plugin unhealthy
+	tracker.Record(helmPlugin, connect.NewError(connect.CodeInternal, errors.New("boom")))
+	tracker.Record(helmPlugin, connect.NewError(connect.CodePermissionDenied, errors.New("forbidden")))
+
+	health := tracker.Health([]*plugins.Plugin{fluxPlugin, helmPlugin, kappPlugin})
+
+	if got, want := len(health), 3; got != want {
+		t.Fatalf("got: %d, want: %d", got, want)
+	}
+
+	flux := health[0]
+	if flux.GetPlugin() != fluxPlugin || flux.GetHealthy() || flux.GetConsecutiveFailures() != 2 {
+		t.Errorf("got: %+v, want: an unhealthy plugin with 2 consecutive failures", flux)
+	}
+	if got, want := flux.GetLastError(), "unavailable: connection refused"; got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+	if flux.GetLastFailureTime() == nil || flux.GetLastSuccessTime() == nil {
+		t.Errorf("got: %+v, want: the times of the last failure and success", flux)
+	}
+
+	helm := health[1]
+	if !helm.GetHealthy() || helm.GetConsecutiveFailures() != 0 || helm.GetLastError() != "internal: boom" {
+		t.Errorf("got: %+v, want: a healthy plugin which failed before", helm)
+	}
+
+	kapp := health[2]
+	if !kapp.GetHealthy() || kapp.GetLastFailureTime() != nil || kapp.GetLastSuccessTime() != nil {
+		t.Errorf("got: %+v, want: a healthy plugin never called", kapp)
+	}
+}
+
+func TestPluginHealthTrackerNil(t *testing.T) {
+	var tracker *PluginHealthTracker
+	tracker.Record(&plugins.Plugin{Name: "helm.packages"}, errors.New("boom"))
+
+	health := tracker.Health([]*plugins.Plugin{{Name: "helm.packages"}})
+	if len(health) != 1 || !health[0].GetHealthy() {
+		t.Errorf("got: %+v, want: a single healthy plugin", health)
+	}
+}
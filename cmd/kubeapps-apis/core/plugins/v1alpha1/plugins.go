@@ -19,11 +19,17 @@ import (
 	"github.com/bufbuild/connect-go"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core/tracing"
 	plugins "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/plugins/v1alpha1"
 	"github.com/vmware-tanzu/kubeapps/pkg/kube"
 	"google.golang.org/grpc"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/transport"
 	log "k8s.io/klog/v2"
 )
 
@@ -42,6 +48,9 @@ type GRPCPluginRegistrationOptions struct {
 	ConfigGetter     core.KubernetesConfigGetter
 	ClustersConfig   kube.ClustersConfig
 	PluginConfigPath string
+	// The watcher with which plugins register to reload their configuration
+	// when the plugin configuration file changes.
+	PluginConfigWatcher *PluginConfigWatcher
 	// The QPS and Burst options that have been configured for any
 	// clients of the K8s API server created by plugins.
 	ClientQPS   float32
@@ -49,6 +58,9 @@ type GRPCPluginRegistrationOptions struct {
 
 	// The mux used for the connect gRPC routing
 	Mux *http.ServeMux
+	// The options, such as interceptors, to be used when creating the
+	// connect handlers registered in the mux
+	HandlerOptions []connect.HandlerOption
 
 	LocalPort int
 }
@@ -57,6 +69,8 @@ type GRPCPluginRegistrationOptions struct {
 type PluginWithServer struct {
 	Plugin *plugins.Plugin
 	Server interface{}
+	// The version the plugin was built from, if reported by the plugin.
+	BuildVersion string
 }
 
 // PluginsServer implements the API defined in "plugins.proto"
@@ -68,9 +82,31 @@ type PluginsServer struct {
 
 	// The parsed config for clusters in a multi-cluster setup.
 	clustersConfig kube.ClustersConfig
+
+	// The features descriptor, computed once all the plugins are registered.
+	features *plugins.GetFeaturesResponse
+
+	// The watcher reloading the configuration of the plugins at runtime.
+	configWatcher *PluginConfigWatcher
+
+	// The health of the plugins, recorded by the core services aggregating
+	// the results of several plugins.
+	health *PluginHealthTracker
+
+	// The options, including the interceptors, with which the handlers of
+	// the plugins are created, as those of the core services.
+	handlerOpts []connect.HandlerOption
+
+	// configGetter returns the configuration of the clients of each cluster
+	// authenticated as the user of a request.
+	configGetter core.KubernetesConfigGetter
+
+	// authenticate checks that a request is made by a user of the kubeapps
+	// cluster. It is a field so that it can be switched in tests.
+	authenticate func(ctx context.Context, headers http.Header) error
 }
 
-func NewPluginsServer(serveOpts core.ServeOptions, gwArgs core.GatewayHandlerArgs, mux *http.ServeMux) (*PluginsServer, error) {
+func NewPluginsServer(serveOpts core.ServeOptions, gwArgs core.GatewayHandlerArgs, mux *http.ServeMux, handlerOpts ...connect.HandlerOption) (*PluginsServer, error) {
 	// Store the serveOptions in the global 'pluginsServeOpts' variable
 
 	// Find all .so plugins in the specified plugins directory.
@@ -79,20 +115,34 @@ func NewPluginsServer(serveOpts core.ServeOptions, gwArgs core.GatewayHandlerArg
 		log.Fatalf("Failed to check for plugins: %v", err)
 	}
 
-	ps := &PluginsServer{}
+	ps := &PluginsServer{handlerOpts: handlerOpts}
 
 	// get the parsed kube.ClustersConfig from the serveOpts
 	clustersConfig, err := getClustersConfigFromServeOpts(serveOpts)
 	if err != nil {
 		return nil, err
 	}
+	// The clusters registered at runtime, if enabled, are shared with the
+	// plugins through the registry of the clusters config.
+	if serveOpts.ClustersSyncInterval > 0 {
+		clustersConfig.Registered = kube.NewClusterRegistry()
+	}
 	ps.clustersConfig = clustersConfig
+	ps.configWatcher = NewPluginConfigWatcher(serveOpts.PluginConfigPath, serveOpts.PluginConfigReloadInterval)
+	ps.health = NewPluginHealthTracker()
 
 	err = ps.registerPlugins(pluginPaths, gwArgs, serveOpts, mux)
 	if err != nil {
 		return nil, fmt.Errorf("failed to register plugins: %w", err)
 	}
 
+	ps.features, err = ps.buildFeatures(serveOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build the features descriptor: %w", err)
+	}
+
+	go ps.configWatcher.Start(gwArgs.Ctx)
+
 	return ps, nil
 }
 
@@ -116,6 +166,30 @@ func (s *PluginsServer) GetConfiguredPlugins(ctx context.Context, in *connect.Re
 	}
 	return connect.NewResponse(&plugins.GetConfiguredPluginsResponse{
 		Plugins: pluginDetails,
+		Health:  s.health.Health(pluginDetails),
+		Details: s.pluginDetails(ctx, in.Msg.GetProbe()),
+	}), nil
+}
+
+// Health returns the tracker of the health of the plugins, in which the core
+// services record the outcome of the calls made to the plugins.
+func (s *PluginsServer) Health() *PluginHealthTracker {
+	return s.health
+}
+
+// GetPluginConfigs returns the configuration in effect for each plugin supporting reloads.
+// As it describes the setup of the cluster, it is only returned to authenticated users.
+func (s *PluginsServer) GetPluginConfigs(ctx context.Context, in *connect.Request[plugins.GetPluginConfigsRequest]) (*connect.Response[plugins.GetPluginConfigsResponse], error) {
+	log.InfoS("+core GetPluginConfigs")
+	if err := s.authenticate(ctx, in.Header()); err != nil {
+		return nil, err
+	}
+	configs, err := s.configWatcher.PluginConfigs()
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to get the plugin configs: %w", err))
+	}
+	return connect.NewResponse(&plugins.GetPluginConfigsResponse{
+		Configs: configs,
 	}), nil
 }
 
@@ -127,6 +201,8 @@ func (s *PluginsServer) registerPlugins(pluginPaths []string, gwArgs core.Gatewa
 	if err != nil {
 		return fmt.Errorf("unable to create a ClientGetter: %w", err)
 	}
+	s.configGetter = configGetter
+	s.authenticate = newAuthenticator(configGetter, s.clustersConfig.KubeappsClusterName)
 
 	for _, pluginPath := range pluginPaths {
 		p, err := plugin.Open(pluginPath)
@@ -143,8 +219,9 @@ func (s *PluginsServer) registerPlugins(pluginPaths []string, gwArgs core.Gatewa
 			return err
 		} else {
 			pluginsWithServers = append(pluginsWithServers, PluginWithServer{
-				Plugin: pluginDetail,
-				Server: grpcServer,
+				Plugin:       pluginDetail,
+				Server:       grpcServer,
+				BuildVersion: getPluginBuildVersion(p, pluginPath),
 			})
 		}
 
@@ -179,13 +256,15 @@ func (s *PluginsServer) registerGRPC(p *plugin.Plugin, pluginDetail *plugins.Plu
 	}
 
 	server, err := grpcFn(GRPCPluginRegistrationOptions{
-		ConfigGetter:     configGetter,
-		ClustersConfig:   s.clustersConfig,
-		PluginConfigPath: serveOpts.PluginConfigPath,
-		ClientQPS:        serveOpts.QPS,
-		ClientBurst:      serveOpts.Burst,
-		Mux:              mux,
-		LocalPort:        serveOpts.Port,
+		ConfigGetter:        configGetter,
+		ClustersConfig:      s.clustersConfig,
+		PluginConfigPath:    serveOpts.PluginConfigPath,
+		PluginConfigWatcher: s.configWatcher,
+		ClientQPS:           serveOpts.QPS,
+		ClientBurst:         serveOpts.Burst,
+		Mux:                 mux,
+		HandlerOptions:      s.handlerOpts,
+		LocalPort:           serveOpts.Port,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("plug-in %q failed to register due to: %v", pluginDetail, err)
@@ -214,6 +293,34 @@ func (s *PluginsServer) GetPluginsSatisfyingInterface(targetInterface reflect.Ty
 	return satisfiedPlugins
 }
 
+// ClustersConfig returns the parsed config for clusters, whose registry holds
+// the clusters registered at runtime.
+func (s *PluginsServer) ClustersConfig() kube.ClustersConfig {
+	return s.clustersConfig
+}
+
+// ConfigGetter returns the configuration of the clients of each cluster
+// authenticated as the user of a request, for the core services.
+func (s *PluginsServer) ConfigGetter() core.KubernetesConfigGetter {
+	return s.configGetter
+}
+
+// GetClusterNames returns the names of the configured and registered clusters,
+// sorted by name.
+func (s *PluginsServer) GetClusterNames() []string {
+	clusters := []string{}
+	for name := range s.clustersConfig.Clusters {
+		clusters = append(clusters, name)
+	}
+	for _, name := range s.clustersConfig.Registered.Names() {
+		if _, ok := s.clustersConfig.Clusters[name]; !ok {
+			clusters = append(clusters, name)
+		}
+	}
+	sort.Strings(clusters)
+	return clusters
+}
+
 // getPluginDetail returns a core.plugins.Plugin as defined by the plugin itself.
 func getPluginDetail(p *plugin.Plugin, pluginPath string) (*plugins.Plugin, error) {
 	pluginDetailFn, err := p.Lookup(pluginDetailFunction)
@@ -312,6 +419,36 @@ func createConfigGetter(serveOpts core.ServeOptions, clustersConfig kube.Cluster
 	return createConfigGetterWithParams(restConfig, serveOpts, clustersConfig)
 }
 
+// newAuthenticator returns a function checking that the token of a request
+// identifies a user of the given cluster, by reviewing an access on their
+// behalf, which the API server only allows to authenticated users.
+func newAuthenticator(configGetter core.KubernetesConfigGetter, cluster string) func(ctx context.Context, headers http.Header) error {
+	return func(ctx context.Context, headers http.Header) error {
+		config, err := configGetter(headers, cluster)
+		if err != nil {
+			if connect.CodeOf(err) == connect.CodeUnauthenticated {
+				return err
+			}
+			return connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to get the client config: %w", err))
+		}
+		clientSet, err := kubernetes.NewForConfig(config)
+		if err != nil {
+			return connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to create the client: %w", err))
+		}
+		_, err = clientSet.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, &authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+				NonResourceAttributes: &authorizationv1.NonResourceAttributes{Path: "/", Verb: "get"},
+			},
+		}, metav1.CreateOptions{})
+		if k8serrors.IsUnauthorized(err) {
+			return connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("Unable to identify the user: %w", err))
+		} else if err != nil {
+			return connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to review the access of the user: %w", err))
+		}
+		return nil
+	}
+}
+
 // createClientGetter takes the required params and returns the closure function.
 // it's split for testing this fn separately
 func createConfigGetterWithParams(inClusterConfig *rest.Config, serveOpts core.ServeOptions, clustersConfig kube.ClustersConfig) (core.KubernetesConfigGetter, error) {
@@ -333,10 +470,16 @@ func createConfigGetterWithParams(inClusterConfig *rest.Config, serveOpts core.S
 			cluster = clustersConfig.KubeappsClusterName
 		}
 
+		impersonate, err := extractImpersonation(headers, serveOpts.ImpersonationEnabled)
+		if err != nil {
+			return nil, err
+		}
+
 		config, err = kube.NewClusterConfig(inClusterConfig, token, cluster, clustersConfig)
 		if err != nil {
 			return nil, fmt.Errorf("unable to get clusterConfig: %w", err)
 		}
+		config.Impersonate = impersonate
 
 		if serveOpts.QPS > 0.0 {
 			config.QPS = serveOpts.QPS
@@ -346,6 +489,9 @@ func createConfigGetterWithParams(inClusterConfig *rest.Config, serveOpts core.S
 			config.Burst = serveOpts.Burst
 		}
 
+		// trace the requests to the API server as part of the calling request
+		config.WrapTransport = transport.Wrappers(config.WrapTransport, tracing.WrapTransport)
+
 		return config, nil
 	}, nil
 }
@@ -368,6 +514,26 @@ func extractToken(headers http.Header) (string, error) {
 	}
 }
 
+// extractImpersonation returns the user and groups to impersonate passed in
+// the "Impersonate-User" and "Impersonate-Group" headers, if any. The API
+// server checks that the user of the token is allowed to impersonate them.
+func extractImpersonation(headers http.Header, enabled bool) (rest.ImpersonationConfig, error) {
+	impersonate := rest.ImpersonationConfig{
+		UserName: headers.Get(transport.ImpersonateUserHeader),
+		Groups:   headers.Values(transport.ImpersonateGroupHeader),
+	}
+	if impersonate.UserName == "" && len(impersonate.Groups) == 0 {
+		return rest.ImpersonationConfig{}, nil
+	}
+	if !enabled {
+		return rest.ImpersonationConfig{}, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("Impersonation is not enabled"))
+	}
+	if impersonate.UserName == "" {
+		return rest.ImpersonationConfig{}, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("The groups to impersonate require a user to impersonate"))
+	}
+	return impersonate, nil
+}
+
 // getClustersConfigFromServeOpts get the serveOptions and calls parseClusterConfig with the proper values
 // returning a kube.ClustersConfig
 func getClustersConfigFromServeOpts(serveOpts core.ServeOptions) (kube.ClustersConfig, error) {
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io/fs"
 	"net/http"
+	"net/http/httptest"
 	"path/filepath"
 	"runtime"
 	"testing"
@@ -321,6 +322,60 @@ func TestExtractToken(t *testing.T) {
 	}
 }
 
+func TestExtractImpersonation(t *testing.T) {
+	testCases := []struct {
+		name                string
+		headers             http.Header
+		enabled             bool
+		expectedImpersonate rest.ImpersonationConfig
+		expectedErrCode     connect.Code
+	}{
+		{
+			name:    "it returns no impersonation without the headers",
+			headers: http.Header{"Authorization": []string{"Bearer abc"}},
+		},
+		{
+			name: "it returns the user and groups to impersonate when enabled",
+			headers: http.Header{
+				"Impersonate-User":  []string{"jane"},
+				"Impersonate-Group": []string{"developers", "testers"},
+			},
+			enabled: true,
+			expectedImpersonate: rest.ImpersonationConfig{
+				UserName: "jane",
+				Groups:   []string{"developers", "testers"},
+			},
+		},
+		{
+			name:            "it returns a permission denied error when not enabled",
+			headers:         http.Header{"Impersonate-User": []string{"jane"}},
+			expectedErrCode: connect.CodePermissionDenied,
+		},
+		{
+			name:            "it returns an invalid argument error for groups without a user",
+			headers:         http.Header{"Impersonate-Group": []string{"developers"}},
+			enabled:         true,
+			expectedErrCode: connect.CodeInvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			impersonate, err := extractImpersonation(tc.headers, tc.enabled)
+
+			if got, want := connect.CodeOf(err), tc.expectedErrCode; tc.expectedErrCode != 0 && got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			} else if tc.expectedErrCode == 0 && err != nil {
+				t.Fatalf("in %s: %+v", tc.name, err)
+			}
+
+			if got, want := impersonate, tc.expectedImpersonate; !cmp.Equal(want, got) {
+				t.Errorf("in %s: mismatch (-want +got):\n%s", tc.name, cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
 func TestCreateConfigGetterWithParams(t *testing.T) {
 	const (
 		DefaultClusterName = "default"
@@ -405,3 +460,70 @@ func TestCreateConfigGetterWithParams(t *testing.T) {
 		})
 	}
 }
+
+func TestGetPluginConfigsAuthentication(t *testing.T) {
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Header.Get("Authorization") != "Bearer valid" {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"Unauthorized","code":401}`)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"kind":"SelfSubjectAccessReview","apiVersion":"authorization.k8s.io/v1","status":{"allowed":false}}`)
+	}))
+	defer apiServer.Close()
+
+	clustersConfig := kube.ClustersConfig{
+		KubeappsClusterName: "default",
+		Clusters: map[string]kube.ClusterConfig{
+			"default": {Name: "default", IsKubeappsCluster: true},
+		},
+	}
+	configGetter, err := createConfigGetterWithParams(&rest.Config{Host: apiServer.URL}, core.ServeOptions{}, clustersConfig)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	testCases := []struct {
+		name              string
+		headers           http.Header
+		expectedErrorCode connect.Code
+	}{
+		{
+			name:    "it returns the configs to an authenticated user",
+			headers: http.Header{"Authorization": []string{"Bearer valid"}},
+		},
+		{
+			name:              "it returns an unauthenticated error without a token",
+			expectedErrorCode: connect.CodeUnauthenticated,
+		},
+		{
+			name:              "it returns an unauthenticated error for an invalid token",
+			headers:           http.Header{"Authorization": []string{"Bearer invalid"}},
+			expectedErrorCode: connect.CodeUnauthenticated,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ps := PluginsServer{authenticate: newAuthenticator(configGetter, "default")}
+			request := connect.NewRequest(&plugins.GetPluginConfigsRequest{})
+			for key, values := range tc.headers {
+				request.Header()[key] = values
+			}
+
+			_, err := ps.GetPluginConfigs(context.Background(), request)
+
+			if tc.expectedErrorCode == 0 {
+				if err != nil {
+					t.Fatalf("%+v", err)
+				}
+				return
+			}
+			if got, want := connect.CodeOf(err), tc.expectedErrorCode; err == nil || got != want {
+				t.Errorf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core/authn"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core/storage"
+	preferences "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/preferences/v1alpha1"
+	"google.golang.org/protobuf/encoding/protojson"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	log "k8s.io/klog/v2"
+)
+
+const (
+	// PreferencesConfigMapPrefix is the prefix of the names of the
+	// ConfigMaps, in the Kubeapps namespace, in which the preferences of each
+	// user are stored, followed by the key of the user.
+	PreferencesConfigMapPrefix = "kubeapps-user-preferences-"
+
+	// PreferencesLabel is the label identifying the ConfigMaps which store
+	// the preferences of a user.
+	PreferencesLabel = "kubeapps.dev/user-preferences"
+
+	// preferencesKey is the ConfigMap key under which the preferences are
+	// stored.
+	preferencesKey = "preferences"
+
+	// maxClusterLength and maxNamespaceLength bound the default cluster and
+	// namespace, which are otherwise stored as sent by the user: the
+	// namespace is a DNS label and the cluster name a DNS subdomain at most.
+	maxClusterLength   = 253
+	maxNamespaceLength = 63
+)
+
+// preferencesServer implements the API defined in proto/kubeappsapis/core/preferences/v1alpha1/preferences.proto
+type preferencesServer struct {
+	preferences.UnimplementedPreferencesServiceServer
+
+	// clientSet identifies the calling user and keeps their preferences
+	// ConfigMap, which the dashboard never reads directly.
+	clientSet kubernetes.Interface
+
+	// namespace in which the preferences ConfigMaps are stored.
+	namespace string
+}
+
+func NewPreferencesServer(clientSet kubernetes.Interface, namespace string) (*preferencesServer, error) {
+	if namespace == "" {
+		return nil, fmt.Errorf("a namespace is required to store the user preferences")
+	}
+	return &preferencesServer{
+		clientSet: clientSet,
+		namespace: namespace,
+	}, nil
+}
+
+// GetUserPreferences returns the preferences stored for the calling user.
+func (s *preferencesServer) GetUserPreferences(ctx context.Context, request *connect.Request[preferences.GetUserPreferencesRequest]) (*connect.Response[preferences.GetUserPreferencesResponse], error) {
+	log.InfoS("+core GetUserPreferences")
+
+	key, err := authn.UserKey(ctx, s.clientSet, request.Header())
+	if err != nil {
+		return nil, err
+	}
+
+	cm, err := s.clientSet.CoreV1().ConfigMaps(s.namespace).Get(ctx, configMapName(key), metav1.GetOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to get the user preferences: %w", err))
+	}
+
+	userPreferences := &preferences.UserPreferences{}
+	if cm != nil && cm.Data[preferencesKey] != "" {
+		if err := protojson.Unmarshal([]byte(cm.Data[preferencesKey]), userPreferences); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to parse the stored user preferences: %w", err))
+		}
+	}
+
+	return connect.NewResponse(&preferences.GetUserPreferencesResponse{
+		Preferences: userPreferences,
+	}), nil
+}
+
+// UpdateUserPreferences replaces the preferences stored for the calling user.
+func (s *preferencesServer) UpdateUserPreferences(ctx context.Context, request *connect.Request[preferences.UpdateUserPreferencesRequest]) (*connect.Response[preferences.UpdateUserPreferencesResponse], error) {
+	log.InfoS("+core UpdateUserPreferences")
+
+	if err := validatePreferences(request.Msg.GetPreferences()); err != nil {
+		return nil, err
+	}
+
+	key, err := authn.UserKey(ctx, s.clientSet, request.Header())
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := protojson.Marshal(request.Msg.GetPreferences())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to serialize the user preferences: %w", err))
+	}
+
+	name := configMapName(key)
+	err = storage.Update(ctx, s.clientSet.CoreV1().ConfigMaps(s.namespace), "configmaps", name, func(cm *corev1.ConfigMap) (*corev1.ConfigMap, error) {
+		if cm == nil {
+			cm = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: s.namespace,
+					Labels:    map[string]string{PreferencesLabel: "true"},
+				},
+			}
+		}
+		cm.Data = map[string]string{preferencesKey: string(value)}
+		return cm, nil
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to store the user preferences: %w", err))
+	}
+
+	return connect.NewResponse(&preferences.UpdateUserPreferencesResponse{
+		Preferences: request.Msg.GetPreferences(),
+	}), nil
+}
+
+// configMapName returns the name of the ConfigMap storing the preferences of
+// the user, identified by their key.
+func configMapName(user string) string {
+	return PreferencesConfigMapPrefix + user
+}
+
+func validatePreferences(userPreferences *preferences.UserPreferences) error {
+	if userPreferences == nil {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Unable to update the user preferences (missing request.Preferences)"))
+	}
+	if len(userPreferences.GetDefaultCluster()) > maxClusterLength {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Unable to update the user preferences: the default cluster is longer than %d characters", maxClusterLength))
+	}
+	if len(userPreferences.GetDefaultNamespace()) > maxNamespaceLength {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Unable to update the user preferences: the default namespace is longer than %d characters", maxNamespaceLength))
+	}
+	return nil
+}
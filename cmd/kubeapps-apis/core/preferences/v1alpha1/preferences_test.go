@@ -0,0 +1,202 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	preferences "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/preferences/v1alpha1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+var ignoreUnexportedPreferencesOpts = cmpopts.IgnoreUnexported(
+	preferences.GetUserPreferencesResponse{},
+	preferences.UpdateUserPreferencesResponse{},
+	preferences.UserPreferences{},
+)
+
+// newTestPreferencesServer returns a server whose token reviews authenticate
+// the tokens in the users map as the corresponding username.
+func newTestPreferencesServer(t *testing.T, users map[string]string) (*preferencesServer, *fake.Clientset) {
+	clientSet := fake.NewSimpleClientset()
+	clientSet.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (handled bool, ret runtime.Object, err error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authenticationv1.TokenReview)
+		if username, ok := users[review.Spec.Token]; ok {
+			review.Status.Authenticated = true
+			review.Status.User.Username = username
+		} else {
+			review.Status.Error = "invalid token"
+		}
+		return true, review, nil
+	})
+	server, err := NewPreferencesServer(clientSet, "kubeapps")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	return server, clientSet
+}
+
+func newRequestWithToken[T any](msg *T, token string) *connect.Request[T] {
+	request := connect.NewRequest(msg)
+	if token != "" {
+		request.Header().Set("Authorization", "Bearer "+token)
+	}
+	return request
+}
+
+func TestGetUserPreferences(t *testing.T) {
+	users := map[string]string{"token-a": "user-a", "token-b": "user-b"}
+	// Store the preferences of user-a through the server so that the key
+	// matches the one computed from the username.
+	server, _ := newTestPreferencesServer(t, users)
+	_, err := server.UpdateUserPreferences(context.Background(), newRequestWithToken(&preferences.UpdateUserPreferencesRequest{
+		Preferences: &preferences.UserPreferences{
+			DefaultCluster:   "default",
+			DefaultNamespace: "team-a",
+			CatalogLayout:    preferences.CatalogLayout_CATALOG_LAYOUT_LIST,
+			Theme:            preferences.Theme_THEME_DARK,
+		},
+	}, "token-a"))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	testCases := []struct {
+		name             string
+		server           *preferencesServer
+		token            string
+		expectedResponse *preferences.GetUserPreferencesResponse
+		errorCode        connect.Code
+	}{
+		{
+			name:   "returns the stored preferences of the user",
+			server: server,
+			token:  "token-a",
+			expectedResponse: &preferences.GetUserPreferencesResponse{
+				Preferences: &preferences.UserPreferences{
+					DefaultCluster:   "default",
+					DefaultNamespace: "team-a",
+					CatalogLayout:    preferences.CatalogLayout_CATALOG_LAYOUT_LIST,
+					Theme:            preferences.Theme_THEME_DARK,
+				},
+			},
+		},
+		{
+			name:   "returns empty preferences for a user without stored preferences",
+			server: server,
+			token:  "token-b",
+			expectedResponse: &preferences.GetUserPreferencesResponse{
+				Preferences: &preferences.UserPreferences{},
+			},
+		},
+		{
+			name: "returns empty preferences when the configmap does not exist yet",
+			server: func() *preferencesServer {
+				s, _ := newTestPreferencesServer(t, users)
+				return s
+			}(),
+			token: "token-a",
+			expectedResponse: &preferences.GetUserPreferencesResponse{
+				Preferences: &preferences.UserPreferences{},
+			},
+		},
+		{
+			name:      "returns unauthenticated if the token is missing",
+			server:    server,
+			errorCode: connect.CodeUnauthenticated,
+		},
+		{
+			name:      "returns unauthenticated if the token cannot be reviewed",
+			server:    server,
+			token:     "token-unknown",
+			errorCode: connect.CodeUnauthenticated,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			response, err := tc.server.GetUserPreferences(context.Background(), newRequestWithToken(&preferences.GetUserPreferencesRequest{}, tc.token))
+
+			if got, want := connect.CodeOf(err), tc.errorCode; err != nil && got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+			if tc.errorCode != 0 {
+				if err == nil {
+					t.Fatalf("got: nil, want: error with code %+v", tc.errorCode)
+				}
+				return
+			}
+
+			if got, want := response.Msg, tc.expectedResponse; !cmp.Equal(got, want, ignoreUnexportedPreferencesOpts) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexportedPreferencesOpts))
+			}
+		})
+	}
+}
+
+func TestUpdateUserPreferences(t *testing.T) {
+	users := map[string]string{"token-a": "user-a", "token-b": "user-b"}
+	server, clientSet := newTestPreferencesServer(t, users)
+	ctx := context.Background()
+
+	update := func(token string, prefs *preferences.UserPreferences) error {
+		_, err := server.UpdateUserPreferences(ctx, newRequestWithToken(&preferences.UpdateUserPreferencesRequest{Preferences: prefs}, token))
+		return err
+	}
+
+	// The configmap is created on the first update and updated afterwards.
+	if err := update("token-a", &preferences.UserPreferences{DefaultNamespace: "team-a"}); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if err := update("token-b", &preferences.UserPreferences{DefaultNamespace: "team-b"}); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if err := update("token-a", &preferences.UserPreferences{DefaultCluster: "other", DefaultNamespace: "team-a2"}); err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	// Each user has their own ConfigMap.
+	cms, err := clientSet.CoreV1().ConfigMaps("kubeapps").List(ctx, metav1.ListOptions{LabelSelector: PreferencesLabel})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if got, want := len(cms.Items), 2; got != want {
+		t.Errorf("got: %d, want: %d", got, want)
+	}
+
+	for token, want := range map[string]*preferences.UserPreferences{
+		"token-a": {DefaultCluster: "other", DefaultNamespace: "team-a2"},
+		"token-b": {DefaultNamespace: "team-b"},
+	} {
+		response, err := server.GetUserPreferences(ctx, newRequestWithToken(&preferences.GetUserPreferencesRequest{}, token))
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		if got := response.Msg.Preferences; !cmp.Equal(got, want, ignoreUnexportedPreferencesOpts) {
+			t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexportedPreferencesOpts))
+		}
+	}
+
+	if got, want := connect.CodeOf(update("token-a", nil)), connect.CodeInvalidArgument; got != want {
+		t.Errorf("got: %+v, want: %+v", got, want)
+	}
+	if got, want := connect.CodeOf(update("token-a", &preferences.UserPreferences{DefaultCluster: strings.Repeat("c", maxClusterLength+1)})), connect.CodeInvalidArgument; got != want {
+		t.Errorf("got: %+v, want: %+v", got, want)
+	}
+	if got, want := connect.CodeOf(update("token-a", &preferences.UserPreferences{DefaultNamespace: strings.Repeat("n", maxNamespaceLength+1)})), connect.CodeInvalidArgument; got != want {
+		t.Errorf("got: %+v, want: %+v", got, want)
+	}
+	if got, want := connect.CodeOf(update("", &preferences.UserPreferences{})), connect.CodeUnauthenticated; got != want {
+		t.Errorf("got: %+v, want: %+v", got, want)
+	}
+}
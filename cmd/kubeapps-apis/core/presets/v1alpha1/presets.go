@@ -0,0 +1,226 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core/pins"
+	packages "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1"
+	packagesconnect "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1/v1connect"
+	presets "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/presets/v1alpha1"
+	"google.golang.org/protobuf/encoding/protojson"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	log "k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// InstallPresetLabel is the label identifying the ConfigMaps, in the
+	// Kubeapps namespace, which store an install preset.
+	InstallPresetLabel = "kubeapps.dev/install-preset"
+
+	// InstallPresetDataKey is the ConfigMap key holding the install preset,
+	// serialized as JSON.
+	InstallPresetDataKey = "preset"
+)
+
+// presetsServer implements the API defined in proto/kubeappsapis/core/presets/v1alpha1/presets.proto
+type presetsServer struct {
+	presets.UnimplementedPresetsServiceServer
+
+	// clientSet lists the ConfigMaps labelled as install presets, which the
+	// operators manage and the users only see through this server.
+	clientSet kubernetes.Interface
+
+	// namespace in which the install presets are stored.
+	namespace string
+
+	// packagesServer is the core.packages.v1 server to which the installs
+	// are forwarded, with the credentials of the calling user.
+	packagesServer packagesconnect.PackagesServiceHandler
+
+	// pins checks the installs against the package pins, as they are
+	// forwarded to the packages server without being intercepted.
+	pins *pins.Checker
+}
+
+func NewPresetsServer(clientSet kubernetes.Interface, namespace string, packagesServer packagesconnect.PackagesServiceHandler, pinsChecker *pins.Checker) (*presetsServer, error) {
+	if namespace == "" {
+		return nil, fmt.Errorf("a namespace is required to read the install presets")
+	}
+	return &presetsServer{
+		clientSet:      clientSet,
+		namespace:      namespace,
+		packagesServer: packagesServer,
+		pins:           pinsChecker,
+	}, nil
+}
+
+// GetInstallPresets returns the install presets stored by the administrators.
+func (s *presetsServer) GetInstallPresets(ctx context.Context, request *connect.Request[presets.GetInstallPresetsRequest]) (*connect.Response[presets.GetInstallPresetsResponse], error) {
+	log.InfoS("+core GetInstallPresets")
+
+	cms, err := s.clientSet.CoreV1().ConfigMaps(s.namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: InstallPresetLabel,
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to list the install presets: %w", err))
+	}
+
+	installPresets := []*presets.InstallPreset{}
+	for i := range cms.Items {
+		preset, err := presetFromConfigMap(&cms.Items[i])
+		if err != nil {
+			// A single invalid preset should not hide the others.
+			log.Errorf("Ignoring the install preset %q: %v", cms.Items[i].Name, err)
+			continue
+		}
+		installPresets = append(installPresets, preset)
+	}
+	sort.Slice(installPresets, func(i, j int) bool {
+		return installPresets[i].GetName() < installPresets[j].GetName()
+	})
+
+	return connect.NewResponse(&presets.GetInstallPresetsResponse{
+		Presets: installPresets,
+	}), nil
+}
+
+// CreateFromPreset installs the package of an install preset, layering the
+// values of the request on top of the default values of the preset.
+func (s *presetsServer) CreateFromPreset(ctx context.Context, request *connect.Request[presets.CreateFromPresetRequest]) (*connect.Response[presets.CreateFromPresetResponse], error) {
+	log.InfoS("+core CreateFromPreset", "preset", request.Msg.GetPresetName())
+
+	if request.Msg.GetPresetName() == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Unable to create from a preset (missing request.PresetName)"))
+	}
+	targetNamespace := request.Msg.GetTargetContext().GetNamespace()
+	if targetNamespace == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Unable to create from a preset (missing request.TargetContext.Namespace)"))
+	}
+
+	preset, err := s.getInstallPreset(ctx, request.Msg.GetPresetName())
+	if err != nil {
+		return nil, err
+	}
+
+	if !strings.HasPrefix(targetNamespace, preset.GetTargetNamespacePrefix()) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("The install preset %q can only be installed in namespaces starting with %q", preset.GetName(), preset.GetTargetNamespacePrefix()))
+	}
+
+	values, err := mergeValues(preset.GetValues(), request.Msg.GetValues())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	createRequest := connect.NewRequest(&packages.CreateInstalledPackageRequest{
+		AvailablePackageRef: preset.GetAvailablePackageRef(),
+		TargetContext:       request.Msg.GetTargetContext(),
+		Name:                request.Msg.GetName(),
+		PkgVersionReference: preset.GetPkgVersionReference(),
+		Values:              values,
+		CreateNamespace:     preset.GetCreateNamespace(),
+	})
+	if err := s.pins.CheckRequest(ctx, createRequest.Msg); err != nil {
+		return nil, err
+	}
+	// The package is installed with the credentials of the calling user.
+	for key, values := range request.Header() {
+		createRequest.Header()[key] = values
+	}
+
+	response, err := s.packagesServer.CreateInstalledPackage(ctx, createRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return connect.NewResponse(&presets.CreateFromPresetResponse{
+		InstalledPackageRef: response.Msg.GetInstalledPackageRef(),
+	}), nil
+}
+
+// getInstallPreset returns the install preset with the given name. ConfigMaps
+// without the install preset label are reported as not found, so that the
+// users cannot read other ConfigMaps of the Kubeapps namespace.
+func (s *presetsServer) getInstallPreset(ctx context.Context, name string) (*presets.InstallPreset, error) {
+	cm, err := s.clientSet.CoreV1().ConfigMaps(s.namespace).Get(ctx, name, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("Unable to find the install preset %q", name))
+	} else if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to get the install preset %q: %w", name, err))
+	}
+	if _, ok := cm.Labels[InstallPresetLabel]; !ok {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("Unable to find the install preset %q", name))
+	}
+
+	preset, err := presetFromConfigMap(cm)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("Invalid install preset %q: %w", name, err))
+	}
+	return preset, nil
+}
+
+// presetFromConfigMap returns the install preset stored in the ConfigMap,
+// named after the ConfigMap.
+func presetFromConfigMap(cm *corev1.ConfigMap) (*presets.InstallPreset, error) {
+	preset := &presets.InstallPreset{}
+	if err := protojson.Unmarshal([]byte(cm.Data[InstallPresetDataKey]), preset); err != nil {
+		return nil, fmt.Errorf("unable to parse the %q key: %w", InstallPresetDataKey, err)
+	}
+	if preset.GetAvailablePackageRef().GetIdentifier() == "" || preset.GetAvailablePackageRef().GetPlugin() == nil {
+		return nil, fmt.Errorf("the preset must reference an available package and its plugin")
+	}
+	preset.Name = cm.Name
+	return preset, nil
+}
+
+// mergeValues returns the default values overridden by the given values, both
+// in YAML or JSON. Objects are merged recursively, while any other value
+// replaces the default one.
+func mergeValues(defaults, overrides string) (string, error) {
+	if overrides == "" {
+		return defaults, nil
+	}
+	if defaults == "" {
+		return overrides, nil
+	}
+	defaultValues := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(defaults), &defaultValues); err != nil {
+		return "", fmt.Errorf("unable to parse the values of the preset: %w", err)
+	}
+	overrideValues := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(overrides), &overrideValues); err != nil {
+		return "", fmt.Errorf("unable to parse the values: %w", err)
+	}
+	merged, err := yaml.Marshal(mergeMaps(defaultValues, overrideValues))
+	if err != nil {
+		return "", fmt.Errorf("unable to serialize the values: %w", err)
+	}
+	return string(merged), nil
+}
+
+func mergeMaps(defaults, overrides map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(defaults))
+	for key, value := range defaults {
+		merged[key] = value
+	}
+	for key, value := range overrides {
+		defaultMap, defaultIsMap := merged[key].(map[string]interface{})
+		overrideMap, overrideIsMap := value.(map[string]interface{})
+		if defaultIsMap && overrideIsMap {
+			merged[key] = mergeMaps(defaultMap, overrideMap)
+		} else {
+			merged[key] = value
+		}
+	}
+	return merged
+}
@@ -0,0 +1,260 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/google/go-cmp/cmp"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core/pins"
+	packages "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1"
+	packagesconnect "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1/v1connect"
+	plugins "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/plugins/v1alpha1"
+	presets "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/presets/v1alpha1"
+	"google.golang.org/protobuf/testing/protocmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const postgresqlPreset = `{
+	"description": "PostgreSQL with the team defaults",
+	"availablePackageRef": {
+		"context": {"cluster": "default", "namespace": "kubeapps"},
+		"identifier": "bitnami/postgresql",
+		"plugin": {"name": "helm.packages", "version": "v1alpha1"}
+	},
+	"pkgVersionReference": {"version": "12.1.2"},
+	"values": "primary:\n  persistence:\n    size: 20Gi\nauth:\n  database: app\n",
+	"targetNamespacePrefix": "team-",
+	"createNamespace": true
+}`
+
+// fakePackagesServer records the CreateInstalledPackage requests it receives.
+type fakePackagesServer struct {
+	packagesconnect.UnimplementedPackagesServiceHandler
+
+	request *connect.Request[packages.CreateInstalledPackageRequest]
+}
+
+func (s *fakePackagesServer) CreateInstalledPackage(ctx context.Context, request *connect.Request[packages.CreateInstalledPackageRequest]) (*connect.Response[packages.CreateInstalledPackageResponse], error) {
+	s.request = request
+	return connect.NewResponse(&packages.CreateInstalledPackageResponse{
+		InstalledPackageRef: &packages.InstalledPackageReference{
+			Context:    request.Msg.GetTargetContext(),
+			Identifier: request.Msg.GetName(),
+			Plugin:     request.Msg.GetAvailablePackageRef().GetPlugin(),
+		},
+	}), nil
+}
+
+func presetConfigMap(name, preset string, labelled bool) *corev1.ConfigMap {
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "kubeapps",
+		},
+		Data: map[string]string{InstallPresetDataKey: preset},
+	}
+	if labelled {
+		cm.Labels = map[string]string{InstallPresetLabel: "true"}
+	}
+	return cm
+}
+
+func newTestPresetsServer(t *testing.T, objects ...runtime.Object) (*presetsServer, *fakePackagesServer) {
+	packagesServer := &fakePackagesServer{}
+	clientSet := fake.NewSimpleClientset(objects...)
+	checker, err := pins.NewChecker(clientSet, "kubeapps")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	server, err := NewPresetsServer(clientSet, "kubeapps", packagesServer, checker)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	return server, packagesServer
+}
+
+func TestGetInstallPresets(t *testing.T) {
+	server, _ := newTestPresetsServer(t,
+		presetConfigMap("team-redis", `{"availablePackageRef": {"identifier": "bitnami/redis", "plugin": {"name": "helm.packages", "version": "v1alpha1"}}}`, true),
+		presetConfigMap("team-postgresql", postgresqlPreset, true),
+		presetConfigMap("invalid", `{"values": 1}`, true),
+		presetConfigMap("other-configmap", postgresqlPreset, false),
+	)
+
+	response, err := server.GetInstallPresets(context.Background(), connect.NewRequest(&presets.GetInstallPresetsRequest{}))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	expected := &presets.GetInstallPresetsResponse{
+		Presets: []*presets.InstallPreset{
+			{
+				Name:        "team-postgresql",
+				Description: "PostgreSQL with the team defaults",
+				AvailablePackageRef: &packages.AvailablePackageReference{
+					Context:    &packages.Context{Cluster: "default", Namespace: "kubeapps"},
+					Identifier: "bitnami/postgresql",
+					Plugin:     &plugins.Plugin{Name: "helm.packages", Version: "v1alpha1"},
+				},
+				PkgVersionReference:   &packages.VersionReference{Version: "12.1.2"},
+				Values:                "primary:\n  persistence:\n    size: 20Gi\nauth:\n  database: app\n",
+				TargetNamespacePrefix: "team-",
+				CreateNamespace:       true,
+			},
+			{
+				Name: "team-redis",
+				AvailablePackageRef: &packages.AvailablePackageReference{
+					Identifier: "bitnami/redis",
+					Plugin:     &plugins.Plugin{Name: "helm.packages", Version: "v1alpha1"},
+				},
+			},
+		},
+	}
+	if got, want := response.Msg, expected; !cmp.Equal(got, want, protocmp.Transform()) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, protocmp.Transform()))
+	}
+}
+
+func TestCreateFromPreset(t *testing.T) {
+	testCases := []struct {
+		name            string
+		request         *presets.CreateFromPresetRequest
+		expectedRequest *packages.CreateInstalledPackageRequest
+		errorCode       connect.Code
+	}{
+		{
+			name: "it installs the package of the preset with the merged values",
+			request: &presets.CreateFromPresetRequest{
+				PresetName:    "team-postgresql",
+				TargetContext: &packages.Context{Cluster: "default", Namespace: "team-a"},
+				Name:          "team-a-db",
+				Values:        "auth:\n  database: orders\n",
+			},
+			expectedRequest: &packages.CreateInstalledPackageRequest{
+				AvailablePackageRef: &packages.AvailablePackageReference{
+					Context:    &packages.Context{Cluster: "default", Namespace: "kubeapps"},
+					Identifier: "bitnami/postgresql",
+					Plugin:     &plugins.Plugin{Name: "helm.packages", Version: "v1alpha1"},
+				},
+				TargetContext:       &packages.Context{Cluster: "default", Namespace: "team-a"},
+				Name:                "team-a-db",
+				PkgVersionReference: &packages.VersionReference{Version: "12.1.2"},
+				Values:              "auth:\n  database: orders\nprimary:\n  persistence:\n    size: 20Gi\n",
+				CreateNamespace:     true,
+			},
+		},
+		{
+			name: "it installs the package with the default values of the preset",
+			request: &presets.CreateFromPresetRequest{
+				PresetName:    "team-postgresql",
+				TargetContext: &packages.Context{Cluster: "default", Namespace: "team-a"},
+				Name:          "team-a-db",
+			},
+			expectedRequest: &packages.CreateInstalledPackageRequest{
+				AvailablePackageRef: &packages.AvailablePackageReference{
+					Context:    &packages.Context{Cluster: "default", Namespace: "kubeapps"},
+					Identifier: "bitnami/postgresql",
+					Plugin:     &plugins.Plugin{Name: "helm.packages", Version: "v1alpha1"},
+				},
+				TargetContext:       &packages.Context{Cluster: "default", Namespace: "team-a"},
+				Name:                "team-a-db",
+				PkgVersionReference: &packages.VersionReference{Version: "12.1.2"},
+				Values:              "primary:\n  persistence:\n    size: 20Gi\nauth:\n  database: app\n",
+				CreateNamespace:     true,
+			},
+		},
+		{
+			name: "it returns invalid argument for a namespace not following the preset conventions",
+			request: &presets.CreateFromPresetRequest{
+				PresetName:    "team-postgresql",
+				TargetContext: &packages.Context{Cluster: "default", Namespace: "kube-system"},
+				Name:          "db",
+			},
+			errorCode: connect.CodeInvalidArgument,
+		},
+		{
+			name: "it returns failed precondition if a package pin rejects the version of the preset",
+			request: &presets.CreateFromPresetRequest{
+				PresetName:    "team-postgresql",
+				TargetContext: &packages.Context{Cluster: "default", Namespace: "team-pinned"},
+				Name:          "db",
+			},
+			errorCode: connect.CodeFailedPrecondition,
+		},
+		{
+			name: "it returns invalid argument without a target namespace",
+			request: &presets.CreateFromPresetRequest{
+				PresetName: "team-postgresql",
+				Name:       "db",
+			},
+			errorCode: connect.CodeInvalidArgument,
+		},
+		{
+			name: "it returns not found for an unknown preset",
+			request: &presets.CreateFromPresetRequest{
+				PresetName:    "unknown",
+				TargetContext: &packages.Context{Cluster: "default", Namespace: "team-a"},
+			},
+			errorCode: connect.CodeNotFound,
+		},
+		{
+			name: "it returns not found for a ConfigMap which is not a preset",
+			request: &presets.CreateFromPresetRequest{
+				PresetName:    "other-configmap",
+				TargetContext: &packages.Context{Cluster: "default", Namespace: "team-a"},
+			},
+			errorCode: connect.CodeNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server, packagesServer := newTestPresetsServer(t,
+				presetConfigMap("team-postgresql", postgresqlPreset, true),
+				presetConfigMap("other-configmap", postgresqlPreset, false),
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "pinned-databases",
+						Namespace: "kubeapps",
+						Labels:    map[string]string{pins.PackagePinLabel: ""},
+					},
+					Data: map[string]string{pins.PackagePinDataKey: `{"namespace": "team-pinned", "name": "*", "versionConstraint": "^13.0.0"}`},
+				},
+			)
+			request := connect.NewRequest(tc.request)
+			request.Header().Set("Authorization", "Bearer token-a")
+
+			response, err := server.CreateFromPreset(context.Background(), request)
+
+			if got, want := connect.CodeOf(err), tc.errorCode; err != nil && got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+			if tc.errorCode != 0 {
+				if err == nil {
+					t.Fatalf("got: nil, want: error with code %+v", tc.errorCode)
+				}
+				if packagesServer.request != nil {
+					t.Errorf("got: %+v, want: no install", packagesServer.request.Msg)
+				}
+				return
+			}
+
+			if got, want := packagesServer.request.Msg, tc.expectedRequest; !cmp.Equal(got, want, protocmp.Transform()) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, protocmp.Transform()))
+			}
+			if got, want := packagesServer.request.Header().Get("Authorization"), "Bearer token-a"; got != want {
+				t.Errorf("got: %q, want: %q", got, want)
+			}
+			if got, want := response.Msg.GetInstalledPackageRef().GetIdentifier(), tc.request.GetName(); got != want {
+				t.Errorf("got: %q, want: %q", got, want)
+			}
+		})
+	}
+}
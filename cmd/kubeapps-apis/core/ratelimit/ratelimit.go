@@ -0,0 +1,238 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package ratelimit limits the rate and the concurrency of the requests made
+// to the kubeapps-apis service by each user, so that a single client cannot
+// overload the Kubernetes API servers.
+package ratelimit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core/authn"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core/metrics"
+	"golang.org/x/time/rate"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"k8s.io/apimachinery/pkg/util/cache"
+	"k8s.io/client-go/kubernetes"
+	log "k8s.io/klog/v2"
+)
+
+const (
+	// ReasonRate is the throttling reason of the requests exceeding the rate
+	// of requests of the user.
+	ReasonRate = "rate"
+	// ReasonConcurrency is the throttling reason of the requests exceeding the
+	// number of concurrent requests of the user.
+	ReasonConcurrency = "concurrency"
+
+	// concurrencyRetryDelay is the delay after which a request rejected for
+	// exceeding the concurrency is suggested to be retried.
+	concurrencyRetryDelay = time.Second
+	// idleUserTimeout is the time after which the state of a user without
+	// requests is forgotten.
+	idleUserTimeout = 10 * time.Minute
+	// reviewedTokensCacheSize is the maximum number of tokens whose user is
+	// cached, so that the tokens are not reviewed for every request.
+	reviewedTokensCacheSize = 10000
+	// reviewedTokenTTL is the time during which the user of a reviewed token
+	// is cached.
+	reviewedTokenTTL = time.Minute
+)
+
+// Options configure the limits applied to the requests of each user. A limit
+// is disabled if 0.
+type Options struct {
+	// RequestsPerSecond is the sustained rate of requests allowed.
+	RequestsPerSecond float64
+	// Burst is the number of requests allowed at once above the sustained
+	// rate. It defaults to the rate, rounded up, if 0.
+	Burst int
+	// MaxConcurrent is the maximum number of requests being handled at once.
+	MaxConcurrent int
+}
+
+// Enabled returns whether any limit is configured.
+func (o Options) Enabled() bool {
+	return o.RequestsPerSecond > 0 || o.MaxConcurrent > 0
+}
+
+// userState is the rate limiter and the number of requests being handled for
+// a user.
+type userState struct {
+	limiter  *rate.Limiter
+	inFlight int
+	lastSeen time.Time
+}
+
+// Limiter tracks the requests of each user against the configured limits.
+type Limiter struct {
+	options   Options
+	now       func() time.Time
+	mutex     sync.Mutex
+	users     map[string]*userState
+	lastSweep time.Time
+}
+
+// NewLimiter returns a limiter applying the given limits to each user.
+func NewLimiter(options Options) *Limiter {
+	if options.RequestsPerSecond > 0 && options.Burst <= 0 {
+		options.Burst = int(math.Ceil(options.RequestsPerSecond))
+	}
+	return &Limiter{
+		options: options,
+		now:     time.Now,
+		users:   map[string]*userState{},
+	}
+}
+
+// Acquire reserves a request for the user, returning the function to call
+// once the request is handled. If the request exceeds one of the limits, the
+// throttling reason and the delay after which it can be retried are returned
+// instead.
+func (l *Limiter) Acquire(user string) (release func(), reason string, retryAfter time.Duration) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+	state, ok := l.users[user]
+	if !ok {
+		state = &userState{}
+		if l.options.RequestsPerSecond > 0 {
+			state.limiter = rate.NewLimiter(rate.Limit(l.options.RequestsPerSecond), l.options.Burst)
+		}
+		l.users[user] = state
+	}
+	state.lastSeen = now
+
+	if l.options.MaxConcurrent > 0 && state.inFlight >= l.options.MaxConcurrent {
+		return nil, ReasonConcurrency, concurrencyRetryDelay
+	}
+	if state.limiter != nil {
+		reservation := state.limiter.ReserveN(now, 1)
+		if delay := reservation.DelayFrom(now); delay > 0 {
+			reservation.CancelAt(now)
+			return nil, ReasonRate, delay
+		}
+	}
+
+	state.inFlight++
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			l.mutex.Lock()
+			defer l.mutex.Unlock()
+			state.inFlight--
+		})
+	}, "", 0
+}
+
+// sweep forgets the users without requests for a while, at most once per idle
+// timeout.
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < idleUserTimeout {
+		return
+	}
+	l.lastSweep = now
+	for user, state := range l.users {
+		if state.inFlight == 0 && now.Sub(state.lastSeen) >= idleUserTimeout {
+			delete(l.users, user)
+		}
+	}
+}
+
+// UserResolver returns the key identifying the user authenticated by the token
+// of a request.
+type UserResolver func(ctx context.Context, headers http.Header) (string, error)
+
+// NewTokenReviewUserResolver returns a resolver identifying the user with
+// authn.UserKey, using the given client. The users of the reviewed tokens are
+// cached for a while, in a cache of bounded size, since reviewing the token
+// of every request would itself load the API server.
+func NewTokenReviewUserResolver(clientSet kubernetes.Interface) UserResolver {
+	reviewedTokens := cache.NewLRUExpireCache(reviewedTokensCacheSize)
+	return func(ctx context.Context, headers http.Header) (string, error) {
+		token, err := authn.ExtractToken(headers)
+		if err != nil {
+			return "", err
+		}
+		sum := sha256.Sum256([]byte(token))
+		tokenHash := hex.EncodeToString(sum[:])
+		if user, ok := reviewedTokens.Get(tokenHash); ok {
+			return user.(string), nil
+		}
+		user, err := authn.UserKey(ctx, clientSet, headers)
+		if err != nil {
+			return "", err
+		}
+		reviewedTokens.Add(tokenHash, user, reviewedTokenTTL)
+		return user, nil
+	}
+}
+
+// NewInterceptor returns a connect interceptor rejecting the unary RPCs which
+// exceed the limits of their user with a ResourceExhausted error, carrying the
+// delay after which the request can be retried both as a RetryInfo detail and
+// a Retry-After header.
+func NewInterceptor(limiter *Limiter, resolveUser UserResolver) connect.Interceptor {
+	return connect.UnaryInterceptorFunc(func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			user := userKey(ctx, req, resolveUser)
+			release, reason, retryAfter := limiter.Acquire(user)
+			if release == nil {
+				metrics.ObserveThrottledRequest(req, reason)
+				log.V(4).Infof("Throttled %s (%s) for %s", req.Spec().Procedure, reason, retryAfter)
+				return nil, throttledError(reason, retryAfter)
+			}
+			defer release()
+			return next(ctx, req)
+		}
+	})
+}
+
+// throttledError returns the ResourceExhausted error for a request throttled
+// for the given reason.
+func throttledError(reason string, retryAfter time.Duration) error {
+	message := "Too many requests, please retry later"
+	if reason == ReasonConcurrency {
+		message = "Too many concurrent requests, please retry later"
+	}
+	connectErr := connect.NewError(connect.CodeResourceExhausted, fmt.Errorf("%s", message))
+	if detail, err := connect.NewErrorDetail(&errdetails.RetryInfo{RetryDelay: durationpb.New(retryAfter)}); err == nil {
+		connectErr.AddDetail(detail)
+	}
+	connectErr.Meta().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	return connectErr
+}
+
+// userKey returns the key identifying the user making the request: the user
+// authenticated by its token, or the address of the peer for the requests
+// without a valid token. Keying on the validated user, rather than on the
+// token, bounds the number of limiters to the number of actual users and
+// peers, and shares the limits of a user across their tokens.
+func userKey(ctx context.Context, req connect.AnyRequest, resolveUser UserResolver) string {
+	if req.Header().Get("Authorization") != "" {
+		user, err := resolveUser(ctx, req.Header())
+		if err == nil {
+			return "user:" + user
+		}
+		log.V(4).Infof("Unable to identify the user of %s, limiting it by peer: %v", req.Spec().Procedure, err)
+	}
+	addr := req.Peer().Addr
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		addr = host
+	}
+	return "peer:" + addr
+}
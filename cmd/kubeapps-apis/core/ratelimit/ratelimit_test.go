@@ -0,0 +1,220 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/bufbuild/connect-go"
+	packages "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	typfake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// fakeUserResolver resolves the tokens of jane and john, rejecting the others.
+func fakeUserResolver(ctx context.Context, headers http.Header) (string, error) {
+	switch headers.Get("Authorization") {
+	case "Bearer jane-token", "Bearer jane-token-2":
+		return "jane", nil
+	case "Bearer john-token":
+		return "john", nil
+	default:
+		return "", errors.New("invalid token")
+	}
+}
+
+func TestLimiterAcquire(t *testing.T) {
+	start := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("it limits the rate of requests of each user", func(t *testing.T) {
+		limiter := NewLimiter(Options{RequestsPerSecond: 2, Burst: 2})
+		limiter.now = func() time.Time { return start }
+
+		for i := 0; i < 2; i++ {
+			release, reason, _ := limiter.Acquire("jane")
+			if release == nil {
+				t.Fatalf("got: throttled (%s), want: request %d allowed", reason, i)
+			}
+			release()
+		}
+		release, reason, retryAfter := limiter.Acquire("jane")
+		if release != nil || reason != ReasonRate {
+			t.Fatalf("got: %q, want: %q", reason, ReasonRate)
+		}
+		if got, want := retryAfter, 500*time.Millisecond; got != want {
+			t.Errorf("got: %s, want: %s", got, want)
+		}
+
+		// other users are not affected
+		if release, reason, _ := limiter.Acquire("john"); release == nil {
+			t.Fatalf("got: throttled (%s), want: request allowed", reason)
+		}
+
+		// the requests are allowed again once the tokens are replenished
+		limiter.now = func() time.Time { return start.Add(retryAfter) }
+		if release, reason, _ := limiter.Acquire("jane"); release == nil {
+			t.Fatalf("got: throttled (%s), want: request allowed", reason)
+		}
+	})
+
+	t.Run("it limits the concurrent requests of each user", func(t *testing.T) {
+		limiter := NewLimiter(Options{MaxConcurrent: 2})
+
+		first, _, _ := limiter.Acquire("jane")
+		second, _, _ := limiter.Acquire("jane")
+		if first == nil || second == nil {
+			t.Fatalf("got: throttled, want: requests allowed")
+		}
+		if release, reason, _ := limiter.Acquire("jane"); release != nil || reason != ReasonConcurrency {
+			t.Fatalf("got: %q, want: %q", reason, ReasonConcurrency)
+		}
+
+		// releasing twice must not free two slots
+		first()
+		first()
+		if release, reason, _ := limiter.Acquire("jane"); release == nil {
+			t.Fatalf("got: throttled (%s), want: request allowed", reason)
+		}
+		if release, reason, _ := limiter.Acquire("jane"); release != nil || reason != ReasonConcurrency {
+			t.Fatalf("got: %q, want: %q", reason, ReasonConcurrency)
+		}
+	})
+
+	t.Run("it forgets the idle users", func(t *testing.T) {
+		limiter := NewLimiter(Options{RequestsPerSecond: 1})
+		limiter.now = func() time.Time { return start }
+		release, _, _ := limiter.Acquire("jane")
+		release()
+
+		limiter.now = func() time.Time { return start.Add(idleUserTimeout) }
+		if _, _, _ = limiter.Acquire("john"); len(limiter.users) != 1 {
+			t.Errorf("got: %d users, want: 1", len(limiter.users))
+		}
+	})
+}
+
+func TestInterceptor(t *testing.T) {
+	limiter := NewLimiter(Options{MaxConcurrent: 1})
+	blocked := make(chan struct{})
+	handled := make(chan struct{}, 2)
+	handler := NewInterceptor(limiter, fakeUserResolver).WrapUnary(func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		handled <- struct{}{}
+		<-blocked
+		return nil, nil
+	})
+	newRequest := func(token string) connect.AnyRequest {
+		req := connect.NewRequest(&packages.GetAvailablePackageSummariesRequest{})
+		req.Header().Set("Authorization", "Bearer "+token)
+		return req
+	}
+
+	done := make(chan error)
+	go func() {
+		_, err := handler(context.Background(), newRequest("jane-token"))
+		done <- err
+	}()
+	<-handled
+
+	_, err := handler(context.Background(), newRequest("jane-token"))
+	if got, want := connect.CodeOf(err), connect.CodeResourceExhausted; got != want {
+		t.Fatalf("got: %s, want: %s, err: %+v", got, want, err)
+	}
+	var connectErr *connect.Error
+	if !errors.As(err, &connectErr) {
+		t.Fatalf("got: %T, want: *connect.Error", err)
+	}
+	if got, want := connectErr.Meta().Get("Retry-After"), "1"; got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+	if len(connectErr.Details()) != 1 {
+		t.Fatalf("got: %d details, want: 1", len(connectErr.Details()))
+	}
+	detail, err := connectErr.Details()[0].Value()
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if retryInfo, ok := detail.(*errdetails.RetryInfo); !ok || retryInfo.GetRetryDelay().AsDuration() != concurrencyRetryDelay {
+		t.Errorf("got: %+v, want: a retry delay of %s", detail, concurrencyRetryDelay)
+	}
+
+	close(blocked)
+	if err := <-done; err != nil {
+		t.Fatalf("%+v", err)
+	}
+	// the slot is released once the request is handled
+	if _, err := handler(context.Background(), newRequest("jane-token")); err != nil {
+		t.Fatalf("%+v", err)
+	}
+}
+
+func TestUserKey(t *testing.T) {
+	newRequest := func(authorization string) connect.AnyRequest {
+		req := connect.NewRequest(&packages.GetAvailablePackageSummariesRequest{})
+		if authorization != "" {
+			req.Header().Set("Authorization", authorization)
+		}
+		return req
+	}
+	keyOf := func(req connect.AnyRequest) string {
+		return userKey(context.Background(), req, fakeUserResolver)
+	}
+
+	if keyOf(newRequest("Bearer jane-token")) != keyOf(newRequest("Bearer jane-token-2")) {
+		t.Errorf("got: different keys, want: the same key for the tokens of the same user")
+	}
+	if keyOf(newRequest("Bearer jane-token")) == keyOf(newRequest("Bearer john-token")) {
+		t.Errorf("got: the same key, want: different keys for different users")
+	}
+	if got, want := keyOf(newRequest("Bearer forged-token")), "peer:"; got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+	if got, want := keyOf(newRequest("")), "peer:"; got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+}
+
+func TestTokenReviewUserResolver(t *testing.T) {
+	clientSet := typfake.NewSimpleClientset()
+	reviews := 0
+	clientSet.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (handled bool, ret runtime.Object, err error) {
+		reviews++
+		review := action.(k8stesting.CreateAction).GetObject().(*authenticationv1.TokenReview)
+		if review.Spec.Token == "jane-token" {
+			review.Status.Authenticated = true
+			review.Status.User.Username = "jane"
+		}
+		return true, review, nil
+	})
+	resolveUser := NewTokenReviewUserResolver(clientSet)
+	newHeaders := func(token string) http.Header {
+		return http.Header{"Authorization": []string{"Bearer " + token}}
+	}
+
+	user, err := resolveUser(context.Background(), newHeaders("jane-token"))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	// the user of the token is then returned from the cache
+	cachedUser, err := resolveUser(context.Background(), newHeaders("jane-token"))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if cachedUser != user {
+		t.Errorf("got: %q, want: %q", cachedUser, user)
+	}
+	if got, want := reviews, 1; got != want {
+		t.Errorf("got: %d reviews, want: %d", got, want)
+	}
+
+	if _, err := resolveUser(context.Background(), newHeaders("forged-token")); connect.CodeOf(err) != connect.CodeUnauthenticated {
+		t.Errorf("got: %+v, want: an unauthenticated error", err)
+	}
+}
@@ -0,0 +1,416 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package repohealth periodically checks the reconciliation status of the
+// package repositories reconciled by kapp-controller, Flux and the Kubeapps
+// apprepository-controller in the cluster where Kubeapps is installed,
+// notifying a webhook when a repository starts failing, keeps failing or
+// recovers.
+package repohealth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	log "k8s.io/klog/v2"
+)
+
+// webhookTimeout is the maximum time spent sending a notification.
+const webhookTimeout = 5 * time.Second
+
+const (
+	// appRepositoryKind is the kind of the repositories synced by the Kubeapps
+	// apprepository-controller.
+	appRepositoryKind = "AppRepository"
+	// labelRepoName and labelRepoNamespace identify the AppRepository synced
+	// by the pods of a sync job.
+	labelRepoName      = "apprepositories.kubeapps.com/repo-name"
+	labelRepoNamespace = "apprepositories.kubeapps.com/repo-namespace"
+)
+
+var (
+	appRepositoryResource = schema.GroupVersionResource{Group: "kubeapps.com", Version: "v1alpha1", Resource: "apprepositories"}
+	jobResource           = schema.GroupVersionResource{Group: "batch", Version: "v1", Resource: "jobs"}
+)
+
+// Options configure the monitoring of the package repositories.
+type Options struct {
+	// Interval is the interval at which the repositories are checked.
+	Interval time.Duration
+	// FailingWindow is the time after which a repository still failing is
+	// notified again. The repositories are only notified when they start
+	// failing if 0.
+	FailingWindow time.Duration
+	// SyncJobsNamespace is the namespace where the apprepository-controller
+	// runs the jobs syncing the AppRepositories, from which their health is
+	// determined. The AppRepositories are not checked if empty.
+	SyncJobsNamespace string
+}
+
+// Health is the reconciliation health of a repository.
+type Health int
+
+const (
+	// HealthUnknown is the health of a repository being reconciled, or of
+	// which the status cannot be interpreted.
+	HealthUnknown Health = iota
+	HealthReady
+	HealthFailed
+)
+
+// RepositoryStatus is the reconciliation status of a repository.
+type RepositoryStatus struct {
+	Kind      string
+	Namespace string
+	Name      string
+	Health    Health
+	Message   string
+}
+
+func (s RepositoryStatus) key() string {
+	return fmt.Sprintf("%s/%s/%s", s.Kind, s.Namespace, s.Name)
+}
+
+// repositoryKind is a kind of repository reconciled in the cluster.
+type repositoryKind struct {
+	kind     string
+	resource schema.GroupVersionResource
+	health   func(obj *unstructured.Unstructured) (Health, string)
+}
+
+// repositoryKinds are the kinds of repositories checked. The kinds whose
+// CRD is not installed in the cluster are skipped.
+var repositoryKinds = []repositoryKind{
+	{
+		kind:     "PackageRepository",
+		resource: schema.GroupVersionResource{Group: "packaging.carvel.dev", Version: "v1alpha1", Resource: "packagerepositories"},
+		health:   kappControllerHealth,
+	},
+	{
+		kind:     "HelmRepository",
+		resource: schema.GroupVersionResource{Group: "source.toolkit.fluxcd.io", Version: "v1beta2", Resource: "helmrepositories"},
+		health:   fluxHealth,
+	},
+}
+
+// Notification is the message posted to the webhook, compatible with the
+// Slack incoming webhooks.
+type Notification struct {
+	Text string `json:"text"`
+}
+
+// Notifier sends the notifications.
+type Notifier interface {
+	Notify(ctx context.Context, notification Notification) error
+}
+
+type webhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookNotifier returns a notifier posting each notification as JSON to
+// the given URL.
+func NewWebhookNotifier(url string, client *http.Client) Notifier {
+	if client == nil {
+		client = &http.Client{Timeout: webhookTimeout}
+	}
+	return &webhookNotifier{url: url, client: client}
+}
+
+func (n *webhookNotifier) Notify(ctx context.Context, notification Notification) error {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status from the repository health webhook: %s", res.Status)
+	}
+	return nil
+}
+
+// failingRepository records since when a repository is failing and when it
+// was last notified. A repository is notified when it recovers only if its
+// failure was notified.
+type failingRepository struct {
+	since        time.Time
+	lastNotified time.Time
+}
+
+// Monitor checks the health of the repositories, notifying the transitions to
+// failed and back to ready, and the repositories failing beyond the failing
+// window.
+type Monitor struct {
+	client   dynamic.Interface
+	notifier Notifier
+	options  Options
+	now      func() time.Time
+	failing  map[string]*failingRepository
+}
+
+// NewMonitor returns a monitor listing the repositories with the given client,
+// which is expected to be allowed to list them in all the namespaces.
+func NewMonitor(client dynamic.Interface, notifier Notifier, options Options) *Monitor {
+	return &Monitor{
+		client:   client,
+		notifier: notifier,
+		options:  options,
+		now:      time.Now,
+		failing:  map[string]*failingRepository{},
+	}
+}
+
+// Run checks the repositories at the configured interval until the context is
+// done.
+func (m *Monitor) Run(ctx context.Context) {
+	log.Infof("Checking the health of the package repositories every %s", m.options.Interval)
+	ticker := time.NewTicker(m.options.Interval)
+	defer ticker.Stop()
+	for {
+		if err := m.Check(ctx); err != nil {
+			log.Errorf("Unable to check the health of the package repositories: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check lists the repositories once, sending the notifications due. A failure
+// to notify is logged, and retried at the next check.
+func (m *Monitor) Check(ctx context.Context) error {
+	statuses, err := m.listRepositoryStatuses(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := m.now()
+	seen := map[string]bool{}
+	for _, status := range statuses {
+		key := status.key()
+		seen[key] = true
+		switch status.Health {
+		case HealthReady:
+			failing, ok := m.failing[key]
+			if !ok {
+				continue
+			}
+			if !failing.lastNotified.IsZero() {
+				text := fmt.Sprintf("The %s %s/%s has recovered after failing for %s", status.Kind, status.Namespace, status.Name, now.Sub(failing.since).Round(time.Second))
+				if err := m.notifier.Notify(ctx, Notification{Text: text}); err != nil {
+					log.Errorf("Unable to notify the health of the %s %s/%s: %v", status.Kind, status.Namespace, status.Name, err)
+					continue
+				}
+			}
+			delete(m.failing, key)
+		case HealthFailed:
+			failing, ok := m.failing[key]
+			if !ok {
+				failing = &failingRepository{since: now}
+				m.failing[key] = failing
+			}
+			var text string
+			switch {
+			case failing.lastNotified.IsZero():
+				text = fmt.Sprintf("The %s %s/%s is failing: %s", status.Kind, status.Namespace, status.Name, status.Message)
+			case m.options.FailingWindow > 0 && now.Sub(failing.lastNotified) >= m.options.FailingWindow:
+				text = fmt.Sprintf("The %s %s/%s has been failing for %s: %s", status.Kind, status.Namespace, status.Name, now.Sub(failing.since).Round(time.Second), status.Message)
+			default:
+				continue
+			}
+			if err := m.notifier.Notify(ctx, Notification{Text: text}); err != nil {
+				log.Errorf("Unable to notify the health of the %s %s/%s: %v", status.Kind, status.Namespace, status.Name, err)
+				continue
+			}
+			failing.lastNotified = now
+		}
+	}
+	// The repositories which have been deleted are forgotten.
+	for key := range m.failing {
+		if !seen[key] {
+			delete(m.failing, key)
+		}
+	}
+	return nil
+}
+
+// listRepositoryStatuses returns the status of the repositories of all the
+// kinds installed in the cluster, sorted by kind, namespace and name.
+func (m *Monitor) listRepositoryStatuses(ctx context.Context) ([]RepositoryStatus, error) {
+	statuses := []RepositoryStatus{}
+	for _, repoKind := range repositoryKinds {
+		list, err := m.client.Resource(repoKind.resource).Namespace(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			if k8sErrors.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("unable to list the %s resources: %w", repoKind.resource.Resource, err)
+		}
+		for i := range list.Items {
+			health, message := repoKind.health(&list.Items[i])
+			statuses = append(statuses, RepositoryStatus{
+				Kind:      repoKind.kind,
+				Namespace: list.Items[i].GetNamespace(),
+				Name:      list.Items[i].GetName(),
+				Health:    health,
+				Message:   message,
+			})
+		}
+	}
+	appRepoStatuses, err := m.listAppRepositoryStatuses(ctx)
+	if err != nil {
+		return nil, err
+	}
+	statuses = append(statuses, appRepoStatuses...)
+	sort.SliceStable(statuses, func(i, j int) bool {
+		return statuses[i].key() < statuses[j].key()
+	})
+	return statuses, nil
+}
+
+// listAppRepositoryStatuses returns the status of the AppRepositories, which
+// is the status of the latest job syncing each of them. They are skipped if
+// the namespace of the sync jobs is unknown or their CRD is not installed.
+func (m *Monitor) listAppRepositoryStatuses(ctx context.Context) ([]RepositoryStatus, error) {
+	if m.options.SyncJobsNamespace == "" {
+		return nil, nil
+	}
+	appRepos, err := m.client.Resource(appRepositoryResource).Namespace(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unable to list the %s resources: %w", appRepositoryResource.Resource, err)
+	}
+	jobs, err := m.client.Resource(jobResource).Namespace(m.options.SyncJobsNamespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list the sync jobs: %w", err)
+	}
+
+	// The one-off and scheduled sync jobs only share the labels of their pods.
+	latestJobs := map[string]*unstructured.Unstructured{}
+	for i := range jobs.Items {
+		job := &jobs.Items[i]
+		labels, _, _ := unstructured.NestedStringMap(job.Object, "spec", "template", "metadata", "labels")
+		if labels[labelRepoName] == "" {
+			continue
+		}
+		key := fmt.Sprintf("%s/%s", labels[labelRepoNamespace], labels[labelRepoName])
+		if latest, ok := latestJobs[key]; !ok || latest.GetCreationTimestamp().Time.Before(job.GetCreationTimestamp().Time) {
+			latestJobs[key] = job
+		}
+	}
+
+	statuses := []RepositoryStatus{}
+	for _, appRepo := range appRepos.Items {
+		health, message := HealthUnknown, ""
+		if job, ok := latestJobs[fmt.Sprintf("%s/%s", appRepo.GetNamespace(), appRepo.GetName())]; ok {
+			health, message = syncJobHealth(job)
+		}
+		statuses = append(statuses, RepositoryStatus{
+			Kind:      appRepositoryKind,
+			Namespace: appRepo.GetNamespace(),
+			Name:      appRepo.GetName(),
+			Health:    health,
+			Message:   message,
+		})
+	}
+	return statuses, nil
+}
+
+// kappControllerHealth returns the health of a kapp-controller
+// PackageRepository from its reconciliation condition.
+func kappControllerHealth(obj *unstructured.Unstructured) (Health, string) {
+	for _, condition := range conditions(obj) {
+		if condition["status"] != "True" {
+			continue
+		}
+		switch condition["type"] {
+		case "ReconcileSucceeded":
+			return HealthReady, ""
+		case "ReconcileFailed":
+			message, _, _ := unstructured.NestedString(obj.Object, "status", "usefulErrorMessage")
+			if message == "" {
+				message = condition["message"]
+			}
+			return HealthFailed, message
+		}
+	}
+	return HealthUnknown, ""
+}
+
+// fluxHealth returns the health of a Flux HelmRepository from its Ready
+// condition.
+func fluxHealth(obj *unstructured.Unstructured) (Health, string) {
+	for _, condition := range conditions(obj) {
+		if condition["type"] != "Ready" {
+			continue
+		}
+		switch condition["status"] {
+		case "True":
+			return HealthReady, ""
+		case "False":
+			return HealthFailed, condition["message"]
+		}
+	}
+	return HealthUnknown, ""
+}
+
+// syncJobHealth returns the health of an AppRepository from the Complete or
+// Failed condition of a job syncing it.
+func syncJobHealth(job *unstructured.Unstructured) (Health, string) {
+	for _, condition := range conditions(job) {
+		if condition["status"] != "True" {
+			continue
+		}
+		switch condition["type"] {
+		case "Complete":
+			return HealthReady, ""
+		case "Failed":
+			return HealthFailed, condition["message"]
+		}
+	}
+	return HealthUnknown, ""
+}
+
+// conditions returns the string fields of the status conditions of an object.
+func conditions(obj *unstructured.Unstructured) []map[string]string {
+	items, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	conditions := []map[string]string{}
+	for _, item := range items {
+		fields, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		condition := map[string]string{}
+		for key, value := range fields {
+			if s, ok := value.(string); ok {
+				condition[key] = s
+			}
+		}
+		conditions = append(conditions, condition)
+	}
+	return conditions
+}
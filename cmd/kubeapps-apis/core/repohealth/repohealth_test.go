@@ -0,0 +1,235 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package repohealth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynfake "k8s.io/client-go/dynamic/fake"
+)
+
+type fakeNotifier struct {
+	notifications []Notification
+}
+
+func (n *fakeNotifier) Notify(ctx context.Context, notification Notification) error {
+	n.notifications = append(n.notifications, notification)
+	return nil
+}
+
+func newRepository(gvr schema.GroupVersionResource, kind, name string, conditions ...map[string]interface{}) *unstructured.Unstructured {
+	items := []interface{}{}
+	for _, condition := range conditions {
+		items = append(items, condition)
+	}
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": gvr.GroupVersion().String(),
+		"kind":       kind,
+		"metadata":   map[string]interface{}{"name": name, "namespace": "default"},
+		"status":     map[string]interface{}{"conditions": items},
+	}}
+}
+
+func newPkgRepository(name, conditionType, message string) *unstructured.Unstructured {
+	return newRepository(repositoryKinds[0].resource, "PackageRepository", name,
+		map[string]interface{}{"type": conditionType, "status": "True", "message": message})
+}
+
+func newHelmRepository(name, status, message string) *unstructured.Unstructured {
+	return newRepository(repositoryKinds[1].resource, "HelmRepository", name,
+		map[string]interface{}{"type": "Ready", "status": status, "message": message})
+}
+
+func newAppRepository(name string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": appRepositoryResource.GroupVersion().String(),
+		"kind":       appRepositoryKind,
+		"metadata":   map[string]interface{}{"name": name, "namespace": "default"},
+	}}
+}
+
+func newSyncJob(name, repoName string, created time.Time, conditionType, message string) *unstructured.Unstructured {
+	conditions := []interface{}{}
+	if conditionType != "" {
+		conditions = append(conditions, map[string]interface{}{"type": conditionType, "status": "True", "message": message})
+	}
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": jobResource.GroupVersion().String(),
+		"kind":       "Job",
+		"metadata": map[string]interface{}{
+			"name":              name,
+			"namespace":         "kubeapps",
+			"creationTimestamp": created.Format(time.RFC3339),
+		},
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"labels": map[string]interface{}{
+						labelRepoName:      repoName,
+						labelRepoNamespace: "default",
+					},
+				},
+			},
+		},
+		"status": map[string]interface{}{"conditions": conditions},
+	}}
+}
+
+func TestMonitorCheck(t *testing.T) {
+	start := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name                  string
+		checks                [][]k8sruntime.Object
+		failingWindow         time.Duration
+		expectedNotifications []Notification
+	}{
+		{
+			name: "it notifies the repositories when they start failing",
+			checks: [][]k8sruntime.Object{
+				{newPkgRepository("tce", "ReconcileSucceeded", ""), newHelmRepository("bitnami", "True", "")},
+				{newPkgRepository("tce", "ReconcileFailed", "fetching: unauthorized"), newHelmRepository("bitnami", "False", "failed to fetch index")},
+				{newPkgRepository("tce", "ReconcileFailed", "fetching: unauthorized"), newHelmRepository("bitnami", "False", "failed to fetch index")},
+			},
+			expectedNotifications: []Notification{
+				{Text: "The HelmRepository default/bitnami is failing: failed to fetch index"},
+				{Text: "The PackageRepository default/tce is failing: fetching: unauthorized"},
+			},
+		},
+		{
+			name: "it notifies the repositories still failing beyond the failing window",
+			checks: [][]k8sruntime.Object{
+				{newHelmRepository("bitnami", "False", "failed to fetch index")},
+				{newHelmRepository("bitnami", "False", "failed to fetch index")},
+				{newHelmRepository("bitnami", "False", "failed to fetch index")},
+			},
+			failingWindow: 2 * time.Minute,
+			expectedNotifications: []Notification{
+				{Text: "The HelmRepository default/bitnami is failing: failed to fetch index"},
+				{Text: "The HelmRepository default/bitnami has been failing for 2m0s: failed to fetch index"},
+			},
+		},
+		{
+			name: "it notifies the repositories recovering and failing again",
+			checks: [][]k8sruntime.Object{
+				{newHelmRepository("bitnami", "False", "failed to fetch index")},
+				{newHelmRepository("bitnami", "True", "")},
+				{newHelmRepository("bitnami", "True", "")},
+				{newHelmRepository("bitnami", "False", "failed to fetch index")},
+			},
+			expectedNotifications: []Notification{
+				{Text: "The HelmRepository default/bitnami is failing: failed to fetch index"},
+				{Text: "The HelmRepository default/bitnami has recovered after failing for 1m0s"},
+				{Text: "The HelmRepository default/bitnami is failing: failed to fetch index"},
+			},
+		},
+		{
+			name: "it notifies the AppRepositories from their latest sync job",
+			checks: [][]k8sruntime.Object{
+				{
+					newAppRepository("bitnami"),
+					newSyncJob("sync-1", "bitnami", start, "Complete", ""),
+					newSyncJob("sync-2", "bitnami", start.Add(time.Minute), "Failed", "Job has reached the specified backoff limit"),
+				},
+				{
+					newAppRepository("bitnami"),
+					newSyncJob("sync-2", "bitnami", start.Add(time.Minute), "Failed", "Job has reached the specified backoff limit"),
+					newSyncJob("sync-3", "bitnami", start.Add(2*time.Minute), "", ""),
+				},
+				{
+					newAppRepository("bitnami"),
+					newSyncJob("sync-3", "bitnami", start.Add(2*time.Minute), "Complete", ""),
+				},
+			},
+			expectedNotifications: []Notification{
+				{Text: "The AppRepository default/bitnami is failing: Job has reached the specified backoff limit"},
+				{Text: "The AppRepository default/bitnami has recovered after failing for 2m0s"},
+			},
+		},
+		{
+			name: "it does not notify the AppRepositories not synced yet",
+			checks: [][]k8sruntime.Object{
+				{newAppRepository("bitnami"), newSyncJob("sync-1", "other", start, "Failed", "Job has reached the specified backoff limit")},
+			},
+			expectedNotifications: []Notification{},
+		},
+		{
+			name: "it does not notify the repositories being reconciled",
+			checks: [][]k8sruntime.Object{
+				{newPkgRepository("tce", "Reconciling", ""), newHelmRepository("bitnami", "Unknown", "reconciliation in progress")},
+			},
+			expectedNotifications: []Notification{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			notifier := &fakeNotifier{notifications: []Notification{}}
+			monitor := NewMonitor(nil, notifier, Options{Interval: time.Minute, FailingWindow: tc.failingWindow, SyncJobsNamespace: "kubeapps"})
+
+			for i, objects := range tc.checks {
+				monitor.client = dynfake.NewSimpleDynamicClientWithCustomListKinds(
+					k8sruntime.NewScheme(),
+					map[schema.GroupVersionResource]string{
+						repositoryKinds[0].resource: "PackageRepositoryList",
+						repositoryKinds[1].resource: "HelmRepositoryList",
+						appRepositoryResource:       "AppRepositoryList",
+						jobResource:                 "JobList",
+					},
+					objects...,
+				)
+				monitor.now = func() time.Time { return start.Add(time.Duration(i) * time.Minute) }
+
+				if err := monitor.Check(context.Background()); err != nil {
+					t.Fatalf("%+v", err)
+				}
+			}
+
+			if got, want := notifier.notifications, tc.expectedNotifications; !cmp.Equal(want, got) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
+func TestWebhookNotifier(t *testing.T) {
+	received := []map[string]interface{}{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := map[string]interface{}{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received = append(received, body)
+		if body["text"] == "rejected" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+	notifier := NewWebhookNotifier(server.URL, server.Client())
+
+	if err := notifier.Notify(context.Background(), Notification{Text: "The HelmRepository default/bitnami is failing"}); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if err := notifier.Notify(context.Background(), Notification{Text: "rejected"}); err == nil {
+		t.Errorf("got: nil, want: error")
+	}
+
+	expected := []map[string]interface{}{
+		{"text": "The HelmRepository default/bitnami is failing"},
+		{"text": "rejected"},
+	}
+	if got, want := received, expected; !cmp.Equal(want, got) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+	}
+}
@@ -0,0 +1,212 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core/authn"
+	packages "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1"
+	packagesconnect "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1/v1connect"
+	"google.golang.org/protobuf/proto"
+	log "k8s.io/klog/v2"
+)
+
+const (
+	// serviceAccountTokenPath is the path of the token of the kubeapps-apis
+	// service account, which is read at each crawl since it is rotated.
+	serviceAccountTokenPath = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+
+	// crawlPageSize is the number of available packages requested at once.
+	crawlPageSize = 100
+
+	// crawlConcurrency is the number of available packages whose details
+	// are requested at once.
+	crawlConcurrency = 4
+)
+
+// Crawler periodically crawls the global available packages of each cluster
+// through the packages server, replacing the index once crawled. The details
+// of a package are only requested when it is new or its summary changed.
+type Crawler struct {
+	packagesServer packagesconnect.PackagesServiceHandler
+	// clusters returns the clusters to crawl, which may be registered at
+	// runtime.
+	clusters func() []string
+	// namespace is the namespace of the global package repositories, whose
+	// packages can be installed in any namespace.
+	namespace string
+	interval  time.Duration
+	token     func() (string, error)
+	now       func() time.Time
+
+	mutex   sync.RWMutex
+	current *index
+}
+
+// NewCrawler returns a crawler listing the available packages of the
+// namespace of the global package repositories in each cluster, at the given
+// interval, authenticated as the kubeapps-apis service account.
+func NewCrawler(packagesServer packagesconnect.PackagesServiceHandler, clusters func() []string, namespace string, interval time.Duration) *Crawler {
+	return &Crawler{
+		packagesServer: packagesServer,
+		clusters:       clusters,
+		namespace:      namespace,
+		interval:       interval,
+		token:          serviceAccountToken,
+		now:            time.Now,
+	}
+}
+
+// Run crawls the available packages at the configured interval until the
+// context is done.
+func (c *Crawler) Run(ctx context.Context) {
+	log.Infof("Indexing the available packages for the search every %s", c.interval)
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		if err := c.Crawl(ctx); err != nil {
+			log.Errorf("Unable to index the available packages for the search: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *Crawler) index() *index {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.current
+}
+
+// Crawl lists the available packages of each cluster once, replacing the
+// index. The packages of the clusters which cannot be crawled are kept from
+// the previous index, if any.
+func (c *Crawler) Crawl(ctx context.Context) error {
+	token, err := c.token()
+	if err != nil {
+		return fmt.Errorf("unable to read the service account token: %w", err)
+	}
+	headers := http.Header{}
+	headers.Set("Authorization", "Bearer "+token)
+
+	previous := c.index()
+	previousPkgs := map[string]indexedPackage{}
+	if previous != nil {
+		for _, pkg := range previous.pkgs {
+			previousPkgs[refKey(pkg.summary.GetAvailablePackageRef())] = pkg
+		}
+	}
+	pkgs := []indexedPackage{}
+	var failedClusters []string
+	for _, cluster := range c.clusters() {
+		clusterPkgs, err := c.crawlCluster(ctx, cluster, headers, previousPkgs)
+		if err != nil {
+			log.Errorf("Unable to index the available packages of the cluster %q for the search: %v", cluster, err)
+			failedClusters = append(failedClusters, cluster)
+			if previous != nil {
+				for _, pkg := range previous.pkgs {
+					if pkg.cluster() == cluster {
+						pkgs = append(pkgs, pkg)
+					}
+				}
+			}
+			continue
+		}
+		pkgs = append(pkgs, clusterPkgs...)
+	}
+
+	idx := newIndex(pkgs, c.now())
+	c.mutex.Lock()
+	c.current = idx
+	c.mutex.Unlock()
+	log.InfoS("Indexed the available packages for the search", "packages", len(pkgs), "words", len(idx.words))
+
+	if len(failedClusters) > 0 {
+		return fmt.Errorf("unable to index the clusters %s", strings.Join(failedClusters, ", "))
+	}
+	return nil
+}
+
+// crawlCluster lists the available packages of the cluster page by page,
+// then requests the details of those which are new or changed since the
+// previous crawl for their maintainers. A package whose details cannot be
+// requested is indexed without its maintainers, and requested again at the
+// next crawl.
+func (c *Crawler) crawlCluster(ctx context.Context, cluster string, headers http.Header, previousPkgs map[string]indexedPackage) ([]indexedPackage, error) {
+	pkgs := []indexedPackage{}
+	includeIcons := false
+	pageToken := ""
+	for {
+		request := connect.NewRequest(&packages.GetAvailablePackageSummariesRequest{
+			Context:           &packages.Context{Cluster: cluster, Namespace: c.namespace},
+			PaginationOptions: &packages.PaginationOptions{PageToken: pageToken, PageSize: crawlPageSize},
+			IncludeIcons:      &includeIcons,
+		})
+		authn.CopyHeaders(request.Header(), headers)
+		response, err := c.packagesServer.GetAvailablePackageSummaries(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+		for _, summary := range response.Msg.GetAvailablePackageSummaries() {
+			pkg := indexedPackage{summary: summary}
+			if previous, ok := previousPkgs[refKey(summary.GetAvailablePackageRef())]; ok && previous.detailed && proto.Equal(previous.summary, summary) {
+				pkg = previous
+			}
+			pkgs = append(pkgs, pkg)
+		}
+		pageToken = response.Msg.GetNextPageToken()
+		if pageToken == "" {
+			break
+		}
+	}
+
+	var wg sync.WaitGroup
+	pending := make(chan *indexedPackage)
+	for i := 0; i < crawlConcurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for pkg := range pending {
+				request := connect.NewRequest(&packages.GetAvailablePackageDetailRequest{
+					AvailablePackageRef: pkg.summary.GetAvailablePackageRef(),
+				})
+				authn.CopyHeaders(request.Header(), headers)
+				response, err := c.packagesServer.GetAvailablePackageDetail(ctx, request)
+				if err != nil {
+					log.V(4).Infof("Indexing the available package %q without its maintainers: %v", pkg.summary.GetAvailablePackageRef().GetIdentifier(), err)
+					continue
+				}
+				pkg.maintainers = response.Msg.GetAvailablePackageDetail().GetMaintainers()
+				pkg.detailed = true
+			}
+		}()
+	}
+	for i := range pkgs {
+		if !pkgs[i].detailed {
+			pending <- &pkgs[i]
+		}
+	}
+	close(pending)
+	wg.Wait()
+	return pkgs, nil
+}
+
+func serviceAccountToken() (string, error) {
+	token, err := os.ReadFile(serviceAccountTokenPath)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(token)), nil
+}
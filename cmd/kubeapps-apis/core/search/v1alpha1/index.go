@@ -0,0 +1,192 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	packages "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1"
+	search "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/search/v1alpha1"
+)
+
+// The weights of the matches in each field of the packages.
+const (
+	nameWeight        float32 = 8
+	keywordsWeight    float32 = 4
+	descriptionWeight float32 = 2
+	maintainersWeight float32 = 1
+
+	// namePrefixBoost is added to the score of the packages whose name starts
+	// with the whole query, and doubled if the name is the query.
+	namePrefixBoost float32 = 4
+)
+
+// indexedPackage is a crawled available package, along with the words of
+// each of its fields.
+type indexedPackage struct {
+	summary     *packages.AvailablePackageSummary
+	maintainers []*packages.Maintainer
+	// detailed is whether the details of the package were crawled, which
+	// are not requested again while its summary is unchanged.
+	detailed bool
+}
+
+func (p indexedPackage) cluster() string {
+	return p.summary.GetAvailablePackageRef().GetContext().GetCluster()
+}
+
+// posting records that a word appears in a field of a package.
+type posting struct {
+	pkg    int
+	weight float32
+}
+
+// index is an inverted index of the crawled packages, mapping the words of
+// their name, description, keywords and maintainers to the packages. It is
+// built once per crawl, and never modified afterwards.
+type index struct {
+	pkgs     []indexedPackage
+	postings map[string][]posting
+	// words are the keys of the postings, sorted so that the words starting
+	// with a prefix are contiguous.
+	words []string
+	time  time.Time
+}
+
+func newIndex(pkgs []indexedPackage, indexTime time.Time) *index {
+	idx := &index{
+		pkgs:     pkgs,
+		postings: map[string][]posting{},
+		time:     indexTime,
+	}
+	for i, pkg := range pkgs {
+		// A word appearing in several fields of a package is only recorded
+		// with the highest weight.
+		weights := map[string]float32{}
+		addWords := func(text string, weight float32) {
+			for _, word := range tokenize(text) {
+				if weight > weights[word] {
+					weights[word] = weight
+				}
+			}
+		}
+		addWords(pkg.summary.GetName(), nameWeight)
+		addWords(pkg.summary.GetDisplayName(), nameWeight)
+		for _, category := range pkg.summary.GetCategories() {
+			addWords(category, keywordsWeight)
+		}
+		addWords(pkg.summary.GetShortDescription(), descriptionWeight)
+		for _, maintainer := range pkg.maintainers {
+			addWords(maintainer.GetName(), maintainersWeight)
+		}
+		for word, weight := range weights {
+			idx.postings[word] = append(idx.postings[word], posting{pkg: i, weight: weight})
+		}
+	}
+	idx.words = make([]string, 0, len(idx.postings))
+	for word := range idx.postings {
+		idx.words = append(idx.words, word)
+	}
+	sort.Strings(idx.words)
+	return idx
+}
+
+// suggest returns at most limit packages of the cluster, or of all the
+// clusters if empty, matching every word of the query and allowed, the best
+// matches first. The last word of the query, being typed, is matched as a
+// prefix as well as the others.
+func (idx *index) suggest(query, cluster string, allowed func(ref *packages.AvailablePackageReference) bool, limit int) []*search.SearchSuggestion {
+	queryWords := tokenize(query)
+	if len(queryWords) == 0 {
+		return []*search.SearchSuggestion{}
+	}
+
+	var scores map[int]float32
+	for _, queryWord := range queryWords {
+		wordScores := idx.match(queryWord)
+		if scores == nil {
+			scores = wordScores
+			continue
+		}
+		for pkg, score := range scores {
+			if wordScore, ok := wordScores[pkg]; ok {
+				scores[pkg] = score + wordScore
+			} else {
+				delete(scores, pkg)
+			}
+		}
+	}
+
+	normalizedQuery := strings.Join(queryWords, " ")
+	suggestions := []*search.SearchSuggestion{}
+	for i, score := range scores {
+		pkg := idx.pkgs[i]
+		if cluster != "" && pkg.cluster() != cluster {
+			continue
+		}
+		if !allowed(pkg.summary.GetAvailablePackageRef()) {
+			continue
+		}
+		name := strings.Join(tokenize(pkg.summary.GetName()), " ")
+		if strings.HasPrefix(name, normalizedQuery) {
+			score += namePrefixBoost
+			if name == normalizedQuery {
+				score += namePrefixBoost
+			}
+		}
+		suggestions = append(suggestions, &search.SearchSuggestion{
+			AvailablePackageRef: pkg.summary.GetAvailablePackageRef(),
+			Name:                pkg.summary.GetName(),
+			DisplayName:         pkg.summary.GetDisplayName(),
+			ShortDescription:    pkg.summary.GetShortDescription(),
+			Score:               score,
+		})
+	}
+
+	sort.Slice(suggestions, func(i, j int) bool {
+		if suggestions[i].Score != suggestions[j].Score {
+			return suggestions[i].Score > suggestions[j].Score
+		}
+		if suggestions[i].Name != suggestions[j].Name {
+			return suggestions[i].Name < suggestions[j].Name
+		}
+		return refKey(suggestions[i].AvailablePackageRef) < refKey(suggestions[j].AvailablePackageRef)
+	})
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+	return suggestions
+}
+
+// match returns the score of each package having a word starting with the
+// query word, that is, the weight of its best matching word, reduced for the
+// partial matches by the part of the word matched.
+func (idx *index) match(queryWord string) map[int]float32 {
+	scores := map[int]float32{}
+	for i := sort.SearchStrings(idx.words, queryWord); i < len(idx.words) && strings.HasPrefix(idx.words[i], queryWord); i++ {
+		word := idx.words[i]
+		ratio := float32(len(queryWord)) / float32(len(word))
+		for _, p := range idx.postings[word] {
+			if score := p.weight * ratio; score > scores[p.pkg] {
+				scores[p.pkg] = score
+			}
+		}
+	}
+	return scores
+}
+
+// refKey identifies an available package across the clusters and plugins.
+func refKey(ref *packages.AvailablePackageReference) string {
+	return strings.Join([]string{ref.GetContext().GetCluster(), ref.GetContext().GetNamespace(), ref.GetPlugin().GetName(), ref.GetIdentifier()}, "/")
+}
+
+// tokenize splits the text in lower case words, made of letters and digits.
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
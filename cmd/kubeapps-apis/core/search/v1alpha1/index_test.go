@@ -0,0 +1,162 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	packages "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1"
+	plugins "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/plugins/v1alpha1"
+)
+
+func summary(cluster, identifier, name, description string, categories ...string) *packages.AvailablePackageSummary {
+	return &packages.AvailablePackageSummary{
+		AvailablePackageRef: &packages.AvailablePackageReference{
+			Context:    &packages.Context{Cluster: cluster, Namespace: "kubeapps"},
+			Identifier: identifier,
+			Plugin:     &plugins.Plugin{Name: "helm.packages", Version: "v1alpha1"},
+		},
+		Name:             name,
+		DisplayName:      name,
+		ShortDescription: description,
+		Categories:       categories,
+	}
+}
+
+// allowAll allows the user to list every package.
+func allowAll(*packages.AvailablePackageReference) bool {
+	return true
+}
+
+func TestSuggest(t *testing.T) {
+	idx := newIndex([]indexedPackage{
+		{summary: summary("default", "bitnami/postgresql", "postgresql", "PostgreSQL is an object-relational database", "Database")},
+		{summary: summary("default", "bitnami/postgresql-ha", "postgresql-ha", "PostgreSQL with replication and failover", "Database")},
+		{summary: summary("default", "bitnami/mariadb", "mariadb", "A fork of MySQL", "Database")},
+		{
+			summary:     summary("default", "bitnami/apache", "apache", "The Apache HTTP server", "Infrastructure"),
+			maintainers: []*packages.Maintainer{{Name: "Bitnami", Email: "containers@bitnami.com"}},
+		},
+		{summary: summary("other", "bitnami/postgresql", "postgresql", "PostgreSQL is an object-relational database", "Database")},
+	}, time.Now())
+
+	testCases := []struct {
+		name     string
+		query    string
+		cluster  string
+		limit    int
+		expected []string
+	}{
+		{
+			name:     "it suggests the packages whose name starts with the query first",
+			query:    "postgr",
+			cluster:  "default",
+			limit:    10,
+			expected: []string{"default/bitnami/postgresql", "default/bitnami/postgresql-ha"},
+		},
+		{
+			name:     "it suggests the packages of all the clusters",
+			query:    "postgresql",
+			limit:    10,
+			expected: []string{"default/bitnami/postgresql", "other/bitnami/postgresql", "default/bitnami/postgresql-ha"},
+		},
+		{
+			name:     "it requires every word of the query to match",
+			query:    "postgresql ha",
+			limit:    10,
+			expected: []string{"default/bitnami/postgresql-ha"},
+		},
+		{
+			name:     "it matches the keywords before the descriptions",
+			query:    "database",
+			cluster:  "default",
+			limit:    10,
+			expected: []string{"default/bitnami/mariadb", "default/bitnami/postgresql", "default/bitnami/postgresql-ha"},
+		},
+		{
+			name:     "it matches the descriptions",
+			query:    "mysql",
+			limit:    10,
+			expected: []string{"default/bitnami/mariadb"},
+		},
+		{
+			name:     "it matches the maintainers",
+			query:    "bitn",
+			limit:    10,
+			expected: []string{"default/bitnami/apache"},
+		},
+		{
+			name:     "it returns at most the limit",
+			query:    "database",
+			limit:    2,
+			expected: []string{"default/bitnami/mariadb", "default/bitnami/postgresql"},
+		},
+		{
+			name:     "it returns no suggestion without a match",
+			query:    "redis",
+			limit:    10,
+			expected: []string{},
+		},
+		{
+			name:     "it returns no suggestion for an empty query",
+			query:    " - ",
+			limit:    10,
+			expected: []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := []string{}
+			for _, suggestion := range idx.suggest(tc.query, tc.cluster, allowAll, tc.limit) {
+				got = append(got, suggestion.GetAvailablePackageRef().GetContext().GetCluster()+"/"+suggestion.GetAvailablePackageRef().GetIdentifier())
+			}
+			if !cmp.Equal(tc.expected, got) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(tc.expected, got))
+			}
+		})
+	}
+}
+
+func TestSuggestScore(t *testing.T) {
+	idx := newIndex([]indexedPackage{
+		{summary: summary("default", "bitnami/postgresql", "postgresql", "PostgreSQL is an object-relational database")},
+	}, time.Now())
+
+	testCases := []struct {
+		name     string
+		query    string
+		expected float32
+	}{
+		{
+			name:     "a partial word is weighted by the part matched, and boosted as a prefix of the name",
+			query:    "postgr",
+			expected: nameWeight*6/10 + namePrefixBoost,
+		},
+		{
+			name:     "the name is boosted twice",
+			query:    "PostgreSQL",
+			expected: nameWeight + 2*namePrefixBoost,
+		},
+		{
+			name:     "the words of the query are summed, the name not starting with the query",
+			query:    "postgresql object",
+			expected: nameWeight + descriptionWeight,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			suggestions := idx.suggest(tc.query, "", allowAll, 10)
+			if len(suggestions) != 1 {
+				t.Fatalf("got: %d suggestions, want: 1", len(suggestions))
+			}
+			if got, want := suggestions[0].GetScore(), tc.expected; got != want {
+				t.Errorf("got: %v, want: %v", got, want)
+			}
+		})
+	}
+}
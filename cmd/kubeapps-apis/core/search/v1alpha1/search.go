@@ -0,0 +1,153 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core/authn"
+	packages "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1"
+	search "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/search/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/resources"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+	log "k8s.io/klog/v2"
+)
+
+const (
+	// defaultLimit is the number of suggestions returned when the request
+	// does not set it, and maxLimit the most that can be requested.
+	defaultLimit = 10
+	maxLimit     = 100
+
+	// accessReviewCacheTTL is the time the access of a user to the packages
+	// of a namespace is cached, as a query is sent at each key stroke.
+	accessReviewCacheTTL = 30 * time.Second
+)
+
+// repositoryResources are the resources of the package repositories, or of
+// the packages, of each plugin. A user is suggested the packages of a
+// namespace of a cluster only if allowed to list them there, and never those
+// of the other plugins.
+var repositoryResources = map[string]schema.GroupResource{
+	"helm.packages":            {Group: "kubeapps.com", Resource: "apprepositories"},
+	"fluxv2.packages":          {Group: "source.toolkit.fluxcd.io", Resource: "helmrepositories"},
+	"kapp_controller.packages": {Group: "packaging.carvel.dev", Resource: "packagerepositories"},
+	"operators.packages":       {Group: "packages.operators.coreos.com", Resource: "packagemanifests"},
+	"oci_catalog.packages":     {Group: "kappctrl.k14s.io", Resource: "apps"},
+}
+
+// searchServer implements the API defined in proto/kubeappsapis/core/search/v1alpha1/search.proto
+type searchServer struct {
+	search.UnimplementedSearchServiceServer
+
+	// crawler builds the index, or is nil if the search is not enabled.
+	crawler *Crawler
+
+	// clientGetter returns a client of the cluster authenticated as the user
+	// of the request. It is a field so that it can be switched in tests.
+	clientGetter  func(headers http.Header, cluster string) (kubernetes.Interface, error)
+	accessReviews *resources.AccessReviewCache
+}
+
+// NewSearchServer returns a server suggesting the packages indexed by the
+// crawler, which is nil if the search is not enabled, to the users allowed to
+// list them.
+func NewSearchServer(crawler *Crawler, configGetter core.KubernetesConfigGetter) *searchServer {
+	return &searchServer{
+		crawler: crawler,
+		clientGetter: func(headers http.Header, cluster string) (kubernetes.Interface, error) {
+			config, err := configGetter(headers, cluster)
+			if err != nil {
+				return nil, err
+			}
+			return kubernetes.NewForConfig(config)
+		},
+		accessReviews: resources.NewAccessReviewCache(accessReviewCacheTTL),
+	}
+}
+
+// GetSearchSuggestions returns the indexed packages matching the query which
+// the user is allowed to list, the best matches first.
+func (s *searchServer) GetSearchSuggestions(ctx context.Context, request *connect.Request[search.GetSearchSuggestionsRequest]) (*connect.Response[search.GetSearchSuggestionsResponse], error) {
+	log.V(4).InfoS("+core GetSearchSuggestions", "query", request.Msg.GetQuery(), "cluster", request.Msg.GetCluster())
+
+	if _, err := authn.ExtractToken(request.Header()); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("Invalid authorization metadata: %w", err))
+	}
+	if s.crawler == nil {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("The search is not enabled"))
+	}
+	limit := int(request.Msg.GetLimit())
+	if limit < 0 || limit > maxLimit {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("The limit must be between 0 and %d", maxLimit))
+	}
+	if limit == 0 {
+		limit = defaultLimit
+	}
+	idx := s.crawler.index()
+	if idx == nil {
+		return nil, connect.NewError(connect.CodeUnavailable, fmt.Errorf("The packages are still being indexed"))
+	}
+
+	// The access is reviewed once per cluster, namespace and plugin of the
+	// matching packages.
+	allowedScopes := map[string]bool{}
+	var accessErr error
+	allowed := func(ref *packages.AvailablePackageReference) bool {
+		scope := strings.Join([]string{ref.GetContext().GetCluster(), ref.GetContext().GetNamespace(), ref.GetPlugin().GetName()}, "/")
+		if ok, reviewed := allowedScopes[scope]; reviewed {
+			return ok
+		}
+		ok, err := s.canList(ctx, request.Header(), ref)
+		if err != nil && accessErr == nil {
+			accessErr = err
+		}
+		allowedScopes[scope] = ok
+		return ok
+	}
+	suggestions := idx.suggest(request.Msg.GetQuery(), request.Msg.GetCluster(), allowed, limit)
+	if accessErr != nil {
+		return nil, accessErr
+	}
+
+	return connect.NewResponse(&search.GetSearchSuggestionsResponse{
+		Suggestions: suggestions,
+		IndexTime:   timestamppb.New(idx.time),
+	}), nil
+}
+
+// canList returns whether the user of the request is allowed to list the
+// packages of the namespace of the reference, reviewing their access to the
+// repositories of its plugin.
+func (s *searchServer) canList(ctx context.Context, headers http.Header, ref *packages.AvailablePackageReference) (bool, error) {
+	gr, ok := repositoryResources[ref.GetPlugin().GetName()]
+	if !ok {
+		return false, nil
+	}
+	cluster := ref.GetContext().GetCluster()
+	client, err := s.clientGetter(headers, cluster)
+	if err != nil {
+		return false, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to get the client of the cluster %q: %w", cluster, err))
+	}
+	allowed, err := s.accessReviews.CanI(ctx, client, resources.AccessReviewKey{
+		User:      resources.AccessReviewUser(headers),
+		Cluster:   cluster,
+		Namespace: ref.GetContext().GetNamespace(),
+		Group:     gr.Group,
+		Resource:  gr.Resource,
+		Verb:      "list",
+	})
+	if err != nil {
+		return false, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to review the access of the user: %w", err))
+	}
+	return allowed, nil
+}
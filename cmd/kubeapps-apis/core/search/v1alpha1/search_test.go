@@ -0,0 +1,334 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/google/go-cmp/cmp"
+	packages "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1"
+	packagesconnect "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1/v1connect"
+	search "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/search/v1alpha1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	typfake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// fakePackagesServer returns the summaries of each cluster one per page, and
+// the maintainers of the identifiers in the maintainers map.
+type fakePackagesServer struct {
+	packagesconnect.UnimplementedPackagesServiceHandler
+
+	summaries      map[string][]*packages.AvailablePackageSummary
+	failedClusters map[string]bool
+	maintainers    map[string]string
+
+	mutex   sync.Mutex
+	headers []string
+	details []string
+}
+
+func (s *fakePackagesServer) GetAvailablePackageSummaries(ctx context.Context, request *connect.Request[packages.GetAvailablePackageSummariesRequest]) (*connect.Response[packages.GetAvailablePackageSummariesResponse], error) {
+	s.recordHeader(request.Header().Get("Authorization"))
+	cluster := request.Msg.GetContext().GetCluster()
+	if s.failedClusters[cluster] {
+		return nil, connect.NewError(connect.CodeUnavailable, fmt.Errorf("cluster %q unreachable", cluster))
+	}
+	if request.Msg.GetIncludeIcons() {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("unexpected icons"))
+	}
+	offset := 0
+	if token := request.Msg.GetPaginationOptions().GetPageToken(); token != "" {
+		offset, _ = strconv.Atoi(token)
+	}
+	summaries := s.summaries[cluster]
+	response := &packages.GetAvailablePackageSummariesResponse{}
+	if offset < len(summaries) {
+		response.AvailablePackageSummaries = summaries[offset : offset+1]
+	}
+	if offset+1 < len(summaries) {
+		response.NextPageToken = strconv.Itoa(offset + 1)
+	}
+	return connect.NewResponse(response), nil
+}
+
+func (s *fakePackagesServer) GetAvailablePackageDetail(ctx context.Context, request *connect.Request[packages.GetAvailablePackageDetailRequest]) (*connect.Response[packages.GetAvailablePackageDetailResponse], error) {
+	s.recordHeader(request.Header().Get("Authorization"))
+	s.mutex.Lock()
+	s.details = append(s.details, request.Msg.GetAvailablePackageRef().GetIdentifier())
+	s.mutex.Unlock()
+	maintainer, ok := s.maintainers[request.Msg.GetAvailablePackageRef().GetIdentifier()]
+	if !ok {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("not found"))
+	}
+	return connect.NewResponse(&packages.GetAvailablePackageDetailResponse{
+		AvailablePackageDetail: &packages.AvailablePackageDetail{
+			Maintainers: []*packages.Maintainer{{Name: maintainer}},
+		},
+	}), nil
+}
+
+func (s *fakePackagesServer) recordHeader(header string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.headers = append(s.headers, header)
+}
+
+func newTestCrawler(packagesServer *fakePackagesServer, clusters []string, now time.Time) *Crawler {
+	crawler := NewCrawler(packagesServer, func() []string { return clusters }, "kubeapps", time.Minute)
+	crawler.token = func() (string, error) { return "service-account-token", nil }
+	crawler.now = func() time.Time { return now }
+	return crawler
+}
+
+// newTestSearchServer returns a search server whose users are allowed to
+// list the resources of the given namespaces, keyed by cluster, namespace and
+// resource.
+func newTestSearchServer(crawler *Crawler, allowed map[string]bool) *searchServer {
+	server := NewSearchServer(crawler, nil)
+	server.clientGetter = func(headers http.Header, cluster string) (kubernetes.Interface, error) {
+		client := typfake.NewSimpleClientset()
+		client.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (handled bool, ret k8sruntime.Object, err error) {
+			attributes := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview).Spec.ResourceAttributes
+			key := strings.Join([]string{cluster, attributes.Namespace, attributes.Resource, attributes.Verb}, "/")
+			return true, &authorizationv1.SelfSubjectAccessReview{Status: authorizationv1.SubjectAccessReviewStatus{Allowed: allowed[key]}}, nil
+		})
+		return client, nil
+	}
+	return server
+}
+
+func suggestionKeys(suggestions []*search.SearchSuggestion) []string {
+	keys := []string{}
+	for _, suggestion := range suggestions {
+		keys = append(keys, suggestion.GetAvailablePackageRef().GetContext().GetCluster()+"/"+suggestion.GetAvailablePackageRef().GetIdentifier())
+	}
+	return keys
+}
+
+func TestCrawl(t *testing.T) {
+	packagesServer := &fakePackagesServer{
+		summaries: map[string][]*packages.AvailablePackageSummary{
+			"default": {
+				summary("default", "bitnami/apache", "apache", "The Apache HTTP server"),
+				summary("default", "bitnami/nginx", "nginx", "NGINX web server"),
+			},
+			"other": {
+				summary("other", "bitnami/apache", "apache", "The Apache HTTP server"),
+			},
+		},
+		maintainers: map[string]string{"bitnami/apache": "Bitnami"},
+	}
+	crawler := newTestCrawler(packagesServer, []string{"default", "other"}, time.Now())
+
+	if err := crawler.Crawl(context.Background()); err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	// Every page is crawled, and the maintainers of the packages whose
+	// details are available indexed.
+	if got, want := suggestionKeys(crawler.index().suggest("server", "", allowAll, 10)), []string{"default/bitnami/apache", "other/bitnami/apache", "default/bitnami/nginx"}; !cmp.Equal(want, got) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+	}
+	if got, want := suggestionKeys(crawler.index().suggest("bitnami", "", allowAll, 10)), []string{"default/bitnami/apache", "other/bitnami/apache"}; !cmp.Equal(want, got) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+	}
+	for _, header := range packagesServer.headers {
+		if got, want := header, "Bearer service-account-token"; got != want {
+			t.Errorf("got: %q, want: %q", got, want)
+		}
+	}
+}
+
+func TestCrawlKeepsFailedClusters(t *testing.T) {
+	packagesServer := &fakePackagesServer{
+		summaries: map[string][]*packages.AvailablePackageSummary{
+			"default": {summary("default", "bitnami/apache", "apache", "The Apache HTTP server")},
+			"other":   {summary("other", "bitnami/apache", "apache", "The Apache HTTP server")},
+		},
+	}
+	crawler := newTestCrawler(packagesServer, []string{"default", "other"}, time.Now())
+	if err := crawler.Crawl(context.Background()); err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	// The packages of the failed cluster are kept from the previous crawl,
+	// while those of the other clusters are replaced.
+	packagesServer.failedClusters = map[string]bool{"other": true}
+	packagesServer.summaries["default"] = []*packages.AvailablePackageSummary{summary("default", "bitnami/nginx", "nginx", "NGINX web server")}
+	if err := crawler.Crawl(context.Background()); err == nil {
+		t.Fatalf("got: nil, want: error")
+	}
+
+	if got, want := suggestionKeys(crawler.index().suggest("server", "", allowAll, 10)), []string{"other/bitnami/apache", "default/bitnami/nginx"}; !cmp.Equal(want, got) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+	}
+}
+
+func TestCrawlRequestsChangedDetails(t *testing.T) {
+	packagesServer := &fakePackagesServer{
+		summaries: map[string][]*packages.AvailablePackageSummary{
+			"default": {
+				summary("default", "bitnami/apache", "apache", "The Apache HTTP server"),
+				summary("default", "bitnami/nginx", "nginx", "NGINX web server"),
+				summary("default", "bitnami/redis", "redis", "Redis key value store"),
+			},
+		},
+		maintainers: map[string]string{"bitnami/apache": "Bitnami", "bitnami/nginx": "Bitnami"},
+	}
+	crawler := newTestCrawler(packagesServer, []string{"default"}, time.Now())
+	if err := crawler.Crawl(context.Background()); err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	// The details of the unchanged packages are not requested again, unlike
+	// those of the changed and new packages and those which failed.
+	packagesServer.details = nil
+	packagesServer.summaries["default"][1] = summary("default", "bitnami/nginx", "nginx", "NGINX web server and proxy")
+	packagesServer.summaries["default"] = append(packagesServer.summaries["default"], summary("default", "bitnami/wordpress", "wordpress", "WordPress blog"))
+	if err := crawler.Crawl(context.Background()); err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	sort.Strings(packagesServer.details)
+	if got, want := packagesServer.details, []string{"bitnami/nginx", "bitnami/redis", "bitnami/wordpress"}; !cmp.Equal(want, got) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+	}
+	// The maintainers of the unchanged packages are kept.
+	if got, want := suggestionKeys(crawler.index().suggest("bitnami", "", allowAll, 10)), []string{"default/bitnami/apache", "default/bitnami/nginx"}; !cmp.Equal(want, got) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+	}
+}
+
+func TestGetSearchSuggestions(t *testing.T) {
+	indexTime := time.Date(2023, 6, 1, 10, 0, 0, 0, time.UTC)
+	packagesServer := &fakePackagesServer{
+		summaries: map[string][]*packages.AvailablePackageSummary{
+			"default": {
+				summary("default", "bitnami/apache", "apache", "The Apache HTTP server"),
+				summary("default", "bitnami/nginx", "nginx", "NGINX web server"),
+			},
+			"other": {
+				summary("other", "bitnami/apache", "apache", "The Apache HTTP server"),
+			},
+		},
+	}
+	indexedCrawler := newTestCrawler(packagesServer, []string{"default", "other"}, indexTime)
+	if err := indexedCrawler.Crawl(context.Background()); err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	allowed := map[string]bool{"default/kubeapps/apprepositories/list": true}
+	testCases := []struct {
+		name          string
+		crawler       *Crawler
+		request       *search.GetSearchSuggestionsRequest
+		authorization string
+		allowed       map[string]bool
+		expectedKeys  []string
+		expectedCode  connect.Code
+	}{
+		{
+			name:         "it returns the suggestions, the best matches first",
+			crawler:      indexedCrawler,
+			request:      &search.GetSearchSuggestionsRequest{Query: "ap"},
+			allowed:      map[string]bool{"default/kubeapps/apprepositories/list": true, "other/kubeapps/apprepositories/list": true},
+			expectedKeys: []string{"default/bitnami/apache", "other/bitnami/apache"},
+		},
+		{
+			name:         "it returns at most the limit",
+			crawler:      indexedCrawler,
+			request:      &search.GetSearchSuggestionsRequest{Query: "server", Limit: 1},
+			allowed:      allowed,
+			expectedKeys: []string{"default/bitnami/apache"},
+		},
+		{
+			name:         "it only returns the packages of the namespaces the user is allowed to list",
+			crawler:      indexedCrawler,
+			request:      &search.GetSearchSuggestionsRequest{Query: "apache"},
+			allowed:      allowed,
+			expectedKeys: []string{"default/bitnami/apache"},
+		},
+		{
+			name:         "it returns no package if the user is not allowed",
+			crawler:      indexedCrawler,
+			request:      &search.GetSearchSuggestionsRequest{Query: "apache"},
+			expectedKeys: []string{},
+		},
+		{
+			name:          "it returns an error without authorization",
+			crawler:       indexedCrawler,
+			request:       &search.GetSearchSuggestionsRequest{Query: "apache"},
+			authorization: "none",
+			allowed:       allowed,
+			expectedCode:  connect.CodeUnauthenticated,
+		},
+		{
+			name:          "it returns an error with a malformed authorization",
+			crawler:       indexedCrawler,
+			request:       &search.GetSearchSuggestionsRequest{Query: "apache"},
+			authorization: "Basic user-token",
+			allowed:       allowed,
+			expectedCode:  connect.CodeUnauthenticated,
+		},
+		{
+			name:         "it returns an error for a limit too high",
+			crawler:      indexedCrawler,
+			request:      &search.GetSearchSuggestionsRequest{Query: "server", Limit: maxLimit + 1},
+			expectedCode: connect.CodeInvalidArgument,
+		},
+		{
+			name:         "it returns an error while the packages are being indexed",
+			crawler:      newTestCrawler(packagesServer, []string{"default", "other"}, indexTime),
+			request:      &search.GetSearchSuggestionsRequest{Query: "server"},
+			expectedCode: connect.CodeUnavailable,
+		},
+		{
+			name:         "it returns an error if the search is not enabled",
+			request:      &search.GetSearchSuggestionsRequest{Query: "server"},
+			expectedCode: connect.CodeFailedPrecondition,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			request := connect.NewRequest(tc.request)
+			switch tc.authorization {
+			case "":
+				request.Header().Set("Authorization", "Bearer user-token")
+			case "none":
+			default:
+				request.Header().Set("Authorization", tc.authorization)
+			}
+			response, err := newTestSearchServer(tc.crawler, tc.allowed).GetSearchSuggestions(context.Background(), request)
+
+			if got, want := connect.CodeOf(err), tc.expectedCode; err != nil && got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+			if tc.expectedCode != 0 {
+				if err == nil {
+					t.Fatalf("got: nil, want: %+v", tc.expectedCode)
+				}
+				return
+			}
+			if got, want := suggestionKeys(response.Msg.GetSuggestions()), tc.expectedKeys; !cmp.Equal(want, got) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+			if got, want := response.Msg.GetIndexTime().AsTime(), indexTime; !got.Equal(want) {
+				t.Errorf("got: %v, want: %v", got, want)
+			}
+		})
+	}
+}
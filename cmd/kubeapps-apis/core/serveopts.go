@@ -6,6 +6,7 @@ package core
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"google.golang.org/grpc"
@@ -14,16 +15,43 @@ import (
 
 // ServeOptions encapsulates the available command-line options.
 type ServeOptions struct {
-	Port                     int
-	PluginDirs               []string
-	ClustersConfigPath       string
-	PluginConfigPath         string
-	PinnipedProxyURL         string
-	PinnipedProxyCACert      string
-	GlobalHelmReposNamespace string
-	UnsafeLocalDevKubeconfig bool
-	QPS                      float32
-	Burst                    int
+	Port                       int
+	MetricsPort                int
+	PluginDirs                 []string
+	ClustersConfigPath         string
+	PluginConfigPath           string
+	PluginConfigReloadInterval time.Duration
+	PinnipedProxyURL           string
+	PinnipedProxyCACert        string
+	GlobalHelmReposNamespace   string
+	UnsafeLocalDevKubeconfig   bool
+	QPS                        float32
+	Burst                      int
+	FeaturesSigningKeyPath     string
+	AuditLogEnabled            bool
+	AuditWebhookURL            string
+	CompressMinBytes           int
+	MaxMessageBytes            int
+	TracingOTLPEndpoint        string
+	TracingOTLPInsecure        bool
+	TracingSampleRatio         float64
+	RepoHealthWebhookURL       string
+	RepoHealthCheckInterval    time.Duration
+	RepoHealthFailingWindow    time.Duration
+	ImpersonationEnabled       bool
+	RateLimitQPS               float64
+	RateLimitBurst             int
+	MaxConcurrentRequests      int
+	UpgradePoliciesInterval    time.Duration
+	IconsMaxBytes              int
+	IconsProxyPrefix           string
+	IconsAllowedDomains        []string
+	IconsAllowedNetworks       []string
+	PluginTimeout              time.Duration
+	NotificationsInterval      time.Duration
+	ClustersSyncInterval       time.Duration
+	SearchIndexInterval        time.Duration
+	JobsRetention              time.Duration
 }
 
 // GatewayHandlerArgs is a helper struct just encapsulating all the args
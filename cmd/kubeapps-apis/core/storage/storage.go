@@ -0,0 +1,80 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package storage updates the ConfigMaps and Secrets in which the core
+// services store their state, in the namespace where Kubeapps is installed.
+package storage
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+// Object is an object storing the state of a core service.
+type Object interface {
+	*corev1.ConfigMap | *corev1.Secret
+	metav1.Object
+}
+
+// Client is the client of the objects of a namespace, such as the one
+// returned by CoreV1().ConfigMaps(namespace).
+type Client[T Object] interface {
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (T, error)
+	Create(ctx context.Context, object T, opts metav1.CreateOptions) (T, error)
+	Update(ctx context.Context, object T, opts metav1.UpdateOptions) (T, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+}
+
+// Update applies the change to the object of the given name, which is nil if
+// it does not exist yet. The change returns the object to store: the
+// existing one once modified, a new one to create it, or nil to delete it.
+//
+// Several replicas (or requests) may be updating the object at the same
+// time, so the read-modify-write is retried if the object was created,
+// updated or deleted in the meantime: its resource version is sent with the
+// update and the deletion. The errors of the change are returned as they are.
+func Update[T Object](ctx context.Context, client Client[T], resource, name string, change func(existing T) (T, error)) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		existing, err := client.Get(ctx, name, metav1.GetOptions{})
+		notFound := k8serrors.IsNotFound(err)
+		if err != nil && !notFound {
+			return err
+		}
+		if notFound {
+			existing = nil
+		}
+
+		updated, err := change(existing)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case updated == nil && existing == nil:
+			return nil
+		case updated == nil:
+			resourceVersion := existing.GetResourceVersion()
+			err = client.Delete(ctx, name, metav1.DeleteOptions{
+				Preconditions: &metav1.Preconditions{ResourceVersion: &resourceVersion},
+			})
+			if k8serrors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		case existing == nil:
+			_, err = client.Create(ctx, updated, metav1.CreateOptions{})
+			if k8serrors.IsAlreadyExists(err) {
+				// Created concurrently, retry as an update.
+				return k8serrors.NewConflict(corev1.Resource(resource), name, err)
+			}
+			return err
+		default:
+			_, err = client.Update(ctx, updated, metav1.UpdateOptions{})
+			return err
+		}
+	})
+}
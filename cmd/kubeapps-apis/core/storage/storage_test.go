@@ -0,0 +1,182 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+const testNamespace = "kubeapps"
+
+// setValue returns a change setting the value of the ConfigMap, creating it
+// if needed, or deleting it if the value is empty.
+func setValue(value string) func(*corev1.ConfigMap) (*corev1.ConfigMap, error) {
+	return func(existing *corev1.ConfigMap) (*corev1.ConfigMap, error) {
+		if value == "" {
+			return nil, nil
+		}
+		if existing == nil {
+			existing = &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "state", Namespace: testNamespace}}
+		}
+		existing.Data = map[string]string{"value": value}
+		return existing, nil
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	testCases := []struct {
+		name          string
+		existing      []runtime.Object
+		value         string
+		expectedValue string
+		expectDeleted bool
+	}{
+		{
+			name:          "it creates the object",
+			value:         "a",
+			expectedValue: "a",
+		},
+		{
+			name: "it updates the object",
+			existing: []runtime.Object{&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "state", Namespace: testNamespace},
+				Data:       map[string]string{"value": "a"},
+			}},
+			value:         "b",
+			expectedValue: "b",
+		},
+		{
+			name: "it deletes the object",
+			existing: []runtime.Object{&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "state", Namespace: testNamespace},
+				Data:       map[string]string{"value": "a"},
+			}},
+			expectDeleted: true,
+		},
+		{
+			name:          "it does nothing if the object to delete does not exist",
+			expectDeleted: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			clientSet := fake.NewSimpleClientset(tc.existing...)
+			configMaps := clientSet.CoreV1().ConfigMaps(testNamespace)
+
+			if err := Update(context.Background(), configMaps, "configmaps", "state", setValue(tc.value)); err != nil {
+				t.Fatalf("%+v", err)
+			}
+
+			cm, err := configMaps.Get(context.Background(), "state", metav1.GetOptions{})
+			if tc.expectDeleted {
+				if !k8serrors.IsNotFound(err) {
+					t.Errorf("got: %+v, want: not found", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if got, want := cm.Data["value"], tc.expectedValue; got != want {
+				t.Errorf("got: %q, want: %q", got, want)
+			}
+		})
+	}
+}
+
+func TestUpdateRetriesConflicts(t *testing.T) {
+	clientSet := fake.NewSimpleClientset()
+	// The object is created concurrently, then updated concurrently once.
+	created := false
+	updated := false
+	clientSet.PrependReactor("create", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if created {
+			return false, nil, nil
+		}
+		created = true
+		cm := action.(k8stesting.CreateAction).GetObject().(*corev1.ConfigMap).DeepCopy()
+		cm.Data = map[string]string{"value": "concurrent"}
+		if err := clientSet.Tracker().Add(cm); err != nil {
+			t.Fatalf("%+v", err)
+		}
+		return true, nil, k8serrors.NewAlreadyExists(corev1.Resource("configmaps"), cm.Name)
+	})
+	clientSet.PrependReactor("update", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if updated {
+			return false, nil, nil
+		}
+		updated = true
+		return true, nil, k8serrors.NewConflict(corev1.Resource("configmaps"), "state", errors.New("changed"))
+	})
+	configMaps := clientSet.CoreV1().ConfigMaps(testNamespace)
+
+	seen := []string{}
+	err := Update(context.Background(), configMaps, "configmaps", "state", func(existing *corev1.ConfigMap) (*corev1.ConfigMap, error) {
+		if existing == nil {
+			seen = append(seen, "")
+		} else {
+			seen = append(seen, existing.Data["value"])
+		}
+		return setValue("mine")(existing)
+	})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	// The change is applied again to the object as stored after each conflict.
+	if got, want := seen, []string{"", "concurrent", "concurrent"}; !cmp.Equal(want, got) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+	}
+	cm, err := configMaps.Get(context.Background(), "state", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if got, want := cm.Data["value"], "mine"; got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+}
+
+func TestUpdateReturnsChangeErrors(t *testing.T) {
+	configMaps := fake.NewSimpleClientset().CoreV1().ConfigMaps(testNamespace)
+	changeErr := errors.New("invalid change")
+
+	err := Update(context.Background(), configMaps, "configmaps", "state", func(existing *corev1.ConfigMap) (*corev1.ConfigMap, error) {
+		return nil, changeErr
+	})
+	if got, want := err, changeErr; got != want {
+		t.Errorf("got: %+v, want: %+v", got, want)
+	}
+}
+
+func TestUpdateSecrets(t *testing.T) {
+	secrets := fake.NewSimpleClientset().CoreV1().Secrets(testNamespace)
+
+	err := Update(context.Background(), secrets, "secrets", "state", func(existing *corev1.Secret) (*corev1.Secret, error) {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "state", Namespace: testNamespace},
+			Data:       map[string][]byte{"value": []byte("a")},
+		}, nil
+	})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	secret, err := secrets.Get(context.Background(), "state", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if got, want := string(secret.Data["value"]), "a"; got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+}
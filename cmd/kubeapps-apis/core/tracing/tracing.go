@@ -0,0 +1,164 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package tracing sets up the OpenTelemetry tracing of the kubeapps-apis
+// service, as well as the helpers used to trace a request through the core,
+// the plugins and the Kubernetes API servers.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/bufbuild/connect-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	serviceName = "kubeapps-apis"
+	tracerName  = "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis"
+
+	// rpcSystem is the value of the rpc.system attribute for the connect-go
+	// handlers, which serve the gRPC, gRPC-Web and Connect protocols.
+	rpcSystem = "connect_rpc"
+
+	pluginAttributeKey    = attribute.Key("kubeapps.plugin")
+	errorCodeAttributeKey = attribute.Key("rpc.connect_rpc.error_code")
+)
+
+// Options configures the export of the traces.
+type Options struct {
+	// OTLPEndpoint is the host and port of the OTLP/HTTP collector to
+	// which the traces are exported. Tracing is disabled if empty.
+	OTLPEndpoint string
+	// OTLPInsecure disables the TLS of the connection to the collector.
+	OTLPInsecure bool
+	// SampleRatio is the ratio of the traces sampled when the incoming
+	// request does not carry a sampling decision.
+	SampleRatio float64
+}
+
+// Setup registers the global tracer provider exporting the traces to the
+// configured collector, along with the W3C trace context propagation. The
+// returned function flushes and stops the export.
+func Setup(ctx context.Context, opts Options) (func(context.Context) error, error) {
+	if opts.OTLPEndpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporterOpts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(opts.OTLPEndpoint)}
+	if opts.OTLPInsecure {
+		exporterOpts = append(exporterOpts, otlptracehttp.WithInsecure())
+	}
+	exporter, err := otlptracehttp.New(ctx, exporterOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create the OTLP trace exporter: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(opts.SampleRatio))),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(serviceName))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	return provider.Shutdown, nil
+}
+
+// NewInterceptor returns a connect interceptor which continues the trace of
+// the incoming request, if any, with a server span for every unary RPC.
+func NewInterceptor() connect.Interceptor {
+	return connect.UnaryInterceptorFunc(func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			ctx = otel.GetTextMapPropagator().Extract(ctx, propagation.HeaderCarrier(req.Header()))
+
+			procedure := strings.TrimPrefix(req.Spec().Procedure, "/")
+			service, method, _ := strings.Cut(procedure, "/")
+			ctx, span := tracer().Start(ctx, procedure,
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(
+					semconv.RPCSystemKey.String(rpcSystem),
+					semconv.RPCService(service),
+					semconv.RPCMethod(method),
+				),
+			)
+			res, err := next(ctx, req)
+			EndSpan(span, err)
+			return res, err
+		}
+	})
+}
+
+// StartPluginSpan starts the span of an RPC handled by a plugin on behalf of
+// the core, which calls the plugin servers directly rather than through
+// their connect handlers.
+func StartPluginSpan(ctx context.Context, plugin, method string) (context.Context, trace.Span) {
+	return tracer().Start(ctx, fmt.Sprintf("plugin %s/%s", plugin, method),
+		trace.WithAttributes(
+			pluginAttributeKey.String(plugin),
+			semconv.RPCMethod(method),
+		),
+	)
+}
+
+// EndSpan ends the span, recording the error, if any.
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		span.SetAttributes(errorCodeAttributeKey.String(connect.CodeOf(err).String()))
+	}
+	span.End()
+}
+
+// WrapTransport wraps the transport of a Kubernetes client so that every
+// request to the API server is traced with a client span, propagating the
+// trace context in the request headers.
+func WrapTransport(rt http.RoundTripper) http.RoundTripper {
+	return &tracingRoundTripper{next: rt}
+}
+
+type tracingRoundTripper struct {
+	next http.RoundTripper
+}
+
+func (t *tracingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, span := tracer().Start(req.Context(), "HTTP "+req.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.HTTPMethod(req.Method),
+			semconv.HTTPTarget(req.URL.Path),
+			semconv.NetPeerName(req.URL.Hostname()),
+		),
+	)
+	// A RoundTripper must not modify the request, so the trace context is
+	// injected in a clone.
+	req = req.Clone(ctx)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	res, err := t.next.RoundTrip(req)
+	if err != nil {
+		EndSpan(span, err)
+		return nil, err
+	}
+	span.SetAttributes(semconv.HTTPStatusCode(res.StatusCode))
+	if res.StatusCode >= http.StatusInternalServerError {
+		span.SetStatus(codes.Error, res.Status)
+	}
+	span.End()
+	return res, nil
+}
+
+func tracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}
@@ -0,0 +1,159 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core/connecttest"
+	packages "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	traceID      = "4bf92f3577b34da6a3ce929d0e0e4736"
+	traceparent  = "00-" + traceID + "-00f067aa0ba902b7-01"
+	getProcedure = "/kubeappsapis.core.packages.v1alpha1.PackagesService/GetAvailablePackageDetail"
+)
+
+func setupTestTracing(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(trace.NewNoopTracerProvider())
+		otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator())
+	})
+	return recorder
+}
+
+func attributeValue(attributes []attribute.KeyValue, key attribute.Key) string {
+	for _, kv := range attributes {
+		if kv.Key == key {
+			return kv.Value.Emit()
+		}
+	}
+	return ""
+}
+
+func TestInterceptor(t *testing.T) {
+	testCases := []struct {
+		name              string
+		err               error
+		expectedStatus    codes.Code
+		expectedErrorCode string
+	}{
+		{
+			name:           "it continues the trace of the request",
+			expectedStatus: codes.Unset,
+		},
+		{
+			name:              "it records the error code",
+			err:               connect.NewError(connect.CodeNotFound, errors.New("not found")),
+			expectedStatus:    codes.Error,
+			expectedErrorCode: "not_found",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := setupTestTracing(t)
+
+			request := connect.NewRequest(&packages.GetAvailablePackageDetailRequest{})
+			request.Header().Set("traceparent", traceparent)
+			var handlerTraceID string
+			handler := NewInterceptor().WrapUnary(func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+				handlerTraceID = trace.SpanContextFromContext(ctx).TraceID().String()
+				return nil, tc.err
+			})
+			_, err := handler(context.Background(), connecttest.Request{AnyRequest: request, Procedure: getProcedure})
+
+			if got, want := err, tc.err; got != want {
+				t.Fatalf("got: %+v, want: %+v", got, want)
+			}
+			if got, want := handlerTraceID, traceID; got != want {
+				t.Errorf("got: %q, want: %q", got, want)
+			}
+			spans := recorder.Ended()
+			if got, want := len(spans), 1; got != want {
+				t.Fatalf("got: %d spans, want: %d", got, want)
+			}
+			span := spans[0]
+			if got, want := span.Name(), "kubeappsapis.core.packages.v1alpha1.PackagesService/GetAvailablePackageDetail"; got != want {
+				t.Errorf("got: %q, want: %q", got, want)
+			}
+			if got, want := span.Parent().TraceID().String(), traceID; got != want {
+				t.Errorf("got: %q, want: %q", got, want)
+			}
+			if got, want := attributeValue(span.Attributes(), "rpc.method"), "GetAvailablePackageDetail"; got != want {
+				t.Errorf("got: %q, want: %q", got, want)
+			}
+			if got, want := span.Status().Code, tc.expectedStatus; got != want {
+				t.Errorf("got: %+v, want: %+v", got, want)
+			}
+			if got, want := attributeValue(span.Attributes(), errorCodeAttributeKey), tc.expectedErrorCode; got != want {
+				t.Errorf("got: %q, want: %q", got, want)
+			}
+		})
+	}
+}
+
+func TestWrapTransport(t *testing.T) {
+	recorder := setupTestTracing(t)
+
+	var receivedTraceparent string
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedTraceparent = r.Header.Get("traceparent")
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer apiServer.Close()
+
+	ctx, parent := StartPluginSpan(context.Background(), "helm.packages", "GetInstalledPackageDetail")
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, apiServer.URL+"/api/v1/namespaces/default/secrets/foo", nil)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	response, err := WrapTransport(http.DefaultTransport).RoundTrip(request)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	response.Body.Close()
+	EndSpan(parent, nil)
+
+	if got, want := request.Header.Get("traceparent"), ""; got != want {
+		t.Errorf("got: %q, want: the original request not to be modified", got)
+	}
+	spans := recorder.Ended()
+	if got, want := len(spans), 2; got != want {
+		t.Fatalf("got: %d spans, want: %d", got, want)
+	}
+	clientSpan, pluginSpan := spans[0], spans[1]
+	if got, want := receivedTraceparent, "00-"+clientSpan.SpanContext().TraceID().String()+"-"+clientSpan.SpanContext().SpanID().String()+"-01"; got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+	if got, want := clientSpan.Parent().SpanID(), pluginSpan.SpanContext().SpanID(); got != want {
+		t.Errorf("got: %s, want: %s", got, want)
+	}
+	if got, want := attributeValue(clientSpan.Attributes(), "http.target"), "/api/v1/namespaces/default/secrets/foo"; got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+	if got, want := attributeValue(clientSpan.Attributes(), "http.status_code"), "404"; got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+	if got, want := attributeValue(pluginSpan.Attributes(), pluginAttributeKey), "helm.packages"; got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+}
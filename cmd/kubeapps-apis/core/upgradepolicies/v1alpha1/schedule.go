@@ -0,0 +1,193 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	// The timezones of the policies are loaded from the embedded database,
+	// since the container image does not ship one.
+	_ "time/tzdata"
+
+	"github.com/Masterminds/semver/v3"
+	upgradepolicies "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/upgradepolicies/v1alpha1"
+)
+
+// maxWindowMinutes is the maximum length of a window, so that a window does
+// not overlap with the next one of a daily schedule.
+const maxWindowMinutes = 24 * 60
+
+// cronField is the range of the values of a field of a cron expression.
+type cronField struct {
+	name     string
+	min, max int
+}
+
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12},
+	// 7 is accepted for Sunday, as in most cron implementations.
+	{name: "day of week", min: 0, max: 7},
+}
+
+// schedule is a parsed cron expression. Each field is a bit set of the
+// values it matches.
+type schedule struct {
+	minutes, hours, daysOfMonth, months, daysOfWeek uint64
+	// anyDayOfMonth and anyDayOfWeek record whether the day fields are "*":
+	// when both are restricted, a day matching either of them matches.
+	anyDayOfMonth, anyDayOfWeek bool
+}
+
+// parseSchedule parses a cron expression with five fields, each being "*"
+// or a comma-separated list of values, ranges ("1-5") and steps ("*/15",
+// "0-30/10").
+func parseSchedule(expr string) (*schedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != len(cronFields) {
+		return nil, fmt.Errorf("the schedule %q must have %d fields", expr, len(cronFields))
+	}
+	bits := make([]uint64, len(fields))
+	for i, field := range fields {
+		var err error
+		if bits[i], err = parseCronField(field, cronFields[i]); err != nil {
+			return nil, fmt.Errorf("invalid %s in the schedule %q: %w", cronFields[i].name, expr, err)
+		}
+	}
+	s := &schedule{
+		minutes:       bits[0],
+		hours:         bits[1],
+		daysOfMonth:   bits[2],
+		months:        bits[3],
+		daysOfWeek:    bits[4],
+		anyDayOfMonth: fields[2] == "*",
+		anyDayOfWeek:  fields[4] == "*",
+	}
+	// Sunday is matched by both 0 and 7.
+	if s.daysOfWeek&(1<<7) != 0 {
+		s.daysOfWeek |= 1
+	}
+	return s, nil
+}
+
+func parseCronField(field string, r cronField) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			rangePart = part[:i]
+			if step, err = strconv.Atoi(part[i+1:]); err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step %q", part[i+1:])
+			}
+		}
+		low, high := r.min, r.max
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if low, err = parseCronValue(bounds[0], r); err != nil {
+				return 0, err
+			}
+			if high, err = parseCronValue(bounds[1], r); err != nil {
+				return 0, err
+			}
+			if low > high {
+				return 0, fmt.Errorf("invalid range %q", rangePart)
+			}
+		default:
+			value, err := parseCronValue(rangePart, r)
+			if err != nil {
+				return 0, err
+			}
+			low = value
+			// A single value with a step, such as "5/15", runs until the
+			// end of the range.
+			if step == 1 {
+				high = value
+			}
+		}
+		for value := low; value <= high; value += step {
+			bits |= 1 << value
+		}
+	}
+	return bits, nil
+}
+
+func parseCronValue(value string, r cronField) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", value)
+	}
+	if n < r.min || n > r.max {
+		return 0, fmt.Errorf("the value %d is not between %d and %d", n, r.min, r.max)
+	}
+	return n, nil
+}
+
+// matches returns whether the schedule matches the minute of t, in the
+// location of t.
+func (s *schedule) matches(t time.Time) bool {
+	if s.minutes&(1<<t.Minute()) == 0 || s.hours&(1<<t.Hour()) == 0 || s.months&(1<<int(t.Month())) == 0 {
+		return false
+	}
+	dayOfMonth := s.daysOfMonth&(1<<t.Day()) != 0
+	dayOfWeek := s.daysOfWeek&(1<<int(t.Weekday())) != 0
+	if !s.anyDayOfMonth && !s.anyDayOfWeek {
+		return dayOfMonth || dayOfWeek
+	}
+	return dayOfMonth && dayOfWeek
+}
+
+// window is the maintenance window of an upgrade policy.
+type window struct {
+	schedule *schedule
+	location *time.Location
+	minutes  int
+}
+
+// newWindow returns the window of the upgrade policy, or an error if the
+// policy is invalid.
+func newWindow(policy *upgradepolicies.UpgradePolicy) (*window, error) {
+	s, err := parseSchedule(policy.GetSchedule())
+	if err != nil {
+		return nil, err
+	}
+	location, err := time.LoadLocation(policy.GetTimezone())
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone %q: %w", policy.GetTimezone(), err)
+	}
+	if policy.GetWindowMinutes() < 1 || policy.GetWindowMinutes() > maxWindowMinutes {
+		return nil, fmt.Errorf("the window must last between 1 and %d minutes", maxWindowMinutes)
+	}
+	if policy.GetVersionConstraint() != "" {
+		if _, err := semver.NewConstraint(policy.GetVersionConstraint()); err != nil {
+			return nil, fmt.Errorf("invalid version constraint %q: %w", policy.GetVersionConstraint(), err)
+		}
+	}
+	return &window{
+		schedule: s,
+		location: location,
+		minutes:  int(policy.GetWindowMinutes()),
+	}, nil
+}
+
+// start returns the start of the window containing t, and false if t is
+// outside of the windows.
+func (w *window) start(t time.Time) (time.Time, bool) {
+	t = t.In(w.location).Truncate(time.Minute)
+	for i := 0; i < w.minutes; i++ {
+		candidate := t.Add(-time.Duration(i) * time.Minute)
+		if w.schedule.matches(candidate) {
+			return candidate, true
+		}
+	}
+	return time.Time{}, false
+}
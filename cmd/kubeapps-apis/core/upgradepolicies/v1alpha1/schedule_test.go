@@ -0,0 +1,205 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	upgradepolicies "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/upgradepolicies/v1alpha1"
+)
+
+func TestScheduleMatches(t *testing.T) {
+	testCases := []struct {
+		name            string
+		schedule        string
+		time            string
+		expectedMatches bool
+	}{
+		{
+			name:            "it matches every minute",
+			schedule:        "* * * * *",
+			time:            "2023-06-03T02:17:00Z",
+			expectedMatches: true,
+		},
+		{
+			name:            "it matches the minute and hour on the day of week",
+			schedule:        "0 2 * * 6",
+			time:            "2023-06-03T02:00:00Z",
+			expectedMatches: true,
+		},
+		{
+			name:     "it does not match another day of week",
+			schedule: "0 2 * * 6",
+			time:     "2023-06-04T02:00:00Z",
+		},
+		{
+			name:            "it matches Sunday as 7",
+			schedule:        "0 2 * * 7",
+			time:            "2023-06-04T02:00:00Z",
+			expectedMatches: true,
+		},
+		{
+			name:            "it matches the steps of a range",
+			schedule:        "10-50/20 * * * *",
+			time:            "2023-06-03T02:30:00Z",
+			expectedMatches: true,
+		},
+		{
+			name:     "it does not match outside of the steps of a range",
+			schedule: "10-50/20 * * * *",
+			time:     "2023-06-03T02:20:00Z",
+		},
+		{
+			name:            "it matches a value of a list",
+			schedule:        "0 1,3,5 * * *",
+			time:            "2023-06-03T03:00:00Z",
+			expectedMatches: true,
+		},
+		{
+			name:            "it matches either restricted day field",
+			schedule:        "0 2 1 * 1",
+			time:            "2023-06-05T02:00:00Z",
+			expectedMatches: true,
+		},
+		{
+			name:     "it requires the day of month when the day of week is not restricted",
+			schedule: "0 2 1-7 * *",
+			time:     "2023-06-08T02:00:00Z",
+		},
+		{
+			name:     "it does not match another month",
+			schedule: "0 2 * 1 *",
+			time:     "2023-06-03T02:00:00Z",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := parseSchedule(tc.schedule)
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			tm, err := time.Parse(time.RFC3339, tc.time)
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if got, want := s.matches(tm), tc.expectedMatches; got != want {
+				t.Errorf("got: %t, want: %t", got, want)
+			}
+		})
+	}
+}
+
+func TestParseScheduleErrors(t *testing.T) {
+	for _, schedule := range []string{
+		"",
+		"0 2 * *",
+		"0 2 * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"a * * * *",
+	} {
+		t.Run(schedule, func(t *testing.T) {
+			if _, err := parseSchedule(schedule); err == nil {
+				t.Errorf("got: nil, want: error")
+			}
+		})
+	}
+}
+
+func TestWindowStart(t *testing.T) {
+	policy := &upgradepolicies.UpgradePolicy{
+		Schedule:      "0 2 * * 6",
+		Timezone:      "Europe/Madrid",
+		WindowMinutes: 120,
+	}
+
+	testCases := []struct {
+		name          string
+		time          string
+		expectedStart string
+	}{
+		{
+			name:          "it returns the start of the window at its start",
+			time:          "2023-06-03T00:00:00Z",
+			expectedStart: "2023-06-03T02:00:00+02:00",
+		},
+		{
+			name:          "it returns the start of the window within it",
+			time:          "2023-06-03T01:59:30Z",
+			expectedStart: "2023-06-03T02:00:00+02:00",
+		},
+		{
+			name: "it returns no start at the end of the window",
+			time: "2023-06-03T02:00:00Z",
+		},
+		{
+			name: "it returns no start before the window, evaluated in the timezone",
+			time: "2023-06-02T23:59:59Z",
+		},
+	}
+
+	w, err := newWindow(policy)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tm, err := time.Parse(time.RFC3339, tc.time)
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			start, ok := w.start(tm)
+			got := ""
+			if ok {
+				got = start.Format(time.RFC3339)
+			}
+			if want := tc.expectedStart; got != want {
+				t.Errorf("got: %q, want: %q", got, want)
+			}
+		})
+	}
+}
+
+func TestNewWindowErrors(t *testing.T) {
+	testCases := []struct {
+		name   string
+		policy *upgradepolicies.UpgradePolicy
+	}{
+		{
+			name:   "it rejects an invalid schedule",
+			policy: &upgradepolicies.UpgradePolicy{Schedule: "0 2 * *", WindowMinutes: 60},
+		},
+		{
+			name:   "it rejects an unknown timezone",
+			policy: &upgradepolicies.UpgradePolicy{Schedule: "0 2 * * *", Timezone: "Europe/Atlantis", WindowMinutes: 60},
+		},
+		{
+			name:   "it rejects an empty window",
+			policy: &upgradepolicies.UpgradePolicy{Schedule: "0 2 * * *"},
+		},
+		{
+			name:   "it rejects a window longer than a day",
+			policy: &upgradepolicies.UpgradePolicy{Schedule: "0 2 * * *", WindowMinutes: 1441},
+		},
+		{
+			name:   "it rejects an invalid version constraint",
+			policy: &upgradepolicies.UpgradePolicy{Schedule: "0 2 * * *", WindowMinutes: 60, VersionConstraint: ">>1"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := newWindow(tc.policy); err == nil {
+				t.Errorf("got: nil, want: error")
+			}
+		})
+	}
+}
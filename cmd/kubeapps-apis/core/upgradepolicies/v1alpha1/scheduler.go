@@ -0,0 +1,296 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/bufbuild/connect-go"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core/authn"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core/storage"
+	packages "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1"
+	packagesconnect "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1/v1connect"
+	upgradepolicies "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/upgradepolicies/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/pkg/kube"
+	"google.golang.org/protobuf/encoding/protojson"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/transport"
+	log "k8s.io/klog/v2"
+)
+
+// serviceAccountTokenPath is the path of the token of the kubeapps-apis
+// service account, which is read at each check since it is rotated.
+const serviceAccountTokenPath = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+
+// Scheduler upgrades the installed packages within the windows of their
+// upgrade policies, to the latest version matching the version constraint of
+// each policy. At most one upgrade is attempted per window.
+type Scheduler struct {
+	clientSet      kubernetes.Interface
+	namespace      string
+	packagesClient packagesconnect.PackagesServiceClient
+	clustersConfig kube.ClustersConfig
+	interval       time.Duration
+	// token returns the token authenticating the upgrades on a cluster, so
+	// that it can be switched in tests.
+	token func(cluster string) (string, error)
+	now   func() time.Time
+}
+
+// NewScheduler returns a scheduler reading the upgrade policies stored in the
+// namespace with the given client, authenticated as the kubeapps-apis service
+// account. The installed packages are upgraded with the packages client by
+// impersonating the users who set the policies, so the service account, or
+// the service token of each additional cluster, must be allowed to
+// impersonate them.
+func NewScheduler(clientSet kubernetes.Interface, namespace string, packagesClient packagesconnect.PackagesServiceClient, clustersConfig kube.ClustersConfig, interval time.Duration) *Scheduler {
+	s := &Scheduler{
+		clientSet:      clientSet,
+		namespace:      namespace,
+		packagesClient: packagesClient,
+		clustersConfig: clustersConfig,
+		interval:       interval,
+		now:            time.Now,
+	}
+	s.token = s.clusterToken
+	return s
+}
+
+// Run checks the upgrade policies at the configured interval until the
+// context is done.
+func (s *Scheduler) Run(ctx context.Context) {
+	log.Infof("Checking the upgrade policies every %s", s.interval)
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		if err := s.Check(ctx); err != nil {
+			log.Errorf("Unable to check the upgrade policies: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check lists the upgrade policies once, upgrading the installed packages
+// whose window is open. A failure to upgrade an installed package is recorded
+// in its history, and not retried until its next window.
+func (s *Scheduler) Check(ctx context.Context) error {
+	cms, err := s.clientSet.CoreV1().ConfigMaps(s.namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: UpgradePolicyLabel,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to list the upgrade policies: %w", err)
+	}
+
+	now := s.now()
+	for i := range cms.Items {
+		cm := &cms.Items[i]
+		stored, err := storedPolicyFromConfigMap(cm)
+		if err != nil {
+			log.Errorf("Ignoring the upgrade policy %q: %v", cm.Name, err)
+			continue
+		}
+		w, err := newWindow(stored.policy)
+		if err != nil {
+			log.Errorf("Ignoring the upgrade policy %q: %v", cm.Name, err)
+			continue
+		}
+		start, ok := w.start(now)
+		if !ok || stored.lastWindow == start.Format(time.RFC3339) {
+			continue
+		}
+		token, err := s.token(stored.installedPackageRef.GetContext().GetCluster())
+		if err != nil {
+			log.Errorf("Unable to upgrade the installed package %q: %v", stored.installedPackageRef.GetIdentifier(), err)
+			continue
+		}
+		if err := s.upgrade(ctx, cm, stored, start, userHeaders(token, stored.user)); err != nil {
+			log.Errorf("Unable to upgrade the installed package %q: %v", stored.installedPackageRef.GetIdentifier(), err)
+		}
+	}
+	return nil
+}
+
+// upgrade upgrades the installed package of the policy to the latest version
+// matching its constraint, if any, recording the attempt in the history.
+func (s *Scheduler) upgrade(ctx context.Context, cm *corev1.ConfigMap, stored *storedPolicy, windowStart time.Time, headers http.Header) error {
+	detailRequest := connect.NewRequest(&packages.GetInstalledPackageDetailRequest{
+		InstalledPackageRef: stored.installedPackageRef,
+	})
+	authn.CopyHeaders(detailRequest.Header(), headers)
+	detailResponse, err := s.packagesClient.GetInstalledPackageDetail(ctx, detailRequest)
+	if err != nil {
+		return err
+	}
+	detail := detailResponse.Msg.GetInstalledPackageDetail()
+	// Installed packages being installed or upgraded are checked again
+	// later in the window.
+	if detail.GetStatus().GetReason() != packages.InstalledPackageStatus_STATUS_REASON_INSTALLED {
+		return nil
+	}
+
+	current := detail.GetCurrentVersion().GetPkgVersion()
+	target, err := s.targetVersion(ctx, detail, stored.policy.GetVersionConstraint(), headers)
+	if err != nil || target == "" {
+		return err
+	}
+
+	// Several replicas may be checking the policies, so the window is claimed
+	// by updating the ConfigMap as listed: only the replica whose update
+	// succeeds upgrades the installed package.
+	claimed := cm.DeepCopy()
+	if claimed.Data == nil {
+		claimed.Data = map[string]string{}
+	}
+	claimed.Data[lastWindowDataKey] = windowStart.Format(time.RFC3339)
+	if _, err := s.clientSet.CoreV1().ConfigMaps(s.namespace).Update(ctx, claimed, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("unable to claim the window: %w", err)
+	}
+
+	entry := &upgradepolicies.UpgradeHistoryEntry{
+		Time:        s.now().Format(time.RFC3339),
+		FromVersion: current,
+		ToVersion:   target,
+		Result:      upgradepolicies.UpgradeHistoryEntry_RESULT_SUCCEEDED,
+	}
+	updateRequest := connect.NewRequest(&packages.UpdateInstalledPackageRequest{
+		InstalledPackageRef:   stored.installedPackageRef,
+		PkgVersionReference:   &packages.VersionReference{Version: target},
+		Values:                detail.GetValuesApplied(),
+		ReconciliationOptions: detail.GetReconciliationOptions(),
+	})
+	authn.CopyHeaders(updateRequest.Header(), headers)
+	if _, err := s.packagesClient.UpdateInstalledPackage(ctx, updateRequest); err != nil {
+		entry.Result = upgradepolicies.UpgradeHistoryEntry_RESULT_FAILED
+		entry.Message = err.Error()
+	}
+	log.InfoS("Scheduled upgrade", "installedPackageRef", stored.installedPackageRef, "from", current, "to", target, "result", entry.Result)
+	return s.recordUpgrade(ctx, cm.Name, entry)
+}
+
+// targetVersion returns the latest version of the installed package matching
+// the constraint, or the caret constraint of the current version if empty, or
+// an empty string if the installed package is up to date.
+func (s *Scheduler) targetVersion(ctx context.Context, detail *packages.InstalledPackageDetail, constraint string, headers http.Header) (string, error) {
+	current, err := semver.NewVersion(detail.GetCurrentVersion().GetPkgVersion())
+	if err != nil {
+		return "", fmt.Errorf("unable to parse the current version %q: %w", detail.GetCurrentVersion().GetPkgVersion(), err)
+	}
+	if constraint == "" {
+		constraint = "^" + current.String()
+	}
+	constraints, err := semver.NewConstraint(constraint)
+	if err != nil {
+		return "", fmt.Errorf("invalid version constraint %q: %w", constraint, err)
+	}
+
+	versionsRequest := connect.NewRequest(&packages.GetAvailablePackageVersionsRequest{
+		AvailablePackageRef: detail.GetAvailablePackageRef(),
+		Constraint:          constraint,
+	})
+	authn.CopyHeaders(versionsRequest.Header(), headers)
+	versionsResponse, err := s.packagesClient.GetAvailablePackageVersions(ctx, versionsRequest)
+	if err != nil {
+		return "", err
+	}
+
+	var latest *semver.Version
+	for _, v := range versionsResponse.Msg.GetPackageAppVersions() {
+		version, err := semver.NewVersion(v.GetPkgVersion())
+		if err != nil || !version.GreaterThan(current) || !constraints.Check(version) {
+			continue
+		}
+		if latest == nil || version.GreaterThan(latest) {
+			latest = version
+		}
+	}
+	if latest == nil {
+		return "", nil
+	}
+	return latest.Original(), nil
+}
+
+// recordUpgrade adds the entry at the top of the history of the policy,
+// keeping the latest entries only.
+func (s *Scheduler) recordUpgrade(ctx context.Context, name string, entry *upgradepolicies.UpgradeHistoryEntry) error {
+	return storage.Update(ctx, s.clientSet.CoreV1().ConfigMaps(s.namespace), "configmaps", name, func(cm *corev1.ConfigMap) (*corev1.ConfigMap, error) {
+		if cm == nil {
+			// The policy was deleted meanwhile.
+			return nil, k8serrors.NewNotFound(corev1.Resource("configmaps"), name)
+		}
+		history := &upgradepolicies.GetUpgradeHistoryResponse{}
+		if cm.Data[upgradeHistoryDataKey] != "" {
+			if err := protojson.Unmarshal([]byte(cm.Data[upgradeHistoryDataKey]), history); err != nil {
+				return nil, fmt.Errorf("unable to parse the %q key: %w", upgradeHistoryDataKey, err)
+			}
+		}
+		history.Entries = append([]*upgradepolicies.UpgradeHistoryEntry{entry}, history.Entries...)
+		if len(history.Entries) > maxHistoryEntries {
+			history.Entries = history.Entries[:maxHistoryEntries]
+		}
+		value, err := protojson.Marshal(history)
+		if err != nil {
+			return nil, err
+		}
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+		cm.Data[upgradeHistoryDataKey] = string(value)
+		return cm, nil
+	})
+}
+
+// userHeaders returns the headers of the requests made on behalf of the user
+// who set a policy: authenticated as the service account, impersonating the
+// user.
+func userHeaders(token string, user policyUser) http.Header {
+	headers := http.Header{}
+	headers.Set("Authorization", "Bearer "+token)
+	headers.Set(transport.ImpersonateUserHeader, user.Username)
+	for _, group := range user.Groups {
+		headers.Add(transport.ImpersonateGroupHeader, group)
+	}
+	return headers
+}
+
+// clusterToken returns the token of the kubeapps-apis service account for the
+// cluster where Kubeapps is installed, and the service token configured for an
+// additional cluster, on which the former is not valid.
+func (s *Scheduler) clusterToken(cluster string) (string, error) {
+	if kube.IsKubeappsClusterRef(cluster) || cluster == s.clustersConfig.KubeappsClusterName {
+		token, err := serviceAccountToken()
+		if err != nil {
+			return "", fmt.Errorf("unable to read the service account token: %w", err)
+		}
+		return token, nil
+	}
+	additionalCluster, ok := s.clustersConfig.Cluster(cluster)
+	if !ok {
+		return "", fmt.Errorf("the cluster %q has no configuration", cluster)
+	}
+	if additionalCluster.ServiceToken == "" {
+		return "", fmt.Errorf("the cluster %q has no service token", cluster)
+	}
+	return additionalCluster.ServiceToken, nil
+}
+
+func serviceAccountToken() (string, error) {
+	token, err := os.ReadFile(serviceAccountTokenPath)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(token)), nil
+}
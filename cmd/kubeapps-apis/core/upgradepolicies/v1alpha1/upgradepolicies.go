@@ -0,0 +1,328 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/bufbuild/connect-go"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core/authn"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core/storage"
+	packages "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1"
+	packagesconnect "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1/v1connect"
+	upgradepolicies "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/upgradepolicies/v1alpha1"
+	"google.golang.org/protobuf/encoding/protojson"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/transport"
+	log "k8s.io/klog/v2"
+)
+
+const (
+	// UpgradePolicyLabel is the label identifying the ConfigMaps, in the
+	// Kubeapps namespace, which store an upgrade policy.
+	UpgradePolicyLabel = "kubeapps.dev/upgrade-policy"
+
+	// The ConfigMap keys holding the installed package, its upgrade policy
+	// and its upgrade history serialized as JSON, the user impersonated to
+	// upgrade it, and the start (RFC3339) of the last window in which an
+	// upgrade was attempted.
+	installedPackageRefDataKey = "installedPackageRef"
+	upgradePolicyDataKey       = "policy"
+	upgradeHistoryDataKey      = "history"
+	userDataKey                = "user"
+	lastWindowDataKey          = "lastWindow"
+
+	// maxHistoryEntries is the number of upgrades kept in the history of
+	// each installed package.
+	maxHistoryEntries = 20
+)
+
+// upgradePoliciesServer implements the API defined in proto/kubeappsapis/core/upgradepolicies/v1alpha1/upgradepolicies.proto
+type upgradePoliciesServer struct {
+	upgradepolicies.UnimplementedUpgradePoliciesServiceServer
+
+	// clientSet identifies the users setting a policy, whom the scheduler
+	// later impersonates, and stores the policies in ConfigMaps which only
+	// the scheduler and this server read.
+	clientSet kubernetes.Interface
+
+	// namespace in which the upgrade policies are stored.
+	namespace string
+
+	// packagesServer is the core.packages.v1 server with which the access of
+	// the users to the installed packages is checked.
+	packagesServer packagesconnect.PackagesServiceHandler
+
+	// enabled is whether the scheduled upgrades are enabled. The policies
+	// cannot be set otherwise, as they would never be applied.
+	enabled bool
+}
+
+func NewUpgradePoliciesServer(clientSet kubernetes.Interface, namespace string, packagesServer packagesconnect.PackagesServiceHandler, enabled bool) (*upgradePoliciesServer, error) {
+	if namespace == "" {
+		return nil, fmt.Errorf("a namespace is required to store the upgrade policies")
+	}
+	return &upgradePoliciesServer{
+		clientSet:      clientSet,
+		namespace:      namespace,
+		packagesServer: packagesServer,
+		enabled:        enabled,
+	}, nil
+}
+
+// GetUpgradePolicy returns the upgrade policy of an installed package.
+func (s *upgradePoliciesServer) GetUpgradePolicy(ctx context.Context, request *connect.Request[upgradepolicies.GetUpgradePolicyRequest]) (*connect.Response[upgradepolicies.GetUpgradePolicyResponse], error) {
+	log.InfoS("+core GetUpgradePolicy", "installedPackageRef", request.Msg.GetInstalledPackageRef())
+
+	stored, err := s.getStoredPolicy(ctx, request.Header(), request.Msg.GetInstalledPackageRef())
+	if err != nil {
+		return nil, err
+	}
+
+	return connect.NewResponse(&upgradepolicies.GetUpgradePolicyResponse{
+		UpgradePolicy: stored.policy,
+	}), nil
+}
+
+// SetUpgradePolicy creates or replaces the upgrade policy of an installed
+// package. The installed package will be upgraded by impersonating the
+// calling user, who must be able to read it.
+func (s *upgradePoliciesServer) SetUpgradePolicy(ctx context.Context, request *connect.Request[upgradepolicies.SetUpgradePolicyRequest]) (*connect.Response[upgradepolicies.SetUpgradePolicyResponse], error) {
+	log.InfoS("+core SetUpgradePolicy", "installedPackageRef", request.Msg.GetInstalledPackageRef())
+
+	if !s.enabled {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("The scheduled upgrades are not enabled"))
+	}
+	if request.Msg.GetUpgradePolicy() == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Unable to set the upgrade policy (missing request.UpgradePolicy)"))
+	}
+	if _, err := newWindow(request.Msg.GetUpgradePolicy()); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Invalid upgrade policy: %w", err))
+	}
+
+	ref := request.Msg.GetInstalledPackageRef()
+	detail, err := s.checkAccess(ctx, request.Header(), ref)
+	if err != nil {
+		return nil, err
+	}
+	// Installed packages whose version is a constraint are already upgraded
+	// by the plugin reconciling them.
+	if _, err := semver.NewVersion(detail.GetPkgVersionReference().GetVersion()); err != nil {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("The version %q of the installed package is not an exact version, it is upgraded by the plugin reconciling it", detail.GetPkgVersionReference().GetVersion()))
+	}
+
+	user, err := s.identifyUser(ctx, request.Header())
+	if err != nil {
+		return nil, err
+	}
+
+	refValue, err := protojson.Marshal(ref)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to serialize the installed package reference: %w", err))
+	}
+	policyValue, err := protojson.Marshal(request.Msg.GetUpgradePolicy())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to serialize the upgrade policy: %w", err))
+	}
+	userValue, err := json.Marshal(user)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to serialize the user: %w", err))
+	}
+	data := map[string]string{
+		installedPackageRefDataKey: string(refValue),
+		upgradePolicyDataKey:       string(policyValue),
+		userDataKey:                string(userValue),
+	}
+
+	// The scheduler may be recording an upgrade at the same time, so retry
+	// the read-modify-write if the resource version changed. The history of
+	// a replaced policy is kept.
+	name := configMapName(ref)
+	err = storage.Update(ctx, s.clientSet.CoreV1().ConfigMaps(s.namespace), "configmaps", name, func(cm *corev1.ConfigMap) (*corev1.ConfigMap, error) {
+		if cm == nil {
+			cm = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: s.namespace,
+					Labels:    map[string]string{UpgradePolicyLabel: "true"},
+				},
+			}
+		} else if _, ok := cm.Labels[UpgradePolicyLabel]; !ok {
+			return nil, fmt.Errorf("the ConfigMap %q does not store an upgrade policy", name)
+		}
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+		for key, value := range data {
+			cm.Data[key] = value
+		}
+		return cm, nil
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to store the upgrade policy: %w", err))
+	}
+
+	return connect.NewResponse(&upgradepolicies.SetUpgradePolicyResponse{
+		UpgradePolicy: request.Msg.GetUpgradePolicy(),
+	}), nil
+}
+
+// DeleteUpgradePolicy deletes the upgrade policy of an installed package,
+// along with its upgrade history.
+func (s *upgradePoliciesServer) DeleteUpgradePolicy(ctx context.Context, request *connect.Request[upgradepolicies.DeleteUpgradePolicyRequest]) (*connect.Response[upgradepolicies.DeleteUpgradePolicyResponse], error) {
+	log.InfoS("+core DeleteUpgradePolicy", "installedPackageRef", request.Msg.GetInstalledPackageRef())
+
+	ref := request.Msg.GetInstalledPackageRef()
+	if _, err := s.getStoredPolicy(ctx, request.Header(), ref); err != nil {
+		return nil, err
+	}
+	err := s.clientSet.CoreV1().ConfigMaps(s.namespace).Delete(ctx, configMapName(ref), metav1.DeleteOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to delete the upgrade policy: %w", err))
+	}
+
+	return connect.NewResponse(&upgradepolicies.DeleteUpgradePolicyResponse{}), nil
+}
+
+// GetUpgradeHistory returns the upgrades attempted within the windows of the
+// upgrade policy of an installed package.
+func (s *upgradePoliciesServer) GetUpgradeHistory(ctx context.Context, request *connect.Request[upgradepolicies.GetUpgradeHistoryRequest]) (*connect.Response[upgradepolicies.GetUpgradeHistoryResponse], error) {
+	log.InfoS("+core GetUpgradeHistory", "installedPackageRef", request.Msg.GetInstalledPackageRef())
+
+	stored, err := s.getStoredPolicy(ctx, request.Header(), request.Msg.GetInstalledPackageRef())
+	if err != nil {
+		return nil, err
+	}
+
+	return connect.NewResponse(&upgradepolicies.GetUpgradeHistoryResponse{
+		Entries: stored.history,
+	}), nil
+}
+
+// getStoredPolicy returns the upgrade policy stored for the installed
+// package, once checked that the calling user can read the installed package.
+func (s *upgradePoliciesServer) getStoredPolicy(ctx context.Context, headers http.Header, ref *packages.InstalledPackageReference) (*storedPolicy, error) {
+	if _, err := s.checkAccess(ctx, headers, ref); err != nil {
+		return nil, err
+	}
+	cm, err := s.clientSet.CoreV1().ConfigMaps(s.namespace).Get(ctx, configMapName(ref), metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("Unable to find an upgrade policy for the installed package %q", ref.GetIdentifier()))
+	} else if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to get the upgrade policy: %w", err))
+	}
+	// ConfigMaps without the upgrade policy label are reported as not
+	// found, so that the users cannot read other ConfigMaps of the Kubeapps
+	// namespace.
+	if _, ok := cm.Labels[UpgradePolicyLabel]; !ok {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("Unable to find an upgrade policy for the installed package %q", ref.GetIdentifier()))
+	}
+	stored, err := storedPolicyFromConfigMap(cm)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Invalid upgrade policy %q: %w", cm.Name, err))
+	}
+	return stored, nil
+}
+
+// checkAccess returns the detail of the installed package, read with the
+// credentials of the calling user, so that users can only manage the
+// policies of the installed packages they can read.
+func (s *upgradePoliciesServer) checkAccess(ctx context.Context, headers http.Header, ref *packages.InstalledPackageReference) (*packages.InstalledPackageDetail, error) {
+	if ref.GetIdentifier() == "" || ref.GetContext().GetNamespace() == "" || ref.GetPlugin() == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Unable to manage the upgrade policy (missing request.InstalledPackageRef identifier, namespace or plugin)"))
+	}
+	detailRequest := connect.NewRequest(&packages.GetInstalledPackageDetailRequest{
+		InstalledPackageRef: ref,
+	})
+	for key, values := range headers {
+		detailRequest.Header()[key] = values
+	}
+	response, err := s.packagesServer.GetInstalledPackageDetail(ctx, detailRequest)
+	if err != nil {
+		return nil, err
+	}
+	return response.Msg.GetInstalledPackageDetail(), nil
+}
+
+// identifyUser returns the user to impersonate when upgrading the installed
+// package: the user impersonated in the request if any, which was checked by
+// the API server when reading the installed package, or the user of the
+// token otherwise.
+func (s *upgradePoliciesServer) identifyUser(ctx context.Context, headers http.Header) (*policyUser, error) {
+	if username := headers.Get(transport.ImpersonateUserHeader); username != "" {
+		return &policyUser{Username: username, Groups: headers.Values(transport.ImpersonateGroupHeader)}, nil
+	}
+
+	user, err := authn.ReviewUser(ctx, s.clientSet, headers)
+	if err != nil {
+		return nil, err
+	}
+	return &policyUser{Username: user.Username, Groups: user.Groups}, nil
+}
+
+// policyUser is the user who set an upgrade policy.
+type policyUser struct {
+	Username string   `json:"username"`
+	Groups   []string `json:"groups,omitempty"`
+}
+
+// storedPolicy is an upgrade policy as stored in its ConfigMap.
+type storedPolicy struct {
+	installedPackageRef *packages.InstalledPackageReference
+	policy              *upgradepolicies.UpgradePolicy
+	history             []*upgradepolicies.UpgradeHistoryEntry
+	user                policyUser
+	lastWindow          string
+}
+
+func storedPolicyFromConfigMap(cm *corev1.ConfigMap) (*storedPolicy, error) {
+	stored := &storedPolicy{
+		installedPackageRef: &packages.InstalledPackageReference{},
+		policy:              &upgradepolicies.UpgradePolicy{},
+		lastWindow:          cm.Data[lastWindowDataKey],
+	}
+	if err := protojson.Unmarshal([]byte(cm.Data[installedPackageRefDataKey]), stored.installedPackageRef); err != nil {
+		return nil, fmt.Errorf("unable to parse the %q key: %w", installedPackageRefDataKey, err)
+	}
+	if err := protojson.Unmarshal([]byte(cm.Data[upgradePolicyDataKey]), stored.policy); err != nil {
+		return nil, fmt.Errorf("unable to parse the %q key: %w", upgradePolicyDataKey, err)
+	}
+	if err := json.Unmarshal([]byte(cm.Data[userDataKey]), &stored.user); err != nil {
+		return nil, fmt.Errorf("unable to parse the %q key: %w", userDataKey, err)
+	}
+	if cm.Data[upgradeHistoryDataKey] != "" {
+		// The history is stored in the format of the GetUpgradeHistory
+		// response.
+		history := &upgradepolicies.GetUpgradeHistoryResponse{}
+		if err := protojson.Unmarshal([]byte(cm.Data[upgradeHistoryDataKey]), history); err != nil {
+			return nil, fmt.Errorf("unable to parse the %q key: %w", upgradeHistoryDataKey, err)
+		}
+		stored.history = history.GetEntries()
+	}
+	return stored, nil
+}
+
+// configMapName returns the name of the ConfigMap storing the upgrade policy
+// of the installed package. The reference is hashed since it may be longer
+// than a ConfigMap name.
+func configMapName(ref *packages.InstalledPackageReference) string {
+	key := strings.Join([]string{
+		ref.GetPlugin().GetName(),
+		ref.GetContext().GetCluster(),
+		ref.GetContext().GetNamespace(),
+		ref.GetIdentifier(),
+	}, "/")
+	hash := sha256.Sum256([]byte(key))
+	return "upgrade-policy-" + hex.EncodeToString(hash[:])
+}
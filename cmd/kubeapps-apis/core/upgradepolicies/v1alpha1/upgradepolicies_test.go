@@ -0,0 +1,534 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/google/go-cmp/cmp"
+	packages "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1"
+	packagesconnect "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1/v1connect"
+	plugins "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/plugins/v1alpha1"
+	upgradepolicies "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/upgradepolicies/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/pkg/kube"
+	"google.golang.org/protobuf/testing/protocmp"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/transport"
+)
+
+var installedPackageRef = &packages.InstalledPackageReference{
+	Context:    &packages.Context{Cluster: "default", Namespace: "team-a"},
+	Identifier: "team-a-db",
+	Plugin:     &plugins.Plugin{Name: "helm.packages", Version: "v1alpha1"},
+}
+
+var weeklyPolicy = &upgradepolicies.UpgradePolicy{
+	Schedule:      "0 2 * * 6",
+	Timezone:      "Europe/Madrid",
+	WindowMinutes: 120,
+}
+
+// fakePackagesServer serves the detail of a single installed package to the
+// users in the readers map, and the available versions of its package,
+// recording the update requests it receives.
+type fakePackagesServer struct {
+	packagesconnect.UnimplementedPackagesServiceHandler
+
+	readers        map[string]bool
+	detail         *packages.InstalledPackageDetail
+	versions       []string
+	updateErr      error
+	updateRequests []*connect.Request[packages.UpdateInstalledPackageRequest]
+}
+
+func (s *fakePackagesServer) GetInstalledPackageDetail(ctx context.Context, request *connect.Request[packages.GetInstalledPackageDetailRequest]) (*connect.Response[packages.GetInstalledPackageDetailResponse], error) {
+	if !s.readers[request.Header().Get("Authorization")] {
+		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("forbidden"))
+	}
+	if request.Msg.GetInstalledPackageRef().GetIdentifier() != s.detail.GetInstalledPackageRef().GetIdentifier() {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("not found"))
+	}
+	return connect.NewResponse(&packages.GetInstalledPackageDetailResponse{
+		InstalledPackageDetail: s.detail,
+	}), nil
+}
+
+func (s *fakePackagesServer) GetAvailablePackageVersions(ctx context.Context, request *connect.Request[packages.GetAvailablePackageVersionsRequest]) (*connect.Response[packages.GetAvailablePackageVersionsResponse], error) {
+	versions := []*packages.PackageAppVersion{}
+	for _, version := range s.versions {
+		versions = append(versions, &packages.PackageAppVersion{PkgVersion: version})
+	}
+	return connect.NewResponse(&packages.GetAvailablePackageVersionsResponse{
+		PackageAppVersions: versions,
+	}), nil
+}
+
+func (s *fakePackagesServer) UpdateInstalledPackage(ctx context.Context, request *connect.Request[packages.UpdateInstalledPackageRequest]) (*connect.Response[packages.UpdateInstalledPackageResponse], error) {
+	s.updateRequests = append(s.updateRequests, request)
+	if s.updateErr != nil {
+		return nil, s.updateErr
+	}
+	return connect.NewResponse(&packages.UpdateInstalledPackageResponse{
+		InstalledPackageRef: request.Msg.GetInstalledPackageRef(),
+	}), nil
+}
+
+func newTestPackagesServer(version string) *fakePackagesServer {
+	return &fakePackagesServer{
+		readers: map[string]bool{"Bearer token-a": true, "Bearer sa-token": true},
+		detail: &packages.InstalledPackageDetail{
+			InstalledPackageRef: installedPackageRef,
+			AvailablePackageRef: &packages.AvailablePackageReference{
+				Context:    &packages.Context{Cluster: "default", Namespace: "kubeapps"},
+				Identifier: "bitnami/postgresql",
+				Plugin:     installedPackageRef.Plugin,
+			},
+			PkgVersionReference: &packages.VersionReference{Version: version},
+			CurrentVersion:      &packages.PackageAppVersion{PkgVersion: version},
+			ValuesApplied:       "auth:\n  database: orders\n",
+			Status:              &packages.InstalledPackageStatus{Ready: true, Reason: packages.InstalledPackageStatus_STATUS_REASON_INSTALLED},
+		},
+		versions: []string{"13.0.0", "12.2.0", "12.1.6", "12.1.5", "12.1.2"},
+	}
+}
+
+// newTestUpgradePoliciesServer returns a server whose token reviews
+// authenticate the tokens in the users map as the corresponding username,
+// in the "team-a" group.
+func newTestUpgradePoliciesServer(t *testing.T, packagesServer *fakePackagesServer, users map[string]string, enabled bool) (*upgradePoliciesServer, *fake.Clientset) {
+	clientSet := fake.NewSimpleClientset()
+	clientSet.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (handled bool, ret runtime.Object, err error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authenticationv1.TokenReview)
+		if username, ok := users[review.Spec.Token]; ok {
+			review.Status.Authenticated = true
+			review.Status.User.Username = username
+			review.Status.User.Groups = []string{"team-a"}
+		} else {
+			review.Status.Error = "invalid token"
+		}
+		return true, review, nil
+	})
+	server, err := NewUpgradePoliciesServer(clientSet, "kubeapps", packagesServer, enabled)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	return server, clientSet
+}
+
+func newRequestWithToken[T any](msg *T, token string) *connect.Request[T] {
+	request := connect.NewRequest(msg)
+	if token != "" {
+		request.Header().Set("Authorization", "Bearer "+token)
+	}
+	return request
+}
+
+func TestSetUpgradePolicy(t *testing.T) {
+	testCases := []struct {
+		name         string
+		request      *upgradepolicies.SetUpgradePolicyRequest
+		token        string
+		version      string
+		disabled     bool
+		expectedUser policyUser
+		errorCode    connect.Code
+	}{
+		{
+			name: "it stores the policy and the user to impersonate",
+			request: &upgradepolicies.SetUpgradePolicyRequest{
+				InstalledPackageRef: installedPackageRef,
+				UpgradePolicy:       weeklyPolicy,
+			},
+			token:        "token-a",
+			version:      "12.1.2",
+			expectedUser: policyUser{Username: "user-a", Groups: []string{"team-a"}},
+		},
+		{
+			name: "it returns failed precondition when the scheduled upgrades are disabled",
+			request: &upgradepolicies.SetUpgradePolicyRequest{
+				InstalledPackageRef: installedPackageRef,
+				UpgradePolicy:       weeklyPolicy,
+			},
+			token:     "token-a",
+			version:   "12.1.2",
+			disabled:  true,
+			errorCode: connect.CodeFailedPrecondition,
+		},
+		{
+			name: "it returns invalid argument for an invalid policy",
+			request: &upgradepolicies.SetUpgradePolicyRequest{
+				InstalledPackageRef: installedPackageRef,
+				UpgradePolicy:       &upgradepolicies.UpgradePolicy{Schedule: "0 2 * *", WindowMinutes: 60},
+			},
+			token:     "token-a",
+			version:   "12.1.2",
+			errorCode: connect.CodeInvalidArgument,
+		},
+		{
+			name: "it returns the error of a user who cannot read the installed package",
+			request: &upgradepolicies.SetUpgradePolicyRequest{
+				InstalledPackageRef: installedPackageRef,
+				UpgradePolicy:       weeklyPolicy,
+			},
+			token:     "token-b",
+			version:   "12.1.2",
+			errorCode: connect.CodePermissionDenied,
+		},
+		{
+			name: "it returns failed precondition for an installed package with a version constraint",
+			request: &upgradepolicies.SetUpgradePolicyRequest{
+				InstalledPackageRef: installedPackageRef,
+				UpgradePolicy:       weeklyPolicy,
+			},
+			token:     "token-a",
+			version:   ">=12.1.0",
+			errorCode: connect.CodeFailedPrecondition,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			packagesServer := newTestPackagesServer(tc.version)
+			server, clientSet := newTestUpgradePoliciesServer(t, packagesServer, map[string]string{"token-a": "user-a", "token-b": "user-b"}, !tc.disabled)
+
+			_, err := server.SetUpgradePolicy(context.Background(), newRequestWithToken(tc.request, tc.token))
+
+			if got, want := connect.CodeOf(err), tc.errorCode; err != nil && got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			} else if err == nil && tc.errorCode != 0 {
+				t.Fatalf("got: nil, want: error with code %+v", tc.errorCode)
+			}
+			if tc.errorCode != 0 {
+				return
+			}
+
+			cm, err := clientSet.CoreV1().ConfigMaps("kubeapps").Get(context.Background(), configMapName(installedPackageRef), metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			stored, err := storedPolicyFromConfigMap(cm)
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if got, want := stored.policy, tc.request.GetUpgradePolicy(); !cmp.Equal(got, want, protocmp.Transform()) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, protocmp.Transform()))
+			}
+			if got, want := stored.user, tc.expectedUser; !cmp.Equal(got, want) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
+func TestGetUpgradePolicyAndHistory(t *testing.T) {
+	packagesServer := newTestPackagesServer("12.1.2")
+	users := map[string]string{"token-a": "user-a", "token-b": "user-b"}
+	server, _ := newTestUpgradePoliciesServer(t, packagesServer, users, true)
+	_, err := server.SetUpgradePolicy(context.Background(), newRequestWithToken(&upgradepolicies.SetUpgradePolicyRequest{
+		InstalledPackageRef: installedPackageRef,
+		UpgradePolicy:       weeklyPolicy,
+	}, "token-a"))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	otherRef := &packages.InstalledPackageReference{
+		Context:    installedPackageRef.Context,
+		Identifier: "team-a-cache",
+		Plugin:     installedPackageRef.Plugin,
+	}
+
+	testCases := []struct {
+		name      string
+		ref       *packages.InstalledPackageReference
+		token     string
+		errorCode connect.Code
+	}{
+		{
+			name:  "it returns the policy of the installed package",
+			ref:   installedPackageRef,
+			token: "token-a",
+		},
+		{
+			name:      "it returns the error of a user who cannot read the installed package",
+			ref:       installedPackageRef,
+			token:     "token-b",
+			errorCode: connect.CodePermissionDenied,
+		},
+		{
+			name: "it returns invalid argument without a plugin",
+			ref: &packages.InstalledPackageReference{
+				Context:    installedPackageRef.Context,
+				Identifier: installedPackageRef.Identifier,
+			},
+			token:     "token-a",
+			errorCode: connect.CodeInvalidArgument,
+		},
+		{
+			name:      "it returns not found for an installed package without policy",
+			ref:       otherRef,
+			token:     "token-a",
+			errorCode: connect.CodeNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// The installed package without policy exists as well.
+			if tc.ref == otherRef {
+				packagesServer.detail.InstalledPackageRef = otherRef
+				defer func() { packagesServer.detail.InstalledPackageRef = installedPackageRef }()
+			}
+
+			response, err := server.GetUpgradePolicy(context.Background(), newRequestWithToken(&upgradepolicies.GetUpgradePolicyRequest{
+				InstalledPackageRef: tc.ref,
+			}, tc.token))
+			if got, want := connect.CodeOf(err), tc.errorCode; err != nil && got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			} else if err == nil && tc.errorCode != 0 {
+				t.Fatalf("got: nil, want: error with code %+v", tc.errorCode)
+			}
+			_, historyErr := server.GetUpgradeHistory(context.Background(), newRequestWithToken(&upgradepolicies.GetUpgradeHistoryRequest{
+				InstalledPackageRef: tc.ref,
+			}, tc.token))
+			if got, want := connect.CodeOf(historyErr), tc.errorCode; historyErr != nil && got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, historyErr)
+			}
+			if tc.errorCode != 0 {
+				return
+			}
+
+			if got, want := response.Msg.GetUpgradePolicy(), weeklyPolicy; !cmp.Equal(got, want, protocmp.Transform()) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, protocmp.Transform()))
+			}
+		})
+	}
+}
+
+func TestDeleteUpgradePolicy(t *testing.T) {
+	packagesServer := newTestPackagesServer("12.1.2")
+	server, _ := newTestUpgradePoliciesServer(t, packagesServer, map[string]string{"token-a": "user-a"}, true)
+	_, err := server.SetUpgradePolicy(context.Background(), newRequestWithToken(&upgradepolicies.SetUpgradePolicyRequest{
+		InstalledPackageRef: installedPackageRef,
+		UpgradePolicy:       weeklyPolicy,
+	}, "token-a"))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	request := &upgradepolicies.DeleteUpgradePolicyRequest{InstalledPackageRef: installedPackageRef}
+	if _, err := server.DeleteUpgradePolicy(context.Background(), newRequestWithToken(request, "token-a")); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	_, err = server.DeleteUpgradePolicy(context.Background(), newRequestWithToken(request, "token-a"))
+	if got, want := connect.CodeOf(err), connect.CodeNotFound; got != want {
+		t.Errorf("got: %+v, want: %+v, err: %+v", got, want, err)
+	}
+}
+
+func TestSchedulerCheck(t *testing.T) {
+	// The window of the weekly policy is open on Saturdays from 02:00 to
+	// 04:00 in Madrid, that is from 00:00 to 02:00 UTC in June.
+	inWindow := time.Date(2023, 6, 3, 0, 30, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name            string
+		now             time.Time
+		policy          *upgradepolicies.UpgradePolicy
+		updateErr       error
+		expectedUpdates []string
+		expectedHistory []*upgradepolicies.UpgradeHistoryEntry
+	}{
+		{
+			name:            "it upgrades to the latest version of the same major version within the window",
+			now:             inWindow,
+			policy:          weeklyPolicy,
+			expectedUpdates: []string{"12.2.0"},
+			expectedHistory: []*upgradepolicies.UpgradeHistoryEntry{{
+				Time:        "2023-06-03T00:30:00Z",
+				FromVersion: "12.1.2",
+				ToVersion:   "12.2.0",
+				Result:      upgradepolicies.UpgradeHistoryEntry_RESULT_SUCCEEDED,
+			}},
+		},
+		{
+			name: "it upgrades to the latest version matching the constraint of the policy",
+			now:  inWindow,
+			policy: &upgradepolicies.UpgradePolicy{
+				Schedule:          weeklyPolicy.Schedule,
+				Timezone:          weeklyPolicy.Timezone,
+				WindowMinutes:     weeklyPolicy.WindowMinutes,
+				VersionConstraint: "~12.1",
+			},
+			expectedUpdates: []string{"12.1.6"},
+			expectedHistory: []*upgradepolicies.UpgradeHistoryEntry{{
+				Time:        "2023-06-03T00:30:00Z",
+				FromVersion: "12.1.2",
+				ToVersion:   "12.1.6",
+				Result:      upgradepolicies.UpgradeHistoryEntry_RESULT_SUCCEEDED,
+			}},
+		},
+		{
+			name:   "it does not upgrade outside of the window",
+			now:    inWindow.Add(2 * time.Hour),
+			policy: weeklyPolicy,
+		},
+		{
+			name:            "it records a failed upgrade",
+			now:             inWindow,
+			policy:          weeklyPolicy,
+			updateErr:       connect.NewError(connect.CodeInternal, fmt.Errorf("boom")),
+			expectedUpdates: []string{"12.2.0"},
+			expectedHistory: []*upgradepolicies.UpgradeHistoryEntry{{
+				Time:        "2023-06-03T00:30:00Z",
+				FromVersion: "12.1.2",
+				ToVersion:   "12.2.0",
+				Result:      upgradepolicies.UpgradeHistoryEntry_RESULT_FAILED,
+				Message:     "internal: boom",
+			}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			packagesServer := newTestPackagesServer("12.1.2")
+			packagesServer.updateErr = tc.updateErr
+			server, clientSet := newTestUpgradePoliciesServer(t, packagesServer, map[string]string{"token-a": "user-a"}, true)
+			_, err := server.SetUpgradePolicy(context.Background(), newRequestWithToken(&upgradepolicies.SetUpgradePolicyRequest{
+				InstalledPackageRef: installedPackageRef,
+				UpgradePolicy:       tc.policy,
+			}, "token-a"))
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+
+			// The upgrades are requested through the handler of the packages
+			// service, so that its interceptors see them as the requests of
+			// the users.
+			intercepted := []string{}
+			interceptor := connect.UnaryInterceptorFunc(func(next connect.UnaryFunc) connect.UnaryFunc {
+				return func(ctx context.Context, request connect.AnyRequest) (connect.AnyResponse, error) {
+					intercepted = append(intercepted, request.Spec().Procedure)
+					return next(ctx, request)
+				}
+			})
+			mux := http.NewServeMux()
+			mux.Handle(packagesconnect.NewPackagesServiceHandler(packagesServer, connect.WithInterceptors(interceptor)))
+			httpServer := httptest.NewServer(mux)
+			defer httpServer.Close()
+
+			scheduler := NewScheduler(clientSet, "kubeapps", packagesconnect.NewPackagesServiceClient(httpServer.Client(), httpServer.URL), kube.ClustersConfig{}, time.Minute)
+			scheduler.token = func(cluster string) (string, error) {
+				if cluster != installedPackageRef.GetContext().GetCluster() {
+					return "", fmt.Errorf("unexpected cluster %q", cluster)
+				}
+				return "sa-token", nil
+			}
+			scheduler.now = func() time.Time { return tc.now }
+
+			// The second check, within the same window, does not upgrade
+			// the installed package again.
+			for i := 0; i < 2; i++ {
+				if err := scheduler.Check(context.Background()); err != nil {
+					t.Fatalf("%+v", err)
+				}
+			}
+
+			updates := []string{}
+			for _, request := range packagesServer.updateRequests {
+				updates = append(updates, request.Msg.GetPkgVersionReference().GetVersion())
+				if got, want := request.Msg.GetValues(), packagesServer.detail.GetValuesApplied(); got != want {
+					t.Errorf("got: %q, want: %q", got, want)
+				}
+				if got, want := request.Header().Get(transport.ImpersonateUserHeader), "user-a"; got != want {
+					t.Errorf("got: %q, want: %q", got, want)
+				}
+				if got, want := request.Header().Values(transport.ImpersonateGroupHeader), []string{"team-a"}; !cmp.Equal(want, got) {
+					t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+				}
+			}
+			if tc.expectedUpdates == nil {
+				tc.expectedUpdates = []string{}
+			}
+			if got, want := updates, tc.expectedUpdates; !cmp.Equal(want, got) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+			interceptedUpdates := 0
+			for _, procedure := range intercepted {
+				if procedure == packagesconnect.PackagesServiceUpdateInstalledPackageProcedure {
+					interceptedUpdates++
+				}
+			}
+			if got, want := interceptedUpdates, len(tc.expectedUpdates); got != want {
+				t.Errorf("got: %d, want: %d", got, want)
+			}
+
+			history, err := server.GetUpgradeHistory(context.Background(), newRequestWithToken(&upgradepolicies.GetUpgradeHistoryRequest{
+				InstalledPackageRef: installedPackageRef,
+			}, "token-a"))
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if got, want := history.Msg.GetEntries(), tc.expectedHistory; !cmp.Equal(want, got, protocmp.Transform()) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, protocmp.Transform()))
+			}
+		})
+	}
+}
+
+func TestSchedulerClusterToken(t *testing.T) {
+	scheduler := NewScheduler(fake.NewSimpleClientset(), "kubeapps", nil, kube.ClustersConfig{
+		KubeappsClusterName: "default",
+		Clusters: map[string]kube.ClusterConfig{
+			"default":  {Name: "default"},
+			"other":    {Name: "other", ServiceToken: "other-token"},
+			"no-token": {Name: "no-token"},
+		},
+	}, time.Minute)
+
+	testCases := []struct {
+		name          string
+		cluster       string
+		expectedToken string
+		expectErr     bool
+	}{
+		{
+			name:          "it returns the service token of an additional cluster",
+			cluster:       "other",
+			expectedToken: "other-token",
+		},
+		{
+			name:      "it returns an error for an additional cluster without service token",
+			cluster:   "no-token",
+			expectErr: true,
+		},
+		{
+			name:      "it returns an error for an unknown cluster",
+			cluster:   "unknown",
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			token, err := scheduler.token(tc.cluster)
+			if got, want := err != nil, tc.expectErr; got != want {
+				t.Fatalf("got: %t, want: %t (err: %v)", got, want, err)
+			}
+			if got, want := token, tc.expectedToken; got != want {
+				t.Errorf("got: %q, want: %q", got, want)
+			}
+		})
+	}
+}
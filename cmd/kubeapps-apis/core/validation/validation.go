@@ -0,0 +1,183 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package validation checks the fields of the requests against the
+// constraints of the core packages and repositories APIs, such as the names
+// and namespaces being valid Kubernetes names, so that every plugin rejects
+// the invalid requests with the same InvalidArgument error, carrying a
+// BadRequest detail with a violation per invalid field.
+package validation
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/bufbuild/connect-go"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
+)
+
+// Constraint returns the description of the violation of the constraint by
+// the value of a field, or an empty string if the value is valid. The empty
+// values are not checked, the required fields being checked by the plugins.
+type Constraint func(value string) string
+
+// corePackages are the proto packages whose messages are constrained.
+var corePackages = []protoreflect.FullName{
+	"kubeappsapis.core.packages.v1alpha1",
+	"kubeappsapis.core.packages.v1",
+}
+
+// fieldConstraints are the constraints of the string fields of the messages
+// of the core packages, keyed by message and field name.
+var fieldConstraints = map[string]Constraint{
+	"Context.namespace": Namespace,
+	"CheckInstalledPackageNameAvailabilityRequest.name": Name,
+	"CreateInstalledPackageRequest.name":                Name,
+	"VersionReference.version":                          SemverConstraint,
+	"GetAvailablePackageVersionsRequest.constraint":     SemverConstraint,
+	"ReconciliationOptions.interval":                    Interval,
+	"ReconciliationOptions.service_account_name":        Name,
+	"AddPackageRepositoryRequest.name":                  Name,
+	"AddPackageRepositoryRequest.url":                   URL,
+	"AddPackageRepositoryRequest.interval":              Interval,
+	"UpdatePackageRepositoryRequest.url":                URL,
+	"UpdatePackageRepositoryRequest.interval":           Interval,
+	"SecretKeyReference.name":                           Name,
+}
+
+// constraints are the fieldConstraints keyed by the full name of the fields
+// in each of the corePackages.
+var constraints = func() map[protoreflect.FullName]Constraint {
+	m := map[protoreflect.FullName]Constraint{}
+	for _, pkg := range corePackages {
+		for field, constraint := range fieldConstraints {
+			messageName, fieldName, _ := strings.Cut(field, ".")
+			m[pkg.Append(protoreflect.Name(messageName)).Append(protoreflect.Name(fieldName))] = constraint
+		}
+	}
+	return m
+}()
+
+// Namespace checks that the value is a valid Kubernetes namespace.
+func Namespace(value string) string {
+	return strings.Join(k8svalidation.IsDNS1123Label(value), ", ")
+}
+
+// Name checks that the value is a valid name of a Kubernetes object.
+func Name(value string) string {
+	return strings.Join(k8svalidation.IsDNS1123Subdomain(value), ", ")
+}
+
+// SemverConstraint checks that the value is a semver version or a semver
+// constraint, such as ">=10.3 < 10.4".
+func SemverConstraint(value string) string {
+	if _, err := semver.NewConstraint(value); err != nil {
+		return fmt.Sprintf("must be a semver version or constraint, such as \">=1.2.0 <2.0.0\": %v", err)
+	}
+	return ""
+}
+
+// Interval checks that the value is a duration, such as "10m", or a cron
+// expression, such as "*/10 * * * *", which some plugins support as well.
+func Interval(value string) string {
+	if _, err := time.ParseDuration(value); err == nil {
+		return ""
+	}
+	if strings.HasPrefix(value, "@") || len(strings.Fields(value)) == 5 {
+		return ""
+	}
+	return "must be a duration, such as \"10m\", or a cron expression, such as \"*/10 * * * *\""
+}
+
+// URL checks that the value is an absolute URL with a host.
+func URL(value string) string {
+	u, err := url.Parse(value)
+	if err != nil {
+		return fmt.Sprintf("must be a valid URL: %v", err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return "must be an absolute URL with a scheme and a host, such as \"https://charts.example.com\""
+	}
+	return ""
+}
+
+// Violations returns the violations of the constraints by the fields of the
+// message and of its nested messages, identified by their path, such as
+// "target_context.namespace", sorted by path.
+func Violations(message proto.Message) []*errdetails.BadRequest_FieldViolation {
+	violations := []*errdetails.BadRequest_FieldViolation{}
+	collectViolations(message.ProtoReflect(), "", &violations)
+	sort.Slice(violations, func(i, j int) bool { return violations[i].GetField() < violations[j].GetField() })
+	return violations
+}
+
+func collectViolations(message protoreflect.Message, path string, violations *[]*errdetails.BadRequest_FieldViolation) {
+	message.Range(func(fd protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		fieldPath := string(fd.Name())
+		if path != "" {
+			fieldPath = path + "." + fieldPath
+		}
+		switch {
+		case fd.IsMap():
+			// None of the constrained fields are maps.
+		case fd.IsList() && fd.Kind() == protoreflect.MessageKind:
+			list := value.List()
+			for i := 0; i < list.Len(); i++ {
+				collectViolations(list.Get(i).Message(), fmt.Sprintf("%s[%d]", fieldPath, i), violations)
+			}
+		case fd.Kind() == protoreflect.MessageKind:
+			collectViolations(value.Message(), fieldPath, violations)
+		case fd.Kind() == protoreflect.StringKind && !fd.IsList():
+			constraint, ok := constraints[fd.FullName()]
+			if !ok || value.String() == "" {
+				break
+			}
+			if description := constraint(value.String()); description != "" {
+				*violations = append(*violations, &errdetails.BadRequest_FieldViolation{
+					Field:       fieldPath,
+					Description: description,
+				})
+			}
+		}
+		return true
+	})
+}
+
+// NewInterceptor returns a connect interceptor rejecting the unary requests
+// whose fields violate their constraints, before they reach the plugins.
+func NewInterceptor() connect.Interceptor {
+	return connect.UnaryInterceptorFunc(func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, request connect.AnyRequest) (connect.AnyResponse, error) {
+			message, ok := request.Any().(proto.Message)
+			if !ok {
+				return next(ctx, request)
+			}
+			if violations := Violations(message); len(violations) > 0 {
+				return nil, invalidArgumentError(violations)
+			}
+			return next(ctx, request)
+		}
+	})
+}
+
+// invalidArgumentError returns the InvalidArgument error for the violations,
+// listing them in both the message and a BadRequest detail.
+func invalidArgumentError(violations []*errdetails.BadRequest_FieldViolation) error {
+	messages := make([]string, len(violations))
+	for i, violation := range violations {
+		messages[i] = fmt.Sprintf("%s: %s", violation.GetField(), violation.GetDescription())
+	}
+	connectErr := connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Invalid request: %s", strings.Join(messages, "; ")))
+	if detail, err := connect.NewErrorDetail(&errdetails.BadRequest{FieldViolations: violations}); err == nil {
+		connectErr.AddDetail(detail)
+	}
+	return connectErr
+}
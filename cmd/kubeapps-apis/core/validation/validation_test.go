@@ -0,0 +1,174 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/google/go-cmp/cmp"
+	packagesv1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1"
+	packagesv1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+func TestFieldConstraintsExist(t *testing.T) {
+	// Ensure the generated packages are registered.
+	_ = packagesv1.File_kubeappsapis_core_packages_v1_packages_proto
+	_ = packagesv1alpha1.File_kubeappsapis_core_packages_v1alpha1_packages_proto
+
+	for name := range constraints {
+		messageName := name.Parent()
+		descriptor, err := protoregistry.GlobalFiles.FindDescriptorByName(messageName)
+		if err != nil {
+			t.Errorf("unknown message %q: %v", messageName, err)
+			continue
+		}
+		field := descriptor.(protoreflect.MessageDescriptor).Fields().ByName(name.Name())
+		if field == nil || field.Kind() != protoreflect.StringKind {
+			t.Errorf("unknown string field %q", name)
+		}
+	}
+}
+
+func TestViolations(t *testing.T) {
+	testCases := []struct {
+		name               string
+		request            proto.Message
+		expectedViolations []*errdetails.BadRequest_FieldViolation
+	}{
+		{
+			name: "valid request",
+			request: &packagesv1.CreateInstalledPackageRequest{
+				TargetContext:         &packagesv1.Context{Cluster: "default", Namespace: "my-ns"},
+				Name:                  "my-apache",
+				PkgVersionReference:   &packagesv1.VersionReference{Version: ">=10.3 < 10.4"},
+				ReconciliationOptions: &packagesv1.ReconciliationOptions{Interval: "10m", ServiceAccountName: "default"},
+			},
+			expectedViolations: []*errdetails.BadRequest_FieldViolation{},
+		},
+		{
+			name: "empty fields are not checked",
+			request: &packagesv1alpha1.CreateInstalledPackageRequest{
+				TargetContext:       &packagesv1alpha1.Context{},
+				PkgVersionReference: &packagesv1alpha1.VersionReference{},
+			},
+			expectedViolations: []*errdetails.BadRequest_FieldViolation{},
+		},
+		{
+			name: "invalid nested fields of both API versions",
+			request: &packagesv1alpha1.CreateInstalledPackageRequest{
+				TargetContext:         &packagesv1alpha1.Context{Cluster: "default", Namespace: "My_NS"},
+				Name:                  "my apache",
+				PkgVersionReference:   &packagesv1alpha1.VersionReference{Version: "latest"},
+				ReconciliationOptions: &packagesv1alpha1.ReconciliationOptions{Interval: "often"},
+			},
+			expectedViolations: []*errdetails.BadRequest_FieldViolation{
+				{Field: "name"},
+				{Field: "pkg_version_reference.version"},
+				{Field: "reconciliation_options.interval"},
+				{Field: "target_context.namespace"},
+			},
+		},
+		{
+			name: "invalid repository",
+			request: &packagesv1.AddPackageRepositoryRequest{
+				Context:  &packagesv1.Context{Cluster: "default", Namespace: "kubeapps"},
+				Name:     "bitnami",
+				Url:      "charts.bitnami.com/bitnami",
+				Interval: "*/10 * * * *",
+				Auth: &packagesv1.PackageRepositoryAuth{
+					PackageRepoAuthOneOf: &packagesv1.PackageRepositoryAuth_SecretRef{
+						SecretRef: &packagesv1.SecretKeyReference{Name: "-secret"},
+					},
+				},
+			},
+			expectedViolations: []*errdetails.BadRequest_FieldViolation{
+				{Field: "auth.secret_ref.name"},
+				{Field: "url"},
+			},
+		},
+		{
+			name: "repeated messages",
+			request: &packagesv1.BulkDeleteInstalledPackagesRequest{
+				InstalledPackageRefs: []*packagesv1.InstalledPackageReference{
+					{Context: &packagesv1.Context{Namespace: "ok"}, Identifier: "a"},
+					{Context: &packagesv1.Context{Namespace: "not.ok"}, Identifier: "b"},
+				},
+			},
+			expectedViolations: []*errdetails.BadRequest_FieldViolation{
+				{Field: "installed_package_refs[1].context.namespace"},
+			},
+		},
+	}
+
+	ignoreDescription := protocmp.IgnoreFields(&errdetails.BadRequest_FieldViolation{}, "description")
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			violations := Violations(tc.request)
+
+			if got, want := violations, tc.expectedViolations; !cmp.Equal(want, got, protocmp.Transform(), ignoreDescription) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, protocmp.Transform(), ignoreDescription))
+			}
+			for _, violation := range violations {
+				if violation.GetDescription() == "" {
+					t.Errorf("missing description for %q", violation.GetField())
+				}
+			}
+		})
+	}
+}
+
+func TestInterceptor(t *testing.T) {
+	called := false
+	next := func(ctx context.Context, request connect.AnyRequest) (connect.AnyResponse, error) {
+		called = true
+		return connect.NewResponse(&packagesv1.CreateInstalledPackageResponse{}), nil
+	}
+	handler := NewInterceptor().WrapUnary(next)
+
+	t.Run("valid requests reach the handler", func(t *testing.T) {
+		_, err := handler(context.Background(), connect.NewRequest(&packagesv1.CreateInstalledPackageRequest{Name: "my-apache"}))
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		if !called {
+			t.Errorf("got: false, want: true")
+		}
+	})
+
+	t.Run("invalid requests are rejected with the field violations", func(t *testing.T) {
+		called = false
+		_, err := handler(context.Background(), connect.NewRequest(&packagesv1.CreateInstalledPackageRequest{Name: "My_Apache"}))
+
+		if got, want := connect.CodeOf(err), connect.CodeInvalidArgument; got != want {
+			t.Fatalf("got: %+v, want: %+v", got, want)
+		}
+		if called {
+			t.Errorf("got: true, want: false")
+		}
+		var connectErr *connect.Error
+		if !errors.As(err, &connectErr) || len(connectErr.Details()) != 1 {
+			t.Fatalf("got: %+v, want: an error with a detail", err)
+		}
+		detail, err := connectErr.Details()[0].Value()
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		badRequest, ok := detail.(*errdetails.BadRequest)
+		if !ok || len(badRequest.GetFieldViolations()) != 1 || badRequest.GetFieldViolations()[0].GetField() != "name" {
+			t.Errorf("got: %+v, want: a violation of the name", detail)
+		}
+		if !strings.Contains(connectErr.Message(), "name: ") {
+			t.Errorf("got: %q, want the field in the message", connectErr.Message())
+		}
+	})
+}
@@ -0,0 +1,1305 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        (unknown)
+// source: kubeappsapis/core/favorites/v1alpha1/favorites.proto
+
+package v1alpha1
+
+import (
+	_ "github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-openapiv2/options"
+	v1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// GetUserFavoritesRequest
+//
+// Request for GetUserFavorites
+type GetUserFavoritesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetUserFavoritesRequest) Reset() {
+	*x = GetUserFavoritesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetUserFavoritesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserFavoritesRequest) ProtoMessage() {}
+
+func (x *GetUserFavoritesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserFavoritesRequest.ProtoReflect.Descriptor instead.
+func (*GetUserFavoritesRequest) Descriptor() ([]byte, []int) {
+	return file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_rawDescGZIP(), []int{0}
+}
+
+// GetUserFavoritesResponse
+//
+// Response for GetUserFavorites
+type GetUserFavoritesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Favorites
+	//
+	// The favorites of the user. Empty if the user has not recorded any yet.
+	Favorites *UserFavorites `protobuf:"bytes,1,opt,name=favorites,proto3" json:"favorites,omitempty"`
+}
+
+func (x *GetUserFavoritesResponse) Reset() {
+	*x = GetUserFavoritesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetUserFavoritesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserFavoritesResponse) ProtoMessage() {}
+
+func (x *GetUserFavoritesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserFavoritesResponse.ProtoReflect.Descriptor instead.
+func (*GetUserFavoritesResponse) Descriptor() ([]byte, []int) {
+	return file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetUserFavoritesResponse) GetFavorites() *UserFavorites {
+	if x != nil {
+		return x.Favorites
+	}
+	return nil
+}
+
+// AddStarredPackageRequest
+//
+// Request for AddStarredPackage
+type AddStarredPackageRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Starred package
+	//
+	// The package to be starred. A package already starred is moved to the top
+	// of the starred packages.
+	StarredPackage *StarredPackage `protobuf:"bytes,1,opt,name=starred_package,json=starredPackage,proto3" json:"starred_package,omitempty"`
+}
+
+func (x *AddStarredPackageRequest) Reset() {
+	*x = AddStarredPackageRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddStarredPackageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddStarredPackageRequest) ProtoMessage() {}
+
+func (x *AddStarredPackageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddStarredPackageRequest.ProtoReflect.Descriptor instead.
+func (*AddStarredPackageRequest) Descriptor() ([]byte, []int) {
+	return file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *AddStarredPackageRequest) GetStarredPackage() *StarredPackage {
+	if x != nil {
+		return x.StarredPackage
+	}
+	return nil
+}
+
+// AddStarredPackageResponse
+//
+// Response for AddStarredPackage
+type AddStarredPackageResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Starred packages
+	//
+	// The packages starred by the user, the most recent first.
+	StarredPackages []*StarredPackage `protobuf:"bytes,1,rep,name=starred_packages,json=starredPackages,proto3" json:"starred_packages,omitempty"`
+}
+
+func (x *AddStarredPackageResponse) Reset() {
+	*x = AddStarredPackageResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddStarredPackageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddStarredPackageResponse) ProtoMessage() {}
+
+func (x *AddStarredPackageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddStarredPackageResponse.ProtoReflect.Descriptor instead.
+func (*AddStarredPackageResponse) Descriptor() ([]byte, []int) {
+	return file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *AddStarredPackageResponse) GetStarredPackages() []*StarredPackage {
+	if x != nil {
+		return x.StarredPackages
+	}
+	return nil
+}
+
+// RemoveStarredPackageRequest
+//
+// Request for RemoveStarredPackage
+type RemoveStarredPackageRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// A reference uniquely identifying the starred package.
+	AvailablePackageRef *v1.AvailablePackageReference `protobuf:"bytes,1,opt,name=available_package_ref,json=availablePackageRef,proto3" json:"available_package_ref,omitempty"`
+}
+
+func (x *RemoveStarredPackageRequest) Reset() {
+	*x = RemoveStarredPackageRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RemoveStarredPackageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveStarredPackageRequest) ProtoMessage() {}
+
+func (x *RemoveStarredPackageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveStarredPackageRequest.ProtoReflect.Descriptor instead.
+func (*RemoveStarredPackageRequest) Descriptor() ([]byte, []int) {
+	return file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RemoveStarredPackageRequest) GetAvailablePackageRef() *v1.AvailablePackageReference {
+	if x != nil {
+		return x.AvailablePackageRef
+	}
+	return nil
+}
+
+// RemoveStarredPackageResponse
+//
+// Response for RemoveStarredPackage
+type RemoveStarredPackageResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Starred packages
+	//
+	// The packages starred by the user, the most recent first.
+	StarredPackages []*StarredPackage `protobuf:"bytes,1,rep,name=starred_packages,json=starredPackages,proto3" json:"starred_packages,omitempty"`
+}
+
+func (x *RemoveStarredPackageResponse) Reset() {
+	*x = RemoveStarredPackageResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RemoveStarredPackageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveStarredPackageResponse) ProtoMessage() {}
+
+func (x *RemoveStarredPackageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveStarredPackageResponse.ProtoReflect.Descriptor instead.
+func (*RemoveStarredPackageResponse) Descriptor() ([]byte, []int) {
+	return file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RemoveStarredPackageResponse) GetStarredPackages() []*StarredPackage {
+	if x != nil {
+		return x.StarredPackages
+	}
+	return nil
+}
+
+// AddRecentInstallRequest
+//
+// Request for AddRecentInstall
+type AddRecentInstallRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Recent install
+	//
+	// The installed package to be recorded. Only the latest recent installs are
+	// kept.
+	RecentInstall *RecentInstall `protobuf:"bytes,1,opt,name=recent_install,json=recentInstall,proto3" json:"recent_install,omitempty"`
+}
+
+func (x *AddRecentInstallRequest) Reset() {
+	*x = AddRecentInstallRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddRecentInstallRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddRecentInstallRequest) ProtoMessage() {}
+
+func (x *AddRecentInstallRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddRecentInstallRequest.ProtoReflect.Descriptor instead.
+func (*AddRecentInstallRequest) Descriptor() ([]byte, []int) {
+	return file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *AddRecentInstallRequest) GetRecentInstall() *RecentInstall {
+	if x != nil {
+		return x.RecentInstall
+	}
+	return nil
+}
+
+// AddRecentInstallResponse
+//
+// Response for AddRecentInstall
+type AddRecentInstallResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Recent installs
+	//
+	// The packages recently installed by the user, the most recent first.
+	RecentInstalls []*RecentInstall `protobuf:"bytes,1,rep,name=recent_installs,json=recentInstalls,proto3" json:"recent_installs,omitempty"`
+}
+
+func (x *AddRecentInstallResponse) Reset() {
+	*x = AddRecentInstallResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddRecentInstallResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddRecentInstallResponse) ProtoMessage() {}
+
+func (x *AddRecentInstallResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddRecentInstallResponse.ProtoReflect.Descriptor instead.
+func (*AddRecentInstallResponse) Descriptor() ([]byte, []int) {
+	return file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *AddRecentInstallResponse) GetRecentInstalls() []*RecentInstall {
+	if x != nil {
+		return x.RecentInstalls
+	}
+	return nil
+}
+
+// RemoveRecentInstallRequest
+//
+// Request for RemoveRecentInstall
+type RemoveRecentInstallRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// A reference uniquely identifying the installed package.
+	InstalledPackageRef *v1.InstalledPackageReference `protobuf:"bytes,1,opt,name=installed_package_ref,json=installedPackageRef,proto3" json:"installed_package_ref,omitempty"`
+}
+
+func (x *RemoveRecentInstallRequest) Reset() {
+	*x = RemoveRecentInstallRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RemoveRecentInstallRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveRecentInstallRequest) ProtoMessage() {}
+
+func (x *RemoveRecentInstallRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveRecentInstallRequest.ProtoReflect.Descriptor instead.
+func (*RemoveRecentInstallRequest) Descriptor() ([]byte, []int) {
+	return file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *RemoveRecentInstallRequest) GetInstalledPackageRef() *v1.InstalledPackageReference {
+	if x != nil {
+		return x.InstalledPackageRef
+	}
+	return nil
+}
+
+// RemoveRecentInstallResponse
+//
+// Response for RemoveRecentInstall
+type RemoveRecentInstallResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Recent installs
+	//
+	// The packages recently installed by the user, the most recent first.
+	RecentInstalls []*RecentInstall `protobuf:"bytes,1,rep,name=recent_installs,json=recentInstalls,proto3" json:"recent_installs,omitempty"`
+}
+
+func (x *RemoveRecentInstallResponse) Reset() {
+	*x = RemoveRecentInstallResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RemoveRecentInstallResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveRecentInstallResponse) ProtoMessage() {}
+
+func (x *RemoveRecentInstallResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveRecentInstallResponse.ProtoReflect.Descriptor instead.
+func (*RemoveRecentInstallResponse) Descriptor() ([]byte, []int) {
+	return file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *RemoveRecentInstallResponse) GetRecentInstalls() []*RecentInstall {
+	if x != nil {
+		return x.RecentInstalls
+	}
+	return nil
+}
+
+// UserFavorites
+//
+// The favorites of a user.
+type UserFavorites struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Starred packages
+	//
+	// The packages starred by the user, the most recent first.
+	StarredPackages []*StarredPackage `protobuf:"bytes,1,rep,name=starred_packages,json=starredPackages,proto3" json:"starred_packages,omitempty"`
+	// Recent installs
+	//
+	// The packages recently installed by the user, the most recent first.
+	RecentInstalls []*RecentInstall `protobuf:"bytes,2,rep,name=recent_installs,json=recentInstalls,proto3" json:"recent_installs,omitempty"`
+}
+
+func (x *UserFavorites) Reset() {
+	*x = UserFavorites{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UserFavorites) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserFavorites) ProtoMessage() {}
+
+func (x *UserFavorites) ProtoReflect() protoreflect.Message {
+	mi := &file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserFavorites.ProtoReflect.Descriptor instead.
+func (*UserFavorites) Descriptor() ([]byte, []int) {
+	return file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *UserFavorites) GetStarredPackages() []*StarredPackage {
+	if x != nil {
+		return x.StarredPackages
+	}
+	return nil
+}
+
+func (x *UserFavorites) GetRecentInstalls() []*RecentInstall {
+	if x != nil {
+		return x.RecentInstalls
+	}
+	return nil
+}
+
+// StarredPackage
+//
+// An available package starred by a user.
+type StarredPackage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// A reference uniquely identifying the available package.
+	AvailablePackageRef *v1.AvailablePackageReference `protobuf:"bytes,1,opt,name=available_package_ref,json=availablePackageRef,proto3" json:"available_package_ref,omitempty"`
+	// Display name
+	//
+	// The name of the package as displayed in the shortcut.
+	DisplayName string `protobuf:"bytes,2,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	// Icon URL
+	//
+	// Optional URL of the icon displayed in the shortcut.
+	IconUrl string `protobuf:"bytes,3,opt,name=icon_url,json=iconUrl,proto3" json:"icon_url,omitempty"`
+	// Time
+	//
+	// The time (RFC3339) at which the package was starred, set by the server.
+	Time string `protobuf:"bytes,4,opt,name=time,proto3" json:"time,omitempty"`
+}
+
+func (x *StarredPackage) Reset() {
+	*x = StarredPackage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StarredPackage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StarredPackage) ProtoMessage() {}
+
+func (x *StarredPackage) ProtoReflect() protoreflect.Message {
+	mi := &file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StarredPackage.ProtoReflect.Descriptor instead.
+func (*StarredPackage) Descriptor() ([]byte, []int) {
+	return file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *StarredPackage) GetAvailablePackageRef() *v1.AvailablePackageReference {
+	if x != nil {
+		return x.AvailablePackageRef
+	}
+	return nil
+}
+
+func (x *StarredPackage) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
+func (x *StarredPackage) GetIconUrl() string {
+	if x != nil {
+		return x.IconUrl
+	}
+	return ""
+}
+
+func (x *StarredPackage) GetTime() string {
+	if x != nil {
+		return x.Time
+	}
+	return ""
+}
+
+// RecentInstall
+//
+// A package recently installed by a user.
+type RecentInstall struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// A reference uniquely identifying the installed package.
+	InstalledPackageRef *v1.InstalledPackageReference `protobuf:"bytes,1,opt,name=installed_package_ref,json=installedPackageRef,proto3" json:"installed_package_ref,omitempty"`
+	// A reference to the available package which was installed.
+	AvailablePackageRef *v1.AvailablePackageReference `protobuf:"bytes,2,opt,name=available_package_ref,json=availablePackageRef,proto3" json:"available_package_ref,omitempty"`
+	// Display name
+	//
+	// The name of the package as displayed in the shortcut.
+	DisplayName string `protobuf:"bytes,3,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	// Icon URL
+	//
+	// Optional URL of the icon displayed in the shortcut.
+	IconUrl string `protobuf:"bytes,4,opt,name=icon_url,json=iconUrl,proto3" json:"icon_url,omitempty"`
+	// Time
+	//
+	// The time (RFC3339) at which the package was installed, set by the server.
+	Time string `protobuf:"bytes,5,opt,name=time,proto3" json:"time,omitempty"`
+}
+
+func (x *RecentInstall) Reset() {
+	*x = RecentInstall{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RecentInstall) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecentInstall) ProtoMessage() {}
+
+func (x *RecentInstall) ProtoReflect() protoreflect.Message {
+	mi := &file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecentInstall.ProtoReflect.Descriptor instead.
+func (*RecentInstall) Descriptor() ([]byte, []int) {
+	return file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RecentInstall) GetInstalledPackageRef() *v1.InstalledPackageReference {
+	if x != nil {
+		return x.InstalledPackageRef
+	}
+	return nil
+}
+
+func (x *RecentInstall) GetAvailablePackageRef() *v1.AvailablePackageReference {
+	if x != nil {
+		return x.AvailablePackageRef
+	}
+	return nil
+}
+
+func (x *RecentInstall) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
+func (x *RecentInstall) GetIconUrl() string {
+	if x != nil {
+		return x.IconUrl
+	}
+	return ""
+}
+
+func (x *RecentInstall) GetTime() string {
+	if x != nil {
+		return x.Time
+	}
+	return ""
+}
+
+var File_kubeappsapis_core_favorites_v1alpha1_favorites_proto protoreflect.FileDescriptor
+
+var file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_rawDesc = []byte{
+	0x0a, 0x34, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2f, 0x63,
+	0x6f, 0x72, 0x65, 0x2f, 0x66, 0x61, 0x76, 0x6f, 0x72, 0x69, 0x74, 0x65, 0x73, 0x2f, 0x76, 0x31,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2f, 0x66, 0x61, 0x76, 0x6f, 0x72, 0x69, 0x74, 0x65, 0x73,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x24, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73,
+	0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x66, 0x61, 0x76, 0x6f, 0x72, 0x69,
+	0x74, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x1a, 0x1c, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x2c, 0x6b, 0x75, 0x62, 0x65,
+	0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x70, 0x61,
+	0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2f, 0x76, 0x31, 0x2f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67,
+	0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63,
+	0x2d, 0x67, 0x65, 0x6e, 0x2d, 0x6f, 0x70, 0x65, 0x6e, 0x61, 0x70, 0x69, 0x76, 0x32, 0x2f, 0x6f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x19, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x55,
+	0x73, 0x65, 0x72, 0x46, 0x61, 0x76, 0x6f, 0x72, 0x69, 0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x22, 0x6d, 0x0a, 0x18, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x46, 0x61,
+	0x76, 0x6f, 0x72, 0x69, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x51, 0x0a, 0x09, 0x66, 0x61, 0x76, 0x6f, 0x72, 0x69, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x33, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69,
+	0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x66, 0x61, 0x76, 0x6f, 0x72, 0x69, 0x74, 0x65, 0x73,
+	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x46, 0x61,
+	0x76, 0x6f, 0x72, 0x69, 0x74, 0x65, 0x73, 0x52, 0x09, 0x66, 0x61, 0x76, 0x6f, 0x72, 0x69, 0x74,
+	0x65, 0x73, 0x22, 0x93, 0x03, 0x0a, 0x18, 0x41, 0x64, 0x64, 0x53, 0x74, 0x61, 0x72, 0x72, 0x65,
+	0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x5d, 0x0a, 0x0f, 0x73, 0x74, 0x61, 0x72, 0x72, 0x65, 0x64, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61,
+	0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x34, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61,
+	0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x66, 0x61, 0x76,
+	0x6f, 0x72, 0x69, 0x74, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e,
+	0x53, 0x74, 0x61, 0x72, 0x72, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x0e,
+	0x73, 0x74, 0x61, 0x72, 0x72, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x3a, 0x97,
+	0x02, 0x92, 0x41, 0x93, 0x02, 0x32, 0x90, 0x02, 0x7b, 0x22, 0x73, 0x74, 0x61, 0x72, 0x72, 0x65,
+	0x64, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x22, 0x3a, 0x20, 0x7b, 0x22, 0x61, 0x76,
+	0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f,
+	0x72, 0x65, 0x66, 0x22, 0x3a, 0x20, 0x7b, 0x22, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x22,
+	0x3a, 0x20, 0x7b, 0x22, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x22, 0x3a, 0x20, 0x22, 0x64,
+	0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x22, 0x2c, 0x20, 0x22, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70,
+	0x61, 0x63, 0x65, 0x22, 0x3a, 0x20, 0x22, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x22,
+	0x7d, 0x2c, 0x20, 0x22, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x22, 0x3a,
+	0x20, 0x22, 0x62, 0x69, 0x74, 0x6e, 0x61, 0x6d, 0x69, 0x2f, 0x61, 0x70, 0x61, 0x63, 0x68, 0x65,
+	0x22, 0x2c, 0x20, 0x22, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x22, 0x3a, 0x20, 0x7b, 0x22, 0x6e,
+	0x61, 0x6d, 0x65, 0x22, 0x3a, 0x20, 0x22, 0x68, 0x65, 0x6c, 0x6d, 0x2e, 0x70, 0x61, 0x63, 0x6b,
+	0x61, 0x67, 0x65, 0x73, 0x22, 0x2c, 0x20, 0x22, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22,
+	0x3a, 0x20, 0x22, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x22, 0x7d, 0x7d, 0x2c, 0x20,
+	0x22, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x3a, 0x20,
+	0x22, 0x61, 0x70, 0x61, 0x63, 0x68, 0x65, 0x22, 0x2c, 0x20, 0x22, 0x69, 0x63, 0x6f, 0x6e, 0x5f,
+	0x75, 0x72, 0x6c, 0x22, 0x3a, 0x20, 0x22, 0x68, 0x74, 0x74, 0x70, 0x73, 0x3a, 0x2f, 0x2f, 0x65,
+	0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x70, 0x61, 0x63, 0x68,
+	0x65, 0x2e, 0x73, 0x76, 0x67, 0x22, 0x7d, 0x7d, 0x22, 0x7c, 0x0a, 0x19, 0x41, 0x64, 0x64, 0x53,
+	0x74, 0x61, 0x72, 0x72, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5f, 0x0a, 0x10, 0x73, 0x74, 0x61, 0x72, 0x72, 0x65, 0x64,
+	0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x34, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63,
+	0x6f, 0x72, 0x65, 0x2e, 0x66, 0x61, 0x76, 0x6f, 0x72, 0x69, 0x74, 0x65, 0x73, 0x2e, 0x76, 0x31,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x72, 0x65, 0x64, 0x50, 0x61,
+	0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x0f, 0x73, 0x74, 0x61, 0x72, 0x72, 0x65, 0x64, 0x50, 0x61,
+	0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x22, 0x8b, 0x01, 0x0a, 0x1b, 0x52, 0x65, 0x6d, 0x6f, 0x76,
+	0x65, 0x53, 0x74, 0x61, 0x72, 0x72, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x6c, 0x0a, 0x15, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61,
+	0x62, 0x6c, 0x65, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x66, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x38, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73,
+	0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67,
+	0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x50,
+	0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x52,
+	0x13, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67,
+	0x65, 0x52, 0x65, 0x66, 0x22, 0x7f, 0x0a, 0x1c, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x53, 0x74,
+	0x61, 0x72, 0x72, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5f, 0x0a, 0x10, 0x73, 0x74, 0x61, 0x72, 0x72, 0x65, 0x64, 0x5f,
+	0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x34,
+	0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f,
+	0x72, 0x65, 0x2e, 0x66, 0x61, 0x76, 0x6f, 0x72, 0x69, 0x74, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61,
+	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x72, 0x65, 0x64, 0x50, 0x61, 0x63,
+	0x6b, 0x61, 0x67, 0x65, 0x52, 0x0f, 0x73, 0x74, 0x61, 0x72, 0x72, 0x65, 0x64, 0x50, 0x61, 0x63,
+	0x6b, 0x61, 0x67, 0x65, 0x73, 0x22, 0x8c, 0x04, 0x0a, 0x17, 0x41, 0x64, 0x64, 0x52, 0x65, 0x63,
+	0x65, 0x6e, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x5a, 0x0a, 0x0e, 0x72, 0x65, 0x63, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x6e, 0x73, 0x74,
+	0x61, 0x6c, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x33, 0x2e, 0x6b, 0x75, 0x62, 0x65,
+	0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x66, 0x61,
+	0x76, 0x6f, 0x72, 0x69, 0x74, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
+	0x2e, 0x52, 0x65, 0x63, 0x65, 0x6e, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x52, 0x0d,
+	0x72, 0x65, 0x63, 0x65, 0x6e, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x3a, 0x94, 0x03,
+	0x92, 0x41, 0x90, 0x03, 0x32, 0x8d, 0x03, 0x7b, 0x22, 0x72, 0x65, 0x63, 0x65, 0x6e, 0x74, 0x5f,
+	0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x22, 0x3a, 0x20, 0x7b, 0x22, 0x69, 0x6e, 0x73, 0x74,
+	0x61, 0x6c, 0x6c, 0x65, 0x64, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65,
+	0x66, 0x22, 0x3a, 0x20, 0x7b, 0x22, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x22, 0x3a, 0x20,
+	0x7b, 0x22, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x22, 0x3a, 0x20, 0x22, 0x64, 0x65, 0x66,
+	0x61, 0x75, 0x6c, 0x74, 0x22, 0x2c, 0x20, 0x22, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63,
+	0x65, 0x22, 0x3a, 0x20, 0x22, 0x74, 0x65, 0x61, 0x6d, 0x2d, 0x61, 0x22, 0x7d, 0x2c, 0x20, 0x22,
+	0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x22, 0x3a, 0x20, 0x22, 0x6d, 0x79,
+	0x2d, 0x61, 0x70, 0x61, 0x63, 0x68, 0x65, 0x22, 0x2c, 0x20, 0x22, 0x70, 0x6c, 0x75, 0x67, 0x69,
+	0x6e, 0x22, 0x3a, 0x20, 0x7b, 0x22, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x3a, 0x20, 0x22, 0x68, 0x65,
+	0x6c, 0x6d, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x22, 0x2c, 0x20, 0x22, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x3a, 0x20, 0x22, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x22, 0x7d, 0x7d, 0x2c, 0x20, 0x22, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c,
+	0x65, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x66, 0x22, 0x3a, 0x20,
+	0x7b, 0x22, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x22, 0x3a, 0x20, 0x7b, 0x22, 0x63, 0x6c,
+	0x75, 0x73, 0x74, 0x65, 0x72, 0x22, 0x3a, 0x20, 0x22, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74,
+	0x22, 0x2c, 0x20, 0x22, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x22, 0x3a, 0x20,
+	0x22, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x22, 0x7d, 0x2c, 0x20, 0x22, 0x69, 0x64,
+	0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x22, 0x3a, 0x20, 0x22, 0x62, 0x69, 0x74, 0x6e,
+	0x61, 0x6d, 0x69, 0x2f, 0x61, 0x70, 0x61, 0x63, 0x68, 0x65, 0x22, 0x2c, 0x20, 0x22, 0x70, 0x6c,
+	0x75, 0x67, 0x69, 0x6e, 0x22, 0x3a, 0x20, 0x7b, 0x22, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x3a, 0x20,
+	0x22, 0x68, 0x65, 0x6c, 0x6d, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x22, 0x2c,
+	0x20, 0x22, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x3a, 0x20, 0x22, 0x76, 0x31, 0x61,
+	0x6c, 0x70, 0x68, 0x61, 0x31, 0x22, 0x7d, 0x7d, 0x2c, 0x20, 0x22, 0x64, 0x69, 0x73, 0x70, 0x6c,
+	0x61, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x3a, 0x20, 0x22, 0x61, 0x70, 0x61, 0x63, 0x68,
+	0x65, 0x22, 0x7d, 0x7d, 0x22, 0x78, 0x0a, 0x18, 0x41, 0x64, 0x64, 0x52, 0x65, 0x63, 0x65, 0x6e,
+	0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x5c, 0x0a, 0x0f, 0x72, 0x65, 0x63, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x6e, 0x73, 0x74, 0x61,
+	0x6c, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x33, 0x2e, 0x6b, 0x75, 0x62, 0x65,
+	0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x66, 0x61,
+	0x76, 0x6f, 0x72, 0x69, 0x74, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
+	0x2e, 0x52, 0x65, 0x63, 0x65, 0x6e, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x52, 0x0e,
+	0x72, 0x65, 0x63, 0x65, 0x6e, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x73, 0x22, 0x8a,
+	0x01, 0x0a, 0x1a, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x63, 0x65, 0x6e, 0x74, 0x49,
+	0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x6c, 0x0a,
+	0x15, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61,
+	0x67, 0x65, 0x5f, 0x72, 0x65, 0x66, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x38, 0x2e, 0x6b,
+	0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65,
+	0x2e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x73,
+	0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x66,
+	0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x13, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65,
+	0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x66, 0x22, 0x7b, 0x0a, 0x1b, 0x52,
+	0x65, 0x6d, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x63, 0x65, 0x6e, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61,
+	0x6c, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5c, 0x0a, 0x0f, 0x72, 0x65,
+	0x63, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x33, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70,
+	0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x66, 0x61, 0x76, 0x6f, 0x72, 0x69, 0x74, 0x65,
+	0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x65, 0x6e,
+	0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x52, 0x0e, 0x72, 0x65, 0x63, 0x65, 0x6e, 0x74,
+	0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x73, 0x22, 0xce, 0x01, 0x0a, 0x0d, 0x55, 0x73, 0x65,
+	0x72, 0x46, 0x61, 0x76, 0x6f, 0x72, 0x69, 0x74, 0x65, 0x73, 0x12, 0x5f, 0x0a, 0x10, 0x73, 0x74,
+	0x61, 0x72, 0x72, 0x65, 0x64, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x34, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61,
+	0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x66, 0x61, 0x76, 0x6f, 0x72, 0x69, 0x74,
+	0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x72,
+	0x72, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x0f, 0x73, 0x74, 0x61, 0x72,
+	0x72, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x12, 0x5c, 0x0a, 0x0f, 0x72,
+	0x65, 0x63, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x33, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61,
+	0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x66, 0x61, 0x76, 0x6f, 0x72, 0x69, 0x74,
+	0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x65,
+	0x6e, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x52, 0x0e, 0x72, 0x65, 0x63, 0x65, 0x6e,
+	0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x73, 0x22, 0xd0, 0x01, 0x0a, 0x0e, 0x53, 0x74,
+	0x61, 0x72, 0x72, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x12, 0x6c, 0x0a, 0x15,
+	0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67,
+	0x65, 0x5f, 0x72, 0x65, 0x66, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x38, 0x2e, 0x6b, 0x75,
+	0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e,
+	0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x76, 0x61, 0x69,
+	0x6c, 0x61, 0x62, 0x6c, 0x65, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x66, 0x65,
+	0x72, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x13, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65,
+	0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x66, 0x12, 0x21, 0x0a, 0x0c, 0x64, 0x69,
+	0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x19, 0x0a,
+	0x08, 0x69, 0x63, 0x6f, 0x6e, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x69, 0x63, 0x6f, 0x6e, 0x55, 0x72, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x22, 0xbd, 0x02, 0x0a,
+	0x0d, 0x52, 0x65, 0x63, 0x65, 0x6e, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x12, 0x6c,
+	0x0a, 0x15, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x5f, 0x70, 0x61, 0x63, 0x6b,
+	0x61, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x66, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x38, 0x2e,
+	0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72,
+	0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e,
+	0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65,
+	0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x13, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c,
+	0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x66, 0x12, 0x6c, 0x0a, 0x15,
+	0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67,
+	0x65, 0x5f, 0x72, 0x65, 0x66, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x38, 0x2e, 0x6b, 0x75,
+	0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e,
+	0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x76, 0x61, 0x69,
+	0x6c, 0x61, 0x62, 0x6c, 0x65, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x66, 0x65,
+	0x72, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x13, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65,
+	0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x66, 0x12, 0x21, 0x0a, 0x0c, 0x64, 0x69,
+	0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x19, 0x0a,
+	0x08, 0x69, 0x63, 0x6f, 0x6e, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x69, 0x63, 0x6f, 0x6e, 0x55, 0x72, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x32, 0x96, 0x0b, 0x0a,
+	0x10, 0x46, 0x61, 0x76, 0x6f, 0x72, 0x69, 0x74, 0x65, 0x73, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x12, 0xb8, 0x01, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x46, 0x61, 0x76,
+	0x6f, 0x72, 0x69, 0x74, 0x65, 0x73, 0x12, 0x3d, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70,
+	0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x66, 0x61, 0x76, 0x6f, 0x72,
+	0x69, 0x74, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x47, 0x65,
+	0x74, 0x55, 0x73, 0x65, 0x72, 0x46, 0x61, 0x76, 0x6f, 0x72, 0x69, 0x74, 0x65, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x3e, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73,
+	0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x66, 0x61, 0x76, 0x6f, 0x72, 0x69,
+	0x74, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x47, 0x65, 0x74,
+	0x55, 0x73, 0x65, 0x72, 0x46, 0x61, 0x76, 0x6f, 0x72, 0x69, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x25, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1f, 0x12, 0x1d, 0x2f,
+	0x63, 0x6f, 0x72, 0x65, 0x2f, 0x66, 0x61, 0x76, 0x6f, 0x72, 0x69, 0x74, 0x65, 0x73, 0x2f, 0x76,
+	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2f, 0x75, 0x73, 0x65, 0x72, 0x12, 0xc6, 0x01, 0x0a,
+	0x11, 0x41, 0x64, 0x64, 0x53, 0x74, 0x61, 0x72, 0x72, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61,
+	0x67, 0x65, 0x12, 0x3e, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69,
+	0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x66, 0x61, 0x76, 0x6f, 0x72, 0x69, 0x74, 0x65, 0x73,
+	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x41, 0x64, 0x64, 0x53, 0x74, 0x61,
+	0x72, 0x72, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x3f, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69,
+	0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x66, 0x61, 0x76, 0x6f, 0x72, 0x69, 0x74, 0x65, 0x73,
+	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x41, 0x64, 0x64, 0x53, 0x74, 0x61,
+	0x72, 0x72, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x30, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x2a, 0x3a, 0x01, 0x2a, 0x22, 0x25,
+	0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x66, 0x61, 0x76, 0x6f, 0x72, 0x69, 0x74, 0x65, 0x73, 0x2f,
+	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2f, 0x75, 0x73, 0x65, 0x72, 0x2f, 0x73, 0x74,
+	0x61, 0x72, 0x72, 0x65, 0x64, 0x12, 0x9e, 0x03, 0x0a, 0x14, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65,
+	0x53, 0x74, 0x61, 0x72, 0x72, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x12, 0x41,
+	0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f,
+	0x72, 0x65, 0x2e, 0x66, 0x61, 0x76, 0x6f, 0x72, 0x69, 0x74, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61,
+	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x53, 0x74, 0x61, 0x72,
+	0x72, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x42, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73,
+	0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x66, 0x61, 0x76, 0x6f, 0x72, 0x69, 0x74, 0x65, 0x73, 0x2e,
+	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x53,
+	0x74, 0x61, 0x72, 0x72, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xfe, 0x01, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0xf7, 0x01, 0x2a,
+	0xf4, 0x01, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x66, 0x61, 0x76, 0x6f, 0x72, 0x69, 0x74, 0x65,
+	0x73, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2f, 0x75, 0x73, 0x65, 0x72, 0x2f,
+	0x73, 0x74, 0x61, 0x72, 0x72, 0x65, 0x64, 0x2f, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2f, 0x7b,
+	0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67,
+	0x65, 0x5f, 0x72, 0x65, 0x66, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x6e, 0x61, 0x6d,
+	0x65, 0x7d, 0x2f, 0x7b, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x70, 0x61,
+	0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x66, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e,
+	0x2e, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x7d, 0x2f, 0x63, 0x2f, 0x7b, 0x61, 0x76, 0x61,
+	0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x72,
+	0x65, 0x66, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x74,
+	0x65, 0x72, 0x7d, 0x2f, 0x6e, 0x73, 0x2f, 0x7b, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c,
+	0x65, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x66, 0x2e, 0x63, 0x6f,
+	0x6e, 0x74, 0x65, 0x78, 0x74, 0x2e, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x7d,
+	0x2f, 0x7b, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x70, 0x61, 0x63, 0x6b,
+	0x61, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x66, 0x2e, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69,
+	0x65, 0x72, 0x3d, 0x2a, 0x2a, 0x7d, 0x12, 0xc2, 0x01, 0x0a, 0x10, 0x41, 0x64, 0x64, 0x52, 0x65,
+	0x63, 0x65, 0x6e, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x12, 0x3d, 0x2e, 0x6b, 0x75,
+	0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e,
+	0x66, 0x61, 0x76, 0x6f, 0x72, 0x69, 0x74, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2e, 0x41, 0x64, 0x64, 0x52, 0x65, 0x63, 0x65, 0x6e, 0x74, 0x49, 0x6e, 0x73, 0x74,
+	0x61, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x3e, 0x2e, 0x6b, 0x75, 0x62,
+	0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x66,
+	0x61, 0x76, 0x6f, 0x72, 0x69, 0x74, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x31, 0x2e, 0x41, 0x64, 0x64, 0x52, 0x65, 0x63, 0x65, 0x6e, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61,
+	0x6c, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x2f, 0x82, 0xd3, 0xe4, 0x93,
+	0x02, 0x29, 0x3a, 0x01, 0x2a, 0x22, 0x24, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x66, 0x61, 0x76,
+	0x6f, 0x72, 0x69, 0x74, 0x65, 0x73, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2f,
+	0x75, 0x73, 0x65, 0x72, 0x2f, 0x72, 0x65, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x97, 0x03, 0x0a, 0x13,
+	0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x63, 0x65, 0x6e, 0x74, 0x49, 0x6e, 0x73, 0x74,
+	0x61, 0x6c, 0x6c, 0x12, 0x40, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70,
+	0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x66, 0x61, 0x76, 0x6f, 0x72, 0x69, 0x74, 0x65,
+	0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76,
+	0x65, 0x52, 0x65, 0x63, 0x65, 0x6e, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x41, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73,
+	0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x66, 0x61, 0x76, 0x6f, 0x72, 0x69,
+	0x74, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x6d,
+	0x6f, 0x76, 0x65, 0x52, 0x65, 0x63, 0x65, 0x6e, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xfa, 0x01, 0x82, 0xd3, 0xe4, 0x93, 0x02,
+	0xf3, 0x01, 0x2a, 0xf0, 0x01, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x66, 0x61, 0x76, 0x6f, 0x72,
+	0x69, 0x74, 0x65, 0x73, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2f, 0x75, 0x73,
+	0x65, 0x72, 0x2f, 0x72, 0x65, 0x63, 0x65, 0x6e, 0x74, 0x2f, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e,
+	0x2f, 0x7b, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x5f, 0x70, 0x61, 0x63, 0x6b,
+	0x61, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x66, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x6e,
+	0x61, 0x6d, 0x65, 0x7d, 0x2f, 0x7b, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x5f,
+	0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x66, 0x2e, 0x70, 0x6c, 0x75, 0x67,
+	0x69, 0x6e, 0x2e, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x7d, 0x2f, 0x63, 0x2f, 0x7b, 0x69,
+	0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65,
+	0x5f, 0x72, 0x65, 0x66, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x2e, 0x63, 0x6c, 0x75,
+	0x73, 0x74, 0x65, 0x72, 0x7d, 0x2f, 0x6e, 0x73, 0x2f, 0x7b, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c,
+	0x6c, 0x65, 0x64, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x66, 0x2e,
+	0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x2e, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63,
+	0x65, 0x7d, 0x2f, 0x7b, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x5f, 0x70, 0x61,
+	0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x66, 0x2e, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69,
+	0x66, 0x69, 0x65, 0x72, 0x7d, 0x42, 0x50, 0x5a, 0x4e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
+	0x63, 0x6f, 0x6d, 0x2f, 0x76, 0x6d, 0x77, 0x61, 0x72, 0x65, 0x2d, 0x74, 0x61, 0x6e, 0x7a, 0x75,
+	0x2f, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x2f, 0x63, 0x6d, 0x64, 0x2f, 0x6b, 0x75,
+	0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x2d, 0x61, 0x70, 0x69, 0x73, 0x2f, 0x67, 0x65, 0x6e, 0x2f,
+	0x63, 0x6f, 0x72, 0x65, 0x2f, 0x66, 0x61, 0x76, 0x6f, 0x72, 0x69, 0x74, 0x65, 0x73, 0x2f, 0x76,
+	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_rawDescOnce sync.Once
+	file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_rawDescData = file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_rawDesc
+)
+
+func file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_rawDescGZIP() []byte {
+	file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_rawDescOnce.Do(func() {
+		file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_rawDescData = protoimpl.X.CompressGZIP(file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_rawDescData)
+	})
+	return file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_rawDescData
+}
+
+var file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_goTypes = []interface{}{
+	(*GetUserFavoritesRequest)(nil),      // 0: kubeappsapis.core.favorites.v1alpha1.GetUserFavoritesRequest
+	(*GetUserFavoritesResponse)(nil),     // 1: kubeappsapis.core.favorites.v1alpha1.GetUserFavoritesResponse
+	(*AddStarredPackageRequest)(nil),     // 2: kubeappsapis.core.favorites.v1alpha1.AddStarredPackageRequest
+	(*AddStarredPackageResponse)(nil),    // 3: kubeappsapis.core.favorites.v1alpha1.AddStarredPackageResponse
+	(*RemoveStarredPackageRequest)(nil),  // 4: kubeappsapis.core.favorites.v1alpha1.RemoveStarredPackageRequest
+	(*RemoveStarredPackageResponse)(nil), // 5: kubeappsapis.core.favorites.v1alpha1.RemoveStarredPackageResponse
+	(*AddRecentInstallRequest)(nil),      // 6: kubeappsapis.core.favorites.v1alpha1.AddRecentInstallRequest
+	(*AddRecentInstallResponse)(nil),     // 7: kubeappsapis.core.favorites.v1alpha1.AddRecentInstallResponse
+	(*RemoveRecentInstallRequest)(nil),   // 8: kubeappsapis.core.favorites.v1alpha1.RemoveRecentInstallRequest
+	(*RemoveRecentInstallResponse)(nil),  // 9: kubeappsapis.core.favorites.v1alpha1.RemoveRecentInstallResponse
+	(*UserFavorites)(nil),                // 10: kubeappsapis.core.favorites.v1alpha1.UserFavorites
+	(*StarredPackage)(nil),               // 11: kubeappsapis.core.favorites.v1alpha1.StarredPackage
+	(*RecentInstall)(nil),                // 12: kubeappsapis.core.favorites.v1alpha1.RecentInstall
+	(*v1.AvailablePackageReference)(nil), // 13: kubeappsapis.core.packages.v1.AvailablePackageReference
+	(*v1.InstalledPackageReference)(nil), // 14: kubeappsapis.core.packages.v1.InstalledPackageReference
+}
+var file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_depIdxs = []int32{
+	10, // 0: kubeappsapis.core.favorites.v1alpha1.GetUserFavoritesResponse.favorites:type_name -> kubeappsapis.core.favorites.v1alpha1.UserFavorites
+	11, // 1: kubeappsapis.core.favorites.v1alpha1.AddStarredPackageRequest.starred_package:type_name -> kubeappsapis.core.favorites.v1alpha1.StarredPackage
+	11, // 2: kubeappsapis.core.favorites.v1alpha1.AddStarredPackageResponse.starred_packages:type_name -> kubeappsapis.core.favorites.v1alpha1.StarredPackage
+	13, // 3: kubeappsapis.core.favorites.v1alpha1.RemoveStarredPackageRequest.available_package_ref:type_name -> kubeappsapis.core.packages.v1.AvailablePackageReference
+	11, // 4: kubeappsapis.core.favorites.v1alpha1.RemoveStarredPackageResponse.starred_packages:type_name -> kubeappsapis.core.favorites.v1alpha1.StarredPackage
+	12, // 5: kubeappsapis.core.favorites.v1alpha1.AddRecentInstallRequest.recent_install:type_name -> kubeappsapis.core.favorites.v1alpha1.RecentInstall
+	12, // 6: kubeappsapis.core.favorites.v1alpha1.AddRecentInstallResponse.recent_installs:type_name -> kubeappsapis.core.favorites.v1alpha1.RecentInstall
+	14, // 7: kubeappsapis.core.favorites.v1alpha1.RemoveRecentInstallRequest.installed_package_ref:type_name -> kubeappsapis.core.packages.v1.InstalledPackageReference
+	12, // 8: kubeappsapis.core.favorites.v1alpha1.RemoveRecentInstallResponse.recent_installs:type_name -> kubeappsapis.core.favorites.v1alpha1.RecentInstall
+	11, // 9: kubeappsapis.core.favorites.v1alpha1.UserFavorites.starred_packages:type_name -> kubeappsapis.core.favorites.v1alpha1.StarredPackage
+	12, // 10: kubeappsapis.core.favorites.v1alpha1.UserFavorites.recent_installs:type_name -> kubeappsapis.core.favorites.v1alpha1.RecentInstall
+	13, // 11: kubeappsapis.core.favorites.v1alpha1.StarredPackage.available_package_ref:type_name -> kubeappsapis.core.packages.v1.AvailablePackageReference
+	14, // 12: kubeappsapis.core.favorites.v1alpha1.RecentInstall.installed_package_ref:type_name -> kubeappsapis.core.packages.v1.InstalledPackageReference
+	13, // 13: kubeappsapis.core.favorites.v1alpha1.RecentInstall.available_package_ref:type_name -> kubeappsapis.core.packages.v1.AvailablePackageReference
+	0,  // 14: kubeappsapis.core.favorites.v1alpha1.FavoritesService.GetUserFavorites:input_type -> kubeappsapis.core.favorites.v1alpha1.GetUserFavoritesRequest
+	2,  // 15: kubeappsapis.core.favorites.v1alpha1.FavoritesService.AddStarredPackage:input_type -> kubeappsapis.core.favorites.v1alpha1.AddStarredPackageRequest
+	4,  // 16: kubeappsapis.core.favorites.v1alpha1.FavoritesService.RemoveStarredPackage:input_type -> kubeappsapis.core.favorites.v1alpha1.RemoveStarredPackageRequest
+	6,  // 17: kubeappsapis.core.favorites.v1alpha1.FavoritesService.AddRecentInstall:input_type -> kubeappsapis.core.favorites.v1alpha1.AddRecentInstallRequest
+	8,  // 18: kubeappsapis.core.favorites.v1alpha1.FavoritesService.RemoveRecentInstall:input_type -> kubeappsapis.core.favorites.v1alpha1.RemoveRecentInstallRequest
+	1,  // 19: kubeappsapis.core.favorites.v1alpha1.FavoritesService.GetUserFavorites:output_type -> kubeappsapis.core.favorites.v1alpha1.GetUserFavoritesResponse
+	3,  // 20: kubeappsapis.core.favorites.v1alpha1.FavoritesService.AddStarredPackage:output_type -> kubeappsapis.core.favorites.v1alpha1.AddStarredPackageResponse
+	5,  // 21: kubeappsapis.core.favorites.v1alpha1.FavoritesService.RemoveStarredPackage:output_type -> kubeappsapis.core.favorites.v1alpha1.RemoveStarredPackageResponse
+	7,  // 22: kubeappsapis.core.favorites.v1alpha1.FavoritesService.AddRecentInstall:output_type -> kubeappsapis.core.favorites.v1alpha1.AddRecentInstallResponse
+	9,  // 23: kubeappsapis.core.favorites.v1alpha1.FavoritesService.RemoveRecentInstall:output_type -> kubeappsapis.core.favorites.v1alpha1.RemoveRecentInstallResponse
+	19, // [19:24] is the sub-list for method output_type
+	14, // [14:19] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
+}
+
+func init() { file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_init() }
+func file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_init() {
+	if File_kubeappsapis_core_favorites_v1alpha1_favorites_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetUserFavoritesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetUserFavoritesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddStarredPackageRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddStarredPackageResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemoveStarredPackageRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemoveStarredPackageResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddRecentInstallRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddRecentInstallResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemoveRecentInstallRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemoveRecentInstallResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UserFavorites); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StarredPackage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RecentInstall); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   13,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_goTypes,
+		DependencyIndexes: file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_depIdxs,
+		MessageInfos:      file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_msgTypes,
+	}.Build()
+	File_kubeappsapis_core_favorites_v1alpha1_favorites_proto = out.File
+	file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_rawDesc = nil
+	file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_goTypes = nil
+	file_kubeappsapis_core_favorites_v1alpha1_favorites_proto_depIdxs = nil
+}
@@ -0,0 +1,272 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: kubeappsapis/core/favorites/v1alpha1/favorites.proto
+
+package v1alpha1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	FavoritesService_GetUserFavorites_FullMethodName     = "/kubeappsapis.core.favorites.v1alpha1.FavoritesService/GetUserFavorites"
+	FavoritesService_AddStarredPackage_FullMethodName    = "/kubeappsapis.core.favorites.v1alpha1.FavoritesService/AddStarredPackage"
+	FavoritesService_RemoveStarredPackage_FullMethodName = "/kubeappsapis.core.favorites.v1alpha1.FavoritesService/RemoveStarredPackage"
+	FavoritesService_AddRecentInstall_FullMethodName     = "/kubeappsapis.core.favorites.v1alpha1.FavoritesService/AddRecentInstall"
+	FavoritesService_RemoveRecentInstall_FullMethodName  = "/kubeappsapis.core.favorites.v1alpha1.FavoritesService/RemoveRecentInstall"
+)
+
+// FavoritesServiceClient is the client API for FavoritesService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type FavoritesServiceClient interface {
+	// GetUserFavorites returns the starred packages and the recent installs of
+	// the calling user.
+	GetUserFavorites(ctx context.Context, in *GetUserFavoritesRequest, opts ...grpc.CallOption) (*GetUserFavoritesResponse, error)
+	// AddStarredPackage stars an available package for the calling user.
+	AddStarredPackage(ctx context.Context, in *AddStarredPackageRequest, opts ...grpc.CallOption) (*AddStarredPackageResponse, error)
+	// RemoveStarredPackage unstars an available package for the calling user.
+	RemoveStarredPackage(ctx context.Context, in *RemoveStarredPackageRequest, opts ...grpc.CallOption) (*RemoveStarredPackageResponse, error)
+	// AddRecentInstall records a package installed by the calling user.
+	AddRecentInstall(ctx context.Context, in *AddRecentInstallRequest, opts ...grpc.CallOption) (*AddRecentInstallResponse, error)
+	// RemoveRecentInstall removes an installed package from the recent installs
+	// of the calling user.
+	RemoveRecentInstall(ctx context.Context, in *RemoveRecentInstallRequest, opts ...grpc.CallOption) (*RemoveRecentInstallResponse, error)
+}
+
+type favoritesServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFavoritesServiceClient(cc grpc.ClientConnInterface) FavoritesServiceClient {
+	return &favoritesServiceClient{cc}
+}
+
+func (c *favoritesServiceClient) GetUserFavorites(ctx context.Context, in *GetUserFavoritesRequest, opts ...grpc.CallOption) (*GetUserFavoritesResponse, error) {
+	out := new(GetUserFavoritesResponse)
+	err := c.cc.Invoke(ctx, FavoritesService_GetUserFavorites_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *favoritesServiceClient) AddStarredPackage(ctx context.Context, in *AddStarredPackageRequest, opts ...grpc.CallOption) (*AddStarredPackageResponse, error) {
+	out := new(AddStarredPackageResponse)
+	err := c.cc.Invoke(ctx, FavoritesService_AddStarredPackage_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *favoritesServiceClient) RemoveStarredPackage(ctx context.Context, in *RemoveStarredPackageRequest, opts ...grpc.CallOption) (*RemoveStarredPackageResponse, error) {
+	out := new(RemoveStarredPackageResponse)
+	err := c.cc.Invoke(ctx, FavoritesService_RemoveStarredPackage_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *favoritesServiceClient) AddRecentInstall(ctx context.Context, in *AddRecentInstallRequest, opts ...grpc.CallOption) (*AddRecentInstallResponse, error) {
+	out := new(AddRecentInstallResponse)
+	err := c.cc.Invoke(ctx, FavoritesService_AddRecentInstall_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *favoritesServiceClient) RemoveRecentInstall(ctx context.Context, in *RemoveRecentInstallRequest, opts ...grpc.CallOption) (*RemoveRecentInstallResponse, error) {
+	out := new(RemoveRecentInstallResponse)
+	err := c.cc.Invoke(ctx, FavoritesService_RemoveRecentInstall_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FavoritesServiceServer is the server API for FavoritesService service.
+// All implementations should embed UnimplementedFavoritesServiceServer
+// for forward compatibility
+type FavoritesServiceServer interface {
+	// GetUserFavorites returns the starred packages and the recent installs of
+	// the calling user.
+	GetUserFavorites(context.Context, *GetUserFavoritesRequest) (*GetUserFavoritesResponse, error)
+	// AddStarredPackage stars an available package for the calling user.
+	AddStarredPackage(context.Context, *AddStarredPackageRequest) (*AddStarredPackageResponse, error)
+	// RemoveStarredPackage unstars an available package for the calling user.
+	RemoveStarredPackage(context.Context, *RemoveStarredPackageRequest) (*RemoveStarredPackageResponse, error)
+	// AddRecentInstall records a package installed by the calling user.
+	AddRecentInstall(context.Context, *AddRecentInstallRequest) (*AddRecentInstallResponse, error)
+	// RemoveRecentInstall removes an installed package from the recent installs
+	// of the calling user.
+	RemoveRecentInstall(context.Context, *RemoveRecentInstallRequest) (*RemoveRecentInstallResponse, error)
+}
+
+// UnimplementedFavoritesServiceServer should be embedded to have forward compatible implementations.
+type UnimplementedFavoritesServiceServer struct {
+}
+
+func (UnimplementedFavoritesServiceServer) GetUserFavorites(context.Context, *GetUserFavoritesRequest) (*GetUserFavoritesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserFavorites not implemented")
+}
+func (UnimplementedFavoritesServiceServer) AddStarredPackage(context.Context, *AddStarredPackageRequest) (*AddStarredPackageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddStarredPackage not implemented")
+}
+func (UnimplementedFavoritesServiceServer) RemoveStarredPackage(context.Context, *RemoveStarredPackageRequest) (*RemoveStarredPackageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveStarredPackage not implemented")
+}
+func (UnimplementedFavoritesServiceServer) AddRecentInstall(context.Context, *AddRecentInstallRequest) (*AddRecentInstallResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddRecentInstall not implemented")
+}
+func (UnimplementedFavoritesServiceServer) RemoveRecentInstall(context.Context, *RemoveRecentInstallRequest) (*RemoveRecentInstallResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveRecentInstall not implemented")
+}
+
+// UnsafeFavoritesServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FavoritesServiceServer will
+// result in compilation errors.
+type UnsafeFavoritesServiceServer interface {
+	mustEmbedUnimplementedFavoritesServiceServer()
+}
+
+func RegisterFavoritesServiceServer(s grpc.ServiceRegistrar, srv FavoritesServiceServer) {
+	s.RegisterService(&FavoritesService_ServiceDesc, srv)
+}
+
+func _FavoritesService_GetUserFavorites_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserFavoritesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FavoritesServiceServer).GetUserFavorites(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FavoritesService_GetUserFavorites_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FavoritesServiceServer).GetUserFavorites(ctx, req.(*GetUserFavoritesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FavoritesService_AddStarredPackage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddStarredPackageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FavoritesServiceServer).AddStarredPackage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FavoritesService_AddStarredPackage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FavoritesServiceServer).AddStarredPackage(ctx, req.(*AddStarredPackageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FavoritesService_RemoveStarredPackage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveStarredPackageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FavoritesServiceServer).RemoveStarredPackage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FavoritesService_RemoveStarredPackage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FavoritesServiceServer).RemoveStarredPackage(ctx, req.(*RemoveStarredPackageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FavoritesService_AddRecentInstall_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddRecentInstallRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FavoritesServiceServer).AddRecentInstall(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FavoritesService_AddRecentInstall_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FavoritesServiceServer).AddRecentInstall(ctx, req.(*AddRecentInstallRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FavoritesService_RemoveRecentInstall_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveRecentInstallRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FavoritesServiceServer).RemoveRecentInstall(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FavoritesService_RemoveRecentInstall_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FavoritesServiceServer).RemoveRecentInstall(ctx, req.(*RemoveRecentInstallRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// FavoritesService_ServiceDesc is the grpc.ServiceDesc for FavoritesService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var FavoritesService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "kubeappsapis.core.favorites.v1alpha1.FavoritesService",
+	HandlerType: (*FavoritesServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetUserFavorites",
+			Handler:    _FavoritesService_GetUserFavorites_Handler,
+		},
+		{
+			MethodName: "AddStarredPackage",
+			Handler:    _FavoritesService_AddStarredPackage_Handler,
+		},
+		{
+			MethodName: "RemoveStarredPackage",
+			Handler:    _FavoritesService_RemoveStarredPackage_Handler,
+		},
+		{
+			MethodName: "AddRecentInstall",
+			Handler:    _FavoritesService_AddRecentInstall_Handler,
+		},
+		{
+			MethodName: "RemoveRecentInstall",
+			Handler:    _FavoritesService_RemoveRecentInstall_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "kubeappsapis/core/favorites/v1alpha1/favorites.proto",
+}
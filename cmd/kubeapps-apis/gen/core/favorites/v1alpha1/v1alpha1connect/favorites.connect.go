@@ -0,0 +1,235 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: kubeappsapis/core/favorites/v1alpha1/favorites.proto
+
+package v1alpha1connect
+
+import (
+	context "context"
+	errors "errors"
+	connect_go "github.com/bufbuild/connect-go"
+	v1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/favorites/v1alpha1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect_go.IsAtLeastVersion0_1_0
+
+const (
+	// FavoritesServiceName is the fully-qualified name of the FavoritesService service.
+	FavoritesServiceName = "kubeappsapis.core.favorites.v1alpha1.FavoritesService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// FavoritesServiceGetUserFavoritesProcedure is the fully-qualified name of the FavoritesService's
+	// GetUserFavorites RPC.
+	FavoritesServiceGetUserFavoritesProcedure = "/kubeappsapis.core.favorites.v1alpha1.FavoritesService/GetUserFavorites"
+	// FavoritesServiceAddStarredPackageProcedure is the fully-qualified name of the FavoritesService's
+	// AddStarredPackage RPC.
+	FavoritesServiceAddStarredPackageProcedure = "/kubeappsapis.core.favorites.v1alpha1.FavoritesService/AddStarredPackage"
+	// FavoritesServiceRemoveStarredPackageProcedure is the fully-qualified name of the
+	// FavoritesService's RemoveStarredPackage RPC.
+	FavoritesServiceRemoveStarredPackageProcedure = "/kubeappsapis.core.favorites.v1alpha1.FavoritesService/RemoveStarredPackage"
+	// FavoritesServiceAddRecentInstallProcedure is the fully-qualified name of the FavoritesService's
+	// AddRecentInstall RPC.
+	FavoritesServiceAddRecentInstallProcedure = "/kubeappsapis.core.favorites.v1alpha1.FavoritesService/AddRecentInstall"
+	// FavoritesServiceRemoveRecentInstallProcedure is the fully-qualified name of the
+	// FavoritesService's RemoveRecentInstall RPC.
+	FavoritesServiceRemoveRecentInstallProcedure = "/kubeappsapis.core.favorites.v1alpha1.FavoritesService/RemoveRecentInstall"
+)
+
+// FavoritesServiceClient is a client for the kubeappsapis.core.favorites.v1alpha1.FavoritesService
+// service.
+type FavoritesServiceClient interface {
+	// GetUserFavorites returns the starred packages and the recent installs of
+	// the calling user.
+	GetUserFavorites(context.Context, *connect_go.Request[v1alpha1.GetUserFavoritesRequest]) (*connect_go.Response[v1alpha1.GetUserFavoritesResponse], error)
+	// AddStarredPackage stars an available package for the calling user.
+	AddStarredPackage(context.Context, *connect_go.Request[v1alpha1.AddStarredPackageRequest]) (*connect_go.Response[v1alpha1.AddStarredPackageResponse], error)
+	// RemoveStarredPackage unstars an available package for the calling user.
+	RemoveStarredPackage(context.Context, *connect_go.Request[v1alpha1.RemoveStarredPackageRequest]) (*connect_go.Response[v1alpha1.RemoveStarredPackageResponse], error)
+	// AddRecentInstall records a package installed by the calling user.
+	AddRecentInstall(context.Context, *connect_go.Request[v1alpha1.AddRecentInstallRequest]) (*connect_go.Response[v1alpha1.AddRecentInstallResponse], error)
+	// RemoveRecentInstall removes an installed package from the recent installs
+	// of the calling user.
+	RemoveRecentInstall(context.Context, *connect_go.Request[v1alpha1.RemoveRecentInstallRequest]) (*connect_go.Response[v1alpha1.RemoveRecentInstallResponse], error)
+}
+
+// NewFavoritesServiceClient constructs a client for the
+// kubeappsapis.core.favorites.v1alpha1.FavoritesService service. By default, it uses the Connect
+// protocol with the binary Protobuf Codec, asks for gzipped responses, and sends uncompressed
+// requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC() or
+// connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewFavoritesServiceClient(httpClient connect_go.HTTPClient, baseURL string, opts ...connect_go.ClientOption) FavoritesServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	return &favoritesServiceClient{
+		getUserFavorites: connect_go.NewClient[v1alpha1.GetUserFavoritesRequest, v1alpha1.GetUserFavoritesResponse](
+			httpClient,
+			baseURL+FavoritesServiceGetUserFavoritesProcedure,
+			opts...,
+		),
+		addStarredPackage: connect_go.NewClient[v1alpha1.AddStarredPackageRequest, v1alpha1.AddStarredPackageResponse](
+			httpClient,
+			baseURL+FavoritesServiceAddStarredPackageProcedure,
+			opts...,
+		),
+		removeStarredPackage: connect_go.NewClient[v1alpha1.RemoveStarredPackageRequest, v1alpha1.RemoveStarredPackageResponse](
+			httpClient,
+			baseURL+FavoritesServiceRemoveStarredPackageProcedure,
+			opts...,
+		),
+		addRecentInstall: connect_go.NewClient[v1alpha1.AddRecentInstallRequest, v1alpha1.AddRecentInstallResponse](
+			httpClient,
+			baseURL+FavoritesServiceAddRecentInstallProcedure,
+			opts...,
+		),
+		removeRecentInstall: connect_go.NewClient[v1alpha1.RemoveRecentInstallRequest, v1alpha1.RemoveRecentInstallResponse](
+			httpClient,
+			baseURL+FavoritesServiceRemoveRecentInstallProcedure,
+			opts...,
+		),
+	}
+}
+
+// favoritesServiceClient implements FavoritesServiceClient.
+type favoritesServiceClient struct {
+	getUserFavorites     *connect_go.Client[v1alpha1.GetUserFavoritesRequest, v1alpha1.GetUserFavoritesResponse]
+	addStarredPackage    *connect_go.Client[v1alpha1.AddStarredPackageRequest, v1alpha1.AddStarredPackageResponse]
+	removeStarredPackage *connect_go.Client[v1alpha1.RemoveStarredPackageRequest, v1alpha1.RemoveStarredPackageResponse]
+	addRecentInstall     *connect_go.Client[v1alpha1.AddRecentInstallRequest, v1alpha1.AddRecentInstallResponse]
+	removeRecentInstall  *connect_go.Client[v1alpha1.RemoveRecentInstallRequest, v1alpha1.RemoveRecentInstallResponse]
+}
+
+// GetUserFavorites calls kubeappsapis.core.favorites.v1alpha1.FavoritesService.GetUserFavorites.
+func (c *favoritesServiceClient) GetUserFavorites(ctx context.Context, req *connect_go.Request[v1alpha1.GetUserFavoritesRequest]) (*connect_go.Response[v1alpha1.GetUserFavoritesResponse], error) {
+	return c.getUserFavorites.CallUnary(ctx, req)
+}
+
+// AddStarredPackage calls kubeappsapis.core.favorites.v1alpha1.FavoritesService.AddStarredPackage.
+func (c *favoritesServiceClient) AddStarredPackage(ctx context.Context, req *connect_go.Request[v1alpha1.AddStarredPackageRequest]) (*connect_go.Response[v1alpha1.AddStarredPackageResponse], error) {
+	return c.addStarredPackage.CallUnary(ctx, req)
+}
+
+// RemoveStarredPackage calls
+// kubeappsapis.core.favorites.v1alpha1.FavoritesService.RemoveStarredPackage.
+func (c *favoritesServiceClient) RemoveStarredPackage(ctx context.Context, req *connect_go.Request[v1alpha1.RemoveStarredPackageRequest]) (*connect_go.Response[v1alpha1.RemoveStarredPackageResponse], error) {
+	return c.removeStarredPackage.CallUnary(ctx, req)
+}
+
+// AddRecentInstall calls kubeappsapis.core.favorites.v1alpha1.FavoritesService.AddRecentInstall.
+func (c *favoritesServiceClient) AddRecentInstall(ctx context.Context, req *connect_go.Request[v1alpha1.AddRecentInstallRequest]) (*connect_go.Response[v1alpha1.AddRecentInstallResponse], error) {
+	return c.addRecentInstall.CallUnary(ctx, req)
+}
+
+// RemoveRecentInstall calls
+// kubeappsapis.core.favorites.v1alpha1.FavoritesService.RemoveRecentInstall.
+func (c *favoritesServiceClient) RemoveRecentInstall(ctx context.Context, req *connect_go.Request[v1alpha1.RemoveRecentInstallRequest]) (*connect_go.Response[v1alpha1.RemoveRecentInstallResponse], error) {
+	return c.removeRecentInstall.CallUnary(ctx, req)
+}
+
+// FavoritesServiceHandler is an implementation of the
+// kubeappsapis.core.favorites.v1alpha1.FavoritesService service.
+type FavoritesServiceHandler interface {
+	// GetUserFavorites returns the starred packages and the recent installs of
+	// the calling user.
+	GetUserFavorites(context.Context, *connect_go.Request[v1alpha1.GetUserFavoritesRequest]) (*connect_go.Response[v1alpha1.GetUserFavoritesResponse], error)
+	// AddStarredPackage stars an available package for the calling user.
+	AddStarredPackage(context.Context, *connect_go.Request[v1alpha1.AddStarredPackageRequest]) (*connect_go.Response[v1alpha1.AddStarredPackageResponse], error)
+	// RemoveStarredPackage unstars an available package for the calling user.
+	RemoveStarredPackage(context.Context, *connect_go.Request[v1alpha1.RemoveStarredPackageRequest]) (*connect_go.Response[v1alpha1.RemoveStarredPackageResponse], error)
+	// AddRecentInstall records a package installed by the calling user.
+	AddRecentInstall(context.Context, *connect_go.Request[v1alpha1.AddRecentInstallRequest]) (*connect_go.Response[v1alpha1.AddRecentInstallResponse], error)
+	// RemoveRecentInstall removes an installed package from the recent installs
+	// of the calling user.
+	RemoveRecentInstall(context.Context, *connect_go.Request[v1alpha1.RemoveRecentInstallRequest]) (*connect_go.Response[v1alpha1.RemoveRecentInstallResponse], error)
+}
+
+// NewFavoritesServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewFavoritesServiceHandler(svc FavoritesServiceHandler, opts ...connect_go.HandlerOption) (string, http.Handler) {
+	favoritesServiceGetUserFavoritesHandler := connect_go.NewUnaryHandler(
+		FavoritesServiceGetUserFavoritesProcedure,
+		svc.GetUserFavorites,
+		opts...,
+	)
+	favoritesServiceAddStarredPackageHandler := connect_go.NewUnaryHandler(
+		FavoritesServiceAddStarredPackageProcedure,
+		svc.AddStarredPackage,
+		opts...,
+	)
+	favoritesServiceRemoveStarredPackageHandler := connect_go.NewUnaryHandler(
+		FavoritesServiceRemoveStarredPackageProcedure,
+		svc.RemoveStarredPackage,
+		opts...,
+	)
+	favoritesServiceAddRecentInstallHandler := connect_go.NewUnaryHandler(
+		FavoritesServiceAddRecentInstallProcedure,
+		svc.AddRecentInstall,
+		opts...,
+	)
+	favoritesServiceRemoveRecentInstallHandler := connect_go.NewUnaryHandler(
+		FavoritesServiceRemoveRecentInstallProcedure,
+		svc.RemoveRecentInstall,
+		opts...,
+	)
+	return "/kubeappsapis.core.favorites.v1alpha1.FavoritesService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case FavoritesServiceGetUserFavoritesProcedure:
+			favoritesServiceGetUserFavoritesHandler.ServeHTTP(w, r)
+		case FavoritesServiceAddStarredPackageProcedure:
+			favoritesServiceAddStarredPackageHandler.ServeHTTP(w, r)
+		case FavoritesServiceRemoveStarredPackageProcedure:
+			favoritesServiceRemoveStarredPackageHandler.ServeHTTP(w, r)
+		case FavoritesServiceAddRecentInstallProcedure:
+			favoritesServiceAddRecentInstallHandler.ServeHTTP(w, r)
+		case FavoritesServiceRemoveRecentInstallProcedure:
+			favoritesServiceRemoveRecentInstallHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedFavoritesServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedFavoritesServiceHandler struct{}
+
+func (UnimplementedFavoritesServiceHandler) GetUserFavorites(context.Context, *connect_go.Request[v1alpha1.GetUserFavoritesRequest]) (*connect_go.Response[v1alpha1.GetUserFavoritesResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.core.favorites.v1alpha1.FavoritesService.GetUserFavorites is not implemented"))
+}
+
+func (UnimplementedFavoritesServiceHandler) AddStarredPackage(context.Context, *connect_go.Request[v1alpha1.AddStarredPackageRequest]) (*connect_go.Response[v1alpha1.AddStarredPackageResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.core.favorites.v1alpha1.FavoritesService.AddStarredPackage is not implemented"))
+}
+
+func (UnimplementedFavoritesServiceHandler) RemoveStarredPackage(context.Context, *connect_go.Request[v1alpha1.RemoveStarredPackageRequest]) (*connect_go.Response[v1alpha1.RemoveStarredPackageResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.core.favorites.v1alpha1.FavoritesService.RemoveStarredPackage is not implemented"))
+}
+
+func (UnimplementedFavoritesServiceHandler) AddRecentInstall(context.Context, *connect_go.Request[v1alpha1.AddRecentInstallRequest]) (*connect_go.Response[v1alpha1.AddRecentInstallResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.core.favorites.v1alpha1.FavoritesService.AddRecentInstall is not implemented"))
+}
+
+func (UnimplementedFavoritesServiceHandler) RemoveRecentInstall(context.Context, *connect_go.Request[v1alpha1.RemoveRecentInstallRequest]) (*connect_go.Response[v1alpha1.RemoveRecentInstallResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.core.favorites.v1alpha1.FavoritesService.RemoveRecentInstall is not implemented"))
+}
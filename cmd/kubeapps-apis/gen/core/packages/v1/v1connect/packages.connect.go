@@ -0,0 +1,602 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: kubeappsapis/core/packages/v1/packages.proto
+
+package v1connect
+
+import (
+	context "context"
+	errors "errors"
+	connect_go "github.com/bufbuild/connect-go"
+	v1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect_go.IsAtLeastVersion0_1_0
+
+const (
+	// PackagesServiceName is the fully-qualified name of the PackagesService service.
+	PackagesServiceName = "kubeappsapis.core.packages.v1.PackagesService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// PackagesServiceGetAvailablePackageSummariesProcedure is the fully-qualified name of the
+	// PackagesService's GetAvailablePackageSummaries RPC.
+	PackagesServiceGetAvailablePackageSummariesProcedure = "/kubeappsapis.core.packages.v1.PackagesService/GetAvailablePackageSummaries"
+	// PackagesServiceGetAvailablePackageDetailProcedure is the fully-qualified name of the
+	// PackagesService's GetAvailablePackageDetail RPC.
+	PackagesServiceGetAvailablePackageDetailProcedure = "/kubeappsapis.core.packages.v1.PackagesService/GetAvailablePackageDetail"
+	// PackagesServiceGetAvailablePackageVersionsProcedure is the fully-qualified name of the
+	// PackagesService's GetAvailablePackageVersions RPC.
+	PackagesServiceGetAvailablePackageVersionsProcedure = "/kubeappsapis.core.packages.v1.PackagesService/GetAvailablePackageVersions"
+	// PackagesServiceGetAvailablePackageTextAssetProcedure is the fully-qualified name of the
+	// PackagesService's GetAvailablePackageTextAsset RPC.
+	PackagesServiceGetAvailablePackageTextAssetProcedure = "/kubeappsapis.core.packages.v1.PackagesService/GetAvailablePackageTextAsset"
+	// PackagesServiceGetAvailablePackageChangelogProcedure is the fully-qualified name of the
+	// PackagesService's GetAvailablePackageChangelog RPC.
+	PackagesServiceGetAvailablePackageChangelogProcedure = "/kubeappsapis.core.packages.v1.PackagesService/GetAvailablePackageChangelog"
+	// PackagesServiceGetAvailablePackageFiltersProcedure is the fully-qualified name of the
+	// PackagesService's GetAvailablePackageFilters RPC.
+	PackagesServiceGetAvailablePackageFiltersProcedure = "/kubeappsapis.core.packages.v1.PackagesService/GetAvailablePackageFilters"
+	// PackagesServiceGetInstalledPackageSummariesProcedure is the fully-qualified name of the
+	// PackagesService's GetInstalledPackageSummaries RPC.
+	PackagesServiceGetInstalledPackageSummariesProcedure = "/kubeappsapis.core.packages.v1.PackagesService/GetInstalledPackageSummaries"
+	// PackagesServiceGetInstalledPackageDetailProcedure is the fully-qualified name of the
+	// PackagesService's GetInstalledPackageDetail RPC.
+	PackagesServiceGetInstalledPackageDetailProcedure = "/kubeappsapis.core.packages.v1.PackagesService/GetInstalledPackageDetail"
+	// PackagesServiceCheckInstalledPackageNameAvailabilityProcedure is the fully-qualified name of the
+	// PackagesService's CheckInstalledPackageNameAvailability RPC.
+	PackagesServiceCheckInstalledPackageNameAvailabilityProcedure = "/kubeappsapis.core.packages.v1.PackagesService/CheckInstalledPackageNameAvailability"
+	// PackagesServiceCreateInstalledPackageProcedure is the fully-qualified name of the
+	// PackagesService's CreateInstalledPackage RPC.
+	PackagesServiceCreateInstalledPackageProcedure = "/kubeappsapis.core.packages.v1.PackagesService/CreateInstalledPackage"
+	// PackagesServiceUpdateInstalledPackageProcedure is the fully-qualified name of the
+	// PackagesService's UpdateInstalledPackage RPC.
+	PackagesServiceUpdateInstalledPackageProcedure = "/kubeappsapis.core.packages.v1.PackagesService/UpdateInstalledPackage"
+	// PackagesServiceDeleteInstalledPackageProcedure is the fully-qualified name of the
+	// PackagesService's DeleteInstalledPackage RPC.
+	PackagesServiceDeleteInstalledPackageProcedure = "/kubeappsapis.core.packages.v1.PackagesService/DeleteInstalledPackage"
+	// PackagesServiceGetInstalledPackageResourceRefsProcedure is the fully-qualified name of the
+	// PackagesService's GetInstalledPackageResourceRefs RPC.
+	PackagesServiceGetInstalledPackageResourceRefsProcedure = "/kubeappsapis.core.packages.v1.PackagesService/GetInstalledPackageResourceRefs"
+	// PackagesServiceBulkUpdateInstalledPackagesProcedure is the fully-qualified name of the
+	// PackagesService's BulkUpdateInstalledPackages RPC.
+	PackagesServiceBulkUpdateInstalledPackagesProcedure = "/kubeappsapis.core.packages.v1.PackagesService/BulkUpdateInstalledPackages"
+	// PackagesServiceBulkDeleteInstalledPackagesProcedure is the fully-qualified name of the
+	// PackagesService's BulkDeleteInstalledPackages RPC.
+	PackagesServiceBulkDeleteInstalledPackagesProcedure = "/kubeappsapis.core.packages.v1.PackagesService/BulkDeleteInstalledPackages"
+	// PackagesServiceCloneInstalledPackageProcedure is the fully-qualified name of the
+	// PackagesService's CloneInstalledPackage RPC.
+	PackagesServiceCloneInstalledPackageProcedure = "/kubeappsapis.core.packages.v1.PackagesService/CloneInstalledPackage"
+	// PackagesServiceGetInstalledPackageOperationStatusProcedure is the fully-qualified name of the
+	// PackagesService's GetInstalledPackageOperationStatus RPC.
+	PackagesServiceGetInstalledPackageOperationStatusProcedure = "/kubeappsapis.core.packages.v1.PackagesService/GetInstalledPackageOperationStatus"
+)
+
+// PackagesServiceClient is a client for the kubeappsapis.core.packages.v1.PackagesService service.
+type PackagesServiceClient interface {
+	GetAvailablePackageSummaries(context.Context, *connect_go.Request[v1.GetAvailablePackageSummariesRequest]) (*connect_go.Response[v1.GetAvailablePackageSummariesResponse], error)
+	GetAvailablePackageDetail(context.Context, *connect_go.Request[v1.GetAvailablePackageDetailRequest]) (*connect_go.Response[v1.GetAvailablePackageDetailResponse], error)
+	GetAvailablePackageVersions(context.Context, *connect_go.Request[v1.GetAvailablePackageVersionsRequest]) (*connect_go.Response[v1.GetAvailablePackageVersionsResponse], error)
+	GetAvailablePackageTextAsset(context.Context, *connect_go.Request[v1.GetAvailablePackageTextAssetRequest]) (*connect_go.Response[v1.GetAvailablePackageTextAssetResponse], error)
+	GetAvailablePackageChangelog(context.Context, *connect_go.Request[v1.GetAvailablePackageChangelogRequest]) (*connect_go.Response[v1.GetAvailablePackageChangelogResponse], error)
+	// GetAvailablePackageFilters returns the distinct values, across every
+	// configured plugin, that can be used in the FilterOptions of the available
+	// packages, such as their categories and providers.
+	GetAvailablePackageFilters(context.Context, *connect_go.Request[v1.GetAvailablePackageFiltersRequest]) (*connect_go.Response[v1.GetAvailablePackageFiltersResponse], error)
+	GetInstalledPackageSummaries(context.Context, *connect_go.Request[v1.GetInstalledPackageSummariesRequest]) (*connect_go.Response[v1.GetInstalledPackageSummariesResponse], error)
+	GetInstalledPackageDetail(context.Context, *connect_go.Request[v1.GetInstalledPackageDetailRequest]) (*connect_go.Response[v1.GetInstalledPackageDetailResponse], error)
+	// CheckInstalledPackageNameAvailability checks, with every configured plugin,
+	// whether a package can be installed with the given name in the target namespace.
+	CheckInstalledPackageNameAvailability(context.Context, *connect_go.Request[v1.CheckInstalledPackageNameAvailabilityRequest]) (*connect_go.Response[v1.CheckInstalledPackageNameAvailabilityResponse], error)
+	CreateInstalledPackage(context.Context, *connect_go.Request[v1.CreateInstalledPackageRequest]) (*connect_go.Response[v1.CreateInstalledPackageResponse], error)
+	UpdateInstalledPackage(context.Context, *connect_go.Request[v1.UpdateInstalledPackageRequest]) (*connect_go.Response[v1.UpdateInstalledPackageResponse], error)
+	DeleteInstalledPackage(context.Context, *connect_go.Request[v1.DeleteInstalledPackageRequest]) (*connect_go.Response[v1.DeleteInstalledPackageResponse], error)
+	GetInstalledPackageResourceRefs(context.Context, *connect_go.Request[v1.GetInstalledPackageResourceRefsRequest]) (*connect_go.Response[v1.GetInstalledPackageResourceRefsResponse], error)
+	// BulkUpdateInstalledPackages updates several installed packages, such as
+	// the same package installed in many namespaces, to the given version,
+	// keeping the values and reconciliation options of each of them. The
+	// updates are performed concurrently and a failed update does not prevent
+	// the others, its error being reported in the result of that package.
+	BulkUpdateInstalledPackages(context.Context, *connect_go.Request[v1.BulkUpdateInstalledPackagesRequest]) (*connect_go.Response[v1.BulkUpdateInstalledPackagesResponse], error)
+	// BulkDeleteInstalledPackages deletes several installed packages
+	// concurrently, reporting the error of each failed deletion in the result
+	// of that package.
+	BulkDeleteInstalledPackages(context.Context, *connect_go.Request[v1.BulkDeleteInstalledPackagesRequest]) (*connect_go.Response[v1.BulkDeleteInstalledPackagesResponse], error)
+	// CloneInstalledPackage re-creates an installed package, with the same
+	// package, version reference, values and custom detail, in another namespace
+	// or cluster, such as to promote the configuration of an application from a
+	// development namespace to a staging one. The plugin re-creates the objects
+	// it manages for the installed package, such as the Secrets holding its
+	// values, in the target namespace.
+	CloneInstalledPackage(context.Context, *connect_go.Request[v1.CloneInstalledPackageRequest]) (*connect_go.Response[v1.CloneInstalledPackageResponse], error)
+	// GetInstalledPackageOperationStatus returns the progress of the operation
+	// in course on an installed package, such as its installation, so that
+	// clients can follow it once CreateInstalledPackage returns.
+	GetInstalledPackageOperationStatus(context.Context, *connect_go.Request[v1.GetInstalledPackageOperationStatusRequest]) (*connect_go.Response[v1.GetInstalledPackageOperationStatusResponse], error)
+}
+
+// NewPackagesServiceClient constructs a client for the
+// kubeappsapis.core.packages.v1.PackagesService service. By default, it uses the Connect protocol
+// with the binary Protobuf Codec, asks for gzipped responses, and sends uncompressed requests. To
+// use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC() or connect.WithGRPCWeb()
+// options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewPackagesServiceClient(httpClient connect_go.HTTPClient, baseURL string, opts ...connect_go.ClientOption) PackagesServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	return &packagesServiceClient{
+		getAvailablePackageSummaries: connect_go.NewClient[v1.GetAvailablePackageSummariesRequest, v1.GetAvailablePackageSummariesResponse](
+			httpClient,
+			baseURL+PackagesServiceGetAvailablePackageSummariesProcedure,
+			opts...,
+		),
+		getAvailablePackageDetail: connect_go.NewClient[v1.GetAvailablePackageDetailRequest, v1.GetAvailablePackageDetailResponse](
+			httpClient,
+			baseURL+PackagesServiceGetAvailablePackageDetailProcedure,
+			opts...,
+		),
+		getAvailablePackageVersions: connect_go.NewClient[v1.GetAvailablePackageVersionsRequest, v1.GetAvailablePackageVersionsResponse](
+			httpClient,
+			baseURL+PackagesServiceGetAvailablePackageVersionsProcedure,
+			opts...,
+		),
+		getAvailablePackageTextAsset: connect_go.NewClient[v1.GetAvailablePackageTextAssetRequest, v1.GetAvailablePackageTextAssetResponse](
+			httpClient,
+			baseURL+PackagesServiceGetAvailablePackageTextAssetProcedure,
+			opts...,
+		),
+		getAvailablePackageChangelog: connect_go.NewClient[v1.GetAvailablePackageChangelogRequest, v1.GetAvailablePackageChangelogResponse](
+			httpClient,
+			baseURL+PackagesServiceGetAvailablePackageChangelogProcedure,
+			opts...,
+		),
+		getAvailablePackageFilters: connect_go.NewClient[v1.GetAvailablePackageFiltersRequest, v1.GetAvailablePackageFiltersResponse](
+			httpClient,
+			baseURL+PackagesServiceGetAvailablePackageFiltersProcedure,
+			opts...,
+		),
+		getInstalledPackageSummaries: connect_go.NewClient[v1.GetInstalledPackageSummariesRequest, v1.GetInstalledPackageSummariesResponse](
+			httpClient,
+			baseURL+PackagesServiceGetInstalledPackageSummariesProcedure,
+			opts...,
+		),
+		getInstalledPackageDetail: connect_go.NewClient[v1.GetInstalledPackageDetailRequest, v1.GetInstalledPackageDetailResponse](
+			httpClient,
+			baseURL+PackagesServiceGetInstalledPackageDetailProcedure,
+			opts...,
+		),
+		checkInstalledPackageNameAvailability: connect_go.NewClient[v1.CheckInstalledPackageNameAvailabilityRequest, v1.CheckInstalledPackageNameAvailabilityResponse](
+			httpClient,
+			baseURL+PackagesServiceCheckInstalledPackageNameAvailabilityProcedure,
+			opts...,
+		),
+		createInstalledPackage: connect_go.NewClient[v1.CreateInstalledPackageRequest, v1.CreateInstalledPackageResponse](
+			httpClient,
+			baseURL+PackagesServiceCreateInstalledPackageProcedure,
+			opts...,
+		),
+		updateInstalledPackage: connect_go.NewClient[v1.UpdateInstalledPackageRequest, v1.UpdateInstalledPackageResponse](
+			httpClient,
+			baseURL+PackagesServiceUpdateInstalledPackageProcedure,
+			opts...,
+		),
+		deleteInstalledPackage: connect_go.NewClient[v1.DeleteInstalledPackageRequest, v1.DeleteInstalledPackageResponse](
+			httpClient,
+			baseURL+PackagesServiceDeleteInstalledPackageProcedure,
+			opts...,
+		),
+		getInstalledPackageResourceRefs: connect_go.NewClient[v1.GetInstalledPackageResourceRefsRequest, v1.GetInstalledPackageResourceRefsResponse](
+			httpClient,
+			baseURL+PackagesServiceGetInstalledPackageResourceRefsProcedure,
+			opts...,
+		),
+		bulkUpdateInstalledPackages: connect_go.NewClient[v1.BulkUpdateInstalledPackagesRequest, v1.BulkUpdateInstalledPackagesResponse](
+			httpClient,
+			baseURL+PackagesServiceBulkUpdateInstalledPackagesProcedure,
+			opts...,
+		),
+		bulkDeleteInstalledPackages: connect_go.NewClient[v1.BulkDeleteInstalledPackagesRequest, v1.BulkDeleteInstalledPackagesResponse](
+			httpClient,
+			baseURL+PackagesServiceBulkDeleteInstalledPackagesProcedure,
+			opts...,
+		),
+		cloneInstalledPackage: connect_go.NewClient[v1.CloneInstalledPackageRequest, v1.CloneInstalledPackageResponse](
+			httpClient,
+			baseURL+PackagesServiceCloneInstalledPackageProcedure,
+			opts...,
+		),
+		getInstalledPackageOperationStatus: connect_go.NewClient[v1.GetInstalledPackageOperationStatusRequest, v1.GetInstalledPackageOperationStatusResponse](
+			httpClient,
+			baseURL+PackagesServiceGetInstalledPackageOperationStatusProcedure,
+			opts...,
+		),
+	}
+}
+
+// packagesServiceClient implements PackagesServiceClient.
+type packagesServiceClient struct {
+	getAvailablePackageSummaries          *connect_go.Client[v1.GetAvailablePackageSummariesRequest, v1.GetAvailablePackageSummariesResponse]
+	getAvailablePackageDetail             *connect_go.Client[v1.GetAvailablePackageDetailRequest, v1.GetAvailablePackageDetailResponse]
+	getAvailablePackageVersions           *connect_go.Client[v1.GetAvailablePackageVersionsRequest, v1.GetAvailablePackageVersionsResponse]
+	getAvailablePackageTextAsset          *connect_go.Client[v1.GetAvailablePackageTextAssetRequest, v1.GetAvailablePackageTextAssetResponse]
+	getAvailablePackageChangelog          *connect_go.Client[v1.GetAvailablePackageChangelogRequest, v1.GetAvailablePackageChangelogResponse]
+	getAvailablePackageFilters            *connect_go.Client[v1.GetAvailablePackageFiltersRequest, v1.GetAvailablePackageFiltersResponse]
+	getInstalledPackageSummaries          *connect_go.Client[v1.GetInstalledPackageSummariesRequest, v1.GetInstalledPackageSummariesResponse]
+	getInstalledPackageDetail             *connect_go.Client[v1.GetInstalledPackageDetailRequest, v1.GetInstalledPackageDetailResponse]
+	checkInstalledPackageNameAvailability *connect_go.Client[v1.CheckInstalledPackageNameAvailabilityRequest, v1.CheckInstalledPackageNameAvailabilityResponse]
+	createInstalledPackage                *connect_go.Client[v1.CreateInstalledPackageRequest, v1.CreateInstalledPackageResponse]
+	updateInstalledPackage                *connect_go.Client[v1.UpdateInstalledPackageRequest, v1.UpdateInstalledPackageResponse]
+	deleteInstalledPackage                *connect_go.Client[v1.DeleteInstalledPackageRequest, v1.DeleteInstalledPackageResponse]
+	getInstalledPackageResourceRefs       *connect_go.Client[v1.GetInstalledPackageResourceRefsRequest, v1.GetInstalledPackageResourceRefsResponse]
+	bulkUpdateInstalledPackages           *connect_go.Client[v1.BulkUpdateInstalledPackagesRequest, v1.BulkUpdateInstalledPackagesResponse]
+	bulkDeleteInstalledPackages           *connect_go.Client[v1.BulkDeleteInstalledPackagesRequest, v1.BulkDeleteInstalledPackagesResponse]
+	cloneInstalledPackage                 *connect_go.Client[v1.CloneInstalledPackageRequest, v1.CloneInstalledPackageResponse]
+	getInstalledPackageOperationStatus    *connect_go.Client[v1.GetInstalledPackageOperationStatusRequest, v1.GetInstalledPackageOperationStatusResponse]
+}
+
+// GetAvailablePackageSummaries calls
+// kubeappsapis.core.packages.v1.PackagesService.GetAvailablePackageSummaries.
+func (c *packagesServiceClient) GetAvailablePackageSummaries(ctx context.Context, req *connect_go.Request[v1.GetAvailablePackageSummariesRequest]) (*connect_go.Response[v1.GetAvailablePackageSummariesResponse], error) {
+	return c.getAvailablePackageSummaries.CallUnary(ctx, req)
+}
+
+// GetAvailablePackageDetail calls
+// kubeappsapis.core.packages.v1.PackagesService.GetAvailablePackageDetail.
+func (c *packagesServiceClient) GetAvailablePackageDetail(ctx context.Context, req *connect_go.Request[v1.GetAvailablePackageDetailRequest]) (*connect_go.Response[v1.GetAvailablePackageDetailResponse], error) {
+	return c.getAvailablePackageDetail.CallUnary(ctx, req)
+}
+
+// GetAvailablePackageVersions calls
+// kubeappsapis.core.packages.v1.PackagesService.GetAvailablePackageVersions.
+func (c *packagesServiceClient) GetAvailablePackageVersions(ctx context.Context, req *connect_go.Request[v1.GetAvailablePackageVersionsRequest]) (*connect_go.Response[v1.GetAvailablePackageVersionsResponse], error) {
+	return c.getAvailablePackageVersions.CallUnary(ctx, req)
+}
+
+// GetAvailablePackageTextAsset calls
+// kubeappsapis.core.packages.v1.PackagesService.GetAvailablePackageTextAsset.
+func (c *packagesServiceClient) GetAvailablePackageTextAsset(ctx context.Context, req *connect_go.Request[v1.GetAvailablePackageTextAssetRequest]) (*connect_go.Response[v1.GetAvailablePackageTextAssetResponse], error) {
+	return c.getAvailablePackageTextAsset.CallUnary(ctx, req)
+}
+
+// GetAvailablePackageChangelog calls
+// kubeappsapis.core.packages.v1.PackagesService.GetAvailablePackageChangelog.
+func (c *packagesServiceClient) GetAvailablePackageChangelog(ctx context.Context, req *connect_go.Request[v1.GetAvailablePackageChangelogRequest]) (*connect_go.Response[v1.GetAvailablePackageChangelogResponse], error) {
+	return c.getAvailablePackageChangelog.CallUnary(ctx, req)
+}
+
+// GetAvailablePackageFilters calls
+// kubeappsapis.core.packages.v1.PackagesService.GetAvailablePackageFilters.
+func (c *packagesServiceClient) GetAvailablePackageFilters(ctx context.Context, req *connect_go.Request[v1.GetAvailablePackageFiltersRequest]) (*connect_go.Response[v1.GetAvailablePackageFiltersResponse], error) {
+	return c.getAvailablePackageFilters.CallUnary(ctx, req)
+}
+
+// GetInstalledPackageSummaries calls
+// kubeappsapis.core.packages.v1.PackagesService.GetInstalledPackageSummaries.
+func (c *packagesServiceClient) GetInstalledPackageSummaries(ctx context.Context, req *connect_go.Request[v1.GetInstalledPackageSummariesRequest]) (*connect_go.Response[v1.GetInstalledPackageSummariesResponse], error) {
+	return c.getInstalledPackageSummaries.CallUnary(ctx, req)
+}
+
+// GetInstalledPackageDetail calls
+// kubeappsapis.core.packages.v1.PackagesService.GetInstalledPackageDetail.
+func (c *packagesServiceClient) GetInstalledPackageDetail(ctx context.Context, req *connect_go.Request[v1.GetInstalledPackageDetailRequest]) (*connect_go.Response[v1.GetInstalledPackageDetailResponse], error) {
+	return c.getInstalledPackageDetail.CallUnary(ctx, req)
+}
+
+// CheckInstalledPackageNameAvailability calls
+// kubeappsapis.core.packages.v1.PackagesService.CheckInstalledPackageNameAvailability.
+func (c *packagesServiceClient) CheckInstalledPackageNameAvailability(ctx context.Context, req *connect_go.Request[v1.CheckInstalledPackageNameAvailabilityRequest]) (*connect_go.Response[v1.CheckInstalledPackageNameAvailabilityResponse], error) {
+	return c.checkInstalledPackageNameAvailability.CallUnary(ctx, req)
+}
+
+// CreateInstalledPackage calls
+// kubeappsapis.core.packages.v1.PackagesService.CreateInstalledPackage.
+func (c *packagesServiceClient) CreateInstalledPackage(ctx context.Context, req *connect_go.Request[v1.CreateInstalledPackageRequest]) (*connect_go.Response[v1.CreateInstalledPackageResponse], error) {
+	return c.createInstalledPackage.CallUnary(ctx, req)
+}
+
+// UpdateInstalledPackage calls
+// kubeappsapis.core.packages.v1.PackagesService.UpdateInstalledPackage.
+func (c *packagesServiceClient) UpdateInstalledPackage(ctx context.Context, req *connect_go.Request[v1.UpdateInstalledPackageRequest]) (*connect_go.Response[v1.UpdateInstalledPackageResponse], error) {
+	return c.updateInstalledPackage.CallUnary(ctx, req)
+}
+
+// DeleteInstalledPackage calls
+// kubeappsapis.core.packages.v1.PackagesService.DeleteInstalledPackage.
+func (c *packagesServiceClient) DeleteInstalledPackage(ctx context.Context, req *connect_go.Request[v1.DeleteInstalledPackageRequest]) (*connect_go.Response[v1.DeleteInstalledPackageResponse], error) {
+	return c.deleteInstalledPackage.CallUnary(ctx, req)
+}
+
+// GetInstalledPackageResourceRefs calls
+// kubeappsapis.core.packages.v1.PackagesService.GetInstalledPackageResourceRefs.
+func (c *packagesServiceClient) GetInstalledPackageResourceRefs(ctx context.Context, req *connect_go.Request[v1.GetInstalledPackageResourceRefsRequest]) (*connect_go.Response[v1.GetInstalledPackageResourceRefsResponse], error) {
+	return c.getInstalledPackageResourceRefs.CallUnary(ctx, req)
+}
+
+// BulkUpdateInstalledPackages calls
+// kubeappsapis.core.packages.v1.PackagesService.BulkUpdateInstalledPackages.
+func (c *packagesServiceClient) BulkUpdateInstalledPackages(ctx context.Context, req *connect_go.Request[v1.BulkUpdateInstalledPackagesRequest]) (*connect_go.Response[v1.BulkUpdateInstalledPackagesResponse], error) {
+	return c.bulkUpdateInstalledPackages.CallUnary(ctx, req)
+}
+
+// BulkDeleteInstalledPackages calls
+// kubeappsapis.core.packages.v1.PackagesService.BulkDeleteInstalledPackages.
+func (c *packagesServiceClient) BulkDeleteInstalledPackages(ctx context.Context, req *connect_go.Request[v1.BulkDeleteInstalledPackagesRequest]) (*connect_go.Response[v1.BulkDeleteInstalledPackagesResponse], error) {
+	return c.bulkDeleteInstalledPackages.CallUnary(ctx, req)
+}
+
+// CloneInstalledPackage calls kubeappsapis.core.packages.v1.PackagesService.CloneInstalledPackage.
+func (c *packagesServiceClient) CloneInstalledPackage(ctx context.Context, req *connect_go.Request[v1.CloneInstalledPackageRequest]) (*connect_go.Response[v1.CloneInstalledPackageResponse], error) {
+	return c.cloneInstalledPackage.CallUnary(ctx, req)
+}
+
+// GetInstalledPackageOperationStatus calls
+// kubeappsapis.core.packages.v1.PackagesService.GetInstalledPackageOperationStatus.
+func (c *packagesServiceClient) GetInstalledPackageOperationStatus(ctx context.Context, req *connect_go.Request[v1.GetInstalledPackageOperationStatusRequest]) (*connect_go.Response[v1.GetInstalledPackageOperationStatusResponse], error) {
+	return c.getInstalledPackageOperationStatus.CallUnary(ctx, req)
+}
+
+// PackagesServiceHandler is an implementation of the kubeappsapis.core.packages.v1.PackagesService
+// service.
+type PackagesServiceHandler interface {
+	GetAvailablePackageSummaries(context.Context, *connect_go.Request[v1.GetAvailablePackageSummariesRequest]) (*connect_go.Response[v1.GetAvailablePackageSummariesResponse], error)
+	GetAvailablePackageDetail(context.Context, *connect_go.Request[v1.GetAvailablePackageDetailRequest]) (*connect_go.Response[v1.GetAvailablePackageDetailResponse], error)
+	GetAvailablePackageVersions(context.Context, *connect_go.Request[v1.GetAvailablePackageVersionsRequest]) (*connect_go.Response[v1.GetAvailablePackageVersionsResponse], error)
+	GetAvailablePackageTextAsset(context.Context, *connect_go.Request[v1.GetAvailablePackageTextAssetRequest]) (*connect_go.Response[v1.GetAvailablePackageTextAssetResponse], error)
+	GetAvailablePackageChangelog(context.Context, *connect_go.Request[v1.GetAvailablePackageChangelogRequest]) (*connect_go.Response[v1.GetAvailablePackageChangelogResponse], error)
+	// GetAvailablePackageFilters returns the distinct values, across every
+	// configured plugin, that can be used in the FilterOptions of the available
+	// packages, such as their categories and providers.
+	GetAvailablePackageFilters(context.Context, *connect_go.Request[v1.GetAvailablePackageFiltersRequest]) (*connect_go.Response[v1.GetAvailablePackageFiltersResponse], error)
+	GetInstalledPackageSummaries(context.Context, *connect_go.Request[v1.GetInstalledPackageSummariesRequest]) (*connect_go.Response[v1.GetInstalledPackageSummariesResponse], error)
+	GetInstalledPackageDetail(context.Context, *connect_go.Request[v1.GetInstalledPackageDetailRequest]) (*connect_go.Response[v1.GetInstalledPackageDetailResponse], error)
+	// CheckInstalledPackageNameAvailability checks, with every configured plugin,
+	// whether a package can be installed with the given name in the target namespace.
+	CheckInstalledPackageNameAvailability(context.Context, *connect_go.Request[v1.CheckInstalledPackageNameAvailabilityRequest]) (*connect_go.Response[v1.CheckInstalledPackageNameAvailabilityResponse], error)
+	CreateInstalledPackage(context.Context, *connect_go.Request[v1.CreateInstalledPackageRequest]) (*connect_go.Response[v1.CreateInstalledPackageResponse], error)
+	UpdateInstalledPackage(context.Context, *connect_go.Request[v1.UpdateInstalledPackageRequest]) (*connect_go.Response[v1.UpdateInstalledPackageResponse], error)
+	DeleteInstalledPackage(context.Context, *connect_go.Request[v1.DeleteInstalledPackageRequest]) (*connect_go.Response[v1.DeleteInstalledPackageResponse], error)
+	GetInstalledPackageResourceRefs(context.Context, *connect_go.Request[v1.GetInstalledPackageResourceRefsRequest]) (*connect_go.Response[v1.GetInstalledPackageResourceRefsResponse], error)
+	// BulkUpdateInstalledPackages updates several installed packages, such as
+	// the same package installed in many namespaces, to the given version,
+	// keeping the values and reconciliation options of each of them. The
+	// updates are performed concurrently and a failed update does not prevent
+	// the others, its error being reported in the result of that package.
+	BulkUpdateInstalledPackages(context.Context, *connect_go.Request[v1.BulkUpdateInstalledPackagesRequest]) (*connect_go.Response[v1.BulkUpdateInstalledPackagesResponse], error)
+	// BulkDeleteInstalledPackages deletes several installed packages
+	// concurrently, reporting the error of each failed deletion in the result
+	// of that package.
+	BulkDeleteInstalledPackages(context.Context, *connect_go.Request[v1.BulkDeleteInstalledPackagesRequest]) (*connect_go.Response[v1.BulkDeleteInstalledPackagesResponse], error)
+	// CloneInstalledPackage re-creates an installed package, with the same
+	// package, version reference, values and custom detail, in another namespace
+	// or cluster, such as to promote the configuration of an application from a
+	// development namespace to a staging one. The plugin re-creates the objects
+	// it manages for the installed package, such as the Secrets holding its
+	// values, in the target namespace.
+	CloneInstalledPackage(context.Context, *connect_go.Request[v1.CloneInstalledPackageRequest]) (*connect_go.Response[v1.CloneInstalledPackageResponse], error)
+	// GetInstalledPackageOperationStatus returns the progress of the operation
+	// in course on an installed package, such as its installation, so that
+	// clients can follow it once CreateInstalledPackage returns.
+	GetInstalledPackageOperationStatus(context.Context, *connect_go.Request[v1.GetInstalledPackageOperationStatusRequest]) (*connect_go.Response[v1.GetInstalledPackageOperationStatusResponse], error)
+}
+
+// NewPackagesServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewPackagesServiceHandler(svc PackagesServiceHandler, opts ...connect_go.HandlerOption) (string, http.Handler) {
+	packagesServiceGetAvailablePackageSummariesHandler := connect_go.NewUnaryHandler(
+		PackagesServiceGetAvailablePackageSummariesProcedure,
+		svc.GetAvailablePackageSummaries,
+		opts...,
+	)
+	packagesServiceGetAvailablePackageDetailHandler := connect_go.NewUnaryHandler(
+		PackagesServiceGetAvailablePackageDetailProcedure,
+		svc.GetAvailablePackageDetail,
+		opts...,
+	)
+	packagesServiceGetAvailablePackageVersionsHandler := connect_go.NewUnaryHandler(
+		PackagesServiceGetAvailablePackageVersionsProcedure,
+		svc.GetAvailablePackageVersions,
+		opts...,
+	)
+	packagesServiceGetAvailablePackageTextAssetHandler := connect_go.NewUnaryHandler(
+		PackagesServiceGetAvailablePackageTextAssetProcedure,
+		svc.GetAvailablePackageTextAsset,
+		opts...,
+	)
+	packagesServiceGetAvailablePackageChangelogHandler := connect_go.NewUnaryHandler(
+		PackagesServiceGetAvailablePackageChangelogProcedure,
+		svc.GetAvailablePackageChangelog,
+		opts...,
+	)
+	packagesServiceGetAvailablePackageFiltersHandler := connect_go.NewUnaryHandler(
+		PackagesServiceGetAvailablePackageFiltersProcedure,
+		svc.GetAvailablePackageFilters,
+		opts...,
+	)
+	packagesServiceGetInstalledPackageSummariesHandler := connect_go.NewUnaryHandler(
+		PackagesServiceGetInstalledPackageSummariesProcedure,
+		svc.GetInstalledPackageSummaries,
+		opts...,
+	)
+	packagesServiceGetInstalledPackageDetailHandler := connect_go.NewUnaryHandler(
+		PackagesServiceGetInstalledPackageDetailProcedure,
+		svc.GetInstalledPackageDetail,
+		opts...,
+	)
+	packagesServiceCheckInstalledPackageNameAvailabilityHandler := connect_go.NewUnaryHandler(
+		PackagesServiceCheckInstalledPackageNameAvailabilityProcedure,
+		svc.CheckInstalledPackageNameAvailability,
+		opts...,
+	)
+	packagesServiceCreateInstalledPackageHandler := connect_go.NewUnaryHandler(
+		PackagesServiceCreateInstalledPackageProcedure,
+		svc.CreateInstalledPackage,
+		opts...,
+	)
+	packagesServiceUpdateInstalledPackageHandler := connect_go.NewUnaryHandler(
+		PackagesServiceUpdateInstalledPackageProcedure,
+		svc.UpdateInstalledPackage,
+		opts...,
+	)
+	packagesServiceDeleteInstalledPackageHandler := connect_go.NewUnaryHandler(
+		PackagesServiceDeleteInstalledPackageProcedure,
+		svc.DeleteInstalledPackage,
+		opts...,
+	)
+	packagesServiceGetInstalledPackageResourceRefsHandler := connect_go.NewUnaryHandler(
+		PackagesServiceGetInstalledPackageResourceRefsProcedure,
+		svc.GetInstalledPackageResourceRefs,
+		opts...,
+	)
+	packagesServiceBulkUpdateInstalledPackagesHandler := connect_go.NewUnaryHandler(
+		PackagesServiceBulkUpdateInstalledPackagesProcedure,
+		svc.BulkUpdateInstalledPackages,
+		opts...,
+	)
+	packagesServiceBulkDeleteInstalledPackagesHandler := connect_go.NewUnaryHandler(
+		PackagesServiceBulkDeleteInstalledPackagesProcedure,
+		svc.BulkDeleteInstalledPackages,
+		opts...,
+	)
+	packagesServiceCloneInstalledPackageHandler := connect_go.NewUnaryHandler(
+		PackagesServiceCloneInstalledPackageProcedure,
+		svc.CloneInstalledPackage,
+		opts...,
+	)
+	packagesServiceGetInstalledPackageOperationStatusHandler := connect_go.NewUnaryHandler(
+		PackagesServiceGetInstalledPackageOperationStatusProcedure,
+		svc.GetInstalledPackageOperationStatus,
+		opts...,
+	)
+	return "/kubeappsapis.core.packages.v1.PackagesService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case PackagesServiceGetAvailablePackageSummariesProcedure:
+			packagesServiceGetAvailablePackageSummariesHandler.ServeHTTP(w, r)
+		case PackagesServiceGetAvailablePackageDetailProcedure:
+			packagesServiceGetAvailablePackageDetailHandler.ServeHTTP(w, r)
+		case PackagesServiceGetAvailablePackageVersionsProcedure:
+			packagesServiceGetAvailablePackageVersionsHandler.ServeHTTP(w, r)
+		case PackagesServiceGetAvailablePackageTextAssetProcedure:
+			packagesServiceGetAvailablePackageTextAssetHandler.ServeHTTP(w, r)
+		case PackagesServiceGetAvailablePackageChangelogProcedure:
+			packagesServiceGetAvailablePackageChangelogHandler.ServeHTTP(w, r)
+		case PackagesServiceGetAvailablePackageFiltersProcedure:
+			packagesServiceGetAvailablePackageFiltersHandler.ServeHTTP(w, r)
+		case PackagesServiceGetInstalledPackageSummariesProcedure:
+			packagesServiceGetInstalledPackageSummariesHandler.ServeHTTP(w, r)
+		case PackagesServiceGetInstalledPackageDetailProcedure:
+			packagesServiceGetInstalledPackageDetailHandler.ServeHTTP(w, r)
+		case PackagesServiceCheckInstalledPackageNameAvailabilityProcedure:
+			packagesServiceCheckInstalledPackageNameAvailabilityHandler.ServeHTTP(w, r)
+		case PackagesServiceCreateInstalledPackageProcedure:
+			packagesServiceCreateInstalledPackageHandler.ServeHTTP(w, r)
+		case PackagesServiceUpdateInstalledPackageProcedure:
+			packagesServiceUpdateInstalledPackageHandler.ServeHTTP(w, r)
+		case PackagesServiceDeleteInstalledPackageProcedure:
+			packagesServiceDeleteInstalledPackageHandler.ServeHTTP(w, r)
+		case PackagesServiceGetInstalledPackageResourceRefsProcedure:
+			packagesServiceGetInstalledPackageResourceRefsHandler.ServeHTTP(w, r)
+		case PackagesServiceBulkUpdateInstalledPackagesProcedure:
+			packagesServiceBulkUpdateInstalledPackagesHandler.ServeHTTP(w, r)
+		case PackagesServiceBulkDeleteInstalledPackagesProcedure:
+			packagesServiceBulkDeleteInstalledPackagesHandler.ServeHTTP(w, r)
+		case PackagesServiceCloneInstalledPackageProcedure:
+			packagesServiceCloneInstalledPackageHandler.ServeHTTP(w, r)
+		case PackagesServiceGetInstalledPackageOperationStatusProcedure:
+			packagesServiceGetInstalledPackageOperationStatusHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedPackagesServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedPackagesServiceHandler struct{}
+
+func (UnimplementedPackagesServiceHandler) GetAvailablePackageSummaries(context.Context, *connect_go.Request[v1.GetAvailablePackageSummariesRequest]) (*connect_go.Response[v1.GetAvailablePackageSummariesResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.core.packages.v1.PackagesService.GetAvailablePackageSummaries is not implemented"))
+}
+
+func (UnimplementedPackagesServiceHandler) GetAvailablePackageDetail(context.Context, *connect_go.Request[v1.GetAvailablePackageDetailRequest]) (*connect_go.Response[v1.GetAvailablePackageDetailResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.core.packages.v1.PackagesService.GetAvailablePackageDetail is not implemented"))
+}
+
+func (UnimplementedPackagesServiceHandler) GetAvailablePackageVersions(context.Context, *connect_go.Request[v1.GetAvailablePackageVersionsRequest]) (*connect_go.Response[v1.GetAvailablePackageVersionsResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.core.packages.v1.PackagesService.GetAvailablePackageVersions is not implemented"))
+}
+
+func (UnimplementedPackagesServiceHandler) GetAvailablePackageTextAsset(context.Context, *connect_go.Request[v1.GetAvailablePackageTextAssetRequest]) (*connect_go.Response[v1.GetAvailablePackageTextAssetResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.core.packages.v1.PackagesService.GetAvailablePackageTextAsset is not implemented"))
+}
+
+func (UnimplementedPackagesServiceHandler) GetAvailablePackageChangelog(context.Context, *connect_go.Request[v1.GetAvailablePackageChangelogRequest]) (*connect_go.Response[v1.GetAvailablePackageChangelogResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.core.packages.v1.PackagesService.GetAvailablePackageChangelog is not implemented"))
+}
+
+func (UnimplementedPackagesServiceHandler) GetAvailablePackageFilters(context.Context, *connect_go.Request[v1.GetAvailablePackageFiltersRequest]) (*connect_go.Response[v1.GetAvailablePackageFiltersResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.core.packages.v1.PackagesService.GetAvailablePackageFilters is not implemented"))
+}
+
+func (UnimplementedPackagesServiceHandler) GetInstalledPackageSummaries(context.Context, *connect_go.Request[v1.GetInstalledPackageSummariesRequest]) (*connect_go.Response[v1.GetInstalledPackageSummariesResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.core.packages.v1.PackagesService.GetInstalledPackageSummaries is not implemented"))
+}
+
+func (UnimplementedPackagesServiceHandler) GetInstalledPackageDetail(context.Context, *connect_go.Request[v1.GetInstalledPackageDetailRequest]) (*connect_go.Response[v1.GetInstalledPackageDetailResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.core.packages.v1.PackagesService.GetInstalledPackageDetail is not implemented"))
+}
+
+func (UnimplementedPackagesServiceHandler) CheckInstalledPackageNameAvailability(context.Context, *connect_go.Request[v1.CheckInstalledPackageNameAvailabilityRequest]) (*connect_go.Response[v1.CheckInstalledPackageNameAvailabilityResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.core.packages.v1.PackagesService.CheckInstalledPackageNameAvailability is not implemented"))
+}
+
+func (UnimplementedPackagesServiceHandler) CreateInstalledPackage(context.Context, *connect_go.Request[v1.CreateInstalledPackageRequest]) (*connect_go.Response[v1.CreateInstalledPackageResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.core.packages.v1.PackagesService.CreateInstalledPackage is not implemented"))
+}
+
+func (UnimplementedPackagesServiceHandler) UpdateInstalledPackage(context.Context, *connect_go.Request[v1.UpdateInstalledPackageRequest]) (*connect_go.Response[v1.UpdateInstalledPackageResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.core.packages.v1.PackagesService.UpdateInstalledPackage is not implemented"))
+}
+
+func (UnimplementedPackagesServiceHandler) DeleteInstalledPackage(context.Context, *connect_go.Request[v1.DeleteInstalledPackageRequest]) (*connect_go.Response[v1.DeleteInstalledPackageResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.core.packages.v1.PackagesService.DeleteInstalledPackage is not implemented"))
+}
+
+func (UnimplementedPackagesServiceHandler) GetInstalledPackageResourceRefs(context.Context, *connect_go.Request[v1.GetInstalledPackageResourceRefsRequest]) (*connect_go.Response[v1.GetInstalledPackageResourceRefsResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.core.packages.v1.PackagesService.GetInstalledPackageResourceRefs is not implemented"))
+}
+
+func (UnimplementedPackagesServiceHandler) BulkUpdateInstalledPackages(context.Context, *connect_go.Request[v1.BulkUpdateInstalledPackagesRequest]) (*connect_go.Response[v1.BulkUpdateInstalledPackagesResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.core.packages.v1.PackagesService.BulkUpdateInstalledPackages is not implemented"))
+}
+
+func (UnimplementedPackagesServiceHandler) BulkDeleteInstalledPackages(context.Context, *connect_go.Request[v1.BulkDeleteInstalledPackagesRequest]) (*connect_go.Response[v1.BulkDeleteInstalledPackagesResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.core.packages.v1.PackagesService.BulkDeleteInstalledPackages is not implemented"))
+}
+
+func (UnimplementedPackagesServiceHandler) CloneInstalledPackage(context.Context, *connect_go.Request[v1.CloneInstalledPackageRequest]) (*connect_go.Response[v1.CloneInstalledPackageResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.core.packages.v1.PackagesService.CloneInstalledPackage is not implemented"))
+}
+
+func (UnimplementedPackagesServiceHandler) GetInstalledPackageOperationStatus(context.Context, *connect_go.Request[v1.GetInstalledPackageOperationStatusRequest]) (*connect_go.Response[v1.GetInstalledPackageOperationStatusResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.core.packages.v1.PackagesService.GetInstalledPackageOperationStatus is not implemented"))
+}
@@ -22,15 +22,20 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	PackagesService_GetAvailablePackageSummaries_FullMethodName    = "/kubeappsapis.core.packages.v1alpha1.PackagesService/GetAvailablePackageSummaries"
-	PackagesService_GetAvailablePackageDetail_FullMethodName       = "/kubeappsapis.core.packages.v1alpha1.PackagesService/GetAvailablePackageDetail"
-	PackagesService_GetAvailablePackageVersions_FullMethodName     = "/kubeappsapis.core.packages.v1alpha1.PackagesService/GetAvailablePackageVersions"
-	PackagesService_GetInstalledPackageSummaries_FullMethodName    = "/kubeappsapis.core.packages.v1alpha1.PackagesService/GetInstalledPackageSummaries"
-	PackagesService_GetInstalledPackageDetail_FullMethodName       = "/kubeappsapis.core.packages.v1alpha1.PackagesService/GetInstalledPackageDetail"
-	PackagesService_CreateInstalledPackage_FullMethodName          = "/kubeappsapis.core.packages.v1alpha1.PackagesService/CreateInstalledPackage"
-	PackagesService_UpdateInstalledPackage_FullMethodName          = "/kubeappsapis.core.packages.v1alpha1.PackagesService/UpdateInstalledPackage"
-	PackagesService_DeleteInstalledPackage_FullMethodName          = "/kubeappsapis.core.packages.v1alpha1.PackagesService/DeleteInstalledPackage"
-	PackagesService_GetInstalledPackageResourceRefs_FullMethodName = "/kubeappsapis.core.packages.v1alpha1.PackagesService/GetInstalledPackageResourceRefs"
+	PackagesService_GetAvailablePackageSummaries_FullMethodName          = "/kubeappsapis.core.packages.v1alpha1.PackagesService/GetAvailablePackageSummaries"
+	PackagesService_GetAvailablePackageDetail_FullMethodName             = "/kubeappsapis.core.packages.v1alpha1.PackagesService/GetAvailablePackageDetail"
+	PackagesService_GetAvailablePackageVersions_FullMethodName           = "/kubeappsapis.core.packages.v1alpha1.PackagesService/GetAvailablePackageVersions"
+	PackagesService_GetAvailablePackageTextAsset_FullMethodName          = "/kubeappsapis.core.packages.v1alpha1.PackagesService/GetAvailablePackageTextAsset"
+	PackagesService_GetAvailablePackageChangelog_FullMethodName          = "/kubeappsapis.core.packages.v1alpha1.PackagesService/GetAvailablePackageChangelog"
+	PackagesService_GetAvailablePackageFilters_FullMethodName            = "/kubeappsapis.core.packages.v1alpha1.PackagesService/GetAvailablePackageFilters"
+	PackagesService_GetInstalledPackageSummaries_FullMethodName          = "/kubeappsapis.core.packages.v1alpha1.PackagesService/GetInstalledPackageSummaries"
+	PackagesService_GetInstalledPackageDetail_FullMethodName             = "/kubeappsapis.core.packages.v1alpha1.PackagesService/GetInstalledPackageDetail"
+	PackagesService_CheckInstalledPackageNameAvailability_FullMethodName = "/kubeappsapis.core.packages.v1alpha1.PackagesService/CheckInstalledPackageNameAvailability"
+	PackagesService_CreateInstalledPackage_FullMethodName                = "/kubeappsapis.core.packages.v1alpha1.PackagesService/CreateInstalledPackage"
+	PackagesService_UpdateInstalledPackage_FullMethodName                = "/kubeappsapis.core.packages.v1alpha1.PackagesService/UpdateInstalledPackage"
+	PackagesService_DeleteInstalledPackage_FullMethodName                = "/kubeappsapis.core.packages.v1alpha1.PackagesService/DeleteInstalledPackage"
+	PackagesService_GetInstalledPackageResourceRefs_FullMethodName       = "/kubeappsapis.core.packages.v1alpha1.PackagesService/GetInstalledPackageResourceRefs"
+	PackagesService_GetInstalledPackageOperationStatus_FullMethodName    = "/kubeappsapis.core.packages.v1alpha1.PackagesService/GetInstalledPackageOperationStatus"
 )
 
 // PackagesServiceClient is the client API for PackagesService service.
@@ -40,12 +45,25 @@ type PackagesServiceClient interface {
 	GetAvailablePackageSummaries(ctx context.Context, in *GetAvailablePackageSummariesRequest, opts ...grpc.CallOption) (*GetAvailablePackageSummariesResponse, error)
 	GetAvailablePackageDetail(ctx context.Context, in *GetAvailablePackageDetailRequest, opts ...grpc.CallOption) (*GetAvailablePackageDetailResponse, error)
 	GetAvailablePackageVersions(ctx context.Context, in *GetAvailablePackageVersionsRequest, opts ...grpc.CallOption) (*GetAvailablePackageVersionsResponse, error)
+	GetAvailablePackageTextAsset(ctx context.Context, in *GetAvailablePackageTextAssetRequest, opts ...grpc.CallOption) (*GetAvailablePackageTextAssetResponse, error)
+	GetAvailablePackageChangelog(ctx context.Context, in *GetAvailablePackageChangelogRequest, opts ...grpc.CallOption) (*GetAvailablePackageChangelogResponse, error)
+	// GetAvailablePackageFilters returns the distinct values, across every
+	// configured plugin, that can be used in the FilterOptions of the available
+	// packages, such as their categories and providers.
+	GetAvailablePackageFilters(ctx context.Context, in *GetAvailablePackageFiltersRequest, opts ...grpc.CallOption) (*GetAvailablePackageFiltersResponse, error)
 	GetInstalledPackageSummaries(ctx context.Context, in *GetInstalledPackageSummariesRequest, opts ...grpc.CallOption) (*GetInstalledPackageSummariesResponse, error)
 	GetInstalledPackageDetail(ctx context.Context, in *GetInstalledPackageDetailRequest, opts ...grpc.CallOption) (*GetInstalledPackageDetailResponse, error)
+	// CheckInstalledPackageNameAvailability checks, with every configured plugin,
+	// whether a package can be installed with the given name in the target namespace.
+	CheckInstalledPackageNameAvailability(ctx context.Context, in *CheckInstalledPackageNameAvailabilityRequest, opts ...grpc.CallOption) (*CheckInstalledPackageNameAvailabilityResponse, error)
 	CreateInstalledPackage(ctx context.Context, in *CreateInstalledPackageRequest, opts ...grpc.CallOption) (*CreateInstalledPackageResponse, error)
 	UpdateInstalledPackage(ctx context.Context, in *UpdateInstalledPackageRequest, opts ...grpc.CallOption) (*UpdateInstalledPackageResponse, error)
 	DeleteInstalledPackage(ctx context.Context, in *DeleteInstalledPackageRequest, opts ...grpc.CallOption) (*DeleteInstalledPackageResponse, error)
 	GetInstalledPackageResourceRefs(ctx context.Context, in *GetInstalledPackageResourceRefsRequest, opts ...grpc.CallOption) (*GetInstalledPackageResourceRefsResponse, error)
+	// GetInstalledPackageOperationStatus returns the progress of the operation
+	// in course on an installed package, such as its installation, so that
+	// clients can follow it once CreateInstalledPackage returns.
+	GetInstalledPackageOperationStatus(ctx context.Context, in *GetInstalledPackageOperationStatusRequest, opts ...grpc.CallOption) (*GetInstalledPackageOperationStatusResponse, error)
 }
 
 type packagesServiceClient struct {
@@ -83,6 +101,33 @@ func (c *packagesServiceClient) GetAvailablePackageVersions(ctx context.Context,
 	return out, nil
 }
 
+func (c *packagesServiceClient) GetAvailablePackageTextAsset(ctx context.Context, in *GetAvailablePackageTextAssetRequest, opts ...grpc.CallOption) (*GetAvailablePackageTextAssetResponse, error) {
+	out := new(GetAvailablePackageTextAssetResponse)
+	err := c.cc.Invoke(ctx, PackagesService_GetAvailablePackageTextAsset_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *packagesServiceClient) GetAvailablePackageChangelog(ctx context.Context, in *GetAvailablePackageChangelogRequest, opts ...grpc.CallOption) (*GetAvailablePackageChangelogResponse, error) {
+	out := new(GetAvailablePackageChangelogResponse)
+	err := c.cc.Invoke(ctx, PackagesService_GetAvailablePackageChangelog_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *packagesServiceClient) GetAvailablePackageFilters(ctx context.Context, in *GetAvailablePackageFiltersRequest, opts ...grpc.CallOption) (*GetAvailablePackageFiltersResponse, error) {
+	out := new(GetAvailablePackageFiltersResponse)
+	err := c.cc.Invoke(ctx, PackagesService_GetAvailablePackageFilters_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *packagesServiceClient) GetInstalledPackageSummaries(ctx context.Context, in *GetInstalledPackageSummariesRequest, opts ...grpc.CallOption) (*GetInstalledPackageSummariesResponse, error) {
 	out := new(GetInstalledPackageSummariesResponse)
 	err := c.cc.Invoke(ctx, PackagesService_GetInstalledPackageSummaries_FullMethodName, in, out, opts...)
@@ -101,6 +146,15 @@ func (c *packagesServiceClient) GetInstalledPackageDetail(ctx context.Context, i
 	return out, nil
 }
 
+func (c *packagesServiceClient) CheckInstalledPackageNameAvailability(ctx context.Context, in *CheckInstalledPackageNameAvailabilityRequest, opts ...grpc.CallOption) (*CheckInstalledPackageNameAvailabilityResponse, error) {
+	out := new(CheckInstalledPackageNameAvailabilityResponse)
+	err := c.cc.Invoke(ctx, PackagesService_CheckInstalledPackageNameAvailability_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *packagesServiceClient) CreateInstalledPackage(ctx context.Context, in *CreateInstalledPackageRequest, opts ...grpc.CallOption) (*CreateInstalledPackageResponse, error) {
 	out := new(CreateInstalledPackageResponse)
 	err := c.cc.Invoke(ctx, PackagesService_CreateInstalledPackage_FullMethodName, in, out, opts...)
@@ -137,6 +191,15 @@ func (c *packagesServiceClient) GetInstalledPackageResourceRefs(ctx context.Cont
 	return out, nil
 }
 
+func (c *packagesServiceClient) GetInstalledPackageOperationStatus(ctx context.Context, in *GetInstalledPackageOperationStatusRequest, opts ...grpc.CallOption) (*GetInstalledPackageOperationStatusResponse, error) {
+	out := new(GetInstalledPackageOperationStatusResponse)
+	err := c.cc.Invoke(ctx, PackagesService_GetInstalledPackageOperationStatus_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PackagesServiceServer is the server API for PackagesService service.
 // All implementations should embed UnimplementedPackagesServiceServer
 // for forward compatibility
@@ -144,12 +207,25 @@ type PackagesServiceServer interface {
 	GetAvailablePackageSummaries(context.Context, *GetAvailablePackageSummariesRequest) (*GetAvailablePackageSummariesResponse, error)
 	GetAvailablePackageDetail(context.Context, *GetAvailablePackageDetailRequest) (*GetAvailablePackageDetailResponse, error)
 	GetAvailablePackageVersions(context.Context, *GetAvailablePackageVersionsRequest) (*GetAvailablePackageVersionsResponse, error)
+	GetAvailablePackageTextAsset(context.Context, *GetAvailablePackageTextAssetRequest) (*GetAvailablePackageTextAssetResponse, error)
+	GetAvailablePackageChangelog(context.Context, *GetAvailablePackageChangelogRequest) (*GetAvailablePackageChangelogResponse, error)
+	// GetAvailablePackageFilters returns the distinct values, across every
+	// configured plugin, that can be used in the FilterOptions of the available
+	// packages, such as their categories and providers.
+	GetAvailablePackageFilters(context.Context, *GetAvailablePackageFiltersRequest) (*GetAvailablePackageFiltersResponse, error)
 	GetInstalledPackageSummaries(context.Context, *GetInstalledPackageSummariesRequest) (*GetInstalledPackageSummariesResponse, error)
 	GetInstalledPackageDetail(context.Context, *GetInstalledPackageDetailRequest) (*GetInstalledPackageDetailResponse, error)
+	// CheckInstalledPackageNameAvailability checks, with every configured plugin,
+	// whether a package can be installed with the given name in the target namespace.
+	CheckInstalledPackageNameAvailability(context.Context, *CheckInstalledPackageNameAvailabilityRequest) (*CheckInstalledPackageNameAvailabilityResponse, error)
 	CreateInstalledPackage(context.Context, *CreateInstalledPackageRequest) (*CreateInstalledPackageResponse, error)
 	UpdateInstalledPackage(context.Context, *UpdateInstalledPackageRequest) (*UpdateInstalledPackageResponse, error)
 	DeleteInstalledPackage(context.Context, *DeleteInstalledPackageRequest) (*DeleteInstalledPackageResponse, error)
 	GetInstalledPackageResourceRefs(context.Context, *GetInstalledPackageResourceRefsRequest) (*GetInstalledPackageResourceRefsResponse, error)
+	// GetInstalledPackageOperationStatus returns the progress of the operation
+	// in course on an installed package, such as its installation, so that
+	// clients can follow it once CreateInstalledPackage returns.
+	GetInstalledPackageOperationStatus(context.Context, *GetInstalledPackageOperationStatusRequest) (*GetInstalledPackageOperationStatusResponse, error)
 }
 
 // UnimplementedPackagesServiceServer should be embedded to have forward compatible implementations.
@@ -165,12 +241,24 @@ func (UnimplementedPackagesServiceServer) GetAvailablePackageDetail(context.Cont
 func (UnimplementedPackagesServiceServer) GetAvailablePackageVersions(context.Context, *GetAvailablePackageVersionsRequest) (*GetAvailablePackageVersionsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAvailablePackageVersions not implemented")
 }
+func (UnimplementedPackagesServiceServer) GetAvailablePackageTextAsset(context.Context, *GetAvailablePackageTextAssetRequest) (*GetAvailablePackageTextAssetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAvailablePackageTextAsset not implemented")
+}
+func (UnimplementedPackagesServiceServer) GetAvailablePackageChangelog(context.Context, *GetAvailablePackageChangelogRequest) (*GetAvailablePackageChangelogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAvailablePackageChangelog not implemented")
+}
+func (UnimplementedPackagesServiceServer) GetAvailablePackageFilters(context.Context, *GetAvailablePackageFiltersRequest) (*GetAvailablePackageFiltersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAvailablePackageFilters not implemented")
+}
 func (UnimplementedPackagesServiceServer) GetInstalledPackageSummaries(context.Context, *GetInstalledPackageSummariesRequest) (*GetInstalledPackageSummariesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetInstalledPackageSummaries not implemented")
 }
 func (UnimplementedPackagesServiceServer) GetInstalledPackageDetail(context.Context, *GetInstalledPackageDetailRequest) (*GetInstalledPackageDetailResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetInstalledPackageDetail not implemented")
 }
+func (UnimplementedPackagesServiceServer) CheckInstalledPackageNameAvailability(context.Context, *CheckInstalledPackageNameAvailabilityRequest) (*CheckInstalledPackageNameAvailabilityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckInstalledPackageNameAvailability not implemented")
+}
 func (UnimplementedPackagesServiceServer) CreateInstalledPackage(context.Context, *CreateInstalledPackageRequest) (*CreateInstalledPackageResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateInstalledPackage not implemented")
 }
@@ -183,6 +271,9 @@ func (UnimplementedPackagesServiceServer) DeleteInstalledPackage(context.Context
 func (UnimplementedPackagesServiceServer) GetInstalledPackageResourceRefs(context.Context, *GetInstalledPackageResourceRefsRequest) (*GetInstalledPackageResourceRefsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetInstalledPackageResourceRefs not implemented")
 }
+func (UnimplementedPackagesServiceServer) GetInstalledPackageOperationStatus(context.Context, *GetInstalledPackageOperationStatusRequest) (*GetInstalledPackageOperationStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInstalledPackageOperationStatus not implemented")
+}
 
 // UnsafePackagesServiceServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to PackagesServiceServer will
@@ -249,6 +340,60 @@ func _PackagesService_GetAvailablePackageVersions_Handler(srv interface{}, ctx c
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PackagesService_GetAvailablePackageTextAsset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAvailablePackageTextAssetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PackagesServiceServer).GetAvailablePackageTextAsset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PackagesService_GetAvailablePackageTextAsset_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PackagesServiceServer).GetAvailablePackageTextAsset(ctx, req.(*GetAvailablePackageTextAssetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PackagesService_GetAvailablePackageChangelog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAvailablePackageChangelogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PackagesServiceServer).GetAvailablePackageChangelog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PackagesService_GetAvailablePackageChangelog_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PackagesServiceServer).GetAvailablePackageChangelog(ctx, req.(*GetAvailablePackageChangelogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PackagesService_GetAvailablePackageFilters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAvailablePackageFiltersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PackagesServiceServer).GetAvailablePackageFilters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PackagesService_GetAvailablePackageFilters_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PackagesServiceServer).GetAvailablePackageFilters(ctx, req.(*GetAvailablePackageFiltersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _PackagesService_GetInstalledPackageSummaries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetInstalledPackageSummariesRequest)
 	if err := dec(in); err != nil {
@@ -285,6 +430,24 @@ func _PackagesService_GetInstalledPackageDetail_Handler(srv interface{}, ctx con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PackagesService_CheckInstalledPackageNameAvailability_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckInstalledPackageNameAvailabilityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PackagesServiceServer).CheckInstalledPackageNameAvailability(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PackagesService_CheckInstalledPackageNameAvailability_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PackagesServiceServer).CheckInstalledPackageNameAvailability(ctx, req.(*CheckInstalledPackageNameAvailabilityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _PackagesService_CreateInstalledPackage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateInstalledPackageRequest)
 	if err := dec(in); err != nil {
@@ -357,6 +520,24 @@ func _PackagesService_GetInstalledPackageResourceRefs_Handler(srv interface{}, c
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PackagesService_GetInstalledPackageOperationStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInstalledPackageOperationStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PackagesServiceServer).GetInstalledPackageOperationStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PackagesService_GetInstalledPackageOperationStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PackagesServiceServer).GetInstalledPackageOperationStatus(ctx, req.(*GetInstalledPackageOperationStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // PackagesService_ServiceDesc is the grpc.ServiceDesc for PackagesService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -376,6 +557,18 @@ var PackagesService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetAvailablePackageVersions",
 			Handler:    _PackagesService_GetAvailablePackageVersions_Handler,
 		},
+		{
+			MethodName: "GetAvailablePackageTextAsset",
+			Handler:    _PackagesService_GetAvailablePackageTextAsset_Handler,
+		},
+		{
+			MethodName: "GetAvailablePackageChangelog",
+			Handler:    _PackagesService_GetAvailablePackageChangelog_Handler,
+		},
+		{
+			MethodName: "GetAvailablePackageFilters",
+			Handler:    _PackagesService_GetAvailablePackageFilters_Handler,
+		},
 		{
 			MethodName: "GetInstalledPackageSummaries",
 			Handler:    _PackagesService_GetInstalledPackageSummaries_Handler,
@@ -384,6 +577,10 @@ var PackagesService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetInstalledPackageDetail",
 			Handler:    _PackagesService_GetInstalledPackageDetail_Handler,
 		},
+		{
+			MethodName: "CheckInstalledPackageNameAvailability",
+			Handler:    _PackagesService_CheckInstalledPackageNameAvailability_Handler,
+		},
 		{
 			MethodName: "CreateInstalledPackage",
 			Handler:    _PackagesService_CreateInstalledPackage_Handler,
@@ -400,6 +597,10 @@ var PackagesService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetInstalledPackageResourceRefs",
 			Handler:    _PackagesService_GetInstalledPackageResourceRefs_Handler,
 		},
+		{
+			MethodName: "GetInstalledPackageOperationStatus",
+			Handler:    _PackagesService_GetInstalledPackageOperationStatus_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "kubeappsapis/core/packages/v1alpha1/packages.proto",
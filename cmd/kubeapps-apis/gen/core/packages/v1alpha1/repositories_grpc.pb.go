@@ -28,6 +28,8 @@ const (
 	RepositoriesService_UpdatePackageRepository_FullMethodName         = "/kubeappsapis.core.packages.v1alpha1.RepositoriesService/UpdatePackageRepository"
 	RepositoriesService_DeletePackageRepository_FullMethodName         = "/kubeappsapis.core.packages.v1alpha1.RepositoriesService/DeletePackageRepository"
 	RepositoriesService_GetPackageRepositoryPermissions_FullMethodName = "/kubeappsapis.core.packages.v1alpha1.RepositoriesService/GetPackageRepositoryPermissions"
+	RepositoriesService_TestPackageRepositoryConnection_FullMethodName = "/kubeappsapis.core.packages.v1alpha1.RepositoriesService/TestPackageRepositoryConnection"
+	RepositoriesService_ListPackageRepositoryPackages_FullMethodName   = "/kubeappsapis.core.packages.v1alpha1.RepositoriesService/ListPackageRepositoryPackages"
 )
 
 // RepositoriesServiceClient is the client API for RepositoriesService service.
@@ -40,6 +42,8 @@ type RepositoriesServiceClient interface {
 	UpdatePackageRepository(ctx context.Context, in *UpdatePackageRepositoryRequest, opts ...grpc.CallOption) (*UpdatePackageRepositoryResponse, error)
 	DeletePackageRepository(ctx context.Context, in *DeletePackageRepositoryRequest, opts ...grpc.CallOption) (*DeletePackageRepositoryResponse, error)
 	GetPackageRepositoryPermissions(ctx context.Context, in *GetPackageRepositoryPermissionsRequest, opts ...grpc.CallOption) (*GetPackageRepositoryPermissionsResponse, error)
+	TestPackageRepositoryConnection(ctx context.Context, in *TestPackageRepositoryConnectionRequest, opts ...grpc.CallOption) (*TestPackageRepositoryConnectionResponse, error)
+	ListPackageRepositoryPackages(ctx context.Context, in *ListPackageRepositoryPackagesRequest, opts ...grpc.CallOption) (*ListPackageRepositoryPackagesResponse, error)
 }
 
 type repositoriesServiceClient struct {
@@ -104,6 +108,24 @@ func (c *repositoriesServiceClient) GetPackageRepositoryPermissions(ctx context.
 	return out, nil
 }
 
+func (c *repositoriesServiceClient) TestPackageRepositoryConnection(ctx context.Context, in *TestPackageRepositoryConnectionRequest, opts ...grpc.CallOption) (*TestPackageRepositoryConnectionResponse, error) {
+	out := new(TestPackageRepositoryConnectionResponse)
+	err := c.cc.Invoke(ctx, RepositoriesService_TestPackageRepositoryConnection_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *repositoriesServiceClient) ListPackageRepositoryPackages(ctx context.Context, in *ListPackageRepositoryPackagesRequest, opts ...grpc.CallOption) (*ListPackageRepositoryPackagesResponse, error) {
+	out := new(ListPackageRepositoryPackagesResponse)
+	err := c.cc.Invoke(ctx, RepositoriesService_ListPackageRepositoryPackages_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RepositoriesServiceServer is the server API for RepositoriesService service.
 // All implementations should embed UnimplementedRepositoriesServiceServer
 // for forward compatibility
@@ -114,6 +136,8 @@ type RepositoriesServiceServer interface {
 	UpdatePackageRepository(context.Context, *UpdatePackageRepositoryRequest) (*UpdatePackageRepositoryResponse, error)
 	DeletePackageRepository(context.Context, *DeletePackageRepositoryRequest) (*DeletePackageRepositoryResponse, error)
 	GetPackageRepositoryPermissions(context.Context, *GetPackageRepositoryPermissionsRequest) (*GetPackageRepositoryPermissionsResponse, error)
+	TestPackageRepositoryConnection(context.Context, *TestPackageRepositoryConnectionRequest) (*TestPackageRepositoryConnectionResponse, error)
+	ListPackageRepositoryPackages(context.Context, *ListPackageRepositoryPackagesRequest) (*ListPackageRepositoryPackagesResponse, error)
 }
 
 // UnimplementedRepositoriesServiceServer should be embedded to have forward compatible implementations.
@@ -138,6 +162,12 @@ func (UnimplementedRepositoriesServiceServer) DeletePackageRepository(context.Co
 func (UnimplementedRepositoriesServiceServer) GetPackageRepositoryPermissions(context.Context, *GetPackageRepositoryPermissionsRequest) (*GetPackageRepositoryPermissionsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetPackageRepositoryPermissions not implemented")
 }
+func (UnimplementedRepositoriesServiceServer) TestPackageRepositoryConnection(context.Context, *TestPackageRepositoryConnectionRequest) (*TestPackageRepositoryConnectionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TestPackageRepositoryConnection not implemented")
+}
+func (UnimplementedRepositoriesServiceServer) ListPackageRepositoryPackages(context.Context, *ListPackageRepositoryPackagesRequest) (*ListPackageRepositoryPackagesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPackageRepositoryPackages not implemented")
+}
 
 // UnsafeRepositoriesServiceServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to RepositoriesServiceServer will
@@ -258,6 +288,42 @@ func _RepositoriesService_GetPackageRepositoryPermissions_Handler(srv interface{
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RepositoriesService_TestPackageRepositoryConnection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TestPackageRepositoryConnectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RepositoriesServiceServer).TestPackageRepositoryConnection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RepositoriesService_TestPackageRepositoryConnection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RepositoriesServiceServer).TestPackageRepositoryConnection(ctx, req.(*TestPackageRepositoryConnectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RepositoriesService_ListPackageRepositoryPackages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPackageRepositoryPackagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RepositoriesServiceServer).ListPackageRepositoryPackages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RepositoriesService_ListPackageRepositoryPackages_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RepositoriesServiceServer).ListPackageRepositoryPackages(ctx, req.(*ListPackageRepositoryPackagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // RepositoriesService_ServiceDesc is the grpc.ServiceDesc for RepositoriesService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -289,6 +355,14 @@ var RepositoriesService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetPackageRepositoryPermissions",
 			Handler:    _RepositoriesService_GetPackageRepositoryPermissions_Handler,
 		},
+		{
+			MethodName: "TestPackageRepositoryConnection",
+			Handler:    _RepositoriesService_TestPackageRepositoryConnection_Handler,
+		},
+		{
+			MethodName: "ListPackageRepositoryPackages",
+			Handler:    _RepositoriesService_ListPackageRepositoryPackages_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "kubeappsapis/core/packages/v1alpha1/repositories.proto",
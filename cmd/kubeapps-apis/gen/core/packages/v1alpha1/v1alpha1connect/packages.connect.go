@@ -45,12 +45,24 @@ const (
 	// PackagesServiceGetAvailablePackageVersionsProcedure is the fully-qualified name of the
 	// PackagesService's GetAvailablePackageVersions RPC.
 	PackagesServiceGetAvailablePackageVersionsProcedure = "/kubeappsapis.core.packages.v1alpha1.PackagesService/GetAvailablePackageVersions"
+	// PackagesServiceGetAvailablePackageTextAssetProcedure is the fully-qualified name of the
+	// PackagesService's GetAvailablePackageTextAsset RPC.
+	PackagesServiceGetAvailablePackageTextAssetProcedure = "/kubeappsapis.core.packages.v1alpha1.PackagesService/GetAvailablePackageTextAsset"
+	// PackagesServiceGetAvailablePackageChangelogProcedure is the fully-qualified name of the
+	// PackagesService's GetAvailablePackageChangelog RPC.
+	PackagesServiceGetAvailablePackageChangelogProcedure = "/kubeappsapis.core.packages.v1alpha1.PackagesService/GetAvailablePackageChangelog"
+	// PackagesServiceGetAvailablePackageFiltersProcedure is the fully-qualified name of the
+	// PackagesService's GetAvailablePackageFilters RPC.
+	PackagesServiceGetAvailablePackageFiltersProcedure = "/kubeappsapis.core.packages.v1alpha1.PackagesService/GetAvailablePackageFilters"
 	// PackagesServiceGetInstalledPackageSummariesProcedure is the fully-qualified name of the
 	// PackagesService's GetInstalledPackageSummaries RPC.
 	PackagesServiceGetInstalledPackageSummariesProcedure = "/kubeappsapis.core.packages.v1alpha1.PackagesService/GetInstalledPackageSummaries"
 	// PackagesServiceGetInstalledPackageDetailProcedure is the fully-qualified name of the
 	// PackagesService's GetInstalledPackageDetail RPC.
 	PackagesServiceGetInstalledPackageDetailProcedure = "/kubeappsapis.core.packages.v1alpha1.PackagesService/GetInstalledPackageDetail"
+	// PackagesServiceCheckInstalledPackageNameAvailabilityProcedure is the fully-qualified name of the
+	// PackagesService's CheckInstalledPackageNameAvailability RPC.
+	PackagesServiceCheckInstalledPackageNameAvailabilityProcedure = "/kubeappsapis.core.packages.v1alpha1.PackagesService/CheckInstalledPackageNameAvailability"
 	// PackagesServiceCreateInstalledPackageProcedure is the fully-qualified name of the
 	// PackagesService's CreateInstalledPackage RPC.
 	PackagesServiceCreateInstalledPackageProcedure = "/kubeappsapis.core.packages.v1alpha1.PackagesService/CreateInstalledPackage"
@@ -63,6 +75,9 @@ const (
 	// PackagesServiceGetInstalledPackageResourceRefsProcedure is the fully-qualified name of the
 	// PackagesService's GetInstalledPackageResourceRefs RPC.
 	PackagesServiceGetInstalledPackageResourceRefsProcedure = "/kubeappsapis.core.packages.v1alpha1.PackagesService/GetInstalledPackageResourceRefs"
+	// PackagesServiceGetInstalledPackageOperationStatusProcedure is the fully-qualified name of the
+	// PackagesService's GetInstalledPackageOperationStatus RPC.
+	PackagesServiceGetInstalledPackageOperationStatusProcedure = "/kubeappsapis.core.packages.v1alpha1.PackagesService/GetInstalledPackageOperationStatus"
 )
 
 // PackagesServiceClient is a client for the kubeappsapis.core.packages.v1alpha1.PackagesService
@@ -71,12 +86,25 @@ type PackagesServiceClient interface {
 	GetAvailablePackageSummaries(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageSummariesRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageSummariesResponse], error)
 	GetAvailablePackageDetail(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageDetailRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageDetailResponse], error)
 	GetAvailablePackageVersions(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageVersionsRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageVersionsResponse], error)
+	GetAvailablePackageTextAsset(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageTextAssetRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageTextAssetResponse], error)
+	GetAvailablePackageChangelog(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageChangelogRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageChangelogResponse], error)
+	// GetAvailablePackageFilters returns the distinct values, across every
+	// configured plugin, that can be used in the FilterOptions of the available
+	// packages, such as their categories and providers.
+	GetAvailablePackageFilters(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageFiltersRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageFiltersResponse], error)
 	GetInstalledPackageSummaries(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageSummariesRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageSummariesResponse], error)
 	GetInstalledPackageDetail(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageDetailRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageDetailResponse], error)
+	// CheckInstalledPackageNameAvailability checks, with every configured plugin,
+	// whether a package can be installed with the given name in the target namespace.
+	CheckInstalledPackageNameAvailability(context.Context, *connect_go.Request[v1alpha1.CheckInstalledPackageNameAvailabilityRequest]) (*connect_go.Response[v1alpha1.CheckInstalledPackageNameAvailabilityResponse], error)
 	CreateInstalledPackage(context.Context, *connect_go.Request[v1alpha1.CreateInstalledPackageRequest]) (*connect_go.Response[v1alpha1.CreateInstalledPackageResponse], error)
 	UpdateInstalledPackage(context.Context, *connect_go.Request[v1alpha1.UpdateInstalledPackageRequest]) (*connect_go.Response[v1alpha1.UpdateInstalledPackageResponse], error)
 	DeleteInstalledPackage(context.Context, *connect_go.Request[v1alpha1.DeleteInstalledPackageRequest]) (*connect_go.Response[v1alpha1.DeleteInstalledPackageResponse], error)
 	GetInstalledPackageResourceRefs(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageResourceRefsRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageResourceRefsResponse], error)
+	// GetInstalledPackageOperationStatus returns the progress of the operation
+	// in course on an installed package, such as its installation, so that
+	// clients can follow it once CreateInstalledPackage returns.
+	GetInstalledPackageOperationStatus(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageOperationStatusRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageOperationStatusResponse], error)
 }
 
 // NewPackagesServiceClient constructs a client for the
@@ -105,6 +133,21 @@ func NewPackagesServiceClient(httpClient connect_go.HTTPClient, baseURL string,
 			baseURL+PackagesServiceGetAvailablePackageVersionsProcedure,
 			opts...,
 		),
+		getAvailablePackageTextAsset: connect_go.NewClient[v1alpha1.GetAvailablePackageTextAssetRequest, v1alpha1.GetAvailablePackageTextAssetResponse](
+			httpClient,
+			baseURL+PackagesServiceGetAvailablePackageTextAssetProcedure,
+			opts...,
+		),
+		getAvailablePackageChangelog: connect_go.NewClient[v1alpha1.GetAvailablePackageChangelogRequest, v1alpha1.GetAvailablePackageChangelogResponse](
+			httpClient,
+			baseURL+PackagesServiceGetAvailablePackageChangelogProcedure,
+			opts...,
+		),
+		getAvailablePackageFilters: connect_go.NewClient[v1alpha1.GetAvailablePackageFiltersRequest, v1alpha1.GetAvailablePackageFiltersResponse](
+			httpClient,
+			baseURL+PackagesServiceGetAvailablePackageFiltersProcedure,
+			opts...,
+		),
 		getInstalledPackageSummaries: connect_go.NewClient[v1alpha1.GetInstalledPackageSummariesRequest, v1alpha1.GetInstalledPackageSummariesResponse](
 			httpClient,
 			baseURL+PackagesServiceGetInstalledPackageSummariesProcedure,
@@ -115,6 +158,11 @@ func NewPackagesServiceClient(httpClient connect_go.HTTPClient, baseURL string,
 			baseURL+PackagesServiceGetInstalledPackageDetailProcedure,
 			opts...,
 		),
+		checkInstalledPackageNameAvailability: connect_go.NewClient[v1alpha1.CheckInstalledPackageNameAvailabilityRequest, v1alpha1.CheckInstalledPackageNameAvailabilityResponse](
+			httpClient,
+			baseURL+PackagesServiceCheckInstalledPackageNameAvailabilityProcedure,
+			opts...,
+		),
 		createInstalledPackage: connect_go.NewClient[v1alpha1.CreateInstalledPackageRequest, v1alpha1.CreateInstalledPackageResponse](
 			httpClient,
 			baseURL+PackagesServiceCreateInstalledPackageProcedure,
@@ -135,20 +183,30 @@ func NewPackagesServiceClient(httpClient connect_go.HTTPClient, baseURL string,
 			baseURL+PackagesServiceGetInstalledPackageResourceRefsProcedure,
 			opts...,
 		),
+		getInstalledPackageOperationStatus: connect_go.NewClient[v1alpha1.GetInstalledPackageOperationStatusRequest, v1alpha1.GetInstalledPackageOperationStatusResponse](
+			httpClient,
+			baseURL+PackagesServiceGetInstalledPackageOperationStatusProcedure,
+			opts...,
+		),
 	}
 }
 
 // packagesServiceClient implements PackagesServiceClient.
 type packagesServiceClient struct {
-	getAvailablePackageSummaries    *connect_go.Client[v1alpha1.GetAvailablePackageSummariesRequest, v1alpha1.GetAvailablePackageSummariesResponse]
-	getAvailablePackageDetail       *connect_go.Client[v1alpha1.GetAvailablePackageDetailRequest, v1alpha1.GetAvailablePackageDetailResponse]
-	getAvailablePackageVersions     *connect_go.Client[v1alpha1.GetAvailablePackageVersionsRequest, v1alpha1.GetAvailablePackageVersionsResponse]
-	getInstalledPackageSummaries    *connect_go.Client[v1alpha1.GetInstalledPackageSummariesRequest, v1alpha1.GetInstalledPackageSummariesResponse]
-	getInstalledPackageDetail       *connect_go.Client[v1alpha1.GetInstalledPackageDetailRequest, v1alpha1.GetInstalledPackageDetailResponse]
-	createInstalledPackage          *connect_go.Client[v1alpha1.CreateInstalledPackageRequest, v1alpha1.CreateInstalledPackageResponse]
-	updateInstalledPackage          *connect_go.Client[v1alpha1.UpdateInstalledPackageRequest, v1alpha1.UpdateInstalledPackageResponse]
-	deleteInstalledPackage          *connect_go.Client[v1alpha1.DeleteInstalledPackageRequest, v1alpha1.DeleteInstalledPackageResponse]
-	getInstalledPackageResourceRefs *connect_go.Client[v1alpha1.GetInstalledPackageResourceRefsRequest, v1alpha1.GetInstalledPackageResourceRefsResponse]
+	getAvailablePackageSummaries          *connect_go.Client[v1alpha1.GetAvailablePackageSummariesRequest, v1alpha1.GetAvailablePackageSummariesResponse]
+	getAvailablePackageDetail             *connect_go.Client[v1alpha1.GetAvailablePackageDetailRequest, v1alpha1.GetAvailablePackageDetailResponse]
+	getAvailablePackageVersions           *connect_go.Client[v1alpha1.GetAvailablePackageVersionsRequest, v1alpha1.GetAvailablePackageVersionsResponse]
+	getAvailablePackageTextAsset          *connect_go.Client[v1alpha1.GetAvailablePackageTextAssetRequest, v1alpha1.GetAvailablePackageTextAssetResponse]
+	getAvailablePackageChangelog          *connect_go.Client[v1alpha1.GetAvailablePackageChangelogRequest, v1alpha1.GetAvailablePackageChangelogResponse]
+	getAvailablePackageFilters            *connect_go.Client[v1alpha1.GetAvailablePackageFiltersRequest, v1alpha1.GetAvailablePackageFiltersResponse]
+	getInstalledPackageSummaries          *connect_go.Client[v1alpha1.GetInstalledPackageSummariesRequest, v1alpha1.GetInstalledPackageSummariesResponse]
+	getInstalledPackageDetail             *connect_go.Client[v1alpha1.GetInstalledPackageDetailRequest, v1alpha1.GetInstalledPackageDetailResponse]
+	checkInstalledPackageNameAvailability *connect_go.Client[v1alpha1.CheckInstalledPackageNameAvailabilityRequest, v1alpha1.CheckInstalledPackageNameAvailabilityResponse]
+	createInstalledPackage                *connect_go.Client[v1alpha1.CreateInstalledPackageRequest, v1alpha1.CreateInstalledPackageResponse]
+	updateInstalledPackage                *connect_go.Client[v1alpha1.UpdateInstalledPackageRequest, v1alpha1.UpdateInstalledPackageResponse]
+	deleteInstalledPackage                *connect_go.Client[v1alpha1.DeleteInstalledPackageRequest, v1alpha1.DeleteInstalledPackageResponse]
+	getInstalledPackageResourceRefs       *connect_go.Client[v1alpha1.GetInstalledPackageResourceRefsRequest, v1alpha1.GetInstalledPackageResourceRefsResponse]
+	getInstalledPackageOperationStatus    *connect_go.Client[v1alpha1.GetInstalledPackageOperationStatusRequest, v1alpha1.GetInstalledPackageOperationStatusResponse]
 }
 
 // GetAvailablePackageSummaries calls
@@ -169,6 +227,24 @@ func (c *packagesServiceClient) GetAvailablePackageVersions(ctx context.Context,
 	return c.getAvailablePackageVersions.CallUnary(ctx, req)
 }
 
+// GetAvailablePackageTextAsset calls
+// kubeappsapis.core.packages.v1alpha1.PackagesService.GetAvailablePackageTextAsset.
+func (c *packagesServiceClient) GetAvailablePackageTextAsset(ctx context.Context, req *connect_go.Request[v1alpha1.GetAvailablePackageTextAssetRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageTextAssetResponse], error) {
+	return c.getAvailablePackageTextAsset.CallUnary(ctx, req)
+}
+
+// GetAvailablePackageChangelog calls
+// kubeappsapis.core.packages.v1alpha1.PackagesService.GetAvailablePackageChangelog.
+func (c *packagesServiceClient) GetAvailablePackageChangelog(ctx context.Context, req *connect_go.Request[v1alpha1.GetAvailablePackageChangelogRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageChangelogResponse], error) {
+	return c.getAvailablePackageChangelog.CallUnary(ctx, req)
+}
+
+// GetAvailablePackageFilters calls
+// kubeappsapis.core.packages.v1alpha1.PackagesService.GetAvailablePackageFilters.
+func (c *packagesServiceClient) GetAvailablePackageFilters(ctx context.Context, req *connect_go.Request[v1alpha1.GetAvailablePackageFiltersRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageFiltersResponse], error) {
+	return c.getAvailablePackageFilters.CallUnary(ctx, req)
+}
+
 // GetInstalledPackageSummaries calls
 // kubeappsapis.core.packages.v1alpha1.PackagesService.GetInstalledPackageSummaries.
 func (c *packagesServiceClient) GetInstalledPackageSummaries(ctx context.Context, req *connect_go.Request[v1alpha1.GetInstalledPackageSummariesRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageSummariesResponse], error) {
@@ -181,6 +257,12 @@ func (c *packagesServiceClient) GetInstalledPackageDetail(ctx context.Context, r
 	return c.getInstalledPackageDetail.CallUnary(ctx, req)
 }
 
+// CheckInstalledPackageNameAvailability calls
+// kubeappsapis.core.packages.v1alpha1.PackagesService.CheckInstalledPackageNameAvailability.
+func (c *packagesServiceClient) CheckInstalledPackageNameAvailability(ctx context.Context, req *connect_go.Request[v1alpha1.CheckInstalledPackageNameAvailabilityRequest]) (*connect_go.Response[v1alpha1.CheckInstalledPackageNameAvailabilityResponse], error) {
+	return c.checkInstalledPackageNameAvailability.CallUnary(ctx, req)
+}
+
 // CreateInstalledPackage calls
 // kubeappsapis.core.packages.v1alpha1.PackagesService.CreateInstalledPackage.
 func (c *packagesServiceClient) CreateInstalledPackage(ctx context.Context, req *connect_go.Request[v1alpha1.CreateInstalledPackageRequest]) (*connect_go.Response[v1alpha1.CreateInstalledPackageResponse], error) {
@@ -205,18 +287,37 @@ func (c *packagesServiceClient) GetInstalledPackageResourceRefs(ctx context.Cont
 	return c.getInstalledPackageResourceRefs.CallUnary(ctx, req)
 }
 
+// GetInstalledPackageOperationStatus calls
+// kubeappsapis.core.packages.v1alpha1.PackagesService.GetInstalledPackageOperationStatus.
+func (c *packagesServiceClient) GetInstalledPackageOperationStatus(ctx context.Context, req *connect_go.Request[v1alpha1.GetInstalledPackageOperationStatusRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageOperationStatusResponse], error) {
+	return c.getInstalledPackageOperationStatus.CallUnary(ctx, req)
+}
+
 // PackagesServiceHandler is an implementation of the
 // kubeappsapis.core.packages.v1alpha1.PackagesService service.
 type PackagesServiceHandler interface {
 	GetAvailablePackageSummaries(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageSummariesRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageSummariesResponse], error)
 	GetAvailablePackageDetail(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageDetailRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageDetailResponse], error)
 	GetAvailablePackageVersions(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageVersionsRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageVersionsResponse], error)
+	GetAvailablePackageTextAsset(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageTextAssetRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageTextAssetResponse], error)
+	GetAvailablePackageChangelog(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageChangelogRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageChangelogResponse], error)
+	// GetAvailablePackageFilters returns the distinct values, across every
+	// configured plugin, that can be used in the FilterOptions of the available
+	// packages, such as their categories and providers.
+	GetAvailablePackageFilters(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageFiltersRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageFiltersResponse], error)
 	GetInstalledPackageSummaries(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageSummariesRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageSummariesResponse], error)
 	GetInstalledPackageDetail(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageDetailRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageDetailResponse], error)
+	// CheckInstalledPackageNameAvailability checks, with every configured plugin,
+	// whether a package can be installed with the given name in the target namespace.
+	CheckInstalledPackageNameAvailability(context.Context, *connect_go.Request[v1alpha1.CheckInstalledPackageNameAvailabilityRequest]) (*connect_go.Response[v1alpha1.CheckInstalledPackageNameAvailabilityResponse], error)
 	CreateInstalledPackage(context.Context, *connect_go.Request[v1alpha1.CreateInstalledPackageRequest]) (*connect_go.Response[v1alpha1.CreateInstalledPackageResponse], error)
 	UpdateInstalledPackage(context.Context, *connect_go.Request[v1alpha1.UpdateInstalledPackageRequest]) (*connect_go.Response[v1alpha1.UpdateInstalledPackageResponse], error)
 	DeleteInstalledPackage(context.Context, *connect_go.Request[v1alpha1.DeleteInstalledPackageRequest]) (*connect_go.Response[v1alpha1.DeleteInstalledPackageResponse], error)
 	GetInstalledPackageResourceRefs(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageResourceRefsRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageResourceRefsResponse], error)
+	// GetInstalledPackageOperationStatus returns the progress of the operation
+	// in course on an installed package, such as its installation, so that
+	// clients can follow it once CreateInstalledPackage returns.
+	GetInstalledPackageOperationStatus(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageOperationStatusRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageOperationStatusResponse], error)
 }
 
 // NewPackagesServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -240,6 +341,21 @@ func NewPackagesServiceHandler(svc PackagesServiceHandler, opts ...connect_go.Ha
 		svc.GetAvailablePackageVersions,
 		opts...,
 	)
+	packagesServiceGetAvailablePackageTextAssetHandler := connect_go.NewUnaryHandler(
+		PackagesServiceGetAvailablePackageTextAssetProcedure,
+		svc.GetAvailablePackageTextAsset,
+		opts...,
+	)
+	packagesServiceGetAvailablePackageChangelogHandler := connect_go.NewUnaryHandler(
+		PackagesServiceGetAvailablePackageChangelogProcedure,
+		svc.GetAvailablePackageChangelog,
+		opts...,
+	)
+	packagesServiceGetAvailablePackageFiltersHandler := connect_go.NewUnaryHandler(
+		PackagesServiceGetAvailablePackageFiltersProcedure,
+		svc.GetAvailablePackageFilters,
+		opts...,
+	)
 	packagesServiceGetInstalledPackageSummariesHandler := connect_go.NewUnaryHandler(
 		PackagesServiceGetInstalledPackageSummariesProcedure,
 		svc.GetInstalledPackageSummaries,
@@ -250,6 +366,11 @@ func NewPackagesServiceHandler(svc PackagesServiceHandler, opts ...connect_go.Ha
 		svc.GetInstalledPackageDetail,
 		opts...,
 	)
+	packagesServiceCheckInstalledPackageNameAvailabilityHandler := connect_go.NewUnaryHandler(
+		PackagesServiceCheckInstalledPackageNameAvailabilityProcedure,
+		svc.CheckInstalledPackageNameAvailability,
+		opts...,
+	)
 	packagesServiceCreateInstalledPackageHandler := connect_go.NewUnaryHandler(
 		PackagesServiceCreateInstalledPackageProcedure,
 		svc.CreateInstalledPackage,
@@ -270,6 +391,11 @@ func NewPackagesServiceHandler(svc PackagesServiceHandler, opts ...connect_go.Ha
 		svc.GetInstalledPackageResourceRefs,
 		opts...,
 	)
+	packagesServiceGetInstalledPackageOperationStatusHandler := connect_go.NewUnaryHandler(
+		PackagesServiceGetInstalledPackageOperationStatusProcedure,
+		svc.GetInstalledPackageOperationStatus,
+		opts...,
+	)
 	return "/kubeappsapis.core.packages.v1alpha1.PackagesService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case PackagesServiceGetAvailablePackageSummariesProcedure:
@@ -278,10 +404,18 @@ func NewPackagesServiceHandler(svc PackagesServiceHandler, opts ...connect_go.Ha
 			packagesServiceGetAvailablePackageDetailHandler.ServeHTTP(w, r)
 		case PackagesServiceGetAvailablePackageVersionsProcedure:
 			packagesServiceGetAvailablePackageVersionsHandler.ServeHTTP(w, r)
+		case PackagesServiceGetAvailablePackageTextAssetProcedure:
+			packagesServiceGetAvailablePackageTextAssetHandler.ServeHTTP(w, r)
+		case PackagesServiceGetAvailablePackageChangelogProcedure:
+			packagesServiceGetAvailablePackageChangelogHandler.ServeHTTP(w, r)
+		case PackagesServiceGetAvailablePackageFiltersProcedure:
+			packagesServiceGetAvailablePackageFiltersHandler.ServeHTTP(w, r)
 		case PackagesServiceGetInstalledPackageSummariesProcedure:
 			packagesServiceGetInstalledPackageSummariesHandler.ServeHTTP(w, r)
 		case PackagesServiceGetInstalledPackageDetailProcedure:
 			packagesServiceGetInstalledPackageDetailHandler.ServeHTTP(w, r)
+		case PackagesServiceCheckInstalledPackageNameAvailabilityProcedure:
+			packagesServiceCheckInstalledPackageNameAvailabilityHandler.ServeHTTP(w, r)
 		case PackagesServiceCreateInstalledPackageProcedure:
 			packagesServiceCreateInstalledPackageHandler.ServeHTTP(w, r)
 		case PackagesServiceUpdateInstalledPackageProcedure:
@@ -290,6 +424,8 @@ func NewPackagesServiceHandler(svc PackagesServiceHandler, opts ...connect_go.Ha
 			packagesServiceDeleteInstalledPackageHandler.ServeHTTP(w, r)
 		case PackagesServiceGetInstalledPackageResourceRefsProcedure:
 			packagesServiceGetInstalledPackageResourceRefsHandler.ServeHTTP(w, r)
+		case PackagesServiceGetInstalledPackageOperationStatusProcedure:
+			packagesServiceGetInstalledPackageOperationStatusHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -311,6 +447,18 @@ func (UnimplementedPackagesServiceHandler) GetAvailablePackageVersions(context.C
 	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.core.packages.v1alpha1.PackagesService.GetAvailablePackageVersions is not implemented"))
 }
 
+func (UnimplementedPackagesServiceHandler) GetAvailablePackageTextAsset(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageTextAssetRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageTextAssetResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.core.packages.v1alpha1.PackagesService.GetAvailablePackageTextAsset is not implemented"))
+}
+
+func (UnimplementedPackagesServiceHandler) GetAvailablePackageChangelog(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageChangelogRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageChangelogResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.core.packages.v1alpha1.PackagesService.GetAvailablePackageChangelog is not implemented"))
+}
+
+func (UnimplementedPackagesServiceHandler) GetAvailablePackageFilters(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageFiltersRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageFiltersResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.core.packages.v1alpha1.PackagesService.GetAvailablePackageFilters is not implemented"))
+}
+
 func (UnimplementedPackagesServiceHandler) GetInstalledPackageSummaries(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageSummariesRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageSummariesResponse], error) {
 	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.core.packages.v1alpha1.PackagesService.GetInstalledPackageSummaries is not implemented"))
 }
@@ -319,6 +467,10 @@ func (UnimplementedPackagesServiceHandler) GetInstalledPackageDetail(context.Con
 	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.core.packages.v1alpha1.PackagesService.GetInstalledPackageDetail is not implemented"))
 }
 
+func (UnimplementedPackagesServiceHandler) CheckInstalledPackageNameAvailability(context.Context, *connect_go.Request[v1alpha1.CheckInstalledPackageNameAvailabilityRequest]) (*connect_go.Response[v1alpha1.CheckInstalledPackageNameAvailabilityResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.core.packages.v1alpha1.PackagesService.CheckInstalledPackageNameAvailability is not implemented"))
+}
+
 func (UnimplementedPackagesServiceHandler) CreateInstalledPackage(context.Context, *connect_go.Request[v1alpha1.CreateInstalledPackageRequest]) (*connect_go.Response[v1alpha1.CreateInstalledPackageResponse], error) {
 	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.core.packages.v1alpha1.PackagesService.CreateInstalledPackage is not implemented"))
 }
@@ -334,3 +486,7 @@ func (UnimplementedPackagesServiceHandler) DeleteInstalledPackage(context.Contex
 func (UnimplementedPackagesServiceHandler) GetInstalledPackageResourceRefs(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageResourceRefsRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageResourceRefsResponse], error) {
 	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.core.packages.v1alpha1.PackagesService.GetInstalledPackageResourceRefs is not implemented"))
 }
+
+func (UnimplementedPackagesServiceHandler) GetInstalledPackageOperationStatus(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageOperationStatusRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageOperationStatusResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.core.packages.v1alpha1.PackagesService.GetInstalledPackageOperationStatus is not implemented"))
+}
@@ -54,6 +54,12 @@ const (
 	// RepositoriesServiceGetPackageRepositoryPermissionsProcedure is the fully-qualified name of the
 	// RepositoriesService's GetPackageRepositoryPermissions RPC.
 	RepositoriesServiceGetPackageRepositoryPermissionsProcedure = "/kubeappsapis.core.packages.v1alpha1.RepositoriesService/GetPackageRepositoryPermissions"
+	// RepositoriesServiceTestPackageRepositoryConnectionProcedure is the fully-qualified name of the
+	// RepositoriesService's TestPackageRepositoryConnection RPC.
+	RepositoriesServiceTestPackageRepositoryConnectionProcedure = "/kubeappsapis.core.packages.v1alpha1.RepositoriesService/TestPackageRepositoryConnection"
+	// RepositoriesServiceListPackageRepositoryPackagesProcedure is the fully-qualified name of the
+	// RepositoriesService's ListPackageRepositoryPackages RPC.
+	RepositoriesServiceListPackageRepositoryPackagesProcedure = "/kubeappsapis.core.packages.v1alpha1.RepositoriesService/ListPackageRepositoryPackages"
 )
 
 // RepositoriesServiceClient is a client for the
@@ -65,6 +71,8 @@ type RepositoriesServiceClient interface {
 	UpdatePackageRepository(context.Context, *connect_go.Request[v1alpha1.UpdatePackageRepositoryRequest]) (*connect_go.Response[v1alpha1.UpdatePackageRepositoryResponse], error)
 	DeletePackageRepository(context.Context, *connect_go.Request[v1alpha1.DeletePackageRepositoryRequest]) (*connect_go.Response[v1alpha1.DeletePackageRepositoryResponse], error)
 	GetPackageRepositoryPermissions(context.Context, *connect_go.Request[v1alpha1.GetPackageRepositoryPermissionsRequest]) (*connect_go.Response[v1alpha1.GetPackageRepositoryPermissionsResponse], error)
+	TestPackageRepositoryConnection(context.Context, *connect_go.Request[v1alpha1.TestPackageRepositoryConnectionRequest]) (*connect_go.Response[v1alpha1.TestPackageRepositoryConnectionResponse], error)
+	ListPackageRepositoryPackages(context.Context, *connect_go.Request[v1alpha1.ListPackageRepositoryPackagesRequest]) (*connect_go.Response[v1alpha1.ListPackageRepositoryPackagesResponse], error)
 }
 
 // NewRepositoriesServiceClient constructs a client for the
@@ -108,6 +116,16 @@ func NewRepositoriesServiceClient(httpClient connect_go.HTTPClient, baseURL stri
 			baseURL+RepositoriesServiceGetPackageRepositoryPermissionsProcedure,
 			opts...,
 		),
+		testPackageRepositoryConnection: connect_go.NewClient[v1alpha1.TestPackageRepositoryConnectionRequest, v1alpha1.TestPackageRepositoryConnectionResponse](
+			httpClient,
+			baseURL+RepositoriesServiceTestPackageRepositoryConnectionProcedure,
+			opts...,
+		),
+		listPackageRepositoryPackages: connect_go.NewClient[v1alpha1.ListPackageRepositoryPackagesRequest, v1alpha1.ListPackageRepositoryPackagesResponse](
+			httpClient,
+			baseURL+RepositoriesServiceListPackageRepositoryPackagesProcedure,
+			opts...,
+		),
 	}
 }
 
@@ -119,6 +137,8 @@ type repositoriesServiceClient struct {
 	updatePackageRepository         *connect_go.Client[v1alpha1.UpdatePackageRepositoryRequest, v1alpha1.UpdatePackageRepositoryResponse]
 	deletePackageRepository         *connect_go.Client[v1alpha1.DeletePackageRepositoryRequest, v1alpha1.DeletePackageRepositoryResponse]
 	getPackageRepositoryPermissions *connect_go.Client[v1alpha1.GetPackageRepositoryPermissionsRequest, v1alpha1.GetPackageRepositoryPermissionsResponse]
+	testPackageRepositoryConnection *connect_go.Client[v1alpha1.TestPackageRepositoryConnectionRequest, v1alpha1.TestPackageRepositoryConnectionResponse]
+	listPackageRepositoryPackages   *connect_go.Client[v1alpha1.ListPackageRepositoryPackagesRequest, v1alpha1.ListPackageRepositoryPackagesResponse]
 }
 
 // AddPackageRepository calls
@@ -157,6 +177,18 @@ func (c *repositoriesServiceClient) GetPackageRepositoryPermissions(ctx context.
 	return c.getPackageRepositoryPermissions.CallUnary(ctx, req)
 }
 
+// TestPackageRepositoryConnection calls
+// kubeappsapis.core.packages.v1alpha1.RepositoriesService.TestPackageRepositoryConnection.
+func (c *repositoriesServiceClient) TestPackageRepositoryConnection(ctx context.Context, req *connect_go.Request[v1alpha1.TestPackageRepositoryConnectionRequest]) (*connect_go.Response[v1alpha1.TestPackageRepositoryConnectionResponse], error) {
+	return c.testPackageRepositoryConnection.CallUnary(ctx, req)
+}
+
+// ListPackageRepositoryPackages calls
+// kubeappsapis.core.packages.v1alpha1.RepositoriesService.ListPackageRepositoryPackages.
+func (c *repositoriesServiceClient) ListPackageRepositoryPackages(ctx context.Context, req *connect_go.Request[v1alpha1.ListPackageRepositoryPackagesRequest]) (*connect_go.Response[v1alpha1.ListPackageRepositoryPackagesResponse], error) {
+	return c.listPackageRepositoryPackages.CallUnary(ctx, req)
+}
+
 // RepositoriesServiceHandler is an implementation of the
 // kubeappsapis.core.packages.v1alpha1.RepositoriesService service.
 type RepositoriesServiceHandler interface {
@@ -166,6 +198,8 @@ type RepositoriesServiceHandler interface {
 	UpdatePackageRepository(context.Context, *connect_go.Request[v1alpha1.UpdatePackageRepositoryRequest]) (*connect_go.Response[v1alpha1.UpdatePackageRepositoryResponse], error)
 	DeletePackageRepository(context.Context, *connect_go.Request[v1alpha1.DeletePackageRepositoryRequest]) (*connect_go.Response[v1alpha1.DeletePackageRepositoryResponse], error)
 	GetPackageRepositoryPermissions(context.Context, *connect_go.Request[v1alpha1.GetPackageRepositoryPermissionsRequest]) (*connect_go.Response[v1alpha1.GetPackageRepositoryPermissionsResponse], error)
+	TestPackageRepositoryConnection(context.Context, *connect_go.Request[v1alpha1.TestPackageRepositoryConnectionRequest]) (*connect_go.Response[v1alpha1.TestPackageRepositoryConnectionResponse], error)
+	ListPackageRepositoryPackages(context.Context, *connect_go.Request[v1alpha1.ListPackageRepositoryPackagesRequest]) (*connect_go.Response[v1alpha1.ListPackageRepositoryPackagesResponse], error)
 }
 
 // NewRepositoriesServiceHandler builds an HTTP handler from the service implementation. It returns
@@ -204,6 +238,16 @@ func NewRepositoriesServiceHandler(svc RepositoriesServiceHandler, opts ...conne
 		svc.GetPackageRepositoryPermissions,
 		opts...,
 	)
+	repositoriesServiceTestPackageRepositoryConnectionHandler := connect_go.NewUnaryHandler(
+		RepositoriesServiceTestPackageRepositoryConnectionProcedure,
+		svc.TestPackageRepositoryConnection,
+		opts...,
+	)
+	repositoriesServiceListPackageRepositoryPackagesHandler := connect_go.NewUnaryHandler(
+		RepositoriesServiceListPackageRepositoryPackagesProcedure,
+		svc.ListPackageRepositoryPackages,
+		opts...,
+	)
 	return "/kubeappsapis.core.packages.v1alpha1.RepositoriesService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case RepositoriesServiceAddPackageRepositoryProcedure:
@@ -218,6 +262,10 @@ func NewRepositoriesServiceHandler(svc RepositoriesServiceHandler, opts ...conne
 			repositoriesServiceDeletePackageRepositoryHandler.ServeHTTP(w, r)
 		case RepositoriesServiceGetPackageRepositoryPermissionsProcedure:
 			repositoriesServiceGetPackageRepositoryPermissionsHandler.ServeHTTP(w, r)
+		case RepositoriesServiceTestPackageRepositoryConnectionProcedure:
+			repositoriesServiceTestPackageRepositoryConnectionHandler.ServeHTTP(w, r)
+		case RepositoriesServiceListPackageRepositoryPackagesProcedure:
+			repositoriesServiceListPackageRepositoryPackagesHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -250,3 +298,11 @@ func (UnimplementedRepositoriesServiceHandler) DeletePackageRepository(context.C
 func (UnimplementedRepositoriesServiceHandler) GetPackageRepositoryPermissions(context.Context, *connect_go.Request[v1alpha1.GetPackageRepositoryPermissionsRequest]) (*connect_go.Response[v1alpha1.GetPackageRepositoryPermissionsResponse], error) {
 	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.core.packages.v1alpha1.RepositoriesService.GetPackageRepositoryPermissions is not implemented"))
 }
+
+func (UnimplementedRepositoriesServiceHandler) TestPackageRepositoryConnection(context.Context, *connect_go.Request[v1alpha1.TestPackageRepositoryConnectionRequest]) (*connect_go.Response[v1alpha1.TestPackageRepositoryConnectionResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.core.packages.v1alpha1.RepositoriesService.TestPackageRepositoryConnection is not implemented"))
+}
+
+func (UnimplementedRepositoriesServiceHandler) ListPackageRepositoryPackages(context.Context, *connect_go.Request[v1alpha1.ListPackageRepositoryPackagesRequest]) (*connect_go.Response[v1alpha1.ListPackageRepositoryPackagesResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.core.packages.v1alpha1.RepositoriesService.ListPackageRepositoryPackages is not implemented"))
+}
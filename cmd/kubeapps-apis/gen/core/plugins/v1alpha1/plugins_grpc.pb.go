@@ -23,6 +23,8 @@ const _ = grpc.SupportPackageIsVersion7
 
 const (
 	PluginsService_GetConfiguredPlugins_FullMethodName = "/kubeappsapis.core.plugins.v1alpha1.PluginsService/GetConfiguredPlugins"
+	PluginsService_GetFeatures_FullMethodName          = "/kubeappsapis.core.plugins.v1alpha1.PluginsService/GetFeatures"
+	PluginsService_GetPluginConfigs_FullMethodName     = "/kubeappsapis.core.plugins.v1alpha1.PluginsService/GetPluginConfigs"
 )
 
 // PluginsServiceClient is the client API for PluginsService service.
@@ -31,6 +33,15 @@ const (
 type PluginsServiceClient interface {
 	// GetConfiguredPlugins returns a map of short and longnames for the configured plugins.
 	GetConfiguredPlugins(ctx context.Context, in *GetConfiguredPluginsRequest, opts ...grpc.CallOption) (*GetConfiguredPluginsResponse, error)
+	// GetFeatures returns a versioned descriptor of the features enabled in the
+	// backend (plugins, clusters and operations), along with its checksum and,
+	// if a signing key is configured, its signature, so that the dashboard can
+	// bootstrap with a single call and verify the configuration it renders.
+	GetFeatures(ctx context.Context, in *GetFeaturesRequest, opts ...grpc.CallOption) (*GetFeaturesResponse, error)
+	// GetPluginConfigs returns the configuration currently in effect for each
+	// plugin supporting the reload of its configuration at runtime, along with
+	// the result of the last reload.
+	GetPluginConfigs(ctx context.Context, in *GetPluginConfigsRequest, opts ...grpc.CallOption) (*GetPluginConfigsResponse, error)
 }
 
 type pluginsServiceClient struct {
@@ -50,12 +61,39 @@ func (c *pluginsServiceClient) GetConfiguredPlugins(ctx context.Context, in *Get
 	return out, nil
 }
 
+func (c *pluginsServiceClient) GetFeatures(ctx context.Context, in *GetFeaturesRequest, opts ...grpc.CallOption) (*GetFeaturesResponse, error) {
+	out := new(GetFeaturesResponse)
+	err := c.cc.Invoke(ctx, PluginsService_GetFeatures_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pluginsServiceClient) GetPluginConfigs(ctx context.Context, in *GetPluginConfigsRequest, opts ...grpc.CallOption) (*GetPluginConfigsResponse, error) {
+	out := new(GetPluginConfigsResponse)
+	err := c.cc.Invoke(ctx, PluginsService_GetPluginConfigs_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PluginsServiceServer is the server API for PluginsService service.
 // All implementations should embed UnimplementedPluginsServiceServer
 // for forward compatibility
 type PluginsServiceServer interface {
 	// GetConfiguredPlugins returns a map of short and longnames for the configured plugins.
 	GetConfiguredPlugins(context.Context, *GetConfiguredPluginsRequest) (*GetConfiguredPluginsResponse, error)
+	// GetFeatures returns a versioned descriptor of the features enabled in the
+	// backend (plugins, clusters and operations), along with its checksum and,
+	// if a signing key is configured, its signature, so that the dashboard can
+	// bootstrap with a single call and verify the configuration it renders.
+	GetFeatures(context.Context, *GetFeaturesRequest) (*GetFeaturesResponse, error)
+	// GetPluginConfigs returns the configuration currently in effect for each
+	// plugin supporting the reload of its configuration at runtime, along with
+	// the result of the last reload.
+	GetPluginConfigs(context.Context, *GetPluginConfigsRequest) (*GetPluginConfigsResponse, error)
 }
 
 // UnimplementedPluginsServiceServer should be embedded to have forward compatible implementations.
@@ -65,6 +103,12 @@ type UnimplementedPluginsServiceServer struct {
 func (UnimplementedPluginsServiceServer) GetConfiguredPlugins(context.Context, *GetConfiguredPluginsRequest) (*GetConfiguredPluginsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetConfiguredPlugins not implemented")
 }
+func (UnimplementedPluginsServiceServer) GetFeatures(context.Context, *GetFeaturesRequest) (*GetFeaturesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFeatures not implemented")
+}
+func (UnimplementedPluginsServiceServer) GetPluginConfigs(context.Context, *GetPluginConfigsRequest) (*GetPluginConfigsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPluginConfigs not implemented")
+}
 
 // UnsafePluginsServiceServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to PluginsServiceServer will
@@ -95,6 +139,42 @@ func _PluginsService_GetConfiguredPlugins_Handler(srv interface{}, ctx context.C
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PluginsService_GetFeatures_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFeaturesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PluginsServiceServer).GetFeatures(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PluginsService_GetFeatures_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PluginsServiceServer).GetFeatures(ctx, req.(*GetFeaturesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PluginsService_GetPluginConfigs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPluginConfigsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PluginsServiceServer).GetPluginConfigs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PluginsService_GetPluginConfigs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PluginsServiceServer).GetPluginConfigs(ctx, req.(*GetPluginConfigsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // PluginsService_ServiceDesc is the grpc.ServiceDesc for PluginsService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -106,6 +186,14 @@ var PluginsService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetConfiguredPlugins",
 			Handler:    _PluginsService_GetConfiguredPlugins_Handler,
 		},
+		{
+			MethodName: "GetFeatures",
+			Handler:    _PluginsService_GetFeatures_Handler,
+		},
+		{
+			MethodName: "GetPluginConfigs",
+			Handler:    _PluginsService_GetPluginConfigs_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "kubeappsapis/core/plugins/v1alpha1/plugins.proto",
@@ -39,6 +39,12 @@ const (
 	// PluginsServiceGetConfiguredPluginsProcedure is the fully-qualified name of the PluginsService's
 	// GetConfiguredPlugins RPC.
 	PluginsServiceGetConfiguredPluginsProcedure = "/kubeappsapis.core.plugins.v1alpha1.PluginsService/GetConfiguredPlugins"
+	// PluginsServiceGetFeaturesProcedure is the fully-qualified name of the PluginsService's
+	// GetFeatures RPC.
+	PluginsServiceGetFeaturesProcedure = "/kubeappsapis.core.plugins.v1alpha1.PluginsService/GetFeatures"
+	// PluginsServiceGetPluginConfigsProcedure is the fully-qualified name of the PluginsService's
+	// GetPluginConfigs RPC.
+	PluginsServiceGetPluginConfigsProcedure = "/kubeappsapis.core.plugins.v1alpha1.PluginsService/GetPluginConfigs"
 )
 
 // PluginsServiceClient is a client for the kubeappsapis.core.plugins.v1alpha1.PluginsService
@@ -46,6 +52,15 @@ const (
 type PluginsServiceClient interface {
 	// GetConfiguredPlugins returns a map of short and longnames for the configured plugins.
 	GetConfiguredPlugins(context.Context, *connect_go.Request[v1alpha1.GetConfiguredPluginsRequest]) (*connect_go.Response[v1alpha1.GetConfiguredPluginsResponse], error)
+	// GetFeatures returns a versioned descriptor of the features enabled in the
+	// backend (plugins, clusters and operations), along with its checksum and,
+	// if a signing key is configured, its signature, so that the dashboard can
+	// bootstrap with a single call and verify the configuration it renders.
+	GetFeatures(context.Context, *connect_go.Request[v1alpha1.GetFeaturesRequest]) (*connect_go.Response[v1alpha1.GetFeaturesResponse], error)
+	// GetPluginConfigs returns the configuration currently in effect for each
+	// plugin supporting the reload of its configuration at runtime, along with
+	// the result of the last reload.
+	GetPluginConfigs(context.Context, *connect_go.Request[v1alpha1.GetPluginConfigsRequest]) (*connect_go.Response[v1alpha1.GetPluginConfigsResponse], error)
 }
 
 // NewPluginsServiceClient constructs a client for the
@@ -64,12 +79,24 @@ func NewPluginsServiceClient(httpClient connect_go.HTTPClient, baseURL string, o
 			baseURL+PluginsServiceGetConfiguredPluginsProcedure,
 			opts...,
 		),
+		getFeatures: connect_go.NewClient[v1alpha1.GetFeaturesRequest, v1alpha1.GetFeaturesResponse](
+			httpClient,
+			baseURL+PluginsServiceGetFeaturesProcedure,
+			opts...,
+		),
+		getPluginConfigs: connect_go.NewClient[v1alpha1.GetPluginConfigsRequest, v1alpha1.GetPluginConfigsResponse](
+			httpClient,
+			baseURL+PluginsServiceGetPluginConfigsProcedure,
+			opts...,
+		),
 	}
 }
 
 // pluginsServiceClient implements PluginsServiceClient.
 type pluginsServiceClient struct {
 	getConfiguredPlugins *connect_go.Client[v1alpha1.GetConfiguredPluginsRequest, v1alpha1.GetConfiguredPluginsResponse]
+	getFeatures          *connect_go.Client[v1alpha1.GetFeaturesRequest, v1alpha1.GetFeaturesResponse]
+	getPluginConfigs     *connect_go.Client[v1alpha1.GetPluginConfigsRequest, v1alpha1.GetPluginConfigsResponse]
 }
 
 // GetConfiguredPlugins calls
@@ -78,11 +105,30 @@ func (c *pluginsServiceClient) GetConfiguredPlugins(ctx context.Context, req *co
 	return c.getConfiguredPlugins.CallUnary(ctx, req)
 }
 
+// GetFeatures calls kubeappsapis.core.plugins.v1alpha1.PluginsService.GetFeatures.
+func (c *pluginsServiceClient) GetFeatures(ctx context.Context, req *connect_go.Request[v1alpha1.GetFeaturesRequest]) (*connect_go.Response[v1alpha1.GetFeaturesResponse], error) {
+	return c.getFeatures.CallUnary(ctx, req)
+}
+
+// GetPluginConfigs calls kubeappsapis.core.plugins.v1alpha1.PluginsService.GetPluginConfigs.
+func (c *pluginsServiceClient) GetPluginConfigs(ctx context.Context, req *connect_go.Request[v1alpha1.GetPluginConfigsRequest]) (*connect_go.Response[v1alpha1.GetPluginConfigsResponse], error) {
+	return c.getPluginConfigs.CallUnary(ctx, req)
+}
+
 // PluginsServiceHandler is an implementation of the
 // kubeappsapis.core.plugins.v1alpha1.PluginsService service.
 type PluginsServiceHandler interface {
 	// GetConfiguredPlugins returns a map of short and longnames for the configured plugins.
 	GetConfiguredPlugins(context.Context, *connect_go.Request[v1alpha1.GetConfiguredPluginsRequest]) (*connect_go.Response[v1alpha1.GetConfiguredPluginsResponse], error)
+	// GetFeatures returns a versioned descriptor of the features enabled in the
+	// backend (plugins, clusters and operations), along with its checksum and,
+	// if a signing key is configured, its signature, so that the dashboard can
+	// bootstrap with a single call and verify the configuration it renders.
+	GetFeatures(context.Context, *connect_go.Request[v1alpha1.GetFeaturesRequest]) (*connect_go.Response[v1alpha1.GetFeaturesResponse], error)
+	// GetPluginConfigs returns the configuration currently in effect for each
+	// plugin supporting the reload of its configuration at runtime, along with
+	// the result of the last reload.
+	GetPluginConfigs(context.Context, *connect_go.Request[v1alpha1.GetPluginConfigsRequest]) (*connect_go.Response[v1alpha1.GetPluginConfigsResponse], error)
 }
 
 // NewPluginsServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -96,10 +142,24 @@ func NewPluginsServiceHandler(svc PluginsServiceHandler, opts ...connect_go.Hand
 		svc.GetConfiguredPlugins,
 		opts...,
 	)
+	pluginsServiceGetFeaturesHandler := connect_go.NewUnaryHandler(
+		PluginsServiceGetFeaturesProcedure,
+		svc.GetFeatures,
+		opts...,
+	)
+	pluginsServiceGetPluginConfigsHandler := connect_go.NewUnaryHandler(
+		PluginsServiceGetPluginConfigsProcedure,
+		svc.GetPluginConfigs,
+		opts...,
+	)
 	return "/kubeappsapis.core.plugins.v1alpha1.PluginsService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case PluginsServiceGetConfiguredPluginsProcedure:
 			pluginsServiceGetConfiguredPluginsHandler.ServeHTTP(w, r)
+		case PluginsServiceGetFeaturesProcedure:
+			pluginsServiceGetFeaturesHandler.ServeHTTP(w, r)
+		case PluginsServiceGetPluginConfigsProcedure:
+			pluginsServiceGetPluginConfigsHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -112,3 +172,11 @@ type UnimplementedPluginsServiceHandler struct{}
 func (UnimplementedPluginsServiceHandler) GetConfiguredPlugins(context.Context, *connect_go.Request[v1alpha1.GetConfiguredPluginsRequest]) (*connect_go.Response[v1alpha1.GetConfiguredPluginsResponse], error) {
 	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.core.plugins.v1alpha1.PluginsService.GetConfiguredPlugins is not implemented"))
 }
+
+func (UnimplementedPluginsServiceHandler) GetFeatures(context.Context, *connect_go.Request[v1alpha1.GetFeaturesRequest]) (*connect_go.Response[v1alpha1.GetFeaturesResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.core.plugins.v1alpha1.PluginsService.GetFeatures is not implemented"))
+}
+
+func (UnimplementedPluginsServiceHandler) GetPluginConfigs(context.Context, *connect_go.Request[v1alpha1.GetPluginConfigsRequest]) (*connect_go.Response[v1alpha1.GetPluginConfigsResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.core.plugins.v1alpha1.PluginsService.GetPluginConfigs is not implemented"))
+}
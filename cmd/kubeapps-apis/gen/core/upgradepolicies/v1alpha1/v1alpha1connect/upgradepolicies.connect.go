@@ -0,0 +1,210 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: kubeappsapis/core/upgradepolicies/v1alpha1/upgradepolicies.proto
+
+package v1alpha1connect
+
+import (
+	context "context"
+	errors "errors"
+	connect_go "github.com/bufbuild/connect-go"
+	v1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/upgradepolicies/v1alpha1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect_go.IsAtLeastVersion0_1_0
+
+const (
+	// UpgradePoliciesServiceName is the fully-qualified name of the UpgradePoliciesService service.
+	UpgradePoliciesServiceName = "kubeappsapis.core.upgradepolicies.v1alpha1.UpgradePoliciesService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// UpgradePoliciesServiceGetUpgradePolicyProcedure is the fully-qualified name of the
+	// UpgradePoliciesService's GetUpgradePolicy RPC.
+	UpgradePoliciesServiceGetUpgradePolicyProcedure = "/kubeappsapis.core.upgradepolicies.v1alpha1.UpgradePoliciesService/GetUpgradePolicy"
+	// UpgradePoliciesServiceSetUpgradePolicyProcedure is the fully-qualified name of the
+	// UpgradePoliciesService's SetUpgradePolicy RPC.
+	UpgradePoliciesServiceSetUpgradePolicyProcedure = "/kubeappsapis.core.upgradepolicies.v1alpha1.UpgradePoliciesService/SetUpgradePolicy"
+	// UpgradePoliciesServiceDeleteUpgradePolicyProcedure is the fully-qualified name of the
+	// UpgradePoliciesService's DeleteUpgradePolicy RPC.
+	UpgradePoliciesServiceDeleteUpgradePolicyProcedure = "/kubeappsapis.core.upgradepolicies.v1alpha1.UpgradePoliciesService/DeleteUpgradePolicy"
+	// UpgradePoliciesServiceGetUpgradeHistoryProcedure is the fully-qualified name of the
+	// UpgradePoliciesService's GetUpgradeHistory RPC.
+	UpgradePoliciesServiceGetUpgradeHistoryProcedure = "/kubeappsapis.core.upgradepolicies.v1alpha1.UpgradePoliciesService/GetUpgradeHistory"
+)
+
+// UpgradePoliciesServiceClient is a client for the
+// kubeappsapis.core.upgradepolicies.v1alpha1.UpgradePoliciesService service.
+type UpgradePoliciesServiceClient interface {
+	// GetUpgradePolicy returns the upgrade policy of an installed package.
+	GetUpgradePolicy(context.Context, *connect_go.Request[v1alpha1.GetUpgradePolicyRequest]) (*connect_go.Response[v1alpha1.GetUpgradePolicyResponse], error)
+	// SetUpgradePolicy creates or replaces the upgrade policy of an installed
+	// package.
+	SetUpgradePolicy(context.Context, *connect_go.Request[v1alpha1.SetUpgradePolicyRequest]) (*connect_go.Response[v1alpha1.SetUpgradePolicyResponse], error)
+	// DeleteUpgradePolicy deletes the upgrade policy of an installed package,
+	// along with its upgrade history.
+	DeleteUpgradePolicy(context.Context, *connect_go.Request[v1alpha1.DeleteUpgradePolicyRequest]) (*connect_go.Response[v1alpha1.DeleteUpgradePolicyResponse], error)
+	// GetUpgradeHistory returns the upgrades attempted within the windows of
+	// the upgrade policy of an installed package.
+	GetUpgradeHistory(context.Context, *connect_go.Request[v1alpha1.GetUpgradeHistoryRequest]) (*connect_go.Response[v1alpha1.GetUpgradeHistoryResponse], error)
+}
+
+// NewUpgradePoliciesServiceClient constructs a client for the
+// kubeappsapis.core.upgradepolicies.v1alpha1.UpgradePoliciesService service. By default, it uses
+// the Connect protocol with the binary Protobuf Codec, asks for gzipped responses, and sends
+// uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC() or
+// connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewUpgradePoliciesServiceClient(httpClient connect_go.HTTPClient, baseURL string, opts ...connect_go.ClientOption) UpgradePoliciesServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	return &upgradePoliciesServiceClient{
+		getUpgradePolicy: connect_go.NewClient[v1alpha1.GetUpgradePolicyRequest, v1alpha1.GetUpgradePolicyResponse](
+			httpClient,
+			baseURL+UpgradePoliciesServiceGetUpgradePolicyProcedure,
+			opts...,
+		),
+		setUpgradePolicy: connect_go.NewClient[v1alpha1.SetUpgradePolicyRequest, v1alpha1.SetUpgradePolicyResponse](
+			httpClient,
+			baseURL+UpgradePoliciesServiceSetUpgradePolicyProcedure,
+			opts...,
+		),
+		deleteUpgradePolicy: connect_go.NewClient[v1alpha1.DeleteUpgradePolicyRequest, v1alpha1.DeleteUpgradePolicyResponse](
+			httpClient,
+			baseURL+UpgradePoliciesServiceDeleteUpgradePolicyProcedure,
+			opts...,
+		),
+		getUpgradeHistory: connect_go.NewClient[v1alpha1.GetUpgradeHistoryRequest, v1alpha1.GetUpgradeHistoryResponse](
+			httpClient,
+			baseURL+UpgradePoliciesServiceGetUpgradeHistoryProcedure,
+			opts...,
+		),
+	}
+}
+
+// upgradePoliciesServiceClient implements UpgradePoliciesServiceClient.
+type upgradePoliciesServiceClient struct {
+	getUpgradePolicy    *connect_go.Client[v1alpha1.GetUpgradePolicyRequest, v1alpha1.GetUpgradePolicyResponse]
+	setUpgradePolicy    *connect_go.Client[v1alpha1.SetUpgradePolicyRequest, v1alpha1.SetUpgradePolicyResponse]
+	deleteUpgradePolicy *connect_go.Client[v1alpha1.DeleteUpgradePolicyRequest, v1alpha1.DeleteUpgradePolicyResponse]
+	getUpgradeHistory   *connect_go.Client[v1alpha1.GetUpgradeHistoryRequest, v1alpha1.GetUpgradeHistoryResponse]
+}
+
+// GetUpgradePolicy calls
+// kubeappsapis.core.upgradepolicies.v1alpha1.UpgradePoliciesService.GetUpgradePolicy.
+func (c *upgradePoliciesServiceClient) GetUpgradePolicy(ctx context.Context, req *connect_go.Request[v1alpha1.GetUpgradePolicyRequest]) (*connect_go.Response[v1alpha1.GetUpgradePolicyResponse], error) {
+	return c.getUpgradePolicy.CallUnary(ctx, req)
+}
+
+// SetUpgradePolicy calls
+// kubeappsapis.core.upgradepolicies.v1alpha1.UpgradePoliciesService.SetUpgradePolicy.
+func (c *upgradePoliciesServiceClient) SetUpgradePolicy(ctx context.Context, req *connect_go.Request[v1alpha1.SetUpgradePolicyRequest]) (*connect_go.Response[v1alpha1.SetUpgradePolicyResponse], error) {
+	return c.setUpgradePolicy.CallUnary(ctx, req)
+}
+
+// DeleteUpgradePolicy calls
+// kubeappsapis.core.upgradepolicies.v1alpha1.UpgradePoliciesService.DeleteUpgradePolicy.
+func (c *upgradePoliciesServiceClient) DeleteUpgradePolicy(ctx context.Context, req *connect_go.Request[v1alpha1.DeleteUpgradePolicyRequest]) (*connect_go.Response[v1alpha1.DeleteUpgradePolicyResponse], error) {
+	return c.deleteUpgradePolicy.CallUnary(ctx, req)
+}
+
+// GetUpgradeHistory calls
+// kubeappsapis.core.upgradepolicies.v1alpha1.UpgradePoliciesService.GetUpgradeHistory.
+func (c *upgradePoliciesServiceClient) GetUpgradeHistory(ctx context.Context, req *connect_go.Request[v1alpha1.GetUpgradeHistoryRequest]) (*connect_go.Response[v1alpha1.GetUpgradeHistoryResponse], error) {
+	return c.getUpgradeHistory.CallUnary(ctx, req)
+}
+
+// UpgradePoliciesServiceHandler is an implementation of the
+// kubeappsapis.core.upgradepolicies.v1alpha1.UpgradePoliciesService service.
+type UpgradePoliciesServiceHandler interface {
+	// GetUpgradePolicy returns the upgrade policy of an installed package.
+	GetUpgradePolicy(context.Context, *connect_go.Request[v1alpha1.GetUpgradePolicyRequest]) (*connect_go.Response[v1alpha1.GetUpgradePolicyResponse], error)
+	// SetUpgradePolicy creates or replaces the upgrade policy of an installed
+	// package.
+	SetUpgradePolicy(context.Context, *connect_go.Request[v1alpha1.SetUpgradePolicyRequest]) (*connect_go.Response[v1alpha1.SetUpgradePolicyResponse], error)
+	// DeleteUpgradePolicy deletes the upgrade policy of an installed package,
+	// along with its upgrade history.
+	DeleteUpgradePolicy(context.Context, *connect_go.Request[v1alpha1.DeleteUpgradePolicyRequest]) (*connect_go.Response[v1alpha1.DeleteUpgradePolicyResponse], error)
+	// GetUpgradeHistory returns the upgrades attempted within the windows of
+	// the upgrade policy of an installed package.
+	GetUpgradeHistory(context.Context, *connect_go.Request[v1alpha1.GetUpgradeHistoryRequest]) (*connect_go.Response[v1alpha1.GetUpgradeHistoryResponse], error)
+}
+
+// NewUpgradePoliciesServiceHandler builds an HTTP handler from the service implementation. It
+// returns the path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewUpgradePoliciesServiceHandler(svc UpgradePoliciesServiceHandler, opts ...connect_go.HandlerOption) (string, http.Handler) {
+	upgradePoliciesServiceGetUpgradePolicyHandler := connect_go.NewUnaryHandler(
+		UpgradePoliciesServiceGetUpgradePolicyProcedure,
+		svc.GetUpgradePolicy,
+		opts...,
+	)
+	upgradePoliciesServiceSetUpgradePolicyHandler := connect_go.NewUnaryHandler(
+		UpgradePoliciesServiceSetUpgradePolicyProcedure,
+		svc.SetUpgradePolicy,
+		opts...,
+	)
+	upgradePoliciesServiceDeleteUpgradePolicyHandler := connect_go.NewUnaryHandler(
+		UpgradePoliciesServiceDeleteUpgradePolicyProcedure,
+		svc.DeleteUpgradePolicy,
+		opts...,
+	)
+	upgradePoliciesServiceGetUpgradeHistoryHandler := connect_go.NewUnaryHandler(
+		UpgradePoliciesServiceGetUpgradeHistoryProcedure,
+		svc.GetUpgradeHistory,
+		opts...,
+	)
+	return "/kubeappsapis.core.upgradepolicies.v1alpha1.UpgradePoliciesService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case UpgradePoliciesServiceGetUpgradePolicyProcedure:
+			upgradePoliciesServiceGetUpgradePolicyHandler.ServeHTTP(w, r)
+		case UpgradePoliciesServiceSetUpgradePolicyProcedure:
+			upgradePoliciesServiceSetUpgradePolicyHandler.ServeHTTP(w, r)
+		case UpgradePoliciesServiceDeleteUpgradePolicyProcedure:
+			upgradePoliciesServiceDeleteUpgradePolicyHandler.ServeHTTP(w, r)
+		case UpgradePoliciesServiceGetUpgradeHistoryProcedure:
+			upgradePoliciesServiceGetUpgradeHistoryHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedUpgradePoliciesServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedUpgradePoliciesServiceHandler struct{}
+
+func (UnimplementedUpgradePoliciesServiceHandler) GetUpgradePolicy(context.Context, *connect_go.Request[v1alpha1.GetUpgradePolicyRequest]) (*connect_go.Response[v1alpha1.GetUpgradePolicyResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.core.upgradepolicies.v1alpha1.UpgradePoliciesService.GetUpgradePolicy is not implemented"))
+}
+
+func (UnimplementedUpgradePoliciesServiceHandler) SetUpgradePolicy(context.Context, *connect_go.Request[v1alpha1.SetUpgradePolicyRequest]) (*connect_go.Response[v1alpha1.SetUpgradePolicyResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.core.upgradepolicies.v1alpha1.UpgradePoliciesService.SetUpgradePolicy is not implemented"))
+}
+
+func (UnimplementedUpgradePoliciesServiceHandler) DeleteUpgradePolicy(context.Context, *connect_go.Request[v1alpha1.DeleteUpgradePolicyRequest]) (*connect_go.Response[v1alpha1.DeleteUpgradePolicyResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.core.upgradepolicies.v1alpha1.UpgradePoliciesService.DeleteUpgradePolicy is not implemented"))
+}
+
+func (UnimplementedUpgradePoliciesServiceHandler) GetUpgradeHistory(context.Context, *connect_go.Request[v1alpha1.GetUpgradeHistoryRequest]) (*connect_go.Response[v1alpha1.GetUpgradeHistoryResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.core.upgradepolicies.v1alpha1.UpgradePoliciesService.GetUpgradeHistory is not implemented"))
+}
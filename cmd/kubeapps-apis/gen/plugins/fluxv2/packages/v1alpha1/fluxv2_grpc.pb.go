@@ -23,15 +23,21 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	FluxV2PackagesService_GetAvailablePackageSummaries_FullMethodName    = "/kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2PackagesService/GetAvailablePackageSummaries"
-	FluxV2PackagesService_GetAvailablePackageDetail_FullMethodName       = "/kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2PackagesService/GetAvailablePackageDetail"
-	FluxV2PackagesService_GetAvailablePackageVersions_FullMethodName     = "/kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2PackagesService/GetAvailablePackageVersions"
-	FluxV2PackagesService_GetInstalledPackageSummaries_FullMethodName    = "/kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2PackagesService/GetInstalledPackageSummaries"
-	FluxV2PackagesService_GetInstalledPackageDetail_FullMethodName       = "/kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2PackagesService/GetInstalledPackageDetail"
-	FluxV2PackagesService_CreateInstalledPackage_FullMethodName          = "/kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2PackagesService/CreateInstalledPackage"
-	FluxV2PackagesService_UpdateInstalledPackage_FullMethodName          = "/kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2PackagesService/UpdateInstalledPackage"
-	FluxV2PackagesService_DeleteInstalledPackage_FullMethodName          = "/kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2PackagesService/DeleteInstalledPackage"
-	FluxV2PackagesService_GetInstalledPackageResourceRefs_FullMethodName = "/kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2PackagesService/GetInstalledPackageResourceRefs"
+	FluxV2PackagesService_GetAvailablePackageSummaries_FullMethodName          = "/kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2PackagesService/GetAvailablePackageSummaries"
+	FluxV2PackagesService_GetAvailablePackageDetail_FullMethodName             = "/kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2PackagesService/GetAvailablePackageDetail"
+	FluxV2PackagesService_GetAvailablePackageVersions_FullMethodName           = "/kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2PackagesService/GetAvailablePackageVersions"
+	FluxV2PackagesService_GetAvailablePackageTextAsset_FullMethodName          = "/kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2PackagesService/GetAvailablePackageTextAsset"
+	FluxV2PackagesService_GetAvailablePackageChangelog_FullMethodName          = "/kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2PackagesService/GetAvailablePackageChangelog"
+	FluxV2PackagesService_GetAvailablePackageFilters_FullMethodName            = "/kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2PackagesService/GetAvailablePackageFilters"
+	FluxV2PackagesService_GetInstalledPackageSummaries_FullMethodName          = "/kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2PackagesService/GetInstalledPackageSummaries"
+	FluxV2PackagesService_GetInstalledPackageDetail_FullMethodName             = "/kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2PackagesService/GetInstalledPackageDetail"
+	FluxV2PackagesService_CheckInstalledPackageNameAvailability_FullMethodName = "/kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2PackagesService/CheckInstalledPackageNameAvailability"
+	FluxV2PackagesService_CreateInstalledPackage_FullMethodName                = "/kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2PackagesService/CreateInstalledPackage"
+	FluxV2PackagesService_UpdateInstalledPackage_FullMethodName                = "/kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2PackagesService/UpdateInstalledPackage"
+	FluxV2PackagesService_DeleteInstalledPackage_FullMethodName                = "/kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2PackagesService/DeleteInstalledPackage"
+	FluxV2PackagesService_GetInstalledPackageResourceRefs_FullMethodName       = "/kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2PackagesService/GetInstalledPackageResourceRefs"
+	FluxV2PackagesService_GetInstalledPackageOperationStatus_FullMethodName    = "/kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2PackagesService/GetInstalledPackageOperationStatus"
+	FluxV2PackagesService_ConvertInstalledPackage_FullMethodName               = "/kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2PackagesService/ConvertInstalledPackage"
 )
 
 // FluxV2PackagesServiceClient is the client API for FluxV2PackagesService service.
@@ -44,10 +50,18 @@ type FluxV2PackagesServiceClient interface {
 	GetAvailablePackageDetail(ctx context.Context, in *v1alpha1.GetAvailablePackageDetailRequest, opts ...grpc.CallOption) (*v1alpha1.GetAvailablePackageDetailResponse, error)
 	// GetAvailablePackageVersions returns the package versions managed by the 'fluxv2' plugin
 	GetAvailablePackageVersions(ctx context.Context, in *v1alpha1.GetAvailablePackageVersionsRequest, opts ...grpc.CallOption) (*v1alpha1.GetAvailablePackageVersionsResponse, error)
+	// GetAvailablePackageTextAsset returns a range of a text asset of a package managed by the 'fluxv2' plugin
+	GetAvailablePackageTextAsset(ctx context.Context, in *v1alpha1.GetAvailablePackageTextAssetRequest, opts ...grpc.CallOption) (*v1alpha1.GetAvailablePackageTextAssetResponse, error)
+	// GetAvailablePackageChangelog returns the release notes between two versions of a package managed by the 'fluxv2' plugin
+	GetAvailablePackageChangelog(ctx context.Context, in *v1alpha1.GetAvailablePackageChangelogRequest, opts ...grpc.CallOption) (*v1alpha1.GetAvailablePackageChangelogResponse, error)
+	// GetAvailablePackageFilters returns the filter values of the available packages managed by the 'fluxv2' plugin
+	GetAvailablePackageFilters(ctx context.Context, in *v1alpha1.GetAvailablePackageFiltersRequest, opts ...grpc.CallOption) (*v1alpha1.GetAvailablePackageFiltersResponse, error)
 	// GetInstalledPackageSummaries returns the installed packages managed by the 'fluxv2' plugin
 	GetInstalledPackageSummaries(ctx context.Context, in *v1alpha1.GetInstalledPackageSummariesRequest, opts ...grpc.CallOption) (*v1alpha1.GetInstalledPackageSummariesResponse, error)
 	// GetInstalledPackageDetail returns the requested installed package managed by the 'fluxv2' plugin
 	GetInstalledPackageDetail(ctx context.Context, in *v1alpha1.GetInstalledPackageDetailRequest, opts ...grpc.CallOption) (*v1alpha1.GetInstalledPackageDetailResponse, error)
+	// CheckInstalledPackageNameAvailability checks whether a package managed by the 'fluxv2' plugin can be installed with the given name
+	CheckInstalledPackageNameAvailability(ctx context.Context, in *v1alpha1.CheckInstalledPackageNameAvailabilityRequest, opts ...grpc.CallOption) (*v1alpha1.CheckInstalledPackageNameAvailabilityResponse, error)
 	// CreateInstalledPackage creates an installed package based on the request.
 	CreateInstalledPackage(ctx context.Context, in *v1alpha1.CreateInstalledPackageRequest, opts ...grpc.CallOption) (*v1alpha1.CreateInstalledPackageResponse, error)
 	// UpdateInstalledPackage updates an installed package based on the request.
@@ -57,6 +71,11 @@ type FluxV2PackagesServiceClient interface {
 	// GetInstalledPackageResourceRefs returns the references for the Kubernetes
 	// resources created by an installed package.
 	GetInstalledPackageResourceRefs(ctx context.Context, in *v1alpha1.GetInstalledPackageResourceRefsRequest, opts ...grpc.CallOption) (*v1alpha1.GetInstalledPackageResourceRefsResponse, error)
+	// GetInstalledPackageOperationStatus returns the progress of the operation in course on an installed package managed by the 'fluxv2' plugin
+	GetInstalledPackageOperationStatus(ctx context.Context, in *v1alpha1.GetInstalledPackageOperationStatusRequest, opts ...grpc.CallOption) (*v1alpha1.GetInstalledPackageOperationStatusResponse, error)
+	// ConvertInstalledPackage converts a Helm release installed by the 'helm' plugin into a
+	// flux HelmRelease managed by the 'fluxv2' plugin, without reinstalling it.
+	ConvertInstalledPackage(ctx context.Context, in *ConvertInstalledPackageRequest, opts ...grpc.CallOption) (*ConvertInstalledPackageResponse, error)
 }
 
 type fluxV2PackagesServiceClient struct {
@@ -94,6 +113,33 @@ func (c *fluxV2PackagesServiceClient) GetAvailablePackageVersions(ctx context.Co
 	return out, nil
 }
 
+func (c *fluxV2PackagesServiceClient) GetAvailablePackageTextAsset(ctx context.Context, in *v1alpha1.GetAvailablePackageTextAssetRequest, opts ...grpc.CallOption) (*v1alpha1.GetAvailablePackageTextAssetResponse, error) {
+	out := new(v1alpha1.GetAvailablePackageTextAssetResponse)
+	err := c.cc.Invoke(ctx, FluxV2PackagesService_GetAvailablePackageTextAsset_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fluxV2PackagesServiceClient) GetAvailablePackageChangelog(ctx context.Context, in *v1alpha1.GetAvailablePackageChangelogRequest, opts ...grpc.CallOption) (*v1alpha1.GetAvailablePackageChangelogResponse, error) {
+	out := new(v1alpha1.GetAvailablePackageChangelogResponse)
+	err := c.cc.Invoke(ctx, FluxV2PackagesService_GetAvailablePackageChangelog_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fluxV2PackagesServiceClient) GetAvailablePackageFilters(ctx context.Context, in *v1alpha1.GetAvailablePackageFiltersRequest, opts ...grpc.CallOption) (*v1alpha1.GetAvailablePackageFiltersResponse, error) {
+	out := new(v1alpha1.GetAvailablePackageFiltersResponse)
+	err := c.cc.Invoke(ctx, FluxV2PackagesService_GetAvailablePackageFilters_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *fluxV2PackagesServiceClient) GetInstalledPackageSummaries(ctx context.Context, in *v1alpha1.GetInstalledPackageSummariesRequest, opts ...grpc.CallOption) (*v1alpha1.GetInstalledPackageSummariesResponse, error) {
 	out := new(v1alpha1.GetInstalledPackageSummariesResponse)
 	err := c.cc.Invoke(ctx, FluxV2PackagesService_GetInstalledPackageSummaries_FullMethodName, in, out, opts...)
@@ -112,6 +158,15 @@ func (c *fluxV2PackagesServiceClient) GetInstalledPackageDetail(ctx context.Cont
 	return out, nil
 }
 
+func (c *fluxV2PackagesServiceClient) CheckInstalledPackageNameAvailability(ctx context.Context, in *v1alpha1.CheckInstalledPackageNameAvailabilityRequest, opts ...grpc.CallOption) (*v1alpha1.CheckInstalledPackageNameAvailabilityResponse, error) {
+	out := new(v1alpha1.CheckInstalledPackageNameAvailabilityResponse)
+	err := c.cc.Invoke(ctx, FluxV2PackagesService_CheckInstalledPackageNameAvailability_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *fluxV2PackagesServiceClient) CreateInstalledPackage(ctx context.Context, in *v1alpha1.CreateInstalledPackageRequest, opts ...grpc.CallOption) (*v1alpha1.CreateInstalledPackageResponse, error) {
 	out := new(v1alpha1.CreateInstalledPackageResponse)
 	err := c.cc.Invoke(ctx, FluxV2PackagesService_CreateInstalledPackage_FullMethodName, in, out, opts...)
@@ -148,6 +203,24 @@ func (c *fluxV2PackagesServiceClient) GetInstalledPackageResourceRefs(ctx contex
 	return out, nil
 }
 
+func (c *fluxV2PackagesServiceClient) GetInstalledPackageOperationStatus(ctx context.Context, in *v1alpha1.GetInstalledPackageOperationStatusRequest, opts ...grpc.CallOption) (*v1alpha1.GetInstalledPackageOperationStatusResponse, error) {
+	out := new(v1alpha1.GetInstalledPackageOperationStatusResponse)
+	err := c.cc.Invoke(ctx, FluxV2PackagesService_GetInstalledPackageOperationStatus_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fluxV2PackagesServiceClient) ConvertInstalledPackage(ctx context.Context, in *ConvertInstalledPackageRequest, opts ...grpc.CallOption) (*ConvertInstalledPackageResponse, error) {
+	out := new(ConvertInstalledPackageResponse)
+	err := c.cc.Invoke(ctx, FluxV2PackagesService_ConvertInstalledPackage_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // FluxV2PackagesServiceServer is the server API for FluxV2PackagesService service.
 // All implementations should embed UnimplementedFluxV2PackagesServiceServer
 // for forward compatibility
@@ -158,10 +231,18 @@ type FluxV2PackagesServiceServer interface {
 	GetAvailablePackageDetail(context.Context, *v1alpha1.GetAvailablePackageDetailRequest) (*v1alpha1.GetAvailablePackageDetailResponse, error)
 	// GetAvailablePackageVersions returns the package versions managed by the 'fluxv2' plugin
 	GetAvailablePackageVersions(context.Context, *v1alpha1.GetAvailablePackageVersionsRequest) (*v1alpha1.GetAvailablePackageVersionsResponse, error)
+	// GetAvailablePackageTextAsset returns a range of a text asset of a package managed by the 'fluxv2' plugin
+	GetAvailablePackageTextAsset(context.Context, *v1alpha1.GetAvailablePackageTextAssetRequest) (*v1alpha1.GetAvailablePackageTextAssetResponse, error)
+	// GetAvailablePackageChangelog returns the release notes between two versions of a package managed by the 'fluxv2' plugin
+	GetAvailablePackageChangelog(context.Context, *v1alpha1.GetAvailablePackageChangelogRequest) (*v1alpha1.GetAvailablePackageChangelogResponse, error)
+	// GetAvailablePackageFilters returns the filter values of the available packages managed by the 'fluxv2' plugin
+	GetAvailablePackageFilters(context.Context, *v1alpha1.GetAvailablePackageFiltersRequest) (*v1alpha1.GetAvailablePackageFiltersResponse, error)
 	// GetInstalledPackageSummaries returns the installed packages managed by the 'fluxv2' plugin
 	GetInstalledPackageSummaries(context.Context, *v1alpha1.GetInstalledPackageSummariesRequest) (*v1alpha1.GetInstalledPackageSummariesResponse, error)
 	// GetInstalledPackageDetail returns the requested installed package managed by the 'fluxv2' plugin
 	GetInstalledPackageDetail(context.Context, *v1alpha1.GetInstalledPackageDetailRequest) (*v1alpha1.GetInstalledPackageDetailResponse, error)
+	// CheckInstalledPackageNameAvailability checks whether a package managed by the 'fluxv2' plugin can be installed with the given name
+	CheckInstalledPackageNameAvailability(context.Context, *v1alpha1.CheckInstalledPackageNameAvailabilityRequest) (*v1alpha1.CheckInstalledPackageNameAvailabilityResponse, error)
 	// CreateInstalledPackage creates an installed package based on the request.
 	CreateInstalledPackage(context.Context, *v1alpha1.CreateInstalledPackageRequest) (*v1alpha1.CreateInstalledPackageResponse, error)
 	// UpdateInstalledPackage updates an installed package based on the request.
@@ -171,6 +252,11 @@ type FluxV2PackagesServiceServer interface {
 	// GetInstalledPackageResourceRefs returns the references for the Kubernetes
 	// resources created by an installed package.
 	GetInstalledPackageResourceRefs(context.Context, *v1alpha1.GetInstalledPackageResourceRefsRequest) (*v1alpha1.GetInstalledPackageResourceRefsResponse, error)
+	// GetInstalledPackageOperationStatus returns the progress of the operation in course on an installed package managed by the 'fluxv2' plugin
+	GetInstalledPackageOperationStatus(context.Context, *v1alpha1.GetInstalledPackageOperationStatusRequest) (*v1alpha1.GetInstalledPackageOperationStatusResponse, error)
+	// ConvertInstalledPackage converts a Helm release installed by the 'helm' plugin into a
+	// flux HelmRelease managed by the 'fluxv2' plugin, without reinstalling it.
+	ConvertInstalledPackage(context.Context, *ConvertInstalledPackageRequest) (*ConvertInstalledPackageResponse, error)
 }
 
 // UnimplementedFluxV2PackagesServiceServer should be embedded to have forward compatible implementations.
@@ -186,12 +272,24 @@ func (UnimplementedFluxV2PackagesServiceServer) GetAvailablePackageDetail(contex
 func (UnimplementedFluxV2PackagesServiceServer) GetAvailablePackageVersions(context.Context, *v1alpha1.GetAvailablePackageVersionsRequest) (*v1alpha1.GetAvailablePackageVersionsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAvailablePackageVersions not implemented")
 }
+func (UnimplementedFluxV2PackagesServiceServer) GetAvailablePackageTextAsset(context.Context, *v1alpha1.GetAvailablePackageTextAssetRequest) (*v1alpha1.GetAvailablePackageTextAssetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAvailablePackageTextAsset not implemented")
+}
+func (UnimplementedFluxV2PackagesServiceServer) GetAvailablePackageChangelog(context.Context, *v1alpha1.GetAvailablePackageChangelogRequest) (*v1alpha1.GetAvailablePackageChangelogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAvailablePackageChangelog not implemented")
+}
+func (UnimplementedFluxV2PackagesServiceServer) GetAvailablePackageFilters(context.Context, *v1alpha1.GetAvailablePackageFiltersRequest) (*v1alpha1.GetAvailablePackageFiltersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAvailablePackageFilters not implemented")
+}
 func (UnimplementedFluxV2PackagesServiceServer) GetInstalledPackageSummaries(context.Context, *v1alpha1.GetInstalledPackageSummariesRequest) (*v1alpha1.GetInstalledPackageSummariesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetInstalledPackageSummaries not implemented")
 }
 func (UnimplementedFluxV2PackagesServiceServer) GetInstalledPackageDetail(context.Context, *v1alpha1.GetInstalledPackageDetailRequest) (*v1alpha1.GetInstalledPackageDetailResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetInstalledPackageDetail not implemented")
 }
+func (UnimplementedFluxV2PackagesServiceServer) CheckInstalledPackageNameAvailability(context.Context, *v1alpha1.CheckInstalledPackageNameAvailabilityRequest) (*v1alpha1.CheckInstalledPackageNameAvailabilityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckInstalledPackageNameAvailability not implemented")
+}
 func (UnimplementedFluxV2PackagesServiceServer) CreateInstalledPackage(context.Context, *v1alpha1.CreateInstalledPackageRequest) (*v1alpha1.CreateInstalledPackageResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateInstalledPackage not implemented")
 }
@@ -204,6 +302,12 @@ func (UnimplementedFluxV2PackagesServiceServer) DeleteInstalledPackage(context.C
 func (UnimplementedFluxV2PackagesServiceServer) GetInstalledPackageResourceRefs(context.Context, *v1alpha1.GetInstalledPackageResourceRefsRequest) (*v1alpha1.GetInstalledPackageResourceRefsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetInstalledPackageResourceRefs not implemented")
 }
+func (UnimplementedFluxV2PackagesServiceServer) GetInstalledPackageOperationStatus(context.Context, *v1alpha1.GetInstalledPackageOperationStatusRequest) (*v1alpha1.GetInstalledPackageOperationStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInstalledPackageOperationStatus not implemented")
+}
+func (UnimplementedFluxV2PackagesServiceServer) ConvertInstalledPackage(context.Context, *ConvertInstalledPackageRequest) (*ConvertInstalledPackageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConvertInstalledPackage not implemented")
+}
 
 // UnsafeFluxV2PackagesServiceServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to FluxV2PackagesServiceServer will
@@ -270,6 +374,60 @@ func _FluxV2PackagesService_GetAvailablePackageVersions_Handler(srv interface{},
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FluxV2PackagesService_GetAvailablePackageTextAsset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha1.GetAvailablePackageTextAssetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FluxV2PackagesServiceServer).GetAvailablePackageTextAsset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FluxV2PackagesService_GetAvailablePackageTextAsset_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FluxV2PackagesServiceServer).GetAvailablePackageTextAsset(ctx, req.(*v1alpha1.GetAvailablePackageTextAssetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FluxV2PackagesService_GetAvailablePackageChangelog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha1.GetAvailablePackageChangelogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FluxV2PackagesServiceServer).GetAvailablePackageChangelog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FluxV2PackagesService_GetAvailablePackageChangelog_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FluxV2PackagesServiceServer).GetAvailablePackageChangelog(ctx, req.(*v1alpha1.GetAvailablePackageChangelogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FluxV2PackagesService_GetAvailablePackageFilters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha1.GetAvailablePackageFiltersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FluxV2PackagesServiceServer).GetAvailablePackageFilters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FluxV2PackagesService_GetAvailablePackageFilters_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FluxV2PackagesServiceServer).GetAvailablePackageFilters(ctx, req.(*v1alpha1.GetAvailablePackageFiltersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _FluxV2PackagesService_GetInstalledPackageSummaries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(v1alpha1.GetInstalledPackageSummariesRequest)
 	if err := dec(in); err != nil {
@@ -306,6 +464,24 @@ func _FluxV2PackagesService_GetInstalledPackageDetail_Handler(srv interface{}, c
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FluxV2PackagesService_CheckInstalledPackageNameAvailability_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha1.CheckInstalledPackageNameAvailabilityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FluxV2PackagesServiceServer).CheckInstalledPackageNameAvailability(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FluxV2PackagesService_CheckInstalledPackageNameAvailability_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FluxV2PackagesServiceServer).CheckInstalledPackageNameAvailability(ctx, req.(*v1alpha1.CheckInstalledPackageNameAvailabilityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _FluxV2PackagesService_CreateInstalledPackage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(v1alpha1.CreateInstalledPackageRequest)
 	if err := dec(in); err != nil {
@@ -378,6 +554,42 @@ func _FluxV2PackagesService_GetInstalledPackageResourceRefs_Handler(srv interfac
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FluxV2PackagesService_GetInstalledPackageOperationStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha1.GetInstalledPackageOperationStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FluxV2PackagesServiceServer).GetInstalledPackageOperationStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FluxV2PackagesService_GetInstalledPackageOperationStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FluxV2PackagesServiceServer).GetInstalledPackageOperationStatus(ctx, req.(*v1alpha1.GetInstalledPackageOperationStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FluxV2PackagesService_ConvertInstalledPackage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConvertInstalledPackageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FluxV2PackagesServiceServer).ConvertInstalledPackage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FluxV2PackagesService_ConvertInstalledPackage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FluxV2PackagesServiceServer).ConvertInstalledPackage(ctx, req.(*ConvertInstalledPackageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // FluxV2PackagesService_ServiceDesc is the grpc.ServiceDesc for FluxV2PackagesService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -397,6 +609,18 @@ var FluxV2PackagesService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetAvailablePackageVersions",
 			Handler:    _FluxV2PackagesService_GetAvailablePackageVersions_Handler,
 		},
+		{
+			MethodName: "GetAvailablePackageTextAsset",
+			Handler:    _FluxV2PackagesService_GetAvailablePackageTextAsset_Handler,
+		},
+		{
+			MethodName: "GetAvailablePackageChangelog",
+			Handler:    _FluxV2PackagesService_GetAvailablePackageChangelog_Handler,
+		},
+		{
+			MethodName: "GetAvailablePackageFilters",
+			Handler:    _FluxV2PackagesService_GetAvailablePackageFilters_Handler,
+		},
 		{
 			MethodName: "GetInstalledPackageSummaries",
 			Handler:    _FluxV2PackagesService_GetInstalledPackageSummaries_Handler,
@@ -405,6 +629,10 @@ var FluxV2PackagesService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetInstalledPackageDetail",
 			Handler:    _FluxV2PackagesService_GetInstalledPackageDetail_Handler,
 		},
+		{
+			MethodName: "CheckInstalledPackageNameAvailability",
+			Handler:    _FluxV2PackagesService_CheckInstalledPackageNameAvailability_Handler,
+		},
 		{
 			MethodName: "CreateInstalledPackage",
 			Handler:    _FluxV2PackagesService_CreateInstalledPackage_Handler,
@@ -421,6 +649,14 @@ var FluxV2PackagesService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetInstalledPackageResourceRefs",
 			Handler:    _FluxV2PackagesService_GetInstalledPackageResourceRefs_Handler,
 		},
+		{
+			MethodName: "GetInstalledPackageOperationStatus",
+			Handler:    _FluxV2PackagesService_GetInstalledPackageOperationStatus_Handler,
+		},
+		{
+			MethodName: "ConvertInstalledPackage",
+			Handler:    _FluxV2PackagesService_ConvertInstalledPackage_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "kubeappsapis/plugins/fluxv2/packages/v1alpha1/fluxv2.proto",
@@ -433,6 +669,8 @@ const (
 	FluxV2RepositoriesService_UpdatePackageRepository_FullMethodName         = "/kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2RepositoriesService/UpdatePackageRepository"
 	FluxV2RepositoriesService_DeletePackageRepository_FullMethodName         = "/kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2RepositoriesService/DeletePackageRepository"
 	FluxV2RepositoriesService_GetPackageRepositoryPermissions_FullMethodName = "/kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2RepositoriesService/GetPackageRepositoryPermissions"
+	FluxV2RepositoriesService_TestPackageRepositoryConnection_FullMethodName = "/kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2RepositoriesService/TestPackageRepositoryConnection"
+	FluxV2RepositoriesService_ListPackageRepositoryPackages_FullMethodName   = "/kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2RepositoriesService/ListPackageRepositoryPackages"
 )
 
 // FluxV2RepositoriesServiceClient is the client API for FluxV2RepositoriesService service.
@@ -447,6 +685,8 @@ type FluxV2RepositoriesServiceClient interface {
 	UpdatePackageRepository(ctx context.Context, in *v1alpha1.UpdatePackageRepositoryRequest, opts ...grpc.CallOption) (*v1alpha1.UpdatePackageRepositoryResponse, error)
 	DeletePackageRepository(ctx context.Context, in *v1alpha1.DeletePackageRepositoryRequest, opts ...grpc.CallOption) (*v1alpha1.DeletePackageRepositoryResponse, error)
 	GetPackageRepositoryPermissions(ctx context.Context, in *v1alpha1.GetPackageRepositoryPermissionsRequest, opts ...grpc.CallOption) (*v1alpha1.GetPackageRepositoryPermissionsResponse, error)
+	TestPackageRepositoryConnection(ctx context.Context, in *v1alpha1.TestPackageRepositoryConnectionRequest, opts ...grpc.CallOption) (*v1alpha1.TestPackageRepositoryConnectionResponse, error)
+	ListPackageRepositoryPackages(ctx context.Context, in *v1alpha1.ListPackageRepositoryPackagesRequest, opts ...grpc.CallOption) (*v1alpha1.ListPackageRepositoryPackagesResponse, error)
 }
 
 type fluxV2RepositoriesServiceClient struct {
@@ -511,6 +751,24 @@ func (c *fluxV2RepositoriesServiceClient) GetPackageRepositoryPermissions(ctx co
 	return out, nil
 }
 
+func (c *fluxV2RepositoriesServiceClient) TestPackageRepositoryConnection(ctx context.Context, in *v1alpha1.TestPackageRepositoryConnectionRequest, opts ...grpc.CallOption) (*v1alpha1.TestPackageRepositoryConnectionResponse, error) {
+	out := new(v1alpha1.TestPackageRepositoryConnectionResponse)
+	err := c.cc.Invoke(ctx, FluxV2RepositoriesService_TestPackageRepositoryConnection_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fluxV2RepositoriesServiceClient) ListPackageRepositoryPackages(ctx context.Context, in *v1alpha1.ListPackageRepositoryPackagesRequest, opts ...grpc.CallOption) (*v1alpha1.ListPackageRepositoryPackagesResponse, error) {
+	out := new(v1alpha1.ListPackageRepositoryPackagesResponse)
+	err := c.cc.Invoke(ctx, FluxV2RepositoriesService_ListPackageRepositoryPackages_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // FluxV2RepositoriesServiceServer is the server API for FluxV2RepositoriesService service.
 // All implementations should embed UnimplementedFluxV2RepositoriesServiceServer
 // for forward compatibility
@@ -523,6 +781,8 @@ type FluxV2RepositoriesServiceServer interface {
 	UpdatePackageRepository(context.Context, *v1alpha1.UpdatePackageRepositoryRequest) (*v1alpha1.UpdatePackageRepositoryResponse, error)
 	DeletePackageRepository(context.Context, *v1alpha1.DeletePackageRepositoryRequest) (*v1alpha1.DeletePackageRepositoryResponse, error)
 	GetPackageRepositoryPermissions(context.Context, *v1alpha1.GetPackageRepositoryPermissionsRequest) (*v1alpha1.GetPackageRepositoryPermissionsResponse, error)
+	TestPackageRepositoryConnection(context.Context, *v1alpha1.TestPackageRepositoryConnectionRequest) (*v1alpha1.TestPackageRepositoryConnectionResponse, error)
+	ListPackageRepositoryPackages(context.Context, *v1alpha1.ListPackageRepositoryPackagesRequest) (*v1alpha1.ListPackageRepositoryPackagesResponse, error)
 }
 
 // UnimplementedFluxV2RepositoriesServiceServer should be embedded to have forward compatible implementations.
@@ -547,6 +807,12 @@ func (UnimplementedFluxV2RepositoriesServiceServer) DeletePackageRepository(cont
 func (UnimplementedFluxV2RepositoriesServiceServer) GetPackageRepositoryPermissions(context.Context, *v1alpha1.GetPackageRepositoryPermissionsRequest) (*v1alpha1.GetPackageRepositoryPermissionsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetPackageRepositoryPermissions not implemented")
 }
+func (UnimplementedFluxV2RepositoriesServiceServer) TestPackageRepositoryConnection(context.Context, *v1alpha1.TestPackageRepositoryConnectionRequest) (*v1alpha1.TestPackageRepositoryConnectionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TestPackageRepositoryConnection not implemented")
+}
+func (UnimplementedFluxV2RepositoriesServiceServer) ListPackageRepositoryPackages(context.Context, *v1alpha1.ListPackageRepositoryPackagesRequest) (*v1alpha1.ListPackageRepositoryPackagesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPackageRepositoryPackages not implemented")
+}
 
 // UnsafeFluxV2RepositoriesServiceServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to FluxV2RepositoriesServiceServer will
@@ -667,6 +933,42 @@ func _FluxV2RepositoriesService_GetPackageRepositoryPermissions_Handler(srv inte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FluxV2RepositoriesService_TestPackageRepositoryConnection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha1.TestPackageRepositoryConnectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FluxV2RepositoriesServiceServer).TestPackageRepositoryConnection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FluxV2RepositoriesService_TestPackageRepositoryConnection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FluxV2RepositoriesServiceServer).TestPackageRepositoryConnection(ctx, req.(*v1alpha1.TestPackageRepositoryConnectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FluxV2RepositoriesService_ListPackageRepositoryPackages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha1.ListPackageRepositoryPackagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FluxV2RepositoriesServiceServer).ListPackageRepositoryPackages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FluxV2RepositoriesService_ListPackageRepositoryPackages_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FluxV2RepositoriesServiceServer).ListPackageRepositoryPackages(ctx, req.(*v1alpha1.ListPackageRepositoryPackagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // FluxV2RepositoriesService_ServiceDesc is the grpc.ServiceDesc for FluxV2RepositoriesService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -698,6 +1000,14 @@ var FluxV2RepositoriesService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetPackageRepositoryPermissions",
 			Handler:    _FluxV2RepositoriesService_GetPackageRepositoryPermissions_Handler,
 		},
+		{
+			MethodName: "TestPackageRepositoryConnection",
+			Handler:    _FluxV2RepositoriesService_TestPackageRepositoryConnection_Handler,
+		},
+		{
+			MethodName: "ListPackageRepositoryPackages",
+			Handler:    _FluxV2RepositoriesService_ListPackageRepositoryPackages_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "kubeappsapis/plugins/fluxv2/packages/v1alpha1/fluxv2.proto",
@@ -12,7 +12,7 @@ import (
 	errors "errors"
 	connect_go "github.com/bufbuild/connect-go"
 	v1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
-	_ "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/plugins/fluxv2/packages/v1alpha1"
+	v1alpha11 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/plugins/fluxv2/packages/v1alpha1"
 	http "net/http"
 	strings "strings"
 )
@@ -49,12 +49,24 @@ const (
 	// FluxV2PackagesServiceGetAvailablePackageVersionsProcedure is the fully-qualified name of the
 	// FluxV2PackagesService's GetAvailablePackageVersions RPC.
 	FluxV2PackagesServiceGetAvailablePackageVersionsProcedure = "/kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2PackagesService/GetAvailablePackageVersions"
+	// FluxV2PackagesServiceGetAvailablePackageTextAssetProcedure is the fully-qualified name of the
+	// FluxV2PackagesService's GetAvailablePackageTextAsset RPC.
+	FluxV2PackagesServiceGetAvailablePackageTextAssetProcedure = "/kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2PackagesService/GetAvailablePackageTextAsset"
+	// FluxV2PackagesServiceGetAvailablePackageChangelogProcedure is the fully-qualified name of the
+	// FluxV2PackagesService's GetAvailablePackageChangelog RPC.
+	FluxV2PackagesServiceGetAvailablePackageChangelogProcedure = "/kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2PackagesService/GetAvailablePackageChangelog"
+	// FluxV2PackagesServiceGetAvailablePackageFiltersProcedure is the fully-qualified name of the
+	// FluxV2PackagesService's GetAvailablePackageFilters RPC.
+	FluxV2PackagesServiceGetAvailablePackageFiltersProcedure = "/kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2PackagesService/GetAvailablePackageFilters"
 	// FluxV2PackagesServiceGetInstalledPackageSummariesProcedure is the fully-qualified name of the
 	// FluxV2PackagesService's GetInstalledPackageSummaries RPC.
 	FluxV2PackagesServiceGetInstalledPackageSummariesProcedure = "/kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2PackagesService/GetInstalledPackageSummaries"
 	// FluxV2PackagesServiceGetInstalledPackageDetailProcedure is the fully-qualified name of the
 	// FluxV2PackagesService's GetInstalledPackageDetail RPC.
 	FluxV2PackagesServiceGetInstalledPackageDetailProcedure = "/kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2PackagesService/GetInstalledPackageDetail"
+	// FluxV2PackagesServiceCheckInstalledPackageNameAvailabilityProcedure is the fully-qualified name
+	// of the FluxV2PackagesService's CheckInstalledPackageNameAvailability RPC.
+	FluxV2PackagesServiceCheckInstalledPackageNameAvailabilityProcedure = "/kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2PackagesService/CheckInstalledPackageNameAvailability"
 	// FluxV2PackagesServiceCreateInstalledPackageProcedure is the fully-qualified name of the
 	// FluxV2PackagesService's CreateInstalledPackage RPC.
 	FluxV2PackagesServiceCreateInstalledPackageProcedure = "/kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2PackagesService/CreateInstalledPackage"
@@ -67,6 +79,12 @@ const (
 	// FluxV2PackagesServiceGetInstalledPackageResourceRefsProcedure is the fully-qualified name of the
 	// FluxV2PackagesService's GetInstalledPackageResourceRefs RPC.
 	FluxV2PackagesServiceGetInstalledPackageResourceRefsProcedure = "/kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2PackagesService/GetInstalledPackageResourceRefs"
+	// FluxV2PackagesServiceGetInstalledPackageOperationStatusProcedure is the fully-qualified name of
+	// the FluxV2PackagesService's GetInstalledPackageOperationStatus RPC.
+	FluxV2PackagesServiceGetInstalledPackageOperationStatusProcedure = "/kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2PackagesService/GetInstalledPackageOperationStatus"
+	// FluxV2PackagesServiceConvertInstalledPackageProcedure is the fully-qualified name of the
+	// FluxV2PackagesService's ConvertInstalledPackage RPC.
+	FluxV2PackagesServiceConvertInstalledPackageProcedure = "/kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2PackagesService/ConvertInstalledPackage"
 	// FluxV2RepositoriesServiceAddPackageRepositoryProcedure is the fully-qualified name of the
 	// FluxV2RepositoriesService's AddPackageRepository RPC.
 	FluxV2RepositoriesServiceAddPackageRepositoryProcedure = "/kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2RepositoriesService/AddPackageRepository"
@@ -85,6 +103,12 @@ const (
 	// FluxV2RepositoriesServiceGetPackageRepositoryPermissionsProcedure is the fully-qualified name of
 	// the FluxV2RepositoriesService's GetPackageRepositoryPermissions RPC.
 	FluxV2RepositoriesServiceGetPackageRepositoryPermissionsProcedure = "/kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2RepositoriesService/GetPackageRepositoryPermissions"
+	// FluxV2RepositoriesServiceTestPackageRepositoryConnectionProcedure is the fully-qualified name of
+	// the FluxV2RepositoriesService's TestPackageRepositoryConnection RPC.
+	FluxV2RepositoriesServiceTestPackageRepositoryConnectionProcedure = "/kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2RepositoriesService/TestPackageRepositoryConnection"
+	// FluxV2RepositoriesServiceListPackageRepositoryPackagesProcedure is the fully-qualified name of
+	// the FluxV2RepositoriesService's ListPackageRepositoryPackages RPC.
+	FluxV2RepositoriesServiceListPackageRepositoryPackagesProcedure = "/kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2RepositoriesService/ListPackageRepositoryPackages"
 )
 
 // FluxV2PackagesServiceClient is a client for the
@@ -96,10 +120,18 @@ type FluxV2PackagesServiceClient interface {
 	GetAvailablePackageDetail(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageDetailRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageDetailResponse], error)
 	// GetAvailablePackageVersions returns the package versions managed by the 'fluxv2' plugin
 	GetAvailablePackageVersions(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageVersionsRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageVersionsResponse], error)
+	// GetAvailablePackageTextAsset returns a range of a text asset of a package managed by the 'fluxv2' plugin
+	GetAvailablePackageTextAsset(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageTextAssetRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageTextAssetResponse], error)
+	// GetAvailablePackageChangelog returns the release notes between two versions of a package managed by the 'fluxv2' plugin
+	GetAvailablePackageChangelog(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageChangelogRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageChangelogResponse], error)
+	// GetAvailablePackageFilters returns the filter values of the available packages managed by the 'fluxv2' plugin
+	GetAvailablePackageFilters(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageFiltersRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageFiltersResponse], error)
 	// GetInstalledPackageSummaries returns the installed packages managed by the 'fluxv2' plugin
 	GetInstalledPackageSummaries(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageSummariesRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageSummariesResponse], error)
 	// GetInstalledPackageDetail returns the requested installed package managed by the 'fluxv2' plugin
 	GetInstalledPackageDetail(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageDetailRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageDetailResponse], error)
+	// CheckInstalledPackageNameAvailability checks whether a package managed by the 'fluxv2' plugin can be installed with the given name
+	CheckInstalledPackageNameAvailability(context.Context, *connect_go.Request[v1alpha1.CheckInstalledPackageNameAvailabilityRequest]) (*connect_go.Response[v1alpha1.CheckInstalledPackageNameAvailabilityResponse], error)
 	// CreateInstalledPackage creates an installed package based on the request.
 	CreateInstalledPackage(context.Context, *connect_go.Request[v1alpha1.CreateInstalledPackageRequest]) (*connect_go.Response[v1alpha1.CreateInstalledPackageResponse], error)
 	// UpdateInstalledPackage updates an installed package based on the request.
@@ -109,6 +141,11 @@ type FluxV2PackagesServiceClient interface {
 	// GetInstalledPackageResourceRefs returns the references for the Kubernetes
 	// resources created by an installed package.
 	GetInstalledPackageResourceRefs(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageResourceRefsRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageResourceRefsResponse], error)
+	// GetInstalledPackageOperationStatus returns the progress of the operation in course on an installed package managed by the 'fluxv2' plugin
+	GetInstalledPackageOperationStatus(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageOperationStatusRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageOperationStatusResponse], error)
+	// ConvertInstalledPackage converts a Helm release installed by the 'helm' plugin into a
+	// flux HelmRelease managed by the 'fluxv2' plugin, without reinstalling it.
+	ConvertInstalledPackage(context.Context, *connect_go.Request[v1alpha11.ConvertInstalledPackageRequest]) (*connect_go.Response[v1alpha11.ConvertInstalledPackageResponse], error)
 }
 
 // NewFluxV2PackagesServiceClient constructs a client for the
@@ -137,6 +174,21 @@ func NewFluxV2PackagesServiceClient(httpClient connect_go.HTTPClient, baseURL st
 			baseURL+FluxV2PackagesServiceGetAvailablePackageVersionsProcedure,
 			opts...,
 		),
+		getAvailablePackageTextAsset: connect_go.NewClient[v1alpha1.GetAvailablePackageTextAssetRequest, v1alpha1.GetAvailablePackageTextAssetResponse](
+			httpClient,
+			baseURL+FluxV2PackagesServiceGetAvailablePackageTextAssetProcedure,
+			opts...,
+		),
+		getAvailablePackageChangelog: connect_go.NewClient[v1alpha1.GetAvailablePackageChangelogRequest, v1alpha1.GetAvailablePackageChangelogResponse](
+			httpClient,
+			baseURL+FluxV2PackagesServiceGetAvailablePackageChangelogProcedure,
+			opts...,
+		),
+		getAvailablePackageFilters: connect_go.NewClient[v1alpha1.GetAvailablePackageFiltersRequest, v1alpha1.GetAvailablePackageFiltersResponse](
+			httpClient,
+			baseURL+FluxV2PackagesServiceGetAvailablePackageFiltersProcedure,
+			opts...,
+		),
 		getInstalledPackageSummaries: connect_go.NewClient[v1alpha1.GetInstalledPackageSummariesRequest, v1alpha1.GetInstalledPackageSummariesResponse](
 			httpClient,
 			baseURL+FluxV2PackagesServiceGetInstalledPackageSummariesProcedure,
@@ -147,6 +199,11 @@ func NewFluxV2PackagesServiceClient(httpClient connect_go.HTTPClient, baseURL st
 			baseURL+FluxV2PackagesServiceGetInstalledPackageDetailProcedure,
 			opts...,
 		),
+		checkInstalledPackageNameAvailability: connect_go.NewClient[v1alpha1.CheckInstalledPackageNameAvailabilityRequest, v1alpha1.CheckInstalledPackageNameAvailabilityResponse](
+			httpClient,
+			baseURL+FluxV2PackagesServiceCheckInstalledPackageNameAvailabilityProcedure,
+			opts...,
+		),
 		createInstalledPackage: connect_go.NewClient[v1alpha1.CreateInstalledPackageRequest, v1alpha1.CreateInstalledPackageResponse](
 			httpClient,
 			baseURL+FluxV2PackagesServiceCreateInstalledPackageProcedure,
@@ -167,20 +224,36 @@ func NewFluxV2PackagesServiceClient(httpClient connect_go.HTTPClient, baseURL st
 			baseURL+FluxV2PackagesServiceGetInstalledPackageResourceRefsProcedure,
 			opts...,
 		),
+		getInstalledPackageOperationStatus: connect_go.NewClient[v1alpha1.GetInstalledPackageOperationStatusRequest, v1alpha1.GetInstalledPackageOperationStatusResponse](
+			httpClient,
+			baseURL+FluxV2PackagesServiceGetInstalledPackageOperationStatusProcedure,
+			opts...,
+		),
+		convertInstalledPackage: connect_go.NewClient[v1alpha11.ConvertInstalledPackageRequest, v1alpha11.ConvertInstalledPackageResponse](
+			httpClient,
+			baseURL+FluxV2PackagesServiceConvertInstalledPackageProcedure,
+			opts...,
+		),
 	}
 }
 
 // fluxV2PackagesServiceClient implements FluxV2PackagesServiceClient.
 type fluxV2PackagesServiceClient struct {
-	getAvailablePackageSummaries    *connect_go.Client[v1alpha1.GetAvailablePackageSummariesRequest, v1alpha1.GetAvailablePackageSummariesResponse]
-	getAvailablePackageDetail       *connect_go.Client[v1alpha1.GetAvailablePackageDetailRequest, v1alpha1.GetAvailablePackageDetailResponse]
-	getAvailablePackageVersions     *connect_go.Client[v1alpha1.GetAvailablePackageVersionsRequest, v1alpha1.GetAvailablePackageVersionsResponse]
-	getInstalledPackageSummaries    *connect_go.Client[v1alpha1.GetInstalledPackageSummariesRequest, v1alpha1.GetInstalledPackageSummariesResponse]
-	getInstalledPackageDetail       *connect_go.Client[v1alpha1.GetInstalledPackageDetailRequest, v1alpha1.GetInstalledPackageDetailResponse]
-	createInstalledPackage          *connect_go.Client[v1alpha1.CreateInstalledPackageRequest, v1alpha1.CreateInstalledPackageResponse]
-	updateInstalledPackage          *connect_go.Client[v1alpha1.UpdateInstalledPackageRequest, v1alpha1.UpdateInstalledPackageResponse]
-	deleteInstalledPackage          *connect_go.Client[v1alpha1.DeleteInstalledPackageRequest, v1alpha1.DeleteInstalledPackageResponse]
-	getInstalledPackageResourceRefs *connect_go.Client[v1alpha1.GetInstalledPackageResourceRefsRequest, v1alpha1.GetInstalledPackageResourceRefsResponse]
+	getAvailablePackageSummaries          *connect_go.Client[v1alpha1.GetAvailablePackageSummariesRequest, v1alpha1.GetAvailablePackageSummariesResponse]
+	getAvailablePackageDetail             *connect_go.Client[v1alpha1.GetAvailablePackageDetailRequest, v1alpha1.GetAvailablePackageDetailResponse]
+	getAvailablePackageVersions           *connect_go.Client[v1alpha1.GetAvailablePackageVersionsRequest, v1alpha1.GetAvailablePackageVersionsResponse]
+	getAvailablePackageTextAsset          *connect_go.Client[v1alpha1.GetAvailablePackageTextAssetRequest, v1alpha1.GetAvailablePackageTextAssetResponse]
+	getAvailablePackageChangelog          *connect_go.Client[v1alpha1.GetAvailablePackageChangelogRequest, v1alpha1.GetAvailablePackageChangelogResponse]
+	getAvailablePackageFilters            *connect_go.Client[v1alpha1.GetAvailablePackageFiltersRequest, v1alpha1.GetAvailablePackageFiltersResponse]
+	getInstalledPackageSummaries          *connect_go.Client[v1alpha1.GetInstalledPackageSummariesRequest, v1alpha1.GetInstalledPackageSummariesResponse]
+	getInstalledPackageDetail             *connect_go.Client[v1alpha1.GetInstalledPackageDetailRequest, v1alpha1.GetInstalledPackageDetailResponse]
+	checkInstalledPackageNameAvailability *connect_go.Client[v1alpha1.CheckInstalledPackageNameAvailabilityRequest, v1alpha1.CheckInstalledPackageNameAvailabilityResponse]
+	createInstalledPackage                *connect_go.Client[v1alpha1.CreateInstalledPackageRequest, v1alpha1.CreateInstalledPackageResponse]
+	updateInstalledPackage                *connect_go.Client[v1alpha1.UpdateInstalledPackageRequest, v1alpha1.UpdateInstalledPackageResponse]
+	deleteInstalledPackage                *connect_go.Client[v1alpha1.DeleteInstalledPackageRequest, v1alpha1.DeleteInstalledPackageResponse]
+	getInstalledPackageResourceRefs       *connect_go.Client[v1alpha1.GetInstalledPackageResourceRefsRequest, v1alpha1.GetInstalledPackageResourceRefsResponse]
+	getInstalledPackageOperationStatus    *connect_go.Client[v1alpha1.GetInstalledPackageOperationStatusRequest, v1alpha1.GetInstalledPackageOperationStatusResponse]
+	convertInstalledPackage               *connect_go.Client[v1alpha11.ConvertInstalledPackageRequest, v1alpha11.ConvertInstalledPackageResponse]
 }
 
 // GetAvailablePackageSummaries calls
@@ -201,6 +274,24 @@ func (c *fluxV2PackagesServiceClient) GetAvailablePackageVersions(ctx context.Co
 	return c.getAvailablePackageVersions.CallUnary(ctx, req)
 }
 
+// GetAvailablePackageTextAsset calls
+// kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2PackagesService.GetAvailablePackageTextAsset.
+func (c *fluxV2PackagesServiceClient) GetAvailablePackageTextAsset(ctx context.Context, req *connect_go.Request[v1alpha1.GetAvailablePackageTextAssetRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageTextAssetResponse], error) {
+	return c.getAvailablePackageTextAsset.CallUnary(ctx, req)
+}
+
+// GetAvailablePackageChangelog calls
+// kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2PackagesService.GetAvailablePackageChangelog.
+func (c *fluxV2PackagesServiceClient) GetAvailablePackageChangelog(ctx context.Context, req *connect_go.Request[v1alpha1.GetAvailablePackageChangelogRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageChangelogResponse], error) {
+	return c.getAvailablePackageChangelog.CallUnary(ctx, req)
+}
+
+// GetAvailablePackageFilters calls
+// kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2PackagesService.GetAvailablePackageFilters.
+func (c *fluxV2PackagesServiceClient) GetAvailablePackageFilters(ctx context.Context, req *connect_go.Request[v1alpha1.GetAvailablePackageFiltersRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageFiltersResponse], error) {
+	return c.getAvailablePackageFilters.CallUnary(ctx, req)
+}
+
 // GetInstalledPackageSummaries calls
 // kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2PackagesService.GetInstalledPackageSummaries.
 func (c *fluxV2PackagesServiceClient) GetInstalledPackageSummaries(ctx context.Context, req *connect_go.Request[v1alpha1.GetInstalledPackageSummariesRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageSummariesResponse], error) {
@@ -213,6 +304,12 @@ func (c *fluxV2PackagesServiceClient) GetInstalledPackageDetail(ctx context.Cont
 	return c.getInstalledPackageDetail.CallUnary(ctx, req)
 }
 
+// CheckInstalledPackageNameAvailability calls
+// kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2PackagesService.CheckInstalledPackageNameAvailability.
+func (c *fluxV2PackagesServiceClient) CheckInstalledPackageNameAvailability(ctx context.Context, req *connect_go.Request[v1alpha1.CheckInstalledPackageNameAvailabilityRequest]) (*connect_go.Response[v1alpha1.CheckInstalledPackageNameAvailabilityResponse], error) {
+	return c.checkInstalledPackageNameAvailability.CallUnary(ctx, req)
+}
+
 // CreateInstalledPackage calls
 // kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2PackagesService.CreateInstalledPackage.
 func (c *fluxV2PackagesServiceClient) CreateInstalledPackage(ctx context.Context, req *connect_go.Request[v1alpha1.CreateInstalledPackageRequest]) (*connect_go.Response[v1alpha1.CreateInstalledPackageResponse], error) {
@@ -237,6 +334,18 @@ func (c *fluxV2PackagesServiceClient) GetInstalledPackageResourceRefs(ctx contex
 	return c.getInstalledPackageResourceRefs.CallUnary(ctx, req)
 }
 
+// GetInstalledPackageOperationStatus calls
+// kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2PackagesService.GetInstalledPackageOperationStatus.
+func (c *fluxV2PackagesServiceClient) GetInstalledPackageOperationStatus(ctx context.Context, req *connect_go.Request[v1alpha1.GetInstalledPackageOperationStatusRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageOperationStatusResponse], error) {
+	return c.getInstalledPackageOperationStatus.CallUnary(ctx, req)
+}
+
+// ConvertInstalledPackage calls
+// kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2PackagesService.ConvertInstalledPackage.
+func (c *fluxV2PackagesServiceClient) ConvertInstalledPackage(ctx context.Context, req *connect_go.Request[v1alpha11.ConvertInstalledPackageRequest]) (*connect_go.Response[v1alpha11.ConvertInstalledPackageResponse], error) {
+	return c.convertInstalledPackage.CallUnary(ctx, req)
+}
+
 // FluxV2PackagesServiceHandler is an implementation of the
 // kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2PackagesService service.
 type FluxV2PackagesServiceHandler interface {
@@ -246,10 +355,18 @@ type FluxV2PackagesServiceHandler interface {
 	GetAvailablePackageDetail(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageDetailRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageDetailResponse], error)
 	// GetAvailablePackageVersions returns the package versions managed by the 'fluxv2' plugin
 	GetAvailablePackageVersions(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageVersionsRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageVersionsResponse], error)
+	// GetAvailablePackageTextAsset returns a range of a text asset of a package managed by the 'fluxv2' plugin
+	GetAvailablePackageTextAsset(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageTextAssetRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageTextAssetResponse], error)
+	// GetAvailablePackageChangelog returns the release notes between two versions of a package managed by the 'fluxv2' plugin
+	GetAvailablePackageChangelog(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageChangelogRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageChangelogResponse], error)
+	// GetAvailablePackageFilters returns the filter values of the available packages managed by the 'fluxv2' plugin
+	GetAvailablePackageFilters(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageFiltersRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageFiltersResponse], error)
 	// GetInstalledPackageSummaries returns the installed packages managed by the 'fluxv2' plugin
 	GetInstalledPackageSummaries(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageSummariesRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageSummariesResponse], error)
 	// GetInstalledPackageDetail returns the requested installed package managed by the 'fluxv2' plugin
 	GetInstalledPackageDetail(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageDetailRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageDetailResponse], error)
+	// CheckInstalledPackageNameAvailability checks whether a package managed by the 'fluxv2' plugin can be installed with the given name
+	CheckInstalledPackageNameAvailability(context.Context, *connect_go.Request[v1alpha1.CheckInstalledPackageNameAvailabilityRequest]) (*connect_go.Response[v1alpha1.CheckInstalledPackageNameAvailabilityResponse], error)
 	// CreateInstalledPackage creates an installed package based on the request.
 	CreateInstalledPackage(context.Context, *connect_go.Request[v1alpha1.CreateInstalledPackageRequest]) (*connect_go.Response[v1alpha1.CreateInstalledPackageResponse], error)
 	// UpdateInstalledPackage updates an installed package based on the request.
@@ -259,6 +376,11 @@ type FluxV2PackagesServiceHandler interface {
 	// GetInstalledPackageResourceRefs returns the references for the Kubernetes
 	// resources created by an installed package.
 	GetInstalledPackageResourceRefs(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageResourceRefsRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageResourceRefsResponse], error)
+	// GetInstalledPackageOperationStatus returns the progress of the operation in course on an installed package managed by the 'fluxv2' plugin
+	GetInstalledPackageOperationStatus(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageOperationStatusRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageOperationStatusResponse], error)
+	// ConvertInstalledPackage converts a Helm release installed by the 'helm' plugin into a
+	// flux HelmRelease managed by the 'fluxv2' plugin, without reinstalling it.
+	ConvertInstalledPackage(context.Context, *connect_go.Request[v1alpha11.ConvertInstalledPackageRequest]) (*connect_go.Response[v1alpha11.ConvertInstalledPackageResponse], error)
 }
 
 // NewFluxV2PackagesServiceHandler builds an HTTP handler from the service implementation. It
@@ -282,6 +404,21 @@ func NewFluxV2PackagesServiceHandler(svc FluxV2PackagesServiceHandler, opts ...c
 		svc.GetAvailablePackageVersions,
 		opts...,
 	)
+	fluxV2PackagesServiceGetAvailablePackageTextAssetHandler := connect_go.NewUnaryHandler(
+		FluxV2PackagesServiceGetAvailablePackageTextAssetProcedure,
+		svc.GetAvailablePackageTextAsset,
+		opts...,
+	)
+	fluxV2PackagesServiceGetAvailablePackageChangelogHandler := connect_go.NewUnaryHandler(
+		FluxV2PackagesServiceGetAvailablePackageChangelogProcedure,
+		svc.GetAvailablePackageChangelog,
+		opts...,
+	)
+	fluxV2PackagesServiceGetAvailablePackageFiltersHandler := connect_go.NewUnaryHandler(
+		FluxV2PackagesServiceGetAvailablePackageFiltersProcedure,
+		svc.GetAvailablePackageFilters,
+		opts...,
+	)
 	fluxV2PackagesServiceGetInstalledPackageSummariesHandler := connect_go.NewUnaryHandler(
 		FluxV2PackagesServiceGetInstalledPackageSummariesProcedure,
 		svc.GetInstalledPackageSummaries,
@@ -292,6 +429,11 @@ func NewFluxV2PackagesServiceHandler(svc FluxV2PackagesServiceHandler, opts ...c
 		svc.GetInstalledPackageDetail,
 		opts...,
 	)
+	fluxV2PackagesServiceCheckInstalledPackageNameAvailabilityHandler := connect_go.NewUnaryHandler(
+		FluxV2PackagesServiceCheckInstalledPackageNameAvailabilityProcedure,
+		svc.CheckInstalledPackageNameAvailability,
+		opts...,
+	)
 	fluxV2PackagesServiceCreateInstalledPackageHandler := connect_go.NewUnaryHandler(
 		FluxV2PackagesServiceCreateInstalledPackageProcedure,
 		svc.CreateInstalledPackage,
@@ -312,6 +454,16 @@ func NewFluxV2PackagesServiceHandler(svc FluxV2PackagesServiceHandler, opts ...c
 		svc.GetInstalledPackageResourceRefs,
 		opts...,
 	)
+	fluxV2PackagesServiceGetInstalledPackageOperationStatusHandler := connect_go.NewUnaryHandler(
+		FluxV2PackagesServiceGetInstalledPackageOperationStatusProcedure,
+		svc.GetInstalledPackageOperationStatus,
+		opts...,
+	)
+	fluxV2PackagesServiceConvertInstalledPackageHandler := connect_go.NewUnaryHandler(
+		FluxV2PackagesServiceConvertInstalledPackageProcedure,
+		svc.ConvertInstalledPackage,
+		opts...,
+	)
 	return "/kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2PackagesService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case FluxV2PackagesServiceGetAvailablePackageSummariesProcedure:
@@ -320,10 +472,18 @@ func NewFluxV2PackagesServiceHandler(svc FluxV2PackagesServiceHandler, opts ...c
 			fluxV2PackagesServiceGetAvailablePackageDetailHandler.ServeHTTP(w, r)
 		case FluxV2PackagesServiceGetAvailablePackageVersionsProcedure:
 			fluxV2PackagesServiceGetAvailablePackageVersionsHandler.ServeHTTP(w, r)
+		case FluxV2PackagesServiceGetAvailablePackageTextAssetProcedure:
+			fluxV2PackagesServiceGetAvailablePackageTextAssetHandler.ServeHTTP(w, r)
+		case FluxV2PackagesServiceGetAvailablePackageChangelogProcedure:
+			fluxV2PackagesServiceGetAvailablePackageChangelogHandler.ServeHTTP(w, r)
+		case FluxV2PackagesServiceGetAvailablePackageFiltersProcedure:
+			fluxV2PackagesServiceGetAvailablePackageFiltersHandler.ServeHTTP(w, r)
 		case FluxV2PackagesServiceGetInstalledPackageSummariesProcedure:
 			fluxV2PackagesServiceGetInstalledPackageSummariesHandler.ServeHTTP(w, r)
 		case FluxV2PackagesServiceGetInstalledPackageDetailProcedure:
 			fluxV2PackagesServiceGetInstalledPackageDetailHandler.ServeHTTP(w, r)
+		case FluxV2PackagesServiceCheckInstalledPackageNameAvailabilityProcedure:
+			fluxV2PackagesServiceCheckInstalledPackageNameAvailabilityHandler.ServeHTTP(w, r)
 		case FluxV2PackagesServiceCreateInstalledPackageProcedure:
 			fluxV2PackagesServiceCreateInstalledPackageHandler.ServeHTTP(w, r)
 		case FluxV2PackagesServiceUpdateInstalledPackageProcedure:
@@ -332,6 +492,10 @@ func NewFluxV2PackagesServiceHandler(svc FluxV2PackagesServiceHandler, opts ...c
 			fluxV2PackagesServiceDeleteInstalledPackageHandler.ServeHTTP(w, r)
 		case FluxV2PackagesServiceGetInstalledPackageResourceRefsProcedure:
 			fluxV2PackagesServiceGetInstalledPackageResourceRefsHandler.ServeHTTP(w, r)
+		case FluxV2PackagesServiceGetInstalledPackageOperationStatusProcedure:
+			fluxV2PackagesServiceGetInstalledPackageOperationStatusHandler.ServeHTTP(w, r)
+		case FluxV2PackagesServiceConvertInstalledPackageProcedure:
+			fluxV2PackagesServiceConvertInstalledPackageHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -353,6 +517,18 @@ func (UnimplementedFluxV2PackagesServiceHandler) GetAvailablePackageVersions(con
 	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2PackagesService.GetAvailablePackageVersions is not implemented"))
 }
 
+func (UnimplementedFluxV2PackagesServiceHandler) GetAvailablePackageTextAsset(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageTextAssetRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageTextAssetResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2PackagesService.GetAvailablePackageTextAsset is not implemented"))
+}
+
+func (UnimplementedFluxV2PackagesServiceHandler) GetAvailablePackageChangelog(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageChangelogRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageChangelogResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2PackagesService.GetAvailablePackageChangelog is not implemented"))
+}
+
+func (UnimplementedFluxV2PackagesServiceHandler) GetAvailablePackageFilters(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageFiltersRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageFiltersResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2PackagesService.GetAvailablePackageFilters is not implemented"))
+}
+
 func (UnimplementedFluxV2PackagesServiceHandler) GetInstalledPackageSummaries(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageSummariesRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageSummariesResponse], error) {
 	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2PackagesService.GetInstalledPackageSummaries is not implemented"))
 }
@@ -361,6 +537,10 @@ func (UnimplementedFluxV2PackagesServiceHandler) GetInstalledPackageDetail(conte
 	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2PackagesService.GetInstalledPackageDetail is not implemented"))
 }
 
+func (UnimplementedFluxV2PackagesServiceHandler) CheckInstalledPackageNameAvailability(context.Context, *connect_go.Request[v1alpha1.CheckInstalledPackageNameAvailabilityRequest]) (*connect_go.Response[v1alpha1.CheckInstalledPackageNameAvailabilityResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2PackagesService.CheckInstalledPackageNameAvailability is not implemented"))
+}
+
 func (UnimplementedFluxV2PackagesServiceHandler) CreateInstalledPackage(context.Context, *connect_go.Request[v1alpha1.CreateInstalledPackageRequest]) (*connect_go.Response[v1alpha1.CreateInstalledPackageResponse], error) {
 	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2PackagesService.CreateInstalledPackage is not implemented"))
 }
@@ -377,6 +557,14 @@ func (UnimplementedFluxV2PackagesServiceHandler) GetInstalledPackageResourceRefs
 	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2PackagesService.GetInstalledPackageResourceRefs is not implemented"))
 }
 
+func (UnimplementedFluxV2PackagesServiceHandler) GetInstalledPackageOperationStatus(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageOperationStatusRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageOperationStatusResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2PackagesService.GetInstalledPackageOperationStatus is not implemented"))
+}
+
+func (UnimplementedFluxV2PackagesServiceHandler) ConvertInstalledPackage(context.Context, *connect_go.Request[v1alpha11.ConvertInstalledPackageRequest]) (*connect_go.Response[v1alpha11.ConvertInstalledPackageResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2PackagesService.ConvertInstalledPackage is not implemented"))
+}
+
 // FluxV2RepositoriesServiceClient is a client for the
 // kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2RepositoriesService service.
 type FluxV2RepositoriesServiceClient interface {
@@ -388,6 +576,8 @@ type FluxV2RepositoriesServiceClient interface {
 	UpdatePackageRepository(context.Context, *connect_go.Request[v1alpha1.UpdatePackageRepositoryRequest]) (*connect_go.Response[v1alpha1.UpdatePackageRepositoryResponse], error)
 	DeletePackageRepository(context.Context, *connect_go.Request[v1alpha1.DeletePackageRepositoryRequest]) (*connect_go.Response[v1alpha1.DeletePackageRepositoryResponse], error)
 	GetPackageRepositoryPermissions(context.Context, *connect_go.Request[v1alpha1.GetPackageRepositoryPermissionsRequest]) (*connect_go.Response[v1alpha1.GetPackageRepositoryPermissionsResponse], error)
+	TestPackageRepositoryConnection(context.Context, *connect_go.Request[v1alpha1.TestPackageRepositoryConnectionRequest]) (*connect_go.Response[v1alpha1.TestPackageRepositoryConnectionResponse], error)
+	ListPackageRepositoryPackages(context.Context, *connect_go.Request[v1alpha1.ListPackageRepositoryPackagesRequest]) (*connect_go.Response[v1alpha1.ListPackageRepositoryPackagesResponse], error)
 }
 
 // NewFluxV2RepositoriesServiceClient constructs a client for the
@@ -431,6 +621,16 @@ func NewFluxV2RepositoriesServiceClient(httpClient connect_go.HTTPClient, baseUR
 			baseURL+FluxV2RepositoriesServiceGetPackageRepositoryPermissionsProcedure,
 			opts...,
 		),
+		testPackageRepositoryConnection: connect_go.NewClient[v1alpha1.TestPackageRepositoryConnectionRequest, v1alpha1.TestPackageRepositoryConnectionResponse](
+			httpClient,
+			baseURL+FluxV2RepositoriesServiceTestPackageRepositoryConnectionProcedure,
+			opts...,
+		),
+		listPackageRepositoryPackages: connect_go.NewClient[v1alpha1.ListPackageRepositoryPackagesRequest, v1alpha1.ListPackageRepositoryPackagesResponse](
+			httpClient,
+			baseURL+FluxV2RepositoriesServiceListPackageRepositoryPackagesProcedure,
+			opts...,
+		),
 	}
 }
 
@@ -442,6 +642,8 @@ type fluxV2RepositoriesServiceClient struct {
 	updatePackageRepository         *connect_go.Client[v1alpha1.UpdatePackageRepositoryRequest, v1alpha1.UpdatePackageRepositoryResponse]
 	deletePackageRepository         *connect_go.Client[v1alpha1.DeletePackageRepositoryRequest, v1alpha1.DeletePackageRepositoryResponse]
 	getPackageRepositoryPermissions *connect_go.Client[v1alpha1.GetPackageRepositoryPermissionsRequest, v1alpha1.GetPackageRepositoryPermissionsResponse]
+	testPackageRepositoryConnection *connect_go.Client[v1alpha1.TestPackageRepositoryConnectionRequest, v1alpha1.TestPackageRepositoryConnectionResponse]
+	listPackageRepositoryPackages   *connect_go.Client[v1alpha1.ListPackageRepositoryPackagesRequest, v1alpha1.ListPackageRepositoryPackagesResponse]
 }
 
 // AddPackageRepository calls
@@ -480,6 +682,18 @@ func (c *fluxV2RepositoriesServiceClient) GetPackageRepositoryPermissions(ctx co
 	return c.getPackageRepositoryPermissions.CallUnary(ctx, req)
 }
 
+// TestPackageRepositoryConnection calls
+// kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2RepositoriesService.TestPackageRepositoryConnection.
+func (c *fluxV2RepositoriesServiceClient) TestPackageRepositoryConnection(ctx context.Context, req *connect_go.Request[v1alpha1.TestPackageRepositoryConnectionRequest]) (*connect_go.Response[v1alpha1.TestPackageRepositoryConnectionResponse], error) {
+	return c.testPackageRepositoryConnection.CallUnary(ctx, req)
+}
+
+// ListPackageRepositoryPackages calls
+// kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2RepositoriesService.ListPackageRepositoryPackages.
+func (c *fluxV2RepositoriesServiceClient) ListPackageRepositoryPackages(ctx context.Context, req *connect_go.Request[v1alpha1.ListPackageRepositoryPackagesRequest]) (*connect_go.Response[v1alpha1.ListPackageRepositoryPackagesResponse], error) {
+	return c.listPackageRepositoryPackages.CallUnary(ctx, req)
+}
+
 // FluxV2RepositoriesServiceHandler is an implementation of the
 // kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2RepositoriesService service.
 type FluxV2RepositoriesServiceHandler interface {
@@ -491,6 +705,8 @@ type FluxV2RepositoriesServiceHandler interface {
 	UpdatePackageRepository(context.Context, *connect_go.Request[v1alpha1.UpdatePackageRepositoryRequest]) (*connect_go.Response[v1alpha1.UpdatePackageRepositoryResponse], error)
 	DeletePackageRepository(context.Context, *connect_go.Request[v1alpha1.DeletePackageRepositoryRequest]) (*connect_go.Response[v1alpha1.DeletePackageRepositoryResponse], error)
 	GetPackageRepositoryPermissions(context.Context, *connect_go.Request[v1alpha1.GetPackageRepositoryPermissionsRequest]) (*connect_go.Response[v1alpha1.GetPackageRepositoryPermissionsResponse], error)
+	TestPackageRepositoryConnection(context.Context, *connect_go.Request[v1alpha1.TestPackageRepositoryConnectionRequest]) (*connect_go.Response[v1alpha1.TestPackageRepositoryConnectionResponse], error)
+	ListPackageRepositoryPackages(context.Context, *connect_go.Request[v1alpha1.ListPackageRepositoryPackagesRequest]) (*connect_go.Response[v1alpha1.ListPackageRepositoryPackagesResponse], error)
 }
 
 // NewFluxV2RepositoriesServiceHandler builds an HTTP handler from the service implementation. It
@@ -529,6 +745,16 @@ func NewFluxV2RepositoriesServiceHandler(svc FluxV2RepositoriesServiceHandler, o
 		svc.GetPackageRepositoryPermissions,
 		opts...,
 	)
+	fluxV2RepositoriesServiceTestPackageRepositoryConnectionHandler := connect_go.NewUnaryHandler(
+		FluxV2RepositoriesServiceTestPackageRepositoryConnectionProcedure,
+		svc.TestPackageRepositoryConnection,
+		opts...,
+	)
+	fluxV2RepositoriesServiceListPackageRepositoryPackagesHandler := connect_go.NewUnaryHandler(
+		FluxV2RepositoriesServiceListPackageRepositoryPackagesProcedure,
+		svc.ListPackageRepositoryPackages,
+		opts...,
+	)
 	return "/kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2RepositoriesService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case FluxV2RepositoriesServiceAddPackageRepositoryProcedure:
@@ -543,6 +769,10 @@ func NewFluxV2RepositoriesServiceHandler(svc FluxV2RepositoriesServiceHandler, o
 			fluxV2RepositoriesServiceDeletePackageRepositoryHandler.ServeHTTP(w, r)
 		case FluxV2RepositoriesServiceGetPackageRepositoryPermissionsProcedure:
 			fluxV2RepositoriesServiceGetPackageRepositoryPermissionsHandler.ServeHTTP(w, r)
+		case FluxV2RepositoriesServiceTestPackageRepositoryConnectionProcedure:
+			fluxV2RepositoriesServiceTestPackageRepositoryConnectionHandler.ServeHTTP(w, r)
+		case FluxV2RepositoriesServiceListPackageRepositoryPackagesProcedure:
+			fluxV2RepositoriesServiceListPackageRepositoryPackagesHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -575,3 +805,11 @@ func (UnimplementedFluxV2RepositoriesServiceHandler) DeletePackageRepository(con
 func (UnimplementedFluxV2RepositoriesServiceHandler) GetPackageRepositoryPermissions(context.Context, *connect_go.Request[v1alpha1.GetPackageRepositoryPermissionsRequest]) (*connect_go.Response[v1alpha1.GetPackageRepositoryPermissionsResponse], error) {
 	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2RepositoriesService.GetPackageRepositoryPermissions is not implemented"))
 }
+
+func (UnimplementedFluxV2RepositoriesServiceHandler) TestPackageRepositoryConnection(context.Context, *connect_go.Request[v1alpha1.TestPackageRepositoryConnectionRequest]) (*connect_go.Response[v1alpha1.TestPackageRepositoryConnectionResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2RepositoriesService.TestPackageRepositoryConnection is not implemented"))
+}
+
+func (UnimplementedFluxV2RepositoriesServiceHandler) ListPackageRepositoryPackages(context.Context, *connect_go.Request[v1alpha1.ListPackageRepositoryPackagesRequest]) (*connect_go.Response[v1alpha1.ListPackageRepositoryPackagesResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2RepositoriesService.ListPackageRepositoryPackages is not implemented"))
+}
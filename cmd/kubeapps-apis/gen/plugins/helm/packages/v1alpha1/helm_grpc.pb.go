@@ -23,16 +23,23 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	HelmPackagesService_GetAvailablePackageSummaries_FullMethodName    = "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/GetAvailablePackageSummaries"
-	HelmPackagesService_GetAvailablePackageDetail_FullMethodName       = "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/GetAvailablePackageDetail"
-	HelmPackagesService_GetAvailablePackageVersions_FullMethodName     = "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/GetAvailablePackageVersions"
-	HelmPackagesService_GetInstalledPackageSummaries_FullMethodName    = "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/GetInstalledPackageSummaries"
-	HelmPackagesService_GetInstalledPackageDetail_FullMethodName       = "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/GetInstalledPackageDetail"
-	HelmPackagesService_CreateInstalledPackage_FullMethodName          = "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/CreateInstalledPackage"
-	HelmPackagesService_UpdateInstalledPackage_FullMethodName          = "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/UpdateInstalledPackage"
-	HelmPackagesService_DeleteInstalledPackage_FullMethodName          = "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/DeleteInstalledPackage"
-	HelmPackagesService_RollbackInstalledPackage_FullMethodName        = "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/RollbackInstalledPackage"
-	HelmPackagesService_GetInstalledPackageResourceRefs_FullMethodName = "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/GetInstalledPackageResourceRefs"
+	HelmPackagesService_GetAvailablePackageSummaries_FullMethodName          = "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/GetAvailablePackageSummaries"
+	HelmPackagesService_GetAvailablePackageDetail_FullMethodName             = "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/GetAvailablePackageDetail"
+	HelmPackagesService_GetAvailablePackageVersions_FullMethodName           = "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/GetAvailablePackageVersions"
+	HelmPackagesService_GetAvailablePackageTextAsset_FullMethodName          = "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/GetAvailablePackageTextAsset"
+	HelmPackagesService_GetAvailablePackageChangelog_FullMethodName          = "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/GetAvailablePackageChangelog"
+	HelmPackagesService_GetAvailablePackageFilters_FullMethodName            = "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/GetAvailablePackageFilters"
+	HelmPackagesService_GetInstalledPackageSummaries_FullMethodName          = "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/GetInstalledPackageSummaries"
+	HelmPackagesService_GetInstalledPackageDetail_FullMethodName             = "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/GetInstalledPackageDetail"
+	HelmPackagesService_CheckInstalledPackageNameAvailability_FullMethodName = "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/CheckInstalledPackageNameAvailability"
+	HelmPackagesService_CreateInstalledPackage_FullMethodName                = "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/CreateInstalledPackage"
+	HelmPackagesService_UpdateInstalledPackage_FullMethodName                = "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/UpdateInstalledPackage"
+	HelmPackagesService_DeleteInstalledPackage_FullMethodName                = "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/DeleteInstalledPackage"
+	HelmPackagesService_RollbackInstalledPackage_FullMethodName              = "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/RollbackInstalledPackage"
+	HelmPackagesService_GetInstalledPackageRevisions_FullMethodName          = "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/GetInstalledPackageRevisions"
+	HelmPackagesService_GetInstalledPackageRevision_FullMethodName           = "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/GetInstalledPackageRevision"
+	HelmPackagesService_GetInstalledPackageResourceRefs_FullMethodName       = "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/GetInstalledPackageResourceRefs"
+	HelmPackagesService_GetInstalledPackageOperationStatus_FullMethodName    = "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/GetInstalledPackageOperationStatus"
 )
 
 // HelmPackagesServiceClient is the client API for HelmPackagesService service.
@@ -45,10 +52,18 @@ type HelmPackagesServiceClient interface {
 	GetAvailablePackageDetail(ctx context.Context, in *v1alpha1.GetAvailablePackageDetailRequest, opts ...grpc.CallOption) (*v1alpha1.GetAvailablePackageDetailResponse, error)
 	// GetAvailablePackageVersions returns the package versions managed by the 'helm' plugin
 	GetAvailablePackageVersions(ctx context.Context, in *v1alpha1.GetAvailablePackageVersionsRequest, opts ...grpc.CallOption) (*v1alpha1.GetAvailablePackageVersionsResponse, error)
+	// GetAvailablePackageTextAsset returns a range of a text asset of a package managed by the 'helm' plugin
+	GetAvailablePackageTextAsset(ctx context.Context, in *v1alpha1.GetAvailablePackageTextAssetRequest, opts ...grpc.CallOption) (*v1alpha1.GetAvailablePackageTextAssetResponse, error)
+	// GetAvailablePackageChangelog returns the release notes between two versions of a package managed by the 'helm' plugin
+	GetAvailablePackageChangelog(ctx context.Context, in *v1alpha1.GetAvailablePackageChangelogRequest, opts ...grpc.CallOption) (*v1alpha1.GetAvailablePackageChangelogResponse, error)
+	// GetAvailablePackageFilters returns the filter values of the available packages managed by the 'helm' plugin
+	GetAvailablePackageFilters(ctx context.Context, in *v1alpha1.GetAvailablePackageFiltersRequest, opts ...grpc.CallOption) (*v1alpha1.GetAvailablePackageFiltersResponse, error)
 	// GetInstalledPackageSummaries returns the installed packages managed by the 'helm' plugin
 	GetInstalledPackageSummaries(ctx context.Context, in *v1alpha1.GetInstalledPackageSummariesRequest, opts ...grpc.CallOption) (*v1alpha1.GetInstalledPackageSummariesResponse, error)
 	// GetInstalledPackageDetail returns the requested installed package managed by the 'helm' plugin
 	GetInstalledPackageDetail(ctx context.Context, in *v1alpha1.GetInstalledPackageDetailRequest, opts ...grpc.CallOption) (*v1alpha1.GetInstalledPackageDetailResponse, error)
+	// CheckInstalledPackageNameAvailability checks whether a package managed by the 'helm' plugin can be installed with the given name
+	CheckInstalledPackageNameAvailability(ctx context.Context, in *v1alpha1.CheckInstalledPackageNameAvailabilityRequest, opts ...grpc.CallOption) (*v1alpha1.CheckInstalledPackageNameAvailabilityResponse, error)
 	// CreateInstalledPackage creates an installed package based on the request.
 	CreateInstalledPackage(ctx context.Context, in *v1alpha1.CreateInstalledPackageRequest, opts ...grpc.CallOption) (*v1alpha1.CreateInstalledPackageResponse, error)
 	// UpdateInstalledPackage updates an installed package based on the request.
@@ -57,9 +72,16 @@ type HelmPackagesServiceClient interface {
 	DeleteInstalledPackage(ctx context.Context, in *v1alpha1.DeleteInstalledPackageRequest, opts ...grpc.CallOption) (*v1alpha1.DeleteInstalledPackageResponse, error)
 	// RollbackInstalledPackage updates an installed package based on the request.
 	RollbackInstalledPackage(ctx context.Context, in *RollbackInstalledPackageRequest, opts ...grpc.CallOption) (*RollbackInstalledPackageResponse, error)
+	// GetInstalledPackageRevisions returns the revisions of the Helm release of an installed package.
+	GetInstalledPackageRevisions(ctx context.Context, in *GetInstalledPackageRevisionsRequest, opts ...grpc.CallOption) (*GetInstalledPackageRevisionsResponse, error)
+	// GetInstalledPackageRevision returns a revision of the Helm release of an installed package,
+	// along with its rendered manifest and values.
+	GetInstalledPackageRevision(ctx context.Context, in *GetInstalledPackageRevisionRequest, opts ...grpc.CallOption) (*GetInstalledPackageRevisionResponse, error)
 	// GetInstalledPackageResourceRefs returns the references for the Kubernetes resources created by
 	// an installed package.
 	GetInstalledPackageResourceRefs(ctx context.Context, in *v1alpha1.GetInstalledPackageResourceRefsRequest, opts ...grpc.CallOption) (*v1alpha1.GetInstalledPackageResourceRefsResponse, error)
+	// GetInstalledPackageOperationStatus returns the progress of the operation in course on an installed package managed by the 'helm' plugin
+	GetInstalledPackageOperationStatus(ctx context.Context, in *v1alpha1.GetInstalledPackageOperationStatusRequest, opts ...grpc.CallOption) (*v1alpha1.GetInstalledPackageOperationStatusResponse, error)
 }
 
 type helmPackagesServiceClient struct {
@@ -97,6 +119,33 @@ func (c *helmPackagesServiceClient) GetAvailablePackageVersions(ctx context.Cont
 	return out, nil
 }
 
+func (c *helmPackagesServiceClient) GetAvailablePackageTextAsset(ctx context.Context, in *v1alpha1.GetAvailablePackageTextAssetRequest, opts ...grpc.CallOption) (*v1alpha1.GetAvailablePackageTextAssetResponse, error) {
+	out := new(v1alpha1.GetAvailablePackageTextAssetResponse)
+	err := c.cc.Invoke(ctx, HelmPackagesService_GetAvailablePackageTextAsset_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *helmPackagesServiceClient) GetAvailablePackageChangelog(ctx context.Context, in *v1alpha1.GetAvailablePackageChangelogRequest, opts ...grpc.CallOption) (*v1alpha1.GetAvailablePackageChangelogResponse, error) {
+	out := new(v1alpha1.GetAvailablePackageChangelogResponse)
+	err := c.cc.Invoke(ctx, HelmPackagesService_GetAvailablePackageChangelog_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *helmPackagesServiceClient) GetAvailablePackageFilters(ctx context.Context, in *v1alpha1.GetAvailablePackageFiltersRequest, opts ...grpc.CallOption) (*v1alpha1.GetAvailablePackageFiltersResponse, error) {
+	out := new(v1alpha1.GetAvailablePackageFiltersResponse)
+	err := c.cc.Invoke(ctx, HelmPackagesService_GetAvailablePackageFilters_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *helmPackagesServiceClient) GetInstalledPackageSummaries(ctx context.Context, in *v1alpha1.GetInstalledPackageSummariesRequest, opts ...grpc.CallOption) (*v1alpha1.GetInstalledPackageSummariesResponse, error) {
 	out := new(v1alpha1.GetInstalledPackageSummariesResponse)
 	err := c.cc.Invoke(ctx, HelmPackagesService_GetInstalledPackageSummaries_FullMethodName, in, out, opts...)
@@ -115,6 +164,15 @@ func (c *helmPackagesServiceClient) GetInstalledPackageDetail(ctx context.Contex
 	return out, nil
 }
 
+func (c *helmPackagesServiceClient) CheckInstalledPackageNameAvailability(ctx context.Context, in *v1alpha1.CheckInstalledPackageNameAvailabilityRequest, opts ...grpc.CallOption) (*v1alpha1.CheckInstalledPackageNameAvailabilityResponse, error) {
+	out := new(v1alpha1.CheckInstalledPackageNameAvailabilityResponse)
+	err := c.cc.Invoke(ctx, HelmPackagesService_CheckInstalledPackageNameAvailability_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *helmPackagesServiceClient) CreateInstalledPackage(ctx context.Context, in *v1alpha1.CreateInstalledPackageRequest, opts ...grpc.CallOption) (*v1alpha1.CreateInstalledPackageResponse, error) {
 	out := new(v1alpha1.CreateInstalledPackageResponse)
 	err := c.cc.Invoke(ctx, HelmPackagesService_CreateInstalledPackage_FullMethodName, in, out, opts...)
@@ -151,6 +209,24 @@ func (c *helmPackagesServiceClient) RollbackInstalledPackage(ctx context.Context
 	return out, nil
 }
 
+func (c *helmPackagesServiceClient) GetInstalledPackageRevisions(ctx context.Context, in *GetInstalledPackageRevisionsRequest, opts ...grpc.CallOption) (*GetInstalledPackageRevisionsResponse, error) {
+	out := new(GetInstalledPackageRevisionsResponse)
+	err := c.cc.Invoke(ctx, HelmPackagesService_GetInstalledPackageRevisions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *helmPackagesServiceClient) GetInstalledPackageRevision(ctx context.Context, in *GetInstalledPackageRevisionRequest, opts ...grpc.CallOption) (*GetInstalledPackageRevisionResponse, error) {
+	out := new(GetInstalledPackageRevisionResponse)
+	err := c.cc.Invoke(ctx, HelmPackagesService_GetInstalledPackageRevision_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *helmPackagesServiceClient) GetInstalledPackageResourceRefs(ctx context.Context, in *v1alpha1.GetInstalledPackageResourceRefsRequest, opts ...grpc.CallOption) (*v1alpha1.GetInstalledPackageResourceRefsResponse, error) {
 	out := new(v1alpha1.GetInstalledPackageResourceRefsResponse)
 	err := c.cc.Invoke(ctx, HelmPackagesService_GetInstalledPackageResourceRefs_FullMethodName, in, out, opts...)
@@ -160,6 +236,15 @@ func (c *helmPackagesServiceClient) GetInstalledPackageResourceRefs(ctx context.
 	return out, nil
 }
 
+func (c *helmPackagesServiceClient) GetInstalledPackageOperationStatus(ctx context.Context, in *v1alpha1.GetInstalledPackageOperationStatusRequest, opts ...grpc.CallOption) (*v1alpha1.GetInstalledPackageOperationStatusResponse, error) {
+	out := new(v1alpha1.GetInstalledPackageOperationStatusResponse)
+	err := c.cc.Invoke(ctx, HelmPackagesService_GetInstalledPackageOperationStatus_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // HelmPackagesServiceServer is the server API for HelmPackagesService service.
 // All implementations should embed UnimplementedHelmPackagesServiceServer
 // for forward compatibility
@@ -170,10 +255,18 @@ type HelmPackagesServiceServer interface {
 	GetAvailablePackageDetail(context.Context, *v1alpha1.GetAvailablePackageDetailRequest) (*v1alpha1.GetAvailablePackageDetailResponse, error)
 	// GetAvailablePackageVersions returns the package versions managed by the 'helm' plugin
 	GetAvailablePackageVersions(context.Context, *v1alpha1.GetAvailablePackageVersionsRequest) (*v1alpha1.GetAvailablePackageVersionsResponse, error)
+	// GetAvailablePackageTextAsset returns a range of a text asset of a package managed by the 'helm' plugin
+	GetAvailablePackageTextAsset(context.Context, *v1alpha1.GetAvailablePackageTextAssetRequest) (*v1alpha1.GetAvailablePackageTextAssetResponse, error)
+	// GetAvailablePackageChangelog returns the release notes between two versions of a package managed by the 'helm' plugin
+	GetAvailablePackageChangelog(context.Context, *v1alpha1.GetAvailablePackageChangelogRequest) (*v1alpha1.GetAvailablePackageChangelogResponse, error)
+	// GetAvailablePackageFilters returns the filter values of the available packages managed by the 'helm' plugin
+	GetAvailablePackageFilters(context.Context, *v1alpha1.GetAvailablePackageFiltersRequest) (*v1alpha1.GetAvailablePackageFiltersResponse, error)
 	// GetInstalledPackageSummaries returns the installed packages managed by the 'helm' plugin
 	GetInstalledPackageSummaries(context.Context, *v1alpha1.GetInstalledPackageSummariesRequest) (*v1alpha1.GetInstalledPackageSummariesResponse, error)
 	// GetInstalledPackageDetail returns the requested installed package managed by the 'helm' plugin
 	GetInstalledPackageDetail(context.Context, *v1alpha1.GetInstalledPackageDetailRequest) (*v1alpha1.GetInstalledPackageDetailResponse, error)
+	// CheckInstalledPackageNameAvailability checks whether a package managed by the 'helm' plugin can be installed with the given name
+	CheckInstalledPackageNameAvailability(context.Context, *v1alpha1.CheckInstalledPackageNameAvailabilityRequest) (*v1alpha1.CheckInstalledPackageNameAvailabilityResponse, error)
 	// CreateInstalledPackage creates an installed package based on the request.
 	CreateInstalledPackage(context.Context, *v1alpha1.CreateInstalledPackageRequest) (*v1alpha1.CreateInstalledPackageResponse, error)
 	// UpdateInstalledPackage updates an installed package based on the request.
@@ -182,9 +275,16 @@ type HelmPackagesServiceServer interface {
 	DeleteInstalledPackage(context.Context, *v1alpha1.DeleteInstalledPackageRequest) (*v1alpha1.DeleteInstalledPackageResponse, error)
 	// RollbackInstalledPackage updates an installed package based on the request.
 	RollbackInstalledPackage(context.Context, *RollbackInstalledPackageRequest) (*RollbackInstalledPackageResponse, error)
+	// GetInstalledPackageRevisions returns the revisions of the Helm release of an installed package.
+	GetInstalledPackageRevisions(context.Context, *GetInstalledPackageRevisionsRequest) (*GetInstalledPackageRevisionsResponse, error)
+	// GetInstalledPackageRevision returns a revision of the Helm release of an installed package,
+	// along with its rendered manifest and values.
+	GetInstalledPackageRevision(context.Context, *GetInstalledPackageRevisionRequest) (*GetInstalledPackageRevisionResponse, error)
 	// GetInstalledPackageResourceRefs returns the references for the Kubernetes resources created by
 	// an installed package.
 	GetInstalledPackageResourceRefs(context.Context, *v1alpha1.GetInstalledPackageResourceRefsRequest) (*v1alpha1.GetInstalledPackageResourceRefsResponse, error)
+	// GetInstalledPackageOperationStatus returns the progress of the operation in course on an installed package managed by the 'helm' plugin
+	GetInstalledPackageOperationStatus(context.Context, *v1alpha1.GetInstalledPackageOperationStatusRequest) (*v1alpha1.GetInstalledPackageOperationStatusResponse, error)
 }
 
 // UnimplementedHelmPackagesServiceServer should be embedded to have forward compatible implementations.
@@ -200,12 +300,24 @@ func (UnimplementedHelmPackagesServiceServer) GetAvailablePackageDetail(context.
 func (UnimplementedHelmPackagesServiceServer) GetAvailablePackageVersions(context.Context, *v1alpha1.GetAvailablePackageVersionsRequest) (*v1alpha1.GetAvailablePackageVersionsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAvailablePackageVersions not implemented")
 }
+func (UnimplementedHelmPackagesServiceServer) GetAvailablePackageTextAsset(context.Context, *v1alpha1.GetAvailablePackageTextAssetRequest) (*v1alpha1.GetAvailablePackageTextAssetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAvailablePackageTextAsset not implemented")
+}
+func (UnimplementedHelmPackagesServiceServer) GetAvailablePackageChangelog(context.Context, *v1alpha1.GetAvailablePackageChangelogRequest) (*v1alpha1.GetAvailablePackageChangelogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAvailablePackageChangelog not implemented")
+}
+func (UnimplementedHelmPackagesServiceServer) GetAvailablePackageFilters(context.Context, *v1alpha1.GetAvailablePackageFiltersRequest) (*v1alpha1.GetAvailablePackageFiltersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAvailablePackageFilters not implemented")
+}
 func (UnimplementedHelmPackagesServiceServer) GetInstalledPackageSummaries(context.Context, *v1alpha1.GetInstalledPackageSummariesRequest) (*v1alpha1.GetInstalledPackageSummariesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetInstalledPackageSummaries not implemented")
 }
 func (UnimplementedHelmPackagesServiceServer) GetInstalledPackageDetail(context.Context, *v1alpha1.GetInstalledPackageDetailRequest) (*v1alpha1.GetInstalledPackageDetailResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetInstalledPackageDetail not implemented")
 }
+func (UnimplementedHelmPackagesServiceServer) CheckInstalledPackageNameAvailability(context.Context, *v1alpha1.CheckInstalledPackageNameAvailabilityRequest) (*v1alpha1.CheckInstalledPackageNameAvailabilityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckInstalledPackageNameAvailability not implemented")
+}
 func (UnimplementedHelmPackagesServiceServer) CreateInstalledPackage(context.Context, *v1alpha1.CreateInstalledPackageRequest) (*v1alpha1.CreateInstalledPackageResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateInstalledPackage not implemented")
 }
@@ -218,9 +330,18 @@ func (UnimplementedHelmPackagesServiceServer) DeleteInstalledPackage(context.Con
 func (UnimplementedHelmPackagesServiceServer) RollbackInstalledPackage(context.Context, *RollbackInstalledPackageRequest) (*RollbackInstalledPackageResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RollbackInstalledPackage not implemented")
 }
+func (UnimplementedHelmPackagesServiceServer) GetInstalledPackageRevisions(context.Context, *GetInstalledPackageRevisionsRequest) (*GetInstalledPackageRevisionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInstalledPackageRevisions not implemented")
+}
+func (UnimplementedHelmPackagesServiceServer) GetInstalledPackageRevision(context.Context, *GetInstalledPackageRevisionRequest) (*GetInstalledPackageRevisionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInstalledPackageRevision not implemented")
+}
 func (UnimplementedHelmPackagesServiceServer) GetInstalledPackageResourceRefs(context.Context, *v1alpha1.GetInstalledPackageResourceRefsRequest) (*v1alpha1.GetInstalledPackageResourceRefsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetInstalledPackageResourceRefs not implemented")
 }
+func (UnimplementedHelmPackagesServiceServer) GetInstalledPackageOperationStatus(context.Context, *v1alpha1.GetInstalledPackageOperationStatusRequest) (*v1alpha1.GetInstalledPackageOperationStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInstalledPackageOperationStatus not implemented")
+}
 
 // UnsafeHelmPackagesServiceServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to HelmPackagesServiceServer will
@@ -287,6 +408,60 @@ func _HelmPackagesService_GetAvailablePackageVersions_Handler(srv interface{}, c
 	return interceptor(ctx, in, info, handler)
 }
 
+func _HelmPackagesService_GetAvailablePackageTextAsset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha1.GetAvailablePackageTextAssetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HelmPackagesServiceServer).GetAvailablePackageTextAsset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HelmPackagesService_GetAvailablePackageTextAsset_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HelmPackagesServiceServer).GetAvailablePackageTextAsset(ctx, req.(*v1alpha1.GetAvailablePackageTextAssetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HelmPackagesService_GetAvailablePackageChangelog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha1.GetAvailablePackageChangelogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HelmPackagesServiceServer).GetAvailablePackageChangelog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HelmPackagesService_GetAvailablePackageChangelog_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HelmPackagesServiceServer).GetAvailablePackageChangelog(ctx, req.(*v1alpha1.GetAvailablePackageChangelogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HelmPackagesService_GetAvailablePackageFilters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha1.GetAvailablePackageFiltersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HelmPackagesServiceServer).GetAvailablePackageFilters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HelmPackagesService_GetAvailablePackageFilters_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HelmPackagesServiceServer).GetAvailablePackageFilters(ctx, req.(*v1alpha1.GetAvailablePackageFiltersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _HelmPackagesService_GetInstalledPackageSummaries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(v1alpha1.GetInstalledPackageSummariesRequest)
 	if err := dec(in); err != nil {
@@ -323,6 +498,24 @@ func _HelmPackagesService_GetInstalledPackageDetail_Handler(srv interface{}, ctx
 	return interceptor(ctx, in, info, handler)
 }
 
+func _HelmPackagesService_CheckInstalledPackageNameAvailability_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha1.CheckInstalledPackageNameAvailabilityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HelmPackagesServiceServer).CheckInstalledPackageNameAvailability(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HelmPackagesService_CheckInstalledPackageNameAvailability_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HelmPackagesServiceServer).CheckInstalledPackageNameAvailability(ctx, req.(*v1alpha1.CheckInstalledPackageNameAvailabilityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _HelmPackagesService_CreateInstalledPackage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(v1alpha1.CreateInstalledPackageRequest)
 	if err := dec(in); err != nil {
@@ -395,6 +588,42 @@ func _HelmPackagesService_RollbackInstalledPackage_Handler(srv interface{}, ctx
 	return interceptor(ctx, in, info, handler)
 }
 
+func _HelmPackagesService_GetInstalledPackageRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInstalledPackageRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HelmPackagesServiceServer).GetInstalledPackageRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HelmPackagesService_GetInstalledPackageRevisions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HelmPackagesServiceServer).GetInstalledPackageRevisions(ctx, req.(*GetInstalledPackageRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HelmPackagesService_GetInstalledPackageRevision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInstalledPackageRevisionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HelmPackagesServiceServer).GetInstalledPackageRevision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HelmPackagesService_GetInstalledPackageRevision_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HelmPackagesServiceServer).GetInstalledPackageRevision(ctx, req.(*GetInstalledPackageRevisionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _HelmPackagesService_GetInstalledPackageResourceRefs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(v1alpha1.GetInstalledPackageResourceRefsRequest)
 	if err := dec(in); err != nil {
@@ -413,6 +642,24 @@ func _HelmPackagesService_GetInstalledPackageResourceRefs_Handler(srv interface{
 	return interceptor(ctx, in, info, handler)
 }
 
+func _HelmPackagesService_GetInstalledPackageOperationStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha1.GetInstalledPackageOperationStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HelmPackagesServiceServer).GetInstalledPackageOperationStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HelmPackagesService_GetInstalledPackageOperationStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HelmPackagesServiceServer).GetInstalledPackageOperationStatus(ctx, req.(*v1alpha1.GetInstalledPackageOperationStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // HelmPackagesService_ServiceDesc is the grpc.ServiceDesc for HelmPackagesService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -432,6 +679,18 @@ var HelmPackagesService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetAvailablePackageVersions",
 			Handler:    _HelmPackagesService_GetAvailablePackageVersions_Handler,
 		},
+		{
+			MethodName: "GetAvailablePackageTextAsset",
+			Handler:    _HelmPackagesService_GetAvailablePackageTextAsset_Handler,
+		},
+		{
+			MethodName: "GetAvailablePackageChangelog",
+			Handler:    _HelmPackagesService_GetAvailablePackageChangelog_Handler,
+		},
+		{
+			MethodName: "GetAvailablePackageFilters",
+			Handler:    _HelmPackagesService_GetAvailablePackageFilters_Handler,
+		},
 		{
 			MethodName: "GetInstalledPackageSummaries",
 			Handler:    _HelmPackagesService_GetInstalledPackageSummaries_Handler,
@@ -440,6 +699,10 @@ var HelmPackagesService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetInstalledPackageDetail",
 			Handler:    _HelmPackagesService_GetInstalledPackageDetail_Handler,
 		},
+		{
+			MethodName: "CheckInstalledPackageNameAvailability",
+			Handler:    _HelmPackagesService_CheckInstalledPackageNameAvailability_Handler,
+		},
 		{
 			MethodName: "CreateInstalledPackage",
 			Handler:    _HelmPackagesService_CreateInstalledPackage_Handler,
@@ -456,10 +719,22 @@ var HelmPackagesService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RollbackInstalledPackage",
 			Handler:    _HelmPackagesService_RollbackInstalledPackage_Handler,
 		},
+		{
+			MethodName: "GetInstalledPackageRevisions",
+			Handler:    _HelmPackagesService_GetInstalledPackageRevisions_Handler,
+		},
+		{
+			MethodName: "GetInstalledPackageRevision",
+			Handler:    _HelmPackagesService_GetInstalledPackageRevision_Handler,
+		},
 		{
 			MethodName: "GetInstalledPackageResourceRefs",
 			Handler:    _HelmPackagesService_GetInstalledPackageResourceRefs_Handler,
 		},
+		{
+			MethodName: "GetInstalledPackageOperationStatus",
+			Handler:    _HelmPackagesService_GetInstalledPackageOperationStatus_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "kubeappsapis/plugins/helm/packages/v1alpha1/helm.proto",
@@ -472,6 +747,8 @@ const (
 	HelmRepositoriesService_UpdatePackageRepository_FullMethodName         = "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmRepositoriesService/UpdatePackageRepository"
 	HelmRepositoriesService_DeletePackageRepository_FullMethodName         = "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmRepositoriesService/DeletePackageRepository"
 	HelmRepositoriesService_GetPackageRepositoryPermissions_FullMethodName = "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmRepositoriesService/GetPackageRepositoryPermissions"
+	HelmRepositoriesService_TestPackageRepositoryConnection_FullMethodName = "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmRepositoriesService/TestPackageRepositoryConnection"
+	HelmRepositoriesService_ListPackageRepositoryPackages_FullMethodName   = "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmRepositoriesService/ListPackageRepositoryPackages"
 )
 
 // HelmRepositoriesServiceClient is the client API for HelmRepositoriesService service.
@@ -485,6 +762,8 @@ type HelmRepositoriesServiceClient interface {
 	UpdatePackageRepository(ctx context.Context, in *v1alpha1.UpdatePackageRepositoryRequest, opts ...grpc.CallOption) (*v1alpha1.UpdatePackageRepositoryResponse, error)
 	DeletePackageRepository(ctx context.Context, in *v1alpha1.DeletePackageRepositoryRequest, opts ...grpc.CallOption) (*v1alpha1.DeletePackageRepositoryResponse, error)
 	GetPackageRepositoryPermissions(ctx context.Context, in *v1alpha1.GetPackageRepositoryPermissionsRequest, opts ...grpc.CallOption) (*v1alpha1.GetPackageRepositoryPermissionsResponse, error)
+	TestPackageRepositoryConnection(ctx context.Context, in *v1alpha1.TestPackageRepositoryConnectionRequest, opts ...grpc.CallOption) (*v1alpha1.TestPackageRepositoryConnectionResponse, error)
+	ListPackageRepositoryPackages(ctx context.Context, in *v1alpha1.ListPackageRepositoryPackagesRequest, opts ...grpc.CallOption) (*v1alpha1.ListPackageRepositoryPackagesResponse, error)
 }
 
 type helmRepositoriesServiceClient struct {
@@ -549,6 +828,24 @@ func (c *helmRepositoriesServiceClient) GetPackageRepositoryPermissions(ctx cont
 	return out, nil
 }
 
+func (c *helmRepositoriesServiceClient) TestPackageRepositoryConnection(ctx context.Context, in *v1alpha1.TestPackageRepositoryConnectionRequest, opts ...grpc.CallOption) (*v1alpha1.TestPackageRepositoryConnectionResponse, error) {
+	out := new(v1alpha1.TestPackageRepositoryConnectionResponse)
+	err := c.cc.Invoke(ctx, HelmRepositoriesService_TestPackageRepositoryConnection_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *helmRepositoriesServiceClient) ListPackageRepositoryPackages(ctx context.Context, in *v1alpha1.ListPackageRepositoryPackagesRequest, opts ...grpc.CallOption) (*v1alpha1.ListPackageRepositoryPackagesResponse, error) {
+	out := new(v1alpha1.ListPackageRepositoryPackagesResponse)
+	err := c.cc.Invoke(ctx, HelmRepositoriesService_ListPackageRepositoryPackages_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // HelmRepositoriesServiceServer is the server API for HelmRepositoriesService service.
 // All implementations should embed UnimplementedHelmRepositoriesServiceServer
 // for forward compatibility
@@ -560,6 +857,8 @@ type HelmRepositoriesServiceServer interface {
 	UpdatePackageRepository(context.Context, *v1alpha1.UpdatePackageRepositoryRequest) (*v1alpha1.UpdatePackageRepositoryResponse, error)
 	DeletePackageRepository(context.Context, *v1alpha1.DeletePackageRepositoryRequest) (*v1alpha1.DeletePackageRepositoryResponse, error)
 	GetPackageRepositoryPermissions(context.Context, *v1alpha1.GetPackageRepositoryPermissionsRequest) (*v1alpha1.GetPackageRepositoryPermissionsResponse, error)
+	TestPackageRepositoryConnection(context.Context, *v1alpha1.TestPackageRepositoryConnectionRequest) (*v1alpha1.TestPackageRepositoryConnectionResponse, error)
+	ListPackageRepositoryPackages(context.Context, *v1alpha1.ListPackageRepositoryPackagesRequest) (*v1alpha1.ListPackageRepositoryPackagesResponse, error)
 }
 
 // UnimplementedHelmRepositoriesServiceServer should be embedded to have forward compatible implementations.
@@ -584,6 +883,12 @@ func (UnimplementedHelmRepositoriesServiceServer) DeletePackageRepository(contex
 func (UnimplementedHelmRepositoriesServiceServer) GetPackageRepositoryPermissions(context.Context, *v1alpha1.GetPackageRepositoryPermissionsRequest) (*v1alpha1.GetPackageRepositoryPermissionsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetPackageRepositoryPermissions not implemented")
 }
+func (UnimplementedHelmRepositoriesServiceServer) TestPackageRepositoryConnection(context.Context, *v1alpha1.TestPackageRepositoryConnectionRequest) (*v1alpha1.TestPackageRepositoryConnectionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TestPackageRepositoryConnection not implemented")
+}
+func (UnimplementedHelmRepositoriesServiceServer) ListPackageRepositoryPackages(context.Context, *v1alpha1.ListPackageRepositoryPackagesRequest) (*v1alpha1.ListPackageRepositoryPackagesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPackageRepositoryPackages not implemented")
+}
 
 // UnsafeHelmRepositoriesServiceServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to HelmRepositoriesServiceServer will
@@ -704,6 +1009,42 @@ func _HelmRepositoriesService_GetPackageRepositoryPermissions_Handler(srv interf
 	return interceptor(ctx, in, info, handler)
 }
 
+func _HelmRepositoriesService_TestPackageRepositoryConnection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha1.TestPackageRepositoryConnectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HelmRepositoriesServiceServer).TestPackageRepositoryConnection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HelmRepositoriesService_TestPackageRepositoryConnection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HelmRepositoriesServiceServer).TestPackageRepositoryConnection(ctx, req.(*v1alpha1.TestPackageRepositoryConnectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HelmRepositoriesService_ListPackageRepositoryPackages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha1.ListPackageRepositoryPackagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HelmRepositoriesServiceServer).ListPackageRepositoryPackages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HelmRepositoriesService_ListPackageRepositoryPackages_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HelmRepositoriesServiceServer).ListPackageRepositoryPackages(ctx, req.(*v1alpha1.ListPackageRepositoryPackagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // HelmRepositoriesService_ServiceDesc is the grpc.ServiceDesc for HelmRepositoriesService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -735,6 +1076,14 @@ var HelmRepositoriesService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetPackageRepositoryPermissions",
 			Handler:    _HelmRepositoriesService_GetPackageRepositoryPermissions_Handler,
 		},
+		{
+			MethodName: "TestPackageRepositoryConnection",
+			Handler:    _HelmRepositoriesService_TestPackageRepositoryConnection_Handler,
+		},
+		{
+			MethodName: "ListPackageRepositoryPackages",
+			Handler:    _HelmRepositoriesService_ListPackageRepositoryPackages_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "kubeappsapis/plugins/helm/packages/v1alpha1/helm.proto",
@@ -48,12 +48,24 @@ const (
 	// HelmPackagesServiceGetAvailablePackageVersionsProcedure is the fully-qualified name of the
 	// HelmPackagesService's GetAvailablePackageVersions RPC.
 	HelmPackagesServiceGetAvailablePackageVersionsProcedure = "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/GetAvailablePackageVersions"
+	// HelmPackagesServiceGetAvailablePackageTextAssetProcedure is the fully-qualified name of the
+	// HelmPackagesService's GetAvailablePackageTextAsset RPC.
+	HelmPackagesServiceGetAvailablePackageTextAssetProcedure = "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/GetAvailablePackageTextAsset"
+	// HelmPackagesServiceGetAvailablePackageChangelogProcedure is the fully-qualified name of the
+	// HelmPackagesService's GetAvailablePackageChangelog RPC.
+	HelmPackagesServiceGetAvailablePackageChangelogProcedure = "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/GetAvailablePackageChangelog"
+	// HelmPackagesServiceGetAvailablePackageFiltersProcedure is the fully-qualified name of the
+	// HelmPackagesService's GetAvailablePackageFilters RPC.
+	HelmPackagesServiceGetAvailablePackageFiltersProcedure = "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/GetAvailablePackageFilters"
 	// HelmPackagesServiceGetInstalledPackageSummariesProcedure is the fully-qualified name of the
 	// HelmPackagesService's GetInstalledPackageSummaries RPC.
 	HelmPackagesServiceGetInstalledPackageSummariesProcedure = "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/GetInstalledPackageSummaries"
 	// HelmPackagesServiceGetInstalledPackageDetailProcedure is the fully-qualified name of the
 	// HelmPackagesService's GetInstalledPackageDetail RPC.
 	HelmPackagesServiceGetInstalledPackageDetailProcedure = "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/GetInstalledPackageDetail"
+	// HelmPackagesServiceCheckInstalledPackageNameAvailabilityProcedure is the fully-qualified name of
+	// the HelmPackagesService's CheckInstalledPackageNameAvailability RPC.
+	HelmPackagesServiceCheckInstalledPackageNameAvailabilityProcedure = "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/CheckInstalledPackageNameAvailability"
 	// HelmPackagesServiceCreateInstalledPackageProcedure is the fully-qualified name of the
 	// HelmPackagesService's CreateInstalledPackage RPC.
 	HelmPackagesServiceCreateInstalledPackageProcedure = "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/CreateInstalledPackage"
@@ -66,9 +78,18 @@ const (
 	// HelmPackagesServiceRollbackInstalledPackageProcedure is the fully-qualified name of the
 	// HelmPackagesService's RollbackInstalledPackage RPC.
 	HelmPackagesServiceRollbackInstalledPackageProcedure = "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/RollbackInstalledPackage"
+	// HelmPackagesServiceGetInstalledPackageRevisionsProcedure is the fully-qualified name of the
+	// HelmPackagesService's GetInstalledPackageRevisions RPC.
+	HelmPackagesServiceGetInstalledPackageRevisionsProcedure = "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/GetInstalledPackageRevisions"
+	// HelmPackagesServiceGetInstalledPackageRevisionProcedure is the fully-qualified name of the
+	// HelmPackagesService's GetInstalledPackageRevision RPC.
+	HelmPackagesServiceGetInstalledPackageRevisionProcedure = "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/GetInstalledPackageRevision"
 	// HelmPackagesServiceGetInstalledPackageResourceRefsProcedure is the fully-qualified name of the
 	// HelmPackagesService's GetInstalledPackageResourceRefs RPC.
 	HelmPackagesServiceGetInstalledPackageResourceRefsProcedure = "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/GetInstalledPackageResourceRefs"
+	// HelmPackagesServiceGetInstalledPackageOperationStatusProcedure is the fully-qualified name of the
+	// HelmPackagesService's GetInstalledPackageOperationStatus RPC.
+	HelmPackagesServiceGetInstalledPackageOperationStatusProcedure = "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/GetInstalledPackageOperationStatus"
 	// HelmRepositoriesServiceAddPackageRepositoryProcedure is the fully-qualified name of the
 	// HelmRepositoriesService's AddPackageRepository RPC.
 	HelmRepositoriesServiceAddPackageRepositoryProcedure = "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmRepositoriesService/AddPackageRepository"
@@ -87,6 +108,12 @@ const (
 	// HelmRepositoriesServiceGetPackageRepositoryPermissionsProcedure is the fully-qualified name of
 	// the HelmRepositoriesService's GetPackageRepositoryPermissions RPC.
 	HelmRepositoriesServiceGetPackageRepositoryPermissionsProcedure = "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmRepositoriesService/GetPackageRepositoryPermissions"
+	// HelmRepositoriesServiceTestPackageRepositoryConnectionProcedure is the fully-qualified name of
+	// the HelmRepositoriesService's TestPackageRepositoryConnection RPC.
+	HelmRepositoriesServiceTestPackageRepositoryConnectionProcedure = "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmRepositoriesService/TestPackageRepositoryConnection"
+	// HelmRepositoriesServiceListPackageRepositoryPackagesProcedure is the fully-qualified name of the
+	// HelmRepositoriesService's ListPackageRepositoryPackages RPC.
+	HelmRepositoriesServiceListPackageRepositoryPackagesProcedure = "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmRepositoriesService/ListPackageRepositoryPackages"
 )
 
 // HelmPackagesServiceClient is a client for the
@@ -98,10 +125,18 @@ type HelmPackagesServiceClient interface {
 	GetAvailablePackageDetail(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageDetailRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageDetailResponse], error)
 	// GetAvailablePackageVersions returns the package versions managed by the 'helm' plugin
 	GetAvailablePackageVersions(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageVersionsRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageVersionsResponse], error)
+	// GetAvailablePackageTextAsset returns a range of a text asset of a package managed by the 'helm' plugin
+	GetAvailablePackageTextAsset(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageTextAssetRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageTextAssetResponse], error)
+	// GetAvailablePackageChangelog returns the release notes between two versions of a package managed by the 'helm' plugin
+	GetAvailablePackageChangelog(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageChangelogRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageChangelogResponse], error)
+	// GetAvailablePackageFilters returns the filter values of the available packages managed by the 'helm' plugin
+	GetAvailablePackageFilters(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageFiltersRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageFiltersResponse], error)
 	// GetInstalledPackageSummaries returns the installed packages managed by the 'helm' plugin
 	GetInstalledPackageSummaries(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageSummariesRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageSummariesResponse], error)
 	// GetInstalledPackageDetail returns the requested installed package managed by the 'helm' plugin
 	GetInstalledPackageDetail(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageDetailRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageDetailResponse], error)
+	// CheckInstalledPackageNameAvailability checks whether a package managed by the 'helm' plugin can be installed with the given name
+	CheckInstalledPackageNameAvailability(context.Context, *connect_go.Request[v1alpha1.CheckInstalledPackageNameAvailabilityRequest]) (*connect_go.Response[v1alpha1.CheckInstalledPackageNameAvailabilityResponse], error)
 	// CreateInstalledPackage creates an installed package based on the request.
 	CreateInstalledPackage(context.Context, *connect_go.Request[v1alpha1.CreateInstalledPackageRequest]) (*connect_go.Response[v1alpha1.CreateInstalledPackageResponse], error)
 	// UpdateInstalledPackage updates an installed package based on the request.
@@ -110,9 +145,16 @@ type HelmPackagesServiceClient interface {
 	DeleteInstalledPackage(context.Context, *connect_go.Request[v1alpha1.DeleteInstalledPackageRequest]) (*connect_go.Response[v1alpha1.DeleteInstalledPackageResponse], error)
 	// RollbackInstalledPackage updates an installed package based on the request.
 	RollbackInstalledPackage(context.Context, *connect_go.Request[v1alpha11.RollbackInstalledPackageRequest]) (*connect_go.Response[v1alpha11.RollbackInstalledPackageResponse], error)
+	// GetInstalledPackageRevisions returns the revisions of the Helm release of an installed package.
+	GetInstalledPackageRevisions(context.Context, *connect_go.Request[v1alpha11.GetInstalledPackageRevisionsRequest]) (*connect_go.Response[v1alpha11.GetInstalledPackageRevisionsResponse], error)
+	// GetInstalledPackageRevision returns a revision of the Helm release of an installed package,
+	// along with its rendered manifest and values.
+	GetInstalledPackageRevision(context.Context, *connect_go.Request[v1alpha11.GetInstalledPackageRevisionRequest]) (*connect_go.Response[v1alpha11.GetInstalledPackageRevisionResponse], error)
 	// GetInstalledPackageResourceRefs returns the references for the Kubernetes resources created by
 	// an installed package.
 	GetInstalledPackageResourceRefs(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageResourceRefsRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageResourceRefsResponse], error)
+	// GetInstalledPackageOperationStatus returns the progress of the operation in course on an installed package managed by the 'helm' plugin
+	GetInstalledPackageOperationStatus(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageOperationStatusRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageOperationStatusResponse], error)
 }
 
 // NewHelmPackagesServiceClient constructs a client for the
@@ -141,6 +183,21 @@ func NewHelmPackagesServiceClient(httpClient connect_go.HTTPClient, baseURL stri
 			baseURL+HelmPackagesServiceGetAvailablePackageVersionsProcedure,
 			opts...,
 		),
+		getAvailablePackageTextAsset: connect_go.NewClient[v1alpha1.GetAvailablePackageTextAssetRequest, v1alpha1.GetAvailablePackageTextAssetResponse](
+			httpClient,
+			baseURL+HelmPackagesServiceGetAvailablePackageTextAssetProcedure,
+			opts...,
+		),
+		getAvailablePackageChangelog: connect_go.NewClient[v1alpha1.GetAvailablePackageChangelogRequest, v1alpha1.GetAvailablePackageChangelogResponse](
+			httpClient,
+			baseURL+HelmPackagesServiceGetAvailablePackageChangelogProcedure,
+			opts...,
+		),
+		getAvailablePackageFilters: connect_go.NewClient[v1alpha1.GetAvailablePackageFiltersRequest, v1alpha1.GetAvailablePackageFiltersResponse](
+			httpClient,
+			baseURL+HelmPackagesServiceGetAvailablePackageFiltersProcedure,
+			opts...,
+		),
 		getInstalledPackageSummaries: connect_go.NewClient[v1alpha1.GetInstalledPackageSummariesRequest, v1alpha1.GetInstalledPackageSummariesResponse](
 			httpClient,
 			baseURL+HelmPackagesServiceGetInstalledPackageSummariesProcedure,
@@ -151,6 +208,11 @@ func NewHelmPackagesServiceClient(httpClient connect_go.HTTPClient, baseURL stri
 			baseURL+HelmPackagesServiceGetInstalledPackageDetailProcedure,
 			opts...,
 		),
+		checkInstalledPackageNameAvailability: connect_go.NewClient[v1alpha1.CheckInstalledPackageNameAvailabilityRequest, v1alpha1.CheckInstalledPackageNameAvailabilityResponse](
+			httpClient,
+			baseURL+HelmPackagesServiceCheckInstalledPackageNameAvailabilityProcedure,
+			opts...,
+		),
 		createInstalledPackage: connect_go.NewClient[v1alpha1.CreateInstalledPackageRequest, v1alpha1.CreateInstalledPackageResponse](
 			httpClient,
 			baseURL+HelmPackagesServiceCreateInstalledPackageProcedure,
@@ -171,26 +233,48 @@ func NewHelmPackagesServiceClient(httpClient connect_go.HTTPClient, baseURL stri
 			baseURL+HelmPackagesServiceRollbackInstalledPackageProcedure,
 			opts...,
 		),
+		getInstalledPackageRevisions: connect_go.NewClient[v1alpha11.GetInstalledPackageRevisionsRequest, v1alpha11.GetInstalledPackageRevisionsResponse](
+			httpClient,
+			baseURL+HelmPackagesServiceGetInstalledPackageRevisionsProcedure,
+			opts...,
+		),
+		getInstalledPackageRevision: connect_go.NewClient[v1alpha11.GetInstalledPackageRevisionRequest, v1alpha11.GetInstalledPackageRevisionResponse](
+			httpClient,
+			baseURL+HelmPackagesServiceGetInstalledPackageRevisionProcedure,
+			opts...,
+		),
 		getInstalledPackageResourceRefs: connect_go.NewClient[v1alpha1.GetInstalledPackageResourceRefsRequest, v1alpha1.GetInstalledPackageResourceRefsResponse](
 			httpClient,
 			baseURL+HelmPackagesServiceGetInstalledPackageResourceRefsProcedure,
 			opts...,
 		),
+		getInstalledPackageOperationStatus: connect_go.NewClient[v1alpha1.GetInstalledPackageOperationStatusRequest, v1alpha1.GetInstalledPackageOperationStatusResponse](
+			httpClient,
+			baseURL+HelmPackagesServiceGetInstalledPackageOperationStatusProcedure,
+			opts...,
+		),
 	}
 }
 
 // helmPackagesServiceClient implements HelmPackagesServiceClient.
 type helmPackagesServiceClient struct {
-	getAvailablePackageSummaries    *connect_go.Client[v1alpha1.GetAvailablePackageSummariesRequest, v1alpha1.GetAvailablePackageSummariesResponse]
-	getAvailablePackageDetail       *connect_go.Client[v1alpha1.GetAvailablePackageDetailRequest, v1alpha1.GetAvailablePackageDetailResponse]
-	getAvailablePackageVersions     *connect_go.Client[v1alpha1.GetAvailablePackageVersionsRequest, v1alpha1.GetAvailablePackageVersionsResponse]
-	getInstalledPackageSummaries    *connect_go.Client[v1alpha1.GetInstalledPackageSummariesRequest, v1alpha1.GetInstalledPackageSummariesResponse]
-	getInstalledPackageDetail       *connect_go.Client[v1alpha1.GetInstalledPackageDetailRequest, v1alpha1.GetInstalledPackageDetailResponse]
-	createInstalledPackage          *connect_go.Client[v1alpha1.CreateInstalledPackageRequest, v1alpha1.CreateInstalledPackageResponse]
-	updateInstalledPackage          *connect_go.Client[v1alpha1.UpdateInstalledPackageRequest, v1alpha1.UpdateInstalledPackageResponse]
-	deleteInstalledPackage          *connect_go.Client[v1alpha1.DeleteInstalledPackageRequest, v1alpha1.DeleteInstalledPackageResponse]
-	rollbackInstalledPackage        *connect_go.Client[v1alpha11.RollbackInstalledPackageRequest, v1alpha11.RollbackInstalledPackageResponse]
-	getInstalledPackageResourceRefs *connect_go.Client[v1alpha1.GetInstalledPackageResourceRefsRequest, v1alpha1.GetInstalledPackageResourceRefsResponse]
+	getAvailablePackageSummaries          *connect_go.Client[v1alpha1.GetAvailablePackageSummariesRequest, v1alpha1.GetAvailablePackageSummariesResponse]
+	getAvailablePackageDetail             *connect_go.Client[v1alpha1.GetAvailablePackageDetailRequest, v1alpha1.GetAvailablePackageDetailResponse]
+	getAvailablePackageVersions           *connect_go.Client[v1alpha1.GetAvailablePackageVersionsRequest, v1alpha1.GetAvailablePackageVersionsResponse]
+	getAvailablePackageTextAsset          *connect_go.Client[v1alpha1.GetAvailablePackageTextAssetRequest, v1alpha1.GetAvailablePackageTextAssetResponse]
+	getAvailablePackageChangelog          *connect_go.Client[v1alpha1.GetAvailablePackageChangelogRequest, v1alpha1.GetAvailablePackageChangelogResponse]
+	getAvailablePackageFilters            *connect_go.Client[v1alpha1.GetAvailablePackageFiltersRequest, v1alpha1.GetAvailablePackageFiltersResponse]
+	getInstalledPackageSummaries          *connect_go.Client[v1alpha1.GetInstalledPackageSummariesRequest, v1alpha1.GetInstalledPackageSummariesResponse]
+	getInstalledPackageDetail             *connect_go.Client[v1alpha1.GetInstalledPackageDetailRequest, v1alpha1.GetInstalledPackageDetailResponse]
+	checkInstalledPackageNameAvailability *connect_go.Client[v1alpha1.CheckInstalledPackageNameAvailabilityRequest, v1alpha1.CheckInstalledPackageNameAvailabilityResponse]
+	createInstalledPackage                *connect_go.Client[v1alpha1.CreateInstalledPackageRequest, v1alpha1.CreateInstalledPackageResponse]
+	updateInstalledPackage                *connect_go.Client[v1alpha1.UpdateInstalledPackageRequest, v1alpha1.UpdateInstalledPackageResponse]
+	deleteInstalledPackage                *connect_go.Client[v1alpha1.DeleteInstalledPackageRequest, v1alpha1.DeleteInstalledPackageResponse]
+	rollbackInstalledPackage              *connect_go.Client[v1alpha11.RollbackInstalledPackageRequest, v1alpha11.RollbackInstalledPackageResponse]
+	getInstalledPackageRevisions          *connect_go.Client[v1alpha11.GetInstalledPackageRevisionsRequest, v1alpha11.GetInstalledPackageRevisionsResponse]
+	getInstalledPackageRevision           *connect_go.Client[v1alpha11.GetInstalledPackageRevisionRequest, v1alpha11.GetInstalledPackageRevisionResponse]
+	getInstalledPackageResourceRefs       *connect_go.Client[v1alpha1.GetInstalledPackageResourceRefsRequest, v1alpha1.GetInstalledPackageResourceRefsResponse]
+	getInstalledPackageOperationStatus    *connect_go.Client[v1alpha1.GetInstalledPackageOperationStatusRequest, v1alpha1.GetInstalledPackageOperationStatusResponse]
 }
 
 // GetAvailablePackageSummaries calls
@@ -211,6 +295,24 @@ func (c *helmPackagesServiceClient) GetAvailablePackageVersions(ctx context.Cont
 	return c.getAvailablePackageVersions.CallUnary(ctx, req)
 }
 
+// GetAvailablePackageTextAsset calls
+// kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService.GetAvailablePackageTextAsset.
+func (c *helmPackagesServiceClient) GetAvailablePackageTextAsset(ctx context.Context, req *connect_go.Request[v1alpha1.GetAvailablePackageTextAssetRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageTextAssetResponse], error) {
+	return c.getAvailablePackageTextAsset.CallUnary(ctx, req)
+}
+
+// GetAvailablePackageChangelog calls
+// kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService.GetAvailablePackageChangelog.
+func (c *helmPackagesServiceClient) GetAvailablePackageChangelog(ctx context.Context, req *connect_go.Request[v1alpha1.GetAvailablePackageChangelogRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageChangelogResponse], error) {
+	return c.getAvailablePackageChangelog.CallUnary(ctx, req)
+}
+
+// GetAvailablePackageFilters calls
+// kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService.GetAvailablePackageFilters.
+func (c *helmPackagesServiceClient) GetAvailablePackageFilters(ctx context.Context, req *connect_go.Request[v1alpha1.GetAvailablePackageFiltersRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageFiltersResponse], error) {
+	return c.getAvailablePackageFilters.CallUnary(ctx, req)
+}
+
 // GetInstalledPackageSummaries calls
 // kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService.GetInstalledPackageSummaries.
 func (c *helmPackagesServiceClient) GetInstalledPackageSummaries(ctx context.Context, req *connect_go.Request[v1alpha1.GetInstalledPackageSummariesRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageSummariesResponse], error) {
@@ -223,6 +325,12 @@ func (c *helmPackagesServiceClient) GetInstalledPackageDetail(ctx context.Contex
 	return c.getInstalledPackageDetail.CallUnary(ctx, req)
 }
 
+// CheckInstalledPackageNameAvailability calls
+// kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService.CheckInstalledPackageNameAvailability.
+func (c *helmPackagesServiceClient) CheckInstalledPackageNameAvailability(ctx context.Context, req *connect_go.Request[v1alpha1.CheckInstalledPackageNameAvailabilityRequest]) (*connect_go.Response[v1alpha1.CheckInstalledPackageNameAvailabilityResponse], error) {
+	return c.checkInstalledPackageNameAvailability.CallUnary(ctx, req)
+}
+
 // CreateInstalledPackage calls
 // kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService.CreateInstalledPackage.
 func (c *helmPackagesServiceClient) CreateInstalledPackage(ctx context.Context, req *connect_go.Request[v1alpha1.CreateInstalledPackageRequest]) (*connect_go.Response[v1alpha1.CreateInstalledPackageResponse], error) {
@@ -247,12 +355,30 @@ func (c *helmPackagesServiceClient) RollbackInstalledPackage(ctx context.Context
 	return c.rollbackInstalledPackage.CallUnary(ctx, req)
 }
 
+// GetInstalledPackageRevisions calls
+// kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService.GetInstalledPackageRevisions.
+func (c *helmPackagesServiceClient) GetInstalledPackageRevisions(ctx context.Context, req *connect_go.Request[v1alpha11.GetInstalledPackageRevisionsRequest]) (*connect_go.Response[v1alpha11.GetInstalledPackageRevisionsResponse], error) {
+	return c.getInstalledPackageRevisions.CallUnary(ctx, req)
+}
+
+// GetInstalledPackageRevision calls
+// kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService.GetInstalledPackageRevision.
+func (c *helmPackagesServiceClient) GetInstalledPackageRevision(ctx context.Context, req *connect_go.Request[v1alpha11.GetInstalledPackageRevisionRequest]) (*connect_go.Response[v1alpha11.GetInstalledPackageRevisionResponse], error) {
+	return c.getInstalledPackageRevision.CallUnary(ctx, req)
+}
+
 // GetInstalledPackageResourceRefs calls
 // kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService.GetInstalledPackageResourceRefs.
 func (c *helmPackagesServiceClient) GetInstalledPackageResourceRefs(ctx context.Context, req *connect_go.Request[v1alpha1.GetInstalledPackageResourceRefsRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageResourceRefsResponse], error) {
 	return c.getInstalledPackageResourceRefs.CallUnary(ctx, req)
 }
 
+// GetInstalledPackageOperationStatus calls
+// kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService.GetInstalledPackageOperationStatus.
+func (c *helmPackagesServiceClient) GetInstalledPackageOperationStatus(ctx context.Context, req *connect_go.Request[v1alpha1.GetInstalledPackageOperationStatusRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageOperationStatusResponse], error) {
+	return c.getInstalledPackageOperationStatus.CallUnary(ctx, req)
+}
+
 // HelmPackagesServiceHandler is an implementation of the
 // kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService service.
 type HelmPackagesServiceHandler interface {
@@ -262,10 +388,18 @@ type HelmPackagesServiceHandler interface {
 	GetAvailablePackageDetail(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageDetailRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageDetailResponse], error)
 	// GetAvailablePackageVersions returns the package versions managed by the 'helm' plugin
 	GetAvailablePackageVersions(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageVersionsRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageVersionsResponse], error)
+	// GetAvailablePackageTextAsset returns a range of a text asset of a package managed by the 'helm' plugin
+	GetAvailablePackageTextAsset(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageTextAssetRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageTextAssetResponse], error)
+	// GetAvailablePackageChangelog returns the release notes between two versions of a package managed by the 'helm' plugin
+	GetAvailablePackageChangelog(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageChangelogRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageChangelogResponse], error)
+	// GetAvailablePackageFilters returns the filter values of the available packages managed by the 'helm' plugin
+	GetAvailablePackageFilters(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageFiltersRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageFiltersResponse], error)
 	// GetInstalledPackageSummaries returns the installed packages managed by the 'helm' plugin
 	GetInstalledPackageSummaries(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageSummariesRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageSummariesResponse], error)
 	// GetInstalledPackageDetail returns the requested installed package managed by the 'helm' plugin
 	GetInstalledPackageDetail(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageDetailRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageDetailResponse], error)
+	// CheckInstalledPackageNameAvailability checks whether a package managed by the 'helm' plugin can be installed with the given name
+	CheckInstalledPackageNameAvailability(context.Context, *connect_go.Request[v1alpha1.CheckInstalledPackageNameAvailabilityRequest]) (*connect_go.Response[v1alpha1.CheckInstalledPackageNameAvailabilityResponse], error)
 	// CreateInstalledPackage creates an installed package based on the request.
 	CreateInstalledPackage(context.Context, *connect_go.Request[v1alpha1.CreateInstalledPackageRequest]) (*connect_go.Response[v1alpha1.CreateInstalledPackageResponse], error)
 	// UpdateInstalledPackage updates an installed package based on the request.
@@ -274,9 +408,16 @@ type HelmPackagesServiceHandler interface {
 	DeleteInstalledPackage(context.Context, *connect_go.Request[v1alpha1.DeleteInstalledPackageRequest]) (*connect_go.Response[v1alpha1.DeleteInstalledPackageResponse], error)
 	// RollbackInstalledPackage updates an installed package based on the request.
 	RollbackInstalledPackage(context.Context, *connect_go.Request[v1alpha11.RollbackInstalledPackageRequest]) (*connect_go.Response[v1alpha11.RollbackInstalledPackageResponse], error)
+	// GetInstalledPackageRevisions returns the revisions of the Helm release of an installed package.
+	GetInstalledPackageRevisions(context.Context, *connect_go.Request[v1alpha11.GetInstalledPackageRevisionsRequest]) (*connect_go.Response[v1alpha11.GetInstalledPackageRevisionsResponse], error)
+	// GetInstalledPackageRevision returns a revision of the Helm release of an installed package,
+	// along with its rendered manifest and values.
+	GetInstalledPackageRevision(context.Context, *connect_go.Request[v1alpha11.GetInstalledPackageRevisionRequest]) (*connect_go.Response[v1alpha11.GetInstalledPackageRevisionResponse], error)
 	// GetInstalledPackageResourceRefs returns the references for the Kubernetes resources created by
 	// an installed package.
 	GetInstalledPackageResourceRefs(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageResourceRefsRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageResourceRefsResponse], error)
+	// GetInstalledPackageOperationStatus returns the progress of the operation in course on an installed package managed by the 'helm' plugin
+	GetInstalledPackageOperationStatus(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageOperationStatusRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageOperationStatusResponse], error)
 }
 
 // NewHelmPackagesServiceHandler builds an HTTP handler from the service implementation. It returns
@@ -300,6 +441,21 @@ func NewHelmPackagesServiceHandler(svc HelmPackagesServiceHandler, opts ...conne
 		svc.GetAvailablePackageVersions,
 		opts...,
 	)
+	helmPackagesServiceGetAvailablePackageTextAssetHandler := connect_go.NewUnaryHandler(
+		HelmPackagesServiceGetAvailablePackageTextAssetProcedure,
+		svc.GetAvailablePackageTextAsset,
+		opts...,
+	)
+	helmPackagesServiceGetAvailablePackageChangelogHandler := connect_go.NewUnaryHandler(
+		HelmPackagesServiceGetAvailablePackageChangelogProcedure,
+		svc.GetAvailablePackageChangelog,
+		opts...,
+	)
+	helmPackagesServiceGetAvailablePackageFiltersHandler := connect_go.NewUnaryHandler(
+		HelmPackagesServiceGetAvailablePackageFiltersProcedure,
+		svc.GetAvailablePackageFilters,
+		opts...,
+	)
 	helmPackagesServiceGetInstalledPackageSummariesHandler := connect_go.NewUnaryHandler(
 		HelmPackagesServiceGetInstalledPackageSummariesProcedure,
 		svc.GetInstalledPackageSummaries,
@@ -310,6 +466,11 @@ func NewHelmPackagesServiceHandler(svc HelmPackagesServiceHandler, opts ...conne
 		svc.GetInstalledPackageDetail,
 		opts...,
 	)
+	helmPackagesServiceCheckInstalledPackageNameAvailabilityHandler := connect_go.NewUnaryHandler(
+		HelmPackagesServiceCheckInstalledPackageNameAvailabilityProcedure,
+		svc.CheckInstalledPackageNameAvailability,
+		opts...,
+	)
 	helmPackagesServiceCreateInstalledPackageHandler := connect_go.NewUnaryHandler(
 		HelmPackagesServiceCreateInstalledPackageProcedure,
 		svc.CreateInstalledPackage,
@@ -330,11 +491,26 @@ func NewHelmPackagesServiceHandler(svc HelmPackagesServiceHandler, opts ...conne
 		svc.RollbackInstalledPackage,
 		opts...,
 	)
+	helmPackagesServiceGetInstalledPackageRevisionsHandler := connect_go.NewUnaryHandler(
+		HelmPackagesServiceGetInstalledPackageRevisionsProcedure,
+		svc.GetInstalledPackageRevisions,
+		opts...,
+	)
+	helmPackagesServiceGetInstalledPackageRevisionHandler := connect_go.NewUnaryHandler(
+		HelmPackagesServiceGetInstalledPackageRevisionProcedure,
+		svc.GetInstalledPackageRevision,
+		opts...,
+	)
 	helmPackagesServiceGetInstalledPackageResourceRefsHandler := connect_go.NewUnaryHandler(
 		HelmPackagesServiceGetInstalledPackageResourceRefsProcedure,
 		svc.GetInstalledPackageResourceRefs,
 		opts...,
 	)
+	helmPackagesServiceGetInstalledPackageOperationStatusHandler := connect_go.NewUnaryHandler(
+		HelmPackagesServiceGetInstalledPackageOperationStatusProcedure,
+		svc.GetInstalledPackageOperationStatus,
+		opts...,
+	)
 	return "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case HelmPackagesServiceGetAvailablePackageSummariesProcedure:
@@ -343,10 +519,18 @@ func NewHelmPackagesServiceHandler(svc HelmPackagesServiceHandler, opts ...conne
 			helmPackagesServiceGetAvailablePackageDetailHandler.ServeHTTP(w, r)
 		case HelmPackagesServiceGetAvailablePackageVersionsProcedure:
 			helmPackagesServiceGetAvailablePackageVersionsHandler.ServeHTTP(w, r)
+		case HelmPackagesServiceGetAvailablePackageTextAssetProcedure:
+			helmPackagesServiceGetAvailablePackageTextAssetHandler.ServeHTTP(w, r)
+		case HelmPackagesServiceGetAvailablePackageChangelogProcedure:
+			helmPackagesServiceGetAvailablePackageChangelogHandler.ServeHTTP(w, r)
+		case HelmPackagesServiceGetAvailablePackageFiltersProcedure:
+			helmPackagesServiceGetAvailablePackageFiltersHandler.ServeHTTP(w, r)
 		case HelmPackagesServiceGetInstalledPackageSummariesProcedure:
 			helmPackagesServiceGetInstalledPackageSummariesHandler.ServeHTTP(w, r)
 		case HelmPackagesServiceGetInstalledPackageDetailProcedure:
 			helmPackagesServiceGetInstalledPackageDetailHandler.ServeHTTP(w, r)
+		case HelmPackagesServiceCheckInstalledPackageNameAvailabilityProcedure:
+			helmPackagesServiceCheckInstalledPackageNameAvailabilityHandler.ServeHTTP(w, r)
 		case HelmPackagesServiceCreateInstalledPackageProcedure:
 			helmPackagesServiceCreateInstalledPackageHandler.ServeHTTP(w, r)
 		case HelmPackagesServiceUpdateInstalledPackageProcedure:
@@ -355,8 +539,14 @@ func NewHelmPackagesServiceHandler(svc HelmPackagesServiceHandler, opts ...conne
 			helmPackagesServiceDeleteInstalledPackageHandler.ServeHTTP(w, r)
 		case HelmPackagesServiceRollbackInstalledPackageProcedure:
 			helmPackagesServiceRollbackInstalledPackageHandler.ServeHTTP(w, r)
+		case HelmPackagesServiceGetInstalledPackageRevisionsProcedure:
+			helmPackagesServiceGetInstalledPackageRevisionsHandler.ServeHTTP(w, r)
+		case HelmPackagesServiceGetInstalledPackageRevisionProcedure:
+			helmPackagesServiceGetInstalledPackageRevisionHandler.ServeHTTP(w, r)
 		case HelmPackagesServiceGetInstalledPackageResourceRefsProcedure:
 			helmPackagesServiceGetInstalledPackageResourceRefsHandler.ServeHTTP(w, r)
+		case HelmPackagesServiceGetInstalledPackageOperationStatusProcedure:
+			helmPackagesServiceGetInstalledPackageOperationStatusHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -378,6 +568,18 @@ func (UnimplementedHelmPackagesServiceHandler) GetAvailablePackageVersions(conte
 	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService.GetAvailablePackageVersions is not implemented"))
 }
 
+func (UnimplementedHelmPackagesServiceHandler) GetAvailablePackageTextAsset(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageTextAssetRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageTextAssetResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService.GetAvailablePackageTextAsset is not implemented"))
+}
+
+func (UnimplementedHelmPackagesServiceHandler) GetAvailablePackageChangelog(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageChangelogRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageChangelogResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService.GetAvailablePackageChangelog is not implemented"))
+}
+
+func (UnimplementedHelmPackagesServiceHandler) GetAvailablePackageFilters(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageFiltersRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageFiltersResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService.GetAvailablePackageFilters is not implemented"))
+}
+
 func (UnimplementedHelmPackagesServiceHandler) GetInstalledPackageSummaries(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageSummariesRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageSummariesResponse], error) {
 	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService.GetInstalledPackageSummaries is not implemented"))
 }
@@ -386,6 +588,10 @@ func (UnimplementedHelmPackagesServiceHandler) GetInstalledPackageDetail(context
 	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService.GetInstalledPackageDetail is not implemented"))
 }
 
+func (UnimplementedHelmPackagesServiceHandler) CheckInstalledPackageNameAvailability(context.Context, *connect_go.Request[v1alpha1.CheckInstalledPackageNameAvailabilityRequest]) (*connect_go.Response[v1alpha1.CheckInstalledPackageNameAvailabilityResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService.CheckInstalledPackageNameAvailability is not implemented"))
+}
+
 func (UnimplementedHelmPackagesServiceHandler) CreateInstalledPackage(context.Context, *connect_go.Request[v1alpha1.CreateInstalledPackageRequest]) (*connect_go.Response[v1alpha1.CreateInstalledPackageResponse], error) {
 	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService.CreateInstalledPackage is not implemented"))
 }
@@ -402,10 +608,22 @@ func (UnimplementedHelmPackagesServiceHandler) RollbackInstalledPackage(context.
 	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService.RollbackInstalledPackage is not implemented"))
 }
 
+func (UnimplementedHelmPackagesServiceHandler) GetInstalledPackageRevisions(context.Context, *connect_go.Request[v1alpha11.GetInstalledPackageRevisionsRequest]) (*connect_go.Response[v1alpha11.GetInstalledPackageRevisionsResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService.GetInstalledPackageRevisions is not implemented"))
+}
+
+func (UnimplementedHelmPackagesServiceHandler) GetInstalledPackageRevision(context.Context, *connect_go.Request[v1alpha11.GetInstalledPackageRevisionRequest]) (*connect_go.Response[v1alpha11.GetInstalledPackageRevisionResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService.GetInstalledPackageRevision is not implemented"))
+}
+
 func (UnimplementedHelmPackagesServiceHandler) GetInstalledPackageResourceRefs(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageResourceRefsRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageResourceRefsResponse], error) {
 	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService.GetInstalledPackageResourceRefs is not implemented"))
 }
 
+func (UnimplementedHelmPackagesServiceHandler) GetInstalledPackageOperationStatus(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageOperationStatusRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageOperationStatusResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService.GetInstalledPackageOperationStatus is not implemented"))
+}
+
 // HelmRepositoriesServiceClient is a client for the
 // kubeappsapis.plugins.helm.packages.v1alpha1.HelmRepositoriesService service.
 type HelmRepositoriesServiceClient interface {
@@ -416,6 +634,8 @@ type HelmRepositoriesServiceClient interface {
 	UpdatePackageRepository(context.Context, *connect_go.Request[v1alpha1.UpdatePackageRepositoryRequest]) (*connect_go.Response[v1alpha1.UpdatePackageRepositoryResponse], error)
 	DeletePackageRepository(context.Context, *connect_go.Request[v1alpha1.DeletePackageRepositoryRequest]) (*connect_go.Response[v1alpha1.DeletePackageRepositoryResponse], error)
 	GetPackageRepositoryPermissions(context.Context, *connect_go.Request[v1alpha1.GetPackageRepositoryPermissionsRequest]) (*connect_go.Response[v1alpha1.GetPackageRepositoryPermissionsResponse], error)
+	TestPackageRepositoryConnection(context.Context, *connect_go.Request[v1alpha1.TestPackageRepositoryConnectionRequest]) (*connect_go.Response[v1alpha1.TestPackageRepositoryConnectionResponse], error)
+	ListPackageRepositoryPackages(context.Context, *connect_go.Request[v1alpha1.ListPackageRepositoryPackagesRequest]) (*connect_go.Response[v1alpha1.ListPackageRepositoryPackagesResponse], error)
 }
 
 // NewHelmRepositoriesServiceClient constructs a client for the
@@ -459,6 +679,16 @@ func NewHelmRepositoriesServiceClient(httpClient connect_go.HTTPClient, baseURL
 			baseURL+HelmRepositoriesServiceGetPackageRepositoryPermissionsProcedure,
 			opts...,
 		),
+		testPackageRepositoryConnection: connect_go.NewClient[v1alpha1.TestPackageRepositoryConnectionRequest, v1alpha1.TestPackageRepositoryConnectionResponse](
+			httpClient,
+			baseURL+HelmRepositoriesServiceTestPackageRepositoryConnectionProcedure,
+			opts...,
+		),
+		listPackageRepositoryPackages: connect_go.NewClient[v1alpha1.ListPackageRepositoryPackagesRequest, v1alpha1.ListPackageRepositoryPackagesResponse](
+			httpClient,
+			baseURL+HelmRepositoriesServiceListPackageRepositoryPackagesProcedure,
+			opts...,
+		),
 	}
 }
 
@@ -470,6 +700,8 @@ type helmRepositoriesServiceClient struct {
 	updatePackageRepository         *connect_go.Client[v1alpha1.UpdatePackageRepositoryRequest, v1alpha1.UpdatePackageRepositoryResponse]
 	deletePackageRepository         *connect_go.Client[v1alpha1.DeletePackageRepositoryRequest, v1alpha1.DeletePackageRepositoryResponse]
 	getPackageRepositoryPermissions *connect_go.Client[v1alpha1.GetPackageRepositoryPermissionsRequest, v1alpha1.GetPackageRepositoryPermissionsResponse]
+	testPackageRepositoryConnection *connect_go.Client[v1alpha1.TestPackageRepositoryConnectionRequest, v1alpha1.TestPackageRepositoryConnectionResponse]
+	listPackageRepositoryPackages   *connect_go.Client[v1alpha1.ListPackageRepositoryPackagesRequest, v1alpha1.ListPackageRepositoryPackagesResponse]
 }
 
 // AddPackageRepository calls
@@ -508,6 +740,18 @@ func (c *helmRepositoriesServiceClient) GetPackageRepositoryPermissions(ctx cont
 	return c.getPackageRepositoryPermissions.CallUnary(ctx, req)
 }
 
+// TestPackageRepositoryConnection calls
+// kubeappsapis.plugins.helm.packages.v1alpha1.HelmRepositoriesService.TestPackageRepositoryConnection.
+func (c *helmRepositoriesServiceClient) TestPackageRepositoryConnection(ctx context.Context, req *connect_go.Request[v1alpha1.TestPackageRepositoryConnectionRequest]) (*connect_go.Response[v1alpha1.TestPackageRepositoryConnectionResponse], error) {
+	return c.testPackageRepositoryConnection.CallUnary(ctx, req)
+}
+
+// ListPackageRepositoryPackages calls
+// kubeappsapis.plugins.helm.packages.v1alpha1.HelmRepositoriesService.ListPackageRepositoryPackages.
+func (c *helmRepositoriesServiceClient) ListPackageRepositoryPackages(ctx context.Context, req *connect_go.Request[v1alpha1.ListPackageRepositoryPackagesRequest]) (*connect_go.Response[v1alpha1.ListPackageRepositoryPackagesResponse], error) {
+	return c.listPackageRepositoryPackages.CallUnary(ctx, req)
+}
+
 // HelmRepositoriesServiceHandler is an implementation of the
 // kubeappsapis.plugins.helm.packages.v1alpha1.HelmRepositoriesService service.
 type HelmRepositoriesServiceHandler interface {
@@ -518,6 +762,8 @@ type HelmRepositoriesServiceHandler interface {
 	UpdatePackageRepository(context.Context, *connect_go.Request[v1alpha1.UpdatePackageRepositoryRequest]) (*connect_go.Response[v1alpha1.UpdatePackageRepositoryResponse], error)
 	DeletePackageRepository(context.Context, *connect_go.Request[v1alpha1.DeletePackageRepositoryRequest]) (*connect_go.Response[v1alpha1.DeletePackageRepositoryResponse], error)
 	GetPackageRepositoryPermissions(context.Context, *connect_go.Request[v1alpha1.GetPackageRepositoryPermissionsRequest]) (*connect_go.Response[v1alpha1.GetPackageRepositoryPermissionsResponse], error)
+	TestPackageRepositoryConnection(context.Context, *connect_go.Request[v1alpha1.TestPackageRepositoryConnectionRequest]) (*connect_go.Response[v1alpha1.TestPackageRepositoryConnectionResponse], error)
+	ListPackageRepositoryPackages(context.Context, *connect_go.Request[v1alpha1.ListPackageRepositoryPackagesRequest]) (*connect_go.Response[v1alpha1.ListPackageRepositoryPackagesResponse], error)
 }
 
 // NewHelmRepositoriesServiceHandler builds an HTTP handler from the service implementation. It
@@ -556,6 +802,16 @@ func NewHelmRepositoriesServiceHandler(svc HelmRepositoriesServiceHandler, opts
 		svc.GetPackageRepositoryPermissions,
 		opts...,
 	)
+	helmRepositoriesServiceTestPackageRepositoryConnectionHandler := connect_go.NewUnaryHandler(
+		HelmRepositoriesServiceTestPackageRepositoryConnectionProcedure,
+		svc.TestPackageRepositoryConnection,
+		opts...,
+	)
+	helmRepositoriesServiceListPackageRepositoryPackagesHandler := connect_go.NewUnaryHandler(
+		HelmRepositoriesServiceListPackageRepositoryPackagesProcedure,
+		svc.ListPackageRepositoryPackages,
+		opts...,
+	)
 	return "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmRepositoriesService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case HelmRepositoriesServiceAddPackageRepositoryProcedure:
@@ -570,6 +826,10 @@ func NewHelmRepositoriesServiceHandler(svc HelmRepositoriesServiceHandler, opts
 			helmRepositoriesServiceDeletePackageRepositoryHandler.ServeHTTP(w, r)
 		case HelmRepositoriesServiceGetPackageRepositoryPermissionsProcedure:
 			helmRepositoriesServiceGetPackageRepositoryPermissionsHandler.ServeHTTP(w, r)
+		case HelmRepositoriesServiceTestPackageRepositoryConnectionProcedure:
+			helmRepositoriesServiceTestPackageRepositoryConnectionHandler.ServeHTTP(w, r)
+		case HelmRepositoriesServiceListPackageRepositoryPackagesProcedure:
+			helmRepositoriesServiceListPackageRepositoryPackagesHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -602,3 +862,11 @@ func (UnimplementedHelmRepositoriesServiceHandler) DeletePackageRepository(conte
 func (UnimplementedHelmRepositoriesServiceHandler) GetPackageRepositoryPermissions(context.Context, *connect_go.Request[v1alpha1.GetPackageRepositoryPermissionsRequest]) (*connect_go.Response[v1alpha1.GetPackageRepositoryPermissionsResponse], error) {
 	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.helm.packages.v1alpha1.HelmRepositoriesService.GetPackageRepositoryPermissions is not implemented"))
 }
+
+func (UnimplementedHelmRepositoriesServiceHandler) TestPackageRepositoryConnection(context.Context, *connect_go.Request[v1alpha1.TestPackageRepositoryConnectionRequest]) (*connect_go.Response[v1alpha1.TestPackageRepositoryConnectionResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.helm.packages.v1alpha1.HelmRepositoriesService.TestPackageRepositoryConnection is not implemented"))
+}
+
+func (UnimplementedHelmRepositoriesServiceHandler) ListPackageRepositoryPackages(context.Context, *connect_go.Request[v1alpha1.ListPackageRepositoryPackagesRequest]) (*connect_go.Response[v1alpha1.ListPackageRepositoryPackagesResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.helm.packages.v1alpha1.HelmRepositoriesService.ListPackageRepositoryPackages is not implemented"))
+}
@@ -23,15 +23,24 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	KappControllerPackagesService_GetAvailablePackageSummaries_FullMethodName    = "/kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService/GetAvailablePackageSummaries"
-	KappControllerPackagesService_GetAvailablePackageDetail_FullMethodName       = "/kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService/GetAvailablePackageDetail"
-	KappControllerPackagesService_GetAvailablePackageVersions_FullMethodName     = "/kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService/GetAvailablePackageVersions"
-	KappControllerPackagesService_GetInstalledPackageSummaries_FullMethodName    = "/kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService/GetInstalledPackageSummaries"
-	KappControllerPackagesService_GetInstalledPackageDetail_FullMethodName       = "/kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService/GetInstalledPackageDetail"
-	KappControllerPackagesService_CreateInstalledPackage_FullMethodName          = "/kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService/CreateInstalledPackage"
-	KappControllerPackagesService_UpdateInstalledPackage_FullMethodName          = "/kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService/UpdateInstalledPackage"
-	KappControllerPackagesService_DeleteInstalledPackage_FullMethodName          = "/kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService/DeleteInstalledPackage"
-	KappControllerPackagesService_GetInstalledPackageResourceRefs_FullMethodName = "/kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService/GetInstalledPackageResourceRefs"
+	KappControllerPackagesService_GetAvailablePackageSummaries_FullMethodName          = "/kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService/GetAvailablePackageSummaries"
+	KappControllerPackagesService_GetAvailablePackageDetail_FullMethodName             = "/kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService/GetAvailablePackageDetail"
+	KappControllerPackagesService_GetAvailablePackageVersions_FullMethodName           = "/kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService/GetAvailablePackageVersions"
+	KappControllerPackagesService_GetAvailablePackageTextAsset_FullMethodName          = "/kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService/GetAvailablePackageTextAsset"
+	KappControllerPackagesService_GetAvailablePackageChangelog_FullMethodName          = "/kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService/GetAvailablePackageChangelog"
+	KappControllerPackagesService_GetAvailablePackageFilters_FullMethodName            = "/kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService/GetAvailablePackageFilters"
+	KappControllerPackagesService_GetInstalledPackageSummaries_FullMethodName          = "/kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService/GetInstalledPackageSummaries"
+	KappControllerPackagesService_GetInstalledPackageDetail_FullMethodName             = "/kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService/GetInstalledPackageDetail"
+	KappControllerPackagesService_CheckInstalledPackageNameAvailability_FullMethodName = "/kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService/CheckInstalledPackageNameAvailability"
+	KappControllerPackagesService_CreateInstalledPackage_FullMethodName                = "/kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService/CreateInstalledPackage"
+	KappControllerPackagesService_UpdateInstalledPackage_FullMethodName                = "/kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService/UpdateInstalledPackage"
+	KappControllerPackagesService_DeleteInstalledPackage_FullMethodName                = "/kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService/DeleteInstalledPackage"
+	KappControllerPackagesService_GetInstalledPackageResourceRefs_FullMethodName       = "/kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService/GetInstalledPackageResourceRefs"
+	KappControllerPackagesService_GetInstalledPackageOperationStatus_FullMethodName    = "/kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService/GetInstalledPackageOperationStatus"
+	KappControllerPackagesService_GetInstalledPackageChangePreview_FullMethodName      = "/kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService/GetInstalledPackageChangePreview"
+	KappControllerPackagesService_GetInstalledPackageReconcileHistory_FullMethodName   = "/kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService/GetInstalledPackageReconcileHistory"
+	KappControllerPackagesService_GetInstalledPackageManifest_FullMethodName           = "/kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService/GetInstalledPackageManifest"
+	KappControllerPackagesService_UpdateInstalledPackageManifest_FullMethodName        = "/kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService/UpdateInstalledPackageManifest"
 )
 
 // KappControllerPackagesServiceClient is the client API for KappControllerPackagesService service.
@@ -44,10 +53,18 @@ type KappControllerPackagesServiceClient interface {
 	GetAvailablePackageDetail(ctx context.Context, in *v1alpha1.GetAvailablePackageDetailRequest, opts ...grpc.CallOption) (*v1alpha1.GetAvailablePackageDetailResponse, error)
 	// GetAvailablePackageVersions returns the package versions managed by the 'kapp_controller' plugin
 	GetAvailablePackageVersions(ctx context.Context, in *v1alpha1.GetAvailablePackageVersionsRequest, opts ...grpc.CallOption) (*v1alpha1.GetAvailablePackageVersionsResponse, error)
+	// GetAvailablePackageTextAsset returns a range of a text asset of a package managed by the 'kapp_controller' plugin
+	GetAvailablePackageTextAsset(ctx context.Context, in *v1alpha1.GetAvailablePackageTextAssetRequest, opts ...grpc.CallOption) (*v1alpha1.GetAvailablePackageTextAssetResponse, error)
+	// GetAvailablePackageChangelog returns the release notes between two versions of a package managed by the 'kapp_controller' plugin
+	GetAvailablePackageChangelog(ctx context.Context, in *v1alpha1.GetAvailablePackageChangelogRequest, opts ...grpc.CallOption) (*v1alpha1.GetAvailablePackageChangelogResponse, error)
+	// GetAvailablePackageFilters returns the filter values of the available packages managed by the 'kapp_controller' plugin
+	GetAvailablePackageFilters(ctx context.Context, in *v1alpha1.GetAvailablePackageFiltersRequest, opts ...grpc.CallOption) (*v1alpha1.GetAvailablePackageFiltersResponse, error)
 	// GetInstalledPackageSummaries returns the installed packages managed by the 'kapp_controller' plugin
 	GetInstalledPackageSummaries(ctx context.Context, in *v1alpha1.GetInstalledPackageSummariesRequest, opts ...grpc.CallOption) (*v1alpha1.GetInstalledPackageSummariesResponse, error)
 	// GetInstalledPackageDetail returns the requested installed package managed by the 'kapp_controller' plugin
 	GetInstalledPackageDetail(ctx context.Context, in *v1alpha1.GetInstalledPackageDetailRequest, opts ...grpc.CallOption) (*v1alpha1.GetInstalledPackageDetailResponse, error)
+	// CheckInstalledPackageNameAvailability checks whether a package managed by the 'kapp_controller' plugin can be installed with the given name
+	CheckInstalledPackageNameAvailability(ctx context.Context, in *v1alpha1.CheckInstalledPackageNameAvailabilityRequest, opts ...grpc.CallOption) (*v1alpha1.CheckInstalledPackageNameAvailabilityResponse, error)
 	// CreateInstalledPackage creates an installed package based on the request.
 	CreateInstalledPackage(ctx context.Context, in *v1alpha1.CreateInstalledPackageRequest, opts ...grpc.CallOption) (*v1alpha1.CreateInstalledPackageResponse, error)
 	// UpdateInstalledPackage updates an installed package based on the request.
@@ -57,6 +74,24 @@ type KappControllerPackagesServiceClient interface {
 	// GetInstalledPackageResourceRefs returns the references for the Kubernetes resources created by
 	// an installed package.
 	GetInstalledPackageResourceRefs(ctx context.Context, in *v1alpha1.GetInstalledPackageResourceRefsRequest, opts ...grpc.CallOption) (*v1alpha1.GetInstalledPackageResourceRefsResponse, error)
+	// GetInstalledPackageOperationStatus returns the progress of the operation in course on an installed package managed by the 'kapp_controller' plugin
+	GetInstalledPackageOperationStatus(ctx context.Context, in *v1alpha1.GetInstalledPackageOperationStatusRequest, opts ...grpc.CallOption) (*v1alpha1.GetInstalledPackageOperationStatusResponse, error)
+	// GetInstalledPackageChangePreview returns the changes of the Kubernetes resources that an update
+	// of an installed package managed by the 'kapp_controller' plugin would apply, without applying them.
+	GetInstalledPackageChangePreview(ctx context.Context, in *GetInstalledPackageChangePreviewRequest, opts ...grpc.CallOption) (*GetInstalledPackageChangePreviewResponse, error)
+	// GetInstalledPackageReconcileHistory returns the last reconcile outcomes of an installed package
+	// managed by the 'kapp_controller' plugin, most recent first, so that flapping installations can
+	// be diagnosed. The outcomes are recorded when observed by the plugin.
+	GetInstalledPackageReconcileHistory(ctx context.Context, in *GetInstalledPackageReconcileHistoryRequest, opts ...grpc.CallOption) (*GetInstalledPackageReconcileHistoryResponse, error)
+	// GetInstalledPackageManifest returns the raw YAML manifest of the PackageInstall, or of its App,
+	// of an installed package managed by the 'kapp_controller' plugin, for expert editing.
+	// Only available when the `advancedMode` plugin configuration is enabled.
+	GetInstalledPackageManifest(ctx context.Context, in *GetInstalledPackageManifestRequest, opts ...grpc.CallOption) (*GetInstalledPackageManifestResponse, error)
+	// UpdateInstalledPackageManifest replaces the PackageInstall of an installed package managed by
+	// the 'kapp_controller' plugin with the given raw YAML manifest, once validated by the API server.
+	// The fields managed by the plugin cannot be changed.
+	// Only available when the `advancedMode` plugin configuration is enabled.
+	UpdateInstalledPackageManifest(ctx context.Context, in *UpdateInstalledPackageManifestRequest, opts ...grpc.CallOption) (*UpdateInstalledPackageManifestResponse, error)
 }
 
 type kappControllerPackagesServiceClient struct {
@@ -94,6 +129,33 @@ func (c *kappControllerPackagesServiceClient) GetAvailablePackageVersions(ctx co
 	return out, nil
 }
 
+func (c *kappControllerPackagesServiceClient) GetAvailablePackageTextAsset(ctx context.Context, in *v1alpha1.GetAvailablePackageTextAssetRequest, opts ...grpc.CallOption) (*v1alpha1.GetAvailablePackageTextAssetResponse, error) {
+	out := new(v1alpha1.GetAvailablePackageTextAssetResponse)
+	err := c.cc.Invoke(ctx, KappControllerPackagesService_GetAvailablePackageTextAsset_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kappControllerPackagesServiceClient) GetAvailablePackageChangelog(ctx context.Context, in *v1alpha1.GetAvailablePackageChangelogRequest, opts ...grpc.CallOption) (*v1alpha1.GetAvailablePackageChangelogResponse, error) {
+	out := new(v1alpha1.GetAvailablePackageChangelogResponse)
+	err := c.cc.Invoke(ctx, KappControllerPackagesService_GetAvailablePackageChangelog_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kappControllerPackagesServiceClient) GetAvailablePackageFilters(ctx context.Context, in *v1alpha1.GetAvailablePackageFiltersRequest, opts ...grpc.CallOption) (*v1alpha1.GetAvailablePackageFiltersResponse, error) {
+	out := new(v1alpha1.GetAvailablePackageFiltersResponse)
+	err := c.cc.Invoke(ctx, KappControllerPackagesService_GetAvailablePackageFilters_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *kappControllerPackagesServiceClient) GetInstalledPackageSummaries(ctx context.Context, in *v1alpha1.GetInstalledPackageSummariesRequest, opts ...grpc.CallOption) (*v1alpha1.GetInstalledPackageSummariesResponse, error) {
 	out := new(v1alpha1.GetInstalledPackageSummariesResponse)
 	err := c.cc.Invoke(ctx, KappControllerPackagesService_GetInstalledPackageSummaries_FullMethodName, in, out, opts...)
@@ -112,6 +174,15 @@ func (c *kappControllerPackagesServiceClient) GetInstalledPackageDetail(ctx cont
 	return out, nil
 }
 
+func (c *kappControllerPackagesServiceClient) CheckInstalledPackageNameAvailability(ctx context.Context, in *v1alpha1.CheckInstalledPackageNameAvailabilityRequest, opts ...grpc.CallOption) (*v1alpha1.CheckInstalledPackageNameAvailabilityResponse, error) {
+	out := new(v1alpha1.CheckInstalledPackageNameAvailabilityResponse)
+	err := c.cc.Invoke(ctx, KappControllerPackagesService_CheckInstalledPackageNameAvailability_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *kappControllerPackagesServiceClient) CreateInstalledPackage(ctx context.Context, in *v1alpha1.CreateInstalledPackageRequest, opts ...grpc.CallOption) (*v1alpha1.CreateInstalledPackageResponse, error) {
 	out := new(v1alpha1.CreateInstalledPackageResponse)
 	err := c.cc.Invoke(ctx, KappControllerPackagesService_CreateInstalledPackage_FullMethodName, in, out, opts...)
@@ -148,6 +219,51 @@ func (c *kappControllerPackagesServiceClient) GetInstalledPackageResourceRefs(ct
 	return out, nil
 }
 
+func (c *kappControllerPackagesServiceClient) GetInstalledPackageOperationStatus(ctx context.Context, in *v1alpha1.GetInstalledPackageOperationStatusRequest, opts ...grpc.CallOption) (*v1alpha1.GetInstalledPackageOperationStatusResponse, error) {
+	out := new(v1alpha1.GetInstalledPackageOperationStatusResponse)
+	err := c.cc.Invoke(ctx, KappControllerPackagesService_GetInstalledPackageOperationStatus_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kappControllerPackagesServiceClient) GetInstalledPackageChangePreview(ctx context.Context, in *GetInstalledPackageChangePreviewRequest, opts ...grpc.CallOption) (*GetInstalledPackageChangePreviewResponse, error) {
+	out := new(GetInstalledPackageChangePreviewResponse)
+	err := c.cc.Invoke(ctx, KappControllerPackagesService_GetInstalledPackageChangePreview_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kappControllerPackagesServiceClient) GetInstalledPackageReconcileHistory(ctx context.Context, in *GetInstalledPackageReconcileHistoryRequest, opts ...grpc.CallOption) (*GetInstalledPackageReconcileHistoryResponse, error) {
+	out := new(GetInstalledPackageReconcileHistoryResponse)
+	err := c.cc.Invoke(ctx, KappControllerPackagesService_GetInstalledPackageReconcileHistory_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kappControllerPackagesServiceClient) GetInstalledPackageManifest(ctx context.Context, in *GetInstalledPackageManifestRequest, opts ...grpc.CallOption) (*GetInstalledPackageManifestResponse, error) {
+	out := new(GetInstalledPackageManifestResponse)
+	err := c.cc.Invoke(ctx, KappControllerPackagesService_GetInstalledPackageManifest_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kappControllerPackagesServiceClient) UpdateInstalledPackageManifest(ctx context.Context, in *UpdateInstalledPackageManifestRequest, opts ...grpc.CallOption) (*UpdateInstalledPackageManifestResponse, error) {
+	out := new(UpdateInstalledPackageManifestResponse)
+	err := c.cc.Invoke(ctx, KappControllerPackagesService_UpdateInstalledPackageManifest_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // KappControllerPackagesServiceServer is the server API for KappControllerPackagesService service.
 // All implementations should embed UnimplementedKappControllerPackagesServiceServer
 // for forward compatibility
@@ -158,10 +274,18 @@ type KappControllerPackagesServiceServer interface {
 	GetAvailablePackageDetail(context.Context, *v1alpha1.GetAvailablePackageDetailRequest) (*v1alpha1.GetAvailablePackageDetailResponse, error)
 	// GetAvailablePackageVersions returns the package versions managed by the 'kapp_controller' plugin
 	GetAvailablePackageVersions(context.Context, *v1alpha1.GetAvailablePackageVersionsRequest) (*v1alpha1.GetAvailablePackageVersionsResponse, error)
+	// GetAvailablePackageTextAsset returns a range of a text asset of a package managed by the 'kapp_controller' plugin
+	GetAvailablePackageTextAsset(context.Context, *v1alpha1.GetAvailablePackageTextAssetRequest) (*v1alpha1.GetAvailablePackageTextAssetResponse, error)
+	// GetAvailablePackageChangelog returns the release notes between two versions of a package managed by the 'kapp_controller' plugin
+	GetAvailablePackageChangelog(context.Context, *v1alpha1.GetAvailablePackageChangelogRequest) (*v1alpha1.GetAvailablePackageChangelogResponse, error)
+	// GetAvailablePackageFilters returns the filter values of the available packages managed by the 'kapp_controller' plugin
+	GetAvailablePackageFilters(context.Context, *v1alpha1.GetAvailablePackageFiltersRequest) (*v1alpha1.GetAvailablePackageFiltersResponse, error)
 	// GetInstalledPackageSummaries returns the installed packages managed by the 'kapp_controller' plugin
 	GetInstalledPackageSummaries(context.Context, *v1alpha1.GetInstalledPackageSummariesRequest) (*v1alpha1.GetInstalledPackageSummariesResponse, error)
 	// GetInstalledPackageDetail returns the requested installed package managed by the 'kapp_controller' plugin
 	GetInstalledPackageDetail(context.Context, *v1alpha1.GetInstalledPackageDetailRequest) (*v1alpha1.GetInstalledPackageDetailResponse, error)
+	// CheckInstalledPackageNameAvailability checks whether a package managed by the 'kapp_controller' plugin can be installed with the given name
+	CheckInstalledPackageNameAvailability(context.Context, *v1alpha1.CheckInstalledPackageNameAvailabilityRequest) (*v1alpha1.CheckInstalledPackageNameAvailabilityResponse, error)
 	// CreateInstalledPackage creates an installed package based on the request.
 	CreateInstalledPackage(context.Context, *v1alpha1.CreateInstalledPackageRequest) (*v1alpha1.CreateInstalledPackageResponse, error)
 	// UpdateInstalledPackage updates an installed package based on the request.
@@ -171,6 +295,24 @@ type KappControllerPackagesServiceServer interface {
 	// GetInstalledPackageResourceRefs returns the references for the Kubernetes resources created by
 	// an installed package.
 	GetInstalledPackageResourceRefs(context.Context, *v1alpha1.GetInstalledPackageResourceRefsRequest) (*v1alpha1.GetInstalledPackageResourceRefsResponse, error)
+	// GetInstalledPackageOperationStatus returns the progress of the operation in course on an installed package managed by the 'kapp_controller' plugin
+	GetInstalledPackageOperationStatus(context.Context, *v1alpha1.GetInstalledPackageOperationStatusRequest) (*v1alpha1.GetInstalledPackageOperationStatusResponse, error)
+	// GetInstalledPackageChangePreview returns the changes of the Kubernetes resources that an update
+	// of an installed package managed by the 'kapp_controller' plugin would apply, without applying them.
+	GetInstalledPackageChangePreview(context.Context, *GetInstalledPackageChangePreviewRequest) (*GetInstalledPackageChangePreviewResponse, error)
+	// GetInstalledPackageReconcileHistory returns the last reconcile outcomes of an installed package
+	// managed by the 'kapp_controller' plugin, most recent first, so that flapping installations can
+	// be diagnosed. The outcomes are recorded when observed by the plugin.
+	GetInstalledPackageReconcileHistory(context.Context, *GetInstalledPackageReconcileHistoryRequest) (*GetInstalledPackageReconcileHistoryResponse, error)
+	// GetInstalledPackageManifest returns the raw YAML manifest of the PackageInstall, or of its App,
+	// of an installed package managed by the 'kapp_controller' plugin, for expert editing.
+	// Only available when the `advancedMode` plugin configuration is enabled.
+	GetInstalledPackageManifest(context.Context, *GetInstalledPackageManifestRequest) (*GetInstalledPackageManifestResponse, error)
+	// UpdateInstalledPackageManifest replaces the PackageInstall of an installed package managed by
+	// the 'kapp_controller' plugin with the given raw YAML manifest, once validated by the API server.
+	// The fields managed by the plugin cannot be changed.
+	// Only available when the `advancedMode` plugin configuration is enabled.
+	UpdateInstalledPackageManifest(context.Context, *UpdateInstalledPackageManifestRequest) (*UpdateInstalledPackageManifestResponse, error)
 }
 
 // UnimplementedKappControllerPackagesServiceServer should be embedded to have forward compatible implementations.
@@ -186,12 +328,24 @@ func (UnimplementedKappControllerPackagesServiceServer) GetAvailablePackageDetai
 func (UnimplementedKappControllerPackagesServiceServer) GetAvailablePackageVersions(context.Context, *v1alpha1.GetAvailablePackageVersionsRequest) (*v1alpha1.GetAvailablePackageVersionsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAvailablePackageVersions not implemented")
 }
+func (UnimplementedKappControllerPackagesServiceServer) GetAvailablePackageTextAsset(context.Context, *v1alpha1.GetAvailablePackageTextAssetRequest) (*v1alpha1.GetAvailablePackageTextAssetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAvailablePackageTextAsset not implemented")
+}
+func (UnimplementedKappControllerPackagesServiceServer) GetAvailablePackageChangelog(context.Context, *v1alpha1.GetAvailablePackageChangelogRequest) (*v1alpha1.GetAvailablePackageChangelogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAvailablePackageChangelog not implemented")
+}
+func (UnimplementedKappControllerPackagesServiceServer) GetAvailablePackageFilters(context.Context, *v1alpha1.GetAvailablePackageFiltersRequest) (*v1alpha1.GetAvailablePackageFiltersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAvailablePackageFilters not implemented")
+}
 func (UnimplementedKappControllerPackagesServiceServer) GetInstalledPackageSummaries(context.Context, *v1alpha1.GetInstalledPackageSummariesRequest) (*v1alpha1.GetInstalledPackageSummariesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetInstalledPackageSummaries not implemented")
 }
 func (UnimplementedKappControllerPackagesServiceServer) GetInstalledPackageDetail(context.Context, *v1alpha1.GetInstalledPackageDetailRequest) (*v1alpha1.GetInstalledPackageDetailResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetInstalledPackageDetail not implemented")
 }
+func (UnimplementedKappControllerPackagesServiceServer) CheckInstalledPackageNameAvailability(context.Context, *v1alpha1.CheckInstalledPackageNameAvailabilityRequest) (*v1alpha1.CheckInstalledPackageNameAvailabilityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckInstalledPackageNameAvailability not implemented")
+}
 func (UnimplementedKappControllerPackagesServiceServer) CreateInstalledPackage(context.Context, *v1alpha1.CreateInstalledPackageRequest) (*v1alpha1.CreateInstalledPackageResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateInstalledPackage not implemented")
 }
@@ -204,6 +358,21 @@ func (UnimplementedKappControllerPackagesServiceServer) DeleteInstalledPackage(c
 func (UnimplementedKappControllerPackagesServiceServer) GetInstalledPackageResourceRefs(context.Context, *v1alpha1.GetInstalledPackageResourceRefsRequest) (*v1alpha1.GetInstalledPackageResourceRefsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetInstalledPackageResourceRefs not implemented")
 }
+func (UnimplementedKappControllerPackagesServiceServer) GetInstalledPackageOperationStatus(context.Context, *v1alpha1.GetInstalledPackageOperationStatusRequest) (*v1alpha1.GetInstalledPackageOperationStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInstalledPackageOperationStatus not implemented")
+}
+func (UnimplementedKappControllerPackagesServiceServer) GetInstalledPackageChangePreview(context.Context, *GetInstalledPackageChangePreviewRequest) (*GetInstalledPackageChangePreviewResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInstalledPackageChangePreview not implemented")
+}
+func (UnimplementedKappControllerPackagesServiceServer) GetInstalledPackageReconcileHistory(context.Context, *GetInstalledPackageReconcileHistoryRequest) (*GetInstalledPackageReconcileHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInstalledPackageReconcileHistory not implemented")
+}
+func (UnimplementedKappControllerPackagesServiceServer) GetInstalledPackageManifest(context.Context, *GetInstalledPackageManifestRequest) (*GetInstalledPackageManifestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInstalledPackageManifest not implemented")
+}
+func (UnimplementedKappControllerPackagesServiceServer) UpdateInstalledPackageManifest(context.Context, *UpdateInstalledPackageManifestRequest) (*UpdateInstalledPackageManifestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateInstalledPackageManifest not implemented")
+}
 
 // UnsafeKappControllerPackagesServiceServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to KappControllerPackagesServiceServer will
@@ -270,6 +439,60 @@ func _KappControllerPackagesService_GetAvailablePackageVersions_Handler(srv inte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _KappControllerPackagesService_GetAvailablePackageTextAsset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha1.GetAvailablePackageTextAssetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KappControllerPackagesServiceServer).GetAvailablePackageTextAsset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KappControllerPackagesService_GetAvailablePackageTextAsset_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KappControllerPackagesServiceServer).GetAvailablePackageTextAsset(ctx, req.(*v1alpha1.GetAvailablePackageTextAssetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KappControllerPackagesService_GetAvailablePackageChangelog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha1.GetAvailablePackageChangelogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KappControllerPackagesServiceServer).GetAvailablePackageChangelog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KappControllerPackagesService_GetAvailablePackageChangelog_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KappControllerPackagesServiceServer).GetAvailablePackageChangelog(ctx, req.(*v1alpha1.GetAvailablePackageChangelogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KappControllerPackagesService_GetAvailablePackageFilters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha1.GetAvailablePackageFiltersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KappControllerPackagesServiceServer).GetAvailablePackageFilters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KappControllerPackagesService_GetAvailablePackageFilters_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KappControllerPackagesServiceServer).GetAvailablePackageFilters(ctx, req.(*v1alpha1.GetAvailablePackageFiltersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _KappControllerPackagesService_GetInstalledPackageSummaries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(v1alpha1.GetInstalledPackageSummariesRequest)
 	if err := dec(in); err != nil {
@@ -306,6 +529,24 @@ func _KappControllerPackagesService_GetInstalledPackageDetail_Handler(srv interf
 	return interceptor(ctx, in, info, handler)
 }
 
+func _KappControllerPackagesService_CheckInstalledPackageNameAvailability_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha1.CheckInstalledPackageNameAvailabilityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KappControllerPackagesServiceServer).CheckInstalledPackageNameAvailability(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KappControllerPackagesService_CheckInstalledPackageNameAvailability_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KappControllerPackagesServiceServer).CheckInstalledPackageNameAvailability(ctx, req.(*v1alpha1.CheckInstalledPackageNameAvailabilityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _KappControllerPackagesService_CreateInstalledPackage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(v1alpha1.CreateInstalledPackageRequest)
 	if err := dec(in); err != nil {
@@ -378,6 +619,96 @@ func _KappControllerPackagesService_GetInstalledPackageResourceRefs_Handler(srv
 	return interceptor(ctx, in, info, handler)
 }
 
+func _KappControllerPackagesService_GetInstalledPackageOperationStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha1.GetInstalledPackageOperationStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KappControllerPackagesServiceServer).GetInstalledPackageOperationStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KappControllerPackagesService_GetInstalledPackageOperationStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KappControllerPackagesServiceServer).GetInstalledPackageOperationStatus(ctx, req.(*v1alpha1.GetInstalledPackageOperationStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KappControllerPackagesService_GetInstalledPackageChangePreview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInstalledPackageChangePreviewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KappControllerPackagesServiceServer).GetInstalledPackageChangePreview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KappControllerPackagesService_GetInstalledPackageChangePreview_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KappControllerPackagesServiceServer).GetInstalledPackageChangePreview(ctx, req.(*GetInstalledPackageChangePreviewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KappControllerPackagesService_GetInstalledPackageReconcileHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInstalledPackageReconcileHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KappControllerPackagesServiceServer).GetInstalledPackageReconcileHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KappControllerPackagesService_GetInstalledPackageReconcileHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KappControllerPackagesServiceServer).GetInstalledPackageReconcileHistory(ctx, req.(*GetInstalledPackageReconcileHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KappControllerPackagesService_GetInstalledPackageManifest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInstalledPackageManifestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KappControllerPackagesServiceServer).GetInstalledPackageManifest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KappControllerPackagesService_GetInstalledPackageManifest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KappControllerPackagesServiceServer).GetInstalledPackageManifest(ctx, req.(*GetInstalledPackageManifestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KappControllerPackagesService_UpdateInstalledPackageManifest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateInstalledPackageManifestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KappControllerPackagesServiceServer).UpdateInstalledPackageManifest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KappControllerPackagesService_UpdateInstalledPackageManifest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KappControllerPackagesServiceServer).UpdateInstalledPackageManifest(ctx, req.(*UpdateInstalledPackageManifestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // KappControllerPackagesService_ServiceDesc is the grpc.ServiceDesc for KappControllerPackagesService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -397,6 +728,18 @@ var KappControllerPackagesService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetAvailablePackageVersions",
 			Handler:    _KappControllerPackagesService_GetAvailablePackageVersions_Handler,
 		},
+		{
+			MethodName: "GetAvailablePackageTextAsset",
+			Handler:    _KappControllerPackagesService_GetAvailablePackageTextAsset_Handler,
+		},
+		{
+			MethodName: "GetAvailablePackageChangelog",
+			Handler:    _KappControllerPackagesService_GetAvailablePackageChangelog_Handler,
+		},
+		{
+			MethodName: "GetAvailablePackageFilters",
+			Handler:    _KappControllerPackagesService_GetAvailablePackageFilters_Handler,
+		},
 		{
 			MethodName: "GetInstalledPackageSummaries",
 			Handler:    _KappControllerPackagesService_GetInstalledPackageSummaries_Handler,
@@ -405,6 +748,10 @@ var KappControllerPackagesService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetInstalledPackageDetail",
 			Handler:    _KappControllerPackagesService_GetInstalledPackageDetail_Handler,
 		},
+		{
+			MethodName: "CheckInstalledPackageNameAvailability",
+			Handler:    _KappControllerPackagesService_CheckInstalledPackageNameAvailability_Handler,
+		},
 		{
 			MethodName: "CreateInstalledPackage",
 			Handler:    _KappControllerPackagesService_CreateInstalledPackage_Handler,
@@ -421,6 +768,26 @@ var KappControllerPackagesService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetInstalledPackageResourceRefs",
 			Handler:    _KappControllerPackagesService_GetInstalledPackageResourceRefs_Handler,
 		},
+		{
+			MethodName: "GetInstalledPackageOperationStatus",
+			Handler:    _KappControllerPackagesService_GetInstalledPackageOperationStatus_Handler,
+		},
+		{
+			MethodName: "GetInstalledPackageChangePreview",
+			Handler:    _KappControllerPackagesService_GetInstalledPackageChangePreview_Handler,
+		},
+		{
+			MethodName: "GetInstalledPackageReconcileHistory",
+			Handler:    _KappControllerPackagesService_GetInstalledPackageReconcileHistory_Handler,
+		},
+		{
+			MethodName: "GetInstalledPackageManifest",
+			Handler:    _KappControllerPackagesService_GetInstalledPackageManifest_Handler,
+		},
+		{
+			MethodName: "UpdateInstalledPackageManifest",
+			Handler:    _KappControllerPackagesService_UpdateInstalledPackageManifest_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "kubeappsapis/plugins/kapp_controller/packages/v1alpha1/kapp_controller.proto",
@@ -433,6 +800,9 @@ const (
 	KappControllerRepositoriesService_UpdatePackageRepository_FullMethodName         = "/kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerRepositoriesService/UpdatePackageRepository"
 	KappControllerRepositoriesService_DeletePackageRepository_FullMethodName         = "/kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerRepositoriesService/DeletePackageRepository"
 	KappControllerRepositoriesService_GetPackageRepositoryPermissions_FullMethodName = "/kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerRepositoriesService/GetPackageRepositoryPermissions"
+	KappControllerRepositoriesService_TestPackageRepositoryConnection_FullMethodName = "/kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerRepositoriesService/TestPackageRepositoryConnection"
+	KappControllerRepositoriesService_ListPackageRepositoryPackages_FullMethodName   = "/kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerRepositoriesService/ListPackageRepositoryPackages"
+	KappControllerRepositoriesService_ScanPackageRepositoryHostKeys_FullMethodName   = "/kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerRepositoriesService/ScanPackageRepositoryHostKeys"
 )
 
 // KappControllerRepositoriesServiceClient is the client API for KappControllerRepositoriesService service.
@@ -446,6 +816,13 @@ type KappControllerRepositoriesServiceClient interface {
 	UpdatePackageRepository(ctx context.Context, in *v1alpha1.UpdatePackageRepositoryRequest, opts ...grpc.CallOption) (*v1alpha1.UpdatePackageRepositoryResponse, error)
 	DeletePackageRepository(ctx context.Context, in *v1alpha1.DeletePackageRepositoryRequest, opts ...grpc.CallOption) (*v1alpha1.DeletePackageRepositoryResponse, error)
 	GetPackageRepositoryPermissions(ctx context.Context, in *v1alpha1.GetPackageRepositoryPermissionsRequest, opts ...grpc.CallOption) (*v1alpha1.GetPackageRepositoryPermissionsResponse, error)
+	TestPackageRepositoryConnection(ctx context.Context, in *v1alpha1.TestPackageRepositoryConnectionRequest, opts ...grpc.CallOption) (*v1alpha1.TestPackageRepositoryConnectionResponse, error)
+	ListPackageRepositoryPackages(ctx context.Context, in *v1alpha1.ListPackageRepositoryPackagesRequest, opts ...grpc.CallOption) (*v1alpha1.ListPackageRepositoryPackagesResponse, error)
+	// ScanPackageRepositoryHostKeys scans the SSH host keys of the git server of a package repository
+	// managed by the 'kapp_controller' plugin, returning their fingerprints to be confirmed by the user.
+	// The confirmed fingerprints are then sent as the trusted_host_key_fingerprints of the custom detail
+	// when adding or updating the repository, so that the plugin stores the matching keys as its known hosts.
+	ScanPackageRepositoryHostKeys(ctx context.Context, in *ScanPackageRepositoryHostKeysRequest, opts ...grpc.CallOption) (*ScanPackageRepositoryHostKeysResponse, error)
 }
 
 type kappControllerRepositoriesServiceClient struct {
@@ -510,6 +887,33 @@ func (c *kappControllerRepositoriesServiceClient) GetPackageRepositoryPermission
 	return out, nil
 }
 
+func (c *kappControllerRepositoriesServiceClient) TestPackageRepositoryConnection(ctx context.Context, in *v1alpha1.TestPackageRepositoryConnectionRequest, opts ...grpc.CallOption) (*v1alpha1.TestPackageRepositoryConnectionResponse, error) {
+	out := new(v1alpha1.TestPackageRepositoryConnectionResponse)
+	err := c.cc.Invoke(ctx, KappControllerRepositoriesService_TestPackageRepositoryConnection_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kappControllerRepositoriesServiceClient) ListPackageRepositoryPackages(ctx context.Context, in *v1alpha1.ListPackageRepositoryPackagesRequest, opts ...grpc.CallOption) (*v1alpha1.ListPackageRepositoryPackagesResponse, error) {
+	out := new(v1alpha1.ListPackageRepositoryPackagesResponse)
+	err := c.cc.Invoke(ctx, KappControllerRepositoriesService_ListPackageRepositoryPackages_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kappControllerRepositoriesServiceClient) ScanPackageRepositoryHostKeys(ctx context.Context, in *ScanPackageRepositoryHostKeysRequest, opts ...grpc.CallOption) (*ScanPackageRepositoryHostKeysResponse, error) {
+	out := new(ScanPackageRepositoryHostKeysResponse)
+	err := c.cc.Invoke(ctx, KappControllerRepositoriesService_ScanPackageRepositoryHostKeys_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // KappControllerRepositoriesServiceServer is the server API for KappControllerRepositoriesService service.
 // All implementations should embed UnimplementedKappControllerRepositoriesServiceServer
 // for forward compatibility
@@ -521,6 +925,13 @@ type KappControllerRepositoriesServiceServer interface {
 	UpdatePackageRepository(context.Context, *v1alpha1.UpdatePackageRepositoryRequest) (*v1alpha1.UpdatePackageRepositoryResponse, error)
 	DeletePackageRepository(context.Context, *v1alpha1.DeletePackageRepositoryRequest) (*v1alpha1.DeletePackageRepositoryResponse, error)
 	GetPackageRepositoryPermissions(context.Context, *v1alpha1.GetPackageRepositoryPermissionsRequest) (*v1alpha1.GetPackageRepositoryPermissionsResponse, error)
+	TestPackageRepositoryConnection(context.Context, *v1alpha1.TestPackageRepositoryConnectionRequest) (*v1alpha1.TestPackageRepositoryConnectionResponse, error)
+	ListPackageRepositoryPackages(context.Context, *v1alpha1.ListPackageRepositoryPackagesRequest) (*v1alpha1.ListPackageRepositoryPackagesResponse, error)
+	// ScanPackageRepositoryHostKeys scans the SSH host keys of the git server of a package repository
+	// managed by the 'kapp_controller' plugin, returning their fingerprints to be confirmed by the user.
+	// The confirmed fingerprints are then sent as the trusted_host_key_fingerprints of the custom detail
+	// when adding or updating the repository, so that the plugin stores the matching keys as its known hosts.
+	ScanPackageRepositoryHostKeys(context.Context, *ScanPackageRepositoryHostKeysRequest) (*ScanPackageRepositoryHostKeysResponse, error)
 }
 
 // UnimplementedKappControllerRepositoriesServiceServer should be embedded to have forward compatible implementations.
@@ -545,6 +956,15 @@ func (UnimplementedKappControllerRepositoriesServiceServer) DeletePackageReposit
 func (UnimplementedKappControllerRepositoriesServiceServer) GetPackageRepositoryPermissions(context.Context, *v1alpha1.GetPackageRepositoryPermissionsRequest) (*v1alpha1.GetPackageRepositoryPermissionsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetPackageRepositoryPermissions not implemented")
 }
+func (UnimplementedKappControllerRepositoriesServiceServer) TestPackageRepositoryConnection(context.Context, *v1alpha1.TestPackageRepositoryConnectionRequest) (*v1alpha1.TestPackageRepositoryConnectionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TestPackageRepositoryConnection not implemented")
+}
+func (UnimplementedKappControllerRepositoriesServiceServer) ListPackageRepositoryPackages(context.Context, *v1alpha1.ListPackageRepositoryPackagesRequest) (*v1alpha1.ListPackageRepositoryPackagesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPackageRepositoryPackages not implemented")
+}
+func (UnimplementedKappControllerRepositoriesServiceServer) ScanPackageRepositoryHostKeys(context.Context, *ScanPackageRepositoryHostKeysRequest) (*ScanPackageRepositoryHostKeysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ScanPackageRepositoryHostKeys not implemented")
+}
 
 // UnsafeKappControllerRepositoriesServiceServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to KappControllerRepositoriesServiceServer will
@@ -665,6 +1085,60 @@ func _KappControllerRepositoriesService_GetPackageRepositoryPermissions_Handler(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _KappControllerRepositoriesService_TestPackageRepositoryConnection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha1.TestPackageRepositoryConnectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KappControllerRepositoriesServiceServer).TestPackageRepositoryConnection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KappControllerRepositoriesService_TestPackageRepositoryConnection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KappControllerRepositoriesServiceServer).TestPackageRepositoryConnection(ctx, req.(*v1alpha1.TestPackageRepositoryConnectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KappControllerRepositoriesService_ListPackageRepositoryPackages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha1.ListPackageRepositoryPackagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KappControllerRepositoriesServiceServer).ListPackageRepositoryPackages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KappControllerRepositoriesService_ListPackageRepositoryPackages_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KappControllerRepositoriesServiceServer).ListPackageRepositoryPackages(ctx, req.(*v1alpha1.ListPackageRepositoryPackagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KappControllerRepositoriesService_ScanPackageRepositoryHostKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScanPackageRepositoryHostKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KappControllerRepositoriesServiceServer).ScanPackageRepositoryHostKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KappControllerRepositoriesService_ScanPackageRepositoryHostKeys_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KappControllerRepositoriesServiceServer).ScanPackageRepositoryHostKeys(ctx, req.(*ScanPackageRepositoryHostKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // KappControllerRepositoriesService_ServiceDesc is the grpc.ServiceDesc for KappControllerRepositoriesService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -696,6 +1170,18 @@ var KappControllerRepositoriesService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetPackageRepositoryPermissions",
 			Handler:    _KappControllerRepositoriesService_GetPackageRepositoryPermissions_Handler,
 		},
+		{
+			MethodName: "TestPackageRepositoryConnection",
+			Handler:    _KappControllerRepositoriesService_TestPackageRepositoryConnection_Handler,
+		},
+		{
+			MethodName: "ListPackageRepositoryPackages",
+			Handler:    _KappControllerRepositoriesService_ListPackageRepositoryPackages_Handler,
+		},
+		{
+			MethodName: "ScanPackageRepositoryHostKeys",
+			Handler:    _KappControllerRepositoriesService_ScanPackageRepositoryHostKeys_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "kubeappsapis/plugins/kapp_controller/packages/v1alpha1/kapp_controller.proto",
@@ -12,7 +12,7 @@ import (
 	errors "errors"
 	connect_go "github.com/bufbuild/connect-go"
 	v1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
-	_ "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/plugins/kapp_controller/packages/v1alpha1"
+	v1alpha11 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/plugins/kapp_controller/packages/v1alpha1"
 	http "net/http"
 	strings "strings"
 )
@@ -50,12 +50,25 @@ const (
 	// KappControllerPackagesServiceGetAvailablePackageVersionsProcedure is the fully-qualified name of
 	// the KappControllerPackagesService's GetAvailablePackageVersions RPC.
 	KappControllerPackagesServiceGetAvailablePackageVersionsProcedure = "/kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService/GetAvailablePackageVersions"
+	// KappControllerPackagesServiceGetAvailablePackageTextAssetProcedure is the fully-qualified name of
+	// the KappControllerPackagesService's GetAvailablePackageTextAsset RPC.
+	KappControllerPackagesServiceGetAvailablePackageTextAssetProcedure = "/kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService/GetAvailablePackageTextAsset"
+	// KappControllerPackagesServiceGetAvailablePackageChangelogProcedure is the fully-qualified name of
+	// the KappControllerPackagesService's GetAvailablePackageChangelog RPC.
+	KappControllerPackagesServiceGetAvailablePackageChangelogProcedure = "/kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService/GetAvailablePackageChangelog"
+	// KappControllerPackagesServiceGetAvailablePackageFiltersProcedure is the fully-qualified name of
+	// the KappControllerPackagesService's GetAvailablePackageFilters RPC.
+	KappControllerPackagesServiceGetAvailablePackageFiltersProcedure = "/kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService/GetAvailablePackageFilters"
 	// KappControllerPackagesServiceGetInstalledPackageSummariesProcedure is the fully-qualified name of
 	// the KappControllerPackagesService's GetInstalledPackageSummaries RPC.
 	KappControllerPackagesServiceGetInstalledPackageSummariesProcedure = "/kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService/GetInstalledPackageSummaries"
 	// KappControllerPackagesServiceGetInstalledPackageDetailProcedure is the fully-qualified name of
 	// the KappControllerPackagesService's GetInstalledPackageDetail RPC.
 	KappControllerPackagesServiceGetInstalledPackageDetailProcedure = "/kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService/GetInstalledPackageDetail"
+	// KappControllerPackagesServiceCheckInstalledPackageNameAvailabilityProcedure is the
+	// fully-qualified name of the KappControllerPackagesService's CheckInstalledPackageNameAvailability
+	// RPC.
+	KappControllerPackagesServiceCheckInstalledPackageNameAvailabilityProcedure = "/kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService/CheckInstalledPackageNameAvailability"
 	// KappControllerPackagesServiceCreateInstalledPackageProcedure is the fully-qualified name of the
 	// KappControllerPackagesService's CreateInstalledPackage RPC.
 	KappControllerPackagesServiceCreateInstalledPackageProcedure = "/kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService/CreateInstalledPackage"
@@ -68,6 +81,21 @@ const (
 	// KappControllerPackagesServiceGetInstalledPackageResourceRefsProcedure is the fully-qualified name
 	// of the KappControllerPackagesService's GetInstalledPackageResourceRefs RPC.
 	KappControllerPackagesServiceGetInstalledPackageResourceRefsProcedure = "/kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService/GetInstalledPackageResourceRefs"
+	// KappControllerPackagesServiceGetInstalledPackageOperationStatusProcedure is the fully-qualified
+	// name of the KappControllerPackagesService's GetInstalledPackageOperationStatus RPC.
+	KappControllerPackagesServiceGetInstalledPackageOperationStatusProcedure = "/kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService/GetInstalledPackageOperationStatus"
+	// KappControllerPackagesServiceGetInstalledPackageChangePreviewProcedure is the fully-qualified
+	// name of the KappControllerPackagesService's GetInstalledPackageChangePreview RPC.
+	KappControllerPackagesServiceGetInstalledPackageChangePreviewProcedure = "/kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService/GetInstalledPackageChangePreview"
+	// KappControllerPackagesServiceGetInstalledPackageReconcileHistoryProcedure is the fully-qualified
+	// name of the KappControllerPackagesService's GetInstalledPackageReconcileHistory RPC.
+	KappControllerPackagesServiceGetInstalledPackageReconcileHistoryProcedure = "/kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService/GetInstalledPackageReconcileHistory"
+	// KappControllerPackagesServiceGetInstalledPackageManifestProcedure is the fully-qualified name of
+	// the KappControllerPackagesService's GetInstalledPackageManifest RPC.
+	KappControllerPackagesServiceGetInstalledPackageManifestProcedure = "/kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService/GetInstalledPackageManifest"
+	// KappControllerPackagesServiceUpdateInstalledPackageManifestProcedure is the fully-qualified name
+	// of the KappControllerPackagesService's UpdateInstalledPackageManifest RPC.
+	KappControllerPackagesServiceUpdateInstalledPackageManifestProcedure = "/kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService/UpdateInstalledPackageManifest"
 	// KappControllerRepositoriesServiceAddPackageRepositoryProcedure is the fully-qualified name of the
 	// KappControllerRepositoriesService's AddPackageRepository RPC.
 	KappControllerRepositoriesServiceAddPackageRepositoryProcedure = "/kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerRepositoriesService/AddPackageRepository"
@@ -86,6 +114,15 @@ const (
 	// KappControllerRepositoriesServiceGetPackageRepositoryPermissionsProcedure is the fully-qualified
 	// name of the KappControllerRepositoriesService's GetPackageRepositoryPermissions RPC.
 	KappControllerRepositoriesServiceGetPackageRepositoryPermissionsProcedure = "/kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerRepositoriesService/GetPackageRepositoryPermissions"
+	// KappControllerRepositoriesServiceTestPackageRepositoryConnectionProcedure is the fully-qualified
+	// name of the KappControllerRepositoriesService's TestPackageRepositoryConnection RPC.
+	KappControllerRepositoriesServiceTestPackageRepositoryConnectionProcedure = "/kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerRepositoriesService/TestPackageRepositoryConnection"
+	// KappControllerRepositoriesServiceListPackageRepositoryPackagesProcedure is the fully-qualified
+	// name of the KappControllerRepositoriesService's ListPackageRepositoryPackages RPC.
+	KappControllerRepositoriesServiceListPackageRepositoryPackagesProcedure = "/kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerRepositoriesService/ListPackageRepositoryPackages"
+	// KappControllerRepositoriesServiceScanPackageRepositoryHostKeysProcedure is the fully-qualified
+	// name of the KappControllerRepositoriesService's ScanPackageRepositoryHostKeys RPC.
+	KappControllerRepositoriesServiceScanPackageRepositoryHostKeysProcedure = "/kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerRepositoriesService/ScanPackageRepositoryHostKeys"
 )
 
 // KappControllerPackagesServiceClient is a client for the
@@ -97,10 +134,18 @@ type KappControllerPackagesServiceClient interface {
 	GetAvailablePackageDetail(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageDetailRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageDetailResponse], error)
 	// GetAvailablePackageVersions returns the package versions managed by the 'kapp_controller' plugin
 	GetAvailablePackageVersions(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageVersionsRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageVersionsResponse], error)
+	// GetAvailablePackageTextAsset returns a range of a text asset of a package managed by the 'kapp_controller' plugin
+	GetAvailablePackageTextAsset(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageTextAssetRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageTextAssetResponse], error)
+	// GetAvailablePackageChangelog returns the release notes between two versions of a package managed by the 'kapp_controller' plugin
+	GetAvailablePackageChangelog(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageChangelogRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageChangelogResponse], error)
+	// GetAvailablePackageFilters returns the filter values of the available packages managed by the 'kapp_controller' plugin
+	GetAvailablePackageFilters(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageFiltersRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageFiltersResponse], error)
 	// GetInstalledPackageSummaries returns the installed packages managed by the 'kapp_controller' plugin
 	GetInstalledPackageSummaries(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageSummariesRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageSummariesResponse], error)
 	// GetInstalledPackageDetail returns the requested installed package managed by the 'kapp_controller' plugin
 	GetInstalledPackageDetail(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageDetailRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageDetailResponse], error)
+	// CheckInstalledPackageNameAvailability checks whether a package managed by the 'kapp_controller' plugin can be installed with the given name
+	CheckInstalledPackageNameAvailability(context.Context, *connect_go.Request[v1alpha1.CheckInstalledPackageNameAvailabilityRequest]) (*connect_go.Response[v1alpha1.CheckInstalledPackageNameAvailabilityResponse], error)
 	// CreateInstalledPackage creates an installed package based on the request.
 	CreateInstalledPackage(context.Context, *connect_go.Request[v1alpha1.CreateInstalledPackageRequest]) (*connect_go.Response[v1alpha1.CreateInstalledPackageResponse], error)
 	// UpdateInstalledPackage updates an installed package based on the request.
@@ -110,6 +155,24 @@ type KappControllerPackagesServiceClient interface {
 	// GetInstalledPackageResourceRefs returns the references for the Kubernetes resources created by
 	// an installed package.
 	GetInstalledPackageResourceRefs(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageResourceRefsRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageResourceRefsResponse], error)
+	// GetInstalledPackageOperationStatus returns the progress of the operation in course on an installed package managed by the 'kapp_controller' plugin
+	GetInstalledPackageOperationStatus(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageOperationStatusRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageOperationStatusResponse], error)
+	// GetInstalledPackageChangePreview returns the changes of the Kubernetes resources that an update
+	// of an installed package managed by the 'kapp_controller' plugin would apply, without applying them.
+	GetInstalledPackageChangePreview(context.Context, *connect_go.Request[v1alpha11.GetInstalledPackageChangePreviewRequest]) (*connect_go.Response[v1alpha11.GetInstalledPackageChangePreviewResponse], error)
+	// GetInstalledPackageReconcileHistory returns the last reconcile outcomes of an installed package
+	// managed by the 'kapp_controller' plugin, most recent first, so that flapping installations can
+	// be diagnosed. The outcomes are recorded when observed by the plugin.
+	GetInstalledPackageReconcileHistory(context.Context, *connect_go.Request[v1alpha11.GetInstalledPackageReconcileHistoryRequest]) (*connect_go.Response[v1alpha11.GetInstalledPackageReconcileHistoryResponse], error)
+	// GetInstalledPackageManifest returns the raw YAML manifest of the PackageInstall, or of its App,
+	// of an installed package managed by the 'kapp_controller' plugin, for expert editing.
+	// Only available when the `advancedMode` plugin configuration is enabled.
+	GetInstalledPackageManifest(context.Context, *connect_go.Request[v1alpha11.GetInstalledPackageManifestRequest]) (*connect_go.Response[v1alpha11.GetInstalledPackageManifestResponse], error)
+	// UpdateInstalledPackageManifest replaces the PackageInstall of an installed package managed by
+	// the 'kapp_controller' plugin with the given raw YAML manifest, once validated by the API server.
+	// The fields managed by the plugin cannot be changed.
+	// Only available when the `advancedMode` plugin configuration is enabled.
+	UpdateInstalledPackageManifest(context.Context, *connect_go.Request[v1alpha11.UpdateInstalledPackageManifestRequest]) (*connect_go.Response[v1alpha11.UpdateInstalledPackageManifestResponse], error)
 }
 
 // NewKappControllerPackagesServiceClient constructs a client for the
@@ -138,6 +201,21 @@ func NewKappControllerPackagesServiceClient(httpClient connect_go.HTTPClient, ba
 			baseURL+KappControllerPackagesServiceGetAvailablePackageVersionsProcedure,
 			opts...,
 		),
+		getAvailablePackageTextAsset: connect_go.NewClient[v1alpha1.GetAvailablePackageTextAssetRequest, v1alpha1.GetAvailablePackageTextAssetResponse](
+			httpClient,
+			baseURL+KappControllerPackagesServiceGetAvailablePackageTextAssetProcedure,
+			opts...,
+		),
+		getAvailablePackageChangelog: connect_go.NewClient[v1alpha1.GetAvailablePackageChangelogRequest, v1alpha1.GetAvailablePackageChangelogResponse](
+			httpClient,
+			baseURL+KappControllerPackagesServiceGetAvailablePackageChangelogProcedure,
+			opts...,
+		),
+		getAvailablePackageFilters: connect_go.NewClient[v1alpha1.GetAvailablePackageFiltersRequest, v1alpha1.GetAvailablePackageFiltersResponse](
+			httpClient,
+			baseURL+KappControllerPackagesServiceGetAvailablePackageFiltersProcedure,
+			opts...,
+		),
 		getInstalledPackageSummaries: connect_go.NewClient[v1alpha1.GetInstalledPackageSummariesRequest, v1alpha1.GetInstalledPackageSummariesResponse](
 			httpClient,
 			baseURL+KappControllerPackagesServiceGetInstalledPackageSummariesProcedure,
@@ -148,6 +226,11 @@ func NewKappControllerPackagesServiceClient(httpClient connect_go.HTTPClient, ba
 			baseURL+KappControllerPackagesServiceGetInstalledPackageDetailProcedure,
 			opts...,
 		),
+		checkInstalledPackageNameAvailability: connect_go.NewClient[v1alpha1.CheckInstalledPackageNameAvailabilityRequest, v1alpha1.CheckInstalledPackageNameAvailabilityResponse](
+			httpClient,
+			baseURL+KappControllerPackagesServiceCheckInstalledPackageNameAvailabilityProcedure,
+			opts...,
+		),
 		createInstalledPackage: connect_go.NewClient[v1alpha1.CreateInstalledPackageRequest, v1alpha1.CreateInstalledPackageResponse](
 			httpClient,
 			baseURL+KappControllerPackagesServiceCreateInstalledPackageProcedure,
@@ -168,20 +251,54 @@ func NewKappControllerPackagesServiceClient(httpClient connect_go.HTTPClient, ba
 			baseURL+KappControllerPackagesServiceGetInstalledPackageResourceRefsProcedure,
 			opts...,
 		),
+		getInstalledPackageOperationStatus: connect_go.NewClient[v1alpha1.GetInstalledPackageOperationStatusRequest, v1alpha1.GetInstalledPackageOperationStatusResponse](
+			httpClient,
+			baseURL+KappControllerPackagesServiceGetInstalledPackageOperationStatusProcedure,
+			opts...,
+		),
+		getInstalledPackageChangePreview: connect_go.NewClient[v1alpha11.GetInstalledPackageChangePreviewRequest, v1alpha11.GetInstalledPackageChangePreviewResponse](
+			httpClient,
+			baseURL+KappControllerPackagesServiceGetInstalledPackageChangePreviewProcedure,
+			opts...,
+		),
+		getInstalledPackageReconcileHistory: connect_go.NewClient[v1alpha11.GetInstalledPackageReconcileHistoryRequest, v1alpha11.GetInstalledPackageReconcileHistoryResponse](
+			httpClient,
+			baseURL+KappControllerPackagesServiceGetInstalledPackageReconcileHistoryProcedure,
+			opts...,
+		),
+		getInstalledPackageManifest: connect_go.NewClient[v1alpha11.GetInstalledPackageManifestRequest, v1alpha11.GetInstalledPackageManifestResponse](
+			httpClient,
+			baseURL+KappControllerPackagesServiceGetInstalledPackageManifestProcedure,
+			opts...,
+		),
+		updateInstalledPackageManifest: connect_go.NewClient[v1alpha11.UpdateInstalledPackageManifestRequest, v1alpha11.UpdateInstalledPackageManifestResponse](
+			httpClient,
+			baseURL+KappControllerPackagesServiceUpdateInstalledPackageManifestProcedure,
+			opts...,
+		),
 	}
 }
 
 // kappControllerPackagesServiceClient implements KappControllerPackagesServiceClient.
 type kappControllerPackagesServiceClient struct {
-	getAvailablePackageSummaries    *connect_go.Client[v1alpha1.GetAvailablePackageSummariesRequest, v1alpha1.GetAvailablePackageSummariesResponse]
-	getAvailablePackageDetail       *connect_go.Client[v1alpha1.GetAvailablePackageDetailRequest, v1alpha1.GetAvailablePackageDetailResponse]
-	getAvailablePackageVersions     *connect_go.Client[v1alpha1.GetAvailablePackageVersionsRequest, v1alpha1.GetAvailablePackageVersionsResponse]
-	getInstalledPackageSummaries    *connect_go.Client[v1alpha1.GetInstalledPackageSummariesRequest, v1alpha1.GetInstalledPackageSummariesResponse]
-	getInstalledPackageDetail       *connect_go.Client[v1alpha1.GetInstalledPackageDetailRequest, v1alpha1.GetInstalledPackageDetailResponse]
-	createInstalledPackage          *connect_go.Client[v1alpha1.CreateInstalledPackageRequest, v1alpha1.CreateInstalledPackageResponse]
-	updateInstalledPackage          *connect_go.Client[v1alpha1.UpdateInstalledPackageRequest, v1alpha1.UpdateInstalledPackageResponse]
-	deleteInstalledPackage          *connect_go.Client[v1alpha1.DeleteInstalledPackageRequest, v1alpha1.DeleteInstalledPackageResponse]
-	getInstalledPackageResourceRefs *connect_go.Client[v1alpha1.GetInstalledPackageResourceRefsRequest, v1alpha1.GetInstalledPackageResourceRefsResponse]
+	getAvailablePackageSummaries          *connect_go.Client[v1alpha1.GetAvailablePackageSummariesRequest, v1alpha1.GetAvailablePackageSummariesResponse]
+	getAvailablePackageDetail             *connect_go.Client[v1alpha1.GetAvailablePackageDetailRequest, v1alpha1.GetAvailablePackageDetailResponse]
+	getAvailablePackageVersions           *connect_go.Client[v1alpha1.GetAvailablePackageVersionsRequest, v1alpha1.GetAvailablePackageVersionsResponse]
+	getAvailablePackageTextAsset          *connect_go.Client[v1alpha1.GetAvailablePackageTextAssetRequest, v1alpha1.GetAvailablePackageTextAssetResponse]
+	getAvailablePackageChangelog          *connect_go.Client[v1alpha1.GetAvailablePackageChangelogRequest, v1alpha1.GetAvailablePackageChangelogResponse]
+	getAvailablePackageFilters            *connect_go.Client[v1alpha1.GetAvailablePackageFiltersRequest, v1alpha1.GetAvailablePackageFiltersResponse]
+	getInstalledPackageSummaries          *connect_go.Client[v1alpha1.GetInstalledPackageSummariesRequest, v1alpha1.GetInstalledPackageSummariesResponse]
+	getInstalledPackageDetail             *connect_go.Client[v1alpha1.GetInstalledPackageDetailRequest, v1alpha1.GetInstalledPackageDetailResponse]
+	checkInstalledPackageNameAvailability *connect_go.Client[v1alpha1.CheckInstalledPackageNameAvailabilityRequest, v1alpha1.CheckInstalledPackageNameAvailabilityResponse]
+	createInstalledPackage                *connect_go.Client[v1alpha1.CreateInstalledPackageRequest, v1alpha1.CreateInstalledPackageResponse]
+	updateInstalledPackage                *connect_go.Client[v1alpha1.UpdateInstalledPackageRequest, v1alpha1.UpdateInstalledPackageResponse]
+	deleteInstalledPackage                *connect_go.Client[v1alpha1.DeleteInstalledPackageRequest, v1alpha1.DeleteInstalledPackageResponse]
+	getInstalledPackageResourceRefs       *connect_go.Client[v1alpha1.GetInstalledPackageResourceRefsRequest, v1alpha1.GetInstalledPackageResourceRefsResponse]
+	getInstalledPackageOperationStatus    *connect_go.Client[v1alpha1.GetInstalledPackageOperationStatusRequest, v1alpha1.GetInstalledPackageOperationStatusResponse]
+	getInstalledPackageChangePreview      *connect_go.Client[v1alpha11.GetInstalledPackageChangePreviewRequest, v1alpha11.GetInstalledPackageChangePreviewResponse]
+	getInstalledPackageReconcileHistory   *connect_go.Client[v1alpha11.GetInstalledPackageReconcileHistoryRequest, v1alpha11.GetInstalledPackageReconcileHistoryResponse]
+	getInstalledPackageManifest           *connect_go.Client[v1alpha11.GetInstalledPackageManifestRequest, v1alpha11.GetInstalledPackageManifestResponse]
+	updateInstalledPackageManifest        *connect_go.Client[v1alpha11.UpdateInstalledPackageManifestRequest, v1alpha11.UpdateInstalledPackageManifestResponse]
 }
 
 // GetAvailablePackageSummaries calls
@@ -202,6 +319,24 @@ func (c *kappControllerPackagesServiceClient) GetAvailablePackageVersions(ctx co
 	return c.getAvailablePackageVersions.CallUnary(ctx, req)
 }
 
+// GetAvailablePackageTextAsset calls
+// kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService.GetAvailablePackageTextAsset.
+func (c *kappControllerPackagesServiceClient) GetAvailablePackageTextAsset(ctx context.Context, req *connect_go.Request[v1alpha1.GetAvailablePackageTextAssetRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageTextAssetResponse], error) {
+	return c.getAvailablePackageTextAsset.CallUnary(ctx, req)
+}
+
+// GetAvailablePackageChangelog calls
+// kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService.GetAvailablePackageChangelog.
+func (c *kappControllerPackagesServiceClient) GetAvailablePackageChangelog(ctx context.Context, req *connect_go.Request[v1alpha1.GetAvailablePackageChangelogRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageChangelogResponse], error) {
+	return c.getAvailablePackageChangelog.CallUnary(ctx, req)
+}
+
+// GetAvailablePackageFilters calls
+// kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService.GetAvailablePackageFilters.
+func (c *kappControllerPackagesServiceClient) GetAvailablePackageFilters(ctx context.Context, req *connect_go.Request[v1alpha1.GetAvailablePackageFiltersRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageFiltersResponse], error) {
+	return c.getAvailablePackageFilters.CallUnary(ctx, req)
+}
+
 // GetInstalledPackageSummaries calls
 // kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService.GetInstalledPackageSummaries.
 func (c *kappControllerPackagesServiceClient) GetInstalledPackageSummaries(ctx context.Context, req *connect_go.Request[v1alpha1.GetInstalledPackageSummariesRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageSummariesResponse], error) {
@@ -214,6 +349,12 @@ func (c *kappControllerPackagesServiceClient) GetInstalledPackageDetail(ctx cont
 	return c.getInstalledPackageDetail.CallUnary(ctx, req)
 }
 
+// CheckInstalledPackageNameAvailability calls
+// kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService.CheckInstalledPackageNameAvailability.
+func (c *kappControllerPackagesServiceClient) CheckInstalledPackageNameAvailability(ctx context.Context, req *connect_go.Request[v1alpha1.CheckInstalledPackageNameAvailabilityRequest]) (*connect_go.Response[v1alpha1.CheckInstalledPackageNameAvailabilityResponse], error) {
+	return c.checkInstalledPackageNameAvailability.CallUnary(ctx, req)
+}
+
 // CreateInstalledPackage calls
 // kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService.CreateInstalledPackage.
 func (c *kappControllerPackagesServiceClient) CreateInstalledPackage(ctx context.Context, req *connect_go.Request[v1alpha1.CreateInstalledPackageRequest]) (*connect_go.Response[v1alpha1.CreateInstalledPackageResponse], error) {
@@ -238,6 +379,36 @@ func (c *kappControllerPackagesServiceClient) GetInstalledPackageResourceRefs(ct
 	return c.getInstalledPackageResourceRefs.CallUnary(ctx, req)
 }
 
+// GetInstalledPackageOperationStatus calls
+// kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService.GetInstalledPackageOperationStatus.
+func (c *kappControllerPackagesServiceClient) GetInstalledPackageOperationStatus(ctx context.Context, req *connect_go.Request[v1alpha1.GetInstalledPackageOperationStatusRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageOperationStatusResponse], error) {
+	return c.getInstalledPackageOperationStatus.CallUnary(ctx, req)
+}
+
+// GetInstalledPackageChangePreview calls
+// kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService.GetInstalledPackageChangePreview.
+func (c *kappControllerPackagesServiceClient) GetInstalledPackageChangePreview(ctx context.Context, req *connect_go.Request[v1alpha11.GetInstalledPackageChangePreviewRequest]) (*connect_go.Response[v1alpha11.GetInstalledPackageChangePreviewResponse], error) {
+	return c.getInstalledPackageChangePreview.CallUnary(ctx, req)
+}
+
+// GetInstalledPackageReconcileHistory calls
+// kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService.GetInstalledPackageReconcileHistory.
+func (c *kappControllerPackagesServiceClient) GetInstalledPackageReconcileHistory(ctx context.Context, req *connect_go.Request[v1alpha11.GetInstalledPackageReconcileHistoryRequest]) (*connect_go.Response[v1alpha11.GetInstalledPackageReconcileHistoryResponse], error) {
+	return c.getInstalledPackageReconcileHistory.CallUnary(ctx, req)
+}
+
+// GetInstalledPackageManifest calls
+// kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService.GetInstalledPackageManifest.
+func (c *kappControllerPackagesServiceClient) GetInstalledPackageManifest(ctx context.Context, req *connect_go.Request[v1alpha11.GetInstalledPackageManifestRequest]) (*connect_go.Response[v1alpha11.GetInstalledPackageManifestResponse], error) {
+	return c.getInstalledPackageManifest.CallUnary(ctx, req)
+}
+
+// UpdateInstalledPackageManifest calls
+// kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService.UpdateInstalledPackageManifest.
+func (c *kappControllerPackagesServiceClient) UpdateInstalledPackageManifest(ctx context.Context, req *connect_go.Request[v1alpha11.UpdateInstalledPackageManifestRequest]) (*connect_go.Response[v1alpha11.UpdateInstalledPackageManifestResponse], error) {
+	return c.updateInstalledPackageManifest.CallUnary(ctx, req)
+}
+
 // KappControllerPackagesServiceHandler is an implementation of the
 // kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService service.
 type KappControllerPackagesServiceHandler interface {
@@ -247,10 +418,18 @@ type KappControllerPackagesServiceHandler interface {
 	GetAvailablePackageDetail(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageDetailRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageDetailResponse], error)
 	// GetAvailablePackageVersions returns the package versions managed by the 'kapp_controller' plugin
 	GetAvailablePackageVersions(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageVersionsRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageVersionsResponse], error)
+	// GetAvailablePackageTextAsset returns a range of a text asset of a package managed by the 'kapp_controller' plugin
+	GetAvailablePackageTextAsset(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageTextAssetRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageTextAssetResponse], error)
+	// GetAvailablePackageChangelog returns the release notes between two versions of a package managed by the 'kapp_controller' plugin
+	GetAvailablePackageChangelog(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageChangelogRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageChangelogResponse], error)
+	// GetAvailablePackageFilters returns the filter values of the available packages managed by the 'kapp_controller' plugin
+	GetAvailablePackageFilters(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageFiltersRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageFiltersResponse], error)
 	// GetInstalledPackageSummaries returns the installed packages managed by the 'kapp_controller' plugin
 	GetInstalledPackageSummaries(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageSummariesRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageSummariesResponse], error)
 	// GetInstalledPackageDetail returns the requested installed package managed by the 'kapp_controller' plugin
 	GetInstalledPackageDetail(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageDetailRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageDetailResponse], error)
+	// CheckInstalledPackageNameAvailability checks whether a package managed by the 'kapp_controller' plugin can be installed with the given name
+	CheckInstalledPackageNameAvailability(context.Context, *connect_go.Request[v1alpha1.CheckInstalledPackageNameAvailabilityRequest]) (*connect_go.Response[v1alpha1.CheckInstalledPackageNameAvailabilityResponse], error)
 	// CreateInstalledPackage creates an installed package based on the request.
 	CreateInstalledPackage(context.Context, *connect_go.Request[v1alpha1.CreateInstalledPackageRequest]) (*connect_go.Response[v1alpha1.CreateInstalledPackageResponse], error)
 	// UpdateInstalledPackage updates an installed package based on the request.
@@ -260,6 +439,24 @@ type KappControllerPackagesServiceHandler interface {
 	// GetInstalledPackageResourceRefs returns the references for the Kubernetes resources created by
 	// an installed package.
 	GetInstalledPackageResourceRefs(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageResourceRefsRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageResourceRefsResponse], error)
+	// GetInstalledPackageOperationStatus returns the progress of the operation in course on an installed package managed by the 'kapp_controller' plugin
+	GetInstalledPackageOperationStatus(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageOperationStatusRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageOperationStatusResponse], error)
+	// GetInstalledPackageChangePreview returns the changes of the Kubernetes resources that an update
+	// of an installed package managed by the 'kapp_controller' plugin would apply, without applying them.
+	GetInstalledPackageChangePreview(context.Context, *connect_go.Request[v1alpha11.GetInstalledPackageChangePreviewRequest]) (*connect_go.Response[v1alpha11.GetInstalledPackageChangePreviewResponse], error)
+	// GetInstalledPackageReconcileHistory returns the last reconcile outcomes of an installed package
+	// managed by the 'kapp_controller' plugin, most recent first, so that flapping installations can
+	// be diagnosed. The outcomes are recorded when observed by the plugin.
+	GetInstalledPackageReconcileHistory(context.Context, *connect_go.Request[v1alpha11.GetInstalledPackageReconcileHistoryRequest]) (*connect_go.Response[v1alpha11.GetInstalledPackageReconcileHistoryResponse], error)
+	// GetInstalledPackageManifest returns the raw YAML manifest of the PackageInstall, or of its App,
+	// of an installed package managed by the 'kapp_controller' plugin, for expert editing.
+	// Only available when the `advancedMode` plugin configuration is enabled.
+	GetInstalledPackageManifest(context.Context, *connect_go.Request[v1alpha11.GetInstalledPackageManifestRequest]) (*connect_go.Response[v1alpha11.GetInstalledPackageManifestResponse], error)
+	// UpdateInstalledPackageManifest replaces the PackageInstall of an installed package managed by
+	// the 'kapp_controller' plugin with the given raw YAML manifest, once validated by the API server.
+	// The fields managed by the plugin cannot be changed.
+	// Only available when the `advancedMode` plugin configuration is enabled.
+	UpdateInstalledPackageManifest(context.Context, *connect_go.Request[v1alpha11.UpdateInstalledPackageManifestRequest]) (*connect_go.Response[v1alpha11.UpdateInstalledPackageManifestResponse], error)
 }
 
 // NewKappControllerPackagesServiceHandler builds an HTTP handler from the service implementation.
@@ -283,6 +480,21 @@ func NewKappControllerPackagesServiceHandler(svc KappControllerPackagesServiceHa
 		svc.GetAvailablePackageVersions,
 		opts...,
 	)
+	kappControllerPackagesServiceGetAvailablePackageTextAssetHandler := connect_go.NewUnaryHandler(
+		KappControllerPackagesServiceGetAvailablePackageTextAssetProcedure,
+		svc.GetAvailablePackageTextAsset,
+		opts...,
+	)
+	kappControllerPackagesServiceGetAvailablePackageChangelogHandler := connect_go.NewUnaryHandler(
+		KappControllerPackagesServiceGetAvailablePackageChangelogProcedure,
+		svc.GetAvailablePackageChangelog,
+		opts...,
+	)
+	kappControllerPackagesServiceGetAvailablePackageFiltersHandler := connect_go.NewUnaryHandler(
+		KappControllerPackagesServiceGetAvailablePackageFiltersProcedure,
+		svc.GetAvailablePackageFilters,
+		opts...,
+	)
 	kappControllerPackagesServiceGetInstalledPackageSummariesHandler := connect_go.NewUnaryHandler(
 		KappControllerPackagesServiceGetInstalledPackageSummariesProcedure,
 		svc.GetInstalledPackageSummaries,
@@ -293,6 +505,11 @@ func NewKappControllerPackagesServiceHandler(svc KappControllerPackagesServiceHa
 		svc.GetInstalledPackageDetail,
 		opts...,
 	)
+	kappControllerPackagesServiceCheckInstalledPackageNameAvailabilityHandler := connect_go.NewUnaryHandler(
+		KappControllerPackagesServiceCheckInstalledPackageNameAvailabilityProcedure,
+		svc.CheckInstalledPackageNameAvailability,
+		opts...,
+	)
 	kappControllerPackagesServiceCreateInstalledPackageHandler := connect_go.NewUnaryHandler(
 		KappControllerPackagesServiceCreateInstalledPackageProcedure,
 		svc.CreateInstalledPackage,
@@ -313,6 +530,31 @@ func NewKappControllerPackagesServiceHandler(svc KappControllerPackagesServiceHa
 		svc.GetInstalledPackageResourceRefs,
 		opts...,
 	)
+	kappControllerPackagesServiceGetInstalledPackageOperationStatusHandler := connect_go.NewUnaryHandler(
+		KappControllerPackagesServiceGetInstalledPackageOperationStatusProcedure,
+		svc.GetInstalledPackageOperationStatus,
+		opts...,
+	)
+	kappControllerPackagesServiceGetInstalledPackageChangePreviewHandler := connect_go.NewUnaryHandler(
+		KappControllerPackagesServiceGetInstalledPackageChangePreviewProcedure,
+		svc.GetInstalledPackageChangePreview,
+		opts...,
+	)
+	kappControllerPackagesServiceGetInstalledPackageReconcileHistoryHandler := connect_go.NewUnaryHandler(
+		KappControllerPackagesServiceGetInstalledPackageReconcileHistoryProcedure,
+		svc.GetInstalledPackageReconcileHistory,
+		opts...,
+	)
+	kappControllerPackagesServiceGetInstalledPackageManifestHandler := connect_go.NewUnaryHandler(
+		KappControllerPackagesServiceGetInstalledPackageManifestProcedure,
+		svc.GetInstalledPackageManifest,
+		opts...,
+	)
+	kappControllerPackagesServiceUpdateInstalledPackageManifestHandler := connect_go.NewUnaryHandler(
+		KappControllerPackagesServiceUpdateInstalledPackageManifestProcedure,
+		svc.UpdateInstalledPackageManifest,
+		opts...,
+	)
 	return "/kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case KappControllerPackagesServiceGetAvailablePackageSummariesProcedure:
@@ -321,10 +563,18 @@ func NewKappControllerPackagesServiceHandler(svc KappControllerPackagesServiceHa
 			kappControllerPackagesServiceGetAvailablePackageDetailHandler.ServeHTTP(w, r)
 		case KappControllerPackagesServiceGetAvailablePackageVersionsProcedure:
 			kappControllerPackagesServiceGetAvailablePackageVersionsHandler.ServeHTTP(w, r)
+		case KappControllerPackagesServiceGetAvailablePackageTextAssetProcedure:
+			kappControllerPackagesServiceGetAvailablePackageTextAssetHandler.ServeHTTP(w, r)
+		case KappControllerPackagesServiceGetAvailablePackageChangelogProcedure:
+			kappControllerPackagesServiceGetAvailablePackageChangelogHandler.ServeHTTP(w, r)
+		case KappControllerPackagesServiceGetAvailablePackageFiltersProcedure:
+			kappControllerPackagesServiceGetAvailablePackageFiltersHandler.ServeHTTP(w, r)
 		case KappControllerPackagesServiceGetInstalledPackageSummariesProcedure:
 			kappControllerPackagesServiceGetInstalledPackageSummariesHandler.ServeHTTP(w, r)
 		case KappControllerPackagesServiceGetInstalledPackageDetailProcedure:
 			kappControllerPackagesServiceGetInstalledPackageDetailHandler.ServeHTTP(w, r)
+		case KappControllerPackagesServiceCheckInstalledPackageNameAvailabilityProcedure:
+			kappControllerPackagesServiceCheckInstalledPackageNameAvailabilityHandler.ServeHTTP(w, r)
 		case KappControllerPackagesServiceCreateInstalledPackageProcedure:
 			kappControllerPackagesServiceCreateInstalledPackageHandler.ServeHTTP(w, r)
 		case KappControllerPackagesServiceUpdateInstalledPackageProcedure:
@@ -333,6 +583,16 @@ func NewKappControllerPackagesServiceHandler(svc KappControllerPackagesServiceHa
 			kappControllerPackagesServiceDeleteInstalledPackageHandler.ServeHTTP(w, r)
 		case KappControllerPackagesServiceGetInstalledPackageResourceRefsProcedure:
 			kappControllerPackagesServiceGetInstalledPackageResourceRefsHandler.ServeHTTP(w, r)
+		case KappControllerPackagesServiceGetInstalledPackageOperationStatusProcedure:
+			kappControllerPackagesServiceGetInstalledPackageOperationStatusHandler.ServeHTTP(w, r)
+		case KappControllerPackagesServiceGetInstalledPackageChangePreviewProcedure:
+			kappControllerPackagesServiceGetInstalledPackageChangePreviewHandler.ServeHTTP(w, r)
+		case KappControllerPackagesServiceGetInstalledPackageReconcileHistoryProcedure:
+			kappControllerPackagesServiceGetInstalledPackageReconcileHistoryHandler.ServeHTTP(w, r)
+		case KappControllerPackagesServiceGetInstalledPackageManifestProcedure:
+			kappControllerPackagesServiceGetInstalledPackageManifestHandler.ServeHTTP(w, r)
+		case KappControllerPackagesServiceUpdateInstalledPackageManifestProcedure:
+			kappControllerPackagesServiceUpdateInstalledPackageManifestHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -354,6 +614,18 @@ func (UnimplementedKappControllerPackagesServiceHandler) GetAvailablePackageVers
 	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService.GetAvailablePackageVersions is not implemented"))
 }
 
+func (UnimplementedKappControllerPackagesServiceHandler) GetAvailablePackageTextAsset(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageTextAssetRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageTextAssetResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService.GetAvailablePackageTextAsset is not implemented"))
+}
+
+func (UnimplementedKappControllerPackagesServiceHandler) GetAvailablePackageChangelog(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageChangelogRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageChangelogResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService.GetAvailablePackageChangelog is not implemented"))
+}
+
+func (UnimplementedKappControllerPackagesServiceHandler) GetAvailablePackageFilters(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageFiltersRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageFiltersResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService.GetAvailablePackageFilters is not implemented"))
+}
+
 func (UnimplementedKappControllerPackagesServiceHandler) GetInstalledPackageSummaries(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageSummariesRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageSummariesResponse], error) {
 	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService.GetInstalledPackageSummaries is not implemented"))
 }
@@ -362,6 +634,10 @@ func (UnimplementedKappControllerPackagesServiceHandler) GetInstalledPackageDeta
 	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService.GetInstalledPackageDetail is not implemented"))
 }
 
+func (UnimplementedKappControllerPackagesServiceHandler) CheckInstalledPackageNameAvailability(context.Context, *connect_go.Request[v1alpha1.CheckInstalledPackageNameAvailabilityRequest]) (*connect_go.Response[v1alpha1.CheckInstalledPackageNameAvailabilityResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService.CheckInstalledPackageNameAvailability is not implemented"))
+}
+
 func (UnimplementedKappControllerPackagesServiceHandler) CreateInstalledPackage(context.Context, *connect_go.Request[v1alpha1.CreateInstalledPackageRequest]) (*connect_go.Response[v1alpha1.CreateInstalledPackageResponse], error) {
 	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService.CreateInstalledPackage is not implemented"))
 }
@@ -378,6 +654,26 @@ func (UnimplementedKappControllerPackagesServiceHandler) GetInstalledPackageReso
 	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService.GetInstalledPackageResourceRefs is not implemented"))
 }
 
+func (UnimplementedKappControllerPackagesServiceHandler) GetInstalledPackageOperationStatus(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageOperationStatusRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageOperationStatusResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService.GetInstalledPackageOperationStatus is not implemented"))
+}
+
+func (UnimplementedKappControllerPackagesServiceHandler) GetInstalledPackageChangePreview(context.Context, *connect_go.Request[v1alpha11.GetInstalledPackageChangePreviewRequest]) (*connect_go.Response[v1alpha11.GetInstalledPackageChangePreviewResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService.GetInstalledPackageChangePreview is not implemented"))
+}
+
+func (UnimplementedKappControllerPackagesServiceHandler) GetInstalledPackageReconcileHistory(context.Context, *connect_go.Request[v1alpha11.GetInstalledPackageReconcileHistoryRequest]) (*connect_go.Response[v1alpha11.GetInstalledPackageReconcileHistoryResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService.GetInstalledPackageReconcileHistory is not implemented"))
+}
+
+func (UnimplementedKappControllerPackagesServiceHandler) GetInstalledPackageManifest(context.Context, *connect_go.Request[v1alpha11.GetInstalledPackageManifestRequest]) (*connect_go.Response[v1alpha11.GetInstalledPackageManifestResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService.GetInstalledPackageManifest is not implemented"))
+}
+
+func (UnimplementedKappControllerPackagesServiceHandler) UpdateInstalledPackageManifest(context.Context, *connect_go.Request[v1alpha11.UpdateInstalledPackageManifestRequest]) (*connect_go.Response[v1alpha11.UpdateInstalledPackageManifestResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService.UpdateInstalledPackageManifest is not implemented"))
+}
+
 // KappControllerRepositoriesServiceClient is a client for the
 // kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerRepositoriesService service.
 type KappControllerRepositoriesServiceClient interface {
@@ -388,6 +684,13 @@ type KappControllerRepositoriesServiceClient interface {
 	UpdatePackageRepository(context.Context, *connect_go.Request[v1alpha1.UpdatePackageRepositoryRequest]) (*connect_go.Response[v1alpha1.UpdatePackageRepositoryResponse], error)
 	DeletePackageRepository(context.Context, *connect_go.Request[v1alpha1.DeletePackageRepositoryRequest]) (*connect_go.Response[v1alpha1.DeletePackageRepositoryResponse], error)
 	GetPackageRepositoryPermissions(context.Context, *connect_go.Request[v1alpha1.GetPackageRepositoryPermissionsRequest]) (*connect_go.Response[v1alpha1.GetPackageRepositoryPermissionsResponse], error)
+	TestPackageRepositoryConnection(context.Context, *connect_go.Request[v1alpha1.TestPackageRepositoryConnectionRequest]) (*connect_go.Response[v1alpha1.TestPackageRepositoryConnectionResponse], error)
+	ListPackageRepositoryPackages(context.Context, *connect_go.Request[v1alpha1.ListPackageRepositoryPackagesRequest]) (*connect_go.Response[v1alpha1.ListPackageRepositoryPackagesResponse], error)
+	// ScanPackageRepositoryHostKeys scans the SSH host keys of the git server of a package repository
+	// managed by the 'kapp_controller' plugin, returning their fingerprints to be confirmed by the user.
+	// The confirmed fingerprints are then sent as the trusted_host_key_fingerprints of the custom detail
+	// when adding or updating the repository, so that the plugin stores the matching keys as its known hosts.
+	ScanPackageRepositoryHostKeys(context.Context, *connect_go.Request[v1alpha11.ScanPackageRepositoryHostKeysRequest]) (*connect_go.Response[v1alpha11.ScanPackageRepositoryHostKeysResponse], error)
 }
 
 // NewKappControllerRepositoriesServiceClient constructs a client for the
@@ -431,6 +734,21 @@ func NewKappControllerRepositoriesServiceClient(httpClient connect_go.HTTPClient
 			baseURL+KappControllerRepositoriesServiceGetPackageRepositoryPermissionsProcedure,
 			opts...,
 		),
+		testPackageRepositoryConnection: connect_go.NewClient[v1alpha1.TestPackageRepositoryConnectionRequest, v1alpha1.TestPackageRepositoryConnectionResponse](
+			httpClient,
+			baseURL+KappControllerRepositoriesServiceTestPackageRepositoryConnectionProcedure,
+			opts...,
+		),
+		listPackageRepositoryPackages: connect_go.NewClient[v1alpha1.ListPackageRepositoryPackagesRequest, v1alpha1.ListPackageRepositoryPackagesResponse](
+			httpClient,
+			baseURL+KappControllerRepositoriesServiceListPackageRepositoryPackagesProcedure,
+			opts...,
+		),
+		scanPackageRepositoryHostKeys: connect_go.NewClient[v1alpha11.ScanPackageRepositoryHostKeysRequest, v1alpha11.ScanPackageRepositoryHostKeysResponse](
+			httpClient,
+			baseURL+KappControllerRepositoriesServiceScanPackageRepositoryHostKeysProcedure,
+			opts...,
+		),
 	}
 }
 
@@ -442,6 +760,9 @@ type kappControllerRepositoriesServiceClient struct {
 	updatePackageRepository         *connect_go.Client[v1alpha1.UpdatePackageRepositoryRequest, v1alpha1.UpdatePackageRepositoryResponse]
 	deletePackageRepository         *connect_go.Client[v1alpha1.DeletePackageRepositoryRequest, v1alpha1.DeletePackageRepositoryResponse]
 	getPackageRepositoryPermissions *connect_go.Client[v1alpha1.GetPackageRepositoryPermissionsRequest, v1alpha1.GetPackageRepositoryPermissionsResponse]
+	testPackageRepositoryConnection *connect_go.Client[v1alpha1.TestPackageRepositoryConnectionRequest, v1alpha1.TestPackageRepositoryConnectionResponse]
+	listPackageRepositoryPackages   *connect_go.Client[v1alpha1.ListPackageRepositoryPackagesRequest, v1alpha1.ListPackageRepositoryPackagesResponse]
+	scanPackageRepositoryHostKeys   *connect_go.Client[v1alpha11.ScanPackageRepositoryHostKeysRequest, v1alpha11.ScanPackageRepositoryHostKeysResponse]
 }
 
 // AddPackageRepository calls
@@ -480,6 +801,24 @@ func (c *kappControllerRepositoriesServiceClient) GetPackageRepositoryPermission
 	return c.getPackageRepositoryPermissions.CallUnary(ctx, req)
 }
 
+// TestPackageRepositoryConnection calls
+// kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerRepositoriesService.TestPackageRepositoryConnection.
+func (c *kappControllerRepositoriesServiceClient) TestPackageRepositoryConnection(ctx context.Context, req *connect_go.Request[v1alpha1.TestPackageRepositoryConnectionRequest]) (*connect_go.Response[v1alpha1.TestPackageRepositoryConnectionResponse], error) {
+	return c.testPackageRepositoryConnection.CallUnary(ctx, req)
+}
+
+// ListPackageRepositoryPackages calls
+// kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerRepositoriesService.ListPackageRepositoryPackages.
+func (c *kappControllerRepositoriesServiceClient) ListPackageRepositoryPackages(ctx context.Context, req *connect_go.Request[v1alpha1.ListPackageRepositoryPackagesRequest]) (*connect_go.Response[v1alpha1.ListPackageRepositoryPackagesResponse], error) {
+	return c.listPackageRepositoryPackages.CallUnary(ctx, req)
+}
+
+// ScanPackageRepositoryHostKeys calls
+// kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerRepositoriesService.ScanPackageRepositoryHostKeys.
+func (c *kappControllerRepositoriesServiceClient) ScanPackageRepositoryHostKeys(ctx context.Context, req *connect_go.Request[v1alpha11.ScanPackageRepositoryHostKeysRequest]) (*connect_go.Response[v1alpha11.ScanPackageRepositoryHostKeysResponse], error) {
+	return c.scanPackageRepositoryHostKeys.CallUnary(ctx, req)
+}
+
 // KappControllerRepositoriesServiceHandler is an implementation of the
 // kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerRepositoriesService service.
 type KappControllerRepositoriesServiceHandler interface {
@@ -490,6 +829,13 @@ type KappControllerRepositoriesServiceHandler interface {
 	UpdatePackageRepository(context.Context, *connect_go.Request[v1alpha1.UpdatePackageRepositoryRequest]) (*connect_go.Response[v1alpha1.UpdatePackageRepositoryResponse], error)
 	DeletePackageRepository(context.Context, *connect_go.Request[v1alpha1.DeletePackageRepositoryRequest]) (*connect_go.Response[v1alpha1.DeletePackageRepositoryResponse], error)
 	GetPackageRepositoryPermissions(context.Context, *connect_go.Request[v1alpha1.GetPackageRepositoryPermissionsRequest]) (*connect_go.Response[v1alpha1.GetPackageRepositoryPermissionsResponse], error)
+	TestPackageRepositoryConnection(context.Context, *connect_go.Request[v1alpha1.TestPackageRepositoryConnectionRequest]) (*connect_go.Response[v1alpha1.TestPackageRepositoryConnectionResponse], error)
+	ListPackageRepositoryPackages(context.Context, *connect_go.Request[v1alpha1.ListPackageRepositoryPackagesRequest]) (*connect_go.Response[v1alpha1.ListPackageRepositoryPackagesResponse], error)
+	// ScanPackageRepositoryHostKeys scans the SSH host keys of the git server of a package repository
+	// managed by the 'kapp_controller' plugin, returning their fingerprints to be confirmed by the user.
+	// The confirmed fingerprints are then sent as the trusted_host_key_fingerprints of the custom detail
+	// when adding or updating the repository, so that the plugin stores the matching keys as its known hosts.
+	ScanPackageRepositoryHostKeys(context.Context, *connect_go.Request[v1alpha11.ScanPackageRepositoryHostKeysRequest]) (*connect_go.Response[v1alpha11.ScanPackageRepositoryHostKeysResponse], error)
 }
 
 // NewKappControllerRepositoriesServiceHandler builds an HTTP handler from the service
@@ -528,6 +874,21 @@ func NewKappControllerRepositoriesServiceHandler(svc KappControllerRepositoriesS
 		svc.GetPackageRepositoryPermissions,
 		opts...,
 	)
+	kappControllerRepositoriesServiceTestPackageRepositoryConnectionHandler := connect_go.NewUnaryHandler(
+		KappControllerRepositoriesServiceTestPackageRepositoryConnectionProcedure,
+		svc.TestPackageRepositoryConnection,
+		opts...,
+	)
+	kappControllerRepositoriesServiceListPackageRepositoryPackagesHandler := connect_go.NewUnaryHandler(
+		KappControllerRepositoriesServiceListPackageRepositoryPackagesProcedure,
+		svc.ListPackageRepositoryPackages,
+		opts...,
+	)
+	kappControllerRepositoriesServiceScanPackageRepositoryHostKeysHandler := connect_go.NewUnaryHandler(
+		KappControllerRepositoriesServiceScanPackageRepositoryHostKeysProcedure,
+		svc.ScanPackageRepositoryHostKeys,
+		opts...,
+	)
 	return "/kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerRepositoriesService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case KappControllerRepositoriesServiceAddPackageRepositoryProcedure:
@@ -542,6 +903,12 @@ func NewKappControllerRepositoriesServiceHandler(svc KappControllerRepositoriesS
 			kappControllerRepositoriesServiceDeletePackageRepositoryHandler.ServeHTTP(w, r)
 		case KappControllerRepositoriesServiceGetPackageRepositoryPermissionsProcedure:
 			kappControllerRepositoriesServiceGetPackageRepositoryPermissionsHandler.ServeHTTP(w, r)
+		case KappControllerRepositoriesServiceTestPackageRepositoryConnectionProcedure:
+			kappControllerRepositoriesServiceTestPackageRepositoryConnectionHandler.ServeHTTP(w, r)
+		case KappControllerRepositoriesServiceListPackageRepositoryPackagesProcedure:
+			kappControllerRepositoriesServiceListPackageRepositoryPackagesHandler.ServeHTTP(w, r)
+		case KappControllerRepositoriesServiceScanPackageRepositoryHostKeysProcedure:
+			kappControllerRepositoriesServiceScanPackageRepositoryHostKeysHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -574,3 +941,15 @@ func (UnimplementedKappControllerRepositoriesServiceHandler) DeletePackageReposi
 func (UnimplementedKappControllerRepositoriesServiceHandler) GetPackageRepositoryPermissions(context.Context, *connect_go.Request[v1alpha1.GetPackageRepositoryPermissionsRequest]) (*connect_go.Response[v1alpha1.GetPackageRepositoryPermissionsResponse], error) {
 	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerRepositoriesService.GetPackageRepositoryPermissions is not implemented"))
 }
+
+func (UnimplementedKappControllerRepositoriesServiceHandler) TestPackageRepositoryConnection(context.Context, *connect_go.Request[v1alpha1.TestPackageRepositoryConnectionRequest]) (*connect_go.Response[v1alpha1.TestPackageRepositoryConnectionResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerRepositoriesService.TestPackageRepositoryConnection is not implemented"))
+}
+
+func (UnimplementedKappControllerRepositoriesServiceHandler) ListPackageRepositoryPackages(context.Context, *connect_go.Request[v1alpha1.ListPackageRepositoryPackagesRequest]) (*connect_go.Response[v1alpha1.ListPackageRepositoryPackagesResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerRepositoriesService.ListPackageRepositoryPackages is not implemented"))
+}
+
+func (UnimplementedKappControllerRepositoriesServiceHandler) ScanPackageRepositoryHostKeys(context.Context, *connect_go.Request[v1alpha11.ScanPackageRepositoryHostKeysRequest]) (*connect_go.Response[v1alpha11.ScanPackageRepositoryHostKeysResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerRepositoriesService.ScanPackageRepositoryHostKeys is not implemented"))
+}
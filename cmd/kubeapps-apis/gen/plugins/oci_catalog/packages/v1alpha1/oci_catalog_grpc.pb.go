@@ -0,0 +1,622 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: kubeappsapis/plugins/oci_catalog/packages/v1alpha1/oci_catalog.proto
+
+package v1alpha1
+
+import (
+	context "context"
+	v1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	OciCatalogPackagesService_GetAvailablePackageSummaries_FullMethodName          = "/kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService/GetAvailablePackageSummaries"
+	OciCatalogPackagesService_GetAvailablePackageDetail_FullMethodName             = "/kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService/GetAvailablePackageDetail"
+	OciCatalogPackagesService_GetAvailablePackageVersions_FullMethodName           = "/kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService/GetAvailablePackageVersions"
+	OciCatalogPackagesService_GetAvailablePackageTextAsset_FullMethodName          = "/kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService/GetAvailablePackageTextAsset"
+	OciCatalogPackagesService_GetAvailablePackageChangelog_FullMethodName          = "/kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService/GetAvailablePackageChangelog"
+	OciCatalogPackagesService_GetAvailablePackageFilters_FullMethodName            = "/kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService/GetAvailablePackageFilters"
+	OciCatalogPackagesService_GetInstalledPackageSummaries_FullMethodName          = "/kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService/GetInstalledPackageSummaries"
+	OciCatalogPackagesService_GetInstalledPackageDetail_FullMethodName             = "/kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService/GetInstalledPackageDetail"
+	OciCatalogPackagesService_CheckInstalledPackageNameAvailability_FullMethodName = "/kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService/CheckInstalledPackageNameAvailability"
+	OciCatalogPackagesService_CreateInstalledPackage_FullMethodName                = "/kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService/CreateInstalledPackage"
+	OciCatalogPackagesService_UpdateInstalledPackage_FullMethodName                = "/kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService/UpdateInstalledPackage"
+	OciCatalogPackagesService_DeleteInstalledPackage_FullMethodName                = "/kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService/DeleteInstalledPackage"
+	OciCatalogPackagesService_GetInstalledPackageResourceRefs_FullMethodName       = "/kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService/GetInstalledPackageResourceRefs"
+	OciCatalogPackagesService_GetInstalledPackageOperationStatus_FullMethodName    = "/kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService/GetInstalledPackageOperationStatus"
+)
+
+// OciCatalogPackagesServiceClient is the client API for OciCatalogPackagesService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type OciCatalogPackagesServiceClient interface {
+	// GetAvailablePackageSummaries returns the available packages managed by the 'oci_catalog' plugin
+	GetAvailablePackageSummaries(ctx context.Context, in *v1alpha1.GetAvailablePackageSummariesRequest, opts ...grpc.CallOption) (*v1alpha1.GetAvailablePackageSummariesResponse, error)
+	// GetAvailablePackageDetail returns the package details managed by the 'oci_catalog' plugin
+	GetAvailablePackageDetail(ctx context.Context, in *v1alpha1.GetAvailablePackageDetailRequest, opts ...grpc.CallOption) (*v1alpha1.GetAvailablePackageDetailResponse, error)
+	// GetAvailablePackageVersions returns the package versions managed by the 'oci_catalog' plugin
+	GetAvailablePackageVersions(ctx context.Context, in *v1alpha1.GetAvailablePackageVersionsRequest, opts ...grpc.CallOption) (*v1alpha1.GetAvailablePackageVersionsResponse, error)
+	// GetAvailablePackageTextAsset returns a range of a text asset of a package managed by the 'oci_catalog' plugin
+	GetAvailablePackageTextAsset(ctx context.Context, in *v1alpha1.GetAvailablePackageTextAssetRequest, opts ...grpc.CallOption) (*v1alpha1.GetAvailablePackageTextAssetResponse, error)
+	// GetAvailablePackageChangelog returns the release notes between two versions of a package managed by the 'oci_catalog' plugin
+	GetAvailablePackageChangelog(ctx context.Context, in *v1alpha1.GetAvailablePackageChangelogRequest, opts ...grpc.CallOption) (*v1alpha1.GetAvailablePackageChangelogResponse, error)
+	// GetAvailablePackageFilters returns the filter values of the available packages managed by the 'oci_catalog' plugin
+	GetAvailablePackageFilters(ctx context.Context, in *v1alpha1.GetAvailablePackageFiltersRequest, opts ...grpc.CallOption) (*v1alpha1.GetAvailablePackageFiltersResponse, error)
+	// GetInstalledPackageSummaries returns the installed packages managed by the 'oci_catalog' plugin
+	GetInstalledPackageSummaries(ctx context.Context, in *v1alpha1.GetInstalledPackageSummariesRequest, opts ...grpc.CallOption) (*v1alpha1.GetInstalledPackageSummariesResponse, error)
+	// GetInstalledPackageDetail returns the requested installed package managed by the 'oci_catalog' plugin
+	GetInstalledPackageDetail(ctx context.Context, in *v1alpha1.GetInstalledPackageDetailRequest, opts ...grpc.CallOption) (*v1alpha1.GetInstalledPackageDetailResponse, error)
+	// CheckInstalledPackageNameAvailability checks whether a package managed by the 'oci_catalog' plugin can be installed with the given name
+	CheckInstalledPackageNameAvailability(ctx context.Context, in *v1alpha1.CheckInstalledPackageNameAvailabilityRequest, opts ...grpc.CallOption) (*v1alpha1.CheckInstalledPackageNameAvailabilityResponse, error)
+	// CreateInstalledPackage creates an installed package based on the request.
+	CreateInstalledPackage(ctx context.Context, in *v1alpha1.CreateInstalledPackageRequest, opts ...grpc.CallOption) (*v1alpha1.CreateInstalledPackageResponse, error)
+	// UpdateInstalledPackage updates an installed package based on the request.
+	UpdateInstalledPackage(ctx context.Context, in *v1alpha1.UpdateInstalledPackageRequest, opts ...grpc.CallOption) (*v1alpha1.UpdateInstalledPackageResponse, error)
+	// DeleteInstalledPackage deletes an installed package based on the request.
+	DeleteInstalledPackage(ctx context.Context, in *v1alpha1.DeleteInstalledPackageRequest, opts ...grpc.CallOption) (*v1alpha1.DeleteInstalledPackageResponse, error)
+	// GetInstalledPackageResourceRefs returns the references for the Kubernetes resources created by
+	// an installed package.
+	GetInstalledPackageResourceRefs(ctx context.Context, in *v1alpha1.GetInstalledPackageResourceRefsRequest, opts ...grpc.CallOption) (*v1alpha1.GetInstalledPackageResourceRefsResponse, error)
+	// GetInstalledPackageOperationStatus returns the progress of the operation in course on an installed package managed by the 'oci_catalog' plugin
+	GetInstalledPackageOperationStatus(ctx context.Context, in *v1alpha1.GetInstalledPackageOperationStatusRequest, opts ...grpc.CallOption) (*v1alpha1.GetInstalledPackageOperationStatusResponse, error)
+}
+
+type ociCatalogPackagesServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewOciCatalogPackagesServiceClient(cc grpc.ClientConnInterface) OciCatalogPackagesServiceClient {
+	return &ociCatalogPackagesServiceClient{cc}
+}
+
+func (c *ociCatalogPackagesServiceClient) GetAvailablePackageSummaries(ctx context.Context, in *v1alpha1.GetAvailablePackageSummariesRequest, opts ...grpc.CallOption) (*v1alpha1.GetAvailablePackageSummariesResponse, error) {
+	out := new(v1alpha1.GetAvailablePackageSummariesResponse)
+	err := c.cc.Invoke(ctx, OciCatalogPackagesService_GetAvailablePackageSummaries_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ociCatalogPackagesServiceClient) GetAvailablePackageDetail(ctx context.Context, in *v1alpha1.GetAvailablePackageDetailRequest, opts ...grpc.CallOption) (*v1alpha1.GetAvailablePackageDetailResponse, error) {
+	out := new(v1alpha1.GetAvailablePackageDetailResponse)
+	err := c.cc.Invoke(ctx, OciCatalogPackagesService_GetAvailablePackageDetail_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ociCatalogPackagesServiceClient) GetAvailablePackageVersions(ctx context.Context, in *v1alpha1.GetAvailablePackageVersionsRequest, opts ...grpc.CallOption) (*v1alpha1.GetAvailablePackageVersionsResponse, error) {
+	out := new(v1alpha1.GetAvailablePackageVersionsResponse)
+	err := c.cc.Invoke(ctx, OciCatalogPackagesService_GetAvailablePackageVersions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ociCatalogPackagesServiceClient) GetAvailablePackageTextAsset(ctx context.Context, in *v1alpha1.GetAvailablePackageTextAssetRequest, opts ...grpc.CallOption) (*v1alpha1.GetAvailablePackageTextAssetResponse, error) {
+	out := new(v1alpha1.GetAvailablePackageTextAssetResponse)
+	err := c.cc.Invoke(ctx, OciCatalogPackagesService_GetAvailablePackageTextAsset_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ociCatalogPackagesServiceClient) GetAvailablePackageChangelog(ctx context.Context, in *v1alpha1.GetAvailablePackageChangelogRequest, opts ...grpc.CallOption) (*v1alpha1.GetAvailablePackageChangelogResponse, error) {
+	out := new(v1alpha1.GetAvailablePackageChangelogResponse)
+	err := c.cc.Invoke(ctx, OciCatalogPackagesService_GetAvailablePackageChangelog_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ociCatalogPackagesServiceClient) GetAvailablePackageFilters(ctx context.Context, in *v1alpha1.GetAvailablePackageFiltersRequest, opts ...grpc.CallOption) (*v1alpha1.GetAvailablePackageFiltersResponse, error) {
+	out := new(v1alpha1.GetAvailablePackageFiltersResponse)
+	err := c.cc.Invoke(ctx, OciCatalogPackagesService_GetAvailablePackageFilters_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ociCatalogPackagesServiceClient) GetInstalledPackageSummaries(ctx context.Context, in *v1alpha1.GetInstalledPackageSummariesRequest, opts ...grpc.CallOption) (*v1alpha1.GetInstalledPackageSummariesResponse, error) {
+	out := new(v1alpha1.GetInstalledPackageSummariesResponse)
+	err := c.cc.Invoke(ctx, OciCatalogPackagesService_GetInstalledPackageSummaries_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ociCatalogPackagesServiceClient) GetInstalledPackageDetail(ctx context.Context, in *v1alpha1.GetInstalledPackageDetailRequest, opts ...grpc.CallOption) (*v1alpha1.GetInstalledPackageDetailResponse, error) {
+	out := new(v1alpha1.GetInstalledPackageDetailResponse)
+	err := c.cc.Invoke(ctx, OciCatalogPackagesService_GetInstalledPackageDetail_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ociCatalogPackagesServiceClient) CheckInstalledPackageNameAvailability(ctx context.Context, in *v1alpha1.CheckInstalledPackageNameAvailabilityRequest, opts ...grpc.CallOption) (*v1alpha1.CheckInstalledPackageNameAvailabilityResponse, error) {
+	out := new(v1alpha1.CheckInstalledPackageNameAvailabilityResponse)
+	err := c.cc.Invoke(ctx, OciCatalogPackagesService_CheckInstalledPackageNameAvailability_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ociCatalogPackagesServiceClient) CreateInstalledPackage(ctx context.Context, in *v1alpha1.CreateInstalledPackageRequest, opts ...grpc.CallOption) (*v1alpha1.CreateInstalledPackageResponse, error) {
+	out := new(v1alpha1.CreateInstalledPackageResponse)
+	err := c.cc.Invoke(ctx, OciCatalogPackagesService_CreateInstalledPackage_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ociCatalogPackagesServiceClient) UpdateInstalledPackage(ctx context.Context, in *v1alpha1.UpdateInstalledPackageRequest, opts ...grpc.CallOption) (*v1alpha1.UpdateInstalledPackageResponse, error) {
+	out := new(v1alpha1.UpdateInstalledPackageResponse)
+	err := c.cc.Invoke(ctx, OciCatalogPackagesService_UpdateInstalledPackage_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ociCatalogPackagesServiceClient) DeleteInstalledPackage(ctx context.Context, in *v1alpha1.DeleteInstalledPackageRequest, opts ...grpc.CallOption) (*v1alpha1.DeleteInstalledPackageResponse, error) {
+	out := new(v1alpha1.DeleteInstalledPackageResponse)
+	err := c.cc.Invoke(ctx, OciCatalogPackagesService_DeleteInstalledPackage_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ociCatalogPackagesServiceClient) GetInstalledPackageResourceRefs(ctx context.Context, in *v1alpha1.GetInstalledPackageResourceRefsRequest, opts ...grpc.CallOption) (*v1alpha1.GetInstalledPackageResourceRefsResponse, error) {
+	out := new(v1alpha1.GetInstalledPackageResourceRefsResponse)
+	err := c.cc.Invoke(ctx, OciCatalogPackagesService_GetInstalledPackageResourceRefs_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ociCatalogPackagesServiceClient) GetInstalledPackageOperationStatus(ctx context.Context, in *v1alpha1.GetInstalledPackageOperationStatusRequest, opts ...grpc.CallOption) (*v1alpha1.GetInstalledPackageOperationStatusResponse, error) {
+	out := new(v1alpha1.GetInstalledPackageOperationStatusResponse)
+	err := c.cc.Invoke(ctx, OciCatalogPackagesService_GetInstalledPackageOperationStatus_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OciCatalogPackagesServiceServer is the server API for OciCatalogPackagesService service.
+// All implementations should embed UnimplementedOciCatalogPackagesServiceServer
+// for forward compatibility
+type OciCatalogPackagesServiceServer interface {
+	// GetAvailablePackageSummaries returns the available packages managed by the 'oci_catalog' plugin
+	GetAvailablePackageSummaries(context.Context, *v1alpha1.GetAvailablePackageSummariesRequest) (*v1alpha1.GetAvailablePackageSummariesResponse, error)
+	// GetAvailablePackageDetail returns the package details managed by the 'oci_catalog' plugin
+	GetAvailablePackageDetail(context.Context, *v1alpha1.GetAvailablePackageDetailRequest) (*v1alpha1.GetAvailablePackageDetailResponse, error)
+	// GetAvailablePackageVersions returns the package versions managed by the 'oci_catalog' plugin
+	GetAvailablePackageVersions(context.Context, *v1alpha1.GetAvailablePackageVersionsRequest) (*v1alpha1.GetAvailablePackageVersionsResponse, error)
+	// GetAvailablePackageTextAsset returns a range of a text asset of a package managed by the 'oci_catalog' plugin
+	GetAvailablePackageTextAsset(context.Context, *v1alpha1.GetAvailablePackageTextAssetRequest) (*v1alpha1.GetAvailablePackageTextAssetResponse, error)
+	// GetAvailablePackageChangelog returns the release notes between two versions of a package managed by the 'oci_catalog' plugin
+	GetAvailablePackageChangelog(context.Context, *v1alpha1.GetAvailablePackageChangelogRequest) (*v1alpha1.GetAvailablePackageChangelogResponse, error)
+	// GetAvailablePackageFilters returns the filter values of the available packages managed by the 'oci_catalog' plugin
+	GetAvailablePackageFilters(context.Context, *v1alpha1.GetAvailablePackageFiltersRequest) (*v1alpha1.GetAvailablePackageFiltersResponse, error)
+	// GetInstalledPackageSummaries returns the installed packages managed by the 'oci_catalog' plugin
+	GetInstalledPackageSummaries(context.Context, *v1alpha1.GetInstalledPackageSummariesRequest) (*v1alpha1.GetInstalledPackageSummariesResponse, error)
+	// GetInstalledPackageDetail returns the requested installed package managed by the 'oci_catalog' plugin
+	GetInstalledPackageDetail(context.Context, *v1alpha1.GetInstalledPackageDetailRequest) (*v1alpha1.GetInstalledPackageDetailResponse, error)
+	// CheckInstalledPackageNameAvailability checks whether a package managed by the 'oci_catalog' plugin can be installed with the given name
+	CheckInstalledPackageNameAvailability(context.Context, *v1alpha1.CheckInstalledPackageNameAvailabilityRequest) (*v1alpha1.CheckInstalledPackageNameAvailabilityResponse, error)
+	// CreateInstalledPackage creates an installed package based on the request.
+	CreateInstalledPackage(context.Context, *v1alpha1.CreateInstalledPackageRequest) (*v1alpha1.CreateInstalledPackageResponse, error)
+	// UpdateInstalledPackage updates an installed package based on the request.
+	UpdateInstalledPackage(context.Context, *v1alpha1.UpdateInstalledPackageRequest) (*v1alpha1.UpdateInstalledPackageResponse, error)
+	// DeleteInstalledPackage deletes an installed package based on the request.
+	DeleteInstalledPackage(context.Context, *v1alpha1.DeleteInstalledPackageRequest) (*v1alpha1.DeleteInstalledPackageResponse, error)
+	// GetInstalledPackageResourceRefs returns the references for the Kubernetes resources created by
+	// an installed package.
+	GetInstalledPackageResourceRefs(context.Context, *v1alpha1.GetInstalledPackageResourceRefsRequest) (*v1alpha1.GetInstalledPackageResourceRefsResponse, error)
+	// GetInstalledPackageOperationStatus returns the progress of the operation in course on an installed package managed by the 'oci_catalog' plugin
+	GetInstalledPackageOperationStatus(context.Context, *v1alpha1.GetInstalledPackageOperationStatusRequest) (*v1alpha1.GetInstalledPackageOperationStatusResponse, error)
+}
+
+// UnimplementedOciCatalogPackagesServiceServer should be embedded to have forward compatible implementations.
+type UnimplementedOciCatalogPackagesServiceServer struct {
+}
+
+func (UnimplementedOciCatalogPackagesServiceServer) GetAvailablePackageSummaries(context.Context, *v1alpha1.GetAvailablePackageSummariesRequest) (*v1alpha1.GetAvailablePackageSummariesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAvailablePackageSummaries not implemented")
+}
+func (UnimplementedOciCatalogPackagesServiceServer) GetAvailablePackageDetail(context.Context, *v1alpha1.GetAvailablePackageDetailRequest) (*v1alpha1.GetAvailablePackageDetailResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAvailablePackageDetail not implemented")
+}
+func (UnimplementedOciCatalogPackagesServiceServer) GetAvailablePackageVersions(context.Context, *v1alpha1.GetAvailablePackageVersionsRequest) (*v1alpha1.GetAvailablePackageVersionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAvailablePackageVersions not implemented")
+}
+func (UnimplementedOciCatalogPackagesServiceServer) GetAvailablePackageTextAsset(context.Context, *v1alpha1.GetAvailablePackageTextAssetRequest) (*v1alpha1.GetAvailablePackageTextAssetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAvailablePackageTextAsset not implemented")
+}
+func (UnimplementedOciCatalogPackagesServiceServer) GetAvailablePackageChangelog(context.Context, *v1alpha1.GetAvailablePackageChangelogRequest) (*v1alpha1.GetAvailablePackageChangelogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAvailablePackageChangelog not implemented")
+}
+func (UnimplementedOciCatalogPackagesServiceServer) GetAvailablePackageFilters(context.Context, *v1alpha1.GetAvailablePackageFiltersRequest) (*v1alpha1.GetAvailablePackageFiltersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAvailablePackageFilters not implemented")
+}
+func (UnimplementedOciCatalogPackagesServiceServer) GetInstalledPackageSummaries(context.Context, *v1alpha1.GetInstalledPackageSummariesRequest) (*v1alpha1.GetInstalledPackageSummariesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInstalledPackageSummaries not implemented")
+}
+func (UnimplementedOciCatalogPackagesServiceServer) GetInstalledPackageDetail(context.Context, *v1alpha1.GetInstalledPackageDetailRequest) (*v1alpha1.GetInstalledPackageDetailResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInstalledPackageDetail not implemented")
+}
+func (UnimplementedOciCatalogPackagesServiceServer) CheckInstalledPackageNameAvailability(context.Context, *v1alpha1.CheckInstalledPackageNameAvailabilityRequest) (*v1alpha1.CheckInstalledPackageNameAvailabilityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckInstalledPackageNameAvailability not implemented")
+}
+func (UnimplementedOciCatalogPackagesServiceServer) CreateInstalledPackage(context.Context, *v1alpha1.CreateInstalledPackageRequest) (*v1alpha1.CreateInstalledPackageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateInstalledPackage not implemented")
+}
+func (UnimplementedOciCatalogPackagesServiceServer) UpdateInstalledPackage(context.Context, *v1alpha1.UpdateInstalledPackageRequest) (*v1alpha1.UpdateInstalledPackageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateInstalledPackage not implemented")
+}
+func (UnimplementedOciCatalogPackagesServiceServer) DeleteInstalledPackage(context.Context, *v1alpha1.DeleteInstalledPackageRequest) (*v1alpha1.DeleteInstalledPackageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteInstalledPackage not implemented")
+}
+func (UnimplementedOciCatalogPackagesServiceServer) GetInstalledPackageResourceRefs(context.Context, *v1alpha1.GetInstalledPackageResourceRefsRequest) (*v1alpha1.GetInstalledPackageResourceRefsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInstalledPackageResourceRefs not implemented")
+}
+func (UnimplementedOciCatalogPackagesServiceServer) GetInstalledPackageOperationStatus(context.Context, *v1alpha1.GetInstalledPackageOperationStatusRequest) (*v1alpha1.GetInstalledPackageOperationStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInstalledPackageOperationStatus not implemented")
+}
+
+// UnsafeOciCatalogPackagesServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to OciCatalogPackagesServiceServer will
+// result in compilation errors.
+type UnsafeOciCatalogPackagesServiceServer interface {
+	mustEmbedUnimplementedOciCatalogPackagesServiceServer()
+}
+
+func RegisterOciCatalogPackagesServiceServer(s grpc.ServiceRegistrar, srv OciCatalogPackagesServiceServer) {
+	s.RegisterService(&OciCatalogPackagesService_ServiceDesc, srv)
+}
+
+func _OciCatalogPackagesService_GetAvailablePackageSummaries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha1.GetAvailablePackageSummariesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OciCatalogPackagesServiceServer).GetAvailablePackageSummaries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OciCatalogPackagesService_GetAvailablePackageSummaries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OciCatalogPackagesServiceServer).GetAvailablePackageSummaries(ctx, req.(*v1alpha1.GetAvailablePackageSummariesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OciCatalogPackagesService_GetAvailablePackageDetail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha1.GetAvailablePackageDetailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OciCatalogPackagesServiceServer).GetAvailablePackageDetail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OciCatalogPackagesService_GetAvailablePackageDetail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OciCatalogPackagesServiceServer).GetAvailablePackageDetail(ctx, req.(*v1alpha1.GetAvailablePackageDetailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OciCatalogPackagesService_GetAvailablePackageVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha1.GetAvailablePackageVersionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OciCatalogPackagesServiceServer).GetAvailablePackageVersions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OciCatalogPackagesService_GetAvailablePackageVersions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OciCatalogPackagesServiceServer).GetAvailablePackageVersions(ctx, req.(*v1alpha1.GetAvailablePackageVersionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OciCatalogPackagesService_GetAvailablePackageTextAsset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha1.GetAvailablePackageTextAssetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OciCatalogPackagesServiceServer).GetAvailablePackageTextAsset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OciCatalogPackagesService_GetAvailablePackageTextAsset_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OciCatalogPackagesServiceServer).GetAvailablePackageTextAsset(ctx, req.(*v1alpha1.GetAvailablePackageTextAssetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OciCatalogPackagesService_GetAvailablePackageChangelog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha1.GetAvailablePackageChangelogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OciCatalogPackagesServiceServer).GetAvailablePackageChangelog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OciCatalogPackagesService_GetAvailablePackageChangelog_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OciCatalogPackagesServiceServer).GetAvailablePackageChangelog(ctx, req.(*v1alpha1.GetAvailablePackageChangelogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OciCatalogPackagesService_GetAvailablePackageFilters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha1.GetAvailablePackageFiltersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OciCatalogPackagesServiceServer).GetAvailablePackageFilters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OciCatalogPackagesService_GetAvailablePackageFilters_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OciCatalogPackagesServiceServer).GetAvailablePackageFilters(ctx, req.(*v1alpha1.GetAvailablePackageFiltersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OciCatalogPackagesService_GetInstalledPackageSummaries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha1.GetInstalledPackageSummariesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OciCatalogPackagesServiceServer).GetInstalledPackageSummaries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OciCatalogPackagesService_GetInstalledPackageSummaries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OciCatalogPackagesServiceServer).GetInstalledPackageSummaries(ctx, req.(*v1alpha1.GetInstalledPackageSummariesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OciCatalogPackagesService_GetInstalledPackageDetail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha1.GetInstalledPackageDetailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OciCatalogPackagesServiceServer).GetInstalledPackageDetail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OciCatalogPackagesService_GetInstalledPackageDetail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OciCatalogPackagesServiceServer).GetInstalledPackageDetail(ctx, req.(*v1alpha1.GetInstalledPackageDetailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OciCatalogPackagesService_CheckInstalledPackageNameAvailability_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha1.CheckInstalledPackageNameAvailabilityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OciCatalogPackagesServiceServer).CheckInstalledPackageNameAvailability(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OciCatalogPackagesService_CheckInstalledPackageNameAvailability_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OciCatalogPackagesServiceServer).CheckInstalledPackageNameAvailability(ctx, req.(*v1alpha1.CheckInstalledPackageNameAvailabilityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OciCatalogPackagesService_CreateInstalledPackage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha1.CreateInstalledPackageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OciCatalogPackagesServiceServer).CreateInstalledPackage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OciCatalogPackagesService_CreateInstalledPackage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OciCatalogPackagesServiceServer).CreateInstalledPackage(ctx, req.(*v1alpha1.CreateInstalledPackageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OciCatalogPackagesService_UpdateInstalledPackage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha1.UpdateInstalledPackageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OciCatalogPackagesServiceServer).UpdateInstalledPackage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OciCatalogPackagesService_UpdateInstalledPackage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OciCatalogPackagesServiceServer).UpdateInstalledPackage(ctx, req.(*v1alpha1.UpdateInstalledPackageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OciCatalogPackagesService_DeleteInstalledPackage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha1.DeleteInstalledPackageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OciCatalogPackagesServiceServer).DeleteInstalledPackage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OciCatalogPackagesService_DeleteInstalledPackage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OciCatalogPackagesServiceServer).DeleteInstalledPackage(ctx, req.(*v1alpha1.DeleteInstalledPackageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OciCatalogPackagesService_GetInstalledPackageResourceRefs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha1.GetInstalledPackageResourceRefsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OciCatalogPackagesServiceServer).GetInstalledPackageResourceRefs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OciCatalogPackagesService_GetInstalledPackageResourceRefs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OciCatalogPackagesServiceServer).GetInstalledPackageResourceRefs(ctx, req.(*v1alpha1.GetInstalledPackageResourceRefsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OciCatalogPackagesService_GetInstalledPackageOperationStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha1.GetInstalledPackageOperationStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OciCatalogPackagesServiceServer).GetInstalledPackageOperationStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OciCatalogPackagesService_GetInstalledPackageOperationStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OciCatalogPackagesServiceServer).GetInstalledPackageOperationStatus(ctx, req.(*v1alpha1.GetInstalledPackageOperationStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// OciCatalogPackagesService_ServiceDesc is the grpc.ServiceDesc for OciCatalogPackagesService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var OciCatalogPackagesService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService",
+	HandlerType: (*OciCatalogPackagesServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetAvailablePackageSummaries",
+			Handler:    _OciCatalogPackagesService_GetAvailablePackageSummaries_Handler,
+		},
+		{
+			MethodName: "GetAvailablePackageDetail",
+			Handler:    _OciCatalogPackagesService_GetAvailablePackageDetail_Handler,
+		},
+		{
+			MethodName: "GetAvailablePackageVersions",
+			Handler:    _OciCatalogPackagesService_GetAvailablePackageVersions_Handler,
+		},
+		{
+			MethodName: "GetAvailablePackageTextAsset",
+			Handler:    _OciCatalogPackagesService_GetAvailablePackageTextAsset_Handler,
+		},
+		{
+			MethodName: "GetAvailablePackageChangelog",
+			Handler:    _OciCatalogPackagesService_GetAvailablePackageChangelog_Handler,
+		},
+		{
+			MethodName: "GetAvailablePackageFilters",
+			Handler:    _OciCatalogPackagesService_GetAvailablePackageFilters_Handler,
+		},
+		{
+			MethodName: "GetInstalledPackageSummaries",
+			Handler:    _OciCatalogPackagesService_GetInstalledPackageSummaries_Handler,
+		},
+		{
+			MethodName: "GetInstalledPackageDetail",
+			Handler:    _OciCatalogPackagesService_GetInstalledPackageDetail_Handler,
+		},
+		{
+			MethodName: "CheckInstalledPackageNameAvailability",
+			Handler:    _OciCatalogPackagesService_CheckInstalledPackageNameAvailability_Handler,
+		},
+		{
+			MethodName: "CreateInstalledPackage",
+			Handler:    _OciCatalogPackagesService_CreateInstalledPackage_Handler,
+		},
+		{
+			MethodName: "UpdateInstalledPackage",
+			Handler:    _OciCatalogPackagesService_UpdateInstalledPackage_Handler,
+		},
+		{
+			MethodName: "DeleteInstalledPackage",
+			Handler:    _OciCatalogPackagesService_DeleteInstalledPackage_Handler,
+		},
+		{
+			MethodName: "GetInstalledPackageResourceRefs",
+			Handler:    _OciCatalogPackagesService_GetInstalledPackageResourceRefs_Handler,
+		},
+		{
+			MethodName: "GetInstalledPackageOperationStatus",
+			Handler:    _OciCatalogPackagesService_GetInstalledPackageOperationStatus_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "kubeappsapis/plugins/oci_catalog/packages/v1alpha1/oci_catalog.proto",
+}
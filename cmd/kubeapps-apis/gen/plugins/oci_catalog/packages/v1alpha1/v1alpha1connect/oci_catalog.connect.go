@@ -0,0 +1,508 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: kubeappsapis/plugins/oci_catalog/packages/v1alpha1/oci_catalog.proto
+
+package v1alpha1connect
+
+import (
+	context "context"
+	errors "errors"
+	connect_go "github.com/bufbuild/connect-go"
+	v1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	_ "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/plugins/oci_catalog/packages/v1alpha1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect_go.IsAtLeastVersion0_1_0
+
+const (
+	// OciCatalogPackagesServiceName is the fully-qualified name of the OciCatalogPackagesService
+	// service.
+	OciCatalogPackagesServiceName = "kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// OciCatalogPackagesServiceGetAvailablePackageSummariesProcedure is the fully-qualified name of the
+	// OciCatalogPackagesService's GetAvailablePackageSummaries RPC.
+	OciCatalogPackagesServiceGetAvailablePackageSummariesProcedure = "/kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService/GetAvailablePackageSummaries"
+	// OciCatalogPackagesServiceGetAvailablePackageDetailProcedure is the fully-qualified name of the
+	// OciCatalogPackagesService's GetAvailablePackageDetail RPC.
+	OciCatalogPackagesServiceGetAvailablePackageDetailProcedure = "/kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService/GetAvailablePackageDetail"
+	// OciCatalogPackagesServiceGetAvailablePackageVersionsProcedure is the fully-qualified name of the
+	// OciCatalogPackagesService's GetAvailablePackageVersions RPC.
+	OciCatalogPackagesServiceGetAvailablePackageVersionsProcedure = "/kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService/GetAvailablePackageVersions"
+	// OciCatalogPackagesServiceGetAvailablePackageTextAssetProcedure is the fully-qualified name of the
+	// OciCatalogPackagesService's GetAvailablePackageTextAsset RPC.
+	OciCatalogPackagesServiceGetAvailablePackageTextAssetProcedure = "/kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService/GetAvailablePackageTextAsset"
+	// OciCatalogPackagesServiceGetAvailablePackageChangelogProcedure is the fully-qualified name of the
+	// OciCatalogPackagesService's GetAvailablePackageChangelog RPC.
+	OciCatalogPackagesServiceGetAvailablePackageChangelogProcedure = "/kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService/GetAvailablePackageChangelog"
+	// OciCatalogPackagesServiceGetAvailablePackageFiltersProcedure is the fully-qualified name of the
+	// OciCatalogPackagesService's GetAvailablePackageFilters RPC.
+	OciCatalogPackagesServiceGetAvailablePackageFiltersProcedure = "/kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService/GetAvailablePackageFilters"
+	// OciCatalogPackagesServiceGetInstalledPackageSummariesProcedure is the fully-qualified name of the
+	// OciCatalogPackagesService's GetInstalledPackageSummaries RPC.
+	OciCatalogPackagesServiceGetInstalledPackageSummariesProcedure = "/kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService/GetInstalledPackageSummaries"
+	// OciCatalogPackagesServiceGetInstalledPackageDetailProcedure is the fully-qualified name of the
+	// OciCatalogPackagesService's GetInstalledPackageDetail RPC.
+	OciCatalogPackagesServiceGetInstalledPackageDetailProcedure = "/kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService/GetInstalledPackageDetail"
+	// OciCatalogPackagesServiceCheckInstalledPackageNameAvailabilityProcedure is the fully-qualified
+	// name of the OciCatalogPackagesService's CheckInstalledPackageNameAvailability RPC.
+	OciCatalogPackagesServiceCheckInstalledPackageNameAvailabilityProcedure = "/kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService/CheckInstalledPackageNameAvailability"
+	// OciCatalogPackagesServiceCreateInstalledPackageProcedure is the fully-qualified name of the
+	// OciCatalogPackagesService's CreateInstalledPackage RPC.
+	OciCatalogPackagesServiceCreateInstalledPackageProcedure = "/kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService/CreateInstalledPackage"
+	// OciCatalogPackagesServiceUpdateInstalledPackageProcedure is the fully-qualified name of the
+	// OciCatalogPackagesService's UpdateInstalledPackage RPC.
+	OciCatalogPackagesServiceUpdateInstalledPackageProcedure = "/kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService/UpdateInstalledPackage"
+	// OciCatalogPackagesServiceDeleteInstalledPackageProcedure is the fully-qualified name of the
+	// OciCatalogPackagesService's DeleteInstalledPackage RPC.
+	OciCatalogPackagesServiceDeleteInstalledPackageProcedure = "/kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService/DeleteInstalledPackage"
+	// OciCatalogPackagesServiceGetInstalledPackageResourceRefsProcedure is the fully-qualified name of
+	// the OciCatalogPackagesService's GetInstalledPackageResourceRefs RPC.
+	OciCatalogPackagesServiceGetInstalledPackageResourceRefsProcedure = "/kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService/GetInstalledPackageResourceRefs"
+	// OciCatalogPackagesServiceGetInstalledPackageOperationStatusProcedure is the fully-qualified name
+	// of the OciCatalogPackagesService's GetInstalledPackageOperationStatus RPC.
+	OciCatalogPackagesServiceGetInstalledPackageOperationStatusProcedure = "/kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService/GetInstalledPackageOperationStatus"
+)
+
+// OciCatalogPackagesServiceClient is a client for the
+// kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService service.
+type OciCatalogPackagesServiceClient interface {
+	// GetAvailablePackageSummaries returns the available packages managed by the 'oci_catalog' plugin
+	GetAvailablePackageSummaries(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageSummariesRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageSummariesResponse], error)
+	// GetAvailablePackageDetail returns the package details managed by the 'oci_catalog' plugin
+	GetAvailablePackageDetail(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageDetailRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageDetailResponse], error)
+	// GetAvailablePackageVersions returns the package versions managed by the 'oci_catalog' plugin
+	GetAvailablePackageVersions(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageVersionsRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageVersionsResponse], error)
+	// GetAvailablePackageTextAsset returns a range of a text asset of a package managed by the 'oci_catalog' plugin
+	GetAvailablePackageTextAsset(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageTextAssetRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageTextAssetResponse], error)
+	// GetAvailablePackageChangelog returns the release notes between two versions of a package managed by the 'oci_catalog' plugin
+	GetAvailablePackageChangelog(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageChangelogRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageChangelogResponse], error)
+	// GetAvailablePackageFilters returns the filter values of the available packages managed by the 'oci_catalog' plugin
+	GetAvailablePackageFilters(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageFiltersRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageFiltersResponse], error)
+	// GetInstalledPackageSummaries returns the installed packages managed by the 'oci_catalog' plugin
+	GetInstalledPackageSummaries(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageSummariesRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageSummariesResponse], error)
+	// GetInstalledPackageDetail returns the requested installed package managed by the 'oci_catalog' plugin
+	GetInstalledPackageDetail(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageDetailRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageDetailResponse], error)
+	// CheckInstalledPackageNameAvailability checks whether a package managed by the 'oci_catalog' plugin can be installed with the given name
+	CheckInstalledPackageNameAvailability(context.Context, *connect_go.Request[v1alpha1.CheckInstalledPackageNameAvailabilityRequest]) (*connect_go.Response[v1alpha1.CheckInstalledPackageNameAvailabilityResponse], error)
+	// CreateInstalledPackage creates an installed package based on the request.
+	CreateInstalledPackage(context.Context, *connect_go.Request[v1alpha1.CreateInstalledPackageRequest]) (*connect_go.Response[v1alpha1.CreateInstalledPackageResponse], error)
+	// UpdateInstalledPackage updates an installed package based on the request.
+	UpdateInstalledPackage(context.Context, *connect_go.Request[v1alpha1.UpdateInstalledPackageRequest]) (*connect_go.Response[v1alpha1.UpdateInstalledPackageResponse], error)
+	// DeleteInstalledPackage deletes an installed package based on the request.
+	DeleteInstalledPackage(context.Context, *connect_go.Request[v1alpha1.DeleteInstalledPackageRequest]) (*connect_go.Response[v1alpha1.DeleteInstalledPackageResponse], error)
+	// GetInstalledPackageResourceRefs returns the references for the Kubernetes resources created by
+	// an installed package.
+	GetInstalledPackageResourceRefs(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageResourceRefsRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageResourceRefsResponse], error)
+	// GetInstalledPackageOperationStatus returns the progress of the operation in course on an installed package managed by the 'oci_catalog' plugin
+	GetInstalledPackageOperationStatus(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageOperationStatusRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageOperationStatusResponse], error)
+}
+
+// NewOciCatalogPackagesServiceClient constructs a client for the
+// kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService service. By default,
+// it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses, and
+// sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC()
+// or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewOciCatalogPackagesServiceClient(httpClient connect_go.HTTPClient, baseURL string, opts ...connect_go.ClientOption) OciCatalogPackagesServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	return &ociCatalogPackagesServiceClient{
+		getAvailablePackageSummaries: connect_go.NewClient[v1alpha1.GetAvailablePackageSummariesRequest, v1alpha1.GetAvailablePackageSummariesResponse](
+			httpClient,
+			baseURL+OciCatalogPackagesServiceGetAvailablePackageSummariesProcedure,
+			opts...,
+		),
+		getAvailablePackageDetail: connect_go.NewClient[v1alpha1.GetAvailablePackageDetailRequest, v1alpha1.GetAvailablePackageDetailResponse](
+			httpClient,
+			baseURL+OciCatalogPackagesServiceGetAvailablePackageDetailProcedure,
+			opts...,
+		),
+		getAvailablePackageVersions: connect_go.NewClient[v1alpha1.GetAvailablePackageVersionsRequest, v1alpha1.GetAvailablePackageVersionsResponse](
+			httpClient,
+			baseURL+OciCatalogPackagesServiceGetAvailablePackageVersionsProcedure,
+			opts...,
+		),
+		getAvailablePackageTextAsset: connect_go.NewClient[v1alpha1.GetAvailablePackageTextAssetRequest, v1alpha1.GetAvailablePackageTextAssetResponse](
+			httpClient,
+			baseURL+OciCatalogPackagesServiceGetAvailablePackageTextAssetProcedure,
+			opts...,
+		),
+		getAvailablePackageChangelog: connect_go.NewClient[v1alpha1.GetAvailablePackageChangelogRequest, v1alpha1.GetAvailablePackageChangelogResponse](
+			httpClient,
+			baseURL+OciCatalogPackagesServiceGetAvailablePackageChangelogProcedure,
+			opts...,
+		),
+		getAvailablePackageFilters: connect_go.NewClient[v1alpha1.GetAvailablePackageFiltersRequest, v1alpha1.GetAvailablePackageFiltersResponse](
+			httpClient,
+			baseURL+OciCatalogPackagesServiceGetAvailablePackageFiltersProcedure,
+			opts...,
+		),
+		getInstalledPackageSummaries: connect_go.NewClient[v1alpha1.GetInstalledPackageSummariesRequest, v1alpha1.GetInstalledPackageSummariesResponse](
+			httpClient,
+			baseURL+OciCatalogPackagesServiceGetInstalledPackageSummariesProcedure,
+			opts...,
+		),
+		getInstalledPackageDetail: connect_go.NewClient[v1alpha1.GetInstalledPackageDetailRequest, v1alpha1.GetInstalledPackageDetailResponse](
+			httpClient,
+			baseURL+OciCatalogPackagesServiceGetInstalledPackageDetailProcedure,
+			opts...,
+		),
+		checkInstalledPackageNameAvailability: connect_go.NewClient[v1alpha1.CheckInstalledPackageNameAvailabilityRequest, v1alpha1.CheckInstalledPackageNameAvailabilityResponse](
+			httpClient,
+			baseURL+OciCatalogPackagesServiceCheckInstalledPackageNameAvailabilityProcedure,
+			opts...,
+		),
+		createInstalledPackage: connect_go.NewClient[v1alpha1.CreateInstalledPackageRequest, v1alpha1.CreateInstalledPackageResponse](
+			httpClient,
+			baseURL+OciCatalogPackagesServiceCreateInstalledPackageProcedure,
+			opts...,
+		),
+		updateInstalledPackage: connect_go.NewClient[v1alpha1.UpdateInstalledPackageRequest, v1alpha1.UpdateInstalledPackageResponse](
+			httpClient,
+			baseURL+OciCatalogPackagesServiceUpdateInstalledPackageProcedure,
+			opts...,
+		),
+		deleteInstalledPackage: connect_go.NewClient[v1alpha1.DeleteInstalledPackageRequest, v1alpha1.DeleteInstalledPackageResponse](
+			httpClient,
+			baseURL+OciCatalogPackagesServiceDeleteInstalledPackageProcedure,
+			opts...,
+		),
+		getInstalledPackageResourceRefs: connect_go.NewClient[v1alpha1.GetInstalledPackageResourceRefsRequest, v1alpha1.GetInstalledPackageResourceRefsResponse](
+			httpClient,
+			baseURL+OciCatalogPackagesServiceGetInstalledPackageResourceRefsProcedure,
+			opts...,
+		),
+		getInstalledPackageOperationStatus: connect_go.NewClient[v1alpha1.GetInstalledPackageOperationStatusRequest, v1alpha1.GetInstalledPackageOperationStatusResponse](
+			httpClient,
+			baseURL+OciCatalogPackagesServiceGetInstalledPackageOperationStatusProcedure,
+			opts...,
+		),
+	}
+}
+
+// ociCatalogPackagesServiceClient implements OciCatalogPackagesServiceClient.
+type ociCatalogPackagesServiceClient struct {
+	getAvailablePackageSummaries          *connect_go.Client[v1alpha1.GetAvailablePackageSummariesRequest, v1alpha1.GetAvailablePackageSummariesResponse]
+	getAvailablePackageDetail             *connect_go.Client[v1alpha1.GetAvailablePackageDetailRequest, v1alpha1.GetAvailablePackageDetailResponse]
+	getAvailablePackageVersions           *connect_go.Client[v1alpha1.GetAvailablePackageVersionsRequest, v1alpha1.GetAvailablePackageVersionsResponse]
+	getAvailablePackageTextAsset          *connect_go.Client[v1alpha1.GetAvailablePackageTextAssetRequest, v1alpha1.GetAvailablePackageTextAssetResponse]
+	getAvailablePackageChangelog          *connect_go.Client[v1alpha1.GetAvailablePackageChangelogRequest, v1alpha1.GetAvailablePackageChangelogResponse]
+	getAvailablePackageFilters            *connect_go.Client[v1alpha1.GetAvailablePackageFiltersRequest, v1alpha1.GetAvailablePackageFiltersResponse]
+	getInstalledPackageSummaries          *connect_go.Client[v1alpha1.GetInstalledPackageSummariesRequest, v1alpha1.GetInstalledPackageSummariesResponse]
+	getInstalledPackageDetail             *connect_go.Client[v1alpha1.GetInstalledPackageDetailRequest, v1alpha1.GetInstalledPackageDetailResponse]
+	checkInstalledPackageNameAvailability *connect_go.Client[v1alpha1.CheckInstalledPackageNameAvailabilityRequest, v1alpha1.CheckInstalledPackageNameAvailabilityResponse]
+	createInstalledPackage                *connect_go.Client[v1alpha1.CreateInstalledPackageRequest, v1alpha1.CreateInstalledPackageResponse]
+	updateInstalledPackage                *connect_go.Client[v1alpha1.UpdateInstalledPackageRequest, v1alpha1.UpdateInstalledPackageResponse]
+	deleteInstalledPackage                *connect_go.Client[v1alpha1.DeleteInstalledPackageRequest, v1alpha1.DeleteInstalledPackageResponse]
+	getInstalledPackageResourceRefs       *connect_go.Client[v1alpha1.GetInstalledPackageResourceRefsRequest, v1alpha1.GetInstalledPackageResourceRefsResponse]
+	getInstalledPackageOperationStatus    *connect_go.Client[v1alpha1.GetInstalledPackageOperationStatusRequest, v1alpha1.GetInstalledPackageOperationStatusResponse]
+}
+
+// GetAvailablePackageSummaries calls
+// kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService.GetAvailablePackageSummaries.
+func (c *ociCatalogPackagesServiceClient) GetAvailablePackageSummaries(ctx context.Context, req *connect_go.Request[v1alpha1.GetAvailablePackageSummariesRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageSummariesResponse], error) {
+	return c.getAvailablePackageSummaries.CallUnary(ctx, req)
+}
+
+// GetAvailablePackageDetail calls
+// kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService.GetAvailablePackageDetail.
+func (c *ociCatalogPackagesServiceClient) GetAvailablePackageDetail(ctx context.Context, req *connect_go.Request[v1alpha1.GetAvailablePackageDetailRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageDetailResponse], error) {
+	return c.getAvailablePackageDetail.CallUnary(ctx, req)
+}
+
+// GetAvailablePackageVersions calls
+// kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService.GetAvailablePackageVersions.
+func (c *ociCatalogPackagesServiceClient) GetAvailablePackageVersions(ctx context.Context, req *connect_go.Request[v1alpha1.GetAvailablePackageVersionsRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageVersionsResponse], error) {
+	return c.getAvailablePackageVersions.CallUnary(ctx, req)
+}
+
+// GetAvailablePackageTextAsset calls
+// kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService.GetAvailablePackageTextAsset.
+func (c *ociCatalogPackagesServiceClient) GetAvailablePackageTextAsset(ctx context.Context, req *connect_go.Request[v1alpha1.GetAvailablePackageTextAssetRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageTextAssetResponse], error) {
+	return c.getAvailablePackageTextAsset.CallUnary(ctx, req)
+}
+
+// GetAvailablePackageChangelog calls
+// kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService.GetAvailablePackageChangelog.
+func (c *ociCatalogPackagesServiceClient) GetAvailablePackageChangelog(ctx context.Context, req *connect_go.Request[v1alpha1.GetAvailablePackageChangelogRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageChangelogResponse], error) {
+	return c.getAvailablePackageChangelog.CallUnary(ctx, req)
+}
+
+// GetAvailablePackageFilters calls
+// kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService.GetAvailablePackageFilters.
+func (c *ociCatalogPackagesServiceClient) GetAvailablePackageFilters(ctx context.Context, req *connect_go.Request[v1alpha1.GetAvailablePackageFiltersRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageFiltersResponse], error) {
+	return c.getAvailablePackageFilters.CallUnary(ctx, req)
+}
+
+// GetInstalledPackageSummaries calls
+// kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService.GetInstalledPackageSummaries.
+func (c *ociCatalogPackagesServiceClient) GetInstalledPackageSummaries(ctx context.Context, req *connect_go.Request[v1alpha1.GetInstalledPackageSummariesRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageSummariesResponse], error) {
+	return c.getInstalledPackageSummaries.CallUnary(ctx, req)
+}
+
+// GetInstalledPackageDetail calls
+// kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService.GetInstalledPackageDetail.
+func (c *ociCatalogPackagesServiceClient) GetInstalledPackageDetail(ctx context.Context, req *connect_go.Request[v1alpha1.GetInstalledPackageDetailRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageDetailResponse], error) {
+	return c.getInstalledPackageDetail.CallUnary(ctx, req)
+}
+
+// CheckInstalledPackageNameAvailability calls
+// kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService.CheckInstalledPackageNameAvailability.
+func (c *ociCatalogPackagesServiceClient) CheckInstalledPackageNameAvailability(ctx context.Context, req *connect_go.Request[v1alpha1.CheckInstalledPackageNameAvailabilityRequest]) (*connect_go.Response[v1alpha1.CheckInstalledPackageNameAvailabilityResponse], error) {
+	return c.checkInstalledPackageNameAvailability.CallUnary(ctx, req)
+}
+
+// CreateInstalledPackage calls
+// kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService.CreateInstalledPackage.
+func (c *ociCatalogPackagesServiceClient) CreateInstalledPackage(ctx context.Context, req *connect_go.Request[v1alpha1.CreateInstalledPackageRequest]) (*connect_go.Response[v1alpha1.CreateInstalledPackageResponse], error) {
+	return c.createInstalledPackage.CallUnary(ctx, req)
+}
+
+// UpdateInstalledPackage calls
+// kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService.UpdateInstalledPackage.
+func (c *ociCatalogPackagesServiceClient) UpdateInstalledPackage(ctx context.Context, req *connect_go.Request[v1alpha1.UpdateInstalledPackageRequest]) (*connect_go.Response[v1alpha1.UpdateInstalledPackageResponse], error) {
+	return c.updateInstalledPackage.CallUnary(ctx, req)
+}
+
+// DeleteInstalledPackage calls
+// kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService.DeleteInstalledPackage.
+func (c *ociCatalogPackagesServiceClient) DeleteInstalledPackage(ctx context.Context, req *connect_go.Request[v1alpha1.DeleteInstalledPackageRequest]) (*connect_go.Response[v1alpha1.DeleteInstalledPackageResponse], error) {
+	return c.deleteInstalledPackage.CallUnary(ctx, req)
+}
+
+// GetInstalledPackageResourceRefs calls
+// kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService.GetInstalledPackageResourceRefs.
+func (c *ociCatalogPackagesServiceClient) GetInstalledPackageResourceRefs(ctx context.Context, req *connect_go.Request[v1alpha1.GetInstalledPackageResourceRefsRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageResourceRefsResponse], error) {
+	return c.getInstalledPackageResourceRefs.CallUnary(ctx, req)
+}
+
+// GetInstalledPackageOperationStatus calls
+// kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService.GetInstalledPackageOperationStatus.
+func (c *ociCatalogPackagesServiceClient) GetInstalledPackageOperationStatus(ctx context.Context, req *connect_go.Request[v1alpha1.GetInstalledPackageOperationStatusRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageOperationStatusResponse], error) {
+	return c.getInstalledPackageOperationStatus.CallUnary(ctx, req)
+}
+
+// OciCatalogPackagesServiceHandler is an implementation of the
+// kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService service.
+type OciCatalogPackagesServiceHandler interface {
+	// GetAvailablePackageSummaries returns the available packages managed by the 'oci_catalog' plugin
+	GetAvailablePackageSummaries(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageSummariesRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageSummariesResponse], error)
+	// GetAvailablePackageDetail returns the package details managed by the 'oci_catalog' plugin
+	GetAvailablePackageDetail(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageDetailRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageDetailResponse], error)
+	// GetAvailablePackageVersions returns the package versions managed by the 'oci_catalog' plugin
+	GetAvailablePackageVersions(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageVersionsRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageVersionsResponse], error)
+	// GetAvailablePackageTextAsset returns a range of a text asset of a package managed by the 'oci_catalog' plugin
+	GetAvailablePackageTextAsset(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageTextAssetRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageTextAssetResponse], error)
+	// GetAvailablePackageChangelog returns the release notes between two versions of a package managed by the 'oci_catalog' plugin
+	GetAvailablePackageChangelog(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageChangelogRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageChangelogResponse], error)
+	// GetAvailablePackageFilters returns the filter values of the available packages managed by the 'oci_catalog' plugin
+	GetAvailablePackageFilters(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageFiltersRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageFiltersResponse], error)
+	// GetInstalledPackageSummaries returns the installed packages managed by the 'oci_catalog' plugin
+	GetInstalledPackageSummaries(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageSummariesRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageSummariesResponse], error)
+	// GetInstalledPackageDetail returns the requested installed package managed by the 'oci_catalog' plugin
+	GetInstalledPackageDetail(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageDetailRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageDetailResponse], error)
+	// CheckInstalledPackageNameAvailability checks whether a package managed by the 'oci_catalog' plugin can be installed with the given name
+	CheckInstalledPackageNameAvailability(context.Context, *connect_go.Request[v1alpha1.CheckInstalledPackageNameAvailabilityRequest]) (*connect_go.Response[v1alpha1.CheckInstalledPackageNameAvailabilityResponse], error)
+	// CreateInstalledPackage creates an installed package based on the request.
+	CreateInstalledPackage(context.Context, *connect_go.Request[v1alpha1.CreateInstalledPackageRequest]) (*connect_go.Response[v1alpha1.CreateInstalledPackageResponse], error)
+	// UpdateInstalledPackage updates an installed package based on the request.
+	UpdateInstalledPackage(context.Context, *connect_go.Request[v1alpha1.UpdateInstalledPackageRequest]) (*connect_go.Response[v1alpha1.UpdateInstalledPackageResponse], error)
+	// DeleteInstalledPackage deletes an installed package based on the request.
+	DeleteInstalledPackage(context.Context, *connect_go.Request[v1alpha1.DeleteInstalledPackageRequest]) (*connect_go.Response[v1alpha1.DeleteInstalledPackageResponse], error)
+	// GetInstalledPackageResourceRefs returns the references for the Kubernetes resources created by
+	// an installed package.
+	GetInstalledPackageResourceRefs(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageResourceRefsRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageResourceRefsResponse], error)
+	// GetInstalledPackageOperationStatus returns the progress of the operation in course on an installed package managed by the 'oci_catalog' plugin
+	GetInstalledPackageOperationStatus(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageOperationStatusRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageOperationStatusResponse], error)
+}
+
+// NewOciCatalogPackagesServiceHandler builds an HTTP handler from the service implementation. It
+// returns the path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewOciCatalogPackagesServiceHandler(svc OciCatalogPackagesServiceHandler, opts ...connect_go.HandlerOption) (string, http.Handler) {
+	ociCatalogPackagesServiceGetAvailablePackageSummariesHandler := connect_go.NewUnaryHandler(
+		OciCatalogPackagesServiceGetAvailablePackageSummariesProcedure,
+		svc.GetAvailablePackageSummaries,
+		opts...,
+	)
+	ociCatalogPackagesServiceGetAvailablePackageDetailHandler := connect_go.NewUnaryHandler(
+		OciCatalogPackagesServiceGetAvailablePackageDetailProcedure,
+		svc.GetAvailablePackageDetail,
+		opts...,
+	)
+	ociCatalogPackagesServiceGetAvailablePackageVersionsHandler := connect_go.NewUnaryHandler(
+		OciCatalogPackagesServiceGetAvailablePackageVersionsProcedure,
+		svc.GetAvailablePackageVersions,
+		opts...,
+	)
+	ociCatalogPackagesServiceGetAvailablePackageTextAssetHandler := connect_go.NewUnaryHandler(
+		OciCatalogPackagesServiceGetAvailablePackageTextAssetProcedure,
+		svc.GetAvailablePackageTextAsset,
+		opts...,
+	)
+	ociCatalogPackagesServiceGetAvailablePackageChangelogHandler := connect_go.NewUnaryHandler(
+		OciCatalogPackagesServiceGetAvailablePackageChangelogProcedure,
+		svc.GetAvailablePackageChangelog,
+		opts...,
+	)
+	ociCatalogPackagesServiceGetAvailablePackageFiltersHandler := connect_go.NewUnaryHandler(
+		OciCatalogPackagesServiceGetAvailablePackageFiltersProcedure,
+		svc.GetAvailablePackageFilters,
+		opts...,
+	)
+	ociCatalogPackagesServiceGetInstalledPackageSummariesHandler := connect_go.NewUnaryHandler(
+		OciCatalogPackagesServiceGetInstalledPackageSummariesProcedure,
+		svc.GetInstalledPackageSummaries,
+		opts...,
+	)
+	ociCatalogPackagesServiceGetInstalledPackageDetailHandler := connect_go.NewUnaryHandler(
+		OciCatalogPackagesServiceGetInstalledPackageDetailProcedure,
+		svc.GetInstalledPackageDetail,
+		opts...,
+	)
+	ociCatalogPackagesServiceCheckInstalledPackageNameAvailabilityHandler := connect_go.NewUnaryHandler(
+		OciCatalogPackagesServiceCheckInstalledPackageNameAvailabilityProcedure,
+		svc.CheckInstalledPackageNameAvailability,
+		opts...,
+	)
+	ociCatalogPackagesServiceCreateInstalledPackageHandler := connect_go.NewUnaryHandler(
+		OciCatalogPackagesServiceCreateInstalledPackageProcedure,
+		svc.CreateInstalledPackage,
+		opts...,
+	)
+	ociCatalogPackagesServiceUpdateInstalledPackageHandler := connect_go.NewUnaryHandler(
+		OciCatalogPackagesServiceUpdateInstalledPackageProcedure,
+		svc.UpdateInstalledPackage,
+		opts...,
+	)
+	ociCatalogPackagesServiceDeleteInstalledPackageHandler := connect_go.NewUnaryHandler(
+		OciCatalogPackagesServiceDeleteInstalledPackageProcedure,
+		svc.DeleteInstalledPackage,
+		opts...,
+	)
+	ociCatalogPackagesServiceGetInstalledPackageResourceRefsHandler := connect_go.NewUnaryHandler(
+		OciCatalogPackagesServiceGetInstalledPackageResourceRefsProcedure,
+		svc.GetInstalledPackageResourceRefs,
+		opts...,
+	)
+	ociCatalogPackagesServiceGetInstalledPackageOperationStatusHandler := connect_go.NewUnaryHandler(
+		OciCatalogPackagesServiceGetInstalledPackageOperationStatusProcedure,
+		svc.GetInstalledPackageOperationStatus,
+		opts...,
+	)
+	return "/kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case OciCatalogPackagesServiceGetAvailablePackageSummariesProcedure:
+			ociCatalogPackagesServiceGetAvailablePackageSummariesHandler.ServeHTTP(w, r)
+		case OciCatalogPackagesServiceGetAvailablePackageDetailProcedure:
+			ociCatalogPackagesServiceGetAvailablePackageDetailHandler.ServeHTTP(w, r)
+		case OciCatalogPackagesServiceGetAvailablePackageVersionsProcedure:
+			ociCatalogPackagesServiceGetAvailablePackageVersionsHandler.ServeHTTP(w, r)
+		case OciCatalogPackagesServiceGetAvailablePackageTextAssetProcedure:
+			ociCatalogPackagesServiceGetAvailablePackageTextAssetHandler.ServeHTTP(w, r)
+		case OciCatalogPackagesServiceGetAvailablePackageChangelogProcedure:
+			ociCatalogPackagesServiceGetAvailablePackageChangelogHandler.ServeHTTP(w, r)
+		case OciCatalogPackagesServiceGetAvailablePackageFiltersProcedure:
+			ociCatalogPackagesServiceGetAvailablePackageFiltersHandler.ServeHTTP(w, r)
+		case OciCatalogPackagesServiceGetInstalledPackageSummariesProcedure:
+			ociCatalogPackagesServiceGetInstalledPackageSummariesHandler.ServeHTTP(w, r)
+		case OciCatalogPackagesServiceGetInstalledPackageDetailProcedure:
+			ociCatalogPackagesServiceGetInstalledPackageDetailHandler.ServeHTTP(w, r)
+		case OciCatalogPackagesServiceCheckInstalledPackageNameAvailabilityProcedure:
+			ociCatalogPackagesServiceCheckInstalledPackageNameAvailabilityHandler.ServeHTTP(w, r)
+		case OciCatalogPackagesServiceCreateInstalledPackageProcedure:
+			ociCatalogPackagesServiceCreateInstalledPackageHandler.ServeHTTP(w, r)
+		case OciCatalogPackagesServiceUpdateInstalledPackageProcedure:
+			ociCatalogPackagesServiceUpdateInstalledPackageHandler.ServeHTTP(w, r)
+		case OciCatalogPackagesServiceDeleteInstalledPackageProcedure:
+			ociCatalogPackagesServiceDeleteInstalledPackageHandler.ServeHTTP(w, r)
+		case OciCatalogPackagesServiceGetInstalledPackageResourceRefsProcedure:
+			ociCatalogPackagesServiceGetInstalledPackageResourceRefsHandler.ServeHTTP(w, r)
+		case OciCatalogPackagesServiceGetInstalledPackageOperationStatusProcedure:
+			ociCatalogPackagesServiceGetInstalledPackageOperationStatusHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedOciCatalogPackagesServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedOciCatalogPackagesServiceHandler struct{}
+
+func (UnimplementedOciCatalogPackagesServiceHandler) GetAvailablePackageSummaries(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageSummariesRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageSummariesResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService.GetAvailablePackageSummaries is not implemented"))
+}
+
+func (UnimplementedOciCatalogPackagesServiceHandler) GetAvailablePackageDetail(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageDetailRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageDetailResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService.GetAvailablePackageDetail is not implemented"))
+}
+
+func (UnimplementedOciCatalogPackagesServiceHandler) GetAvailablePackageVersions(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageVersionsRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageVersionsResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService.GetAvailablePackageVersions is not implemented"))
+}
+
+func (UnimplementedOciCatalogPackagesServiceHandler) GetAvailablePackageTextAsset(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageTextAssetRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageTextAssetResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService.GetAvailablePackageTextAsset is not implemented"))
+}
+
+func (UnimplementedOciCatalogPackagesServiceHandler) GetAvailablePackageChangelog(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageChangelogRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageChangelogResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService.GetAvailablePackageChangelog is not implemented"))
+}
+
+func (UnimplementedOciCatalogPackagesServiceHandler) GetAvailablePackageFilters(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageFiltersRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageFiltersResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService.GetAvailablePackageFilters is not implemented"))
+}
+
+func (UnimplementedOciCatalogPackagesServiceHandler) GetInstalledPackageSummaries(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageSummariesRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageSummariesResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService.GetInstalledPackageSummaries is not implemented"))
+}
+
+func (UnimplementedOciCatalogPackagesServiceHandler) GetInstalledPackageDetail(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageDetailRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageDetailResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService.GetInstalledPackageDetail is not implemented"))
+}
+
+func (UnimplementedOciCatalogPackagesServiceHandler) CheckInstalledPackageNameAvailability(context.Context, *connect_go.Request[v1alpha1.CheckInstalledPackageNameAvailabilityRequest]) (*connect_go.Response[v1alpha1.CheckInstalledPackageNameAvailabilityResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService.CheckInstalledPackageNameAvailability is not implemented"))
+}
+
+func (UnimplementedOciCatalogPackagesServiceHandler) CreateInstalledPackage(context.Context, *connect_go.Request[v1alpha1.CreateInstalledPackageRequest]) (*connect_go.Response[v1alpha1.CreateInstalledPackageResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService.CreateInstalledPackage is not implemented"))
+}
+
+func (UnimplementedOciCatalogPackagesServiceHandler) UpdateInstalledPackage(context.Context, *connect_go.Request[v1alpha1.UpdateInstalledPackageRequest]) (*connect_go.Response[v1alpha1.UpdateInstalledPackageResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService.UpdateInstalledPackage is not implemented"))
+}
+
+func (UnimplementedOciCatalogPackagesServiceHandler) DeleteInstalledPackage(context.Context, *connect_go.Request[v1alpha1.DeleteInstalledPackageRequest]) (*connect_go.Response[v1alpha1.DeleteInstalledPackageResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService.DeleteInstalledPackage is not implemented"))
+}
+
+func (UnimplementedOciCatalogPackagesServiceHandler) GetInstalledPackageResourceRefs(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageResourceRefsRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageResourceRefsResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService.GetInstalledPackageResourceRefs is not implemented"))
+}
+
+func (UnimplementedOciCatalogPackagesServiceHandler) GetInstalledPackageOperationStatus(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageOperationStatusRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageOperationStatusResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService.GetInstalledPackageOperationStatus is not implemented"))
+}
@@ -0,0 +1,622 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: kubeappsapis/plugins/operators/packages/v1alpha1/operators.proto
+
+package v1alpha1
+
+import (
+	context "context"
+	v1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	OperatorsPackagesService_GetAvailablePackageSummaries_FullMethodName          = "/kubeappsapis.plugins.operators.packages.v1alpha1.OperatorsPackagesService/GetAvailablePackageSummaries"
+	OperatorsPackagesService_GetAvailablePackageDetail_FullMethodName             = "/kubeappsapis.plugins.operators.packages.v1alpha1.OperatorsPackagesService/GetAvailablePackageDetail"
+	OperatorsPackagesService_GetAvailablePackageVersions_FullMethodName           = "/kubeappsapis.plugins.operators.packages.v1alpha1.OperatorsPackagesService/GetAvailablePackageVersions"
+	OperatorsPackagesService_GetAvailablePackageTextAsset_FullMethodName          = "/kubeappsapis.plugins.operators.packages.v1alpha1.OperatorsPackagesService/GetAvailablePackageTextAsset"
+	OperatorsPackagesService_GetAvailablePackageChangelog_FullMethodName          = "/kubeappsapis.plugins.operators.packages.v1alpha1.OperatorsPackagesService/GetAvailablePackageChangelog"
+	OperatorsPackagesService_GetAvailablePackageFilters_FullMethodName            = "/kubeappsapis.plugins.operators.packages.v1alpha1.OperatorsPackagesService/GetAvailablePackageFilters"
+	OperatorsPackagesService_GetInstalledPackageSummaries_FullMethodName          = "/kubeappsapis.plugins.operators.packages.v1alpha1.OperatorsPackagesService/GetInstalledPackageSummaries"
+	OperatorsPackagesService_GetInstalledPackageDetail_FullMethodName             = "/kubeappsapis.plugins.operators.packages.v1alpha1.OperatorsPackagesService/GetInstalledPackageDetail"
+	OperatorsPackagesService_CheckInstalledPackageNameAvailability_FullMethodName = "/kubeappsapis.plugins.operators.packages.v1alpha1.OperatorsPackagesService/CheckInstalledPackageNameAvailability"
+	OperatorsPackagesService_CreateInstalledPackage_FullMethodName                = "/kubeappsapis.plugins.operators.packages.v1alpha1.OperatorsPackagesService/CreateInstalledPackage"
+	OperatorsPackagesService_UpdateInstalledPackage_FullMethodName                = "/kubeappsapis.plugins.operators.packages.v1alpha1.OperatorsPackagesService/UpdateInstalledPackage"
+	OperatorsPackagesService_DeleteInstalledPackage_FullMethodName                = "/kubeappsapis.plugins.operators.packages.v1alpha1.OperatorsPackagesService/DeleteInstalledPackage"
+	OperatorsPackagesService_GetInstalledPackageResourceRefs_FullMethodName       = "/kubeappsapis.plugins.operators.packages.v1alpha1.OperatorsPackagesService/GetInstalledPackageResourceRefs"
+	OperatorsPackagesService_GetInstalledPackageOperationStatus_FullMethodName    = "/kubeappsapis.plugins.operators.packages.v1alpha1.OperatorsPackagesService/GetInstalledPackageOperationStatus"
+)
+
+// OperatorsPackagesServiceClient is the client API for OperatorsPackagesService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type OperatorsPackagesServiceClient interface {
+	// GetAvailablePackageSummaries returns the available packages managed by the 'operators' plugin
+	GetAvailablePackageSummaries(ctx context.Context, in *v1alpha1.GetAvailablePackageSummariesRequest, opts ...grpc.CallOption) (*v1alpha1.GetAvailablePackageSummariesResponse, error)
+	// GetAvailablePackageDetail returns the package details managed by the 'operators' plugin
+	GetAvailablePackageDetail(ctx context.Context, in *v1alpha1.GetAvailablePackageDetailRequest, opts ...grpc.CallOption) (*v1alpha1.GetAvailablePackageDetailResponse, error)
+	// GetAvailablePackageVersions returns the package versions managed by the 'operators' plugin
+	GetAvailablePackageVersions(ctx context.Context, in *v1alpha1.GetAvailablePackageVersionsRequest, opts ...grpc.CallOption) (*v1alpha1.GetAvailablePackageVersionsResponse, error)
+	// GetAvailablePackageTextAsset returns a range of a text asset of a package managed by the 'operators' plugin
+	GetAvailablePackageTextAsset(ctx context.Context, in *v1alpha1.GetAvailablePackageTextAssetRequest, opts ...grpc.CallOption) (*v1alpha1.GetAvailablePackageTextAssetResponse, error)
+	// GetAvailablePackageChangelog returns the release notes between two versions of a package managed by the 'operators' plugin
+	GetAvailablePackageChangelog(ctx context.Context, in *v1alpha1.GetAvailablePackageChangelogRequest, opts ...grpc.CallOption) (*v1alpha1.GetAvailablePackageChangelogResponse, error)
+	// GetAvailablePackageFilters returns the filter values of the available packages managed by the 'operators' plugin
+	GetAvailablePackageFilters(ctx context.Context, in *v1alpha1.GetAvailablePackageFiltersRequest, opts ...grpc.CallOption) (*v1alpha1.GetAvailablePackageFiltersResponse, error)
+	// GetInstalledPackageSummaries returns the installed packages managed by the 'operators' plugin
+	GetInstalledPackageSummaries(ctx context.Context, in *v1alpha1.GetInstalledPackageSummariesRequest, opts ...grpc.CallOption) (*v1alpha1.GetInstalledPackageSummariesResponse, error)
+	// GetInstalledPackageDetail returns the requested installed package managed by the 'operators' plugin
+	GetInstalledPackageDetail(ctx context.Context, in *v1alpha1.GetInstalledPackageDetailRequest, opts ...grpc.CallOption) (*v1alpha1.GetInstalledPackageDetailResponse, error)
+	// CheckInstalledPackageNameAvailability checks whether a package managed by the 'operators' plugin can be installed with the given name
+	CheckInstalledPackageNameAvailability(ctx context.Context, in *v1alpha1.CheckInstalledPackageNameAvailabilityRequest, opts ...grpc.CallOption) (*v1alpha1.CheckInstalledPackageNameAvailabilityResponse, error)
+	// CreateInstalledPackage creates an installed package based on the request.
+	CreateInstalledPackage(ctx context.Context, in *v1alpha1.CreateInstalledPackageRequest, opts ...grpc.CallOption) (*v1alpha1.CreateInstalledPackageResponse, error)
+	// UpdateInstalledPackage updates an installed package based on the request.
+	UpdateInstalledPackage(ctx context.Context, in *v1alpha1.UpdateInstalledPackageRequest, opts ...grpc.CallOption) (*v1alpha1.UpdateInstalledPackageResponse, error)
+	// DeleteInstalledPackage deletes an installed package based on the request.
+	DeleteInstalledPackage(ctx context.Context, in *v1alpha1.DeleteInstalledPackageRequest, opts ...grpc.CallOption) (*v1alpha1.DeleteInstalledPackageResponse, error)
+	// GetInstalledPackageResourceRefs returns the references for the Kubernetes resources created by
+	// an installed package.
+	GetInstalledPackageResourceRefs(ctx context.Context, in *v1alpha1.GetInstalledPackageResourceRefsRequest, opts ...grpc.CallOption) (*v1alpha1.GetInstalledPackageResourceRefsResponse, error)
+	// GetInstalledPackageOperationStatus returns the progress of the operation in course on an installed package managed by the 'operators' plugin
+	GetInstalledPackageOperationStatus(ctx context.Context, in *v1alpha1.GetInstalledPackageOperationStatusRequest, opts ...grpc.CallOption) (*v1alpha1.GetInstalledPackageOperationStatusResponse, error)
+}
+
+type operatorsPackagesServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewOperatorsPackagesServiceClient(cc grpc.ClientConnInterface) OperatorsPackagesServiceClient {
+	return &operatorsPackagesServiceClient{cc}
+}
+
+func (c *operatorsPackagesServiceClient) GetAvailablePackageSummaries(ctx context.Context, in *v1alpha1.GetAvailablePackageSummariesRequest, opts ...grpc.CallOption) (*v1alpha1.GetAvailablePackageSummariesResponse, error) {
+	out := new(v1alpha1.GetAvailablePackageSummariesResponse)
+	err := c.cc.Invoke(ctx, OperatorsPackagesService_GetAvailablePackageSummaries_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *operatorsPackagesServiceClient) GetAvailablePackageDetail(ctx context.Context, in *v1alpha1.GetAvailablePackageDetailRequest, opts ...grpc.CallOption) (*v1alpha1.GetAvailablePackageDetailResponse, error) {
+	out := new(v1alpha1.GetAvailablePackageDetailResponse)
+	err := c.cc.Invoke(ctx, OperatorsPackagesService_GetAvailablePackageDetail_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *operatorsPackagesServiceClient) GetAvailablePackageVersions(ctx context.Context, in *v1alpha1.GetAvailablePackageVersionsRequest, opts ...grpc.CallOption) (*v1alpha1.GetAvailablePackageVersionsResponse, error) {
+	out := new(v1alpha1.GetAvailablePackageVersionsResponse)
+	err := c.cc.Invoke(ctx, OperatorsPackagesService_GetAvailablePackageVersions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *operatorsPackagesServiceClient) GetAvailablePackageTextAsset(ctx context.Context, in *v1alpha1.GetAvailablePackageTextAssetRequest, opts ...grpc.CallOption) (*v1alpha1.GetAvailablePackageTextAssetResponse, error) {
+	out := new(v1alpha1.GetAvailablePackageTextAssetResponse)
+	err := c.cc.Invoke(ctx, OperatorsPackagesService_GetAvailablePackageTextAsset_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *operatorsPackagesServiceClient) GetAvailablePackageChangelog(ctx context.Context, in *v1alpha1.GetAvailablePackageChangelogRequest, opts ...grpc.CallOption) (*v1alpha1.GetAvailablePackageChangelogResponse, error) {
+	out := new(v1alpha1.GetAvailablePackageChangelogResponse)
+	err := c.cc.Invoke(ctx, OperatorsPackagesService_GetAvailablePackageChangelog_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *operatorsPackagesServiceClient) GetAvailablePackageFilters(ctx context.Context, in *v1alpha1.GetAvailablePackageFiltersRequest, opts ...grpc.CallOption) (*v1alpha1.GetAvailablePackageFiltersResponse, error) {
+	out := new(v1alpha1.GetAvailablePackageFiltersResponse)
+	err := c.cc.Invoke(ctx, OperatorsPackagesService_GetAvailablePackageFilters_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *operatorsPackagesServiceClient) GetInstalledPackageSummaries(ctx context.Context, in *v1alpha1.GetInstalledPackageSummariesRequest, opts ...grpc.CallOption) (*v1alpha1.GetInstalledPackageSummariesResponse, error) {
+	out := new(v1alpha1.GetInstalledPackageSummariesResponse)
+	err := c.cc.Invoke(ctx, OperatorsPackagesService_GetInstalledPackageSummaries_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *operatorsPackagesServiceClient) GetInstalledPackageDetail(ctx context.Context, in *v1alpha1.GetInstalledPackageDetailRequest, opts ...grpc.CallOption) (*v1alpha1.GetInstalledPackageDetailResponse, error) {
+	out := new(v1alpha1.GetInstalledPackageDetailResponse)
+	err := c.cc.Invoke(ctx, OperatorsPackagesService_GetInstalledPackageDetail_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *operatorsPackagesServiceClient) CheckInstalledPackageNameAvailability(ctx context.Context, in *v1alpha1.CheckInstalledPackageNameAvailabilityRequest, opts ...grpc.CallOption) (*v1alpha1.CheckInstalledPackageNameAvailabilityResponse, error) {
+	out := new(v1alpha1.CheckInstalledPackageNameAvailabilityResponse)
+	err := c.cc.Invoke(ctx, OperatorsPackagesService_CheckInstalledPackageNameAvailability_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *operatorsPackagesServiceClient) CreateInstalledPackage(ctx context.Context, in *v1alpha1.CreateInstalledPackageRequest, opts ...grpc.CallOption) (*v1alpha1.CreateInstalledPackageResponse, error) {
+	out := new(v1alpha1.CreateInstalledPackageResponse)
+	err := c.cc.Invoke(ctx, OperatorsPackagesService_CreateInstalledPackage_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *operatorsPackagesServiceClient) UpdateInstalledPackage(ctx context.Context, in *v1alpha1.UpdateInstalledPackageRequest, opts ...grpc.CallOption) (*v1alpha1.UpdateInstalledPackageResponse, error) {
+	out := new(v1alpha1.UpdateInstalledPackageResponse)
+	err := c.cc.Invoke(ctx, OperatorsPackagesService_UpdateInstalledPackage_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *operatorsPackagesServiceClient) DeleteInstalledPackage(ctx context.Context, in *v1alpha1.DeleteInstalledPackageRequest, opts ...grpc.CallOption) (*v1alpha1.DeleteInstalledPackageResponse, error) {
+	out := new(v1alpha1.DeleteInstalledPackageResponse)
+	err := c.cc.Invoke(ctx, OperatorsPackagesService_DeleteInstalledPackage_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *operatorsPackagesServiceClient) GetInstalledPackageResourceRefs(ctx context.Context, in *v1alpha1.GetInstalledPackageResourceRefsRequest, opts ...grpc.CallOption) (*v1alpha1.GetInstalledPackageResourceRefsResponse, error) {
+	out := new(v1alpha1.GetInstalledPackageResourceRefsResponse)
+	err := c.cc.Invoke(ctx, OperatorsPackagesService_GetInstalledPackageResourceRefs_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *operatorsPackagesServiceClient) GetInstalledPackageOperationStatus(ctx context.Context, in *v1alpha1.GetInstalledPackageOperationStatusRequest, opts ...grpc.CallOption) (*v1alpha1.GetInstalledPackageOperationStatusResponse, error) {
+	out := new(v1alpha1.GetInstalledPackageOperationStatusResponse)
+	err := c.cc.Invoke(ctx, OperatorsPackagesService_GetInstalledPackageOperationStatus_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OperatorsPackagesServiceServer is the server API for OperatorsPackagesService service.
+// All implementations should embed UnimplementedOperatorsPackagesServiceServer
+// for forward compatibility
+type OperatorsPackagesServiceServer interface {
+	// GetAvailablePackageSummaries returns the available packages managed by the 'operators' plugin
+	GetAvailablePackageSummaries(context.Context, *v1alpha1.GetAvailablePackageSummariesRequest) (*v1alpha1.GetAvailablePackageSummariesResponse, error)
+	// GetAvailablePackageDetail returns the package details managed by the 'operators' plugin
+	GetAvailablePackageDetail(context.Context, *v1alpha1.GetAvailablePackageDetailRequest) (*v1alpha1.GetAvailablePackageDetailResponse, error)
+	// GetAvailablePackageVersions returns the package versions managed by the 'operators' plugin
+	GetAvailablePackageVersions(context.Context, *v1alpha1.GetAvailablePackageVersionsRequest) (*v1alpha1.GetAvailablePackageVersionsResponse, error)
+	// GetAvailablePackageTextAsset returns a range of a text asset of a package managed by the 'operators' plugin
+	GetAvailablePackageTextAsset(context.Context, *v1alpha1.GetAvailablePackageTextAssetRequest) (*v1alpha1.GetAvailablePackageTextAssetResponse, error)
+	// GetAvailablePackageChangelog returns the release notes between two versions of a package managed by the 'operators' plugin
+	GetAvailablePackageChangelog(context.Context, *v1alpha1.GetAvailablePackageChangelogRequest) (*v1alpha1.GetAvailablePackageChangelogResponse, error)
+	// GetAvailablePackageFilters returns the filter values of the available packages managed by the 'operators' plugin
+	GetAvailablePackageFilters(context.Context, *v1alpha1.GetAvailablePackageFiltersRequest) (*v1alpha1.GetAvailablePackageFiltersResponse, error)
+	// GetInstalledPackageSummaries returns the installed packages managed by the 'operators' plugin
+	GetInstalledPackageSummaries(context.Context, *v1alpha1.GetInstalledPackageSummariesRequest) (*v1alpha1.GetInstalledPackageSummariesResponse, error)
+	// GetInstalledPackageDetail returns the requested installed package managed by the 'operators' plugin
+	GetInstalledPackageDetail(context.Context, *v1alpha1.GetInstalledPackageDetailRequest) (*v1alpha1.GetInstalledPackageDetailResponse, error)
+	// CheckInstalledPackageNameAvailability checks whether a package managed by the 'operators' plugin can be installed with the given name
+	CheckInstalledPackageNameAvailability(context.Context, *v1alpha1.CheckInstalledPackageNameAvailabilityRequest) (*v1alpha1.CheckInstalledPackageNameAvailabilityResponse, error)
+	// CreateInstalledPackage creates an installed package based on the request.
+	CreateInstalledPackage(context.Context, *v1alpha1.CreateInstalledPackageRequest) (*v1alpha1.CreateInstalledPackageResponse, error)
+	// UpdateInstalledPackage updates an installed package based on the request.
+	UpdateInstalledPackage(context.Context, *v1alpha1.UpdateInstalledPackageRequest) (*v1alpha1.UpdateInstalledPackageResponse, error)
+	// DeleteInstalledPackage deletes an installed package based on the request.
+	DeleteInstalledPackage(context.Context, *v1alpha1.DeleteInstalledPackageRequest) (*v1alpha1.DeleteInstalledPackageResponse, error)
+	// GetInstalledPackageResourceRefs returns the references for the Kubernetes resources created by
+	// an installed package.
+	GetInstalledPackageResourceRefs(context.Context, *v1alpha1.GetInstalledPackageResourceRefsRequest) (*v1alpha1.GetInstalledPackageResourceRefsResponse, error)
+	// GetInstalledPackageOperationStatus returns the progress of the operation in course on an installed package managed by the 'operators' plugin
+	GetInstalledPackageOperationStatus(context.Context, *v1alpha1.GetInstalledPackageOperationStatusRequest) (*v1alpha1.GetInstalledPackageOperationStatusResponse, error)
+}
+
+// UnimplementedOperatorsPackagesServiceServer should be embedded to have forward compatible implementations.
+type UnimplementedOperatorsPackagesServiceServer struct {
+}
+
+func (UnimplementedOperatorsPackagesServiceServer) GetAvailablePackageSummaries(context.Context, *v1alpha1.GetAvailablePackageSummariesRequest) (*v1alpha1.GetAvailablePackageSummariesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAvailablePackageSummaries not implemented")
+}
+func (UnimplementedOperatorsPackagesServiceServer) GetAvailablePackageDetail(context.Context, *v1alpha1.GetAvailablePackageDetailRequest) (*v1alpha1.GetAvailablePackageDetailResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAvailablePackageDetail not implemented")
+}
+func (UnimplementedOperatorsPackagesServiceServer) GetAvailablePackageVersions(context.Context, *v1alpha1.GetAvailablePackageVersionsRequest) (*v1alpha1.GetAvailablePackageVersionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAvailablePackageVersions not implemented")
+}
+func (UnimplementedOperatorsPackagesServiceServer) GetAvailablePackageTextAsset(context.Context, *v1alpha1.GetAvailablePackageTextAssetRequest) (*v1alpha1.GetAvailablePackageTextAssetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAvailablePackageTextAsset not implemented")
+}
+func (UnimplementedOperatorsPackagesServiceServer) GetAvailablePackageChangelog(context.Context, *v1alpha1.GetAvailablePackageChangelogRequest) (*v1alpha1.GetAvailablePackageChangelogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAvailablePackageChangelog not implemented")
+}
+func (UnimplementedOperatorsPackagesServiceServer) GetAvailablePackageFilters(context.Context, *v1alpha1.GetAvailablePackageFiltersRequest) (*v1alpha1.GetAvailablePackageFiltersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAvailablePackageFilters not implemented")
+}
+func (UnimplementedOperatorsPackagesServiceServer) GetInstalledPackageSummaries(context.Context, *v1alpha1.GetInstalledPackageSummariesRequest) (*v1alpha1.GetInstalledPackageSummariesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInstalledPackageSummaries not implemented")
+}
+func (UnimplementedOperatorsPackagesServiceServer) GetInstalledPackageDetail(context.Context, *v1alpha1.GetInstalledPackageDetailRequest) (*v1alpha1.GetInstalledPackageDetailResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInstalledPackageDetail not implemented")
+}
+func (UnimplementedOperatorsPackagesServiceServer) CheckInstalledPackageNameAvailability(context.Context, *v1alpha1.CheckInstalledPackageNameAvailabilityRequest) (*v1alpha1.CheckInstalledPackageNameAvailabilityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckInstalledPackageNameAvailability not implemented")
+}
+func (UnimplementedOperatorsPackagesServiceServer) CreateInstalledPackage(context.Context, *v1alpha1.CreateInstalledPackageRequest) (*v1alpha1.CreateInstalledPackageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateInstalledPackage not implemented")
+}
+func (UnimplementedOperatorsPackagesServiceServer) UpdateInstalledPackage(context.Context, *v1alpha1.UpdateInstalledPackageRequest) (*v1alpha1.UpdateInstalledPackageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateInstalledPackage not implemented")
+}
+func (UnimplementedOperatorsPackagesServiceServer) DeleteInstalledPackage(context.Context, *v1alpha1.DeleteInstalledPackageRequest) (*v1alpha1.DeleteInstalledPackageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteInstalledPackage not implemented")
+}
+func (UnimplementedOperatorsPackagesServiceServer) GetInstalledPackageResourceRefs(context.Context, *v1alpha1.GetInstalledPackageResourceRefsRequest) (*v1alpha1.GetInstalledPackageResourceRefsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInstalledPackageResourceRefs not implemented")
+}
+func (UnimplementedOperatorsPackagesServiceServer) GetInstalledPackageOperationStatus(context.Context, *v1alpha1.GetInstalledPackageOperationStatusRequest) (*v1alpha1.GetInstalledPackageOperationStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInstalledPackageOperationStatus not implemented")
+}
+
+// UnsafeOperatorsPackagesServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to OperatorsPackagesServiceServer will
+// result in compilation errors.
+type UnsafeOperatorsPackagesServiceServer interface {
+	mustEmbedUnimplementedOperatorsPackagesServiceServer()
+}
+
+func RegisterOperatorsPackagesServiceServer(s grpc.ServiceRegistrar, srv OperatorsPackagesServiceServer) {
+	s.RegisterService(&OperatorsPackagesService_ServiceDesc, srv)
+}
+
+func _OperatorsPackagesService_GetAvailablePackageSummaries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha1.GetAvailablePackageSummariesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OperatorsPackagesServiceServer).GetAvailablePackageSummaries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OperatorsPackagesService_GetAvailablePackageSummaries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OperatorsPackagesServiceServer).GetAvailablePackageSummaries(ctx, req.(*v1alpha1.GetAvailablePackageSummariesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OperatorsPackagesService_GetAvailablePackageDetail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha1.GetAvailablePackageDetailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OperatorsPackagesServiceServer).GetAvailablePackageDetail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OperatorsPackagesService_GetAvailablePackageDetail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OperatorsPackagesServiceServer).GetAvailablePackageDetail(ctx, req.(*v1alpha1.GetAvailablePackageDetailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OperatorsPackagesService_GetAvailablePackageVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha1.GetAvailablePackageVersionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OperatorsPackagesServiceServer).GetAvailablePackageVersions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OperatorsPackagesService_GetAvailablePackageVersions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OperatorsPackagesServiceServer).GetAvailablePackageVersions(ctx, req.(*v1alpha1.GetAvailablePackageVersionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OperatorsPackagesService_GetAvailablePackageTextAsset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha1.GetAvailablePackageTextAssetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OperatorsPackagesServiceServer).GetAvailablePackageTextAsset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OperatorsPackagesService_GetAvailablePackageTextAsset_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OperatorsPackagesServiceServer).GetAvailablePackageTextAsset(ctx, req.(*v1alpha1.GetAvailablePackageTextAssetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OperatorsPackagesService_GetAvailablePackageChangelog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha1.GetAvailablePackageChangelogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OperatorsPackagesServiceServer).GetAvailablePackageChangelog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OperatorsPackagesService_GetAvailablePackageChangelog_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OperatorsPackagesServiceServer).GetAvailablePackageChangelog(ctx, req.(*v1alpha1.GetAvailablePackageChangelogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OperatorsPackagesService_GetAvailablePackageFilters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha1.GetAvailablePackageFiltersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OperatorsPackagesServiceServer).GetAvailablePackageFilters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OperatorsPackagesService_GetAvailablePackageFilters_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OperatorsPackagesServiceServer).GetAvailablePackageFilters(ctx, req.(*v1alpha1.GetAvailablePackageFiltersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OperatorsPackagesService_GetInstalledPackageSummaries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha1.GetInstalledPackageSummariesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OperatorsPackagesServiceServer).GetInstalledPackageSummaries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OperatorsPackagesService_GetInstalledPackageSummaries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OperatorsPackagesServiceServer).GetInstalledPackageSummaries(ctx, req.(*v1alpha1.GetInstalledPackageSummariesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OperatorsPackagesService_GetInstalledPackageDetail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha1.GetInstalledPackageDetailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OperatorsPackagesServiceServer).GetInstalledPackageDetail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OperatorsPackagesService_GetInstalledPackageDetail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OperatorsPackagesServiceServer).GetInstalledPackageDetail(ctx, req.(*v1alpha1.GetInstalledPackageDetailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OperatorsPackagesService_CheckInstalledPackageNameAvailability_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha1.CheckInstalledPackageNameAvailabilityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OperatorsPackagesServiceServer).CheckInstalledPackageNameAvailability(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OperatorsPackagesService_CheckInstalledPackageNameAvailability_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OperatorsPackagesServiceServer).CheckInstalledPackageNameAvailability(ctx, req.(*v1alpha1.CheckInstalledPackageNameAvailabilityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OperatorsPackagesService_CreateInstalledPackage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha1.CreateInstalledPackageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OperatorsPackagesServiceServer).CreateInstalledPackage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OperatorsPackagesService_CreateInstalledPackage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OperatorsPackagesServiceServer).CreateInstalledPackage(ctx, req.(*v1alpha1.CreateInstalledPackageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OperatorsPackagesService_UpdateInstalledPackage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha1.UpdateInstalledPackageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OperatorsPackagesServiceServer).UpdateInstalledPackage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OperatorsPackagesService_UpdateInstalledPackage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OperatorsPackagesServiceServer).UpdateInstalledPackage(ctx, req.(*v1alpha1.UpdateInstalledPackageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OperatorsPackagesService_DeleteInstalledPackage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha1.DeleteInstalledPackageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OperatorsPackagesServiceServer).DeleteInstalledPackage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OperatorsPackagesService_DeleteInstalledPackage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OperatorsPackagesServiceServer).DeleteInstalledPackage(ctx, req.(*v1alpha1.DeleteInstalledPackageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OperatorsPackagesService_GetInstalledPackageResourceRefs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha1.GetInstalledPackageResourceRefsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OperatorsPackagesServiceServer).GetInstalledPackageResourceRefs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OperatorsPackagesService_GetInstalledPackageResourceRefs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OperatorsPackagesServiceServer).GetInstalledPackageResourceRefs(ctx, req.(*v1alpha1.GetInstalledPackageResourceRefsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OperatorsPackagesService_GetInstalledPackageOperationStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha1.GetInstalledPackageOperationStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OperatorsPackagesServiceServer).GetInstalledPackageOperationStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OperatorsPackagesService_GetInstalledPackageOperationStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OperatorsPackagesServiceServer).GetInstalledPackageOperationStatus(ctx, req.(*v1alpha1.GetInstalledPackageOperationStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// OperatorsPackagesService_ServiceDesc is the grpc.ServiceDesc for OperatorsPackagesService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var OperatorsPackagesService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "kubeappsapis.plugins.operators.packages.v1alpha1.OperatorsPackagesService",
+	HandlerType: (*OperatorsPackagesServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetAvailablePackageSummaries",
+			Handler:    _OperatorsPackagesService_GetAvailablePackageSummaries_Handler,
+		},
+		{
+			MethodName: "GetAvailablePackageDetail",
+			Handler:    _OperatorsPackagesService_GetAvailablePackageDetail_Handler,
+		},
+		{
+			MethodName: "GetAvailablePackageVersions",
+			Handler:    _OperatorsPackagesService_GetAvailablePackageVersions_Handler,
+		},
+		{
+			MethodName: "GetAvailablePackageTextAsset",
+			Handler:    _OperatorsPackagesService_GetAvailablePackageTextAsset_Handler,
+		},
+		{
+			MethodName: "GetAvailablePackageChangelog",
+			Handler:    _OperatorsPackagesService_GetAvailablePackageChangelog_Handler,
+		},
+		{
+			MethodName: "GetAvailablePackageFilters",
+			Handler:    _OperatorsPackagesService_GetAvailablePackageFilters_Handler,
+		},
+		{
+			MethodName: "GetInstalledPackageSummaries",
+			Handler:    _OperatorsPackagesService_GetInstalledPackageSummaries_Handler,
+		},
+		{
+			MethodName: "GetInstalledPackageDetail",
+			Handler:    _OperatorsPackagesService_GetInstalledPackageDetail_Handler,
+		},
+		{
+			MethodName: "CheckInstalledPackageNameAvailability",
+			Handler:    _OperatorsPackagesService_CheckInstalledPackageNameAvailability_Handler,
+		},
+		{
+			MethodName: "CreateInstalledPackage",
+			Handler:    _OperatorsPackagesService_CreateInstalledPackage_Handler,
+		},
+		{
+			MethodName: "UpdateInstalledPackage",
+			Handler:    _OperatorsPackagesService_UpdateInstalledPackage_Handler,
+		},
+		{
+			MethodName: "DeleteInstalledPackage",
+			Handler:    _OperatorsPackagesService_DeleteInstalledPackage_Handler,
+		},
+		{
+			MethodName: "GetInstalledPackageResourceRefs",
+			Handler:    _OperatorsPackagesService_GetInstalledPackageResourceRefs_Handler,
+		},
+		{
+			MethodName: "GetInstalledPackageOperationStatus",
+			Handler:    _OperatorsPackagesService_GetInstalledPackageOperationStatus_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "kubeappsapis/plugins/operators/packages/v1alpha1/operators.proto",
+}
@@ -0,0 +1,507 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: kubeappsapis/plugins/operators/packages/v1alpha1/operators.proto
+
+package v1alpha1connect
+
+import (
+	context "context"
+	errors "errors"
+	connect_go "github.com/bufbuild/connect-go"
+	v1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	_ "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/plugins/operators/packages/v1alpha1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect_go.IsAtLeastVersion0_1_0
+
+const (
+	// OperatorsPackagesServiceName is the fully-qualified name of the OperatorsPackagesService service.
+	OperatorsPackagesServiceName = "kubeappsapis.plugins.operators.packages.v1alpha1.OperatorsPackagesService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// OperatorsPackagesServiceGetAvailablePackageSummariesProcedure is the fully-qualified name of the
+	// OperatorsPackagesService's GetAvailablePackageSummaries RPC.
+	OperatorsPackagesServiceGetAvailablePackageSummariesProcedure = "/kubeappsapis.plugins.operators.packages.v1alpha1.OperatorsPackagesService/GetAvailablePackageSummaries"
+	// OperatorsPackagesServiceGetAvailablePackageDetailProcedure is the fully-qualified name of the
+	// OperatorsPackagesService's GetAvailablePackageDetail RPC.
+	OperatorsPackagesServiceGetAvailablePackageDetailProcedure = "/kubeappsapis.plugins.operators.packages.v1alpha1.OperatorsPackagesService/GetAvailablePackageDetail"
+	// OperatorsPackagesServiceGetAvailablePackageVersionsProcedure is the fully-qualified name of the
+	// OperatorsPackagesService's GetAvailablePackageVersions RPC.
+	OperatorsPackagesServiceGetAvailablePackageVersionsProcedure = "/kubeappsapis.plugins.operators.packages.v1alpha1.OperatorsPackagesService/GetAvailablePackageVersions"
+	// OperatorsPackagesServiceGetAvailablePackageTextAssetProcedure is the fully-qualified name of the
+	// OperatorsPackagesService's GetAvailablePackageTextAsset RPC.
+	OperatorsPackagesServiceGetAvailablePackageTextAssetProcedure = "/kubeappsapis.plugins.operators.packages.v1alpha1.OperatorsPackagesService/GetAvailablePackageTextAsset"
+	// OperatorsPackagesServiceGetAvailablePackageChangelogProcedure is the fully-qualified name of the
+	// OperatorsPackagesService's GetAvailablePackageChangelog RPC.
+	OperatorsPackagesServiceGetAvailablePackageChangelogProcedure = "/kubeappsapis.plugins.operators.packages.v1alpha1.OperatorsPackagesService/GetAvailablePackageChangelog"
+	// OperatorsPackagesServiceGetAvailablePackageFiltersProcedure is the fully-qualified name of the
+	// OperatorsPackagesService's GetAvailablePackageFilters RPC.
+	OperatorsPackagesServiceGetAvailablePackageFiltersProcedure = "/kubeappsapis.plugins.operators.packages.v1alpha1.OperatorsPackagesService/GetAvailablePackageFilters"
+	// OperatorsPackagesServiceGetInstalledPackageSummariesProcedure is the fully-qualified name of the
+	// OperatorsPackagesService's GetInstalledPackageSummaries RPC.
+	OperatorsPackagesServiceGetInstalledPackageSummariesProcedure = "/kubeappsapis.plugins.operators.packages.v1alpha1.OperatorsPackagesService/GetInstalledPackageSummaries"
+	// OperatorsPackagesServiceGetInstalledPackageDetailProcedure is the fully-qualified name of the
+	// OperatorsPackagesService's GetInstalledPackageDetail RPC.
+	OperatorsPackagesServiceGetInstalledPackageDetailProcedure = "/kubeappsapis.plugins.operators.packages.v1alpha1.OperatorsPackagesService/GetInstalledPackageDetail"
+	// OperatorsPackagesServiceCheckInstalledPackageNameAvailabilityProcedure is the fully-qualified
+	// name of the OperatorsPackagesService's CheckInstalledPackageNameAvailability RPC.
+	OperatorsPackagesServiceCheckInstalledPackageNameAvailabilityProcedure = "/kubeappsapis.plugins.operators.packages.v1alpha1.OperatorsPackagesService/CheckInstalledPackageNameAvailability"
+	// OperatorsPackagesServiceCreateInstalledPackageProcedure is the fully-qualified name of the
+	// OperatorsPackagesService's CreateInstalledPackage RPC.
+	OperatorsPackagesServiceCreateInstalledPackageProcedure = "/kubeappsapis.plugins.operators.packages.v1alpha1.OperatorsPackagesService/CreateInstalledPackage"
+	// OperatorsPackagesServiceUpdateInstalledPackageProcedure is the fully-qualified name of the
+	// OperatorsPackagesService's UpdateInstalledPackage RPC.
+	OperatorsPackagesServiceUpdateInstalledPackageProcedure = "/kubeappsapis.plugins.operators.packages.v1alpha1.OperatorsPackagesService/UpdateInstalledPackage"
+	// OperatorsPackagesServiceDeleteInstalledPackageProcedure is the fully-qualified name of the
+	// OperatorsPackagesService's DeleteInstalledPackage RPC.
+	OperatorsPackagesServiceDeleteInstalledPackageProcedure = "/kubeappsapis.plugins.operators.packages.v1alpha1.OperatorsPackagesService/DeleteInstalledPackage"
+	// OperatorsPackagesServiceGetInstalledPackageResourceRefsProcedure is the fully-qualified name of
+	// the OperatorsPackagesService's GetInstalledPackageResourceRefs RPC.
+	OperatorsPackagesServiceGetInstalledPackageResourceRefsProcedure = "/kubeappsapis.plugins.operators.packages.v1alpha1.OperatorsPackagesService/GetInstalledPackageResourceRefs"
+	// OperatorsPackagesServiceGetInstalledPackageOperationStatusProcedure is the fully-qualified name
+	// of the OperatorsPackagesService's GetInstalledPackageOperationStatus RPC.
+	OperatorsPackagesServiceGetInstalledPackageOperationStatusProcedure = "/kubeappsapis.plugins.operators.packages.v1alpha1.OperatorsPackagesService/GetInstalledPackageOperationStatus"
+)
+
+// OperatorsPackagesServiceClient is a client for the
+// kubeappsapis.plugins.operators.packages.v1alpha1.OperatorsPackagesService service.
+type OperatorsPackagesServiceClient interface {
+	// GetAvailablePackageSummaries returns the available packages managed by the 'operators' plugin
+	GetAvailablePackageSummaries(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageSummariesRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageSummariesResponse], error)
+	// GetAvailablePackageDetail returns the package details managed by the 'operators' plugin
+	GetAvailablePackageDetail(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageDetailRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageDetailResponse], error)
+	// GetAvailablePackageVersions returns the package versions managed by the 'operators' plugin
+	GetAvailablePackageVersions(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageVersionsRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageVersionsResponse], error)
+	// GetAvailablePackageTextAsset returns a range of a text asset of a package managed by the 'operators' plugin
+	GetAvailablePackageTextAsset(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageTextAssetRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageTextAssetResponse], error)
+	// GetAvailablePackageChangelog returns the release notes between two versions of a package managed by the 'operators' plugin
+	GetAvailablePackageChangelog(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageChangelogRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageChangelogResponse], error)
+	// GetAvailablePackageFilters returns the filter values of the available packages managed by the 'operators' plugin
+	GetAvailablePackageFilters(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageFiltersRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageFiltersResponse], error)
+	// GetInstalledPackageSummaries returns the installed packages managed by the 'operators' plugin
+	GetInstalledPackageSummaries(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageSummariesRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageSummariesResponse], error)
+	// GetInstalledPackageDetail returns the requested installed package managed by the 'operators' plugin
+	GetInstalledPackageDetail(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageDetailRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageDetailResponse], error)
+	// CheckInstalledPackageNameAvailability checks whether a package managed by the 'operators' plugin can be installed with the given name
+	CheckInstalledPackageNameAvailability(context.Context, *connect_go.Request[v1alpha1.CheckInstalledPackageNameAvailabilityRequest]) (*connect_go.Response[v1alpha1.CheckInstalledPackageNameAvailabilityResponse], error)
+	// CreateInstalledPackage creates an installed package based on the request.
+	CreateInstalledPackage(context.Context, *connect_go.Request[v1alpha1.CreateInstalledPackageRequest]) (*connect_go.Response[v1alpha1.CreateInstalledPackageResponse], error)
+	// UpdateInstalledPackage updates an installed package based on the request.
+	UpdateInstalledPackage(context.Context, *connect_go.Request[v1alpha1.UpdateInstalledPackageRequest]) (*connect_go.Response[v1alpha1.UpdateInstalledPackageResponse], error)
+	// DeleteInstalledPackage deletes an installed package based on the request.
+	DeleteInstalledPackage(context.Context, *connect_go.Request[v1alpha1.DeleteInstalledPackageRequest]) (*connect_go.Response[v1alpha1.DeleteInstalledPackageResponse], error)
+	// GetInstalledPackageResourceRefs returns the references for the Kubernetes resources created by
+	// an installed package.
+	GetInstalledPackageResourceRefs(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageResourceRefsRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageResourceRefsResponse], error)
+	// GetInstalledPackageOperationStatus returns the progress of the operation in course on an installed package managed by the 'operators' plugin
+	GetInstalledPackageOperationStatus(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageOperationStatusRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageOperationStatusResponse], error)
+}
+
+// NewOperatorsPackagesServiceClient constructs a client for the
+// kubeappsapis.plugins.operators.packages.v1alpha1.OperatorsPackagesService service. By default, it
+// uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses, and sends
+// uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC() or
+// connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewOperatorsPackagesServiceClient(httpClient connect_go.HTTPClient, baseURL string, opts ...connect_go.ClientOption) OperatorsPackagesServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	return &operatorsPackagesServiceClient{
+		getAvailablePackageSummaries: connect_go.NewClient[v1alpha1.GetAvailablePackageSummariesRequest, v1alpha1.GetAvailablePackageSummariesResponse](
+			httpClient,
+			baseURL+OperatorsPackagesServiceGetAvailablePackageSummariesProcedure,
+			opts...,
+		),
+		getAvailablePackageDetail: connect_go.NewClient[v1alpha1.GetAvailablePackageDetailRequest, v1alpha1.GetAvailablePackageDetailResponse](
+			httpClient,
+			baseURL+OperatorsPackagesServiceGetAvailablePackageDetailProcedure,
+			opts...,
+		),
+		getAvailablePackageVersions: connect_go.NewClient[v1alpha1.GetAvailablePackageVersionsRequest, v1alpha1.GetAvailablePackageVersionsResponse](
+			httpClient,
+			baseURL+OperatorsPackagesServiceGetAvailablePackageVersionsProcedure,
+			opts...,
+		),
+		getAvailablePackageTextAsset: connect_go.NewClient[v1alpha1.GetAvailablePackageTextAssetRequest, v1alpha1.GetAvailablePackageTextAssetResponse](
+			httpClient,
+			baseURL+OperatorsPackagesServiceGetAvailablePackageTextAssetProcedure,
+			opts...,
+		),
+		getAvailablePackageChangelog: connect_go.NewClient[v1alpha1.GetAvailablePackageChangelogRequest, v1alpha1.GetAvailablePackageChangelogResponse](
+			httpClient,
+			baseURL+OperatorsPackagesServiceGetAvailablePackageChangelogProcedure,
+			opts...,
+		),
+		getAvailablePackageFilters: connect_go.NewClient[v1alpha1.GetAvailablePackageFiltersRequest, v1alpha1.GetAvailablePackageFiltersResponse](
+			httpClient,
+			baseURL+OperatorsPackagesServiceGetAvailablePackageFiltersProcedure,
+			opts...,
+		),
+		getInstalledPackageSummaries: connect_go.NewClient[v1alpha1.GetInstalledPackageSummariesRequest, v1alpha1.GetInstalledPackageSummariesResponse](
+			httpClient,
+			baseURL+OperatorsPackagesServiceGetInstalledPackageSummariesProcedure,
+			opts...,
+		),
+		getInstalledPackageDetail: connect_go.NewClient[v1alpha1.GetInstalledPackageDetailRequest, v1alpha1.GetInstalledPackageDetailResponse](
+			httpClient,
+			baseURL+OperatorsPackagesServiceGetInstalledPackageDetailProcedure,
+			opts...,
+		),
+		checkInstalledPackageNameAvailability: connect_go.NewClient[v1alpha1.CheckInstalledPackageNameAvailabilityRequest, v1alpha1.CheckInstalledPackageNameAvailabilityResponse](
+			httpClient,
+			baseURL+OperatorsPackagesServiceCheckInstalledPackageNameAvailabilityProcedure,
+			opts...,
+		),
+		createInstalledPackage: connect_go.NewClient[v1alpha1.CreateInstalledPackageRequest, v1alpha1.CreateInstalledPackageResponse](
+			httpClient,
+			baseURL+OperatorsPackagesServiceCreateInstalledPackageProcedure,
+			opts...,
+		),
+		updateInstalledPackage: connect_go.NewClient[v1alpha1.UpdateInstalledPackageRequest, v1alpha1.UpdateInstalledPackageResponse](
+			httpClient,
+			baseURL+OperatorsPackagesServiceUpdateInstalledPackageProcedure,
+			opts...,
+		),
+		deleteInstalledPackage: connect_go.NewClient[v1alpha1.DeleteInstalledPackageRequest, v1alpha1.DeleteInstalledPackageResponse](
+			httpClient,
+			baseURL+OperatorsPackagesServiceDeleteInstalledPackageProcedure,
+			opts...,
+		),
+		getInstalledPackageResourceRefs: connect_go.NewClient[v1alpha1.GetInstalledPackageResourceRefsRequest, v1alpha1.GetInstalledPackageResourceRefsResponse](
+			httpClient,
+			baseURL+OperatorsPackagesServiceGetInstalledPackageResourceRefsProcedure,
+			opts...,
+		),
+		getInstalledPackageOperationStatus: connect_go.NewClient[v1alpha1.GetInstalledPackageOperationStatusRequest, v1alpha1.GetInstalledPackageOperationStatusResponse](
+			httpClient,
+			baseURL+OperatorsPackagesServiceGetInstalledPackageOperationStatusProcedure,
+			opts...,
+		),
+	}
+}
+
+// operatorsPackagesServiceClient implements OperatorsPackagesServiceClient.
+type operatorsPackagesServiceClient struct {
+	getAvailablePackageSummaries          *connect_go.Client[v1alpha1.GetAvailablePackageSummariesRequest, v1alpha1.GetAvailablePackageSummariesResponse]
+	getAvailablePackageDetail             *connect_go.Client[v1alpha1.GetAvailablePackageDetailRequest, v1alpha1.GetAvailablePackageDetailResponse]
+	getAvailablePackageVersions           *connect_go.Client[v1alpha1.GetAvailablePackageVersionsRequest, v1alpha1.GetAvailablePackageVersionsResponse]
+	getAvailablePackageTextAsset          *connect_go.Client[v1alpha1.GetAvailablePackageTextAssetRequest, v1alpha1.GetAvailablePackageTextAssetResponse]
+	getAvailablePackageChangelog          *connect_go.Client[v1alpha1.GetAvailablePackageChangelogRequest, v1alpha1.GetAvailablePackageChangelogResponse]
+	getAvailablePackageFilters            *connect_go.Client[v1alpha1.GetAvailablePackageFiltersRequest, v1alpha1.GetAvailablePackageFiltersResponse]
+	getInstalledPackageSummaries          *connect_go.Client[v1alpha1.GetInstalledPackageSummariesRequest, v1alpha1.GetInstalledPackageSummariesResponse]
+	getInstalledPackageDetail             *connect_go.Client[v1alpha1.GetInstalledPackageDetailRequest, v1alpha1.GetInstalledPackageDetailResponse]
+	checkInstalledPackageNameAvailability *connect_go.Client[v1alpha1.CheckInstalledPackageNameAvailabilityRequest, v1alpha1.CheckInstalledPackageNameAvailabilityResponse]
+	createInstalledPackage                *connect_go.Client[v1alpha1.CreateInstalledPackageRequest, v1alpha1.CreateInstalledPackageResponse]
+	updateInstalledPackage                *connect_go.Client[v1alpha1.UpdateInstalledPackageRequest, v1alpha1.UpdateInstalledPackageResponse]
+	deleteInstalledPackage                *connect_go.Client[v1alpha1.DeleteInstalledPackageRequest, v1alpha1.DeleteInstalledPackageResponse]
+	getInstalledPackageResourceRefs       *connect_go.Client[v1alpha1.GetInstalledPackageResourceRefsRequest, v1alpha1.GetInstalledPackageResourceRefsResponse]
+	getInstalledPackageOperationStatus    *connect_go.Client[v1alpha1.GetInstalledPackageOperationStatusRequest, v1alpha1.GetInstalledPackageOperationStatusResponse]
+}
+
+// GetAvailablePackageSummaries calls
+// kubeappsapis.plugins.operators.packages.v1alpha1.OperatorsPackagesService.GetAvailablePackageSummaries.
+func (c *operatorsPackagesServiceClient) GetAvailablePackageSummaries(ctx context.Context, req *connect_go.Request[v1alpha1.GetAvailablePackageSummariesRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageSummariesResponse], error) {
+	return c.getAvailablePackageSummaries.CallUnary(ctx, req)
+}
+
+// GetAvailablePackageDetail calls
+// kubeappsapis.plugins.operators.packages.v1alpha1.OperatorsPackagesService.GetAvailablePackageDetail.
+func (c *operatorsPackagesServiceClient) GetAvailablePackageDetail(ctx context.Context, req *connect_go.Request[v1alpha1.GetAvailablePackageDetailRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageDetailResponse], error) {
+	return c.getAvailablePackageDetail.CallUnary(ctx, req)
+}
+
+// GetAvailablePackageVersions calls
+// kubeappsapis.plugins.operators.packages.v1alpha1.OperatorsPackagesService.GetAvailablePackageVersions.
+func (c *operatorsPackagesServiceClient) GetAvailablePackageVersions(ctx context.Context, req *connect_go.Request[v1alpha1.GetAvailablePackageVersionsRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageVersionsResponse], error) {
+	return c.getAvailablePackageVersions.CallUnary(ctx, req)
+}
+
+// GetAvailablePackageTextAsset calls
+// kubeappsapis.plugins.operators.packages.v1alpha1.OperatorsPackagesService.GetAvailablePackageTextAsset.
+func (c *operatorsPackagesServiceClient) GetAvailablePackageTextAsset(ctx context.Context, req *connect_go.Request[v1alpha1.GetAvailablePackageTextAssetRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageTextAssetResponse], error) {
+	return c.getAvailablePackageTextAsset.CallUnary(ctx, req)
+}
+
+// GetAvailablePackageChangelog calls
+// kubeappsapis.plugins.operators.packages.v1alpha1.OperatorsPackagesService.GetAvailablePackageChangelog.
+func (c *operatorsPackagesServiceClient) GetAvailablePackageChangelog(ctx context.Context, req *connect_go.Request[v1alpha1.GetAvailablePackageChangelogRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageChangelogResponse], error) {
+	return c.getAvailablePackageChangelog.CallUnary(ctx, req)
+}
+
+// GetAvailablePackageFilters calls
+// kubeappsapis.plugins.operators.packages.v1alpha1.OperatorsPackagesService.GetAvailablePackageFilters.
+func (c *operatorsPackagesServiceClient) GetAvailablePackageFilters(ctx context.Context, req *connect_go.Request[v1alpha1.GetAvailablePackageFiltersRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageFiltersResponse], error) {
+	return c.getAvailablePackageFilters.CallUnary(ctx, req)
+}
+
+// GetInstalledPackageSummaries calls
+// kubeappsapis.plugins.operators.packages.v1alpha1.OperatorsPackagesService.GetInstalledPackageSummaries.
+func (c *operatorsPackagesServiceClient) GetInstalledPackageSummaries(ctx context.Context, req *connect_go.Request[v1alpha1.GetInstalledPackageSummariesRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageSummariesResponse], error) {
+	return c.getInstalledPackageSummaries.CallUnary(ctx, req)
+}
+
+// GetInstalledPackageDetail calls
+// kubeappsapis.plugins.operators.packages.v1alpha1.OperatorsPackagesService.GetInstalledPackageDetail.
+func (c *operatorsPackagesServiceClient) GetInstalledPackageDetail(ctx context.Context, req *connect_go.Request[v1alpha1.GetInstalledPackageDetailRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageDetailResponse], error) {
+	return c.getInstalledPackageDetail.CallUnary(ctx, req)
+}
+
+// CheckInstalledPackageNameAvailability calls
+// kubeappsapis.plugins.operators.packages.v1alpha1.OperatorsPackagesService.CheckInstalledPackageNameAvailability.
+func (c *operatorsPackagesServiceClient) CheckInstalledPackageNameAvailability(ctx context.Context, req *connect_go.Request[v1alpha1.CheckInstalledPackageNameAvailabilityRequest]) (*connect_go.Response[v1alpha1.CheckInstalledPackageNameAvailabilityResponse], error) {
+	return c.checkInstalledPackageNameAvailability.CallUnary(ctx, req)
+}
+
+// CreateInstalledPackage calls
+// kubeappsapis.plugins.operators.packages.v1alpha1.OperatorsPackagesService.CreateInstalledPackage.
+func (c *operatorsPackagesServiceClient) CreateInstalledPackage(ctx context.Context, req *connect_go.Request[v1alpha1.CreateInstalledPackageRequest]) (*connect_go.Response[v1alpha1.CreateInstalledPackageResponse], error) {
+	return c.createInstalledPackage.CallUnary(ctx, req)
+}
+
+// UpdateInstalledPackage calls
+// kubeappsapis.plugins.operators.packages.v1alpha1.OperatorsPackagesService.UpdateInstalledPackage.
+func (c *operatorsPackagesServiceClient) UpdateInstalledPackage(ctx context.Context, req *connect_go.Request[v1alpha1.UpdateInstalledPackageRequest]) (*connect_go.Response[v1alpha1.UpdateInstalledPackageResponse], error) {
+	return c.updateInstalledPackage.CallUnary(ctx, req)
+}
+
+// DeleteInstalledPackage calls
+// kubeappsapis.plugins.operators.packages.v1alpha1.OperatorsPackagesService.DeleteInstalledPackage.
+func (c *operatorsPackagesServiceClient) DeleteInstalledPackage(ctx context.Context, req *connect_go.Request[v1alpha1.DeleteInstalledPackageRequest]) (*connect_go.Response[v1alpha1.DeleteInstalledPackageResponse], error) {
+	return c.deleteInstalledPackage.CallUnary(ctx, req)
+}
+
+// GetInstalledPackageResourceRefs calls
+// kubeappsapis.plugins.operators.packages.v1alpha1.OperatorsPackagesService.GetInstalledPackageResourceRefs.
+func (c *operatorsPackagesServiceClient) GetInstalledPackageResourceRefs(ctx context.Context, req *connect_go.Request[v1alpha1.GetInstalledPackageResourceRefsRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageResourceRefsResponse], error) {
+	return c.getInstalledPackageResourceRefs.CallUnary(ctx, req)
+}
+
+// GetInstalledPackageOperationStatus calls
+// kubeappsapis.plugins.operators.packages.v1alpha1.OperatorsPackagesService.GetInstalledPackageOperationStatus.
+func (c *operatorsPackagesServiceClient) GetInstalledPackageOperationStatus(ctx context.Context, req *connect_go.Request[v1alpha1.GetInstalledPackageOperationStatusRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageOperationStatusResponse], error) {
+	return c.getInstalledPackageOperationStatus.CallUnary(ctx, req)
+}
+
+// OperatorsPackagesServiceHandler is an implementation of the
+// kubeappsapis.plugins.operators.packages.v1alpha1.OperatorsPackagesService service.
+type OperatorsPackagesServiceHandler interface {
+	// GetAvailablePackageSummaries returns the available packages managed by the 'operators' plugin
+	GetAvailablePackageSummaries(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageSummariesRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageSummariesResponse], error)
+	// GetAvailablePackageDetail returns the package details managed by the 'operators' plugin
+	GetAvailablePackageDetail(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageDetailRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageDetailResponse], error)
+	// GetAvailablePackageVersions returns the package versions managed by the 'operators' plugin
+	GetAvailablePackageVersions(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageVersionsRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageVersionsResponse], error)
+	// GetAvailablePackageTextAsset returns a range of a text asset of a package managed by the 'operators' plugin
+	GetAvailablePackageTextAsset(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageTextAssetRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageTextAssetResponse], error)
+	// GetAvailablePackageChangelog returns the release notes between two versions of a package managed by the 'operators' plugin
+	GetAvailablePackageChangelog(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageChangelogRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageChangelogResponse], error)
+	// GetAvailablePackageFilters returns the filter values of the available packages managed by the 'operators' plugin
+	GetAvailablePackageFilters(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageFiltersRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageFiltersResponse], error)
+	// GetInstalledPackageSummaries returns the installed packages managed by the 'operators' plugin
+	GetInstalledPackageSummaries(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageSummariesRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageSummariesResponse], error)
+	// GetInstalledPackageDetail returns the requested installed package managed by the 'operators' plugin
+	GetInstalledPackageDetail(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageDetailRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageDetailResponse], error)
+	// CheckInstalledPackageNameAvailability checks whether a package managed by the 'operators' plugin can be installed with the given name
+	CheckInstalledPackageNameAvailability(context.Context, *connect_go.Request[v1alpha1.CheckInstalledPackageNameAvailabilityRequest]) (*connect_go.Response[v1alpha1.CheckInstalledPackageNameAvailabilityResponse], error)
+	// CreateInstalledPackage creates an installed package based on the request.
+	CreateInstalledPackage(context.Context, *connect_go.Request[v1alpha1.CreateInstalledPackageRequest]) (*connect_go.Response[v1alpha1.CreateInstalledPackageResponse], error)
+	// UpdateInstalledPackage updates an installed package based on the request.
+	UpdateInstalledPackage(context.Context, *connect_go.Request[v1alpha1.UpdateInstalledPackageRequest]) (*connect_go.Response[v1alpha1.UpdateInstalledPackageResponse], error)
+	// DeleteInstalledPackage deletes an installed package based on the request.
+	DeleteInstalledPackage(context.Context, *connect_go.Request[v1alpha1.DeleteInstalledPackageRequest]) (*connect_go.Response[v1alpha1.DeleteInstalledPackageResponse], error)
+	// GetInstalledPackageResourceRefs returns the references for the Kubernetes resources created by
+	// an installed package.
+	GetInstalledPackageResourceRefs(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageResourceRefsRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageResourceRefsResponse], error)
+	// GetInstalledPackageOperationStatus returns the progress of the operation in course on an installed package managed by the 'operators' plugin
+	GetInstalledPackageOperationStatus(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageOperationStatusRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageOperationStatusResponse], error)
+}
+
+// NewOperatorsPackagesServiceHandler builds an HTTP handler from the service implementation. It
+// returns the path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewOperatorsPackagesServiceHandler(svc OperatorsPackagesServiceHandler, opts ...connect_go.HandlerOption) (string, http.Handler) {
+	operatorsPackagesServiceGetAvailablePackageSummariesHandler := connect_go.NewUnaryHandler(
+		OperatorsPackagesServiceGetAvailablePackageSummariesProcedure,
+		svc.GetAvailablePackageSummaries,
+		opts...,
+	)
+	operatorsPackagesServiceGetAvailablePackageDetailHandler := connect_go.NewUnaryHandler(
+		OperatorsPackagesServiceGetAvailablePackageDetailProcedure,
+		svc.GetAvailablePackageDetail,
+		opts...,
+	)
+	operatorsPackagesServiceGetAvailablePackageVersionsHandler := connect_go.NewUnaryHandler(
+		OperatorsPackagesServiceGetAvailablePackageVersionsProcedure,
+		svc.GetAvailablePackageVersions,
+		opts...,
+	)
+	operatorsPackagesServiceGetAvailablePackageTextAssetHandler := connect_go.NewUnaryHandler(
+		OperatorsPackagesServiceGetAvailablePackageTextAssetProcedure,
+		svc.GetAvailablePackageTextAsset,
+		opts...,
+	)
+	operatorsPackagesServiceGetAvailablePackageChangelogHandler := connect_go.NewUnaryHandler(
+		OperatorsPackagesServiceGetAvailablePackageChangelogProcedure,
+		svc.GetAvailablePackageChangelog,
+		opts...,
+	)
+	operatorsPackagesServiceGetAvailablePackageFiltersHandler := connect_go.NewUnaryHandler(
+		OperatorsPackagesServiceGetAvailablePackageFiltersProcedure,
+		svc.GetAvailablePackageFilters,
+		opts...,
+	)
+	operatorsPackagesServiceGetInstalledPackageSummariesHandler := connect_go.NewUnaryHandler(
+		OperatorsPackagesServiceGetInstalledPackageSummariesProcedure,
+		svc.GetInstalledPackageSummaries,
+		opts...,
+	)
+	operatorsPackagesServiceGetInstalledPackageDetailHandler := connect_go.NewUnaryHandler(
+		OperatorsPackagesServiceGetInstalledPackageDetailProcedure,
+		svc.GetInstalledPackageDetail,
+		opts...,
+	)
+	operatorsPackagesServiceCheckInstalledPackageNameAvailabilityHandler := connect_go.NewUnaryHandler(
+		OperatorsPackagesServiceCheckInstalledPackageNameAvailabilityProcedure,
+		svc.CheckInstalledPackageNameAvailability,
+		opts...,
+	)
+	operatorsPackagesServiceCreateInstalledPackageHandler := connect_go.NewUnaryHandler(
+		OperatorsPackagesServiceCreateInstalledPackageProcedure,
+		svc.CreateInstalledPackage,
+		opts...,
+	)
+	operatorsPackagesServiceUpdateInstalledPackageHandler := connect_go.NewUnaryHandler(
+		OperatorsPackagesServiceUpdateInstalledPackageProcedure,
+		svc.UpdateInstalledPackage,
+		opts...,
+	)
+	operatorsPackagesServiceDeleteInstalledPackageHandler := connect_go.NewUnaryHandler(
+		OperatorsPackagesServiceDeleteInstalledPackageProcedure,
+		svc.DeleteInstalledPackage,
+		opts...,
+	)
+	operatorsPackagesServiceGetInstalledPackageResourceRefsHandler := connect_go.NewUnaryHandler(
+		OperatorsPackagesServiceGetInstalledPackageResourceRefsProcedure,
+		svc.GetInstalledPackageResourceRefs,
+		opts...,
+	)
+	operatorsPackagesServiceGetInstalledPackageOperationStatusHandler := connect_go.NewUnaryHandler(
+		OperatorsPackagesServiceGetInstalledPackageOperationStatusProcedure,
+		svc.GetInstalledPackageOperationStatus,
+		opts...,
+	)
+	return "/kubeappsapis.plugins.operators.packages.v1alpha1.OperatorsPackagesService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case OperatorsPackagesServiceGetAvailablePackageSummariesProcedure:
+			operatorsPackagesServiceGetAvailablePackageSummariesHandler.ServeHTTP(w, r)
+		case OperatorsPackagesServiceGetAvailablePackageDetailProcedure:
+			operatorsPackagesServiceGetAvailablePackageDetailHandler.ServeHTTP(w, r)
+		case OperatorsPackagesServiceGetAvailablePackageVersionsProcedure:
+			operatorsPackagesServiceGetAvailablePackageVersionsHandler.ServeHTTP(w, r)
+		case OperatorsPackagesServiceGetAvailablePackageTextAssetProcedure:
+			operatorsPackagesServiceGetAvailablePackageTextAssetHandler.ServeHTTP(w, r)
+		case OperatorsPackagesServiceGetAvailablePackageChangelogProcedure:
+			operatorsPackagesServiceGetAvailablePackageChangelogHandler.ServeHTTP(w, r)
+		case OperatorsPackagesServiceGetAvailablePackageFiltersProcedure:
+			operatorsPackagesServiceGetAvailablePackageFiltersHandler.ServeHTTP(w, r)
+		case OperatorsPackagesServiceGetInstalledPackageSummariesProcedure:
+			operatorsPackagesServiceGetInstalledPackageSummariesHandler.ServeHTTP(w, r)
+		case OperatorsPackagesServiceGetInstalledPackageDetailProcedure:
+			operatorsPackagesServiceGetInstalledPackageDetailHandler.ServeHTTP(w, r)
+		case OperatorsPackagesServiceCheckInstalledPackageNameAvailabilityProcedure:
+			operatorsPackagesServiceCheckInstalledPackageNameAvailabilityHandler.ServeHTTP(w, r)
+		case OperatorsPackagesServiceCreateInstalledPackageProcedure:
+			operatorsPackagesServiceCreateInstalledPackageHandler.ServeHTTP(w, r)
+		case OperatorsPackagesServiceUpdateInstalledPackageProcedure:
+			operatorsPackagesServiceUpdateInstalledPackageHandler.ServeHTTP(w, r)
+		case OperatorsPackagesServiceDeleteInstalledPackageProcedure:
+			operatorsPackagesServiceDeleteInstalledPackageHandler.ServeHTTP(w, r)
+		case OperatorsPackagesServiceGetInstalledPackageResourceRefsProcedure:
+			operatorsPackagesServiceGetInstalledPackageResourceRefsHandler.ServeHTTP(w, r)
+		case OperatorsPackagesServiceGetInstalledPackageOperationStatusProcedure:
+			operatorsPackagesServiceGetInstalledPackageOperationStatusHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedOperatorsPackagesServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedOperatorsPackagesServiceHandler struct{}
+
+func (UnimplementedOperatorsPackagesServiceHandler) GetAvailablePackageSummaries(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageSummariesRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageSummariesResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.operators.packages.v1alpha1.OperatorsPackagesService.GetAvailablePackageSummaries is not implemented"))
+}
+
+func (UnimplementedOperatorsPackagesServiceHandler) GetAvailablePackageDetail(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageDetailRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageDetailResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.operators.packages.v1alpha1.OperatorsPackagesService.GetAvailablePackageDetail is not implemented"))
+}
+
+func (UnimplementedOperatorsPackagesServiceHandler) GetAvailablePackageVersions(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageVersionsRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageVersionsResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.operators.packages.v1alpha1.OperatorsPackagesService.GetAvailablePackageVersions is not implemented"))
+}
+
+func (UnimplementedOperatorsPackagesServiceHandler) GetAvailablePackageTextAsset(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageTextAssetRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageTextAssetResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.operators.packages.v1alpha1.OperatorsPackagesService.GetAvailablePackageTextAsset is not implemented"))
+}
+
+func (UnimplementedOperatorsPackagesServiceHandler) GetAvailablePackageChangelog(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageChangelogRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageChangelogResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.operators.packages.v1alpha1.OperatorsPackagesService.GetAvailablePackageChangelog is not implemented"))
+}
+
+func (UnimplementedOperatorsPackagesServiceHandler) GetAvailablePackageFilters(context.Context, *connect_go.Request[v1alpha1.GetAvailablePackageFiltersRequest]) (*connect_go.Response[v1alpha1.GetAvailablePackageFiltersResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.operators.packages.v1alpha1.OperatorsPackagesService.GetAvailablePackageFilters is not implemented"))
+}
+
+func (UnimplementedOperatorsPackagesServiceHandler) GetInstalledPackageSummaries(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageSummariesRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageSummariesResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.operators.packages.v1alpha1.OperatorsPackagesService.GetInstalledPackageSummaries is not implemented"))
+}
+
+func (UnimplementedOperatorsPackagesServiceHandler) GetInstalledPackageDetail(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageDetailRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageDetailResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.operators.packages.v1alpha1.OperatorsPackagesService.GetInstalledPackageDetail is not implemented"))
+}
+
+func (UnimplementedOperatorsPackagesServiceHandler) CheckInstalledPackageNameAvailability(context.Context, *connect_go.Request[v1alpha1.CheckInstalledPackageNameAvailabilityRequest]) (*connect_go.Response[v1alpha1.CheckInstalledPackageNameAvailabilityResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.operators.packages.v1alpha1.OperatorsPackagesService.CheckInstalledPackageNameAvailability is not implemented"))
+}
+
+func (UnimplementedOperatorsPackagesServiceHandler) CreateInstalledPackage(context.Context, *connect_go.Request[v1alpha1.CreateInstalledPackageRequest]) (*connect_go.Response[v1alpha1.CreateInstalledPackageResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.operators.packages.v1alpha1.OperatorsPackagesService.CreateInstalledPackage is not implemented"))
+}
+
+func (UnimplementedOperatorsPackagesServiceHandler) UpdateInstalledPackage(context.Context, *connect_go.Request[v1alpha1.UpdateInstalledPackageRequest]) (*connect_go.Response[v1alpha1.UpdateInstalledPackageResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.operators.packages.v1alpha1.OperatorsPackagesService.UpdateInstalledPackage is not implemented"))
+}
+
+func (UnimplementedOperatorsPackagesServiceHandler) DeleteInstalledPackage(context.Context, *connect_go.Request[v1alpha1.DeleteInstalledPackageRequest]) (*connect_go.Response[v1alpha1.DeleteInstalledPackageResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.operators.packages.v1alpha1.OperatorsPackagesService.DeleteInstalledPackage is not implemented"))
+}
+
+func (UnimplementedOperatorsPackagesServiceHandler) GetInstalledPackageResourceRefs(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageResourceRefsRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageResourceRefsResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.operators.packages.v1alpha1.OperatorsPackagesService.GetInstalledPackageResourceRefs is not implemented"))
+}
+
+func (UnimplementedOperatorsPackagesServiceHandler) GetInstalledPackageOperationStatus(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageOperationStatusRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageOperationStatusResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.operators.packages.v1alpha1.OperatorsPackagesService.GetInstalledPackageOperationStatus is not implemented"))
+}
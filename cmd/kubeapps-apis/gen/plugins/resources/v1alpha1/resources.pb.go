@@ -26,6 +26,119 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// RolloutStatus
+//
+// The rollout status of a single workload.
+type RolloutStatus int32
+
+const (
+	RolloutStatus_ROLLOUT_STATUS_UNSPECIFIED RolloutStatus = 0
+	RolloutStatus_ROLLOUT_STATUS_IN_PROGRESS RolloutStatus = 1
+	RolloutStatus_ROLLOUT_STATUS_SUCCEEDED   RolloutStatus = 2
+	RolloutStatus_ROLLOUT_STATUS_FAILED      RolloutStatus = 3
+)
+
+// Enum value maps for RolloutStatus.
+var (
+	RolloutStatus_name = map[int32]string{
+		0: "ROLLOUT_STATUS_UNSPECIFIED",
+		1: "ROLLOUT_STATUS_IN_PROGRESS",
+		2: "ROLLOUT_STATUS_SUCCEEDED",
+		3: "ROLLOUT_STATUS_FAILED",
+	}
+	RolloutStatus_value = map[string]int32{
+		"ROLLOUT_STATUS_UNSPECIFIED": 0,
+		"ROLLOUT_STATUS_IN_PROGRESS": 1,
+		"ROLLOUT_STATUS_SUCCEEDED":   2,
+		"ROLLOUT_STATUS_FAILED":      3,
+	}
+)
+
+func (x RolloutStatus) Enum() *RolloutStatus {
+	p := new(RolloutStatus)
+	*p = x
+	return p
+}
+
+func (x RolloutStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RolloutStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_enumTypes[0].Descriptor()
+}
+
+func (RolloutStatus) Type() protoreflect.EnumType {
+	return &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_enumTypes[0]
+}
+
+func (x RolloutStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RolloutStatus.Descriptor instead.
+func (RolloutStatus) EnumDescriptor() ([]byte, []int) {
+	return file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_rawDescGZIP(), []int{0}
+}
+
+// ManifestSource
+//
+// The source of the exported manifests of the resources.
+type ManifestSource int32
+
+const (
+	// The live state of the resources on the cluster, without the fields
+	// populated by the API server (the status, the managed fields, the uid,
+	// resource version, generation and creation timestamp) so that the
+	// manifests can be diffed or applied elsewhere.
+	ManifestSource_MANIFEST_SOURCE_LIVE_UNSPECIFIED ManifestSource = 0
+	// The configuration last applied to the resources, as rendered in the
+	// deployed revision of the Helm release owning them or as recorded in their
+	// `kubectl.kubernetes.io/last-applied-configuration` (kubectl) or
+	// `kapp.k14s.io/original` (kapp) annotations. The resources without such
+	// record are skipped.
+	ManifestSource_MANIFEST_SOURCE_LAST_APPLIED ManifestSource = 1
+)
+
+// Enum value maps for ManifestSource.
+var (
+	ManifestSource_name = map[int32]string{
+		0: "MANIFEST_SOURCE_LIVE_UNSPECIFIED",
+		1: "MANIFEST_SOURCE_LAST_APPLIED",
+	}
+	ManifestSource_value = map[string]int32{
+		"MANIFEST_SOURCE_LIVE_UNSPECIFIED": 0,
+		"MANIFEST_SOURCE_LAST_APPLIED":     1,
+	}
+)
+
+func (x ManifestSource) Enum() *ManifestSource {
+	p := new(ManifestSource)
+	*p = x
+	return p
+}
+
+func (x ManifestSource) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ManifestSource) Descriptor() protoreflect.EnumDescriptor {
+	return file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_enumTypes[1].Descriptor()
+}
+
+func (ManifestSource) Type() protoreflect.EnumType {
+	return &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_enumTypes[1]
+}
+
+func (x ManifestSource) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ManifestSource.Descriptor instead.
+func (ManifestSource) EnumDescriptor() ([]byte, []int) {
+	return file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_rawDescGZIP(), []int{1}
+}
+
 // SecretType
 //
 // The type of secret. Currently Kubeapps itself only deals with OPAQUE
@@ -80,11 +193,11 @@ func (x SecretType) String() string {
 }
 
 func (SecretType) Descriptor() protoreflect.EnumDescriptor {
-	return file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_enumTypes[0].Descriptor()
+	return file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_enumTypes[2].Descriptor()
 }
 
 func (SecretType) Type() protoreflect.EnumType {
-	return &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_enumTypes[0]
+	return &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_enumTypes[2]
 }
 
 func (x SecretType) Number() protoreflect.EnumNumber {
@@ -93,7 +206,7 @@ func (x SecretType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use SecretType.Descriptor instead.
 func (SecretType) EnumDescriptor() ([]byte, []int) {
-	return file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_rawDescGZIP(), []int{0}
+	return file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_rawDescGZIP(), []int{2}
 }
 
 // GetResourcesRequest
@@ -241,22 +354,31 @@ func (x *GetResourcesResponse) GetManifest() string {
 	return ""
 }
 
-// GetServiceAccountNamesRequest
+// GetRolloutStatusRequest
 //
-// Request for GetServiceAccountNames
-type GetServiceAccountNamesRequest struct {
+// Request for GetRolloutStatus that specifies the installed package whose
+// workloads (Deployments, StatefulSets and DaemonSets) are being followed.
+type GetRolloutStatusRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Context
+	// InstalledPackageRef
 	//
-	// The context for which the service account names are being fetched.
-	Context *v1alpha1.Context `protobuf:"bytes,1,opt,name=context,proto3" json:"context,omitempty"`
+	// The installed package reference for which the rollout status of the
+	// workloads is being fetched.
+	InstalledPackageRef *v1alpha1.InstalledPackageReference `protobuf:"bytes,1,opt,name=installed_package_ref,json=installedPackageRef,proto3" json:"installed_package_ref,omitempty"`
+	// Watch
+	//
+	// When true, similar to `kubectl rollout status`, the stream remains open
+	// with progress being sent as events are received from the Kubernetes API
+	// server, until every workload has either succeeded or failed to roll out.
+	// Otherwise only the current rollout status of each workload is sent.
+	Watch bool `protobuf:"varint,2,opt,name=watch,proto3" json:"watch,omitempty"`
 }
 
-func (x *GetServiceAccountNamesRequest) Reset() {
-	*x = GetServiceAccountNamesRequest{}
+func (x *GetRolloutStatusRequest) Reset() {
+	*x = GetRolloutStatusRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -264,13 +386,13 @@ func (x *GetServiceAccountNamesRequest) Reset() {
 	}
 }
 
-func (x *GetServiceAccountNamesRequest) String() string {
+func (x *GetRolloutStatusRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetServiceAccountNamesRequest) ProtoMessage() {}
+func (*GetRolloutStatusRequest) ProtoMessage() {}
 
-func (x *GetServiceAccountNamesRequest) ProtoReflect() protoreflect.Message {
+func (x *GetRolloutStatusRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -282,34 +404,57 @@ func (x *GetServiceAccountNamesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetServiceAccountNamesRequest.ProtoReflect.Descriptor instead.
-func (*GetServiceAccountNamesRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetRolloutStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetRolloutStatusRequest) Descriptor() ([]byte, []int) {
 	return file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *GetServiceAccountNamesRequest) GetContext() *v1alpha1.Context {
+func (x *GetRolloutStatusRequest) GetInstalledPackageRef() *v1alpha1.InstalledPackageReference {
 	if x != nil {
-		return x.Context
+		return x.InstalledPackageRef
 	}
 	return nil
 }
 
-// GetServiceAccountNamesResponse
+func (x *GetRolloutStatusRequest) GetWatch() bool {
+	if x != nil {
+		return x.Watch
+	}
+	return false
+}
+
+// GetRolloutStatusResponse
 //
-// Response for GetServiceAccountNames
-type GetServiceAccountNamesResponse struct {
+// Response for GetRolloutStatus, sent whenever the rollout status of one of
+// the workloads of the installed package changes.
+type GetRolloutStatusResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// ServiceAccountNames
+	// ResourceRef
 	//
-	// The list of Service Account names.
-	ServiceaccountNames []string `protobuf:"bytes,1,rep,name=serviceaccount_names,json=serviceaccountNames,proto3" json:"serviceaccount_names,omitempty"`
+	// The resource reference of the workload.
+	ResourceRef *v1alpha1.ResourceRef `protobuf:"bytes,1,opt,name=resource_ref,json=resourceRef,proto3" json:"resource_ref,omitempty"`
+	// Status
+	//
+	// Whether the rollout is in progress or has terminated, either
+	// successfully or not.
+	Status RolloutStatus `protobuf:"varint,2,opt,name=status,proto3,enum=kubeappsapis.plugins.resources.v1alpha1.RolloutStatus" json:"status,omitempty"`
+	// Message
+	//
+	// A human-readable message describing the progress of the rollout, using
+	// the same wording as `kubectl rollout status`.
+	Message string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	// Reason
+	//
+	// A machine-readable reason, such as `ProgressDeadlineExceeded`, only set
+	// when the rollout failed.
+	Reason string `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
 }
 
-func (x *GetServiceAccountNamesResponse) Reset() {
-	*x = GetServiceAccountNamesResponse{}
+func (x *GetRolloutStatusResponse) Reset() {
+	*x = GetRolloutStatusResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -317,13 +462,13 @@ func (x *GetServiceAccountNamesResponse) Reset() {
 	}
 }
 
-func (x *GetServiceAccountNamesResponse) String() string {
+func (x *GetRolloutStatusResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetServiceAccountNamesResponse) ProtoMessage() {}
+func (*GetRolloutStatusResponse) ProtoMessage() {}
 
-func (x *GetServiceAccountNamesResponse) ProtoReflect() protoreflect.Message {
+func (x *GetRolloutStatusResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -335,36 +480,57 @@ func (x *GetServiceAccountNamesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetServiceAccountNamesResponse.ProtoReflect.Descriptor instead.
-func (*GetServiceAccountNamesResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetRolloutStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetRolloutStatusResponse) Descriptor() ([]byte, []int) {
 	return file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *GetServiceAccountNamesResponse) GetServiceaccountNames() []string {
+func (x *GetRolloutStatusResponse) GetResourceRef() *v1alpha1.ResourceRef {
 	if x != nil {
-		return x.ServiceaccountNames
+		return x.ResourceRef
 	}
 	return nil
 }
 
-// GetNamespaceNamesRequest
+func (x *GetRolloutStatusResponse) GetStatus() RolloutStatus {
+	if x != nil {
+		return x.Status
+	}
+	return RolloutStatus_ROLLOUT_STATUS_UNSPECIFIED
+}
+
+func (x *GetRolloutStatusResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *GetRolloutStatusResponse) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// GetSchedulingInfoRequest
 //
-// Request for GetNamespaceNames
-type GetNamespaceNamesRequest struct {
+// Request for GetSchedulingInfo that specifies the installed package whose
+// pods are being inspected.
+type GetSchedulingInfoRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Cluster
+	// InstalledPackageRef
 	//
-	// The context for which the namespace names are being fetched.  The service
-	// will attempt to list namespaces across the cluster, first with the users
-	// credential, then with a configured service account if available.
-	Cluster string `protobuf:"bytes,1,opt,name=cluster,proto3" json:"cluster,omitempty"`
+	// The installed package reference for which the scheduling information
+	// of the pods is being fetched.
+	InstalledPackageRef *v1alpha1.InstalledPackageReference `protobuf:"bytes,1,opt,name=installed_package_ref,json=installedPackageRef,proto3" json:"installed_package_ref,omitempty"`
 }
 
-func (x *GetNamespaceNamesRequest) Reset() {
-	*x = GetNamespaceNamesRequest{}
+func (x *GetSchedulingInfoRequest) Reset() {
+	*x = GetSchedulingInfoRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -372,13 +538,13 @@ func (x *GetNamespaceNamesRequest) Reset() {
 	}
 }
 
-func (x *GetNamespaceNamesRequest) String() string {
+func (x *GetSchedulingInfoRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetNamespaceNamesRequest) ProtoMessage() {}
+func (*GetSchedulingInfoRequest) ProtoMessage() {}
 
-func (x *GetNamespaceNamesRequest) ProtoReflect() protoreflect.Message {
+func (x *GetSchedulingInfoRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -390,34 +556,35 @@ func (x *GetNamespaceNamesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetNamespaceNamesRequest.ProtoReflect.Descriptor instead.
-func (*GetNamespaceNamesRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetSchedulingInfoRequest.ProtoReflect.Descriptor instead.
+func (*GetSchedulingInfoRequest) Descriptor() ([]byte, []int) {
 	return file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *GetNamespaceNamesRequest) GetCluster() string {
+func (x *GetSchedulingInfoRequest) GetInstalledPackageRef() *v1alpha1.InstalledPackageReference {
 	if x != nil {
-		return x.Cluster
+		return x.InstalledPackageRef
 	}
-	return ""
+	return nil
 }
 
-// GetNamespaceNamesResponse
+// GetSchedulingInfoResponse
 //
-// Response for GetNamespaceNames
-type GetNamespaceNamesResponse struct {
+// Response for GetSchedulingInfo.
+type GetSchedulingInfoResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// NamespaceNames
+	// Pods
 	//
-	// The list of Namespace names.
-	NamespaceNames []string `protobuf:"bytes,1,rep,name=namespace_names,json=namespaceNames,proto3" json:"namespace_names,omitempty"`
+	// The scheduling information of the pods of the installed package, both
+	// those referenced directly and those selected by its workloads.
+	Pods []*PodSchedulingInfo `protobuf:"bytes,1,rep,name=pods,proto3" json:"pods,omitempty"`
 }
 
-func (x *GetNamespaceNamesResponse) Reset() {
-	*x = GetNamespaceNamesResponse{}
+func (x *GetSchedulingInfoResponse) Reset() {
+	*x = GetSchedulingInfoResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -425,13 +592,13 @@ func (x *GetNamespaceNamesResponse) Reset() {
 	}
 }
 
-func (x *GetNamespaceNamesResponse) String() string {
+func (x *GetSchedulingInfoResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetNamespaceNamesResponse) ProtoMessage() {}
+func (*GetSchedulingInfoResponse) ProtoMessage() {}
 
-func (x *GetNamespaceNamesResponse) ProtoReflect() protoreflect.Message {
+func (x *GetSchedulingInfoResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -443,38 +610,72 @@ func (x *GetNamespaceNamesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetNamespaceNamesResponse.ProtoReflect.Descriptor instead.
-func (*GetNamespaceNamesResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetSchedulingInfoResponse.ProtoReflect.Descriptor instead.
+func (*GetSchedulingInfoResponse) Descriptor() ([]byte, []int) {
 	return file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *GetNamespaceNamesResponse) GetNamespaceNames() []string {
+func (x *GetSchedulingInfoResponse) GetPods() []*PodSchedulingInfo {
 	if x != nil {
-		return x.NamespaceNames
+		return x.Pods
 	}
 	return nil
 }
 
-// CreateNamespaceRequest
+// PodSchedulingInfo
 //
-// Request for CreateNamespace
-type CreateNamespaceRequest struct {
+// Where a single pod is placed and, when it is pending, why.
+type PodSchedulingInfo struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Context
+	// PodRef
 	//
-	// The context of the namespace being created.
-	Context *v1alpha1.Context `protobuf:"bytes,1,opt,name=context,proto3" json:"context,omitempty"`
-	// Labels
+	// The resource reference of the pod.
+	PodRef *v1alpha1.ResourceRef `protobuf:"bytes,1,opt,name=pod_ref,json=podRef,proto3" json:"pod_ref,omitempty"`
+	// WorkloadRef
 	//
-	// The additional labels added to the namespace at creation time
-	Labels map[string]string `protobuf:"bytes,2,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// The resource reference of the workload of the installed package
+	// selecting the pod, if any.
+	WorkloadRef *v1alpha1.ResourceRef `protobuf:"bytes,2,opt,name=workload_ref,json=workloadRef,proto3" json:"workload_ref,omitempty"`
+	// Phase
+	//
+	// The phase of the pod, such as `Pending` or `Running`.
+	Phase string `protobuf:"bytes,3,opt,name=phase,proto3" json:"phase,omitempty"`
+	// Scheduled
+	//
+	// Whether the pod has been bound to a node.
+	Scheduled bool `protobuf:"varint,4,opt,name=scheduled,proto3" json:"scheduled,omitempty"`
+	// NodeName
+	//
+	// The node the pod is scheduled to, if any.
+	NodeName string `protobuf:"bytes,5,opt,name=node_name,json=nodeName,proto3" json:"node_name,omitempty"`
+	// NodeSelector
+	//
+	// The node labels required by the pod.
+	NodeSelector map[string]string `protobuf:"bytes,6,rep,name=node_selector,json=nodeSelector,proto3" json:"node_selector,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Tolerations
+	//
+	// A summary of each toleration of the pod, using the same wording as
+	// `kubectl describe pod`, such as `node.kubernetes.io/not-ready:NoExecute op=Exists for 300s`.
+	Tolerations []string `protobuf:"bytes,7,rep,name=tolerations,proto3" json:"tolerations,omitempty"`
+	// Affinity
+	//
+	// A summary of each node affinity, pod affinity and pod anti-affinity
+	// term of the pod, such as `required node affinity: kubernetes.io/arch in (amd64)`.
+	Affinity []string `protobuf:"bytes,8,rep,name=affinity,proto3" json:"affinity,omitempty"`
+	// PendingReasons
+	//
+	// Why the pod is still pending, from the most recent: the messages of the
+	// scheduler, such as `0/3 nodes are available: 3 Insufficient cpu.`, when
+	// the pod cannot be scheduled, or the reasons its containers are waiting,
+	// such as `ImagePullBackOff`, once it is scheduled.
+	PendingReasons []string `protobuf:"bytes,9,rep,name=pending_reasons,json=pendingReasons,proto3" json:"pending_reasons,omitempty"`
 }
 
-func (x *CreateNamespaceRequest) Reset() {
-	*x = CreateNamespaceRequest{}
+func (x *PodSchedulingInfo) Reset() {
+	*x = PodSchedulingInfo{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -482,6 +683,895 @@ func (x *CreateNamespaceRequest) Reset() {
 	}
 }
 
+func (x *PodSchedulingInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PodSchedulingInfo) ProtoMessage() {}
+
+func (x *PodSchedulingInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PodSchedulingInfo.ProtoReflect.Descriptor instead.
+func (*PodSchedulingInfo) Descriptor() ([]byte, []int) {
+	return file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PodSchedulingInfo) GetPodRef() *v1alpha1.ResourceRef {
+	if x != nil {
+		return x.PodRef
+	}
+	return nil
+}
+
+func (x *PodSchedulingInfo) GetWorkloadRef() *v1alpha1.ResourceRef {
+	if x != nil {
+		return x.WorkloadRef
+	}
+	return nil
+}
+
+func (x *PodSchedulingInfo) GetPhase() string {
+	if x != nil {
+		return x.Phase
+	}
+	return ""
+}
+
+func (x *PodSchedulingInfo) GetScheduled() bool {
+	if x != nil {
+		return x.Scheduled
+	}
+	return false
+}
+
+func (x *PodSchedulingInfo) GetNodeName() string {
+	if x != nil {
+		return x.NodeName
+	}
+	return ""
+}
+
+func (x *PodSchedulingInfo) GetNodeSelector() map[string]string {
+	if x != nil {
+		return x.NodeSelector
+	}
+	return nil
+}
+
+func (x *PodSchedulingInfo) GetTolerations() []string {
+	if x != nil {
+		return x.Tolerations
+	}
+	return nil
+}
+
+func (x *PodSchedulingInfo) GetAffinity() []string {
+	if x != nil {
+		return x.Affinity
+	}
+	return nil
+}
+
+func (x *PodSchedulingInfo) GetPendingReasons() []string {
+	if x != nil {
+		return x.PendingReasons
+	}
+	return nil
+}
+
+// GetInstalledPackageEventsRequest
+//
+// Request for GetInstalledPackageEvents that specifies the installed package
+// whose events are being fetched.
+type GetInstalledPackageEventsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// InstalledPackageRef
+	//
+	// The installed package reference for which the events of the resources
+	// are being fetched.
+	InstalledPackageRef *v1alpha1.InstalledPackageReference `protobuf:"bytes,1,opt,name=installed_package_ref,json=installedPackageRef,proto3" json:"installed_package_ref,omitempty"`
+}
+
+func (x *GetInstalledPackageEventsRequest) Reset() {
+	*x = GetInstalledPackageEventsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetInstalledPackageEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInstalledPackageEventsRequest) ProtoMessage() {}
+
+func (x *GetInstalledPackageEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInstalledPackageEventsRequest.ProtoReflect.Descriptor instead.
+func (*GetInstalledPackageEventsRequest) Descriptor() ([]byte, []int) {
+	return file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetInstalledPackageEventsRequest) GetInstalledPackageRef() *v1alpha1.InstalledPackageReference {
+	if x != nil {
+		return x.InstalledPackageRef
+	}
+	return nil
+}
+
+// GetInstalledPackageEventsResponse
+//
+// Response for GetInstalledPackageEvents.
+type GetInstalledPackageEventsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Events
+	//
+	// The events of the resources of the installed package, from the most
+	// recent.
+	Events []*ResourceEvent `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+}
+
+func (x *GetInstalledPackageEventsResponse) Reset() {
+	*x = GetInstalledPackageEventsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetInstalledPackageEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInstalledPackageEventsResponse) ProtoMessage() {}
+
+func (x *GetInstalledPackageEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInstalledPackageEventsResponse.ProtoReflect.Descriptor instead.
+func (*GetInstalledPackageEventsResponse) Descriptor() ([]byte, []int) {
+	return file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetInstalledPackageEventsResponse) GetEvents() []*ResourceEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+// ResourceEvent
+//
+// A Kubernetes event of a resource of an installed package. The events with
+// the same resource, type, reason and message are reported once.
+type ResourceEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// ResourceRef
+	//
+	// The resource reference of the object the event is about.
+	ResourceRef *v1alpha1.ResourceRef `protobuf:"bytes,1,opt,name=resource_ref,json=resourceRef,proto3" json:"resource_ref,omitempty"`
+	// Type
+	//
+	// The type of the event, either `Normal` or `Warning`.
+	Type string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	// Reason
+	//
+	// The reason of the event, such as `BackOff` or `FailedMount`.
+	Reason string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	// Message
+	//
+	// The human-readable description of the event.
+	Message string `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	// Count
+	//
+	// The number of times the event has occurred.
+	Count int32 `protobuf:"varint,5,opt,name=count,proto3" json:"count,omitempty"`
+	// FirstTimestamp
+	//
+	// When the event first occurred, in RFC3339 format.
+	FirstTimestamp string `protobuf:"bytes,6,opt,name=first_timestamp,json=firstTimestamp,proto3" json:"first_timestamp,omitempty"`
+	// LastTimestamp
+	//
+	// When the event most recently occurred, in RFC3339 format.
+	LastTimestamp string `protobuf:"bytes,7,opt,name=last_timestamp,json=lastTimestamp,proto3" json:"last_timestamp,omitempty"`
+	// Source
+	//
+	// The component reporting the event, such as `kubelet`.
+	Source string `protobuf:"bytes,8,opt,name=source,proto3" json:"source,omitempty"`
+}
+
+func (x *ResourceEvent) Reset() {
+	*x = ResourceEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResourceEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResourceEvent) ProtoMessage() {}
+
+func (x *ResourceEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResourceEvent.ProtoReflect.Descriptor instead.
+func (*ResourceEvent) Descriptor() ([]byte, []int) {
+	return file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ResourceEvent) GetResourceRef() *v1alpha1.ResourceRef {
+	if x != nil {
+		return x.ResourceRef
+	}
+	return nil
+}
+
+func (x *ResourceEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ResourceEvent) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ResourceEvent) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ResourceEvent) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *ResourceEvent) GetFirstTimestamp() string {
+	if x != nil {
+		return x.FirstTimestamp
+	}
+	return ""
+}
+
+func (x *ResourceEvent) GetLastTimestamp() string {
+	if x != nil {
+		return x.LastTimestamp
+	}
+	return ""
+}
+
+func (x *ResourceEvent) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+// GetInstalledPackageCustomFieldsRequest
+//
+// Request for GetInstalledPackageCustomFields that specifies the installed
+// package whose custom fields are being extracted.
+type GetInstalledPackageCustomFieldsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// InstalledPackageRef
+	//
+	// The installed package reference for which the custom fields of the
+	// resources are being extracted.
+	InstalledPackageRef *v1alpha1.InstalledPackageReference `protobuf:"bytes,1,opt,name=installed_package_ref,json=installedPackageRef,proto3" json:"installed_package_ref,omitempty"`
+}
+
+func (x *GetInstalledPackageCustomFieldsRequest) Reset() {
+	*x = GetInstalledPackageCustomFieldsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetInstalledPackageCustomFieldsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInstalledPackageCustomFieldsRequest) ProtoMessage() {}
+
+func (x *GetInstalledPackageCustomFieldsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInstalledPackageCustomFieldsRequest.ProtoReflect.Descriptor instead.
+func (*GetInstalledPackageCustomFieldsRequest) Descriptor() ([]byte, []int) {
+	return file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetInstalledPackageCustomFieldsRequest) GetInstalledPackageRef() *v1alpha1.InstalledPackageReference {
+	if x != nil {
+		return x.InstalledPackageRef
+	}
+	return nil
+}
+
+// GetInstalledPackageCustomFieldsResponse
+//
+// Response for GetInstalledPackageCustomFields.
+type GetInstalledPackageCustomFieldsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Fields
+	//
+	// The custom fields extracted from the resources of the installed package,
+	// in the order of the resources. The fields of a resource declared in the
+	// plugin configuration come before those declared in its
+	// `kubeapps.dev/custom-fields` annotation. The fields whose expression
+	// does not match anything are left out.
+	Fields []*CustomField `protobuf:"bytes,1,rep,name=fields,proto3" json:"fields,omitempty"`
+}
+
+func (x *GetInstalledPackageCustomFieldsResponse) Reset() {
+	*x = GetInstalledPackageCustomFieldsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetInstalledPackageCustomFieldsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInstalledPackageCustomFieldsResponse) ProtoMessage() {}
+
+func (x *GetInstalledPackageCustomFieldsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInstalledPackageCustomFieldsResponse.ProtoReflect.Descriptor instead.
+func (*GetInstalledPackageCustomFieldsResponse) Descriptor() ([]byte, []int) {
+	return file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetInstalledPackageCustomFieldsResponse) GetFields() []*CustomField {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+// CustomField
+//
+// A key/value pair extracted with a JSONPath expression from a resource of an
+// installed package, such as the external URL of an Ingress.
+type CustomField struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Name
+	//
+	// The name of the field, as declared, such as `External URL`.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Value
+	//
+	// The result of the JSONPath expression, with multiple results separated
+	// by spaces as with `kubectl get -o jsonpath`.
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	// ResourceRef
+	//
+	// The resource reference of the object the field is extracted from.
+	ResourceRef *v1alpha1.ResourceRef `protobuf:"bytes,3,opt,name=resource_ref,json=resourceRef,proto3" json:"resource_ref,omitempty"`
+}
+
+func (x *CustomField) Reset() {
+	*x = CustomField{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CustomField) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CustomField) ProtoMessage() {}
+
+func (x *CustomField) ProtoReflect() protoreflect.Message {
+	mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CustomField.ProtoReflect.Descriptor instead.
+func (*CustomField) Descriptor() ([]byte, []int) {
+	return file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CustomField) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CustomField) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *CustomField) GetResourceRef() *v1alpha1.ResourceRef {
+	if x != nil {
+		return x.ResourceRef
+	}
+	return nil
+}
+
+// ExportInstalledPackageManifestsRequest
+//
+// Request for ExportInstalledPackageManifests that specifies the installed
+// package whose manifests are being exported.
+type ExportInstalledPackageManifestsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// InstalledPackageRef
+	//
+	// The installed package reference for which the manifests of the resources
+	// are being exported.
+	InstalledPackageRef *v1alpha1.InstalledPackageReference `protobuf:"bytes,1,opt,name=installed_package_ref,json=installedPackageRef,proto3" json:"installed_package_ref,omitempty"`
+	// Source
+	//
+	// Whether the live state of the resources or the configuration last
+	// applied to them is exported. Defaults to the live state.
+	Source ManifestSource `protobuf:"varint,2,opt,name=source,proto3,enum=kubeappsapis.plugins.resources.v1alpha1.ManifestSource" json:"source,omitempty"`
+}
+
+func (x *ExportInstalledPackageManifestsRequest) Reset() {
+	*x = ExportInstalledPackageManifestsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportInstalledPackageManifestsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportInstalledPackageManifestsRequest) ProtoMessage() {}
+
+func (x *ExportInstalledPackageManifestsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportInstalledPackageManifestsRequest.ProtoReflect.Descriptor instead.
+func (*ExportInstalledPackageManifestsRequest) Descriptor() ([]byte, []int) {
+	return file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ExportInstalledPackageManifestsRequest) GetInstalledPackageRef() *v1alpha1.InstalledPackageReference {
+	if x != nil {
+		return x.InstalledPackageRef
+	}
+	return nil
+}
+
+func (x *ExportInstalledPackageManifestsRequest) GetSource() ManifestSource {
+	if x != nil {
+		return x.Source
+	}
+	return ManifestSource_MANIFEST_SOURCE_LIVE_UNSPECIFIED
+}
+
+// ExportInstalledPackageManifestsResponse
+//
+// Response for ExportInstalledPackageManifests.
+type ExportInstalledPackageManifestsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Manifests
+	//
+	// The manifests of the resources of the installed package, in the order of
+	// the resources, as a single multi-document YAML.
+	Manifests string `protobuf:"bytes,1,opt,name=manifests,proto3" json:"manifests,omitempty"`
+	// SkippedResourceRefs
+	//
+	// The references of the resources left out of the manifests, either not
+	// created yet or without last applied configuration.
+	SkippedResourceRefs []*v1alpha1.ResourceRef `protobuf:"bytes,2,rep,name=skipped_resource_refs,json=skippedResourceRefs,proto3" json:"skipped_resource_refs,omitempty"`
+}
+
+func (x *ExportInstalledPackageManifestsResponse) Reset() {
+	*x = ExportInstalledPackageManifestsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportInstalledPackageManifestsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportInstalledPackageManifestsResponse) ProtoMessage() {}
+
+func (x *ExportInstalledPackageManifestsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportInstalledPackageManifestsResponse.ProtoReflect.Descriptor instead.
+func (*ExportInstalledPackageManifestsResponse) Descriptor() ([]byte, []int) {
+	return file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ExportInstalledPackageManifestsResponse) GetManifests() string {
+	if x != nil {
+		return x.Manifests
+	}
+	return ""
+}
+
+func (x *ExportInstalledPackageManifestsResponse) GetSkippedResourceRefs() []*v1alpha1.ResourceRef {
+	if x != nil {
+		return x.SkippedResourceRefs
+	}
+	return nil
+}
+
+// GetServiceAccountNamesRequest
+//
+// Request for GetServiceAccountNames
+type GetServiceAccountNamesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Context
+	//
+	// The context for which the service account names are being fetched.
+	Context *v1alpha1.Context `protobuf:"bytes,1,opt,name=context,proto3" json:"context,omitempty"`
+}
+
+func (x *GetServiceAccountNamesRequest) Reset() {
+	*x = GetServiceAccountNamesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetServiceAccountNamesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetServiceAccountNamesRequest) ProtoMessage() {}
+
+func (x *GetServiceAccountNamesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetServiceAccountNamesRequest.ProtoReflect.Descriptor instead.
+func (*GetServiceAccountNamesRequest) Descriptor() ([]byte, []int) {
+	return file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetServiceAccountNamesRequest) GetContext() *v1alpha1.Context {
+	if x != nil {
+		return x.Context
+	}
+	return nil
+}
+
+// GetServiceAccountNamesResponse
+//
+// Response for GetServiceAccountNames
+type GetServiceAccountNamesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// ServiceAccountNames
+	//
+	// The list of Service Account names.
+	ServiceaccountNames []string `protobuf:"bytes,1,rep,name=serviceaccount_names,json=serviceaccountNames,proto3" json:"serviceaccount_names,omitempty"`
+}
+
+func (x *GetServiceAccountNamesResponse) Reset() {
+	*x = GetServiceAccountNamesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetServiceAccountNamesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetServiceAccountNamesResponse) ProtoMessage() {}
+
+func (x *GetServiceAccountNamesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetServiceAccountNamesResponse.ProtoReflect.Descriptor instead.
+func (*GetServiceAccountNamesResponse) Descriptor() ([]byte, []int) {
+	return file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetServiceAccountNamesResponse) GetServiceaccountNames() []string {
+	if x != nil {
+		return x.ServiceaccountNames
+	}
+	return nil
+}
+
+// GetNamespaceNamesRequest
+//
+// Request for GetNamespaceNames
+type GetNamespaceNamesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Cluster
+	//
+	// The context for which the namespace names are being fetched.  The service
+	// will attempt to list namespaces across the cluster, first with the users
+	// credential, then with a configured service account if available.
+	Cluster string `protobuf:"bytes,1,opt,name=cluster,proto3" json:"cluster,omitempty"`
+}
+
+func (x *GetNamespaceNamesRequest) Reset() {
+	*x = GetNamespaceNamesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetNamespaceNamesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNamespaceNamesRequest) ProtoMessage() {}
+
+func (x *GetNamespaceNamesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNamespaceNamesRequest.ProtoReflect.Descriptor instead.
+func (*GetNamespaceNamesRequest) Descriptor() ([]byte, []int) {
+	return file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetNamespaceNamesRequest) GetCluster() string {
+	if x != nil {
+		return x.Cluster
+	}
+	return ""
+}
+
+// GetNamespaceNamesResponse
+//
+// Response for GetNamespaceNames
+type GetNamespaceNamesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// NamespaceNames
+	//
+	// The list of Namespace names.
+	NamespaceNames []string `protobuf:"bytes,1,rep,name=namespace_names,json=namespaceNames,proto3" json:"namespace_names,omitempty"`
+}
+
+func (x *GetNamespaceNamesResponse) Reset() {
+	*x = GetNamespaceNamesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetNamespaceNamesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNamespaceNamesResponse) ProtoMessage() {}
+
+func (x *GetNamespaceNamesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNamespaceNamesResponse.ProtoReflect.Descriptor instead.
+func (*GetNamespaceNamesResponse) Descriptor() ([]byte, []int) {
+	return file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetNamespaceNamesResponse) GetNamespaceNames() []string {
+	if x != nil {
+		return x.NamespaceNames
+	}
+	return nil
+}
+
+// CreateNamespaceRequest
+//
+// Request for CreateNamespace
+type CreateNamespaceRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Context
+	//
+	// The context of the namespace being created.
+	Context *v1alpha1.Context `protobuf:"bytes,1,opt,name=context,proto3" json:"context,omitempty"`
+	// Labels
+	//
+	// The additional labels added to the namespace at creation time
+	Labels map[string]string `protobuf:"bytes,2,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *CreateNamespaceRequest) Reset() {
+	*x = CreateNamespaceRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
 func (x *CreateNamespaceRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
@@ -489,7 +1579,7 @@ func (x *CreateNamespaceRequest) String() string {
 func (*CreateNamespaceRequest) ProtoMessage() {}
 
 func (x *CreateNamespaceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[6]
+	mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -502,7 +1592,7 @@ func (x *CreateNamespaceRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateNamespaceRequest.ProtoReflect.Descriptor instead.
 func (*CreateNamespaceRequest) Descriptor() ([]byte, []int) {
-	return file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_rawDescGZIP(), []int{6}
+	return file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *CreateNamespaceRequest) GetContext() *v1alpha1.Context {
@@ -531,7 +1621,7 @@ type CreateNamespaceResponse struct {
 func (x *CreateNamespaceResponse) Reset() {
 	*x = CreateNamespaceResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[7]
+		mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -544,7 +1634,7 @@ func (x *CreateNamespaceResponse) String() string {
 func (*CreateNamespaceResponse) ProtoMessage() {}
 
 func (x *CreateNamespaceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[7]
+	mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -557,7 +1647,7 @@ func (x *CreateNamespaceResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateNamespaceResponse.ProtoReflect.Descriptor instead.
 func (*CreateNamespaceResponse) Descriptor() ([]byte, []int) {
-	return file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_rawDescGZIP(), []int{7}
+	return file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_rawDescGZIP(), []int{20}
 }
 
 // CheckNamespaceExistsRequest
@@ -577,7 +1667,7 @@ type CheckNamespaceExistsRequest struct {
 func (x *CheckNamespaceExistsRequest) Reset() {
 	*x = CheckNamespaceExistsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[8]
+		mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -590,7 +1680,7 @@ func (x *CheckNamespaceExistsRequest) String() string {
 func (*CheckNamespaceExistsRequest) ProtoMessage() {}
 
 func (x *CheckNamespaceExistsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[8]
+	mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -603,7 +1693,7 @@ func (x *CheckNamespaceExistsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CheckNamespaceExistsRequest.ProtoReflect.Descriptor instead.
 func (*CheckNamespaceExistsRequest) Descriptor() ([]byte, []int) {
-	return file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_rawDescGZIP(), []int{8}
+	return file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *CheckNamespaceExistsRequest) GetContext() *v1alpha1.Context {
@@ -627,7 +1717,7 @@ type CheckNamespaceExistsResponse struct {
 func (x *CheckNamespaceExistsResponse) Reset() {
 	*x = CheckNamespaceExistsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[9]
+		mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -640,7 +1730,7 @@ func (x *CheckNamespaceExistsResponse) String() string {
 func (*CheckNamespaceExistsResponse) ProtoMessage() {}
 
 func (x *CheckNamespaceExistsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[9]
+	mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -653,7 +1743,7 @@ func (x *CheckNamespaceExistsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CheckNamespaceExistsResponse.ProtoReflect.Descriptor instead.
 func (*CheckNamespaceExistsResponse) Descriptor() ([]byte, []int) {
-	return file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_rawDescGZIP(), []int{9}
+	return file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *CheckNamespaceExistsResponse) GetExists() bool {
@@ -694,7 +1784,7 @@ type CreateSecretRequest struct {
 func (x *CreateSecretRequest) Reset() {
 	*x = CreateSecretRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[10]
+		mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -707,7 +1797,7 @@ func (x *CreateSecretRequest) String() string {
 func (*CreateSecretRequest) ProtoMessage() {}
 
 func (x *CreateSecretRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[10]
+	mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -720,7 +1810,7 @@ func (x *CreateSecretRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateSecretRequest.ProtoReflect.Descriptor instead.
 func (*CreateSecretRequest) Descriptor() ([]byte, []int) {
-	return file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_rawDescGZIP(), []int{10}
+	return file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *CreateSecretRequest) GetContext() *v1alpha1.Context {
@@ -763,7 +1853,7 @@ type CreateSecretResponse struct {
 func (x *CreateSecretResponse) Reset() {
 	*x = CreateSecretResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[11]
+		mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -776,7 +1866,7 @@ func (x *CreateSecretResponse) String() string {
 func (*CreateSecretResponse) ProtoMessage() {}
 
 func (x *CreateSecretResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[11]
+	mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -789,7 +1879,7 @@ func (x *CreateSecretResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateSecretResponse.ProtoReflect.Descriptor instead.
 func (*CreateSecretResponse) Descriptor() ([]byte, []int) {
-	return file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_rawDescGZIP(), []int{11}
+	return file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_rawDescGZIP(), []int{24}
 }
 
 // GetSecretNamesRequest
@@ -809,7 +1899,7 @@ type GetSecretNamesRequest struct {
 func (x *GetSecretNamesRequest) Reset() {
 	*x = GetSecretNamesRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[12]
+		mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[25]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -822,7 +1912,7 @@ func (x *GetSecretNamesRequest) String() string {
 func (*GetSecretNamesRequest) ProtoMessage() {}
 
 func (x *GetSecretNamesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[12]
+	mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[25]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -835,7 +1925,7 @@ func (x *GetSecretNamesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetSecretNamesRequest.ProtoReflect.Descriptor instead.
 func (*GetSecretNamesRequest) Descriptor() ([]byte, []int) {
-	return file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_rawDescGZIP(), []int{12}
+	return file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *GetSecretNamesRequest) GetContext() *v1alpha1.Context {
@@ -862,7 +1952,7 @@ type GetSecretNamesResponse struct {
 func (x *GetSecretNamesResponse) Reset() {
 	*x = GetSecretNamesResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[13]
+		mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[26]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -875,7 +1965,7 @@ func (x *GetSecretNamesResponse) String() string {
 func (*GetSecretNamesResponse) ProtoMessage() {}
 
 func (x *GetSecretNamesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[13]
+	mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[26]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -888,7 +1978,7 @@ func (x *GetSecretNamesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetSecretNamesResponse.ProtoReflect.Descriptor instead.
 func (*GetSecretNamesResponse) Descriptor() ([]byte, []int) {
-	return file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_rawDescGZIP(), []int{13}
+	return file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *GetSecretNamesResponse) GetSecretNames() map[string]SecretType {
@@ -923,7 +2013,7 @@ type CanIRequest struct {
 func (x *CanIRequest) Reset() {
 	*x = CanIRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[14]
+		mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -936,7 +2026,7 @@ func (x *CanIRequest) String() string {
 func (*CanIRequest) ProtoMessage() {}
 
 func (x *CanIRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[14]
+	mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -949,7 +2039,7 @@ func (x *CanIRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CanIRequest.ProtoReflect.Descriptor instead.
 func (*CanIRequest) Descriptor() ([]byte, []int) {
-	return file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_rawDescGZIP(), []int{14}
+	return file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *CanIRequest) GetContext() *v1alpha1.Context {
@@ -997,7 +2087,7 @@ type CanIResponse struct {
 func (x *CanIResponse) Reset() {
 	*x = CanIResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[15]
+		mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1010,7 +2100,7 @@ func (x *CanIResponse) String() string {
 func (*CanIResponse) ProtoMessage() {}
 
 func (x *CanIResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[15]
+	mi := &file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1023,7 +2113,7 @@ func (x *CanIResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CanIResponse.ProtoReflect.Descriptor instead.
 func (*CanIResponse) Descriptor() ([]byte, []int) {
-	return file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_rawDescGZIP(), []int{15}
+	return file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *CanIResponse) GetAllowed() bool {
@@ -1073,155 +2163,466 @@ var file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_rawDesc = []byt
 	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x66, 0x52, 0x0b, 0x72, 0x65, 0x73, 0x6f, 0x75,
 	0x72, 0x63, 0x65, 0x52, 0x65, 0x66, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65,
 	0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65,
-	0x73, 0x74, 0x22, 0x67, 0x0a, 0x1d, 0x47, 0x65, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
-	0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x46, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61,
-	0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65,
-	0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x65,
-	0x78, 0x74, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x22, 0x53, 0x0a, 0x1e, 0x47,
-	0x65, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74,
-	0x4e, 0x61, 0x6d, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x31, 0x0a,
-	0x14, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f,
-	0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x13, 0x73, 0x65, 0x72,
-	0x76, 0x69, 0x63, 0x65, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x73,
-	0x22, 0x34, 0x0a, 0x18, 0x47, 0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65,
-	0x4e, 0x61, 0x6d, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07,
-	0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63,
-	0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x22, 0x44, 0x0a, 0x19, 0x47, 0x65, 0x74, 0x4e, 0x61, 0x6d,
-	0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65,
-	0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x6e, 0x61,
-	0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x22, 0x80, 0x02, 0x0a,
-	0x16, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x46, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65,
-	0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61,
+	0x73, 0x74, 0x22, 0xa3, 0x01, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x6c, 0x6c, 0x6f, 0x75,
+	0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x72,
+	0x0a, 0x15, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x5f, 0x70, 0x61, 0x63, 0x6b,
+	0x61, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x66, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3e, 0x2e,
+	0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72,
+	0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x50, 0x61, 0x63,
+	0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x13, 0x69,
+	0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52,
+	0x65, 0x66, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x61, 0x74, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x05, 0x77, 0x61, 0x74, 0x63, 0x68, 0x22, 0xf1, 0x01, 0x0a, 0x18, 0x47, 0x65, 0x74,
+	0x52, 0x6f, 0x6c, 0x6c, 0x6f, 0x75, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x53, 0x0a, 0x0c, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x5f, 0x72, 0x65, 0x66, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x6b, 0x75,
+	0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e,
+	0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x31, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x66, 0x52, 0x0b, 0x72,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x66, 0x12, 0x4e, 0x0a, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x36, 0x2e, 0x6b, 0x75, 0x62,
+	0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e,
+	0x73, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x6f, 0x6c, 0x6c, 0x6f, 0x75, 0x74, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x8e, 0x01, 0x0a,
+	0x18, 0x47, 0x65, 0x74, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x69, 0x6e, 0x67, 0x49, 0x6e,
+	0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x72, 0x0a, 0x15, 0x69, 0x6e, 0x73,
+	0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x72,
+	0x65, 0x66, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3e, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61,
 	0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x70, 0x61, 0x63,
-	0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43,
-	0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x12,
-	0x63, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x4b, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x70,
+	0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x49,
+	0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52,
+	0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x13, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c,
+	0x6c, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x66, 0x22, 0x6b, 0x0a,
+	0x19, 0x47, 0x65, 0x74, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x69, 0x6e, 0x67, 0x49, 0x6e,
+	0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4e, 0x0a, 0x04, 0x70, 0x6f,
+	0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x3a, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61,
+	0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x2e,
+	0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2e, 0x50, 0x6f, 0x64, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x69, 0x6e, 0x67,
+	0x49, 0x6e, 0x66, 0x6f, 0x52, 0x04, 0x70, 0x6f, 0x64, 0x73, 0x22, 0x9f, 0x04, 0x0a, 0x11, 0x50,
+	0x6f, 0x64, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x69, 0x6e, 0x67, 0x49, 0x6e, 0x66, 0x6f,
+	0x12, 0x49, 0x0a, 0x07, 0x70, 0x6f, 0x64, 0x5f, 0x72, 0x65, 0x66, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x30, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73,
+	0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x76,
+	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x52, 0x65, 0x66, 0x52, 0x06, 0x70, 0x6f, 0x64, 0x52, 0x65, 0x66, 0x12, 0x53, 0x0a, 0x0c, 0x77,
+	0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x72, 0x65, 0x66, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x30, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73,
+	0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x76,
+	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x52, 0x65, 0x66, 0x52, 0x0b, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x66,
+	0x12, 0x14, 0x0a, 0x05, 0x70, 0x68, 0x61, 0x73, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x70, 0x68, 0x61, 0x73, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75,
+	0x6c, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x73, 0x63, 0x68, 0x65, 0x64,
+	0x75, 0x6c, 0x65, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6e, 0x6f, 0x64, 0x65, 0x4e, 0x61, 0x6d,
+	0x65, 0x12, 0x71, 0x0a, 0x0d, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74,
+	0x6f, 0x72, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x4c, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61,
+	0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x2e,
+	0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2e, 0x50, 0x6f, 0x64, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x69, 0x6e, 0x67,
+	0x49, 0x6e, 0x66, 0x6f, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f,
+	0x72, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0c, 0x6e, 0x6f, 0x64, 0x65, 0x53, 0x65, 0x6c, 0x65,
+	0x63, 0x74, 0x6f, 0x72, 0x12, 0x20, 0x0a, 0x0b, 0x74, 0x6f, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x74, 0x6f, 0x6c, 0x65, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x66, 0x66, 0x69, 0x6e, 0x69,
+	0x74, 0x79, 0x18, 0x08, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x61, 0x66, 0x66, 0x69, 0x6e, 0x69,
+	0x74, 0x79, 0x12, 0x27, 0x0a, 0x0f, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x72, 0x65,
+	0x61, 0x73, 0x6f, 0x6e, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x70, 0x65, 0x6e,
+	0x64, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x73, 0x1a, 0x3f, 0x0a, 0x11, 0x4e,
+	0x6f, 0x64, 0x65, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x96, 0x01, 0x0a,
+	0x20, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x50, 0x61, 0x63,
+	0x6b, 0x61, 0x67, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x72, 0x0a, 0x15, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x5f, 0x70,
+	0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x66, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x3e, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e,
+	0x63, 0x6f, 0x72, 0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x76, 0x31,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64,
+	0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65,
+	0x52, 0x13, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61,
+	0x67, 0x65, 0x52, 0x65, 0x66, 0x22, 0x73, 0x0a, 0x21, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x73, 0x74,
+	0x61, 0x6c, 0x6c, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4e, 0x0a, 0x06, 0x65, 0x76,
+	0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x36, 0x2e, 0x6b, 0x75, 0x62,
+	0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e,
+	0x73, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x52, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x22, 0xa8, 0x02, 0x0a, 0x0d, 0x52,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x53, 0x0a, 0x0c,
+	0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x72, 0x65, 0x66, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x30, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69,
+	0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e,
+	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x52, 0x65, 0x66, 0x52, 0x0b, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65,
+	0x66, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x18, 0x0a,
+	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x27, 0x0a,
+	0x0f, 0x66, 0x69, 0x72, 0x73, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x66, 0x69, 0x72, 0x73, 0x74, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x25, 0x0a, 0x0e, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d,
+	0x6c, 0x61, 0x73, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x16, 0x0a,
+	0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x22, 0x9c, 0x01, 0x0a, 0x26, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x73,
+	0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x43, 0x75, 0x73,
+	0x74, 0x6f, 0x6d, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x72, 0x0a, 0x15, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x5f, 0x70, 0x61,
+	0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x66, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x3e, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63,
+	0x6f, 0x72, 0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61,
+	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x50,
+	0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x52,
+	0x13, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67,
+	0x65, 0x52, 0x65, 0x66, 0x22, 0x77, 0x0a, 0x27, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61,
+	0x6c, 0x6c, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x43, 0x75, 0x73, 0x74, 0x6f,
+	0x6d, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x4c, 0x0a, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x34, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x70,
 	0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73,
-	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
-	0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x6c, 0x61,
-	0x62, 0x65, 0x6c, 0x73, 0x1a, 0x39, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e,
-	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22,
-	0x19, 0x0a, 0x17, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61,
-	0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x65, 0x0a, 0x1b, 0x43, 0x68,
-	0x65, 0x63, 0x6b, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x45, 0x78, 0x69, 0x73,
-	0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x46, 0x0a, 0x07, 0x63, 0x6f, 0x6e,
-	0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x6b, 0x75, 0x62,
-	0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x70,
-	0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
-	0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78,
-	0x74, 0x22, 0x36, 0x0a, 0x1c, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70,
-	0x61, 0x63, 0x65, 0x45, 0x78, 0x69, 0x73, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x78, 0x69, 0x73, 0x74, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x08, 0x52, 0x06, 0x65, 0x78, 0x69, 0x73, 0x74, 0x73, 0x22, 0xe8, 0x02, 0x0a, 0x13, 0x43, 0x72,
-	0x65, 0x61, 0x74, 0x65, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d,
+	0x46, 0x69, 0x65, 0x6c, 0x64, 0x52, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x22, 0x8c, 0x01,
+	0x0a, 0x0b, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x53, 0x0a, 0x0c, 0x72, 0x65, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x5f, 0x72, 0x65, 0x66, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x30, 0x2e,
+	0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72,
+	0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x66, 0x52,
+	0x0b, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x66, 0x22, 0xed, 0x01, 0x0a,
+	0x26, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64,
+	0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x72, 0x0a, 0x15, 0x69, 0x6e, 0x73, 0x74, 0x61,
+	0x6c, 0x6c, 0x65, 0x64, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x66,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3e, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70,
+	0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x61,
+	0x67, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x49, 0x6e, 0x73,
+	0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x66,
+	0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x13, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65,
+	0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x66, 0x12, 0x4f, 0x0a, 0x06, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x37, 0x2e, 0x6b, 0x75,
+	0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69,
+	0x6e, 0x73, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61,
+	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x53, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x22, 0xad, 0x01, 0x0a,
+	0x27, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64,
+	0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6d, 0x61, 0x6e, 0x69,
+	0x66, 0x65, 0x73, 0x74, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6d, 0x61, 0x6e,
+	0x69, 0x66, 0x65, 0x73, 0x74, 0x73, 0x12, 0x64, 0x0a, 0x15, 0x73, 0x6b, 0x69, 0x70, 0x70, 0x65,
+	0x64, 0x5f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x72, 0x65, 0x66, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73,
+	0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67,
+	0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x66, 0x52, 0x13, 0x73, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64,
+	0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x66, 0x73, 0x22, 0x67, 0x0a, 0x1d,
+	0x47, 0x65, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x46, 0x0a,
+	0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2c,
+	0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f,
+	0x72, 0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x52, 0x07, 0x63, 0x6f,
+	0x6e, 0x74, 0x65, 0x78, 0x74, 0x22, 0x53, 0x0a, 0x1e, 0x47, 0x65, 0x74, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x31, 0x0a, 0x14, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x13, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x61, 0x63,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x22, 0x34, 0x0a, 0x18, 0x47, 0x65,
+	0x74, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65,
+	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72,
+	0x22, 0x44, 0x0a, 0x19, 0x47, 0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65,
+	0x4e, 0x61, 0x6d, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x27, 0x0a,
+	0x0f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63,
+	0x65, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x22, 0x80, 0x02, 0x0a, 0x16, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
 	0x74, 0x12, 0x46, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01,
 	0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69,
 	0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e,
 	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74,
-	0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x12, 0x47, 0x0a, 0x04, 0x74, 0x79, 0x70,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x33, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70,
-	0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x2e, 0x72,
-	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
-	0x31, 0x2e, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79,
-	0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x6d, 0x0a, 0x0b, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67,
-	0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x4c, 0x2e, 0x6b, 0x75,
+	0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x12, 0x63, 0x0a, 0x06, 0x6c, 0x61, 0x62,
+	0x65, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x4b, 0x2e, 0x6b, 0x75, 0x62, 0x65,
+	0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73,
+	0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70,
+	0x61, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x1a, 0x39,
+	0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x19, 0x0a, 0x17, 0x43, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x65, 0x0a, 0x1b, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x4e, 0x61, 0x6d,
+	0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x45, 0x78, 0x69, 0x73, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x46, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61,
+	0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65,
+	0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x65,
+	0x78, 0x74, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x22, 0x36, 0x0a, 0x1c, 0x43,
+	0x68, 0x65, 0x63, 0x6b, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x45, 0x78, 0x69,
+	0x73, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x65,
+	0x78, 0x69, 0x73, 0x74, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x65, 0x78, 0x69,
+	0x73, 0x74, 0x73, 0x22, 0xe8, 0x02, 0x0a, 0x13, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x65,
+	0x63, 0x72, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x46, 0x0a, 0x07, 0x63,
+	0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x6b,
+	0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65,
+	0x2e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74,
+	0x65, 0x78, 0x74, 0x12, 0x47, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x33, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73,
+	0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53, 0x65, 0x63, 0x72,
+	0x65, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x6d, 0x0a, 0x0b, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18,
+	0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x4c, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73,
+	0x61, 0x70, 0x69, 0x73, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x2e, 0x72, 0x65, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x44, 0x61, 0x74, 0x61, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x0a, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x44, 0x61, 0x74, 0x61, 0x1a,
+	0x3d, 0x0a, 0x0f, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x44, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x16,
+	0x0a, 0x14, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x5f, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x53, 0x65, 0x63,
+	0x72, 0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x46, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x2c, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e,
+	0x63, 0x6f, 0x72, 0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x76, 0x31,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x52, 0x07,
+	0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x22, 0x82, 0x02, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x53,
+	0x65, 0x63, 0x72, 0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x73, 0x0a, 0x0c, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x5f, 0x6e, 0x61, 0x6d,
+	0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x50, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61,
+	0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x2e,
+	0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74,
+	0x4e, 0x61, 0x6d, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x73, 0x65, 0x63, 0x72,
+	0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x1a, 0x73, 0x0a, 0x10, 0x53, 0x65, 0x63, 0x72, 0x65,
+	0x74, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x49, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x33, 0x2e, 0x6b,
+	0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x70, 0x6c, 0x75, 0x67,
+	0x69, 0x6e, 0x73, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x2e, 0x76, 0x31,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x54, 0x79, 0x70,
+	0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x9b, 0x01, 0x0a,
+	0x0b, 0x43, 0x61, 0x6e, 0x49, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x46, 0x0a, 0x07,
+	0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2c, 0x2e,
+	0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72,
+	0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x52, 0x07, 0x63, 0x6f, 0x6e,
+	0x74, 0x65, 0x78, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x76, 0x65, 0x72, 0x62, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x76, 0x65, 0x72, 0x62, 0x22, 0x28, 0x0a, 0x0c, 0x43, 0x61,
+	0x6e, 0x49, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x6c,
+	0x6c, 0x6f, 0x77, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x61, 0x6c, 0x6c,
+	0x6f, 0x77, 0x65, 0x64, 0x2a, 0x88, 0x01, 0x0a, 0x0d, 0x52, 0x6f, 0x6c, 0x6c, 0x6f, 0x75, 0x74,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1e, 0x0a, 0x1a, 0x52, 0x4f, 0x4c, 0x4c, 0x4f, 0x55,
+	0x54, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49,
+	0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1e, 0x0a, 0x1a, 0x52, 0x4f, 0x4c, 0x4c, 0x4f, 0x55,
+	0x54, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x49, 0x4e, 0x5f, 0x50, 0x52, 0x4f, 0x47,
+	0x52, 0x45, 0x53, 0x53, 0x10, 0x01, 0x12, 0x1c, 0x0a, 0x18, 0x52, 0x4f, 0x4c, 0x4c, 0x4f, 0x55,
+	0x54, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x53, 0x55, 0x43, 0x43, 0x45, 0x45, 0x44,
+	0x45, 0x44, 0x10, 0x02, 0x12, 0x19, 0x0a, 0x15, 0x52, 0x4f, 0x4c, 0x4c, 0x4f, 0x55, 0x54, 0x5f,
+	0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x03, 0x2a,
+	0x58, 0x0a, 0x0e, 0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x53, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x12, 0x24, 0x0a, 0x20, 0x4d, 0x41, 0x4e, 0x49, 0x46, 0x45, 0x53, 0x54, 0x5f, 0x53, 0x4f,
+	0x55, 0x52, 0x43, 0x45, 0x5f, 0x4c, 0x49, 0x56, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43,
+	0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x20, 0x0a, 0x1c, 0x4d, 0x41, 0x4e, 0x49, 0x46,
+	0x45, 0x53, 0x54, 0x5f, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x4c, 0x41, 0x53, 0x54, 0x5f,
+	0x41, 0x50, 0x50, 0x4c, 0x49, 0x45, 0x44, 0x10, 0x01, 0x2a, 0x86, 0x02, 0x0a, 0x0a, 0x53, 0x65,
+	0x63, 0x72, 0x65, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x22, 0x0a, 0x1e, 0x53, 0x45, 0x43, 0x52,
+	0x45, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4f, 0x50, 0x41, 0x51, 0x55, 0x45, 0x5f, 0x55,
+	0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x25, 0x0a, 0x21,
+	0x53, 0x45, 0x43, 0x52, 0x45, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x53, 0x45, 0x52, 0x56,
+	0x49, 0x43, 0x45, 0x5f, 0x41, 0x43, 0x43, 0x4f, 0x55, 0x4e, 0x54, 0x5f, 0x54, 0x4f, 0x4b, 0x45,
+	0x4e, 0x10, 0x01, 0x12, 0x1d, 0x0a, 0x19, 0x53, 0x45, 0x43, 0x52, 0x45, 0x54, 0x5f, 0x54, 0x59,
+	0x50, 0x45, 0x5f, 0x44, 0x4f, 0x43, 0x4b, 0x45, 0x52, 0x5f, 0x43, 0x4f, 0x4e, 0x46, 0x49, 0x47,
+	0x10, 0x02, 0x12, 0x22, 0x0a, 0x1e, 0x53, 0x45, 0x43, 0x52, 0x45, 0x54, 0x5f, 0x54, 0x59, 0x50,
+	0x45, 0x5f, 0x44, 0x4f, 0x43, 0x4b, 0x45, 0x52, 0x5f, 0x43, 0x4f, 0x4e, 0x46, 0x49, 0x47, 0x5f,
+	0x4a, 0x53, 0x4f, 0x4e, 0x10, 0x03, 0x12, 0x1a, 0x0a, 0x16, 0x53, 0x45, 0x43, 0x52, 0x45, 0x54,
+	0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x42, 0x41, 0x53, 0x49, 0x43, 0x5f, 0x41, 0x55, 0x54, 0x48,
+	0x10, 0x04, 0x12, 0x18, 0x0a, 0x14, 0x53, 0x45, 0x43, 0x52, 0x45, 0x54, 0x5f, 0x54, 0x59, 0x50,
+	0x45, 0x5f, 0x53, 0x53, 0x48, 0x5f, 0x41, 0x55, 0x54, 0x48, 0x10, 0x05, 0x12, 0x13, 0x0a, 0x0f,
+	0x53, 0x45, 0x43, 0x52, 0x45, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x54, 0x4c, 0x53, 0x10,
+	0x06, 0x12, 0x1f, 0x0a, 0x1b, 0x53, 0x45, 0x43, 0x52, 0x45, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45,
+	0x5f, 0x42, 0x4f, 0x4f, 0x54, 0x53, 0x54, 0x52, 0x41, 0x50, 0x5f, 0x54, 0x4f, 0x4b, 0x45, 0x4e,
+	0x10, 0x07, 0x32, 0xa1, 0x20, 0x0a, 0x10, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0xfa, 0x02, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x52,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x3c, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61,
+	0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x2e,
+	0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x3d, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70,
+	0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x2e, 0x72, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
+	0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xea, 0x01, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0xe3, 0x01, 0x12,
+	0xe0, 0x01, 0x2f, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x2f, 0x72, 0x65, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x73, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2f, 0x7b, 0x69,
+	0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65,
+	0x5f, 0x72, 0x65, 0x66, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x6e, 0x61, 0x6d, 0x65,
+	0x7d, 0x2f, 0x7b, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x5f, 0x70, 0x61, 0x63,
+	0x6b, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x66, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x7d, 0x2f, 0x63, 0x2f, 0x7b, 0x69, 0x6e, 0x73, 0x74,
+	0x61, 0x6c, 0x6c, 0x65, 0x64, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65,
+	0x66, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65,
+	0x72, 0x7d, 0x2f, 0x6e, 0x73, 0x2f, 0x7b, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64,
+	0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x66, 0x2e, 0x63, 0x6f, 0x6e,
+	0x74, 0x65, 0x78, 0x74, 0x2e, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x7d, 0x2f,
+	0x7b, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61,
+	0x67, 0x65, 0x5f, 0x72, 0x65, 0x66, 0x2e, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65,
+	0x72, 0x7d, 0x30, 0x01, 0x12, 0x94, 0x03, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x6c, 0x6c,
+	0x6f, 0x75, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x40, 0x2e, 0x6b, 0x75, 0x62, 0x65,
+	0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73,
+	0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x6c, 0x6c, 0x6f, 0x75, 0x74, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x41, 0x2e, 0x6b, 0x75,
 	0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69,
 	0x6e, 0x73, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61,
-	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x65, 0x63, 0x72,
-	0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67,
-	0x44, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0a, 0x73, 0x74, 0x72, 0x69, 0x6e,
-	0x67, 0x44, 0x61, 0x74, 0x61, 0x1a, 0x3d, 0x0a, 0x0f, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x44,
-	0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x3a, 0x02, 0x38, 0x01, 0x22, 0x16, 0x0a, 0x14, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x65,
-	0x63, 0x72, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x5f, 0x0a, 0x15,
-	0x47, 0x65, 0x74, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x46, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70,
-	0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x61,
-	0x67, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x6f, 0x6e,
-	0x74, 0x65, 0x78, 0x74, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x22, 0x82, 0x02,
-	0x0a, 0x16, 0x47, 0x65, 0x74, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x73,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x73, 0x0a, 0x0c, 0x73, 0x65, 0x63, 0x72,
-	0x65, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x50,
+	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x6c, 0x6c, 0x6f, 0x75, 0x74,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xf8,
+	0x01, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0xf1, 0x01, 0x12, 0xee, 0x01, 0x2f, 0x70, 0x6c, 0x75, 0x67,
+	0x69, 0x6e, 0x73, 0x2f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x2f, 0x76, 0x31,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2f, 0x7b, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65,
+	0x64, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x66, 0x2e, 0x70, 0x6c,
+	0x75, 0x67, 0x69, 0x6e, 0x2e, 0x6e, 0x61, 0x6d, 0x65, 0x7d, 0x2f, 0x7b, 0x69, 0x6e, 0x73, 0x74,
+	0x61, 0x6c, 0x6c, 0x65, 0x64, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65,
+	0x66, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x7d, 0x2f, 0x63, 0x2f, 0x7b, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x5f, 0x70,
+	0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x66, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x65,
+	0x78, 0x74, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x7d, 0x2f, 0x6e, 0x73, 0x2f, 0x7b,
+	0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67,
+	0x65, 0x5f, 0x72, 0x65, 0x66, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x2e, 0x6e, 0x61,
+	0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x7d, 0x2f, 0x7b, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c,
+	0x6c, 0x65, 0x64, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x66, 0x2e,
+	0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x7d, 0x2f, 0x72, 0x6f, 0x6c, 0x6c,
+	0x6f, 0x75, 0x74, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x30, 0x01, 0x12, 0x92, 0x03, 0x0a, 0x11,
+	0x47, 0x65, 0x74, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x69, 0x6e, 0x67, 0x49, 0x6e, 0x66,
+	0x6f, 0x12, 0x41, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73,
+	0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x53,
+	0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x69, 0x6e, 0x67, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x42, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61,
+	0x70, 0x69, 0x73, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x2e, 0x72, 0x65, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x47,
+	0x65, 0x74, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x69, 0x6e, 0x67, 0x49, 0x6e, 0x66, 0x6f,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xf5, 0x01, 0x82, 0xd3, 0xe4, 0x93, 0x02,
+	0xee, 0x01, 0x12, 0xeb, 0x01, 0x2f, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x2f, 0x72, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
+	0x2f, 0x7b, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x5f, 0x70, 0x61, 0x63, 0x6b,
+	0x61, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x66, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x6e,
+	0x61, 0x6d, 0x65, 0x7d, 0x2f, 0x7b, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x5f,
+	0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x66, 0x2e, 0x70, 0x6c, 0x75, 0x67,
+	0x69, 0x6e, 0x2e, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x7d, 0x2f, 0x63, 0x2f, 0x7b, 0x69,
+	0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65,
+	0x5f, 0x72, 0x65, 0x66, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x2e, 0x63, 0x6c, 0x75,
+	0x73, 0x74, 0x65, 0x72, 0x7d, 0x2f, 0x6e, 0x73, 0x2f, 0x7b, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c,
+	0x6c, 0x65, 0x64, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x66, 0x2e,
+	0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x2e, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63,
+	0x65, 0x7d, 0x2f, 0x7b, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x5f, 0x70, 0x61,
+	0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x66, 0x2e, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69,
+	0x66, 0x69, 0x65, 0x72, 0x7d, 0x2f, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x69, 0x6e, 0x67,
+	0x12, 0xa6, 0x03, 0x0a, 0x19, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65,
+	0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x49,
 	0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x70, 0x6c,
 	0x75, 0x67, 0x69, 0x6e, 0x73, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x2e,
-	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x65, 0x63, 0x72,
-	0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e,
-	0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
-	0x52, 0x0b, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x1a, 0x73, 0x0a,
-	0x10, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72,
-	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
-	0x6b, 0x65, 0x79, 0x12, 0x49, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0e, 0x32, 0x33, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69,
-	0x73, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72,
-	0x63, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53, 0x65, 0x63,
-	0x72, 0x65, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
-	0x38, 0x01, 0x22, 0x9b, 0x01, 0x0a, 0x0b, 0x43, 0x61, 0x6e, 0x49, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x46, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70,
-	0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73,
-	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78,
-	0x74, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x72,
-	0x6f, 0x75, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70,
-	0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04,
-	0x76, 0x65, 0x72, 0x62, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x76, 0x65, 0x72, 0x62,
-	0x22, 0x28, 0x0a, 0x0c, 0x43, 0x61, 0x6e, 0x49, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x18, 0x0a, 0x07, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x08, 0x52, 0x07, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x2a, 0x86, 0x02, 0x0a, 0x0a, 0x53,
-	0x65, 0x63, 0x72, 0x65, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x22, 0x0a, 0x1e, 0x53, 0x45, 0x43,
-	0x52, 0x45, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4f, 0x50, 0x41, 0x51, 0x55, 0x45, 0x5f,
-	0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x25, 0x0a,
-	0x21, 0x53, 0x45, 0x43, 0x52, 0x45, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x53, 0x45, 0x52,
-	0x56, 0x49, 0x43, 0x45, 0x5f, 0x41, 0x43, 0x43, 0x4f, 0x55, 0x4e, 0x54, 0x5f, 0x54, 0x4f, 0x4b,
-	0x45, 0x4e, 0x10, 0x01, 0x12, 0x1d, 0x0a, 0x19, 0x53, 0x45, 0x43, 0x52, 0x45, 0x54, 0x5f, 0x54,
-	0x59, 0x50, 0x45, 0x5f, 0x44, 0x4f, 0x43, 0x4b, 0x45, 0x52, 0x5f, 0x43, 0x4f, 0x4e, 0x46, 0x49,
-	0x47, 0x10, 0x02, 0x12, 0x22, 0x0a, 0x1e, 0x53, 0x45, 0x43, 0x52, 0x45, 0x54, 0x5f, 0x54, 0x59,
-	0x50, 0x45, 0x5f, 0x44, 0x4f, 0x43, 0x4b, 0x45, 0x52, 0x5f, 0x43, 0x4f, 0x4e, 0x46, 0x49, 0x47,
-	0x5f, 0x4a, 0x53, 0x4f, 0x4e, 0x10, 0x03, 0x12, 0x1a, 0x0a, 0x16, 0x53, 0x45, 0x43, 0x52, 0x45,
-	0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x42, 0x41, 0x53, 0x49, 0x43, 0x5f, 0x41, 0x55, 0x54,
-	0x48, 0x10, 0x04, 0x12, 0x18, 0x0a, 0x14, 0x53, 0x45, 0x43, 0x52, 0x45, 0x54, 0x5f, 0x54, 0x59,
-	0x50, 0x45, 0x5f, 0x53, 0x53, 0x48, 0x5f, 0x41, 0x55, 0x54, 0x48, 0x10, 0x05, 0x12, 0x13, 0x0a,
-	0x0f, 0x53, 0x45, 0x43, 0x52, 0x45, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x54, 0x4c, 0x53,
-	0x10, 0x06, 0x12, 0x1f, 0x0a, 0x1b, 0x53, 0x45, 0x43, 0x52, 0x45, 0x54, 0x5f, 0x54, 0x59, 0x50,
-	0x45, 0x5f, 0x42, 0x4f, 0x4f, 0x54, 0x53, 0x54, 0x52, 0x41, 0x50, 0x5f, 0x54, 0x4f, 0x4b, 0x45,
-	0x4e, 0x10, 0x07, 0x32, 0xd0, 0x0f, 0x0a, 0x10, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
-	0x73, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0xfa, 0x02, 0x0a, 0x0c, 0x47, 0x65, 0x74,
-	0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x3c, 0x2e, 0x6b, 0x75, 0x62, 0x65,
+	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x73, 0x74,
+	0x61, 0x6c, 0x6c, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x4a, 0x2e, 0x6b, 0x75, 0x62, 0x65,
 	0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73,
 	0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
-	0x68, 0x61, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x3d, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70,
-	0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x2e, 0x72,
-	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
-	0x31, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xea, 0x01, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0xe3, 0x01,
-	0x12, 0xe0, 0x01, 0x2f, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x2f, 0x72, 0x65, 0x73, 0x6f,
-	0x75, 0x72, 0x63, 0x65, 0x73, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2f, 0x7b,
-	0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67,
-	0x65, 0x5f, 0x72, 0x65, 0x66, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x6e, 0x61, 0x6d,
-	0x65, 0x7d, 0x2f, 0x7b, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x5f, 0x70, 0x61,
-	0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x66, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e,
-	0x2e, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x7d, 0x2f, 0x63, 0x2f, 0x7b, 0x69, 0x6e, 0x73,
-	0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x72,
-	0x65, 0x66, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x74,
-	0x65, 0x72, 0x7d, 0x2f, 0x6e, 0x73, 0x2f, 0x7b, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65,
-	0x64, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x66, 0x2e, 0x63, 0x6f,
-	0x6e, 0x74, 0x65, 0x78, 0x74, 0x2e, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x7d,
+	0x68, 0x61, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64,
+	0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xf1, 0x01, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0xea, 0x01, 0x12,
+	0xe7, 0x01, 0x2f, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x2f, 0x72, 0x65, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x73, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2f, 0x7b, 0x69,
+	0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65,
+	0x5f, 0x72, 0x65, 0x66, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x6e, 0x61, 0x6d, 0x65,
+	0x7d, 0x2f, 0x7b, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x5f, 0x70, 0x61, 0x63,
+	0x6b, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x66, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x7d, 0x2f, 0x63, 0x2f, 0x7b, 0x69, 0x6e, 0x73, 0x74,
+	0x61, 0x6c, 0x6c, 0x65, 0x64, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65,
+	0x66, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65,
+	0x72, 0x7d, 0x2f, 0x6e, 0x73, 0x2f, 0x7b, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64,
+	0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x66, 0x2e, 0x63, 0x6f, 0x6e,
+	0x74, 0x65, 0x78, 0x74, 0x2e, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x7d, 0x2f,
+	0x7b, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61,
+	0x67, 0x65, 0x5f, 0x72, 0x65, 0x66, 0x2e, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65,
+	0x72, 0x7d, 0x2f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12, 0xbe, 0x03, 0x0a, 0x1f, 0x47, 0x65,
+	0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67,
+	0x65, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x4f, 0x2e,
+	0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x70, 0x6c, 0x75,
+	0x67, 0x69, 0x6e, 0x73, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x2e, 0x76,
+	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61,
+	0x6c, 0x6c, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x43, 0x75, 0x73, 0x74, 0x6f,
+	0x6d, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x50,
+	0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x70, 0x6c,
+	0x75, 0x67, 0x69, 0x6e, 0x73, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x2e,
+	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x73, 0x74,
+	0x61, 0x6c, 0x6c, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x43, 0x75, 0x73, 0x74,
+	0x6f, 0x6d, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0xf7, 0x01, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0xf0, 0x01, 0x12, 0xed, 0x01, 0x2f, 0x70, 0x6c,
+	0x75, 0x67, 0x69, 0x6e, 0x73, 0x2f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x2f,
+	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2f, 0x7b, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c,
+	0x6c, 0x65, 0x64, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x66, 0x2e,
+	0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x6e, 0x61, 0x6d, 0x65, 0x7d, 0x2f, 0x7b, 0x69, 0x6e,
+	0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f,
+	0x72, 0x65, 0x66, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x7d, 0x2f, 0x63, 0x2f, 0x7b, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64,
+	0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x66, 0x2e, 0x63, 0x6f, 0x6e,
+	0x74, 0x65, 0x78, 0x74, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x7d, 0x2f, 0x6e, 0x73,
 	0x2f, 0x7b, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x5f, 0x70, 0x61, 0x63, 0x6b,
-	0x61, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x66, 0x2e, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69,
-	0x65, 0x72, 0x7d, 0x30, 0x01, 0x12, 0x8d, 0x02, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x53, 0x65, 0x72,
+	0x61, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x66, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x2e,
+	0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x7d, 0x2f, 0x7b, 0x69, 0x6e, 0x73, 0x74,
+	0x61, 0x6c, 0x6c, 0x65, 0x64, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65,
+	0x66, 0x2e, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x7d, 0x2f, 0x63, 0x75,
+	0x73, 0x74, 0x6f, 0x6d, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0xb8, 0x03, 0x0a, 0x1f, 0x45,
+	0x78, 0x70, 0x6f, 0x72, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x50, 0x61,
+	0x63, 0x6b, 0x61, 0x67, 0x65, 0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x73, 0x12, 0x4f,
+	0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x70, 0x6c,
+	0x75, 0x67, 0x69, 0x6e, 0x73, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x2e,
+	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x49,
+	0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x4d,
+	0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x50, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x70,
+	0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73,
+	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74,
+	0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65,
+	0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0xf1, 0x01, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0xea, 0x01, 0x12, 0xe7, 0x01, 0x2f, 0x70,
+	0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x2f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73,
+	0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2f, 0x7b, 0x69, 0x6e, 0x73, 0x74, 0x61,
+	0x6c, 0x6c, 0x65, 0x64, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x66,
+	0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x6e, 0x61, 0x6d, 0x65, 0x7d, 0x2f, 0x7b, 0x69,
+	0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65,
+	0x5f, 0x72, 0x65, 0x66, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x7d, 0x2f, 0x63, 0x2f, 0x7b, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65,
+	0x64, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x66, 0x2e, 0x63, 0x6f,
+	0x6e, 0x74, 0x65, 0x78, 0x74, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x7d, 0x2f, 0x6e,
+	0x73, 0x2f, 0x7b, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x5f, 0x70, 0x61, 0x63,
+	0x6b, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x66, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74,
+	0x2e, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x7d, 0x2f, 0x7b, 0x69, 0x6e, 0x73,
+	0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x72,
+	0x65, 0x66, 0x2e, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x7d, 0x2f, 0x65,
+	0x78, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x8d, 0x02, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x53, 0x65, 0x72,
 	0x76, 0x69, 0x63, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x73,
 	0x12, 0x46, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e,
 	0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
@@ -1342,69 +2743,112 @@ func file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_rawDescGZIP()
 	return file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_rawDescData
 }
 
-var file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
+var file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes = make([]protoimpl.MessageInfo, 33)
 var file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_goTypes = []interface{}{
-	(SecretType)(0),                            // 0: kubeappsapis.plugins.resources.v1alpha1.SecretType
-	(*GetResourcesRequest)(nil),                // 1: kubeappsapis.plugins.resources.v1alpha1.GetResourcesRequest
-	(*GetResourcesResponse)(nil),               // 2: kubeappsapis.plugins.resources.v1alpha1.GetResourcesResponse
-	(*GetServiceAccountNamesRequest)(nil),      // 3: kubeappsapis.plugins.resources.v1alpha1.GetServiceAccountNamesRequest
-	(*GetServiceAccountNamesResponse)(nil),     // 4: kubeappsapis.plugins.resources.v1alpha1.GetServiceAccountNamesResponse
-	(*GetNamespaceNamesRequest)(nil),           // 5: kubeappsapis.plugins.resources.v1alpha1.GetNamespaceNamesRequest
-	(*GetNamespaceNamesResponse)(nil),          // 6: kubeappsapis.plugins.resources.v1alpha1.GetNamespaceNamesResponse
-	(*CreateNamespaceRequest)(nil),             // 7: kubeappsapis.plugins.resources.v1alpha1.CreateNamespaceRequest
-	(*CreateNamespaceResponse)(nil),            // 8: kubeappsapis.plugins.resources.v1alpha1.CreateNamespaceResponse
-	(*CheckNamespaceExistsRequest)(nil),        // 9: kubeappsapis.plugins.resources.v1alpha1.CheckNamespaceExistsRequest
-	(*CheckNamespaceExistsResponse)(nil),       // 10: kubeappsapis.plugins.resources.v1alpha1.CheckNamespaceExistsResponse
-	(*CreateSecretRequest)(nil),                // 11: kubeappsapis.plugins.resources.v1alpha1.CreateSecretRequest
-	(*CreateSecretResponse)(nil),               // 12: kubeappsapis.plugins.resources.v1alpha1.CreateSecretResponse
-	(*GetSecretNamesRequest)(nil),              // 13: kubeappsapis.plugins.resources.v1alpha1.GetSecretNamesRequest
-	(*GetSecretNamesResponse)(nil),             // 14: kubeappsapis.plugins.resources.v1alpha1.GetSecretNamesResponse
-	(*CanIRequest)(nil),                        // 15: kubeappsapis.plugins.resources.v1alpha1.CanIRequest
-	(*CanIResponse)(nil),                       // 16: kubeappsapis.plugins.resources.v1alpha1.CanIResponse
-	nil,                                        // 17: kubeappsapis.plugins.resources.v1alpha1.CreateNamespaceRequest.LabelsEntry
-	nil,                                        // 18: kubeappsapis.plugins.resources.v1alpha1.CreateSecretRequest.StringDataEntry
-	nil,                                        // 19: kubeappsapis.plugins.resources.v1alpha1.GetSecretNamesResponse.SecretNamesEntry
-	(*v1alpha1.InstalledPackageReference)(nil), // 20: kubeappsapis.core.packages.v1alpha1.InstalledPackageReference
-	(*v1alpha1.ResourceRef)(nil),               // 21: kubeappsapis.core.packages.v1alpha1.ResourceRef
-	(*v1alpha1.Context)(nil),                   // 22: kubeappsapis.core.packages.v1alpha1.Context
+	(RolloutStatus)(0),                              // 0: kubeappsapis.plugins.resources.v1alpha1.RolloutStatus
+	(ManifestSource)(0),                             // 1: kubeappsapis.plugins.resources.v1alpha1.ManifestSource
+	(SecretType)(0),                                 // 2: kubeappsapis.plugins.resources.v1alpha1.SecretType
+	(*GetResourcesRequest)(nil),                     // 3: kubeappsapis.plugins.resources.v1alpha1.GetResourcesRequest
+	(*GetResourcesResponse)(nil),                    // 4: kubeappsapis.plugins.resources.v1alpha1.GetResourcesResponse
+	(*GetRolloutStatusRequest)(nil),                 // 5: kubeappsapis.plugins.resources.v1alpha1.GetRolloutStatusRequest
+	(*GetRolloutStatusResponse)(nil),                // 6: kubeappsapis.plugins.resources.v1alpha1.GetRolloutStatusResponse
+	(*GetSchedulingInfoRequest)(nil),                // 7: kubeappsapis.plugins.resources.v1alpha1.GetSchedulingInfoRequest
+	(*GetSchedulingInfoResponse)(nil),               // 8: kubeappsapis.plugins.resources.v1alpha1.GetSchedulingInfoResponse
+	(*PodSchedulingInfo)(nil),                       // 9: kubeappsapis.plugins.resources.v1alpha1.PodSchedulingInfo
+	(*GetInstalledPackageEventsRequest)(nil),        // 10: kubeappsapis.plugins.resources.v1alpha1.GetInstalledPackageEventsRequest
+	(*GetInstalledPackageEventsResponse)(nil),       // 11: kubeappsapis.plugins.resources.v1alpha1.GetInstalledPackageEventsResponse
+	(*ResourceEvent)(nil),                           // 12: kubeappsapis.plugins.resources.v1alpha1.ResourceEvent
+	(*GetInstalledPackageCustomFieldsRequest)(nil),  // 13: kubeappsapis.plugins.resources.v1alpha1.GetInstalledPackageCustomFieldsRequest
+	(*GetInstalledPackageCustomFieldsResponse)(nil), // 14: kubeappsapis.plugins.resources.v1alpha1.GetInstalledPackageCustomFieldsResponse
+	(*CustomField)(nil),                             // 15: kubeappsapis.plugins.resources.v1alpha1.CustomField
+	(*ExportInstalledPackageManifestsRequest)(nil),  // 16: kubeappsapis.plugins.resources.v1alpha1.ExportInstalledPackageManifestsRequest
+	(*ExportInstalledPackageManifestsResponse)(nil), // 17: kubeappsapis.plugins.resources.v1alpha1.ExportInstalledPackageManifestsResponse
+	(*GetServiceAccountNamesRequest)(nil),           // 18: kubeappsapis.plugins.resources.v1alpha1.GetServiceAccountNamesRequest
+	(*GetServiceAccountNamesResponse)(nil),          // 19: kubeappsapis.plugins.resources.v1alpha1.GetServiceAccountNamesResponse
+	(*GetNamespaceNamesRequest)(nil),                // 20: kubeappsapis.plugins.resources.v1alpha1.GetNamespaceNamesRequest
+	(*GetNamespaceNamesResponse)(nil),               // 21: kubeappsapis.plugins.resources.v1alpha1.GetNamespaceNamesResponse
+	(*CreateNamespaceRequest)(nil),                  // 22: kubeappsapis.plugins.resources.v1alpha1.CreateNamespaceRequest
+	(*CreateNamespaceResponse)(nil),                 // 23: kubeappsapis.plugins.resources.v1alpha1.CreateNamespaceResponse
+	(*CheckNamespaceExistsRequest)(nil),             // 24: kubeappsapis.plugins.resources.v1alpha1.CheckNamespaceExistsRequest
+	(*CheckNamespaceExistsResponse)(nil),            // 25: kubeappsapis.plugins.resources.v1alpha1.CheckNamespaceExistsResponse
+	(*CreateSecretRequest)(nil),                     // 26: kubeappsapis.plugins.resources.v1alpha1.CreateSecretRequest
+	(*CreateSecretResponse)(nil),                    // 27: kubeappsapis.plugins.resources.v1alpha1.CreateSecretResponse
+	(*GetSecretNamesRequest)(nil),                   // 28: kubeappsapis.plugins.resources.v1alpha1.GetSecretNamesRequest
+	(*GetSecretNamesResponse)(nil),                  // 29: kubeappsapis.plugins.resources.v1alpha1.GetSecretNamesResponse
+	(*CanIRequest)(nil),                             // 30: kubeappsapis.plugins.resources.v1alpha1.CanIRequest
+	(*CanIResponse)(nil),                            // 31: kubeappsapis.plugins.resources.v1alpha1.CanIResponse
+	nil,                                             // 32: kubeappsapis.plugins.resources.v1alpha1.PodSchedulingInfo.NodeSelectorEntry
+	nil,                                             // 33: kubeappsapis.plugins.resources.v1alpha1.CreateNamespaceRequest.LabelsEntry
+	nil,                                             // 34: kubeappsapis.plugins.resources.v1alpha1.CreateSecretRequest.StringDataEntry
+	nil,                                             // 35: kubeappsapis.plugins.resources.v1alpha1.GetSecretNamesResponse.SecretNamesEntry
+	(*v1alpha1.InstalledPackageReference)(nil),      // 36: kubeappsapis.core.packages.v1alpha1.InstalledPackageReference
+	(*v1alpha1.ResourceRef)(nil),                    // 37: kubeappsapis.core.packages.v1alpha1.ResourceRef
+	(*v1alpha1.Context)(nil),                        // 38: kubeappsapis.core.packages.v1alpha1.Context
 }
 var file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_depIdxs = []int32{
-	20, // 0: kubeappsapis.plugins.resources.v1alpha1.GetResourcesRequest.installed_package_ref:type_name -> kubeappsapis.core.packages.v1alpha1.InstalledPackageReference
-	21, // 1: kubeappsapis.plugins.resources.v1alpha1.GetResourcesRequest.resource_refs:type_name -> kubeappsapis.core.packages.v1alpha1.ResourceRef
-	21, // 2: kubeappsapis.plugins.resources.v1alpha1.GetResourcesResponse.resource_ref:type_name -> kubeappsapis.core.packages.v1alpha1.ResourceRef
-	22, // 3: kubeappsapis.plugins.resources.v1alpha1.GetServiceAccountNamesRequest.context:type_name -> kubeappsapis.core.packages.v1alpha1.Context
-	22, // 4: kubeappsapis.plugins.resources.v1alpha1.CreateNamespaceRequest.context:type_name -> kubeappsapis.core.packages.v1alpha1.Context
-	17, // 5: kubeappsapis.plugins.resources.v1alpha1.CreateNamespaceRequest.labels:type_name -> kubeappsapis.plugins.resources.v1alpha1.CreateNamespaceRequest.LabelsEntry
-	22, // 6: kubeappsapis.plugins.resources.v1alpha1.CheckNamespaceExistsRequest.context:type_name -> kubeappsapis.core.packages.v1alpha1.Context
-	22, // 7: kubeappsapis.plugins.resources.v1alpha1.CreateSecretRequest.context:type_name -> kubeappsapis.core.packages.v1alpha1.Context
-	0,  // 8: kubeappsapis.plugins.resources.v1alpha1.CreateSecretRequest.type:type_name -> kubeappsapis.plugins.resources.v1alpha1.SecretType
-	18, // 9: kubeappsapis.plugins.resources.v1alpha1.CreateSecretRequest.string_data:type_name -> kubeappsapis.plugins.resources.v1alpha1.CreateSecretRequest.StringDataEntry
-	22, // 10: kubeappsapis.plugins.resources.v1alpha1.GetSecretNamesRequest.context:type_name -> kubeappsapis.core.packages.v1alpha1.Context
-	19, // 11: kubeappsapis.plugins.resources.v1alpha1.GetSecretNamesResponse.secret_names:type_name -> kubeappsapis.plugins.resources.v1alpha1.GetSecretNamesResponse.SecretNamesEntry
-	22, // 12: kubeappsapis.plugins.resources.v1alpha1.CanIRequest.context:type_name -> kubeappsapis.core.packages.v1alpha1.Context
-	0,  // 13: kubeappsapis.plugins.resources.v1alpha1.GetSecretNamesResponse.SecretNamesEntry.value:type_name -> kubeappsapis.plugins.resources.v1alpha1.SecretType
-	1,  // 14: kubeappsapis.plugins.resources.v1alpha1.ResourcesService.GetResources:input_type -> kubeappsapis.plugins.resources.v1alpha1.GetResourcesRequest
-	3,  // 15: kubeappsapis.plugins.resources.v1alpha1.ResourcesService.GetServiceAccountNames:input_type -> kubeappsapis.plugins.resources.v1alpha1.GetServiceAccountNamesRequest
-	5,  // 16: kubeappsapis.plugins.resources.v1alpha1.ResourcesService.GetNamespaceNames:input_type -> kubeappsapis.plugins.resources.v1alpha1.GetNamespaceNamesRequest
-	7,  // 17: kubeappsapis.plugins.resources.v1alpha1.ResourcesService.CreateNamespace:input_type -> kubeappsapis.plugins.resources.v1alpha1.CreateNamespaceRequest
-	9,  // 18: kubeappsapis.plugins.resources.v1alpha1.ResourcesService.CheckNamespaceExists:input_type -> kubeappsapis.plugins.resources.v1alpha1.CheckNamespaceExistsRequest
-	13, // 19: kubeappsapis.plugins.resources.v1alpha1.ResourcesService.GetSecretNames:input_type -> kubeappsapis.plugins.resources.v1alpha1.GetSecretNamesRequest
-	11, // 20: kubeappsapis.plugins.resources.v1alpha1.ResourcesService.CreateSecret:input_type -> kubeappsapis.plugins.resources.v1alpha1.CreateSecretRequest
-	15, // 21: kubeappsapis.plugins.resources.v1alpha1.ResourcesService.CanI:input_type -> kubeappsapis.plugins.resources.v1alpha1.CanIRequest
-	2,  // 22: kubeappsapis.plugins.resources.v1alpha1.ResourcesService.GetResources:output_type -> kubeappsapis.plugins.resources.v1alpha1.GetResourcesResponse
-	4,  // 23: kubeappsapis.plugins.resources.v1alpha1.ResourcesService.GetServiceAccountNames:output_type -> kubeappsapis.plugins.resources.v1alpha1.GetServiceAccountNamesResponse
-	6,  // 24: kubeappsapis.plugins.resources.v1alpha1.ResourcesService.GetNamespaceNames:output_type -> kubeappsapis.plugins.resources.v1alpha1.GetNamespaceNamesResponse
-	8,  // 25: kubeappsapis.plugins.resources.v1alpha1.ResourcesService.CreateNamespace:output_type -> kubeappsapis.plugins.resources.v1alpha1.CreateNamespaceResponse
-	10, // 26: kubeappsapis.plugins.resources.v1alpha1.ResourcesService.CheckNamespaceExists:output_type -> kubeappsapis.plugins.resources.v1alpha1.CheckNamespaceExistsResponse
-	14, // 27: kubeappsapis.plugins.resources.v1alpha1.ResourcesService.GetSecretNames:output_type -> kubeappsapis.plugins.resources.v1alpha1.GetSecretNamesResponse
-	12, // 28: kubeappsapis.plugins.resources.v1alpha1.ResourcesService.CreateSecret:output_type -> kubeappsapis.plugins.resources.v1alpha1.CreateSecretResponse
-	16, // 29: kubeappsapis.plugins.resources.v1alpha1.ResourcesService.CanI:output_type -> kubeappsapis.plugins.resources.v1alpha1.CanIResponse
-	22, // [22:30] is the sub-list for method output_type
-	14, // [14:22] is the sub-list for method input_type
-	14, // [14:14] is the sub-list for extension type_name
-	14, // [14:14] is the sub-list for extension extendee
-	0,  // [0:14] is the sub-list for field type_name
+	36, // 0: kubeappsapis.plugins.resources.v1alpha1.GetResourcesRequest.installed_package_ref:type_name -> kubeappsapis.core.packages.v1alpha1.InstalledPackageReference
+	37, // 1: kubeappsapis.plugins.resources.v1alpha1.GetResourcesRequest.resource_refs:type_name -> kubeappsapis.core.packages.v1alpha1.ResourceRef
+	37, // 2: kubeappsapis.plugins.resources.v1alpha1.GetResourcesResponse.resource_ref:type_name -> kubeappsapis.core.packages.v1alpha1.ResourceRef
+	36, // 3: kubeappsapis.plugins.resources.v1alpha1.GetRolloutStatusRequest.installed_package_ref:type_name -> kubeappsapis.core.packages.v1alpha1.InstalledPackageReference
+	37, // 4: kubeappsapis.plugins.resources.v1alpha1.GetRolloutStatusResponse.resource_ref:type_name -> kubeappsapis.core.packages.v1alpha1.ResourceRef
+	0,  // 5: kubeappsapis.plugins.resources.v1alpha1.GetRolloutStatusResponse.status:type_name -> kubeappsapis.plugins.resources.v1alpha1.RolloutStatus
+	36, // 6: kubeappsapis.plugins.resources.v1alpha1.GetSchedulingInfoRequest.installed_package_ref:type_name -> kubeappsapis.core.packages.v1alpha1.InstalledPackageReference
+	9,  // 7: kubeappsapis.plugins.resources.v1alpha1.GetSchedulingInfoResponse.pods:type_name -> kubeappsapis.plugins.resources.v1alpha1.PodSchedulingInfo
+	37, // 8: kubeappsapis.plugins.resources.v1alpha1.PodSchedulingInfo.pod_ref:type_name -> kubeappsapis.core.packages.v1alpha1.ResourceRef
+	37, // 9: kubeappsapis.plugins.resources.v1alpha1.PodSchedulingInfo.workload_ref:type_name -> kubeappsapis.core.packages.v1alpha1.ResourceRef
+	32, // 10: kubeappsapis.plugins.resources.v1alpha1.PodSchedulingInfo.node_selector:type_name -> kubeappsapis.plugins.resources.v1alpha1.PodSchedulingInfo.NodeSelectorEntry
+	36, // 11: kubeappsapis.plugins.resources.v1alpha1.GetInstalledPackageEventsRequest.installed_package_ref:type_name -> kubeappsapis.core.packages.v1alpha1.InstalledPackageReference
+	12, // 12: kubeappsapis.plugins.resources.v1alpha1.GetInstalledPackageEventsResponse.events:type_name -> kubeappsapis.plugins.resources.v1alpha1.ResourceEvent
+	37, // 13: kubeappsapis.plugins.resources.v1alpha1.ResourceEvent.resource_ref:type_name -> kubeappsapis.core.packages.v1alpha1.ResourceRef
+	36, // 14: kubeappsapis.plugins.resources.v1alpha1.GetInstalledPackageCustomFieldsRequest.installed_package_ref:type_name -> kubeappsapis.core.packages.v1alpha1.InstalledPackageReference
+	15, // 15: kubeappsapis.plugins.resources.v1alpha1.GetInstalledPackageCustomFieldsResponse.fields:type_name -> kubeappsapis.plugins.resources.v1alpha1.CustomField
+	37, // 16: kubeappsapis.plugins.resources.v1alpha1.CustomField.resource_ref:type_name -> kubeappsapis.core.packages.v1alpha1.ResourceRef
+	36, // 17: kubeappsapis.plugins.resources.v1alpha1.ExportInstalledPackageManifestsRequest.installed_package_ref:type_name -> kubeappsapis.core.packages.v1alpha1.InstalledPackageReference
+	1,  // 18: kubeappsapis.plugins.resources.v1alpha1.ExportInstalledPackageManifestsRequest.source:type_name -> kubeappsapis.plugins.resources.v1alpha1.ManifestSource
+	37, // 19: kubeappsapis.plugins.resources.v1alpha1.ExportInstalledPackageManifestsResponse.skipped_resource_refs:type_name -> kubeappsapis.core.packages.v1alpha1.ResourceRef
+	38, // 20: kubeappsapis.plugins.resources.v1alpha1.GetServiceAccountNamesRequest.context:type_name -> kubeappsapis.core.packages.v1alpha1.Context
+	38, // 21: kubeappsapis.plugins.resources.v1alpha1.CreateNamespaceRequest.context:type_name -> kubeappsapis.core.packages.v1alpha1.Context
+	33, // 22: kubeappsapis.plugins.resources.v1alpha1.CreateNamespaceRequest.labels:type_name -> kubeappsapis.plugins.resources.v1alpha1.CreateNamespaceRequest.LabelsEntry
+	38, // 23: kubeappsapis.plugins.resources.v1alpha1.CheckNamespaceExistsRequest.context:type_name -> kubeappsapis.core.packages.v1alpha1.Context
+	38, // 24: kubeappsapis.plugins.resources.v1alpha1.CreateSecretRequest.context:type_name -> kubeappsapis.core.packages.v1alpha1.Context
+	2,  // 25: kubeappsapis.plugins.resources.v1alpha1.CreateSecretRequest.type:type_name -> kubeappsapis.plugins.resources.v1alpha1.SecretType
+	34, // 26: kubeappsapis.plugins.resources.v1alpha1.CreateSecretRequest.string_data:type_name -> kubeappsapis.plugins.resources.v1alpha1.CreateSecretRequest.StringDataEntry
+	38, // 27: kubeappsapis.plugins.resources.v1alpha1.GetSecretNamesRequest.context:type_name -> kubeappsapis.core.packages.v1alpha1.Context
+	35, // 28: kubeappsapis.plugins.resources.v1alpha1.GetSecretNamesResponse.secret_names:type_name -> kubeappsapis.plugins.resources.v1alpha1.GetSecretNamesResponse.SecretNamesEntry
+	38, // 29: kubeappsapis.plugins.resources.v1alpha1.CanIRequest.context:type_name -> kubeappsapis.core.packages.v1alpha1.Context
+	2,  // 30: kubeappsapis.plugins.resources.v1alpha1.GetSecretNamesResponse.SecretNamesEntry.value:type_name -> kubeappsapis.plugins.resources.v1alpha1.SecretType
+	3,  // 31: kubeappsapis.plugins.resources.v1alpha1.ResourcesService.GetResources:input_type -> kubeappsapis.plugins.resources.v1alpha1.GetResourcesRequest
+	5,  // 32: kubeappsapis.plugins.resources.v1alpha1.ResourcesService.GetRolloutStatus:input_type -> kubeappsapis.plugins.resources.v1alpha1.GetRolloutStatusRequest
+	7,  // 33: kubeappsapis.plugins.resources.v1alpha1.ResourcesService.GetSchedulingInfo:input_type -> kubeappsapis.plugins.resources.v1alpha1.GetSchedulingInfoRequest
+	10, // 34: kubeappsapis.plugins.resources.v1alpha1.ResourcesService.GetInstalledPackageEvents:input_type -> kubeappsapis.plugins.resources.v1alpha1.GetInstalledPackageEventsRequest
+	13, // 35: kubeappsapis.plugins.resources.v1alpha1.ResourcesService.GetInstalledPackageCustomFields:input_type -> kubeappsapis.plugins.resources.v1alpha1.GetInstalledPackageCustomFieldsRequest
+	16, // 36: kubeappsapis.plugins.resources.v1alpha1.ResourcesService.ExportInstalledPackageManifests:input_type -> kubeappsapis.plugins.resources.v1alpha1.ExportInstalledPackageManifestsRequest
+	18, // 37: kubeappsapis.plugins.resources.v1alpha1.ResourcesService.GetServiceAccountNames:input_type -> kubeappsapis.plugins.resources.v1alpha1.GetServiceAccountNamesRequest
+	20, // 38: kubeappsapis.plugins.resources.v1alpha1.ResourcesService.GetNamespaceNames:input_type -> kubeappsapis.plugins.resources.v1alpha1.GetNamespaceNamesRequest
+	22, // 39: kubeappsapis.plugins.resources.v1alpha1.ResourcesService.CreateNamespace:input_type -> kubeappsapis.plugins.resources.v1alpha1.CreateNamespaceRequest
+	24, // 40: kubeappsapis.plugins.resources.v1alpha1.ResourcesService.CheckNamespaceExists:input_type -> kubeappsapis.plugins.resources.v1alpha1.CheckNamespaceExistsRequest
+	28, // 41: kubeappsapis.plugins.resources.v1alpha1.ResourcesService.GetSecretNames:input_type -> kubeappsapis.plugins.resources.v1alpha1.GetSecretNamesRequest
+	26, // 42: kubeappsapis.plugins.resources.v1alpha1.ResourcesService.CreateSecret:input_type -> kubeappsapis.plugins.resources.v1alpha1.CreateSecretRequest
+	30, // 43: kubeappsapis.plugins.resources.v1alpha1.ResourcesService.CanI:input_type -> kubeappsapis.plugins.resources.v1alpha1.CanIRequest
+	4,  // 44: kubeappsapis.plugins.resources.v1alpha1.ResourcesService.GetResources:output_type -> kubeappsapis.plugins.resources.v1alpha1.GetResourcesResponse
+	6,  // 45: kubeappsapis.plugins.resources.v1alpha1.ResourcesService.GetRolloutStatus:output_type -> kubeappsapis.plugins.resources.v1alpha1.GetRolloutStatusResponse
+	8,  // 46: kubeappsapis.plugins.resources.v1alpha1.ResourcesService.GetSchedulingInfo:output_type -> kubeappsapis.plugins.resources.v1alpha1.GetSchedulingInfoResponse
+	11, // 47: kubeappsapis.plugins.resources.v1alpha1.ResourcesService.GetInstalledPackageEvents:output_type -> kubeappsapis.plugins.resources.v1alpha1.GetInstalledPackageEventsResponse
+	14, // 48: kubeappsapis.plugins.resources.v1alpha1.ResourcesService.GetInstalledPackageCustomFields:output_type -> kubeappsapis.plugins.resources.v1alpha1.GetInstalledPackageCustomFieldsResponse
+	17, // 49: kubeappsapis.plugins.resources.v1alpha1.ResourcesService.ExportInstalledPackageManifests:output_type -> kubeappsapis.plugins.resources.v1alpha1.ExportInstalledPackageManifestsResponse
+	19, // 50: kubeappsapis.plugins.resources.v1alpha1.ResourcesService.GetServiceAccountNames:output_type -> kubeappsapis.plugins.resources.v1alpha1.GetServiceAccountNamesResponse
+	21, // 51: kubeappsapis.plugins.resources.v1alpha1.ResourcesService.GetNamespaceNames:output_type -> kubeappsapis.plugins.resources.v1alpha1.GetNamespaceNamesResponse
+	23, // 52: kubeappsapis.plugins.resources.v1alpha1.ResourcesService.CreateNamespace:output_type -> kubeappsapis.plugins.resources.v1alpha1.CreateNamespaceResponse
+	25, // 53: kubeappsapis.plugins.resources.v1alpha1.ResourcesService.CheckNamespaceExists:output_type -> kubeappsapis.plugins.resources.v1alpha1.CheckNamespaceExistsResponse
+	29, // 54: kubeappsapis.plugins.resources.v1alpha1.ResourcesService.GetSecretNames:output_type -> kubeappsapis.plugins.resources.v1alpha1.GetSecretNamesResponse
+	27, // 55: kubeappsapis.plugins.resources.v1alpha1.ResourcesService.CreateSecret:output_type -> kubeappsapis.plugins.resources.v1alpha1.CreateSecretResponse
+	31, // 56: kubeappsapis.plugins.resources.v1alpha1.ResourcesService.CanI:output_type -> kubeappsapis.plugins.resources.v1alpha1.CanIResponse
+	44, // [44:57] is the sub-list for method output_type
+	31, // [31:44] is the sub-list for method input_type
+	31, // [31:31] is the sub-list for extension type_name
+	31, // [31:31] is the sub-list for extension extendee
+	0,  // [0:31] is the sub-list for field type_name
 }
 
 func init() { file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_init() }
@@ -1438,7 +2882,7 @@ func file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_init() {
 			}
 		}
 		file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetServiceAccountNamesRequest); i {
+			switch v := v.(*GetRolloutStatusRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1450,7 +2894,7 @@ func file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_init() {
 			}
 		}
 		file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetServiceAccountNamesResponse); i {
+			switch v := v.(*GetRolloutStatusResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1462,7 +2906,7 @@ func file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_init() {
 			}
 		}
 		file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetNamespaceNamesRequest); i {
+			switch v := v.(*GetSchedulingInfoRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1474,7 +2918,7 @@ func file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_init() {
 			}
 		}
 		file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetNamespaceNamesResponse); i {
+			switch v := v.(*GetSchedulingInfoResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1486,7 +2930,7 @@ func file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_init() {
 			}
 		}
 		file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateNamespaceRequest); i {
+			switch v := v.(*PodSchedulingInfo); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1498,7 +2942,7 @@ func file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_init() {
 			}
 		}
 		file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateNamespaceResponse); i {
+			switch v := v.(*GetInstalledPackageEventsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1510,7 +2954,7 @@ func file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_init() {
 			}
 		}
 		file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CheckNamespaceExistsRequest); i {
+			switch v := v.(*GetInstalledPackageEventsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1522,7 +2966,7 @@ func file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_init() {
 			}
 		}
 		file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CheckNamespaceExistsResponse); i {
+			switch v := v.(*ResourceEvent); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1534,7 +2978,7 @@ func file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_init() {
 			}
 		}
 		file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateSecretRequest); i {
+			switch v := v.(*GetInstalledPackageCustomFieldsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1546,7 +2990,7 @@ func file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_init() {
 			}
 		}
 		file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateSecretResponse); i {
+			switch v := v.(*GetInstalledPackageCustomFieldsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1558,7 +3002,7 @@ func file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_init() {
 			}
 		}
 		file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetSecretNamesRequest); i {
+			switch v := v.(*CustomField); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1570,7 +3014,7 @@ func file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_init() {
 			}
 		}
 		file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetSecretNamesResponse); i {
+			switch v := v.(*ExportInstalledPackageManifestsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1582,7 +3026,7 @@ func file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_init() {
 			}
 		}
 		file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CanIRequest); i {
+			switch v := v.(*ExportInstalledPackageManifestsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1594,6 +3038,162 @@ func file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_init() {
 			}
 		}
 		file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetServiceAccountNamesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetServiceAccountNamesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetNamespaceNamesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetNamespaceNamesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateNamespaceRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateNamespaceResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CheckNamespaceExistsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CheckNamespaceExistsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateSecretRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateSecretResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetSecretNamesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetSecretNamesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CanIRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CanIResponse); i {
 			case 0:
 				return &v.state
@@ -1611,8 +3211,8 @@ func file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_kubeappsapis_plugins_resources_v1alpha1_resources_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   19,
+			NumEnums:      3,
+			NumMessages:   33,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -22,14 +22,19 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	ResourcesService_GetResources_FullMethodName           = "/kubeappsapis.plugins.resources.v1alpha1.ResourcesService/GetResources"
-	ResourcesService_GetServiceAccountNames_FullMethodName = "/kubeappsapis.plugins.resources.v1alpha1.ResourcesService/GetServiceAccountNames"
-	ResourcesService_GetNamespaceNames_FullMethodName      = "/kubeappsapis.plugins.resources.v1alpha1.ResourcesService/GetNamespaceNames"
-	ResourcesService_CreateNamespace_FullMethodName        = "/kubeappsapis.plugins.resources.v1alpha1.ResourcesService/CreateNamespace"
-	ResourcesService_CheckNamespaceExists_FullMethodName   = "/kubeappsapis.plugins.resources.v1alpha1.ResourcesService/CheckNamespaceExists"
-	ResourcesService_GetSecretNames_FullMethodName         = "/kubeappsapis.plugins.resources.v1alpha1.ResourcesService/GetSecretNames"
-	ResourcesService_CreateSecret_FullMethodName           = "/kubeappsapis.plugins.resources.v1alpha1.ResourcesService/CreateSecret"
-	ResourcesService_CanI_FullMethodName                   = "/kubeappsapis.plugins.resources.v1alpha1.ResourcesService/CanI"
+	ResourcesService_GetResources_FullMethodName                    = "/kubeappsapis.plugins.resources.v1alpha1.ResourcesService/GetResources"
+	ResourcesService_GetRolloutStatus_FullMethodName                = "/kubeappsapis.plugins.resources.v1alpha1.ResourcesService/GetRolloutStatus"
+	ResourcesService_GetSchedulingInfo_FullMethodName               = "/kubeappsapis.plugins.resources.v1alpha1.ResourcesService/GetSchedulingInfo"
+	ResourcesService_GetInstalledPackageEvents_FullMethodName       = "/kubeappsapis.plugins.resources.v1alpha1.ResourcesService/GetInstalledPackageEvents"
+	ResourcesService_GetInstalledPackageCustomFields_FullMethodName = "/kubeappsapis.plugins.resources.v1alpha1.ResourcesService/GetInstalledPackageCustomFields"
+	ResourcesService_ExportInstalledPackageManifests_FullMethodName = "/kubeappsapis.plugins.resources.v1alpha1.ResourcesService/ExportInstalledPackageManifests"
+	ResourcesService_GetServiceAccountNames_FullMethodName          = "/kubeappsapis.plugins.resources.v1alpha1.ResourcesService/GetServiceAccountNames"
+	ResourcesService_GetNamespaceNames_FullMethodName               = "/kubeappsapis.plugins.resources.v1alpha1.ResourcesService/GetNamespaceNames"
+	ResourcesService_CreateNamespace_FullMethodName                 = "/kubeappsapis.plugins.resources.v1alpha1.ResourcesService/CreateNamespace"
+	ResourcesService_CheckNamespaceExists_FullMethodName            = "/kubeappsapis.plugins.resources.v1alpha1.ResourcesService/CheckNamespaceExists"
+	ResourcesService_GetSecretNames_FullMethodName                  = "/kubeappsapis.plugins.resources.v1alpha1.ResourcesService/GetSecretNames"
+	ResourcesService_CreateSecret_FullMethodName                    = "/kubeappsapis.plugins.resources.v1alpha1.ResourcesService/CreateSecret"
+	ResourcesService_CanI_FullMethodName                            = "/kubeappsapis.plugins.resources.v1alpha1.ResourcesService/CanI"
 )
 
 // ResourcesServiceClient is the client API for ResourcesService service.
@@ -37,6 +42,11 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type ResourcesServiceClient interface {
 	GetResources(ctx context.Context, in *GetResourcesRequest, opts ...grpc.CallOption) (ResourcesService_GetResourcesClient, error)
+	GetRolloutStatus(ctx context.Context, in *GetRolloutStatusRequest, opts ...grpc.CallOption) (ResourcesService_GetRolloutStatusClient, error)
+	GetSchedulingInfo(ctx context.Context, in *GetSchedulingInfoRequest, opts ...grpc.CallOption) (*GetSchedulingInfoResponse, error)
+	GetInstalledPackageEvents(ctx context.Context, in *GetInstalledPackageEventsRequest, opts ...grpc.CallOption) (*GetInstalledPackageEventsResponse, error)
+	GetInstalledPackageCustomFields(ctx context.Context, in *GetInstalledPackageCustomFieldsRequest, opts ...grpc.CallOption) (*GetInstalledPackageCustomFieldsResponse, error)
+	ExportInstalledPackageManifests(ctx context.Context, in *ExportInstalledPackageManifestsRequest, opts ...grpc.CallOption) (*ExportInstalledPackageManifestsResponse, error)
 	GetServiceAccountNames(ctx context.Context, in *GetServiceAccountNamesRequest, opts ...grpc.CallOption) (*GetServiceAccountNamesResponse, error)
 	GetNamespaceNames(ctx context.Context, in *GetNamespaceNamesRequest, opts ...grpc.CallOption) (*GetNamespaceNamesResponse, error)
 	CreateNamespace(ctx context.Context, in *CreateNamespaceRequest, opts ...grpc.CallOption) (*CreateNamespaceResponse, error)
@@ -86,6 +96,74 @@ func (x *resourcesServiceGetResourcesClient) Recv() (*GetResourcesResponse, erro
 	return m, nil
 }
 
+func (c *resourcesServiceClient) GetRolloutStatus(ctx context.Context, in *GetRolloutStatusRequest, opts ...grpc.CallOption) (ResourcesService_GetRolloutStatusClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ResourcesService_ServiceDesc.Streams[1], ResourcesService_GetRolloutStatus_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &resourcesServiceGetRolloutStatusClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ResourcesService_GetRolloutStatusClient interface {
+	Recv() (*GetRolloutStatusResponse, error)
+	grpc.ClientStream
+}
+
+type resourcesServiceGetRolloutStatusClient struct {
+	grpc.ClientStream
+}
+
+func (x *resourcesServiceGetRolloutStatusClient) Recv() (*GetRolloutStatusResponse, error) {
+	m := new(GetRolloutStatusResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *resourcesServiceClient) GetSchedulingInfo(ctx context.Context, in *GetSchedulingInfoRequest, opts ...grpc.CallOption) (*GetSchedulingInfoResponse, error) {
+	out := new(GetSchedulingInfoResponse)
+	err := c.cc.Invoke(ctx, ResourcesService_GetSchedulingInfo_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *resourcesServiceClient) GetInstalledPackageEvents(ctx context.Context, in *GetInstalledPackageEventsRequest, opts ...grpc.CallOption) (*GetInstalledPackageEventsResponse, error) {
+	out := new(GetInstalledPackageEventsResponse)
+	err := c.cc.Invoke(ctx, ResourcesService_GetInstalledPackageEvents_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *resourcesServiceClient) GetInstalledPackageCustomFields(ctx context.Context, in *GetInstalledPackageCustomFieldsRequest, opts ...grpc.CallOption) (*GetInstalledPackageCustomFieldsResponse, error) {
+	out := new(GetInstalledPackageCustomFieldsResponse)
+	err := c.cc.Invoke(ctx, ResourcesService_GetInstalledPackageCustomFields_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *resourcesServiceClient) ExportInstalledPackageManifests(ctx context.Context, in *ExportInstalledPackageManifestsRequest, opts ...grpc.CallOption) (*ExportInstalledPackageManifestsResponse, error) {
+	out := new(ExportInstalledPackageManifestsResponse)
+	err := c.cc.Invoke(ctx, ResourcesService_ExportInstalledPackageManifests_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *resourcesServiceClient) GetServiceAccountNames(ctx context.Context, in *GetServiceAccountNamesRequest, opts ...grpc.CallOption) (*GetServiceAccountNamesResponse, error) {
 	out := new(GetServiceAccountNamesResponse)
 	err := c.cc.Invoke(ctx, ResourcesService_GetServiceAccountNames_FullMethodName, in, out, opts...)
@@ -154,6 +232,11 @@ func (c *resourcesServiceClient) CanI(ctx context.Context, in *CanIRequest, opts
 // for forward compatibility
 type ResourcesServiceServer interface {
 	GetResources(*GetResourcesRequest, ResourcesService_GetResourcesServer) error
+	GetRolloutStatus(*GetRolloutStatusRequest, ResourcesService_GetRolloutStatusServer) error
+	GetSchedulingInfo(context.Context, *GetSchedulingInfoRequest) (*GetSchedulingInfoResponse, error)
+	GetInstalledPackageEvents(context.Context, *GetInstalledPackageEventsRequest) (*GetInstalledPackageEventsResponse, error)
+	GetInstalledPackageCustomFields(context.Context, *GetInstalledPackageCustomFieldsRequest) (*GetInstalledPackageCustomFieldsResponse, error)
+	ExportInstalledPackageManifests(context.Context, *ExportInstalledPackageManifestsRequest) (*ExportInstalledPackageManifestsResponse, error)
 	GetServiceAccountNames(context.Context, *GetServiceAccountNamesRequest) (*GetServiceAccountNamesResponse, error)
 	GetNamespaceNames(context.Context, *GetNamespaceNamesRequest) (*GetNamespaceNamesResponse, error)
 	CreateNamespace(context.Context, *CreateNamespaceRequest) (*CreateNamespaceResponse, error)
@@ -170,6 +253,21 @@ type UnimplementedResourcesServiceServer struct {
 func (UnimplementedResourcesServiceServer) GetResources(*GetResourcesRequest, ResourcesService_GetResourcesServer) error {
 	return status.Errorf(codes.Unimplemented, "method GetResources not implemented")
 }
+func (UnimplementedResourcesServiceServer) GetRolloutStatus(*GetRolloutStatusRequest, ResourcesService_GetRolloutStatusServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetRolloutStatus not implemented")
+}
+func (UnimplementedResourcesServiceServer) GetSchedulingInfo(context.Context, *GetSchedulingInfoRequest) (*GetSchedulingInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSchedulingInfo not implemented")
+}
+func (UnimplementedResourcesServiceServer) GetInstalledPackageEvents(context.Context, *GetInstalledPackageEventsRequest) (*GetInstalledPackageEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInstalledPackageEvents not implemented")
+}
+func (UnimplementedResourcesServiceServer) GetInstalledPackageCustomFields(context.Context, *GetInstalledPackageCustomFieldsRequest) (*GetInstalledPackageCustomFieldsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInstalledPackageCustomFields not implemented")
+}
+func (UnimplementedResourcesServiceServer) ExportInstalledPackageManifests(context.Context, *ExportInstalledPackageManifestsRequest) (*ExportInstalledPackageManifestsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportInstalledPackageManifests not implemented")
+}
 func (UnimplementedResourcesServiceServer) GetServiceAccountNames(context.Context, *GetServiceAccountNamesRequest) (*GetServiceAccountNamesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetServiceAccountNames not implemented")
 }
@@ -224,6 +322,99 @@ func (x *resourcesServiceGetResourcesServer) Send(m *GetResourcesResponse) error
 	return x.ServerStream.SendMsg(m)
 }
 
+func _ResourcesService_GetRolloutStatus_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetRolloutStatusRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ResourcesServiceServer).GetRolloutStatus(m, &resourcesServiceGetRolloutStatusServer{stream})
+}
+
+type ResourcesService_GetRolloutStatusServer interface {
+	Send(*GetRolloutStatusResponse) error
+	grpc.ServerStream
+}
+
+type resourcesServiceGetRolloutStatusServer struct {
+	grpc.ServerStream
+}
+
+func (x *resourcesServiceGetRolloutStatusServer) Send(m *GetRolloutStatusResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ResourcesService_GetSchedulingInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSchedulingInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResourcesServiceServer).GetSchedulingInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ResourcesService_GetSchedulingInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResourcesServiceServer).GetSchedulingInfo(ctx, req.(*GetSchedulingInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ResourcesService_GetInstalledPackageEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInstalledPackageEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResourcesServiceServer).GetInstalledPackageEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ResourcesService_GetInstalledPackageEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResourcesServiceServer).GetInstalledPackageEvents(ctx, req.(*GetInstalledPackageEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ResourcesService_GetInstalledPackageCustomFields_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInstalledPackageCustomFieldsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResourcesServiceServer).GetInstalledPackageCustomFields(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ResourcesService_GetInstalledPackageCustomFields_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResourcesServiceServer).GetInstalledPackageCustomFields(ctx, req.(*GetInstalledPackageCustomFieldsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ResourcesService_ExportInstalledPackageManifests_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportInstalledPackageManifestsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResourcesServiceServer).ExportInstalledPackageManifests(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ResourcesService_ExportInstalledPackageManifests_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResourcesServiceServer).ExportInstalledPackageManifests(ctx, req.(*ExportInstalledPackageManifestsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ResourcesService_GetServiceAccountNames_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetServiceAccountNamesRequest)
 	if err := dec(in); err != nil {
@@ -357,6 +548,22 @@ var ResourcesService_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "kubeappsapis.plugins.resources.v1alpha1.ResourcesService",
 	HandlerType: (*ResourcesServiceServer)(nil),
 	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetSchedulingInfo",
+			Handler:    _ResourcesService_GetSchedulingInfo_Handler,
+		},
+		{
+			MethodName: "GetInstalledPackageEvents",
+			Handler:    _ResourcesService_GetInstalledPackageEvents_Handler,
+		},
+		{
+			MethodName: "GetInstalledPackageCustomFields",
+			Handler:    _ResourcesService_GetInstalledPackageCustomFields_Handler,
+		},
+		{
+			MethodName: "ExportInstalledPackageManifests",
+			Handler:    _ResourcesService_ExportInstalledPackageManifests_Handler,
+		},
 		{
 			MethodName: "GetServiceAccountNames",
 			Handler:    _ResourcesService_GetServiceAccountNames_Handler,
@@ -392,6 +599,11 @@ var ResourcesService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _ResourcesService_GetResources_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "GetRolloutStatus",
+			Handler:       _ResourcesService_GetRolloutStatus_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "kubeappsapis/plugins/resources/v1alpha1/resources.proto",
 }
@@ -39,6 +39,21 @@ const (
 	// ResourcesServiceGetResourcesProcedure is the fully-qualified name of the ResourcesService's
 	// GetResources RPC.
 	ResourcesServiceGetResourcesProcedure = "/kubeappsapis.plugins.resources.v1alpha1.ResourcesService/GetResources"
+	// ResourcesServiceGetRolloutStatusProcedure is the fully-qualified name of the ResourcesService's
+	// GetRolloutStatus RPC.
+	ResourcesServiceGetRolloutStatusProcedure = "/kubeappsapis.plugins.resources.v1alpha1.ResourcesService/GetRolloutStatus"
+	// ResourcesServiceGetSchedulingInfoProcedure is the fully-qualified name of the ResourcesService's
+	// GetSchedulingInfo RPC.
+	ResourcesServiceGetSchedulingInfoProcedure = "/kubeappsapis.plugins.resources.v1alpha1.ResourcesService/GetSchedulingInfo"
+	// ResourcesServiceGetInstalledPackageEventsProcedure is the fully-qualified name of the
+	// ResourcesService's GetInstalledPackageEvents RPC.
+	ResourcesServiceGetInstalledPackageEventsProcedure = "/kubeappsapis.plugins.resources.v1alpha1.ResourcesService/GetInstalledPackageEvents"
+	// ResourcesServiceGetInstalledPackageCustomFieldsProcedure is the fully-qualified name of the
+	// ResourcesService's GetInstalledPackageCustomFields RPC.
+	ResourcesServiceGetInstalledPackageCustomFieldsProcedure = "/kubeappsapis.plugins.resources.v1alpha1.ResourcesService/GetInstalledPackageCustomFields"
+	// ResourcesServiceExportInstalledPackageManifestsProcedure is the fully-qualified name of the
+	// ResourcesService's ExportInstalledPackageManifests RPC.
+	ResourcesServiceExportInstalledPackageManifestsProcedure = "/kubeappsapis.plugins.resources.v1alpha1.ResourcesService/ExportInstalledPackageManifests"
 	// ResourcesServiceGetServiceAccountNamesProcedure is the fully-qualified name of the
 	// ResourcesService's GetServiceAccountNames RPC.
 	ResourcesServiceGetServiceAccountNamesProcedure = "/kubeappsapis.plugins.resources.v1alpha1.ResourcesService/GetServiceAccountNames"
@@ -65,6 +80,11 @@ const (
 // kubeappsapis.plugins.resources.v1alpha1.ResourcesService service.
 type ResourcesServiceClient interface {
 	GetResources(context.Context, *connect_go.Request[v1alpha1.GetResourcesRequest]) (*connect_go.ServerStreamForClient[v1alpha1.GetResourcesResponse], error)
+	GetRolloutStatus(context.Context, *connect_go.Request[v1alpha1.GetRolloutStatusRequest]) (*connect_go.ServerStreamForClient[v1alpha1.GetRolloutStatusResponse], error)
+	GetSchedulingInfo(context.Context, *connect_go.Request[v1alpha1.GetSchedulingInfoRequest]) (*connect_go.Response[v1alpha1.GetSchedulingInfoResponse], error)
+	GetInstalledPackageEvents(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageEventsRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageEventsResponse], error)
+	GetInstalledPackageCustomFields(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageCustomFieldsRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageCustomFieldsResponse], error)
+	ExportInstalledPackageManifests(context.Context, *connect_go.Request[v1alpha1.ExportInstalledPackageManifestsRequest]) (*connect_go.Response[v1alpha1.ExportInstalledPackageManifestsResponse], error)
 	GetServiceAccountNames(context.Context, *connect_go.Request[v1alpha1.GetServiceAccountNamesRequest]) (*connect_go.Response[v1alpha1.GetServiceAccountNamesResponse], error)
 	GetNamespaceNames(context.Context, *connect_go.Request[v1alpha1.GetNamespaceNamesRequest]) (*connect_go.Response[v1alpha1.GetNamespaceNamesResponse], error)
 	CreateNamespace(context.Context, *connect_go.Request[v1alpha1.CreateNamespaceRequest]) (*connect_go.Response[v1alpha1.CreateNamespaceResponse], error)
@@ -90,6 +110,31 @@ func NewResourcesServiceClient(httpClient connect_go.HTTPClient, baseURL string,
 			baseURL+ResourcesServiceGetResourcesProcedure,
 			opts...,
 		),
+		getRolloutStatus: connect_go.NewClient[v1alpha1.GetRolloutStatusRequest, v1alpha1.GetRolloutStatusResponse](
+			httpClient,
+			baseURL+ResourcesServiceGetRolloutStatusProcedure,
+			opts...,
+		),
+		getSchedulingInfo: connect_go.NewClient[v1alpha1.GetSchedulingInfoRequest, v1alpha1.GetSchedulingInfoResponse](
+			httpClient,
+			baseURL+ResourcesServiceGetSchedulingInfoProcedure,
+			opts...,
+		),
+		getInstalledPackageEvents: connect_go.NewClient[v1alpha1.GetInstalledPackageEventsRequest, v1alpha1.GetInstalledPackageEventsResponse](
+			httpClient,
+			baseURL+ResourcesServiceGetInstalledPackageEventsProcedure,
+			opts...,
+		),
+		getInstalledPackageCustomFields: connect_go.NewClient[v1alpha1.GetInstalledPackageCustomFieldsRequest, v1alpha1.GetInstalledPackageCustomFieldsResponse](
+			httpClient,
+			baseURL+ResourcesServiceGetInstalledPackageCustomFieldsProcedure,
+			opts...,
+		),
+		exportInstalledPackageManifests: connect_go.NewClient[v1alpha1.ExportInstalledPackageManifestsRequest, v1alpha1.ExportInstalledPackageManifestsResponse](
+			httpClient,
+			baseURL+ResourcesServiceExportInstalledPackageManifestsProcedure,
+			opts...,
+		),
 		getServiceAccountNames: connect_go.NewClient[v1alpha1.GetServiceAccountNamesRequest, v1alpha1.GetServiceAccountNamesResponse](
 			httpClient,
 			baseURL+ResourcesServiceGetServiceAccountNamesProcedure,
@@ -130,14 +175,19 @@ func NewResourcesServiceClient(httpClient connect_go.HTTPClient, baseURL string,
 
 // resourcesServiceClient implements ResourcesServiceClient.
 type resourcesServiceClient struct {
-	getResources           *connect_go.Client[v1alpha1.GetResourcesRequest, v1alpha1.GetResourcesResponse]
-	getServiceAccountNames *connect_go.Client[v1alpha1.GetServiceAccountNamesRequest, v1alpha1.GetServiceAccountNamesResponse]
-	getNamespaceNames      *connect_go.Client[v1alpha1.GetNamespaceNamesRequest, v1alpha1.GetNamespaceNamesResponse]
-	createNamespace        *connect_go.Client[v1alpha1.CreateNamespaceRequest, v1alpha1.CreateNamespaceResponse]
-	checkNamespaceExists   *connect_go.Client[v1alpha1.CheckNamespaceExistsRequest, v1alpha1.CheckNamespaceExistsResponse]
-	getSecretNames         *connect_go.Client[v1alpha1.GetSecretNamesRequest, v1alpha1.GetSecretNamesResponse]
-	createSecret           *connect_go.Client[v1alpha1.CreateSecretRequest, v1alpha1.CreateSecretResponse]
-	canI                   *connect_go.Client[v1alpha1.CanIRequest, v1alpha1.CanIResponse]
+	getResources                    *connect_go.Client[v1alpha1.GetResourcesRequest, v1alpha1.GetResourcesResponse]
+	getRolloutStatus                *connect_go.Client[v1alpha1.GetRolloutStatusRequest, v1alpha1.GetRolloutStatusResponse]
+	getSchedulingInfo               *connect_go.Client[v1alpha1.GetSchedulingInfoRequest, v1alpha1.GetSchedulingInfoResponse]
+	getInstalledPackageEvents       *connect_go.Client[v1alpha1.GetInstalledPackageEventsRequest, v1alpha1.GetInstalledPackageEventsResponse]
+	getInstalledPackageCustomFields *connect_go.Client[v1alpha1.GetInstalledPackageCustomFieldsRequest, v1alpha1.GetInstalledPackageCustomFieldsResponse]
+	exportInstalledPackageManifests *connect_go.Client[v1alpha1.ExportInstalledPackageManifestsRequest, v1alpha1.ExportInstalledPackageManifestsResponse]
+	getServiceAccountNames          *connect_go.Client[v1alpha1.GetServiceAccountNamesRequest, v1alpha1.GetServiceAccountNamesResponse]
+	getNamespaceNames               *connect_go.Client[v1alpha1.GetNamespaceNamesRequest, v1alpha1.GetNamespaceNamesResponse]
+	createNamespace                 *connect_go.Client[v1alpha1.CreateNamespaceRequest, v1alpha1.CreateNamespaceResponse]
+	checkNamespaceExists            *connect_go.Client[v1alpha1.CheckNamespaceExistsRequest, v1alpha1.CheckNamespaceExistsResponse]
+	getSecretNames                  *connect_go.Client[v1alpha1.GetSecretNamesRequest, v1alpha1.GetSecretNamesResponse]
+	createSecret                    *connect_go.Client[v1alpha1.CreateSecretRequest, v1alpha1.CreateSecretResponse]
+	canI                            *connect_go.Client[v1alpha1.CanIRequest, v1alpha1.CanIResponse]
 }
 
 // GetResources calls kubeappsapis.plugins.resources.v1alpha1.ResourcesService.GetResources.
@@ -145,6 +195,35 @@ func (c *resourcesServiceClient) GetResources(ctx context.Context, req *connect_
 	return c.getResources.CallServerStream(ctx, req)
 }
 
+// GetRolloutStatus calls kubeappsapis.plugins.resources.v1alpha1.ResourcesService.GetRolloutStatus.
+func (c *resourcesServiceClient) GetRolloutStatus(ctx context.Context, req *connect_go.Request[v1alpha1.GetRolloutStatusRequest]) (*connect_go.ServerStreamForClient[v1alpha1.GetRolloutStatusResponse], error) {
+	return c.getRolloutStatus.CallServerStream(ctx, req)
+}
+
+// GetSchedulingInfo calls
+// kubeappsapis.plugins.resources.v1alpha1.ResourcesService.GetSchedulingInfo.
+func (c *resourcesServiceClient) GetSchedulingInfo(ctx context.Context, req *connect_go.Request[v1alpha1.GetSchedulingInfoRequest]) (*connect_go.Response[v1alpha1.GetSchedulingInfoResponse], error) {
+	return c.getSchedulingInfo.CallUnary(ctx, req)
+}
+
+// GetInstalledPackageEvents calls
+// kubeappsapis.plugins.resources.v1alpha1.ResourcesService.GetInstalledPackageEvents.
+func (c *resourcesServiceClient) GetInstalledPackageEvents(ctx context.Context, req *connect_go.Request[v1alpha1.GetInstalledPackageEventsRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageEventsResponse], error) {
+	return c.getInstalledPackageEvents.CallUnary(ctx, req)
+}
+
+// GetInstalledPackageCustomFields calls
+// kubeappsapis.plugins.resources.v1alpha1.ResourcesService.GetInstalledPackageCustomFields.
+func (c *resourcesServiceClient) GetInstalledPackageCustomFields(ctx context.Context, req *connect_go.Request[v1alpha1.GetInstalledPackageCustomFieldsRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageCustomFieldsResponse], error) {
+	return c.getInstalledPackageCustomFields.CallUnary(ctx, req)
+}
+
+// ExportInstalledPackageManifests calls
+// kubeappsapis.plugins.resources.v1alpha1.ResourcesService.ExportInstalledPackageManifests.
+func (c *resourcesServiceClient) ExportInstalledPackageManifests(ctx context.Context, req *connect_go.Request[v1alpha1.ExportInstalledPackageManifestsRequest]) (*connect_go.Response[v1alpha1.ExportInstalledPackageManifestsResponse], error) {
+	return c.exportInstalledPackageManifests.CallUnary(ctx, req)
+}
+
 // GetServiceAccountNames calls
 // kubeappsapis.plugins.resources.v1alpha1.ResourcesService.GetServiceAccountNames.
 func (c *resourcesServiceClient) GetServiceAccountNames(ctx context.Context, req *connect_go.Request[v1alpha1.GetServiceAccountNamesRequest]) (*connect_go.Response[v1alpha1.GetServiceAccountNamesResponse], error) {
@@ -187,6 +266,11 @@ func (c *resourcesServiceClient) CanI(ctx context.Context, req *connect_go.Reque
 // kubeappsapis.plugins.resources.v1alpha1.ResourcesService service.
 type ResourcesServiceHandler interface {
 	GetResources(context.Context, *connect_go.Request[v1alpha1.GetResourcesRequest], *connect_go.ServerStream[v1alpha1.GetResourcesResponse]) error
+	GetRolloutStatus(context.Context, *connect_go.Request[v1alpha1.GetRolloutStatusRequest], *connect_go.ServerStream[v1alpha1.GetRolloutStatusResponse]) error
+	GetSchedulingInfo(context.Context, *connect_go.Request[v1alpha1.GetSchedulingInfoRequest]) (*connect_go.Response[v1alpha1.GetSchedulingInfoResponse], error)
+	GetInstalledPackageEvents(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageEventsRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageEventsResponse], error)
+	GetInstalledPackageCustomFields(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageCustomFieldsRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageCustomFieldsResponse], error)
+	ExportInstalledPackageManifests(context.Context, *connect_go.Request[v1alpha1.ExportInstalledPackageManifestsRequest]) (*connect_go.Response[v1alpha1.ExportInstalledPackageManifestsResponse], error)
 	GetServiceAccountNames(context.Context, *connect_go.Request[v1alpha1.GetServiceAccountNamesRequest]) (*connect_go.Response[v1alpha1.GetServiceAccountNamesResponse], error)
 	GetNamespaceNames(context.Context, *connect_go.Request[v1alpha1.GetNamespaceNamesRequest]) (*connect_go.Response[v1alpha1.GetNamespaceNamesResponse], error)
 	CreateNamespace(context.Context, *connect_go.Request[v1alpha1.CreateNamespaceRequest]) (*connect_go.Response[v1alpha1.CreateNamespaceResponse], error)
@@ -207,6 +291,31 @@ func NewResourcesServiceHandler(svc ResourcesServiceHandler, opts ...connect_go.
 		svc.GetResources,
 		opts...,
 	)
+	resourcesServiceGetRolloutStatusHandler := connect_go.NewServerStreamHandler(
+		ResourcesServiceGetRolloutStatusProcedure,
+		svc.GetRolloutStatus,
+		opts...,
+	)
+	resourcesServiceGetSchedulingInfoHandler := connect_go.NewUnaryHandler(
+		ResourcesServiceGetSchedulingInfoProcedure,
+		svc.GetSchedulingInfo,
+		opts...,
+	)
+	resourcesServiceGetInstalledPackageEventsHandler := connect_go.NewUnaryHandler(
+		ResourcesServiceGetInstalledPackageEventsProcedure,
+		svc.GetInstalledPackageEvents,
+		opts...,
+	)
+	resourcesServiceGetInstalledPackageCustomFieldsHandler := connect_go.NewUnaryHandler(
+		ResourcesServiceGetInstalledPackageCustomFieldsProcedure,
+		svc.GetInstalledPackageCustomFields,
+		opts...,
+	)
+	resourcesServiceExportInstalledPackageManifestsHandler := connect_go.NewUnaryHandler(
+		ResourcesServiceExportInstalledPackageManifestsProcedure,
+		svc.ExportInstalledPackageManifests,
+		opts...,
+	)
 	resourcesServiceGetServiceAccountNamesHandler := connect_go.NewUnaryHandler(
 		ResourcesServiceGetServiceAccountNamesProcedure,
 		svc.GetServiceAccountNames,
@@ -246,6 +355,16 @@ func NewResourcesServiceHandler(svc ResourcesServiceHandler, opts ...connect_go.
 		switch r.URL.Path {
 		case ResourcesServiceGetResourcesProcedure:
 			resourcesServiceGetResourcesHandler.ServeHTTP(w, r)
+		case ResourcesServiceGetRolloutStatusProcedure:
+			resourcesServiceGetRolloutStatusHandler.ServeHTTP(w, r)
+		case ResourcesServiceGetSchedulingInfoProcedure:
+			resourcesServiceGetSchedulingInfoHandler.ServeHTTP(w, r)
+		case ResourcesServiceGetInstalledPackageEventsProcedure:
+			resourcesServiceGetInstalledPackageEventsHandler.ServeHTTP(w, r)
+		case ResourcesServiceGetInstalledPackageCustomFieldsProcedure:
+			resourcesServiceGetInstalledPackageCustomFieldsHandler.ServeHTTP(w, r)
+		case ResourcesServiceExportInstalledPackageManifestsProcedure:
+			resourcesServiceExportInstalledPackageManifestsHandler.ServeHTTP(w, r)
 		case ResourcesServiceGetServiceAccountNamesProcedure:
 			resourcesServiceGetServiceAccountNamesHandler.ServeHTTP(w, r)
 		case ResourcesServiceGetNamespaceNamesProcedure:
@@ -273,6 +392,26 @@ func (UnimplementedResourcesServiceHandler) GetResources(context.Context, *conne
 	return connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.resources.v1alpha1.ResourcesService.GetResources is not implemented"))
 }
 
+func (UnimplementedResourcesServiceHandler) GetRolloutStatus(context.Context, *connect_go.Request[v1alpha1.GetRolloutStatusRequest], *connect_go.ServerStream[v1alpha1.GetRolloutStatusResponse]) error {
+	return connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.resources.v1alpha1.ResourcesService.GetRolloutStatus is not implemented"))
+}
+
+func (UnimplementedResourcesServiceHandler) GetSchedulingInfo(context.Context, *connect_go.Request[v1alpha1.GetSchedulingInfoRequest]) (*connect_go.Response[v1alpha1.GetSchedulingInfoResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.resources.v1alpha1.ResourcesService.GetSchedulingInfo is not implemented"))
+}
+
+func (UnimplementedResourcesServiceHandler) GetInstalledPackageEvents(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageEventsRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageEventsResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.resources.v1alpha1.ResourcesService.GetInstalledPackageEvents is not implemented"))
+}
+
+func (UnimplementedResourcesServiceHandler) GetInstalledPackageCustomFields(context.Context, *connect_go.Request[v1alpha1.GetInstalledPackageCustomFieldsRequest]) (*connect_go.Response[v1alpha1.GetInstalledPackageCustomFieldsResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.resources.v1alpha1.ResourcesService.GetInstalledPackageCustomFields is not implemented"))
+}
+
+func (UnimplementedResourcesServiceHandler) ExportInstalledPackageManifests(context.Context, *connect_go.Request[v1alpha1.ExportInstalledPackageManifestsRequest]) (*connect_go.Response[v1alpha1.ExportInstalledPackageManifestsResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.resources.v1alpha1.ResourcesService.ExportInstalledPackageManifests is not implemented"))
+}
+
 func (UnimplementedResourcesServiceHandler) GetServiceAccountNames(context.Context, *connect_go.Request[v1alpha1.GetServiceAccountNamesRequest]) (*connect_go.Response[v1alpha1.GetServiceAccountNamesResponse], error) {
 	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("kubeappsapis.plugins.resources.v1alpha1.ResourcesService.GetServiceAccountNames is not implemented"))
 }
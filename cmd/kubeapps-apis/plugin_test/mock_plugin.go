@@ -11,6 +11,8 @@ import (
 	corev1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
 	plugins "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/plugins/v1alpha1"
 	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/paginate"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/pkgutils"
+	"google.golang.org/protobuf/proto"
 )
 
 type TestPackagingPluginServer struct {
@@ -23,7 +25,9 @@ type TestPackagingPluginServer struct {
 	PackageAppVersions        []*corev1.PackageAppVersion
 	ResourceRefs              []*corev1.ResourceRef
 	Categories                []string
+	Providers                 []string
 	NextPageToken             string
+	PartialResults            *corev1.PartialResults
 	ErrorCode                 connect.Code
 }
 
@@ -53,6 +57,8 @@ func (s TestPackagingPluginServer) GetAvailablePackageSummaries(ctx context.Cont
 		AvailablePackageSummaries: summaries,
 		Categories:                s.Categories,
 		NextPageToken:             nextPageToken,
+		PartialResults:            s.PartialResults,
+		TotalSize:                 proto.Int32(int32(len(s.AvailablePackageSummaries))),
 	}), nil
 }
 
@@ -66,6 +72,17 @@ func (s TestPackagingPluginServer) GetAvailablePackageDetail(ctx context.Context
 	}), nil
 }
 
+// GetAvailablePackageFilters returns the configured categories and providers.
+func (s TestPackagingPluginServer) GetAvailablePackageFilters(ctx context.Context, request *connect.Request[corev1.GetAvailablePackageFiltersRequest]) (*connect.Response[corev1.GetAvailablePackageFiltersResponse], error) {
+	if s.ErrorCode != 0 {
+		return nil, connect.NewError(s.ErrorCode, fmt.Errorf("Non-OK response"))
+	}
+	return connect.NewResponse(&corev1.GetAvailablePackageFiltersResponse{
+		Categories: s.Categories,
+		Providers:  s.Providers,
+	}), nil
+}
+
 // GetInstalledPackageSummaries returns the installed package summaries based on the request.
 func (s TestPackagingPluginServer) GetInstalledPackageSummaries(ctx context.Context, request *connect.Request[corev1.GetInstalledPackageSummariesRequest]) (*connect.Response[corev1.GetInstalledPackageSummariesResponse], error) {
 	if s.ErrorCode != 0 {
@@ -74,6 +91,7 @@ func (s TestPackagingPluginServer) GetInstalledPackageSummaries(ctx context.Cont
 	return connect.NewResponse(&corev1.GetInstalledPackageSummariesResponse{
 		InstalledPackageSummaries: s.InstalledPackageSummaries,
 		NextPageToken:             s.NextPageToken,
+		PartialResults:            s.PartialResults,
 	}), nil
 }
 
@@ -97,6 +115,29 @@ func (s TestPackagingPluginServer) GetAvailablePackageVersions(ctx context.Conte
 	}), nil
 }
 
+// GetAvailablePackageTextAsset returns a range of a text asset of the package detail.
+func (s TestPackagingPluginServer) GetAvailablePackageTextAsset(ctx context.Context, request *connect.Request[corev1.GetAvailablePackageTextAssetRequest]) (*connect.Response[corev1.GetAvailablePackageTextAssetResponse], error) {
+	if s.ErrorCode != 0 {
+		return nil, connect.NewError(s.ErrorCode, fmt.Errorf("Non-OK response"))
+	}
+	return pkgutils.GetAvailablePackageTextAsset(ctx, request, s.GetAvailablePackageDetail)
+}
+
+// GetAvailablePackageChangelog returns the release notes of the latest version of the package.
+func (s TestPackagingPluginServer) GetAvailablePackageChangelog(ctx context.Context, request *connect.Request[corev1.GetAvailablePackageChangelogRequest]) (*connect.Response[corev1.GetAvailablePackageChangelogResponse], error) {
+	if s.ErrorCode != 0 {
+		return nil, connect.NewError(s.ErrorCode, fmt.Errorf("Non-OK response"))
+	}
+	return connect.NewResponse(&corev1.GetAvailablePackageChangelogResponse{
+		Changelogs: []*corev1.PackageVersionChangelog{
+			{
+				PkgVersion:   DefaultPkgUpdateVersion,
+				ReleaseNotes: DefaultReleaseNotes,
+			},
+		},
+	}), nil
+}
+
 // GetInstalledPackageResourceRefs returns the resource references based on the request.
 func (s TestPackagingPluginServer) GetInstalledPackageResourceRefs(ctx context.Context, request *connect.Request[corev1.GetInstalledPackageResourceRefsRequest]) (*connect.Response[corev1.GetInstalledPackageResourceRefsResponse], error) {
 	if s.ErrorCode != 0 {
@@ -108,6 +149,30 @@ func (s TestPackagingPluginServer) GetInstalledPackageResourceRefs(ctx context.C
 	}), nil
 }
 
+// GetInstalledPackageOperationStatus returns the status of the installed package detail.
+func (s TestPackagingPluginServer) GetInstalledPackageOperationStatus(ctx context.Context, request *connect.Request[corev1.GetInstalledPackageOperationStatusRequest]) (*connect.Response[corev1.GetInstalledPackageOperationStatusResponse], error) {
+	return pkgutils.GetInstalledPackageOperationStatus(ctx, request, s.GetInstalledPackageDetail)
+}
+
+// CheckInstalledPackageNameAvailability reports a conflict for each installed
+// package summary in the target namespace with the requested name.
+func (s TestPackagingPluginServer) CheckInstalledPackageNameAvailability(ctx context.Context, request *connect.Request[corev1.CheckInstalledPackageNameAvailabilityRequest]) (*connect.Response[corev1.CheckInstalledPackageNameAvailabilityResponse], error) {
+	if s.ErrorCode != 0 {
+		return nil, connect.NewError(s.ErrorCode, fmt.Errorf("Non-OK response"))
+	}
+	conflicts := []*corev1.InstalledPackageReference{}
+	for _, summary := range s.InstalledPackageSummaries {
+		ref := summary.GetInstalledPackageRef()
+		if summary.GetName() == request.Msg.GetName() && ref.GetContext().GetNamespace() == request.Msg.GetTargetContext().GetNamespace() {
+			conflicts = append(conflicts, ref)
+		}
+	}
+	return connect.NewResponse(&corev1.CheckInstalledPackageNameAvailabilityResponse{
+		Available: len(conflicts) == 0,
+		Conflicts: conflicts,
+	}), nil
+}
+
 func (s TestPackagingPluginServer) CreateInstalledPackage(ctx context.Context, request *connect.Request[corev1.CreateInstalledPackageRequest]) (*connect.Response[corev1.CreateInstalledPackageResponse], error) {
 	if s.ErrorCode != 0 {
 		return nil, connect.NewError(s.ErrorCode, fmt.Errorf("Non-OK response"))
@@ -146,6 +211,7 @@ type TestRepositoriesPluginServer struct {
 	Plugin                     *plugins.Plugin
 	PackageRepositoryDetail    *corev1.PackageRepositoryDetail
 	PackageRepositorySummaries []*corev1.PackageRepositorySummary
+	PartialResults             *corev1.PartialResults
 	ErrorCode                  connect.Code
 }
 
@@ -184,6 +250,7 @@ func (s TestRepositoriesPluginServer) GetPackageRepositorySummaries(ctx context.
 	}
 	return connect.NewResponse(&corev1.GetPackageRepositorySummariesResponse{
 		PackageRepositorySummaries: s.PackageRepositorySummaries,
+		PartialResults:             s.PartialResults,
 	}), nil
 }
 
@@ -225,3 +292,31 @@ func (s TestRepositoriesPluginServer) GetPackageRepositoryPermissions(ctx contex
 		},
 	}), nil
 }
+
+func (s TestRepositoriesPluginServer) TestPackageRepositoryConnection(ctx context.Context, request *connect.Request[corev1.TestPackageRepositoryConnectionRequest]) (*connect.Response[corev1.TestPackageRepositoryConnectionResponse], error) {
+	if s.ErrorCode != 0 {
+		return nil, connect.NewError(s.ErrorCode, fmt.Errorf("Non-OK response"))
+	}
+	return connect.NewResponse(&corev1.TestPackageRepositoryConnectionResponse{
+		Message: fmt.Sprintf("Connected to %s using the plugin %s", request.Msg.GetPackageRepository().GetUrl(), s.Plugin.GetName()),
+	}), nil
+}
+
+func (s TestRepositoriesPluginServer) ListPackageRepositoryPackages(ctx context.Context, request *connect.Request[corev1.ListPackageRepositoryPackagesRequest]) (*connect.Response[corev1.ListPackageRepositoryPackagesResponse], error) {
+	if s.ErrorCode != 0 {
+		return nil, connect.NewError(s.ErrorCode, fmt.Errorf("Non-OK response"))
+	}
+	return connect.NewResponse(&corev1.ListPackageRepositoryPackagesResponse{
+		AvailablePackageSummaries: []*corev1.AvailablePackageSummary{
+			{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context:    request.Msg.GetPackageRepoRef().GetContext(),
+					Identifier: request.Msg.GetPackageRepoRef().GetIdentifier() + "/pkg-1",
+					Plugin:     s.Plugin,
+				},
+				Name: "pkg-1",
+			},
+		},
+		TotalCount: proto.Int32(1),
+	}), nil
+}
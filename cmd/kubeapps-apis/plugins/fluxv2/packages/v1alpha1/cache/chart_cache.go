@@ -15,6 +15,7 @@ import (
 
 	"github.com/bufbuild/connect-go"
 	"github.com/go-redis/redis/v8"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core/metrics"
 	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/fluxv2/packages/v1alpha1/common"
 	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/pkgutils"
 	"github.com/vmware-tanzu/kubeapps/pkg/chart/models"
@@ -525,6 +526,7 @@ func (c *ChartCache) Get(key string, chart *models.Chart, downloadFn DownloadCha
 		return nil, err
 	} else if value == nil {
 		// cache miss
+		metrics.ObserveCacheLookup(common.GetPluginDetail().GetName(), "charts", false)
 		namespace, chartID, version, err := c.fromKey(key)
 		if err != nil {
 			return nil, err
@@ -559,6 +561,8 @@ func (c *ChartCache) Get(key string, chart *models.Chart, downloadFn DownloadCha
 			c.queue.WaitUntilForgotten(key)
 			return c.Fetch(key)
 		}
+	} else {
+		metrics.ObserveCacheLookup(common.GetPluginDetail().GetName(), "charts", true)
 	}
 	return value, nil
 }
@@ -14,6 +14,7 @@ import (
 
 	"github.com/bufbuild/connect-go"
 	"github.com/go-redis/redis/v8"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core/metrics"
 	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/fluxv2/packages/v1alpha1/common"
 	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/clientgetter"
 	apiv1 "k8s.io/api/core/v1"
@@ -781,6 +782,7 @@ func (c *NamespacedResourceWatcherCache) GetMultiple(keys sets.Set[string]) (map
 	keysLeft := sets.Set[string]{}
 
 	for key, value := range chartsUntyped {
+		metrics.ObserveCacheLookup(common.GetPluginDetail().GetName(), c.config.Gvr.Resource, value != nil)
 		if value == nil {
 			// this cache miss may have happened due to one of these reasons:
 			// 1) key truly does not exist in k8s (e.g. there is no repo with
@@ -970,8 +972,10 @@ func (c *NamespacedResourceWatcherCache) Get(key string) (interface{}, error) {
 		return nil, err
 	} else if value == nil {
 		// cache miss
+		metrics.ObserveCacheLookup(common.GetPluginDetail().GetName(), c.config.Gvr.Resource, false)
 		return c.ForceAndFetch(key, false)
 	}
+	metrics.ObserveCacheLookup(common.GetPluginDetail().GetName(), c.config.Gvr.Resource, true)
 	return value, nil
 }
 
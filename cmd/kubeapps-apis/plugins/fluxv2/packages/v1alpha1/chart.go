@@ -169,6 +169,10 @@ func passesFilter(chart models.Chart, filters *corev1.FilterOptions) bool {
 	if filters == nil {
 		return true
 	}
+	// charts have no provider, so none passes a filter on providers
+	if len(filters.GetProviders()) > 0 {
+		return false
+	}
 	ok := true
 	if categories := filters.GetCategories(); len(categories) > 0 {
 		ok = false
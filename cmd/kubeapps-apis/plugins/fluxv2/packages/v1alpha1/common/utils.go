@@ -28,7 +28,9 @@ import (
 	"github.com/go-redis/redis/v8"
 	"github.com/google/go-containerregistry/pkg/authn"
 	plugins "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/plugins/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/netrestrict"
 	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/pkgutils"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/resources"
 	httpclient "github.com/vmware-tanzu/kubeapps/pkg/http-client"
 	"golang.org/x/net/http/httpproxy"
 	"helm.sh/helm/v3/pkg/getter"
@@ -46,6 +48,8 @@ const (
 	UserAgentPrefix          = "kubeapps-apis/plugins"
 	redisInitClientRetryWait = 1 * time.Second
 	redisInitClientTimeout   = 10 * time.Second
+	// namespace in which flux installs its controllers by default
+	DefaultFluxNamespace = "flux-system"
 )
 
 // Set the pluginDetail once during a module init function so the single struct
@@ -94,6 +98,7 @@ func NewDefaultPluginConfig() *FluxPluginConfig {
 		TimeoutSeconds:       int32(-1),
 		DefaultUpgradePolicy: pkgutils.UpgradePolicyNone,
 		NoCrossNamespaceRefs: false,
+		FluxNamespace:        DefaultFluxNamespace,
 	}
 }
 
@@ -431,6 +436,20 @@ type FluxPluginConfig struct {
 	DefaultUpgradePolicy pkgutils.UpgradePolicy
 	// ref https://github.com/vmware-tanzu/kubeapps/issues/5541
 	NoCrossNamespaceRefs bool
+	// FluxNamespace is the namespace in which the flux controllers are
+	// installed, used to detect the multi-tenancy lockdown flags of the
+	// helm-controller.
+	FluxNamespace string
+	// NamespaceCreation holds the labels and annotations set on the target
+	// namespaces created on install.
+	NamespaceCreation resources.NamespaceCreationOptions
+	// InstalledPackageMetadata configures the labels and annotations which
+	// can be set on the HelmReleases.
+	InstalledPackageMetadata resources.InstalledPackageMetadataOptions
+	// RepositoryConnectionAllowedNetworks are the networks, in CIDR notation,
+	// which the connection tests of the repositories can reach despite being
+	// blocked by default, such as the loopback addresses.
+	RepositoryConnectionAllowedNetworks []string
 }
 
 // ParsePluginConfig parses the input plugin configuration json file and return the
@@ -443,8 +462,10 @@ func ParsePluginConfig(pluginConfigPath string) (*FluxPluginConfig, error) {
 		Core struct {
 			Packages struct {
 				V1alpha1 struct {
-					VersionsInSummary pkgutils.VersionsInSummary
-					TimeoutSeconds    int32 `json:"timeoutSeconds"`
+					VersionsInSummary        pkgutils.VersionsInSummary
+					TimeoutSeconds           int32                                     `json:"timeoutSeconds"`
+					NamespaceCreation        resources.NamespaceCreationOptions        `json:"namespaceCreation"`
+					InstalledPackageMetadata resources.InstalledPackageMetadataOptions `json:"installedPackageMetadata"`
 				} `json:"v1alpha1"`
 			} `json:"packages"`
 		} `json:"core"`
@@ -452,8 +473,10 @@ func ParsePluginConfig(pluginConfigPath string) (*FluxPluginConfig, error) {
 		Flux struct {
 			Packages struct {
 				V1alpha1 struct {
-					DefaultUpgradePolicy string `json:"defaultUpgradePolicy"`
-					NoCrossNamespaceRefs bool   `json:"noCrossNamespaceRefs"`
+					DefaultUpgradePolicy                string   `json:"defaultUpgradePolicy"`
+					NoCrossNamespaceRefs                bool     `json:"noCrossNamespaceRefs"`
+					FluxNamespace                       string   `json:"fluxNamespace"`
+					RepositoryConnectionAllowedNetworks []string `json:"repositoryConnectionAllowedNetworks"`
 				} `json:"v1alpha1"`
 			} `json:"packages"`
 		} `json:"flux"`
@@ -470,16 +493,28 @@ func ParsePluginConfig(pluginConfigPath string) (*FluxPluginConfig, error) {
 		return nil, fmt.Errorf("unable to unmarshal plugin config: %q error: %w", string(pluginConfig), err)
 	}
 
+	if err := netrestrict.ValidateNetworks(config.Flux.Packages.V1alpha1.RepositoryConnectionAllowedNetworks); err != nil {
+		return nil, fmt.Errorf("invalid repositoryConnectionAllowedNetworks: %w", err)
+	}
+
 	if defaultUpgradePolicy, err := pkgutils.UpgradePolicyFromString(
 		config.Flux.Packages.V1alpha1.DefaultUpgradePolicy); err != nil {
 		return nil, err
 	} else {
+		fluxNamespace := config.Flux.Packages.V1alpha1.FluxNamespace
+		if fluxNamespace == "" {
+			fluxNamespace = DefaultFluxNamespace
+		}
 		// return configured value
 		return &FluxPluginConfig{
-			VersionsInSummary:    config.Core.Packages.V1alpha1.VersionsInSummary,
-			TimeoutSeconds:       config.Core.Packages.V1alpha1.TimeoutSeconds,
-			DefaultUpgradePolicy: defaultUpgradePolicy,
-			NoCrossNamespaceRefs: config.Flux.Packages.V1alpha1.NoCrossNamespaceRefs,
+			VersionsInSummary:                   config.Core.Packages.V1alpha1.VersionsInSummary,
+			TimeoutSeconds:                      config.Core.Packages.V1alpha1.TimeoutSeconds,
+			DefaultUpgradePolicy:                defaultUpgradePolicy,
+			NoCrossNamespaceRefs:                config.Flux.Packages.V1alpha1.NoCrossNamespaceRefs,
+			FluxNamespace:                       fluxNamespace,
+			NamespaceCreation:                   config.Core.Packages.V1alpha1.NamespaceCreation,
+			InstalledPackageMetadata:            config.Core.Packages.V1alpha1.InstalledPackageMetadata,
+			RepositoryConnectionAllowedNetworks: config.Flux.Packages.V1alpha1.RepositoryConnectionAllowedNetworks,
 		}, nil
 	}
 }
@@ -335,3 +335,79 @@ flux:
 		})
 	}
 }
+
+func TestParsePluginConfigRepositoryConnectionAllowedNetworks(t *testing.T) {
+	testCases := []struct {
+		name           string
+		pluginYAMLConf []byte
+		exp_networks   []string
+		exp_error_str  string
+	}{
+		{
+			name: "no networks specified in plugin config",
+			pluginYAMLConf: []byte(`
+flux:
+  packages:
+    v1alpha1:
+      noCrossNamespaceRefs: true
+      `),
+			exp_networks:  nil,
+			exp_error_str: "",
+		},
+		{
+			name: "specific networks in plugin config",
+			pluginYAMLConf: []byte(`
+flux:
+  packages:
+    v1alpha1:
+      repositoryConnectionAllowedNetworks:
+      - 127.0.0.0/8
+      `),
+			exp_networks:  []string{"127.0.0.0/8"},
+			exp_error_str: "",
+		},
+		{
+			name: "invalid network in plugin config",
+			pluginYAMLConf: []byte(`
+flux:
+  packages:
+    v1alpha1:
+      repositoryConnectionAllowedNetworks:
+      - 127.0.0.1
+      `),
+			exp_error_str: "invalid repositoryConnectionAllowedNetworks",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pluginJSONConf, err := yaml.YAMLToJSON(tc.pluginYAMLConf)
+			if err != nil {
+				log.Fatalf("%s", err)
+			}
+			f, err := os.CreateTemp(".", "plugin_json_conf")
+			if err != nil {
+				log.Fatalf("%s", err)
+			}
+			defer os.Remove(f.Name()) // clean up
+			if _, err := f.Write(pluginJSONConf); err != nil {
+				log.Fatalf("%s", err)
+			}
+			if err := f.Close(); err != nil {
+				log.Fatalf("%s", err)
+			}
+			config, err := ParsePluginConfig(f.Name())
+			if tc.exp_error_str != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.exp_error_str) {
+					t.Errorf("err got %v, want to find %q", err, tc.exp_error_str)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if got, want := config.RepositoryConnectionAllowedNetworks, tc.exp_networks; !cmp.Equal(want, got) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
@@ -0,0 +1,218 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/bufbuild/connect-go"
+	helmv2 "github.com/fluxcd/helm-controller/api/v2beta1"
+	corev1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/plugins/fluxv2/packages/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/connecterror"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/pkgutils"
+	"github.com/vmware-tanzu/kubeapps/pkg/chart/models"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/storage/driver"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	log "k8s.io/klog/v2"
+)
+
+const (
+	defaultConvertTimeout  = 5 * time.Minute
+	convertPollingInterval = 2 * time.Second
+)
+
+// ConvertInstalledPackage converts a Helm release installed by the 'helm'
+// plugin into a flux HelmRelease, without reinstalling it.
+func (s *Server) ConvertInstalledPackage(ctx context.Context, request *connect.Request[v1alpha1.ConvertInstalledPackageRequest]) (*connect.Response[v1alpha1.ConvertInstalledPackageResponse], error) {
+	log.Infof("+fluxv2 ConvertInstalledPackage [%v]", request)
+
+	if request == nil || request.Msg.InstalledPackageRef == nil || request.Msg.AvailablePackageRef == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("No request InstalledPackageRef or AvailablePackageRef provided"))
+	}
+	installedRef := request.Msg.InstalledPackageRef
+	if installedRef.GetContext().GetNamespace() == "" || installedRef.GetIdentifier() == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Required context or identifier not provided"))
+	}
+	packageRef := request.Msg.AvailablePackageRef
+	if packageRef.GetContext().GetNamespace() == "" || packageRef.GetIdentifier() == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Required context or identifier not provided"))
+	}
+	for _, cluster := range []string{installedRef.GetContext().GetCluster(), packageRef.GetContext().GetCluster()} {
+		if cluster != "" && cluster != s.kubeappsCluster {
+			return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("Not supported yet: request.Context.Cluster: [%v]", cluster))
+		}
+	}
+	if request.Msg.TimeoutSeconds < 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("The timeout must not be negative"))
+	}
+
+	if convertedRef, err := s.convertRelease(
+		ctx,
+		request.Header(),
+		types.NamespacedName{Name: installedRef.Identifier, Namespace: installedRef.Context.Namespace},
+		packageRef,
+		request.Msg.ReconciliationOptions,
+		convertTimeout(request.Msg.TimeoutSeconds)); err != nil {
+		return nil, err
+	} else {
+		return connect.NewResponse(&v1alpha1.ConvertInstalledPackageResponse{
+			InstalledPackageRef: convertedRef,
+		}), nil
+	}
+}
+
+// convertRelease creates a HelmRelease installing the same chart, version and
+// values as the existing Helm release, with the same name and namespace, so
+// that flux adopts the release by upgrading it in place. Once the HelmRelease
+// is reconciled, the Helm storage of the release is annotated as converted.
+// If the HelmRelease fails or times out, it is kept for inspection: deleting
+// it would uninstall the release.
+func (s *Server) convertRelease(ctx context.Context, headers http.Header, releaseName types.NamespacedName, packageRef *corev1.AvailablePackageReference, reconcile *corev1.ReconciliationOptions, timeout time.Duration) (*corev1.InstalledPackageReference, error) {
+	if s.actionConfigGetter == nil {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("Server is not configured with actionConfigGetter"))
+	}
+	actionConfig, err := s.actionConfigGetter(headers, releaseName.Namespace)
+	if err != nil || actionConfig == nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to create Helm action config in namespace [%s] due to: %v", releaseName.Namespace, err))
+	}
+	helmRelease, err := action.NewGet(actionConfig).Run(releaseName.Name)
+	if err != nil {
+		if err == driver.ErrReleaseNotFound {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("Unable to find Helm release [%s]", releaseName))
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to run Helm Get action for release [%s]: %w", releaseName, err))
+	}
+
+	repoName, chartName, err := pkgutils.SplitPackageIdentifier(packageRef.Identifier)
+	if err != nil {
+		return nil, err
+	}
+	repo := types.NamespacedName{Namespace: packageRef.Context.Namespace, Name: repoName}
+	if err = s.checkFluxLockdown(ctx, headers, repo, releaseName.Namespace, reconcile.GetServiceAccountName()); err != nil {
+		return nil, err
+	}
+	chart, err := s.getChartModel(ctx, headers, repo, chartName)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkConvertibleRelease(helmRelease, chart); err != nil {
+		return nil, err
+	}
+
+	// the exact version of the release is pinned, so that flux upgrades it to
+	// the very same chart
+	fluxRelease, err := s.newFluxHelmRelease(chart, releaseName, helmRelease.Chart.Metadata.Version, reconcile, helmRelease.Config, nil)
+	if err != nil {
+		return nil, err
+	}
+	fluxRelease.Spec.ReleaseName = releaseName.Name
+	fluxRelease.Spec.StorageNamespace = releaseName.Namespace
+
+	client, err := s.getClient(headers, releaseName.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	if err = client.Create(ctx, fluxRelease); err != nil {
+		return nil, connecterror.FromK8sError("create", "HelmRelease", releaseName.String(), err)
+	}
+
+	err = wait.PollImmediateWithContext(ctx, convertPollingInterval, timeout, func(ctx context.Context) (bool, error) {
+		rel, err := s.getReleaseInCluster(ctx, headers, releaseName)
+		if err != nil {
+			return false, err
+		}
+		return convertedReleaseReconciled(*rel)
+	})
+	if err == wait.ErrWaitTimeout {
+		return nil, connect.NewError(connect.CodeDeadlineExceeded, fmt.Errorf("HelmRelease [%s] was not reconciled within %s, it is kept for inspection", releaseName, timeout))
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err = s.markReleaseConverted(ctx, headers, releaseName); err != nil {
+		return nil, err
+	}
+
+	return &corev1.InstalledPackageReference{
+		Context: &corev1.Context{
+			Namespace: releaseName.Namespace,
+			Cluster:   s.kubeappsCluster,
+		},
+		Identifier: releaseName.Name,
+		Plugin:     GetPluginDetail(),
+	}, nil
+}
+
+// markReleaseConverted annotates the Helm storage secrets of the release with
+// the HelmRelease now managing it, so that the 'helm' plugin stops mutating it.
+// The revisions written afterwards belong to flux.
+func (s *Server) markReleaseConverted(ctx context.Context, headers http.Header, releaseName types.NamespacedName) error {
+	typedClient, err := s.clientGetter.Typed(headers, s.kubeappsCluster)
+	if err != nil {
+		return err
+	}
+	secrets, err := typedClient.CoreV1().Secrets(releaseName.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("owner=helm,name=%s", releaseName.Name),
+	})
+	if err != nil {
+		return connecterror.FromK8sError("list", "Secret", releaseName.Namespace+"/*", err)
+	}
+	for i := range secrets.Items {
+		secret := &secrets.Items[i]
+		if secret.Annotations == nil {
+			secret.Annotations = map[string]string{}
+		}
+		secret.Annotations[pkgutils.ConvertedReleaseAnnotation] = releaseName.String()
+		if _, err = typedClient.CoreV1().Secrets(releaseName.Namespace).Update(ctx, secret, metav1.UpdateOptions{}); err != nil {
+			return connecterror.FromK8sError("update", "Secret", secret.Namespace+"/"+secret.Name, err)
+		}
+	}
+	return nil
+}
+
+// checkConvertibleRelease returns an error unless the chart of the Helm
+// release, at its deployed version, is available from the flux repository.
+func checkConvertibleRelease(helmRelease *release.Release, chart *models.Chart) error {
+	if helmRelease.Info == nil || helmRelease.Info.Status != release.StatusDeployed {
+		return connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("Helm release [%s] is not deployed", helmRelease.Name))
+	}
+	if helmRelease.Chart == nil || helmRelease.Chart.Metadata == nil {
+		return connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("Helm release [%s] has no chart metadata", helmRelease.Name))
+	}
+	metadata := helmRelease.Chart.Metadata
+	if metadata.Name != chart.Name {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Helm release [%s] installs the chart [%s], not [%s]", helmRelease.Name, metadata.Name, chart.Name))
+	}
+	for _, version := range chart.ChartVersions {
+		if version.Version == metadata.Version {
+			return nil
+		}
+	}
+	return connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("Version [%s] of chart [%s] is not available from the repository [%s/%s]", metadata.Version, chart.Name, chart.Repo.Namespace, chart.Repo.Name))
+}
+
+// convertedReleaseReconciled returns whether the HelmRelease of a converted
+// release is ready, or an error if flux failed to reconcile it.
+func convertedReleaseReconciled(rel helmv2.HelmRelease) (bool, error) {
+	ready, reason, userReason := isHelmReleaseReady(rel)
+	if reason == corev1.InstalledPackageStatus_STATUS_REASON_FAILED {
+		return false, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("HelmRelease [%s/%s] failed to reconcile, it is kept for inspection: %s", rel.Namespace, rel.Name, userReason))
+	}
+	return ready, nil
+}
+
+func convertTimeout(timeoutSeconds int32) time.Duration {
+	if timeoutSeconds > 0 {
+		return time.Duration(timeoutSeconds) * time.Second
+	}
+	return defaultConvertTimeout
+}
@@ -0,0 +1,120 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"testing"
+
+	"github.com/bufbuild/connect-go"
+	helmv2 "github.com/fluxcd/helm-controller/api/v2beta1"
+	fluxmeta "github.com/fluxcd/pkg/apis/meta"
+	"github.com/vmware-tanzu/kubeapps/pkg/chart/models"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/release"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCheckConvertibleRelease(t *testing.T) {
+	fluxChart := &models.Chart{
+		Name:          "podinfo",
+		Repo:          &models.AppRepository{Name: "podinfo", Namespace: "default"},
+		ChartVersions: []models.ChartVersion{{Version: "6.0.3"}, {Version: "6.0.0"}},
+	}
+	helmRelease := func(status release.Status, chartName, version string) *release.Release {
+		return &release.Release{
+			Name:  "my-podinfo",
+			Info:  &release.Info{Status: status},
+			Chart: &chart.Chart{Metadata: &chart.Metadata{Name: chartName, Version: version}},
+		}
+	}
+
+	testCases := []struct {
+		name              string
+		release           *release.Release
+		expectedErrorCode connect.Code
+	}{
+		{
+			name:    "it accepts a deployed release of an available chart version",
+			release: helmRelease(release.StatusDeployed, "podinfo", "6.0.0"),
+		},
+		{
+			name:              "it rejects a release which is not deployed",
+			release:           helmRelease(release.StatusFailed, "podinfo", "6.0.0"),
+			expectedErrorCode: connect.CodeFailedPrecondition,
+		},
+		{
+			name:              "it rejects a release of another chart",
+			release:           helmRelease(release.StatusDeployed, "nginx", "6.0.0"),
+			expectedErrorCode: connect.CodeInvalidArgument,
+		},
+		{
+			name:              "it rejects a release of a version not available from the repository",
+			release:           helmRelease(release.StatusDeployed, "podinfo", "5.2.1"),
+			expectedErrorCode: connect.CodeFailedPrecondition,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkConvertibleRelease(tc.release, fluxChart)
+			if got, want := connect.CodeOf(err), tc.expectedErrorCode; err != nil && got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			} else if err == nil && tc.expectedErrorCode != 0 {
+				t.Fatalf("got: nil, want: error with code %+v", tc.expectedErrorCode)
+			}
+		})
+	}
+}
+
+func TestConvertedReleaseReconciled(t *testing.T) {
+	helmRelease := func(status metav1.ConditionStatus, reason string) helmv2.HelmRelease {
+		return helmv2.HelmRelease{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-podinfo", Namespace: "default", Generation: 1},
+			Status: helmv2.HelmReleaseStatus{
+				ObservedGeneration: 1,
+				Conditions: []metav1.Condition{{
+					Type:   fluxmeta.ReadyCondition,
+					Status: status,
+					Reason: reason,
+				}},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name              string
+		release           helmv2.HelmRelease
+		expectedDone      bool
+		expectedErrorCode connect.Code
+	}{
+		{
+			name:         "it is done once the HelmRelease is ready",
+			release:      helmRelease(metav1.ConditionTrue, helmv2.ReconciliationSucceededReason),
+			expectedDone: true,
+		},
+		{
+			name:    "it keeps waiting while the HelmRelease is progressing",
+			release: helmRelease(metav1.ConditionUnknown, fluxmeta.ProgressingReason),
+		},
+		{
+			name:              "it fails when the HelmRelease upgrade failed",
+			release:           helmRelease(metav1.ConditionFalse, helmv2.UpgradeFailedReason),
+			expectedErrorCode: connect.CodeFailedPrecondition,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			done, err := convertedReleaseReconciled(tc.release)
+			if got, want := connect.CodeOf(err), tc.expectedErrorCode; err != nil && got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			} else if err == nil && tc.expectedErrorCode != 0 {
+				t.Fatalf("got: nil, want: error with code %+v", tc.expectedErrorCode)
+			}
+			if got, want := done, tc.expectedDone; got != want {
+				t.Errorf("got: %t, want: %t", got, want)
+			}
+		})
+	}
+}
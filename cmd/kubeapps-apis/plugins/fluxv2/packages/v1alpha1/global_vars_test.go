@@ -2406,6 +2406,35 @@ var (
 		},
 	}
 
+	flux_helm_release_updated_reused_values = &helmv2.HelmRelease{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       helmv2.HelmReleaseKind,
+			APIVersion: helmv2.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "my-redis",
+			Namespace:       "test",
+			Generation:      int64(1),
+			ResourceVersion: "1000",
+		},
+		Spec: helmv2.HelmReleaseSpec{
+			Chart: helmv2.HelmChartTemplate{
+				Spec: helmv2.HelmChartTemplateSpec{
+					Chart: "redis",
+					SourceRef: helmv2.CrossNamespaceObjectReference{
+						Kind:      sourcev1.HelmRepositoryKind,
+						Name:      "bitnami-1",
+						Namespace: "default",
+					},
+					Version: ">14.4.0",
+				},
+			},
+			Interval: metav1.Duration{Duration: 1 * time.Minute},
+			Suspend:  true,
+			Values:   &v1.JSON{Raw: redis_existing_spec_completed_with_values_and_reconciliation_options_values_bytes},
+		},
+	}
+
 	redis_existing_spec_target_ns_is_set = testSpecGetInstalledPackages{
 		repoName:             "bitnami-1",
 		repoNamespace:        "default",
@@ -2613,6 +2642,13 @@ var (
 		TargetContext:       &corev1.Context{Namespace: "test"},
 	}
 
+	create_package_create_namespace_req = &corev1.CreateInstalledPackageRequest{
+		AvailablePackageRef: availableRef("podinfo/podinfo", "namespace-1"),
+		Name:                "my-podinfo",
+		TargetContext:       &corev1.Context{Namespace: "test"},
+		CreateNamespace:     true,
+	}
+
 	create_package_semver_constraint_req = &corev1.CreateInstalledPackageRequest{
 		AvailablePackageRef: availableRef("podinfo/podinfo", "namespace-1"),
 		Name:                "my-podinfo",
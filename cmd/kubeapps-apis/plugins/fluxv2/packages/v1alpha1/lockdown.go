@@ -0,0 +1,163 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	log "k8s.io/klog/v2"
+)
+
+const (
+	// name of the flux helm-controller deployment
+	helmControllerName = "helm-controller"
+	// how long the lockdown flags detected from the helm-controller are used
+	// before being detected again
+	fluxLockdownCacheTTL = 5 * time.Minute
+)
+
+// fluxLockdown describes the multi-tenancy lockdown restrictions enforced by
+// the flux helm-controller.
+// ref https://fluxcd.io/flux/installation/configuration/multitenancy/
+type fluxLockdown struct {
+	// noCrossNamespaceRefs is set when the controller refuses HelmReleases
+	// referencing a source in another namespace (--no-cross-namespace-refs).
+	noCrossNamespaceRefs bool
+	// defaultServiceAccount is the service account impersonated by the
+	// controller for HelmReleases which do not specify one
+	// (--default-service-account).
+	defaultServiceAccount string
+}
+
+// fluxLockdownCache holds the lockdown flags last detected from the
+// helm-controller. A nil cache detects the flags on every request.
+type fluxLockdownCache struct {
+	mu       sync.Mutex
+	lockdown fluxLockdown
+	expiry   time.Time
+}
+
+func newFluxLockdownCache() *fluxLockdownCache {
+	return &fluxLockdownCache{}
+}
+
+func (c *fluxLockdownCache) get() (fluxLockdown, bool) {
+	if c == nil {
+		return fluxLockdown{}, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if time.Now().After(c.expiry) {
+		return fluxLockdown{}, false
+	}
+	return c.lockdown, true
+}
+
+func (c *fluxLockdownCache) set(lockdown fluxLockdown) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lockdown = lockdown
+	c.expiry = time.Now().Add(fluxLockdownCacheTTL)
+}
+
+// getFluxLockdown returns the lockdown restrictions in effect, combining the
+// ones configured for the plugin with the ones detected from the arguments of
+// the helm-controller.
+func (s *Server) getFluxLockdown(ctx context.Context) fluxLockdown {
+	lockdown, ok := s.lockdownCache.get()
+	if !ok {
+		var err error
+		lockdown, err = s.detectFluxLockdown(ctx)
+		if err != nil {
+			// Not being able to read the helm-controller (e.g. flux being
+			// installed in another namespace) only means the configured
+			// restrictions are used.
+			log.Warningf("Unable to detect the flux multi-tenancy lockdown, using the plugin config: %v", err)
+		}
+		s.lockdownCache.set(lockdown)
+	}
+	if s.config().NoCrossNamespaceRefs {
+		lockdown.noCrossNamespaceRefs = true
+	}
+	return lockdown
+}
+
+// detectFluxLockdown reads the lockdown flags from the arguments of the
+// helm-controller deployment.
+func (s *Server) detectFluxLockdown(ctx context.Context) (fluxLockdown, error) {
+	if s.serviceAccountClientGetter == nil {
+		return fluxLockdown{}, fmt.Errorf("no service account client configured")
+	}
+	typedClient, err := s.serviceAccountClientGetter.Typed(ctx)
+	if err != nil {
+		return fluxLockdown{}, err
+	}
+	deployment, err := typedClient.AppsV1().Deployments(s.config().FluxNamespace).Get(ctx, helmControllerName, metav1.GetOptions{})
+	if err != nil {
+		return fluxLockdown{}, err
+	}
+	args := []string{}
+	for _, container := range deployment.Spec.Template.Spec.Containers {
+		args = append(args, container.Args...)
+	}
+	return fluxLockdownFromArgs(args), nil
+}
+
+// fluxLockdownFromArgs parses the lockdown flags from the helm-controller
+// arguments, ignoring any other flag.
+func fluxLockdownFromArgs(args []string) fluxLockdown {
+	flags := pflag.NewFlagSet(helmControllerName, pflag.ContinueOnError)
+	flags.ParseErrorsWhitelist.UnknownFlags = true
+	flags.SetOutput(io.Discard)
+	noCrossNamespaceRefs := flags.Bool("no-cross-namespace-refs", false, "")
+	defaultServiceAccount := flags.String("default-service-account", "", "")
+	if err := flags.Parse(args); err != nil {
+		log.Warningf("Unable to parse the %s arguments %v: %v", helmControllerName, args, err)
+	}
+	return fluxLockdown{
+		noCrossNamespaceRefs:  *noCrossNamespaceRefs,
+		defaultServiceAccount: *defaultServiceAccount,
+	}
+}
+
+// checkFluxLockdown returns an error explaining why flux would refuse to
+// reconcile a HelmRelease in the given namespace referencing the given
+// repository and impersonating the given service account, if any.
+func (s *Server) checkFluxLockdown(ctx context.Context, headers http.Header, repo types.NamespacedName, releaseNamespace, serviceAccountName string) error {
+	lockdown := s.getFluxLockdown(ctx)
+
+	if lockdown.noCrossNamespaceRefs && repo.Namespace != releaseNamespace {
+		return connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("Flux is configured to disallow cross-namespace references (--no-cross-namespace-refs), so packages from the repository [%s] can only be installed in the namespace [%s], not in [%s]", repo.String(), repo.Namespace, releaseNamespace))
+	}
+
+	if serviceAccountName != "" || lockdown.defaultServiceAccount == "" {
+		return nil
+	}
+	typedClient, err := s.clientGetter.Typed(headers, s.kubeappsCluster)
+	if err != nil {
+		return err
+	}
+	_, err = typedClient.CoreV1().ServiceAccounts(releaseNamespace).Get(ctx, lockdown.defaultServiceAccount, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("Flux is configured to impersonate the service account [%s] when none is specified (--default-service-account), but it does not exist in the namespace [%s]. Either create it or specify a service account in the reconciliation options", lockdown.defaultServiceAccount, releaseNamespace))
+	} else if err != nil {
+		// The user may not be able to read service accounts, in which case
+		// flux will report any issue when reconciling the release.
+		log.V(4).Infof("Unable to check the service account [%s/%s]: %v", releaseNamespace, lockdown.defaultServiceAccount, err)
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/fluxv2/packages/v1alpha1/common"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/clientgetter"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	typfake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestFluxLockdownFromArgs(t *testing.T) {
+	testCases := []struct {
+		name     string
+		args     []string
+		expected fluxLockdown
+	}{
+		{
+			name:     "no lockdown flags",
+			args:     []string{"--events-addr=http://notification-controller.flux-system.svc.cluster.local./", "--watch-all-namespaces=true", "--log-level=info"},
+			expected: fluxLockdown{},
+		},
+		{
+			name: "lockdown flags with values",
+			args: []string{"--watch-all-namespaces=true", "--no-cross-namespace-refs=true", "--default-service-account=default"},
+			expected: fluxLockdown{
+				noCrossNamespaceRefs:  true,
+				defaultServiceAccount: "default",
+			},
+		},
+		{
+			name: "lockdown flags with separate values",
+			args: []string{"--log-level", "info", "--no-cross-namespace-refs", "--default-service-account", "flux-tenant"},
+			expected: fluxLockdown{
+				noCrossNamespaceRefs:  true,
+				defaultServiceAccount: "flux-tenant",
+			},
+		},
+		{
+			name:     "cross namespace refs explicitly allowed",
+			args:     []string{"--no-cross-namespace-refs=false"},
+			expected: fluxLockdown{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got, want := fluxLockdownFromArgs(tc.args), tc.expected; got != want {
+				t.Errorf("got: %+v, want: %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestCheckFluxLockdown(t *testing.T) {
+	helmController := func(args ...string) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: helmControllerName, Namespace: common.DefaultFluxNamespace},
+			Spec: appsv1.DeploymentSpec{
+				Template: apiv1.PodTemplateSpec{
+					Spec: apiv1.PodSpec{
+						Containers: []apiv1.Container{{Name: "manager", Args: args}},
+					},
+				},
+			},
+		}
+	}
+	serviceAccount := &apiv1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "tenant-a"},
+	}
+	repo := types.NamespacedName{Namespace: "tenant-a", Name: "bitnami"}
+
+	testCases := []struct {
+		name                 string
+		objects              []runtime.Object
+		noCrossNamespaceRefs bool
+		releaseNamespace     string
+		serviceAccountName   string
+		expectedStatusCode   connect.Code
+	}{
+		{
+			name:             "allows cross namespace refs without lockdown",
+			objects:          []runtime.Object{helmController("--watch-all-namespaces=true")},
+			releaseNamespace: "tenant-b",
+		},
+		{
+			name:             "allows cross namespace refs if the helm-controller is not found",
+			releaseNamespace: "tenant-b",
+		},
+		{
+			name:               "refuses cross namespace refs when detected",
+			objects:            []runtime.Object{helmController("--no-cross-namespace-refs=true")},
+			releaseNamespace:   "tenant-b",
+			expectedStatusCode: connect.CodeFailedPrecondition,
+		},
+		{
+			name:                 "refuses cross namespace refs when configured",
+			noCrossNamespaceRefs: true,
+			releaseNamespace:     "tenant-b",
+			expectedStatusCode:   connect.CodeFailedPrecondition,
+		},
+		{
+			name:             "allows same namespace refs when detected",
+			objects:          []runtime.Object{helmController("--no-cross-namespace-refs=true")},
+			releaseNamespace: "tenant-a",
+		},
+		{
+			name:             "allows the default service account when it exists",
+			objects:          []runtime.Object{helmController("--default-service-account=default"), serviceAccount},
+			releaseNamespace: "tenant-a",
+		},
+		{
+			name:               "refuses the default service account when it does not exist",
+			objects:            []runtime.Object{helmController("--default-service-account=missing"), serviceAccount},
+			releaseNamespace:   "tenant-a",
+			expectedStatusCode: connect.CodeFailedPrecondition,
+		},
+		{
+			name:               "allows a missing default service account when one is specified",
+			objects:            []runtime.Object{helmController("--default-service-account=missing")},
+			releaseNamespace:   "tenant-a",
+			serviceAccountName: "tenant-a-sa",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			typedClient := typfake.NewSimpleClientset(tc.objects...)
+			pluginConfig := common.NewDefaultPluginConfig()
+			pluginConfig.NoCrossNamespaceRefs = tc.noCrossNamespaceRefs
+			s := &Server{
+				clientGetter:               clientgetter.NewBuilder().WithTyped(typedClient).Build(),
+				serviceAccountClientGetter: clientgetter.NewBuilder().WithTyped(typedClient).BuildFixedCluster(),
+				kubeappsCluster:            KubeappsCluster,
+				pluginConfig:               pluginConfig,
+				lockdownCache:              newFluxLockdownCache(),
+			}
+
+			err := s.checkFluxLockdown(context.Background(), http.Header{}, repo, tc.releaseNamespace, tc.serviceAccountName)
+
+			if got, want := connect.CodeOf(err), tc.expectedStatusCode; err != nil && got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+			if tc.expectedStatusCode != 0 && err == nil {
+				t.Fatalf("got: nil, want: error with code %+v", tc.expectedStatusCode)
+			}
+		})
+	}
+}
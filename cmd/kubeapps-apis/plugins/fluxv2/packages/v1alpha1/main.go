@@ -15,6 +15,10 @@ import (
 	log "k8s.io/klog/v2"
 )
 
+// This version var is updated during the build (see the -ldflags option in the
+// cmd/kubeapps-apis/Dockerfile)
+var version = "devel"
+
 // RegisterWithGRPCServer enables a plugin to register with a gRPC server
 // returning the server implementation.
 //
@@ -27,12 +31,13 @@ func RegisterWithGRPCServer(opts pluginsv1alpha1.GRPCPluginRegistrationOptions)
 	// 'Shutdown' hook
 	stopCh := make(chan struct{})
 
-	svr, err := NewServer(opts.ConfigGetter, opts.ClustersConfig.KubeappsClusterName, stopCh, opts.PluginConfigPath, opts.ClientQPS, opts.ClientBurst)
+	svr, err := NewServer(opts.ConfigGetter, opts.ClustersConfig.KubeappsClusterName, stopCh, opts.PluginConfigPath, opts.ClientQPS, opts.ClientBurst, opts.ClustersConfig.CABundleForCluster(opts.ClustersConfig.KubeappsClusterName))
 	if err != nil {
 		return nil, err
 	}
-	opts.Mux.Handle(packagesConnect.NewFluxV2PackagesServiceHandler(svr))
-	opts.Mux.Handle(packagesConnect.NewFluxV2RepositoriesServiceHandler(svr))
+	opts.PluginConfigWatcher.Register(GetPluginDetail(), svr.config(), svr.reloadPluginConfig)
+	opts.Mux.Handle(packagesConnect.NewFluxV2PackagesServiceHandler(svr, opts.HandlerOptions...))
+	opts.Mux.Handle(packagesConnect.NewFluxV2RepositoriesServiceHandler(svr, opts.HandlerOptions...))
 	return svr, nil
 }
 
@@ -49,3 +54,8 @@ func RegisterHTTPHandlerFromEndpoint(ctx context.Context, mux *runtime.ServeMux,
 		return v1alpha1.RegisterFluxV2RepositoriesServiceHandlerFromEndpoint(ctx, mux, endpoint, opts)
 	}
 }
+
+// GetPluginBuildVersion returns the version the plugin was built from.
+func GetPluginBuildVersion() string {
+	return version
+}
@@ -46,7 +46,7 @@ import (
 	log "k8s.io/klog/v2"
 
 	"github.com/fluxcd/pkg/oci/auth/login"
-	"github.com/fluxcd/pkg/version"
+	fluxversion "github.com/fluxcd/pkg/version"
 	sourcev1 "github.com/fluxcd/source-controller/api/v1beta2"
 
 	// OCI Registry As a Storage (ORAS)
@@ -322,7 +322,7 @@ func getLastMatchingVersionOrConstraint(cvs []string, ver string) (string, error
 
 	matchingVersions := make([]*semver.Version, 0, len(cvs))
 	for _, cv := range cvs {
-		v, err := version.ParseVersion(cv)
+		v, err := fluxversion.ParseVersion(cv)
 		if err != nil {
 			continue
 		}
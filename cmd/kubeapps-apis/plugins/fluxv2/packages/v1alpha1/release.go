@@ -16,12 +16,15 @@ import (
 	fluxmeta "github.com/fluxcd/pkg/apis/meta"
 	sourcev1 "github.com/fluxcd/source-controller/api/v1beta2"
 	corev1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/plugins/fluxv2/packages/v1alpha1"
 	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/fluxv2/packages/v1alpha1/common"
 	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/connecterror"
 	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/pkgutils"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/resources"
 	"github.com/vmware-tanzu/kubeapps/pkg/chart/models"
 	httpclient "github.com/vmware-tanzu/kubeapps/pkg/http-client"
 	"github.com/vmware-tanzu/kubeapps/pkg/tarutil"
+	"google.golang.org/protobuf/types/known/anypb"
 	"helm.sh/helm/v3/pkg/action"
 	"helm.sh/helm/v3/pkg/release"
 	"helm.sh/helm/v3/pkg/storage/driver"
@@ -29,8 +32,11 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
 	log "k8s.io/klog/v2"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/yaml"
 )
 
@@ -40,7 +46,7 @@ var (
 )
 
 // namespace maybe "", in which case releases from all namespaces are returned
-func (s *Server) listReleasesInCluster(ctx context.Context, headers http.Header, namespace string) ([]helmv2.HelmRelease, error) {
+func (s *Server) listReleasesInCluster(ctx context.Context, headers http.Header, namespace string, selector labels.Selector) ([]helmv2.HelmRelease, error) {
 	client, err := s.getClient(headers, namespace)
 	if err != nil {
 		return nil, err
@@ -54,13 +60,33 @@ func (s *Server) listReleasesInCluster(ctx context.Context, headers http.Header,
 	// see any results created/updated/deleted after the first request is issued
 	// To fix this, we must make use of resourceVersion := relList.GetResourceVersion()
 	var relList helmv2.HelmReleaseList
-	if err = client.List(ctx, &relList); err != nil {
+	if err = client.List(ctx, &relList, ctrlclient.MatchingLabelsSelector{Selector: selector}); err != nil {
 		return nil, connecterror.FromK8sError("list", "HelmRelease", namespace+"/*", err)
 	} else {
 		return relList.Items, nil
 	}
 }
 
+// getAccessibleNamespaces returns the names of the active namespaces to which the user has access
+func (s *Server) getAccessibleNamespaces(headers http.Header) ([]string, error) {
+	clusterTypedClientFunc := func() (kubernetes.Interface, error) {
+		return s.clientGetter.Typed(headers, s.kubeappsCluster)
+	}
+	inClusterTypedClientFunc := func() (kubernetes.Interface, error) {
+		return s.serviceAccountClientGetter.Typed(context.Background())
+	}
+
+	namespaceList, err := resources.FindAccessibleNamespaces(clusterTypedClientFunc, inClusterTypedClientFunc, int(s.clientQPS))
+	if err != nil {
+		return nil, err
+	}
+	var namespaces []string
+	for _, ns := range resources.FilterActiveNamespaces(namespaceList) {
+		namespaces = append(namespaces, ns.Name)
+	}
+	return namespaces, nil
+}
+
 func (s *Server) getReleaseInCluster(ctx context.Context, headers http.Header, key types.NamespacedName) (*helmv2.HelmRelease, error) {
 	client, err := s.getClient(headers, key.Namespace)
 	if err != nil {
@@ -74,12 +100,22 @@ func (s *Server) getReleaseInCluster(ctx context.Context, headers http.Header, k
 	return &rel, nil
 }
 
-func (s *Server) paginatedInstalledPkgSummaries(ctx context.Context, headers http.Header, namespace string, pageSize int32, itemOffset int) ([]*corev1.InstalledPackageSummary, error) {
-	releasesFromCluster, err := s.listReleasesInCluster(ctx, headers, namespace)
+func (s *Server) paginatedInstalledPkgSummaries(ctx context.Context, headers http.Header, namespace string, filterOptions *corev1.InstalledPackageFilterOptions, pageSize int32, itemOffset int) ([]*corev1.InstalledPackageSummary, error) {
+	selector, err := pkgutils.InstalledPackageLabelSelector(filterOptions)
+	if err != nil {
+		return nil, err
+	}
+	releasesFromCluster, err := s.listReleasesInCluster(ctx, headers, namespace, selector)
 	if err != nil {
 		return nil, err
 	}
 
+	// The status and pending upgrade filters can only be applied to the
+	// summaries once built, so the offset then counts the matching summaries
+	// rather than the releases.
+	postFilter := pkgutils.HasInstalledPackageSummaryFilters(filterOptions)
+	matched := 0
+
 	installedPkgSummaries := []*corev1.InstalledPackageSummary{}
 	if len(releasesFromCluster) > 0 {
 		startAt := -1
@@ -88,7 +124,7 @@ func (s *Server) paginatedInstalledPkgSummaries(ctx context.Context, headers htt
 		}
 
 		for i, r := range releasesFromCluster {
-			if startAt <= i {
+			if postFilter || startAt <= i {
 				summary, err := s.installedPkgSummaryFromRelease(ctx, headers, r)
 				if err != nil {
 					return nil, err
@@ -96,6 +132,15 @@ func (s *Server) paginatedInstalledPkgSummaries(ctx context.Context, headers htt
 					// not ready yet
 					continue
 				}
+				if postFilter {
+					if !pkgutils.MatchesInstalledPackageSummaryFilters(summary, filterOptions) {
+						continue
+					}
+					matched++
+					if matched <= startAt {
+						continue
+					}
+				}
 				installedPkgSummaries = append(installedPkgSummaries, summary)
 				if pageSize > 0 && len(installedPkgSummaries) == int(pageSize) {
 					break
@@ -264,6 +309,22 @@ func (s *Server) installedPackageDetail(ctx context.Context, headers http.Header
 		log.Warningf("Failed to get helm release due to %v", err)
 	}
 
+	var valuesFromGit *v1alpha1.GitValuesReference
+	if hasGitValues(rel) {
+		if valuesFromGit, err = s.getGitValuesReference(ctx, headers, key); err != nil {
+			log.Warningf("Failed to get the values from Git of the release %q due to %v", key, err)
+		}
+	}
+
+	var customDetail *anypb.Any
+	detail := installedPackageCustomDetail(rel)
+	detail.ValuesFromGit = valuesFromGit
+	if len(detail.DependsOn) > 0 || detail.ValuesFromGit != nil || len(detail.PostRenderPatches) > 0 {
+		if customDetail, err = anypb.New(detail); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to serialize the custom detail of the release %q: %w", key, err))
+		}
+	}
+
 	return &corev1.InstalledPackageDetail{
 		InstalledPackageRef: &corev1.InstalledPackageReference{
 			Context: &corev1.Context{
@@ -284,6 +345,8 @@ func (s *Server) installedPackageDetail(ctx context.Context, headers http.Header
 		AvailablePackageRef:   availablePackageRef,
 		PostInstallationNotes: postInstallNotes,
 		Status:                installedPackageStatus(*rel),
+		CustomDetail:          customDetail,
+		Metadata:              resources.InstalledPackageMetadataFromObject(rel.ObjectMeta, s.config().InstalledPackageMetadata),
 	}, nil
 }
 
@@ -310,13 +373,20 @@ func (s *Server) getReleaseViaHelmApi(headers http.Header, key types.NamespacedN
 	return release, nil
 }
 
-func (s *Server) newRelease(ctx context.Context, headers http.Header, packageRef *corev1.AvailablePackageReference, targetName types.NamespacedName, versionRef *corev1.VersionReference, reconcile *corev1.ReconciliationOptions, valuesString string) (*corev1.InstalledPackageReference, error) {
+func (s *Server) newRelease(ctx context.Context, headers http.Header, packageRef *corev1.AvailablePackageReference, targetName types.NamespacedName, versionRef *corev1.VersionReference, reconcile *corev1.ReconciliationOptions, valuesString string, customDetail *v1alpha1.FluxInstalledPackageCustomDetail, metadata *corev1.InstalledPackageMetadata, createNamespace bool) (*corev1.InstalledPackageReference, error) {
 	repoName, chartName, err := pkgutils.SplitPackageIdentifier(packageRef.Identifier)
 	if err != nil {
 		return nil, err
 	}
 
 	repo := types.NamespacedName{Namespace: packageRef.Context.Namespace, Name: repoName}
+	if err = s.checkFluxLockdown(ctx, headers, repo, targetName.Namespace, reconcile.GetServiceAccountName()); err != nil {
+		return nil, err
+	}
+	if err = s.checkRepoAllowsPackage(ctx, repo, chartName); err != nil {
+		return nil, err
+	}
+
 	chart, err := s.getChartModel(ctx, headers, repo, chartName)
 	if err != nil {
 		return nil, err
@@ -335,17 +405,37 @@ func (s *Server) newRelease(ctx context.Context, headers http.Header, packageRef
 	versionExpr := versionRef.GetVersion()
 	if versionExpr != "" {
 		versionExpr, err = pkgutils.VersionConstraintWithUpgradePolicy(
-			versionRef.GetVersion(), s.pluginConfig.DefaultUpgradePolicy)
+			versionRef.GetVersion(), s.config().DefaultUpgradePolicy)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	fluxRelease, err := s.newFluxHelmRelease(chart, targetName, versionExpr, reconcile, values)
+	dependsOn, err := helmReleaseDependsOn(targetName, customDetail)
+	if err != nil {
+		return nil, err
+	}
+	valuesFromGit := customDetail.GetValuesFromGit()
+	if valuesFromGit != nil {
+		if err = validateGitValuesReference(valuesFromGit); err != nil {
+			return nil, err
+		}
+	}
+	patches, err := postRenderPatches(customDetail)
 	if err != nil {
 		return nil, err
 	}
 
+	fluxRelease, err := s.newFluxHelmRelease(chart, targetName, versionExpr, reconcile, values, dependsOn)
+	if err != nil {
+		return nil, err
+	}
+	setPostRenderPatches(fluxRelease, patches)
+	if metadata != nil {
+		fluxRelease.Labels = metadata.GetLabels()
+		fluxRelease.Annotations = metadata.GetAnnotations()
+	}
+
 	// per https://github.com/vmware-tanzu/kubeapps/pull/3640#issuecomment-949315105
 	// the helm release CR to also be created in the target namespace (where the helm
 	// release itself is currently created)
@@ -354,7 +444,32 @@ func (s *Server) newRelease(ctx context.Context, headers http.Header, packageRef
 		return nil, err
 	}
 
+	// create the target namespace when requested, deleting it again if the
+	// HelmRelease cannot be created
+	namespaceCreated := false
+	var typedClient kubernetes.Interface
+	if createNamespace {
+		if typedClient, err = s.clientGetter.Typed(headers, s.kubeappsCluster); err != nil {
+			return nil, err
+		}
+		namespaceCreated, err = resources.EnsureNamespace(ctx, typedClient, targetName.Namespace, s.config().NamespaceCreation)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// the values from Git are stored before creating the HelmRelease, so that
+	// its first reconciliation already merges them
+	if valuesFromGit != nil {
+		if err = s.syncGitValues(ctx, headers, targetName, valuesFromGit, fluxRelease.Spec.Interval); err != nil {
+			s.cleanupNewRelease(ctx, headers, targetName, true, namespaceCreated, typedClient)
+			return nil, err
+		}
+		setGitValuesFrom(fluxRelease, true)
+	}
+
 	if err = client.Create(ctx, fluxRelease); err != nil {
+		s.cleanupNewRelease(ctx, headers, targetName, valuesFromGit != nil, namespaceCreated, typedClient)
 		return nil, connecterror.FromK8sError("create", "HelmRelease", targetName.String(), err)
 	}
 
@@ -368,7 +483,20 @@ func (s *Server) newRelease(ctx context.Context, headers http.Header, packageRef
 	}, nil
 }
 
-func (s *Server) updateRelease(ctx context.Context, headers http.Header, packageRef *corev1.InstalledPackageReference, versionRef *corev1.VersionReference, reconcile *corev1.ReconciliationOptions, valuesString string) (*corev1.InstalledPackageReference, error) {
+// cleanupNewRelease deletes the objects created for a new release which could
+// not be created.
+func (s *Server) cleanupNewRelease(ctx context.Context, headers http.Header, targetName types.NamespacedName, gitValues, namespaceCreated bool, typedClient kubernetes.Interface) {
+	if gitValues {
+		if err := s.deleteGitValues(ctx, headers, targetName); err != nil {
+			log.Warningf("Failed to delete the values from Git of the release %q due to %v", targetName, err)
+		}
+	}
+	if namespaceCreated {
+		resources.DeleteCreatedNamespace(ctx, typedClient, targetName.Namespace)
+	}
+}
+
+func (s *Server) updateRelease(ctx context.Context, headers http.Header, packageRef *corev1.InstalledPackageReference, versionRef *corev1.VersionReference, reconcile *corev1.ReconciliationOptions, valuesString string, reuseValues bool, customDetail *v1alpha1.FluxInstalledPackageCustomDetail, metadata *corev1.InstalledPackageMetadata) (*corev1.InstalledPackageReference, error) {
 	key := types.NamespacedName{Name: packageRef.Identifier, Namespace: packageRef.Context.Namespace}
 
 	rel, err := s.getReleaseInCluster(ctx, headers, key)
@@ -403,7 +531,7 @@ func (s *Server) updateRelease(ctx context.Context, headers http.Header, package
 	versionExpr := versionRef.GetVersion()
 	if versionExpr != "" {
 		versionExpr, err = pkgutils.VersionConstraintWithUpgradePolicy(
-			versionRef.GetVersion(), s.pluginConfig.DefaultUpgradePolicy)
+			versionRef.GetVersion(), s.config().DefaultUpgradePolicy)
 		if err != nil {
 			return nil, err
 		}
@@ -412,7 +540,10 @@ func (s *Server) updateRelease(ctx context.Context, headers http.Header, package
 		rel.Spec.Chart.Spec.Version = ""
 	}
 
-	if valuesString != "" {
+	switch {
+	case reuseValues:
+		// the values of the HelmRelease are kept as they are
+	case valuesString != "":
 		// could be JSON or YAML
 		var values map[string]interface{}
 		if err = yaml.Unmarshal([]byte(valuesString), &values); err != nil {
@@ -423,7 +554,7 @@ func (s *Server) updateRelease(ctx context.Context, headers http.Header, package
 			return nil, err
 		}
 		rel.Spec.Values = &v1.JSON{Raw: byteArray}
-	} else {
+	default:
 		rel.Spec.Values = nil
 	}
 
@@ -453,6 +584,52 @@ func (s *Server) updateRelease(ctx context.Context, headers http.Header, package
 		rel.Spec.ServiceAccountName = ""
 	}
 
+	// the dependencies, the post render patches and the values from Git are
+	// only replaced when a custom detail is provided. Otherwise, the values
+	// from Git are read again, so that the update picks up their latest revision
+	deleteGitValues := false
+	if customDetail != nil {
+		if rel.Spec.DependsOn, err = helmReleaseDependsOn(key, customDetail); err != nil {
+			return nil, err
+		}
+		patches, err := postRenderPatches(customDetail)
+		if err != nil {
+			return nil, err
+		}
+		setPostRenderPatches(rel, patches)
+		if valuesFromGit := customDetail.GetValuesFromGit(); valuesFromGit != nil {
+			if err = validateGitValuesReference(valuesFromGit); err != nil {
+				return nil, err
+			}
+			if err = s.syncGitValues(ctx, headers, key, valuesFromGit, rel.Spec.Interval); err != nil {
+				return nil, err
+			}
+			setGitValuesFrom(rel, true)
+		} else if hasGitValues(rel) {
+			setGitValuesFrom(rel, false)
+			deleteGitValues = true
+		}
+	} else if hasGitValues(rel) {
+		if err = s.syncGitValues(ctx, headers, key, nil, rel.Spec.Interval); err != nil {
+			return nil, err
+		}
+	}
+
+	// likewise, the labels and annotations are only replaced when provided,
+	// keeping the protected ones
+	if metadata != nil {
+		rel.Labels = resources.MergeInstalledPackageMetadata(rel.Labels, metadata.GetLabels(), s.config().InstalledPackageMetadata)
+		rel.Annotations = resources.MergeInstalledPackageMetadata(rel.Annotations, metadata.GetAnnotations(), s.config().InstalledPackageMetadata)
+	}
+
+	sourceRef := types.NamespacedName{Namespace: rel.Spec.Chart.Spec.SourceRef.Namespace, Name: rel.Spec.Chart.Spec.SourceRef.Name}
+	if sourceRef.Namespace == "" {
+		sourceRef.Namespace = rel.Namespace
+	}
+	if err = s.checkFluxLockdown(ctx, headers, sourceRef, rel.Namespace, rel.Spec.ServiceAccountName); err != nil {
+		return nil, err
+	}
+
 	// get rid of the status field, since now there will be a new reconciliation
 	// process and the current status no longer applies. metadata and spec I want
 	// to keep, as they may have had added labels and/or annotations and/or
@@ -467,6 +644,11 @@ func (s *Server) updateRelease(ctx context.Context, headers http.Header, package
 	if err = client.Update(ctx, rel); err != nil {
 		return nil, connecterror.FromK8sError("update", "HelmRelease", key.String(), err)
 	}
+	if deleteGitValues {
+		if err = s.deleteGitValues(ctx, headers, key); err != nil {
+			return nil, err
+		}
+	}
 
 	log.V(4).Infof("Updated release: %s", common.PrettyPrint(rel))
 
@@ -498,7 +680,7 @@ func (s *Server) deleteRelease(ctx context.Context, headers http.Header, package
 	if err = client.Delete(ctx, rel); err != nil {
 		return connecterror.FromK8sError("delete", "HelmRelease", packageRef.Identifier, err)
 	}
-	return nil
+	return s.deleteGitValues(ctx, headers, types.NamespacedName{Namespace: rel.Namespace, Name: rel.Name})
 }
 
 // Potentially, there are 3 different namespaces that can be specified here
@@ -506,7 +688,7 @@ func (s *Server) deleteRelease(ctx context.Context, headers http.Header, package
 //  2. metadata.namespace, where this HelmRelease CRD will exist, same as (3) below
 //     per https://github.com/vmware-tanzu/kubeapps/pull/3640#issuecomment-949315105
 //  3. spec.targetNamespace, where flux will install any artifacts from the release
-func (s *Server) newFluxHelmRelease(chart *models.Chart, targetName types.NamespacedName, versionExpr string, reconcile *corev1.ReconciliationOptions, values map[string]interface{}) (*helmv2.HelmRelease, error) {
+func (s *Server) newFluxHelmRelease(chart *models.Chart, targetName types.NamespacedName, versionExpr string, reconcile *corev1.ReconciliationOptions, values map[string]interface{}, dependsOn []fluxmeta.NamespacedObjectReference) (*helmv2.HelmRelease, error) {
 	fluxRelease := &helmv2.HelmRelease{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      targetName.Name,
@@ -528,6 +710,9 @@ func (s *Server) newFluxHelmRelease(chart *models.Chart, targetName types.Namesp
 	if versionExpr != "" {
 		fluxRelease.Spec.Chart.Spec.Version = versionExpr
 	}
+	if len(dependsOn) > 0 {
+		fluxRelease.Spec.DependsOn = dependsOn
+	}
 
 	reconcileInterval := defaultReconcileInterval // unless explicitly specified
 	if reconcile != nil {
@@ -559,8 +744,8 @@ func (s *Server) newFluxHelmRelease(chart *models.Chart, targetName types.Namesp
 	// So far we just use one configured per server/installation, if specified,
 	// same as helm plug-in.
 	// Otherwise the default timeout is used.
-	if s.pluginConfig.TimeoutSeconds > 0 {
-		timeoutInterval := metav1.Duration{Duration: time.Duration(s.pluginConfig.TimeoutSeconds) * time.Second}
+	if s.config().TimeoutSeconds > 0 {
+		timeoutInterval := metav1.Duration{Duration: time.Duration(s.config().TimeoutSeconds) * time.Second}
 		fluxRelease.Spec.Timeout = &timeoutInterval
 	}
 	return fluxRelease, nil
@@ -595,6 +780,11 @@ func isHelmReleaseReady(rel helmv2.HelmRelease) (ready bool, status corev1.Insta
 	readyCond := meta.FindStatusCondition(rel.GetConditions(), fluxmeta.ReadyCondition)
 
 	if readyCond != nil {
+		if readyCond.Reason == helmv2.DependencyNotReadyReason {
+			// flux neither installs nor upgrades the release until all the
+			// HelmReleases in spec.dependsOn are ready, retrying every interval
+			return false, corev1.InstalledPackageStatus_STATUS_REASON_PENDING, "Waiting for dependencies: " + readyCond.Message
+		}
 		if readyCond.Reason != "" {
 			// this could be something like
 			//   "reason": "InstallFailed"
@@ -668,6 +858,57 @@ func installedPackageAvailablePackageRef(rel *helmv2.HelmRelease) (*corev1.Avail
 	}, nil
 }
 
+// installedPackageCustomDetailFromAny returns the flux custom detail of an
+// installed package request, or nil if none was provided.
+func installedPackageCustomDetailFromAny(detail *anypb.Any) (*v1alpha1.FluxInstalledPackageCustomDetail, error) {
+	if detail == nil {
+		return nil, nil
+	}
+	customDetail := &v1alpha1.FluxInstalledPackageCustomDetail{}
+	if err := detail.UnmarshalTo(customDetail); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("The custom detail is invalid: %w", err))
+	}
+	return customDetail, nil
+}
+
+func installedPackageCustomDetail(rel *helmv2.HelmRelease) *v1alpha1.FluxInstalledPackageCustomDetail {
+	dependsOn := make([]*v1alpha1.HelmReleaseDependency, len(rel.Spec.DependsOn))
+	for i, dependency := range rel.Spec.DependsOn {
+		dependsOn[i] = &v1alpha1.HelmReleaseDependency{
+			Name:      dependency.Name,
+			Namespace: dependency.Namespace,
+		}
+	}
+	return &v1alpha1.FluxInstalledPackageCustomDetail{
+		DependsOn:         dependsOn,
+		PostRenderPatches: installedPackagePostRenderPatches(rel),
+	}
+}
+
+// helmReleaseDependsOn returns the spec.dependsOn of the HelmRelease named
+// key for the dependencies of the custom detail.
+// ref https://fluxcd.io/flux/components/helm/helmreleases/#helmrelease-dependencies
+func helmReleaseDependsOn(key types.NamespacedName, customDetail *v1alpha1.FluxInstalledPackageCustomDetail) ([]fluxmeta.NamespacedObjectReference, error) {
+	var dependsOn []fluxmeta.NamespacedObjectReference
+	for _, dependency := range customDetail.GetDependsOn() {
+		if dependency.GetName() == "" {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("The dependencies of the release %q must have a name", key))
+		}
+		namespace := dependency.GetNamespace()
+		if namespace == "" {
+			namespace = key.Namespace
+		}
+		if dependency.GetName() == key.Name && namespace == key.Namespace {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("The release %q cannot depend on itself", key))
+		}
+		dependsOn = append(dependsOn, fluxmeta.NamespacedObjectReference{
+			Name:      dependency.GetName(),
+			Namespace: dependency.GetNamespace(),
+		})
+	}
+	return dependsOn, nil
+}
+
 // ref https://fluxcd.io/docs/components/helm/helmreleases/
 func helmReleaseName(key types.NamespacedName, rel *helmv2.HelmRelease) types.NamespacedName {
 	helmReleaseName := rel.Spec.ReleaseName
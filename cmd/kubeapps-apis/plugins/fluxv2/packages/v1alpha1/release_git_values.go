@@ -0,0 +1,442 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/bufbuild/connect-go"
+	helmv2 "github.com/fluxcd/helm-controller/api/v2beta1"
+	fluxmeta "github.com/fluxcd/pkg/apis/meta"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1beta2"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/plugins/fluxv2/packages/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/connecterror"
+	httpclient "github.com/vmware-tanzu/kubeapps/pkg/http-client"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	log "k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// label set on the GitRepository and ConfigMap managed for the values
+	// from Git of a HelmRelease, with the name of the HelmRelease as value
+	gitValuesReleaseLabel = "fluxv2.kubeapps.dev/values-of"
+	// annotation set on the managed ConfigMap with the path of the values
+	// file within the GitRepository
+	gitValuesPathAnnotation = "fluxv2.kubeapps.dev/values-path"
+	// annotation set on the managed ConfigMap with the revision of the
+	// GitRepository artifact the values were read from
+	gitValuesRevisionAnnotation = "fluxv2.kubeapps.dev/values-revision"
+	// key of the values in the managed ConfigMap
+	gitValuesKey = "values.yaml"
+
+	gitValuesPollingInterval = 2 * time.Second
+	// the maximum size of the values file
+	gitValuesMaxBytes = 1024 * 1024
+)
+
+var (
+	// how long to wait for the managed GitRepository to fetch the values file
+	gitValuesTimeout = 1 * time.Minute
+	// the maximum size of the gzipped tarball of the GitRepository artifact
+	gitValuesArtifactMaxBytes int64 = 32 * 1024 * 1024
+)
+
+// gitValuesName returns the name of the GitRepository and ConfigMap managed
+// for the values from Git of the HelmRelease named key.
+func gitValuesName(key types.NamespacedName) types.NamespacedName {
+	return types.NamespacedName{Namespace: key.Namespace, Name: key.Name + "-values"}
+}
+
+// validateGitValuesReference checks the reference to a values file in Git.
+func validateGitValuesReference(ref *v1alpha1.GitValuesReference) error {
+	if ref.GetUrl() == "" {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("The URL of the Git repository with the values is required"))
+	}
+	valuesPath := ref.GetPath()
+	if valuesPath == "" || path.IsAbs(valuesPath) || strings.HasPrefix(path.Clean(valuesPath), "..") {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("The path of the values file must be relative to the root of the Git repository: %q", valuesPath))
+	}
+	set := 0
+	for _, r := range []string{ref.GetBranch(), ref.GetTag(), ref.GetCommit()} {
+		if r != "" {
+			set++
+		}
+	}
+	if set > 1 {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("At most one of the branch, tag and commit of the Git repository with the values can be set"))
+	}
+	return nil
+}
+
+// newGitValuesRepository returns the GitRepository managed for the values
+// from Git of the HelmRelease named key.
+func newGitValuesRepository(key types.NamespacedName, ref *v1alpha1.GitValuesReference, interval metav1.Duration) *sourcev1.GitRepository {
+	name := gitValuesName(key)
+	repo := &sourcev1.GitRepository{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name.Name,
+			Namespace: name.Namespace,
+			Labels:    map[string]string{gitValuesReleaseLabel: key.Name},
+		},
+	}
+	setGitValuesRepositorySpec(repo, ref, interval)
+	return repo
+}
+
+func setGitValuesRepositorySpec(repo *sourcev1.GitRepository, ref *v1alpha1.GitValuesReference, interval metav1.Duration) {
+	repo.Spec.URL = ref.GetUrl()
+	repo.Spec.Interval = interval
+	repo.Spec.Reference = nil
+	if ref.GetBranch() != "" || ref.GetTag() != "" || ref.GetCommit() != "" {
+		repo.Spec.Reference = &sourcev1.GitRepositoryRef{
+			Branch: ref.GetBranch(),
+			Tag:    ref.GetTag(),
+			Commit: ref.GetCommit(),
+		}
+	}
+	repo.Spec.SecretRef = nil
+	if ref.GetSecretRef() != "" {
+		repo.Spec.SecretRef = &fluxmeta.LocalObjectReference{Name: ref.GetSecretRef()}
+	}
+}
+
+// gitValuesReference returns the reference to the values file in Git of the
+// managed GitRepository, with the path recorded on the managed ConfigMap.
+func gitValuesReference(repo *sourcev1.GitRepository, valuesPath string) *v1alpha1.GitValuesReference {
+	ref := &v1alpha1.GitValuesReference{
+		Url:  repo.Spec.URL,
+		Path: valuesPath,
+	}
+	if repo.Spec.Reference != nil {
+		ref.Branch = repo.Spec.Reference.Branch
+		ref.Tag = repo.Spec.Reference.Tag
+		ref.Commit = repo.Spec.Reference.Commit
+	}
+	if repo.Spec.SecretRef != nil {
+		ref.SecretRef = repo.Spec.SecretRef.Name
+	}
+	return ref
+}
+
+// gitValuesFrom returns the spec.valuesFrom entry of the HelmRelease named key
+// for its values from Git.
+func gitValuesFrom(key types.NamespacedName) helmv2.ValuesReference {
+	return helmv2.ValuesReference{
+		Kind:      "ConfigMap",
+		Name:      gitValuesName(key).Name,
+		ValuesKey: gitValuesKey,
+	}
+}
+
+// hasGitValues returns whether the HelmRelease uses values from Git managed by
+// the plugin.
+func hasGitValues(rel *helmv2.HelmRelease) bool {
+	name := gitValuesName(types.NamespacedName{Namespace: rel.Namespace, Name: rel.Name}).Name
+	for _, valuesFrom := range rel.Spec.ValuesFrom {
+		if valuesFrom.Kind == "ConfigMap" && valuesFrom.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// setGitValuesFrom adds or removes the spec.valuesFrom entry of the values
+// from Git of the HelmRelease, keeping the other entries. The values from Git
+// come first, so that the other values references override them.
+func setGitValuesFrom(rel *helmv2.HelmRelease, enabled bool) {
+	key := types.NamespacedName{Namespace: rel.Namespace, Name: rel.Name}
+	valuesFrom := []helmv2.ValuesReference{}
+	if enabled {
+		valuesFrom = append(valuesFrom, gitValuesFrom(key))
+	}
+	for _, v := range rel.Spec.ValuesFrom {
+		if v.Kind == "ConfigMap" && v.Name == gitValuesName(key).Name {
+			continue
+		}
+		valuesFrom = append(valuesFrom, v)
+	}
+	rel.Spec.ValuesFrom = valuesFrom
+	if len(valuesFrom) == 0 {
+		rel.Spec.ValuesFrom = nil
+	}
+}
+
+// syncGitValues creates or updates the GitRepository managed for the values
+// from Git of the HelmRelease named key, waits for flux to fetch it and
+// stores the values file in the managed ConfigMap. When ref is nil, the
+// existing GitRepository is fetched again, so that the ConfigMap holds the
+// latest values.
+func (s *Server) syncGitValues(ctx context.Context, headers http.Header, key types.NamespacedName, ref *v1alpha1.GitValuesReference, interval metav1.Duration) error {
+	name := gitValuesName(key)
+	client, err := s.getClient(headers, key.Namespace)
+	if err != nil {
+		return err
+	}
+
+	valuesPath := ref.GetPath()
+	repo := &sourcev1.GitRepository{}
+	if err = client.Get(ctx, name, repo); err != nil && !errors.IsNotFound(err) {
+		return connecterror.FromK8sError("get", "GitRepository", name.String(), err)
+	} else if errors.IsNotFound(err) {
+		if ref == nil {
+			return connect.NewError(connect.CodeNotFound, fmt.Errorf("The GitRepository %q with the values of the release %q is missing", name, key))
+		}
+		repo = newGitValuesRepository(key, ref, interval)
+		if err = client.Create(ctx, repo); err != nil {
+			return connecterror.FromK8sError("create", "GitRepository", name.String(), err)
+		}
+	} else if repo.Labels[gitValuesReleaseLabel] != key.Name {
+		return connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("The GitRepository %q is not managed for the values of the release %q", name, key))
+	} else if ref != nil {
+		setGitValuesRepositorySpec(repo, ref, interval)
+		if err = client.Update(ctx, repo); err != nil {
+			return connecterror.FromK8sError("update", "GitRepository", name.String(), err)
+		}
+	} else {
+		// ask flux to fetch the repository again rather than waiting for the
+		// next interval
+		if repo.Annotations == nil {
+			repo.Annotations = map[string]string{}
+		}
+		repo.Annotations[fluxmeta.ReconcileRequestAnnotation] = time.Now().Format(time.RFC3339Nano)
+		if err = client.Update(ctx, repo); err != nil {
+			return connecterror.FromK8sError("update", "GitRepository", name.String(), err)
+		}
+		if valuesPath, err = s.gitValuesPath(ctx, headers, key); err != nil {
+			return err
+		}
+	}
+
+	var artifact *sourcev1.Artifact
+	err = wait.PollImmediateWithContext(ctx, gitValuesPollingInterval, gitValuesTimeout, func(ctx context.Context) (bool, error) {
+		if err := client.Get(ctx, name, repo); err != nil {
+			return false, connecterror.FromK8sError("get", "GitRepository", name.String(), err)
+		}
+		var done bool
+		artifact, done, err = gitValuesArtifact(repo)
+		return done, err
+	})
+	if err == wait.ErrWaitTimeout {
+		return connect.NewError(connect.CodeDeadlineExceeded, fmt.Errorf("The GitRepository %q with the values of the release %q was not fetched within %s", name, key, gitValuesTimeout))
+	} else if err != nil {
+		return err
+	}
+
+	tarball, err := downloadGitValuesArtifact(artifact.URL)
+	if err != nil {
+		return connect.NewError(connect.CodeOf(err), fmt.Errorf("Unable to download the artifact of the GitRepository %q: %w", name, err))
+	}
+	values, err := valuesFileFromTarball(tarball, valuesPath)
+	if err != nil {
+		return err
+	}
+	return s.storeGitValues(ctx, headers, key, valuesPath, artifact.Revision, values)
+}
+
+// gitValuesArtifact returns the artifact of the GitRepository once flux has
+// fetched its current generation, or an error if fetching it failed.
+func gitValuesArtifact(repo *sourcev1.GitRepository) (*sourcev1.Artifact, bool, error) {
+	readyCond := meta.FindStatusCondition(repo.Status.Conditions, fluxmeta.ReadyCondition)
+	if readyCond == nil || readyCond.ObservedGeneration != repo.Generation {
+		return nil, false, nil
+	}
+	// the fetch is being retried while reconciling
+	if meta.IsStatusConditionTrue(repo.Status.Conditions, fluxmeta.ReconcilingCondition) {
+		return nil, false, nil
+	}
+	if readyCond.Status != metav1.ConditionTrue || repo.GetArtifact() == nil {
+		return nil, false, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("Unable to fetch the GitRepository %q with the values: %s: %s", repo.Name, readyCond.Reason, readyCond.Message))
+	}
+	return repo.GetArtifact(), true, nil
+}
+
+// downloadGitValuesArtifact returns the gzipped tarball of a GitRepository
+// artifact, failing once it exceeds gitValuesArtifactMaxBytes. As for the index
+// of the HelmRepositories, the artifact is served by the flux
+// source-controller within the cluster.
+func downloadGitValuesArtifact(artifactURL string) ([]byte, error) {
+	reader, _, err := httpclient.GetStream(artifactURL, httpclient.New(), nil)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnavailable, err)
+	}
+	defer reader.Close()
+
+	tarball, err := io.ReadAll(io.LimitReader(reader, gitValuesArtifactMaxBytes+1))
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnavailable, err)
+	}
+	if int64(len(tarball)) > gitValuesArtifactMaxBytes {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("the artifact is bigger than %d bytes", gitValuesArtifactMaxBytes))
+	}
+	return tarball, nil
+}
+
+// valuesFileFromTarball returns the values file at the given path of the
+// gzipped tarball of a GitRepository artifact, checking it is valid YAML.
+func valuesFileFromTarball(tarball []byte, valuesPath string) (string, error) {
+	gzipReader, err := gzip.NewReader(bytes.NewReader(tarball))
+	if err != nil {
+		return "", connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to read the artifact of the GitRepository: %w", err))
+	}
+	defer gzipReader.Close()
+
+	valuesPath = path.Clean(valuesPath)
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return "", connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to read the artifact of the GitRepository: %w", err))
+		}
+		if header.Typeflag != tar.TypeReg || path.Clean(header.Name) != valuesPath {
+			continue
+		}
+		if header.Size > gitValuesMaxBytes {
+			return "", connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("The values file %q is bigger than %d bytes", valuesPath, gitValuesMaxBytes))
+		}
+		content, err := io.ReadAll(io.LimitReader(tarReader, gitValuesMaxBytes+1))
+		if err != nil {
+			return "", connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to read the values file %q: %w", valuesPath, err))
+		}
+		if len(content) > gitValuesMaxBytes {
+			return "", connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("The values file %q is bigger than %d bytes", valuesPath, gitValuesMaxBytes))
+		}
+		values := map[string]interface{}{}
+		if err = yaml.Unmarshal(content, &values); err != nil {
+			return "", connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("The values file %q is not valid YAML: %w", valuesPath, err))
+		}
+		return string(content), nil
+	}
+	return "", connect.NewError(connect.CodeNotFound, fmt.Errorf("The values file %q was not found in the Git repository", valuesPath))
+}
+
+// storeGitValues creates or updates the ConfigMap managed for the values from
+// Git of the HelmRelease named key.
+func (s *Server) storeGitValues(ctx context.Context, headers http.Header, key types.NamespacedName, valuesPath, revision, values string) error {
+	typedClient, err := s.clientGetter.Typed(headers, s.kubeappsCluster)
+	if err != nil {
+		return err
+	}
+	name := gitValuesName(key)
+	configMaps := typedClient.CoreV1().ConfigMaps(name.Namespace)
+
+	cm, err := configMaps.Get(ctx, name.Name, metav1.GetOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return connecterror.FromK8sError("get", "ConfigMap", name.String(), err)
+	}
+	if errors.IsNotFound(err) {
+		cm = &apiv1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name.Name,
+				Namespace: name.Namespace,
+				Labels:    map[string]string{gitValuesReleaseLabel: key.Name},
+			},
+		}
+	} else if cm.Labels[gitValuesReleaseLabel] != key.Name {
+		return connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("The ConfigMap %q is not managed for the values of the release %q", name, key))
+	}
+	if cm.Annotations == nil {
+		cm.Annotations = map[string]string{}
+	}
+	cm.Annotations[gitValuesPathAnnotation] = valuesPath
+	cm.Annotations[gitValuesRevisionAnnotation] = revision
+	cm.Data = map[string]string{gitValuesKey: values}
+
+	if cm.ResourceVersion == "" {
+		_, err = configMaps.Create(ctx, cm, metav1.CreateOptions{})
+	} else {
+		_, err = configMaps.Update(ctx, cm, metav1.UpdateOptions{})
+	}
+	if err != nil {
+		return connecterror.FromK8sError("store", "ConfigMap", name.String(), err)
+	}
+	log.V(4).Infof("Stored the values %q of the release %q at revision %q", valuesPath, key, revision)
+	return nil
+}
+
+// gitValuesPath returns the path of the values file recorded on the managed
+// ConfigMap of the HelmRelease named key.
+func (s *Server) gitValuesPath(ctx context.Context, headers http.Header, key types.NamespacedName) (string, error) {
+	typedClient, err := s.clientGetter.Typed(headers, s.kubeappsCluster)
+	if err != nil {
+		return "", err
+	}
+	name := gitValuesName(key)
+	cm, err := typedClient.CoreV1().ConfigMaps(name.Namespace).Get(ctx, name.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", connecterror.FromK8sError("get", "ConfigMap", name.String(), err)
+	}
+	valuesPath := cm.Annotations[gitValuesPathAnnotation]
+	if valuesPath == "" {
+		return "", connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("The ConfigMap %q does not record the path of the values of the release %q", name, key))
+	}
+	return valuesPath, nil
+}
+
+// getGitValuesReference returns the reference to the values file in Git of
+// the HelmRelease named key.
+func (s *Server) getGitValuesReference(ctx context.Context, headers http.Header, key types.NamespacedName) (*v1alpha1.GitValuesReference, error) {
+	client, err := s.getClient(headers, key.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	name := gitValuesName(key)
+	repo := &sourcev1.GitRepository{}
+	if err = client.Get(ctx, name, repo); err != nil {
+		return nil, connecterror.FromK8sError("get", "GitRepository", name.String(), err)
+	}
+	valuesPath, err := s.gitValuesPath(ctx, headers, key)
+	if err != nil {
+		return nil, err
+	}
+	return gitValuesReference(repo, valuesPath), nil
+}
+
+// deleteGitValues deletes the GitRepository and the ConfigMap managed for the
+// values from Git of the HelmRelease named key, if any.
+func (s *Server) deleteGitValues(ctx context.Context, headers http.Header, key types.NamespacedName) error {
+	name := gitValuesName(key)
+	client, err := s.getClient(headers, key.Namespace)
+	if err != nil {
+		return err
+	}
+	repo := &sourcev1.GitRepository{}
+	if err = client.Get(ctx, name, repo); err == nil && repo.Labels[gitValuesReleaseLabel] == key.Name {
+		if err = client.Delete(ctx, repo); err != nil && !errors.IsNotFound(err) {
+			return connecterror.FromK8sError("delete", "GitRepository", name.String(), err)
+		}
+	} else if err != nil && !errors.IsNotFound(err) {
+		return connecterror.FromK8sError("get", "GitRepository", name.String(), err)
+	}
+
+	typedClient, err := s.clientGetter.Typed(headers, s.kubeappsCluster)
+	if err != nil {
+		return err
+	}
+	configMaps := typedClient.CoreV1().ConfigMaps(name.Namespace)
+	if cm, err := configMaps.Get(ctx, name.Name, metav1.GetOptions{}); err == nil && cm.Labels[gitValuesReleaseLabel] == key.Name {
+		if err = configMaps.Delete(ctx, name.Name, metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+			return connecterror.FromK8sError("delete", "ConfigMap", name.String(), err)
+		}
+	} else if err != nil && !errors.IsNotFound(err) {
+		return connecterror.FromK8sError("get", "ConfigMap", name.String(), err)
+	}
+	return nil
+}
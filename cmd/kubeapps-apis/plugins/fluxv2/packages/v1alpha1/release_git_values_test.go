@@ -0,0 +1,327 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bufbuild/connect-go"
+	helmv2 "github.com/fluxcd/helm-controller/api/v2beta1"
+	fluxmeta "github.com/fluxcd/pkg/apis/meta"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1beta2"
+	"github.com/google/go-cmp/cmp"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/plugins/fluxv2/packages/v1alpha1"
+	"google.golang.org/protobuf/testing/protocmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestValidateGitValuesReference(t *testing.T) {
+	testCases := []struct {
+		name              string
+		ref               *v1alpha1.GitValuesReference
+		expectedErrorCode connect.Code
+	}{
+		{
+			name: "accepts a values file on a branch",
+			ref:  &v1alpha1.GitValuesReference{Url: "https://github.com/org/config", Path: "apps/podinfo/values.yaml", Branch: "main"},
+		},
+		{
+			name:              "returns invalid argument without a URL",
+			ref:               &v1alpha1.GitValuesReference{Path: "values.yaml"},
+			expectedErrorCode: connect.CodeInvalidArgument,
+		},
+		{
+			name:              "returns invalid argument for an absolute path",
+			ref:               &v1alpha1.GitValuesReference{Url: "https://github.com/org/config", Path: "/values.yaml"},
+			expectedErrorCode: connect.CodeInvalidArgument,
+		},
+		{
+			name:              "returns invalid argument for a path outside the repository",
+			ref:               &v1alpha1.GitValuesReference{Url: "https://github.com/org/config", Path: "apps/../../values.yaml"},
+			expectedErrorCode: connect.CodeInvalidArgument,
+		},
+		{
+			name:              "returns invalid argument for both a branch and a tag",
+			ref:               &v1alpha1.GitValuesReference{Url: "https://github.com/org/config", Path: "values.yaml", Branch: "main", Tag: "v1.0.0"},
+			expectedErrorCode: connect.CodeInvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateGitValuesReference(tc.ref)
+
+			if got, want := connect.CodeOf(err), tc.expectedErrorCode; tc.expectedErrorCode != 0 && got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			} else if tc.expectedErrorCode == 0 && err != nil {
+				t.Fatalf("%+v", err)
+			}
+		})
+	}
+}
+
+func TestGitValuesRepository(t *testing.T) {
+	key := types.NamespacedName{Namespace: "test", Name: "my-podinfo"}
+	ref := &v1alpha1.GitValuesReference{
+		Url:       "https://github.com/org/config",
+		Path:      "apps/podinfo/values.yaml",
+		Tag:       "v1.0.0",
+		SecretRef: "git-credentials",
+	}
+	interval := metav1.Duration{Duration: defaultReconcileInterval.Duration}
+
+	repo := newGitValuesRepository(key, ref, interval)
+
+	expectedRepo := &sourcev1.GitRepository{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-podinfo-values",
+			Namespace: "test",
+			Labels:    map[string]string{gitValuesReleaseLabel: "my-podinfo"},
+		},
+		Spec: sourcev1.GitRepositorySpec{
+			URL:       "https://github.com/org/config",
+			Reference: &sourcev1.GitRepositoryRef{Tag: "v1.0.0"},
+			SecretRef: &fluxmeta.LocalObjectReference{Name: "git-credentials"},
+			Interval:  interval,
+		},
+	}
+	if got, want := repo, expectedRepo; !cmp.Equal(want, got) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+	}
+
+	// the reference is reported back in the custom detail
+	if got, want := gitValuesReference(repo, ref.Path), ref; !cmp.Equal(want, got, protocmp.Transform()) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, protocmp.Transform()))
+	}
+}
+
+func TestSetGitValuesFrom(t *testing.T) {
+	rel := &helmv2.HelmRelease{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "my-podinfo"},
+		Spec: helmv2.HelmReleaseSpec{
+			ValuesFrom: []helmv2.ValuesReference{{Kind: "Secret", Name: "podinfo-secrets"}},
+		},
+	}
+
+	setGitValuesFrom(rel, true)
+	if !hasGitValues(rel) {
+		t.Fatalf("got: false, want: true")
+	}
+	expectedValuesFrom := []helmv2.ValuesReference{
+		{Kind: "ConfigMap", Name: "my-podinfo-values", ValuesKey: gitValuesKey},
+		{Kind: "Secret", Name: "podinfo-secrets"},
+	}
+	if got, want := rel.Spec.ValuesFrom, expectedValuesFrom; !cmp.Equal(want, got) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+	}
+
+	// setting it again does not duplicate the entry
+	setGitValuesFrom(rel, true)
+	if got, want := len(rel.Spec.ValuesFrom), 2; got != want {
+		t.Errorf("got: %d, want: %d", got, want)
+	}
+
+	setGitValuesFrom(rel, false)
+	if hasGitValues(rel) {
+		t.Fatalf("got: true, want: false")
+	}
+	if got, want := rel.Spec.ValuesFrom, expectedValuesFrom[1:]; !cmp.Equal(want, got) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+	}
+}
+
+func TestGitValuesArtifact(t *testing.T) {
+	artifact := &sourcev1.Artifact{URL: "http://source-controller/gitrepository/test/my-podinfo-values/latest.tar.gz", Revision: "main/1234"}
+	testCases := []struct {
+		name              string
+		conditions        []metav1.Condition
+		artifact          *sourcev1.Artifact
+		expectedDone      bool
+		expectedErrorCode connect.Code
+	}{
+		{
+			name: "waits for the current generation to be fetched",
+			conditions: []metav1.Condition{
+				{Type: fluxmeta.ReadyCondition, Status: metav1.ConditionTrue, ObservedGeneration: 1},
+			},
+			artifact: artifact,
+		},
+		{
+			name: "waits while reconciling",
+			conditions: []metav1.Condition{
+				{Type: fluxmeta.ReadyCondition, Status: metav1.ConditionFalse, ObservedGeneration: 2},
+				{Type: fluxmeta.ReconcilingCondition, Status: metav1.ConditionTrue, ObservedGeneration: 2},
+			},
+		},
+		{
+			name: "returns the artifact once ready",
+			conditions: []metav1.Condition{
+				{Type: fluxmeta.ReadyCondition, Status: metav1.ConditionTrue, ObservedGeneration: 2},
+			},
+			artifact:     artifact,
+			expectedDone: true,
+		},
+		{
+			name: "returns failed precondition when the fetch failed",
+			conditions: []metav1.Condition{
+				{Type: fluxmeta.ReadyCondition, Status: metav1.ConditionFalse, ObservedGeneration: 2, Reason: "GitOperationFailed", Message: "authentication required"},
+			},
+			expectedErrorCode: connect.CodeFailedPrecondition,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &sourcev1.GitRepository{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-podinfo-values", Generation: 2},
+				Status:     sourcev1.GitRepositoryStatus{Conditions: tc.conditions, Artifact: tc.artifact},
+			}
+
+			got, done, err := gitValuesArtifact(repo)
+
+			if got, want := connect.CodeOf(err), tc.expectedErrorCode; tc.expectedErrorCode != 0 && got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			} else if tc.expectedErrorCode == 0 && err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if done != tc.expectedDone {
+				t.Fatalf("got: %t, want: %t", done, tc.expectedDone)
+			}
+			if done && got != artifact {
+				t.Errorf("got: %+v, want: %+v", got, artifact)
+			}
+		})
+	}
+}
+
+func TestValuesFileFromTarball(t *testing.T) {
+	tarball := newTestTarball(t, map[string]string{
+		"./README.md":                "# config",
+		"./apps/podinfo/values.yaml": "replicaCount: 2\n",
+		"./apps/broken/values.yaml":  "replicaCount: [2\n",
+		"./apps/huge/values.yaml":    "replicaCount: 2\n" + strings.Repeat("#", gitValuesMaxBytes),
+	})
+
+	testCases := []struct {
+		name              string
+		path              string
+		expectedValues    string
+		expectedErrorCode connect.Code
+	}{
+		{
+			name:           "returns the values file",
+			path:           "apps/podinfo/values.yaml",
+			expectedValues: "replicaCount: 2\n",
+		},
+		{
+			name:           "returns the values file of an unclean path",
+			path:           "./apps//podinfo/values.yaml",
+			expectedValues: "replicaCount: 2\n",
+		},
+		{
+			name:              "returns not found for a missing file",
+			path:              "apps/redis/values.yaml",
+			expectedErrorCode: connect.CodeNotFound,
+		},
+		{
+			name:              "returns invalid argument for invalid YAML",
+			path:              "apps/broken/values.yaml",
+			expectedErrorCode: connect.CodeInvalidArgument,
+		},
+		{
+			name:              "returns invalid argument for a file bigger than the maximum size",
+			path:              "apps/huge/values.yaml",
+			expectedErrorCode: connect.CodeInvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			values, err := valuesFileFromTarball(tarball, tc.path)
+
+			if got, want := connect.CodeOf(err), tc.expectedErrorCode; tc.expectedErrorCode != 0 && got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			} else if tc.expectedErrorCode == 0 && err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if got, want := values, tc.expectedValues; got != want {
+				t.Errorf("got: %q, want: %q", got, want)
+			}
+		})
+	}
+}
+
+func TestDownloadGitValuesArtifact(t *testing.T) {
+	tarball := newTestTarball(t, map[string]string{"./values.yaml": "replicaCount: 2\n"})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := w.Write(tarball); err != nil {
+			t.Errorf("%+v", err)
+		}
+	}))
+	defer ts.Close()
+
+	testCases := []struct {
+		name              string
+		maxBytes          int64
+		expectedErrorCode connect.Code
+	}{
+		{
+			name:     "returns the artifact",
+			maxBytes: int64(len(tarball)),
+		},
+		{
+			name:              "returns failed precondition for an artifact bigger than the maximum size",
+			maxBytes:          int64(len(tarball)) - 1,
+			expectedErrorCode: connect.CodeFailedPrecondition,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			oldMaxBytes := gitValuesArtifactMaxBytes
+			gitValuesArtifactMaxBytes = tc.maxBytes
+			defer func() { gitValuesArtifactMaxBytes = oldMaxBytes }()
+
+			got, err := downloadGitValuesArtifact(ts.URL)
+
+			if got, want := connect.CodeOf(err), tc.expectedErrorCode; tc.expectedErrorCode != 0 && got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			} else if tc.expectedErrorCode == 0 && err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if tc.expectedErrorCode == 0 && !bytes.Equal(got, tarball) {
+				t.Errorf("got: %d bytes, want: the %d bytes of the tarball", len(got), len(tarball))
+			}
+		})
+	}
+}
+
+func newTestTarball(t *testing.T, files map[string]string) []byte {
+	var buf bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buf)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for name, content := range files {
+		header := &tar.Header{Name: name, Mode: 0600, Size: int64(len(content)), Typeflag: tar.TypeReg}
+		if err := tarWriter.WriteHeader(header); err != nil {
+			t.Fatalf("%+v", err)
+		}
+		if _, err := tarWriter.Write([]byte(content)); err != nil {
+			t.Fatalf("%+v", err)
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,183 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bufbuild/connect-go"
+	helmv2 "github.com/fluxcd/helm-controller/api/v2beta1"
+	"github.com/fluxcd/pkg/apis/kustomize"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/plugins/fluxv2/packages/v1alpha1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/yaml"
+)
+
+// jsonPatchOps are the operations of the JSON6902 patches.
+// ref https://datatracker.ietf.org/doc/html/rfc6902#section-4
+var jsonPatchOps = map[string]bool{
+	"add":     true,
+	"remove":  true,
+	"replace": true,
+	"move":    true,
+	"copy":    true,
+	"test":    true,
+}
+
+// postRenderPatches returns the Kustomize patches of the post render patches
+// of the custom detail, checking their syntax.
+func postRenderPatches(customDetail *v1alpha1.FluxInstalledPackageCustomDetail) ([]kustomize.Patch, error) {
+	var patches []kustomize.Patch
+	for i, p := range customDetail.GetPostRenderPatches() {
+		if err := validatePostRenderPatch(p); err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("The post render patch %d is invalid: %w", i, err))
+		}
+		patch := kustomize.Patch{Patch: p.GetPatch()}
+		if target := p.GetTarget(); target != nil {
+			patch.Target = &kustomize.Selector{
+				Group:              target.GetGroup(),
+				Version:            target.GetVersion(),
+				Kind:               target.GetKind(),
+				Namespace:          target.GetNamespace(),
+				Name:               target.GetName(),
+				LabelSelector:      target.GetLabelSelector(),
+				AnnotationSelector: target.GetAnnotationSelector(),
+			}
+		}
+		patches = append(patches, patch)
+	}
+	return patches, nil
+}
+
+// validatePostRenderPatch checks that the patch is either a strategic merge
+// patch, identifying the resource it applies to unless it has a target, or
+// a JSON6902 patch with a target.
+func validatePostRenderPatch(p *v1alpha1.PostRenderPatch) error {
+	if strings.TrimSpace(p.GetPatch()) == "" {
+		return fmt.Errorf("the patch is required")
+	}
+	if target := p.GetTarget(); target != nil {
+		for _, selector := range []string{target.GetLabelSelector(), target.GetAnnotationSelector()} {
+			if _, err := labels.Parse(selector); err != nil {
+				return fmt.Errorf("invalid selector %q: %w", selector, err)
+			}
+		}
+	}
+
+	var patch interface{}
+	if err := yaml.Unmarshal([]byte(p.GetPatch()), &patch); err != nil {
+		return fmt.Errorf("the patch is neither valid YAML nor JSON: %w", err)
+	}
+	switch patch := patch.(type) {
+	case []interface{}:
+		if p.GetTarget() == nil {
+			return fmt.Errorf("a JSON6902 patch requires a target")
+		}
+		for i, op := range patch {
+			if err := validateJSONPatchOp(op); err != nil {
+				return fmt.Errorf("invalid operation %d: %w", i, err)
+			}
+		}
+	case map[string]interface{}:
+		if p.GetTarget() != nil {
+			return nil
+		}
+		metadata, _ := patch["metadata"].(map[string]interface{})
+		if kind, _ := patch["kind"].(string); kind == "" || metadata["name"] == nil {
+			return fmt.Errorf("a strategic merge patch without a target requires a kind and a metadata.name")
+		}
+	default:
+		return fmt.Errorf("the patch must be an object (strategic merge) or an array of operations (JSON6902)")
+	}
+	return nil
+}
+
+func validateJSONPatchOp(op interface{}) error {
+	fields, ok := op.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("the operation must be an object")
+	}
+	name, _ := fields["op"].(string)
+	if !jsonPatchOps[name] {
+		return fmt.Errorf("unsupported op %q", name)
+	}
+	pointers := []string{"path"}
+	switch name {
+	case "add", "replace", "test":
+		if _, ok := fields["value"]; !ok {
+			return fmt.Errorf("the %q op requires a value", name)
+		}
+	case "move", "copy":
+		pointers = append(pointers, "from")
+	}
+	for _, field := range pointers {
+		pointer, ok := fields[field].(string)
+		if !ok {
+			return fmt.Errorf("the %q op requires a %s", name, field)
+		}
+		if pointer != "" && !strings.HasPrefix(pointer, "/") {
+			return fmt.Errorf("the %s %q must be a JSON pointer", field, pointer)
+		}
+	}
+	return nil
+}
+
+// setPostRenderPatches replaces the patches of the Kustomize post renderers
+// of the HelmRelease, setting them in the first Kustomize post renderer, or a
+// new one appended if none. The Kustomize post renderers left empty are
+// removed.
+func setPostRenderPatches(rel *helmv2.HelmRelease, patches []kustomize.Patch) {
+	var postRenderers []helmv2.PostRenderer
+	for _, postRenderer := range rel.Spec.PostRenderers {
+		if k := postRenderer.Kustomize; k != nil {
+			k.Patches = nil
+			if len(k.PatchesStrategicMerge) == 0 && len(k.PatchesJSON6902) == 0 && len(k.Images) == 0 {
+				continue
+			}
+		}
+		postRenderers = append(postRenderers, postRenderer)
+	}
+	if len(patches) > 0 {
+		set := false
+		for _, postRenderer := range postRenderers {
+			if postRenderer.Kustomize != nil {
+				postRenderer.Kustomize.Patches = patches
+				set = true
+				break
+			}
+		}
+		if !set {
+			postRenderers = append(postRenderers, helmv2.PostRenderer{Kustomize: &helmv2.Kustomize{Patches: patches}})
+		}
+	}
+	rel.Spec.PostRenderers = postRenderers
+}
+
+// installedPackagePostRenderPatches returns the patches of the Kustomize post
+// renderers of the HelmRelease, in order.
+func installedPackagePostRenderPatches(rel *helmv2.HelmRelease) []*v1alpha1.PostRenderPatch {
+	var patches []*v1alpha1.PostRenderPatch
+	for _, postRenderer := range rel.Spec.PostRenderers {
+		if postRenderer.Kustomize == nil {
+			continue
+		}
+		for _, patch := range postRenderer.Kustomize.Patches {
+			p := &v1alpha1.PostRenderPatch{Patch: patch.Patch}
+			if target := patch.Target; target != nil {
+				p.Target = &v1alpha1.PatchTarget{
+					Group:              target.Group,
+					Version:            target.Version,
+					Kind:               target.Kind,
+					Namespace:          target.Namespace,
+					Name:               target.Name,
+					LabelSelector:      target.LabelSelector,
+					AnnotationSelector: target.AnnotationSelector,
+				}
+			}
+			patches = append(patches, p)
+		}
+	}
+	return patches
+}
@@ -0,0 +1,204 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"testing"
+
+	"github.com/bufbuild/connect-go"
+	helmv2 "github.com/fluxcd/helm-controller/api/v2beta1"
+	"github.com/fluxcd/pkg/apis/kustomize"
+	"github.com/google/go-cmp/cmp"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/plugins/fluxv2/packages/v1alpha1"
+	"google.golang.org/protobuf/testing/protocmp"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+func TestPostRenderPatches(t *testing.T) {
+	deploymentTarget := &v1alpha1.PatchTarget{Kind: "Deployment", LabelSelector: "app.kubernetes.io/name=podinfo"}
+
+	testCases := []struct {
+		name              string
+		patches           []*v1alpha1.PostRenderPatch
+		expectedPatches   []kustomize.Patch
+		expectedErrorCode connect.Code
+	}{
+		{
+			name: "accepts a strategic merge patch identifying its resource",
+			patches: []*v1alpha1.PostRenderPatch{
+				{Patch: "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: podinfo\nspec:\n  replicas: 3\n"},
+			},
+			expectedPatches: []kustomize.Patch{
+				{Patch: "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: podinfo\nspec:\n  replicas: 3\n"},
+			},
+		},
+		{
+			name: "accepts a strategic merge patch with a target",
+			patches: []*v1alpha1.PostRenderPatch{
+				{Patch: `{"spec": {"replicas": 3}}`, Target: deploymentTarget},
+			},
+			expectedPatches: []kustomize.Patch{
+				{Patch: `{"spec": {"replicas": 3}}`, Target: &kustomize.Selector{Kind: "Deployment", LabelSelector: "app.kubernetes.io/name=podinfo"}},
+			},
+		},
+		{
+			name: "accepts a JSON6902 patch with a target",
+			patches: []*v1alpha1.PostRenderPatch{
+				{Patch: "- op: add\n  path: /metadata/annotations/team\n  value: payments\n- op: remove\n  path: /spec/template/spec/securityContext\n", Target: deploymentTarget},
+			},
+			expectedPatches: []kustomize.Patch{
+				{
+					Patch:  "- op: add\n  path: /metadata/annotations/team\n  value: payments\n- op: remove\n  path: /spec/template/spec/securityContext\n",
+					Target: &kustomize.Selector{Kind: "Deployment", LabelSelector: "app.kubernetes.io/name=podinfo"},
+				},
+			},
+		},
+		{
+			name:              "returns invalid argument for an empty patch",
+			patches:           []*v1alpha1.PostRenderPatch{{Patch: " ", Target: deploymentTarget}},
+			expectedErrorCode: connect.CodeInvalidArgument,
+		},
+		{
+			name:              "returns invalid argument for invalid YAML",
+			patches:           []*v1alpha1.PostRenderPatch{{Patch: "spec: [replicas", Target: deploymentTarget}},
+			expectedErrorCode: connect.CodeInvalidArgument,
+		},
+		{
+			name:              "returns invalid argument for a scalar patch",
+			patches:           []*v1alpha1.PostRenderPatch{{Patch: "replicas", Target: deploymentTarget}},
+			expectedErrorCode: connect.CodeInvalidArgument,
+		},
+		{
+			name:              "returns invalid argument for a strategic merge patch without a target nor a name",
+			patches:           []*v1alpha1.PostRenderPatch{{Patch: "kind: Deployment\nspec:\n  replicas: 3\n"}},
+			expectedErrorCode: connect.CodeInvalidArgument,
+		},
+		{
+			name:              "returns invalid argument for a JSON6902 patch without a target",
+			patches:           []*v1alpha1.PostRenderPatch{{Patch: `[{"op": "remove", "path": "/spec/replicas"}]`}},
+			expectedErrorCode: connect.CodeInvalidArgument,
+		},
+		{
+			name:              "returns invalid argument for an unsupported op",
+			patches:           []*v1alpha1.PostRenderPatch{{Patch: `[{"op": "delete", "path": "/spec/replicas"}]`, Target: deploymentTarget}},
+			expectedErrorCode: connect.CodeInvalidArgument,
+		},
+		{
+			name:              "returns invalid argument for an op without a value",
+			patches:           []*v1alpha1.PostRenderPatch{{Patch: `[{"op": "replace", "path": "/spec/replicas"}]`, Target: deploymentTarget}},
+			expectedErrorCode: connect.CodeInvalidArgument,
+		},
+		{
+			name:              "returns invalid argument for a path which is not a JSON pointer",
+			patches:           []*v1alpha1.PostRenderPatch{{Patch: `[{"op": "remove", "path": "spec.replicas"}]`, Target: deploymentTarget}},
+			expectedErrorCode: connect.CodeInvalidArgument,
+		},
+		{
+			name:              "returns invalid argument for a move without a from",
+			patches:           []*v1alpha1.PostRenderPatch{{Patch: `[{"op": "move", "path": "/spec/replicas"}]`, Target: deploymentTarget}},
+			expectedErrorCode: connect.CodeInvalidArgument,
+		},
+		{
+			name: "returns invalid argument for an invalid selector",
+			patches: []*v1alpha1.PostRenderPatch{
+				{Patch: `{"spec": {"replicas": 3}}`, Target: &v1alpha1.PatchTarget{LabelSelector: "app in (podinfo"}},
+			},
+			expectedErrorCode: connect.CodeInvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			patches, err := postRenderPatches(&v1alpha1.FluxInstalledPackageCustomDetail{PostRenderPatches: tc.patches})
+
+			if got, want := connect.CodeOf(err), tc.expectedErrorCode; tc.expectedErrorCode != 0 && got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			} else if tc.expectedErrorCode == 0 && err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if got, want := patches, tc.expectedPatches; !cmp.Equal(want, got) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
+func TestSetPostRenderPatches(t *testing.T) {
+	images := []kustomize.Image{{Name: "podinfo", NewTag: "6.3.5"}}
+	strategicMerge := []apiextensionsv1.JSON{{Raw: []byte(`{"kind": "Service", "metadata": {"name": "podinfo"}}`)}}
+	oldPatch := kustomize.Patch{Patch: "old"}
+	newPatch := kustomize.Patch{Patch: "new", Target: &kustomize.Selector{Kind: "Deployment"}}
+
+	testCases := []struct {
+		name                  string
+		postRenderers         []helmv2.PostRenderer
+		patches               []kustomize.Patch
+		expectedPostRenderers []helmv2.PostRenderer
+	}{
+		{
+			name:    "adds a Kustomize post renderer with the patches",
+			patches: []kustomize.Patch{newPatch},
+			expectedPostRenderers: []helmv2.PostRenderer{
+				{Kustomize: &helmv2.Kustomize{Patches: []kustomize.Patch{newPatch}}},
+			},
+		},
+		{
+			name: "replaces the patches of the Kustomize post renderer, keeping its other fields",
+			postRenderers: []helmv2.PostRenderer{
+				{Kustomize: &helmv2.Kustomize{Patches: []kustomize.Patch{oldPatch}, Images: images}},
+			},
+			patches: []kustomize.Patch{newPatch},
+			expectedPostRenderers: []helmv2.PostRenderer{
+				{Kustomize: &helmv2.Kustomize{Patches: []kustomize.Patch{newPatch}, Images: images}},
+			},
+		},
+		{
+			name: "removes the patches of every Kustomize post renderer, and the post renderers left empty",
+			postRenderers: []helmv2.PostRenderer{
+				{Kustomize: &helmv2.Kustomize{Patches: []kustomize.Patch{oldPatch}}},
+				{Kustomize: &helmv2.Kustomize{Patches: []kustomize.Patch{oldPatch}, PatchesStrategicMerge: strategicMerge}},
+			},
+			patches: []kustomize.Patch{newPatch},
+			expectedPostRenderers: []helmv2.PostRenderer{
+				{Kustomize: &helmv2.Kustomize{Patches: []kustomize.Patch{newPatch}, PatchesStrategicMerge: strategicMerge}},
+			},
+		},
+		{
+			name: "removes the post renderers without patches",
+			postRenderers: []helmv2.PostRenderer{
+				{Kustomize: &helmv2.Kustomize{Patches: []kustomize.Patch{oldPatch}}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rel := &helmv2.HelmRelease{Spec: helmv2.HelmReleaseSpec{PostRenderers: tc.postRenderers}}
+
+			setPostRenderPatches(rel, tc.patches)
+
+			if got, want := rel.Spec.PostRenderers, tc.expectedPostRenderers; !cmp.Equal(want, got) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
+func TestInstalledPackagePostRenderPatches(t *testing.T) {
+	rel := &helmv2.HelmRelease{Spec: helmv2.HelmReleaseSpec{PostRenderers: []helmv2.PostRenderer{
+		{},
+		{Kustomize: &helmv2.Kustomize{Patches: []kustomize.Patch{
+			{Patch: "first"},
+			{Patch: "second", Target: &kustomize.Selector{Kind: "Deployment", AnnotationSelector: "team=payments"}},
+		}}},
+	}}}
+
+	expectedPatches := []*v1alpha1.PostRenderPatch{
+		{Patch: "first"},
+		{Patch: "second", Target: &v1alpha1.PatchTarget{Kind: "Deployment", AnnotationSelector: "team=payments"}},
+	}
+	if got, want := installedPackagePostRenderPatches(rel), expectedPatches; !cmp.Equal(want, got, protocmp.Transform()) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, protocmp.Transform()))
+	}
+}
@@ -5,6 +5,7 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -19,15 +20,22 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	corev1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
 	plugins "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/plugins/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/plugins/fluxv2/packages/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/fluxv2/packages/v1alpha1/common"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/clientgetter"
 	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/pkgutils"
 	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/resourcerefs/resourcerefstest"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/resources"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"helm.sh/helm/v3/pkg/release"
+	k8scorev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	typfake "k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 type testSpecGetInstalledPackages struct {
@@ -380,6 +388,63 @@ func TestGetInstalledPackageSummariesWithPagination(t *testing.T) {
 	})
 }
 
+// forbiddingListClient is a controller-runtime client for which listing the
+// resources in the given namespaces, "" for the cluster scope, is forbidden.
+type forbiddingListClient struct {
+	client.WithWatch
+	forbiddenNamespaces []string
+}
+
+func (c *forbiddingListClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	listOptions := &client.ListOptions{}
+	listOptions.ApplyOptions(opts)
+	for _, namespace := range c.forbiddenNamespaces {
+		if listOptions.Namespace == namespace {
+			return errors.NewForbidden(helmv2.GroupVersion.WithResource("helmreleases").GroupResource(), "", fmt.Errorf("bang"))
+		}
+	}
+	return c.WithWatch.List(ctx, list, opts...)
+}
+
+func TestGetInstalledPackageSummariesNamespaces(t *testing.T) {
+	ctrlClient := newCtrlClient(nil, nil, nil)
+	typedClient := typfake.NewSimpleClientset(
+		&k8scorev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns-accessible"}, Status: k8scorev1.NamespaceStatus{Phase: k8scorev1.NamespaceActive}},
+		&k8scorev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns-inaccessible"}, Status: k8scorev1.NamespaceStatus{Phase: k8scorev1.NamespaceActive}},
+	)
+	s := &Server{
+		clientGetter: clientgetter.NewBuilder().
+			WithTyped(typedClient).
+			// Forbidden cluster-wide listing and a specific namespace
+			WithControllerRuntime(&forbiddingListClient{WithWatch: &ctrlClient, forbiddenNamespaces: []string{"", "ns-inaccessible"}}).
+			Build(),
+		kubeappsCluster: KubeappsCluster,
+		pluginConfig:    common.NewDefaultPluginConfig(),
+	}
+
+	response, err := s.GetInstalledPackageSummaries(context.Background(), connect.NewRequest(&corev1.GetInstalledPackageSummariesRequest{
+		Context: &corev1.Context{Cluster: KubeappsCluster},
+	}))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	expectedPartialResults := &corev1.PartialResults{
+		NamespaceFailures: []*corev1.NamespaceFailure{
+			{
+				Context: &corev1.Context{Cluster: KubeappsCluster, Namespace: "ns-inaccessible"},
+				Plugin:  GetPluginDetail(),
+				Code:    connect.CodePermissionDenied.String(),
+				Reason:  "Forbidden to list the HelmRelease 'ns-inaccessible/*' due to 'helmreleases.helm.toolkit.fluxcd.io is forbidden: bang'",
+			},
+		},
+	}
+	opts := cmpopts.IgnoreUnexported(corev1.PartialResults{}, corev1.NamespaceFailure{}, corev1.Context{}, plugins.Plugin{})
+	if got, want := response.Msg.GetPartialResults(), expectedPartialResults; !cmp.Equal(want, got, opts) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, opts))
+	}
+}
+
 type helmReleaseStub struct {
 	name         string
 	namespace    string
@@ -498,9 +563,10 @@ func TestGetInstalledPackageDetail(t *testing.T) {
 }
 
 type testSpecCreateInstalledPackage struct {
-	repoName      string
-	repoNamespace string
-	repoIndex     string
+	repoName        string
+	repoNamespace   string
+	repoIndex       string
+	repoAnnotations map[string]string
 }
 
 func TestCreateInstalledPackage(t *testing.T) {
@@ -508,7 +574,7 @@ func TestCreateInstalledPackage(t *testing.T) {
 		name                    string
 		request                 *corev1.CreateInstalledPackageRequest
 		existingObjs            testSpecCreateInstalledPackage
-		expectedStatusCode      codes.Code
+		expectedErrorCode       connect.Code
 		expectedResponse        *corev1.CreateInstalledPackageResponse
 		expectedRelease         *helmv2.HelmRelease
 		defaultUpgradePolicyStr string
@@ -521,9 +587,8 @@ func TestCreateInstalledPackage(t *testing.T) {
 				repoNamespace: "namespace-1",
 				repoIndex:     testYaml("podinfo-index.yaml"),
 			},
-			expectedStatusCode: codes.OK,
-			expectedResponse:   create_installed_package_resp_my_podinfo,
-			expectedRelease:    flux_helm_release_basic,
+			expectedResponse: create_installed_package_resp_my_podinfo,
+			expectedRelease:  flux_helm_release_basic,
 		},
 		{
 			name:    "create package (semver constraint)",
@@ -533,9 +598,8 @@ func TestCreateInstalledPackage(t *testing.T) {
 				repoNamespace: "namespace-1",
 				repoIndex:     testYaml("podinfo-index.yaml"),
 			},
-			expectedStatusCode: codes.OK,
-			expectedResponse:   create_installed_package_resp_my_podinfo,
-			expectedRelease:    flux_helm_release_semver_constraint,
+			expectedResponse: create_installed_package_resp_my_podinfo,
+			expectedRelease:  flux_helm_release_semver_constraint,
 		},
 		{
 			name:    "create package (reconcile options)",
@@ -545,9 +609,8 @@ func TestCreateInstalledPackage(t *testing.T) {
 				repoNamespace: "namespace-1",
 				repoIndex:     testYaml("podinfo-index.yaml"),
 			},
-			expectedStatusCode: codes.OK,
-			expectedResponse:   create_installed_package_resp_my_podinfo,
-			expectedRelease:    flux_helm_release_reconcile_options,
+			expectedResponse: create_installed_package_resp_my_podinfo,
+			expectedRelease:  flux_helm_release_reconcile_options,
 		},
 		{
 			name:    "create package (values JSON override)",
@@ -557,9 +620,8 @@ func TestCreateInstalledPackage(t *testing.T) {
 				repoNamespace: "namespace-1",
 				repoIndex:     testYaml("podinfo-index.yaml"),
 			},
-			expectedStatusCode: codes.OK,
-			expectedResponse:   create_installed_package_resp_my_podinfo,
-			expectedRelease:    flux_helm_release_values,
+			expectedResponse: create_installed_package_resp_my_podinfo,
+			expectedRelease:  flux_helm_release_values,
 		},
 		{
 			name:    "create package (values YAML override)",
@@ -569,9 +631,8 @@ func TestCreateInstalledPackage(t *testing.T) {
 				repoNamespace: "namespace-1",
 				repoIndex:     testYaml("podinfo-index.yaml"),
 			},
-			expectedStatusCode: codes.OK,
-			expectedResponse:   create_installed_package_resp_my_podinfo,
-			expectedRelease:    flux_helm_release_values,
+			expectedResponse: create_installed_package_resp_my_podinfo,
+			expectedRelease:  flux_helm_release_values,
 		},
 		{
 			name:    "create with specific version (upgrade policy none)",
@@ -581,7 +642,6 @@ func TestCreateInstalledPackage(t *testing.T) {
 				repoNamespace: "namespace-1",
 				repoIndex:     testYaml("podinfo-index.yaml"),
 			},
-			expectedStatusCode:      codes.OK,
 			expectedResponse:        create_installed_package_resp_my_podinfo,
 			expectedRelease:         flux_helm_release_upgrade_policy_none,
 			defaultUpgradePolicyStr: "none",
@@ -594,7 +654,6 @@ func TestCreateInstalledPackage(t *testing.T) {
 				repoNamespace: "namespace-1",
 				repoIndex:     testYaml("podinfo-index.yaml"),
 			},
-			expectedStatusCode:      codes.OK,
 			expectedResponse:        create_installed_package_resp_my_podinfo,
 			expectedRelease:         flux_helm_release_upgrade_policy_major,
 			defaultUpgradePolicyStr: "major",
@@ -607,7 +666,6 @@ func TestCreateInstalledPackage(t *testing.T) {
 				repoNamespace: "namespace-1",
 				repoIndex:     testYaml("podinfo-index.yaml"),
 			},
-			expectedStatusCode:      codes.OK,
 			expectedResponse:        create_installed_package_resp_my_podinfo,
 			expectedRelease:         flux_helm_release_upgrade_policy_minor,
 			defaultUpgradePolicyStr: "minor",
@@ -620,11 +678,21 @@ func TestCreateInstalledPackage(t *testing.T) {
 				repoNamespace: "namespace-1",
 				repoIndex:     testYaml("podinfo-index.yaml"),
 			},
-			expectedStatusCode:      codes.OK,
 			expectedResponse:        create_installed_package_resp_my_podinfo,
 			expectedRelease:         flux_helm_release_upgrade_policy_patch,
 			defaultUpgradePolicyStr: "patch",
 		},
+		{
+			name:    "returns failed precondition if the repo policy does not allow the package",
+			request: create_package_simple_req,
+			existingObjs: testSpecCreateInstalledPackage{
+				repoName:        "podinfo",
+				repoNamespace:   "namespace-1",
+				repoIndex:       testYaml("podinfo-index.yaml"),
+				repoAnnotations: map[string]string{pkgutils.AnnotationAllowedPackagesKey: "nginx"},
+			},
+			expectedErrorCode: connect.CodeFailedPrecondition,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -635,6 +703,7 @@ func TestCreateInstalledPackage(t *testing.T) {
 				t.Fatal(err)
 			}
 			defer ts.Close()
+			repo.Annotations = tc.existingObjs.repoAnnotations
 
 			s, mock, err := newSimpleServerWithRepos(t, []sourcev1.HelmRepository{*repo})
 			if err != nil {
@@ -656,12 +725,12 @@ func TestCreateInstalledPackage(t *testing.T) {
 
 			response, err := s.CreateInstalledPackage(context.Background(), connect.NewRequest(tc.request))
 
-			if got, want := status.Code(err), tc.expectedStatusCode; got != want {
+			if got, want := connect.CodeOf(err), tc.expectedErrorCode; err != nil && got != want {
 				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
 			}
 
 			// We don't need to check anything else for non-OK codes.
-			if tc.expectedStatusCode != codes.OK {
+			if tc.expectedErrorCode != 0 {
 				return
 			}
 
@@ -702,6 +771,84 @@ func TestCreateInstalledPackage(t *testing.T) {
 	}
 }
 
+func TestCreateInstalledPackageCreatesNamespace(t *testing.T) {
+	testCases := []struct {
+		name                    string
+		existingRelease         bool
+		expectedStatusCode      codes.Code
+		expectedNamespaceLabels map[string]string
+	}{
+		{
+			name:                    "it creates the target namespace with the configured labels",
+			expectedStatusCode:      codes.OK,
+			expectedNamespaceLabels: map[string]string{"team": "apps"},
+		},
+		{
+			name:               "it deletes the created namespace when the HelmRelease cannot be created",
+			existingRelease:    true,
+			expectedStatusCode: codes.AlreadyExists,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ts, repo, err := newHttpRepoAndServeIndex(
+				testYaml("podinfo-index.yaml"), "podinfo", "namespace-1", nil, "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer ts.Close()
+
+			s, mock, err := newSimpleServerWithRepos(t, []sourcev1.HelmRepository{*repo})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err = s.redisMockExpectGetFromRepoCache(mock, nil, *repo); err != nil {
+				t.Fatal(err)
+			}
+			s.pluginConfig.NamespaceCreation = resources.NamespaceCreationOptions{
+				Labels: map[string]string{"team": "apps"},
+			}
+
+			ctrlClient, err := s.clientGetter.ControllerRuntime(http.Header{}, s.kubeappsCluster)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tc.existingRelease {
+				existing := flux_helm_release_basic.DeepCopy()
+				existing.ResourceVersion = ""
+				if err = ctrlClient.Create(context.Background(), existing); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			_, err = s.CreateInstalledPackage(context.Background(), connect.NewRequest(create_package_create_namespace_req))
+			if got, want := status.Code(err), tc.expectedStatusCode; got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+
+			typedClient, err := s.clientGetter.Typed(http.Header{}, s.kubeappsCluster)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ns, err := typedClient.CoreV1().Namespaces().Get(context.Background(), "test", metav1.GetOptions{})
+			if tc.expectedStatusCode != codes.OK {
+				if !errors.IsNotFound(err) {
+					t.Fatalf("got: %+v, want: a not found error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if got, want := ns.Labels, tc.expectedNamespaceLabels; !cmp.Equal(want, got) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
 func TestUpdateInstalledPackage(t *testing.T) {
 	testCases := []struct {
 		name                    string
@@ -774,6 +921,21 @@ func TestUpdateInstalledPackage(t *testing.T) {
 			},
 			expectedRelease: flux_helm_release_updated_2,
 		},
+		{
+			name: "update package (values reused)",
+			request: &corev1.UpdateInstalledPackageRequest{
+				InstalledPackageRef: my_redis_ref,
+				PkgVersionReference: &corev1.VersionReference{
+					Version: ">14.4.0",
+				},
+				ReuseValues: true,
+			},
+			existingK8sObjs: &redis_existing_spec_completed_with_values_and_reconciliation_options,
+			expectedResponse: &corev1.UpdateInstalledPackageResponse{
+				InstalledPackageRef: my_redis_ref,
+			},
+			expectedRelease: flux_helm_release_updated_reused_values,
+		},
 		{
 			name: "update package (default upgrade policy major)",
 			request: &corev1.UpdateInstalledPackageRequest{
@@ -907,6 +1069,162 @@ func TestUpdateInstalledPackage(t *testing.T) {
 	}
 }
 
+func TestHelmReleaseDependsOn(t *testing.T) {
+	key := types.NamespacedName{Namespace: "test", Name: "my-podinfo"}
+
+	testCases := []struct {
+		name              string
+		customDetail      *v1alpha1.FluxInstalledPackageCustomDetail
+		expectedDependsOn []fluxmeta.NamespacedObjectReference
+		expectedErrorCode connect.Code
+	}{
+		{
+			name: "returns no dependencies without a custom detail",
+		},
+		{
+			name: "returns the dependencies, keeping the namespace optional",
+			customDetail: &v1alpha1.FluxInstalledPackageCustomDetail{
+				DependsOn: []*v1alpha1.HelmReleaseDependency{
+					{Name: "my-redis"},
+					{Name: "my-postgresql", Namespace: "databases"},
+				},
+			},
+			expectedDependsOn: []fluxmeta.NamespacedObjectReference{
+				{Name: "my-redis"},
+				{Name: "my-postgresql", Namespace: "databases"},
+			},
+		},
+		{
+			name: "returns invalid argument for a dependency without a name",
+			customDetail: &v1alpha1.FluxInstalledPackageCustomDetail{
+				DependsOn: []*v1alpha1.HelmReleaseDependency{{Namespace: "databases"}},
+			},
+			expectedErrorCode: connect.CodeInvalidArgument,
+		},
+		{
+			name: "returns invalid argument for a release depending on itself",
+			customDetail: &v1alpha1.FluxInstalledPackageCustomDetail{
+				DependsOn: []*v1alpha1.HelmReleaseDependency{{Name: "my-podinfo"}},
+			},
+			expectedErrorCode: connect.CodeInvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dependsOn, err := helmReleaseDependsOn(key, tc.customDetail)
+
+			if got, want := connect.CodeOf(err), tc.expectedErrorCode; err != nil && got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+			if tc.expectedErrorCode != 0 {
+				if err == nil {
+					t.Fatalf("got: nil, want: error with code %+v", tc.expectedErrorCode)
+				}
+				return
+			}
+			if got, want := dependsOn, tc.expectedDependsOn; !cmp.Equal(want, got) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
+func TestInstalledPackageStatusDependencyNotReady(t *testing.T) {
+	rel := helmv2.HelmRelease{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-podinfo", Namespace: "test", Generation: 1},
+		Spec: helmv2.HelmReleaseSpec{
+			DependsOn: []fluxmeta.NamespacedObjectReference{{Name: "my-redis"}},
+		},
+		Status: helmv2.HelmReleaseStatus{
+			ObservedGeneration: 1,
+			Conditions: []metav1.Condition{
+				{
+					Type:    fluxmeta.ReadyCondition,
+					Status:  metav1.ConditionFalse,
+					Reason:  helmv2.DependencyNotReadyReason,
+					Message: "dependency 'test/my-redis' is not ready",
+				},
+			},
+		},
+	}
+
+	expected := &corev1.InstalledPackageStatus{
+		Ready:      false,
+		Reason:     corev1.InstalledPackageStatus_STATUS_REASON_PENDING,
+		UserReason: "Waiting for dependencies: dependency 'test/my-redis' is not ready",
+	}
+	opts := cmpopts.IgnoreUnexported(corev1.InstalledPackageStatus{})
+	if got, want := installedPackageStatus(rel), expected; !cmp.Equal(want, got, opts) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, opts))
+	}
+
+	customDetail := &v1alpha1.FluxInstalledPackageCustomDetail{
+		DependsOn: []*v1alpha1.HelmReleaseDependency{{Name: "my-redis"}},
+	}
+	detailOpts := cmpopts.IgnoreUnexported(v1alpha1.FluxInstalledPackageCustomDetail{}, v1alpha1.HelmReleaseDependency{})
+	if got, want := installedPackageCustomDetail(&rel), customDetail; !cmp.Equal(want, got, detailOpts) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, detailOpts))
+	}
+}
+
+func TestCheckInstalledPackageNameAvailability(t *testing.T) {
+	testCases := []struct {
+		name             string
+		request          *corev1.CheckInstalledPackageNameAvailabilityRequest
+		expectedResponse *corev1.CheckInstalledPackageNameAvailabilityResponse
+	}{
+		{
+			name: "reports an unused name as available",
+			request: &corev1.CheckInstalledPackageNameAvailabilityRequest{
+				TargetContext: &corev1.Context{Namespace: "test"},
+				Name:          "my-other-redis",
+			},
+			expectedResponse: &corev1.CheckInstalledPackageNameAvailabilityResponse{
+				Available: true,
+				Conflicts: []*corev1.InstalledPackageReference{},
+			},
+		},
+		{
+			name: "reports the release using the name",
+			request: &corev1.CheckInstalledPackageNameAvailabilityRequest{
+				TargetContext: &corev1.Context{Namespace: "test"},
+				Name:          "my-redis",
+			},
+			expectedResponse: &corev1.CheckInstalledPackageNameAvailabilityResponse{
+				Available: false,
+				Conflicts: []*corev1.InstalledPackageReference{my_redis_ref},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			charts, repos, cleanup := newChartsAndReleases(t, []testSpecGetInstalledPackages{redis_existing_spec_completed})
+			defer cleanup()
+			s, _, err := newServerWithChartsAndReleases(t, nil, charts, repos)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			response, err := s.CheckInstalledPackageNameAvailability(context.Background(), connect.NewRequest(tc.request))
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+
+			opts := cmpopts.IgnoreUnexported(
+				corev1.CheckInstalledPackageNameAvailabilityResponse{},
+				corev1.InstalledPackageReference{},
+				corev1.Context{},
+				plugins.Plugin{},
+			)
+			if got, want := response.Msg, tc.expectedResponse; !cmp.Equal(want, got, opts) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, opts))
+			}
+		})
+	}
+}
+
 func TestDeleteInstalledPackage(t *testing.T) {
 	testCases := []struct {
 		name              string
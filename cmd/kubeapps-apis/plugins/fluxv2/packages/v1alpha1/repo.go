@@ -17,6 +17,7 @@ import (
 	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/connecterror"
 	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/k8sutils"
 
+	helmv2 "github.com/fluxcd/helm-controller/api/v2beta1"
 	fluxmeta "github.com/fluxcd/pkg/apis/meta"
 	sourcev1 "github.com/fluxcd/source-controller/api/v1beta2"
 	corev1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
@@ -30,8 +31,10 @@ import (
 	httpclient "github.com/vmware-tanzu/kubeapps/pkg/http-client"
 	"google.golang.org/protobuf/types/known/anypb"
 	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	log "k8s.io/klog/v2"
@@ -162,6 +165,57 @@ func (s *Server) getChartsForRepos(ctx context.Context, headers http.Header, ns
 		return nil, err
 	}
 
+	charts, err := s.getChartsForRepoKeys(repoNames)
+	if err != nil {
+		return nil, err
+	}
+	return s.filterChartsByRepoPolicies(repoList, charts), nil
+}
+
+// filterChartsByRepoPolicies removes, from the charts keyed by repo cache key,
+// the charts not made available by the package policy of their repository.
+func (s *Server) filterChartsByRepoPolicies(repoList []sourcev1.HelmRepository, charts map[string][]models.Chart) map[string][]models.Chart {
+	for _, repo := range repoList {
+		policy := pkgutils.PackagePolicyFromAnnotations(repo.Annotations)
+		if policy == nil {
+			continue
+		}
+		key := s.repoCache.KeyForNamespacedName(types.NamespacedName{Namespace: repo.Namespace, Name: repo.Name})
+		repoCharts, ok := charts[key]
+		if !ok || repoCharts == nil {
+			continue
+		}
+		allowedCharts := []models.Chart{}
+		for _, chart := range repoCharts {
+			if policy.Allows(chart.Name) {
+				allowedCharts = append(allowedCharts, chart)
+			}
+		}
+		charts[key] = allowedCharts
+	}
+	return charts
+}
+
+// checkRepoAllowsPackage returns a FailedPrecondition error if the chart is not
+// made available by the package policy of its repository. The repository is
+// read with the service account, since the policy applies to every user.
+func (s *Server) checkRepoAllowsPackage(ctx context.Context, repoName types.NamespacedName, chartName string) error {
+	client, err := s.serviceAccountClientGetter.ControllerRuntime(ctx)
+	if err != nil {
+		return err
+	}
+	var repo sourcev1.HelmRepository
+	if err = client.Get(ctx, repoName, &repo); err != nil {
+		return connecterror.FromK8sError("get", "HelmRepository", repoName.String(), err)
+	}
+	if !pkgutils.PackagePolicyFromAnnotations(repo.Annotations).Allows(chartName) {
+		return connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("The package %q is not allowed by the policy of its repository", chartName))
+	}
+	return nil
+}
+
+// getChartsForRepoKeys returns the charts cached for the given repo cache keys
+func (s *Server) getChartsForRepoKeys(repoNames sets.Set[string]) (map[string][]models.Chart, error) {
 	chartsUntyped, err := s.repoCache.GetMultiple(repoNames)
 	if err != nil {
 		return nil, err
@@ -275,7 +329,7 @@ func (s *Server) newRepo(ctx context.Context, request *connect.Request[corev1.Ad
 		}
 	}
 
-	if fluxRepo, err := newFluxHelmRepo(name, description, typ, url, interval, secret, passCredentials, provider); err != nil {
+	if fluxRepo, err := newFluxHelmRepo(name, description, typ, url, interval, secret, passCredentials, customDetail); err != nil {
 		return nil, err
 	} else if client, err := s.getClient(request.Header(), name.Namespace); err != nil {
 		return nil, err
@@ -323,10 +377,23 @@ func (s *Server) repoDetail(ctx context.Context, headers http.Header, repoRef *c
 	// will have a FluxPackageRepositoryCustomDetail in it. Flux spec already clearly states
 	// If you do not specify .spec.provider, it defaults to generic.
 	// https://fluxcd.io/flux/components/source/helmrepositories/#provider
-	if repo.Spec.Provider != "" && repo.Spec.Provider != sourcev1.GenericOCIProvider {
-		if customDetail, err = anypb.New(&v1alpha1.FluxPackageRepositoryCustomDetail{
-			Provider: repo.Spec.Provider,
-		}); err != nil {
+	provider := ""
+	if repo.Spec.Provider != sourcev1.GenericOCIProvider {
+		provider = repo.Spec.Provider
+	}
+	timeout := pkgutils.FromDuration(repo.Spec.Timeout)
+	packagePolicy := pkgutils.PackagePolicyFromAnnotations(repo.Annotations)
+	if provider != "" || timeout != "" || repo.Spec.Suspend || packagePolicy != nil {
+		fluxDetail := &v1alpha1.FluxPackageRepositoryCustomDetail{
+			Provider: provider,
+			Timeout:  timeout,
+			Suspend:  repo.Spec.Suspend,
+		}
+		if packagePolicy != nil {
+			fluxDetail.AllowedPackages = packagePolicy.AllowedPackages
+			fluxDetail.DeniedPackages = packagePolicy.DeniedPackages
+		}
+		if customDetail, err = anypb.New(fluxDetail); err != nil {
 			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("The customDetail could not be marshalled due to: %w", err))
 		}
 	}
@@ -483,6 +550,13 @@ func (s *Server) updateRepo(ctx context.Context, repoRef *corev1.PackageReposito
 		} else {
 			repo.Spec.Provider = ""
 		}
+
+		if err := setHelmRepoTimeoutAndSuspend(repo, customDetail); err != nil {
+			return nil, err
+		}
+		if err := setHelmRepoPackagePolicy(repo, customDetail); err != nil {
+			return nil, err
+		}
 	}
 
 	// get rid of the status field, since now there will be a new reconciliation
@@ -516,16 +590,20 @@ func (s *Server) updateRepo(ctx context.Context, repoRef *corev1.PackageReposito
 	}
 }
 
-func (s *Server) deleteRepo(ctx context.Context, headers http.Header, repoRef *corev1.PackageRepositoryReference) error {
+func (s *Server) deleteRepo(ctx context.Context, headers http.Header, repoRef *corev1.PackageRepositoryReference, retainReferencedSecrets bool) ([]*corev1.PackageRepositorySecretUsage, error) {
 	client, err := s.getClient(headers, repoRef.Context.Namespace)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	log.V(4).Infof("Deleting repo: [%s]", repoRef.Identifier)
-
 	// For kubeapps-managed secrets environment secrets will be deleted (garbage-collected)
-	// when the owner repo is deleted
+	// when the owner repo is deleted, unless they are still referenced elsewhere
+	retainedSecrets, err := s.releaseReferencedRepoSecret(ctx, headers, client, repoRef, retainReferencedSecrets)
+	if err != nil {
+		return nil, err
+	}
+
+	log.V(4).Infof("Deleting repo: [%s]", repoRef.Identifier)
 
 	repo := &sourcev1.HelmRepository{
 		ObjectMeta: metav1.ObjectMeta{
@@ -534,16 +612,99 @@ func (s *Server) deleteRepo(ctx context.Context, headers http.Header, repoRef *c
 		},
 	}
 	if err = client.Delete(ctx, repo); err != nil {
-		return connecterror.FromK8sError("delete", "HelmRepository", repoRef.Identifier, err)
+		return nil, connecterror.FromK8sError("delete", "HelmRepository", repoRef.Identifier, err)
 	} else {
-		return nil
+		return retainedSecrets, nil
 	}
 }
 
+// releaseReferencedRepoSecret checks whether the secret managed by the plugin for the
+// repository is still referenced by other HelmRepositories or HelmReleases of its namespace.
+// Unless it is retained, these references prevent the deletion of the repository.
+// Otherwise, the secret is no longer owned by the repository, so that it is not
+// garbage-collected along with it, and its usage is returned.
+func (s *Server) releaseReferencedRepoSecret(ctx context.Context, headers http.Header, client ctrlclient.Client, repoRef *corev1.PackageRepositoryReference, retainReferencedSecrets bool) ([]*corev1.PackageRepositorySecretUsage, error) {
+	key := types.NamespacedName{Namespace: repoRef.Context.Namespace, Name: repoRef.Identifier}
+	var repo sourcev1.HelmRepository
+	if err := client.Get(ctx, key, &repo); err != nil {
+		return nil, connecterror.FromK8sError("get", "HelmRepository", key.String(), err)
+	}
+	if repo.Spec.SecretRef == nil {
+		return nil, nil
+	}
+	typedClient, err := s.clientGetter.Typed(headers, s.kubeappsCluster)
+	if err != nil {
+		return nil, err
+	}
+	secretsInterface := typedClient.CoreV1().Secrets(repo.Namespace)
+	secret, err := secretsInterface.Get(ctx, repo.Spec.SecretRef.Name, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, connecterror.FromK8sError("get", "secret", repo.Spec.SecretRef.Name, err)
+	}
+	if !isSecretKubeappsManaged(secret, &repo) {
+		return nil, nil
+	}
+
+	usages := pkgutils.NewSecretUsages(secret.GetName())
+	var repoList sourcev1.HelmRepositoryList
+	if err := client.List(ctx, &repoList); err != nil {
+		return nil, connecterror.FromK8sError("list", "HelmRepository", repo.Namespace+"/*", err)
+	}
+	for _, otherRepo := range repoList.Items {
+		if otherRepo.GetName() == repo.GetName() || otherRepo.Spec.SecretRef == nil {
+			continue
+		}
+		usages.Add(otherRepo.Spec.SecretRef.Name, &corev1.ResourceRef{
+			ApiVersion: sourcev1.GroupVersion.String(),
+			Kind:       sourcev1.HelmRepositoryKind,
+			Name:       otherRepo.GetName(),
+			Namespace:  otherRepo.GetNamespace(),
+		})
+	}
+	releases, err := s.listReleasesInCluster(ctx, headers, repo.Namespace, labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, release := range releases {
+		ref := &corev1.ResourceRef{
+			ApiVersion: helmv2.GroupVersion.String(),
+			Kind:       helmv2.HelmReleaseKind,
+			Name:       release.GetName(),
+			Namespace:  release.GetNamespace(),
+		}
+		for _, valuesRef := range release.Spec.ValuesFrom {
+			if valuesRef.Kind == "Secret" {
+				usages.Add(valuesRef.Name, ref)
+			}
+		}
+		if release.Spec.KubeConfig != nil {
+			usages.Add(release.Spec.KubeConfig.SecretRef.Name, ref)
+		}
+	}
+
+	inUse := usages.InUse()
+	if len(inUse) == 0 {
+		return nil, nil
+	}
+	if !retainReferencedSecrets {
+		return nil, pkgutils.SecretsInUseError(repo.GetName(), inUse)
+	}
+	if pkgutils.RemoveOwnerReference(secret, repo.GetUID()) {
+		if _, err := secretsInterface.Update(ctx, secret, metav1.UpdateOptions{}); err != nil {
+			return nil, connecterror.FromK8sError("update", "secret", secret.GetName(), err)
+		}
+		log.InfoS("Retained the secret of the deleted HelmRepository", "secret", secret.GetName(), "repository", key.String())
+	}
+	return inUse, nil
+}
+
 // implements plug-in specific cache-related functionality
 type repoEventSink struct {
 	clientGetter clientgetter.FixedClusterClientProviderInterface
 	chartCache   *cache.ChartCache // chartCache maybe nil only in unit tests
+	caBundle     []byte            // caBundle is trusted in addition to the CA of the repo
 }
 
 // this is what we store in the cache for each cached repo
@@ -823,11 +984,32 @@ func (s *repoEventSink) getRepoSecret(ctx context.Context, repo sourcev1.HelmRep
 // The reason I do this here is to set up auth that may be needed to fetch chart tarballs by
 // ChartCache
 func (s *repoEventSink) clientOptionsForHttpRepo(ctx context.Context, repo sourcev1.HelmRepository) (*common.HttpClientOptions, error) {
-	if secret, err := s.getRepoSecret(ctx, repo); err == nil && secret != nil {
-		return common.HttpClientOptionsFromSecret(*secret)
-	} else {
+	secret, err := s.getRepoSecret(ctx, repo)
+	if err != nil {
 		return nil, err
 	}
+	return clientOptionsForRepoSecret(secret, s.caBundle)
+}
+
+// clientOptionsForRepoSecret returns the options of the http client of a
+// repository with the given secret, which may be nil, trusting the CA bundle
+// in addition to the CA of the secret.
+func clientOptionsForRepoSecret(secret *apiv1.Secret, caBundle []byte) (*common.HttpClientOptions, error) {
+	var opts *common.HttpClientOptions
+	if secret != nil {
+		var err error
+		if opts, err = common.HttpClientOptionsFromSecret(*secret); err != nil {
+			return nil, err
+		}
+	}
+	if len(caBundle) == 0 {
+		return opts, nil
+	}
+	if opts == nil {
+		opts = &common.HttpClientOptions{}
+	}
+	opts.CaBytes = httpclient.JoinCerts(caBundle, opts.CaBytes)
+	return opts, nil
 }
 
 //
@@ -911,7 +1093,7 @@ func newFluxHelmRepo(
 	interval string,
 	secret *apiv1.Secret,
 	passCredentials bool,
-	provider string) (*sourcev1.HelmRepository, error) {
+	customDetail *v1alpha1.FluxPackageRepositoryCustomDetail) (*sourcev1.HelmRepository, error) {
 	pollInterval := defaultPollInterval
 	if interval != "" {
 		if duration, err := pkgutils.ToDuration(interval); err != nil {
@@ -944,8 +1126,46 @@ func newFluxHelmRepo(
 	if passCredentials {
 		fluxRepo.Spec.PassCredentials = true
 	}
-	if provider != "" {
-		fluxRepo.Spec.Provider = provider
+	if customDetail != nil {
+		if customDetail.Provider != "" {
+			fluxRepo.Spec.Provider = customDetail.Provider
+		}
+		if err := setHelmRepoTimeoutAndSuspend(fluxRepo, customDetail); err != nil {
+			return nil, err
+		}
+		if err := setHelmRepoPackagePolicy(fluxRepo, customDetail); err != nil {
+			return nil, err
+		}
 	}
 	return fluxRepo, nil
 }
+
+// setHelmRepoTimeoutAndSuspend sets the timeout and the suspension of the
+// repository from the custom detail, an empty timeout resetting it to the
+// Flux default.
+func setHelmRepoTimeoutAndSuspend(repo *sourcev1.HelmRepository, customDetail *v1alpha1.FluxPackageRepositoryCustomDetail) error {
+	repo.Spec.Timeout = nil
+	if customDetail.Timeout != "" {
+		if duration, err := pkgutils.ToDuration(customDetail.Timeout); err != nil {
+			return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Timeout is invalid: %w", err))
+		} else {
+			repo.Spec.Timeout = duration
+		}
+	}
+	repo.Spec.Suspend = customDetail.Suspend
+	return nil
+}
+
+// setHelmRepoPackagePolicy stores the patterns of the packages made available
+// from the repository, from the custom detail, in its annotations.
+func setHelmRepoPackagePolicy(repo *sourcev1.HelmRepository, customDetail *v1alpha1.FluxPackageRepositoryCustomDetail) error {
+	if err := pkgutils.ValidatePackagePatterns(customDetail.AllowedPackages); err != nil {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Allowed packages are invalid: %w", err))
+	}
+	if err := pkgutils.ValidatePackagePatterns(customDetail.DeniedPackages); err != nil {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Denied packages are invalid: %w", err))
+	}
+	pkgutils.SetPackagePatterns(&repo.ObjectMeta, pkgutils.AnnotationAllowedPackagesKey, customDetail.AllowedPackages)
+	pkgutils.SetPackagePatterns(&repo.ObjectMeta, pkgutils.AnnotationDeniedPackagesKey, customDetail.DeniedPackages)
+	return nil
+}
@@ -36,6 +36,36 @@ func (s *Server) handleRepoSecretForCreate(
 	tlsConfig *corev1.PackageRepositoryTlsConfig,
 	auth *corev1.PackageRepositoryAuth) (*apiv1.Secret, bool, error) {
 
+	secret, isSecretKubeappsManaged, err := s.newRepoSecret(ctx, headers, repoName, repoType, tlsConfig, auth)
+	if err != nil || !isSecretKubeappsManaged {
+		return secret, false, err
+	}
+
+	// a bit of catch 22: I need to create a secret first, so that I can create a repo that references it
+	// but then I need to set the owner reference on this secret to the repo. In has to be done
+	// in that order because to set an owner ref you need object (i.e. repo) UID, which you only get
+	// once the object's been created
+	// create a secret first, if applicable
+	if typedClient, err := s.clientGetter.Typed(headers, s.kubeappsCluster); err != nil {
+		return nil, false, err
+	} else if secret, err = typedClient.CoreV1().Secrets(repoName.Namespace).Create(ctx, secret, metav1.CreateOptions{}); err != nil {
+		return nil, false, connecterror.FromK8sError("create", "secret", secret.GetGenerateName(), err)
+	} else {
+		return secret, true, err
+	}
+}
+
+// newRepoSecret returns the secret of a new repository, which is either the
+// user-managed secret it references or a kubeapps-managed secret holding its
+// TLS config and auth, not created in the cluster yet.
+func (s *Server) newRepoSecret(
+	ctx context.Context,
+	headers http.Header,
+	repoName types.NamespacedName,
+	repoType string,
+	tlsConfig *corev1.PackageRepositoryTlsConfig,
+	auth *corev1.PackageRepositoryAuth) (*apiv1.Secret, bool, error) {
+
 	hasCaRef := tlsConfig != nil && tlsConfig.GetSecretRef() != nil
 	hasCaData := tlsConfig != nil && tlsConfig.GetCertAuthority() != ""
 	hasAuthRef := auth != nil && auth.GetSecretRef() != nil
@@ -46,7 +76,6 @@ func (s *Server) handleRepoSecretForCreate(
 		return nil, false, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Package repository cannot mix referenced secrets and user provided secret data"))
 	}
 
-	// create/get secret
 	if hasCaRef || hasAuthRef {
 		// user-managed
 		secret, err := s.validateUserManagedRepoSecret(ctx, headers, repoName, repoType, tlsConfig, auth)
@@ -57,25 +86,12 @@ func (s *Server) handleRepoSecretForCreate(
 		if err != nil {
 			return nil, false, err
 		}
-		// a bit of catch 22: I need to create a secret first, so that I can create a repo that references it
-		// but then I need to set the owner reference on this secret to the repo. In has to be done
-		// in that order because to set an owner ref you need object (i.e. repo) UID, which you only get
-		// once the object's been created
-		// create a secret first, if applicable
-		if typedClient, err := s.clientGetter.Typed(headers, s.kubeappsCluster); err != nil {
-			return nil, false, err
-		} else if secret, err = typedClient.CoreV1().Secrets(repoName.Namespace).Create(ctx, secret, metav1.CreateOptions{}); err != nil {
-			return nil, false, connecterror.FromK8sError("create", "secret", secret.GetGenerateName(), err)
-		} else {
-			return secret, true, err
-		}
+		return secret, true, nil
 	} else {
 		return nil, false, nil
 	}
 }
 
-// isSecretUpdated is a boolean indicating whether or not the secret ref for a repository
-// has been updated as a result of this call.
 func (s *Server) handleRepoSecretForUpdate(
 	ctx context.Context,
 	headers http.Header,
@@ -0,0 +1,100 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/bufbuild/connect-go"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1beta2"
+	"github.com/google/go-containerregistry/pkg/authn"
+	corev1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/plugins/fluxv2/packages/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/fluxv2/packages/v1alpha1/common"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/helm"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/netrestrict"
+	httpclient "github.com/vmware-tanzu/kubeapps/pkg/http-client"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// testRepoConnection checks that the repository can be reached with its TLS
+// configuration and credentials, probing it as the helm plugin does.
+// Referenced secrets are read, but nothing is created in the cluster.
+func (s *Server) testRepoConnection(ctx context.Context, headers http.Header, request *corev1.AddPackageRepositoryRequest) (*corev1.TestPackageRepositoryConnectionResponse, error) {
+	if request == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("No package repository provided"))
+	}
+	if !request.GetNamespaceScoped() {
+		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("Global-scoped repositories are not supported"))
+	}
+	typ := request.GetType()
+	if typ != "helm" && typ != sourcev1.HelmRepositoryTypeOCI {
+		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("Repository type [%s] not supported", typ))
+	}
+	url := request.GetUrl()
+	tlsConfig := request.GetTlsConfig()
+	if url == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Repository url may not be empty"))
+	} else if tlsConfig != nil && tlsConfig.InsecureSkipVerify {
+		// ref https://github.com/fluxcd/source-controller/issues/807
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("TLS flag insecureSkipVerify is not supported"))
+	}
+	if request.CustomDetail != nil {
+		customDetail := &v1alpha1.FluxPackageRepositoryCustomDetail{}
+		if err := request.CustomDetail.UnmarshalTo(customDetail); err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("The customDetail could not be parsed due to: %w", err))
+		}
+		if customDetail.Provider != "" && customDetail.Provider != sourcev1.GenericOCIProvider {
+			return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("Not supported yet: testing the connection to a repository authenticated by the provider %q", customDetail.Provider))
+		}
+	}
+
+	name := types.NamespacedName{Name: request.GetName(), Namespace: request.GetContext().GetNamespace()}
+	secret, _, err := s.newRepoSecret(ctx, headers, name, typ, tlsConfig, request.GetAuth())
+	if err != nil {
+		return nil, err
+	}
+	opts, err := clientOptionsForRepoSecret(secret, s.caBundle)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	client, clientHeaders, err := common.NewHttpClientAndHeaders(opts)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	// the repository URL is chosen by the user, so the requests cannot reach
+	// the addresses only exposed to the plugin itself
+	restrictions, err := netrestrict.New(s.config().RepositoryConnectionAllowedNetworks)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	if typ == sourcev1.HelmRepositoryTypeOCI {
+		auth := authn.Anonymous
+		if secret != nil {
+			cred, err := common.OCIChartRepositoryCredentialFromSecret(url, *secret)
+			if err != nil {
+				return nil, connect.NewError(connect.CodeInvalidArgument, err)
+			}
+			if cred != nil {
+				auth = authn.FromConfig(authn.AuthConfig{
+					Username:      cred.Username,
+					Password:      cred.Password,
+					IdentityToken: cred.RefreshToken,
+					RegistryToken: cred.AccessToken,
+				})
+			}
+		}
+		return helm.ProbeOCIRegistry(ctx, url, auth, restrictions.Transport(client.Transport))
+	}
+
+	header := http.Header{}
+	for key, value := range clientHeaders {
+		header.Set(key, value)
+	}
+	return helm.ProbeRepoIndex(ctx, restrictions.Client(httpclient.NewDefaultHeaderClient(client, header)), url)
+}
@@ -24,9 +24,13 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	corev1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
 	plugins "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/plugins/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/plugins/fluxv2/packages/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/fluxv2/packages/v1alpha1/common"
 	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/clientgetter"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/pkgutils"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -36,10 +40,11 @@ import (
 )
 
 type testSpecGetAvailablePackageSummaries struct {
-	name      string
-	namespace string
-	url       string
-	index     string
+	name        string
+	namespace   string
+	url         string
+	index       string
+	annotations map[string]string
 }
 
 func TestGetAvailablePackageSummariesWithoutPagination(t *testing.T) {
@@ -378,6 +383,29 @@ func TestGetAvailablePackageSummariesWithoutPagination(t *testing.T) {
 				AvailablePackageSummaries: []*corev1.AvailablePackageSummary{},
 			},
 		},
+		{
+			name: "it hides the packages not allowed by the policies of the repositories",
+			repos: []testSpecGetAvailablePackageSummaries{
+				{
+					name:        "bitnami-1",
+					namespace:   "default",
+					url:         "https://example.repo.com/charts",
+					index:       testYaml("valid-index.yaml"),
+					annotations: map[string]string{pkgutils.AnnotationAllowedPackagesKey: "acs-*,wordpress", pkgutils.AnnotationDeniedPackagesKey: "word*"},
+				},
+				{
+					name:        "jetstack-1",
+					namespace:   "ns1",
+					url:         "https://charts.jetstack.io",
+					index:       testYaml("jetstack-index.yaml"),
+					annotations: map[string]string{pkgutils.AnnotationDeniedPackagesKey: "cert-manager"},
+				},
+			},
+			request: &corev1.GetAvailablePackageSummariesRequest{Context: &corev1.Context{Namespace: "default"}},
+			expectedResponse: &corev1.GetAvailablePackageSummariesResponse{
+				AvailablePackageSummaries: valid_index_available_package_summaries[:1],
+			},
+		},
 		{
 			name: "it returns an error if a cluster other than the kubeapps cluster is specified",
 			request: &corev1.GetAvailablePackageSummariesRequest{Context: &corev1.Context{
@@ -421,6 +449,7 @@ func TestGetAvailablePackageSummariesWithoutPagination(t *testing.T) {
 					t.Fatalf("%+v", err)
 				}
 				defer ts2.Close()
+				repo.Annotations = rs.annotations
 				repos = append(repos, *repo)
 			}
 
@@ -1457,6 +1486,218 @@ func TestAddPackageRepository(t *testing.T) {
 	}
 }
 
+func TestTestPackageRepositoryConnection(t *testing.T) {
+	newRequest := func(repoType string, auth *corev1.PackageRepositoryAuth) *corev1.TestPackageRepositoryConnectionRequest {
+		return &corev1.TestPackageRepositoryConnectionRequest{
+			PackageRepository: &corev1.AddPackageRepositoryRequest{
+				Name:            "bar",
+				Context:         &corev1.Context{Namespace: "foo", Cluster: KubeappsCluster},
+				Type:            repoType,
+				NamespaceScoped: true,
+				Auth:            auth,
+			},
+		}
+	}
+	basicAuth := &corev1.PackageRepositoryAuth{
+		Type: corev1.PackageRepositoryAuth_PACKAGE_REPOSITORY_AUTH_TYPE_BASIC_AUTH,
+		PackageRepoAuthOneOf: &corev1.PackageRepositoryAuth_UsernamePassword{
+			UsernamePassword: &corev1.UsernamePassword{Username: "foo", Password: "bar"},
+		},
+	}
+	// requireBasicAuth serves the given handler only to the requests with the
+	// credentials of basicAuth.
+	requireBasicAuth := func(handler http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if username, password, ok := r.BasicAuth(); !ok || username != "foo" || password != "bar" {
+				w.Header().Set("WWW-Authenticate", `Basic realm="test"`)
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			handler(w, r)
+		}
+	}
+	serveIndex := func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/index.yaml" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("ETag", `"abc"`)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("apiVersion: v1\nentries:\n  apache:\n  - name: apache\n    version: 1.0.0\n  nginx:\n  - name: nginx\n    version: 2.0.0\n"))
+	}
+	serveRegistry := func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}
+
+	testCases := []struct {
+		name           string
+		request        *corev1.TestPackageRepositoryConnectionRequest
+		existingSecret *apiv1.Secret
+		handler        http.HandlerFunc
+		// repositoryNetworkBlocked keeps the loopback address of the test
+		// server blocked, as it is by default.
+		repositoryNetworkBlocked bool
+		expectedResponse         *corev1.TestPackageRepositoryConnectionResponse
+		errorCode                connect.Code
+	}{
+		{
+			name:      "returns error if no package repository is provided",
+			request:   &corev1.TestPackageRepositoryConnectionRequest{},
+			errorCode: connect.CodeInvalidArgument,
+		},
+		{
+			name: "returns error if the repository is not namespace scoped",
+			request: &corev1.TestPackageRepositoryConnectionRequest{
+				PackageRepository: &corev1.AddPackageRepositoryRequest{Type: "helm"},
+			},
+			errorCode: connect.CodeUnimplemented,
+		},
+		{
+			name:      "returns error if the repository type is not supported",
+			request:   newRequest("git", nil),
+			errorCode: connect.CodeUnimplemented,
+		},
+		{
+			name:    "returns the metadata and the number of packages of the repository index",
+			request: newRequest("helm", nil),
+			handler: serveIndex,
+			expectedResponse: &corev1.TestPackageRepositoryConnectionResponse{
+				Message:      "Found the repository index at '{{url}}/index.yaml'",
+				Metadata:     map[string]string{"etag": `"abc"`},
+				PackageCount: proto.Int32(2),
+			},
+		},
+		{
+			name:    "uses the credentials of the request",
+			request: newRequest("helm", basicAuth),
+			handler: requireBasicAuth(serveIndex),
+			expectedResponse: &corev1.TestPackageRepositoryConnectionResponse{
+				Message:      "Found the repository index at '{{url}}/index.yaml'",
+				Metadata:     map[string]string{"etag": `"abc"`},
+				PackageCount: proto.Int32(2),
+			},
+		},
+		{
+			name: "uses the credentials of the referenced secret",
+			request: newRequest("helm", &corev1.PackageRepositoryAuth{
+				Type: corev1.PackageRepositoryAuth_PACKAGE_REPOSITORY_AUTH_TYPE_BASIC_AUTH,
+				PackageRepoAuthOneOf: &corev1.PackageRepositoryAuth_SecretRef{
+					SecretRef: &corev1.SecretKeyReference{Name: "secret-1"},
+				},
+			}),
+			existingSecret: newBasicAuthSecret(types.NamespacedName{Name: "secret-1", Namespace: "foo"}, "foo", "bar"),
+			handler:        requireBasicAuth(serveIndex),
+			expectedResponse: &corev1.TestPackageRepositoryConnectionResponse{
+				Message:      "Found the repository index at '{{url}}/index.yaml'",
+				Metadata:     map[string]string{"etag": `"abc"`},
+				PackageCount: proto.Int32(2),
+			},
+		},
+		{
+			name: "returns not found if the referenced secret does not exist",
+			request: newRequest("helm", &corev1.PackageRepositoryAuth{
+				Type: corev1.PackageRepositoryAuth_PACKAGE_REPOSITORY_AUTH_TYPE_BASIC_AUTH,
+				PackageRepoAuthOneOf: &corev1.PackageRepositoryAuth_SecretRef{
+					SecretRef: &corev1.SecretKeyReference{Name: "secret-1"},
+				},
+			}),
+			handler:   serveIndex,
+			errorCode: connect.CodeNotFound,
+		},
+		{
+			name:      "returns unauthenticated if the repository rejects the credentials",
+			request:   newRequest("helm", nil),
+			handler:   requireBasicAuth(serveIndex),
+			errorCode: connect.CodeUnauthenticated,
+		},
+		{
+			name:                     "returns permission denied if the repository is served from a blocked network",
+			request:                  newRequest("helm", nil),
+			handler:                  serveIndex,
+			repositoryNetworkBlocked: true,
+			errorCode:                connect.CodePermissionDenied,
+		},
+		{
+			name:    "connects to the OCI registry with the credentials of the request",
+			request: newRequest("oci", basicAuth),
+			handler: requireBasicAuth(serveRegistry),
+			expectedResponse: &corev1.TestPackageRepositoryConnectionResponse{
+				Message: "Connected to the OCI registry '{{url}}'",
+			},
+		},
+		{
+			name:      "returns unauthenticated if the OCI registry rejects the credentials",
+			request:   newRequest("oci", nil),
+			handler:   requireBasicAuth(serveRegistry),
+			errorCode: connect.CodeUnauthenticated,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var secrets []runtime.Object
+			if tc.existingSecret != nil {
+				secrets = append(secrets, tc.existingSecret)
+			}
+			s, _, err := newServerWithRepos(t, nil, nil, secrets)
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+
+			url := "https://example.com"
+			if tc.handler != nil {
+				testRepoServer := httptest.NewServer(tc.handler)
+				defer testRepoServer.Close()
+				url = testRepoServer.URL
+				if tc.request.PackageRepository.Type == "oci" {
+					url = strings.Replace(url, "http://", "oci://", 1)
+				}
+				if !tc.repositoryNetworkBlocked {
+					s.pluginConfig.RepositoryConnectionAllowedNetworks = []string{"127.0.0.0/8"}
+				}
+			}
+			if tc.request.PackageRepository != nil {
+				tc.request.PackageRepository.Url = url
+			}
+
+			response, err := s.TestPackageRepositoryConnection(context.Background(), connect.NewRequest(tc.request))
+
+			if tc.errorCode != 0 {
+				if got, want := connect.CodeOf(err), tc.errorCode; err == nil || got != want {
+					t.Fatalf("got: %v, want: error with code %s", err, want)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+
+			tc.expectedResponse.Message = strings.Replace(tc.expectedResponse.Message, "{{url}}", url, 1)
+			opts := cmpopts.IgnoreUnexported(corev1.TestPackageRepositoryConnectionResponse{})
+			if got, want := response.Msg, tc.expectedResponse; !cmp.Equal(got, want, opts) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, opts))
+			}
+
+			// Testing the connection must not create anything in the cluster.
+			typedClient, err := s.clientGetter.Typed(http.Header{}, s.kubeappsCluster)
+			if err != nil {
+				t.Fatal(err)
+			}
+			secretList, err := typedClient.CoreV1().Secrets("").List(context.Background(), metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := len(secretList.Items), len(secrets); got != want {
+				t.Errorf("got: %d secrets, want: %d", got, want)
+			}
+		})
+	}
+}
+
 func TestGetPackageRepositoryDetail(t *testing.T) {
 	ca, pub, priv := getCertsForTesting(t)
 	testCases := []struct {
@@ -2228,6 +2469,115 @@ func TestUpdatePackageRepository(t *testing.T) {
 	}
 }
 
+func TestSetHelmRepoTimeoutAndSuspend(t *testing.T) {
+	testCases := []struct {
+		name              string
+		customDetail      *v1alpha1.FluxPackageRepositoryCustomDetail
+		expectedTimeout   *metav1.Duration
+		expectedSuspend   bool
+		expectedErrorCode connect.Code
+	}{
+		{
+			name:            "it sets the timeout and suspends the repository",
+			customDetail:    &v1alpha1.FluxPackageRepositoryCustomDetail{Timeout: "2m", Suspend: true},
+			expectedTimeout: &metav1.Duration{Duration: 2 * time.Minute},
+			expectedSuspend: true,
+		},
+		{
+			name:         "it resets the timeout and resumes the repository",
+			customDetail: &v1alpha1.FluxPackageRepositoryCustomDetail{},
+		},
+		{
+			name:              "it returns an invalid argument error for an invalid timeout",
+			customDetail:      &v1alpha1.FluxPackageRepositoryCustomDetail{Timeout: "2 minutes"},
+			expectedErrorCode: connect.CodeInvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &sourcev1.HelmRepository{
+				Spec: sourcev1.HelmRepositorySpec{
+					Timeout: &metav1.Duration{Duration: time.Minute},
+					Suspend: true,
+				},
+			}
+			err := setHelmRepoTimeoutAndSuspend(repo, tc.customDetail)
+			if got, want := connect.CodeOf(err), tc.expectedErrorCode; err != nil && got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			} else if err == nil && tc.expectedErrorCode != 0 {
+				t.Fatalf("got: nil, want: error with code %+v", tc.expectedErrorCode)
+			}
+			if tc.expectedErrorCode != 0 {
+				return
+			}
+			if got, want := repo.Spec.Timeout, tc.expectedTimeout; !cmp.Equal(want, got) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+			if got, want := repo.Spec.Suspend, tc.expectedSuspend; got != want {
+				t.Errorf("got: %t, want: %t", got, want)
+			}
+		})
+	}
+}
+
+func TestSetHelmRepoPackagePolicy(t *testing.T) {
+	testCases := []struct {
+		name                string
+		customDetail        *v1alpha1.FluxPackageRepositoryCustomDetail
+		expectedAnnotations map[string]string
+		expectedErrorCode   connect.Code
+	}{
+		{
+			name: "it stores the package patterns in the annotations",
+			customDetail: &v1alpha1.FluxPackageRepositoryCustomDetail{
+				AllowedPackages: []string{"apache", "*sql"},
+				DeniedPackages:  []string{"mysql"},
+			},
+			expectedAnnotations: map[string]string{
+				"foo":                                 "bar",
+				pkgutils.AnnotationAllowedPackagesKey: "apache,*sql",
+				pkgutils.AnnotationDeniedPackagesKey:  "mysql",
+			},
+		},
+		{
+			name:                "it removes the package patterns from the annotations",
+			customDetail:        &v1alpha1.FluxPackageRepositoryCustomDetail{},
+			expectedAnnotations: map[string]string{"foo": "bar"},
+		},
+		{
+			name:              "it returns an invalid argument error for an invalid pattern",
+			customDetail:      &v1alpha1.FluxPackageRepositoryCustomDetail{DeniedPackages: []string{"[a-"}},
+			expectedErrorCode: connect.CodeInvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &sourcev1.HelmRepository{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"foo":                                "bar",
+						pkgutils.AnnotationDeniedPackagesKey: "nginx",
+					},
+				},
+			}
+			err := setHelmRepoPackagePolicy(repo, tc.customDetail)
+			if got, want := connect.CodeOf(err), tc.expectedErrorCode; err != nil && got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			} else if err == nil && tc.expectedErrorCode != 0 {
+				t.Fatalf("got: nil, want: error with code %+v", tc.expectedErrorCode)
+			}
+			if tc.expectedErrorCode != 0 {
+				return
+			}
+			if got, want := repo.Annotations, tc.expectedAnnotations; !cmp.Equal(want, got) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
 func TestDeletePackageRepository(t *testing.T) {
 	testCases := []struct {
 		name               string
@@ -2435,6 +2785,39 @@ func TestGetOciAvailablePackageSummariesWithoutPagination(t *testing.T) {
 	}
 }
 
+func TestClientOptionsForHttpRepoWithCABundle(t *testing.T) {
+	testCases := []struct {
+		name            string
+		caBundle        []byte
+		expectedOptions *common.HttpClientOptions
+	}{
+		{
+			name:            "it returns no options for a repo without secret nor CA bundle",
+			expectedOptions: nil,
+		},
+		{
+			name:            "it trusts the CA bundle for a repo without secret",
+			caBundle:        []byte("ca-bundle-data"),
+			expectedOptions: &common.HttpClientOptions{CaBytes: []byte("ca-bundle-data")},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sink := repoEventSink{caBundle: tc.caBundle}
+			repo := newRepo("bitnami", "default", &sourcev1.HelmRepositorySpec{URL: "https://example.repo.com/charts"}, nil)
+
+			opts, err := sink.clientOptionsForHttpRepo(context.Background(), repo)
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if got, want := opts, tc.expectedOptions; !cmp.Equal(want, got) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
 func newRepo(name string, namespace string, spec *sourcev1.HelmRepositorySpec, status *sourcev1.HelmRepositoryStatus) sourcev1.HelmRepository {
 	helmRepository := sourcev1.HelmRepository{
 		ObjectMeta: metav1.ObjectMeta{
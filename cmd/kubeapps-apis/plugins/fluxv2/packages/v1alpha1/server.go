@@ -7,6 +7,8 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"sync"
+	"time"
 
 	"github.com/bufbuild/connect-go"
 	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/resources"
@@ -15,6 +17,7 @@ import (
 
 	helmv2 "github.com/fluxcd/helm-controller/api/v2beta1"
 	sourcev1 "github.com/fluxcd/source-controller/api/v1beta2"
+	"google.golang.org/protobuf/proto"
 	authorizationv1 "k8s.io/api/authorization/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -29,6 +32,7 @@ import (
 	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/fluxv2/packages/v1alpha1/cache"
 	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/fluxv2/packages/v1alpha1/common"
 	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/clientgetter"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/k8sutils"
 	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/paginate"
 	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/pkgutils"
 	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/resourcerefs"
@@ -63,11 +67,26 @@ type Server struct {
 	chartCache *cache.ChartCache
 
 	pluginConfig *common.FluxPluginConfig
+	// pluginConfigMutex guards the pluginConfig, which is replaced when the
+	// plugin configuration file is reloaded.
+	pluginConfigMutex sync.RWMutex
+
+	// lockdownCache holds the multi-tenancy lockdown flags detected from the
+	// flux helm-controller.
+	lockdownCache *fluxLockdownCache
+
+	// caBundle is trusted, in addition to the CA of each repository, when
+	// fetching the charts of the repositories.
+	caBundle []byte
+
+	// clientQPS bounds the number of concurrent access reviews when checking
+	// the namespaces to which the user has access.
+	clientQPS float32
 }
 
 // NewServer returns a Server automatically configured with a function to obtain
 // the k8s client config.
-func NewServer(configGetter core.KubernetesConfigGetter, kubeappsCluster string, stopCh <-chan struct{}, pluginConfigPath string, clientQPS float32, clientBurst int) (*Server, error) {
+func NewServer(configGetter core.KubernetesConfigGetter, kubeappsCluster string, stopCh <-chan struct{}, pluginConfigPath string, clientQPS float32, clientBurst int, caBundle []byte) (*Server, error) {
 	log.Infof("+fluxv2 NewServer(kubeappsCluster: [%v], pluginConfigPath: [%s]",
 		kubeappsCluster, pluginConfigPath)
 
@@ -103,6 +122,7 @@ func NewServer(configGetter core.KubernetesConfigGetter, kubeappsCluster string,
 		s := repoEventSink{
 			clientGetter: backgroundClientGetter,
 			chartCache:   chartCache,
+			caBundle:     caBundle,
 		}
 		repoCacheConfig := cache.NamespacedResourceWatcherCacheConfig{
 			Gvr:          common.GetRepositoriesGvr(),
@@ -144,11 +164,45 @@ func NewServer(configGetter core.KubernetesConfigGetter, kubeappsCluster string,
 				chartCache:      chartCache,
 				kubeappsCluster: kubeappsCluster,
 				pluginConfig:    pluginConfig,
+				lockdownCache:   newFluxLockdownCache(),
+				caBundle:        caBundle,
+				clientQPS:       clientQPS,
 			}, nil
 		}
 	}
 }
 
+// config returns the plugin configuration currently in effect.
+func (s *Server) config() *common.FluxPluginConfig {
+	s.pluginConfigMutex.RLock()
+	defer s.pluginConfigMutex.RUnlock()
+	return s.pluginConfig
+}
+
+// reloadPluginConfig parses the plugin configuration file and, if valid,
+// replaces the configuration in effect, which is returned.
+func (s *Server) reloadPluginConfig(pluginConfigPath string) (interface{}, error) {
+	pluginConfig, err := common.ParsePluginConfig(pluginConfigPath)
+	if err != nil {
+		return nil, err
+	}
+	s.pluginConfigMutex.Lock()
+	s.pluginConfig = pluginConfig
+	s.pluginConfigMutex.Unlock()
+	log.Infof("+fluxv2 reloaded custom config: [%v]", *pluginConfig)
+	return pluginConfig, nil
+}
+
+// Probe checks that the flux resources used by the plugin are served in the
+// kubeapps cluster.
+func (s *Server) Probe(ctx context.Context) error {
+	typedClient, err := s.serviceAccountClientGetter.Typed(ctx)
+	if err != nil {
+		return err
+	}
+	return k8sutils.CheckServedResources(typedClient.Discovery(), common.GetRepositoriesGvr(), common.GetChartsGvr(), common.GetReleasesGvr())
+}
+
 // ===== general note on error handling ========
 // using fmt.Errorf vs status.Errorf in functions exposed as grpc:
 //
@@ -165,7 +219,8 @@ func NewServer(configGetter core.KubernetesConfigGetter, kubeappsCluster string,
 // Note that currently packages are returned only from repos that are in a 'Ready'
 // state. For the fluxv2 plugin:
 //   - if flux helm-controller flag "-no-cross-namespace-refs=true" is
-//     enabled only the request target namespace is relevant
+//     enabled (either detected or configured for the plugin) only the request
+//     target namespace is relevant
 //     ref https://github.com/vmware-tanzu/kubeapps/issues/5541
 //   - otherwise the request context namespace (the target
 //     namespace) is not relevant since charts from a repository in any namespace
@@ -190,7 +245,7 @@ func (s *Server) GetAvailablePackageSummaries(ctx context.Context, request *conn
 	}
 
 	ns := metav1.NamespaceAll
-	if s.pluginConfig.NoCrossNamespaceRefs {
+	if s.getFluxLockdown(ctx).noCrossNamespaceRefs {
 		ns = request.Msg.Context.Namespace
 	}
 
@@ -258,6 +313,14 @@ func (s *Server) GetAvailablePackageDetail(ctx context.Context, request *connect
 	}), nil
 }
 
+// GetAvailablePackageTextAsset returns a range of a text asset of a package managed by the 'fluxv2' plugin
+func (s *Server) GetAvailablePackageTextAsset(ctx context.Context, request *connect.Request[corev1.GetAvailablePackageTextAssetRequest]) (*connect.Response[corev1.GetAvailablePackageTextAssetResponse], error) {
+	log.Infof("+fluxv2 GetAvailablePackageTextAsset [%v]", request)
+	defer log.Info("-fluxv2 GetAvailablePackageTextAsset")
+
+	return pkgutils.GetAvailablePackageTextAsset(ctx, request, s.GetAvailablePackageDetail)
+}
+
 // GetAvailablePackageVersions returns the package versions managed by the 'fluxv2' plugin
 func (s *Server) GetAvailablePackageVersions(ctx context.Context, request *connect.Request[corev1.GetAvailablePackageVersionsRequest]) (*connect.Response[corev1.GetAvailablePackageVersionsResponse], error) {
 	log.Infof("+fluxv2 GetAvailablePackageVersions [%v]", request)
@@ -290,16 +353,100 @@ func (s *Server) GetAvailablePackageVersions(ctx context.Context, request *conne
 		return nil, err
 	} else if chart != nil {
 		// found it
+		versions := pkgutils.PackageAppVersionsSummary(
+			chart.ChartVersions,
+			s.config().VersionsInSummary)
+		if err := pkgutils.SetUpgradeImpacts(
+			versions,
+			pkgutils.ChangelogsFromChartVersions(chart.ChartVersions),
+			request.Msg.GetRelativeToVersion()); err != nil {
+			return nil, err
+		}
 		return connect.NewResponse(&corev1.GetAvailablePackageVersionsResponse{
-			PackageAppVersions: pkgutils.PackageAppVersionsSummary(
-				chart.ChartVersions,
-				s.pluginConfig.VersionsInSummary),
+			PackageAppVersions: versions,
 		}), nil
 	} else {
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to retrieve versions for chart: [%s]", packageRef.Identifier))
 	}
 }
 
+// GetAvailablePackageChangelog returns the release notes between two versions of a package managed by the 'fluxv2' plugin
+func (s *Server) GetAvailablePackageChangelog(ctx context.Context, request *connect.Request[corev1.GetAvailablePackageChangelogRequest]) (*connect.Response[corev1.GetAvailablePackageChangelogResponse], error) {
+	log.Infof("+fluxv2 GetAvailablePackageChangelog [%v]", request)
+	defer log.Info("-fluxv2 GetAvailablePackageChangelog")
+
+	packageRef := request.Msg.GetAvailablePackageRef()
+	namespace := packageRef.GetContext().GetNamespace()
+	if namespace == "" || packageRef.GetIdentifier() == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Required context or identifier not provided"))
+	}
+
+	cluster := packageRef.Context.Cluster
+	if cluster != "" && cluster != s.kubeappsCluster {
+		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("Not supported yet: request.AvailablePackageRef.Context.Cluster: [%v]", cluster))
+	}
+
+	repoName, chartName, err := pkgutils.SplitPackageIdentifier(packageRef.Identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	repo := types.NamespacedName{Namespace: namespace, Name: repoName}
+	chart, err := s.getChartModel(ctx, request.Header(), repo, chartName)
+	if err != nil {
+		return nil, err
+	} else if chart == nil {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("Unable to find the chart: [%s]", packageRef.Identifier))
+	}
+
+	changelogs, err := pkgutils.FilterChangelogs(
+		pkgutils.ChangelogsFromChartVersions(chart.ChartVersions),
+		request.Msg.GetFromVersion(),
+		request.Msg.GetToVersion())
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&corev1.GetAvailablePackageChangelogResponse{
+		Changelogs: changelogs,
+	}), nil
+}
+
+// GetAvailablePackageFilters returns the distinct categories of the charts available to the user
+func (s *Server) GetAvailablePackageFilters(ctx context.Context, request *connect.Request[corev1.GetAvailablePackageFiltersRequest]) (*connect.Response[corev1.GetAvailablePackageFiltersResponse], error) {
+	log.Infof("+fluxv2 GetAvailablePackageFilters [%v]", request)
+	defer log.Info("-fluxv2 GetAvailablePackageFilters")
+
+	cluster := request.Msg.GetContext().GetCluster()
+	if cluster != "" && cluster != s.kubeappsCluster {
+		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("Not supported yet: request.Context.Cluster: [%v]", cluster))
+	}
+
+	ns := metav1.NamespaceAll
+	if s.getFluxLockdown(ctx).noCrossNamespaceRefs {
+		ns = request.Msg.GetContext().GetNamespace()
+	}
+
+	charts, err := s.getChartsForRepos(ctx, request.Header(), ns, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	categories := []string{}
+	for _, packages := range charts {
+		for _, chart := range packages {
+			if chart.Category != "" {
+				categories = append(categories, chart.Category)
+			}
+		}
+	}
+
+	// charts have no provider
+	return connect.NewResponse(&corev1.GetAvailablePackageFiltersResponse{
+		Categories: pkgutils.SortedDistinct(categories),
+		Providers:  []string{},
+	}), nil
+}
+
 // GetInstalledPackageSummaries returns the installed packages managed by the 'fluxv2' plugin
 func (s *Server) GetInstalledPackageSummaries(ctx context.Context, request *connect.Request[corev1.GetInstalledPackageSummariesRequest]) (*connect.Response[corev1.GetInstalledPackageSummariesResponse], error) {
 	log.Infof("+fluxv2 GetInstalledPackageSummaries [%v]", request)
@@ -315,8 +462,17 @@ func (s *Server) GetInstalledPackageSummaries(ctx context.Context, request *conn
 
 	pageSize := request.Msg.GetPaginationOptions().GetPageSize()
 	installedPkgSummaries, err := s.paginatedInstalledPkgSummaries(
-		ctx, request.Header(), request.Msg.GetContext().GetNamespace(), pageSize, itemOffset)
-	if err != nil {
+		ctx, request.Header(), request.Msg.GetContext().GetNamespace(), request.Msg.GetFilterOptions(), pageSize, itemOffset)
+	if connect.CodeOf(err) == connect.CodePermissionDenied && request.Msg.GetContext().GetNamespace() == "" {
+		// Catch forbidden errors in cluster-wide listings, listing the releases
+		// in each of the namespaces the user has access to instead
+		log.Warningf("+fluxv2 unable to list releases at the cluster scope due to [%v]", err)
+		namespaces, err := s.getAccessibleNamespaces(request.Header())
+		if err != nil {
+			return nil, err
+		}
+		return pkgutils.GetInstalledPackageSummariesPerNamespace(ctx, request, s.kubeappsCluster, namespaces, GetPluginDetail(), s.GetInstalledPackageSummaries)
+	} else if err != nil {
 		return nil, err
 	}
 
@@ -364,6 +520,37 @@ func (s *Server) GetInstalledPackageDetail(ctx context.Context, request *connect
 	}), nil
 }
 
+// CheckInstalledPackageNameAvailability checks whether a flux HelmRelease with
+// the name exists in the target namespace.
+func (s *Server) CheckInstalledPackageNameAvailability(ctx context.Context, request *connect.Request[corev1.CheckInstalledPackageNameAvailabilityRequest]) (*connect.Response[corev1.CheckInstalledPackageNameAvailabilityResponse], error) {
+	log.InfoS("+fluxv2 CheckInstalledPackageNameAvailability", "cluster", request.Msg.GetTargetContext().GetCluster(), "namespace", request.Msg.GetTargetContext().GetNamespace(), "name", request.Msg.GetName())
+
+	cluster := request.Msg.GetTargetContext().GetCluster()
+	if cluster != "" && cluster != s.kubeappsCluster {
+		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("Not supported yet: request.TargetContext.Cluster: [%v]", cluster))
+	}
+
+	name := types.NamespacedName{Name: request.Msg.GetName(), Namespace: request.Msg.GetTargetContext().GetNamespace()}
+	conflicts := []*corev1.InstalledPackageReference{}
+	if _, err := s.getReleaseInCluster(ctx, request.Header(), name); err == nil {
+		conflicts = append(conflicts, &corev1.InstalledPackageReference{
+			Context: &corev1.Context{
+				Namespace: name.Namespace,
+				Cluster:   s.kubeappsCluster,
+			},
+			Identifier: name.Name,
+			Plugin:     GetPluginDetail(),
+		})
+	} else if connect.CodeOf(err) != connect.CodeNotFound {
+		return nil, err
+	}
+
+	return connect.NewResponse(&corev1.CheckInstalledPackageNameAvailabilityResponse{
+		Available: len(conflicts) == 0,
+		Conflicts: conflicts,
+	}), nil
+}
+
 // CreateInstalledPackage creates an installed package based on the request.
 func (s *Server) CreateInstalledPackage(ctx context.Context, request *connect.Request[corev1.CreateInstalledPackageRequest]) (*connect.Response[corev1.CreateInstalledPackageResponse], error) {
 	log.Infof("+fluxv2 CreateInstalledPackage [%v]", request)
@@ -392,6 +579,16 @@ func (s *Server) CreateInstalledPackage(ctx context.Context, request *connect.Re
 
 	name := types.NamespacedName{Name: request.Msg.Name, Namespace: request.Msg.TargetContext.Namespace}
 
+	customDetail, err := installedPackageCustomDetailFromAny(request.Msg.CustomDetail)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := request.Msg.GetMetadata()
+	if err := resources.ValidateInstalledPackageMetadata(metadata.GetLabels(), metadata.GetAnnotations(), s.config().InstalledPackageMetadata); err != nil {
+		return nil, err
+	}
+
 	if installedRef, err := s.newRelease(
 		ctx,
 		request.Header(),
@@ -399,7 +596,10 @@ func (s *Server) CreateInstalledPackage(ctx context.Context, request *connect.Re
 		name,
 		request.Msg.PkgVersionReference,
 		request.Msg.ReconciliationOptions,
-		request.Msg.Values); err != nil {
+		request.Msg.Values,
+		customDetail,
+		metadata,
+		request.Msg.GetCreateNamespace()); err != nil {
 		return nil, err
 	} else {
 		return connect.NewResponse(&corev1.CreateInstalledPackageResponse{
@@ -422,13 +622,26 @@ func (s *Server) UpdateInstalledPackage(ctx context.Context, request *connect.Re
 		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("Not supported yet: request.installedPackageRef.Context.Cluster: [%v]", cluster))
 	}
 
+	customDetail, err := installedPackageCustomDetailFromAny(request.Msg.CustomDetail)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := request.Msg.GetMetadata()
+	if err := resources.ValidateInstalledPackageMetadata(metadata.GetLabels(), metadata.GetAnnotations(), s.config().InstalledPackageMetadata); err != nil {
+		return nil, err
+	}
+
 	if installedRef, err := s.updateRelease(
 		ctx,
 		request.Header(),
 		installedPackageRef,
 		request.Msg.PkgVersionReference,
 		request.Msg.ReconciliationOptions,
-		request.Msg.Values); err != nil {
+		request.Msg.Values,
+		request.Msg.ReuseValues,
+		customDetail,
+		metadata); err != nil {
 		return nil, err
 	} else {
 		return connect.NewResponse(&corev1.UpdateInstalledPackageResponse{
@@ -494,6 +707,14 @@ func (s *Server) GetInstalledPackageResourceRefs(ctx context.Context, request *c
 	}
 }
 
+// GetInstalledPackageOperationStatus returns the status of the HelmRelease of an installed package managed by the 'fluxv2' plugin
+func (s *Server) GetInstalledPackageOperationStatus(ctx context.Context, request *connect.Request[corev1.GetInstalledPackageOperationStatusRequest]) (*connect.Response[corev1.GetInstalledPackageOperationStatusResponse], error) {
+	log.Infof("+fluxv2 GetInstalledPackageOperationStatus [%v]", request)
+	defer log.Info("-fluxv2 GetInstalledPackageOperationStatus")
+
+	return pkgutils.GetInstalledPackageOperationStatus(ctx, request, s.GetInstalledPackageDetail)
+}
+
 func (s *Server) AddPackageRepository(ctx context.Context, request *connect.Request[corev1.AddPackageRepositoryRequest]) (*connect.Response[corev1.AddPackageRepositoryResponse], error) {
 	if request == nil {
 		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("No request provided"))
@@ -598,10 +819,12 @@ func (s *Server) DeletePackageRepository(ctx context.Context, request *connect.R
 		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("Not supported yet: request.packageRepoRef.Context.Cluster: [%v]", cluster))
 	}
 
-	if err := s.deleteRepo(ctx, request.Header(), repoRef); err != nil {
+	if retainedSecrets, err := s.deleteRepo(ctx, request.Header(), repoRef, request.Msg.GetRetainReferencedSecrets()); err != nil {
 		return nil, err
 	} else {
-		return connect.NewResponse(&corev1.DeletePackageRepositoryResponse{}), nil
+		return connect.NewResponse(&corev1.DeletePackageRepositoryResponse{
+			RetainedSecrets: retainedSecrets,
+		}), nil
 	}
 }
 
@@ -642,6 +865,92 @@ func (s *Server) GetPackageRepositoryPermissions(ctx context.Context, request *c
 	}), nil
 }
 
+// TestPackageRepositoryConnection checks that a package repository can be reached
+// with the given details, without creating it.
+func (s *Server) TestPackageRepositoryConnection(ctx context.Context, request *connect.Request[corev1.TestPackageRepositoryConnectionRequest]) (*connect.Response[corev1.TestPackageRepositoryConnectionResponse], error) {
+	log.Infof("+fluxv2 TestPackageRepositoryConnection [%v]", request.Msg.GetPackageRepository().GetUrl())
+	defer log.Info("-fluxv2 TestPackageRepositoryConnection")
+
+	response, err := s.testRepoConnection(ctx, request.Header(), request.Msg.GetPackageRepository())
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(response), nil
+}
+
+// ListPackageRepositoryPackages returns the charts of a single package
+// repository managed by the 'fluxv2' plugin
+func (s *Server) ListPackageRepositoryPackages(ctx context.Context, request *connect.Request[corev1.ListPackageRepositoryPackagesRequest]) (*connect.Response[corev1.ListPackageRepositoryPackagesResponse], error) {
+	log.Infof("+fluxv2 ListPackageRepositoryPackages [%v]", request)
+	defer log.Info("-fluxv2 ListPackageRepositoryPackages")
+	if request == nil || request.Msg.PackageRepoRef == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("No request PackageRepoRef provided"))
+	}
+
+	repoRef := request.Msg.PackageRepoRef
+	// flux CRDs require a namespace, cluster-wide resources are not supported
+	if repoRef.Context == nil || len(repoRef.Context.Namespace) == 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("PackageRepositoryReference is missing required namespace"))
+	}
+
+	cluster := repoRef.Context.Cluster
+	if cluster != "" && cluster != s.kubeappsCluster {
+		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("Not supported yet: request.PackageRepoRef.Context.Cluster: [%v]", cluster))
+	}
+
+	itemOffset, err := paginate.ItemOffsetFromPageToken(request.Msg.GetPaginationOptions().GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+
+	key := types.NamespacedName{Namespace: repoRef.Context.Namespace, Name: repoRef.Identifier}
+	repo, err := s.getRepoInCluster(ctx, request.Header(), key)
+	if err != nil {
+		return nil, err
+	}
+
+	// the charts of a repository are only cached once it is ready
+	repoKeys, err := s.filterReadyReposByName([]sourcev1.HelmRepository{*repo}, nil)
+	if err != nil {
+		return nil, err
+	}
+	charts, err := s.getChartsForRepoKeys(repoKeys)
+	if err != nil {
+		return nil, err
+	}
+	charts = s.filterChartsByRepoPolicies([]sourcev1.HelmRepository{*repo}, charts)
+
+	pageSize := request.Msg.GetPaginationOptions().GetPageSize()
+	packageSummaries, err := filterAndPaginateCharts(nil, pageSize, itemOffset, charts)
+	if err != nil {
+		return nil, err
+	}
+	for _, summary := range packageSummaries {
+		summary.AvailablePackageRef.Context.Cluster = s.kubeappsCluster
+	}
+
+	// Only return a next page token if the request was for pagination and
+	// the results are a full page.
+	nextPageToken := ""
+	if pageSize > 0 && len(packageSummaries) == int(pageSize) {
+		nextPageToken = fmt.Sprintf("%d", itemOffset+int(pageSize))
+	}
+
+	totalCount := 0
+	for _, repoCharts := range charts {
+		totalCount += len(repoCharts)
+	}
+	response := &corev1.ListPackageRepositoryPackagesResponse{
+		AvailablePackageSummaries: packageSummaries,
+		NextPageToken:             nextPageToken,
+		TotalCount:                proto.Int32(int32(totalCount)),
+	}
+	if artifact := repo.GetArtifact(); artifact != nil && !artifact.LastUpdateTime.IsZero() {
+		response.LastUpdated = artifact.LastUpdateTime.UTC().Format(time.RFC3339)
+	}
+	return connect.NewResponse(response), nil
+}
+
 // makes the server look like a repo event sink. Facilitates code reuse between
 // use cases when something happens in background as a result of a watch event,
 // aka an "out-of-band" interaction and use cases when the user wants something
@@ -668,6 +977,7 @@ func (s *Server) newRepoEventSink() repoEventSink {
 	return repoEventSink{
 		clientGetter: cg,
 		chartCache:   s.chartCache,
+		caBundle:     s.caBundle,
 	}
 }
 
@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -43,6 +45,55 @@ import (
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+func TestReloadPluginConfig(t *testing.T) {
+	testCases := []struct {
+		name                   string
+		pluginJSONConf         string
+		expectedErrorStr       string
+		expectedTimeoutSeconds int32
+	}{
+		{
+			name:                   "it replaces the config in effect",
+			pluginJSONConf:         `{"core": {"packages": {"v1alpha1": {"timeoutSeconds": 60}}}}`,
+			expectedTimeoutSeconds: 60,
+		},
+		{
+			name:                   "it keeps the config in effect when the config is invalid",
+			pluginJSONConf:         `{"core": `,
+			expectedErrorStr:       "unable to unmarshal",
+			expectedTimeoutSeconds: common.NewDefaultPluginConfig().TimeoutSeconds,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			filename := filepath.Join(t.TempDir(), "plugin_json_conf")
+			if err := os.WriteFile(filename, []byte(tc.pluginJSONConf), 0600); err != nil {
+				t.Fatalf("%+v", err)
+			}
+			s := Server{pluginConfig: common.NewDefaultPluginConfig()}
+
+			effectiveConfig, err := s.reloadPluginConfig(filename)
+
+			if tc.expectedErrorStr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedErrorStr) {
+					t.Fatalf("err got %v, want to find %q", err, tc.expectedErrorStr)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("%+v", err)
+				}
+				if got, want := effectiveConfig, s.config(); got != want {
+					t.Errorf("got: %v, want: %v", got, want)
+				}
+			}
+			if got, want := s.config().TimeoutSeconds, tc.expectedTimeoutSeconds; got != want {
+				t.Errorf("got: %d, want: %d", got, want)
+			}
+		})
+	}
+}
+
 func TestGetAvailablePackagesStatus(t *testing.T) {
 	testCases := []struct {
 		name       string
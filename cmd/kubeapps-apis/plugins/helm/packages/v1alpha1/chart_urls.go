@@ -0,0 +1,138 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/bufbuild/connect-go"
+	appRepov1 "github.com/vmware-tanzu/kubeapps/cmd/apprepository-controller/pkg/apis/apprepository/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/helm/packages/v1alpha1/utils"
+	"helm.sh/helm/v3/pkg/chart"
+	log "k8s.io/klog/v2"
+)
+
+// chartURLIdentifierPrefix prefixes the identifier of an available package
+// referring to a chart tarball by its https URL rather than to a chart of a
+// registered repository. The expected digest of the tarball can be set as the
+// fragment of the URL, such as:
+//
+//	url:https://charts.example.com/apache-1.0.0.tgz#sha256:<hex digest>
+const chartURLIdentifierPrefix = "url:"
+
+// chartURLDigestRegex matches the digests supported for chart tarballs.
+var chartURLDigestRegex = regexp.MustCompile(`^sha256:[a-fA-F0-9]{64}$`)
+
+// chartURLReference is the tarball URL and optional digest of a chart
+// referenced by an available package identifier.
+type chartURLReference struct {
+	url    string
+	digest string
+}
+
+// isChartURLIdentifier returns whether the available package identifier
+// refers to a chart tarball URL.
+func isChartURLIdentifier(identifier string) bool {
+	return strings.HasPrefix(identifier, chartURLIdentifierPrefix)
+}
+
+// parseChartURLIdentifier returns the tarball URL and digest of the chart
+// referenced by the available package identifier.
+func parseChartURLIdentifier(identifier string) (*chartURLReference, error) {
+	chartURL, err := url.Parse(strings.TrimPrefix(identifier, chartURLIdentifierPrefix))
+	if err != nil {
+		return nil, fmt.Errorf("invalid chart URL in the identifier %q: %w", identifier, err)
+	}
+	if chartURL.Scheme != "https" || chartURL.Host == "" {
+		return nil, fmt.Errorf("the chart URL in the identifier %q must be an absolute https URL", identifier)
+	}
+	if chartURL.User != nil {
+		return nil, fmt.Errorf("the chart URL in the identifier %q must not include credentials", identifier)
+	}
+	digest := chartURL.Fragment
+	if digest != "" && !chartURLDigestRegex.MatchString(digest) {
+		return nil, fmt.Errorf("invalid digest %q in the identifier %q, expected sha256:<hex digest>", digest, identifier)
+	}
+	chartURL.Fragment = ""
+	return &chartURLReference{
+		url:    chartURL.String(),
+		digest: digest,
+	}, nil
+}
+
+// fetchChartFromURL downloads the chart tarball referenced by the available
+// package identifier, verifying it against its digest when provided. Only
+// tarballs served by the hosts allowed in the plugin config, and not exceeding
+// its maximum size, can be fetched.
+func (s *Server) fetchChartFromURL(ctx context.Context, identifier string) (*chart.Chart, error) {
+	ref, err := parseChartURLIdentifier(identifier)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if err := s.checkChartURLHostAllowed(ref.url); err != nil {
+		return nil, err
+	}
+
+	userAgentString := fmt.Sprintf("%s/%s/%s/%s", UserAgentPrefix, pluginDetail.Name, pluginDetail.Version, version)
+	log.InfoS("Fetching chart from URL with user-agent", "url", ref.url, "userAgentString", userAgentString)
+
+	// The chart client expects a repository, so the tarball is fetched as if
+	// it was served by an anonymous helm repository without custom CA.
+	appRepo := &appRepov1.AppRepository{
+		Spec: appRepov1.AppRepositorySpec{
+			Type: "helm",
+			URL:  ref.url,
+		},
+	}
+	ch, err := utils.GetChart(
+		ctx,
+		&utils.ChartDetails{
+			TarballURL:     ref.url,
+			TarballDigest:  ref.digest,
+			TarballMaxSize: s.config().MaxChartURLSizeBytes,
+		},
+		appRepo,
+		nil, nil,
+		s.chartClientFactory.New(appRepo.Spec.Type, userAgentString),
+	)
+	if errors.Is(err, utils.ErrTarballDigestMismatch) || errors.Is(err, utils.ErrTarballTooLarge) {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("Unable to fetch the chart from %q: %w", ref.url, err))
+	} else if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to fetch the chart from %q: %w", ref.url, err))
+	}
+	return ch, nil
+}
+
+// recordChartURLIdentifier annotates the chart with the identifier of the
+// tarball URL it was fetched from. The annotation is stored with the release,
+// as a chart installed from its URL does not match any available package.
+func recordChartURLIdentifier(ch *chart.Chart, identifier string) {
+	if ch.Metadata == nil {
+		ch.Metadata = &chart.Metadata{}
+	}
+	if ch.Metadata.Annotations == nil {
+		ch.Metadata.Annotations = map[string]string{}
+	}
+	ch.Metadata.Annotations[ChartURLAnnotation] = identifier
+}
+
+// checkChartURLHostAllowed returns a PermissionDenied error unless the host of
+// the chart URL is one of the hosts allowed in the plugin config.
+func (s *Server) checkChartURLHostAllowed(chartURL string) error {
+	parsedURL, err := url.Parse(chartURL)
+	if err != nil {
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	for _, host := range s.config().AllowedChartURLHosts {
+		if strings.EqualFold(host, parsedURL.Host) {
+			return nil
+		}
+	}
+	return connect.NewError(connect.CodePermissionDenied, fmt.Errorf("Installing charts from the host %q is not allowed", parsedURL.Host))
+}
@@ -6,29 +6,116 @@ package common
 import (
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"os"
 
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/netrestrict"
 	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/pkgutils"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/resources"
 )
 
 const (
 	DefaultTimeoutSeconds           int32 = 300
 	DefaultGlobalPackagingNamespace       = ""
+	// DefaultReleaseNameTemplate is used to generate the name of a release
+	// when none is provided on creation.
+	DefaultReleaseNameTemplate = "{{ .PackageName }}-{{ .RandomSuffix }}"
+	// DefaultVulnerabilityScannerTimeoutSeconds bounds each request to the
+	// vulnerability scanner when no timeout is configured.
+	DefaultVulnerabilityScannerTimeoutSeconds int32 = 5
+	// DefaultMaxChartURLSizeBytes bounds the size of the chart tarballs
+	// installed given their URL when no maximum size is configured.
+	DefaultMaxChartURLSizeBytes int64 = 20 * 1024 * 1024
 )
 
+// ReleaseNameConflictStrategy determines what happens when the name of a
+// release being created is already used in its namespace.
+type ReleaseNameConflictStrategy string
+
+const (
+	// ReleaseNameConflictError rejects the creation with an AlreadyExists
+	// error.
+	ReleaseNameConflictError ReleaseNameConflictStrategy = "error"
+	// ReleaseNameConflictAutoSuffix appends a random suffix to the name until
+	// it is available.
+	ReleaseNameConflictAutoSuffix ReleaseNameConflictStrategy = "autoSuffix"
+)
+
+// UnmarshalJSON reads a release name conflict strategy, an empty one meaning
+// error.
+func (s *ReleaseNameConflictStrategy) UnmarshalJSON(data []byte) error {
+	var strategy string
+	if err := json.Unmarshal(data, &strategy); err != nil {
+		return err
+	}
+	switch ReleaseNameConflictStrategy(strategy) {
+	case "", ReleaseNameConflictError:
+		*s = ReleaseNameConflictError
+	case ReleaseNameConflictAutoSuffix:
+		*s = ReleaseNameConflictAutoSuffix
+	default:
+		return fmt.Errorf("unsupported release name conflict strategy %q, expected one of %q or %q", strategy, ReleaseNameConflictError, ReleaseNameConflictAutoSuffix)
+	}
+	return nil
+}
+
 type HelmPluginConfig struct {
-	VersionsInSummary        pkgutils.VersionsInSummary
-	TimeoutSeconds           int32
-	GlobalPackagingNamespace string
+	VersionsInSummary        pkgutils.VersionsInSummary `json:"versionsInSummary"`
+	TimeoutSeconds           int32                      `json:"timeoutSeconds"`
+	GlobalPackagingNamespace string                     `json:"globalPackagingNamespace"`
+	ReleaseNameTemplate      string                     `json:"releaseNameTemplate"`
+	// ReleaseNameConflictStrategy determines what happens when the name of a
+	// release being created is already used, unless overridden in the request.
+	ReleaseNameConflictStrategy ReleaseNameConflictStrategy `json:"releaseNameConflictStrategy"`
+	// AllowedChartURLHosts are the hosts from which a chart can be installed
+	// given the URL of its tarball. No chart can be installed from a URL if empty.
+	AllowedChartURLHosts []string `json:"allowedChartURLHosts"`
+	// MaxChartURLSizeBytes is the maximum size of a chart tarball installed
+	// given its URL.
+	MaxChartURLSizeBytes int64 `json:"maxChartURLSizeBytes"`
+	// RepositoryConnectionAllowedNetworks are the networks, in CIDR notation,
+	// which the connection tests of the repositories can reach despite being
+	// blocked by default, such as the loopback addresses.
+	RepositoryConnectionAllowedNetworks []string `json:"repositoryConnectionAllowedNetworks"`
+	// OCICredentialProviderAllowedRegistries are the hosts of the registries
+	// whose credentials the global OCI repositories can obtain with the cloud
+	// identity of the plugin. No repository can use a credential provider if
+	// empty.
+	OCICredentialProviderAllowedRegistries []string `json:"ociCredentialProviderAllowedRegistries"`
+	// NamespaceCreation are the labels and annotations of the target
+	// namespaces created when installing a package.
+	NamespaceCreation resources.NamespaceCreationOptions `json:"namespaceCreation"`
+	// CapacityCheck determines whether the capacity required by a chart is
+	// checked against the quota left in the target namespace.
+	CapacityCheck resources.CapacityCheckMode `json:"capacityCheck"`
+	// VulnerabilityScanner is the external service summarizing the
+	// vulnerabilities of a chart version in its available package detail.
+	VulnerabilityScanner VulnerabilityScannerConfig `json:"vulnerabilityScanner"`
+}
+
+// VulnerabilityScannerConfig configures the external service summarizing the
+// vulnerabilities of the charts.
+type VulnerabilityScannerConfig struct {
+	// URL receives a POST request with the digest of a chart version and the
+	// references of its images. No chart is scanned if empty.
+	URL string `json:"url"`
+	// TimeoutSeconds bounds each request to the scanner.
+	TimeoutSeconds int32 `json:"timeoutSeconds"`
 }
 
 func NewDefaultPluginConfig() *HelmPluginConfig {
 	// If no config is provided, we default to the existing values for backwards
 	// compatibility.
 	return &HelmPluginConfig{
-		VersionsInSummary:        pkgutils.GetDefaultVersionsInSummary(),
-		TimeoutSeconds:           DefaultTimeoutSeconds,
-		GlobalPackagingNamespace: DefaultGlobalPackagingNamespace,
+		VersionsInSummary:           pkgutils.GetDefaultVersionsInSummary(),
+		TimeoutSeconds:              DefaultTimeoutSeconds,
+		GlobalPackagingNamespace:    DefaultGlobalPackagingNamespace,
+		ReleaseNameTemplate:         DefaultReleaseNameTemplate,
+		ReleaseNameConflictStrategy: ReleaseNameConflictError,
+		MaxChartURLSizeBytes:        DefaultMaxChartURLSizeBytes,
+		VulnerabilityScanner: VulnerabilityScannerConfig{
+			TimeoutSeconds: DefaultVulnerabilityScannerTimeoutSeconds,
+		},
 	}
 }
 
@@ -46,7 +133,9 @@ func ParsePluginConfig(pluginConfigPath string) (*HelmPluginConfig, error) {
 				Packages struct {
 					V1alpha1 struct {
 						VersionsInSummary pkgutils.VersionsInSummary
-						TimeoutSeconds    int32 `json:"timeoutSeconds"`
+						TimeoutSeconds    int32                              `json:"timeoutSeconds"`
+						NamespaceCreation resources.NamespaceCreationOptions `json:"namespaceCreation"`
+						CapacityCheck     resources.CapacityCheckMode        `json:"capacityCheck"`
 					} `json:"v1alpha1"`
 				} `json:"packages"`
 			} `json:"core"`
@@ -54,7 +143,14 @@ func ParsePluginConfig(pluginConfigPath string) (*HelmPluginConfig, error) {
 			Helm struct {
 				Packages struct {
 					V1alpha1 struct {
-						GlobalPackagingNamespace string `json:"globalPackagingNamespace"`
+						GlobalPackagingNamespace               string                      `json:"globalPackagingNamespace"`
+						ReleaseNameTemplate                    string                      `json:"releaseNameTemplate"`
+						ReleaseNameConflictStrategy            ReleaseNameConflictStrategy `json:"releaseNameConflictStrategy"`
+						AllowedChartURLHosts                   []string                    `json:"allowedChartURLHosts"`
+						MaxChartURLSizeBytes                   int64                       `json:"maxChartURLSizeBytes"`
+						RepositoryConnectionAllowedNetworks    []string                    `json:"repositoryConnectionAllowedNetworks"`
+						OCICredentialProviderAllowedRegistries []string                    `json:"ociCredentialProviderAllowedRegistries"`
+						VulnerabilityScanner                   VulnerabilityScannerConfig  `json:"vulnerabilityScanner"`
 					} `json:"v1alpha1"`
 				} `json:"packages"`
 			} `json:"helm"`
@@ -72,10 +168,49 @@ func ParsePluginConfig(pluginConfigPath string) (*HelmPluginConfig, error) {
 		return nil, fmt.Errorf("unable to unmarshal pluginconfig: %q error: %w", string(pluginConfig), err)
 	}
 
+	vulnerabilityScanner := config.Helm.Packages.V1alpha1.VulnerabilityScanner
+	if vulnerabilityScanner.URL != "" {
+		scannerURL, err := url.Parse(vulnerabilityScanner.URL)
+		if err != nil || (scannerURL.Scheme != "http" && scannerURL.Scheme != "https") || scannerURL.Host == "" {
+			return nil, fmt.Errorf("invalid vulnerabilityScanner url %q, expected an absolute http or https URL", vulnerabilityScanner.URL)
+		}
+	}
+	if vulnerabilityScanner.TimeoutSeconds <= 0 {
+		vulnerabilityScanner.TimeoutSeconds = DefaultVulnerabilityScannerTimeoutSeconds
+	}
+
+	if err := netrestrict.ValidateNetworks(config.Helm.Packages.V1alpha1.RepositoryConnectionAllowedNetworks); err != nil {
+		return nil, fmt.Errorf("invalid repositoryConnectionAllowedNetworks: %w", err)
+	}
+
+	maxChartURLSizeBytes := config.Helm.Packages.V1alpha1.MaxChartURLSizeBytes
+	if maxChartURLSizeBytes <= 0 {
+		maxChartURLSizeBytes = DefaultMaxChartURLSizeBytes
+	}
+
+	releaseNameTemplate := config.Helm.Packages.V1alpha1.ReleaseNameTemplate
+	if releaseNameTemplate == "" {
+		releaseNameTemplate = DefaultReleaseNameTemplate
+	}
+
+	releaseNameConflictStrategy := config.Helm.Packages.V1alpha1.ReleaseNameConflictStrategy
+	if releaseNameConflictStrategy == "" {
+		releaseNameConflictStrategy = ReleaseNameConflictError
+	}
+
 	// return configured value
 	return &HelmPluginConfig{
-		VersionsInSummary:        config.Core.Packages.V1alpha1.VersionsInSummary,
-		TimeoutSeconds:           config.Core.Packages.V1alpha1.TimeoutSeconds,
-		GlobalPackagingNamespace: config.Helm.Packages.V1alpha1.GlobalPackagingNamespace,
+		VersionsInSummary:                      config.Core.Packages.V1alpha1.VersionsInSummary,
+		TimeoutSeconds:                         config.Core.Packages.V1alpha1.TimeoutSeconds,
+		GlobalPackagingNamespace:               config.Helm.Packages.V1alpha1.GlobalPackagingNamespace,
+		ReleaseNameTemplate:                    releaseNameTemplate,
+		ReleaseNameConflictStrategy:            releaseNameConflictStrategy,
+		AllowedChartURLHosts:                   config.Helm.Packages.V1alpha1.AllowedChartURLHosts,
+		MaxChartURLSizeBytes:                   maxChartURLSizeBytes,
+		RepositoryConnectionAllowedNetworks:    config.Helm.Packages.V1alpha1.RepositoryConnectionAllowedNetworks,
+		OCICredentialProviderAllowedRegistries: config.Helm.Packages.V1alpha1.OCICredentialProviderAllowedRegistries,
+		NamespaceCreation:                      config.Core.Packages.V1alpha1.NamespaceCreation,
+		CapacityCheck:                          config.Core.Packages.V1alpha1.CapacityCheck,
+		VulnerabilityScanner:                   vulnerabilityScanner,
 	}, nil
 }
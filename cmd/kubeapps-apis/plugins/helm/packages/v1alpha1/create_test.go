@@ -5,6 +5,9 @@ package main
 
 import (
 	"context"
+	"errors"
+	"net/http"
+	"regexp"
 	"testing"
 
 	"github.com/bufbuild/connect-go"
@@ -13,9 +16,15 @@ import (
 	"github.com/vmware-tanzu/kubeapps/cmd/apprepository-controller/pkg/apis/apprepository/v1alpha1"
 	corev1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
 	plugins "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/plugins/v1alpha1"
+	helmv1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/plugins/helm/packages/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/helm/packages/v1alpha1/common"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/pkgutils"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/resources"
+	"google.golang.org/protobuf/types/known/anypb"
 	"helm.sh/helm/v3/pkg/action"
 	"helm.sh/helm/v3/pkg/chart"
 	"helm.sh/helm/v3/pkg/release"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -27,6 +36,8 @@ func TestCreateInstalledPackage(t *testing.T) {
 		expectedResponse   *corev1.CreateInstalledPackageResponse
 		expectedStatusCode connect.Code
 		expectedRelease    *release.Release
+		allowedNamespaces  []string
+		repoAnnotations    map[string]string
 	}{
 		{
 			name: "creates the installed package from repo without credentials",
@@ -79,6 +90,87 @@ func TestCreateInstalledPackage(t *testing.T) {
 				Namespace: "default",
 			},
 		},
+		{
+			name: "creates the installed package from a repo allowing the target namespace",
+			releaseStub: releaseStub{
+				chartID:       "bitnami/apache",
+				latestVersion: "1.18.3",
+			},
+			allowedNamespaces: []string{"default"},
+			request: &corev1.CreateInstalledPackageRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context: &corev1.Context{
+						Namespace: globalPackagingNamespace,
+					},
+					Identifier: "bitnami/apache",
+				},
+				TargetContext: &corev1.Context{
+					Namespace: "default",
+				},
+				Name: "my-apache",
+				PkgVersionReference: &corev1.VersionReference{
+					Version: "1.18.3",
+				},
+			},
+			expectedResponse: &corev1.CreateInstalledPackageResponse{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context: &corev1.Context{
+						Cluster:   "default",
+						Namespace: "default",
+					},
+					Identifier: "my-apache",
+					Plugin:     GetPluginDetail(),
+				},
+			},
+		},
+		{
+			name: "returns permission denied if the repo does not allow the target namespace",
+			releaseStub: releaseStub{
+				chartID:       "bitnami/apache",
+				latestVersion: "1.18.3",
+			},
+			allowedNamespaces: []string{"team-a"},
+			request: &corev1.CreateInstalledPackageRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context: &corev1.Context{
+						Namespace: globalPackagingNamespace,
+					},
+					Identifier: "bitnami/apache",
+				},
+				TargetContext: &corev1.Context{
+					Namespace: "default",
+				},
+				Name: "my-apache",
+				PkgVersionReference: &corev1.VersionReference{
+					Version: "1.18.3",
+				},
+			},
+			expectedStatusCode: connect.CodePermissionDenied,
+		},
+		{
+			name: "returns failed precondition if the repo policy does not allow the package",
+			releaseStub: releaseStub{
+				chartID:       "bitnami/apache",
+				latestVersion: "1.18.3",
+			},
+			repoAnnotations: map[string]string{pkgutils.AnnotationDeniedPackagesKey: "apa*"},
+			request: &corev1.CreateInstalledPackageRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context: &corev1.Context{
+						Namespace: globalPackagingNamespace,
+					},
+					Identifier: "bitnami/apache",
+				},
+				TargetContext: &corev1.Context{
+					Namespace: "default",
+				},
+				Name: "my-apache",
+				PkgVersionReference: &corev1.VersionReference{
+					Version: "1.18.3",
+				},
+			},
+			expectedStatusCode: connect.CodeFailedPrecondition,
+		},
 		{
 			name: "returns invalid if available package ref invalid",
 			request: &corev1.CreateInstalledPackageRequest{
@@ -107,8 +199,12 @@ func TestCreateInstalledPackage(t *testing.T) {
 			actionConfig := newActionConfigFixture(t, tc.request.GetTargetContext().GetNamespace(), nil, nil)
 			server, mockDB, cleanup := makeServer(t, authorized, actionConfig, &v1alpha1.AppRepository{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:      "bitnami",
-					Namespace: globalPackagingNamespace,
+					Name:        "bitnami",
+					Namespace:   globalPackagingNamespace,
+					Annotations: tc.repoAnnotations,
+				},
+				Spec: v1alpha1.AppRepositorySpec{
+					AllowedNamespaces: tc.allowedNamespaces,
 				},
 			})
 			defer cleanup()
@@ -229,3 +325,542 @@ func TestTimeoutCreateInstalledPackage(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateInstalledPackageGeneratesName(t *testing.T) {
+	request := &corev1.CreateInstalledPackageRequest{
+		AvailablePackageRef: &corev1.AvailablePackageReference{
+			Context: &corev1.Context{
+				Namespace: globalPackagingNamespace,
+			},
+			Identifier: "bitnami/apache",
+		},
+		TargetContext: &corev1.Context{
+			Namespace: "default",
+		},
+		PkgVersionReference: &corev1.VersionReference{
+			Version: "1.18.3",
+		},
+	}
+	actionConfig := newActionConfigFixture(t, request.GetTargetContext().GetNamespace(), nil, nil)
+	server, mockDB, cleanup := makeServer(t, true, actionConfig, &v1alpha1.AppRepository{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "bitnami",
+			Namespace: globalPackagingNamespace,
+		},
+	})
+	defer cleanup()
+	populateAssetDB(t, mockDB, []releaseStub{{chartID: "bitnami/apache", latestVersion: "1.18.3"}})
+
+	response, err := server.CreateInstalledPackage(context.Background(), connect.NewRequest(request))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	if got, want := response.Msg.GetInstalledPackageRef().GetIdentifier(), regexp.MustCompile(`^apache-[a-z0-9]{5}$`); !want.MatchString(got) {
+		t.Errorf("got: %q, want a name matching %q", got, want)
+	}
+}
+
+func TestCreateInstalledPackageResolvesVersionRange(t *testing.T) {
+	testCases := []struct {
+		name               string
+		version            string
+		expectedVersion    string
+		expectedStatusCode connect.Code
+	}{
+		{
+			name:            "it installs the highest version satisfying the range",
+			version:         ">=1.17 <1.18",
+			expectedVersion: "1.17.2",
+		},
+		{
+			name:            "it installs the highest version satisfying a tilde range",
+			version:         "~1.18.0",
+			expectedVersion: "1.18.3",
+		},
+		{
+			name:               "it returns not found when no version satisfies the range",
+			version:            "^2",
+			expectedStatusCode: connect.CodeNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			request := &corev1.CreateInstalledPackageRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context: &corev1.Context{
+						Namespace: globalPackagingNamespace,
+					},
+					Identifier: "bitnami/apache",
+				},
+				TargetContext: &corev1.Context{
+					Namespace: "default",
+				},
+				Name: "my-apache",
+				PkgVersionReference: &corev1.VersionReference{
+					Version: tc.version,
+				},
+			}
+			actionConfig := newActionConfigFixture(t, request.GetTargetContext().GetNamespace(), nil, nil)
+			server, mockDB, cleanup := makeServer(t, true, actionConfig, &v1alpha1.AppRepository{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "bitnami",
+					Namespace: globalPackagingNamespace,
+				},
+			})
+			defer cleanup()
+			// The versions of the chart are queried to resolve the range, then
+			// the resolved version is fetched.
+			stub := releaseStub{chartID: "bitnami/apache", latestVersion: "1.18.3", chartVersion: "1.17.2"}
+			populateAssetDB(t, mockDB, []releaseStub{stub, stub})
+
+			_, err := server.CreateInstalledPackage(context.Background(), connect.NewRequest(request))
+			if got, want := connect.CodeOf(err), tc.expectedStatusCode; err != nil && got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+			if tc.expectedStatusCode != 0 {
+				return
+			}
+
+			releases, err := actionConfig.Releases.Driver.List(func(*release.Release) bool { return true })
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if got, want := len(releases), 1; got != want {
+				t.Fatalf("got: %d, want: %d", got, want)
+			}
+			if got, want := releases[0].Chart.Metadata.Version, tc.expectedVersion; got != want {
+				t.Errorf("got: %q, want: %q", got, want)
+			}
+		})
+	}
+}
+
+func TestGenerateReleaseName(t *testing.T) {
+	testCases := []struct {
+		name                string
+		releaseNameTemplate string
+		strategy            common.ReleaseNameConflictStrategy
+		existingReleases    []releaseStub
+		expectedName        *regexp.Regexp
+		expectedStatusCode  connect.Code
+	}{
+		{
+			name:                "it generates a name with a random suffix by default",
+			releaseNameTemplate: common.DefaultReleaseNameTemplate,
+			expectedName:        regexp.MustCompile(`^apache-[a-z0-9]{5}$`),
+		},
+		{
+			name:                "it generates a name from a custom template",
+			releaseNameTemplate: "web-{{ .PackageName }}",
+			expectedName:        regexp.MustCompile(`^web-apache$`),
+		},
+		{
+			name:                "it returns already exists if a template without a random part collides",
+			releaseNameTemplate: "{{ .PackageName }}",
+			existingReleases: []releaseStub{
+				{name: "apache", namespace: "default", status: release.StatusDeployed},
+			},
+			expectedStatusCode: connect.CodeAlreadyExists,
+		},
+		{
+			name:                "it suffixes a template without a random part which collides with the auto-suffix strategy",
+			releaseNameTemplate: "{{ .PackageName }}",
+			strategy:            common.ReleaseNameConflictAutoSuffix,
+			existingReleases: []releaseStub{
+				{name: "apache", namespace: "default", status: release.StatusDeployed},
+			},
+			expectedName: regexp.MustCompile(`^apache-[a-z0-9]{5}$`),
+		},
+		{
+			name:                "it returns already exists if an uninstalled release kept the name",
+			releaseNameTemplate: "{{ .PackageName }}",
+			existingReleases: []releaseStub{
+				{name: "apache", namespace: "default", status: release.StatusUninstalled},
+			},
+			expectedStatusCode: connect.CodeAlreadyExists,
+		},
+		{
+			name:                "it returns invalid argument if the generated name is invalid",
+			releaseNameTemplate: "{{ .PackageName }}_{{ .RandomSuffix }}",
+			expectedStatusCode:  connect.CodeInvalidArgument,
+		},
+		{
+			name:                "it returns an internal error if the template uses unknown fields",
+			releaseNameTemplate: "{{ .Unknown }}",
+			expectedStatusCode:  connect.CodeInternal,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actionConfig := newActionConfigFixture(t, "default", tc.existingReleases, nil)
+			strategy := tc.strategy
+			if strategy == "" {
+				strategy = common.ReleaseNameConflictError
+			}
+
+			name, err := generateReleaseName(actionConfig, "apache", tc.releaseNameTemplate, strategy)
+
+			if got, want := connect.CodeOf(err), tc.expectedStatusCode; err != nil && got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+			if tc.expectedStatusCode != 0 {
+				if err == nil {
+					t.Fatalf("got: %q, want: error with code %+v", name, tc.expectedStatusCode)
+				}
+				return
+			}
+			if !tc.expectedName.MatchString(name) {
+				t.Errorf("got: %q, want a name matching %q", name, tc.expectedName)
+			}
+		})
+	}
+}
+
+func TestResolveReleaseName(t *testing.T) {
+	longName := "a-very-long-release-name-which-is-close-to-the-limit-x"[:maxReleaseNameLength]
+
+	testCases := []struct {
+		name               string
+		requestName        string
+		customDetail       *helmv1.CreateInstalledPackageCustomDataHelm
+		configStrategy     common.ReleaseNameConflictStrategy
+		existingReleases   []releaseStub
+		expectedName       *regexp.Regexp
+		expectedStatusCode connect.Code
+	}{
+		{
+			name:         "it returns the requested name when available",
+			requestName:  "my-apache",
+			expectedName: regexp.MustCompile(`^my-apache$`),
+		},
+		{
+			name:        "it returns already exists if the requested name is used with the error strategy",
+			requestName: "my-apache",
+			existingReleases: []releaseStub{
+				{name: "my-apache", namespace: "default", status: release.StatusDeployed},
+			},
+			expectedStatusCode: connect.CodeAlreadyExists,
+		},
+		{
+			name:           "it suffixes the requested name if used with the configured auto-suffix strategy",
+			requestName:    "my-apache",
+			configStrategy: common.ReleaseNameConflictAutoSuffix,
+			existingReleases: []releaseStub{
+				{name: "my-apache", namespace: "default", status: release.StatusDeployed},
+			},
+			expectedName: regexp.MustCompile(`^my-apache-[a-z0-9]{5}$`),
+		},
+		{
+			name:        "it suffixes the requested name if used with the requested auto-suffix strategy",
+			requestName: "my-apache",
+			customDetail: &helmv1.CreateInstalledPackageCustomDataHelm{
+				ReleaseNameConflictStrategy: helmv1.ReleaseNameConflictStrategy_RELEASE_NAME_CONFLICT_STRATEGY_AUTO_SUFFIX,
+			},
+			existingReleases: []releaseStub{
+				{name: "my-apache", namespace: "default", status: release.StatusDeployed},
+			},
+			expectedName: regexp.MustCompile(`^my-apache-[a-z0-9]{5}$`),
+		},
+		{
+			name:        "it returns already exists with the requested error strategy overriding the configured one",
+			requestName: "my-apache",
+			customDetail: &helmv1.CreateInstalledPackageCustomDataHelm{
+				ReleaseNameConflictStrategy: helmv1.ReleaseNameConflictStrategy_RELEASE_NAME_CONFLICT_STRATEGY_ERROR,
+			},
+			configStrategy: common.ReleaseNameConflictAutoSuffix,
+			existingReleases: []releaseStub{
+				{name: "my-apache", namespace: "default", status: release.StatusDeployed},
+			},
+			expectedStatusCode: connect.CodeAlreadyExists,
+		},
+		{
+			name:           "it truncates a long requested name to suffix it",
+			requestName:    longName,
+			configStrategy: common.ReleaseNameConflictAutoSuffix,
+			existingReleases: []releaseStub{
+				{name: longName, namespace: "default", status: release.StatusDeployed},
+			},
+			expectedName: regexp.MustCompile(`^a-very-long-release-name-which-is-close-to-the-[a-z0-9]{5}$`),
+		},
+		{
+			name: "it generates the name from the requested template",
+			customDetail: &helmv1.CreateInstalledPackageCustomDataHelm{
+				ReleaseNameTemplate: "team-{{ .PackageName }}",
+			},
+			expectedName: regexp.MustCompile(`^team-apache$`),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actionConfig := newActionConfigFixture(t, "default", tc.existingReleases, nil)
+			configStrategy := tc.configStrategy
+			if configStrategy == "" {
+				configStrategy = common.ReleaseNameConflictError
+			}
+			server := &Server{
+				pluginConfig: &common.HelmPluginConfig{
+					ReleaseNameTemplate:         common.DefaultReleaseNameTemplate,
+					ReleaseNameConflictStrategy: configStrategy,
+				},
+			}
+			request := &corev1.CreateInstalledPackageRequest{
+				TargetContext: &corev1.Context{Namespace: "default"},
+				Name:          tc.requestName,
+			}
+			if tc.customDetail != nil {
+				customDetail, err := anypb.New(tc.customDetail)
+				if err != nil {
+					t.Fatalf("%+v", err)
+				}
+				request.CustomDetail = customDetail
+			}
+
+			name, err := server.resolveReleaseName(actionConfig, request, "apache")
+
+			if got, want := connect.CodeOf(err), tc.expectedStatusCode; err != nil && got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+			if tc.expectedStatusCode != 0 {
+				if err == nil {
+					t.Fatalf("got: %q, want: error with code %+v", name, tc.expectedStatusCode)
+				}
+				return
+			}
+			if !tc.expectedName.MatchString(name) {
+				t.Errorf("got: %q, want a name matching %q", name, tc.expectedName)
+			}
+		})
+	}
+}
+
+func TestCheckInstalledPackageNameAvailability(t *testing.T) {
+	testCases := []struct {
+		name             string
+		existingReleases []releaseStub
+		releaseName      string
+		expectedResponse *corev1.CheckInstalledPackageNameAvailabilityResponse
+	}{
+		{
+			name:        "it reports an unused name as available",
+			releaseName: "my-apache",
+			existingReleases: []releaseStub{
+				{name: "other-apache", namespace: "default", status: release.StatusDeployed},
+			},
+			expectedResponse: &corev1.CheckInstalledPackageNameAvailabilityResponse{
+				Available: true,
+				Conflicts: []*corev1.InstalledPackageReference{},
+			},
+		},
+		{
+			name:        "it reports the release using the name",
+			releaseName: "my-apache",
+			existingReleases: []releaseStub{
+				{name: "my-apache", namespace: "default", status: release.StatusDeployed},
+			},
+			expectedResponse: &corev1.CheckInstalledPackageNameAvailabilityResponse{
+				Available: false,
+				Conflicts: []*corev1.InstalledPackageReference{
+					{
+						Context: &corev1.Context{
+							Cluster:   globalPackagingCluster,
+							Namespace: "default",
+						},
+						Identifier: "my-apache",
+						Plugin:     GetPluginDetail(),
+					},
+				},
+			},
+		},
+	}
+
+	ignoredUnexported := cmpopts.IgnoreUnexported(
+		corev1.CheckInstalledPackageNameAvailabilityResponse{},
+		corev1.InstalledPackageReference{},
+		corev1.Context{},
+		plugins.Plugin{},
+	)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actionConfig := newActionConfigFixture(t, "default", tc.existingReleases, nil)
+			server, _, cleanup := makeServer(t, true, actionConfig)
+			defer cleanup()
+
+			response, err := server.CheckInstalledPackageNameAvailability(context.Background(), connect.NewRequest(&corev1.CheckInstalledPackageNameAvailabilityRequest{
+				TargetContext: &corev1.Context{Namespace: "default"},
+				Name:          tc.releaseName,
+			}))
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+
+			if got, want := response.Msg, tc.expectedResponse; !cmp.Equal(got, want, ignoredUnexported) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoredUnexported))
+			}
+		})
+	}
+}
+
+func TestCreateInstalledPackageFromChartURL(t *testing.T) {
+	testCases := []struct {
+		name               string
+		identifier         string
+		allowedHosts       []string
+		expectedStatusCode connect.Code
+	}{
+		{
+			name:         "creates the installed package from a chart URL of an allowed host",
+			identifier:   "url:https://charts.example.com/apache-1.18.3.tgz#" + testChartDigest,
+			allowedHosts: []string{"charts.example.com"},
+		},
+		{
+			name:               "returns permission denied if the host of the chart URL is not allowed",
+			identifier:         "url:https://charts.example.com/apache-1.18.3.tgz",
+			expectedStatusCode: connect.CodePermissionDenied,
+		},
+		{
+			name:               "returns invalid argument if the chart URL is not https",
+			identifier:         "url:http://charts.example.com/apache-1.18.3.tgz",
+			allowedHosts:       []string{"charts.example.com"},
+			expectedStatusCode: connect.CodeInvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			request := &corev1.CreateInstalledPackageRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Identifier: tc.identifier,
+				},
+				TargetContext: &corev1.Context{
+					Namespace: "default",
+				},
+				Name: "my-apache",
+			}
+			actionConfig := newActionConfigFixture(t, request.GetTargetContext().GetNamespace(), nil, nil)
+			// No repository is needed to install a chart from its URL.
+			server, _, cleanup := makeServer(t, true, actionConfig)
+			defer cleanup()
+			server.pluginConfig.AllowedChartURLHosts = tc.allowedHosts
+
+			releasesCreated := 0
+			var releasedChart *chart.Chart
+			server.createReleaseFunc = func(config *action.Configuration, name string, namespace string, valueString string, ch *chart.Chart,
+				registrySecrets map[string]string, timeout int32) (*release.Release, error) {
+				releasesCreated++
+				releasedChart = ch
+				return &release.Release{Name: name, Namespace: namespace}, nil
+			}
+
+			response, err := server.CreateInstalledPackage(context.Background(), connect.NewRequest(request))
+			if got, want := connect.CodeOf(err), tc.expectedStatusCode; err != nil && got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+			if tc.expectedStatusCode != 0 {
+				if got, want := releasesCreated, 0; got != want {
+					t.Errorf("got: %d, want: %d", got, want)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+
+			if got, want := releasesCreated, 1; got != want {
+				t.Errorf("got: %d, want: %d", got, want)
+			}
+			if got, want := response.Msg.GetInstalledPackageRef().GetIdentifier(), "my-apache"; got != want {
+				t.Errorf("got: %q, want: %q", got, want)
+			}
+			// The chart URL is recorded so that the release can be upgraded.
+			if got, want := releasedChart.Metadata.Annotations[ChartURLAnnotation], tc.identifier; got != want {
+				t.Errorf("got: %q, want: %q", got, want)
+			}
+		})
+	}
+}
+
+func TestCreateInstalledPackageCreatesNamespace(t *testing.T) {
+	testCases := []struct {
+		name              string
+		createReleaseErr  error
+		expectedNamespace bool
+	}{
+		{
+			name:              "creates the target namespace with the configured labels",
+			expectedNamespace: true,
+		},
+		{
+			name:              "deletes the created namespace if the release cannot be created",
+			createReleaseErr:  errors.New("boom"),
+			expectedNamespace: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			request := &corev1.CreateInstalledPackageRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context: &corev1.Context{
+						Namespace: globalPackagingNamespace,
+					},
+					Identifier: "bitnami/apache",
+				},
+				TargetContext: &corev1.Context{
+					Namespace: "team-a",
+				},
+				Name: "my-apache",
+				PkgVersionReference: &corev1.VersionReference{
+					Version: "1.18.3",
+				},
+				CreateNamespace: true,
+			}
+			actionConfig := newActionConfigFixture(t, request.GetTargetContext().GetNamespace(), nil, nil)
+			server, mockDB, cleanup := makeServer(t, true, actionConfig, &v1alpha1.AppRepository{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "bitnami",
+					Namespace: globalPackagingNamespace,
+				},
+			})
+			defer cleanup()
+			populateAssetDB(t, mockDB, []releaseStub{{chartID: "bitnami/apache", latestVersion: "1.18.3"}})
+			server.pluginConfig.NamespaceCreation = resources.NamespaceCreationOptions{
+				Labels: map[string]string{"pod-security.kubernetes.io/enforce": "restricted"},
+			}
+			server.createReleaseFunc = func(config *action.Configuration, name string, namespace string, valueString string, ch *chart.Chart,
+				registrySecrets map[string]string, timeout int32) (*release.Release, error) {
+				if tc.createReleaseErr != nil {
+					return nil, tc.createReleaseErr
+				}
+				return &release.Release{Name: name, Namespace: namespace}, nil
+			}
+
+			_, err := server.CreateInstalledPackage(context.Background(), connect.NewRequest(request))
+			if got, want := err != nil, tc.createReleaseErr != nil; got != want {
+				t.Fatalf("got error: %v, want error: %t", err, want)
+			}
+
+			typedClient, err := server.clientGetter.Typed(http.Header{}, "")
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			namespace, err := typedClient.CoreV1().Namespaces().Get(context.Background(), "team-a", metav1.GetOptions{})
+			if !tc.expectedNamespace {
+				if !k8serrors.IsNotFound(err) {
+					t.Fatalf("got: %v, want: not found", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if got, want := namespace.Labels, server.pluginConfig.NamespaceCreation.Labels; !cmp.Equal(got, want) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
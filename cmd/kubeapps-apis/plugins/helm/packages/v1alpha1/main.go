@@ -35,9 +35,10 @@ func init() {
 //
 //nolint:deadcode
 func RegisterWithGRPCServer(opts pluginsv1alpha1.GRPCPluginRegistrationOptions) (interface{}, error) {
-	svr := NewServer(opts.ConfigGetter, opts.ClustersConfig.KubeappsClusterName, opts.ClustersConfig.GlobalPackagingNamespace, opts.ClientQPS, opts.ClientBurst, opts.PluginConfigPath)
-	opts.Mux.Handle(packagesConnect.NewHelmPackagesServiceHandler(svr))
-	opts.Mux.Handle(packagesConnect.NewHelmRepositoriesServiceHandler(svr))
+	svr := NewServer(opts.ConfigGetter, opts.ClustersConfig.KubeappsClusterName, opts.ClustersConfig.GlobalPackagingNamespace, opts.ClientQPS, opts.ClientBurst, opts.PluginConfigPath, opts.ClustersConfig.CABundleForCluster(opts.ClustersConfig.KubeappsClusterName))
+	opts.PluginConfigWatcher.Register(GetPluginDetail(), svr.config(), svr.reloadPluginConfig)
+	opts.Mux.Handle(packagesConnect.NewHelmPackagesServiceHandler(svr, opts.HandlerOptions...))
+	opts.Mux.Handle(packagesConnect.NewHelmRepositoriesServiceHandler(svr, opts.HandlerOptions...))
 	return svr, nil
 }
 
@@ -53,3 +54,8 @@ func RegisterHTTPHandlerFromEndpoint(ctx context.Context, mux *runtime.ServeMux,
 func GetPluginDetail() *pluginsgrpcv1alpha1.Plugin {
 	return &pluginDetail
 }
+
+// GetPluginBuildVersion returns the version the plugin was built from.
+func GetPluginBuildVersion() string {
+	return version
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/bufbuild/connect-go"
 	"github.com/vmware-tanzu/kubeapps/cmd/apprepository-controller/pkg/apis/apprepository"
@@ -14,9 +15,14 @@ import (
 	apprepov1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/apprepository-controller/pkg/apis/apprepository/v1alpha1"
 	corev1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
 	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/plugins/helm/packages/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/helm/packages/v1alpha1/utils"
 	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/connecterror"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/paginate"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/pkgutils"
 	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/resources"
 	"github.com/vmware-tanzu/kubeapps/pkg/helm"
+	"github.com/vmware-tanzu/kubeapps/pkg/ocicredentials"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
 	k8scorev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -55,12 +61,62 @@ const HTTP_PROXY = "HTTP_PROXY"
 const HTTPS_PROXY = "HTTPS_PROXY"
 const NO_PROXY = "NO_PROXY"
 
-func (s *Server) newRepo(ctx context.Context, headers http.Header, repo *HelmRepository) (*corev1.PackageRepositoryReference, error) {
+// validateRepoUrlAndType checks the url and type of a repository which is
+// about to be created.
+func (s *Server) validateRepoUrlAndType(repo *HelmRepository) error {
 	if repo.url == "" {
-		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Repository url may not be empty"))
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Repository url may not be empty"))
 	}
 	if repo.repoType == "" || !slices.Contains(ValidRepoTypes, repo.repoType) {
-		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Repository type [%s] not supported", repo.repoType))
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Repository type [%s] not supported", repo.repoType))
+	}
+	return s.validateOCICredentialProvider(repo.repoType, repo.name.Namespace, repo.url, repo.customDetail)
+}
+
+// validatePackagePatterns checks the allowed and denied packages patterns of
+// a repository.
+func validatePackagePatterns(customDetail *v1alpha1.HelmPackageRepositoryCustomDetail) error {
+	for _, patterns := range [][]string{customDetail.GetAllowedPackages(), customDetail.GetDeniedPackages()} {
+		if err := pkgutils.ValidatePackagePatterns(patterns); err != nil {
+			return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("The custom details are invalid: %w", err))
+		}
+	}
+	return nil
+}
+
+// validateOCICredentialProvider checks that the OCI credential provider of a
+// repository, if any, is supported, that the repository is an OCI one and
+// that it is allowed to use the provider in the plugin config.
+func (s *Server) validateOCICredentialProvider(repoType, namespace, url string, customDetail *v1alpha1.HelmPackageRepositoryCustomDetail) error {
+	provider := customDetail.GetOciCredentialProvider()
+	if provider == "" {
+		return nil
+	}
+	if repoType != OCIRepoType {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("The OCI credential provider is only supported for OCI repositories"))
+	}
+	if !ocicredentials.IsSupportedProvider(provider) {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("OCI credential provider [%s] not supported, expected one of %v", provider, ocicredentials.Providers()))
+	}
+	return s.checkOCICredentialProviderAllowed(namespace, url)
+}
+
+// checkOCICredentialProviderAllowed returns a PermissionDenied error unless the
+// repository of the namespace, with the given URL, can pull with the cloud
+// identity of the plugin, as per the registries allowed in the plugin config.
+func (s *Server) checkOCICredentialProviderAllowed(namespace, url string) error {
+	if err := ocicredentials.CheckAllowed(s.config().OCICredentialProviderAllowedRegistries, namespace, s.GetGlobalPackagingNamespace(), url); err != nil {
+		return connect.NewError(connect.CodePermissionDenied, fmt.Errorf("The repository %q is %w", url, err))
+	}
+	return nil
+}
+
+func (s *Server) newRepo(ctx context.Context, headers http.Header, repo *HelmRepository) (*corev1.PackageRepositoryReference, error) {
+	if err := s.validateRepoUrlAndType(repo); err != nil {
+		return nil, err
+	}
+	if err := validatePackagePatterns(repo.customDetail); err != nil {
+		return nil, err
 	}
 	// up to here, go through and update all these helpers?
 	typedClient, err := s.clientGetter.Typed(headers, repo.cluster)
@@ -187,6 +243,15 @@ func newHelmRepoCrd(repo *HelmRepository, secret *k8scorev1.Secret, imagePullSec
 		if repo.customDetail.OciRepositories != nil {
 			appRepoCrd.Spec.OCIRepositories = repo.customDetail.OciRepositories
 		}
+		appRepoCrd.Spec.OCICredentialProvider = repo.customDetail.OciCredentialProvider
+		appRepoCrd.Spec.AllowedNamespaces = repo.customDetail.AllowedNamespaces
+		pkgutils.SetPackagePatterns(&appRepoCrd.ObjectMeta, pkgutils.AnnotationAllowedPackagesKey, repo.customDetail.AllowedPackages)
+		pkgutils.SetPackagePatterns(&appRepoCrd.ObjectMeta, pkgutils.AnnotationDeniedPackagesKey, repo.customDetail.DeniedPackages)
+		if selector, err := newAllowedNamespaceSelector(repo.customDetail.AllowedNamespaceSelector); err != nil {
+			return nil, err
+		} else {
+			appRepoCrd.Spec.AllowedNamespaceSelector = selector
+		}
 		if repo.customDetail.NodeSelector != nil {
 			appRepoCrd.Spec.SyncJobPodTemplate.Spec.NodeSelector = repo.customDetail.NodeSelector
 		}
@@ -276,7 +341,8 @@ func (s *Server) mapToPackageRepositoryDetail(source *apprepov1alpha1.AppReposit
 	}
 
 	// Custom details
-	if source.Spec.DockerRegistrySecrets != nil || source.Spec.FilterRule.JQ != "" || source.Spec.OCIRepositories != nil {
+	packagePolicy := pkgutils.PackagePolicyFromAnnotations(source.Annotations)
+	if source.Spec.DockerRegistrySecrets != nil || source.Spec.FilterRule.JQ != "" || source.Spec.OCIRepositories != nil || source.Spec.OCICredentialProvider != "" || hasNamespaceRestrictions(source) || packagePolicy != nil {
 		var customDetail = &v1alpha1.HelmPackageRepositoryCustomDetail{}
 
 		customDetail.ImagesPullSecret = getRepoImagesPullSecret(source, imagesPullSecret)
@@ -287,6 +353,15 @@ func (s *Server) mapToPackageRepositoryDetail(source *apprepov1alpha1.AppReposit
 			}
 		}
 		customDetail.OciRepositories = source.Spec.OCIRepositories
+		customDetail.OciCredentialProvider = source.Spec.OCICredentialProvider
+		customDetail.AllowedNamespaces = source.Spec.AllowedNamespaces
+		if source.Spec.AllowedNamespaceSelector != nil {
+			customDetail.AllowedNamespaceSelector = metav1.FormatLabelSelector(source.Spec.AllowedNamespaceSelector)
+		}
+		if packagePolicy != nil {
+			customDetail.AllowedPackages = packagePolicy.AllowedPackages
+			customDetail.DeniedPackages = packagePolicy.DeniedPackages
+		}
 		target.CustomDetail, err = anypb.New(customDetail)
 		if err != nil {
 			return nil, err
@@ -346,6 +421,12 @@ func (s *Server) updateRepo(ctx context.Context,
 	if repo.name.Name == "" {
 		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Repository name may not be empty"))
 	}
+	if err := s.validateOCICredentialProvider(appRepo.Spec.Type, appRepo.Namespace, repo.url, repo.customDetail); err != nil {
+		return nil, err
+	}
+	if err := validatePackagePatterns(repo.customDetail); err != nil {
+		return nil, err
+	}
 	typedClient, err := s.clientGetter.Typed(headers, repo.cluster)
 	if err != nil {
 		return nil, err
@@ -422,6 +503,15 @@ func (s *Server) updateRepo(ctx context.Context,
 			appRepo.Spec.FilterRule = apprepov1alpha1.FilterRuleSpec{}
 		}
 		appRepo.Spec.OCIRepositories = repo.customDetail.OciRepositories
+		appRepo.Spec.OCICredentialProvider = repo.customDetail.OciCredentialProvider
+		appRepo.Spec.AllowedNamespaces = repo.customDetail.AllowedNamespaces
+		pkgutils.SetPackagePatterns(&appRepo.ObjectMeta, pkgutils.AnnotationAllowedPackagesKey, repo.customDetail.AllowedPackages)
+		pkgutils.SetPackagePatterns(&appRepo.ObjectMeta, pkgutils.AnnotationDeniedPackagesKey, repo.customDetail.DeniedPackages)
+		if selector, err := newAllowedNamespaceSelector(repo.customDetail.AllowedNamespaceSelector); err != nil {
+			return nil, err
+		} else {
+			appRepo.Spec.AllowedNamespaceSelector = selector
+		}
 		appRepo.Spec.SyncJobPodTemplate.Spec.NodeSelector = repo.customDetail.NodeSelector
 
 		if repo.customDetail.Tolerations != nil {
@@ -473,7 +563,10 @@ func (s *Server) updateRepo(ctx context.Context,
 	} else {
 		appRepo.Spec.DockerRegistrySecrets = nil
 		appRepo.Spec.OCIRepositories = nil
+		appRepo.Spec.OCICredentialProvider = ""
 		appRepo.Spec.FilterRule = apprepov1alpha1.FilterRuleSpec{}
+		appRepo.Spec.AllowedNamespaces = nil
+		appRepo.Spec.AllowedNamespaceSelector = nil
 	}
 
 	// store secrets if kubeapps managed (see newRepo, this is required in order to handle owner references)
@@ -518,20 +611,21 @@ func (s *Server) updateRepo(ctx context.Context,
 	}, nil
 }
 
-func (s *Server) repoSummaries(ctx context.Context, headers http.Header, cluster string, namespace string) ([]*corev1.PackageRepositorySummary, error) {
+func (s *Server) repoSummaries(ctx context.Context, headers http.Header, cluster string, namespace string) ([]*corev1.PackageRepositorySummary, []*corev1.NamespaceFailure, error) {
 	var summaries []*corev1.PackageRepositorySummary
+	var failures []*corev1.NamespaceFailure
 
 	repos, err := s.GetPkgRepositories(ctx, headers, cluster, namespace)
 	if err != nil {
 		// Catch forbidden errors in cluster-wide listings
 		if errors.IsForbidden(err) && namespace == "" {
 			log.Warningf("+helm unable to list package repositories at the cluster scope in '%s' due to [%v]", cluster, err)
-			repos, err = s.getAccessiblePackageRepositories(ctx, headers, cluster)
+			repos, failures, err = s.getAccessiblePackageRepositories(ctx, headers, cluster)
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 		} else {
-			return nil, connecterror.FromK8sError("get", "AppRepository", "", err)
+			return nil, nil, connecterror.FromK8sError("get", "AppRepository", "", err)
 		}
 	}
 
@@ -559,7 +653,7 @@ func (s *Server) repoSummaries(ctx context.Context, headers http.Header, cluster
 		}
 		summaries = append(summaries, summary)
 	}
-	return summaries, nil
+	return summaries, failures, nil
 }
 
 // GetPkgRepositories returns the list of package repositories for the given cluster and namespace
@@ -585,8 +679,8 @@ func (s *Server) GetPkgRepositories(ctx context.Context, headers http.Header, cl
 	return pkgRepositories, nil
 }
 
-// getAccessiblePackageRepositories gather list of repositories to which the user has access per namespace
-func (s *Server) getAccessiblePackageRepositories(ctx context.Context, headers http.Header, cluster string) ([]*apprepov1alpha1.AppRepository, error) {
+// getAccessibleNamespaces returns the names of the active namespaces to which the user has access
+func (s *Server) getAccessibleNamespaces(headers http.Header, cluster string) ([]string, error) {
 	clusterTypedClientFunc := func() (kubernetes.Interface, error) {
 		return s.clientGetter.Typed(headers, cluster)
 	}
@@ -598,29 +692,53 @@ func (s *Server) getAccessiblePackageRepositories(ctx context.Context, headers h
 	if err != nil {
 		return nil, err
 	}
-	namespaceList = resources.FilterActiveNamespaces(namespaceList)
+	var namespaces []string
+	for _, ns := range resources.FilterActiveNamespaces(namespaceList) {
+		namespaces = append(namespaces, ns.Name)
+	}
+	return namespaces, nil
+}
+
+// getAccessiblePackageRepositories gather list of repositories to which the user has access per namespace,
+// along with the namespaces in which the repositories could not be listed
+func (s *Server) getAccessiblePackageRepositories(ctx context.Context, headers http.Header, cluster string) ([]*apprepov1alpha1.AppRepository, []*corev1.NamespaceFailure, error) {
+	namespaces, err := s.getAccessibleNamespaces(headers, cluster)
+	if err != nil {
+		return nil, nil, err
+	}
 	var accessibleRepos []*apprepov1alpha1.AppRepository
-	for _, ns := range namespaceList {
-		nsRepos, err := s.GetPkgRepositories(ctx, headers, cluster, ns.Name)
+	var failures []*corev1.NamespaceFailure
+	for _, ns := range namespaces {
+		nsRepos, err := s.GetPkgRepositories(ctx, headers, cluster, ns)
 		if err != nil {
-			log.Warningf("+helm could not list AppRepository in namespace %s", ns.Name)
-			// Continue. Error in a single namespace should not block the whole list
+			log.Warningf("+helm could not list AppRepository in namespace %s", ns)
+			// Continue. Error in a single namespace should not block the whole list,
+			// but it is reported so that the caller knows the list is partial
+			failures = append(failures, pkgutils.NewNamespaceFailure(cluster, ns, GetPluginDetail(), connecterror.FromK8sError("list", AppRepositoryKind, "", err)))
 		}
 		accessibleRepos = append(accessibleRepos, nsRepos...)
 	}
-	return accessibleRepos, nil
+	return accessibleRepos, failures, nil
 }
 
-func (s *Server) deleteRepo(ctx context.Context, headers http.Header, cluster string, repoRef *corev1.PackageRepositoryReference) error {
+func (s *Server) deleteRepo(ctx context.Context, headers http.Header, cluster string, repoRef *corev1.PackageRepositoryReference, retainReferencedSecrets bool) ([]*corev1.PackageRepositorySecretUsage, error) {
 	client, err := s.getClient(headers, cluster, repoRef.Context.Namespace)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	typedClient, err := s.clientGetter.Typed(headers, cluster)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
 	}
-
-	log.V(4).Infof("Deleting AppRepository: [%s]", repoRef.Identifier)
 
 	// For kubeapps-managed secrets environment secrets will be deleted (garbage-collected)
-	// when the owner repo is deleted
+	// when the owner repo is deleted, unless they are still referenced elsewhere
+	retainedSecrets, err := s.releaseReferencedRepoSecrets(ctx, headers, cluster, typedClient, repoRef, retainReferencedSecrets)
+	if err != nil {
+		return nil, err
+	}
+
+	log.V(4).Infof("Deleting AppRepository: [%s]", repoRef.Identifier)
 
 	repo := &apprepov1alpha1.AppRepository{
 		ObjectMeta: metav1.ObjectMeta{
@@ -629,20 +747,107 @@ func (s *Server) deleteRepo(ctx context.Context, headers http.Header, cluster st
 		},
 	}
 	if err = client.Delete(ctx, repo); err != nil {
-		return connecterror.FromK8sError("delete", AppRepositoryKind, repoRef.Identifier, err)
+		return nil, connecterror.FromK8sError("delete", AppRepositoryKind, repoRef.Identifier, err)
 	} else {
 		// Cross-namespace owner references are disallowed by design.
 		// We need to explicitly delete the repo secret from the namespace of the asset syncer.
-		typedClient, err := s.clientGetter.Typed(headers, cluster)
-		if err != nil {
-			return connect.NewError(connect.CodeInvalidArgument, err)
-		}
 		namespacedSecretName := helm.SecretNameForNamespacedRepo(repoRef.Identifier, repoRef.Context.Namespace)
 		if deleteErr := s.deleteRepositorySecretFromNamespace(typedClient, s.kubeappsNamespace, namespacedSecretName); deleteErr != nil {
-			return connecterror.FromK8sError("delete", "Secret", namespacedSecretName, deleteErr)
+			return nil, connecterror.FromK8sError("delete", "Secret", namespacedSecretName, deleteErr)
 		}
-		return nil
+		return retainedSecrets, nil
+	}
+}
+
+// releaseReferencedRepoSecrets checks whether the secrets managed by the plugin for
+// the repository are still referenced by other AppRepositories of the namespace.
+// Unless they are retained, these references prevent the deletion of the repository.
+// Otherwise, the secrets still referenced are no longer owned by the repository, so
+// that they are not garbage-collected along with it, and returned.
+// Note that the Helm releases do not record the repository of their chart, so that
+// the images pull secrets they may use are not taken into account.
+func (s *Server) releaseReferencedRepoSecrets(ctx context.Context, headers http.Header, cluster string, typedClient kubernetes.Interface, repoRef *corev1.PackageRepositoryReference, retainReferencedSecrets bool) ([]*corev1.PackageRepositorySecretUsage, error) {
+	namespace := repoRef.GetContext().GetNamespace()
+	repos, err := s.GetPkgRepositories(ctx, headers, cluster, namespace)
+	if err != nil {
+		return nil, connecterror.FromK8sError("list", AppRepositoryKind, "", err)
 	}
+	var repo *apprepov1alpha1.AppRepository
+	for _, r := range repos {
+		if r.GetName() == repoRef.GetIdentifier() {
+			repo = r
+		}
+	}
+	if repo == nil {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("Unable to find the %s %q", AppRepositoryKind, repoRef.GetIdentifier()))
+	}
+
+	secretsInterface := typedClient.CoreV1().Secrets(namespace)
+	managedSecrets := map[string]*k8scorev1.Secret{}
+	var managedSecretNames []string
+	for _, name := range appRepoSecretNames(repo) {
+		secret, err := secretsInterface.Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return nil, connecterror.FromK8sError("get", "Secret", name, err)
+		}
+		if isSecretKubeappsManaged(repo, secret) {
+			managedSecrets[name] = secret
+			managedSecretNames = append(managedSecretNames, name)
+		}
+	}
+	if len(managedSecrets) == 0 {
+		return nil, nil
+	}
+
+	usages := pkgutils.NewSecretUsages(managedSecretNames...)
+	for _, r := range repos {
+		if r.GetName() == repo.GetName() {
+			continue
+		}
+		ref := &corev1.ResourceRef{
+			ApiVersion: apprepov1alpha1.SchemeGroupVersion.String(),
+			Kind:       AppRepositoryKind,
+			Name:       r.GetName(),
+			Namespace:  r.GetNamespace(),
+		}
+		for _, name := range appRepoSecretNames(r) {
+			usages.Add(name, ref)
+		}
+	}
+	inUse := usages.InUse()
+	if len(inUse) == 0 {
+		return nil, nil
+	}
+	if !retainReferencedSecrets {
+		return nil, pkgutils.SecretsInUseError(repo.GetName(), inUse)
+	}
+
+	for _, usage := range inUse {
+		secret := managedSecrets[usage.GetSecretName()]
+		if !pkgutils.RemoveOwnerReference(secret, repo.GetUID()) {
+			continue
+		}
+		if _, err := secretsInterface.Update(ctx, secret, metav1.UpdateOptions{}); err != nil {
+			return nil, connecterror.FromK8sError("update", "Secret", secret.GetName(), err)
+		}
+		log.InfoS("Retained the secret of the deleted AppRepository", "secret", secret.GetName(), "repository", repo.GetName(), "namespace", namespace)
+	}
+	return inUse, nil
+}
+
+// appRepoSecretNames returns the names of the secrets referenced by the AppRepository.
+func appRepoSecretNames(repo *apprepov1alpha1.AppRepository) []string {
+	var names []string
+	if header := repo.Spec.Auth.Header; header != nil {
+		names = append(names, header.SecretKeyRef.Name)
+	}
+	if customCA := repo.Spec.Auth.CustomCA; customCA != nil {
+		names = append(names, customCA.SecretKeyRef.Name)
+	}
+	return append(names, repo.Spec.DockerRegistrySecrets...)
 }
 
 func (s *Server) GetPackageRepositoryPermissions(ctx context.Context, request *connect.Request[corev1.GetPackageRepositoryPermissionsRequest]) (*connect.Response[corev1.GetPackageRepositoryPermissionsResponse], error) {
@@ -685,3 +890,91 @@ func (s *Server) GetPackageRepositoryPermissions(ctx context.Context, request *c
 		Permissions: []*corev1.PackageRepositoriesPermissions{permissions},
 	}), nil
 }
+
+// ListPackageRepositoryPackages returns the charts synced from a single package repository
+func (s *Server) ListPackageRepositoryPackages(ctx context.Context, request *connect.Request[corev1.ListPackageRepositoryPackagesRequest]) (*connect.Response[corev1.ListPackageRepositoryPackagesResponse], error) {
+	repoRef := request.Msg.GetPackageRepoRef()
+	if repoRef.GetContext().GetNamespace() == "" || repoRef.GetIdentifier() == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("No valid PackageRepoRef provided"))
+	}
+	cluster := repoRef.GetContext().GetCluster()
+	if cluster == "" {
+		cluster = s.globalPackagingCluster
+	}
+	namespace := repoRef.GetContext().GetNamespace()
+	name := repoRef.GetIdentifier()
+
+	log.InfoS("+helm ListPackageRepositoryPackages", "cluster", cluster, "namespace", namespace, "name", name)
+
+	// The repository is read with the user credentials, so that only the
+	// charts of a readable repository are returned.
+	client, err := s.getClient(request.Header(), cluster, namespace)
+	if err != nil {
+		return nil, err
+	}
+	key := types.NamespacedName{Namespace: namespace, Name: name}
+	if err = client.Get(ctx, key, &apprepov1alpha1.AppRepository{}); err != nil {
+		return nil, connecterror.FromK8sError("get", AppRepositoryKind, key.String(), err)
+	}
+
+	// The chart query for a namespace includes the global repositories, so a
+	// global repository with the same name is excluded.
+	cq := utils.ChartQuery{
+		Namespace: namespace,
+		Repos:     []string{name},
+	}
+	if namespace != s.GetGlobalPackagingNamespace() {
+		cq.ExcludedRepos = []types.NamespacedName{{Namespace: s.GetGlobalPackagingNamespace(), Name: name}}
+	}
+	if cq.PackagePolicies, err = s.getRepoPackagePolicies(ctx, []string{namespace}); err != nil {
+		return nil, err
+	}
+
+	pageSize := request.Msg.GetPaginationOptions().GetPageSize()
+	itemOffset, err := paginate.ItemOffsetFromPageToken(request.Msg.GetPaginationOptions().GetPageToken())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	charts, err := s.manager.GetPaginatedChartListWithFilters(cq, itemOffset, int(pageSize))
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to retrieve charts: %w", err))
+	}
+	summaries := []*corev1.AvailablePackageSummary{}
+	for _, chart := range charts {
+		pkg, err := pkgutils.AvailablePackageSummaryFromChart(chart, GetPluginDetail())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to parse chart to an AvailablePackageSummary: %w", err))
+		}
+		// We currently support app repositories on the kubeapps cluster only.
+		pkg.AvailablePackageRef.Context.Cluster = s.globalPackagingCluster
+		summaries = append(summaries, pkg)
+	}
+
+	// Only return a next page token if the request was for pagination and
+	// the results are a full page.
+	nextPageToken := ""
+	if pageSize > 0 && len(summaries) == int(pageSize) {
+		nextPageToken = fmt.Sprintf("%d", itemOffset+int(pageSize))
+	}
+
+	count, err := s.manager.GetChartCountWithFilters(cq)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to count charts: %w", err))
+	}
+	response := &corev1.ListPackageRepositoryPackagesResponse{
+		AvailablePackageSummaries: summaries,
+		NextPageToken:             nextPageToken,
+		TotalCount:                proto.Int32(int32(count)),
+	}
+
+	lastUpdate, err := s.manager.GetRepositoryLastUpdate(namespace, name)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to retrieve the last update of the repository: %w", err))
+	}
+	if !lastUpdate.IsZero() {
+		response.LastUpdated = lastUpdate.UTC().Format(time.RFC3339)
+	}
+
+	return connect.NewResponse(response), nil
+}
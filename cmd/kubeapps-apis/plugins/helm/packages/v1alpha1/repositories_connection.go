@@ -0,0 +1,96 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/bufbuild/connect-go"
+	apprepov1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/apprepository-controller/pkg/apis/apprepository/v1alpha1"
+	corev1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/helm"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/netrestrict"
+	k8scorev1 "k8s.io/api/core/v1"
+)
+
+// testRepoConnection checks that the given repository can be reached with its
+// TLS configuration and credentials. Referenced secrets are read, but nothing
+// is created in the cluster.
+func (s *Server) testRepoConnection(ctx context.Context, headers http.Header, repo *HelmRepository) (*corev1.TestPackageRepositoryConnectionResponse, error) {
+	if err := s.validateRepoUrlAndType(repo); err != nil {
+		return nil, err
+	}
+	typedClient, err := s.clientGetter.Typed(headers, repo.cluster)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	secret, _, err := handleAuthSecretForCreate(ctx, typedClient, repo.name, repo.tlsConfig, repo.auth)
+	if err != nil {
+		return nil, err
+	}
+	helmRepoCrd, err := newHelmRepoCrd(repo, secret, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// the repository URL is chosen by the user, so the requests cannot reach
+	// the addresses only exposed to the plugin itself
+	restrictions, err := netrestrict.New(s.config().RepositoryConnectionAllowedNetworks)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	clientGetter := func(appRepo *apprepov1alpha1.AppRepository, secret *k8scorev1.Secret) (*http.Client, error) {
+		cli, err := s.repoClientGetter(appRepo, secret)
+		if err != nil {
+			return nil, err
+		}
+		return restrictions.Client(cli), nil
+	}
+
+	if repo.repoType == OCIRepoType {
+		return s.testOCIRepoConnection(ctx, helmRepoCrd, secret, clientGetter)
+	}
+	return testHelmRepoConnection(ctx, helmRepoCrd, secret, clientGetter)
+}
+
+// testHelmRepoConnection checks that the index of a non-OCI repository can be
+// retrieved, counting the packages it contains.
+func testHelmRepoConnection(ctx context.Context, appRepo *apprepov1alpha1.AppRepository, secret *k8scorev1.Secret, clientGetter repositoryClientGetter) (*corev1.TestPackageRepositoryConnectionResponse, error) {
+	cli, err := clientGetter(appRepo, secret)
+	if err != nil {
+		return nil, err
+	}
+	return helm.ProbeRepoIndex(ctx, cli, appRepo.Spec.URL)
+}
+
+// testOCIRepoConnection checks that the OCI registry can be reached and that
+// the listed repositories, if any, contain Helm charts.
+func (s *Server) testOCIRepoConnection(ctx context.Context, appRepo *apprepov1alpha1.AppRepository, secret *k8scorev1.Secret, clientGetter repositoryClientGetter) (*corev1.TestPackageRepositoryConnectionResponse, error) {
+	validator := HelmOCIValidator{
+		AppRepo:             appRepo,
+		Secret:              secret,
+		ClientGetter:        clientGetter,
+		OCICatalogAddr:      s.OCICatalogAddr,
+		OCIReplacementProto: OCIDistributionAPIProto,
+	}
+	resp, err := validator.Validate(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	} else if resp.Code >= 400 {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("Unable to connect to the OCI registry '%s': %s", appRepo.Spec.URL, resp.Message))
+	}
+
+	response := &corev1.TestPackageRepositoryConnectionResponse{
+		Message: fmt.Sprintf("Connected to the OCI registry '%s'", appRepo.Spec.URL),
+	}
+	if len(appRepo.Spec.OCIRepositories) > 0 {
+		// Each of the listed OCI repositories, which were all checked, is a package.
+		packageCount := int32(len(appRepo.Spec.OCIRepositories))
+		response.PackageCount = &packageCount
+	}
+	return response, nil
+}
@@ -0,0 +1,204 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	appRepov1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/apprepository-controller/pkg/apis/apprepository/v1alpha1"
+	corev1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	"google.golang.org/protobuf/proto"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestTestPackageRepositoryConnection(t *testing.T) {
+	newRequest := func(repoType string, auth *corev1.PackageRepositoryAuth) *corev1.TestPackageRepositoryConnectionRequest {
+		return &corev1.TestPackageRepositoryConnectionRequest{
+			PackageRepository: &corev1.AddPackageRepositoryRequest{
+				Name:            "bar",
+				Context:         &corev1.Context{Namespace: "foo", Cluster: KubeappsCluster},
+				Type:            repoType,
+				NamespaceScoped: true,
+				Auth:            auth,
+			},
+		}
+	}
+
+	testCases := []struct {
+		name               string
+		request            *corev1.TestPackageRepositoryConnectionRequest
+		existingAuthSecret *apiv1.Secret
+		handler            http.HandlerFunc
+		// repositoryNetworkBlocked keeps the loopback address of the test
+		// server blocked, as it is by default.
+		repositoryNetworkBlocked bool
+		expectedResponse         *corev1.TestPackageRepositoryConnectionResponse
+		errorCode                connect.Code
+	}{
+		{
+			name:      "returns error if no package repository is provided",
+			request:   &corev1.TestPackageRepositoryConnectionRequest{},
+			errorCode: connect.CodeInvalidArgument,
+		},
+		{
+			name:      "returns error if the repository type is not supported",
+			request:   newRequest("git", nil),
+			errorCode: connect.CodeInvalidArgument,
+		},
+		{
+			name:    "returns the metadata and the number of packages of the repository index",
+			request: newRequest("helm", nil),
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodGet || r.URL.Path != "/index.yaml" {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				w.Header().Set("ETag", `"abc"`)
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte("apiVersion: v1\nentries:\n  apache:\n  - name: apache\n    version: 1.0.0\n  - name: apache\n    version: 0.9.0\n  nginx:\n  - name: nginx\n    version: 2.0.0\n"))
+			},
+			expectedResponse: &corev1.TestPackageRepositoryConnectionResponse{
+				Metadata:     map[string]string{"etag": `"abc"`},
+				PackageCount: proto.Int32(2),
+			},
+		},
+		{
+			name:    "returns failed precondition if the repository index is invalid",
+			request: newRequest("helm", nil),
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte("entries: [not, a, map]"))
+			},
+			errorCode: connect.CodeFailedPrecondition,
+		},
+		{
+			name:                     "returns permission denied if the repository is served from a blocked network",
+			request:                  newRequest("helm", nil),
+			repositoryNetworkBlocked: true,
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			},
+			errorCode: connect.CodePermissionDenied,
+		},
+		{
+			name: "uses the credentials of the referenced secret",
+			request: newRequest("helm", &corev1.PackageRepositoryAuth{
+				Type: corev1.PackageRepositoryAuth_PACKAGE_REPOSITORY_AUTH_TYPE_BASIC_AUTH,
+				PackageRepoAuthOneOf: &corev1.PackageRepositoryAuth_SecretRef{
+					SecretRef: &corev1.SecretKeyReference{Name: "repo-creds"},
+				},
+			}),
+			existingAuthSecret: newBasicAuthSecret("repo-creds", "foo", "baz-user", "zot-pwd"),
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			},
+			expectedResponse: &corev1.TestPackageRepositoryConnectionResponse{
+				Metadata:     map[string]string{},
+				PackageCount: proto.Int32(0),
+			},
+		},
+		{
+			name: "returns not found if the referenced secret does not exist",
+			request: newRequest("helm", &corev1.PackageRepositoryAuth{
+				Type: corev1.PackageRepositoryAuth_PACKAGE_REPOSITORY_AUTH_TYPE_BASIC_AUTH,
+				PackageRepoAuthOneOf: &corev1.PackageRepositoryAuth_SecretRef{
+					SecretRef: &corev1.SecretKeyReference{Name: "repo-creds"},
+				},
+			}),
+			errorCode: connect.CodeNotFound,
+		},
+		{
+			name:    "returns unauthenticated if the repository rejects the credentials",
+			request: newRequest("helm", nil),
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusUnauthorized)
+			},
+			errorCode: connect.CodeUnauthenticated,
+		},
+		{
+			name:    "returns not found if the repository has no index",
+			request: newRequest("helm", nil),
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+			},
+			errorCode: connect.CodeNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var secrets []k8sruntime.Object
+			if tc.existingAuthSecret != nil {
+				secrets = append(secrets, tc.existingAuthSecret)
+			}
+			s := newServerWithSecretsAndRepos(t, secrets, nil, nil)
+
+			url := "https://example.com"
+			if tc.handler != nil {
+				testRepoServer := httptest.NewServer(tc.handler)
+				defer testRepoServer.Close()
+				s.repoClientGetter = func(_ *appRepov1alpha1.AppRepository, _ *apiv1.Secret) (*http.Client, error) {
+					return testRepoServer.Client(), nil
+				}
+				url = testRepoServer.URL
+				if !tc.repositoryNetworkBlocked {
+					s.pluginConfig.RepositoryConnectionAllowedNetworks = []string{"127.0.0.0/8"}
+				}
+			}
+			if tc.request.PackageRepository != nil {
+				tc.request.PackageRepository.Url = url
+			}
+
+			response, err := s.TestPackageRepositoryConnection(context.Background(), connect.NewRequest(tc.request))
+
+			if got, want := connect.CodeOf(err), tc.errorCode; err != nil && got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+			if tc.errorCode != 0 {
+				if err == nil {
+					t.Fatalf("got: nil, want: error with code %s", tc.errorCode)
+				}
+				return
+			}
+
+			tc.expectedResponse.Message = "Found the repository index at '" + url + "/index.yaml'"
+			opts := cmpopts.IgnoreUnexported(corev1.TestPackageRepositoryConnectionResponse{})
+			if got, want := response.Msg, tc.expectedResponse; !cmp.Equal(got, want, opts) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, opts))
+			}
+
+			// Testing the connection must not create anything in the cluster.
+			ctrlClient, err := s.clientGetter.ControllerRuntime(http.Header{}, s.kubeappsCluster)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var repos appRepov1alpha1.AppRepositoryList
+			if err = ctrlClient.List(context.Background(), &repos); err != nil {
+				t.Fatal(err)
+			}
+			if len(repos.Items) != 0 {
+				t.Errorf("expected no repository to be created, got: %v", repos.Items)
+			}
+			typedClient, err := s.clientGetter.Typed(http.Header{}, s.kubeappsCluster)
+			if err != nil {
+				t.Fatal(err)
+			}
+			secretList, err := typedClient.CoreV1().Secrets("").List(context.Background(), metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := len(secretList.Items), len(secrets); got != want {
+				t.Errorf("got: %d secrets, want: %d", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/bufbuild/connect-go"
+	apprepov1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/apprepository-controller/pkg/apis/apprepository/v1alpha1"
+	corev1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/connecterror"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	log "k8s.io/klog/v2"
+	"k8s.io/utils/strings/slices"
+)
+
+// newAllowedNamespaceSelector parses the label selector, in its string form,
+// restricting the namespaces into which packages from a repository can be installed.
+func newAllowedNamespaceSelector(selector string) (*metav1.LabelSelector, error) {
+	if selector == "" {
+		return nil, nil
+	}
+	labelSelector, err := metav1.ParseToLabelSelector(selector)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Invalid allowed namespace selector %q: %w", selector, err))
+	}
+	return labelSelector, nil
+}
+
+// hasNamespaceRestrictions returns true if packages from the repository can
+// only be installed in some namespaces.
+func hasNamespaceRestrictions(appRepo *apprepov1alpha1.AppRepository) bool {
+	return len(appRepo.Spec.AllowedNamespaces) > 0 || appRepo.Spec.AllowedNamespaceSelector != nil
+}
+
+// repoAllowsNamespace returns true if packages from the repository can be
+// installed in the namespace with the given name and labels.
+func repoAllowsNamespace(appRepo *apprepov1alpha1.AppRepository, namespace string, namespaceLabels map[string]string) (bool, error) {
+	if !hasNamespaceRestrictions(appRepo) || slices.Contains(appRepo.Spec.AllowedNamespaces, namespace) {
+		return true, nil
+	}
+	if appRepo.Spec.AllowedNamespaceSelector == nil {
+		return false, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(appRepo.Spec.AllowedNamespaceSelector)
+	if err != nil {
+		return false, fmt.Errorf("invalid allowed namespace selector in repository %s/%s: %w", appRepo.Namespace, appRepo.Name, err)
+	}
+	return selector.Matches(labels.Set(namespaceLabels)), nil
+}
+
+// getNamespaceLabels returns the labels of the given namespace.
+//
+// Users are not necessarily allowed to read namespaces, so the kubeapps-apis
+// service account, which can list them, is used as a fallback when the
+// namespace lives in the cluster on which Kubeapps is installed.
+func (s *Server) getNamespaceLabels(ctx context.Context, headers http.Header, cluster, namespace string) (map[string]string, error) {
+	typedClient, err := s.clientGetter.Typed(headers, cluster)
+	if err != nil {
+		return nil, err
+	}
+	ns, err := typedClient.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
+	if err == nil {
+		return ns.Labels, nil
+	}
+	if !errors.IsForbidden(err) || cluster != s.globalPackagingCluster || s.localServiceAccountClientGetter == nil {
+		return nil, err
+	}
+
+	serviceAccountClient, err := s.localServiceAccountClientGetter.Typed(ctx)
+	if err != nil {
+		return nil, err
+	}
+	namespaces, err := serviceAccountClient.CoreV1().Namespaces().List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("metadata.name", namespace).String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(namespaces.Items) == 0 {
+		return nil, errors.NewNotFound(schema.GroupResource{Resource: "namespaces"}, namespace)
+	}
+	return namespaces.Items[0].Labels, nil
+}
+
+// checkRepoAllowsNamespace returns a PermissionDenied error if packages from
+// the given repository cannot be installed in the target context.
+func (s *Server) checkRepoAllowsNamespace(ctx context.Context, headers http.Header, repoName types.NamespacedName, targetContext *corev1.Context) error {
+	resource, err := s.getPkgRepositoryResource(headers, s.globalPackagingCluster, repoName.Namespace)
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, err)
+	}
+	appRepoUnstructured, err := resource.Get(ctx, repoName.Name, metav1.GetOptions{})
+	if err != nil {
+		return connecterror.FromK8sError("get", AppRepositoryKind, repoName.String(), err)
+	}
+	appRepo := &apprepov1alpha1.AppRepository{}
+	if err = runtime.DefaultUnstructuredConverter.FromUnstructured(appRepoUnstructured.UnstructuredContent(), appRepo); err != nil {
+		return connect.NewError(connect.CodeInternal, err)
+	}
+	if !hasNamespaceRestrictions(appRepo) {
+		return nil
+	}
+
+	cluster := targetContext.GetCluster()
+	if cluster == "" {
+		cluster = s.globalPackagingCluster
+	}
+	namespace := targetContext.GetNamespace()
+	var namespaceLabels map[string]string
+	if appRepo.Spec.AllowedNamespaceSelector != nil && !slices.Contains(appRepo.Spec.AllowedNamespaces, namespace) {
+		if namespaceLabels, err = s.getNamespaceLabels(ctx, headers, cluster, namespace); err != nil {
+			return connecterror.FromK8sError("get", "Namespace", namespace, err)
+		}
+	}
+
+	allowed, err := repoAllowsNamespace(appRepo, namespace, namespaceLabels)
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, err)
+	}
+	if !allowed {
+		return connect.NewError(connect.CodePermissionDenied, fmt.Errorf("Packages from the repository %q cannot be installed in the namespace %q", repoName.String(), namespace))
+	}
+	return nil
+}
+
+// getRestrictedRepos returns the repositories, among those in the given
+// repository namespaces, whose packages cannot be installed in the target
+// namespace so that they can be excluded from the catalog.
+//
+// Restrictions are enforced when installing a package, so repositories that
+// cannot be read by the user are simply not filtered.
+func (s *Server) getRestrictedRepos(ctx context.Context, headers http.Header, cluster, namespace string, repoNamespaces []string) []types.NamespacedName {
+	var restrictedRepos []types.NamespacedName
+	var namespaceLabels map[string]string
+	namespaceLabelsFetched := false
+	for _, repoNamespace := range repoNamespaces {
+		appRepos, err := s.GetPkgRepositories(ctx, headers, s.globalPackagingCluster, repoNamespace)
+		if err != nil {
+			log.Warningf("+helm could not list AppRepository in namespace %s to check namespace restrictions: %v", repoNamespace, err)
+			continue
+		}
+		for _, appRepo := range appRepos {
+			if !hasNamespaceRestrictions(appRepo) {
+				continue
+			}
+			if appRepo.Spec.AllowedNamespaceSelector != nil && !namespaceLabelsFetched {
+				namespaceLabelsFetched = true
+				if namespaceLabels, err = s.getNamespaceLabels(ctx, headers, cluster, namespace); err != nil {
+					log.Warningf("+helm could not get the labels of namespace %s: %v", namespace, err)
+				}
+			}
+			if allowed, err := repoAllowsNamespace(appRepo, namespace, namespaceLabels); err != nil || !allowed {
+				restrictedRepos = append(restrictedRepos, types.NamespacedName{Namespace: appRepo.Namespace, Name: appRepo.Name})
+			}
+		}
+	}
+	return restrictedRepos
+}
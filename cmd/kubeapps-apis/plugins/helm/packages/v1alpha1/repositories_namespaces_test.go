@@ -0,0 +1,99 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"testing"
+
+	"github.com/vmware-tanzu/kubeapps/cmd/apprepository-controller/pkg/apis/apprepository/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRepoAllowsNamespace(t *testing.T) {
+	testCases := []struct {
+		name            string
+		spec            v1alpha1.AppRepositorySpec
+		namespace       string
+		namespaceLabels map[string]string
+		expectedAllowed bool
+	}{
+		{
+			name:            "allows any namespace without restrictions",
+			namespace:       "my-ns",
+			expectedAllowed: true,
+		},
+		{
+			name:            "allows a namespace in the allowed list",
+			spec:            v1alpha1.AppRepositorySpec{AllowedNamespaces: []string{"team-a", "my-ns"}},
+			namespace:       "my-ns",
+			expectedAllowed: true,
+		},
+		{
+			name:            "denies a namespace not in the allowed list",
+			spec:            v1alpha1.AppRepositorySpec{AllowedNamespaces: []string{"team-a"}},
+			namespace:       "my-ns",
+			expectedAllowed: false,
+		},
+		{
+			name: "allows a namespace matching the selector",
+			spec: v1alpha1.AppRepositorySpec{AllowedNamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"tier": "internal"},
+			}},
+			namespace:       "my-ns",
+			namespaceLabels: map[string]string{"tier": "internal", "foo": "bar"},
+			expectedAllowed: true,
+		},
+		{
+			name: "denies a namespace not matching the selector",
+			spec: v1alpha1.AppRepositorySpec{AllowedNamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"tier": "internal"},
+			}},
+			namespace:       "my-ns",
+			namespaceLabels: map[string]string{"tier": "public"},
+			expectedAllowed: false,
+		},
+		{
+			name: "allows a namespace in the allowed list not matching the selector",
+			spec: v1alpha1.AppRepositorySpec{
+				AllowedNamespaces: []string{"my-ns"},
+				AllowedNamespaceSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"tier": "internal"},
+				},
+			},
+			namespace:       "my-ns",
+			expectedAllowed: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			appRepo := &v1alpha1.AppRepository{Spec: tc.spec}
+			allowed, err := repoAllowsNamespace(appRepo, tc.namespace, tc.namespaceLabels)
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if got, want := allowed, tc.expectedAllowed; got != want {
+				t.Errorf("got: %t, want: %t", got, want)
+			}
+		})
+	}
+}
+
+func TestNewAllowedNamespaceSelector(t *testing.T) {
+	selector, err := newAllowedNamespaceSelector("tier=internal")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if got, want := metav1.FormatLabelSelector(selector), "tier=internal"; got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+
+	if selector, err = newAllowedNamespaceSelector(""); err != nil || selector != nil {
+		t.Errorf("expected no selector, got: %v, err: %v", selector, err)
+	}
+
+	if _, err = newAllowedNamespaceSelector("tier in ("); err == nil {
+		t.Errorf("expected an error for an invalid selector")
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bufbuild/connect-go"
+	apprepov1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/apprepository-controller/pkg/apis/apprepository/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/connecterror"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/pkgutils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+)
+
+// getServiceAccountPkgRepositoryResource returns the AppRepository resource of
+// the given namespace for the kubeapps-apis service account.
+//
+// The package policies of the repositories curate the catalog rather than
+// replace RBAC, so they must apply to every user regardless of the
+// repositories they are allowed to read.
+func (s *Server) getServiceAccountPkgRepositoryResource(ctx context.Context, namespace string) (dynamic.ResourceInterface, error) {
+	dynClient, err := s.localServiceAccountClientGetter.Dynamic(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return dynClient.Resource(apprepov1alpha1.SchemeGroupVersion.WithResource(AppRepositoryResource)).Namespace(namespace), nil
+}
+
+// getRepoPackagePolicies returns the package policies of the repositories in
+// the given namespaces, for the repositories restricting their packages.
+func (s *Server) getRepoPackagePolicies(ctx context.Context, repoNamespaces []string) (map[types.NamespacedName]*pkgutils.PackagePolicy, error) {
+	policies := map[types.NamespacedName]*pkgutils.PackagePolicy{}
+	for _, repoNamespace := range repoNamespaces {
+		resource, err := s.getServiceAccountPkgRepositoryResource(ctx, repoNamespace)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		appRepos, err := resource.List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, connecterror.FromK8sError("list", AppRepositoryKind, "", err)
+		}
+		for _, appRepo := range appRepos.Items {
+			if policy := pkgutils.PackagePolicyFromAnnotations(appRepo.GetAnnotations()); policy != nil {
+				policies[types.NamespacedName{Namespace: appRepo.GetNamespace(), Name: appRepo.GetName()}] = policy
+			}
+		}
+	}
+	return policies, nil
+}
+
+// checkRepoAllowsPackage returns a FailedPrecondition error if the chart is not
+// made available by the package policy of its repository.
+func (s *Server) checkRepoAllowsPackage(ctx context.Context, repoName types.NamespacedName, chartName string) error {
+	resource, err := s.getServiceAccountPkgRepositoryResource(ctx, repoName.Namespace)
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, err)
+	}
+	appRepoUnstructured, err := resource.Get(ctx, repoName.Name, metav1.GetOptions{})
+	if err != nil {
+		return connecterror.FromK8sError("get", AppRepositoryKind, repoName.String(), err)
+	}
+	appRepo := &apprepov1alpha1.AppRepository{}
+	if err = runtime.DefaultUnstructuredConverter.FromUnstructured(appRepoUnstructured.UnstructuredContent(), appRepo); err != nil {
+		return connect.NewError(connect.CodeInternal, err)
+	}
+	if !pkgutils.PackagePolicyFromAnnotations(appRepo.Annotations).Allows(chartName) {
+		return connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("The package %q is not allowed by the policy of its repository", chartName))
+	}
+	return nil
+}
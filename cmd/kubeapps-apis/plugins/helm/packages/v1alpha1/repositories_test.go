@@ -5,14 +5,17 @@ package main
 
 import (
 	"context"
+	sqldriver "database/sql/driver"
 	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"regexp"
 	"strings"
 	"testing"
 
+	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/bufbuild/connect-go"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -21,7 +24,10 @@ import (
 	corev1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
 	plugins "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/plugins/v1alpha1"
 	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/plugins/helm/packages/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/pkgutils"
+	"github.com/vmware-tanzu/kubeapps/pkg/chart/models"
 	"github.com/vmware-tanzu/kubeapps/pkg/helm"
+	"google.golang.org/protobuf/testing/protocmp"
 	"google.golang.org/protobuf/types/known/anypb"
 	authorizationv1 "k8s.io/api/authorization/v1"
 	apiv1 "k8s.io/api/core/v1"
@@ -239,18 +245,19 @@ func TestAddPackageRepository(t *testing.T) {
 	}
 
 	testCases := []struct {
-		name                        string
-		request                     *corev1.AddPackageRepositoryRequest
-		expectedResponse            *corev1.AddPackageRepositoryResponse
-		expectedRepo                *appRepov1alpha1.AppRepository
-		errorCode                   connect.Code
-		existingAuthSecret          *apiv1.Secret
-		existingDockerSecret        *apiv1.Secret
-		expectedAuthCreatedSecret   *apiv1.Secret
-		expectedDockerCreatedSecret *apiv1.Secret
-		userManagedSecrets          bool
-		testRepoServer              *httptest.Server
-		expectedGlobalSecret        *apiv1.Secret
+		name                                   string
+		request                                *corev1.AddPackageRepositoryRequest
+		expectedResponse                       *corev1.AddPackageRepositoryResponse
+		expectedRepo                           *appRepov1alpha1.AppRepository
+		errorCode                              connect.Code
+		existingAuthSecret                     *apiv1.Secret
+		existingDockerSecret                   *apiv1.Secret
+		expectedAuthCreatedSecret              *apiv1.Secret
+		expectedDockerCreatedSecret            *apiv1.Secret
+		userManagedSecrets                     bool
+		testRepoServer                         *httptest.Server
+		expectedGlobalSecret                   *apiv1.Secret
+		ociCredentialProviderAllowedRegistries []string
 	}{
 		{
 			name:      "returns error if no namespace is provided",
@@ -272,6 +279,101 @@ func TestAddPackageRepository(t *testing.T) {
 			request:   addRepoReqNoUrl,
 			errorCode: connect.CodeInvalidArgument,
 		},
+		{
+			name: "returns error if OCI credential provider for a helm repository",
+			request: newPackageRepoRequestWithDetails(&v1alpha1.HelmPackageRepositoryCustomDetail{
+				OciCredentialProvider: "aws",
+			}),
+			errorCode: connect.CodeInvalidArgument,
+		},
+		{
+			name: "returns error if OCI credential provider not supported",
+			request: &corev1.AddPackageRepositoryRequest{
+				Name:            "bar",
+				Context:         &corev1.Context{Namespace: "foo", Cluster: KubeappsCluster},
+				Type:            "oci",
+				Url:             "oci://123456789012.dkr.ecr.us-east-1.amazonaws.com/charts",
+				NamespaceScoped: true,
+				CustomDetail: toProtoBufAny(&v1alpha1.HelmPackageRepositoryCustomDetail{
+					OciCredentialProvider: "alibaba",
+				}),
+			},
+			errorCode: connect.CodeInvalidArgument,
+		},
+		{
+			name: "returns permission denied if no registry is allowed to use an OCI credential provider",
+			request: &corev1.AddPackageRepositoryRequest{
+				Name:    "bar",
+				Context: &corev1.Context{Namespace: globalPackagingNamespace, Cluster: KubeappsCluster},
+				Type:    "oci",
+				Url:     "oci://123456789012.dkr.ecr.us-east-1.amazonaws.com/charts",
+				CustomDetail: toProtoBufAny(&v1alpha1.HelmPackageRepositoryCustomDetail{
+					OciCredentialProvider: "aws",
+				}),
+			},
+			errorCode: connect.CodePermissionDenied,
+		},
+		{
+			name: "returns permission denied if a namespaced repository uses an OCI credential provider",
+			request: &corev1.AddPackageRepositoryRequest{
+				Name:            "bar",
+				Context:         &corev1.Context{Namespace: "foo", Cluster: KubeappsCluster},
+				Type:            "oci",
+				Url:             "oci://123456789012.dkr.ecr.us-east-1.amazonaws.com/charts",
+				NamespaceScoped: true,
+				CustomDetail: toProtoBufAny(&v1alpha1.HelmPackageRepositoryCustomDetail{
+					OciCredentialProvider: "aws",
+				}),
+			},
+			ociCredentialProviderAllowedRegistries: []string{"123456789012.dkr.ecr.us-east-1.amazonaws.com"},
+			errorCode:                              connect.CodePermissionDenied,
+		},
+		{
+			name: "returns permission denied if the registry is not allowed to use an OCI credential provider",
+			request: &corev1.AddPackageRepositoryRequest{
+				Name:    "bar",
+				Context: &corev1.Context{Namespace: globalPackagingNamespace, Cluster: KubeappsCluster},
+				Type:    "oci",
+				Url:     "oci://210987654321.dkr.ecr.us-east-1.amazonaws.com/charts",
+				CustomDetail: toProtoBufAny(&v1alpha1.HelmPackageRepositoryCustomDetail{
+					OciCredentialProvider: "aws",
+				}),
+			},
+			ociCredentialProviderAllowedRegistries: []string{"123456789012.dkr.ecr.us-east-1.amazonaws.com"},
+			errorCode:                              connect.CodePermissionDenied,
+		},
+		{
+			name: "add global OCI package repository with a credential provider",
+			request: &corev1.AddPackageRepositoryRequest{
+				Name:    "bar",
+				Context: &corev1.Context{Namespace: globalPackagingNamespace, Cluster: KubeappsCluster},
+				Type:    "oci",
+				Url:     "oci://123456789012.dkr.ecr.us-east-1.amazonaws.com/charts",
+				CustomDetail: toProtoBufAny(&v1alpha1.HelmPackageRepositoryCustomDetail{
+					OciRepositories:       []string{"apache"},
+					OciCredentialProvider: "aws",
+				}),
+			},
+			ociCredentialProviderAllowedRegistries: []string{"123456789012.dkr.ecr.us-east-1.amazonaws.com"},
+			expectedResponse:                       addRepoExpectedGlobalResp,
+			expectedRepo: &appRepov1alpha1.AppRepository{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       AppRepositoryKind,
+					APIVersion: AppRepositoryApi,
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "bar",
+					Namespace:       globalPackagingNamespace,
+					ResourceVersion: "1",
+				},
+				Spec: appRepov1alpha1.AppRepositorySpec{
+					URL:                   "oci://123456789012.dkr.ecr.us-east-1.amazonaws.com/charts",
+					Type:                  "oci",
+					OCIRepositories:       []string{"apache"},
+					OCICredentialProvider: "aws",
+				},
+			},
+		},
 		{
 			name:             "check that interval is used",
 			request:          addRepoReqWithInterval,
@@ -734,6 +836,40 @@ func TestAddPackageRepository(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "[custom details] repository is created with package policies",
+			request: newPackageRepoRequestWithDetails(&v1alpha1.HelmPackageRepositoryCustomDetail{
+				AllowedPackages: []string{"apache", "*sql"},
+				DeniedPackages:  []string{"mysql"},
+			}),
+			expectedResponse: addRepoExpectedResp,
+			expectedRepo: &appRepov1alpha1.AppRepository{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       AppRepositoryKind,
+					APIVersion: AppRepositoryApi,
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "bar",
+					Namespace:       "foo",
+					ResourceVersion: "1",
+					Annotations: map[string]string{
+						pkgutils.AnnotationAllowedPackagesKey: "apache,*sql",
+						pkgutils.AnnotationDeniedPackagesKey:  "mysql",
+					},
+				},
+				Spec: appRepov1alpha1.AppRepositorySpec{
+					URL:  "https://example.com",
+					Type: "helm",
+				},
+			},
+		},
+		{
+			name: "[custom details] create repository fails with invalid package policies",
+			request: newPackageRepoRequestWithDetails(&v1alpha1.HelmPackageRepositoryCustomDetail{
+				AllowedPackages: []string{"[a-"},
+			}),
+			errorCode: connect.CodeInvalidArgument,
+		},
 		{
 			name: "[custom details] repository is created with pod template options",
 			request: newPackageRepoRequestWithDetails(&v1alpha1.HelmPackageRepositoryCustomDetail{
@@ -809,6 +945,7 @@ func TestAddPackageRepository(t *testing.T) {
 				secrets = append(secrets, tc.existingDockerSecret)
 			}
 			s := newServerWithSecretsAndRepos(t, secrets, nil, nil)
+			s.pluginConfig.OCICredentialProviderAllowedRegistries = tc.ociCredentialProviderAllowedRegistries
 			if tc.testRepoServer != nil {
 				defer tc.testRepoServer.Close()
 				s.repoClientGetter = func(_ *appRepov1.AppRepository, _ *apiv1.Secret) (*http.Client, error) {
@@ -993,6 +1130,16 @@ func TestGetPackageRepositorySummaries(t *testing.T) {
 						Status:          &corev1.PackageRepositoryStatus{Ready: true},
 					},
 				},
+				PartialResults: &corev1.PartialResults{
+					NamespaceFailures: []*corev1.NamespaceFailure{
+						{
+							Context: &corev1.Context{Cluster: KubeappsCluster, Namespace: "ns-inaccessible"},
+							Plugin:  GetPluginDetail(),
+							Code:    connect.CodePermissionDenied.String(),
+							Reason:  "Forbidden to list the AppRepository 'all' due to 'AppRepository.authorization.k8s.io is forbidden: bang'",
+						},
+					},
+				},
 			},
 		},
 		{
@@ -1064,6 +1211,8 @@ func TestGetPackageRepositorySummaries(t *testing.T) {
 				corev1.PackageRepositoryReference{},
 				corev1.PackageRepositoryStatus{},
 				corev1.PackageRepositoryAuth{},
+				corev1.PartialResults{},
+				corev1.NamespaceFailure{},
 			)
 			opts2 := cmpopts.SortSlices(lessPackageRepositorySummaryFunc)
 			if got, want := response.Msg, tc.expectedResponse; !cmp.Equal(want, got, opts, opts2) {
@@ -2104,6 +2253,274 @@ func TestDeletePackageRepository(t *testing.T) {
 	}
 }
 
+func TestDeletePackageRepositoryWithReferencedSecrets(t *testing.T) {
+	ownedRepo := &appRepov1alpha1.AppRepository{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: appReposAPIVersion,
+			Kind:       AppRepositoryKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "repo-1",
+			Namespace:       "ns-1",
+			UID:             "repo-1-uid",
+			ResourceVersion: "1",
+		},
+		Spec: appRepov1alpha1.AppRepositorySpec{
+			URL:  "https://test-repo",
+			Type: "helm",
+			Auth: appRepov1alpha1.AppRepositoryAuth{
+				Header: &appRepov1alpha1.AppRepositoryAuthHeader{
+					SecretKeyRef: apiv1.SecretKeySelector{LocalObjectReference: apiv1.LocalObjectReference{Name: "apprepo-repo-1"}, Key: SecretAuthHeaderKey},
+				},
+			},
+		},
+	}
+	managedSecret := &apiv1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "apprepo-repo-1",
+			Namespace:   "ns-1",
+			Annotations: map[string]string{Annotation_ManagedBy_Key: Annotation_ManagedBy_Value},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(ownedRepo, appRepov1alpha1.SchemeGroupVersion.WithKind(AppRepositoryKind)),
+			},
+		},
+		Data: map[string][]byte{SecretAuthHeaderKey: []byte("Bearer token")},
+	}
+	referencingRepo := &appRepov1alpha1.AppRepository{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: appReposAPIVersion,
+			Kind:       AppRepositoryKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "repo-shared",
+			Namespace:       "ns-1",
+			ResourceVersion: "1",
+		},
+		Spec: appRepov1alpha1.AppRepositorySpec{
+			URL:  "https://test-repo-mirror",
+			Type: "helm",
+			Auth: appRepov1alpha1.AppRepositoryAuth{
+				Header: &appRepov1alpha1.AppRepositoryAuthHeader{
+					SecretKeyRef: apiv1.SecretKeySelector{LocalObjectReference: apiv1.LocalObjectReference{Name: "apprepo-repo-1"}, Key: SecretAuthHeaderKey},
+				},
+			},
+		},
+	}
+	expectedUsage := &corev1.PackageRepositorySecretUsage{
+		SecretName: "apprepo-repo-1",
+		ReferencingResources: []*corev1.ResourceRef{
+			{ApiVersion: "kubeapps.com/v1alpha1", Kind: AppRepositoryKind, Name: "repo-shared", Namespace: "ns-1"},
+		},
+	}
+
+	testCases := []struct {
+		name                    string
+		repos                   []*appRepov1alpha1.AppRepository
+		retainReferencedSecrets bool
+		expectedErrorCode       connect.Code
+		expectedResponse        *corev1.DeletePackageRepositoryResponse
+		expectedOwnerReferences int
+	}{
+		{
+			name:                    "it deletes a repository whose secret is not referenced elsewhere",
+			repos:                   []*appRepov1alpha1.AppRepository{ownedRepo},
+			expectedResponse:        &corev1.DeletePackageRepositoryResponse{},
+			expectedOwnerReferences: 1,
+		},
+		{
+			name:                    "it does not delete a repository whose secret is referenced by another repository",
+			repos:                   []*appRepov1alpha1.AppRepository{ownedRepo, referencingRepo},
+			expectedErrorCode:       connect.CodeFailedPrecondition,
+			expectedOwnerReferences: 1,
+		},
+		{
+			name:                    "it deletes the repository but retains its referenced secret when requested",
+			repos:                   []*appRepov1alpha1.AppRepository{ownedRepo, referencingRepo},
+			retainReferencedSecrets: true,
+			expectedResponse: &corev1.DeletePackageRepositoryResponse{
+				RetainedSecrets: []*corev1.PackageRepositorySecretUsage{expectedUsage},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var unstructuredObjects []k8sruntime.Object
+			for _, obj := range tc.repos {
+				unstructuredContent, _ := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(obj)
+				unstructuredObjects = append(unstructuredObjects, &unstructured.Unstructured{Object: unstructuredContent})
+			}
+			s := newServerWithSecretsAndRepos(t, []k8sruntime.Object{managedSecret.DeepCopy()}, unstructuredObjects, tc.repos)
+
+			response, err := s.DeletePackageRepository(context.Background(), connect.NewRequest(&corev1.DeletePackageRepositoryRequest{
+				PackageRepoRef: &corev1.PackageRepositoryReference{
+					Plugin:     plugin,
+					Context:    &corev1.Context{Namespace: "ns-1", Cluster: KubeappsCluster},
+					Identifier: "repo-1",
+				},
+				RetainReferencedSecrets: tc.retainReferencedSecrets,
+			}))
+
+			if got, want := connect.CodeOf(err), tc.expectedErrorCode; err != nil && got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			} else if err == nil && tc.expectedErrorCode != 0 {
+				t.Fatalf("got: nil, want: error with code %+v", tc.expectedErrorCode)
+			}
+			if err != nil {
+				connectErr := err.(*connect.Error)
+				if got, want := len(connectErr.Details()), 1; got != want {
+					t.Fatalf("got: %d, want: %d", got, want)
+				}
+				detail, err := connectErr.Details()[0].Value()
+				if err != nil {
+					t.Fatalf("%+v", err)
+				}
+				if got, want := detail, expectedUsage; !cmp.Equal(want, got, protocmp.Transform()) {
+					t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, protocmp.Transform()))
+				}
+			} else if got, want := response.Msg, tc.expectedResponse; !cmp.Equal(want, got, protocmp.Transform()) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, protocmp.Transform()))
+			}
+
+			typedClient, err := s.clientGetter.Typed(http.Header{}, s.kubeappsCluster)
+			if err != nil {
+				t.Fatal(err)
+			}
+			secret, err := typedClient.CoreV1().Secrets("ns-1").Get(context.Background(), "apprepo-repo-1", metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := len(secret.GetOwnerReferences()), tc.expectedOwnerReferences; got != want {
+				t.Errorf("got: %d, want: %d", got, want)
+			}
+		})
+	}
+}
+
+func TestListPackageRepositoryPackages(t *testing.T) {
+	repos := []*appRepov1alpha1.AppRepository{repo1}
+	charts := []*models.Chart{
+		makeChart("chart-1", "repo-1", "http://chart-1", "ns-1", []string{"1.0.0"}, DefaultChartCategory),
+		makeChart("chart-2", "repo-1", "http://chart-2", "ns-1", []string{"1.0.0"}, DefaultChartCategory),
+	}
+
+	testCases := []struct {
+		name                  string
+		request               *corev1.ListPackageRepositoryPackagesRequest
+		lastUpdateRows        *sqlmock.Rows
+		expectedIdentifiers   []string
+		expectedNextPageToken string
+		expectedLastUpdated   string
+		expectedErrorCode     connect.Code
+	}{
+		{
+			name: "it returns the charts of the repository with its last update",
+			request: &corev1.ListPackageRepositoryPackagesRequest{
+				PackageRepoRef: &corev1.PackageRepositoryReference{
+					Plugin:     plugin,
+					Context:    &corev1.Context{Namespace: "ns-1", Cluster: KubeappsCluster},
+					Identifier: "repo-1",
+				},
+			},
+			// The asset-syncer stores the time using time.Time.String()
+			lastUpdateRows:      sqlmock.NewRows([]string{"last_update"}).AddRow("2023-03-01 10:00:00.123456 +0100 CET m=+12.345678"),
+			expectedIdentifiers: []string{"repo-1/chart-1", "repo-1/chart-2"},
+			expectedLastUpdated: "2023-03-01T09:00:00Z",
+		},
+		{
+			name: "it returns a page of the charts of a repository not synced yet",
+			request: &corev1.ListPackageRepositoryPackagesRequest{
+				PackageRepoRef: &corev1.PackageRepositoryReference{
+					Plugin:     plugin,
+					Context:    &corev1.Context{Namespace: "ns-1", Cluster: KubeappsCluster},
+					Identifier: "repo-1",
+				},
+				PaginationOptions: &corev1.PaginationOptions{PageSize: 1},
+			},
+			lastUpdateRows:        sqlmock.NewRows([]string{"last_update"}),
+			expectedIdentifiers:   []string{"repo-1/chart-1"},
+			expectedNextPageToken: "1",
+		},
+		{
+			name: "it returns not found for an unknown repository",
+			request: &corev1.ListPackageRepositoryPackagesRequest{
+				PackageRepoRef: &corev1.PackageRepositoryReference{
+					Plugin:     plugin,
+					Context:    &corev1.Context{Namespace: "ns-1", Cluster: KubeappsCluster},
+					Identifier: "repo-2",
+				},
+			},
+			expectedErrorCode: connect.CodeNotFound,
+		},
+		{
+			name: "it returns invalid argument without a namespace",
+			request: &corev1.ListPackageRepositoryPackagesRequest{
+				PackageRepoRef: &corev1.PackageRepositoryReference{
+					Plugin:     plugin,
+					Identifier: "repo-1",
+				},
+			},
+			expectedErrorCode: connect.CodeInvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := newServerWithSecretsAndRepos(t, nil, nil, repos)
+			mock, cleanup, manager := setMockManager(t)
+			defer cleanup()
+			s.manager = manager
+
+			if tc.expectedErrorCode == 0 {
+				// Only the charts of the repository in its namespace are
+				// queried, excluding a global repository with the same name.
+				queryArgs := []sqldriver.Value{"ns-1", globalPackagingNamespace, "repo-1", globalPackagingNamespace, "repo-1"}
+				rows := sqlmock.NewRows([]string{"info"})
+				for _, row := range makeChartRowsJSON(t, charts, tc.request.GetPaginationOptions().GetPageToken(), int(tc.request.GetPaginationOptions().GetPageSize())) {
+					rows.AddRow(row)
+				}
+				mock.ExpectQuery("SELECT info FROM").
+					WithArgs(queryArgs...).
+					WillReturnRows(rows)
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM")).
+					WithArgs(queryArgs...).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(len(charts)))
+				mock.ExpectQuery("SELECT last_update FROM").
+					WithArgs("ns-1", "repo-1").
+					WillReturnRows(tc.lastUpdateRows)
+			}
+
+			response, err := s.ListPackageRepositoryPackages(context.Background(), connect.NewRequest(tc.request))
+
+			if got, want := connect.CodeOf(err), tc.expectedErrorCode; err != nil && got != want {
+				t.Fatalf("got error: %d, want: %d, err: %+v", got, want, err)
+			} else if got != 0 {
+				return
+			}
+
+			identifiers := []string{}
+			for _, summary := range response.Msg.AvailablePackageSummaries {
+				identifiers = append(identifiers, summary.AvailablePackageRef.Identifier)
+			}
+			if got, want := identifiers, tc.expectedIdentifiers; !cmp.Equal(got, want) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+			if got, want := response.Msg.NextPageToken, tc.expectedNextPageToken; got != want {
+				t.Errorf("got: %q, want: %q", got, want)
+			}
+			if got, want := response.Msg.GetTotalCount(), int32(len(charts)); got != want {
+				t.Errorf("got: %d, want: %d", got, want)
+			}
+			if got, want := response.Msg.LastUpdated, tc.expectedLastUpdated; got != want {
+				t.Errorf("got: %q, want: %q", got, want)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
 func TestGetPackageRepositoryPermissions(t *testing.T) {
 
 	testCases := []struct {
@@ -76,11 +76,15 @@ func (s *Server) getValidator(appRepo *apprepov1alpha1.AppRepository, secret *co
 	}
 }
 
-func newRepositoryClient(appRepo *apprepov1alpha1.AppRepository, secret *corev1.Secret) (*http.Client, error) {
-	if cli, err := helm.InitNetClient(appRepo, secret, secret, nil); err != nil {
-		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("Unable to create HTTP client for repository: %w", err))
-	} else {
-		return cli, nil
+// newRepositoryClientGetter returns a repositoryClientGetter whose clients
+// trust the given CA bundle in addition to the custom CA of the repository.
+func newRepositoryClientGetter(caBundle []byte) repositoryClientGetter {
+	return func(appRepo *apprepov1alpha1.AppRepository, secret *corev1.Secret) (*http.Client, error) {
+		if cli, err := helm.InitNetClient(appRepo, secret, secret, caBundle, nil); err != nil {
+			return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("Unable to create HTTP client for repository: %w", err))
+		} else {
+			return cli, nil
+		}
 	}
 }
 
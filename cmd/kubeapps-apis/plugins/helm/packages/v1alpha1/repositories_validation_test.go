@@ -399,7 +399,7 @@ func TestOCIValidate(t *testing.T) {
 			tc.validator.AppRepo.Spec.URL = fmt.Sprintf("%s/%s", registryURL, registryName)
 
 			// Use the actual client getter since we're using a test double.
-			tc.validator.ClientGetter = newRepositoryClient
+			tc.validator.ClientGetter = newRepositoryClientGetter(nil)
 
 			response, err := tc.validator.Validate(context.TODO())
 			if err != nil {
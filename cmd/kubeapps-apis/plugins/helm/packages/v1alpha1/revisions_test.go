@@ -0,0 +1,207 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	helmv1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/plugins/helm/packages/v1alpha1"
+	"helm.sh/helm/v3/pkg/release"
+)
+
+var apacheReleaseHistory = []releaseStub{
+	{
+		name:         "my-apache",
+		namespace:    "default",
+		chartID:      "bitnami/apache",
+		chartVersion: "1.18.3",
+		status:       release.StatusDeployed,
+		version:      2,
+		values:       `{"replicaCount": 2}`,
+		manifest:     "apiVersion: apps/v1\nkind: Deployment\n",
+	},
+	{
+		name:         "my-apache",
+		namespace:    "default",
+		chartID:      "bitnami/apache",
+		chartVersion: "1.18.2",
+		status:       release.StatusSuperseded,
+		version:      1,
+		manifest:     "apiVersion: v1\nkind: Service\n",
+	},
+}
+
+func apacheInstalledPackageRef(identifier string) *corev1.InstalledPackageReference {
+	return &corev1.InstalledPackageReference{
+		Context: &corev1.Context{
+			Cluster:   "default",
+			Namespace: "default",
+		},
+		Identifier: identifier,
+	}
+}
+
+func TestGetInstalledPackageRevisions(t *testing.T) {
+	testCases := []struct {
+		name              string
+		existingReleases  []releaseStub
+		request           *helmv1.GetInstalledPackageRevisionsRequest
+		expectedResponse  *helmv1.GetInstalledPackageRevisionsResponse
+		expectedErrorCode connect.Code
+	}{
+		{
+			name:             "returns the revisions from the latest to the oldest",
+			existingReleases: apacheReleaseHistory,
+			request: &helmv1.GetInstalledPackageRevisionsRequest{
+				InstalledPackageRef: apacheInstalledPackageRef("my-apache"),
+			},
+			expectedResponse: &helmv1.GetInstalledPackageRevisionsResponse{
+				Revisions: []*helmv1.InstalledPackageRevision{
+					{
+						ReleaseRevision: 2,
+						Status:          "deployed",
+						ChartVersion:    "1.18.3",
+						AppVersion:      DefaultAppVersion,
+					},
+					{
+						ReleaseRevision: 1,
+						Status:          "superseded",
+						ChartVersion:    "1.18.2",
+						AppVersion:      DefaultAppVersion,
+					},
+				},
+			},
+		},
+		{
+			name: "returns not found if installed package doesn't exist",
+			request: &helmv1.GetInstalledPackageRevisionsRequest{
+				InstalledPackageRef: apacheInstalledPackageRef("not-a-valid-identifier"),
+			},
+			expectedErrorCode: connect.CodeNotFound,
+		},
+	}
+
+	ignoredUnexported := cmpopts.IgnoreUnexported(
+		helmv1.GetInstalledPackageRevisionsResponse{},
+		helmv1.InstalledPackageRevision{},
+	)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actionConfig := newActionConfigFixture(t, tc.request.GetInstalledPackageRef().GetContext().GetNamespace(), tc.existingReleases, nil)
+			server, _, cleanup := makeServer(t, true, actionConfig)
+			defer cleanup()
+
+			response, err := server.GetInstalledPackageRevisions(context.Background(), connect.NewRequest(tc.request))
+
+			if got, want := connect.CodeOf(err), tc.expectedErrorCode; err != nil && got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+			if tc.expectedErrorCode != 0 {
+				return
+			}
+
+			if got, want := response.Msg, tc.expectedResponse; !cmp.Equal(got, want, ignoredUnexported) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoredUnexported))
+			}
+		})
+	}
+}
+
+func TestGetInstalledPackageRevision(t *testing.T) {
+	testCases := []struct {
+		name              string
+		existingReleases  []releaseStub
+		request           *helmv1.GetInstalledPackageRevisionRequest
+		expectedResponse  *helmv1.GetInstalledPackageRevisionResponse
+		expectedErrorCode connect.Code
+	}{
+		{
+			name:             "returns the manifest and values of the latest revision",
+			existingReleases: apacheReleaseHistory,
+			request: &helmv1.GetInstalledPackageRevisionRequest{
+				InstalledPackageRef: apacheInstalledPackageRef("my-apache"),
+				ReleaseRevision:     2,
+			},
+			expectedResponse: &helmv1.GetInstalledPackageRevisionResponse{
+				Revision: &helmv1.InstalledPackageRevision{
+					ReleaseRevision: 2,
+					Status:          "deployed",
+					ChartVersion:    "1.18.3",
+					AppVersion:      DefaultAppVersion,
+				},
+				Manifest:      "apiVersion: apps/v1\nkind: Deployment\n",
+				ValuesApplied: `{"replicaCount":2}`,
+			},
+		},
+		{
+			name:             "returns the manifest of a previous revision",
+			existingReleases: apacheReleaseHistory,
+			request: &helmv1.GetInstalledPackageRevisionRequest{
+				InstalledPackageRef: apacheInstalledPackageRef("my-apache"),
+				ReleaseRevision:     1,
+			},
+			expectedResponse: &helmv1.GetInstalledPackageRevisionResponse{
+				Revision: &helmv1.InstalledPackageRevision{
+					ReleaseRevision: 1,
+					Status:          "superseded",
+					ChartVersion:    "1.18.2",
+					AppVersion:      DefaultAppVersion,
+				},
+				Manifest: "apiVersion: v1\nkind: Service\n",
+			},
+		},
+		{
+			name:             "returns not found for a revision which doesn't exist",
+			existingReleases: apacheReleaseHistory,
+			request: &helmv1.GetInstalledPackageRevisionRequest{
+				InstalledPackageRef: apacheInstalledPackageRef("my-apache"),
+				ReleaseRevision:     3,
+			},
+			expectedErrorCode: connect.CodeNotFound,
+		},
+		{
+			name:             "returns invalid argument without a revision",
+			existingReleases: apacheReleaseHistory,
+			request: &helmv1.GetInstalledPackageRevisionRequest{
+				InstalledPackageRef: apacheInstalledPackageRef("my-apache"),
+			},
+			expectedErrorCode: connect.CodeInvalidArgument,
+		},
+	}
+
+	ignoredUnexported := cmpopts.IgnoreUnexported(
+		helmv1.GetInstalledPackageRevisionResponse{},
+		helmv1.InstalledPackageRevision{},
+	)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actionConfig := newActionConfigFixture(t, tc.request.GetInstalledPackageRef().GetContext().GetNamespace(), tc.existingReleases, nil)
+			server, _, cleanup := makeServer(t, true, actionConfig)
+			defer cleanup()
+
+			response, err := server.GetInstalledPackageRevision(context.Background(), connect.NewRequest(tc.request))
+
+			if got, want := connect.CodeOf(err), tc.expectedErrorCode; err != nil && got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+			if tc.expectedErrorCode != 0 {
+				if err == nil {
+					t.Fatalf("got: nil, want: error with code %+v", tc.expectedErrorCode)
+				}
+				return
+			}
+
+			if got, want := response.Msg, tc.expectedResponse; !cmp.Equal(got, want, ignoredUnexported) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoredUnexported))
+			}
+		})
+	}
+}
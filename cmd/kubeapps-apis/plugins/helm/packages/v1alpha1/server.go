@@ -5,6 +5,7 @@ package main
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -13,6 +14,9 @@ import (
 	"os"
 	"path"
 	"strings"
+	"sync"
+	"text/template"
+	"time"
 
 	"github.com/bufbuild/connect-go"
 	appRepov1 "github.com/vmware-tanzu/kubeapps/cmd/apprepository-controller/pkg/apis/apprepository/v1alpha1"
@@ -23,25 +27,33 @@ import (
 	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/helm/packages/v1alpha1/common"
 	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/helm/packages/v1alpha1/utils"
 	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/clientgetter"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/connecterror"
 	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/helm"
 	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/helm/agent"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/k8sutils"
 	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/paginate"
 	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/pkgutils"
 	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/resourcerefs"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/resources"
 	"github.com/vmware-tanzu/kubeapps/pkg/chart/models"
 	"github.com/vmware-tanzu/kubeapps/pkg/dbutils"
 	"github.com/vmware-tanzu/kubeapps/pkg/kube"
+	"github.com/vmware-tanzu/kubeapps/pkg/ocicredentials"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
 	"helm.sh/helm/v3/pkg/action"
 	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chartutil"
 	"helm.sh/helm/v3/pkg/release"
 	"helm.sh/helm/v3/pkg/storage/driver"
 	authorizationv1 "k8s.io/api/authorization/v1"
 	corek8sv1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/rand"
 	"k8s.io/client-go/kubernetes"
 	log "k8s.io/klog/v2"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
@@ -55,6 +67,12 @@ var _ corev1connect.RepositoriesServiceHandler = (*Server)(nil)
 
 const (
 	UserAgentPrefix = "kubeapps-apis/plugins"
+	// CapacityRequirementsAnnotation is the chart annotation describing the
+	// capacity required by the chart, such as "cpu: 1, memory: 2Gi".
+	CapacityRequirementsAnnotation = "kubeapps.dev/capacity-requirements"
+	// ChartURLAnnotation is the chart annotation recording the identifier of
+	// a chart installed from its tarball URL, so that it can be upgraded.
+	ChartURLAnnotation = "kubeapps.dev/chart-url"
 )
 
 type createRelease func(*action.Configuration, string, string, string, *chart.Chart, map[string]string, int32) (*release.Release, error)
@@ -79,14 +97,21 @@ type Server struct {
 	kubeappsCluster                 string // Specifies the cluster on which Kubeapps is installed.
 	kubeappsNamespace               string // Namespace in which Kubeapps is installed
 	pluginConfig                    *common.HelmPluginConfig
-	repoClientGetter                repositoryClientGetter
-	clientQPS                       float32
-	OCICatalogAddr                  string
+	// pluginConfigMutex guards the pluginConfig, which is replaced when the
+	// plugin configuration file is reloaded.
+	pluginConfigMutex sync.RWMutex
+	repoClientGetter  repositoryClientGetter
+	clientQPS         float32
+	OCICatalogAddr    string
+	// vulnerabilityScanner requests the vulnerability scanner configured for
+	// the plugin, if any.
+	vulnerabilityScanner *vulnerabilityScanner
 }
 
 // NewServer returns a Server automatically configured with a function to obtain
-// the k8s client config.
-func NewServer(configGetter core.KubernetesConfigGetter, globalPackagingCluster string, globalPackagingNamespace string, clientQPS float32, clientBurst int, pluginConfigPath string) *Server {
+// the k8s client config. The caBundle is trusted, in addition to the custom CA
+// of each repository, when requesting the repositories.
+func NewServer(configGetter core.KubernetesConfigGetter, globalPackagingCluster string, globalPackagingNamespace string, clientQPS float32, clientBurst int, pluginConfigPath string, caBundle []byte) *Server {
 	var ASSET_SYNCER_DB_URL = os.Getenv("ASSET_SYNCER_DB_URL")
 	var ASSET_SYNCER_DB_NAME = os.Getenv("ASSET_SYNCER_DB_NAME")
 	var ASSET_SYNCER_DB_USERNAME = os.Getenv("ASSET_SYNCER_DB_USERNAME")
@@ -148,6 +173,11 @@ func NewServer(configGetter core.KubernetesConfigGetter, globalPackagingCluster
 		log.Fatalf("%s", err)
 	}
 
+	scanner, err := newVulnerabilityScanner(caBundle)
+	if err != nil {
+		log.Fatalf("%s", err)
+	}
+
 	return &Server{
 		clientGetter: clientProvider,
 		// Get the "in-cluster" client getter
@@ -166,13 +196,40 @@ func NewServer(configGetter core.KubernetesConfigGetter, globalPackagingCluster
 		kubeappsNamespace:        kubeappsNamespace,
 		globalPackagingNamespace: globalPackagingNamespace,
 		globalPackagingCluster:   globalPackagingCluster,
-		chartClientFactory:       &utils.ChartClientFactory{},
+		chartClientFactory:       &utils.ChartClientFactory{CABundle: caBundle, TokenCache: ocicredentials.NewTokenCache(ocicredentials.DefaultHelpers())},
 		pluginConfig:             pluginConfig,
 		createReleaseFunc:        agent.CreateRelease,
-		repoClientGetter:         newRepositoryClient,
+		repoClientGetter:         newRepositoryClientGetter(caBundle),
 		clientQPS:                clientQPS,
 		OCICatalogAddr:           OCI_CATALOG_URL,
+		vulnerabilityScanner:     scanner,
+	}
+}
+
+// config returns the plugin configuration currently in effect.
+func (s *Server) config() *common.HelmPluginConfig {
+	s.pluginConfigMutex.RLock()
+	defer s.pluginConfigMutex.RUnlock()
+	return s.pluginConfig
+}
+
+// reloadPluginConfig parses the plugin configuration file and, if valid,
+// replaces the configuration in effect, which is returned. As the asset
+// manager is scoped to the global packaging namespace when the server is
+// created, changing it requires a restart.
+func (s *Server) reloadPluginConfig(pluginConfigPath string) (interface{}, error) {
+	pluginConfig, err := common.ParsePluginConfig(pluginConfigPath)
+	if err != nil {
+		return nil, err
 	}
+	if current := s.config(); pluginConfig.GlobalPackagingNamespace != current.GlobalPackagingNamespace {
+		return nil, fmt.Errorf("changing the globalPackagingNamespace from %q to %q requires a restart", current.GlobalPackagingNamespace, pluginConfig.GlobalPackagingNamespace)
+	}
+	s.pluginConfigMutex.Lock()
+	s.pluginConfig = pluginConfig
+	s.pluginConfigMutex.Unlock()
+	log.Infof("+helm reloaded custom config: [%v]", *pluginConfig)
+	return pluginConfig, nil
 }
 
 func (s *Server) MaxWorkers() int {
@@ -191,42 +248,32 @@ func (s *Server) GetManager() (utils.AssetManager, error) {
 // GetGlobalPackagingNamespace returns the configured global packaging namespace in in the plugin config if any,
 // otherwise it uses the one passed as a cmd argument to the kubeapps-apis server for backwards compatibility.
 func (s *Server) GetGlobalPackagingNamespace() string {
-	if s.pluginConfig.GlobalPackagingNamespace != "" {
-		return s.pluginConfig.GlobalPackagingNamespace
+	if s.config().GlobalPackagingNamespace != "" {
+		return s.config().GlobalPackagingNamespace
 	} else {
 		return s.globalPackagingNamespace
 	}
 }
 
+// Probe checks that the AppRepository CRD used by the plugin is served in the
+// kubeapps cluster.
+func (s *Server) Probe(ctx context.Context) error {
+	typedClient, err := s.localServiceAccountClientGetter.Typed(ctx)
+	if err != nil {
+		return err
+	}
+	return k8sutils.CheckServedResources(typedClient.Discovery(), appRepov1.SchemeGroupVersion.WithResource(AppRepositoryResource))
+}
+
 // GetAvailablePackageSummaries returns the available packages based on the request.
 func (s *Server) GetAvailablePackageSummaries(ctx context.Context, request *connect.Request[corev1.GetAvailablePackageSummariesRequest]) (*connect.Response[corev1.GetAvailablePackageSummariesResponse], error) {
 	log.InfoS("+helm GetAvailablePackageSummaries", "cluster", request.Msg.GetContext().GetCluster(), "namespace", request.Msg.GetContext().GetNamespace())
 
-	namespace := request.Msg.GetContext().GetNamespace()
-	cluster := request.Msg.GetContext().GetCluster()
-
-	// Check the requested namespace: if any, return "everything a user can read";
-	// otherwise, first check if the user can access the requested ns
-	if namespace == "" {
-		// TODO(agamez): not including a namespace means that it returns everything a user can read
-		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("Not supported yet: not including a namespace means that it returns everything a user can read"))
-	}
-	// After requesting a specific namespace, we have to ensure the user can actually access to it
-	if err := s.hasAccessToNamespace(request.Header(), cluster, namespace); err != nil {
+	cq, err := s.availablePackagesChartQuery(ctx, request.Header(), request.Msg.GetContext().GetCluster(), request.Msg.GetContext().GetNamespace())
+	if err != nil {
 		return nil, err
 	}
 
-	// If the request is for available packages on another cluster, we only
-	// return the global packages (ie. kubeapps namespace)
-	if cluster != "" && cluster != s.globalPackagingCluster {
-		namespace = s.GetGlobalPackagingNamespace()
-	}
-
-	// Create the initial chart query with the namespace
-	cq := utils.ChartQuery{
-		Namespace: namespace,
-	}
-
 	// Add any other filter if a FilterOptions is passed
 	if request.Msg.FilterOptions != nil {
 		cq.Categories = request.Msg.FilterOptions.Categories
@@ -244,7 +291,7 @@ func (s *Server) GetAvailablePackageSummaries(ctx context.Context, request *conn
 
 	// This plugin will include, as part of the GetAvailablePackageSummariesResponse,
 	// a "Categories" field containing only the distinct category names considering just the namespace
-	chartCategories, err := s.manager.GetAllChartCategories(utils.ChartQuery{Namespace: namespace})
+	chartCategories, err := s.manager.GetAllChartCategories(utils.ChartQuery{Namespace: cq.Namespace, ExcludedRepos: cq.ExcludedRepos, PackagePolicies: cq.PackagePolicies})
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to fetch chart categories: %w", err))
 	}
@@ -254,6 +301,15 @@ func (s *Server) GetAvailablePackageSummaries(ctx context.Context, request *conn
 		categories = append(categories, cat.Name)
 	}
 
+	// Charts have no provider, so none matches a filter on providers.
+	if len(request.Msg.GetFilterOptions().GetProviders()) > 0 {
+		return connect.NewResponse(&corev1.GetAvailablePackageSummariesResponse{
+			AvailablePackageSummaries: []*corev1.AvailablePackageSummary{},
+			Categories:                categories,
+			TotalSize:                 proto.Int32(0),
+		}), nil
+	}
+
 	charts, err := s.manager.GetPaginatedChartListWithFilters(cq, itemOffset, int(pageSize))
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to retrieve charts: %w", err))
@@ -277,13 +333,96 @@ func (s *Server) GetAvailablePackageSummaries(ctx context.Context, request *conn
 	if pageSize > 0 && len(responsePackages) == int(pageSize) {
 		nextPageToken = fmt.Sprintf("%d", itemOffset+int(pageSize))
 	}
+
+	// The total size is known from the results when they include the last
+	// chart, otherwise the charts matching the query are counted.
+	totalSize := int32(itemOffset + len(responsePackages))
+	if pageSize > 0 && (len(responsePackages) == int(pageSize) || (len(responsePackages) == 0 && itemOffset > 0)) {
+		count, err := s.manager.GetChartCountWithFilters(cq)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to count charts: %w", err))
+		}
+		totalSize = int32(count)
+	}
 	return connect.NewResponse(&corev1.GetAvailablePackageSummariesResponse{
 		AvailablePackageSummaries: responsePackages,
 		NextPageToken:             nextPageToken,
 		Categories:                categories,
+		TotalSize:                 proto.Int32(totalSize),
+	}), nil
+}
+
+// GetAvailablePackageFilters returns the distinct categories of the charts available in the namespace
+func (s *Server) GetAvailablePackageFilters(ctx context.Context, request *connect.Request[corev1.GetAvailablePackageFiltersRequest]) (*connect.Response[corev1.GetAvailablePackageFiltersResponse], error) {
+	log.InfoS("+helm GetAvailablePackageFilters", "cluster", request.Msg.GetContext().GetCluster(), "namespace", request.Msg.GetContext().GetNamespace())
+
+	cq, err := s.availablePackagesChartQuery(ctx, request.Header(), request.Msg.GetContext().GetCluster(), request.Msg.GetContext().GetNamespace())
+	if err != nil {
+		return nil, err
+	}
+
+	chartCategories, err := s.manager.GetAllChartCategories(cq)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to fetch chart categories: %w", err))
+	}
+	categories := []string{}
+	for _, cat := range chartCategories {
+		categories = append(categories, cat.Name)
+	}
+
+	// Charts have no provider.
+	return connect.NewResponse(&corev1.GetAvailablePackageFiltersResponse{
+		Categories: pkgutils.SortedDistinct(categories),
+		Providers:  []string{},
 	}), nil
 }
 
+// availablePackagesChartQuery returns the chart query for the packages
+// available in the requested namespace, once checked that the user can access
+// it, excluding the repositories whose packages cannot be installed there.
+func (s *Server) availablePackagesChartQuery(ctx context.Context, headers http.Header, cluster, requestedNamespace string) (utils.ChartQuery, error) {
+	namespace := requestedNamespace
+	// Check the requested namespace: if any, return "everything a user can read";
+	// otherwise, first check if the user can access the requested ns
+	if namespace == "" {
+		// TODO(agamez): not including a namespace means that it returns everything a user can read
+		return utils.ChartQuery{}, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("Not supported yet: not including a namespace means that it returns everything a user can read"))
+	}
+	// After requesting a specific namespace, we have to ensure the user can actually access to it
+	if err := s.hasAccessToNamespace(headers, cluster, namespace); err != nil {
+		return utils.ChartQuery{}, err
+	}
+
+	// If the request is for available packages on another cluster, we only
+	// return the global packages (ie. kubeapps namespace)
+	if cluster != "" && cluster != s.globalPackagingCluster {
+		namespace = s.GetGlobalPackagingNamespace()
+	}
+
+	// Exclude the repositories whose packages cannot be installed in the
+	// requested namespace
+	repoNamespaces := []string{s.GetGlobalPackagingNamespace()}
+	if namespace != s.GetGlobalPackagingNamespace() {
+		repoNamespaces = append(repoNamespaces, namespace)
+	}
+	excludedRepos := s.getRestrictedRepos(ctx, headers, cluster, requestedNamespace, repoNamespaces)
+
+	// Hide the charts that the policies of the repositories do not make available
+	packagePolicies, err := s.getRepoPackagePolicies(ctx, repoNamespaces)
+	if err != nil {
+		return utils.ChartQuery{}, err
+	}
+
+	// Create the initial chart query with the namespace
+	cq := utils.ChartQuery{
+		Namespace:       namespace,
+		ExcludedRepos:   excludedRepos,
+		PackagePolicies: packagePolicies,
+	}
+
+	return cq, nil
+}
+
 // GetAvailablePackageDetail returns the package metadata managed by the 'helm' plugin
 func (s *Server) GetAvailablePackageDetail(ctx context.Context, request *connect.Request[corev1.GetAvailablePackageDetailRequest]) (*connect.Response[corev1.GetAvailablePackageDetailResponse], error) {
 	log.InfoS("+helm GetAvailablePackageDetail", "cluster", request.Msg.GetAvailablePackageRef().GetContext().GetCluster(), "namespace", request.Msg.GetAvailablePackageRef().GetContext().GetNamespace())
@@ -352,6 +491,23 @@ func (s *Server) GetAvailablePackageDetail(ctx context.Context, request *connect
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to parse chart to an availablePackageDetail: %w", err))
 	}
 
+	// Attach the vulnerabilities reported by the scanner configured for the plugin, if any
+	var chartVersion *models.ChartVersion
+	for i := range chart.ChartVersions {
+		if chart.ChartVersions[i].Version == version {
+			chartVersion = &chart.ChartVersions[i]
+		}
+	}
+	if summary := s.scanChartVulnerabilities(ctx, unescapedChartID, chartVersion); summary != nil {
+		customDetail, err := anypb.New(&helmv1.AvailablePackageDetailCustomDataHelm{
+			VulnerabilitySummary: summary,
+		})
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to create Any proto from custom detail: %w", err))
+		}
+		availablePackageDetail.CustomDetail = customDetail
+	}
+
 	return connect.NewResponse(&corev1.GetAvailablePackageDetailResponse{
 		AvailablePackageDetail: availablePackageDetail,
 	}), nil
@@ -362,6 +518,13 @@ func fileIDForChart(id, version string) string {
 	return fmt.Sprintf("%s-%s", id, version)
 }
 
+// GetAvailablePackageTextAsset returns a range of a text asset of a package managed by the 'helm' plugin
+func (s *Server) GetAvailablePackageTextAsset(ctx context.Context, request *connect.Request[corev1.GetAvailablePackageTextAssetRequest]) (*connect.Response[corev1.GetAvailablePackageTextAssetResponse], error) {
+	log.InfoS("+helm GetAvailablePackageTextAsset", "cluster", request.Msg.GetAvailablePackageRef().GetContext().GetCluster(), "namespace", request.Msg.GetAvailablePackageRef().GetContext().GetNamespace(), "asset", request.Msg.GetAsset())
+
+	return pkgutils.GetAvailablePackageTextAsset(ctx, request, s.GetAvailablePackageDetail)
+}
+
 // GetAvailablePackageVersions returns the package versions managed by the 'helm' plugin
 func (s *Server) GetAvailablePackageVersions(ctx context.Context, request *connect.Request[corev1.GetAvailablePackageVersionsRequest]) (*connect.Response[corev1.GetAvailablePackageVersionsResponse], error) {
 	log.InfoS("+helm GetAvailablePackageVersions", "cluster", request.Msg.GetAvailablePackageRef().GetContext().GetCluster(), "namespace", request.Msg.GetAvailablePackageRef().GetContext().GetNamespace())
@@ -392,8 +555,57 @@ func (s *Server) GetAvailablePackageVersions(ctx context.Context, request *conne
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to retrieve chart: %v", err))
 	}
 
+	versions := pkgutils.PackageAppVersionsSummary(chart.ChartVersions, s.config().VersionsInSummary)
+	if err := pkgutils.SetUpgradeImpacts(versions, pkgutils.ChangelogsFromChartVersions(chart.ChartVersions), request.Msg.GetRelativeToVersion()); err != nil {
+		return nil, err
+	}
+
 	return connect.NewResponse(&corev1.GetAvailablePackageVersionsResponse{
-		PackageAppVersions: pkgutils.PackageAppVersionsSummary(chart.ChartVersions, s.pluginConfig.VersionsInSummary),
+		PackageAppVersions: versions,
+	}), nil
+}
+
+// GetAvailablePackageChangelog returns the release notes between two versions of a package managed by the 'helm' plugin
+func (s *Server) GetAvailablePackageChangelog(ctx context.Context, request *connect.Request[corev1.GetAvailablePackageChangelogRequest]) (*connect.Response[corev1.GetAvailablePackageChangelogResponse], error) {
+	log.InfoS("+helm GetAvailablePackageChangelog", "cluster", request.Msg.GetAvailablePackageRef().GetContext().GetCluster(), "namespace", request.Msg.GetAvailablePackageRef().GetContext().GetNamespace(), "from", request.Msg.GetFromVersion(), "to", request.Msg.GetToVersion())
+
+	namespace := request.Msg.GetAvailablePackageRef().GetContext().GetNamespace()
+	if namespace == "" || request.Msg.GetAvailablePackageRef().GetIdentifier() == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Required context or identifier not provided"))
+	}
+	cluster := request.Msg.GetAvailablePackageRef().GetContext().GetCluster()
+	// Currently we support available packages on the kubeapps cluster only.
+	if cluster != "" && cluster != s.globalPackagingCluster {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Requests for changelogs of available packages on clusters other than %q not supported. Requested cluster was %q.", s.globalPackagingCluster, cluster))
+	}
+
+	// After requesting a specific namespace, we have to ensure the user can actually access to it
+	if err := s.hasAccessToNamespace(request.Header(), cluster, namespace); err != nil {
+		return nil, err
+	}
+
+	unescapedChartID, err := pkgutils.GetUnescapedPackageID(request.Msg.GetAvailablePackageRef().GetIdentifier())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	chart, err := s.manager.GetChart(namespace, unescapedChartID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("Unable to find the chart %q in namespace %q", unescapedChartID, namespace))
+	} else if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to retrieve chart: %v", err))
+	}
+
+	// The changes of each version come from its artifacthub.io/changes annotation.
+	changelogs, err := pkgutils.FilterChangelogs(
+		pkgutils.ChangelogsFromChartVersions(chart.ChartVersions),
+		request.Msg.GetFromVersion(),
+		request.Msg.GetToVersion())
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&corev1.GetAvailablePackageChangelogResponse{
+		Changelogs: changelogs,
 	}), nil
 }
 
@@ -498,24 +710,58 @@ func (s *Server) GetInstalledPackageSummaries(ctx context.Context, request *conn
 		cmd.AllNamespaces = true
 	}
 
-	cmd.Limit = int(request.Msg.GetPaginationOptions().GetPageSize())
-	cmd.Offset, err = paginate.ItemOffsetFromPageToken(request.Msg.GetPaginationOptions().GetPageToken())
+	filterOptions := request.Msg.GetFilterOptions()
+	selector, err := pkgutils.InstalledPackageLabelSelector(filterOptions)
 	if err != nil {
 		return nil, err
 	}
+	// Helm matches the selector against the labels of the release storage
+	// objects, and filters the releases by status before paginating them.
+	cmd.Selector = selector.String()
+	if reasons := filterOptions.GetStatusReasons(); len(reasons) > 0 {
+		cmd.StateMask = listStatesForStatusReasons(reasons)
+	}
 
-	// TODO(mnelson): Check whether we need to support ListAll (status == "all" in existing helm support)
-
-	releases, err := cmd.Run()
+	pageSize := request.Msg.GetPaginationOptions().GetPageSize()
+	itemOffset, err := paginate.ItemOffsetFromPageToken(request.Msg.GetPaginationOptions().GetPageToken())
 	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to run Helm List action: %w", err))
+		return nil, err
+	}
+	// Whether a release can be upgraded is only known once its latest version
+	// is looked up below, so in that case all the releases are listed to be
+	// filtered and paginated afterwards.
+	postFilter := filterOptions.GetOnlyUpgradable()
+	if !postFilter {
+		cmd.Limit = int(pageSize)
+		cmd.Offset = itemOffset
 	}
 
-	installedPkgSummaries := make([]*corev1.InstalledPackageSummary, len(releases))
+	// TODO(mnelson): Check whether we need to support ListAll (status == "all" in existing helm support)
+
 	cluster := request.Msg.GetContext().GetCluster()
 	if cluster == "" {
 		cluster = s.globalPackagingCluster
 	}
+	releases, err := cmd.Run()
+	if k8sErrors.IsForbidden(err) && cmd.AllNamespaces {
+		// Catch forbidden errors in cluster-wide listings, listing the releases
+		// in each of the namespaces the user has access to instead
+		log.Warningf("+helm unable to list releases at the cluster scope in '%s' due to [%v]", cluster, err)
+		namespaces, err := s.getAccessibleNamespaces(request.Header(), cluster)
+		if err != nil {
+			return nil, err
+		}
+		return pkgutils.GetInstalledPackageSummariesPerNamespace(ctx, request, cluster, namespaces, GetPluginDetail(), s.GetInstalledPackageSummaries)
+	} else if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to run Helm List action: %w", err))
+	}
+
+	convertedReleases, err := s.convertedReleases(ctx, request.Header(), cluster, request.Msg.GetContext().GetNamespace(), "")
+	if err != nil {
+		return nil, err
+	}
+
+	installedPkgSummaries := make([]*corev1.InstalledPackageSummary, len(releases))
 	for i, r := range releases {
 		installedPkgSummaries[i] = installedPkgSummaryFromRelease(r)
 		installedPkgSummaries[i].InstalledPackageRef.Context.Cluster = cluster
@@ -565,21 +811,57 @@ func (s *Server) GetInstalledPackageSummaries(ctx context.Context, request *conn
 			Reason:     statusReasonForHelmStatus(rel.Info.Status),
 			UserReason: rel.Info.Status.String(),
 		}
+		if helmRelease, ok := convertedReleases[types.NamespacedName{Namespace: rel.Namespace, Name: rel.Name}]; ok {
+			markConvertedRelease(installedPkgSummaries[i].Status, helmRelease)
+		}
 		installedPkgSummaries[i].CurrentVersion = &corev1.PackageAppVersion{
 			PkgVersion: rel.Chart.Metadata.Version,
 			AppVersion: rel.Chart.Metadata.AppVersion,
 		}
 	}
 
+	if postFilter {
+		installedPkgSummaries = pkgutils.FilterInstalledPackageSummaries(installedPkgSummaries, filterOptions)
+		installedPkgSummaries = pkgutils.PaginateInstalledPackageSummaries(installedPkgSummaries, itemOffset, pageSize)
+	}
+
 	response := &corev1.GetInstalledPackageSummariesResponse{
 		InstalledPackageSummaries: installedPkgSummaries,
 	}
-	if cmd.Limit > 0 && len(releases) == cmd.Limit {
-		response.NextPageToken = fmt.Sprintf("%d", cmd.Offset+cmd.Limit)
+	if pageSize > 0 && len(installedPkgSummaries) == int(pageSize) {
+		response.NextPageToken = fmt.Sprintf("%d", itemOffset+int(pageSize))
 	}
 	return connect.NewResponse(response), nil
 }
 
+// helmListStates are the states by which the Helm List action can filter the
+// releases, with their corresponding release status.
+var helmListStates = map[action.ListStates]release.Status{
+	action.ListDeployed:        release.StatusDeployed,
+	action.ListUninstalled:     release.StatusUninstalled,
+	action.ListUninstalling:    release.StatusUninstalling,
+	action.ListPendingInstall:  release.StatusPendingInstall,
+	action.ListPendingUpgrade:  release.StatusPendingUpgrade,
+	action.ListPendingRollback: release.StatusPendingRollback,
+	action.ListSuperseded:      release.StatusSuperseded,
+	action.ListFailed:          release.StatusFailed,
+}
+
+// listStatesForStatusReasons returns the states of the Helm List action
+// matching the releases with one of the given status reasons.
+func listStatesForStatusReasons(reasons []corev1.InstalledPackageStatus_StatusReason) action.ListStates {
+	states := action.ListStates(0)
+	for state, status := range helmListStates {
+		statusReason := statusReasonForHelmStatus(status)
+		for _, reason := range reasons {
+			if reason == statusReason {
+				states |= state
+			}
+		}
+	}
+	return states
+}
+
 func statusReasonForHelmStatus(s release.Status) corev1.InstalledPackageStatus_StatusReason {
 	switch s {
 	case release.StatusDeployed:
@@ -640,6 +922,17 @@ func (s *Server) GetInstalledPackageDetail(ctx context.Context, request *connect
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to create installed package detail from release: %w", err))
 	}
+	cluster := request.Msg.GetInstalledPackageRef().GetContext().GetCluster()
+	if cluster == "" {
+		cluster = s.globalPackagingCluster
+	}
+	convertedReleases, err := s.convertedReleases(ctx, request.Header(), cluster, release.Namespace, release.Name)
+	if err != nil {
+		return nil, err
+	}
+	if helmRelease, ok := convertedReleases[types.NamespacedName{Namespace: release.Namespace, Name: release.Name}]; ok {
+		markConvertedRelease(installedPkgDetail.Status, helmRelease)
+	}
 
 	// Grab the released values.
 	valuescmd := action.NewGetValues(actionConfig)
@@ -653,6 +946,18 @@ func (s *Server) GetInstalledPackageDetail(ctx context.Context, request *connect
 	}
 	installedPkgDetail.ValuesApplied = string(valuesMarshalled)
 
+	// A chart installed from its tarball URL records the identifier of the
+	// URL rather than matching a chart of a repository.
+	if chartURLIdentifier := release.Chart.Metadata.Annotations[ChartURLAnnotation]; chartURLIdentifier != "" {
+		installedPkgDetail.AvailablePackageRef = &corev1.AvailablePackageReference{
+			Identifier: chartURLIdentifier,
+			Plugin:     GetPluginDetail(),
+		}
+		return connect.NewResponse(&corev1.GetInstalledPackageDetailResponse{
+			InstalledPackageDetail: installedPkgDetail,
+		}), nil
+	}
+
 	// Check for a chart matching the installed package.
 	cq := utils.ChartQuery{
 		Namespace: release.Namespace,
@@ -736,25 +1041,57 @@ func installedPkgDetailFromRelease(r *release.Release, ref *corev1.InstalledPack
 func (s *Server) CreateInstalledPackage(ctx context.Context, request *connect.Request[corev1.CreateInstalledPackageRequest]) (*connect.Response[corev1.CreateInstalledPackageResponse], error) {
 	log.InfoS("+helm CreateInstalledPackage", "cluster", request.Msg.GetTargetContext().GetCluster(), "namespace", request.Msg.GetTargetContext().GetNamespace())
 
+	if err := checkNoReleaseMetadata(request.Msg.GetMetadata()); err != nil {
+		return nil, err
+	}
+
 	typedClient, err := s.clientGetter.Typed(request.Header(), s.globalPackagingCluster)
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to create kubernetes clientset: %w", err))
 	}
 	chartID := request.Msg.GetAvailablePackageRef().GetIdentifier()
-	repoNamespace := request.Msg.GetAvailablePackageRef().GetContext().GetNamespace()
-	repoName, chartName, err := pkgutils.SplitPackageIdentifier(chartID)
-	if err != nil {
-		return nil, connect.NewError(connect.CodeInvalidArgument, err)
-	}
-	chartDetails := &utils.ChartDetails{
-		AppRepositoryResourceName:      repoName,
-		AppRepositoryResourceNamespace: repoNamespace,
-		ChartName:                      chartName,
-		Version:                        request.Msg.GetPkgVersionReference().GetVersion(),
+	var chartName string
+	var ch *chart.Chart
+	var registrySecrets map[string]string
+	if isChartURLIdentifier(chartID) {
+		// A chart installed from its tarball URL does not belong to any
+		// repository, so there are neither namespace restrictions nor
+		// registry secrets to apply.
+		ch, err = s.fetchChartFromURL(ctx, chartID)
+		if err != nil {
+			return nil, err
+		}
+		recordChartURLIdentifier(ch, chartID)
+		chartName = ch.Name()
+	} else {
+		repoNamespace := request.Msg.GetAvailablePackageRef().GetContext().GetNamespace()
+		var repoName string
+		repoName, chartName, err = pkgutils.SplitPackageIdentifier(chartID)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+		if err := s.checkRepoAllowsNamespace(ctx, request.Header(), types.NamespacedName{Namespace: repoNamespace, Name: repoName}, request.Msg.GetTargetContext()); err != nil {
+			return nil, err
+		}
+		if err := s.checkRepoAllowsPackage(ctx, types.NamespacedName{Namespace: repoNamespace, Name: repoName}, chartName); err != nil {
+			return nil, err
+		}
+		chartDetails := &utils.ChartDetails{
+			AppRepositoryResourceName:      repoName,
+			AppRepositoryResourceNamespace: repoNamespace,
+			ChartName:                      chartName,
+			Version:                        request.Msg.GetPkgVersionReference().GetVersion(),
+		}
+		if chartDetails.Version, err = s.resolveChartVersion(chartDetails); err != nil {
+			return nil, err
+		}
+		ch, registrySecrets, err = s.fetchChartWithRegistrySecrets(ctx, request.Header(), chartDetails, typedClient)
+		if err != nil {
+			return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("Missing permissions %w", err))
+		}
 	}
-	ch, registrySecrets, err := s.fetchChartWithRegistrySecrets(ctx, request.Header(), chartDetails, typedClient)
-	if err != nil {
-		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("Missing permissions %w", err))
+	if err := validateValues(ch, request.Msg.GetValues()); err != nil {
+		return nil, err
 	}
 
 	// Create an action config for the target namespace.
@@ -763,15 +1100,46 @@ func (s *Server) CreateInstalledPackage(ctx context.Context, request *connect.Re
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to create Helm action config: %w", err))
 	}
 
-	release, err := s.createReleaseFunc(actionConfig, request.Msg.GetName(), request.Msg.GetTargetContext().GetNamespace(), request.Msg.GetValues(), ch, registrySecrets, s.pluginConfig.TimeoutSeconds)
+	releaseName, err := s.resolveReleaseName(actionConfig, request.Msg, chartName)
 	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to create helm release %q in the namespace %q: %w", request.Msg.GetName(), request.Msg.GetTargetContext().GetNamespace(), err))
+		return nil, err
 	}
 
 	cluster := request.Msg.GetTargetContext().GetCluster()
 	if cluster == "" {
 		cluster = s.globalPackagingCluster
 	}
+	targetNamespace := request.Msg.GetTargetContext().GetNamespace()
+	targetClient, err := s.clientGetter.Typed(request.Header(), cluster)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to create kubernetes clientset: %w", err))
+	}
+
+	// Check the capacity required by the chart, if described, against the
+	// quota left in the target namespace.
+	warnings, err := resources.CheckNamespaceCapacity(ctx, targetClient, targetNamespace, chartCapacityRequirements(ch), s.config().CapacityCheck)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create the target namespace if requested, deleting it again if the
+	// release cannot be created so that no empty namespace is left behind.
+	namespaceCreated := false
+	if request.Msg.GetCreateNamespace() {
+		namespaceCreated, err = resources.EnsureNamespace(ctx, targetClient, targetNamespace, s.config().NamespaceCreation)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	release, err := s.createReleaseFunc(actionConfig, releaseName, targetNamespace, request.Msg.GetValues(), ch, registrySecrets, s.config().TimeoutSeconds)
+	if err != nil {
+		if namespaceCreated {
+			resources.DeleteCreatedNamespace(ctx, targetClient, targetNamespace)
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to create helm release %q in the namespace %q: %w", releaseName, targetNamespace, err))
+	}
+
 	return connect.NewResponse(&corev1.CreateInstalledPackageResponse{
 		InstalledPackageRef: &corev1.InstalledPackageReference{
 			Context: &corev1.Context{
@@ -781,6 +1149,251 @@ func (s *Server) CreateInstalledPackage(ctx context.Context, request *connect.Re
 			Identifier: release.Name,
 			Plugin:     GetPluginDetail(),
 		},
+		Warnings: warnings,
+	}), nil
+}
+
+// checkNoReleaseMetadata returns an Unimplemented error if labels or
+// annotations are requested, since Helm releases have none.
+func checkNoReleaseMetadata(metadata *corev1.InstalledPackageMetadata) error {
+	if len(metadata.GetLabels()) > 0 || len(metadata.GetAnnotations()) > 0 {
+		return connect.NewError(connect.CodeUnimplemented, fmt.Errorf("Not supported yet: setting the labels or annotations of a Helm release"))
+	}
+	return nil
+}
+
+// convertedReleases returns the namespaced names of the flux HelmReleases into
+// which the releases of the namespace, or of all the namespaces if empty, were
+// converted, keyed by the namespaced name of the release. A release is
+// converted if any of its storage secrets is annotated, since the revisions
+// written by flux afterwards are not.
+func (s *Server) convertedReleases(ctx context.Context, headers http.Header, cluster, namespace, releaseName string) (map[types.NamespacedName]string, error) {
+	typedClient, err := s.clientGetter.Typed(headers, cluster)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to create kubernetes clientset: %w", err))
+	}
+	selector := "owner=helm"
+	if releaseName != "" {
+		selector += ",name=" + releaseName
+	}
+	secrets, err := typedClient.CoreV1().Secrets(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, connecterror.FromK8sError("list", "Secret", namespace+"/*", err)
+	}
+	converted := map[types.NamespacedName]string{}
+	for _, secret := range secrets.Items {
+		if helmRelease := secret.Annotations[pkgutils.ConvertedReleaseAnnotation]; helmRelease != "" {
+			converted[types.NamespacedName{Namespace: secret.Namespace, Name: secret.Labels["name"]}] = helmRelease
+		}
+	}
+	return converted, nil
+}
+
+// checkReleaseNotConverted returns a FailedPrecondition error if the release
+// was converted into a flux HelmRelease, which is then the only one to manage
+// it.
+func (s *Server) checkReleaseNotConverted(ctx context.Context, headers http.Header, installedRef *corev1.InstalledPackageReference) error {
+	cluster := installedRef.GetContext().GetCluster()
+	if cluster == "" {
+		cluster = s.globalPackagingCluster
+	}
+	releaseName := types.NamespacedName{Namespace: installedRef.GetContext().GetNamespace(), Name: installedRef.GetIdentifier()}
+	converted, err := s.convertedReleases(ctx, headers, cluster, releaseName.Namespace, releaseName.Name)
+	if err != nil {
+		return err
+	}
+	if helmRelease, ok := converted[releaseName]; ok {
+		return connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("The Helm release %q was converted into the flux HelmRelease %q and can only be managed by the 'fluxv2' plugin", releaseName.String(), helmRelease))
+	}
+	return nil
+}
+
+// markConvertedRelease records in the status of an installed package that its
+// release was converted into the given flux HelmRelease.
+func markConvertedRelease(status *corev1.InstalledPackageStatus, helmRelease string) {
+	status.UserReason = fmt.Sprintf("%s (converted into the flux HelmRelease %s)", status.UserReason, helmRelease)
+}
+
+// chartCapacityRequirements returns the capacity required by a chart, as
+// described in its annotations.
+func chartCapacityRequirements(ch *chart.Chart) corek8sv1.ResourceList {
+	if ch.Metadata == nil {
+		return nil
+	}
+	return resources.ParseCapacityRequirements(ch.Metadata.Annotations[CapacityRequirementsAnnotation])
+}
+
+// releaseNameTemplateData is the data available to the release name template.
+type releaseNameTemplateData struct {
+	PackageName  string
+	RandomSuffix string
+}
+
+const (
+	releaseNameRandomSuffixLength = 5
+	maxReleaseNameAttempts        = 5
+	// maxReleaseNameLength is the maximum length of a release name allowed by Helm.
+	maxReleaseNameLength = 53
+)
+
+// resolveReleaseName returns the name of the release to create, either the
+// requested one or one generated from the release name template, applying the
+// release name conflict strategy when the name is already used in the
+// namespace of the action config. The request custom detail can override both
+// the template and the strategy configured for the plugin.
+func (s *Server) resolveReleaseName(actionConfig *action.Configuration, request *corev1.CreateInstalledPackageRequest, packageName string) (string, error) {
+	customDetail := &helmv1.CreateInstalledPackageCustomDataHelm{}
+	if request.GetCustomDetail() != nil {
+		if err := request.GetCustomDetail().UnmarshalTo(customDetail); err != nil {
+			return "", connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("The custom details could not be parsed: %w", err))
+		}
+	}
+	nameTemplate := customDetail.GetReleaseNameTemplate()
+	if nameTemplate == "" {
+		nameTemplate = s.config().ReleaseNameTemplate
+	}
+	var strategy common.ReleaseNameConflictStrategy
+	switch customDetail.GetReleaseNameConflictStrategy() {
+	case helmv1.ReleaseNameConflictStrategy_RELEASE_NAME_CONFLICT_STRATEGY_ERROR:
+		strategy = common.ReleaseNameConflictError
+	case helmv1.ReleaseNameConflictStrategy_RELEASE_NAME_CONFLICT_STRATEGY_AUTO_SUFFIX:
+		strategy = common.ReleaseNameConflictAutoSuffix
+	default:
+		strategy = s.config().ReleaseNameConflictStrategy
+	}
+
+	releaseName := request.GetName()
+	if releaseName == "" {
+		return generateReleaseName(actionConfig, packageName, nameTemplate, strategy)
+	}
+	available, err := isReleaseNameAvailable(actionConfig, releaseName)
+	if err != nil {
+		return "", err
+	}
+	if available {
+		return releaseName, nil
+	}
+	if strategy != common.ReleaseNameConflictAutoSuffix {
+		return "", connect.NewError(connect.CodeAlreadyExists, fmt.Errorf("The release name %q is already used in the namespace %q", releaseName, request.GetTargetContext().GetNamespace()))
+	}
+	return suffixReleaseName(actionConfig, releaseName)
+}
+
+// generateReleaseName renders the release name template for the package,
+// retrying with a new random suffix while the name is already used by a
+// release in the namespace of the action config. A name which stays in use is
+// suffixed with the auto-suffix conflict strategy.
+func generateReleaseName(actionConfig *action.Configuration, packageName, nameTemplate string, strategy common.ReleaseNameConflictStrategy) (string, error) {
+	tmpl, err := template.New("releaseName").Option("missingkey=error").Parse(nameTemplate)
+	if err != nil {
+		return "", connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to parse the release name template %q: %w", nameTemplate, err))
+	}
+
+	previousName := ""
+	for i := 0; i < maxReleaseNameAttempts; i++ {
+		var name strings.Builder
+		err := tmpl.Execute(&name, releaseNameTemplateData{
+			PackageName:  packageName,
+			RandomSuffix: rand.String(releaseNameRandomSuffixLength),
+		})
+		if err != nil {
+			return "", connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to render the release name template %q: %w", nameTemplate, err))
+		}
+		releaseName := name.String()
+		if err := chartutil.ValidateReleaseName(releaseName); err != nil {
+			return "", connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("The generated release name %q is invalid: %w", releaseName, err))
+		}
+		// A template without a random part always renders the same name.
+		if releaseName == previousName {
+			break
+		}
+		previousName = releaseName
+
+		available, err := isReleaseNameAvailable(actionConfig, releaseName)
+		if err != nil {
+			return "", err
+		}
+		if available {
+			return releaseName, nil
+		}
+	}
+	if strategy == common.ReleaseNameConflictAutoSuffix {
+		return suffixReleaseName(actionConfig, previousName)
+	}
+	return "", connect.NewError(connect.CodeAlreadyExists, fmt.Errorf("Unable to generate an available release name for the package %q", packageName))
+}
+
+// suffixReleaseName appends a random suffix to a release name which is
+// already used, retrying with a new suffix while the suffixed name is also
+// used in the namespace of the action config. The name is truncated if needed
+// to keep the suffixed name within the length allowed for a release.
+func suffixReleaseName(actionConfig *action.Configuration, releaseName string) (string, error) {
+	maxNameLength := maxReleaseNameLength - releaseNameRandomSuffixLength - 1
+	if len(releaseName) > maxNameLength {
+		releaseName = strings.TrimRight(releaseName[:maxNameLength], "-.")
+	}
+	for i := 0; i < maxReleaseNameAttempts; i++ {
+		suffixedName := fmt.Sprintf("%s-%s", releaseName, rand.String(releaseNameRandomSuffixLength))
+		if err := chartutil.ValidateReleaseName(suffixedName); err != nil {
+			return "", connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("The suffixed release name %q is invalid: %w", suffixedName, err))
+		}
+		available, err := isReleaseNameAvailable(actionConfig, suffixedName)
+		if err != nil {
+			return "", err
+		}
+		if available {
+			return suffixedName, nil
+		}
+	}
+	return "", connect.NewError(connect.CodeAlreadyExists, fmt.Errorf("Unable to generate an available release name from %q", releaseName))
+}
+
+// isReleaseNameAvailable returns whether no release, including uninstalled
+// releases whose history was kept, uses the name in the namespace of the action config.
+func isReleaseNameAvailable(actionConfig *action.Configuration, releaseName string) (bool, error) {
+	_, err := actionConfig.Releases.Last(releaseName)
+	if err == nil {
+		return false, nil
+	}
+	if errors.Is(err, driver.ErrReleaseNotFound) {
+		return true, nil
+	}
+	return false, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to check the release %q: %w", releaseName, err))
+}
+
+// CheckInstalledPackageNameAvailability checks whether a release with the name
+// exists in the target namespace.
+func (s *Server) CheckInstalledPackageNameAvailability(ctx context.Context, request *connect.Request[corev1.CheckInstalledPackageNameAvailabilityRequest]) (*connect.Response[corev1.CheckInstalledPackageNameAvailabilityResponse], error) {
+	targetContext := request.Msg.GetTargetContext()
+	log.InfoS("+helm CheckInstalledPackageNameAvailability", "cluster", targetContext.GetCluster(), "namespace", targetContext.GetNamespace(), "name", request.Msg.GetName())
+
+	actionConfig, err := s.actionConfigGetter(request.Header(), targetContext)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to create Helm action config: %w", err))
+	}
+	available, err := isReleaseNameAvailable(actionConfig, request.Msg.GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	conflicts := []*corev1.InstalledPackageReference{}
+	if !available {
+		cluster := targetContext.GetCluster()
+		if cluster == "" {
+			cluster = s.globalPackagingCluster
+		}
+		conflicts = append(conflicts, &corev1.InstalledPackageReference{
+			Context: &corev1.Context{
+				Cluster:   cluster,
+				Namespace: targetContext.GetNamespace(),
+			},
+			Identifier: request.Msg.GetName(),
+			Plugin:     GetPluginDetail(),
+		})
+	}
+	return connect.NewResponse(&corev1.CheckInstalledPackageNameAvailabilityResponse{
+		Available: available,
+		Conflicts: conflicts,
 	}), nil
 }
 
@@ -790,6 +1403,13 @@ func (s *Server) UpdateInstalledPackage(ctx context.Context, request *connect.Re
 	releaseName := installedRef.GetIdentifier()
 	log.InfoS("+helm UpdateInstalledPackage", "cluster", installedRef.GetContext().GetCluster(), "namespace", installedRef.GetContext().GetNamespace())
 
+	if err := checkNoReleaseMetadata(request.Msg.GetMetadata()); err != nil {
+		return nil, err
+	}
+	if err := s.checkReleaseNotConverted(ctx, request.Header(), installedRef); err != nil {
+		return nil, err
+	}
+
 	// Determine the chart used for this installed package.
 	// We may want to include the AvailablePackageRef in the request, given
 	// that it can be ambiguous, but we dont yet have a UI that allows the
@@ -815,19 +1435,52 @@ func (s *Server) UpdateInstalledPackage(ctx context.Context, request *connect.Re
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to create kubernetes clientset: %w", err))
 	}
 	chartID := availablePkgRef.GetIdentifier()
-	repoName, chartName, err := pkgutils.SplitPackageIdentifier(chartID)
-	if err != nil {
-		return nil, err
+	var ch *chart.Chart
+	var registrySecrets map[string]string
+	if isChartURLIdentifier(chartID) {
+		// The host of the chart URL may have been disallowed since the
+		// package was installed, and the tarball is verified again against
+		// its digest.
+		ch, err = s.fetchChartFromURL(ctx, chartID)
+		if err != nil {
+			return nil, err
+		}
+		// The chart URL references a single version of the chart.
+		if version := request.Msg.GetPkgVersionReference().GetVersion(); version != "" && version != ch.Metadata.Version {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("The package %q was installed from a chart URL which references the version %q, it cannot be updated to the version %q", releaseName, ch.Metadata.Version, version))
+		}
+		recordChartURLIdentifier(ch, chartID)
+	} else {
+		repoName, chartName, err := pkgutils.SplitPackageIdentifier(chartID)
+		if err != nil {
+			return nil, err
+		}
+		// The repository may have been restricted since the package was installed
+		if err := s.checkRepoAllowsNamespace(ctx, request.Header(), types.NamespacedName{Namespace: availablePkgRef.GetContext().GetNamespace(), Name: repoName}, installedRef.GetContext()); err != nil {
+			return nil, err
+		}
+		chartDetails := &utils.ChartDetails{
+			AppRepositoryResourceName:      repoName,
+			AppRepositoryResourceNamespace: availablePkgRef.GetContext().GetNamespace(),
+			ChartName:                      chartName,
+			Version:                        request.Msg.GetPkgVersionReference().GetVersion(),
+		}
+		if chartDetails.Version, err = s.resolveChartVersion(chartDetails); err != nil {
+			return nil, err
+		}
+		ch, registrySecrets, err = s.fetchChartWithRegistrySecrets(ctx, request.Header(), chartDetails, typedClient)
+		if err != nil {
+			return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("Missing permissions %w", err))
+		}
 	}
-	chartDetails := &utils.ChartDetails{
-		AppRepositoryResourceName:      repoName,
-		AppRepositoryResourceNamespace: availablePkgRef.GetContext().GetNamespace(),
-		ChartName:                      chartName,
-		Version:                        request.Msg.GetPkgVersionReference().GetVersion(),
+	// The values supplied by the user for the current release, as returned
+	// in its detail, are reused if requested.
+	values := request.Msg.GetValues()
+	if request.Msg.GetReuseValues() {
+		values = detailResponse.Msg.GetInstalledPackageDetail().GetValuesApplied()
 	}
-	ch, registrySecrets, err := s.fetchChartWithRegistrySecrets(ctx, request.Header(), chartDetails, typedClient)
-	if err != nil {
-		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("Missing permissions %w", err))
+	if err := validateValues(ch, values); err != nil {
+		return nil, err
 	}
 
 	// Create an action config for the installed pkg context.
@@ -836,7 +1489,7 @@ func (s *Server) UpdateInstalledPackage(ctx context.Context, request *connect.Re
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to create Helm action config: %w", err))
 	}
 
-	release, err := agent.UpgradeRelease(actionConfig, releaseName, request.Msg.GetValues(), ch, registrySecrets, s.pluginConfig.TimeoutSeconds)
+	release, err := agent.UpgradeRelease(actionConfig, releaseName, values, ch, registrySecrets, s.config().TimeoutSeconds)
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to upgrade helm release %q in the namespace %q: %w", releaseName, installedRef.GetContext().GetNamespace(), err))
 	}
@@ -922,6 +1575,26 @@ func (s *Server) getAppRepoAndRelatedSecrets(ctx context.Context, headers http.H
 	return &appRepo, caCertSecret, authSecret, imagesPullSecret, nil
 }
 
+// resolveChartVersion returns the version of the chart to install for the
+// requested version: the version itself when exact, or else the highest
+// version of the chart satisfying the requested range, as a Helm release is
+// installed from a single chart version.
+func (s *Server) resolveChartVersion(chartDetails *utils.ChartDetails) (string, error) {
+	if !pkgutils.IsVersionRange(chartDetails.Version) {
+		return chartDetails.Version, nil
+	}
+	chartID := fmt.Sprintf("%s/%s", chartDetails.AppRepositoryResourceName, chartDetails.ChartName)
+	chart, err := s.manager.GetChart(chartDetails.AppRepositoryResourceNamespace, chartID)
+	if err != nil {
+		return "", connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to fetch the versions of the chart %s from the namespace %q: %w", chartID, chartDetails.AppRepositoryResourceNamespace, err))
+	}
+	versions := make([]string, len(chart.ChartVersions))
+	for i, v := range chart.ChartVersions {
+		versions[i] = v.Version
+	}
+	return pkgutils.ResolveVersionReference(chartDetails.Version, versions, chartID)
+}
+
 // fetchChartWithRegistrySecrets returns the chart and related registry secrets.
 //
 // Mainly to DRY up similar code in the create and update methods.
@@ -932,6 +1605,14 @@ func (s *Server) fetchChartWithRegistrySecrets(ctx context.Context, headers http
 		return nil, nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to fetch app repo %q from namespace %q: %v", chartDetails.AppRepositoryResourceName, chartDetails.AppRepositoryResourceNamespace, err))
 	}
 
+	// The repository may have been created, or the registries allowed in the
+	// plugin config changed, without the credential provider being checked.
+	if appRepo.Spec.OCICredentialProvider != "" {
+		if err := s.checkOCICredentialProviderAllowed(appRepo.Namespace, appRepo.Spec.URL); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	userAgentString := fmt.Sprintf("%s/%s/%s/%s", UserAgentPrefix, pluginDetail.Name, pluginDetail.Version, version)
 
 	chartID := fmt.Sprintf("%s/%s", appRepo.Name, chartDetails.ChartName)
@@ -952,6 +1633,7 @@ func (s *Server) fetchChartWithRegistrySecrets(ctx context.Context, headers http
 
 	// Grab the chart itself
 	ch, err := utils.GetChart(
+		ctx,
 		&utils.ChartDetails{
 			AppRepositoryResourceName:      appRepo.Name,
 			AppRepositoryResourceNamespace: appRepo.Namespace,
@@ -999,6 +1681,10 @@ func (s *Server) DeleteInstalledPackage(ctx context.Context, request *connect.Re
 	namespace := installedRef.GetContext().GetNamespace()
 	log.InfoS("+helm DeleteInstalledPackage", "cluster", installedRef.GetContext().GetCluster(), "namespace", namespace)
 
+	if err := s.checkReleaseNotConverted(ctx, request.Header(), installedRef); err != nil {
+		return nil, err
+	}
+
 	// Create an action config for the installed pkg context.
 	actionConfig, err := s.actionConfigGetter(request.Header(), installedRef.GetContext())
 	if err != nil {
@@ -1006,7 +1692,7 @@ func (s *Server) DeleteInstalledPackage(ctx context.Context, request *connect.Re
 	}
 
 	keepHistory := false
-	err = agent.DeleteRelease(actionConfig, releaseName, keepHistory, s.pluginConfig.TimeoutSeconds)
+	err = agent.DeleteRelease(actionConfig, releaseName, keepHistory, s.config().TimeoutSeconds)
 	if err != nil {
 		if errors.Is(err, driver.ErrReleaseNotFound) {
 			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("Unable to find Helm release %q in namespace %q: %+w", releaseName, namespace, err))
@@ -1023,13 +1709,17 @@ func (s *Server) RollbackInstalledPackage(ctx context.Context, request *connect.
 	releaseName := installedRef.GetIdentifier()
 	log.InfoS("+helm RollbackInstalledPackage", "cluster", installedRef.GetContext().GetCluster(), "namespace", installedRef.GetContext().GetNamespace())
 
+	if err := s.checkReleaseNotConverted(ctx, request.Header(), installedRef); err != nil {
+		return nil, err
+	}
+
 	// Create an action config for the installed pkg context.
 	actionConfig, err := s.actionConfigGetter(request.Header(), installedRef.GetContext())
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to create Helm action config: %w", err))
 	}
 
-	release, err := agent.RollbackRelease(actionConfig, releaseName, int(request.Msg.GetReleaseRevision()), s.pluginConfig.TimeoutSeconds)
+	release, err := agent.RollbackRelease(actionConfig, releaseName, int(request.Msg.GetReleaseRevision()), s.config().TimeoutSeconds)
 	if err != nil {
 		if errors.Is(err, driver.ErrReleaseNotFound) {
 			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("Unable to find Helm release %q in namespace %q: %+w", releaseName, installedRef.GetContext().GetNamespace(), err))
@@ -1054,6 +1744,95 @@ func (s *Server) RollbackInstalledPackage(ctx context.Context, request *connect.
 	}), nil
 }
 
+// GetInstalledPackageRevisions returns the revisions of the release of an
+// installed package, from the latest to the oldest.
+func (s *Server) GetInstalledPackageRevisions(ctx context.Context, request *connect.Request[helmv1.GetInstalledPackageRevisionsRequest]) (*connect.Response[helmv1.GetInstalledPackageRevisionsResponse], error) {
+	installedRef := request.Msg.GetInstalledPackageRef()
+	releaseName := installedRef.GetIdentifier()
+	log.InfoS("+helm GetInstalledPackageRevisions", "cluster", installedRef.GetContext().GetCluster(), "namespace", installedRef.GetContext().GetNamespace(), "id", releaseName)
+
+	actionConfig, err := s.actionConfigGetter(request.Header(), installedRef.GetContext())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to create Helm action config: %w", err))
+	}
+
+	releases, err := agent.GetReleaseHistory(actionConfig, releaseName)
+	if err != nil {
+		if errors.Is(err, driver.ErrReleaseNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("Unable to find Helm release %q in namespace %q: %+w", releaseName, installedRef.GetContext().GetNamespace(), err))
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to get the history of helm release %q in the namespace %q: %w", releaseName, installedRef.GetContext().GetNamespace(), err))
+	}
+
+	revisions := make([]*helmv1.InstalledPackageRevision, len(releases))
+	for i, r := range releases {
+		revisions[i] = installedPackageRevisionFromRelease(r)
+	}
+
+	return connect.NewResponse(&helmv1.GetInstalledPackageRevisionsResponse{
+		Revisions: revisions,
+	}), nil
+}
+
+// GetInstalledPackageRevision returns a revision of the release of an
+// installed package, along with its rendered manifest and applied values.
+func (s *Server) GetInstalledPackageRevision(ctx context.Context, request *connect.Request[helmv1.GetInstalledPackageRevisionRequest]) (*connect.Response[helmv1.GetInstalledPackageRevisionResponse], error) {
+	installedRef := request.Msg.GetInstalledPackageRef()
+	releaseName := installedRef.GetIdentifier()
+	revision := request.Msg.GetReleaseRevision()
+	log.InfoS("+helm GetInstalledPackageRevision", "cluster", installedRef.GetContext().GetCluster(), "namespace", installedRef.GetContext().GetNamespace(), "id", releaseName, "revision", revision)
+
+	if revision <= 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Invalid release revision %d", revision))
+	}
+
+	actionConfig, err := s.actionConfigGetter(request.Header(), installedRef.GetContext())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to create Helm action config: %w", err))
+	}
+
+	release, err := agent.GetReleaseRevision(actionConfig, releaseName, int(revision))
+	if err != nil {
+		if errors.Is(err, driver.ErrReleaseNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("Unable to find revision %d of Helm release %q in namespace %q: %+w", revision, releaseName, installedRef.GetContext().GetNamespace(), err))
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to get revision %d of helm release %q in the namespace %q: %w", revision, releaseName, installedRef.GetContext().GetNamespace(), err))
+	}
+
+	valuesApplied := ""
+	if len(release.Config) > 0 {
+		valuesJSON, err := json.Marshal(release.Config)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to marshal the values of revision %d of helm release %q: %w", revision, releaseName, err))
+		}
+		valuesApplied = string(valuesJSON)
+	}
+
+	return connect.NewResponse(&helmv1.GetInstalledPackageRevisionResponse{
+		Revision:      installedPackageRevisionFromRelease(release),
+		Manifest:      release.Manifest,
+		ValuesApplied: valuesApplied,
+	}), nil
+}
+
+func installedPackageRevisionFromRelease(r *release.Release) *helmv1.InstalledPackageRevision {
+	revision := &helmv1.InstalledPackageRevision{
+		ReleaseRevision: int32(r.Version),
+	}
+	if r.Info != nil {
+		revision.Status = r.Info.Status.String()
+		revision.Description = r.Info.Description
+		if !r.Info.LastDeployed.IsZero() {
+			revision.Updated = r.Info.LastDeployed.Format(time.RFC3339)
+		}
+	}
+	if r.Chart != nil && r.Chart.Metadata != nil {
+		revision.ChartVersion = r.Chart.Metadata.Version
+		revision.AppVersion = r.Chart.Metadata.AppVersion
+	}
+	return revision
+}
+
 // GetInstalledPackageResourceRefs returns the references for the Kubernetes
 // resources created by an installed package.
 func (s *Server) GetInstalledPackageResourceRefs(ctx context.Context, request *connect.Request[corev1.GetInstalledPackageResourceRefsRequest]) (*connect.Response[corev1.GetInstalledPackageResourceRefsResponse], error) {
@@ -1081,6 +1860,14 @@ func (s *Server) GetInstalledPackageResourceRefs(ctx context.Context, request *c
 	}
 }
 
+// GetInstalledPackageOperationStatus returns the status of an installed package managed by the 'helm' plugin.
+// Helm releases are installed synchronously, so their operations have no phases.
+func (s *Server) GetInstalledPackageOperationStatus(ctx context.Context, request *connect.Request[corev1.GetInstalledPackageOperationStatusRequest]) (*connect.Response[corev1.GetInstalledPackageOperationStatusResponse], error) {
+	log.InfoS("+helm GetInstalledPackageOperationStatus", "cluster", request.Msg.GetInstalledPackageRef().GetContext().GetCluster(), "namespace", request.Msg.GetInstalledPackageRef().GetContext().GetNamespace(), "id", request.Msg.GetInstalledPackageRef().GetIdentifier())
+
+	return pkgutils.GetInstalledPackageOperationStatus(ctx, request, s.GetInstalledPackageDetail)
+}
+
 func (s *Server) AddPackageRepository(ctx context.Context, request *connect.Request[corev1.AddPackageRepositoryRequest]) (*connect.Response[corev1.AddPackageRepositoryResponse], error) {
 
 	if request == nil {
@@ -1093,49 +1880,77 @@ func (s *Server) AddPackageRepository(ctx context.Context, request *connect.Requ
 	repoName := request.Msg.Name
 	log.Infof("+helm AddPackageRepository '%s'", repoName)
 
-	cluster := request.Msg.GetContext().GetCluster()
+	helmRepo, err := s.newHelmRepository(request.Msg)
+	if err != nil {
+		return nil, err
+	}
+	if repoRef, err := s.newRepo(ctx, request.Header(), helmRepo); err != nil {
+		return nil, err
+	} else {
+		return connect.NewResponse(&corev1.AddPackageRepositoryResponse{PackageRepoRef: repoRef}), nil
+	}
+}
+
+// TestPackageRepositoryConnection checks that a package repository can be reached
+// with the given details, without creating it.
+func (s *Server) TestPackageRepositoryConnection(ctx context.Context, request *connect.Request[corev1.TestPackageRepositoryConnectionRequest]) (*connect.Response[corev1.TestPackageRepositoryConnectionResponse], error) {
+	if request.Msg.GetPackageRepository() == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("No package repository provided"))
+	}
+	log.Infof("+helm TestPackageRepositoryConnection '%s'", request.Msg.PackageRepository.GetUrl())
+
+	helmRepo, err := s.newHelmRepository(request.Msg.PackageRepository)
+	if err != nil {
+		return nil, err
+	}
+	if response, err := s.testRepoConnection(ctx, request.Header(), helmRepo); err != nil {
+		return nil, err
+	} else {
+		return connect.NewResponse(response), nil
+	}
+}
+
+// newHelmRepository returns the HelmRepository described by the request to add
+// a package repository.
+func (s *Server) newHelmRepository(request *corev1.AddPackageRepositoryRequest) (*HelmRepository, error) {
+	cluster := request.GetContext().GetCluster()
 	if cluster == "" {
 		cluster = s.globalPackagingCluster
 	}
 
-	namespace := request.Msg.GetContext().GetNamespace()
+	namespace := request.GetContext().GetNamespace()
 	if namespace == "" {
 		namespace = s.GetGlobalPackagingNamespace()
 	}
-	if request.Msg.GetNamespaceScoped() != (namespace != s.GetGlobalPackagingNamespace()) {
+	if request.GetNamespaceScoped() != (namespace != s.GetGlobalPackagingNamespace()) {
 		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Namespace Scope is inconsistent with the provided Namespace"))
 	}
 	name := types.NamespacedName{
-		Name:      repoName,
+		Name:      request.GetName(),
 		Namespace: namespace,
 	}
 
 	// Get Helm-specific values
 	var customDetail *helmv1.HelmPackageRepositoryCustomDetail
-	if request.Msg.CustomDetail != nil {
+	if request.CustomDetail != nil {
 		customDetail = &helmv1.HelmPackageRepositoryCustomDetail{}
-		if err := request.Msg.CustomDetail.UnmarshalTo(customDetail); err != nil {
-			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("The custom details could not be parsed: [%v]", request.Msg.CustomDetail))
+		if err := request.CustomDetail.UnmarshalTo(customDetail); err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("The custom details could not be parsed: [%v]", request.CustomDetail))
 		}
 		log.Infof("+helm customDetail [%v]", customDetail)
 	}
 
-	helmRepo := &HelmRepository{
+	return &HelmRepository{
 		cluster:      cluster,
 		name:         name,
-		url:          request.Msg.GetUrl(),
-		repoType:     request.Msg.GetType(),
-		description:  request.Msg.GetDescription(),
-		interval:     request.Msg.GetInterval(),
-		tlsConfig:    request.Msg.GetTlsConfig(),
-		auth:         request.Msg.GetAuth(),
+		url:          request.GetUrl(),
+		repoType:     request.GetType(),
+		description:  request.GetDescription(),
+		interval:     request.GetInterval(),
+		tlsConfig:    request.GetTlsConfig(),
+		auth:         request.GetAuth(),
 		customDetail: customDetail,
-	}
-	if repoRef, err := s.newRepo(ctx, request.Header(), helmRepo); err != nil {
-		return nil, err
-	} else {
-		return connect.NewResponse(&corev1.AddPackageRepositoryResponse{PackageRepoRef: repoRef}), nil
-	}
+	}, nil
 }
 
 func (s *Server) getClient(headers http.Header, cluster string, namespace string) (ctrlclient.Client, error) {
@@ -1185,11 +2000,12 @@ func (s *Server) GetPackageRepositoryDetail(ctx context.Context, request *connec
 func (s *Server) GetPackageRepositorySummaries(ctx context.Context, request *connect.Request[corev1.GetPackageRepositorySummariesRequest]) (*connect.Response[corev1.GetPackageRepositorySummariesResponse], error) {
 	log.Infof("+helm GetPackageRepositorySummaries [%v]", request.Msg.GetContext())
 
-	if summaries, err := s.repoSummaries(ctx, request.Header(), request.Msg.GetContext().GetCluster(), request.Msg.GetContext().GetNamespace()); err != nil {
+	if summaries, failures, err := s.repoSummaries(ctx, request.Header(), request.Msg.GetContext().GetCluster(), request.Msg.GetContext().GetNamespace()); err != nil {
 		return nil, err
 	} else {
 		return connect.NewResponse(&corev1.GetPackageRepositorySummariesResponse{
 			PackageRepositorySummaries: summaries,
+			PartialResults:             pkgutils.NewPartialResults(failures),
 		}), nil
 	}
 }
@@ -1260,9 +2076,11 @@ func (s *Server) DeletePackageRepository(ctx context.Context, request *connect.R
 	}
 	cluster := repoRef.GetContext().GetCluster()
 
-	if err := s.deleteRepo(ctx, request.Header(), cluster, repoRef); err != nil {
+	if retainedSecrets, err := s.deleteRepo(ctx, request.Header(), cluster, repoRef, request.Msg.GetRetainReferencedSecrets()); err != nil {
 		return nil, err
 	} else {
-		return connect.NewResponse(&corev1.DeletePackageRepositoryResponse{}), nil
+		return connect.NewResponse(&corev1.DeletePackageRepositoryResponse{
+			RetainedSecrets: retainedSecrets,
+		}), nil
 	}
 }
@@ -5,12 +5,16 @@ package main
 
 import (
 	"context"
+	sqldriver "database/sql/driver"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"os"
+	"path/filepath"
 	"regexp"
 	"runtime"
 	"sort"
@@ -37,6 +41,7 @@ import (
 	"github.com/vmware-tanzu/kubeapps/pkg/dbutils"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
 	"helm.sh/helm/v3/pkg/action"
 	"helm.sh/helm/v3/pkg/chart"
@@ -47,10 +52,14 @@ import (
 	"helm.sh/helm/v3/pkg/storage"
 	"helm.sh/helm/v3/pkg/storage/driver"
 	authorizationv1 "k8s.io/api/authorization/v1"
+	k8scorev1 "k8s.io/api/core/v1"
 	apiextfake "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	k8sruntime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/cache"
 	"k8s.io/apiserver/pkg/storage/names"
 	dynfake "k8s.io/client-go/dynamic/fake"
 	typfake "k8s.io/client-go/kubernetes/fake"
@@ -231,12 +240,28 @@ func makeServer(t *testing.T, authorized bool, actionConfig *action.Configuratio
 	if err != nil {
 		t.Fatalf("%+v", err)
 	}
+	// The objects are stored as unstructured so that the dynamic client can list them
+	unstructuredObjects := make([]k8sruntime.Object, len(objects))
+	for i, obj := range objects {
+		gvks, _, err := scheme.ObjectKinds(obj)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		content, err := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		unstructuredObj := &unstructured.Unstructured{Object: content}
+		unstructuredObj.SetGroupVersionKind(gvks[0])
+		unstructuredObjects[i] = unstructuredObj
+	}
 	dynamicClient := dynfake.NewSimpleDynamicClientWithCustomListKinds(
 		scheme,
 		map[schema.GroupVersionResource]string{
-			{Group: "foo", Version: "bar", Resource: "baz"}: "PackageList",
+			{Group: "foo", Version: "bar", Resource: "baz"}:                 "PackageList",
+			v1alpha1.SchemeGroupVersion.WithResource(AppRepositoryResource): AppRepositoryResource + "List",
 		},
-		objects...,
+		unstructuredObjects...,
 	)
 
 	// Creating an authorized clientGetter
@@ -255,6 +280,9 @@ func makeServer(t *testing.T, authorized bool, actionConfig *action.Configuratio
 			WithTyped(clientSet).
 			WithDynamic(dynamicClient).
 			Build(),
+		localServiceAccountClientGetter: clientgetter.NewBuilder().
+			WithDynamic(dynamicClient).
+			BuildFixedCluster(),
 		manager:                  manager,
 		kubeappsNamespace:        kubeappsNamespace,
 		globalPackagingNamespace: globalPackagingNamespace,
@@ -265,6 +293,10 @@ func makeServer(t *testing.T, authorized bool, actionConfig *action.Configuratio
 		chartClientFactory: &fake.ChartClientFactory{},
 		pluginConfig:       common.NewDefaultPluginConfig(),
 		createReleaseFunc:  agent.CreateRelease,
+		vulnerabilityScanner: &vulnerabilityScanner{
+			client:    http.DefaultClient,
+			summaries: cache.NewLRUExpireCache(vulnerabilitySummariesCacheSize),
+		},
 	}, mock, cleanup
 }
 
@@ -320,6 +352,9 @@ func newServerWithSecretsAndRepos(t *testing.T, secrets []k8sruntime.Object, uns
 			WithApiExt(apiExtIfc).
 			WithDynamic(dynClient).
 			Build(),
+		localServiceAccountClientGetter: clientgetter.NewBuilder().
+			WithDynamic(dynClient).
+			BuildFixedCluster(),
 		kubeappsNamespace:        kubeappsNamespace,
 		globalPackagingNamespace: globalPackagingNamespace,
 		globalPackagingCluster:   globalPackagingCluster,
@@ -378,6 +413,9 @@ func newServerWithAppRepoReactors(unstructuredObjs []k8sruntime.Object, repos []
 			WithApiExt(apiExtIfc).
 			WithDynamic(dynClient).
 			Build(),
+		localServiceAccountClientGetter: clientgetter.NewBuilder().
+			WithDynamic(dynClient).
+			BuildFixedCluster(),
 		kubeappsNamespace:        kubeappsNamespace,
 		globalPackagingNamespace: globalPackagingNamespace,
 		globalPackagingCluster:   globalPackagingCluster,
@@ -398,6 +436,8 @@ func TestGetAvailablePackageSummaries(t *testing.T) {
 		expectedResponse       *corev1.GetAvailablePackageSummariesResponse
 		authorized             bool
 		expectedCategories     []*models.ChartCategory
+		appRepos               []k8sruntime.Object
+		expectDBExcludedRepos  []interface{}
 	}{
 		{
 			name:       "it returns a set of availablePackageSummary from the database (global ns)",
@@ -466,6 +506,7 @@ func TestGetAvailablePackageSummaries(t *testing.T) {
 					},
 				},
 				Categories: []string{"cat1"},
+				TotalSize:  proto.Int32(3),
 			},
 		},
 		{
@@ -517,6 +558,7 @@ func TestGetAvailablePackageSummaries(t *testing.T) {
 					},
 				},
 				Categories: []string{"cat1"},
+				TotalSize:  proto.Int32(2),
 			},
 		},
 		{
@@ -569,6 +611,7 @@ func TestGetAvailablePackageSummaries(t *testing.T) {
 					},
 				},
 				Categories: []string{"cat1"},
+				TotalSize:  proto.Int32(2),
 			},
 		},
 		{
@@ -646,6 +689,7 @@ func TestGetAvailablePackageSummaries(t *testing.T) {
 				},
 				NextPageToken: "2",
 				Categories:    []string{"cat1"},
+				TotalSize:     proto.Int32(3),
 			},
 		},
 		{
@@ -690,6 +734,7 @@ func TestGetAvailablePackageSummaries(t *testing.T) {
 				},
 				NextPageToken: "",
 				Categories:    []string{"cat1"},
+				TotalSize:     proto.Int32(3),
 			},
 		},
 		{
@@ -707,6 +752,107 @@ func TestGetAvailablePackageSummaries(t *testing.T) {
 			},
 			errorCode: connect.CodeInvalidArgument,
 		},
+		{
+			name:       "it excludes the repositories not allowed in the requested namespace",
+			authorized: true,
+			request: &corev1.GetAvailablePackageSummariesRequest{
+				Context: &corev1.Context{
+					Namespace: "my-ns",
+				},
+			},
+			appRepos: []k8sruntime.Object{
+				&v1alpha1.AppRepository{
+					ObjectMeta: metav1.ObjectMeta{Name: "internal", Namespace: globalPackagingNamespace},
+					Spec:       v1alpha1.AppRepositorySpec{AllowedNamespaces: []string{"team-a"}},
+				},
+				&v1alpha1.AppRepository{
+					ObjectMeta: metav1.ObjectMeta{Name: "public", Namespace: globalPackagingNamespace},
+				},
+				&v1alpha1.AppRepository{
+					ObjectMeta: metav1.ObjectMeta{Name: "repo-1", Namespace: "my-ns"},
+					Spec:       v1alpha1.AppRepositorySpec{AllowedNamespaces: []string{"my-ns"}},
+				},
+			},
+			expectDBQueryNamespace: "my-ns",
+			expectDBExcludedRepos:  []interface{}{globalPackagingNamespace, "internal"},
+			charts: []*models.Chart{
+				makeChart("chart-1", "repo-1", "http://chart-1", "my-ns", []string{"2.0.0", "3.0.0"}, DefaultChartCategory),
+			},
+			expectedResponse: &corev1.GetAvailablePackageSummariesResponse{
+				AvailablePackageSummaries: []*corev1.AvailablePackageSummary{
+					{
+						Name:        "chart-1",
+						DisplayName: "chart-1",
+						LatestVersion: &corev1.PackageAppVersion{
+							PkgVersion: "3.0.0",
+							AppVersion: DefaultAppVersion,
+						},
+						IconUrl:          DefaultChartIconURL,
+						Categories:       []string{DefaultChartCategory},
+						ShortDescription: DefaultChartDescription,
+						AvailablePackageRef: &corev1.AvailablePackageReference{
+							Context:    &corev1.Context{Cluster: globalPackagingCluster, Namespace: "my-ns"},
+							Identifier: "repo-1/chart-1",
+							Plugin:     &plugins.Plugin{Name: "helm.packages", Version: "v1alpha1"},
+						},
+					},
+				},
+				Categories: []string{"cat1"},
+				TotalSize:  proto.Int32(1),
+			},
+		},
+		{
+			name:       "it hides the charts not allowed by the policies of the repositories",
+			authorized: true,
+			request: &corev1.GetAvailablePackageSummariesRequest{
+				Context: &corev1.Context{
+					Namespace: "my-ns",
+				},
+			},
+			appRepos: []k8sruntime.Object{
+				&v1alpha1.AppRepository{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "public",
+						Namespace:   globalPackagingNamespace,
+						Annotations: map[string]string{pkgutils.AnnotationDeniedPackagesKey: "chart-2"},
+					},
+				},
+				&v1alpha1.AppRepository{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "repo-1",
+						Namespace:   "my-ns",
+						Annotations: map[string]string{pkgutils.AnnotationAllowedPackagesKey: "chart-*"},
+					},
+				},
+			},
+			expectDBQueryNamespace: "my-ns",
+			expectDBExcludedRepos:  []interface{}{globalPackagingNamespace, "public", "^(chart-2)$", "my-ns", "repo-1", "^(chart-[^/]*)$"},
+			charts: []*models.Chart{
+				makeChart("chart-1", "repo-1", "http://chart-1", "my-ns", []string{"2.0.0", "3.0.0"}, DefaultChartCategory),
+			},
+			expectedResponse: &corev1.GetAvailablePackageSummariesResponse{
+				AvailablePackageSummaries: []*corev1.AvailablePackageSummary{
+					{
+						Name:        "chart-1",
+						DisplayName: "chart-1",
+						LatestVersion: &corev1.PackageAppVersion{
+							PkgVersion: "3.0.0",
+							AppVersion: DefaultAppVersion,
+						},
+						IconUrl:          DefaultChartIconURL,
+						Categories:       []string{DefaultChartCategory},
+						ShortDescription: DefaultChartDescription,
+						AvailablePackageRef: &corev1.AvailablePackageReference{
+							Context:    &corev1.Context{Cluster: globalPackagingCluster, Namespace: "my-ns"},
+							Identifier: "repo-1/chart-1",
+							Plugin:     &plugins.Plugin{Name: "helm.packages", Version: "v1alpha1"},
+						},
+					},
+				},
+				Categories: []string{"cat1"},
+				TotalSize:  proto.Int32(1),
+			},
+		},
 		{
 			name:       "it returns the proper chart categories",
 			authorized: true,
@@ -774,13 +920,14 @@ func TestGetAvailablePackageSummaries(t *testing.T) {
 					},
 				},
 				Categories: []string{"bar", "foo"},
+				TotalSize:  proto.Int32(3),
 			},
 		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			server, mock, cleanup := makeServer(t, tc.authorized, nil)
+			server, mock, cleanup := makeServer(t, tc.authorized, nil, tc.appRepos...)
 			defer cleanup()
 
 			// Simulate the pagination by reducing the rows of JSON based on the offset and limit.
@@ -813,13 +960,25 @@ func TestGetAvailablePackageSummaries(t *testing.T) {
 					catrows.AddRow(category, dict[category])
 				}
 
+				queryArgs := []sqldriver.Value{tc.expectDBQueryNamespace, server.globalPackagingNamespace}
+				for _, arg := range tc.expectDBExcludedRepos {
+					queryArgs = append(queryArgs, arg)
+				}
+
 				mock.ExpectQuery("SELECT (info ->> 'category')*").
-					WithArgs(tc.expectDBQueryNamespace, server.globalPackagingNamespace).
+					WithArgs(queryArgs...).
 					WillReturnRows(catrows)
 
 				mock.ExpectQuery("SELECT info FROM").
-					WithArgs(tc.expectDBQueryNamespace, server.globalPackagingNamespace).
+					WithArgs(queryArgs...).
 					WillReturnRows(rows)
+
+				// The charts are only counted when the total size is not known from a full page.
+				if pageSize := int(tc.request.GetPaginationOptions().GetPageSize()); pageSize > 0 && len(rowsJSON) == pageSize {
+					mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM")).
+						WithArgs(queryArgs...).
+						WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(len(tc.charts)))
+				}
 			}
 
 			availablePackageSummaries, err := server.GetAvailablePackageSummaries(context.Background(), connect.NewRequest(tc.request))
@@ -1158,6 +1317,121 @@ func TestGetAvailablePackageDetail(t *testing.T) {
 	}
 }
 
+func TestGetAvailablePackageDetailVulnerabilities(t *testing.T) {
+	testCases := []struct {
+		name            string
+		scannerHandler  http.HandlerFunc
+		noScanner       bool
+		expectedSummary *helmv1.VulnerabilitySummary
+	}{
+		{
+			name: "it attaches the summary reported by the scanner",
+			scannerHandler: func(w http.ResponseWriter, r *http.Request) {
+				var scanRequest vulnerabilityScanRequest
+				if err := json.NewDecoder(r.Body).Decode(&scanRequest); err != nil {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				expectedRequest := vulnerabilityScanRequest{
+					Chart: vulnerabilityScanChart{
+						ID:      "repo-1/foo",
+						Version: "3.0.0",
+						Digest:  "sha256:3333",
+					},
+					Images: []string{"docker.io/bitnami/foo:3.0.0"},
+				}
+				if !cmp.Equal(expectedRequest, scanRequest) {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				fmt.Fprint(w, `{"critical": 1, "high": 4, "reportURL": "https://scanner.example.com/reports/foo-3.0.0"}`)
+			},
+			expectedSummary: &helmv1.VulnerabilitySummary{
+				Critical:  1,
+				High:      4,
+				ReportUrl: "https://scanner.example.com/reports/foo-3.0.0",
+			},
+		},
+		{
+			name: "it returns the detail without summary when the scan fails",
+			scannerHandler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+		},
+		{
+			name: "it returns the detail without summary when the report URL is not a web page",
+			scannerHandler: func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, `{"critical": 1, "high": 4, "reportURL": "javascript:alert(1)"}`)
+			},
+		},
+		{
+			name:      "it returns the detail without summary when no scanner is configured",
+			noScanner: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server, mock, cleanup := makeServer(t, true, nil)
+			defer cleanup()
+			if !tc.noScanner {
+				scanner := httptest.NewServer(tc.scannerHandler)
+				defer scanner.Close()
+				server.pluginConfig.VulnerabilityScanner.URL = scanner.URL
+			}
+
+			chart := makeChart("foo", "repo-1", "http://foo", "my-ns", []string{"2.0.0", "3.0.0"}, DefaultChartCategory)
+			chart.ChartVersions[0].Digest = "sha256:2222"
+			chart.ChartVersions[1].Digest = "sha256:3333"
+			chart.ChartVersions[1].Images = []string{"docker.io/bitnami/foo:3.0.0"}
+			chartJSON, err := json.Marshal(chart)
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			mock.ExpectQuery("SELECT info FROM charts").
+				WithArgs("my-ns", "repo-1/foo").
+				WillReturnRows(sqlmock.NewRows([]string{"info"}).AddRow(string(chartJSON)))
+			fileJSON, err := json.Marshal(models.ChartFiles{Readme: "chart readme"})
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			mock.ExpectQuery("SELECT info FROM files").
+				WithArgs("my-ns", fileIDForChart("repo-1/foo", "3.0.0")).
+				WillReturnRows(sqlmock.NewRows([]string{"info"}).AddRow(string(fileJSON)))
+
+			response, err := server.GetAvailablePackageDetail(context.Background(), connect.NewRequest(&corev1.GetAvailablePackageDetailRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context:    &corev1.Context{Namespace: "my-ns"},
+					Identifier: "repo-1%2Ffoo",
+				},
+			}))
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+
+			customDetail := response.Msg.AvailablePackageDetail.CustomDetail
+			if tc.expectedSummary == nil {
+				if customDetail != nil {
+					t.Errorf("got: %+v, want: nil", customDetail)
+				}
+				return
+			}
+			helmDetail := &helmv1.AvailablePackageDetailCustomDataHelm{}
+			if err := customDetail.UnmarshalTo(helmDetail); err != nil {
+				t.Fatalf("%+v", err)
+			}
+			opt := cmpopts.IgnoreUnexported(helmv1.VulnerabilitySummary{})
+			if got, want := helmDetail.VulnerabilitySummary, tc.expectedSummary; !cmp.Equal(want, got, opt) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, opt))
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
 func TestGetAvailablePackageVersions(t *testing.T) {
 	testCases := []struct {
 		name              string
@@ -1276,6 +1550,170 @@ func TestGetAvailablePackageVersions(t *testing.T) {
 	}
 }
 
+func TestGetAvailablePackageChangelog(t *testing.T) {
+	chartWithChanges := makeChart("apache", "bitnami", "http://apache", "kubeapps", []string{"2.0.0", "3.0.0", "1.0.0"}, DefaultChartCategory)
+	for i, version := range chartWithChanges.ChartVersions {
+		if version.Version == "1.0.0" {
+			continue
+		}
+		chartWithChanges.ChartVersions[i].Changes = []models.ChartVersionChange{
+			{
+				Kind:        "added",
+				Description: "Added in " + version.Version,
+				Links:       []models.ChartChangeLink{{Name: "GitHub PR", URL: "https://github.com/bitnami/charts/pull/1"}},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name              string
+		charts            []*models.Chart
+		request           *corev1.GetAvailablePackageChangelogRequest
+		chartNotFound     bool
+		expectedErrorCode connect.Code
+		expectedResponse  *corev1.GetAvailablePackageChangelogResponse
+	}{
+		{
+			name: "it returns invalid argument if called without an identifier",
+			request: &corev1.GetAvailablePackageChangelogRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context: &corev1.Context{
+						Namespace: "kubeapps",
+					},
+				},
+			},
+			expectedErrorCode: connect.CodeInvalidArgument,
+		},
+		{
+			name: "it returns invalid argument if called with a cluster other than the global/kubeapps one",
+			request: &corev1.GetAvailablePackageChangelogRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context:    &corev1.Context{Cluster: "other-cluster", Namespace: "kubeapps"},
+					Identifier: "bitnami/apache",
+				},
+			},
+			expectedErrorCode: connect.CodeInvalidArgument,
+		},
+		{
+			name: "it returns not found if the chart does not exist",
+			request: &corev1.GetAvailablePackageChangelogRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context: &corev1.Context{
+						Namespace: "kubeapps",
+					},
+					Identifier: "bitnami/apache",
+				},
+			},
+			chartNotFound:     true,
+			expectedErrorCode: connect.CodeNotFound,
+		},
+		{
+			name:   "it returns the changes of the versions after the from version",
+			charts: []*models.Chart{chartWithChanges},
+			request: &corev1.GetAvailablePackageChangelogRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context: &corev1.Context{
+						Namespace: "kubeapps",
+					},
+					Identifier: "bitnami/apache",
+				},
+				FromVersion: "1.0.0",
+			},
+			expectedResponse: &corev1.GetAvailablePackageChangelogResponse{
+				Changelogs: []*corev1.PackageVersionChangelog{
+					{
+						PkgVersion: "3.0.0",
+						Changes: []*corev1.PackageChange{
+							{
+								Kind:        "added",
+								Description: "Added in 3.0.0",
+								Links:       []*corev1.PackageChangeLink{{Name: "GitHub PR", Url: "https://github.com/bitnami/charts/pull/1"}},
+							},
+						},
+					},
+					{
+						PkgVersion: "2.0.0",
+						Changes: []*corev1.PackageChange{
+							{
+								Kind:        "added",
+								Description: "Added in 2.0.0",
+								Links:       []*corev1.PackageChangeLink{{Name: "GitHub PR", Url: "https://github.com/bitnami/charts/pull/1"}},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:   "it returns no changelogs for a chart without changes",
+			charts: []*models.Chart{makeChart("apache", "bitnami", "http://apache", "kubeapps", []string{"2.0.0", "1.0.0"}, DefaultChartCategory)},
+			request: &corev1.GetAvailablePackageChangelogRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context: &corev1.Context{
+						Namespace: "kubeapps",
+					},
+					Identifier: "bitnami/apache",
+				},
+			},
+			expectedResponse: &corev1.GetAvailablePackageChangelogResponse{
+				Changelogs: []*corev1.PackageVersionChangelog{},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			authorized := true
+			server, mock, cleanup := makeServer(t, authorized, nil)
+			defer cleanup()
+
+			rows := sqlmock.NewRows([]string{"info"})
+
+			for _, chart := range tc.charts {
+				chartJSON, err := json.Marshal(chart)
+				if err != nil {
+					t.Fatalf("%+v", err)
+				}
+				rows.AddRow(string(chartJSON))
+			}
+			if tc.expectedErrorCode == 0 || tc.chartNotFound {
+				mock.ExpectQuery("SELECT info FROM").
+					WithArgs(tc.request.AvailablePackageRef.Context.Namespace, tc.request.AvailablePackageRef.Identifier).
+					WillReturnRows(rows)
+			}
+			if tc.chartNotFound {
+				// the chart is also looked up as a mirrored one
+				mock.ExpectQuery("SELECT info FROM").
+					WithArgs(tc.request.AvailablePackageRef.Context.Namespace, "bitnami%apache").
+					WillReturnRows(sqlmock.NewRows([]string{"info"}))
+			}
+
+			response, err := server.GetAvailablePackageChangelog(context.Background(), connect.NewRequest(tc.request))
+
+			if got, want := connect.CodeOf(err), tc.expectedErrorCode; err != nil && got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+
+			// We don't need to check anything else for non-OK codes.
+			if tc.expectedErrorCode != 0 {
+				if err == nil {
+					t.Fatalf("got: nil, want: error with code %s", tc.expectedErrorCode)
+				}
+				return
+			}
+
+			opts := cmpopts.IgnoreUnexported(corev1.GetAvailablePackageChangelogResponse{}, corev1.PackageVersionChangelog{}, corev1.PackageChange{}, corev1.PackageChangeLink{})
+			if got, want := response.Msg, tc.expectedResponse; !cmp.Equal(want, got, opts) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, opts))
+			}
+			// we make sure that all expectations were met
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
 func TestParsePluginConfig(t *testing.T) {
 	testCases := []struct {
 		name                    string
@@ -1425,11 +1863,228 @@ core:
 		})
 	}
 }
+func TestScanChartVulnerabilitiesCache(t *testing.T) {
+	scans := 0
+	scanner := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		scans++
+		fmt.Fprintf(w, `{"critical": %d, "high": 0}`, scans)
+	}))
+	defer scanner.Close()
+
+	server, _, cleanup := makeServer(t, true, nil)
+	defer cleanup()
+	server.pluginConfig.VulnerabilityScanner.URL = scanner.URL
+
+	testCases := []struct {
+		name             string
+		chartVersion     *models.ChartVersion
+		expectedCritical int32
+	}{
+		{
+			name:             "it scans a digest not scanned yet",
+			chartVersion:     &models.ChartVersion{Version: "1.0.0", Digest: "sha256:1111"},
+			expectedCritical: 1,
+		},
+		{
+			name:             "it reuses the summary of a digest already scanned",
+			chartVersion:     &models.ChartVersion{Version: "1.0.0", Digest: "sha256:1111"},
+			expectedCritical: 1,
+		},
+		{
+			name:             "it scans a different digest",
+			chartVersion:     &models.ChartVersion{Version: "2.0.0", Digest: "sha256:2222"},
+			expectedCritical: 2,
+		},
+		{
+			name:             "it does not cache the summary of a chart version without digest",
+			chartVersion:     &models.ChartVersion{Version: "3.0.0"},
+			expectedCritical: 3,
+		},
+		{
+			name:             "it scans again a chart version without digest",
+			chartVersion:     &models.ChartVersion{Version: "3.0.0"},
+			expectedCritical: 4,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			summary := server.scanChartVulnerabilities(context.Background(), "repo-1/foo", tc.chartVersion)
+			if summary == nil {
+				t.Fatalf("got: nil, want: a summary")
+			}
+			if got, want := summary.Critical, tc.expectedCritical; got != want {
+				t.Errorf("got: %d, want: %d", got, want)
+			}
+		})
+	}
+}
+
+func TestParsePluginConfigVulnerabilityScanner(t *testing.T) {
+	testCases := []struct {
+		name             string
+		pluginJSONConf   string
+		expectedScanner  common.VulnerabilityScannerConfig
+		expectedErrorStr string
+	}{
+		{
+			name:            "no scanner specified in plugin config",
+			pluginJSONConf:  `{}`,
+			expectedScanner: common.VulnerabilityScannerConfig{TimeoutSeconds: common.DefaultVulnerabilityScannerTimeoutSeconds},
+		},
+		{
+			name:            "specific scanner in plugin config",
+			pluginJSONConf:  `{"helm": {"packages": {"v1alpha1": {"vulnerabilityScanner": {"url": "https://scanner.example.com/scan", "timeoutSeconds": 10}}}}}`,
+			expectedScanner: common.VulnerabilityScannerConfig{URL: "https://scanner.example.com/scan", TimeoutSeconds: 10},
+		},
+		{
+			name:             "invalid scanner url in plugin config",
+			pluginJSONConf:   `{"helm": {"packages": {"v1alpha1": {"vulnerabilityScanner": {"url": "scanner.example.com/scan"}}}}}`,
+			expectedErrorStr: "invalid vulnerabilityScanner url",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			f, err := os.CreateTemp(".", "plugin_json_conf")
+			if err != nil {
+				log.Fatalf("%s", err)
+			}
+			defer os.Remove(f.Name()) // clean up
+			if _, err := f.WriteString(tc.pluginJSONConf); err != nil {
+				log.Fatalf("%s", err)
+			}
+			if err := f.Close(); err != nil {
+				log.Fatalf("%s", err)
+			}
+
+			pluginConfig, err := common.ParsePluginConfig(f.Name())
+			if tc.expectedErrorStr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedErrorStr) {
+					t.Fatalf("err got %v, want to find %q", err, tc.expectedErrorStr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if got, want := pluginConfig.VulnerabilityScanner, tc.expectedScanner; !cmp.Equal(want, got) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
+func TestParsePluginConfigReleaseNameConflictStrategy(t *testing.T) {
+	testCases := []struct {
+		name             string
+		pluginJSONConf   string
+		expectedStrategy common.ReleaseNameConflictStrategy
+		expectedErrorStr string
+	}{
+		{
+			name:             "no strategy specified in plugin config",
+			pluginJSONConf:   `{}`,
+			expectedStrategy: common.ReleaseNameConflictError,
+		},
+		{
+			name:             "specific strategy in plugin config",
+			pluginJSONConf:   `{"helm": {"packages": {"v1alpha1": {"releaseNameConflictStrategy": "autoSuffix"}}}}`,
+			expectedStrategy: common.ReleaseNameConflictAutoSuffix,
+		},
+		{
+			name:             "unsupported strategy in plugin config",
+			pluginJSONConf:   `{"helm": {"packages": {"v1alpha1": {"releaseNameConflictStrategy": "overwrite"}}}}`,
+			expectedErrorStr: "unsupported release name conflict strategy",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			f, err := os.CreateTemp(".", "plugin_json_conf")
+			if err != nil {
+				log.Fatalf("%s", err)
+			}
+			defer os.Remove(f.Name()) // clean up
+			if _, err := f.WriteString(tc.pluginJSONConf); err != nil {
+				log.Fatalf("%s", err)
+			}
+			if err := f.Close(); err != nil {
+				log.Fatalf("%s", err)
+			}
+
+			pluginConfig, err := common.ParsePluginConfig(f.Name())
+			if tc.expectedErrorStr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedErrorStr) {
+					t.Fatalf("err got %v, want to find %q", err, tc.expectedErrorStr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if got, want := pluginConfig.ReleaseNameConflictStrategy, tc.expectedStrategy; got != want {
+				t.Errorf("got: %q, want: %q", got, want)
+			}
+		})
+	}
+}
+
+func TestReloadPluginConfig(t *testing.T) {
+	testCases := []struct {
+		name                   string
+		pluginJSONConf         string
+		expectedErrorStr       string
+		expectedTimeoutSeconds int32
+	}{
+		{
+			name:                   "it replaces the config in effect",
+			pluginJSONConf:         `{"core": {"packages": {"v1alpha1": {"timeoutSeconds": 60}}}}`,
+			expectedTimeoutSeconds: 60,
+		},
+		{
+			name:                   "it keeps the config in effect when the global packaging namespace changes",
+			pluginJSONConf:         `{"core": {"packages": {"v1alpha1": {"timeoutSeconds": 60}}}, "helm": {"packages": {"v1alpha1": {"globalPackagingNamespace": "other"}}}}`,
+			expectedErrorStr:       "requires a restart",
+			expectedTimeoutSeconds: common.DefaultTimeoutSeconds,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			filename := filepath.Join(t.TempDir(), "plugin_json_conf")
+			if err := os.WriteFile(filename, []byte(tc.pluginJSONConf), 0600); err != nil {
+				t.Fatalf("%+v", err)
+			}
+			s := Server{pluginConfig: common.NewDefaultPluginConfig()}
+
+			effectiveConfig, err := s.reloadPluginConfig(filename)
+
+			if tc.expectedErrorStr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedErrorStr) {
+					t.Fatalf("err got %v, want to find %q", err, tc.expectedErrorStr)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("%+v", err)
+				}
+				if got, want := effectiveConfig, s.config(); got != want {
+					t.Errorf("got: %v, want: %v", got, want)
+				}
+			}
+			if got, want := s.config().TimeoutSeconds, tc.expectedTimeoutSeconds; got != want {
+				t.Errorf("got: %d, want: %d", got, want)
+			}
+		})
+	}
+}
+
 func TestGetInstalledPackageSummaries(t *testing.T) {
 	testCases := []struct {
-		name               string
-		request            *corev1.GetInstalledPackageSummariesRequest
-		existingReleases   []releaseStub
+		name             string
+		request          *corev1.GetInstalledPackageSummariesRequest
+		existingReleases []releaseStub
+		// existingAssets are the charts of the releases looked up in the
+		// asset DB, when not all of them are in the expected response.
+		existingAssets     []releaseStub
 		expectedStatusCode codes.Code
 		expectedResponse   *corev1.GetInstalledPackageSummariesResponse
 	}{
@@ -1801,6 +2456,146 @@ func TestGetInstalledPackageSummaries(t *testing.T) {
 				NextPageToken: "",
 			},
 		},
+		{
+			name: "filters installed packages by label selector and status",
+			request: &corev1.GetInstalledPackageSummariesRequest{
+				Context: &corev1.Context{Namespace: "namespace-1"},
+				FilterOptions: &corev1.InstalledPackageFilterOptions{
+					LabelSelector: "team=frontend",
+					StatusReasons: []corev1.InstalledPackageStatus_StatusReason{corev1.InstalledPackageStatus_STATUS_REASON_FAILED},
+				},
+			},
+			existingReleases: []releaseStub{
+				{
+					name:         "my-release-1",
+					namespace:    "namespace-1",
+					chartVersion: "1.2.3",
+					status:       release.StatusDeployed,
+					version:      1,
+					labels:       map[string]string{"team": "frontend"},
+				},
+				{
+					name:         "my-release-2",
+					namespace:    "namespace-1",
+					chartVersion: "3.4.5",
+					status:       release.StatusFailed,
+					version:      1,
+					labels:       map[string]string{"team": "frontend"},
+				},
+				{
+					name:         "my-release-3",
+					namespace:    "namespace-1",
+					chartVersion: "4.5.6",
+					status:       release.StatusFailed,
+					version:      1,
+					labels:       map[string]string{"team": "backend"},
+				},
+			},
+			expectedStatusCode: codes.OK,
+			expectedResponse: &corev1.GetInstalledPackageSummariesResponse{
+				InstalledPackageSummaries: []*corev1.InstalledPackageSummary{
+					{
+						InstalledPackageRef: &corev1.InstalledPackageReference{
+							Context: &corev1.Context{
+								Cluster:   globalPackagingCluster,
+								Namespace: "namespace-1",
+							},
+							Identifier: "my-release-2",
+							Plugin:     GetPluginDetail(),
+						},
+						Name:    "my-release-2",
+						IconUrl: "https://example.com/icon.png",
+						PkgVersionReference: &corev1.VersionReference{
+							Version: "3.4.5",
+						},
+						CurrentVersion: &corev1.PackageAppVersion{
+							PkgVersion: "3.4.5",
+							AppVersion: DefaultAppVersion,
+						},
+						LatestVersion: &corev1.PackageAppVersion{
+							PkgVersion: "3.4.5",
+						},
+						Status: &corev1.InstalledPackageStatus{
+							Ready:      false,
+							Reason:     corev1.InstalledPackageStatus_STATUS_REASON_FAILED,
+							UserReason: "failed",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "filters installed packages with a newer version available",
+			request: &corev1.GetInstalledPackageSummariesRequest{
+				Context: &corev1.Context{Namespace: "namespace-1"},
+				FilterOptions: &corev1.InstalledPackageFilterOptions{
+					OnlyUpgradable: true,
+				},
+			},
+			existingReleases: []releaseStub{
+				{
+					name:         "my-release-1",
+					namespace:    "namespace-1",
+					chartVersion: "1.2.3",
+					status:       release.StatusDeployed,
+					version:      1,
+				},
+				{
+					name:         "my-release-2",
+					namespace:    "namespace-1",
+					chartVersion: "3.4.5",
+					status:       release.StatusDeployed,
+					version:      1,
+				},
+			},
+			existingAssets: []releaseStub{
+				{
+					name:          "my-release-1",
+					namespace:     "namespace-1",
+					chartVersion:  "1.2.3",
+					latestVersion: "1.2.5",
+					version:       DefaultReleaseRevision,
+				},
+				{
+					name:         "my-release-2",
+					namespace:    "namespace-1",
+					chartVersion: "3.4.5",
+					version:      DefaultReleaseRevision,
+				},
+			},
+			expectedStatusCode: codes.OK,
+			expectedResponse: &corev1.GetInstalledPackageSummariesResponse{
+				InstalledPackageSummaries: []*corev1.InstalledPackageSummary{
+					{
+						InstalledPackageRef: &corev1.InstalledPackageReference{
+							Context: &corev1.Context{
+								Cluster:   globalPackagingCluster,
+								Namespace: "namespace-1",
+							},
+							Identifier: "my-release-1",
+							Plugin:     GetPluginDetail(),
+						},
+						Name:    "my-release-1",
+						IconUrl: "https://example.com/icon.png",
+						PkgVersionReference: &corev1.VersionReference{
+							Version: "1.2.3",
+						},
+						CurrentVersion: &corev1.PackageAppVersion{
+							PkgVersion: "1.2.3",
+							AppVersion: DefaultAppVersion,
+						},
+						LatestVersion: &corev1.PackageAppVersion{
+							PkgVersion: "1.2.5",
+						},
+						Status: &corev1.InstalledPackageStatus{
+							Ready:      true,
+							Reason:     corev1.InstalledPackageStatus_STATUS_REASON_INSTALLED,
+							UserReason: "deployed",
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "includes a latest package version when available",
 			request: &corev1.GetInstalledPackageSummariesRequest{
@@ -1858,7 +2653,9 @@ func TestGetInstalledPackageSummaries(t *testing.T) {
 			server, mock, cleanup := makeServer(t, authorized, actionConfig)
 			defer cleanup()
 
-			if tc.expectedStatusCode == codes.OK {
+			if tc.existingAssets != nil {
+				populateAssetDB(t, mock, tc.existingAssets)
+			} else if tc.expectedStatusCode == codes.OK {
 				populateAssetDBWithSummaries(t, mock, tc.expectedResponse.InstalledPackageSummaries)
 			}
 
@@ -1886,6 +2683,180 @@ func TestGetInstalledPackageSummaries(t *testing.T) {
 	}
 }
 
+// forbiddenDriver is a release storage driver for which listing the releases is forbidden.
+type forbiddenDriver struct {
+	*driver.Memory
+}
+
+func (d forbiddenDriver) List(filter func(*release.Release) bool) ([]*release.Release, error) {
+	return nil, k8sErrors.NewForbidden(schema.GroupResource{Resource: "secrets"}, "", errors.New("bang"))
+}
+
+func TestGetInstalledPackageSummariesNamespaces(t *testing.T) {
+	accessibleRelease := releaseStub{
+		name:         "my-release",
+		namespace:    "ns-accessible",
+		chartVersion: "1.2.3",
+		status:       release.StatusDeployed,
+		version:      1,
+	}
+	actionConfig := newActionConfigFixture(t, "ns-accessible", []releaseStub{accessibleRelease}, nil)
+	forbiddenActionConfig := newActionConfigFixture(t, "", nil, nil)
+	forbiddenActionConfig.Releases.Driver = forbiddenDriver{driver.NewMemory()}
+
+	server, mock, cleanup := makeServer(t, true, actionConfig)
+	defer cleanup()
+	populateAssetDB(t, mock, []releaseStub{accessibleRelease})
+
+	// Forbidden cluster-wide listing and a specific namespace
+	server.actionConfigGetter = func(headers http.Header, context *corev1.Context) (*action.Configuration, error) {
+		if context.GetNamespace() == "ns-accessible" {
+			return actionConfig, nil
+		}
+		return forbiddenActionConfig, nil
+	}
+	server.clientGetter = clientgetter.NewBuilder().
+		WithTyped(typfake.NewSimpleClientset(
+			&k8scorev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns-accessible"}, Status: k8scorev1.NamespaceStatus{Phase: k8scorev1.NamespaceActive}},
+			&k8scorev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns-inaccessible"}, Status: k8scorev1.NamespaceStatus{Phase: k8scorev1.NamespaceActive}},
+		)).
+		Build()
+
+	response, err := server.GetInstalledPackageSummaries(context.Background(), connect.NewRequest(&corev1.GetInstalledPackageSummariesRequest{
+		Context: &corev1.Context{Cluster: globalPackagingCluster},
+	}))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	if got, want := len(response.Msg.GetInstalledPackageSummaries()), 1; got != want {
+		t.Fatalf("got: %d, want: %d", got, want)
+	}
+	if got, want := response.Msg.GetInstalledPackageSummaries()[0].GetInstalledPackageRef().GetContext().GetNamespace(), "ns-accessible"; got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+	failures := response.Msg.GetPartialResults().GetNamespaceFailures()
+	if got, want := len(failures), 1; got != want {
+		t.Fatalf("got: %d, want: %d", got, want)
+	}
+	if got, want := failures[0].GetContext().GetNamespace(), "ns-inaccessible"; got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+	if got, want := failures[0].GetPlugin().GetName(), GetPluginDetail().GetName(); got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+}
+
+// convertedReleaseClientGetter returns a client getter with the Helm storage
+// secret of the release annotated as converted into a flux HelmRelease.
+func convertedReleaseClientGetter(r releaseStub) clientgetter.ClientProviderInterface {
+	return clientgetter.NewBuilder().
+		WithTyped(typfake.NewSimpleClientset(&k8scorev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        fmt.Sprintf("sh.helm.release.v1.%s.v%d", r.name, r.version),
+				Namespace:   r.namespace,
+				Labels:      map[string]string{"owner": "helm", "name": r.name},
+				Annotations: map[string]string{pkgutils.ConvertedReleaseAnnotation: r.namespace + "/" + r.name},
+			},
+		})).
+		Build()
+}
+
+func TestGetInstalledPackageSummariesConvertedRelease(t *testing.T) {
+	convertedRelease := releaseStub{
+		name:         "my-converted-release",
+		namespace:    "namespace-1",
+		chartVersion: "1.2.3",
+		status:       release.StatusDeployed,
+		version:      1,
+	}
+	otherRelease := releaseStub{
+		name:         "my-release",
+		namespace:    "namespace-1",
+		chartVersion: "1.2.3",
+		status:       release.StatusDeployed,
+		version:      1,
+	}
+	actionConfig := newActionConfigFixture(t, "namespace-1", []releaseStub{convertedRelease, otherRelease}, nil)
+	server, mock, cleanup := makeServer(t, true, actionConfig)
+	defer cleanup()
+	server.clientGetter = convertedReleaseClientGetter(convertedRelease)
+	populateAssetDB(t, mock, []releaseStub{convertedRelease, otherRelease})
+
+	response, err := server.GetInstalledPackageSummaries(context.Background(), connect.NewRequest(&corev1.GetInstalledPackageSummariesRequest{
+		Context: &corev1.Context{Namespace: "namespace-1"},
+	}))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	userReasons := map[string]string{}
+	for _, summary := range response.Msg.GetInstalledPackageSummaries() {
+		userReasons[summary.GetName()] = summary.GetStatus().GetUserReason()
+	}
+	expectedUserReasons := map[string]string{
+		"my-converted-release": "deployed (converted into the flux HelmRelease namespace-1/my-converted-release)",
+		"my-release":           "deployed",
+	}
+	if got, want := userReasons, expectedUserReasons; !cmp.Equal(got, want) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+	}
+}
+
+func TestConvertedReleaseIsNotMutated(t *testing.T) {
+	convertedRelease := releaseStub{
+		name:           "my-apache",
+		namespace:      "default",
+		chartID:        "bitnami/apache",
+		chartVersion:   "1.18.3",
+		chartNamespace: globalPackagingNamespace,
+		status:         release.StatusDeployed,
+		version:        1,
+	}
+	installedRef := &corev1.InstalledPackageReference{
+		Context: &corev1.Context{
+			Cluster:   globalPackagingCluster,
+			Namespace: "default",
+		},
+		Identifier: "my-apache",
+	}
+	actionConfig := newActionConfigFixture(t, "default", []releaseStub{convertedRelease}, nil)
+	server, _, cleanup := makeServer(t, true, actionConfig)
+	defer cleanup()
+	server.clientGetter = convertedReleaseClientGetter(convertedRelease)
+
+	_, err := server.UpdateInstalledPackage(context.Background(), connect.NewRequest(&corev1.UpdateInstalledPackageRequest{
+		InstalledPackageRef: installedRef,
+		PkgVersionReference: &corev1.VersionReference{Version: "1.18.4"},
+	}))
+	if got, want := connect.CodeOf(err), connect.CodeFailedPrecondition; got != want {
+		t.Errorf("update: got: %+v, want: %+v, err: %+v", got, want, err)
+	}
+
+	_, err = server.RollbackInstalledPackage(context.Background(), connect.NewRequest(&helmv1.RollbackInstalledPackageRequest{
+		InstalledPackageRef: installedRef,
+		ReleaseRevision:     1,
+	}))
+	if got, want := connect.CodeOf(err), connect.CodeFailedPrecondition; got != want {
+		t.Errorf("rollback: got: %+v, want: %+v, err: %+v", got, want, err)
+	}
+
+	_, err = server.DeleteInstalledPackage(context.Background(), connect.NewRequest(&corev1.DeleteInstalledPackageRequest{
+		InstalledPackageRef: installedRef,
+	}))
+	if got, want := connect.CodeOf(err), connect.CodeFailedPrecondition; got != want {
+		t.Errorf("delete: got: %+v, want: %+v, err: %+v", got, want, err)
+	}
+
+	rel, err := actionConfig.Releases.Last("my-apache")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if got, want := rel.Version, 1; got != want {
+		t.Errorf("got: %d, want: %d", got, want)
+	}
+}
+
 func TestGetInstalledPackageDetail(t *testing.T) {
 	customDetailRevision2, err := anypb.New(&helmv1.InstalledPackageDetailCustomDataHelm{
 		ReleaseRevision: 2,
@@ -2128,12 +3099,14 @@ func releaseForStub(t *testing.T, r releaseStub) *release.Release {
 		},
 		Chart: &chart.Chart{
 			Metadata: &chart.Metadata{
-				Version:    r.chartVersion,
-				Icon:       "https://example.com/icon.png",
-				AppVersion: DefaultAppVersion,
+				Version:     r.chartVersion,
+				Icon:        "https://example.com/icon.png",
+				AppVersion:  DefaultAppVersion,
+				Annotations: r.chartAnnotations,
 			},
 		},
 		Config: config,
+		Labels: r.labels,
 	}
 }
 
@@ -2228,15 +3201,17 @@ func populateAssetDB(t *testing.T, mock sqlmock.Sqlmock, rels []releaseStub) {
 }
 
 type releaseStub struct {
-	name           string
-	namespace      string
-	version        int
-	chartVersion   string
-	chartID        string
-	chartNamespace string
-	latestVersion  string
-	values         string
-	notes          string
-	status         release.Status
-	manifest       string
+	name             string
+	namespace        string
+	version          int
+	chartVersion     string
+	chartID          string
+	chartNamespace   string
+	latestVersion    string
+	values           string
+	notes            string
+	status           release.Status
+	manifest         string
+	labels           map[string]string
+	chartAnnotations map[string]string
 }
@@ -5,7 +5,7 @@ package main
 
 import (
 	"context"
-	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/bufbuild/connect-go"
@@ -27,6 +27,7 @@ func TestUpdateInstalledPackage(t *testing.T) {
 		expectedResponse  *corev1.UpdateInstalledPackageResponse
 		expectedErrorCode connect.Code
 		expectedRelease   *release.Release
+		allowedNamespaces []string
 	}{
 		{
 			name: "updates the installed package from repo without credentials",
@@ -81,6 +82,60 @@ func TestUpdateInstalledPackage(t *testing.T) {
 				Namespace: "default",
 			},
 		},
+		{
+			name: "updates the installed package reusing its values",
+			existingReleases: []releaseStub{
+				{
+					name:           "my-apache",
+					namespace:      "default",
+					chartID:        "bitnami/apache",
+					chartVersion:   "1.18.3",
+					chartNamespace: globalPackagingNamespace,
+					status:         release.StatusDeployed,
+					values:         "{\"foo\": \"bar\"}",
+				},
+			},
+			request: &corev1.UpdateInstalledPackageRequest{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context: &corev1.Context{
+						Cluster:   "default",
+						Namespace: "default",
+					},
+					Identifier: "my-apache",
+				},
+				PkgVersionReference: &corev1.VersionReference{
+					Version: "1.18.4",
+				},
+				ReuseValues: true,
+			},
+			expectedResponse: &corev1.UpdateInstalledPackageResponse{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context: &corev1.Context{
+						Cluster:   "default",
+						Namespace: "default",
+					},
+					Identifier: "my-apache",
+					Plugin:     GetPluginDetail(),
+				},
+			},
+			expectedRelease: &release.Release{
+				Name: "my-apache",
+				Info: &release.Info{
+					Description: "Upgrade complete",
+					Status:      release.StatusDeployed,
+				},
+				Chart: &chart.Chart{
+					Metadata: &chart.Metadata{
+						Name:    "apache",
+						Version: "1.18.4",
+					},
+					Values: map[string]interface{}{},
+				},
+				Config:    map[string]interface{}{"foo": "bar"},
+				Version:   1,
+				Namespace: "default",
+			},
+		},
 		{
 			name: "populates the cluster in the returned context if not set in request",
 			existingReleases: []releaseStub{
@@ -133,6 +188,34 @@ func TestUpdateInstalledPackage(t *testing.T) {
 				Namespace: "default",
 			},
 		},
+		{
+			name: "returns permission denied if the repo no longer allows the namespace",
+			existingReleases: []releaseStub{
+				{
+					name:           "my-apache",
+					namespace:      "default",
+					chartID:        "bitnami/apache",
+					chartVersion:   "1.18.3",
+					chartNamespace: globalPackagingNamespace,
+					status:         release.StatusDeployed,
+				},
+			},
+			allowedNamespaces: []string{"team-a"},
+			request: &corev1.UpdateInstalledPackageRequest{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context: &corev1.Context{
+						Cluster:   "default",
+						Namespace: "default",
+					},
+					Identifier: "my-apache",
+				},
+				PkgVersionReference: &corev1.VersionReference{
+					Version: "1.18.4",
+				},
+				Values: "{\"foo\": \"baz\"}",
+			},
+			expectedErrorCode: connect.CodePermissionDenied,
+		},
 		{
 			name: "returns invalid if installed package doesn't exist",
 			request: &corev1.UpdateInstalledPackageRequest{
@@ -164,11 +247,14 @@ func TestUpdateInstalledPackage(t *testing.T) {
 					Name:      "bitnami",
 					Namespace: globalPackagingNamespace,
 				},
+				Spec: v1alpha1.AppRepositorySpec{
+					AllowedNamespaces: tc.allowedNamespaces,
+				},
 			})
 			defer cleanup()
 			populateAssetDB(t, mockDB, tc.existingReleases)
-			if tc.expectedRelease != nil {
-				populateAssetForTarball(t, mockDB, fmt.Sprintf("bitnami%%%s", tc.expectedRelease.Chart.Metadata.Name), globalPackagingNamespace, tc.expectedRelease.Chart.Metadata.Version)
+			for _, rel := range tc.existingReleases {
+				populateAssetForTarball(t, mockDB, strings.Replace(rel.chartID, "/", "%", 1), rel.chartNamespace, tc.request.GetPkgVersionReference().GetVersion())
 			}
 			response, err := server.UpdateInstalledPackage(context.Background(), connect.NewRequest(tc.request))
 
@@ -205,3 +291,84 @@ func TestUpdateInstalledPackage(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateInstalledPackageFromChartURL(t *testing.T) {
+	chartURLIdentifier := "url:https://charts.example.com/apache-1.18.3.tgz#" + testChartDigest
+	testCases := []struct {
+		name               string
+		allowedHosts       []string
+		version            string
+		expectedStatusCode connect.Code
+	}{
+		{
+			name:         "updates the installed package from the chart URL it was installed from",
+			allowedHosts: []string{"charts.example.com"},
+		},
+		{
+			name:               "returns permission denied if the host of the chart URL is no longer allowed",
+			expectedStatusCode: connect.CodePermissionDenied,
+		},
+		{
+			name:               "returns invalid argument if another version of the chart is requested",
+			allowedHosts:       []string{"charts.example.com"},
+			version:            "1.18.4",
+			expectedStatusCode: connect.CodeInvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			existingReleases := []releaseStub{
+				{
+					name:             "my-apache",
+					namespace:        "default",
+					chartVersion:     "1.18.3",
+					status:           release.StatusDeployed,
+					chartAnnotations: map[string]string{ChartURLAnnotation: chartURLIdentifier},
+				},
+			}
+			request := &corev1.UpdateInstalledPackageRequest{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context: &corev1.Context{
+						Cluster:   "default",
+						Namespace: "default",
+					},
+					Identifier: "my-apache",
+				},
+				PkgVersionReference: &corev1.VersionReference{
+					Version: tc.version,
+				},
+				Values: "{\"foo\": \"baz\"}",
+			}
+			actionConfig := newActionConfigFixture(t, "default", existingReleases, nil)
+			// No repository is needed to update a chart installed from its URL.
+			server, _, cleanup := makeServer(t, true, actionConfig)
+			defer cleanup()
+			server.pluginConfig.AllowedChartURLHosts = tc.allowedHosts
+
+			_, err := server.UpdateInstalledPackage(context.Background(), connect.NewRequest(request))
+
+			if got, want := connect.CodeOf(err), tc.expectedStatusCode; err != nil && got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+			if tc.expectedStatusCode != 0 {
+				if err == nil {
+					t.Fatalf("got: nil, want: error with code %s", tc.expectedStatusCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+
+			// The upgraded release still records the chart URL.
+			upgraded, err := actionConfig.Releases.Last("my-apache")
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if got, want := upgraded.Chart.Metadata.Annotations[ChartURLAnnotation], chartURLIdentifier; got != want {
+				t.Errorf("got: %q, want: %q", got, want)
+			}
+		})
+	}
+}
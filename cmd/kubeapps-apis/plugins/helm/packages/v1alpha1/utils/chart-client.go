@@ -4,6 +4,11 @@
 package utils
 
 import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -15,6 +20,7 @@ import (
 	appRepov1 "github.com/vmware-tanzu/kubeapps/cmd/apprepository-controller/pkg/apis/apprepository/v1alpha1"
 	"github.com/vmware-tanzu/kubeapps/pkg/helm"
 	"github.com/vmware-tanzu/kubeapps/pkg/kube"
+	"github.com/vmware-tanzu/kubeapps/pkg/ocicredentials"
 	"helm.sh/helm/v3/pkg/chart"
 	"helm.sh/helm/v3/pkg/chart/loader"
 	corev1 "k8s.io/api/core/v1"
@@ -39,20 +45,36 @@ type ChartDetails struct {
 	Values string `json:"values,omitempty"`
 	// TarballURL is the URL to the tarball file
 	TarballURL string `json:"tarballURL,omitempty"`
+	// TarballDigest is the expected digest of the tarball file, such as
+	// "sha256:<hex digest>". The tarball is not verified if empty.
+	TarballDigest string `json:"tarballDigest,omitempty"`
+	// TarballMaxSize is the maximum size in bytes of the tarball file. The
+	// size of the tarball is not limited if zero.
+	TarballMaxSize int64 `json:"tarballMaxSize,omitempty"`
 }
 
+var (
+	// ErrTarballDigestMismatch is returned when the downloaded tarball does not
+	// match the expected digest.
+	ErrTarballDigestMismatch = errors.New("the chart tarball does not match the expected digest")
+	// ErrTarballTooLarge is returned when the downloaded tarball exceeds the
+	// maximum size.
+	ErrTarballTooLarge = errors.New("the chart tarball exceeds the maximum size")
+)
+
 // LoadHelmChart returns a helm3 Chart struct from an IOReader
 type LoadHelmChart func(in io.Reader) (*chart.Chart, error)
 
 // ChartClient for exposed funcs
 type ChartClient interface {
-	Init(appRepo *appRepov1.AppRepository, caCertSecret *corev1.Secret, authSecret *corev1.Secret) error
+	Init(ctx context.Context, appRepo *appRepov1.AppRepository, caCertSecret *corev1.Secret, authSecret *corev1.Secret) error
 	GetChart(details *ChartDetails, repoURL string) (*chart.Chart, error)
 }
 
 // HelmRepoClient struct contains the clients required to retrieve charts info
 type HelmRepoClient struct {
 	userAgent string
+	caBundle  []byte
 	netClient *http.Client
 }
 
@@ -65,8 +87,10 @@ func NewChartClient(userAgent string) ChartClient {
 
 // OCIRepoClient struct contains the clients required to retrieve charts info from an OCI registry
 type OCIRepoClient struct {
-	userAgent string
-	puller    helm.ChartPuller
+	userAgent  string
+	caBundle   []byte
+	tokenCache *ocicredentials.TokenCache
+	puller     helm.ChartPuller
 }
 
 // NewOCIClient returns a new OCIClient
@@ -78,9 +102,9 @@ func NewOCIClient(userAgent string) ChartClient {
 
 // Init initialises the HTTP client based on the chart details loading a
 // custom CA if provided (as a secret)
-func (c *HelmRepoClient) Init(appRepo *appRepov1.AppRepository, caCertSecret *corev1.Secret, authSecret *corev1.Secret) error {
+func (c *HelmRepoClient) Init(ctx context.Context, appRepo *appRepov1.AppRepository, caCertSecret *corev1.Secret, authSecret *corev1.Secret) error {
 	var err error
-	c.netClient, err = helm.InitNetClient(appRepo, caCertSecret, authSecret, http.Header{"User-Agent": []string{c.userAgent}})
+	c.netClient, err = helm.InitNetClient(appRepo, caCertSecret, authSecret, c.caBundle, http.Header{"User-Agent": []string{c.userAgent}})
 	return err
 }
 
@@ -101,7 +125,7 @@ func (c *HelmRepoClient) GetChart(details *ChartDetails, repoURL string) (*chart
 	}
 
 	log.Infof("Downloading %s ...", chartURL)
-	chart, err := fetchChart(c.netClient, chartURL)
+	chart, err := fetchChart(c.netClient, chartURL, details.TarballDigest, details.TarballMaxSize)
 	if err != nil {
 		return nil, err
 	}
@@ -121,8 +145,9 @@ func resolveChartURL(indexURL, chartURL string) (string, error) {
 	return parsedChartURL.String(), nil
 }
 
-// fetchChart returns the Chart content given an URL
-func fetchChart(netClient *http.Client, chartURL string) (*chart.Chart, error) {
+// fetchChart returns the Chart content given an URL, verifying the tarball
+// against the digest and maximum size if any.
+func fetchChart(netClient *http.Client, chartURL, digest string, maxSize int64) (*chart.Chart, error) {
 	req, err := getReq(chartURL)
 	if err != nil {
 		return nil, err
@@ -133,11 +158,58 @@ func fetchChart(netClient *http.Client, chartURL string) (*chart.Chart, error) {
 		return nil, err
 	}
 
+	defer res.Body.Close()
+
 	if res.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("chart download request failed")
 	}
 
-	return loader.LoadArchive(res.Body)
+	if digest == "" && maxSize <= 0 {
+		return loader.LoadArchive(res.Body)
+	}
+	tarball, err := readTarball(res, maxSize)
+	if err != nil {
+		return nil, err
+	}
+	if digest != "" {
+		if err := verifyTarballDigest(tarball, digest); err != nil {
+			return nil, err
+		}
+	}
+	return loader.LoadArchive(bytes.NewReader(tarball))
+}
+
+// readTarball reads the tarball of the response, failing as soon as it exceeds
+// the maximum size, if any.
+func readTarball(res *http.Response, maxSize int64) ([]byte, error) {
+	if maxSize <= 0 {
+		return io.ReadAll(res.Body)
+	}
+	if res.ContentLength > maxSize {
+		return nil, fmt.Errorf("%w of %d bytes: got %d bytes", ErrTarballTooLarge, maxSize, res.ContentLength)
+	}
+	tarball, err := io.ReadAll(io.LimitReader(res.Body, maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(tarball)) > maxSize {
+		return nil, fmt.Errorf("%w of %d bytes", ErrTarballTooLarge, maxSize)
+	}
+	return tarball, nil
+}
+
+// verifyTarballDigest checks that the tarball matches the digest, which is
+// only supported with the sha256 algorithm.
+func verifyTarballDigest(tarball []byte, digest string) error {
+	algorithm, expected, found := strings.Cut(digest, ":")
+	if !found || algorithm != "sha256" {
+		return fmt.Errorf("unsupported digest %q, expected sha256:<hex digest>", digest)
+	}
+	sum := sha256.Sum256(tarball)
+	if actual := hex.EncodeToString(sum[:]); !strings.EqualFold(actual, expected) {
+		return fmt.Errorf("%w: got sha256:%s, want %s", ErrTarballDigestMismatch, actual, digest)
+	}
+	return nil
 }
 
 func getReq(rawURL string) (*http.Request, error) {
@@ -157,12 +229,12 @@ func getReq(rawURL string) (*http.Request, error) {
 // Init initialises the HTTP client based on the chart details loading a
 // custom CA if provided (as a secret)
 // TODO(andresmgot): Using a custom CA cert is not supported by ORAS (neither helm), only using the insecure flag
-func (c *OCIRepoClient) Init(appRepo *appRepov1.AppRepository, caCertSecret *corev1.Secret, authSecret *corev1.Secret) error {
+func (c *OCIRepoClient) Init(ctx context.Context, appRepo *appRepov1.AppRepository, caCertSecret *corev1.Secret, authSecret *corev1.Secret) error {
 	var err error
 	headers := http.Header{
 		"User-Agent": []string{c.userAgent},
 	}
-	netClient, err := helm.InitHTTPClient(appRepo, caCertSecret)
+	netClient, err := helm.InitHTTPClient(appRepo, caCertSecret, c.caBundle)
 	if err != nil {
 		return err
 	}
@@ -174,6 +246,19 @@ func (c *OCIRepoClient) Init(appRepo *appRepov1.AppRepository, caCertSecret *cor
 		}
 		headers.Set("Authorization", string(auth))
 	}
+	// The credentials of cloud registries are exchanged for the identity of the
+	// plugin, overriding any static credentials.
+	if provider := appRepo.Spec.OCICredentialProvider; provider != "" {
+		if c.tokenCache == nil {
+			return fmt.Errorf("unable to obtain the %s credentials of the registry, no credential helper configured", provider)
+		}
+		var auth string
+		auth, err = c.tokenCache.AuthorizationHeader(ctx, provider, appRepo.Spec.URL)
+		if err != nil {
+			return err
+		}
+		headers.Set("Authorization", auth)
+	}
 
 	c.puller = &helm.OCIPuller{Resolver: docker.NewResolver(docker.ResolverOptions{Headers: headers, Client: netClient})}
 	return err
@@ -212,23 +297,30 @@ type ChartClientFactoryInterface interface {
 
 // ChartClientFactory provides a real implementation of the ChartClientFactory interface
 // returning either an OCI repository client or a traditional helm repository chart client.
-type ChartClientFactory struct{}
+type ChartClientFactory struct {
+	// CABundle is trusted, in addition to the custom CA of each repository,
+	// by the clients created by the factory.
+	CABundle []byte
+	// TokenCache provides the credentials of the OCI repositories with a
+	// credential provider, shared by the clients to reuse them until they expire.
+	TokenCache *ocicredentials.TokenCache
+}
 
 // New for ClientResolver
 func (c *ChartClientFactory) New(repoType, userAgent string) ChartClient {
 	var client ChartClient
 	switch repoType {
 	case "oci":
-		client = NewOCIClient(userAgent)
+		client = &OCIRepoClient{userAgent: userAgent, caBundle: c.CABundle, tokenCache: c.TokenCache}
 	default:
-		client = NewChartClient(userAgent)
+		client = &HelmRepoClient{userAgent: userAgent, caBundle: c.CABundle}
 	}
 	return client
 }
 
 // GetChart retrieves a chart
-func GetChart(chartDetails *ChartDetails, appRepo *appRepov1.AppRepository, caCertSecret *k8scorev1.Secret, authSecret *k8scorev1.Secret, chartClient ChartClient) (*chart.Chart, error) {
-	err := chartClient.Init(appRepo, caCertSecret, authSecret)
+func GetChart(ctx context.Context, chartDetails *ChartDetails, appRepo *appRepov1.AppRepository, caCertSecret *k8scorev1.Secret, authSecret *k8scorev1.Secret, chartClient ChartClient) (*chart.Chart, error) {
+	err := chartClient.Init(ctx, appRepo, caCertSecret, authSecret)
 	if err != nil {
 		return nil, err
 	}
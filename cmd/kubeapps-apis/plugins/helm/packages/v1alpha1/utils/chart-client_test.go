@@ -5,6 +5,8 @@ package utils
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -12,10 +14,12 @@ import (
 	"path"
 	"strings"
 	"testing"
+	"time"
 
 	appRepov1 "github.com/vmware-tanzu/kubeapps/cmd/apprepository-controller/pkg/apis/apprepository/v1alpha1"
 	helmfake "github.com/vmware-tanzu/kubeapps/pkg/helm/fake"
 	helmtest "github.com/vmware-tanzu/kubeapps/pkg/helm/test"
+	"github.com/vmware-tanzu/kubeapps/pkg/ocicredentials"
 	corev1 "k8s.io/api/core/v1"
 
 	"github.com/stretchr/testify/assert"
@@ -65,10 +69,13 @@ func Test_resolveChartURL(t *testing.T) {
 func TestGetChartHttp(t *testing.T) {
 	const repoName = "foo-repo"
 	testCases := []struct {
-		name         string
-		chartVersion string
-		userAgent    string
-		tarballPath  string
+		name          string
+		chartVersion  string
+		userAgent     string
+		tarballPath   string
+		tarballDigest string
+		tarballSize   int64
+		expectedErr   error
 	}{
 		{
 			name:         "gets the chart with tarballURL",
@@ -92,6 +99,32 @@ func TestGetChartHttp(t *testing.T) {
 			chartVersion: "5.1.1-apiVersionV2",
 			tarballPath:  "/nginx-5.1.1-apiVersionV2.tgz",
 		},
+		{
+			name:          "gets the chart matching the tarball digest",
+			chartVersion:  "5.1.1-apiVersionV2",
+			tarballPath:   "/nginx-5.1.1-apiVersionV2.tgz",
+			tarballDigest: "sha256:4882fe14b7e62401333f280eda72ebde3216b71528f24376b59efd3068a34641",
+		},
+		{
+			name:          "fails if the chart does not match the tarball digest",
+			chartVersion:  "5.1.1-apiVersionV2",
+			tarballPath:   "/nginx-5.1.1-apiVersionV2.tgz",
+			tarballDigest: "sha256:0000000000000000000000000000000000000000000000000000000000000000",
+			expectedErr:   ErrTarballDigestMismatch,
+		},
+		{
+			name:         "gets the chart not exceeding the maximum size",
+			chartVersion: "5.1.1-apiVersionV2",
+			tarballPath:  "/nginx-5.1.1-apiVersionV2.tgz",
+			tarballSize:  7582,
+		},
+		{
+			name:         "fails if the chart exceeds the maximum size",
+			chartVersion: "5.1.1-apiVersionV2",
+			tarballPath:  "/nginx-5.1.1-apiVersionV2.tgz",
+			tarballSize:  7581,
+			expectedErr:  ErrTarballTooLarge,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -116,6 +149,8 @@ func TestGetChartHttp(t *testing.T) {
 			ChartName:                 "nginx",
 			Version:                   tc.chartVersion,
 			TarballURL:                server.URL + tc.tarballPath,
+			TarballDigest:             tc.tarballDigest,
+			TarballMaxSize:            tc.tarballSize,
 		}
 		t.Run(tc.name, func(t *testing.T) {
 
@@ -125,6 +160,12 @@ func TestGetChartHttp(t *testing.T) {
 			chUtils.netClient = server.Client()
 			ch, err := chUtils.GetChart(&target, server.URL)
 
+			if tc.expectedErr != nil {
+				if !errors.Is(err, tc.expectedErr) {
+					t.Fatalf("got: %v, want: %v", err, tc.expectedErr)
+				}
+				return
+			}
 			if err != nil {
 				t.Fatalf("Unexpected error: %v", err)
 			}
@@ -148,7 +189,7 @@ func TestGetChartHttp(t *testing.T) {
 func TestOCIClient(t *testing.T) {
 	t.Run("InitClient - Creates puller with User-Agent header", func(t *testing.T) {
 		cli := NewOCIClient("foo")
-		err := cli.Init(&appRepov1.AppRepository{}, &corev1.Secret{}, &corev1.Secret{})
+		err := cli.Init(context.Background(), &appRepov1.AppRepository{}, &corev1.Secret{}, &corev1.Secret{})
 		if err != nil {
 			t.Errorf("unexpected error: %v", err)
 		}
@@ -174,7 +215,7 @@ func TestOCIClient(t *testing.T) {
 				"custom-secret-key": []byte("Basic Auth"),
 			},
 		}
-		err := cli.Init(appRepo, &corev1.Secret{}, authSecret)
+		err := cli.Init(context.Background(), appRepo, &corev1.Secret{}, authSecret)
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -200,7 +241,7 @@ func TestOCIClient(t *testing.T) {
 				".dockerconfigjson": []byte(`{"auths":{"foo":{"username":"foo","password":"bar"}}}`),
 			},
 		}
-		err := cli.Init(appRepo, &corev1.Secret{}, authSecret)
+		err := cli.Init(context.Background(), appRepo, &corev1.Secret{}, authSecret)
 		if err != nil {
 			t.Fatalf("Unexpected error: %v", err)
 		}
@@ -208,6 +249,40 @@ func TestOCIClient(t *testing.T) {
 		helmtest.CheckHeader(t, cli.(*OCIRepoClient).puller, "Authorization", "Basic Zm9vOmJhcg==")
 	})
 
+	t.Run("InitClient - Creates puller with the credentials of the provider", func(t *testing.T) {
+		factory := &ChartClientFactory{
+			TokenCache: ocicredentials.NewTokenCache(map[string]ocicredentials.Helper{
+				ocicredentials.ProviderAWS: fakeCredentialHelper{},
+			}),
+		}
+		cli := factory.New("oci", "")
+		appRepo := &appRepov1.AppRepository{
+			Spec: appRepov1.AppRepositorySpec{
+				URL:                   "oci://123456789012.dkr.ecr.us-east-1.amazonaws.com/charts",
+				OCICredentialProvider: ocicredentials.ProviderAWS,
+			},
+		}
+		err := cli.Init(context.Background(), appRepo, &corev1.Secret{}, &corev1.Secret{})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		// Authorization: Basic base64('AWS:token')
+		helmtest.CheckHeader(t, cli.(*OCIRepoClient).puller, "Authorization", "Basic QVdTOnRva2Vu")
+	})
+
+	t.Run("InitClient - Fails without credential helper for the provider", func(t *testing.T) {
+		cli := NewOCIClient("")
+		appRepo := &appRepov1.AppRepository{
+			Spec: appRepov1.AppRepositorySpec{
+				URL:                   "oci://123456789012.dkr.ecr.us-east-1.amazonaws.com/charts",
+				OCICredentialProvider: ocicredentials.ProviderAWS,
+			},
+		}
+		if err := cli.Init(context.Background(), appRepo, &corev1.Secret{}, &corev1.Secret{}); err == nil {
+			t.Errorf("got: nil, want: an error")
+		}
+	})
+
 	t.Run("GetChart - Fails if the puller has not been instantiated", func(t *testing.T) {
 		cli := NewOCIClient("foo")
 		_, err := cli.GetChart(nil, "")
@@ -257,3 +332,9 @@ func TestOCIClient(t *testing.T) {
 		}
 	})
 }
+
+type fakeCredentialHelper struct{}
+
+func (fakeCredentialHelper) Credentials(ctx context.Context, repoURL string) (*ocicredentials.Credentials, error) {
+	return &ocicredentials.Credentials{Username: "AWS", Password: "token", ExpiresAt: time.Now().Add(time.Hour)}, nil
+}
@@ -4,6 +4,7 @@
 package fake
 
 import (
+	"context"
 	appRepov1 "github.com/vmware-tanzu/kubeapps/cmd/apprepository-controller/pkg/apis/apprepository/v1alpha1"
 	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/helm/packages/v1alpha1/utils"
 	"helm.sh/helm/v3/pkg/chart"
@@ -39,7 +40,7 @@ func getValues(raw []byte) (map[string]interface{}, error) {
 }
 
 // Init fake
-func (f *ChartClient) Init(appRepo *appRepov1.AppRepository, caCertSecret *corev1.Secret, authSecret *corev1.Secret) error {
+func (f *ChartClient) Init(ctx context.Context, appRepo *appRepov1.AppRepository, caCertSecret *corev1.Secret, authSecret *corev1.Secret) error {
 	return nil
 }
 
@@ -4,14 +4,19 @@
 package utils
 
 import (
+	"database/sql"
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 
 	_ "github.com/lib/pq"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/pkgutils"
 	"github.com/vmware-tanzu/kubeapps/pkg/chart/models"
 	"github.com/vmware-tanzu/kubeapps/pkg/dbutils"
+	"k8s.io/apimachinery/pkg/types"
 	log "k8s.io/klog/v2"
 )
 
@@ -192,6 +197,41 @@ func (m *PostgresAssetManager) GetPaginatedChartListWithFilters(cq ChartQuery, s
 	return charts, nil
 }
 
+// GetChartCountWithFilters returns the number of charts matching the query.
+func (m *PostgresAssetManager) GetChartCountWithFilters(cq ChartQuery) (int, error) {
+	whereQuery, whereQueryParams, err := m.GenerateWhereClause(cq)
+	if err != nil {
+		return 0, err
+	}
+	return m.QueryCount(fmt.Sprintf("SELECT COUNT(*) FROM %s %s", dbutils.ChartTable, whereQuery), whereQueryParams...)
+}
+
+// GetRepositoryLastUpdate returns the time at which the charts of the
+// repository were last synced, or the zero time if it has not been synced yet.
+func (m *PostgresAssetManager) GetRepositoryLastUpdate(namespace, name string) (time.Time, error) {
+	var lastUpdate sql.NullString
+	row := m.GetDB().QueryRow(fmt.Sprintf("SELECT last_update FROM %s WHERE namespace = $1 AND name = $2", dbutils.RepositoryTable), namespace, name)
+	if err := row.Scan(&lastUpdate); err == sql.ErrNoRows {
+		return time.Time{}, nil
+	} else if err != nil {
+		return time.Time{}, err
+	}
+	if !lastUpdate.Valid || lastUpdate.String == "" {
+		return time.Time{}, nil
+	}
+	return parseRepositoryLastUpdate(lastUpdate.String)
+}
+
+// parseRepositoryLastUpdate parses the last update of a repository, which the
+// asset-syncer stores using the default format of time.Time.String(),
+// including the monotonic clock reading when present.
+func parseRepositoryLastUpdate(lastUpdate string) (time.Time, error) {
+	if i := strings.Index(lastUpdate, " m="); i >= 0 {
+		lastUpdate = lastUpdate[:i]
+	}
+	return time.Parse("2006-01-02 15:04:05.999999999 -0700 MST", lastUpdate)
+}
+
 func (m *PostgresAssetManager) GenerateWhereClause(cq ChartQuery) (string, []interface{}, error) {
 	whereClauses := []string{}
 	whereQueryParams := []interface{}{}
@@ -234,6 +274,35 @@ func (m *PostgresAssetManager) GenerateWhereClause(cq ChartQuery) (string, []int
 			whereClauses = append(whereClauses, repoQuery)
 		}
 	}
+	for _, repo := range cq.ExcludedRepos {
+		whereQueryParams = append(whereQueryParams, repo.Namespace, repo.Name)
+		whereClauses = append(whereClauses, fmt.Sprintf(
+			"NOT (repo_namespace = $%d AND repo_name = $%d)", len(whereQueryParams)-1, len(whereQueryParams),
+		))
+	}
+	// Iterate the repositories in order so that the query is deterministic
+	policyRepos := make([]types.NamespacedName, 0, len(cq.PackagePolicies))
+	for repo, policy := range cq.PackagePolicies {
+		if policy != nil {
+			policyRepos = append(policyRepos, repo)
+		}
+	}
+	sort.Slice(policyRepos, func(i, j int) bool { return policyRepos[i].String() < policyRepos[j].String() })
+	for _, repo := range policyRepos {
+		policy := cq.PackagePolicies[repo]
+		whereQueryParams = append(whereQueryParams, repo.Namespace, repo.Name)
+		repoClause := fmt.Sprintf("repo_namespace = $%d AND repo_name = $%d", len(whereQueryParams)-1, len(whereQueryParams))
+		policyClauses := []string{}
+		if denied := pkgutils.PackagePatternsRegexp(policy.DeniedPackages); denied != "" {
+			whereQueryParams = append(whereQueryParams, denied)
+			policyClauses = append(policyClauses, fmt.Sprintf("info->>'name' ~ $%d", len(whereQueryParams)))
+		}
+		if allowed := pkgutils.PackagePatternsRegexp(policy.AllowedPackages); allowed != "" {
+			whereQueryParams = append(whereQueryParams, allowed)
+			policyClauses = append(policyClauses, fmt.Sprintf("NOT (info->>'name' ~ $%d)", len(whereQueryParams)))
+		}
+		whereClauses = append(whereClauses, fmt.Sprintf("NOT (%s AND (%s))", repoClause, strings.Join(policyClauses, " OR ")))
+	}
 	if cq.Categories != nil && len(cq.Categories) > 0 {
 		categoryClauses := []string{}
 		for _, category := range cq.Categories {
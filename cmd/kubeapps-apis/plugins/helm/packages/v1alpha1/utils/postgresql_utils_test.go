@@ -8,12 +8,15 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/google/go-cmp/cmp"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/pkgutils"
 	"github.com/vmware-tanzu/kubeapps/pkg/chart/models"
 	"github.com/vmware-tanzu/kubeapps/pkg/dbutils"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 func getMockManager(t *testing.T) (*PostgresAssetManager, sqlmock.Sqlmock, func()) {
@@ -273,6 +276,24 @@ func Test_GetAllChartCategories(t *testing.T) {
 		})
 	}
 }
+
+func Test_GetChartCountWithFilters(t *testing.T) {
+	pgManager, mock, cleanup := getMockManager(t)
+	defer cleanup()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM charts WHERE (repo_namespace = $1 OR repo_namespace = $2) AND (info->>'category' = $3)")).
+		WithArgs("other-namespace", "kubeapps", "cat1").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	count, err := pgManager.GetChartCountWithFilters(ChartQuery{Namespace: "other-namespace", Categories: []string{"cat1"}})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if got, want := count, 3; got != want {
+		t.Errorf("got: %d, want: %d", got, want)
+	}
+}
+
 func Test_GetPaginatedChartList(t *testing.T) {
 	availableCharts := []*models.Chart{
 		{ID: "bar", ChartVersions: []models.ChartVersion{{Digest: "456"}}},
@@ -425,6 +446,8 @@ func Test_GenerateWhereClause(t *testing.T) {
 		repos          []string
 		categories     []string
 		query          string
+		excludedRepos  []types.NamespacedName
+		policies       map[types.NamespacedName]*pkgutils.PackagePolicy
 		expectedClause string
 		expectedParams []interface{}
 	}{
@@ -620,6 +643,32 @@ func Test_GenerateWhereClause(t *testing.T) {
 			expectedClause: `WHERE (repo_namespace = $1 OR repo_namespace = $2) AND ((info ->> 'name' ILIKE $3) OR (info ->> 'description' ILIKE $3) OR (info -> 'repo' ->> 'name' ILIKE $3) OR (info ->> 'keywords' ILIKE $3) OR (info ->> 'sources' ILIKE $3) OR (info -> 'maintainers' ->> 'name' ILIKE $3))`,
 			expectedParams: []interface{}{string(""), string("kubeapps"), string("%my%2Fchart%")},
 		},
+		{
+			name:           "returns where clause - excluded repos",
+			namespace:      "my-ns",
+			chartName:      "",
+			version:        "",
+			appVersion:     "",
+			repos:          []string{""},
+			categories:     []string{""},
+			query:          "",
+			excludedRepos:  []types.NamespacedName{{Namespace: "kubeapps", Name: "internal"}, {Namespace: "my-ns", Name: "other"}},
+			expectedClause: "WHERE (repo_namespace = $1 OR repo_namespace = $2) AND NOT (repo_namespace = $3 AND repo_name = $4) AND NOT (repo_namespace = $5 AND repo_name = $6)",
+			expectedParams: []interface{}{string("my-ns"), string("kubeapps"), string("kubeapps"), string("internal"), string("my-ns"), string("other")},
+		},
+		{
+			name:       "returns where clause - package policies",
+			namespace:  "my-ns",
+			repos:      []string{""},
+			categories: []string{""},
+			policies: map[types.NamespacedName]*pkgutils.PackagePolicy{
+				{Namespace: "my-ns", Name: "other"}:        {AllowedPackages: []string{"nginx", "apache"}},
+				{Namespace: "kubeapps", Name: "internal"}:  {AllowedPackages: []string{"*"}, DeniedPackages: []string{"ngin?"}},
+				{Namespace: "my-ns", Name: "unrestricted"}: nil,
+			},
+			expectedClause: `WHERE (repo_namespace = $1 OR repo_namespace = $2) AND NOT (repo_namespace = $3 AND repo_name = $4 AND (info->>'name' ~ $5 OR NOT (info->>'name' ~ $6))) AND NOT (repo_namespace = $7 AND repo_name = $8 AND (NOT (info->>'name' ~ $9)))`,
+			expectedParams: []interface{}{string("my-ns"), string("kubeapps"), string("kubeapps"), string("internal"), string("^(ngin[^/])$"), string("^([^/]*)$"), string("my-ns"), string("other"), string("^(nginx|apache)$")},
+		},
 		{
 			name:           "returns where clause - every param",
 			namespace:      "my-ns",
@@ -639,13 +688,15 @@ func Test_GenerateWhereClause(t *testing.T) {
 			defer cleanup()
 
 			cq := ChartQuery{
-				Namespace:   tt.namespace,
-				ChartName:   tt.chartName,
-				Version:     tt.version,
-				AppVersion:  tt.appVersion,
-				SearchQuery: tt.query,
-				Repos:       tt.repos,
-				Categories:  tt.categories,
+				Namespace:       tt.namespace,
+				ChartName:       tt.chartName,
+				Version:         tt.version,
+				AppVersion:      tt.appVersion,
+				SearchQuery:     tt.query,
+				Repos:           tt.repos,
+				Categories:      tt.categories,
+				ExcludedRepos:   tt.excludedRepos,
+				PackagePolicies: tt.policies,
 			}
 			whereQuery, whereQueryParams, _ := pgManager.GenerateWhereClause(cq)
 
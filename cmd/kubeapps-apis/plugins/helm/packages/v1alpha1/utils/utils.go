@@ -7,11 +7,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/pkgutils"
 	"github.com/vmware-tanzu/kubeapps/pkg/chart/models"
 	"github.com/vmware-tanzu/kubeapps/pkg/dbutils"
 	"github.com/vmware-tanzu/kubeapps/pkg/kube"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 )
 
@@ -27,7 +30,9 @@ type AssetManager interface {
 	GetChartVersion(namespace, chartID, version string) (models.Chart, error)
 	GetChartFiles(namespace, filesID string) (models.ChartFiles, error)
 	GetPaginatedChartListWithFilters(cq ChartQuery, startItemNumber, pageSize int) ([]*models.Chart, error)
+	GetChartCountWithFilters(cq ChartQuery) (int, error)
 	GetAllChartCategories(cq ChartQuery) ([]*models.ChartCategory, error)
+	GetRepositoryLastUpdate(namespace, name string) (time.Time, error)
 }
 
 // ChartQuery is a container for passing the supported query parameters for generating the WHERE query
@@ -39,6 +44,10 @@ type ChartQuery struct {
 	SearchQuery string
 	Repos       []string
 	Categories  []string
+	// ExcludedRepos are the repositories whose charts must not be returned
+	ExcludedRepos []types.NamespacedName
+	// PackagePolicies restrict the charts returned from the given repositories
+	PackagePolicies map[types.NamespacedName]*pkgutils.PackagePolicy
 }
 
 func NewManager(databaseType string, config dbutils.Config, globalPackagingNamespace string) (AssetManager, error) {
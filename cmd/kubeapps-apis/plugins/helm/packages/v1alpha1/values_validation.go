@@ -0,0 +1,142 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/xeipuuv/gojsonschema"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chartutil"
+)
+
+// rootValuesPath is the path reported by the schema validation for a
+// violation of the values themselves rather than of one of their fields.
+const rootValuesPath = "(root)"
+
+// validateValues validates the values, coalesced with the default values of
+// the chart, against the values.schema.json of the chart and of its enabled
+// dependencies. When the values violate a schema, an InvalidArgument error is
+// returned with a BadRequest detail listing the path and message of each
+// violation.
+func validateValues(ch *chart.Chart, valuesYaml string) error {
+	values, err := chartutil.ReadValues([]byte(valuesYaml))
+	if err != nil {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Unable to parse the values: %w", err))
+	}
+	coalesced, err := chartutil.CoalesceValues(ch, values)
+	if err != nil {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Unable to merge the values with the default values of the chart %q: %w", ch.Name(), err))
+	}
+
+	violations, err := schemaViolations(ch, coalesced, coalesced, "")
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to validate the values against the schema of the chart %q: %w", ch.Name(), err))
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+
+	messages := make([]string, len(violations))
+	for i, violation := range violations {
+		messages[i] = fmt.Sprintf("%s: %s", violation.GetField(), violation.GetDescription())
+	}
+	connectErr := connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("The values do not match the schema of the chart %q: %s", ch.Name(), strings.Join(messages, "; ")))
+	detail, err := connect.NewErrorDetail(&errdetails.BadRequest{FieldViolations: violations})
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to report the schema violations: %w", err))
+	}
+	connectErr.AddDetail(detail)
+	return connectErr
+}
+
+// schemaViolations returns the violations of the schema of the chart, and of
+// the schemas of its enabled dependencies, by the values. The path is the
+// prefix of the chart values within the root values.
+func schemaViolations(ch *chart.Chart, values, rootValues chartutil.Values, path string) ([]*errdetails.BadRequest_FieldViolation, error) {
+	violations := []*errdetails.BadRequest_FieldViolation{}
+	if len(ch.Schema) > 0 {
+		valuesJSON, err := json.Marshal(values)
+		if err != nil {
+			return nil, err
+		}
+		result, err := gojsonschema.Validate(gojsonschema.NewBytesLoader(ch.Schema), gojsonschema.NewBytesLoader(valuesJSON))
+		if err != nil {
+			return nil, err
+		}
+		for _, resultErr := range result.Errors() {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{
+				Field:       violationPath(path, resultErr.Field()),
+				Description: resultErr.Description(),
+			})
+		}
+	}
+
+	if ch.Metadata == nil {
+		return violations, nil
+	}
+	dependencies := map[string]*chart.Chart{}
+	for _, dependency := range ch.Dependencies() {
+		dependencies[dependency.Name()] = dependency
+	}
+	for _, dependency := range ch.Metadata.Dependencies {
+		dependencyChart, ok := dependencies[dependency.Name]
+		if !ok || !isDependencyEnabled(dependency, rootValues, path) {
+			continue
+		}
+		key := dependency.Name
+		if dependency.Alias != "" {
+			key = dependency.Alias
+		}
+		dependencyValues, err := values.Table(key)
+		if err != nil {
+			dependencyValues = chartutil.Values{}
+		}
+		dependencyViolations, err := schemaViolations(dependencyChart, dependencyValues, rootValues, path+key+".")
+		if err != nil {
+			return nil, err
+		}
+		violations = append(violations, dependencyViolations...)
+	}
+	return violations, nil
+}
+
+// isDependencyEnabled evaluates the condition of the dependency as Helm does,
+// the first condition path resolving to a boolean deciding whether the
+// dependency is enabled.
+func isDependencyEnabled(dependency *chart.Dependency, rootValues chartutil.Values, path string) bool {
+	for _, condition := range strings.Split(dependency.Condition, ",") {
+		condition = strings.TrimSpace(condition)
+		if condition == "" {
+			continue
+		}
+		value, err := rootValues.PathValue(path + condition)
+		if err != nil {
+			continue
+		}
+		if enabled, ok := value.(bool); ok {
+			return enabled
+		}
+	}
+	return true
+}
+
+// violationPath returns the path of the violated field within the root values.
+func violationPath(path, field string) string {
+	path = strings.TrimSuffix(path, ".")
+	switch {
+	case field == rootValuesPath && path == "":
+		return rootValuesPath
+	case field == rootValuesPath:
+		return path
+	case path == "":
+		return field
+	default:
+		return path + "." + field
+	}
+}
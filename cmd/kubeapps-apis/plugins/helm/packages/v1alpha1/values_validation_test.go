@@ -0,0 +1,139 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/testing/protocmp"
+	"helm.sh/helm/v3/pkg/chart"
+)
+
+const testValuesSchema = `{
+	"$schema": "http://json-schema.org/schema#",
+	"type": "object",
+	"required": ["replicaCount"],
+	"properties": {
+		"replicaCount": {"type": "integer", "minimum": 1},
+		"service": {
+			"type": "object",
+			"properties": {
+				"type": {"type": "string", "enum": ["ClusterIP", "NodePort"]}
+			}
+		}
+	}
+}`
+
+const testSubchartValuesSchema = `{
+	"$schema": "http://json-schema.org/schema#",
+	"type": "object",
+	"properties": {
+		"auth": {"type": "boolean"}
+	}
+}`
+
+func newChartWithSchema(name string, values map[string]interface{}, schema string, dependencies ...*chart.Dependency) *chart.Chart {
+	return &chart.Chart{
+		Metadata: &chart.Metadata{
+			Name:         name,
+			Version:      "1.0.0",
+			Dependencies: dependencies,
+		},
+		Values: values,
+		Schema: []byte(schema),
+	}
+}
+
+func TestValidateValues(t *testing.T) {
+	testCases := []struct {
+		name               string
+		values             string
+		subchartCondition  string
+		expectedViolations []*errdetails.BadRequest_FieldViolation
+	}{
+		{
+			name:   "it accepts values matching the schemas",
+			values: "replicaCount: 2\nservice:\n  type: NodePort\nredis:\n  auth: true\n",
+		},
+		{
+			name:   "it accepts empty values when the defaults match the schemas",
+			values: "",
+		},
+		{
+			name:   "it reports the violations of the chart schema",
+			values: "replicaCount: 0\nservice:\n  type: LoadBalancer\n",
+			expectedViolations: []*errdetails.BadRequest_FieldViolation{
+				{Field: "replicaCount", Description: "Must be greater than or equal to 1"},
+				{Field: "service.type", Description: `service.type must be one of the following: "ClusterIP", "NodePort"`},
+			},
+		},
+		{
+			name:   "it reports the violations of the subchart schema within the subchart values",
+			values: "redis:\n  auth: not-a-boolean\n",
+			expectedViolations: []*errdetails.BadRequest_FieldViolation{
+				{Field: "redis.auth", Description: "Invalid type. Expected: boolean, given: string"},
+			},
+		},
+		{
+			name:              "it ignores the schema of a disabled subchart",
+			values:            "redis:\n  enabled: false\n  auth: not-a-boolean\n",
+			subchartCondition: "redis.enabled",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			subchart := newChartWithSchema("redis", map[string]interface{}{"auth": false}, testSubchartValuesSchema)
+			ch := newChartWithSchema("apache", map[string]interface{}{"replicaCount": 1}, testValuesSchema, &chart.Dependency{
+				Name:      "redis",
+				Condition: tc.subchartCondition,
+			})
+			ch.AddDependency(subchart)
+
+			err := validateValues(ch, tc.values)
+
+			if tc.expectedViolations == nil {
+				if err != nil {
+					t.Fatalf("%+v", err)
+				}
+				return
+			}
+			if got, want := connect.CodeOf(err), connect.CodeInvalidArgument; got != want {
+				t.Fatalf("got: %v, want: %v, err: %+v", got, want, err)
+			}
+			var connectErr *connect.Error
+			if !errors.As(err, &connectErr) {
+				t.Fatalf("got: %+v, want: a connect error", err)
+			}
+			if got, want := len(connectErr.Details()), 1; got != want {
+				t.Fatalf("got: %d, want: %d", got, want)
+			}
+			detail, err := connectErr.Details()[0].Value()
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			badRequest, ok := detail.(*errdetails.BadRequest)
+			if !ok {
+				t.Fatalf("got: %T, want: *errdetails.BadRequest", detail)
+			}
+			if got, want := badRequest.GetFieldViolations(), tc.expectedViolations; !cmp.Equal(want, got, protocmp.Transform()) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, protocmp.Transform()))
+			}
+		})
+	}
+}
+
+func TestValidateValuesInvalidYaml(t *testing.T) {
+	ch := newChartWithSchema("apache", map[string]interface{}{"replicaCount": 1}, testValuesSchema)
+
+	err := validateValues(ch, "replicaCount: [")
+
+	if got, want := connect.CodeOf(err), connect.CodeInvalidArgument; got != want {
+		t.Errorf("got: %v, want: %v, err: %+v", got, want, err)
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	helmv1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/plugins/helm/packages/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/pkg/chart/models"
+	httpclient "github.com/vmware-tanzu/kubeapps/pkg/http-client"
+	"k8s.io/apimachinery/pkg/util/cache"
+	log "k8s.io/klog/v2"
+)
+
+const (
+	// vulnerabilitySummariesCacheSize is the number of chart digests whose
+	// vulnerability summary is kept in memory.
+	vulnerabilitySummariesCacheSize = 1000
+	// vulnerabilitySummariesCacheTTL is how long the vulnerability summary of a
+	// chart digest is reused before the scanner is requested again, so that
+	// newly disclosed vulnerabilities are eventually reported.
+	vulnerabilitySummariesCacheTTL = 15 * time.Minute
+)
+
+// vulnerabilityScanner requests the vulnerability scanner configured for the
+// plugin and caches its summaries per chart digest.
+type vulnerabilityScanner struct {
+	// client trusts the CA bundle of the plugin and honours the proxy
+	// configured in the environment.
+	client    *http.Client
+	summaries *cache.LRUExpireCache
+}
+
+// newVulnerabilityScanner returns a vulnerabilityScanner whose requests trust
+// the given CA bundle, in addition to the system certificates.
+func newVulnerabilityScanner(caBundle []byte) (*vulnerabilityScanner, error) {
+	client, err := httpclient.NewWithCertBytes(caBundle, false)
+	if err != nil {
+		return nil, err
+	}
+	return &vulnerabilityScanner{
+		client:    client,
+		summaries: cache.NewLRUExpireCache(vulnerabilitySummariesCacheSize),
+	}, nil
+}
+
+// vulnerabilityScanRequest is the body posted to the vulnerability scanner
+// configured for the plugin, identifying a chart version and its images.
+type vulnerabilityScanRequest struct {
+	Chart  vulnerabilityScanChart `json:"chart"`
+	Images []string               `json:"images"`
+}
+
+// vulnerabilityScanChart identifies the chart version to be scanned.
+type vulnerabilityScanChart struct {
+	ID      string   `json:"id"`
+	Version string   `json:"version"`
+	Digest  string   `json:"digest"`
+	URLs    []string `json:"urls"`
+}
+
+// vulnerabilityScanResponse is the summary returned by the vulnerability
+// scanner configured for the plugin.
+type vulnerabilityScanResponse struct {
+	Critical  int32  `json:"critical"`
+	High      int32  `json:"high"`
+	ReportURL string `json:"reportURL"`
+}
+
+// scanChartVulnerabilities returns the summary of the vulnerabilities of a
+// chart version, as reported by the vulnerability scanner configured for the
+// plugin, or nil when none is configured. A failing scan is only logged, not
+// to prevent the package detail from being returned. The summaries are cached
+// per scanner and chart digest, as the charts of a digest are identical.
+func (s *Server) scanChartVulnerabilities(ctx context.Context, chartID string, chartVersion *models.ChartVersion) *helmv1.VulnerabilitySummary {
+	config := s.config().VulnerabilityScanner
+	if config.URL == "" || chartVersion == nil || s.vulnerabilityScanner == nil {
+		return nil
+	}
+	cacheKey := ""
+	if chartVersion.Digest != "" {
+		cacheKey = config.URL + "|" + chartVersion.Digest
+		if summary, ok := s.vulnerabilityScanner.summaries.Get(cacheKey); ok {
+			return summary.(*helmv1.VulnerabilitySummary)
+		}
+	}
+	summary, err := postVulnerabilityScan(ctx, s.vulnerabilityScanner.client, config.URL, time.Duration(config.TimeoutSeconds)*time.Second, &vulnerabilityScanRequest{
+		Chart: vulnerabilityScanChart{
+			ID:      chartID,
+			Version: chartVersion.Version,
+			Digest:  chartVersion.Digest,
+			URLs:    chartVersion.URLs,
+		},
+		Images: chartVersion.Images,
+	})
+	if err != nil {
+		log.Errorf("Unable to scan the vulnerabilities of chart %q version %q: %v", chartID, chartVersion.Version, err)
+		return nil
+	}
+	if cacheKey != "" {
+		s.vulnerabilityScanner.summaries.Add(cacheKey, summary, vulnerabilitySummariesCacheTTL)
+	}
+	return summary
+}
+
+// postVulnerabilityScan posts the scan request to the scanner at the given URL.
+func postVulnerabilityScan(ctx context.Context, client *http.Client, scannerURL string, timeout time.Duration, scanRequest *vulnerabilityScanRequest) (*helmv1.VulnerabilitySummary, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	body, err := json.Marshal(scanRequest)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, scannerURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", fmt.Sprintf("%s/%s/%s/%s", UserAgentPrefix, pluginDetail.Name, pluginDetail.Version, version))
+
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %q from the vulnerability scanner", res.Status)
+	}
+
+	var scanResponse vulnerabilityScanResponse
+	if err := json.NewDecoder(res.Body).Decode(&scanResponse); err != nil {
+		return nil, fmt.Errorf("invalid response from the vulnerability scanner: %w", err)
+	}
+	// The report URL is linked from the package detail, so only web pages are
+	// accepted.
+	if scanResponse.ReportURL != "" {
+		reportURL, err := url.Parse(scanResponse.ReportURL)
+		if err != nil || (reportURL.Scheme != "http" && reportURL.Scheme != "https") || reportURL.Host == "" {
+			return nil, fmt.Errorf("invalid report URL %q from the vulnerability scanner, expected an absolute http or https URL", scanResponse.ReportURL)
+		}
+	}
+	return &helmv1.VulnerabilitySummary{
+		Critical:  scanResponse.Critical,
+		High:      scanResponse.High,
+		ReportUrl: scanResponse.ReportURL,
+	}, nil
+}
@@ -0,0 +1,148 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bufbuild/connect-go"
+	kappctrlv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	packagingv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/packaging/v1alpha1"
+	datapackagingv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apiserver/apis/datapackaging/v1alpha1"
+	corev1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/clientgetter"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynfake "k8s.io/client-go/dynamic/fake"
+	"sigs.k8s.io/yaml"
+)
+
+// fixturesDir contains the CRs exported from a real kapp-controller by the
+// integration tests (see server_integration_test.go).
+const fixturesDir = "testdata/fixtures"
+
+const fixturesHeader = "# CRs of a real kapp-controller used as fixtures by the unit tests, regenerate them with\n# make kapp-controller-plugin-fixtures\n"
+
+// fixtureResources are the resources exported as fixtures.
+var fixtureResources = []schema.GroupVersionResource{
+	packagingv1alpha1.SchemeGroupVersion.WithResource(pkgRepositoriesResource),
+	datapackagingv1alpha1.SchemeGroupVersion.WithResource(pkgMetadatasResource),
+	datapackagingv1alpha1.SchemeGroupVersion.WithResource(pkgsResource),
+	packagingv1alpha1.SchemeGroupVersion.WithResource(pkgInstallsResource),
+	kappctrlv1alpha1.SchemeGroupVersion.WithResource(appsResource),
+}
+
+// fixtureTypedObjects returns, for each resource, the typed object used by the
+// plugin which its fixtures must be decoded into.
+var fixtureTypedObjects = map[string]func() interface{}{
+	pkgRepositoriesResource: func() interface{} { return &packagingv1alpha1.PackageRepository{} },
+	pkgMetadatasResource:    func() interface{} { return &datapackagingv1alpha1.PackageMetadata{} },
+	pkgsResource:            func() interface{} { return &datapackagingv1alpha1.Package{} },
+	pkgInstallsResource:     func() interface{} { return &packagingv1alpha1.PackageInstall{} },
+	appsResource:            func() interface{} { return &kappctrlv1alpha1.App{} },
+}
+
+// loadFixtures returns the objects of the given resource exported as fixtures.
+func loadFixtures(t *testing.T, resource string) []k8sruntime.Object {
+	content, err := os.ReadFile(filepath.Join(fixturesDir, resource+".yaml"))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	var objects []k8sruntime.Object
+	for _, document := range strings.Split(string(content), "\n---\n") {
+		obj := map[string]interface{}{}
+		if err := yaml.Unmarshal([]byte(document), &obj); err != nil {
+			t.Fatalf("unable to parse fixture of %s: %+v", resource, err)
+		}
+		if len(obj) == 0 {
+			continue
+		}
+		objects = append(objects, &unstructured.Unstructured{Object: obj})
+	}
+	return objects
+}
+
+func TestFixtures(t *testing.T) {
+	// Every field of the CRs created by kapp-controller must be known by the types
+	// used by the plugin, otherwise they have drifted from the upstream CRDs.
+	t.Run("the fixtures match the kapp-controller types", func(t *testing.T) {
+		for _, resource := range fixtureResources {
+			objects := loadFixtures(t, resource.Resource)
+			if len(objects) == 0 {
+				t.Errorf("no fixtures found for %s", resource.Resource)
+			}
+			for _, obj := range objects {
+				content, err := yaml.Marshal(obj.(*unstructured.Unstructured).Object)
+				if err != nil {
+					t.Fatalf("%+v", err)
+				}
+				if err := yaml.UnmarshalStrict(content, fixtureTypedObjects[resource.Resource]()); err != nil {
+					t.Errorf("fixture of %s does not match its type: %+v", resource.Resource, err)
+				}
+			}
+		}
+	})
+
+	var objects []k8sruntime.Object
+	for _, resource := range fixtureResources {
+		objects = append(objects, loadFixtures(t, resource.Resource)...)
+	}
+	dynamicClient := dynfake.NewSimpleDynamicClientWithCustomListKinds(
+		k8sruntime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			fixtureResources[0]: pkgRepositoryResource + "List",
+			fixtureResources[1]: pkgMetadataResource + "List",
+			fixtureResources[2]: pkgResource + "List",
+			fixtureResources[3]: pkgInstallResource + "List",
+			fixtureResources[4]: appResource + "List",
+		},
+		objects...,
+	)
+	s := Server{
+		pluginConfig:                    defaultPluginConfig,
+		clientGetter:                    clientgetter.NewBuilder().WithDynamic(dynamicClient).Build(),
+		localServiceAccountClientGetter: clientgetter.NewBuilder().WithDynamic(dynamicClient).BuildFixedCluster(),
+		globalPackagingCluster:          integrationTestsCluster,
+	}
+	fixturesContext := &corev1.Context{Cluster: integrationTestsCluster, Namespace: integrationTestsNamespace}
+
+	t.Run("it returns the package repositories of the fixtures", func(t *testing.T) {
+		response, err := s.GetPackageRepositorySummaries(context.Background(), connect.NewRequest(&corev1.GetPackageRepositorySummariesRequest{
+			Context: fixturesContext,
+		}))
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		for _, summary := range response.Msg.GetPackageRepositorySummaries() {
+			if summary.GetUrl() == "" || summary.GetStatus().GetReason() != corev1.PackageRepositoryStatus_STATUS_REASON_SUCCESS {
+				t.Errorf("unexpected package repository summary: %+v", summary)
+			}
+		}
+		if got, want := len(response.Msg.GetPackageRepositorySummaries()), len(loadFixtures(t, pkgRepositoriesResource)); got != want {
+			t.Errorf("got: %d, want: %d", got, want)
+		}
+	})
+
+	t.Run("it returns the available packages of the fixtures", func(t *testing.T) {
+		response, err := s.GetAvailablePackageSummaries(context.Background(), connect.NewRequest(&corev1.GetAvailablePackageSummariesRequest{
+			Context: fixturesContext,
+		}))
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		for _, summary := range response.Msg.GetAvailablePackageSummaries() {
+			if summary.GetLatestVersion().GetPkgVersion() == "" {
+				t.Errorf("unexpected available package summary: %+v", summary)
+			}
+		}
+		if got, want := len(response.Msg.GetAvailablePackageSummaries()), len(loadFixtures(t, pkgMetadatasResource)); got != want {
+			t.Errorf("got: %d, want: %d", got, want)
+		}
+	})
+}
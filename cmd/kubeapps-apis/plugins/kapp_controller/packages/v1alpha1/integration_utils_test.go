@@ -0,0 +1,146 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/clientgetter"
+	k8scorev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const (
+	// envVarKappIntegrationTests enables tests that run against a local kind cluster
+	// with kapp-controller deployed (see the kapp-controller-plugin-integration make target).
+	envVarKappIntegrationTests = "ENABLE_KAPP_CONTROLLER_INTEGRATION_TESTS"
+	// envVarKappFixturesDir is the directory where the CRs created by the integration
+	// tests are exported as fixtures for the unit tests, if set.
+	envVarKappFixturesDir = "KAPP_CONTROLLER_FIXTURES_DIR"
+	// envVarKappTestRepoURL overrides the imgpkg bundle of the package repository
+	// added by the integration tests.
+	envVarKappTestRepoURL = "KAPP_CONTROLLER_TEST_REPO_URL"
+
+	// defaultKappTestRepoURL is the package repository used by the kapp-controller
+	// examples, which contains a few versions of the simple-app package.
+	defaultKappTestRepoURL = "k8slt/kc-e2e-test-repo"
+
+	integrationTestsNamespace      = "kapp-controller-plugin-integration"
+	integrationTestsServiceAccount = "kapp-controller-plugin-integration"
+	integrationTestsCluster        = "default"
+	integrationTestsTimeout        = 5 * time.Minute
+	integrationTestsPollInterval   = 2 * time.Second
+)
+
+// checkEnv skips the test unless the integration tests are enabled and returns
+// the REST config of the kind cluster (from KUBECONFIG or the default location).
+func checkEnv(t *testing.T) *rest.Config {
+	enableEnvVar := os.Getenv(envVarKappIntegrationTests)
+	runTests := false
+	if enableEnvVar != "" {
+		var err error
+		runTests, err = strconv.ParseBool(enableEnvVar)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+	}
+	if !runTests {
+		t.Skipf("skipping kapp-controller plugin integration tests because environment variable %q not set to be true", envVarKappIntegrationTests)
+	}
+
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	config, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, &clientcmd.ConfigOverrides{}).ClientConfig()
+	if err != nil {
+		t.Fatalf("unable to load the kubeconfig of the kind cluster: %+v", err)
+	}
+	return config
+}
+
+// newIntegrationServer returns a Server, configured as NewServer does, which talks
+// to the kind cluster with the credentials of the kubeconfig, for both the user
+// requests and the requests otherwise done with the kubeapps-apis service account.
+func newIntegrationServer(t *testing.T, config *rest.Config) *Server {
+	configGetter := func(headers http.Header, cluster string) (*rest.Config, error) {
+		return rest.CopyConfig(config), nil
+	}
+	s := NewServer(configGetter, 50, 100, integrationTestsCluster, "")
+	s.localServiceAccountClientGetter = &clientgetter.FixedClusterClientProvider{ClientsFunc: func(ctx context.Context) (*clientgetter.ClientGetter, error) {
+		return s.clientGetter.GetClients(nil, integrationTestsCluster)
+	}}
+	return s
+}
+
+// ensureIntegrationNamespace creates the namespace of the integration tests with a
+// service account allowed to reconcile the installed packages, which is deleted,
+// along with everything created in it, once the test finishes.
+func ensureIntegrationNamespace(t *testing.T, typedClient kubernetes.Interface) {
+	ctx := context.Background()
+	_, err := typedClient.CoreV1().Namespaces().Create(ctx, &k8scorev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: integrationTestsNamespace},
+	}, metav1.CreateOptions{})
+	if err != nil && !k8sErrors.IsAlreadyExists(err) {
+		t.Fatalf("%+v", err)
+	}
+	_, err = typedClient.CoreV1().ServiceAccounts(integrationTestsNamespace).Create(ctx, &k8scorev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{Name: integrationTestsServiceAccount},
+	}, metav1.CreateOptions{})
+	if err != nil && !k8sErrors.IsAlreadyExists(err) {
+		t.Fatalf("%+v", err)
+	}
+	_, err = typedClient.RbacV1().ClusterRoleBindings().Create(ctx, &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: integrationTestsServiceAccount},
+		RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "cluster-admin"},
+		Subjects: []rbacv1.Subject{
+			{Kind: rbacv1.ServiceAccountKind, Name: integrationTestsServiceAccount, Namespace: integrationTestsNamespace},
+		},
+	}, metav1.CreateOptions{})
+	if err != nil && !k8sErrors.IsAlreadyExists(err) {
+		t.Fatalf("%+v", err)
+	}
+
+	t.Cleanup(func() {
+		ctx := context.Background()
+		if err := typedClient.RbacV1().ClusterRoleBindings().Delete(ctx, integrationTestsServiceAccount, metav1.DeleteOptions{}); err != nil && !k8sErrors.IsNotFound(err) {
+			t.Logf("unable to delete cluster role binding: %+v", err)
+		}
+		if err := typedClient.CoreV1().Namespaces().Delete(ctx, integrationTestsNamespace, metav1.DeleteOptions{}); err != nil && !k8sErrors.IsNotFound(err) {
+			t.Logf("unable to delete namespace: %+v", err)
+		}
+	})
+}
+
+// waitUntil polls the condition until it is met or the integration tests timeout
+// is reached, reporting the last error of the condition, if any.
+func waitUntil(t *testing.T, description string, condition func(ctx context.Context) (bool, error)) {
+	var lastErr error
+	err := wait.PollImmediate(integrationTestsPollInterval, integrationTestsTimeout, func() (bool, error) {
+		done, err := condition(context.Background())
+		if err != nil {
+			lastErr = err
+			return false, nil
+		}
+		return done, nil
+	})
+	if err != nil {
+		t.Fatalf("timed out waiting until %s: %v (last error: %v)", description, err, lastErr)
+	}
+}
+
+func integrationTestRepoURL() string {
+	if url := os.Getenv(envVarKappTestRepoURL); url != "" {
+		return url
+	}
+	return defaultKappTestRepoURL
+}
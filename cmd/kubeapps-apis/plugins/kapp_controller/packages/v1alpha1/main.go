@@ -17,7 +17,12 @@ import (
 
 // Set the pluginDetail once during a module init function so the single struct
 // can be used throughout the plugin.
-var pluginDetail pluginsgrpcv1alpha1.Plugin
+var (
+	pluginDetail pluginsgrpcv1alpha1.Plugin
+	// This version var is updated during the build (see the -ldflags option
+	// in the cmd/kubeapps-apis/Dockerfile)
+	version = "devel"
+)
 
 func init() {
 	pluginDetail = pluginsgrpcv1alpha1.Plugin{
@@ -32,8 +37,9 @@ func init() {
 //nolint:deadcode
 func RegisterWithGRPCServer(opts pluginsv1alpha1.GRPCPluginRegistrationOptions) (interface{}, error) {
 	svr := NewServer(opts.ConfigGetter, opts.ClientQPS, opts.ClientBurst, opts.ClustersConfig.KubeappsClusterName, opts.PluginConfigPath)
-	opts.Mux.Handle(packagesConnect.NewKappControllerPackagesServiceHandler(svr))
-	opts.Mux.Handle(packagesConnect.NewKappControllerRepositoriesServiceHandler(svr))
+	opts.PluginConfigWatcher.Register(GetPluginDetail(), svr.config().toPluginConfig(), svr.reloadPluginConfig)
+	opts.Mux.Handle(packagesConnect.NewKappControllerPackagesServiceHandler(svr, opts.HandlerOptions...))
+	opts.Mux.Handle(packagesConnect.NewKappControllerRepositoriesServiceHandler(svr, opts.HandlerOptions...))
 	return svr, nil
 }
 
@@ -49,3 +55,8 @@ func RegisterHTTPHandlerFromEndpoint(ctx context.Context, mux *runtime.ServeMux,
 func GetPluginDetail() *pluginsgrpcv1alpha1.Plugin {
 	return &pluginDetail
 }
+
+// GetPluginBuildVersion returns the version the plugin was built from.
+func GetPluginBuildVersion() string {
+	return version
+}
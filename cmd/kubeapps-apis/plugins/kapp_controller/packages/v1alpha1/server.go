@@ -4,13 +4,17 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"os"
+	"sync"
 
 	"github.com/bufbuild/connect-go"
 	"github.com/cppforlife/go-cli-ui/ui"
+	packagingv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/packaging/v1alpha1"
+	datapackagingv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apiserver/apis/datapackaging/v1alpha1"
 	ctlapp "github.com/vmware-tanzu/carvel-kapp/pkg/kapp/app"
 	kappcmdapp "github.com/vmware-tanzu/carvel-kapp/pkg/kapp/cmd/app"
 	kappcmdcore "github.com/vmware-tanzu/carvel-kapp/pkg/kapp/cmd/core"
@@ -21,6 +25,8 @@ import (
 	corev1connect "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1/v1alpha1connect"
 	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/plugins/kapp_controller/packages/v1alpha1"
 	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/clientgetter"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/k8sutils"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/netrestrict"
 	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/pkgutils"
 	log "k8s.io/klog/v2"
 )
@@ -28,10 +34,17 @@ import (
 type kappClientsGetter func(headers http.Header, cluster, namespace string) (ctlapp.Apps, ctlres.IdentifiedResources, *kappcmdapp.FailingAPIServicesPolicy, ctlres.ResourceFilter, error)
 
 const (
-	fallbackGlobalPackagingNamespace                        = "kapp-controller-packaging-global"
-	fallbackDefaultUpgradePolicy     pkgutils.UpgradePolicy = pkgutils.UpgradePolicyNone
-	fallbackDefaultAllowDowngrades                          = false
-	fallbackTimeoutSeconds                                  = 300
+	fallbackGlobalPackagingNamespace                             = "kapp-controller-packaging-global"
+	fallbackDefaultUpgradePolicy          pkgutils.UpgradePolicy = pkgutils.UpgradePolicyNone
+	fallbackDefaultAllowDowngrades                               = false
+	fallbackTimeoutSeconds                                       = 300
+	fallbackAllowServiceAccountCreation                          = false
+	fallbackServiceAccountRoleTemplate                           = serviceAccountRoleTemplateRestricted
+	fallbackAllowImagePullSecretCreation                         = false
+	fallbackReadmeFromBundle                                     = false
+	fallbackAllowDefaultNamespaceOverride                        = false
+	fallbackReconcileHistoryLimit                                = 10
+	fallbackAdvancedMode                                         = false
 )
 
 func fallbackDefaultPrereleasesVersionSelection() []string {
@@ -58,39 +71,75 @@ type Server struct {
 	// (i.e. code is re-usable by multiple components)
 	kappClientsGetter kappClientsGetter
 	pluginConfig      *kappControllerPluginParsedConfig
+	// pluginConfigMutex guards the pluginConfig, which is replaced when the
+	// plugin configuration file is reloaded.
+	pluginConfigMutex sync.RWMutex
 	clientQPS         float32
+	// pkgListPositions caches where the next page of the available package
+	// summaries starts, to resume the listing with a continue token.
+	pkgListPositions *pkgListPositionCache
+	// bundleReadmes caches the READMEs fetched from the imgpkg bundles of the
+	// packages.
+	bundleReadmes *bundleReadmeCache
+	// pkgRepoRefs caches the package repository of each package metadata of
+	// the global packaging cluster, when watching them is enabled.
+	pkgRepoRefs *pkgRepoRefCache
 }
 
 // parsePluginConfig parses the input plugin configuration json file and return the configuration options.
 func parsePluginConfig(pluginConfigPath string) (*kappControllerPluginParsedConfig, error) {
-	// default configuration
-	config := defaultPluginConfig
+	// default configuration, copied so that the defaults are not overridden
+	config := *defaultPluginConfig
 
 	// load the configuration file and unmarshall the values
 	// #nosec G304
 	pluginConfigFile, err := os.ReadFile(pluginConfigPath)
 	if err != nil {
-		return config, fmt.Errorf("unable to open plugin config at %q: %w", pluginConfigPath, err)
+		return &config, fmt.Errorf("unable to open plugin config at %q: %w", pluginConfigPath, err)
 	}
 	var pluginConfig kappControllerPluginConfig
 	err = json.Unmarshal([]byte(pluginConfigFile), &pluginConfig)
 	if err != nil {
-		return config, fmt.Errorf("unable to unmarshal pluginconfig: %q error: %w", string(pluginConfigFile), err)
+		return &config, fmt.Errorf("unable to unmarshal pluginconfig: %q error: %w", string(pluginConfigFile), err)
 	}
 
 	defaultUpgradePolicy, err := pkgutils.UpgradePolicyFromString(pluginConfig.KappController.Packages.V1alpha1.DefaultUpgradePolicy)
 	if err != nil {
-		return config, err
+		return &config, err
+	}
+	serviceAccountRoleTemplate, err := serviceAccountRoleTemplateFromString(pluginConfig.KappController.Packages.V1alpha1.ServiceAccountRoleTemplate)
+	if err != nil {
+		return &config, err
+	}
+	if err := netrestrict.ValidateNetworks(pluginConfig.KappController.Packages.V1alpha1.RepositoryConnectionAllowedNetworks); err != nil {
+		return &config, fmt.Errorf("invalid repositoryConnectionAllowedNetworks: %w", err)
+	}
+	if imagePullSecretImport := pluginConfig.KappController.Packages.V1alpha1.ImagePullSecretImport; imagePullSecretImport.Name == "" && imagePullSecretImport.Namespace != "" {
+		return &config, fmt.Errorf("invalid imagePullSecretImport: the name of the exported Secret is required")
 	}
 	// override the defaults with the loaded configuration
 	config.timeoutSeconds = pluginConfig.Core.Packages.V1alpha1.TimeoutSeconds
 	config.versionsInSummary = pluginConfig.Core.Packages.V1alpha1.VersionsInSummary
+	config.namespaceCreation = pluginConfig.Core.Packages.V1alpha1.NamespaceCreation
+	config.capacityCheck = pluginConfig.Core.Packages.V1alpha1.CapacityCheck
+	config.installedPackageMetadata = pluginConfig.Core.Packages.V1alpha1.InstalledPackageMetadata
 	config.defaultUpgradePolicy = defaultUpgradePolicy
 	config.defaultPrereleasesVersionSelection = pluginConfig.KappController.Packages.V1alpha1.DefaultPrereleasesVersionSelection
 	config.defaultAllowDowngrades = pluginConfig.KappController.Packages.V1alpha1.DefaultAllowDowngrades
 	config.globalPackagingNamespace = pluginConfig.KappController.Packages.V1alpha1.GlobalPackagingNamespace
+	config.allowServiceAccountCreation = pluginConfig.KappController.Packages.V1alpha1.AllowServiceAccountCreation
+	config.serviceAccountRoleTemplate = serviceAccountRoleTemplate
+	config.allowImagePullSecretCreation = pluginConfig.KappController.Packages.V1alpha1.AllowImagePullSecretCreation
+	config.imagePullSecretImport = pluginConfig.KappController.Packages.V1alpha1.ImagePullSecretImport
+	config.readmeFromBundle = pluginConfig.KappController.Packages.V1alpha1.ReadmeFromBundle
+	config.allowDefaultNamespaceOverride = pluginConfig.KappController.Packages.V1alpha1.AllowDefaultNamespaceOverride
+	config.reconcileHistoryLimit = pluginConfig.KappController.Packages.V1alpha1.ReconcileHistoryLimit
+	config.advancedMode = pluginConfig.KappController.Packages.V1alpha1.AdvancedMode
+	config.excludeGlobalPackagesNamespaces = pluginConfig.KappController.Packages.V1alpha1.ExcludeGlobalPackagesNamespaces
+	config.watchPackageRepositoryRefs = pluginConfig.KappController.Packages.V1alpha1.WatchPackageRepositoryRefs
+	config.repositoryConnectionAllowedNetworks = pluginConfig.KappController.Packages.V1alpha1.RepositoryConnectionAllowedNetworks
 
-	return config, nil
+	return &config, nil
 }
 
 // NewServer returns a Server automatically configured with a function to obtain
@@ -113,13 +162,16 @@ func NewServer(configGetter core.KubernetesConfigGetter, clientQPS float32, clie
 		log.Fatalf("%s", err)
 	}
 
-	return &Server{
+	s := &Server{
 		clientGetter: clientProvider,
 		// Get the "in-cluster" client getter
 		localServiceAccountClientGetter: clientgetter.NewBackgroundClientProvider(clientgetter.Options{}, clientQPS, clientBurst),
 		clientQPS:                       clientQPS,
 		globalPackagingCluster:          globalPackagingCluster,
 		pluginConfig:                    pluginConfig,
+		pkgListPositions:                newPkgListPositionCache(),
+		bundleReadmes:                   newBundleReadmeCache(),
+		pkgRepoRefs:                     newPkgRepoRefCache(),
 		kappClientsGetter: func(headers http.Header, cluster, namespace string) (ctlapp.Apps, ctlres.IdentifiedResources, *kappcmdapp.FailingAPIServicesPolicy, ctlres.ResourceFilter, error) {
 			if configGetter == nil {
 				return ctlapp.Apps{}, ctlres.IdentifiedResources{}, nil, ctlres.ResourceFilter{}, connect.NewError(connect.CodeInternal, fmt.Errorf("The configGetter arg is required"))
@@ -165,6 +217,55 @@ func NewServer(configGetter core.KubernetesConfigGetter, clientQPS float32, clie
 			return supportingNsObjs.Apps, supportingObjs.IdentifiedResources, failingAPIServicesPolicy, resourceFilter, nil
 		},
 	}
+	s.startPkgRepoRefsWatch()
+	return s
+}
+
+// startPkgRepoRefsWatch starts watching the package metadatas and
+// repositories of the global packaging cluster if enabled, which remain
+// watched until the plugin stops.
+func (s *Server) startPkgRepoRefsWatch() {
+	if s.config().watchPackageRepositoryRefs {
+		s.pkgRepoRefs.start(context.Background(), s.localServiceAccountClientGetter.Dynamic)
+	}
+}
+
+// config returns the plugin configuration currently in effect.
+func (s *Server) config() *kappControllerPluginParsedConfig {
+	s.pluginConfigMutex.RLock()
+	defer s.pluginConfigMutex.RUnlock()
+	return s.pluginConfig
+}
+
+// Probe checks that the kapp-controller resources used by the plugin, including
+// the ones of its aggregated API server, are served in the kubeapps cluster.
+func (s *Server) Probe(ctx context.Context) error {
+	typedClient, err := s.localServiceAccountClientGetter.Typed(ctx)
+	if err != nil {
+		return err
+	}
+	return k8sutils.CheckServedResources(typedClient.Discovery(),
+		packagingv1alpha1.SchemeGroupVersion.WithResource(pkgRepositoriesResource),
+		packagingv1alpha1.SchemeGroupVersion.WithResource(pkgInstallsResource),
+		datapackagingv1alpha1.SchemeGroupVersion.WithResource(pkgsResource),
+		datapackagingv1alpha1.SchemeGroupVersion.WithResource(pkgMetadatasResource),
+	)
+}
+
+// reloadPluginConfig parses the plugin configuration file and, if valid,
+// replaces the configuration in effect, which is returned in the format of
+// the configuration file.
+func (s *Server) reloadPluginConfig(pluginConfigPath string) (interface{}, error) {
+	pluginConfig, err := parsePluginConfig(pluginConfigPath)
+	if err != nil {
+		return nil, err
+	}
+	s.pluginConfigMutex.Lock()
+	s.pluginConfig = pluginConfig
+	s.pluginConfigMutex.Unlock()
+	s.startPkgRepoRefsWatch()
+	log.InfoS("+kapp-controller reloaded custom config", "pluginConfig", pluginConfig)
+	return pluginConfig.toPluginConfig(), nil
 }
 
 func (s *Server) MaxWorkers() int {
@@ -6,6 +6,7 @@ package main
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"strings"
 	"time"
 
@@ -13,15 +14,21 @@ import (
 	packagingv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/packaging/v1alpha1"
 	datapackagingv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apiserver/apis/datapackaging/v1alpha1"
 	kappctrlpackageinstall "github.com/vmware-tanzu/carvel-kapp-controller/pkg/packageinstall"
+	ctlres "github.com/vmware-tanzu/carvel-kapp/pkg/kapp/resources"
 	"github.com/vmware-tanzu/carvel-vendir/pkg/vendir/versions"
 	vendirversions "github.com/vmware-tanzu/carvel-vendir/pkg/vendir/versions/v1alpha1"
 	corev1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	kappcorev1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/plugins/kapp_controller/packages/v1alpha1"
 	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/connecterror"
-	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/k8sutils"
 	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/paginate"
 	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/pkgutils"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/resources"
+	"google.golang.org/protobuf/proto"
+	k8scorev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	log "k8s.io/klog/v2"
 )
 
@@ -44,39 +51,64 @@ func (s *Server) GetAvailablePackageSummaries(ctx context.Context, request *conn
 	if cluster == "" {
 		cluster = s.globalPackagingCluster
 	}
-	// fetch all the package metadatas
-	pkgMetadatas, err := s.getPkgMetadatas(ctx, request.Header(), cluster, namespace)
+	// The namespaces configured to only see their own packages exclude the
+	// global ones regardless of the request.
+	filterOptions := request.Msg.GetFilterOptions()
+	if !filterOptions.GetExcludeGlobalPackages() && s.config().excludesGlobalPackages(namespace) {
+		if filterOptions == nil {
+			filterOptions = &corev1.FilterOptions{}
+		} else {
+			filterOptions = proto.Clone(filterOptions).(*corev1.FilterOptions)
+		}
+		filterOptions.ExcludeGlobalPackages = true
+	}
+	// Packages hidden by the policies of their repositories are filtered out
+	// along with the ones not matching the filter options.
+	policies, err := s.getPkgRepositoryPolicies(ctx, request.Header(), cluster, namespace)
 	if err != nil {
+		return nil, connecterror.FromK8sError("list", "PackageRepository", "", err)
+	}
+	// Fetch the page of (filtered) package metadatas, resuming from the
+	// position where the previous page ended when it is known. Otherwise
+	// the listing starts from the beginning, skipping the items offset.
+	positionKey := func(offset int) string {
+		return pkgListPositionKey(cluster, namespace, filterOptions, policies, offset)
+	}
+	start := pkgListPosition{Skip: itemOffset}
+	if pageSize == 0 {
+		start = pkgListPosition{}
+	} else if position, ok := s.pkgListPositions.get(positionKey(itemOffset)); ok {
+		start = position
+	}
+	pkgMetadatas, next, err := s.getPkgMetadatasPage(ctx, request.Header(), cluster, namespace, filterOptions, policies, start, int(pageSize))
+	if errors.IsResourceExpired(err) && start.Continue != "" {
+		// The continue token expired, start again from the beginning.
+		pkgMetadatas, next, err = s.getPkgMetadatasPage(ctx, request.Header(), cluster, namespace, filterOptions, policies, pkgListPosition{Skip: itemOffset}, int(pageSize))
+	}
+	if err == errPkgListPositionOutOfRange {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Invalid pagination arguments %v", request.Msg.GetPaginationOptions()))
+	} else if err != nil {
 		return nil, connecterror.FromK8sError("get", "PackageMetadata", "", err)
 	}
-
-	// Filter the package metadatas using any specified filter.
-	pkgMetadatas = FilterMetadatas(pkgMetadatas, request.Msg.GetFilterOptions())
+	if next != nil {
+		s.pkgListPositions.set(positionKey(itemOffset+len(pkgMetadatas)), *next)
+	}
 
 	availablePackageSummaries := []*corev1.AvailablePackageSummary{}
 	categories := []string{}
 
 	if len(pkgMetadatas) > 0 {
-		// Update the slice to be the correct page of results.
-		startAt := 0
-		if pageSize > 0 {
-			startAt = itemOffset
-			if startAt > len(pkgMetadatas) {
-				return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Invalid pagination arguments %v", request.Msg.GetPaginationOptions()))
-			}
-			pkgMetadatas = pkgMetadatas[startAt:]
-			if len(pkgMetadatas) > int(pageSize) {
-				pkgMetadatas = pkgMetadatas[:pageSize]
-			}
-		}
-
 		// Create a channel to receive all packages available in the namespace.
 		// Using a buffered channel so that we don't block the network request if we
 		// can't process fast enough.
+		// The packages after the ones for this page are not consumed, so the
+		// listing is cancelled when returning.
 		getPkgsChannel := make(chan *datapackagingv1alpha1.Package, PACKAGES_CHANNEL_BUFFER_SIZE)
+		getPkgsCtx, cancelGetPkgs := context.WithCancel(ctx)
+		defer cancelGetPkgs()
 		var getPkgsError error
 		go func() {
-			getPkgsError = s.getPkgs(ctx, request.Header(), cluster, namespace, getPkgsChannel)
+			getPkgsError = s.getPkgs(getPkgsCtx, request.Header(), cluster, namespace, getPkgsChannel)
 		}()
 
 		// Skip through the packages until we get to the first item in our
@@ -136,9 +168,9 @@ func (s *Server) GetAvailablePackageSummaries(ctx context.Context, request *conn
 	}
 
 	// Only return a next page token if the request was for pagination and
-	// the results are a full page.
+	// there are more package metadatas after this page.
 	nextPageToken := ""
-	if pageSize > 0 && len(availablePackageSummaries) == int(pageSize) {
+	if pageSize > 0 && next != nil {
 		nextPageToken = fmt.Sprintf("%d", itemOffset+int(pageSize))
 	}
 	response := &corev1.GetAvailablePackageSummariesResponse{
@@ -146,9 +178,25 @@ func (s *Server) GetAvailablePackageSummaries(ctx context.Context, request *conn
 		Categories:                categories,
 		NextPageToken:             nextPageToken,
 	}
+	// The total size is only known once the last package metadata is listed,
+	// as counting them would require listing every chunk.
+	if next == nil {
+		totalSize := len(pkgMetadatas)
+		if pageSize > 0 {
+			totalSize += itemOffset
+		}
+		response.TotalSize = proto.Int32(int32(totalSize))
+	}
 	return connect.NewResponse(response), nil
 }
 
+// GetAvailablePackageTextAsset returns a range of a text asset of a package managed by the 'kapp_controller' plugin
+func (s *Server) GetAvailablePackageTextAsset(ctx context.Context, request *connect.Request[corev1.GetAvailablePackageTextAssetRequest]) (*connect.Response[corev1.GetAvailablePackageTextAssetResponse], error) {
+	log.InfoS("+kapp-controller GetAvailablePackageTextAsset", "cluster", request.Msg.GetAvailablePackageRef().GetContext().GetCluster(), "namespace", request.Msg.GetAvailablePackageRef().GetContext().GetNamespace(), "id", request.Msg.GetAvailablePackageRef().GetIdentifier(), "asset", request.Msg.GetAsset())
+
+	return pkgutils.GetAvailablePackageTextAsset(ctx, request, s.GetAvailablePackageDetail)
+}
+
 // GetAvailablePackageVersions returns the package versions managed by the 'kapp_controller' plugin
 func (s *Server) GetAvailablePackageVersions(ctx context.Context, request *connect.Request[corev1.GetAvailablePackageVersionsRequest]) (*connect.Response[corev1.GetAvailablePackageVersionsResponse], error) {
 	// Retrieve parameters from the request
@@ -182,24 +230,112 @@ func (s *Server) GetAvailablePackageVersions(ctx context.Context, request *conne
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to get the PkgVersionsMap: '%w'", err))
 
 	}
+	pkgVersions := pkgVersionsMap[pkgName]
+	if constraint := request.Msg.GetConstraint(); constraint != "" {
+		pkgVersions, err = versionsMatchingConstraint(pkgVersions, constraint)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Unable to filter the package versions: %w", err))
+		}
+	}
 
 	// TODO(minelson): support configurable version summary for kapp-controller pkgs
 	// as already done for Helm (see #3588 for more info).
-	versions := make([]*corev1.PackageAppVersion, len(pkgVersionsMap[pkgName]))
-	for i, v := range pkgVersionsMap[pkgName] {
+	versions := make([]*corev1.PackageAppVersion, len(pkgVersions))
+	now := time.Now()
+	for i, v := range pkgVersions {
 		// Currently, PkgVersion and AppVersion are the same
 		// https://kubernetes.slack.com/archives/CH8KCCKA5/p1636386358322000?thread_ts=1636371493.320900&cid=CH8KCCKA5
 		versions[i] = &corev1.PackageAppVersion{
-			PkgVersion: v.version.String(),
-			AppVersion: v.version.String(),
+			PkgVersion:  v.version.String(),
+			AppVersion:  v.version.String(),
+			Deprecation: pkgVersionDeprecation(v.pkg, now),
 		}
 	}
+	if err := pkgutils.SetUpgradeImpacts(versions, changelogsFromPkgs(pkgs), request.Msg.GetRelativeToVersion()); err != nil {
+		return nil, err
+	}
 
 	return connect.NewResponse(&corev1.GetAvailablePackageVersionsResponse{
 		PackageAppVersions: versions,
 	}), nil
 }
 
+// GetAvailablePackageChangelog returns the release notes between two versions of a package managed by the 'kapp_controller' plugin
+func (s *Server) GetAvailablePackageChangelog(ctx context.Context, request *connect.Request[corev1.GetAvailablePackageChangelogRequest]) (*connect.Response[corev1.GetAvailablePackageChangelogResponse], error) {
+	// Retrieve parameters from the request
+	namespace := request.Msg.GetAvailablePackageRef().GetContext().GetNamespace()
+	cluster := request.Msg.GetAvailablePackageRef().GetContext().GetCluster()
+	identifier := request.Msg.GetAvailablePackageRef().GetIdentifier()
+	log.InfoS("+kapp-controller GetAvailablePackageChangelog", "cluster", cluster, "namespace", namespace, "id", identifier, "from", request.Msg.GetFromVersion(), "to", request.Msg.GetToVersion())
+
+	// Validate the request
+	if namespace == "" || identifier == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Required context or identifier not provided"))
+	}
+
+	if cluster == "" {
+		cluster = s.globalPackagingCluster
+	}
+
+	_, pkgName, err := pkgutils.SplitPackageIdentifier(identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	// Each Package CR is a version of the package, with its own release notes.
+	fieldSelector := fmt.Sprintf("spec.refName=%s", pkgName)
+	pkgs, err := s.getPkgsWithFieldSelector(ctx, request.Header(), cluster, namespace, fieldSelector)
+	if err != nil {
+		return nil, connecterror.FromK8sError("get", "Package", "", err)
+	}
+	if len(pkgs) == 0 {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("Unable to find any Package for '%s'", pkgName))
+	}
+	changelogs, err := pkgutils.FilterChangelogs(changelogsFromPkgs(pkgs), request.Msg.GetFromVersion(), request.Msg.GetToVersion())
+	if err != nil {
+		return nil, err
+	}
+
+	return connect.NewResponse(&corev1.GetAvailablePackageChangelogResponse{
+		Changelogs: changelogs,
+	}), nil
+}
+
+// GetAvailablePackageFilters returns the distinct categories and providers of the package metadatas managed by the 'kapp_controller' plugin
+func (s *Server) GetAvailablePackageFilters(ctx context.Context, request *connect.Request[corev1.GetAvailablePackageFiltersRequest]) (*connect.Response[corev1.GetAvailablePackageFiltersResponse], error) {
+	// Retrieve parameters from the request
+	namespace := request.Msg.GetContext().GetNamespace()
+	cluster := request.Msg.GetContext().GetCluster()
+	log.InfoS("+kapp-controller GetAvailablePackageFilters", "cluster", cluster, "namespace", namespace)
+
+	// Assume the default cluster if none is specified
+	if cluster == "" {
+		cluster = s.globalPackagingCluster
+	}
+
+	pkgMetadatas, err := s.getPkgMetadatas(ctx, request.Header(), cluster, namespace)
+	if err != nil {
+		return nil, connecterror.FromK8sError("get", "PackageMetadata", "", err)
+	}
+	if s.config().excludesGlobalPackages(namespace) {
+		pkgMetadatas = FilterGlobalMetadatas(pkgMetadatas, s.config().globalPackagingNamespace)
+	}
+
+	categories := []string{}
+	providers := []string{}
+	for _, pkgMetadata := range pkgMetadatas {
+		categories = append(categories, pkgMetadata.Spec.Categories...)
+		if pkgMetadata.Spec.ProviderName != "" {
+			providers = append(providers, pkgMetadata.Spec.ProviderName)
+		}
+	}
+
+	return connect.NewResponse(&corev1.GetAvailablePackageFiltersResponse{
+		Categories: pkgutils.SortedDistinct(categories),
+		Providers:  pkgutils.SortedDistinct(providers),
+	}), nil
+}
+
 // GetAvailablePackageDetail returns the package metadata managed by the 'kapp_controller' plugin
 func (s *Server) GetAvailablePackageDetail(ctx context.Context, request *connect.Request[corev1.GetAvailablePackageDetailRequest]) (*connect.Response[corev1.GetAvailablePackageDetailResponse], error) {
 	// Retrieve parameters from the request
@@ -271,6 +407,15 @@ func (s *Server) GetAvailablePackageDetail(ctx context.Context, request *connect
 
 	}
 
+	// Replace the synthesized readme with the one of the package bundle, if any.
+	if s.config().readmeFromBundle {
+		if readme, err := s.getBundleReadme(ctx, request.Header(), cluster, foundPkgSemver.pkg); err != nil {
+			log.Warningf("+kapp-controller unable to get the readme of the bundle of %q: %v", pkgName, err)
+		} else if readme != "" {
+			availablePackageDetail.Readme = readme
+		}
+	}
+
 	return connect.NewResponse(&corev1.GetAvailablePackageDetailResponse{
 		AvailablePackageDetail: availablePackageDetail,
 	}), nil
@@ -295,10 +440,29 @@ func (s *Server) GetInstalledPackageSummaries(ctx context.Context, request *conn
 		cluster = s.globalPackagingCluster
 	}
 
+	// The label selector is passed to the API server, whereas the status and
+	// pending upgrade filters are applied to the summaries once built, which
+	// then need to be paginated afterwards.
+	filterOptions := request.Msg.GetFilterOptions()
+	labelSelector, err := pkgutils.InstalledPackageLabelSelector(filterOptions)
+	if err != nil {
+		return nil, err
+	}
+	postFilter := pkgutils.HasInstalledPackageSummaryFilters(filterOptions)
+
 	// retrieve the paginated list of installed packages
 	// TODO(agamez): we should be paginating this request rather than requesting everything every time
-	pkgInstalls, err := s.getPkgInstalls(ctx, request.Header(), cluster, namespace)
-	if err != nil {
+	pkgInstalls, err := s.getPkgInstalls(ctx, request.Header(), cluster, namespace, labelSelector.String())
+	if errors.IsForbidden(err) && namespace == "" {
+		// Catch forbidden errors in cluster-wide listings, listing the installed
+		// packages in each of the namespaces the user has access to instead
+		log.Warningf("+kapp-controller unable to list package installs at the cluster scope in '%s' due to [%v]", cluster, err)
+		namespaces, err := s.getAccessibleNamespaces(request.Header(), cluster)
+		if err != nil {
+			return nil, err
+		}
+		return pkgutils.GetInstalledPackageSummariesPerNamespace(ctx, request, cluster, namespaces, GetPluginDetail(), s.GetInstalledPackageSummaries)
+	} else if err != nil {
 		return nil, connecterror.FromK8sError("get", "PackageInstall", "", err)
 	}
 
@@ -308,7 +472,7 @@ func (s *Server) GetInstalledPackageSummaries(ctx context.Context, request *conn
 	if len(pkgInstalls) > 0 {
 		//nolint:ineffassign
 		startAt := -1
-		if pageSize > 0 {
+		if pageSize > 0 && !postFilter {
 			startAt = itemOffset
 			if startAt > len(pkgInstalls) {
 				return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Invalid pagination arguments %v", request.Msg.GetPaginationOptions()))
@@ -344,7 +508,7 @@ func (s *Server) GetInstalledPackageSummaries(ctx context.Context, request *conn
 			}
 			// As each package install could potentially be from a pkg in the same
 			// namespace or a package in the global namespace, we track both.
-			for _, ns := range []string{pkgInstall.Namespace, s.pluginConfig.globalPackagingNamespace} {
+			for _, ns := range []string{pkgInstall.Namespace, s.config().globalPackagingNamespace} {
 				pkgData, ok := pkgDataForNamespaces[ns]
 				if !ok {
 					pkgData = &pkgMetaAndVersionsData{
@@ -425,7 +589,7 @@ func (s *Server) GetInstalledPackageSummaries(ctx context.Context, request *conn
 			pkgData := pkgDataForNamespaces[pkgi.Namespace]
 			var ok bool
 			if pkgData.meta == nil {
-				pkgData, ok = pkgDataForNamespaces[s.pluginConfig.globalPackagingNamespace]
+				pkgData, ok = pkgDataForNamespaces[s.config().globalPackagingNamespace]
 				// Ignore packages which do not have associated metadata
 				// available. See https://github.com/vmware-tanzu/kubeapps/issues/4901
 				if !ok || pkgData.meta == nil {
@@ -444,6 +608,11 @@ func (s *Server) GetInstalledPackageSummaries(ctx context.Context, request *conn
 		}
 	}
 
+	if postFilter {
+		installedPkgSummaries = pkgutils.FilterInstalledPackageSummaries(installedPkgSummaries, filterOptions)
+		installedPkgSummaries = pkgutils.PaginateInstalledPackageSummaries(installedPkgSummaries, itemOffset, pageSize)
+	}
+
 	// Only return a next page token if the request was for pagination and
 	// the results are a full page.
 	nextPageToken := ""
@@ -469,21 +638,21 @@ func (s *Server) GetInstalledPackageDetail(ctx context.Context, request *connect
 		cluster = s.globalPackagingCluster
 	}
 
-	typedClient, err := s.clientGetter.Typed(request.Header(), cluster)
-	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to get the k8s client: '%w'", err))
-	}
-
 	// fetch the package install
 	pkgInstall, err := s.getPkgInstall(ctx, request.Header(), cluster, namespace, installedPackageRefId)
 	if err != nil {
 		return nil, connecterror.FromK8sError("get", "PackageInstall", installedPackageRefId, err)
 	}
 
-	// fetch the resulting deployed app after the installation
+	// fetch the resulting deployed app after the installation, if already created, cleaning up
+	// the package install if kapp-controller did not create it within the timeout
 	app, err := s.getApp(ctx, request.Header(), cluster, namespace, installedPackageRefId)
 	if err != nil {
-		return nil, connecterror.FromK8sError("get", "App", installedPackageRefId, err)
+		if !errors.IsNotFound(err) {
+			return nil, connecterror.FromK8sError("get", "App", installedPackageRefId, err)
+		}
+		app = nil
+		s.cleanUpIfAppTimedOut(ctx, request.Header(), cluster, pkgInstall)
 	}
 
 	// retrieve the package metadata associated with this installed package
@@ -505,31 +674,47 @@ func (s *Server) GetInstalledPackageDetail(ctx context.Context, request *connect
 	}
 
 	// get the values applies i) get the secret name where it is stored; 2) get the values from the secret
+	installedValuesEntries, err := s.getInstalledValuesEntries(ctx, request.Header(), cluster, pkgInstall)
+	if err != nil {
+		return nil, err
+	}
 	valuesApplied := ""
-	// retrieve every value and build a single string containing all of them
-	for _, pkgInstallValue := range pkgInstall.Spec.Values {
-		secretRefName := pkgInstallValue.SecretRef.Name
-		// if there is a secret containing the applied values of this installed package, get the them
-		if secretRefName != "" {
-			values, err := typedClient.CoreV1().Secrets(namespace).Get(ctx, secretRefName, metav1.GetOptions{})
-			if err != nil {
-				if errors.IsNotFound(err) {
-					log.Warningf("The referenced secret does not exist: %s", connecterror.FromK8sError("get", "Secret", secretRefName, err).Error())
-				} else {
-					return nil, connecterror.FromK8sError("get", "Secret", secretRefName, err)
-				}
-			}
-			if values != nil {
-				for fileName, valuesContent := range values.Data {
-					valuesApplied = fmt.Sprintf("%s\n# %s\n%s\n---", valuesApplied, fileName, valuesContent)
+	valuesEntries := []*kappcorev1.PackageInstallValues{}
+	var valuesSecretKeys []string
+	// retrieve every value and build a single string containing all of them,
+	// the additional keys of the values Secret of the request, which may hold
+	// binary content, being only returned by name
+	for _, entry := range installedValuesEntries {
+		valuesKey := entry.ref.SecretRef.Key
+		isRequestValues := entry.ref.SecretRef.Name == valuesSecretName(pkgInstall.Name, pkgInstall.Namespace)
+		if isRequestValues {
+			valuesKey = valuesSecretKey
+			valuesSecretKeys = secretKeys(entry.current)
+		}
+		if entry.current != nil {
+			for fileName, valuesContent := range entry.current.Data {
+				if isRequestValues && fileName != valuesSecretKey {
+					continue
 				}
+				valuesApplied = fmt.Sprintf("%s\n# %s\n%s\n---", valuesApplied, fileName, valuesContent)
 			}
 		}
+		valuesEntries = append(valuesEntries, &kappcorev1.PackageInstallValues{
+			Layer:      entry.layer,
+			Values:     valuesFromSecret(entry.current, valuesKey),
+			SecretName: entry.ref.SecretRef.Name,
+			SecretKey:  entry.ref.SecretRef.Key,
+			Managed:    entry.managed,
+		})
 	}
 	// trim the new doc separator in the last element
 	valuesApplied = strings.TrimSuffix(valuesApplied, "---")
 
-	installedPackageDetail, err := s.buildInstalledPackageDetail(pkgInstall, pkgMetadata, pkgVersionsMap, app, valuesApplied, cluster)
+	if _, err := s.recordReconcileOutcome(ctx, request.Header(), cluster, pkgInstall, app); err != nil {
+		log.V(4).Infof("Unable to get the reconcile history of the PackageInstall %s/%s: %v", namespace, installedPackageRefId, err)
+	}
+
+	installedPackageDetail, err := s.buildInstalledPackageDetail(pkgInstall, pkgMetadata, pkgVersionsMap, app, valuesApplied, valuesEntries, valuesSecretKeys, cluster)
 	if err != nil {
 		return nil, connecterror.FromK8sError("create", "InstalledPackageDetail", pkgInstall.Name, err)
 
@@ -541,6 +726,36 @@ func (s *Server) GetInstalledPackageDetail(ctx context.Context, request *connect
 	return connect.NewResponse(response), nil
 }
 
+// CheckInstalledPackageNameAvailability checks whether a package install with the name exists in the target namespace
+func (s *Server) CheckInstalledPackageNameAvailability(ctx context.Context, request *connect.Request[corev1.CheckInstalledPackageNameAvailabilityRequest]) (*connect.Response[corev1.CheckInstalledPackageNameAvailabilityResponse], error) {
+	// Retrieve parameters from the request
+	cluster := request.Msg.GetTargetContext().GetCluster()
+	namespace := request.Msg.GetTargetContext().GetNamespace()
+	name := request.Msg.GetName()
+	log.InfoS("+kapp-controller CheckInstalledPackageNameAvailability", "cluster", cluster, "namespace", namespace, "name", name)
+
+	if cluster == "" {
+		cluster = s.globalPackagingCluster
+	}
+
+	conflicts := []*corev1.InstalledPackageReference{}
+	_, err := s.getPkgInstall(ctx, request.Header(), cluster, namespace, name)
+	if err == nil {
+		conflicts = append(conflicts, &corev1.InstalledPackageReference{
+			Context:    &corev1.Context{Cluster: cluster, Namespace: namespace},
+			Identifier: name,
+			Plugin:     GetPluginDetail(),
+		})
+	} else if !errors.IsNotFound(err) {
+		return nil, connecterror.FromK8sError("get", "PackageInstall", name, err)
+	}
+
+	return connect.NewResponse(&corev1.CheckInstalledPackageNameAvailabilityResponse{
+		Available: len(conflicts) == 0,
+		Conflicts: conflicts,
+	}), nil
+}
+
 // CreateInstalledPackage creates an installed package managed by the 'kapp_controller' plugin
 func (s *Server) CreateInstalledPackage(ctx context.Context, request *connect.Request[corev1.CreateInstalledPackageRequest]) (*connect.Response[corev1.CreateInstalledPackageResponse], error) {
 	// Retrieve parameters from the request
@@ -563,9 +778,44 @@ func (s *Server) CreateInstalledPackage(ctx context.Context, request *connect.Re
 	if request.Msg.GetTargetContext() == nil || request.Msg.GetTargetContext().GetNamespace() == "" {
 		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("No request TargetContext namespace provided"))
 	}
-	if request.Msg.GetReconciliationOptions() == nil || request.Msg.GetReconciliationOptions().GetServiceAccountName() == "" {
+
+	// Retrieve the plugin-specific details, if any
+	customDetail := &kappcorev1.KappControllerInstalledPackageCustomDetail{}
+	if request.Msg.GetCustomDetail() != nil {
+		if err := request.Msg.GetCustomDetail().UnmarshalTo(customDetail); err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("custom details are invalid: %v", err))
+		}
+	}
+	createServiceAccount := customDetail.GetCreateServiceAccount()
+	if createServiceAccount {
+		if !s.config().allowServiceAccountCreation {
+			return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("The creation of service accounts is not allowed by the plugin configuration"))
+		}
+		if request.Msg.GetReconciliationOptions().GetServiceAccountName() != "" {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("A ReconciliationOptions serviceAccountName cannot be provided when requesting its creation"))
+		}
+	} else if request.Msg.GetReconciliationOptions() == nil || request.Msg.GetReconciliationOptions().GetServiceAccountName() == "" {
 		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("No request ReconciliationOptions serviceAccountName provided"))
 	}
+	createImagePullSecret := customDetail.GetCreateImagePullSecret()
+	if createImagePullSecret && !s.config().allowImagePullSecretCreation {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("The creation of image pull secrets is not allowed by the plugin configuration"))
+	}
+	if err := checkDefaultNamespace(customDetail.GetDefaultNamespace(), targetNamespace, s.config().allowDefaultNamespaceOverride); err != nil {
+		return nil, err
+	}
+	valuesEntries, err := s.buildPkgInstallValuesEntries(installedPackageName, targetNamespace, customDetail.GetValues())
+	if err != nil {
+		return nil, err
+	}
+	valuesData, err := valuesSecretData(customDetail)
+	if err != nil {
+		return nil, err
+	}
+	metadata := request.Msg.GetMetadata()
+	if err := resources.ValidateInstalledPackageMetadata(metadata.GetLabels(), metadata.GetAnnotations(), s.installedPackageMetadataOptions()); err != nil {
+		return nil, err
+	}
 
 	// Retrieve additional parameters from the request
 	identifier := request.Msg.GetAvailablePackageRef().GetIdentifier()
@@ -599,58 +849,232 @@ func (s *Server) CreateInstalledPackage(ctx context.Context, request *connect.Re
 		return nil, connecterror.FromK8sError("get", "PackageMetadata", pkgName, err)
 	}
 
-	// build a new secret object with the values
-	secret, err := s.buildSecret(installedPackageName, values, targetNamespace)
+	// check the package is made available by the policy of its repository
+	policies, err := s.getPkgRepositoryPolicies(ctx, request.Header(), packageCluster, packageNamespace)
+	if err != nil {
+		return nil, connecterror.FromK8sError("list", "PackageRepository", "", err)
+	}
+	if !policies.allows(pkgMetadata) {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("The package %q is not allowed by the policy of its repository", pkgName))
+	}
+
+	// check the capacity required by the package, if described, against the
+	// quota left in the target namespace
+	var warnings []string
+	if s.config().capacityCheck != resources.CapacityCheckOff && s.config().capacityCheck != "" {
+		capacityRequirements, err := s.getPkgCapacityRequirements(ctx, request.Header(), packageCluster, packageNamespace, pkgMetadata.Name, pkgVersion)
+		if err != nil {
+			return nil, err
+		}
+		warnings, err = resources.CheckNamespaceCapacity(ctx, typedClient, targetNamespace, capacityRequirements, s.config().capacityCheck)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// adopt the existing PackageInstall with the requested name, if requested
+	if customDetail.GetAdoptExisting() {
+		existingPkgInstall, err := s.getPkgInstall(ctx, request.Header(), targetCluster, targetNamespace, installedPackageName)
+		if err != nil && !errors.IsNotFound(err) {
+			return nil, connecterror.FromK8sError("get", "PackageInstall", installedPackageName, err)
+		}
+		if err == nil {
+			adoptedPkgInstall, err := s.adoptPkgInstall(ctx, request.Header(), targetCluster, request.Msg, customDetail, existingPkgInstall, pkgMetadata.Name)
+			if err != nil {
+				return nil, err
+			}
+			return connect.NewResponse(&corev1.CreateInstalledPackageResponse{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context: &corev1.Context{
+						Namespace: adoptedPkgInstall.GetNamespace(),
+						Cluster:   targetCluster,
+					},
+					Identifier: adoptedPkgInstall.Name,
+					Plugin:     GetPluginDetail(),
+				},
+			}), nil
+		}
+	}
+
+	// build a new secret object with the values, merged after any other values entry
+	secret, err := s.buildSecret(installedPackageName, values, targetNamespace, valuesData)
 	if err != nil {
 		return nil, connecterror.FromK8sError("create", "Secret", installedPackageName, err)
 	}
+	valuesEntries = append(valuesEntries, pkgInstallValuesEntry{
+		layer: kappcorev1.PackageInstallValuesLayer_PACKAGE_INSTALL_VALUES_LAYER_USER_OVERRIDES,
+		ref: packagingv1alpha1.PackageInstallValues{
+			SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{Name: secret.Name},
+		},
+		secret: secret,
+	})
 
 	// build a new pkgInstall object
-	newPkgInstall, err := s.buildPkgInstall(installedPackageName, targetCluster, targetNamespace, pkgMetadata.Name, pkgVersion, reconciliationOptions, secret)
+	allowDowngrades := s.config().defaultAllowDowngrades
+	if customDetail.AllowDowngrades != nil {
+		allowDowngrades = customDetail.GetAllowDowngrades()
+	}
+	newPkgInstall, err := s.buildPkgInstall(installedPackageName, targetCluster, targetNamespace, pkgMetadata.Name, pkgVersion, reconciliationOptions, allowDowngrades, valuesEntries)
 	if err != nil {
 		return nil, connect.NewError(connect.CodeOf(err), fmt.Errorf("Unable to create the PackageInstall '%s' due to '%w'", installedPackageName, err))
 	}
+	if metadata != nil {
+		setPkgInstallMetadata(newPkgInstall, metadata, s.installedPackageMetadataOptions())
+	}
+	newPkgInstall.Spec.DefaultNamespace = customDetail.GetDefaultNamespace()
+
+	// build the ServiceAccount, Role and RoleBinding, if requested, to be used by the pkgInstall
+	serviceAccount, role, roleBinding := s.buildServiceAccountResources(installedPackageName, targetNamespace)
+	if createServiceAccount {
+		newPkgInstall.Spec.ServiceAccountName = serviceAccount.Name
+	} else {
+		serviceAccount, role, roleBinding = nil, nil, nil
+	}
+
+	// build the image pull Secret, only created if requested: either the SecretImport of the
+	// registry credentials configured by the administrator or a placeholder Secret
+	imagePullSecret := s.buildImagePullSecret(installedPackageName, targetNamespace)
+	imagePullSecretImport := s.buildImagePullSecretImport(targetNamespace)
+	if createImagePullSecret {
+		imagePullSecretName := imagePullSecret.Name
+		if imagePullSecretImport != nil {
+			imagePullSecretName = imagePullSecretImport.GetName()
+		}
+		newPkgInstall.Annotations[annotationImagePullSecretNameKey] = imagePullSecretName
+	}
 
-	// create the Secret in the cluster
+	// create the target namespace, if requested, before any resource in it
+	namespaceCreated := false
+	if request.Msg.GetCreateNamespace() {
+		namespaceCreated, err = resources.EnsureNamespace(ctx, typedClient, targetNamespace, s.config().namespaceCreation)
+		if err != nil {
+			return nil, err
+		}
+	}
+	// delete the created namespace, along with the resources created in it,
+	// if the PackageInstall cannot be created
+	pkgInstallCreated := false
+	defer func() {
+		if namespaceCreated && !pkgInstallCreated {
+			resources.DeleteCreatedNamespace(ctx, typedClient, targetNamespace)
+		}
+	}()
+
+	// create the values Secrets in the cluster
 	// TODO(agamez): check when is the best moment to create this object.
 	// See if we can delay the creation until the PackageInstall is successfully created.
-	createdSecret, err := typedClient.CoreV1().Secrets(targetNamespace).Create(ctx, secret, metav1.CreateOptions{})
-	if createdSecret == nil || err != nil {
-		return nil, connecterror.FromK8sError("create", "Secret", secret.Name, err)
+	if err := s.createValuesSecrets(ctx, request.Header(), targetCluster, targetNamespace, valuesEntries); err != nil {
+		return nil, err
+	}
+
+	// create the ServiceAccount resources in the cluster
+	if createServiceAccount {
+		err = s.createServiceAccountResources(ctx, request.Header(), targetCluster, serviceAccount, role, roleBinding)
+		if err != nil {
+			// clean-up the values secrets if something fails
+			if err := s.deleteValuesSecrets(ctx, request.Header(), targetCluster, targetNamespace, valuesEntries); err != nil {
+				return nil, err
+			}
+			return nil, err
+		}
+	}
+
+	// create the image pull Secret in the cluster, the SecretImport being only deleted on
+	// failure if it did not exist yet
+	var createdImagePullSecret *k8scorev1.Secret
+	var createdImagePullSecretImport *unstructured.Unstructured
+	if createImagePullSecret {
+		if imagePullSecretImport != nil {
+			createdImagePullSecretImport, err = s.createImagePullSecretImport(ctx, request.Header(), targetCluster, imagePullSecretImport)
+		} else {
+			createdImagePullSecret, err = typedClient.CoreV1().Secrets(targetNamespace).Create(ctx, imagePullSecret, metav1.CreateOptions{})
+			if err != nil {
+				err = connecterror.FromK8sError("create", "Secret", imagePullSecret.Name, err)
+			}
+		}
+		if err != nil {
+			// clean-up the values secrets and the ServiceAccount resources if something fails
+			if err := s.deleteValuesSecrets(ctx, request.Header(), targetCluster, targetNamespace, valuesEntries); err != nil {
+				return nil, err
+			}
+			if createServiceAccount {
+				if err := s.deleteServiceAccountResources(ctx, request.Header(), targetCluster, serviceAccount, role, roleBinding); err != nil {
+					return nil, err
+				}
+			}
+			return nil, err
+		}
 	}
 
 	// create the PackageInstall in the cluster
 	createdPkgInstall, err := s.createPkgInstall(ctx, request.Header(), targetCluster, targetNamespace, newPkgInstall)
 	if err != nil {
-		// clean-up the secret if something fails
-		err := typedClient.CoreV1().Secrets(targetNamespace).Delete(ctx, secret.Name, metav1.DeleteOptions{})
-		if err != nil {
-			return nil, connecterror.FromK8sError("delete", "Secret", secret.Name, err)
+		// clean-up the values secrets if something fails
+		if err := s.deleteValuesSecrets(ctx, request.Header(), targetCluster, targetNamespace, valuesEntries); err != nil {
+			return nil, err
+		}
+		// clean-up the ServiceAccount resources if something fails
+		if createServiceAccount {
+			if err := s.deleteServiceAccountResources(ctx, request.Header(), targetCluster, serviceAccount, role, roleBinding); err != nil {
+				return nil, err
+			}
+		}
+		// clean-up the image pull Secret if something fails
+		if createdImagePullSecret != nil {
+			if err := s.deleteSecret(ctx, request.Header(), targetCluster, targetNamespace, createdImagePullSecret.Name); err != nil && !errors.IsNotFound(err) {
+				return nil, connecterror.FromK8sError("delete", "Secret", createdImagePullSecret.Name, err)
+			}
+		}
+		if createdImagePullSecretImport != nil {
+			if err := s.deleteSecretImport(ctx, request.Header(), targetCluster, targetNamespace, createdImagePullSecretImport.GetName()); err != nil {
+				return nil, err
+			}
 		}
 		return nil, connecterror.FromK8sError("create", "PackageInstall", newPkgInstall.Name, err)
 	}
+	pkgInstallCreated = true
 
-	resource, err := s.getAppResource(request.Header(), targetCluster, targetNamespace)
-	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to get the App resource: '%w'", err))
+	// clean-up the PackageInstall and the resources created for it if their owner cannot be set
+	deleteCreated := func() error {
+		err := s.deleteCreatedPkgInstall(ctx, request.Header(), targetCluster, targetNamespace, newPkgInstall.Name, valuesEntries, serviceAccount, role, roleBinding, createdImagePullSecret, createdImagePullSecretImport)
+		if err == nil {
+			pkgInstallCreated = false
+		}
+		return err
 	}
-	// The InstalledPackage is considered as created once the associated kapp App gets created,
-	// so we actively wait for the App CR to be present in the cluster before returning OK
-	err = k8sutils.WaitForResource(ctx, resource, newPkgInstall.Name, time.Second*1, time.Second*time.Duration(s.pluginConfig.timeoutSeconds))
-	if err != nil {
-		// clean-up the secret if something fails
-		err := typedClient.CoreV1().Secrets(targetNamespace).Delete(ctx, secret.Name, metav1.DeleteOptions{})
+
+	// the ServiceAccount resources are owned by the PackageInstall, so that they are
+	// garbage collected once kapp-controller has finished deleting the installed resources
+	if createServiceAccount {
+		err = s.setServiceAccountResourcesOwner(ctx, request.Header(), targetCluster, createdPkgInstall, serviceAccount, role, roleBinding)
 		if err != nil {
-			return nil, connecterror.FromK8sError("delete", "Secret", secret.Name, err)
+			if err := deleteCreated(); err != nil {
+				return nil, err
+			}
+			return nil, err
+		}
+	}
+	// likewise, the image pull Secret is owned by the PackageInstall, as the installed workloads
+	// may need it, while the shared SecretImport is owned by every PackageInstall using it
+	if createImagePullSecret {
+		if imagePullSecretImport != nil {
+			err = s.addSecretImportOwner(ctx, request.Header(), targetCluster, createdPkgInstall, imagePullSecretImport.GetName())
+		} else {
+			err = s.setSecretOwner(ctx, request.Header(), targetCluster, createdPkgInstall, createdImagePullSecret)
 		}
-		// clean-up the package install if something fails
-		err = s.deletePkgInstall(ctx, request.Header(), targetCluster, targetNamespace, newPkgInstall.Name)
 		if err != nil {
-			return nil, connecterror.FromK8sError("delete", "PackageInstall", newPkgInstall.Name, err)
+			if err := deleteCreated(); err != nil {
+				return nil, err
+			}
+			return nil, err
 		}
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Timeout exceeded (%v s) waiting for resource to be installed: '%w'", s.pluginConfig.timeoutSeconds, err))
 	}
 
+	// The installation is not awaited: kapp-controller creates the App for the
+	// PackageInstall asynchronously, so the progress of its fetch, template and
+	// deploy phases is reported by GetInstalledPackageOperationStatus. The
+	// PackageInstall and the resources created for it are cleaned up once its
+	// App is found to be missing after the timeout.
 	// generate the response
 	installedRef := &corev1.InstalledPackageReference{
 		Context: &corev1.Context{
@@ -663,9 +1087,26 @@ func (s *Server) CreateInstalledPackage(ctx context.Context, request *connect.Re
 
 	return connect.NewResponse(&corev1.CreateInstalledPackageResponse{
 		InstalledPackageRef: installedRef,
+		Warnings:            warnings,
 	}), nil
 }
 
+// getPkgCapacityRequirements returns the capacity required by a version of a
+// package, as described by its Package.
+func (s *Server) getPkgCapacityRequirements(ctx context.Context, headers http.Header, cluster, namespace, refName, version string) (k8scorev1.ResourceList, error) {
+	pkgs, err := s.getPkgsWithFieldSelector(ctx, headers, cluster, namespace, "spec.refName="+refName)
+	if err != nil {
+		return nil, connecterror.FromK8sError("list", "Package", refName, err)
+	}
+	for _, pkg := range pkgs {
+		if pkg.Spec.RefName == refName && pkg.Spec.Version == version {
+			// the field name is misspelled in the kapp-controller types
+			return resources.ParseCapacityRequirements(pkg.Spec.CapactiyRequirementsDescription), nil
+		}
+	}
+	return nil, nil
+}
+
 // UpdateInstalledPackage Updates an installed package managed by the 'kapp_controller' plugin
 func (s *Server) UpdateInstalledPackage(ctx context.Context, request *connect.Request[corev1.UpdateInstalledPackageRequest]) (*connect.Response[corev1.UpdateInstalledPackageResponse], error) {
 	// Validate the request
@@ -676,7 +1117,7 @@ func (s *Server) UpdateInstalledPackage(ctx context.Context, request *connect.Re
 	packageCluster := request.Msg.GetInstalledPackageRef().GetContext().GetCluster()
 	packageNamespace := request.Msg.GetInstalledPackageRef().GetContext().GetNamespace()
 	installedPackageName := request.Msg.GetInstalledPackageRef().GetIdentifier()
-	log.InfoS("+kapp-controller UpdateInstalledPackage", "cluster", packageCluster, "namespace", "packageNamespace", "id", installedPackageName)
+	log.InfoS("+kapp-controller UpdateInstalledPackage", "cluster", packageCluster, "namespace", packageNamespace, "id", installedPackageName)
 
 	if request.Msg.GetInstalledPackageRef().GetContext().GetNamespace() == "" || request.Msg.GetInstalledPackageRef().GetIdentifier() == "" {
 		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Required context or identifier not provided"))
@@ -685,32 +1126,217 @@ func (s *Server) UpdateInstalledPackage(ctx context.Context, request *connect.Re
 		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("No request Name provided"))
 	}
 
-	// Retrieve additional parameters from the request
-	reconciliationOptions := request.Msg.GetReconciliationOptions()
-	pkgVersion := request.Msg.GetPkgVersionReference().GetVersion()
-	values := request.Msg.GetValues()
+	if packageCluster == "" {
+		packageCluster = s.globalPackagingCluster
+	}
 
+	update, err := s.buildPkgInstallUpdate(ctx, request.Header(), packageCluster, request.Msg)
+	if err != nil {
+		return nil, err
+	}
+	updatedPkgInstall, err := s.applyPkgInstallUpdate(ctx, request.Header(), packageCluster, update)
+	if err != nil {
+		return nil, err
+	}
+
+	// generate the response
+	updatedRef := &corev1.InstalledPackageReference{
+		Context: &corev1.Context{
+			Namespace: updatedPkgInstall.GetNamespace(),
+			Cluster:   packageCluster,
+		},
+		Identifier: updatedPkgInstall.Name,
+		Plugin:     GetPluginDetail(),
+	}
+	return connect.NewResponse(&corev1.UpdateInstalledPackageResponse{
+		InstalledPackageRef: updatedRef,
+	}), nil
+}
+
+// GetInstalledPackageChangePreview returns the changes of the Kubernetes resources that an update
+// of an installed package managed by the 'kapp_controller' plugin would apply, without applying them.
+// The preview covers the PackageInstall and the Secrets holding its values: the resources of the
+// package are only rendered by kapp-controller when reconciling the updated PackageInstall.
+func (s *Server) GetInstalledPackageChangePreview(ctx context.Context, request *connect.Request[kappcorev1.GetInstalledPackageChangePreviewRequest]) (*connect.Response[kappcorev1.GetInstalledPackageChangePreviewResponse], error) {
+	installedRef := request.Msg.GetInstalledPackageRef()
+	packageCluster := installedRef.GetContext().GetCluster()
+	packageNamespace := installedRef.GetContext().GetNamespace()
+	installedPackageName := installedRef.GetIdentifier()
+	log.InfoS("+kapp-controller GetInstalledPackageChangePreview", "cluster", packageCluster, "namespace", packageNamespace, "id", installedPackageName)
+
+	if packageNamespace == "" || installedPackageName == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Required context or identifier not provided"))
+	}
 	if packageCluster == "" {
 		packageCluster = s.globalPackagingCluster
 	}
 
-	typedClient, err := s.clientGetter.Typed(request.Header(), packageCluster)
+	update, err := s.buildPkgInstallUpdate(ctx, request.Header(), packageCluster, &corev1.UpdateInstalledPackageRequest{
+		InstalledPackageRef:   installedRef,
+		PkgVersionReference:   request.Msg.GetPkgVersionReference(),
+		Values:                request.Msg.GetValues(),
+		ReconciliationOptions: request.Msg.GetReconciliationOptions(),
+		CustomDetail:          request.Msg.GetCustomDetail(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	pkgInstallGVK := packagingv1alpha1.SchemeGroupVersion.WithKind(pkgInstallResource)
+	secretGVK := k8scorev1.SchemeGroupVersion.WithKind("Secret")
+	existingRs, proposedRs := []ctlres.Resource{}, []ctlres.Resource{}
+	addResource := func(resources *[]ctlres.Resource, obj interface{}, gvk schema.GroupVersionKind) error {
+		res, err := newKappResource(obj, gvk)
+		if err != nil {
+			return connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to convert the %s to a kapp resource: %w", gvk.Kind, err))
+		}
+		*resources = append(*resources, res)
+		return nil
+	}
+	if err := addResource(&existingRs, update.current, pkgInstallGVK); err != nil {
+		return nil, err
+	}
+	if err := addResource(&proposedRs, update.pkgInstall, pkgInstallGVK); err != nil {
+		return nil, err
+	}
+	for _, entry := range update.valuesEntries {
+		if entry.secret == nil {
+			continue
+		}
+		if err := addResource(&proposedRs, entry.secret, secretGVK); err != nil {
+			return nil, err
+		}
+		secret, err := s.getSecret(ctx, request.Header(), packageCluster, packageNamespace, entry.secret.Name)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return nil, connecterror.FromK8sError("get", "Secret", entry.secret.Name, err)
+		}
+		if err := addResource(&existingRs, secret, secretGVK); err != nil {
+			return nil, err
+		}
+	}
+	for _, entry := range update.staleValuesEntries {
+		if err := addResource(&existingRs, entry.secret, secretGVK); err != nil {
+			return nil, err
+		}
+	}
+
+	changes, err := buildResourceChanges(existingRs, proposedRs)
 	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to compute the changes of the installed package %q: %w", installedPackageName, err))
+	}
+	return connect.NewResponse(&kappcorev1.GetInstalledPackageChangePreviewResponse{
+		Changes: changes,
+	}), nil
+}
+
+// pkgInstallUpdate is the PackageInstall resulting from an update request,
+// together with the values entries to apply and the stale ones to delete.
+type pkgInstallUpdate struct {
+	current            *packagingv1alpha1.PackageInstall
+	pkgInstall         *packagingv1alpha1.PackageInstall
+	valuesEntries      []pkgInstallValuesEntry
+	staleValuesEntries []pkgInstallValuesEntry
+}
+
+// applyPkgInstallUpdate applies in the cluster the values Secrets and the
+// PackageInstall of the update, deleting the values Secrets created by this
+// plugin which are not referenced anymore.
+func (s *Server) applyPkgInstallUpdate(ctx context.Context, headers http.Header, cluster string, update *pkgInstallUpdate) (*packagingv1alpha1.PackageInstall, error) {
+	namespace := update.pkgInstall.Namespace
+	if err := s.applyValuesSecrets(ctx, headers, cluster, namespace, update.valuesEntries); err != nil {
 		return nil, err
 	}
 
+	// update the pkgInstall in the server
+	updatedPkgInstall, err := s.updatePkgInstall(ctx, headers, cluster, namespace, update.pkgInstall)
+	if err != nil {
+		return nil, connecterror.FromK8sError("update", "PackageInstall", update.pkgInstall.Name, err)
+	}
+
+	// Delete the values secrets created by this plugin which are not referenced anymore
+	if err := s.deleteValuesSecrets(ctx, headers, cluster, namespace, update.staleValuesEntries); err != nil {
+		return nil, err
+	}
+	return updatedPkgInstall, nil
+}
+
+// adoptPkgInstall takes over the management of an existing PackageInstall
+// (such as one created by kctrl) on behalf of a create request, updating it
+// with the request. An AlreadyExists error is returned if the PackageInstall
+// cannot be adopted.
+func (s *Server) adoptPkgInstall(ctx context.Context, headers http.Header, cluster string, request *corev1.CreateInstalledPackageRequest, customDetail *kappcorev1.KappControllerInstalledPackageCustomDetail, pkgInstall *packagingv1alpha1.PackageInstall, packageRefName string) (*packagingv1alpha1.PackageInstall, error) {
+	switch {
+	case pkgInstall.DeletionTimestamp != nil:
+		return nil, connect.NewError(connect.CodeAlreadyExists, fmt.Errorf("Unable to adopt the PackageInstall %q as it is being deleted", pkgInstall.Name))
+	case pkgInstall.Spec.PackageRef == nil || pkgInstall.Spec.PackageRef.RefName != packageRefName:
+		return nil, connect.NewError(connect.CodeAlreadyExists, fmt.Errorf("Unable to adopt the PackageInstall %q as it does not install the package %q", pkgInstall.Name, packageRefName))
+	case customDetail.GetCreateServiceAccount(), customDetail.GetCreateImagePullSecret():
+		return nil, connect.NewError(connect.CodeAlreadyExists, fmt.Errorf("Unable to adopt the PackageInstall %q when requesting the creation of a service account or an image pull secret", pkgInstall.Name))
+	}
+
+	// The existing PackageInstall is updated as requested by the creation,
+	// replacing the values entries previously declared by the plugin.
+	update, err := s.buildPkgInstallUpdate(ctx, headers, cluster, &corev1.UpdateInstalledPackageRequest{
+		InstalledPackageRef: &corev1.InstalledPackageReference{
+			Context:    &corev1.Context{Cluster: cluster, Namespace: pkgInstall.Namespace},
+			Identifier: pkgInstall.Name,
+			Plugin:     GetPluginDetail(),
+		},
+		PkgVersionReference:   request.GetPkgVersionReference(),
+		Values:                request.GetValues(),
+		ReconciliationOptions: request.GetReconciliationOptions(),
+		CustomDetail:          request.GetCustomDetail(),
+		Metadata:              request.GetMetadata(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if update.pkgInstall.Annotations == nil {
+		update.pkgInstall.Annotations = map[string]string{}
+	}
+	update.pkgInstall.Annotations[annotationManagedByKey] = annotationManagedByValue
+
+	return s.applyPkgInstallUpdate(ctx, headers, cluster, update)
+}
+
+// buildPkgInstallUpdate fetches the PackageInstall of the request and returns
+// it updated with the request, without applying any change in the cluster.
+func (s *Server) buildPkgInstallUpdate(ctx context.Context, headers http.Header, packageCluster string, request *corev1.UpdateInstalledPackageRequest) (*pkgInstallUpdate, error) {
+	packageNamespace := request.GetInstalledPackageRef().GetContext().GetNamespace()
+	installedPackageName := request.GetInstalledPackageRef().GetIdentifier()
+
+	// Retrieve the plugin-specific details, if any
+	customDetail := &kappcorev1.KappControllerInstalledPackageCustomDetail{}
+	if request.GetCustomDetail() != nil {
+		if err := request.GetCustomDetail().UnmarshalTo(customDetail); err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("custom details are invalid: %v", err))
+		}
+	}
+	if customDetail.GetCreateServiceAccount() {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("The creation of service accounts is only supported when installing a package"))
+	}
+
+	// Retrieve additional parameters from the request
+	reconciliationOptions := request.GetReconciliationOptions()
+	pkgVersion := request.GetPkgVersionReference().GetVersion()
+	values := request.GetValues()
+
 	// fetch the package install
-	pkgInstall, err := s.getPkgInstall(ctx, request.Header(), packageCluster, packageNamespace, installedPackageName)
+	pkgInstall, err := s.getPkgInstall(ctx, headers, packageCluster, packageNamespace, installedPackageName)
 	if err != nil {
 		return nil, connecterror.FromK8sError("get", "PackageInstall", installedPackageName, err)
 	}
+	current := pkgInstall.DeepCopy()
 
 	// Calculate the constraints and prerelease fields
-	versionConstraints, err := pkgutils.VersionConstraintWithUpgradePolicy(pkgVersion, s.pluginConfig.defaultUpgradePolicy)
+	versionConstraints, err := pkgutils.VersionConstraintWithUpgradePolicy(pkgVersion, s.config().defaultUpgradePolicy)
 	if err != nil {
 		return nil, err
 	}
-	prereleases := prereleasesVersionSelection(s.pluginConfig.defaultPrereleasesVersionSelection)
+	prereleases := prereleasesVersionSelection(s.config().defaultPrereleasesVersionSelection)
 
 	versionSelection := &vendirversions.VersionSelectionSemver{
 		Constraints: versionConstraints,
@@ -726,13 +1352,33 @@ func (s *Server) UpdateInstalledPackage(ctx context.Context, request *connect.Re
 	// Set the versionSelection
 	pkgInstall.Spec.PackageRef.VersionSelection = versionSelection
 
-	// Allow this PackageInstall to be downgraded
+	// Allow (or disallow) this PackageInstall to be downgraded, as requested or else as it
+	// already was, the configured default only applying to newly created PackageInstalls
 	// https://carvel.dev/kapp-controller/docs/v0.32.0/package-consumer-concepts/#downgrading
-	if s.pluginConfig.defaultAllowDowngrades {
+	allowDowngrades := isDowngradable(pkgInstall)
+	if customDetail.AllowDowngrades != nil {
+		allowDowngrades = customDetail.GetAllowDowngrades()
+	}
+	if !allowDowngrades {
+		if err := checkDowngrade(pkgInstall, pkgVersion); err != nil {
+			return nil, err
+		}
+	}
+	// Replace the labels and annotations, if requested, keeping the protected ones
+	if metadata := request.GetMetadata(); metadata != nil {
+		options := s.installedPackageMetadataOptions()
+		if err := resources.ValidateInstalledPackageMetadata(metadata.GetLabels(), metadata.GetAnnotations(), options); err != nil {
+			return nil, err
+		}
+		setPkgInstallMetadata(pkgInstall, metadata, options)
+	}
+	if allowDowngrades {
 		if pkgInstall.ObjectMeta.Annotations == nil {
 			pkgInstall.ObjectMeta.Annotations = map[string]string{}
 		}
 		pkgInstall.ObjectMeta.Annotations[kappctrlpackageinstall.DowngradableAnnKey] = ""
+	} else {
+		delete(pkgInstall.ObjectMeta.Annotations, kappctrlpackageinstall.DowngradableAnnKey)
 	}
 
 	// Update the rest of the fields
@@ -745,62 +1391,98 @@ func (s *Server) UpdateInstalledPackage(ctx context.Context, request *connect.Re
 		}
 		pkgInstall.Spec.Paused = reconciliationOptions.Suspend
 	}
+	if request.GetCustomDetail() != nil {
+		if err := checkDefaultNamespace(customDetail.GetDefaultNamespace(), packageNamespace, s.config().allowDefaultNamespaceOverride); err != nil {
+			return nil, err
+		}
+		pkgInstall.Spec.DefaultNamespace = customDetail.GetDefaultNamespace()
+	}
 
-	// update the pkgInstall in the server
-	updatedPkgInstall, err := s.updatePkgInstall(ctx, request.Header(), packageCluster, packageNamespace, pkgInstall)
+	// Update the values entries: the values of the request are stored in its own
+	// Secret (which is deleted if no values are passed), the entries declared in
+	// the custom detail (if provided) replace the previously declared ones and
+	// the entries not managed by this plugin are kept untouched.
+	installedValuesEntries, err := s.getInstalledValuesEntries(ctx, headers, packageCluster, pkgInstall)
 	if err != nil {
-		return nil, connecterror.FromK8sError("update", "PackageInstall", installedPackageName, err)
+		return nil, err
 	}
-
-	// Update the values.yaml values file if any is passed, otherwise, delete the values
-	if values != "" {
-		secret, err := s.buildSecret(installedPackageName, values, packageNamespace)
+	valuesEntries := []pkgInstallValuesEntry{}
+	requestedValuesEntries := []pkgInstallValuesEntry{}
+	if request.GetCustomDetail() != nil {
+		requestedValuesEntries, err = s.buildPkgInstallValuesEntries(installedPackageName, packageNamespace, customDetail.GetValues())
 		if err != nil {
-			return nil, connecterror.FromK8sError("update", "Secret", secret.Name, err)
+			return nil, err
 		}
-		updatedSecret, err := typedClient.CoreV1().Secrets(packageNamespace).Update(ctx, secret, metav1.UpdateOptions{})
-		if updatedSecret == nil || err != nil {
-			return nil, connecterror.FromK8sError("update", "Secret", secret.Name, err)
-		}
-
-		if updatedSecret != nil {
-			// Similar logic as in https://github.com/vmware-tanzu/carvel-kapp-controller/blob/v0.32.0/cli/pkg/kctrl/cmd/package/installed/create_or_update.go#L505
-			pkgInstall.Spec.Values = []packagingv1alpha1.PackageInstallValues{{
-				SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{
-					// The secret name should have the format: <name>-<namespace> as per:
-					// https://github.com/vmware-tanzu/carvel-kapp-controller/blob/v0.32.0/cli/pkg/kctrl/cmd/package/installed/created_resource_annotations.go#L19
-					Name: updatedSecret.Name,
-				},
-			}}
+	}
+	requestedSecretNames := map[string]bool{}
+	for _, entry := range requestedValuesEntries {
+		requestedSecretNames[entry.ref.SecretRef.Name] = true
+	}
+	var currentValuesSecret *k8scorev1.Secret
+	staleValuesEntries := []pkgInstallValuesEntry{}
+	for _, entry := range installedValuesEntries {
+		name := entry.ref.SecretRef.Name
+		switch {
+		case requestedSecretNames[name]:
+			// replaced by the requested entry
+		case !entry.managed:
+			valuesEntries = append(valuesEntries, entry.pkgInstallValuesEntry)
+		case name == valuesSecretName(installedPackageName, packageNamespace):
+			// updated below with the values and keys of the request
+			currentValuesSecret = entry.current
+		case request.GetCustomDetail() == nil:
+			valuesEntries = append(valuesEntries, entry.pkgInstallValuesEntry)
+		case entry.current != nil:
+			staleValuesEntries = append(staleValuesEntries, pkgInstallValuesEntry{secret: entry.current})
 		}
+	}
+	valuesEntries = append(valuesEntries, requestedValuesEntries...)
 
-	} else {
-		// Delete all the associated secrets
-		// TODO(agamez): maybe it's too aggressive and we should be deleting only those secrets created by this plugin
-		// See https://github.com/vmware-tanzu/kubeapps/pull/3790#discussion_r754797195
-		for _, packageInstallValue := range pkgInstall.Spec.Values {
-			secretId := packageInstallValue.SecretRef.Name
-			err := typedClient.CoreV1().Secrets(packageNamespace).Delete(ctx, secretId, metav1.DeleteOptions{})
-			if errors.IsNotFound(err) {
-				log.Warningf("The referenced secret does not exist: %s", connecterror.FromK8sError("get", "Secret", secretId, err).Error())
-			} else {
-				return nil, connecterror.FromK8sError("delete", "Secret", secretId, err)
-			}
+	// The additional keys of the values Secret are replaced when a custom detail
+	// is provided, while the keys added by users are kept. The Secret is deleted
+	// once it holds no key anymore.
+	valuesData := valuesSecretManagedData(currentValuesSecret)
+	if request.GetCustomDetail() != nil {
+		if valuesData, err = valuesSecretData(customDetail); err != nil {
+			return nil, err
 		}
 	}
-
-	// generate the response
-	updatedRef := &corev1.InstalledPackageReference{
-		Context: &corev1.Context{
-			Namespace: updatedPkgInstall.GetNamespace(),
-			Cluster:   packageCluster,
-		},
-		Identifier: updatedPkgInstall.Name,
-		Plugin:     GetPluginDetail(),
+	// The values of the request may be the ones currently stored in the Secret.
+	if request.GetReuseValues() && currentValuesSecret != nil {
+		values = string(currentValuesSecret.Data[valuesSecretKey])
 	}
-	return connect.NewResponse(&corev1.UpdateInstalledPackageResponse{
-		InstalledPackageRef: updatedRef,
-	}), nil
+	secret, err := s.buildSecret(installedPackageName, values, packageNamespace, valuesData)
+	if err != nil {
+		return nil, connecterror.FromK8sError("update", "Secret", secret.Name, err)
+	}
+	if values == "" {
+		delete(secret.Data, valuesSecretKey)
+	}
+	keepValuesSecretUserKeys(secret, currentValuesSecret)
+	if len(secret.Data) == 0 {
+		if currentValuesSecret != nil {
+			staleValuesEntries = append(staleValuesEntries, pkgInstallValuesEntry{secret: currentValuesSecret})
+		}
+	} else {
+		valuesEntries = append(valuesEntries, pkgInstallValuesEntry{
+			layer: kappcorev1.PackageInstallValuesLayer_PACKAGE_INSTALL_VALUES_LAYER_USER_OVERRIDES,
+			// Similar logic as in https://github.com/vmware-tanzu/carvel-kapp-controller/blob/v0.32.0/cli/pkg/kctrl/cmd/package/installed/create_or_update.go#L505
+			ref: packagingv1alpha1.PackageInstallValues{
+				SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{Name: secret.Name},
+			},
+			secret: secret,
+		})
+	}
+	if err := setPkgInstallValues(pkgInstall, valuesEntries); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to set the values of the PackageInstall: %w", err))
+	}
+
+	return &pkgInstallUpdate{
+		current:            current,
+		pkgInstall:         pkgInstall,
+		valuesEntries:      valuesEntries,
+		staleValuesEntries: staleValuesEntries,
+	}, nil
 }
 
 // DeleteInstalledPackage Deletes an installed package managed by the 'kapp_controller' plugin
@@ -823,36 +1505,65 @@ func (s *Server) DeleteInstalledPackage(ctx context.Context, request *connect.Re
 		cluster = s.globalPackagingCluster
 	}
 
-	typedClient, err := s.clientGetter.Typed(request.Header(), cluster)
-	if err != nil {
-		return nil, err
+	// Retrieve the plugin-specific deletion options, if any
+	customDetail := &kappcorev1.KappControllerInstalledPackageCustomDetail{}
+	if request.Msg.GetCustomDetail() != nil {
+		if err := request.Msg.GetCustomDetail().UnmarshalTo(customDetail); err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("custom details are invalid: %v", err))
+		}
 	}
+	orphanResources := customDetail.GetOrphanResources()
+	noopDelete := customDetail.GetNoopDelete() || orphanResources
 
 	pkgInstall, err := s.getPkgInstall(ctx, request.Header(), cluster, namespace, identifier)
 	if err != nil {
 		return nil, connecterror.FromK8sError("get", "PackageInstall", identifier, err)
 	}
+	installedValuesEntries, err := s.getInstalledValuesEntries(ctx, request.Header(), cluster, pkgInstall)
+	if err != nil {
+		return nil, err
+	}
+
+	// kapp-controller copies the noopDelete of the PackageInstall to its App when
+	// deleting it, so that the resources deployed by kapp are kept
+	if noopDelete && !pkgInstall.Spec.NoopDelete {
+		pkgInstall.Spec.NoopDelete = true
+		pkgInstall, err = s.updatePkgInstall(ctx, request.Header(), cluster, namespace, pkgInstall)
+		if err != nil {
+			return nil, connecterror.FromK8sError("update", "PackageInstall", identifier, err)
+		}
+	}
+	if orphanResources {
+		if err := s.orphanPkgInstallResources(ctx, request.Header(), cluster, pkgInstall); err != nil {
+			return nil, err
+		}
+	}
 
 	// Delete the package install
 	err = s.deletePkgInstall(ctx, request.Header(), cluster, namespace, identifier)
 	if err != nil {
 		return nil, connecterror.FromK8sError("delete", "PackageInstall", identifier, err)
 	}
+	if orphanResources {
+		return connect.NewResponse(&corev1.DeleteInstalledPackageResponse{}), nil
+	}
 
-	// Delete all the associated secrets
-	// TODO(agamez): maybe it's too aggressive and we should be deleting only those secrets created by this plugin
+	// Delete the associated values secrets created by this plugin, the other ones
+	// may be shared with other installations
 	// See https://github.com/vmware-tanzu/kubeapps/pull/3790#discussion_r754797195
-	for _, packageInstallValue := range pkgInstall.Spec.Values {
-		secretId := packageInstallValue.SecretRef.Name
-		err := typedClient.CoreV1().Secrets(namespace).Delete(ctx, secretId, metav1.DeleteOptions{})
-		if err != nil {
-			if errors.IsNotFound(err) {
-				log.Warningf("The referenced secret does not exist: %s", connecterror.FromK8sError("get", "Secret", secretId, err).Error())
-			} else {
-				return nil, connecterror.FromK8sError("delete", "Secret", secretId, err)
-			}
+	managedValuesEntries := []pkgInstallValuesEntry{}
+	for _, entry := range installedValuesEntries {
+		if entry.managed && entry.current != nil {
+			managedValuesEntries = append(managedValuesEntries, pkgInstallValuesEntry{secret: entry.current})
 		}
 	}
+	if err := s.deleteValuesSecrets(ctx, request.Header(), cluster, namespace, managedValuesEntries); err != nil {
+		return nil, err
+	}
+
+	// The ServiceAccount, Role and RoleBinding created by this plugin, if any, are not deleted here,
+	// since kapp-controller still requires them to delete the installed resources. Instead, they are
+	// owned by the PackageInstall and garbage collected once it has been removed.
 	return connect.NewResponse(&corev1.DeleteInstalledPackageResponse{}), nil
 }
 
@@ -879,3 +1590,139 @@ func (s *Server) GetInstalledPackageResourceRefs(ctx context.Context, request *c
 		ResourceRefs: refs,
 	}), nil
 }
+
+// GetInstalledPackageOperationStatus returns the progress of the fetch, template and deploy phases of the App reconciling an installed package managed by the 'kapp_controller' plugin
+func (s *Server) GetInstalledPackageOperationStatus(ctx context.Context, request *connect.Request[corev1.GetInstalledPackageOperationStatusRequest]) (*connect.Response[corev1.GetInstalledPackageOperationStatusResponse], error) {
+	// Retrieve parameters from the request
+	cluster := request.Msg.GetInstalledPackageRef().GetContext().GetCluster()
+	namespace := request.Msg.GetInstalledPackageRef().GetContext().GetNamespace()
+	installedPackageRefId := request.Msg.GetInstalledPackageRef().GetIdentifier()
+	log.InfoS("+kapp-controller GetInstalledPackageOperationStatus", "cluster", cluster, "namespace", namespace, "id", installedPackageRefId)
+
+	if cluster == "" {
+		cluster = s.globalPackagingCluster
+	}
+
+	// fetch the package install
+	pkgInstall, err := s.getPkgInstall(ctx, request.Header(), cluster, namespace, installedPackageRefId)
+	if err != nil {
+		return nil, connecterror.FromK8sError("get", "PackageInstall", installedPackageRefId, err)
+	}
+
+	// fetch the App created by kapp-controller for the package install, if already created,
+	// cleaning up the package install if kapp-controller did not create it within the timeout
+	app, err := s.getApp(ctx, request.Header(), cluster, namespace, installedPackageRefId)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return nil, connecterror.FromK8sError("get", "App", installedPackageRefId, err)
+		}
+		app = nil
+		s.cleanUpIfAppTimedOut(ctx, request.Header(), cluster, pkgInstall)
+	}
+
+	// the reconcile outcomes are recorded while the status is followed
+	if _, err := s.recordReconcileOutcome(ctx, request.Header(), cluster, pkgInstall, app); err != nil {
+		log.V(4).Infof("Unable to get the reconcile history of the PackageInstall %s/%s: %v", namespace, installedPackageRefId, err)
+	}
+
+	timeout := time.Second * time.Duration(s.config().timeoutSeconds)
+	return connect.NewResponse(buildInstalledPackageOperationStatus(pkgInstall, app, timeout, time.Now())), nil
+}
+
+// cleanUpIfAppTimedOut cleans up a package install whose App was not created within the
+// timeout, as the creation of the package install is not awaited. The failures are only
+// logged, since the package install is still reported as failed.
+func (s *Server) cleanUpIfAppTimedOut(ctx context.Context, headers http.Header, cluster string, pkgInstall *packagingv1alpha1.PackageInstall) {
+	if timedOut, err := s.cleanUpTimedOutPkgInstall(ctx, headers, cluster, pkgInstall, time.Now()); err != nil {
+		log.Errorf("Unable to clean up the PackageInstall %s/%s whose App was not created: %v", pkgInstall.Namespace, pkgInstall.Name, err)
+	} else if timedOut {
+		log.InfoS("+kapp-controller deleted the PackageInstall whose App was not created within the timeout", "namespace", pkgInstall.Namespace, "id", pkgInstall.Name)
+	}
+}
+
+// GetInstalledPackageReconcileHistory returns the last reconcile outcomes of an installed package,
+// most recent first, after recording the outcome of its last reconciliation.
+func (s *Server) GetInstalledPackageReconcileHistory(ctx context.Context, request *connect.Request[kappcorev1.GetInstalledPackageReconcileHistoryRequest]) (*connect.Response[kappcorev1.GetInstalledPackageReconcileHistoryResponse], error) {
+	// Retrieve parameters from the request
+	cluster := request.Msg.GetInstalledPackageRef().GetContext().GetCluster()
+	namespace := request.Msg.GetInstalledPackageRef().GetContext().GetNamespace()
+	installedPackageRefId := request.Msg.GetInstalledPackageRef().GetIdentifier()
+	log.InfoS("+kapp-controller GetInstalledPackageReconcileHistory", "cluster", cluster, "namespace", namespace, "id", installedPackageRefId)
+
+	if cluster == "" {
+		cluster = s.globalPackagingCluster
+	}
+
+	// fetch the package install
+	pkgInstall, err := s.getPkgInstall(ctx, request.Header(), cluster, namespace, installedPackageRefId)
+	if err != nil {
+		return nil, connecterror.FromK8sError("get", "PackageInstall", installedPackageRefId, err)
+	}
+
+	// fetch the App created by kapp-controller for the package install, if already created
+	app, err := s.getApp(ctx, request.Header(), cluster, namespace, installedPackageRefId)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return nil, connecterror.FromK8sError("get", "App", installedPackageRefId, err)
+		}
+		app = nil
+	}
+
+	history, err := s.recordReconcileOutcome(ctx, request.Header(), cluster, pkgInstall, app)
+	if err != nil {
+		return nil, connecterror.FromK8sError("get", "ConfigMap", reconcileHistoryConfigMapName(installedPackageRefId, namespace), err)
+	}
+	return connect.NewResponse(&kappcorev1.GetInstalledPackageReconcileHistoryResponse{
+		Outcomes: history,
+	}), nil
+}
+
+// GetInstalledPackageManifest returns the YAML manifest of the PackageInstall, or of the App,
+// of an installed package, for expert editing.
+func (s *Server) GetInstalledPackageManifest(ctx context.Context, request *connect.Request[kappcorev1.GetInstalledPackageManifestRequest]) (*connect.Response[kappcorev1.GetInstalledPackageManifestResponse], error) {
+	// Retrieve parameters from the request
+	cluster := request.Msg.GetInstalledPackageRef().GetContext().GetCluster()
+	namespace := request.Msg.GetInstalledPackageRef().GetContext().GetNamespace()
+	installedPackageRefId := request.Msg.GetInstalledPackageRef().GetIdentifier()
+	log.InfoS("+kapp-controller GetInstalledPackageManifest", "cluster", cluster, "namespace", namespace, "id", installedPackageRefId, "kind", request.Msg.GetKind())
+
+	if !s.config().advancedMode {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("Fetching the manifests of the installed packages is not allowed by the plugin configuration"))
+	}
+	if cluster == "" {
+		cluster = s.globalPackagingCluster
+	}
+
+	manifest, err := s.getInstalledPackageManifest(ctx, request.Header(), cluster, namespace, installedPackageRefId, request.Msg.GetKind())
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&kappcorev1.GetInstalledPackageManifestResponse{
+		Manifest: manifest,
+	}), nil
+}
+
+// UpdateInstalledPackageManifest replaces the PackageInstall of an installed package with
+// the given YAML manifest, validated server-side, the fields managed by the plugin being protected.
+func (s *Server) UpdateInstalledPackageManifest(ctx context.Context, request *connect.Request[kappcorev1.UpdateInstalledPackageManifestRequest]) (*connect.Response[kappcorev1.UpdateInstalledPackageManifestResponse], error) {
+	// Retrieve parameters from the request
+	cluster := request.Msg.GetInstalledPackageRef().GetContext().GetCluster()
+	namespace := request.Msg.GetInstalledPackageRef().GetContext().GetNamespace()
+	installedPackageRefId := request.Msg.GetInstalledPackageRef().GetIdentifier()
+	log.InfoS("+kapp-controller UpdateInstalledPackageManifest", "cluster", cluster, "namespace", namespace, "id", installedPackageRefId, "dryRun", request.Msg.GetDryRun())
+
+	if !s.config().advancedMode {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("Updating the manifests of the installed packages is not allowed by the plugin configuration"))
+	}
+	if cluster == "" {
+		cluster = s.globalPackagingCluster
+	}
+
+	manifest, err := s.updatePkgInstallManifest(ctx, request.Header(), cluster, namespace, installedPackageRefId, request.Msg.GetManifest(), request.Msg.GetDryRun())
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&kappcorev1.UpdateInstalledPackageManifestResponse{
+		Manifest: manifest,
+	}), nil
+}
@@ -6,14 +6,19 @@ package main
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"time"
 
 	"github.com/bufbuild/connect-go"
 	packagingv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/packaging/v1alpha1"
 	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/connecterror"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/pkgutils"
 	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/resources"
+	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	corev1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	kappcorev1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/plugins/kapp_controller/packages/v1alpha1"
 	k8scorev1 "k8s.io/api/core/v1"
 	log "k8s.io/klog/v2"
 )
@@ -27,7 +32,7 @@ func (s *Server) AddPackageRepository(ctx context.Context, request *connect.Requ
 	}
 	namespace := request.Msg.GetContext().GetNamespace()
 	if namespace == "" {
-		namespace = s.pluginConfig.globalPackagingNamespace
+		namespace = s.config().globalPackagingNamespace
 	}
 
 	// trace logging
@@ -40,11 +45,21 @@ func (s *Server) AddPackageRepository(ctx context.Context, request *connect.Requ
 	if err := s.validatePackageRepositoryCreate(ctx, cluster, request); err != nil {
 		return nil, err
 	}
+	if err := s.trustPkgRepositoryHostKeys(ctx, request.Msg.Url, request.Msg.Auth, request.Msg.CustomDetail); err != nil {
+		return nil, err
+	}
 
 	// create secret (must be done first, to get the name)
 	var err error
 	var pkgSecret *k8scorev1.Secret
 	if request.Msg.Auth != nil && request.Msg.Auth.Type != corev1.PackageRepositoryAuth_PACKAGE_REPOSITORY_AUTH_TYPE_UNSPECIFIED && request.Msg.Auth.GetSecretRef() == nil {
+		// The secret is created before the repository and updated after it, to
+		// set its owner reference, so check both up front rather than leaving
+		// a repository without its credentials.
+		if err := s.checkSecretsAccess(ctx, request.Header(), cluster, namespace, "create", "update"); err != nil {
+			return nil, err
+		}
+
 		pkgSecret, err = s.buildPkgRepositorySecretCreate(namespace, request.Msg.Name, request.Msg.Auth)
 		if err != nil {
 			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to build the associated secret: %w", err))
@@ -66,13 +81,14 @@ func (s *Server) AddPackageRepository(ctx context.Context, request *connect.Requ
 		return nil, connecterror.FromK8sError("create", "PackageRepository", request.Msg.Name, err)
 	}
 
-	// update secret with owner reference if needed
+	// update secret with owner reference if needed, and export it
 	if pkgSecret != nil {
 		setOwnerReference(pkgSecret, pkgRepository)
-		_, err = s.updateSecret(ctx, request.Header(), cluster, pkgSecret)
+		pkgSecret, err = s.updateSecret(ctx, request.Header(), cluster, pkgSecret)
 		if err != nil {
 			return nil, connecterror.FromK8sError("update", "Secret", request.Msg.Name, err)
 		}
+		s.exportPkgRepositorySecret(ctx, request.Header(), cluster, pkgRepository, pkgSecret)
 	}
 
 	// response
@@ -91,6 +107,64 @@ func (s *Server) AddPackageRepository(ctx context.Context, request *connect.Requ
 	return connect.NewResponse(response), nil
 }
 
+// TestPackageRepositoryConnection checks that a package repository managed by the 'kapp_controller' plugin can be fetched, without creating it
+func (s *Server) TestPackageRepositoryConnection(ctx context.Context, request *connect.Request[corev1.TestPackageRepositoryConnectionRequest]) (*connect.Response[corev1.TestPackageRepositoryConnectionResponse], error) {
+	repository := request.Msg.GetPackageRepository()
+	if repository == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("No package repository provided"))
+	}
+
+	// context info
+	cluster := repository.GetContext().GetCluster()
+	if cluster == "" {
+		cluster = s.globalPackagingCluster
+	}
+	namespace := repository.GetContext().GetNamespace()
+	if namespace == "" {
+		namespace = s.config().globalPackagingNamespace
+	}
+
+	// trace logging
+	log.InfoS("+kapp-controller TestPackageRepositoryConnection", "cluster", cluster, "namespace", namespace, "name", repository.GetName())
+
+	// validation, using the same rules as when adding the repository
+	if cluster != s.globalPackagingCluster {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Installing package repositories in other clusters in not supported yet"))
+	}
+	addRequest := connect.NewRequest(repository)
+	for key, values := range request.Header() {
+		addRequest.Header()[key] = values
+	}
+	if err := s.validatePackageRepositoryCreate(ctx, cluster, addRequest); err != nil {
+		return nil, err
+	}
+
+	// the secret is either read, when referenced, or only built in memory
+	var err error
+	var pkgSecret *k8scorev1.Secret
+	if repository.Auth != nil && repository.Auth.Type != corev1.PackageRepositoryAuth_PACKAGE_REPOSITORY_AUTH_TYPE_UNSPECIFIED {
+		if secretRef := repository.Auth.GetSecretRef(); secretRef != nil {
+			pkgSecret, err = s.getSecret(ctx, request.Header(), cluster, namespace, secretRef.Name)
+			if err != nil {
+				return nil, connecterror.FromK8sError("get", "Secret", secretRef.Name, err)
+			}
+		} else {
+			pkgSecret, err = s.buildPkgRepositorySecretCreate(namespace, repository.Name, repository.Auth)
+			if err != nil {
+				return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to build the associated secret: %w", err))
+			}
+		}
+	}
+
+	response, err := s.testPkgRepositoryConnection(ctx, repository.Type, repository.Url, pkgSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	log.InfoS("-kapp-controller TestPackageRepositoryConnection", "cluster", cluster, "namespace", namespace, "name", repository.GetName())
+	return connect.NewResponse(response), nil
+}
+
 // GetPackageRepositoryDetail returns the package repository metadata managed by the 'kapp_controller' plugin
 func (s *Server) GetPackageRepositoryDetail(ctx context.Context, request *connect.Request[corev1.GetPackageRepositoryDetailRequest]) (*connect.Response[corev1.GetPackageRepositoryDetailResponse], error) {
 	// context info
@@ -100,7 +174,7 @@ func (s *Server) GetPackageRepositoryDetail(ctx context.Context, request *connec
 	}
 	namespace := request.Msg.GetPackageRepoRef().GetContext().GetNamespace()
 	if namespace == "" {
-		namespace = s.pluginConfig.globalPackagingNamespace
+		namespace = s.config().globalPackagingNamespace
 	}
 	name := request.Msg.GetPackageRepoRef().GetIdentifier()
 
@@ -122,12 +196,29 @@ func (s *Server) GetPackageRepositoryDetail(ctx context.Context, request *connec
 		}
 	}
 
+	// fetch the namespaces to which the repository secret is exported, if managed by the plugin
+	var secretExportedTo []string
+	if pkgSecret != nil && isPluginManaged(pkgRepository, pkgSecret) && namespace == s.config().globalPackagingNamespace {
+		secretExportedTo, err = s.getSecretExportNamespaces(ctx, request.Header(), cluster, namespace, pkgSecret.Name)
+		if err != nil {
+			log.Warningf("Unable to get the SecretExport of the secret [%s]: %v", pkgSecret.Name, err)
+		}
+	}
+
 	// translate
-	repository, err := s.buildPackageRepository(pkgRepository, pkgSecret, cluster)
+	repository, err := s.buildPackageRepository(pkgRepository, pkgSecret, secretExportedTo, cluster)
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to convert the PackageRepository: %w", err))
 	}
 
+	// probe, telling whether the repository can be fetched even before kapp-controller syncs it
+	if request.Msg.GetIncludeProbe() && repository.Type != typeInline {
+		probe := s.probePkgRepository(ctx, repository.Type, repository.Url, pkgSecret)
+		if err := setPackageRepositoryProbe(repository, probe); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to convert the PackageRepository: %w", err))
+		}
+	}
+
 	// response
 	response := &corev1.GetPackageRepositoryDetailResponse{
 		Detail: repository,
@@ -137,6 +228,122 @@ func (s *Server) GetPackageRepositoryDetail(ctx context.Context, request *connec
 	return connect.NewResponse(response), nil
 }
 
+// ListPackageRepositoryPackages returns the available packages contributed by a package repository managed by the 'kapp_controller' plugin
+func (s *Server) ListPackageRepositoryPackages(ctx context.Context, request *connect.Request[corev1.ListPackageRepositoryPackagesRequest]) (*connect.Response[corev1.ListPackageRepositoryPackagesResponse], error) {
+	// context info
+	cluster := request.Msg.GetPackageRepoRef().GetContext().GetCluster()
+	if cluster == "" {
+		cluster = s.globalPackagingCluster
+	}
+	namespace := request.Msg.GetPackageRepoRef().GetContext().GetNamespace()
+	if namespace == "" {
+		namespace = s.config().globalPackagingNamespace
+	}
+	name := request.Msg.GetPackageRepoRef().GetIdentifier()
+
+	// trace logging
+	log.InfoS("+kapp-controller ListPackageRepositoryPackages", "cluster", cluster, "namespace", namespace, "name", name)
+
+	// fetch repository, also checking that the user can read it
+	pkgRepository, err := s.getPkgRepository(ctx, request.Header(), cluster, namespace, name)
+	if err != nil {
+		return nil, connecterror.FromK8sError("get", "PackageRepository", name, err)
+	}
+
+	// the packages of a repository are annotated with its "namespace/name",
+	// the packages of the global repositories being visible in every namespace.
+	summariesRequest := connect.NewRequest(&corev1.GetAvailablePackageSummariesRequest{
+		Context: &corev1.Context{
+			Cluster:   cluster,
+			Namespace: namespace,
+		},
+		FilterOptions: &corev1.FilterOptions{
+			Repositories: []string{fmt.Sprintf("%s/%s", namespace, name)},
+		},
+		PaginationOptions: request.Msg.GetPaginationOptions(),
+	})
+	for key, values := range request.Header() {
+		summariesRequest.Header()[key] = values
+	}
+	summaries, err := s.GetAvailablePackageSummaries(ctx, summariesRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	// response
+	response := &corev1.ListPackageRepositoryPackagesResponse{
+		AvailablePackageSummaries: summaries.Msg.AvailablePackageSummaries,
+		NextPageToken:             summaries.Msg.NextPageToken,
+		TotalCount:                summaries.Msg.TotalSize,
+	}
+	if fetch := pkgRepository.Status.Fetch; fetch != nil && !fetch.UpdatedAt.IsZero() {
+		response.LastUpdated = fetch.UpdatedAt.UTC().Format(time.RFC3339)
+	}
+
+	log.InfoS("-kapp-controller ListPackageRepositoryPackages", "cluster", cluster, "namespace", namespace, "name", name)
+	return connect.NewResponse(response), nil
+}
+
+// ScanPackageRepositoryHostKeys scans the SSH host keys of the git server of a package repository managed by the 'kapp_controller' plugin
+func (s *Server) ScanPackageRepositoryHostKeys(ctx context.Context, request *connect.Request[kappcorev1.ScanPackageRepositoryHostKeysRequest]) (*connect.Response[kappcorev1.ScanPackageRepositoryHostKeysResponse], error) {
+	repoURL := request.Msg.GetUrl()
+	repoRef := request.Msg.GetPackageRepoRef()
+
+	// trace logging
+	log.InfoS("+kapp-controller ScanPackageRepositoryHostKeys", "url", repoURL, "name", repoRef.GetIdentifier())
+
+	// the known hosts of an existing repository, to flag the known keys
+	knownHosts := ""
+	if repoRef != nil {
+		cluster := repoRef.GetContext().GetCluster()
+		if cluster == "" {
+			cluster = s.globalPackagingCluster
+		}
+		namespace := repoRef.GetContext().GetNamespace()
+		if namespace == "" {
+			namespace = s.config().globalPackagingNamespace
+		}
+		name := repoRef.GetIdentifier()
+
+		pkgRepository, err := s.getPkgRepository(ctx, request.Header(), cluster, namespace, name)
+		if err != nil {
+			return nil, connecterror.FromK8sError("get", "PackageRepository", name, err)
+		}
+		if pkgRepository.Spec.Fetch == nil || pkgRepository.Spec.Fetch.Git == nil {
+			return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("The package repository %q is not a git repository", name))
+		}
+		if repoURL == "" {
+			repoURL = pkgRepository.Spec.Fetch.Git.URL
+		}
+		if pkgSecretRef := repositorySecretRef(pkgRepository); pkgSecretRef != nil {
+			pkgSecret, err := s.getSecret(ctx, request.Header(), cluster, namespace, pkgSecretRef.Name)
+			if err != nil {
+				return nil, connecterror.FromK8sError("get", "Secret", pkgSecretRef.Name, err)
+			}
+			knownHosts = string(pkgSecret.Data[sshAuthKnownHosts])
+		}
+	}
+	if repoURL == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("No request Url or package repository provided"))
+	}
+
+	address, err := sshHostAddress(repoURL)
+	if err != nil {
+		return nil, err
+	}
+	dialer, err := s.hostKeyScanDialer()
+	if err != nil {
+		return nil, err
+	}
+	keys, err := scanHostKeys(ctx, dialer, address)
+	if err != nil {
+		return nil, err
+	}
+
+	log.InfoS("-kapp-controller ScanPackageRepositoryHostKeys", "url", repoURL, "name", repoRef.GetIdentifier())
+	return connect.NewResponse(buildHostKeysResponse(keys, knownHosts)), nil
+}
+
 // GetPackageRepositorySummaries returns the package repositories managed by the 'kapp_controller' plugin
 func (s *Server) GetPackageRepositorySummaries(ctx context.Context, request *connect.Request[corev1.GetPackageRepositorySummariesRequest]) (*connect.Response[corev1.GetPackageRepositorySummariesResponse], error) {
 	// context info
@@ -151,14 +358,16 @@ func (s *Server) GetPackageRepositorySummaries(ctx context.Context, request *con
 
 	// retrieve the list of repositories
 	var pkgRepositories []*packagingv1alpha1.PackageRepository
+	var failures []*corev1.NamespaceFailure
 	if namespace == "" {
 		// find globally, either via cluster access or by enumerating through namespaces
 		if repos, err := s.getPkgRepositories(ctx, request.Header(), cluster, ""); err == nil {
 			pkgRepositories = append(pkgRepositories, repos...)
 		} else {
 			log.Warningf("+kapp-controller unable to list package repositories at the cluster scope in '%s' due to [%v]", cluster, err)
-			if repos, err = s.getAccessiblePackageRepositories(ctx, request.Header(), cluster); err == nil {
+			if repos, nsFailures, err := s.getAccessiblePackageRepositories(ctx, request.Header(), cluster); err == nil {
 				pkgRepositories = append(pkgRepositories, repos...)
+				failures = append(failures, nsFailures...)
 			} else {
 				return nil, err
 			}
@@ -172,9 +381,11 @@ func (s *Server) GetPackageRepositorySummaries(ctx context.Context, request *con
 		}
 
 		// try to also include global repositories
-		if namespace != s.pluginConfig.globalPackagingNamespace {
-			if repos, err := s.getPkgRepositories(ctx, request.Header(), cluster, s.pluginConfig.globalPackagingNamespace); err == nil {
+		if namespace != s.config().globalPackagingNamespace {
+			if repos, err := s.getPkgRepositories(ctx, request.Header(), cluster, s.config().globalPackagingNamespace); err == nil {
 				pkgRepositories = append(pkgRepositories, repos...)
+			} else {
+				failures = append(failures, pkgutils.NewNamespaceFailure(cluster, s.config().globalPackagingNamespace, GetPluginDetail(), connecterror.FromK8sError("list", "PackageRepository", "", err)))
 			}
 		}
 	}
@@ -193,6 +404,7 @@ func (s *Server) GetPackageRepositorySummaries(ctx context.Context, request *con
 	// response
 	response := &corev1.GetPackageRepositorySummariesResponse{
 		PackageRepositorySummaries: repositories,
+		PartialResults:             pkgutils.NewPartialResults(failures),
 	}
 
 	log.InfoS("-kapp-controller GetPackageRepositories", "cluster", cluster, "namespace", namespace)
@@ -208,7 +420,7 @@ func (s *Server) UpdatePackageRepository(ctx context.Context, request *connect.R
 	}
 	namespace := request.Msg.GetPackageRepoRef().GetContext().GetNamespace()
 	if namespace == "" {
-		namespace = s.pluginConfig.globalPackagingNamespace
+		namespace = s.config().globalPackagingNamespace
 	}
 	name := request.Msg.GetPackageRepoRef().GetIdentifier()
 
@@ -242,6 +454,9 @@ func (s *Server) UpdatePackageRepository(ctx context.Context, request *connect.R
 	if err := s.validatePackageRepositoryUpdate(ctx, cluster, request, pkgRepository, pkgSecret); err != nil {
 		return nil, err
 	}
+	if err := s.trustPkgRepositoryHostKeys(ctx, request.Msg.Url, request.Msg.Auth, request.Msg.CustomDetail); err != nil {
+		return nil, err
+	}
 
 	// handle managed secret, there are 3 cases to consider:
 	//    create the secret if auth was not previously configured
@@ -283,6 +498,7 @@ func (s *Server) UpdatePackageRepository(ctx context.Context, request *connect.R
 			if pkgSecret, err = s.createSecret(ctx, request.Header(), cluster, newSecret); err != nil {
 				return nil, connecterror.FromK8sError("create", "Secret", name, err)
 			}
+			s.exportPkgRepositorySecret(ctx, request.Header(), cluster, pkgRepository, pkgSecret)
 		}
 	}
 
@@ -321,26 +537,126 @@ func (s *Server) DeletePackageRepository(ctx context.Context, request *connect.R
 	}
 	namespace := request.Msg.GetPackageRepoRef().GetContext().GetNamespace()
 	if namespace == "" {
-		namespace = s.pluginConfig.globalPackagingNamespace
+		namespace = s.config().globalPackagingNamespace
 	}
 	name := request.Msg.GetPackageRepoRef().GetIdentifier()
 
 	// trace logging
 	log.InfoS("+kapp-controller DeletePackageRepository", "cluster", cluster, "namespace", namespace, "name", name)
 
+	// secret still referenced by other objects
+	retainedSecrets, err := s.releaseReferencedRepoSecret(ctx, request.Header(), cluster, namespace, name, request.Msg.GetRetainReferencedSecrets())
+	if err != nil {
+		return nil, err
+	}
+
 	// delete
-	err := s.deletePkgRepository(ctx, request.Header(), cluster, namespace, name)
+	err = s.deletePkgRepository(ctx, request.Header(), cluster, namespace, name)
 	if err != nil {
 		return nil, connecterror.FromK8sError("delete", "PackageRepository", name, err)
 	}
 
 	// response
-	response := &corev1.DeletePackageRepositoryResponse{}
+	response := &corev1.DeletePackageRepositoryResponse{
+		RetainedSecrets: retainedSecrets,
+	}
 
 	log.InfoS("-kapp-controller DeletePackageRepository", "cluster", cluster, "namespace", namespace, "name", name)
 	return connect.NewResponse(response), nil
 }
 
+// releaseReferencedRepoSecret checks whether the Secret managed by the plugin for the package
+// repository is still referenced by other PackageRepositories or PackageInstalls of its namespace.
+// Unless it is retained, these references prevent the deletion of the package repository.
+// Otherwise, the Secret is no longer owned by the package repository, so that it does not get
+// garbage collected along with it, and its usage is returned.
+func (s *Server) releaseReferencedRepoSecret(ctx context.Context, headers http.Header, cluster, namespace, name string, retainReferencedSecrets bool) ([]*corev1.PackageRepositorySecretUsage, error) {
+	pkgRepository, err := s.getPkgRepository(ctx, headers, cluster, namespace, name)
+	if err != nil {
+		return nil, connecterror.FromK8sError("get", "PackageRepository", name, err)
+	}
+	pkgSecretRef := repositorySecretRef(pkgRepository)
+	if pkgSecretRef == nil {
+		return nil, nil
+	}
+	pkgSecret, err := s.getSecret(ctx, headers, cluster, namespace, pkgSecretRef.Name)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, connecterror.FromK8sError("get", "Secret", pkgSecretRef.Name, err)
+	}
+	if !isPluginManaged(pkgRepository, pkgSecret) {
+		return nil, nil
+	}
+
+	usages := pkgutils.NewSecretUsages(pkgSecret.GetName())
+	pkgRepositories, err := s.getPkgRepositories(ctx, headers, cluster, namespace)
+	if err != nil {
+		return nil, connecterror.FromK8sError("list", "PackageRepository", "", err)
+	}
+	for _, otherRepository := range pkgRepositories {
+		if otherRepository.GetName() == name {
+			continue
+		}
+		if secretRef := repositorySecretRef(otherRepository); secretRef != nil {
+			usages.Add(secretRef.Name, &corev1.ResourceRef{
+				ApiVersion: packagingv1alpha1.SchemeGroupVersion.String(),
+				Kind:       pkgRepositoryResource,
+				Name:       otherRepository.GetName(),
+				Namespace:  otherRepository.GetNamespace(),
+			})
+		}
+	}
+	pkgInstalls, err := s.getPkgInstalls(ctx, headers, cluster, namespace, "")
+	if err != nil {
+		return nil, connecterror.FromK8sError("list", "PackageInstall", "", err)
+	}
+	for _, pkgInstall := range pkgInstalls {
+		ref := &corev1.ResourceRef{
+			ApiVersion: packagingv1alpha1.SchemeGroupVersion.String(),
+			Kind:       pkgInstallResource,
+			Name:       pkgInstall.GetName(),
+			Namespace:  pkgInstall.GetNamespace(),
+		}
+		for _, values := range pkgInstall.Spec.Values {
+			if values.SecretRef != nil {
+				usages.Add(values.SecretRef.Name, ref)
+			}
+		}
+		if pkgInstall.Spec.Cluster != nil && pkgInstall.Spec.Cluster.KubeconfigSecretRef != nil {
+			usages.Add(pkgInstall.Spec.Cluster.KubeconfigSecretRef.Name, ref)
+		}
+	}
+
+	inUse := usages.InUse()
+	if len(inUse) == 0 {
+		return nil, nil
+	}
+	if !retainReferencedSecrets {
+		return nil, pkgutils.SecretsInUseError(name, inUse)
+	}
+	if pkgutils.RemoveOwnerReference(pkgSecret, pkgRepository.GetUID()) {
+		if _, err := s.updateSecret(ctx, headers, cluster, pkgSecret); err != nil {
+			return nil, connecterror.FromK8sError("update", "Secret", pkgSecret.GetName(), err)
+		}
+		log.InfoS("Retained the secret of the deleted PackageRepository", "secret", pkgSecret.GetName(), "repository", name, "namespace", namespace)
+	}
+	return inUse, nil
+}
+
+// exportPkgRepositorySecret exports the Secret, managed by the plugin, of a global package
+// repository with secretgen-controller. The packages installed from the repository in other
+// namespaces can then use its credentials through a placeholder Secret. As secretgen-controller
+// is optional, a failure to export the Secret is only logged.
+func (s *Server) exportPkgRepositorySecret(ctx context.Context, headers http.Header, cluster string, pkgRepository *packagingv1alpha1.PackageRepository, pkgSecret *k8scorev1.Secret) {
+	if pkgRepository.GetNamespace() != s.config().globalPackagingNamespace {
+		return
+	}
+	if err := s.createSecretExport(ctx, headers, cluster, s.buildSecretExport(pkgSecret)); err != nil {
+		log.Warningf("Unable to export the secret [%s] of the package repository [%s]: %v", pkgSecret.GetName(), pkgRepository.GetName(), err)
+	}
+}
+
 // GetPackageRepositoryPermissions provides permissions available to manage package repository by the 'kapp_controller' plugin
 func (s *Server) GetPackageRepositoryPermissions(ctx context.Context, request *connect.Request[corev1.GetPackageRepositoryPermissionsRequest]) (*connect.Response[corev1.GetPackageRepositoryPermissionsResponse], error) {
 	log.Infof("+kapp-controller GetPackageRepositoryPermissions [%v]", request)
@@ -364,18 +680,20 @@ func (s *Server) GetPackageRepositoryPermissions(ctx context.Context, request *c
 		Plugin: GetPluginDetail(),
 	}
 
-	// Global permissions
-	permissions.Global, err = resources.GetPermissionsOnResource(ctx, typedClient, resource, s.pluginConfig.globalPackagingNamespace)
+	// The global and the namespace permissions are requested as a single batch
+	// of access reviews.
+	globalNamespace := s.config().globalPackagingNamespace
+	namespaces := []string{globalNamespace}
+	if namespace != "" {
+		namespaces = append(namespaces, namespace)
+	}
+	namespacesPermissions, err := resources.GetPermissionsOnResourceInNamespaces(ctx, typedClient, resource, namespaces)
 	if err != nil {
 		return nil, err
 	}
-
-	// Namespace permissions
+	permissions.Global = namespacesPermissions[globalNamespace]
 	if namespace != "" {
-		permissions.Namespace, err = resources.GetPermissionsOnResource(ctx, typedClient, resource, request.Msg.GetContext().GetNamespace())
-		if err != nil {
-			return nil, err
-		}
+		permissions.Namespace = namespacesPermissions[namespace]
 	}
 
 	return connect.NewResponse(&corev1.GetPackageRepositoryPermissionsResponse{
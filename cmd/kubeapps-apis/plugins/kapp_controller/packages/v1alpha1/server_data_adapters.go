@@ -7,7 +7,10 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"sort"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/bufbuild/connect-go"
 	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/connecterror"
@@ -22,9 +25,14 @@ import (
 	corev1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
 	kappcorev1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/plugins/kapp_controller/packages/v1alpha1"
 	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/pkgutils"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/resources"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	k8scorev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	log "k8s.io/klog/v2"
 )
 
@@ -37,9 +45,52 @@ const (
 
 	redacted = "REDACTED"
 
+	// maxStatusMessageLength is the maximum length of the status messages
+	// returned for a repository, as kapp-controller may report the whole
+	// output of the fetch in them.
+	maxStatusMessageLength = 2048
+	truncatedSuffix        = "... (truncated)"
+
+	// defaultPkgRepositorySyncPeriod and minPkgRepositorySyncPeriod are the
+	// sync periods used by kapp-controller for package repositories.
+	defaultPkgRepositorySyncPeriod = 5 * time.Minute
+	minPkgRepositorySyncPeriod     = 30 * time.Second
+
+	// defaultAppSyncPeriod is the sync period used by kapp-controller for the
+	// Apps of the package installs without an explicit one.
+	defaultAppSyncPeriod = 10 * time.Minute
+
 	annotationManagedByKey   = "kubeapps.dev/managed-by"
 	annotationManagedByValue = "plugin:kapp-controller"
 
+	// annotationImagePullSecretKey marks a Secret as a placeholder to be filled in by
+	// secretgen-controller with the exported registry credentials.
+	annotationImagePullSecretKey = "secretgen.carvel.dev/image-pull-secret"
+
+	// annotationImagePullSecretNameKey records, in a PackageInstall, the name of
+	// the image pull Secret created for it, either a placeholder or imported.
+	annotationImagePullSecretNameKey = "kubeapps.dev/image-pull-secret"
+
+	// annotationValuesLayersKey records, in a PackageInstall, the layer of each
+	// values entry declared through the plugin, keyed by the Secret name.
+	annotationValuesLayersKey = "kubeapps.dev/values-layers"
+
+	// annotationValuesSecretKeysKey records, in the Secret holding the values
+	// of a request, the comma-separated additional keys set through the plugin,
+	// the other keys but the values one having been added by users.
+	annotationValuesSecretKeysKey = "kubeapps.dev/values-secret-keys"
+
+	// valuesSecretKey is the key of the Secret holding the values of a request, as per:
+	// https://github.com/vmware-tanzu/carvel-kapp-controller/blob/v0.32.0/cli/pkg/kctrl/cmd/package/installed/create_or_update.go#L32
+	valuesSecretKey = "values.yaml"
+
+	// annotationDeprecatedKey marks, in a Package, its version as deprecated,
+	// with an optional message, such as the version to use instead, as value.
+	// annotationEndOfLifeKey records, in a Package, the date from which its
+	// version is no longer supported, such as "2024-06-30".
+	annotationDeprecatedKey = "kubeapps.dev/deprecated"
+	annotationEndOfLifeKey  = "kubeapps.dev/end-of-life"
+
 	sshAuthKnownHosts = "ssh-knownhosts"
 	bearerAuthToken   = "token"
 )
@@ -61,7 +112,7 @@ func (s *Server) buildAvailablePackageSummary(pkgMetadata *datapackagingv1alpha1
 	}
 
 	// build package identifier based on the metadata
-	identifier := buildPackageIdentifier(pkgMetadata)
+	identifier := s.packageIdentifier(pkgMetadata, cluster)
 
 	availablePackageSummary := &corev1.AvailablePackageSummary{
 		AvailablePackageRef: &corev1.AvailablePackageReference{
@@ -111,7 +162,7 @@ func (s *Server) buildAvailablePackageDetail(pkgMetadata *datapackagingv1alpha1.
 	}
 
 	// build package identifier based on the metadata
-	identifier := buildPackageIdentifier(pkgMetadata)
+	identifier := s.packageIdentifier(pkgMetadata, cluster)
 
 	// build readme
 	readme := buildReadme(pkgMetadata, foundPkgSemver)
@@ -141,8 +192,9 @@ func (s *Server) buildAvailablePackageDetail(pkgMetadata *datapackagingv1alpha1.
 		// Currently, PkgVersion and AppVersion are the same
 		// https://kubernetes.slack.com/archives/CH8KCCKA5/p1636386358322000?thread_ts=1636371493.320900&cid=CH8KCCKA5
 		Version: &corev1.PackageAppVersion{
-			PkgVersion: requestedPkgVersion,
-			AppVersion: requestedPkgVersion,
+			PkgVersion:  requestedPkgVersion,
+			AppVersion:  requestedPkgVersion,
+			Deprecation: pkgVersionDeprecation(foundPkgSemver.pkg, time.Now()),
 		},
 		Maintainers:   maintainers,
 		Readme:        readme,
@@ -226,6 +278,16 @@ func (s *Server) buildInstalledPackageSummary(pkgInstall *packagingv1alpha1.Pack
 		}
 	}
 
+	// flag the installed version when deprecated by the publisher
+	for _, v := range versions {
+		if v.pkg.Spec.Version == pkgInstall.Status.LastAttemptedVersion {
+			deprecation := pkgVersionDeprecation(v.pkg, time.Now())
+			installedPackageSummary.CurrentVersion.Deprecation = deprecation
+			installedPackageSummary.Warnings = deprecationWarnings(v.pkg.Spec.Version, deprecation)
+			break
+		}
+	}
+
 	if len(pkgInstall.Status.Conditions) > 0 {
 		installedPackageSummary.Status = &corev1.InstalledPackageStatus{
 			Ready:      pkgInstall.Status.Conditions[0].Type == kappctrlv1alpha1.ReconcileSucceeded,
@@ -237,15 +299,18 @@ func (s *Server) buildInstalledPackageSummary(pkgInstall *packagingv1alpha1.Pack
 	return installedPackageSummary, nil
 }
 
-func (s *Server) buildInstalledPackageDetail(pkgInstall *packagingv1alpha1.PackageInstall, pkgMetadata *datapackagingv1alpha1.PackageMetadata, pkgVersionsMap map[string][]pkgSemver, app *kappctrlv1alpha1.App, valuesApplied, cluster string) (*corev1.InstalledPackageDetail, error) {
+func (s *Server) buildInstalledPackageDetail(pkgInstall *packagingv1alpha1.PackageInstall, pkgMetadata *datapackagingv1alpha1.PackageMetadata, pkgVersionsMap map[string][]pkgSemver, app *kappctrlv1alpha1.App, valuesApplied string, valuesEntries []*kappcorev1.PackageInstallValues, valuesSecretKeys []string, cluster string) (*corev1.InstalledPackageDetail, error) {
 	// get the versions associated with the package
 	versions := pkgVersionsMap[pkgMetadata.Name]
 	if len(versions) == 0 {
 		return nil, fmt.Errorf("no package versions for the package %q", pkgMetadata.Name)
 	}
 
-	// build postInstallationNotes
-	postInstallationNotes := buildPostInstallationNotes(app)
+	// build postInstallationNotes, once kapp-controller has created the App
+	postInstallationNotes := ""
+	if app != nil {
+		postInstallationNotes = buildPostInstallationNotes(app)
+	}
 
 	if len(pkgInstall.Status.Conditions) > 1 {
 		log.Warningf("The package install %s has more than one status conditions. Using the first one: %s", pkgInstall.Name, pkgInstall.Status.Conditions[0])
@@ -257,7 +322,7 @@ func (s *Server) buildInstalledPackageDetail(pkgInstall *packagingv1alpha1.Packa
 	}
 
 	// build package availablePackageIdentifier based on the metadata
-	availablePackageIdentifier := buildPackageIdentifier(pkgMetadata)
+	availablePackageIdentifier := s.packageIdentifier(pkgMetadata, cluster)
 
 	installedPackageDetail := &corev1.InstalledPackageDetail{
 		InstalledPackageRef: &corev1.InstalledPackageReference{
@@ -298,6 +363,7 @@ func (s *Server) buildInstalledPackageDetail(pkgInstall *packagingv1alpha1.Packa
 			PkgVersion: versions[0].version.String(),
 			AppVersion: versions[0].version.String(),
 		},
+		Metadata: resources.InstalledPackageMetadataFromObject(pkgInstall.ObjectMeta, s.installedPackageMetadataOptions()),
 	}
 
 	if latestMatchingVersion != nil {
@@ -310,53 +376,337 @@ func (s *Server) buildInstalledPackageDetail(pkgInstall *packagingv1alpha1.Packa
 	}
 
 	// Some fields would require an extra nil check before being populated
-	if app.Spec.SyncPeriod != nil {
+	if app != nil && app.Spec.SyncPeriod != nil {
 		installedPackageDetail.ReconciliationOptions.Interval = pkgutils.FromDuration(app.Spec.SyncPeriod)
 	}
 
-	if pkgInstall.Status.Conditions != nil && len(pkgInstall.Status.Conditions) > 0 {
+	if app == nil {
+		// the installation is pending until kapp-controller creates the App
+		timeout := time.Second * time.Duration(s.config().timeoutSeconds)
+		installedPackageDetail.Status = buildInstalledPackageOperationStatus(pkgInstall, nil, timeout, time.Now()).Status
+	} else if pkgInstall.Status.Conditions != nil && len(pkgInstall.Status.Conditions) > 0 {
 		installedPackageDetail.Status = &corev1.InstalledPackageStatus{
 			Ready:      pkgInstall.Status.Conditions[0].Type == kappctrlv1alpha1.ReconcileSucceeded,
 			Reason:     statusReasonForKappStatus(pkgInstall.Status.Conditions[0].Type),
 			UserReason: pkgInstall.Status.UsefulErrorMessage, // long message, instead of the simpleUserReasonForKappStatus
 		}
 		installedPackageDetail.ReconciliationOptions.Suspend = pkgInstall.Status.Conditions[0].Type == kappctrlv1alpha1.Reconciling
+		setInstalledPackageReconcileTimes(installedPackageDetail.Status, pkgInstall, app, time.Now())
+	}
+
+	// Add the plugin-specific details
+	if customDetail, err := anypb.New(&kappcorev1.KappControllerInstalledPackageCustomDetail{
+		AllowDowngrades:     proto.Bool(isDowngradable(pkgInstall)),
+		Values:              valuesEntries,
+		DefaultNamespace:    pkgInstall.Spec.DefaultNamespace,
+		AppIdentity:         buildInstalledPackageAppIdentity(app),
+		ValuesSecretKeys:    valuesSecretKeys,
+		ImagePullSecretName: pkgInstall.GetAnnotations()[annotationImagePullSecretNameKey],
+	}); err != nil {
+		return nil, err
+	} else {
+		installedPackageDetail.CustomDetail = customDetail
 	}
 
 	return installedPackageDetail, nil
 }
 
-func (s *Server) buildSecret(installedPackageName, values, targetNamespace string) (*k8scorev1.Secret, error) {
-	// Using this pattern as per:
-	// https://github.com/vmware-tanzu/carvel-kapp-controller/blob/v0.36.1/cli/pkg/kctrl/cmd/package/installed/created_resource_annotations.go#L19
-	// #nosec G101
-	kappctrlSecretName := "%s-%s-values"
+// buildInstalledPackageAppIdentity returns the name of the App of a package
+// install and the label set by kapp on its deployed resources, as reported in
+// the status of the App once deployed.
+func buildInstalledPackageAppIdentity(app *kappctrlv1alpha1.App) *kappcorev1.InstalledPackageAppIdentity {
+	if app == nil {
+		return nil
+	}
+	identity := &kappcorev1.InstalledPackageAppIdentity{
+		AppName: app.Name,
+	}
+	if app.Status.Deploy == nil || app.Status.Deploy.KappDeployStatus == nil {
+		return identity
+	}
+	label := app.Status.Deploy.KappDeployStatus.AssociatedResources.Label
+	if key, value, found := strings.Cut(label, "="); found && key != "" {
+		identity.LabelKey = key
+		identity.LabelValue = value
+		identity.LabelSelector = label
+	}
+	return identity
+}
+
+// setInstalledPackageReconcileTimes reports in the status when the App of the
+// package install was last fetched and deployed, together with its sync
+// interval. The status is flagged as stale when the last successful deploy is
+// older than twice the sync interval, unless the package install is paused.
+func setInstalledPackageReconcileTimes(status *corev1.InstalledPackageStatus, pkgInstall *packagingv1alpha1.PackageInstall, app *kappctrlv1alpha1.App, now time.Time) {
+	syncPeriod := defaultAppSyncPeriod
+	if app.Spec.SyncPeriod != nil {
+		syncPeriod = app.Spec.SyncPeriod.Duration
+	}
+	status.SyncInterval = pkgutils.FromDuration(&metav1.Duration{Duration: syncPeriod})
+
+	if fetch := app.Status.Fetch; fetch != nil && !fetch.UpdatedAt.IsZero() {
+		status.LastFetchTime = fetch.UpdatedAt.UTC().Format(time.RFC3339)
+	}
+	// the deploy status is last updated when the deploy finishes
+	deploy := app.Status.Deploy
+	if deploy == nil || !deploy.Finished || deploy.UpdatedAt.IsZero() {
+		return
+	}
+	status.LastDeployTime = deploy.UpdatedAt.UTC().Format(time.RFC3339)
+
+	succeeded := deploy.ExitCode == 0 && deploy.Error == ""
+	paused := pkgInstall.Spec.Paused || pkgInstall.Spec.Canceled
+	status.Stale = succeeded && !paused && now.Sub(deploy.UpdatedAt.Time) > 2*syncPeriod
+}
+
+// buildSecret builds the Secret holding the values of a request, together with
+// the given additional keys, which are recorded as set through the plugin.
+func (s *Server) buildSecret(installedPackageName, values, targetNamespace string, data map[string][]byte) (*k8scorev1.Secret, error) {
+	secret := s.buildValuesSecret(valuesSecretName(installedPackageName, targetNamespace), values, targetNamespace)
+	keys := []string{}
+	for key, value := range data {
+		secret.Data[key] = value
+		keys = append(keys, key)
+	}
+	if len(keys) > 0 {
+		sort.Strings(keys)
+		secret.Annotations[annotationValuesSecretKeysKey] = strings.Join(keys, ",")
+	}
+	return secret, nil
+}
 
+// buildValuesSecret builds a Secret, managed by the plugin, holding the given values.
+func (s *Server) buildValuesSecret(name, values, targetNamespace string) *k8scorev1.Secret {
 	return &k8scorev1.Secret{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       k8scorev1.ResourceSecrets.String(),
 			APIVersion: k8scorev1.SchemeGroupVersion.WithResource(k8scorev1.ResourceSecrets.String()).String(),
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      fmt.Sprintf(kappctrlSecretName, installedPackageName, targetNamespace),
-			Namespace: targetNamespace,
+			Name:        name,
+			Namespace:   targetNamespace,
+			Annotations: map[string]string{annotationManagedByKey: annotationManagedByValue},
 		},
 		Data: map[string][]byte{
-			// Using "values.yaml" as per:
-			// https://github.com/vmware-tanzu/carvel-kapp-controller/blob/v0.32.0/cli/pkg/kctrl/cmd/package/installed/create_or_update.go#L32
-			"values.yaml": []byte(values),
+			valuesSecretKey: []byte(values),
 		},
 		Type: "Opaque",
-	}, nil
+	}
 }
 
-func (s *Server) buildPkgInstall(installedPackageName, targetCluster, targetNamespace, packageRefName, pkgVersion string, reconciliationOptions *corev1.ReconciliationOptions, secret *k8scorev1.Secret) (*packagingv1alpha1.PackageInstall, error) {
+// buildPkgInstallValuesEntries builds the values entries declared in the custom
+// detail of a request, along with the Secrets holding the values managed by the plugin.
+func (s *Server) buildPkgInstallValuesEntries(installedPackageName, targetNamespace string, values []*kappcorev1.PackageInstallValues) ([]pkgInstallValuesEntry, error) {
+	entries := []pkgInstallValuesEntry{}
+	for i, v := range values {
+		// the managed entries of an InstalledPackageDetail, such as when cloning
+		// an installed package, are re-created from their values, except the one
+		// holding the values of the request, which replace it, while the other
+		// entries keep referencing their Secret
+		if v.GetManaged() && v.GetValues() != "" {
+			if isRequestValuesSecretName(v.GetSecretName()) {
+				continue
+			}
+			v = &kappcorev1.PackageInstallValues{Layer: v.GetLayer(), Values: v.GetValues()}
+		}
+		if v.GetLayer() == kappcorev1.PackageInstallValuesLayer_PACKAGE_INSTALL_VALUES_LAYER_UNSPECIFIED {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("The values entry %d has no layer", i))
+		}
+		if v.GetValues() == "" && v.GetSecretName() == "" {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("The values entry %d must provide either the values or a secretName", i))
+		}
+		entry := pkgInstallValuesEntry{layer: v.GetLayer()}
+		if v.GetSecretName() != "" {
+			entry.ref = packagingv1alpha1.PackageInstallValues{
+				SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{
+					Name: v.GetSecretName(),
+					Key:  v.GetSecretKey(),
+				},
+			}
+		} else {
+			entry.secret = s.buildValuesSecret(layeredValuesSecretName(installedPackageName, targetNamespace, v.GetLayer(), i), v.GetValues(), targetNamespace)
+			entry.ref = packagingv1alpha1.PackageInstallValues{
+				SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{Name: entry.secret.Name},
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// buildServiceAccountResources builds the ServiceAccount, Role and RoleBinding used to reconcile
+// a PackageInstall, similar to the ones created by the kctrl CLI. When using the cluster-admin
+// template, no Role is built and the RoleBinding refers to the cluster-admin ClusterRole instead.
+func (s *Server) buildServiceAccountResources(installedPackageName, targetNamespace string) (*k8scorev1.ServiceAccount, *rbacv1.Role, *rbacv1.RoleBinding) {
+	// Using a similar pattern as per:
+	// https://github.com/vmware-tanzu/carvel-kapp-controller/blob/v0.36.1/cli/pkg/kctrl/cmd/package/installed/created_resource_annotations.go#L19
+	objectMeta := func(nameFormat string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{
+			Name:        fmt.Sprintf(nameFormat, installedPackageName, targetNamespace),
+			Namespace:   targetNamespace,
+			Annotations: map[string]string{annotationManagedByKey: annotationManagedByValue},
+		}
+	}
+
+	serviceAccount := &k8scorev1.ServiceAccount{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ServiceAccount",
+			APIVersion: k8scorev1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: objectMeta("%s-%s-sa"),
+	}
+
+	roleBinding := &rbacv1.RoleBinding{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "RoleBinding",
+			APIVersion: rbacv1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: objectMeta("%s-%s-rolebinding"),
+		Subjects: []rbacv1.Subject{{
+			Kind:      rbacv1.ServiceAccountKind,
+			Name:      serviceAccount.Name,
+			Namespace: targetNamespace,
+		}},
+	}
+
+	if s.config().serviceAccountRoleTemplate == serviceAccountRoleTemplateClusterAdmin {
+		roleBinding.RoleRef = rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     "cluster-admin",
+		}
+		return serviceAccount, nil, roleBinding
+	}
+
+	role := &rbacv1.Role{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Role",
+			APIVersion: rbacv1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: objectMeta("%s-%s-role"),
+		Rules: []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{""},
+				Resources: []string{"configmaps", "secrets", "services", "serviceaccounts", "pods", "persistentvolumeclaims"},
+				Verbs:     []string{rbacv1.VerbAll},
+			},
+			{
+				APIGroups: []string{"apps", "batch", "autoscaling", "policy", "networking.k8s.io"},
+				Resources: []string{rbacv1.ResourceAll},
+				Verbs:     []string{rbacv1.VerbAll},
+			},
+		},
+	}
+	roleBinding.RoleRef = rbacv1.RoleRef{
+		APIGroup: rbacv1.GroupName,
+		Kind:     "Role",
+		Name:     role.Name,
+	}
+	return serviceAccount, role, roleBinding
+}
+
+// buildImagePullSecret builds a placeholder image pull Secret for an installed package.
+// secretgen-controller fills in the placeholder with the registry credentials of every
+// SecretExport targeting the namespace, see:
+// https://carvel.dev/secretgen-controller/docs/latest/secret-export/#using-placeholder-secrets
+func (s *Server) buildImagePullSecret(installedPackageName, targetNamespace string) *k8scorev1.Secret {
+	return &k8scorev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Secret",
+			APIVersion: k8scorev1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-%s-registry-creds", installedPackageName, targetNamespace),
+			Namespace: targetNamespace,
+			Annotations: map[string]string{
+				annotationManagedByKey:       annotationManagedByValue,
+				annotationImagePullSecretKey: "",
+			},
+		},
+		Type: k8scorev1.SecretTypeDockerConfigJson,
+		Data: map[string][]byte{
+			// an empty docker config, as required by the Secret type, until it is filled in
+			k8scorev1.DockerConfigJsonKey: []byte("{}"),
+		},
+	}
+}
+
+// buildImagePullSecretImport builds the SecretImport of the registry credentials configured
+// by the administrator, if any, so that secretgen-controller copies them into the target
+// namespace as an image pull Secret with the same name, once exported to it, see:
+// https://carvel.dev/secretgen-controller/docs/latest/secret-export/
+func (s *Server) buildImagePullSecretImport(targetNamespace string) *unstructured.Unstructured {
+	options := s.config().imagePullSecretImport
+	if options.Name == "" {
+		return nil
+	}
+	fromNamespace := options.Namespace
+	if fromNamespace == "" {
+		fromNamespace = s.config().globalPackagingNamespace
+	}
+	secretImport := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"fromNamespace": fromNamespace,
+			},
+		},
+	}
+	secretImport.SetGroupVersionKind(secretgenGroupVersion.WithKind(secretImportResource))
+	secretImport.SetName(options.Name)
+	secretImport.SetNamespace(targetNamespace)
+	secretImport.SetAnnotations(map[string]string{
+		annotationManagedByKey: annotationManagedByValue,
+	})
+	return secretImport
+}
+
+// buildSecretExport builds the SecretExport of a repository Secret managed by the plugin,
+// exporting it to all the namespaces so that secretgen-controller fills in the placeholder
+// image pull Secrets of the packages installed from the repository. It is owned by the
+// Secret, to be garbage collected along with it.
+func (s *Server) buildSecretExport(pkgSecret *k8scorev1.Secret) *unstructured.Unstructured {
+	secretExport := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"toNamespaces": []interface{}{"*"},
+			},
+		},
+	}
+	secretExport.SetGroupVersionKind(secretgenGroupVersion.WithKind(secretExportResource))
+	secretExport.SetName(pkgSecret.GetName())
+	secretExport.SetNamespace(pkgSecret.GetNamespace())
+	secretExport.SetAnnotations(map[string]string{
+		annotationManagedByKey: annotationManagedByValue,
+	})
+	secretExport.SetOwnerReferences([]metav1.OwnerReference{
+		*metav1.NewControllerRef(pkgSecret, k8scorev1.SchemeGroupVersion.WithKind("Secret")),
+	})
+	return secretExport
+}
+
+// installedPackageMetadataOptions returns the configured options for the labels
+// and annotations of the PackageInstalls, also protecting the annotations
+// managed by this plugin.
+func (s *Server) installedPackageMetadataOptions() resources.InstalledPackageMetadataOptions {
+	options := s.config().installedPackageMetadata
+	options.ProtectedPrefixes = append([]string{kappctrlpackageinstall.DowngradableAnnKey}, options.ProtectedPrefixes...)
+	return options
+}
+
+// setPkgInstallMetadata replaces the labels and annotations of a PackageInstall
+// with the requested ones, keeping the protected ones.
+func setPkgInstallMetadata(pkgInstall *packagingv1alpha1.PackageInstall, metadata *corev1.InstalledPackageMetadata, options resources.InstalledPackageMetadataOptions) {
+	pkgInstall.Labels = resources.MergeInstalledPackageMetadata(pkgInstall.Labels, metadata.GetLabels(), options)
+	pkgInstall.Annotations = resources.MergeInstalledPackageMetadata(pkgInstall.Annotations, metadata.GetAnnotations(), options)
+}
+
+func (s *Server) buildPkgInstall(installedPackageName, targetCluster, targetNamespace, packageRefName, pkgVersion string, reconciliationOptions *corev1.ReconciliationOptions, allowDowngrades bool, valuesEntries []pkgInstallValuesEntry) (*packagingv1alpha1.PackageInstall, error) {
 	// Calculate the constraints and prerelease fields
-	versionConstraints, err := pkgutils.VersionConstraintWithUpgradePolicy(pkgVersion, s.pluginConfig.defaultUpgradePolicy)
+	versionConstraints, err := pkgutils.VersionConstraintWithUpgradePolicy(pkgVersion, s.config().defaultUpgradePolicy)
 	if err != nil {
 		return nil, err
 	}
-	prereleases := prereleasesVersionSelection(s.pluginConfig.defaultPrereleasesVersionSelection)
+	prereleases := prereleasesVersionSelection(s.config().defaultPrereleasesVersionSelection)
 
 	versionSelection := &vendirversions.VersionSelectionSemver{
 		Constraints: versionConstraints,
@@ -397,7 +747,7 @@ func (s *Server) buildPkgInstall(installedPackageName, targetCluster, targetName
 
 	// Allow this PackageInstall to be downgraded
 	// https://carvel.dev/kapp-controller/docs/v0.32.0/package-consumer-concepts/#downgrading
-	if s.pluginConfig.defaultAllowDowngrades {
+	if allowDowngrades {
 		pkgInstall.ObjectMeta.Annotations[kappctrlpackageinstall.DowngradableAnnKey] = ""
 	}
 
@@ -409,15 +759,11 @@ func (s *Server) buildPkgInstall(installedPackageName, targetCluster, targetName
 		pkgInstall.Spec.Paused = reconciliationOptions.Suspend
 	}
 
-	if secret != nil {
-		// Similar logic as in https://github.com/vmware-tanzu/carvel-kapp-controller/blob/v0.32.0/cli/pkg/kctrl/cmd/package/installed/create_or_update.go#L505
-		pkgInstall.Spec.Values = []packagingv1alpha1.PackageInstallValues{{
-			SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{
-				// The secret name should have the format: <name>-<namespace> as per:
-				// https://github.com/vmware-tanzu/carvel-kapp-controller/blob/v0.32.0/cli/pkg/kctrl/cmd/package/installed/created_resource_annotations.go#L19
-				Name: secret.Name,
-			},
-		}}
+	// Similar logic as in https://github.com/vmware-tanzu/carvel-kapp-controller/blob/v0.32.0/cli/pkg/kctrl/cmd/package/installed/create_or_update.go#L505
+	if len(valuesEntries) > 0 {
+		if err := setPkgInstallValues(pkgInstall, valuesEntries); err != nil {
+			return nil, err
+		}
 	}
 
 	return pkgInstall, nil
@@ -439,7 +785,7 @@ func (s *Server) buildPackageRepositorySummary(pkgRepository *packagingv1alpha1.
 		},
 		Name:            pkgRepository.Name,
 		Description:     k8sutils.GetDescription(&pkgRepository.ObjectMeta),
-		NamespaceScoped: s.pluginConfig.globalPackagingNamespace != pkgRepository.Namespace,
+		NamespaceScoped: s.config().globalPackagingNamespace != pkgRepository.Namespace,
 		RequiresAuth:    repositorySecretRef(pkgRepository) != nil,
 	}
 
@@ -465,19 +811,13 @@ func (s *Server) buildPackageRepositorySummary(pkgRepository *packagingv1alpha1.
 	}
 
 	// extract status
-	if len(pkgRepository.Status.Conditions) > 0 {
-		repository.Status = &corev1.PackageRepositoryStatus{
-			Ready:      pkgRepository.Status.Conditions[0].Type == kappctrlv1alpha1.ReconcileSucceeded,
-			Reason:     statusReason(pkgRepository.Status.Conditions[0]),
-			UserReason: statusUserReason(pkgRepository.Status.Conditions[0], pkgRepository.Status.UsefulErrorMessage),
-		}
-	}
+	repository.Status = buildPackageRepositoryStatus(pkgRepository)
 
 	// result
 	return repository, nil
 }
 
-func (s *Server) buildPackageRepository(pkgRepository *packagingv1alpha1.PackageRepository, pkgSecret *k8scorev1.Secret, cluster string) (*corev1.PackageRepositoryDetail, error) {
+func (s *Server) buildPackageRepository(pkgRepository *packagingv1alpha1.PackageRepository, pkgSecret *k8scorev1.Secret, secretExportedTo []string, cluster string) (*corev1.PackageRepositoryDetail, error) {
 
 	// base struct
 	repository := &corev1.PackageRepositoryDetail{
@@ -491,7 +831,7 @@ func (s *Server) buildPackageRepository(pkgRepository *packagingv1alpha1.Package
 		},
 		Name:            pkgRepository.Name,
 		Description:     k8sutils.GetDescription(&pkgRepository.ObjectMeta),
-		NamespaceScoped: s.pluginConfig.globalPackagingNamespace != pkgRepository.Namespace,
+		NamespaceScoped: s.config().globalPackagingNamespace != pkgRepository.Namespace,
 	}
 
 	// synchronization
@@ -539,9 +879,14 @@ func (s *Server) buildPackageRepository(pkgRepository *packagingv1alpha1.Package
 		return nil, fmt.Errorf("the package repository has a fetch directive that is not supported")
 	}
 
-	if customFetch != nil {
+	allowedPackages := pkgutils.SplitPackagePatterns(pkgRepository.Annotations[pkgutils.AnnotationAllowedPackagesKey])
+	deniedPackages := pkgutils.SplitPackagePatterns(pkgRepository.Annotations[pkgutils.AnnotationDeniedPackagesKey])
+	if customFetch != nil || len(allowedPackages) > 0 || len(deniedPackages) > 0 || len(secretExportedTo) > 0 {
 		if customDetail, err := anypb.New(&kappcorev1.KappControllerPackageRepositoryCustomDetail{
-			Fetch: customFetch,
+			Fetch:                      customFetch,
+			AllowedPackages:            allowedPackages,
+			DeniedPackages:             deniedPackages,
+			SecretExportedToNamespaces: secretExportedTo,
 		}); err != nil {
 			return nil, err
 		} else {
@@ -611,23 +956,36 @@ func (s *Server) buildPackageRepository(pkgRepository *packagingv1alpha1.Package
 	}
 
 	// extract status
-	if len(pkgRepository.Status.Conditions) > 0 {
-		repository.Status = &corev1.PackageRepositoryStatus{
-			Ready:      pkgRepository.Status.Conditions[0].Type == kappctrlv1alpha1.ReconcileSucceeded,
-			Reason:     statusReason(pkgRepository.Status.Conditions[0]),
-			UserReason: statusUserReason(pkgRepository.Status.Conditions[0], pkgRepository.Status.UsefulErrorMessage),
-		}
-	}
+	repository.Status = buildPackageRepositoryStatus(pkgRepository)
 
 	// result
 	return repository, nil
 }
 
+// setPackageRepositoryProbe adds the result of probing the repository to its
+// custom detail.
+func setPackageRepositoryProbe(repository *corev1.PackageRepositoryDetail, probe *kappcorev1.PackageRepositoryProbe) error {
+	customDetail := &kappcorev1.KappControllerPackageRepositoryCustomDetail{}
+	if repository.CustomDetail != nil {
+		if err := repository.CustomDetail.UnmarshalTo(customDetail); err != nil {
+			return err
+		}
+	}
+	customDetail.Probe = probe
+
+	any, err := anypb.New(customDetail)
+	if err != nil {
+		return err
+	}
+	repository.CustomDetail = any
+	return nil
+}
+
 func (s *Server) buildPkgRepositoryCreate(request *corev1.AddPackageRepositoryRequest, pkgSecret *k8scorev1.Secret) (*packagingv1alpha1.PackageRepository, error) {
 	// identifier
 	namespace := request.GetContext().GetNamespace()
 	if namespace == "" {
-		namespace = s.pluginConfig.globalPackagingNamespace
+		namespace = s.config().globalPackagingNamespace
 	}
 	name := request.Name
 
@@ -655,6 +1013,10 @@ func (s *Server) buildPkgRepositoryCreate(request *corev1.AddPackageRepositoryRe
 	// description
 	k8sutils.SetDescription(&repository.ObjectMeta, request.Description)
 
+	// package policy
+	pkgutils.SetPackagePatterns(&repository.ObjectMeta, pkgutils.AnnotationAllowedPackagesKey, details.AllowedPackages)
+	pkgutils.SetPackagePatterns(&repository.ObjectMeta, pkgutils.AnnotationDeniedPackagesKey, details.DeniedPackages)
+
 	return repository, nil
 }
 
@@ -686,6 +1048,10 @@ func (s *Server) buildPkgRepositoryUpdate(request *corev1.UpdatePackageRepositor
 	// description
 	k8sutils.SetDescription(&repository.ObjectMeta, request.Description)
 
+	// package policy
+	pkgutils.SetPackagePatterns(&repository.ObjectMeta, pkgutils.AnnotationAllowedPackagesKey, details.AllowedPackages)
+	pkgutils.SetPackagePatterns(&repository.ObjectMeta, pkgutils.AnnotationDeniedPackagesKey, details.DeniedPackages)
+
 	return repository, nil
 }
 
@@ -764,7 +1130,7 @@ func (s *Server) buildPkgRepositorySpec(rptype string, interval string, url stri
 func (s *Server) validatePackageRepositoryCreate(ctx context.Context, cluster string, request *connect.Request[corev1.AddPackageRepositoryRequest]) error {
 	namespace := request.Msg.GetContext().GetNamespace()
 	if namespace == "" {
-		namespace = s.pluginConfig.globalPackagingNamespace
+		namespace = s.config().globalPackagingNamespace
 	}
 
 	if request.Msg.TlsConfig != nil {
@@ -774,7 +1140,7 @@ func (s *Server) validatePackageRepositoryCreate(ctx context.Context, cluster st
 	if request.Msg.Name == "" {
 		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("No request Name provided"))
 	}
-	if request.Msg.NamespaceScoped != (namespace != s.pluginConfig.globalPackagingNamespace) {
+	if request.Msg.NamespaceScoped != (namespace != s.config().globalPackagingNamespace) {
 		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Namespace Scope is inconsistent with the provided Namespace"))
 	}
 
@@ -888,6 +1254,14 @@ func (s *Server) validatePackageRepositoryDetails(rptype string, any *anypb.Any)
 			}
 		}
 	}
+	for _, patterns := range [][]string{details.AllowedPackages, details.DeniedPackages} {
+		if err := pkgutils.ValidatePackagePatterns(patterns); err != nil {
+			return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("The custom details are invalid: %w", err))
+		}
+	}
+	if len(details.TrustedHostKeyFingerprints) > 0 && rptype != typeGIT {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Trusted host key fingerprints are only supported by git repositories"))
+	}
 	return nil
 }
 
@@ -1161,6 +1535,94 @@ func (s *Server) buildPkgRepositorySecretUpdate(pkgSecret *k8scorev1.Secret, nam
 
 // status utils
 
+// buildPackageRepositoryStatus returns the status of the repository, including
+// the whole set of conditions and, for a failed repository, a hint of when
+// kapp-controller will try to sync it again.
+func buildPackageRepositoryStatus(pkgRepository *packagingv1alpha1.PackageRepository) *corev1.PackageRepositoryStatus {
+	if len(pkgRepository.Status.Conditions) == 0 {
+		return nil
+	}
+
+	status := &corev1.PackageRepositoryStatus{
+		Ready:               pkgRepository.Status.Conditions[0].Type == kappctrlv1alpha1.ReconcileSucceeded,
+		Reason:              statusReason(pkgRepository.Status.Conditions[0]),
+		UserReason:          truncateStatusMessage(statusUserReason(pkgRepository.Status.Conditions[0], pkgRepository.Status.UsefulErrorMessage)),
+		ConsecutiveFailures: int32(pkgRepository.Status.ConsecutiveReconcileFailures),
+	}
+	for _, condition := range pkgRepository.Status.Conditions {
+		message := condition.Message
+		if strings.Contains(message, ".status.usefulErrorMessage") && pkgRepository.Status.UsefulErrorMessage != "" {
+			message = pkgRepository.Status.UsefulErrorMessage
+		}
+		status.Conditions = append(status.Conditions, &corev1.PackageRepositoryCondition{
+			Type:    string(condition.Type),
+			Status:  string(condition.Status),
+			Reason:  condition.Reason,
+			Message: truncateStatusMessage(message),
+		})
+	}
+	if status.Reason == corev1.PackageRepositoryStatus_STATUS_REASON_FAILED {
+		if nextRetryTime := pkgRepositoryNextRetryTime(pkgRepository); !nextRetryTime.IsZero() {
+			status.NextRetryTime = timestamppb.New(nextRetryTime)
+		}
+	}
+	return status
+}
+
+// pkgRepositoryNextRetryTime returns the time at which kapp-controller is
+// expected to sync a failed repository again, or the zero time if unknown.
+// kapp-controller backs off exponentially (2^failures seconds) after each
+// consecutive failure, up to the sync period of the repository.
+func pkgRepositoryNextRetryTime(pkgRepository *packagingv1alpha1.PackageRepository) time.Time {
+	fetch := pkgRepository.Status.Fetch
+	if fetch == nil {
+		return time.Time{}
+	}
+	lastAttempt := fetch.UpdatedAt.Time
+	if lastAttempt.IsZero() {
+		lastAttempt = fetch.StartedAt.Time
+	}
+	if lastAttempt.IsZero() {
+		return time.Time{}
+	}
+
+	syncPeriod := defaultPkgRepositorySyncPeriod
+	if pkgRepository.Spec.SyncPeriod != nil {
+		syncPeriod = pkgRepository.Spec.SyncPeriod.Duration
+	}
+	if syncPeriod < minPkgRepositorySyncPeriod {
+		syncPeriod = minPkgRepositorySyncPeriod
+	}
+
+	retryPeriod := syncPeriod
+	// Avoid overflowing the duration for a large number of failures.
+	if failures := pkgRepository.Status.ConsecutiveReconcileFailures; failures > 0 && failures < 30 {
+		if backoff := time.Duration(1<<failures) * time.Second; backoff < syncPeriod {
+			retryPeriod = backoff
+		}
+	}
+	return lastAttempt.Add(retryPeriod)
+}
+
+// truncateStatusMessage truncates the message to maxStatusMessageLength bytes,
+// without splitting a multi-byte character.
+func truncateStatusMessage(message string) string {
+	return truncateMessage(message, maxStatusMessageLength)
+}
+
+// truncateMessage truncates the message to maxLength bytes, without splitting
+// a multi-byte character.
+func truncateMessage(message string, maxLength int) string {
+	if len(message) <= maxLength {
+		return message
+	}
+	cut := maxLength - len(truncatedSuffix)
+	for cut > 0 && !utf8.RuneStart(message[cut]) {
+		cut--
+	}
+	return message[:cut] + truncatedSuffix
+}
+
 func statusReason(status kappctrlv1alpha1.Condition) corev1.PackageRepositoryStatus_StatusReason {
 	switch status.Type {
 	case kappctrlv1alpha1.ReconcileSucceeded:
@@ -0,0 +1,296 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	corev1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	kappcorev1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/plugins/kapp_controller/packages/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/connecterror"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/netrestrict"
+	httpclient "github.com/vmware-tanzu/kubeapps/pkg/http-client"
+	k8scorev1 "k8s.io/api/core/v1"
+	log "k8s.io/klog/v2"
+)
+
+// repositoryProbeTimeout bounds the time spent probing a package repository
+// when getting its detail.
+const repositoryProbeTimeout = 10 * time.Second
+
+// repositoryCredentials are the credentials, taken from the repository
+// secret, used when connecting to a package repository.
+type repositoryCredentials struct {
+	username string
+	password string
+	token    string
+}
+
+// testPkgRepositoryConnection checks that the package repository with the
+// given type and url can be fetched with the credentials of the given secret,
+// which is never stored in the cluster.
+func (s *Server) testPkgRepositoryConnection(ctx context.Context, rptype, url string, pkgSecret *k8scorev1.Secret) (*corev1.TestPackageRepositoryConnectionResponse, error) {
+	creds, err := credentialsFromSecret(pkgSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	// the repository URL is chosen by the user, so the requests cannot reach
+	// the addresses only exposed to the plugin itself
+	restrictions, err := netrestrict.New(s.config().repositoryConnectionAllowedNetworks)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	switch rptype {
+	case typeImgPkgBundle, typeImage:
+		return testImageConnection(ctx, url, creds, restrictions.Transport(remote.DefaultTransport))
+	case typeGIT:
+		return testGitConnection(ctx, url, creds, restrictions.Client(httpclient.New()))
+	case typeHTTP:
+		return testHTTPConnection(ctx, url, creds, restrictions.Client(httpclient.New()))
+	default:
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Invalid repository Type"))
+	}
+}
+
+// probePkgRepository fetches the package repository with the credentials of
+// its secret, as when testing its connection, and reports any failure in the
+// returned probe rather than as an error.
+func (s *Server) probePkgRepository(ctx context.Context, rptype, url string, pkgSecret *k8scorev1.Secret) *kappcorev1.PackageRepositoryProbe {
+	ctx, cancel := context.WithTimeout(ctx, repositoryProbeTimeout)
+	defer cancel()
+
+	response, err := s.testPkgRepositoryConnection(ctx, rptype, url, pkgSecret)
+	if err != nil {
+		probe := &kappcorev1.PackageRepositoryProbe{
+			Message:   err.Error(),
+			ErrorCode: connect.CodeOf(err).String(),
+		}
+		var connectErr *connect.Error
+		if errors.As(err, &connectErr) {
+			probe.Message = connectErr.Message()
+		}
+		return probe
+	}
+	return &kappcorev1.PackageRepositoryProbe{
+		Success:  true,
+		Message:  response.GetMessage(),
+		Metadata: response.GetMetadata(),
+	}
+}
+
+// testImageConnection checks that the image, or imgpkg bundle, can be found in
+// its registry, without pulling its layers.
+func testImageConnection(ctx context.Context, url string, creds *repositoryCredentials, roundTripper http.RoundTripper) (*corev1.TestPackageRepositoryConnectionResponse, error) {
+	ref, err := name.ParseReference(url)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Invalid image reference %q: %w", url, err))
+	}
+
+	auth := authn.Anonymous
+	if creds != nil {
+		auth = authn.FromConfig(authn.AuthConfig{
+			Username:      creds.username,
+			Password:      creds.password,
+			RegistryToken: creds.token,
+		})
+	}
+
+	desc, err := remote.Head(ref, remote.WithContext(ctx), remote.WithAuth(auth), remote.WithTransport(roundTripper))
+	if err != nil {
+		var transportErr *transport.Error
+		if errors.As(err, &transportErr) {
+			log.Errorf("+kapp-controller failed to request the image: %v", transportErr)
+			return nil, connecterror.FromHTTPStatus(url, transportErr.StatusCode)
+		}
+		return nil, connectionError(url, err)
+	}
+
+	metadata := map[string]string{"digest": desc.Digest.String()}
+	// not every registry allows listing the tags, in which case their number is unknown
+	if tags, err := remote.List(ref.Context(), remote.WithContext(ctx), remote.WithAuth(auth), remote.WithTransport(roundTripper)); err == nil {
+		metadata["tags"] = strconv.Itoa(len(tags))
+	}
+
+	return &corev1.TestPackageRepositoryConnectionResponse{
+		Message:  fmt.Sprintf("Found the image '%s'", ref.Name()),
+		Metadata: metadata,
+	}, nil
+}
+
+// testGitConnection checks that the git repository can be cloned by
+// requesting its references, using the git smart HTTP protocol.
+func testGitConnection(ctx context.Context, url string, creds *repositoryCredentials, client *http.Client) (*corev1.TestPackageRepositoryConnectionResponse, error) {
+	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("Not supported yet: testing the connection to a git repository not served over http(s)"))
+	}
+	infoRefsURL := strings.TrimSuffix(url, "/") + "/info/refs?service=git-upload-pack"
+
+	res, err := doRepositoryRequest(ctx, client, http.MethodGet, infoRefsURL, creds)
+	if err != nil {
+		return nil, connectionError(url, err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		log.Errorf("+kapp-controller failed to request the git references: %s", res.Status)
+		return nil, connecterror.FromHTTPStatus(url, res.StatusCode)
+	}
+
+	revision, err := gitHeadRevision(res.Body)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("Unable to read the git references from '%s': %w", url, err))
+	}
+
+	response := &corev1.TestPackageRepositoryConnectionResponse{
+		Message:  fmt.Sprintf("Found the git repository '%s'", url),
+		Metadata: map[string]string{},
+	}
+	if revision != "" {
+		response.Metadata["revision"] = revision
+	}
+	return response, nil
+}
+
+// testHTTPConnection checks that the archive served over http can be
+// retrieved, without downloading it.
+func testHTTPConnection(ctx context.Context, url string, creds *repositoryCredentials, client *http.Client) (*corev1.TestPackageRepositoryConnectionResponse, error) {
+	res, err := doRepositoryRequest(ctx, client, http.MethodHead, url, creds)
+	if err == nil && res.StatusCode == http.StatusMethodNotAllowed {
+		// Some servers do not support HEAD requests, in which case the archive
+		// is requested but its body is not read.
+		res.Body.Close()
+		res, err = doRepositoryRequest(ctx, client, http.MethodGet, url, creds)
+	}
+	if err != nil {
+		return nil, connectionError(url, err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		log.Errorf("+kapp-controller failed to request the archive: %s", res.Status)
+		return nil, connecterror.FromHTTPStatus(url, res.StatusCode)
+	}
+
+	metadata := map[string]string{}
+	if lastModified := res.Header.Get("Last-Modified"); lastModified != "" {
+		metadata["lastModified"] = lastModified
+	}
+	if etag := res.Header.Get("ETag"); etag != "" {
+		metadata["etag"] = etag
+	}
+	return &corev1.TestPackageRepositoryConnectionResponse{
+		Message:  fmt.Sprintf("Found the archive '%s'", url),
+		Metadata: metadata,
+	}, nil
+}
+
+// connectionError returns the error of a failed request to a package
+// repository, telling apart the addresses which are not allowed from the
+// unreachable ones.
+func connectionError(url string, err error) error {
+	if errors.Is(err, netrestrict.ErrNotAllowed) {
+		return connect.NewError(connect.CodePermissionDenied, fmt.Errorf("Connecting to '%s' is not allowed: %w", url, err))
+	}
+	return connect.NewError(connect.CodeUnavailable, fmt.Errorf("Unable to connect to '%s': %w", url, err))
+}
+
+func doRepositoryRequest(ctx context.Context, client *http.Client, method, url string, creds *repositoryCredentials) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if creds != nil {
+		if creds.token != "" {
+			req.Header.Set("Authorization", "Bearer "+creds.token)
+		} else {
+			req.SetBasicAuth(creds.username, creds.password)
+		}
+	}
+	return client.Do(req)
+}
+
+// gitHeadRevision reads the pkt-lines advertised by a git server and returns
+// the revision HEAD points to, if any.
+// See https://git-scm.com/docs/http-protocol#_smart_clients
+func gitHeadRevision(body io.Reader) (string, error) {
+	reader := bufio.NewReader(body)
+	for {
+		length := make([]byte, 4)
+		if _, err := io.ReadFull(reader, length); err != nil {
+			if err == io.EOF {
+				return "", nil
+			}
+			return "", err
+		}
+		size, err := strconv.ParseUint(string(length), 16, 16)
+		if err != nil {
+			return "", fmt.Errorf("invalid pkt-line length %q", length)
+		}
+		if size < 4 {
+			// flush packets separate the service announcement from the references
+			continue
+		}
+		line := make([]byte, size-4)
+		if _, err := io.ReadFull(reader, line); err != nil {
+			return "", err
+		}
+		ref := strings.TrimSuffix(string(line), "\n")
+		if strings.HasPrefix(ref, "#") {
+			continue
+		}
+		// the first reference also includes the server capabilities
+		ref, _, _ = strings.Cut(ref, "\x00")
+		if revision, refName, found := strings.Cut(ref, " "); found && refName == "HEAD" {
+			return revision, nil
+		}
+	}
+}
+
+// credentialsFromSecret returns the credentials stored in the repository secret,
+// or nil if there is no secret.
+func credentialsFromSecret(pkgSecret *k8scorev1.Secret) (*repositoryCredentials, error) {
+	if pkgSecret == nil {
+		return nil, nil
+	}
+
+	switch {
+	case secretValue(pkgSecret, k8scorev1.SSHAuthPrivateKey) != "":
+		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("Not supported yet: testing the connection to a package repository using SSH credentials"))
+	case secretValue(pkgSecret, k8scorev1.DockerConfigJsonKey) != "":
+		docker, err := fromDockerConfig([]byte(secretValue(pkgSecret, k8scorev1.DockerConfigJsonKey)))
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("The docker credentials are invalid: %w", err))
+		}
+		return &repositoryCredentials{username: docker.Username, password: docker.Password}, nil
+	case secretValue(pkgSecret, bearerAuthToken) != "":
+		return &repositoryCredentials{token: secretValue(pkgSecret, bearerAuthToken)}, nil
+	default:
+		return &repositoryCredentials{
+			username: secretValue(pkgSecret, k8scorev1.BasicAuthUsernameKey),
+			password: secretValue(pkgSecret, k8scorev1.BasicAuthPasswordKey),
+		}, nil
+	}
+}
+
+// secretValue returns the value of the given key, whether the secret was read
+// from the cluster or built but not created yet.
+func secretValue(secret *k8scorev1.Secret, key string) string {
+	if value, ok := secret.StringData[key]; ok {
+		return value
+	}
+	return string(secret.Data[key])
+}
@@ -0,0 +1,172 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	kappctrlv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	packagingv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/packaging/v1alpha1"
+	corev1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	kappcorev1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/plugins/kapp_controller/packages/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/pkgutils"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	k8scorev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	log "k8s.io/klog/v2"
+)
+
+const (
+	// reconcileHistoryKey is the key of the ConfigMap holding the reconcile
+	// outcomes of a PackageInstall, serialized as a
+	// GetInstalledPackageReconcileHistoryResponse.
+	reconcileHistoryKey = "history.json"
+	// maxReconcileErrorLength bounds the error recorded for each reconcile
+	// outcome, so that the whole history fits in a ConfigMap.
+	maxReconcileErrorLength = 512
+)
+
+// reconcileHistoryConfigMapName returns the name of the ConfigMap holding the
+// reconcile history of the PackageInstall.
+func reconcileHistoryConfigMapName(installedPackageName, namespace string) string {
+	return fmt.Sprintf("%s-%s-reconcile-history", installedPackageName, namespace)
+}
+
+// buildReconcileOutcome returns the outcome of the last reconciliation of the
+// App of the package install, which spans from the start of its fetch to the
+// end of its last phase. No outcome is returned while the App is reconciling.
+func buildReconcileOutcome(pkgInstall *packagingv1alpha1.PackageInstall, app *kappctrlv1alpha1.App) (*kappcorev1.ReconcileOutcome, bool) {
+	if app == nil || app.Status.Fetch == nil || app.Status.Fetch.StartedAt.IsZero() {
+		return nil, false
+	}
+	status := buildInstalledPackageOperationStatus(pkgInstall, app, 0, time.Time{}).GetStatus()
+	if status.GetReason() == corev1.InstalledPackageStatus_STATUS_REASON_PENDING {
+		return nil, false
+	}
+
+	startedAt := app.Status.Fetch.StartedAt
+	finishedAt := app.Status.Fetch.UpdatedAt
+	// the template and deploy statuses older than the fetch belong to a previous reconciliation
+	if template := app.Status.Template; template != nil && !template.UpdatedAt.Before(&startedAt) && finishedAt.Before(&template.UpdatedAt) {
+		finishedAt = template.UpdatedAt
+	}
+	if deploy := app.Status.Deploy; deploy != nil && !deploy.StartedAt.Before(&startedAt) && finishedAt.Before(&deploy.UpdatedAt) {
+		finishedAt = deploy.UpdatedAt
+	}
+
+	outcome := &kappcorev1.ReconcileOutcome{
+		Version:          pkgInstall.Status.LastAttemptedVersion,
+		Succeeded:        status.GetReason() == corev1.InstalledPackageStatus_STATUS_REASON_INSTALLED,
+		StartedAt:        startedAt.UTC().Format(time.RFC3339),
+		FinishedAt:       finishedAt.UTC().Format(time.RFC3339),
+		Duration:         pkgutils.FromDuration(&metav1.Duration{Duration: finishedAt.Sub(startedAt.Time)}),
+		ConsecutiveCount: int32(app.Status.ConsecutiveReconcileSuccesses),
+	}
+	if !outcome.Succeeded {
+		outcome.Error = truncateMessage(status.GetUserReason(), maxReconcileErrorLength)
+		outcome.ConsecutiveCount = int32(app.Status.ConsecutiveReconcileFailures)
+	}
+	return outcome, true
+}
+
+// mergeReconcileOutcome adds the outcome at the beginning of the history,
+// keeping at most limit outcomes, and returns whether the history changed. An
+// outcome already recorded, identified by its start time, is replaced.
+func mergeReconcileOutcome(history []*kappcorev1.ReconcileOutcome, outcome *kappcorev1.ReconcileOutcome, limit int) ([]*kappcorev1.ReconcileOutcome, bool) {
+	if len(history) > 0 && history[0].GetStartedAt() == outcome.GetStartedAt() {
+		if proto.Equal(history[0], outcome) {
+			return history, false
+		}
+		history = history[1:]
+	} else if len(history) > 0 && history[0].GetStartedAt() > outcome.GetStartedAt() {
+		// an outcome older than the recorded ones is not recorded again
+		return history, false
+	}
+
+	merged := append([]*kappcorev1.ReconcileOutcome{outcome}, history...)
+	if len(merged) > limit {
+		merged = merged[:limit]
+	}
+	return merged, true
+}
+
+// getReconcileHistory returns the recorded reconcile outcomes of the package
+// install, along with the ConfigMap holding them, if it exists.
+func (s *Server) getReconcileHistory(ctx context.Context, headers http.Header, cluster string, pkgInstall *packagingv1alpha1.PackageInstall) ([]*kappcorev1.ReconcileOutcome, *k8scorev1.ConfigMap, error) {
+	typedClient, err := s.clientGetter.Typed(headers, cluster)
+	if err != nil {
+		return nil, nil, err
+	}
+	configMap, err := typedClient.CoreV1().ConfigMaps(pkgInstall.Namespace).Get(ctx, reconcileHistoryConfigMapName(pkgInstall.Name, pkgInstall.Namespace), metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+
+	history := &kappcorev1.GetInstalledPackageReconcileHistoryResponse{}
+	if err := protojson.Unmarshal([]byte(configMap.Data[reconcileHistoryKey]), history); err != nil {
+		// the history is recorded again from scratch rather than failing
+		log.Warningf("Ignoring the invalid reconcile history of the PackageInstall %s/%s: %v", pkgInstall.Namespace, pkgInstall.Name, err)
+		return nil, configMap, nil
+	}
+	return history.GetOutcomes(), configMap, nil
+}
+
+// recordReconcileOutcome records the outcome of the last reconciliation of the
+// package install in its reconcile history, bounded by the
+// reconcileHistoryLimit configuration, and returns the resulting history.
+// The history is kept in a ConfigMap owned by the PackageInstall, so that it
+// is deleted with it. Only an error reading the history is returned, since
+// failing to write it, for instance for lack of permissions, should not
+// prevent returning the history.
+func (s *Server) recordReconcileOutcome(ctx context.Context, headers http.Header, cluster string, pkgInstall *packagingv1alpha1.PackageInstall, app *kappctrlv1alpha1.App) ([]*kappcorev1.ReconcileOutcome, error) {
+	history, configMap, err := s.getReconcileHistory(ctx, headers, cluster, pkgInstall)
+	if err != nil {
+		return nil, err
+	}
+	limit := s.config().reconcileHistoryLimit
+	outcome, ok := buildReconcileOutcome(pkgInstall, app)
+	if !ok || limit <= 0 {
+		return history, nil
+	}
+	history, changed := mergeReconcileOutcome(history, outcome, limit)
+	if !changed {
+		return history, nil
+	}
+
+	data, err := protojson.Marshal(&kappcorev1.GetInstalledPackageReconcileHistoryResponse{Outcomes: history})
+	if err != nil {
+		return nil, err
+	}
+	typedClient, err := s.clientGetter.Typed(headers, cluster)
+	if err != nil {
+		return nil, err
+	}
+	if configMap == nil {
+		configMap = &k8scorev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            reconcileHistoryConfigMapName(pkgInstall.Name, pkgInstall.Namespace),
+				Namespace:       pkgInstall.Namespace,
+				Annotations:     map[string]string{annotationManagedByKey: annotationManagedByValue},
+				OwnerReferences: []metav1.OwnerReference{pkgInstallOwnerReference(pkgInstall)},
+			},
+			Data: map[string]string{reconcileHistoryKey: string(data)},
+		}
+		_, err = typedClient.CoreV1().ConfigMaps(pkgInstall.Namespace).Create(ctx, configMap, metav1.CreateOptions{})
+	} else {
+		configMap.Data = map[string]string{reconcileHistoryKey: string(data)}
+		_, err = typedClient.CoreV1().ConfigMaps(pkgInstall.Namespace).Update(ctx, configMap, metav1.UpdateOptions{})
+	}
+	if err != nil {
+		log.V(4).Infof("Unable to record the reconcile outcome of the PackageInstall %s/%s: %v", pkgInstall.Namespace, pkgInstall.Name, err)
+	}
+	return history, nil
+}
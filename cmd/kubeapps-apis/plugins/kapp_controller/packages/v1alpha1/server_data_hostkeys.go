@@ -0,0 +1,278 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/bufbuild/connect-go"
+	corev1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	kappcorev1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/plugins/kapp_controller/packages/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/netrestrict"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// hostKeyScanTimeout bounds the time spent scanning the host keys of the git
+// server of a package repository.
+const hostKeyScanTimeout = 10 * time.Second
+
+// hostKeyAlgorithms are the host key algorithms requested, one connection
+// each, when scanning the host keys of a git server, as ssh-keyscan does.
+var hostKeyAlgorithms = []string{
+	ssh.KeyAlgoED25519,
+	ssh.KeyAlgoECDSA256,
+	ssh.KeyAlgoECDSA384,
+	ssh.KeyAlgoECDSA521,
+	ssh.KeyAlgoRSASHA512,
+}
+
+// errHostKeyScanned aborts the SSH handshake once the host key is received.
+var errHostKeyScanned = errors.New("host key scanned")
+
+// scannedHostKey is a host key offered by the git server of a package repository.
+type scannedHostKey struct {
+	// host is the host as written in a known_hosts file
+	host string
+	key  ssh.PublicKey
+}
+
+// sshHostAddress returns the address, as "host:port", of the server of a git
+// url over SSH, either "ssh://[user@]host[:port]/path" or the scp-like
+// "[user@]host:path".
+func sshHostAddress(repoURL string) (string, error) {
+	if strings.Contains(repoURL, "://") {
+		u, err := url.Parse(repoURL)
+		if err != nil {
+			return "", connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Invalid url %q: %w", repoURL, err))
+		}
+		if u.Scheme != "ssh" && u.Scheme != "git+ssh" {
+			return "", connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("The url %q is not a git url over SSH", repoURL))
+		}
+		if u.Hostname() == "" {
+			return "", connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("The url %q has no host", repoURL))
+		}
+		port := u.Port()
+		if port == "" {
+			port = "22"
+		}
+		return net.JoinHostPort(u.Hostname(), port), nil
+	}
+
+	// scp-like syntax, the host being before the first colon
+	host, _, found := strings.Cut(repoURL, ":")
+	if i := strings.LastIndex(host, "@"); i >= 0 {
+		host = host[i+1:]
+	}
+	if !found || host == "" || strings.Contains(host, "/") {
+		return "", connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("The url %q is not a git url over SSH", repoURL))
+	}
+	return net.JoinHostPort(host, "22"), nil
+}
+
+// hostKeyScanDialer returns the dialer of the host key scans. The address of
+// the git server is chosen by the user, so the scans cannot reach the
+// addresses only exposed to the plugin itself, as for the connection tests.
+func (s *Server) hostKeyScanDialer() (*net.Dialer, error) {
+	restrictions, err := netrestrict.New(s.config().repositoryConnectionAllowedNetworks)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return restrictions.Dialer(), nil
+}
+
+// scanHostKeys returns the host keys offered by the SSH server at the given
+// address, one per key type, without authenticating.
+func scanHostKeys(ctx context.Context, dialer *net.Dialer, address string) ([]scannedHostKey, error) {
+	ctx, cancel := context.WithTimeout(ctx, hostKeyScanTimeout)
+	defer cancel()
+
+	host := knownhosts.Normalize(address)
+	var keys []scannedHostKey
+	var lastErr error
+	for _, algorithm := range hostKeyAlgorithms {
+		key, err := scanHostKey(ctx, dialer, address, algorithm)
+		if errors.Is(err, netrestrict.ErrNotAllowed) {
+			return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("Scanning the host keys of %q is not allowed: %w", address, err))
+		} else if err != nil {
+			lastErr = err
+			continue
+		}
+		duplicate := false
+		for _, k := range keys {
+			duplicate = duplicate || bytes.Equal(k.key.Marshal(), key.Marshal())
+		}
+		if !duplicate {
+			keys = append(keys, scannedHostKey{host: host, key: key})
+		}
+	}
+	if len(keys) == 0 {
+		return nil, connect.NewError(connect.CodeUnavailable, fmt.Errorf("Unable to scan the host keys of %q: %w", address, lastErr))
+	}
+	return keys, nil
+}
+
+// scanHostKey returns the host key of the given algorithm offered by the SSH
+// server at the given address, aborting the handshake once received.
+func scanHostKey(ctx context.Context, dialer *net.Dialer, address, algorithm string) (ssh.PublicKey, error) {
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return nil, err
+		}
+	}
+
+	var hostKey ssh.PublicKey
+	config := &ssh.ClientConfig{
+		User:              "git",
+		HostKeyAlgorithms: []string{algorithm},
+		HostKeyCallback: func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+			hostKey = key
+			return errHostKeyScanned
+		},
+	}
+	_, _, _, err = ssh.NewClientConn(conn, address, config)
+	if hostKey == nil {
+		if err == nil {
+			err = fmt.Errorf("no host key offered for %s", algorithm)
+		}
+		return nil, err
+	}
+	return hostKey, nil
+}
+
+// knownHostKeys returns the keys of the given known_hosts content for the
+// host, as written in a known_hosts file, either in plain text or hashed.
+// Revoked keys and certificate authorities are ignored.
+func knownHostKeys(knownHosts, host string) []ssh.PublicKey {
+	var keys []ssh.PublicKey
+	rest := []byte(knownHosts)
+	for len(rest) > 0 {
+		marker, hosts, key, _, next, err := ssh.ParseKnownHosts(rest)
+		if err != nil {
+			break
+		}
+		rest = next
+		if marker != "" {
+			continue
+		}
+		for _, h := range hosts {
+			if h == host || hashedHostMatches(h, host) {
+				keys = append(keys, key)
+				break
+			}
+		}
+	}
+	return keys
+}
+
+// hashedHostMatches returns whether a hashed known_hosts entry, such as
+// "|1|salt|hash", is the given host.
+func hashedHostMatches(entry, host string) bool {
+	parts := strings.Split(entry, "|")
+	if len(parts) != 4 || parts[0] != "" || parts[1] != "1" {
+		return false
+	}
+	salt, err := base64.StdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return false
+	}
+	hash, err := base64.StdEncoding.DecodeString(parts[3])
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha1.New, salt)
+	mac.Write([]byte(host))
+	return hmac.Equal(mac.Sum(nil), hash)
+}
+
+// buildHostKeysResponse returns the scanned host keys, flagged when already
+// among the given known hosts, and whether the known hosts have keys for the
+// git server none of which is offered anymore.
+func buildHostKeysResponse(keys []scannedHostKey, knownHosts string) *kappcorev1.ScanPackageRepositoryHostKeysResponse {
+	response := &kappcorev1.ScanPackageRepositoryHostKeysResponse{}
+	lines := []string{}
+	anyKnown := false
+	for _, k := range keys {
+		known := false
+		for _, knownKey := range knownHostKeys(knownHosts, k.host) {
+			known = known || bytes.Equal(knownKey.Marshal(), k.key.Marshal())
+		}
+		anyKnown = anyKnown || known
+		response.HostKeys = append(response.HostKeys, &kappcorev1.PackageRepositoryHostKey{
+			Host:        k.host,
+			Type:        k.key.Type(),
+			Fingerprint: ssh.FingerprintSHA256(k.key),
+			Known:       known,
+		})
+		lines = append(lines, knownhosts.Line([]string{k.host}, k.key))
+	}
+	if len(keys) > 0 && !anyKnown {
+		response.HostKeyChanged = len(knownHostKeys(knownHosts, keys[0].host)) > 0
+	}
+	response.KnownHosts = strings.Join(lines, "\n") + "\n"
+	return response
+}
+
+// trustPkgRepositoryHostKeys replaces the known hosts of the SSH credentials
+// of a package repository with the host keys of its git server matching the
+// trusted fingerprints of the custom details, if any, scanning them again
+// rather than trusting the ones sent by the user.
+func (s *Server) trustPkgRepositoryHostKeys(ctx context.Context, repoURL string, auth *corev1.PackageRepositoryAuth, customDetail *anypb.Any) error {
+	if customDetail == nil {
+		return nil
+	}
+	details := &kappcorev1.KappControllerPackageRepositoryCustomDetail{}
+	if err := customDetail.UnmarshalTo(details); err != nil {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("The custom details are invalid: %w", err))
+	}
+	if len(details.TrustedHostKeyFingerprints) == 0 {
+		return nil
+	}
+	if auth.GetType() != corev1.PackageRepositoryAuth_PACKAGE_REPOSITORY_AUTH_TYPE_SSH || auth.GetSecretRef() != nil || auth.GetSshCreds() == nil {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Trusted host key fingerprints require SSH credentials managed by the plugin"))
+	}
+
+	address, err := sshHostAddress(repoURL)
+	if err != nil {
+		return err
+	}
+	dialer, err := s.hostKeyScanDialer()
+	if err != nil {
+		return err
+	}
+	keys, err := scanHostKeys(ctx, dialer, address)
+	if err != nil {
+		return err
+	}
+	lines := []string{}
+	for _, k := range keys {
+		for _, fingerprint := range details.TrustedHostKeyFingerprints {
+			if ssh.FingerprintSHA256(k.key) == fingerprint {
+				lines = append(lines, knownhosts.Line([]string{k.host}, k.key))
+				break
+			}
+		}
+	}
+	if len(lines) == 0 {
+		return connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("None of the host keys of %q matches the trusted fingerprints, scan them again", address))
+	}
+	auth.GetSshCreds().KnownHosts = strings.Join(lines, "\n") + "\n"
+	return nil
+}
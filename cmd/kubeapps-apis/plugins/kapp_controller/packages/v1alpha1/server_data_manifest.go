@@ -0,0 +1,217 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/bufbuild/connect-go"
+	kappctrlv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	packagingv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/packaging/v1alpha1"
+	vendirversions "github.com/vmware-tanzu/carvel-vendir/pkg/vendir/versions/v1alpha1"
+	kappcorev1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/plugins/kapp_controller/packages/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/connecterror"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/resources"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+)
+
+// getInstalledPackageManifest returns the YAML manifest of the PackageInstall
+// or of the App of an installed package.
+func (s *Server) getInstalledPackageManifest(ctx context.Context, headers http.Header, cluster, namespace, name string, kind kappcorev1.InstalledPackageManifestKind) (string, error) {
+	switch kind {
+	case kappcorev1.InstalledPackageManifestKind_INSTALLED_PACKAGE_MANIFEST_KIND_UNSPECIFIED, kappcorev1.InstalledPackageManifestKind_INSTALLED_PACKAGE_MANIFEST_KIND_PACKAGE_INSTALL:
+		resource, err := s.getPkgInstallResource(headers, cluster, namespace)
+		if err != nil {
+			return "", err
+		}
+		pkgInstall, err := resource.Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return "", connecterror.FromK8sError("get", pkgInstallResource, name, err)
+		}
+		return manifestFromUnstructured(pkgInstall, packagingv1alpha1.SchemeGroupVersion.String(), pkgInstallResource)
+	case kappcorev1.InstalledPackageManifestKind_INSTALLED_PACKAGE_MANIFEST_KIND_APP:
+		resource, err := s.getAppResource(headers, cluster, namespace)
+		if err != nil {
+			return "", err
+		}
+		app, err := resource.Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return "", connecterror.FromK8sError("get", appResource, name, err)
+		}
+		return manifestFromUnstructured(app, kappctrlv1alpha1.SchemeGroupVersion.String(), appResource)
+	default:
+		return "", connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Unsupported manifest kind %q", kind))
+	}
+}
+
+// updatePkgInstallManifest replaces the PackageInstall with the given YAML
+// manifest, once checked that the fields managed by the plugin are unchanged,
+// returning the manifest of the updated PackageInstall. Only a server-side
+// dry-run of the update is applied if requested.
+func (s *Server) updatePkgInstallManifest(ctx context.Context, headers http.Header, cluster, namespace, name, manifest string, dryRun bool) (string, error) {
+	edited := &unstructured.Unstructured{}
+	if err := yaml.Unmarshal([]byte(manifest), &edited.Object); err != nil || edited.Object == nil {
+		return "", connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("The manifest is not a valid YAML object: %v", err))
+	}
+	// The status of a PackageInstall is set by kapp-controller only.
+	unstructured.RemoveNestedField(edited.Object, "status")
+
+	resource, err := s.getPkgInstallResource(headers, cluster, namespace)
+	if err != nil {
+		return "", err
+	}
+	current, err := resource.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", connecterror.FromK8sError("get", pkgInstallResource, name, err)
+	}
+
+	var currentPkgInstall, editedPkgInstall packagingv1alpha1.PackageInstall
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(current.Object, &currentPkgInstall); err != nil {
+		return "", connect.NewError(connect.CodeInternal, err)
+	}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(edited.Object, &editedPkgInstall); err != nil {
+		return "", connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("The manifest is not a valid PackageInstall: %w", err))
+	}
+	if err := checkPkgInstallManifest(&currentPkgInstall, &editedPkgInstall, s.installedPackageMetadataOptions()); err != nil {
+		return "", err
+	}
+
+	options := metav1.UpdateOptions{}
+	if dryRun {
+		options.DryRun = []string{metav1.DryRunAll}
+	}
+	updated, err := resource.Update(ctx, edited, options)
+	if err != nil {
+		return "", manifestUpdateError(name, err)
+	}
+	return manifestFromUnstructured(updated, packagingv1alpha1.SchemeGroupVersion.String(), pkgInstallResource)
+}
+
+// checkPkgInstallManifest returns an InvalidArgument error if the edited
+// PackageInstall changes a field managed by the plugin: its identity, its
+// package and version, its protected labels and annotations, and its values
+// entries referencing the values Secrets of the plugin.
+func checkPkgInstallManifest(current, edited *packagingv1alpha1.PackageInstall, options resources.InstalledPackageMetadataOptions) error {
+	problems := []string{}
+	if edited.APIVersion != packagingv1alpha1.SchemeGroupVersion.String() || edited.Kind != pkgInstallResource {
+		problems = append(problems, fmt.Sprintf("the apiVersion and kind must be %s %s", packagingv1alpha1.SchemeGroupVersion.String(), pkgInstallResource))
+	}
+	if edited.Name != current.Name || edited.Namespace != current.Namespace {
+		problems = append(problems, "the name and namespace cannot be changed")
+	}
+	if packageRefName(edited) != packageRefName(current) {
+		problems = append(problems, "the package (packageRef.refName) cannot be changed")
+	}
+	// The version is changed by updating the installed package, whose
+	// requests are checked against the package pins.
+	if !reflect.DeepEqual(versionSelection(edited), versionSelection(current)) {
+		problems = append(problems, "the version (packageRef.versionSelection) cannot be changed")
+	}
+	problems = append(problems, protectedMetadataChanges("label", current.Labels, edited.Labels, options)...)
+	problems = append(problems, protectedMetadataChanges("annotation", current.Annotations, edited.Annotations, options)...)
+	if !reflect.DeepEqual(pluginManagedValuesSecrets(current), pluginManagedValuesSecrets(edited)) {
+		problems = append(problems, "the values entries referencing the values Secrets managed by Kubeapps cannot be added or removed")
+	}
+	if len(problems) > 0 {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("The manifest changes fields managed by Kubeapps: %s", strings.Join(problems, "; ")))
+	}
+	return nil
+}
+
+// packageRefName returns the name of the package of the PackageInstall.
+func packageRefName(pkgInstall *packagingv1alpha1.PackageInstall) string {
+	if pkgInstall.Spec.PackageRef == nil {
+		return ""
+	}
+	return pkgInstall.Spec.PackageRef.RefName
+}
+
+// versionSelection returns the version selection of the package of the
+// PackageInstall.
+func versionSelection(pkgInstall *packagingv1alpha1.PackageInstall) *vendirversions.VersionSelectionSemver {
+	if pkgInstall.Spec.PackageRef == nil {
+		return nil
+	}
+	return pkgInstall.Spec.PackageRef.VersionSelection
+}
+
+// protectedMetadataChanges returns the protected labels or annotations which
+// are added, removed or changed.
+func protectedMetadataChanges(kind string, current, edited map[string]string, options resources.InstalledPackageMetadataOptions) []string {
+	keys := map[string]bool{}
+	for key := range current {
+		keys[key] = true
+	}
+	for key := range edited {
+		keys[key] = true
+	}
+	changes := []string{}
+	for key := range keys {
+		if !options.IsProtected(key) {
+			continue
+		}
+		currentValue, inCurrent := current[key]
+		editedValue, inEdited := edited[key]
+		if inCurrent != inEdited || currentValue != editedValue {
+			changes = append(changes, fmt.Sprintf("the %s %q is protected", kind, key))
+		}
+	}
+	sort.Strings(changes)
+	return changes
+}
+
+// pluginManagedValuesSecrets returns the sorted names of the values Secrets
+// created by the plugin which are referenced by the PackageInstall, that is,
+// the one of the values of the installed package and those declared with a
+// layer.
+func pluginManagedValuesSecrets(pkgInstall *packagingv1alpha1.PackageInstall) []string {
+	names := []string{}
+	for _, values := range pkgInstall.Spec.Values {
+		if values.SecretRef == nil {
+			continue
+		}
+		name := values.SecretRef.Name
+		if name == valuesSecretName(pkgInstall.Name, pkgInstall.Namespace) || pkgInstallValuesLayer(pkgInstall, name) != kappcorev1.PackageInstallValuesLayer_PACKAGE_INSTALL_VALUES_LAYER_UNSPECIFIED {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// manifestFromUnstructured returns the YAML manifest of the object, without
+// its managed fields.
+func manifestFromUnstructured(object *unstructured.Unstructured, apiVersion, kind string) (string, error) {
+	object = object.DeepCopy()
+	object.SetAPIVersion(apiVersion)
+	object.SetKind(kind)
+	unstructured.RemoveNestedField(object.Object, "metadata", "managedFields")
+	manifest, err := yaml.Marshal(object.Object)
+	if err != nil {
+		return "", connect.NewError(connect.CodeInternal, err)
+	}
+	return string(manifest), nil
+}
+
+// manifestUpdateError returns the error of a rejected update of a manifest,
+// the errors of validation being caused by the manifest.
+func manifestUpdateError(name string, err error) error {
+	switch {
+	case errors.IsInvalid(err), errors.IsBadRequest(err):
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("The manifest of the PackageInstall '%s' is invalid: %w", name, err))
+	case errors.IsConflict(err):
+		return connect.NewError(connect.CodeAborted, fmt.Errorf("The PackageInstall '%s' changed since its manifest was fetched: %w", name, err))
+	default:
+		return connecterror.FromK8sError("update", pkgInstallResource, name, err)
+	}
+}
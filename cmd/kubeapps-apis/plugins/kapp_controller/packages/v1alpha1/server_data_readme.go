@@ -0,0 +1,225 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"archive/tar"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	datapackagingv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apiserver/apis/datapackaging/v1alpha1"
+	k8scorev1 "k8s.io/api/core/v1"
+	log "k8s.io/klog/v2"
+)
+
+const (
+	// bundleReadmeTimeout bounds the time spent fetching the README of a
+	// package from its imgpkg bundle when getting the package detail.
+	bundleReadmeTimeout = 10 * time.Second
+	// bundleReadmeCacheSize is the maximum number of bundle READMEs cached.
+	bundleReadmeCacheSize = 500
+	// maxBundleReadmeBytes is the maximum size of a README read from a bundle,
+	// any content beyond it being truncated.
+	maxBundleReadmeBytes = 1024 * 1024
+)
+
+// bundleReadmeCache caches the READMEs fetched from the imgpkg bundles, keyed
+// by bundle image and, for the bundles pulled with the credentials of a
+// package repository, by the secret holding them, so that a README is only
+// returned to the users who could pull it. Since the images of the bundles
+// referenced by the Package CRs are usually pinned to a digest, their READMEs
+// do not change.
+type bundleReadmeCache struct {
+	mutex   sync.Mutex
+	readmes map[string]string
+	keys    []string
+}
+
+func newBundleReadmeCache() *bundleReadmeCache {
+	return &bundleReadmeCache{readmes: map[string]string{}}
+}
+
+// get returns the cached README of the bundle, if any. A nil cache never
+// returns a README.
+func (c *bundleReadmeCache) get(key string) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	readme, ok := c.readmes[key]
+	return readme, ok
+}
+
+// set caches the README of the bundle, evicting the oldest README once the
+// cache is full.
+func (c *bundleReadmeCache) set(key, readme string) {
+	if c == nil {
+		return
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if _, ok := c.readmes[key]; !ok {
+		if len(c.keys) >= bundleReadmeCacheSize {
+			delete(c.readmes, c.keys[0])
+			c.keys = c.keys[1:]
+		}
+		c.keys = append(c.keys, key)
+	}
+	c.readmes[key] = readme
+}
+
+// bundleReadmeCacheKey returns the key of the README of the bundle pulled
+// with the credentials of the given secret, or anonymously if nil. The
+// resource version of the secret is part of the key so that the README is
+// fetched again once the credentials are changed.
+func bundleReadmeCacheKey(cluster, image string, pkgSecret *k8scorev1.Secret) string {
+	if pkgSecret == nil {
+		return image
+	}
+	return fmt.Sprintf("%s/%s/%s@%s|%s", cluster, pkgSecret.Namespace, pkgSecret.Name, pkgSecret.ResourceVersion, image)
+}
+
+// pkgBundleImage returns the image of the imgpkg bundle fetched by the
+// package template, or an empty string if it is fetched otherwise.
+func pkgBundleImage(pkg *datapackagingv1alpha1.Package) string {
+	if pkg == nil || pkg.Spec.Template.Spec == nil {
+		return ""
+	}
+	for _, fetch := range pkg.Spec.Template.Spec.Fetch {
+		if fetch.ImgpkgBundle != nil {
+			return fetch.ImgpkgBundle.Image
+		}
+	}
+	return ""
+}
+
+// getBundleReadme returns the README.md at the root of the imgpkg bundle of
+// the package, or an empty string if the package is not fetched from a bundle
+// or its bundle has no README. The bundle is pulled with the credentials of the
+// package repository providing the package, if the user can read them.
+func (s *Server) getBundleReadme(ctx context.Context, headers http.Header, cluster string, pkg *datapackagingv1alpha1.Package) (string, error) {
+	image := pkgBundleImage(pkg)
+	if image == "" {
+		return "", nil
+	}
+
+	var creds *repositoryCredentials
+	pkgSecret, err := s.getPkgRepositorySecret(ctx, headers, cluster, pkg)
+	if err == nil {
+		creds, err = credentialsFromSecret(pkgSecret)
+	}
+	if err != nil {
+		log.Warningf("+kapp-controller unable to get the credentials to fetch the bundle %q, fetching it anonymously: %v", image, err)
+		pkgSecret, creds = nil, nil
+	}
+	cacheKey := bundleReadmeCacheKey(cluster, image, pkgSecret)
+	if readme, ok := s.bundleReadmes.get(cacheKey); ok {
+		return readme, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, bundleReadmeTimeout)
+	defer cancel()
+	readme, err := fetchBundleReadme(ctx, image, creds)
+	if err != nil {
+		return "", err
+	}
+	s.bundleReadmes.set(cacheKey, readme)
+	return readme, nil
+}
+
+// getPkgRepositorySecret returns the secret of the package repository
+// referenced by the annotation of the package, or nil if the repository has
+// no secret.
+func (s *Server) getPkgRepositorySecret(ctx context.Context, headers http.Header, cluster string, pkg *datapackagingv1alpha1.Package) (*k8scorev1.Secret, error) {
+	repoNamespace, repoName, found := strings.Cut(pkg.Annotations[REPO_REF_ANNOTATION], "/")
+	if !found || repoName == "" {
+		return nil, nil
+	}
+	pkgRepository, err := s.getPkgRepository(ctx, headers, cluster, repoNamespace, repoName)
+	if err != nil {
+		return nil, err
+	}
+	secretRef := repositorySecretRef(pkgRepository)
+	if secretRef == nil {
+		return nil, nil
+	}
+	return s.getSecret(ctx, headers, cluster, repoNamespace, secretRef.Name)
+}
+
+// fetchBundleReadme pulls the layers of the bundle image until finding a
+// README.md file at its root, returning an empty string if there is none.
+func fetchBundleReadme(ctx context.Context, image string, creds *repositoryCredentials) (string, error) {
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return "", fmt.Errorf("invalid bundle image reference %q: %w", image, err)
+	}
+
+	auth := authn.Anonymous
+	if creds != nil {
+		auth = authn.FromConfig(authn.AuthConfig{
+			Username:      creds.username,
+			Password:      creds.password,
+			RegistryToken: creds.token,
+		})
+	}
+
+	img, err := remote.Image(ref, remote.WithContext(ctx), remote.WithAuth(auth))
+	if err != nil {
+		return "", fmt.Errorf("unable to fetch the bundle %q: %w", image, err)
+	}
+	layers, err := img.Layers()
+	if err != nil {
+		return "", fmt.Errorf("unable to get the layers of the bundle %q: %w", image, err)
+	}
+	for _, layer := range layers {
+		readme, found, err := readmeFromLayer(layer.Uncompressed)
+		if err != nil {
+			return "", fmt.Errorf("unable to read the layers of the bundle %q: %w", image, err)
+		}
+		if found {
+			return readme, nil
+		}
+	}
+	return "", nil
+}
+
+// readmeFromLayer returns the content of the README.md file at the root of the
+// uncompressed tar layer, if found.
+func readmeFromLayer(uncompressed func() (io.ReadCloser, error)) (string, bool, error) {
+	rc, err := uncompressed()
+	if err != nil {
+		return "", false, err
+	}
+	defer rc.Close()
+
+	tr := tar.NewReader(rc)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return "", false, nil
+		}
+		if err != nil {
+			return "", false, err
+		}
+		if header.Typeflag != tar.TypeReg || !strings.EqualFold(path.Clean("/"+header.Name), "/README.md") {
+			continue
+		}
+		content, err := io.ReadAll(io.LimitReader(tr, maxBundleReadmeBytes))
+		if err != nil {
+			return "", false, err
+		}
+		return string(content), true, nil
+	}
+}
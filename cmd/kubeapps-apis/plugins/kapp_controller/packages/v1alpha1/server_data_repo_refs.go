@@ -0,0 +1,202 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	packagingv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/packaging/v1alpha1"
+	datapackagingv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apiserver/apis/datapackaging/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+	log "k8s.io/klog/v2"
+)
+
+// pkgRepoRefsRewatchPeriod is the time waited before listing and watching
+// again the package metadatas or repositories once their watch has ended.
+const pkgRepoRefsRewatchPeriod = 10 * time.Second
+
+// pkgRepoRefCache maps the package metadatas of the cluster to the package
+// repositories providing them, so that the identifiers of the packages resolve
+// with a lookup. It is kept up to date by watching the package metadatas,
+// whose REPO_REF_ANNOTATION is recorded, and the package repositories, whose
+// deletion drops the packages they provided.
+type pkgRepoRefCache struct {
+	mutex sync.RWMutex
+	// repoRefs are the "namespace/name" refs of the package repositories,
+	// keyed by the "namespace/name" of the package metadatas.
+	repoRefs map[string]string
+	// synced is true while the package metadatas are being watched, the
+	// refs being possibly stale otherwise.
+	synced    bool
+	startOnce sync.Once
+}
+
+func newPkgRepoRefCache() *pkgRepoRefCache {
+	return &pkgRepoRefCache{repoRefs: map[string]string{}}
+}
+
+// start watches the package metadatas and repositories of the cluster until
+// the context is done, listing and watching them again whenever a watch ends.
+// Only the first call starts the watches.
+func (c *pkgRepoRefCache) start(ctx context.Context, dynamicClient func(ctx context.Context) (dynamic.Interface, error)) {
+	c.startOnce.Do(func() {
+		go wait.UntilWithContext(ctx, func(ctx context.Context) {
+			client, err := dynamicClient(ctx)
+			if err != nil {
+				log.Warningf("+kapp-controller unable to get the client to watch the PackageMetadatas: %v", err)
+				return
+			}
+			c.watchPkgMetadatas(ctx, client)
+		}, pkgRepoRefsRewatchPeriod)
+		go wait.UntilWithContext(ctx, func(ctx context.Context) {
+			client, err := dynamicClient(ctx)
+			if err != nil {
+				log.Warningf("+kapp-controller unable to get the client to watch the PackageRepositories: %v", err)
+				return
+			}
+			c.watchPkgRepositories(ctx, client)
+		}, pkgRepoRefsRewatchPeriod)
+	})
+}
+
+// watchPkgMetadatas lists the package metadatas to replace the cached refs,
+// then keeps them up to date until the watch ends.
+func (c *pkgRepoRefCache) watchPkgMetadatas(ctx context.Context, client dynamic.Interface) {
+	resource := client.Resource(datapackagingv1alpha1.SchemeGroupVersion.WithResource(pkgMetadatasResource))
+	list, err := resource.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		log.Warningf("+kapp-controller unable to list the PackageMetadatas to cache their repositories: %v", err)
+		return
+	}
+	watcher, err := resource.Watch(ctx, metav1.ListOptions{ResourceVersion: list.GetResourceVersion()})
+	if err != nil {
+		log.Warningf("+kapp-controller unable to watch the PackageMetadatas to cache their repositories: %v", err)
+		return
+	}
+	defer watcher.Stop()
+
+	repoRefs := make(map[string]string, len(list.Items))
+	for _, item := range list.Items {
+		repoRefs[pkgRepoRefKey(item.GetNamespace(), item.GetName())] = item.GetAnnotations()[REPO_REF_ANNOTATION]
+	}
+	c.mutex.Lock()
+	c.repoRefs = repoRefs
+	c.synced = true
+	c.mutex.Unlock()
+	defer c.setSynced(false)
+
+	for event := range watcher.ResultChan() {
+		if event.Type == watch.Error {
+			log.Warningf("+kapp-controller error while watching the PackageMetadatas: %v", event.Object)
+			return
+		}
+		pkgMetadata, ok := event.Object.(*unstructured.Unstructured)
+		if !ok {
+			continue
+		}
+		c.applyPkgMetadataEvent(event.Type, pkgMetadata)
+	}
+}
+
+// watchPkgRepositories drops the refs of the package repositories being
+// deleted until the watch ends.
+func (c *pkgRepoRefCache) watchPkgRepositories(ctx context.Context, client dynamic.Interface) {
+	resource := client.Resource(packagingv1alpha1.SchemeGroupVersion.WithResource(pkgRepositoriesResource))
+	watcher, err := resource.Watch(ctx, metav1.ListOptions{})
+	if err != nil {
+		log.Warningf("+kapp-controller unable to watch the PackageRepositories to cache their packages: %v", err)
+		return
+	}
+	defer watcher.Stop()
+
+	for event := range watcher.ResultChan() {
+		if event.Type == watch.Error {
+			log.Warningf("+kapp-controller error while watching the PackageRepositories: %v", event.Object)
+			return
+		}
+		pkgRepository, ok := event.Object.(*unstructured.Unstructured)
+		if !ok || event.Type != watch.Deleted {
+			continue
+		}
+		c.deletePkgRepository(pkgRepository.GetNamespace(), pkgRepository.GetName())
+	}
+}
+
+// applyPkgMetadataEvent records or drops the ref of the package metadata.
+func (c *pkgRepoRefCache) applyPkgMetadataEvent(eventType watch.EventType, pkgMetadata *unstructured.Unstructured) {
+	key := pkgRepoRefKey(pkgMetadata.GetNamespace(), pkgMetadata.GetName())
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	switch eventType {
+	case watch.Added, watch.Modified:
+		c.repoRefs[key] = pkgMetadata.GetAnnotations()[REPO_REF_ANNOTATION]
+	case watch.Deleted:
+		delete(c.repoRefs, key)
+	}
+}
+
+// deletePkgRepository drops the refs of the packages provided by the package
+// repository.
+func (c *pkgRepoRefCache) deletePkgRepository(namespace, name string) {
+	repoRef := pkgRepoRefKey(namespace, name)
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for key, ref := range c.repoRefs {
+		if ref == repoRef {
+			delete(c.repoRefs, key)
+		}
+	}
+}
+
+func (c *pkgRepoRefCache) setSynced(synced bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.synced = synced
+}
+
+// repoName returns the name of the package repository providing the package
+// with the given refName to the namespace, that is, a package of the
+// namespace or, failing that, of the global packaging namespace. Nothing is
+// returned while the cache is not in sync. A nil cache never returns a name.
+func (c *pkgRepoRefCache) repoName(namespace, globalPackagingNamespace, refName string) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	if !c.synced {
+		return "", false
+	}
+	repoRef, ok := c.repoRefs[pkgRepoRefKey(namespace, refName)]
+	if !ok {
+		repoRef, ok = c.repoRefs[pkgRepoRefKey(globalPackagingNamespace, refName)]
+	}
+	if !ok {
+		return "", false
+	}
+	return getRepoNameFromAnnotation(repoRef), true
+}
+
+func pkgRepoRefKey(namespace, name string) string {
+	return fmt.Sprintf("%s/%s", namespace, name)
+}
+
+// packageIdentifier returns the identifier of the package, resolving its
+// repository from the package repository refs cache when it is in sync with
+// the cluster of the package.
+func (s *Server) packageIdentifier(pkgMetadata *datapackagingv1alpha1.PackageMetadata, cluster string) string {
+	if cluster == s.globalPackagingCluster && s.config().watchPackageRepositoryRefs {
+		if repoName, ok := s.pkgRepoRefs.repoName(pkgMetadata.Namespace, s.config().globalPackagingNamespace, pkgMetadata.Name); ok {
+			return fmt.Sprintf("%s/%s", repoName, pkgMetadata.Name)
+		}
+	}
+	return buildPackageIdentifier(pkgMetadata)
+}
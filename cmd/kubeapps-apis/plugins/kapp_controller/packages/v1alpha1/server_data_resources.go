@@ -5,12 +5,15 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/bufbuild/connect-go"
 	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/connecterror"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/pkgutils"
 	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/resources"
 	"k8s.io/client-go/kubernetes"
 
@@ -21,11 +24,15 @@ import (
 	ctlres "github.com/vmware-tanzu/carvel-kapp/pkg/kapp/resources"
 	corev1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
 	k8scorev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/util/retry"
 
 	log "k8s.io/klog/v2"
 )
@@ -41,8 +48,16 @@ const (
 	pkgInstallsResource     = "packageinstalls"
 	appResource             = "App"
 	appsResource            = "apps"
+	secretExportResource    = "SecretExport"
+	secretExportsResource   = "secretexports"
+	secretImportResource    = "SecretImport"
+	secretImportsResource   = "secretimports"
 )
 
+// secretgenGroupVersion is the group version of the secretgen-controller
+// SecretExport and SecretImport, which are not a dependency of the plugin.
+var secretgenGroupVersion = schema.GroupVersion{Group: "secretgen.carvel.dev", Version: "v1alpha1"}
+
 // Dynamic ResourceInterface getters to encapsulate the logic of getting the proper group version API resources
 
 // See https://carvel.dev/kapp-controller/docs/latest/packaging/#package-cr
@@ -115,6 +130,26 @@ func (s *Server) getAppResource(headers http.Header, cluster, namespace string)
 	return ri, nil
 }
 
+// See https://carvel.dev/secretgen-controller/docs/latest/secret-export/
+func (s *Server) getSecretExportResource(headers http.Header, cluster, namespace string) (dynamic.ResourceInterface, error) {
+	dynClient, err := s.clientGetter.Dynamic(headers, cluster)
+	if err != nil {
+		return nil, err
+	}
+	ri := dynClient.Resource(secretgenGroupVersion.WithResource(secretExportsResource)).Namespace(namespace)
+	return ri, nil
+}
+
+// See https://carvel.dev/secretgen-controller/docs/latest/secret-export/
+func (s *Server) getSecretImportResource(headers http.Header, cluster, namespace string) (dynamic.ResourceInterface, error) {
+	dynClient, err := s.clientGetter.Dynamic(headers, cluster)
+	if err != nil {
+		return nil, err
+	}
+	ri := dynClient.Resource(secretgenGroupVersion.WithResource(secretImportsResource)).Namespace(namespace)
+	return ri, nil
+}
+
 //  Single resource getters
 
 // getPkg returns the package for the given cluster, namespace and identifier
@@ -222,11 +257,41 @@ func (s *Server) getSecret(ctx context.Context, headers http.Header, cluster, na
 	return secret, nil
 }
 
+// getSecretExportNamespaces returns the namespaces to which the SecretExport
+// with the given name exports its Secret, none if it does not exist or
+// secretgen-controller is not installed.
+func (s *Server) getSecretExportNamespaces(ctx context.Context, headers http.Header, cluster, namespace, name string) ([]string, error) {
+	resource, err := s.getSecretExportResource(headers, cluster, namespace)
+	if err != nil {
+		return nil, err
+	}
+	secretExport, err := resource.Get(ctx, name, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	namespaces, _, err := unstructured.NestedStringSlice(secretExport.Object, "spec", "toNamespaces")
+	if err != nil {
+		return nil, err
+	}
+	toNamespace, _, err := unstructured.NestedString(secretExport.Object, "spec", "toNamespace")
+	if err != nil {
+		return nil, err
+	}
+	if toNamespace != "" {
+		namespaces = append([]string{toNamespace}, namespaces...)
+	}
+	return namespaces, nil
+}
+
 //  List of resources getters
 
 // getPkgs requests the packages for the given cluster and namespace and sends
 // them to the channel to be processed immediately, closing the channel
-// when finished or when an error is returned.
+// when finished or when an error is returned. The packages are requested in
+// chunks so that the next chunk is only fetched once the previous one has
+// been consumed.
 func (s *Server) getPkgs(ctx context.Context, headers http.Header, cluster, namespace string, ch chan<- *datapackagingv1alpha1.Package) error {
 	defer close(ch)
 	resource, err := s.getPkgResource(headers, cluster, namespace)
@@ -234,21 +299,34 @@ func (s *Server) getPkgs(ctx context.Context, headers http.Header, cluster, name
 		return err
 	}
 
-	unstructured, err := resource.List(ctx, metav1.ListOptions{})
-	if err != nil {
-		return err
-	}
-
-	for _, unstructured := range unstructured.Items {
-		pkg := &datapackagingv1alpha1.Package{}
-		err := runtime.DefaultUnstructuredConverter.FromUnstructured(unstructured.Object, pkg)
+	continueToken := ""
+	for {
+		unstructured, err := resource.List(ctx, metav1.ListOptions{Limit: pkgListChunkSize, Continue: continueToken})
 		if err != nil {
 			return err
 		}
 
-		ch <- pkg
+		for _, unstructured := range unstructured.Items {
+			pkg := &datapackagingv1alpha1.Package{}
+			err := runtime.DefaultUnstructuredConverter.FromUnstructured(unstructured.Object, pkg)
+			if err != nil {
+				return err
+			}
+
+			// The caller may stop consuming packages once it has the ones it
+			// needs, in which case the request context is done.
+			select {
+			case ch <- pkg:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		continueToken = unstructured.GetContinue()
+		if continueToken == "" {
+			return nil
+		}
 	}
-	return nil
 }
 
 // getPkgs returns the list of packages for the given cluster and namespace
@@ -282,33 +360,91 @@ func (s *Server) getPkgsWithFieldSelector(ctx context.Context, headers http.Head
 
 // getPkgMetadatas returns the list of package metadatas for the given cluster and namespace
 func (s *Server) getPkgMetadatas(ctx context.Context, headers http.Header, cluster, namespace string) ([]*datapackagingv1alpha1.PackageMetadata, error) {
+	pkgMetadatas, _, err := s.getPkgMetadatasPage(ctx, headers, cluster, namespace, nil, nil, pkgListPosition{}, 0)
+	return pkgMetadatas, err
+}
+
+// getPkgMetadatasPage returns a page of at most pageSize package metadatas
+// matching the filter options and made available by the policies of their
+// repositories, starting at the given list position, together
+// with the position at which the next page starts (nil if there are no more
+// package metadatas). A pageSize of 0 returns every remaining package metadata.
+//
+// The package metadatas are requested in chunks using the limit and continue
+// options of the list, so that only the chunks covering the page are fetched
+// from the aggregated API rather than the whole list.
+func (s *Server) getPkgMetadatasPage(ctx context.Context, headers http.Header, cluster, namespace string, filterOptions *corev1.FilterOptions, policies pkgRepositoryPolicies, start pkgListPosition, pageSize int) ([]*datapackagingv1alpha1.PackageMetadata, *pkgListPosition, error) {
 	resource, err := s.getPkgMetadataResource(headers, cluster, namespace)
 	if err != nil {
-		return nil, err
-	}
-	unstructured, err := resource.List(ctx, metav1.ListOptions{})
-	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
+
 	var pkgMetadatas []*datapackagingv1alpha1.PackageMetadata
-	for _, unstructured := range unstructured.Items {
-		pkgMetadata := &datapackagingv1alpha1.PackageMetadata{}
-		err := runtime.DefaultUnstructuredConverter.FromUnstructured(unstructured.Object, pkgMetadata)
+	position := start
+	for {
+		unstructured, err := resource.List(ctx, metav1.ListOptions{Limit: pkgListChunkSize, Continue: position.Continue})
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
-		pkgMetadatas = append(pkgMetadatas, pkgMetadata)
+		var chunk []*datapackagingv1alpha1.PackageMetadata
+		for _, unstructured := range unstructured.Items {
+			pkgMetadata := &datapackagingv1alpha1.PackageMetadata{}
+			err := runtime.DefaultUnstructuredConverter.FromUnstructured(unstructured.Object, pkgMetadata)
+			if err != nil {
+				return nil, nil, err
+			}
+			chunk = append(chunk, pkgMetadata)
+		}
+		chunk = FilterMetadatasByPolicies(FilterMetadatas(chunk, filterOptions), policies)
+		// The packages of the global packaging namespace are local to it, so
+		// they are only excluded when listing other namespaces.
+		if filterOptions.GetExcludeGlobalPackages() && namespace != s.config().globalPackagingNamespace {
+			chunk = FilterGlobalMetadatas(chunk, s.config().globalPackagingNamespace)
+		}
+
+		// Skip the items of the chunk that were already returned in previous
+		// pages. The skip may span several chunks when the position was not
+		// cached and the listing has to start from the beginning.
+		skip, remainingSkip := position.Skip, 0
+		if skip >= len(chunk) {
+			remainingSkip = skip - len(chunk)
+			chunk = nil
+		} else {
+			chunk = chunk[skip:]
+		}
+
+		if pageSize > 0 && len(pkgMetadatas)+len(chunk) >= pageSize {
+			needed := pageSize - len(pkgMetadatas)
+			pkgMetadatas = append(pkgMetadatas, chunk[:needed]...)
+			if needed < len(chunk) {
+				// The next page starts within this same chunk.
+				return pkgMetadatas, &pkgListPosition{Continue: position.Continue, Skip: skip + needed}, nil
+			}
+			if unstructured.GetContinue() == "" {
+				return pkgMetadatas, nil, nil
+			}
+			return pkgMetadatas, &pkgListPosition{Continue: unstructured.GetContinue()}, nil
+		}
+		pkgMetadatas = append(pkgMetadatas, chunk...)
+
+		if unstructured.GetContinue() == "" {
+			if remainingSkip > 0 {
+				return nil, nil, errPkgListPositionOutOfRange
+			}
+			return pkgMetadatas, nil, nil
+		}
+		position = pkgListPosition{Continue: unstructured.GetContinue(), Skip: remainingSkip}
 	}
-	return pkgMetadatas, nil
 }
 
-// getPkgInstalls returns the list of package installs for the given cluster and namespace
-func (s *Server) getPkgInstalls(ctx context.Context, headers http.Header, cluster, namespace string) ([]*packagingv1alpha1.PackageInstall, error) {
+// getPkgInstalls returns the list of package installs for the given cluster and namespace,
+// matching the label selector if not empty
+func (s *Server) getPkgInstalls(ctx context.Context, headers http.Header, cluster, namespace, labelSelector string) ([]*packagingv1alpha1.PackageInstall, error) {
 	resource, err := s.getPkgInstallResource(headers, cluster, namespace)
 	if err != nil {
 		return nil, err
 	}
-	unstructured, err := resource.List(ctx, metav1.ListOptions{})
+	unstructured, err := resource.List(ctx, metav1.ListOptions{LabelSelector: labelSelector})
 	if err != nil {
 		return nil, err
 	}
@@ -330,6 +466,11 @@ func (s *Server) getPkgRepositories(ctx context.Context, headers http.Header, cl
 	if err != nil {
 		return nil, err
 	}
+	return listPkgRepositories(ctx, resource)
+}
+
+// listPkgRepositories returns the list of package repositories of the resource
+func listPkgRepositories(ctx context.Context, resource dynamic.ResourceInterface) ([]*packagingv1alpha1.PackageRepository, error) {
 	unstructured, err := resource.List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return nil, err
@@ -346,8 +487,60 @@ func (s *Server) getPkgRepositories(ctx context.Context, headers http.Header, cl
 	return pkgRepositories, nil
 }
 
-// getAccessiblePackageRepositories gather list of repositories to which the user has access per namespace
-func (s *Server) getAccessiblePackageRepositories(ctx context.Context, headers http.Header, cluster string) ([]*packagingv1alpha1.PackageRepository, error) {
+// getPkgRepositoryPolicies returns the policies of the package repositories
+// providing packages to the given namespace, that is, the repositories in the
+// namespace and in the global packaging namespace.
+//
+// The policies curate the catalog rather than replace RBAC, so they must
+// apply to every user regardless of the repositories they are allowed to
+// list: they are read with the plugin's service account in the kubeapps
+// cluster (the only one it has credentials for) and any error reading them
+// is returned rather than ignored.
+func (s *Server) getPkgRepositoryPolicies(ctx context.Context, headers http.Header, cluster, namespace string) (pkgRepositoryPolicies, error) {
+	namespaces := []string{namespace}
+	if namespace != "" && namespace != s.config().globalPackagingNamespace {
+		namespaces = append(namespaces, s.config().globalPackagingNamespace)
+	}
+
+	policies := pkgRepositoryPolicies{}
+	for _, ns := range namespaces {
+		resource, err := s.getPolicyPkgRepositoryResource(ctx, headers, cluster, ns)
+		if err != nil {
+			return nil, err
+		}
+		pkgRepositories, err := listPkgRepositories(ctx, resource)
+		if err != nil {
+			return nil, err
+		}
+		for _, pkgRepository := range pkgRepositories {
+			if policy := pkgutils.PackagePolicyFromAnnotations(pkgRepository.Annotations); policy != nil {
+				policies[fmt.Sprintf("%s/%s", pkgRepository.Namespace, pkgRepository.Name)] = policy
+			}
+		}
+	}
+	return policies, nil
+}
+
+// getPolicyPkgRepositoryResource returns the resource used to read the package
+// repository policies: the service account one in the kubeapps cluster and
+// the user one in the other clusters.
+func (s *Server) getPolicyPkgRepositoryResource(ctx context.Context, headers http.Header, cluster, namespace string) (dynamic.ResourceInterface, error) {
+	if cluster != s.globalPackagingCluster {
+		return s.getPkgRepositoryResource(headers, cluster, namespace)
+	}
+	dynClient, err := s.localServiceAccountClientGetter.Dynamic(ctx)
+	if err != nil {
+		return nil, err
+	}
+	gvr := schema.GroupVersionResource{
+		Group:    packagingv1alpha1.SchemeGroupVersion.Group,
+		Version:  packagingv1alpha1.SchemeGroupVersion.Version,
+		Resource: pkgRepositoriesResource}
+	return dynClient.Resource(gvr).Namespace(namespace), nil
+}
+
+// getAccessibleNamespaces returns the names of the active namespaces to which the user has access
+func (s *Server) getAccessibleNamespaces(headers http.Header, cluster string) ([]string, error) {
 	clusterTypedClientFunc := func() (kubernetes.Interface, error) {
 		return s.clientGetter.Typed(headers, cluster)
 	}
@@ -359,13 +552,29 @@ func (s *Server) getAccessiblePackageRepositories(ctx context.Context, headers h
 	if err != nil {
 		return nil, err
 	}
-	namespaceList = resources.FilterActiveNamespaces(namespaceList)
+	var namespaces []string
+	for _, ns := range resources.FilterActiveNamespaces(namespaceList) {
+		namespaces = append(namespaces, ns.Name)
+	}
+	return namespaces, nil
+}
+
+// getAccessiblePackageRepositories gather list of repositories to which the user has access per namespace,
+// along with the namespaces in which the repositories could not be listed
+func (s *Server) getAccessiblePackageRepositories(ctx context.Context, headers http.Header, cluster string) ([]*packagingv1alpha1.PackageRepository, []*corev1.NamespaceFailure, error) {
+	namespaces, err := s.getAccessibleNamespaces(headers, cluster)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	var accessibleRepos []*packagingv1alpha1.PackageRepository
-	for _, ns := range namespaceList {
-		if nsRepos, err := s.getPkgRepositories(ctx, headers, cluster, ns.Name); err != nil {
-			log.Warningf("++kapp-controller could not list PackageRepository in namespace %s", ns.Name)
-			// Continue. Error in a single namespace should not block the whole list
+	var failures []*corev1.NamespaceFailure
+	for _, ns := range namespaces {
+		if nsRepos, err := s.getPkgRepositories(ctx, headers, cluster, ns); err != nil {
+			log.Warningf("++kapp-controller could not list PackageRepository in namespace %s", ns)
+			// Continue. Error in a single namespace should not block the whole list,
+			// but it is reported so that the caller knows the list is partial
+			failures = append(failures, pkgutils.NewNamespaceFailure(cluster, ns, GetPluginDetail(), connecterror.FromK8sError("list", "PackageRepository", "", err)))
 		} else {
 			accessibleRepos = append(accessibleRepos, nsRepos...)
 		}
@@ -373,21 +582,22 @@ func (s *Server) getAccessiblePackageRepositories(ctx context.Context, headers h
 
 	// in general, the user will not have admin level access to the global namespace, so checking explicitly
 	var hasglobalns bool
-	for _, ns := range namespaceList {
-		if ns.Name == s.pluginConfig.globalPackagingNamespace {
+	for _, ns := range namespaces {
+		if ns == s.config().globalPackagingNamespace {
 			hasglobalns = true
 			break
 		}
 	}
 	if !hasglobalns {
-		if nsRepos, err := s.getPkgRepositories(ctx, headers, cluster, s.pluginConfig.globalPackagingNamespace); err != nil {
+		if nsRepos, err := s.getPkgRepositories(ctx, headers, cluster, s.config().globalPackagingNamespace); err != nil {
 			log.Warningf("++kapp-controller could not list PackageRepository in global namespace")
+			failures = append(failures, pkgutils.NewNamespaceFailure(cluster, s.config().globalPackagingNamespace, GetPluginDetail(), connecterror.FromK8sError("list", "PackageRepository", "", err)))
 		} else {
 			accessibleRepos = append(accessibleRepos, nsRepos...)
 		}
 	}
 
-	return accessibleRepos, nil
+	return accessibleRepos, failures, nil
 }
 
 // getApps returns the list of apps for the given cluster and namespace
@@ -470,6 +680,28 @@ func (s *Server) createPkgRepository(ctx context.Context, headers http.Header, c
 	return &pkgRepository, nil
 }
 
+// checkSecretsAccess returns a PermissionDenied error naming the namespace if the
+// user is not allowed to perform any of the verbs on the secrets of the namespace.
+// If the access cannot be reviewed, the error is only logged and the operations
+// left to fail on their own.
+func (s *Server) checkSecretsAccess(ctx context.Context, headers http.Header, cluster, namespace string, verbs ...string) error {
+	typedClient, err := s.clientGetter.Typed(headers, cluster)
+	if err != nil {
+		return err
+	}
+	for _, verb := range verbs {
+		allowed, err := resources.CanI(ctx, typedClient, k8scorev1.Resource("secrets"), verb, namespace)
+		if err != nil {
+			log.Warningf("Unable to review the access to %s secrets in the namespace [%s]: %v", verb, namespace, err)
+			return nil
+		}
+		if !allowed {
+			return connect.NewError(connect.CodePermissionDenied, fmt.Errorf("Not allowed to %s secrets in the namespace [%s]", verb, namespace))
+		}
+	}
+	return nil
+}
+
 // create Secret
 func (s *Server) createSecret(ctx context.Context, headers http.Header, cluster string, secret *k8scorev1.Secret) (*k8scorev1.Secret, error) {
 	typedClient, err := s.clientGetter.Typed(headers, cluster)
@@ -483,6 +715,39 @@ func (s *Server) createSecret(ctx context.Context, headers http.Header, cluster
 	return secret, nil
 }
 
+// createSecretExport creates the SecretExport for the given cluster
+func (s *Server) createSecretExport(ctx context.Context, headers http.Header, cluster string, secretExport *unstructured.Unstructured) error {
+	resource, err := s.getSecretExportResource(headers, cluster, secretExport.GetNamespace())
+	if err != nil {
+		return err
+	}
+	_, err = resource.Create(ctx, secretExport, metav1.CreateOptions{})
+	return err
+}
+
+// createServiceAccountResources creates the ServiceAccount, the (optional) Role and the RoleBinding
+// used to reconcile a PackageInstall. If any of them fails, the already created ones are deleted.
+func (s *Server) createServiceAccountResources(ctx context.Context, headers http.Header, cluster string, serviceAccount *k8scorev1.ServiceAccount, role *rbacv1.Role, roleBinding *rbacv1.RoleBinding) error {
+	typedClient, err := s.clientGetter.Typed(headers, cluster)
+	if err != nil {
+		return err
+	}
+	if _, err = typedClient.CoreV1().ServiceAccounts(serviceAccount.Namespace).Create(ctx, serviceAccount, metav1.CreateOptions{}); err != nil {
+		return connecterror.FromK8sError("create", "ServiceAccount", serviceAccount.Name, err)
+	}
+	if role != nil {
+		if _, err = typedClient.RbacV1().Roles(role.Namespace).Create(ctx, role, metav1.CreateOptions{}); err != nil {
+			_ = s.deleteServiceAccountResources(ctx, headers, cluster, serviceAccount, nil, nil)
+			return connecterror.FromK8sError("create", "Role", role.Name, err)
+		}
+	}
+	if _, err = typedClient.RbacV1().RoleBindings(roleBinding.Namespace).Create(ctx, roleBinding, metav1.CreateOptions{}); err != nil {
+		_ = s.deleteServiceAccountResources(ctx, headers, cluster, serviceAccount, role, nil)
+		return connecterror.FromK8sError("create", "RoleBinding", roleBinding.Name, err)
+	}
+	return nil
+}
+
 // Deletion functions
 
 // deletePkgInstall deletes a package install for the given cluster, namespace and identifier
@@ -524,6 +789,236 @@ func (s *Server) deleteSecret(ctx context.Context, headers http.Header, cluster,
 	return nil
 }
 
+// installedValuesEntry is a values entry of an existing PackageInstall, along
+// with the Secret holding the values, nil if it does not exist.
+type installedValuesEntry struct {
+	pkgInstallValuesEntry
+	current *k8scorev1.Secret
+	managed bool
+}
+
+// getInstalledValuesEntries returns the values entries of the PackageInstall,
+// in the order in which they are merged.
+func (s *Server) getInstalledValuesEntries(ctx context.Context, headers http.Header, cluster string, pkgInstall *packagingv1alpha1.PackageInstall) ([]installedValuesEntry, error) {
+	typedClient, err := s.clientGetter.Typed(headers, cluster)
+	if err != nil {
+		return nil, err
+	}
+	entries := []installedValuesEntry{}
+	for _, pkgInstallValue := range pkgInstall.Spec.Values {
+		if pkgInstallValue.SecretRef == nil || pkgInstallValue.SecretRef.Name == "" {
+			continue
+		}
+		secretRefName := pkgInstallValue.SecretRef.Name
+		secret, err := typedClient.CoreV1().Secrets(pkgInstall.Namespace).Get(ctx, secretRefName, metav1.GetOptions{})
+		if err != nil {
+			if !errors.IsNotFound(err) {
+				return nil, connecterror.FromK8sError("get", "Secret", secretRefName, err)
+			}
+			log.Warningf("The referenced secret does not exist: %s", connecterror.FromK8sError("get", "Secret", secretRefName, err).Error())
+			secret = nil
+		}
+		entries = append(entries, installedValuesEntry{
+			pkgInstallValuesEntry: pkgInstallValuesEntry{
+				layer: pkgInstallValuesLayer(pkgInstall, secretRefName),
+				ref:   pkgInstallValue,
+			},
+			current: secret,
+			managed: isPluginManagedValuesSecret(pkgInstall, secretRefName, secret),
+		})
+	}
+	return entries, nil
+}
+
+// createValuesSecrets creates the Secrets holding the values of the given
+// entries, deleting the ones already created if any of them fails.
+func (s *Server) createValuesSecrets(ctx context.Context, headers http.Header, cluster, namespace string, entries []pkgInstallValuesEntry) error {
+	typedClient, err := s.clientGetter.Typed(headers, cluster)
+	if err != nil {
+		return err
+	}
+	for i, entry := range entries {
+		if entry.secret == nil {
+			continue
+		}
+		if _, err := typedClient.CoreV1().Secrets(namespace).Create(ctx, entry.secret, metav1.CreateOptions{}); err != nil {
+			if err := s.deleteValuesSecrets(ctx, headers, cluster, namespace, entries[:i]); err != nil {
+				return err
+			}
+			return connecterror.FromK8sError("create", "Secret", entry.secret.Name, err)
+		}
+	}
+	return nil
+}
+
+// applyValuesSecrets creates, or updates if they already exist, the Secrets
+// holding the values of the given entries.
+func (s *Server) applyValuesSecrets(ctx context.Context, headers http.Header, cluster, namespace string, entries []pkgInstallValuesEntry) error {
+	typedClient, err := s.clientGetter.Typed(headers, cluster)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if entry.secret == nil {
+			continue
+		}
+		_, err := typedClient.CoreV1().Secrets(namespace).Update(ctx, entry.secret, metav1.UpdateOptions{})
+		if errors.IsNotFound(err) {
+			_, err = typedClient.CoreV1().Secrets(namespace).Create(ctx, entry.secret, metav1.CreateOptions{})
+		}
+		if err != nil {
+			return connecterror.FromK8sError("update", "Secret", entry.secret.Name, err)
+		}
+	}
+	return nil
+}
+
+// deleteValuesSecrets deletes the Secrets holding the values of the given
+// entries managed by the plugin, ignoring the ones that do not exist anymore.
+func (s *Server) deleteValuesSecrets(ctx context.Context, headers http.Header, cluster, namespace string, entries []pkgInstallValuesEntry) error {
+	for _, entry := range entries {
+		if entry.secret == nil {
+			continue
+		}
+		if err := s.deleteSecret(ctx, headers, cluster, namespace, entry.secret.Name); err != nil && !errors.IsNotFound(err) {
+			return connecterror.FromK8sError("delete", "Secret", entry.secret.Name, err)
+		}
+	}
+	return nil
+}
+
+// deleteServiceAccountResources deletes the given ServiceAccount, Role and RoleBinding, ignoring
+// the ones that are nil or do not exist anymore.
+func (s *Server) deleteServiceAccountResources(ctx context.Context, headers http.Header, cluster string, serviceAccount *k8scorev1.ServiceAccount, role *rbacv1.Role, roleBinding *rbacv1.RoleBinding) error {
+	typedClient, err := s.clientGetter.Typed(headers, cluster)
+	if err != nil {
+		return err
+	}
+	if roleBinding != nil {
+		err = typedClient.RbacV1().RoleBindings(roleBinding.Namespace).Delete(ctx, roleBinding.Name, metav1.DeleteOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			return connecterror.FromK8sError("delete", "RoleBinding", roleBinding.Name, err)
+		}
+	}
+	if role != nil {
+		err = typedClient.RbacV1().Roles(role.Namespace).Delete(ctx, role.Name, metav1.DeleteOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			return connecterror.FromK8sError("delete", "Role", role.Name, err)
+		}
+	}
+	if serviceAccount != nil {
+		err = typedClient.CoreV1().ServiceAccounts(serviceAccount.Namespace).Delete(ctx, serviceAccount.Name, metav1.DeleteOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			return connecterror.FromK8sError("delete", "ServiceAccount", serviceAccount.Name, err)
+		}
+	}
+	return nil
+}
+
+// deleteCreatedPkgInstall deletes a PackageInstall whose creation could not be completed, along
+// with the values Secrets, the ServiceAccount resources and the image pull Secret or SecretImport
+// created for it, ignoring the ones that are nil or do not exist anymore.
+func (s *Server) deleteCreatedPkgInstall(ctx context.Context, headers http.Header, cluster, namespace, name string, valuesEntries []pkgInstallValuesEntry, serviceAccount *k8scorev1.ServiceAccount, role *rbacv1.Role, roleBinding *rbacv1.RoleBinding, imagePullSecret *k8scorev1.Secret, imagePullSecretImport *unstructured.Unstructured) error {
+	if err := s.deletePkgInstall(ctx, headers, cluster, namespace, name); err != nil && !errors.IsNotFound(err) {
+		return connecterror.FromK8sError("delete", "PackageInstall", name, err)
+	}
+	if err := s.deleteValuesSecrets(ctx, headers, cluster, namespace, valuesEntries); err != nil {
+		return err
+	}
+	if err := s.deleteServiceAccountResources(ctx, headers, cluster, serviceAccount, role, roleBinding); err != nil {
+		return err
+	}
+	if imagePullSecret != nil {
+		if err := s.deleteSecret(ctx, headers, cluster, namespace, imagePullSecret.Name); err != nil && !errors.IsNotFound(err) {
+			return connecterror.FromK8sError("delete", "Secret", imagePullSecret.Name, err)
+		}
+	}
+	if imagePullSecretImport != nil {
+		if err := s.deleteSecretImport(ctx, headers, cluster, namespace, imagePullSecretImport.GetName()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cleanUpTimedOutPkgInstall deletes a PackageInstall whose App has not been created by
+// kapp-controller within the configured timeout, along with the values Secrets, the
+// ServiceAccount resources and the image pull Secret created by this plugin for it. It
+// returns whether the timeout expired.
+func (s *Server) cleanUpTimedOutPkgInstall(ctx context.Context, headers http.Header, cluster string, pkgInstall *packagingv1alpha1.PackageInstall, now time.Time) (bool, error) {
+	timeout := time.Second * time.Duration(s.config().timeoutSeconds)
+	if now.Sub(pkgInstall.CreationTimestamp.Time) <= timeout {
+		return false, nil
+	}
+	installedValuesEntries, err := s.getInstalledValuesEntries(ctx, headers, cluster, pkgInstall)
+	if err != nil {
+		return true, err
+	}
+	managedValuesEntries := []pkgInstallValuesEntry{}
+	for _, entry := range installedValuesEntries {
+		if entry.managed && entry.current != nil {
+			managedValuesEntries = append(managedValuesEntries, pkgInstallValuesEntry{secret: entry.current})
+		}
+	}
+
+	// the ServiceAccount resources and the image pull Secret were created for the
+	// PackageInstall only if they are owned by it
+	typedClient, err := s.clientGetter.Typed(headers, cluster)
+	if err != nil {
+		return true, err
+	}
+	namespace, ownerUID := pkgInstall.GetNamespace(), pkgInstall.GetUID()
+	serviceAccount, role, roleBinding := s.buildServiceAccountResources(pkgInstall.GetName(), namespace)
+	imagePullSecret := s.buildImagePullSecret(pkgInstall.GetName(), namespace)
+	serviceAccounts := typedClient.CoreV1().ServiceAccounts(namespace)
+	if owned, err := isOwnedResource(ctx, "ServiceAccount", serviceAccount.Name, ownerUID, serviceAccounts.Get); err != nil {
+		return true, err
+	} else if !owned {
+		serviceAccount = nil
+	}
+	if role != nil {
+		roles := typedClient.RbacV1().Roles(namespace)
+		if owned, err := isOwnedResource(ctx, "Role", role.Name, ownerUID, roles.Get); err != nil {
+			return true, err
+		} else if !owned {
+			role = nil
+		}
+	}
+	roleBindings := typedClient.RbacV1().RoleBindings(namespace)
+	if owned, err := isOwnedResource(ctx, "RoleBinding", roleBinding.Name, ownerUID, roleBindings.Get); err != nil {
+		return true, err
+	} else if !owned {
+		roleBinding = nil
+	}
+	secrets := typedClient.CoreV1().Secrets(namespace)
+	if owned, err := isOwnedResource(ctx, "Secret", imagePullSecret.Name, ownerUID, secrets.Get); err != nil {
+		return true, err
+	} else if !owned {
+		imagePullSecret = nil
+	}
+
+	// an imported image pull Secret is shared with the other PackageInstalls of the namespace,
+	// its SecretImport being garbage collected once none of its owners remains
+	return true, s.deleteCreatedPkgInstall(ctx, headers, cluster, namespace, pkgInstall.GetName(), managedValuesEntries, serviceAccount, role, roleBinding, imagePullSecret, nil)
+}
+
+// isOwnedResource returns whether the named resource exists and is owned by the owner.
+func isOwnedResource[T metav1.Object](ctx context.Context, kind, name string, ownerUID types.UID, get func(context.Context, string, metav1.GetOptions) (T, error)) (bool, error) {
+	obj, err := get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, connecterror.FromK8sError("get", kind, name, err)
+	}
+	for _, ownerReference := range obj.GetOwnerReferences() {
+		if ownerReference.UID == ownerUID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 // Update functions
 
 // createPkgInstall creates a package install for the given cluster, namespace and identifier
@@ -679,3 +1174,178 @@ func (s *Server) updateSecret(ctx context.Context, headers http.Header, cluster
 	}
 	return secret, nil
 }
+
+// setSecretOwner sets the given PackageInstall as the owner of the Secret, so that it gets
+// garbage collected once the PackageInstall has been deleted. Only the owner references are
+// patched, as the data of a placeholder Secret may have been filled in by secretgen-controller
+// in the meantime.
+func (s *Server) setSecretOwner(ctx context.Context, headers http.Header, cluster string, pkgInstall *packagingv1alpha1.PackageInstall, secret *k8scorev1.Secret) error {
+	typedClient, err := s.clientGetter.Typed(headers, cluster)
+	if err != nil {
+		return err
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"ownerReferences": []metav1.OwnerReference{pkgInstallOwnerReference(pkgInstall)},
+		},
+	})
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, err)
+	}
+	if _, err = typedClient.CoreV1().Secrets(secret.Namespace).Patch(ctx, secret.Name, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		return connecterror.FromK8sError("patch", "Secret", secret.Name, err)
+	}
+	return nil
+}
+
+// createImagePullSecretImport creates the SecretImport of the registry credentials configured by
+// the administrator, returning nil if it already exists, as it is shared by the PackageInstalls of
+// the namespace.
+func (s *Server) createImagePullSecretImport(ctx context.Context, headers http.Header, cluster string, secretImport *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	resource, err := s.getSecretImportResource(headers, cluster, secretImport.GetNamespace())
+	if err != nil {
+		return nil, err
+	}
+	created, err := resource.Create(ctx, secretImport, metav1.CreateOptions{})
+	if errors.IsAlreadyExists(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, connecterror.FromK8sError("create", secretImportResource, secretImport.GetName(), err)
+	}
+	return created, nil
+}
+
+// addSecretImportOwner adds the given PackageInstall to the owners of the SecretImport, so that
+// it gets garbage collected once every PackageInstall using it has been deleted. The owner is not
+// the controller, as the SecretImport is shared by the PackageInstalls of the namespace.
+func (s *Server) addSecretImportOwner(ctx context.Context, headers http.Header, cluster string, pkgInstall *packagingv1alpha1.PackageInstall, name string) error {
+	resource, err := s.getSecretImportResource(headers, cluster, pkgInstall.GetNamespace())
+	if err != nil {
+		return err
+	}
+	ownerReference := pkgInstallOwnerReference(pkgInstall)
+	ownerReference.Controller = nil
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		secretImport, err := resource.Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		ownerReferences := secretImport.GetOwnerReferences()
+		for _, ref := range ownerReferences {
+			if ref.UID == pkgInstall.GetUID() {
+				return nil
+			}
+		}
+		secretImport.SetOwnerReferences(append(ownerReferences, ownerReference))
+		_, err = resource.Update(ctx, secretImport, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		return connecterror.FromK8sError("update", secretImportResource, name, err)
+	}
+	return nil
+}
+
+// deleteSecretImport deletes the SecretImport with the given name, ignoring it if it does not exist.
+func (s *Server) deleteSecretImport(ctx context.Context, headers http.Header, cluster, namespace, name string) error {
+	resource, err := s.getSecretImportResource(headers, cluster, namespace)
+	if err != nil {
+		return err
+	}
+	if err := resource.Delete(ctx, name, metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+		return connecterror.FromK8sError("delete", secretImportResource, name, err)
+	}
+	return nil
+}
+
+// setServiceAccountResourcesOwner sets the given PackageInstall as the owner of the ServiceAccount,
+// the (optional) Role and the RoleBinding, so that they get garbage collected once the
+// PackageInstall (and, therefore, the resources it installed) has been deleted.
+func (s *Server) setServiceAccountResourcesOwner(ctx context.Context, headers http.Header, cluster string, pkgInstall *packagingv1alpha1.PackageInstall, serviceAccount *k8scorev1.ServiceAccount, role *rbacv1.Role, roleBinding *rbacv1.RoleBinding) error {
+	typedClient, err := s.clientGetter.Typed(headers, cluster)
+	if err != nil {
+		return err
+	}
+	ownerReferences := []metav1.OwnerReference{pkgInstallOwnerReference(pkgInstall)}
+
+	serviceAccount.OwnerReferences = ownerReferences
+	if _, err = typedClient.CoreV1().ServiceAccounts(serviceAccount.Namespace).Update(ctx, serviceAccount, metav1.UpdateOptions{}); err != nil {
+		return connecterror.FromK8sError("update", "ServiceAccount", serviceAccount.Name, err)
+	}
+	if role != nil {
+		role.OwnerReferences = ownerReferences
+		if _, err = typedClient.RbacV1().Roles(role.Namespace).Update(ctx, role, metav1.UpdateOptions{}); err != nil {
+			return connecterror.FromK8sError("update", "Role", role.Name, err)
+		}
+	}
+	roleBinding.OwnerReferences = ownerReferences
+	if _, err = typedClient.RbacV1().RoleBindings(roleBinding.Namespace).Update(ctx, roleBinding, metav1.UpdateOptions{}); err != nil {
+		return connecterror.FromK8sError("update", "RoleBinding", roleBinding.Name, err)
+	}
+	return nil
+}
+
+// orphanPkgInstallResources removes the given PackageInstall from the owners of the
+// ServiceAccount, Role and RoleBinding and of the image pull Secret or SecretImport created by
+// this plugin for it, if any, so that they are not garbage collected once it has been deleted.
+func (s *Server) orphanPkgInstallResources(ctx context.Context, headers http.Header, cluster string, pkgInstall *packagingv1alpha1.PackageInstall) error {
+	typedClient, err := s.clientGetter.Typed(headers, cluster)
+	if err != nil {
+		return err
+	}
+	namespace, ownerUID := pkgInstall.GetNamespace(), pkgInstall.GetUID()
+	serviceAccount, role, roleBinding := s.buildServiceAccountResources(pkgInstall.GetName(), namespace)
+	imagePullSecret := s.buildImagePullSecret(pkgInstall.GetName(), namespace)
+
+	serviceAccounts := typedClient.CoreV1().ServiceAccounts(namespace)
+	if err := orphanResource(ctx, "ServiceAccount", serviceAccount.Name, ownerUID, serviceAccounts.Get, serviceAccounts.Update); err != nil {
+		return err
+	}
+	if role != nil {
+		roles := typedClient.RbacV1().Roles(namespace)
+		if err := orphanResource(ctx, "Role", role.Name, ownerUID, roles.Get, roles.Update); err != nil {
+			return err
+		}
+	}
+	roleBindings := typedClient.RbacV1().RoleBindings(namespace)
+	if err := orphanResource(ctx, "RoleBinding", roleBinding.Name, ownerUID, roleBindings.Get, roleBindings.Update); err != nil {
+		return err
+	}
+	secrets := typedClient.CoreV1().Secrets(namespace)
+	if err := orphanResource(ctx, "Secret", imagePullSecret.Name, ownerUID, secrets.Get, secrets.Update); err != nil {
+		return err
+	}
+	if name := pkgInstall.GetAnnotations()[annotationImagePullSecretNameKey]; name != "" && name != imagePullSecret.Name {
+		secretImports, err := s.getSecretImportResource(headers, cluster, namespace)
+		if err != nil {
+			return err
+		}
+		getSecretImport := func(ctx context.Context, name string, options metav1.GetOptions) (*unstructured.Unstructured, error) {
+			return secretImports.Get(ctx, name, options)
+		}
+		updateSecretImport := func(ctx context.Context, secretImport *unstructured.Unstructured, options metav1.UpdateOptions) (*unstructured.Unstructured, error) {
+			return secretImports.Update(ctx, secretImport, options)
+		}
+		return orphanResource(ctx, secretImportResource, name, ownerUID, getSecretImport, updateSecretImport)
+	}
+	return nil
+}
+
+// orphanResource removes the owner from the owners of the named resource, if it exists
+// and is owned by it.
+func orphanResource[T metav1.Object](ctx context.Context, kind, name string, ownerUID types.UID, get func(context.Context, string, metav1.GetOptions) (T, error), update func(context.Context, T, metav1.UpdateOptions) (T, error)) error {
+	obj, err := get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return connecterror.FromK8sError("get", kind, name, err)
+	}
+	if !pkgutils.RemoveOwnerReference(obj, ownerUID) {
+		return nil
+	}
+	if _, err := update(ctx, obj, metav1.UpdateOptions{}); err != nil {
+		return connecterror.FromK8sError("update", kind, name, err)
+	}
+	return nil
+}
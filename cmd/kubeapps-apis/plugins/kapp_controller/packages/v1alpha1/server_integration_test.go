@@ -0,0 +1,238 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bufbuild/connect-go"
+	corev1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+// This is an integration test: it tests the full integration of the kapp-controller
+// plugin with a real kapp-controller, so that changes in the upstream CRDs are noticed.
+// To run these tests, enable the ENABLE_KAPP_CONTROLLER_INTEGRATION_TESTS variable.
+// Pre-requisites for these tests to run:
+//  1. a kind cluster with kapp-controller deployed, as created by
+//     make cluster-kind deploy-kapp-controller
+//  2. the KUBECONFIG variable pointing to the kind cluster, if it is not the default one.
+//
+// The make target kapp-controller-plugin-integration does both and runs these tests.
+// When KAPP_CONTROLLER_FIXTURES_DIR is also set (see the make target
+// kapp-controller-plugin-fixtures), the CRs created during the test are exported
+// there as the fixtures used by the fake-client unit tests.
+func TestKindClusterPackagesAndRepositories(t *testing.T) {
+	config := checkEnv(t)
+	s := newIntegrationServer(t, config)
+
+	typedClient, err := s.clientGetter.Typed(nil, integrationTestsCluster)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	ensureIntegrationNamespace(t, typedClient)
+
+	ctx := context.Background()
+	testContext := &corev1.Context{Cluster: integrationTestsCluster, Namespace: integrationTestsNamespace}
+	repoName := "integration-repo"
+	repoRef := &corev1.PackageRepositoryReference{Context: testContext, Plugin: &pluginDetail, Identifier: repoName}
+
+	// Repositories
+	if _, err := s.AddPackageRepository(ctx, connect.NewRequest(&corev1.AddPackageRepositoryRequest{
+		Context: testContext,
+		Name:    repoName,
+		Type:    typeImgPkgBundle,
+		Url:     integrationTestRepoURL(),
+		Plugin:  &pluginDetail,
+	})); err != nil {
+		t.Fatalf("AddPackageRepository: %+v", err)
+	}
+	waitUntil(t, "the package repository is reconciled", func(ctx context.Context) (bool, error) {
+		response, err := s.GetPackageRepositoryDetail(ctx, connect.NewRequest(&corev1.GetPackageRepositoryDetailRequest{PackageRepoRef: repoRef}))
+		if err != nil {
+			return false, err
+		}
+		status := response.Msg.GetDetail().GetStatus()
+		if status.GetReason() == corev1.PackageRepositoryStatus_STATUS_REASON_FAILED {
+			return false, fmt.Errorf("the package repository failed to reconcile: %s", status.GetUserReason())
+		}
+		return status.GetReason() == corev1.PackageRepositoryStatus_STATUS_REASON_SUCCESS, nil
+	})
+
+	repoSummaries, err := s.GetPackageRepositorySummaries(ctx, connect.NewRequest(&corev1.GetPackageRepositorySummariesRequest{Context: testContext}))
+	if err != nil {
+		t.Fatalf("GetPackageRepositorySummaries: %+v", err)
+	}
+	if got, want := len(repoSummaries.Msg.GetPackageRepositorySummaries()), 1; got != want {
+		t.Fatalf("got: %d package repositories, want: %d", got, want)
+	}
+	if _, err := s.UpdatePackageRepository(ctx, connect.NewRequest(&corev1.UpdatePackageRepositoryRequest{
+		PackageRepoRef: repoRef,
+		Url:            integrationTestRepoURL(),
+		Description:    "updated by the integration tests",
+	})); err != nil {
+		t.Fatalf("UpdatePackageRepository: %+v", err)
+	}
+	if _, err := s.GetPackageRepositoryPermissions(ctx, connect.NewRequest(&corev1.GetPackageRepositoryPermissionsRequest{Context: testContext})); err != nil {
+		t.Fatalf("GetPackageRepositoryPermissions: %+v", err)
+	}
+
+	// Available packages
+	var availableSummary *corev1.AvailablePackageSummary
+	waitUntil(t, "the packages of the repository are available", func(ctx context.Context) (bool, error) {
+		response, err := s.GetAvailablePackageSummaries(ctx, connect.NewRequest(&corev1.GetAvailablePackageSummariesRequest{Context: testContext}))
+		if err != nil {
+			return false, err
+		}
+		if len(response.Msg.GetAvailablePackageSummaries()) == 0 {
+			return false, nil
+		}
+		availableSummary = response.Msg.GetAvailablePackageSummaries()[0]
+		return true, nil
+	})
+	availableRef := availableSummary.GetAvailablePackageRef()
+
+	versions, err := s.GetAvailablePackageVersions(ctx, connect.NewRequest(&corev1.GetAvailablePackageVersionsRequest{AvailablePackageRef: availableRef}))
+	if err != nil {
+		t.Fatalf("GetAvailablePackageVersions: %+v", err)
+	}
+	if len(versions.Msg.GetPackageAppVersions()) == 0 {
+		t.Fatalf("no versions found for available package %q", availableRef.GetIdentifier())
+	}
+	detail, err := s.GetAvailablePackageDetail(ctx, connect.NewRequest(&corev1.GetAvailablePackageDetailRequest{AvailablePackageRef: availableRef}))
+	if err != nil {
+		t.Fatalf("GetAvailablePackageDetail: %+v", err)
+	}
+	if got, want := detail.Msg.GetAvailablePackageDetail().GetVersion().GetPkgVersion(), availableSummary.GetLatestVersion().GetPkgVersion(); got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+
+	// Installed packages
+	installedName := "integration-install"
+	installedRef := &corev1.InstalledPackageReference{Context: testContext, Plugin: &pluginDetail, Identifier: installedName}
+	oldestVersion := versions.Msg.GetPackageAppVersions()[len(versions.Msg.GetPackageAppVersions())-1].GetPkgVersion()
+	if _, err := s.CreateInstalledPackage(ctx, connect.NewRequest(&corev1.CreateInstalledPackageRequest{
+		AvailablePackageRef: availableRef,
+		PkgVersionReference: &corev1.VersionReference{Version: oldestVersion},
+		Name:                installedName,
+		TargetContext:       testContext,
+		ReconciliationOptions: &corev1.ReconciliationOptions{
+			ServiceAccountName: integrationTestsServiceAccount,
+		},
+	})); err != nil {
+		t.Fatalf("CreateInstalledPackage: %+v", err)
+	}
+	waitUntilInstalled := func(version string) {
+		waitUntil(t, fmt.Sprintf("the version %q of the package is installed", version), func(ctx context.Context) (bool, error) {
+			response, err := s.GetInstalledPackageDetail(ctx, connect.NewRequest(&corev1.GetInstalledPackageDetailRequest{InstalledPackageRef: installedRef}))
+			if err != nil {
+				return false, err
+			}
+			installedDetail := response.Msg.GetInstalledPackageDetail()
+			if installedDetail.GetStatus().GetReason() == corev1.InstalledPackageStatus_STATUS_REASON_FAILED {
+				return false, fmt.Errorf("the package failed to install: %s", installedDetail.GetStatus().GetUserReason())
+			}
+			return installedDetail.GetStatus().GetReady() && installedDetail.GetCurrentVersion().GetPkgVersion() == version, nil
+		})
+	}
+	waitUntilInstalled(oldestVersion)
+
+	installedSummaries, err := s.GetInstalledPackageSummaries(ctx, connect.NewRequest(&corev1.GetInstalledPackageSummariesRequest{Context: testContext}))
+	if err != nil {
+		t.Fatalf("GetInstalledPackageSummaries: %+v", err)
+	}
+	if got, want := len(installedSummaries.Msg.GetInstalledPackageSummaries()), 1; got != want {
+		t.Fatalf("got: %d installed packages, want: %d", got, want)
+	}
+	resourceRefs, err := s.GetInstalledPackageResourceRefs(ctx, connect.NewRequest(&corev1.GetInstalledPackageResourceRefsRequest{InstalledPackageRef: installedRef}))
+	if err != nil {
+		t.Fatalf("GetInstalledPackageResourceRefs: %+v", err)
+	}
+	if len(resourceRefs.Msg.GetResourceRefs()) == 0 {
+		t.Errorf("no resource refs found for installed package %q", installedName)
+	}
+
+	latestVersion := versions.Msg.GetPackageAppVersions()[0].GetPkgVersion()
+	if _, err := s.UpdateInstalledPackage(ctx, connect.NewRequest(&corev1.UpdateInstalledPackageRequest{
+		InstalledPackageRef: installedRef,
+		PkgVersionReference: &corev1.VersionReference{Version: latestVersion},
+		ReconciliationOptions: &corev1.ReconciliationOptions{
+			ServiceAccountName: integrationTestsServiceAccount,
+		},
+	})); err != nil {
+		t.Fatalf("UpdateInstalledPackage: %+v", err)
+	}
+	waitUntilInstalled(latestVersion)
+
+	if dir := os.Getenv(envVarKappFixturesDir); dir != "" {
+		exportFixtures(t, s, dir)
+	}
+
+	// Clean up through the plugin too, so that the deletions are tested as well.
+	if _, err := s.DeleteInstalledPackage(ctx, connect.NewRequest(&corev1.DeleteInstalledPackageRequest{InstalledPackageRef: installedRef})); err != nil {
+		t.Fatalf("DeleteInstalledPackage: %+v", err)
+	}
+	waitUntil(t, "the installed package is deleted", func(ctx context.Context) (bool, error) {
+		_, err := s.GetInstalledPackageDetail(ctx, connect.NewRequest(&corev1.GetInstalledPackageDetailRequest{InstalledPackageRef: installedRef}))
+		if connect.CodeOf(err) == connect.CodeNotFound {
+			return true, nil
+		}
+		return false, err
+	})
+	if _, err := s.DeletePackageRepository(ctx, connect.NewRequest(&corev1.DeletePackageRepositoryRequest{PackageRepoRef: repoRef})); err != nil {
+		t.Fatalf("DeletePackageRepository: %+v", err)
+	}
+}
+
+// exportFixtures writes the CRs of the integration tests namespace, without the
+// fields assigned by the API server, to a YAML file per resource in the given dir.
+func exportFixtures(t *testing.T, s *Server, dir string) {
+	dynamicClient, err := s.clientGetter.Dynamic(nil, integrationTestsCluster)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	for _, resource := range fixtureResources {
+		list, err := dynamicClient.Resource(resource).Namespace(integrationTestsNamespace).List(context.Background(), metav1.ListOptions{})
+		if err != nil {
+			t.Fatalf("unable to list %s: %+v", resource.Resource, err)
+		}
+		documents := []string{fixturesHeader}
+		for _, item := range list.Items {
+			sanitizeFixture(&item)
+			document, err := yaml.Marshal(item.Object)
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			documents = append(documents, string(document))
+		}
+		path := filepath.Join(dir, resource.Resource+".yaml")
+		if err := os.WriteFile(path, []byte(strings.Join(documents, "---\n")), 0644); err != nil {
+			t.Fatalf("%+v", err)
+		}
+		t.Logf("exported %d %s to %q", len(list.Items), resource.Resource, path)
+	}
+}
+
+// sanitizeFixture removes the fields which are assigned by the API server, and
+// therefore change every time the fixtures are generated.
+func sanitizeFixture(obj *unstructured.Unstructured) {
+	obj.SetUID("")
+	obj.SetResourceVersion("")
+	obj.SetGeneration(0)
+	obj.SetCreationTimestamp(metav1.Time{})
+	obj.SetManagedFields(nil)
+	ownerReferences := obj.GetOwnerReferences()
+	for i := range ownerReferences {
+		ownerReferences[i].UID = ""
+	}
+	obj.SetOwnerReferences(ownerReferences)
+	unstructured.RemoveNestedField(obj.Object, "metadata", "creationTimestamp")
+}
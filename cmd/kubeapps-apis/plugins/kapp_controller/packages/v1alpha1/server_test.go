@@ -4,14 +4,23 @@
 package main
 
 import (
+	"archive/tar"
+	"bytes"
 	"context"
+	"crypto/ed25519"
+	"crypto/rand"
 	"errors"
 	"fmt"
+	"io"
 	"log"
+	"net"
 	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"runtime"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"testing"
@@ -23,11 +32,23 @@ import (
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	k8stesting "k8s.io/client-go/testing"
 
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/cppforlife/go-cli-ui/ui"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
 	kappctrlv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/kappctrl/v1alpha1"
 	packagingv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/packaging/v1alpha1"
 	datapackagingv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apiserver/apis/datapackaging/v1alpha1"
@@ -44,11 +65,17 @@ import (
 	kappcorev1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/plugins/kapp_controller/packages/v1alpha1"
 	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/clientgetter"
 	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/pkgutils"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/resources"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
 	k8scorev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	k8sruntime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
 	disfake "k8s.io/client-go/discovery/fake"
 	"k8s.io/client-go/dynamic"
 	dynfake "k8s.io/client-go/dynamic/fake"
@@ -59,24 +86,36 @@ import (
 
 var ignoreUnexported = cmpopts.IgnoreUnexported(
 	anypb.Any{},
+	timestamppb.Timestamp{},
+	kappcorev1.GetInstalledPackageReconcileHistoryResponse{},
+	kappcorev1.ReconcileOutcome{},
 	corev1.AvailablePackageDetail{},
 	corev1.AvailablePackageReference{},
 	corev1.AvailablePackageSummary{},
 	corev1.Context{},
+	corev1.CheckInstalledPackageNameAvailabilityResponse{},
+	corev1.UpgradeImpact{},
+	corev1.PackageVersionDeprecation{},
 	corev1.CreateInstalledPackageResponse{},
 	corev1.CreateInstalledPackageResponse{},
 	corev1.DeleteInstalledPackageResponse{},
+	corev1.GetAvailablePackageChangelogResponse{},
 	corev1.GetAvailablePackageVersionsResponse{},
 	corev1.GetAvailablePackageVersionsResponse{},
 	corev1.GetInstalledPackageResourceRefsResponse{},
 	corev1.GetInstalledPackageResourceRefsResponse{},
+	corev1.GetInstalledPackageOperationStatusResponse{},
 	corev1.GetPackageRepositoryDetailResponse{},
 	corev1.InstalledPackageDetail{},
+	corev1.InstalledPackageMetadata{},
+	corev1.InstalledPackageOperationPhase{},
 	corev1.InstalledPackageReference{},
 	corev1.InstalledPackageStatus{},
 	corev1.InstalledPackageSummary{},
 	corev1.Maintainer{},
+	corev1.NamespaceFailure{},
 	corev1.PackageAppVersion{},
+	corev1.PackageVersionChangelog{},
 	corev1.PackageRepositoryAuth{},
 	corev1.PackageRepositoryAuth_DockerCreds{},
 	corev1.PackageRepositoryAuth_Header{},
@@ -87,8 +126,10 @@ var ignoreUnexported = cmpopts.IgnoreUnexported(
 	corev1.PackageRepositoryDetail{},
 	corev1.PackageRepositoryReference{},
 	corev1.PackageRepositoryStatus{},
+	corev1.PackageRepositoryCondition{},
 	corev1.GetPackageRepositorySummariesResponse{},
 	corev1.PackageRepositorySummary{},
+	corev1.PartialResults{},
 	corev1.ReconciliationOptions{},
 	corev1.ResourceRef{},
 	corev1.DockerCredentials{},
@@ -102,6 +143,12 @@ var ignoreUnexported = cmpopts.IgnoreUnexported(
 	pluginv1.Plugin{},
 	corev1.GetPackageRepositoryPermissionsResponse{},
 	corev1.PackageRepositoriesPermissions{},
+	corev1.DeletePackageRepositoryResponse{},
+	corev1.PackageRepositorySecretUsage{},
+	kappcorev1.ResourceChange{},
+	kappcorev1.PackageRepositoryProbe{},
+	kappcorev1.ScanPackageRepositoryHostKeysResponse{},
+	kappcorev1.PackageRepositoryHostKey{},
 )
 
 const demoGlobalPackagingNamespace = "kapp-controller-packaging-global"
@@ -1109,89 +1156,68 @@ func TestGetAvailablePackageSummaries(t *testing.T) {
 			},
 			expectedPackages: []*corev1.AvailablePackageSummary{},
 		},
-	}
-
-	//nolint:govet
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			var unstructuredObjects []k8sruntime.Object
-			for _, obj := range tc.existingObjects {
-				unstructuredContent, _ := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(obj)
-				unstructuredObjects = append(unstructuredObjects, &unstructured.Unstructured{Object: unstructuredContent})
-			}
-
-			s := Server{
-				pluginConfig: defaultPluginConfig,
-				clientGetter: clientgetter.NewBuilder().
-					WithDynamic(dynfake.NewSimpleDynamicClientWithCustomListKinds(
-						k8sruntime.NewScheme(),
-						map[schema.GroupVersionResource]string{
-							{Group: datapackagingv1alpha1.SchemeGroupVersion.Group, Version: datapackagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgsResource}:         pkgResource + "List",
-							{Group: datapackagingv1alpha1.SchemeGroupVersion.Group, Version: datapackagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgMetadatasResource}: pkgMetadataResource + "List",
-						},
-						unstructuredObjects...,
-					)).
-					Build(),
-			}
-
-			response, err := s.GetAvailablePackageSummaries(context.Background(), connect.NewRequest(&corev1.GetAvailablePackageSummariesRequest{
-				Context:           defaultContext,
-				PaginationOptions: &tc.paginationOptions,
-				FilterOptions:     &tc.filterOptions,
-			}))
-
-			if got, want := connect.CodeOf(err), tc.expectedErrorCode; err != nil && got != want {
-				t.Fatalf("got: %d, want: %d, err: %+v", got, want, err)
-			}
-			// If we were expecting an error, continue to the next test.
-			if tc.expectedErrorCode != 0 {
-				return
-			}
-
-			if got, want := response.Msg.AvailablePackageSummaries, tc.expectedPackages; !cmp.Equal(got, want, ignoreUnexported) {
-				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexported))
-			}
-		})
-	}
-}
-
-func TestGetAvailablePackageVersions(t *testing.T) {
-	testCases := []struct {
-		name              string
-		existingObjects   []k8sruntime.Object
-		request           *corev1.GetAvailablePackageVersionsRequest
-		expectedErrorCode connect.Code
-		expectedResponse  *corev1.GetAvailablePackageVersionsResponse
-	}{
-		{
-			name:              "it returns invalid argument if called without a package reference",
-			request:           nil,
-			expectedErrorCode: connect.CodeInvalidArgument,
-		},
 		{
-			name: "it returns invalid argument if called without namespace",
-			request: &corev1.GetAvailablePackageVersionsRequest{
-				AvailablePackageRef: &corev1.AvailablePackageReference{
-					Context:    &corev1.Context{},
-					Identifier: "unknown/package-one",
+			name: "it filters out the packages hidden by the policy of their repository",
+			existingObjects: []k8sruntime.Object{
+				&packagingv1alpha1.PackageRepository{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       pkgRepositoryResource,
+						APIVersion: packagingAPIVersion,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "tce-repo",
+						Annotations: map[string]string{
+							pkgutils.AnnotationAllowedPackagesKey: "*.foo.example.com",
+							pkgutils.AnnotationDeniedPackagesKey:  "tombi.*",
+						},
+					},
 				},
-			},
-			expectedErrorCode: connect.CodeInvalidArgument,
-		},
-		{
-			name: "it returns invalid argument if called without an identifier",
-			request: &corev1.GetAvailablePackageVersionsRequest{
-				AvailablePackageRef: &corev1.AvailablePackageReference{
-					Context: &corev1.Context{
-						Namespace: "kubeapps",
+				&datapackagingv1alpha1.PackageMetadata{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       pkgMetadataResource,
+						APIVersion: datapackagingAPIVersion,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:   "default",
+						Name:        "tetris.foo.example.com",
+						Annotations: map[string]string{REPO_REF_ANNOTATION: "default/tce-repo"},
+					},
+					Spec: datapackagingv1alpha1.PackageMetadataSpec{
+						DisplayName:      "Classic Tetris",
+						ShortDescription: "A great game for arcade gamers",
+					},
+				},
+				&datapackagingv1alpha1.PackageMetadata{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       pkgMetadataResource,
+						APIVersion: datapackagingAPIVersion,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:   "default",
+						Name:        "tombi.foo.example.com",
+						Annotations: map[string]string{REPO_REF_ANNOTATION: "default/tce-repo"},
+					},
+					Spec: datapackagingv1alpha1.PackageMetadataSpec{
+						DisplayName:      "Tombi!",
+						ShortDescription: "A great game for arcade gamers",
+					},
+				},
+				&datapackagingv1alpha1.PackageMetadata{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       pkgMetadataResource,
+						APIVersion: datapackagingAPIVersion,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:   "default",
+						Name:        "zelda.bar.example.com",
+						Annotations: map[string]string{REPO_REF_ANNOTATION: "default/tce-repo"},
+					},
+					Spec: datapackagingv1alpha1.PackageMetadataSpec{
+						DisplayName:      "Zelda",
+						ShortDescription: "A great game for arcade gamers",
 					},
 				},
-			},
-			expectedErrorCode: connect.CodeInvalidArgument,
-		},
-		{
-			name: "it returns the package version summary",
-			existingObjects: []k8sruntime.Object{
 				&datapackagingv1alpha1.Package{
 					TypeMeta: metav1.TypeMeta{
 						Kind:       pkgResource,
@@ -1202,12 +1228,8 @@ func TestGetAvailablePackageVersions(t *testing.T) {
 						Name:      "tetris.foo.example.com.1.2.3",
 					},
 					Spec: datapackagingv1alpha1.PackageSpec{
-						RefName:                         "tetris.foo.example.com",
-						Version:                         "1.2.3",
-						Licenses:                        []string{"my-license"},
-						ReleaseNotes:                    "release notes",
-						CapactiyRequirementsDescription: "capacity description",
-						ReleasedAt:                      metav1.Time{Time: time.Date(1984, time.June, 6, 0, 0, 0, 0, time.UTC)},
+						RefName: "tetris.foo.example.com",
+						Version: "1.2.3",
 					},
 				},
 				&datapackagingv1alpha1.Package{
@@ -1217,15 +1239,11 @@ func TestGetAvailablePackageVersions(t *testing.T) {
 					},
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: "default",
-						Name:      "tetris.foo.example.com.1.2.7",
+						Name:      "tombi.foo.example.com.1.2.5",
 					},
 					Spec: datapackagingv1alpha1.PackageSpec{
-						RefName:                         "tetris.foo.example.com",
-						Version:                         "1.2.7",
-						Licenses:                        []string{"my-license"},
-						ReleaseNotes:                    "release notes",
-						CapactiyRequirementsDescription: "capacity description",
-						ReleasedAt:                      metav1.Time{Time: time.Date(1984, time.June, 6, 0, 0, 0, 0, time.UTC)},
+						RefName: "tombi.foo.example.com",
+						Version: "1.2.5",
 					},
 				},
 				&datapackagingv1alpha1.Package{
@@ -1235,45 +1253,34 @@ func TestGetAvailablePackageVersions(t *testing.T) {
 					},
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: "default",
-						Name:      "tetris.foo.example.com.1.2.4",
+						Name:      "zelda.bar.example.com.1.0.0",
 					},
 					Spec: datapackagingv1alpha1.PackageSpec{
-						RefName:                         "tetris.foo.example.com",
-						Version:                         "1.2.4",
-						Licenses:                        []string{"my-license"},
-						ReleaseNotes:                    "release notes",
-						CapactiyRequirementsDescription: "capacity description",
-						ReleasedAt:                      metav1.Time{Time: time.Date(1984, time.June, 6, 0, 0, 0, 0, time.UTC)},
-					},
-				},
-			},
-			request: &corev1.GetAvailablePackageVersionsRequest{
-				AvailablePackageRef: &corev1.AvailablePackageReference{
-					Context: &corev1.Context{
-						Namespace: "default",
+						RefName: "zelda.bar.example.com",
+						Version: "1.0.0",
 					},
-					Identifier: "unknown/tetris.foo.example.com",
 				},
 			},
-			expectedResponse: &corev1.GetAvailablePackageVersionsResponse{
-				PackageAppVersions: []*corev1.PackageAppVersion{
-					{
-						PkgVersion: "1.2.7",
-						AppVersion: "1.2.7",
-					},
-					{
-						PkgVersion: "1.2.4",
-						AppVersion: "1.2.4",
+			expectedPackages: []*corev1.AvailablePackageSummary{
+				{
+					AvailablePackageRef: &corev1.AvailablePackageReference{
+						Context:    defaultContext,
+						Plugin:     &pluginDetail,
+						Identifier: "tce-repo/tetris.foo.example.com",
 					},
-					{
+					Name:        "tetris.foo.example.com",
+					DisplayName: "Classic Tetris",
+					LatestVersion: &corev1.PackageAppVersion{
 						PkgVersion: "1.2.3",
 						AppVersion: "1.2.3",
 					},
+					ShortDescription: "A great game for arcade gamers",
 				},
 			},
 		},
 	}
 
+	//nolint:govet
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			var unstructuredObjects []k8sruntime.Object
@@ -1288,161 +1295,644 @@ func TestGetAvailablePackageVersions(t *testing.T) {
 					WithDynamic(dynfake.NewSimpleDynamicClientWithCustomListKinds(
 						k8sruntime.NewScheme(),
 						map[schema.GroupVersionResource]string{
-							{Group: datapackagingv1alpha1.SchemeGroupVersion.Group, Version: datapackagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgsResource}: pkgResource + "List",
+							{Group: datapackagingv1alpha1.SchemeGroupVersion.Group, Version: datapackagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgsResource}:         pkgResource + "List",
+							{Group: datapackagingv1alpha1.SchemeGroupVersion.Group, Version: datapackagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgMetadatasResource}: pkgMetadataResource + "List",
+							{Group: packagingv1alpha1.SchemeGroupVersion.Group, Version: packagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgRepositoriesResource}:      pkgRepositoryResource + "List",
 						},
 						unstructuredObjects...,
 					)).
 					Build(),
 			}
 
-			response, err := s.GetAvailablePackageVersions(context.Background(), connect.NewRequest(tc.request))
+			response, err := s.GetAvailablePackageSummaries(context.Background(), connect.NewRequest(&corev1.GetAvailablePackageSummariesRequest{
+				Context:           defaultContext,
+				PaginationOptions: &tc.paginationOptions,
+				FilterOptions:     &tc.filterOptions,
+			}))
 
 			if got, want := connect.CodeOf(err), tc.expectedErrorCode; err != nil && got != want {
-				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+				t.Fatalf("got: %d, want: %d, err: %+v", got, want, err)
 			}
-
-			// We don't need to check anything else for non-OK codes.
+			// If we were expecting an error, continue to the next test.
 			if tc.expectedErrorCode != 0 {
 				return
 			}
 
-			if got, want := response.Msg, tc.expectedResponse; !cmp.Equal(want, got, ignoreUnexported) {
+			if got, want := response.Msg.AvailablePackageSummaries, tc.expectedPackages; !cmp.Equal(got, want, ignoreUnexported) {
 				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexported))
 			}
 		})
 	}
 }
 
-func TestGetAvailablePackageDetail(t *testing.T) {
+func TestGetAvailablePackageSummariesExcludingGlobalPackages(t *testing.T) {
+	metadata := func(name, repoRef string) *datapackagingv1alpha1.PackageMetadata {
+		return &datapackagingv1alpha1.PackageMetadata{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       pkgMetadataResource,
+				APIVersion: datapackagingAPIVersion,
+			},
+			// The aggregated API returns the global packages in the namespace
+			// of the request.
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "default",
+				Name:        name,
+				Annotations: map[string]string{REPO_REF_ANNOTATION: repoRef},
+			},
+		}
+	}
+	pkg := func(refName string) *datapackagingv1alpha1.Package {
+		return &datapackagingv1alpha1.Package{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       pkgResource,
+				APIVersion: datapackagingAPIVersion,
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      refName + ".1.0.0",
+			},
+			Spec: datapackagingv1alpha1.PackageSpec{
+				RefName: refName,
+				Version: "1.0.0",
+			},
+		}
+	}
+	existingObjects := []k8sruntime.Object{
+		metadata("global.example.com", "kapp-controller-packaging-global/global-repo"),
+		metadata("local.example.com", "default/tce-repo"),
+		pkg("global.example.com"),
+		pkg("local.example.com"),
+	}
+
 	testCases := []struct {
-		name            string
-		existingObjects []k8sruntime.Object
-		expectedPackage *corev1.AvailablePackageDetail
-		errorCode       connect.Code
-		request         *corev1.GetAvailablePackageDetailRequest
+		name                            string
+		filterOptions                   *corev1.FilterOptions
+		excludeGlobalPackagesNamespaces []string
+		expectedNames                   []string
 	}{
 		{
-			name: "it returns an availablePackageDetail of the latest version",
-			request: &corev1.GetAvailablePackageDetailRequest{
-				AvailablePackageRef: &corev1.AvailablePackageReference{
-					Context:    defaultContext,
-					Identifier: "unknown/tetris.foo.example.com",
-				},
+			name:          "it returns the global packages by default",
+			expectedNames: []string{"global.example.com", "local.example.com"},
+		},
+		{
+			name:          "it excludes the global packages when requested",
+			filterOptions: &corev1.FilterOptions{ExcludeGlobalPackages: true},
+			expectedNames: []string{"local.example.com"},
+		},
+		{
+			name:                            "it excludes the global packages in the configured namespaces",
+			excludeGlobalPackagesNamespaces: []string{"default"},
+			expectedNames:                   []string{"local.example.com"},
+		},
+		{
+			name:                            "it returns the global packages in other namespaces than the configured ones",
+			excludeGlobalPackagesNamespaces: []string{"other"},
+			expectedNames:                   []string{"global.example.com", "local.example.com"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var unstructuredObjects []k8sruntime.Object
+			for _, obj := range existingObjects {
+				unstructuredContent, _ := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(obj)
+				unstructuredObjects = append(unstructuredObjects, &unstructured.Unstructured{Object: unstructuredContent})
+			}
+			pluginConfig := *defaultPluginConfig
+			pluginConfig.excludeGlobalPackagesNamespaces = tc.excludeGlobalPackagesNamespaces
+
+			s := Server{
+				pluginConfig: &pluginConfig,
+				clientGetter: clientgetter.NewBuilder().
+					WithDynamic(dynfake.NewSimpleDynamicClientWithCustomListKinds(
+						k8sruntime.NewScheme(),
+						map[schema.GroupVersionResource]string{
+							{Group: datapackagingv1alpha1.SchemeGroupVersion.Group, Version: datapackagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgsResource}:         pkgResource + "List",
+							{Group: datapackagingv1alpha1.SchemeGroupVersion.Group, Version: datapackagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgMetadatasResource}: pkgMetadataResource + "List",
+							{Group: packagingv1alpha1.SchemeGroupVersion.Group, Version: packagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgRepositoriesResource}:      pkgRepositoryResource + "List",
+						},
+						unstructuredObjects...,
+					)).
+					Build(),
+			}
+
+			response, err := s.GetAvailablePackageSummaries(context.Background(), connect.NewRequest(&corev1.GetAvailablePackageSummariesRequest{
+				Context:       defaultContext,
+				FilterOptions: tc.filterOptions,
+			}))
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+
+			names := []string{}
+			for _, summary := range response.Msg.AvailablePackageSummaries {
+				names = append(names, summary.Name)
+			}
+			if got, want := names, tc.expectedNames; !cmp.Equal(got, want) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
+func TestGetAvailablePackageSummariesPoliciesOfUnlistableRepositories(t *testing.T) {
+	globalNamespace := defaultPluginConfig.globalPackagingNamespace
+	toUnstructured := func(objects ...k8sruntime.Object) []k8sruntime.Object {
+		var unstructuredObjects []k8sruntime.Object
+		for _, obj := range objects {
+			unstructuredContent, _ := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(obj)
+			unstructuredObjects = append(unstructuredObjects, &unstructured.Unstructured{Object: unstructuredContent})
+		}
+		return unstructuredObjects
+	}
+	metadata := func(name, repoRef string) *datapackagingv1alpha1.PackageMetadata {
+		return &datapackagingv1alpha1.PackageMetadata{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       pkgMetadataResource,
+				APIVersion: datapackagingAPIVersion,
 			},
-			existingObjects: []k8sruntime.Object{
-				&datapackagingv1alpha1.PackageMetadata{
-					TypeMeta: metav1.TypeMeta{
-						Kind:       pkgMetadataResource,
-						APIVersion: datapackagingAPIVersion,
-					},
-					ObjectMeta: metav1.ObjectMeta{
-						Namespace: "default",
-						Name:      "tetris.foo.example.com",
-					},
-					Spec: datapackagingv1alpha1.PackageMetadataSpec{
-						DisplayName:        "Classic Tetris",
-						IconSVGBase64:      "Tm90IHJlYWxseSBTVkcK",
-						ShortDescription:   "A great game for arcade gamers",
-						LongDescription:    "A few sentences but not really a readme",
-						Categories:         []string{"logging", "daemon-set"},
-						Maintainers:        []datapackagingv1alpha1.Maintainer{{Name: "person1"}, {Name: "person2"}},
-						SupportDescription: "Some support information",
-						ProviderName:       "Tetris inc.",
-					},
-				},
-				&datapackagingv1alpha1.Package{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "default",
+				Name:        name,
+				Annotations: map[string]string{REPO_REF_ANNOTATION: repoRef},
+			},
+		}
+	}
+	pkg := func(refName string) *datapackagingv1alpha1.Package {
+		return &datapackagingv1alpha1.Package{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       pkgResource,
+				APIVersion: datapackagingAPIVersion,
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      refName + ".1.0.0",
+			},
+			Spec: datapackagingv1alpha1.PackageSpec{
+				RefName: refName,
+				Version: "1.0.0",
+			},
+		}
+	}
+	listKinds := map[schema.GroupVersionResource]string{
+		{Group: datapackagingv1alpha1.SchemeGroupVersion.Group, Version: datapackagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgsResource}:         pkgResource + "List",
+		{Group: datapackagingv1alpha1.SchemeGroupVersion.Group, Version: datapackagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgMetadatasResource}: pkgMetadataResource + "List",
+		{Group: packagingv1alpha1.SchemeGroupVersion.Group, Version: packagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgRepositoriesResource}:      pkgRepositoryResource + "List",
+	}
+	// The user can list the packages but not the repositories.
+	userClient := dynfake.NewSimpleDynamicClientWithCustomListKinds(k8sruntime.NewScheme(), listKinds, toUnstructured(
+		metadata("tetris.example.com", globalNamespace+"/global-repo"),
+		metadata("tombi.example.com", globalNamespace+"/global-repo"),
+		pkg("tetris.example.com"),
+		pkg("tombi.example.com"),
+	)...)
+	userClient.PrependReactor("list", pkgRepositoriesResource, func(action k8stesting.Action) (handled bool, ret k8sruntime.Object, err error) {
+		return true, nil, k8sErrors.NewForbidden(authorizationv1.Resource("PackageRepository"), "", errors.New("bang"))
+	})
+
+	testCases := []struct {
+		name              string
+		serviceAccountErr error
+		expectedNames     []string
+		expectedErrorCode connect.Code
+	}{
+		{
+			name:          "it applies the policies read with the service account",
+			expectedNames: []string{"tetris.example.com"},
+		},
+		{
+			name:              "it returns an error when the policies cannot be read",
+			serviceAccountErr: errors.New("bang"),
+			expectedErrorCode: connect.CodeInternal,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			serviceAccountClient := dynfake.NewSimpleDynamicClientWithCustomListKinds(k8sruntime.NewScheme(), listKinds, toUnstructured(
+				&packagingv1alpha1.PackageRepository{
 					TypeMeta: metav1.TypeMeta{
-						Kind:       pkgResource,
-						APIVersion: datapackagingAPIVersion,
+						Kind:       pkgRepositoryResource,
+						APIVersion: packagingAPIVersion,
 					},
 					ObjectMeta: metav1.ObjectMeta{
-						Namespace: "default",
-						Name:      "tetris.foo.example.com.1.2.3",
-					},
-					Spec: datapackagingv1alpha1.PackageSpec{
-						RefName:                         "tetris.foo.example.com",
-						Version:                         "1.2.3",
-						Licenses:                        []string{"my-license"},
-						ReleaseNotes:                    "release notes",
-						CapactiyRequirementsDescription: "capacity description",
-						ReleasedAt:                      metav1.Time{Time: time.Date(1984, time.June, 6, 0, 0, 0, 0, time.UTC)},
+						Namespace:   globalNamespace,
+						Name:        "global-repo",
+						Annotations: map[string]string{pkgutils.AnnotationDeniedPackagesKey: "tombi.*"},
 					},
 				},
+			)...)
+			if tc.serviceAccountErr != nil {
+				serviceAccountClient.PrependReactor("list", pkgRepositoriesResource, func(action k8stesting.Action) (handled bool, ret k8sruntime.Object, err error) {
+					return true, nil, tc.serviceAccountErr
+				})
+			}
+			s := Server{
+				pluginConfig:                    defaultPluginConfig,
+				clientGetter:                    clientgetter.NewBuilder().WithDynamic(userClient).Build(),
+				localServiceAccountClientGetter: clientgetter.NewBuilder().WithDynamic(serviceAccountClient).BuildFixedCluster(),
+				globalPackagingCluster:          defaultContext.Cluster,
+			}
+
+			response, err := s.GetAvailablePackageSummaries(context.Background(), connect.NewRequest(&corev1.GetAvailablePackageSummariesRequest{
+				Context: defaultContext,
+			}))
+			if got, want := connect.CodeOf(err), tc.expectedErrorCode; err != nil && got != want {
+				t.Fatalf("got: %d, want: %d, err: %+v", got, want, err)
+			}
+			if tc.expectedErrorCode != 0 {
+				if err == nil {
+					t.Fatalf("got: nil, want: error with code %d", tc.expectedErrorCode)
+				}
+				return
+			}
+
+			names := []string{}
+			for _, summary := range response.Msg.AvailablePackageSummaries {
+				names = append(names, summary.Name)
+			}
+			if got, want := names, tc.expectedNames; !cmp.Equal(got, want) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
+func TestPkgRepoRefCacheWatch(t *testing.T) {
+	globalNamespace := defaultPluginConfig.globalPackagingNamespace
+	metadata := func(namespace, name, repoRef string) *unstructured.Unstructured {
+		unstructuredContent, _ := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(&datapackagingv1alpha1.PackageMetadata{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       pkgMetadataResource,
+				APIVersion: datapackagingAPIVersion,
 			},
-			expectedPackage: &corev1.AvailablePackageDetail{
-				Name:            "tetris.foo.example.com",
-				DisplayName:     "Classic Tetris",
-				LongDescription: "A few sentences but not really a readme",
-				Version: &corev1.PackageAppVersion{
-					PkgVersion: "1.2.3",
-					AppVersion: "1.2.3",
-				},
-				Maintainers:      []*corev1.Maintainer{{Name: "person1"}, {Name: "person2"}},
-				IconUrl:          "data:image/svg+xml;base64,Tm90IHJlYWxseSBTVkcK",
-				ShortDescription: "A great game for arcade gamers",
-				Categories:       []string{"logging", "daemon-set"},
-				Readme: `## Description
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   namespace,
+				Name:        name,
+				Annotations: map[string]string{REPO_REF_ANNOTATION: repoRef},
+			},
+		})
+		return &unstructured.Unstructured{Object: unstructuredContent}
+	}
+	repository := &packagingv1alpha1.PackageRepository{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       pkgRepositoryResource,
+			APIVersion: packagingAPIVersion,
+		},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "local-repo"},
+	}
+	unstructuredRepository, _ := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(repository)
 
-A few sentences but not really a readme
+	metadataGVR := datapackagingv1alpha1.SchemeGroupVersion.WithResource(pkgMetadatasResource)
+	repositoryGVR := packagingv1alpha1.SchemeGroupVersion.WithResource(pkgRepositoriesResource)
+	dynamicClient := dynfake.NewSimpleDynamicClientWithCustomListKinds(
+		k8sruntime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			metadataGVR:   pkgMetadataResource + "List",
+			repositoryGVR: pkgRepositoryResource + "List",
+		},
+		metadata("default", "local.example.com", "default/local-repo"),
+		metadata(globalNamespace, "global.example.com", globalNamespace+"/global-repo"),
+		&unstructured.Unstructured{Object: unstructuredRepository},
+	)
+	pluginConfig := *defaultPluginConfig
+	pluginConfig.watchPackageRepositoryRefs = true
+	s := Server{
+		pluginConfig:           &pluginConfig,
+		globalPackagingCluster: defaultContext.Cluster,
+		pkgRepoRefs:            newPkgRepoRefCache(),
+	}
 
-## Capactiy requirements
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.pkgRepoRefs.start(ctx, func(ctx context.Context) (dynamic.Interface, error) {
+		return dynamicClient, nil
+	})
 
-capacity description
+	// The metadatas without annotation can only be resolved from the cache.
+	identifier := func(namespace, name string) string {
+		return s.packageIdentifier(&datapackagingv1alpha1.PackageMetadata{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		}, defaultContext.Cluster)
+	}
+	waitForIdentifier := func(namespace, name, expected string) {
+		t.Helper()
+		err := wait.PollImmediate(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+			return identifier(namespace, name) == expected, nil
+		})
+		if err != nil {
+			t.Fatalf("got: %q, want: %q", identifier(namespace, name), expected)
+		}
+	}
+	// Both watches must be established before changing the objects, as the
+	// fake client does not replay the changes made before.
+	err := wait.PollImmediate(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+		watches := 0
+		for _, action := range dynamicClient.Actions() {
+			if action.GetVerb() == "watch" {
+				watches++
+			}
+		}
+		return watches == 2, nil
+	})
+	if err != nil {
+		t.Fatalf("the package metadatas and repositories are not watched: %+v", err)
+	}
 
-## Release notes
+	t.Run("it resolves the listed package metadatas", func(t *testing.T) {
+		waitForIdentifier("default", "local.example.com", "local-repo/local.example.com")
+	})
 
-release notes
+	t.Run("it resolves the global package metadatas from the namespaces", func(t *testing.T) {
+		waitForIdentifier("default", "global.example.com", "global-repo/global.example.com")
+	})
 
-Released at: June, 6 1984
+	t.Run("it resolves the package metadatas added later", func(t *testing.T) {
+		_, err := dynamicClient.Resource(metadataGVR).Namespace("default").Create(ctx, metadata("default", "added.example.com", "default/local-repo"), metav1.CreateOptions{})
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		waitForIdentifier("default", "added.example.com", "local-repo/added.example.com")
+	})
 
-## Support
+	t.Run("it drops the package metadatas of a deleted repository", func(t *testing.T) {
+		err := dynamicClient.Resource(repositoryGVR).Namespace("default").Delete(ctx, "local-repo", metav1.DeleteOptions{})
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		waitForIdentifier("default", "local.example.com", DEFAULT_REPO_NAME+"/local.example.com")
+		waitForIdentifier("default", "added.example.com", DEFAULT_REPO_NAME+"/added.example.com")
+		waitForIdentifier("default", "global.example.com", "global-repo/global.example.com")
+	})
 
-Some support information
+	t.Run("it does not use the cache when the watch is disabled", func(t *testing.T) {
+		pluginConfig.watchPackageRepositoryRefs = false
+		if got, want := identifier("default", "global.example.com"), DEFAULT_REPO_NAME+"/global.example.com"; got != want {
+			t.Errorf("got: %q, want: %q", got, want)
+		}
+	})
+}
 
-## Licenses
+// chunkedDynamicClient wraps a fake dynamic client so that lists honour the
+// limit and continue options, as the aggregated API does, recording the
+// continue tokens requested. The continue token is the offset of the chunk,
+// or "expired" to simulate an expired token.
+type chunkedDynamicClient struct {
+	dynamic.Interface
+	continueTokens map[string][]string
+}
 
-- my-license
+type chunkedNamespaceableResource struct {
+	dynamic.NamespaceableResourceInterface
+	client   *chunkedDynamicClient
+	resource string
+}
 
-`,
-				AvailablePackageRef: &corev1.AvailablePackageReference{
-					Context:    defaultContext,
-					Identifier: "unknown/tetris.foo.example.com",
-					Plugin:     &pluginDetail,
+type chunkedResource struct {
+	dynamic.ResourceInterface
+	client   *chunkedDynamicClient
+	resource string
+}
+
+func (c *chunkedDynamicClient) Resource(gvr schema.GroupVersionResource) dynamic.NamespaceableResourceInterface {
+	return &chunkedNamespaceableResource{c.Interface.Resource(gvr), c, gvr.Resource}
+}
+
+func (r *chunkedNamespaceableResource) Namespace(namespace string) dynamic.ResourceInterface {
+	return &chunkedResource{r.NamespaceableResourceInterface.Namespace(namespace), r.client, r.resource}
+}
+
+func (r *chunkedResource) List(ctx context.Context, opts metav1.ListOptions) (*unstructured.UnstructuredList, error) {
+	r.client.continueTokens[r.resource] = append(r.client.continueTokens[r.resource], opts.Continue)
+	if opts.Continue == "expired" {
+		return nil, k8sErrors.NewResourceExpired("the continue token has expired")
+	}
+	list, err := r.ResourceInterface.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(list.Items, func(i, j int) bool { return list.Items[i].GetName() < list.Items[j].GetName() })
+	start := 0
+	if opts.Continue != "" {
+		if start, err = strconv.Atoi(opts.Continue); err != nil {
+			return nil, k8sErrors.NewBadRequest(err.Error())
+		}
+	}
+	end := len(list.Items)
+	if opts.Limit > 0 && start+int(opts.Limit) < end {
+		end = start + int(opts.Limit)
+		list.SetContinue(fmt.Sprintf("%d", end))
+	}
+	list.Items = list.Items[start:end]
+	return list, nil
+}
+
+func TestGetAvailablePackageSummariesInChunks(t *testing.T) {
+	defer func(chunkSize int64) { pkgListChunkSize = chunkSize }(pkgListChunkSize)
+	pkgListChunkSize = 2
+
+	names := []string{"apple", "banana", "cherry", "damson", "elderberry"}
+	var unstructuredObjects []k8sruntime.Object
+	for _, name := range names {
+		for _, obj := range []k8sruntime.Object{
+			&datapackagingv1alpha1.PackageMetadata{
+				TypeMeta:   metav1.TypeMeta{Kind: pkgMetadataResource, APIVersion: datapackagingAPIVersion},
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name + ".example.com"},
+				Spec: datapackagingv1alpha1.PackageMetadataSpec{
+					DisplayName: name,
+					// Only some of the packages match the query used below.
+					ShortDescription: map[bool]string{true: "a fruit with a stone", false: "a fruit"}[name == "cherry" || name == "damson"],
+				},
+			},
+			&datapackagingv1alpha1.Package{
+				TypeMeta:   metav1.TypeMeta{Kind: pkgResource, APIVersion: datapackagingAPIVersion},
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name + ".example.com.1.0.0"},
+				Spec: datapackagingv1alpha1.PackageSpec{
+					RefName: name + ".example.com",
+					Version: "1.0.0",
 				},
 			},
+		} {
+			unstructuredContent, _ := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(obj)
+			unstructuredObjects = append(unstructuredObjects, &unstructured.Unstructured{Object: unstructuredContent})
+		}
+	}
+
+	newServer := func() (*Server, *chunkedDynamicClient) {
+		dynClient := &chunkedDynamicClient{
+			Interface: dynfake.NewSimpleDynamicClientWithCustomListKinds(
+				k8sruntime.NewScheme(),
+				map[schema.GroupVersionResource]string{
+					{Group: datapackagingv1alpha1.SchemeGroupVersion.Group, Version: datapackagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgsResource}:         pkgResource + "List",
+					{Group: datapackagingv1alpha1.SchemeGroupVersion.Group, Version: datapackagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgMetadatasResource}: pkgMetadataResource + "List",
+					{Group: packagingv1alpha1.SchemeGroupVersion.Group, Version: packagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgRepositoriesResource}:      pkgRepositoryResource + "List",
+				},
+				unstructuredObjects...,
+			),
+			continueTokens: map[string][]string{},
+		}
+		return &Server{
+			pluginConfig:     defaultPluginConfig,
+			clientGetter:     clientgetter.NewBuilder().WithDynamic(dynClient).Build(),
+			pkgListPositions: newPkgListPositionCache(),
+		}, dynClient
+	}
+
+	type page struct {
+		pageToken             string
+		expectedNames         []string
+		expectedNextPageToken string
+		// The total size is only known on the last page.
+		expectedTotalSize *int32
+		// The continue tokens used to list the package metadatas.
+		expectedContinueTokens []string
+	}
+
+	testCases := []struct {
+		name          string
+		filterOptions *corev1.FilterOptions
+		pageSize      int32
+		pages         []page
+	}{
+		{
+			name:     "it returns the pages resuming the listing from the continue token",
+			pageSize: 2,
+			pages: []page{
+				{"", []string{"apple", "banana"}, "2", nil, []string{""}},
+				{"2", []string{"cherry", "damson"}, "4", nil, []string{"2"}},
+				{"4", []string{"elderberry"}, "", proto.Int32(5), []string{"4"}},
+			},
 		},
 		{
-			name: "it returns an availablePackageDetail of the latest version with repo-based identifiers",
-			request: &corev1.GetAvailablePackageDetailRequest{
+			name:     "it resumes a page that starts within a chunk",
+			pageSize: 3,
+			pages: []page{
+				{"", []string{"apple", "banana", "cherry"}, "3", nil, []string{"", "2"}},
+				{"3", []string{"damson", "elderberry"}, "", proto.Int32(5), []string{"2", "4"}},
+			},
+		},
+		{
+			name:          "it fetches further chunks until the page of filtered results is complete",
+			filterOptions: &corev1.FilterOptions{Query: "stone"},
+			pageSize:      1,
+			pages: []page{
+				{"", []string{"cherry"}, "1", nil, []string{"", "2"}},
+				// The remaining chunks are not fetched until they are needed,
+				// so the last page may be empty.
+				{"1", []string{"damson"}, "2", nil, []string{"2"}},
+				{"2", []string{}, "", proto.Int32(2), []string{"4"}},
+			},
+		},
+		{
+			name:     "it walks the chunks from the beginning when the position is not known",
+			pageSize: 2,
+			pages: []page{
+				{"3", []string{"damson", "elderberry"}, "", proto.Int32(5), []string{"", "2", "4"}},
+			},
+		},
+		{
+			name:     "it returns all the packages in a single page without pagination",
+			pageSize: 0,
+			pages: []page{
+				{"", []string{"apple", "banana", "cherry", "damson", "elderberry"}, "", proto.Int32(5), []string{"", "2", "4"}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, dynClient := newServer()
+			for _, p := range tc.pages {
+				dynClient.continueTokens = map[string][]string{}
+				response, err := s.GetAvailablePackageSummaries(context.Background(), connect.NewRequest(&corev1.GetAvailablePackageSummariesRequest{
+					Context:           defaultContext,
+					PaginationOptions: &corev1.PaginationOptions{PageSize: tc.pageSize, PageToken: p.pageToken},
+					FilterOptions:     tc.filterOptions,
+				}))
+				if err != nil {
+					t.Fatalf("%+v", err)
+				}
+
+				gotNames := []string{}
+				for _, summary := range response.Msg.AvailablePackageSummaries {
+					gotNames = append(gotNames, summary.DisplayName)
+				}
+				if got, want := gotNames, p.expectedNames; !cmp.Equal(got, want) {
+					t.Errorf("page %q: mismatch (-want +got):\n%s", p.pageToken, cmp.Diff(want, got))
+				}
+				if got, want := response.Msg.NextPageToken, p.expectedNextPageToken; got != want {
+					t.Errorf("page %q: got: %q, want: %q", p.pageToken, got, want)
+				}
+				if got, want := response.Msg.TotalSize, p.expectedTotalSize; !cmp.Equal(got, want) {
+					t.Errorf("page %q: mismatch (-want +got):\n%s", p.pageToken, cmp.Diff(want, got))
+				}
+				if got, want := dynClient.continueTokens[pkgMetadatasResource], p.expectedContinueTokens; !cmp.Equal(got, want) {
+					t.Errorf("page %q: mismatch (-want +got):\n%s", p.pageToken, cmp.Diff(want, got))
+				}
+			}
+		})
+	}
+
+	t.Run("it starts from the beginning when the continue token expired", func(t *testing.T) {
+		s, dynClient := newServer()
+		s.pkgListPositions.set(pkgListPositionKey("default", "default", nil, pkgRepositoryPolicies{}, 2), pkgListPosition{Continue: "expired"})
+
+		response, err := s.GetAvailablePackageSummaries(context.Background(), connect.NewRequest(&corev1.GetAvailablePackageSummariesRequest{
+			Context:           defaultContext,
+			PaginationOptions: &corev1.PaginationOptions{PageSize: 2, PageToken: "2"},
+		}))
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		if got, want := len(response.Msg.AvailablePackageSummaries), 2; got != want {
+			t.Fatalf("got: %d, want: %d", got, want)
+		}
+		if got, want := response.Msg.AvailablePackageSummaries[0].DisplayName, "cherry"; got != want {
+			t.Errorf("got: %q, want: %q", got, want)
+		}
+		if got, want := dynClient.continueTokens[pkgMetadatasResource], []string{"expired", "", "2"}; !cmp.Equal(got, want) {
+			t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+		}
+	})
+}
+
+func TestGetAvailablePackageVersions(t *testing.T) {
+	testCases := []struct {
+		name              string
+		existingObjects   []k8sruntime.Object
+		request           *corev1.GetAvailablePackageVersionsRequest
+		expectedErrorCode connect.Code
+		expectedResponse  *corev1.GetAvailablePackageVersionsResponse
+	}{
+		{
+			name:              "it returns invalid argument if called without a package reference",
+			request:           nil,
+			expectedErrorCode: connect.CodeInvalidArgument,
+		},
+		{
+			name: "it returns invalid argument if called without namespace",
+			request: &corev1.GetAvailablePackageVersionsRequest{
 				AvailablePackageRef: &corev1.AvailablePackageReference{
-					Context:    defaultContext,
-					Identifier: "unknown/tetris.foo.example.com",
+					Context:    &corev1.Context{},
+					Identifier: "unknown/package-one",
 				},
 			},
-			existingObjects: []k8sruntime.Object{
-				&datapackagingv1alpha1.PackageMetadata{
-					TypeMeta: metav1.TypeMeta{
-						Kind:       pkgMetadataResource,
-						APIVersion: datapackagingAPIVersion,
-					},
-					ObjectMeta: metav1.ObjectMeta{
-						Namespace: "default",
-						Name:      "tetris.foo.example.com",
-						Annotations: map[string]string{
-							REPO_REF_ANNOTATION: "default/tce-repo",
-						},
-					},
-					Spec: datapackagingv1alpha1.PackageMetadataSpec{
-						DisplayName:        "Classic Tetris",
-						IconSVGBase64:      "Tm90IHJlYWxseSBTVkcK",
-						ShortDescription:   "A great game for arcade gamers",
-						LongDescription:    "A few sentences but not really a readme",
-						Categories:         []string{"logging", "daemon-set"},
-						Maintainers:        []datapackagingv1alpha1.Maintainer{{Name: "person1"}, {Name: "person2"}},
-						SupportDescription: "Some support information",
-						ProviderName:       "Tetris inc.",
+			expectedErrorCode: connect.CodeInvalidArgument,
+		},
+		{
+			name: "it returns invalid argument if called without an identifier",
+			request: &corev1.GetAvailablePackageVersionsRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context: &corev1.Context{
+						Namespace: "kubeapps",
 					},
 				},
+			},
+			expectedErrorCode: connect.CodeInvalidArgument,
+		},
+		{
+			name: "it returns the package version summary",
+			existingObjects: []k8sruntime.Object{
 				&datapackagingv1alpha1.Package{
 					TypeMeta: metav1.TypeMeta{
 						Kind:       pkgResource,
@@ -1461,76 +1951,22 @@ Some support information
 						ReleasedAt:                      metav1.Time{Time: time.Date(1984, time.June, 6, 0, 0, 0, 0, time.UTC)},
 					},
 				},
-			},
-			expectedPackage: &corev1.AvailablePackageDetail{
-				Name:            "tetris.foo.example.com",
-				DisplayName:     "Classic Tetris",
-				LongDescription: "A few sentences but not really a readme",
-				Version: &corev1.PackageAppVersion{
-					PkgVersion: "1.2.3",
-					AppVersion: "1.2.3",
-				},
-				Maintainers:      []*corev1.Maintainer{{Name: "person1"}, {Name: "person2"}},
-				IconUrl:          "data:image/svg+xml;base64,Tm90IHJlYWxseSBTVkcK",
-				ShortDescription: "A great game for arcade gamers",
-				Categories:       []string{"logging", "daemon-set"},
-				Readme: `## Description
-
-A few sentences but not really a readme
-
-## Capactiy requirements
-
-capacity description
-
-## Release notes
-
-release notes
-
-Released at: June, 6 1984
-
-## Support
-
-Some support information
-
-## Licenses
-
-- my-license
-
-`,
-				AvailablePackageRef: &corev1.AvailablePackageReference{
-					Context:    defaultContext,
-					Identifier: "tce-repo/tetris.foo.example.com",
-					Plugin:     &pluginDetail,
-				},
-			},
-		},
-		{
-			name: "it combines long description and support description for readme field",
-			request: &corev1.GetAvailablePackageDetailRequest{
-				AvailablePackageRef: &corev1.AvailablePackageReference{
-					Context:    defaultContext,
-					Identifier: "unknown/tetris.foo.example.com",
-				},
-			},
-			existingObjects: []k8sruntime.Object{
-				&datapackagingv1alpha1.PackageMetadata{
+				&datapackagingv1alpha1.Package{
 					TypeMeta: metav1.TypeMeta{
-						Kind:       pkgMetadataResource,
+						Kind:       pkgResource,
 						APIVersion: datapackagingAPIVersion,
 					},
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: "default",
-						Name:      "tetris.foo.example.com",
+						Name:      "tetris.foo.example.com.1.2.7",
 					},
-					Spec: datapackagingv1alpha1.PackageMetadataSpec{
-						DisplayName:        "Classic Tetris",
-						IconSVGBase64:      "Tm90IHJlYWxseSBTVkcK",
-						ShortDescription:   "A great game for arcade gamers",
-						LongDescription:    "A few sentences but not really a readme",
-						Categories:         []string{"logging", "daemon-set"},
-						Maintainers:        []datapackagingv1alpha1.Maintainer{{Name: "person1"}, {Name: "person2"}},
-						SupportDescription: "Some support information",
-						ProviderName:       "Tetris inc.",
+					Spec: datapackagingv1alpha1.PackageSpec{
+						RefName:                         "tetris.foo.example.com",
+						Version:                         "1.2.7",
+						Licenses:                        []string{"my-license"},
+						ReleaseNotes:                    "release notes",
+						CapactiyRequirementsDescription: "capacity description",
+						ReleasedAt:                      metav1.Time{Time: time.Date(1984, time.June, 6, 0, 0, 0, 0, time.UTC)},
 					},
 				},
 				&datapackagingv1alpha1.Package{
@@ -1540,11 +1976,11 @@ Some support information
 					},
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: "default",
-						Name:      "tetris.foo.example.com.1.2.3",
+						Name:      "tetris.foo.example.com.1.2.4",
 					},
 					Spec: datapackagingv1alpha1.PackageSpec{
 						RefName:                         "tetris.foo.example.com",
-						Version:                         "1.2.3",
+						Version:                         "1.2.4",
 						Licenses:                        []string{"my-license"},
 						ReleaseNotes:                    "release notes",
 						CapactiyRequirementsDescription: "capacity description",
@@ -1552,85 +1988,50 @@ Some support information
 					},
 				},
 			},
-			expectedPackage: &corev1.AvailablePackageDetail{
-				Name:            "tetris.foo.example.com",
-				DisplayName:     "Classic Tetris",
-				LongDescription: "A few sentences but not really a readme",
-				Version: &corev1.PackageAppVersion{
-					PkgVersion: "1.2.3",
-					AppVersion: "1.2.3",
-				},
-				Maintainers:      []*corev1.Maintainer{{Name: "person1"}, {Name: "person2"}},
-				IconUrl:          "data:image/svg+xml;base64,Tm90IHJlYWxseSBTVkcK",
-				ShortDescription: "A great game for arcade gamers",
-				Categories:       []string{"logging", "daemon-set"},
-				Readme: `## Description
-
-A few sentences but not really a readme
-
-## Capactiy requirements
-
-capacity description
-
-## Release notes
-
-release notes
-
-Released at: June, 6 1984
-
-## Support
-
-Some support information
-
-## Licenses
-
-- my-license
-
-`,
+			request: &corev1.GetAvailablePackageVersionsRequest{
 				AvailablePackageRef: &corev1.AvailablePackageReference{
-					Context:    defaultContext,
+					Context: &corev1.Context{
+						Namespace: "default",
+					},
 					Identifier: "unknown/tetris.foo.example.com",
-					Plugin:     &pluginDetail,
 				},
 			},
-		},
-		{
-			name: "it returns an invalid arg error status if no context is provided",
-			request: &corev1.GetAvailablePackageDetailRequest{
-				AvailablePackageRef: &corev1.AvailablePackageReference{
-					Identifier: "unknown/foo/bar",
+			expectedResponse: &corev1.GetAvailablePackageVersionsResponse{
+				PackageAppVersions: []*corev1.PackageAppVersion{
+					{
+						PkgVersion: "1.2.7",
+						AppVersion: "1.2.7",
+					},
+					{
+						PkgVersion: "1.2.4",
+						AppVersion: "1.2.4",
+					},
+					{
+						PkgVersion: "1.2.3",
+						AppVersion: "1.2.3",
+					},
 				},
 			},
-			errorCode: connect.CodeInvalidArgument,
 		},
 		{
-			name: "it returns not found error status if the requested package version doesn't exist",
-			request: &corev1.GetAvailablePackageDetailRequest{
-				AvailablePackageRef: &corev1.AvailablePackageReference{
-					Context:    defaultContext,
-					Identifier: "unknown/tetris.foo.example.com",
-				},
-				PkgVersion: "1.2.4",
-			},
+			name: "it flags the deprecated versions",
 			existingObjects: []k8sruntime.Object{
-				&datapackagingv1alpha1.PackageMetadata{
+				&datapackagingv1alpha1.Package{
 					TypeMeta: metav1.TypeMeta{
-						Kind:       pkgMetadataResource,
+						Kind:       pkgResource,
 						APIVersion: datapackagingAPIVersion,
 					},
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: "default",
-						Name:      "tetris.foo.example.com",
+						Name:      "tetris.foo.example.com.1.2.3",
+						Annotations: map[string]string{
+							annotationDeprecatedKey: "Upgrade to 2.0.0",
+							annotationEndOfLifeKey:  "2020-01-31",
+						},
 					},
-					Spec: datapackagingv1alpha1.PackageMetadataSpec{
-						DisplayName:        "Classic Tetris",
-						IconSVGBase64:      "Tm90IHJlYWxseSBTVkcK",
-						ShortDescription:   "A great game for arcade gamers",
-						LongDescription:    "A few sentences but not really a readme",
-						Categories:         []string{"logging", "daemon-set"},
-						Maintainers:        []datapackagingv1alpha1.Maintainer{{Name: "person1"}, {Name: "person2"}},
-						SupportDescription: "Some support information",
-						ProviderName:       "Tetris inc.",
+					Spec: datapackagingv1alpha1.PackageSpec{
+						RefName: "tetris.foo.example.com",
+						Version: "1.2.3",
 					},
 				},
 				&datapackagingv1alpha1.Package{
@@ -1640,95 +2041,55 @@ Some support information
 					},
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: "default",
-						Name:      "tetris.foo.example.com.1.2.3",
+						Name:      "tetris.foo.example.com.2.0.0",
 					},
 					Spec: datapackagingv1alpha1.PackageSpec{
-						RefName:                         "tetris.foo.example.com",
-						Version:                         "1.2.3",
-						Licenses:                        []string{"my-license"},
-						ReleaseNotes:                    "release notes",
-						CapactiyRequirementsDescription: "capacity description",
-						ReleasedAt:                      metav1.Time{Time: time.Date(1984, time.June, 6, 0, 0, 0, 0, time.UTC)},
+						RefName: "tetris.foo.example.com",
+						Version: "2.0.0",
 					},
 				},
 			},
-			errorCode: connect.CodeNotFound,
-		},
-	}
-
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			var unstructuredObjects []k8sruntime.Object
-			for _, obj := range tc.existingObjects {
-				unstructuredContent, _ := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(obj)
-				unstructuredObjects = append(unstructuredObjects, &unstructured.Unstructured{Object: unstructuredContent})
-			}
-
-			s := Server{
-				pluginConfig: defaultPluginConfig,
-				clientGetter: clientgetter.NewBuilder().
-					WithDynamic(dynfake.NewSimpleDynamicClientWithCustomListKinds(
-						k8sruntime.NewScheme(),
-						map[schema.GroupVersionResource]string{
-							{Group: datapackagingv1alpha1.SchemeGroupVersion.Group, Version: datapackagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgsResource}:         pkgResource + "List",
-							{Group: datapackagingv1alpha1.SchemeGroupVersion.Group, Version: datapackagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgMetadatasResource}: pkgMetadataResource + "List",
+			request: &corev1.GetAvailablePackageVersionsRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context: &corev1.Context{
+						Namespace: "default",
+					},
+					Identifier: "unknown/tetris.foo.example.com",
+				},
+			},
+			expectedResponse: &corev1.GetAvailablePackageVersionsResponse{
+				PackageAppVersions: []*corev1.PackageAppVersion{
+					{
+						PkgVersion: "2.0.0",
+						AppVersion: "2.0.0",
+					},
+					{
+						PkgVersion: "1.2.3",
+						AppVersion: "1.2.3",
+						Deprecation: &corev1.PackageVersionDeprecation{
+							Message:          "Upgrade to 2.0.0",
+							EndOfLife:        "2020-01-31",
+							EndOfLifeReached: true,
 						},
-						unstructuredObjects...,
-					)).Build(),
-			}
-			availablePackageDetail, err := s.GetAvailablePackageDetail(context.Background(), connect.NewRequest(tc.request))
-
-			if got, want := connect.CodeOf(err), tc.errorCode; err != nil && got != want {
-				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
-			}
-
-			if tc.errorCode == 0 {
-				if got, want := availablePackageDetail.Msg.AvailablePackageDetail, tc.expectedPackage; !cmp.Equal(got, want, ignoreUnexported) {
-					t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexported))
-				}
-			}
-		})
-	}
-}
-
-// installed packages
-
-func TestGetInstalledPackageSummaries(t *testing.T) {
-	testCases := []struct {
-		name              string
-		request           *corev1.GetInstalledPackageSummariesRequest
-		existingObjects   []k8sruntime.Object
-		expectedPackages  []*corev1.InstalledPackageSummary
-		expectedErrorCode connect.Code
-	}{
-		{
-			name: "it returns an error if a non-existent page is requested",
-			request: &corev1.GetInstalledPackageSummariesRequest{
-				Context: defaultContext,
-				PaginationOptions: &corev1.PaginationOptions{
-					PageToken: "2",
-					PageSize:  2,
+					},
 				},
 			},
+		},
+		{
+			name: "it returns only the versions satisfying the constraint",
 			existingObjects: []k8sruntime.Object{
-				&datapackagingv1alpha1.PackageMetadata{
+				&datapackagingv1alpha1.Package{
 					TypeMeta: metav1.TypeMeta{
-						Kind:       pkgMetadataResource,
+						Kind:       pkgResource,
 						APIVersion: datapackagingAPIVersion,
 					},
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: "default",
-						Name:      "tetris.foo.example.com",
+						Name:      "tetris.foo.example.com.1.2.3",
 					},
-					Spec: datapackagingv1alpha1.PackageMetadataSpec{
-						DisplayName:        "Classic Tetris",
-						IconSVGBase64:      "Tm90IHJlYWxseSBTVkcK",
-						ShortDescription:   "A great game for arcade gamers",
-						LongDescription:    "A few sentences but not really a readme",
-						Categories:         []string{"logging", "daemon-set"},
-						Maintainers:        []datapackagingv1alpha1.Maintainer{{Name: "person1"}, {Name: "person2"}},
-						SupportDescription: "Some support information",
-						ProviderName:       "Tetris inc.",
+					Spec: datapackagingv1alpha1.PackageSpec{
+						RefName: "tetris.foo.example.com",
+						Version: "1.2.3",
 					},
 				},
 				&datapackagingv1alpha1.Package{
@@ -1738,88 +2099,53 @@ func TestGetInstalledPackageSummaries(t *testing.T) {
 					},
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: "default",
-						Name:      "tetris.foo.example.com.1.2.3",
+						Name:      "tetris.foo.example.com.1.3.0",
 					},
 					Spec: datapackagingv1alpha1.PackageSpec{
-						RefName:                         "tetris.foo.example.com",
-						Version:                         "1.2.3",
-						Licenses:                        []string{"my-license"},
-						ReleaseNotes:                    "release notes",
-						CapactiyRequirementsDescription: "capacity description",
-						ReleasedAt:                      metav1.Time{Time: time.Date(1984, time.June, 6, 0, 0, 0, 0, time.UTC)},
+						RefName: "tetris.foo.example.com",
+						Version: "1.3.0",
 					},
 				},
-				&packagingv1alpha1.PackageInstall{
+				&datapackagingv1alpha1.Package{
 					TypeMeta: metav1.TypeMeta{
-						Kind:       pkgInstallResource,
-						APIVersion: packagingAPIVersion,
+						Kind:       pkgResource,
+						APIVersion: datapackagingAPIVersion,
 					},
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: "default",
-						Name:      "my-installation",
-					},
-					Spec: packagingv1alpha1.PackageInstallSpec{
-						ServiceAccountName: "default",
-						PackageRef: &packagingv1alpha1.PackageRef{
-							RefName: "tetris.foo.example.com",
-							VersionSelection: &vendirversions.VersionSelectionSemver{
-								Constraints: "1.2.3",
-							},
-						},
-						Values: []packagingv1alpha1.PackageInstallValues{{
-							SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{
-								Name: "my-installation-default-values",
-							},
-						},
-						},
-						Paused:     false,
-						Canceled:   false,
-						SyncPeriod: &metav1.Duration{Duration: (time.Second * 30)},
-						NoopDelete: false,
+						Name:      "tetris.foo.example.com.2.0.0",
 					},
-					Status: packagingv1alpha1.PackageInstallStatus{
-						GenericStatus: kappctrlv1alpha1.GenericStatus{
-							ObservedGeneration: 1,
-							Conditions: []kappctrlv1alpha1.Condition{{
-								Type:    kappctrlv1alpha1.ReconcileSucceeded,
-								Status:  k8scorev1.ConditionTrue,
-								Reason:  "baz",
-								Message: "qux",
-							}},
-							FriendlyDescription: "foo",
-							UsefulErrorMessage:  "Deployed",
-						},
-						Version:              "1.2.3",
-						LastAttemptedVersion: "1.2.3",
+					Spec: datapackagingv1alpha1.PackageSpec{
+						RefName: "tetris.foo.example.com",
+						Version: "2.0.0",
 					},
 				},
 			},
-			expectedErrorCode: connect.CodeInvalidArgument,
-		},
-		{
-			name:    "it returns carvel empty installed package summary when no package install is present",
-			request: &corev1.GetInstalledPackageSummariesRequest{Context: defaultContext},
-			existingObjects: []k8sruntime.Object{
-				&datapackagingv1alpha1.PackageMetadata{
-					TypeMeta: metav1.TypeMeta{
-						Kind:       pkgMetadataResource,
-						APIVersion: datapackagingAPIVersion,
-					},
-					ObjectMeta: metav1.ObjectMeta{
+			request: &corev1.GetAvailablePackageVersionsRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context: &corev1.Context{
 						Namespace: "default",
-						Name:      "tetris.foo.example.com",
 					},
-					Spec: datapackagingv1alpha1.PackageMetadataSpec{
-						DisplayName:        "Classic Tetris",
-						IconSVGBase64:      "Tm90IHJlYWxseSBTVkcK",
-						ShortDescription:   "A great game for arcade gamers",
-						LongDescription:    "A few sentences but not really a readme",
-						Categories:         []string{"logging", "daemon-set"},
-						Maintainers:        []datapackagingv1alpha1.Maintainer{{Name: "person1"}, {Name: "person2"}},
-						SupportDescription: "Some support information",
-						ProviderName:       "Tetris inc.",
+					Identifier: "unknown/tetris.foo.example.com",
+				},
+				Constraint: ">=1.2.0 <2.0.0",
+			},
+			expectedResponse: &corev1.GetAvailablePackageVersionsResponse{
+				PackageAppVersions: []*corev1.PackageAppVersion{
+					{
+						PkgVersion: "1.3.0",
+						AppVersion: "1.3.0",
+					},
+					{
+						PkgVersion: "1.2.3",
+						AppVersion: "1.2.3",
 					},
 				},
+			},
+		},
+		{
+			name: "it returns invalid argument if the constraint is not semver-compatible",
+			existingObjects: []k8sruntime.Object{
 				&datapackagingv1alpha1.Package{
 					TypeMeta: metav1.TypeMeta{
 						Kind:       pkgResource,
@@ -1830,39 +2156,38 @@ func TestGetInstalledPackageSummaries(t *testing.T) {
 						Name:      "tetris.foo.example.com.1.2.3",
 					},
 					Spec: datapackagingv1alpha1.PackageSpec{
-						RefName:                         "tetris.foo.example.com",
-						Version:                         "1.2.3",
-						Licenses:                        []string{"my-license"},
-						ReleaseNotes:                    "release notes",
-						CapactiyRequirementsDescription: "capacity description",
-						ReleasedAt:                      metav1.Time{Time: time.Date(1984, time.June, 6, 0, 0, 0, 0, time.UTC)},
+						RefName: "tetris.foo.example.com",
+						Version: "1.2.3",
 					},
 				},
 			},
-			expectedPackages: []*corev1.InstalledPackageSummary{},
+			request: &corev1.GetAvailablePackageVersionsRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context: &corev1.Context{
+						Namespace: "default",
+					},
+					Identifier: "unknown/tetris.foo.example.com",
+				},
+				Constraint: "not a constraint",
+			},
+			expectedErrorCode: connect.CodeInvalidArgument,
 		},
 		{
-			name:    "it returns carvel installed package summary with complete metadata",
-			request: &corev1.GetInstalledPackageSummariesRequest{Context: defaultContext},
+			name: "it classifies the upgrade impact relative to a version",
 			existingObjects: []k8sruntime.Object{
-				&datapackagingv1alpha1.PackageMetadata{
+				&datapackagingv1alpha1.Package{
 					TypeMeta: metav1.TypeMeta{
-						Kind:       pkgMetadataResource,
+						Kind:       pkgResource,
 						APIVersion: datapackagingAPIVersion,
 					},
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: "default",
-						Name:      "tetris.foo.example.com",
+						Name:      "tetris.foo.example.com.1.2.3",
 					},
-					Spec: datapackagingv1alpha1.PackageMetadataSpec{
-						DisplayName:        "Classic Tetris",
-						IconSVGBase64:      "Tm90IHJlYWxseSBTVkcK",
-						ShortDescription:   "A great game for arcade gamers",
-						LongDescription:    "A few sentences but not really a readme",
-						Categories:         []string{"logging", "daemon-set"},
-						Maintainers:        []datapackagingv1alpha1.Maintainer{{Name: "person1"}, {Name: "person2"}},
-						SupportDescription: "Some support information",
-						ProviderName:       "Tetris inc.",
+					Spec: datapackagingv1alpha1.PackageSpec{
+						RefName:      "tetris.foo.example.com",
+						Version:      "1.2.3",
+						ReleaseNotes: "release notes",
 					},
 				},
 				&datapackagingv1alpha1.Package{
@@ -1872,125 +2197,311 @@ func TestGetInstalledPackageSummaries(t *testing.T) {
 					},
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: "default",
-						Name:      "tetris.foo.example.com.1.2.3",
+						Name:      "tetris.foo.example.com.2.0.0",
 					},
 					Spec: datapackagingv1alpha1.PackageSpec{
-						RefName:                         "tetris.foo.example.com",
-						Version:                         "1.2.3",
-						Licenses:                        []string{"my-license"},
-						ReleaseNotes:                    "release notes",
-						CapactiyRequirementsDescription: "capacity description",
-						ReleasedAt:                      metav1.Time{Time: time.Date(1984, time.June, 6, 0, 0, 0, 0, time.UTC)},
+						RefName:      "tetris.foo.example.com",
+						Version:      "2.0.0",
+						ReleaseNotes: "New levels\nBreaking change: the scores are reset",
 					},
 				},
-				&packagingv1alpha1.PackageInstall{
-					TypeMeta: metav1.TypeMeta{
-						Kind:       pkgInstallResource,
-						APIVersion: packagingAPIVersion,
-					},
-					ObjectMeta: metav1.ObjectMeta{
+			},
+			request: &corev1.GetAvailablePackageVersionsRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context: &corev1.Context{
 						Namespace: "default",
-						Name:      "my-installation",
-					},
-					Spec: packagingv1alpha1.PackageInstallSpec{
-						ServiceAccountName: "default",
-						PackageRef: &packagingv1alpha1.PackageRef{
-							RefName: "tetris.foo.example.com",
-							VersionSelection: &vendirversions.VersionSelectionSemver{
-								Constraints: "1.2.3",
-							},
-						},
-						Values: []packagingv1alpha1.PackageInstallValues{{
-							SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{
-								Name: "my-installation-default-values",
-							},
-						},
-						},
-						Paused:     false,
-						Canceled:   false,
-						SyncPeriod: &metav1.Duration{Duration: (time.Second * 30)},
-						NoopDelete: false,
-					},
-					Status: packagingv1alpha1.PackageInstallStatus{
-						GenericStatus: kappctrlv1alpha1.GenericStatus{
-							ObservedGeneration: 1,
-							Conditions: []kappctrlv1alpha1.Condition{{
-								Type:    kappctrlv1alpha1.ReconcileSucceeded,
-								Status:  k8scorev1.ConditionTrue,
-								Reason:  "baz",
-								Message: "qux",
-							}},
-							FriendlyDescription: "foo",
-							UsefulErrorMessage:  "Deployed",
-						},
-						Version:              "1.2.3",
-						LastAttemptedVersion: "1.2.3",
 					},
+					Identifier: "unknown/tetris.foo.example.com",
 				},
+				RelativeToVersion: "1.2.3",
 			},
-			expectedPackages: []*corev1.InstalledPackageSummary{
-				{
-					InstalledPackageRef: &corev1.InstalledPackageReference{
-						Context:    defaultContext,
-						Plugin:     &pluginDetail,
-						Identifier: "my-installation",
-					},
-					Name:           "my-installation",
-					PkgDisplayName: "Classic Tetris",
-					LatestVersion: &corev1.PackageAppVersion{
-						PkgVersion: "1.2.3",
-						AppVersion: "1.2.3",
-					},
-					IconUrl:             "data:image/svg+xml;base64,Tm90IHJlYWxseSBTVkcK",
-					ShortDescription:    "A great game for arcade gamers",
-					PkgVersionReference: &corev1.VersionReference{Version: "1.2.3"},
-					CurrentVersion: &corev1.PackageAppVersion{
-						PkgVersion: "1.2.3",
-						AppVersion: "1.2.3",
-					},
-					LatestMatchingVersion: &corev1.PackageAppVersion{
-						PkgVersion: "1.2.3",
-						AppVersion: "1.2.3",
+			expectedResponse: &corev1.GetAvailablePackageVersionsResponse{
+				PackageAppVersions: []*corev1.PackageAppVersion{
+					{
+						PkgVersion: "2.0.0",
+						AppVersion: "2.0.0",
+						UpgradeImpact: &corev1.UpgradeImpact{
+							Type:            corev1.UpgradeImpact_UPGRADE_TYPE_MAJOR,
+							Breaking:        true,
+							BreakingChanges: []string{"2.0.0: Breaking change: the scores are reset"},
+						},
 					},
-					Status: &corev1.InstalledPackageStatus{
-						Ready:      true,
-						Reason:     corev1.InstalledPackageStatus_STATUS_REASON_INSTALLED,
-						UserReason: "Deployed",
+					{
+						PkgVersion:    "1.2.3",
+						AppVersion:    "1.2.3",
+						UpgradeImpact: &corev1.UpgradeImpact{Type: corev1.UpgradeImpact_UPGRADE_TYPE_NONE},
 					},
 				},
 			},
 		},
-		{
-			name: "it returns carvel installed package summary with complete metadata from global namespace",
-			// Request in test has to use empty namespace to search across all
-			// namespaces since in real life the metadata and package resources
-			// are not actual CRs but aggregated APIs that return data across
-			// namespaces.
-			request: &corev1.GetInstalledPackageSummariesRequest{
-				Context: &corev1.Context{
-					Namespace: "",
-					Cluster:   defaultContext.Cluster,
-				},
-			},
-			existingObjects: []k8sruntime.Object{
-				&datapackagingv1alpha1.PackageMetadata{
-					TypeMeta: metav1.TypeMeta{
-						Kind:       pkgMetadataResource,
-						APIVersion: datapackagingAPIVersion,
-					},
-					ObjectMeta: metav1.ObjectMeta{
-						Namespace: demoGlobalPackagingNamespace,
-						Name:      "tetris.foo.example.com",
-					},
-					Spec: datapackagingv1alpha1.PackageMetadataSpec{
-						DisplayName:        "Classic Tetris",
-						IconSVGBase64:      "Tm90IHJlYWxseSBTVkcK",
-						ShortDescription:   "A great game for arcade gamers",
-						LongDescription:    "A few sentences but not really a readme",
-						Categories:         []string{"logging", "daemon-set"},
-						Maintainers:        []datapackagingv1alpha1.Maintainer{{Name: "person1"}, {Name: "person2"}},
-						SupportDescription: "Some support information",
-						ProviderName:       "Tetris inc.",
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var unstructuredObjects []k8sruntime.Object
+			for _, obj := range tc.existingObjects {
+				unstructuredContent, _ := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(obj)
+				unstructuredObjects = append(unstructuredObjects, &unstructured.Unstructured{Object: unstructuredContent})
+			}
+
+			s := Server{
+				pluginConfig: defaultPluginConfig,
+				clientGetter: clientgetter.NewBuilder().
+					WithDynamic(dynfake.NewSimpleDynamicClientWithCustomListKinds(
+						k8sruntime.NewScheme(),
+						map[schema.GroupVersionResource]string{
+							{Group: datapackagingv1alpha1.SchemeGroupVersion.Group, Version: datapackagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgsResource}: pkgResource + "List",
+						},
+						unstructuredObjects...,
+					)).
+					Build(),
+			}
+
+			response, err := s.GetAvailablePackageVersions(context.Background(), connect.NewRequest(tc.request))
+
+			if got, want := connect.CodeOf(err), tc.expectedErrorCode; err != nil && got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+
+			// We don't need to check anything else for non-OK codes.
+			if tc.expectedErrorCode != 0 {
+				return
+			}
+
+			if got, want := response.Msg, tc.expectedResponse; !cmp.Equal(want, got, ignoreUnexported) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexported))
+			}
+		})
+	}
+}
+
+func TestGetAvailablePackageChangelog(t *testing.T) {
+	existingObjects := []k8sruntime.Object{
+		&datapackagingv1alpha1.Package{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       pkgResource,
+				APIVersion: datapackagingAPIVersion,
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "tetris.foo.example.com.1.2.3",
+			},
+			Spec: datapackagingv1alpha1.PackageSpec{
+				RefName:      "tetris.foo.example.com",
+				Version:      "1.2.3",
+				ReleaseNotes: "first release",
+			},
+		},
+		&datapackagingv1alpha1.Package{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       pkgResource,
+				APIVersion: datapackagingAPIVersion,
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "tetris.foo.example.com.1.2.7",
+			},
+			Spec: datapackagingv1alpha1.PackageSpec{
+				RefName:      "tetris.foo.example.com",
+				Version:      "1.2.7",
+				ReleaseNotes: "fixed the scores",
+			},
+		},
+		&datapackagingv1alpha1.Package{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       pkgResource,
+				APIVersion: datapackagingAPIVersion,
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "tetris.foo.example.com.1.2.4",
+			},
+			Spec: datapackagingv1alpha1.PackageSpec{
+				RefName:      "tetris.foo.example.com",
+				Version:      "1.2.4",
+				ReleaseNotes: "added a new level",
+			},
+		},
+		&datapackagingv1alpha1.Package{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       pkgResource,
+				APIVersion: datapackagingAPIVersion,
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "tetris.foo.example.com.1.2.5",
+			},
+			Spec: datapackagingv1alpha1.PackageSpec{
+				RefName:      "tetris.foo.example.com",
+				Version:      "1.2.5",
+				ReleaseNotes: "",
+			},
+		},
+	}
+
+	testCases := []struct {
+		name              string
+		request           *corev1.GetAvailablePackageChangelogRequest
+		noPackages        bool
+		expectedErrorCode connect.Code
+		expectedResponse  *corev1.GetAvailablePackageChangelogResponse
+	}{
+		{
+			name: "it returns invalid argument if called without an identifier",
+			request: &corev1.GetAvailablePackageChangelogRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context: &corev1.Context{
+						Namespace: "default",
+					},
+				},
+			},
+			expectedErrorCode: connect.CodeInvalidArgument,
+		},
+		{
+			name: "it returns invalid argument for a from version which is not semver",
+			request: &corev1.GetAvailablePackageChangelogRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context: &corev1.Context{
+						Namespace: "default",
+					},
+					Identifier: "unknown/tetris.foo.example.com",
+				},
+				FromVersion: "foo",
+			},
+			expectedErrorCode: connect.CodeInvalidArgument,
+		},
+		{
+			name: "it returns not found if the package has no version",
+			request: &corev1.GetAvailablePackageChangelogRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context: &corev1.Context{
+						Namespace: "default",
+					},
+					Identifier: "unknown/pacman.foo.example.com",
+				},
+			},
+			noPackages:        true,
+			expectedErrorCode: connect.CodeNotFound,
+		},
+		{
+			name: "it returns the release notes of every version, skipping the empty ones",
+			request: &corev1.GetAvailablePackageChangelogRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context: &corev1.Context{
+						Namespace: "default",
+					},
+					Identifier: "unknown/tetris.foo.example.com",
+				},
+			},
+			expectedResponse: &corev1.GetAvailablePackageChangelogResponse{
+				Changelogs: []*corev1.PackageVersionChangelog{
+					{PkgVersion: "1.2.7", ReleaseNotes: "fixed the scores"},
+					{PkgVersion: "1.2.4", ReleaseNotes: "added a new level"},
+					{PkgVersion: "1.2.3", ReleaseNotes: "first release"},
+				},
+			},
+		},
+		{
+			name: "it returns the release notes between the requested versions",
+			request: &corev1.GetAvailablePackageChangelogRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context: &corev1.Context{
+						Namespace: "default",
+					},
+					Identifier: "unknown/tetris.foo.example.com",
+				},
+				FromVersion: "1.2.3",
+				ToVersion:   "1.2.5",
+			},
+			expectedResponse: &corev1.GetAvailablePackageChangelogResponse{
+				Changelogs: []*corev1.PackageVersionChangelog{
+					{PkgVersion: "1.2.4", ReleaseNotes: "added a new level"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			objects := existingObjects
+			if tc.noPackages {
+				objects = nil
+			}
+			var unstructuredObjects []k8sruntime.Object
+			for _, obj := range objects {
+				unstructuredContent, _ := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(obj)
+				unstructuredObjects = append(unstructuredObjects, &unstructured.Unstructured{Object: unstructuredContent})
+			}
+
+			s := Server{
+				pluginConfig: defaultPluginConfig,
+				clientGetter: clientgetter.NewBuilder().
+					WithDynamic(dynfake.NewSimpleDynamicClientWithCustomListKinds(
+						k8sruntime.NewScheme(),
+						map[schema.GroupVersionResource]string{
+							{Group: datapackagingv1alpha1.SchemeGroupVersion.Group, Version: datapackagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgsResource}: pkgResource + "List",
+						},
+						unstructuredObjects...,
+					)).
+					Build(),
+			}
+
+			response, err := s.GetAvailablePackageChangelog(context.Background(), connect.NewRequest(tc.request))
+
+			if got, want := connect.CodeOf(err), tc.expectedErrorCode; err != nil && got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+
+			// We don't need to check anything else for non-OK codes.
+			if tc.expectedErrorCode != 0 {
+				if err == nil {
+					t.Fatalf("got: nil, want: error with code %s", tc.expectedErrorCode)
+				}
+				return
+			}
+
+			if got, want := response.Msg, tc.expectedResponse; !cmp.Equal(want, got, ignoreUnexported) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexported))
+			}
+		})
+	}
+}
+
+func TestGetAvailablePackageDetail(t *testing.T) {
+	testCases := []struct {
+		name            string
+		existingObjects []k8sruntime.Object
+		expectedPackage *corev1.AvailablePackageDetail
+		errorCode       connect.Code
+		request         *corev1.GetAvailablePackageDetailRequest
+	}{
+		{
+			name: "it returns an availablePackageDetail of the latest version",
+			request: &corev1.GetAvailablePackageDetailRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context:    defaultContext,
+					Identifier: "unknown/tetris.foo.example.com",
+				},
+			},
+			existingObjects: []k8sruntime.Object{
+				&datapackagingv1alpha1.PackageMetadata{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       pkgMetadataResource,
+						APIVersion: datapackagingAPIVersion,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "tetris.foo.example.com",
+					},
+					Spec: datapackagingv1alpha1.PackageMetadataSpec{
+						DisplayName:        "Classic Tetris",
+						IconSVGBase64:      "Tm90IHJlYWxseSBTVkcK",
+						ShortDescription:   "A great game for arcade gamers",
+						LongDescription:    "A few sentences but not really a readme",
+						Categories:         []string{"logging", "daemon-set"},
+						Maintainers:        []datapackagingv1alpha1.Maintainer{{Name: "person1"}, {Name: "person2"}},
+						SupportDescription: "Some support information",
+						ProviderName:       "Tetris inc.",
 					},
 				},
 				&datapackagingv1alpha1.Package{
@@ -1999,7 +2510,7 @@ func TestGetInstalledPackageSummaries(t *testing.T) {
 						APIVersion: datapackagingAPIVersion,
 					},
 					ObjectMeta: metav1.ObjectMeta{
-						Namespace: demoGlobalPackagingNamespace,
+						Namespace: "default",
 						Name:      "tetris.foo.example.com.1.2.3",
 					},
 					Spec: datapackagingv1alpha1.PackageSpec{
@@ -2011,99 +2522,88 @@ func TestGetInstalledPackageSummaries(t *testing.T) {
 						ReleasedAt:                      metav1.Time{Time: time.Date(1984, time.June, 6, 0, 0, 0, 0, time.UTC)},
 					},
 				},
-				&packagingv1alpha1.PackageInstall{
+			},
+			expectedPackage: &corev1.AvailablePackageDetail{
+				Name:            "tetris.foo.example.com",
+				DisplayName:     "Classic Tetris",
+				LongDescription: "A few sentences but not really a readme",
+				Version: &corev1.PackageAppVersion{
+					PkgVersion: "1.2.3",
+					AppVersion: "1.2.3",
+				},
+				Maintainers:      []*corev1.Maintainer{{Name: "person1"}, {Name: "person2"}},
+				IconUrl:          "data:image/svg+xml;base64,Tm90IHJlYWxseSBTVkcK",
+				ShortDescription: "A great game for arcade gamers",
+				Categories:       []string{"logging", "daemon-set"},
+				Readme: `## Description
+
+A few sentences but not really a readme
+
+## Capactiy requirements
+
+capacity description
+
+## Release notes
+
+release notes
+
+Released at: June, 6 1984
+
+## Support
+
+Some support information
+
+## Licenses
+
+- my-license
+
+`,
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context:    defaultContext,
+					Identifier: "unknown/tetris.foo.example.com",
+					Plugin:     &pluginDetail,
+				},
+			},
+		},
+		{
+			name: "it returns an availablePackageDetail of the latest version with repo-based identifiers",
+			request: &corev1.GetAvailablePackageDetailRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context:    defaultContext,
+					Identifier: "unknown/tetris.foo.example.com",
+				},
+			},
+			existingObjects: []k8sruntime.Object{
+				&datapackagingv1alpha1.PackageMetadata{
 					TypeMeta: metav1.TypeMeta{
-						Kind:       pkgInstallResource,
-						APIVersion: packagingAPIVersion,
+						Kind:       pkgMetadataResource,
+						APIVersion: datapackagingAPIVersion,
 					},
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: "default",
-						Name:      "my-installation",
-					},
-					Spec: packagingv1alpha1.PackageInstallSpec{
-						ServiceAccountName: "default",
-						PackageRef: &packagingv1alpha1.PackageRef{
-							RefName: "tetris.foo.example.com",
-							VersionSelection: &vendirversions.VersionSelectionSemver{
-								Constraints: "1.2.3",
-							},
-						},
-						Values: []packagingv1alpha1.PackageInstallValues{{
-							SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{
-								Name: "my-installation-default-values",
-							},
-						},
-						},
-						Paused:     false,
-						Canceled:   false,
-						SyncPeriod: &metav1.Duration{Duration: (time.Second * 30)},
-						NoopDelete: false,
-					},
-					Status: packagingv1alpha1.PackageInstallStatus{
-						GenericStatus: kappctrlv1alpha1.GenericStatus{
-							ObservedGeneration: 1,
-							Conditions: []kappctrlv1alpha1.Condition{{
-								Type:    kappctrlv1alpha1.ReconcileSucceeded,
-								Status:  k8scorev1.ConditionTrue,
-								Reason:  "baz",
-								Message: "qux",
-							}},
-							FriendlyDescription: "foo",
-							UsefulErrorMessage:  "Deployed",
+						Name:      "tetris.foo.example.com",
+						Annotations: map[string]string{
+							REPO_REF_ANNOTATION: "default/tce-repo",
 						},
-						Version:              "1.2.3",
-						LastAttemptedVersion: "1.2.3",
-					},
-				},
-			},
-			expectedPackages: []*corev1.InstalledPackageSummary{
-				{
-					InstalledPackageRef: &corev1.InstalledPackageReference{
-						Context:    defaultContext,
-						Plugin:     &pluginDetail,
-						Identifier: "my-installation",
-					},
-					Name:           "my-installation",
-					PkgDisplayName: "Classic Tetris",
-					LatestVersion: &corev1.PackageAppVersion{
-						PkgVersion: "1.2.3",
-						AppVersion: "1.2.3",
-					},
-					IconUrl:             "data:image/svg+xml;base64,Tm90IHJlYWxseSBTVkcK",
-					ShortDescription:    "A great game for arcade gamers",
-					PkgVersionReference: &corev1.VersionReference{Version: "1.2.3"},
-					CurrentVersion: &corev1.PackageAppVersion{
-						PkgVersion: "1.2.3",
-						AppVersion: "1.2.3",
-					},
-					LatestMatchingVersion: &corev1.PackageAppVersion{
-						PkgVersion: "1.2.3",
-						AppVersion: "1.2.3",
 					},
-					Status: &corev1.InstalledPackageStatus{
-						Ready:      true,
-						Reason:     corev1.InstalledPackageStatus_STATUS_REASON_INSTALLED,
-						UserReason: "Deployed",
+					Spec: datapackagingv1alpha1.PackageMetadataSpec{
+						DisplayName:        "Classic Tetris",
+						IconSVGBase64:      "Tm90IHJlYWxseSBTVkcK",
+						ShortDescription:   "A great game for arcade gamers",
+						LongDescription:    "A few sentences but not really a readme",
+						Categories:         []string{"logging", "daemon-set"},
+						Maintainers:        []datapackagingv1alpha1.Maintainer{{Name: "person1"}, {Name: "person2"}},
+						SupportDescription: "Some support information",
+						ProviderName:       "Tetris inc.",
 					},
 				},
-			},
-		},
-		{
-			name: "it ignores carvel package install without any related metadata",
-			request: &corev1.GetInstalledPackageSummariesRequest{
-				Context: &corev1.Context{
-					Namespace: "",
-					Cluster:   defaultContext.Cluster,
-				},
-			},
-			existingObjects: []k8sruntime.Object{
 				&datapackagingv1alpha1.Package{
 					TypeMeta: metav1.TypeMeta{
 						Kind:       pkgResource,
 						APIVersion: datapackagingAPIVersion,
 					},
 					ObjectMeta: metav1.ObjectMeta{
-						Namespace: demoGlobalPackagingNamespace,
+						Namespace: "default",
 						Name:      "tetris.foo.example.com.1.2.3",
 					},
 					Spec: datapackagingv1alpha1.PackageSpec{
@@ -2115,59 +2615,55 @@ func TestGetInstalledPackageSummaries(t *testing.T) {
 						ReleasedAt:                      metav1.Time{Time: time.Date(1984, time.June, 6, 0, 0, 0, 0, time.UTC)},
 					},
 				},
-				&packagingv1alpha1.PackageInstall{
-					TypeMeta: metav1.TypeMeta{
-						Kind:       pkgInstallResource,
-						APIVersion: packagingAPIVersion,
-					},
-					ObjectMeta: metav1.ObjectMeta{
-						Namespace: "default",
-						Name:      "my-installation",
-					},
-					Spec: packagingv1alpha1.PackageInstallSpec{
-						ServiceAccountName: "default",
-						PackageRef: &packagingv1alpha1.PackageRef{
-							RefName: "tetris.foo.example.com",
-							VersionSelection: &vendirversions.VersionSelectionSemver{
-								Constraints: "1.2.3",
-							},
-						},
-						Values: []packagingv1alpha1.PackageInstallValues{{
-							SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{
-								Name: "my-installation-default-values",
-							},
-						},
-						},
-						Paused:     false,
-						Canceled:   false,
-						SyncPeriod: &metav1.Duration{Duration: (time.Second * 30)},
-						NoopDelete: false,
-					},
-					Status: packagingv1alpha1.PackageInstallStatus{
-						GenericStatus: kappctrlv1alpha1.GenericStatus{
-							ObservedGeneration: 1,
-							Conditions: []kappctrlv1alpha1.Condition{{
-								Type:    kappctrlv1alpha1.ReconcileSucceeded,
-								Status:  k8scorev1.ConditionTrue,
-								Reason:  "baz",
-								Message: "qux",
-							}},
-							FriendlyDescription: "foo",
-							UsefulErrorMessage:  "Deployed",
-						},
-						Version:              "1.2.3",
-						LastAttemptedVersion: "1.2.3",
-					},
+			},
+			expectedPackage: &corev1.AvailablePackageDetail{
+				Name:            "tetris.foo.example.com",
+				DisplayName:     "Classic Tetris",
+				LongDescription: "A few sentences but not really a readme",
+				Version: &corev1.PackageAppVersion{
+					PkgVersion: "1.2.3",
+					AppVersion: "1.2.3",
+				},
+				Maintainers:      []*corev1.Maintainer{{Name: "person1"}, {Name: "person2"}},
+				IconUrl:          "data:image/svg+xml;base64,Tm90IHJlYWxseSBTVkcK",
+				ShortDescription: "A great game for arcade gamers",
+				Categories:       []string{"logging", "daemon-set"},
+				Readme: `## Description
+
+A few sentences but not really a readme
+
+## Capactiy requirements
+
+capacity description
+
+## Release notes
+
+release notes
+
+Released at: June, 6 1984
+
+## Support
+
+Some support information
+
+## Licenses
+
+- my-license
+
+`,
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context:    defaultContext,
+					Identifier: "tce-repo/tetris.foo.example.com",
+					Plugin:     &pluginDetail,
 				},
 			},
-			expectedPackages: []*corev1.InstalledPackageSummary{},
 		},
 		{
-			name: "it returns carvel installed package from different namespaces if context.namespace==''",
-			request: &corev1.GetInstalledPackageSummariesRequest{
-				Context: &corev1.Context{
-					Namespace: "",
-					Cluster:   defaultContext.Cluster,
+			name: "it combines long description and support description for readme field",
+			request: &corev1.GetAvailablePackageDetailRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context:    defaultContext,
+					Identifier: "unknown/tetris.foo.example.com",
 				},
 			},
 			existingObjects: []k8sruntime.Object{
@@ -2191,26 +2687,6 @@ func TestGetInstalledPackageSummaries(t *testing.T) {
 						ProviderName:       "Tetris inc.",
 					},
 				},
-				&datapackagingv1alpha1.PackageMetadata{
-					TypeMeta: metav1.TypeMeta{
-						Kind:       pkgMetadataResource,
-						APIVersion: datapackagingAPIVersion,
-					},
-					ObjectMeta: metav1.ObjectMeta{
-						Namespace: "another-ns",
-						Name:      "tetris.foo.example.com",
-					},
-					Spec: datapackagingv1alpha1.PackageMetadataSpec{
-						DisplayName:        "Classic Tetris",
-						IconSVGBase64:      "Tm90IHJlYWxseSBTVkcK",
-						ShortDescription:   "A great game for arcade gamers",
-						LongDescription:    "A few sentences but not really a readme",
-						Categories:         []string{"logging", "daemon-set"},
-						Maintainers:        []datapackagingv1alpha1.Maintainer{{Name: "person1"}, {Name: "person2"}},
-						SupportDescription: "Some support information",
-						ProviderName:       "Tetris inc.",
-					},
-				},
 				&datapackagingv1alpha1.Package{
 					TypeMeta: metav1.TypeMeta{
 						Kind:       pkgResource,
@@ -2229,177 +2705,166 @@ func TestGetInstalledPackageSummaries(t *testing.T) {
 						ReleasedAt:                      metav1.Time{Time: time.Date(1984, time.June, 6, 0, 0, 0, 0, time.UTC)},
 					},
 				},
-				&datapackagingv1alpha1.Package{
-					TypeMeta: metav1.TypeMeta{
-						Kind:       pkgResource,
-						APIVersion: datapackagingAPIVersion,
-					},
-					ObjectMeta: metav1.ObjectMeta{
-						Namespace: "another-ns",
-						Name:      "tetris.foo.example.com.1.2.3",
-					},
-					Spec: datapackagingv1alpha1.PackageSpec{
-						RefName:                         "tetris.foo.example.com",
-						Version:                         "1.2.3",
-						Licenses:                        []string{"my-license"},
-						ReleaseNotes:                    "release notes",
-						CapactiyRequirementsDescription: "capacity description",
-						ReleasedAt:                      metav1.Time{Time: time.Date(1984, time.June, 6, 0, 0, 0, 0, time.UTC)},
-					},
+			},
+			expectedPackage: &corev1.AvailablePackageDetail{
+				Name:            "tetris.foo.example.com",
+				DisplayName:     "Classic Tetris",
+				LongDescription: "A few sentences but not really a readme",
+				Version: &corev1.PackageAppVersion{
+					PkgVersion: "1.2.3",
+					AppVersion: "1.2.3",
 				},
-				&packagingv1alpha1.PackageInstall{
-					TypeMeta: metav1.TypeMeta{
-						Kind:       pkgInstallResource,
-						APIVersion: packagingAPIVersion,
-					},
-					ObjectMeta: metav1.ObjectMeta{
-						Namespace: "default",
-						Name:      "my-installation",
-					},
-					Spec: packagingv1alpha1.PackageInstallSpec{
-						ServiceAccountName: "default",
-						PackageRef: &packagingv1alpha1.PackageRef{
-							RefName: "tetris.foo.example.com",
-							VersionSelection: &vendirversions.VersionSelectionSemver{
-								Constraints: "1.2.3",
-							},
-						},
-						Values: []packagingv1alpha1.PackageInstallValues{{
-							SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{
-								Name: "my-installation-default-values",
-							},
-						},
-						},
-						Paused:     false,
-						Canceled:   false,
-						SyncPeriod: &metav1.Duration{Duration: (time.Second * 30)},
-						NoopDelete: false,
-					},
-					Status: packagingv1alpha1.PackageInstallStatus{
-						GenericStatus: kappctrlv1alpha1.GenericStatus{
-							ObservedGeneration: 1,
-							Conditions: []kappctrlv1alpha1.Condition{{
-								Type:    kappctrlv1alpha1.ReconcileSucceeded,
-								Status:  k8scorev1.ConditionTrue,
-								Reason:  "baz",
-								Message: "qux",
-							}},
-							FriendlyDescription: "foo",
-							UsefulErrorMessage:  "foo",
-						},
-						Version:              "1.2.3",
-						LastAttemptedVersion: "1.2.3",
-					},
+				Maintainers:      []*corev1.Maintainer{{Name: "person1"}, {Name: "person2"}},
+				IconUrl:          "data:image/svg+xml;base64,Tm90IHJlYWxseSBTVkcK",
+				ShortDescription: "A great game for arcade gamers",
+				Categories:       []string{"logging", "daemon-set"},
+				Readme: `## Description
+
+A few sentences but not really a readme
+
+## Capactiy requirements
+
+capacity description
+
+## Release notes
+
+release notes
+
+Released at: June, 6 1984
+
+## Support
+
+Some support information
+
+## Licenses
+
+- my-license
+
+`,
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context:    defaultContext,
+					Identifier: "unknown/tetris.foo.example.com",
+					Plugin:     &pluginDetail,
 				},
-				&packagingv1alpha1.PackageInstall{
-					TypeMeta: metav1.TypeMeta{
-						Kind:       pkgInstallResource,
-						APIVersion: packagingAPIVersion,
-					},
-					ObjectMeta: metav1.ObjectMeta{
-						Namespace: "another-ns",
-						Name:      "my-installation",
-					},
-					Spec: packagingv1alpha1.PackageInstallSpec{
-						ServiceAccountName: "default",
-						PackageRef: &packagingv1alpha1.PackageRef{
-							RefName: "tetris.foo.example.com",
-							VersionSelection: &vendirversions.VersionSelectionSemver{
-								Constraints: "1.2.3",
-							},
-						},
-						Values: []packagingv1alpha1.PackageInstallValues{{
-							SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{
-								Name: "my-installation-default-values",
-							},
-						},
-						},
-						Paused:     false,
-						Canceled:   false,
-						SyncPeriod: &metav1.Duration{Duration: (time.Second * 30)},
-						NoopDelete: false,
-					},
-					Status: packagingv1alpha1.PackageInstallStatus{
-						GenericStatus: kappctrlv1alpha1.GenericStatus{
-							ObservedGeneration: 1,
-							Conditions: []kappctrlv1alpha1.Condition{{
-								Type:    kappctrlv1alpha1.ReconcileSucceeded,
-								Status:  k8scorev1.ConditionTrue,
-								Reason:  "baz",
-								Message: "qux",
-							}},
-							FriendlyDescription: "foo",
-							UsefulErrorMessage:  "foo",
-						},
-						Version:              "1.2.3",
-						LastAttemptedVersion: "1.2.3",
-					},
+			},
+		},
+		{
+			name: "it returns an invalid arg error status if no context is provided",
+			request: &corev1.GetAvailablePackageDetailRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Identifier: "unknown/foo/bar",
 				},
 			},
-			expectedPackages: []*corev1.InstalledPackageSummary{
-				{
-					InstalledPackageRef: &corev1.InstalledPackageReference{
-						Context:    &corev1.Context{Namespace: "another-ns", Cluster: defaultContext.Cluster},
-						Plugin:     &pluginDetail,
-						Identifier: "my-installation",
-					},
-					Name:           "my-installation",
-					PkgDisplayName: "Classic Tetris",
-					LatestVersion: &corev1.PackageAppVersion{
-						PkgVersion: "1.2.3",
-						AppVersion: "1.2.3",
-					},
-					IconUrl:             "data:image/svg+xml;base64,Tm90IHJlYWxseSBTVkcK",
-					ShortDescription:    "A great game for arcade gamers",
-					PkgVersionReference: &corev1.VersionReference{Version: "1.2.3"},
-					CurrentVersion: &corev1.PackageAppVersion{
-						PkgVersion: "1.2.3",
-						AppVersion: "1.2.3",
+			errorCode: connect.CodeInvalidArgument,
+		},
+		{
+			name: "it returns not found error status if the requested package version doesn't exist",
+			request: &corev1.GetAvailablePackageDetailRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context:    defaultContext,
+					Identifier: "unknown/tetris.foo.example.com",
+				},
+				PkgVersion: "1.2.4",
+			},
+			existingObjects: []k8sruntime.Object{
+				&datapackagingv1alpha1.PackageMetadata{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       pkgMetadataResource,
+						APIVersion: datapackagingAPIVersion,
 					},
-					LatestMatchingVersion: &corev1.PackageAppVersion{
-						PkgVersion: "1.2.3",
-						AppVersion: "1.2.3",
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "tetris.foo.example.com",
 					},
-					Status: &corev1.InstalledPackageStatus{
-						Ready:      true,
-						Reason:     corev1.InstalledPackageStatus_STATUS_REASON_INSTALLED,
-						UserReason: "Deployed",
+					Spec: datapackagingv1alpha1.PackageMetadataSpec{
+						DisplayName:        "Classic Tetris",
+						IconSVGBase64:      "Tm90IHJlYWxseSBTVkcK",
+						ShortDescription:   "A great game for arcade gamers",
+						LongDescription:    "A few sentences but not really a readme",
+						Categories:         []string{"logging", "daemon-set"},
+						Maintainers:        []datapackagingv1alpha1.Maintainer{{Name: "person1"}, {Name: "person2"}},
+						SupportDescription: "Some support information",
+						ProviderName:       "Tetris inc.",
 					},
 				},
-				{
-					InstalledPackageRef: &corev1.InstalledPackageReference{
-						Context:    defaultContext,
-						Plugin:     &pluginDetail,
-						Identifier: "my-installation",
-					},
-					Name:           "my-installation",
-					PkgDisplayName: "Classic Tetris",
-					LatestVersion: &corev1.PackageAppVersion{
-						PkgVersion: "1.2.3",
-						AppVersion: "1.2.3",
-					},
-					IconUrl:             "data:image/svg+xml;base64,Tm90IHJlYWxseSBTVkcK",
-					ShortDescription:    "A great game for arcade gamers",
-					PkgVersionReference: &corev1.VersionReference{Version: "1.2.3"},
-					CurrentVersion: &corev1.PackageAppVersion{
-						PkgVersion: "1.2.3",
-						AppVersion: "1.2.3",
+				&datapackagingv1alpha1.Package{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       pkgResource,
+						APIVersion: datapackagingAPIVersion,
 					},
-					LatestMatchingVersion: &corev1.PackageAppVersion{
-						PkgVersion: "1.2.3",
-						AppVersion: "1.2.3",
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "tetris.foo.example.com.1.2.3",
 					},
-					Status: &corev1.InstalledPackageStatus{
-						Ready:      true,
-						Reason:     corev1.InstalledPackageStatus_STATUS_REASON_INSTALLED,
-						UserReason: "Deployed",
+					Spec: datapackagingv1alpha1.PackageSpec{
+						RefName:                         "tetris.foo.example.com",
+						Version:                         "1.2.3",
+						Licenses:                        []string{"my-license"},
+						ReleaseNotes:                    "release notes",
+						CapactiyRequirementsDescription: "capacity description",
+						ReleasedAt:                      metav1.Time{Time: time.Date(1984, time.June, 6, 0, 0, 0, 0, time.UTC)},
 					},
 				},
 			},
+			errorCode: connect.CodeNotFound,
 		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var unstructuredObjects []k8sruntime.Object
+			for _, obj := range tc.existingObjects {
+				unstructuredContent, _ := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(obj)
+				unstructuredObjects = append(unstructuredObjects, &unstructured.Unstructured{Object: unstructuredContent})
+			}
+
+			s := Server{
+				pluginConfig: defaultPluginConfig,
+				clientGetter: clientgetter.NewBuilder().
+					WithDynamic(dynfake.NewSimpleDynamicClientWithCustomListKinds(
+						k8sruntime.NewScheme(),
+						map[schema.GroupVersionResource]string{
+							{Group: datapackagingv1alpha1.SchemeGroupVersion.Group, Version: datapackagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgsResource}:         pkgResource + "List",
+							{Group: datapackagingv1alpha1.SchemeGroupVersion.Group, Version: datapackagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgMetadatasResource}: pkgMetadataResource + "List",
+							{Group: packagingv1alpha1.SchemeGroupVersion.Group, Version: packagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgRepositoriesResource}:      pkgRepositoryResource + "List",
+						},
+						unstructuredObjects...,
+					)).Build(),
+			}
+			availablePackageDetail, err := s.GetAvailablePackageDetail(context.Background(), connect.NewRequest(tc.request))
+
+			if got, want := connect.CodeOf(err), tc.errorCode; err != nil && got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+
+			if tc.errorCode == 0 {
+				if got, want := availablePackageDetail.Msg.AvailablePackageDetail, tc.expectedPackage; !cmp.Equal(got, want, ignoreUnexported) {
+					t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexported))
+				}
+			}
+		})
+	}
+}
+
+// installed packages
+
+func TestGetInstalledPackageSummaries(t *testing.T) {
+	testCases := []struct {
+		name              string
+		request           *corev1.GetInstalledPackageSummariesRequest
+		existingObjects   []k8sruntime.Object
+		expectedPackages  []*corev1.InstalledPackageSummary
+		expectedErrorCode connect.Code
+	}{
 		{
-			name:    "it returns carvel installed package summary with a packageInstall without status",
-			request: &corev1.GetInstalledPackageSummariesRequest{Context: defaultContext},
+			name: "it returns an error if a non-existent page is requested",
+			request: &corev1.GetInstalledPackageSummariesRequest{
+				Context: defaultContext,
+				PaginationOptions: &corev1.PaginationOptions{
+					PageToken: "2",
+					PageSize:  2,
+				},
+			},
 			existingObjects: []k8sruntime.Object{
 				&datapackagingv1alpha1.PackageMetadata{
 					TypeMeta: metav1.TypeMeta{
@@ -2467,44 +2932,27 @@ func TestGetInstalledPackageSummaries(t *testing.T) {
 						SyncPeriod: &metav1.Duration{Duration: (time.Second * 30)},
 						NoopDelete: false,
 					},
-				},
-			},
-			expectedPackages: []*corev1.InstalledPackageSummary{
-				{
-					InstalledPackageRef: &corev1.InstalledPackageReference{
-						Context:    defaultContext,
-						Plugin:     &pluginDetail,
-						Identifier: "my-installation",
-					},
-					Name:           "my-installation",
-					PkgDisplayName: "Classic Tetris",
-					LatestVersion: &corev1.PackageAppVersion{
-						PkgVersion: "1.2.3",
-						AppVersion: "1.2.3",
-					},
-					IconUrl:          "data:image/svg+xml;base64,Tm90IHJlYWxseSBTVkcK",
-					ShortDescription: "A great game for arcade gamers",
-					PkgVersionReference: &corev1.VersionReference{
-						Version: "",
-					},
-					CurrentVersion: &corev1.PackageAppVersion{
-						PkgVersion: "",
-						AppVersion: "",
-					},
-					LatestMatchingVersion: &corev1.PackageAppVersion{
-						PkgVersion: "1.2.3",
-						AppVersion: "1.2.3",
-					},
-					Status: &corev1.InstalledPackageStatus{
-						Ready:      false,
-						Reason:     corev1.InstalledPackageStatus_STATUS_REASON_PENDING,
-						UserReason: "No status information yet",
+					Status: packagingv1alpha1.PackageInstallStatus{
+						GenericStatus: kappctrlv1alpha1.GenericStatus{
+							ObservedGeneration: 1,
+							Conditions: []kappctrlv1alpha1.Condition{{
+								Type:    kappctrlv1alpha1.ReconcileSucceeded,
+								Status:  k8scorev1.ConditionTrue,
+								Reason:  "baz",
+								Message: "qux",
+							}},
+							FriendlyDescription: "foo",
+							UsefulErrorMessage:  "Deployed",
+						},
+						Version:              "1.2.3",
+						LastAttemptedVersion: "1.2.3",
 					},
 				},
 			},
+			expectedErrorCode: connect.CodeInvalidArgument,
 		},
 		{
-			name:    "it returns the latest semver version in the latest version field with the latest matching version",
+			name:    "it returns carvel empty installed package summary when no package install is present",
 			request: &corev1.GetInstalledPackageSummariesRequest{Context: defaultContext},
 			existingObjects: []k8sruntime.Object{
 				&datapackagingv1alpha1.PackageMetadata{
@@ -2545,22 +2993,31 @@ func TestGetInstalledPackageSummaries(t *testing.T) {
 						ReleasedAt:                      metav1.Time{Time: time.Date(1984, time.June, 6, 0, 0, 0, 0, time.UTC)},
 					},
 				},
-				&datapackagingv1alpha1.Package{
+			},
+			expectedPackages: []*corev1.InstalledPackageSummary{},
+		},
+		{
+			name:    "it returns carvel installed package summary with complete metadata",
+			request: &corev1.GetInstalledPackageSummariesRequest{Context: defaultContext},
+			existingObjects: []k8sruntime.Object{
+				&datapackagingv1alpha1.PackageMetadata{
 					TypeMeta: metav1.TypeMeta{
-						Kind:       pkgResource,
+						Kind:       pkgMetadataResource,
 						APIVersion: datapackagingAPIVersion,
 					},
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: "default",
-						Name:      "tetris.foo.example.com.1.2.7",
+						Name:      "tetris.foo.example.com",
 					},
-					Spec: datapackagingv1alpha1.PackageSpec{
-						RefName:                         "tetris.foo.example.com",
-						Version:                         "1.2.7",
-						Licenses:                        []string{"my-license"},
-						ReleaseNotes:                    "release notes",
-						CapactiyRequirementsDescription: "capacity description",
-						ReleasedAt:                      metav1.Time{Time: time.Date(1984, time.June, 6, 0, 0, 0, 0, time.UTC)},
+					Spec: datapackagingv1alpha1.PackageMetadataSpec{
+						DisplayName:        "Classic Tetris",
+						IconSVGBase64:      "Tm90IHJlYWxseSBTVkcK",
+						ShortDescription:   "A great game for arcade gamers",
+						LongDescription:    "A few sentences but not really a readme",
+						Categories:         []string{"logging", "daemon-set"},
+						Maintainers:        []datapackagingv1alpha1.Maintainer{{Name: "person1"}, {Name: "person2"}},
+						SupportDescription: "Some support information",
+						ProviderName:       "Tetris inc.",
 					},
 				},
 				&datapackagingv1alpha1.Package{
@@ -2570,11 +3027,11 @@ func TestGetInstalledPackageSummaries(t *testing.T) {
 					},
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: "default",
-						Name:      "tetris.foo.example.com.2.0.0",
+						Name:      "tetris.foo.example.com.1.2.3",
 					},
 					Spec: datapackagingv1alpha1.PackageSpec{
 						RefName:                         "tetris.foo.example.com",
-						Version:                         "2.0.0",
+						Version:                         "1.2.3",
 						Licenses:                        []string{"my-license"},
 						ReleaseNotes:                    "release notes",
 						CapactiyRequirementsDescription: "capacity description",
@@ -2595,7 +3052,7 @@ func TestGetInstalledPackageSummaries(t *testing.T) {
 						PackageRef: &packagingv1alpha1.PackageRef{
 							RefName: "tetris.foo.example.com",
 							VersionSelection: &vendirversions.VersionSelectionSemver{
-								Constraints: ">1.0.0 <2.0.0",
+								Constraints: "1.2.3",
 							},
 						},
 						Values: []packagingv1alpha1.PackageInstallValues{{
@@ -2636,8 +3093,8 @@ func TestGetInstalledPackageSummaries(t *testing.T) {
 					Name:           "my-installation",
 					PkgDisplayName: "Classic Tetris",
 					LatestVersion: &corev1.PackageAppVersion{
-						PkgVersion: "2.0.0",
-						AppVersion: "2.0.0",
+						PkgVersion: "1.2.3",
+						AppVersion: "1.2.3",
 					},
 					IconUrl:             "data:image/svg+xml;base64,Tm90IHJlYWxseSBTVkcK",
 					ShortDescription:    "A great game for arcade gamers",
@@ -2647,8 +3104,8 @@ func TestGetInstalledPackageSummaries(t *testing.T) {
 						AppVersion: "1.2.3",
 					},
 					LatestMatchingVersion: &corev1.PackageAppVersion{
-						PkgVersion: "1.2.7",
-						AppVersion: "1.2.7",
+						PkgVersion: "1.2.3",
+						AppVersion: "1.2.3",
 					},
 					Status: &corev1.InstalledPackageStatus{
 						Ready:      true,
@@ -2659,8 +3116,17 @@ func TestGetInstalledPackageSummaries(t *testing.T) {
 			},
 		},
 		{
-			name:    "it returns the latest semver version in the latest version field with no latest matching version if constraint is not satisfied ",
-			request: &corev1.GetInstalledPackageSummariesRequest{Context: defaultContext},
+			name: "it returns carvel installed package summary with complete metadata from global namespace",
+			// Request in test has to use empty namespace to search across all
+			// namespaces since in real life the metadata and package resources
+			// are not actual CRs but aggregated APIs that return data across
+			// namespaces.
+			request: &corev1.GetInstalledPackageSummariesRequest{
+				Context: &corev1.Context{
+					Namespace: "",
+					Cluster:   defaultContext.Cluster,
+				},
+			},
 			existingObjects: []k8sruntime.Object{
 				&datapackagingv1alpha1.PackageMetadata{
 					TypeMeta: metav1.TypeMeta{
@@ -2668,7 +3134,7 @@ func TestGetInstalledPackageSummaries(t *testing.T) {
 						APIVersion: datapackagingAPIVersion,
 					},
 					ObjectMeta: metav1.ObjectMeta{
-						Namespace: "default",
+						Namespace: demoGlobalPackagingNamespace,
 						Name:      "tetris.foo.example.com",
 					},
 					Spec: datapackagingv1alpha1.PackageMetadataSpec{
@@ -2688,7 +3154,7 @@ func TestGetInstalledPackageSummaries(t *testing.T) {
 						APIVersion: datapackagingAPIVersion,
 					},
 					ObjectMeta: metav1.ObjectMeta{
-						Namespace: "default",
+						Namespace: demoGlobalPackagingNamespace,
 						Name:      "tetris.foo.example.com.1.2.3",
 					},
 					Spec: datapackagingv1alpha1.PackageSpec{
@@ -2697,23 +3163,7 @@ func TestGetInstalledPackageSummaries(t *testing.T) {
 						Licenses:                        []string{"my-license"},
 						ReleaseNotes:                    "release notes",
 						CapactiyRequirementsDescription: "capacity description",
-					},
-				},
-				&datapackagingv1alpha1.Package{
-					TypeMeta: metav1.TypeMeta{
-						Kind:       pkgResource,
-						APIVersion: datapackagingAPIVersion,
-					},
-					ObjectMeta: metav1.ObjectMeta{
-						Namespace: "default",
-						Name:      "tetris.foo.example.com.1.2.7",
-					},
-					Spec: datapackagingv1alpha1.PackageSpec{
-						RefName:                         "tetris.foo.example.com",
-						Version:                         "1.2.7",
-						Licenses:                        []string{"my-license"},
-						ReleaseNotes:                    "release notes",
-						CapactiyRequirementsDescription: "capacity description",
+						ReleasedAt:                      metav1.Time{Time: time.Date(1984, time.June, 6, 0, 0, 0, 0, time.UTC)},
 					},
 				},
 				&packagingv1alpha1.PackageInstall{
@@ -2730,7 +3180,7 @@ func TestGetInstalledPackageSummaries(t *testing.T) {
 						PackageRef: &packagingv1alpha1.PackageRef{
 							RefName: "tetris.foo.example.com",
 							VersionSelection: &vendirversions.VersionSelectionSemver{
-								Constraints: "9.9.9",
+								Constraints: "1.2.3",
 							},
 						},
 						Values: []packagingv1alpha1.PackageInstallValues{{
@@ -2771,8 +3221,8 @@ func TestGetInstalledPackageSummaries(t *testing.T) {
 					Name:           "my-installation",
 					PkgDisplayName: "Classic Tetris",
 					LatestVersion: &corev1.PackageAppVersion{
-						PkgVersion: "1.2.7",
-						AppVersion: "1.2.7",
+						PkgVersion: "1.2.3",
+						AppVersion: "1.2.3",
 					},
 					IconUrl:             "data:image/svg+xml;base64,Tm90IHJlYWxseSBTVkcK",
 					ShortDescription:    "A great game for arcade gamers",
@@ -2781,6 +3231,10 @@ func TestGetInstalledPackageSummaries(t *testing.T) {
 						PkgVersion: "1.2.3",
 						AppVersion: "1.2.3",
 					},
+					LatestMatchingVersion: &corev1.PackageAppVersion{
+						PkgVersion: "1.2.3",
+						AppVersion: "1.2.3",
+					},
 					Status: &corev1.InstalledPackageStatus{
 						Ready:      true,
 						Reason:     corev1.InstalledPackageStatus_STATUS_REASON_INSTALLED,
@@ -2789,92 +3243,22 @@ func TestGetInstalledPackageSummaries(t *testing.T) {
 				},
 			},
 		},
-	}
-
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			var unstructuredObjects []k8sruntime.Object
-			for _, obj := range tc.existingObjects {
-				unstructuredContent, _ := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(obj)
-				unstructuredObjects = append(unstructuredObjects, &unstructured.Unstructured{Object: unstructuredContent})
-			}
-
-			s := Server{
-				pluginConfig: defaultPluginConfig,
-				clientGetter: clientgetter.NewBuilder().
-					WithDynamic(dynfake.NewSimpleDynamicClientWithCustomListKinds(
-						k8sruntime.NewScheme(),
-						map[schema.GroupVersionResource]string{
-							{Group: datapackagingv1alpha1.SchemeGroupVersion.Group, Version: datapackagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgsResource}:         pkgResource + "List",
-							{Group: datapackagingv1alpha1.SchemeGroupVersion.Group, Version: datapackagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgMetadatasResource}: pkgMetadataResource + "List",
-							{Group: packagingv1alpha1.SchemeGroupVersion.Group, Version: packagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgInstallsResource}:          pkgInstallResource + "List",
-						},
-						unstructuredObjects...,
-					)).Build(),
-			}
-
-			response, err := s.GetInstalledPackageSummaries(context.Background(), connect.NewRequest(tc.request))
-
-			if got, want := connect.CodeOf(err), tc.expectedErrorCode; err != nil && got != want {
-				t.Fatalf("got: %d, want: %d, err: %+v", got, want, err)
-			}
-			// If we were expecting an error, continue to the next test.
-			if tc.expectedErrorCode != 0 {
-				return
-			}
-
-			if got, want := response.Msg.InstalledPackageSummaries, tc.expectedPackages; !cmp.Equal(got, want, ignoreUnexported) {
-				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexported))
-			}
-		})
-	}
-}
-
-func TestGetInstalledPackageDetail(t *testing.T) {
-	testCases := []struct {
-		name                 string
-		existingObjects      []k8sruntime.Object
-		existingTypedObjects []k8sruntime.Object
-		expectedPackage      *corev1.InstalledPackageDetail
-		errorCode            connect.Code
-		request              *corev1.GetInstalledPackageDetailRequest
-	}{
 		{
-			name: "it returns carvel installed package detail with the latest matching version",
-			request: &corev1.GetInstalledPackageDetailRequest{
-				InstalledPackageRef: &corev1.InstalledPackageReference{
-					Context:    defaultContext,
-					Identifier: "my-installation",
+			name: "it ignores carvel package install without any related metadata",
+			request: &corev1.GetInstalledPackageSummariesRequest{
+				Context: &corev1.Context{
+					Namespace: "",
+					Cluster:   defaultContext.Cluster,
 				},
 			},
 			existingObjects: []k8sruntime.Object{
-				&datapackagingv1alpha1.PackageMetadata{
-					TypeMeta: metav1.TypeMeta{
-						Kind:       pkgMetadataResource,
-						APIVersion: datapackagingAPIVersion,
-					},
-					ObjectMeta: metav1.ObjectMeta{
-						Namespace: "default",
-						Name:      "tetris.foo.example.com",
-					},
-					Spec: datapackagingv1alpha1.PackageMetadataSpec{
-						DisplayName:        "Classic Tetris",
-						IconSVGBase64:      "Tm90IHJlYWxseSBTVkcK",
-						ShortDescription:   "A great game for arcade gamers",
-						LongDescription:    "A few sentences but not really a readme",
-						Categories:         []string{"logging", "daemon-set"},
-						Maintainers:        []datapackagingv1alpha1.Maintainer{{Name: "person1"}, {Name: "person2"}},
-						SupportDescription: "Some support information",
-						ProviderName:       "Tetris inc.",
-					},
-				},
 				&datapackagingv1alpha1.Package{
 					TypeMeta: metav1.TypeMeta{
 						Kind:       pkgResource,
 						APIVersion: datapackagingAPIVersion,
 					},
 					ObjectMeta: metav1.ObjectMeta{
-						Namespace: "default",
+						Namespace: demoGlobalPackagingNamespace,
 						Name:      "tetris.foo.example.com.1.2.3",
 					},
 					Spec: datapackagingv1alpha1.PackageSpec{
@@ -2886,40 +3270,6 @@ func TestGetInstalledPackageDetail(t *testing.T) {
 						ReleasedAt:                      metav1.Time{Time: time.Date(1984, time.June, 6, 0, 0, 0, 0, time.UTC)},
 					},
 				},
-				&datapackagingv1alpha1.Package{
-					TypeMeta: metav1.TypeMeta{
-						Kind:       pkgResource,
-						APIVersion: datapackagingAPIVersion,
-					},
-					ObjectMeta: metav1.ObjectMeta{
-						Namespace: "default",
-						Name:      "tetris.foo.example.com.1.2.7",
-					},
-					Spec: datapackagingv1alpha1.PackageSpec{
-						RefName:                         "tetris.foo.example.com",
-						Version:                         "1.2.7",
-						Licenses:                        []string{"my-license"},
-						ReleaseNotes:                    "release notes",
-						CapactiyRequirementsDescription: "capacity description",
-					},
-				},
-				&datapackagingv1alpha1.Package{
-					TypeMeta: metav1.TypeMeta{
-						Kind:       pkgResource,
-						APIVersion: datapackagingAPIVersion,
-					},
-					ObjectMeta: metav1.ObjectMeta{
-						Namespace: "default",
-						Name:      "tetris.foo.example.com.2.0.0",
-					},
-					Spec: datapackagingv1alpha1.PackageSpec{
-						RefName:                         "tetris.foo.example.com",
-						Version:                         "2.0.0",
-						Licenses:                        []string{"my-license"},
-						ReleaseNotes:                    "release notes",
-						CapactiyRequirementsDescription: "capacity description",
-					},
-				},
 				&packagingv1alpha1.PackageInstall{
 					TypeMeta: metav1.TypeMeta{
 						Kind:       pkgInstallResource,
@@ -2934,7 +3284,7 @@ func TestGetInstalledPackageDetail(t *testing.T) {
 						PackageRef: &packagingv1alpha1.PackageRef{
 							RefName: "tetris.foo.example.com",
 							VersionSelection: &vendirversions.VersionSelectionSemver{
-								Constraints: ">1.0.0 <2.0.0",
+								Constraints: "1.2.3",
 							},
 						},
 						Values: []packagingv1alpha1.PackageInstallValues{{
@@ -2964,119 +3314,15 @@ func TestGetInstalledPackageDetail(t *testing.T) {
 						LastAttemptedVersion: "1.2.3",
 					},
 				},
-				&kappctrlv1alpha1.App{
-					TypeMeta: metav1.TypeMeta{
-						Kind:       appResource,
-						APIVersion: kappctrlAPIVersion,
-					},
-					ObjectMeta: metav1.ObjectMeta{
-						Namespace: "default",
-						Name:      "my-installation",
-					},
-					Spec: kappctrlv1alpha1.AppSpec{
-						SyncPeriod: &metav1.Duration{Duration: (time.Second * 30)},
-					},
-					Status: kappctrlv1alpha1.AppStatus{
-						Deploy: &kappctrlv1alpha1.AppStatusDeploy{
-							Stdout: "deployStdout",
-							Stderr: "deployStderr",
-						},
-						Fetch: &kappctrlv1alpha1.AppStatusFetch{
-							Stdout: "fetchStdout",
-							Stderr: "fetchStderr",
-						},
-						Inspect: &kappctrlv1alpha1.AppStatusInspect{
-							Stdout: "inspectStdout",
-							Stderr: "inspectStderr",
-						},
-					},
-				},
-			},
-			existingTypedObjects: []k8sruntime.Object{
-				&k8scorev1.Secret{
-					ObjectMeta: metav1.ObjectMeta{
-						Namespace: "default",
-						Name:      "my-installation-default-values",
-					},
-					Type: "Opaque",
-					Data: map[string][]byte{
-						"values.yaml": []byte("foo: bar"),
-					},
-				},
-			},
-			expectedPackage: &corev1.InstalledPackageDetail{
-				AvailablePackageRef: &corev1.AvailablePackageReference{
-					Context:    defaultContext,
-					Plugin:     &pluginDetail,
-					Identifier: "unknown/tetris.foo.example.com",
-				},
-				InstalledPackageRef: &corev1.InstalledPackageReference{
-					Context:    defaultContext,
-					Plugin:     &pluginDetail,
-					Identifier: "my-installation",
-				},
-				Name: "my-installation",
-				PkgVersionReference: &corev1.VersionReference{
-					Version: "1.2.3",
-				},
-				CurrentVersion: &corev1.PackageAppVersion{
-					PkgVersion: "1.2.3",
-					AppVersion: "1.2.3",
-				},
-				ValuesApplied: "\n# values.yaml\nfoo: bar\n",
-				ReconciliationOptions: &corev1.ReconciliationOptions{
-					ServiceAccountName: "default",
-					Interval:           "30s",
-					Suspend:            false,
-				},
-				Status: &corev1.InstalledPackageStatus{
-					Ready:      true,
-					Reason:     corev1.InstalledPackageStatus_STATUS_REASON_INSTALLED,
-					UserReason: "Deployed",
-				},
-				PostInstallationNotes: strings.ReplaceAll(`#### Deploy
-
-<x60><x60><x60>
-deployStdout
-<x60><x60><x60>
-
-#### Fetch
-
-<x60><x60><x60>
-fetchStdout
-<x60><x60><x60>
-
-### Errors
-
-#### Deploy
-
-<x60><x60><x60>
-deployStderr
-<x60><x60><x60>
-
-#### Fetch
-
-<x60><x60><x60>
-fetchStderr
-<x60><x60><x60>
-
-`, "<x60>", "`"),
-				LatestMatchingVersion: &corev1.PackageAppVersion{
-					PkgVersion: "1.2.7",
-					AppVersion: "1.2.7",
-				},
-				LatestVersion: &corev1.PackageAppVersion{
-					PkgVersion: "2.0.0",
-					AppVersion: "2.0.0",
-				},
 			},
+			expectedPackages: []*corev1.InstalledPackageSummary{},
 		},
 		{
-			name: "it returns carvel installed package detail with the latest matching version and repo-based identifiers",
-			request: &corev1.GetInstalledPackageDetailRequest{
-				InstalledPackageRef: &corev1.InstalledPackageReference{
-					Context:    defaultContext,
-					Identifier: "my-installation",
+			name: "it returns carvel installed package from different namespaces if context.namespace==''",
+			request: &corev1.GetInstalledPackageSummariesRequest{
+				Context: &corev1.Context{
+					Namespace: "",
+					Cluster:   defaultContext.Cluster,
 				},
 			},
 			existingObjects: []k8sruntime.Object{
@@ -3088,9 +3334,6 @@ fetchStderr
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: "default",
 						Name:      "tetris.foo.example.com",
-						Annotations: map[string]string{
-							REPO_REF_ANNOTATION: "default/tce-repo",
-						},
 					},
 					Spec: datapackagingv1alpha1.PackageMetadataSpec{
 						DisplayName:        "Classic Tetris",
@@ -3103,22 +3346,24 @@ fetchStderr
 						ProviderName:       "Tetris inc.",
 					},
 				},
-				&datapackagingv1alpha1.Package{
+				&datapackagingv1alpha1.PackageMetadata{
 					TypeMeta: metav1.TypeMeta{
-						Kind:       pkgResource,
+						Kind:       pkgMetadataResource,
 						APIVersion: datapackagingAPIVersion,
 					},
 					ObjectMeta: metav1.ObjectMeta{
-						Namespace: "default",
-						Name:      "tetris.foo.example.com.1.2.3",
+						Namespace: "another-ns",
+						Name:      "tetris.foo.example.com",
 					},
-					Spec: datapackagingv1alpha1.PackageSpec{
-						RefName:                         "tetris.foo.example.com",
-						Version:                         "1.2.3",
-						Licenses:                        []string{"my-license"},
-						ReleaseNotes:                    "release notes",
-						CapactiyRequirementsDescription: "capacity description",
-						ReleasedAt:                      metav1.Time{Time: time.Date(1984, time.June, 6, 0, 0, 0, 0, time.UTC)},
+					Spec: datapackagingv1alpha1.PackageMetadataSpec{
+						DisplayName:        "Classic Tetris",
+						IconSVGBase64:      "Tm90IHJlYWxseSBTVkcK",
+						ShortDescription:   "A great game for arcade gamers",
+						LongDescription:    "A few sentences but not really a readme",
+						Categories:         []string{"logging", "daemon-set"},
+						Maintainers:        []datapackagingv1alpha1.Maintainer{{Name: "person1"}, {Name: "person2"}},
+						SupportDescription: "Some support information",
+						ProviderName:       "Tetris inc.",
 					},
 				},
 				&datapackagingv1alpha1.Package{
@@ -3128,14 +3373,15 @@ fetchStderr
 					},
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: "default",
-						Name:      "tetris.foo.example.com.1.2.7",
+						Name:      "tetris.foo.example.com.1.2.3",
 					},
 					Spec: datapackagingv1alpha1.PackageSpec{
 						RefName:                         "tetris.foo.example.com",
-						Version:                         "1.2.7",
+						Version:                         "1.2.3",
 						Licenses:                        []string{"my-license"},
 						ReleaseNotes:                    "release notes",
 						CapactiyRequirementsDescription: "capacity description",
+						ReleasedAt:                      metav1.Time{Time: time.Date(1984, time.June, 6, 0, 0, 0, 0, time.UTC)},
 					},
 				},
 				&datapackagingv1alpha1.Package{
@@ -3144,15 +3390,16 @@ fetchStderr
 						APIVersion: datapackagingAPIVersion,
 					},
 					ObjectMeta: metav1.ObjectMeta{
-						Namespace: "default",
-						Name:      "tetris.foo.example.com.2.0.0",
+						Namespace: "another-ns",
+						Name:      "tetris.foo.example.com.1.2.3",
 					},
 					Spec: datapackagingv1alpha1.PackageSpec{
 						RefName:                         "tetris.foo.example.com",
-						Version:                         "2.0.0",
+						Version:                         "1.2.3",
 						Licenses:                        []string{"my-license"},
 						ReleaseNotes:                    "release notes",
 						CapactiyRequirementsDescription: "capacity description",
+						ReleasedAt:                      metav1.Time{Time: time.Date(1984, time.June, 6, 0, 0, 0, 0, time.UTC)},
 					},
 				},
 				&packagingv1alpha1.PackageInstall{
@@ -3169,7 +3416,7 @@ fetchStderr
 						PackageRef: &packagingv1alpha1.PackageRef{
 							RefName: "tetris.foo.example.com",
 							VersionSelection: &vendirversions.VersionSelectionSemver{
-								Constraints: ">1.0.0 <2.0.0",
+								Constraints: "1.2.3",
 							},
 						},
 						Values: []packagingv1alpha1.PackageInstallValues{{
@@ -3193,127 +3440,227 @@ fetchStderr
 								Message: "qux",
 							}},
 							FriendlyDescription: "foo",
-							UsefulErrorMessage:  "Deployed",
+							UsefulErrorMessage:  "foo",
 						},
 						Version:              "1.2.3",
 						LastAttemptedVersion: "1.2.3",
 					},
 				},
-				&kappctrlv1alpha1.App{
+				&packagingv1alpha1.PackageInstall{
 					TypeMeta: metav1.TypeMeta{
-						Kind:       appResource,
-						APIVersion: kappctrlAPIVersion,
+						Kind:       pkgInstallResource,
+						APIVersion: packagingAPIVersion,
 					},
 					ObjectMeta: metav1.ObjectMeta{
-						Namespace: "default",
+						Namespace: "another-ns",
 						Name:      "my-installation",
 					},
-					Spec: kappctrlv1alpha1.AppSpec{
-						SyncPeriod: &metav1.Duration{Duration: (time.Second * 30)},
-					},
-					Status: kappctrlv1alpha1.AppStatus{
-						Deploy: &kappctrlv1alpha1.AppStatusDeploy{
-							Stdout: "deployStdout",
-							Stderr: "deployStderr",
+					Spec: packagingv1alpha1.PackageInstallSpec{
+						ServiceAccountName: "default",
+						PackageRef: &packagingv1alpha1.PackageRef{
+							RefName: "tetris.foo.example.com",
+							VersionSelection: &vendirversions.VersionSelectionSemver{
+								Constraints: "1.2.3",
+							},
 						},
-						Fetch: &kappctrlv1alpha1.AppStatusFetch{
-							Stdout: "fetchStdout",
-							Stderr: "fetchStderr",
+						Values: []packagingv1alpha1.PackageInstallValues{{
+							SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{
+								Name: "my-installation-default-values",
+							},
 						},
-						Inspect: &kappctrlv1alpha1.AppStatusInspect{
-							Stdout: "inspectStdout",
-							Stderr: "inspectStderr",
 						},
+						Paused:     false,
+						Canceled:   false,
+						SyncPeriod: &metav1.Duration{Duration: (time.Second * 30)},
+						NoopDelete: false,
+					},
+					Status: packagingv1alpha1.PackageInstallStatus{
+						GenericStatus: kappctrlv1alpha1.GenericStatus{
+							ObservedGeneration: 1,
+							Conditions: []kappctrlv1alpha1.Condition{{
+								Type:    kappctrlv1alpha1.ReconcileSucceeded,
+								Status:  k8scorev1.ConditionTrue,
+								Reason:  "baz",
+								Message: "qux",
+							}},
+							FriendlyDescription: "foo",
+							UsefulErrorMessage:  "foo",
+						},
+						Version:              "1.2.3",
+						LastAttemptedVersion: "1.2.3",
 					},
 				},
 			},
-			existingTypedObjects: []k8sruntime.Object{
-				&k8scorev1.Secret{
-					ObjectMeta: metav1.ObjectMeta{
-						Namespace: "default",
-						Name:      "my-installation-default-values",
+			expectedPackages: []*corev1.InstalledPackageSummary{
+				{
+					InstalledPackageRef: &corev1.InstalledPackageReference{
+						Context:    &corev1.Context{Namespace: "another-ns", Cluster: defaultContext.Cluster},
+						Plugin:     &pluginDetail,
+						Identifier: "my-installation",
 					},
-					Type: "Opaque",
-					Data: map[string][]byte{
-						"values.yaml": []byte("foo: bar"),
+					Name:           "my-installation",
+					PkgDisplayName: "Classic Tetris",
+					LatestVersion: &corev1.PackageAppVersion{
+						PkgVersion: "1.2.3",
+						AppVersion: "1.2.3",
+					},
+					IconUrl:             "data:image/svg+xml;base64,Tm90IHJlYWxseSBTVkcK",
+					ShortDescription:    "A great game for arcade gamers",
+					PkgVersionReference: &corev1.VersionReference{Version: "1.2.3"},
+					CurrentVersion: &corev1.PackageAppVersion{
+						PkgVersion: "1.2.3",
+						AppVersion: "1.2.3",
+					},
+					LatestMatchingVersion: &corev1.PackageAppVersion{
+						PkgVersion: "1.2.3",
+						AppVersion: "1.2.3",
+					},
+					Status: &corev1.InstalledPackageStatus{
+						Ready:      true,
+						Reason:     corev1.InstalledPackageStatus_STATUS_REASON_INSTALLED,
+						UserReason: "Deployed",
 					},
 				},
-			},
-			expectedPackage: &corev1.InstalledPackageDetail{
-				AvailablePackageRef: &corev1.AvailablePackageReference{
-					Context:    defaultContext,
-					Plugin:     &pluginDetail,
-					Identifier: "tce-repo/tetris.foo.example.com",
-				},
-				InstalledPackageRef: &corev1.InstalledPackageReference{
-					Context:    defaultContext,
-					Plugin:     &pluginDetail,
-					Identifier: "my-installation",
-				},
-				Name: "my-installation",
-				PkgVersionReference: &corev1.VersionReference{
-					Version: "1.2.3",
-				},
-				CurrentVersion: &corev1.PackageAppVersion{
-					PkgVersion: "1.2.3",
-					AppVersion: "1.2.3",
-				},
-				ValuesApplied: "\n# values.yaml\nfoo: bar\n",
-				ReconciliationOptions: &corev1.ReconciliationOptions{
-					ServiceAccountName: "default",
-					Interval:           "30s",
-					Suspend:            false,
-				},
-				Status: &corev1.InstalledPackageStatus{
-					Ready:      true,
-					Reason:     corev1.InstalledPackageStatus_STATUS_REASON_INSTALLED,
-					UserReason: "Deployed",
-				},
-				PostInstallationNotes: strings.ReplaceAll(`#### Deploy
-
-<x60><x60><x60>
-deployStdout
-<x60><x60><x60>
-
-#### Fetch
-
-<x60><x60><x60>
-fetchStdout
-<x60><x60><x60>
-
-### Errors
-
-#### Deploy
-
-<x60><x60><x60>
-deployStderr
-<x60><x60><x60>
-
-#### Fetch
-
-<x60><x60><x60>
-fetchStderr
-<x60><x60><x60>
-
-`, "<x60>", "`"),
-				LatestMatchingVersion: &corev1.PackageAppVersion{
-					PkgVersion: "1.2.7",
-					AppVersion: "1.2.7",
-				},
-				LatestVersion: &corev1.PackageAppVersion{
-					PkgVersion: "2.0.0",
-					AppVersion: "2.0.0",
+				{
+					InstalledPackageRef: &corev1.InstalledPackageReference{
+						Context:    defaultContext,
+						Plugin:     &pluginDetail,
+						Identifier: "my-installation",
+					},
+					Name:           "my-installation",
+					PkgDisplayName: "Classic Tetris",
+					LatestVersion: &corev1.PackageAppVersion{
+						PkgVersion: "1.2.3",
+						AppVersion: "1.2.3",
+					},
+					IconUrl:             "data:image/svg+xml;base64,Tm90IHJlYWxseSBTVkcK",
+					ShortDescription:    "A great game for arcade gamers",
+					PkgVersionReference: &corev1.VersionReference{Version: "1.2.3"},
+					CurrentVersion: &corev1.PackageAppVersion{
+						PkgVersion: "1.2.3",
+						AppVersion: "1.2.3",
+					},
+					LatestMatchingVersion: &corev1.PackageAppVersion{
+						PkgVersion: "1.2.3",
+						AppVersion: "1.2.3",
+					},
+					Status: &corev1.InstalledPackageStatus{
+						Ready:      true,
+						Reason:     corev1.InstalledPackageStatus_STATUS_REASON_INSTALLED,
+						UserReason: "Deployed",
+					},
 				},
 			},
 		},
 		{
-			name: "it returns carvel installed package detail with no latest matching version if constraint is not satisfied",
-			request: &corev1.GetInstalledPackageDetailRequest{
-				InstalledPackageRef: &corev1.InstalledPackageReference{
-					Context:    defaultContext,
-					Identifier: "my-installation",
+			name:    "it returns carvel installed package summary with a packageInstall without status",
+			request: &corev1.GetInstalledPackageSummariesRequest{Context: defaultContext},
+			existingObjects: []k8sruntime.Object{
+				&datapackagingv1alpha1.PackageMetadata{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       pkgMetadataResource,
+						APIVersion: datapackagingAPIVersion,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "tetris.foo.example.com",
+					},
+					Spec: datapackagingv1alpha1.PackageMetadataSpec{
+						DisplayName:        "Classic Tetris",
+						IconSVGBase64:      "Tm90IHJlYWxseSBTVkcK",
+						ShortDescription:   "A great game for arcade gamers",
+						LongDescription:    "A few sentences but not really a readme",
+						Categories:         []string{"logging", "daemon-set"},
+						Maintainers:        []datapackagingv1alpha1.Maintainer{{Name: "person1"}, {Name: "person2"}},
+						SupportDescription: "Some support information",
+						ProviderName:       "Tetris inc.",
+					},
+				},
+				&datapackagingv1alpha1.Package{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       pkgResource,
+						APIVersion: datapackagingAPIVersion,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "tetris.foo.example.com.1.2.3",
+					},
+					Spec: datapackagingv1alpha1.PackageSpec{
+						RefName:                         "tetris.foo.example.com",
+						Version:                         "1.2.3",
+						Licenses:                        []string{"my-license"},
+						ReleaseNotes:                    "release notes",
+						CapactiyRequirementsDescription: "capacity description",
+						ReleasedAt:                      metav1.Time{Time: time.Date(1984, time.June, 6, 0, 0, 0, 0, time.UTC)},
+					},
+				},
+				&packagingv1alpha1.PackageInstall{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       pkgInstallResource,
+						APIVersion: packagingAPIVersion,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "my-installation",
+					},
+					Spec: packagingv1alpha1.PackageInstallSpec{
+						ServiceAccountName: "default",
+						PackageRef: &packagingv1alpha1.PackageRef{
+							RefName: "tetris.foo.example.com",
+							VersionSelection: &vendirversions.VersionSelectionSemver{
+								Constraints: "1.2.3",
+							},
+						},
+						Values: []packagingv1alpha1.PackageInstallValues{{
+							SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{
+								Name: "my-installation-default-values",
+							},
+						},
+						},
+						Paused:     false,
+						Canceled:   false,
+						SyncPeriod: &metav1.Duration{Duration: (time.Second * 30)},
+						NoopDelete: false,
+					},
+				},
+			},
+			expectedPackages: []*corev1.InstalledPackageSummary{
+				{
+					InstalledPackageRef: &corev1.InstalledPackageReference{
+						Context:    defaultContext,
+						Plugin:     &pluginDetail,
+						Identifier: "my-installation",
+					},
+					Name:           "my-installation",
+					PkgDisplayName: "Classic Tetris",
+					LatestVersion: &corev1.PackageAppVersion{
+						PkgVersion: "1.2.3",
+						AppVersion: "1.2.3",
+					},
+					IconUrl:          "data:image/svg+xml;base64,Tm90IHJlYWxseSBTVkcK",
+					ShortDescription: "A great game for arcade gamers",
+					PkgVersionReference: &corev1.VersionReference{
+						Version: "",
+					},
+					CurrentVersion: &corev1.PackageAppVersion{
+						PkgVersion: "",
+						AppVersion: "",
+					},
+					LatestMatchingVersion: &corev1.PackageAppVersion{
+						PkgVersion: "1.2.3",
+						AppVersion: "1.2.3",
+					},
+					Status: &corev1.InstalledPackageStatus{
+						Ready:      false,
+						Reason:     corev1.InstalledPackageStatus_STATUS_REASON_PENDING,
+						UserReason: "No status information yet",
+					},
 				},
 			},
+		},
+		{
+			name:    "it returns the latest semver version in the latest version field with the latest matching version",
+			request: &corev1.GetInstalledPackageSummariesRequest{Context: defaultContext},
 			existingObjects: []k8sruntime.Object{
 				&datapackagingv1alpha1.PackageMetadata{
 					TypeMeta: metav1.TypeMeta{
@@ -3350,6 +3697,43 @@ fetchStderr
 						Licenses:                        []string{"my-license"},
 						ReleaseNotes:                    "release notes",
 						CapactiyRequirementsDescription: "capacity description",
+						ReleasedAt:                      metav1.Time{Time: time.Date(1984, time.June, 6, 0, 0, 0, 0, time.UTC)},
+					},
+				},
+				&datapackagingv1alpha1.Package{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       pkgResource,
+						APIVersion: datapackagingAPIVersion,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "tetris.foo.example.com.1.2.7",
+					},
+					Spec: datapackagingv1alpha1.PackageSpec{
+						RefName:                         "tetris.foo.example.com",
+						Version:                         "1.2.7",
+						Licenses:                        []string{"my-license"},
+						ReleaseNotes:                    "release notes",
+						CapactiyRequirementsDescription: "capacity description",
+						ReleasedAt:                      metav1.Time{Time: time.Date(1984, time.June, 6, 0, 0, 0, 0, time.UTC)},
+					},
+				},
+				&datapackagingv1alpha1.Package{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       pkgResource,
+						APIVersion: datapackagingAPIVersion,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "tetris.foo.example.com.2.0.0",
+					},
+					Spec: datapackagingv1alpha1.PackageSpec{
+						RefName:                         "tetris.foo.example.com",
+						Version:                         "2.0.0",
+						Licenses:                        []string{"my-license"},
+						ReleaseNotes:                    "release notes",
+						CapactiyRequirementsDescription: "capacity description",
+						ReleasedAt:                      metav1.Time{Time: time.Date(1984, time.June, 6, 0, 0, 0, 0, time.UTC)},
 					},
 				},
 				&packagingv1alpha1.PackageInstall{
@@ -3366,7 +3750,7 @@ fetchStderr
 						PackageRef: &packagingv1alpha1.PackageRef{
 							RefName: "tetris.foo.example.com",
 							VersionSelection: &vendirversions.VersionSelectionSemver{
-								Constraints: "9.9.9",
+								Constraints: ">1.0.0 <2.0.0",
 							},
 						},
 						Values: []packagingv1alpha1.PackageInstallValues{{
@@ -3396,182 +3780,42 @@ fetchStderr
 						LastAttemptedVersion: "1.2.3",
 					},
 				},
-				&kappctrlv1alpha1.App{
-					TypeMeta: metav1.TypeMeta{
-						Kind:       appResource,
-						APIVersion: kappctrlAPIVersion,
-					},
-					ObjectMeta: metav1.ObjectMeta{
-						Namespace: "default",
-						Name:      "my-installation",
+			},
+			expectedPackages: []*corev1.InstalledPackageSummary{
+				{
+					InstalledPackageRef: &corev1.InstalledPackageReference{
+						Context:    defaultContext,
+						Plugin:     &pluginDetail,
+						Identifier: "my-installation",
 					},
-					Spec: kappctrlv1alpha1.AppSpec{
-						SyncPeriod: &metav1.Duration{Duration: (time.Second * 30)},
+					Name:           "my-installation",
+					PkgDisplayName: "Classic Tetris",
+					LatestVersion: &corev1.PackageAppVersion{
+						PkgVersion: "2.0.0",
+						AppVersion: "2.0.0",
 					},
-					Status: kappctrlv1alpha1.AppStatus{
-						Deploy: &kappctrlv1alpha1.AppStatusDeploy{
-							Stdout: "deployStdout",
-							Stderr: "deployStderr",
-						},
-						Fetch: &kappctrlv1alpha1.AppStatusFetch{
-							Stdout: "fetchStdout",
-							Stderr: "fetchStderr",
-						},
-						Inspect: &kappctrlv1alpha1.AppStatusInspect{
-							Stdout: "inspectStdout",
-							Stderr: "inspectStderr",
-						},
+					IconUrl:             "data:image/svg+xml;base64,Tm90IHJlYWxseSBTVkcK",
+					ShortDescription:    "A great game for arcade gamers",
+					PkgVersionReference: &corev1.VersionReference{Version: "1.2.3"},
+					CurrentVersion: &corev1.PackageAppVersion{
+						PkgVersion: "1.2.3",
+						AppVersion: "1.2.3",
 					},
-				},
-			},
-			existingTypedObjects: []k8sruntime.Object{
-				&k8scorev1.Secret{
-					ObjectMeta: metav1.ObjectMeta{
-						Namespace: "default",
-						Name:      "my-installation-default-values",
+					LatestMatchingVersion: &corev1.PackageAppVersion{
+						PkgVersion: "1.2.7",
+						AppVersion: "1.2.7",
 					},
-					Type: "Opaque",
-					Data: map[string][]byte{
-						"values.yaml": []byte("foo: bar"),
+					Status: &corev1.InstalledPackageStatus{
+						Ready:      true,
+						Reason:     corev1.InstalledPackageStatus_STATUS_REASON_INSTALLED,
+						UserReason: "Deployed",
 					},
 				},
 			},
-			expectedPackage: &corev1.InstalledPackageDetail{
-				AvailablePackageRef: &corev1.AvailablePackageReference{
-					Context:    defaultContext,
-					Plugin:     &pluginDetail,
-					Identifier: "unknown/tetris.foo.example.com",
-				},
-				InstalledPackageRef: &corev1.InstalledPackageReference{
-					Context:    defaultContext,
-					Plugin:     &pluginDetail,
-					Identifier: "my-installation",
-				},
-				Name: "my-installation",
-				PkgVersionReference: &corev1.VersionReference{
-					Version: "1.2.3",
-				},
-				CurrentVersion: &corev1.PackageAppVersion{
-					PkgVersion: "1.2.3",
-					AppVersion: "1.2.3",
-				},
-				ValuesApplied: "\n# values.yaml\nfoo: bar\n",
-				ReconciliationOptions: &corev1.ReconciliationOptions{
-					ServiceAccountName: "default",
-					Interval:           "30s",
-					Suspend:            false,
-				},
-				Status: &corev1.InstalledPackageStatus{
-					Ready:      true,
-					Reason:     corev1.InstalledPackageStatus_STATUS_REASON_INSTALLED,
-					UserReason: "Deployed",
-				},
-				PostInstallationNotes: strings.ReplaceAll(`#### Deploy
-
-<x60><x60><x60>
-deployStdout
-<x60><x60><x60>
-
-#### Fetch
-
-<x60><x60><x60>
-fetchStdout
-<x60><x60><x60>
-
-### Errors
-
-#### Deploy
-
-<x60><x60><x60>
-deployStderr
-<x60><x60><x60>
-
-#### Fetch
-
-<x60><x60><x60>
-fetchStderr
-<x60><x60><x60>
-
-`, "<x60>", "`"),
-				LatestVersion: &corev1.PackageAppVersion{
-					PkgVersion: "1.2.3",
-					AppVersion: "1.2.3",
-				},
-			},
 		},
-	}
-
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			var unstructuredObjects []k8sruntime.Object
-			for _, obj := range tc.existingObjects {
-				unstructuredContent, _ := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(obj)
-				unstructuredObjects = append(unstructuredObjects, &unstructured.Unstructured{Object: unstructuredContent})
-			}
-
-			s := Server{
-				pluginConfig: defaultPluginConfig,
-				clientGetter: clientgetter.NewBuilder().
-					WithTyped(typfake.NewSimpleClientset(tc.existingTypedObjects...)).
-					WithDynamic(dynfake.NewSimpleDynamicClientWithCustomListKinds(
-						k8sruntime.NewScheme(),
-						map[schema.GroupVersionResource]string{
-							{Group: datapackagingv1alpha1.SchemeGroupVersion.Group, Version: datapackagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgsResource}:         pkgResource + "List",
-							{Group: datapackagingv1alpha1.SchemeGroupVersion.Group, Version: datapackagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgMetadatasResource}: pkgMetadataResource + "List",
-						},
-						unstructuredObjects...,
-					)).Build(),
-			}
-			installedPackageDetail, err := s.GetInstalledPackageDetail(context.Background(), connect.NewRequest(tc.request))
-
-			if got, want := connect.CodeOf(err), tc.errorCode; err != nil && got != want {
-				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
-			}
-
-			if tc.errorCode == 0 {
-				if got, want := installedPackageDetail.Msg.InstalledPackageDetail, tc.expectedPackage; !cmp.Equal(got, want, ignoreUnexported) {
-					t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexported))
-				}
-			}
-		})
-	}
-}
-
-func TestCreateInstalledPackage(t *testing.T) {
-	testCases := []struct {
-		name                   string
-		request                *corev1.CreateInstalledPackageRequest
-		pluginConfig           *kappControllerPluginParsedConfig
-		existingObjects        []k8sruntime.Object
-		existingTypedObjects   []k8sruntime.Object
-		expectedErrorCode      connect.Code
-		expectedResponse       *corev1.CreateInstalledPackageResponse
-		expectedPackageInstall *packagingv1alpha1.PackageInstall
-	}{
 		{
-			name: "create installed package",
-			request: &corev1.CreateInstalledPackageRequest{
-				AvailablePackageRef: &corev1.AvailablePackageReference{
-					Context: &corev1.Context{
-						Namespace: "default",
-						Cluster:   "default",
-					},
-					Plugin:     &pluginDetail,
-					Identifier: "unknown/tetris.foo.example.com",
-				},
-				PkgVersionReference: &corev1.VersionReference{
-					Version: "1.2.3",
-				},
-				Name: "my-installation",
-				TargetContext: &corev1.Context{
-					Namespace: "default",
-					Cluster:   "default",
-				},
-				ReconciliationOptions: &corev1.ReconciliationOptions{
-					ServiceAccountName: "default",
-				},
-			},
-			pluginConfig: defaultPluginConfig,
+			name:    "it returns the latest semver version in the latest version field with no latest matching version if constraint is not satisfied ",
+			request: &corev1.GetInstalledPackageSummariesRequest{Context: defaultContext},
 			existingObjects: []k8sruntime.Object{
 				&datapackagingv1alpha1.PackageMetadata{
 					TypeMeta: metav1.TypeMeta{
@@ -3608,128 +3852,107 @@ func TestCreateInstalledPackage(t *testing.T) {
 						Licenses:                        []string{"my-license"},
 						ReleaseNotes:                    "release notes",
 						CapactiyRequirementsDescription: "capacity description",
-						ReleasedAt:                      metav1.Time{Time: time.Date(1984, time.June, 6, 0, 0, 0, 0, time.UTC)},
 					},
 				},
-				&kappctrlv1alpha1.App{
+				&datapackagingv1alpha1.Package{
 					TypeMeta: metav1.TypeMeta{
-						Kind:       appResource,
-						APIVersion: kappctrlAPIVersion,
+						Kind:       pkgResource,
+						APIVersion: datapackagingAPIVersion,
 					},
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: "default",
-						Name:      "my-installation",
-					},
-					Spec: kappctrlv1alpha1.AppSpec{
-						SyncPeriod: &metav1.Duration{Duration: (time.Second * 30)},
+						Name:      "tetris.foo.example.com.1.2.7",
 					},
-					Status: kappctrlv1alpha1.AppStatus{
-						Deploy: &kappctrlv1alpha1.AppStatusDeploy{
-							Stdout: "deployStdout",
-							Stderr: "deployStderr",
-						},
-						Fetch: &kappctrlv1alpha1.AppStatusFetch{
-							Stdout: "fetchStdout",
-							Stderr: "fetchStderr",
-						},
-						Inspect: &kappctrlv1alpha1.AppStatusInspect{
-							Stdout: "inspectStdout",
-							Stderr: "inspectStderr",
-						},
+					Spec: datapackagingv1alpha1.PackageSpec{
+						RefName:                         "tetris.foo.example.com",
+						Version:                         "1.2.7",
+						Licenses:                        []string{"my-license"},
+						ReleaseNotes:                    "release notes",
+						CapactiyRequirementsDescription: "capacity description",
 					},
 				},
-			},
-			existingTypedObjects: []k8sruntime.Object{
-				&k8scorev1.ConfigMap{
+				&packagingv1alpha1.PackageInstall{
 					TypeMeta: metav1.TypeMeta{
-						Kind:       "ConfigMap",
-						APIVersion: "v1",
+						Kind:       pkgInstallResource,
+						APIVersion: packagingAPIVersion,
 					},
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: "default",
-						Name:      "my-installation-ctrl",
-					},
-					Data: map[string]string{
-						"spec": "{\"labelKey\":\"kapp.k14s.io/app\",\"labelValue\":\"my-id\"}",
+						Name:      "my-installation",
 					},
-				},
-			},
-			expectedResponse: &corev1.CreateInstalledPackageResponse{
-				InstalledPackageRef: &corev1.InstalledPackageReference{
-					Context:    defaultContext,
-					Plugin:     &pluginDetail,
-					Identifier: "my-installation",
-				},
-			},
-			expectedPackageInstall: &packagingv1alpha1.PackageInstall{
-				TypeMeta: metav1.TypeMeta{
-					Kind:       pkgInstallResource,
-					APIVersion: packagingAPIVersion,
-				},
-				ObjectMeta: metav1.ObjectMeta{
-					Namespace: "default",
-					Name:      "my-installation",
-				},
-				Spec: packagingv1alpha1.PackageInstallSpec{
-					ServiceAccountName: "default",
-					PackageRef: &packagingv1alpha1.PackageRef{
-						RefName: "tetris.foo.example.com",
-						VersionSelection: &vendirversions.VersionSelectionSemver{
-							Constraints: "1.2.3",
+					Spec: packagingv1alpha1.PackageInstallSpec{
+						ServiceAccountName: "default",
+						PackageRef: &packagingv1alpha1.PackageRef{
+							RefName: "tetris.foo.example.com",
+							VersionSelection: &vendirversions.VersionSelectionSemver{
+								Constraints: "9.9.9",
+							},
 						},
-					},
-					Values: []packagingv1alpha1.PackageInstallValues{{
-						SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{
-							Name: "my-installation-default-values",
+						Values: []packagingv1alpha1.PackageInstallValues{{
+							SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{
+								Name: "my-installation-default-values",
+							},
 						},
+						},
+						Paused:     false,
+						Canceled:   false,
+						SyncPeriod: &metav1.Duration{Duration: (time.Second * 30)},
+						NoopDelete: false,
 					},
+					Status: packagingv1alpha1.PackageInstallStatus{
+						GenericStatus: kappctrlv1alpha1.GenericStatus{
+							ObservedGeneration: 1,
+							Conditions: []kappctrlv1alpha1.Condition{{
+								Type:    kappctrlv1alpha1.ReconcileSucceeded,
+								Status:  k8scorev1.ConditionTrue,
+								Reason:  "baz",
+								Message: "qux",
+							}},
+							FriendlyDescription: "foo",
+							UsefulErrorMessage:  "Deployed",
+						},
+						Version:              "1.2.3",
+						LastAttemptedVersion: "1.2.3",
 					},
-					Paused:     false,
-					Canceled:   false,
-					SyncPeriod: nil,
-					NoopDelete: false,
 				},
-				Status: packagingv1alpha1.PackageInstallStatus{
-					GenericStatus: kappctrlv1alpha1.GenericStatus{
-						ObservedGeneration:  0,
-						Conditions:          nil,
-						FriendlyDescription: "",
-						UsefulErrorMessage:  "",
+			},
+			expectedPackages: []*corev1.InstalledPackageSummary{
+				{
+					InstalledPackageRef: &corev1.InstalledPackageReference{
+						Context:    defaultContext,
+						Plugin:     &pluginDetail,
+						Identifier: "my-installation",
+					},
+					Name:           "my-installation",
+					PkgDisplayName: "Classic Tetris",
+					LatestVersion: &corev1.PackageAppVersion{
+						PkgVersion: "1.2.7",
+						AppVersion: "1.2.7",
+					},
+					IconUrl:             "data:image/svg+xml;base64,Tm90IHJlYWxseSBTVkcK",
+					ShortDescription:    "A great game for arcade gamers",
+					PkgVersionReference: &corev1.VersionReference{Version: "1.2.3"},
+					CurrentVersion: &corev1.PackageAppVersion{
+						PkgVersion: "1.2.3",
+						AppVersion: "1.2.3",
+					},
+					Status: &corev1.InstalledPackageStatus{
+						Ready:      true,
+						Reason:     corev1.InstalledPackageStatus_STATUS_REASON_INSTALLED,
+						UserReason: "Deployed",
 					},
-					Version:              "",
-					LastAttemptedVersion: "",
 				},
 			},
 		},
 		{
-			name: "create installed package with error (kapp App not being created)",
-			request: &corev1.CreateInstalledPackageRequest{
-				AvailablePackageRef: &corev1.AvailablePackageReference{
-					Context: &corev1.Context{
-						Namespace: "default",
-						Cluster:   "default",
-					},
-					Plugin:     &pluginDetail,
-					Identifier: "unknown/tetris.foo.example.com",
-				},
-				PkgVersionReference: &corev1.VersionReference{
-					Version: "1.2.3",
-				},
-				Name: "my-installation",
-				TargetContext: &corev1.Context{
-					Namespace: "default",
-					Cluster:   "default",
-				},
-				ReconciliationOptions: &corev1.ReconciliationOptions{
-					ServiceAccountName: "default",
+			name: "it filters the carvel installed packages by label selector and pending upgrade",
+			request: &corev1.GetInstalledPackageSummariesRequest{
+				Context: defaultContext,
+				FilterOptions: &corev1.InstalledPackageFilterOptions{
+					LabelSelector:  "team=frontend",
+					OnlyUpgradable: true,
 				},
 			},
-			pluginConfig: &kappControllerPluginParsedConfig{
-				timeoutSeconds:                     1, //to avoid unnecessary test delays
-				defaultUpgradePolicy:               defaultPluginConfig.defaultUpgradePolicy,
-				defaultPrereleasesVersionSelection: defaultPluginConfig.defaultPrereleasesVersionSelection,
-				defaultAllowDowngrades:             defaultPluginConfig.defaultAllowDowngrades,
-			},
 			existingObjects: []k8sruntime.Object{
 				&datapackagingv1alpha1.PackageMetadata{
 					TypeMeta: metav1.TypeMeta{
@@ -3741,14 +3964,7 @@ func TestCreateInstalledPackage(t *testing.T) {
 						Name:      "tetris.foo.example.com",
 					},
 					Spec: datapackagingv1alpha1.PackageMetadataSpec{
-						DisplayName:        "Classic Tetris",
-						IconSVGBase64:      "Tm90IHJlYWxseSBTVkcK",
-						ShortDescription:   "A great game for arcade gamers",
-						LongDescription:    "A few sentences but not really a readme",
-						Categories:         []string{"logging", "daemon-set"},
-						Maintainers:        []datapackagingv1alpha1.Maintainer{{Name: "person1"}, {Name: "person2"}},
-						SupportDescription: "Some support information",
-						ProviderName:       "Tetris inc.",
+						DisplayName: "Classic Tetris",
 					},
 				},
 				&datapackagingv1alpha1.Package{
@@ -3761,212 +3977,307 @@ func TestCreateInstalledPackage(t *testing.T) {
 						Name:      "tetris.foo.example.com.1.2.3",
 					},
 					Spec: datapackagingv1alpha1.PackageSpec{
-						RefName:                         "tetris.foo.example.com",
-						Version:                         "1.2.3",
-						Licenses:                        []string{"my-license"},
-						ReleaseNotes:                    "release notes",
-						CapactiyRequirementsDescription: "capacity description",
-						ReleasedAt:                      metav1.Time{Time: time.Date(1984, time.June, 6, 0, 0, 0, 0, time.UTC)},
+						RefName: "tetris.foo.example.com",
+						Version: "1.2.3",
 					},
 				},
-			},
-			existingTypedObjects: []k8sruntime.Object{
-				&k8scorev1.ConfigMap{
+				&datapackagingv1alpha1.Package{
 					TypeMeta: metav1.TypeMeta{
-						Kind:       "ConfigMap",
-						APIVersion: "v1",
+						Kind:       pkgResource,
+						APIVersion: datapackagingAPIVersion,
 					},
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: "default",
-						Name:      "my-installation-ctrl",
-					},
-					Data: map[string]string{
-						"spec": "{\"labelKey\":\"kapp.k14s.io/app\",\"labelValue\":\"my-id\"}",
+						Name:      "tetris.foo.example.com.1.2.7",
 					},
-				},
-			},
-			expectedErrorCode: connect.CodeInternal,
-		},
-		{
-			name: "create installed package (with values)",
-			request: &corev1.CreateInstalledPackageRequest{
-				AvailablePackageRef: &corev1.AvailablePackageReference{
-					Context: &corev1.Context{
-						Namespace: "default",
-						Cluster:   "default",
+					Spec: datapackagingv1alpha1.PackageSpec{
+						RefName: "tetris.foo.example.com",
+						Version: "1.2.7",
 					},
-					Plugin:     &pluginDetail,
-					Identifier: "unknown/tetris.foo.example.com",
-				},
-				PkgVersionReference: &corev1.VersionReference{
-					Version: "1.2.3",
-				},
-				Name:   "my-installation",
-				Values: "foo: bar",
-				TargetContext: &corev1.Context{
-					Namespace: "default",
-					Cluster:   "default",
-				},
-				ReconciliationOptions: &corev1.ReconciliationOptions{
-					ServiceAccountName: "default",
 				},
-			},
-			pluginConfig: defaultPluginConfig,
-			existingObjects: []k8sruntime.Object{
-				&datapackagingv1alpha1.PackageMetadata{
+				&packagingv1alpha1.PackageInstall{
 					TypeMeta: metav1.TypeMeta{
-						Kind:       pkgMetadataResource,
-						APIVersion: datapackagingAPIVersion,
+						Kind:       pkgInstallResource,
+						APIVersion: packagingAPIVersion,
 					},
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: "default",
-						Name:      "tetris.foo.example.com",
+						Name:      "my-installation",
+						Labels:    map[string]string{"team": "frontend"},
 					},
-					Spec: datapackagingv1alpha1.PackageMetadataSpec{
-						DisplayName:        "Classic Tetris",
-						IconSVGBase64:      "Tm90IHJlYWxseSBTVkcK",
-						ShortDescription:   "A great game for arcade gamers",
-						LongDescription:    "A few sentences but not really a readme",
-						Categories:         []string{"logging", "daemon-set"},
-						Maintainers:        []datapackagingv1alpha1.Maintainer{{Name: "person1"}, {Name: "person2"}},
-						SupportDescription: "Some support information",
-						ProviderName:       "Tetris inc.",
+					Spec: packagingv1alpha1.PackageInstallSpec{
+						ServiceAccountName: "default",
+						PackageRef: &packagingv1alpha1.PackageRef{
+							RefName: "tetris.foo.example.com",
+							VersionSelection: &vendirversions.VersionSelectionSemver{
+								Constraints: ">1.0.0 <2.0.0",
+							},
+						},
+					},
+					Status: packagingv1alpha1.PackageInstallStatus{
+						GenericStatus: kappctrlv1alpha1.GenericStatus{
+							ObservedGeneration: 1,
+							Conditions: []kappctrlv1alpha1.Condition{{
+								Type:   kappctrlv1alpha1.ReconcileSucceeded,
+								Status: k8scorev1.ConditionTrue,
+							}},
+							UsefulErrorMessage: "Deployed",
+						},
+						Version:              "1.2.3",
+						LastAttemptedVersion: "1.2.3",
 					},
 				},
-				&datapackagingv1alpha1.Package{
+				&packagingv1alpha1.PackageInstall{
 					TypeMeta: metav1.TypeMeta{
-						Kind:       pkgResource,
-						APIVersion: datapackagingAPIVersion,
+						Kind:       pkgInstallResource,
+						APIVersion: packagingAPIVersion,
 					},
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: "default",
-						Name:      "tetris.foo.example.com.1.2.3",
+						Name:      "up-to-date-installation",
+						Labels:    map[string]string{"team": "frontend"},
 					},
-					Spec: datapackagingv1alpha1.PackageSpec{
-						RefName:                         "tetris.foo.example.com",
-						Version:                         "1.2.3",
-						Licenses:                        []string{"my-license"},
-						ReleaseNotes:                    "release notes",
-						CapactiyRequirementsDescription: "capacity description",
-						ReleasedAt:                      metav1.Time{Time: time.Date(1984, time.June, 6, 0, 0, 0, 0, time.UTC)},
+					Spec: packagingv1alpha1.PackageInstallSpec{
+						ServiceAccountName: "default",
+						PackageRef: &packagingv1alpha1.PackageRef{
+							RefName: "tetris.foo.example.com",
+							VersionSelection: &vendirversions.VersionSelectionSemver{
+								Constraints: ">1.0.0 <2.0.0",
+							},
+						},
+					},
+					Status: packagingv1alpha1.PackageInstallStatus{
+						GenericStatus: kappctrlv1alpha1.GenericStatus{
+							ObservedGeneration: 1,
+							Conditions: []kappctrlv1alpha1.Condition{{
+								Type:   kappctrlv1alpha1.ReconcileSucceeded,
+								Status: k8scorev1.ConditionTrue,
+							}},
+							UsefulErrorMessage: "Deployed",
+						},
+						Version:              "1.2.7",
+						LastAttemptedVersion: "1.2.7",
 					},
 				},
-				&kappctrlv1alpha1.App{
+				&packagingv1alpha1.PackageInstall{
 					TypeMeta: metav1.TypeMeta{
-						Kind:       appResource,
-						APIVersion: kappctrlAPIVersion,
+						Kind:       pkgInstallResource,
+						APIVersion: packagingAPIVersion,
 					},
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: "default",
-						Name:      "my-installation",
-					},
-					Spec: kappctrlv1alpha1.AppSpec{
-						SyncPeriod: &metav1.Duration{Duration: (time.Second * 30)},
+						Name:      "other-team-installation",
+						Labels:    map[string]string{"team": "backend"},
 					},
-					Status: kappctrlv1alpha1.AppStatus{
-						Deploy: &kappctrlv1alpha1.AppStatusDeploy{
-							Stdout: "deployStdout",
-							Stderr: "deployStderr",
-						},
-						Fetch: &kappctrlv1alpha1.AppStatusFetch{
-							Stdout: "fetchStdout",
-							Stderr: "fetchStderr",
+					Spec: packagingv1alpha1.PackageInstallSpec{
+						ServiceAccountName: "default",
+						PackageRef: &packagingv1alpha1.PackageRef{
+							RefName: "tetris.foo.example.com",
+							VersionSelection: &vendirversions.VersionSelectionSemver{
+								Constraints: ">1.0.0 <2.0.0",
+							},
 						},
-						Inspect: &kappctrlv1alpha1.AppStatusInspect{
-							Stdout: "inspectStdout",
-							Stderr: "inspectStderr",
+					},
+					Status: packagingv1alpha1.PackageInstallStatus{
+						GenericStatus: kappctrlv1alpha1.GenericStatus{
+							ObservedGeneration: 1,
+							Conditions: []kappctrlv1alpha1.Condition{{
+								Type:   kappctrlv1alpha1.ReconcileSucceeded,
+								Status: k8scorev1.ConditionTrue,
+							}},
+							UsefulErrorMessage: "Deployed",
 						},
+						Version:              "1.2.3",
+						LastAttemptedVersion: "1.2.3",
 					},
 				},
 			},
-			existingTypedObjects: []k8sruntime.Object{
-				&k8scorev1.ConfigMap{
-					TypeMeta: metav1.TypeMeta{
-						Kind:       "ConfigMap",
-						APIVersion: "v1",
+			expectedPackages: []*corev1.InstalledPackageSummary{
+				{
+					InstalledPackageRef: &corev1.InstalledPackageReference{
+						Context:    defaultContext,
+						Plugin:     &pluginDetail,
+						Identifier: "my-installation",
 					},
-					ObjectMeta: metav1.ObjectMeta{
-						Namespace: "default",
-						Name:      "my-installation-ctrl",
+					Name:           "my-installation",
+					PkgDisplayName: "Classic Tetris",
+					LatestVersion: &corev1.PackageAppVersion{
+						PkgVersion: "1.2.7",
+						AppVersion: "1.2.7",
 					},
-					Data: map[string]string{
-						"spec": "{\"labelKey\":\"kapp.k14s.io/app\",\"labelValue\":\"my-id\"}",
+					PkgVersionReference: &corev1.VersionReference{Version: "1.2.3"},
+					CurrentVersion: &corev1.PackageAppVersion{
+						PkgVersion: "1.2.3",
+						AppVersion: "1.2.3",
+					},
+					LatestMatchingVersion: &corev1.PackageAppVersion{
+						PkgVersion: "1.2.7",
+						AppVersion: "1.2.7",
+					},
+					Status: &corev1.InstalledPackageStatus{
+						Ready:      true,
+						Reason:     corev1.InstalledPackageStatus_STATUS_REASON_INSTALLED,
+						UserReason: "Deployed",
 					},
 				},
 			},
-			expectedResponse: &corev1.CreateInstalledPackageResponse{
-				InstalledPackageRef: &corev1.InstalledPackageReference{
-					Context:    defaultContext,
-					Plugin:     &pluginDetail,
-					Identifier: "my-installation",
-				},
-			},
-			expectedPackageInstall: &packagingv1alpha1.PackageInstall{
-				TypeMeta: metav1.TypeMeta{
-					Kind:       pkgInstallResource,
-					APIVersion: packagingAPIVersion,
-				},
-				ObjectMeta: metav1.ObjectMeta{
-					Namespace: "default",
-					Name:      "my-installation",
+		},
+		{
+			name: "it returns an error for an invalid label selector",
+			request: &corev1.GetInstalledPackageSummariesRequest{
+				Context: defaultContext,
+				FilterOptions: &corev1.InstalledPackageFilterOptions{
+					LabelSelector: "team in (frontend",
 				},
-				Spec: packagingv1alpha1.PackageInstallSpec{
-					ServiceAccountName: "default",
-					PackageRef: &packagingv1alpha1.PackageRef{
-						RefName: "tetris.foo.example.com",
-						VersionSelection: &vendirversions.VersionSelectionSemver{
-							Constraints: "1.2.3",
-						},
-					},
-					Values: []packagingv1alpha1.PackageInstallValues{{
-						SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{
-							Name: "my-installation-default-values",
+			},
+			expectedErrorCode: connect.CodeInvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var unstructuredObjects []k8sruntime.Object
+			for _, obj := range tc.existingObjects {
+				unstructuredContent, _ := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(obj)
+				unstructuredObjects = append(unstructuredObjects, &unstructured.Unstructured{Object: unstructuredContent})
+			}
+
+			s := Server{
+				pluginConfig: defaultPluginConfig,
+				clientGetter: clientgetter.NewBuilder().
+					WithDynamic(dynfake.NewSimpleDynamicClientWithCustomListKinds(
+						k8sruntime.NewScheme(),
+						map[schema.GroupVersionResource]string{
+							{Group: datapackagingv1alpha1.SchemeGroupVersion.Group, Version: datapackagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgsResource}:         pkgResource + "List",
+							{Group: datapackagingv1alpha1.SchemeGroupVersion.Group, Version: datapackagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgMetadatasResource}: pkgMetadataResource + "List",
+							{Group: packagingv1alpha1.SchemeGroupVersion.Group, Version: packagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgRepositoriesResource}:      pkgRepositoryResource + "List",
+							{Group: packagingv1alpha1.SchemeGroupVersion.Group, Version: packagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgInstallsResource}:          pkgInstallResource + "List",
 						},
-					},
-					},
-					Paused:     false,
-					Canceled:   false,
-					SyncPeriod: nil,
-					NoopDelete: false,
-				},
-				Status: packagingv1alpha1.PackageInstallStatus{
-					GenericStatus: kappctrlv1alpha1.GenericStatus{
-						ObservedGeneration:  0,
-						Conditions:          nil,
-						FriendlyDescription: "",
-						UsefulErrorMessage:  "",
-					},
-					Version:              "",
-					LastAttemptedVersion: "",
+						unstructuredObjects...,
+					)).Build(),
+			}
+
+			response, err := s.GetInstalledPackageSummaries(context.Background(), connect.NewRequest(tc.request))
+
+			if got, want := connect.CodeOf(err), tc.expectedErrorCode; err != nil && got != want {
+				t.Fatalf("got: %d, want: %d, err: %+v", got, want, err)
+			}
+			// If we were expecting an error, continue to the next test.
+			if tc.expectedErrorCode != 0 {
+				return
+			}
+
+			if got, want := response.Msg.InstalledPackageSummaries, tc.expectedPackages; !cmp.Equal(got, want, ignoreUnexported) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexported))
+			}
+		})
+	}
+}
+
+func TestGetInstalledPackageSummariesNamespaces(t *testing.T) {
+	existingObjects := []k8sruntime.Object{
+		&datapackagingv1alpha1.PackageMetadata{
+			TypeMeta:   metav1.TypeMeta{Kind: pkgMetadataResource, APIVersion: datapackagingAPIVersion},
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns-accessible", Name: "tetris.foo.example.com"},
+			Spec:       datapackagingv1alpha1.PackageMetadataSpec{DisplayName: "Classic Tetris"},
+		},
+		&datapackagingv1alpha1.Package{
+			TypeMeta:   metav1.TypeMeta{Kind: pkgResource, APIVersion: datapackagingAPIVersion},
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns-accessible", Name: "tetris.foo.example.com.1.2.3"},
+			Spec:       datapackagingv1alpha1.PackageSpec{RefName: "tetris.foo.example.com", Version: "1.2.3"},
+		},
+		&packagingv1alpha1.PackageInstall{
+			TypeMeta:   metav1.TypeMeta{Kind: pkgInstallResource, APIVersion: packagingAPIVersion},
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns-accessible", Name: "my-installation"},
+			Spec: packagingv1alpha1.PackageInstallSpec{
+				PackageRef: &packagingv1alpha1.PackageRef{
+					RefName:          "tetris.foo.example.com",
+					VersionSelection: &vendirversions.VersionSelectionSemver{Constraints: "1.2.3"},
 				},
 			},
+			Status: packagingv1alpha1.PackageInstallStatus{Version: "1.2.3", LastAttemptedVersion: "1.2.3"},
+		},
+	}
+	var unstructuredObjects []k8sruntime.Object
+	for _, obj := range existingObjects {
+		unstructuredContent, _ := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		unstructuredObjects = append(unstructuredObjects, &unstructured.Unstructured{Object: unstructuredContent})
+	}
+	dynamicClient := dynfake.NewSimpleDynamicClientWithCustomListKinds(
+		k8sruntime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			{Group: datapackagingv1alpha1.SchemeGroupVersion.Group, Version: datapackagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgsResource}:         pkgResource + "List",
+			{Group: datapackagingv1alpha1.SchemeGroupVersion.Group, Version: datapackagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgMetadatasResource}: pkgMetadataResource + "List",
+			{Group: packagingv1alpha1.SchemeGroupVersion.Group, Version: packagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgInstallsResource}:          pkgInstallResource + "List",
+		},
+		unstructuredObjects...,
+	)
+	// Forbidden cluster-wide listing and a specific namespace
+	dynamicClient.PrependReactor("list", pkgInstallsResource, func(action k8stesting.Action) (handled bool, ret k8sruntime.Object, err error) {
+		if action.GetNamespace() == "" || action.GetNamespace() == "ns-inaccessible" {
+			return true, nil, k8sErrors.NewForbidden(authorizationv1.Resource("PackageInstall"), "", errors.New("bang"))
+		}
+		return false, nil, nil
+	})
+	typedClient := typfake.NewSimpleClientset(
+		&k8scorev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns-accessible"}, Status: k8scorev1.NamespaceStatus{Phase: k8scorev1.NamespaceActive}},
+		&k8scorev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns-inaccessible"}, Status: k8scorev1.NamespaceStatus{Phase: k8scorev1.NamespaceActive}},
+	)
+
+	s := Server{
+		pluginConfig: defaultPluginConfig,
+		clientGetter: clientgetter.NewBuilder().
+			WithTyped(typedClient).
+			WithDynamic(dynamicClient).
+			Build(),
+		globalPackagingCluster: defaultGlobalContext.Cluster,
+	}
+
+	response, err := s.GetInstalledPackageSummaries(context.Background(), connect.NewRequest(&corev1.GetInstalledPackageSummariesRequest{
+		Context: &corev1.Context{Cluster: "default"},
+	}))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	if got, want := len(response.Msg.GetInstalledPackageSummaries()), 1; got != want {
+		t.Fatalf("got: %d, want: %d", got, want)
+	}
+	if got, want := response.Msg.GetInstalledPackageSummaries()[0].GetInstalledPackageRef().GetContext().GetNamespace(), "ns-accessible"; got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+	expectedPartialResults := &corev1.PartialResults{
+		NamespaceFailures: []*corev1.NamespaceFailure{
+			{
+				Context: &corev1.Context{Cluster: defaultContext.Cluster, Namespace: "ns-inaccessible"},
+				Plugin:  &pluginDetail,
+				Code:    connect.CodePermissionDenied.String(),
+				Reason:  "Forbidden to get the PackageInstall 'all' due to 'PackageInstall.authorization.k8s.io is forbidden: bang'",
+			},
 		},
+	}
+	if got, want := response.Msg.GetPartialResults(), expectedPartialResults; !cmp.Equal(want, got, ignoreUnexported) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexported))
+	}
+}
+
+func TestGetInstalledPackageDetail(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		existingObjects      []k8sruntime.Object
+		existingTypedObjects []k8sruntime.Object
+		expectedPackage      *corev1.InstalledPackageDetail
+		errorCode            connect.Code
+		request              *corev1.GetInstalledPackageDetailRequest
+	}{
 		{
-			name: "create installed package (with reconciliationOptions)",
-			request: &corev1.CreateInstalledPackageRequest{
-				AvailablePackageRef: &corev1.AvailablePackageReference{
-					Context: &corev1.Context{
-						Namespace: "default",
-						Cluster:   "default",
-					},
-					Plugin:     &pluginDetail,
-					Identifier: "unknown/tetris.foo.example.com",
-				},
-				PkgVersionReference: &corev1.VersionReference{
-					Version: "1.2.3",
-				},
-				ReconciliationOptions: &corev1.ReconciliationOptions{
-					Interval:           "99s",
-					Suspend:            true,
-					ServiceAccountName: "my-sa",
-				},
-				Name: "my-installation",
-				TargetContext: &corev1.Context{
-					Namespace: "default",
-					Cluster:   "default",
+			name: "it returns carvel installed package detail with the latest matching version",
+			request: &corev1.GetInstalledPackageDetailRequest{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context:    defaultContext,
+					Identifier: "my-installation",
 				},
 			},
-			pluginConfig: defaultPluginConfig,
 			existingObjects: []k8sruntime.Object{
 				&datapackagingv1alpha1.PackageMetadata{
 					TypeMeta: metav1.TypeMeta{
@@ -4006,6 +4317,84 @@ func TestCreateInstalledPackage(t *testing.T) {
 						ReleasedAt:                      metav1.Time{Time: time.Date(1984, time.June, 6, 0, 0, 0, 0, time.UTC)},
 					},
 				},
+				&datapackagingv1alpha1.Package{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       pkgResource,
+						APIVersion: datapackagingAPIVersion,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "tetris.foo.example.com.1.2.7",
+					},
+					Spec: datapackagingv1alpha1.PackageSpec{
+						RefName:                         "tetris.foo.example.com",
+						Version:                         "1.2.7",
+						Licenses:                        []string{"my-license"},
+						ReleaseNotes:                    "release notes",
+						CapactiyRequirementsDescription: "capacity description",
+					},
+				},
+				&datapackagingv1alpha1.Package{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       pkgResource,
+						APIVersion: datapackagingAPIVersion,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "tetris.foo.example.com.2.0.0",
+					},
+					Spec: datapackagingv1alpha1.PackageSpec{
+						RefName:                         "tetris.foo.example.com",
+						Version:                         "2.0.0",
+						Licenses:                        []string{"my-license"},
+						ReleaseNotes:                    "release notes",
+						CapactiyRequirementsDescription: "capacity description",
+					},
+				},
+				&packagingv1alpha1.PackageInstall{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       pkgInstallResource,
+						APIVersion: packagingAPIVersion,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "my-installation",
+					},
+					Spec: packagingv1alpha1.PackageInstallSpec{
+						ServiceAccountName: "default",
+						PackageRef: &packagingv1alpha1.PackageRef{
+							RefName: "tetris.foo.example.com",
+							VersionSelection: &vendirversions.VersionSelectionSemver{
+								Constraints: ">1.0.0 <2.0.0",
+							},
+						},
+						Values: []packagingv1alpha1.PackageInstallValues{{
+							SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{
+								Name: "my-installation-default-values",
+							},
+						},
+						},
+						Paused:     false,
+						Canceled:   false,
+						SyncPeriod: &metav1.Duration{Duration: (time.Second * 30)},
+						NoopDelete: false,
+					},
+					Status: packagingv1alpha1.PackageInstallStatus{
+						GenericStatus: kappctrlv1alpha1.GenericStatus{
+							ObservedGeneration: 1,
+							Conditions: []kappctrlv1alpha1.Condition{{
+								Type:    kappctrlv1alpha1.ReconcileSucceeded,
+								Status:  k8scorev1.ConditionTrue,
+								Reason:  "baz",
+								Message: "qux",
+							}},
+							FriendlyDescription: "foo",
+							UsefulErrorMessage:  "Deployed",
+						},
+						Version:              "1.2.3",
+						LastAttemptedVersion: "1.2.3",
+					},
+				},
 				&kappctrlv1alpha1.App{
 					TypeMeta: metav1.TypeMeta{
 						Kind:       appResource,
@@ -4022,6 +4411,11 @@ func TestCreateInstalledPackage(t *testing.T) {
 						Deploy: &kappctrlv1alpha1.AppStatusDeploy{
 							Stdout: "deployStdout",
 							Stderr: "deployStderr",
+							KappDeployStatus: &kappctrlv1alpha1.KappDeployStatus{
+								AssociatedResources: kappctrlv1alpha1.AssociatedResources{
+									Label: "kapp.k14s.io/app=1678901234567890123",
+								},
+							},
 						},
 						Fetch: &kappctrlv1alpha1.AppStatusFetch{
 							Stdout: "fetchStdout",
@@ -4035,95 +4429,112 @@ func TestCreateInstalledPackage(t *testing.T) {
 				},
 			},
 			existingTypedObjects: []k8sruntime.Object{
-				&k8scorev1.ConfigMap{
-					TypeMeta: metav1.TypeMeta{
-						Kind:       "ConfigMap",
-						APIVersion: "v1",
-					},
+				&k8scorev1.Secret{
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: "default",
-						Name:      "my-installation-ctrl",
+						Name:      "my-installation-default-values",
 					},
-					Data: map[string]string{
-						"spec": "{\"labelKey\":\"kapp.k14s.io/app\",\"labelValue\":\"my-id\"}",
+					Type: "Opaque",
+					Data: map[string][]byte{
+						"values.yaml": []byte("foo: bar"),
 					},
 				},
 			},
-			expectedResponse: &corev1.CreateInstalledPackageResponse{
+			expectedPackage: &corev1.InstalledPackageDetail{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context:    defaultContext,
+					Plugin:     &pluginDetail,
+					Identifier: "unknown/tetris.foo.example.com",
+				},
 				InstalledPackageRef: &corev1.InstalledPackageReference{
 					Context:    defaultContext,
 					Plugin:     &pluginDetail,
 					Identifier: "my-installation",
 				},
-			},
-			expectedPackageInstall: &packagingv1alpha1.PackageInstall{
-				TypeMeta: metav1.TypeMeta{
-					Kind:       pkgInstallResource,
-					APIVersion: packagingAPIVersion,
+				Name: "my-installation",
+				PkgVersionReference: &corev1.VersionReference{
+					Version: "1.2.3",
 				},
-				ObjectMeta: metav1.ObjectMeta{
-					Namespace: "default",
-					Name:      "my-installation",
+				CurrentVersion: &corev1.PackageAppVersion{
+					PkgVersion: "1.2.3",
+					AppVersion: "1.2.3",
 				},
-				Spec: packagingv1alpha1.PackageInstallSpec{
-					ServiceAccountName: "my-sa",
-					PackageRef: &packagingv1alpha1.PackageRef{
-						RefName: "tetris.foo.example.com",
-						VersionSelection: &vendirversions.VersionSelectionSemver{
-							Constraints: "1.2.3",
-						},
-					},
-					Values: []packagingv1alpha1.PackageInstallValues{{
-						SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{
-							Name: "my-installation-default-values",
-						},
-					},
-					},
-					Paused:     true,
-					Canceled:   false,
-					SyncPeriod: &metav1.Duration{Duration: (time.Second * 99)},
-					NoopDelete: false,
+				ValuesApplied: "\n# values.yaml\nfoo: bar\n",
+				ReconciliationOptions: &corev1.ReconciliationOptions{
+					ServiceAccountName: "default",
+					Interval:           "30s",
+					Suspend:            false,
 				},
-				Status: packagingv1alpha1.PackageInstallStatus{
-					GenericStatus: kappctrlv1alpha1.GenericStatus{
-						ObservedGeneration:  0,
-						Conditions:          nil,
-						FriendlyDescription: "",
-						UsefulErrorMessage:  "",
-					},
-					Version:              "",
-					LastAttemptedVersion: "",
+				Status: &corev1.InstalledPackageStatus{
+					Ready:        true,
+					Reason:       corev1.InstalledPackageStatus_STATUS_REASON_INSTALLED,
+					UserReason:   "Deployed",
+					SyncInterval: "30s",
+				},
+				PostInstallationNotes: strings.ReplaceAll(`#### Deploy
+
+<x60><x60><x60>
+deployStdout
+<x60><x60><x60>
+
+#### Fetch
+
+<x60><x60><x60>
+fetchStdout
+<x60><x60><x60>
+
+### Errors
+
+#### Deploy
+
+<x60><x60><x60>
+deployStderr
+<x60><x60><x60>
+
+#### Fetch
+
+<x60><x60><x60>
+fetchStderr
+<x60><x60><x60>
+
+`, "<x60>", "`"),
+				LatestMatchingVersion: &corev1.PackageAppVersion{
+					PkgVersion: "1.2.7",
+					AppVersion: "1.2.7",
+				},
+				LatestVersion: &corev1.PackageAppVersion{
+					PkgVersion: "2.0.0",
+					AppVersion: "2.0.0",
 				},
+				CustomDetail: func() *anypb.Any {
+					customDetail, _ := anypb.New(&kappcorev1.KappControllerInstalledPackageCustomDetail{
+						AllowDowngrades: proto.Bool(false),
+						Values: []*kappcorev1.PackageInstallValues{{
+							Layer:      kappcorev1.PackageInstallValuesLayer_PACKAGE_INSTALL_VALUES_LAYER_USER_OVERRIDES,
+							Values:     "foo: bar",
+							SecretName: "my-installation-default-values",
+							Managed:    true,
+						}},
+						AppIdentity: &kappcorev1.InstalledPackageAppIdentity{
+							AppName:       "my-installation",
+							LabelKey:      "kapp.k14s.io/app",
+							LabelValue:    "1678901234567890123",
+							LabelSelector: "kapp.k14s.io/app=1678901234567890123",
+						},
+						ValuesSecretKeys: []string{"values.yaml"},
+					})
+					return customDetail
+				}(),
 			},
 		},
 		{
-			name: "create installed package (prereleases - defaultPrereleasesVersionSelection: nil)",
-			request: &corev1.CreateInstalledPackageRequest{
-				AvailablePackageRef: &corev1.AvailablePackageReference{
-					Context: &corev1.Context{
-						Namespace: "default",
-						Cluster:   "default",
-					},
-					Plugin:     &pluginDetail,
-					Identifier: "unknown/tetris.foo.example.com",
-				},
-				PkgVersionReference: &corev1.VersionReference{
-					Version: "1.0.0",
-				},
-				Name: "my-installation",
-				TargetContext: &corev1.Context{
-					Namespace: "default",
-					Cluster:   "default",
-				},
-				ReconciliationOptions: &corev1.ReconciliationOptions{
-					ServiceAccountName: "default",
+			name: "it returns carvel installed package detail with the latest matching version and repo-based identifiers",
+			request: &corev1.GetInstalledPackageDetailRequest{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context:    defaultContext,
+					Identifier: "my-installation",
 				},
 			},
-			pluginConfig: &kappControllerPluginParsedConfig{
-				defaultUpgradePolicy:               defaultPluginConfig.defaultUpgradePolicy,
-				defaultPrereleasesVersionSelection: nil,
-				defaultAllowDowngrades:             defaultPluginConfig.defaultAllowDowngrades,
-			},
 			existingObjects: []k8sruntime.Object{
 				&datapackagingv1alpha1.PackageMetadata{
 					TypeMeta: metav1.TypeMeta{
@@ -4133,6 +4544,9 @@ func TestCreateInstalledPackage(t *testing.T) {
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: "default",
 						Name:      "tetris.foo.example.com",
+						Annotations: map[string]string{
+							REPO_REF_ANNOTATION: "default/tce-repo",
+						},
 					},
 					Spec: datapackagingv1alpha1.PackageMetadataSpec{
 						DisplayName:        "Classic Tetris",
@@ -4163,6 +4577,84 @@ func TestCreateInstalledPackage(t *testing.T) {
 						ReleasedAt:                      metav1.Time{Time: time.Date(1984, time.June, 6, 0, 0, 0, 0, time.UTC)},
 					},
 				},
+				&datapackagingv1alpha1.Package{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       pkgResource,
+						APIVersion: datapackagingAPIVersion,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "tetris.foo.example.com.1.2.7",
+					},
+					Spec: datapackagingv1alpha1.PackageSpec{
+						RefName:                         "tetris.foo.example.com",
+						Version:                         "1.2.7",
+						Licenses:                        []string{"my-license"},
+						ReleaseNotes:                    "release notes",
+						CapactiyRequirementsDescription: "capacity description",
+					},
+				},
+				&datapackagingv1alpha1.Package{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       pkgResource,
+						APIVersion: datapackagingAPIVersion,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "tetris.foo.example.com.2.0.0",
+					},
+					Spec: datapackagingv1alpha1.PackageSpec{
+						RefName:                         "tetris.foo.example.com",
+						Version:                         "2.0.0",
+						Licenses:                        []string{"my-license"},
+						ReleaseNotes:                    "release notes",
+						CapactiyRequirementsDescription: "capacity description",
+					},
+				},
+				&packagingv1alpha1.PackageInstall{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       pkgInstallResource,
+						APIVersion: packagingAPIVersion,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "my-installation",
+					},
+					Spec: packagingv1alpha1.PackageInstallSpec{
+						ServiceAccountName: "default",
+						PackageRef: &packagingv1alpha1.PackageRef{
+							RefName: "tetris.foo.example.com",
+							VersionSelection: &vendirversions.VersionSelectionSemver{
+								Constraints: ">1.0.0 <2.0.0",
+							},
+						},
+						Values: []packagingv1alpha1.PackageInstallValues{{
+							SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{
+								Name: "my-installation-default-values",
+							},
+						},
+						},
+						Paused:     false,
+						Canceled:   false,
+						SyncPeriod: &metav1.Duration{Duration: (time.Second * 30)},
+						NoopDelete: false,
+					},
+					Status: packagingv1alpha1.PackageInstallStatus{
+						GenericStatus: kappctrlv1alpha1.GenericStatus{
+							ObservedGeneration: 1,
+							Conditions: []kappctrlv1alpha1.Condition{{
+								Type:    kappctrlv1alpha1.ReconcileSucceeded,
+								Status:  k8scorev1.ConditionTrue,
+								Reason:  "baz",
+								Message: "qux",
+							}},
+							FriendlyDescription: "foo",
+							UsefulErrorMessage:  "Deployed",
+						},
+						Version:              "1.2.3",
+						LastAttemptedVersion: "1.2.3",
+					},
+				},
 				&kappctrlv1alpha1.App{
 					TypeMeta: metav1.TypeMeta{
 						Kind:       appResource,
@@ -4192,95 +4684,108 @@ func TestCreateInstalledPackage(t *testing.T) {
 				},
 			},
 			existingTypedObjects: []k8sruntime.Object{
-				&k8scorev1.ConfigMap{
-					TypeMeta: metav1.TypeMeta{
-						Kind:       "ConfigMap",
-						APIVersion: "v1",
-					},
+				&k8scorev1.Secret{
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: "default",
-						Name:      "my-installation-ctrl",
+						Name:      "my-installation-default-values",
 					},
-					Data: map[string]string{
-						"spec": "{\"labelKey\":\"kapp.k14s.io/app\",\"labelValue\":\"my-id\"}",
+					Type: "Opaque",
+					Data: map[string][]byte{
+						"values.yaml": []byte("foo: bar"),
 					},
 				},
 			},
-			expectedResponse: &corev1.CreateInstalledPackageResponse{
+			expectedPackage: &corev1.InstalledPackageDetail{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context:    defaultContext,
+					Plugin:     &pluginDetail,
+					Identifier: "tce-repo/tetris.foo.example.com",
+				},
 				InstalledPackageRef: &corev1.InstalledPackageReference{
 					Context:    defaultContext,
 					Plugin:     &pluginDetail,
 					Identifier: "my-installation",
 				},
-			},
-			expectedPackageInstall: &packagingv1alpha1.PackageInstall{
-				TypeMeta: metav1.TypeMeta{
-					Kind:       pkgInstallResource,
-					APIVersion: packagingAPIVersion,
+				Name: "my-installation",
+				PkgVersionReference: &corev1.VersionReference{
+					Version: "1.2.3",
 				},
-				ObjectMeta: metav1.ObjectMeta{
-					Namespace: "default",
-					Name:      "my-installation",
+				CurrentVersion: &corev1.PackageAppVersion{
+					PkgVersion: "1.2.3",
+					AppVersion: "1.2.3",
 				},
-				Spec: packagingv1alpha1.PackageInstallSpec{
+				ValuesApplied: "\n# values.yaml\nfoo: bar\n",
+				ReconciliationOptions: &corev1.ReconciliationOptions{
 					ServiceAccountName: "default",
-					PackageRef: &packagingv1alpha1.PackageRef{
-						RefName: "tetris.foo.example.com",
-						VersionSelection: &vendirversions.VersionSelectionSemver{
-							Constraints: "1.0.0",
-							Prereleases: nil,
-						},
-					},
-					Values: []packagingv1alpha1.PackageInstallValues{{
-						SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{
-							Name: "my-installation-default-values",
-						},
-					},
-					},
-					Paused:     false,
-					Canceled:   false,
-					SyncPeriod: nil,
-					NoopDelete: false,
+					Interval:           "30s",
+					Suspend:            false,
 				},
-				Status: packagingv1alpha1.PackageInstallStatus{
-					GenericStatus: kappctrlv1alpha1.GenericStatus{
-						ObservedGeneration:  0,
-						Conditions:          nil,
-						FriendlyDescription: "",
-						UsefulErrorMessage:  "",
-					},
-					Version:              "",
-					LastAttemptedVersion: "",
+				Status: &corev1.InstalledPackageStatus{
+					Ready:        true,
+					Reason:       corev1.InstalledPackageStatus_STATUS_REASON_INSTALLED,
+					UserReason:   "Deployed",
+					SyncInterval: "30s",
 				},
-			},
-		},
-		{
-			name: "create installed package (non eligible version)",
-			request: &corev1.CreateInstalledPackageRequest{
-				AvailablePackageRef: &corev1.AvailablePackageReference{
-					Context: &corev1.Context{
-						Namespace: "default",
-						Cluster:   "default",
-					},
-					Plugin:     &pluginDetail,
-					Identifier: "unknown/tetris.foo.example.com",
-				},
-				PkgVersionReference: &corev1.VersionReference{
-					Version: "1.0.0-rc1",
-				},
-				Name: "my-installation",
-				TargetContext: &corev1.Context{
-					Namespace: "default",
-					Cluster:   "default",
+				PostInstallationNotes: strings.ReplaceAll(`#### Deploy
+
+<x60><x60><x60>
+deployStdout
+<x60><x60><x60>
+
+#### Fetch
+
+<x60><x60><x60>
+fetchStdout
+<x60><x60><x60>
+
+### Errors
+
+#### Deploy
+
+<x60><x60><x60>
+deployStderr
+<x60><x60><x60>
+
+#### Fetch
+
+<x60><x60><x60>
+fetchStderr
+<x60><x60><x60>
+
+`, "<x60>", "`"),
+				LatestMatchingVersion: &corev1.PackageAppVersion{
+					PkgVersion: "1.2.7",
+					AppVersion: "1.2.7",
 				},
-				ReconciliationOptions: &corev1.ReconciliationOptions{
-					ServiceAccountName: "default",
+				LatestVersion: &corev1.PackageAppVersion{
+					PkgVersion: "2.0.0",
+					AppVersion: "2.0.0",
 				},
+				CustomDetail: func() *anypb.Any {
+					customDetail, _ := anypb.New(&kappcorev1.KappControllerInstalledPackageCustomDetail{
+						AllowDowngrades: proto.Bool(false),
+						Values: []*kappcorev1.PackageInstallValues{{
+							Layer:      kappcorev1.PackageInstallValuesLayer_PACKAGE_INSTALL_VALUES_LAYER_USER_OVERRIDES,
+							Values:     "foo: bar",
+							SecretName: "my-installation-default-values",
+							Managed:    true,
+						}},
+						AppIdentity: &kappcorev1.InstalledPackageAppIdentity{
+							AppName: "my-installation",
+						},
+						ValuesSecretKeys: []string{"values.yaml"},
+					})
+					return customDetail
+				}(),
 			},
-			pluginConfig: &kappControllerPluginParsedConfig{
-				defaultUpgradePolicy:               defaultPluginConfig.defaultUpgradePolicy,
-				defaultPrereleasesVersionSelection: nil,
-				defaultAllowDowngrades:             defaultPluginConfig.defaultAllowDowngrades,
+		},
+		{
+			name: "it returns carvel installed package detail with no latest matching version if constraint is not satisfied",
+			request: &corev1.GetInstalledPackageDetailRequest{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context:    defaultContext,
+					Identifier: "my-installation",
+				},
 			},
 			existingObjects: []k8sruntime.Object{
 				&datapackagingv1alpha1.PackageMetadata{
@@ -4318,7 +4823,50 @@ func TestCreateInstalledPackage(t *testing.T) {
 						Licenses:                        []string{"my-license"},
 						ReleaseNotes:                    "release notes",
 						CapactiyRequirementsDescription: "capacity description",
-						ReleasedAt:                      metav1.Time{Time: time.Date(1984, time.June, 6, 0, 0, 0, 0, time.UTC)},
+					},
+				},
+				&packagingv1alpha1.PackageInstall{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       pkgInstallResource,
+						APIVersion: packagingAPIVersion,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "my-installation",
+					},
+					Spec: packagingv1alpha1.PackageInstallSpec{
+						ServiceAccountName: "default",
+						PackageRef: &packagingv1alpha1.PackageRef{
+							RefName: "tetris.foo.example.com",
+							VersionSelection: &vendirversions.VersionSelectionSemver{
+								Constraints: "9.9.9",
+							},
+						},
+						Values: []packagingv1alpha1.PackageInstallValues{{
+							SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{
+								Name: "my-installation-default-values",
+							},
+						},
+						},
+						Paused:     false,
+						Canceled:   false,
+						SyncPeriod: &metav1.Duration{Duration: (time.Second * 30)},
+						NoopDelete: false,
+					},
+					Status: packagingv1alpha1.PackageInstallStatus{
+						GenericStatus: kappctrlv1alpha1.GenericStatus{
+							ObservedGeneration: 1,
+							Conditions: []kappctrlv1alpha1.Condition{{
+								Type:    kappctrlv1alpha1.ReconcileSucceeded,
+								Status:  k8scorev1.ConditionTrue,
+								Reason:  "baz",
+								Message: "qux",
+							}},
+							FriendlyDescription: "foo",
+							UsefulErrorMessage:  "Deployed",
+						},
+						Version:              "1.2.3",
+						LastAttemptedVersion: "1.2.3",
 					},
 				},
 				&kappctrlv1alpha1.App{
@@ -4350,51 +4898,173 @@ func TestCreateInstalledPackage(t *testing.T) {
 				},
 			},
 			existingTypedObjects: []k8sruntime.Object{
-				&k8scorev1.ConfigMap{
-					TypeMeta: metav1.TypeMeta{
-						Kind:       "ConfigMap",
-						APIVersion: "v1",
-					},
+				&k8scorev1.Secret{
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: "default",
-						Name:      "my-installation-ctrl",
+						Name:      "my-installation-default-values",
 					},
-					Data: map[string]string{
-						"spec": "{\"labelKey\":\"kapp.k14s.io/app\",\"labelValue\":\"my-id\"}",
+					Type: "Opaque",
+					Data: map[string][]byte{
+						"values.yaml": []byte("foo: bar"),
 					},
 				},
 			},
-			expectedErrorCode: connect.CodeInvalidArgument,
-		},
-		{
-			name: "create installed package (prereleases - defaultPrereleasesVersionSelection: [])",
-			request: &corev1.CreateInstalledPackageRequest{
+			expectedPackage: &corev1.InstalledPackageDetail{
 				AvailablePackageRef: &corev1.AvailablePackageReference{
-					Context: &corev1.Context{
-						Namespace: "default",
-						Cluster:   "default",
-					},
+					Context:    defaultContext,
 					Plugin:     &pluginDetail,
 					Identifier: "unknown/tetris.foo.example.com",
 				},
-				PkgVersionReference: &corev1.VersionReference{
-					Version: "1.0.0",
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context:    defaultContext,
+					Plugin:     &pluginDetail,
+					Identifier: "my-installation",
 				},
 				Name: "my-installation",
-				TargetContext: &corev1.Context{
-					Namespace: "default",
-					Cluster:   "default",
+				PkgVersionReference: &corev1.VersionReference{
+					Version: "1.2.3",
+				},
+				CurrentVersion: &corev1.PackageAppVersion{
+					PkgVersion: "1.2.3",
+					AppVersion: "1.2.3",
 				},
+				ValuesApplied: "\n# values.yaml\nfoo: bar\n",
 				ReconciliationOptions: &corev1.ReconciliationOptions{
 					ServiceAccountName: "default",
+					Interval:           "30s",
+					Suspend:            false,
+				},
+				Status: &corev1.InstalledPackageStatus{
+					Ready:        true,
+					Reason:       corev1.InstalledPackageStatus_STATUS_REASON_INSTALLED,
+					UserReason:   "Deployed",
+					SyncInterval: "30s",
+				},
+				PostInstallationNotes: strings.ReplaceAll(`#### Deploy
+
+<x60><x60><x60>
+deployStdout
+<x60><x60><x60>
+
+#### Fetch
+
+<x60><x60><x60>
+fetchStdout
+<x60><x60><x60>
+
+### Errors
+
+#### Deploy
+
+<x60><x60><x60>
+deployStderr
+<x60><x60><x60>
+
+#### Fetch
+
+<x60><x60><x60>
+fetchStderr
+<x60><x60><x60>
+
+`, "<x60>", "`"),
+				LatestVersion: &corev1.PackageAppVersion{
+					PkgVersion: "1.2.3",
+					AppVersion: "1.2.3",
 				},
+				CustomDetail: func() *anypb.Any {
+					customDetail, _ := anypb.New(&kappcorev1.KappControllerInstalledPackageCustomDetail{
+						AllowDowngrades: proto.Bool(false),
+						Values: []*kappcorev1.PackageInstallValues{{
+							Layer:      kappcorev1.PackageInstallValuesLayer_PACKAGE_INSTALL_VALUES_LAYER_USER_OVERRIDES,
+							Values:     "foo: bar",
+							SecretName: "my-installation-default-values",
+							Managed:    true,
+						}},
+						AppIdentity: &kappcorev1.InstalledPackageAppIdentity{
+							AppName: "my-installation",
+						},
+						ValuesSecretKeys: []string{"values.yaml"},
+					})
+					return customDetail
+				}(),
 			},
-			pluginConfig: &kappControllerPluginParsedConfig{
-				defaultUpgradePolicy:               defaultPluginConfig.defaultUpgradePolicy,
-				defaultPrereleasesVersionSelection: []string{},
-				defaultAllowDowngrades:             defaultPluginConfig.defaultAllowDowngrades,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var unstructuredObjects []k8sruntime.Object
+			for _, obj := range tc.existingObjects {
+				unstructuredContent, _ := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(obj)
+				unstructuredObjects = append(unstructuredObjects, &unstructured.Unstructured{Object: unstructuredContent})
+			}
+
+			s := Server{
+				pluginConfig: defaultPluginConfig,
+				clientGetter: clientgetter.NewBuilder().
+					WithTyped(typfake.NewSimpleClientset(tc.existingTypedObjects...)).
+					WithDynamic(dynfake.NewSimpleDynamicClientWithCustomListKinds(
+						k8sruntime.NewScheme(),
+						map[schema.GroupVersionResource]string{
+							{Group: datapackagingv1alpha1.SchemeGroupVersion.Group, Version: datapackagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgsResource}:         pkgResource + "List",
+							{Group: datapackagingv1alpha1.SchemeGroupVersion.Group, Version: datapackagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgMetadatasResource}: pkgMetadataResource + "List",
+							{Group: packagingv1alpha1.SchemeGroupVersion.Group, Version: packagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgRepositoriesResource}:      pkgRepositoryResource + "List",
+						},
+						unstructuredObjects...,
+					)).Build(),
+			}
+			installedPackageDetail, err := s.GetInstalledPackageDetail(context.Background(), connect.NewRequest(tc.request))
+
+			if got, want := connect.CodeOf(err), tc.errorCode; err != nil && got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+
+			if tc.errorCode == 0 {
+				if got, want := installedPackageDetail.Msg.InstalledPackageDetail, tc.expectedPackage; !cmp.Equal(got, want, ignoreUnexported) {
+					t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexported))
+				}
+			}
+		})
+	}
+}
+
+func TestGetInstalledPackageDetailWithoutApp(t *testing.T) {
+	ownerReferences := []metav1.OwnerReference{{
+		APIVersion: packagingAPIVersion,
+		Kind:       pkgInstallResource,
+		Name:       "my-installation",
+		UID:        "my-installation-uid",
+	}}
+
+	testCases := []struct {
+		name                 string
+		createdAgo           time.Duration
+		expectedStatus       *corev1.InstalledPackageStatus
+		expectedDeleted      bool
+		expectedKeptResource string
+	}{
+		{
+			name:       "it returns a pending status while kapp-controller has not created the App",
+			createdAgo: time.Second,
+			expectedStatus: &corev1.InstalledPackageStatus{
+				Reason:     corev1.InstalledPackageStatus_STATUS_REASON_PENDING,
+				UserReason: "Waiting for the App to be created",
 			},
-			existingObjects: []k8sruntime.Object{
+		},
+		{
+			name:       "it cleans up the package install once the App was not created within the timeout",
+			createdAgo: time.Hour,
+			expectedStatus: &corev1.InstalledPackageStatus{
+				Reason:     corev1.InstalledPackageStatus_STATUS_REASON_FAILED,
+				UserReason: fmt.Sprintf("Timeout exceeded (%v s) waiting for the App to be created", float64(defaultPluginConfig.timeoutSeconds)),
+			},
+			expectedDeleted: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			existingObjects := []k8sruntime.Object{
 				&datapackagingv1alpha1.PackageMetadata{
 					TypeMeta: metav1.TypeMeta{
 						Kind:       pkgMetadataResource,
@@ -4404,16 +5074,6 @@ func TestCreateInstalledPackage(t *testing.T) {
 						Namespace: "default",
 						Name:      "tetris.foo.example.com",
 					},
-					Spec: datapackagingv1alpha1.PackageMetadataSpec{
-						DisplayName:        "Classic Tetris",
-						IconSVGBase64:      "Tm90IHJlYWxseSBTVkcK",
-						ShortDescription:   "A great game for arcade gamers",
-						LongDescription:    "A few sentences but not really a readme",
-						Categories:         []string{"logging", "daemon-set"},
-						Maintainers:        []datapackagingv1alpha1.Maintainer{{Name: "person1"}, {Name: "person2"}},
-						SupportDescription: "Some support information",
-						ProviderName:       "Tetris inc.",
-					},
 				},
 				&datapackagingv1alpha1.Package{
 					TypeMeta: metav1.TypeMeta{
@@ -4425,107 +5085,138 @@ func TestCreateInstalledPackage(t *testing.T) {
 						Name:      "tetris.foo.example.com.1.2.3",
 					},
 					Spec: datapackagingv1alpha1.PackageSpec{
-						RefName:                         "tetris.foo.example.com",
-						Version:                         "1.2.3",
-						Licenses:                        []string{"my-license"},
-						ReleaseNotes:                    "release notes",
-						CapactiyRequirementsDescription: "capacity description",
-						ReleasedAt:                      metav1.Time{Time: time.Date(1984, time.June, 6, 0, 0, 0, 0, time.UTC)},
+						RefName: "tetris.foo.example.com",
+						Version: "1.2.3",
 					},
 				},
-				&kappctrlv1alpha1.App{
+				&packagingv1alpha1.PackageInstall{
 					TypeMeta: metav1.TypeMeta{
-						Kind:       appResource,
-						APIVersion: kappctrlAPIVersion,
+						Kind:       pkgInstallResource,
+						APIVersion: packagingAPIVersion,
 					},
 					ObjectMeta: metav1.ObjectMeta{
-						Namespace: "default",
-						Name:      "my-installation",
-					},
-					Spec: kappctrlv1alpha1.AppSpec{
-						SyncPeriod: &metav1.Duration{Duration: (time.Second * 30)},
+						Namespace:         "default",
+						Name:              "my-installation",
+						UID:               "my-installation-uid",
+						CreationTimestamp: metav1.NewTime(time.Now().Add(-tc.createdAgo)),
 					},
-					Status: kappctrlv1alpha1.AppStatus{
-						Deploy: &kappctrlv1alpha1.AppStatusDeploy{
-							Stdout: "deployStdout",
-							Stderr: "deployStderr",
-						},
-						Fetch: &kappctrlv1alpha1.AppStatusFetch{
-							Stdout: "fetchStdout",
-							Stderr: "fetchStderr",
-						},
-						Inspect: &kappctrlv1alpha1.AppStatusInspect{
-							Stdout: "inspectStdout",
-							Stderr: "inspectStderr",
+					Spec: packagingv1alpha1.PackageInstallSpec{
+						ServiceAccountName: "my-installation-default-sa",
+						PackageRef: &packagingv1alpha1.PackageRef{
+							RefName: "tetris.foo.example.com",
+							VersionSelection: &vendirversions.VersionSelectionSemver{
+								Constraints: "1.2.3",
+							},
 						},
+						Values: []packagingv1alpha1.PackageInstallValues{{
+							SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{
+								Name: "my-installation-default-values",
+							},
+						}},
 					},
 				},
-			},
-			existingTypedObjects: []k8sruntime.Object{
-				&k8scorev1.ConfigMap{
-					TypeMeta: metav1.TypeMeta{
-						Kind:       "ConfigMap",
-						APIVersion: "v1",
-					},
-					ObjectMeta: metav1.ObjectMeta{
-						Namespace: "default",
-						Name:      "my-installation-ctrl",
-					},
-					Data: map[string]string{
-						"spec": "{\"labelKey\":\"kapp.k14s.io/app\",\"labelValue\":\"my-id\"}",
-					},
+			}
+			var unstructuredObjects []k8sruntime.Object
+			for _, obj := range existingObjects {
+				unstructuredContent, _ := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(obj)
+				unstructuredObjects = append(unstructuredObjects, &unstructured.Unstructured{Object: unstructuredContent})
+			}
+			typedClient := typfake.NewSimpleClientset(
+				&k8scorev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "my-installation-default-values"},
+					Data:       map[string][]byte{"values.yaml": []byte("foo: bar")},
 				},
-			},
-			expectedResponse: &corev1.CreateInstalledPackageResponse{
-				InstalledPackageRef: &corev1.InstalledPackageReference{
-					Context:    defaultContext,
-					Plugin:     &pluginDetail,
-					Identifier: "my-installation",
+				&k8scorev1.ServiceAccount{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "my-installation-default-sa", OwnerReferences: ownerReferences},
 				},
-			},
-			expectedPackageInstall: &packagingv1alpha1.PackageInstall{
-				TypeMeta: metav1.TypeMeta{
-					Kind:       pkgInstallResource,
-					APIVersion: packagingAPIVersion,
+				&rbacv1.Role{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "my-installation-default-role", OwnerReferences: ownerReferences},
 				},
-				ObjectMeta: metav1.ObjectMeta{
-					Namespace: "default",
-					Name:      "my-installation",
+				&rbacv1.RoleBinding{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "my-installation-default-rolebinding", OwnerReferences: ownerReferences},
 				},
-				Spec: packagingv1alpha1.PackageInstallSpec{
-					ServiceAccountName: "default",
-					PackageRef: &packagingv1alpha1.PackageRef{
-						RefName: "tetris.foo.example.com",
-						VersionSelection: &vendirversions.VersionSelectionSemver{
-							Constraints: "1.0.0",
-							Prereleases: &vendirversions.VersionSelectionSemverPrereleases{},
-						},
-					},
-					Values: []packagingv1alpha1.PackageInstallValues{{
-						SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{
-							Name: "my-installation-default-values",
-						},
-					},
-					},
-					Paused:     false,
-					Canceled:   false,
-					SyncPeriod: nil,
-					NoopDelete: false,
+				// an image pull Secret not created for the package install
+				&k8scorev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "my-installation-default-registry-creds"},
 				},
-				Status: packagingv1alpha1.PackageInstallStatus{
-					GenericStatus: kappctrlv1alpha1.GenericStatus{
-						ObservedGeneration:  0,
-						Conditions:          nil,
-						FriendlyDescription: "",
-						UsefulErrorMessage:  "",
-					},
-					Version:              "",
-					LastAttemptedVersion: "",
+			)
+			s := Server{
+				pluginConfig: defaultPluginConfig,
+				clientGetter: clientgetter.NewBuilder().
+					WithTyped(typedClient).
+					WithDynamic(dynfake.NewSimpleDynamicClientWithCustomListKinds(
+						k8sruntime.NewScheme(),
+						map[schema.GroupVersionResource]string{
+							{Group: datapackagingv1alpha1.SchemeGroupVersion.Group, Version: datapackagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgsResource}:         pkgResource + "List",
+							{Group: datapackagingv1alpha1.SchemeGroupVersion.Group, Version: datapackagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgMetadatasResource}: pkgMetadataResource + "List",
+						},
+						unstructuredObjects...,
+					)).Build(),
+			}
+
+			response, err := s.GetInstalledPackageDetail(context.Background(), connect.NewRequest(&corev1.GetInstalledPackageDetailRequest{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context:    defaultContext,
+					Identifier: "my-installation",
 				},
-			},
-		},
+			}))
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if got, want := response.Msg.InstalledPackageDetail.Status, tc.expectedStatus; !cmp.Equal(want, got, ignoreUnexported) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexported))
+			}
+
+			_, err = s.getPkgInstall(context.Background(), http.Header{}, "default", "default", "my-installation")
+			if got, want := k8sErrors.IsNotFound(err), tc.expectedDeleted; got != want {
+				t.Errorf("PackageInstall deleted: got: %t, want: %t, err: %+v", got, want, err)
+			}
+			ctx := context.Background()
+			for kind, get := range map[string]func() error{
+				"values Secret": func() error {
+					_, err := typedClient.CoreV1().Secrets("default").Get(ctx, "my-installation-default-values", metav1.GetOptions{})
+					return err
+				},
+				"ServiceAccount": func() error {
+					_, err := typedClient.CoreV1().ServiceAccounts("default").Get(ctx, "my-installation-default-sa", metav1.GetOptions{})
+					return err
+				},
+				"Role": func() error {
+					_, err := typedClient.RbacV1().Roles("default").Get(ctx, "my-installation-default-role", metav1.GetOptions{})
+					return err
+				},
+				"RoleBinding": func() error {
+					_, err := typedClient.RbacV1().RoleBindings("default").Get(ctx, "my-installation-default-rolebinding", metav1.GetOptions{})
+					return err
+				},
+			} {
+				if got, want := k8sErrors.IsNotFound(get()), tc.expectedDeleted; got != want {
+					t.Errorf("%s deleted: got: %t, want: %t", kind, got, want)
+				}
+			}
+			if _, err := typedClient.CoreV1().Secrets("default").Get(ctx, "my-installation-default-registry-creds", metav1.GetOptions{}); err != nil {
+				t.Errorf("the image pull Secret not owned by the PackageInstall was deleted: %+v", err)
+			}
+		})
+	}
+}
+
+func TestCreateInstalledPackage(t *testing.T) {
+	testCases := []struct {
+		name                   string
+		request                *corev1.CreateInstalledPackageRequest
+		pluginConfig           *kappControllerPluginParsedConfig
+		existingObjects        []k8sruntime.Object
+		existingTypedObjects   []k8sruntime.Object
+		expectedErrorCode      connect.Code
+		expectedResponse       *corev1.CreateInstalledPackageResponse
+		expectedPackageInstall *packagingv1alpha1.PackageInstall
+		expectedRoleBinding    *rbacv1.RoleBinding
+		// expectedImagePullSecret is the placeholder image pull Secret expected to be created, if any
+		expectedImagePullSecret *k8scorev1.Secret
+	}{
 		{
-			name: "create installed package (prereleases - defaultPrereleasesVersionSelection: ['rc'])",
+			name: "create installed package",
 			request: &corev1.CreateInstalledPackageRequest{
 				AvailablePackageRef: &corev1.AvailablePackageReference{
 					Context: &corev1.Context{
@@ -4536,7 +5227,7 @@ func TestCreateInstalledPackage(t *testing.T) {
 					Identifier: "unknown/tetris.foo.example.com",
 				},
 				PkgVersionReference: &corev1.VersionReference{
-					Version: "1.0.0",
+					Version: "1.2.3",
 				},
 				Name: "my-installation",
 				TargetContext: &corev1.Context{
@@ -4547,11 +5238,7 @@ func TestCreateInstalledPackage(t *testing.T) {
 					ServiceAccountName: "default",
 				},
 			},
-			pluginConfig: &kappControllerPluginParsedConfig{
-				defaultUpgradePolicy:               defaultPluginConfig.defaultUpgradePolicy,
-				defaultPrereleasesVersionSelection: []string{"rc"},
-				defaultAllowDowngrades:             defaultPluginConfig.defaultAllowDowngrades,
-			},
+			pluginConfig: defaultPluginConfig,
 			existingObjects: []k8sruntime.Object{
 				&datapackagingv1alpha1.PackageMetadata{
 					TypeMeta: metav1.TypeMeta{
@@ -4655,8 +5342,7 @@ func TestCreateInstalledPackage(t *testing.T) {
 					PackageRef: &packagingv1alpha1.PackageRef{
 						RefName: "tetris.foo.example.com",
 						VersionSelection: &vendirversions.VersionSelectionSemver{
-							Constraints: "1.0.0",
-							Prereleases: &vendirversions.VersionSelectionSemverPrereleases{Identifiers: []string{"rc"}},
+							Constraints: "1.2.3",
 						},
 					},
 					Values: []packagingv1alpha1.PackageInstallValues{{
@@ -4683,7 +5369,7 @@ func TestCreateInstalledPackage(t *testing.T) {
 			},
 		},
 		{
-			name: "create installed package (version constraint - upgradePolicy: none)",
+			name: "create installed package (with service account creation)",
 			request: &corev1.CreateInstalledPackageRequest{
 				AvailablePackageRef: &corev1.AvailablePackageReference{
 					Context: &corev1.Context{
@@ -4694,18 +5380,25 @@ func TestCreateInstalledPackage(t *testing.T) {
 					Identifier: "unknown/tetris.foo.example.com",
 				},
 				PkgVersionReference: &corev1.VersionReference{
-					Version: "1.0.0",
+					Version: "1.2.3",
 				},
 				Name: "my-installation",
 				TargetContext: &corev1.Context{
 					Namespace: "default",
 					Cluster:   "default",
 				},
-				ReconciliationOptions: &corev1.ReconciliationOptions{
-					ServiceAccountName: "default",
-				},
+				CustomDetail: func() *anypb.Any {
+					customDetail, _ := anypb.New(&kappcorev1.KappControllerInstalledPackageCustomDetail{
+						CreateServiceAccount: true,
+					})
+					return customDetail
+				}(),
+			},
+			pluginConfig: &kappControllerPluginParsedConfig{
+				defaultUpgradePolicy:        defaultPluginConfig.defaultUpgradePolicy,
+				allowServiceAccountCreation: true,
+				serviceAccountRoleTemplate:  serviceAccountRoleTemplateRestricted,
 			},
-			pluginConfig: defaultPluginConfig,
 			existingObjects: []k8sruntime.Object{
 				&datapackagingv1alpha1.PackageMetadata{
 					TypeMeta: metav1.TypeMeta{
@@ -4805,11 +5498,11 @@ func TestCreateInstalledPackage(t *testing.T) {
 					Name:      "my-installation",
 				},
 				Spec: packagingv1alpha1.PackageInstallSpec{
-					ServiceAccountName: "default",
+					ServiceAccountName: "my-installation-default-sa",
 					PackageRef: &packagingv1alpha1.PackageRef{
 						RefName: "tetris.foo.example.com",
 						VersionSelection: &vendirversions.VersionSelectionSemver{
-							Constraints: "1.0.0",
+							Constraints: "1.2.3",
 						},
 					},
 					Values: []packagingv1alpha1.PackageInstallValues{{
@@ -4834,9 +5527,37 @@ func TestCreateInstalledPackage(t *testing.T) {
 					LastAttemptedVersion: "",
 				},
 			},
+			expectedRoleBinding: &rbacv1.RoleBinding{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "RoleBinding",
+					APIVersion: "rbac.authorization.k8s.io/v1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "default",
+					Name:        "my-installation-default-rolebinding",
+					Annotations: map[string]string{annotationManagedByKey: annotationManagedByValue},
+					OwnerReferences: []metav1.OwnerReference{{
+						APIVersion:         packagingAPIVersion,
+						Kind:               pkgInstallResource,
+						Name:               "my-installation",
+						Controller:         func() *bool { v := true; return &v }(),
+						BlockOwnerDeletion: func() *bool { v := true; return &v }(),
+					}},
+				},
+				Subjects: []rbacv1.Subject{{
+					Kind:      rbacv1.ServiceAccountKind,
+					Name:      "my-installation-default-sa",
+					Namespace: "default",
+				}},
+				RoleRef: rbacv1.RoleRef{
+					APIGroup: rbacv1.GroupName,
+					Kind:     "Role",
+					Name:     "my-installation-default-role",
+				},
+			},
 		},
 		{
-			name: "create installed package (version constraint - upgradePolicy: major)",
+			name: "create installed package (service account creation not allowed)",
 			request: &corev1.CreateInstalledPackageRequest{
 				AvailablePackageRef: &corev1.AvailablePackageReference{
 					Context: &corev1.Context{
@@ -4847,7 +5568,36 @@ func TestCreateInstalledPackage(t *testing.T) {
 					Identifier: "unknown/tetris.foo.example.com",
 				},
 				PkgVersionReference: &corev1.VersionReference{
-					Version: "1.0.0",
+					Version: "1.2.3",
+				},
+				Name: "my-installation",
+				TargetContext: &corev1.Context{
+					Namespace: "default",
+					Cluster:   "default",
+				},
+				CustomDetail: func() *anypb.Any {
+					customDetail, _ := anypb.New(&kappcorev1.KappControllerInstalledPackageCustomDetail{
+						CreateServiceAccount: true,
+					})
+					return customDetail
+				}(),
+			},
+			pluginConfig:      defaultPluginConfig,
+			expectedErrorCode: connect.CodeFailedPrecondition,
+		},
+		{
+			name: "create installed package (package not allowed by the repository)",
+			request: &corev1.CreateInstalledPackageRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context: &corev1.Context{
+						Namespace: "default",
+						Cluster:   "default",
+					},
+					Plugin:     &pluginDetail,
+					Identifier: "tce-repo/tetris.foo.example.com",
+				},
+				PkgVersionReference: &corev1.VersionReference{
+					Version: "1.2.3",
 				},
 				Name: "my-installation",
 				TargetContext: &corev1.Context{
@@ -4858,30 +5608,31 @@ func TestCreateInstalledPackage(t *testing.T) {
 					ServiceAccountName: "default",
 				},
 			},
-			pluginConfig: &kappControllerPluginParsedConfig{
-				defaultUpgradePolicy:               pkgutils.UpgradePolicyMajor,
-				defaultPrereleasesVersionSelection: defaultPluginConfig.defaultPrereleasesVersionSelection,
-				defaultAllowDowngrades:             defaultPluginConfig.defaultAllowDowngrades,
-			},
+			pluginConfig: defaultPluginConfig,
 			existingObjects: []k8sruntime.Object{
+				&packagingv1alpha1.PackageRepository{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       pkgRepositoryResource,
+						APIVersion: packagingAPIVersion,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:   "default",
+						Name:        "tce-repo",
+						Annotations: map[string]string{pkgutils.AnnotationDeniedPackagesKey: "tetris.*"},
+					},
+				},
 				&datapackagingv1alpha1.PackageMetadata{
 					TypeMeta: metav1.TypeMeta{
 						Kind:       pkgMetadataResource,
 						APIVersion: datapackagingAPIVersion,
 					},
 					ObjectMeta: metav1.ObjectMeta{
-						Namespace: "default",
-						Name:      "tetris.foo.example.com",
+						Namespace:   "default",
+						Name:        "tetris.foo.example.com",
+						Annotations: map[string]string{REPO_REF_ANNOTATION: "default/tce-repo"},
 					},
 					Spec: datapackagingv1alpha1.PackageMetadataSpec{
-						DisplayName:        "Classic Tetris",
-						IconSVGBase64:      "Tm90IHJlYWxseSBTVkcK",
-						ShortDescription:   "A great game for arcade gamers",
-						LongDescription:    "A few sentences but not really a readme",
-						Categories:         []string{"logging", "daemon-set"},
-						Maintainers:        []datapackagingv1alpha1.Maintainer{{Name: "person1"}, {Name: "person2"}},
-						SupportDescription: "Some support information",
-						ProviderName:       "Tetris inc.",
+						DisplayName: "Classic Tetris",
 					},
 				},
 				&datapackagingv1alpha1.Package{
@@ -4894,55 +5645,105 @@ func TestCreateInstalledPackage(t *testing.T) {
 						Name:      "tetris.foo.example.com.1.2.3",
 					},
 					Spec: datapackagingv1alpha1.PackageSpec{
-						RefName:                         "tetris.foo.example.com",
-						Version:                         "1.2.3",
-						Licenses:                        []string{"my-license"},
-						ReleaseNotes:                    "release notes",
-						CapactiyRequirementsDescription: "capacity description",
-						ReleasedAt:                      metav1.Time{Time: time.Date(1984, time.June, 6, 0, 0, 0, 0, time.UTC)},
+						RefName: "tetris.foo.example.com",
+						Version: "1.2.3",
 					},
 				},
-				&kappctrlv1alpha1.App{
-					TypeMeta: metav1.TypeMeta{
-						Kind:       appResource,
-						APIVersion: kappctrlAPIVersion,
-					},
-					ObjectMeta: metav1.ObjectMeta{
+			},
+			expectedErrorCode: connect.CodeFailedPrecondition,
+		},
+		{
+			name: "create installed package (adopting an existing package install)",
+			request: &corev1.CreateInstalledPackageRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context: &corev1.Context{
 						Namespace: "default",
-						Name:      "my-installation",
-					},
-					Spec: kappctrlv1alpha1.AppSpec{
-						SyncPeriod: &metav1.Duration{Duration: (time.Second * 30)},
-					},
-					Status: kappctrlv1alpha1.AppStatus{
-						Deploy: &kappctrlv1alpha1.AppStatusDeploy{
-							Stdout: "deployStdout",
-							Stderr: "deployStderr",
-						},
-						Fetch: &kappctrlv1alpha1.AppStatusFetch{
-							Stdout: "fetchStdout",
-							Stderr: "fetchStderr",
-						},
-						Inspect: &kappctrlv1alpha1.AppStatusInspect{
-							Stdout: "inspectStdout",
-							Stderr: "inspectStderr",
-						},
+						Cluster:   "default",
 					},
+					Plugin:     &pluginDetail,
+					Identifier: "unknown/tetris.foo.example.com",
+				},
+				PkgVersionReference: &corev1.VersionReference{
+					Version: "1.2.3",
+				},
+				Name: "my-installation",
+				TargetContext: &corev1.Context{
+					Namespace: "default",
+					Cluster:   "default",
+				},
+				ReconciliationOptions: &corev1.ReconciliationOptions{
+					ServiceAccountName: "default",
 				},
+				Values: "foo: bar",
+				CustomDetail: func() *anypb.Any {
+					customDetail, _ := anypb.New(&kappcorev1.KappControllerInstalledPackageCustomDetail{
+						AdoptExisting: true,
+					})
+					return customDetail
+				}(),
 			},
-			existingTypedObjects: []k8sruntime.Object{
-				&k8scorev1.ConfigMap{
+			pluginConfig: defaultPluginConfig,
+			existingObjects: []k8sruntime.Object{
+				&datapackagingv1alpha1.PackageMetadata{
 					TypeMeta: metav1.TypeMeta{
-						Kind:       "ConfigMap",
-						APIVersion: "v1",
+						Kind:       pkgMetadataResource,
+						APIVersion: datapackagingAPIVersion,
 					},
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: "default",
-						Name:      "my-installation-ctrl",
+						Name:      "tetris.foo.example.com",
 					},
-					Data: map[string]string{
-						"spec": "{\"labelKey\":\"kapp.k14s.io/app\",\"labelValue\":\"my-id\"}",
+					Spec: datapackagingv1alpha1.PackageMetadataSpec{
+						DisplayName: "Classic Tetris",
+					},
+				},
+				&datapackagingv1alpha1.Package{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       pkgResource,
+						APIVersion: datapackagingAPIVersion,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "tetris.foo.example.com.1.2.3",
+					},
+					Spec: datapackagingv1alpha1.PackageSpec{
+						RefName: "tetris.foo.example.com",
+						Version: "1.2.3",
+					},
+				},
+				&packagingv1alpha1.PackageInstall{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       pkgInstallResource,
+						APIVersion: packagingAPIVersion,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:   "default",
+						Name:        "my-installation",
+						Annotations: map[string]string{"packaging.carvel.dev/package-ClusterRole": "my-installation-default-cluster-role"},
+					},
+					Spec: packagingv1alpha1.PackageInstallSpec{
+						ServiceAccountName: "my-installation-default-sa",
+						PackageRef: &packagingv1alpha1.PackageRef{
+							RefName: "tetris.foo.example.com",
+							VersionSelection: &vendirversions.VersionSelectionSemver{
+								Constraints: "1.2.0",
+							},
+						},
+						Values: []packagingv1alpha1.PackageInstallValues{{
+							SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{
+								Name: "my-installation-default-values",
+							},
+						}},
+					},
+				},
+			},
+			existingTypedObjects: []k8sruntime.Object{
+				&k8scorev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "my-installation-default-values",
 					},
+					Data: map[string][]byte{"values.yaml": []byte("foo: baz")},
 				},
 			},
 			expectedResponse: &corev1.CreateInstalledPackageResponse{
@@ -4960,40 +5761,29 @@ func TestCreateInstalledPackage(t *testing.T) {
 				ObjectMeta: metav1.ObjectMeta{
 					Namespace: "default",
 					Name:      "my-installation",
+					Annotations: map[string]string{
+						"packaging.carvel.dev/package-ClusterRole": "my-installation-default-cluster-role",
+						annotationManagedByKey:                     annotationManagedByValue,
+					},
 				},
 				Spec: packagingv1alpha1.PackageInstallSpec{
 					ServiceAccountName: "default",
 					PackageRef: &packagingv1alpha1.PackageRef{
 						RefName: "tetris.foo.example.com",
 						VersionSelection: &vendirversions.VersionSelectionSemver{
-							Constraints: ">=1.0.0",
+							Constraints: "1.2.3",
 						},
 					},
 					Values: []packagingv1alpha1.PackageInstallValues{{
 						SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{
 							Name: "my-installation-default-values",
 						},
-					},
-					},
-					Paused:     false,
-					Canceled:   false,
-					SyncPeriod: nil,
-					NoopDelete: false,
-				},
-				Status: packagingv1alpha1.PackageInstallStatus{
-					GenericStatus: kappctrlv1alpha1.GenericStatus{
-						ObservedGeneration:  0,
-						Conditions:          nil,
-						FriendlyDescription: "",
-						UsefulErrorMessage:  "",
-					},
-					Version:              "",
-					LastAttemptedVersion: "",
+					}},
 				},
 			},
 		},
 		{
-			name: "create installed package (version constraint - upgradePolicy: minor)",
+			name: "create installed package (unable to adopt a package install of another package)",
 			request: &corev1.CreateInstalledPackageRequest{
 				AvailablePackageRef: &corev1.AvailablePackageReference{
 					Context: &corev1.Context{
@@ -5004,7 +5794,7 @@ func TestCreateInstalledPackage(t *testing.T) {
 					Identifier: "unknown/tetris.foo.example.com",
 				},
 				PkgVersionReference: &corev1.VersionReference{
-					Version: "1.0.0",
+					Version: "1.2.3",
 				},
 				Name: "my-installation",
 				TargetContext: &corev1.Context{
@@ -5014,12 +5804,15 @@ func TestCreateInstalledPackage(t *testing.T) {
 				ReconciliationOptions: &corev1.ReconciliationOptions{
 					ServiceAccountName: "default",
 				},
+				Values: "foo: bar",
+				CustomDetail: func() *anypb.Any {
+					customDetail, _ := anypb.New(&kappcorev1.KappControllerInstalledPackageCustomDetail{
+						AdoptExisting: true,
+					})
+					return customDetail
+				}(),
 			},
-			pluginConfig: &kappControllerPluginParsedConfig{
-				defaultUpgradePolicy:               pkgutils.UpgradePolicyMinor,
-				defaultPrereleasesVersionSelection: defaultPluginConfig.defaultPrereleasesVersionSelection,
-				defaultAllowDowngrades:             defaultPluginConfig.defaultAllowDowngrades,
-			},
+			pluginConfig: defaultPluginConfig,
 			existingObjects: []k8sruntime.Object{
 				&datapackagingv1alpha1.PackageMetadata{
 					TypeMeta: metav1.TypeMeta{
@@ -5031,14 +5824,7 @@ func TestCreateInstalledPackage(t *testing.T) {
 						Name:      "tetris.foo.example.com",
 					},
 					Spec: datapackagingv1alpha1.PackageMetadataSpec{
-						DisplayName:        "Classic Tetris",
-						IconSVGBase64:      "Tm90IHJlYWxseSBTVkcK",
-						ShortDescription:   "A great game for arcade gamers",
-						LongDescription:    "A few sentences but not really a readme",
-						Categories:         []string{"logging", "daemon-set"},
-						Maintainers:        []datapackagingv1alpha1.Maintainer{{Name: "person1"}, {Name: "person2"}},
-						SupportDescription: "Some support information",
-						ProviderName:       "Tetris inc.",
+						DisplayName: "Classic Tetris",
 					},
 				},
 				&datapackagingv1alpha1.Package{
@@ -5051,106 +5837,49 @@ func TestCreateInstalledPackage(t *testing.T) {
 						Name:      "tetris.foo.example.com.1.2.3",
 					},
 					Spec: datapackagingv1alpha1.PackageSpec{
-						RefName:                         "tetris.foo.example.com",
-						Version:                         "1.2.3",
-						Licenses:                        []string{"my-license"},
-						ReleaseNotes:                    "release notes",
-						CapactiyRequirementsDescription: "capacity description",
-						ReleasedAt:                      metav1.Time{Time: time.Date(1984, time.June, 6, 0, 0, 0, 0, time.UTC)},
+						RefName: "tetris.foo.example.com",
+						Version: "1.2.3",
 					},
 				},
-				&kappctrlv1alpha1.App{
+				&packagingv1alpha1.PackageInstall{
 					TypeMeta: metav1.TypeMeta{
-						Kind:       appResource,
-						APIVersion: kappctrlAPIVersion,
+						Kind:       pkgInstallResource,
+						APIVersion: packagingAPIVersion,
 					},
 					ObjectMeta: metav1.ObjectMeta{
-						Namespace: "default",
-						Name:      "my-installation",
-					},
-					Spec: kappctrlv1alpha1.AppSpec{
-						SyncPeriod: &metav1.Duration{Duration: (time.Second * 30)},
+						Namespace:   "default",
+						Name:        "my-installation",
+						Annotations: map[string]string{"packaging.carvel.dev/package-ClusterRole": "my-installation-default-cluster-role"},
 					},
-					Status: kappctrlv1alpha1.AppStatus{
-						Deploy: &kappctrlv1alpha1.AppStatusDeploy{
-							Stdout: "deployStdout",
-							Stderr: "deployStderr",
-						},
-						Fetch: &kappctrlv1alpha1.AppStatusFetch{
-							Stdout: "fetchStdout",
-							Stderr: "fetchStderr",
-						},
-						Inspect: &kappctrlv1alpha1.AppStatusInspect{
-							Stdout: "inspectStdout",
-							Stderr: "inspectStderr",
+					Spec: packagingv1alpha1.PackageInstallSpec{
+						ServiceAccountName: "my-installation-default-sa",
+						PackageRef: &packagingv1alpha1.PackageRef{
+							RefName: "tombi.foo.example.com",
+							VersionSelection: &vendirversions.VersionSelectionSemver{
+								Constraints: "1.2.0",
+							},
 						},
+						Values: []packagingv1alpha1.PackageInstallValues{{
+							SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{
+								Name: "my-installation-default-values",
+							},
+						}},
 					},
 				},
 			},
 			existingTypedObjects: []k8sruntime.Object{
-				&k8scorev1.ConfigMap{
-					TypeMeta: metav1.TypeMeta{
-						Kind:       "ConfigMap",
-						APIVersion: "v1",
-					},
+				&k8scorev1.Secret{
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: "default",
-						Name:      "my-installation-ctrl",
-					},
-					Data: map[string]string{
-						"spec": "{\"labelKey\":\"kapp.k14s.io/app\",\"labelValue\":\"my-id\"}",
-					},
-				},
-			},
-			expectedResponse: &corev1.CreateInstalledPackageResponse{
-				InstalledPackageRef: &corev1.InstalledPackageReference{
-					Context:    defaultContext,
-					Plugin:     &pluginDetail,
-					Identifier: "my-installation",
-				},
-			},
-			expectedPackageInstall: &packagingv1alpha1.PackageInstall{
-				TypeMeta: metav1.TypeMeta{
-					Kind:       pkgInstallResource,
-					APIVersion: packagingAPIVersion,
-				},
-				ObjectMeta: metav1.ObjectMeta{
-					Namespace: "default",
-					Name:      "my-installation",
-				},
-				Spec: packagingv1alpha1.PackageInstallSpec{
-					ServiceAccountName: "default",
-					PackageRef: &packagingv1alpha1.PackageRef{
-						RefName: "tetris.foo.example.com",
-						VersionSelection: &vendirversions.VersionSelectionSemver{
-							Constraints: ">=1.0.0 <2.0.0",
-						},
-					},
-					Values: []packagingv1alpha1.PackageInstallValues{{
-						SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{
-							Name: "my-installation-default-values",
-						},
-					},
-					},
-					Paused:     false,
-					Canceled:   false,
-					SyncPeriod: nil,
-					NoopDelete: false,
-				},
-				Status: packagingv1alpha1.PackageInstallStatus{
-					GenericStatus: kappctrlv1alpha1.GenericStatus{
-						ObservedGeneration:  0,
-						Conditions:          nil,
-						FriendlyDescription: "",
-						UsefulErrorMessage:  "",
+						Name:      "my-installation-default-values",
 					},
-					Version:              "",
-					LastAttemptedVersion: "",
+					Data: map[string][]byte{"values.yaml": []byte("foo: baz")},
 				},
 			},
+			expectedErrorCode: connect.CodeAlreadyExists,
 		},
 		{
-			name: "create installed package (version constraint - upgradePolicy: patch)",
+			name: "create installed package (with image pull secret creation)",
 			request: &corev1.CreateInstalledPackageRequest{
 				AvailablePackageRef: &corev1.AvailablePackageReference{
 					Context: &corev1.Context{
@@ -5161,7 +5890,7 @@ func TestCreateInstalledPackage(t *testing.T) {
 					Identifier: "unknown/tetris.foo.example.com",
 				},
 				PkgVersionReference: &corev1.VersionReference{
-					Version: "1.0.0",
+					Version: "1.2.3",
 				},
 				Name: "my-installation",
 				TargetContext: &corev1.Context{
@@ -5171,11 +5900,16 @@ func TestCreateInstalledPackage(t *testing.T) {
 				ReconciliationOptions: &corev1.ReconciliationOptions{
 					ServiceAccountName: "default",
 				},
+				CustomDetail: func() *anypb.Any {
+					customDetail, _ := anypb.New(&kappcorev1.KappControllerInstalledPackageCustomDetail{
+						CreateImagePullSecret: true,
+					})
+					return customDetail
+				}(),
 			},
 			pluginConfig: &kappControllerPluginParsedConfig{
-				defaultUpgradePolicy:               pkgutils.UpgradePolicyPatch,
-				defaultPrereleasesVersionSelection: defaultPluginConfig.defaultPrereleasesVersionSelection,
-				defaultAllowDowngrades:             defaultPluginConfig.defaultAllowDowngrades,
+				defaultUpgradePolicy:         defaultPluginConfig.defaultUpgradePolicy,
+				allowImagePullSecretCreation: true,
 			},
 			existingObjects: []k8sruntime.Object{
 				&datapackagingv1alpha1.PackageMetadata{
@@ -5272,15 +6006,16 @@ func TestCreateInstalledPackage(t *testing.T) {
 					APIVersion: packagingAPIVersion,
 				},
 				ObjectMeta: metav1.ObjectMeta{
-					Namespace: "default",
-					Name:      "my-installation",
+					Namespace:   "default",
+					Name:        "my-installation",
+					Annotations: map[string]string{annotationImagePullSecretNameKey: "my-installation-default-registry-creds"},
 				},
 				Spec: packagingv1alpha1.PackageInstallSpec{
 					ServiceAccountName: "default",
 					PackageRef: &packagingv1alpha1.PackageRef{
 						RefName: "tetris.foo.example.com",
 						VersionSelection: &vendirversions.VersionSelectionSemver{
-							Constraints: ">=1.0.0 <1.1.0",
+							Constraints: "1.2.3",
 						},
 					},
 					Values: []packagingv1alpha1.PackageInstallValues{{
@@ -5305,9 +6040,34 @@ func TestCreateInstalledPackage(t *testing.T) {
 					LastAttemptedVersion: "",
 				},
 			},
+			expectedImagePullSecret: &k8scorev1.Secret{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "Secret",
+					APIVersion: "v1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      "my-installation-default-registry-creds",
+					Annotations: map[string]string{
+						annotationManagedByKey:       annotationManagedByValue,
+						annotationImagePullSecretKey: "",
+					},
+					OwnerReferences: []metav1.OwnerReference{{
+						APIVersion:         packagingAPIVersion,
+						Kind:               pkgInstallResource,
+						Name:               "my-installation",
+						Controller:         func() *bool { v := true; return &v }(),
+						BlockOwnerDeletion: func() *bool { v := true; return &v }(),
+					}},
+				},
+				Type: k8scorev1.SecretTypeDockerConfigJson,
+				Data: map[string][]byte{
+					k8scorev1.DockerConfigJsonKey: []byte("{}"),
+				},
+			},
 		},
 		{
-			name: "create installed package (defaultAllowDowngrades: true)",
+			name: "create installed package (image pull secret creation not allowed)",
 			request: &corev1.CreateInstalledPackageRequest{
 				AvailablePackageRef: &corev1.AvailablePackageReference{
 					Context: &corev1.Context{
@@ -5318,7 +6078,7 @@ func TestCreateInstalledPackage(t *testing.T) {
 					Identifier: "unknown/tetris.foo.example.com",
 				},
 				PkgVersionReference: &corev1.VersionReference{
-					Version: "1.0.0",
+					Version: "1.2.3",
 				},
 				Name: "my-installation",
 				TargetContext: &corev1.Context{
@@ -5328,26 +6088,177 @@ func TestCreateInstalledPackage(t *testing.T) {
 				ReconciliationOptions: &corev1.ReconciliationOptions{
 					ServiceAccountName: "default",
 				},
+				CustomDetail: func() *anypb.Any {
+					customDetail, _ := anypb.New(&kappcorev1.KappControllerInstalledPackageCustomDetail{
+						CreateImagePullSecret: true,
+					})
+					return customDetail
+				}(),
 			},
-			pluginConfig: &kappControllerPluginParsedConfig{
-				defaultUpgradePolicy:               defaultPluginConfig.defaultUpgradePolicy,
-				defaultPrereleasesVersionSelection: defaultPluginConfig.defaultPrereleasesVersionSelection,
-				defaultAllowDowngrades:             true,
-			},
-			existingObjects: []k8sruntime.Object{
-				&datapackagingv1alpha1.PackageMetadata{
-					TypeMeta: metav1.TypeMeta{
-						Kind:       pkgMetadataResource,
-						APIVersion: datapackagingAPIVersion,
-					},
-					ObjectMeta: metav1.ObjectMeta{
+			pluginConfig:      defaultPluginConfig,
+			expectedErrorCode: connect.CodeFailedPrecondition,
+		},
+		{
+			name: "create installed package (default namespace override not allowed)",
+			request: &corev1.CreateInstalledPackageRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context: &corev1.Context{
 						Namespace: "default",
-						Name:      "tetris.foo.example.com",
+						Cluster:   "default",
 					},
-					Spec: datapackagingv1alpha1.PackageMetadataSpec{
-						DisplayName:        "Classic Tetris",
-						IconSVGBase64:      "Tm90IHJlYWxseSBTVkcK",
-						ShortDescription:   "A great game for arcade gamers",
+					Plugin:     &pluginDetail,
+					Identifier: "unknown/tetris.foo.example.com",
+				},
+				PkgVersionReference: &corev1.VersionReference{
+					Version: "1.2.3",
+				},
+				Name: "my-installation",
+				TargetContext: &corev1.Context{
+					Namespace: "default",
+					Cluster:   "default",
+				},
+				ReconciliationOptions: &corev1.ReconciliationOptions{
+					ServiceAccountName: "default",
+				},
+				CustomDetail: func() *anypb.Any {
+					customDetail, _ := anypb.New(&kappcorev1.KappControllerInstalledPackageCustomDetail{
+						DefaultNamespace: "other",
+					})
+					return customDetail
+				}(),
+			},
+			pluginConfig:      defaultPluginConfig,
+			expectedErrorCode: connect.CodeFailedPrecondition,
+		},
+		{
+			name: "create installed package without waiting for the kapp App to be created",
+			request: &corev1.CreateInstalledPackageRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context: &corev1.Context{
+						Namespace: "default",
+						Cluster:   "default",
+					},
+					Plugin:     &pluginDetail,
+					Identifier: "unknown/tetris.foo.example.com",
+				},
+				PkgVersionReference: &corev1.VersionReference{
+					Version: "1.2.3",
+				},
+				Name: "my-installation",
+				TargetContext: &corev1.Context{
+					Namespace: "default",
+					Cluster:   "default",
+				},
+				ReconciliationOptions: &corev1.ReconciliationOptions{
+					ServiceAccountName: "default",
+				},
+			},
+			pluginConfig: defaultPluginConfig,
+			existingObjects: []k8sruntime.Object{
+				&datapackagingv1alpha1.PackageMetadata{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       pkgMetadataResource,
+						APIVersion: datapackagingAPIVersion,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "tetris.foo.example.com",
+					},
+					Spec: datapackagingv1alpha1.PackageMetadataSpec{
+						DisplayName:        "Classic Tetris",
+						IconSVGBase64:      "Tm90IHJlYWxseSBTVkcK",
+						ShortDescription:   "A great game for arcade gamers",
+						LongDescription:    "A few sentences but not really a readme",
+						Categories:         []string{"logging", "daemon-set"},
+						Maintainers:        []datapackagingv1alpha1.Maintainer{{Name: "person1"}, {Name: "person2"}},
+						SupportDescription: "Some support information",
+						ProviderName:       "Tetris inc.",
+					},
+				},
+				&datapackagingv1alpha1.Package{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       pkgResource,
+						APIVersion: datapackagingAPIVersion,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "tetris.foo.example.com.1.2.3",
+					},
+					Spec: datapackagingv1alpha1.PackageSpec{
+						RefName:                         "tetris.foo.example.com",
+						Version:                         "1.2.3",
+						Licenses:                        []string{"my-license"},
+						ReleaseNotes:                    "release notes",
+						CapactiyRequirementsDescription: "capacity description",
+						ReleasedAt:                      metav1.Time{Time: time.Date(1984, time.June, 6, 0, 0, 0, 0, time.UTC)},
+					},
+				},
+			},
+			existingTypedObjects: []k8sruntime.Object{
+				&k8scorev1.ConfigMap{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       "ConfigMap",
+						APIVersion: "v1",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "my-installation-ctrl",
+					},
+					Data: map[string]string{
+						"spec": "{\"labelKey\":\"kapp.k14s.io/app\",\"labelValue\":\"my-id\"}",
+					},
+				},
+			},
+			expectedResponse: &corev1.CreateInstalledPackageResponse{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context: &corev1.Context{
+						Namespace: "default",
+						Cluster:   "default",
+					},
+					Plugin:     &pluginDetail,
+					Identifier: "my-installation",
+				},
+			},
+		},
+		{
+			name: "create installed package (with values)",
+			request: &corev1.CreateInstalledPackageRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context: &corev1.Context{
+						Namespace: "default",
+						Cluster:   "default",
+					},
+					Plugin:     &pluginDetail,
+					Identifier: "unknown/tetris.foo.example.com",
+				},
+				PkgVersionReference: &corev1.VersionReference{
+					Version: "1.2.3",
+				},
+				Name:   "my-installation",
+				Values: "foo: bar",
+				TargetContext: &corev1.Context{
+					Namespace: "default",
+					Cluster:   "default",
+				},
+				ReconciliationOptions: &corev1.ReconciliationOptions{
+					ServiceAccountName: "default",
+				},
+			},
+			pluginConfig: defaultPluginConfig,
+			existingObjects: []k8sruntime.Object{
+				&datapackagingv1alpha1.PackageMetadata{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       pkgMetadataResource,
+						APIVersion: datapackagingAPIVersion,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "tetris.foo.example.com",
+					},
+					Spec: datapackagingv1alpha1.PackageMetadataSpec{
+						DisplayName:        "Classic Tetris",
+						IconSVGBase64:      "Tm90IHJlYWxseSBTVkcK",
+						ShortDescription:   "A great game for arcade gamers",
 						LongDescription:    "A few sentences but not really a readme",
 						Categories:         []string{"logging", "daemon-set"},
 						Maintainers:        []datapackagingv1alpha1.Maintainer{{Name: "person1"}, {Name: "person2"}},
@@ -5429,16 +6340,15 @@ func TestCreateInstalledPackage(t *testing.T) {
 					APIVersion: packagingAPIVersion,
 				},
 				ObjectMeta: metav1.ObjectMeta{
-					Namespace:   "default",
-					Name:        "my-installation",
-					Annotations: map[string]string{kappctrlpackageinstall.DowngradableAnnKey: ""},
+					Namespace: "default",
+					Name:      "my-installation",
 				},
 				Spec: packagingv1alpha1.PackageInstallSpec{
 					ServiceAccountName: "default",
 					PackageRef: &packagingv1alpha1.PackageRef{
 						RefName: "tetris.foo.example.com",
 						VersionSelection: &vendirversions.VersionSelectionSemver{
-							Constraints: "1.0.0",
+							Constraints: "1.2.3",
 						},
 					},
 					Values: []packagingv1alpha1.PackageInstallValues{{
@@ -5464,91 +6374,29 @@ func TestCreateInstalledPackage(t *testing.T) {
 				},
 			},
 		},
-	}
-
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			var unstructuredObjects []k8sruntime.Object
-			for _, obj := range tc.existingObjects {
-				unstructuredContent, _ := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(obj)
-				unstructuredObjects = append(unstructuredObjects, &unstructured.Unstructured{Object: unstructuredContent})
-			}
-
-			dynamicClient := dynfake.NewSimpleDynamicClientWithCustomListKinds(
-				k8sruntime.NewScheme(),
-				map[schema.GroupVersionResource]string{
-					{Group: datapackagingv1alpha1.SchemeGroupVersion.Group, Version: datapackagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgsResource}:         pkgResource + "List",
-					{Group: datapackagingv1alpha1.SchemeGroupVersion.Group, Version: datapackagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgMetadatasResource}: pkgMetadataResource + "List",
-					{Group: packagingv1alpha1.SchemeGroupVersion.Group, Version: packagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgInstallsResource}:          pkgInstallResource + "List",
-				},
-				unstructuredObjects...,
-			)
-
-			s := Server{
-				pluginConfig: tc.pluginConfig,
-				clientGetter: clientgetter.NewBuilder().
-					WithTyped(typfake.NewSimpleClientset(tc.existingTypedObjects...)).
-					WithDynamic(dynamicClient).
-					Build(),
-			}
-
-			createInstalledPackageResponse, err := s.CreateInstalledPackage(context.Background(), connect.NewRequest(tc.request))
-
-			if got, want := connect.CodeOf(err), tc.expectedErrorCode; err != nil && got != want {
-				t.Fatalf("got: %d, want: %d, err: %+v", got, want, err)
-			}
-			// If we were expecting an error, continue to the next test.
-			if tc.expectedErrorCode != 0 {
-				return
-			}
-			if tc.expectedPackageInstall != nil {
-				if got, want := createInstalledPackageResponse.Msg, tc.expectedResponse; !cmp.Equal(want, got, ignoreUnexported) {
-					t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexported))
-				}
-
-				createdPkgInstall, err := s.getPkgInstall(context.Background(), http.Header{}, "default", tc.request.TargetContext.Namespace, createInstalledPackageResponse.Msg.InstalledPackageRef.Identifier)
-				if err != nil {
-					t.Fatalf("%+v", err)
-				}
-
-				if got, want := createdPkgInstall, tc.expectedPackageInstall; !cmp.Equal(want, got, ignoreUnexported) {
-					t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexported))
-				}
-			}
-		})
-	}
-}
-
-func TestUpdateInstalledPackage(t *testing.T) {
-	testCases := []struct {
-		name                   string
-		request                *corev1.UpdateInstalledPackageRequest
-		pluginConfig           *kappControllerPluginParsedConfig
-		existingObjects        []k8sruntime.Object
-		existingTypedObjects   []k8sruntime.Object
-		expectedErrorCode      connect.Code
-		expectedResponse       *corev1.UpdateInstalledPackageResponse
-		expectedPackageInstall *packagingv1alpha1.PackageInstall
-	}{
 		{
-			name: "update installed package",
-			request: &corev1.UpdateInstalledPackageRequest{
-				InstalledPackageRef: &corev1.InstalledPackageReference{
+			name: "create installed package (with reconciliationOptions)",
+			request: &corev1.CreateInstalledPackageRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
 					Context: &corev1.Context{
 						Namespace: "default",
 						Cluster:   "default",
 					},
 					Plugin:     &pluginDetail,
-					Identifier: "my-installation",
+					Identifier: "unknown/tetris.foo.example.com",
 				},
 				PkgVersionReference: &corev1.VersionReference{
 					Version: "1.2.3",
 				},
-				Values: "foo: bar",
 				ReconciliationOptions: &corev1.ReconciliationOptions{
-					ServiceAccountName: "default",
-					Interval:           "30s",
-					Suspend:            false,
+					Interval:           "99s",
+					Suspend:            true,
+					ServiceAccountName: "my-sa",
+				},
+				Name: "my-installation",
+				TargetContext: &corev1.Context{
+					Namespace: "default",
+					Cluster:   "default",
 				},
 			},
 			pluginConfig: defaultPluginConfig,
@@ -5591,64 +6439,50 @@ func TestUpdateInstalledPackage(t *testing.T) {
 						ReleasedAt:                      metav1.Time{Time: time.Date(1984, time.June, 6, 0, 0, 0, 0, time.UTC)},
 					},
 				},
-				&packagingv1alpha1.PackageInstall{
+				&kappctrlv1alpha1.App{
 					TypeMeta: metav1.TypeMeta{
-						Kind:       pkgInstallResource,
-						APIVersion: packagingAPIVersion,
+						Kind:       appResource,
+						APIVersion: kappctrlAPIVersion,
 					},
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: "default",
 						Name:      "my-installation",
 					},
-					Spec: packagingv1alpha1.PackageInstallSpec{
-						ServiceAccountName: "default",
-						PackageRef: &packagingv1alpha1.PackageRef{
-							RefName: "tetris.foo.example.com",
-							VersionSelection: &vendirversions.VersionSelectionSemver{
-								Constraints: "1.2.3",
-							},
-						},
-						Values: []packagingv1alpha1.PackageInstallValues{{
-							SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{
-								Name: "my-installation-default-values",
-							},
-						},
-						},
-						Paused:     false,
-						Canceled:   false,
+					Spec: kappctrlv1alpha1.AppSpec{
 						SyncPeriod: &metav1.Duration{Duration: (time.Second * 30)},
-						NoopDelete: false,
 					},
-					Status: packagingv1alpha1.PackageInstallStatus{
-						GenericStatus: kappctrlv1alpha1.GenericStatus{
-							ObservedGeneration: 1,
-							Conditions: []kappctrlv1alpha1.Condition{{
-								Type:    kappctrlv1alpha1.ReconcileSucceeded,
-								Status:  k8scorev1.ConditionTrue,
-								Reason:  "baz",
-								Message: "qux",
-							}},
-							FriendlyDescription: "foo",
-							UsefulErrorMessage:  "Deployed",
+					Status: kappctrlv1alpha1.AppStatus{
+						Deploy: &kappctrlv1alpha1.AppStatusDeploy{
+							Stdout: "deployStdout",
+							Stderr: "deployStderr",
+						},
+						Fetch: &kappctrlv1alpha1.AppStatusFetch{
+							Stdout: "fetchStdout",
+							Stderr: "fetchStderr",
+						},
+						Inspect: &kappctrlv1alpha1.AppStatusInspect{
+							Stdout: "inspectStdout",
+							Stderr: "inspectStderr",
 						},
-						Version:              "1.2.3",
-						LastAttemptedVersion: "1.2.3",
 					},
 				},
 			},
 			existingTypedObjects: []k8sruntime.Object{
-				&k8scorev1.Secret{
+				&k8scorev1.ConfigMap{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       "ConfigMap",
+						APIVersion: "v1",
+					},
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: "default",
-						Name:      "my-installation-default-values",
+						Name:      "my-installation-ctrl",
 					},
-					Type: "Opaque",
-					Data: map[string][]byte{
-						"values.yaml": []byte("foo: bar"),
+					Data: map[string]string{
+						"spec": "{\"labelKey\":\"kapp.k14s.io/app\",\"labelValue\":\"my-id\"}",
 					},
 				},
 			},
-			expectedResponse: &corev1.UpdateInstalledPackageResponse{
+			expectedResponse: &corev1.CreateInstalledPackageResponse{
 				InstalledPackageRef: &corev1.InstalledPackageReference{
 					Context:    defaultContext,
 					Plugin:     &pluginDetail,
@@ -5665,7 +6499,7 @@ func TestUpdateInstalledPackage(t *testing.T) {
 					Name:      "my-installation",
 				},
 				Spec: packagingv1alpha1.PackageInstallSpec{
-					ServiceAccountName: "default",
+					ServiceAccountName: "my-sa",
 					PackageRef: &packagingv1alpha1.PackageRef{
 						RefName: "tetris.foo.example.com",
 						VersionSelection: &vendirversions.VersionSelectionSemver{
@@ -5678,47 +6512,44 @@ func TestUpdateInstalledPackage(t *testing.T) {
 						},
 					},
 					},
-					Paused:     false,
+					Paused:     true,
 					Canceled:   false,
-					SyncPeriod: &metav1.Duration{Duration: (time.Second * 30)},
+					SyncPeriod: &metav1.Duration{Duration: (time.Second * 99)},
 					NoopDelete: false,
 				},
 				Status: packagingv1alpha1.PackageInstallStatus{
 					GenericStatus: kappctrlv1alpha1.GenericStatus{
-						ObservedGeneration: 1,
-						Conditions: []kappctrlv1alpha1.Condition{{
-							Type:    kappctrlv1alpha1.ReconcileSucceeded,
-							Status:  k8scorev1.ConditionTrue,
-							Reason:  "baz",
-							Message: "qux",
-						}},
-						FriendlyDescription: "foo",
-						UsefulErrorMessage:  "Deployed",
+						ObservedGeneration:  0,
+						Conditions:          nil,
+						FriendlyDescription: "",
+						UsefulErrorMessage:  "",
 					},
-					Version:              "1.2.3",
-					LastAttemptedVersion: "1.2.3",
+					Version:              "",
+					LastAttemptedVersion: "",
 				},
 			},
 		},
 		{
-			name: "update installed package (non eligible version)",
-			request: &corev1.UpdateInstalledPackageRequest{
-				InstalledPackageRef: &corev1.InstalledPackageReference{
+			name: "create installed package (prereleases - defaultPrereleasesVersionSelection: nil)",
+			request: &corev1.CreateInstalledPackageRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
 					Context: &corev1.Context{
 						Namespace: "default",
 						Cluster:   "default",
 					},
 					Plugin:     &pluginDetail,
-					Identifier: "my-installation",
+					Identifier: "unknown/tetris.foo.example.com",
 				},
 				PkgVersionReference: &corev1.VersionReference{
-					Version: "1.2.3-rc",
+					Version: "1.0.0",
+				},
+				Name: "my-installation",
+				TargetContext: &corev1.Context{
+					Namespace: "default",
+					Cluster:   "default",
 				},
-				Values: "foo: bar",
 				ReconciliationOptions: &corev1.ReconciliationOptions{
 					ServiceAccountName: "default",
-					Interval:           "30s",
-					Suspend:            false,
 				},
 			},
 			pluginConfig: &kappControllerPluginParsedConfig{
@@ -5765,385 +6596,301 @@ func TestUpdateInstalledPackage(t *testing.T) {
 						ReleasedAt:                      metav1.Time{Time: time.Date(1984, time.June, 6, 0, 0, 0, 0, time.UTC)},
 					},
 				},
-				&packagingv1alpha1.PackageInstall{
+				&kappctrlv1alpha1.App{
 					TypeMeta: metav1.TypeMeta{
-						Kind:       pkgInstallResource,
-						APIVersion: packagingAPIVersion,
+						Kind:       appResource,
+						APIVersion: kappctrlAPIVersion,
 					},
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: "default",
 						Name:      "my-installation",
 					},
-					Spec: packagingv1alpha1.PackageInstallSpec{
-						ServiceAccountName: "default",
-						PackageRef: &packagingv1alpha1.PackageRef{
-							RefName: "tetris.foo.example.com",
-							VersionSelection: &vendirversions.VersionSelectionSemver{
-								Constraints: "1.2.3",
-							},
-						},
-						Values: []packagingv1alpha1.PackageInstallValues{{
-							SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{
-								Name: "my-installation-default-values",
-							},
-						},
-						},
-						Paused:     false,
-						Canceled:   false,
+					Spec: kappctrlv1alpha1.AppSpec{
 						SyncPeriod: &metav1.Duration{Duration: (time.Second * 30)},
-						NoopDelete: false,
 					},
-					Status: packagingv1alpha1.PackageInstallStatus{
-						GenericStatus: kappctrlv1alpha1.GenericStatus{
-							ObservedGeneration: 1,
-							Conditions: []kappctrlv1alpha1.Condition{{
-								Type:    kappctrlv1alpha1.ReconcileSucceeded,
-								Status:  k8scorev1.ConditionTrue,
-								Reason:  "baz",
-								Message: "qux",
-							}},
-							FriendlyDescription: "foo",
-							UsefulErrorMessage:  "Deployed",
+					Status: kappctrlv1alpha1.AppStatus{
+						Deploy: &kappctrlv1alpha1.AppStatusDeploy{
+							Stdout: "deployStdout",
+							Stderr: "deployStderr",
+						},
+						Fetch: &kappctrlv1alpha1.AppStatusFetch{
+							Stdout: "fetchStdout",
+							Stderr: "fetchStderr",
+						},
+						Inspect: &kappctrlv1alpha1.AppStatusInspect{
+							Stdout: "inspectStdout",
+							Stderr: "inspectStderr",
 						},
-						Version:              "1.2.3",
-						LastAttemptedVersion: "1.2.3",
 					},
 				},
 			},
 			existingTypedObjects: []k8sruntime.Object{
-				&k8scorev1.Secret{
+				&k8scorev1.ConfigMap{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       "ConfigMap",
+						APIVersion: "v1",
+					},
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: "default",
-						Name:      "my-installation-default-values",
+						Name:      "my-installation-ctrl",
 					},
-					Type: "Opaque",
-					Data: map[string][]byte{
-						"values.yaml": []byte("foo: bar"),
+					Data: map[string]string{
+						"spec": "{\"labelKey\":\"kapp.k14s.io/app\",\"labelValue\":\"my-id\"}",
 					},
 				},
 			},
-			expectedErrorCode: connect.CodeInvalidArgument,
-		},
-	}
-
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			var unstructuredObjects []k8sruntime.Object
-			for _, obj := range tc.existingObjects {
-				unstructuredContent, _ := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(obj)
-				unstructuredObjects = append(unstructuredObjects, &unstructured.Unstructured{Object: unstructuredContent})
-			}
-
-			s := Server{
-				pluginConfig: defaultPluginConfig,
-				clientGetter: clientgetter.NewBuilder().
-					WithTyped(typfake.NewSimpleClientset(tc.existingTypedObjects...)).
-					WithDynamic(dynfake.NewSimpleDynamicClientWithCustomListKinds(
-						k8sruntime.NewScheme(),
-						map[schema.GroupVersionResource]string{
-							{Group: datapackagingv1alpha1.SchemeGroupVersion.Group, Version: datapackagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgsResource}:         pkgResource + "List",
-							{Group: datapackagingv1alpha1.SchemeGroupVersion.Group, Version: datapackagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgMetadatasResource}: pkgMetadataResource + "List",
-							{Group: packagingv1alpha1.SchemeGroupVersion.Group, Version: packagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgInstallsResource}:          pkgInstallResource + "List",
-						},
-						unstructuredObjects...,
-					)).Build(),
-			}
-
-			updateInstalledPackageResponse, err := s.UpdateInstalledPackage(context.Background(), connect.NewRequest(tc.request))
-
-			if got, want := connect.CodeOf(err), tc.expectedErrorCode; err != nil && got != want {
-				t.Fatalf("got: %d, want: %d, err: %+v", got, want, err)
-			}
-			// If we were expecting an error, continue to the next test.
-			if tc.expectedErrorCode != 0 {
-				return
-			}
-			if tc.expectedPackageInstall != nil {
-				if got, want := updateInstalledPackageResponse.Msg, tc.expectedResponse; !cmp.Equal(want, got, ignoreUnexported) {
-					t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexported))
-				}
-
-				updatedPkgInstall, err := s.getPkgInstall(context.Background(), http.Header{}, "default", updateInstalledPackageResponse.Msg.InstalledPackageRef.Context.Namespace, updateInstalledPackageResponse.Msg.InstalledPackageRef.Identifier)
-				if err != nil {
-					t.Fatalf("%+v", err)
-				}
-
-				if got, want := updatedPkgInstall, tc.expectedPackageInstall; !cmp.Equal(want, got, ignoreUnexported) {
-					t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexported))
-				}
-			}
-		})
-	}
-}
-
-func TestDeleteInstalledPackage(t *testing.T) {
-	testCases := []struct {
-		name                 string
-		request              *corev1.DeleteInstalledPackageRequest
-		existingObjects      []k8sruntime.Object
-		existingTypedObjects []k8sruntime.Object
-		expectedErrorCode    connect.Code
-		expectedResponse     *corev1.DeleteInstalledPackageResponse
-	}{
-		{
-			name: "deletes installed package",
-			request: &corev1.DeleteInstalledPackageRequest{
+			expectedResponse: &corev1.CreateInstalledPackageResponse{
 				InstalledPackageRef: &corev1.InstalledPackageReference{
 					Context:    defaultContext,
 					Plugin:     &pluginDetail,
 					Identifier: "my-installation",
 				},
 			},
-			existingObjects: []k8sruntime.Object{
-				&packagingv1alpha1.PackageInstall{
-					TypeMeta: metav1.TypeMeta{
-						Kind:       pkgInstallResource,
-						APIVersion: packagingAPIVersion,
-					},
-					ObjectMeta: metav1.ObjectMeta{
-						Namespace: "default",
-						Name:      "my-installation",
+			expectedPackageInstall: &packagingv1alpha1.PackageInstall{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       pkgInstallResource,
+					APIVersion: packagingAPIVersion,
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      "my-installation",
+				},
+				Spec: packagingv1alpha1.PackageInstallSpec{
+					ServiceAccountName: "default",
+					PackageRef: &packagingv1alpha1.PackageRef{
+						RefName: "tetris.foo.example.com",
+						VersionSelection: &vendirversions.VersionSelectionSemver{
+							Constraints: "1.0.0",
+							Prereleases: nil,
+						},
 					},
-					Spec: packagingv1alpha1.PackageInstallSpec{
-						ServiceAccountName: "default",
-						PackageRef: &packagingv1alpha1.PackageRef{
-							RefName: "tetris.foo.example.com",
-							VersionSelection: &vendirversions.VersionSelectionSemver{
-								Constraints: "1.2.3",
-							},
+					Values: []packagingv1alpha1.PackageInstallValues{{
+						SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{
+							Name: "my-installation-default-values",
 						},
-						Values: []packagingv1alpha1.PackageInstallValues{{
-							SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{
-								Name: "my-installation-default-values",
-							},
-						},
-						},
-						Paused:     false,
-						Canceled:   false,
-						SyncPeriod: &metav1.Duration{Duration: (time.Second * 30)},
-						NoopDelete: false,
 					},
-					Status: packagingv1alpha1.PackageInstallStatus{
-						GenericStatus: kappctrlv1alpha1.GenericStatus{
-							ObservedGeneration: 1,
-							Conditions: []kappctrlv1alpha1.Condition{{
-								Type:    kappctrlv1alpha1.ReconcileSucceeded,
-								Status:  k8scorev1.ConditionTrue,
-								Reason:  "baz",
-								Message: "qux",
-							}},
-							FriendlyDescription: "foo",
-							UsefulErrorMessage:  "Deployed",
-						},
-						Version:              "1.2.3",
-						LastAttemptedVersion: "1.2.3",
 					},
+					Paused:     false,
+					Canceled:   false,
+					SyncPeriod: nil,
+					NoopDelete: false,
 				},
-			},
-			existingTypedObjects: []k8sruntime.Object{
-				&k8scorev1.Secret{
-					ObjectMeta: metav1.ObjectMeta{
-						Namespace: "default",
-						Name:      "my-installation-default-values",
-					},
-					Type: "Opaque",
-					Data: map[string][]byte{
-						"values.yaml": []byte("foo: bar"),
+				Status: packagingv1alpha1.PackageInstallStatus{
+					GenericStatus: kappctrlv1alpha1.GenericStatus{
+						ObservedGeneration:  0,
+						Conditions:          nil,
+						FriendlyDescription: "",
+						UsefulErrorMessage:  "",
 					},
+					Version:              "",
+					LastAttemptedVersion: "",
 				},
 			},
-			expectedResponse: &corev1.DeleteInstalledPackageResponse{},
 		},
 		{
-			name: "returns not found if installed package doesn't exist",
-			request: &corev1.DeleteInstalledPackageRequest{
-				InstalledPackageRef: &corev1.InstalledPackageReference{
-					Context:    defaultContext,
+			name: "create installed package (non eligible version)",
+			request: &corev1.CreateInstalledPackageRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context: &corev1.Context{
+						Namespace: "default",
+						Cluster:   "default",
+					},
 					Plugin:     &pluginDetail,
-					Identifier: "noy-my-installation",
+					Identifier: "unknown/tetris.foo.example.com",
+				},
+				PkgVersionReference: &corev1.VersionReference{
+					Version: "1.0.0-rc1",
+				},
+				Name: "my-installation",
+				TargetContext: &corev1.Context{
+					Namespace: "default",
+					Cluster:   "default",
 				},
+				ReconciliationOptions: &corev1.ReconciliationOptions{
+					ServiceAccountName: "default",
+				},
+			},
+			pluginConfig: &kappControllerPluginParsedConfig{
+				defaultUpgradePolicy:               defaultPluginConfig.defaultUpgradePolicy,
+				defaultPrereleasesVersionSelection: nil,
+				defaultAllowDowngrades:             defaultPluginConfig.defaultAllowDowngrades,
 			},
 			existingObjects: []k8sruntime.Object{
-				&packagingv1alpha1.PackageInstall{
+				&datapackagingv1alpha1.PackageMetadata{
 					TypeMeta: metav1.TypeMeta{
-						Kind:       pkgInstallResource,
-						APIVersion: packagingAPIVersion,
+						Kind:       pkgMetadataResource,
+						APIVersion: datapackagingAPIVersion,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "tetris.foo.example.com",
+					},
+					Spec: datapackagingv1alpha1.PackageMetadataSpec{
+						DisplayName:        "Classic Tetris",
+						IconSVGBase64:      "Tm90IHJlYWxseSBTVkcK",
+						ShortDescription:   "A great game for arcade gamers",
+						LongDescription:    "A few sentences but not really a readme",
+						Categories:         []string{"logging", "daemon-set"},
+						Maintainers:        []datapackagingv1alpha1.Maintainer{{Name: "person1"}, {Name: "person2"}},
+						SupportDescription: "Some support information",
+						ProviderName:       "Tetris inc.",
+					},
+				},
+				&datapackagingv1alpha1.Package{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       pkgResource,
+						APIVersion: datapackagingAPIVersion,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "tetris.foo.example.com.1.2.3",
+					},
+					Spec: datapackagingv1alpha1.PackageSpec{
+						RefName:                         "tetris.foo.example.com",
+						Version:                         "1.2.3",
+						Licenses:                        []string{"my-license"},
+						ReleaseNotes:                    "release notes",
+						CapactiyRequirementsDescription: "capacity description",
+						ReleasedAt:                      metav1.Time{Time: time.Date(1984, time.June, 6, 0, 0, 0, 0, time.UTC)},
+					},
+				},
+				&kappctrlv1alpha1.App{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       appResource,
+						APIVersion: kappctrlAPIVersion,
 					},
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: "default",
 						Name:      "my-installation",
 					},
-					Spec: packagingv1alpha1.PackageInstallSpec{
-						ServiceAccountName: "default",
-						PackageRef: &packagingv1alpha1.PackageRef{
-							RefName: "tetris.foo.example.com",
-							VersionSelection: &vendirversions.VersionSelectionSemver{
-								Constraints: "1.2.3",
-							},
-						},
-						Values: []packagingv1alpha1.PackageInstallValues{{
-							SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{
-								Name: "my-installation-default-values",
-							},
-						},
-						},
-						Paused:     false,
-						Canceled:   false,
+					Spec: kappctrlv1alpha1.AppSpec{
 						SyncPeriod: &metav1.Duration{Duration: (time.Second * 30)},
-						NoopDelete: false,
 					},
-					Status: packagingv1alpha1.PackageInstallStatus{
-						GenericStatus: kappctrlv1alpha1.GenericStatus{
-							ObservedGeneration: 1,
-							Conditions: []kappctrlv1alpha1.Condition{{
-								Type:    kappctrlv1alpha1.ReconcileSucceeded,
-								Status:  k8scorev1.ConditionTrue,
-								Reason:  "baz",
-								Message: "qux",
-							}},
-							FriendlyDescription: "foo",
-							UsefulErrorMessage:  "Deployed",
+					Status: kappctrlv1alpha1.AppStatus{
+						Deploy: &kappctrlv1alpha1.AppStatusDeploy{
+							Stdout: "deployStdout",
+							Stderr: "deployStderr",
+						},
+						Fetch: &kappctrlv1alpha1.AppStatusFetch{
+							Stdout: "fetchStdout",
+							Stderr: "fetchStderr",
+						},
+						Inspect: &kappctrlv1alpha1.AppStatusInspect{
+							Stdout: "inspectStdout",
+							Stderr: "inspectStderr",
 						},
-						Version:              "1.2.3",
-						LastAttemptedVersion: "1.2.3",
 					},
 				},
 			},
 			existingTypedObjects: []k8sruntime.Object{
-				&k8scorev1.Secret{
+				&k8scorev1.ConfigMap{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       "ConfigMap",
+						APIVersion: "v1",
+					},
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: "default",
-						Name:      "my-installation-default-values",
+						Name:      "my-installation-ctrl",
 					},
-					Type: "Opaque",
-					Data: map[string][]byte{
-						"values.yaml": []byte("foo: bar"),
+					Data: map[string]string{
+						"spec": "{\"labelKey\":\"kapp.k14s.io/app\",\"labelValue\":\"my-id\"}",
 					},
 				},
 			},
-			expectedErrorCode: connect.CodeNotFound,
-			expectedResponse:  &corev1.DeleteInstalledPackageResponse{},
+			expectedErrorCode: connect.CodeInvalidArgument,
 		},
-	}
-
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			var unstructuredObjects []k8sruntime.Object
-			for _, obj := range tc.existingObjects {
-				unstructuredContent, _ := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(obj)
-				unstructuredObjects = append(unstructuredObjects, &unstructured.Unstructured{Object: unstructuredContent})
-			}
-
-			s := Server{
-				pluginConfig: defaultPluginConfig,
-				clientGetter: clientgetter.NewBuilder().
-					WithTyped(typfake.NewSimpleClientset(tc.existingTypedObjects...)).
-					WithDynamic(dynfake.NewSimpleDynamicClientWithCustomListKinds(
-						k8sruntime.NewScheme(),
-						map[schema.GroupVersionResource]string{
-							{Group: datapackagingv1alpha1.SchemeGroupVersion.Group, Version: datapackagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgsResource}:         pkgResource + "List",
-							{Group: datapackagingv1alpha1.SchemeGroupVersion.Group, Version: datapackagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgMetadatasResource}: pkgMetadataResource + "List",
-							{Group: packagingv1alpha1.SchemeGroupVersion.Group, Version: packagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgInstallsResource}:          pkgInstallResource + "List",
-						},
-						unstructuredObjects...,
-					)).
-					Build(),
-			}
-
-			deleteInstalledPackageResponse, err := s.DeleteInstalledPackage(context.Background(), connect.NewRequest(tc.request))
-
-			if got, want := connect.CodeOf(err), tc.expectedErrorCode; err != nil && got != want {
-				t.Fatalf("got: %d, want: %d, err: %+v", got, want, err)
-			}
-			// If we were expecting an error, continue to the next test.
-			if tc.expectedErrorCode != 0 {
-				return
-			}
-			if got, want := deleteInstalledPackageResponse.Msg, tc.expectedResponse; !cmp.Equal(want, got, ignoreUnexported) {
-				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexported))
-			}
-		})
-	}
-}
-
-func TestGetInstalledPackageResourceRefs(t *testing.T) {
-	testCases := []struct {
-		name                 string
-		request              *corev1.GetInstalledPackageResourceRefsRequest
-		existingObjects      []k8sruntime.Object
-		existingTypedObjects []k8sruntime.Object
-		expectedErrorCode    connect.Code
-		expectedResponse     *corev1.GetInstalledPackageResourceRefsResponse
-	}{
 		{
-			name: "fetch the resources from an installed package (kapp < 0.47 suffix)",
-			request: &corev1.GetInstalledPackageResourceRefsRequest{
-				InstalledPackageRef: &corev1.InstalledPackageReference{
-					Context:    defaultContext,
+			name: "create installed package (prereleases - defaultPrereleasesVersionSelection: [])",
+			request: &corev1.CreateInstalledPackageRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context: &corev1.Context{
+						Namespace: "default",
+						Cluster:   "default",
+					},
 					Plugin:     &pluginDetail,
-					Identifier: "my-installation",
+					Identifier: "unknown/tetris.foo.example.com",
+				},
+				PkgVersionReference: &corev1.VersionReference{
+					Version: "1.0.0",
+				},
+				Name: "my-installation",
+				TargetContext: &corev1.Context{
+					Namespace: "default",
+					Cluster:   "default",
+				},
+				ReconciliationOptions: &corev1.ReconciliationOptions{
+					ServiceAccountName: "default",
 				},
 			},
+			pluginConfig: &kappControllerPluginParsedConfig{
+				defaultUpgradePolicy:               defaultPluginConfig.defaultUpgradePolicy,
+				defaultPrereleasesVersionSelection: []string{},
+				defaultAllowDowngrades:             defaultPluginConfig.defaultAllowDowngrades,
+			},
 			existingObjects: []k8sruntime.Object{
-				&packagingv1alpha1.PackageInstall{
+				&datapackagingv1alpha1.PackageMetadata{
 					TypeMeta: metav1.TypeMeta{
-						Kind:       pkgInstallResource,
-						APIVersion: packagingAPIVersion,
+						Kind:       pkgMetadataResource,
+						APIVersion: datapackagingAPIVersion,
 					},
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: "default",
-						Name:      "my-installation",
+						Name:      "tetris.foo.example.com",
 					},
-					Spec: packagingv1alpha1.PackageInstallSpec{
-						ServiceAccountName: "default",
-						PackageRef: &packagingv1alpha1.PackageRef{
-							RefName: "tetris.foo.example.com",
-							VersionSelection: &vendirversions.VersionSelectionSemver{
-								Constraints: "1.2.3",
-							},
-						},
-						Values: []packagingv1alpha1.PackageInstallValues{{
-							SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{
-								Name: "my-installation-default-values",
-							},
-						},
-						},
-						Paused:     false,
-						Canceled:   false,
-						SyncPeriod: &metav1.Duration{Duration: (time.Second * 30)},
-						NoopDelete: false,
+					Spec: datapackagingv1alpha1.PackageMetadataSpec{
+						DisplayName:        "Classic Tetris",
+						IconSVGBase64:      "Tm90IHJlYWxseSBTVkcK",
+						ShortDescription:   "A great game for arcade gamers",
+						LongDescription:    "A few sentences but not really a readme",
+						Categories:         []string{"logging", "daemon-set"},
+						Maintainers:        []datapackagingv1alpha1.Maintainer{{Name: "person1"}, {Name: "person2"}},
+						SupportDescription: "Some support information",
+						ProviderName:       "Tetris inc.",
 					},
-					Status: packagingv1alpha1.PackageInstallStatus{
-						GenericStatus: kappctrlv1alpha1.GenericStatus{
-							ObservedGeneration: 1,
-							Conditions: []kappctrlv1alpha1.Condition{{
-								Type:    kappctrlv1alpha1.ReconcileSucceeded,
-								Status:  k8scorev1.ConditionTrue,
-								Reason:  "baz",
-								Message: "qux",
-							}},
-							FriendlyDescription: "foo",
-							UsefulErrorMessage:  "foo",
-						},
-						Version:              "1.2.3",
-						LastAttemptedVersion: "1.2.3",
+				},
+				&datapackagingv1alpha1.Package{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       pkgResource,
+						APIVersion: datapackagingAPIVersion,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "tetris.foo.example.com.1.2.3",
+					},
+					Spec: datapackagingv1alpha1.PackageSpec{
+						RefName:                         "tetris.foo.example.com",
+						Version:                         "1.2.3",
+						Licenses:                        []string{"my-license"},
+						ReleaseNotes:                    "release notes",
+						CapactiyRequirementsDescription: "capacity description",
+						ReleasedAt:                      metav1.Time{Time: time.Date(1984, time.June, 6, 0, 0, 0, 0, time.UTC)},
 					},
 				},
-				// Although it's a typical k8s object, it is retrieved with the dynamic client
-				&k8scorev1.Pod{
+				&kappctrlv1alpha1.App{
 					TypeMeta: metav1.TypeMeta{
-						APIVersion: "v1",
-						Kind:       "Pod",
+						Kind:       appResource,
+						APIVersion: kappctrlAPIVersion,
 					},
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: "default",
-						Name:      "my-installation-pod",
-						Labels:    map[string]string{"kapp.k14s.io/app": "my-id"},
+						Name:      "my-installation",
 					},
-					Spec: k8scorev1.PodSpec{
-						Containers: []k8scorev1.Container{{
-							Name: "my-installation-container",
-						}},
+					Spec: kappctrlv1alpha1.AppSpec{
+						SyncPeriod: &metav1.Duration{Duration: (time.Second * 30)},
+					},
+					Status: kappctrlv1alpha1.AppStatus{
+						Deploy: &kappctrlv1alpha1.AppStatusDeploy{
+							Stdout: "deployStdout",
+							Stderr: "deployStderr",
+						},
+						Fetch: &kappctrlv1alpha1.AppStatusFetch{
+							Stdout: "fetchStdout",
+							Stderr: "fetchStderr",
+						},
+						Inspect: &kappctrlv1alpha1.AppStatusInspect{
+							Stdout: "inspectStdout",
+							Stderr: "inspectStderr",
+						},
 					},
 				},
 			},
@@ -6162,226 +6909,300 @@ func TestGetInstalledPackageResourceRefs(t *testing.T) {
 					},
 				},
 			},
-			expectedResponse: &corev1.GetInstalledPackageResourceRefsResponse{
-				ResourceRefs: []*corev1.ResourceRef{
-					{
-						ApiVersion: "v1",
-						Kind:       "Pod",
-						Name:       "my-installation-pod",
-						Namespace:  "default",
+			expectedResponse: &corev1.CreateInstalledPackageResponse{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context:    defaultContext,
+					Plugin:     &pluginDetail,
+					Identifier: "my-installation",
+				},
+			},
+			expectedPackageInstall: &packagingv1alpha1.PackageInstall{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       pkgInstallResource,
+					APIVersion: packagingAPIVersion,
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      "my-installation",
+				},
+				Spec: packagingv1alpha1.PackageInstallSpec{
+					ServiceAccountName: "default",
+					PackageRef: &packagingv1alpha1.PackageRef{
+						RefName: "tetris.foo.example.com",
+						VersionSelection: &vendirversions.VersionSelectionSemver{
+							Constraints: "1.0.0",
+							Prereleases: &vendirversions.VersionSelectionSemverPrereleases{},
+						},
+					},
+					Values: []packagingv1alpha1.PackageInstallValues{{
+						SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{
+							Name: "my-installation-default-values",
+						},
+					},
 					},
+					Paused:     false,
+					Canceled:   false,
+					SyncPeriod: nil,
+					NoopDelete: false,
+				},
+				Status: packagingv1alpha1.PackageInstallStatus{
+					GenericStatus: kappctrlv1alpha1.GenericStatus{
+						ObservedGeneration:  0,
+						Conditions:          nil,
+						FriendlyDescription: "",
+						UsefulErrorMessage:  "",
+					},
+					Version:              "",
+					LastAttemptedVersion: "",
 				},
-				Context: defaultContext,
 			},
 		},
 		{
-			name: "fetch the resources from an installed package (kapp => 0.47 suffix)",
-			request: &corev1.GetInstalledPackageResourceRefsRequest{
-				InstalledPackageRef: &corev1.InstalledPackageReference{
-					Context:    defaultContext,
+			name: "create installed package (prereleases - defaultPrereleasesVersionSelection: ['rc'])",
+			request: &corev1.CreateInstalledPackageRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context: &corev1.Context{
+						Namespace: "default",
+						Cluster:   "default",
+					},
 					Plugin:     &pluginDetail,
-					Identifier: "my-installation",
+					Identifier: "unknown/tetris.foo.example.com",
 				},
+				PkgVersionReference: &corev1.VersionReference{
+					Version: "1.0.0",
+				},
+				Name: "my-installation",
+				TargetContext: &corev1.Context{
+					Namespace: "default",
+					Cluster:   "default",
+				},
+				ReconciliationOptions: &corev1.ReconciliationOptions{
+					ServiceAccountName: "default",
+				},
+			},
+			pluginConfig: &kappControllerPluginParsedConfig{
+				defaultUpgradePolicy:               defaultPluginConfig.defaultUpgradePolicy,
+				defaultPrereleasesVersionSelection: []string{"rc"},
+				defaultAllowDowngrades:             defaultPluginConfig.defaultAllowDowngrades,
 			},
 			existingObjects: []k8sruntime.Object{
-				&packagingv1alpha1.PackageInstall{
+				&datapackagingv1alpha1.PackageMetadata{
 					TypeMeta: metav1.TypeMeta{
-						Kind:       pkgInstallResource,
-						APIVersion: packagingAPIVersion,
+						Kind:       pkgMetadataResource,
+						APIVersion: datapackagingAPIVersion,
 					},
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: "default",
-						Name:      "my-installation",
-					},
-					Spec: packagingv1alpha1.PackageInstallSpec{
-						ServiceAccountName: "default",
-						PackageRef: &packagingv1alpha1.PackageRef{
-							RefName: "tetris.foo.example.com",
-							VersionSelection: &vendirversions.VersionSelectionSemver{
-								Constraints: "1.2.3",
-							},
-						},
-						Values: []packagingv1alpha1.PackageInstallValues{{
-							SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{
-								Name: "my-installation-default-values",
-							},
-						},
-						},
-						Paused:     false,
-						Canceled:   false,
-						SyncPeriod: &metav1.Duration{Duration: (time.Second * 30)},
-						NoopDelete: false,
+						Name:      "tetris.foo.example.com",
 					},
-					Status: packagingv1alpha1.PackageInstallStatus{
-						GenericStatus: kappctrlv1alpha1.GenericStatus{
-							ObservedGeneration: 1,
-							Conditions: []kappctrlv1alpha1.Condition{{
-								Type:    kappctrlv1alpha1.ReconcileSucceeded,
-								Status:  k8scorev1.ConditionTrue,
-								Reason:  "baz",
-								Message: "qux",
-							}},
-							FriendlyDescription: "foo",
-							UsefulErrorMessage:  "foo",
-						},
-						Version:              "1.2.3",
-						LastAttemptedVersion: "1.2.3",
+					Spec: datapackagingv1alpha1.PackageMetadataSpec{
+						DisplayName:        "Classic Tetris",
+						IconSVGBase64:      "Tm90IHJlYWxseSBTVkcK",
+						ShortDescription:   "A great game for arcade gamers",
+						LongDescription:    "A few sentences but not really a readme",
+						Categories:         []string{"logging", "daemon-set"},
+						Maintainers:        []datapackagingv1alpha1.Maintainer{{Name: "person1"}, {Name: "person2"}},
+						SupportDescription: "Some support information",
+						ProviderName:       "Tetris inc.",
 					},
 				},
-				// Although it's a typical k8s object, it is retrieved with the dynamic client
-				&k8scorev1.Pod{
+				&datapackagingv1alpha1.Package{
 					TypeMeta: metav1.TypeMeta{
-						APIVersion: "v1",
-						Kind:       "Pod",
+						Kind:       pkgResource,
+						APIVersion: datapackagingAPIVersion,
 					},
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: "default",
-						Name:      "my-installation-pod",
-						Labels:    map[string]string{"kapp.k14s.io/app": "my-id"},
+						Name:      "tetris.foo.example.com.1.2.3",
 					},
-					Spec: k8scorev1.PodSpec{
-						Containers: []k8scorev1.Container{{
-							Name: "my-installation-container",
-						}},
+					Spec: datapackagingv1alpha1.PackageSpec{
+						RefName:                         "tetris.foo.example.com",
+						Version:                         "1.2.3",
+						Licenses:                        []string{"my-license"},
+						ReleaseNotes:                    "release notes",
+						CapactiyRequirementsDescription: "capacity description",
+						ReleasedAt:                      metav1.Time{Time: time.Date(1984, time.June, 6, 0, 0, 0, 0, time.UTC)},
 					},
 				},
-			},
-			existingTypedObjects: []k8sruntime.Object{
-				&k8scorev1.ConfigMap{
+				&kappctrlv1alpha1.App{
 					TypeMeta: metav1.TypeMeta{
-						Kind:       "ConfigMap",
-						APIVersion: "v1",
+						Kind:       appResource,
+						APIVersion: kappctrlAPIVersion,
 					},
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: "default",
-						Name:      "my-installation.app",
+						Name:      "my-installation",
 					},
-					Data: map[string]string{
-						"spec": "{\"labelKey\":\"kapp.k14s.io/app\",\"labelValue\":\"my-id\"}",
+					Spec: kappctrlv1alpha1.AppSpec{
+						SyncPeriod: &metav1.Duration{Duration: (time.Second * 30)},
+					},
+					Status: kappctrlv1alpha1.AppStatus{
+						Deploy: &kappctrlv1alpha1.AppStatusDeploy{
+							Stdout: "deployStdout",
+							Stderr: "deployStderr",
+						},
+						Fetch: &kappctrlv1alpha1.AppStatusFetch{
+							Stdout: "fetchStdout",
+							Stderr: "fetchStderr",
+						},
+						Inspect: &kappctrlv1alpha1.AppStatusInspect{
+							Stdout: "inspectStdout",
+							Stderr: "inspectStderr",
+						},
 					},
 				},
 			},
-			expectedResponse: &corev1.GetInstalledPackageResourceRefsResponse{
-				ResourceRefs: []*corev1.ResourceRef{
-					{
-						ApiVersion: "v1",
-						Kind:       "Pod",
-						Name:       "my-installation-pod",
-						Namespace:  "default",
+			existingTypedObjects: []k8sruntime.Object{
+				&k8scorev1.ConfigMap{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       "ConfigMap",
+						APIVersion: "v1",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "my-installation-ctrl",
+					},
+					Data: map[string]string{
+						"spec": "{\"labelKey\":\"kapp.k14s.io/app\",\"labelValue\":\"my-id\"}",
 					},
 				},
-				Context: defaultContext,
 			},
-		},
-		{
-			name: "returns NotFound if the app configmap is not yet available",
-			request: &corev1.GetInstalledPackageResourceRefsRequest{
+			expectedResponse: &corev1.CreateInstalledPackageResponse{
 				InstalledPackageRef: &corev1.InstalledPackageReference{
 					Context:    defaultContext,
 					Plugin:     &pluginDetail,
 					Identifier: "my-installation",
 				},
 			},
-			existingObjects: []k8sruntime.Object{
-				&packagingv1alpha1.PackageInstall{
-					TypeMeta: metav1.TypeMeta{
-						Kind:       pkgInstallResource,
-						APIVersion: packagingAPIVersion,
-					},
-					ObjectMeta: metav1.ObjectMeta{
-						Namespace: "default",
-						Name:      "my-installation",
-					},
-					Spec: packagingv1alpha1.PackageInstallSpec{
-						ServiceAccountName: "default",
-						PackageRef: &packagingv1alpha1.PackageRef{
-							RefName: "tetris.foo.example.com",
-							VersionSelection: &vendirversions.VersionSelectionSemver{
-								Constraints: "1.2.3",
-							},
-						},
-						Values: []packagingv1alpha1.PackageInstallValues{{
-							SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{
-								Name: "my-installation-default-values",
-							},
-						},
+			expectedPackageInstall: &packagingv1alpha1.PackageInstall{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       pkgInstallResource,
+					APIVersion: packagingAPIVersion,
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      "my-installation",
+				},
+				Spec: packagingv1alpha1.PackageInstallSpec{
+					ServiceAccountName: "default",
+					PackageRef: &packagingv1alpha1.PackageRef{
+						RefName: "tetris.foo.example.com",
+						VersionSelection: &vendirversions.VersionSelectionSemver{
+							Constraints: "1.0.0",
+							Prereleases: &vendirversions.VersionSelectionSemverPrereleases{Identifiers: []string{"rc"}},
 						},
-						Paused:     false,
-						Canceled:   false,
-						SyncPeriod: &metav1.Duration{Duration: (time.Second * 30)},
-						NoopDelete: false,
 					},
-					Status: packagingv1alpha1.PackageInstallStatus{
-						GenericStatus: kappctrlv1alpha1.GenericStatus{
-							ObservedGeneration: 1,
-							Conditions: []kappctrlv1alpha1.Condition{{
-								Type:    kappctrlv1alpha1.ReconcileSucceeded,
-								Status:  k8scorev1.ConditionTrue,
-								Reason:  "baz",
-								Message: "qux",
-							}},
-							FriendlyDescription: "foo",
-							UsefulErrorMessage:  "foo",
+					Values: []packagingv1alpha1.PackageInstallValues{{
+						SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{
+							Name: "my-installation-default-values",
 						},
-						Version:              "1.2.3",
-						LastAttemptedVersion: "1.2.3",
 					},
+					},
+					Paused:     false,
+					Canceled:   false,
+					SyncPeriod: nil,
+					NoopDelete: false,
+				},
+				Status: packagingv1alpha1.PackageInstallStatus{
+					GenericStatus: kappctrlv1alpha1.GenericStatus{
+						ObservedGeneration:  0,
+						Conditions:          nil,
+						FriendlyDescription: "",
+						UsefulErrorMessage:  "",
+					},
+					Version:              "",
+					LastAttemptedVersion: "",
 				},
 			},
-			expectedErrorCode: connect.CodeNotFound,
 		},
 		{
-			name: "returns NotFound if app exists but no resources found",
-			request: &corev1.GetInstalledPackageResourceRefsRequest{
-				InstalledPackageRef: &corev1.InstalledPackageReference{
-					Context:    defaultContext,
+			name: "create installed package (version constraint - upgradePolicy: none)",
+			request: &corev1.CreateInstalledPackageRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context: &corev1.Context{
+						Namespace: "default",
+						Cluster:   "default",
+					},
 					Plugin:     &pluginDetail,
-					Identifier: "my-installation",
+					Identifier: "unknown/tetris.foo.example.com",
+				},
+				PkgVersionReference: &corev1.VersionReference{
+					Version: "1.0.0",
+				},
+				Name: "my-installation",
+				TargetContext: &corev1.Context{
+					Namespace: "default",
+					Cluster:   "default",
+				},
+				ReconciliationOptions: &corev1.ReconciliationOptions{
+					ServiceAccountName: "default",
 				},
 			},
+			pluginConfig: defaultPluginConfig,
 			existingObjects: []k8sruntime.Object{
-				&packagingv1alpha1.PackageInstall{
+				&datapackagingv1alpha1.PackageMetadata{
 					TypeMeta: metav1.TypeMeta{
-						Kind:       pkgInstallResource,
-						APIVersion: packagingAPIVersion,
+						Kind:       pkgMetadataResource,
+						APIVersion: datapackagingAPIVersion,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "tetris.foo.example.com",
+					},
+					Spec: datapackagingv1alpha1.PackageMetadataSpec{
+						DisplayName:        "Classic Tetris",
+						IconSVGBase64:      "Tm90IHJlYWxseSBTVkcK",
+						ShortDescription:   "A great game for arcade gamers",
+						LongDescription:    "A few sentences but not really a readme",
+						Categories:         []string{"logging", "daemon-set"},
+						Maintainers:        []datapackagingv1alpha1.Maintainer{{Name: "person1"}, {Name: "person2"}},
+						SupportDescription: "Some support information",
+						ProviderName:       "Tetris inc.",
+					},
+				},
+				&datapackagingv1alpha1.Package{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       pkgResource,
+						APIVersion: datapackagingAPIVersion,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "tetris.foo.example.com.1.2.3",
+					},
+					Spec: datapackagingv1alpha1.PackageSpec{
+						RefName:                         "tetris.foo.example.com",
+						Version:                         "1.2.3",
+						Licenses:                        []string{"my-license"},
+						ReleaseNotes:                    "release notes",
+						CapactiyRequirementsDescription: "capacity description",
+						ReleasedAt:                      metav1.Time{Time: time.Date(1984, time.June, 6, 0, 0, 0, 0, time.UTC)},
+					},
+				},
+				&kappctrlv1alpha1.App{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       appResource,
+						APIVersion: kappctrlAPIVersion,
 					},
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: "default",
 						Name:      "my-installation",
 					},
-					Spec: packagingv1alpha1.PackageInstallSpec{
-						ServiceAccountName: "default",
-						PackageRef: &packagingv1alpha1.PackageRef{
-							RefName: "tetris.foo.example.com",
-							VersionSelection: &vendirversions.VersionSelectionSemver{
-								Constraints: "1.2.3",
-							},
-						},
-						Values: []packagingv1alpha1.PackageInstallValues{{
-							SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{
-								Name: "my-installation-default-values",
-							},
+					Spec: kappctrlv1alpha1.AppSpec{
+						SyncPeriod: &metav1.Duration{Duration: (time.Second * 30)},
+					},
+					Status: kappctrlv1alpha1.AppStatus{
+						Deploy: &kappctrlv1alpha1.AppStatusDeploy{
+							Stdout: "deployStdout",
+							Stderr: "deployStderr",
 						},
+						Fetch: &kappctrlv1alpha1.AppStatusFetch{
+							Stdout: "fetchStdout",
+							Stderr: "fetchStderr",
 						},
-						Paused:     false,
-						Canceled:   false,
-						SyncPeriod: &metav1.Duration{Duration: time.Second * 30},
-						NoopDelete: false,
-					},
-					Status: packagingv1alpha1.PackageInstallStatus{
-						GenericStatus: kappctrlv1alpha1.GenericStatus{
-							ObservedGeneration: 1,
-							Conditions: []kappctrlv1alpha1.Condition{{
-								Type:    kappctrlv1alpha1.ReconcileSucceeded,
-								Status:  k8scorev1.ConditionTrue,
-								Reason:  "baz",
-								Message: "qux",
-							}},
-							FriendlyDescription: "foo",
-							UsefulErrorMessage:  "foo",
+						Inspect: &kappctrlv1alpha1.AppStatusInspect{
+							Stdout: "inspectStdout",
+							Stderr: "inspectStderr",
 						},
-						Version:              "1.2.3",
-						LastAttemptedVersion: "1.2.3",
 					},
 				},
 			},
@@ -6400,27 +7221,3929 @@ func TestGetInstalledPackageResourceRefs(t *testing.T) {
 					},
 				},
 			},
-			expectedErrorCode: connect.CodeNotFound,
-		},
-	}
-
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			var unstructuredObjects []k8sruntime.Object
-			for _, obj := range tc.existingObjects {
-				unstructuredContent, _ := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(obj)
-				unstructuredObjects = append(unstructuredObjects, &unstructured.Unstructured{Object: unstructuredContent})
-			}
-			// If more resources types are added, this will need to be updated accordingly
-			apiResources := []*metav1.APIResourceList{
-				{
-					GroupVersion: "v1",
-					APIResources: []metav1.APIResource{
-						{Name: "pods", Namespaced: true, Kind: "Pod", Verbs: []string{"list", "get"}},
-						{Name: "configmaps", Namespaced: true, Kind: "ConfigMap", Verbs: []string{"list", "get"}},
-					},
+			expectedResponse: &corev1.CreateInstalledPackageResponse{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context:    defaultContext,
+					Plugin:     &pluginDetail,
+					Identifier: "my-installation",
 				},
-			}
+			},
+			expectedPackageInstall: &packagingv1alpha1.PackageInstall{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       pkgInstallResource,
+					APIVersion: packagingAPIVersion,
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      "my-installation",
+				},
+				Spec: packagingv1alpha1.PackageInstallSpec{
+					ServiceAccountName: "default",
+					PackageRef: &packagingv1alpha1.PackageRef{
+						RefName: "tetris.foo.example.com",
+						VersionSelection: &vendirversions.VersionSelectionSemver{
+							Constraints: "1.0.0",
+						},
+					},
+					Values: []packagingv1alpha1.PackageInstallValues{{
+						SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{
+							Name: "my-installation-default-values",
+						},
+					},
+					},
+					Paused:     false,
+					Canceled:   false,
+					SyncPeriod: nil,
+					NoopDelete: false,
+				},
+				Status: packagingv1alpha1.PackageInstallStatus{
+					GenericStatus: kappctrlv1alpha1.GenericStatus{
+						ObservedGeneration:  0,
+						Conditions:          nil,
+						FriendlyDescription: "",
+						UsefulErrorMessage:  "",
+					},
+					Version:              "",
+					LastAttemptedVersion: "",
+				},
+			},
+		},
+		{
+			name: "create installed package (version constraint - upgradePolicy: major)",
+			request: &corev1.CreateInstalledPackageRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context: &corev1.Context{
+						Namespace: "default",
+						Cluster:   "default",
+					},
+					Plugin:     &pluginDetail,
+					Identifier: "unknown/tetris.foo.example.com",
+				},
+				PkgVersionReference: &corev1.VersionReference{
+					Version: "1.0.0",
+				},
+				Name: "my-installation",
+				TargetContext: &corev1.Context{
+					Namespace: "default",
+					Cluster:   "default",
+				},
+				ReconciliationOptions: &corev1.ReconciliationOptions{
+					ServiceAccountName: "default",
+				},
+			},
+			pluginConfig: &kappControllerPluginParsedConfig{
+				defaultUpgradePolicy:               pkgutils.UpgradePolicyMajor,
+				defaultPrereleasesVersionSelection: defaultPluginConfig.defaultPrereleasesVersionSelection,
+				defaultAllowDowngrades:             defaultPluginConfig.defaultAllowDowngrades,
+			},
+			existingObjects: []k8sruntime.Object{
+				&datapackagingv1alpha1.PackageMetadata{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       pkgMetadataResource,
+						APIVersion: datapackagingAPIVersion,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "tetris.foo.example.com",
+					},
+					Spec: datapackagingv1alpha1.PackageMetadataSpec{
+						DisplayName:        "Classic Tetris",
+						IconSVGBase64:      "Tm90IHJlYWxseSBTVkcK",
+						ShortDescription:   "A great game for arcade gamers",
+						LongDescription:    "A few sentences but not really a readme",
+						Categories:         []string{"logging", "daemon-set"},
+						Maintainers:        []datapackagingv1alpha1.Maintainer{{Name: "person1"}, {Name: "person2"}},
+						SupportDescription: "Some support information",
+						ProviderName:       "Tetris inc.",
+					},
+				},
+				&datapackagingv1alpha1.Package{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       pkgResource,
+						APIVersion: datapackagingAPIVersion,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "tetris.foo.example.com.1.2.3",
+					},
+					Spec: datapackagingv1alpha1.PackageSpec{
+						RefName:                         "tetris.foo.example.com",
+						Version:                         "1.2.3",
+						Licenses:                        []string{"my-license"},
+						ReleaseNotes:                    "release notes",
+						CapactiyRequirementsDescription: "capacity description",
+						ReleasedAt:                      metav1.Time{Time: time.Date(1984, time.June, 6, 0, 0, 0, 0, time.UTC)},
+					},
+				},
+				&kappctrlv1alpha1.App{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       appResource,
+						APIVersion: kappctrlAPIVersion,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "my-installation",
+					},
+					Spec: kappctrlv1alpha1.AppSpec{
+						SyncPeriod: &metav1.Duration{Duration: (time.Second * 30)},
+					},
+					Status: kappctrlv1alpha1.AppStatus{
+						Deploy: &kappctrlv1alpha1.AppStatusDeploy{
+							Stdout: "deployStdout",
+							Stderr: "deployStderr",
+						},
+						Fetch: &kappctrlv1alpha1.AppStatusFetch{
+							Stdout: "fetchStdout",
+							Stderr: "fetchStderr",
+						},
+						Inspect: &kappctrlv1alpha1.AppStatusInspect{
+							Stdout: "inspectStdout",
+							Stderr: "inspectStderr",
+						},
+					},
+				},
+			},
+			existingTypedObjects: []k8sruntime.Object{
+				&k8scorev1.ConfigMap{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       "ConfigMap",
+						APIVersion: "v1",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "my-installation-ctrl",
+					},
+					Data: map[string]string{
+						"spec": "{\"labelKey\":\"kapp.k14s.io/app\",\"labelValue\":\"my-id\"}",
+					},
+				},
+			},
+			expectedResponse: &corev1.CreateInstalledPackageResponse{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context:    defaultContext,
+					Plugin:     &pluginDetail,
+					Identifier: "my-installation",
+				},
+			},
+			expectedPackageInstall: &packagingv1alpha1.PackageInstall{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       pkgInstallResource,
+					APIVersion: packagingAPIVersion,
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      "my-installation",
+				},
+				Spec: packagingv1alpha1.PackageInstallSpec{
+					ServiceAccountName: "default",
+					PackageRef: &packagingv1alpha1.PackageRef{
+						RefName: "tetris.foo.example.com",
+						VersionSelection: &vendirversions.VersionSelectionSemver{
+							Constraints: ">=1.0.0",
+						},
+					},
+					Values: []packagingv1alpha1.PackageInstallValues{{
+						SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{
+							Name: "my-installation-default-values",
+						},
+					},
+					},
+					Paused:     false,
+					Canceled:   false,
+					SyncPeriod: nil,
+					NoopDelete: false,
+				},
+				Status: packagingv1alpha1.PackageInstallStatus{
+					GenericStatus: kappctrlv1alpha1.GenericStatus{
+						ObservedGeneration:  0,
+						Conditions:          nil,
+						FriendlyDescription: "",
+						UsefulErrorMessage:  "",
+					},
+					Version:              "",
+					LastAttemptedVersion: "",
+				},
+			},
+		},
+		{
+			name: "create installed package (version constraint - upgradePolicy: minor)",
+			request: &corev1.CreateInstalledPackageRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context: &corev1.Context{
+						Namespace: "default",
+						Cluster:   "default",
+					},
+					Plugin:     &pluginDetail,
+					Identifier: "unknown/tetris.foo.example.com",
+				},
+				PkgVersionReference: &corev1.VersionReference{
+					Version: "1.0.0",
+				},
+				Name: "my-installation",
+				TargetContext: &corev1.Context{
+					Namespace: "default",
+					Cluster:   "default",
+				},
+				ReconciliationOptions: &corev1.ReconciliationOptions{
+					ServiceAccountName: "default",
+				},
+			},
+			pluginConfig: &kappControllerPluginParsedConfig{
+				defaultUpgradePolicy:               pkgutils.UpgradePolicyMinor,
+				defaultPrereleasesVersionSelection: defaultPluginConfig.defaultPrereleasesVersionSelection,
+				defaultAllowDowngrades:             defaultPluginConfig.defaultAllowDowngrades,
+			},
+			existingObjects: []k8sruntime.Object{
+				&datapackagingv1alpha1.PackageMetadata{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       pkgMetadataResource,
+						APIVersion: datapackagingAPIVersion,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "tetris.foo.example.com",
+					},
+					Spec: datapackagingv1alpha1.PackageMetadataSpec{
+						DisplayName:        "Classic Tetris",
+						IconSVGBase64:      "Tm90IHJlYWxseSBTVkcK",
+						ShortDescription:   "A great game for arcade gamers",
+						LongDescription:    "A few sentences but not really a readme",
+						Categories:         []string{"logging", "daemon-set"},
+						Maintainers:        []datapackagingv1alpha1.Maintainer{{Name: "person1"}, {Name: "person2"}},
+						SupportDescription: "Some support information",
+						ProviderName:       "Tetris inc.",
+					},
+				},
+				&datapackagingv1alpha1.Package{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       pkgResource,
+						APIVersion: datapackagingAPIVersion,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "tetris.foo.example.com.1.2.3",
+					},
+					Spec: datapackagingv1alpha1.PackageSpec{
+						RefName:                         "tetris.foo.example.com",
+						Version:                         "1.2.3",
+						Licenses:                        []string{"my-license"},
+						ReleaseNotes:                    "release notes",
+						CapactiyRequirementsDescription: "capacity description",
+						ReleasedAt:                      metav1.Time{Time: time.Date(1984, time.June, 6, 0, 0, 0, 0, time.UTC)},
+					},
+				},
+				&kappctrlv1alpha1.App{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       appResource,
+						APIVersion: kappctrlAPIVersion,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "my-installation",
+					},
+					Spec: kappctrlv1alpha1.AppSpec{
+						SyncPeriod: &metav1.Duration{Duration: (time.Second * 30)},
+					},
+					Status: kappctrlv1alpha1.AppStatus{
+						Deploy: &kappctrlv1alpha1.AppStatusDeploy{
+							Stdout: "deployStdout",
+							Stderr: "deployStderr",
+						},
+						Fetch: &kappctrlv1alpha1.AppStatusFetch{
+							Stdout: "fetchStdout",
+							Stderr: "fetchStderr",
+						},
+						Inspect: &kappctrlv1alpha1.AppStatusInspect{
+							Stdout: "inspectStdout",
+							Stderr: "inspectStderr",
+						},
+					},
+				},
+			},
+			existingTypedObjects: []k8sruntime.Object{
+				&k8scorev1.ConfigMap{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       "ConfigMap",
+						APIVersion: "v1",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "my-installation-ctrl",
+					},
+					Data: map[string]string{
+						"spec": "{\"labelKey\":\"kapp.k14s.io/app\",\"labelValue\":\"my-id\"}",
+					},
+				},
+			},
+			expectedResponse: &corev1.CreateInstalledPackageResponse{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context:    defaultContext,
+					Plugin:     &pluginDetail,
+					Identifier: "my-installation",
+				},
+			},
+			expectedPackageInstall: &packagingv1alpha1.PackageInstall{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       pkgInstallResource,
+					APIVersion: packagingAPIVersion,
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      "my-installation",
+				},
+				Spec: packagingv1alpha1.PackageInstallSpec{
+					ServiceAccountName: "default",
+					PackageRef: &packagingv1alpha1.PackageRef{
+						RefName: "tetris.foo.example.com",
+						VersionSelection: &vendirversions.VersionSelectionSemver{
+							Constraints: ">=1.0.0 <2.0.0",
+						},
+					},
+					Values: []packagingv1alpha1.PackageInstallValues{{
+						SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{
+							Name: "my-installation-default-values",
+						},
+					},
+					},
+					Paused:     false,
+					Canceled:   false,
+					SyncPeriod: nil,
+					NoopDelete: false,
+				},
+				Status: packagingv1alpha1.PackageInstallStatus{
+					GenericStatus: kappctrlv1alpha1.GenericStatus{
+						ObservedGeneration:  0,
+						Conditions:          nil,
+						FriendlyDescription: "",
+						UsefulErrorMessage:  "",
+					},
+					Version:              "",
+					LastAttemptedVersion: "",
+				},
+			},
+		},
+		{
+			name: "create installed package (version constraint - upgradePolicy: patch)",
+			request: &corev1.CreateInstalledPackageRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context: &corev1.Context{
+						Namespace: "default",
+						Cluster:   "default",
+					},
+					Plugin:     &pluginDetail,
+					Identifier: "unknown/tetris.foo.example.com",
+				},
+				PkgVersionReference: &corev1.VersionReference{
+					Version: "1.0.0",
+				},
+				Name: "my-installation",
+				TargetContext: &corev1.Context{
+					Namespace: "default",
+					Cluster:   "default",
+				},
+				ReconciliationOptions: &corev1.ReconciliationOptions{
+					ServiceAccountName: "default",
+				},
+			},
+			pluginConfig: &kappControllerPluginParsedConfig{
+				defaultUpgradePolicy:               pkgutils.UpgradePolicyPatch,
+				defaultPrereleasesVersionSelection: defaultPluginConfig.defaultPrereleasesVersionSelection,
+				defaultAllowDowngrades:             defaultPluginConfig.defaultAllowDowngrades,
+			},
+			existingObjects: []k8sruntime.Object{
+				&datapackagingv1alpha1.PackageMetadata{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       pkgMetadataResource,
+						APIVersion: datapackagingAPIVersion,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "tetris.foo.example.com",
+					},
+					Spec: datapackagingv1alpha1.PackageMetadataSpec{
+						DisplayName:        "Classic Tetris",
+						IconSVGBase64:      "Tm90IHJlYWxseSBTVkcK",
+						ShortDescription:   "A great game for arcade gamers",
+						LongDescription:    "A few sentences but not really a readme",
+						Categories:         []string{"logging", "daemon-set"},
+						Maintainers:        []datapackagingv1alpha1.Maintainer{{Name: "person1"}, {Name: "person2"}},
+						SupportDescription: "Some support information",
+						ProviderName:       "Tetris inc.",
+					},
+				},
+				&datapackagingv1alpha1.Package{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       pkgResource,
+						APIVersion: datapackagingAPIVersion,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "tetris.foo.example.com.1.2.3",
+					},
+					Spec: datapackagingv1alpha1.PackageSpec{
+						RefName:                         "tetris.foo.example.com",
+						Version:                         "1.2.3",
+						Licenses:                        []string{"my-license"},
+						ReleaseNotes:                    "release notes",
+						CapactiyRequirementsDescription: "capacity description",
+						ReleasedAt:                      metav1.Time{Time: time.Date(1984, time.June, 6, 0, 0, 0, 0, time.UTC)},
+					},
+				},
+				&kappctrlv1alpha1.App{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       appResource,
+						APIVersion: kappctrlAPIVersion,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "my-installation",
+					},
+					Spec: kappctrlv1alpha1.AppSpec{
+						SyncPeriod: &metav1.Duration{Duration: (time.Second * 30)},
+					},
+					Status: kappctrlv1alpha1.AppStatus{
+						Deploy: &kappctrlv1alpha1.AppStatusDeploy{
+							Stdout: "deployStdout",
+							Stderr: "deployStderr",
+						},
+						Fetch: &kappctrlv1alpha1.AppStatusFetch{
+							Stdout: "fetchStdout",
+							Stderr: "fetchStderr",
+						},
+						Inspect: &kappctrlv1alpha1.AppStatusInspect{
+							Stdout: "inspectStdout",
+							Stderr: "inspectStderr",
+						},
+					},
+				},
+			},
+			existingTypedObjects: []k8sruntime.Object{
+				&k8scorev1.ConfigMap{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       "ConfigMap",
+						APIVersion: "v1",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "my-installation-ctrl",
+					},
+					Data: map[string]string{
+						"spec": "{\"labelKey\":\"kapp.k14s.io/app\",\"labelValue\":\"my-id\"}",
+					},
+				},
+			},
+			expectedResponse: &corev1.CreateInstalledPackageResponse{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context:    defaultContext,
+					Plugin:     &pluginDetail,
+					Identifier: "my-installation",
+				},
+			},
+			expectedPackageInstall: &packagingv1alpha1.PackageInstall{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       pkgInstallResource,
+					APIVersion: packagingAPIVersion,
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      "my-installation",
+				},
+				Spec: packagingv1alpha1.PackageInstallSpec{
+					ServiceAccountName: "default",
+					PackageRef: &packagingv1alpha1.PackageRef{
+						RefName: "tetris.foo.example.com",
+						VersionSelection: &vendirversions.VersionSelectionSemver{
+							Constraints: ">=1.0.0 <1.1.0",
+						},
+					},
+					Values: []packagingv1alpha1.PackageInstallValues{{
+						SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{
+							Name: "my-installation-default-values",
+						},
+					},
+					},
+					Paused:     false,
+					Canceled:   false,
+					SyncPeriod: nil,
+					NoopDelete: false,
+				},
+				Status: packagingv1alpha1.PackageInstallStatus{
+					GenericStatus: kappctrlv1alpha1.GenericStatus{
+						ObservedGeneration:  0,
+						Conditions:          nil,
+						FriendlyDescription: "",
+						UsefulErrorMessage:  "",
+					},
+					Version:              "",
+					LastAttemptedVersion: "",
+				},
+			},
+		},
+		{
+			name: "create installed package (defaultAllowDowngrades: true)",
+			request: &corev1.CreateInstalledPackageRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context: &corev1.Context{
+						Namespace: "default",
+						Cluster:   "default",
+					},
+					Plugin:     &pluginDetail,
+					Identifier: "unknown/tetris.foo.example.com",
+				},
+				PkgVersionReference: &corev1.VersionReference{
+					Version: "1.0.0",
+				},
+				Name: "my-installation",
+				TargetContext: &corev1.Context{
+					Namespace: "default",
+					Cluster:   "default",
+				},
+				ReconciliationOptions: &corev1.ReconciliationOptions{
+					ServiceAccountName: "default",
+				},
+			},
+			pluginConfig: &kappControllerPluginParsedConfig{
+				defaultUpgradePolicy:               defaultPluginConfig.defaultUpgradePolicy,
+				defaultPrereleasesVersionSelection: defaultPluginConfig.defaultPrereleasesVersionSelection,
+				defaultAllowDowngrades:             true,
+			},
+			existingObjects: []k8sruntime.Object{
+				&datapackagingv1alpha1.PackageMetadata{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       pkgMetadataResource,
+						APIVersion: datapackagingAPIVersion,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "tetris.foo.example.com",
+					},
+					Spec: datapackagingv1alpha1.PackageMetadataSpec{
+						DisplayName:        "Classic Tetris",
+						IconSVGBase64:      "Tm90IHJlYWxseSBTVkcK",
+						ShortDescription:   "A great game for arcade gamers",
+						LongDescription:    "A few sentences but not really a readme",
+						Categories:         []string{"logging", "daemon-set"},
+						Maintainers:        []datapackagingv1alpha1.Maintainer{{Name: "person1"}, {Name: "person2"}},
+						SupportDescription: "Some support information",
+						ProviderName:       "Tetris inc.",
+					},
+				},
+				&datapackagingv1alpha1.Package{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       pkgResource,
+						APIVersion: datapackagingAPIVersion,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "tetris.foo.example.com.1.2.3",
+					},
+					Spec: datapackagingv1alpha1.PackageSpec{
+						RefName:                         "tetris.foo.example.com",
+						Version:                         "1.2.3",
+						Licenses:                        []string{"my-license"},
+						ReleaseNotes:                    "release notes",
+						CapactiyRequirementsDescription: "capacity description",
+						ReleasedAt:                      metav1.Time{Time: time.Date(1984, time.June, 6, 0, 0, 0, 0, time.UTC)},
+					},
+				},
+				&kappctrlv1alpha1.App{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       appResource,
+						APIVersion: kappctrlAPIVersion,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "my-installation",
+					},
+					Spec: kappctrlv1alpha1.AppSpec{
+						SyncPeriod: &metav1.Duration{Duration: (time.Second * 30)},
+					},
+					Status: kappctrlv1alpha1.AppStatus{
+						Deploy: &kappctrlv1alpha1.AppStatusDeploy{
+							Stdout: "deployStdout",
+							Stderr: "deployStderr",
+						},
+						Fetch: &kappctrlv1alpha1.AppStatusFetch{
+							Stdout: "fetchStdout",
+							Stderr: "fetchStderr",
+						},
+						Inspect: &kappctrlv1alpha1.AppStatusInspect{
+							Stdout: "inspectStdout",
+							Stderr: "inspectStderr",
+						},
+					},
+				},
+			},
+			existingTypedObjects: []k8sruntime.Object{
+				&k8scorev1.ConfigMap{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       "ConfigMap",
+						APIVersion: "v1",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "my-installation-ctrl",
+					},
+					Data: map[string]string{
+						"spec": "{\"labelKey\":\"kapp.k14s.io/app\",\"labelValue\":\"my-id\"}",
+					},
+				},
+			},
+			expectedResponse: &corev1.CreateInstalledPackageResponse{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context:    defaultContext,
+					Plugin:     &pluginDetail,
+					Identifier: "my-installation",
+				},
+			},
+			expectedPackageInstall: &packagingv1alpha1.PackageInstall{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       pkgInstallResource,
+					APIVersion: packagingAPIVersion,
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "default",
+					Name:        "my-installation",
+					Annotations: map[string]string{kappctrlpackageinstall.DowngradableAnnKey: ""},
+				},
+				Spec: packagingv1alpha1.PackageInstallSpec{
+					ServiceAccountName: "default",
+					PackageRef: &packagingv1alpha1.PackageRef{
+						RefName: "tetris.foo.example.com",
+						VersionSelection: &vendirversions.VersionSelectionSemver{
+							Constraints: "1.0.0",
+						},
+					},
+					Values: []packagingv1alpha1.PackageInstallValues{{
+						SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{
+							Name: "my-installation-default-values",
+						},
+					},
+					},
+					Paused:     false,
+					Canceled:   false,
+					SyncPeriod: nil,
+					NoopDelete: false,
+				},
+				Status: packagingv1alpha1.PackageInstallStatus{
+					GenericStatus: kappctrlv1alpha1.GenericStatus{
+						ObservedGeneration:  0,
+						Conditions:          nil,
+						FriendlyDescription: "",
+						UsefulErrorMessage:  "",
+					},
+					Version:              "",
+					LastAttemptedVersion: "",
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var unstructuredObjects []k8sruntime.Object
+			for _, obj := range tc.existingObjects {
+				unstructuredContent, _ := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(obj)
+				unstructuredObjects = append(unstructuredObjects, &unstructured.Unstructured{Object: unstructuredContent})
+			}
+
+			dynamicClient := dynfake.NewSimpleDynamicClientWithCustomListKinds(
+				k8sruntime.NewScheme(),
+				map[schema.GroupVersionResource]string{
+					{Group: datapackagingv1alpha1.SchemeGroupVersion.Group, Version: datapackagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgsResource}:         pkgResource + "List",
+					{Group: datapackagingv1alpha1.SchemeGroupVersion.Group, Version: datapackagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgMetadatasResource}: pkgMetadataResource + "List",
+					{Group: packagingv1alpha1.SchemeGroupVersion.Group, Version: packagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgRepositoriesResource}:      pkgRepositoryResource + "List",
+					{Group: packagingv1alpha1.SchemeGroupVersion.Group, Version: packagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgInstallsResource}:          pkgInstallResource + "List",
+				},
+				unstructuredObjects...,
+			)
+
+			typedClient := typfake.NewSimpleClientset(tc.existingTypedObjects...)
+			s := Server{
+				pluginConfig: tc.pluginConfig,
+				clientGetter: clientgetter.NewBuilder().
+					WithTyped(typedClient).
+					WithDynamic(dynamicClient).
+					Build(),
+			}
+
+			createInstalledPackageResponse, err := s.CreateInstalledPackage(context.Background(), connect.NewRequest(tc.request))
+
+			if got, want := connect.CodeOf(err), tc.expectedErrorCode; err != nil && got != want {
+				t.Fatalf("got: %d, want: %d, err: %+v", got, want, err)
+			}
+			// If we were expecting an error, continue to the next test.
+			if tc.expectedErrorCode != 0 {
+				return
+			}
+			if tc.expectedResponse != nil {
+				if got, want := createInstalledPackageResponse.Msg, tc.expectedResponse; !cmp.Equal(want, got, ignoreUnexported) {
+					t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexported))
+				}
+			}
+			if tc.expectedPackageInstall != nil {
+				createdPkgInstall, err := s.getPkgInstall(context.Background(), http.Header{}, "default", tc.request.TargetContext.Namespace, createInstalledPackageResponse.Msg.InstalledPackageRef.Identifier)
+				if err != nil {
+					t.Fatalf("%+v", err)
+				}
+
+				if got, want := createdPkgInstall, tc.expectedPackageInstall; !cmp.Equal(want, got, ignoreUnexported) {
+					t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexported))
+				}
+			}
+			if tc.expectedRoleBinding != nil {
+				roleBinding, err := typedClient.RbacV1().RoleBindings(tc.expectedRoleBinding.Namespace).Get(context.Background(), tc.expectedRoleBinding.Name, metav1.GetOptions{})
+				if err != nil {
+					t.Fatalf("%+v", err)
+				}
+				if got, want := roleBinding, tc.expectedRoleBinding; !cmp.Equal(want, got, ignoreUnexported) {
+					t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexported))
+				}
+				if _, err := typedClient.CoreV1().ServiceAccounts(tc.expectedRoleBinding.Namespace).Get(context.Background(), tc.expectedRoleBinding.Subjects[0].Name, metav1.GetOptions{}); err != nil {
+					t.Fatalf("%+v", err)
+				}
+			}
+			if tc.expectedImagePullSecret != nil {
+				imagePullSecret, err := typedClient.CoreV1().Secrets(tc.expectedImagePullSecret.Namespace).Get(context.Background(), tc.expectedImagePullSecret.Name, metav1.GetOptions{})
+				if err != nil {
+					t.Fatalf("%+v", err)
+				}
+				if got, want := imagePullSecret, tc.expectedImagePullSecret; !cmp.Equal(want, got, ignoreUnexported) {
+					t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexported))
+				}
+			}
+		})
+	}
+}
+
+func TestCreateInstalledPackageCreatesNamespace(t *testing.T) {
+	testCases := []struct {
+		name                    string
+		allowed                 bool
+		createPkgInstallErr     error
+		expectedErrorCode       connect.Code
+		expectedNamespace       bool
+		expectedNamespaceLabels map[string]string
+	}{
+		{
+			name:                    "it creates the target namespace with the configured labels",
+			allowed:                 true,
+			expectedNamespace:       true,
+			expectedNamespaceLabels: map[string]string{"pod-security.kubernetes.io/enforce": "baseline"},
+		},
+		{
+			name:              "it returns permission denied if the user cannot create namespaces",
+			allowed:           false,
+			expectedErrorCode: connect.CodePermissionDenied,
+		},
+		{
+			name:                "it deletes the created namespace if the PackageInstall cannot be created",
+			allowed:             true,
+			createPkgInstallErr: errors.New("boom"),
+			expectedErrorCode:   connect.CodeInternal,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var unstructuredObjects []k8sruntime.Object
+			for _, obj := range []k8sruntime.Object{
+				&datapackagingv1alpha1.PackageMetadata{
+					TypeMeta:   metav1.TypeMeta{Kind: pkgMetadataResource, APIVersion: datapackagingAPIVersion},
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "tetris.foo.example.com"},
+				},
+				&datapackagingv1alpha1.Package{
+					TypeMeta:   metav1.TypeMeta{Kind: pkgResource, APIVersion: datapackagingAPIVersion},
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "tetris.foo.example.com.1.2.3"},
+					Spec:       datapackagingv1alpha1.PackageSpec{RefName: "tetris.foo.example.com", Version: "1.2.3"},
+				},
+			} {
+				unstructuredContent, _ := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(obj)
+				unstructuredObjects = append(unstructuredObjects, &unstructured.Unstructured{Object: unstructuredContent})
+			}
+			dynamicClient := dynfake.NewSimpleDynamicClientWithCustomListKinds(
+				k8sruntime.NewScheme(),
+				map[schema.GroupVersionResource]string{
+					{Group: datapackagingv1alpha1.SchemeGroupVersion.Group, Version: datapackagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgsResource}:         pkgResource + "List",
+					{Group: datapackagingv1alpha1.SchemeGroupVersion.Group, Version: datapackagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgMetadatasResource}: pkgMetadataResource + "List",
+					{Group: packagingv1alpha1.SchemeGroupVersion.Group, Version: packagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgRepositoriesResource}:      pkgRepositoryResource + "List",
+					{Group: packagingv1alpha1.SchemeGroupVersion.Group, Version: packagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgInstallsResource}:          pkgInstallResource + "List",
+				},
+				unstructuredObjects...,
+			)
+			if tc.createPkgInstallErr != nil {
+				dynamicClient.PrependReactor("create", pkgInstallsResource, func(action k8stesting.Action) (handled bool, ret k8sruntime.Object, err error) {
+					return true, nil, tc.createPkgInstallErr
+				})
+			}
+			typedClient := typfake.NewSimpleClientset()
+			typedClient.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (handled bool, ret k8sruntime.Object, err error) {
+				return true, &authorizationv1.SelfSubjectAccessReview{Status: authorizationv1.SubjectAccessReviewStatus{Allowed: tc.allowed}}, nil
+			})
+
+			pluginConfig := *defaultPluginConfig
+			pluginConfig.namespaceCreation = resources.NamespaceCreationOptions{
+				Labels: map[string]string{"pod-security.kubernetes.io/enforce": "baseline"},
+			}
+			s := Server{
+				pluginConfig: &pluginConfig,
+				clientGetter: clientgetter.NewBuilder().
+					WithTyped(typedClient).
+					WithDynamic(dynamicClient).
+					Build(),
+			}
+
+			_, err := s.CreateInstalledPackage(context.Background(), connect.NewRequest(&corev1.CreateInstalledPackageRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context:    defaultContext,
+					Plugin:     &pluginDetail,
+					Identifier: "unknown/tetris.foo.example.com",
+				},
+				PkgVersionReference: &corev1.VersionReference{Version: "1.2.3"},
+				Name:                "my-installation",
+				TargetContext:       &corev1.Context{Namespace: "team-a", Cluster: "default"},
+				ReconciliationOptions: &corev1.ReconciliationOptions{
+					ServiceAccountName: "default",
+				},
+				CreateNamespace: true,
+			}))
+			if got, want := connect.CodeOf(err), tc.expectedErrorCode; err != nil && got != want {
+				t.Fatalf("got: %d, want: %d, err: %+v", got, want, err)
+			}
+			if err == nil && tc.expectedErrorCode != 0 {
+				t.Fatalf("got: nil, want: %d", tc.expectedErrorCode)
+			}
+
+			namespace, err := typedClient.CoreV1().Namespaces().Get(context.Background(), "team-a", metav1.GetOptions{})
+			if !tc.expectedNamespace {
+				if !k8sErrors.IsNotFound(err) {
+					t.Fatalf("got: %v, want: not found", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if got, want := namespace.Labels, tc.expectedNamespaceLabels; !cmp.Equal(want, got) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
+func TestCreateInstalledPackageCleansUpWhenOwnerCannotBeSet(t *testing.T) {
+	var unstructuredObjects []k8sruntime.Object
+	for _, obj := range []k8sruntime.Object{
+		&datapackagingv1alpha1.PackageMetadata{
+			TypeMeta:   metav1.TypeMeta{Kind: pkgMetadataResource, APIVersion: datapackagingAPIVersion},
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "tetris.foo.example.com"},
+		},
+		&datapackagingv1alpha1.Package{
+			TypeMeta:   metav1.TypeMeta{Kind: pkgResource, APIVersion: datapackagingAPIVersion},
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "tetris.foo.example.com.1.2.3"},
+			Spec:       datapackagingv1alpha1.PackageSpec{RefName: "tetris.foo.example.com", Version: "1.2.3"},
+		},
+	} {
+		unstructuredContent, _ := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		unstructuredObjects = append(unstructuredObjects, &unstructured.Unstructured{Object: unstructuredContent})
+	}
+	dynamicClient := dynfake.NewSimpleDynamicClientWithCustomListKinds(
+		k8sruntime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			{Group: datapackagingv1alpha1.SchemeGroupVersion.Group, Version: datapackagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgsResource}:         pkgResource + "List",
+			{Group: datapackagingv1alpha1.SchemeGroupVersion.Group, Version: datapackagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgMetadatasResource}: pkgMetadataResource + "List",
+			{Group: packagingv1alpha1.SchemeGroupVersion.Group, Version: packagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgRepositoriesResource}:      pkgRepositoryResource + "List",
+			{Group: packagingv1alpha1.SchemeGroupVersion.Group, Version: packagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgInstallsResource}:          pkgInstallResource + "List",
+		},
+		unstructuredObjects...,
+	)
+	typedClient := typfake.NewSimpleClientset()
+	typedClient.PrependReactor("update", "serviceaccounts", func(action k8stesting.Action) (handled bool, ret k8sruntime.Object, err error) {
+		return true, nil, errors.New("boom")
+	})
+
+	s := Server{
+		pluginConfig: &kappControllerPluginParsedConfig{
+			defaultUpgradePolicy:        defaultPluginConfig.defaultUpgradePolicy,
+			allowServiceAccountCreation: true,
+			serviceAccountRoleTemplate:  serviceAccountRoleTemplateRestricted,
+		},
+		clientGetter: clientgetter.NewBuilder().
+			WithTyped(typedClient).
+			WithDynamic(dynamicClient).
+			Build(),
+	}
+
+	_, err := s.CreateInstalledPackage(context.Background(), connect.NewRequest(&corev1.CreateInstalledPackageRequest{
+		AvailablePackageRef: &corev1.AvailablePackageReference{
+			Context:    defaultContext,
+			Plugin:     &pluginDetail,
+			Identifier: "unknown/tetris.foo.example.com",
+		},
+		PkgVersionReference: &corev1.VersionReference{Version: "1.2.3"},
+		Name:                "my-installation",
+		TargetContext:       &corev1.Context{Namespace: "default", Cluster: "default"},
+		Values:              "foo: bar",
+		CustomDetail: func() *anypb.Any {
+			customDetail, _ := anypb.New(&kappcorev1.KappControllerInstalledPackageCustomDetail{
+				CreateServiceAccount: true,
+			})
+			return customDetail
+		}(),
+	}))
+	if got, want := connect.CodeOf(err), connect.CodeInternal; err == nil || got != want {
+		t.Fatalf("got: %d, want: %d, err: %+v", got, want, err)
+	}
+
+	pkgInstalls, err := dynamicClient.Resource(packagingv1alpha1.SchemeGroupVersion.WithResource(pkgInstallsResource)).Namespace("default").List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if got := len(pkgInstalls.Items); got != 0 {
+		t.Errorf("got: %d PackageInstalls, want: 0", got)
+	}
+	secrets, err := typedClient.CoreV1().Secrets("default").List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if got := len(secrets.Items); got != 0 {
+		t.Errorf("got: %d Secrets, want: 0", got)
+	}
+	serviceAccounts, err := typedClient.CoreV1().ServiceAccounts("default").List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if got := len(serviceAccounts.Items); got != 0 {
+		t.Errorf("got: %d ServiceAccounts, want: 0", got)
+	}
+}
+
+func TestCreateInstalledPackageImportsImagePullSecret(t *testing.T) {
+	secretImportGVR := secretgenGroupVersion.WithResource(secretImportsResource)
+	otherOwnerReference := metav1.OwnerReference{
+		APIVersion: packagingAPIVersion,
+		Kind:       pkgInstallResource,
+		Name:       "other-installation",
+		UID:        "other-installation",
+	}
+	ownerReference := metav1.OwnerReference{
+		APIVersion:         packagingAPIVersion,
+		Kind:               pkgInstallResource,
+		Name:               "my-installation",
+		BlockOwnerDeletion: func() *bool { v := true; return &v }(),
+	}
+
+	testCases := []struct {
+		name                    string
+		existingSecretImport    bool
+		expectedOwnerReferences []metav1.OwnerReference
+	}{
+		{
+			name:                    "it creates the SecretImport of the configured registry credentials",
+			expectedOwnerReferences: []metav1.OwnerReference{ownerReference},
+		},
+		{
+			name:                    "it shares the existing SecretImport of the namespace",
+			existingSecretImport:    true,
+			expectedOwnerReferences: []metav1.OwnerReference{otherOwnerReference, ownerReference},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var unstructuredObjects []k8sruntime.Object
+			for _, obj := range []k8sruntime.Object{
+				&datapackagingv1alpha1.PackageMetadata{
+					TypeMeta:   metav1.TypeMeta{Kind: pkgMetadataResource, APIVersion: datapackagingAPIVersion},
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "tetris.foo.example.com"},
+				},
+				&datapackagingv1alpha1.Package{
+					TypeMeta:   metav1.TypeMeta{Kind: pkgResource, APIVersion: datapackagingAPIVersion},
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "tetris.foo.example.com.1.2.3"},
+					Spec:       datapackagingv1alpha1.PackageSpec{RefName: "tetris.foo.example.com", Version: "1.2.3"},
+				},
+			} {
+				unstructuredContent, _ := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(obj)
+				unstructuredObjects = append(unstructuredObjects, &unstructured.Unstructured{Object: unstructuredContent})
+			}
+			if tc.existingSecretImport {
+				secretImport := &unstructured.Unstructured{
+					Object: map[string]interface{}{
+						"spec": map[string]interface{}{"fromNamespace": "kapp-controller-packaging-global"},
+					},
+				}
+				secretImport.SetGroupVersionKind(secretgenGroupVersion.WithKind(secretImportResource))
+				secretImport.SetNamespace("default")
+				secretImport.SetName("registry-creds")
+				secretImport.SetOwnerReferences([]metav1.OwnerReference{otherOwnerReference})
+				unstructuredObjects = append(unstructuredObjects, secretImport)
+			}
+			dynamicClient := dynfake.NewSimpleDynamicClientWithCustomListKinds(
+				k8sruntime.NewScheme(),
+				map[schema.GroupVersionResource]string{
+					{Group: datapackagingv1alpha1.SchemeGroupVersion.Group, Version: datapackagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgsResource}:         pkgResource + "List",
+					{Group: datapackagingv1alpha1.SchemeGroupVersion.Group, Version: datapackagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgMetadatasResource}: pkgMetadataResource + "List",
+					{Group: packagingv1alpha1.SchemeGroupVersion.Group, Version: packagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgRepositoriesResource}:      pkgRepositoryResource + "List",
+					{Group: packagingv1alpha1.SchemeGroupVersion.Group, Version: packagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgInstallsResource}:          pkgInstallResource + "List",
+					secretImportGVR: secretImportResource + "List",
+				},
+				unstructuredObjects...,
+			)
+			typedClient := typfake.NewSimpleClientset()
+
+			pluginConfig := *defaultPluginConfig
+			pluginConfig.allowImagePullSecretCreation = true
+			pluginConfig.imagePullSecretImport = imagePullSecretImportOptions{Name: "registry-creds"}
+			s := Server{
+				pluginConfig: &pluginConfig,
+				clientGetter: clientgetter.NewBuilder().
+					WithTyped(typedClient).
+					WithDynamic(dynamicClient).
+					Build(),
+			}
+
+			_, err := s.CreateInstalledPackage(context.Background(), connect.NewRequest(&corev1.CreateInstalledPackageRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context:    defaultContext,
+					Plugin:     &pluginDetail,
+					Identifier: "unknown/tetris.foo.example.com",
+				},
+				PkgVersionReference: &corev1.VersionReference{Version: "1.2.3"},
+				Name:                "my-installation",
+				TargetContext:       &corev1.Context{Namespace: "default", Cluster: "default"},
+				ReconciliationOptions: &corev1.ReconciliationOptions{
+					ServiceAccountName: "default",
+				},
+				CustomDetail: func() *anypb.Any {
+					customDetail, _ := anypb.New(&kappcorev1.KappControllerInstalledPackageCustomDetail{
+						CreateImagePullSecret: true,
+					})
+					return customDetail
+				}(),
+			}))
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+
+			secretImport, err := dynamicClient.Resource(secretImportGVR).Namespace("default").Get(context.Background(), "registry-creds", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			fromNamespace, _, _ := unstructured.NestedString(secretImport.Object, "spec", "fromNamespace")
+			if got, want := fromNamespace, "kapp-controller-packaging-global"; got != want {
+				t.Errorf("got: %q, want: %q", got, want)
+			}
+			if got, want := secretImport.GetOwnerReferences(), tc.expectedOwnerReferences; !cmp.Equal(want, got) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+			pkgInstall, err := s.getPkgInstall(context.Background(), http.Header{}, "default", "default", "my-installation")
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if got, want := pkgInstall.GetAnnotations()[annotationImagePullSecretNameKey], "registry-creds"; got != want {
+				t.Errorf("got: %q, want: %q", got, want)
+			}
+			secrets, err := typedClient.CoreV1().Secrets("default").List(context.Background(), metav1.ListOptions{})
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			for _, secret := range secrets.Items {
+				if secret.Type == k8scorev1.SecretTypeDockerConfigJson {
+					t.Errorf("got: placeholder Secret %q, want: none", secret.Name)
+				}
+			}
+		})
+	}
+}
+
+func TestSetSecretOwnerKeepsData(t *testing.T) {
+	filledData := map[string][]byte{k8scorev1.DockerConfigJsonKey: []byte(`{"auths":{"registry.example.com":{}}}`)}
+	typedClient := typfake.NewSimpleClientset(&k8scorev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "my-installation-default-registry-creds"},
+		Type:       k8scorev1.SecretTypeDockerConfigJson,
+		Data:       filledData,
+	})
+	s := Server{
+		pluginConfig: defaultPluginConfig,
+		clientGetter: clientgetter.NewBuilder().
+			WithTyped(typedClient).
+			Build(),
+	}
+	pkgInstall := &packagingv1alpha1.PackageInstall{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "my-installation", UID: "my-installation"},
+	}
+
+	// the placeholder Secret as created, before secretgen-controller filled it in
+	placeholder := s.buildImagePullSecret("my-installation", "default")
+	if err := s.setSecretOwner(context.Background(), http.Header{}, "default", pkgInstall, placeholder); err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	secret, err := typedClient.CoreV1().Secrets("default").Get(context.Background(), placeholder.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if got, want := secret.Data, filledData; !cmp.Equal(want, got) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+	}
+	if got, want := secret.OwnerReferences, []metav1.OwnerReference{pkgInstallOwnerReference(pkgInstall)}; !cmp.Equal(want, got) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+	}
+}
+
+func TestCreateInstalledPackageChecksCapacity(t *testing.T) {
+	quota := &k8scorev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "compute", Namespace: "default"},
+		Status: k8scorev1.ResourceQuotaStatus{
+			Hard: k8scorev1.ResourceList{k8scorev1.ResourceRequestsMemory: resource.MustParse("4Gi")},
+			Used: k8scorev1.ResourceList{k8scorev1.ResourceRequestsMemory: resource.MustParse("3Gi")},
+		},
+	}
+
+	testCases := []struct {
+		name              string
+		capacityCheck     resources.CapacityCheckMode
+		expectedErrorCode connect.Code
+		expectedWarnings  []string
+	}{
+		{
+			name:              "it rejects the installation when the quota is exceeded and enforced",
+			capacityCheck:     resources.CapacityCheckEnforce,
+			expectedErrorCode: connect.CodeFailedPrecondition,
+		},
+		{
+			name:             "it returns a warning when the quota is exceeded and not enforced",
+			capacityCheck:    resources.CapacityCheckWarn,
+			expectedWarnings: []string{`The package requires 2Gi of memory but the ResourceQuota "compute" of the namespace "default" only has 1Gi left`},
+		},
+		{
+			name:          "it does not check the quota when off",
+			capacityCheck: resources.CapacityCheckOff,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var unstructuredObjects []k8sruntime.Object
+			for _, obj := range []k8sruntime.Object{
+				&datapackagingv1alpha1.PackageMetadata{
+					TypeMeta:   metav1.TypeMeta{Kind: pkgMetadataResource, APIVersion: datapackagingAPIVersion},
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "tetris.foo.example.com"},
+				},
+				&datapackagingv1alpha1.Package{
+					TypeMeta:   metav1.TypeMeta{Kind: pkgResource, APIVersion: datapackagingAPIVersion},
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "tetris.foo.example.com.1.2.3"},
+					Spec: datapackagingv1alpha1.PackageSpec{
+						RefName:                         "tetris.foo.example.com",
+						Version:                         "1.2.3",
+						CapactiyRequirementsDescription: "cpu: 500m, RAM: 2",
+					},
+				},
+			} {
+				unstructuredContent, _ := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(obj)
+				unstructuredObjects = append(unstructuredObjects, &unstructured.Unstructured{Object: unstructuredContent})
+			}
+			dynamicClient := dynfake.NewSimpleDynamicClientWithCustomListKinds(
+				k8sruntime.NewScheme(),
+				map[schema.GroupVersionResource]string{
+					{Group: datapackagingv1alpha1.SchemeGroupVersion.Group, Version: datapackagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgsResource}:         pkgResource + "List",
+					{Group: datapackagingv1alpha1.SchemeGroupVersion.Group, Version: datapackagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgMetadatasResource}: pkgMetadataResource + "List",
+					{Group: packagingv1alpha1.SchemeGroupVersion.Group, Version: packagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgRepositoriesResource}:      pkgRepositoryResource + "List",
+					{Group: packagingv1alpha1.SchemeGroupVersion.Group, Version: packagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgInstallsResource}:          pkgInstallResource + "List",
+				},
+				unstructuredObjects...,
+			)
+
+			pluginConfig := *defaultPluginConfig
+			pluginConfig.capacityCheck = tc.capacityCheck
+			s := Server{
+				pluginConfig: &pluginConfig,
+				clientGetter: clientgetter.NewBuilder().
+					WithTyped(typfake.NewSimpleClientset(quota)).
+					WithDynamic(dynamicClient).
+					Build(),
+			}
+
+			response, err := s.CreateInstalledPackage(context.Background(), connect.NewRequest(&corev1.CreateInstalledPackageRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context:    defaultContext,
+					Plugin:     &pluginDetail,
+					Identifier: "unknown/tetris.foo.example.com",
+				},
+				PkgVersionReference: &corev1.VersionReference{Version: "1.2.3"},
+				Name:                "my-installation",
+				TargetContext:       defaultContext,
+				ReconciliationOptions: &corev1.ReconciliationOptions{
+					ServiceAccountName: "default",
+				},
+			}))
+			if got, want := connect.CodeOf(err), tc.expectedErrorCode; err != nil && got != want {
+				t.Fatalf("got: %d, want: %d, err: %+v", got, want, err)
+			}
+			if tc.expectedErrorCode != 0 {
+				if err == nil {
+					t.Fatalf("got: nil, want: %d", tc.expectedErrorCode)
+				}
+				return
+			}
+
+			if got, want := response.Msg.GetWarnings(), tc.expectedWarnings; !cmp.Equal(want, got, cmpopts.EquateEmpty()) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, cmpopts.EquateEmpty()))
+			}
+		})
+	}
+}
+
+func TestCreateInstalledPackageSetsMetadata(t *testing.T) {
+	testCases := []struct {
+		name                string
+		metadata            *corev1.InstalledPackageMetadata
+		expectedErrorCode   connect.Code
+		expectedLabels      map[string]string
+		expectedAnnotations map[string]string
+	}{
+		{
+			name: "it sets the requested labels and annotations on the PackageInstall",
+			metadata: &corev1.InstalledPackageMetadata{
+				Labels:      map[string]string{"example.com/cost-center": "team-a"},
+				Annotations: map[string]string{"example.com/owner": "team-a@example.com"},
+			},
+			expectedLabels:      map[string]string{"example.com/cost-center": "team-a"},
+			expectedAnnotations: map[string]string{"example.com/owner": "team-a@example.com"},
+		},
+		{
+			name: "it rejects a protected label",
+			metadata: &corev1.InstalledPackageMetadata{
+				Labels: map[string]string{"kapp.k14s.io/app": "1"},
+			},
+			expectedErrorCode: connect.CodeInvalidArgument,
+		},
+		{
+			name: "it rejects an annotation managed by the plugin",
+			metadata: &corev1.InstalledPackageMetadata{
+				Annotations: map[string]string{"packaging.carvel.dev/downgradable": ""},
+			},
+			expectedErrorCode: connect.CodeInvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var unstructuredObjects []k8sruntime.Object
+			for _, obj := range []k8sruntime.Object{
+				&datapackagingv1alpha1.PackageMetadata{
+					TypeMeta:   metav1.TypeMeta{Kind: pkgMetadataResource, APIVersion: datapackagingAPIVersion},
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "tetris.foo.example.com"},
+				},
+				&datapackagingv1alpha1.Package{
+					TypeMeta:   metav1.TypeMeta{Kind: pkgResource, APIVersion: datapackagingAPIVersion},
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "tetris.foo.example.com.1.2.3"},
+					Spec:       datapackagingv1alpha1.PackageSpec{RefName: "tetris.foo.example.com", Version: "1.2.3"},
+				},
+			} {
+				unstructuredContent, _ := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(obj)
+				unstructuredObjects = append(unstructuredObjects, &unstructured.Unstructured{Object: unstructuredContent})
+			}
+			dynamicClient := dynfake.NewSimpleDynamicClientWithCustomListKinds(
+				k8sruntime.NewScheme(),
+				map[schema.GroupVersionResource]string{
+					{Group: datapackagingv1alpha1.SchemeGroupVersion.Group, Version: datapackagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgsResource}:         pkgResource + "List",
+					{Group: datapackagingv1alpha1.SchemeGroupVersion.Group, Version: datapackagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgMetadatasResource}: pkgMetadataResource + "List",
+					{Group: packagingv1alpha1.SchemeGroupVersion.Group, Version: packagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgRepositoriesResource}:      pkgRepositoryResource + "List",
+					{Group: packagingv1alpha1.SchemeGroupVersion.Group, Version: packagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgInstallsResource}:          pkgInstallResource + "List",
+				},
+				unstructuredObjects...,
+			)
+
+			pluginConfig := *defaultPluginConfig
+			pluginConfig.installedPackageMetadata = resources.InstalledPackageMetadataOptions{
+				ProtectedPrefixes: []string{"kapp.k14s.io/"},
+			}
+			s := Server{
+				pluginConfig: &pluginConfig,
+				clientGetter: clientgetter.NewBuilder().
+					WithTyped(typfake.NewSimpleClientset()).
+					WithDynamic(dynamicClient).
+					Build(),
+			}
+
+			_, err := s.CreateInstalledPackage(context.Background(), connect.NewRequest(&corev1.CreateInstalledPackageRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context:    defaultContext,
+					Plugin:     &pluginDetail,
+					Identifier: "unknown/tetris.foo.example.com",
+				},
+				PkgVersionReference: &corev1.VersionReference{Version: "1.2.3"},
+				Name:                "my-installation",
+				TargetContext:       defaultContext,
+				ReconciliationOptions: &corev1.ReconciliationOptions{
+					ServiceAccountName: "default",
+				},
+				Metadata: tc.metadata,
+			}))
+			if got, want := connect.CodeOf(err), tc.expectedErrorCode; err != nil && got != want {
+				t.Fatalf("got: %d, want: %d, err: %+v", got, want, err)
+			}
+			if tc.expectedErrorCode != 0 {
+				if err == nil {
+					t.Fatalf("got: nil, want: %d", tc.expectedErrorCode)
+				}
+				return
+			}
+
+			pkgInstall, err := s.getPkgInstall(context.Background(), nil, "default", "default", "my-installation")
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if got, want := pkgInstall.Labels, tc.expectedLabels; !cmp.Equal(want, got) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+			if got, want := pkgInstall.Annotations, tc.expectedAnnotations; !cmp.Equal(want, got) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
+func TestUpdateInstalledPackage(t *testing.T) {
+	testCases := []struct {
+		name                   string
+		request                *corev1.UpdateInstalledPackageRequest
+		pluginConfig           *kappControllerPluginParsedConfig
+		existingObjects        []k8sruntime.Object
+		existingTypedObjects   []k8sruntime.Object
+		expectedErrorCode      connect.Code
+		expectedResponse       *corev1.UpdateInstalledPackageResponse
+		expectedPackageInstall *packagingv1alpha1.PackageInstall
+	}{
+		{
+			name: "update installed package",
+			request: &corev1.UpdateInstalledPackageRequest{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context: &corev1.Context{
+						Namespace: "default",
+						Cluster:   "default",
+					},
+					Plugin:     &pluginDetail,
+					Identifier: "my-installation",
+				},
+				PkgVersionReference: &corev1.VersionReference{
+					Version: "1.2.3",
+				},
+				Values: "foo: bar",
+				ReconciliationOptions: &corev1.ReconciliationOptions{
+					ServiceAccountName: "default",
+					Interval:           "30s",
+					Suspend:            false,
+				},
+			},
+			pluginConfig: defaultPluginConfig,
+			existingObjects: []k8sruntime.Object{
+				&datapackagingv1alpha1.PackageMetadata{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       pkgMetadataResource,
+						APIVersion: datapackagingAPIVersion,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "tetris.foo.example.com",
+					},
+					Spec: datapackagingv1alpha1.PackageMetadataSpec{
+						DisplayName:        "Classic Tetris",
+						IconSVGBase64:      "Tm90IHJlYWxseSBTVkcK",
+						ShortDescription:   "A great game for arcade gamers",
+						LongDescription:    "A few sentences but not really a readme",
+						Categories:         []string{"logging", "daemon-set"},
+						Maintainers:        []datapackagingv1alpha1.Maintainer{{Name: "person1"}, {Name: "person2"}},
+						SupportDescription: "Some support information",
+						ProviderName:       "Tetris inc.",
+					},
+				},
+				&datapackagingv1alpha1.Package{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       pkgResource,
+						APIVersion: datapackagingAPIVersion,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "tetris.foo.example.com.1.2.3",
+					},
+					Spec: datapackagingv1alpha1.PackageSpec{
+						RefName:                         "tetris.foo.example.com",
+						Version:                         "1.2.3",
+						Licenses:                        []string{"my-license"},
+						ReleaseNotes:                    "release notes",
+						CapactiyRequirementsDescription: "capacity description",
+						ReleasedAt:                      metav1.Time{Time: time.Date(1984, time.June, 6, 0, 0, 0, 0, time.UTC)},
+					},
+				},
+				&packagingv1alpha1.PackageInstall{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       pkgInstallResource,
+						APIVersion: packagingAPIVersion,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "my-installation",
+					},
+					Spec: packagingv1alpha1.PackageInstallSpec{
+						ServiceAccountName: "default",
+						PackageRef: &packagingv1alpha1.PackageRef{
+							RefName: "tetris.foo.example.com",
+							VersionSelection: &vendirversions.VersionSelectionSemver{
+								Constraints: "1.2.3",
+							},
+						},
+						Values: []packagingv1alpha1.PackageInstallValues{{
+							SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{
+								Name: "my-installation-default-values",
+							},
+						},
+						},
+						Paused:     false,
+						Canceled:   false,
+						SyncPeriod: &metav1.Duration{Duration: (time.Second * 30)},
+						NoopDelete: false,
+					},
+					Status: packagingv1alpha1.PackageInstallStatus{
+						GenericStatus: kappctrlv1alpha1.GenericStatus{
+							ObservedGeneration: 1,
+							Conditions: []kappctrlv1alpha1.Condition{{
+								Type:    kappctrlv1alpha1.ReconcileSucceeded,
+								Status:  k8scorev1.ConditionTrue,
+								Reason:  "baz",
+								Message: "qux",
+							}},
+							FriendlyDescription: "foo",
+							UsefulErrorMessage:  "Deployed",
+						},
+						Version:              "1.2.3",
+						LastAttemptedVersion: "1.2.3",
+					},
+				},
+			},
+			existingTypedObjects: []k8sruntime.Object{
+				&k8scorev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "my-installation-default-values",
+					},
+					Type: "Opaque",
+					Data: map[string][]byte{
+						"values.yaml": []byte("foo: bar"),
+					},
+				},
+			},
+			expectedResponse: &corev1.UpdateInstalledPackageResponse{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context:    defaultContext,
+					Plugin:     &pluginDetail,
+					Identifier: "my-installation",
+				},
+			},
+			expectedPackageInstall: &packagingv1alpha1.PackageInstall{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       pkgInstallResource,
+					APIVersion: packagingAPIVersion,
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      "my-installation",
+				},
+				Spec: packagingv1alpha1.PackageInstallSpec{
+					ServiceAccountName: "default",
+					PackageRef: &packagingv1alpha1.PackageRef{
+						RefName: "tetris.foo.example.com",
+						VersionSelection: &vendirversions.VersionSelectionSemver{
+							Constraints: "1.2.3",
+						},
+					},
+					Values: []packagingv1alpha1.PackageInstallValues{{
+						SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{
+							Name: "my-installation-default-values",
+						},
+					},
+					},
+					Paused:     false,
+					Canceled:   false,
+					SyncPeriod: &metav1.Duration{Duration: (time.Second * 30)},
+					NoopDelete: false,
+				},
+				Status: packagingv1alpha1.PackageInstallStatus{
+					GenericStatus: kappctrlv1alpha1.GenericStatus{
+						ObservedGeneration: 1,
+						Conditions: []kappctrlv1alpha1.Condition{{
+							Type:    kappctrlv1alpha1.ReconcileSucceeded,
+							Status:  k8scorev1.ConditionTrue,
+							Reason:  "baz",
+							Message: "qux",
+						}},
+						FriendlyDescription: "foo",
+						UsefulErrorMessage:  "Deployed",
+					},
+					Version:              "1.2.3",
+					LastAttemptedVersion: "1.2.3",
+				},
+			},
+		},
+		{
+			name: "update installed package (allowing downgrades)",
+			request: &corev1.UpdateInstalledPackageRequest{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context: &corev1.Context{
+						Namespace: "default",
+						Cluster:   "default",
+					},
+					Plugin:     &pluginDetail,
+					Identifier: "my-installation",
+				},
+				PkgVersionReference: &corev1.VersionReference{
+					Version: "1.2.3",
+				},
+				Values: "foo: bar",
+				ReconciliationOptions: &corev1.ReconciliationOptions{
+					ServiceAccountName: "default",
+					Interval:           "30s",
+					Suspend:            false,
+				},
+				CustomDetail: func() *anypb.Any {
+					customDetail, _ := anypb.New(&kappcorev1.KappControllerInstalledPackageCustomDetail{
+						AllowDowngrades: proto.Bool(true),
+					})
+					return customDetail
+				}(),
+			},
+			pluginConfig: defaultPluginConfig,
+			existingObjects: []k8sruntime.Object{
+				&datapackagingv1alpha1.PackageMetadata{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       pkgMetadataResource,
+						APIVersion: datapackagingAPIVersion,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "tetris.foo.example.com",
+					},
+					Spec: datapackagingv1alpha1.PackageMetadataSpec{
+						DisplayName:        "Classic Tetris",
+						IconSVGBase64:      "Tm90IHJlYWxseSBTVkcK",
+						ShortDescription:   "A great game for arcade gamers",
+						LongDescription:    "A few sentences but not really a readme",
+						Categories:         []string{"logging", "daemon-set"},
+						Maintainers:        []datapackagingv1alpha1.Maintainer{{Name: "person1"}, {Name: "person2"}},
+						SupportDescription: "Some support information",
+						ProviderName:       "Tetris inc.",
+					},
+				},
+				&datapackagingv1alpha1.Package{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       pkgResource,
+						APIVersion: datapackagingAPIVersion,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "tetris.foo.example.com.1.2.3",
+					},
+					Spec: datapackagingv1alpha1.PackageSpec{
+						RefName:                         "tetris.foo.example.com",
+						Version:                         "1.2.3",
+						Licenses:                        []string{"my-license"},
+						ReleaseNotes:                    "release notes",
+						CapactiyRequirementsDescription: "capacity description",
+						ReleasedAt:                      metav1.Time{Time: time.Date(1984, time.June, 6, 0, 0, 0, 0, time.UTC)},
+					},
+				},
+				&packagingv1alpha1.PackageInstall{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       pkgInstallResource,
+						APIVersion: packagingAPIVersion,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "my-installation",
+					},
+					Spec: packagingv1alpha1.PackageInstallSpec{
+						ServiceAccountName: "default",
+						PackageRef: &packagingv1alpha1.PackageRef{
+							RefName: "tetris.foo.example.com",
+							VersionSelection: &vendirversions.VersionSelectionSemver{
+								Constraints: "1.2.3",
+							},
+						},
+						Values: []packagingv1alpha1.PackageInstallValues{{
+							SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{
+								Name: "my-installation-default-values",
+							},
+						},
+						},
+						Paused:     false,
+						Canceled:   false,
+						SyncPeriod: &metav1.Duration{Duration: (time.Second * 30)},
+						NoopDelete: false,
+					},
+					Status: packagingv1alpha1.PackageInstallStatus{
+						GenericStatus: kappctrlv1alpha1.GenericStatus{
+							ObservedGeneration: 1,
+							Conditions: []kappctrlv1alpha1.Condition{{
+								Type:    kappctrlv1alpha1.ReconcileSucceeded,
+								Status:  k8scorev1.ConditionTrue,
+								Reason:  "baz",
+								Message: "qux",
+							}},
+							FriendlyDescription: "foo",
+							UsefulErrorMessage:  "Deployed",
+						},
+						Version:              "1.2.3",
+						LastAttemptedVersion: "1.2.3",
+					},
+				},
+			},
+			existingTypedObjects: []k8sruntime.Object{
+				&k8scorev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "my-installation-default-values",
+					},
+					Type: "Opaque",
+					Data: map[string][]byte{
+						"values.yaml": []byte("foo: bar"),
+					},
+				},
+			},
+			expectedResponse: &corev1.UpdateInstalledPackageResponse{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context:    defaultContext,
+					Plugin:     &pluginDetail,
+					Identifier: "my-installation",
+				},
+			},
+			expectedPackageInstall: &packagingv1alpha1.PackageInstall{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       pkgInstallResource,
+					APIVersion: packagingAPIVersion,
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "default",
+					Name:        "my-installation",
+					Annotations: map[string]string{kappctrlpackageinstall.DowngradableAnnKey: ""},
+				},
+				Spec: packagingv1alpha1.PackageInstallSpec{
+					ServiceAccountName: "default",
+					PackageRef: &packagingv1alpha1.PackageRef{
+						RefName: "tetris.foo.example.com",
+						VersionSelection: &vendirversions.VersionSelectionSemver{
+							Constraints: "1.2.3",
+						},
+					},
+					Values: []packagingv1alpha1.PackageInstallValues{{
+						SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{
+							Name: "my-installation-default-values",
+						},
+					},
+					},
+					Paused:     false,
+					Canceled:   false,
+					SyncPeriod: &metav1.Duration{Duration: (time.Second * 30)},
+					NoopDelete: false,
+				},
+				Status: packagingv1alpha1.PackageInstallStatus{
+					GenericStatus: kappctrlv1alpha1.GenericStatus{
+						ObservedGeneration: 1,
+						Conditions: []kappctrlv1alpha1.Condition{{
+							Type:    kappctrlv1alpha1.ReconcileSucceeded,
+							Status:  k8scorev1.ConditionTrue,
+							Reason:  "baz",
+							Message: "qux",
+						}},
+						FriendlyDescription: "foo",
+						UsefulErrorMessage:  "Deployed",
+					},
+					Version:              "1.2.3",
+					LastAttemptedVersion: "1.2.3",
+				},
+			},
+		},
+		{
+			name: "update installed package (downgrade not allowed)",
+			request: &corev1.UpdateInstalledPackageRequest{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context: &corev1.Context{
+						Namespace: "default",
+						Cluster:   "default",
+					},
+					Plugin:     &pluginDetail,
+					Identifier: "my-installation",
+				},
+				PkgVersionReference: &corev1.VersionReference{
+					Version: "1.2.3",
+				},
+				Values: "foo: bar",
+				ReconciliationOptions: &corev1.ReconciliationOptions{
+					ServiceAccountName: "default",
+					Interval:           "30s",
+					Suspend:            false,
+				},
+				CustomDetail: func() *anypb.Any {
+					customDetail, _ := anypb.New(&kappcorev1.KappControllerInstalledPackageCustomDetail{
+						AllowDowngrades: proto.Bool(false),
+					})
+					return customDetail
+				}(),
+			},
+			pluginConfig: defaultPluginConfig,
+			existingObjects: []k8sruntime.Object{
+				&datapackagingv1alpha1.PackageMetadata{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       pkgMetadataResource,
+						APIVersion: datapackagingAPIVersion,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "tetris.foo.example.com",
+					},
+					Spec: datapackagingv1alpha1.PackageMetadataSpec{
+						DisplayName:        "Classic Tetris",
+						IconSVGBase64:      "Tm90IHJlYWxseSBTVkcK",
+						ShortDescription:   "A great game for arcade gamers",
+						LongDescription:    "A few sentences but not really a readme",
+						Categories:         []string{"logging", "daemon-set"},
+						Maintainers:        []datapackagingv1alpha1.Maintainer{{Name: "person1"}, {Name: "person2"}},
+						SupportDescription: "Some support information",
+						ProviderName:       "Tetris inc.",
+					},
+				},
+				&datapackagingv1alpha1.Package{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       pkgResource,
+						APIVersion: datapackagingAPIVersion,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "tetris.foo.example.com.1.2.3",
+					},
+					Spec: datapackagingv1alpha1.PackageSpec{
+						RefName:                         "tetris.foo.example.com",
+						Version:                         "1.2.3",
+						Licenses:                        []string{"my-license"},
+						ReleaseNotes:                    "release notes",
+						CapactiyRequirementsDescription: "capacity description",
+						ReleasedAt:                      metav1.Time{Time: time.Date(1984, time.June, 6, 0, 0, 0, 0, time.UTC)},
+					},
+				},
+				&packagingv1alpha1.PackageInstall{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       pkgInstallResource,
+						APIVersion: packagingAPIVersion,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "my-installation",
+					},
+					Spec: packagingv1alpha1.PackageInstallSpec{
+						ServiceAccountName: "default",
+						PackageRef: &packagingv1alpha1.PackageRef{
+							RefName: "tetris.foo.example.com",
+							VersionSelection: &vendirversions.VersionSelectionSemver{
+								Constraints: "1.2.3",
+							},
+						},
+						Values: []packagingv1alpha1.PackageInstallValues{{
+							SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{
+								Name: "my-installation-default-values",
+							},
+						},
+						},
+						Paused:     false,
+						Canceled:   false,
+						SyncPeriod: &metav1.Duration{Duration: (time.Second * 30)},
+						NoopDelete: false,
+					},
+					Status: packagingv1alpha1.PackageInstallStatus{
+						GenericStatus: kappctrlv1alpha1.GenericStatus{
+							ObservedGeneration: 1,
+							Conditions: []kappctrlv1alpha1.Condition{{
+								Type:    kappctrlv1alpha1.ReconcileSucceeded,
+								Status:  k8scorev1.ConditionTrue,
+								Reason:  "baz",
+								Message: "qux",
+							}},
+							FriendlyDescription: "foo",
+							UsefulErrorMessage:  "Deployed",
+						},
+						Version:              "2.0.0",
+						LastAttemptedVersion: "2.0.0",
+					},
+				},
+			},
+			existingTypedObjects: []k8sruntime.Object{
+				&k8scorev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "my-installation-default-values",
+					},
+					Type: "Opaque",
+					Data: map[string][]byte{
+						"values.yaml": []byte("foo: bar"),
+					},
+				},
+			},
+			expectedErrorCode: connect.CodeFailedPrecondition,
+		},
+		{
+			name: "update installed package (downgrade not allowed by the existing PackageInstall, despite the default)",
+			request: &corev1.UpdateInstalledPackageRequest{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context: &corev1.Context{
+						Namespace: "default",
+						Cluster:   "default",
+					},
+					Plugin:     &pluginDetail,
+					Identifier: "my-installation",
+				},
+				PkgVersionReference: &corev1.VersionReference{
+					Version: "1.2.3",
+				},
+				Values: "foo: bar",
+				ReconciliationOptions: &corev1.ReconciliationOptions{
+					ServiceAccountName: "default",
+					Interval:           "30s",
+					Suspend:            false,
+				},
+			},
+			pluginConfig: &kappControllerPluginParsedConfig{
+				defaultUpgradePolicy:               defaultPluginConfig.defaultUpgradePolicy,
+				defaultPrereleasesVersionSelection: defaultPluginConfig.defaultPrereleasesVersionSelection,
+				defaultAllowDowngrades:             true,
+			},
+			existingObjects: []k8sruntime.Object{
+				&datapackagingv1alpha1.PackageMetadata{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       pkgMetadataResource,
+						APIVersion: datapackagingAPIVersion,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "tetris.foo.example.com",
+					},
+					Spec: datapackagingv1alpha1.PackageMetadataSpec{
+						DisplayName:        "Classic Tetris",
+						IconSVGBase64:      "Tm90IHJlYWxseSBTVkcK",
+						ShortDescription:   "A great game for arcade gamers",
+						LongDescription:    "A few sentences but not really a readme",
+						Categories:         []string{"logging", "daemon-set"},
+						Maintainers:        []datapackagingv1alpha1.Maintainer{{Name: "person1"}, {Name: "person2"}},
+						SupportDescription: "Some support information",
+						ProviderName:       "Tetris inc.",
+					},
+				},
+				&datapackagingv1alpha1.Package{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       pkgResource,
+						APIVersion: datapackagingAPIVersion,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "tetris.foo.example.com.1.2.3",
+					},
+					Spec: datapackagingv1alpha1.PackageSpec{
+						RefName:                         "tetris.foo.example.com",
+						Version:                         "1.2.3",
+						Licenses:                        []string{"my-license"},
+						ReleaseNotes:                    "release notes",
+						CapactiyRequirementsDescription: "capacity description",
+						ReleasedAt:                      metav1.Time{Time: time.Date(1984, time.June, 6, 0, 0, 0, 0, time.UTC)},
+					},
+				},
+				&packagingv1alpha1.PackageInstall{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       pkgInstallResource,
+						APIVersion: packagingAPIVersion,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "my-installation",
+					},
+					Spec: packagingv1alpha1.PackageInstallSpec{
+						ServiceAccountName: "default",
+						PackageRef: &packagingv1alpha1.PackageRef{
+							RefName: "tetris.foo.example.com",
+							VersionSelection: &vendirversions.VersionSelectionSemver{
+								Constraints: "1.2.3",
+							},
+						},
+						Values: []packagingv1alpha1.PackageInstallValues{{
+							SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{
+								Name: "my-installation-default-values",
+							},
+						},
+						},
+						Paused:     false,
+						Canceled:   false,
+						SyncPeriod: &metav1.Duration{Duration: (time.Second * 30)},
+						NoopDelete: false,
+					},
+					Status: packagingv1alpha1.PackageInstallStatus{
+						GenericStatus: kappctrlv1alpha1.GenericStatus{
+							ObservedGeneration: 1,
+							Conditions: []kappctrlv1alpha1.Condition{{
+								Type:    kappctrlv1alpha1.ReconcileSucceeded,
+								Status:  k8scorev1.ConditionTrue,
+								Reason:  "baz",
+								Message: "qux",
+							}},
+							FriendlyDescription: "foo",
+							UsefulErrorMessage:  "Deployed",
+						},
+						Version:              "2.0.0",
+						LastAttemptedVersion: "2.0.0",
+					},
+				},
+			},
+			existingTypedObjects: []k8sruntime.Object{
+				&k8scorev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "my-installation-default-values",
+					},
+					Type: "Opaque",
+					Data: map[string][]byte{
+						"values.yaml": []byte("foo: bar"),
+					},
+				},
+			},
+			expectedErrorCode: connect.CodeFailedPrecondition,
+		},
+		{
+			name: "update installed package (non eligible version)",
+			request: &corev1.UpdateInstalledPackageRequest{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context: &corev1.Context{
+						Namespace: "default",
+						Cluster:   "default",
+					},
+					Plugin:     &pluginDetail,
+					Identifier: "my-installation",
+				},
+				PkgVersionReference: &corev1.VersionReference{
+					Version: "1.2.3-rc",
+				},
+				Values: "foo: bar",
+				ReconciliationOptions: &corev1.ReconciliationOptions{
+					ServiceAccountName: "default",
+					Interval:           "30s",
+					Suspend:            false,
+				},
+			},
+			pluginConfig: &kappControllerPluginParsedConfig{
+				defaultUpgradePolicy:               defaultPluginConfig.defaultUpgradePolicy,
+				defaultPrereleasesVersionSelection: nil,
+				defaultAllowDowngrades:             defaultPluginConfig.defaultAllowDowngrades,
+			},
+			existingObjects: []k8sruntime.Object{
+				&datapackagingv1alpha1.PackageMetadata{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       pkgMetadataResource,
+						APIVersion: datapackagingAPIVersion,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "tetris.foo.example.com",
+					},
+					Spec: datapackagingv1alpha1.PackageMetadataSpec{
+						DisplayName:        "Classic Tetris",
+						IconSVGBase64:      "Tm90IHJlYWxseSBTVkcK",
+						ShortDescription:   "A great game for arcade gamers",
+						LongDescription:    "A few sentences but not really a readme",
+						Categories:         []string{"logging", "daemon-set"},
+						Maintainers:        []datapackagingv1alpha1.Maintainer{{Name: "person1"}, {Name: "person2"}},
+						SupportDescription: "Some support information",
+						ProviderName:       "Tetris inc.",
+					},
+				},
+				&datapackagingv1alpha1.Package{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       pkgResource,
+						APIVersion: datapackagingAPIVersion,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "tetris.foo.example.com.1.2.3",
+					},
+					Spec: datapackagingv1alpha1.PackageSpec{
+						RefName:                         "tetris.foo.example.com",
+						Version:                         "1.2.3",
+						Licenses:                        []string{"my-license"},
+						ReleaseNotes:                    "release notes",
+						CapactiyRequirementsDescription: "capacity description",
+						ReleasedAt:                      metav1.Time{Time: time.Date(1984, time.June, 6, 0, 0, 0, 0, time.UTC)},
+					},
+				},
+				&packagingv1alpha1.PackageInstall{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       pkgInstallResource,
+						APIVersion: packagingAPIVersion,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "my-installation",
+					},
+					Spec: packagingv1alpha1.PackageInstallSpec{
+						ServiceAccountName: "default",
+						PackageRef: &packagingv1alpha1.PackageRef{
+							RefName: "tetris.foo.example.com",
+							VersionSelection: &vendirversions.VersionSelectionSemver{
+								Constraints: "1.2.3",
+							},
+						},
+						Values: []packagingv1alpha1.PackageInstallValues{{
+							SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{
+								Name: "my-installation-default-values",
+							},
+						},
+						},
+						Paused:     false,
+						Canceled:   false,
+						SyncPeriod: &metav1.Duration{Duration: (time.Second * 30)},
+						NoopDelete: false,
+					},
+					Status: packagingv1alpha1.PackageInstallStatus{
+						GenericStatus: kappctrlv1alpha1.GenericStatus{
+							ObservedGeneration: 1,
+							Conditions: []kappctrlv1alpha1.Condition{{
+								Type:    kappctrlv1alpha1.ReconcileSucceeded,
+								Status:  k8scorev1.ConditionTrue,
+								Reason:  "baz",
+								Message: "qux",
+							}},
+							FriendlyDescription: "foo",
+							UsefulErrorMessage:  "Deployed",
+						},
+						Version:              "1.2.3",
+						LastAttemptedVersion: "1.2.3",
+					},
+				},
+			},
+			existingTypedObjects: []k8sruntime.Object{
+				&k8scorev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "my-installation-default-values",
+					},
+					Type: "Opaque",
+					Data: map[string][]byte{
+						"values.yaml": []byte("foo: bar"),
+					},
+				},
+			},
+			expectedErrorCode: connect.CodeInvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var unstructuredObjects []k8sruntime.Object
+			for _, obj := range tc.existingObjects {
+				unstructuredContent, _ := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(obj)
+				unstructuredObjects = append(unstructuredObjects, &unstructured.Unstructured{Object: unstructuredContent})
+			}
+
+			pluginConfig := tc.pluginConfig
+			if pluginConfig == nil {
+				pluginConfig = defaultPluginConfig
+			}
+			s := Server{
+				pluginConfig: pluginConfig,
+				clientGetter: clientgetter.NewBuilder().
+					WithTyped(typfake.NewSimpleClientset(tc.existingTypedObjects...)).
+					WithDynamic(dynfake.NewSimpleDynamicClientWithCustomListKinds(
+						k8sruntime.NewScheme(),
+						map[schema.GroupVersionResource]string{
+							{Group: datapackagingv1alpha1.SchemeGroupVersion.Group, Version: datapackagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgsResource}:         pkgResource + "List",
+							{Group: datapackagingv1alpha1.SchemeGroupVersion.Group, Version: datapackagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgMetadatasResource}: pkgMetadataResource + "List",
+							{Group: packagingv1alpha1.SchemeGroupVersion.Group, Version: packagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgRepositoriesResource}:      pkgRepositoryResource + "List",
+							{Group: packagingv1alpha1.SchemeGroupVersion.Group, Version: packagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgInstallsResource}:          pkgInstallResource + "List",
+						},
+						unstructuredObjects...,
+					)).Build(),
+			}
+
+			updateInstalledPackageResponse, err := s.UpdateInstalledPackage(context.Background(), connect.NewRequest(tc.request))
+
+			if got, want := connect.CodeOf(err), tc.expectedErrorCode; err != nil && got != want {
+				t.Fatalf("got: %d, want: %d, err: %+v", got, want, err)
+			}
+			// If we were expecting an error, continue to the next test.
+			if tc.expectedErrorCode != 0 {
+				if err == nil {
+					t.Fatalf("got: nil, want: %d", tc.expectedErrorCode)
+				}
+				return
+			}
+			if tc.expectedPackageInstall != nil {
+				if got, want := updateInstalledPackageResponse.Msg, tc.expectedResponse; !cmp.Equal(want, got, ignoreUnexported) {
+					t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexported))
+				}
+
+				updatedPkgInstall, err := s.getPkgInstall(context.Background(), http.Header{}, "default", updateInstalledPackageResponse.Msg.InstalledPackageRef.Context.Namespace, updateInstalledPackageResponse.Msg.InstalledPackageRef.Identifier)
+				if err != nil {
+					t.Fatalf("%+v", err)
+				}
+
+				if got, want := updatedPkgInstall, tc.expectedPackageInstall; !cmp.Equal(want, got, ignoreUnexported) {
+					t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexported))
+				}
+			}
+		})
+	}
+}
+
+func TestUpdateInstalledPackageValuesEntries(t *testing.T) {
+	pkgInstall := &packagingv1alpha1.PackageInstall{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       pkgInstallResource,
+			APIVersion: packagingAPIVersion,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "my-installation",
+			Annotations: map[string]string{
+				annotationValuesLayersKey: `{"my-installation-default-environment-values-0":"PACKAGE_INSTALL_VALUES_LAYER_ENVIRONMENT"}`,
+			},
+		},
+		Spec: packagingv1alpha1.PackageInstallSpec{
+			ServiceAccountName: "default",
+			PackageRef: &packagingv1alpha1.PackageRef{
+				RefName: "tetris.foo.example.com",
+				VersionSelection: &vendirversions.VersionSelectionSemver{
+					Constraints: "1.2.3",
+				},
+			},
+			Values: []packagingv1alpha1.PackageInstallValues{
+				{SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{Name: "shared-values"}},
+				{SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{Name: "my-installation-default-environment-values-0"}},
+				{SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{Name: "my-installation-default-values"}},
+			},
+		},
+		Status: packagingv1alpha1.PackageInstallStatus{
+			GenericStatus: kappctrlv1alpha1.GenericStatus{
+				Conditions: []kappctrlv1alpha1.Condition{{
+					Type:   kappctrlv1alpha1.ReconcileSucceeded,
+					Status: k8scorev1.ConditionTrue,
+				}},
+			},
+			Version:              "1.2.3",
+			LastAttemptedVersion: "1.2.3",
+		},
+	}
+	valuesSecret := func(name string, managed bool, values string) *k8scorev1.Secret {
+		secret := &k8scorev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+			Type:       "Opaque",
+			Data:       map[string][]byte{"values.yaml": []byte(values)},
+		}
+		if managed {
+			secret.Annotations = map[string]string{annotationManagedByKey: annotationManagedByValue}
+		}
+		return secret
+	}
+
+	customDetail, err := anypb.New(&kappcorev1.KappControllerInstalledPackageCustomDetail{
+		Values: []*kappcorev1.PackageInstallValues{
+			{
+				Layer:      kappcorev1.PackageInstallValuesLayer_PACKAGE_INSTALL_VALUES_LAYER_ENVIRONMENT,
+				SecretName: "env-values",
+			},
+			{
+				Layer:  kappcorev1.PackageInstallValuesLayer_PACKAGE_INSTALL_VALUES_LAYER_BASE,
+				Values: "replicas: 1",
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	request := &corev1.UpdateInstalledPackageRequest{
+		InstalledPackageRef: &corev1.InstalledPackageReference{
+			Context:    defaultContext,
+			Plugin:     &pluginDetail,
+			Identifier: "my-installation",
+		},
+		PkgVersionReference: &corev1.VersionReference{
+			Version: "1.2.3",
+		},
+		Values:       "foo: baz",
+		CustomDetail: customDetail,
+	}
+
+	unstructuredContent, _ := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(pkgInstall)
+	typedClient := typfake.NewSimpleClientset(
+		valuesSecret("shared-values", false, "shared: true"),
+		valuesSecret("my-installation-default-environment-values-0", true, "env: dev"),
+		valuesSecret("my-installation-default-values", false, "foo: bar"),
+	)
+	s := Server{
+		pluginConfig: defaultPluginConfig,
+		clientGetter: clientgetter.NewBuilder().
+			WithTyped(typedClient).
+			WithDynamic(dynfake.NewSimpleDynamicClientWithCustomListKinds(
+				k8sruntime.NewScheme(),
+				map[schema.GroupVersionResource]string{
+					{Group: packagingv1alpha1.SchemeGroupVersion.Group, Version: packagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgInstallsResource}: pkgInstallResource + "List",
+				},
+				&unstructured.Unstructured{Object: unstructuredContent},
+			)).Build(),
+	}
+
+	if _, err := s.UpdateInstalledPackage(context.Background(), connect.NewRequest(request)); err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	updatedPkgInstall, err := s.getPkgInstall(context.Background(), http.Header{}, "default", "default", "my-installation")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	expectedValues := []packagingv1alpha1.PackageInstallValues{
+		// not managed by the plugin, so kept untouched before any other layer
+		{SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{Name: "shared-values"}},
+		{SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{Name: "my-installation-default-base-values-1"}},
+		{SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{Name: "env-values"}},
+		{SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{Name: "my-installation-default-values"}},
+	}
+	if got, want := updatedPkgInstall.Spec.Values, expectedValues; !cmp.Equal(want, got) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+	}
+	expectedLayers := `{"env-values":"PACKAGE_INSTALL_VALUES_LAYER_ENVIRONMENT","my-installation-default-base-values-1":"PACKAGE_INSTALL_VALUES_LAYER_BASE"}`
+	if got, want := updatedPkgInstall.Annotations[annotationValuesLayersKey], expectedLayers; got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+
+	expectedSecretValues := map[string]string{
+		"shared-values":                         "shared: true",
+		"my-installation-default-base-values-1": "replicas: 1",
+		"my-installation-default-values":        "foo: baz",
+	}
+	for name, want := range expectedSecretValues {
+		secret, err := typedClient.CoreV1().Secrets("default").Get(context.Background(), name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		if got := string(secret.Data["values.yaml"]); got != want {
+			t.Errorf("got: %q, want: %q", got, want)
+		}
+	}
+	// the previously declared entry managed by the plugin is deleted
+	if _, err := typedClient.CoreV1().Secrets("default").Get(context.Background(), "my-installation-default-environment-values-0", metav1.GetOptions{}); !k8sErrors.IsNotFound(err) {
+		t.Errorf("got: %+v, want: not found error", err)
+	}
+}
+
+func TestUpdateInstalledPackageValuesSecretKeys(t *testing.T) {
+	pkgInstall := &packagingv1alpha1.PackageInstall{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       pkgInstallResource,
+			APIVersion: packagingAPIVersion,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "my-installation",
+		},
+		Spec: packagingv1alpha1.PackageInstallSpec{
+			ServiceAccountName: "default",
+			PackageRef: &packagingv1alpha1.PackageRef{
+				RefName: "tetris.foo.example.com",
+				VersionSelection: &vendirversions.VersionSelectionSemver{
+					Constraints: "1.2.3",
+				},
+			},
+			Values: []packagingv1alpha1.PackageInstallValues{
+				{SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{Name: "my-installation-default-values"}},
+			},
+		},
+		Status: packagingv1alpha1.PackageInstallStatus{
+			GenericStatus: kappctrlv1alpha1.GenericStatus{
+				Conditions: []kappctrlv1alpha1.Condition{{
+					Type:   kappctrlv1alpha1.ReconcileSucceeded,
+					Status: k8scorev1.ConditionTrue,
+				}},
+			},
+			Version:              "1.2.3",
+			LastAttemptedVersion: "1.2.3",
+		},
+	}
+	valuesSecret := &k8scorev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "my-installation-default-values",
+			Annotations: map[string]string{
+				annotationManagedByKey:        annotationManagedByValue,
+				annotationValuesSecretKeysKey: "ca.crt",
+			},
+		},
+		Type: "Opaque",
+		Data: map[string][]byte{
+			"values.yaml": []byte("foo: bar"),
+			"ca.crt":      []byte("old-ca"),
+			// added by a user, so kept untouched
+			"extra.yaml": []byte("user: true"),
+		},
+	}
+
+	testCases := []struct {
+		name               string
+		values             string
+		reuseValues        bool
+		customDetail       *kappcorev1.KappControllerInstalledPackageCustomDetail
+		expectedData       map[string][]byte
+		expectedAnnotation string
+	}{
+		{
+			name:        "it keeps the values and the additional keys when reusing the values",
+			reuseValues: true,
+			expectedData: map[string][]byte{
+				"values.yaml": []byte("foo: bar"),
+				"ca.crt":      []byte("old-ca"),
+				"extra.yaml":  []byte("user: true"),
+			},
+			expectedAnnotation: "ca.crt",
+		},
+		{
+			name:   "it keeps the additional keys without a custom detail",
+			values: "foo: baz",
+			expectedData: map[string][]byte{
+				"values.yaml": []byte("foo: baz"),
+				"ca.crt":      []byte("old-ca"),
+				"extra.yaml":  []byte("user: true"),
+			},
+			expectedAnnotation: "ca.crt",
+		},
+		{
+			name:   "it replaces the additional keys with the ones of the custom detail",
+			values: "foo: baz",
+			customDetail: &kappcorev1.KappControllerInstalledPackageCustomDetail{
+				ValuesSecretData:       map[string][]byte{"tls.der": {0x30, 0x82}},
+				ValuesSecretStringData: map[string]string{"tls.key": "key"},
+			},
+			expectedData: map[string][]byte{
+				"values.yaml": []byte("foo: baz"),
+				"tls.der":     {0x30, 0x82},
+				"tls.key":     []byte("key"),
+				"extra.yaml":  []byte("user: true"),
+			},
+			expectedAnnotation: "tls.der,tls.key",
+		},
+		{
+			name:         "it keeps the keys of the user without values nor additional keys",
+			customDetail: &kappcorev1.KappControllerInstalledPackageCustomDetail{},
+			expectedData: map[string][]byte{
+				"extra.yaml": []byte("user: true"),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			request := &corev1.UpdateInstalledPackageRequest{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context:    defaultContext,
+					Plugin:     &pluginDetail,
+					Identifier: "my-installation",
+				},
+				PkgVersionReference: &corev1.VersionReference{
+					Version: "1.2.3",
+				},
+				Values:      tc.values,
+				ReuseValues: tc.reuseValues,
+			}
+			if tc.customDetail != nil {
+				customDetail, err := anypb.New(tc.customDetail)
+				if err != nil {
+					t.Fatalf("%+v", err)
+				}
+				request.CustomDetail = customDetail
+			}
+
+			unstructuredContent, _ := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(pkgInstall)
+			typedClient := typfake.NewSimpleClientset(valuesSecret.DeepCopy())
+			s := Server{
+				pluginConfig: defaultPluginConfig,
+				clientGetter: clientgetter.NewBuilder().
+					WithTyped(typedClient).
+					WithDynamic(dynfake.NewSimpleDynamicClientWithCustomListKinds(
+						k8sruntime.NewScheme(),
+						map[schema.GroupVersionResource]string{
+							{Group: packagingv1alpha1.SchemeGroupVersion.Group, Version: packagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgInstallsResource}: pkgInstallResource + "List",
+						},
+						&unstructured.Unstructured{Object: unstructuredContent},
+					)).Build(),
+			}
+
+			if _, err := s.UpdateInstalledPackage(context.Background(), connect.NewRequest(request)); err != nil {
+				t.Fatalf("%+v", err)
+			}
+
+			secret, err := typedClient.CoreV1().Secrets("default").Get(context.Background(), "my-installation-default-values", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if got, want := secret.Data, tc.expectedData; !cmp.Equal(want, got) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+			if got, want := secret.Annotations[annotationValuesSecretKeysKey], tc.expectedAnnotation; got != want {
+				t.Errorf("got: %q, want: %q", got, want)
+			}
+		})
+	}
+}
+
+func TestBuildPkgInstallValuesEntriesFromDetail(t *testing.T) {
+	// the values entries of the InstalledPackageDetail of "my-installation" in
+	// the "dev" namespace, re-created for its clone in "staging"
+	values := []*kappcorev1.PackageInstallValues{
+		{
+			Layer:      kappcorev1.PackageInstallValuesLayer_PACKAGE_INSTALL_VALUES_LAYER_BASE,
+			Values:     "replicas: 1",
+			SecretName: "my-installation-dev-base-values-0",
+			Managed:    true,
+		},
+		{
+			Layer:      kappcorev1.PackageInstallValuesLayer_PACKAGE_INSTALL_VALUES_LAYER_ENVIRONMENT,
+			Values:     "env: dev",
+			SecretName: "dev-values",
+			SecretKey:  "values.yaml",
+		},
+		{
+			Layer:      kappcorev1.PackageInstallValuesLayer_PACKAGE_INSTALL_VALUES_LAYER_USER_OVERRIDES,
+			Values:     "replicas: 2",
+			SecretName: "my-installation-dev-values",
+			Managed:    true,
+		},
+	}
+
+	entries, err := (&Server{}).buildPkgInstallValuesEntries("my-installation", "staging", values)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	secretNames := []string{}
+	createdSecrets := []string{}
+	for _, entry := range entries {
+		secretNames = append(secretNames, entry.ref.SecretRef.Name)
+		if entry.secret != nil {
+			createdSecrets = append(createdSecrets, string(entry.secret.Data["values.yaml"]))
+		}
+	}
+	if got, want := secretNames, []string{"my-installation-staging-base-values-0", "dev-values"}; !cmp.Equal(want, got) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+	}
+	if got, want := createdSecrets, []string{"replicas: 1"}; !cmp.Equal(want, got) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+	}
+}
+
+func TestGetInstalledPackageChangePreview(t *testing.T) {
+	pkgInstall := &packagingv1alpha1.PackageInstall{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       pkgInstallResource,
+			APIVersion: packagingAPIVersion,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "my-installation",
+		},
+		Spec: packagingv1alpha1.PackageInstallSpec{
+			ServiceAccountName: "default",
+			PackageRef: &packagingv1alpha1.PackageRef{
+				RefName: "tetris.foo.example.com",
+				VersionSelection: &vendirversions.VersionSelectionSemver{
+					Constraints: "1.2.3",
+				},
+			},
+			Values: []packagingv1alpha1.PackageInstallValues{
+				{SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{Name: "my-installation-default-values"}},
+			},
+		},
+		Status: packagingv1alpha1.PackageInstallStatus{
+			Version:              "1.2.3",
+			LastAttemptedVersion: "1.2.3",
+		},
+	}
+	valuesSecret := &k8scorev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "default",
+			Name:        "my-installation-default-values",
+			Annotations: map[string]string{annotationManagedByKey: annotationManagedByValue},
+		},
+		Type: "Opaque",
+		Data: map[string][]byte{"values.yaml": []byte("foo: bar")},
+	}
+	pkgInstallRef := &corev1.ResourceRef{
+		ApiVersion: packagingAPIVersion,
+		Kind:       pkgInstallResource,
+		Name:       "my-installation",
+		Namespace:  "default",
+	}
+	valuesSecretRef := &corev1.ResourceRef{
+		ApiVersion: "v1",
+		Kind:       "Secret",
+		Name:       "my-installation-default-values",
+		Namespace:  "default",
+	}
+
+	testCases := []struct {
+		name               string
+		request            *kappcorev1.GetInstalledPackageChangePreviewRequest
+		expectedChanges    []*kappcorev1.ResourceChange
+		expectedDiffs      []string
+		expectedStatusCode connect.Code
+	}{
+		{
+			name: "it previews the update of the version and values",
+			request: &kappcorev1.GetInstalledPackageChangePreviewRequest{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context:    defaultContext,
+					Plugin:     &pluginDetail,
+					Identifier: "my-installation",
+				},
+				PkgVersionReference: &corev1.VersionReference{Version: "1.2.4"},
+				Values:              "foo: baz",
+			},
+			expectedChanges: []*kappcorev1.ResourceChange{
+				{ResourceRef: pkgInstallRef, Operation: kappcorev1.ResourceChangeOperation_RESOURCE_CHANGE_OPERATION_UPDATE},
+				{ResourceRef: valuesSecretRef, Operation: kappcorev1.ResourceChangeOperation_RESOURCE_CHANGE_OPERATION_UPDATE},
+			},
+			expectedDiffs: []string{"constraints: 1.2.4", "values.yaml: <-- value not shown"},
+		},
+		{
+			name: "it previews the deletion of the values",
+			request: &kappcorev1.GetInstalledPackageChangePreviewRequest{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context:    defaultContext,
+					Plugin:     &pluginDetail,
+					Identifier: "my-installation",
+				},
+				PkgVersionReference: &corev1.VersionReference{Version: "1.2.3"},
+			},
+			expectedChanges: []*kappcorev1.ResourceChange{
+				{ResourceRef: pkgInstallRef, Operation: kappcorev1.ResourceChangeOperation_RESOURCE_CHANGE_OPERATION_UPDATE},
+				{ResourceRef: valuesSecretRef, Operation: kappcorev1.ResourceChangeOperation_RESOURCE_CHANGE_OPERATION_DELETE},
+			},
+			expectedDiffs: []string{"secretRef:", "values.yaml: <-- value not shown"},
+		},
+		{
+			name: "it returns not found for a missing installed package",
+			request: &kappcorev1.GetInstalledPackageChangePreviewRequest{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context:    defaultContext,
+					Plugin:     &pluginDetail,
+					Identifier: "other-installation",
+				},
+				PkgVersionReference: &corev1.VersionReference{Version: "1.2.3"},
+			},
+			expectedStatusCode: connect.CodeNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			unstructuredContent, _ := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(pkgInstall)
+			typedClient := typfake.NewSimpleClientset(valuesSecret.DeepCopy())
+			s := Server{
+				pluginConfig: defaultPluginConfig,
+				clientGetter: clientgetter.NewBuilder().
+					WithTyped(typedClient).
+					WithDynamic(dynfake.NewSimpleDynamicClientWithCustomListKinds(
+						k8sruntime.NewScheme(),
+						map[schema.GroupVersionResource]string{
+							{Group: packagingv1alpha1.SchemeGroupVersion.Group, Version: packagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgInstallsResource}: pkgInstallResource + "List",
+						},
+						&unstructured.Unstructured{Object: unstructuredContent},
+					)).Build(),
+			}
+
+			response, err := s.GetInstalledPackageChangePreview(context.Background(), connect.NewRequest(tc.request))
+
+			if got, want := connect.CodeOf(err), tc.expectedStatusCode; err != nil && got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+			if tc.expectedStatusCode != 0 {
+				return
+			}
+
+			ignoredDiff := cmpopts.IgnoreFields(kappcorev1.ResourceChange{}, "Diff")
+			if got, want := response.Msg.GetChanges(), tc.expectedChanges; !cmp.Equal(want, got, ignoreUnexported, ignoredDiff) {
+				t.Fatalf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexported, ignoredDiff))
+			}
+			for i, expectedDiff := range tc.expectedDiffs {
+				if got := response.Msg.GetChanges()[i].GetDiff(); !strings.Contains(got, expectedDiff) {
+					t.Errorf("got: %q, want a diff containing: %q", got, expectedDiff)
+				}
+			}
+
+			// The preview does not apply any change.
+			secret, err := typedClient.CoreV1().Secrets("default").Get(context.Background(), valuesSecret.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if got, want := string(secret.Data["values.yaml"]), "foo: bar"; got != want {
+				t.Errorf("got: %q, want: %q", got, want)
+			}
+		})
+	}
+}
+
+func TestGetInstalledPackageReconcileHistory(t *testing.T) {
+	pkgInstall := &packagingv1alpha1.PackageInstall{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       pkgInstallResource,
+			APIVersion: packagingAPIVersion,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "my-installation",
+			UID:       "my-installation-uid",
+		},
+		Spec: packagingv1alpha1.PackageInstallSpec{
+			ServiceAccountName: "default",
+			PackageRef: &packagingv1alpha1.PackageRef{
+				RefName: "tetris.foo.example.com",
+				VersionSelection: &vendirversions.VersionSelectionSemver{
+					Constraints: "1.2.4",
+				},
+			},
+		},
+		Status: packagingv1alpha1.PackageInstallStatus{
+			Version:              "1.2.3",
+			LastAttemptedVersion: "1.2.4",
+		},
+	}
+	app := &kappctrlv1alpha1.App{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       appResource,
+			APIVersion: kappctrlAPIVersion,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "my-installation",
+		},
+		Status: kappctrlv1alpha1.AppStatus{
+			GenericStatus: kappctrlv1alpha1.GenericStatus{
+				Conditions: []kappctrlv1alpha1.Condition{{Type: kappctrlv1alpha1.ReconcileFailed, Status: k8scorev1.ConditionTrue}},
+			},
+			Fetch: &kappctrlv1alpha1.AppStatusFetch{
+				StartedAt: metav1.NewTime(time.Date(2023, time.May, 4, 10, 0, 0, 0, time.UTC)),
+				UpdatedAt: metav1.NewTime(time.Date(2023, time.May, 4, 10, 0, 5, 0, time.UTC)),
+				ExitCode:  1,
+				Error:     "unable to fetch the bundle",
+			},
+			ConsecutiveReconcileFailures: 3,
+		},
+	}
+	lastOutcome := &kappcorev1.ReconcileOutcome{
+		Version:          "1.2.4",
+		StartedAt:        "2023-05-04T10:00:00Z",
+		FinishedAt:       "2023-05-04T10:00:05Z",
+		Duration:         "5s",
+		Error:            "The fetch phase failed: unable to fetch the bundle",
+		ConsecutiveCount: 3,
+	}
+	previousOutcome := &kappcorev1.ReconcileOutcome{
+		Version:          "1.2.3",
+		Succeeded:        true,
+		StartedAt:        "2023-05-04T09:00:00Z",
+		FinishedAt:       "2023-05-04T09:00:30Z",
+		Duration:         "30s",
+		ConsecutiveCount: 7,
+	}
+	historyConfigMap := func(outcomes ...*kappcorev1.ReconcileOutcome) *k8scorev1.ConfigMap {
+		data, _ := protojson.Marshal(&kappcorev1.GetInstalledPackageReconcileHistoryResponse{Outcomes: outcomes})
+		return &k8scorev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "my-installation-default-reconcile-history",
+			},
+			Data: map[string]string{reconcileHistoryKey: string(data)},
+		}
+	}
+	request := &kappcorev1.GetInstalledPackageReconcileHistoryRequest{
+		InstalledPackageRef: &corev1.InstalledPackageReference{
+			Context:    defaultContext,
+			Plugin:     &pluginDetail,
+			Identifier: "my-installation",
+		},
+	}
+
+	testCases := []struct {
+		name                 string
+		request              *kappcorev1.GetInstalledPackageReconcileHistoryRequest
+		existingObjects      []k8sruntime.Object
+		existingTypedObjects []k8sruntime.Object
+		historyLimit         int
+		expectedOutcomes     []*kappcorev1.ReconcileOutcome
+		expectedStatusCode   connect.Code
+	}{
+		{
+			name:             "it records the outcome of the last reconciliation",
+			request:          request,
+			existingObjects:  []k8sruntime.Object{pkgInstall, app},
+			historyLimit:     10,
+			expectedOutcomes: []*kappcorev1.ReconcileOutcome{lastOutcome},
+		},
+		{
+			name:                 "it returns the outcome of the last reconciliation before the recorded ones",
+			request:              request,
+			existingObjects:      []k8sruntime.Object{pkgInstall, app},
+			existingTypedObjects: []k8sruntime.Object{historyConfigMap(previousOutcome)},
+			historyLimit:         10,
+			expectedOutcomes:     []*kappcorev1.ReconcileOutcome{lastOutcome, previousOutcome},
+		},
+		{
+			name:                 "it keeps the number of outcomes within the limit",
+			request:              request,
+			existingObjects:      []k8sruntime.Object{pkgInstall, app},
+			existingTypedObjects: []k8sruntime.Object{historyConfigMap(previousOutcome)},
+			historyLimit:         1,
+			expectedOutcomes:     []*kappcorev1.ReconcileOutcome{lastOutcome},
+		},
+		{
+			name:                 "it returns the recorded outcomes without recording while disabled",
+			request:              request,
+			existingObjects:      []k8sruntime.Object{pkgInstall, app},
+			existingTypedObjects: []k8sruntime.Object{historyConfigMap(previousOutcome)},
+			expectedOutcomes:     []*kappcorev1.ReconcileOutcome{previousOutcome},
+		},
+		{
+			name:            "it returns an empty history before the App is created",
+			request:         request,
+			existingObjects: []k8sruntime.Object{pkgInstall},
+			historyLimit:    10,
+		},
+		{
+			name:               "it returns not found for a missing installed package",
+			request:            request,
+			historyLimit:       10,
+			expectedStatusCode: connect.CodeNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var unstructuredObjects []k8sruntime.Object
+			for _, obj := range tc.existingObjects {
+				unstructuredContent, _ := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(obj)
+				unstructuredObjects = append(unstructuredObjects, &unstructured.Unstructured{Object: unstructuredContent})
+			}
+			typedClient := typfake.NewSimpleClientset(tc.existingTypedObjects...)
+			pluginConfig := *defaultPluginConfig
+			pluginConfig.reconcileHistoryLimit = tc.historyLimit
+			s := Server{
+				pluginConfig: &pluginConfig,
+				clientGetter: clientgetter.NewBuilder().
+					WithTyped(typedClient).
+					WithDynamic(dynfake.NewSimpleDynamicClientWithCustomListKinds(
+						k8sruntime.NewScheme(),
+						map[schema.GroupVersionResource]string{
+							{Group: packagingv1alpha1.SchemeGroupVersion.Group, Version: packagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgInstallsResource}: pkgInstallResource + "List",
+						},
+						unstructuredObjects...,
+					)).Build(),
+			}
+
+			response, err := s.GetInstalledPackageReconcileHistory(context.Background(), connect.NewRequest(tc.request))
+
+			if got, want := connect.CodeOf(err), tc.expectedStatusCode; err != nil && got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+			if tc.expectedStatusCode != 0 {
+				return
+			}
+
+			if got, want := response.Msg.GetOutcomes(), tc.expectedOutcomes; !cmp.Equal(want, got, ignoreUnexported) {
+				t.Fatalf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexported))
+			}
+
+			// The returned history is the recorded one.
+			if tc.historyLimit > 0 && len(tc.expectedOutcomes) > 0 {
+				configMap, err := typedClient.CoreV1().ConfigMaps("default").Get(context.Background(), "my-installation-default-reconcile-history", metav1.GetOptions{})
+				if err != nil {
+					t.Fatalf("%+v", err)
+				}
+				recorded := &kappcorev1.GetInstalledPackageReconcileHistoryResponse{}
+				if err := protojson.Unmarshal([]byte(configMap.Data[reconcileHistoryKey]), recorded); err != nil {
+					t.Fatalf("%+v", err)
+				}
+				if got, want := recorded.GetOutcomes(), tc.expectedOutcomes; !cmp.Equal(want, got, ignoreUnexported) {
+					t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexported))
+				}
+			}
+		})
+	}
+}
+
+func TestGetInstalledPackageManifest(t *testing.T) {
+	pkgInstall := &packagingv1alpha1.PackageInstall{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       pkgInstallResource,
+			APIVersion: packagingAPIVersion,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:     "default",
+			Name:          "my-installation",
+			ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "kubeapps-apis"}},
+		},
+		Spec: packagingv1alpha1.PackageInstallSpec{
+			ServiceAccountName: "default",
+			PackageRef: &packagingv1alpha1.PackageRef{
+				RefName: "tetris.foo.example.com",
+			},
+		},
+	}
+	app := &kappctrlv1alpha1.App{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       appResource,
+			APIVersion: kappctrlAPIVersion,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "my-installation",
+		},
+		Spec: kappctrlv1alpha1.AppSpec{
+			ServiceAccountName: "default",
+		},
+	}
+	installedPackageRef := &corev1.InstalledPackageReference{
+		Context:    defaultContext,
+		Plugin:     &pluginDetail,
+		Identifier: "my-installation",
+	}
+
+	testCases := []struct {
+		name               string
+		request            *kappcorev1.GetInstalledPackageManifestRequest
+		existingObjects    []k8sruntime.Object
+		advancedMode       bool
+		expectedManifest   string
+		expectedStatusCode connect.Code
+	}{
+		{
+			name: "it returns the manifest of the PackageInstall without its managed fields",
+			request: &kappcorev1.GetInstalledPackageManifestRequest{
+				InstalledPackageRef: installedPackageRef,
+			},
+			existingObjects: []k8sruntime.Object{pkgInstall, app},
+			advancedMode:    true,
+			expectedManifest: `apiVersion: packaging.carvel.dev/v1alpha1
+kind: PackageInstall
+metadata:
+  creationTimestamp: null
+  name: my-installation
+  namespace: default
+spec:
+  packageRef:
+    refName: tetris.foo.example.com
+  serviceAccountName: default
+status:
+  conditions: null
+  friendlyDescription: ""
+  observedGeneration: 0
+`,
+		},
+		{
+			name: "it returns the manifest of the App",
+			request: &kappcorev1.GetInstalledPackageManifestRequest{
+				InstalledPackageRef: installedPackageRef,
+				Kind:                kappcorev1.InstalledPackageManifestKind_INSTALLED_PACKAGE_MANIFEST_KIND_APP,
+			},
+			existingObjects: []k8sruntime.Object{pkgInstall, app},
+			advancedMode:    true,
+			expectedManifest: `apiVersion: kappctrl.k14s.io/v1alpha1
+kind: App
+metadata:
+  creationTimestamp: null
+  name: my-installation
+  namespace: default
+spec:
+  serviceAccountName: default
+status:
+  conditions: null
+  friendlyDescription: ""
+  observedGeneration: 0
+`,
+		},
+		{
+			name: "it returns not found for a missing installed package",
+			request: &kappcorev1.GetInstalledPackageManifestRequest{
+				InstalledPackageRef: installedPackageRef,
+			},
+			advancedMode:       true,
+			expectedStatusCode: connect.CodeNotFound,
+		},
+		{
+			name: "it returns failed precondition when the advanced mode is disabled",
+			request: &kappcorev1.GetInstalledPackageManifestRequest{
+				InstalledPackageRef: installedPackageRef,
+			},
+			existingObjects:    []k8sruntime.Object{pkgInstall, app},
+			expectedStatusCode: connect.CodeFailedPrecondition,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var unstructuredObjects []k8sruntime.Object
+			for _, obj := range tc.existingObjects {
+				unstructuredContent, _ := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(obj)
+				unstructuredObjects = append(unstructuredObjects, &unstructured.Unstructured{Object: unstructuredContent})
+			}
+			pluginConfig := *defaultPluginConfig
+			pluginConfig.advancedMode = tc.advancedMode
+			s := Server{
+				pluginConfig: &pluginConfig,
+				clientGetter: clientgetter.NewBuilder().
+					WithDynamic(dynfake.NewSimpleDynamicClientWithCustomListKinds(
+						k8sruntime.NewScheme(),
+						map[schema.GroupVersionResource]string{
+							{Group: packagingv1alpha1.SchemeGroupVersion.Group, Version: packagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgInstallsResource}: pkgInstallResource + "List",
+						},
+						unstructuredObjects...,
+					)).Build(),
+			}
+
+			response, err := s.GetInstalledPackageManifest(context.Background(), connect.NewRequest(tc.request))
+
+			if got, want := connect.CodeOf(err), tc.expectedStatusCode; err != nil && got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+			if tc.expectedStatusCode != 0 {
+				if err == nil {
+					t.Fatalf("got: nil, want: error with code %+v", tc.expectedStatusCode)
+				}
+				return
+			}
+
+			if got, want := response.Msg.GetManifest(), tc.expectedManifest; got != want {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
+func TestUpdateInstalledPackageManifest(t *testing.T) {
+	pkgInstall := &packagingv1alpha1.PackageInstall{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       pkgInstallResource,
+			APIVersion: packagingAPIVersion,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "my-installation",
+			Annotations: map[string]string{
+				annotationManagedByKey: annotationManagedByValue,
+				"team":                 "tetris",
+			},
+		},
+		Spec: packagingv1alpha1.PackageInstallSpec{
+			ServiceAccountName: "default",
+			PackageRef: &packagingv1alpha1.PackageRef{
+				RefName: "tetris.foo.example.com",
+			},
+			Values: []packagingv1alpha1.PackageInstallValues{
+				{SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{Name: "my-installation-default-values"}},
+			},
+		},
+	}
+	// edit returns the manifest of the PackageInstall once edited.
+	edit := func(edit func(pkgInstall *packagingv1alpha1.PackageInstall)) string {
+		edited := pkgInstall.DeepCopy()
+		edit(edited)
+		manifest, _ := yaml.Marshal(edited)
+		return string(manifest)
+	}
+	installedPackageRef := &corev1.InstalledPackageReference{
+		Context:    defaultContext,
+		Plugin:     &pluginDetail,
+		Identifier: "my-installation",
+	}
+	updateRequest := func(manifest string) *kappcorev1.UpdateInstalledPackageManifestRequest {
+		return &kappcorev1.UpdateInstalledPackageManifestRequest{
+			InstalledPackageRef: installedPackageRef,
+			Manifest:            manifest,
+		}
+	}
+
+	testCases := []struct {
+		name                   string
+		request                *kappcorev1.UpdateInstalledPackageManifestRequest
+		advancedMode           bool
+		updateError            error
+		expectedServiceAccount string
+		expectedStatusCode     connect.Code
+	}{
+		{
+			name: "it updates the PackageInstall with the manifest",
+			request: updateRequest(edit(func(pkgInstall *packagingv1alpha1.PackageInstall) {
+				pkgInstall.Spec.ServiceAccountName = "tetris-sa"
+				pkgInstall.Annotations["team"] = "puzzles"
+			})),
+			advancedMode:           true,
+			expectedServiceAccount: "tetris-sa",
+		},
+		{
+			name: "it rejects a manifest changing a protected annotation",
+			request: updateRequest(edit(func(pkgInstall *packagingv1alpha1.PackageInstall) {
+				delete(pkgInstall.Annotations, annotationManagedByKey)
+			})),
+			advancedMode:       true,
+			expectedStatusCode: connect.CodeInvalidArgument,
+		},
+		{
+			name: "it rejects a manifest changing the package",
+			request: updateRequest(edit(func(pkgInstall *packagingv1alpha1.PackageInstall) {
+				pkgInstall.Spec.PackageRef.RefName = "other.foo.example.com"
+			})),
+			advancedMode:       true,
+			expectedStatusCode: connect.CodeInvalidArgument,
+		},
+		{
+			name: "it rejects a manifest changing the version",
+			request: updateRequest(edit(func(pkgInstall *packagingv1alpha1.PackageInstall) {
+				pkgInstall.Spec.PackageRef.VersionSelection = &vendirversions.VersionSelectionSemver{Constraints: "2.0.0"}
+			})),
+			advancedMode:       true,
+			expectedStatusCode: connect.CodeInvalidArgument,
+		},
+		{
+			name: "it rejects a manifest removing the values of the installed package",
+			request: updateRequest(edit(func(pkgInstall *packagingv1alpha1.PackageInstall) {
+				pkgInstall.Spec.Values = nil
+			})),
+			advancedMode:       true,
+			expectedStatusCode: connect.CodeInvalidArgument,
+		},
+		{
+			name: "it rejects a manifest of another resource",
+			request: updateRequest(edit(func(pkgInstall *packagingv1alpha1.PackageInstall) {
+				pkgInstall.Name = "other-installation"
+			})),
+			advancedMode:       true,
+			expectedStatusCode: connect.CodeInvalidArgument,
+		},
+		{
+			name:               "it rejects an invalid YAML manifest",
+			request:            updateRequest("spec: [unclosed"),
+			advancedMode:       true,
+			expectedStatusCode: connect.CodeInvalidArgument,
+		},
+		{
+			name:               "it returns invalid argument when the update is rejected by the validation of the cluster",
+			request:            updateRequest(edit(func(pkgInstall *packagingv1alpha1.PackageInstall) {})),
+			advancedMode:       true,
+			updateError:        k8sErrors.NewInvalid(schema.GroupKind{Group: packagingv1alpha1.SchemeGroupVersion.Group, Kind: pkgInstallResource}, "my-installation", nil),
+			expectedStatusCode: connect.CodeInvalidArgument,
+		},
+		{
+			name:               "it returns aborted when the PackageInstall changed meanwhile",
+			request:            updateRequest(edit(func(pkgInstall *packagingv1alpha1.PackageInstall) {})),
+			advancedMode:       true,
+			updateError:        k8sErrors.NewConflict(schema.GroupResource{Group: packagingv1alpha1.SchemeGroupVersion.Group, Resource: pkgInstallsResource}, "my-installation", nil),
+			expectedStatusCode: connect.CodeAborted,
+		},
+		{
+			name:               "it returns failed precondition when the advanced mode is disabled",
+			request:            updateRequest(edit(func(pkgInstall *packagingv1alpha1.PackageInstall) {})),
+			expectedStatusCode: connect.CodeFailedPrecondition,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			unstructuredContent, _ := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(pkgInstall)
+			dynamicClient := dynfake.NewSimpleDynamicClientWithCustomListKinds(
+				k8sruntime.NewScheme(),
+				map[schema.GroupVersionResource]string{
+					{Group: packagingv1alpha1.SchemeGroupVersion.Group, Version: packagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgInstallsResource}: pkgInstallResource + "List",
+				},
+				&unstructured.Unstructured{Object: unstructuredContent},
+			)
+			if tc.updateError != nil {
+				dynamicClient.PrependReactor("update", pkgInstallsResource, func(action k8stesting.Action) (handled bool, ret k8sruntime.Object, err error) {
+					return true, nil, tc.updateError
+				})
+			}
+			pluginConfig := *defaultPluginConfig
+			pluginConfig.advancedMode = tc.advancedMode
+			s := Server{
+				pluginConfig: &pluginConfig,
+				clientGetter: clientgetter.NewBuilder().WithDynamic(dynamicClient).Build(),
+			}
+
+			response, err := s.UpdateInstalledPackageManifest(context.Background(), connect.NewRequest(tc.request))
+
+			if got, want := connect.CodeOf(err), tc.expectedStatusCode; err != nil && got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+			if tc.expectedStatusCode != 0 {
+				if err == nil {
+					t.Fatalf("got: nil, want: error with code %+v", tc.expectedStatusCode)
+				}
+				return
+			}
+
+			updated := &packagingv1alpha1.PackageInstall{}
+			if err := yaml.Unmarshal([]byte(response.Msg.GetManifest()), updated); err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if got, want := updated.Spec.ServiceAccountName, tc.expectedServiceAccount; got != want {
+				t.Errorf("got: %q, want: %q", got, want)
+			}
+		})
+	}
+}
+
+func TestCheckInstalledPackageNameAvailability(t *testing.T) {
+	existingPkgInstall := &packagingv1alpha1.PackageInstall{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       pkgInstallResource,
+			APIVersion: packagingAPIVersion,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "my-installation",
+		},
+		Spec: packagingv1alpha1.PackageInstallSpec{
+			ServiceAccountName: "default",
+			PackageRef: &packagingv1alpha1.PackageRef{
+				RefName: "tetris.foo.example.com",
+			},
+		},
+	}
+
+	testCases := []struct {
+		name             string
+		request          *corev1.CheckInstalledPackageNameAvailabilityRequest
+		expectedResponse *corev1.CheckInstalledPackageNameAvailabilityResponse
+	}{
+		{
+			name: "reports an unused name as available",
+			request: &corev1.CheckInstalledPackageNameAvailabilityRequest{
+				TargetContext: defaultContext,
+				Name:          "other-installation",
+			},
+			expectedResponse: &corev1.CheckInstalledPackageNameAvailabilityResponse{
+				Available: true,
+				Conflicts: []*corev1.InstalledPackageReference{},
+			},
+		},
+		{
+			name: "reports the package install using the name",
+			request: &corev1.CheckInstalledPackageNameAvailabilityRequest{
+				TargetContext: defaultContext,
+				Name:          "my-installation",
+			},
+			expectedResponse: &corev1.CheckInstalledPackageNameAvailabilityResponse{
+				Available: false,
+				Conflicts: []*corev1.InstalledPackageReference{
+					{
+						Context:    defaultContext,
+						Plugin:     &pluginDetail,
+						Identifier: "my-installation",
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			unstructuredContent, _ := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(existingPkgInstall)
+
+			s := Server{
+				pluginConfig: defaultPluginConfig,
+				clientGetter: clientgetter.NewBuilder().
+					WithDynamic(dynfake.NewSimpleDynamicClientWithCustomListKinds(
+						k8sruntime.NewScheme(),
+						map[schema.GroupVersionResource]string{
+							{Group: packagingv1alpha1.SchemeGroupVersion.Group, Version: packagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgInstallsResource}: pkgInstallResource + "List",
+						},
+						&unstructured.Unstructured{Object: unstructuredContent},
+					)).
+					Build(),
+			}
+
+			response, err := s.CheckInstalledPackageNameAvailability(context.Background(), connect.NewRequest(tc.request))
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if got, want := response.Msg, tc.expectedResponse; !cmp.Equal(want, got, ignoreUnexported) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexported))
+			}
+		})
+	}
+}
+
+func TestDeleteInstalledPackage(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		request              *corev1.DeleteInstalledPackageRequest
+		existingObjects      []k8sruntime.Object
+		existingTypedObjects []k8sruntime.Object
+		expectedErrorCode    connect.Code
+		expectedResponse     *corev1.DeleteInstalledPackageResponse
+	}{
+		{
+			name: "deletes installed package",
+			request: &corev1.DeleteInstalledPackageRequest{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context:    defaultContext,
+					Plugin:     &pluginDetail,
+					Identifier: "my-installation",
+				},
+			},
+			existingObjects: []k8sruntime.Object{
+				&packagingv1alpha1.PackageInstall{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       pkgInstallResource,
+						APIVersion: packagingAPIVersion,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "my-installation",
+					},
+					Spec: packagingv1alpha1.PackageInstallSpec{
+						ServiceAccountName: "default",
+						PackageRef: &packagingv1alpha1.PackageRef{
+							RefName: "tetris.foo.example.com",
+							VersionSelection: &vendirversions.VersionSelectionSemver{
+								Constraints: "1.2.3",
+							},
+						},
+						Values: []packagingv1alpha1.PackageInstallValues{{
+							SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{
+								Name: "my-installation-default-values",
+							},
+						},
+						},
+						Paused:     false,
+						Canceled:   false,
+						SyncPeriod: &metav1.Duration{Duration: (time.Second * 30)},
+						NoopDelete: false,
+					},
+					Status: packagingv1alpha1.PackageInstallStatus{
+						GenericStatus: kappctrlv1alpha1.GenericStatus{
+							ObservedGeneration: 1,
+							Conditions: []kappctrlv1alpha1.Condition{{
+								Type:    kappctrlv1alpha1.ReconcileSucceeded,
+								Status:  k8scorev1.ConditionTrue,
+								Reason:  "baz",
+								Message: "qux",
+							}},
+							FriendlyDescription: "foo",
+							UsefulErrorMessage:  "Deployed",
+						},
+						Version:              "1.2.3",
+						LastAttemptedVersion: "1.2.3",
+					},
+				},
+			},
+			existingTypedObjects: []k8sruntime.Object{
+				&k8scorev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "my-installation-default-values",
+					},
+					Type: "Opaque",
+					Data: map[string][]byte{
+						"values.yaml": []byte("foo: bar"),
+					},
+				},
+			},
+			expectedResponse: &corev1.DeleteInstalledPackageResponse{},
+		},
+		{
+			name: "returns not found if installed package doesn't exist",
+			request: &corev1.DeleteInstalledPackageRequest{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context:    defaultContext,
+					Plugin:     &pluginDetail,
+					Identifier: "noy-my-installation",
+				},
+			},
+			existingObjects: []k8sruntime.Object{
+				&packagingv1alpha1.PackageInstall{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       pkgInstallResource,
+						APIVersion: packagingAPIVersion,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "my-installation",
+					},
+					Spec: packagingv1alpha1.PackageInstallSpec{
+						ServiceAccountName: "default",
+						PackageRef: &packagingv1alpha1.PackageRef{
+							RefName: "tetris.foo.example.com",
+							VersionSelection: &vendirversions.VersionSelectionSemver{
+								Constraints: "1.2.3",
+							},
+						},
+						Values: []packagingv1alpha1.PackageInstallValues{{
+							SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{
+								Name: "my-installation-default-values",
+							},
+						},
+						},
+						Paused:     false,
+						Canceled:   false,
+						SyncPeriod: &metav1.Duration{Duration: (time.Second * 30)},
+						NoopDelete: false,
+					},
+					Status: packagingv1alpha1.PackageInstallStatus{
+						GenericStatus: kappctrlv1alpha1.GenericStatus{
+							ObservedGeneration: 1,
+							Conditions: []kappctrlv1alpha1.Condition{{
+								Type:    kappctrlv1alpha1.ReconcileSucceeded,
+								Status:  k8scorev1.ConditionTrue,
+								Reason:  "baz",
+								Message: "qux",
+							}},
+							FriendlyDescription: "foo",
+							UsefulErrorMessage:  "Deployed",
+						},
+						Version:              "1.2.3",
+						LastAttemptedVersion: "1.2.3",
+					},
+				},
+			},
+			existingTypedObjects: []k8sruntime.Object{
+				&k8scorev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "my-installation-default-values",
+					},
+					Type: "Opaque",
+					Data: map[string][]byte{
+						"values.yaml": []byte("foo: bar"),
+					},
+				},
+			},
+			expectedErrorCode: connect.CodeNotFound,
+			expectedResponse:  &corev1.DeleteInstalledPackageResponse{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var unstructuredObjects []k8sruntime.Object
+			for _, obj := range tc.existingObjects {
+				unstructuredContent, _ := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(obj)
+				unstructuredObjects = append(unstructuredObjects, &unstructured.Unstructured{Object: unstructuredContent})
+			}
+
+			s := Server{
+				pluginConfig: defaultPluginConfig,
+				clientGetter: clientgetter.NewBuilder().
+					WithTyped(typfake.NewSimpleClientset(tc.existingTypedObjects...)).
+					WithDynamic(dynfake.NewSimpleDynamicClientWithCustomListKinds(
+						k8sruntime.NewScheme(),
+						map[schema.GroupVersionResource]string{
+							{Group: datapackagingv1alpha1.SchemeGroupVersion.Group, Version: datapackagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgsResource}:         pkgResource + "List",
+							{Group: datapackagingv1alpha1.SchemeGroupVersion.Group, Version: datapackagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgMetadatasResource}: pkgMetadataResource + "List",
+							{Group: packagingv1alpha1.SchemeGroupVersion.Group, Version: packagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgRepositoriesResource}:      pkgRepositoryResource + "List",
+							{Group: packagingv1alpha1.SchemeGroupVersion.Group, Version: packagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgInstallsResource}:          pkgInstallResource + "List",
+						},
+						unstructuredObjects...,
+					)).
+					Build(),
+			}
+
+			deleteInstalledPackageResponse, err := s.DeleteInstalledPackage(context.Background(), connect.NewRequest(tc.request))
+
+			if got, want := connect.CodeOf(err), tc.expectedErrorCode; err != nil && got != want {
+				t.Fatalf("got: %d, want: %d, err: %+v", got, want, err)
+			}
+			// If we were expecting an error, continue to the next test.
+			if tc.expectedErrorCode != 0 {
+				return
+			}
+			if got, want := deleteInstalledPackageResponse.Msg, tc.expectedResponse; !cmp.Equal(want, got, ignoreUnexported) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexported))
+			}
+		})
+	}
+}
+
+func TestDeleteInstalledPackageOrphaning(t *testing.T) {
+	pkgInstall := &packagingv1alpha1.PackageInstall{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       pkgInstallResource,
+			APIVersion: packagingAPIVersion,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "my-installation",
+			UID:       "my-installation-uid",
+		},
+		Spec: packagingv1alpha1.PackageInstallSpec{
+			ServiceAccountName: "my-installation-default-sa",
+			PackageRef: &packagingv1alpha1.PackageRef{
+				RefName: "tetris.foo.example.com",
+				VersionSelection: &vendirversions.VersionSelectionSemver{
+					Constraints: "1.2.3",
+				},
+			},
+			Values: []packagingv1alpha1.PackageInstallValues{{
+				SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{
+					Name: "my-installation-default-values",
+				},
+			}},
+		},
+	}
+	ownerReferences := []metav1.OwnerReference{pkgInstallOwnerReference(pkgInstall)}
+	objectMeta := func(name string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{Namespace: "default", Name: name, OwnerReferences: ownerReferences}
+	}
+	existingTypedObjects := func() []k8sruntime.Object {
+		return []k8sruntime.Object{
+			&k8scorev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "my-installation-default-values"},
+				Data:       map[string][]byte{"values.yaml": []byte("foo: bar")},
+			},
+			&k8scorev1.ServiceAccount{ObjectMeta: objectMeta("my-installation-default-sa")},
+			&rbacv1.Role{ObjectMeta: objectMeta("my-installation-default-role")},
+			&rbacv1.RoleBinding{ObjectMeta: objectMeta("my-installation-default-rolebinding")},
+			&k8scorev1.Secret{ObjectMeta: objectMeta("my-installation-default-registry-creds")},
+		}
+	}
+	customDetail := func(detail *kappcorev1.KappControllerInstalledPackageCustomDetail) *anypb.Any {
+		a, err := anypb.New(detail)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		return a
+	}
+
+	testCases := []struct {
+		name                  string
+		customDetail          *anypb.Any
+		expectedErrorCode     connect.Code
+		expectedNoopDelete    bool
+		expectedValuesDeleted bool
+		expectedOrphaned      bool
+	}{
+		{
+			name:                  "deletes the resources without custom detail",
+			expectedValuesDeleted: true,
+		},
+		{
+			name:                  "sets noopDelete on the PackageInstall before deleting it",
+			customDetail:          customDetail(&kappcorev1.KappControllerInstalledPackageCustomDetail{NoopDelete: true}),
+			expectedNoopDelete:    true,
+			expectedValuesDeleted: true,
+		},
+		{
+			name:               "orphans the resources created alongside the PackageInstall",
+			customDetail:       customDetail(&kappcorev1.KappControllerInstalledPackageCustomDetail{OrphanResources: true}),
+			expectedNoopDelete: true,
+			expectedOrphaned:   true,
+		},
+		{
+			name:              "returns invalid argument if the custom detail is of another type",
+			customDetail:      &anypb.Any{TypeUrl: "type.googleapis.com/kubeappsapis.core.packages.v1alpha1.Context"},
+			expectedErrorCode: connect.CodeInvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			unstructuredContent, _ := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(pkgInstall)
+			typedClient := typfake.NewSimpleClientset(existingTypedObjects()...)
+			dynamicClient := dynfake.NewSimpleDynamicClientWithCustomListKinds(
+				k8sruntime.NewScheme(),
+				map[schema.GroupVersionResource]string{
+					{Group: packagingv1alpha1.SchemeGroupVersion.Group, Version: packagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgInstallsResource}: pkgInstallResource + "List",
+				},
+				&unstructured.Unstructured{Object: unstructuredContent},
+			)
+			s := Server{
+				pluginConfig: defaultPluginConfig,
+				clientGetter: clientgetter.NewBuilder().
+					WithTyped(typedClient).
+					WithDynamic(dynamicClient).
+					Build(),
+			}
+
+			_, err := s.DeleteInstalledPackage(context.Background(), connect.NewRequest(&corev1.DeleteInstalledPackageRequest{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context:    defaultContext,
+					Plugin:     &pluginDetail,
+					Identifier: "my-installation",
+				},
+				CustomDetail: tc.customDetail,
+			}))
+
+			if got, want := connect.CodeOf(err), tc.expectedErrorCode; err != nil && got != want {
+				t.Fatalf("got: %d, want: %d, err: %+v", got, want, err)
+			} else if err == nil && tc.expectedErrorCode != 0 {
+				t.Fatalf("got: nil, want: error with code %d", tc.expectedErrorCode)
+			}
+			if tc.expectedErrorCode != 0 {
+				return
+			}
+
+			noopDelete := false
+			for _, action := range dynamicClient.Actions() {
+				if updateAction, ok := action.(k8stesting.UpdateAction); ok && action.GetResource().Resource == pkgInstallsResource {
+					noopDelete, _, _ = unstructured.NestedBool(updateAction.GetObject().(*unstructured.Unstructured).Object, "spec", "noopDelete")
+				}
+			}
+			if got, want := noopDelete, tc.expectedNoopDelete; got != want {
+				t.Errorf("got noopDelete: %t, want: %t", got, want)
+			}
+
+			_, err = typedClient.CoreV1().Secrets("default").Get(context.Background(), "my-installation-default-values", metav1.GetOptions{})
+			if got, want := k8sErrors.IsNotFound(err), tc.expectedValuesDeleted; got != want {
+				t.Errorf("got values secret deleted: %t, want: %t", got, want)
+			}
+
+			var owned []metav1.Object
+			if sa, err := typedClient.CoreV1().ServiceAccounts("default").Get(context.Background(), "my-installation-default-sa", metav1.GetOptions{}); err == nil {
+				owned = append(owned, sa)
+			}
+			if role, err := typedClient.RbacV1().Roles("default").Get(context.Background(), "my-installation-default-role", metav1.GetOptions{}); err == nil {
+				owned = append(owned, role)
+			}
+			if roleBinding, err := typedClient.RbacV1().RoleBindings("default").Get(context.Background(), "my-installation-default-rolebinding", metav1.GetOptions{}); err == nil {
+				owned = append(owned, roleBinding)
+			}
+			if secret, err := typedClient.CoreV1().Secrets("default").Get(context.Background(), "my-installation-default-registry-creds", metav1.GetOptions{}); err == nil {
+				owned = append(owned, secret)
+			}
+			if got, want := len(owned), 4; got != want {
+				t.Fatalf("got: %d, want: %d", got, want)
+			}
+			for _, obj := range owned {
+				if got, want := len(obj.GetOwnerReferences()) == 0, tc.expectedOrphaned; got != want {
+					t.Errorf("got %q orphaned: %t, want: %t", obj.GetName(), got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestGetInstalledPackageResourceRefs(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		request              *corev1.GetInstalledPackageResourceRefsRequest
+		existingObjects      []k8sruntime.Object
+		existingTypedObjects []k8sruntime.Object
+		expectedErrorCode    connect.Code
+		expectedResponse     *corev1.GetInstalledPackageResourceRefsResponse
+	}{
+		{
+			name: "fetch the resources from an installed package (kapp < 0.47 suffix)",
+			request: &corev1.GetInstalledPackageResourceRefsRequest{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context:    defaultContext,
+					Plugin:     &pluginDetail,
+					Identifier: "my-installation",
+				},
+			},
+			existingObjects: []k8sruntime.Object{
+				&packagingv1alpha1.PackageInstall{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       pkgInstallResource,
+						APIVersion: packagingAPIVersion,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "my-installation",
+					},
+					Spec: packagingv1alpha1.PackageInstallSpec{
+						ServiceAccountName: "default",
+						PackageRef: &packagingv1alpha1.PackageRef{
+							RefName: "tetris.foo.example.com",
+							VersionSelection: &vendirversions.VersionSelectionSemver{
+								Constraints: "1.2.3",
+							},
+						},
+						Values: []packagingv1alpha1.PackageInstallValues{{
+							SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{
+								Name: "my-installation-default-values",
+							},
+						},
+						},
+						Paused:     false,
+						Canceled:   false,
+						SyncPeriod: &metav1.Duration{Duration: (time.Second * 30)},
+						NoopDelete: false,
+					},
+					Status: packagingv1alpha1.PackageInstallStatus{
+						GenericStatus: kappctrlv1alpha1.GenericStatus{
+							ObservedGeneration: 1,
+							Conditions: []kappctrlv1alpha1.Condition{{
+								Type:    kappctrlv1alpha1.ReconcileSucceeded,
+								Status:  k8scorev1.ConditionTrue,
+								Reason:  "baz",
+								Message: "qux",
+							}},
+							FriendlyDescription: "foo",
+							UsefulErrorMessage:  "foo",
+						},
+						Version:              "1.2.3",
+						LastAttemptedVersion: "1.2.3",
+					},
+				},
+				// Although it's a typical k8s object, it is retrieved with the dynamic client
+				&k8scorev1.Pod{
+					TypeMeta: metav1.TypeMeta{
+						APIVersion: "v1",
+						Kind:       "Pod",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "my-installation-pod",
+						Labels:    map[string]string{"kapp.k14s.io/app": "my-id"},
+					},
+					Spec: k8scorev1.PodSpec{
+						Containers: []k8scorev1.Container{{
+							Name: "my-installation-container",
+						}},
+					},
+				},
+			},
+			existingTypedObjects: []k8sruntime.Object{
+				&k8scorev1.ConfigMap{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       "ConfigMap",
+						APIVersion: "v1",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "my-installation-ctrl",
+					},
+					Data: map[string]string{
+						"spec": "{\"labelKey\":\"kapp.k14s.io/app\",\"labelValue\":\"my-id\"}",
+					},
+				},
+			},
+			expectedResponse: &corev1.GetInstalledPackageResourceRefsResponse{
+				ResourceRefs: []*corev1.ResourceRef{
+					{
+						ApiVersion: "v1",
+						Kind:       "Pod",
+						Name:       "my-installation-pod",
+						Namespace:  "default",
+					},
+				},
+				Context: defaultContext,
+			},
+		},
+		{
+			name: "fetch the resources from an installed package (kapp => 0.47 suffix)",
+			request: &corev1.GetInstalledPackageResourceRefsRequest{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context:    defaultContext,
+					Plugin:     &pluginDetail,
+					Identifier: "my-installation",
+				},
+			},
+			existingObjects: []k8sruntime.Object{
+				&packagingv1alpha1.PackageInstall{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       pkgInstallResource,
+						APIVersion: packagingAPIVersion,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "my-installation",
+					},
+					Spec: packagingv1alpha1.PackageInstallSpec{
+						ServiceAccountName: "default",
+						PackageRef: &packagingv1alpha1.PackageRef{
+							RefName: "tetris.foo.example.com",
+							VersionSelection: &vendirversions.VersionSelectionSemver{
+								Constraints: "1.2.3",
+							},
+						},
+						Values: []packagingv1alpha1.PackageInstallValues{{
+							SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{
+								Name: "my-installation-default-values",
+							},
+						},
+						},
+						Paused:     false,
+						Canceled:   false,
+						SyncPeriod: &metav1.Duration{Duration: (time.Second * 30)},
+						NoopDelete: false,
+					},
+					Status: packagingv1alpha1.PackageInstallStatus{
+						GenericStatus: kappctrlv1alpha1.GenericStatus{
+							ObservedGeneration: 1,
+							Conditions: []kappctrlv1alpha1.Condition{{
+								Type:    kappctrlv1alpha1.ReconcileSucceeded,
+								Status:  k8scorev1.ConditionTrue,
+								Reason:  "baz",
+								Message: "qux",
+							}},
+							FriendlyDescription: "foo",
+							UsefulErrorMessage:  "foo",
+						},
+						Version:              "1.2.3",
+						LastAttemptedVersion: "1.2.3",
+					},
+				},
+				// Although it's a typical k8s object, it is retrieved with the dynamic client
+				&k8scorev1.Pod{
+					TypeMeta: metav1.TypeMeta{
+						APIVersion: "v1",
+						Kind:       "Pod",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "my-installation-pod",
+						Labels:    map[string]string{"kapp.k14s.io/app": "my-id"},
+					},
+					Spec: k8scorev1.PodSpec{
+						Containers: []k8scorev1.Container{{
+							Name: "my-installation-container",
+						}},
+					},
+				},
+			},
+			existingTypedObjects: []k8sruntime.Object{
+				&k8scorev1.ConfigMap{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       "ConfigMap",
+						APIVersion: "v1",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "my-installation.app",
+					},
+					Data: map[string]string{
+						"spec": "{\"labelKey\":\"kapp.k14s.io/app\",\"labelValue\":\"my-id\"}",
+					},
+				},
+			},
+			expectedResponse: &corev1.GetInstalledPackageResourceRefsResponse{
+				ResourceRefs: []*corev1.ResourceRef{
+					{
+						ApiVersion: "v1",
+						Kind:       "Pod",
+						Name:       "my-installation-pod",
+						Namespace:  "default",
+					},
+				},
+				Context: defaultContext,
+			},
+		},
+		{
+			name: "returns NotFound if the app configmap is not yet available",
+			request: &corev1.GetInstalledPackageResourceRefsRequest{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context:    defaultContext,
+					Plugin:     &pluginDetail,
+					Identifier: "my-installation",
+				},
+			},
+			existingObjects: []k8sruntime.Object{
+				&packagingv1alpha1.PackageInstall{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       pkgInstallResource,
+						APIVersion: packagingAPIVersion,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "my-installation",
+					},
+					Spec: packagingv1alpha1.PackageInstallSpec{
+						ServiceAccountName: "default",
+						PackageRef: &packagingv1alpha1.PackageRef{
+							RefName: "tetris.foo.example.com",
+							VersionSelection: &vendirversions.VersionSelectionSemver{
+								Constraints: "1.2.3",
+							},
+						},
+						Values: []packagingv1alpha1.PackageInstallValues{{
+							SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{
+								Name: "my-installation-default-values",
+							},
+						},
+						},
+						Paused:     false,
+						Canceled:   false,
+						SyncPeriod: &metav1.Duration{Duration: (time.Second * 30)},
+						NoopDelete: false,
+					},
+					Status: packagingv1alpha1.PackageInstallStatus{
+						GenericStatus: kappctrlv1alpha1.GenericStatus{
+							ObservedGeneration: 1,
+							Conditions: []kappctrlv1alpha1.Condition{{
+								Type:    kappctrlv1alpha1.ReconcileSucceeded,
+								Status:  k8scorev1.ConditionTrue,
+								Reason:  "baz",
+								Message: "qux",
+							}},
+							FriendlyDescription: "foo",
+							UsefulErrorMessage:  "foo",
+						},
+						Version:              "1.2.3",
+						LastAttemptedVersion: "1.2.3",
+					},
+				},
+			},
+			expectedErrorCode: connect.CodeNotFound,
+		},
+		{
+			name: "returns NotFound if app exists but no resources found",
+			request: &corev1.GetInstalledPackageResourceRefsRequest{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context:    defaultContext,
+					Plugin:     &pluginDetail,
+					Identifier: "my-installation",
+				},
+			},
+			existingObjects: []k8sruntime.Object{
+				&packagingv1alpha1.PackageInstall{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       pkgInstallResource,
+						APIVersion: packagingAPIVersion,
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "my-installation",
+					},
+					Spec: packagingv1alpha1.PackageInstallSpec{
+						ServiceAccountName: "default",
+						PackageRef: &packagingv1alpha1.PackageRef{
+							RefName: "tetris.foo.example.com",
+							VersionSelection: &vendirversions.VersionSelectionSemver{
+								Constraints: "1.2.3",
+							},
+						},
+						Values: []packagingv1alpha1.PackageInstallValues{{
+							SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{
+								Name: "my-installation-default-values",
+							},
+						},
+						},
+						Paused:     false,
+						Canceled:   false,
+						SyncPeriod: &metav1.Duration{Duration: time.Second * 30},
+						NoopDelete: false,
+					},
+					Status: packagingv1alpha1.PackageInstallStatus{
+						GenericStatus: kappctrlv1alpha1.GenericStatus{
+							ObservedGeneration: 1,
+							Conditions: []kappctrlv1alpha1.Condition{{
+								Type:    kappctrlv1alpha1.ReconcileSucceeded,
+								Status:  k8scorev1.ConditionTrue,
+								Reason:  "baz",
+								Message: "qux",
+							}},
+							FriendlyDescription: "foo",
+							UsefulErrorMessage:  "foo",
+						},
+						Version:              "1.2.3",
+						LastAttemptedVersion: "1.2.3",
+					},
+				},
+			},
+			existingTypedObjects: []k8sruntime.Object{
+				&k8scorev1.ConfigMap{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       "ConfigMap",
+						APIVersion: "v1",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "my-installation-ctrl",
+					},
+					Data: map[string]string{
+						"spec": "{\"labelKey\":\"kapp.k14s.io/app\",\"labelValue\":\"my-id\"}",
+					},
+				},
+			},
+			expectedErrorCode: connect.CodeNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var unstructuredObjects []k8sruntime.Object
+			for _, obj := range tc.existingObjects {
+				unstructuredContent, _ := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(obj)
+				unstructuredObjects = append(unstructuredObjects, &unstructured.Unstructured{Object: unstructuredContent})
+			}
+			// If more resources types are added, this will need to be updated accordingly
+			apiResources := []*metav1.APIResourceList{
+				{
+					GroupVersion: "v1",
+					APIResources: []metav1.APIResource{
+						{Name: "pods", Namespaced: true, Kind: "Pod", Verbs: []string{"list", "get"}},
+						{Name: "configmaps", Namespaced: true, Kind: "ConfigMap", Verbs: []string{"list", "get"}},
+					},
+				},
+			}
 
 			typedClient := typfake.NewSimpleClientset(tc.existingTypedObjects...)
 
@@ -6433,6 +11156,7 @@ func TestGetInstalledPackageResourceRefs(t *testing.T) {
 				map[schema.GroupVersionResource]string{
 					{Group: datapackagingv1alpha1.SchemeGroupVersion.Group, Version: datapackagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgsResource}:         pkgResource + "List",
 					{Group: datapackagingv1alpha1.SchemeGroupVersion.Group, Version: datapackagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgMetadatasResource}: pkgMetadataResource + "List",
+					{Group: packagingv1alpha1.SchemeGroupVersion.Group, Version: packagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgRepositoriesResource}:      pkgRepositoryResource + "List",
 					{Group: packagingv1alpha1.SchemeGroupVersion.Group, Version: packagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgInstallsResource}:          pkgInstallResource + "List",
 					{Group: packagingv1alpha1.SchemeGroupVersion.Group, Version: packagingv1alpha1.SchemeGroupVersion.Version, Resource: appsResource}:                 appResource + "List",
 					// If more resources types are added, this will need to be updated accordingly
@@ -6530,6 +11254,7 @@ func TestAddPackageRepository(t *testing.T) {
 		name                 string
 		existingObjects      []k8sruntime.Object
 		existingTypedObjects []k8sruntime.Object
+		deniedSecretVerbs    []string
 		requestCustomizer    func(request *corev1.AddPackageRepositoryRequest) *corev1.AddPackageRepositoryRequest
 		repositoryCustomizer func(repository *packagingv1alpha1.PackageRepository) *packagingv1alpha1.PackageRepository
 		expectedErrorCode    connect.Code
@@ -6781,391 +11506,917 @@ func TestAddPackageRepository(t *testing.T) {
 				}
 				return request
 			},
-			expectedErrorCode:   connect.CodeInvalidArgument,
-			expectedErrorString: "unexpected REDACTED",
-		},
-		{
-			name: "create with description",
-			requestCustomizer: func(request *corev1.AddPackageRepositoryRequest) *corev1.AddPackageRepositoryRequest {
-				request.Description = "repository description"
-				return request
-			},
+			expectedErrorCode:   connect.CodeInvalidArgument,
+			expectedErrorString: "unexpected REDACTED",
+		},
+		{
+			name: "create with description",
+			requestCustomizer: func(request *corev1.AddPackageRepositoryRequest) *corev1.AddPackageRepositoryRequest {
+				request.Description = "repository description"
+				return request
+			},
+			repositoryCustomizer: func(repository *packagingv1alpha1.PackageRepository) *packagingv1alpha1.PackageRepository {
+				repository.Annotations = map[string]string{k8sutils.AnnotationDescriptionKey: "repository description"}
+				return repository
+			},
+			expectedRef: defaultRef,
+		},
+		{
+			name: "create with no interval",
+			requestCustomizer: func(request *corev1.AddPackageRepositoryRequest) *corev1.AddPackageRepositoryRequest {
+				request.Interval = ""
+				return request
+			},
+			repositoryCustomizer: func(repository *packagingv1alpha1.PackageRepository) *packagingv1alpha1.PackageRepository {
+				repository.Spec.SyncPeriod = nil
+				return repository
+			},
+			expectedRef: defaultRef,
+		},
+		{
+			name: "create with interval",
+			requestCustomizer: func(request *corev1.AddPackageRepositoryRequest) *corev1.AddPackageRepositoryRequest {
+				request.Interval = "12h"
+				return request
+			},
+			repositoryCustomizer: func(repository *packagingv1alpha1.PackageRepository) *packagingv1alpha1.PackageRepository {
+				repository.Spec.SyncPeriod = &metav1.Duration{Duration: time.Duration(12) * time.Hour}
+				return repository
+			},
+			expectedRef: defaultRef,
+		},
+		{
+			name: "create with url",
+			requestCustomizer: func(request *corev1.AddPackageRepositoryRequest) *corev1.AddPackageRepositoryRequest {
+				request.Url = "foo"
+				return request
+			},
+			repositoryCustomizer: func(repository *packagingv1alpha1.PackageRepository) *packagingv1alpha1.PackageRepository {
+				repository.Spec.Fetch.ImgpkgBundle.Image = "foo"
+				return repository
+			},
+			expectedRef: defaultRef,
+		},
+		{
+			name: "create with details (imgpkg)",
+			requestCustomizer: func(request *corev1.AddPackageRepositoryRequest) *corev1.AddPackageRepositoryRequest {
+				request.Type = typeImgPkgBundle
+				request.Url = "projects.registry.example.com/repo-1/main@sha256:abcd"
+				request.CustomDetail, _ = anypb.New(&kappcorev1.KappControllerPackageRepositoryCustomDetail{
+					Fetch: &kappcorev1.PackageRepositoryFetch{
+						ImgpkgBundle: &kappcorev1.PackageRepositoryImgpkg{
+							TagSelection: &kappcorev1.VersionSelection{
+								Semver: &kappcorev1.VersionSelectionSemver{
+									Constraints: ">0.10.0 <0.11.0",
+									Prereleases: &kappcorev1.VersionSelectionSemverPrereleases{
+										Identifiers: []string{"beta", "rc"},
+									},
+								},
+							},
+						},
+					},
+				})
+				return request
+			},
+			repositoryCustomizer: func(repository *packagingv1alpha1.PackageRepository) *packagingv1alpha1.PackageRepository {
+				repository.Spec.Fetch = &packagingv1alpha1.PackageRepositoryFetch{
+					ImgpkgBundle: &kappctrlv1alpha1.AppFetchImgpkgBundle{
+						Image: "projects.registry.example.com/repo-1/main@sha256:abcd",
+						TagSelection: &vendirversions.VersionSelection{
+							Semver: &vendirversions.VersionSelectionSemver{
+								Constraints: ">0.10.0 <0.11.0",
+								Prereleases: &vendirversions.VersionSelectionSemverPrereleases{
+									Identifiers: []string{"beta", "rc"},
+								},
+							},
+						},
+					},
+				}
+				return repository
+			},
+			expectedRef: defaultRef,
+		},
+		{
+			name: "create with details (image)",
+			requestCustomizer: func(request *corev1.AddPackageRepositoryRequest) *corev1.AddPackageRepositoryRequest {
+				request.Type = typeImage
+				request.Url = "projects.registry.example.com/repo-1/main@sha256:abcd"
+				request.CustomDetail, _ = anypb.New(&kappcorev1.KappControllerPackageRepositoryCustomDetail{
+					Fetch: &kappcorev1.PackageRepositoryFetch{
+						Image: &kappcorev1.PackageRepositoryImage{
+							SubPath: "packages",
+							TagSelection: &kappcorev1.VersionSelection{
+								Semver: &kappcorev1.VersionSelectionSemver{
+									Constraints: ">0.10.0 <0.11.0",
+									Prereleases: &kappcorev1.VersionSelectionSemverPrereleases{
+										Identifiers: []string{"beta", "rc"},
+									},
+								},
+							},
+						},
+					},
+				})
+				return request
+			},
+			repositoryCustomizer: func(repository *packagingv1alpha1.PackageRepository) *packagingv1alpha1.PackageRepository {
+				repository.Spec.Fetch = &packagingv1alpha1.PackageRepositoryFetch{
+					Image: &kappctrlv1alpha1.AppFetchImage{
+						URL:     "projects.registry.example.com/repo-1/main@sha256:abcd",
+						SubPath: "packages",
+						TagSelection: &vendirversions.VersionSelection{
+							Semver: &vendirversions.VersionSelectionSemver{
+								Constraints: ">0.10.0 <0.11.0",
+								Prereleases: &vendirversions.VersionSelectionSemverPrereleases{
+									Identifiers: []string{"beta", "rc"},
+								},
+							},
+						},
+					},
+				}
+				return repository
+			},
+			expectedRef: defaultRef,
+		},
+		{
+			name: "create with details (git)",
+			requestCustomizer: func(request *corev1.AddPackageRepositoryRequest) *corev1.AddPackageRepositoryRequest {
+				request.Type = typeGIT
+				request.Url = "https://github.com/projects.registry.vmware.com/tce/main"
+				request.CustomDetail, _ = anypb.New(&kappcorev1.KappControllerPackageRepositoryCustomDetail{
+					Fetch: &kappcorev1.PackageRepositoryFetch{
+						Git: &kappcorev1.PackageRepositoryGit{
+							SubPath: "packages",
+							Ref:     "main",
+							RefSelection: &kappcorev1.VersionSelection{
+								Semver: &kappcorev1.VersionSelectionSemver{
+									Constraints: ">0.10.0 <0.11.0",
+									Prereleases: &kappcorev1.VersionSelectionSemverPrereleases{
+										Identifiers: []string{"beta", "rc"},
+									},
+								},
+							},
+							LfsSkipSmudge: true,
+						},
+					},
+				})
+				return request
+			},
+			repositoryCustomizer: func(repository *packagingv1alpha1.PackageRepository) *packagingv1alpha1.PackageRepository {
+				repository.Spec.Fetch = &packagingv1alpha1.PackageRepositoryFetch{
+					Git: &kappctrlv1alpha1.AppFetchGit{
+						URL:     "https://github.com/projects.registry.vmware.com/tce/main",
+						Ref:     "main",
+						SubPath: "packages",
+						RefSelection: &vendirversions.VersionSelection{
+							Semver: &vendirversions.VersionSelectionSemver{
+								Constraints: ">0.10.0 <0.11.0",
+								Prereleases: &vendirversions.VersionSelectionSemverPrereleases{
+									Identifiers: []string{"beta", "rc"},
+								},
+							},
+						},
+						LFSSkipSmudge: true,
+					},
+				}
+				return repository
+			},
+			expectedRef: defaultRef,
+		},
+		{
+			name: "create with details (http)",
+			requestCustomizer: func(request *corev1.AddPackageRepositoryRequest) *corev1.AddPackageRepositoryRequest {
+				request.Type = typeHTTP
+				request.Url = "https://projects.registry.vmware.com/tce/main"
+				request.CustomDetail, _ = anypb.New(&kappcorev1.KappControllerPackageRepositoryCustomDetail{
+					Fetch: &kappcorev1.PackageRepositoryFetch{
+						Http: &kappcorev1.PackageRepositoryHttp{
+							SubPath: "packages",
+							Sha256:  "ABC",
+						},
+					},
+				})
+				return request
+			},
+			repositoryCustomizer: func(repository *packagingv1alpha1.PackageRepository) *packagingv1alpha1.PackageRepository {
+				repository.Spec.Fetch = &packagingv1alpha1.PackageRepositoryFetch{
+					HTTP: &kappctrlv1alpha1.AppFetchHTTP{
+						URL:     "https://projects.registry.vmware.com/tce/main",
+						SubPath: "packages",
+						SHA256:  "ABC",
+					},
+				}
+				return repository
+			},
+			expectedRef: defaultRef,
+		},
+		{
+			name: "validate details (package patterns)",
+			requestCustomizer: func(request *corev1.AddPackageRepositoryRequest) *corev1.AddPackageRepositoryRequest {
+				request.CustomDetail, _ = anypb.New(&kappcorev1.KappControllerPackageRepositoryCustomDetail{
+					AllowedPackages: []string{"foo.[a-z"},
+				})
+				return request
+			},
+			expectedErrorCode: connect.CodeInvalidArgument,
+		},
+		{
+			name: "create with details (package patterns)",
+			requestCustomizer: func(request *corev1.AddPackageRepositoryRequest) *corev1.AddPackageRepositoryRequest {
+				request.CustomDetail, _ = anypb.New(&kappcorev1.KappControllerPackageRepositoryCustomDetail{
+					AllowedPackages: []string{"*.example.com", "*.example.org"},
+					DeniedPackages:  []string{"tetris.*"},
+				})
+				return request
+			},
+			repositoryCustomizer: func(repository *packagingv1alpha1.PackageRepository) *packagingv1alpha1.PackageRepository {
+				repository.Annotations = map[string]string{
+					pkgutils.AnnotationAllowedPackagesKey: "*.example.com,*.example.org",
+					pkgutils.AnnotationDeniedPackagesKey:  "tetris.*",
+				}
+				return repository
+			},
+			expectedRef: defaultRef,
+		},
+		{
+			name: "create with auth (user managed)",
+			existingTypedObjects: []k8sruntime.Object{
+				&k8scorev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: defaultGlobalContext.Namespace, Name: "my-secret"},
+					Data:       map[string][]byte{k8scorev1.BasicAuthUsernameKey: []byte("foo"), k8scorev1.BasicAuthPasswordKey: []byte("bar")},
+				},
+			},
+			requestCustomizer: func(request *corev1.AddPackageRepositoryRequest) *corev1.AddPackageRepositoryRequest {
+				request.Auth = &corev1.PackageRepositoryAuth{
+					Type: corev1.PackageRepositoryAuth_PACKAGE_REPOSITORY_AUTH_TYPE_BASIC_AUTH,
+					PackageRepoAuthOneOf: &corev1.PackageRepositoryAuth_SecretRef{
+						SecretRef: &corev1.SecretKeyReference{
+							Name: "my-secret",
+						},
+					},
+				}
+				return request
+			},
 			repositoryCustomizer: func(repository *packagingv1alpha1.PackageRepository) *packagingv1alpha1.PackageRepository {
-				repository.Annotations = map[string]string{k8sutils.AnnotationDescriptionKey: "repository description"}
+				repository.Spec.Fetch.ImgpkgBundle.SecretRef = &kappctrlv1alpha1.AppFetchLocalRef{
+					Name: "my-secret",
+				}
 				return repository
 			},
 			expectedRef: defaultRef,
 		},
 		{
-			name: "create with no interval",
+			name: "create with auth (plugin managed, basic auth)",
 			requestCustomizer: func(request *corev1.AddPackageRepositoryRequest) *corev1.AddPackageRepositoryRequest {
-				request.Interval = ""
+				request.Auth = &corev1.PackageRepositoryAuth{
+					Type: corev1.PackageRepositoryAuth_PACKAGE_REPOSITORY_AUTH_TYPE_BASIC_AUTH,
+					PackageRepoAuthOneOf: &corev1.PackageRepositoryAuth_UsernamePassword{
+						UsernamePassword: &corev1.UsernamePassword{
+							Username: "foo",
+							Password: "bar",
+						},
+					},
+				}
 				return request
 			},
 			repositoryCustomizer: func(repository *packagingv1alpha1.PackageRepository) *packagingv1alpha1.PackageRepository {
-				repository.Spec.SyncPeriod = nil
+				repository.Spec.Fetch.ImgpkgBundle.SecretRef = &kappctrlv1alpha1.AppFetchLocalRef{} // the name will be empty as the fake client does not handle generating names
 				return repository
 			},
 			expectedRef: defaultRef,
+			customChecks: func(t *testing.T, s *Server) {
+				secret, err := s.getSecret(context.Background(), http.Header{}, defaultGlobalContext.Cluster, demoGlobalPackagingNamespace, "")
+				if err != nil {
+					t.Fatalf("error fetching newly created secret:%+v", err)
+				}
+				if !isPluginManaged(defaultRepository(), secret) {
+					t.Errorf("annotations and ownership was not properly set: %+v", secret)
+				}
+				if secret.StringData[k8scorev1.BasicAuthUsernameKey] != "foo" || secret.StringData[k8scorev1.BasicAuthPasswordKey] != "bar" {
+					t.Errorf("secret data was not properly constructed: %+v", secret)
+				}
+			},
 		},
 		{
-			name: "create with interval",
+			name: "create with auth (plugin managed, bearer auth)",
 			requestCustomizer: func(request *corev1.AddPackageRepositoryRequest) *corev1.AddPackageRepositoryRequest {
-				request.Interval = "12h"
+				request.Auth = &corev1.PackageRepositoryAuth{
+					Type: corev1.PackageRepositoryAuth_PACKAGE_REPOSITORY_AUTH_TYPE_BEARER,
+					PackageRepoAuthOneOf: &corev1.PackageRepositoryAuth_Header{
+						Header: "foo",
+					},
+				}
 				return request
 			},
 			repositoryCustomizer: func(repository *packagingv1alpha1.PackageRepository) *packagingv1alpha1.PackageRepository {
-				repository.Spec.SyncPeriod = &metav1.Duration{Duration: time.Duration(12) * time.Hour}
+				repository.Spec.Fetch.ImgpkgBundle.SecretRef = &kappctrlv1alpha1.AppFetchLocalRef{} // the name will be empty as the fake client does not handle generating names
 				return repository
 			},
 			expectedRef: defaultRef,
+			customChecks: func(t *testing.T, s *Server) {
+				secret, err := s.getSecret(context.Background(), http.Header{}, defaultGlobalContext.Cluster, demoGlobalPackagingNamespace, "")
+				if err != nil {
+					t.Fatalf("error fetching newly created secret:%+v", err)
+				}
+				if !isPluginManaged(defaultRepository(), secret) {
+					t.Errorf("annotations and ownership was not properly set: %+v", secret)
+				}
+				if secret.Type != k8scorev1.SecretTypeOpaque || secret.StringData[bearerAuthToken] != "foo" {
+					t.Errorf("secret data was not properly constructed: %+v", secret)
+				}
+			},
 		},
 		{
-			name: "create with url",
+			name: "create with auth (plugin managed) without permission to create secrets",
 			requestCustomizer: func(request *corev1.AddPackageRepositoryRequest) *corev1.AddPackageRepositoryRequest {
-				request.Url = "foo"
+				request.Auth = &corev1.PackageRepositoryAuth{
+					Type: corev1.PackageRepositoryAuth_PACKAGE_REPOSITORY_AUTH_TYPE_BEARER,
+					PackageRepoAuthOneOf: &corev1.PackageRepositoryAuth_Header{
+						Header: "foo",
+					},
+				}
 				return request
 			},
-			repositoryCustomizer: func(repository *packagingv1alpha1.PackageRepository) *packagingv1alpha1.PackageRepository {
-				repository.Spec.Fetch.ImgpkgBundle.Image = "foo"
-				return repository
+			deniedSecretVerbs:   []string{"create"},
+			expectedErrorCode:   connect.CodePermissionDenied,
+			expectedErrorString: "Not allowed to create secrets in the namespace [" + demoGlobalPackagingNamespace + "]",
+			customChecks: func(t *testing.T, s *Server) {
+				if _, err := s.getPkgRepository(context.Background(), http.Header{}, defaultGlobalContext.Cluster, demoGlobalPackagingNamespace, "globalrepo"); !k8sErrors.IsNotFound(err) {
+					t.Errorf("expected the repository not to be created, got: %+v", err)
+				}
 			},
-			expectedRef: defaultRef,
 		},
 		{
-			name: "create with details (imgpkg)",
+			name: "create with auth (plugin managed) without permission to update secrets",
 			requestCustomizer: func(request *corev1.AddPackageRepositoryRequest) *corev1.AddPackageRepositoryRequest {
-				request.Type = typeImgPkgBundle
-				request.Url = "projects.registry.example.com/repo-1/main@sha256:abcd"
-				request.CustomDetail, _ = anypb.New(&kappcorev1.KappControllerPackageRepositoryCustomDetail{
-					Fetch: &kappcorev1.PackageRepositoryFetch{
-						ImgpkgBundle: &kappcorev1.PackageRepositoryImgpkg{
-							TagSelection: &kappcorev1.VersionSelection{
-								Semver: &kappcorev1.VersionSelectionSemver{
-									Constraints: ">0.10.0 <0.11.0",
-									Prereleases: &kappcorev1.VersionSelectionSemverPrereleases{
-										Identifiers: []string{"beta", "rc"},
-									},
-								},
-							},
-						},
-					},
-				})
-				return request
-			},
-			repositoryCustomizer: func(repository *packagingv1alpha1.PackageRepository) *packagingv1alpha1.PackageRepository {
-				repository.Spec.Fetch = &packagingv1alpha1.PackageRepositoryFetch{
-					ImgpkgBundle: &kappctrlv1alpha1.AppFetchImgpkgBundle{
-						Image: "projects.registry.example.com/repo-1/main@sha256:abcd",
-						TagSelection: &vendirversions.VersionSelection{
-							Semver: &vendirversions.VersionSelectionSemver{
-								Constraints: ">0.10.0 <0.11.0",
-								Prereleases: &vendirversions.VersionSelectionSemverPrereleases{
-									Identifiers: []string{"beta", "rc"},
-								},
-							},
-						},
+				request.Auth = &corev1.PackageRepositoryAuth{
+					Type: corev1.PackageRepositoryAuth_PACKAGE_REPOSITORY_AUTH_TYPE_BEARER,
+					PackageRepoAuthOneOf: &corev1.PackageRepositoryAuth_Header{
+						Header: "foo",
 					},
 				}
-				return repository
+				return request
 			},
-			expectedRef: defaultRef,
+			deniedSecretVerbs:   []string{"update"},
+			expectedErrorCode:   connect.CodePermissionDenied,
+			expectedErrorString: "Not allowed to update secrets in the namespace [" + demoGlobalPackagingNamespace + "]",
 		},
 		{
-			name: "create with details (image)",
+			name: "create with auth (plugin managed, docker auth)",
 			requestCustomizer: func(request *corev1.AddPackageRepositoryRequest) *corev1.AddPackageRepositoryRequest {
-				request.Type = typeImage
-				request.Url = "projects.registry.example.com/repo-1/main@sha256:abcd"
-				request.CustomDetail, _ = anypb.New(&kappcorev1.KappControllerPackageRepositoryCustomDetail{
-					Fetch: &kappcorev1.PackageRepositoryFetch{
-						Image: &kappcorev1.PackageRepositoryImage{
-							SubPath: "packages",
-							TagSelection: &kappcorev1.VersionSelection{
-								Semver: &kappcorev1.VersionSelectionSemver{
-									Constraints: ">0.10.0 <0.11.0",
-									Prereleases: &kappcorev1.VersionSelectionSemverPrereleases{
-										Identifiers: []string{"beta", "rc"},
-									},
-								},
-							},
+				request.Auth = &corev1.PackageRepositoryAuth{
+					Type: corev1.PackageRepositoryAuth_PACKAGE_REPOSITORY_AUTH_TYPE_DOCKER_CONFIG_JSON,
+					PackageRepoAuthOneOf: &corev1.PackageRepositoryAuth_DockerCreds{
+						DockerCreds: &corev1.DockerCredentials{
+							Username: "foo",
+							Password: "bar",
+							Server:   "localhost",
+							Email:    "foo@example.com",
 						},
 					},
-				})
+				}
 				return request
 			},
 			repositoryCustomizer: func(repository *packagingv1alpha1.PackageRepository) *packagingv1alpha1.PackageRepository {
-				repository.Spec.Fetch = &packagingv1alpha1.PackageRepositoryFetch{
-					Image: &kappctrlv1alpha1.AppFetchImage{
-						URL:     "projects.registry.example.com/repo-1/main@sha256:abcd",
-						SubPath: "packages",
-						TagSelection: &vendirversions.VersionSelection{
-							Semver: &vendirversions.VersionSelectionSemver{
-								Constraints: ">0.10.0 <0.11.0",
-								Prereleases: &vendirversions.VersionSelectionSemverPrereleases{
-									Identifiers: []string{"beta", "rc"},
-								},
-							},
-						},
-					},
-				}
+				repository.Spec.Fetch.ImgpkgBundle.SecretRef = &kappctrlv1alpha1.AppFetchLocalRef{} // the name will be empty as the fake client does not handle generating names
 				return repository
 			},
-			expectedRef: defaultRef,
+			expectedRef: defaultRef,
+			customChecks: func(t *testing.T, s *Server) {
+				secret, err := s.getSecret(context.Background(), http.Header{}, defaultGlobalContext.Cluster, demoGlobalPackagingNamespace, "")
+				if err != nil {
+					t.Fatalf("error fetching newly created secret:%+v", err)
+				}
+				if !isPluginManaged(defaultRepository(), secret) {
+					t.Errorf("annotations and ownership was not properly set: %+v", secret)
+				}
+				if secret.Type != k8scorev1.SecretTypeDockerConfigJson || !strings.Contains(secret.StringData[k8scorev1.DockerConfigJsonKey], "foo@example.com") {
+					t.Errorf("secret data was not properly constructed: %+v", secret)
+				}
+				namespaces, err := s.getSecretExportNamespaces(context.Background(), http.Header{}, defaultGlobalContext.Cluster, demoGlobalPackagingNamespace, "")
+				if err != nil {
+					t.Fatalf("error fetching the SecretExport: %+v", err)
+				}
+				if got, want := namespaces, []string{"*"}; !cmp.Equal(want, got) {
+					t.Errorf("got: %+v, want: %+v", got, want)
+				}
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var unstructuredObjects []k8sruntime.Object
+			for _, obj := range tc.existingObjects {
+				unstructuredContent, _ := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(obj)
+				unstructuredObjects = append(unstructuredObjects, &unstructured.Unstructured{Object: unstructuredContent})
+			}
+
+			typedClient := typfake.NewSimpleClientset(tc.existingTypedObjects...)
+			typedClient.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (handled bool, ret k8sruntime.Object, err error) {
+				accessReview := action.(k8stesting.CreateActionImpl).Object.(*authorizationv1.SelfSubjectAccessReview)
+				allowed := true
+				for _, verb := range tc.deniedSecretVerbs {
+					if accessReview.Spec.ResourceAttributes.Resource == "secrets" && accessReview.Spec.ResourceAttributes.Verb == verb {
+						allowed = false
+					}
+				}
+				return true, &authorizationv1.SelfSubjectAccessReview{Status: authorizationv1.SubjectAccessReviewStatus{Allowed: allowed}}, nil
+			})
+			dynamicClient := dynfake.NewSimpleDynamicClientWithCustomListKinds(
+				k8sruntime.NewScheme(),
+				map[schema.GroupVersionResource]string{
+					{Group: packagingv1alpha1.SchemeGroupVersion.Group, Version: packagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgRepositoriesResource}: pkgRepositoryResource + "List",
+				},
+				unstructuredObjects...,
+			)
+
+			s := Server{
+				pluginConfig: defaultPluginConfig,
+				clientGetter: clientgetter.NewBuilder().
+					WithTyped(typedClient).
+					WithDynamic(dynamicClient).
+					Build(),
+				globalPackagingCluster: defaultGlobalContext.Cluster,
+			}
+
+			request := tc.requestCustomizer(defaultRequest())
+			response, err := s.AddPackageRepository(context.Background(), connect.NewRequest(request))
+
+			// check status
+			if got, want := connect.CodeOf(err), tc.expectedErrorCode; err != nil && got != want {
+				t.Fatalf("got error: %d, want: %d, err: %+v", got, want, err)
+			} else if got != 0 {
+				if tc.expectedErrorString != "" && !strings.Contains(fmt.Sprint(err), tc.expectedErrorString) {
+					t.Fatalf("error without expected string: expected %s, err: %+v", tc.expectedErrorString, err)
+				}
+				if tc.customChecks != nil {
+					tc.customChecks(t, &s)
+				}
+				return
+			}
+
+			// check ref
+			if got, want := response.Msg.GetPackageRepoRef(), tc.expectedRef; !cmp.Equal(want, got, ignoreUnexported) {
+				t.Errorf("response mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexported))
+			}
+
+			// check repository
+			repository, err := s.getPkgRepository(context.Background(), http.Header{}, tc.expectedRef.Context.Cluster, tc.expectedRef.Context.Namespace, tc.expectedRef.Identifier)
+			if err != nil {
+				t.Fatalf("unexpected error retrieving repository: %+v", err)
+			}
+			expectedRepository := tc.repositoryCustomizer(defaultRepository())
+
+			if got, want := repository, expectedRepository; !cmp.Equal(want, got, ignoreUnexported) {
+				t.Fatalf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexported))
+			}
+
+			// custom checks
+			if tc.customChecks != nil {
+				tc.customChecks(t, &s)
+			}
+		})
+	}
+}
+
+// loopbackAllowedPluginConfig returns the default plugin config allowing the
+// connection tests of the package repositories to reach the test servers.
+func loopbackAllowedPluginConfig() *kappControllerPluginParsedConfig {
+	config := *defaultPluginConfig
+	config.repositoryConnectionAllowedNetworks = []string{"127.0.0.0/8"}
+	return &config
+}
+
+func TestTestPackageRepositoryConnection(t *testing.T) {
+	gitPktLine := func(line string) string {
+		return fmt.Sprintf("%04x%s", len(line)+4, line)
+	}
+	defaultRequest := func() *corev1.AddPackageRepositoryRequest {
+		return &corev1.AddPackageRepositoryRequest{
+			Context:  defaultGlobalContext,
+			Name:     "globalrepo",
+			Type:     typeHTTP,
+			Interval: "24h",
+			Plugin:   &pluginDetail,
+		}
+	}
+
+	testCases := []struct {
+		name                 string
+		existingTypedObjects []k8sruntime.Object
+		handler              http.Handler
+		prepare              func(t *testing.T, serverURL string)
+		requestCustomizer    func(request *corev1.AddPackageRepositoryRequest, serverURL string) *corev1.AddPackageRepositoryRequest
+		// repositoryNetworkBlocked keeps the loopback address of the test
+		// server blocked, as it is by default.
+		repositoryNetworkBlocked bool
+		expectedErrorCode        connect.Code
+		customChecks             func(t *testing.T, response *corev1.TestPackageRepositoryConnectionResponse)
+	}{
+		{
+			name: "validate type",
+			requestCustomizer: func(request *corev1.AddPackageRepositoryRequest, serverURL string) *corev1.AddPackageRepositoryRequest {
+				request.Type = typeInline
+				request.Url = serverURL
+				return request
+			},
+			expectedErrorCode: connect.CodeInvalidArgument,
 		},
 		{
-			name: "create with details (git)",
-			requestCustomizer: func(request *corev1.AddPackageRepositoryRequest) *corev1.AddPackageRepositoryRequest {
-				request.Type = typeGIT
-				request.Url = "https://github.com/projects.registry.vmware.com/tce/main"
-				request.CustomDetail, _ = anypb.New(&kappcorev1.KappControllerPackageRepositoryCustomDetail{
-					Fetch: &kappcorev1.PackageRepositoryFetch{
-						Git: &kappcorev1.PackageRepositoryGit{
-							SubPath: "packages",
-							Ref:     "main",
-							RefSelection: &kappcorev1.VersionSelection{
-								Semver: &kappcorev1.VersionSelectionSemver{
-									Constraints: ">0.10.0 <0.11.0",
-									Prereleases: &kappcorev1.VersionSelectionSemverPrereleases{
-										Identifiers: []string{"beta", "rc"},
-									},
-								},
-							},
-							LfsSkipSmudge: true,
-						},
-					},
-				})
+			name: "validate url",
+			requestCustomizer: func(request *corev1.AddPackageRepositoryRequest, serverURL string) *corev1.AddPackageRepositoryRequest {
 				return request
 			},
-			repositoryCustomizer: func(repository *packagingv1alpha1.PackageRepository) *packagingv1alpha1.PackageRepository {
-				repository.Spec.Fetch = &packagingv1alpha1.PackageRepositoryFetch{
-					Git: &kappctrlv1alpha1.AppFetchGit{
-						URL:     "https://github.com/projects.registry.vmware.com/tce/main",
-						Ref:     "main",
-						SubPath: "packages",
-						RefSelection: &vendirversions.VersionSelection{
-							Semver: &vendirversions.VersionSelectionSemver{
-								Constraints: ">0.10.0 <0.11.0",
-								Prereleases: &vendirversions.VersionSelectionSemverPrereleases{
-									Identifiers: []string{"beta", "rc"},
-								},
-							},
-						},
-						LFSSkipSmudge: true,
-					},
-				}
-				return repository
-			},
-			expectedRef: defaultRef,
+			expectedErrorCode: connect.CodeInvalidArgument,
 		},
 		{
-			name: "create with details (http)",
-			requestCustomizer: func(request *corev1.AddPackageRepositoryRequest) *corev1.AddPackageRepositoryRequest {
-				request.Type = typeHTTP
-				request.Url = "https://projects.registry.vmware.com/tce/main"
-				request.CustomDetail, _ = anypb.New(&kappcorev1.KappControllerPackageRepositoryCustomDetail{
-					Fetch: &kappcorev1.PackageRepositoryFetch{
-						Http: &kappcorev1.PackageRepositoryHttp{
-							SubPath: "packages",
-							Sha256:  "ABC",
-						},
+			name: "http archive with basic auth",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if username, password, ok := r.BasicAuth(); !ok || username != "foo" || password != "bar" {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				w.Header().Set("ETag", `"abc"`)
+				w.WriteHeader(http.StatusOK)
+			}),
+			requestCustomizer: func(request *corev1.AddPackageRepositoryRequest, serverURL string) *corev1.AddPackageRepositoryRequest {
+				request.Url = serverURL + "/packages.tgz"
+				request.Auth = &corev1.PackageRepositoryAuth{
+					Type: corev1.PackageRepositoryAuth_PACKAGE_REPOSITORY_AUTH_TYPE_BASIC_AUTH,
+					PackageRepoAuthOneOf: &corev1.PackageRepositoryAuth_UsernamePassword{
+						UsernamePassword: &corev1.UsernamePassword{Username: "foo", Password: "bar"},
 					},
-				})
+				}
 				return request
 			},
-			repositoryCustomizer: func(repository *packagingv1alpha1.PackageRepository) *packagingv1alpha1.PackageRepository {
-				repository.Spec.Fetch = &packagingv1alpha1.PackageRepositoryFetch{
-					HTTP: &kappctrlv1alpha1.AppFetchHTTP{
-						URL:     "https://projects.registry.vmware.com/tce/main",
-						SubPath: "packages",
-						SHA256:  "ABC",
-					},
+			customChecks: func(t *testing.T, response *corev1.TestPackageRepositoryConnectionResponse) {
+				if got, want := response.Metadata, map[string]string{"etag": `"abc"`}; !cmp.Equal(want, got) {
+					t.Errorf("metadata mismatch (-want +got):\n%s", cmp.Diff(want, got))
 				}
-				return repository
 			},
-			expectedRef: defaultRef,
 		},
 		{
-			name: "create with auth (user managed)",
+			name: "http archive with user managed secret",
 			existingTypedObjects: []k8sruntime.Object{
 				&k8scorev1.Secret{
 					ObjectMeta: metav1.ObjectMeta{Namespace: defaultGlobalContext.Namespace, Name: "my-secret"},
 					Data:       map[string][]byte{k8scorev1.BasicAuthUsernameKey: []byte("foo"), k8scorev1.BasicAuthPasswordKey: []byte("bar")},
 				},
 			},
-			requestCustomizer: func(request *corev1.AddPackageRepositoryRequest) *corev1.AddPackageRepositoryRequest {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if username, password, ok := r.BasicAuth(); !ok || username != "foo" || password != "bar" {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}),
+			requestCustomizer: func(request *corev1.AddPackageRepositoryRequest, serverURL string) *corev1.AddPackageRepositoryRequest {
+				request.Url = serverURL + "/packages.tgz"
 				request.Auth = &corev1.PackageRepositoryAuth{
 					Type: corev1.PackageRepositoryAuth_PACKAGE_REPOSITORY_AUTH_TYPE_BASIC_AUTH,
 					PackageRepoAuthOneOf: &corev1.PackageRepositoryAuth_SecretRef{
-						SecretRef: &corev1.SecretKeyReference{
-							Name: "my-secret",
-						},
+						SecretRef: &corev1.SecretKeyReference{Name: "my-secret"},
 					},
 				}
 				return request
 			},
-			repositoryCustomizer: func(repository *packagingv1alpha1.PackageRepository) *packagingv1alpha1.PackageRepository {
-				repository.Spec.Fetch.ImgpkgBundle.SecretRef = &kappctrlv1alpha1.AppFetchLocalRef{
-					Name: "my-secret",
-				}
-				return repository
-			},
-			expectedRef: defaultRef,
 		},
 		{
-			name: "create with auth (plugin managed, basic auth)",
-			requestCustomizer: func(request *corev1.AddPackageRepositoryRequest) *corev1.AddPackageRepositoryRequest {
-				request.Auth = &corev1.PackageRepositoryAuth{
-					Type: corev1.PackageRepositoryAuth_PACKAGE_REPOSITORY_AUTH_TYPE_BASIC_AUTH,
-					PackageRepoAuthOneOf: &corev1.PackageRepositoryAuth_UsernamePassword{
-						UsernamePassword: &corev1.UsernamePassword{
-							Username: "foo",
-							Password: "bar",
-						},
-					},
-				}
+			name: "http archive rejecting the credentials",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusUnauthorized)
+			}),
+			requestCustomizer: func(request *corev1.AddPackageRepositoryRequest, serverURL string) *corev1.AddPackageRepositoryRequest {
+				request.Url = serverURL + "/packages.tgz"
 				return request
 			},
-			repositoryCustomizer: func(repository *packagingv1alpha1.PackageRepository) *packagingv1alpha1.PackageRepository {
-				repository.Spec.Fetch.ImgpkgBundle.SecretRef = &kappctrlv1alpha1.AppFetchLocalRef{} // the name will be empty as the fake client does not handle generating names
-				return repository
+			expectedErrorCode: connect.CodeUnauthenticated,
+		},
+		{
+			name: "http archive served from a blocked network",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}),
+			requestCustomizer: func(request *corev1.AddPackageRepositoryRequest, serverURL string) *corev1.AddPackageRepositoryRequest {
+				request.Url = serverURL + "/packages.tgz"
+				return request
 			},
-			expectedRef: defaultRef,
-			customChecks: func(t *testing.T, s *Server) {
-				secret, err := s.getSecret(context.Background(), http.Header{}, defaultGlobalContext.Cluster, demoGlobalPackagingNamespace, "")
-				if err != nil {
-					t.Fatalf("error fetching newly created secret:%+v", err)
-				}
-				if !isPluginManaged(defaultRepository(), secret) {
-					t.Errorf("annotations and ownership was not properly set: %+v", secret)
+			repositoryNetworkBlocked: true,
+			expectedErrorCode:        connect.CodePermissionDenied,
+		},
+		{
+			name: "git repository",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/repo/info/refs" || r.URL.Query().Get("service") != "git-upload-pack" {
+					w.WriteHeader(http.StatusNotFound)
+					return
 				}
-				if secret.StringData[k8scorev1.BasicAuthUsernameKey] != "foo" || secret.StringData[k8scorev1.BasicAuthPasswordKey] != "bar" {
-					t.Errorf("secret data was not properly constructed: %+v", secret)
+				fmt.Fprint(w, gitPktLine("# service=git-upload-pack\n")+"0000"+
+					gitPktLine("6ecf0ef2c2dffb796033e5a02219af86ec6584e5 HEAD\x00multi_ack symref=HEAD:refs/heads/main\n")+
+					gitPktLine("6ecf0ef2c2dffb796033e5a02219af86ec6584e5 refs/heads/main\n")+"0000")
+			}),
+			requestCustomizer: func(request *corev1.AddPackageRepositoryRequest, serverURL string) *corev1.AddPackageRepositoryRequest {
+				request.Type = typeGIT
+				request.Url = serverURL + "/repo"
+				return request
+			},
+			customChecks: func(t *testing.T, response *corev1.TestPackageRepositoryConnectionResponse) {
+				if got, want := response.Metadata, map[string]string{"revision": "6ecf0ef2c2dffb796033e5a02219af86ec6584e5"}; !cmp.Equal(want, got) {
+					t.Errorf("metadata mismatch (-want +got):\n%s", cmp.Diff(want, got))
 				}
 			},
 		},
 		{
-			name: "create with auth (plugin managed, bearer auth)",
-			requestCustomizer: func(request *corev1.AddPackageRepositoryRequest) *corev1.AddPackageRepositoryRequest {
-				request.Auth = &corev1.PackageRepositoryAuth{
-					Type: corev1.PackageRepositoryAuth_PACKAGE_REPOSITORY_AUTH_TYPE_BEARER,
-					PackageRepoAuthOneOf: &corev1.PackageRepositoryAuth_Header{
-						Header: "foo",
-					},
-				}
+			name: "git repository over ssh",
+			requestCustomizer: func(request *corev1.AddPackageRepositoryRequest, serverURL string) *corev1.AddPackageRepositoryRequest {
+				request.Type = typeGIT
+				request.Url = "git@github.com:vmware-tanzu/kubeapps.git"
 				return request
 			},
-			repositoryCustomizer: func(repository *packagingv1alpha1.PackageRepository) *packagingv1alpha1.PackageRepository {
-				repository.Spec.Fetch.ImgpkgBundle.SecretRef = &kappctrlv1alpha1.AppFetchLocalRef{} // the name will be empty as the fake client does not handle generating names
-				return repository
-			},
-			expectedRef: defaultRef,
-			customChecks: func(t *testing.T, s *Server) {
-				secret, err := s.getSecret(context.Background(), http.Header{}, defaultGlobalContext.Cluster, demoGlobalPackagingNamespace, "")
+			expectedErrorCode: connect.CodeUnimplemented,
+		},
+		{
+			name:    "imgpkg bundle",
+			handler: registry.New(registry.Logger(log.New(io.Discard, "", 0))),
+			prepare: func(t *testing.T, serverURL string) {
+				image, err := random.Image(1024, 1)
 				if err != nil {
-					t.Fatalf("error fetching newly created secret:%+v", err)
+					t.Fatal(err)
 				}
-				if !isPluginManaged(defaultRepository(), secret) {
-					t.Errorf("annotations and ownership was not properly set: %+v", secret)
+				ref, err := name.ParseReference(strings.TrimPrefix(serverURL, "http://") + "/repo-1/main:latest")
+				if err != nil {
+					t.Fatal(err)
 				}
-				if secret.Type != k8scorev1.SecretTypeOpaque || secret.StringData[bearerAuthToken] != "foo" {
-					t.Errorf("secret data was not properly constructed: %+v", secret)
+				if err = remote.Write(ref, image); err != nil {
+					t.Fatal(err)
 				}
 			},
-		},
-		{
-			name: "create with auth (plugin managed, docker auth)",
-			requestCustomizer: func(request *corev1.AddPackageRepositoryRequest) *corev1.AddPackageRepositoryRequest {
-				request.Auth = &corev1.PackageRepositoryAuth{
-					Type: corev1.PackageRepositoryAuth_PACKAGE_REPOSITORY_AUTH_TYPE_DOCKER_CONFIG_JSON,
-					PackageRepoAuthOneOf: &corev1.PackageRepositoryAuth_DockerCreds{
-						DockerCreds: &corev1.DockerCredentials{
-							Username: "foo",
-							Password: "bar",
-							Server:   "localhost",
-							Email:    "foo@example.com",
-						},
-					},
-				}
+			requestCustomizer: func(request *corev1.AddPackageRepositoryRequest, serverURL string) *corev1.AddPackageRepositoryRequest {
+				request.Type = typeImgPkgBundle
+				request.Url = strings.TrimPrefix(serverURL, "http://") + "/repo-1/main:latest"
 				return request
 			},
-			repositoryCustomizer: func(repository *packagingv1alpha1.PackageRepository) *packagingv1alpha1.PackageRepository {
-				repository.Spec.Fetch.ImgpkgBundle.SecretRef = &kappctrlv1alpha1.AppFetchLocalRef{} // the name will be empty as the fake client does not handle generating names
-				return repository
-			},
-			expectedRef: defaultRef,
-			customChecks: func(t *testing.T, s *Server) {
-				secret, err := s.getSecret(context.Background(), http.Header{}, defaultGlobalContext.Cluster, demoGlobalPackagingNamespace, "")
-				if err != nil {
-					t.Fatalf("error fetching newly created secret:%+v", err)
+			customChecks: func(t *testing.T, response *corev1.TestPackageRepositoryConnectionResponse) {
+				if !strings.HasPrefix(response.Metadata["digest"], "sha256:") {
+					t.Errorf("expected the digest of the image, got: %+v", response.Metadata)
 				}
-				if !isPluginManaged(defaultRepository(), secret) {
-					t.Errorf("annotations and ownership was not properly set: %+v", secret)
+				if got, want := response.Metadata["tags"], "1"; got != want {
+					t.Errorf("got: %q tags, want: %q", got, want)
 				}
-				if secret.Type != k8scorev1.SecretTypeDockerConfigJson || !strings.Contains(secret.StringData[k8scorev1.DockerConfigJsonKey], "foo@example.com") {
-					t.Errorf("secret data was not properly constructed: %+v", secret)
+			},
+		},
+		{
+			name:    "imgpkg bundle not found",
+			handler: registry.New(registry.Logger(log.New(io.Discard, "", 0))),
+			requestCustomizer: func(request *corev1.AddPackageRepositoryRequest, serverURL string) *corev1.AddPackageRepositoryRequest {
+				request.Type = typeImgPkgBundle
+				request.Url = strings.TrimPrefix(serverURL, "http://") + "/repo-1/main:latest"
+				return request
+			},
+			expectedErrorCode: connect.CodeNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			serverURL := ""
+			if tc.handler != nil {
+				repoServer := httptest.NewServer(tc.handler)
+				defer repoServer.Close()
+				serverURL = repoServer.URL
+			}
+			if tc.prepare != nil {
+				tc.prepare(t, serverURL)
+			}
+
+			typedClient := typfake.NewSimpleClientset(tc.existingTypedObjects...)
+			dynamicClient := dynfake.NewSimpleDynamicClientWithCustomListKinds(
+				k8sruntime.NewScheme(),
+				map[schema.GroupVersionResource]string{
+					{Group: packagingv1alpha1.SchemeGroupVersion.Group, Version: packagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgRepositoriesResource}: pkgRepositoryResource + "List",
+				},
+			)
+
+			pluginConfig := loopbackAllowedPluginConfig()
+			if tc.repositoryNetworkBlocked {
+				pluginConfig = defaultPluginConfig
+			}
+			s := Server{
+				pluginConfig: pluginConfig,
+				clientGetter: clientgetter.NewBuilder().
+					WithTyped(typedClient).
+					WithDynamic(dynamicClient).
+					Build(),
+				globalPackagingCluster: defaultGlobalContext.Cluster,
+			}
+
+			request := &corev1.TestPackageRepositoryConnectionRequest{
+				PackageRepository: tc.requestCustomizer(defaultRequest(), serverURL),
+			}
+			response, err := s.TestPackageRepositoryConnection(context.Background(), connect.NewRequest(request))
+
+			// check status
+			if got, want := connect.CodeOf(err), tc.expectedErrorCode; err != nil && got != want {
+				t.Fatalf("got error: %d, want: %d, err: %+v", got, want, err)
+			} else if tc.expectedErrorCode != 0 {
+				if err == nil {
+					t.Fatalf("got: nil, want: error with code %s", tc.expectedErrorCode)
 				}
+				return
+			}
+
+			if tc.customChecks != nil {
+				tc.customChecks(t, response.Msg)
+			}
+
+			// testing the connection must not create anything in the cluster
+			secrets, err := typedClient.CoreV1().Secrets("").List(context.Background(), metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := len(secrets.Items), len(tc.existingTypedObjects); got != want {
+				t.Errorf("got: %d secrets, want: %d", got, want)
+			}
+			repositories, err := s.getPkgRepositories(context.Background(), http.Header{}, defaultGlobalContext.Cluster, "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(repositories) != 0 {
+				t.Errorf("expected no repository to be created, got: %+v", repositories)
+			}
+		})
+	}
+}
+
+func TestGetBundleReadme(t *testing.T) {
+	// newBundle returns a bundle image with a single layer made of the given files.
+	newBundle := func(t *testing.T, files map[string]string) v1.Image {
+		var buf bytes.Buffer
+		tw := tar.NewWriter(&buf)
+		for _, fileName := range []string{"README.md", ".imgpkg/images.yml", "config/package.yml"} {
+			content, ok := files[fileName]
+			if !ok {
+				continue
+			}
+			if err := tw.WriteHeader(&tar.Header{Name: fileName, Typeflag: tar.TypeReg, Mode: 0600, Size: int64(len(content))}); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := tw.Write([]byte(content)); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := tw.Close(); err != nil {
+			t.Fatal(err)
+		}
+		layer, err := tarball.LayerFromOpener(func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(buf.Bytes())), nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		image, err := mutate.AppendLayers(empty.Image, layer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return image
+	}
+	// basicAuth requires the credentials of the repository secret.
+	basicAuth := func(handler http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if username, password, ok := r.BasicAuth(); !ok || username != "foo" || password != "bar" {
+				w.Header().Set("WWW-Authenticate", `Basic realm="registry"`)
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			handler.ServeHTTP(w, r)
+		})
+	}
+
+	testCases := []struct {
+		name           string
+		files          map[string]string
+		fetch          func(image string) kappctrlv1alpha1.AppFetch
+		expectedReadme string
+	}{
+		{
+			name: "it returns the README at the root of the bundle",
+			files: map[string]string{
+				"README.md":          "# Tetris\n\nThe actual documentation.",
+				"config/package.yml": "kind: Deployment",
+			},
+			expectedReadme: "# Tetris\n\nThe actual documentation.",
+		},
+		{
+			name: "it returns an empty README if the bundle has none",
+			files: map[string]string{
+				"config/package.yml": "kind: Deployment",
+			},
+			expectedReadme: "",
+		},
+		{
+			name: "it returns an empty README if the package is not fetched from a bundle",
+			fetch: func(image string) kappctrlv1alpha1.AppFetch {
+				return kappctrlv1alpha1.AppFetch{Git: &kappctrlv1alpha1.AppFetchGit{URL: "https://github.com/vmware-tanzu/carvel-kapp-controller"}}
 			},
+			expectedReadme: "",
 		},
 	}
 
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			var unstructuredObjects []k8sruntime.Object
-			for _, obj := range tc.existingObjects {
-				unstructuredContent, _ := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(obj)
-				unstructuredObjects = append(unstructuredObjects, &unstructured.Unstructured{Object: unstructuredContent})
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repoServer := httptest.NewServer(basicAuth(registry.New(registry.Logger(log.New(io.Discard, "", 0)))))
+			defer repoServer.Close()
+
+			image := strings.TrimPrefix(repoServer.URL, "http://") + "/tetris/bundle:1.0.0"
+			ref, err := name.ParseReference(image)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err = remote.Write(ref, newBundle(t, tc.files), remote.WithAuth(&authn.Basic{Username: "foo", Password: "bar"})); err != nil {
+				t.Fatal(err)
+			}
+
+			fetch := kappctrlv1alpha1.AppFetch{ImgpkgBundle: &kappctrlv1alpha1.AppFetchImgpkgBundle{Image: image}}
+			if tc.fetch != nil {
+				fetch = tc.fetch(image)
+			}
+			pkg := &datapackagingv1alpha1.Package{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   defaultGlobalContext.Namespace,
+					Name:        "tetris.foo.example.com.1.0.0",
+					Annotations: map[string]string{REPO_REF_ANNOTATION: defaultGlobalContext.Namespace + "/globalrepo"},
+				},
+				Spec: datapackagingv1alpha1.PackageSpec{
+					RefName: "tetris.foo.example.com",
+					Version: "1.0.0",
+					Template: datapackagingv1alpha1.AppTemplateSpec{
+						Spec: &kappctrlv1alpha1.AppSpec{Fetch: []kappctrlv1alpha1.AppFetch{fetch}},
+					},
+				},
 			}
 
-			typedClient := typfake.NewSimpleClientset(tc.existingTypedObjects...)
+			repository := &packagingv1alpha1.PackageRepository{
+				TypeMeta:   defaultTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{Name: "globalrepo", Namespace: defaultGlobalContext.Namespace},
+				Spec: packagingv1alpha1.PackageRepositorySpec{
+					Fetch: &packagingv1alpha1.PackageRepositoryFetch{
+						ImgpkgBundle: &kappctrlv1alpha1.AppFetchImgpkgBundle{
+							Image:     strings.TrimPrefix(repoServer.URL, "http://") + "/tetris/repo:latest",
+							SecretRef: &kappctrlv1alpha1.AppFetchLocalRef{Name: "my-secret"},
+						},
+					},
+				},
+			}
+			unstructuredContent, _ := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(repository)
+
 			dynamicClient := dynfake.NewSimpleDynamicClientWithCustomListKinds(
 				k8sruntime.NewScheme(),
 				map[schema.GroupVersionResource]string{
 					{Group: packagingv1alpha1.SchemeGroupVersion.Group, Version: packagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgRepositoriesResource}: pkgRepositoryResource + "List",
 				},
-				unstructuredObjects...,
+				&unstructured.Unstructured{Object: unstructuredContent},
 			)
-
 			s := Server{
 				pluginConfig: defaultPluginConfig,
 				clientGetter: clientgetter.NewBuilder().
-					WithTyped(typedClient).
+					WithTyped(typfake.NewSimpleClientset(&k8scorev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{Namespace: defaultGlobalContext.Namespace, Name: "my-secret", ResourceVersion: "1"},
+						Data:       map[string][]byte{k8scorev1.BasicAuthUsernameKey: []byte("foo"), k8scorev1.BasicAuthPasswordKey: []byte("bar")},
+					})).
 					WithDynamic(dynamicClient).
 					Build(),
 				globalPackagingCluster: defaultGlobalContext.Cluster,
+				bundleReadmes:          newBundleReadmeCache(),
 			}
 
-			request := tc.requestCustomizer(defaultRequest())
-			response, err := s.AddPackageRepository(context.Background(), connect.NewRequest(request))
-
-			// check status
-			if got, want := connect.CodeOf(err), tc.expectedErrorCode; err != nil && got != want {
-				t.Fatalf("got error: %d, want: %d, err: %+v", got, want, err)
-			} else if got != 0 {
-				if tc.expectedErrorString != "" && !strings.Contains(fmt.Sprint(err), tc.expectedErrorString) {
-					t.Fatalf("error without expected string: expected %s, err: %+v", tc.expectedErrorString, err)
-				}
-				return
+			readme, err := s.getBundleReadme(context.Background(), http.Header{}, defaultGlobalContext.Cluster, pkg)
+			if err != nil {
+				t.Fatalf("%+v", err)
 			}
-
-			// check ref
-			if got, want := response.Msg.GetPackageRepoRef(), tc.expectedRef; !cmp.Equal(want, got, ignoreUnexported) {
-				t.Errorf("response mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexported))
+			if got, want := readme, tc.expectedReadme; got != want {
+				t.Errorf("got: %q, want: %q", got, want)
 			}
 
-			// check repository
-			repository, err := s.getPkgRepository(context.Background(), http.Header{}, tc.expectedRef.Context.Cluster, tc.expectedRef.Context.Namespace, tc.expectedRef.Identifier)
+			// the README is then returned from the cache, without fetching the bundle
+			repoServer.Close()
+			readme, err = s.getBundleReadme(context.Background(), http.Header{}, defaultGlobalContext.Cluster, pkg)
 			if err != nil {
-				t.Fatalf("unexpected error retrieving repository: %+v", err)
+				t.Fatalf("%+v", err)
 			}
-			expectedRepository := tc.repositoryCustomizer(defaultRepository())
-
-			if got, want := repository, expectedRepository; !cmp.Equal(want, got, ignoreUnexported) {
-				t.Fatalf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexported))
+			if got, want := readme, tc.expectedReadme; got != want {
+				t.Errorf("got: %q, want: %q from the cache", got, want)
 			}
 
-			// custom checks
-			if tc.customChecks != nil {
-				tc.customChecks(t, &s)
+			// the README fetched with the repository credentials is not
+			// returned to the users who cannot read them
+			if tc.fetch == nil {
+				s.clientGetter = clientgetter.NewBuilder().
+					WithTyped(typfake.NewSimpleClientset()).
+					WithDynamic(dynamicClient).
+					Build()
+				if readme, err = s.getBundleReadme(context.Background(), http.Header{}, defaultGlobalContext.Cluster, pkg); err == nil {
+					t.Errorf("got: %q from the cache, want: error", readme)
+				}
 			}
 		})
 	}
@@ -8141,11 +13392,57 @@ func TestDeletePackageRepository(t *testing.T) {
 		}
 	}
 
+	pluginManagedSecret := func() *k8scorev1.Secret {
+		return &k8scorev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "my-secret",
+				Namespace:   demoGlobalPackagingNamespace,
+				Annotations: map[string]string{annotationManagedByKey: annotationManagedByValue},
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: packagingAPIVersion,
+					Kind:       pkgRepositoryResource,
+					Name:       "globalrepo",
+					UID:        "globalrepo",
+					Controller: func() *bool { v := true; return &v }(),
+				}},
+			},
+		}
+	}
+	pluginManagedRepository := func() *packagingv1alpha1.PackageRepository {
+		r := defaultRepository()
+		r.ObjectMeta.UID = "globalrepo"
+		r.Spec.Fetch.ImgpkgBundle.SecretRef = &kappctrlv1alpha1.AppFetchLocalRef{
+			Name: "my-secret",
+		}
+		return r
+	}
+	referencingInstall := &packagingv1alpha1.PackageInstall{
+		TypeMeta:   metav1.TypeMeta{Kind: pkgInstallResource, APIVersion: packagingAPIVersion},
+		ObjectMeta: metav1.ObjectMeta{Name: "my-installation", Namespace: demoGlobalPackagingNamespace},
+		Spec: packagingv1alpha1.PackageInstallSpec{
+			Values: []packagingv1alpha1.PackageInstallValues{{
+				SecretRef: &packagingv1alpha1.PackageInstallValuesSecretRef{Name: "my-secret"},
+			}},
+		},
+	}
+	secretUsage := &corev1.PackageRepositorySecretUsage{
+		SecretName: "my-secret",
+		ReferencingResources: []*corev1.ResourceRef{{
+			ApiVersion: packagingAPIVersion,
+			Kind:       pkgInstallResource,
+			Name:       "my-installation",
+			Namespace:  demoGlobalPackagingNamespace,
+		}},
+	}
+
 	testCases := []struct {
-		name              string
-		existingObjects   []k8sruntime.Object
-		request           *corev1.DeletePackageRepositoryRequest
-		expectedErrorCode connect.Code
+		name                    string
+		existingObjects         []k8sruntime.Object
+		existingTypedObjects    []k8sruntime.Object
+		request                 *corev1.DeletePackageRepositoryRequest
+		expectedErrorCode       connect.Code
+		expectedResponse        *corev1.DeletePackageRepositoryResponse
+		expectedOwnerReferences int
 	}{
 		{
 			name:            "delete - success",
@@ -8215,6 +13512,50 @@ func TestDeletePackageRepository(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:                 "delete - with plugin managed secret not referenced elsewhere",
+			existingObjects:      []k8sruntime.Object{pluginManagedRepository()},
+			existingTypedObjects: []k8sruntime.Object{pluginManagedSecret()},
+			request: &corev1.DeletePackageRepositoryRequest{
+				PackageRepoRef: &corev1.PackageRepositoryReference{
+					Context:    defaultGlobalContext,
+					Plugin:     &pluginDetail,
+					Identifier: "globalrepo",
+				},
+			},
+			expectedResponse:        &corev1.DeletePackageRepositoryResponse{},
+			expectedOwnerReferences: 1,
+		},
+		{
+			name:                 "delete - with plugin managed secret referenced by a package install",
+			existingObjects:      []k8sruntime.Object{pluginManagedRepository(), referencingInstall},
+			existingTypedObjects: []k8sruntime.Object{pluginManagedSecret()},
+			request: &corev1.DeletePackageRepositoryRequest{
+				PackageRepoRef: &corev1.PackageRepositoryReference{
+					Context:    defaultGlobalContext,
+					Plugin:     &pluginDetail,
+					Identifier: "globalrepo",
+				},
+			},
+			expectedErrorCode:       connect.CodeFailedPrecondition,
+			expectedOwnerReferences: 1,
+		},
+		{
+			name:                 "delete - retaining the plugin managed secret referenced by a package install",
+			existingObjects:      []k8sruntime.Object{pluginManagedRepository(), referencingInstall},
+			existingTypedObjects: []k8sruntime.Object{pluginManagedSecret()},
+			request: &corev1.DeletePackageRepositoryRequest{
+				PackageRepoRef: &corev1.PackageRepositoryReference{
+					Context:    defaultGlobalContext,
+					Plugin:     &pluginDetail,
+					Identifier: "globalrepo",
+				},
+				RetainReferencedSecrets: true,
+			},
+			expectedResponse: &corev1.DeletePackageRepositoryResponse{
+				RetainedSecrets: []*corev1.PackageRepositorySecretUsage{secretUsage},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -8229,21 +13570,41 @@ func TestDeletePackageRepository(t *testing.T) {
 				k8sruntime.NewScheme(),
 				map[schema.GroupVersionResource]string{
 					{Group: packagingv1alpha1.SchemeGroupVersion.Group, Version: packagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgRepositoriesResource}: pkgRepositoryResource + "List",
+					{Group: packagingv1alpha1.SchemeGroupVersion.Group, Version: packagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgInstallsResource}:     pkgInstallResource + "List",
 				},
 				unstructuredObjects...,
 			)
+			typedClient := typfake.NewSimpleClientset(tc.existingTypedObjects...)
 			s := Server{
 				pluginConfig: defaultPluginConfig,
 				clientGetter: clientgetter.NewBuilder().
 					WithDynamic(dynamicClient).
+					WithTyped(typedClient).
 					Build(),
 				globalPackagingCluster: defaultGlobalContext.Cluster,
 			}
 
-			_, err := s.DeletePackageRepository(context.Background(), connect.NewRequest(tc.request))
+			response, err := s.DeletePackageRepository(context.Background(), connect.NewRequest(tc.request))
 
 			if got, want := connect.CodeOf(err), tc.expectedErrorCode; err != nil && got != want {
 				t.Fatalf("got: %d, want: %d, err: %+v", got, want, err)
+			} else if err == nil && tc.expectedErrorCode != 0 {
+				t.Fatalf("got: nil, want: error with code %d", tc.expectedErrorCode)
+			}
+
+			if tc.expectedResponse != nil {
+				if got, want := response.Msg, tc.expectedResponse; !cmp.Equal(want, got, ignoreUnexported) {
+					t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexported))
+				}
+			}
+			for _, obj := range tc.existingTypedObjects {
+				secret, err := typedClient.CoreV1().Secrets(demoGlobalPackagingNamespace).Get(context.Background(), obj.(*k8scorev1.Secret).GetName(), metav1.GetOptions{})
+				if err != nil {
+					t.Fatalf("%+v", err)
+				}
+				if got, want := len(secret.GetOwnerReferences()), tc.expectedOwnerReferences; got != want {
+					t.Errorf("got: %d, want: %d", got, want)
+				}
 			}
 		})
 	}
@@ -8313,6 +13674,7 @@ func TestGetPackageRepositoryDetail(t *testing.T) {
 
 	testCases := []struct {
 		name                 string
+		existingObjects      []k8sruntime.Object
 		existingTypedObjects []k8sruntime.Object
 		requestCustomizer    func(request *corev1.GetPackageRepositoryDetailRequest) *corev1.GetPackageRepositoryDetailRequest
 		repositoryCustomizer func(repository *packagingv1alpha1.PackageRepository) *packagingv1alpha1.PackageRepository
@@ -8325,491 +13687,1207 @@ func TestGetPackageRepositoryDetail(t *testing.T) {
 				request.PackageRepoRef.Identifier = "foo"
 				return request
 			},
-			expectedErrorCode: connect.CodeNotFound,
+			expectedErrorCode: connect.CodeNotFound,
+		},
+		{
+			name: "check ref",
+			repositoryCustomizer: func(repository *packagingv1alpha1.PackageRepository) *packagingv1alpha1.PackageRepository {
+				repository.ObjectMeta.Name = "foo"
+				repository.Name = "foo"
+				return repository
+			},
+			requestCustomizer: func(request *corev1.GetPackageRepositoryDetailRequest) *corev1.GetPackageRepositoryDetailRequest {
+				request.PackageRepoRef.Identifier = "foo"
+				return request
+			},
+			responseCustomizer: func(response *corev1.GetPackageRepositoryDetailResponse) *corev1.GetPackageRepositoryDetailResponse {
+				response.Detail.PackageRepoRef.Identifier = "foo"
+				response.Detail.Name = "foo"
+				return response
+			},
+		},
+		{
+			name: "check name",
+		},
+		{
+			name: "check global scope",
+		},
+		{
+			name: "check namespace scoped",
+			repositoryCustomizer: func(repository *packagingv1alpha1.PackageRepository) *packagingv1alpha1.PackageRepository {
+				repository.ObjectMeta.Namespace = "privatens"
+				return repository
+			},
+			requestCustomizer: func(request *corev1.GetPackageRepositoryDetailRequest) *corev1.GetPackageRepositoryDetailRequest {
+				request.PackageRepoRef.Context.Namespace = "privatens"
+				return request
+			},
+			responseCustomizer: func(response *corev1.GetPackageRepositoryDetailResponse) *corev1.GetPackageRepositoryDetailResponse {
+				response.Detail.PackageRepoRef.Context.Namespace = "privatens"
+				response.Detail.NamespaceScoped = true
+				return response
+			},
+		},
+		{
+			name: "check url",
+			repositoryCustomizer: func(repository *packagingv1alpha1.PackageRepository) *packagingv1alpha1.PackageRepository {
+				repository.Spec.Fetch.ImgpkgBundle.Image = "foo"
+				return repository
+			},
+			responseCustomizer: func(response *corev1.GetPackageRepositoryDetailResponse) *corev1.GetPackageRepositoryDetailResponse {
+				response.Detail.Url = "foo"
+				return response
+			},
+		},
+		{
+			name: "check description",
+			repositoryCustomizer: func(repository *packagingv1alpha1.PackageRepository) *packagingv1alpha1.PackageRepository {
+				repository.Annotations = map[string]string{k8sutils.AnnotationDescriptionKey: "repository description"}
+				return repository
+			},
+			responseCustomizer: func(response *corev1.GetPackageRepositoryDetailResponse) *corev1.GetPackageRepositoryDetailResponse {
+				response.Detail.Description = "repository description"
+				return response
+			},
+		},
+		{
+			name: "check interval (none)",
+			repositoryCustomizer: func(repository *packagingv1alpha1.PackageRepository) *packagingv1alpha1.PackageRepository {
+				repository.Spec.SyncPeriod = nil
+				return repository
+			},
+			responseCustomizer: func(response *corev1.GetPackageRepositoryDetailResponse) *corev1.GetPackageRepositoryDetailResponse {
+				response.Detail.Interval = ""
+				return response
+			},
+		},
+		{
+			name: "check interval (set)",
+			repositoryCustomizer: func(repository *packagingv1alpha1.PackageRepository) *packagingv1alpha1.PackageRepository {
+				repository.Spec.SyncPeriod = &metav1.Duration{Duration: time.Duration(12) * time.Hour}
+				return repository
+			},
+			responseCustomizer: func(response *corev1.GetPackageRepositoryDetailResponse) *corev1.GetPackageRepositoryDetailResponse {
+				response.Detail.Interval = "12h"
+				return response
+			},
+		},
+		{
+			name: "check imgpkg type",
+			repositoryCustomizer: func(repository *packagingv1alpha1.PackageRepository) *packagingv1alpha1.PackageRepository {
+				repository.Spec.Fetch = &packagingv1alpha1.PackageRepositoryFetch{
+					ImgpkgBundle: &kappctrlv1alpha1.AppFetchImgpkgBundle{
+						Image: "projects.registry.example.com/repo-1/main@sha256:abcd",
+						TagSelection: &vendirversions.VersionSelection{
+							Semver: &vendirversions.VersionSelectionSemver{
+								Constraints: ">0.10.0 <0.11.0",
+								Prereleases: &vendirversions.VersionSelectionSemverPrereleases{
+									Identifiers: []string{"beta", "rc"},
+								},
+							},
+						},
+					},
+				}
+				return repository
+			},
+			responseCustomizer: func(response *corev1.GetPackageRepositoryDetailResponse) *corev1.GetPackageRepositoryDetailResponse {
+				response.Detail.Type = typeImgPkgBundle
+				response.Detail.Url = "projects.registry.example.com/repo-1/main@sha256:abcd"
+				response.Detail.CustomDetail, _ = anypb.New(&kappcorev1.KappControllerPackageRepositoryCustomDetail{
+					Fetch: &kappcorev1.PackageRepositoryFetch{
+						ImgpkgBundle: &kappcorev1.PackageRepositoryImgpkg{
+							TagSelection: &kappcorev1.VersionSelection{
+								Semver: &kappcorev1.VersionSelectionSemver{
+									Constraints: ">0.10.0 <0.11.0",
+									Prereleases: &kappcorev1.VersionSelectionSemverPrereleases{
+										Identifiers: []string{"beta", "rc"},
+									},
+								},
+							},
+						},
+					},
+				})
+				return response
+			},
+		},
+		{
+			name: "check image type",
+			repositoryCustomizer: func(repository *packagingv1alpha1.PackageRepository) *packagingv1alpha1.PackageRepository {
+				repository.Spec.Fetch = &packagingv1alpha1.PackageRepositoryFetch{
+					Image: &kappctrlv1alpha1.AppFetchImage{
+						URL:     "projects.registry.example.com/repo-1/main@sha256:abcd",
+						SubPath: "packages",
+						TagSelection: &vendirversions.VersionSelection{
+							Semver: &vendirversions.VersionSelectionSemver{
+								Constraints: ">0.10.0 <0.11.0",
+								Prereleases: &vendirversions.VersionSelectionSemverPrereleases{
+									Identifiers: []string{"beta", "rc"},
+								},
+							},
+						},
+					},
+				}
+				return repository
+			},
+			responseCustomizer: func(response *corev1.GetPackageRepositoryDetailResponse) *corev1.GetPackageRepositoryDetailResponse {
+				response.Detail.Type = typeImage
+				response.Detail.Url = "projects.registry.example.com/repo-1/main@sha256:abcd"
+				response.Detail.CustomDetail, _ = anypb.New(&kappcorev1.KappControllerPackageRepositoryCustomDetail{
+					Fetch: &kappcorev1.PackageRepositoryFetch{
+						Image: &kappcorev1.PackageRepositoryImage{
+							SubPath: "packages",
+							TagSelection: &kappcorev1.VersionSelection{
+								Semver: &kappcorev1.VersionSelectionSemver{
+									Constraints: ">0.10.0 <0.11.0",
+									Prereleases: &kappcorev1.VersionSelectionSemverPrereleases{
+										Identifiers: []string{"beta", "rc"},
+									},
+								},
+							},
+						},
+					},
+				})
+				return response
+			},
 		},
 		{
-			name: "check ref",
+			name: "check git type",
 			repositoryCustomizer: func(repository *packagingv1alpha1.PackageRepository) *packagingv1alpha1.PackageRepository {
-				repository.ObjectMeta.Name = "foo"
-				repository.Name = "foo"
+				repository.Spec.Fetch = &packagingv1alpha1.PackageRepositoryFetch{
+					Git: &kappctrlv1alpha1.AppFetchGit{
+						URL:     "https://github.com/projects.registry.vmware.com/tce/main",
+						Ref:     "main",
+						SubPath: "packages",
+						RefSelection: &vendirversions.VersionSelection{
+							Semver: &vendirversions.VersionSelectionSemver{
+								Constraints: ">0.10.0 <0.11.0",
+								Prereleases: &vendirversions.VersionSelectionSemverPrereleases{
+									Identifiers: []string{"beta", "rc"},
+								},
+							},
+						},
+						LFSSkipSmudge: true,
+					},
+				}
 				return repository
 			},
-			requestCustomizer: func(request *corev1.GetPackageRepositoryDetailRequest) *corev1.GetPackageRepositoryDetailRequest {
-				request.PackageRepoRef.Identifier = "foo"
-				return request
-			},
 			responseCustomizer: func(response *corev1.GetPackageRepositoryDetailResponse) *corev1.GetPackageRepositoryDetailResponse {
-				response.Detail.PackageRepoRef.Identifier = "foo"
-				response.Detail.Name = "foo"
+				response.Detail.Type = typeGIT
+				response.Detail.Url = "https://github.com/projects.registry.vmware.com/tce/main"
+				response.Detail.CustomDetail, _ = anypb.New(&kappcorev1.KappControllerPackageRepositoryCustomDetail{
+					Fetch: &kappcorev1.PackageRepositoryFetch{
+						Git: &kappcorev1.PackageRepositoryGit{
+							SubPath: "packages",
+							Ref:     "main",
+							RefSelection: &kappcorev1.VersionSelection{
+								Semver: &kappcorev1.VersionSelectionSemver{
+									Constraints: ">0.10.0 <0.11.0",
+									Prereleases: &kappcorev1.VersionSelectionSemverPrereleases{
+										Identifiers: []string{"beta", "rc"},
+									},
+								},
+							},
+							LfsSkipSmudge: true,
+						},
+					},
+				})
 				return response
 			},
 		},
 		{
-			name: "check name",
-		},
-		{
-			name: "check global scope",
-		},
-		{
-			name: "check namespace scoped",
+			name: "check http type",
 			repositoryCustomizer: func(repository *packagingv1alpha1.PackageRepository) *packagingv1alpha1.PackageRepository {
-				repository.ObjectMeta.Namespace = "privatens"
+				repository.Spec.Fetch = &packagingv1alpha1.PackageRepositoryFetch{
+					HTTP: &kappctrlv1alpha1.AppFetchHTTP{
+						URL:     "https://projects.registry.vmware.com/tce/main",
+						SubPath: "packages",
+						SHA256:  "ABC",
+					},
+				}
 				return repository
 			},
-			requestCustomizer: func(request *corev1.GetPackageRepositoryDetailRequest) *corev1.GetPackageRepositoryDetailRequest {
-				request.PackageRepoRef.Context.Namespace = "privatens"
-				return request
-			},
 			responseCustomizer: func(response *corev1.GetPackageRepositoryDetailResponse) *corev1.GetPackageRepositoryDetailResponse {
-				response.Detail.PackageRepoRef.Context.Namespace = "privatens"
-				response.Detail.NamespaceScoped = true
+				response.Detail.Type = typeHTTP
+				response.Detail.Url = "https://projects.registry.vmware.com/tce/main"
+				response.Detail.CustomDetail, _ = anypb.New(&kappcorev1.KappControllerPackageRepositoryCustomDetail{
+					Fetch: &kappcorev1.PackageRepositoryFetch{
+						Http: &kappcorev1.PackageRepositoryHttp{
+							SubPath: "packages",
+							Sha256:  "ABC",
+						},
+					},
+				})
 				return response
 			},
 		},
 		{
-			name: "check url",
+			name: "check inline type",
 			repositoryCustomizer: func(repository *packagingv1alpha1.PackageRepository) *packagingv1alpha1.PackageRepository {
-				repository.Spec.Fetch.ImgpkgBundle.Image = "foo"
+				repository.Spec.Fetch = &packagingv1alpha1.PackageRepositoryFetch{
+					Inline: &kappctrlv1alpha1.AppFetchInline{
+						Paths: map[string]string{
+							"dir/file.ext": "foo",
+						},
+						PathsFrom: []kappctrlv1alpha1.AppFetchInlineSource{
+							{
+								SecretRef: &kappctrlv1alpha1.AppFetchInlineSourceRef{Name: "my-secret", DirectoryPath: "foo"},
+							},
+							{
+								SecretRef:    &kappctrlv1alpha1.AppFetchInlineSourceRef{Name: "my-secret", DirectoryPath: "foo"},
+								ConfigMapRef: &kappctrlv1alpha1.AppFetchInlineSourceRef{Name: "my-secret", DirectoryPath: "bar"},
+							},
+						},
+					},
+				}
 				return repository
 			},
 			responseCustomizer: func(response *corev1.GetPackageRepositoryDetailResponse) *corev1.GetPackageRepositoryDetailResponse {
-				response.Detail.Url = "foo"
+				response.Detail.Type = typeInline
+				response.Detail.Url = ""
+				response.Detail.CustomDetail, _ = anypb.New(&kappcorev1.KappControllerPackageRepositoryCustomDetail{
+					Fetch: &kappcorev1.PackageRepositoryFetch{
+						Inline: &kappcorev1.PackageRepositoryInline{
+							Paths: map[string]string{
+								"dir/file.ext": "foo",
+							},
+							PathsFrom: []*kappcorev1.PackageRepositoryInline_Source{
+								{
+									SecretRef: &kappcorev1.PackageRepositoryInline_SourceRef{Name: "my-secret", DirectoryPath: "foo"},
+								},
+								{
+									SecretRef:    &kappcorev1.PackageRepositoryInline_SourceRef{Name: "my-secret", DirectoryPath: "foo"},
+									ConfigMapRef: &kappcorev1.PackageRepositoryInline_SourceRef{Name: "my-secret", DirectoryPath: "bar"},
+								},
+							},
+						},
+					},
+				})
 				return response
 			},
 		},
 		{
-			name: "check description",
+			name: "check auth - missing secret",
 			repositoryCustomizer: func(repository *packagingv1alpha1.PackageRepository) *packagingv1alpha1.PackageRepository {
-				repository.Annotations = map[string]string{k8sutils.AnnotationDescriptionKey: "repository description"}
+				repository.Spec.Fetch.ImgpkgBundle.SecretRef = &kappctrlv1alpha1.AppFetchLocalRef{
+					Name: "my-secret",
+				}
+				return repository
+			},
+			expectedErrorCode: connect.CodeNotFound,
+		},
+		{
+			name: "check auth - user managed secret",
+			existingTypedObjects: []k8sruntime.Object{
+				&k8scorev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: defaultGlobalContext.Namespace, Name: "my-secret"},
+					Data:       map[string][]byte{k8scorev1.BasicAuthUsernameKey: []byte("foo"), k8scorev1.BasicAuthPasswordKey: []byte("bar")},
+				},
+			},
+			repositoryCustomizer: func(repository *packagingv1alpha1.PackageRepository) *packagingv1alpha1.PackageRepository {
+				repository.Spec.Fetch.ImgpkgBundle.SecretRef = &kappctrlv1alpha1.AppFetchLocalRef{
+					Name: "my-secret",
+				}
 				return repository
 			},
 			responseCustomizer: func(response *corev1.GetPackageRepositoryDetailResponse) *corev1.GetPackageRepositoryDetailResponse {
-				response.Detail.Description = "repository description"
+				response.Detail.Auth = &corev1.PackageRepositoryAuth{
+					Type: corev1.PackageRepositoryAuth_PACKAGE_REPOSITORY_AUTH_TYPE_BASIC_AUTH,
+					PackageRepoAuthOneOf: &corev1.PackageRepositoryAuth_SecretRef{
+						SecretRef: &corev1.SecretKeyReference{
+							Name: "my-secret",
+						},
+					},
+				}
 				return response
 			},
 		},
 		{
-			name: "check interval (none)",
+			name: "check auth - plugin managed secret - basic auth",
+			existingTypedObjects: []k8sruntime.Object{
+				func() *k8scorev1.Secret {
+					s := defaultSecret()
+					s.Data[k8scorev1.BasicAuthUsernameKey] = []byte("foo")
+					s.Data[k8scorev1.BasicAuthPasswordKey] = []byte("bar")
+					return s
+				}(),
+			},
 			repositoryCustomizer: func(repository *packagingv1alpha1.PackageRepository) *packagingv1alpha1.PackageRepository {
-				repository.Spec.SyncPeriod = nil
+				repository.Spec.Fetch.ImgpkgBundle.SecretRef = &kappctrlv1alpha1.AppFetchLocalRef{
+					Name: "my-secret",
+				}
 				return repository
 			},
 			responseCustomizer: func(response *corev1.GetPackageRepositoryDetailResponse) *corev1.GetPackageRepositoryDetailResponse {
-				response.Detail.Interval = ""
+				response.Detail.Auth = &corev1.PackageRepositoryAuth{
+					Type: corev1.PackageRepositoryAuth_PACKAGE_REPOSITORY_AUTH_TYPE_BASIC_AUTH,
+					PackageRepoAuthOneOf: &corev1.PackageRepositoryAuth_UsernamePassword{
+						UsernamePassword: &corev1.UsernamePassword{
+							Username: redacted,
+							Password: redacted,
+						},
+					},
+				}
 				return response
 			},
 		},
 		{
-			name: "check interval (set)",
+			name: "check auth - plugin managed secret - ssh auth",
+			existingTypedObjects: []k8sruntime.Object{
+				func() *k8scorev1.Secret {
+					s := defaultSecret()
+					s.Data[k8scorev1.SSHAuthPrivateKey] = []byte("foo")
+					return s
+				}(),
+			},
 			repositoryCustomizer: func(repository *packagingv1alpha1.PackageRepository) *packagingv1alpha1.PackageRepository {
-				repository.Spec.SyncPeriod = &metav1.Duration{Duration: time.Duration(12) * time.Hour}
+				repository.Spec.Fetch.ImgpkgBundle.SecretRef = &kappctrlv1alpha1.AppFetchLocalRef{
+					Name: "my-secret",
+				}
 				return repository
 			},
 			responseCustomizer: func(response *corev1.GetPackageRepositoryDetailResponse) *corev1.GetPackageRepositoryDetailResponse {
-				response.Detail.Interval = "12h"
+				response.Detail.Auth = &corev1.PackageRepositoryAuth{
+					Type: corev1.PackageRepositoryAuth_PACKAGE_REPOSITORY_AUTH_TYPE_SSH,
+					PackageRepoAuthOneOf: &corev1.PackageRepositoryAuth_SshCreds{
+						SshCreds: &corev1.SshCredentials{
+							PrivateKey: redacted,
+							KnownHosts: redacted,
+						},
+					},
+				}
 				return response
 			},
 		},
 		{
-			name: "check imgpkg type",
+			name: "check auth - plugin managed secret - bearer auth",
+			existingTypedObjects: []k8sruntime.Object{
+				func() *k8scorev1.Secret {
+					s := defaultSecret()
+					s.Data[bearerAuthToken] = []byte("foo")
+					return s
+				}(),
+			},
 			repositoryCustomizer: func(repository *packagingv1alpha1.PackageRepository) *packagingv1alpha1.PackageRepository {
-				repository.Spec.Fetch = &packagingv1alpha1.PackageRepositoryFetch{
-					ImgpkgBundle: &kappctrlv1alpha1.AppFetchImgpkgBundle{
-						Image: "projects.registry.example.com/repo-1/main@sha256:abcd",
-						TagSelection: &vendirversions.VersionSelection{
-							Semver: &vendirversions.VersionSelectionSemver{
-								Constraints: ">0.10.0 <0.11.0",
-								Prereleases: &vendirversions.VersionSelectionSemverPrereleases{
-									Identifiers: []string{"beta", "rc"},
-								},
-							},
-						},
-					},
+				repository.Spec.Fetch.ImgpkgBundle.SecretRef = &kappctrlv1alpha1.AppFetchLocalRef{
+					Name: "my-secret",
 				}
 				return repository
 			},
 			responseCustomizer: func(response *corev1.GetPackageRepositoryDetailResponse) *corev1.GetPackageRepositoryDetailResponse {
-				response.Detail.Type = typeImgPkgBundle
-				response.Detail.Url = "projects.registry.example.com/repo-1/main@sha256:abcd"
-				response.Detail.CustomDetail, _ = anypb.New(&kappcorev1.KappControllerPackageRepositoryCustomDetail{
-					Fetch: &kappcorev1.PackageRepositoryFetch{
-						ImgpkgBundle: &kappcorev1.PackageRepositoryImgpkg{
-							TagSelection: &kappcorev1.VersionSelection{
-								Semver: &kappcorev1.VersionSelectionSemver{
-									Constraints: ">0.10.0 <0.11.0",
-									Prereleases: &kappcorev1.VersionSelectionSemverPrereleases{
-										Identifiers: []string{"beta", "rc"},
-									},
-								},
-							},
-						},
+				response.Detail.Auth = &corev1.PackageRepositoryAuth{
+					Type: corev1.PackageRepositoryAuth_PACKAGE_REPOSITORY_AUTH_TYPE_BEARER,
+					PackageRepoAuthOneOf: &corev1.PackageRepositoryAuth_Header{
+						Header: redacted,
 					},
-				})
+				}
 				return response
 			},
 		},
 		{
-			name: "check image type",
+			name: "check auth - plugin managed secret - docker auth",
+			existingTypedObjects: []k8sruntime.Object{
+				func() *k8scorev1.Secret {
+					s := defaultSecret()
+					s.Data[k8scorev1.DockerConfigJsonKey] = []byte(`{ "auths": { "localhost": { "username": "foo", "password": "bar", "email": "foo@example.com" }}}`)
+					return s
+				}(),
+			},
 			repositoryCustomizer: func(repository *packagingv1alpha1.PackageRepository) *packagingv1alpha1.PackageRepository {
-				repository.Spec.Fetch = &packagingv1alpha1.PackageRepositoryFetch{
-					Image: &kappctrlv1alpha1.AppFetchImage{
-						URL:     "projects.registry.example.com/repo-1/main@sha256:abcd",
-						SubPath: "packages",
-						TagSelection: &vendirversions.VersionSelection{
-							Semver: &vendirversions.VersionSelectionSemver{
-								Constraints: ">0.10.0 <0.11.0",
-								Prereleases: &vendirversions.VersionSelectionSemverPrereleases{
-									Identifiers: []string{"beta", "rc"},
-								},
-							},
-						},
-					},
+				repository.Spec.Fetch.ImgpkgBundle.SecretRef = &kappctrlv1alpha1.AppFetchLocalRef{
+					Name: "my-secret",
 				}
 				return repository
 			},
 			responseCustomizer: func(response *corev1.GetPackageRepositoryDetailResponse) *corev1.GetPackageRepositoryDetailResponse {
-				response.Detail.Type = typeImage
-				response.Detail.Url = "projects.registry.example.com/repo-1/main@sha256:abcd"
-				response.Detail.CustomDetail, _ = anypb.New(&kappcorev1.KappControllerPackageRepositoryCustomDetail{
-					Fetch: &kappcorev1.PackageRepositoryFetch{
-						Image: &kappcorev1.PackageRepositoryImage{
-							SubPath: "packages",
-							TagSelection: &kappcorev1.VersionSelection{
-								Semver: &kappcorev1.VersionSelectionSemver{
-									Constraints: ">0.10.0 <0.11.0",
-									Prereleases: &kappcorev1.VersionSelectionSemverPrereleases{
-										Identifiers: []string{"beta", "rc"},
-									},
-								},
-							},
+				response.Detail.Auth = &corev1.PackageRepositoryAuth{
+					Type: corev1.PackageRepositoryAuth_PACKAGE_REPOSITORY_AUTH_TYPE_DOCKER_CONFIG_JSON,
+					PackageRepoAuthOneOf: &corev1.PackageRepositoryAuth_DockerCreds{
+						DockerCreds: &corev1.DockerCredentials{
+							Server:   redacted,
+							Username: redacted,
+							Password: redacted,
+							Email:    redacted,
 						},
 					},
-				})
+				}
 				return response
 			},
 		},
 		{
-			name: "check git type",
+			name: "check auth - plugin managed secret - exported with secretgen",
+			existingObjects: []k8sruntime.Object{
+				func() *unstructured.Unstructured {
+					secretExport := (&Server{}).buildSecretExport(defaultSecret())
+					secretExport.Object["spec"] = map[string]interface{}{
+						"toNamespace":  "team-a",
+						"toNamespaces": []interface{}{"team-b"},
+					}
+					return secretExport
+				}(),
+			},
+			existingTypedObjects: []k8sruntime.Object{
+				func() *k8scorev1.Secret {
+					s := defaultSecret()
+					s.Data[bearerAuthToken] = []byte("Bearer foo")
+					return s
+				}(),
+			},
 			repositoryCustomizer: func(repository *packagingv1alpha1.PackageRepository) *packagingv1alpha1.PackageRepository {
-				repository.Spec.Fetch = &packagingv1alpha1.PackageRepositoryFetch{
-					Git: &kappctrlv1alpha1.AppFetchGit{
-						URL:     "https://github.com/projects.registry.vmware.com/tce/main",
-						Ref:     "main",
-						SubPath: "packages",
-						RefSelection: &vendirversions.VersionSelection{
-							Semver: &vendirversions.VersionSelectionSemver{
-								Constraints: ">0.10.0 <0.11.0",
-								Prereleases: &vendirversions.VersionSelectionSemverPrereleases{
-									Identifiers: []string{"beta", "rc"},
-								},
-							},
-						},
-						LFSSkipSmudge: true,
-					},
+				repository.Spec.Fetch.ImgpkgBundle.SecretRef = &kappctrlv1alpha1.AppFetchLocalRef{
+					Name: "my-secret",
 				}
 				return repository
 			},
 			responseCustomizer: func(response *corev1.GetPackageRepositoryDetailResponse) *corev1.GetPackageRepositoryDetailResponse {
-				response.Detail.Type = typeGIT
-				response.Detail.Url = "https://github.com/projects.registry.vmware.com/tce/main"
-				response.Detail.CustomDetail, _ = anypb.New(&kappcorev1.KappControllerPackageRepositoryCustomDetail{
-					Fetch: &kappcorev1.PackageRepositoryFetch{
-						Git: &kappcorev1.PackageRepositoryGit{
-							SubPath: "packages",
-							Ref:     "main",
-							RefSelection: &kappcorev1.VersionSelection{
-								Semver: &kappcorev1.VersionSelectionSemver{
-									Constraints: ">0.10.0 <0.11.0",
-									Prereleases: &kappcorev1.VersionSelectionSemverPrereleases{
-										Identifiers: []string{"beta", "rc"},
-									},
-								},
-							},
-							LfsSkipSmudge: true,
-						},
+				response.Detail.Auth = &corev1.PackageRepositoryAuth{
+					Type: corev1.PackageRepositoryAuth_PACKAGE_REPOSITORY_AUTH_TYPE_BEARER,
+					PackageRepoAuthOneOf: &corev1.PackageRepositoryAuth_Header{
+						Header: redacted,
 					},
+				}
+				response.Detail.CustomDetail, _ = anypb.New(&kappcorev1.KappControllerPackageRepositoryCustomDetail{
+					SecretExportedToNamespaces: []string{"team-a", "team-b"},
 				})
 				return response
 			},
 		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repository := defaultRepository()
+			if tc.repositoryCustomizer != nil {
+				repository = tc.repositoryCustomizer(repository)
+			}
+
+			var unstructuredObjects []k8sruntime.Object
+			for _, obj := range append([]k8sruntime.Object{repository}, tc.existingObjects...) {
+				unstructuredContent, _ := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(obj)
+				unstructuredObjects = append(unstructuredObjects, &unstructured.Unstructured{Object: unstructuredContent})
+			}
+
+			typedClient := typfake.NewSimpleClientset(tc.existingTypedObjects...)
+			dynamicClient := dynfake.NewSimpleDynamicClientWithCustomListKinds(
+				k8sruntime.NewScheme(),
+				map[schema.GroupVersionResource]string{
+					{Group: packagingv1alpha1.SchemeGroupVersion.Group, Version: packagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgRepositoriesResource}: pkgRepositoryResource + "List",
+				},
+				unstructuredObjects...,
+			)
+
+			s := Server{
+				pluginConfig: defaultPluginConfig,
+				clientGetter: clientgetter.NewBuilder().
+					WithTyped(typedClient).
+					WithDynamic(dynamicClient).
+					Build(),
+				globalPackagingCluster: defaultGlobalContext.Cluster,
+			}
+
+			// invocation
+			request := defaultRequest()
+			if tc.requestCustomizer != nil {
+				request = tc.requestCustomizer(request)
+			}
+
+			response, err := s.GetPackageRepositoryDetail(context.Background(), connect.NewRequest(request))
+
+			// checks
+			if got, want := connect.CodeOf(err), tc.expectedErrorCode; err != nil && got != want {
+				t.Fatalf("got error: %d, want: %d, err: %+v", got, want, err)
+			} else if got != 0 {
+				return
+			}
+
+			if got, want := request.PackageRepoRef, response.Msg.Detail.PackageRepoRef; !cmp.Equal(want, got, ignoreUnexported) {
+				t.Errorf("ref mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexported))
+			}
+
+			expectedResponse := defaultResponse()
+			if tc.responseCustomizer != nil {
+				expectedResponse = tc.responseCustomizer(expectedResponse)
+			}
+
+			if got, want := response.Msg, expectedResponse; !cmp.Equal(want, got, ignoreUnexported) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexported))
+			}
+		})
+	}
+}
+
+func TestGetPackageRepositoryDetailProbe(t *testing.T) {
+	testCases := []struct {
+		name          string
+		handler       http.Handler
+		expectedProbe func(serverURL string) *kappcorev1.PackageRepositoryProbe
+	}{
+		{
+			name: "it reports a repository fetched with its credentials",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if username, password, ok := r.BasicAuth(); !ok || username != "foo" || password != "bar" {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				w.Header().Set("ETag", `"abc"`)
+				w.WriteHeader(http.StatusOK)
+			}),
+			expectedProbe: func(serverURL string) *kappcorev1.PackageRepositoryProbe {
+				return &kappcorev1.PackageRepositoryProbe{
+					Success:  true,
+					Message:  fmt.Sprintf("Found the archive '%s/packages.tgz'", serverURL),
+					Metadata: map[string]string{"etag": `"abc"`},
+				}
+			},
+		},
 		{
-			name: "check http type",
-			repositoryCustomizer: func(repository *packagingv1alpha1.PackageRepository) *packagingv1alpha1.PackageRepository {
-				repository.Spec.Fetch = &packagingv1alpha1.PackageRepositoryFetch{
-					HTTP: &kappctrlv1alpha1.AppFetchHTTP{
-						URL:     "https://projects.registry.vmware.com/tce/main",
-						SubPath: "packages",
-						SHA256:  "ABC",
-					},
+			name: "it reports a repository rejecting its credentials",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusUnauthorized)
+			}),
+			expectedProbe: func(serverURL string) *kappcorev1.PackageRepositoryProbe {
+				return &kappcorev1.PackageRepositoryProbe{
+					Message:   fmt.Sprintf("Unexpected status '401 Unauthorized' from '%s/packages.tgz'", serverURL),
+					ErrorCode: "unauthenticated",
 				}
-				return repository
 			},
-			responseCustomizer: func(response *corev1.GetPackageRepositoryDetailResponse) *corev1.GetPackageRepositoryDetailResponse {
-				response.Detail.Type = typeHTTP
-				response.Detail.Url = "https://projects.registry.vmware.com/tce/main"
-				response.Detail.CustomDetail, _ = anypb.New(&kappcorev1.KappControllerPackageRepositoryCustomDetail{
-					Fetch: &kappcorev1.PackageRepositoryFetch{
-						Http: &kappcorev1.PackageRepositoryHttp{
-							SubPath: "packages",
-							Sha256:  "ABC",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repoServer := httptest.NewServer(tc.handler)
+			defer repoServer.Close()
+
+			repository := &packagingv1alpha1.PackageRepository{
+				TypeMeta:   defaultTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{Name: "globalrepo", Namespace: defaultGlobalContext.Namespace},
+				Spec: packagingv1alpha1.PackageRepositorySpec{
+					Fetch: &packagingv1alpha1.PackageRepositoryFetch{
+						HTTP: &kappctrlv1alpha1.AppFetchHTTP{
+							URL:       repoServer.URL + "/packages.tgz",
+							SecretRef: &kappctrlv1alpha1.AppFetchLocalRef{Name: "my-secret"},
 						},
 					},
-				})
-				return response
+				},
+			}
+			unstructuredContent, _ := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(repository)
+
+			s := Server{
+				pluginConfig: loopbackAllowedPluginConfig(),
+				clientGetter: clientgetter.NewBuilder().
+					WithTyped(typfake.NewSimpleClientset(&k8scorev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{Namespace: defaultGlobalContext.Namespace, Name: "my-secret"},
+						Data:       map[string][]byte{k8scorev1.BasicAuthUsernameKey: []byte("foo"), k8scorev1.BasicAuthPasswordKey: []byte("bar")},
+					})).
+					WithDynamic(dynfake.NewSimpleDynamicClientWithCustomListKinds(
+						k8sruntime.NewScheme(),
+						map[schema.GroupVersionResource]string{
+							{Group: packagingv1alpha1.SchemeGroupVersion.Group, Version: packagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgRepositoriesResource}: pkgRepositoryResource + "List",
+						},
+						&unstructured.Unstructured{Object: unstructuredContent},
+					)).
+					Build(),
+				globalPackagingCluster: defaultGlobalContext.Cluster,
+			}
+
+			response, err := s.GetPackageRepositoryDetail(context.Background(), connect.NewRequest(&corev1.GetPackageRepositoryDetailRequest{
+				PackageRepoRef: &corev1.PackageRepositoryReference{
+					Plugin:     &pluginDetail,
+					Context:    defaultGlobalContext,
+					Identifier: "globalrepo",
+				},
+				IncludeProbe: true,
+			}))
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+
+			customDetail := &kappcorev1.KappControllerPackageRepositoryCustomDetail{}
+			if err := response.Msg.Detail.CustomDetail.UnmarshalTo(customDetail); err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if got, want := customDetail.Probe, tc.expectedProbe(repoServer.URL); !cmp.Equal(want, got, ignoreUnexported) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexported))
+			}
+		})
+	}
+}
+
+// newTestSSHServer starts an SSH server, offering the returned ed25519 host
+// key, for the host key scans, and returns its address.
+func newTestSSHServer(t *testing.T) (string, ssh.PublicKey) {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	signer, err := ssh.NewSignerFromKey(privateKey)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	config := &ssh.ServerConfig{NoClientAuth: true}
+	config.AddHostKey(signer)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				// the scans abort the handshake once the host key is received
+				_, _, _, _ = ssh.NewServerConn(conn, config)
+			}()
+		}
+	}()
+	return listener.Addr().String(), signer.PublicKey()
+}
+
+func TestSshHostAddress(t *testing.T) {
+	testCases := []struct {
+		url               string
+		expectedAddress   string
+		expectedErrorCode connect.Code
+	}{
+		{url: "ssh://git@github.com/org/repo.git", expectedAddress: "github.com:22"},
+		{url: "ssh://git@git.example.com:2222/org/repo.git", expectedAddress: "git.example.com:2222"},
+		{url: "git@github.com:org/repo.git", expectedAddress: "github.com:22"},
+		{url: "https://github.com/org/repo.git", expectedErrorCode: connect.CodeInvalidArgument},
+		{url: "/org/repo.git", expectedErrorCode: connect.CodeInvalidArgument},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.url, func(t *testing.T) {
+			address, err := sshHostAddress(tc.url)
+			if got, want := connect.CodeOf(err), tc.expectedErrorCode; err != nil && got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			} else if err == nil && tc.expectedErrorCode != 0 {
+				t.Fatalf("got: nil, want: %+v", tc.expectedErrorCode)
+			}
+			if got, want := address, tc.expectedAddress; got != want {
+				t.Errorf("got: %q, want: %q", got, want)
+			}
+		})
+	}
+}
+
+func TestScanPackageRepositoryHostKeys(t *testing.T) {
+	address, hostKey := newTestSSHServer(t)
+	host := knownhosts.Normalize(address)
+	repoURL := fmt.Sprintf("ssh://git@%s/org/repo.git", address)
+
+	_, otherPrivateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	otherKey, err := ssh.NewPublicKey(otherPrivateKey.Public())
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	repoRef := &corev1.PackageRepositoryReference{
+		Context:    defaultGlobalContext,
+		Plugin:     &pluginDetail,
+		Identifier: "globalrepo",
+	}
+
+	testCases := []struct {
+		name              string
+		request           *kappcorev1.ScanPackageRepositoryHostKeysRequest
+		knownHosts        string
+		pluginConfig      *kappControllerPluginParsedConfig
+		expectedResponse  *kappcorev1.ScanPackageRepositoryHostKeysResponse
+		expectedErrorCode connect.Code
+	}{
+		{
+			name:    "it scans the host keys of a url",
+			request: &kappcorev1.ScanPackageRepositoryHostKeysRequest{Url: repoURL},
+			expectedResponse: &kappcorev1.ScanPackageRepositoryHostKeysResponse{
+				HostKeys: []*kappcorev1.PackageRepositoryHostKey{
+					{Host: host, Type: ssh.KeyAlgoED25519, Fingerprint: ssh.FingerprintSHA256(hostKey)},
+				},
+				KnownHosts: knownhosts.Line([]string{host}, hostKey) + "\n",
 			},
 		},
 		{
-			name: "check inline type",
-			repositoryCustomizer: func(repository *packagingv1alpha1.PackageRepository) *packagingv1alpha1.PackageRepository {
-				repository.Spec.Fetch = &packagingv1alpha1.PackageRepositoryFetch{
-					Inline: &kappctrlv1alpha1.AppFetchInline{
-						Paths: map[string]string{
-							"dir/file.ext": "foo",
-						},
-						PathsFrom: []kappctrlv1alpha1.AppFetchInlineSource{
-							{
-								SecretRef: &kappctrlv1alpha1.AppFetchInlineSourceRef{Name: "my-secret", DirectoryPath: "foo"},
-							},
-							{
-								SecretRef:    &kappctrlv1alpha1.AppFetchInlineSourceRef{Name: "my-secret", DirectoryPath: "foo"},
-								ConfigMapRef: &kappctrlv1alpha1.AppFetchInlineSourceRef{Name: "my-secret", DirectoryPath: "bar"},
-							},
-						},
-					},
-				}
-				return repository
-			},
-			responseCustomizer: func(response *corev1.GetPackageRepositoryDetailResponse) *corev1.GetPackageRepositoryDetailResponse {
-				response.Detail.Type = typeInline
-				response.Detail.Url = ""
-				response.Detail.CustomDetail, _ = anypb.New(&kappcorev1.KappControllerPackageRepositoryCustomDetail{
-					Fetch: &kappcorev1.PackageRepositoryFetch{
-						Inline: &kappcorev1.PackageRepositoryInline{
-							Paths: map[string]string{
-								"dir/file.ext": "foo",
-							},
-							PathsFrom: []*kappcorev1.PackageRepositoryInline_Source{
-								{
-									SecretRef: &kappcorev1.PackageRepositoryInline_SourceRef{Name: "my-secret", DirectoryPath: "foo"},
-								},
-								{
-									SecretRef:    &kappcorev1.PackageRepositoryInline_SourceRef{Name: "my-secret", DirectoryPath: "foo"},
-									ConfigMapRef: &kappcorev1.PackageRepositoryInline_SourceRef{Name: "my-secret", DirectoryPath: "bar"},
-								},
-							},
-						},
-					},
-				})
-				return response
+			name:       "it flags the host keys known by the repository",
+			request:    &kappcorev1.ScanPackageRepositoryHostKeysRequest{PackageRepoRef: repoRef},
+			knownHosts: knownhosts.Line([]string{host}, hostKey) + "\n",
+			expectedResponse: &kappcorev1.ScanPackageRepositoryHostKeysResponse{
+				HostKeys: []*kappcorev1.PackageRepositoryHostKey{
+					{Host: host, Type: ssh.KeyAlgoED25519, Fingerprint: ssh.FingerprintSHA256(hostKey), Known: true},
+				},
+				KnownHosts: knownhosts.Line([]string{host}, hostKey) + "\n",
 			},
 		},
 		{
-			name: "check auth - missing secret",
-			repositoryCustomizer: func(repository *packagingv1alpha1.PackageRepository) *packagingv1alpha1.PackageRepository {
-				repository.Spec.Fetch.ImgpkgBundle.SecretRef = &kappctrlv1alpha1.AppFetchLocalRef{
-					Name: "my-secret",
-				}
-				return repository
+			name:       "it flags the host keys known by the repository in hashed form",
+			request:    &kappcorev1.ScanPackageRepositoryHostKeysRequest{PackageRepoRef: repoRef},
+			knownHosts: knownhosts.Line([]string{knownhosts.HashHostname(host)}, hostKey) + "\n",
+			expectedResponse: &kappcorev1.ScanPackageRepositoryHostKeysResponse{
+				HostKeys: []*kappcorev1.PackageRepositoryHostKey{
+					{Host: host, Type: ssh.KeyAlgoED25519, Fingerprint: ssh.FingerprintSHA256(hostKey), Known: true},
+				},
+				KnownHosts: knownhosts.Line([]string{host}, hostKey) + "\n",
 			},
-			expectedErrorCode: connect.CodeNotFound,
 		},
 		{
-			name: "check auth - user managed secret",
-			existingTypedObjects: []k8sruntime.Object{
-				&k8scorev1.Secret{
-					ObjectMeta: metav1.ObjectMeta{Namespace: defaultGlobalContext.Namespace, Name: "my-secret"},
-					Data:       map[string][]byte{k8scorev1.BasicAuthUsernameKey: []byte("foo"), k8scorev1.BasicAuthPasswordKey: []byte("bar")},
+			name:       "it reports a changed host key",
+			request:    &kappcorev1.ScanPackageRepositoryHostKeysRequest{PackageRepoRef: repoRef},
+			knownHosts: knownhosts.Line([]string{host}, otherKey) + "\n",
+			expectedResponse: &kappcorev1.ScanPackageRepositoryHostKeysResponse{
+				HostKeys: []*kappcorev1.PackageRepositoryHostKey{
+					{Host: host, Type: ssh.KeyAlgoED25519, Fingerprint: ssh.FingerprintSHA256(hostKey)},
 				},
+				HostKeyChanged: true,
+				KnownHosts:     knownhosts.Line([]string{host}, hostKey) + "\n",
 			},
-			repositoryCustomizer: func(repository *packagingv1alpha1.PackageRepository) *packagingv1alpha1.PackageRepository {
-				repository.Spec.Fetch.ImgpkgBundle.SecretRef = &kappctrlv1alpha1.AppFetchLocalRef{
-					Name: "my-secret",
-				}
-				return repository
+		},
+		{
+			name:       "it ignores the known hosts of other hosts",
+			request:    &kappcorev1.ScanPackageRepositoryHostKeysRequest{PackageRepoRef: repoRef},
+			knownHosts: knownhosts.Line([]string{"github.com"}, otherKey) + "\n",
+			expectedResponse: &kappcorev1.ScanPackageRepositoryHostKeysResponse{
+				HostKeys: []*kappcorev1.PackageRepositoryHostKey{
+					{Host: host, Type: ssh.KeyAlgoED25519, Fingerprint: ssh.FingerprintSHA256(hostKey)},
+				},
+				KnownHosts: knownhosts.Line([]string{host}, hostKey) + "\n",
 			},
-			responseCustomizer: func(response *corev1.GetPackageRepositoryDetailResponse) *corev1.GetPackageRepositoryDetailResponse {
-				response.Detail.Auth = &corev1.PackageRepositoryAuth{
-					Type: corev1.PackageRepositoryAuth_PACKAGE_REPOSITORY_AUTH_TYPE_BASIC_AUTH,
-					PackageRepoAuthOneOf: &corev1.PackageRepositoryAuth_SecretRef{
-						SecretRef: &corev1.SecretKeyReference{
-							Name: "my-secret",
+		},
+		{
+			name:              "it returns invalid argument without url nor repository",
+			request:           &kappcorev1.ScanPackageRepositoryHostKeysRequest{},
+			expectedErrorCode: connect.CodeInvalidArgument,
+		},
+		{
+			name:              "it returns invalid argument for a url not over SSH",
+			request:           &kappcorev1.ScanPackageRepositoryHostKeysRequest{Url: "https://github.com/org/repo.git"},
+			expectedErrorCode: connect.CodeInvalidArgument,
+		},
+		{
+			name:              "it returns permission denied for a host in a blocked network",
+			request:           &kappcorev1.ScanPackageRepositoryHostKeysRequest{Url: repoURL},
+			pluginConfig:      defaultPluginConfig,
+			expectedErrorCode: connect.CodePermissionDenied,
+		},
+		{
+			name:              "it returns not found for an unknown repository",
+			request:           &kappcorev1.ScanPackageRepositoryHostKeysRequest{PackageRepoRef: &corev1.PackageRepositoryReference{Context: defaultGlobalContext, Identifier: "other"}},
+			expectedErrorCode: connect.CodeNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repository := &packagingv1alpha1.PackageRepository{
+				TypeMeta:   defaultTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{Name: "globalrepo", Namespace: defaultGlobalContext.Namespace},
+				Spec: packagingv1alpha1.PackageRepositorySpec{
+					Fetch: &packagingv1alpha1.PackageRepositoryFetch{
+						Git: &kappctrlv1alpha1.AppFetchGit{
+							URL:       repoURL,
+							SecretRef: &kappctrlv1alpha1.AppFetchLocalRef{Name: "my-secret"},
 						},
 					},
-				}
-				return response
+				},
+			}
+			unstructuredContent, _ := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(repository)
+
+			pluginConfig := loopbackAllowedPluginConfig()
+			if tc.pluginConfig != nil {
+				pluginConfig = tc.pluginConfig
+			}
+			s := Server{
+				pluginConfig: pluginConfig,
+				clientGetter: clientgetter.NewBuilder().
+					WithTyped(typfake.NewSimpleClientset(&k8scorev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{Namespace: defaultGlobalContext.Namespace, Name: "my-secret"},
+						Data:       map[string][]byte{k8scorev1.SSHAuthPrivateKey: []byte("ssh-key"), sshAuthKnownHosts: []byte(tc.knownHosts)},
+					})).
+					WithDynamic(dynfake.NewSimpleDynamicClientWithCustomListKinds(
+						k8sruntime.NewScheme(),
+						map[schema.GroupVersionResource]string{
+							{Group: packagingv1alpha1.SchemeGroupVersion.Group, Version: packagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgRepositoriesResource}: pkgRepositoryResource + "List",
+						},
+						&unstructured.Unstructured{Object: unstructuredContent},
+					)).
+					Build(),
+				globalPackagingCluster: defaultGlobalContext.Cluster,
+			}
+
+			response, err := s.ScanPackageRepositoryHostKeys(context.Background(), connect.NewRequest(tc.request))
+			if got, want := connect.CodeOf(err), tc.expectedErrorCode; err != nil && got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			} else if err == nil && tc.expectedErrorCode != 0 {
+				t.Fatalf("got: nil, want: %+v", tc.expectedErrorCode)
+			}
+			if tc.expectedErrorCode != 0 {
+				return
+			}
+
+			if got, want := response.Msg, tc.expectedResponse; !cmp.Equal(want, got, ignoreUnexported) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexported))
+			}
+		})
+	}
+}
+
+func TestPackageRepositoryTrustedHostKeys(t *testing.T) {
+	address, hostKey := newTestSSHServer(t)
+	host := knownhosts.Normalize(address)
+	repoURL := fmt.Sprintf("ssh://git@%s/org/repo.git", address)
+	expectedKnownHosts := knownhosts.Line([]string{host}, hostKey) + "\n"
+
+	sshAuth := func(privateKey, knownHosts string) *corev1.PackageRepositoryAuth {
+		return &corev1.PackageRepositoryAuth{
+			Type: corev1.PackageRepositoryAuth_PACKAGE_REPOSITORY_AUTH_TYPE_SSH,
+			PackageRepoAuthOneOf: &corev1.PackageRepositoryAuth_SshCreds{
+				SshCreds: &corev1.SshCredentials{PrivateKey: privateKey, KnownHosts: knownHosts},
 			},
+		}
+	}
+	trustedDetail := func(fingerprints ...string) *anypb.Any {
+		customDetail, _ := anypb.New(&kappcorev1.KappControllerPackageRepositoryCustomDetail{
+			TrustedHostKeyFingerprints: fingerprints,
+		})
+		return customDetail
+	}
+
+	addTestCases := []struct {
+		name               string
+		repoType           string
+		auth               *corev1.PackageRepositoryAuth
+		customDetail       *anypb.Any
+		pluginConfig       *kappControllerPluginParsedConfig
+		expectedErrorCode  connect.Code
+		expectedKnownHosts string
+	}{
+		{
+			name:               "it stores the host keys matching the trusted fingerprints",
+			repoType:           typeGIT,
+			auth:               sshAuth("ssh-key", ""),
+			customDetail:       trustedDetail(ssh.FingerprintSHA256(hostKey)),
+			expectedKnownHosts: expectedKnownHosts,
 		},
 		{
-			name: "check auth - plugin managed secret - basic auth",
-			existingTypedObjects: []k8sruntime.Object{
-				func() *k8scorev1.Secret {
-					s := defaultSecret()
-					s.Data[k8scorev1.BasicAuthUsernameKey] = []byte("foo")
-					s.Data[k8scorev1.BasicAuthPasswordKey] = []byte("bar")
-					return s
-				}(),
-			},
-			repositoryCustomizer: func(repository *packagingv1alpha1.PackageRepository) *packagingv1alpha1.PackageRepository {
-				repository.Spec.Fetch.ImgpkgBundle.SecretRef = &kappctrlv1alpha1.AppFetchLocalRef{
-					Name: "my-secret",
-				}
-				return repository
+			name:               "it replaces the known hosts provided by the user",
+			repoType:           typeGIT,
+			auth:               sshAuth("ssh-key", "github.com ssh-ed25519 AAAA"),
+			customDetail:       trustedDetail("SHA256:unknown", ssh.FingerprintSHA256(hostKey)),
+			expectedKnownHosts: expectedKnownHosts,
+		},
+		{
+			name:              "it fails when the git server is in a blocked network",
+			repoType:          typeGIT,
+			auth:              sshAuth("ssh-key", ""),
+			customDetail:      trustedDetail(ssh.FingerprintSHA256(hostKey)),
+			pluginConfig:      defaultPluginConfig,
+			expectedErrorCode: connect.CodePermissionDenied,
+		},
+		{
+			name:              "it fails when no host key matches the trusted fingerprints",
+			repoType:          typeGIT,
+			auth:              sshAuth("ssh-key", ""),
+			customDetail:      trustedDetail("SHA256:unknown"),
+			expectedErrorCode: connect.CodeFailedPrecondition,
+		},
+		{
+			name:     "it requires SSH credentials",
+			repoType: typeGIT,
+			auth: &corev1.PackageRepositoryAuth{
+				Type: corev1.PackageRepositoryAuth_PACKAGE_REPOSITORY_AUTH_TYPE_BASIC_AUTH,
+				PackageRepoAuthOneOf: &corev1.PackageRepositoryAuth_UsernamePassword{
+					UsernamePassword: &corev1.UsernamePassword{Username: "foo", Password: "bar"},
+				},
 			},
-			responseCustomizer: func(response *corev1.GetPackageRepositoryDetailResponse) *corev1.GetPackageRepositoryDetailResponse {
-				response.Detail.Auth = &corev1.PackageRepositoryAuth{
-					Type: corev1.PackageRepositoryAuth_PACKAGE_REPOSITORY_AUTH_TYPE_BASIC_AUTH,
-					PackageRepoAuthOneOf: &corev1.PackageRepositoryAuth_UsernamePassword{
-						UsernamePassword: &corev1.UsernamePassword{
-							Username: redacted,
-							Password: redacted,
+			customDetail:      trustedDetail(ssh.FingerprintSHA256(hostKey)),
+			expectedErrorCode: connect.CodeInvalidArgument,
+		},
+		{
+			name:              "it requires a git repository",
+			repoType:          typeHTTP,
+			customDetail:      trustedDetail(ssh.FingerprintSHA256(hostKey)),
+			expectedErrorCode: connect.CodeInvalidArgument,
+		},
+	}
+
+	for _, tc := range addTestCases {
+		t.Run(tc.name, func(t *testing.T) {
+			typedClient := typfake.NewSimpleClientset()
+			typedClient.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (handled bool, ret k8sruntime.Object, err error) {
+				return true, &authorizationv1.SelfSubjectAccessReview{Status: authorizationv1.SubjectAccessReviewStatus{Allowed: true}}, nil
+			})
+			pluginConfig := loopbackAllowedPluginConfig()
+			if tc.pluginConfig != nil {
+				pluginConfig = tc.pluginConfig
+			}
+			s := Server{
+				pluginConfig: pluginConfig,
+				clientGetter: clientgetter.NewBuilder().
+					WithTyped(typedClient).
+					WithDynamic(dynfake.NewSimpleDynamicClientWithCustomListKinds(
+						k8sruntime.NewScheme(),
+						map[schema.GroupVersionResource]string{
+							{Group: packagingv1alpha1.SchemeGroupVersion.Group, Version: packagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgRepositoriesResource}: pkgRepositoryResource + "List",
 						},
+					)).
+					Build(),
+				globalPackagingCluster: defaultGlobalContext.Cluster,
+			}
+
+			_, err := s.AddPackageRepository(context.Background(), connect.NewRequest(&corev1.AddPackageRepositoryRequest{
+				Context:      defaultGlobalContext,
+				Name:         "globalrepo",
+				Type:         tc.repoType,
+				Url:          repoURL,
+				Interval:     "24h",
+				Plugin:       &pluginDetail,
+				Auth:         tc.auth,
+				CustomDetail: tc.customDetail,
+			}))
+			if got, want := connect.CodeOf(err), tc.expectedErrorCode; err != nil && got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			} else if err == nil && tc.expectedErrorCode != 0 {
+				t.Fatalf("got: nil, want: %+v", tc.expectedErrorCode)
+			}
+			if tc.expectedErrorCode != 0 {
+				return
+			}
+
+			// the name will be empty as the fake client does not handle generating names
+			secret, err := s.getSecret(context.Background(), http.Header{}, defaultGlobalContext.Cluster, defaultGlobalContext.Namespace, "")
+			if err != nil {
+				t.Fatalf("error fetching secret:%+v", err)
+			}
+			if got, want := secret.StringData[sshAuthKnownHosts], tc.expectedKnownHosts; got != want {
+				t.Errorf("got: %q, want: %q", got, want)
+			}
+		})
+	}
+
+	t.Run("it rotates the known hosts of an existing repository", func(t *testing.T) {
+		repository := &packagingv1alpha1.PackageRepository{
+			TypeMeta:   defaultTypeMeta,
+			ObjectMeta: metav1.ObjectMeta{Name: "globalrepo", Namespace: defaultGlobalContext.Namespace, UID: "globalrepo"},
+			Spec: packagingv1alpha1.PackageRepositorySpec{
+				SyncPeriod: &metav1.Duration{Duration: time.Duration(24) * time.Hour},
+				Fetch: &packagingv1alpha1.PackageRepositoryFetch{
+					Git: &kappctrlv1alpha1.AppFetchGit{
+						URL:       repoURL,
+						SecretRef: &kappctrlv1alpha1.AppFetchLocalRef{Name: "my-secret"},
 					},
-				}
-				return response
+				},
+			},
+		}
+		unstructuredContent, _ := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(repository)
+		secret := &k8scorev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   defaultGlobalContext.Namespace,
+				Name:        "my-secret",
+				Annotations: map[string]string{annotationManagedByKey: annotationManagedByValue},
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						APIVersion: defaultTypeMeta.APIVersion,
+						Kind:       defaultTypeMeta.Kind,
+						Name:       "globalrepo",
+						UID:        "globalrepo",
+						Controller: func() *bool { v := true; return &v }(),
+					},
+				},
+			},
+			Type: k8scorev1.SecretTypeOpaque,
+			Data: map[string][]byte{
+				k8scorev1.SSHAuthPrivateKey: []byte("ssh-key"),
+				sshAuthKnownHosts:           []byte(host + " ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIOMqqnkVzrm0SdG6UOoqKLsabgH5C9okWi0dh2l9GKJl\n"),
+			},
+		}
+
+		s := Server{
+			pluginConfig: loopbackAllowedPluginConfig(),
+			clientGetter: clientgetter.NewBuilder().
+				WithTyped(typfake.NewSimpleClientset(secret)).
+				WithDynamic(dynfake.NewSimpleDynamicClientWithCustomListKinds(
+					k8sruntime.NewScheme(),
+					map[schema.GroupVersionResource]string{
+						{Group: packagingv1alpha1.SchemeGroupVersion.Group, Version: packagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgRepositoriesResource}: pkgRepositoryResource + "List",
+					},
+					&unstructured.Unstructured{Object: unstructuredContent},
+				)).
+				Build(),
+			globalPackagingCluster: defaultGlobalContext.Cluster,
+		}
+
+		_, err := s.UpdatePackageRepository(context.Background(), connect.NewRequest(&corev1.UpdatePackageRepositoryRequest{
+			PackageRepoRef: &corev1.PackageRepositoryReference{
+				Context:    defaultGlobalContext,
+				Plugin:     &pluginDetail,
+				Identifier: "globalrepo",
+			},
+			Url:          repoURL,
+			Interval:     "24h",
+			Auth:         sshAuth(redacted, redacted),
+			CustomDetail: trustedDetail(ssh.FingerprintSHA256(hostKey)),
+		}))
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+
+		// the secret is updated via delete+create, the fake client not generating its name
+		updated, err := s.getSecret(context.Background(), http.Header{}, defaultGlobalContext.Cluster, defaultGlobalContext.Namespace, "")
+		if err != nil {
+			t.Fatalf("error fetching secret:%+v", err)
+		}
+		if got, want := updated.StringData[sshAuthKnownHosts], expectedKnownHosts; got != want {
+			t.Errorf("got: %q, want: %q", got, want)
+		}
+		if got, want := updated.StringData[k8scorev1.SSHAuthPrivateKey], "ssh-key"; got != want {
+			t.Errorf("got: %q, want: %q", got, want)
+		}
+	})
+}
+
+func TestListPackageRepositoryPackages(t *testing.T) {
+	repository := func(name string, fetch *kappctrlv1alpha1.AppStatusFetch) *packagingv1alpha1.PackageRepository {
+		return &packagingv1alpha1.PackageRepository{
+			TypeMeta:   defaultTypeMeta,
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Status:     packagingv1alpha1.PackageRepositoryStatus{Fetch: fetch},
+		}
+	}
+	existingObjects := []k8sruntime.Object{
+		repository("repo-a", &kappctrlv1alpha1.AppStatusFetch{
+			UpdatedAt: metav1.Time{Time: time.Date(2023, time.March, 1, 10, 0, 0, 0, time.UTC)},
+		}),
+		repository("repo-b", nil),
+	}
+	for _, pkg := range []struct{ name, repoRef string }{
+		{"apple", "default/repo-a"},
+		{"banana", "default/repo-b"},
+		{"cherry", "default/repo-a"},
+		// packages of a global repository with the same name are visible in
+		// the namespace too.
+		{"damson", demoGlobalPackagingNamespace + "/repo-a"},
+	} {
+		existingObjects = append(existingObjects,
+			&datapackagingv1alpha1.PackageMetadata{
+				TypeMeta: metav1.TypeMeta{Kind: pkgMetadataResource, APIVersion: datapackagingAPIVersion},
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "default",
+					Name:        pkg.name + ".example.com",
+					Annotations: map[string]string{REPO_REF_ANNOTATION: pkg.repoRef},
+				},
+				Spec: datapackagingv1alpha1.PackageMetadataSpec{DisplayName: pkg.name},
+			},
+			&datapackagingv1alpha1.Package{
+				TypeMeta:   metav1.TypeMeta{Kind: pkgResource, APIVersion: datapackagingAPIVersion},
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: pkg.name + ".example.com.1.0.0"},
+				Spec: datapackagingv1alpha1.PackageSpec{
+					RefName: pkg.name + ".example.com",
+					Version: "1.0.0",
+				},
 			},
+		)
+	}
+
+	testCases := []struct {
+		name                  string
+		identifier            string
+		paginationOptions     *corev1.PaginationOptions
+		expectedNames         []string
+		expectedNextPageToken string
+		expectedTotalCount    *int32
+		expectedLastUpdated   string
+		expectedErrorCode     connect.Code
+	}{
+		{
+			name:                "it returns the packages of the repository with its last fetch time",
+			identifier:          "repo-a",
+			expectedNames:       []string{"apple", "cherry"},
+			expectedTotalCount:  proto.Int32(2),
+			expectedLastUpdated: "2023-03-01T10:00:00Z",
 		},
 		{
-			name: "check auth - plugin managed secret - ssh auth",
-			existingTypedObjects: []k8sruntime.Object{
-				func() *k8scorev1.Secret {
-					s := defaultSecret()
-					s.Data[k8scorev1.SSHAuthPrivateKey] = []byte("foo")
-					return s
-				}(),
-			},
-			repositoryCustomizer: func(repository *packagingv1alpha1.PackageRepository) *packagingv1alpha1.PackageRepository {
-				repository.Spec.Fetch.ImgpkgBundle.SecretRef = &kappctrlv1alpha1.AppFetchLocalRef{
-					Name: "my-secret",
-				}
-				return repository
-			},
-			responseCustomizer: func(response *corev1.GetPackageRepositoryDetailResponse) *corev1.GetPackageRepositoryDetailResponse {
-				response.Detail.Auth = &corev1.PackageRepositoryAuth{
-					Type: corev1.PackageRepositoryAuth_PACKAGE_REPOSITORY_AUTH_TYPE_SSH,
-					PackageRepoAuthOneOf: &corev1.PackageRepositoryAuth_SshCreds{
-						SshCreds: &corev1.SshCredentials{
-							PrivateKey: redacted,
-							KnownHosts: redacted,
-						},
-					},
-				}
-				return response
-			},
+			name:                  "it returns a page of the packages of the repository",
+			identifier:            "repo-a",
+			paginationOptions:     &corev1.PaginationOptions{PageSize: 1},
+			expectedNames:         []string{"apple"},
+			expectedNextPageToken: "1",
+			expectedLastUpdated:   "2023-03-01T10:00:00Z",
 		},
 		{
-			name: "check auth - plugin managed secret - bearer auth",
-			existingTypedObjects: []k8sruntime.Object{
-				func() *k8scorev1.Secret {
-					s := defaultSecret()
-					s.Data[bearerAuthToken] = []byte("foo")
-					return s
-				}(),
-			},
-			repositoryCustomizer: func(repository *packagingv1alpha1.PackageRepository) *packagingv1alpha1.PackageRepository {
-				repository.Spec.Fetch.ImgpkgBundle.SecretRef = &kappctrlv1alpha1.AppFetchLocalRef{
-					Name: "my-secret",
-				}
-				return repository
-			},
-			responseCustomizer: func(response *corev1.GetPackageRepositoryDetailResponse) *corev1.GetPackageRepositoryDetailResponse {
-				response.Detail.Auth = &corev1.PackageRepositoryAuth{
-					Type: corev1.PackageRepositoryAuth_PACKAGE_REPOSITORY_AUTH_TYPE_BEARER,
-					PackageRepoAuthOneOf: &corev1.PackageRepositoryAuth_Header{
-						Header: redacted,
-					},
-				}
-				return response
-			},
+			name:               "it returns the packages of a repository not fetched yet",
+			identifier:         "repo-b",
+			expectedNames:      []string{"banana"},
+			expectedTotalCount: proto.Int32(1),
 		},
 		{
-			name: "check auth - plugin managed secret - docker auth",
-			existingTypedObjects: []k8sruntime.Object{
-				func() *k8scorev1.Secret {
-					s := defaultSecret()
-					s.Data[k8scorev1.DockerConfigJsonKey] = []byte(`{ "auths": { "localhost": { "username": "foo", "password": "bar", "email": "foo@example.com" }}}`)
-					return s
-				}(),
-			},
-			repositoryCustomizer: func(repository *packagingv1alpha1.PackageRepository) *packagingv1alpha1.PackageRepository {
-				repository.Spec.Fetch.ImgpkgBundle.SecretRef = &kappctrlv1alpha1.AppFetchLocalRef{
-					Name: "my-secret",
-				}
-				return repository
-			},
-			responseCustomizer: func(response *corev1.GetPackageRepositoryDetailResponse) *corev1.GetPackageRepositoryDetailResponse {
-				response.Detail.Auth = &corev1.PackageRepositoryAuth{
-					Type: corev1.PackageRepositoryAuth_PACKAGE_REPOSITORY_AUTH_TYPE_DOCKER_CONFIG_JSON,
-					PackageRepoAuthOneOf: &corev1.PackageRepositoryAuth_DockerCreds{
-						DockerCreds: &corev1.DockerCredentials{
-							Server:   redacted,
-							Username: redacted,
-							Password: redacted,
-							Email:    redacted,
-						},
-					},
-				}
-				return response
-			},
+			name:              "it returns not found for an unknown repository",
+			identifier:        "repo-c",
+			expectedErrorCode: connect.CodeNotFound,
 		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			repository := defaultRepository()
-			if tc.repositoryCustomizer != nil {
-				repository = tc.repositoryCustomizer(repository)
-			}
-
 			var unstructuredObjects []k8sruntime.Object
-			for _, obj := range []k8sruntime.Object{repository} {
+			for _, obj := range existingObjects {
 				unstructuredContent, _ := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(obj)
 				unstructuredObjects = append(unstructuredObjects, &unstructured.Unstructured{Object: unstructuredContent})
 			}
 
-			typedClient := typfake.NewSimpleClientset(tc.existingTypedObjects...)
 			dynamicClient := dynfake.NewSimpleDynamicClientWithCustomListKinds(
 				k8sruntime.NewScheme(),
 				map[schema.GroupVersionResource]string{
-					{Group: packagingv1alpha1.SchemeGroupVersion.Group, Version: packagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgRepositoriesResource}: pkgRepositoryResource + "List",
+					{Group: datapackagingv1alpha1.SchemeGroupVersion.Group, Version: datapackagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgsResource}:         pkgResource + "List",
+					{Group: datapackagingv1alpha1.SchemeGroupVersion.Group, Version: datapackagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgMetadatasResource}: pkgMetadataResource + "List",
+					{Group: packagingv1alpha1.SchemeGroupVersion.Group, Version: packagingv1alpha1.SchemeGroupVersion.Version, Resource: pkgRepositoriesResource}:      pkgRepositoryResource + "List",
 				},
 				unstructuredObjects...,
 			)
-
 			s := Server{
-				pluginConfig: defaultPluginConfig,
-				clientGetter: clientgetter.NewBuilder().
-					WithTyped(typedClient).
-					WithDynamic(dynamicClient).
-					Build(),
-				globalPackagingCluster: defaultGlobalContext.Cluster,
-			}
-
-			// invocation
-			request := defaultRequest()
-			if tc.requestCustomizer != nil {
-				request = tc.requestCustomizer(request)
+				pluginConfig:                    defaultPluginConfig,
+				clientGetter:                    clientgetter.NewBuilder().WithDynamic(dynamicClient).Build(),
+				localServiceAccountClientGetter: clientgetter.NewBuilder().WithDynamic(dynamicClient).BuildFixedCluster(),
+				globalPackagingCluster:          defaultContext.Cluster,
+				pkgListPositions:                newPkgListPositionCache(),
 			}
 
-			response, err := s.GetPackageRepositoryDetail(context.Background(), connect.NewRequest(request))
+			response, err := s.ListPackageRepositoryPackages(context.Background(), connect.NewRequest(&corev1.ListPackageRepositoryPackagesRequest{
+				PackageRepoRef: &corev1.PackageRepositoryReference{
+					Plugin:     &pluginDetail,
+					Context:    defaultContext,
+					Identifier: tc.identifier,
+				},
+				PaginationOptions: tc.paginationOptions,
+			}))
 
-			// checks
 			if got, want := connect.CodeOf(err), tc.expectedErrorCode; err != nil && got != want {
-				t.Fatalf("got error: %d, want: %d, err: %+v", got, want, err)
-			} else if got != 0 {
+				t.Fatalf("got: %d, want: %d, err: %+v", got, want, err)
+			}
+			if tc.expectedErrorCode != 0 {
 				return
 			}
 
-			if got, want := request.PackageRepoRef, response.Msg.Detail.PackageRepoRef; !cmp.Equal(want, got, ignoreUnexported) {
-				t.Errorf("ref mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexported))
+			names := []string{}
+			for _, summary := range response.Msg.AvailablePackageSummaries {
+				names = append(names, summary.DisplayName)
 			}
-
-			expectedResponse := defaultResponse()
-			if tc.responseCustomizer != nil {
-				expectedResponse = tc.responseCustomizer(expectedResponse)
+			if got, want := names, tc.expectedNames; !cmp.Equal(got, want) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
 			}
-
-			if got, want := response.Msg, expectedResponse; !cmp.Equal(want, got, ignoreUnexported) {
-				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexported))
+			if got, want := response.Msg.NextPageToken, tc.expectedNextPageToken; got != want {
+				t.Errorf("got: %q, want: %q", got, want)
+			}
+			if got, want := response.Msg.TotalCount, tc.expectedTotalCount; !cmp.Equal(got, want) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+			if got, want := response.Msg.LastUpdated, tc.expectedLastUpdated; got != want {
+				t.Errorf("got: %q, want: %q", got, want)
 			}
 		})
 	}
@@ -9241,6 +15319,16 @@ func TestGetPackageRepositorySummariesNamespaces(t *testing.T) {
 						Url:             "projects.registry.example.com/repo-1/main@sha256:abcd",
 					},
 				},
+				PartialResults: &corev1.PartialResults{
+					NamespaceFailures: []*corev1.NamespaceFailure{
+						{
+							Context: &corev1.Context{Cluster: defaultContext.Cluster, Namespace: "ns-inaccessible"},
+							Plugin:  &pluginDetail,
+							Code:    connect.CodePermissionDenied.String(),
+							Reason:  "Forbidden to list the PackageRepository 'all' due to 'PackageRepository.authorization.k8s.io is forbidden: bang'",
+						},
+					},
+				},
 			},
 		},
 	}
@@ -9441,7 +15529,7 @@ func TestGetPackageRepositorySummariesFiltering(t *testing.T) {
 }
 
 func TestGetPackageRepositoryStatus(t *testing.T) {
-	factory := func(status kappctrlv1alpha1.GenericStatus) *packagingv1alpha1.PackageRepository {
+	factory := func(status packagingv1alpha1.PackageRepositoryStatus, syncPeriod *metav1.Duration) *packagingv1alpha1.PackageRepository {
 		return &packagingv1alpha1.PackageRepository{
 			TypeMeta:   defaultTypeMeta,
 			ObjectMeta: metav1.ObjectMeta{Name: "nsrepo", Namespace: "privatens"},
@@ -9451,16 +15539,21 @@ func TestGetPackageRepositoryStatus(t *testing.T) {
 						Image: "projects.registry.example.com/repo-1/main@sha256:abcd",
 					},
 				},
+				SyncPeriod: syncPeriod,
 			},
-			Status: packagingv1alpha1.PackageRepositoryStatus{
-				GenericStatus: status,
-			},
+			Status: status,
 		}
 	}
 
+	lastAttempt := metav1.Date(2023, time.March, 1, 10, 0, 0, 0, time.UTC)
+	longMessage := strings.Repeat("x", maxStatusMessageLength-len(truncatedSuffix)-1) + "ñ" + strings.Repeat("x", 100)
+
 	testCases := []struct {
 		name             string
 		existingStatus   kappctrlv1alpha1.GenericStatus
+		existingFetch    *kappctrlv1alpha1.AppStatusFetch
+		failures         int
+		syncPeriod       *metav1.Duration
 		expectedResponse *corev1.PackageRepositoryStatus
 	}{
 		{
@@ -9477,6 +15570,12 @@ func TestGetPackageRepositoryStatus(t *testing.T) {
 				Ready:      true,
 				Reason:     corev1.PackageRepositoryStatus_STATUS_REASON_SUCCESS,
 				UserReason: "Succeeded",
+				Conditions: []*corev1.PackageRepositoryCondition{
+					{
+						Type:    string(kappctrlv1alpha1.ReconcileSucceeded),
+						Message: "Succeeded",
+					},
+				},
 			},
 		},
 		{
@@ -9492,6 +15591,12 @@ func TestGetPackageRepositoryStatus(t *testing.T) {
 			expectedResponse: &corev1.PackageRepositoryStatus{
 				Reason:     corev1.PackageRepositoryStatus_STATUS_REASON_PENDING,
 				UserReason: "Fetching in progress",
+				Conditions: []*corev1.PackageRepositoryCondition{
+					{
+						Type:    string(kappctrlv1alpha1.Reconciling),
+						Message: "Fetching in progress",
+					},
+				},
 			},
 		},
 		{
@@ -9506,6 +15611,11 @@ func TestGetPackageRepositoryStatus(t *testing.T) {
 			expectedResponse: &corev1.PackageRepositoryStatus{
 				Reason:     corev1.PackageRepositoryStatus_STATUS_REASON_PENDING,
 				UserReason: "Reconciling",
+				Conditions: []*corev1.PackageRepositoryCondition{
+					{
+						Type: string(kappctrlv1alpha1.Reconciling),
+					},
+				},
 			},
 		},
 		{
@@ -9521,6 +15631,12 @@ func TestGetPackageRepositoryStatus(t *testing.T) {
 			expectedResponse: &corev1.PackageRepositoryStatus{
 				Reason:     corev1.PackageRepositoryStatus_STATUS_REASON_PENDING,
 				UserReason: "Terminating",
+				Conditions: []*corev1.PackageRepositoryCondition{
+					{
+						Type:    string(kappctrlv1alpha1.Deleting),
+						Message: "Terminating",
+					},
+				},
 			},
 		},
 		{
@@ -9528,81 +15644,250 @@ func TestGetPackageRepositoryStatus(t *testing.T) {
 			existingStatus: kappctrlv1alpha1.GenericStatus{
 				Conditions: []kappctrlv1alpha1.Condition{
 					{
-						Type: kappctrlv1alpha1.Deleting,
+						Type: kappctrlv1alpha1.Deleting,
+					},
+				},
+			},
+			expectedResponse: &corev1.PackageRepositoryStatus{
+				Reason:     corev1.PackageRepositoryStatus_STATUS_REASON_PENDING,
+				UserReason: "Deleting",
+				Conditions: []*corev1.PackageRepositoryCondition{
+					{
+						Type: string(kappctrlv1alpha1.Deleting),
+					},
+				},
+			},
+		},
+		{
+			name: "reconciliation failure",
+			existingStatus: kappctrlv1alpha1.GenericStatus{
+				Conditions: []kappctrlv1alpha1.Condition{
+					{
+						Type:    kappctrlv1alpha1.ReconcileFailed,
+						Message: "fetch failure",
+					},
+				},
+			},
+			expectedResponse: &corev1.PackageRepositoryStatus{
+				Reason:     corev1.PackageRepositoryStatus_STATUS_REASON_FAILED,
+				UserReason: "fetch failure",
+				Conditions: []*corev1.PackageRepositoryCondition{
+					{
+						Type:    string(kappctrlv1alpha1.ReconcileFailed),
+						Message: "fetch failure",
+					},
+				},
+			},
+		},
+		{
+			name: "reconciliation failure, extra error message",
+			existingStatus: kappctrlv1alpha1.GenericStatus{
+				Conditions: []kappctrlv1alpha1.Condition{
+					{
+						Type:    kappctrlv1alpha1.ReconcileFailed,
+						Message: "see .status.usefulErrorMessage for detailed error message",
+					},
+				},
+				UsefulErrorMessage: "fetch failed connecting to registry",
+			},
+			expectedResponse: &corev1.PackageRepositoryStatus{
+				Reason:     corev1.PackageRepositoryStatus_STATUS_REASON_FAILED,
+				UserReason: "fetch failed connecting to registry",
+				Conditions: []*corev1.PackageRepositoryCondition{
+					{
+						Type:    string(kappctrlv1alpha1.ReconcileFailed),
+						Message: "fetch failed connecting to registry",
+					},
+				},
+			},
+		},
+		{
+			name: "deletion failure",
+			existingStatus: kappctrlv1alpha1.GenericStatus{
+				Conditions: []kappctrlv1alpha1.Condition{
+					{
+						Type:    kappctrlv1alpha1.DeleteFailed,
+						Message: "failed termination",
+					},
+				},
+			},
+			expectedResponse: &corev1.PackageRepositoryStatus{
+				Reason:     corev1.PackageRepositoryStatus_STATUS_REASON_FAILED,
+				UserReason: "failed termination",
+				Conditions: []*corev1.PackageRepositoryCondition{
+					{
+						Type:    string(kappctrlv1alpha1.DeleteFailed),
+						Message: "failed termination",
+					},
+				},
+			},
+		},
+		{
+			name: "unknown type",
+			existingStatus: kappctrlv1alpha1.GenericStatus{
+				Conditions: []kappctrlv1alpha1.Condition{
+					{
+						Type:    "unknown",
+						Message: "unexpected failure",
+					},
+				},
+			},
+			expectedResponse: &corev1.PackageRepositoryStatus{
+				Reason:     corev1.PackageRepositoryStatus_STATUS_REASON_UNSPECIFIED,
+				UserReason: "unexpected failure",
+				Conditions: []*corev1.PackageRepositoryCondition{
+					{
+						Type:    "unknown",
+						Message: "unexpected failure",
+					},
+				},
+			},
+		},
+		{
+			name: "reconciliation failure, all conditions are returned",
+			existingStatus: kappctrlv1alpha1.GenericStatus{
+				Conditions: []kappctrlv1alpha1.Condition{
+					{
+						Type:    kappctrlv1alpha1.ReconcileFailed,
+						Status:  k8scorev1.ConditionTrue,
+						Message: "fetch failure",
+					},
+					{
+						Type:   kappctrlv1alpha1.Reconciling,
+						Status: k8scorev1.ConditionFalse,
+						Reason: "FetchFailed",
+					},
+				},
+			},
+			expectedResponse: &corev1.PackageRepositoryStatus{
+				Reason:     corev1.PackageRepositoryStatus_STATUS_REASON_FAILED,
+				UserReason: "fetch failure",
+				Conditions: []*corev1.PackageRepositoryCondition{
+					{
+						Type:    string(kappctrlv1alpha1.ReconcileFailed),
+						Status:  string(k8scorev1.ConditionTrue),
+						Message: "fetch failure",
+					},
+					{
+						Type:   string(kappctrlv1alpha1.Reconciling),
+						Status: string(k8scorev1.ConditionFalse),
+						Reason: "FetchFailed",
 					},
 				},
 			},
-			expectedResponse: &corev1.PackageRepositoryStatus{
-				Reason:     corev1.PackageRepositoryStatus_STATUS_REASON_PENDING,
-				UserReason: "Deleting",
-			},
 		},
 		{
-			name: "reconciliation failure",
+			name: "reconciliation failure, long error message is truncated",
 			existingStatus: kappctrlv1alpha1.GenericStatus{
 				Conditions: []kappctrlv1alpha1.Condition{
 					{
 						Type:    kappctrlv1alpha1.ReconcileFailed,
-						Message: "fetch failure",
+						Message: "see .status.usefulErrorMessage for detailed error message",
 					},
 				},
+				UsefulErrorMessage: longMessage,
 			},
 			expectedResponse: &corev1.PackageRepositoryStatus{
 				Reason:     corev1.PackageRepositoryStatus_STATUS_REASON_FAILED,
-				UserReason: "fetch failure",
+				UserReason: longMessage[:maxStatusMessageLength-len(truncatedSuffix)-1] + truncatedSuffix,
+				Conditions: []*corev1.PackageRepositoryCondition{
+					{
+						Type:    string(kappctrlv1alpha1.ReconcileFailed),
+						Message: longMessage[:maxStatusMessageLength-len(truncatedSuffix)-1] + truncatedSuffix,
+					},
+				},
 			},
 		},
 		{
-			name: "reconciliation failure, extra error message",
+			name: "reconciliation failure, next retry is backed off",
 			existingStatus: kappctrlv1alpha1.GenericStatus{
 				Conditions: []kappctrlv1alpha1.Condition{
 					{
 						Type:    kappctrlv1alpha1.ReconcileFailed,
-						Message: "see .status.usefulErrorMessage for detailed error message",
+						Message: "fetch failure",
 					},
 				},
-				UsefulErrorMessage: "fetch failed connecting to registry",
 			},
+			existingFetch: &kappctrlv1alpha1.AppStatusFetch{
+				StartedAt: metav1.Date(2023, time.March, 1, 9, 59, 0, 0, time.UTC),
+				UpdatedAt: lastAttempt,
+			},
+			failures: 3,
 			expectedResponse: &corev1.PackageRepositoryStatus{
-				Reason:     corev1.PackageRepositoryStatus_STATUS_REASON_FAILED,
-				UserReason: "fetch failed connecting to registry",
+				Reason:              corev1.PackageRepositoryStatus_STATUS_REASON_FAILED,
+				UserReason:          "fetch failure",
+				ConsecutiveFailures: 3,
+				NextRetryTime:       timestamppb.New(time.Date(2023, time.March, 1, 10, 0, 8, 0, time.UTC)),
+				Conditions: []*corev1.PackageRepositoryCondition{
+					{
+						Type:    string(kappctrlv1alpha1.ReconcileFailed),
+						Message: "fetch failure",
+					},
+				},
 			},
 		},
 		{
-			name: "deletion failure",
+			name: "reconciliation failure, next retry is capped at the sync period",
 			existingStatus: kappctrlv1alpha1.GenericStatus{
 				Conditions: []kappctrlv1alpha1.Condition{
 					{
-						Type:    kappctrlv1alpha1.DeleteFailed,
-						Message: "failed termination",
+						Type:    kappctrlv1alpha1.ReconcileFailed,
+						Message: "fetch failure",
 					},
 				},
 			},
+			existingFetch: &kappctrlv1alpha1.AppStatusFetch{
+				UpdatedAt: lastAttempt,
+			},
+			failures:   12,
+			syncPeriod: &metav1.Duration{Duration: 10 * time.Minute},
 			expectedResponse: &corev1.PackageRepositoryStatus{
-				Reason:     corev1.PackageRepositoryStatus_STATUS_REASON_FAILED,
-				UserReason: "failed termination",
+				Reason:              corev1.PackageRepositoryStatus_STATUS_REASON_FAILED,
+				UserReason:          "fetch failure",
+				ConsecutiveFailures: 12,
+				NextRetryTime:       timestamppb.New(time.Date(2023, time.March, 1, 10, 10, 0, 0, time.UTC)),
+				Conditions: []*corev1.PackageRepositoryCondition{
+					{
+						Type:    string(kappctrlv1alpha1.ReconcileFailed),
+						Message: "fetch failure",
+					},
+				},
 			},
 		},
 		{
-			name: "unknown type",
+			name: "reconciliation success, no next retry",
 			existingStatus: kappctrlv1alpha1.GenericStatus{
 				Conditions: []kappctrlv1alpha1.Condition{
 					{
-						Type:    "unknown",
-						Message: "unexpected failure",
+						Type:    kappctrlv1alpha1.ReconcileSucceeded,
+						Message: "Succeeded",
 					},
 				},
 			},
+			existingFetch: &kappctrlv1alpha1.AppStatusFetch{
+				UpdatedAt: lastAttempt,
+			},
 			expectedResponse: &corev1.PackageRepositoryStatus{
-				Reason:     corev1.PackageRepositoryStatus_STATUS_REASON_UNSPECIFIED,
-				UserReason: "unexpected failure",
+				Ready:      true,
+				Reason:     corev1.PackageRepositoryStatus_STATUS_REASON_SUCCESS,
+				UserReason: "Succeeded",
+				Conditions: []*corev1.PackageRepositoryCondition{
+					{
+						Type:    string(kappctrlv1alpha1.ReconcileSucceeded),
+						Message: "Succeeded",
+					},
+				},
 			},
 		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			var repository = factory(tc.existingStatus)
+			var repository = factory(packagingv1alpha1.PackageRepositoryStatus{
+				GenericStatus:                tc.existingStatus,
+				Fetch:                        tc.existingFetch,
+				ConsecutiveReconcileFailures: tc.failures,
+			}, tc.syncPeriod)
 
 			var unstructuredObjects []k8sruntime.Object
 			unstructuredContent, _ := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(repository)
@@ -9642,6 +15927,54 @@ func TestGetPackageRepositoryStatus(t *testing.T) {
 
 // plugin
 
+func TestProbe(t *testing.T) {
+	testCases := []struct {
+		name          string
+		resources     []*metav1.APIResourceList
+		expectedError bool
+	}{
+		{
+			name: "it succeeds if the kapp-controller resources are served",
+			resources: []*metav1.APIResourceList{
+				{
+					GroupVersion: packagingv1alpha1.SchemeGroupVersion.String(),
+					APIResources: []metav1.APIResource{{Name: pkgRepositoriesResource}, {Name: pkgInstallsResource}},
+				},
+				{
+					GroupVersion: datapackagingv1alpha1.SchemeGroupVersion.String(),
+					APIResources: []metav1.APIResource{{Name: pkgsResource}, {Name: pkgMetadatasResource}},
+				},
+			},
+		},
+		{
+			name: "it fails if the aggregated API server is not available",
+			resources: []*metav1.APIResourceList{
+				{
+					GroupVersion: packagingv1alpha1.SchemeGroupVersion.String(),
+					APIResources: []metav1.APIResource{{Name: pkgRepositoriesResource}, {Name: pkgInstallsResource}},
+				},
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			typedClient := typfake.NewSimpleClientset()
+			typedClient.Resources = tc.resources
+			s := Server{
+				localServiceAccountClientGetter: clientgetter.NewBuilder().WithTyped(typedClient).BuildFixedCluster(),
+			}
+
+			err := s.Probe(context.Background())
+
+			if got, want := err != nil, tc.expectedError; got != want {
+				t.Errorf("got error: %+v, want error: %t", err, want)
+			}
+		})
+	}
+}
+
 func TestParsePluginConfig(t *testing.T) {
 	testCases := []struct {
 		name                 string
@@ -9895,6 +16228,176 @@ kappController:
 			expectedPluginConfig: defaultPluginConfig,
 			expectedErrorStr:     "json: cannot unmarshal",
 		},
+		{
+			name: "allowServiceAccountCreation: true",
+			pluginYAMLConf: []byte(`
+kappController:
+  packages:
+    v1alpha1:
+      allowServiceAccountCreation: true
+        `),
+			expectedPluginConfig: &kappControllerPluginParsedConfig{
+				defaultUpgradePolicy:        defaultPluginConfig.defaultUpgradePolicy,
+				allowServiceAccountCreation: true,
+				serviceAccountRoleTemplate:  serviceAccountRoleTemplateRestricted,
+			},
+			expectedErrorStr: "",
+		},
+		{
+			name: "serviceAccountRoleTemplate: cluster-admin",
+			pluginYAMLConf: []byte(`
+kappController:
+  packages:
+    v1alpha1:
+      allowServiceAccountCreation: true
+      serviceAccountRoleTemplate: cluster-admin
+        `),
+			expectedPluginConfig: &kappControllerPluginParsedConfig{
+				defaultUpgradePolicy:        defaultPluginConfig.defaultUpgradePolicy,
+				allowServiceAccountCreation: true,
+				serviceAccountRoleTemplate:  serviceAccountRoleTemplateClusterAdmin,
+			},
+			expectedErrorStr: "",
+		},
+		{
+			name: "allowImagePullSecretCreation: true",
+			pluginYAMLConf: []byte(`
+kappController:
+  packages:
+    v1alpha1:
+      allowImagePullSecretCreation: true
+        `),
+			expectedPluginConfig: &kappControllerPluginParsedConfig{
+				defaultUpgradePolicy:         defaultPluginConfig.defaultUpgradePolicy,
+				allowImagePullSecretCreation: true,
+			},
+			expectedErrorStr: "",
+		},
+		{
+			name: "imagePullSecretImport with a name",
+			pluginYAMLConf: []byte(`
+kappController:
+  packages:
+    v1alpha1:
+      imagePullSecretImport:
+        name: registry-creds
+        namespace: registries
+        `),
+			expectedPluginConfig: &kappControllerPluginParsedConfig{
+				defaultUpgradePolicy:  defaultPluginConfig.defaultUpgradePolicy,
+				imagePullSecretImport: imagePullSecretImportOptions{Name: "registry-creds", Namespace: "registries"},
+			},
+			expectedErrorStr: "",
+		},
+		{
+			name: "imagePullSecretImport without a name",
+			pluginYAMLConf: []byte(`
+kappController:
+  packages:
+    v1alpha1:
+      imagePullSecretImport:
+        namespace: registries
+        `),
+			expectedPluginConfig: defaultPluginConfig,
+			expectedErrorStr:     "invalid imagePullSecretImport",
+		},
+		{
+			name: "allowDefaultNamespaceOverride: true",
+			pluginYAMLConf: []byte(`
+kappController:
+  packages:
+    v1alpha1:
+      allowDefaultNamespaceOverride: true
+        `),
+			expectedPluginConfig: &kappControllerPluginParsedConfig{
+				defaultUpgradePolicy:          defaultPluginConfig.defaultUpgradePolicy,
+				allowDefaultNamespaceOverride: true,
+			},
+			expectedErrorStr: "",
+		},
+		{
+			name: "reconcileHistoryLimit: 5",
+			pluginYAMLConf: []byte(`
+kappController:
+  packages:
+    v1alpha1:
+      reconcileHistoryLimit: 5
+        `),
+			expectedPluginConfig: &kappControllerPluginParsedConfig{
+				defaultUpgradePolicy:  defaultPluginConfig.defaultUpgradePolicy,
+				reconcileHistoryLimit: 5,
+			},
+			expectedErrorStr: "",
+		},
+		{
+			name: "advancedMode: true",
+			pluginYAMLConf: []byte(`
+kappController:
+  packages:
+    v1alpha1:
+      advancedMode: true
+        `),
+			expectedPluginConfig: &kappControllerPluginParsedConfig{
+				defaultUpgradePolicy: defaultPluginConfig.defaultUpgradePolicy,
+				advancedMode:         true,
+			},
+			expectedErrorStr: "",
+		},
+		{
+			name: "excludeGlobalPackagesNamespaces: [tenant-a, tenant-b]",
+			pluginYAMLConf: []byte(`
+kappController:
+  packages:
+    v1alpha1:
+      excludeGlobalPackagesNamespaces:
+        - tenant-a
+        - tenant-b
+        `),
+			expectedPluginConfig: &kappControllerPluginParsedConfig{
+				defaultUpgradePolicy:            defaultPluginConfig.defaultUpgradePolicy,
+				excludeGlobalPackagesNamespaces: []string{"tenant-a", "tenant-b"},
+			},
+			expectedErrorStr: "",
+		},
+		{
+			name: "watchPackageRepositoryRefs: true",
+			pluginYAMLConf: []byte(`
+kappController:
+  packages:
+    v1alpha1:
+      watchPackageRepositoryRefs: true
+        `),
+			expectedPluginConfig: &kappControllerPluginParsedConfig{
+				defaultUpgradePolicy:       defaultPluginConfig.defaultUpgradePolicy,
+				watchPackageRepositoryRefs: true,
+			},
+			expectedErrorStr: "",
+		},
+		{
+			name: "readmeFromBundle: true",
+			pluginYAMLConf: []byte(`
+kappController:
+  packages:
+    v1alpha1:
+      readmeFromBundle: true
+        `),
+			expectedPluginConfig: &kappControllerPluginParsedConfig{
+				defaultUpgradePolicy: defaultPluginConfig.defaultUpgradePolicy,
+				readmeFromBundle:     true,
+			},
+			expectedErrorStr: "",
+		},
+		{
+			name: "invalid serviceAccountRoleTemplate",
+			pluginYAMLConf: []byte(`
+kappController:
+  packages:
+    v1alpha1:
+      serviceAccountRoleTemplate: foo
+      `),
+			expectedPluginConfig: defaultPluginConfig,
+			expectedErrorStr:     "unsupported service account role template: [foo]",
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -9933,6 +16436,65 @@ kappController:
 	}
 }
 
+func TestReloadPluginConfig(t *testing.T) {
+	testCases := []struct {
+		name                   string
+		pluginJSONConf         string
+		expectedErrorStr       string
+		expectedTimeoutSeconds int32
+		expectedUpgradePolicy  string
+	}{
+		{
+			name:                   "it replaces the config in effect",
+			pluginJSONConf:         `{"core": {"packages": {"v1alpha1": {"timeoutSeconds": 60}}}, "kappController": {"packages": {"v1alpha1": {"defaultUpgradePolicy": "minor"}}}}`,
+			expectedTimeoutSeconds: 60,
+			expectedUpgradePolicy:  "minor",
+		},
+		{
+			name:                   "it keeps the config in effect when the new one is invalid",
+			pluginJSONConf:         `{"kappController": {"packages": {"v1alpha1": {"defaultUpgradePolicy": "foo"}}}}`,
+			expectedErrorStr:       "unsupported upgrade policy",
+			expectedTimeoutSeconds: fallbackTimeoutSeconds,
+			expectedUpgradePolicy:  "none",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			filename := filepath.Join(t.TempDir(), "plugin_json_conf")
+			if err := os.WriteFile(filename, []byte(tc.pluginJSONConf), 0600); err != nil {
+				t.Fatalf("%+v", err)
+			}
+			s := Server{pluginConfig: defaultPluginConfig}
+
+			effectiveConfig, err := s.reloadPluginConfig(filename)
+
+			if tc.expectedErrorStr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedErrorStr) {
+					t.Fatalf("err got %v, want to find %q", err, tc.expectedErrorStr)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("%+v", err)
+				}
+				pluginConfig, ok := effectiveConfig.(*kappControllerPluginConfig)
+				if !ok {
+					t.Fatalf("got: %T, want: *kappControllerPluginConfig", effectiveConfig)
+				}
+				if got, want := pluginConfig.Core.Packages.V1alpha1.TimeoutSeconds, tc.expectedTimeoutSeconds; got != want {
+					t.Errorf("got: %d, want: %d", got, want)
+				}
+			}
+			if got, want := s.config().timeoutSeconds, tc.expectedTimeoutSeconds; got != want {
+				t.Errorf("got: %d, want: %d", got, want)
+			}
+			if got, want := s.config().defaultUpgradePolicy.String(), tc.expectedUpgradePolicy; got != want {
+				t.Errorf("got: %q, want: %q", got, want)
+			}
+		})
+	}
+}
+
 func TestGetPackageRepositoryPermissions(t *testing.T) {
 
 	testCases := []struct {
@@ -10067,6 +16629,46 @@ func TestGetPackageRepositoryPermissions(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "returns the same permissions when the namespace is the global packaging namespace",
+			request: &corev1.GetPackageRepositoryPermissionsRequest{
+				Context: &corev1.Context{Cluster: defaultContext.Cluster, Namespace: fallbackGlobalPackagingNamespace},
+			},
+			reactors: []*ClientReaction{
+				{
+					verb:     "create",
+					resource: "selfsubjectaccessreviews",
+					reaction: func(action k8stesting.Action) (handled bool, ret k8sruntime.Object, err error) {
+						createAction := action.(k8stesting.CreateActionImpl)
+						accessReview := createAction.Object.(*authorizationv1.SelfSubjectAccessReview)
+						return true, &authorizationv1.SelfSubjectAccessReview{Status: authorizationv1.SubjectAccessReviewStatus{Allowed: accessReview.Spec.ResourceAttributes.Verb == "get"}}, nil
+					},
+				},
+			},
+			expectedResponse: &corev1.GetPackageRepositoryPermissionsResponse{
+				Permissions: []*corev1.PackageRepositoriesPermissions{
+					{
+						Plugin: GetPluginDetail(),
+						Global: map[string]bool{
+							"create": false,
+							"delete": false,
+							"get":    true,
+							"list":   false,
+							"update": false,
+							"watch":  false,
+						},
+						Namespace: map[string]bool{
+							"create": false,
+							"delete": false,
+							"get":    true,
+							"list":   false,
+							"update": false,
+							"watch":  false,
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -4,28 +4,42 @@
 package main
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
 
 	k8scorev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/rest"
 
 	"github.com/Masterminds/semver/v3"
+	"github.com/bufbuild/connect-go"
 	kappctrlv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/kappctrl/v1alpha1"
 	packagingv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/packaging/v1alpha1"
 	datapackagingv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apiserver/apis/datapackaging/v1alpha1"
+	kappctrlpackageinstall "github.com/vmware-tanzu/carvel-kapp-controller/pkg/packageinstall"
 	kappcmdcore "github.com/vmware-tanzu/carvel-kapp/pkg/kapp/cmd/core"
+	ctlconf "github.com/vmware-tanzu/carvel-kapp/pkg/kapp/config"
+	ctldiff "github.com/vmware-tanzu/carvel-kapp/pkg/kapp/diff"
+	ctlres "github.com/vmware-tanzu/carvel-kapp/pkg/kapp/resources"
 	vendirversions "github.com/vmware-tanzu/carvel-vendir/pkg/vendir/versions/v1alpha1"
 	corev1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
 	kappcorev1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/plugins/kapp_controller/packages/v1alpha1"
 	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/pkgutils"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/resources"
 	"github.com/vmware-tanzu/kubeapps/pkg/kube"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 )
 
 const REPO_REF_ANNOTATION = "packaging.carvel.dev/package-repository-ref"
@@ -60,6 +74,73 @@ func getPkgVersionsMap(packages []*datapackagingv1alpha1.Package) (map[string][]
 	return pkgVersionsMap, nil
 }
 
+// changelogsFromPkgs returns the changelog of each Package CR, that is, of each
+// version of a package, made of its release notes.
+func changelogsFromPkgs(pkgs []*datapackagingv1alpha1.Package) []*corev1.PackageVersionChangelog {
+	changelogs := make([]*corev1.PackageVersionChangelog, len(pkgs))
+	for i, pkg := range pkgs {
+		changelogs[i] = &corev1.PackageVersionChangelog{
+			PkgVersion:   pkg.Spec.Version,
+			ReleaseNotes: pkg.Spec.ReleaseNotes,
+		}
+	}
+	return changelogs
+}
+
+// pkgVersionDeprecation returns the deprecation of the version of a package,
+// declared by the annotations of its Package CR, or nil if the version is
+// neither deprecated nor has an end of life. An invalid end of life date is
+// ignored rather than failing the request.
+func pkgVersionDeprecation(pkg *datapackagingv1alpha1.Package, now time.Time) *corev1.PackageVersionDeprecation {
+	message, deprecated := pkg.Annotations[annotationDeprecatedKey]
+	if strings.EqualFold(message, "false") {
+		deprecated = false
+	}
+	if strings.EqualFold(message, "true") {
+		message = ""
+	}
+
+	endOfLife := strings.TrimSpace(pkg.Annotations[annotationEndOfLifeKey])
+	var endOfLifeDate time.Time
+	if endOfLife != "" {
+		var err error
+		if endOfLifeDate, err = time.Parse(time.DateOnly, endOfLife); err != nil {
+			if endOfLifeDate, err = time.Parse(time.RFC3339, endOfLife); err != nil {
+				endOfLife = ""
+			}
+		}
+	}
+
+	if !deprecated && endOfLife == "" {
+		return nil
+	}
+	deprecation := &corev1.PackageVersionDeprecation{Message: strings.TrimSpace(message)}
+	if endOfLife != "" {
+		deprecation.EndOfLife = endOfLife
+		deprecation.EndOfLifeReached = !now.Before(endOfLifeDate)
+	}
+	return deprecation
+}
+
+// deprecationWarnings returns the warnings about an installed version of a
+// package with the given deprecation.
+func deprecationWarnings(version string, deprecation *corev1.PackageVersionDeprecation) []string {
+	if deprecation == nil {
+		return nil
+	}
+	warning := fmt.Sprintf("The installed version %s is deprecated", version)
+	if deprecation.GetMessage() != "" {
+		warning = fmt.Sprintf("%s: %s", warning, deprecation.GetMessage())
+	}
+	warnings := []string{warning}
+	if deprecation.GetEndOfLifeReached() {
+		warnings = append(warnings, fmt.Sprintf("The installed version %s reached its end of life on %s", version, deprecation.GetEndOfLife()))
+	} else if deprecation.GetEndOfLife() != "" {
+		warnings = append(warnings, fmt.Sprintf("The installed version %s reaches its end of life on %s", version, deprecation.GetEndOfLife()))
+	}
+	return warnings
+}
+
 // latestMatchingVersion returns the latest version of a package that matches the given version constraint.
 func latestMatchingVersion(versions []pkgSemver, constraints string) (*semver.Version, error) {
 	// constraints can be a single one (e.g., ">1.2.3") or a range (e.g., ">1.0.0 <2.0.0 || 3.0.0")
@@ -78,6 +159,23 @@ func latestMatchingVersion(versions []pkgSemver, constraints string) (*semver.Ve
 	return nil, nil
 }
 
+// versionsMatchingConstraint returns the versions of a package that match the given version constraint,
+// in the same order.
+func versionsMatchingConstraint(versions []pkgSemver, constraints string) ([]pkgSemver, error) {
+	constraint, err := semver.NewConstraint(constraints)
+	if err != nil {
+		return nil, fmt.Errorf("the version in the constraint ('%s') is not semver-compatible: %v", constraints, err)
+	}
+
+	matching := []pkgSemver{}
+	for _, v := range versions {
+		if constraint.Check(v.version) {
+			matching = append(matching, v)
+		}
+	}
+	return matching, nil
+}
+
 // statusReasonForKappStatus returns the reason for a given status
 func statusReasonForKappStatus(status kappctrlv1alpha1.ConditionType) corev1.InstalledPackageStatus_StatusReason {
 	switch status {
@@ -198,13 +296,148 @@ func buildPostInstallationNotes(app *kappctrlv1alpha1.App) string {
 	return postInstallNotesSB.String()
 }
 
+// The phases of the reconciliation of a kapp-controller App
+const (
+	appPhaseFetch    = "fetch"
+	appPhaseTemplate = "template"
+	appPhaseDeploy   = "deploy"
+)
+
+// buildInstalledPackageOperationStatus reports the progress of the fetch, template and deploy
+// phases of the App reconciling a package install. While kapp-controller has not created the App
+// yet, every phase is pending, unless the timeout since the creation of the package install expired.
+func buildInstalledPackageOperationStatus(pkgInstall *packagingv1alpha1.PackageInstall, app *kappctrlv1alpha1.App, timeout time.Duration, now time.Time) *corev1.GetInstalledPackageOperationStatusResponse {
+	pendingPhases := []*corev1.InstalledPackageOperationPhase{
+		{Name: appPhaseFetch, State: corev1.InstalledPackageOperationPhase_PHASE_STATE_PENDING},
+		{Name: appPhaseTemplate, State: corev1.InstalledPackageOperationPhase_PHASE_STATE_PENDING},
+		{Name: appPhaseDeploy, State: corev1.InstalledPackageOperationPhase_PHASE_STATE_PENDING},
+	}
+	if app == nil {
+		status := &corev1.InstalledPackageStatus{
+			Reason:     corev1.InstalledPackageStatus_STATUS_REASON_PENDING,
+			UserReason: "Waiting for the App to be created",
+		}
+		if now.Sub(pkgInstall.CreationTimestamp.Time) > timeout {
+			status = &corev1.InstalledPackageStatus{
+				Reason:     corev1.InstalledPackageStatus_STATUS_REASON_FAILED,
+				UserReason: fmt.Sprintf("Timeout exceeded (%v s) waiting for the App to be created", timeout.Seconds()),
+			}
+		}
+		return &corev1.GetInstalledPackageOperationStatusResponse{Status: status, Phases: pendingPhases}
+	}
+	// the status refers to a previous spec until kapp-controller observes the current one
+	if app.Status.ObservedGeneration < app.Generation {
+		return &corev1.GetInstalledPackageOperationStatusResponse{
+			Status: &corev1.InstalledPackageStatus{
+				Reason:     corev1.InstalledPackageStatus_STATUS_REASON_PENDING,
+				UserReason: simpleUserReasonForKappStatus(kappctrlv1alpha1.Reconciling),
+			},
+			Phases: pendingPhases,
+		}
+	}
+
+	reconciling := false
+	for _, condition := range app.Status.Conditions {
+		if condition.Type == kappctrlv1alpha1.Reconciling && condition.Status == k8scorev1.ConditionTrue {
+			reconciling = true
+		}
+	}
+	// a phase is running when the previous one succeeded and the App is still reconciling
+	nextState := func(previous corev1.InstalledPackageOperationPhase_PhaseState) corev1.InstalledPackageOperationPhase_PhaseState {
+		if reconciling && previous == corev1.InstalledPackageOperationPhase_PHASE_STATE_SUCCEEDED {
+			return corev1.InstalledPackageOperationPhase_PHASE_STATE_IN_PROGRESS
+		}
+		return corev1.InstalledPackageOperationPhase_PHASE_STATE_PENDING
+	}
+
+	// the template and deploy statuses older than the last fetch belong to a previous reconciliation
+	fetchStatus, templateStatus, deployStatus := app.Status.Fetch, app.Status.Template, app.Status.Deploy
+	if fetchStatus != nil && templateStatus != nil && templateStatus.UpdatedAt.Before(&fetchStatus.StartedAt) {
+		templateStatus = nil
+	}
+	if fetchStatus != nil && deployStatus != nil && deployStatus.StartedAt.Before(&fetchStatus.StartedAt) {
+		deployStatus = nil
+	}
+
+	fetch := &corev1.InstalledPackageOperationPhase{Name: appPhaseFetch}
+	switch {
+	case fetchStatus == nil:
+		fetch.State = nextState(corev1.InstalledPackageOperationPhase_PHASE_STATE_SUCCEEDED)
+	case fetchStatus.ExitCode != 0 || fetchStatus.Error != "":
+		fetch.State = corev1.InstalledPackageOperationPhase_PHASE_STATE_FAILED
+		fetch.Message = appPhaseErrorMessage(fetchStatus.Error, fetchStatus.Stderr)
+	case templateStatus == nil && reconciling:
+		fetch.State = corev1.InstalledPackageOperationPhase_PHASE_STATE_IN_PROGRESS
+	default:
+		fetch.State = corev1.InstalledPackageOperationPhase_PHASE_STATE_SUCCEEDED
+	}
+
+	template := &corev1.InstalledPackageOperationPhase{Name: appPhaseTemplate}
+	switch {
+	case templateStatus == nil:
+		template.State = nextState(fetch.State)
+	case templateStatus.ExitCode != 0 || templateStatus.Error != "":
+		template.State = corev1.InstalledPackageOperationPhase_PHASE_STATE_FAILED
+		template.Message = appPhaseErrorMessage(templateStatus.Error, templateStatus.Stderr)
+	default:
+		template.State = corev1.InstalledPackageOperationPhase_PHASE_STATE_SUCCEEDED
+	}
+
+	deploy := &corev1.InstalledPackageOperationPhase{Name: appPhaseDeploy}
+	switch {
+	case deployStatus == nil:
+		deploy.State = nextState(template.State)
+	case deployStatus.ExitCode != 0 || deployStatus.Error != "":
+		deploy.State = corev1.InstalledPackageOperationPhase_PHASE_STATE_FAILED
+		deploy.Message = appPhaseErrorMessage(deployStatus.Error, deployStatus.Stderr)
+	case !deployStatus.Finished:
+		deploy.State = corev1.InstalledPackageOperationPhase_PHASE_STATE_IN_PROGRESS
+	default:
+		deploy.State = corev1.InstalledPackageOperationPhase_PHASE_STATE_SUCCEEDED
+	}
+
+	phases := []*corev1.InstalledPackageOperationPhase{fetch, template, deploy}
+	status := &corev1.InstalledPackageStatus{
+		Reason:     corev1.InstalledPackageStatus_STATUS_REASON_PENDING,
+		UserReason: simpleUserReasonForKappStatus(kappctrlv1alpha1.Reconciling),
+	}
+	if deploy.State == corev1.InstalledPackageOperationPhase_PHASE_STATE_SUCCEEDED {
+		status = &corev1.InstalledPackageStatus{
+			Ready:      true,
+			Reason:     corev1.InstalledPackageStatus_STATUS_REASON_INSTALLED,
+			UserReason: simpleUserReasonForKappStatus(kappctrlv1alpha1.ReconcileSucceeded),
+		}
+	}
+	for _, phase := range phases {
+		if phase.State == corev1.InstalledPackageOperationPhase_PHASE_STATE_FAILED {
+			status = &corev1.InstalledPackageStatus{
+				Reason:     corev1.InstalledPackageStatus_STATUS_REASON_FAILED,
+				UserReason: fmt.Sprintf("The %s phase failed: %s", phase.Name, phase.Message),
+			}
+			break
+		}
+	}
+	return &corev1.GetInstalledPackageOperationStatusResponse{Status: status, Phases: phases}
+}
+
+// appPhaseErrorMessage returns the error of a failed App phase, falling back to its stderr
+func appPhaseErrorMessage(err, stderr string) string {
+	if err != "" {
+		return err
+	}
+	return stderr
+}
+
 type (
 	kappControllerPluginConfig struct {
 		Core struct {
 			Packages struct {
 				V1alpha1 struct {
-					VersionsInSummary pkgutils.VersionsInSummary
-					TimeoutSeconds    int32 `json:"timeoutSeconds"`
+					VersionsInSummary        pkgutils.VersionsInSummary                `json:"versionsInSummary"`
+					TimeoutSeconds           int32                                     `json:"timeoutSeconds"`
+					NamespaceCreation        resources.NamespaceCreationOptions        `json:"namespaceCreation"`
+					CapacityCheck            resources.CapacityCheckMode               `json:"capacityCheck"`
+					InstalledPackageMetadata resources.InstalledPackageMetadataOptions `json:"installedPackageMetadata"`
 				} `json:"v1alpha1"`
 			} `json:"packages"`
 		} `json:"core"`
@@ -212,10 +445,21 @@ type (
 		KappController struct {
 			Packages struct {
 				V1alpha1 struct {
-					DefaultUpgradePolicy               string   `json:"defaultUpgradePolicy"`
-					DefaultPrereleasesVersionSelection []string `json:"defaultPrereleasesVersionSelection"`
-					DefaultAllowDowngrades             bool     `json:"defaultAllowDowngrades"`
-					GlobalPackagingNamespace           string   `json:"globalPackagingNamespace"`
+					DefaultUpgradePolicy                string                       `json:"defaultUpgradePolicy"`
+					DefaultPrereleasesVersionSelection  []string                     `json:"defaultPrereleasesVersionSelection"`
+					DefaultAllowDowngrades              bool                         `json:"defaultAllowDowngrades"`
+					GlobalPackagingNamespace            string                       `json:"globalPackagingNamespace"`
+					AllowServiceAccountCreation         bool                         `json:"allowServiceAccountCreation"`
+					ServiceAccountRoleTemplate          string                       `json:"serviceAccountRoleTemplate"`
+					AllowImagePullSecretCreation        bool                         `json:"allowImagePullSecretCreation"`
+					ImagePullSecretImport               imagePullSecretImportOptions `json:"imagePullSecretImport"`
+					ReadmeFromBundle                    bool                         `json:"readmeFromBundle"`
+					AllowDefaultNamespaceOverride       bool                         `json:"allowDefaultNamespaceOverride"`
+					ReconcileHistoryLimit               int                          `json:"reconcileHistoryLimit"`
+					AdvancedMode                        bool                         `json:"advancedMode"`
+					ExcludeGlobalPackagesNamespaces     []string                     `json:"excludeGlobalPackagesNamespaces"`
+					WatchPackageRepositoryRefs          bool                         `json:"watchPackageRepositoryRefs"`
+					RepositoryConnectionAllowedNetworks []string                     `json:"repositoryConnectionAllowedNetworks"`
 				} `json:"v1alpha1"`
 			} `json:"packages"`
 		} `json:"kappController"`
@@ -227,9 +471,68 @@ type (
 		defaultPrereleasesVersionSelection []string
 		defaultAllowDowngrades             bool
 		globalPackagingNamespace           string
+		allowServiceAccountCreation        bool
+		serviceAccountRoleTemplate         serviceAccountRoleTemplate
+		allowImagePullSecretCreation       bool
+		imagePullSecretImport              imagePullSecretImportOptions
+		readmeFromBundle                   bool
+		allowDefaultNamespaceOverride      bool
+		reconcileHistoryLimit              int
+		advancedMode                       bool
+		excludeGlobalPackagesNamespaces    []string
+		watchPackageRepositoryRefs         bool
+		// repositoryConnectionAllowedNetworks are the networks which the
+		// connection tests and the host key scans of the package
+		// repositories can reach despite being blocked by default, such as
+		// the loopback addresses.
+		repositoryConnectionAllowedNetworks []string
+		namespaceCreation                   resources.NamespaceCreationOptions
+		capacityCheck                       resources.CapacityCheckMode
+		installedPackageMetadata            resources.InstalledPackageMetadataOptions
 	}
 )
 
+// excludesGlobalPackages returns true if the namespace is configured to only
+// see its own packages, excluding the ones of the global packaging namespace.
+func (c *kappControllerPluginParsedConfig) excludesGlobalPackages(namespace string) bool {
+	if namespace == c.globalPackagingNamespace {
+		return false
+	}
+	for _, ns := range c.excludeGlobalPackagesNamespaces {
+		if ns == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// toPluginConfig returns the parsed configuration in the format of the plugin
+// configuration file.
+func (c *kappControllerPluginParsedConfig) toPluginConfig() *kappControllerPluginConfig {
+	pluginConfig := &kappControllerPluginConfig{}
+	pluginConfig.Core.Packages.V1alpha1.VersionsInSummary = c.versionsInSummary
+	pluginConfig.Core.Packages.V1alpha1.TimeoutSeconds = c.timeoutSeconds
+	pluginConfig.Core.Packages.V1alpha1.NamespaceCreation = c.namespaceCreation
+	pluginConfig.Core.Packages.V1alpha1.CapacityCheck = c.capacityCheck
+	pluginConfig.Core.Packages.V1alpha1.InstalledPackageMetadata = c.installedPackageMetadata
+	pluginConfig.KappController.Packages.V1alpha1.DefaultUpgradePolicy = c.defaultUpgradePolicy.String()
+	pluginConfig.KappController.Packages.V1alpha1.DefaultPrereleasesVersionSelection = c.defaultPrereleasesVersionSelection
+	pluginConfig.KappController.Packages.V1alpha1.DefaultAllowDowngrades = c.defaultAllowDowngrades
+	pluginConfig.KappController.Packages.V1alpha1.GlobalPackagingNamespace = c.globalPackagingNamespace
+	pluginConfig.KappController.Packages.V1alpha1.AllowServiceAccountCreation = c.allowServiceAccountCreation
+	pluginConfig.KappController.Packages.V1alpha1.ServiceAccountRoleTemplate = c.serviceAccountRoleTemplate.String()
+	pluginConfig.KappController.Packages.V1alpha1.AllowImagePullSecretCreation = c.allowImagePullSecretCreation
+	pluginConfig.KappController.Packages.V1alpha1.ImagePullSecretImport = c.imagePullSecretImport
+	pluginConfig.KappController.Packages.V1alpha1.ReadmeFromBundle = c.readmeFromBundle
+	pluginConfig.KappController.Packages.V1alpha1.AllowDefaultNamespaceOverride = c.allowDefaultNamespaceOverride
+	pluginConfig.KappController.Packages.V1alpha1.ReconcileHistoryLimit = c.reconcileHistoryLimit
+	pluginConfig.KappController.Packages.V1alpha1.AdvancedMode = c.advancedMode
+	pluginConfig.KappController.Packages.V1alpha1.ExcludeGlobalPackagesNamespaces = c.excludeGlobalPackagesNamespaces
+	pluginConfig.KappController.Packages.V1alpha1.WatchPackageRepositoryRefs = c.watchPackageRepositoryRefs
+	pluginConfig.KappController.Packages.V1alpha1.RepositoryConnectionAllowedNetworks = c.repositoryConnectionAllowedNetworks
+	return pluginConfig
+}
+
 var defaultPluginConfig = &kappControllerPluginParsedConfig{
 	versionsInSummary:                  pkgutils.GetDefaultVersionsInSummary(),
 	timeoutSeconds:                     fallbackTimeoutSeconds,
@@ -237,6 +540,59 @@ var defaultPluginConfig = &kappControllerPluginParsedConfig{
 	defaultPrereleasesVersionSelection: fallbackDefaultPrereleasesVersionSelection(),
 	defaultAllowDowngrades:             fallbackDefaultAllowDowngrades,
 	globalPackagingNamespace:           fallbackGlobalPackagingNamespace,
+	allowServiceAccountCreation:        fallbackAllowServiceAccountCreation,
+	serviceAccountRoleTemplate:         fallbackServiceAccountRoleTemplate,
+	allowImagePullSecretCreation:       fallbackAllowImagePullSecretCreation,
+	readmeFromBundle:                   fallbackReadmeFromBundle,
+	allowDefaultNamespaceOverride:      fallbackAllowDefaultNamespaceOverride,
+	reconcileHistoryLimit:              fallbackReconcileHistoryLimit,
+	advancedMode:                       fallbackAdvancedMode,
+}
+
+// imagePullSecretImportOptions reference the Secret with the registry
+// credentials configured by the cluster administrator, exported with a
+// secretgen-controller SecretExport, which is imported with a SecretImport as
+// the image pull Secret of the installed packages requesting one.
+type imagePullSecretImportOptions struct {
+	// Name is the name of the exported Secret, which is also the name of the
+	// imported one.
+	Name string `json:"name,omitempty"`
+	// Namespace is the namespace of the exported Secret, defaulting to the
+	// global packaging namespace.
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// serviceAccountRoleTemplate determines the permissions granted to the
+// ServiceAccounts created by the plugin for reconciling a PackageInstall.
+type serviceAccountRoleTemplate int
+
+const (
+	// serviceAccountRoleTemplateRestricted grants full access only to the
+	// namespace-scoped resources in the target namespace.
+	serviceAccountRoleTemplateRestricted serviceAccountRoleTemplate = iota
+	// serviceAccountRoleTemplateClusterAdmin binds the cluster-admin ClusterRole
+	// within the target namespace.
+	serviceAccountRoleTemplateClusterAdmin
+)
+
+func serviceAccountRoleTemplateFromString(templateStr string) (serviceAccountRoleTemplate, error) {
+	switch templateStr {
+	case "", "restricted":
+		return serviceAccountRoleTemplateRestricted, nil
+	case "cluster-admin":
+		return serviceAccountRoleTemplateClusterAdmin, nil
+	default:
+		return serviceAccountRoleTemplateRestricted, fmt.Errorf("unsupported service account role template: [%s]", templateStr)
+	}
+}
+
+func (t serviceAccountRoleTemplate) String() string {
+	switch t {
+	case serviceAccountRoleTemplateClusterAdmin:
+		return "cluster-admin"
+	default:
+		return "restricted"
+	}
 }
 
 // prereleasesVersionSelection returns the proper value to the prereleases used in kappctrl from the selection
@@ -292,12 +648,14 @@ func FilterMetadatas(metadatas []*datapackagingv1alpha1.PackageMetadata, filterO
 		skipQueryFilter := filterOptions.Query == ""
 		skipCategoriesFilter := len(filterOptions.Categories) == 0
 		skipRepositoriesFilter := len(filterOptions.Repositories) == 0
+		skipProvidersFilter := len(filterOptions.Providers) == 0
 
 		for _, metadata := range metadatas {
 			matchesQuery := skipQueryFilter || testMetadataMatchesQuery(metadata, filterOptions.Query)
 			matchesCategories := skipCategoriesFilter || testMetadataMatchesCategories(metadata, filterOptions.Categories)
 			matchesRepos := skipRepositoriesFilter || testMetadataMatchesRepos(metadata, filterOptions.Repositories)
-			if matchesRepos && matchesQuery && matchesCategories {
+			matchesProviders := skipProvidersFilter || testMetadataMatchesProviders(metadata, filterOptions.Providers)
+			if matchesRepos && matchesQuery && matchesCategories && matchesProviders {
 				filteredMetadatas = append(filteredMetadatas, metadata)
 			}
 		}
@@ -327,18 +685,92 @@ func testMetadataMatchesCategories(metadata *datapackagingv1alpha1.PackageMetada
 	return intersection
 }
 
+// testMetadataMatchesProviders returns true if the metadata is provided by
+// one of the given providers.
+func testMetadataMatchesProviders(metadata *datapackagingv1alpha1.PackageMetadata, providers []string) bool {
+	for _, provider := range providers {
+		if metadata.Spec.ProviderName == provider {
+			return true
+		}
+	}
+	return false
+}
+
 // testMetadataMatchesRepos returns true if the metadata matches the provided repositories.
+// A repository given as "namespace/name" only matches the packages of the
+// repository in that namespace, while a bare name matches the repositories
+// with that name in any namespace.
 func testMetadataMatchesRepos(metadata *datapackagingv1alpha1.PackageMetadata, repositories []string) bool {
-	metadataRepoName := getRepoNameFromAnnotation(metadata.Annotations[REPO_REF_ANNOTATION])
+	metadataRepoRef := metadata.Annotations[REPO_REF_ANNOTATION]
+	metadataRepoName := getRepoNameFromAnnotation(metadataRepoRef)
 	// if the package is from one of the given repositories, it matches
 	for _, repo := range repositories {
-		if metadataRepoName == repo {
+		if strings.Contains(repo, "/") {
+			if metadataRepoRef == repo {
+				return true
+			}
+		} else if metadataRepoName == repo {
 			return true
 		}
 	}
 	return false
 }
 
+// pkgRepositoryPolicies holds the policies of the package repositories,
+// keyed by "namespace/name" as in the REPO_REF_ANNOTATION of the packages.
+type pkgRepositoryPolicies map[string]*pkgutils.PackagePolicy
+
+// allows returns true if the package metadata is made available by the policy
+// of the repository it comes from.
+func (p pkgRepositoryPolicies) allows(metadata *datapackagingv1alpha1.PackageMetadata) bool {
+	return p[metadata.Annotations[REPO_REF_ANNOTATION]].Allows(metadata.Name)
+}
+
+// hash returns a digest of the policies, which is the same for any set of
+// policies restricting the same packages.
+func (p pkgRepositoryPolicies) hash() string {
+	repoRefs := make([]string, 0, len(p))
+	for repoRef := range p {
+		repoRefs = append(repoRefs, repoRef)
+	}
+	sort.Strings(repoRefs)
+	h := sha256.New()
+	for _, repoRef := range repoRefs {
+		fmt.Fprintf(h, "%q/%q/%q\n", repoRef, p[repoRef].AllowedPackages, p[repoRef].DeniedPackages)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// FilterMetadatasByPolicies returns a slice where the package metadatas not
+// made available by the policy of their repository have been removed.
+func FilterMetadatasByPolicies(metadatas []*datapackagingv1alpha1.PackageMetadata, policies pkgRepositoryPolicies) []*datapackagingv1alpha1.PackageMetadata {
+	if len(policies) == 0 {
+		return metadatas
+	}
+	filteredMetadatas := []*datapackagingv1alpha1.PackageMetadata{}
+	for _, metadata := range metadatas {
+		if policies.allows(metadata) {
+			filteredMetadatas = append(filteredMetadatas, metadata)
+		}
+	}
+	return filteredMetadatas
+}
+
+// FilterGlobalMetadatas returns a slice where the package metadatas provided
+// by the package repositories of the global packaging namespace have been
+// removed. The packages not referencing their repository are kept, as their
+// origin is unknown.
+func FilterGlobalMetadatas(metadatas []*datapackagingv1alpha1.PackageMetadata, globalPackagingNamespace string) []*datapackagingv1alpha1.PackageMetadata {
+	filteredMetadatas := []*datapackagingv1alpha1.PackageMetadata{}
+	for _, metadata := range metadatas {
+		repoNamespace, _, found := strings.Cut(metadata.Annotations[REPO_REF_ANNOTATION], "/")
+		if !found || repoNamespace != globalPackagingNamespace {
+			filteredMetadatas = append(filteredMetadatas, metadata)
+		}
+	}
+	return filteredMetadatas
+}
+
 //
 //		Utils for repositories
 
@@ -578,3 +1010,394 @@ func setOwnerReference(pkgSecret *k8scorev1.Secret, pkgRepository *packagingv1al
 			}),
 	}
 }
+
+// pkgInstallOwnerReference returns an owner reference pointing to the given PackageInstall
+func pkgInstallOwnerReference(pkgInstall *packagingv1alpha1.PackageInstall) metav1.OwnerReference {
+	return *metav1.NewControllerRef(
+		pkgInstall,
+		schema.GroupVersionKind{
+			Group:   packagingv1alpha1.SchemeGroupVersion.Group,
+			Version: packagingv1alpha1.SchemeGroupVersion.Version,
+			Kind:    pkgInstallResource,
+		})
+}
+
+// isDowngradable returns whether the PackageInstall is allowed to be downgraded to previous versions
+// https://carvel.dev/kapp-controller/docs/v0.32.0/package-consumer-concepts/#downgrading
+func isDowngradable(pkgInstall *packagingv1alpha1.PackageInstall) bool {
+	_, ok := pkgInstall.GetAnnotations()[kappctrlpackageinstall.DowngradableAnnKey]
+	return ok
+}
+
+// downgradeNotAllowedReason is the reason of the ErrorInfo detail of the
+// error returned when updating a PackageInstall to a lower version without
+// allowing downgrades.
+const downgradeNotAllowedReason = "DOWNGRADE_NOT_ALLOWED"
+
+// checkDowngrade returns a FailedPrecondition error when the requested version
+// is lower than the one last attempted by kapp-controller, which would refuse
+// to reconcile the PackageInstall unless it is downgradable.
+func checkDowngrade(pkgInstall *packagingv1alpha1.PackageInstall, pkgVersion string) error {
+	attemptedVersion := pkgInstall.Status.LastAttemptedVersion
+	if attemptedVersion == "" {
+		attemptedVersion = pkgInstall.Status.Version
+	}
+	if attemptedVersion == "" {
+		return nil
+	}
+	installedVersion, errInstalled := semver.NewVersion(attemptedVersion)
+	requestedVersion, errRequested := semver.NewVersion(pkgVersion)
+	if errInstalled != nil || errRequested != nil || !requestedVersion.LessThan(installedVersion) {
+		return nil
+	}
+
+	connectErr := connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("The selected version %q is lower than the installed version %q, but downgrades are not allowed", pkgVersion, attemptedVersion))
+	detail, err := connect.NewErrorDetail(&errdetails.ErrorInfo{
+		Reason: downgradeNotAllowedReason,
+		Domain: GetPluginDetail().GetName(),
+		Metadata: map[string]string{
+			"installedVersion": attemptedVersion,
+			"requestedVersion": pkgVersion,
+		},
+	})
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to report the downgrade error: %w", err))
+	}
+	connectErr.AddDetail(detail)
+	return connectErr
+}
+
+// pkgListChunkSize is the number of objects requested per chunk when listing
+// packages and package metadatas from the aggregated API.
+var pkgListChunkSize int64 = 500
+
+// pkgListPositionCacheSize is the maximum number of list positions remembered
+// to resume paginated listings.
+const pkgListPositionCacheSize = 1000
+
+var errPkgListPositionOutOfRange = errors.New("list position out of range")
+
+// pkgListPosition is the position of an item in a list requested in chunks:
+// the continue token for the chunk containing the item and the number of
+// (filtered) items to skip from the start of that chunk.
+type pkgListPosition struct {
+	Continue string
+	Skip     int
+}
+
+// pkgListPositionCache remembers the list position at which the next page of
+// a paginated listing starts. The core API uses item offsets as page tokens
+// (so that it can merge the results of several plugins), which the plugin
+// translates back into a continue token for the aggregated API.
+type pkgListPositionCache struct {
+	mutex     sync.Mutex
+	positions map[string]pkgListPosition
+	keys      []string
+}
+
+func newPkgListPositionCache() *pkgListPositionCache {
+	return &pkgListPositionCache{positions: map[string]pkgListPosition{}}
+}
+
+// pkgListPositionKey returns the cache key for the given item offset of the
+// listing for the cluster, namespace, filter options and policies, as they
+// all determine which items are skipped.
+func pkgListPositionKey(cluster, namespace string, filterOptions *corev1.FilterOptions, policies pkgRepositoryPolicies, itemOffset int) string {
+	return fmt.Sprintf("%s/%s/%q/%q/%q/%q/%t/%s/%d", cluster, namespace, filterOptions.GetQuery(), filterOptions.GetCategories(), filterOptions.GetRepositories(), filterOptions.GetProviders(), filterOptions.GetExcludeGlobalPackages(), policies.hash(), itemOffset)
+}
+
+// get returns the cached position for the key, if any. A nil cache never
+// returns a position.
+func (c *pkgListPositionCache) get(key string) (pkgListPosition, bool) {
+	if c == nil {
+		return pkgListPosition{}, false
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	position, ok := c.positions[key]
+	return position, ok
+}
+
+// set caches the position for the key, evicting the oldest position once the
+// cache is full.
+func (c *pkgListPositionCache) set(key string, position pkgListPosition) {
+	if c == nil {
+		return
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if _, ok := c.positions[key]; !ok {
+		if len(c.keys) >= pkgListPositionCacheSize {
+			delete(c.positions, c.keys[0])
+			c.keys = c.keys[1:]
+		}
+		c.keys = append(c.keys, key)
+	}
+	c.positions[key] = position
+}
+
+// pkgInstallValuesEntry is a values entry of a PackageInstall, together with
+// the layer in which it is merged and, for the entries whose values are being
+// created or updated by the plugin, the Secret holding them.
+type pkgInstallValuesEntry struct {
+	layer  kappcorev1.PackageInstallValuesLayer
+	ref    packagingv1alpha1.PackageInstallValues
+	secret *k8scorev1.Secret
+}
+
+// valuesSecretName returns the name of the Secret holding the values of the
+// request, as per:
+// https://github.com/vmware-tanzu/carvel-kapp-controller/blob/v0.36.1/cli/pkg/kctrl/cmd/package/installed/created_resource_annotations.go#L19
+func valuesSecretName(installedPackageName, namespace string) string {
+	return fmt.Sprintf("%s-%s-values", installedPackageName, namespace)
+}
+
+// layeredValuesSecretName returns the name of the Secret holding the values of
+// the given entry of the custom detail.
+func layeredValuesSecretName(installedPackageName, namespace string, layer kappcorev1.PackageInstallValuesLayer, index int) string {
+	layerName := strings.TrimPrefix(layer.String(), "PACKAGE_INSTALL_VALUES_LAYER_")
+	layerName = strings.ToLower(strings.ReplaceAll(layerName, "_", "-"))
+	return fmt.Sprintf("%s-%s-%s-values-%d", installedPackageName, namespace, layerName, index)
+}
+
+// isRequestValuesSecretName returns whether the given name is the one of a
+// Secret holding the values of a request, rather than of an entry of the
+// custom detail.
+func isRequestValuesSecretName(name string) bool {
+	return strings.HasSuffix(name, "-values")
+}
+
+// isPluginManagedValuesSecret returns whether the values Secret of the given
+// PackageInstall was created by this plugin. The Secret holding the values of
+// the request may have been created before the Secrets were annotated.
+func isPluginManagedValuesSecret(pkgInstall *packagingv1alpha1.PackageInstall, secretName string, secret *k8scorev1.Secret) bool {
+	if secretName == valuesSecretName(pkgInstall.Name, pkgInstall.Namespace) {
+		return true
+	}
+	return secret != nil && secret.GetAnnotations()[annotationManagedByKey] == annotationManagedByValue
+}
+
+// pkgInstallValuesLayer returns the layer of the given values entry of the
+// PackageInstall, as recorded when declared through the plugin.
+func pkgInstallValuesLayer(pkgInstall *packagingv1alpha1.PackageInstall, secretName string) kappcorev1.PackageInstallValuesLayer {
+	if secretName == valuesSecretName(pkgInstall.Name, pkgInstall.Namespace) {
+		return kappcorev1.PackageInstallValuesLayer_PACKAGE_INSTALL_VALUES_LAYER_USER_OVERRIDES
+	}
+	layers := map[string]string{}
+	if err := json.Unmarshal([]byte(pkgInstall.GetAnnotations()[annotationValuesLayersKey]), &layers); err != nil {
+		return kappcorev1.PackageInstallValuesLayer_PACKAGE_INSTALL_VALUES_LAYER_UNSPECIFIED
+	}
+	return kappcorev1.PackageInstallValuesLayer(kappcorev1.PackageInstallValuesLayer_value[layers[secretName]])
+}
+
+// setPkgInstallValues sets the values of the PackageInstall to the given
+// entries, sorted by layer so that kapp-controller merges them in order, and
+// records the layer of each of them.
+func setPkgInstallValues(pkgInstall *packagingv1alpha1.PackageInstall, entries []pkgInstallValuesEntry) error {
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].layer < entries[j].layer
+	})
+
+	values := []packagingv1alpha1.PackageInstallValues{}
+	layers := map[string]string{}
+	for _, entry := range entries {
+		values = append(values, entry.ref)
+		name := entry.ref.SecretRef.Name
+		// The layer of the values of the request is known from its name.
+		if entry.layer != kappcorev1.PackageInstallValuesLayer_PACKAGE_INSTALL_VALUES_LAYER_UNSPECIFIED && name != valuesSecretName(pkgInstall.Name, pkgInstall.Namespace) {
+			layers[name] = entry.layer.String()
+		}
+	}
+	pkgInstall.Spec.Values = values
+
+	if len(layers) == 0 {
+		delete(pkgInstall.ObjectMeta.Annotations, annotationValuesLayersKey)
+		return nil
+	}
+	layersJSON, err := json.Marshal(layers)
+	if err != nil {
+		return err
+	}
+	if pkgInstall.ObjectMeta.Annotations == nil {
+		pkgInstall.ObjectMeta.Annotations = map[string]string{}
+	}
+	pkgInstall.ObjectMeta.Annotations[annotationValuesLayersKey] = string(layersJSON)
+	return nil
+}
+
+// valuesFromSecret returns the values held by the Secret under the given key
+// or, if empty, under every key.
+func valuesFromSecret(secret *k8scorev1.Secret, key string) string {
+	if secret == nil {
+		return ""
+	}
+	if key != "" {
+		return string(secret.Data[key])
+	}
+	keys := []string{}
+	for k := range secret.Data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	values := []string{}
+	for _, k := range keys {
+		values = append(values, string(secret.Data[k]))
+	}
+	return strings.Join(values, "\n---\n")
+}
+
+// valuesSecretData returns the additional keys of the Secret holding the values
+// of the request declared in the custom detail, the string data taking
+// precedence over the binary one, as with the stringData of a Secret.
+func valuesSecretData(customDetail *kappcorev1.KappControllerInstalledPackageCustomDetail) (map[string][]byte, error) {
+	data := map[string][]byte{}
+	for key, value := range customDetail.GetValuesSecretData() {
+		data[key] = value
+	}
+	for key, value := range customDetail.GetValuesSecretStringData() {
+		data[key] = []byte(value)
+	}
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if key == valuesSecretKey {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("The key %q of the values Secret is reserved for the values of the request", key))
+		}
+		if errs := validation.IsConfigMapKey(key); len(errs) > 0 {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("The key %q of the values Secret is invalid: %s", key, strings.Join(errs, ", ")))
+		}
+	}
+	return data, nil
+}
+
+// valuesSecretManagedKeys returns whether each key of the Secret holding the
+// values of a request was set through the plugin, that is, the values one and
+// the additional keys recorded in its annotation.
+func valuesSecretManagedKeys(secret *k8scorev1.Secret) map[string]bool {
+	keys := map[string]bool{valuesSecretKey: true}
+	if recorded := secret.GetAnnotations()[annotationValuesSecretKeysKey]; recorded != "" {
+		for _, key := range strings.Split(recorded, ",") {
+			keys[key] = true
+		}
+	}
+	return keys
+}
+
+// valuesSecretManagedData returns the additional keys of the Secret holding
+// the values of a request which were set through the plugin.
+func valuesSecretManagedData(secret *k8scorev1.Secret) map[string][]byte {
+	data := map[string][]byte{}
+	if secret == nil {
+		return data
+	}
+	for key := range valuesSecretManagedKeys(secret) {
+		if value, ok := secret.Data[key]; ok && key != valuesSecretKey {
+			data[key] = value
+		}
+	}
+	return data
+}
+
+// keepValuesSecretUserKeys copies into the Secret holding the values of a
+// request the keys added by users to its current version.
+func keepValuesSecretUserKeys(secret, current *k8scorev1.Secret) {
+	if current == nil {
+		return
+	}
+	managedKeys := valuesSecretManagedKeys(current)
+	for key, value := range current.Data {
+		if _, ok := secret.Data[key]; !ok && !managedKeys[key] {
+			secret.Data[key] = value
+		}
+	}
+}
+
+// secretKeys returns the sorted keys of the Secret.
+func secretKeys(secret *k8scorev1.Secret) []string {
+	keys := []string{}
+	if secret == nil {
+		return keys
+	}
+	for key := range secret.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// newKappResource returns the kapp resource for the given Kubernetes object of the given kind.
+func newKappResource(obj interface{}, gvk schema.GroupVersionKind) (ctlres.Resource, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+	un := unstructured.Unstructured{Object: content}
+	un.SetGroupVersionKind(gvk)
+	return ctlres.NewResourceUnstructured(un, ctlres.ResourceType{}), nil
+}
+
+// resourceChangeOperations maps the kapp change operations to the ones returned
+// in a change preview. The operations not applying any change are not mapped.
+var resourceChangeOperations = map[ctldiff.ChangeOp]kappcorev1.ResourceChangeOperation{
+	ctldiff.ChangeOpAdd:    kappcorev1.ResourceChangeOperation_RESOURCE_CHANGE_OPERATION_CREATE,
+	ctldiff.ChangeOpUpdate: kappcorev1.ResourceChangeOperation_RESOURCE_CHANGE_OPERATION_UPDATE,
+	ctldiff.ChangeOpDelete: kappcorev1.ResourceChangeOperation_RESOURCE_CHANGE_OPERATION_DELETE,
+}
+
+// buildResourceChanges computes, as kapp does with its default configuration,
+// the changes from the existing resources to the proposed ones. The data of
+// the Secrets is masked in the diffs.
+func buildResourceChanges(existingRs, proposedRs []ctlres.Resource) ([]*kappcorev1.ResourceChange, error) {
+	_, conf, err := ctlconf.NewConfFromResourcesWithDefaults(nil)
+	if err != nil {
+		return nil, err
+	}
+	changeFactory := ctldiff.NewChangeFactory(conf.RebaseMods(), conf.DiffAgainstLastAppliedFieldExclusionMods())
+	changes, err := ctldiff.NewChangeSet(existingRs, proposedRs, ctldiff.ChangeSetOpts{}, changeFactory).Calculate()
+	if err != nil {
+		return nil, err
+	}
+
+	resourceChanges := []*kappcorev1.ResourceChange{}
+	for _, change := range changes {
+		operation, ok := resourceChangeOperations[change.Op()]
+		if !ok {
+			continue
+		}
+		textDiff, err := change.ConfigurableTextDiff().Masked(conf.DiffMaskRules())
+		if err != nil {
+			return nil, err
+		}
+		res := change.NewOrExistingResource()
+		resourceChanges = append(resourceChanges, &kappcorev1.ResourceChange{
+			ResourceRef: &corev1.ResourceRef{
+				ApiVersion: res.APIVersion(),
+				Kind:       res.Kind(),
+				Name:       res.Name(),
+				Namespace:  res.Namespace(),
+			},
+			Operation: operation,
+			Diff:      textDiff.MinimalString(),
+		})
+	}
+	return resourceChanges, nil
+}
+
+// checkDefaultNamespace returns an error unless the requested default namespace
+// of a PackageInstall is a valid namespace name and, when it differs from the
+// target namespace, overriding it is allowed by the plugin configuration.
+func checkDefaultNamespace(defaultNamespace, targetNamespace string, allowOverride bool) error {
+	if defaultNamespace == "" || defaultNamespace == targetNamespace {
+		return nil
+	}
+	if errs := validation.IsDNS1123Label(defaultNamespace); len(errs) > 0 {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("The default namespace %q is invalid: %s", defaultNamespace, strings.Join(errs, ", ")))
+	}
+	if !allowOverride {
+		return connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("Overriding the default namespace is not allowed by the plugin configuration"))
+	}
+	return nil
+}
@@ -4,17 +4,23 @@
 package main
 
 import (
+	"errors"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/Masterminds/semver/v3"
+	"github.com/bufbuild/connect-go"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	kappctrlv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	packagingv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/packaging/v1alpha1"
 	datapackagingv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apiserver/apis/datapackaging/v1alpha1"
 	vendirversions "github.com/vmware-tanzu/carvel-vendir/pkg/vendir/versions/v1alpha1"
 	corev1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	kappcorev1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/plugins/kapp_controller/packages/v1alpha1"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	k8scorev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -446,6 +452,169 @@ fetchStdout
 	}
 }
 
+func TestBuildInstalledPackageOperationStatus(t *testing.T) {
+	now := time.Date(2023, time.May, 4, 10, 0, 0, 0, time.UTC)
+	fetchStartedAt := metav1.NewTime(now.Add(-time.Minute))
+	fetchUpdatedAt := metav1.NewTime(now.Add(-50 * time.Second))
+	previousReconcile := metav1.NewTime(now.Add(-time.Hour))
+	reconciling := []kappctrlv1alpha1.Condition{{Type: kappctrlv1alpha1.Reconciling, Status: k8scorev1.ConditionTrue}}
+	reconcileFailed := []kappctrlv1alpha1.Condition{{Type: kappctrlv1alpha1.ReconcileFailed, Status: k8scorev1.ConditionTrue}}
+	pkgInstall := &packagingv1alpha1.PackageInstall{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         "default",
+			Name:              "my-installation",
+			CreationTimestamp: metav1.NewTime(now.Add(-time.Minute)),
+		},
+	}
+	phases := func(fetch, template, deploy corev1.InstalledPackageOperationPhase_PhaseState) []*corev1.InstalledPackageOperationPhase {
+		return []*corev1.InstalledPackageOperationPhase{
+			{Name: appPhaseFetch, State: fetch},
+			{Name: appPhaseTemplate, State: template},
+			{Name: appPhaseDeploy, State: deploy},
+		}
+	}
+	const (
+		pending    = corev1.InstalledPackageOperationPhase_PHASE_STATE_PENDING
+		inProgress = corev1.InstalledPackageOperationPhase_PHASE_STATE_IN_PROGRESS
+		succeeded  = corev1.InstalledPackageOperationPhase_PHASE_STATE_SUCCEEDED
+		failed     = corev1.InstalledPackageOperationPhase_PHASE_STATE_FAILED
+	)
+
+	testCases := []struct {
+		name     string
+		app      *kappctrlv1alpha1.App
+		timeout  time.Duration
+		expected *corev1.GetInstalledPackageOperationStatusResponse
+	}{
+		{
+			name:    "every phase is pending while the App is not created",
+			timeout: 5 * time.Minute,
+			expected: &corev1.GetInstalledPackageOperationStatusResponse{
+				Status: &corev1.InstalledPackageStatus{
+					Reason:     corev1.InstalledPackageStatus_STATUS_REASON_PENDING,
+					UserReason: "Waiting for the App to be created",
+				},
+				Phases: phases(pending, pending, pending),
+			},
+		},
+		{
+			name:    "the operation fails when the App is not created within the timeout",
+			timeout: 30 * time.Second,
+			expected: &corev1.GetInstalledPackageOperationStatusResponse{
+				Status: &corev1.InstalledPackageStatus{
+					Reason:     corev1.InstalledPackageStatus_STATUS_REASON_FAILED,
+					UserReason: "Timeout exceeded (30 s) waiting for the App to be created",
+				},
+				Phases: phases(pending, pending, pending),
+			},
+		},
+		{
+			name: "every phase is pending until the App generation is observed",
+			app: &kappctrlv1alpha1.App{
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Status: kappctrlv1alpha1.AppStatus{
+					Fetch:         &kappctrlv1alpha1.AppStatusFetch{StartedAt: fetchStartedAt, UpdatedAt: fetchUpdatedAt},
+					GenericStatus: kappctrlv1alpha1.GenericStatus{ObservedGeneration: 1},
+				},
+			},
+			timeout: 5 * time.Minute,
+			expected: &corev1.GetInstalledPackageOperationStatusResponse{
+				Status: &corev1.InstalledPackageStatus{
+					Reason:     corev1.InstalledPackageStatus_STATUS_REASON_PENDING,
+					UserReason: "Reconciling",
+				},
+				Phases: phases(pending, pending, pending),
+			},
+		},
+		{
+			name: "the fetch phase is in progress once the App is reconciling",
+			app: &kappctrlv1alpha1.App{
+				Status: kappctrlv1alpha1.AppStatus{
+					GenericStatus: kappctrlv1alpha1.GenericStatus{Conditions: reconciling},
+				},
+			},
+			timeout: 5 * time.Minute,
+			expected: &corev1.GetInstalledPackageOperationStatusResponse{
+				Status: &corev1.InstalledPackageStatus{
+					Reason:     corev1.InstalledPackageStatus_STATUS_REASON_PENDING,
+					UserReason: "Reconciling",
+				},
+				Phases: phases(inProgress, pending, pending),
+			},
+		},
+		{
+			name: "the deploy phase is in progress once templated, ignoring the statuses of a previous reconciliation",
+			app: &kappctrlv1alpha1.App{
+				Status: kappctrlv1alpha1.AppStatus{
+					Fetch:         &kappctrlv1alpha1.AppStatusFetch{StartedAt: fetchStartedAt, UpdatedAt: fetchUpdatedAt},
+					Template:      &kappctrlv1alpha1.AppStatusTemplate{UpdatedAt: fetchUpdatedAt},
+					Deploy:        &kappctrlv1alpha1.AppStatusDeploy{StartedAt: previousReconcile, UpdatedAt: previousReconcile, Finished: true},
+					GenericStatus: kappctrlv1alpha1.GenericStatus{Conditions: reconciling},
+				},
+			},
+			timeout: 5 * time.Minute,
+			expected: &corev1.GetInstalledPackageOperationStatusResponse{
+				Status: &corev1.InstalledPackageStatus{
+					Reason:     corev1.InstalledPackageStatus_STATUS_REASON_PENDING,
+					UserReason: "Reconciling",
+				},
+				Phases: phases(succeeded, succeeded, inProgress),
+			},
+		},
+		{
+			name: "the operation succeeds once deployed",
+			app: &kappctrlv1alpha1.App{
+				Status: kappctrlv1alpha1.AppStatus{
+					Fetch:    &kappctrlv1alpha1.AppStatusFetch{StartedAt: fetchStartedAt, UpdatedAt: fetchUpdatedAt},
+					Template: &kappctrlv1alpha1.AppStatusTemplate{UpdatedAt: fetchUpdatedAt},
+					Deploy:   &kappctrlv1alpha1.AppStatusDeploy{StartedAt: fetchUpdatedAt, UpdatedAt: fetchUpdatedAt, Finished: true},
+				},
+			},
+			timeout: 5 * time.Minute,
+			expected: &corev1.GetInstalledPackageOperationStatusResponse{
+				Status: &corev1.InstalledPackageStatus{
+					Ready:      true,
+					Reason:     corev1.InstalledPackageStatus_STATUS_REASON_INSTALLED,
+					UserReason: "Deployed",
+				},
+				Phases: phases(succeeded, succeeded, succeeded),
+			},
+		},
+		{
+			name: "the operation fails with the error of the failed phase",
+			app: &kappctrlv1alpha1.App{
+				Status: kappctrlv1alpha1.AppStatus{
+					Fetch:         &kappctrlv1alpha1.AppStatusFetch{StartedAt: fetchStartedAt, UpdatedAt: fetchUpdatedAt},
+					Template:      &kappctrlv1alpha1.AppStatusTemplate{UpdatedAt: fetchUpdatedAt, ExitCode: 1, Stderr: "ytt: Error: Overlay not found"},
+					GenericStatus: kappctrlv1alpha1.GenericStatus{Conditions: reconcileFailed},
+				},
+			},
+			timeout: 5 * time.Minute,
+			expected: &corev1.GetInstalledPackageOperationStatusResponse{
+				Status: &corev1.InstalledPackageStatus{
+					Reason:     corev1.InstalledPackageStatus_STATUS_REASON_FAILED,
+					UserReason: "The template phase failed: ytt: Error: Overlay not found",
+				},
+				Phases: []*corev1.InstalledPackageOperationPhase{
+					{Name: appPhaseFetch, State: succeeded},
+					{Name: appPhaseTemplate, State: failed, Message: "ytt: Error: Overlay not found"},
+					{Name: appPhaseDeploy, State: pending},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			opts := cmpopts.IgnoreUnexported(corev1.GetInstalledPackageOperationStatusResponse{}, corev1.InstalledPackageStatus{}, corev1.InstalledPackageOperationPhase{})
+			got := buildInstalledPackageOperationStatus(pkgInstall, tc.app, tc.timeout, now)
+			if want := tc.expected; !cmp.Equal(want, got, opts) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, opts))
+			}
+		})
+	}
+}
+
 func TestBuildReadme(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -689,6 +858,39 @@ func TestFilterMetadatas(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "filters out metadatas of other providers",
+			metadatas: []*datapackagingv1alpha1.PackageMetadata{
+				{
+					Spec: datapackagingv1alpha1.PackageMetadataSpec{
+						DisplayName:  "my foo game",
+						ProviderName: "Tetris inc.",
+					},
+				},
+				{
+					Spec: datapackagingv1alpha1.PackageMetadataSpec{
+						DisplayName:  "my bar game",
+						ProviderName: "Tombi!",
+					},
+				},
+				{
+					Spec: datapackagingv1alpha1.PackageMetadataSpec{
+						DisplayName: "my zed game",
+					},
+				},
+			},
+			filterOptions: corev1.FilterOptions{
+				Providers: []string{"Tombi!"},
+			},
+			expectedMetadatas: []*datapackagingv1alpha1.PackageMetadata{
+				{
+					Spec: datapackagingv1alpha1.PackageMetadataSpec{
+						DisplayName:  "my bar game",
+						ProviderName: "Tombi!",
+					},
+				},
+			},
+		},
 		{
 			name: "matches display name",
 			metadatas: []*datapackagingv1alpha1.PackageMetadata{
@@ -994,6 +1196,37 @@ func TestFilterMetadatas(t *testing.T) {
 			},
 			},
 		},
+		{
+			name: "it returns only the metadatas of the repository in the given namespace",
+			metadatas: []*datapackagingv1alpha1.PackageMetadata{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:   "default",
+						Name:        "tetris.foo.example.com",
+						Annotations: map[string]string{REPO_REF_ANNOTATION: "default/match-repo"},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:   "default",
+						Name:        "tombi.foo.example.com",
+						Annotations: map[string]string{REPO_REF_ANNOTATION: "global/match-repo"},
+					},
+				},
+			},
+			filterOptions: corev1.FilterOptions{
+				Repositories: []string{"default/match-repo"},
+			},
+			expectedMetadatas: []*datapackagingv1alpha1.PackageMetadata{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:   "default",
+						Name:        "tetris.foo.example.com",
+						Annotations: map[string]string{REPO_REF_ANNOTATION: "default/match-repo"},
+					},
+				},
+			},
+		},
 	}
 
 	//nolint:govet
@@ -1006,3 +1239,791 @@ func TestFilterMetadatas(t *testing.T) {
 	}
 
 }
+
+func TestFilterMetadatasByPolicies(t *testing.T) {
+	metadata := func(repoRef, name string) *datapackagingv1alpha1.PackageMetadata {
+		return &datapackagingv1alpha1.PackageMetadata{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Annotations: map[string]string{REPO_REF_ANNOTATION: repoRef},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name              string
+		metadatas         []*datapackagingv1alpha1.PackageMetadata
+		policies          pkgRepositoryPolicies
+		expectedMetadatas []*datapackagingv1alpha1.PackageMetadata
+	}{
+		{
+			name: "it returns every metadata without policies",
+			metadatas: []*datapackagingv1alpha1.PackageMetadata{
+				metadata("default/repo", "foo.example.com"),
+				metadata("default/repo", "bar.example.com"),
+			},
+			expectedMetadatas: []*datapackagingv1alpha1.PackageMetadata{
+				metadata("default/repo", "foo.example.com"),
+				metadata("default/repo", "bar.example.com"),
+			},
+		},
+		{
+			name: "it returns only the allowed metadatas",
+			metadatas: []*datapackagingv1alpha1.PackageMetadata{
+				metadata("default/repo", "foo.example.com"),
+				metadata("default/repo", "bar.example.org"),
+			},
+			policies: pkgRepositoryPolicies{
+				"default/repo": {AllowedPackages: []string{"*.example.com"}},
+			},
+			expectedMetadatas: []*datapackagingv1alpha1.PackageMetadata{
+				metadata("default/repo", "foo.example.com"),
+			},
+		},
+		{
+			name: "it filters out the denied metadatas even if allowed",
+			metadatas: []*datapackagingv1alpha1.PackageMetadata{
+				metadata("default/repo", "foo.example.com"),
+				metadata("default/repo", "bar.example.com"),
+			},
+			policies: pkgRepositoryPolicies{
+				"default/repo": {AllowedPackages: []string{"*.example.com"}, DeniedPackages: []string{"bar.*"}},
+			},
+			expectedMetadatas: []*datapackagingv1alpha1.PackageMetadata{
+				metadata("default/repo", "foo.example.com"),
+			},
+		},
+		{
+			name: "it applies the policy of the repository of each metadata",
+			metadatas: []*datapackagingv1alpha1.PackageMetadata{
+				metadata("default/repo", "foo.example.com"),
+				metadata("kapp-controller-packaging-global/other-repo", "foo.example.com"),
+			},
+			policies: pkgRepositoryPolicies{
+				"default/repo": {DeniedPackages: []string{"foo.example.com"}},
+			},
+			expectedMetadatas: []*datapackagingv1alpha1.PackageMetadata{
+				metadata("kapp-controller-packaging-global/other-repo", "foo.example.com"),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got, want := FilterMetadatasByPolicies(tc.metadatas, tc.policies), tc.expectedMetadatas; !cmp.Equal(want, got) {
+				t.Errorf("mismatch in '%s': %s", tc.name, cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
+func TestPkgListPositionKey(t *testing.T) {
+	policies := pkgRepositoryPolicies{
+		"default/repo": {DeniedPackages: []string{"foo.example.com"}},
+	}
+	testCases := []struct {
+		name          string
+		otherPolicies pkgRepositoryPolicies
+		expectedEqual bool
+	}{
+		{
+			name: "it returns the same key for the same policies",
+			otherPolicies: pkgRepositoryPolicies{
+				"default/repo": {DeniedPackages: []string{"foo.example.com"}},
+			},
+			expectedEqual: true,
+		},
+		{
+			name: "it returns another key when the patterns change",
+			otherPolicies: pkgRepositoryPolicies{
+				"default/repo": {DeniedPackages: []string{"bar.example.com"}},
+			},
+		},
+		{
+			name: "it returns another key when the patterns move to another list",
+			otherPolicies: pkgRepositoryPolicies{
+				"default/repo": {AllowedPackages: []string{"foo.example.com"}},
+			},
+		},
+		{
+			name: "it returns another key when the repository changes",
+			otherPolicies: pkgRepositoryPolicies{
+				"default/other-repo": {DeniedPackages: []string{"foo.example.com"}},
+			},
+		},
+		{
+			name: "it returns another key without policies",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			key := pkgListPositionKey("default", "default", nil, policies, 2)
+			otherKey := pkgListPositionKey("default", "default", nil, tc.otherPolicies, 2)
+			if got, want := key == otherKey, tc.expectedEqual; got != want {
+				t.Errorf("got: %t, want: %t (keys %q and %q)", got, want, key, otherKey)
+			}
+		})
+	}
+}
+
+func TestFilterGlobalMetadatas(t *testing.T) {
+	metadata := func(annotations map[string]string, name string) *datapackagingv1alpha1.PackageMetadata {
+		return &datapackagingv1alpha1.PackageMetadata{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Annotations: annotations,
+			},
+		}
+	}
+	global := map[string]string{REPO_REF_ANNOTATION: "kapp-controller-packaging-global/global-repo"}
+	local := map[string]string{REPO_REF_ANNOTATION: "default/repo"}
+
+	testCases := []struct {
+		name              string
+		metadatas         []*datapackagingv1alpha1.PackageMetadata
+		expectedMetadatas []*datapackagingv1alpha1.PackageMetadata
+	}{
+		{
+			name: "it filters out the metadatas of the global repositories",
+			metadatas: []*datapackagingv1alpha1.PackageMetadata{
+				metadata(local, "foo.example.com"),
+				metadata(global, "bar.example.com"),
+			},
+			expectedMetadatas: []*datapackagingv1alpha1.PackageMetadata{
+				metadata(local, "foo.example.com"),
+			},
+		},
+		{
+			name: "it keeps the metadatas without a repository reference",
+			metadatas: []*datapackagingv1alpha1.PackageMetadata{
+				metadata(nil, "foo.example.com"),
+				metadata(global, "bar.example.com"),
+			},
+			expectedMetadatas: []*datapackagingv1alpha1.PackageMetadata{
+				metadata(nil, "foo.example.com"),
+			},
+		},
+		{
+			name: "it returns an empty slice if every metadata is global",
+			metadatas: []*datapackagingv1alpha1.PackageMetadata{
+				metadata(global, "bar.example.com"),
+			},
+			expectedMetadatas: []*datapackagingv1alpha1.PackageMetadata{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got, want := FilterGlobalMetadatas(tc.metadatas, "kapp-controller-packaging-global"), tc.expectedMetadatas; !cmp.Equal(want, got) {
+				t.Errorf("mismatch in '%s': %s", tc.name, cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
+func TestSetInstalledPackageReconcileTimes(t *testing.T) {
+	now := time.Date(2023, time.May, 4, 10, 0, 0, 0, time.UTC)
+	fetchedAt := metav1.NewTime(now.Add(-25 * time.Minute))
+	deployedAt := metav1.NewTime(now.Add(-21 * time.Minute))
+	recentlyDeployedAt := metav1.NewTime(now.Add(-5 * time.Minute))
+	fiveMinutes := &metav1.Duration{Duration: 5 * time.Minute}
+
+	testCases := []struct {
+		name           string
+		paused         bool
+		appSpec        kappctrlv1alpha1.AppSpec
+		appStatus      kappctrlv1alpha1.AppStatus
+		expectedStatus *corev1.InstalledPackageStatus
+	}{
+		{
+			name: "it reports the default sync interval of an App not reconciled yet",
+			expectedStatus: &corev1.InstalledPackageStatus{
+				SyncInterval: "10m",
+			},
+		},
+		{
+			name:    "it reports the reconcile times of an App deployed recently",
+			appSpec: kappctrlv1alpha1.AppSpec{SyncPeriod: fiveMinutes},
+			appStatus: kappctrlv1alpha1.AppStatus{
+				Fetch:  &kappctrlv1alpha1.AppStatusFetch{UpdatedAt: fetchedAt},
+				Deploy: &kappctrlv1alpha1.AppStatusDeploy{Finished: true, UpdatedAt: recentlyDeployedAt},
+			},
+			expectedStatus: &corev1.InstalledPackageStatus{
+				LastFetchTime:  "2023-05-04T09:35:00Z",
+				LastDeployTime: "2023-05-04T09:55:00Z",
+				SyncInterval:   "5m",
+			},
+		},
+		{
+			name:    "it flags as stale an App deployed more than twice the sync interval ago",
+			appSpec: kappctrlv1alpha1.AppSpec{SyncPeriod: fiveMinutes},
+			appStatus: kappctrlv1alpha1.AppStatus{
+				Fetch:  &kappctrlv1alpha1.AppStatusFetch{UpdatedAt: fetchedAt},
+				Deploy: &kappctrlv1alpha1.AppStatusDeploy{Finished: true, UpdatedAt: deployedAt},
+			},
+			expectedStatus: &corev1.InstalledPackageStatus{
+				LastFetchTime:  "2023-05-04T09:35:00Z",
+				LastDeployTime: "2023-05-04T09:39:00Z",
+				SyncInterval:   "5m",
+				Stale:          true,
+			},
+		},
+		{
+			name:    "it does not flag as stale an App whose last deploy failed",
+			appSpec: kappctrlv1alpha1.AppSpec{SyncPeriod: fiveMinutes},
+			appStatus: kappctrlv1alpha1.AppStatus{
+				Deploy: &kappctrlv1alpha1.AppStatusDeploy{Finished: true, ExitCode: 1, Error: "Deploying: Error", UpdatedAt: deployedAt},
+			},
+			expectedStatus: &corev1.InstalledPackageStatus{
+				LastDeployTime: "2023-05-04T09:39:00Z",
+				SyncInterval:   "5m",
+			},
+		},
+		{
+			name:    "it does not flag as stale a paused package install",
+			paused:  true,
+			appSpec: kappctrlv1alpha1.AppSpec{SyncPeriod: fiveMinutes},
+			appStatus: kappctrlv1alpha1.AppStatus{
+				Deploy: &kappctrlv1alpha1.AppStatusDeploy{Finished: true, UpdatedAt: deployedAt},
+			},
+			expectedStatus: &corev1.InstalledPackageStatus{
+				LastDeployTime: "2023-05-04T09:39:00Z",
+				SyncInterval:   "5m",
+			},
+		},
+		{
+			name:    "it does not report the deploy time of an unfinished deploy",
+			appSpec: kappctrlv1alpha1.AppSpec{SyncPeriod: fiveMinutes},
+			appStatus: kappctrlv1alpha1.AppStatus{
+				Deploy: &kappctrlv1alpha1.AppStatusDeploy{UpdatedAt: deployedAt},
+			},
+			expectedStatus: &corev1.InstalledPackageStatus{
+				SyncInterval: "5m",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pkgInstall := &packagingv1alpha1.PackageInstall{
+				Spec: packagingv1alpha1.PackageInstallSpec{Paused: tc.paused},
+			}
+			app := &kappctrlv1alpha1.App{Spec: tc.appSpec, Status: tc.appStatus}
+
+			status := &corev1.InstalledPackageStatus{}
+			setInstalledPackageReconcileTimes(status, pkgInstall, app, now)
+
+			opts := cmpopts.IgnoreUnexported(corev1.InstalledPackageStatus{})
+			if got, want := status, tc.expectedStatus; !cmp.Equal(want, got, opts) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, opts))
+			}
+		})
+	}
+}
+
+func TestPkgVersionDeprecation(t *testing.T) {
+	now := time.Date(2023, time.May, 4, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name                string
+		annotations         map[string]string
+		expectedDeprecation *corev1.PackageVersionDeprecation
+	}{
+		{
+			name: "it returns nil for a version without annotations",
+		},
+		{
+			name:        "it returns nil for a version explicitly not deprecated",
+			annotations: map[string]string{annotationDeprecatedKey: "false"},
+		},
+		{
+			name:                "it returns the deprecation without a message",
+			annotations:         map[string]string{annotationDeprecatedKey: "true"},
+			expectedDeprecation: &corev1.PackageVersionDeprecation{},
+		},
+		{
+			name:                "it returns the deprecation with its message",
+			annotations:         map[string]string{annotationDeprecatedKey: "Upgrade to 2.x"},
+			expectedDeprecation: &corev1.PackageVersionDeprecation{Message: "Upgrade to 2.x"},
+		},
+		{
+			name:        "it returns the end of life to come",
+			annotations: map[string]string{annotationEndOfLifeKey: "2023-06-30"},
+			expectedDeprecation: &corev1.PackageVersionDeprecation{
+				EndOfLife: "2023-06-30",
+			},
+		},
+		{
+			name: "it returns the end of life reached",
+			annotations: map[string]string{
+				annotationDeprecatedKey: "",
+				annotationEndOfLifeKey:  "2023-05-04T09:00:00Z",
+			},
+			expectedDeprecation: &corev1.PackageVersionDeprecation{
+				EndOfLife:        "2023-05-04T09:00:00Z",
+				EndOfLifeReached: true,
+			},
+		},
+		{
+			name:        "it ignores an invalid end of life",
+			annotations: map[string]string{annotationEndOfLifeKey: "next summer"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pkg := &datapackagingv1alpha1.Package{
+				ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations},
+			}
+
+			deprecation := pkgVersionDeprecation(pkg, now)
+
+			opts := cmpopts.IgnoreUnexported(corev1.PackageVersionDeprecation{})
+			if got, want := deprecation, tc.expectedDeprecation; !cmp.Equal(want, got, opts) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, opts))
+			}
+		})
+	}
+}
+
+func TestDeprecationWarnings(t *testing.T) {
+	testCases := []struct {
+		name             string
+		deprecation      *corev1.PackageVersionDeprecation
+		expectedWarnings []string
+	}{
+		{
+			name: "it returns no warnings for a version not deprecated",
+		},
+		{
+			name:        "it warns about the deprecation with its message",
+			deprecation: &corev1.PackageVersionDeprecation{Message: "Upgrade to 2.x"},
+			expectedWarnings: []string{
+				"The installed version 1.2.3 is deprecated: Upgrade to 2.x",
+			},
+		},
+		{
+			name:        "it warns about the end of life to come",
+			deprecation: &corev1.PackageVersionDeprecation{EndOfLife: "2023-06-30"},
+			expectedWarnings: []string{
+				"The installed version 1.2.3 is deprecated",
+				"The installed version 1.2.3 reaches its end of life on 2023-06-30",
+			},
+		},
+		{
+			name:        "it warns about the end of life reached",
+			deprecation: &corev1.PackageVersionDeprecation{EndOfLife: "2023-04-30", EndOfLifeReached: true},
+			expectedWarnings: []string{
+				"The installed version 1.2.3 is deprecated",
+				"The installed version 1.2.3 reached its end of life on 2023-04-30",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got, want := deprecationWarnings("1.2.3", tc.deprecation), tc.expectedWarnings; !cmp.Equal(want, got) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
+func TestCheckDowngrade(t *testing.T) {
+	testCases := []struct {
+		name             string
+		status           packagingv1alpha1.PackageInstallStatus
+		pkgVersion       string
+		expectedMetadata map[string]string
+	}{
+		{
+			name:       "it allows an upgrade",
+			status:     packagingv1alpha1.PackageInstallStatus{Version: "1.2.3", LastAttemptedVersion: "1.2.3"},
+			pkgVersion: "1.3.0",
+		},
+		{
+			name:       "it allows a package install not reconciled yet",
+			pkgVersion: "1.0.0",
+		},
+		{
+			name:       "it rejects a version lower than the last attempted one",
+			status:     packagingv1alpha1.PackageInstallStatus{Version: "1.2.3", LastAttemptedVersion: "1.2.3"},
+			pkgVersion: "1.0.0",
+			expectedMetadata: map[string]string{
+				"installedVersion": "1.2.3",
+				"requestedVersion": "1.0.0",
+			},
+		},
+		{
+			name:       "it compares with the last attempted version rather than the installed one",
+			status:     packagingv1alpha1.PackageInstallStatus{Version: "1.2.3", LastAttemptedVersion: "2.0.0"},
+			pkgVersion: "1.2.3",
+			expectedMetadata: map[string]string{
+				"installedVersion": "2.0.0",
+				"requestedVersion": "1.2.3",
+			},
+		},
+		{
+			name:       "it falls back to the installed version",
+			status:     packagingv1alpha1.PackageInstallStatus{Version: "1.2.3"},
+			pkgVersion: "1.0.0",
+			expectedMetadata: map[string]string{
+				"installedVersion": "1.2.3",
+				"requestedVersion": "1.0.0",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkDowngrade(&packagingv1alpha1.PackageInstall{Status: tc.status}, tc.pkgVersion)
+
+			if tc.expectedMetadata == nil {
+				if err != nil {
+					t.Fatalf("%+v", err)
+				}
+				return
+			}
+			if got, want := connect.CodeOf(err), connect.CodeFailedPrecondition; got != want {
+				t.Fatalf("got: %v, want: %v, err: %+v", got, want, err)
+			}
+			var connectErr *connect.Error
+			if !errors.As(err, &connectErr) {
+				t.Fatalf("got: %+v, want: a connect error", err)
+			}
+			if got, want := len(connectErr.Details()), 1; got != want {
+				t.Fatalf("got: %d, want: %d", got, want)
+			}
+			detail, err := connectErr.Details()[0].Value()
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			errorInfo, ok := detail.(*errdetails.ErrorInfo)
+			if !ok {
+				t.Fatalf("got: %T, want: *errdetails.ErrorInfo", detail)
+			}
+			if got, want := errorInfo.GetReason(), downgradeNotAllowedReason; got != want {
+				t.Errorf("got: %q, want: %q", got, want)
+			}
+			if got, want := errorInfo.GetMetadata(), tc.expectedMetadata; !cmp.Equal(want, got) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
+func TestCheckDefaultNamespace(t *testing.T) {
+	testCases := []struct {
+		name              string
+		defaultNamespace  string
+		allowOverride     bool
+		expectedErrorCode connect.Code
+	}{
+		{
+			name: "it accepts an empty default namespace",
+		},
+		{
+			name:             "it accepts the target namespace",
+			defaultNamespace: "default",
+		},
+		{
+			name:             "it accepts another namespace when allowed",
+			defaultNamespace: "other",
+			allowOverride:    true,
+		},
+		{
+			name:              "it rejects another namespace when not allowed",
+			defaultNamespace:  "other",
+			expectedErrorCode: connect.CodeFailedPrecondition,
+		},
+		{
+			name:              "it rejects an invalid namespace",
+			defaultNamespace:  "Not_Valid",
+			allowOverride:     true,
+			expectedErrorCode: connect.CodeInvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkDefaultNamespace(tc.defaultNamespace, "default", tc.allowOverride)
+			if got, want := connect.CodeOf(err), tc.expectedErrorCode; err != nil && got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			} else if err == nil && tc.expectedErrorCode != 0 {
+				t.Fatalf("got: nil, want: error with code %+v", tc.expectedErrorCode)
+			}
+		})
+	}
+}
+
+func TestValuesSecretData(t *testing.T) {
+	testCases := []struct {
+		name              string
+		customDetail      *kappcorev1.KappControllerInstalledPackageCustomDetail
+		expectedData      map[string][]byte
+		expectedErrorCode connect.Code
+	}{
+		{
+			name:         "it returns no keys without a custom detail",
+			expectedData: map[string][]byte{},
+		},
+		{
+			name: "it merges the binary and string keys, the string ones taking precedence",
+			customDetail: &kappcorev1.KappControllerInstalledPackageCustomDetail{
+				ValuesSecretData: map[string][]byte{
+					"ca.der":  {0x30, 0x82, 0x01, 0x0a},
+					"tls.crt": []byte("old"),
+				},
+				ValuesSecretStringData: map[string]string{
+					"tls.crt": "-----BEGIN CERTIFICATE-----",
+				},
+			},
+			expectedData: map[string][]byte{
+				"ca.der":  {0x30, 0x82, 0x01, 0x0a},
+				"tls.crt": []byte("-----BEGIN CERTIFICATE-----"),
+			},
+		},
+		{
+			name: "it rejects the key of the values of the request",
+			customDetail: &kappcorev1.KappControllerInstalledPackageCustomDetail{
+				ValuesSecretStringData: map[string]string{"values.yaml": "foo: bar"},
+			},
+			expectedErrorCode: connect.CodeInvalidArgument,
+		},
+		{
+			name: "it rejects an invalid key",
+			customDetail: &kappcorev1.KappControllerInstalledPackageCustomDetail{
+				ValuesSecretData: map[string][]byte{"certs/tls.crt": []byte("cert")},
+			},
+			expectedErrorCode: connect.CodeInvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := valuesSecretData(tc.customDetail)
+			if got, want := connect.CodeOf(err), tc.expectedErrorCode; err != nil && got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			} else if err == nil && tc.expectedErrorCode != 0 {
+				t.Fatalf("got: nil, want: error with code %+v", tc.expectedErrorCode)
+			}
+			if got, want := data, tc.expectedData; !cmp.Equal(want, got) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
+func TestKeepValuesSecretUserKeys(t *testing.T) {
+	current := &k8scorev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{annotationValuesSecretKeysKey: "ca.crt,tls.crt"},
+		},
+		Data: map[string][]byte{
+			"values.yaml": []byte("foo: bar"),
+			"ca.crt":      []byte("ca"),
+			"tls.crt":     []byte("old"),
+			"extra.yaml":  []byte("user: true"),
+		},
+	}
+	secret := &k8scorev1.Secret{
+		Data: map[string][]byte{
+			"values.yaml": []byte("foo: baz"),
+			"tls.crt":     []byte("new"),
+		},
+	}
+
+	keepValuesSecretUserKeys(secret, current)
+
+	expectedData := map[string][]byte{
+		"values.yaml": []byte("foo: baz"),
+		"tls.crt":     []byte("new"),
+		"extra.yaml":  []byte("user: true"),
+	}
+	if got, want := secret.Data, expectedData; !cmp.Equal(want, got) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+	}
+	if got, want := valuesSecretManagedData(current), map[string][]byte{"ca.crt": []byte("ca"), "tls.crt": []byte("old")}; !cmp.Equal(want, got) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+	}
+}
+
+func TestBuildReconcileOutcome(t *testing.T) {
+	now := time.Date(2023, time.May, 4, 10, 0, 0, 0, time.UTC)
+	fetchStartedAt := metav1.NewTime(now.Add(-time.Minute))
+	fetchUpdatedAt := metav1.NewTime(now.Add(-50 * time.Second))
+	templateUpdatedAt := metav1.NewTime(now.Add(-45 * time.Second))
+	deployStartedAt := metav1.NewTime(now.Add(-40 * time.Second))
+	deployUpdatedAt := metav1.NewTime(now.Add(-20 * time.Second))
+	previousReconcile := metav1.NewTime(now.Add(-time.Hour))
+	reconciling := []kappctrlv1alpha1.Condition{{Type: kappctrlv1alpha1.Reconciling, Status: k8scorev1.ConditionTrue}}
+	reconcileFailed := []kappctrlv1alpha1.Condition{{Type: kappctrlv1alpha1.ReconcileFailed, Status: k8scorev1.ConditionTrue}}
+	reconcileSucceeded := []kappctrlv1alpha1.Condition{{Type: kappctrlv1alpha1.ReconcileSucceeded, Status: k8scorev1.ConditionTrue}}
+	pkgInstall := &packagingv1alpha1.PackageInstall{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "my-installation",
+		},
+		Status: packagingv1alpha1.PackageInstallStatus{
+			LastAttemptedVersion: "1.2.3",
+		},
+	}
+
+	testCases := []struct {
+		name     string
+		app      *kappctrlv1alpha1.App
+		expected *kappcorev1.ReconcileOutcome
+	}{
+		{
+			name: "no outcome while the App is not created",
+		},
+		{
+			name: "no outcome while the App is reconciling",
+			app: &kappctrlv1alpha1.App{
+				Status: kappctrlv1alpha1.AppStatus{
+					GenericStatus: kappctrlv1alpha1.GenericStatus{Conditions: reconciling},
+					Fetch:         &kappctrlv1alpha1.AppStatusFetch{StartedAt: fetchStartedAt, UpdatedAt: fetchUpdatedAt},
+					Template:      &kappctrlv1alpha1.AppStatusTemplate{UpdatedAt: templateUpdatedAt},
+				},
+			},
+		},
+		{
+			name: "the outcome of a successful reconciliation spans until the end of the deploy",
+			app: &kappctrlv1alpha1.App{
+				Status: kappctrlv1alpha1.AppStatus{
+					GenericStatus:                 kappctrlv1alpha1.GenericStatus{Conditions: reconcileSucceeded},
+					Fetch:                         &kappctrlv1alpha1.AppStatusFetch{StartedAt: fetchStartedAt, UpdatedAt: fetchUpdatedAt},
+					Template:                      &kappctrlv1alpha1.AppStatusTemplate{UpdatedAt: templateUpdatedAt},
+					Deploy:                        &kappctrlv1alpha1.AppStatusDeploy{StartedAt: deployStartedAt, UpdatedAt: deployUpdatedAt, Finished: true},
+					ConsecutiveReconcileSuccesses: 4,
+				},
+			},
+			expected: &kappcorev1.ReconcileOutcome{
+				Version:          "1.2.3",
+				Succeeded:        true,
+				StartedAt:        "2023-05-04T09:59:00Z",
+				FinishedAt:       "2023-05-04T09:59:40Z",
+				Duration:         "40s",
+				ConsecutiveCount: 4,
+			},
+		},
+		{
+			name: "the outcome of a failed fetch ignores the deploy of a previous reconciliation",
+			app: &kappctrlv1alpha1.App{
+				Status: kappctrlv1alpha1.AppStatus{
+					GenericStatus:                 kappctrlv1alpha1.GenericStatus{Conditions: reconcileFailed},
+					Fetch:                         &kappctrlv1alpha1.AppStatusFetch{StartedAt: fetchStartedAt, UpdatedAt: fetchUpdatedAt, ExitCode: 1, Error: "unable to fetch the bundle"},
+					Deploy:                        &kappctrlv1alpha1.AppStatusDeploy{StartedAt: previousReconcile, UpdatedAt: previousReconcile, Finished: true},
+					ConsecutiveReconcileSuccesses: 4,
+					ConsecutiveReconcileFailures:  2,
+				},
+			},
+			expected: &kappcorev1.ReconcileOutcome{
+				Version:          "1.2.3",
+				StartedAt:        "2023-05-04T09:59:00Z",
+				FinishedAt:       "2023-05-04T09:59:10Z",
+				Duration:         "10s",
+				Error:            "The fetch phase failed: unable to fetch the bundle",
+				ConsecutiveCount: 2,
+			},
+		},
+		{
+			name: "the error of a failed reconciliation is truncated",
+			app: &kappctrlv1alpha1.App{
+				Status: kappctrlv1alpha1.AppStatus{
+					GenericStatus: kappctrlv1alpha1.GenericStatus{Conditions: reconcileFailed},
+					Fetch:         &kappctrlv1alpha1.AppStatusFetch{StartedAt: fetchStartedAt, UpdatedAt: fetchUpdatedAt},
+					Template:      &kappctrlv1alpha1.AppStatusTemplate{UpdatedAt: templateUpdatedAt},
+					Deploy:        &kappctrlv1alpha1.AppStatusDeploy{StartedAt: deployStartedAt, UpdatedAt: deployUpdatedAt, Finished: true, ExitCode: 1, Stderr: strings.Repeat("x", 1000)},
+				},
+			},
+			expected: &kappcorev1.ReconcileOutcome{
+				Version:    "1.2.3",
+				StartedAt:  "2023-05-04T09:59:00Z",
+				FinishedAt: "2023-05-04T09:59:40Z",
+				Duration:   "40s",
+				Error:      "The deploy phase failed: " + strings.Repeat("x", maxReconcileErrorLength-len("The deploy phase failed: ")-len(truncatedSuffix)) + truncatedSuffix,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			outcome, ok := buildReconcileOutcome(pkgInstall, tc.app)
+			if got, want := ok, tc.expected != nil; got != want {
+				t.Fatalf("got: %t, want: %t", got, want)
+			}
+			opts := cmpopts.IgnoreUnexported(kappcorev1.ReconcileOutcome{})
+			if got, want := outcome, tc.expected; !cmp.Equal(want, got, opts) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, opts))
+			}
+		})
+	}
+}
+
+func TestMergeReconcileOutcome(t *testing.T) {
+	outcome := func(startedAt string, succeeded bool) *kappcorev1.ReconcileOutcome {
+		return &kappcorev1.ReconcileOutcome{StartedAt: startedAt, Succeeded: succeeded}
+	}
+
+	testCases := []struct {
+		name            string
+		history         []*kappcorev1.ReconcileOutcome
+		outcome         *kappcorev1.ReconcileOutcome
+		limit           int
+		expectedHistory []*kappcorev1.ReconcileOutcome
+		expectedChanged bool
+	}{
+		{
+			name:            "it records the first outcome",
+			outcome:         outcome("2023-05-04T10:00:00Z", true),
+			limit:           3,
+			expectedHistory: []*kappcorev1.ReconcileOutcome{outcome("2023-05-04T10:00:00Z", true)},
+			expectedChanged: true,
+		},
+		{
+			name:            "it records a new outcome first, dropping the oldest ones beyond the limit",
+			history:         []*kappcorev1.ReconcileOutcome{outcome("2023-05-04T09:00:00Z", false), outcome("2023-05-04T08:00:00Z", true)},
+			outcome:         outcome("2023-05-04T10:00:00Z", true),
+			limit:           2,
+			expectedHistory: []*kappcorev1.ReconcileOutcome{outcome("2023-05-04T10:00:00Z", true), outcome("2023-05-04T09:00:00Z", false)},
+			expectedChanged: true,
+		},
+		{
+			name:            "it does not record an outcome already recorded",
+			history:         []*kappcorev1.ReconcileOutcome{outcome("2023-05-04T10:00:00Z", true)},
+			outcome:         outcome("2023-05-04T10:00:00Z", true),
+			limit:           3,
+			expectedHistory: []*kappcorev1.ReconcileOutcome{outcome("2023-05-04T10:00:00Z", true)},
+		},
+		{
+			name:            "it replaces an outcome recorded with different details",
+			history:         []*kappcorev1.ReconcileOutcome{outcome("2023-05-04T10:00:00Z", true), outcome("2023-05-04T09:00:00Z", true)},
+			outcome:         outcome("2023-05-04T10:00:00Z", false),
+			limit:           3,
+			expectedHistory: []*kappcorev1.ReconcileOutcome{outcome("2023-05-04T10:00:00Z", false), outcome("2023-05-04T09:00:00Z", true)},
+			expectedChanged: true,
+		},
+		{
+			name:            "it does not record an outcome older than the recorded ones",
+			history:         []*kappcorev1.ReconcileOutcome{outcome("2023-05-04T10:00:00Z", true)},
+			outcome:         outcome("2023-05-04T09:00:00Z", false),
+			limit:           3,
+			expectedHistory: []*kappcorev1.ReconcileOutcome{outcome("2023-05-04T10:00:00Z", true)},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			history, changed := mergeReconcileOutcome(tc.history, tc.outcome, tc.limit)
+			if got, want := changed, tc.expectedChanged; got != want {
+				t.Errorf("got: %t, want: %t", got, want)
+			}
+			opts := cmpopts.IgnoreUnexported(kappcorev1.ReconcileOutcome{})
+			if got, want := history, tc.expectedHistory; !cmp.Equal(want, got, opts) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, opts))
+			}
+		})
+	}
+}
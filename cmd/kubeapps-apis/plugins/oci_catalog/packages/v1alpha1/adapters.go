@@ -0,0 +1,125 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	kappctrlv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	corev1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/pkgutils"
+)
+
+// available packages
+
+func availablePackageRef(registry bundleRegistry, bundleName, cluster, namespace string) *corev1.AvailablePackageReference {
+	return &corev1.AvailablePackageReference{
+		Context:    &corev1.Context{Cluster: cluster, Namespace: namespace},
+		Identifier: registry.packageIdentifier(bundleName),
+		Plugin:     GetPluginDetail(),
+	}
+}
+
+func bundleShortDescription(registry bundleRegistry, bundleName string) string {
+	return fmt.Sprintf("Carvel imgpkg bundle from %s", registry.repository(bundleName))
+}
+
+func availablePackageSummary(registry bundleRegistry, bundleName, latestVersion, cluster, namespace string) *corev1.AvailablePackageSummary {
+	return &corev1.AvailablePackageSummary{
+		AvailablePackageRef: availablePackageRef(registry, bundleName, cluster, namespace),
+		Name:                bundleName,
+		DisplayName:         bundleName,
+		LatestVersion: &corev1.PackageAppVersion{
+			PkgVersion: latestVersion,
+			AppVersion: latestVersion,
+		},
+		ShortDescription: bundleShortDescription(registry, bundleName),
+		Categories:       []string{},
+	}
+}
+
+func availablePackageDetail(registry bundleRegistry, bundleName, version, cluster, namespace string) *corev1.AvailablePackageDetail {
+	image := registry.image(bundleName, version)
+	var readmeSB strings.Builder
+	readmeSB.WriteString(fmt.Sprintf("## %s\n\n", bundleName))
+	readmeSB.WriteString(fmt.Sprintf("Installs the Carvel imgpkg bundle `%s` with a kapp-controller App, ", image))
+	readmeSB.WriteString(fmt.Sprintf("templating the `%s` paths with ytt and kbld.\n\n", strings.Join(registry.templatePaths, "`, `")))
+	readmeSB.WriteString("The values provided are passed to ytt as data values.\n")
+
+	return &corev1.AvailablePackageDetail{
+		AvailablePackageRef: availablePackageRef(registry, bundleName, cluster, namespace),
+		Name:                bundleName,
+		DisplayName:         bundleName,
+		Version: &corev1.PackageAppVersion{
+			PkgVersion: version,
+			AppVersion: version,
+		},
+		RepoUrl:          registry.url(),
+		ShortDescription: bundleShortDescription(registry, bundleName),
+		Readme:           readmeSB.String(),
+		Categories:       []string{},
+	}
+}
+
+// installed packages
+
+func installedPackageRef(app *kappctrlv1alpha1.App, cluster string) *corev1.InstalledPackageReference {
+	return &corev1.InstalledPackageReference{
+		Context:    &corev1.Context{Cluster: cluster, Namespace: app.Namespace},
+		Identifier: app.Name,
+		Plugin:     GetPluginDetail(),
+	}
+}
+
+// appBundleName returns the name of the bundle installed by the App.
+func appBundleName(app *kappctrlv1alpha1.App) string {
+	_, bundleName, _ := strings.Cut(app.Annotations[annotationPackageKey], "/")
+	return bundleName
+}
+
+func installedPackageSummary(app *kappctrlv1alpha1.App, cluster string) *corev1.InstalledPackageSummary {
+	version := appBundleVersion(app)
+	return &corev1.InstalledPackageSummary{
+		InstalledPackageRef: installedPackageRef(app, cluster),
+		Name:                app.Name,
+		PkgVersionReference: &corev1.VersionReference{Version: version},
+		CurrentVersion: &corev1.PackageAppVersion{
+			PkgVersion: version,
+			AppVersion: version,
+		},
+		PkgDisplayName:   appBundleName(app),
+		ShortDescription: fmt.Sprintf("Carvel imgpkg bundle %s", appBundleImage(app)),
+		Status:           appStatus(app),
+	}
+}
+
+func installedPackageDetail(app *kappctrlv1alpha1.App, values, cluster string) *corev1.InstalledPackageDetail {
+	version := appBundleVersion(app)
+	detail := &corev1.InstalledPackageDetail{
+		InstalledPackageRef: installedPackageRef(app, cluster),
+		PkgVersionReference: &corev1.VersionReference{Version: version},
+		Name:                app.Name,
+		CurrentVersion: &corev1.PackageAppVersion{
+			PkgVersion: version,
+			AppVersion: version,
+		},
+		ValuesApplied: values,
+		ReconciliationOptions: &corev1.ReconciliationOptions{
+			ServiceAccountName: app.Spec.ServiceAccountName,
+			Suspend:            app.Spec.Paused,
+		},
+		Status: appStatus(app),
+		AvailablePackageRef: &corev1.AvailablePackageReference{
+			// Bundles are available in every namespace.
+			Context:    &corev1.Context{Cluster: cluster, Namespace: app.Namespace},
+			Identifier: app.Annotations[annotationPackageKey],
+			Plugin:     GetPluginDetail(),
+		},
+	}
+	if app.Spec.SyncPeriod != nil {
+		detail.ReconciliationOptions.Interval = pkgutils.FromDuration(app.Spec.SyncPeriod)
+	}
+	return detail
+}
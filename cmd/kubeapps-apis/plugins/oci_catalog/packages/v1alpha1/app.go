@@ -0,0 +1,412 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	kappctrlv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	corev1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/pkgutils"
+	k8scorev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	log "k8s.io/klog/v2"
+)
+
+const (
+	appResource  = "App"
+	appsResource = "apps"
+
+	annotationManagedByKey   = "kubeapps.dev/managed-by"
+	annotationManagedByValue = "plugin:oci-catalog"
+
+	// annotationPackageKey records, in an App, the identifier of the
+	// available package it was installed from.
+	annotationPackageKey = "kubeapps.dev/oci-catalog-package"
+
+	valuesSecretKey = "values.yaml"
+)
+
+// appOptions are the options of an App installing a bundle.
+type appOptions struct {
+	name                  string
+	namespace             string
+	identifier            string
+	image                 string
+	registry              bundleRegistry
+	valuesSecretName      string
+	reconciliationOptions *corev1.ReconciliationOptions
+}
+
+// newApp returns a kapp-controller App fetching the imgpkg bundle, templating
+// it with ytt and kbld, and deploying it with kapp.
+// See https://carvel.dev/kapp-controller/docs/latest/app-spec/
+func newApp(opts appOptions) (*kappctrlv1alpha1.App, error) {
+	app := &kappctrlv1alpha1.App{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       appResource,
+			APIVersion: kappctrlv1alpha1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      opts.name,
+			Namespace: opts.namespace,
+			Annotations: map[string]string{
+				annotationManagedByKey: annotationManagedByValue,
+				annotationPackageKey:   opts.identifier,
+			},
+		},
+		Spec: kappctrlv1alpha1.AppSpec{
+			Fetch: []kappctrlv1alpha1.AppFetch{{
+				ImgpkgBundle: &kappctrlv1alpha1.AppFetchImgpkgBundle{Image: opts.image},
+			}},
+			Template: []kappctrlv1alpha1.AppTemplate{
+				{Ytt: &kappctrlv1alpha1.AppTemplateYtt{Paths: opts.registry.templatePaths}},
+				// Resolve the images to the ones relocated with the bundle.
+				{Kbld: &kappctrlv1alpha1.AppTemplateKbld{Paths: []string{"-", ".imgpkg/images.yml"}}},
+			},
+			Deploy: []kappctrlv1alpha1.AppDeploy{{Kapp: &kappctrlv1alpha1.AppDeployKapp{}}},
+		},
+	}
+	if opts.registry.secretName != "" {
+		app.Spec.Fetch[0].ImgpkgBundle.SecretRef = &kappctrlv1alpha1.AppFetchLocalRef{Name: opts.registry.secretName}
+	}
+	if opts.valuesSecretName != "" {
+		app.Spec.Template[0].Ytt.ValuesFrom = []kappctrlv1alpha1.AppTemplateValuesSource{{
+			SecretRef: &kappctrlv1alpha1.AppTemplateValuesSourceRef{Name: opts.valuesSecretName},
+		}}
+	}
+	if err := setAppReconciliationOptions(app, opts.reconciliationOptions); err != nil {
+		return nil, err
+	}
+	return app, nil
+}
+
+// setAppReconciliationOptions updates the App with the given reconciliation
+// options, keeping the current service account when none is given.
+func setAppReconciliationOptions(app *kappctrlv1alpha1.App, reconciliationOptions *corev1.ReconciliationOptions) error {
+	if reconciliationOptions == nil {
+		return nil
+	}
+	if serviceAccountName := reconciliationOptions.GetServiceAccountName(); serviceAccountName != "" {
+		app.Spec.ServiceAccountName = serviceAccountName
+	}
+	app.Spec.SyncPeriod = nil
+	if reconciliationOptions.GetInterval() != "" {
+		syncPeriod, err := pkgutils.ToDuration(reconciliationOptions.GetInterval())
+		if err != nil {
+			return err
+		}
+		app.Spec.SyncPeriod = syncPeriod
+	}
+	app.Spec.Paused = reconciliationOptions.GetSuspend()
+	return nil
+}
+
+// isManagedApp returns whether the App was created by this plugin.
+func isManagedApp(app *kappctrlv1alpha1.App) bool {
+	return app.Annotations[annotationManagedByKey] == annotationManagedByValue
+}
+
+// appBundleImage returns the image of the bundle fetched by the App.
+func appBundleImage(app *kappctrlv1alpha1.App) string {
+	for _, fetch := range app.Spec.Fetch {
+		if fetch.ImgpkgBundle != nil {
+			return fetch.ImgpkgBundle.Image
+		}
+	}
+	return ""
+}
+
+// appBundleVersion returns the version, that is, the tag, of the bundle
+// fetched by the App.
+func appBundleVersion(app *kappctrlv1alpha1.App) string {
+	// Images pinned to a digest have no tag.
+	image, _, _ := strings.Cut(appBundleImage(app), "@")
+	// The tag follows the last colon unless it is part of the registry port.
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		return image[i+1:]
+	}
+	return ""
+}
+
+// setAppBundleImage updates the image of the bundle fetched by the App.
+func setAppBundleImage(app *kappctrlv1alpha1.App, image string) {
+	for _, fetch := range app.Spec.Fetch {
+		if fetch.ImgpkgBundle != nil {
+			fetch.ImgpkgBundle.Image = image
+		}
+	}
+}
+
+// appValuesSecretName returns the name of the Secret providing the values of
+// the App, if any.
+func appValuesSecretName(app *kappctrlv1alpha1.App) string {
+	for _, template := range app.Spec.Template {
+		if template.Ytt == nil {
+			continue
+		}
+		for _, valuesFrom := range template.Ytt.ValuesFrom {
+			if valuesFrom.SecretRef != nil {
+				return valuesFrom.SecretRef.Name
+			}
+		}
+	}
+	return ""
+}
+
+// setAppValuesSecretName updates the Secret providing the values of the App,
+// removing it when the name is empty.
+func setAppValuesSecretName(app *kappctrlv1alpha1.App, name string) {
+	for _, template := range app.Spec.Template {
+		if template.Ytt == nil {
+			continue
+		}
+		template.Ytt.ValuesFrom = nil
+		if name != "" {
+			template.Ytt.ValuesFrom = []kappctrlv1alpha1.AppTemplateValuesSource{{
+				SecretRef: &kappctrlv1alpha1.AppTemplateValuesSourceRef{Name: name},
+			}}
+		}
+		return
+	}
+}
+
+// valuesSecretNameForApp returns the name of the Secret created for the
+// values of an App.
+func valuesSecretNameForApp(appName string) string {
+	return appName + "-values"
+}
+
+// newValuesSecret returns the Secret providing the values to an App.
+func newValuesSecret(name, namespace, values string) *k8scorev1.Secret {
+	return &k8scorev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Secret",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Annotations: map[string]string{
+				annotationManagedByKey: annotationManagedByValue,
+			},
+		},
+		Type: k8scorev1.SecretTypeOpaque,
+		StringData: map[string]string{
+			valuesSecretKey: values,
+		},
+	}
+}
+
+// appStatus returns the status of the installed package reconciled by the App.
+func appStatus(app *kappctrlv1alpha1.App) *corev1.InstalledPackageStatus {
+	if len(app.Status.Conditions) == 0 {
+		return &corev1.InstalledPackageStatus{
+			Reason:     corev1.InstalledPackageStatus_STATUS_REASON_PENDING,
+			UserReason: "No status information yet",
+		}
+	}
+	status := &corev1.InstalledPackageStatus{}
+	switch app.Status.Conditions[0].Type {
+	case kappctrlv1alpha1.ReconcileSucceeded:
+		status.Ready = true
+		status.Reason = corev1.InstalledPackageStatus_STATUS_REASON_INSTALLED
+		status.UserReason = "Deployed"
+	case kappctrlv1alpha1.ReconcileFailed:
+		status.Reason = corev1.InstalledPackageStatus_STATUS_REASON_FAILED
+		status.UserReason = "Reconcile failed"
+		if app.Status.UsefulErrorMessage != "" {
+			status.UserReason = app.Status.UsefulErrorMessage
+		}
+	case kappctrlv1alpha1.Reconciling:
+		status.Reason = corev1.InstalledPackageStatus_STATUS_REASON_PENDING
+		status.UserReason = "Reconciling"
+	default:
+		status.Reason = corev1.InstalledPackageStatus_STATUS_REASON_UNSPECIFIED
+		status.UserReason = "Unknown"
+	}
+	return status
+}
+
+// See https://carvel.dev/kapp-controller/docs/latest/app-spec/
+func (s *Server) getAppResource(headers http.Header, cluster, namespace string) (dynamic.ResourceInterface, error) {
+	dynClient, err := s.clientGetter.Dynamic(headers, cluster)
+	if err != nil {
+		return nil, err
+	}
+	gvr := schema.GroupVersionResource{
+		Group:    kappctrlv1alpha1.SchemeGroupVersion.Group,
+		Version:  kappctrlv1alpha1.SchemeGroupVersion.Version,
+		Resource: appsResource}
+	return dynClient.Resource(gvr).Namespace(namespace), nil
+}
+
+// getApp returns the App for the given cluster, namespace and name.
+func (s *Server) getApp(ctx context.Context, headers http.Header, cluster, namespace, name string) (*kappctrlv1alpha1.App, error) {
+	resource, err := s.getAppResource(headers, cluster, namespace)
+	if err != nil {
+		return nil, err
+	}
+	unstructuredApp, err := resource.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	app := &kappctrlv1alpha1.App{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(unstructuredApp.Object, app); err != nil {
+		return nil, err
+	}
+	return app, nil
+}
+
+// getManagedApp returns the App for the given cluster, namespace and name,
+// returning a NotFound error if it was not created by this plugin.
+func (s *Server) getManagedApp(ctx context.Context, headers http.Header, cluster, namespace, name string) (*kappctrlv1alpha1.App, error) {
+	app, err := s.getApp(ctx, headers, cluster, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	if !isManagedApp(app) {
+		return nil, errors.NewNotFound(kappctrlv1alpha1.SchemeGroupVersion.WithResource(appsResource).GroupResource(), name)
+	}
+	return app, nil
+}
+
+// getManagedApps returns the Apps created by this plugin for the given
+// cluster and namespace, matching the label selector if not empty.
+func (s *Server) getManagedApps(ctx context.Context, headers http.Header, cluster, namespace, labelSelector string) ([]*kappctrlv1alpha1.App, error) {
+	resource, err := s.getAppResource(headers, cluster, namespace)
+	if err != nil {
+		return nil, err
+	}
+	unstructuredApps, err := resource.List(ctx, metav1.ListOptions{LabelSelector: labelSelector})
+	if err != nil {
+		return nil, err
+	}
+	apps := []*kappctrlv1alpha1.App{}
+	for _, unstructuredApp := range unstructuredApps.Items {
+		app := &kappctrlv1alpha1.App{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(unstructuredApp.Object, app); err != nil {
+			return nil, err
+		}
+		if isManagedApp(app) {
+			apps = append(apps, app)
+		}
+	}
+	return apps, nil
+}
+
+// createApp creates the App in its namespace of the given cluster.
+func (s *Server) createApp(ctx context.Context, headers http.Header, cluster string, app *kappctrlv1alpha1.App) error {
+	resource, err := s.getAppResource(headers, cluster, app.Namespace)
+	if err != nil {
+		return err
+	}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(app)
+	if err != nil {
+		return err
+	}
+	_, err = resource.Create(ctx, &unstructured.Unstructured{Object: content}, metav1.CreateOptions{})
+	return err
+}
+
+// updateApp updates the App in its namespace of the given cluster.
+func (s *Server) updateApp(ctx context.Context, headers http.Header, cluster string, app *kappctrlv1alpha1.App) error {
+	resource, err := s.getAppResource(headers, cluster, app.Namespace)
+	if err != nil {
+		return err
+	}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(app)
+	if err != nil {
+		return err
+	}
+	_, err = resource.Update(ctx, &unstructured.Unstructured{Object: content}, metav1.UpdateOptions{})
+	return err
+}
+
+// deleteApp deletes the App for the given cluster, namespace and name.
+func (s *Server) deleteApp(ctx context.Context, headers http.Header, cluster, namespace, name string) error {
+	resource, err := s.getAppResource(headers, cluster, namespace)
+	if err != nil {
+		return err
+	}
+	return resource.Delete(ctx, name, metav1.DeleteOptions{})
+}
+
+// getValues returns the values stored in the Secret of an App.
+func (s *Server) getValues(ctx context.Context, headers http.Header, cluster, namespace, name string) (string, error) {
+	typedClient, err := s.clientGetter.Typed(headers, cluster)
+	if err != nil {
+		return "", err
+	}
+	secret, err := typedClient.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	if values, ok := secret.StringData[valuesSecretKey]; ok {
+		return values, nil
+	}
+	return string(secret.Data[valuesSecretKey]), nil
+}
+
+// applyValuesSecret creates or updates the Secret providing the values to an App.
+func (s *Server) applyValuesSecret(ctx context.Context, headers http.Header, cluster string, secret *k8scorev1.Secret) error {
+	typedClient, err := s.clientGetter.Typed(headers, cluster)
+	if err != nil {
+		return err
+	}
+	secrets := typedClient.CoreV1().Secrets(secret.Namespace)
+	_, err = secrets.Update(ctx, secret, metav1.UpdateOptions{})
+	if errors.IsNotFound(err) {
+		_, err = secrets.Create(ctx, secret, metav1.CreateOptions{})
+	}
+	return err
+}
+
+// valuesSecretRestorer returns a function restoring the current state of the
+// values Secret of an App, deleting it if it does not exist yet. Failures to
+// restore it are only logged, as the error of the operation is returned.
+func (s *Server) valuesSecretRestorer(ctx context.Context, headers http.Header, cluster, namespace, name string) (func(), error) {
+	typedClient, err := s.clientGetter.Typed(headers, cluster)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := typedClient.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return func() {
+			if err := s.deleteValuesSecret(ctx, headers, cluster, namespace, name); err != nil {
+				log.Errorf("+oci-catalog unable to clean up the values Secret %q: %v", name, err)
+			}
+		}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return func() {
+		// The restored Secret is updated regardless of its resource version.
+		secret.ResourceVersion = ""
+		if err := s.applyValuesSecret(ctx, headers, cluster, secret); err != nil {
+			log.Errorf("+oci-catalog unable to restore the values Secret %q: %v", name, err)
+		}
+	}, nil
+}
+
+// deleteValuesSecret deletes the Secret providing the values to an App, if
+// it exists.
+func (s *Server) deleteValuesSecret(ctx context.Context, headers http.Header, cluster, namespace, name string) error {
+	typedClient, err := s.clientGetter.Typed(headers, cluster)
+	if err != nil {
+		return err
+	}
+	err = typedClient.CoreV1().Secrets(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,262 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/bufbuild/connect-go"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	ocicatalog "github.com/vmware-tanzu/kubeapps/cmd/oci-catalog/gen/catalog/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/pkg/ocicatalog_client"
+)
+
+type (
+	ociCatalogPluginConfig struct {
+		OCICatalog struct {
+			Packages struct {
+				V1alpha1 struct {
+					Registries []registryConfig `json:"registries"`
+				} `json:"v1alpha1"`
+			} `json:"packages"`
+		} `json:"ociCatalog"`
+	}
+
+	// registryConfig configures an OCI registry namespace whose imgpkg
+	// bundles are listed as available packages.
+	registryConfig struct {
+		// Name identifies the registry in the package identifiers.
+		Name string `json:"name"`
+		// URL of the registry namespace, such as oci://ghcr.io/my-org/bundles.
+		URL string `json:"url"`
+		// SecretName is the optional docker config Secret, in the namespace
+		// of each installation, used by kapp-controller to fetch the bundles.
+		SecretName string `json:"secretName"`
+		// TemplatePaths are the paths of the bundle templated with ytt.
+		TemplatePaths []string `json:"templatePaths"`
+	}
+
+	// bundleRegistry is a validated registryConfig.
+	bundleRegistry struct {
+		name          string
+		host          string
+		namespace     string
+		secretName    string
+		templatePaths []string
+	}
+)
+
+// fallbackTemplatePaths are the paths templated when not configured, which
+// follow the layout of the bundles created with kctrl.
+var fallbackTemplatePaths = []string{"config/"}
+
+// parsePluginConfig parses the input plugin configuration json file and
+// returns the configured registries.
+func parsePluginConfig(pluginConfigPath string) ([]bundleRegistry, error) {
+	// #nosec G304
+	pluginConfigFile, err := os.ReadFile(pluginConfigPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open plugin config at %q: %w", pluginConfigPath, err)
+	}
+	var pluginConfig ociCatalogPluginConfig
+	err = json.Unmarshal([]byte(pluginConfigFile), &pluginConfig)
+	if err != nil {
+		return nil, fmt.Errorf("unable to unmarshal pluginconfig: %q error: %w", string(pluginConfigFile), err)
+	}
+	return newBundleRegistries(pluginConfig.OCICatalog.Packages.V1alpha1.Registries)
+}
+
+// newBundleRegistries validates the configured registries.
+func newBundleRegistries(configs []registryConfig) ([]bundleRegistry, error) {
+	registries := []bundleRegistry{}
+	names := map[string]bool{}
+	for _, config := range configs {
+		if config.Name == "" || strings.Contains(config.Name, "/") {
+			return nil, fmt.Errorf("invalid registry name %q", config.Name)
+		}
+		if names[config.Name] {
+			return nil, fmt.Errorf("duplicate registry name %q", config.Name)
+		}
+		names[config.Name] = true
+
+		registryURL, err := url.Parse(config.URL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid URL for registry %q: %w", config.Name, err)
+		}
+		if registryURL.Scheme != "oci" || registryURL.Host == "" {
+			return nil, fmt.Errorf("invalid URL for registry %q: %q is not an oci:// URL", config.Name, config.URL)
+		}
+
+		templatePaths := config.TemplatePaths
+		if len(templatePaths) == 0 {
+			templatePaths = fallbackTemplatePaths
+		}
+		registries = append(registries, bundleRegistry{
+			name:          config.Name,
+			host:          registryURL.Host,
+			namespace:     strings.Trim(registryURL.Path, "/"),
+			secretName:    config.SecretName,
+			templatePaths: templatePaths,
+		})
+	}
+	return registries, nil
+}
+
+// url returns the URL of the registry namespace.
+func (r bundleRegistry) url() string {
+	return "oci://" + path.Join(r.host, r.namespace)
+}
+
+// repository returns the OCI repository of a bundle of the registry.
+func (r bundleRegistry) repository(bundleName string) string {
+	return path.Join(r.host, r.namespace, bundleName)
+}
+
+// image returns the image reference of a version of a bundle of the registry.
+func (r bundleRegistry) image(bundleName, version string) string {
+	return r.repository(bundleName) + ":" + version
+}
+
+// packageIdentifier returns the identifier of the available package for a
+// bundle of the registry.
+func (r bundleRegistry) packageIdentifier(bundleName string) string {
+	return r.name + "/" + bundleName
+}
+
+// registryForIdentifier returns the registry and the bundle name of an
+// available package identifier.
+func (s *Server) registryForIdentifier(identifier string) (bundleRegistry, string, error) {
+	registryName, bundleName, found := strings.Cut(identifier, "/")
+	if !found || bundleName == "" {
+		return bundleRegistry{}, "", connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Invalid package identifier %q", identifier))
+	}
+	for _, registry := range s.registries {
+		if registry.name == registryName {
+			return registry, bundleName, nil
+		}
+	}
+	return bundleRegistry{}, "", connect.NewError(connect.CodeNotFound, fmt.Errorf("Unable to find the registry %q", registryName))
+}
+
+// newCatalogClient returns a client for the OCI catalog service, together
+// with the function closing its connection.
+func (s *Server) newCatalogClient() (ocicatalog.OCICatalogServiceClient, func(), error) {
+	if s.ociCatalogAddr == "" {
+		return nil, nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("The OCI catalog service is not configured"))
+	}
+	client, closer, err := ocicatalog_client.NewClient(s.ociCatalogAddr)
+	if err != nil {
+		return nil, nil, connect.NewError(connect.CodeUnavailable, err)
+	}
+	return client, closer, nil
+}
+
+// imgpkgBundleLabel is the label of the image config identifying an imgpkg
+// bundle, which otherwise has the media types of an image.
+const imgpkgBundleLabel = "dev.carvel.imgpkg.bundle"
+
+// bundleChecker reports whether an image is an imgpkg bundle.
+type bundleChecker func(ctx context.Context, image string) (bool, error)
+
+// isImgpkgBundle reports whether the image is an imgpkg bundle: an image
+// manifest whose config is labelled as a bundle.
+func isImgpkgBundle(ctx context.Context, image string) (bool, error) {
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return false, err
+	}
+	desc, err := remote.Get(ref, remote.WithContext(ctx))
+	if err != nil {
+		return false, err
+	}
+	if !desc.MediaType.IsImage() {
+		return false, nil
+	}
+	img, err := desc.Image()
+	if err != nil {
+		return false, err
+	}
+	config, err := img.ConfigFile()
+	if err != nil {
+		return false, err
+	}
+	_, ok := config.Config.Labels[imgpkgBundleLabel]
+	return ok, nil
+}
+
+// listBundleNames returns the names of the image repositories of the
+// registry namespace, which include the imgpkg bundles. The catalog only
+// tells apart the images from other artifacts, such as charts, so the
+// bundles are identified with a bundleChecker.
+func listBundleNames(ctx context.Context, client ocicatalog.OCICatalogServiceClient, registry bundleRegistry) ([]string, error) {
+	stream, err := client.ListRepositoriesForRegistry(ctx, &ocicatalog.ListRepositoriesForRegistryRequest{
+		Registry:     registry.host,
+		Namespace:    registry.namespace,
+		ContentTypes: []string{ocicatalog_client.CONTENT_TYPE_IMAGE},
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnavailable, fmt.Errorf("Unable to list the repositories of the registry %q: %w", registry.name, err))
+	}
+	names := []string{}
+	for {
+		repo, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, connect.NewError(connect.CodeUnavailable, fmt.Errorf("Unable to list the repositories of the registry %q: %w", registry.name, err))
+		}
+		names = append(names, repo.GetName())
+	}
+	return names, nil
+}
+
+// listBundleVersions returns the tags of a bundle which are valid semantic
+// versions, from the most recent.
+func listBundleVersions(ctx context.Context, client ocicatalog.OCICatalogServiceClient, registry bundleRegistry, bundleName string) ([]string, error) {
+	stream, err := client.ListTagsForRepository(ctx, &ocicatalog.ListTagsForRepositoryRequest{
+		Repository: &ocicatalog.Repository{
+			Registry:  registry.host,
+			Namespace: registry.namespace,
+			Name:      bundleName,
+		},
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnavailable, fmt.Errorf("Unable to list the tags of the bundle %q: %w", bundleName, err))
+	}
+	versions := semver.Collection{}
+	for {
+		tag, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, connect.NewError(connect.CodeUnavailable, fmt.Errorf("Unable to list the tags of the bundle %q: %w", bundleName, err))
+		}
+		// Tags such as "latest" cannot be ordered and are not listed.
+		version, err := semver.NewVersion(tag.GetName())
+		if err != nil {
+			continue
+		}
+		versions = append(versions, version)
+	}
+	sort.Sort(sort.Reverse(versions))
+
+	tags := make([]string, len(versions))
+	for i, version := range versions {
+		tags[i] = version.Original()
+	}
+	return tags, nil
+}
@@ -0,0 +1,594 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core"
+	corev1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	corev1connect "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1/v1alpha1connect"
+	packagesConnect "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/plugins/oci_catalog/packages/v1alpha1/v1alpha1connect"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/clientgetter"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/connecterror"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/paginate"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/pkgutils"
+	"k8s.io/apimachinery/pkg/api/errors"
+	log "k8s.io/klog/v2"
+)
+
+// Compile-time statement to ensure this service implementation satisfies the core packaging API
+var _ corev1connect.PackagesServiceHandler = (*Server)(nil)
+
+// Server implements the OCI catalog packages v1alpha1 interface, listing the
+// Carvel imgpkg bundles of the configured OCI registry namespaces and
+// installing them as kapp-controller Apps.
+type Server struct {
+	packagesConnect.UnimplementedOciCatalogPackagesServiceHandler
+	// clientGetter is a field so that it can be switched in tests for
+	// a fake client. NewServer() below sets this automatically with the
+	// non-test implementation.
+	clientGetter clientgetter.ClientProviderInterface
+	// kubeappsCluster is the cluster assumed when none is requested.
+	kubeappsCluster string
+	// ociCatalogAddr is the address of the OCI catalog service used to list
+	// the bundles of the registries and their tags.
+	ociCatalogAddr string
+	registries     []bundleRegistry
+	// isBundle is a field so that it can be switched in tests, as it
+	// fetches the images from their registry.
+	isBundle bundleChecker
+}
+
+// NewServer returns a Server automatically configured with a function to obtain
+// the k8s client config.
+func NewServer(configGetter core.KubernetesConfigGetter, kubeappsCluster, pluginConfigPath string) *Server {
+	registries := []bundleRegistry{}
+	if pluginConfigPath != "" {
+		var err error
+		registries, err = parsePluginConfig(pluginConfigPath)
+		if err != nil {
+			log.Fatalf("%s", err)
+		}
+		log.InfoS("+oci-catalog using custom config", "registries", len(registries))
+	} else {
+		log.Info("+oci-catalog using default config since pluginConfigPath is empty")
+	}
+
+	clientProvider, err := clientgetter.NewClientProvider(configGetter, clientgetter.Options{})
+	if err != nil {
+		log.Fatalf("%s", err)
+	}
+
+	return &Server{
+		clientGetter:    clientProvider,
+		kubeappsCluster: kubeappsCluster,
+		ociCatalogAddr:  os.Getenv("OCI_CATALOG_URL"),
+		registries:      registries,
+		isBundle:        isImgpkgBundle,
+	}
+}
+
+// GetAvailablePackageSummaries returns the bundles of the configured registries.
+func (s *Server) GetAvailablePackageSummaries(ctx context.Context, request *connect.Request[corev1.GetAvailablePackageSummariesRequest]) (*connect.Response[corev1.GetAvailablePackageSummariesResponse], error) {
+	cluster := request.Msg.GetContext().GetCluster()
+	namespace := request.Msg.GetContext().GetNamespace()
+	log.InfoS("+oci-catalog GetAvailablePackageSummaries", "cluster", cluster, "namespace", namespace)
+
+	pageSize := int(request.Msg.GetPaginationOptions().GetPageSize())
+	itemOffset, err := paginate.ItemOffsetFromPageToken(request.Msg.GetPaginationOptions().GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+	if cluster == "" {
+		cluster = s.kubeappsCluster
+	}
+
+	client, closer, err := s.newCatalogClient()
+	if err != nil {
+		return nil, err
+	}
+	defer closer()
+
+	type bundle struct {
+		registry bundleRegistry
+		name     string
+	}
+	filterOptions := request.Msg.GetFilterOptions()
+	query := strings.ToLower(filterOptions.GetQuery())
+	bundles := []bundle{}
+	// Bundles have neither categories nor providers, so none matches a
+	// filter on them.
+	if len(filterOptions.GetCategories()) > 0 || len(filterOptions.GetProviders()) > 0 {
+		return connect.NewResponse(&corev1.GetAvailablePackageSummariesResponse{
+			AvailablePackageSummaries: []*corev1.AvailablePackageSummary{},
+			Categories:                []string{},
+		}), nil
+	}
+	for _, registry := range s.registries {
+		if len(filterOptions.GetRepositories()) > 0 && !contains(filterOptions.GetRepositories(), registry.name) {
+			continue
+		}
+		names, err := listBundleNames(ctx, client, registry)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range names {
+			if query == "" || strings.Contains(strings.ToLower(name), query) {
+				bundles = append(bundles, bundle{registry: registry, name: name})
+			}
+		}
+	}
+
+	// Only the bundles of the requested page have their tags listed.
+	nextPageToken := ""
+	if pageSize > 0 {
+		if itemOffset > len(bundles) {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Invalid pagination arguments %v", request.Msg.GetPaginationOptions()))
+		}
+		bundles = bundles[itemOffset:]
+		if len(bundles) > pageSize {
+			bundles = bundles[:pageSize]
+			nextPageToken = fmt.Sprintf("%d", itemOffset+pageSize)
+		}
+	}
+
+	summaries := []*corev1.AvailablePackageSummary{}
+	for _, b := range bundles {
+		versions, err := listBundleVersions(ctx, client, b.registry, b.name)
+		if err != nil {
+			return nil, err
+		}
+		if len(versions) == 0 {
+			log.InfoS("+oci-catalog ignoring bundle without versions", "registry", b.registry.name, "bundle", b.name)
+			continue
+		}
+		if ok, err := s.isBundle(ctx, b.registry.image(b.name, versions[0])); err != nil || !ok {
+			log.InfoS("+oci-catalog ignoring image which is not an imgpkg bundle", "registry", b.registry.name, "bundle", b.name, "err", err)
+			continue
+		}
+		summaries = append(summaries, availablePackageSummary(b.registry, b.name, versions[0], cluster, namespace))
+	}
+
+	return connect.NewResponse(&corev1.GetAvailablePackageSummariesResponse{
+		AvailablePackageSummaries: summaries,
+		Categories:                []string{},
+		NextPageToken:             nextPageToken,
+	}), nil
+}
+
+// GetAvailablePackageDetail returns the details of a version of a bundle,
+// the most recent one if none is requested.
+func (s *Server) GetAvailablePackageDetail(ctx context.Context, request *connect.Request[corev1.GetAvailablePackageDetailRequest]) (*connect.Response[corev1.GetAvailablePackageDetailResponse], error) {
+	packageRef := request.Msg.GetAvailablePackageRef()
+	log.InfoS("+oci-catalog GetAvailablePackageDetail", "cluster", packageRef.GetContext().GetCluster(), "namespace", packageRef.GetContext().GetNamespace(), "id", packageRef.GetIdentifier())
+
+	registry, bundleName, err := s.registryForIdentifier(packageRef.GetIdentifier())
+	if err != nil {
+		return nil, err
+	}
+	versions, err := s.bundleVersions(ctx, registry, bundleName)
+	if err != nil {
+		return nil, err
+	}
+	version, err := resolveVersion(versions, request.Msg.GetPkgVersion(), packageRef.GetIdentifier())
+	if err != nil {
+		return nil, err
+	}
+
+	cluster := packageRef.GetContext().GetCluster()
+	if cluster == "" {
+		cluster = s.kubeappsCluster
+	}
+	return connect.NewResponse(&corev1.GetAvailablePackageDetailResponse{
+		AvailablePackageDetail: availablePackageDetail(registry, bundleName, version, cluster, packageRef.GetContext().GetNamespace()),
+	}), nil
+}
+
+// GetAvailablePackageVersions returns the versions of a bundle, from the most
+// recent.
+func (s *Server) GetAvailablePackageVersions(ctx context.Context, request *connect.Request[corev1.GetAvailablePackageVersionsRequest]) (*connect.Response[corev1.GetAvailablePackageVersionsResponse], error) {
+	packageRef := request.Msg.GetAvailablePackageRef()
+	log.InfoS("+oci-catalog GetAvailablePackageVersions", "cluster", packageRef.GetContext().GetCluster(), "namespace", packageRef.GetContext().GetNamespace(), "id", packageRef.GetIdentifier())
+
+	registry, bundleName, err := s.registryForIdentifier(packageRef.GetIdentifier())
+	if err != nil {
+		return nil, err
+	}
+	versions, err := s.bundleVersions(ctx, registry, bundleName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Bundles have no version other than their tag.
+	appVersions := make([]*corev1.PackageAppVersion, len(versions))
+	for i, version := range versions {
+		appVersions[i] = &corev1.PackageAppVersion{PkgVersion: version, AppVersion: version}
+	}
+	if err := pkgutils.SetUpgradeImpacts(appVersions, nil, request.Msg.GetRelativeToVersion()); err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&corev1.GetAvailablePackageVersionsResponse{
+		PackageAppVersions: appVersions,
+	}), nil
+}
+
+// GetAvailablePackageTextAsset returns a range of a text asset of a bundle.
+func (s *Server) GetAvailablePackageTextAsset(ctx context.Context, request *connect.Request[corev1.GetAvailablePackageTextAssetRequest]) (*connect.Response[corev1.GetAvailablePackageTextAssetResponse], error) {
+	return pkgutils.GetAvailablePackageTextAsset(ctx, request, s.GetAvailablePackageDetail)
+}
+
+// GetAvailablePackageFilters returns no filters, since bundles have neither
+// categories nor providers.
+func (s *Server) GetAvailablePackageFilters(ctx context.Context, request *connect.Request[corev1.GetAvailablePackageFiltersRequest]) (*connect.Response[corev1.GetAvailablePackageFiltersResponse], error) {
+	return connect.NewResponse(&corev1.GetAvailablePackageFiltersResponse{
+		Categories: []string{},
+		Providers:  []string{},
+	}), nil
+}
+
+// SupportsResourceRefs returns false as the plugin does not track the
+// resources of the bundles it installs.
+func (s *Server) SupportsResourceRefs() bool {
+	return false
+}
+
+// GetInstalledPackageSummaries returns the bundles installed by this plugin.
+func (s *Server) GetInstalledPackageSummaries(ctx context.Context, request *connect.Request[corev1.GetInstalledPackageSummariesRequest]) (*connect.Response[corev1.GetInstalledPackageSummariesResponse], error) {
+	cluster := request.Msg.GetContext().GetCluster()
+	namespace := request.Msg.GetContext().GetNamespace()
+	log.InfoS("+oci-catalog GetInstalledPackageSummaries", "cluster", cluster, "namespace", namespace)
+
+	pageSize := int(request.Msg.GetPaginationOptions().GetPageSize())
+	itemOffset, err := paginate.ItemOffsetFromPageToken(request.Msg.GetPaginationOptions().GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+	if cluster == "" {
+		cluster = s.kubeappsCluster
+	}
+
+	filterOptions := request.Msg.GetFilterOptions()
+	selector, err := pkgutils.InstalledPackageLabelSelector(filterOptions)
+	if err != nil {
+		return nil, err
+	}
+	apps, err := s.getManagedApps(ctx, request.Header(), cluster, namespace, selector.String())
+	if err != nil {
+		return nil, connecterror.FromK8sError("get", "App", "", err)
+	}
+
+	// The summaries are built before paginating, as they are filtered by
+	// status. Since no newer bundle version is looked up, none of them is
+	// pending an upgrade.
+	summaries := make([]*corev1.InstalledPackageSummary, len(apps))
+	for i, app := range apps {
+		summaries[i] = installedPackageSummary(app, cluster)
+	}
+	summaries = pkgutils.FilterInstalledPackageSummaries(summaries, filterOptions)
+
+	nextPageToken := ""
+	if pageSize > 0 {
+		if itemOffset > len(summaries) {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Invalid pagination arguments %v", request.Msg.GetPaginationOptions()))
+		}
+		summaries = summaries[itemOffset:]
+		if len(summaries) > pageSize {
+			summaries = summaries[:pageSize]
+			nextPageToken = fmt.Sprintf("%d", itemOffset+pageSize)
+		}
+	}
+	return connect.NewResponse(&corev1.GetInstalledPackageSummariesResponse{
+		InstalledPackageSummaries: summaries,
+		NextPageToken:             nextPageToken,
+	}), nil
+}
+
+// GetInstalledPackageDetail returns the details of a bundle installed by this plugin.
+func (s *Server) GetInstalledPackageDetail(ctx context.Context, request *connect.Request[corev1.GetInstalledPackageDetailRequest]) (*connect.Response[corev1.GetInstalledPackageDetailResponse], error) {
+	installedRef := request.Msg.GetInstalledPackageRef()
+	cluster := installedRef.GetContext().GetCluster()
+	namespace := installedRef.GetContext().GetNamespace()
+	identifier := installedRef.GetIdentifier()
+	log.InfoS("+oci-catalog GetInstalledPackageDetail", "cluster", cluster, "namespace", namespace, "id", identifier)
+
+	if cluster == "" {
+		cluster = s.kubeappsCluster
+	}
+
+	app, err := s.getManagedApp(ctx, request.Header(), cluster, namespace, identifier)
+	if err != nil {
+		return nil, connecterror.FromK8sError("get", "App", identifier, err)
+	}
+	values := ""
+	if secretName := appValuesSecretName(app); secretName != "" {
+		values, err = s.getValues(ctx, request.Header(), cluster, namespace, secretName)
+		if err != nil && !errors.IsNotFound(err) {
+			return nil, connecterror.FromK8sError("get", "Secret", secretName, err)
+		}
+	}
+
+	return connect.NewResponse(&corev1.GetInstalledPackageDetailResponse{
+		InstalledPackageDetail: installedPackageDetail(app, values, cluster),
+	}), nil
+}
+
+// GetInstalledPackageOperationStatus returns the status of the App of an installed bundle.
+func (s *Server) GetInstalledPackageOperationStatus(ctx context.Context, request *connect.Request[corev1.GetInstalledPackageOperationStatusRequest]) (*connect.Response[corev1.GetInstalledPackageOperationStatusResponse], error) {
+	return pkgutils.GetInstalledPackageOperationStatus(ctx, request, s.GetInstalledPackageDetail)
+}
+
+// CheckInstalledPackageNameAvailability checks whether a bundle can be
+// installed with the given name, that is, whether no App already has it.
+func (s *Server) CheckInstalledPackageNameAvailability(ctx context.Context, request *connect.Request[corev1.CheckInstalledPackageNameAvailabilityRequest]) (*connect.Response[corev1.CheckInstalledPackageNameAvailabilityResponse], error) {
+	cluster := request.Msg.GetTargetContext().GetCluster()
+	namespace := request.Msg.GetTargetContext().GetNamespace()
+	name := request.Msg.GetName()
+	log.InfoS("+oci-catalog CheckInstalledPackageNameAvailability", "cluster", cluster, "namespace", namespace, "name", name)
+
+	if cluster == "" {
+		cluster = s.kubeappsCluster
+	}
+
+	response := &corev1.CheckInstalledPackageNameAvailabilityResponse{Available: true}
+	_, err := s.getApp(ctx, request.Header(), cluster, namespace, name)
+	if err == nil {
+		response.Available = false
+		response.Conflicts = []*corev1.InstalledPackageReference{{
+			Context:    &corev1.Context{Cluster: cluster, Namespace: namespace},
+			Identifier: name,
+			Plugin:     GetPluginDetail(),
+		}}
+	} else if !errors.IsNotFound(err) {
+		return nil, connecterror.FromK8sError("get", "App", name, err)
+	}
+	return connect.NewResponse(response), nil
+}
+
+// CreateInstalledPackage installs a bundle by creating a kapp-controller App.
+func (s *Server) CreateInstalledPackage(ctx context.Context, request *connect.Request[corev1.CreateInstalledPackageRequest]) (*connect.Response[corev1.CreateInstalledPackageResponse], error) {
+	targetCluster := request.Msg.GetTargetContext().GetCluster()
+	targetNamespace := request.Msg.GetTargetContext().GetNamespace()
+	name := request.Msg.GetName()
+	log.InfoS("+oci-catalog CreateInstalledPackage", "cluster", targetCluster, "namespace", targetNamespace, "id", name)
+
+	if request.Msg.GetAvailablePackageRef().GetIdentifier() == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("No request AvailablePackageRef identifier provided"))
+	}
+	if name == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("No request Name provided"))
+	}
+	if targetNamespace == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("No request TargetContext namespace provided"))
+	}
+	if request.Msg.GetReconciliationOptions().GetServiceAccountName() == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("No request ReconciliationOptions serviceAccountName provided"))
+	}
+	if targetCluster == "" {
+		targetCluster = s.kubeappsCluster
+	}
+
+	identifier := request.Msg.GetAvailablePackageRef().GetIdentifier()
+	registry, bundleName, err := s.registryForIdentifier(identifier)
+	if err != nil {
+		return nil, err
+	}
+	versions, err := s.bundleVersions(ctx, registry, bundleName)
+	if err != nil {
+		return nil, err
+	}
+	version, err := resolveVersion(versions, request.Msg.GetPkgVersionReference().GetVersion(), identifier)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkBundle(ctx, registry.image(bundleName, version)); err != nil {
+		return nil, err
+	}
+
+	valuesSecretName := ""
+	if request.Msg.GetValues() != "" {
+		valuesSecretName = valuesSecretNameForApp(name)
+	}
+	app, err := newApp(appOptions{
+		name:                  name,
+		namespace:             targetNamespace,
+		identifier:            identifier,
+		image:                 registry.image(bundleName, version),
+		registry:              registry,
+		valuesSecretName:      valuesSecretName,
+		reconciliationOptions: request.Msg.GetReconciliationOptions(),
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Invalid reconciliation options: %w", err))
+	}
+
+	if valuesSecretName != "" {
+		secret := newValuesSecret(valuesSecretName, targetNamespace, request.Msg.GetValues())
+		if err := s.applyValuesSecret(ctx, request.Header(), targetCluster, secret); err != nil {
+			return nil, connecterror.FromK8sError("create", "Secret", valuesSecretName, err)
+		}
+	}
+	if err := s.createApp(ctx, request.Header(), targetCluster, app); err != nil {
+		if valuesSecretName != "" {
+			if err := s.deleteValuesSecret(ctx, request.Header(), targetCluster, targetNamespace, valuesSecretName); err != nil {
+				log.Errorf("+oci-catalog unable to clean up the values Secret %q: %v", valuesSecretName, err)
+			}
+		}
+		return nil, connecterror.FromK8sError("create", "App", name, err)
+	}
+
+	return connect.NewResponse(&corev1.CreateInstalledPackageResponse{
+		InstalledPackageRef: &corev1.InstalledPackageReference{
+			Context:    &corev1.Context{Cluster: targetCluster, Namespace: targetNamespace},
+			Identifier: name,
+			Plugin:     GetPluginDetail(),
+		},
+	}), nil
+}
+
+// UpdateInstalledPackage updates the version, values and reconciliation
+// options of a bundle installed by this plugin.
+func (s *Server) UpdateInstalledPackage(ctx context.Context, request *connect.Request[corev1.UpdateInstalledPackageRequest]) (*connect.Response[corev1.UpdateInstalledPackageResponse], error) {
+	installedRef := request.Msg.GetInstalledPackageRef()
+	cluster := installedRef.GetContext().GetCluster()
+	namespace := installedRef.GetContext().GetNamespace()
+	identifier := installedRef.GetIdentifier()
+	log.InfoS("+oci-catalog UpdateInstalledPackage", "cluster", cluster, "namespace", namespace, "id", identifier)
+
+	if cluster == "" {
+		cluster = s.kubeappsCluster
+	}
+
+	app, err := s.getManagedApp(ctx, request.Header(), cluster, namespace, identifier)
+	if err != nil {
+		return nil, connecterror.FromK8sError("get", "App", identifier, err)
+	}
+
+	if version := request.Msg.GetPkgVersionReference().GetVersion(); version != "" && version != appBundleVersion(app) {
+		registry, bundleName, err := s.registryForIdentifier(app.Annotations[annotationPackageKey])
+		if err != nil {
+			return nil, err
+		}
+		versions, err := s.bundleVersions(ctx, registry, bundleName)
+		if err != nil {
+			return nil, err
+		}
+		resolved, err := resolveVersion(versions, version, app.Annotations[annotationPackageKey])
+		if err != nil {
+			return nil, err
+		}
+		if err := s.checkBundle(ctx, registry.image(bundleName, resolved)); err != nil {
+			return nil, err
+		}
+		setAppBundleImage(app, registry.image(bundleName, resolved))
+	}
+	if err := setAppReconciliationOptions(app, request.Msg.GetReconciliationOptions()); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Invalid reconciliation options: %w", err))
+	}
+
+	// The values are stored in a Secret only when there are any. The Secret
+	// is applied before the App referencing it, so it is restored if the App
+	// cannot be updated.
+	previousSecretName := appValuesSecretName(app)
+	valuesSecretName := ""
+	var restoreValuesSecret func()
+	if request.Msg.GetReuseValues() {
+		// The Secret holding the current values, if any, is kept as is.
+		valuesSecretName = previousSecretName
+	} else if request.Msg.GetValues() != "" {
+		valuesSecretName = valuesSecretNameForApp(identifier)
+		restoreValuesSecret, err = s.valuesSecretRestorer(ctx, request.Header(), cluster, namespace, valuesSecretName)
+		if err != nil {
+			return nil, connecterror.FromK8sError("get", "Secret", valuesSecretName, err)
+		}
+		secret := newValuesSecret(valuesSecretName, namespace, request.Msg.GetValues())
+		if err := s.applyValuesSecret(ctx, request.Header(), cluster, secret); err != nil {
+			return nil, connecterror.FromK8sError("update", "Secret", valuesSecretName, err)
+		}
+	}
+	setAppValuesSecretName(app, valuesSecretName)
+
+	if err := s.updateApp(ctx, request.Header(), cluster, app); err != nil {
+		if restoreValuesSecret != nil {
+			restoreValuesSecret()
+		}
+		return nil, connecterror.FromK8sError("update", "App", identifier, err)
+	}
+	if previousSecretName != "" && previousSecretName != valuesSecretName {
+		if err := s.deleteValuesSecret(ctx, request.Header(), cluster, namespace, previousSecretName); err != nil {
+			return nil, connecterror.FromK8sError("delete", "Secret", previousSecretName, err)
+		}
+	}
+
+	return connect.NewResponse(&corev1.UpdateInstalledPackageResponse{
+		InstalledPackageRef: &corev1.InstalledPackageReference{
+			Context:    &corev1.Context{Cluster: cluster, Namespace: namespace},
+			Identifier: identifier,
+			Plugin:     GetPluginDetail(),
+		},
+	}), nil
+}
+
+// DeleteInstalledPackage deletes the App of a bundle installed by this
+// plugin, which makes kapp-controller delete its resources, and its values.
+func (s *Server) DeleteInstalledPackage(ctx context.Context, request *connect.Request[corev1.DeleteInstalledPackageRequest]) (*connect.Response[corev1.DeleteInstalledPackageResponse], error) {
+	installedRef := request.Msg.GetInstalledPackageRef()
+	cluster := installedRef.GetContext().GetCluster()
+	namespace := installedRef.GetContext().GetNamespace()
+	identifier := installedRef.GetIdentifier()
+	log.InfoS("+oci-catalog DeleteInstalledPackage", "cluster", cluster, "namespace", namespace, "id", identifier)
+
+	if cluster == "" {
+		cluster = s.kubeappsCluster
+	}
+
+	app, err := s.getManagedApp(ctx, request.Header(), cluster, namespace, identifier)
+	if err != nil {
+		return nil, connecterror.FromK8sError("get", "App", identifier, err)
+	}
+	if err := s.deleteApp(ctx, request.Header(), cluster, namespace, identifier); err != nil {
+		return nil, connecterror.FromK8sError("delete", "App", identifier, err)
+	}
+	if secretName := appValuesSecretName(app); secretName != "" {
+		if err := s.deleteValuesSecret(ctx, request.Header(), cluster, namespace, secretName); err != nil {
+			return nil, connecterror.FromK8sError("delete", "Secret", secretName, err)
+		}
+	}
+	return connect.NewResponse(&corev1.DeleteInstalledPackageResponse{}), nil
+}
+
+// checkBundle returns a FailedPrecondition error unless the image is an
+// imgpkg bundle, so that no other image is installed.
+func (s *Server) checkBundle(ctx context.Context, image string) error {
+	ok, err := s.isBundle(ctx, image)
+	if err != nil {
+		return connect.NewError(connect.CodeUnavailable, fmt.Errorf("Unable to fetch the image %q: %w", image, err))
+	}
+	if !ok {
+		return connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("The image %q is not an imgpkg bundle", image))
+	}
+	return nil
+}
+
+// bundleVersions returns the versions of a bundle, from the most recent,
+// returning a NotFound error if it has none.
+func (s *Server) bundleVersions(ctx context.Context, registry bundleRegistry, bundleName string) ([]string, error) {
+	client, closer, err := s.newCatalogClient()
+	if err != nil {
+		return nil, err
+	}
+	defer closer()
+
+	versions, err := listBundleVersions(ctx, client, registry, bundleName)
+	if err != nil {
+		return nil, err
+	}
+	if len(versions) == 0 {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("Unable to find versions for the package %q", registry.packageIdentifier(bundleName)))
+	}
+	return versions, nil
+}
+
+// resolveVersion returns the requested version, or the most recent one if
+// none is requested. An App pulls a single bundle image, so a requested range
+// is resolved to the highest version satisfying it.
+func resolveVersion(versions []string, requested, identifier string) (string, error) {
+	if requested == "" {
+		return versions[0], nil
+	}
+	return pkgutils.ResolveVersionReference(requested, versions, identifier)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,859 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	kappctrlv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	corev1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	plugins "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/plugins/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/clientgetter"
+	ocicatalog "github.com/vmware-tanzu/kubeapps/cmd/oci-catalog/gen/catalog/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/pkg/ocicatalog_client/ocicatalog_clienttest"
+	k8scorev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynfake "k8s.io/client-go/dynamic/fake"
+	typfake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+var ignoreUnexported = cmpopts.IgnoreUnexported(
+	corev1.AvailablePackageDetail{},
+	corev1.AvailablePackageReference{},
+	corev1.AvailablePackageSummary{},
+	corev1.Context{},
+	corev1.GetAvailablePackageSummariesResponse{},
+	corev1.InstalledPackageDetail{},
+	corev1.InstalledPackageReference{},
+	corev1.InstalledPackageStatus{},
+	corev1.InstalledPackageSummary{},
+	corev1.PackageAppVersion{},
+	corev1.ReconciliationOptions{},
+	corev1.UpgradeImpact{},
+	corev1.VersionReference{},
+	plugins.Plugin{},
+)
+
+var testRegistry = bundleRegistry{
+	name:          "my-bundles",
+	host:          "ghcr.io",
+	namespace:     "my-org/bundles",
+	templatePaths: fallbackTemplatePaths,
+}
+
+func newTestServer(t *testing.T, ociCatalogAddr string, existingObjects ...k8sruntime.Object) (*Server, *dynfake.FakeDynamicClient, *typfake.Clientset) {
+	unstructuredObjects := []k8sruntime.Object{}
+	for _, obj := range existingObjects {
+		content, err := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		unstructuredObjects = append(unstructuredObjects, &unstructured.Unstructured{Object: content})
+	}
+	dynamicClient := dynfake.NewSimpleDynamicClientWithCustomListKinds(
+		k8sruntime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			kappctrlv1alpha1.SchemeGroupVersion.WithResource(appsResource): appResource + "List",
+		},
+		unstructuredObjects...,
+	)
+	typedClient := typfake.NewSimpleClientset()
+	return &Server{
+		clientGetter:    clientgetter.NewBuilder().WithDynamic(dynamicClient).WithTyped(typedClient).Build(),
+		kubeappsCluster: "default",
+		ociCatalogAddr:  ociCatalogAddr,
+		registries:      []bundleRegistry{testRegistry},
+		isBundle:        fakeBundleChecker(),
+	}, dynamicClient, typedClient
+}
+
+// fakeBundleChecker returns a bundleChecker reporting every image as an
+// imgpkg bundle except the given ones.
+func fakeBundleChecker(nonBundles ...string) bundleChecker {
+	return func(ctx context.Context, image string) (bool, error) {
+		for _, nonBundle := range nonBundles {
+			if image == nonBundle {
+				return false, nil
+			}
+		}
+		return true, nil
+	}
+}
+
+func newTestApp(name, version string, conditionType kappctrlv1alpha1.ConditionType) *kappctrlv1alpha1.App {
+	app, _ := newApp(appOptions{
+		name:                  name,
+		namespace:             "default",
+		identifier:            "my-bundles/tetris",
+		image:                 testRegistry.image("tetris", version),
+		registry:              testRegistry,
+		reconciliationOptions: &corev1.ReconciliationOptions{ServiceAccountName: "default"},
+	})
+	if conditionType != "" {
+		app.Status.Conditions = []kappctrlv1alpha1.Condition{{Type: conditionType, Status: k8scorev1.ConditionTrue}}
+	}
+	return app
+}
+
+func TestNewBundleRegistries(t *testing.T) {
+	testCases := []struct {
+		name               string
+		configs            []registryConfig
+		expectedRegistries []bundleRegistry
+		expectedError      bool
+	}{
+		{
+			name: "it parses the registry namespace and defaults the template paths",
+			configs: []registryConfig{
+				{Name: "my-bundles", URL: "oci://ghcr.io/my-org/bundles/"},
+				{Name: "private", URL: "oci://registry.example.com:5000", SecretName: "registry-creds", TemplatePaths: []string{"manifests/"}},
+			},
+			expectedRegistries: []bundleRegistry{
+				testRegistry,
+				{name: "private", host: "registry.example.com:5000", secretName: "registry-creds", templatePaths: []string{"manifests/"}},
+			},
+		},
+		{
+			name:          "it errors for a registry name with a slash",
+			configs:       []registryConfig{{Name: "my/bundles", URL: "oci://ghcr.io/my-org"}},
+			expectedError: true,
+		},
+		{
+			name: "it errors for duplicate registry names",
+			configs: []registryConfig{
+				{Name: "my-bundles", URL: "oci://ghcr.io/my-org"},
+				{Name: "my-bundles", URL: "oci://ghcr.io/other-org"},
+			},
+			expectedError: true,
+		},
+		{
+			name:          "it errors for a URL which is not an OCI one",
+			configs:       []registryConfig{{Name: "my-bundles", URL: "https://ghcr.io/my-org"}},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			registries, err := newBundleRegistries(tc.configs)
+			if got, want := err != nil, tc.expectedError; got != want {
+				t.Fatalf("got error: %+v, want error: %t", err, want)
+			}
+			if got, want := registries, tc.expectedRegistries; !cmp.Equal(want, got, cmp.AllowUnexported(bundleRegistry{})) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, cmp.AllowUnexported(bundleRegistry{})))
+			}
+		})
+	}
+}
+
+func TestGetAvailablePackageSummaries(t *testing.T) {
+	addr, catalogDouble, cleanup := ocicatalog_clienttest.SetupTestDouble(t)
+	defer cleanup()
+	catalogDouble.Repositories = []*ocicatalog.Repository{
+		{Registry: "ghcr.io", Namespace: "my-org/bundles", Name: "pacman"},
+		{Registry: "ghcr.io", Namespace: "my-org/bundles", Name: "tetris"},
+	}
+	catalogDouble.Tags = []*ocicatalog.Tag{{Name: "latest"}, {Name: "v1.2.0"}, {Name: "v1.10.0"}}
+
+	summary := func(name string) *corev1.AvailablePackageSummary {
+		return &corev1.AvailablePackageSummary{
+			AvailablePackageRef: &corev1.AvailablePackageReference{
+				Context:    &corev1.Context{Cluster: "default", Namespace: "default"},
+				Identifier: "my-bundles/" + name,
+				Plugin:     GetPluginDetail(),
+			},
+			Name:             name,
+			DisplayName:      name,
+			LatestVersion:    &corev1.PackageAppVersion{PkgVersion: "v1.10.0", AppVersion: "v1.10.0"},
+			ShortDescription: "Carvel imgpkg bundle from ghcr.io/my-org/bundles/" + name,
+			Categories:       []string{},
+		}
+	}
+
+	testCases := []struct {
+		name              string
+		request           *corev1.GetAvailablePackageSummariesRequest
+		expectedResponse  *corev1.GetAvailablePackageSummariesResponse
+		expectedErrorCode connect.Code
+	}{
+		{
+			name: "it lists the bundles with their latest version",
+			request: &corev1.GetAvailablePackageSummariesRequest{
+				Context: &corev1.Context{Namespace: "default"},
+			},
+			expectedResponse: &corev1.GetAvailablePackageSummariesResponse{
+				AvailablePackageSummaries: []*corev1.AvailablePackageSummary{summary("pacman"), summary("tetris")},
+				Categories:                []string{},
+			},
+		},
+		{
+			name: "it filters the bundles by name",
+			request: &corev1.GetAvailablePackageSummariesRequest{
+				Context:       &corev1.Context{Namespace: "default"},
+				FilterOptions: &corev1.FilterOptions{Query: "TET"},
+			},
+			expectedResponse: &corev1.GetAvailablePackageSummariesResponse{
+				AvailablePackageSummaries: []*corev1.AvailablePackageSummary{summary("tetris")},
+				Categories:                []string{},
+			},
+		},
+		{
+			name: "it filters the bundles by registry",
+			request: &corev1.GetAvailablePackageSummariesRequest{
+				Context:       &corev1.Context{Namespace: "default"},
+				FilterOptions: &corev1.FilterOptions{Repositories: []string{"other-bundles"}},
+			},
+			expectedResponse: &corev1.GetAvailablePackageSummariesResponse{
+				AvailablePackageSummaries: []*corev1.AvailablePackageSummary{},
+				Categories:                []string{},
+			},
+		},
+		{
+			name: "it paginates the bundles",
+			request: &corev1.GetAvailablePackageSummariesRequest{
+				Context:           &corev1.Context{Namespace: "default"},
+				PaginationOptions: &corev1.PaginationOptions{PageSize: 1},
+			},
+			expectedResponse: &corev1.GetAvailablePackageSummariesResponse{
+				AvailablePackageSummaries: []*corev1.AvailablePackageSummary{summary("pacman")},
+				Categories:                []string{},
+				NextPageToken:             "1",
+			},
+		},
+		{
+			name: "it returns the last page without a next page token",
+			request: &corev1.GetAvailablePackageSummariesRequest{
+				Context:           &corev1.Context{Namespace: "default"},
+				PaginationOptions: &corev1.PaginationOptions{PageSize: 1, PageToken: "1"},
+			},
+			expectedResponse: &corev1.GetAvailablePackageSummariesResponse{
+				AvailablePackageSummaries: []*corev1.AvailablePackageSummary{summary("tetris")},
+				Categories:                []string{},
+			},
+		},
+		{
+			name: "it returns an error for an invalid page token",
+			request: &corev1.GetAvailablePackageSummariesRequest{
+				Context:           &corev1.Context{Namespace: "default"},
+				PaginationOptions: &corev1.PaginationOptions{PageSize: 1, PageToken: "foo"},
+			},
+			expectedErrorCode: connect.CodeInvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, _, _ := newTestServer(t, addr)
+
+			response, err := s.GetAvailablePackageSummaries(context.Background(), connect.NewRequest(tc.request))
+
+			if got, want := connect.CodeOf(err), tc.expectedErrorCode; err != nil && got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+			if tc.expectedErrorCode != 0 {
+				return
+			}
+			if got, want := response.Msg, tc.expectedResponse; !cmp.Equal(want, got, ignoreUnexported) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexported))
+			}
+		})
+	}
+}
+
+func TestGetAvailablePackageSummariesSkipsNonBundles(t *testing.T) {
+	addr, catalogDouble, cleanup := ocicatalog_clienttest.SetupTestDouble(t)
+	defer cleanup()
+	catalogDouble.Repositories = []*ocicatalog.Repository{
+		{Registry: "ghcr.io", Namespace: "my-org/bundles", Name: "pacman"},
+		{Registry: "ghcr.io", Namespace: "my-org/bundles", Name: "tetris"},
+	}
+	catalogDouble.Tags = []*ocicatalog.Tag{{Name: "v1.0.0"}}
+	s, _, _ := newTestServer(t, addr)
+	s.isBundle = fakeBundleChecker(testRegistry.image("pacman", "v1.0.0"))
+
+	response, err := s.GetAvailablePackageSummaries(context.Background(), connect.NewRequest(&corev1.GetAvailablePackageSummariesRequest{
+		Context: &corev1.Context{Namespace: "default"},
+	}))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	var names []string
+	for _, summary := range response.Msg.AvailablePackageSummaries {
+		names = append(names, summary.Name)
+	}
+	if got, want := names, []string{"tetris"}; !cmp.Equal(want, got) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+	}
+}
+
+func TestGetAvailablePackageSummariesWithoutCatalog(t *testing.T) {
+	s, _, _ := newTestServer(t, "")
+
+	_, err := s.GetAvailablePackageSummaries(context.Background(), connect.NewRequest(&corev1.GetAvailablePackageSummariesRequest{}))
+
+	if got, want := connect.CodeOf(err), connect.CodeFailedPrecondition; got != want {
+		t.Errorf("got: %+v, want: %+v, err: %+v", got, want, err)
+	}
+}
+
+func TestGetAvailablePackageDetail(t *testing.T) {
+	addr, catalogDouble, cleanup := ocicatalog_clienttest.SetupTestDouble(t)
+	defer cleanup()
+	catalogDouble.Tags = []*ocicatalog.Tag{{Name: "1.0.0"}, {Name: "1.1.0"}}
+
+	testCases := []struct {
+		name              string
+		identifier        string
+		version           string
+		expectedVersion   string
+		expectedErrorCode connect.Code
+	}{
+		{
+			name:            "it returns the latest version by default",
+			identifier:      "my-bundles/tetris",
+			expectedVersion: "1.1.0",
+		},
+		{
+			name:            "it returns the requested version",
+			identifier:      "my-bundles/tetris",
+			version:         "1.0.0",
+			expectedVersion: "1.0.0",
+		},
+		{
+			name:              "it returns not found for an unknown version",
+			identifier:        "my-bundles/tetris",
+			version:           "2.0.0",
+			expectedErrorCode: connect.CodeNotFound,
+		},
+		{
+			name:              "it returns not found for an unknown registry",
+			identifier:        "other-bundles/tetris",
+			expectedErrorCode: connect.CodeNotFound,
+		},
+		{
+			name:              "it returns an error for an invalid identifier",
+			identifier:        "tetris",
+			expectedErrorCode: connect.CodeInvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, _, _ := newTestServer(t, addr)
+
+			response, err := s.GetAvailablePackageDetail(context.Background(), connect.NewRequest(&corev1.GetAvailablePackageDetailRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context:    &corev1.Context{Namespace: "default"},
+					Identifier: tc.identifier,
+				},
+				PkgVersion: tc.version,
+			}))
+
+			if got, want := connect.CodeOf(err), tc.expectedErrorCode; err != nil && got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+			if tc.expectedErrorCode != 0 {
+				return
+			}
+			detail := response.Msg.GetAvailablePackageDetail()
+			if got, want := detail.GetVersion().GetPkgVersion(), tc.expectedVersion; got != want {
+				t.Errorf("got: %q, want: %q", got, want)
+			}
+			if got, want := detail.GetRepoUrl(), "oci://ghcr.io/my-org/bundles"; got != want {
+				t.Errorf("got: %q, want: %q", got, want)
+			}
+		})
+	}
+}
+
+func TestGetAvailablePackageVersions(t *testing.T) {
+	addr, catalogDouble, cleanup := ocicatalog_clienttest.SetupTestDouble(t)
+	defer cleanup()
+	catalogDouble.Tags = []*ocicatalog.Tag{{Name: "1.0.0"}, {Name: "latest"}, {Name: "2.0.0"}, {Name: "1.1.0"}}
+	s, _, _ := newTestServer(t, addr)
+
+	response, err := s.GetAvailablePackageVersions(context.Background(), connect.NewRequest(&corev1.GetAvailablePackageVersionsRequest{
+		AvailablePackageRef: &corev1.AvailablePackageReference{
+			Context:    &corev1.Context{Namespace: "default"},
+			Identifier: "my-bundles/tetris",
+		},
+		RelativeToVersion: "1.1.0",
+	}))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	expected := []*corev1.PackageAppVersion{
+		{PkgVersion: "2.0.0", AppVersion: "2.0.0", UpgradeImpact: &corev1.UpgradeImpact{Type: corev1.UpgradeImpact_UPGRADE_TYPE_MAJOR}},
+		{PkgVersion: "1.1.0", AppVersion: "1.1.0", UpgradeImpact: &corev1.UpgradeImpact{Type: corev1.UpgradeImpact_UPGRADE_TYPE_NONE}},
+		{PkgVersion: "1.0.0", AppVersion: "1.0.0", UpgradeImpact: &corev1.UpgradeImpact{Type: corev1.UpgradeImpact_UPGRADE_TYPE_DOWNGRADE}},
+	}
+	if got, want := response.Msg.PackageAppVersions, expected; !cmp.Equal(want, got, ignoreUnexported) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexported))
+	}
+}
+
+func TestCreateInstalledPackage(t *testing.T) {
+	addr, catalogDouble, cleanup := ocicatalog_clienttest.SetupTestDouble(t)
+	defer cleanup()
+	catalogDouble.Tags = []*ocicatalog.Tag{{Name: "1.0.0"}, {Name: "1.1.0"}}
+
+	testCases := []struct {
+		name              string
+		request           *corev1.CreateInstalledPackageRequest
+		nonBundles        []string
+		expectedApp       *kappctrlv1alpha1.App
+		expectedValues    string
+		expectedErrorCode connect.Code
+	}{
+		{
+			name: "it creates an App fetching the latest version of the bundle",
+			request: &corev1.CreateInstalledPackageRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context:    &corev1.Context{Namespace: "default"},
+					Identifier: "my-bundles/tetris",
+				},
+				TargetContext:         &corev1.Context{Namespace: "default"},
+				Name:                  "my-tetris",
+				ReconciliationOptions: &corev1.ReconciliationOptions{ServiceAccountName: "default"},
+			},
+			expectedApp: newTestApp("my-tetris", "1.1.0", ""),
+		},
+		{
+			name: "it stores the values in a Secret",
+			request: &corev1.CreateInstalledPackageRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context:    &corev1.Context{Namespace: "default"},
+					Identifier: "my-bundles/tetris",
+				},
+				TargetContext:         &corev1.Context{Namespace: "default"},
+				Name:                  "my-tetris",
+				PkgVersionReference:   &corev1.VersionReference{Version: "1.0.0"},
+				Values:                "speed: 2",
+				ReconciliationOptions: &corev1.ReconciliationOptions{ServiceAccountName: "default"},
+			},
+			expectedApp: func() *kappctrlv1alpha1.App {
+				app := newTestApp("my-tetris", "1.0.0", "")
+				setAppValuesSecretName(app, "my-tetris-values")
+				return app
+			}(),
+			expectedValues: "speed: 2",
+		},
+		{
+			name: "it resolves a version range to the highest version satisfying it",
+			request: &corev1.CreateInstalledPackageRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context:    &corev1.Context{Namespace: "default"},
+					Identifier: "my-bundles/tetris",
+				},
+				TargetContext:         &corev1.Context{Namespace: "default"},
+				Name:                  "my-tetris",
+				PkgVersionReference:   &corev1.VersionReference{Version: ">=1.0 <1.1"},
+				ReconciliationOptions: &corev1.ReconciliationOptions{ServiceAccountName: "default"},
+			},
+			expectedApp: newTestApp("my-tetris", "1.0.0", ""),
+		},
+		{
+			name: "it returns an error without a service account",
+			request: &corev1.CreateInstalledPackageRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context:    &corev1.Context{Namespace: "default"},
+					Identifier: "my-bundles/tetris",
+				},
+				TargetContext: &corev1.Context{Namespace: "default"},
+				Name:          "my-tetris",
+			},
+			expectedErrorCode: connect.CodeInvalidArgument,
+		},
+		{
+			name: "it returns not found for an unknown version",
+			request: &corev1.CreateInstalledPackageRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context:    &corev1.Context{Namespace: "default"},
+					Identifier: "my-bundles/tetris",
+				},
+				TargetContext:         &corev1.Context{Namespace: "default"},
+				Name:                  "my-tetris",
+				PkgVersionReference:   &corev1.VersionReference{Version: "3.0.0"},
+				ReconciliationOptions: &corev1.ReconciliationOptions{ServiceAccountName: "default"},
+			},
+			expectedErrorCode: connect.CodeNotFound,
+		},
+		{
+			name: "it returns an error for an image which is not an imgpkg bundle",
+			request: &corev1.CreateInstalledPackageRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context:    &corev1.Context{Namespace: "default"},
+					Identifier: "my-bundles/tetris",
+				},
+				TargetContext:         &corev1.Context{Namespace: "default"},
+				Name:                  "my-tetris",
+				PkgVersionReference:   &corev1.VersionReference{Version: "1.0.0"},
+				ReconciliationOptions: &corev1.ReconciliationOptions{ServiceAccountName: "default"},
+			},
+			nonBundles:        []string{testRegistry.image("tetris", "1.0.0")},
+			expectedErrorCode: connect.CodeFailedPrecondition,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, _, _ := newTestServer(t, addr)
+			s.isBundle = fakeBundleChecker(tc.nonBundles...)
+
+			_, err := s.CreateInstalledPackage(context.Background(), connect.NewRequest(tc.request))
+
+			if got, want := connect.CodeOf(err), tc.expectedErrorCode; err != nil && got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+			if tc.expectedErrorCode != 0 {
+				if err == nil {
+					t.Fatalf("got: nil, want: %+v", tc.expectedErrorCode)
+				}
+				return
+			}
+
+			app, err := s.getApp(context.Background(), nil, "default", "default", "my-tetris")
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if got, want := app, tc.expectedApp; !cmp.Equal(want, got) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+			if tc.expectedValues != "" {
+				values, err := s.getValues(context.Background(), nil, "default", "default", "my-tetris-values")
+				if err != nil {
+					t.Fatalf("%+v", err)
+				}
+				if got, want := values, tc.expectedValues; got != want {
+					t.Errorf("got: %q, want: %q", got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestGetInstalledPackageSummaries(t *testing.T) {
+	unmanagedApp := &kappctrlv1alpha1.App{
+		TypeMeta:   metav1.TypeMeta{Kind: appResource, APIVersion: kappctrlv1alpha1.SchemeGroupVersion.String()},
+		ObjectMeta: metav1.ObjectMeta{Name: "other-app", Namespace: "default"},
+	}
+	s, _, _ := newTestServer(t, "", newTestApp("my-tetris", "1.0.0", kappctrlv1alpha1.ReconcileSucceeded), unmanagedApp)
+
+	response, err := s.GetInstalledPackageSummaries(context.Background(), connect.NewRequest(&corev1.GetInstalledPackageSummariesRequest{
+		Context: &corev1.Context{Namespace: "default"},
+	}))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	expected := []*corev1.InstalledPackageSummary{
+		{
+			InstalledPackageRef: &corev1.InstalledPackageReference{
+				Context:    &corev1.Context{Cluster: "default", Namespace: "default"},
+				Identifier: "my-tetris",
+				Plugin:     GetPluginDetail(),
+			},
+			Name:                "my-tetris",
+			PkgVersionReference: &corev1.VersionReference{Version: "1.0.0"},
+			CurrentVersion:      &corev1.PackageAppVersion{PkgVersion: "1.0.0", AppVersion: "1.0.0"},
+			PkgDisplayName:      "tetris",
+			ShortDescription:    "Carvel imgpkg bundle ghcr.io/my-org/bundles/tetris:1.0.0",
+			Status: &corev1.InstalledPackageStatus{
+				Ready:      true,
+				Reason:     corev1.InstalledPackageStatus_STATUS_REASON_INSTALLED,
+				UserReason: "Deployed",
+			},
+		},
+	}
+	if got, want := response.Msg.InstalledPackageSummaries, expected; !cmp.Equal(want, got, ignoreUnexported) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexported))
+	}
+
+	t.Run("it filters the summaries by status", func(t *testing.T) {
+		s, _, _ := newTestServer(t, "",
+			newTestApp("my-tetris", "1.0.0", kappctrlv1alpha1.ReconcileSucceeded),
+			newTestApp("my-failing-tetris", "1.0.0", kappctrlv1alpha1.ReconcileFailed))
+
+		response, err := s.GetInstalledPackageSummaries(context.Background(), connect.NewRequest(&corev1.GetInstalledPackageSummariesRequest{
+			Context: &corev1.Context{Namespace: "default"},
+			FilterOptions: &corev1.InstalledPackageFilterOptions{
+				StatusReasons: []corev1.InstalledPackageStatus_StatusReason{corev1.InstalledPackageStatus_STATUS_REASON_FAILED},
+			},
+		}))
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		names := []string{}
+		for _, summary := range response.Msg.InstalledPackageSummaries {
+			names = append(names, summary.GetName())
+		}
+		if got, want := names, []string{"my-failing-tetris"}; !cmp.Equal(want, got) {
+			t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+		}
+	})
+
+	t.Run("it returns an error for an invalid label selector", func(t *testing.T) {
+		_, err := s.GetInstalledPackageSummaries(context.Background(), connect.NewRequest(&corev1.GetInstalledPackageSummariesRequest{
+			Context:       &corev1.Context{Namespace: "default"},
+			FilterOptions: &corev1.InstalledPackageFilterOptions{LabelSelector: "team in (frontend"},
+		}))
+		if got, want := connect.CodeOf(err), connect.CodeInvalidArgument; got != want {
+			t.Errorf("got: %s, want: %s, err: %+v", got, want, err)
+		}
+	})
+}
+
+func TestUpdateInstalledPackage(t *testing.T) {
+	addr, catalogDouble, cleanup := ocicatalog_clienttest.SetupTestDouble(t)
+	defer cleanup()
+	catalogDouble.Tags = []*ocicatalog.Tag{{Name: "1.0.0"}, {Name: "1.1.0"}}
+	s, _, _ := newTestServer(t, addr, newTestApp("my-tetris", "1.0.0", kappctrlv1alpha1.ReconcileSucceeded))
+
+	_, err := s.UpdateInstalledPackage(context.Background(), connect.NewRequest(&corev1.UpdateInstalledPackageRequest{
+		InstalledPackageRef: &corev1.InstalledPackageReference{
+			Context:    &corev1.Context{Namespace: "default"},
+			Identifier: "my-tetris",
+		},
+		PkgVersionReference:   &corev1.VersionReference{Version: "1.1.0"},
+		Values:                "speed: 3",
+		ReconciliationOptions: &corev1.ReconciliationOptions{Interval: "10m"},
+	}))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	response, err := s.GetInstalledPackageDetail(context.Background(), connect.NewRequest(&corev1.GetInstalledPackageDetailRequest{
+		InstalledPackageRef: &corev1.InstalledPackageReference{
+			Context:    &corev1.Context{Namespace: "default"},
+			Identifier: "my-tetris",
+		},
+	}))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	expected := &corev1.InstalledPackageDetail{
+		InstalledPackageRef: &corev1.InstalledPackageReference{
+			Context:    &corev1.Context{Cluster: "default", Namespace: "default"},
+			Identifier: "my-tetris",
+			Plugin:     GetPluginDetail(),
+		},
+		PkgVersionReference: &corev1.VersionReference{Version: "1.1.0"},
+		Name:                "my-tetris",
+		CurrentVersion:      &corev1.PackageAppVersion{PkgVersion: "1.1.0", AppVersion: "1.1.0"},
+		ValuesApplied:       "speed: 3",
+		ReconciliationOptions: &corev1.ReconciliationOptions{
+			ServiceAccountName: "default",
+			Interval:           "10m",
+		},
+		Status: &corev1.InstalledPackageStatus{
+			Ready:      true,
+			Reason:     corev1.InstalledPackageStatus_STATUS_REASON_INSTALLED,
+			UserReason: "Deployed",
+		},
+		AvailablePackageRef: &corev1.AvailablePackageReference{
+			Context:    &corev1.Context{Cluster: "default", Namespace: "default"},
+			Identifier: "my-bundles/tetris",
+			Plugin:     GetPluginDetail(),
+		},
+	}
+	if got, want := response.Msg.InstalledPackageDetail, expected; !cmp.Equal(want, got, ignoreUnexported) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexported))
+	}
+}
+
+func TestUpdateInstalledPackageReusesValues(t *testing.T) {
+	addr, catalogDouble, cleanup := ocicatalog_clienttest.SetupTestDouble(t)
+	defer cleanup()
+	catalogDouble.Tags = []*ocicatalog.Tag{{Name: "1.0.0"}, {Name: "1.1.0"}}
+	app := newTestApp("my-tetris", "1.0.0", kappctrlv1alpha1.ReconcileSucceeded)
+	setAppValuesSecretName(app, "my-tetris-values")
+	s, _, typedClient := newTestServer(t, addr, app)
+	if _, err := typedClient.CoreV1().Secrets("default").Create(context.Background(), newValuesSecret("my-tetris-values", "default", "speed: 1"), metav1.CreateOptions{}); err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	_, err := s.UpdateInstalledPackage(context.Background(), connect.NewRequest(&corev1.UpdateInstalledPackageRequest{
+		InstalledPackageRef: &corev1.InstalledPackageReference{
+			Context:    &corev1.Context{Namespace: "default"},
+			Identifier: "my-tetris",
+		},
+		PkgVersionReference: &corev1.VersionReference{Version: "1.1.0"},
+		ReuseValues:         true,
+	}))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	values, err := s.getValues(context.Background(), nil, "default", "default", "my-tetris-values")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if got, want := values, "speed: 1"; got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+}
+
+func TestUpdateInstalledPackageRestoresValues(t *testing.T) {
+	addr, catalogDouble, cleanup := ocicatalog_clienttest.SetupTestDouble(t)
+	defer cleanup()
+	catalogDouble.Tags = []*ocicatalog.Tag{{Name: "1.0.0"}, {Name: "1.1.0"}}
+
+	testCases := []struct {
+		name           string
+		previousValues string
+	}{
+		{
+			name:           "it restores the previous values",
+			previousValues: "speed: 1",
+		},
+		{
+			name: "it deletes the values of an App without previous values",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			app := newTestApp("my-tetris", "1.0.0", kappctrlv1alpha1.ReconcileSucceeded)
+			if tc.previousValues != "" {
+				setAppValuesSecretName(app, "my-tetris-values")
+			}
+			s, dynamicClient, typedClient := newTestServer(t, addr, app)
+			if tc.previousValues != "" {
+				if _, err := typedClient.CoreV1().Secrets("default").Create(context.Background(), newValuesSecret("my-tetris-values", "default", tc.previousValues), metav1.CreateOptions{}); err != nil {
+					t.Fatalf("%+v", err)
+				}
+			}
+			dynamicClient.PrependReactor("update", appsResource, func(action k8stesting.Action) (bool, k8sruntime.Object, error) {
+				return true, nil, k8serrors.NewConflict(kappctrlv1alpha1.Resource(appsResource), "my-tetris", nil)
+			})
+
+			_, err := s.UpdateInstalledPackage(context.Background(), connect.NewRequest(&corev1.UpdateInstalledPackageRequest{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context:    &corev1.Context{Namespace: "default"},
+					Identifier: "my-tetris",
+				},
+				PkgVersionReference: &corev1.VersionReference{Version: "1.1.0"},
+				Values:              "speed: 3",
+			}))
+			if err == nil {
+				t.Fatalf("got: nil, want: error")
+			}
+
+			values, err := s.getValues(context.Background(), nil, "default", "default", "my-tetris-values")
+			if tc.previousValues == "" {
+				if !k8serrors.IsNotFound(err) {
+					t.Errorf("got: %+v, want: not found", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if got, want := values, tc.previousValues; got != want {
+				t.Errorf("got: %q, want: %q", got, want)
+			}
+		})
+	}
+}
+
+func TestDeleteInstalledPackage(t *testing.T) {
+	app := newTestApp("my-tetris", "1.0.0", kappctrlv1alpha1.ReconcileSucceeded)
+	setAppValuesSecretName(app, "my-tetris-values")
+	unmanagedApp := &kappctrlv1alpha1.App{
+		TypeMeta:   metav1.TypeMeta{Kind: appResource, APIVersion: kappctrlv1alpha1.SchemeGroupVersion.String()},
+		ObjectMeta: metav1.ObjectMeta{Name: "other-app", Namespace: "default"},
+	}
+
+	testCases := []struct {
+		name              string
+		identifier        string
+		expectedErrorCode connect.Code
+	}{
+		{
+			name:       "it deletes the App and its values",
+			identifier: "my-tetris",
+		},
+		{
+			name:              "it does not delete an App created by other means",
+			identifier:        "other-app",
+			expectedErrorCode: connect.CodeNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, _, typedClient := newTestServer(t, "", app, unmanagedApp)
+			secret := newValuesSecret("my-tetris-values", "default", "speed: 2")
+			if _, err := typedClient.CoreV1().Secrets("default").Create(context.Background(), secret, metav1.CreateOptions{}); err != nil {
+				t.Fatalf("%+v", err)
+			}
+
+			_, err := s.DeleteInstalledPackage(context.Background(), connect.NewRequest(&corev1.DeleteInstalledPackageRequest{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context:    &corev1.Context{Namespace: "default"},
+					Identifier: tc.identifier,
+				},
+			}))
+
+			if got, want := connect.CodeOf(err), tc.expectedErrorCode; err != nil && got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+			if tc.expectedErrorCode != 0 {
+				return
+			}
+			if _, err := s.getApp(context.Background(), nil, "default", "default", tc.identifier); err == nil {
+				t.Errorf("got: App %q, want: deleted", tc.identifier)
+			}
+			if _, err := s.getValues(context.Background(), nil, "default", "default", "my-tetris-values"); err == nil {
+				t.Errorf("got: values Secret, want: deleted")
+			}
+		})
+	}
+}
+
+func TestCheckInstalledPackageNameAvailability(t *testing.T) {
+	s, _, _ := newTestServer(t, "", newTestApp("my-tetris", "1.0.0", ""))
+
+	for name, expectedAvailable := range map[string]bool{"my-tetris": false, "my-pacman": true} {
+		response, err := s.CheckInstalledPackageNameAvailability(context.Background(), connect.NewRequest(&corev1.CheckInstalledPackageNameAvailabilityRequest{
+			TargetContext: &corev1.Context{Namespace: "default"},
+			Name:          name,
+		}))
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		if got, want := response.Msg.Available, expectedAvailable; got != want {
+			t.Errorf("%s: got: %t, want: %t", name, got, want)
+		}
+	}
+}
+
+func TestAppBundleVersion(t *testing.T) {
+	testCases := map[string]string{
+		"ghcr.io/my-org/bundles/tetris:1.0.0":       "1.0.0",
+		"registry.example.com:5000/tetris:v2":       "v2",
+		"registry.example.com:5000/tetris@sha256:a": "",
+		"registry.example.com:5000/tetris":          "",
+	}
+	for image, expectedVersion := range testCases {
+		app := &kappctrlv1alpha1.App{Spec: kappctrlv1alpha1.AppSpec{
+			Fetch: []kappctrlv1alpha1.AppFetch{{ImgpkgBundle: &kappctrlv1alpha1.AppFetchImgpkgBundle{Image: image}}},
+		}}
+		if got, want := appBundleVersion(app), expectedVersion; got != want {
+			t.Errorf("%s: got: %q, want: %q", image, got, want)
+		}
+	}
+}
@@ -0,0 +1,259 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/bufbuild/connect-go"
+	corev1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	operatorsv1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/plugins/operators/packages/v1alpha1"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// available packages
+
+func availablePackageRef(manifest *packageManifest, cluster, namespace string) *corev1.AvailablePackageReference {
+	return &corev1.AvailablePackageReference{
+		Context:    &corev1.Context{Cluster: cluster, Namespace: namespace},
+		Identifier: packageIdentifier(manifest.Status.CatalogSource, manifest.Name),
+		Plugin:     GetPluginDetail(),
+	}
+}
+
+func displayName(manifest *packageManifest, channel *packageChannel) string {
+	if channel.CurrentCSVDesc.DisplayName != "" {
+		return channel.CurrentCSVDesc.DisplayName
+	}
+	return manifest.Name
+}
+
+func availablePackageSummary(manifest *packageManifest, cluster, namespace string) (*corev1.AvailablePackageSummary, bool) {
+	channel, ok := manifest.defaultChannel()
+	if !ok {
+		return nil, false
+	}
+	versions := manifest.versions()
+	if len(versions) == 0 {
+		return nil, false
+	}
+	return &corev1.AvailablePackageSummary{
+		AvailablePackageRef: availablePackageRef(manifest, cluster, namespace),
+		Name:                manifest.Name,
+		DisplayName:         displayName(manifest, channel),
+		LatestVersion: &corev1.PackageAppVersion{
+			PkgVersion: versions[0],
+			AppVersion: versions[0],
+		},
+		IconUrl:          iconURL(channel.CurrentCSVDesc.Icon),
+		ShortDescription: channel.CurrentCSVDesc.Annotations[annotationDescription],
+		Categories:       manifest.categories(),
+	}, true
+}
+
+func availablePackageDetail(manifest *packageManifest, channel *packageChannel, cluster, namespace string) (*corev1.AvailablePackageDetail, error) {
+	desc := channel.CurrentCSVDesc
+	detail := &corev1.AvailablePackageDetail{
+		AvailablePackageRef: availablePackageRef(manifest, cluster, namespace),
+		Name:                manifest.Name,
+		DisplayName:         displayName(manifest, channel),
+		Version: &corev1.PackageAppVersion{
+			PkgVersion: desc.Version,
+			AppVersion: desc.Version,
+		},
+		HomeUrl:          manifest.Status.Provider.URL,
+		IconUrl:          iconURL(desc.Icon),
+		ShortDescription: desc.Annotations[annotationDescription],
+		Readme:           desc.Description,
+		SourceUrls:       []string{},
+		Maintainers:      []*corev1.Maintainer{},
+		Categories:       manifest.categories(),
+	}
+	for _, link := range desc.Links {
+		if link.URL != "" {
+			detail.SourceUrls = append(detail.SourceUrls, link.URL)
+		}
+	}
+	for _, m := range desc.Maintainers {
+		detail.Maintainers = append(detail.Maintainers, &corev1.Maintainer{Name: m.Name, Email: m.Email})
+	}
+
+	customDetail := &operatorsv1alpha1.OperatorsAvailablePackageCustomDetail{
+		CatalogSource:          manifest.Status.CatalogSource,
+		CatalogSourceNamespace: manifest.Status.CatalogSourceNamespace,
+		DefaultChannel:         manifest.Status.DefaultChannel,
+		Channels:               make([]*operatorsv1alpha1.OperatorChannel, len(manifest.Status.Channels)),
+		Capabilities:           desc.Annotations[annotationCapabilities],
+		InstallModes:           channel.installModes(),
+	}
+	for i, c := range manifest.Status.Channels {
+		customDetail.Channels[i] = &operatorsv1alpha1.OperatorChannel{
+			Name:       c.Name,
+			CurrentCsv: c.CurrentCSV,
+			Version:    c.CurrentCSVDesc.Version,
+		}
+	}
+	anyDetail, err := anypb.New(customDetail)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to create the custom detail of the package %q: %w", detail.Name, err))
+	}
+	detail.CustomDetail = anyDetail
+	return detail, nil
+}
+
+// installed packages
+
+func installedPackageRef(sub *subscription, cluster string) *corev1.InstalledPackageReference {
+	return &corev1.InstalledPackageReference{
+		Context:    &corev1.Context{Cluster: cluster, Namespace: sub.Namespace},
+		Identifier: sub.Name,
+		Plugin:     GetPluginDetail(),
+	}
+}
+
+// installedVersion returns the version of the operator installed by the
+// Subscription, if any.
+func installedVersion(csv *clusterServiceVersion) *corev1.PackageAppVersion {
+	if csv == nil {
+		return nil
+	}
+	return &corev1.PackageAppVersion{
+		PkgVersion: csv.Spec.Version,
+		AppVersion: csv.Spec.Version,
+	}
+}
+
+func installedPackageSummary(sub *subscription, csv *clusterServiceVersion, manifest *packageManifest, cluster string) *corev1.InstalledPackageSummary {
+	summary := &corev1.InstalledPackageSummary{
+		InstalledPackageRef: installedPackageRef(sub, cluster),
+		Name:                sub.Name,
+		PkgVersionReference: &corev1.VersionReference{},
+		CurrentVersion:      installedVersion(csv),
+		PkgDisplayName:      sub.Spec.Package,
+		Status:              subscriptionStatusToInstalledStatus(sub, csv),
+	}
+	if csv != nil {
+		summary.PkgVersionReference.Version = csv.Spec.Version
+		summary.IconUrl = iconURL(csv.Spec.Icon)
+		if csv.Spec.DisplayName != "" {
+			summary.PkgDisplayName = csv.Spec.DisplayName
+		}
+	}
+	if manifest != nil {
+		if channel, ok := manifest.channel(sub.Spec.Channel); ok {
+			summary.LatestVersion = &corev1.PackageAppVersion{
+				PkgVersion: channel.CurrentCSVDesc.Version,
+				AppVersion: channel.CurrentCSVDesc.Version,
+			}
+			summary.ShortDescription = channel.CurrentCSVDesc.Annotations[annotationDescription]
+		}
+	}
+	return summary
+}
+
+func installedPackageDetail(sub *subscription, csv *clusterServiceVersion, cluster string) (*corev1.InstalledPackageDetail, error) {
+	detail := &corev1.InstalledPackageDetail{
+		InstalledPackageRef: installedPackageRef(sub, cluster),
+		PkgVersionReference: &corev1.VersionReference{},
+		Name:                sub.Name,
+		CurrentVersion:      installedVersion(csv),
+		Status:              subscriptionStatusToInstalledStatus(sub, csv),
+		AvailablePackageRef: &corev1.AvailablePackageReference{
+			Context:    &corev1.Context{Cluster: cluster, Namespace: sub.Namespace},
+			Identifier: packageIdentifier(sub.Spec.CatalogSource, sub.Spec.Package),
+			Plugin:     GetPluginDetail(),
+		},
+	}
+	if csv != nil {
+		detail.PkgVersionReference.Version = csv.Spec.Version
+	}
+	anyDetail, err := anypb.New(&operatorsv1alpha1.OperatorsInstalledPackageCustomDetail{
+		Channel:        sub.Spec.Channel,
+		ManualApproval: sub.Spec.InstallPlanApproval == approvalManual,
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to create the custom detail of the installed package %q: %w", sub.Name, err))
+	}
+	detail.CustomDetail = anyDetail
+	return detail, nil
+}
+
+// subscriptionStatusToInstalledStatus returns the status of the operator
+// installed by the Subscription, which is the one of its installed CSV.
+func subscriptionStatusToInstalledStatus(sub *subscription, csv *clusterServiceVersion) *corev1.InstalledPackageStatus {
+	if csv == nil {
+		for _, condition := range sub.Status.Conditions {
+			if condition.Status == "True" && (condition.Type == conditionResolutionFailed || condition.Type == conditionInstallPlanFailed) {
+				return &corev1.InstalledPackageStatus{
+					Reason:     corev1.InstalledPackageStatus_STATUS_REASON_FAILED,
+					UserReason: condition.Message,
+				}
+			}
+		}
+		status := &corev1.InstalledPackageStatus{
+			Reason:     corev1.InstalledPackageStatus_STATUS_REASON_PENDING,
+			UserReason: "Waiting for the InstallPlan",
+		}
+		if sub.Spec.InstallPlanApproval == approvalManual && sub.Status.State == subscriptionStateUpgradePending {
+			status.UserReason = "Waiting for the InstallPlan to be approved"
+		}
+		return status
+	}
+
+	switch csv.Status.Phase {
+	case csvPhaseSucceeded:
+		return &corev1.InstalledPackageStatus{
+			Ready:      true,
+			Reason:     corev1.InstalledPackageStatus_STATUS_REASON_INSTALLED,
+			UserReason: "Deployed",
+		}
+	case csvPhaseFailed:
+		status := &corev1.InstalledPackageStatus{
+			Reason:     corev1.InstalledPackageStatus_STATUS_REASON_FAILED,
+			UserReason: "Install failed",
+		}
+		if csv.Status.Message != "" {
+			status.UserReason = csv.Status.Message
+		}
+		return status
+	default:
+		status := &corev1.InstalledPackageStatus{
+			Reason:     corev1.InstalledPackageStatus_STATUS_REASON_PENDING,
+			UserReason: "Installing",
+		}
+		if csv.Status.Phase != "" {
+			status.UserReason = csv.Status.Phase
+		}
+		return status
+	}
+}
+
+// resourceRefs returns the references to the Subscription, its installed CSV
+// and the Deployments of the operator.
+func resourceRefs(sub *subscription, csv *clusterServiceVersion) []*corev1.ResourceRef {
+	refs := []*corev1.ResourceRef{{
+		ApiVersion: subscriptionsResource.GroupVersion().String(),
+		Kind:       subscriptionKind,
+		Name:       sub.Name,
+		Namespace:  sub.Namespace,
+	}}
+	if csv == nil {
+		return refs
+	}
+	refs = append(refs, &corev1.ResourceRef{
+		ApiVersion: clusterServiceVersionsResource.GroupVersion().String(),
+		Kind:       clusterServiceVersionKind,
+		Name:       csv.Name,
+		Namespace:  csv.Namespace,
+	})
+	for _, deployment := range csv.Spec.Install.Spec.Deployments {
+		refs = append(refs, &corev1.ResourceRef{
+			ApiVersion: "apps/v1",
+			Kind:       "Deployment",
+			Name:       deployment.Name,
+			Namespace:  csv.Namespace,
+		})
+	}
+	return refs
+}
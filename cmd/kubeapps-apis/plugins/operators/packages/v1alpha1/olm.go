@@ -0,0 +1,497 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/connecterror"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+// The OLM resources are handled with the dynamic client and the subset of
+// their fields used by the plugin, rather than depending on the
+// operator-framework API modules.
+// See https://olm.operatorframework.io/docs/concepts/crds/
+var (
+	packageManifestsResource = schema.GroupVersionResource{
+		Group:    "packages.operators.coreos.com",
+		Version:  "v1",
+		Resource: "packagemanifests",
+	}
+	subscriptionsResource = schema.GroupVersionResource{
+		Group:    "operators.coreos.com",
+		Version:  "v1alpha1",
+		Resource: "subscriptions",
+	}
+	clusterServiceVersionsResource = schema.GroupVersionResource{
+		Group:    "operators.coreos.com",
+		Version:  "v1alpha1",
+		Resource: "clusterserviceversions",
+	}
+	operatorGroupsResource = schema.GroupVersionResource{
+		Group:    "operators.coreos.com",
+		Version:  "v1",
+		Resource: "operatorgroups",
+	}
+)
+
+const (
+	subscriptionKind          = "Subscription"
+	clusterServiceVersionKind = "ClusterServiceVersion"
+
+	// catalogLabel is set by the package server on the PackageManifests to
+	// the name of their CatalogSource.
+	catalogLabel = "catalog"
+
+	// annotationCategories is the CSV annotation listing its categories,
+	// separated by commas.
+	annotationCategories = "categories"
+	// annotationDescription is the CSV annotation with its short description.
+	annotationDescription = "description"
+	// annotationCapabilities is the CSV annotation with its capability level.
+	annotationCapabilities = "capabilities"
+
+	approvalAutomatic = "Automatic"
+	approvalManual    = "Manual"
+
+	subscriptionStateUpgradePending = "UpgradePending"
+	conditionResolutionFailed       = "ResolutionFailed"
+	conditionInstallPlanFailed      = "InstallPlanFailed"
+
+	csvPhaseSucceeded = "Succeeded"
+	csvPhaseFailed    = "Failed"
+)
+
+// packageManifest is an operator published in a catalog, as served by the
+// OLM package server.
+type packageManifest struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Status            packageManifestStatus `json:"status,omitempty"`
+}
+
+type packageManifestStatus struct {
+	CatalogSource          string           `json:"catalogSource,omitempty"`
+	CatalogSourceNamespace string           `json:"catalogSourceNamespace,omitempty"`
+	Provider               appLink          `json:"provider,omitempty"`
+	PackageName            string           `json:"packageName,omitempty"`
+	Channels               []packageChannel `json:"channels,omitempty"`
+	DefaultChannel         string           `json:"defaultChannel,omitempty"`
+}
+
+type packageChannel struct {
+	Name           string         `json:"name"`
+	CurrentCSV     string         `json:"currentCSV"`
+	CurrentCSVDesc csvDescription `json:"currentCSVDesc,omitempty"`
+}
+
+// csvDescription is the description of the ClusterServiceVersion at the head
+// of a channel.
+type csvDescription struct {
+	DisplayName  string            `json:"displayName,omitempty"`
+	Icon         []csvIcon         `json:"icon,omitempty"`
+	Version      string            `json:"version,omitempty"`
+	Provider     appLink           `json:"provider,omitempty"`
+	Annotations  map[string]string `json:"annotations,omitempty"`
+	Keywords     []string          `json:"keywords,omitempty"`
+	Links        []appLink         `json:"links,omitempty"`
+	Maintainers  []maintainer      `json:"maintainers,omitempty"`
+	Description  string            `json:"description,omitempty"`
+	InstallModes []installMode     `json:"installModes,omitempty"`
+}
+
+type csvIcon struct {
+	Data      string `json:"base64data"`
+	MediaType string `json:"mediatype"`
+}
+
+type appLink struct {
+	Name string `json:"name,omitempty"`
+	URL  string `json:"url,omitempty"`
+}
+
+type maintainer struct {
+	Name  string `json:"name,omitempty"`
+	Email string `json:"email,omitempty"`
+}
+
+type installMode struct {
+	Type      string `json:"type"`
+	Supported bool   `json:"supported"`
+}
+
+// subscription keeps an operator installed and up to date with a channel.
+type subscription struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              subscriptionSpec   `json:"spec,omitempty"`
+	Status            subscriptionStatus `json:"status,omitempty"`
+}
+
+type subscriptionSpec struct {
+	CatalogSource          string `json:"source"`
+	CatalogSourceNamespace string `json:"sourceNamespace"`
+	Package                string `json:"name"`
+	Channel                string `json:"channel,omitempty"`
+	StartingCSV            string `json:"startingCSV,omitempty"`
+	InstallPlanApproval    string `json:"installPlanApproval,omitempty"`
+}
+
+type subscriptionStatus struct {
+	CurrentCSV   string                  `json:"currentCSV,omitempty"`
+	InstalledCSV string                  `json:"installedCSV,omitempty"`
+	State        string                  `json:"state,omitempty"`
+	Conditions   []subscriptionCondition `json:"conditions,omitempty"`
+}
+
+type subscriptionCondition struct {
+	Type    string `json:"type"`
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+// clusterServiceVersion is an installed version of an operator.
+type clusterServiceVersion struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              csvSpec   `json:"spec,omitempty"`
+	Status            csvStatus `json:"status,omitempty"`
+}
+
+type csvSpec struct {
+	DisplayName string     `json:"displayName,omitempty"`
+	Version     string     `json:"version,omitempty"`
+	Icon        []csvIcon  `json:"icon,omitempty"`
+	Install     csvInstall `json:"install,omitempty"`
+}
+
+type csvInstall struct {
+	Spec csvInstallSpec `json:"spec,omitempty"`
+}
+
+type csvInstallSpec struct {
+	Deployments []csvDeployment `json:"deployments,omitempty"`
+}
+
+type csvDeployment struct {
+	Name string `json:"name"`
+}
+
+type csvStatus struct {
+	Phase   string `json:"phase,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// packageIdentifier returns the identifier of the available package for the
+// operator of the given catalog.
+func packageIdentifier(catalogSource, packageName string) string {
+	return catalogSource + "/" + packageName
+}
+
+// channel returns the channel with the given name.
+func (m *packageManifest) channel(name string) (*packageChannel, bool) {
+	for i := range m.Status.Channels {
+		if m.Status.Channels[i].Name == name {
+			return &m.Status.Channels[i], true
+		}
+	}
+	return nil, false
+}
+
+// defaultChannel returns the channel subscribed to when none is requested.
+func (m *packageManifest) defaultChannel() (*packageChannel, bool) {
+	if channel, ok := m.channel(m.Status.DefaultChannel); ok {
+		return channel, true
+	}
+	if len(m.Status.Channels) > 0 {
+		return &m.Status.Channels[0], true
+	}
+	return nil, false
+}
+
+// channelForVersion returns the channel whose head has the given version,
+// preferring the default channel when several of them do.
+func (m *packageManifest) channelForVersion(version string) (*packageChannel, bool) {
+	if channel, ok := m.defaultChannel(); ok && channel.CurrentCSVDesc.Version == version {
+		return channel, true
+	}
+	for i := range m.Status.Channels {
+		if m.Status.Channels[i].CurrentCSVDesc.Version == version {
+			return &m.Status.Channels[i], true
+		}
+	}
+	return nil, false
+}
+
+// versions returns the distinct versions at the head of the channels, from
+// the most recent.
+func (m *packageManifest) versions() []string {
+	versions := semver.Collection{}
+	invalid := []string{}
+	seen := map[string]bool{}
+	for _, channel := range m.Status.Channels {
+		version := channel.CurrentCSVDesc.Version
+		if version == "" || seen[version] {
+			continue
+		}
+		seen[version] = true
+		if v, err := semver.NewVersion(version); err == nil {
+			versions = append(versions, v)
+		} else {
+			invalid = append(invalid, version)
+		}
+	}
+	sort.Sort(sort.Reverse(versions))
+	result := make([]string, 0, len(seen))
+	for _, v := range versions {
+		result = append(result, v.Original())
+	}
+	// Versions which are not semantic are listed last.
+	return append(result, invalid...)
+}
+
+// categories returns the categories of the operator, as declared by the CSV
+// at the head of its default channel.
+func (m *packageManifest) categories() []string {
+	channel, ok := m.defaultChannel()
+	if !ok {
+		return []string{}
+	}
+	categories := []string{}
+	for _, category := range strings.Split(channel.CurrentCSVDesc.Annotations[annotationCategories], ",") {
+		if category = strings.TrimSpace(category); category != "" {
+			categories = append(categories, category)
+		}
+	}
+	return categories
+}
+
+// installModes returns the install modes supported by the operator.
+func (c *packageChannel) installModes() []string {
+	modes := []string{}
+	for _, mode := range c.CurrentCSVDesc.InstallModes {
+		if mode.Supported {
+			modes = append(modes, mode.Type)
+		}
+	}
+	return modes
+}
+
+// iconURL returns the first icon as a data URL, which the core packages
+// service normalizes.
+func iconURL(icons []csvIcon) string {
+	if len(icons) == 0 || icons[0].Data == "" {
+		return ""
+	}
+	return "data:" + icons[0].MediaType + ";base64," + icons[0].Data
+}
+
+func (s *Server) getResource(headers http.Header, cluster, namespace string, gvr schema.GroupVersionResource) (dynamic.ResourceInterface, error) {
+	dynClient, err := s.clientGetter.Dynamic(headers, cluster)
+	if err != nil {
+		return nil, err
+	}
+	return dynClient.Resource(gvr).Namespace(namespace), nil
+}
+
+// getObject gets the named resource, converting it to the given type.
+func getObject[T any](ctx context.Context, resource dynamic.ResourceInterface, name string) (*T, error) {
+	unstructuredObj, err := resource.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	obj := new(T)
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(unstructuredObj.Object, obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// listObjects lists the resources matching the label selector, converting
+// them to the given type.
+func listObjects[T any](ctx context.Context, resource dynamic.ResourceInterface, labelSelector string) ([]*T, error) {
+	unstructuredList, err := resource.List(ctx, metav1.ListOptions{LabelSelector: labelSelector})
+	if err != nil {
+		return nil, err
+	}
+	objs := make([]*T, len(unstructuredList.Items))
+	for i, unstructuredObj := range unstructuredList.Items {
+		objs[i] = new(T)
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(unstructuredObj.Object, objs[i]); err != nil {
+			return nil, err
+		}
+	}
+	return objs, nil
+}
+
+// getPackageManifests returns the operators available in the namespace, that
+// is, the ones of the global catalogs and of the catalogs of the namespace.
+// The operators available in several namespaces are only returned once.
+func (s *Server) getPackageManifests(ctx context.Context, headers http.Header, cluster, namespace string) ([]*packageManifest, error) {
+	resource, err := s.getResource(headers, cluster, namespace, packageManifestsResource)
+	if err != nil {
+		return nil, err
+	}
+	manifests, err := listObjects[packageManifest](ctx, resource, "")
+	if err != nil {
+		return nil, err
+	}
+	result := []*packageManifest{}
+	seen := map[string]bool{}
+	for _, manifest := range manifests {
+		identifier := packageIdentifier(manifest.Status.CatalogSource, manifest.Name)
+		if !seen[identifier] {
+			seen[identifier] = true
+			result = append(result, manifest)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return packageIdentifier(result[i].Status.CatalogSource, result[i].Name) < packageIdentifier(result[j].Status.CatalogSource, result[j].Name)
+	})
+	return result, nil
+}
+
+// getPackageManifest returns the operator of the catalog with the given
+// identifier, as available in the namespace, failing with a connect error.
+func (s *Server) getPackageManifest(ctx context.Context, headers http.Header, cluster, namespace, identifier string) (*packageManifest, error) {
+	catalogSource, packageName, ok := strings.Cut(identifier, "/")
+	if !ok || catalogSource == "" || packageName == "" {
+		return nil, errInvalidIdentifier(identifier)
+	}
+	resource, err := s.getResource(headers, cluster, namespace, packageManifestsResource)
+	if err != nil {
+		return nil, err
+	}
+	// Operators of different catalogs may have the same name.
+	selector := labels.SelectorFromSet(labels.Set{catalogLabel: catalogSource})
+	manifests, err := listObjects[packageManifest](ctx, resource, selector.String())
+	if err != nil {
+		return nil, connecterror.FromK8sError("get", "PackageManifest", identifier, err)
+	}
+	for _, manifest := range manifests {
+		if manifest.Name == packageName {
+			return manifest, nil
+		}
+	}
+	return nil, errPackageNotFound(identifier)
+}
+
+// getSubscription returns the Subscription with the given name.
+func (s *Server) getSubscription(ctx context.Context, headers http.Header, cluster, namespace, name string) (*subscription, error) {
+	resource, err := s.getResource(headers, cluster, namespace, subscriptionsResource)
+	if err != nil {
+		return nil, err
+	}
+	return getObject[subscription](ctx, resource, name)
+}
+
+// getSubscriptions returns the Subscriptions of the namespace, or of every
+// namespace if empty.
+func (s *Server) getSubscriptions(ctx context.Context, headers http.Header, cluster, namespace, labelSelector string) ([]*subscription, error) {
+	resource, err := s.getResource(headers, cluster, namespace, subscriptionsResource)
+	if err != nil {
+		return nil, err
+	}
+	subscriptions, err := listObjects[subscription](ctx, resource, labelSelector)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(subscriptions, func(i, j int) bool {
+		if subscriptions[i].Namespace != subscriptions[j].Namespace {
+			return subscriptions[i].Namespace < subscriptions[j].Namespace
+		}
+		return subscriptions[i].Name < subscriptions[j].Name
+	})
+	return subscriptions, nil
+}
+
+// applySubscription creates the Subscription, or updates it if it exists.
+func (s *Server) applySubscription(ctx context.Context, headers http.Header, cluster string, sub *subscription, create bool) error {
+	resource, err := s.getResource(headers, cluster, sub.Namespace, subscriptionsResource)
+	if err != nil {
+		return err
+	}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(sub)
+	if err != nil {
+		return err
+	}
+	// The status is managed by OLM.
+	delete(content, "status")
+	if create {
+		_, err = resource.Create(ctx, &unstructured.Unstructured{Object: content}, metav1.CreateOptions{})
+	} else {
+		_, err = resource.Update(ctx, &unstructured.Unstructured{Object: content}, metav1.UpdateOptions{})
+	}
+	return err
+}
+
+// deleteSubscription deletes the Subscription with the given name.
+func (s *Server) deleteSubscription(ctx context.Context, headers http.Header, cluster, namespace, name string) error {
+	resource, err := s.getResource(headers, cluster, namespace, subscriptionsResource)
+	if err != nil {
+		return err
+	}
+	return resource.Delete(ctx, name, metav1.DeleteOptions{})
+}
+
+// getCSV returns the ClusterServiceVersion with the given name.
+func (s *Server) getCSV(ctx context.Context, headers http.Header, cluster, namespace, name string) (*clusterServiceVersion, error) {
+	resource, err := s.getResource(headers, cluster, namespace, clusterServiceVersionsResource)
+	if err != nil {
+		return nil, err
+	}
+	return getObject[clusterServiceVersion](ctx, resource, name)
+}
+
+// getCSVs returns the ClusterServiceVersions of the namespace, or of every
+// namespace if empty, keyed by namespace and name.
+func (s *Server) getCSVs(ctx context.Context, headers http.Header, cluster, namespace string) (map[string]*clusterServiceVersion, error) {
+	resource, err := s.getResource(headers, cluster, namespace, clusterServiceVersionsResource)
+	if err != nil {
+		return nil, err
+	}
+	csvs, err := listObjects[clusterServiceVersion](ctx, resource, "")
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]*clusterServiceVersion, len(csvs))
+	for _, csv := range csvs {
+		result[csv.Namespace+"/"+csv.Name] = csv
+	}
+	return result, nil
+}
+
+// deleteCSV deletes the ClusterServiceVersion with the given name, which
+// uninstalls the operator.
+func (s *Server) deleteCSV(ctx context.Context, headers http.Header, cluster, namespace, name string) error {
+	resource, err := s.getResource(headers, cluster, namespace, clusterServiceVersionsResource)
+	if err != nil {
+		return err
+	}
+	return resource.Delete(ctx, name, metav1.DeleteOptions{})
+}
+
+// hasOperatorGroup returns whether the namespace has an OperatorGroup, without
+// which OLM does not install the operators subscribed to in it.
+func (s *Server) hasOperatorGroup(ctx context.Context, headers http.Header, cluster, namespace string) (bool, error) {
+	resource, err := s.getResource(headers, cluster, namespace, operatorGroupsResource)
+	if err != nil {
+		return false, err
+	}
+	operatorGroups, err := resource.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return false, err
+	}
+	return len(operatorGroups.Items) > 0, nil
+}
@@ -0,0 +1,638 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core"
+	corev1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	corev1connect "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1/v1alpha1connect"
+	operatorsv1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/plugins/operators/packages/v1alpha1"
+	packagesConnect "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/plugins/operators/packages/v1alpha1/v1alpha1connect"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/clientgetter"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/connecterror"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/paginate"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/pkgutils"
+	"google.golang.org/protobuf/types/known/anypb"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	log "k8s.io/klog/v2"
+)
+
+// Compile-time statement to ensure this service implementation satisfies the core packaging API
+var _ corev1connect.PackagesServiceHandler = (*Server)(nil)
+
+// Server implements the operators packages v1alpha1 interface, listing the
+// operators of the OLM catalogs and installing them with Subscriptions.
+type Server struct {
+	packagesConnect.UnimplementedOperatorsPackagesServiceHandler
+	// clientGetter is a field so that it can be switched in tests for
+	// a fake client. NewServer() below sets this automatically with the
+	// non-test implementation.
+	clientGetter clientgetter.ClientProviderInterface
+	// kubeappsCluster is the cluster assumed when none is requested.
+	kubeappsCluster string
+}
+
+// NewServer returns a Server automatically configured with a function to obtain
+// the k8s client config.
+func NewServer(configGetter core.KubernetesConfigGetter, kubeappsCluster string) *Server {
+	clientProvider, err := clientgetter.NewClientProvider(configGetter, clientgetter.Options{})
+	if err != nil {
+		log.Fatalf("%s", err)
+	}
+	return &Server{
+		clientGetter:    clientProvider,
+		kubeappsCluster: kubeappsCluster,
+	}
+}
+
+// GetAvailablePackageSummaries returns the operators of the catalogs
+// available in the requested namespace.
+func (s *Server) GetAvailablePackageSummaries(ctx context.Context, request *connect.Request[corev1.GetAvailablePackageSummariesRequest]) (*connect.Response[corev1.GetAvailablePackageSummariesResponse], error) {
+	cluster := request.Msg.GetContext().GetCluster()
+	namespace := request.Msg.GetContext().GetNamespace()
+	log.InfoS("+operators GetAvailablePackageSummaries", "cluster", cluster, "namespace", namespace)
+
+	pageSize := int(request.Msg.GetPaginationOptions().GetPageSize())
+	itemOffset, err := paginate.ItemOffsetFromPageToken(request.Msg.GetPaginationOptions().GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+	if cluster == "" {
+		cluster = s.kubeappsCluster
+	}
+
+	manifests, err := s.getPackageManifests(ctx, request.Header(), cluster, namespace)
+	if err != nil {
+		return nil, connecterror.FromK8sError("get", "PackageManifest", "", err)
+	}
+
+	filterOptions := request.Msg.GetFilterOptions()
+	summaries := []*corev1.AvailablePackageSummary{}
+	categories := []string{}
+	for _, manifest := range manifests {
+		summary, ok := availablePackageSummary(manifest, cluster, namespace)
+		if !ok {
+			log.InfoS("+operators ignoring operator without channels", "catalog", manifest.Status.CatalogSource, "package", manifest.Name)
+			continue
+		}
+		categories = append(categories, summary.Categories...)
+		if matchesFilters(manifest, summary, filterOptions) {
+			summaries = append(summaries, summary)
+		}
+	}
+
+	nextPageToken := ""
+	if pageSize > 0 {
+		if itemOffset > len(summaries) {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Invalid pagination arguments %v", request.Msg.GetPaginationOptions()))
+		}
+		summaries = summaries[itemOffset:]
+		if len(summaries) > pageSize {
+			summaries = summaries[:pageSize]
+			nextPageToken = fmt.Sprintf("%d", itemOffset+pageSize)
+		}
+	}
+
+	return connect.NewResponse(&corev1.GetAvailablePackageSummariesResponse{
+		AvailablePackageSummaries: summaries,
+		Categories:                pkgutils.SortedDistinct(categories),
+		NextPageToken:             nextPageToken,
+	}), nil
+}
+
+// GetAvailablePackageDetail returns the details of a version of an operator,
+// the one of its default channel if none is requested.
+func (s *Server) GetAvailablePackageDetail(ctx context.Context, request *connect.Request[corev1.GetAvailablePackageDetailRequest]) (*connect.Response[corev1.GetAvailablePackageDetailResponse], error) {
+	packageRef := request.Msg.GetAvailablePackageRef()
+	cluster := packageRef.GetContext().GetCluster()
+	namespace := packageRef.GetContext().GetNamespace()
+	log.InfoS("+operators GetAvailablePackageDetail", "cluster", cluster, "namespace", namespace, "id", packageRef.GetIdentifier())
+
+	if cluster == "" {
+		cluster = s.kubeappsCluster
+	}
+
+	manifest, err := s.getPackageManifest(ctx, request.Header(), cluster, namespace, packageRef.GetIdentifier())
+	if err != nil {
+		return nil, err
+	}
+	channel, err := resolveChannel(manifest, "", request.Msg.GetPkgVersion())
+	if err != nil {
+		return nil, err
+	}
+	detail, err := availablePackageDetail(manifest, channel, cluster, namespace)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&corev1.GetAvailablePackageDetailResponse{
+		AvailablePackageDetail: detail,
+	}), nil
+}
+
+// GetAvailablePackageVersions returns the versions at the head of the
+// channels of an operator, from the most recent.
+func (s *Server) GetAvailablePackageVersions(ctx context.Context, request *connect.Request[corev1.GetAvailablePackageVersionsRequest]) (*connect.Response[corev1.GetAvailablePackageVersionsResponse], error) {
+	packageRef := request.Msg.GetAvailablePackageRef()
+	cluster := packageRef.GetContext().GetCluster()
+	namespace := packageRef.GetContext().GetNamespace()
+	log.InfoS("+operators GetAvailablePackageVersions", "cluster", cluster, "namespace", namespace, "id", packageRef.GetIdentifier())
+
+	if cluster == "" {
+		cluster = s.kubeappsCluster
+	}
+
+	manifest, err := s.getPackageManifest(ctx, request.Header(), cluster, namespace, packageRef.GetIdentifier())
+	if err != nil {
+		return nil, err
+	}
+
+	// Operators have no version other than the one of their CSV.
+	versions := manifest.versions()
+	appVersions := make([]*corev1.PackageAppVersion, len(versions))
+	for i, version := range versions {
+		appVersions[i] = &corev1.PackageAppVersion{PkgVersion: version, AppVersion: version}
+	}
+	if err := pkgutils.SetUpgradeImpacts(appVersions, nil, request.Msg.GetRelativeToVersion()); err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&corev1.GetAvailablePackageVersionsResponse{
+		PackageAppVersions: appVersions,
+	}), nil
+}
+
+// GetAvailablePackageTextAsset returns a range of a text asset of an operator.
+func (s *Server) GetAvailablePackageTextAsset(ctx context.Context, request *connect.Request[corev1.GetAvailablePackageTextAssetRequest]) (*connect.Response[corev1.GetAvailablePackageTextAssetResponse], error) {
+	return pkgutils.GetAvailablePackageTextAsset(ctx, request, s.GetAvailablePackageDetail)
+}
+
+// GetAvailablePackageFilters returns the categories and providers of the
+// operators available in the requested namespace.
+func (s *Server) GetAvailablePackageFilters(ctx context.Context, request *connect.Request[corev1.GetAvailablePackageFiltersRequest]) (*connect.Response[corev1.GetAvailablePackageFiltersResponse], error) {
+	cluster := request.Msg.GetContext().GetCluster()
+	namespace := request.Msg.GetContext().GetNamespace()
+	log.InfoS("+operators GetAvailablePackageFilters", "cluster", cluster, "namespace", namespace)
+
+	if cluster == "" {
+		cluster = s.kubeappsCluster
+	}
+
+	manifests, err := s.getPackageManifests(ctx, request.Header(), cluster, namespace)
+	if err != nil {
+		return nil, connecterror.FromK8sError("get", "PackageManifest", "", err)
+	}
+	categories, providers := []string{}, []string{}
+	for _, manifest := range manifests {
+		categories = append(categories, manifest.categories()...)
+		if manifest.Status.Provider.Name != "" {
+			providers = append(providers, manifest.Status.Provider.Name)
+		}
+	}
+	return connect.NewResponse(&corev1.GetAvailablePackageFiltersResponse{
+		Categories: pkgutils.SortedDistinct(categories),
+		Providers:  pkgutils.SortedDistinct(providers),
+	}), nil
+}
+
+// GetInstalledPackageSummaries returns the operators installed with
+// Subscriptions.
+func (s *Server) GetInstalledPackageSummaries(ctx context.Context, request *connect.Request[corev1.GetInstalledPackageSummariesRequest]) (*connect.Response[corev1.GetInstalledPackageSummariesResponse], error) {
+	cluster := request.Msg.GetContext().GetCluster()
+	namespace := request.Msg.GetContext().GetNamespace()
+	log.InfoS("+operators GetInstalledPackageSummaries", "cluster", cluster, "namespace", namespace)
+
+	pageSize := int(request.Msg.GetPaginationOptions().GetPageSize())
+	itemOffset, err := paginate.ItemOffsetFromPageToken(request.Msg.GetPaginationOptions().GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+	if cluster == "" {
+		cluster = s.kubeappsCluster
+	}
+
+	filterOptions := request.Msg.GetFilterOptions()
+	selector, err := pkgutils.InstalledPackageLabelSelector(filterOptions)
+	if err != nil {
+		return nil, err
+	}
+	subscriptions, err := s.getSubscriptions(ctx, request.Header(), cluster, namespace, selector.String())
+	if err != nil {
+		return nil, connecterror.FromK8sError("get", "Subscription", "", err)
+	}
+	csvs, err := s.getCSVs(ctx, request.Header(), cluster, namespace)
+	if err != nil {
+		return nil, connecterror.FromK8sError("get", "ClusterServiceVersion", "", err)
+	}
+	// The latest versions are only informative, so the summaries are
+	// returned without them if the catalogs cannot be read.
+	manifests := map[string]*packageManifest{}
+	if packageManifests, err := s.getPackageManifests(ctx, request.Header(), cluster, namespace); err == nil {
+		for _, manifest := range packageManifests {
+			manifests[packageIdentifier(manifest.Status.CatalogSource, manifest.Name)] = manifest
+		}
+	} else {
+		log.InfoS("+operators unable to get the PackageManifests", "cluster", cluster, "namespace", namespace, "error", err)
+	}
+
+	summaries := make([]*corev1.InstalledPackageSummary, len(subscriptions))
+	for i, sub := range subscriptions {
+		csv := csvs[sub.Namespace+"/"+sub.Status.InstalledCSV]
+		manifest := manifests[packageIdentifier(sub.Spec.CatalogSource, sub.Spec.Package)]
+		summaries[i] = installedPackageSummary(sub, csv, manifest, cluster)
+	}
+	summaries = pkgutils.FilterInstalledPackageSummaries(summaries, filterOptions)
+
+	nextPageToken := ""
+	if pageSize > 0 {
+		if itemOffset > len(summaries) {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Invalid pagination arguments %v", request.Msg.GetPaginationOptions()))
+		}
+		summaries = summaries[itemOffset:]
+		if len(summaries) > pageSize {
+			summaries = summaries[:pageSize]
+			nextPageToken = fmt.Sprintf("%d", itemOffset+pageSize)
+		}
+	}
+	return connect.NewResponse(&corev1.GetInstalledPackageSummariesResponse{
+		InstalledPackageSummaries: summaries,
+		NextPageToken:             nextPageToken,
+	}), nil
+}
+
+// GetInstalledPackageDetail returns the details of an operator installed with
+// a Subscription.
+func (s *Server) GetInstalledPackageDetail(ctx context.Context, request *connect.Request[corev1.GetInstalledPackageDetailRequest]) (*connect.Response[corev1.GetInstalledPackageDetailResponse], error) {
+	installedRef := request.Msg.GetInstalledPackageRef()
+	cluster := installedRef.GetContext().GetCluster()
+	namespace := installedRef.GetContext().GetNamespace()
+	identifier := installedRef.GetIdentifier()
+	log.InfoS("+operators GetInstalledPackageDetail", "cluster", cluster, "namespace", namespace, "id", identifier)
+
+	if cluster == "" {
+		cluster = s.kubeappsCluster
+	}
+
+	sub, csv, err := s.getInstalledOperator(ctx, request.Header(), cluster, namespace, identifier)
+	if err != nil {
+		return nil, err
+	}
+	detail, err := installedPackageDetail(sub, csv, cluster)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&corev1.GetInstalledPackageDetailResponse{
+		InstalledPackageDetail: detail,
+	}), nil
+}
+
+// GetInstalledPackageOperationStatus returns the status of the CSV of an installed operator.
+func (s *Server) GetInstalledPackageOperationStatus(ctx context.Context, request *connect.Request[corev1.GetInstalledPackageOperationStatusRequest]) (*connect.Response[corev1.GetInstalledPackageOperationStatusResponse], error) {
+	return pkgutils.GetInstalledPackageOperationStatus(ctx, request, s.GetInstalledPackageDetail)
+}
+
+// CheckInstalledPackageNameAvailability checks whether an operator can be
+// installed with the given name, that is, whether no Subscription already has it.
+func (s *Server) CheckInstalledPackageNameAvailability(ctx context.Context, request *connect.Request[corev1.CheckInstalledPackageNameAvailabilityRequest]) (*connect.Response[corev1.CheckInstalledPackageNameAvailabilityResponse], error) {
+	cluster := request.Msg.GetTargetContext().GetCluster()
+	namespace := request.Msg.GetTargetContext().GetNamespace()
+	name := request.Msg.GetName()
+	log.InfoS("+operators CheckInstalledPackageNameAvailability", "cluster", cluster, "namespace", namespace, "name", name)
+
+	if cluster == "" {
+		cluster = s.kubeappsCluster
+	}
+
+	response := &corev1.CheckInstalledPackageNameAvailabilityResponse{Available: true}
+	_, err := s.getSubscription(ctx, request.Header(), cluster, namespace, name)
+	if err == nil {
+		response.Available = false
+		response.Conflicts = []*corev1.InstalledPackageReference{{
+			Context:    &corev1.Context{Cluster: cluster, Namespace: namespace},
+			Identifier: name,
+			Plugin:     GetPluginDetail(),
+		}}
+	} else if !errors.IsNotFound(err) {
+		return nil, connecterror.FromK8sError("get", "Subscription", name, err)
+	}
+	return connect.NewResponse(response), nil
+}
+
+// CreateInstalledPackage installs an operator by creating a Subscription to
+// the channel matching the requested version, the default one if none is
+// requested. The target namespace must have an OperatorGroup.
+func (s *Server) CreateInstalledPackage(ctx context.Context, request *connect.Request[corev1.CreateInstalledPackageRequest]) (*connect.Response[corev1.CreateInstalledPackageResponse], error) {
+	targetCluster := request.Msg.GetTargetContext().GetCluster()
+	targetNamespace := request.Msg.GetTargetContext().GetNamespace()
+	name := request.Msg.GetName()
+	log.InfoS("+operators CreateInstalledPackage", "cluster", targetCluster, "namespace", targetNamespace, "id", name)
+
+	identifier := request.Msg.GetAvailablePackageRef().GetIdentifier()
+	if identifier == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("No request AvailablePackageRef identifier provided"))
+	}
+	if name == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("No request Name provided"))
+	}
+	if targetNamespace == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("No request TargetContext namespace provided"))
+	}
+	if request.Msg.GetValues() != "" {
+		return nil, errValuesNotSupported()
+	}
+	customDetail, err := installedPackageCustomDetail(request.Msg.GetCustomDetail())
+	if err != nil {
+		return nil, err
+	}
+	if targetCluster == "" {
+		targetCluster = s.kubeappsCluster
+	}
+
+	manifest, err := s.getPackageManifest(ctx, request.Header(), targetCluster, targetNamespace, identifier)
+	if err != nil {
+		return nil, err
+	}
+	channel, err := resolveChannel(manifest, customDetail.GetChannel(), request.Msg.GetPkgVersionReference().GetVersion())
+	if err != nil {
+		return nil, err
+	}
+	hasOperatorGroup, err := s.hasOperatorGroup(ctx, request.Header(), targetCluster, targetNamespace)
+	if err != nil {
+		return nil, connecterror.FromK8sError("get", "OperatorGroup", "", err)
+	}
+	if !hasOperatorGroup {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("Unable to install the operator %q in the namespace %q, which has no OperatorGroup", identifier, targetNamespace))
+	}
+
+	sub := &subscription{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       subscriptionKind,
+			APIVersion: subscriptionsResource.GroupVersion().String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: targetNamespace,
+		},
+		Spec: subscriptionSpec{
+			CatalogSource:          manifest.Status.CatalogSource,
+			CatalogSourceNamespace: manifest.Status.CatalogSourceNamespace,
+			Package:                manifest.Name,
+			Channel:                channel.Name,
+			StartingCSV:            channel.CurrentCSV,
+			InstallPlanApproval:    installPlanApproval(customDetail.GetManualApproval()),
+		},
+	}
+	if err := s.applySubscription(ctx, request.Header(), targetCluster, sub, true); err != nil {
+		return nil, connecterror.FromK8sError("create", "Subscription", name, err)
+	}
+
+	return connect.NewResponse(&corev1.CreateInstalledPackageResponse{
+		InstalledPackageRef: installedPackageRef(sub, targetCluster),
+	}), nil
+}
+
+// UpdateInstalledPackage switches the Subscription of an installed operator
+// to the channel matching the requested version, or to the requested channel,
+// and updates its approval strategy. OLM then upgrades the operator, once
+// the InstallPlan is approved if the approval is manual.
+func (s *Server) UpdateInstalledPackage(ctx context.Context, request *connect.Request[corev1.UpdateInstalledPackageRequest]) (*connect.Response[corev1.UpdateInstalledPackageResponse], error) {
+	installedRef := request.Msg.GetInstalledPackageRef()
+	cluster := installedRef.GetContext().GetCluster()
+	namespace := installedRef.GetContext().GetNamespace()
+	identifier := installedRef.GetIdentifier()
+	log.InfoS("+operators UpdateInstalledPackage", "cluster", cluster, "namespace", namespace, "id", identifier)
+
+	if request.Msg.GetValues() != "" {
+		return nil, errValuesNotSupported()
+	}
+	customDetail, err := installedPackageCustomDetail(request.Msg.GetCustomDetail())
+	if err != nil {
+		return nil, err
+	}
+	if cluster == "" {
+		cluster = s.kubeappsCluster
+	}
+
+	sub, err := s.getSubscription(ctx, request.Header(), cluster, namespace, identifier)
+	if err != nil {
+		return nil, connecterror.FromK8sError("get", "Subscription", identifier, err)
+	}
+
+	channelName := customDetail.GetChannel()
+	version := request.Msg.GetPkgVersionReference().GetVersion()
+	if channelName != "" || version != "" {
+		manifest, err := s.getPackageManifest(ctx, request.Header(), cluster, namespace, packageIdentifier(sub.Spec.CatalogSource, sub.Spec.Package))
+		if err != nil {
+			return nil, err
+		}
+		// The current channel is kept while its head has the requested version,
+		// or satisfies the requested range.
+		if current, ok := manifest.channel(sub.Spec.Channel); ok && channelName == "" {
+			if _, err := pkgutils.ResolveVersionReference(version, []string{current.CurrentCSVDesc.Version}, identifier); err == nil {
+				channelName = current.Name
+			}
+		}
+		channel, err := resolveChannel(manifest, channelName, version)
+		if err != nil {
+			return nil, err
+		}
+		sub.Spec.Channel = channel.Name
+	}
+	if request.Msg.GetCustomDetail() != nil {
+		sub.Spec.InstallPlanApproval = installPlanApproval(customDetail.GetManualApproval())
+	}
+
+	if err := s.applySubscription(ctx, request.Header(), cluster, sub, false); err != nil {
+		return nil, connecterror.FromK8sError("update", "Subscription", identifier, err)
+	}
+
+	return connect.NewResponse(&corev1.UpdateInstalledPackageResponse{
+		InstalledPackageRef: installedPackageRef(sub, cluster),
+	}), nil
+}
+
+// DeleteInstalledPackage uninstalls an operator by deleting its Subscription
+// and its installed CSV, which makes OLM delete the operator Deployments. The
+// custom resource definitions, and so the custom resources, are kept.
+func (s *Server) DeleteInstalledPackage(ctx context.Context, request *connect.Request[corev1.DeleteInstalledPackageRequest]) (*connect.Response[corev1.DeleteInstalledPackageResponse], error) {
+	installedRef := request.Msg.GetInstalledPackageRef()
+	cluster := installedRef.GetContext().GetCluster()
+	namespace := installedRef.GetContext().GetNamespace()
+	identifier := installedRef.GetIdentifier()
+	log.InfoS("+operators DeleteInstalledPackage", "cluster", cluster, "namespace", namespace, "id", identifier)
+
+	if cluster == "" {
+		cluster = s.kubeappsCluster
+	}
+
+	sub, err := s.getSubscription(ctx, request.Header(), cluster, namespace, identifier)
+	if err != nil {
+		return nil, connecterror.FromK8sError("get", "Subscription", identifier, err)
+	}
+	if err := s.deleteSubscription(ctx, request.Header(), cluster, namespace, identifier); err != nil {
+		return nil, connecterror.FromK8sError("delete", "Subscription", identifier, err)
+	}
+	if csvName := sub.Status.InstalledCSV; csvName != "" {
+		if err := s.deleteCSV(ctx, request.Header(), cluster, namespace, csvName); err != nil && !errors.IsNotFound(err) {
+			return nil, connecterror.FromK8sError("delete", "ClusterServiceVersion", csvName, err)
+		}
+	}
+	return connect.NewResponse(&corev1.DeleteInstalledPackageResponse{}), nil
+}
+
+// GetInstalledPackageResourceRefs returns the references to the Subscription
+// of an installed operator, its CSV and the operator Deployments.
+func (s *Server) GetInstalledPackageResourceRefs(ctx context.Context, request *connect.Request[corev1.GetInstalledPackageResourceRefsRequest]) (*connect.Response[corev1.GetInstalledPackageResourceRefsResponse], error) {
+	installedRef := request.Msg.GetInstalledPackageRef()
+	cluster := installedRef.GetContext().GetCluster()
+	namespace := installedRef.GetContext().GetNamespace()
+	identifier := installedRef.GetIdentifier()
+	log.InfoS("+operators GetInstalledPackageResourceRefs", "cluster", cluster, "namespace", namespace, "id", identifier)
+
+	if cluster == "" {
+		cluster = s.kubeappsCluster
+	}
+
+	sub, csv, err := s.getInstalledOperator(ctx, request.Header(), cluster, namespace, identifier)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&corev1.GetInstalledPackageResourceRefsResponse{
+		Context:      &corev1.Context{Cluster: cluster, Namespace: namespace},
+		ResourceRefs: resourceRefs(sub, csv),
+	}), nil
+}
+
+// getInstalledOperator returns the Subscription with the given name and its
+// installed CSV, nil if the operator is not installed yet.
+func (s *Server) getInstalledOperator(ctx context.Context, headers http.Header, cluster, namespace, name string) (*subscription, *clusterServiceVersion, error) {
+	sub, err := s.getSubscription(ctx, headers, cluster, namespace, name)
+	if err != nil {
+		return nil, nil, connecterror.FromK8sError("get", "Subscription", name, err)
+	}
+	if sub.Status.InstalledCSV == "" {
+		return sub, nil, nil
+	}
+	csv, err := s.getCSV(ctx, headers, cluster, namespace, sub.Status.InstalledCSV)
+	if errors.IsNotFound(err) {
+		return sub, nil, nil
+	} else if err != nil {
+		return nil, nil, connecterror.FromK8sError("get", "ClusterServiceVersion", sub.Status.InstalledCSV, err)
+	}
+	return sub, csv, nil
+}
+
+// resolveChannel returns the requested channel, which must have the requested
+// version if any, or else the channel whose head has the requested version,
+// or else the default channel.
+func resolveChannel(manifest *packageManifest, channelName, version string) (*packageChannel, error) {
+	identifier := packageIdentifier(manifest.Status.CatalogSource, manifest.Name)
+	if pkgutils.IsVersionRange(version) {
+		// An operator is installed at the version at the head of a channel, so
+		// a range is resolved to the highest of those satisfying it.
+		available := manifest.versions()
+		if channel, ok := manifest.channel(channelName); ok {
+			available = []string{channel.CurrentCSVDesc.Version}
+		}
+		resolved, err := pkgutils.ResolveVersionReference(version, available, identifier)
+		if err != nil {
+			return nil, err
+		}
+		version = resolved
+	}
+	switch {
+	case channelName != "":
+		channel, ok := manifest.channel(channelName)
+		if !ok {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("Unable to find the channel %q of the operator %q", channelName, identifier))
+		}
+		if version != "" && channel.CurrentCSVDesc.Version != version {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("The channel %q of the operator %q provides the version %q rather than %q", channelName, identifier, channel.CurrentCSVDesc.Version, version))
+		}
+		return channel, nil
+	case version != "":
+		channel, ok := manifest.channelForVersion(version)
+		if !ok {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("Unable to find the version %q of the operator %q", version, identifier))
+		}
+		return channel, nil
+	default:
+		channel, ok := manifest.defaultChannel()
+		if !ok {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("Unable to find a channel of the operator %q", identifier))
+		}
+		return channel, nil
+	}
+}
+
+// installedPackageCustomDetail returns the custom detail of a create or update
+// request, empty if none is provided.
+func installedPackageCustomDetail(customDetail *anypb.Any) (*operatorsv1alpha1.OperatorsInstalledPackageCustomDetail, error) {
+	detail := &operatorsv1alpha1.OperatorsInstalledPackageCustomDetail{}
+	if customDetail == nil {
+		return detail, nil
+	}
+	if err := customDetail.UnmarshalTo(detail); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Invalid custom detail, expected an OperatorsInstalledPackageCustomDetail: %w", err))
+	}
+	return detail, nil
+}
+
+func installPlanApproval(manual bool) string {
+	if manual {
+		return approvalManual
+	}
+	return approvalAutomatic
+}
+
+// matchesFilters returns whether the operator matches the filter options.
+func matchesFilters(manifest *packageManifest, summary *corev1.AvailablePackageSummary, filterOptions *corev1.FilterOptions) bool {
+	if query := strings.ToLower(filterOptions.GetQuery()); query != "" &&
+		!strings.Contains(strings.ToLower(summary.Name), query) &&
+		!strings.Contains(strings.ToLower(summary.DisplayName), query) &&
+		!strings.Contains(strings.ToLower(summary.ShortDescription), query) {
+		return false
+	}
+	if repositories := filterOptions.GetRepositories(); len(repositories) > 0 && !contains(repositories, manifest.Status.CatalogSource) {
+		return false
+	}
+	if providers := filterOptions.GetProviders(); len(providers) > 0 && !contains(providers, manifest.Status.Provider.Name) {
+		return false
+	}
+	if categories := filterOptions.GetCategories(); len(categories) > 0 {
+		for _, category := range summary.Categories {
+			if contains(categories, category) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func errInvalidIdentifier(identifier string) error {
+	return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Invalid identifier %q, expected <catalog source>/<package name>", identifier))
+}
+
+func errPackageNotFound(identifier string) error {
+	return connect.NewError(connect.CodeNotFound, fmt.Errorf("Unable to find the operator %q", identifier))
+}
+
+func errValuesNotSupported() error {
+	return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Operators do not accept values, they are configured through their custom resources"))
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
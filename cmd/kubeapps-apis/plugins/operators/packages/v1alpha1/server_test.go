@@ -0,0 +1,689 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	plugins "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/plugins/v1alpha1"
+	operatorsv1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/plugins/operators/packages/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/clientgetter"
+	"google.golang.org/protobuf/types/known/anypb"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynfake "k8s.io/client-go/dynamic/fake"
+)
+
+var ignoreUnexported = cmpopts.IgnoreUnexported(
+	corev1.AvailablePackageDetail{},
+	corev1.AvailablePackageReference{},
+	corev1.AvailablePackageSummary{},
+	corev1.Context{},
+	corev1.InstalledPackageDetail{},
+	corev1.InstalledPackageReference{},
+	corev1.InstalledPackageStatus{},
+	corev1.InstalledPackageSummary{},
+	corev1.Maintainer{},
+	corev1.PackageAppVersion{},
+	corev1.ResourceRef{},
+	corev1.UpgradeImpact{},
+	corev1.VersionReference{},
+	plugins.Plugin{},
+)
+
+func newTestPackageManifest(catalog, name string, channels ...packageChannel) *packageManifest {
+	return &packageManifest{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "PackageManifest",
+			APIVersion: packageManifestsResource.GroupVersion().String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels:    map[string]string{catalogLabel: catalog},
+		},
+		Status: packageManifestStatus{
+			CatalogSource:          catalog,
+			CatalogSourceNamespace: "olm",
+			Provider:               appLink{Name: "Example", URL: "https://example.com"},
+			PackageName:            name,
+			Channels:               channels,
+			DefaultChannel:         "stable",
+		},
+	}
+}
+
+func newTestChannel(name, csvName, version string) packageChannel {
+	return packageChannel{
+		Name:       name,
+		CurrentCSV: csvName,
+		CurrentCSVDesc: csvDescription{
+			DisplayName: "My Operator",
+			Version:     version,
+			Icon:        []csvIcon{{Data: "PHN2Zz48L3N2Zz4=", MediaType: "image/svg+xml"}},
+			Annotations: map[string]string{
+				annotationDescription:  "Runs my application",
+				annotationCategories:   "Database, Monitoring",
+				annotationCapabilities: "Basic Install",
+			},
+			Maintainers:  []maintainer{{Name: "Jane", Email: "jane@example.com"}},
+			Links:        []appLink{{Name: "Source", URL: "https://github.com/example/my-operator"}},
+			Description:  "# My Operator",
+			InstallModes: []installMode{{Type: "OwnNamespace", Supported: true}, {Type: "AllNamespaces", Supported: false}},
+		},
+	}
+}
+
+var testManifest = newTestPackageManifest("operatorhubio-catalog", "my-operator",
+	newTestChannel("alpha", "my-operator.v2.0.0", "2.0.0"),
+	newTestChannel("stable", "my-operator.v1.2.0", "1.2.0"),
+	newTestChannel("stable-v1", "my-operator.v1.2.0", "1.2.0"),
+)
+
+func newTestSubscription(name, channel, installedCSV string) *subscription {
+	return &subscription{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       subscriptionKind,
+			APIVersion: subscriptionsResource.GroupVersion().String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: subscriptionSpec{
+			CatalogSource:          "operatorhubio-catalog",
+			CatalogSourceNamespace: "olm",
+			Package:                "my-operator",
+			Channel:                channel,
+			InstallPlanApproval:    approvalAutomatic,
+		},
+		Status: subscriptionStatus{InstalledCSV: installedCSV},
+	}
+}
+
+func newTestCSV(name, version, phase string) *clusterServiceVersion {
+	return &clusterServiceVersion{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       clusterServiceVersionKind,
+			APIVersion: clusterServiceVersionsResource.GroupVersion().String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: csvSpec{
+			DisplayName: "My Operator",
+			Version:     version,
+			Install: csvInstall{Spec: csvInstallSpec{
+				Deployments: []csvDeployment{{Name: "my-operator-controller"}},
+			}},
+		},
+		Status: csvStatus{Phase: phase},
+	}
+}
+
+func newTestOperatorGroup() *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": operatorGroupsResource.GroupVersion().String(),
+		"kind":       "OperatorGroup",
+		"metadata":   map[string]interface{}{"name": "default", "namespace": "default"},
+	}}
+}
+
+func newTestServer(t *testing.T, existingObjects ...interface{}) (*Server, *dynfake.FakeDynamicClient) {
+	unstructuredObjects := []k8sruntime.Object{}
+	for _, obj := range existingObjects {
+		if u, ok := obj.(*unstructured.Unstructured); ok {
+			unstructuredObjects = append(unstructuredObjects, u)
+			continue
+		}
+		content, err := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		unstructuredObjects = append(unstructuredObjects, &unstructured.Unstructured{Object: content})
+	}
+	dynamicClient := dynfake.NewSimpleDynamicClientWithCustomListKinds(
+		k8sruntime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			packageManifestsResource:       "PackageManifestList",
+			subscriptionsResource:          "SubscriptionList",
+			clusterServiceVersionsResource: "ClusterServiceVersionList",
+			operatorGroupsResource:         "OperatorGroupList",
+		},
+		unstructuredObjects...,
+	)
+	return &Server{
+		clientGetter:    clientgetter.NewBuilder().WithDynamic(dynamicClient).Build(),
+		kubeappsCluster: "default",
+	}, dynamicClient
+}
+
+func getTestSubscription(t *testing.T, dynamicClient *dynfake.FakeDynamicClient, name string) *subscription {
+	obj, err := dynamicClient.Resource(subscriptionsResource).Namespace("default").Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	sub := &subscription{}
+	if err := k8sruntime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, sub); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	return sub
+}
+
+func TestGetAvailablePackageSummaries(t *testing.T) {
+	otherManifest := newTestPackageManifest("community-operators", "other-operator",
+		newTestChannel("stable", "other-operator.v0.1.0", "0.1.0"),
+	)
+	otherManifest.Status.Channels[0].CurrentCSVDesc.Annotations = map[string]string{
+		annotationDescription: "Another operator",
+		annotationCategories:  "Storage",
+	}
+	expectedSummary := &corev1.AvailablePackageSummary{
+		AvailablePackageRef: &corev1.AvailablePackageReference{
+			Context:    &corev1.Context{Cluster: "default", Namespace: "default"},
+			Identifier: "operatorhubio-catalog/my-operator",
+			Plugin:     GetPluginDetail(),
+		},
+		Name:             "my-operator",
+		DisplayName:      "My Operator",
+		LatestVersion:    &corev1.PackageAppVersion{PkgVersion: "2.0.0", AppVersion: "2.0.0"},
+		IconUrl:          "data:image/svg+xml;base64,PHN2Zz48L3N2Zz4=",
+		ShortDescription: "Runs my application",
+		Categories:       []string{"Database", "Monitoring"},
+	}
+
+	testCases := []struct {
+		name              string
+		filterOptions     *corev1.FilterOptions
+		expectedPackages  []string
+		expectedFirstItem *corev1.AvailablePackageSummary
+	}{
+		{
+			name:             "it returns the operators of every catalog",
+			expectedPackages: []string{"community-operators/other-operator", "operatorhubio-catalog/my-operator"},
+		},
+		{
+			name:              "it filters the operators by query",
+			filterOptions:     &corev1.FilterOptions{Query: "runs my"},
+			expectedPackages:  []string{"operatorhubio-catalog/my-operator"},
+			expectedFirstItem: expectedSummary,
+		},
+		{
+			name:             "it filters the operators by category",
+			filterOptions:    &corev1.FilterOptions{Categories: []string{"Storage"}},
+			expectedPackages: []string{"community-operators/other-operator"},
+		},
+		{
+			name:             "it filters the operators by catalog",
+			filterOptions:    &corev1.FilterOptions{Repositories: []string{"operatorhubio-catalog"}},
+			expectedPackages: []string{"operatorhubio-catalog/my-operator"},
+		},
+		{
+			name:             "it filters the operators by provider",
+			filterOptions:    &corev1.FilterOptions{Providers: []string{"Someone else"}},
+			expectedPackages: []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, _ := newTestServer(t, testManifest, otherManifest)
+
+			response, err := s.GetAvailablePackageSummaries(context.Background(), connect.NewRequest(&corev1.GetAvailablePackageSummariesRequest{
+				Context:       &corev1.Context{Namespace: "default"},
+				FilterOptions: tc.filterOptions,
+			}))
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+
+			packages := []string{}
+			for _, summary := range response.Msg.AvailablePackageSummaries {
+				packages = append(packages, summary.GetAvailablePackageRef().GetIdentifier())
+			}
+			if got, want := packages, tc.expectedPackages; !cmp.Equal(want, got) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+			if got, want := response.Msg.Categories, []string{"Database", "Monitoring", "Storage"}; !cmp.Equal(want, got) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+			if tc.expectedFirstItem != nil {
+				if got, want := response.Msg.AvailablePackageSummaries[0], tc.expectedFirstItem; !cmp.Equal(want, got, ignoreUnexported) {
+					t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexported))
+				}
+			}
+		})
+	}
+}
+
+func TestGetAvailablePackageDetail(t *testing.T) {
+	testCases := []struct {
+		name            string
+		identifier      string
+		version         string
+		expectedVersion string
+		expectedCode    connect.Code
+	}{
+		{
+			name:            "it returns the version of the default channel",
+			identifier:      "operatorhubio-catalog/my-operator",
+			expectedVersion: "1.2.0",
+		},
+		{
+			name:            "it returns the requested version",
+			identifier:      "operatorhubio-catalog/my-operator",
+			version:         "2.0.0",
+			expectedVersion: "2.0.0",
+		},
+		{
+			name:         "it returns not found for an unknown version",
+			identifier:   "operatorhubio-catalog/my-operator",
+			version:      "3.0.0",
+			expectedCode: connect.CodeNotFound,
+		},
+		{
+			name:         "it returns not found for an operator of another catalog",
+			identifier:   "community-operators/my-operator",
+			expectedCode: connect.CodeNotFound,
+		},
+		{
+			name:         "it returns invalid argument for an identifier without catalog",
+			identifier:   "my-operator",
+			expectedCode: connect.CodeInvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, _ := newTestServer(t, testManifest)
+
+			response, err := s.GetAvailablePackageDetail(context.Background(), connect.NewRequest(&corev1.GetAvailablePackageDetailRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context:    &corev1.Context{Namespace: "default"},
+					Identifier: tc.identifier,
+				},
+				PkgVersion: tc.version,
+			}))
+
+			if got, want := connect.CodeOf(err), tc.expectedCode; err != nil && got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+			if tc.expectedCode != 0 {
+				if err == nil {
+					t.Fatalf("got: nil, want: error with code %+v", tc.expectedCode)
+				}
+				return
+			}
+
+			detail := response.Msg.AvailablePackageDetail
+			if got, want := detail.GetVersion().GetPkgVersion(), tc.expectedVersion; got != want {
+				t.Errorf("got: %q, want: %q", got, want)
+			}
+			if got, want := detail.GetMaintainers(), []*corev1.Maintainer{{Name: "Jane", Email: "jane@example.com"}}; !cmp.Equal(want, got, ignoreUnexported) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexported))
+			}
+			customDetail := &operatorsv1alpha1.OperatorsAvailablePackageCustomDetail{}
+			if err := detail.GetCustomDetail().UnmarshalTo(customDetail); err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if got, want := customDetail.GetDefaultChannel(), "stable"; got != want {
+				t.Errorf("got: %q, want: %q", got, want)
+			}
+			if got, want := len(customDetail.GetChannels()), 3; got != want {
+				t.Errorf("got: %d, want: %d", got, want)
+			}
+			if got, want := customDetail.GetInstallModes(), []string{"OwnNamespace"}; !cmp.Equal(want, got) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
+func TestGetAvailablePackageVersions(t *testing.T) {
+	s, _ := newTestServer(t, testManifest)
+
+	response, err := s.GetAvailablePackageVersions(context.Background(), connect.NewRequest(&corev1.GetAvailablePackageVersionsRequest{
+		AvailablePackageRef: &corev1.AvailablePackageReference{
+			Context:    &corev1.Context{Namespace: "default"},
+			Identifier: "operatorhubio-catalog/my-operator",
+		},
+	}))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	expected := []*corev1.PackageAppVersion{
+		{PkgVersion: "2.0.0", AppVersion: "2.0.0"},
+		{PkgVersion: "1.2.0", AppVersion: "1.2.0"},
+	}
+	if got, want := response.Msg.PackageAppVersions, expected; !cmp.Equal(want, got, ignoreUnexported) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexported))
+	}
+}
+
+func TestGetInstalledPackageSummaries(t *testing.T) {
+	s, _ := newTestServer(t,
+		testManifest,
+		newTestSubscription("installed", "stable", "my-operator.v1.0.0"),
+		newTestCSV("my-operator.v1.0.0", "1.0.0", csvPhaseSucceeded),
+		newTestSubscription("pending", "alpha", ""),
+	)
+
+	response, err := s.GetInstalledPackageSummaries(context.Background(), connect.NewRequest(&corev1.GetInstalledPackageSummariesRequest{
+		Context: &corev1.Context{Namespace: "default"},
+	}))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	expected := []*corev1.InstalledPackageSummary{
+		{
+			InstalledPackageRef: &corev1.InstalledPackageReference{
+				Context:    &corev1.Context{Cluster: "default", Namespace: "default"},
+				Identifier: "installed",
+				Plugin:     GetPluginDetail(),
+			},
+			Name:                "installed",
+			PkgVersionReference: &corev1.VersionReference{Version: "1.0.0"},
+			CurrentVersion:      &corev1.PackageAppVersion{PkgVersion: "1.0.0", AppVersion: "1.0.0"},
+			LatestVersion:       &corev1.PackageAppVersion{PkgVersion: "1.2.0", AppVersion: "1.2.0"},
+			PkgDisplayName:      "My Operator",
+			ShortDescription:    "Runs my application",
+			Status: &corev1.InstalledPackageStatus{
+				Ready:      true,
+				Reason:     corev1.InstalledPackageStatus_STATUS_REASON_INSTALLED,
+				UserReason: "Deployed",
+			},
+		},
+		{
+			InstalledPackageRef: &corev1.InstalledPackageReference{
+				Context:    &corev1.Context{Cluster: "default", Namespace: "default"},
+				Identifier: "pending",
+				Plugin:     GetPluginDetail(),
+			},
+			Name:                "pending",
+			PkgVersionReference: &corev1.VersionReference{},
+			LatestVersion:       &corev1.PackageAppVersion{PkgVersion: "2.0.0", AppVersion: "2.0.0"},
+			PkgDisplayName:      "my-operator",
+			ShortDescription:    "Runs my application",
+			Status: &corev1.InstalledPackageStatus{
+				Reason:     corev1.InstalledPackageStatus_STATUS_REASON_PENDING,
+				UserReason: "Waiting for the InstallPlan",
+			},
+		},
+	}
+	if got, want := response.Msg.InstalledPackageSummaries, expected; !cmp.Equal(want, got, ignoreUnexported) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexported))
+	}
+}
+
+func TestCreateInstalledPackage(t *testing.T) {
+	manualApproval, err := anypb.New(&operatorsv1alpha1.OperatorsInstalledPackageCustomDetail{ManualApproval: true})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	stableV1Channel, err := anypb.New(&operatorsv1alpha1.OperatorsInstalledPackageCustomDetail{Channel: "stable-v1"})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	testCases := []struct {
+		name                 string
+		request              *corev1.CreateInstalledPackageRequest
+		withoutOperatorGroup bool
+		expectedSpec         subscriptionSpec
+		expectedCode         connect.Code
+	}{
+		{
+			name: "it subscribes to the default channel",
+			request: &corev1.CreateInstalledPackageRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{Identifier: "operatorhubio-catalog/my-operator"},
+				TargetContext:       &corev1.Context{Namespace: "default"},
+				Name:                "my-operator",
+			},
+			expectedSpec: subscriptionSpec{
+				CatalogSource:          "operatorhubio-catalog",
+				CatalogSourceNamespace: "olm",
+				Package:                "my-operator",
+				Channel:                "stable",
+				StartingCSV:            "my-operator.v1.2.0",
+				InstallPlanApproval:    approvalAutomatic,
+			},
+		},
+		{
+			name: "it subscribes to the channel of the requested version with manual approval",
+			request: &corev1.CreateInstalledPackageRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{Identifier: "operatorhubio-catalog/my-operator"},
+				TargetContext:       &corev1.Context{Namespace: "default"},
+				Name:                "my-operator",
+				PkgVersionReference: &corev1.VersionReference{Version: "2.0.0"},
+				CustomDetail:        manualApproval,
+			},
+			expectedSpec: subscriptionSpec{
+				CatalogSource:          "operatorhubio-catalog",
+				CatalogSourceNamespace: "olm",
+				Package:                "my-operator",
+				Channel:                "alpha",
+				StartingCSV:            "my-operator.v2.0.0",
+				InstallPlanApproval:    approvalManual,
+			},
+		},
+		{
+			name: "it subscribes to the requested channel",
+			request: &corev1.CreateInstalledPackageRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{Identifier: "operatorhubio-catalog/my-operator"},
+				TargetContext:       &corev1.Context{Namespace: "default"},
+				Name:                "my-operator",
+				PkgVersionReference: &corev1.VersionReference{Version: "1.2.0"},
+				CustomDetail:        stableV1Channel,
+			},
+			expectedSpec: subscriptionSpec{
+				CatalogSource:          "operatorhubio-catalog",
+				CatalogSourceNamespace: "olm",
+				Package:                "my-operator",
+				Channel:                "stable-v1",
+				StartingCSV:            "my-operator.v1.2.0",
+				InstallPlanApproval:    approvalAutomatic,
+			},
+		},
+		{
+			name: "it rejects a version which is not the one of the requested channel",
+			request: &corev1.CreateInstalledPackageRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{Identifier: "operatorhubio-catalog/my-operator"},
+				TargetContext:       &corev1.Context{Namespace: "default"},
+				Name:                "my-operator",
+				PkgVersionReference: &corev1.VersionReference{Version: "2.0.0"},
+				CustomDetail:        stableV1Channel,
+			},
+			expectedCode: connect.CodeInvalidArgument,
+		},
+		{
+			name: "it rejects values",
+			request: &corev1.CreateInstalledPackageRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{Identifier: "operatorhubio-catalog/my-operator"},
+				TargetContext:       &corev1.Context{Namespace: "default"},
+				Name:                "my-operator",
+				Values:              "replicas: 2",
+			},
+			expectedCode: connect.CodeInvalidArgument,
+		},
+		{
+			name: "it fails without an OperatorGroup in the namespace",
+			request: &corev1.CreateInstalledPackageRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{Identifier: "operatorhubio-catalog/my-operator"},
+				TargetContext:       &corev1.Context{Namespace: "default"},
+				Name:                "my-operator",
+			},
+			withoutOperatorGroup: true,
+			expectedCode:         connect.CodeFailedPrecondition,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			objects := []interface{}{testManifest}
+			if !tc.withoutOperatorGroup {
+				objects = append(objects, newTestOperatorGroup())
+			}
+			s, dynamicClient := newTestServer(t, objects...)
+
+			response, err := s.CreateInstalledPackage(context.Background(), connect.NewRequest(tc.request))
+
+			if got, want := connect.CodeOf(err), tc.expectedCode; err != nil && got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+			if tc.expectedCode != 0 {
+				if err == nil {
+					t.Fatalf("got: nil, want: error with code %+v", tc.expectedCode)
+				}
+				return
+			}
+
+			expectedRef := &corev1.InstalledPackageReference{
+				Context:    &corev1.Context{Cluster: "default", Namespace: "default"},
+				Identifier: "my-operator",
+				Plugin:     GetPluginDetail(),
+			}
+			if got, want := response.Msg.InstalledPackageRef, expectedRef; !cmp.Equal(want, got, ignoreUnexported) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexported))
+			}
+			if got, want := getTestSubscription(t, dynamicClient, "my-operator").Spec, tc.expectedSpec; !cmp.Equal(want, got) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
+func TestUpdateInstalledPackage(t *testing.T) {
+	manualApproval, err := anypb.New(&operatorsv1alpha1.OperatorsInstalledPackageCustomDetail{Channel: "stable-v1", ManualApproval: true})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	testCases := []struct {
+		name             string
+		request          *corev1.UpdateInstalledPackageRequest
+		expectedChannel  string
+		expectedApproval string
+	}{
+		{
+			name: "it switches to the channel of the requested version",
+			request: &corev1.UpdateInstalledPackageRequest{
+				PkgVersionReference: &corev1.VersionReference{Version: "2.0.0"},
+			},
+			expectedChannel:  "alpha",
+			expectedApproval: approvalAutomatic,
+		},
+		{
+			name: "it keeps the current channel when its head has the requested version",
+			request: &corev1.UpdateInstalledPackageRequest{
+				PkgVersionReference: &corev1.VersionReference{Version: "1.2.0"},
+			},
+			expectedChannel:  "stable-v1",
+			expectedApproval: approvalAutomatic,
+		},
+		{
+			name: "it keeps the current channel when its head satisfies the requested range",
+			request: &corev1.UpdateInstalledPackageRequest{
+				PkgVersionReference: &corev1.VersionReference{Version: ">=1.0 <2"},
+			},
+			expectedChannel:  "stable-v1",
+			expectedApproval: approvalAutomatic,
+		},
+		{
+			name: "it switches to the channel of the highest version satisfying the requested range",
+			request: &corev1.UpdateInstalledPackageRequest{
+				PkgVersionReference: &corev1.VersionReference{Version: "^2"},
+			},
+			expectedChannel:  "alpha",
+			expectedApproval: approvalAutomatic,
+		},
+		{
+			name: "it updates the channel and approval of the custom detail",
+			request: &corev1.UpdateInstalledPackageRequest{
+				CustomDetail: manualApproval,
+			},
+			expectedChannel:  "stable-v1",
+			expectedApproval: approvalManual,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, dynamicClient := newTestServer(t, testManifest, newTestSubscription("my-operator", "stable-v1", "my-operator.v1.2.0"))
+			tc.request.InstalledPackageRef = &corev1.InstalledPackageReference{
+				Context:    &corev1.Context{Namespace: "default"},
+				Identifier: "my-operator",
+			}
+
+			if _, err := s.UpdateInstalledPackage(context.Background(), connect.NewRequest(tc.request)); err != nil {
+				t.Fatalf("%+v", err)
+			}
+
+			sub := getTestSubscription(t, dynamicClient, "my-operator")
+			if got, want := sub.Spec.Channel, tc.expectedChannel; got != want {
+				t.Errorf("got: %q, want: %q", got, want)
+			}
+			if got, want := sub.Spec.InstallPlanApproval, tc.expectedApproval; got != want {
+				t.Errorf("got: %q, want: %q", got, want)
+			}
+		})
+	}
+}
+
+func TestDeleteInstalledPackage(t *testing.T) {
+	s, dynamicClient := newTestServer(t,
+		newTestSubscription("my-operator", "stable", "my-operator.v1.2.0"),
+		newTestCSV("my-operator.v1.2.0", "1.2.0", csvPhaseSucceeded),
+	)
+
+	_, err := s.DeleteInstalledPackage(context.Background(), connect.NewRequest(&corev1.DeleteInstalledPackageRequest{
+		InstalledPackageRef: &corev1.InstalledPackageReference{
+			Context:    &corev1.Context{Namespace: "default"},
+			Identifier: "my-operator",
+		},
+	}))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	for resource, name := range map[schema.GroupVersionResource]string{
+		subscriptionsResource:          "my-operator",
+		clusterServiceVersionsResource: "my-operator.v1.2.0",
+	} {
+		_, err := dynamicClient.Resource(resource).Namespace("default").Get(context.Background(), name, metav1.GetOptions{})
+		if !errors.IsNotFound(err) {
+			t.Errorf("got: %+v, want: not found error for %s %q", err, resource.Resource, name)
+		}
+	}
+}
+
+func TestGetInstalledPackageResourceRefs(t *testing.T) {
+	s, _ := newTestServer(t,
+		newTestSubscription("my-operator", "stable", "my-operator.v1.2.0"),
+		newTestCSV("my-operator.v1.2.0", "1.2.0", "Installing"),
+	)
+
+	response, err := s.GetInstalledPackageResourceRefs(context.Background(), connect.NewRequest(&corev1.GetInstalledPackageResourceRefsRequest{
+		InstalledPackageRef: &corev1.InstalledPackageReference{
+			Context:    &corev1.Context{Namespace: "default"},
+			Identifier: "my-operator",
+		},
+	}))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	expected := []*corev1.ResourceRef{
+		{ApiVersion: "operators.coreos.com/v1alpha1", Kind: "Subscription", Name: "my-operator", Namespace: "default"},
+		{ApiVersion: "operators.coreos.com/v1alpha1", Kind: "ClusterServiceVersion", Name: "my-operator.v1.2.0", Namespace: "default"},
+		{ApiVersion: "apps/v1", Kind: "Deployment", Name: "my-operator-controller", Namespace: "default"},
+	}
+	if got, want := response.Msg.ResourceRefs, expected; !cmp.Equal(want, got, ignoreUnexported) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexported))
+	}
+}
@@ -193,9 +193,10 @@ func buildClientsProviderFunction(configGetter core.KubernetesConfigGetter, opti
 		config, err := configGetter(headers, cluster)
 		if err != nil {
 			code := connect.CodeFailedPrecondition
-			if connect.CodeOf(err) == connect.CodeUnauthenticated {
-				// want to make sure we return same status in this case
-				code = connect.CodeUnauthenticated
+			switch connect.CodeOf(err) {
+			case connect.CodeUnauthenticated, connect.CodePermissionDenied, connect.CodeInvalidArgument:
+				// want to make sure we return same status in these cases
+				code = connect.CodeOf(err)
 			}
 			return nil, connect.NewError(code, fmt.Errorf("unable to get in cluster config due to: %w", err))
 		}
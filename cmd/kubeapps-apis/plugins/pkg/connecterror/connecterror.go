@@ -5,6 +5,7 @@ package connecterror
 
 import (
 	"fmt"
+	"net/http"
 
 	"github.com/bufbuild/connect-go"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -27,3 +28,24 @@ func FromK8sError(verb, resource, identifier string, err error) error {
 	}
 	return connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to %s the %s '%s' due to '%w'", verb, resource, identifier, err))
 }
+
+// FromHTTPStatus generates a grpc connect error from an unexpected status
+// returned by an HTTP server, such as a package repository.
+func FromHTTPStatus(url string, statusCode int) error {
+	var code connect.Code
+	switch {
+	case statusCode == http.StatusUnauthorized:
+		code = connect.CodeUnauthenticated
+	case statusCode == http.StatusForbidden:
+		code = connect.CodePermissionDenied
+	case statusCode == http.StatusNotFound:
+		code = connect.CodeNotFound
+	case statusCode == http.StatusTooManyRequests:
+		code = connect.CodeResourceExhausted
+	case statusCode >= http.StatusInternalServerError:
+		code = connect.CodeUnavailable
+	default:
+		code = connect.CodeFailedPrecondition
+	}
+	return connect.NewError(code, fmt.Errorf("Unexpected status '%d %s' from '%s'", statusCode, http.StatusText(statusCode), url))
+}
@@ -5,6 +5,7 @@ package connecterror
 
 import (
 	"fmt"
+	"net/http"
 	"testing"
 
 	"github.com/bufbuild/connect-go"
@@ -48,3 +49,31 @@ func TestErrorByStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestFromHTTPStatus(t *testing.T) {
+	tests := []struct {
+		name         string
+		statusCode   int
+		expectedCode connect.Code
+	}{
+		{"unauthorized", http.StatusUnauthorized, connect.CodeUnauthenticated},
+		{"forbidden", http.StatusForbidden, connect.CodePermissionDenied},
+		{"not found", http.StatusNotFound, connect.CodeNotFound},
+		{"too many requests", http.StatusTooManyRequests, connect.CodeResourceExhausted},
+		{"server error", http.StatusBadGateway, connect.CodeUnavailable},
+		{"other client error", http.StatusBadRequest, connect.CodeFailedPrecondition},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := FromHTTPStatus("https://example.com/index.yaml", tt.statusCode)
+			if got, want := connect.CodeOf(err), tt.expectedCode; got != want {
+				t.Errorf("got: %s, want: %s", got, want)
+			}
+		})
+	}
+
+	err := FromHTTPStatus("https://example.com/index.yaml", http.StatusNotFound)
+	if got, want := err.Error(), "not_found: Unexpected status '404 Not Found' from 'https://example.com/index.yaml'"; got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+}
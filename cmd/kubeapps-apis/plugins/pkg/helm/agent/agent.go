@@ -5,6 +5,7 @@ package agent
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -137,6 +138,30 @@ func GetRelease(actionConfig *action.Configuration, name string) (*release.Relea
 	return release, nil
 }
 
+// GetReleaseHistory returns the revisions of a release, from the latest to the oldest.
+func GetReleaseHistory(actionConfig *action.Configuration, name string) ([]*release.Release, error) {
+	cmd := action.NewHistory(actionConfig)
+	releases, err := cmd.Run(name)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(releases, func(i, j int) bool {
+		return releases[i].Version > releases[j].Version
+	})
+	return releases, nil
+}
+
+// GetReleaseRevision returns the info of a given revision of a release.
+func GetReleaseRevision(actionConfig *action.Configuration, name string, revision int) (*release.Release, error) {
+	cmd := action.NewGet(actionConfig)
+	cmd.Version = revision
+	release, err := cmd.Run(name)
+	if err != nil {
+		return nil, err
+	}
+	return release, nil
+}
+
 // DeleteRelease deletes a release.
 func DeleteRelease(actionConfig *action.Configuration, name string, keepHistory bool, timeoutSeconds int32) error {
 	// Namespace is already known by the RESTClientGetter.
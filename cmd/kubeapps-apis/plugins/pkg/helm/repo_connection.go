@@ -0,0 +1,136 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package helm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	corev1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/connecterror"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/netrestrict"
+	"helm.sh/helm/v3/pkg/repo"
+	log "k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+)
+
+// maxRepoIndexBytes caps the size of the repository index read when testing
+// the connection, as it is chosen by the user. Large public repositories have
+// indexes of a few tens of MiB.
+var maxRepoIndexBytes int64 = 64 * 1024 * 1024
+
+// ProbeRepoIndex checks that the index of a (non-OCI) Helm repository can be
+// retrieved with the given client, counting the packages it contains.
+func ProbeRepoIndex(ctx context.Context, cli *http.Client, repoURL string) (*corev1.TestPackageRepositoryConnectionResponse, error) {
+	indexURL, err := url.ParseRequestURI(strings.TrimSuffix(strings.TrimSpace(repoURL), "/"))
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Invalid repository url %q: %w", repoURL, err))
+	}
+	indexURL.Path = path.Join(indexURL.Path, "index.yaml")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, indexURL.String(), nil)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	res, err := cli.Do(req)
+	if err != nil {
+		return nil, ConnectionError(indexURL.String(), err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		log.Errorf("Failed to request the repository index: %s", res.Status)
+		return nil, connecterror.FromHTTPStatus(indexURL.String(), res.StatusCode)
+	}
+
+	// reading one more byte than allowed tells a too large index apart
+	contents, err := io.ReadAll(io.LimitReader(res.Body, maxRepoIndexBytes+1))
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnavailable, fmt.Errorf("Unable to read the repository index at '%s': %w", indexURL.String(), err))
+	}
+	if int64(len(contents)) > maxRepoIndexBytes {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("The repository index at '%s' is too large, it exceeds %d bytes", indexURL.String(), maxRepoIndexBytes))
+	}
+	var index repo.IndexFile
+	if err := yaml.Unmarshal(contents, &index); err != nil {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("Invalid repository index at '%s': %w", indexURL.String(), err))
+	}
+	packageCount := int32(len(index.Entries))
+
+	metadata := map[string]string{}
+	if lastModified := res.Header.Get("Last-Modified"); lastModified != "" {
+		metadata["lastModified"] = lastModified
+	}
+	if etag := res.Header.Get("ETag"); etag != "" {
+		metadata["etag"] = etag
+	}
+	return &corev1.TestPackageRepositoryConnectionResponse{
+		Message:      fmt.Sprintf("Found the repository index at '%s'", indexURL.String()),
+		Metadata:     metadata,
+		PackageCount: &packageCount,
+	}, nil
+}
+
+// ProbeOCIRegistry checks that the OCI registry of the given URL, such as
+// oci://ghcr.io/stefanprodan/charts, can be reached and accepts the
+// credentials, by requesting the base endpoint of the distribution API.
+func ProbeOCIRegistry(ctx context.Context, registryURL string, auth authn.Authenticator, roundTripper http.RoundTripper) (*corev1.TestPackageRepositoryConnectionResponse, error) {
+	u, err := url.Parse(strings.TrimSpace(registryURL))
+	if err != nil || u.Host == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Invalid OCI registry url %q: %v", registryURL, err))
+	}
+	reg, err := name.NewRegistry(u.Host)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Invalid OCI registry url %q: %w", registryURL, err))
+	}
+
+	// creating the transport pings the registry and, if it requires a token,
+	// exchanges the credentials for one
+	authTransport, err := transport.NewWithContext(ctx, reg, auth, roundTripper, nil)
+	if err != nil {
+		var transportErr *transport.Error
+		if errors.As(err, &transportErr) {
+			log.Errorf("Failed to authenticate to the OCI registry: %v", transportErr)
+			return nil, connecterror.FromHTTPStatus(registryURL, transportErr.StatusCode)
+		}
+		return nil, ConnectionError(registryURL, err)
+	}
+
+	baseURL := fmt.Sprintf("%s://%s/v2/", reg.Scheme(), reg.RegistryStr())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL, nil)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	res, err := (&http.Client{Transport: authTransport}).Do(req)
+	if err != nil {
+		return nil, ConnectionError(registryURL, err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		log.Errorf("Failed to request the OCI registry: %s", res.Status)
+		return nil, connecterror.FromHTTPStatus(registryURL, res.StatusCode)
+	}
+
+	return &corev1.TestPackageRepositoryConnectionResponse{
+		Message: fmt.Sprintf("Connected to the OCI registry '%s'", registryURL),
+	}, nil
+}
+
+// ConnectionError returns the error of a failed request to a repository,
+// telling apart the addresses which are not allowed from the unreachable ones.
+func ConnectionError(url string, err error) error {
+	if errors.Is(err, netrestrict.ErrNotAllowed) {
+		return connect.NewError(connect.CodePermissionDenied, fmt.Errorf("Connecting to '%s' is not allowed: %w", url, err))
+	}
+	return connect.NewError(connect.CodeUnavailable, fmt.Errorf("Unable to connect to '%s': %w", url, err))
+}
@@ -0,0 +1,106 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package helm
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/netrestrict"
+)
+
+func TestProbeRepoIndexTooLarge(t *testing.T) {
+	index := "apiVersion: v1\nentries:\n  apache:\n  - name: apache\n    version: 1.0.0\n"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(index))
+	}))
+	defer server.Close()
+
+	defer func(max int64) { maxRepoIndexBytes = max }(maxRepoIndexBytes)
+	maxRepoIndexBytes = int64(len(index))
+	response, err := ProbeRepoIndex(context.Background(), server.Client(), server.URL)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if got, want := response.GetPackageCount(), int32(1); got != want {
+		t.Errorf("got: %d, want: %d", got, want)
+	}
+
+	maxRepoIndexBytes = int64(len(index)) - 1
+	_, err = ProbeRepoIndex(context.Background(), server.Client(), server.URL)
+	if got, want := connect.CodeOf(err), connect.CodeFailedPrecondition; err == nil || got != want {
+		t.Errorf("got: %v, want: error with code %s", err, want)
+	}
+}
+
+func TestProbeOCIRegistry(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if username, password, ok := r.BasicAuth(); !ok || username != "foo" || password != "bar" {
+			w.Header().Set("WWW-Authenticate", `Basic realm="test"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	registryURL := "oci://" + strings.TrimPrefix(server.URL, "http://") + "/charts"
+
+	testCases := []struct {
+		name            string
+		auth            authn.Authenticator
+		allowedNetworks []string
+		errorCode       connect.Code
+	}{
+		{
+			name:            "it connects to the registry with the credentials",
+			auth:            &authn.Basic{Username: "foo", Password: "bar"},
+			allowedNetworks: []string{"127.0.0.0/8"},
+		},
+		{
+			name:            "it returns unauthenticated if the registry rejects the credentials",
+			auth:            &authn.Basic{Username: "foo", Password: "baz"},
+			allowedNetworks: []string{"127.0.0.0/8"},
+			errorCode:       connect.CodeUnauthenticated,
+		},
+		{
+			name:      "it returns permission denied if the registry is served from a blocked network",
+			auth:      &authn.Basic{Username: "foo", Password: "bar"},
+			errorCode: connect.CodePermissionDenied,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			restrictions, err := netrestrict.New(tc.allowedNetworks)
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+
+			response, err := ProbeOCIRegistry(context.Background(), registryURL, tc.auth, restrictions.Transport(server.Client().Transport))
+
+			if tc.errorCode != 0 {
+				if got, want := connect.CodeOf(err), tc.errorCode; err == nil || got != want {
+					t.Fatalf("got: %v, want: error with code %s", err, want)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if got, want := response.GetMessage(), "Connected to the OCI registry '"+registryURL+"'"; got != want {
+				t.Errorf("got: %q, want: %q", got, want)
+			}
+		})
+	}
+}
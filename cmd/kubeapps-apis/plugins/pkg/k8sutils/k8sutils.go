@@ -5,12 +5,15 @@ package k8sutils
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/connecterror"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/dynamic"
 )
 
@@ -37,6 +40,28 @@ func WaitForResource(ctx context.Context, ri dynamic.ResourceInterface, name str
 	return err
 }
 
+// CheckServedResources returns an error if any of the given resources is not
+// served by the API server, such as when the CRD defining it is not installed.
+func CheckServedResources(client discovery.DiscoveryInterface, gvrs ...schema.GroupVersionResource) error {
+	for _, gvr := range gvrs {
+		resourceList, err := client.ServerResourcesForGroupVersion(gvr.GroupVersion().String())
+		if err != nil {
+			return fmt.Errorf("unable to get the resources served for %q: %w", gvr.GroupVersion(), err)
+		}
+		served := false
+		for _, resource := range resourceList.APIResources {
+			if resource.Name == gvr.Resource {
+				served = true
+				break
+			}
+		}
+		if !served {
+			return fmt.Errorf("the resource %q is not served for %q", gvr.Resource, gvr.GroupVersion())
+		}
+	}
+	return nil
+}
+
 // description
 func SetDescription(metadata *metav1.ObjectMeta, description string) {
 	if description != "" {
@@ -16,6 +16,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	dynfake "k8s.io/client-go/dynamic/fake"
+	typfake "k8s.io/client-go/kubernetes/fake"
 )
 
 func TestWaitForResource(t *testing.T) {
@@ -97,6 +98,60 @@ func TestWaitForResource(t *testing.T) {
 	}
 }
 
+func TestCheckServedResources(t *testing.T) {
+	appRepositoriesGvr := schema.GroupVersionResource{Group: "kubeapps.com", Version: "v1alpha1", Resource: "apprepositories"}
+
+	testCases := []struct {
+		name          string
+		resources     []*metav1.APIResourceList
+		gvrs          []schema.GroupVersionResource
+		expectedError string
+	}{
+		{
+			name: "it succeeds if the resources are served",
+			resources: []*metav1.APIResourceList{
+				{GroupVersion: "kubeapps.com/v1alpha1", APIResources: []metav1.APIResource{{Name: "apprepositories"}}},
+			},
+			gvrs: []schema.GroupVersionResource{appRepositoriesGvr},
+		},
+		{
+			name: "it fails if the group version is not served",
+			gvrs: []schema.GroupVersionResource{appRepositoriesGvr},
+			expectedError: `unable to get the resources served for "kubeapps.com/v1alpha1": ` +
+				`the server could not find the requested resource, GroupVersion "kubeapps.com/v1alpha1" not found`,
+		},
+		{
+			name: "it fails if the resource is not served",
+			resources: []*metav1.APIResourceList{
+				{GroupVersion: "kubeapps.com/v1alpha1", APIResources: []metav1.APIResource{{Name: "other"}}},
+			},
+			gvrs:          []schema.GroupVersionResource{appRepositoriesGvr},
+			expectedError: `the resource "apprepositories" is not served for "kubeapps.com/v1alpha1"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := typfake.NewSimpleClientset()
+			client.Resources = tc.resources
+
+			err := CheckServedResources(client.Discovery(), tc.gvrs...)
+
+			if tc.expectedError == "" && err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if tc.expectedError != "" {
+				if err == nil {
+					t.Fatalf("got: nil, want: %q", tc.expectedError)
+				}
+				if got, want := err.Error(), tc.expectedError; got != want {
+					t.Errorf("got: %q, want: %q", got, want)
+				}
+			}
+		})
+	}
+}
+
 func TestSetDescription(t *testing.T) {
 	// with no prior annotations
 	{
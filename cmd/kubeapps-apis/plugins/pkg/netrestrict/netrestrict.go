@@ -0,0 +1,203 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package netrestrict restricts the addresses reached by the requests sent on
+// behalf of a user to a URL of their choice, such as when testing the
+// connection to a package repository, so that they cannot be used to reach
+// the services only exposed to the pod itself (server-side request forgery).
+package netrestrict
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+
+	httpclient "github.com/vmware-tanzu/kubeapps/pkg/http-client"
+)
+
+// ErrNotAllowed is returned, possibly wrapped, when a request is sent to an
+// address which is not allowed.
+var ErrNotAllowed = errors.New("address not allowed")
+
+// BlockedNetworks are the networks which cannot be reached unless explicitly
+// allowed: the loopback, unspecified and link-local addresses, the latter
+// including the metadata services of the cloud providers.
+var BlockedNetworks = []string{
+	"0.0.0.0/8",
+	"127.0.0.0/8",
+	"169.254.0.0/16",
+	"::/128",
+	"::1/128",
+	"fe80::/10",
+}
+
+// Restrictions check the addresses reached by the requests sent on behalf of
+// a user.
+type Restrictions struct {
+	blocked []*net.IPNet
+	allowed []*net.IPNet
+}
+
+// New returns the restrictions blocking the BlockedNetworks, except for the
+// given networks, in CIDR notation, which are allowed.
+func New(allowedNetworks []string) (*Restrictions, error) {
+	blocked, err := parseNetworks(BlockedNetworks)
+	if err != nil {
+		return nil, err
+	}
+	allowed, err := parseNetworks(allowedNetworks)
+	if err != nil {
+		return nil, err
+	}
+	return &Restrictions{
+		blocked: blocked,
+		allowed: allowed,
+	}, nil
+}
+
+// ValidateNetworks checks that the given networks are in CIDR notation.
+func ValidateNetworks(networks []string) error {
+	_, err := parseNetworks(networks)
+	return err
+}
+
+func parseNetworks(networks []string) ([]*net.IPNet, error) {
+	ipNets := make([]*net.IPNet, 0, len(networks))
+	for _, network := range networks {
+		_, ipNet, err := net.ParseCIDR(network)
+		if err != nil {
+			return nil, fmt.Errorf("invalid network %q: %w", network, err)
+		}
+		ipNets = append(ipNets, ipNet)
+	}
+	return ipNets, nil
+}
+
+// CheckURL returns an error wrapping ErrNotAllowed unless the URL is an http
+// or https URL whose host, if it is an IP address, is allowed. The host names
+// are not resolved here but checked on the addresses actually dialed, see
+// Control.
+func (r *Restrictions) CheckURL(u *url.URL) error {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("%w: the scheme of %q is not http or https", ErrNotAllowed, u.Redacted())
+	}
+	if ip := net.ParseIP(u.Hostname()); ip != nil {
+		return r.checkIP(u.Hostname(), ip)
+	}
+	return nil
+}
+
+// Control returns an error wrapping ErrNotAllowed unless the address, as
+// passed to the Control function of a net.Dialer once the host name is
+// resolved, is allowed. Checking the address actually dialed, rather than
+// resolving the host name beforehand, ensures that a host name cannot resolve
+// to an allowed address when checked and to a blocked one when dialed.
+func (r *Restrictions) Control(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrNotAllowed, err)
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return fmt.Errorf("%w: %q is not an IP address", ErrNotAllowed, host)
+	}
+	return r.checkIP(host, ip)
+}
+
+func (r *Restrictions) checkIP(host string, ip net.IP) error {
+	for _, ipNet := range r.allowed {
+		if ipNet.Contains(ip) {
+			return nil
+		}
+	}
+	for _, ipNet := range r.blocked {
+		if ipNet.Contains(ip) {
+			return fmt.Errorf("%w: %q is %s, in the blocked network %s", ErrNotAllowed, host, ip, ipNet)
+		}
+	}
+	return nil
+}
+
+// Dialer returns a dialer which only connects to the allowed addresses.
+func (r *Restrictions) Dialer() *net.Dialer {
+	// same settings as the dialer of the http.DefaultTransport
+	return &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control:   r.Control,
+	}
+}
+
+// Transport returns a copy of the given transport which only connects to the
+// allowed addresses, including when following redirections. The transport
+// must be an *http.Transport, possibly wrapped in an
+// *httpclient.DefaultHeaderTransport, since the check is done by its dialer:
+// any other transport fails every request.
+func (r *Restrictions) Transport(transport http.RoundTripper) http.RoundTripper {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &restrictedTransport{restrictions: r, transport: r.restrictDialer(transport)}
+}
+
+func (r *Restrictions) restrictDialer(transport http.RoundTripper) http.RoundTripper {
+	switch t := transport.(type) {
+	case nil:
+		return r.restrictDialer(http.DefaultTransport)
+	case *http.Transport:
+		restricted := t.Clone()
+		restricted.DialContext = r.Dialer().DialContext
+		// the other dial functions take precedence over DialContext
+		restricted.Dial = nil
+		restricted.DialTLS = nil
+		restricted.DialTLSContext = nil
+		return restricted
+	case *httpclient.DefaultHeaderTransport:
+		restricted := *t
+		restricted.Transport = r.restrictDialer(t.Transport)
+		return &restricted
+	default:
+		return &failingTransport{err: fmt.Errorf("%w: the dialer of the transport %T cannot be restricted", ErrNotAllowed, transport)}
+	}
+}
+
+// Client returns a copy of the given client which only connects to the
+// allowed addresses.
+func (r *Restrictions) Client(client *http.Client) *http.Client {
+	restricted := *client
+	restricted.Transport = r.Transport(client.Transport)
+	return &restricted
+}
+
+type restrictedTransport struct {
+	restrictions *Restrictions
+	transport    http.RoundTripper
+}
+
+func (t *restrictedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// the addresses of the host names are checked when dialing, but a request
+	// sent through a proxy is only dialed to the proxy
+	if err := t.restrictions.CheckURL(req.URL); err != nil {
+		// a round tripper must close the request body, even on errors
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, err
+	}
+	return t.transport.RoundTrip(req)
+}
+
+type failingTransport struct {
+	err error
+}
+
+func (t *failingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+	return nil, t.err
+}
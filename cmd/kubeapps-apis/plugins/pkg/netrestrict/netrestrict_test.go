@@ -0,0 +1,245 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package netrestrict
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	httpclient "github.com/vmware-tanzu/kubeapps/pkg/http-client"
+)
+
+func TestCheckURL(t *testing.T) {
+	testCases := []struct {
+		name            string
+		url             string
+		allowedNetworks []string
+		expectedAllowed bool
+	}{
+		{
+			name:            "it allows a host name, checked when dialing",
+			url:             "https://charts.example.com/index.yaml",
+			expectedAllowed: true,
+		},
+		{
+			name:            "it allows a private address, such as the one of a service of the cluster",
+			url:             "http://10.0.0.5:8080/index.yaml",
+			expectedAllowed: true,
+		},
+		{
+			name:            "it blocks a loopback address",
+			url:             "http://127.0.0.1:50051/",
+			expectedAllowed: false,
+		},
+		{
+			name:            "it blocks a loopback IPv6 address",
+			url:             "http://[::1]:50051/",
+			expectedAllowed: false,
+		},
+		{
+			name:            "it blocks an IPv4-mapped loopback address",
+			url:             "http://[::ffff:127.0.0.1]/",
+			expectedAllowed: false,
+		},
+		{
+			name:            "it blocks a link-local address",
+			url:             "http://169.254.169.254/latest/meta-data",
+			expectedAllowed: false,
+		},
+		{
+			name:            "it blocks a scheme other than http or https",
+			url:             "file:///etc/passwd",
+			expectedAllowed: false,
+		},
+		{
+			name:            "it allows a blocked address which is explicitly allowed",
+			url:             "http://127.0.0.1:8080/",
+			allowedNetworks: []string{"127.0.0.0/8"},
+			expectedAllowed: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			restrictions, err := New(tc.allowedNetworks)
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			u, err := url.Parse(tc.url)
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+
+			err = restrictions.CheckURL(u)
+			if got, want := err == nil, tc.expectedAllowed; got != want {
+				t.Errorf("got allowed: %t, want: %t, err: %v", got, want, err)
+			}
+			if err != nil && !errors.Is(err, ErrNotAllowed) {
+				t.Errorf("got: %v, want an error wrapping %v", err, ErrNotAllowed)
+			}
+		})
+	}
+}
+
+func TestControl(t *testing.T) {
+	testCases := []struct {
+		name            string
+		address         string
+		allowedNetworks []string
+		expectedAllowed bool
+	}{
+		{
+			name:            "it allows a public address",
+			address:         "203.0.113.10:443",
+			expectedAllowed: true,
+		},
+		{
+			name:            "it allows a private address",
+			address:         "10.0.0.5:8080",
+			expectedAllowed: true,
+		},
+		{
+			name:            "it blocks a loopback address",
+			address:         "127.0.0.1:50051",
+			expectedAllowed: false,
+		},
+		{
+			name:            "it blocks an IPv4-mapped loopback address",
+			address:         "[::ffff:127.0.0.1]:80",
+			expectedAllowed: false,
+		},
+		{
+			name:            "it blocks a link-local address",
+			address:         "169.254.169.254:80",
+			expectedAllowed: false,
+		},
+		{
+			name:            "it blocks an address which is not an IP address",
+			address:         "charts.example.com:443",
+			expectedAllowed: false,
+		},
+		{
+			name:            "it allows a blocked address which is explicitly allowed",
+			address:         "[::1]:8080",
+			allowedNetworks: []string{"::1/128"},
+			expectedAllowed: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			restrictions, err := New(tc.allowedNetworks)
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+
+			err = restrictions.Control("tcp", tc.address, nil)
+			if got, want := err == nil, tc.expectedAllowed; got != want {
+				t.Errorf("got allowed: %t, want: %t, err: %v", got, want, err)
+			}
+			if err != nil && !errors.Is(err, ErrNotAllowed) {
+				t.Errorf("got: %v, want an error wrapping %v", err, ErrNotAllowed)
+			}
+		})
+	}
+}
+
+func TestClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/redirect" {
+			http.Redirect(w, r, "http://[::1]/", http.StatusFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	restrictions, err := New(nil)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if _, err := restrictions.Client(server.Client()).Get(server.URL); !errors.Is(err, ErrNotAllowed) {
+		t.Errorf("got: %v, want an error wrapping %v", err, ErrNotAllowed)
+	}
+
+	restrictions, err = New([]string{"127.0.0.0/8"})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	res, err := restrictions.Client(server.Client()).Get(server.URL)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	res.Body.Close()
+	if _, err := restrictions.Client(server.Client()).Get(server.URL + "/redirect"); !errors.Is(err, ErrNotAllowed) {
+		t.Errorf("got: %v, want the redirection to be blocked with an error wrapping %v", err, ErrNotAllowed)
+	}
+}
+
+func TestClientHostName(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	serverURL, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	// a host name resolving to a blocked address is only caught when dialing
+	hostNameURL := "http://localhost:" + serverURL.Port()
+
+	restrictions, err := New(nil)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	clients := map[string]*http.Client{
+		"http transport":           server.Client(),
+		"default header transport": httpclient.NewDefaultHeaderClient(server.Client(), http.Header{"X-Foo": {"bar"}}),
+	}
+	for name, client := range clients {
+		if _, err := restrictions.Client(client).Get(hostNameURL); !errors.Is(err, ErrNotAllowed) {
+			t.Errorf("%s: got: %v, want an error wrapping %v", name, err, ErrNotAllowed)
+		}
+	}
+
+	restrictions, err = New([]string{"127.0.0.0/8", "::1/128"})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	for name, client := range clients {
+		res, err := restrictions.Client(client).Get(hostNameURL)
+		if err != nil {
+			t.Fatalf("%s: %+v", name, err)
+		}
+		res.Body.Close()
+	}
+}
+
+func TestTransportUnsupported(t *testing.T) {
+	restrictions, err := New(nil)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	client := &http.Client{Transport: roundTripperFunc(func(*http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	})}
+	if _, err := restrictions.Client(client).Get("https://charts.example.com/"); !errors.Is(err, ErrNotAllowed) {
+		t.Errorf("got: %v, want an error wrapping %v", err, ErrNotAllowed)
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestNewInvalidNetwork(t *testing.T) {
+	if _, err := New([]string{"127.0.0.1"}); err == nil {
+		t.Errorf("got: nil, want: error")
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package pkgutils
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/bufbuild/connect-go"
+	corev1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/pkg/chart/models"
+	log "k8s.io/klog/v2"
+)
+
+// ChangelogsFromChartVersions converts the changes of the model chart versions
+// into the changelog of each version.
+func ChangelogsFromChartVersions(versions []models.ChartVersion) []*corev1.PackageVersionChangelog {
+	changelogs := make([]*corev1.PackageVersionChangelog, 0, len(versions))
+	for _, v := range versions {
+		changelog := &corev1.PackageVersionChangelog{PkgVersion: v.Version}
+		for _, c := range v.Changes {
+			change := &corev1.PackageChange{
+				Kind:        c.Kind,
+				Description: c.Description,
+			}
+			for _, l := range c.Links {
+				change.Links = append(change.Links, &corev1.PackageChangeLink{Name: l.Name, Url: l.URL})
+			}
+			changelog.Changes = append(changelog.Changes, change)
+		}
+		changelogs = append(changelogs, changelog)
+	}
+	return changelogs
+}
+
+// FilterChangelogs returns the changelogs of the versions after fromVersion (excluded)
+// and up to toVersion (included), sorted from the most recent version to the oldest
+// one. Either bound is ignored when empty. Changelogs without release notes nor
+// changes, as well as versions which are not semver, are omitted.
+func FilterChangelogs(changelogs []*corev1.PackageVersionChangelog, fromVersion, toVersion string) ([]*corev1.PackageVersionChangelog, error) {
+	parseBound := func(name, version string) (*semver.Version, error) {
+		if version == "" {
+			return nil, nil
+		}
+		v, err := semver.NewVersion(version)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Invalid %s %q: %w", name, version, err))
+		}
+		return v, nil
+	}
+	from, err := parseBound("from version", fromVersion)
+	if err != nil {
+		return nil, err
+	}
+	to, err := parseBound("to version", toVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	type versionChangelog struct {
+		version   *semver.Version
+		changelog *corev1.PackageVersionChangelog
+	}
+	var filtered []versionChangelog
+	for _, changelog := range changelogs {
+		if changelog.GetReleaseNotes() == "" && len(changelog.GetChanges()) == 0 {
+			continue
+		}
+		version, err := semver.NewVersion(changelog.GetPkgVersion())
+		if err != nil {
+			log.Errorf("Ignoring the changelog of version %q as it is not semver: %v", changelog.GetPkgVersion(), err)
+			continue
+		}
+		if (from != nil && !version.GreaterThan(from)) || (to != nil && version.GreaterThan(to)) {
+			continue
+		}
+		filtered = append(filtered, versionChangelog{version: version, changelog: changelog})
+	}
+	sort.SliceStable(filtered, func(i, j int) bool {
+		return filtered[i].version.GreaterThan(filtered[j].version)
+	})
+
+	result := make([]*corev1.PackageVersionChangelog, 0, len(filtered))
+	for _, f := range filtered {
+		result = append(result, f.changelog)
+	}
+	return result, nil
+}
@@ -0,0 +1,122 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package pkgutils
+
+import (
+	"testing"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/pkg/chart/models"
+)
+
+func TestChangelogsFromChartVersions(t *testing.T) {
+	versions := []models.ChartVersion{
+		{
+			Version: "2.0.0",
+			Changes: []models.ChartVersionChange{
+				{
+					Kind:        "added",
+					Description: "Added support for ingress",
+					Links:       []models.ChartChangeLink{{Name: "GitHub Issue", URL: "https://github.com/example/chart/issues/1"}},
+				},
+				{Description: "Fixed the probes"},
+			},
+		},
+		{Version: "1.0.0"},
+	}
+	expected := []*corev1.PackageVersionChangelog{
+		{
+			PkgVersion: "2.0.0",
+			Changes: []*corev1.PackageChange{
+				{
+					Kind:        "added",
+					Description: "Added support for ingress",
+					Links:       []*corev1.PackageChangeLink{{Name: "GitHub Issue", Url: "https://github.com/example/chart/issues/1"}},
+				},
+				{Description: "Fixed the probes"},
+			},
+		},
+		{PkgVersion: "1.0.0"},
+	}
+
+	opts := cmpopts.IgnoreUnexported(corev1.PackageVersionChangelog{}, corev1.PackageChange{}, corev1.PackageChangeLink{})
+	if got, want := ChangelogsFromChartVersions(versions), expected; !cmp.Equal(want, got, opts) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, opts))
+	}
+}
+
+func TestFilterChangelogs(t *testing.T) {
+	changelogs := []*corev1.PackageVersionChangelog{
+		{PkgVersion: "1.0.0", ReleaseNotes: "first release"},
+		{PkgVersion: "1.2.0", ReleaseNotes: "new features"},
+		{PkgVersion: "1.1.0", Changes: []*corev1.PackageChange{{Description: "Fixed the probes"}}},
+		{PkgVersion: "1.3.0"},
+		{PkgVersion: "latest", ReleaseNotes: "not semver"},
+		{PkgVersion: "2.0.0", ReleaseNotes: "breaking changes"},
+	}
+
+	testCases := []struct {
+		name              string
+		fromVersion       string
+		toVersion         string
+		expectedVersions  []string
+		expectedErrorCode connect.Code
+	}{
+		{
+			name:             "it returns every changelog, most recent first, without bounds",
+			expectedVersions: []string{"2.0.0", "1.2.0", "1.1.0", "1.0.0"},
+		},
+		{
+			name:             "it excludes the from version and includes the to version",
+			fromVersion:      "1.0.0",
+			toVersion:        "1.2.0",
+			expectedVersions: []string{"1.2.0", "1.1.0"},
+		},
+		{
+			name:             "it returns the changelogs up to the latest version by default",
+			fromVersion:      "1.1.0",
+			expectedVersions: []string{"2.0.0", "1.2.0"},
+		},
+		{
+			name:             "it returns no changelogs when already on the target version",
+			fromVersion:      "2.0.0",
+			toVersion:        "2.0.0",
+			expectedVersions: []string{},
+		},
+		{
+			name:              "it returns an error for an invalid from version",
+			fromVersion:       "foo",
+			expectedErrorCode: connect.CodeInvalidArgument,
+		},
+		{
+			name:              "it returns an error for an invalid to version",
+			toVersion:         "bar",
+			expectedErrorCode: connect.CodeInvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			filtered, err := FilterChangelogs(changelogs, tc.fromVersion, tc.toVersion)
+
+			if got, want := connect.CodeOf(err), tc.expectedErrorCode; err != nil && got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+			if tc.expectedErrorCode != 0 {
+				return
+			}
+
+			versions := []string{}
+			for _, changelog := range filtered {
+				versions = append(versions, changelog.GetPkgVersion())
+			}
+			if got, want := versions, tc.expectedVersions; !cmp.Equal(want, got) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
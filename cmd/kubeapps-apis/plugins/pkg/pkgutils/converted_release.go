@@ -0,0 +1,10 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package pkgutils
+
+// ConvertedReleaseAnnotation is set by the fluxv2 plugin on the Helm storage
+// secrets of a release converted into a flux HelmRelease, with the namespaced
+// name of the HelmRelease as value. The 'helm' plugin does not mutate such a
+// release anymore.
+const ConvertedReleaseAnnotation = "kubeapps.dev/converted-to"
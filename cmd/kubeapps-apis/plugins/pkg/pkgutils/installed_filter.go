@@ -0,0 +1,110 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package pkgutils
+
+import (
+	"fmt"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/bufbuild/connect-go"
+	corev1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// InstalledPackageLabelSelector parses the label selector of the filter
+// options, to be passed to the Kubernetes API when listing the resources
+// representing the installed packages. An empty selector matches everything.
+func InstalledPackageLabelSelector(filterOptions *corev1.InstalledPackageFilterOptions) (labels.Selector, error) {
+	selector, err := labels.Parse(filterOptions.GetLabelSelector())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Invalid label selector %q: %w", filterOptions.GetLabelSelector(), err))
+	}
+	return selector, nil
+}
+
+// HasInstalledPackageSummaryFilters returns whether the filter options can
+// only be applied to the installed package summaries once built, that is,
+// whether they filter by status or by pending upgrade. When they do, the
+// plugins need to filter the summaries before paginating them.
+func HasInstalledPackageSummaryFilters(filterOptions *corev1.InstalledPackageFilterOptions) bool {
+	return len(filterOptions.GetStatusReasons()) > 0 || filterOptions.GetOnlyUpgradable()
+}
+
+// MatchesInstalledPackageSummaryFilters returns whether the summary matches
+// the status and pending upgrade filters of the filter options. The label
+// selector is not checked, as the summaries do not carry the labels of their
+// resources.
+func MatchesInstalledPackageSummaryFilters(summary *corev1.InstalledPackageSummary, filterOptions *corev1.InstalledPackageFilterOptions) bool {
+	if reasons := filterOptions.GetStatusReasons(); len(reasons) > 0 {
+		matches := false
+		for _, reason := range reasons {
+			if summary.GetStatus().GetReason() == reason {
+				matches = true
+				break
+			}
+		}
+		if !matches {
+			return false
+		}
+	}
+	if filterOptions.GetOnlyUpgradable() && !HasPendingUpgrade(summary) {
+		return false
+	}
+	return true
+}
+
+// FilterInstalledPackageSummaries returns the summaries matching the status
+// and pending upgrade filters of the filter options.
+func FilterInstalledPackageSummaries(summaries []*corev1.InstalledPackageSummary, filterOptions *corev1.InstalledPackageFilterOptions) []*corev1.InstalledPackageSummary {
+	if !HasInstalledPackageSummaryFilters(filterOptions) {
+		return summaries
+	}
+	filtered := []*corev1.InstalledPackageSummary{}
+	for _, summary := range summaries {
+		if MatchesInstalledPackageSummaryFilters(summary, filterOptions) {
+			filtered = append(filtered, summary)
+		}
+	}
+	return filtered
+}
+
+// PaginateInstalledPackageSummaries returns the page of summaries starting at
+// the item offset, all of them if the page size is 0.
+func PaginateInstalledPackageSummaries(summaries []*corev1.InstalledPackageSummary, itemOffset int, pageSize int32) []*corev1.InstalledPackageSummary {
+	if pageSize <= 0 {
+		return summaries
+	}
+	if itemOffset >= len(summaries) {
+		return []*corev1.InstalledPackageSummary{}
+	}
+	summaries = summaries[itemOffset:]
+	if len(summaries) > int(pageSize) {
+		summaries = summaries[:pageSize]
+	}
+	return summaries
+}
+
+// HasPendingUpgrade returns whether a newer version than the current one is
+// available for the installed package: the latest version matching its
+// version constraints if known, the latest version otherwise. Versions which
+// are not semver are only compared for equality.
+func HasPendingUpgrade(summary *corev1.InstalledPackageSummary) bool {
+	latest := summary.GetLatestMatchingVersion().GetPkgVersion()
+	if latest == "" {
+		latest = summary.GetLatestVersion().GetPkgVersion()
+	}
+	current := summary.GetCurrentVersion().GetPkgVersion()
+	if latest == "" || current == "" {
+		return false
+	}
+	latestVersion, err := semver.NewVersion(latest)
+	if err != nil {
+		return latest != current
+	}
+	currentVersion, err := semver.NewVersion(current)
+	if err != nil {
+		return latest != current
+	}
+	return latestVersion.GreaterThan(currentVersion)
+}
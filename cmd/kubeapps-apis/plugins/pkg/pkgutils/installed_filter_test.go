@@ -0,0 +1,185 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package pkgutils
+
+import (
+	"testing"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/google/go-cmp/cmp"
+	corev1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func TestInstalledPackageLabelSelector(t *testing.T) {
+	testCases := []struct {
+		name              string
+		filterOptions     *corev1.InstalledPackageFilterOptions
+		labels            labels.Set
+		expectedMatch     bool
+		expectedErrorCode connect.Code
+	}{
+		{
+			name:          "it matches everything without filter options",
+			labels:        labels.Set{"team": "frontend"},
+			expectedMatch: true,
+		},
+		{
+			name:          "it matches the labels of the selector",
+			filterOptions: &corev1.InstalledPackageFilterOptions{LabelSelector: "team=frontend,tier!=cache"},
+			labels:        labels.Set{"team": "frontend", "tier": "web"},
+			expectedMatch: true,
+		},
+		{
+			name:          "it does not match other labels",
+			filterOptions: &corev1.InstalledPackageFilterOptions{LabelSelector: "team=frontend,tier!=cache"},
+			labels:        labels.Set{"team": "frontend", "tier": "cache"},
+		},
+		{
+			name:              "it returns an invalid argument error for an invalid selector",
+			filterOptions:     &corev1.InstalledPackageFilterOptions{LabelSelector: "team in (frontend"},
+			expectedErrorCode: connect.CodeInvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			selector, err := InstalledPackageLabelSelector(tc.filterOptions)
+			if got, want := connect.CodeOf(err), tc.expectedErrorCode; err != nil && got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			} else if err == nil && tc.expectedErrorCode != 0 {
+				t.Fatalf("got: nil, want: error with code %+v", tc.expectedErrorCode)
+			}
+			if err != nil {
+				return
+			}
+			if got, want := selector.Matches(tc.labels), tc.expectedMatch; got != want {
+				t.Errorf("got: %t, want: %t", got, want)
+			}
+		})
+	}
+}
+
+func TestFilterInstalledPackageSummaries(t *testing.T) {
+	summary := func(name string, reason corev1.InstalledPackageStatus_StatusReason, current, latestMatching, latest string) *corev1.InstalledPackageSummary {
+		s := &corev1.InstalledPackageSummary{
+			Name:           name,
+			Status:         &corev1.InstalledPackageStatus{Reason: reason},
+			CurrentVersion: &corev1.PackageAppVersion{PkgVersion: current},
+		}
+		if latestMatching != "" {
+			s.LatestMatchingVersion = &corev1.PackageAppVersion{PkgVersion: latestMatching}
+		}
+		if latest != "" {
+			s.LatestVersion = &corev1.PackageAppVersion{PkgVersion: latest}
+		}
+		return s
+	}
+	summaries := []*corev1.InstalledPackageSummary{
+		summary("up-to-date", corev1.InstalledPackageStatus_STATUS_REASON_INSTALLED, "1.0.0", "", "1.0.0"),
+		summary("upgradable", corev1.InstalledPackageStatus_STATUS_REASON_INSTALLED, "1.0.0", "", "1.1.0"),
+		summary("constrained", corev1.InstalledPackageStatus_STATUS_REASON_INSTALLED, "1.0.0", "1.0.0", "2.0.0"),
+		summary("failed", corev1.InstalledPackageStatus_STATUS_REASON_FAILED, "1.0.0", "1.0.1", "1.0.1"),
+		summary("pending", corev1.InstalledPackageStatus_STATUS_REASON_PENDING, "1.0.0", "", ""),
+		summary("not-semver", corev1.InstalledPackageStatus_STATUS_REASON_INSTALLED, "stable", "", "edge"),
+	}
+
+	testCases := []struct {
+		name          string
+		filterOptions *corev1.InstalledPackageFilterOptions
+		expectedNames []string
+	}{
+		{
+			name:          "it returns all the summaries without filter options",
+			expectedNames: []string{"up-to-date", "upgradable", "constrained", "failed", "pending", "not-semver"},
+		},
+		{
+			name:          "it ignores the label selector",
+			filterOptions: &corev1.InstalledPackageFilterOptions{LabelSelector: "team=frontend"},
+			expectedNames: []string{"up-to-date", "upgradable", "constrained", "failed", "pending", "not-semver"},
+		},
+		{
+			name: "it returns the summaries with one of the status reasons",
+			filterOptions: &corev1.InstalledPackageFilterOptions{
+				StatusReasons: []corev1.InstalledPackageStatus_StatusReason{
+					corev1.InstalledPackageStatus_STATUS_REASON_FAILED,
+					corev1.InstalledPackageStatus_STATUS_REASON_PENDING,
+				},
+			},
+			expectedNames: []string{"failed", "pending"},
+		},
+		{
+			name:          "it returns the summaries with a newer version matching their constraints",
+			filterOptions: &corev1.InstalledPackageFilterOptions{OnlyUpgradable: true},
+			expectedNames: []string{"upgradable", "failed", "not-semver"},
+		},
+		{
+			name: "it returns the summaries matching all the filters",
+			filterOptions: &corev1.InstalledPackageFilterOptions{
+				StatusReasons:  []corev1.InstalledPackageStatus_StatusReason{corev1.InstalledPackageStatus_STATUS_REASON_INSTALLED},
+				OnlyUpgradable: true,
+			},
+			expectedNames: []string{"upgradable", "not-semver"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			names := []string{}
+			for _, s := range FilterInstalledPackageSummaries(summaries, tc.filterOptions) {
+				names = append(names, s.GetName())
+			}
+			if got, want := names, tc.expectedNames; !cmp.Equal(want, got) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
+func TestPaginateInstalledPackageSummaries(t *testing.T) {
+	summaries := []*corev1.InstalledPackageSummary{{Name: "a"}, {Name: "b"}, {Name: "c"}}
+
+	testCases := []struct {
+		name          string
+		itemOffset    int
+		pageSize      int32
+		expectedNames []string
+	}{
+		{
+			name:          "it returns all the summaries without a page size",
+			itemOffset:    1,
+			expectedNames: []string{"a", "b", "c"},
+		},
+		{
+			name:          "it returns the page at the offset",
+			itemOffset:    1,
+			pageSize:      1,
+			expectedNames: []string{"b"},
+		},
+		{
+			name:          "it returns a partial last page",
+			itemOffset:    2,
+			pageSize:      2,
+			expectedNames: []string{"c"},
+		},
+		{
+			name:          "it returns no summary beyond the last page",
+			itemOffset:    3,
+			pageSize:      2,
+			expectedNames: []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			names := []string{}
+			for _, s := range PaginateInstalledPackageSummaries(summaries, tc.itemOffset, tc.pageSize) {
+				names = append(names, s.GetName())
+			}
+			if got, want := names, tc.expectedNames; !cmp.Equal(want, got) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
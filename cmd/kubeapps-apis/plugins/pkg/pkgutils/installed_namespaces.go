@@ -0,0 +1,63 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package pkgutils
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bufbuild/connect-go"
+	corev1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	plugins "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/plugins/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/paginate"
+	"google.golang.org/protobuf/proto"
+	log "k8s.io/klog/v2"
+)
+
+// GetInstalledPackageSummariesPerNamespace serves a listing of the installed
+// packages across namespaces, which the user is not allowed to do at the
+// cluster scope, by listing them with the given function in each of the given
+// namespaces of the given cluster. The namespaces in which the listing fails do
+// not block the whole list but are reported as partial results.
+func GetInstalledPackageSummariesPerNamespace(ctx context.Context, request *connect.Request[corev1.GetInstalledPackageSummariesRequest], cluster string, namespaces []string, plugin *plugins.Plugin, getInstalledPackageSummaries func(context.Context, *connect.Request[corev1.GetInstalledPackageSummariesRequest]) (*connect.Response[corev1.GetInstalledPackageSummariesResponse], error)) (*connect.Response[corev1.GetInstalledPackageSummariesResponse], error) {
+	pageSize := request.Msg.GetPaginationOptions().GetPageSize()
+	itemOffset, err := paginate.ItemOffsetFromPageToken(request.Msg.GetPaginationOptions().GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := []*corev1.InstalledPackageSummary{}
+	var failures []*corev1.NamespaceFailure
+	for _, namespace := range namespaces {
+		nsRequest := proto.Clone(request.Msg).(*corev1.GetInstalledPackageSummariesRequest)
+		nsRequest.Context = &corev1.Context{Cluster: cluster, Namespace: namespace}
+		nsRequest.PaginationOptions = nil
+		nsConnectRequest := connect.NewRequest(nsRequest)
+		for key, values := range request.Header() {
+			nsConnectRequest.Header()[key] = values
+		}
+
+		response, err := getInstalledPackageSummaries(ctx, nsConnectRequest)
+		if err != nil {
+			log.Warningf("+%s could not list the installed packages in namespace %s: %v", plugin.GetName(), namespace, err)
+			failures = append(failures, NewNamespaceFailure(cluster, namespace, plugin, err))
+			continue
+		}
+		summaries = append(summaries, response.Msg.GetInstalledPackageSummaries()...)
+		failures = append(failures, response.Msg.GetPartialResults().GetNamespaceFailures()...)
+	}
+	summaries = PaginateInstalledPackageSummaries(summaries, itemOffset, pageSize)
+
+	// Only return a next page token if the request was for pagination and
+	// the results are a full page.
+	nextPageToken := ""
+	if pageSize > 0 && len(summaries) == int(pageSize) {
+		nextPageToken = fmt.Sprintf("%d", itemOffset+int(pageSize))
+	}
+	return connect.NewResponse(&corev1.GetInstalledPackageSummariesResponse{
+		InstalledPackageSummaries: summaries,
+		NextPageToken:             nextPageToken,
+		PartialResults:            NewPartialResults(failures),
+	}), nil
+}
@@ -0,0 +1,94 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package pkgutils
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	plugins "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/plugins/v1alpha1"
+)
+
+func TestGetInstalledPackageSummariesPerNamespace(t *testing.T) {
+	plugin := &plugins.Plugin{Name: "my-plugin", Version: "v1alpha1"}
+	summariesByNamespace := map[string][]*corev1.InstalledPackageSummary{
+		"team-a": {{Name: "a1"}, {Name: "a2"}},
+		"team-b": {{Name: "b1"}},
+	}
+	getInstalledPackageSummaries := func(ctx context.Context, request *connect.Request[corev1.GetInstalledPackageSummariesRequest]) (*connect.Response[corev1.GetInstalledPackageSummariesResponse], error) {
+		if got, want := request.Header().Get("Authorization"), "Bearer token"; got != want {
+			t.Errorf("got: %q, want: %q", got, want)
+		}
+		if request.Msg.GetPaginationOptions() != nil {
+			t.Errorf("got: %v, want: no pagination options", request.Msg.GetPaginationOptions())
+		}
+		summaries, ok := summariesByNamespace[request.Msg.GetContext().GetNamespace()]
+		if !ok {
+			return nil, connect.NewError(connect.CodePermissionDenied, errors.New("forbidden"))
+		}
+		return connect.NewResponse(&corev1.GetInstalledPackageSummariesResponse{InstalledPackageSummaries: summaries}), nil
+	}
+
+	testCases := []struct {
+		name              string
+		paginationOptions *corev1.PaginationOptions
+		expectedResponse  *corev1.GetInstalledPackageSummariesResponse
+	}{
+		{
+			name: "it lists the installed packages of each namespace, reporting the failed ones",
+			expectedResponse: &corev1.GetInstalledPackageSummariesResponse{
+				InstalledPackageSummaries: []*corev1.InstalledPackageSummary{{Name: "a1"}, {Name: "a2"}, {Name: "b1"}},
+				PartialResults: &corev1.PartialResults{
+					NamespaceFailures: []*corev1.NamespaceFailure{{
+						Context: &corev1.Context{Cluster: "default", Namespace: "team-c"},
+						Plugin:  plugin,
+						Code:    connect.CodePermissionDenied.String(),
+						Reason:  "forbidden",
+					}},
+				},
+			},
+		},
+		{
+			name:              "it paginates the installed packages of all the namespaces",
+			paginationOptions: &corev1.PaginationOptions{PageSize: 2, PageToken: "1"},
+			expectedResponse: &corev1.GetInstalledPackageSummariesResponse{
+				InstalledPackageSummaries: []*corev1.InstalledPackageSummary{{Name: "a2"}, {Name: "b1"}},
+				NextPageToken:             "3",
+				PartialResults: &corev1.PartialResults{
+					NamespaceFailures: []*corev1.NamespaceFailure{{
+						Context: &corev1.Context{Cluster: "default", Namespace: "team-c"},
+						Plugin:  plugin,
+						Code:    connect.CodePermissionDenied.String(),
+						Reason:  "forbidden",
+					}},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			request := connect.NewRequest(&corev1.GetInstalledPackageSummariesRequest{
+				Context:           &corev1.Context{Cluster: "default"},
+				PaginationOptions: tc.paginationOptions,
+			})
+			request.Header().Set("Authorization", "Bearer token")
+
+			response, err := GetInstalledPackageSummariesPerNamespace(context.Background(), request, "default", []string{"team-a", "team-b", "team-c"}, plugin, getInstalledPackageSummaries)
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+
+			opts := cmpopts.IgnoreUnexported(corev1.GetInstalledPackageSummariesResponse{}, corev1.InstalledPackageSummary{}, corev1.PartialResults{}, corev1.NamespaceFailure{}, corev1.Context{}, plugins.Plugin{})
+			if got, want := response.Msg, tc.expectedResponse; !cmp.Equal(want, got, opts) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, opts))
+			}
+		})
+	}
+}
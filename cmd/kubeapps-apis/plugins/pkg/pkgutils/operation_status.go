@@ -0,0 +1,40 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package pkgutils
+
+import (
+	"context"
+
+	"github.com/bufbuild/connect-go"
+	corev1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+)
+
+// GetInstalledPackageOperationStatus returns the overall status of an installed
+// package, with no phases, for the plugins whose operations are not split in
+// phases. The installed package is retrieved with the given
+// getInstalledPackageDetail function (usually the plugin's
+// GetInstalledPackageDetail) on behalf of the same user.
+func GetInstalledPackageOperationStatus(ctx context.Context, request *connect.Request[corev1.GetInstalledPackageOperationStatusRequest], getInstalledPackageDetail func(context.Context, *connect.Request[corev1.GetInstalledPackageDetailRequest]) (*connect.Response[corev1.GetInstalledPackageDetailResponse], error)) (*connect.Response[corev1.GetInstalledPackageOperationStatusResponse], error) {
+	detailRequest := connect.NewRequest(&corev1.GetInstalledPackageDetailRequest{
+		InstalledPackageRef: request.Msg.GetInstalledPackageRef(),
+	})
+	for key, values := range request.Header() {
+		detailRequest.Header()[key] = values
+	}
+	detailResponse, err := getInstalledPackageDetail(ctx, detailRequest)
+	if err != nil {
+		return nil, err
+	}
+	status := detailResponse.Msg.GetInstalledPackageDetail().GetStatus()
+	if status == nil {
+		// Without any status reported yet, the operation has not finished.
+		status = &corev1.InstalledPackageStatus{
+			Reason: corev1.InstalledPackageStatus_STATUS_REASON_PENDING,
+		}
+	}
+	return connect.NewResponse(&corev1.GetInstalledPackageOperationStatusResponse{
+		Status: status,
+		Phases: []*corev1.InstalledPackageOperationPhase{},
+	}), nil
+}
@@ -0,0 +1,88 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package pkgutils
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+)
+
+func TestGetInstalledPackageOperationStatus(t *testing.T) {
+	testCases := []struct {
+		name              string
+		detail            *corev1.InstalledPackageDetail
+		detailErrorCode   connect.Code
+		expectedResponse  *corev1.GetInstalledPackageOperationStatusResponse
+		expectedErrorCode connect.Code
+	}{
+		{
+			name: "it returns the status of the installed package, without phases",
+			detail: &corev1.InstalledPackageDetail{
+				Status: &corev1.InstalledPackageStatus{
+					Ready:  true,
+					Reason: corev1.InstalledPackageStatus_STATUS_REASON_INSTALLED,
+				},
+			},
+			expectedResponse: &corev1.GetInstalledPackageOperationStatusResponse{
+				Status: &corev1.InstalledPackageStatus{
+					Ready:  true,
+					Reason: corev1.InstalledPackageStatus_STATUS_REASON_INSTALLED,
+				},
+				Phases: []*corev1.InstalledPackageOperationPhase{},
+			},
+		},
+		{
+			name:   "it returns a pending status when the installed package has none",
+			detail: &corev1.InstalledPackageDetail{},
+			expectedResponse: &corev1.GetInstalledPackageOperationStatusResponse{
+				Status: &corev1.InstalledPackageStatus{
+					Reason: corev1.InstalledPackageStatus_STATUS_REASON_PENDING,
+				},
+				Phases: []*corev1.InstalledPackageOperationPhase{},
+			},
+		},
+		{
+			name:              "it returns the error retrieving the installed package",
+			detailErrorCode:   connect.CodeNotFound,
+			expectedErrorCode: connect.CodeNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			getDetail := func(ctx context.Context, request *connect.Request[corev1.GetInstalledPackageDetailRequest]) (*connect.Response[corev1.GetInstalledPackageDetailResponse], error) {
+				if got, want := request.Header().Get("Authorization"), "Bearer foo"; got != want {
+					t.Errorf("got: %q, want: %q", got, want)
+				}
+				if tc.detailErrorCode != 0 {
+					return nil, connect.NewError(tc.detailErrorCode, fmt.Errorf("Non-OK response"))
+				}
+				return connect.NewResponse(&corev1.GetInstalledPackageDetailResponse{InstalledPackageDetail: tc.detail}), nil
+			}
+			request := connect.NewRequest(&corev1.GetInstalledPackageOperationStatusRequest{
+				InstalledPackageRef: &corev1.InstalledPackageReference{Identifier: "my-release"},
+			})
+			request.Header().Set("Authorization", "Bearer foo")
+
+			response, err := GetInstalledPackageOperationStatus(context.Background(), request, getDetail)
+
+			if got, want := connect.CodeOf(err), tc.expectedErrorCode; err != nil && got != want {
+				t.Fatalf("got: %v, want: %v", got, want)
+			}
+			if tc.expectedErrorCode != 0 {
+				return
+			}
+			opts := cmpopts.IgnoreUnexported(corev1.GetInstalledPackageOperationStatusResponse{}, corev1.InstalledPackageStatus{})
+			if got, want := response.Msg, tc.expectedResponse; !cmp.Equal(want, got, opts) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, opts))
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package pkgutils
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// AnnotationAllowedPackagesKey and AnnotationDeniedPackagesKey record, in
+	// a package repository resource, the comma-separated glob patterns on the
+	// name of the packages made available or hidden from the repository.
+	AnnotationAllowedPackagesKey = "kubeapps.dev/allowed-packages"
+	AnnotationDeniedPackagesKey  = "kubeapps.dev/denied-packages"
+)
+
+// PackagePolicy restricts the packages made available from a package
+// repository, using glob patterns (as in path.Match) matched against the
+// package names.
+type PackagePolicy struct {
+	AllowedPackages []string
+	DeniedPackages  []string
+}
+
+// PackagePolicyFromAnnotations returns the policy declared in the annotations
+// of a package repository resource, or nil if the repository does not
+// restrict its packages.
+func PackagePolicyFromAnnotations(annotations map[string]string) *PackagePolicy {
+	policy := &PackagePolicy{
+		AllowedPackages: SplitPackagePatterns(annotations[AnnotationAllowedPackagesKey]),
+		DeniedPackages:  SplitPackagePatterns(annotations[AnnotationDeniedPackagesKey]),
+	}
+	if len(policy.AllowedPackages) == 0 && len(policy.DeniedPackages) == 0 {
+		return nil
+	}
+	return policy
+}
+
+// Allows returns true if the package with the given name is made available.
+// A nil policy allows every package.
+func (p *PackagePolicy) Allows(name string) bool {
+	if p == nil {
+		return true
+	}
+	if matchesPackagePatterns(name, p.DeniedPackages) {
+		return false
+	}
+	return len(p.AllowedPackages) == 0 || matchesPackagePatterns(name, p.AllowedPackages)
+}
+
+func matchesPackagePatterns(name string, patterns []string) bool {
+	for _, pattern := range patterns {
+		// the patterns are validated when stored, so errors are not expected
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidatePackagePatterns checks that the patterns are valid globs that can be
+// stored in a comma-separated annotation.
+func ValidatePackagePatterns(patterns []string) error {
+	for _, pattern := range patterns {
+		if strings.TrimSpace(pattern) == "" {
+			return fmt.Errorf("empty package pattern")
+		}
+		if strings.Contains(pattern, ",") {
+			return fmt.Errorf("the package pattern %q must not contain a comma", pattern)
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid package pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// SplitPackagePatterns returns the patterns of a comma-separated annotation.
+func SplitPackagePatterns(annotation string) []string {
+	var patterns []string
+	for _, pattern := range strings.Split(annotation, ",") {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			patterns = append(patterns, pattern)
+		}
+	}
+	return patterns
+}
+
+// SetPackagePatterns stores the patterns in the given annotation, removing it
+// if there are no patterns.
+func SetPackagePatterns(metadata *metav1.ObjectMeta, key string, patterns []string) {
+	if len(patterns) == 0 {
+		delete(metadata.Annotations, key)
+		return
+	}
+	if metadata.Annotations == nil {
+		metadata.Annotations = make(map[string]string)
+	}
+	trimmed := make([]string, len(patterns))
+	for i, pattern := range patterns {
+		trimmed[i] = strings.TrimSpace(pattern)
+	}
+	metadata.Annotations[key] = strings.Join(trimmed, ",")
+}
+
+// PackagePatternsRegexp returns a POSIX regular expression matching the same
+// names as any of the (valid) glob patterns, so that the patterns can be
+// applied by a database. It returns an empty string if there are no patterns.
+func PackagePatternsRegexp(patterns []string) string {
+	if len(patterns) == 0 {
+		return ""
+	}
+	alternatives := make([]string, len(patterns))
+	for i, pattern := range patterns {
+		alternatives[i] = globToRegexp(pattern)
+	}
+	return "^(" + strings.Join(alternatives, "|") + ")$"
+}
+
+// globToRegexp translates a glob pattern, as supported by path.Match, into a
+// regular expression.
+func globToRegexp(pattern string) string {
+	var b strings.Builder
+	inClass := false
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		switch {
+		case c == '\\' && i+1 < len(pattern):
+			i++
+			b.WriteString(regexp.QuoteMeta(string(pattern[i])))
+		case inClass:
+			if c == ']' {
+				inClass = false
+			}
+			b.WriteByte(c)
+		case c == '*':
+			b.WriteString("[^/]*")
+		case c == '?':
+			b.WriteString("[^/]")
+		case c == '[':
+			inClass = true
+			b.WriteByte(c)
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,132 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package pkgutils
+
+import (
+	"path"
+	"regexp"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPackagePolicyAllows(t *testing.T) {
+	testCases := []struct {
+		name     string
+		policy   *PackagePolicy
+		expected map[string]bool
+	}{
+		{
+			name:     "a nil policy allows every package",
+			expected: map[string]bool{"apache": true, "nginx": true},
+		},
+		{
+			name:     "it only allows the packages matching the allow list",
+			policy:   &PackagePolicy{AllowedPackages: []string{"*.bitnami.com", "nginx"}},
+			expected: map[string]bool{"apache.bitnami.com": true, "nginx": true, "apache": false},
+		},
+		{
+			name:     "it denies the packages matching the deny list",
+			policy:   &PackagePolicy{DeniedPackages: []string{"ngin?"}},
+			expected: map[string]bool{"apache": true, "nginx": false},
+		},
+		{
+			name:     "the deny list takes precedence over the allow list",
+			policy:   &PackagePolicy{AllowedPackages: []string{"*"}, DeniedPackages: []string{"nginx"}},
+			expected: map[string]bool{"apache": true, "nginx": false},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for name, expected := range tc.expected {
+				if got, want := tc.policy.Allows(name), expected; got != want {
+					t.Errorf("%q: got: %t, want: %t", name, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestPackagePolicyAnnotations(t *testing.T) {
+	metadata := metav1.ObjectMeta{}
+	SetPackagePatterns(&metadata, AnnotationAllowedPackagesKey, []string{" *.bitnami.com", "nginx "})
+	SetPackagePatterns(&metadata, AnnotationDeniedPackagesKey, nil)
+
+	expectedAnnotations := map[string]string{AnnotationAllowedPackagesKey: "*.bitnami.com,nginx"}
+	if got, want := metadata.Annotations, expectedAnnotations; !cmp.Equal(want, got) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+	}
+	expectedPolicy := &PackagePolicy{AllowedPackages: []string{"*.bitnami.com", "nginx"}}
+	if got, want := PackagePolicyFromAnnotations(metadata.Annotations), expectedPolicy; !cmp.Equal(want, got) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+	}
+
+	SetPackagePatterns(&metadata, AnnotationAllowedPackagesKey, nil)
+	if got := PackagePolicyFromAnnotations(metadata.Annotations); got != nil {
+		t.Errorf("got: %+v, want: nil", got)
+	}
+}
+
+func TestValidatePackagePatterns(t *testing.T) {
+	testCases := []struct {
+		name        string
+		patterns    []string
+		expectedErr bool
+	}{
+		{
+			name:     "it accepts globs",
+			patterns: []string{"*.bitnami.com", "ngin?", "[a-c]*"},
+		},
+		{
+			name:        "it rejects empty patterns",
+			patterns:    []string{" "},
+			expectedErr: true,
+		},
+		{
+			name:        "it rejects patterns with a comma",
+			patterns:    []string{"apache,nginx"},
+			expectedErr: true,
+		},
+		{
+			name:        "it rejects malformed patterns",
+			patterns:    []string{"[a-"},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got, want := ValidatePackagePatterns(tc.patterns) != nil, tc.expectedErr; got != want {
+				t.Errorf("got: %t, want: %t", got, want)
+			}
+		})
+	}
+}
+
+func TestPackagePatternsRegexp(t *testing.T) {
+	patterns := []string{"*.bitnami.com", "ngin?", "[a-c]pache", "[^a-c]ar", `lit\*eral`, "dot.ted", "a+b"}
+	names := []string{
+		"apache.bitnami.com", "foo/bar.bitnami.com", "bitnami.com", "nginx", "nginxx",
+		"apache", "bpache", "dpache", "bar", "car", "lit*eral", "litteral", "dot.ted", "dotxted", "a+b", "aab",
+	}
+
+	r := regexp.MustCompile(PackagePatternsRegexp(patterns))
+	for _, name := range names {
+		expected := false
+		for _, pattern := range patterns {
+			if matched, _ := path.Match(pattern, name); matched {
+				expected = true
+			}
+		}
+		if got, want := r.MatchString(name), expected; got != want {
+			t.Errorf("%q: got: %t, want: %t", name, got, want)
+		}
+	}
+
+	if got := PackagePatternsRegexp(nil); got != "" {
+		t.Errorf("got: %q, want: empty", got)
+	}
+}
@@ -8,6 +8,7 @@ package pkgutils
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"net/url"
 	"reflect"
@@ -421,3 +422,47 @@ func FromDuration(duration *metav1.Duration) string {
 		return s
 	}
 }
+
+// NewNamespaceFailure returns the NamespaceFailure reported in partial results
+// when the given namespace cannot be included in a listing.
+func NewNamespaceFailure(cluster, namespace string, plugin *plugins.Plugin, err error) *corev1.NamespaceFailure {
+	reason := err.Error()
+	var connectErr *connect.Error
+	if errors.As(err, &connectErr) {
+		reason = connectErr.Message()
+	}
+	return &corev1.NamespaceFailure{
+		Context: &corev1.Context{
+			Cluster:   cluster,
+			Namespace: namespace,
+		},
+		Plugin: plugin,
+		Code:   connect.CodeOf(err).String(),
+		Reason: reason,
+	}
+}
+
+// NewPartialResults returns the PartialResults for the given namespace
+// failures, or nil if there is none, ie. the results are complete.
+func NewPartialResults(failures []*corev1.NamespaceFailure) *corev1.PartialResults {
+	if len(failures) == 0 {
+		return nil
+	}
+	return &corev1.PartialResults{
+		NamespaceFailures: failures,
+	}
+}
+
+// SortedDistinct returns the distinct values, sorted in increasing order.
+func SortedDistinct(values []string) []string {
+	seen := map[string]bool{}
+	distinct := []string{}
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			distinct = append(distinct, value)
+		}
+	}
+	sort.Strings(distinct)
+	return distinct
+}
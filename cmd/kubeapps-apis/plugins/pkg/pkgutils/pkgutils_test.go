@@ -6,6 +6,7 @@ package pkgutils
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"reflect"
 	"testing"
 
@@ -1114,3 +1115,55 @@ func TestDefaultValues(t *testing.T) {
 		})
 	}
 }
+
+func TestNewPartialResults(t *testing.T) {
+	plugin := &plugins.Plugin{Name: "helm.packages", Version: "v1alpha1"}
+	err := connect.NewError(connect.CodePermissionDenied, fmt.Errorf("forbidden"))
+
+	if got := NewPartialResults(nil); got != nil {
+		t.Errorf("got: %v, want: nil", got)
+	}
+
+	got := NewPartialResults([]*corev1.NamespaceFailure{NewNamespaceFailure("default", "my-ns", plugin, err)})
+	want := &corev1.PartialResults{
+		NamespaceFailures: []*corev1.NamespaceFailure{
+			{
+				Context: &corev1.Context{Cluster: "default", Namespace: "my-ns"},
+				Plugin:  plugin,
+				Code:    "permission_denied",
+				Reason:  "forbidden",
+			},
+		},
+	}
+	opts := cmpopts.IgnoreUnexported(corev1.PartialResults{}, corev1.NamespaceFailure{}, corev1.Context{}, plugins.Plugin{})
+	if !cmp.Equal(want, got, opts) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, opts))
+	}
+}
+
+func TestSortedDistinct(t *testing.T) {
+	testCases := []struct {
+		name     string
+		values   []string
+		expected []string
+	}{
+		{
+			name:     "it returns an empty slice for no values",
+			values:   nil,
+			expected: []string{},
+		},
+		{
+			name:     "it removes the duplicates and sorts the values",
+			values:   []string{"Networking", "Database", "Networking", "Analytics"},
+			expected: []string{"Analytics", "Database", "Networking"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got, want := SortedDistinct(tc.values), tc.expected; !cmp.Equal(want, got) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package pkgutils
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bufbuild/connect-go"
+	corev1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// SecretUsages collects the objects referencing the secrets managed by a
+// plugin for a package repository, keyed by the name of the secret.
+type SecretUsages map[string][]*corev1.ResourceRef
+
+// NewSecretUsages returns the usages of the given managed secrets, with no
+// referencing object yet.
+func NewSecretUsages(secretNames ...string) SecretUsages {
+	usages := SecretUsages{}
+	for _, name := range secretNames {
+		if name != "" {
+			usages[name] = nil
+		}
+	}
+	return usages
+}
+
+// Add records that the object references the secret, ignoring the secrets
+// which are not managed for the package repository.
+func (u SecretUsages) Add(secretName string, ref *corev1.ResourceRef) {
+	refs, ok := u[secretName]
+	if !ok {
+		return
+	}
+	for _, r := range refs {
+		if r.GetApiVersion() == ref.GetApiVersion() && r.GetKind() == ref.GetKind() && r.GetNamespace() == ref.GetNamespace() && r.GetName() == ref.GetName() {
+			return
+		}
+	}
+	u[secretName] = append(refs, ref)
+}
+
+// InUse returns the usages of the secrets still referenced by other objects,
+// sorted by the name of the secret.
+func (u SecretUsages) InUse() []*corev1.PackageRepositorySecretUsage {
+	var inUse []*corev1.PackageRepositorySecretUsage
+	for name, refs := range u {
+		if len(refs) == 0 {
+			continue
+		}
+		inUse = append(inUse, &corev1.PackageRepositorySecretUsage{
+			SecretName:           name,
+			ReferencingResources: refs,
+		})
+	}
+	sort.Slice(inUse, func(i, j int) bool {
+		return inUse[i].SecretName < inUse[j].SecretName
+	})
+	return inUse
+}
+
+// SecretsInUseError returns the FailedPrecondition error preventing the
+// deletion of a package repository whose managed secrets are still in use,
+// with the usage of each secret as details.
+func SecretsInUseError(repoName string, inUse []*corev1.PackageRepositorySecretUsage) error {
+	var names []string
+	for _, usage := range inUse {
+		names = append(names, usage.GetSecretName())
+	}
+	connectErr := connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("Unable to delete the package repository %q: its secrets %s are still referenced by other objects", repoName, strings.Join(names, ", ")))
+	for _, usage := range inUse {
+		detail, err := connect.NewErrorDetail(usage)
+		if err != nil {
+			return connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to report the secrets in use: %w", err))
+		}
+		connectErr.AddDetail(detail)
+	}
+	return connectErr
+}
+
+// RemoveOwnerReference removes the reference to the owner from the object, such
+// as a secret, so that the object is not garbage-collected along with its owner.
+// It returns false if the object was not owned by it.
+func RemoveOwnerReference(obj metav1.Object, ownerUID types.UID) bool {
+	refs := obj.GetOwnerReferences()
+	for i, ref := range refs {
+		if ref.UID == ownerUID {
+			obj.SetOwnerReferences(append(refs[:i:i], refs[i+1:]...))
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,98 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package pkgutils
+
+import (
+	"testing"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/google/go-cmp/cmp"
+	corev1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	"google.golang.org/protobuf/testing/protocmp"
+	k8scorev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSecretUsagesInUse(t *testing.T) {
+	otherRepo := &corev1.ResourceRef{ApiVersion: "kubeapps.com/v1alpha1", Kind: "AppRepository", Name: "other-repo", Namespace: "default"}
+	otherInstall := &corev1.ResourceRef{ApiVersion: "packaging.carvel.dev/v1alpha1", Kind: "PackageInstall", Name: "my-install", Namespace: "default"}
+
+	usages := NewSecretUsages("apprepo-repo", "pullsecret-repo", "")
+	usages.Add("pullsecret-repo", otherInstall)
+	usages.Add("apprepo-repo", otherRepo)
+	usages.Add("apprepo-repo", otherInstall)
+	usages.Add("apprepo-repo", otherRepo)
+	usages.Add("user-managed-secret", otherRepo)
+
+	expected := []*corev1.PackageRepositorySecretUsage{
+		{
+			SecretName:           "apprepo-repo",
+			ReferencingResources: []*corev1.ResourceRef{otherRepo, otherInstall},
+		},
+		{
+			SecretName:           "pullsecret-repo",
+			ReferencingResources: []*corev1.ResourceRef{otherInstall},
+		},
+	}
+	if got, want := usages.InUse(), expected; !cmp.Equal(want, got, protocmp.Transform()) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, protocmp.Transform()))
+	}
+
+	if got := NewSecretUsages("apprepo-repo").InUse(); got != nil {
+		t.Errorf("got: %+v, want: nil", got)
+	}
+}
+
+func TestSecretsInUseError(t *testing.T) {
+	inUse := []*corev1.PackageRepositorySecretUsage{
+		{
+			SecretName: "apprepo-repo",
+			ReferencingResources: []*corev1.ResourceRef{
+				{ApiVersion: "kubeapps.com/v1alpha1", Kind: "AppRepository", Name: "other-repo", Namespace: "default"},
+			},
+		},
+	}
+
+	err := SecretsInUseError("repo", inUse)
+	if got, want := connect.CodeOf(err), connect.CodeFailedPrecondition; got != want {
+		t.Fatalf("got: %+v, want: %+v", got, want)
+	}
+	connectErr, ok := err.(*connect.Error)
+	if !ok {
+		t.Fatalf("got: %T, want: *connect.Error", err)
+	}
+	if got, want := len(connectErr.Details()), 1; got != want {
+		t.Fatalf("got: %d, want: %d", got, want)
+	}
+	detail, err := connectErr.Details()[0].Value()
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if got, want := detail, inUse[0]; !cmp.Equal(want, got, protocmp.Transform()) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, protocmp.Transform()))
+	}
+}
+
+func TestRemoveOwnerReference(t *testing.T) {
+	secret := &k8scorev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "apprepo-repo",
+			OwnerReferences: []metav1.OwnerReference{
+				{Kind: "AppRepository", Name: "repo", UID: "repo-uid"},
+				{Kind: "ConfigMap", Name: "other", UID: "other-uid"},
+			},
+		},
+	}
+
+	if got, want := RemoveOwnerReference(secret, "unknown-uid"), false; got != want {
+		t.Errorf("got: %t, want: %t", got, want)
+	}
+	if got, want := RemoveOwnerReference(secret, "repo-uid"), true; got != want {
+		t.Errorf("got: %t, want: %t", got, want)
+	}
+	expected := []metav1.OwnerReference{{Kind: "ConfigMap", Name: "other", UID: "other-uid"}}
+	if got, want := secret.GetOwnerReferences(), expected; !cmp.Equal(want, got) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package pkgutils
+
+import (
+	"context"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/bufbuild/connect-go"
+	corev1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+)
+
+const (
+	// MaxTextAssetSize is the size, in bytes, above which the readme and the
+	// default values of an available package detail are truncated.
+	MaxTextAssetSize = 512 * 1024
+	// MaxTextAssetRangeLimit is the maximum size, in bytes, of a range of a text
+	// asset returned by GetAvailablePackageTextAsset.
+	MaxTextAssetRangeLimit = 256 * 1024
+)
+
+// truncatedTextAssetMarkers are appended to the text assets once truncated,
+// using the syntax (markdown or YAML) of each of them.
+var truncatedTextAssetMarkers = map[corev1.TextAsset]string{
+	corev1.TextAsset_TEXT_ASSET_README:         "\n\n*... (truncated)*\n",
+	corev1.TextAsset_TEXT_ASSET_DEFAULT_VALUES: "\n# ... (truncated)\n",
+}
+
+// GetAvailablePackageTextAsset returns the requested range of a text asset of an
+// available package, retrieving the package with the given getAvailablePackageDetail
+// function (usually the plugin's GetAvailablePackageDetail) on behalf of the same user.
+func GetAvailablePackageTextAsset(ctx context.Context, request *connect.Request[corev1.GetAvailablePackageTextAssetRequest], getAvailablePackageDetail func(context.Context, *connect.Request[corev1.GetAvailablePackageDetailRequest]) (*connect.Response[corev1.GetAvailablePackageDetailResponse], error)) (*connect.Response[corev1.GetAvailablePackageTextAssetResponse], error) {
+	detailRequest := connect.NewRequest(&corev1.GetAvailablePackageDetailRequest{
+		AvailablePackageRef: request.Msg.GetAvailablePackageRef(),
+		PkgVersion:          request.Msg.GetPkgVersion(),
+	})
+	for key, values := range request.Header() {
+		detailRequest.Header()[key] = values
+	}
+	detailResponse, err := getAvailablePackageDetail(ctx, detailRequest)
+	if err != nil {
+		return nil, err
+	}
+	content, err := TextAssetContent(detailResponse.Msg.GetAvailablePackageDetail(), request.Msg.GetAsset())
+	if err != nil {
+		return nil, err
+	}
+	response, err := TextAssetRange(content, request.Msg.GetOffset(), request.Msg.GetLimit())
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(response), nil
+}
+
+// TextAssetContent returns the content of the given text asset of an available package.
+func TextAssetContent(detail *corev1.AvailablePackageDetail, asset corev1.TextAsset) (string, error) {
+	switch asset {
+	case corev1.TextAsset_TEXT_ASSET_README:
+		return detail.GetReadme(), nil
+	case corev1.TextAsset_TEXT_ASSET_DEFAULT_VALUES:
+		return detail.GetDefaultValues(), nil
+	case corev1.TextAsset_TEXT_ASSET_VALUES_SCHEMA:
+		return detail.GetValuesSchema(), nil
+	default:
+		return "", connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Unsupported text asset: %v", asset))
+	}
+}
+
+// TextAssetRange returns the range of the content starting at offset and with, at most,
+// limit bytes. Both ends of the range are moved back to the start of a character, if
+// needed, so that a multi-byte character is never split.
+func TextAssetRange(content string, offset, limit int64) (*corev1.GetAvailablePackageTextAssetResponse, error) {
+	totalSize := int64(len(content))
+	if offset < 0 || offset > totalSize {
+		return nil, connect.NewError(connect.CodeOutOfRange, fmt.Errorf("Offset %d is out of the range of the text asset (%d bytes)", offset, totalSize))
+	}
+	if limit <= 0 || limit > MaxTextAssetRangeLimit {
+		limit = MaxTextAssetRangeLimit
+	}
+
+	for offset > 0 && offset < totalSize && !utf8.RuneStart(content[offset]) {
+		offset--
+	}
+	end := offset + limit
+	if end >= totalSize {
+		return &corev1.GetAvailablePackageTextAssetResponse{
+			Content:   content[offset:],
+			Offset:    offset,
+			TotalSize: totalSize,
+		}, nil
+	}
+	for end > offset && !utf8.RuneStart(content[end]) {
+		end--
+	}
+	if end == offset {
+		// The limit is smaller than the character at the offset, return it anyway
+		// so that the next range does not start at the same offset.
+		_, size := utf8.DecodeRuneInString(content[offset:])
+		end = offset + int64(size)
+	}
+	return &corev1.GetAvailablePackageTextAssetResponse{
+		Content:    content[offset:end],
+		Offset:     offset,
+		NextOffset: end,
+		TotalSize:  totalSize,
+	}, nil
+}
+
+// TruncateTextAssets truncates the readme and the default values of the available
+// package detail to maxSize bytes, appending a truncation marker and reporting them
+// as truncated. The values schema is never truncated, since it is required as a
+// whole to validate the values.
+func TruncateTextAssets(detail *corev1.AvailablePackageDetail, maxSize int) {
+	if detail == nil {
+		return
+	}
+	truncate := func(content string, asset corev1.TextAsset) string {
+		if len(content) <= maxSize {
+			return content
+		}
+		detail.TruncatedTextAssets = append(detail.TruncatedTextAssets, &corev1.TruncatedTextAsset{
+			Asset:     asset,
+			TotalSize: int64(len(content)),
+		})
+		end := maxSize
+		for end > 0 && !utf8.RuneStart(content[end]) {
+			end--
+		}
+		return content[:end] + truncatedTextAssetMarkers[asset]
+	}
+	detail.Readme = truncate(detail.Readme, corev1.TextAsset_TEXT_ASSET_README)
+	detail.DefaultValues = truncate(detail.DefaultValues, corev1.TextAsset_TEXT_ASSET_DEFAULT_VALUES)
+}
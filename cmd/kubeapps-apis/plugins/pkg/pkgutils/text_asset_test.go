@@ -0,0 +1,198 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package pkgutils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+)
+
+func TestTextAssetRange(t *testing.T) {
+	// "ñ" is a two-byte character, at offsets 3 and 4.
+	const content = "abcñdef"
+
+	testCases := []struct {
+		name              string
+		content           string
+		offset            int64
+		limit             int64
+		expectedResponse  *corev1.GetAvailablePackageTextAssetResponse
+		expectedErrorCode connect.Code
+	}{
+		{
+			name:    "it returns the whole content when it fits within the limit",
+			content: content,
+			limit:   100,
+			expectedResponse: &corev1.GetAvailablePackageTextAssetResponse{
+				Content:   content,
+				TotalSize: 8,
+			},
+		},
+		{
+			name:    "it returns the first range and the offset of the next one",
+			content: content,
+			limit:   3,
+			expectedResponse: &corev1.GetAvailablePackageTextAssetResponse{
+				Content:    "abc",
+				NextOffset: 3,
+				TotalSize:  8,
+			},
+		},
+		{
+			name:    "it does not split a multi-byte character at the end of the range",
+			content: content,
+			limit:   4,
+			expectedResponse: &corev1.GetAvailablePackageTextAssetResponse{
+				Content:    "abc",
+				NextOffset: 3,
+				TotalSize:  8,
+			},
+		},
+		{
+			name:    "it does not split a multi-byte character at the start of the range",
+			content: content,
+			offset:  4,
+			limit:   3,
+			expectedResponse: &corev1.GetAvailablePackageTextAssetResponse{
+				Content:    "ñd",
+				Offset:     3,
+				NextOffset: 6,
+				TotalSize:  8,
+			},
+		},
+		{
+			name:    "it returns a whole character even if the limit is smaller",
+			content: content,
+			offset:  3,
+			limit:   1,
+			expectedResponse: &corev1.GetAvailablePackageTextAssetResponse{
+				Content:    "ñ",
+				Offset:     3,
+				NextOffset: 5,
+				TotalSize:  8,
+			},
+		},
+		{
+			name:    "it returns the last range",
+			content: content,
+			offset:  5,
+			limit:   3,
+			expectedResponse: &corev1.GetAvailablePackageTextAssetResponse{
+				Content:   "def",
+				Offset:    5,
+				TotalSize: 8,
+			},
+		},
+		{
+			name:    "it uses the maximum limit when none is requested",
+			content: strings.Repeat("a", MaxTextAssetRangeLimit+1),
+			expectedResponse: &corev1.GetAvailablePackageTextAssetResponse{
+				Content:    strings.Repeat("a", MaxTextAssetRangeLimit),
+				NextOffset: MaxTextAssetRangeLimit,
+				TotalSize:  MaxTextAssetRangeLimit + 1,
+			},
+		},
+		{
+			name:              "it returns an error for an offset out of range",
+			content:           content,
+			offset:            9,
+			expectedErrorCode: connect.CodeOutOfRange,
+		},
+		{
+			name:              "it returns an error for a negative offset",
+			content:           content,
+			offset:            -1,
+			expectedErrorCode: connect.CodeOutOfRange,
+		},
+	}
+
+	ignoreUnexported := cmpopts.IgnoreUnexported(corev1.GetAvailablePackageTextAssetResponse{})
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			response, err := TextAssetRange(tc.content, tc.offset, tc.limit)
+
+			if got, want := connect.CodeOf(err), tc.expectedErrorCode; err != nil && got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+			if tc.expectedErrorCode != 0 {
+				return
+			}
+
+			if got, want := response, tc.expectedResponse; !cmp.Equal(want, got, ignoreUnexported) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexported))
+			}
+		})
+	}
+}
+
+func TestTruncateTextAssets(t *testing.T) {
+	testCases := []struct {
+		name           string
+		detail         *corev1.AvailablePackageDetail
+		maxSize        int
+		expectedDetail *corev1.AvailablePackageDetail
+	}{
+		{
+			name: "it does not truncate text assets within the limit",
+			detail: &corev1.AvailablePackageDetail{
+				Readme:        "readme",
+				DefaultValues: "foo: bar",
+			},
+			maxSize: 10,
+			expectedDetail: &corev1.AvailablePackageDetail{
+				Readme:        "readme",
+				DefaultValues: "foo: bar",
+			},
+		},
+		{
+			name: "it truncates the readme and the default values",
+			detail: &corev1.AvailablePackageDetail{
+				Readme:        "a long readme",
+				DefaultValues: "foo: bar\nbar: foo",
+				ValuesSchema:  `{"type": "object"}`,
+			},
+			maxSize: 10,
+			expectedDetail: &corev1.AvailablePackageDetail{
+				Readme:        "a long rea\n\n*... (truncated)*\n",
+				DefaultValues: "foo: bar\nb\n# ... (truncated)\n",
+				ValuesSchema:  `{"type": "object"}`,
+				TruncatedTextAssets: []*corev1.TruncatedTextAsset{
+					{Asset: corev1.TextAsset_TEXT_ASSET_README, TotalSize: 13},
+					{Asset: corev1.TextAsset_TEXT_ASSET_DEFAULT_VALUES, TotalSize: 17},
+				},
+			},
+		},
+		{
+			name: "it does not split a multi-byte character",
+			detail: &corev1.AvailablePackageDetail{
+				Readme: "readmeñ readme",
+			},
+			maxSize: 7,
+			expectedDetail: &corev1.AvailablePackageDetail{
+				Readme: "readme\n\n*... (truncated)*\n",
+				TruncatedTextAssets: []*corev1.TruncatedTextAsset{
+					{Asset: corev1.TextAsset_TEXT_ASSET_README, TotalSize: 15},
+				},
+			},
+		},
+	}
+
+	ignoreUnexported := cmpopts.IgnoreUnexported(corev1.AvailablePackageDetail{}, corev1.TruncatedTextAsset{})
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			TruncateTextAssets(tc.detail, tc.maxSize)
+
+			if got, want := tc.detail, tc.expectedDetail; !cmp.Equal(want, got, ignoreUnexported) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexported))
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package pkgutils
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/bufbuild/connect-go"
+	corev1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+)
+
+var (
+	// breakingChangeKinds are the kinds of the artifacthub.io/changes entries
+	// which are flagged as breaking.
+	// ref https://artifacthub.io/docs/topics/annotations/helm/#supported-annotations
+	breakingChangeKinds = map[string]bool{
+		"changed": true,
+		"removed": true,
+	}
+
+	// breakingReleaseNotesRegex matches the lines of free-form release notes,
+	// such as the ones of Carvel packages, mentioning breaking changes.
+	breakingReleaseNotesRegex = regexp.MustCompile(`(?i)\b(breaking|incompatible)\b`)
+)
+
+// SetUpgradeImpacts classifies the upgrade impact of each version relative to
+// relativeToVersion, flagging the breaking changes found in the changelogs of
+// the versions being upgraded through. The changelogs should include every
+// version of the package, not only the summarized ones. Nothing is set when
+// relativeToVersion is empty.
+func SetUpgradeImpacts(versions []*corev1.PackageAppVersion, changelogs []*corev1.PackageVersionChangelog, relativeToVersion string) error {
+	if relativeToVersion == "" {
+		return nil
+	}
+	from, err := semver.NewVersion(relativeToVersion)
+	if err != nil {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Invalid relative to version %q: %w", relativeToVersion, err))
+	}
+
+	// The changelogs of the versions after the relative one, from the most recent.
+	newerChangelogs, err := FilterChangelogs(changelogs, relativeToVersion, "")
+	if err != nil {
+		return err
+	}
+
+	for _, v := range versions {
+		v.UpgradeImpact = upgradeImpact(from, v.GetPkgVersion(), newerChangelogs)
+	}
+	return nil
+}
+
+func upgradeImpact(from *semver.Version, pkgVersion string, newerChangelogs []*corev1.PackageVersionChangelog) *corev1.UpgradeImpact {
+	impact := &corev1.UpgradeImpact{}
+	to, err := semver.NewVersion(pkgVersion)
+	if err != nil {
+		return impact
+	}
+
+	switch {
+	case to.Equal(from):
+		impact.Type = corev1.UpgradeImpact_UPGRADE_TYPE_NONE
+		return impact
+	case to.LessThan(from):
+		impact.Type = corev1.UpgradeImpact_UPGRADE_TYPE_DOWNGRADE
+		return impact
+	case to.Major() != from.Major():
+		impact.Type = corev1.UpgradeImpact_UPGRADE_TYPE_MAJOR
+	case to.Minor() != from.Minor():
+		impact.Type = corev1.UpgradeImpact_UPGRADE_TYPE_MINOR
+	default:
+		impact.Type = corev1.UpgradeImpact_UPGRADE_TYPE_PATCH
+	}
+
+	for _, changelog := range newerChangelogs {
+		// FilterChangelogs only returns changelogs of valid semver versions.
+		version := semver.MustParse(changelog.GetPkgVersion())
+		if version.GreaterThan(to) {
+			continue
+		}
+		impact.BreakingChanges = append(impact.BreakingChanges, breakingChanges(changelog)...)
+	}
+	impact.Breaking = len(impact.BreakingChanges) > 0
+	return impact
+}
+
+// breakingChanges returns the description of the changes of the changelog
+// flagged as breaking, prefixed with the version.
+func breakingChanges(changelog *corev1.PackageVersionChangelog) []string {
+	var result []string
+	for _, change := range changelog.GetChanges() {
+		if breakingChangeKinds[strings.ToLower(change.GetKind())] {
+			result = append(result, fmt.Sprintf("%s: %s", changelog.GetPkgVersion(), change.GetDescription()))
+		}
+	}
+	for _, line := range strings.Split(changelog.GetReleaseNotes(), "\n") {
+		if breakingReleaseNotesRegex.MatchString(line) {
+			result = append(result, fmt.Sprintf("%s: %s", changelog.GetPkgVersion(), strings.TrimLeft(strings.TrimSpace(line), "-*# ")))
+		}
+	}
+	return result
+}
@@ -0,0 +1,98 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package pkgutils
+
+import (
+	"testing"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+)
+
+func TestSetUpgradeImpacts(t *testing.T) {
+	changelogs := []*corev1.PackageVersionChangelog{
+		{PkgVersion: "1.0.0", Changes: []*corev1.PackageChange{{Kind: "removed", Description: "Removed the legacy ingress"}}},
+		{PkgVersion: "1.0.1", Changes: []*corev1.PackageChange{{Kind: "fixed", Description: "Fixed the probes"}}},
+		{PkgVersion: "1.1.0", Changes: []*corev1.PackageChange{{Kind: "Changed", Description: "Renamed the service port"}}},
+		{PkgVersion: "2.0.0", ReleaseNotes: "## Highlights\n- New dashboard\n- BREAKING: the config format is incompatible with 1.x"},
+	}
+
+	testCases := []struct {
+		name              string
+		relativeToVersion string
+		versions          []string
+		expectedImpacts   []*corev1.UpgradeImpact
+		expectedErrorCode connect.Code
+	}{
+		{
+			name:     "it sets no impact without a relative version",
+			versions: []string{"2.0.0"},
+			expectedImpacts: []*corev1.UpgradeImpact{
+				nil,
+			},
+		},
+		{
+			name:              "it classifies each version and flags the breaking changes upgraded through",
+			relativeToVersion: "1.0.0",
+			versions:          []string{"2.0.0", "1.1.0", "1.0.1", "1.0.0", "0.9.0", "latest"},
+			expectedImpacts: []*corev1.UpgradeImpact{
+				{
+					Type:     corev1.UpgradeImpact_UPGRADE_TYPE_MAJOR,
+					Breaking: true,
+					BreakingChanges: []string{
+						"2.0.0: BREAKING: the config format is incompatible with 1.x",
+						"1.1.0: Renamed the service port",
+					},
+				},
+				{
+					Type:            corev1.UpgradeImpact_UPGRADE_TYPE_MINOR,
+					Breaking:        true,
+					BreakingChanges: []string{"1.1.0: Renamed the service port"},
+				},
+				{Type: corev1.UpgradeImpact_UPGRADE_TYPE_PATCH},
+				{Type: corev1.UpgradeImpact_UPGRADE_TYPE_NONE},
+				{Type: corev1.UpgradeImpact_UPGRADE_TYPE_DOWNGRADE},
+				{Type: corev1.UpgradeImpact_UPGRADE_TYPE_UNSPECIFIED},
+			},
+		},
+		{
+			name:              "it returns an error for an invalid relative version",
+			relativeToVersion: "foo",
+			versions:          []string{"1.0.0"},
+			expectedErrorCode: connect.CodeInvalidArgument,
+		},
+	}
+
+	opts := cmpopts.IgnoreUnexported(corev1.UpgradeImpact{})
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			versions := make([]*corev1.PackageAppVersion, len(tc.versions))
+			for i, v := range tc.versions {
+				versions[i] = &corev1.PackageAppVersion{PkgVersion: v}
+			}
+
+			err := SetUpgradeImpacts(versions, changelogs, tc.relativeToVersion)
+
+			if got, want := connect.CodeOf(err), tc.expectedErrorCode; err != nil && got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+			if tc.expectedErrorCode != 0 {
+				if err == nil {
+					t.Fatalf("got: nil, want: error with code %+v", tc.expectedErrorCode)
+				}
+				return
+			}
+
+			impacts := make([]*corev1.UpgradeImpact, len(versions))
+			for i, v := range versions {
+				impacts[i] = v.GetUpgradeImpact()
+			}
+			if got, want := impacts, tc.expectedImpacts; !cmp.Equal(want, got, opts) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, opts))
+			}
+		})
+	}
+}
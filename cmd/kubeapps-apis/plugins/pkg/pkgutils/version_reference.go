@@ -0,0 +1,71 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package pkgutils
+
+import (
+	"fmt"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/bufbuild/connect-go"
+)
+
+// The version of a VersionReference is either an exact version, such as
+// "1.2.3", or a semver range expression, such as ">=1.2 <2" or "~1.2.3", as
+// validated by the core API for every plugin. How a range is honoured depends
+// on what the plugin installs:
+//
+//   - helm, operators and oci-catalog install a single version, so a range is
+//     resolved with ResolveVersionReference to the highest available version
+//     satisfying it when the package is installed or upgraded, and the
+//     installed package then reports that exact version.
+//   - kapp-controller and flux pass the range as is to their controllers, as
+//     the version selection constraints of the PackageInstall and the chart
+//     version of the HelmRelease respectively, so the controllers keep the
+//     package at the highest version satisfying it.
+
+// IsVersionRange returns whether the version of a VersionReference is a range
+// expression rather than an exact version.
+func IsVersionRange(version string) bool {
+	if version == "" {
+		return false
+	}
+	_, err := semver.NewVersion(version)
+	return err != nil
+}
+
+// ResolveVersionReference returns the version, among the available ones,
+// which a VersionReference designates: the version itself when exact, or the
+// highest version satisfying the range, ignoring the versions which are not
+// semver. A NotFound error is returned if no available version matches, and an
+// InvalidArgument error if the version is neither a version nor a range.
+func ResolveVersionReference(version string, available []string, identifier string) (string, error) {
+	if !IsVersionRange(version) {
+		for _, v := range available {
+			if v == version {
+				return version, nil
+			}
+		}
+		return "", connect.NewError(connect.CodeNotFound, fmt.Errorf("Unable to find the version %q of the package %q", version, identifier))
+	}
+
+	constraint, err := semver.NewConstraint(version)
+	if err != nil {
+		return "", connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Invalid version %q, expected a semver version or range expression: %w", version, err))
+	}
+	var highest *semver.Version
+	resolved := ""
+	for _, v := range available {
+		sv, err := semver.NewVersion(v)
+		if err != nil || !constraint.Check(sv) {
+			continue
+		}
+		if highest == nil || sv.GreaterThan(highest) {
+			highest, resolved = sv, v
+		}
+	}
+	if resolved == "" {
+		return "", connect.NewError(connect.CodeNotFound, fmt.Errorf("Unable to find a version of the package %q satisfying %q", identifier, version))
+	}
+	return resolved, nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package pkgutils
+
+import (
+	"testing"
+
+	"github.com/bufbuild/connect-go"
+)
+
+func TestIsVersionRange(t *testing.T) {
+	testCases := []struct {
+		version  string
+		expected bool
+	}{
+		{version: "", expected: false},
+		{version: "1.2.3", expected: false},
+		{version: "v1.2.3", expected: false},
+		{version: "1.2.3-rc.1", expected: false},
+		{version: ">=1.2 <2", expected: true},
+		{version: "~1.2.3", expected: true},
+		{version: "^1.2", expected: true},
+		{version: "1.2.x", expected: true},
+		{version: "*", expected: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.version, func(t *testing.T) {
+			if got, want := IsVersionRange(tc.version), tc.expected; got != want {
+				t.Errorf("got: %t, want: %t", got, want)
+			}
+		})
+	}
+}
+
+func TestResolveVersionReference(t *testing.T) {
+	available := []string{"2.0.0", "1.3.0-rc.1", "1.2.10", "1.2.3", "1.10.0", "latest"}
+
+	testCases := []struct {
+		name              string
+		version           string
+		expectedVersion   string
+		expectedErrorCode connect.Code
+	}{
+		{
+			name:            "it returns an exact version which is available",
+			version:         "1.2.3",
+			expectedVersion: "1.2.3",
+		},
+		{
+			name:              "it returns not found for an exact version which is not available",
+			version:           "1.2.4",
+			expectedErrorCode: connect.CodeNotFound,
+		},
+		{
+			name:            "it resolves a range to the highest version satisfying it",
+			version:         ">=1.2 <2",
+			expectedVersion: "1.10.0",
+		},
+		{
+			name:            "it resolves a tilde range",
+			version:         "~1.2.3",
+			expectedVersion: "1.2.10",
+		},
+		{
+			name:            "it resolves a wildcard range",
+			version:         "*",
+			expectedVersion: "2.0.0",
+		},
+		{
+			name:              "it returns not found when no version satisfies the range",
+			version:           ">=3",
+			expectedErrorCode: connect.CodeNotFound,
+		},
+		{
+			name:              "it returns invalid argument for an invalid range",
+			version:           ">=foo",
+			expectedErrorCode: connect.CodeInvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			version, err := ResolveVersionReference(tc.version, available, "bitnami/apache")
+			if got, want := connect.CodeOf(err), tc.expectedErrorCode; err != nil && got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			} else if err == nil && tc.expectedErrorCode != 0 {
+				t.Fatalf("got: nil, want: %+v", tc.expectedErrorCode)
+			}
+			if got, want := version, tc.expectedVersion; got != want {
+				t.Errorf("got: %q, want: %q", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+)
+
+// accessReviewCacheMaxEntries bounds the number of access reviews cached, the
+// expired ones being evicted when reached.
+const accessReviewCacheMaxEntries = 10000
+
+// mutatingVerbs are the verbs whose access reviews are never cached, so that
+// a mutating operation is never allowed or denied on a stale permission.
+var mutatingVerbs = map[string]bool{
+	"create":           true,
+	"update":           true,
+	"patch":            true,
+	"delete":           true,
+	"deletecollection": true,
+	"escalate":         true,
+	"bind":             true,
+	"impersonate":      true,
+}
+
+// IsMutatingVerb returns whether the verb modifies the resources.
+func IsMutatingVerb(verb string) bool {
+	return mutatingVerbs[strings.ToLower(verb)]
+}
+
+// AccessReviewKey identifies an access review of a user.
+type AccessReviewKey struct {
+	// User identifies the credentials of the user, see AccessReviewUser.
+	User      string
+	Cluster   string
+	Namespace string
+	Group     string
+	Resource  string
+	Verb      string
+}
+
+type accessReviewCacheEntry struct {
+	allowed bool
+	expires time.Time
+}
+
+// AccessReviewCache caches the outcome of the access reviews of each user for
+// a TTL, as the pages listing resources check many permissions per request.
+// The access reviews of mutating verbs bypass the cache.
+type AccessReviewCache struct {
+	ttl     time.Duration
+	mutex   sync.Mutex
+	entries map[AccessReviewKey]accessReviewCacheEntry
+	// now is a field so that it can be switched in tests.
+	now func() time.Time
+}
+
+// NewAccessReviewCache returns a cache keeping the access reviews for the TTL,
+// nothing being cached if not positive.
+func NewAccessReviewCache(ttl time.Duration) *AccessReviewCache {
+	return &AccessReviewCache{
+		ttl:     ttl,
+		entries: map[AccessReviewKey]accessReviewCacheEntry{},
+		now:     time.Now,
+	}
+}
+
+// impersonateExtraPrefix is the prefix of the headers impersonating the extra
+// fields of a user.
+const impersonateExtraPrefix = "Impersonate-Extra-"
+
+// AccessReviewUser returns the identity of the user, for the access review
+// cache, from the credentials of the request and the user, groups and extra
+// fields they impersonate, which are hashed rather than kept in memory. An
+// empty identity is returned without credentials.
+func AccessReviewUser(headers http.Header) string {
+	identity := map[string][]string{}
+	for name, values := range headers {
+		name = http.CanonicalHeaderKey(name)
+		if name == "Authorization" || name == "Impersonate-User" || name == "Impersonate-Group" ||
+			strings.HasPrefix(name, impersonateExtraPrefix) {
+			identity[name] = append(identity[name], values...)
+		}
+	}
+	if len(identity["Authorization"]) == 0 || identity["Authorization"][0] == "" {
+		return ""
+	}
+	names := make([]string, 0, len(identity))
+	for name := range identity {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// Each header and value is written with its length, so that distinct
+	// headers never hash the same.
+	hash := sha256.New()
+	for _, name := range names {
+		values := identity[name]
+		fmt.Fprintf(hash, "%d:%s%d:", len(name), name, len(values))
+		for _, value := range values {
+			fmt.Fprintf(hash, "%d:%s", len(value), value)
+		}
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// CanI returns whether the user of the client is allowed to perform the verb
+// on the resource in the namespace, reusing the outcome of a previous access
+// review of the same user within the TTL unless the verb is mutating.
+func (c *AccessReviewCache) CanI(ctx context.Context, client kubernetes.Interface, key AccessReviewKey) (bool, error) {
+	cacheable := c != nil && c.ttl > 0 && key.User != "" && !IsMutatingVerb(key.Verb)
+	if cacheable {
+		if allowed, ok := c.get(key); ok {
+			return allowed, nil
+		}
+	}
+
+	allowed, err := CanI(ctx, client, schema.GroupResource{Group: key.Group, Resource: key.Resource}, key.Verb, key.Namespace)
+	if err != nil {
+		return false, err
+	}
+	if cacheable {
+		c.set(key, allowed)
+	}
+	return allowed, nil
+}
+
+func (c *AccessReviewCache) get(key AccessReviewKey) (bool, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	entry, ok := c.entries[key]
+	if !ok {
+		return false, false
+	}
+	if !c.now().Before(entry.expires) {
+		delete(c.entries, key)
+		return false, false
+	}
+	return entry.allowed, true
+}
+
+func (c *AccessReviewCache) set(key AccessReviewKey, allowed bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	now := c.now()
+	if len(c.entries) >= accessReviewCacheMaxEntries {
+		for k, entry := range c.entries {
+			if !now.Before(entry.expires) {
+				delete(c.entries, k)
+			}
+		}
+		if len(c.entries) >= accessReviewCacheMaxEntries {
+			c.entries = map[AccessReviewKey]accessReviewCacheEntry{}
+		}
+	}
+	c.entries[key] = accessReviewCacheEntry{
+		allowed: allowed,
+		expires: now.Add(c.ttl),
+	}
+}
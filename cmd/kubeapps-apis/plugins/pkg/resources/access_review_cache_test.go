@@ -0,0 +1,198 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	typfake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestAccessReviewCache(t *testing.T) {
+	testCases := []struct {
+		name string
+		ttl  time.Duration
+		// elapsed is the time between the two access reviews
+		elapsed          time.Duration
+		firstKey         AccessReviewKey
+		secondKey        AccessReviewKey
+		expectedReviewed int
+	}{
+		{
+			name:             "caches the access review of the same user",
+			ttl:              30 * time.Second,
+			elapsed:          10 * time.Second,
+			firstKey:         AccessReviewKey{User: "user-1", Cluster: "default", Namespace: "kubeapps", Resource: "secrets", Verb: "get"},
+			secondKey:        AccessReviewKey{User: "user-1", Cluster: "default", Namespace: "kubeapps", Resource: "secrets", Verb: "get"},
+			expectedReviewed: 1,
+		},
+		{
+			name:             "reviews again once expired",
+			ttl:              30 * time.Second,
+			elapsed:          30 * time.Second,
+			firstKey:         AccessReviewKey{User: "user-1", Cluster: "default", Namespace: "kubeapps", Resource: "secrets", Verb: "get"},
+			secondKey:        AccessReviewKey{User: "user-1", Cluster: "default", Namespace: "kubeapps", Resource: "secrets", Verb: "get"},
+			expectedReviewed: 2,
+		},
+		{
+			name:             "reviews the access of other users",
+			ttl:              30 * time.Second,
+			firstKey:         AccessReviewKey{User: "user-1", Cluster: "default", Namespace: "kubeapps", Resource: "secrets", Verb: "get"},
+			secondKey:        AccessReviewKey{User: "user-2", Cluster: "default", Namespace: "kubeapps", Resource: "secrets", Verb: "get"},
+			expectedReviewed: 2,
+		},
+		{
+			name:             "reviews the access to other namespaces",
+			ttl:              30 * time.Second,
+			firstKey:         AccessReviewKey{User: "user-1", Cluster: "default", Namespace: "kubeapps", Resource: "secrets", Verb: "get"},
+			secondKey:        AccessReviewKey{User: "user-1", Cluster: "default", Namespace: "other", Resource: "secrets", Verb: "get"},
+			expectedReviewed: 2,
+		},
+		{
+			name:             "bypasses the cache for mutating verbs",
+			ttl:              30 * time.Second,
+			firstKey:         AccessReviewKey{User: "user-1", Cluster: "default", Namespace: "kubeapps", Resource: "secrets", Verb: "Delete"},
+			secondKey:        AccessReviewKey{User: "user-1", Cluster: "default", Namespace: "kubeapps", Resource: "secrets", Verb: "Delete"},
+			expectedReviewed: 2,
+		},
+		{
+			name:             "bypasses the cache without user",
+			ttl:              30 * time.Second,
+			firstKey:         AccessReviewKey{Cluster: "default", Namespace: "kubeapps", Resource: "secrets", Verb: "get"},
+			secondKey:        AccessReviewKey{Cluster: "default", Namespace: "kubeapps", Resource: "secrets", Verb: "get"},
+			expectedReviewed: 2,
+		},
+		{
+			name:             "disabled without TTL",
+			firstKey:         AccessReviewKey{User: "user-1", Cluster: "default", Namespace: "kubeapps", Resource: "secrets", Verb: "get"},
+			secondKey:        AccessReviewKey{User: "user-1", Cluster: "default", Namespace: "kubeapps", Resource: "secrets", Verb: "get"},
+			expectedReviewed: 2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			reviewed := 0
+			client := typfake.NewSimpleClientset()
+			client.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (handled bool, ret k8sruntime.Object, err error) {
+				reviewed++
+				return true, &authorizationv1.SelfSubjectAccessReview{Status: authorizationv1.SubjectAccessReviewStatus{Allowed: true}}, nil
+			})
+
+			now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+			cache := NewAccessReviewCache(tc.ttl)
+			cache.now = func() time.Time { return now }
+
+			for i, key := range []AccessReviewKey{tc.firstKey, tc.secondKey} {
+				if i > 0 {
+					now = now.Add(tc.elapsed)
+				}
+				allowed, err := cache.CanI(context.Background(), client, key)
+				if err != nil {
+					t.Fatalf("%+v", err)
+				}
+				if !allowed {
+					t.Errorf("got: not allowed, want: allowed")
+				}
+			}
+
+			if got, want := reviewed, tc.expectedReviewed; got != want {
+				t.Errorf("got: %d, want: %d", got, want)
+			}
+		})
+	}
+}
+
+func TestAccessReviewUser(t *testing.T) {
+	if got := AccessReviewUser(http.Header{}); got != "" {
+		t.Errorf("got: %q, want: empty user without credentials", got)
+	}
+
+	user1 := AccessReviewUser(http.Header{"Authorization": []string{"Bearer token-1"}})
+	user2 := AccessReviewUser(http.Header{"Authorization": []string{"Bearer token-2"}})
+	if user1 == "" || user1 == user2 {
+		t.Errorf("got: %q and %q, want: distinct users", user1, user2)
+	}
+	if got, want := AccessReviewUser(http.Header{"Authorization": []string{"Bearer token-1"}}), user1; got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+}
+
+func TestAccessReviewUserImpersonation(t *testing.T) {
+	authorization := []string{"Bearer token-1"}
+	testCases := []struct {
+		name    string
+		headers http.Header
+	}{
+		{
+			name:    "impersonating a user",
+			headers: http.Header{"Authorization": authorization, "Impersonate-User": []string{"user-1"}},
+		},
+		{
+			name:    "impersonating another user",
+			headers: http.Header{"Authorization": authorization, "Impersonate-User": []string{"user-2"}},
+		},
+		{
+			name: "impersonating a group",
+			headers: http.Header{
+				"Authorization":     authorization,
+				"Impersonate-User":  []string{"user-1"},
+				"Impersonate-Group": []string{"group-1"},
+			},
+		},
+		{
+			name: "impersonating groups",
+			headers: http.Header{
+				"Authorization":     authorization,
+				"Impersonate-User":  []string{"user-1"},
+				"Impersonate-Group": []string{"group-1", "group-2"},
+			},
+		},
+		{
+			name: "impersonating an extra field",
+			headers: http.Header{
+				"Authorization":            authorization,
+				"Impersonate-User":         []string{"user-1"},
+				"Impersonate-Extra-Scopes": []string{"view"},
+			},
+		},
+		{
+			name: "impersonating another extra field",
+			headers: http.Header{
+				"Authorization":            authorization,
+				"Impersonate-User":         []string{"user-1"},
+				"Impersonate-Extra-Scopes": []string{"edit"},
+			},
+		},
+	}
+
+	users := map[string]string{
+		AccessReviewUser(http.Header{"Authorization": authorization}): "without impersonation",
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			user := AccessReviewUser(tc.headers)
+			if other, ok := users[user]; ok {
+				t.Errorf("got: the same user as %s, want: distinct users", other)
+			}
+			users[user] = tc.name
+
+			// The identity does not depend on the case of the headers.
+			lower := http.Header{}
+			for name, values := range tc.headers {
+				lower[strings.ToLower(name)] = values
+			}
+			if got, want := AccessReviewUser(lower), user; got != want {
+				t.Errorf("got: %q, want: %q", got, want)
+			}
+		})
+	}
+}
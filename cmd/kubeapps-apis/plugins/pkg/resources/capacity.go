@@ -0,0 +1,179 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/bufbuild/connect-go"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	log "k8s.io/klog/v2"
+)
+
+// CapacityCheckMode determines what happens when the namespace in which a
+// package is being installed has not enough quota left for the capacity
+// required by the package.
+type CapacityCheckMode string
+
+const (
+	// CapacityCheckOff does not check the quota of the namespace.
+	CapacityCheckOff CapacityCheckMode = "off"
+	// CapacityCheckWarn installs the package, returning a warning for each
+	// quota exceeded.
+	CapacityCheckWarn CapacityCheckMode = "warn"
+	// CapacityCheckEnforce rejects the installation of the package with a
+	// FailedPrecondition error detailing the quotas exceeded.
+	CapacityCheckEnforce CapacityCheckMode = "enforce"
+)
+
+// UnmarshalJSON reads a capacity check mode, an empty one meaning off.
+func (m *CapacityCheckMode) UnmarshalJSON(data []byte) error {
+	var mode string
+	if err := json.Unmarshal(data, &mode); err != nil {
+		return err
+	}
+	switch CapacityCheckMode(mode) {
+	case "", CapacityCheckOff:
+		*m = CapacityCheckOff
+	case CapacityCheckWarn, CapacityCheckEnforce:
+		*m = CapacityCheckMode(mode)
+	default:
+		return fmt.Errorf("unsupported capacity check mode %q, expected one of %q, %q or %q", mode, CapacityCheckOff, CapacityCheckWarn, CapacityCheckEnforce)
+	}
+	return nil
+}
+
+// capacityResourceNames maps the names used to describe the capacity required
+// by a package to the resources they are counted as.
+var capacityResourceNames = map[string]corev1.ResourceName{
+	"cpu":     corev1.ResourceCPU,
+	"cpus":    corev1.ResourceCPU,
+	"memory":  corev1.ResourceMemory,
+	"mem":     corev1.ResourceMemory,
+	"ram":     corev1.ResourceMemory,
+	"storage": corev1.ResourceStorage,
+	"disk":    corev1.ResourceStorage,
+}
+
+// quotaResourceNames are the quota resources bounding each required resource.
+var quotaResourceNames = map[corev1.ResourceName][]corev1.ResourceName{
+	corev1.ResourceCPU:     {corev1.ResourceRequestsCPU, corev1.ResourceCPU},
+	corev1.ResourceMemory:  {corev1.ResourceRequestsMemory, corev1.ResourceMemory},
+	corev1.ResourceStorage: {corev1.ResourceRequestsStorage},
+}
+
+var capacityEntrySeparator = regexp.MustCompile(`[,;\n]`)
+
+var unitlessQuantity = regexp.MustCompile(`^[0-9]+(\.[0-9]+)?$`)
+
+// ParseCapacityRequirements returns the resources described by a free-form
+// capacity requirements description, such as "cpu: 1, RAM: 2Gi, Disk: 10Gi".
+// As in the Carvel examples, a memory or storage without unit is read in
+// gibibytes. The entries which are not understood are ignored.
+func ParseCapacityRequirements(description string) corev1.ResourceList {
+	requirements := corev1.ResourceList{}
+	for _, entry := range capacityEntrySeparator.Split(description, -1) {
+		key, value, found := strings.Cut(entry, ":")
+		if !found {
+			key, value, found = strings.Cut(entry, "=")
+		}
+		if !found {
+			continue
+		}
+		name, ok := capacityResourceNames[strings.ToLower(strings.TrimSpace(key))]
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		if name != corev1.ResourceCPU && unitlessQuantity.MatchString(value) {
+			value += "Gi"
+		}
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			continue
+		}
+		requirements[name] = quantity
+	}
+	return requirements
+}
+
+// CheckNamespaceCapacity compares the capacity required by a package with the
+// quota left in the namespace in which it is being installed. Depending on the
+// mode, it returns a warning for each quota exceeded or a FailedPrecondition
+// error with a QuotaFailure detail. LimitRanges are not checked since they
+// bound single pods or containers rather than whole packages.
+func CheckNamespaceCapacity(ctx context.Context, client kubernetes.Interface, namespace string, requirements corev1.ResourceList, mode CapacityCheckMode) ([]string, error) {
+	if mode == CapacityCheckOff || mode == "" || len(requirements) == 0 {
+		return nil, nil
+	}
+
+	quotas, err := client.CoreV1().ResourceQuotas(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		if k8sErrors.IsForbidden(err) {
+			// Not checking the quota is better than refusing the
+			// installation to a user allowed to install the package.
+			log.Warningf("Unable to check the capacity of the namespace %q: %v", namespace, err)
+			return nil, nil
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to list the resource quotas of the namespace %q: %w", namespace, err))
+	}
+	sort.Slice(quotas.Items, func(i, j int) bool { return quotas.Items[i].Name < quotas.Items[j].Name })
+
+	requiredNames := []string{}
+	for name := range requirements {
+		requiredNames = append(requiredNames, string(name))
+	}
+	sort.Strings(requiredNames)
+
+	violations := []*errdetails.QuotaFailure_Violation{}
+	for _, quota := range quotas.Items {
+		for _, requiredName := range requiredNames {
+			required := requirements[corev1.ResourceName(requiredName)]
+			for _, quotaName := range quotaResourceNames[corev1.ResourceName(requiredName)] {
+				hard, ok := quota.Status.Hard[quotaName]
+				if !ok {
+					hard, ok = quota.Spec.Hard[quotaName]
+				}
+				if !ok {
+					continue
+				}
+				left := hard.DeepCopy()
+				left.Sub(quota.Status.Used[quotaName])
+				if required.Cmp(left) > 0 {
+					violations = append(violations, &errdetails.QuotaFailure_Violation{
+						Subject:     fmt.Sprintf("ResourceQuota/%s/%s", namespace, quota.Name),
+						Description: fmt.Sprintf("The package requires %s of %s but the ResourceQuota %q of the namespace %q only has %s left", required.String(), requiredName, quota.Name, namespace, left.String()),
+					})
+				}
+			}
+		}
+	}
+	if len(violations) == 0 {
+		return nil, nil
+	}
+
+	if mode == CapacityCheckWarn {
+		warnings := []string{}
+		for _, violation := range violations {
+			warnings = append(warnings, violation.Description)
+		}
+		return warnings, nil
+	}
+
+	connectErr := connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("Unable to install the package: the namespace %q has not enough quota left for the capacity it requires", namespace))
+	if detail, err := connect.NewErrorDetail(&errdetails.QuotaFailure{Violations: violations}); err == nil {
+		connectErr.AddDetail(detail)
+	}
+	return nil, connectErr
+}
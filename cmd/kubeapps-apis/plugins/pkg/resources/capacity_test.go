@@ -0,0 +1,150 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	typfake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestParseCapacityRequirements(t *testing.T) {
+	testCases := []struct {
+		name         string
+		description  string
+		expectedList corev1.ResourceList
+	}{
+		{
+			name:        "it parses the resources with their units",
+			description: "cpu: 500m, memory: 512Mi, storage: 10Gi",
+			expectedList: corev1.ResourceList{
+				corev1.ResourceCPU:     resource.MustParse("500m"),
+				corev1.ResourceMemory:  resource.MustParse("512Mi"),
+				corev1.ResourceStorage: resource.MustParse("10Gi"),
+			},
+		},
+		{
+			name:        "it reads the memory and storage without unit in gibibytes, as in the Carvel examples",
+			description: "cpu: 1,RAM: 2, Disk: 3",
+			expectedList: corev1.ResourceList{
+				corev1.ResourceCPU:     resource.MustParse("1"),
+				corev1.ResourceMemory:  resource.MustParse("2Gi"),
+				corev1.ResourceStorage: resource.MustParse("3Gi"),
+			},
+		},
+		{
+			name:         "it ignores the entries which are not understood",
+			description:  "Requires a cluster with at least 3 nodes\ngpu=1; cpu=two; mem=1Gi",
+			expectedList: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got, want := ParseCapacityRequirements(tc.description), tc.expectedList; !cmp.Equal(want, got) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
+func TestCheckNamespaceCapacity(t *testing.T) {
+	quota := &corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "compute", Namespace: "team-a"},
+		Status: corev1.ResourceQuotaStatus{
+			Hard: corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse("2"), corev1.ResourceRequestsMemory: resource.MustParse("4Gi")},
+			Used: corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse("1500m"), corev1.ResourceRequestsMemory: resource.MustParse("1Gi")},
+		},
+	}
+	requirements := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("1"),
+		corev1.ResourceMemory: resource.MustParse("2Gi"),
+	}
+
+	testCases := []struct {
+		name              string
+		existingObjects   []k8sruntime.Object
+		requirements      corev1.ResourceList
+		mode              CapacityCheckMode
+		expectedWarnings  []string
+		expectedErrorCode connect.Code
+	}{
+		{
+			name:             "it returns a warning for each quota exceeded",
+			existingObjects:  []k8sruntime.Object{quota},
+			requirements:     requirements,
+			mode:             CapacityCheckWarn,
+			expectedWarnings: []string{`The package requires 1 of cpu but the ResourceQuota "compute" of the namespace "team-a" only has 500m left`},
+		},
+		{
+			name:              "it returns a failed precondition error when enforced",
+			existingObjects:   []k8sruntime.Object{quota},
+			requirements:      requirements,
+			mode:              CapacityCheckEnforce,
+			expectedErrorCode: connect.CodeFailedPrecondition,
+		},
+		{
+			name:            "it accepts the package when the quota has enough left",
+			existingObjects: []k8sruntime.Object{quota},
+			requirements:    corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+			mode:            CapacityCheckEnforce,
+		},
+		{
+			name:         "it accepts the package in a namespace without quota",
+			requirements: requirements,
+			mode:         CapacityCheckEnforce,
+		},
+		{
+			name:            "it does not check the quota when off",
+			existingObjects: []k8sruntime.Object{quota},
+			requirements:    requirements,
+			mode:            CapacityCheckOff,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := typfake.NewSimpleClientset(tc.existingObjects...)
+
+			warnings, err := CheckNamespaceCapacity(context.Background(), client, "team-a", tc.requirements, tc.mode)
+
+			if got, want := connect.CodeOf(err), tc.expectedErrorCode; err != nil && got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+			if tc.expectedErrorCode != 0 {
+				if err == nil {
+					t.Fatalf("got: nil, want: error with code %+v", tc.expectedErrorCode)
+				}
+				return
+			}
+
+			if got, want := warnings, tc.expectedWarnings; !cmp.Equal(want, got) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
+func TestCapacityCheckModeUnmarshalJSON(t *testing.T) {
+	var config struct {
+		CapacityCheck CapacityCheckMode `json:"capacityCheck"`
+	}
+	if err := json.Unmarshal([]byte(`{"capacityCheck": "enforce"}`), &config); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if got, want := config.CapacityCheck, CapacityCheckEnforce; got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+	if err := json.Unmarshal([]byte(`{"capacityCheck": "block"}`), &config); err == nil {
+		t.Errorf("got: nil, want: error for an unsupported mode")
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bufbuild/connect-go"
+	pkgsGRPCv1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// builtinProtectedMetadataPrefixes are always protected, since the plugins
+// rely on their own annotations of the installed packages.
+var builtinProtectedMetadataPrefixes = []string{"kubeapps.dev/"}
+
+// InstalledPackageMetadataOptions configure the labels and annotations which
+// can be set on the object created to install a package, such as a
+// kapp-controller PackageInstall or a Flux HelmRelease.
+type InstalledPackageMetadataOptions struct {
+	// ProtectedPrefixes are the prefixes of the label and annotation keys which
+	// cannot be set through the API, such as "kapp.k14s.io/".
+	ProtectedPrefixes []string `json:"protectedPrefixes,omitempty"`
+}
+
+// IsProtected returns whether a label or annotation key starts with one of the
+// protected prefixes.
+func (o InstalledPackageMetadataOptions) IsProtected(key string) bool {
+	for _, prefixes := range [][]string{builtinProtectedMetadataPrefixes, o.ProtectedPrefixes} {
+		for _, prefix := range prefixes {
+			if prefix != "" && strings.HasPrefix(key, prefix) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ValidateInstalledPackageMetadata returns an InvalidArgument error if one of
+// the requested labels or annotations is not valid or is protected.
+func ValidateInstalledPackageMetadata(labels, annotations map[string]string, options InstalledPackageMetadataOptions) error {
+	problems := []string{}
+	for _, key := range sortedKeys(labels) {
+		problems = append(problems, validateMetadataKey("label", key, options)...)
+		for _, msg := range validation.IsValidLabelValue(labels[key]) {
+			problems = append(problems, fmt.Sprintf("the value of the label %q is invalid: %s", key, msg))
+		}
+	}
+	for _, key := range sortedKeys(annotations) {
+		problems = append(problems, validateMetadataKey("annotation", key, options)...)
+	}
+	if len(problems) > 0 {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Invalid installed package metadata: %s", strings.Join(problems, "; ")))
+	}
+	return nil
+}
+
+func validateMetadataKey(kind, key string, options InstalledPackageMetadataOptions) []string {
+	problems := []string{}
+	for _, msg := range validation.IsQualifiedName(key) {
+		problems = append(problems, fmt.Sprintf("the %s key %q is invalid: %s", kind, key, msg))
+	}
+	if options.IsProtected(key) {
+		problems = append(problems, fmt.Sprintf("the %s key %q is protected", kind, key))
+	}
+	return problems
+}
+
+// MergeInstalledPackageMetadata returns the requested labels or annotations,
+// replacing the existing ones except the protected ones, which are kept.
+func MergeInstalledPackageMetadata(existing, requested map[string]string, options InstalledPackageMetadataOptions) map[string]string {
+	merged := map[string]string{}
+	for key, value := range existing {
+		if options.IsProtected(key) {
+			merged[key] = value
+		}
+	}
+	for key, value := range requested {
+		merged[key] = value
+	}
+	return merged
+}
+
+// UnprotectedInstalledPackageMetadata returns the existing labels or
+// annotations without the protected ones, that is, those which can be set
+// through the API.
+func UnprotectedInstalledPackageMetadata(existing map[string]string, options InstalledPackageMetadataOptions) map[string]string {
+	unprotected := map[string]string{}
+	for key, value := range existing {
+		if !options.IsProtected(key) {
+			unprotected[key] = value
+		}
+	}
+	return unprotected
+}
+
+// InstalledPackageMetadataFromObject returns the labels and annotations of the
+// object of an installed package which can be set through the API, or nil if
+// there are none.
+func InstalledPackageMetadataFromObject(objectMeta metav1.ObjectMeta, options InstalledPackageMetadataOptions) *pkgsGRPCv1alpha1.InstalledPackageMetadata {
+	labels := UnprotectedInstalledPackageMetadata(objectMeta.Labels, options)
+	annotations := UnprotectedInstalledPackageMetadata(objectMeta.Annotations, options)
+	if len(labels) == 0 && len(annotations) == 0 {
+		return nil
+	}
+	return &pkgsGRPCv1alpha1.InstalledPackageMetadata{
+		Labels:      labels,
+		Annotations: annotations,
+	}
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
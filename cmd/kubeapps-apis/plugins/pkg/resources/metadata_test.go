@@ -0,0 +1,85 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestValidateInstalledPackageMetadata(t *testing.T) {
+	options := InstalledPackageMetadataOptions{ProtectedPrefixes: []string{"kapp.k14s.io/"}}
+
+	testCases := []struct {
+		name              string
+		labels            map[string]string
+		annotations       map[string]string
+		expectedErrorCode connect.Code
+	}{
+		{
+			name:        "it accepts valid labels and annotations",
+			labels:      map[string]string{"example.com/cost-center": "team-a"},
+			annotations: map[string]string{"owner": "Team A <team-a@example.com>"},
+		},
+		{
+			name:              "it rejects a label with a protected prefix",
+			labels:            map[string]string{"kapp.k14s.io/app": "1"},
+			expectedErrorCode: connect.CodeInvalidArgument,
+		},
+		{
+			name:              "it rejects an annotation with a builtin protected prefix",
+			annotations:       map[string]string{"kubeapps.dev/managed-by": "me"},
+			expectedErrorCode: connect.CodeInvalidArgument,
+		},
+		{
+			name:              "it rejects an invalid label value",
+			labels:            map[string]string{"owner": "Team A"},
+			expectedErrorCode: connect.CodeInvalidArgument,
+		},
+		{
+			name:              "it rejects an invalid annotation key",
+			annotations:       map[string]string{"not a key": "value"},
+			expectedErrorCode: connect.CodeInvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateInstalledPackageMetadata(tc.labels, tc.annotations, options)
+
+			if got, want := connect.CodeOf(err), tc.expectedErrorCode; err != nil && got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+			if err == nil && tc.expectedErrorCode != 0 {
+				t.Fatalf("got: nil, want: error with code %+v", tc.expectedErrorCode)
+			}
+		})
+	}
+}
+
+func TestMergeInstalledPackageMetadata(t *testing.T) {
+	options := InstalledPackageMetadataOptions{ProtectedPrefixes: []string{"packaging.carvel.dev/"}}
+	existing := map[string]string{
+		"kubeapps.dev/managed-by":           "plugin:kapp-controller",
+		"packaging.carvel.dev/downgradable": "",
+		"example.com/cost-center":           "team-a",
+	}
+
+	merged := MergeInstalledPackageMetadata(existing, map[string]string{"example.com/team": "b"}, options)
+
+	expected := map[string]string{
+		"kubeapps.dev/managed-by":           "plugin:kapp-controller",
+		"packaging.carvel.dev/downgradable": "",
+		"example.com/team":                  "b",
+	}
+	if got, want := merged, expected; !cmp.Equal(want, got) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+	}
+
+	if got, want := UnprotectedInstalledPackageMetadata(existing, options), map[string]string{"example.com/cost-center": "team-a"}; !cmp.Equal(want, got) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+	}
+}
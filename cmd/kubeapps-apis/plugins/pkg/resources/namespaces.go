@@ -15,6 +15,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/kubernetes"
 	log "k8s.io/klog/v2"
 )
@@ -148,3 +149,62 @@ func FilterActiveNamespaces(namespaces []corev1.Namespace) []corev1.Namespace {
 	}
 	return readyNamespaces
 }
+
+// NamespaceCreationOptions are the labels and annotations, such as the pod
+// security admission labels, set on a target namespace created when
+// installing a package.
+type NamespaceCreationOptions struct {
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// EnsureNamespace creates the namespace with the given labels and annotations
+// unless it already exists, returning whether it was created. As the namespace
+// is created with the client of the user, a PermissionDenied error is returned
+// if the user is not allowed to create namespaces.
+func EnsureNamespace(ctx context.Context, client kubernetes.Interface, name string, options NamespaceCreationOptions) (bool, error) {
+	_, err := client.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
+	if err == nil {
+		return false, nil
+	}
+	// A user allowed to create namespaces is not necessarily allowed to get
+	// them, in which case the creation tells whether the namespace exists.
+	if !k8sErrors.IsNotFound(err) && !k8sErrors.IsForbidden(err) {
+		return false, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to get the namespace %q: %w", name, err))
+	}
+
+	allowed, err := CanI(ctx, client, schema.GroupResource{Resource: "namespaces"}, "create", "")
+	if err != nil {
+		return false, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to check the permission to create the namespace %q: %w", name, err))
+	}
+	if !allowed {
+		return false, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("Unable to create the namespace %q: the user is not allowed to create namespaces", name))
+	}
+
+	_, err = client.CoreV1().Namespaces().Create(ctx, &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Labels:      options.Labels,
+			Annotations: options.Annotations,
+		},
+	}, metav1.CreateOptions{})
+	if k8sErrors.IsAlreadyExists(err) {
+		return false, nil
+	} else if k8sErrors.IsForbidden(err) {
+		return false, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("Unable to create the namespace %q: %w", name, err))
+	} else if err != nil {
+		return false, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to create the namespace %q: %w", name, err))
+	}
+	log.InfoS("Created the target namespace", "namespace", name)
+	return true, nil
+}
+
+// DeleteCreatedNamespace deletes the namespace created by EnsureNamespace when
+// the installation of the package in it failed, so that a failed installation
+// does not leave an empty namespace behind.
+func DeleteCreatedNamespace(ctx context.Context, client kubernetes.Interface, name string) {
+	err := client.CoreV1().Namespaces().Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil && !k8sErrors.IsNotFound(err) {
+		log.Errorf("Unable to delete the namespace %q created for a failed installation: %v", name, err)
+	}
+}
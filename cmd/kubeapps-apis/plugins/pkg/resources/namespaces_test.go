@@ -4,7 +4,9 @@
 package resources
 
 import (
+	"context"
 	"errors"
+	"github.com/bufbuild/connect-go"
 	"github.com/google/go-cmp/cmp"
 	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/clientgetter"
 	authorizationv1 "k8s.io/api/authorization/v1"
@@ -169,3 +171,107 @@ func newTypedClients(objects []k8sruntime.Object, clientReactions []*ClientReact
 			return inClusterClient, nil
 		}
 }
+
+func TestEnsureNamespace(t *testing.T) {
+	options := NamespaceCreationOptions{
+		Labels:      map[string]string{"pod-security.kubernetes.io/enforce": "restricted"},
+		Annotations: map[string]string{"owner": "team-a"},
+	}
+	allowCreate := func(allowed bool) *ClientReaction {
+		return &ClientReaction{
+			verb:     "create",
+			resource: "selfsubjectaccessreviews",
+			reaction: func(action k8stesting.Action) (handled bool, ret k8sruntime.Object, err error) {
+				return true, &authorizationv1.SelfSubjectAccessReview{
+					Status: authorizationv1.SubjectAccessReviewStatus{Allowed: allowed},
+				}, nil
+			},
+		}
+	}
+	forbidGet := &ClientReaction{
+		verb:     "get",
+		resource: "namespaces",
+		reaction: func(action k8stesting.Action) (handled bool, ret k8sruntime.Object, err error) {
+			return true, nil, k8sErrors.NewForbidden(schema.GroupResource{Resource: "namespaces"}, "team-a", errors.New("forbidden"))
+		},
+	}
+
+	testCases := []struct {
+		name              string
+		existingObjects   []k8sruntime.Object
+		reactions         []*ClientReaction
+		expectedCreated   bool
+		expectedErrorCode connect.Code
+		expectedNamespace *apiv1.Namespace
+	}{
+		{
+			name:            "it does not create an existing namespace",
+			existingObjects: []k8sruntime.Object{&apiv1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}}},
+			reactions:       []*ClientReaction{allowCreate(true)},
+			expectedCreated: false,
+			expectedNamespace: &apiv1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{Name: "team-a"},
+			},
+		},
+		{
+			name:            "it creates a missing namespace with the labels and annotations",
+			reactions:       []*ClientReaction{allowCreate(true)},
+			expectedCreated: true,
+			expectedNamespace: &apiv1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "team-a",
+					Labels:      options.Labels,
+					Annotations: options.Annotations,
+				},
+			},
+		},
+		{
+			name:              "it returns permission denied if the user cannot create namespaces",
+			reactions:         []*ClientReaction{allowCreate(false)},
+			expectedErrorCode: connect.CodePermissionDenied,
+		},
+		{
+			name:            "it does not fail if the user cannot get the existing namespace",
+			existingObjects: []k8sruntime.Object{&apiv1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}}},
+			reactions:       []*ClientReaction{allowCreate(true), forbidGet},
+			expectedCreated: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			clientGetter, _ := newTypedClients(tc.existingObjects, tc.reactions, nil)
+			client, err := clientGetter()
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+
+			created, err := EnsureNamespace(context.Background(), client, "team-a", options)
+			if got, want := connect.CodeOf(err), tc.expectedErrorCode; err != nil && got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+			if tc.expectedErrorCode != 0 {
+				if err == nil {
+					t.Fatalf("got: nil, want: %+v", tc.expectedErrorCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if got, want := created, tc.expectedCreated; got != want {
+				t.Errorf("got: %t, want: %t", got, want)
+			}
+
+			if tc.expectedNamespace != nil {
+				namespace, err := client.CoreV1().Namespaces().Get(context.Background(), "team-a", metav1.GetOptions{})
+				if err != nil {
+					t.Fatalf("%+v", err)
+				}
+				if got, want := namespace, tc.expectedNamespace; !cmp.Equal(want, got) {
+					t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+				}
+			}
+		})
+	}
+}
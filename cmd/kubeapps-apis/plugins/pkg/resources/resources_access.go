@@ -65,6 +65,64 @@ func GetPermissionsOnResource(ctx context.Context, client kubernetes.Interface,
 	return m, nil
 }
 
+// GetPermissionsOnResourceInNamespaces returns the permissions of the user of
+// the client on the resource for each of the namespaces, keyed by namespace.
+// All the access reviews are requested concurrently as a single batch rather
+// than namespace after namespace.
+func GetPermissionsOnResourceInNamespaces(ctx context.Context, client kubernetes.Interface, gr schema.GroupResource, namespaces []string) (map[string]map[string]bool, error) {
+	var wg sync.WaitGroup
+	type accessReviewResult struct {
+		namespace string
+		verb      string
+		allowed   bool
+	}
+	accessReviewChan := make(chan accessReviewResult, len(AccessVerbs)*len(namespaces))
+
+	permissions := make(map[string]map[string]bool, len(namespaces))
+	for _, namespace := range namespaces {
+		if _, ok := permissions[namespace]; ok {
+			continue
+		}
+		permissions[namespace] = make(map[string]bool)
+		for _, v := range AccessVerbs {
+			wg.Add(1)
+			go func(namespace, verb string) {
+				defer wg.Done()
+
+				response, err := doResourceAccessReview(ctx, client, gr, verb, namespace)
+				if err != nil {
+					log.Errorf("Error finding permissions for %s/%s - %s in namespace %s: %v", gr.Group, gr.Resource, verb, namespace, err)
+					return
+				}
+				accessReviewChan <- accessReviewResult{
+					namespace: namespace,
+					verb:      verb,
+					allowed:   response.Status.Allowed,
+				}
+			}(namespace, v)
+		}
+	}
+	go func() {
+		wg.Wait()
+		close(accessReviewChan)
+	}()
+
+	for r := range accessReviewChan {
+		permissions[r.namespace][r.verb] = r.allowed
+	}
+	return permissions, nil
+}
+
+// CanI returns whether the user of the client is allowed to perform the verb
+// on the resource in the namespace.
+func CanI(ctx context.Context, client kubernetes.Interface, gr schema.GroupResource, verb, namespace string) (bool, error) {
+	response, err := doResourceAccessReview(ctx, client, gr, verb, namespace)
+	if err != nil {
+		return false, err
+	}
+	return response.Status.Allowed, nil
+}
+
 func doResourceAccessReview(ctx context.Context, client kubernetes.Interface, gr schema.GroupResource, verb, namespace string) (*authorizationapi.SelfSubjectAccessReview, error) {
 	return client.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, &authorizationapi.SelfSubjectAccessReview{
 		Spec: authorizationapi.SelfSubjectAccessReviewSpec{
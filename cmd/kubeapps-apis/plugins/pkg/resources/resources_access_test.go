@@ -0,0 +1,53 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	typfake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestGetPermissionsOnResourceInNamespaces(t *testing.T) {
+	var mutex sync.Mutex
+	reviewed := map[string]int{}
+
+	client := typfake.NewSimpleClientset()
+	client.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (handled bool, ret k8sruntime.Object, err error) {
+		accessReview := action.(k8stesting.CreateActionImpl).Object.(*authorizationv1.SelfSubjectAccessReview)
+		attributes := accessReview.Spec.ResourceAttributes
+		mutex.Lock()
+		reviewed[attributes.Namespace]++
+		mutex.Unlock()
+		allowed := attributes.Namespace == "kubeapps" || attributes.Verb == "get"
+		return true, &authorizationv1.SelfSubjectAccessReview{Status: authorizationv1.SubjectAccessReviewStatus{Allowed: allowed}}, nil
+	})
+
+	gr := schema.GroupResource{Group: "packaging.carvel.dev", Resource: "packagerepositories"}
+	permissions, err := GetPermissionsOnResourceInNamespaces(context.Background(), client, gr, []string{"kubeapps", "my-ns", "kubeapps"})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	expectedPermissions := map[string]map[string]bool{
+		"kubeapps": {"create": true, "update": true, "delete": true, "get": true, "list": true, "watch": true},
+		"my-ns":    {"create": false, "update": false, "delete": false, "get": true, "list": false, "watch": false},
+	}
+	if got, want := permissions, expectedPermissions; !cmp.Equal(want, got) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+	}
+
+	// Repeated namespaces are reviewed only once.
+	expectedReviewed := map[string]int{"kubeapps": len(AccessVerbs), "my-ns": len(AccessVerbs)}
+	if got, want := reviewed, expectedReviewed; !cmp.Equal(want, got) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+	}
+}
@@ -7,10 +7,22 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
+
+	"k8s.io/client-go/util/jsonpath"
 )
 
+// DefaultAccessReviewCacheTTLSeconds is the time the outcome of the access
+// reviews of the users is cached for, unless configured otherwise.
+const DefaultAccessReviewCacheTTLSeconds = 30
+
 type ResourcesPluginConfig struct {
 	TrustedNamespaces TrustedNamespaces
+	CustomFields      []CustomField
+	// AccessReviewCacheTTLSeconds is the time the outcome of the access reviews
+	// of the users is cached for, 0 disabling the cache. The access reviews of
+	// mutating verbs are never cached.
+	AccessReviewCacheTTLSeconds int32
 }
 
 type TrustedNamespaces struct {
@@ -18,9 +30,40 @@ type TrustedNamespaces struct {
 	HeaderPattern string
 }
 
+// CustomField declares a field extracted with a JSONPath expression from the
+// resources of an installed package, to be displayed with the package.
+type CustomField struct {
+	// Name is the name of the field, such as "External URL".
+	Name string `json:"name"`
+	// APIVersion and Kind select the resources the field is extracted from
+	// when declared in the plugin configuration. Only the group of the
+	// APIVersion is matched, so that any version of the resources is selected.
+	APIVersion string `json:"apiVersion,omitempty"`
+	Kind       string `json:"kind,omitempty"`
+	// JSONPath is the expression, using the kubectl JSONPath syntax, such as
+	// "{.spec.rules[0].host}". The surrounding braces are optional.
+	JSONPath string `json:"jsonPath"`
+}
+
+// ParseJSONPath parses the JSONPath expression of a custom field, accepting
+// expressions without the surrounding braces as kubectl does. Missing keys
+// result in no output rather than in an error.
+func ParseJSONPath(name, expression string) (*jsonpath.JSONPath, error) {
+	if !strings.Contains(expression, "{") {
+		expression = fmt.Sprintf("{%s}", expression)
+	}
+	parser := jsonpath.New(name).AllowMissingKeys(true)
+	if err := parser.Parse(expression); err != nil {
+		return nil, fmt.Errorf("invalid JSONPath expression %q for the custom field %q: %w", expression, name, err)
+	}
+	return parser, nil
+}
+
 func NewDefaultPluginConfig() *ResourcesPluginConfig {
 	// If no config is provided, we default to the existing values for backwards compatibility.
-	return &ResourcesPluginConfig{}
+	return &ResourcesPluginConfig{
+		AccessReviewCacheTTLSeconds: DefaultAccessReviewCacheTTLSeconds,
+	}
 }
 
 // ParsePluginConfig parses the input plugin configuration json file and returns the configuration options.
@@ -35,6 +78,8 @@ func ParsePluginConfig(pluginConfigPath string) (*ResourcesPluginConfig, error)
 						HeaderName    string `json:"headerName"`
 						HeaderPattern string `json:"headerPattern"`
 					} `json:"trustedNamespaces"`
+					CustomFields                []CustomField `json:"customFields"`
+					AccessReviewCacheTTLSeconds *int32        `json:"accessReviewCacheTTLSeconds"`
 				} `json:"v1alpha1"`
 			} `json:"packages"`
 		} `json:"resources"`
@@ -51,11 +96,31 @@ func ParsePluginConfig(pluginConfigPath string) (*ResourcesPluginConfig, error)
 		return nil, fmt.Errorf("unable to unmarshal pluginconfig: %q error: %w", string(pluginConfig), err)
 	}
 
+	customFields := config.Resources.Packages.V1alpha1.CustomFields
+	for i, field := range customFields {
+		if field.Name == "" || field.Kind == "" || field.JSONPath == "" {
+			return nil, fmt.Errorf("the custom field %d requires a name, a kind and a jsonPath", i)
+		}
+		if _, err := ParseJSONPath(field.Name, field.JSONPath); err != nil {
+			return nil, err
+		}
+	}
+
+	accessReviewCacheTTLSeconds := int32(DefaultAccessReviewCacheTTLSeconds)
+	if ttl := config.Resources.Packages.V1alpha1.AccessReviewCacheTTLSeconds; ttl != nil {
+		if *ttl < 0 {
+			return nil, fmt.Errorf("the accessReviewCacheTTLSeconds %d must not be negative", *ttl)
+		}
+		accessReviewCacheTTLSeconds = *ttl
+	}
+
 	// return configured value
 	return &ResourcesPluginConfig{
 		TrustedNamespaces: TrustedNamespaces{
 			HeaderName:    config.Resources.Packages.V1alpha1.TrustedNamespaces.HeaderName,
 			HeaderPattern: config.Resources.Packages.V1alpha1.TrustedNamespaces.HeaderPattern,
 		},
+		CustomFields:                customFields,
+		AccessReviewCacheTTLSeconds: accessReviewCacheTTLSeconds,
 	}, nil
 }
@@ -54,9 +54,96 @@ resources:
 					HeaderName:    "X-Consumer-Groups",
 					HeaderPattern: "^namespace:([\\w-]+)$",
 				},
+				AccessReviewCacheTTLSeconds: DefaultAccessReviewCacheTTLSeconds,
 			},
 			expectedError: "",
 		},
+		{
+			name: "custom fields",
+			pluginYAMLConf: []byte(`
+resources:
+  packages:
+    v1alpha1:
+      customFields:
+        - name: "External URL"
+          apiVersion: networking.k8s.io/v1
+          kind: Ingress
+          jsonPath: "{.spec.rules[0].host}"
+        - name: "Image"
+          kind: Deployment
+          jsonPath: ".spec.template.spec.containers[*].image"
+      `),
+			expectedConfig: &ResourcesPluginConfig{
+				CustomFields: []CustomField{
+					{Name: "External URL", APIVersion: "networking.k8s.io/v1", Kind: "Ingress", JSONPath: "{.spec.rules[0].host}"},
+					{Name: "Image", Kind: "Deployment", JSONPath: ".spec.template.spec.containers[*].image"},
+				},
+				AccessReviewCacheTTLSeconds: DefaultAccessReviewCacheTTLSeconds,
+			},
+			expectedError: "",
+		},
+		{
+			name: "custom field without kind",
+			pluginYAMLConf: []byte(`
+resources:
+  packages:
+    v1alpha1:
+      customFields:
+        - name: "Image"
+          jsonPath: ".spec.template.spec.containers[*].image"
+      `),
+			expectedConfig: nil,
+			expectedError:  "requires a name, a kind and a jsonPath",
+		},
+		{
+			name: "custom field with an invalid JSONPath expression",
+			pluginYAMLConf: []byte(`
+resources:
+  packages:
+    v1alpha1:
+      customFields:
+        - name: "Image"
+          kind: Deployment
+          jsonPath: "{.spec.template.spec.containers[*.image}"
+      `),
+			expectedConfig: nil,
+			expectedError:  "invalid JSONPath expression",
+		},
+		{
+			name: "access review cache TTL",
+			pluginYAMLConf: []byte(`
+resources:
+  packages:
+    v1alpha1:
+      accessReviewCacheTTLSeconds: 60
+      `),
+			expectedConfig: &ResourcesPluginConfig{
+				AccessReviewCacheTTLSeconds: 60,
+			},
+			expectedError: "",
+		},
+		{
+			name: "access review cache disabled",
+			pluginYAMLConf: []byte(`
+resources:
+  packages:
+    v1alpha1:
+      accessReviewCacheTTLSeconds: 0
+      `),
+			expectedConfig: &ResourcesPluginConfig{},
+			expectedError:  "",
+		},
+		{
+			name: "negative access review cache TTL",
+			pluginYAMLConf: []byte(`
+resources:
+  packages:
+    v1alpha1:
+      accessReviewCacheTTLSeconds: -1
+      `),
+			expectedConfig: nil,
+			expectedError:  "must not be negative",
+		},
 	}
 	opts := cmpopts.IgnoreUnexported(pkgutils.VersionsInSummary{})
 	for _, tc := range testCases {
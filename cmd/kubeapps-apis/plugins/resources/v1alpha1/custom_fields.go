@@ -0,0 +1,135 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/bufbuild/connect-go"
+	pkgsGRPCv1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/plugins/resources/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/connecterror"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/resources/v1alpha1/common"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	log "k8s.io/klog/v2"
+)
+
+// customFieldsAnnotation is the annotation with which a resource of a package
+// declares the custom fields extracted from itself, as a JSON list of objects
+// with a name and a jsonPath.
+const customFieldsAnnotation = "kubeapps.dev/custom-fields"
+
+// GetInstalledPackageCustomFields returns the custom fields extracted with
+// JSONPath expressions from the resources of an installed package, as declared
+// in the plugin configuration or in the annotations of the resources.
+func (s *Server) GetInstalledPackageCustomFields(ctx context.Context, r *connect.Request[v1alpha1.GetInstalledPackageCustomFieldsRequest]) (*connect.Response[v1alpha1.GetInstalledPackageCustomFieldsResponse], error) {
+	namespace := r.Msg.GetInstalledPackageRef().GetContext().GetNamespace()
+	cluster := r.Msg.GetInstalledPackageRef().GetContext().GetCluster()
+	log.InfoS("+resources GetInstalledPackageCustomFields ", "cluster", cluster, "namespace", namespace)
+
+	pkgResourceRefs, err := s.getInstalledPackageResourceRefs(ctx, r.Header(), r.Msg.GetInstalledPackageRef())
+	if err != nil {
+		return nil, err
+	}
+
+	dynamicClient, err := s.clientGetter.Dynamic(r.Header(), cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := []*v1alpha1.CustomField{}
+	for _, ref := range pkgResourceRefs {
+		gvk, err := resourceRefGVK(ref)
+		if err != nil {
+			return nil, err
+		}
+		resourceClient, err := s.resourceClient(dynamicClient, gvk, ref.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		obj, err := resourceClient.Get(ctx, ref.GetName(), metav1.GetOptions{})
+		if err != nil {
+			// The resources not created yet have no fields to extract.
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return nil, connecterror.FromK8sError("get", ref.Kind, ref.GetName(), err)
+		}
+
+		fields = append(fields, extractCustomFields(ref, obj, customFieldDeclarations(s.pluginConfig.CustomFields, gvk.GroupKind(), obj))...)
+	}
+
+	return connect.NewResponse(&v1alpha1.GetInstalledPackageCustomFieldsResponse{
+		Fields: fields,
+	}), nil
+}
+
+// customFieldDeclarations returns the custom fields declared for a resource:
+// those of the plugin configuration selecting its group and kind, followed by
+// those of its annotation.
+func customFieldDeclarations(configured []common.CustomField, groupKind schema.GroupKind, obj *unstructured.Unstructured) []common.CustomField {
+	declarations := []common.CustomField{}
+	for _, field := range configured {
+		if field.Kind != groupKind.Kind {
+			continue
+		}
+		if field.APIVersion != "" {
+			groupVersion, err := schema.ParseGroupVersion(field.APIVersion)
+			if err != nil || groupVersion.Group != groupKind.Group {
+				continue
+			}
+		}
+		declarations = append(declarations, field)
+	}
+
+	annotation, ok := obj.GetAnnotations()[customFieldsAnnotation]
+	if !ok {
+		return declarations
+	}
+	var annotated []common.CustomField
+	if err := json.Unmarshal([]byte(annotation), &annotated); err != nil {
+		log.Warningf("Ignoring the invalid %s annotation of %s %q: %v", customFieldsAnnotation, obj.GetKind(), obj.GetName(), err)
+		return declarations
+	}
+	return append(declarations, annotated...)
+}
+
+// extractCustomFields evaluates the JSONPath expression of each declared field
+// over the resource, leaving out the fields which are invalid or do not match
+// anything.
+func extractCustomFields(ref *pkgsGRPCv1alpha1.ResourceRef, obj *unstructured.Unstructured, declarations []common.CustomField) []*v1alpha1.CustomField {
+	fields := []*v1alpha1.CustomField{}
+	for _, declaration := range declarations {
+		if declaration.Name == "" || declaration.JSONPath == "" {
+			log.Warningf("Ignoring a custom field of %s %q without a name or a jsonPath", ref.Kind, ref.GetName())
+			continue
+		}
+		parser, err := common.ParseJSONPath(declaration.Name, declaration.JSONPath)
+		if err != nil {
+			log.Warningf("Ignoring the custom field of %s %q: %v", ref.Kind, ref.GetName(), err)
+			continue
+		}
+		var value bytes.Buffer
+		if err := parser.Execute(&value, obj.Object); err != nil {
+			log.V(4).Infof("Unable to extract the custom field %q of %s %q: %v", declaration.Name, ref.Kind, ref.GetName(), err)
+			continue
+		}
+		trimmed := strings.TrimSpace(value.String())
+		if trimmed == "" {
+			continue
+		}
+		fields = append(fields, &v1alpha1.CustomField{
+			Name:        declaration.Name,
+			Value:       trimmed,
+			ResourceRef: ref,
+		})
+	}
+	return fields
+}
@@ -0,0 +1,150 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	pkgsGRPCv1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	pkgsConnectV1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1/v1alpha1connect"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/plugins/resources/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/clientgetter"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/resources/v1alpha1/common"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynfake "k8s.io/client-go/dynamic/fake"
+)
+
+func newIngress(name string, hosts []interface{}, annotations map[string]string) *unstructured.Unstructured {
+	rules := []interface{}{}
+	for _, host := range hosts {
+		rules = append(rules, map[string]interface{}{"host": host})
+	}
+	ingress := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "networking.k8s.io/v1",
+		"kind":       "Ingress",
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": "default",
+		},
+		"spec": map[string]interface{}{"rules": rules},
+	}}
+	ingress.SetAnnotations(annotations)
+	return ingress
+}
+
+func TestGetInstalledPackageCustomFields(t *testing.T) {
+	ingressRef := &pkgsGRPCv1alpha1.ResourceRef{ApiVersion: "networking.k8s.io/v1", Kind: "Ingress", Name: "web", Namespace: "default"}
+	deploymentRef := &pkgsGRPCv1alpha1.ResourceRef{ApiVersion: "apps/v1", Kind: "Deployment", Name: "web", Namespace: "default"}
+	hostField := common.CustomField{Name: "External URL", APIVersion: "networking.k8s.io/v1", Kind: "Ingress", JSONPath: "{.spec.rules[0].host}"}
+	replicasField := common.CustomField{Name: "Replicas", Kind: "Deployment", JSONPath: ".spec.replicas"}
+
+	testCases := []struct {
+		name           string
+		customFields   []common.CustomField
+		resourceRefs   []*pkgsGRPCv1alpha1.ResourceRef
+		dynamicObjects []runtime.Object
+		expectedFields []*v1alpha1.CustomField
+	}{
+		{
+			name:           "it extracts the fields of the plugin configuration, in the order of the resources",
+			customFields:   []common.CustomField{replicasField, hostField},
+			resourceRefs:   []*pkgsGRPCv1alpha1.ResourceRef{ingressRef, deploymentRef},
+			dynamicObjects: []runtime.Object{newIngress("web", []interface{}{"web.example.com"}, nil), newDeployment(t, "web", 3, 3)},
+			expectedFields: []*v1alpha1.CustomField{
+				{Name: "External URL", Value: "web.example.com", ResourceRef: ingressRef},
+				{Name: "Replicas", Value: "3", ResourceRef: deploymentRef},
+			},
+		},
+		{
+			name:           "it extracts the fields of the plugin configuration only from the resources of the same group",
+			customFields:   []common.CustomField{{Name: "External URL", APIVersion: "extensions/v1beta1", Kind: "Ingress", JSONPath: "{.spec.rules[0].host}"}},
+			resourceRefs:   []*pkgsGRPCv1alpha1.ResourceRef{ingressRef},
+			dynamicObjects: []runtime.Object{newIngress("web", []interface{}{"web.example.com"}, nil)},
+			expectedFields: []*v1alpha1.CustomField{},
+		},
+		{
+			name:         "it extracts the fields of the annotation after those of the plugin configuration",
+			customFields: []common.CustomField{hostField},
+			resourceRefs: []*pkgsGRPCv1alpha1.ResourceRef{ingressRef},
+			dynamicObjects: []runtime.Object{newIngress("web", []interface{}{"web.example.com", "www.example.com"}, map[string]string{
+				customFieldsAnnotation: `[{"name": "Hosts", "jsonPath": "{.spec.rules[*].host}"}]`,
+			})},
+			expectedFields: []*v1alpha1.CustomField{
+				{Name: "External URL", Value: "web.example.com", ResourceRef: ingressRef},
+				{Name: "Hosts", Value: "web.example.com www.example.com", ResourceRef: ingressRef},
+			},
+		},
+		{
+			name:         "it leaves out the fields which do not match anything or are invalid",
+			customFields: []common.CustomField{hostField},
+			resourceRefs: []*pkgsGRPCv1alpha1.ResourceRef{ingressRef},
+			dynamicObjects: []runtime.Object{newIngress("web", []interface{}{}, map[string]string{
+				customFieldsAnnotation: `[{"name": "TLS", "jsonPath": "{.spec.tls[0].secretName}"}, {"name": "Invalid", "jsonPath": "{.spec.rules[*.host}"}, {"name": "Class", "jsonPath": ""}]`,
+			})},
+			expectedFields: []*v1alpha1.CustomField{},
+		},
+		{
+			name:           "it ignores an invalid annotation",
+			customFields:   []common.CustomField{hostField},
+			resourceRefs:   []*pkgsGRPCv1alpha1.ResourceRef{ingressRef},
+			dynamicObjects: []runtime.Object{newIngress("web", []interface{}{"web.example.com"}, map[string]string{customFieldsAnnotation: "not json"})},
+			expectedFields: []*v1alpha1.CustomField{
+				{Name: "External URL", Value: "web.example.com", ResourceRef: ingressRef},
+			},
+		},
+		{
+			name:           "it skips the resources which are not created yet",
+			customFields:   []common.CustomField{hostField, replicasField},
+			resourceRefs:   []*pkgsGRPCv1alpha1.ResourceRef{ingressRef, deploymentRef},
+			dynamicObjects: []runtime.Object{newDeployment(t, "web", 1, 0)},
+			expectedFields: []*v1alpha1.CustomField{
+				{Name: "Replicas", Value: "1", ResourceRef: deploymentRef},
+			},
+		},
+	}
+
+	ignoredUnexported := cmpopts.IgnoreUnexported(
+		v1alpha1.CustomField{},
+		pkgsGRPCv1alpha1.ResourceRef{},
+	)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := &Server{
+				clientGetter: clientgetter.NewBuilder().
+					WithDynamic(dynfake.NewSimpleDynamicClient(runtime.NewScheme(), tc.dynamicObjects...)).
+					Build(),
+				corePackagesClientGetter: func() (pkgsConnectV1alpha1.PackagesServiceClient, error) {
+					return &fakeCorePackagesClient{resourceRefs: tc.resourceRefs}, nil
+				},
+				kindToResource: func(mapper meta.RESTMapper, gvk schema.GroupVersionKind) (schema.GroupVersionResource, meta.RESTScopeName, error) {
+					gvr, _ := meta.UnsafeGuessKindToResource(gvk)
+					return gvr, meta.RESTScopeNameNamespace, nil
+				},
+				pluginConfig: &common.ResourcesPluginConfig{CustomFields: tc.customFields},
+			}
+
+			response, err := s.GetInstalledPackageCustomFields(context.Background(), connect.NewRequest(&v1alpha1.GetInstalledPackageCustomFieldsRequest{
+				InstalledPackageRef: &pkgsGRPCv1alpha1.InstalledPackageReference{
+					Context:    &pkgsGRPCv1alpha1.Context{Cluster: "default", Namespace: "default"},
+					Identifier: "my-package",
+				},
+			}))
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+
+			if got, want := response.Msg.GetFields(), tc.expectedFields; !cmp.Equal(want, got, ignoredUnexported) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoredUnexported))
+			}
+		})
+	}
+}
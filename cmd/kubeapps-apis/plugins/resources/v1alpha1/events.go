@@ -0,0 +1,178 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/bufbuild/connect-go"
+	pkgsGRPCv1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/plugins/resources/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/connecterror"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	log "k8s.io/klog/v2"
+)
+
+// eventObjectKey identifies the object an event is about. The version is left
+// out since the events keep the version the object was reported with.
+type eventObjectKey struct {
+	group     string
+	kind      string
+	namespace string
+	name      string
+}
+
+// eventKey identifies the events reported once.
+type eventKey struct {
+	object    eventObjectKey
+	eventType string
+	reason    string
+	message   string
+}
+
+// GetInstalledPackageEvents returns the events of the resources of an
+// installed package, from the most recent.
+func (s *Server) GetInstalledPackageEvents(ctx context.Context, r *connect.Request[v1alpha1.GetInstalledPackageEventsRequest]) (*connect.Response[v1alpha1.GetInstalledPackageEventsResponse], error) {
+	namespace := r.Msg.GetInstalledPackageRef().GetContext().GetNamespace()
+	cluster := r.Msg.GetInstalledPackageRef().GetContext().GetCluster()
+	log.InfoS("+resources GetInstalledPackageEvents ", "cluster", cluster, "namespace", namespace)
+
+	pkgResourceRefs, err := s.getInstalledPackageResourceRefs(ctx, r.Header(), r.Msg.GetInstalledPackageRef())
+	if err != nil {
+		return nil, err
+	}
+
+	typedClient, err := s.clientGetter.Typed(r.Header(), cluster)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to get the k8s client: '%w'", err))
+	}
+
+	refs := map[eventObjectKey]*pkgsGRPCv1alpha1.ResourceRef{}
+	namespaces := []string{}
+	for _, ref := range pkgResourceRefs {
+		groupVersion, err := schema.ParseGroupVersion(ref.ApiVersion)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to parse group version from %q: %w", ref.ApiVersion, err))
+		}
+		// The events of cluster-scoped resources are in the default namespace.
+		eventsNamespace := ref.Namespace
+		if eventsNamespace == "" {
+			eventsNamespace = metav1.NamespaceDefault
+		}
+		if !contains(namespaces, eventsNamespace) {
+			namespaces = append(namespaces, eventsNamespace)
+		}
+		refs[eventObjectKey{group: groupVersion.Group, kind: ref.Kind, namespace: ref.Namespace, name: ref.Name}] = ref
+	}
+
+	events := []*v1alpha1.ResourceEvent{}
+	eventsByKey := map[eventKey]*v1alpha1.ResourceEvent{}
+	for _, eventsNamespace := range namespaces {
+		eventList, err := typedClient.CoreV1().Events(eventsNamespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, connecterror.FromK8sError("list", "Events", "", err)
+		}
+		for _, event := range eventList.Items {
+			groupVersion, err := schema.ParseGroupVersion(event.InvolvedObject.APIVersion)
+			if err != nil {
+				continue
+			}
+			objectKey := eventObjectKey{
+				group:     groupVersion.Group,
+				kind:      event.InvolvedObject.Kind,
+				namespace: event.InvolvedObject.Namespace,
+				name:      event.InvolvedObject.Name,
+			}
+			ref, ok := refs[objectKey]
+			if !ok {
+				continue
+			}
+
+			key := eventKey{object: objectKey, eventType: event.Type, reason: event.Reason, message: event.Message}
+			if existing, ok := eventsByKey[key]; ok {
+				mergeResourceEvent(existing, event)
+				continue
+			}
+			resourceEvent := &v1alpha1.ResourceEvent{
+				ResourceRef:    ref,
+				Type:           event.Type,
+				Reason:         event.Reason,
+				Message:        event.Message,
+				Count:          eventCount(event),
+				FirstTimestamp: formatEventTime(eventFirstTime(event)),
+				LastTimestamp:  formatEventTime(eventTime(event)),
+				Source:         eventSource(event),
+			}
+			eventsByKey[key] = resourceEvent
+			events = append(events, resourceEvent)
+		}
+	}
+
+	// RFC3339 timestamps in UTC sort in time order.
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].LastTimestamp > events[j].LastTimestamp
+	})
+
+	return connect.NewResponse(&v1alpha1.GetInstalledPackageEventsResponse{
+		Events: events,
+	}), nil
+}
+
+// mergeResourceEvent adds the occurrences of an event to those of an already
+// reported event with the same resource, type, reason and message.
+func mergeResourceEvent(resourceEvent *v1alpha1.ResourceEvent, event corev1.Event) {
+	resourceEvent.Count += eventCount(event)
+	if first := formatEventTime(eventFirstTime(event)); first != "" && (resourceEvent.FirstTimestamp == "" || first < resourceEvent.FirstTimestamp) {
+		resourceEvent.FirstTimestamp = first
+	}
+	if last := formatEventTime(eventTime(event)); last > resourceEvent.LastTimestamp {
+		resourceEvent.LastTimestamp = last
+		if source := eventSource(event); source != "" {
+			resourceEvent.Source = source
+		}
+	}
+}
+
+// eventFirstTime returns the first time an event was observed.
+func eventFirstTime(event corev1.Event) metav1.Time {
+	if !event.FirstTimestamp.IsZero() {
+		return event.FirstTimestamp
+	}
+	if !event.EventTime.IsZero() {
+		return metav1.NewTime(event.EventTime.Time)
+	}
+	return event.LastTimestamp
+}
+
+// eventCount returns the number of times an event was observed, counting the
+// events of a series.
+func eventCount(event corev1.Event) int32 {
+	if event.Series != nil && event.Series.Count > 0 {
+		return event.Series.Count
+	}
+	if event.Count > 0 {
+		return event.Count
+	}
+	return 1
+}
+
+// eventSource returns the component reporting an event.
+func eventSource(event corev1.Event) string {
+	if event.Source.Component != "" {
+		return event.Source.Component
+	}
+	return event.ReportingController
+}
+
+func formatEventTime(t metav1.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
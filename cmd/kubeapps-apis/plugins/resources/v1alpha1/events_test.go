@@ -0,0 +1,143 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	pkgsGRPCv1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	pkgsConnectV1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1/v1alpha1connect"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/plugins/resources/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/clientgetter"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	typfake "k8s.io/client-go/kubernetes/fake"
+)
+
+func newEvent(name string, involvedObject core.ObjectReference, eventType, reason, message string, count int32, lastTimestamp time.Time) *core.Event {
+	return &core.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: name, Namespace: "default"},
+		InvolvedObject: involvedObject,
+		Type:           eventType,
+		Reason:         reason,
+		Message:        message,
+		Count:          count,
+		Source:         core.EventSource{Component: "kubelet"},
+		FirstTimestamp: metav1.NewTime(lastTimestamp.Add(-time.Hour)),
+		LastTimestamp:  metav1.NewTime(lastTimestamp),
+	}
+}
+
+func TestGetInstalledPackageEvents(t *testing.T) {
+	deploymentRef := &pkgsGRPCv1alpha1.ResourceRef{ApiVersion: "apps/v1", Kind: "Deployment", Name: "web", Namespace: "default"}
+	podRef := &pkgsGRPCv1alpha1.ResourceRef{ApiVersion: "v1", Kind: "Pod", Name: "web-1", Namespace: "default"}
+	deployment := core.ObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Name: "web", Namespace: "default"}
+	pod := core.ObjectReference{APIVersion: "v1", Kind: "Pod", Name: "web-1", Namespace: "default"}
+	now := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name           string
+		resourceRefs   []*pkgsGRPCv1alpha1.ResourceRef
+		typedObjects   []runtime.Object
+		expectedEvents []*v1alpha1.ResourceEvent
+	}{
+		{
+			name:         "it returns the events of the resources, from the most recent",
+			resourceRefs: []*pkgsGRPCv1alpha1.ResourceRef{deploymentRef, podRef},
+			typedObjects: []runtime.Object{
+				newEvent("web.1", deployment, "Normal", "ScalingReplicaSet", "Scaled up replica set web-1 to 1", 1, now.Add(-time.Minute)),
+				newEvent("web-1.1", pod, "Warning", "BackOff", "Back-off restarting failed container", 5, now),
+				newEvent("other.1", core.ObjectReference{APIVersion: "v1", Kind: "Pod", Name: "other", Namespace: "default"}, "Warning", "BackOff", "Back-off restarting failed container", 1, now),
+			},
+			expectedEvents: []*v1alpha1.ResourceEvent{
+				{
+					ResourceRef:    podRef,
+					Type:           "Warning",
+					Reason:         "BackOff",
+					Message:        "Back-off restarting failed container",
+					Count:          5,
+					FirstTimestamp: "2023-06-01T11:00:00Z",
+					LastTimestamp:  "2023-06-01T12:00:00Z",
+					Source:         "kubelet",
+				},
+				{
+					ResourceRef:    deploymentRef,
+					Type:           "Normal",
+					Reason:         "ScalingReplicaSet",
+					Message:        "Scaled up replica set web-1 to 1",
+					Count:          1,
+					FirstTimestamp: "2023-06-01T10:59:00Z",
+					LastTimestamp:  "2023-06-01T11:59:00Z",
+					Source:         "kubelet",
+				},
+			},
+		},
+		{
+			name:         "it reports the same event of a resource once",
+			resourceRefs: []*pkgsGRPCv1alpha1.ResourceRef{podRef},
+			typedObjects: []runtime.Object{
+				newEvent("web-1.1", pod, "Warning", "BackOff", "Back-off restarting failed container", 2, now.Add(-time.Minute)),
+				newEvent("web-1.2", pod, "Warning", "BackOff", "Back-off restarting failed container", 3, now),
+			},
+			expectedEvents: []*v1alpha1.ResourceEvent{
+				{
+					ResourceRef:    podRef,
+					Type:           "Warning",
+					Reason:         "BackOff",
+					Message:        "Back-off restarting failed container",
+					Count:          5,
+					FirstTimestamp: "2023-06-01T10:59:00Z",
+					LastTimestamp:  "2023-06-01T12:00:00Z",
+					Source:         "kubelet",
+				},
+			},
+		},
+		{
+			name:         "it ignores the events of a resource with the same name of another group",
+			resourceRefs: []*pkgsGRPCv1alpha1.ResourceRef{deploymentRef},
+			typedObjects: []runtime.Object{
+				newEvent("web.1", core.ObjectReference{APIVersion: "example.com/v1", Kind: "Deployment", Name: "web", Namespace: "default"}, "Normal", "Synced", "Synced", 1, now),
+			},
+			expectedEvents: []*v1alpha1.ResourceEvent{},
+		},
+	}
+
+	ignoredUnexported := cmpopts.IgnoreUnexported(
+		v1alpha1.ResourceEvent{},
+		pkgsGRPCv1alpha1.ResourceRef{},
+	)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := &Server{
+				clientGetter: clientgetter.NewBuilder().
+					WithTyped(typfake.NewSimpleClientset(tc.typedObjects...)).
+					Build(),
+				corePackagesClientGetter: func() (pkgsConnectV1alpha1.PackagesServiceClient, error) {
+					return &fakeCorePackagesClient{resourceRefs: tc.resourceRefs}, nil
+				},
+			}
+
+			response, err := s.GetInstalledPackageEvents(context.Background(), connect.NewRequest(&v1alpha1.GetInstalledPackageEventsRequest{
+				InstalledPackageRef: &pkgsGRPCv1alpha1.InstalledPackageReference{
+					Context:    &pkgsGRPCv1alpha1.Context{Cluster: "default", Namespace: "default"},
+					Identifier: "my-package",
+				},
+			}))
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+
+			if got, want := response.Msg.GetEvents(), tc.expectedEvents; !cmp.Equal(want, got, ignoredUnexported) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoredUnexported))
+			}
+		})
+	}
+}
@@ -0,0 +1,245 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/bufbuild/connect-go"
+	pkgsGRPCv1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/plugins/resources/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/connecterror"
+	"helm.sh/helm/v3/pkg/releaseutil"
+	"helm.sh/helm/v3/pkg/storage"
+	"helm.sh/helm/v3/pkg/storage/driver"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	log "k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// kubectlLastAppliedAnnotation is the annotation in which `kubectl apply`
+	// records the configuration applied to a resource.
+	kubectlLastAppliedAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+	// kappOriginalAnnotation is the annotation in which kapp records the
+	// configuration applied to a resource.
+	kappOriginalAnnotation = "kapp.k14s.io/original"
+	// helmReleaseNameAnnotation and helmReleaseNamespaceAnnotation are the
+	// annotations with which helm records the release owning a resource.
+	helmReleaseNameAnnotation      = "meta.helm.sh/release-name"
+	helmReleaseNamespaceAnnotation = "meta.helm.sh/release-namespace"
+)
+
+// serverPopulatedMetadataFields are the metadata fields populated by the API
+// server, removed from the exported live state of the resources.
+var serverPopulatedMetadataFields = []string{
+	"managedFields",
+	"uid",
+	"resourceVersion",
+	"generation",
+	"creationTimestamp",
+	"selfLink",
+}
+
+// ExportInstalledPackageManifests returns the manifests of the resources of
+// an installed package as a single multi-document YAML, either of their live
+// state or of the configuration last applied to them.
+func (s *Server) ExportInstalledPackageManifests(ctx context.Context, r *connect.Request[v1alpha1.ExportInstalledPackageManifestsRequest]) (*connect.Response[v1alpha1.ExportInstalledPackageManifestsResponse], error) {
+	namespace := r.Msg.GetInstalledPackageRef().GetContext().GetNamespace()
+	cluster := r.Msg.GetInstalledPackageRef().GetContext().GetCluster()
+	log.InfoS("+resources ExportInstalledPackageManifests ", "cluster", cluster, "namespace", namespace, "source", r.Msg.GetSource())
+
+	pkgResourceRefs, err := s.getInstalledPackageResourceRefs(ctx, r.Header(), r.Msg.GetInstalledPackageRef())
+	if err != nil {
+		return nil, err
+	}
+
+	dynamicClient, err := s.clientGetter.Dynamic(r.Header(), cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	helmReleases := &helmReleaseManifests{
+		headers:      r.Header(),
+		cluster:      cluster,
+		clientGetter: s.clientGetter.Typed,
+		objects:      map[types.NamespacedName][]map[string]interface{}{},
+	}
+
+	documents := []string{}
+	skipped := []*pkgsGRPCv1alpha1.ResourceRef{}
+	for _, ref := range pkgResourceRefs {
+		gvk, err := resourceRefGVK(ref)
+		if err != nil {
+			return nil, err
+		}
+		resourceClient, err := s.resourceClient(dynamicClient, gvk, ref.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		obj, err := resourceClient.Get(ctx, ref.GetName(), metav1.GetOptions{})
+		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				skipped = append(skipped, ref)
+				continue
+			}
+			return nil, connecterror.FromK8sError("get", ref.Kind, ref.GetName(), err)
+		}
+
+		var manifest map[string]interface{}
+		if r.Msg.GetSource() == v1alpha1.ManifestSource_MANIFEST_SOURCE_LAST_APPLIED {
+			manifest, err = helmReleases.manifest(obj)
+			if err != nil {
+				return nil, err
+			}
+			if manifest == nil {
+				manifest, err = lastAppliedManifest(obj)
+				if err != nil {
+					return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to parse the last applied configuration of %s %q: %w", ref.Kind, ref.GetName(), err))
+				}
+			}
+			if manifest == nil {
+				skipped = append(skipped, ref)
+				continue
+			}
+		} else {
+			manifest = liveManifest(obj)
+		}
+
+		document, err := yaml.Marshal(manifest)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to marshal the manifest of %s %q: %w", ref.Kind, ref.GetName(), err))
+		}
+		documents = append(documents, string(document))
+	}
+
+	return connect.NewResponse(&v1alpha1.ExportInstalledPackageManifestsResponse{
+		Manifests:           strings.Join(documents, "---\n"),
+		SkippedResourceRefs: skipped,
+	}), nil
+}
+
+// liveManifest returns the live state of a resource without the status and
+// the fields populated by the API server, nor the last applied configuration.
+func liveManifest(obj *unstructured.Unstructured) map[string]interface{} {
+	manifest := obj.DeepCopy()
+	unstructured.RemoveNestedField(manifest.Object, "status")
+	for _, field := range serverPopulatedMetadataFields {
+		unstructured.RemoveNestedField(manifest.Object, "metadata", field)
+	}
+	annotations := manifest.GetAnnotations()
+	if _, ok := annotations[kubectlLastAppliedAnnotation]; ok {
+		delete(annotations, kubectlLastAppliedAnnotation)
+		manifest.SetAnnotations(annotations)
+	}
+	return manifest.Object
+}
+
+// lastAppliedManifest returns the configuration last applied to a resource by
+// kubectl or kapp, or nil when not recorded.
+func lastAppliedManifest(obj *unstructured.Unstructured) (map[string]interface{}, error) {
+	for _, annotation := range []string{kubectlLastAppliedAnnotation, kappOriginalAnnotation} {
+		lastApplied, ok := obj.GetAnnotations()[annotation]
+		if !ok || lastApplied == "" {
+			continue
+		}
+		var manifest map[string]interface{}
+		if err := json.Unmarshal([]byte(lastApplied), &manifest); err != nil {
+			return nil, err
+		}
+		return manifest, nil
+	}
+	return nil, nil
+}
+
+// helmReleaseManifests provides the objects rendered by the deployed revision
+// of the helm releases owning the exported resources, loading each release
+// from the helm storage at most once.
+type helmReleaseManifests struct {
+	headers      http.Header
+	cluster      string
+	clientGetter func(headers http.Header, cluster string) (kubernetes.Interface, error)
+	objects      map[types.NamespacedName][]map[string]interface{}
+}
+
+// manifest returns the object rendered by helm for a resource owned by a
+// helm release, or nil when the resource is not owned by a deployed release.
+func (h *helmReleaseManifests) manifest(obj *unstructured.Unstructured) (map[string]interface{}, error) {
+	annotations := obj.GetAnnotations()
+	releaseName, releaseNamespace := annotations[helmReleaseNameAnnotation], annotations[helmReleaseNamespaceAnnotation]
+	if releaseName == "" || releaseNamespace == "" {
+		return nil, nil
+	}
+
+	releaseKey := types.NamespacedName{Namespace: releaseNamespace, Name: releaseName}
+	objects, ok := h.objects[releaseKey]
+	if !ok {
+		var err error
+		objects, err = h.releaseObjects(releaseKey)
+		if err != nil {
+			return nil, err
+		}
+		h.objects[releaseKey] = objects
+	}
+
+	groupKind := obj.GroupVersionKind().GroupKind()
+	for _, object := range objects {
+		rendered := unstructured.Unstructured{Object: object}
+		if rendered.GroupVersionKind().GroupKind() != groupKind || rendered.GetName() != obj.GetName() {
+			continue
+		}
+		// Namespaced objects rendered without namespace are created by helm
+		// in the namespace of the release.
+		namespace := rendered.GetNamespace()
+		if namespace == "" && obj.GetNamespace() != "" {
+			namespace = releaseNamespace
+		}
+		if namespace != obj.GetNamespace() {
+			continue
+		}
+		manifest := rendered.DeepCopy()
+		if manifest.GetNamespace() == "" && obj.GetNamespace() != "" {
+			manifest.SetNamespace(obj.GetNamespace())
+		}
+		return manifest.Object, nil
+	}
+	return nil, nil
+}
+
+// releaseObjects returns the objects of the manifest of the deployed revision
+// of a helm release, or none when the release has no deployed revision.
+func (h *helmReleaseManifests) releaseObjects(releaseKey types.NamespacedName) ([]map[string]interface{}, error) {
+	typedClient, err := h.clientGetter(h.headers, h.cluster)
+	if err != nil {
+		return nil, err
+	}
+	release, err := storage.Init(driver.NewSecrets(typedClient.CoreV1().Secrets(releaseKey.Namespace))).Deployed(releaseKey.Name)
+	if err != nil {
+		if errors.Is(err, driver.ErrNoDeployedReleases) || errors.Is(err, driver.ErrReleaseNotFound) {
+			return []map[string]interface{}{}, nil
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to get the deployed revision of the helm release %q: %w", releaseKey, err))
+	}
+
+	objects := []map[string]interface{}{}
+	for _, document := range releaseutil.SplitManifests(release.Manifest) {
+		var object map[string]interface{}
+		if err := yaml.Unmarshal([]byte(document), &object); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to parse the manifest of the helm release %q: %w", releaseKey, err))
+		}
+		if object != nil {
+			objects = append(objects, object)
+		}
+	}
+	return objects, nil
+}
@@ -0,0 +1,269 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	pkgsGRPCv1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	pkgsConnectV1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1/v1alpha1connect"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/plugins/resources/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/clientgetter"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/storage/driver"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynfake "k8s.io/client-go/dynamic/fake"
+	typfake "k8s.io/client-go/kubernetes/fake"
+)
+
+func newConfigMap(name string, annotations map[string]string) *unstructured.Unstructured {
+	configMap := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]interface{}{
+			"name":              name,
+			"namespace":         "default",
+			"uid":               "3f1a2b7c",
+			"resourceVersion":   "1234",
+			"creationTimestamp": "2023-01-01T00:00:00Z",
+			"managedFields":     []interface{}{map[string]interface{}{"manager": "kubectl"}},
+			"labels":            map[string]interface{}{"app": "web"},
+		},
+		"data": map[string]interface{}{"color": "blue"},
+	}}
+	configMap.SetAnnotations(annotations)
+	return configMap
+}
+
+func TestExportInstalledPackageManifests(t *testing.T) {
+	configRef := &pkgsGRPCv1alpha1.ResourceRef{ApiVersion: "v1", Kind: "ConfigMap", Name: "config", Namespace: "default"}
+	otherRef := &pkgsGRPCv1alpha1.ResourceRef{ApiVersion: "v1", Kind: "ConfigMap", Name: "other", Namespace: "default"}
+	deploymentRef := &pkgsGRPCv1alpha1.ResourceRef{ApiVersion: "apps/v1", Kind: "Deployment", Name: "web", Namespace: "default"}
+
+	testCases := []struct {
+		name              string
+		source            v1alpha1.ManifestSource
+		resourceRefs      []*pkgsGRPCv1alpha1.ResourceRef
+		dynamicObjects    []runtime.Object
+		helmReleases      []*release.Release
+		expectedManifests string
+		expectedSkipped   []*pkgsGRPCv1alpha1.ResourceRef
+	}{
+		{
+			name:         "it exports the live state without the fields populated by the API server",
+			resourceRefs: []*pkgsGRPCv1alpha1.ResourceRef{configRef, deploymentRef},
+			dynamicObjects: []runtime.Object{
+				newConfigMap("config", map[string]string{
+					kubectlLastAppliedAnnotation: `{"apiVersion":"v1","kind":"ConfigMap"}`,
+					"team":                       "web",
+				}),
+				newDeployment(t, "web", 3, 3),
+			},
+			expectedManifests: `apiVersion: v1
+data:
+  color: blue
+kind: ConfigMap
+metadata:
+  annotations:
+    team: web
+  labels:
+    app: web
+  name: config
+  namespace: default
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: default
+spec:
+  replicas: 3
+  selector: null
+  strategy: {}
+  template:
+    metadata:
+      creationTimestamp: null
+    spec:
+      containers: null
+`,
+			expectedSkipped: []*pkgsGRPCv1alpha1.ResourceRef{},
+		},
+		{
+			name:         "it exports the configuration last applied by kubectl or kapp",
+			source:       v1alpha1.ManifestSource_MANIFEST_SOURCE_LAST_APPLIED,
+			resourceRefs: []*pkgsGRPCv1alpha1.ResourceRef{configRef, otherRef},
+			dynamicObjects: []runtime.Object{
+				newConfigMap("config", map[string]string{
+					kubectlLastAppliedAnnotation: `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"config","namespace":"default"},"data":{"color":"red"}}`,
+				}),
+				newConfigMap("other", map[string]string{
+					kappOriginalAnnotation: `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"other"},"data":{"color":"green"}}`,
+				}),
+			},
+			expectedManifests: `apiVersion: v1
+data:
+  color: red
+kind: ConfigMap
+metadata:
+  name: config
+  namespace: default
+---
+apiVersion: v1
+data:
+  color: green
+kind: ConfigMap
+metadata:
+  name: other
+`,
+			expectedSkipped: []*pkgsGRPCv1alpha1.ResourceRef{},
+		},
+		{
+			name:         "it exports the objects rendered in the deployed revision of the helm release",
+			source:       v1alpha1.ManifestSource_MANIFEST_SOURCE_LAST_APPLIED,
+			resourceRefs: []*pkgsGRPCv1alpha1.ResourceRef{configRef, otherRef},
+			dynamicObjects: []runtime.Object{
+				newConfigMap("config", map[string]string{
+					helmReleaseNameAnnotation:      "my-release",
+					helmReleaseNamespaceAnnotation: "default",
+					kubectlLastAppliedAnnotation:   `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"config","namespace":"default"},"data":{"color":"red"}}`,
+				}),
+				newConfigMap("other", map[string]string{
+					helmReleaseNameAnnotation:      "my-release",
+					helmReleaseNamespaceAnnotation: "default",
+				}),
+			},
+			helmReleases: []*release.Release{
+				{
+					Name:      "my-release",
+					Namespace: "default",
+					Version:   1,
+					Info:      &release.Info{Status: release.StatusSuperseded},
+					Manifest: `---
+# Source: web/templates/config.yaml
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+data:
+  color: yellow
+`,
+				},
+				{
+					Name:      "my-release",
+					Namespace: "default",
+					Version:   2,
+					Info:      &release.Info{Status: release.StatusDeployed},
+					Manifest: `---
+# Source: web/templates/config.yaml
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+data:
+  color: green
+---
+# Source: web/templates/service.yaml
+apiVersion: v1
+kind: Service
+metadata:
+  name: other
+`,
+				},
+			},
+			expectedManifests: `apiVersion: v1
+data:
+  color: green
+kind: ConfigMap
+metadata:
+  name: config
+  namespace: default
+`,
+			expectedSkipped: []*pkgsGRPCv1alpha1.ResourceRef{otherRef},
+		},
+		{
+			name:         "it skips the resources without last applied configuration",
+			source:       v1alpha1.ManifestSource_MANIFEST_SOURCE_LAST_APPLIED,
+			resourceRefs: []*pkgsGRPCv1alpha1.ResourceRef{configRef},
+			dynamicObjects: []runtime.Object{
+				newConfigMap("config", nil),
+			},
+			expectedManifests: "",
+			expectedSkipped:   []*pkgsGRPCv1alpha1.ResourceRef{configRef},
+		},
+		{
+			name:         "it skips the resources which are not created yet",
+			resourceRefs: []*pkgsGRPCv1alpha1.ResourceRef{otherRef, configRef},
+			dynamicObjects: []runtime.Object{
+				newConfigMap("config", nil),
+			},
+			expectedManifests: `apiVersion: v1
+data:
+  color: blue
+kind: ConfigMap
+metadata:
+  labels:
+    app: web
+  name: config
+  namespace: default
+`,
+			expectedSkipped: []*pkgsGRPCv1alpha1.ResourceRef{otherRef},
+		},
+	}
+
+	ignoredUnexported := cmpopts.IgnoreUnexported(
+		pkgsGRPCv1alpha1.ResourceRef{},
+	)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			typedClient := typfake.NewSimpleClientset()
+			for _, rel := range tc.helmReleases {
+				key := fmt.Sprintf("sh.helm.release.v1.%s.v%d", rel.Name, rel.Version)
+				if err := driver.NewSecrets(typedClient.CoreV1().Secrets(rel.Namespace)).Create(key, rel); err != nil {
+					t.Fatalf("%+v", err)
+				}
+			}
+
+			s := &Server{
+				clientGetter: clientgetter.NewBuilder().
+					WithDynamic(dynfake.NewSimpleDynamicClient(runtime.NewScheme(), tc.dynamicObjects...)).
+					WithTyped(typedClient).
+					Build(),
+				corePackagesClientGetter: func() (pkgsConnectV1alpha1.PackagesServiceClient, error) {
+					return &fakeCorePackagesClient{resourceRefs: tc.resourceRefs}, nil
+				},
+				kindToResource: func(mapper meta.RESTMapper, gvk schema.GroupVersionKind) (schema.GroupVersionResource, meta.RESTScopeName, error) {
+					gvr, _ := meta.UnsafeGuessKindToResource(gvk)
+					return gvr, meta.RESTScopeNameNamespace, nil
+				},
+			}
+
+			response, err := s.ExportInstalledPackageManifests(context.Background(), connect.NewRequest(&v1alpha1.ExportInstalledPackageManifestsRequest{
+				InstalledPackageRef: &pkgsGRPCv1alpha1.InstalledPackageReference{
+					Context:    &pkgsGRPCv1alpha1.Context{Cluster: "default", Namespace: "default"},
+					Identifier: "my-package",
+				},
+				Source: tc.source,
+			}))
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+
+			if got, want := response.Msg.GetManifests(), tc.expectedManifests; got != want {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+			if got, want := response.Msg.GetSkippedResourceRefs(), tc.expectedSkipped; !cmp.Equal(want, got, ignoredUnexported) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoredUnexported))
+			}
+		})
+	}
+}
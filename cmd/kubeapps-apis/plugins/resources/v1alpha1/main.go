@@ -17,7 +17,12 @@ import (
 
 // Set the pluginDetail once during a module init function so the single struct
 // can be used throughout the plugin.
-var pluginDetail pluginsgrpcv1alpha1.Plugin
+var (
+	pluginDetail pluginsgrpcv1alpha1.Plugin
+	// This version var is updated during the build (see the -ldflags option
+	// in the cmd/kubeapps-apis/Dockerfile)
+	version = "devel"
+)
 
 func init() {
 	pluginDetail = pluginsgrpcv1alpha1.Plugin{
@@ -35,7 +40,7 @@ func RegisterWithGRPCServer(opts pluginsv1alpha1.GRPCPluginRegistrationOptions)
 	if err != nil {
 		return nil, err
 	}
-	opts.Mux.Handle(resourcesConnect.NewResourcesServiceHandler(svr))
+	opts.Mux.Handle(resourcesConnect.NewResourcesServiceHandler(svr, opts.HandlerOptions...))
 	return svr, nil
 }
 
@@ -53,3 +58,8 @@ func RegisterHTTPHandlerFromEndpoint(ctx context.Context, mux *runtime.ServeMux,
 func GetPluginDetail() *pluginsgrpcv1alpha1.Plugin {
 	return &pluginDetail
 }
+
+// GetPluginBuildVersion returns the version the plugin was built from.
+func GetPluginBuildVersion() string {
+	return version
+}
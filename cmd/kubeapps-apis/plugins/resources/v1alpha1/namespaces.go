@@ -8,12 +8,12 @@ import (
 	"fmt"
 	"strings"
 
-	authorizationapi "k8s.io/api/authorization/v1"
 	"k8s.io/client-go/kubernetes"
 
 	"github.com/bufbuild/connect-go"
 	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/plugins/resources/v1alpha1"
 	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/connecterror"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/resources"
 	core "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -127,21 +127,21 @@ func (s *Server) CanI(ctx context.Context, r *connect.Request[v1alpha1.CanIReque
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to get the k8s client: '%w'", err))
 	}
 
-	reviewResult, err := typedClient.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, &authorizationapi.SelfSubjectAccessReview{
-		Spec: authorizationapi.SelfSubjectAccessReviewSpec{
-			ResourceAttributes: &authorizationapi.ResourceAttributes{
-				Group:     r.Msg.Group,
-				Resource:  r.Msg.Resource,
-				Verb:      r.Msg.Verb,
-				Namespace: namespace,
-			},
-		},
-	}, metav1.CreateOptions{})
+	// The outcome of the access reviews is cached per user for the configured
+	// TTL, except for mutating verbs, as list-heavy pages check many of them.
+	allowed, err := s.accessReviewCache.CanI(ctx, typedClient, resources.AccessReviewKey{
+		User:      resources.AccessReviewUser(r.Header()),
+		Cluster:   cluster,
+		Namespace: namespace,
+		Group:     r.Msg.GetGroup(),
+		Resource:  r.Msg.GetResource(),
+		Verb:      r.Msg.GetVerb(),
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	return connect.NewResponse(&v1alpha1.CanIResponse{
-		Allowed: reviewResult.Status.Allowed,
+		Allowed: allowed,
 	}), nil
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/bufbuild/connect-go"
 	"github.com/stretchr/testify/assert"
 	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/clientgetter"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/resources"
 	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/resources/v1alpha1/common"
 	"google.golang.org/grpc/metadata"
 
@@ -18,6 +19,7 @@ import (
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -739,3 +741,50 @@ func TestCanI(t *testing.T) {
 		})
 	}
 }
+
+func TestCanICachesAccessReviews(t *testing.T) {
+	reviewed := 0
+	fakeClient := typfake.NewSimpleClientset()
+	fakeClient.PrependReactor("create", "selfsubjectaccessreviews", func(action clientGoTesting.Action) (handled bool, ret runtime.Object, err error) {
+		reviewed++
+		return true, &authorizationv1.SelfSubjectAccessReview{
+			Status: authorizationv1.SubjectAccessReviewStatus{Allowed: true},
+		}, nil
+	})
+
+	s := Server{
+		clientGetter: clientgetter.NewBuilder().
+			WithTyped(fakeClient).
+			Build(),
+		accessReviewCache: resources.NewAccessReviewCache(time.Minute),
+		clientQPS:         5,
+	}
+
+	canI := func(verb string) {
+		request := connect.NewRequest(&v1alpha1.CanIRequest{
+			Context:  &pkgsGRPCv1alpha1.Context{Cluster: "default", Namespace: "kubeapps"},
+			Resource: "secrets",
+			Verb:     verb,
+		})
+		request.Header().Set("Authorization", "Bearer abc")
+		response, err := s.CanI(context.Background(), request)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		if !response.Msg.Allowed {
+			t.Errorf("got: not allowed, want: allowed")
+		}
+	}
+
+	canI("list")
+	canI("list")
+	if got, want := reviewed, 1; got != want {
+		t.Errorf("got: %d reviews of a non-mutating verb, want: %d", got, want)
+	}
+
+	canI("delete")
+	canI("delete")
+	if got, want := reviewed, 3; got != want {
+		t.Errorf("got: %d reviews after a mutating verb, want: %d", got, want)
+	}
+}
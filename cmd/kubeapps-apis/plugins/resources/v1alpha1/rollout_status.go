@@ -0,0 +1,214 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/bufbuild/connect-go"
+	pkgsGRPCv1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/plugins/resources/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/connecterror"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/watch"
+	log "k8s.io/klog/v2"
+	"k8s.io/kubectl/pkg/polymorphichelpers"
+)
+
+const (
+	// rolloutDeletedReason is the reason of a failed rollout when the workload
+	// is deleted while it is being watched.
+	rolloutDeletedReason = "Deleted"
+	// rolloutFailedReason is the reason of a failed rollout when the workload
+	// does not report a more specific one in its Progressing condition.
+	rolloutFailedReason = "RolloutFailed"
+)
+
+// GetRolloutStatus returns the rollout status of the workloads (Deployments,
+// StatefulSets and DaemonSets) of an installed package, similar to
+// `kubectl rollout status`.
+func (s *Server) GetRolloutStatus(ctx context.Context, r *connect.Request[v1alpha1.GetRolloutStatusRequest], stream *connect.ServerStream[v1alpha1.GetRolloutStatusResponse]) error {
+	namespace := r.Msg.GetInstalledPackageRef().GetContext().GetNamespace()
+	cluster := r.Msg.GetInstalledPackageRef().GetContext().GetCluster()
+	log.InfoS("+resources GetRolloutStatus ", "cluster", cluster, "namespace", namespace)
+
+	pkgResourceRefs, err := s.getInstalledPackageResourceRefs(ctx, r.Header(), r.Msg.GetInstalledPackageRef())
+	if err != nil {
+		return err
+	}
+
+	dynamicClient, err := s.clientGetter.Dynamic(r.Header(), cluster)
+	if err != nil {
+		return err
+	}
+
+	// Send the current status of each workload, and watch those which are
+	// still being rolled out if requested.
+	var watchers []*ResourceWatcher
+	viewers := map[*pkgsGRPCv1alpha1.ResourceRef]polymorphichelpers.StatusViewer{}
+	lastMessages := map[*pkgsGRPCv1alpha1.ResourceRef]string{}
+	for _, ref := range pkgResourceRefs {
+		gvk, err := resourceRefGVK(ref)
+		if err != nil {
+			return err
+		}
+
+		// Only workloads have a rollout status, other resources are ignored.
+		viewer, err := polymorphichelpers.StatusViewerFor(gvk.GroupKind())
+		if err != nil {
+			continue
+		}
+		resourceClient, err := s.resourceClient(dynamicClient, gvk, ref.Namespace)
+		if err != nil {
+			return err
+		}
+
+		obj, err := resourceClient.Get(ctx, ref.GetName(), metav1.GetOptions{})
+		if err != nil {
+			return connecterror.FromK8sError("get", ref.Kind, ref.GetName(), err)
+		}
+		status := rolloutStatus(ref, viewer, obj)
+		if err = sendRolloutStatus(status, stream); err != nil {
+			return err
+		}
+		if !r.Msg.GetWatch() || status.Status != v1alpha1.RolloutStatus_ROLLOUT_STATUS_IN_PROGRESS {
+			continue
+		}
+
+		// Watch from the version that was just sent so that no update is missed.
+		watcher, err := resourceClient.Watch(ctx, metav1.ListOptions{
+			FieldSelector:   fmt.Sprintf("metadata.name=%s", ref.GetName()),
+			ResourceVersion: obj.GetResourceVersion(),
+		})
+		if err != nil {
+			log.Errorf("Unable to watch resource %v: %v", ref, err)
+			return connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to watch resource %v", ref))
+		}
+		watchers = append(watchers, &ResourceWatcher{
+			ResourceRef: ref,
+			Watcher:     watcher,
+		})
+		viewers[ref] = viewer
+		lastMessages[ref] = status.Message
+	}
+
+	// If we're not watching, or every rollout has already terminated, we're done.
+	if watchers == nil {
+		return nil
+	}
+
+	resourceWatcher := mergeWatchers(watchers)
+	defer func() {
+		resourceWatcher.Stop()
+		// Drain the remaining events so that the go-routines merging the
+		// watchers are not blocked.
+		go func() {
+			for range resourceWatcher.ResultChan() {
+			}
+		}()
+	}()
+
+	for len(viewers) > 0 {
+		var e ResourceEvent
+		var ok bool
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case e, ok = <-resourceWatcher.ResultChan():
+			if !ok {
+				return connect.NewError(connect.CodeUnavailable, fmt.Errorf("The watch was closed before the rollout of every workload terminated"))
+			}
+		}
+
+		viewer, found := viewers[e.ResourceRef]
+		if !found {
+			// The rollout of this workload has already terminated.
+			continue
+		}
+
+		var status *v1alpha1.GetRolloutStatusResponse
+		switch e.Type {
+		case watch.Added, watch.Modified:
+			obj, ok := e.Object.(*unstructured.Unstructured)
+			if !ok || obj.GetName() != e.ResourceRef.GetName() {
+				continue
+			}
+			status = rolloutStatus(e.ResourceRef, viewer, obj)
+		case watch.Deleted:
+			status = &v1alpha1.GetRolloutStatusResponse{
+				ResourceRef: e.ResourceRef,
+				Status:      v1alpha1.RolloutStatus_ROLLOUT_STATUS_FAILED,
+				Message:     fmt.Sprintf("%s %q was deleted", strings.ToLower(e.ResourceRef.Kind), e.ResourceRef.GetName()),
+				Reason:      rolloutDeletedReason,
+			}
+		case watch.Error:
+			return connecterror.FromK8sError("watch", e.ResourceRef.Kind, e.ResourceRef.GetName(), k8serrors.FromObject(e.Object))
+		default:
+			continue
+		}
+
+		// Avoid sending the same progress again for updates unrelated to the rollout.
+		if status.Status == v1alpha1.RolloutStatus_ROLLOUT_STATUS_IN_PROGRESS && lastMessages[e.ResourceRef] == status.Message {
+			continue
+		}
+		lastMessages[e.ResourceRef] = status.Message
+		if err = sendRolloutStatus(status, stream); err != nil {
+			return err
+		}
+		if status.Status != v1alpha1.RolloutStatus_ROLLOUT_STATUS_IN_PROGRESS {
+			delete(viewers, e.ResourceRef)
+		}
+	}
+
+	return nil
+}
+
+// rolloutStatus returns the rollout status of the given workload, using the
+// same logic and wording as `kubectl rollout status`.
+func rolloutStatus(ref *pkgsGRPCv1alpha1.ResourceRef, viewer polymorphichelpers.StatusViewer, obj *unstructured.Unstructured) *v1alpha1.GetRolloutStatusResponse {
+	status := &v1alpha1.GetRolloutStatusResponse{
+		ResourceRef: ref,
+	}
+	message, done, err := viewer.Status(obj, 0)
+	switch {
+	case err != nil:
+		status.Status = v1alpha1.RolloutStatus_ROLLOUT_STATUS_FAILED
+		status.Message = err.Error()
+		status.Reason = rolloutFailureReason(obj)
+	case done:
+		status.Status = v1alpha1.RolloutStatus_ROLLOUT_STATUS_SUCCEEDED
+		status.Message = strings.TrimSpace(message)
+	default:
+		status.Status = v1alpha1.RolloutStatus_ROLLOUT_STATUS_IN_PROGRESS
+		status.Message = strings.TrimSpace(message)
+	}
+	return status
+}
+
+// rolloutFailureReason returns the reason of the Progressing condition when
+// the workload is no longer progressing, such as ProgressDeadlineExceeded.
+func rolloutFailureReason(obj *unstructured.Unstructured) string {
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok || condition["type"] != "Progressing" || condition["status"] != "False" {
+			continue
+		}
+		if reason, ok := condition["reason"].(string); ok && reason != "" {
+			return reason
+		}
+	}
+	return rolloutFailedReason
+}
+
+func sendRolloutStatus(status *v1alpha1.GetRolloutStatusResponse, s *connect.ServerStream[v1alpha1.GetRolloutStatusResponse]) error {
+	if err := s.Send(status); err != nil {
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("Unable send GetRolloutStatusResponse: %w", err))
+	}
+	return nil
+}
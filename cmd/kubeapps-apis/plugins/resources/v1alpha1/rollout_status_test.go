@@ -0,0 +1,249 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	pkgsGRPCv1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	pkgsConnectV1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1/v1alpha1connect"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/plugins/resources/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/plugins/resources/v1alpha1/v1alpha1connect"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/clientgetter"
+	appsv1 "k8s.io/api/apps/v1"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	dynfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// fakeCorePackagesClient returns the given resource refs for any installed package.
+type fakeCorePackagesClient struct {
+	pkgsConnectV1alpha1.PackagesServiceClient
+	resourceRefs []*pkgsGRPCv1alpha1.ResourceRef
+}
+
+func (c *fakeCorePackagesClient) GetInstalledPackageResourceRefs(ctx context.Context, request *connect.Request[pkgsGRPCv1alpha1.GetInstalledPackageResourceRefsRequest]) (*connect.Response[pkgsGRPCv1alpha1.GetInstalledPackageResourceRefsResponse], error) {
+	return connect.NewResponse(&pkgsGRPCv1alpha1.GetInstalledPackageResourceRefsResponse{
+		Context:      request.Msg.GetInstalledPackageRef().GetContext(),
+		ResourceRefs: c.resourceRefs,
+	}), nil
+}
+
+func newDeployment(t *testing.T, name string, replicas, updatedReplicas int32, conditions ...appsv1.DeploymentCondition) *unstructured.Unstructured {
+	deployment := &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       name,
+			Namespace:  "default",
+			Generation: 1,
+		},
+		Spec: appsv1.DeploymentSpec{Replicas: &replicas},
+		Status: appsv1.DeploymentStatus{
+			ObservedGeneration: 1,
+			Replicas:           updatedReplicas,
+			UpdatedReplicas:    updatedReplicas,
+			AvailableReplicas:  updatedReplicas,
+			Conditions:         conditions,
+		},
+	}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(deployment)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	return &unstructured.Unstructured{Object: content}
+}
+
+func TestGetRolloutStatus(t *testing.T) {
+	deploymentRef := func(name string) *pkgsGRPCv1alpha1.ResourceRef {
+		return &pkgsGRPCv1alpha1.ResourceRef{ApiVersion: "apps/v1", Kind: "Deployment", Name: name, Namespace: "default"}
+	}
+	configMapRef := &pkgsGRPCv1alpha1.ResourceRef{ApiVersion: "v1", Kind: "ConfigMap", Name: "config", Namespace: "default"}
+
+	testCases := []struct {
+		name           string
+		watch          bool
+		resourceRefs   []*pkgsGRPCv1alpha1.ResourceRef
+		clusterObjects []runtime.Object
+		// watchEvents are sent, in order, once the initial statuses are received.
+		watchEvents       []watch.Event
+		expectedResponses []*v1alpha1.GetRolloutStatusResponse
+	}{
+		{
+			name:         "it returns the current rollout status of the workloads only",
+			resourceRefs: []*pkgsGRPCv1alpha1.ResourceRef{deploymentRef("done"), configMapRef, deploymentRef("rolling")},
+			clusterObjects: []runtime.Object{
+				newDeployment(t, "done", 3, 3),
+				newDeployment(t, "rolling", 3, 1),
+			},
+			expectedResponses: []*v1alpha1.GetRolloutStatusResponse{
+				{
+					ResourceRef: deploymentRef("done"),
+					Status:      v1alpha1.RolloutStatus_ROLLOUT_STATUS_SUCCEEDED,
+					Message:     `deployment "done" successfully rolled out`,
+				},
+				{
+					ResourceRef: deploymentRef("rolling"),
+					Status:      v1alpha1.RolloutStatus_ROLLOUT_STATUS_IN_PROGRESS,
+					Message:     `Waiting for deployment "rolling" rollout to finish: 1 out of 3 new replicas have been updated...`,
+				},
+			},
+		},
+		{
+			name:         "it returns the reason of a failed rollout",
+			resourceRefs: []*pkgsGRPCv1alpha1.ResourceRef{deploymentRef("stuck")},
+			clusterObjects: []runtime.Object{
+				newDeployment(t, "stuck", 3, 1, appsv1.DeploymentCondition{
+					Type:   appsv1.DeploymentProgressing,
+					Status: core.ConditionFalse,
+					Reason: "ProgressDeadlineExceeded",
+				}),
+			},
+			expectedResponses: []*v1alpha1.GetRolloutStatusResponse{
+				{
+					ResourceRef: deploymentRef("stuck"),
+					Status:      v1alpha1.RolloutStatus_ROLLOUT_STATUS_FAILED,
+					Message:     `deployment "stuck" exceeded its progress deadline`,
+					Reason:      "ProgressDeadlineExceeded",
+				},
+			},
+		},
+		{
+			name:           "it streams the progress until the rollout succeeds",
+			watch:          true,
+			resourceRefs:   []*pkgsGRPCv1alpha1.ResourceRef{deploymentRef("rolling")},
+			clusterObjects: []runtime.Object{newDeployment(t, "rolling", 3, 1)},
+			watchEvents: []watch.Event{
+				{Type: watch.Modified, Object: newDeployment(t, "rolling", 3, 1)},
+				{Type: watch.Modified, Object: newDeployment(t, "rolling", 3, 2)},
+				{Type: watch.Modified, Object: newDeployment(t, "rolling", 3, 3)},
+			},
+			expectedResponses: []*v1alpha1.GetRolloutStatusResponse{
+				{
+					ResourceRef: deploymentRef("rolling"),
+					Status:      v1alpha1.RolloutStatus_ROLLOUT_STATUS_IN_PROGRESS,
+					Message:     `Waiting for deployment "rolling" rollout to finish: 1 out of 3 new replicas have been updated...`,
+				},
+				{
+					ResourceRef: deploymentRef("rolling"),
+					Status:      v1alpha1.RolloutStatus_ROLLOUT_STATUS_IN_PROGRESS,
+					Message:     `Waiting for deployment "rolling" rollout to finish: 2 out of 3 new replicas have been updated...`,
+				},
+				{
+					ResourceRef: deploymentRef("rolling"),
+					Status:      v1alpha1.RolloutStatus_ROLLOUT_STATUS_SUCCEEDED,
+					Message:     `deployment "rolling" successfully rolled out`,
+				},
+			},
+		},
+		{
+			name:           "it fails the rollout of a deleted workload",
+			watch:          true,
+			resourceRefs:   []*pkgsGRPCv1alpha1.ResourceRef{deploymentRef("rolling")},
+			clusterObjects: []runtime.Object{newDeployment(t, "rolling", 3, 1)},
+			watchEvents: []watch.Event{
+				{Type: watch.Deleted, Object: newDeployment(t, "rolling", 3, 1)},
+			},
+			expectedResponses: []*v1alpha1.GetRolloutStatusResponse{
+				{
+					ResourceRef: deploymentRef("rolling"),
+					Status:      v1alpha1.RolloutStatus_ROLLOUT_STATUS_IN_PROGRESS,
+					Message:     `Waiting for deployment "rolling" rollout to finish: 1 out of 3 new replicas have been updated...`,
+				},
+				{
+					ResourceRef: deploymentRef("rolling"),
+					Status:      v1alpha1.RolloutStatus_ROLLOUT_STATUS_FAILED,
+					Message:     `deployment "rolling" was deleted`,
+					Reason:      "Deleted",
+				},
+			},
+		},
+		{
+			name:           "it does not watch workloads which are already rolled out",
+			watch:          true,
+			resourceRefs:   []*pkgsGRPCv1alpha1.ResourceRef{deploymentRef("done")},
+			clusterObjects: []runtime.Object{newDeployment(t, "done", 3, 3)},
+			expectedResponses: []*v1alpha1.GetRolloutStatusResponse{
+				{
+					ResourceRef: deploymentRef("done"),
+					Status:      v1alpha1.RolloutStatus_ROLLOUT_STATUS_SUCCEEDED,
+					Message:     `deployment "done" successfully rolled out`,
+				},
+			},
+		},
+	}
+
+	ignoredUnexported := cmpopts.IgnoreUnexported(
+		v1alpha1.GetRolloutStatusResponse{},
+		pkgsGRPCv1alpha1.ResourceRef{},
+	)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeDynamicClient := dynfake.NewSimpleDynamicClient(runtime.NewScheme(), tc.clusterObjects...)
+			fakeWatcher := watch.NewFake()
+			fakeDynamicClient.PrependWatchReactor("deployments", k8stesting.DefaultWatchReactor(fakeWatcher, nil))
+
+			s := &Server{
+				clientGetter: clientgetter.NewBuilder().
+					WithDynamic(fakeDynamicClient).
+					Build(),
+				corePackagesClientGetter: func() (pkgsConnectV1alpha1.PackagesServiceClient, error) {
+					return &fakeCorePackagesClient{resourceRefs: tc.resourceRefs}, nil
+				},
+				kindToResource: func(mapper meta.RESTMapper, gvk schema.GroupVersionKind) (schema.GroupVersionResource, meta.RESTScopeName, error) {
+					gvr, _ := meta.UnsafeGuessKindToResource(gvk)
+					return gvr, meta.RESTScopeNameNamespace, nil
+				},
+			}
+			_, handler := v1alpha1connect.NewResourcesServiceHandler(s)
+			server := httptest.NewServer(handler)
+			defer server.Close()
+			client := v1alpha1connect.NewResourcesServiceClient(server.Client(), server.URL)
+
+			stream, err := client.GetRolloutStatus(context.Background(), connect.NewRequest(&v1alpha1.GetRolloutStatusRequest{
+				InstalledPackageRef: &pkgsGRPCv1alpha1.InstalledPackageReference{
+					Context:    &pkgsGRPCv1alpha1.Context{Cluster: "default", Namespace: "default"},
+					Identifier: "my-package",
+				},
+				Watch: tc.watch,
+			}))
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			defer stream.Close()
+
+			responses := []*v1alpha1.GetRolloutStatusResponse{}
+			for stream.Receive() {
+				responses = append(responses, stream.Msg())
+				// Once the initial status has been received, the updates are sent.
+				if len(tc.watchEvents) > 0 {
+					go func(events []watch.Event) {
+						for _, e := range events {
+							fakeWatcher.Action(e.Type, e.Object)
+						}
+					}(tc.watchEvents)
+					tc.watchEvents = nil
+				}
+			}
+			if err = stream.Err(); err != nil {
+				t.Fatalf("%+v", err)
+			}
+
+			if got, want := responses, tc.expectedResponses; !cmp.Equal(want, got, ignoredUnexported) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoredUnexported))
+			}
+		})
+	}
+}
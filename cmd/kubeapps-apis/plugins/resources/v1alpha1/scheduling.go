@@ -0,0 +1,340 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bufbuild/connect-go"
+	pkgsGRPCv1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/plugins/resources/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/connecterror"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+	log "k8s.io/klog/v2"
+)
+
+// failedSchedulingReason is the reason of the events emitted by the scheduler
+// when a pod cannot be scheduled.
+const failedSchedulingReason = "FailedScheduling"
+
+// podControllerKinds are the workloads whose pods are selected with the
+// label selector of their spec.
+var podControllerKinds = map[schema.GroupKind]bool{
+	{Group: "apps", Kind: "Deployment"}:  true,
+	{Group: "apps", Kind: "StatefulSet"}: true,
+	{Group: "apps", Kind: "DaemonSet"}:   true,
+	{Group: "apps", Kind: "ReplicaSet"}:  true,
+	{Group: "batch", Kind: "Job"}:        true,
+}
+
+// GetSchedulingInfo returns, for each pod of an installed package, the node it
+// is placed on together with the constraints of its placement and, when it is
+// pending, why.
+func (s *Server) GetSchedulingInfo(ctx context.Context, r *connect.Request[v1alpha1.GetSchedulingInfoRequest]) (*connect.Response[v1alpha1.GetSchedulingInfoResponse], error) {
+	namespace := r.Msg.GetInstalledPackageRef().GetContext().GetNamespace()
+	cluster := r.Msg.GetInstalledPackageRef().GetContext().GetCluster()
+	log.InfoS("+resources GetSchedulingInfo ", "cluster", cluster, "namespace", namespace)
+
+	pkgResourceRefs, err := s.getInstalledPackageResourceRefs(ctx, r.Header(), r.Msg.GetInstalledPackageRef())
+	if err != nil {
+		return nil, err
+	}
+
+	dynamicClient, err := s.clientGetter.Dynamic(r.Header(), cluster)
+	if err != nil {
+		return nil, err
+	}
+	typedClient, err := s.clientGetter.Typed(r.Header(), cluster)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to get the k8s client: '%w'", err))
+	}
+
+	infos := []*v1alpha1.PodSchedulingInfo{}
+	seenPods := map[string]bool{}
+	addPod := func(pod *corev1.Pod, workloadRef *pkgsGRPCv1alpha1.ResourceRef) {
+		key := pod.Namespace + "/" + pod.Name
+		if seenPods[key] {
+			return
+		}
+		seenPods[key] = true
+		infos = append(infos, podSchedulingInfo(pod, workloadRef))
+	}
+
+	for _, ref := range pkgResourceRefs {
+		gvk, err := resourceRefGVK(ref)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case gvk.GroupKind() == (schema.GroupKind{Kind: "Pod"}):
+			pod, err := typedClient.CoreV1().Pods(ref.Namespace).Get(ctx, ref.GetName(), metav1.GetOptions{})
+			if err != nil {
+				return nil, connecterror.FromK8sError("get", "Pod", ref.GetName(), err)
+			}
+			addPod(pod, nil)
+		case podControllerKinds[gvk.GroupKind()]:
+			resourceClient, err := s.resourceClient(dynamicClient, gvk, ref.Namespace)
+			if err != nil {
+				return nil, err
+			}
+			obj, err := resourceClient.Get(ctx, ref.GetName(), metav1.GetOptions{})
+			if err != nil {
+				return nil, connecterror.FromK8sError("get", ref.Kind, ref.GetName(), err)
+			}
+			selector, err := workloadPodSelector(obj)
+			if err != nil {
+				return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to get the pod selector of %s %q: %w", ref.Kind, ref.GetName(), err))
+			}
+			if selector == "" {
+				// An empty selector would match every pod of the namespace.
+				continue
+			}
+			pods, err := typedClient.CoreV1().Pods(ref.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+			if err != nil {
+				return nil, connecterror.FromK8sError("list", "Pods", "", err)
+			}
+			sort.Slice(pods.Items, func(i, j int) bool { return pods.Items[i].Name < pods.Items[j].Name })
+			for i := range pods.Items {
+				addPod(&pods.Items[i], ref)
+			}
+		}
+	}
+
+	// The scheduler only reports why a pod cannot be scheduled in events.
+	if err = addFailedSchedulingReasons(ctx, typedClient, infos); err != nil {
+		return nil, err
+	}
+
+	return connect.NewResponse(&v1alpha1.GetSchedulingInfoResponse{
+		Pods: infos,
+	}), nil
+}
+
+// workloadPodSelector returns the label selector of the pods of a workload, in
+// its string form.
+func workloadPodSelector(obj *unstructured.Unstructured) (string, error) {
+	selectorContent, found, err := unstructured.NestedMap(obj.Object, "spec", "selector")
+	if err != nil || !found {
+		return "", err
+	}
+	var labelSelector metav1.LabelSelector
+	if err = runtime.DefaultUnstructuredConverter.FromUnstructured(selectorContent, &labelSelector); err != nil {
+		return "", err
+	}
+	selector, err := metav1.LabelSelectorAsSelector(&labelSelector)
+	if err != nil {
+		return "", err
+	}
+	return selector.String(), nil
+}
+
+// podSchedulingInfo returns the placement of a pod and, when it is pending, why.
+func podSchedulingInfo(pod *corev1.Pod, workloadRef *pkgsGRPCv1alpha1.ResourceRef) *v1alpha1.PodSchedulingInfo {
+	info := &v1alpha1.PodSchedulingInfo{
+		PodRef: &pkgsGRPCv1alpha1.ResourceRef{
+			ApiVersion: "v1",
+			Kind:       "Pod",
+			Name:       pod.Name,
+			Namespace:  pod.Namespace,
+		},
+		WorkloadRef:    workloadRef,
+		Phase:          string(pod.Status.Phase),
+		Scheduled:      pod.Spec.NodeName != "",
+		NodeName:       pod.Spec.NodeName,
+		NodeSelector:   pod.Spec.NodeSelector,
+		Tolerations:    []string{},
+		Affinity:       affinitySummary(pod.Spec.Affinity),
+		PendingReasons: []string{},
+	}
+	for _, toleration := range pod.Spec.Tolerations {
+		info.Tolerations = append(info.Tolerations, tolerationSummary(toleration))
+	}
+
+	if pod.Status.Phase != corev1.PodPending {
+		return info
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodScheduled && condition.Status == corev1.ConditionFalse && condition.Message != "" {
+			info.PendingReasons = append(info.PendingReasons, condition.Message)
+		}
+	}
+	statuses := append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...)
+	for _, status := range append(statuses, pod.Status.ContainerStatuses...) {
+		if waiting := status.State.Waiting; waiting != nil && waiting.Reason != "" {
+			reason := fmt.Sprintf("container %q is waiting: %s", status.Name, waiting.Reason)
+			if waiting.Message != "" {
+				reason = fmt.Sprintf("%s: %s", reason, waiting.Message)
+			}
+			info.PendingReasons = append(info.PendingReasons, reason)
+		}
+	}
+	return info
+}
+
+// addFailedSchedulingReasons adds the messages of the FailedScheduling events
+// of the unscheduled pods to their pending reasons, from the most recent.
+func addFailedSchedulingReasons(ctx context.Context, typedClient kubernetes.Interface, infos []*v1alpha1.PodSchedulingInfo) error {
+	unscheduled := map[string][]*v1alpha1.PodSchedulingInfo{}
+	for _, info := range infos {
+		if !info.Scheduled {
+			unscheduled[info.PodRef.Namespace] = append(unscheduled[info.PodRef.Namespace], info)
+		}
+	}
+
+	for namespace, namespaceInfos := range unscheduled {
+		events, err := typedClient.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{
+			FieldSelector: fmt.Sprintf("involvedObject.kind=Pod,reason=%s", failedSchedulingReason),
+		})
+		if err != nil {
+			return connecterror.FromK8sError("list", "Events", "", err)
+		}
+		sort.SliceStable(events.Items, func(i, j int) bool {
+			return eventTime(events.Items[i]).After(eventTime(events.Items[j]).Time)
+		})
+
+		for _, info := range namespaceInfos {
+			for _, event := range events.Items {
+				if event.InvolvedObject.Kind != "Pod" || event.InvolvedObject.Name != info.PodRef.Name || event.Reason != failedSchedulingReason {
+					continue
+				}
+				if !contains(info.PendingReasons, event.Message) {
+					info.PendingReasons = append(info.PendingReasons, event.Message)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// eventTime returns the last time an event was observed.
+func eventTime(event corev1.Event) metav1.Time {
+	if !event.LastTimestamp.IsZero() {
+		return event.LastTimestamp
+	}
+	if event.Series != nil {
+		return metav1.NewTime(event.Series.LastObservedTime.Time)
+	}
+	if !event.EventTime.IsZero() {
+		return metav1.NewTime(event.EventTime.Time)
+	}
+	return event.FirstTimestamp
+}
+
+// tolerationSummary returns a toleration using the same wording as
+// `kubectl describe pod`.
+func tolerationSummary(toleration corev1.Toleration) string {
+	summary := toleration.Key
+	if toleration.Value != "" {
+		summary += "=" + toleration.Value
+	}
+	if toleration.Effect != "" {
+		summary += ":" + string(toleration.Effect)
+	}
+	if toleration.Operator == corev1.TolerationOpExists && toleration.Value == "" {
+		summary += " op=Exists"
+	}
+	if toleration.TolerationSeconds != nil {
+		summary += fmt.Sprintf(" for %ds", *toleration.TolerationSeconds)
+	}
+	return strings.TrimSpace(summary)
+}
+
+// affinitySummary returns a summary of each term of the node affinity, pod
+// affinity and pod anti-affinity of a pod.
+func affinitySummary(affinity *corev1.Affinity) []string {
+	summary := []string{}
+	if affinity == nil {
+		return summary
+	}
+
+	if nodeAffinity := affinity.NodeAffinity; nodeAffinity != nil {
+		if required := nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution; required != nil {
+			for _, term := range required.NodeSelectorTerms {
+				summary = append(summary, fmt.Sprintf("required node affinity: %s", nodeSelectorTermSummary(term)))
+			}
+		}
+		for _, preferred := range nodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution {
+			summary = append(summary, fmt.Sprintf("preferred node affinity (weight %d): %s", preferred.Weight, nodeSelectorTermSummary(preferred.Preference)))
+		}
+	}
+
+	if podAffinity := affinity.PodAffinity; podAffinity != nil {
+		summary = append(summary, podAffinitySummary("pod affinity", podAffinity.RequiredDuringSchedulingIgnoredDuringExecution, podAffinity.PreferredDuringSchedulingIgnoredDuringExecution)...)
+	}
+	if podAntiAffinity := affinity.PodAntiAffinity; podAntiAffinity != nil {
+		summary = append(summary, podAffinitySummary("pod anti-affinity", podAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution, podAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution)...)
+	}
+	return summary
+}
+
+// nodeSelectorTermSummary returns the requirements of a node selector term
+// using the label selector syntax, such as `kubernetes.io/arch in (amd64)`.
+func nodeSelectorTermSummary(term corev1.NodeSelectorTerm) string {
+	requirements := []string{}
+	for _, expression := range term.MatchExpressions {
+		requirements = append(requirements, nodeSelectorRequirementSummary(expression))
+	}
+	for _, field := range term.MatchFields {
+		requirements = append(requirements, nodeSelectorRequirementSummary(field))
+	}
+	return strings.Join(requirements, ",")
+}
+
+func nodeSelectorRequirementSummary(requirement corev1.NodeSelectorRequirement) string {
+	switch requirement.Operator {
+	case corev1.NodeSelectorOpIn:
+		return fmt.Sprintf("%s in (%s)", requirement.Key, strings.Join(requirement.Values, ","))
+	case corev1.NodeSelectorOpNotIn:
+		return fmt.Sprintf("%s notin (%s)", requirement.Key, strings.Join(requirement.Values, ","))
+	case corev1.NodeSelectorOpExists:
+		return requirement.Key
+	case corev1.NodeSelectorOpDoesNotExist:
+		return "!" + requirement.Key
+	case corev1.NodeSelectorOpGt:
+		return fmt.Sprintf("%s>%s", requirement.Key, strings.Join(requirement.Values, ","))
+	case corev1.NodeSelectorOpLt:
+		return fmt.Sprintf("%s<%s", requirement.Key, strings.Join(requirement.Values, ","))
+	default:
+		return fmt.Sprintf("%s %s (%s)", requirement.Key, requirement.Operator, strings.Join(requirement.Values, ","))
+	}
+}
+
+func podAffinitySummary(name string, required []corev1.PodAffinityTerm, preferred []corev1.WeightedPodAffinityTerm) []string {
+	summary := []string{}
+	for _, term := range required {
+		summary = append(summary, fmt.Sprintf("required %s: %s", name, podAffinityTermSummary(term)))
+	}
+	for _, weighted := range preferred {
+		summary = append(summary, fmt.Sprintf("preferred %s (weight %d): %s", name, weighted.Weight, podAffinityTermSummary(weighted.PodAffinityTerm)))
+	}
+	return summary
+}
+
+// podAffinityTermSummary returns the pods selected by a pod affinity term and
+// its topology, such as `app=db in topology kubernetes.io/hostname`.
+func podAffinityTermSummary(term corev1.PodAffinityTerm) string {
+	summary := fmt.Sprintf("%s in topology %s", metav1.FormatLabelSelector(term.LabelSelector), term.TopologyKey)
+	if len(term.Namespaces) > 0 {
+		summary += fmt.Sprintf(" of namespaces %s", strings.Join(term.Namespaces, ","))
+	}
+	return summary
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,277 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	pkgsGRPCv1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	pkgsConnectV1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1/v1alpha1connect"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/plugins/resources/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/clientgetter"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynfake "k8s.io/client-go/dynamic/fake"
+	typfake "k8s.io/client-go/kubernetes/fake"
+)
+
+func newSelectingDeployment(t *testing.T, name string, matchLabels map[string]string) *unstructured.Unstructured {
+	deployment := newDeployment(t, name, 1, 1)
+	unstructured.RemoveNestedField(deployment.Object, "spec", "selector")
+	if err := unstructured.SetNestedStringMap(deployment.Object, matchLabels, "spec", "selector", "matchLabels"); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	return deployment
+}
+
+func newPod(name string, labels map[string]string, nodeName string, phase core.PodPhase) *core.Pod {
+	return &core.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: labels},
+		Spec:       core.PodSpec{NodeName: nodeName},
+		Status:     core.PodStatus{Phase: phase},
+	}
+}
+
+func newFailedSchedulingEvent(name, podName, message string, lastTimestamp time.Time) *core.Event {
+	return &core.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: name, Namespace: "default"},
+		InvolvedObject: core.ObjectReference{Kind: "Pod", Name: podName, Namespace: "default"},
+		Reason:         "FailedScheduling",
+		Message:        message,
+		LastTimestamp:  metav1.NewTime(lastTimestamp),
+	}
+}
+
+func TestGetSchedulingInfo(t *testing.T) {
+	deploymentRef := &pkgsGRPCv1alpha1.ResourceRef{ApiVersion: "apps/v1", Kind: "Deployment", Name: "web", Namespace: "default"}
+	podRef := func(name string) *pkgsGRPCv1alpha1.ResourceRef {
+		return &pkgsGRPCv1alpha1.ResourceRef{ApiVersion: "v1", Kind: "Pod", Name: name, Namespace: "default"}
+	}
+	webLabels := map[string]string{"app": "web"}
+	now := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name           string
+		resourceRefs   []*pkgsGRPCv1alpha1.ResourceRef
+		dynamicObjects []runtime.Object
+		typedObjects   []runtime.Object
+		expectedPods   []*v1alpha1.PodSchedulingInfo
+	}{
+		{
+			name:           "it returns the node of the pods selected by a workload",
+			resourceRefs:   []*pkgsGRPCv1alpha1.ResourceRef{deploymentRef},
+			dynamicObjects: []runtime.Object{newSelectingDeployment(t, "web", webLabels)},
+			typedObjects: []runtime.Object{
+				newPod("web-2", webLabels, "node-2", core.PodRunning),
+				newPod("web-1", webLabels, "node-1", core.PodRunning),
+				newPod("other", map[string]string{"app": "other"}, "node-1", core.PodRunning),
+			},
+			expectedPods: []*v1alpha1.PodSchedulingInfo{
+				{
+					PodRef:         podRef("web-1"),
+					WorkloadRef:    deploymentRef,
+					Phase:          "Running",
+					Scheduled:      true,
+					NodeName:       "node-1",
+					Tolerations:    []string{},
+					Affinity:       []string{},
+					PendingReasons: []string{},
+				},
+				{
+					PodRef:         podRef("web-2"),
+					WorkloadRef:    deploymentRef,
+					Phase:          "Running",
+					Scheduled:      true,
+					NodeName:       "node-2",
+					Tolerations:    []string{},
+					Affinity:       []string{},
+					PendingReasons: []string{},
+				},
+			},
+		},
+		{
+			name:         "it returns why an unschedulable pod is pending, from the most recent",
+			resourceRefs: []*pkgsGRPCv1alpha1.ResourceRef{podRef("web-1")},
+			typedObjects: []runtime.Object{
+				func() *core.Pod {
+					pod := newPod("web-1", webLabels, "", core.PodPending)
+					pod.Spec.NodeSelector = map[string]string{"disktype": "ssd"}
+					pod.Status.Conditions = []core.PodCondition{{
+						Type:    core.PodScheduled,
+						Status:  core.ConditionFalse,
+						Reason:  core.PodReasonUnschedulable,
+						Message: "0/3 nodes are available: 3 Insufficient cpu.",
+					}}
+					return pod
+				}(),
+				newFailedSchedulingEvent("web-1.1", "web-1", "0/3 nodes are available: 3 node(s) didn't match Pod's node affinity/selector.", now.Add(-time.Minute)),
+				newFailedSchedulingEvent("web-1.2", "web-1", "0/3 nodes are available: 3 Insufficient cpu.", now),
+				newFailedSchedulingEvent("web-2.1", "web-2", "0/3 nodes are available: 3 Insufficient memory.", now),
+			},
+			expectedPods: []*v1alpha1.PodSchedulingInfo{
+				{
+					PodRef:       podRef("web-1"),
+					Phase:        "Pending",
+					NodeSelector: map[string]string{"disktype": "ssd"},
+					Tolerations:  []string{},
+					Affinity:     []string{},
+					PendingReasons: []string{
+						"0/3 nodes are available: 3 Insufficient cpu.",
+						"0/3 nodes are available: 3 node(s) didn't match Pod's node affinity/selector.",
+					},
+				},
+			},
+		},
+		{
+			name:         "it returns the waiting containers of a scheduled pending pod",
+			resourceRefs: []*pkgsGRPCv1alpha1.ResourceRef{podRef("web-1")},
+			typedObjects: []runtime.Object{
+				func() *core.Pod {
+					pod := newPod("web-1", webLabels, "node-1", core.PodPending)
+					pod.Status.ContainerStatuses = []core.ContainerStatus{{
+						Name: "web",
+						State: core.ContainerState{Waiting: &core.ContainerStateWaiting{
+							Reason:  "ImagePullBackOff",
+							Message: `Back-off pulling image "web:1.0"`,
+						}},
+					}}
+					return pod
+				}(),
+			},
+			expectedPods: []*v1alpha1.PodSchedulingInfo{
+				{
+					PodRef:         podRef("web-1"),
+					Phase:          "Pending",
+					Scheduled:      true,
+					NodeName:       "node-1",
+					Tolerations:    []string{},
+					Affinity:       []string{},
+					PendingReasons: []string{`container "web" is waiting: ImagePullBackOff: Back-off pulling image "web:1.0"`},
+				},
+			},
+		},
+		{
+			name:         "it summarizes the tolerations and affinity of the pods",
+			resourceRefs: []*pkgsGRPCv1alpha1.ResourceRef{podRef("web-1")},
+			typedObjects: []runtime.Object{
+				func() *core.Pod {
+					pod := newPod("web-1", webLabels, "node-1", core.PodRunning)
+					tolerationSeconds := int64(300)
+					pod.Spec.Tolerations = []core.Toleration{
+						{Key: "dedicated", Operator: core.TolerationOpEqual, Value: "web", Effect: core.TaintEffectNoSchedule},
+						{Key: "node.kubernetes.io/not-ready", Operator: core.TolerationOpExists, Effect: core.TaintEffectNoExecute, TolerationSeconds: &tolerationSeconds},
+					}
+					pod.Spec.Affinity = &core.Affinity{
+						NodeAffinity: &core.NodeAffinity{
+							RequiredDuringSchedulingIgnoredDuringExecution: &core.NodeSelector{
+								NodeSelectorTerms: []core.NodeSelectorTerm{{
+									MatchExpressions: []core.NodeSelectorRequirement{
+										{Key: "kubernetes.io/arch", Operator: core.NodeSelectorOpIn, Values: []string{"amd64", "arm64"}},
+										{Key: "spot", Operator: core.NodeSelectorOpDoesNotExist},
+									},
+								}},
+							},
+						},
+						PodAntiAffinity: &core.PodAntiAffinity{
+							PreferredDuringSchedulingIgnoredDuringExecution: []core.WeightedPodAffinityTerm{{
+								Weight: 100,
+								PodAffinityTerm: core.PodAffinityTerm{
+									LabelSelector: &metav1.LabelSelector{MatchLabels: webLabels},
+									TopologyKey:   "kubernetes.io/hostname",
+								},
+							}},
+						},
+					}
+					return pod
+				}(),
+			},
+			expectedPods: []*v1alpha1.PodSchedulingInfo{
+				{
+					PodRef:    podRef("web-1"),
+					Phase:     "Running",
+					Scheduled: true,
+					NodeName:  "node-1",
+					Tolerations: []string{
+						"dedicated=web:NoSchedule",
+						"node.kubernetes.io/not-ready:NoExecute op=Exists for 300s",
+					},
+					Affinity: []string{
+						"required node affinity: kubernetes.io/arch in (amd64,arm64),!spot",
+						"preferred pod anti-affinity (weight 100): app=web in topology kubernetes.io/hostname",
+					},
+					PendingReasons: []string{},
+				},
+			},
+		},
+		{
+			name:           "it returns each pod once when referenced directly and by a workload",
+			resourceRefs:   []*pkgsGRPCv1alpha1.ResourceRef{deploymentRef, podRef("web-1")},
+			dynamicObjects: []runtime.Object{newSelectingDeployment(t, "web", webLabels)},
+			typedObjects:   []runtime.Object{newPod("web-1", webLabels, "node-1", core.PodRunning)},
+			expectedPods: []*v1alpha1.PodSchedulingInfo{
+				{
+					PodRef:         podRef("web-1"),
+					WorkloadRef:    deploymentRef,
+					Phase:          "Running",
+					Scheduled:      true,
+					NodeName:       "node-1",
+					Tolerations:    []string{},
+					Affinity:       []string{},
+					PendingReasons: []string{},
+				},
+			},
+		},
+		{
+			name:         "it ignores the resources which are not pods or workloads",
+			resourceRefs: []*pkgsGRPCv1alpha1.ResourceRef{{ApiVersion: "v1", Kind: "Service", Name: "web", Namespace: "default"}},
+			expectedPods: []*v1alpha1.PodSchedulingInfo{},
+		},
+	}
+
+	ignoredUnexported := cmpopts.IgnoreUnexported(
+		v1alpha1.PodSchedulingInfo{},
+		pkgsGRPCv1alpha1.ResourceRef{},
+	)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := &Server{
+				clientGetter: clientgetter.NewBuilder().
+					WithDynamic(dynfake.NewSimpleDynamicClient(runtime.NewScheme(), tc.dynamicObjects...)).
+					WithTyped(typfake.NewSimpleClientset(tc.typedObjects...)).
+					Build(),
+				corePackagesClientGetter: func() (pkgsConnectV1alpha1.PackagesServiceClient, error) {
+					return &fakeCorePackagesClient{resourceRefs: tc.resourceRefs}, nil
+				},
+				kindToResource: func(mapper meta.RESTMapper, gvk schema.GroupVersionKind) (schema.GroupVersionResource, meta.RESTScopeName, error) {
+					gvr, _ := meta.UnsafeGuessKindToResource(gvk)
+					return gvr, meta.RESTScopeNameNamespace, nil
+				},
+			}
+
+			response, err := s.GetSchedulingInfo(context.Background(), connect.NewRequest(&v1alpha1.GetSchedulingInfoRequest{
+				InstalledPackageRef: &pkgsGRPCv1alpha1.InstalledPackageReference{
+					Context:    &pkgsGRPCv1alpha1.Context{Cluster: "default", Namespace: "default"},
+					Identifier: "my-package",
+				},
+			}))
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+
+			if got, want := response.Msg.GetPods(), tc.expectedPods; !cmp.Equal(want, got, ignoredUnexported) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoredUnexported))
+			}
+		})
+	}
+}
@@ -9,10 +9,12 @@ import (
 	"fmt"
 	"net/http"
 	"sync"
+	"time"
 
 	"github.com/bufbuild/connect-go"
 	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/clientgetter"
 	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/connecterror"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/pkg/resources"
 	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/plugins/resources/v1alpha1/common"
 	"github.com/vmware-tanzu/kubeapps/pkg/kube"
 
@@ -22,6 +24,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/restmapper"
 	log "k8s.io/klog/v2"
@@ -63,6 +66,10 @@ type Server struct {
 	// pluginConfig Resources plugin configuration values
 	pluginConfig *common.ResourcesPluginConfig
 
+	// accessReviewCache caches the outcome of the access reviews of the CanI
+	// requests of the users. Nil disables the cache.
+	accessReviewCache *resources.AccessReviewCache
+
 	clientQPS float32
 
 	kubeappsCluster string
@@ -149,9 +156,10 @@ func NewServer(configGetter core.KubernetesConfigGetter, clientQPS float32, clie
 			}
 			return mapping.Resource, mapping.Scope.Name(), nil
 		},
-		clientQPS:       clientQPS,
-		pluginConfig:    pluginConfig,
-		kubeappsCluster: clustersConfig.KubeappsClusterName,
+		clientQPS:         clientQPS,
+		pluginConfig:      pluginConfig,
+		accessReviewCache: resources.NewAccessReviewCache(time.Duration(pluginConfig.AccessReviewCacheTTLSeconds) * time.Second),
+		kubeappsCluster:   clustersConfig.KubeappsClusterName,
 	}, nil
 }
 
@@ -190,7 +198,7 @@ func setupRestConfigForCluster(restConfig *rest.Config, cluster string, clusters
 	if cluster == clustersConfig.KubeappsClusterName {
 		log.Infof("Kubeapps cluster, should already have correct token for service acc: %q", restConfig.BearerTokenFile)
 	} else {
-		additionalCluster, ok := clustersConfig.Clusters[cluster]
+		additionalCluster, ok := clustersConfig.Cluster(cluster)
 		if !ok {
 			return connect.NewError(connect.CodeInternal, fmt.Errorf("Cluster %q has no configuration", cluster))
 		}
@@ -208,21 +216,8 @@ func (s *Server) GetResources(ctx context.Context, r *connect.Request[v1alpha1.G
 	log.InfoS("+resources GetResources ", "cluster", cluster, "namespace", namespace)
 
 	// First we grab the resource references for the specified installed package.
-	coreClient, err := s.corePackagesClientGetter()
-	if err != nil {
-		log.Errorf("Unable to create core packages client: %+v", err)
-		return err
-	}
-
-	newRequest := connect.NewRequest(&pkgsGRPCv1alpha1.GetInstalledPackageResourceRefsRequest{
-		InstalledPackageRef: r.Msg.InstalledPackageRef,
-	})
-	newRequest.Header().Set("Authorization", r.Header().Get("Authorization"))
-
-	refsResponse, err := coreClient.GetInstalledPackageResourceRefs(ctx, newRequest)
-
+	pkgResourceRefs, err := s.getInstalledPackageResourceRefs(ctx, r.Header(), r.Msg.GetInstalledPackageRef())
 	if err != nil {
-		log.Errorf("Unable to query core packages client for installed package resource refs: %+v", err)
 		return err
 	}
 	var resourcesToReturn []*pkgsGRPCv1alpha1.ResourceRef
@@ -233,11 +228,11 @@ func (s *Server) GetResources(ctx context.Context, r *connect.Request[v1alpha1.G
 		if r.Msg.GetWatch() {
 			return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Resource refs must be specified in request when watching resources"))
 		}
-		resourcesToReturn = refsResponse.Msg.GetResourceRefs()
+		resourcesToReturn = pkgResourceRefs
 	} else {
 		for _, requestedRef := range r.Msg.GetResourceRefs() {
 			found := false
-			for _, pkgRef := range refsResponse.Msg.GetResourceRefs() {
+			for _, pkgRef := range pkgResourceRefs {
 				if resourceRefsEqual(pkgRef, requestedRef) {
 					found = true
 					break
@@ -257,26 +252,20 @@ func (s *Server) GetResources(ctx context.Context, r *connect.Request[v1alpha1.G
 	}
 	var watchers []*ResourceWatcher
 	for _, ref := range resourcesToReturn {
-		groupVersion, err := schema.ParseGroupVersion(ref.ApiVersion)
+		gvk, err := resourceRefGVK(ref)
 		if err != nil {
-			return connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to parse group version from %q: %w", ref.ApiVersion, err))
+			return err
 		}
-		gvk := groupVersion.WithKind(ref.Kind)
 
 		// We need to get or watch a different endpoint depending on
 		// the scope of the resource (namespaced or not).
-		gvr, scopeName, err := s.kindToResource(s.restMapper, gvk)
+		resourceClient, err := s.resourceClient(dynamicClient, gvk, ref.Namespace)
 		if err != nil {
-			return connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to map group-kind %v to resource: %w", gvk.GroupKind(), err))
+			return err
 		}
 
 		if !r.Msg.GetWatch() {
-			var resource interface{}
-			if scopeName == meta.RESTScopeNameNamespace {
-				resource, err = dynamicClient.Resource(gvr).Namespace(ref.Namespace).Get(ctx, ref.GetName(), metav1.GetOptions{})
-			} else {
-				resource, err = dynamicClient.Resource(gvr).Get(ctx, ref.GetName(), metav1.GetOptions{})
-			}
+			resource, err := resourceClient.Get(ctx, ref.GetName(), metav1.GetOptions{})
 			if err != nil {
 				return connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to get resource referenced by %+v: %w", ref, err))
 			}
@@ -288,15 +277,9 @@ func (s *Server) GetResources(ctx context.Context, r *connect.Request[v1alpha1.G
 			continue
 		}
 
-		listOptions := metav1.ListOptions{
+		watcher, err := resourceClient.Watch(ctx, metav1.ListOptions{
 			FieldSelector: fmt.Sprintf("metadata.name=%s", ref.GetName()),
-		}
-		var watcher watch.Interface
-		if scopeName == meta.RESTScopeNameNamespace {
-			watcher, err = dynamicClient.Resource(gvr).Namespace(ref.Namespace).Watch(ctx, listOptions)
-		} else {
-			watcher, err = dynamicClient.Resource(gvr).Watch(ctx, listOptions)
-		}
+		})
 		if err != nil {
 			log.Errorf("Unable to watch resource %v: %v", ref, err)
 			return connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to watch resource %v", ref))
@@ -353,6 +336,51 @@ func (s *Server) GetServiceAccountNames(ctx context.Context, r *connect.Request[
 
 }
 
+// getInstalledPackageResourceRefs queries the core packages API, with the
+// credentials of the original request, for the references to the resources
+// of the installed package.
+func (s *Server) getInstalledPackageResourceRefs(ctx context.Context, headers http.Header, installedPackageRef *pkgsGRPCv1alpha1.InstalledPackageReference) ([]*pkgsGRPCv1alpha1.ResourceRef, error) {
+	coreClient, err := s.corePackagesClientGetter()
+	if err != nil {
+		log.Errorf("Unable to create core packages client: %+v", err)
+		return nil, err
+	}
+
+	newRequest := connect.NewRequest(&pkgsGRPCv1alpha1.GetInstalledPackageResourceRefsRequest{
+		InstalledPackageRef: installedPackageRef,
+	})
+	newRequest.Header().Set("Authorization", headers.Get("Authorization"))
+
+	refsResponse, err := coreClient.GetInstalledPackageResourceRefs(ctx, newRequest)
+	if err != nil {
+		log.Errorf("Unable to query core packages client for installed package resource refs: %+v", err)
+		return nil, err
+	}
+	return refsResponse.Msg.GetResourceRefs(), nil
+}
+
+// resourceRefGVK returns the group, version and kind of a resource reference.
+func resourceRefGVK(ref *pkgsGRPCv1alpha1.ResourceRef) (schema.GroupVersionKind, error) {
+	groupVersion, err := schema.ParseGroupVersion(ref.ApiVersion)
+	if err != nil {
+		return schema.GroupVersionKind{}, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to parse group version from %q: %w", ref.ApiVersion, err))
+	}
+	return groupVersion.WithKind(ref.Kind), nil
+}
+
+// resourceClient returns the dynamic client of the resources of the given
+// group, version and kind, scoped to the namespace when they are namespaced.
+func (s *Server) resourceClient(dynamicClient dynamic.Interface, gvk schema.GroupVersionKind, namespace string) (dynamic.ResourceInterface, error) {
+	gvr, scopeName, err := s.kindToResource(s.restMapper, gvk)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Unable to map group-kind %v to resource: %w", gvk.GroupKind(), err))
+	}
+	if scopeName == meta.RESTScopeNameNamespace {
+		return dynamicClient.Resource(gvr).Namespace(namespace), nil
+	}
+	return dynamicClient.Resource(gvr), nil
+}
+
 // sendResourceData just DRYs up this functionality shared between requests to
 // watch or get resources.
 func sendResourceData(ref *pkgsGRPCv1alpha1.ResourceRef, obj interface{}, s *connect.ServerStream[v1alpha1.GetResourcesResponse]) error {
@@ -7,25 +7,65 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"os"
 	"reflect"
 	"strings"
 	"time"
 
 	"golang.org/x/net/http2"
 	"golang.org/x/net/http2/h2c"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 
+	"github.com/bufbuild/connect-go"
 	grpchealth "github.com/bufbuild/connect-grpchealth-go"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core/audit"
+	clustersv1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core/clusters/v1alpha1"
+	favoritesv1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core/favorites/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core/icons"
+	jobsv1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core/jobs/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core/metrics"
+	notificationsv1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core/notifications/v1alpha1"
+	packagesv1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core/packages/v1"
 	packagesv1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core/packages/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core/pins"
 	pluginsv1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core/plugins/v1alpha1"
+	preferencesv1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core/preferences/v1alpha1"
+	presetsv1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core/presets/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core/ratelimit"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core/repohealth"
+	searchv1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core/search/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core/tracing"
+	upgradepoliciesv1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core/upgradepolicies/v1alpha1"
+	"github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/core/validation"
+	clustersGRPCv1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/clusters/v1alpha1"
+	clustersConnect "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/clusters/v1alpha1/v1alpha1connect"
+	favoritesGRPCv1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/favorites/v1alpha1"
+	favoritesConnect "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/favorites/v1alpha1/v1alpha1connect"
+	jobsGRPCv1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/jobs/v1alpha1"
+	jobsConnect "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/jobs/v1alpha1/v1alpha1connect"
+	notificationsGRPCv1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/notifications/v1alpha1"
+	notificationsConnect "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/notifications/v1alpha1/v1alpha1connect"
+	packagesGRPCv1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1"
+	packagesConnectv1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1/v1connect"
 	packagesGRPCv1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
 	packagesConnect "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1/v1alpha1connect"
 	pluginsGRPCv1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/plugins/v1alpha1"
 	pluginsConnect "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/plugins/v1alpha1/v1alpha1connect"
+	preferencesGRPCv1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/preferences/v1alpha1"
+	preferencesConnect "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/preferences/v1alpha1/v1alpha1connect"
+	presetsGRPCv1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/presets/v1alpha1"
+	presetsConnect "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/presets/v1alpha1/v1alpha1connect"
+	searchGRPCv1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/search/v1alpha1"
+	searchConnect "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/search/v1alpha1/v1alpha1connect"
+	upgradepoliciesGRPCv1alpha1 "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/upgradepolicies/v1alpha1"
+	upgradepoliciesConnect "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/core/upgradepolicies/v1alpha1/v1alpha1connect"
+	resourcesConnect "github.com/vmware-tanzu/kubeapps/cmd/kubeapps-apis/gen/plugins/resources/v1alpha1/v1alpha1connect"
+	"github.com/vmware-tanzu/kubeapps/pkg/kube"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/status"
@@ -90,27 +130,181 @@ func Serve(serveOpts core.ServeOptions) error {
 		DialOptions: []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())},
 	}
 
+	shutdownTracing, err := tracing.Setup(ctx, tracing.Options{
+		OTLPEndpoint: serveOpts.TracingOTLPEndpoint,
+		OTLPInsecure: serveOpts.TracingOTLPInsecure,
+		SampleRatio:  serveOpts.TracingSampleRatio,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to initialize the tracing: %w", err)
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			log.Errorf("Failed to flush the traces: %v", err)
+		}
+	}()
+
 	mux := http.NewServeMux()
+	// The tracing interceptor goes first, so that the span covers the others.
+	interceptors := []connect.Interceptor{tracing.NewInterceptor(), metrics.NewInterceptor()}
+	// The throttled requests are rejected before being audited, but after being
+	// recorded in the metrics.
+	rateLimitOpts := ratelimit.Options{
+		RequestsPerSecond: serveOpts.RateLimitQPS,
+		Burst:             serveOpts.RateLimitBurst,
+		MaxConcurrent:     serveOpts.MaxConcurrentRequests,
+	}
+	if rateLimitOpts.Enabled() {
+		// The users are identified with the service account, since they may
+		// not be allowed to review their own token.
+		clientSet, err := serviceAccountClientSet()
+		if err != nil {
+			return fmt.Errorf("failed to initialize the rate limits: %v", err)
+		}
+		interceptors = append(interceptors, ratelimit.NewInterceptor(ratelimit.NewLimiter(rateLimitOpts), ratelimit.NewTokenReviewUserResolver(clientSet)))
+	}
+	if serveOpts.AuditLogEnabled || serveOpts.AuditWebhookURL != "" {
+		auditInterceptor, err := newAuditInterceptor(serveOpts)
+		if err != nil {
+			return fmt.Errorf("failed to initialize the audit log: %v", err)
+		}
+		interceptors = append(interceptors, auditInterceptor)
+	}
+	// The operations of the users are recorded once succeeded, if their
+	// outcome is notified or they are followed as jobs.
+	var operationsTracker *notificationsv1alpha1.Tracker
+	if serveOpts.NotificationsInterval > 0 || serveOpts.JobsRetention > 0 {
+		clientSet, err := serviceAccountClientSet()
+		if err != nil {
+			return fmt.Errorf("failed to initialize the operations tracker: %v", err)
+		}
+		operationsTracker = notificationsv1alpha1.NewTracker(clientSet)
+		interceptors = append(interceptors, operationsTracker.Interceptor())
+	}
+	// The installs and upgrades recorded by the tracker are stored as jobs,
+	// if enabled. As the favorites, the jobs are stored in the namespace
+	// where Kubeapps is installed, using the service account of
+	// kubeapps-apis.
+	var jobsStore *jobsv1alpha1.Store
+	if serveOpts.JobsRetention > 0 {
+		clientSet, err := serviceAccountClientSet()
+		if err != nil {
+			return fmt.Errorf("failed to initialize the jobs: %v", err)
+		}
+		jobsStore, err = jobsv1alpha1.NewStore(clientSet, os.Getenv("POD_NAMESPACE"), serveOpts.JobsRetention)
+		if err != nil {
+			return fmt.Errorf("failed to initialize the jobs: %v", err)
+		}
+		operationsTracker.OnRecord(jobsStore.Record)
+	}
+	// The invalid requests are rejected last, so that they are still audited.
+	interceptors = append(interceptors, validation.NewInterceptor())
+	// The installed packages are created, updated and rolled back within the
+	// package pins stored by the administrators in the namespace where
+	// Kubeapps is installed, which are read with the service account of
+	// kubeapps-apis. The requests to the core services and the plugins are
+	// intercepted, while the core services installing or updating packages
+	// on behalf of a request are given the checker.
+	pinsClientSet, err := serviceAccountClientSet()
+	if err != nil {
+		return fmt.Errorf("failed to initialize the package pins: %v", err)
+	}
+	pinsChecker, err := pins.NewChecker(pinsClientSet, os.Getenv("POD_NAMESPACE"))
+	if err != nil {
+		return fmt.Errorf("failed to initialize the package pins: %v", err)
+	}
+	interceptors = append(interceptors, pinsChecker.Interceptor())
+	handlerOpts := append(core.HandlerOptions(serveOpts), connect.WithInterceptors(interceptors...))
 
 	// Create the core.plugins.v1alpha1 server which handles registration of
-	// plugins, and register it for both grpc and http.
-	pluginsServer, err := pluginsv1alpha1.NewPluginsServer(serveOpts, gwArgs, mux)
+	// plugins, and register it for both grpc and http. The services of the
+	// plugins are registered with the same interceptors as the core ones, so
+	// that requesting them directly is also audited and rate limited.
+	pluginsServer, err := pluginsv1alpha1.NewPluginsServer(serveOpts, gwArgs, mux, handlerOpts...)
 	if err != nil {
 		return fmt.Errorf("failed to initialize plugins server: %v", err)
 	}
-	if err := registerPluginsServiceServer(mux, pluginsServer, gwArgs); err != nil {
+	if err := registerPluginsServiceServer(mux, pluginsServer, gwArgs, handlerOpts...); err != nil {
 		return fmt.Errorf("failed to register plugins server: %v", err)
 	}
-	if err := registerPackagesServiceServer(mux, pluginsServer, gwArgs); err != nil {
+	// The icons of the available packages are normalized, and the external
+	// ones proxied if a prefix at which the browsers reach this service is set.
+	iconNormalizer, err := icons.NewNormalizer(icons.Options{
+		MaxBytes:        serveOpts.IconsMaxBytes,
+		ProxyPrefix:     serveOpts.IconsProxyPrefix,
+		AllowedDomains:  serveOpts.IconsAllowedDomains,
+		AllowedNetworks: serveOpts.IconsAllowedNetworks,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to initialize the icons normalizer: %v", err)
+	}
+	if iconNormalizer.ProxyEnabled() {
+		mux.Handle(icons.ProxyPath, iconNormalizer)
+	}
+	packagesServer, err := registerPackagesServiceServer(mux, pluginsServer, iconNormalizer, pinsChecker, serveOpts.PluginTimeout, gwArgs, handlerOpts...)
+	if err != nil {
+		return err
+	}
+	repositoriesServer, err := registerRepositoriesServiceServer(mux, pluginsServer, gwArgs, handlerOpts...)
+	if err != nil {
+		return err
+	}
+	if err := registerPreferencesServiceServer(mux, gwArgs, handlerOpts...); err != nil {
+		return err
+	}
+	if err := registerFavoritesServiceServer(mux, gwArgs, handlerOpts...); err != nil {
+		return err
+	}
+	if err := registerPresetsServiceServer(mux, packagesServer, pinsChecker, gwArgs, handlerOpts...); err != nil {
+		return err
+	}
+	if err := registerUpgradePoliciesServiceServer(mux, packagesServer, serveOpts, gwArgs, handlerOpts...); err != nil {
+		return err
+	}
+	if err := registerClustersServiceServer(mux, pluginsServer, serveOpts, gwArgs, handlerOpts...); err != nil {
+		return err
+	}
+	// The status of the operations is followed with the token of the users
+	// watching their notifications, rather than the service account. The
+	// resources of the installed packages are watched through the stream of
+	// the resources plugin served by this server.
+	resourcesClient := resourcesConnect.NewResourcesServiceClient(http.DefaultClient, fmt.Sprintf("http://localhost:%d/", serveOpts.Port))
+	// The tracker may only be created for the jobs, in which case the
+	// notifications are disabled by passing no tracker.
+	var notificationsTracker *notificationsv1alpha1.Tracker
+	if serveOpts.NotificationsInterval > 0 {
+		notificationsTracker = operationsTracker
+	}
+	notificationsServer := notificationsv1alpha1.NewNotificationsServer(notificationsTracker, packagesServer, repositoriesServer, resourcesClient, serveOpts.NotificationsInterval)
+	if err := registerNotificationsServiceServer(mux, notificationsServer, gwArgs, handlerOpts...); err != nil {
+		return err
+	}
+	// The state of the jobs is refreshed with the token of the users
+	// requesting them, rather than the service account.
+	if err := registerJobsServiceServer(mux, jobsv1alpha1.NewJobsServer(jobsStore, packagesServer), gwArgs, handlerOpts...); err != nil {
 		return err
 	}
-	if err := registerRepositoriesServiceServer(mux, pluginsServer, gwArgs); err != nil {
+	// The available packages are indexed for the search with the service
+	// account, if enabled, and suggested to the users allowed to list them.
+	var searchCrawler *searchv1alpha1.Crawler
+	if serveOpts.SearchIndexInterval > 0 {
+		searchCrawler = searchv1alpha1.NewCrawler(packagesServer, pluginsServer.GetClusterNames, serveOpts.GlobalHelmReposNamespace, serveOpts.SearchIndexInterval)
+	}
+	if err := registerSearchServiceServer(mux, searchv1alpha1.NewSearchServer(searchCrawler, pluginsServer.ConfigGetter()), gwArgs, handlerOpts...); err != nil {
 		return err
 	}
 
 	// The gRPC Health checker reports on all connected services.
 	checker := grpchealth.NewStaticChecker(
 		pluginsConnect.PluginsServiceName,
+		preferencesConnect.PreferencesServiceName,
+		favoritesConnect.FavoritesServiceName,
+		presetsConnect.PresetsServiceName,
+		upgradepoliciesConnect.UpgradePoliciesServiceName,
+		notificationsConnect.NotificationsServiceName,
+		clustersConnect.ClustersServiceName,
+		searchConnect.SearchServiceName,
+		jobsConnect.JobsServiceName,
 	)
 	mux.Handle(grpchealth.NewHandler(checker))
 
@@ -119,6 +313,44 @@ func Serve(serveOpts core.ServeOptions) error {
 		gwArgs.Mux.ServeHTTP(w, r)
 	}))
 
+	// Serve the Prometheus metrics in a separate port, if configured,
+	// so that they are not exposed along with the API
+	if serveOpts.MetricsPort != 0 {
+		go serveMetrics(serveOpts.MetricsPort)
+	}
+
+	// Monitor the health of the package repositories in the background, if
+	// a webhook is configured to be notified
+	if serveOpts.RepoHealthWebhookURL != "" {
+		monitor, err := newRepoHealthMonitor(serveOpts)
+		if err != nil {
+			return fmt.Errorf("failed to initialize the repository health monitor: %v", err)
+		}
+		go monitor.Run(ctx)
+	}
+
+	// Upgrade the installed packages within the windows of their upgrade
+	// policies in the background, if enabled
+	if serveOpts.UpgradePoliciesInterval > 0 {
+		scheduler, err := newUpgradeScheduler(serveOpts, pluginsServer.ClustersConfig())
+		if err != nil {
+			return fmt.Errorf("failed to initialize the upgrade scheduler: %v", err)
+		}
+		go scheduler.Run(ctx)
+	}
+
+	// Follow the operations of the users watching their notifications in
+	// the background, if enabled
+	if notificationsTracker != nil {
+		go notificationsServer.Run(ctx)
+	}
+
+	// Index the available packages for the search in the background, if
+	// enabled
+	if searchCrawler != nil {
+		go searchCrawler.Run(ctx)
+	}
+
 	if serveOpts.UnsafeLocalDevKubeconfig {
 		log.Warning("Using the local Kubeconfig file instead of the actual in-cluster's config. This is not recommended except for development purposes.")
 	}
@@ -131,7 +363,21 @@ func Serve(serveOpts core.ServeOptions) error {
 	return nil
 }
 
-func registerPackagesServiceServer(mux *http.ServeMux, pluginsServer *pluginsv1alpha1.PluginsServer, gwArgs core.GatewayHandlerArgs) error {
+// serveMetrics serves the Prometheus metrics at /metrics in the given port.
+func serveMetrics(port int) {
+	metricsAddr := fmt.Sprintf(":%d", port)
+	metricsMux := http.NewServeMux()
+	metricsMux.Handle("/metrics", metrics.Handler())
+
+	log.Infof("Starting metrics server on %q", metricsAddr)
+	if err := http.ListenAndServe(metricsAddr, metricsMux); err != nil {
+		log.Fatalf("Failed to serve metrics: %+v", err)
+	}
+}
+
+// Registers the core.packages servers with the mux and gateway, returning the
+// core.packages.v1 server so that other core services can install packages.
+func registerPackagesServiceServer(mux *http.ServeMux, pluginsServer *pluginsv1alpha1.PluginsServer, iconNormalizer *icons.Normalizer, pinsChecker *pins.Checker, pluginTimeout time.Duration, gwArgs core.GatewayHandlerArgs, opts ...connect.HandlerOption) (packagesConnectv1.PackagesServiceHandler, error) {
 	// Ask the plugins server for plugins with GRPC servers that fulfil the core
 	// packaging v1alpha1 API, then pass to the constructor below.
 	// The argument for the reflect.TypeOf is based on what grpc-go
@@ -140,38 +386,307 @@ func registerPackagesServiceServer(mux *http.ServeMux, pluginsServer *pluginsv1a
 	packagingPlugins := pluginsServer.GetPluginsSatisfyingInterface(reflect.TypeOf((*packagesConnect.PackagesServiceHandler)(nil)).Elem())
 
 	// Create the core.packages server and register it for both grpc and http.
-	packagesServer, err := packagesv1alpha1.NewPackagesServer(packagingPlugins)
+	// Each plugin is given the same time to respond when aggregating the results
+	// of several plugins, recording their health in the plugins server.
+	packagesServer, err := packagesv1alpha1.NewPackagesServer(packagingPlugins, pluginsServer.GetClusterNames, iconNormalizer, pluginsServer.Health(), pluginTimeout)
 	if err != nil {
-		return fmt.Errorf("failed to create core.packages.v1alpha1 server: %w", err)
+		return nil, fmt.Errorf("failed to create core.packages.v1alpha1 server: %w", err)
 	}
 
-	mux.Handle(packagesConnect.NewPackagesServiceHandler(packagesServer))
+	// The core.packages.v1alpha1 API is deprecated but still served, so that
+	// existing clients keep working.
+	mux.Handle(packagesConnect.NewPackagesServiceHandler(packagesServer, deprecatedHandlerOpts(opts)...))
 
 	err = packagesGRPCv1alpha1.RegisterPackagesServiceHandlerFromEndpoint(gwArgs.Ctx, gwArgs.Mux, gwArgs.Addr, gwArgs.DialOptions)
 	if err != nil {
-		return fmt.Errorf("failed to register core.packages handler for gateway: %v", err)
+		return nil, fmt.Errorf("failed to register core.packages handler for gateway: %v", err)
 	}
-	return nil
+
+	// The stable core.packages.v1 API translates each request to the v1alpha1
+	// one, checking the package pins of its bulk updates and clones.
+	packagesServerv1 := packagesv1.NewPackagesServer(packagesServer, pinsChecker)
+	mux.Handle(packagesConnectv1.NewPackagesServiceHandler(packagesServerv1, opts...))
+
+	err = packagesGRPCv1.RegisterPackagesServiceHandlerFromEndpoint(gwArgs.Ctx, gwArgs.Mux, gwArgs.Addr, gwArgs.DialOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to register core.packages.v1 handler for gateway: %v", err)
+	}
+	return packagesServerv1, nil
 }
 
-func registerRepositoriesServiceServer(mux *http.ServeMux, pluginsServer *pluginsv1alpha1.PluginsServer, gwArgs core.GatewayHandlerArgs) error {
+// Registers the core.packages repositories servers with the mux and gateway,
+// returning the core.packages.v1 server so that other core services can get
+// the package repositories.
+func registerRepositoriesServiceServer(mux *http.ServeMux, pluginsServer *pluginsv1alpha1.PluginsServer, gwArgs core.GatewayHandlerArgs, opts ...connect.HandlerOption) (packagesConnectv1.RepositoriesServiceHandler, error) {
 	// see comment in registerPackagesServiceServer
 	repositoriesPlugins := pluginsServer.GetPluginsSatisfyingInterface(reflect.TypeOf((*packagesConnect.RepositoriesServiceHandler)(nil)).Elem())
 
 	// Create the core.packages server and register it for both grpc and http.
 	repoServer, err := packagesv1alpha1.NewRepositoriesServer(repositoriesPlugins)
 	if err != nil {
-		return fmt.Errorf("failed to create core.packages.v1alpha1 server: %w", err)
+		return nil, fmt.Errorf("failed to create core.packages.v1alpha1 server: %w", err)
 	}
-	mux.Handle(packagesConnect.NewRepositoriesServiceHandler(repoServer))
+	mux.Handle(packagesConnect.NewRepositoriesServiceHandler(repoServer, deprecatedHandlerOpts(opts)...))
 
 	err = packagesGRPCv1alpha1.RegisterRepositoriesServiceHandlerFromEndpoint(gwArgs.Ctx, gwArgs.Mux, gwArgs.Addr, gwArgs.DialOptions)
 	if err != nil {
-		return fmt.Errorf("failed to register core.packages handler for gateway: %v", err)
+		return nil, fmt.Errorf("failed to register core.packages handler for gateway: %v", err)
+	}
+
+	repoServerv1 := packagesv1.NewRepositoriesServer(repoServer)
+	mux.Handle(packagesConnectv1.NewRepositoriesServiceHandler(repoServerv1, opts...))
+
+	err = packagesGRPCv1.RegisterRepositoriesServiceHandlerFromEndpoint(gwArgs.Ctx, gwArgs.Mux, gwArgs.Addr, gwArgs.DialOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to register core.packages.v1 handler for gateway: %v", err)
+	}
+	return repoServerv1, nil
+}
+
+// deprecatedHandlerOpts returns the given handler options, adding the
+// interceptor flagging the responses of the deprecated core.packages.v1alpha1 API.
+func deprecatedHandlerOpts(opts []connect.HandlerOption) []connect.HandlerOption {
+	return append(append([]connect.HandlerOption{}, opts...), connect.WithInterceptors(packagesv1alpha1.NewDeprecationInterceptor()))
+}
+
+// serviceAccountClientSet returns a client authenticated as the kubeapps-apis
+// service account in the cluster where Kubeapps is installed.
+func serviceAccountClientSet() (kubernetes.Interface, error) {
+	svcRestConfig, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve in cluster configuration: %v", err)
+	}
+	clientSet, err := kubernetes.NewForConfig(svcRestConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve clientset: %v", err)
+	}
+	return clientSet, nil
+}
+
+// newAuditInterceptor returns the interceptor emitting the audit events to
+// the standard output and/or the webhook configured in the serve options.
+func newAuditInterceptor(serveOpts core.ServeOptions) (connect.Interceptor, error) {
+	sinks := []audit.Sink{}
+	if serveOpts.AuditLogEnabled {
+		sinks = append(sinks, audit.NewWriterSink(os.Stdout))
+	}
+	if serveOpts.AuditWebhookURL != "" {
+		sinks = append(sinks, audit.NewWebhookSink(serveOpts.AuditWebhookURL, nil))
+	}
+	// The users are identified with the service account, since they may not
+	// be allowed to review their own token.
+	clientSet, err := serviceAccountClientSet()
+	if err != nil {
+		return nil, err
+	}
+	return audit.NewInterceptor(audit.NewMultiSink(sinks...), audit.NewTokenReviewUserResolver(clientSet)), nil
+}
+
+// newRepoHealthMonitor returns the monitor notifying the webhook configured in
+// the serve options of the failing package repositories. The repositories are
+// listed with the service account, in the cluster where Kubeapps is installed.
+// The AppRepositories are only checked when the namespace of their sync jobs,
+// which is the namespace of Kubeapps, is known.
+func newRepoHealthMonitor(serveOpts core.ServeOptions) (*repohealth.Monitor, error) {
+	svcRestConfig, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve in cluster configuration: %v", err)
+	}
+	dynamicClient, err := dynamic.NewForConfig(svcRestConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve dynamic client: %v", err)
+	}
+	return repohealth.NewMonitor(dynamicClient, repohealth.NewWebhookNotifier(serveOpts.RepoHealthWebhookURL, nil), repohealth.Options{
+		Interval:          serveOpts.RepoHealthCheckInterval,
+		FailingWindow:     serveOpts.RepoHealthFailingWindow,
+		SyncJobsNamespace: os.Getenv("POD_NAMESPACE"),
+	}), nil
+}
+
+func registerPreferencesServiceServer(mux *http.ServeMux, gwArgs core.GatewayHandlerArgs, opts ...connect.HandlerOption) error {
+	// The preferences are stored in the namespace where Kubeapps is installed,
+	// using the service account of kubeapps-apis rather than the user's token.
+	namespace := os.Getenv("POD_NAMESPACE")
+	clientSet, err := serviceAccountClientSet()
+	if err != nil {
+		return err
+	}
+
+	// Create the core.preferences server and register it for both grpc and http.
+	preferencesServer, err := preferencesv1alpha1.NewPreferencesServer(clientSet, namespace)
+	if err != nil {
+		return fmt.Errorf("failed to create core.preferences.v1alpha1 server: %w", err)
+	}
+	mux.Handle(preferencesConnect.NewPreferencesServiceHandler(preferencesServer, opts...))
+
+	err = preferencesGRPCv1alpha1.RegisterPreferencesServiceHandlerFromEndpoint(gwArgs.Ctx, gwArgs.Mux, gwArgs.Addr, gwArgs.DialOptions)
+	if err != nil {
+		return fmt.Errorf("failed to register core.preferences handler for gateway: %v", err)
 	}
 	return nil
 }
 
+func registerFavoritesServiceServer(mux *http.ServeMux, gwArgs core.GatewayHandlerArgs, opts ...connect.HandlerOption) error {
+	// As the preferences, the favorites are stored in the namespace where
+	// Kubeapps is installed, using the service account of kubeapps-apis.
+	namespace := os.Getenv("POD_NAMESPACE")
+	clientSet, err := serviceAccountClientSet()
+	if err != nil {
+		return err
+	}
+
+	// Create the core.favorites server and register it for both grpc and http.
+	favoritesServer, err := favoritesv1alpha1.NewFavoritesServer(clientSet, namespace)
+	if err != nil {
+		return fmt.Errorf("failed to create core.favorites.v1alpha1 server: %w", err)
+	}
+	mux.Handle(favoritesConnect.NewFavoritesServiceHandler(favoritesServer, opts...))
+
+	err = favoritesGRPCv1alpha1.RegisterFavoritesServiceHandlerFromEndpoint(gwArgs.Ctx, gwArgs.Mux, gwArgs.Addr, gwArgs.DialOptions)
+	if err != nil {
+		return fmt.Errorf("failed to register core.favorites handler for gateway: %v", err)
+	}
+	return nil
+}
+
+func registerPresetsServiceServer(mux *http.ServeMux, packagesServer packagesConnectv1.PackagesServiceHandler, pinsChecker *pins.Checker, gwArgs core.GatewayHandlerArgs, opts ...connect.HandlerOption) error {
+	// The install presets are stored by the administrators in the namespace
+	// where Kubeapps is installed, and read with the service account of
+	// kubeapps-apis rather than the user's token.
+	namespace := os.Getenv("POD_NAMESPACE")
+	clientSet, err := serviceAccountClientSet()
+	if err != nil {
+		return err
+	}
+
+	// Create the core.presets server and register it for both grpc and http.
+	presetsServer, err := presetsv1alpha1.NewPresetsServer(clientSet, namespace, packagesServer, pinsChecker)
+	if err != nil {
+		return fmt.Errorf("failed to create core.presets.v1alpha1 server: %w", err)
+	}
+	mux.Handle(presetsConnect.NewPresetsServiceHandler(presetsServer, opts...))
+
+	err = presetsGRPCv1alpha1.RegisterPresetsServiceHandlerFromEndpoint(gwArgs.Ctx, gwArgs.Mux, gwArgs.Addr, gwArgs.DialOptions)
+	if err != nil {
+		return fmt.Errorf("failed to register core.presets handler for gateway: %v", err)
+	}
+	return nil
+}
+
+func registerUpgradePoliciesServiceServer(mux *http.ServeMux, packagesServer packagesConnectv1.PackagesServiceHandler, serveOpts core.ServeOptions, gwArgs core.GatewayHandlerArgs, opts ...connect.HandlerOption) error {
+	// The upgrade policies are stored in the namespace where Kubeapps is
+	// installed, using the service account of kubeapps-apis rather than the
+	// user's token.
+	namespace := os.Getenv("POD_NAMESPACE")
+	clientSet, err := serviceAccountClientSet()
+	if err != nil {
+		return err
+	}
+
+	// Create the core.upgradepolicies server and register it for both grpc and http.
+	upgradePoliciesServer, err := upgradepoliciesv1alpha1.NewUpgradePoliciesServer(clientSet, namespace, packagesServer, serveOpts.UpgradePoliciesInterval > 0)
+	if err != nil {
+		return fmt.Errorf("failed to create core.upgradepolicies.v1alpha1 server: %w", err)
+	}
+	mux.Handle(upgradepoliciesConnect.NewUpgradePoliciesServiceHandler(upgradePoliciesServer, opts...))
+
+	err = upgradepoliciesGRPCv1alpha1.RegisterUpgradePoliciesServiceHandlerFromEndpoint(gwArgs.Ctx, gwArgs.Mux, gwArgs.Addr, gwArgs.DialOptions)
+	if err != nil {
+		return fmt.Errorf("failed to register core.upgradepolicies handler for gateway: %v", err)
+	}
+	return nil
+}
+
+func registerClustersServiceServer(mux *http.ServeMux, pluginsServer *pluginsv1alpha1.PluginsServer, serveOpts core.ServeOptions, gwArgs core.GatewayHandlerArgs, opts ...connect.HandlerOption) error {
+	// The registered clusters are stored in the namespace where Kubeapps is
+	// installed, using the service account of kubeapps-apis rather than the
+	// user's token.
+	namespace := os.Getenv("POD_NAMESPACE")
+	clientSet, err := serviceAccountClientSet()
+	if err != nil {
+		return err
+	}
+
+	// Create the core.clusters server and register it for both grpc and http.
+	clustersServer, err := clustersv1alpha1.NewClustersServer(clientSet, namespace, pluginsServer.ClustersConfig())
+	if err != nil {
+		return fmt.Errorf("failed to create core.clusters.v1alpha1 server: %w", err)
+	}
+	mux.Handle(clustersConnect.NewClustersServiceHandler(clustersServer, opts...))
+
+	err = clustersGRPCv1alpha1.RegisterClustersServiceHandlerFromEndpoint(gwArgs.Ctx, gwArgs.Mux, gwArgs.Addr, gwArgs.DialOptions)
+	if err != nil {
+		return fmt.Errorf("failed to register core.clusters handler for gateway: %v", err)
+	}
+
+	// Sync the registered clusters in the background, if enabled, so that
+	// the ones registered through other replicas are picked up.
+	if serveOpts.ClustersSyncInterval > 0 {
+		go clustersServer.Run(gwArgs.Ctx, serveOpts.ClustersSyncInterval)
+	}
+	return nil
+}
+
+func registerNotificationsServiceServer(mux *http.ServeMux, notificationsServer notificationsConnect.NotificationsServiceHandler, gwArgs core.GatewayHandlerArgs, opts ...connect.HandlerOption) error {
+	// Register the core.notifications server for both grpc and http.
+	mux.Handle(notificationsConnect.NewNotificationsServiceHandler(notificationsServer, opts...))
+
+	err := notificationsGRPCv1alpha1.RegisterNotificationsServiceHandlerFromEndpoint(gwArgs.Ctx, gwArgs.Mux, gwArgs.Addr, gwArgs.DialOptions)
+	if err != nil {
+		return fmt.Errorf("failed to register core.notifications handler for gateway: %v", err)
+	}
+	return nil
+}
+
+func registerSearchServiceServer(mux *http.ServeMux, searchServer searchConnect.SearchServiceHandler, gwArgs core.GatewayHandlerArgs, opts ...connect.HandlerOption) error {
+	// Register the core.search server for both grpc and http.
+	mux.Handle(searchConnect.NewSearchServiceHandler(searchServer, opts...))
+
+	err := searchGRPCv1alpha1.RegisterSearchServiceHandlerFromEndpoint(gwArgs.Ctx, gwArgs.Mux, gwArgs.Addr, gwArgs.DialOptions)
+	if err != nil {
+		return fmt.Errorf("failed to register core.search handler for gateway: %v", err)
+	}
+	return nil
+}
+
+func registerJobsServiceServer(mux *http.ServeMux, jobsServer jobsConnect.JobsServiceHandler, gwArgs core.GatewayHandlerArgs, opts ...connect.HandlerOption) error {
+	// Register the core.jobs server for both grpc and http.
+	mux.Handle(jobsConnect.NewJobsServiceHandler(jobsServer, opts...))
+
+	err := jobsGRPCv1alpha1.RegisterJobsServiceHandlerFromEndpoint(gwArgs.Ctx, gwArgs.Mux, gwArgs.Addr, gwArgs.DialOptions)
+	if err != nil {
+		return fmt.Errorf("failed to register core.jobs handler for gateway: %v", err)
+	}
+	return nil
+}
+
+// newUpgradeScheduler returns the scheduler upgrading the installed packages
+// within the windows of their upgrade policies. The upgrades impersonate the
+// users who set the policies, which requires the impersonation to be enabled.
+// They are requested to this server, as those of the users, so that they go
+// through the same interceptors.
+func newUpgradeScheduler(serveOpts core.ServeOptions, clustersConfig kube.ClustersConfig) (*upgradepoliciesv1alpha1.Scheduler, error) {
+	if !serveOpts.ImpersonationEnabled {
+		return nil, fmt.Errorf("the scheduled upgrades require the impersonation to be enabled")
+	}
+	clientSet, err := serviceAccountClientSet()
+	if err != nil {
+		return nil, err
+	}
+	packagesClient := packagesConnectv1.NewPackagesServiceClient(http.DefaultClient, fmt.Sprintf("http://localhost:%d/", serveOpts.Port))
+	return upgradepoliciesv1alpha1.NewScheduler(clientSet, os.Getenv("POD_NAMESPACE"), packagesClient, clustersConfig, serveOpts.UpgradePoliciesInterval), nil
+}
+
+// gatewayHeaderMatcher forwards the W3C trace context headers along with the
+// default ones, so that the requests to the REST API are traced as well.
+func gatewayHeaderMatcher(key string) (string, bool) {
+	switch key := strings.ToLower(key); key {
+	case "traceparent", "tracestate":
+		return key, true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
 // Create a gateway mux that does not emit unpopulated fields.
 func gatewayMux() (*runtime.ServeMux, error) {
 	gwmux := runtime.NewServeMux(
@@ -183,6 +698,7 @@ func gatewayMux() (*runtime.ServeMux, error) {
 				DiscardUnknown: true,
 			},
 		}),
+		runtime.WithIncomingHeaderMatcher(gatewayHeaderMatcher),
 	)
 
 	// TODO(agamez): remove these '/openapi.json' and '/docs' paths. They are serving a
@@ -242,8 +758,8 @@ func gatewayMux() (*runtime.ServeMux, error) {
 }
 
 // Registers the pluginsServer with the mux and gateway.
-func registerPluginsServiceServer(mux *http.ServeMux, pluginsServer *pluginsv1alpha1.PluginsServer, gwArgs core.GatewayHandlerArgs) error {
-	mux.Handle(pluginsConnect.NewPluginsServiceHandler(pluginsServer))
+func registerPluginsServiceServer(mux *http.ServeMux, pluginsServer *pluginsv1alpha1.PluginsServer, gwArgs core.GatewayHandlerArgs, opts ...connect.HandlerOption) error {
+	mux.Handle(pluginsConnect.NewPluginsServiceHandler(pluginsServer, opts...))
 	err := pluginsGRPCv1alpha1.RegisterPluginsServiceHandlerFromEndpoint(gwArgs.Ctx, gwArgs.Mux, gwArgs.Addr, gwArgs.DialOptions)
 	if err != nil {
 		return fmt.Errorf("failed to register core.plugins handler for gateway: %v", err)
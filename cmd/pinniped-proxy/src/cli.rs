@@ -42,6 +42,13 @@ pub struct Options {
         help = "Specify the file path to a PEM encoded TLS certificate key. Providing the cert and key implies listening for TLS requests."
     )]
     pub proxy_tls_cert_key: String,
+    #[arg(
+        long = "metrics-port",
+        env = "PINNIPED_PROXY_METRICS_PORT",
+        default_value = "0",
+        help = "Specify the port on which the Prometheus metrics of the credential exchanges are served at /metrics. The metrics are not served if 0."
+    )]
+    pub metrics_port: u16,
 }
 
 #[cfg(test)]
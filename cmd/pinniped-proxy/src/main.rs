@@ -5,6 +5,7 @@ use chrono::Local;
 use std::convert::Infallible;
 use std::fs;
 use std::io::Write;
+use std::net::SocketAddr;
 
 use anyhow::{Context, Result};
 use clap::Parser;
@@ -13,7 +14,7 @@ use hyper::{
     service::{make_service_fn, service_fn},
     Server,
 };
-use log::info;
+use log::{error, info};
 use tls_listener::TlsListener;
 
 // Ensure the root crate is aware of the child modules.
@@ -21,6 +22,7 @@ mod cache;
 mod cli;
 mod https;
 mod logging;
+mod metrics;
 mod pinniped;
 mod service;
 mod tls_config;
@@ -55,6 +57,21 @@ async fn main() -> Result<()> {
     // can be shared across threads.
     let credential_cache = pinniped::new_credential_cache();
 
+    // Serve the metrics of the credential exchanges in a separate port, if
+    // configured, so that they are not mixed up with the proxied requests.
+    if opt.metrics_port != 0 {
+        let metrics_addr: SocketAddr = ([0, 0, 0, 0], opt.metrics_port).into();
+        let make_metrics_svc =
+            make_service_fn(|_conn| async { Ok::<_, Infallible>(service_fn(metrics::serve)) });
+        let metrics_server = Server::bind(&metrics_addr).serve(make_metrics_svc);
+        info!("Serving metrics on http://{}/metrics", metrics_addr);
+        tokio::spawn(async move {
+            if let Err(e) = metrics_server.await {
+                error!("unexpected error while serving metrics: {}", e);
+            }
+        });
+    }
+
     // Run the server for ever. If it returns with an error, return the
     // result, otherwise, if it completes, we return Ok.
     if with_tls {
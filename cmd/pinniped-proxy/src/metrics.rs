@@ -0,0 +1,199 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+use std::convert::Infallible;
+use std::fmt::Write;
+use std::sync::atomic::{AtomicU64, Ordering};
+use std::time::Duration;
+
+use hyper::{header::CONTENT_TYPE, Body, Method, Request, Response, StatusCode};
+
+/// The upper bounds, in seconds, of the buckets of the exchange latency
+/// histogram.
+const EXCHANGE_DURATION_BUCKETS: [f64; 10] =
+    [0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1.0, 2.5, 5.0];
+
+// A const item is required to initialise the array of atomics in a static.
+#[allow(clippy::declare_interior_mutable_const)]
+const ZERO: AtomicU64 = AtomicU64::new(0);
+
+/// The metrics of the credential exchanges of the proxy, shared across
+/// threads.
+pub static METRICS: Metrics = Metrics::new();
+
+/// Metrics records the credential exchanges with pinniped-concierge, which
+/// are served in the Prometheus text format.
+pub struct Metrics {
+    cache_hits: AtomicU64,
+    exchanges: AtomicU64,
+    exchange_failures: AtomicU64,
+    // The number of exchanges per bucket of the histogram, the last one being
+    // for the exchanges slower than the biggest bound.
+    exchange_duration_buckets: [AtomicU64; EXCHANGE_DURATION_BUCKETS.len() + 1],
+    exchange_duration_sum_micros: AtomicU64,
+}
+
+impl Metrics {
+    pub const fn new() -> Metrics {
+        Metrics {
+            cache_hits: AtomicU64::new(0),
+            exchanges: AtomicU64::new(0),
+            exchange_failures: AtomicU64::new(0),
+            exchange_duration_buckets: [ZERO; EXCHANGE_DURATION_BUCKETS.len() + 1],
+            exchange_duration_sum_micros: AtomicU64::new(0),
+        }
+    }
+
+    /// record_cache_hit records a request whose credential was cached, so not
+    /// exchanged.
+    pub fn record_cache_hit(&self) {
+        self.cache_hits.fetch_add(1, Ordering::Relaxed);
+    }
+
+    /// record_exchange records a credential exchange with its duration and
+    /// whether a credential was returned.
+    pub fn record_exchange(&self, duration: Duration, succeeded: bool) {
+        self.exchanges.fetch_add(1, Ordering::Relaxed);
+        if !succeeded {
+            self.exchange_failures.fetch_add(1, Ordering::Relaxed);
+        }
+        let seconds = duration.as_secs_f64();
+        let bucket = EXCHANGE_DURATION_BUCKETS
+            .iter()
+            .position(|bound| seconds <= *bound)
+            .unwrap_or(EXCHANGE_DURATION_BUCKETS.len());
+        self.exchange_duration_buckets[bucket].fetch_add(1, Ordering::Relaxed);
+        self.exchange_duration_sum_micros
+            .fetch_add(duration.as_micros() as u64, Ordering::Relaxed);
+    }
+
+    /// render returns the metrics in the Prometheus text format.
+    pub fn render(&self) -> String {
+        let mut out = String::new();
+        write_counter(
+            &mut out,
+            "pinniped_proxy_credential_cache_hits_total",
+            "Number of requests whose credential was served from the cache.",
+            self.cache_hits.load(Ordering::Relaxed),
+        );
+        write_counter(
+            &mut out,
+            "pinniped_proxy_credential_exchanges_total",
+            "Number of credential exchanges with pinniped-concierge.",
+            self.exchanges.load(Ordering::Relaxed),
+        );
+        write_counter(
+            &mut out,
+            "pinniped_proxy_credential_exchange_failures_total",
+            "Number of credential exchanges with pinniped-concierge which failed or did not return a credential.",
+            self.exchange_failures.load(Ordering::Relaxed),
+        );
+
+        let name = "pinniped_proxy_credential_exchange_duration_seconds";
+        let _ = writeln!(
+            out,
+            "# HELP {} Duration of the credential exchanges with pinniped-concierge.",
+            name
+        );
+        let _ = writeln!(out, "# TYPE {} histogram", name);
+        // The buckets of the Prometheus histograms are cumulative.
+        let mut count = 0;
+        for (i, bound) in EXCHANGE_DURATION_BUCKETS.iter().enumerate() {
+            count += self.exchange_duration_buckets[i].load(Ordering::Relaxed);
+            let _ = writeln!(out, "{}_bucket{{le=\"{}\"}} {}", name, bound, count);
+        }
+        count +=
+            self.exchange_duration_buckets[EXCHANGE_DURATION_BUCKETS.len()].load(Ordering::Relaxed);
+        let _ = writeln!(out, "{}_bucket{{le=\"+Inf\"}} {}", name, count);
+        let sum = self.exchange_duration_sum_micros.load(Ordering::Relaxed) as f64 / 1_000_000.0;
+        let _ = writeln!(out, "{}_sum {}", name, sum);
+        let _ = writeln!(out, "{}_count {}", name, count);
+        out
+    }
+}
+
+fn write_counter(out: &mut String, name: &str, help: &str, value: u64) {
+    let _ = writeln!(out, "# HELP {} {}", name, help);
+    let _ = writeln!(out, "# TYPE {} counter", name);
+    let _ = writeln!(out, "{} {}", name, value);
+}
+
+/// serve returns the metrics at /metrics, in the port dedicated to them so
+/// that the proxied requests to the API servers' own /metrics are not
+/// intercepted.
+pub async fn serve(req: Request<Body>) -> Result<Response<Body>, Infallible> {
+    if req.method() != Method::GET || req.uri().path() != "/metrics" {
+        return Ok(Response::builder()
+            .status(StatusCode::NOT_FOUND)
+            .body(Body::empty())
+            .unwrap());
+    }
+    Ok(Response::builder()
+        .header(CONTENT_TYPE, "text/plain; version=0.0.4")
+        .body(Body::from(METRICS.render()))
+        .unwrap())
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    #[test]
+    fn test_render_empty() {
+        let metrics = Metrics::new();
+
+        let rendered = metrics.render();
+
+        assert!(rendered.contains("pinniped_proxy_credential_cache_hits_total 0\n"));
+        assert!(rendered.contains("pinniped_proxy_credential_exchanges_total 0\n"));
+        assert!(rendered.contains("pinniped_proxy_credential_exchange_duration_seconds_count 0\n"));
+    }
+
+    #[test]
+    fn test_render_records() {
+        let metrics = Metrics::new();
+
+        metrics.record_cache_hit();
+        metrics.record_cache_hit();
+        metrics.record_exchange(Duration::from_millis(20), true);
+        metrics.record_exchange(Duration::from_millis(200), false);
+        metrics.record_exchange(Duration::from_secs(10), true);
+
+        let rendered = metrics.render();
+
+        assert!(rendered.contains("pinniped_proxy_credential_cache_hits_total 2\n"));
+        assert!(rendered.contains("pinniped_proxy_credential_exchanges_total 3\n"));
+        assert!(rendered.contains("pinniped_proxy_credential_exchange_failures_total 1\n"));
+        // The buckets are cumulative.
+        assert!(rendered.contains(
+            "pinniped_proxy_credential_exchange_duration_seconds_bucket{le=\"0.01\"} 0\n"
+        ));
+        assert!(rendered.contains(
+            "pinniped_proxy_credential_exchange_duration_seconds_bucket{le=\"0.025\"} 1\n"
+        ));
+        assert!(rendered.contains(
+            "pinniped_proxy_credential_exchange_duration_seconds_bucket{le=\"0.25\"} 2\n"
+        ));
+        assert!(rendered
+            .contains("pinniped_proxy_credential_exchange_duration_seconds_bucket{le=\"5\"} 2\n"));
+        assert!(rendered.contains(
+            "pinniped_proxy_credential_exchange_duration_seconds_bucket{le=\"+Inf\"} 3\n"
+        ));
+        assert!(
+            rendered.contains("pinniped_proxy_credential_exchange_duration_seconds_sum 10.22\n")
+        );
+        assert!(rendered.contains("pinniped_proxy_credential_exchange_duration_seconds_count 3\n"));
+    }
+
+    #[test]
+    fn test_serve_not_found() {
+        let req = Request::builder()
+            .uri("/api/v1/namespaces")
+            .body(Body::empty())
+            .unwrap();
+
+        let response = tokio_test::block_on(serve(req)).unwrap();
+
+        assert_eq!(response.status(), StatusCode::NOT_FOUND);
+    }
+}
@@ -4,10 +4,12 @@ use std::convert::TryFrom;
 use std::env;
 use std::hash::{Hash, Hasher};
 use std::sync::Arc;
+use std::time::Instant;
 
 use crate::cache::PruningCache;
+use crate::metrics::METRICS;
 use anyhow::{Context, Result};
-use chrono::Utc;
+use chrono::{DateTime, TimeZone, Utc};
 use http::Uri;
 use k8s_openapi::api::core::v1 as corev1;
 use k8s_openapi::apimachinery::pkg::apis::meta::v1 as metav1;
@@ -17,6 +19,7 @@ use kube::{
     Client, Config,
 };
 use native_tls::Identity;
+use openssl::asn1::Asn1Time;
 use openssl::x509::X509;
 use serde::{Deserialize, Serialize};
 use serde_json;
@@ -262,20 +265,75 @@ async fn prepare_and_call_pinniped_exchange(
     // If the credential already exists in the cache (the cache handles expired
     // creds), then return that.
     match credential_cache.get(&(k8s_api_server_url.into(), cred_data.clone())) {
-        Some(cached_cred) => Ok(cached_cred),
+        Some(cached_cred) => {
+            METRICS.record_cache_hit();
+            Ok(cached_cred)
+        }
         None => {
-            let client = get_client_config(
+            let start = Instant::now();
+            let result = match get_client_config(
                 k8s_api_server_url,
                 k8s_api_ca_cert_data,
                 pinniped_namespace.clone(),
-            )?;
-            let cred = call_pinniped(pinniped_namespace, client, cred_data.clone()).await?;
+            ) {
+                Ok(client) => call_pinniped(pinniped_namespace, client, cred_data.clone()).await,
+                Err(e) => Err(e),
+            };
+            // An exchange returning a status without a credential is an
+            // unsuccessful authentication, so it is recorded as a failure too.
+            let succeeded = match &result {
+                Ok(cred) => cred
+                    .status
+                    .as_ref()
+                    .and_then(|s| s.credential.as_ref())
+                    .is_some(),
+                Err(_) => false,
+            };
+            METRICS.record_exchange(start.elapsed(), succeeded);
+
+            let mut cred = result?;
+            limit_expiration_to_certificate(&mut cred);
             credential_cache.insert((k8s_api_server_url.into(), cred_data), cred.clone());
             Ok(cred)
         }
     }
 }
 
+/// limit_expiration_to_certificate sets the expiration timestamp of the
+/// returned credential to the expiry of its client certificate, if earlier, so
+/// that the cached credential is not used once its certificate has expired.
+fn limit_expiration_to_certificate(cred_request: &mut TokenCredentialRequest) {
+    let credential = match cred_request
+        .status
+        .as_mut()
+        .and_then(|s| s.credential.as_mut())
+    {
+        Some(c) => c,
+        None => return,
+    };
+    // The certificate is parsed again when creating the identity, which
+    // reports an invalid certificate.
+    if let Ok(not_after) = certificate_not_after(&credential.client_certificate_data) {
+        if not_after < credential.expiration_timestamp.0 {
+            credential.expiration_timestamp = metav1::Time(not_after);
+        }
+    }
+}
+
+/// certificate_not_after returns the expiry of a PEM-encoded certificate.
+fn certificate_not_after(certificate_data: &str) -> Result<DateTime<Utc>> {
+    let x509 =
+        X509::from_pem(certificate_data.as_bytes()).context("error creating x509 from pem")?;
+    let epoch = Asn1Time::from_unix(0).context("error creating asn1 time for the epoch")?;
+    let diff = epoch
+        .diff(x509.not_after())
+        .context("error comparing the certificate expiry")?;
+    let seconds = i64::from(diff.days) * 24 * 60 * 60 + i64::from(diff.secs);
+    Utc.timestamp_opt(seconds, 0)
+        .single()
+        .context("invalid certificate expiry")
+}
+
 async fn call_pinniped(
     pinniped_namespace: String,
     client: kube::Client,
@@ -765,6 +823,70 @@ mZu9A/ivt37pOQXm/HOX6tHB
         assert_eq!(cc.len(), 2);
     }
 
+    #[test]
+    fn test_certificate_not_after() -> Result<()> {
+        assert_eq!(
+            certificate_not_after(VALID_CERT_PEM)?,
+            Utc.timestamp_opt(1657248297, 0).single().unwrap()
+        );
+        assert!(certificate_not_after("cert data").is_err());
+        Ok(())
+    }
+
+    #[test]
+    fn test_limit_expiration_to_certificate() {
+        let mut cred = make_token_credential_request();
+        cred.status = Some(TokenCredentialRequestStatus {
+            credential: Some(ClusterCredential {
+                expiration_timestamp: metav1::Time(Utc::now() + Duration::days(1)),
+                client_certificate_data: String::from(VALID_CERT_PEM),
+                client_key_data: String::from(VALID_KEY_PEM),
+                token: None,
+            }),
+            message: None,
+        });
+        assert!(!cred.is_expired());
+
+        // The certificate expired before the expiration timestamp.
+        limit_expiration_to_certificate(&mut cred);
+
+        assert!(cred.is_expired());
+        assert_eq!(
+            cred.status
+                .unwrap()
+                .credential
+                .unwrap()
+                .expiration_timestamp,
+            metav1::Time(Utc.timestamp_opt(1657248297, 0).single().unwrap())
+        );
+    }
+
+    #[test]
+    fn test_limit_expiration_to_certificate_keeps_earlier_expiration() {
+        let expiration_timestamp = metav1::Time(Utc.timestamp_opt(1600000000, 0).single().unwrap());
+        let mut cred = make_token_credential_request();
+        cred.status = Some(TokenCredentialRequestStatus {
+            credential: Some(ClusterCredential {
+                expiration_timestamp: expiration_timestamp.clone(),
+                client_certificate_data: String::from(VALID_CERT_PEM),
+                client_key_data: String::from(VALID_KEY_PEM),
+                token: None,
+            }),
+            message: None,
+        });
+
+        limit_expiration_to_certificate(&mut cred);
+
+        assert_eq!(
+            cred.status
+                .unwrap()
+                .credential
+                .unwrap()
+                .expiration_timestamp,
+            expiration_timestamp
+        );
+    }
+
     #[test]
     fn test_credential_cache_prunes_expired_tokens() {
         let cc = new_credential_cache();
@@ -5,7 +5,7 @@ import { IAuthState } from "reducers/auth";
 import configureMockStore from "redux-mock-store";
 import thunk from "redux-thunk";
 import { Auth } from "shared/Auth";
-import Namespace, * as NS from "shared/Namespace";
+import Namespace from "shared/Namespace";
 import { initialState } from "shared/specs/mountWrapper";
 import { IStoreState } from "shared/types";
 import { getType } from "typesafe-actions";
@@ -31,7 +31,6 @@ beforeEach(() => {
   Auth.setAuthToken = jest.fn();
   Auth.unsetAuthToken = jest.fn();
   Namespace.list = jest.fn(async () => []);
-  jest.spyOn(NS, "unsetStoredNamespace");
 
   store = mockStore({
     auth: {
@@ -160,8 +159,9 @@ describe("OIDC authentication", () => {
 });
 
 describe("logout", () => {
-  it("unsets the stored namespace", async () => {
+  it("unsets the auth token and clears the clusters", async () => {
     await store.dispatch(actions.auth.logout());
-    expect(NS.unsetStoredNamespace).toHaveBeenCalled();
+    expect(Auth.unsetAuthToken).toHaveBeenCalled();
+    expect(store.getActions()).toContainEqual({ type: getType(actions.namespace.clearClusters) });
   });
 });
@@ -3,7 +3,6 @@
 
 import { ThunkAction } from "redux-thunk";
 import { Auth } from "shared/Auth";
-import * as Namespace from "shared/Namespace";
 import { IStoreState, UnauthorizedNetworkError } from "shared/types";
 import { ActionType, deprecated } from "typesafe-actions";
 import { clearClusters, NamespaceAction } from "./namespace";
@@ -67,7 +66,6 @@ export function logout(): ThunkAction<
       dispatch(setAuthenticated(false, false));
       dispatch(clearClusters());
     }
-    Namespace.unsetStoredNamespace();
   };
 }
 
@@ -1,9 +1,11 @@
 // Copyright 2018-2022 the Kubeapps contributors.
 // SPDX-License-Identifier: Apache-2.0
 
+import { Theme, UserPreferences } from "gen/kubeappsapis/core/preferences/v1alpha1/preferences_pb";
 import configureMockStore from "redux-mock-store";
 import thunk from "redux-thunk";
 import Config, { IConfig, SupportedThemes } from "shared/Config";
+import Preferences from "shared/Preferences";
 import { getType } from "typesafe-actions";
 import actions from ".";
 
@@ -60,6 +62,8 @@ describe("getConfig", () => {
 
 describe("getTheme", () => {
   it("dispatches request config and its returned value", async () => {
+    const preferences = new UserPreferences({ theme: Theme.DARK });
+    Preferences.get = jest.fn().mockResolvedValue(preferences);
     Config.getTheme = jest.fn().mockReturnValue(SupportedThemes.dark);
     const expectedActions = [
       {
@@ -70,12 +74,27 @@ describe("getTheme", () => {
 
     await store.dispatch(actions.config.getTheme());
     expect(store.getActions()).toEqual(expectedActions);
+    expect(Config.getTheme).toHaveBeenCalledWith(testConfig, preferences);
+  });
+
+  it("dispatches the theme if the user preferences cannot be retrieved", async () => {
+    Preferences.get = jest.fn().mockRejectedValue(new Error("Unauthenticated"));
+    Config.getTheme = jest.fn().mockReturnValue(SupportedThemes.light);
+
+    await store.dispatch(actions.config.getTheme());
+    expect(store.getActions()).toEqual([
+      {
+        payload: SupportedThemes.light,
+        type: getType(actions.config.receiveTheme),
+      },
+    ]);
+    expect(Config.getTheme).toHaveBeenCalledWith(testConfig, undefined);
   });
 });
 
 describe("setUserTheme", () => {
   it("dispatches request config and its returned value", async () => {
-    Config.setUserTheme = jest.fn();
+    Config.setUserTheme = jest.fn().mockResolvedValue(undefined);
     const expectedActions = [
       {
         payload: SupportedThemes.dark,
@@ -85,5 +104,18 @@ describe("setUserTheme", () => {
 
     await store.dispatch(actions.config.setUserTheme(SupportedThemes.dark));
     expect(store.getActions()).toEqual(expectedActions);
+    expect(Config.setUserTheme).toHaveBeenCalledWith(SupportedThemes.dark);
+  });
+
+  it("dispatches the theme even if it cannot be stored", async () => {
+    Config.setUserTheme = jest.fn().mockRejectedValue(new Error("Bang!"));
+
+    await store.dispatch(actions.config.setUserTheme(SupportedThemes.dark));
+    expect(store.getActions()).toEqual([
+      {
+        payload: SupportedThemes.dark,
+        type: getType(actions.config.receiveTheme),
+      },
+    ]);
   });
 });
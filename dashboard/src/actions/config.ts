@@ -3,6 +3,7 @@
 
 import { ThunkAction } from "redux-thunk";
 import Config, { IConfig, SupportedThemes } from "shared/Config";
+import Preferences from "shared/Preferences";
 import { IStoreState } from "shared/types";
 import { ActionType, deprecated } from "typesafe-actions";
 
@@ -27,7 +28,7 @@ export function getConfig(): ThunkAction<Promise<void>, IStoreState, null, Confi
     dispatch(requestConfig());
     try {
       const config = await Config.getConfig();
-      const configuredPlugins = await Config.getConfiguredPlugins();
+      const configuredPlugins = await Config.getConfiguredPlugins(config.featuresPublicKey);
       dispatch(receiveConfig({ ...config, configuredPlugins }));
     } catch (e: any) {
       dispatch(errorConfig(e));
@@ -35,13 +36,15 @@ export function getConfig(): ThunkAction<Promise<void>, IStoreState, null, Confi
   };
 }
 
-// getTheme retrieves the current server configuration, calculates the proper theme
-// and stores it in both the redux state and the user's localstorage
+// getTheme retrieves the current server configuration and user preferences, calculates
+// the proper theme and stores it in the redux state
 export function getTheme(): ThunkAction<Promise<void>, IStoreState, null, ConfigAction> {
   return async dispatch => {
     try {
       const config = await Config.getConfig();
-      const theme = Config.getTheme(config);
+      // The user preferences cannot be retrieved before logging in.
+      const preferences = await Preferences.get().catch(() => undefined);
+      const theme = Config.getTheme(config, preferences);
       Config.setTheme(theme);
       dispatch(receiveTheme(theme));
     } catch (e: any) {
@@ -51,12 +54,13 @@ export function getTheme(): ThunkAction<Promise<void>, IStoreState, null, Config
 }
 
 // setUserTheme receives a theme and stores it
-// in both the redux state and the user's localstorage
+// in both the redux state and the user preferences
 export function setUserTheme(
   theme: SupportedThemes,
 ): ThunkAction<Promise<void>, IStoreState, null, ConfigAction> {
   return async dispatch => {
-    Config.setUserTheme(theme);
     dispatch(receiveTheme(theme));
+    // The theme is applied even if it cannot be stored in the user preferences.
+    await Config.setUserTheme(theme).catch(() => undefined);
   };
 }
@@ -1,10 +1,12 @@
 // Copyright 2018-2022 the Kubeapps contributors.
 // SPDX-License-Identifier: Apache-2.0
 
+import { UserPreferences } from "gen/kubeappsapis/core/preferences/v1alpha1/preferences_pb";
 import configureMockStore from "redux-mock-store";
 import thunk from "redux-thunk";
 import { Kube } from "shared/Kube";
 import Namespace from "shared/Namespace";
+import Preferences from "shared/Preferences";
 import { getType } from "typesafe-actions";
 import {
   canCreate,
@@ -26,7 +28,9 @@ const mockStore = configureMockStore([thunk]);
 let store: any;
 
 beforeEach(() => {
-  store = mockStore();
+  store = mockStore({ clusters: { currentCluster: "default-c", clusters: {} } });
+  Preferences.get = jest.fn().mockResolvedValue(new UserPreferences());
+  Preferences.update = jest.fn().mockResolvedValue(new UserPreferences());
 });
 afterEach(() => {
   jest.resetAllMocks();
@@ -51,8 +55,8 @@ const actionTestCases: ITestCase[] = [
   {
     name: "receiveNamespces",
     action: receiveNamespaces,
-    args: ["default", ["jack", "danny"]],
-    payload: { cluster: "default", namespaces: ["jack", "danny"] },
+    args: ["default", ["jack", "danny"], "jack"],
+    payload: { cluster: "default", namespaces: ["jack", "danny"], storedNamespace: "jack" },
   },
 ];
 
@@ -76,7 +80,11 @@ describe("fetchNamespaces", () => {
     const expectedActions = [
       {
         type: getType(receiveNamespaces),
-        payload: { cluster: "default-c", namespaces: ["overlook-hotel", "room-217"] },
+        payload: {
+          cluster: "default-c",
+          namespaces: ["overlook-hotel", "room-217"],
+          storedNamespace: "",
+        },
       },
     ];
 
@@ -84,6 +92,48 @@ describe("fetchNamespaces", () => {
     expect(store.getActions()).toEqual(expectedActions);
   });
 
+  it("dispatches the namespace stored in the user preferences for the cluster", async () => {
+    Namespace.list = jest.fn().mockResolvedValue(["overlook-hotel", "room-217"]);
+    Preferences.get = jest.fn().mockResolvedValue(
+      new UserPreferences({ defaultCluster: "default-c", defaultNamespace: "room-217" }),
+    );
+    const expectedActions = [
+      {
+        type: getType(receiveNamespaces),
+        payload: {
+          cluster: "default-c",
+          namespaces: ["overlook-hotel", "room-217"],
+          storedNamespace: "room-217",
+        },
+      },
+    ];
+
+    await store.dispatch(fetchNamespaces("default-c"));
+    expect(store.getActions()).toEqual(expectedActions);
+  });
+
+  it("ignores the namespace stored in the user preferences for another cluster", async () => {
+    Namespace.list = jest.fn().mockResolvedValue(["overlook-hotel", "room-217"]);
+    Preferences.get = jest.fn().mockResolvedValue(
+      new UserPreferences({ defaultCluster: "other", defaultNamespace: "room-217" }),
+    );
+
+    await store.dispatch(fetchNamespaces("default-c"));
+    expect(store.getActions()[0].payload.storedNamespace).toBe("");
+  });
+
+  it("dispatches the list of namespace names if the user preferences cannot be retrieved", async () => {
+    Namespace.list = jest.fn().mockResolvedValue(["overlook-hotel", "room-217"]);
+    Preferences.get = jest.fn().mockRejectedValue(new Error("Bang!"));
+
+    await store.dispatch(fetchNamespaces("default-c"));
+    expect(store.getActions()[0].payload).toEqual({
+      cluster: "default-c",
+      namespaces: ["overlook-hotel", "room-217"],
+      storedNamespace: "",
+    });
+  });
+
   it("dispatches errorNamespace if the request returns no 'namespaces'", async () => {
     Namespace.list = jest.fn().mockImplementationOnce(() => {
       return [];
@@ -129,7 +179,11 @@ describe("createNamespace", () => {
       },
       {
         type: getType(receiveNamespaces),
-        payload: { cluster: "default-c", namespaces: ["overlook-hotel", "room-217"] },
+        payload: {
+          cluster: "default-c",
+          namespaces: ["overlook-hotel", "room-217"],
+          storedNamespace: "",
+        },
       },
     ];
 
@@ -218,10 +272,21 @@ describe("setNamespace", () => {
     ];
     await store.dispatch(setNamespace("default-c", "default-ns"));
     expect(store.getActions()).toEqual(expectedActions);
-    expect(localStorage.setItem).toHaveBeenCalledWith(
-      "kubeapps_namespace",
-      '{"default-c":"default-ns"}',
-    );
+    expect(Preferences.update).toHaveBeenCalledWith({
+      defaultCluster: "default-c",
+      defaultNamespace: "default-ns",
+    });
+  });
+
+  it("dispatches namespace set even if it cannot be stored in the user preferences", async () => {
+    Preferences.update = jest.fn().mockRejectedValue(new Error("Bang!"));
+    await store.dispatch(setNamespace("default-c", "default-ns"));
+    expect(store.getActions()).toEqual([
+      {
+        type: getType(setNamespaceState),
+        payload: { cluster: "default-c", namespace: "default-ns" },
+      },
+    ]);
   });
 });
 
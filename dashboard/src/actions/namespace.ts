@@ -3,7 +3,7 @@
 
 import { ThunkAction } from "redux-thunk";
 import { Kube } from "shared/Kube";
-import Namespace, { setStoredNamespace } from "shared/Namespace";
+import Namespace, { getStoredNamespace, setStoredNamespace } from "shared/Namespace";
 import { IStoreState } from "shared/types";
 import { ActionType, deprecated } from "typesafe-actions";
 import { handleErrorAction } from "./auth";
@@ -26,7 +26,8 @@ export const postNamespace = createAction("CREATE_NAMESPACE", resolve => {
 });
 
 export const receiveNamespaces = createAction("RECEIVE_NAMESPACES", resolve => {
-  return (cluster: string, namespaces: string[]) => resolve({ cluster, namespaces });
+  return (cluster: string, namespaces: string[], storedNamespace = "") =>
+    resolve({ cluster, namespaces, storedNamespace });
 });
 
 export const errorNamespaces = createAction("ERROR_NAMESPACES", resolve => {
@@ -54,7 +55,7 @@ export type NamespaceAction = ActionType<(typeof allActions)[number]>;
 export function fetchNamespaces(
   cluster: string,
 ): ThunkAction<Promise<string[]>, IStoreState, null, NamespaceAction> {
-  return async dispatch => {
+  return async (dispatch, getState) => {
     try {
       const namespaceList = await Namespace.list(cluster);
       if (!namespaceList || namespaceList.length === 0) {
@@ -67,7 +68,11 @@ export function fetchNamespaces(
         );
         return [];
       }
-      dispatch(receiveNamespaces(cluster, namespaceList));
+      // The stored namespace is only needed if there is no namespace selected yet.
+      const storedNamespace = getState().clusters.clusters[cluster]?.currentNamespace
+        ? ""
+        : await getStoredNamespace(cluster);
+      dispatch(receiveNamespaces(cluster, namespaceList, storedNamespace));
       return namespaceList;
     } catch (e: any) {
       dispatch(handleErrorAction(e, errorNamespaces(cluster, e, "list")));
@@ -118,8 +123,9 @@ export function setNamespace(
   ns: string,
 ): ThunkAction<Promise<void>, IStoreState, null, NamespaceAction> {
   return async dispatch => {
-    setStoredNamespace(cluster, ns);
     dispatch(setNamespaceState(cluster, ns));
+    // The namespace is selected even if it cannot be stored as the default one.
+    await setStoredNamespace(cluster, ns).catch(() => undefined);
   };
 }
 
@@ -20,6 +20,7 @@ import context from "jest-plugin-context";
 import qs from "qs";
 import * as ReactRedux from "react-redux";
 import { MemoryRouter } from "react-router-dom";
+import Jobs from "shared/Jobs";
 import { Kube } from "shared/Kube";
 import {
   defaultStore,
@@ -35,6 +36,7 @@ import CustomResourceListItem from "./CustomResourceListItem";
 import { screen } from "@testing-library/react";
 
 let spyOnUseDispatch: jest.SpyInstance;
+let spyOnJobsList: jest.SpyInstance;
 const opActions = { ...actions.operators };
 const appActions = { ...actions.installedpackages };
 beforeEach(() => {
@@ -52,12 +54,14 @@ beforeEach(() => {
     then: jest.fn(f => f(true)),
     catch: jest.fn(f => f(false)),
   });
+  spyOnJobsList = jest.spyOn(Jobs, "list").mockResolvedValue([]);
 });
 
 afterEach(() => {
   actions.operators = { ...opActions };
   actions.installedpackages = { ...appActions };
   spyOnUseDispatch.mockRestore();
+  spyOnJobsList.mockRestore();
 });
 
 context("when changing props", () => {
@@ -6,6 +6,7 @@ import { CdsIcon } from "@cds/react/icon";
 import { CdsToggle, CdsToggleGroup } from "@cds/react/toggle";
 import actions from "actions";
 import ErrorAlert from "components/ErrorAlert";
+import JobList from "components/JobList";
 import LoadingWrapper from "components/LoadingWrapper/LoadingWrapper";
 import { usePush } from "hooks/push";
 import qs from "qs";
@@ -123,6 +124,7 @@ function AppList() {
           </Link>,
         ]}
       />
+      <JobList />
       <LoadingWrapper
         loaded={!isFetching && !isFetchingResources}
         loadingText="Getting the list of applications..."
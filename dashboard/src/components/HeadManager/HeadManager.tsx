@@ -33,11 +33,13 @@ export default function HeadManager({ children }: IHeadManagerProps) {
 
   const {
     config: { theme },
+    auth: { authenticated },
   } = useSelector((state: IStoreState) => state);
 
+  // The theme stored in the user preferences is only available once authenticated.
   React.useEffect(() => {
     dispatch(actions.config.getTheme());
-  }, [dispatch]);
+  }, [dispatch, authenticated]);
 
   return (
     <>
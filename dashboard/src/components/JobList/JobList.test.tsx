@@ -0,0 +1,114 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+import { act, screen } from "@testing-library/react";
+import userEvent from "@testing-library/user-event";
+import { Job, JobState, JobType } from "gen/kubeappsapis/core/jobs/v1alpha1/jobs_pb";
+import { InstalledPackageStatus } from "gen/kubeappsapis/core/packages/v1/packages_pb";
+import Jobs from "shared/Jobs";
+import { renderWithProviders } from "shared/specs/mountWrapper";
+import JobList, { refreshInterval } from "./JobList";
+
+const installedPackageRef = {
+  context: { cluster: "default", namespace: "apps" },
+  identifier: "my-apache",
+  plugin: { name: "helm.packages", version: "v1alpha1" },
+};
+
+const pending = new Job({
+  id: "1",
+  type: JobType.UPGRADE,
+  installedPackageRef,
+  state: JobState.PENDING,
+  status: new InstalledPackageStatus({ userReason: "Deploying" }),
+  updateTime: "2023-06-01T10:00:00Z",
+});
+
+const succeeded = new Job({
+  id: "2",
+  type: JobType.INSTALL,
+  installedPackageRef: { ...installedPackageRef, identifier: "my-nginx" },
+  state: JobState.SUCCEEDED,
+  updateTime: "2023-06-01T09:00:00Z",
+});
+
+// list lets the component receive the listed jobs.
+async function list() {
+  await act(async () => {
+    for (let i = 0; i < 10; i++) {
+      await Promise.resolve();
+    }
+  });
+}
+
+afterEach(() => {
+  jest.useRealTimers();
+  jest.restoreAllMocks();
+});
+
+it("shows the jobs of the user", async () => {
+  jest.spyOn(Jobs, "list").mockResolvedValue([pending, succeeded]);
+
+  renderWithProviders(<JobList />);
+  await list();
+
+  expect(screen.getByText("Recent Jobs")).toBeInTheDocument();
+  expect(screen.getByRole("link", { name: "my-apache" })).toHaveAttribute(
+    "href",
+    "/c/default/ns/apps/apps/helm.packages/v1alpha1/my-apache",
+  );
+  expect(screen.getByText("Upgrade")).toBeInTheDocument();
+  expect(screen.getByText("Pending (Deploying)")).toBeInTheDocument();
+  expect(screen.getByText("Install")).toBeInTheDocument();
+  expect(screen.getByText("Succeeded")).toBeInTheDocument();
+  // Only the finished jobs can be dismissed.
+  expect(screen.getAllByText("Dismiss")).toHaveLength(1);
+});
+
+it("shows nothing without jobs or if the jobs are not enabled", async () => {
+  jest.spyOn(Jobs, "list").mockResolvedValue(undefined);
+
+  renderWithProviders(<JobList />);
+  await list();
+
+  expect(screen.queryByText("Recent Jobs")).not.toBeInTheDocument();
+});
+
+it("lists the jobs again while some are pending", async () => {
+  jest.useFakeTimers();
+  const completed = new Job({ ...pending, state: JobState.SUCCEEDED, status: undefined });
+  const listJobs = jest
+    .spyOn(Jobs, "list")
+    .mockResolvedValueOnce([pending])
+    .mockResolvedValueOnce([completed]);
+
+  renderWithProviders(<JobList />);
+  await list();
+  expect(screen.getByText("Pending (Deploying)")).toBeInTheDocument();
+
+  act(() => {
+    jest.advanceTimersByTime(refreshInterval);
+  });
+  await list();
+  expect(screen.getByText("Succeeded")).toBeInTheDocument();
+
+  // The jobs are no longer listed once none is pending.
+  act(() => {
+    jest.advanceTimersByTime(refreshInterval);
+  });
+  await list();
+  expect(listJobs).toHaveBeenCalledTimes(2);
+});
+
+it("dismisses a finished job", async () => {
+  jest.spyOn(Jobs, "list").mockResolvedValue([succeeded]);
+  const deleteJob = jest.spyOn(Jobs, "delete").mockResolvedValue(undefined);
+
+  renderWithProviders(<JobList />);
+  await list();
+  await userEvent.click(screen.getByText("Dismiss"));
+  await list();
+
+  expect(deleteJob).toHaveBeenCalledWith("2");
+  expect(screen.queryByText("Recent Jobs")).not.toBeInTheDocument();
+});
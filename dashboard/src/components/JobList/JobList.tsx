@@ -0,0 +1,119 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+import { CdsButton } from "@cds/react/button";
+import { CdsIcon } from "@cds/react/icon";
+import ErrorAlert from "components/ErrorAlert";
+import Table from "components/Table";
+import { Job, JobState, JobType } from "gen/kubeappsapis/core/jobs/v1alpha1/jobs_pb";
+import { InstalledPackageReference } from "gen/kubeappsapis/core/packages/v1alpha1/packages_pb";
+import React from "react";
+import { Link } from "react-router-dom";
+import Jobs from "shared/Jobs";
+import * as url from "shared/url";
+
+// refreshInterval is the interval at which the jobs are listed again, for as
+// long as some of them are pending.
+export const refreshInterval = 10000;
+
+const typeLabels: { [type: number]: string } = {
+  [JobType.INSTALL]: "Install",
+  [JobType.UPGRADE]: "Upgrade",
+};
+
+const stateLabels: { [state: number]: string } = {
+  [JobState.PENDING]: "Pending",
+  [JobState.SUCCEEDED]: "Succeeded",
+  [JobState.FAILED]: "Failed",
+  [JobState.CANCELLED]: "Cancelled",
+  [JobState.EXPIRED]: "Expired",
+};
+
+const tableColumns = [
+  { accessor: "package", Header: "Application" },
+  { accessor: "type", Header: "Operation" },
+  { accessor: "state", Header: "State" },
+  { accessor: "updated", Header: "Last Update" },
+  { accessor: "actions", Header: "" },
+];
+
+// JobList shows the recent installs and upgrades of the current user, which
+// are followed by the kubeapps-apis server until their reconciliation
+// finishes. Nothing is shown if the user has no jobs or the jobs are not
+// enabled.
+function JobList() {
+  const [jobs, setJobs] = React.useState<Job[]>();
+  const [error, setError] = React.useState<Error>();
+
+  React.useEffect(() => {
+    let timer: ReturnType<typeof setTimeout>;
+    let cancelled = false;
+    const list = async () => {
+      try {
+        const userJobs = await Jobs.list();
+        if (cancelled) {
+          return;
+        }
+        setJobs(userJobs);
+        setError(undefined);
+        if (userJobs?.some(job => job.state === JobState.PENDING)) {
+          timer = setTimeout(list, refreshInterval);
+        }
+      } catch (e: any) {
+        if (!cancelled) {
+          setError(e);
+        }
+      }
+    };
+    list();
+    return () => {
+      cancelled = true;
+      clearTimeout(timer);
+    };
+  }, []);
+
+  const dismiss = async (id: string) => {
+    try {
+      await Jobs.delete(id);
+      setJobs(current => current?.filter(job => job.id !== id));
+    } catch (e: any) {
+      setError(e);
+    }
+  };
+
+  if (error) {
+    return <ErrorAlert error={error} />;
+  }
+  if (!jobs?.length) {
+    return null;
+  }
+  const data = jobs.map(job => ({
+    package: (
+      <Link to={url.app.apps.get(new InstalledPackageReference(job.installedPackageRef))}>
+        {job.installedPackageRef?.identifier}
+      </Link>
+    ),
+    type: typeLabels[job.type],
+    state: (
+      <>
+        {stateLabels[job.state]}
+        {job.status?.userReason && ` (${job.status.userReason})`}
+      </>
+    ),
+    updated: new Date(job.updateTime).toLocaleString(),
+    actions:
+      job.state !== JobState.PENDING ? (
+        <CdsButton action="flat" size="sm" onClick={() => dismiss(job.id)}>
+          <CdsIcon shape="times" /> Dismiss
+        </CdsButton>
+      ) : undefined,
+  }));
+  return (
+    <section className="job-list">
+      <h3>Recent Jobs</h3>
+      <Table valign="center" columns={tableColumns} data={data} />
+    </section>
+  );
+}
+
+export default JobList;
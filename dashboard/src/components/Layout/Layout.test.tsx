@@ -2,7 +2,9 @@
 // SPDX-License-Identifier: Apache-2.0
 
 import actions from "actions";
+import NotificationToasts from "components/NotificationToasts";
 import * as ReactRedux from "react-redux";
+import Notifications from "shared/Notifications";
 import { getStore, initialState, mountWrapper } from "shared/specs/mountWrapper";
 import { IStoreState } from "shared/types";
 import Layout from "./Layout";
@@ -16,6 +18,12 @@ beforeEach(() => {
   };
   const mockDispatch = jest.fn(res => res);
   spyOnUseDispatch = jest.spyOn(ReactRedux, "useDispatch").mockReturnValue(mockDispatch);
+  // The stream of notifications stays open.
+  jest.spyOn(Notifications, "watch").mockImplementation(() =>
+    (async function* () {
+      await new Promise(() => {});
+    })(),
+  );
 });
 
 afterEach(() => {
@@ -61,3 +69,16 @@ it("does not fetch resource kinds when operators disabled", () => {
 
   expect(actions.kube.getResourceKinds).not.toHaveBeenCalled();
 });
+
+it("shows the notifications of an authenticated user", () => {
+  const wrapper = mountWrapper(getStore(defaultState), <Layout />);
+
+  expect(wrapper.find(NotificationToasts)).toExist();
+});
+
+it("does not show the notifications before the user is authenticated", () => {
+  const state = { ...defaultState, auth: { ...defaultState.auth, authenticated: false } };
+  const wrapper = mountWrapper(getStore(state), <Layout />);
+
+  expect(wrapper.find(NotificationToasts)).not.toExist();
+});
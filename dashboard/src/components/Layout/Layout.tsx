@@ -6,6 +6,7 @@ import actions from "actions";
 import AlertGroup from "components/AlertGroup";
 import Column from "components/Column";
 import Header from "components/Header";
+import NotificationToasts from "components/NotificationToasts";
 import React from "react";
 import { ErrorBoundary, FallbackProps } from "react-error-boundary";
 import { useDispatch, useSelector } from "react-redux";
@@ -65,6 +66,7 @@ function Layout({ children }: any) {
           </div>
         </div>
       </main>
+      {authenticated && <NotificationToasts />}
     </section>
   );
 }
@@ -0,0 +1,121 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+import { Code, ConnectError } from "@bufbuild/connect";
+import { CdsAlert, CdsAlertGroup } from "@cds/react/alert";
+import { act } from "@testing-library/react";
+import { mount } from "enzyme";
+import {
+  Notification,
+  NotificationType,
+  WatchNotificationsResponse,
+} from "gen/kubeappsapis/core/notifications/v1alpha1/notifications_pb";
+import Notifications from "shared/Notifications";
+import NotificationToasts, { toastTimeout } from "./NotificationToasts";
+
+const installed = new Notification({
+  id: BigInt(1),
+  type: NotificationType.INSTALL_COMPLETED,
+  message: 'The package "my-apache" in the namespace "apps" was installed',
+});
+
+const failed = new Notification({
+  id: BigInt(2),
+  type: NotificationType.UPGRADE_FAILED,
+  message: 'The upgrade of the package "my-apache" in the namespace "apps" failed',
+  reason: "timed out",
+});
+
+// streamOf returns a stream of the given notifications, which stays open
+// until aborted.
+function streamOf(notifications: Notification[]) {
+  return (_afterId: bigint, signal: AbortSignal) =>
+    (async function* () {
+      for (const notification of notifications) {
+        yield new WatchNotificationsResponse({ notification });
+      }
+      await new Promise(resolve => signal.addEventListener("abort", resolve));
+    })();
+}
+
+// receive lets the component receive the notifications of the stream.
+async function receive() {
+  await act(async () => {
+    for (let i = 0; i < 10; i++) {
+      await Promise.resolve();
+    }
+  });
+}
+
+afterEach(() => {
+  jest.useRealTimers();
+  jest.restoreAllMocks();
+});
+
+it("shows the notifications as toasts", async () => {
+  jest.spyOn(Notifications, "watch").mockImplementation(streamOf([installed, failed]));
+
+  const wrapper = mount(<NotificationToasts />);
+  await receive();
+  wrapper.update();
+
+  const groups = wrapper.find(CdsAlertGroup);
+  expect(groups).toHaveLength(2);
+  expect(groups.at(0).prop("status")).toBe("success");
+  expect(groups.at(0).text()).toBe(installed.message);
+  expect(groups.at(1).prop("status")).toBe("danger");
+  expect(groups.at(1).text()).toBe(`${failed.message}: timed out`);
+  expect(Notifications.watch).toHaveBeenCalledWith(BigInt(0), expect.any(AbortSignal));
+  wrapper.unmount();
+});
+
+it("closes a toast", async () => {
+  jest.spyOn(Notifications, "watch").mockImplementation(streamOf([installed]));
+
+  const wrapper = mount(<NotificationToasts />);
+  await receive();
+  wrapper.update();
+  act(() => {
+    (wrapper.find(CdsAlert).prop("onCloseChange") as any)();
+  });
+  wrapper.update();
+
+  expect(wrapper.find(CdsAlertGroup)).toHaveLength(0);
+  wrapper.unmount();
+});
+
+it("hides a toast after a while", async () => {
+  jest.useFakeTimers();
+  jest.spyOn(Notifications, "watch").mockImplementation(streamOf([installed]));
+
+  const wrapper = mount(<NotificationToasts />);
+  await receive();
+  wrapper.update();
+  expect(wrapper.find(CdsAlertGroup)).toHaveLength(1);
+
+  act(() => {
+    jest.advanceTimersByTime(toastTimeout);
+  });
+  wrapper.update();
+  expect(wrapper.find(CdsAlertGroup)).toHaveLength(0);
+  wrapper.unmount();
+});
+
+it("does not watch again when the notifications are disabled", async () => {
+  jest.useFakeTimers();
+  jest.spyOn(Notifications, "watch").mockImplementation(() =>
+    (async function* () {
+      throw new ConnectError("The notifications are not enabled", Code.FailedPrecondition);
+    })(),
+  );
+
+  const wrapper = mount(<NotificationToasts />);
+  await receive();
+  act(() => {
+    jest.runOnlyPendingTimers();
+  });
+
+  expect(Notifications.watch).toHaveBeenCalledTimes(1);
+  expect(wrapper.find(CdsAlertGroup)).toHaveLength(0);
+  wrapper.unmount();
+});
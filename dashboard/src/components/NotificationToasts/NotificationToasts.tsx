@@ -0,0 +1,85 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+import { Code, ConnectError } from "@bufbuild/connect";
+import { protoInt64 } from "@bufbuild/protobuf";
+import { CdsAlert, CdsAlertGroup } from "@cds/react/alert";
+import {
+  Notification,
+  NotificationType,
+} from "gen/kubeappsapis/core/notifications/v1alpha1/notifications_pb";
+import React from "react";
+import Notifications from "shared/Notifications";
+import "./NotificationToasts.css";
+
+// toastTimeout is the time during which a toast is shown, unless closed.
+export const toastTimeout = 10000;
+
+// reconnectDelay is the time after which the stream is opened again once
+// closed, resuming from the last notification received.
+export const reconnectDelay = 5000;
+
+const failedTypes = [
+  NotificationType.INSTALL_FAILED,
+  NotificationType.UPGRADE_FAILED,
+  NotificationType.REPOSITORY_SYNC_FAILED,
+];
+
+// NotificationToasts shows the notifications of the current user as toasts,
+// for as long as it is mounted.
+function NotificationToasts() {
+  const [toasts, setToasts] = React.useState<Notification[]>([]);
+  const close = (id: bigint) => setToasts(current => current.filter(toast => toast.id !== id));
+
+  React.useEffect(() => {
+    const controller = new AbortController();
+    const timers: ReturnType<typeof setTimeout>[] = [];
+    let lastId = protoInt64.zero;
+    const watch = async () => {
+      try {
+        for await (const { notification } of Notifications.watch(lastId, controller.signal)) {
+          if (!notification) {
+            continue;
+          }
+          lastId = notification.id;
+          setToasts(current => [...current, notification]);
+          timers.push(setTimeout(() => close(notification.id), toastTimeout));
+        }
+      } catch (e: any) {
+        // The stream is not opened again if the notifications are disabled.
+        if (ConnectError.from(e).code === Code.FailedPrecondition) {
+          return;
+        }
+      }
+      if (!controller.signal.aborted) {
+        timers.push(setTimeout(watch, reconnectDelay));
+      }
+    };
+    watch();
+    return () => {
+      controller.abort();
+      timers.forEach(clearTimeout);
+    };
+  }, []);
+
+  if (toasts.length === 0) {
+    return null;
+  }
+  return (
+    <div className="notification-toasts" role="status">
+      {toasts.map(toast => (
+        <CdsAlertGroup
+          key={toast.id.toString()}
+          status={failedTypes.includes(toast.type) ? "danger" : "success"}
+        >
+          <CdsAlert closable={true} onCloseChange={() => close(toast.id)}>
+            {toast.message}
+            {toast.reason && `: ${toast.reason}`}
+          </CdsAlert>
+        </CdsAlertGroup>
+      ))}
+    </div>
+  );
+}
+
+export default NotificationToasts;
@@ -0,0 +1,6 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+import NotificationToasts from "./NotificationToasts";
+
+export default NotificationToasts;
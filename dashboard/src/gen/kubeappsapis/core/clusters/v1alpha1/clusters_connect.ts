@@ -0,0 +1,68 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+// @generated by protoc-gen-connect-es v0.13.0 with parameter "target=ts,import_extension=none"
+// @generated from file kubeappsapis/core/clusters/v1alpha1/clusters.proto (package kubeappsapis.core.clusters.v1alpha1, syntax proto3)
+/* eslint-disable */
+// @ts-nocheck
+
+import {
+  DeregisterClusterRequest,
+  DeregisterClusterResponse,
+  GetClustersRequest,
+  GetClustersResponse,
+  RegisterClusterRequest,
+  RegisterClusterResponse,
+} from "./clusters_pb";
+import { MethodKind } from "@bufbuild/protobuf";
+
+/**
+ * The Clusters service registers and deregisters additional clusters at
+ * runtime, in addition to the ones of the clusters config, without restarting
+ * Kubeapps.
+ *
+ * The registered clusters are stored in the "kubeapps-registered-clusters"
+ * Secret in the namespace where Kubeapps is installed, so only the users
+ * allowed to update this Secret can register or deregister clusters.
+ *
+ * @generated from service kubeappsapis.core.clusters.v1alpha1.ClustersService
+ */
+export const ClustersService = {
+  typeName: "kubeappsapis.core.clusters.v1alpha1.ClustersService",
+  methods: {
+    /**
+     * GetClusters returns the configured and the registered clusters.
+     *
+     * @generated from rpc kubeappsapis.core.clusters.v1alpha1.ClustersService.GetClusters
+     */
+    getClusters: {
+      name: "GetClusters",
+      I: GetClustersRequest,
+      O: GetClustersResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * RegisterCluster registers an additional cluster, replacing its previous
+     * registration if any.
+     *
+     * @generated from rpc kubeappsapis.core.clusters.v1alpha1.ClustersService.RegisterCluster
+     */
+    registerCluster: {
+      name: "RegisterCluster",
+      I: RegisterClusterRequest,
+      O: RegisterClusterResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * DeregisterCluster deregisters a cluster registered at runtime.
+     *
+     * @generated from rpc kubeappsapis.core.clusters.v1alpha1.ClustersService.DeregisterCluster
+     */
+    deregisterCluster: {
+      name: "DeregisterCluster",
+      I: DeregisterClusterRequest,
+      O: DeregisterClusterResponse,
+      kind: MethodKind.Unary,
+    },
+  },
+} as const;
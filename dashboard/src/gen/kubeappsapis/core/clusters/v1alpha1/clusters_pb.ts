@@ -0,0 +1,523 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+// @generated by protoc-gen-es v1.3.1 with parameter "target=ts,import_extension=none"
+// @generated from file kubeappsapis/core/clusters/v1alpha1/clusters.proto (package kubeappsapis.core.clusters.v1alpha1, syntax proto3)
+/* eslint-disable */
+// @ts-nocheck
+
+import type {
+  BinaryReadOptions,
+  FieldList,
+  JsonReadOptions,
+  JsonValue,
+  PartialMessage,
+  PlainMessage,
+} from "@bufbuild/protobuf";
+import { Message, proto3 } from "@bufbuild/protobuf";
+
+/**
+ * GetClustersRequest
+ *
+ * Request for GetClusters
+ *
+ * @generated from message kubeappsapis.core.clusters.v1alpha1.GetClustersRequest
+ */
+export class GetClustersRequest extends Message<GetClustersRequest> {
+  constructor(data?: PartialMessage<GetClustersRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.clusters.v1alpha1.GetClustersRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => []);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): GetClustersRequest {
+    return new GetClustersRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): GetClustersRequest {
+    return new GetClustersRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetClustersRequest {
+    return new GetClustersRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: GetClustersRequest | PlainMessage<GetClustersRequest> | undefined,
+    b: GetClustersRequest | PlainMessage<GetClustersRequest> | undefined,
+  ): boolean {
+    return proto3.util.equals(GetClustersRequest, a, b);
+  }
+}
+
+/**
+ * GetClustersResponse
+ *
+ * Response for GetClusters
+ *
+ * @generated from message kubeappsapis.core.clusters.v1alpha1.GetClustersResponse
+ */
+export class GetClustersResponse extends Message<GetClustersResponse> {
+  /**
+   * Clusters
+   *
+   * The configured and the registered clusters, sorted by name. Their service
+   * tokens are never returned.
+   *
+   * @generated from field: repeated kubeappsapis.core.clusters.v1alpha1.Cluster clusters = 1;
+   */
+  clusters: Cluster[] = [];
+
+  constructor(data?: PartialMessage<GetClustersResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.clusters.v1alpha1.GetClustersResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "clusters", kind: "message", T: Cluster, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): GetClustersResponse {
+    return new GetClustersResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): GetClustersResponse {
+    return new GetClustersResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetClustersResponse {
+    return new GetClustersResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: GetClustersResponse | PlainMessage<GetClustersResponse> | undefined,
+    b: GetClustersResponse | PlainMessage<GetClustersResponse> | undefined,
+  ): boolean {
+    return proto3.util.equals(GetClustersResponse, a, b);
+  }
+}
+
+/**
+ * RegisterClusterRequest
+ *
+ * Request for RegisterCluster
+ *
+ * @generated from message kubeappsapis.core.clusters.v1alpha1.RegisterClusterRequest
+ */
+export class RegisterClusterRequest extends Message<RegisterClusterRequest> {
+  /**
+   * Cluster
+   *
+   * The cluster to register.
+   *
+   * @generated from field: kubeappsapis.core.clusters.v1alpha1.Cluster cluster = 1;
+   */
+  cluster?: Cluster;
+
+  constructor(data?: PartialMessage<RegisterClusterRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.clusters.v1alpha1.RegisterClusterRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "cluster", kind: "message", T: Cluster },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): RegisterClusterRequest {
+    return new RegisterClusterRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): RegisterClusterRequest {
+    return new RegisterClusterRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): RegisterClusterRequest {
+    return new RegisterClusterRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: RegisterClusterRequest | PlainMessage<RegisterClusterRequest> | undefined,
+    b: RegisterClusterRequest | PlainMessage<RegisterClusterRequest> | undefined,
+  ): boolean {
+    return proto3.util.equals(RegisterClusterRequest, a, b);
+  }
+}
+
+/**
+ * RegisterClusterResponse
+ *
+ * Response for RegisterCluster
+ *
+ * @generated from message kubeappsapis.core.clusters.v1alpha1.RegisterClusterResponse
+ */
+export class RegisterClusterResponse extends Message<RegisterClusterResponse> {
+  /**
+   * Cluster
+   *
+   * The registered cluster, without its service token.
+   *
+   * @generated from field: kubeappsapis.core.clusters.v1alpha1.Cluster cluster = 1;
+   */
+  cluster?: Cluster;
+
+  constructor(data?: PartialMessage<RegisterClusterResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.clusters.v1alpha1.RegisterClusterResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "cluster", kind: "message", T: Cluster },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): RegisterClusterResponse {
+    return new RegisterClusterResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): RegisterClusterResponse {
+    return new RegisterClusterResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): RegisterClusterResponse {
+    return new RegisterClusterResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: RegisterClusterResponse | PlainMessage<RegisterClusterResponse> | undefined,
+    b: RegisterClusterResponse | PlainMessage<RegisterClusterResponse> | undefined,
+  ): boolean {
+    return proto3.util.equals(RegisterClusterResponse, a, b);
+  }
+}
+
+/**
+ * DeregisterClusterRequest
+ *
+ * Request for DeregisterCluster
+ *
+ * @generated from message kubeappsapis.core.clusters.v1alpha1.DeregisterClusterRequest
+ */
+export class DeregisterClusterRequest extends Message<DeregisterClusterRequest> {
+  /**
+   * Name
+   *
+   * The name of the registered cluster.
+   *
+   * @generated from field: string name = 1;
+   */
+  name = "";
+
+  constructor(data?: PartialMessage<DeregisterClusterRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.clusters.v1alpha1.DeregisterClusterRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): DeregisterClusterRequest {
+    return new DeregisterClusterRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): DeregisterClusterRequest {
+    return new DeregisterClusterRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): DeregisterClusterRequest {
+    return new DeregisterClusterRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: DeregisterClusterRequest | PlainMessage<DeregisterClusterRequest> | undefined,
+    b: DeregisterClusterRequest | PlainMessage<DeregisterClusterRequest> | undefined,
+  ): boolean {
+    return proto3.util.equals(DeregisterClusterRequest, a, b);
+  }
+}
+
+/**
+ * DeregisterClusterResponse
+ *
+ * Response for DeregisterCluster
+ *
+ * @generated from message kubeappsapis.core.clusters.v1alpha1.DeregisterClusterResponse
+ */
+export class DeregisterClusterResponse extends Message<DeregisterClusterResponse> {
+  constructor(data?: PartialMessage<DeregisterClusterResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.clusters.v1alpha1.DeregisterClusterResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => []);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): DeregisterClusterResponse {
+    return new DeregisterClusterResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): DeregisterClusterResponse {
+    return new DeregisterClusterResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): DeregisterClusterResponse {
+    return new DeregisterClusterResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: DeregisterClusterResponse | PlainMessage<DeregisterClusterResponse> | undefined,
+    b: DeregisterClusterResponse | PlainMessage<DeregisterClusterResponse> | undefined,
+  ): boolean {
+    return proto3.util.equals(DeregisterClusterResponse, a, b);
+  }
+}
+
+/**
+ * Cluster
+ *
+ * The configuration with which Kubeapps talks to a cluster.
+ *
+ * @generated from message kubeappsapis.core.clusters.v1alpha1.Cluster
+ */
+export class Cluster extends Message<Cluster> {
+  /**
+   * Name
+   *
+   * The name identifying the cluster in the requests.
+   *
+   * @generated from field: string name = 1;
+   */
+  name = "";
+
+  /**
+   * API service URL
+   *
+   * The https URL of the API server of the cluster.
+   *
+   * @generated from field: string api_service_url = 2;
+   */
+  apiServiceUrl = "";
+
+  /**
+   * Certificate authority data
+   *
+   * The base64-encoded PEM certificate authority of the API server of the
+   * cluster. The system certificate authorities are trusted if empty.
+   *
+   * @generated from field: string certificate_authority_data = 3;
+   */
+  certificateAuthorityData = "";
+
+  /**
+   * CA bundle
+   *
+   * An optional base64-encoded bundle of PEM certificate authorities trusted,
+   * in addition to the system ones, for the outbound requests performed on
+   * behalf of the cluster.
+   *
+   * @generated from field: string ca_bundle = 4;
+   */
+  caBundle = "";
+
+  /**
+   * Service token
+   *
+   * An optional token with which Kubeapps itself accesses the cluster, for
+   * example to list its namespaces. Write only, it is never returned.
+   *
+   * @generated from field: string service_token = 5;
+   */
+  serviceToken = "";
+
+  /**
+   * Insecure
+   *
+   * Whether the certificate of the API server is not verified. Only for test
+   * or development environments.
+   *
+   * @generated from field: bool insecure = 6;
+   */
+  insecure = false;
+
+  /**
+   * Pinniped config
+   *
+   * The optional pinniped-concierge installation with which the credentials
+   * of the users are exchanged for the cluster.
+   *
+   * @generated from field: kubeappsapis.core.clusters.v1alpha1.PinnipedConfig pinniped_config = 7;
+   */
+  pinnipedConfig?: PinnipedConfig;
+
+  /**
+   * Static
+   *
+   * Whether the cluster is defined in the clusters config, rather than
+   * registered at runtime. Output only, the static clusters cannot be
+   * replaced nor deregistered.
+   *
+   * @generated from field: bool static = 8;
+   */
+  static = false;
+
+  constructor(data?: PartialMessage<Cluster>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.clusters.v1alpha1.Cluster";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "api_service_url", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "certificate_authority_data", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "ca_bundle", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "service_token", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 6, name: "insecure", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 7, name: "pinniped_config", kind: "message", T: PinnipedConfig },
+    { no: 8, name: "static", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): Cluster {
+    return new Cluster().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): Cluster {
+    return new Cluster().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): Cluster {
+    return new Cluster().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: Cluster | PlainMessage<Cluster> | undefined,
+    b: Cluster | PlainMessage<Cluster> | undefined,
+  ): boolean {
+    return proto3.util.equals(Cluster, a, b);
+  }
+}
+
+/**
+ * PinnipedConfig
+ *
+ * The pinniped-concierge installation with which the credentials of the
+ * users are exchanged.
+ *
+ * @generated from message kubeappsapis.core.clusters.v1alpha1.PinnipedConfig
+ */
+export class PinnipedConfig extends Message<PinnipedConfig> {
+  /**
+   * Whether the credentials are exchanged with pinniped.
+   *
+   * @generated from field: bool enabled = 1;
+   */
+  enabled = false;
+
+  /**
+   * The namespace of pinniped-concierge.
+   *
+   * @generated from field: string namespace = 2;
+   */
+  namespace = "";
+
+  /**
+   * The type of the pinniped authenticator, e.g. "JWTAuthenticator".
+   *
+   * @generated from field: string authenticator_type = 3;
+   */
+  authenticatorType = "";
+
+  /**
+   * The name of the pinniped authenticator.
+   *
+   * @generated from field: string authenticator_name = 4;
+   */
+  authenticatorName = "";
+
+  constructor(data?: PartialMessage<PinnipedConfig>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.clusters.v1alpha1.PinnipedConfig";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "enabled", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 2, name: "namespace", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "authenticator_type", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "authenticator_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): PinnipedConfig {
+    return new PinnipedConfig().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): PinnipedConfig {
+    return new PinnipedConfig().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): PinnipedConfig {
+    return new PinnipedConfig().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: PinnipedConfig | PlainMessage<PinnipedConfig> | undefined,
+    b: PinnipedConfig | PlainMessage<PinnipedConfig> | undefined,
+  ): boolean {
+    return proto3.util.equals(PinnipedConfig, a, b);
+  }
+}
@@ -0,0 +1,87 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+// @generated by protoc-gen-connect-es v0.13.0 with parameter "target=ts,import_extension=none"
+// @generated from file kubeappsapis/core/favorites/v1alpha1/favorites.proto (package kubeappsapis.core.favorites.v1alpha1, syntax proto3)
+/* eslint-disable */
+// @ts-nocheck
+
+import {
+  AddRecentInstallRequest,
+  AddRecentInstallResponse,
+  AddStarredPackageRequest,
+  AddStarredPackageResponse,
+  GetUserFavoritesRequest,
+  GetUserFavoritesResponse,
+  RemoveRecentInstallRequest,
+  RemoveRecentInstallResponse,
+  RemoveStarredPackageRequest,
+  RemoveStarredPackageResponse,
+} from "./favorites_pb";
+import { MethodKind } from "@bufbuild/protobuf";
+
+/**
+ * @generated from service kubeappsapis.core.favorites.v1alpha1.FavoritesService
+ */
+export const FavoritesService = {
+  typeName: "kubeappsapis.core.favorites.v1alpha1.FavoritesService",
+  methods: {
+    /**
+     * GetUserFavorites returns the starred packages and the recent installs of
+     * the calling user.
+     *
+     * @generated from rpc kubeappsapis.core.favorites.v1alpha1.FavoritesService.GetUserFavorites
+     */
+    getUserFavorites: {
+      name: "GetUserFavorites",
+      I: GetUserFavoritesRequest,
+      O: GetUserFavoritesResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * AddStarredPackage stars an available package for the calling user.
+     *
+     * @generated from rpc kubeappsapis.core.favorites.v1alpha1.FavoritesService.AddStarredPackage
+     */
+    addStarredPackage: {
+      name: "AddStarredPackage",
+      I: AddStarredPackageRequest,
+      O: AddStarredPackageResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * RemoveStarredPackage unstars an available package for the calling user.
+     *
+     * @generated from rpc kubeappsapis.core.favorites.v1alpha1.FavoritesService.RemoveStarredPackage
+     */
+    removeStarredPackage: {
+      name: "RemoveStarredPackage",
+      I: RemoveStarredPackageRequest,
+      O: RemoveStarredPackageResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * AddRecentInstall records a package installed by the calling user.
+     *
+     * @generated from rpc kubeappsapis.core.favorites.v1alpha1.FavoritesService.AddRecentInstall
+     */
+    addRecentInstall: {
+      name: "AddRecentInstall",
+      I: AddRecentInstallRequest,
+      O: AddRecentInstallResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * RemoveRecentInstall removes an installed package from the recent installs
+     * of the calling user.
+     *
+     * @generated from rpc kubeappsapis.core.favorites.v1alpha1.FavoritesService.RemoveRecentInstall
+     */
+    removeRecentInstall: {
+      name: "RemoveRecentInstall",
+      I: RemoveRecentInstallRequest,
+      O: RemoveRecentInstallResponse,
+      kind: MethodKind.Unary,
+    },
+  },
+} as const;
@@ -0,0 +1,796 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+// @generated by protoc-gen-es v1.3.1 with parameter "target=ts,import_extension=none"
+// @generated from file kubeappsapis/core/favorites/v1alpha1/favorites.proto (package kubeappsapis.core.favorites.v1alpha1, syntax proto3)
+/* eslint-disable */
+// @ts-nocheck
+
+import type {
+  BinaryReadOptions,
+  FieldList,
+  JsonReadOptions,
+  JsonValue,
+  PartialMessage,
+  PlainMessage,
+} from "@bufbuild/protobuf";
+import { Message, proto3 } from "@bufbuild/protobuf";
+import {
+  AvailablePackageReference,
+  InstalledPackageReference,
+} from "../../packages/v1/packages_pb";
+
+/**
+ * GetUserFavoritesRequest
+ *
+ * Request for GetUserFavorites
+ *
+ * @generated from message kubeappsapis.core.favorites.v1alpha1.GetUserFavoritesRequest
+ */
+export class GetUserFavoritesRequest extends Message<GetUserFavoritesRequest> {
+  constructor(data?: PartialMessage<GetUserFavoritesRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.favorites.v1alpha1.GetUserFavoritesRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => []);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): GetUserFavoritesRequest {
+    return new GetUserFavoritesRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): GetUserFavoritesRequest {
+    return new GetUserFavoritesRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetUserFavoritesRequest {
+    return new GetUserFavoritesRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: GetUserFavoritesRequest | PlainMessage<GetUserFavoritesRequest> | undefined,
+    b: GetUserFavoritesRequest | PlainMessage<GetUserFavoritesRequest> | undefined,
+  ): boolean {
+    return proto3.util.equals(GetUserFavoritesRequest, a, b);
+  }
+}
+
+/**
+ * GetUserFavoritesResponse
+ *
+ * Response for GetUserFavorites
+ *
+ * @generated from message kubeappsapis.core.favorites.v1alpha1.GetUserFavoritesResponse
+ */
+export class GetUserFavoritesResponse extends Message<GetUserFavoritesResponse> {
+  /**
+   * Favorites
+   *
+   * The favorites of the user. Empty if the user has not recorded any yet.
+   *
+   * @generated from field: kubeappsapis.core.favorites.v1alpha1.UserFavorites favorites = 1;
+   */
+  favorites?: UserFavorites;
+
+  constructor(data?: PartialMessage<GetUserFavoritesResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.favorites.v1alpha1.GetUserFavoritesResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "favorites", kind: "message", T: UserFavorites },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): GetUserFavoritesResponse {
+    return new GetUserFavoritesResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): GetUserFavoritesResponse {
+    return new GetUserFavoritesResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetUserFavoritesResponse {
+    return new GetUserFavoritesResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: GetUserFavoritesResponse | PlainMessage<GetUserFavoritesResponse> | undefined,
+    b: GetUserFavoritesResponse | PlainMessage<GetUserFavoritesResponse> | undefined,
+  ): boolean {
+    return proto3.util.equals(GetUserFavoritesResponse, a, b);
+  }
+}
+
+/**
+ * AddStarredPackageRequest
+ *
+ * Request for AddStarredPackage
+ *
+ * @generated from message kubeappsapis.core.favorites.v1alpha1.AddStarredPackageRequest
+ */
+export class AddStarredPackageRequest extends Message<AddStarredPackageRequest> {
+  /**
+   * Starred package
+   *
+   * The package to be starred. A package already starred is moved to the top
+   * of the starred packages.
+   *
+   * @generated from field: kubeappsapis.core.favorites.v1alpha1.StarredPackage starred_package = 1;
+   */
+  starredPackage?: StarredPackage;
+
+  constructor(data?: PartialMessage<AddStarredPackageRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.favorites.v1alpha1.AddStarredPackageRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "starred_package", kind: "message", T: StarredPackage },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): AddStarredPackageRequest {
+    return new AddStarredPackageRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): AddStarredPackageRequest {
+    return new AddStarredPackageRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): AddStarredPackageRequest {
+    return new AddStarredPackageRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: AddStarredPackageRequest | PlainMessage<AddStarredPackageRequest> | undefined,
+    b: AddStarredPackageRequest | PlainMessage<AddStarredPackageRequest> | undefined,
+  ): boolean {
+    return proto3.util.equals(AddStarredPackageRequest, a, b);
+  }
+}
+
+/**
+ * AddStarredPackageResponse
+ *
+ * Response for AddStarredPackage
+ *
+ * @generated from message kubeappsapis.core.favorites.v1alpha1.AddStarredPackageResponse
+ */
+export class AddStarredPackageResponse extends Message<AddStarredPackageResponse> {
+  /**
+   * Starred packages
+   *
+   * The packages starred by the user, the most recent first.
+   *
+   * @generated from field: repeated kubeappsapis.core.favorites.v1alpha1.StarredPackage starred_packages = 1;
+   */
+  starredPackages: StarredPackage[] = [];
+
+  constructor(data?: PartialMessage<AddStarredPackageResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.favorites.v1alpha1.AddStarredPackageResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "starred_packages", kind: "message", T: StarredPackage, repeated: true },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): AddStarredPackageResponse {
+    return new AddStarredPackageResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): AddStarredPackageResponse {
+    return new AddStarredPackageResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): AddStarredPackageResponse {
+    return new AddStarredPackageResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: AddStarredPackageResponse | PlainMessage<AddStarredPackageResponse> | undefined,
+    b: AddStarredPackageResponse | PlainMessage<AddStarredPackageResponse> | undefined,
+  ): boolean {
+    return proto3.util.equals(AddStarredPackageResponse, a, b);
+  }
+}
+
+/**
+ * RemoveStarredPackageRequest
+ *
+ * Request for RemoveStarredPackage
+ *
+ * @generated from message kubeappsapis.core.favorites.v1alpha1.RemoveStarredPackageRequest
+ */
+export class RemoveStarredPackageRequest extends Message<RemoveStarredPackageRequest> {
+  /**
+   * A reference uniquely identifying the starred package.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.AvailablePackageReference available_package_ref = 1;
+   */
+  availablePackageRef?: AvailablePackageReference;
+
+  constructor(data?: PartialMessage<RemoveStarredPackageRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.favorites.v1alpha1.RemoveStarredPackageRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "available_package_ref", kind: "message", T: AvailablePackageReference },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): RemoveStarredPackageRequest {
+    return new RemoveStarredPackageRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): RemoveStarredPackageRequest {
+    return new RemoveStarredPackageRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): RemoveStarredPackageRequest {
+    return new RemoveStarredPackageRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: RemoveStarredPackageRequest | PlainMessage<RemoveStarredPackageRequest> | undefined,
+    b: RemoveStarredPackageRequest | PlainMessage<RemoveStarredPackageRequest> | undefined,
+  ): boolean {
+    return proto3.util.equals(RemoveStarredPackageRequest, a, b);
+  }
+}
+
+/**
+ * RemoveStarredPackageResponse
+ *
+ * Response for RemoveStarredPackage
+ *
+ * @generated from message kubeappsapis.core.favorites.v1alpha1.RemoveStarredPackageResponse
+ */
+export class RemoveStarredPackageResponse extends Message<RemoveStarredPackageResponse> {
+  /**
+   * Starred packages
+   *
+   * The packages starred by the user, the most recent first.
+   *
+   * @generated from field: repeated kubeappsapis.core.favorites.v1alpha1.StarredPackage starred_packages = 1;
+   */
+  starredPackages: StarredPackage[] = [];
+
+  constructor(data?: PartialMessage<RemoveStarredPackageResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.favorites.v1alpha1.RemoveStarredPackageResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "starred_packages", kind: "message", T: StarredPackage, repeated: true },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): RemoveStarredPackageResponse {
+    return new RemoveStarredPackageResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): RemoveStarredPackageResponse {
+    return new RemoveStarredPackageResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): RemoveStarredPackageResponse {
+    return new RemoveStarredPackageResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: RemoveStarredPackageResponse | PlainMessage<RemoveStarredPackageResponse> | undefined,
+    b: RemoveStarredPackageResponse | PlainMessage<RemoveStarredPackageResponse> | undefined,
+  ): boolean {
+    return proto3.util.equals(RemoveStarredPackageResponse, a, b);
+  }
+}
+
+/**
+ * AddRecentInstallRequest
+ *
+ * Request for AddRecentInstall
+ *
+ * @generated from message kubeappsapis.core.favorites.v1alpha1.AddRecentInstallRequest
+ */
+export class AddRecentInstallRequest extends Message<AddRecentInstallRequest> {
+  /**
+   * Recent install
+   *
+   * The installed package to be recorded. Only the latest recent installs are
+   * kept.
+   *
+   * @generated from field: kubeappsapis.core.favorites.v1alpha1.RecentInstall recent_install = 1;
+   */
+  recentInstall?: RecentInstall;
+
+  constructor(data?: PartialMessage<AddRecentInstallRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.favorites.v1alpha1.AddRecentInstallRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "recent_install", kind: "message", T: RecentInstall },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): AddRecentInstallRequest {
+    return new AddRecentInstallRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): AddRecentInstallRequest {
+    return new AddRecentInstallRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): AddRecentInstallRequest {
+    return new AddRecentInstallRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: AddRecentInstallRequest | PlainMessage<AddRecentInstallRequest> | undefined,
+    b: AddRecentInstallRequest | PlainMessage<AddRecentInstallRequest> | undefined,
+  ): boolean {
+    return proto3.util.equals(AddRecentInstallRequest, a, b);
+  }
+}
+
+/**
+ * AddRecentInstallResponse
+ *
+ * Response for AddRecentInstall
+ *
+ * @generated from message kubeappsapis.core.favorites.v1alpha1.AddRecentInstallResponse
+ */
+export class AddRecentInstallResponse extends Message<AddRecentInstallResponse> {
+  /**
+   * Recent installs
+   *
+   * The packages recently installed by the user, the most recent first.
+   *
+   * @generated from field: repeated kubeappsapis.core.favorites.v1alpha1.RecentInstall recent_installs = 1;
+   */
+  recentInstalls: RecentInstall[] = [];
+
+  constructor(data?: PartialMessage<AddRecentInstallResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.favorites.v1alpha1.AddRecentInstallResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "recent_installs", kind: "message", T: RecentInstall, repeated: true },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): AddRecentInstallResponse {
+    return new AddRecentInstallResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): AddRecentInstallResponse {
+    return new AddRecentInstallResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): AddRecentInstallResponse {
+    return new AddRecentInstallResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: AddRecentInstallResponse | PlainMessage<AddRecentInstallResponse> | undefined,
+    b: AddRecentInstallResponse | PlainMessage<AddRecentInstallResponse> | undefined,
+  ): boolean {
+    return proto3.util.equals(AddRecentInstallResponse, a, b);
+  }
+}
+
+/**
+ * RemoveRecentInstallRequest
+ *
+ * Request for RemoveRecentInstall
+ *
+ * @generated from message kubeappsapis.core.favorites.v1alpha1.RemoveRecentInstallRequest
+ */
+export class RemoveRecentInstallRequest extends Message<RemoveRecentInstallRequest> {
+  /**
+   * A reference uniquely identifying the installed package.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.InstalledPackageReference installed_package_ref = 1;
+   */
+  installedPackageRef?: InstalledPackageReference;
+
+  constructor(data?: PartialMessage<RemoveRecentInstallRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.favorites.v1alpha1.RemoveRecentInstallRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "installed_package_ref", kind: "message", T: InstalledPackageReference },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): RemoveRecentInstallRequest {
+    return new RemoveRecentInstallRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): RemoveRecentInstallRequest {
+    return new RemoveRecentInstallRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): RemoveRecentInstallRequest {
+    return new RemoveRecentInstallRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: RemoveRecentInstallRequest | PlainMessage<RemoveRecentInstallRequest> | undefined,
+    b: RemoveRecentInstallRequest | PlainMessage<RemoveRecentInstallRequest> | undefined,
+  ): boolean {
+    return proto3.util.equals(RemoveRecentInstallRequest, a, b);
+  }
+}
+
+/**
+ * RemoveRecentInstallResponse
+ *
+ * Response for RemoveRecentInstall
+ *
+ * @generated from message kubeappsapis.core.favorites.v1alpha1.RemoveRecentInstallResponse
+ */
+export class RemoveRecentInstallResponse extends Message<RemoveRecentInstallResponse> {
+  /**
+   * Recent installs
+   *
+   * The packages recently installed by the user, the most recent first.
+   *
+   * @generated from field: repeated kubeappsapis.core.favorites.v1alpha1.RecentInstall recent_installs = 1;
+   */
+  recentInstalls: RecentInstall[] = [];
+
+  constructor(data?: PartialMessage<RemoveRecentInstallResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.favorites.v1alpha1.RemoveRecentInstallResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "recent_installs", kind: "message", T: RecentInstall, repeated: true },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): RemoveRecentInstallResponse {
+    return new RemoveRecentInstallResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): RemoveRecentInstallResponse {
+    return new RemoveRecentInstallResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): RemoveRecentInstallResponse {
+    return new RemoveRecentInstallResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: RemoveRecentInstallResponse | PlainMessage<RemoveRecentInstallResponse> | undefined,
+    b: RemoveRecentInstallResponse | PlainMessage<RemoveRecentInstallResponse> | undefined,
+  ): boolean {
+    return proto3.util.equals(RemoveRecentInstallResponse, a, b);
+  }
+}
+
+/**
+ * UserFavorites
+ *
+ * The favorites of a user.
+ *
+ * @generated from message kubeappsapis.core.favorites.v1alpha1.UserFavorites
+ */
+export class UserFavorites extends Message<UserFavorites> {
+  /**
+   * Starred packages
+   *
+   * The packages starred by the user, the most recent first.
+   *
+   * @generated from field: repeated kubeappsapis.core.favorites.v1alpha1.StarredPackage starred_packages = 1;
+   */
+  starredPackages: StarredPackage[] = [];
+
+  /**
+   * Recent installs
+   *
+   * The packages recently installed by the user, the most recent first.
+   *
+   * @generated from field: repeated kubeappsapis.core.favorites.v1alpha1.RecentInstall recent_installs = 2;
+   */
+  recentInstalls: RecentInstall[] = [];
+
+  constructor(data?: PartialMessage<UserFavorites>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.favorites.v1alpha1.UserFavorites";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "starred_packages", kind: "message", T: StarredPackage, repeated: true },
+    { no: 2, name: "recent_installs", kind: "message", T: RecentInstall, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): UserFavorites {
+    return new UserFavorites().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): UserFavorites {
+    return new UserFavorites().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): UserFavorites {
+    return new UserFavorites().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: UserFavorites | PlainMessage<UserFavorites> | undefined,
+    b: UserFavorites | PlainMessage<UserFavorites> | undefined,
+  ): boolean {
+    return proto3.util.equals(UserFavorites, a, b);
+  }
+}
+
+/**
+ * StarredPackage
+ *
+ * An available package starred by a user.
+ *
+ * @generated from message kubeappsapis.core.favorites.v1alpha1.StarredPackage
+ */
+export class StarredPackage extends Message<StarredPackage> {
+  /**
+   * A reference uniquely identifying the available package.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.AvailablePackageReference available_package_ref = 1;
+   */
+  availablePackageRef?: AvailablePackageReference;
+
+  /**
+   * Display name
+   *
+   * The name of the package as displayed in the shortcut.
+   *
+   * @generated from field: string display_name = 2;
+   */
+  displayName = "";
+
+  /**
+   * Icon URL
+   *
+   * Optional URL of the icon displayed in the shortcut.
+   *
+   * @generated from field: string icon_url = 3;
+   */
+  iconUrl = "";
+
+  /**
+   * Time
+   *
+   * The time (RFC3339) at which the package was starred, set by the server.
+   *
+   * @generated from field: string time = 4;
+   */
+  time = "";
+
+  constructor(data?: PartialMessage<StarredPackage>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.favorites.v1alpha1.StarredPackage";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "available_package_ref", kind: "message", T: AvailablePackageReference },
+    { no: 2, name: "display_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "icon_url", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "time", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): StarredPackage {
+    return new StarredPackage().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): StarredPackage {
+    return new StarredPackage().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): StarredPackage {
+    return new StarredPackage().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: StarredPackage | PlainMessage<StarredPackage> | undefined,
+    b: StarredPackage | PlainMessage<StarredPackage> | undefined,
+  ): boolean {
+    return proto3.util.equals(StarredPackage, a, b);
+  }
+}
+
+/**
+ * RecentInstall
+ *
+ * A package recently installed by a user.
+ *
+ * @generated from message kubeappsapis.core.favorites.v1alpha1.RecentInstall
+ */
+export class RecentInstall extends Message<RecentInstall> {
+  /**
+   * A reference uniquely identifying the installed package.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.InstalledPackageReference installed_package_ref = 1;
+   */
+  installedPackageRef?: InstalledPackageReference;
+
+  /**
+   * A reference to the available package which was installed.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.AvailablePackageReference available_package_ref = 2;
+   */
+  availablePackageRef?: AvailablePackageReference;
+
+  /**
+   * Display name
+   *
+   * The name of the package as displayed in the shortcut.
+   *
+   * @generated from field: string display_name = 3;
+   */
+  displayName = "";
+
+  /**
+   * Icon URL
+   *
+   * Optional URL of the icon displayed in the shortcut.
+   *
+   * @generated from field: string icon_url = 4;
+   */
+  iconUrl = "";
+
+  /**
+   * Time
+   *
+   * The time (RFC3339) at which the package was installed, set by the server.
+   *
+   * @generated from field: string time = 5;
+   */
+  time = "";
+
+  constructor(data?: PartialMessage<RecentInstall>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.favorites.v1alpha1.RecentInstall";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "installed_package_ref", kind: "message", T: InstalledPackageReference },
+    { no: 2, name: "available_package_ref", kind: "message", T: AvailablePackageReference },
+    { no: 3, name: "display_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "icon_url", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "time", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): RecentInstall {
+    return new RecentInstall().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): RecentInstall {
+    return new RecentInstall().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): RecentInstall {
+    return new RecentInstall().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: RecentInstall | PlainMessage<RecentInstall> | undefined,
+    b: RecentInstall | PlainMessage<RecentInstall> | undefined,
+  ): boolean {
+    return proto3.util.equals(RecentInstall, a, b);
+  }
+}
@@ -0,0 +1,69 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+// @generated by protoc-gen-connect-es v0.13.0 with parameter "target=ts,import_extension=none"
+// @generated from file kubeappsapis/core/jobs/v1alpha1/jobs.proto (package kubeappsapis.core.jobs.v1alpha1, syntax proto3)
+/* eslint-disable */
+// @ts-nocheck
+
+import {
+  DeleteJobRequest,
+  DeleteJobResponse,
+  GetJobRequest,
+  GetJobResponse,
+  ListJobsRequest,
+  ListJobsResponse,
+} from "./jobs_pb";
+import { MethodKind } from "@bufbuild/protobuf";
+
+/**
+ * The Jobs service returns the installs and upgrades started by the calling
+ * user whose reconciliation is followed, so that the dashboard can display
+ * their progress, including after a restart of the backend. The jobs are
+ * recorded once the creation or update of the installed package succeeded and
+ * are stored in the namespace where Kubeapps is installed, keyed by the
+ * identity of the user, as resolved by the Kubernetes API server from the
+ * token sent with the request. The state of the pending jobs is refreshed
+ * with the token of the user when they are requested.
+ *
+ * @generated from service kubeappsapis.core.jobs.v1alpha1.JobsService
+ */
+export const JobsService = {
+  typeName: "kubeappsapis.core.jobs.v1alpha1.JobsService",
+  methods: {
+    /**
+     * ListJobs returns the jobs of the calling user, the most recent first.
+     *
+     * @generated from rpc kubeappsapis.core.jobs.v1alpha1.JobsService.ListJobs
+     */
+    listJobs: {
+      name: "ListJobs",
+      I: ListJobsRequest,
+      O: ListJobsResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * GetJob returns a job of the calling user.
+     *
+     * @generated from rpc kubeappsapis.core.jobs.v1alpha1.JobsService.GetJob
+     */
+    getJob: {
+      name: "GetJob",
+      I: GetJobRequest,
+      O: GetJobResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * DeleteJob forgets a job of the calling user, such as once its outcome
+     * has been displayed.
+     *
+     * @generated from rpc kubeappsapis.core.jobs.v1alpha1.JobsService.DeleteJob
+     */
+    deleteJob: {
+      name: "DeleteJob",
+      I: DeleteJobRequest,
+      O: DeleteJobResponse,
+      kind: MethodKind.Unary,
+    },
+  },
+} as const;
@@ -0,0 +1,549 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+// @generated by protoc-gen-es v1.3.1 with parameter "target=ts,import_extension=none"
+// @generated from file kubeappsapis/core/jobs/v1alpha1/jobs.proto (package kubeappsapis.core.jobs.v1alpha1, syntax proto3)
+/* eslint-disable */
+// @ts-nocheck
+
+import type {
+  BinaryReadOptions,
+  FieldList,
+  JsonReadOptions,
+  JsonValue,
+  PartialMessage,
+  PlainMessage,
+} from "@bufbuild/protobuf";
+import { Message, proto3 } from "@bufbuild/protobuf";
+import {
+  InstalledPackageOperationPhase,
+  InstalledPackageReference,
+  InstalledPackageStatus,
+} from "../../packages/v1/packages_pb";
+
+/**
+ * JobType
+ *
+ * The operations followed as jobs.
+ *
+ * @generated from enum kubeappsapis.core.jobs.v1alpha1.JobType
+ */
+export enum JobType {
+  /**
+   * @generated from enum value: JOB_TYPE_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * @generated from enum value: JOB_TYPE_INSTALL = 1;
+   */
+  INSTALL = 1,
+
+  /**
+   * @generated from enum value: JOB_TYPE_UPGRADE = 2;
+   */
+  UPGRADE = 2,
+}
+// Retrieve enum metadata with: proto3.getEnumType(JobType)
+proto3.util.setEnumType(JobType, "kubeappsapis.core.jobs.v1alpha1.JobType", [
+  { no: 0, name: "JOB_TYPE_UNSPECIFIED" },
+  { no: 1, name: "JOB_TYPE_INSTALL" },
+  { no: 2, name: "JOB_TYPE_UPGRADE" },
+]);
+
+/**
+ * JobState
+ *
+ * The states a job goes through. A failed job is still followed until it
+ * times out, as the reconciliation may be retried.
+ *
+ * @generated from enum kubeappsapis.core.jobs.v1alpha1.JobState
+ */
+export enum JobState {
+  /**
+   * @generated from enum value: JOB_STATE_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * @generated from enum value: JOB_STATE_PENDING = 1;
+   */
+  PENDING = 1,
+
+  /**
+   * @generated from enum value: JOB_STATE_SUCCEEDED = 2;
+   */
+  SUCCEEDED = 2,
+
+  /**
+   * @generated from enum value: JOB_STATE_FAILED = 3;
+   */
+  FAILED = 3,
+
+  /**
+   * The installed package was deleted before the job finished.
+   *
+   * @generated from enum value: JOB_STATE_CANCELLED = 4;
+   */
+  CANCELLED = 4,
+
+  /**
+   * The job was still pending when it timed out.
+   *
+   * @generated from enum value: JOB_STATE_EXPIRED = 5;
+   */
+  EXPIRED = 5,
+}
+// Retrieve enum metadata with: proto3.getEnumType(JobState)
+proto3.util.setEnumType(JobState, "kubeappsapis.core.jobs.v1alpha1.JobState", [
+  { no: 0, name: "JOB_STATE_UNSPECIFIED" },
+  { no: 1, name: "JOB_STATE_PENDING" },
+  { no: 2, name: "JOB_STATE_SUCCEEDED" },
+  { no: 3, name: "JOB_STATE_FAILED" },
+  { no: 4, name: "JOB_STATE_CANCELLED" },
+  { no: 5, name: "JOB_STATE_EXPIRED" },
+]);
+
+/**
+ * ListJobsRequest
+ *
+ * Request for ListJobs
+ *
+ * @generated from message kubeappsapis.core.jobs.v1alpha1.ListJobsRequest
+ */
+export class ListJobsRequest extends Message<ListJobsRequest> {
+  /**
+   * Pending only
+   *
+   * Whether only the jobs still pending should be returned.
+   *
+   * @generated from field: bool pending_only = 1;
+   */
+  pendingOnly = false;
+
+  constructor(data?: PartialMessage<ListJobsRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.jobs.v1alpha1.ListJobsRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "pending_only", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListJobsRequest {
+    return new ListJobsRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ListJobsRequest {
+    return new ListJobsRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ListJobsRequest {
+    return new ListJobsRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: ListJobsRequest | PlainMessage<ListJobsRequest> | undefined,
+    b: ListJobsRequest | PlainMessage<ListJobsRequest> | undefined,
+  ): boolean {
+    return proto3.util.equals(ListJobsRequest, a, b);
+  }
+}
+
+/**
+ * ListJobsResponse
+ *
+ * Response for ListJobs
+ *
+ * @generated from message kubeappsapis.core.jobs.v1alpha1.ListJobsResponse
+ */
+export class ListJobsResponse extends Message<ListJobsResponse> {
+  /**
+   * Jobs
+   *
+   * The jobs of the user, the most recent first.
+   *
+   * @generated from field: repeated kubeappsapis.core.jobs.v1alpha1.Job jobs = 1;
+   */
+  jobs: Job[] = [];
+
+  constructor(data?: PartialMessage<ListJobsResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.jobs.v1alpha1.ListJobsResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "jobs", kind: "message", T: Job, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListJobsResponse {
+    return new ListJobsResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ListJobsResponse {
+    return new ListJobsResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ListJobsResponse {
+    return new ListJobsResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: ListJobsResponse | PlainMessage<ListJobsResponse> | undefined,
+    b: ListJobsResponse | PlainMessage<ListJobsResponse> | undefined,
+  ): boolean {
+    return proto3.util.equals(ListJobsResponse, a, b);
+  }
+}
+
+/**
+ * GetJobRequest
+ *
+ * Request for GetJob
+ *
+ * @generated from message kubeappsapis.core.jobs.v1alpha1.GetJobRequest
+ */
+export class GetJobRequest extends Message<GetJobRequest> {
+  /**
+   * Id
+   *
+   * The identifier of the job.
+   *
+   * @generated from field: string id = 1;
+   */
+  id = "";
+
+  constructor(data?: PartialMessage<GetJobRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.jobs.v1alpha1.GetJobRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): GetJobRequest {
+    return new GetJobRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): GetJobRequest {
+    return new GetJobRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): GetJobRequest {
+    return new GetJobRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: GetJobRequest | PlainMessage<GetJobRequest> | undefined,
+    b: GetJobRequest | PlainMessage<GetJobRequest> | undefined,
+  ): boolean {
+    return proto3.util.equals(GetJobRequest, a, b);
+  }
+}
+
+/**
+ * GetJobResponse
+ *
+ * Response for GetJob
+ *
+ * @generated from message kubeappsapis.core.jobs.v1alpha1.GetJobResponse
+ */
+export class GetJobResponse extends Message<GetJobResponse> {
+  /**
+   * Job
+   *
+   * The job, with its state refreshed if it was pending.
+   *
+   * @generated from field: kubeappsapis.core.jobs.v1alpha1.Job job = 1;
+   */
+  job?: Job;
+
+  constructor(data?: PartialMessage<GetJobResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.jobs.v1alpha1.GetJobResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "job", kind: "message", T: Job },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): GetJobResponse {
+    return new GetJobResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): GetJobResponse {
+    return new GetJobResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): GetJobResponse {
+    return new GetJobResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: GetJobResponse | PlainMessage<GetJobResponse> | undefined,
+    b: GetJobResponse | PlainMessage<GetJobResponse> | undefined,
+  ): boolean {
+    return proto3.util.equals(GetJobResponse, a, b);
+  }
+}
+
+/**
+ * DeleteJobRequest
+ *
+ * Request for DeleteJob
+ *
+ * @generated from message kubeappsapis.core.jobs.v1alpha1.DeleteJobRequest
+ */
+export class DeleteJobRequest extends Message<DeleteJobRequest> {
+  /**
+   * Id
+   *
+   * The identifier of the job.
+   *
+   * @generated from field: string id = 1;
+   */
+  id = "";
+
+  constructor(data?: PartialMessage<DeleteJobRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.jobs.v1alpha1.DeleteJobRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): DeleteJobRequest {
+    return new DeleteJobRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): DeleteJobRequest {
+    return new DeleteJobRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): DeleteJobRequest {
+    return new DeleteJobRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: DeleteJobRequest | PlainMessage<DeleteJobRequest> | undefined,
+    b: DeleteJobRequest | PlainMessage<DeleteJobRequest> | undefined,
+  ): boolean {
+    return proto3.util.equals(DeleteJobRequest, a, b);
+  }
+}
+
+/**
+ * DeleteJobResponse
+ *
+ * Response for DeleteJob
+ *
+ * @generated from message kubeappsapis.core.jobs.v1alpha1.DeleteJobResponse
+ */
+export class DeleteJobResponse extends Message<DeleteJobResponse> {
+  constructor(data?: PartialMessage<DeleteJobResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.jobs.v1alpha1.DeleteJobResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => []);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): DeleteJobResponse {
+    return new DeleteJobResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): DeleteJobResponse {
+    return new DeleteJobResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): DeleteJobResponse {
+    return new DeleteJobResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: DeleteJobResponse | PlainMessage<DeleteJobResponse> | undefined,
+    b: DeleteJobResponse | PlainMessage<DeleteJobResponse> | undefined,
+  ): boolean {
+    return proto3.util.equals(DeleteJobResponse, a, b);
+  }
+}
+
+/**
+ * UserJobs
+ *
+ * The jobs of a user, as stored.
+ *
+ * @generated from message kubeappsapis.core.jobs.v1alpha1.UserJobs
+ */
+export class UserJobs extends Message<UserJobs> {
+  /**
+   * Jobs
+   *
+   * The jobs of the user, the most recent first.
+   *
+   * @generated from field: repeated kubeappsapis.core.jobs.v1alpha1.Job jobs = 1;
+   */
+  jobs: Job[] = [];
+
+  constructor(data?: PartialMessage<UserJobs>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.jobs.v1alpha1.UserJobs";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "jobs", kind: "message", T: Job, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): UserJobs {
+    return new UserJobs().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): UserJobs {
+    return new UserJobs().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): UserJobs {
+    return new UserJobs().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: UserJobs | PlainMessage<UserJobs> | undefined,
+    b: UserJobs | PlainMessage<UserJobs> | undefined,
+  ): boolean {
+    return proto3.util.equals(UserJobs, a, b);
+  }
+}
+
+/**
+ * Job
+ *
+ * An install or upgrade started by a user, followed until its reconciliation
+ * finishes.
+ *
+ * @generated from message kubeappsapis.core.jobs.v1alpha1.Job
+ */
+export class Job extends Message<Job> {
+  /**
+   * Id
+   *
+   * The identifier of the job.
+   *
+   * @generated from field: string id = 1;
+   */
+  id = "";
+
+  /**
+   * Type
+   *
+   * The operation followed by the job.
+   *
+   * @generated from field: kubeappsapis.core.jobs.v1alpha1.JobType type = 2;
+   */
+  type = JobType.UNSPECIFIED;
+
+  /**
+   * A reference uniquely identifying the installed package.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.InstalledPackageReference installed_package_ref = 3;
+   */
+  installedPackageRef?: InstalledPackageReference;
+
+  /**
+   * State
+   *
+   * The state of the job.
+   *
+   * @generated from field: kubeappsapis.core.jobs.v1alpha1.JobState state = 4;
+   */
+  state = JobState.UNSPECIFIED;
+
+  /**
+   * Status
+   *
+   * The status of the installed package when the job was last refreshed.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.InstalledPackageStatus status = 5;
+   */
+  status?: InstalledPackageStatus;
+
+  /**
+   * Phases
+   *
+   * The phases of the operation when the job was last refreshed. Plugins not
+   * reporting granular progress return no phases, only the overall status.
+   *
+   * @generated from field: repeated kubeappsapis.core.packages.v1.InstalledPackageOperationPhase phases = 6;
+   */
+  phases: InstalledPackageOperationPhase[] = [];
+
+  /**
+   * Create time
+   *
+   * The time (RFC3339) at which the job was recorded.
+   *
+   * @generated from field: string create_time = 7;
+   */
+  createTime = "";
+
+  /**
+   * Update time
+   *
+   * The time (RFC3339) at which the state, status or phases of the job last
+   * changed.
+   *
+   * @generated from field: string update_time = 8;
+   */
+  updateTime = "";
+
+  constructor(data?: PartialMessage<Job>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.jobs.v1alpha1.Job";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "type", kind: "enum", T: proto3.getEnumType(JobType) },
+    { no: 3, name: "installed_package_ref", kind: "message", T: InstalledPackageReference },
+    { no: 4, name: "state", kind: "enum", T: proto3.getEnumType(JobState) },
+    { no: 5, name: "status", kind: "message", T: InstalledPackageStatus },
+    { no: 6, name: "phases", kind: "message", T: InstalledPackageOperationPhase, repeated: true },
+    { no: 7, name: "create_time", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 8, name: "update_time", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): Job {
+    return new Job().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): Job {
+    return new Job().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): Job {
+    return new Job().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: Job | PlainMessage<Job> | undefined,
+    b: Job | PlainMessage<Job> | undefined,
+  ): boolean {
+    return proto3.util.equals(Job, a, b);
+  }
+}
@@ -0,0 +1,31 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+// @generated by protoc-gen-connect-es v0.13.0 with parameter "target=ts,import_extension=none"
+// @generated from file kubeappsapis/core/notifications/v1alpha1/notifications.proto (package kubeappsapis.core.notifications.v1alpha1, syntax proto3)
+/* eslint-disable */
+// @ts-nocheck
+
+import { WatchNotificationsRequest, WatchNotificationsResponse } from "./notifications_pb";
+import { MethodKind } from "@bufbuild/protobuf";
+
+/**
+ * @generated from service kubeappsapis.core.notifications.v1alpha1.NotificationsService
+ */
+export const NotificationsService = {
+  typeName: "kubeappsapis.core.notifications.v1alpha1.NotificationsService",
+  methods: {
+    /**
+     * WatchNotifications streams the notifications of the calling user, first
+     * the recent ones following the given one, then the new ones as they happen.
+     *
+     * @generated from rpc kubeappsapis.core.notifications.v1alpha1.NotificationsService.WatchNotifications
+     */
+    watchNotifications: {
+      name: "WatchNotifications",
+      I: WatchNotificationsRequest,
+      O: WatchNotificationsResponse,
+      kind: MethodKind.ServerStreaming,
+    },
+  },
+} as const;
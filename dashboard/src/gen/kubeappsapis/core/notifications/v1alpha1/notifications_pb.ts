@@ -0,0 +1,310 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+// @generated by protoc-gen-es v1.3.1 with parameter "target=ts,import_extension=none"
+// @generated from file kubeappsapis/core/notifications/v1alpha1/notifications.proto (package kubeappsapis.core.notifications.v1alpha1, syntax proto3)
+/* eslint-disable */
+// @ts-nocheck
+
+import type {
+  BinaryReadOptions,
+  FieldList,
+  JsonReadOptions,
+  JsonValue,
+  PartialMessage,
+  PlainMessage,
+} from "@bufbuild/protobuf";
+import { Message, proto3, protoInt64 } from "@bufbuild/protobuf";
+import { InstalledPackageReference } from "../../packages/v1/packages_pb";
+import { PackageRepositoryReference } from "../../packages/v1/repositories_pb";
+
+/**
+ * NotificationType
+ *
+ * The outcomes of the operations notified to the users.
+ *
+ * @generated from enum kubeappsapis.core.notifications.v1alpha1.NotificationType
+ */
+export enum NotificationType {
+  /**
+   * @generated from enum value: NOTIFICATION_TYPE_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * @generated from enum value: NOTIFICATION_TYPE_INSTALL_COMPLETED = 1;
+   */
+  INSTALL_COMPLETED = 1,
+
+  /**
+   * @generated from enum value: NOTIFICATION_TYPE_INSTALL_FAILED = 2;
+   */
+  INSTALL_FAILED = 2,
+
+  /**
+   * @generated from enum value: NOTIFICATION_TYPE_UPGRADE_COMPLETED = 3;
+   */
+  UPGRADE_COMPLETED = 3,
+
+  /**
+   * @generated from enum value: NOTIFICATION_TYPE_UPGRADE_FAILED = 4;
+   */
+  UPGRADE_FAILED = 4,
+
+  /**
+   * @generated from enum value: NOTIFICATION_TYPE_REPOSITORY_SYNC_COMPLETED = 5;
+   */
+  REPOSITORY_SYNC_COMPLETED = 5,
+
+  /**
+   * @generated from enum value: NOTIFICATION_TYPE_REPOSITORY_SYNC_FAILED = 6;
+   */
+  REPOSITORY_SYNC_FAILED = 6,
+
+  /**
+   * The sync of a package repository succeeded after failing.
+   *
+   * @generated from enum value: NOTIFICATION_TYPE_REPOSITORY_SYNC_RECOVERED = 7;
+   */
+  REPOSITORY_SYNC_RECOVERED = 7,
+}
+// Retrieve enum metadata with: proto3.getEnumType(NotificationType)
+proto3.util.setEnumType(
+  NotificationType,
+  "kubeappsapis.core.notifications.v1alpha1.NotificationType",
+  [
+    { no: 0, name: "NOTIFICATION_TYPE_UNSPECIFIED" },
+    { no: 1, name: "NOTIFICATION_TYPE_INSTALL_COMPLETED" },
+    { no: 2, name: "NOTIFICATION_TYPE_INSTALL_FAILED" },
+    { no: 3, name: "NOTIFICATION_TYPE_UPGRADE_COMPLETED" },
+    { no: 4, name: "NOTIFICATION_TYPE_UPGRADE_FAILED" },
+    { no: 5, name: "NOTIFICATION_TYPE_REPOSITORY_SYNC_COMPLETED" },
+    { no: 6, name: "NOTIFICATION_TYPE_REPOSITORY_SYNC_FAILED" },
+    { no: 7, name: "NOTIFICATION_TYPE_REPOSITORY_SYNC_RECOVERED" },
+  ],
+);
+
+/**
+ * WatchNotificationsRequest
+ *
+ * Request for WatchNotifications
+ *
+ * @generated from message kubeappsapis.core.notifications.v1alpha1.WatchNotificationsRequest
+ */
+export class WatchNotificationsRequest extends Message<WatchNotificationsRequest> {
+  /**
+   * AfterId
+   *
+   * The identifier of the last notification received, so that a client
+   * reconnecting to the stream receives the recent notifications it missed.
+   * No recent notification is sent if 0.
+   *
+   * @generated from field: uint64 after_id = 1;
+   */
+  afterId = protoInt64.zero;
+
+  constructor(data?: PartialMessage<WatchNotificationsRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.notifications.v1alpha1.WatchNotificationsRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "after_id", kind: "scalar", T: 4 /* ScalarType.UINT64 */ },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): WatchNotificationsRequest {
+    return new WatchNotificationsRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): WatchNotificationsRequest {
+    return new WatchNotificationsRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): WatchNotificationsRequest {
+    return new WatchNotificationsRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: WatchNotificationsRequest | PlainMessage<WatchNotificationsRequest> | undefined,
+    b: WatchNotificationsRequest | PlainMessage<WatchNotificationsRequest> | undefined,
+  ): boolean {
+    return proto3.util.equals(WatchNotificationsRequest, a, b);
+  }
+}
+
+/**
+ * WatchNotificationsResponse
+ *
+ * Response for WatchNotifications, one per notification
+ *
+ * @generated from message kubeappsapis.core.notifications.v1alpha1.WatchNotificationsResponse
+ */
+export class WatchNotificationsResponse extends Message<WatchNotificationsResponse> {
+  /**
+   * Notification
+   *
+   * A notification of the calling user.
+   *
+   * @generated from field: kubeappsapis.core.notifications.v1alpha1.Notification notification = 1;
+   */
+  notification?: Notification;
+
+  constructor(data?: PartialMessage<WatchNotificationsResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.notifications.v1alpha1.WatchNotificationsResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "notification", kind: "message", T: Notification },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): WatchNotificationsResponse {
+    return new WatchNotificationsResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): WatchNotificationsResponse {
+    return new WatchNotificationsResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): WatchNotificationsResponse {
+    return new WatchNotificationsResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: WatchNotificationsResponse | PlainMessage<WatchNotificationsResponse> | undefined,
+    b: WatchNotificationsResponse | PlainMessage<WatchNotificationsResponse> | undefined,
+  ): boolean {
+    return proto3.util.equals(WatchNotificationsResponse, a, b);
+  }
+}
+
+/**
+ * Notification
+ *
+ * The outcome of an operation started by the user.
+ *
+ * @generated from message kubeappsapis.core.notifications.v1alpha1.Notification
+ */
+export class Notification extends Message<Notification> {
+  /**
+   * Id
+   *
+   * The identifier of the notification, increasing with the time at which the
+   * notifications happen.
+   *
+   * @generated from field: uint64 id = 1;
+   */
+  id = protoInt64.zero;
+
+  /**
+   * Type
+   *
+   * The outcome of the operation.
+   *
+   * @generated from field: kubeappsapis.core.notifications.v1alpha1.NotificationType type = 2;
+   */
+  type = NotificationType.UNSPECIFIED;
+
+  /**
+   * Message
+   *
+   * A message describing the outcome, to be shown to the user.
+   *
+   * @generated from field: string message = 3;
+   */
+  message = "";
+
+  /**
+   * Reason
+   *
+   * The reason of a failure, as reported by the plugin.
+   *
+   * @generated from field: string reason = 4;
+   */
+  reason = "";
+
+  /**
+   * Time
+   *
+   * The time (RFC3339) at which the outcome was observed.
+   *
+   * @generated from field: string time = 5;
+   */
+  time = "";
+
+  /**
+   * InstalledPackageRef
+   *
+   * The installed package of an install or upgrade operation.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.InstalledPackageReference installed_package_ref = 6;
+   */
+  installedPackageRef?: InstalledPackageReference;
+
+  /**
+   * PackageRepoRef
+   *
+   * The package repository of a repository sync operation.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.PackageRepositoryReference package_repo_ref = 7;
+   */
+  packageRepoRef?: PackageRepositoryReference;
+
+  constructor(data?: PartialMessage<Notification>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.notifications.v1alpha1.Notification";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "id", kind: "scalar", T: 4 /* ScalarType.UINT64 */ },
+    { no: 2, name: "type", kind: "enum", T: proto3.getEnumType(NotificationType) },
+    { no: 3, name: "message", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "reason", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "time", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 6, name: "installed_package_ref", kind: "message", T: InstalledPackageReference },
+    { no: 7, name: "package_repo_ref", kind: "message", T: PackageRepositoryReference },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): Notification {
+    return new Notification().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): Notification {
+    return new Notification().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): Notification {
+    return new Notification().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: Notification | PlainMessage<Notification> | undefined,
+    b: Notification | PlainMessage<Notification> | undefined,
+  ): boolean {
+    return proto3.util.equals(Notification, a, b);
+  }
+}
@@ -0,0 +1,239 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+// @generated by protoc-gen-connect-es v0.13.0 with parameter "target=ts,import_extension=none"
+// @generated from file kubeappsapis/core/packages/v1/packages.proto (package kubeappsapis.core.packages.v1, syntax proto3)
+/* eslint-disable */
+// @ts-nocheck
+
+import {
+  BulkDeleteInstalledPackagesRequest,
+  BulkDeleteInstalledPackagesResponse,
+  BulkUpdateInstalledPackagesRequest,
+  BulkUpdateInstalledPackagesResponse,
+  CheckInstalledPackageNameAvailabilityRequest,
+  CheckInstalledPackageNameAvailabilityResponse,
+  CloneInstalledPackageRequest,
+  CloneInstalledPackageResponse,
+  CreateInstalledPackageRequest,
+  CreateInstalledPackageResponse,
+  DeleteInstalledPackageRequest,
+  DeleteInstalledPackageResponse,
+  GetAvailablePackageChangelogRequest,
+  GetAvailablePackageChangelogResponse,
+  GetAvailablePackageDetailRequest,
+  GetAvailablePackageDetailResponse,
+  GetAvailablePackageFiltersRequest,
+  GetAvailablePackageFiltersResponse,
+  GetAvailablePackageSummariesRequest,
+  GetAvailablePackageSummariesResponse,
+  GetAvailablePackageTextAssetRequest,
+  GetAvailablePackageTextAssetResponse,
+  GetAvailablePackageVersionsRequest,
+  GetAvailablePackageVersionsResponse,
+  GetInstalledPackageDetailRequest,
+  GetInstalledPackageDetailResponse,
+  GetInstalledPackageOperationStatusRequest,
+  GetInstalledPackageOperationStatusResponse,
+  GetInstalledPackageResourceRefsRequest,
+  GetInstalledPackageResourceRefsResponse,
+  GetInstalledPackageSummariesRequest,
+  GetInstalledPackageSummariesResponse,
+  UpdateInstalledPackageRequest,
+  UpdateInstalledPackageResponse,
+} from "./packages_pb";
+import { MethodKind } from "@bufbuild/protobuf";
+
+/**
+ * The PackagesService is served by the core, translating each request to the
+ * v1alpha1 PackagesService implemented by the packaging plugins.
+ *
+ *
+ * @generated from service kubeappsapis.core.packages.v1.PackagesService
+ */
+export const PackagesService = {
+  typeName: "kubeappsapis.core.packages.v1.PackagesService",
+  methods: {
+    /**
+     * @generated from rpc kubeappsapis.core.packages.v1.PackagesService.GetAvailablePackageSummaries
+     */
+    getAvailablePackageSummaries: {
+      name: "GetAvailablePackageSummaries",
+      I: GetAvailablePackageSummariesRequest,
+      O: GetAvailablePackageSummariesResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc kubeappsapis.core.packages.v1.PackagesService.GetAvailablePackageDetail
+     */
+    getAvailablePackageDetail: {
+      name: "GetAvailablePackageDetail",
+      I: GetAvailablePackageDetailRequest,
+      O: GetAvailablePackageDetailResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc kubeappsapis.core.packages.v1.PackagesService.GetAvailablePackageVersions
+     */
+    getAvailablePackageVersions: {
+      name: "GetAvailablePackageVersions",
+      I: GetAvailablePackageVersionsRequest,
+      O: GetAvailablePackageVersionsResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc kubeappsapis.core.packages.v1.PackagesService.GetAvailablePackageTextAsset
+     */
+    getAvailablePackageTextAsset: {
+      name: "GetAvailablePackageTextAsset",
+      I: GetAvailablePackageTextAssetRequest,
+      O: GetAvailablePackageTextAssetResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc kubeappsapis.core.packages.v1.PackagesService.GetAvailablePackageChangelog
+     */
+    getAvailablePackageChangelog: {
+      name: "GetAvailablePackageChangelog",
+      I: GetAvailablePackageChangelogRequest,
+      O: GetAvailablePackageChangelogResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * GetAvailablePackageFilters returns the distinct values, across every
+     * configured plugin, that can be used in the FilterOptions of the available
+     * packages, such as their categories and providers.
+     *
+     * @generated from rpc kubeappsapis.core.packages.v1.PackagesService.GetAvailablePackageFilters
+     */
+    getAvailablePackageFilters: {
+      name: "GetAvailablePackageFilters",
+      I: GetAvailablePackageFiltersRequest,
+      O: GetAvailablePackageFiltersResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc kubeappsapis.core.packages.v1.PackagesService.GetInstalledPackageSummaries
+     */
+    getInstalledPackageSummaries: {
+      name: "GetInstalledPackageSummaries",
+      I: GetInstalledPackageSummariesRequest,
+      O: GetInstalledPackageSummariesResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc kubeappsapis.core.packages.v1.PackagesService.GetInstalledPackageDetail
+     */
+    getInstalledPackageDetail: {
+      name: "GetInstalledPackageDetail",
+      I: GetInstalledPackageDetailRequest,
+      O: GetInstalledPackageDetailResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * CheckInstalledPackageNameAvailability checks, with every configured plugin,
+     * whether a package can be installed with the given name in the target namespace.
+     *
+     * @generated from rpc kubeappsapis.core.packages.v1.PackagesService.CheckInstalledPackageNameAvailability
+     */
+    checkInstalledPackageNameAvailability: {
+      name: "CheckInstalledPackageNameAvailability",
+      I: CheckInstalledPackageNameAvailabilityRequest,
+      O: CheckInstalledPackageNameAvailabilityResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc kubeappsapis.core.packages.v1.PackagesService.CreateInstalledPackage
+     */
+    createInstalledPackage: {
+      name: "CreateInstalledPackage",
+      I: CreateInstalledPackageRequest,
+      O: CreateInstalledPackageResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc kubeappsapis.core.packages.v1.PackagesService.UpdateInstalledPackage
+     */
+    updateInstalledPackage: {
+      name: "UpdateInstalledPackage",
+      I: UpdateInstalledPackageRequest,
+      O: UpdateInstalledPackageResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc kubeappsapis.core.packages.v1.PackagesService.DeleteInstalledPackage
+     */
+    deleteInstalledPackage: {
+      name: "DeleteInstalledPackage",
+      I: DeleteInstalledPackageRequest,
+      O: DeleteInstalledPackageResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc kubeappsapis.core.packages.v1.PackagesService.GetInstalledPackageResourceRefs
+     */
+    getInstalledPackageResourceRefs: {
+      name: "GetInstalledPackageResourceRefs",
+      I: GetInstalledPackageResourceRefsRequest,
+      O: GetInstalledPackageResourceRefsResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * BulkUpdateInstalledPackages updates several installed packages, such as
+     * the same package installed in many namespaces, to the given version,
+     * keeping the values and reconciliation options of each of them. The
+     * updates are performed concurrently and a failed update does not prevent
+     * the others, its error being reported in the result of that package.
+     *
+     * @generated from rpc kubeappsapis.core.packages.v1.PackagesService.BulkUpdateInstalledPackages
+     */
+    bulkUpdateInstalledPackages: {
+      name: "BulkUpdateInstalledPackages",
+      I: BulkUpdateInstalledPackagesRequest,
+      O: BulkUpdateInstalledPackagesResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * BulkDeleteInstalledPackages deletes several installed packages
+     * concurrently, reporting the error of each failed deletion in the result
+     * of that package.
+     *
+     * @generated from rpc kubeappsapis.core.packages.v1.PackagesService.BulkDeleteInstalledPackages
+     */
+    bulkDeleteInstalledPackages: {
+      name: "BulkDeleteInstalledPackages",
+      I: BulkDeleteInstalledPackagesRequest,
+      O: BulkDeleteInstalledPackagesResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * CloneInstalledPackage re-creates an installed package, with the same
+     * package, version reference, values and custom detail, in another namespace
+     * or cluster, such as to promote the configuration of an application from a
+     * development namespace to a staging one. The plugin re-creates the objects
+     * it manages for the installed package, such as the Secrets holding its
+     * values, in the target namespace.
+     *
+     * @generated from rpc kubeappsapis.core.packages.v1.PackagesService.CloneInstalledPackage
+     */
+    cloneInstalledPackage: {
+      name: "CloneInstalledPackage",
+      I: CloneInstalledPackageRequest,
+      O: CloneInstalledPackageResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * GetInstalledPackageOperationStatus returns the progress of the operation
+     * in course on an installed package, such as its installation, so that
+     * clients can follow it once CreateInstalledPackage returns.
+     *
+     * @generated from rpc kubeappsapis.core.packages.v1.PackagesService.GetInstalledPackageOperationStatus
+     */
+    getInstalledPackageOperationStatus: {
+      name: "GetInstalledPackageOperationStatus",
+      I: GetInstalledPackageOperationStatusRequest,
+      O: GetInstalledPackageOperationStatusResponse,
+      kind: MethodKind.Unary,
+    },
+  },
+} as const;
@@ -0,0 +1,5526 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+// @generated by protoc-gen-es v1.3.1 with parameter "target=ts,import_extension=none"
+// @generated from file kubeappsapis/core/packages/v1/packages.proto (package kubeappsapis.core.packages.v1, syntax proto3)
+/* eslint-disable */
+// @ts-nocheck
+
+import type {
+  BinaryReadOptions,
+  FieldList,
+  JsonReadOptions,
+  JsonValue,
+  PartialMessage,
+  PlainMessage,
+} from "@bufbuild/protobuf";
+import { Any, Message, proto3, protoInt64 } from "@bufbuild/protobuf";
+import { Plugin } from "../../plugins/v1alpha1/plugins_pb";
+
+/**
+ * Text asset
+ *
+ * The text assets of an available package which may be retrieved in ranges.
+ *
+ * @generated from enum kubeappsapis.core.packages.v1.TextAsset
+ */
+export enum TextAsset {
+  /**
+   * @generated from enum value: TEXT_ASSET_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * @generated from enum value: TEXT_ASSET_README = 1;
+   */
+  README = 1,
+
+  /**
+   * @generated from enum value: TEXT_ASSET_DEFAULT_VALUES = 2;
+   */
+  DEFAULT_VALUES = 2,
+
+  /**
+   * @generated from enum value: TEXT_ASSET_VALUES_SCHEMA = 3;
+   */
+  VALUES_SCHEMA = 3,
+}
+// Retrieve enum metadata with: proto3.getEnumType(TextAsset)
+proto3.util.setEnumType(TextAsset, "kubeappsapis.core.packages.v1.TextAsset", [
+  { no: 0, name: "TEXT_ASSET_UNSPECIFIED" },
+  { no: 1, name: "TEXT_ASSET_README" },
+  { no: 2, name: "TEXT_ASSET_DEFAULT_VALUES" },
+  { no: 3, name: "TEXT_ASSET_VALUES_SCHEMA" },
+]);
+
+/**
+ * GetAvailablePackageSummariesRequest
+ *
+ * Request for GetAvailablePackageSummaries
+ *
+ * @generated from message kubeappsapis.core.packages.v1.GetAvailablePackageSummariesRequest
+ */
+export class GetAvailablePackageSummariesRequest extends Message<GetAvailablePackageSummariesRequest> {
+  /**
+   * The context (cluster/namespace) for the request
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.Context context = 1;
+   */
+  context?: Context;
+
+  /**
+   * The filters used for the request
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.FilterOptions filter_options = 2;
+   */
+  filterOptions?: FilterOptions;
+
+  /**
+   * Pagination options specifying where to start and how many results to include.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.PaginationOptions pagination_options = 3;
+   */
+  paginationOptions?: PaginationOptions;
+
+  /**
+   * Include icons
+   *
+   * Whether to include the icons inlined in the summaries as data URLs, which
+   * can make the response several MB big. Icons referenced by an http(s) URL
+   * are always included. Defaults to true when unset.
+   *
+   * @generated from field: optional bool include_icons = 4;
+   */
+  includeIcons?: boolean;
+
+  constructor(data?: PartialMessage<GetAvailablePackageSummariesRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.GetAvailablePackageSummariesRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "context", kind: "message", T: Context },
+    { no: 2, name: "filter_options", kind: "message", T: FilterOptions },
+    { no: 3, name: "pagination_options", kind: "message", T: PaginationOptions },
+    { no: 4, name: "include_icons", kind: "scalar", T: 8 /* ScalarType.BOOL */, opt: true },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): GetAvailablePackageSummariesRequest {
+    return new GetAvailablePackageSummariesRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): GetAvailablePackageSummariesRequest {
+    return new GetAvailablePackageSummariesRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetAvailablePackageSummariesRequest {
+    return new GetAvailablePackageSummariesRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | GetAvailablePackageSummariesRequest
+      | PlainMessage<GetAvailablePackageSummariesRequest>
+      | undefined,
+    b:
+      | GetAvailablePackageSummariesRequest
+      | PlainMessage<GetAvailablePackageSummariesRequest>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(GetAvailablePackageSummariesRequest, a, b);
+  }
+}
+
+/**
+ * GetAvailablePackageFiltersRequest
+ *
+ * Request for GetAvailablePackageFilters
+ *
+ * @generated from message kubeappsapis.core.packages.v1.GetAvailablePackageFiltersRequest
+ */
+export class GetAvailablePackageFiltersRequest extends Message<GetAvailablePackageFiltersRequest> {
+  /**
+   * The context (cluster/namespace) for the request
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.Context context = 1;
+   */
+  context?: Context;
+
+  constructor(data?: PartialMessage<GetAvailablePackageFiltersRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.GetAvailablePackageFiltersRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "context", kind: "message", T: Context },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): GetAvailablePackageFiltersRequest {
+    return new GetAvailablePackageFiltersRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): GetAvailablePackageFiltersRequest {
+    return new GetAvailablePackageFiltersRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetAvailablePackageFiltersRequest {
+    return new GetAvailablePackageFiltersRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | GetAvailablePackageFiltersRequest
+      | PlainMessage<GetAvailablePackageFiltersRequest>
+      | undefined,
+    b:
+      | GetAvailablePackageFiltersRequest
+      | PlainMessage<GetAvailablePackageFiltersRequest>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(GetAvailablePackageFiltersRequest, a, b);
+  }
+}
+
+/**
+ * GetAvailablePackageDetailRequest
+ *
+ * Request for GetAvailablePackageDetail
+ *
+ * @generated from message kubeappsapis.core.packages.v1.GetAvailablePackageDetailRequest
+ */
+export class GetAvailablePackageDetailRequest extends Message<GetAvailablePackageDetailRequest> {
+  /**
+   * The information required to uniquely
+   * identify an available package
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.AvailablePackageReference available_package_ref = 1;
+   */
+  availablePackageRef?: AvailablePackageReference;
+
+  /**
+   * Optional specific version (or version reference) to request.
+   * By default the latest version (or latest version matching the reference)
+   * will be returned.
+   *
+   * @generated from field: string pkg_version = 2;
+   */
+  pkgVersion = "";
+
+  constructor(data?: PartialMessage<GetAvailablePackageDetailRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.GetAvailablePackageDetailRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "available_package_ref", kind: "message", T: AvailablePackageReference },
+    { no: 2, name: "pkg_version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): GetAvailablePackageDetailRequest {
+    return new GetAvailablePackageDetailRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): GetAvailablePackageDetailRequest {
+    return new GetAvailablePackageDetailRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetAvailablePackageDetailRequest {
+    return new GetAvailablePackageDetailRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | GetAvailablePackageDetailRequest
+      | PlainMessage<GetAvailablePackageDetailRequest>
+      | undefined,
+    b:
+      | GetAvailablePackageDetailRequest
+      | PlainMessage<GetAvailablePackageDetailRequest>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(GetAvailablePackageDetailRequest, a, b);
+  }
+}
+
+/**
+ * GetAvailablePackageVersionsRequest
+ *
+ * Request for GetAvailablePackageVersions
+ *
+ * @generated from message kubeappsapis.core.packages.v1.GetAvailablePackageVersionsRequest
+ */
+export class GetAvailablePackageVersionsRequest extends Message<GetAvailablePackageVersionsRequest> {
+  /**
+   * The information required to uniquely
+   * identify an available package
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.AvailablePackageReference available_package_ref = 1;
+   */
+  availablePackageRef?: AvailablePackageReference;
+
+  /**
+   * Optional version reference for which full version history is required.  By
+   * default a summary of versions is returned as outlined in the response.
+   * Plugins can choose not to implement this and provide the summary only, it
+   * is provided for completeness only.
+   *
+   * @generated from field: string pkg_version = 2;
+   */
+  pkgVersion = "";
+
+  /**
+   * Optional version, usually the installed version, relative to which the
+   * upgrade impact of each returned version is classified. By default, the
+   * upgrade impact is not set.
+   *
+   * @generated from field: string relative_to_version = 3;
+   */
+  relativeToVersion = "";
+
+  /**
+   * Optional semver constraint, such as ">=1.2.0 <2.0.0", which the returned
+   * versions satisfy. By default, all the versions are returned. Plugins can
+   * choose not to implement this, in which case all the versions are returned.
+   *
+   * @generated from field: string constraint = 4;
+   */
+  constraint = "";
+
+  constructor(data?: PartialMessage<GetAvailablePackageVersionsRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.GetAvailablePackageVersionsRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "available_package_ref", kind: "message", T: AvailablePackageReference },
+    { no: 2, name: "pkg_version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "relative_to_version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "constraint", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): GetAvailablePackageVersionsRequest {
+    return new GetAvailablePackageVersionsRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): GetAvailablePackageVersionsRequest {
+    return new GetAvailablePackageVersionsRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetAvailablePackageVersionsRequest {
+    return new GetAvailablePackageVersionsRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | GetAvailablePackageVersionsRequest
+      | PlainMessage<GetAvailablePackageVersionsRequest>
+      | undefined,
+    b:
+      | GetAvailablePackageVersionsRequest
+      | PlainMessage<GetAvailablePackageVersionsRequest>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(GetAvailablePackageVersionsRequest, a, b);
+  }
+}
+
+/**
+ * GetAvailablePackageTextAssetRequest
+ *
+ * Request for GetAvailablePackageTextAsset
+ *
+ * @generated from message kubeappsapis.core.packages.v1.GetAvailablePackageTextAssetRequest
+ */
+export class GetAvailablePackageTextAssetRequest extends Message<GetAvailablePackageTextAssetRequest> {
+  /**
+   * The information required to uniquely
+   * identify an available package
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.AvailablePackageReference available_package_ref = 1;
+   */
+  availablePackageRef?: AvailablePackageReference;
+
+  /**
+   * Optional specific version (or version reference) to request.
+   * By default the latest version (or latest version matching the reference)
+   * will be used.
+   *
+   * @generated from field: string pkg_version = 2;
+   */
+  pkgVersion = "";
+
+  /**
+   * The text asset of the package to be retrieved.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.TextAsset asset = 3;
+   */
+  asset = TextAsset.UNSPECIFIED;
+
+  /**
+   * The offset, in bytes, from which the text asset is retrieved.
+   *
+   * @generated from field: int64 offset = 4;
+   */
+  offset = protoInt64.zero;
+
+  /**
+   * The maximum number of bytes to be retrieved. When not set (or too big),
+   * a server-side default limit is used.
+   *
+   * @generated from field: int64 limit = 5;
+   */
+  limit = protoInt64.zero;
+
+  constructor(data?: PartialMessage<GetAvailablePackageTextAssetRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.GetAvailablePackageTextAssetRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "available_package_ref", kind: "message", T: AvailablePackageReference },
+    { no: 2, name: "pkg_version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "asset", kind: "enum", T: proto3.getEnumType(TextAsset) },
+    { no: 4, name: "offset", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 5, name: "limit", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): GetAvailablePackageTextAssetRequest {
+    return new GetAvailablePackageTextAssetRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): GetAvailablePackageTextAssetRequest {
+    return new GetAvailablePackageTextAssetRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetAvailablePackageTextAssetRequest {
+    return new GetAvailablePackageTextAssetRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | GetAvailablePackageTextAssetRequest
+      | PlainMessage<GetAvailablePackageTextAssetRequest>
+      | undefined,
+    b:
+      | GetAvailablePackageTextAssetRequest
+      | PlainMessage<GetAvailablePackageTextAssetRequest>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(GetAvailablePackageTextAssetRequest, a, b);
+  }
+}
+
+/**
+ * GetAvailablePackageChangelogRequest
+ *
+ * Request for GetAvailablePackageChangelog
+ *
+ * @generated from message kubeappsapis.core.packages.v1.GetAvailablePackageChangelogRequest
+ */
+export class GetAvailablePackageChangelogRequest extends Message<GetAvailablePackageChangelogRequest> {
+  /**
+   * The information required to uniquely
+   * identify an available package
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.AvailablePackageReference available_package_ref = 1;
+   */
+  availablePackageRef?: AvailablePackageReference;
+
+  /**
+   * Optional version from which the changelog is requested, usually the
+   * installed version. The changes of this version are not included.
+   * By default the changelog of every version is returned.
+   *
+   * @generated from field: string from_version = 2;
+   */
+  fromVersion = "";
+
+  /**
+   * Optional version up to which the changelog is requested, usually the
+   * version to be upgraded to. The changes of this version are included.
+   * By default the latest version is used.
+   *
+   * @generated from field: string to_version = 3;
+   */
+  toVersion = "";
+
+  constructor(data?: PartialMessage<GetAvailablePackageChangelogRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.GetAvailablePackageChangelogRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "available_package_ref", kind: "message", T: AvailablePackageReference },
+    { no: 2, name: "from_version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "to_version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): GetAvailablePackageChangelogRequest {
+    return new GetAvailablePackageChangelogRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): GetAvailablePackageChangelogRequest {
+    return new GetAvailablePackageChangelogRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetAvailablePackageChangelogRequest {
+    return new GetAvailablePackageChangelogRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | GetAvailablePackageChangelogRequest
+      | PlainMessage<GetAvailablePackageChangelogRequest>
+      | undefined,
+    b:
+      | GetAvailablePackageChangelogRequest
+      | PlainMessage<GetAvailablePackageChangelogRequest>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(GetAvailablePackageChangelogRequest, a, b);
+  }
+}
+
+/**
+ * GetInstalledPackageSummariesRequest
+ *
+ * Request for GetInstalledPackageSummaries
+ *
+ * @generated from message kubeappsapis.core.packages.v1.GetInstalledPackageSummariesRequest
+ */
+export class GetInstalledPackageSummariesRequest extends Message<GetInstalledPackageSummariesRequest> {
+  /**
+   * The context (cluster/namespace) for the request.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.Context context = 1;
+   */
+  context?: Context;
+
+  /**
+   * Pagination options specifying where to start and how many results to include.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.PaginationOptions pagination_options = 2;
+   */
+  paginationOptions?: PaginationOptions;
+
+  /**
+   * Filter options specifying which installed packages to include.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.InstalledPackageFilterOptions filter_options = 3;
+   */
+  filterOptions?: InstalledPackageFilterOptions;
+
+  constructor(data?: PartialMessage<GetInstalledPackageSummariesRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.GetInstalledPackageSummariesRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "context", kind: "message", T: Context },
+    { no: 2, name: "pagination_options", kind: "message", T: PaginationOptions },
+    { no: 3, name: "filter_options", kind: "message", T: InstalledPackageFilterOptions },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): GetInstalledPackageSummariesRequest {
+    return new GetInstalledPackageSummariesRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): GetInstalledPackageSummariesRequest {
+    return new GetInstalledPackageSummariesRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetInstalledPackageSummariesRequest {
+    return new GetInstalledPackageSummariesRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | GetInstalledPackageSummariesRequest
+      | PlainMessage<GetInstalledPackageSummariesRequest>
+      | undefined,
+    b:
+      | GetInstalledPackageSummariesRequest
+      | PlainMessage<GetInstalledPackageSummariesRequest>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(GetInstalledPackageSummariesRequest, a, b);
+  }
+}
+
+/**
+ * GetInstalledPackageDetailRequest
+ *
+ * Request for GetInstalledPackageDetail
+ *
+ * @generated from message kubeappsapis.core.packages.v1.GetInstalledPackageDetailRequest
+ */
+export class GetInstalledPackageDetailRequest extends Message<GetInstalledPackageDetailRequest> {
+  /**
+   * The information required to uniquely
+   * identify an installed package
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.InstalledPackageReference installed_package_ref = 1;
+   */
+  installedPackageRef?: InstalledPackageReference;
+
+  constructor(data?: PartialMessage<GetInstalledPackageDetailRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.GetInstalledPackageDetailRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "installed_package_ref", kind: "message", T: InstalledPackageReference },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): GetInstalledPackageDetailRequest {
+    return new GetInstalledPackageDetailRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): GetInstalledPackageDetailRequest {
+    return new GetInstalledPackageDetailRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetInstalledPackageDetailRequest {
+    return new GetInstalledPackageDetailRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | GetInstalledPackageDetailRequest
+      | PlainMessage<GetInstalledPackageDetailRequest>
+      | undefined,
+    b:
+      | GetInstalledPackageDetailRequest
+      | PlainMessage<GetInstalledPackageDetailRequest>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(GetInstalledPackageDetailRequest, a, b);
+  }
+}
+
+/**
+ * CheckInstalledPackageNameAvailabilityRequest
+ *
+ * Request for CheckInstalledPackageNameAvailability
+ *
+ * @generated from message kubeappsapis.core.packages.v1.CheckInstalledPackageNameAvailabilityRequest
+ */
+export class CheckInstalledPackageNameAvailabilityRequest extends Message<CheckInstalledPackageNameAvailabilityRequest> {
+  /**
+   * The target context where the package is intended to be installed.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.Context target_context = 1;
+   */
+  targetContext?: Context;
+
+  /**
+   * The name intended for the installed package.
+   *
+   * @generated from field: string name = 2;
+   */
+  name = "";
+
+  constructor(data?: PartialMessage<CheckInstalledPackageNameAvailabilityRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName =
+    "kubeappsapis.core.packages.v1.CheckInstalledPackageNameAvailabilityRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "target_context", kind: "message", T: Context },
+    { no: 2, name: "name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): CheckInstalledPackageNameAvailabilityRequest {
+    return new CheckInstalledPackageNameAvailabilityRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): CheckInstalledPackageNameAvailabilityRequest {
+    return new CheckInstalledPackageNameAvailabilityRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): CheckInstalledPackageNameAvailabilityRequest {
+    return new CheckInstalledPackageNameAvailabilityRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | CheckInstalledPackageNameAvailabilityRequest
+      | PlainMessage<CheckInstalledPackageNameAvailabilityRequest>
+      | undefined,
+    b:
+      | CheckInstalledPackageNameAvailabilityRequest
+      | PlainMessage<CheckInstalledPackageNameAvailabilityRequest>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(CheckInstalledPackageNameAvailabilityRequest, a, b);
+  }
+}
+
+/**
+ * CheckInstalledPackageNameAvailabilityResponse
+ *
+ * Response for CheckInstalledPackageNameAvailability
+ *
+ * @generated from message kubeappsapis.core.packages.v1.CheckInstalledPackageNameAvailabilityResponse
+ */
+export class CheckInstalledPackageNameAvailabilityResponse extends Message<CheckInstalledPackageNameAvailabilityResponse> {
+  /**
+   * Whether no installed package, of any plugin, uses the name in the target namespace.
+   *
+   * @generated from field: bool available = 1;
+   */
+  available = false;
+
+  /**
+   * The installed packages already using the name, if any.
+   *
+   * @generated from field: repeated kubeappsapis.core.packages.v1.InstalledPackageReference conflicts = 2;
+   */
+  conflicts: InstalledPackageReference[] = [];
+
+  constructor(data?: PartialMessage<CheckInstalledPackageNameAvailabilityResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName =
+    "kubeappsapis.core.packages.v1.CheckInstalledPackageNameAvailabilityResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "available", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 2, name: "conflicts", kind: "message", T: InstalledPackageReference, repeated: true },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): CheckInstalledPackageNameAvailabilityResponse {
+    return new CheckInstalledPackageNameAvailabilityResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): CheckInstalledPackageNameAvailabilityResponse {
+    return new CheckInstalledPackageNameAvailabilityResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): CheckInstalledPackageNameAvailabilityResponse {
+    return new CheckInstalledPackageNameAvailabilityResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | CheckInstalledPackageNameAvailabilityResponse
+      | PlainMessage<CheckInstalledPackageNameAvailabilityResponse>
+      | undefined,
+    b:
+      | CheckInstalledPackageNameAvailabilityResponse
+      | PlainMessage<CheckInstalledPackageNameAvailabilityResponse>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(CheckInstalledPackageNameAvailabilityResponse, a, b);
+  }
+}
+
+/**
+ * CreateInstalledPackageRequest
+ *
+ * Request for CreateInstalledPackage
+ *
+ * @generated from message kubeappsapis.core.packages.v1.CreateInstalledPackageRequest
+ */
+export class CreateInstalledPackageRequest extends Message<CreateInstalledPackageRequest> {
+  /**
+   * A reference uniquely identifying the package available for installation.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.AvailablePackageReference available_package_ref = 1;
+   */
+  availablePackageRef?: AvailablePackageReference;
+
+  /**
+   * The target context where the package is intended to be installed.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.Context target_context = 2;
+   */
+  targetContext?: Context;
+
+  /**
+   * A user-provided name for the installed package (eg. project-x-db)
+   *
+   * Plugins supporting it (eg. helm) generate a name which is not in use in
+   * the target namespace when it is empty.
+   *
+   * @generated from field: string name = 3;
+   */
+  name = "";
+
+  /**
+   * For helm this will be the exact version in VersionReference.version
+   * For other plugins we can extend the VersionReference as needed.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.VersionReference pkg_version_reference = 4;
+   */
+  pkgVersionReference?: VersionReference;
+
+  /**
+   * An optional serialized values string to be included when templating a package
+   * in the format expected by the plugin. Included when the backend format doesn't
+   * use secrets or configmaps for values or supports both. These values are layered
+   * on top of any values refs above, when relevant.
+   *
+   * @generated from field: string values = 5;
+   */
+  values = "";
+
+  /**
+   * An optional field for specifying data common to systems that reconcile
+   * the package on the cluster.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.ReconciliationOptions reconciliation_options = 6;
+   */
+  reconciliationOptions?: ReconciliationOptions;
+
+  /**
+   * Custom data added by the plugin
+   *
+   * A plugin can define custom details for data which is not yet, or never will
+   * be specified in the core CreateInstalledPackageRequest fields. The use
+   * of an `Any` field means that each plugin can define the structure of this
+   * message as required, while still satisfying the core interface.
+   * See https://developers.google.com/protocol-buffers/docs/proto3#any
+   *
+   * @generated from field: google.protobuf.Any custom_detail = 7;
+   */
+  customDetail?: Any;
+
+  /**
+   * Whether to create the target namespace if it does not exist, with the
+   * labels and annotations configured for the plugins. The user must be
+   * allowed to create namespaces.
+   *
+   * @generated from field: bool create_namespace = 8;
+   */
+  createNamespace = false;
+
+  /**
+   * An optional field for specifying the labels and annotations set on the
+   * object created by the plugin to install the package, such as a
+   * kapp-controller PackageInstall or a Flux HelmRelease.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.InstalledPackageMetadata metadata = 9;
+   */
+  metadata?: InstalledPackageMetadata;
+
+  constructor(data?: PartialMessage<CreateInstalledPackageRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.CreateInstalledPackageRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "available_package_ref", kind: "message", T: AvailablePackageReference },
+    { no: 2, name: "target_context", kind: "message", T: Context },
+    { no: 3, name: "name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "pkg_version_reference", kind: "message", T: VersionReference },
+    { no: 5, name: "values", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 6, name: "reconciliation_options", kind: "message", T: ReconciliationOptions },
+    { no: 7, name: "custom_detail", kind: "message", T: Any },
+    { no: 8, name: "create_namespace", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 9, name: "metadata", kind: "message", T: InstalledPackageMetadata },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): CreateInstalledPackageRequest {
+    return new CreateInstalledPackageRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): CreateInstalledPackageRequest {
+    return new CreateInstalledPackageRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): CreateInstalledPackageRequest {
+    return new CreateInstalledPackageRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: CreateInstalledPackageRequest | PlainMessage<CreateInstalledPackageRequest> | undefined,
+    b: CreateInstalledPackageRequest | PlainMessage<CreateInstalledPackageRequest> | undefined,
+  ): boolean {
+    return proto3.util.equals(CreateInstalledPackageRequest, a, b);
+  }
+}
+
+/**
+ * UpdateInstalledPackageRequest
+ *
+ * Request for UpdateInstalledPackage. The intent is to reach the desired state specified
+ * by the fields in the request, while leaving other fields intact. This is a whole
+ * object "Update" semantics rather than "Patch" semantics. The caller will provide the
+ * values for the fields below, which will replace, or be overlaid onto, the
+ * corresponding fields in the existing resource. For example, with the
+ * UpdateInstalledPackageRequest, it is not possible to change just the 'package version
+ * reference' without also specifying 'values' field. As a side effect, not specifying the
+ * 'values' field in the request means there are no values specified in the desired state.
+ * So the meaning of each field value is describing the desired state of the corresponding
+ * field in the resource after the update operation has completed the renconciliation.
+ *
+ * @generated from message kubeappsapis.core.packages.v1.UpdateInstalledPackageRequest
+ */
+export class UpdateInstalledPackageRequest extends Message<UpdateInstalledPackageRequest> {
+  /**
+   * A reference uniquely identifying the installed package being updated.
+   * Required
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.InstalledPackageReference installed_package_ref = 1;
+   */
+  installedPackageRef?: InstalledPackageReference;
+
+  /**
+   * For helm this will be the exact version in VersionReference.version
+   * For fluxv2 this could be any semver constraint expression
+   * For other plugins we can extend the VersionReference as needed. Optional
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.VersionReference pkg_version_reference = 2;
+   */
+  pkgVersionReference?: VersionReference;
+
+  /**
+   * An optional serialized values string to be included when templating a
+   * package in the format expected by the plugin. Included when the backend
+   * format doesn't use secrets or configmaps for values or supports both.
+   * These values are layered on top of any values refs above, when
+   * relevant.
+   *
+   * @generated from field: string values = 3;
+   */
+  values = "";
+
+  /**
+   * An optional field for specifying data common to systems that reconcile
+   * the package on the cluster.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.ReconciliationOptions reconciliation_options = 4;
+   */
+  reconciliationOptions?: ReconciliationOptions;
+
+  /**
+   * Custom data added by the plugin
+   *
+   * A plugin can define custom details for data which is not yet, or never will
+   * be specified in the core UpdateInstalledPackageRequest fields. The use
+   * of an `Any` field means that each plugin can define the structure of this
+   * message as required, while still satisfying the core interface.
+   * See https://developers.google.com/protocol-buffers/docs/proto3#any
+   *
+   * @generated from field: google.protobuf.Any custom_detail = 5;
+   */
+  customDetail?: Any;
+
+  /**
+   * An optional field for specifying the labels and annotations of the
+   * installed package. When provided, they replace those previously set,
+   * except the protected ones. When not provided, they are left intact.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.InstalledPackageMetadata metadata = 6;
+   */
+  metadata?: InstalledPackageMetadata;
+
+  /**
+   * An optional field for keeping the values currently applied to the
+   * installed package, as stored by the plugin, rather than replacing them
+   * with the values above, which must then be empty.
+   *
+   * @generated from field: bool reuse_values = 7;
+   */
+  reuseValues = false;
+
+  constructor(data?: PartialMessage<UpdateInstalledPackageRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.UpdateInstalledPackageRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "installed_package_ref", kind: "message", T: InstalledPackageReference },
+    { no: 2, name: "pkg_version_reference", kind: "message", T: VersionReference },
+    { no: 3, name: "values", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "reconciliation_options", kind: "message", T: ReconciliationOptions },
+    { no: 5, name: "custom_detail", kind: "message", T: Any },
+    { no: 6, name: "metadata", kind: "message", T: InstalledPackageMetadata },
+    { no: 7, name: "reuse_values", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): UpdateInstalledPackageRequest {
+    return new UpdateInstalledPackageRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): UpdateInstalledPackageRequest {
+    return new UpdateInstalledPackageRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): UpdateInstalledPackageRequest {
+    return new UpdateInstalledPackageRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: UpdateInstalledPackageRequest | PlainMessage<UpdateInstalledPackageRequest> | undefined,
+    b: UpdateInstalledPackageRequest | PlainMessage<UpdateInstalledPackageRequest> | undefined,
+  ): boolean {
+    return proto3.util.equals(UpdateInstalledPackageRequest, a, b);
+  }
+}
+
+/**
+ * DeleteInstalledPackageRequest
+ *
+ * Request for DeleteInstalledPackage
+ *
+ * @generated from message kubeappsapis.core.packages.v1.DeleteInstalledPackageRequest
+ */
+export class DeleteInstalledPackageRequest extends Message<DeleteInstalledPackageRequest> {
+  /**
+   * A reference to uniquely identify the installed package to be deleted.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.InstalledPackageReference installed_package_ref = 1;
+   */
+  installedPackageRef?: InstalledPackageReference;
+
+  /**
+   * Custom data added by the plugin
+   *
+   * A plugin can define custom details for data which is not yet, or never will
+   * be specified in the core DeleteInstalledPackageRequest fields, such as
+   * deletion options. The use of an `Any` field means that each plugin can
+   * define the structure of this message as required, while still satisfying
+   * the core interface.
+   * See https://developers.google.com/protocol-buffers/docs/proto3#any
+   *
+   * @generated from field: google.protobuf.Any custom_detail = 2;
+   */
+  customDetail?: Any;
+
+  constructor(data?: PartialMessage<DeleteInstalledPackageRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.DeleteInstalledPackageRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "installed_package_ref", kind: "message", T: InstalledPackageReference },
+    { no: 2, name: "custom_detail", kind: "message", T: Any },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): DeleteInstalledPackageRequest {
+    return new DeleteInstalledPackageRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): DeleteInstalledPackageRequest {
+    return new DeleteInstalledPackageRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): DeleteInstalledPackageRequest {
+    return new DeleteInstalledPackageRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: DeleteInstalledPackageRequest | PlainMessage<DeleteInstalledPackageRequest> | undefined,
+    b: DeleteInstalledPackageRequest | PlainMessage<DeleteInstalledPackageRequest> | undefined,
+  ): boolean {
+    return proto3.util.equals(DeleteInstalledPackageRequest, a, b);
+  }
+}
+
+/**
+ * GetInstalledPackageResourceRefsRequest
+ *
+ * Request for GetInstalledPackageResourceRefs
+ *
+ * @generated from message kubeappsapis.core.packages.v1.GetInstalledPackageResourceRefsRequest
+ */
+export class GetInstalledPackageResourceRefsRequest extends Message<GetInstalledPackageResourceRefsRequest> {
+  /**
+   * @generated from field: kubeappsapis.core.packages.v1.InstalledPackageReference installed_package_ref = 1;
+   */
+  installedPackageRef?: InstalledPackageReference;
+
+  constructor(data?: PartialMessage<GetInstalledPackageResourceRefsRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.GetInstalledPackageResourceRefsRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "installed_package_ref", kind: "message", T: InstalledPackageReference },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): GetInstalledPackageResourceRefsRequest {
+    return new GetInstalledPackageResourceRefsRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): GetInstalledPackageResourceRefsRequest {
+    return new GetInstalledPackageResourceRefsRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetInstalledPackageResourceRefsRequest {
+    return new GetInstalledPackageResourceRefsRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | GetInstalledPackageResourceRefsRequest
+      | PlainMessage<GetInstalledPackageResourceRefsRequest>
+      | undefined,
+    b:
+      | GetInstalledPackageResourceRefsRequest
+      | PlainMessage<GetInstalledPackageResourceRefsRequest>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(GetInstalledPackageResourceRefsRequest, a, b);
+  }
+}
+
+/**
+ * GetInstalledPackageOperationStatusRequest
+ *
+ * Request for GetInstalledPackageOperationStatus
+ *
+ * @generated from message kubeappsapis.core.packages.v1.GetInstalledPackageOperationStatusRequest
+ */
+export class GetInstalledPackageOperationStatusRequest extends Message<GetInstalledPackageOperationStatusRequest> {
+  /**
+   * The installed package reference for which the operation status is requested
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.InstalledPackageReference installed_package_ref = 1;
+   */
+  installedPackageRef?: InstalledPackageReference;
+
+  constructor(data?: PartialMessage<GetInstalledPackageOperationStatusRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName =
+    "kubeappsapis.core.packages.v1.GetInstalledPackageOperationStatusRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "installed_package_ref", kind: "message", T: InstalledPackageReference },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): GetInstalledPackageOperationStatusRequest {
+    return new GetInstalledPackageOperationStatusRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): GetInstalledPackageOperationStatusRequest {
+    return new GetInstalledPackageOperationStatusRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetInstalledPackageOperationStatusRequest {
+    return new GetInstalledPackageOperationStatusRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | GetInstalledPackageOperationStatusRequest
+      | PlainMessage<GetInstalledPackageOperationStatusRequest>
+      | undefined,
+    b:
+      | GetInstalledPackageOperationStatusRequest
+      | PlainMessage<GetInstalledPackageOperationStatusRequest>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(GetInstalledPackageOperationStatusRequest, a, b);
+  }
+}
+
+/**
+ * BulkUpdateInstalledPackagesRequest
+ *
+ * Request for BulkUpdateInstalledPackages
+ *
+ * @generated from message kubeappsapis.core.packages.v1.BulkUpdateInstalledPackagesRequest
+ */
+export class BulkUpdateInstalledPackagesRequest extends Message<BulkUpdateInstalledPackagesRequest> {
+  /**
+   * The references of the installed packages to be updated. Required
+   *
+   * @generated from field: repeated kubeappsapis.core.packages.v1.InstalledPackageReference installed_package_refs = 1;
+   */
+  installedPackageRefs: InstalledPackageReference[] = [];
+
+  /**
+   * The version reference each installed package is updated to, as in
+   * UpdateInstalledPackageRequest. Required
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.VersionReference pkg_version_reference = 2;
+   */
+  pkgVersionReference?: VersionReference;
+
+  /**
+   * The maximum number of updates performed at the same time. Defaults to
+   * 10 when unset.
+   *
+   * @generated from field: int32 max_concurrency = 3;
+   */
+  maxConcurrency = 0;
+
+  constructor(data?: PartialMessage<BulkUpdateInstalledPackagesRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.BulkUpdateInstalledPackagesRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    {
+      no: 1,
+      name: "installed_package_refs",
+      kind: "message",
+      T: InstalledPackageReference,
+      repeated: true,
+    },
+    { no: 2, name: "pkg_version_reference", kind: "message", T: VersionReference },
+    { no: 3, name: "max_concurrency", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): BulkUpdateInstalledPackagesRequest {
+    return new BulkUpdateInstalledPackagesRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): BulkUpdateInstalledPackagesRequest {
+    return new BulkUpdateInstalledPackagesRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): BulkUpdateInstalledPackagesRequest {
+    return new BulkUpdateInstalledPackagesRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | BulkUpdateInstalledPackagesRequest
+      | PlainMessage<BulkUpdateInstalledPackagesRequest>
+      | undefined,
+    b:
+      | BulkUpdateInstalledPackagesRequest
+      | PlainMessage<BulkUpdateInstalledPackagesRequest>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(BulkUpdateInstalledPackagesRequest, a, b);
+  }
+}
+
+/**
+ * BulkDeleteInstalledPackagesRequest
+ *
+ * Request for BulkDeleteInstalledPackages
+ *
+ * @generated from message kubeappsapis.core.packages.v1.BulkDeleteInstalledPackagesRequest
+ */
+export class BulkDeleteInstalledPackagesRequest extends Message<BulkDeleteInstalledPackagesRequest> {
+  /**
+   * The references of the installed packages to be deleted. Required
+   *
+   * @generated from field: repeated kubeappsapis.core.packages.v1.InstalledPackageReference installed_package_refs = 1;
+   */
+  installedPackageRefs: InstalledPackageReference[] = [];
+
+  /**
+   * Custom data added by the plugin, such as deletion options, sent with
+   * the deletion of every installed package, as in
+   * DeleteInstalledPackageRequest.
+   *
+   * @generated from field: google.protobuf.Any custom_detail = 2;
+   */
+  customDetail?: Any;
+
+  /**
+   * The maximum number of deletions performed at the same time. Defaults to
+   * 10 when unset.
+   *
+   * @generated from field: int32 max_concurrency = 3;
+   */
+  maxConcurrency = 0;
+
+  constructor(data?: PartialMessage<BulkDeleteInstalledPackagesRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.BulkDeleteInstalledPackagesRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    {
+      no: 1,
+      name: "installed_package_refs",
+      kind: "message",
+      T: InstalledPackageReference,
+      repeated: true,
+    },
+    { no: 2, name: "custom_detail", kind: "message", T: Any },
+    { no: 3, name: "max_concurrency", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): BulkDeleteInstalledPackagesRequest {
+    return new BulkDeleteInstalledPackagesRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): BulkDeleteInstalledPackagesRequest {
+    return new BulkDeleteInstalledPackagesRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): BulkDeleteInstalledPackagesRequest {
+    return new BulkDeleteInstalledPackagesRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | BulkDeleteInstalledPackagesRequest
+      | PlainMessage<BulkDeleteInstalledPackagesRequest>
+      | undefined,
+    b:
+      | BulkDeleteInstalledPackagesRequest
+      | PlainMessage<BulkDeleteInstalledPackagesRequest>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(BulkDeleteInstalledPackagesRequest, a, b);
+  }
+}
+
+/**
+ * CloneInstalledPackageRequest
+ *
+ * Request for CloneInstalledPackage
+ *
+ * @generated from message kubeappsapis.core.packages.v1.CloneInstalledPackageRequest
+ */
+export class CloneInstalledPackageRequest extends Message<CloneInstalledPackageRequest> {
+  /**
+   * The reference of the installed package to be cloned. Required
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.InstalledPackageReference installed_package_ref = 1;
+   */
+  installedPackageRef?: InstalledPackageReference;
+
+  /**
+   * The context, that is, the cluster and namespace, in which the clone is
+   * installed. Required
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.Context target_context = 2;
+   */
+  targetContext?: Context;
+
+  /**
+   * The name of the clone. Defaults to the name of the cloned installed
+   * package.
+   *
+   * @generated from field: string name = 3;
+   */
+  name = "";
+
+  /**
+   * The reconciliation options of the clone, replacing the ones of the cloned
+   * installed package, such as to use a service account of the target
+   * namespace.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.ReconciliationOptions reconciliation_options = 4;
+   */
+  reconciliationOptions?: ReconciliationOptions;
+
+  /**
+   * Custom data added by the plugin, replacing the custom detail of the cloned
+   * installed package, such as to change its dependencies.
+   *
+   * @generated from field: google.protobuf.Any custom_detail = 5;
+   */
+  customDetail?: Any;
+
+  /**
+   * Whether the target namespace is created when it does not exist, as in
+   * CreateInstalledPackageRequest.
+   *
+   * @generated from field: bool create_namespace = 6;
+   */
+  createNamespace = false;
+
+  /**
+   * Whether the cloned installed package is deleted once its clone is
+   * created, moving it to the target context.
+   *
+   * @generated from field: bool delete_source = 7;
+   */
+  deleteSource = false;
+
+  constructor(data?: PartialMessage<CloneInstalledPackageRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.CloneInstalledPackageRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "installed_package_ref", kind: "message", T: InstalledPackageReference },
+    { no: 2, name: "target_context", kind: "message", T: Context },
+    { no: 3, name: "name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "reconciliation_options", kind: "message", T: ReconciliationOptions },
+    { no: 5, name: "custom_detail", kind: "message", T: Any },
+    { no: 6, name: "create_namespace", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 7, name: "delete_source", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): CloneInstalledPackageRequest {
+    return new CloneInstalledPackageRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): CloneInstalledPackageRequest {
+    return new CloneInstalledPackageRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): CloneInstalledPackageRequest {
+    return new CloneInstalledPackageRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: CloneInstalledPackageRequest | PlainMessage<CloneInstalledPackageRequest> | undefined,
+    b: CloneInstalledPackageRequest | PlainMessage<CloneInstalledPackageRequest> | undefined,
+  ): boolean {
+    return proto3.util.equals(CloneInstalledPackageRequest, a, b);
+  }
+}
+
+/**
+ * GetAvailablePackageSummariesResponse
+ *
+ * Response for GetAvailablePackageSummaries
+ *
+ * TODO: add example for API docs
+ * option (grpc.gateway.protoc_gen_openapiv2.options.openapiv2_schema) = {
+ *   example: '{"available_package_summaries": [{}]}'
+ * };
+ *
+ * @generated from message kubeappsapis.core.packages.v1.GetAvailablePackageSummariesResponse
+ */
+export class GetAvailablePackageSummariesResponse extends Message<GetAvailablePackageSummariesResponse> {
+  /**
+   * Available packages summaries
+   *
+   * List of AvailablePackageSummary
+   *
+   * @generated from field: repeated kubeappsapis.core.packages.v1.AvailablePackageSummary available_package_summaries = 1;
+   */
+  availablePackageSummaries: AvailablePackageSummary[] = [];
+
+  /**
+   * Next page token
+   *
+   * This field represents the pagination token to retrieve the next page of
+   * results. If the value is "", it means no further results for the request.
+   *
+   * @generated from field: string next_page_token = 2;
+   */
+  nextPageToken = "";
+
+  /**
+   * Categories
+   *
+   * This optional field contains the distinct category names considering the FilterOptions.
+   *
+   * @generated from field: repeated string categories = 3;
+   */
+  categories: string[] = [];
+
+  /**
+   * Partial results
+   *
+   * This optional field is set when the results do not include some of the
+   * requested namespaces, for example because the user cannot access them.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.PartialResults partial_results = 4;
+   */
+  partialResults?: PartialResults;
+
+  /**
+   * Total size
+   *
+   * This optional field contains the total number of available packages
+   * matching the request, across every page. It is only set when it can be
+   * cheaply computed, so clients may need to rely on the next_page_token to
+   * know whether there are more results.
+   *
+   * @generated from field: optional int32 total_size = 5;
+   */
+  totalSize?: number;
+
+  constructor(data?: PartialMessage<GetAvailablePackageSummariesResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.GetAvailablePackageSummariesResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    {
+      no: 1,
+      name: "available_package_summaries",
+      kind: "message",
+      T: AvailablePackageSummary,
+      repeated: true,
+    },
+    { no: 2, name: "next_page_token", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "categories", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 4, name: "partial_results", kind: "message", T: PartialResults },
+    { no: 5, name: "total_size", kind: "scalar", T: 5 /* ScalarType.INT32 */, opt: true },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): GetAvailablePackageSummariesResponse {
+    return new GetAvailablePackageSummariesResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): GetAvailablePackageSummariesResponse {
+    return new GetAvailablePackageSummariesResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetAvailablePackageSummariesResponse {
+    return new GetAvailablePackageSummariesResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | GetAvailablePackageSummariesResponse
+      | PlainMessage<GetAvailablePackageSummariesResponse>
+      | undefined,
+    b:
+      | GetAvailablePackageSummariesResponse
+      | PlainMessage<GetAvailablePackageSummariesResponse>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(GetAvailablePackageSummariesResponse, a, b);
+  }
+}
+
+/**
+ * GetAvailablePackageFiltersResponse
+ *
+ * Response for GetAvailablePackageFilters
+ *
+ * @generated from message kubeappsapis.core.packages.v1.GetAvailablePackageFiltersResponse
+ */
+export class GetAvailablePackageFiltersResponse extends Message<GetAvailablePackageFiltersResponse> {
+  /**
+   * Categories
+   *
+   * The distinct category names of the available packages, sorted by name.
+   *
+   * @generated from field: repeated string categories = 1;
+   */
+  categories: string[] = [];
+
+  /**
+   * Providers
+   *
+   * The distinct provider names of the available packages, sorted by name.
+   * Only the plugins whose packages have a provider (eg. kapp_controller)
+   * contribute to this list.
+   *
+   * @generated from field: repeated string providers = 2;
+   */
+  providers: string[] = [];
+
+  constructor(data?: PartialMessage<GetAvailablePackageFiltersResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.GetAvailablePackageFiltersResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "categories", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 2, name: "providers", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): GetAvailablePackageFiltersResponse {
+    return new GetAvailablePackageFiltersResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): GetAvailablePackageFiltersResponse {
+    return new GetAvailablePackageFiltersResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetAvailablePackageFiltersResponse {
+    return new GetAvailablePackageFiltersResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | GetAvailablePackageFiltersResponse
+      | PlainMessage<GetAvailablePackageFiltersResponse>
+      | undefined,
+    b:
+      | GetAvailablePackageFiltersResponse
+      | PlainMessage<GetAvailablePackageFiltersResponse>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(GetAvailablePackageFiltersResponse, a, b);
+  }
+}
+
+/**
+ * GetAvailablePackageDetailResponse
+ *
+ * Response for GetAvailablePackageDetail
+ *
+ * TODO: add example for API docs
+ * option (grpc.gateway.protoc_gen_openapiv2.options.openapiv2_schema) = {
+ *   example: '{"available_package_detail": [{}]}'
+ * };
+ *
+ * @generated from message kubeappsapis.core.packages.v1.GetAvailablePackageDetailResponse
+ */
+export class GetAvailablePackageDetailResponse extends Message<GetAvailablePackageDetailResponse> {
+  /**
+   * Available package detail
+   *
+   * The requested AvailablePackageDetail
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.AvailablePackageDetail available_package_detail = 1;
+   */
+  availablePackageDetail?: AvailablePackageDetail;
+
+  constructor(data?: PartialMessage<GetAvailablePackageDetailResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.GetAvailablePackageDetailResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "available_package_detail", kind: "message", T: AvailablePackageDetail },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): GetAvailablePackageDetailResponse {
+    return new GetAvailablePackageDetailResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): GetAvailablePackageDetailResponse {
+    return new GetAvailablePackageDetailResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetAvailablePackageDetailResponse {
+    return new GetAvailablePackageDetailResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | GetAvailablePackageDetailResponse
+      | PlainMessage<GetAvailablePackageDetailResponse>
+      | undefined,
+    b:
+      | GetAvailablePackageDetailResponse
+      | PlainMessage<GetAvailablePackageDetailResponse>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(GetAvailablePackageDetailResponse, a, b);
+  }
+}
+
+/**
+ * GetAvailablePackageVersionsResponse
+ *
+ * Response for GetAvailablePackageVersions
+ *
+ * TODO: add example for API docs
+ * option (grpc.gateway.protoc_gen_openapiv2.options.openapiv2_schema) = {
+ *   example: '{"package_app_versions": [{}]}'
+ * };
+ *
+ * @generated from message kubeappsapis.core.packages.v1.GetAvailablePackageVersionsResponse
+ */
+export class GetAvailablePackageVersionsResponse extends Message<GetAvailablePackageVersionsResponse> {
+  /**
+   * Package app versions
+   *
+   * By default (when version_query is empty or ignored) the response
+   * should contain an ordered summary of versions including the most recent three
+   * patch versions of the most recent three minor versions of the most recent three
+   * major versions when available, something like:
+   * [
+   *   { pkg_version: "10.3.19", app_version: "2.16.8" },
+   *   { pkg_version: "10.3.18", app_version: "2.16.8" },
+   *   { pkg_version: "10.3.17", app_version: "2.16.7" },
+   *   { pkg_version: "10.2.6", app_version: "2.15.3" },
+   *   { pkg_version: "10.2.5", app_version: "2.15.2" },
+   *   { pkg_version: "10.2.4", app_version: "2.15.2" },
+   *   { pkg_version: "10.1.8", app_version: "2.13.5" },
+   *   { pkg_version: "10.1.7", app_version: "2.13.5" },
+   *   { pkg_version: "10.1.6", app_version: "2.13.5" },
+   *   { pkg_version: "9.5.4", app_version: "2.8.9" },
+   *   ...
+   *   { pkg_version: "8.2.5", app_version: "1.19.5" },
+   *   ...
+   * ]
+   * If a version_query is present and the plugin chooses to support it,
+   * the full history of versions matching the version query should be returned.
+   *
+   * @generated from field: repeated kubeappsapis.core.packages.v1.PackageAppVersion package_app_versions = 1;
+   */
+  packageAppVersions: PackageAppVersion[] = [];
+
+  constructor(data?: PartialMessage<GetAvailablePackageVersionsResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.GetAvailablePackageVersionsResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "package_app_versions", kind: "message", T: PackageAppVersion, repeated: true },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): GetAvailablePackageVersionsResponse {
+    return new GetAvailablePackageVersionsResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): GetAvailablePackageVersionsResponse {
+    return new GetAvailablePackageVersionsResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetAvailablePackageVersionsResponse {
+    return new GetAvailablePackageVersionsResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | GetAvailablePackageVersionsResponse
+      | PlainMessage<GetAvailablePackageVersionsResponse>
+      | undefined,
+    b:
+      | GetAvailablePackageVersionsResponse
+      | PlainMessage<GetAvailablePackageVersionsResponse>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(GetAvailablePackageVersionsResponse, a, b);
+  }
+}
+
+/**
+ * GetAvailablePackageTextAssetResponse
+ *
+ * Response for GetAvailablePackageTextAsset
+ *
+ * @generated from message kubeappsapis.core.packages.v1.GetAvailablePackageTextAssetResponse
+ */
+export class GetAvailablePackageTextAssetResponse extends Message<GetAvailablePackageTextAssetResponse> {
+  /**
+   * Content
+   *
+   * The requested range of the text asset. The range is adjusted so that a
+   * multi-byte character is never split.
+   *
+   * @generated from field: string content = 1;
+   */
+  content = "";
+
+  /**
+   * Offset
+   *
+   * The offset, in bytes, of the returned content within the text asset.
+   *
+   * @generated from field: int64 offset = 2;
+   */
+  offset = protoInt64.zero;
+
+  /**
+   * Next offset
+   *
+   * The offset from which the next range should be requested, or 0 when the
+   * end of the text asset has been reached.
+   *
+   * @generated from field: int64 next_offset = 3;
+   */
+  nextOffset = protoInt64.zero;
+
+  /**
+   * Total size
+   *
+   * The total size, in bytes, of the text asset.
+   *
+   * @generated from field: int64 total_size = 4;
+   */
+  totalSize = protoInt64.zero;
+
+  constructor(data?: PartialMessage<GetAvailablePackageTextAssetResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.GetAvailablePackageTextAssetResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "content", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "offset", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 3, name: "next_offset", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 4, name: "total_size", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): GetAvailablePackageTextAssetResponse {
+    return new GetAvailablePackageTextAssetResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): GetAvailablePackageTextAssetResponse {
+    return new GetAvailablePackageTextAssetResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetAvailablePackageTextAssetResponse {
+    return new GetAvailablePackageTextAssetResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | GetAvailablePackageTextAssetResponse
+      | PlainMessage<GetAvailablePackageTextAssetResponse>
+      | undefined,
+    b:
+      | GetAvailablePackageTextAssetResponse
+      | PlainMessage<GetAvailablePackageTextAssetResponse>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(GetAvailablePackageTextAssetResponse, a, b);
+  }
+}
+
+/**
+ * GetAvailablePackageChangelogResponse
+ *
+ * Response for GetAvailablePackageChangelog
+ *
+ * @generated from message kubeappsapis.core.packages.v1.GetAvailablePackageChangelogResponse
+ */
+export class GetAvailablePackageChangelogResponse extends Message<GetAvailablePackageChangelogResponse> {
+  /**
+   * Changelogs
+   *
+   * The changelog of each version between the requested versions, sorted from
+   * the most recent version to the oldest one. Versions without any release
+   * notes or changes are omitted.
+   *
+   * @generated from field: repeated kubeappsapis.core.packages.v1.PackageVersionChangelog changelogs = 1;
+   */
+  changelogs: PackageVersionChangelog[] = [];
+
+  constructor(data?: PartialMessage<GetAvailablePackageChangelogResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.GetAvailablePackageChangelogResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "changelogs", kind: "message", T: PackageVersionChangelog, repeated: true },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): GetAvailablePackageChangelogResponse {
+    return new GetAvailablePackageChangelogResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): GetAvailablePackageChangelogResponse {
+    return new GetAvailablePackageChangelogResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetAvailablePackageChangelogResponse {
+    return new GetAvailablePackageChangelogResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | GetAvailablePackageChangelogResponse
+      | PlainMessage<GetAvailablePackageChangelogResponse>
+      | undefined,
+    b:
+      | GetAvailablePackageChangelogResponse
+      | PlainMessage<GetAvailablePackageChangelogResponse>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(GetAvailablePackageChangelogResponse, a, b);
+  }
+}
+
+/**
+ * GetInstalledPackageSummariesResponse
+ *
+ * Response for GetInstalledPackageSummaries
+ *
+ * @generated from message kubeappsapis.core.packages.v1.GetInstalledPackageSummariesResponse
+ */
+export class GetInstalledPackageSummariesResponse extends Message<GetInstalledPackageSummariesResponse> {
+  /**
+   * Installed packages summaries
+   *
+   * List of InstalledPackageSummary
+   *
+   * @generated from field: repeated kubeappsapis.core.packages.v1.InstalledPackageSummary installed_package_summaries = 1;
+   */
+  installedPackageSummaries: InstalledPackageSummary[] = [];
+
+  /**
+   * Next page token
+   *
+   * This field represents the pagination token to retrieve the next page of
+   * results. If the value is "", it means no further results for the request.
+   *
+   * @generated from field: string next_page_token = 2;
+   */
+  nextPageToken = "";
+
+  /**
+   * Partial results
+   *
+   * This optional field is set when the results do not include some of the
+   * requested namespaces, for example because the user cannot access them.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.PartialResults partial_results = 3;
+   */
+  partialResults?: PartialResults;
+
+  constructor(data?: PartialMessage<GetInstalledPackageSummariesResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.GetInstalledPackageSummariesResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    {
+      no: 1,
+      name: "installed_package_summaries",
+      kind: "message",
+      T: InstalledPackageSummary,
+      repeated: true,
+    },
+    { no: 2, name: "next_page_token", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "partial_results", kind: "message", T: PartialResults },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): GetInstalledPackageSummariesResponse {
+    return new GetInstalledPackageSummariesResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): GetInstalledPackageSummariesResponse {
+    return new GetInstalledPackageSummariesResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetInstalledPackageSummariesResponse {
+    return new GetInstalledPackageSummariesResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | GetInstalledPackageSummariesResponse
+      | PlainMessage<GetInstalledPackageSummariesResponse>
+      | undefined,
+    b:
+      | GetInstalledPackageSummariesResponse
+      | PlainMessage<GetInstalledPackageSummariesResponse>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(GetInstalledPackageSummariesResponse, a, b);
+  }
+}
+
+/**
+ * GetInstalledPackageDetailResponse
+ *
+ * Response for GetInstalledPackageDetail
+ *
+ * TODO: add example for API docs
+ * option (grpc.gateway.protoc_gen_openapiv2.options.openapiv2_schema) = {
+ *   example: '{"installed_package_detail": [{}]}'
+ * };
+ *
+ * @generated from message kubeappsapis.core.packages.v1.GetInstalledPackageDetailResponse
+ */
+export class GetInstalledPackageDetailResponse extends Message<GetInstalledPackageDetailResponse> {
+  /**
+   * InstalledPackageDetail
+   *
+   * The requested InstalledPackageDetail
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.InstalledPackageDetail installed_package_detail = 1;
+   */
+  installedPackageDetail?: InstalledPackageDetail;
+
+  constructor(data?: PartialMessage<GetInstalledPackageDetailResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.GetInstalledPackageDetailResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "installed_package_detail", kind: "message", T: InstalledPackageDetail },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): GetInstalledPackageDetailResponse {
+    return new GetInstalledPackageDetailResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): GetInstalledPackageDetailResponse {
+    return new GetInstalledPackageDetailResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetInstalledPackageDetailResponse {
+    return new GetInstalledPackageDetailResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | GetInstalledPackageDetailResponse
+      | PlainMessage<GetInstalledPackageDetailResponse>
+      | undefined,
+    b:
+      | GetInstalledPackageDetailResponse
+      | PlainMessage<GetInstalledPackageDetailResponse>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(GetInstalledPackageDetailResponse, a, b);
+  }
+}
+
+/**
+ * CreateInstalledPackageResponse
+ *
+ * Response for CreateInstalledPackage
+ *
+ * TODO: add example for API docs
+ * option (grpc.gateway.protoc_gen_openapiv2.options.openapiv2_schema) = {
+ *   example: '{"installed_package_ref": {}}'
+ * };
+ *
+ * @generated from message kubeappsapis.core.packages.v1.CreateInstalledPackageResponse
+ */
+export class CreateInstalledPackageResponse extends Message<CreateInstalledPackageResponse> {
+  /**
+   * @generated from field: kubeappsapis.core.packages.v1.InstalledPackageReference installed_package_ref = 1;
+   */
+  installedPackageRef?: InstalledPackageReference;
+
+  /**
+   * Warnings about the installed package, such as the quota of the target
+   * namespace not having enough left for the capacity the package requires,
+   * when the plugin is configured to warn rather than to refuse it
+   *
+   * @generated from field: repeated string warnings = 2;
+   */
+  warnings: string[] = [];
+
+  constructor(data?: PartialMessage<CreateInstalledPackageResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.CreateInstalledPackageResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "installed_package_ref", kind: "message", T: InstalledPackageReference },
+    { no: 2, name: "warnings", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): CreateInstalledPackageResponse {
+    return new CreateInstalledPackageResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): CreateInstalledPackageResponse {
+    return new CreateInstalledPackageResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): CreateInstalledPackageResponse {
+    return new CreateInstalledPackageResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: CreateInstalledPackageResponse | PlainMessage<CreateInstalledPackageResponse> | undefined,
+    b: CreateInstalledPackageResponse | PlainMessage<CreateInstalledPackageResponse> | undefined,
+  ): boolean {
+    return proto3.util.equals(CreateInstalledPackageResponse, a, b);
+  }
+}
+
+/**
+ * UpdateInstalledPackageResponse
+ *
+ * Response for UpdateInstalledPackage
+ *
+ * TODO: add example for API docs
+ * option (grpc.gateway.protoc_gen_openapiv2.options.openapiv2_schema) = {
+ *   example: '{"installed_package_ref": {}}'
+ * };
+ *
+ * @generated from message kubeappsapis.core.packages.v1.UpdateInstalledPackageResponse
+ */
+export class UpdateInstalledPackageResponse extends Message<UpdateInstalledPackageResponse> {
+  /**
+   * @generated from field: kubeappsapis.core.packages.v1.InstalledPackageReference installed_package_ref = 1;
+   */
+  installedPackageRef?: InstalledPackageReference;
+
+  constructor(data?: PartialMessage<UpdateInstalledPackageResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.UpdateInstalledPackageResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "installed_package_ref", kind: "message", T: InstalledPackageReference },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): UpdateInstalledPackageResponse {
+    return new UpdateInstalledPackageResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): UpdateInstalledPackageResponse {
+    return new UpdateInstalledPackageResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): UpdateInstalledPackageResponse {
+    return new UpdateInstalledPackageResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: UpdateInstalledPackageResponse | PlainMessage<UpdateInstalledPackageResponse> | undefined,
+    b: UpdateInstalledPackageResponse | PlainMessage<UpdateInstalledPackageResponse> | undefined,
+  ): boolean {
+    return proto3.util.equals(UpdateInstalledPackageResponse, a, b);
+  }
+}
+
+/**
+ * DeleteInstalledPackageResponse
+ *
+ * Response for DeleteInstalledPackage
+ *
+ * For future extensibility only.
+ *
+ * @generated from message kubeappsapis.core.packages.v1.DeleteInstalledPackageResponse
+ */
+export class DeleteInstalledPackageResponse extends Message<DeleteInstalledPackageResponse> {
+  constructor(data?: PartialMessage<DeleteInstalledPackageResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.DeleteInstalledPackageResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => []);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): DeleteInstalledPackageResponse {
+    return new DeleteInstalledPackageResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): DeleteInstalledPackageResponse {
+    return new DeleteInstalledPackageResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): DeleteInstalledPackageResponse {
+    return new DeleteInstalledPackageResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: DeleteInstalledPackageResponse | PlainMessage<DeleteInstalledPackageResponse> | undefined,
+    b: DeleteInstalledPackageResponse | PlainMessage<DeleteInstalledPackageResponse> | undefined,
+  ): boolean {
+    return proto3.util.equals(DeleteInstalledPackageResponse, a, b);
+  }
+}
+
+/**
+ * GetInstalledPackageResourceRefsResponse
+ *
+ * Response for GetInstalledPackageResourceRefs
+ *
+ * @generated from message kubeappsapis.core.packages.v1.GetInstalledPackageResourceRefsResponse
+ */
+export class GetInstalledPackageResourceRefsResponse extends Message<GetInstalledPackageResourceRefsResponse> {
+  /**
+   * @generated from field: kubeappsapis.core.packages.v1.Context context = 1;
+   */
+  context?: Context;
+
+  /**
+   * @generated from field: repeated kubeappsapis.core.packages.v1.ResourceRef resource_refs = 2;
+   */
+  resourceRefs: ResourceRef[] = [];
+
+  constructor(data?: PartialMessage<GetInstalledPackageResourceRefsResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName =
+    "kubeappsapis.core.packages.v1.GetInstalledPackageResourceRefsResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "context", kind: "message", T: Context },
+    { no: 2, name: "resource_refs", kind: "message", T: ResourceRef, repeated: true },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): GetInstalledPackageResourceRefsResponse {
+    return new GetInstalledPackageResourceRefsResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): GetInstalledPackageResourceRefsResponse {
+    return new GetInstalledPackageResourceRefsResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetInstalledPackageResourceRefsResponse {
+    return new GetInstalledPackageResourceRefsResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | GetInstalledPackageResourceRefsResponse
+      | PlainMessage<GetInstalledPackageResourceRefsResponse>
+      | undefined,
+    b:
+      | GetInstalledPackageResourceRefsResponse
+      | PlainMessage<GetInstalledPackageResourceRefsResponse>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(GetInstalledPackageResourceRefsResponse, a, b);
+  }
+}
+
+/**
+ * GetInstalledPackageOperationStatusResponse
+ *
+ * Response for GetInstalledPackageOperationStatus
+ *
+ * @generated from message kubeappsapis.core.packages.v1.GetInstalledPackageOperationStatusResponse
+ */
+export class GetInstalledPackageOperationStatusResponse extends Message<GetInstalledPackageOperationStatusResponse> {
+  /**
+   * Status
+   *
+   * The overall status of the operation. It is pending until every phase
+   * has succeeded or any of them has failed.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.InstalledPackageStatus status = 1;
+   */
+  status?: InstalledPackageStatus;
+
+  /**
+   * Phases
+   *
+   * The phases of the operation, in the order they are run. Plugins not
+   * reporting granular progress return no phases, only the overall status.
+   *
+   * @generated from field: repeated kubeappsapis.core.packages.v1.InstalledPackageOperationPhase phases = 2;
+   */
+  phases: InstalledPackageOperationPhase[] = [];
+
+  constructor(data?: PartialMessage<GetInstalledPackageOperationStatusResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName =
+    "kubeappsapis.core.packages.v1.GetInstalledPackageOperationStatusResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "status", kind: "message", T: InstalledPackageStatus },
+    { no: 2, name: "phases", kind: "message", T: InstalledPackageOperationPhase, repeated: true },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): GetInstalledPackageOperationStatusResponse {
+    return new GetInstalledPackageOperationStatusResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): GetInstalledPackageOperationStatusResponse {
+    return new GetInstalledPackageOperationStatusResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetInstalledPackageOperationStatusResponse {
+    return new GetInstalledPackageOperationStatusResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | GetInstalledPackageOperationStatusResponse
+      | PlainMessage<GetInstalledPackageOperationStatusResponse>
+      | undefined,
+    b:
+      | GetInstalledPackageOperationStatusResponse
+      | PlainMessage<GetInstalledPackageOperationStatusResponse>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(GetInstalledPackageOperationStatusResponse, a, b);
+  }
+}
+
+/**
+ * BulkUpdateInstalledPackagesResponse
+ *
+ * Response for BulkUpdateInstalledPackages
+ *
+ * @generated from message kubeappsapis.core.packages.v1.BulkUpdateInstalledPackagesResponse
+ */
+export class BulkUpdateInstalledPackagesResponse extends Message<BulkUpdateInstalledPackagesResponse> {
+  /**
+   * The result of each update, in the order of the requested references.
+   *
+   * @generated from field: repeated kubeappsapis.core.packages.v1.BulkInstalledPackageResult results = 1;
+   */
+  results: BulkInstalledPackageResult[] = [];
+
+  constructor(data?: PartialMessage<BulkUpdateInstalledPackagesResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.BulkUpdateInstalledPackagesResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "results", kind: "message", T: BulkInstalledPackageResult, repeated: true },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): BulkUpdateInstalledPackagesResponse {
+    return new BulkUpdateInstalledPackagesResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): BulkUpdateInstalledPackagesResponse {
+    return new BulkUpdateInstalledPackagesResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): BulkUpdateInstalledPackagesResponse {
+    return new BulkUpdateInstalledPackagesResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | BulkUpdateInstalledPackagesResponse
+      | PlainMessage<BulkUpdateInstalledPackagesResponse>
+      | undefined,
+    b:
+      | BulkUpdateInstalledPackagesResponse
+      | PlainMessage<BulkUpdateInstalledPackagesResponse>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(BulkUpdateInstalledPackagesResponse, a, b);
+  }
+}
+
+/**
+ * BulkDeleteInstalledPackagesResponse
+ *
+ * Response for BulkDeleteInstalledPackages
+ *
+ * @generated from message kubeappsapis.core.packages.v1.BulkDeleteInstalledPackagesResponse
+ */
+export class BulkDeleteInstalledPackagesResponse extends Message<BulkDeleteInstalledPackagesResponse> {
+  /**
+   * The result of each deletion, in the order of the requested references.
+   *
+   * @generated from field: repeated kubeappsapis.core.packages.v1.BulkInstalledPackageResult results = 1;
+   */
+  results: BulkInstalledPackageResult[] = [];
+
+  constructor(data?: PartialMessage<BulkDeleteInstalledPackagesResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.BulkDeleteInstalledPackagesResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "results", kind: "message", T: BulkInstalledPackageResult, repeated: true },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): BulkDeleteInstalledPackagesResponse {
+    return new BulkDeleteInstalledPackagesResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): BulkDeleteInstalledPackagesResponse {
+    return new BulkDeleteInstalledPackagesResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): BulkDeleteInstalledPackagesResponse {
+    return new BulkDeleteInstalledPackagesResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | BulkDeleteInstalledPackagesResponse
+      | PlainMessage<BulkDeleteInstalledPackagesResponse>
+      | undefined,
+    b:
+      | BulkDeleteInstalledPackagesResponse
+      | PlainMessage<BulkDeleteInstalledPackagesResponse>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(BulkDeleteInstalledPackagesResponse, a, b);
+  }
+}
+
+/**
+ * CloneInstalledPackageResponse
+ *
+ * Response for CloneInstalledPackage
+ *
+ * @generated from message kubeappsapis.core.packages.v1.CloneInstalledPackageResponse
+ */
+export class CloneInstalledPackageResponse extends Message<CloneInstalledPackageResponse> {
+  /**
+   * The reference of the clone.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.InstalledPackageReference installed_package_ref = 1;
+   */
+  installedPackageRef?: InstalledPackageReference;
+
+  /**
+   * Warnings about the clone, as returned when creating it.
+   *
+   * @generated from field: repeated string warnings = 2;
+   */
+  warnings: string[] = [];
+
+  constructor(data?: PartialMessage<CloneInstalledPackageResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.CloneInstalledPackageResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "installed_package_ref", kind: "message", T: InstalledPackageReference },
+    { no: 2, name: "warnings", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): CloneInstalledPackageResponse {
+    return new CloneInstalledPackageResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): CloneInstalledPackageResponse {
+    return new CloneInstalledPackageResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): CloneInstalledPackageResponse {
+    return new CloneInstalledPackageResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: CloneInstalledPackageResponse | PlainMessage<CloneInstalledPackageResponse> | undefined,
+    b: CloneInstalledPackageResponse | PlainMessage<CloneInstalledPackageResponse> | undefined,
+  ): boolean {
+    return proto3.util.equals(CloneInstalledPackageResponse, a, b);
+  }
+}
+
+/**
+ * AvailablePackageSummary
+ *
+ * An AvailablePackageSummary provides a summary of a package available for installation
+ * useful when aggregating many available packages.
+ *
+ * TODO: add example for API docs
+ * option (grpc.gateway.protoc_gen_openapiv2.options.openapiv2_schema) = {
+ *   example: '{}'
+ * };
+ *
+ * @generated from message kubeappsapis.core.packages.v1.AvailablePackageSummary
+ */
+export class AvailablePackageSummary extends Message<AvailablePackageSummary> {
+  /**
+   * Available package reference
+   *
+   * A reference uniquely identifying the package.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.AvailablePackageReference available_package_ref = 1;
+   */
+  availablePackageRef?: AvailablePackageReference;
+
+  /**
+   * Available package name
+   *
+   * The name of the available package
+   *
+   * @generated from field: string name = 2;
+   */
+  name = "";
+
+  /**
+   * Latest available version
+   *
+   * The latest version available for this package. Often expected when viewing
+   * a summary of many available packages.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.PackageAppVersion latest_version = 3;
+   */
+  latestVersion?: PackageAppVersion;
+
+  /**
+   * Available package Icon URL
+   *
+   * A url for an icon.
+   *
+   * @generated from field: string icon_url = 4;
+   */
+  iconUrl = "";
+
+  /**
+   * Available package display name
+   *
+   * A name as displayed to users
+   *
+   * @generated from field: string display_name = 5;
+   */
+  displayName = "";
+
+  /**
+   * Available package short description
+   *
+   * A short description of the app provided by the package
+   *
+   * @generated from field: string short_description = 6;
+   */
+  shortDescription = "";
+
+  /**
+   * Available package categories
+   *
+   * A user-facing list of category names useful for creating richer user interfaces.
+   * Plugins can choose not to implement this
+   *
+   * @generated from field: repeated string categories = 7;
+   */
+  categories: string[] = [];
+
+  constructor(data?: PartialMessage<AvailablePackageSummary>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.AvailablePackageSummary";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "available_package_ref", kind: "message", T: AvailablePackageReference },
+    { no: 2, name: "name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "latest_version", kind: "message", T: PackageAppVersion },
+    { no: 4, name: "icon_url", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "display_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 6, name: "short_description", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 7, name: "categories", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): AvailablePackageSummary {
+    return new AvailablePackageSummary().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): AvailablePackageSummary {
+    return new AvailablePackageSummary().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): AvailablePackageSummary {
+    return new AvailablePackageSummary().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: AvailablePackageSummary | PlainMessage<AvailablePackageSummary> | undefined,
+    b: AvailablePackageSummary | PlainMessage<AvailablePackageSummary> | undefined,
+  ): boolean {
+    return proto3.util.equals(AvailablePackageSummary, a, b);
+  }
+}
+
+/**
+ * AvailablePackageDetail
+ *
+ * An AvailablePackageDetail provides additional details required when
+ * inspecting an individual package.
+ *
+ * TODO: add example for API docs
+ * option (grpc.gateway.protoc_gen_openapiv2.options.openapiv2_schema) = {
+ *   example: '{}'
+ * };
+ *
+ * @generated from message kubeappsapis.core.packages.v1.AvailablePackageDetail
+ */
+export class AvailablePackageDetail extends Message<AvailablePackageDetail> {
+  /**
+   * Available package reference
+   *
+   * A reference uniquely identifying the package.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.AvailablePackageReference available_package_ref = 1;
+   */
+  availablePackageRef?: AvailablePackageReference;
+
+  /**
+   * Available package name
+   *
+   * The name of the available package
+   *
+   * @generated from field: string name = 2;
+   */
+  name = "";
+
+  /**
+   * Available version
+   *
+   * The version of the package and application.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.PackageAppVersion version = 3;
+   */
+  version?: PackageAppVersion;
+
+  /**
+   * the url of the package repository that contains this package
+   *
+   * @generated from field: string repo_url = 4;
+   */
+  repoUrl = "";
+
+  /**
+   * the url of the "home" for the package
+   *
+   * @generated from field: string home_url = 5;
+   */
+  homeUrl = "";
+
+  /**
+   * Available package icon URL
+   *
+   * A url for an icon.
+   *
+   * @generated from field: string icon_url = 6;
+   */
+  iconUrl = "";
+
+  /**
+   * Available package display name
+   *
+   * A name as displayed to users
+   *
+   * @generated from field: string display_name = 7;
+   */
+  displayName = "";
+
+  /**
+   * Available package short description
+   *
+   * A short description of the app provided by the package
+   *
+   * @generated from field: string short_description = 8;
+   */
+  shortDescription = "";
+
+  /**
+   * Available package long description
+   *
+   * A longer description of the package, a few sentences.
+   *
+   * @generated from field: string long_description = 9;
+   */
+  longDescription = "";
+
+  /**
+   * Available package readme
+   *
+   * A longer README with potentially pages of formatted Markdown.
+   *
+   * @generated from field: string readme = 10;
+   */
+  readme = "";
+
+  /**
+   * Available package default values
+   *
+   * An example of default values used during package templating that can serve
+   * as documentation or a starting point for user customization.
+   *
+   * @generated from field: string default_values = 11;
+   */
+  defaultValues = "";
+
+  /**
+   * Available package additional default values
+   *
+   * A package may contain additional default value files for specific scenarios,
+   * such as values_production.yaml or values_dev.yaml
+   *
+   * @generated from field: map<string, string> additional_default_values = 17;
+   */
+  additionalDefaultValues: { [key: string]: string } = {};
+
+  /**
+   * @generated from field: string values_schema = 12;
+   */
+  valuesSchema = "";
+
+  /**
+   * source urls for the package
+   *
+   * @generated from field: repeated string source_urls = 13;
+   */
+  sourceUrls: string[] = [];
+
+  /**
+   * Available package maintainers
+   *
+   * List of Maintainer
+   *
+   * @generated from field: repeated kubeappsapis.core.packages.v1.Maintainer maintainers = 14;
+   */
+  maintainers: Maintainer[] = [];
+
+  /**
+   * Available package categories
+   *
+   * A user-facing list of category names useful for creating richer user interfaces.
+   * Plugins can choose not to implement this
+   *
+   * @generated from field: repeated string categories = 15;
+   */
+  categories: string[] = [];
+
+  /**
+   * Custom data added by the plugin
+   *
+   * A plugin can define custom details for data which is not yet, or never will
+   * be specified in the core.packaging.CreateInstalledPackageRequest fields. The use
+   * of an `Any` field means that each plugin can define the structure of this
+   * message as required, while still satisfying the core interface.
+   * See https://developers.google.com/protocol-buffers/docs/proto3#any
+   *
+   * @generated from field: google.protobuf.Any custom_detail = 16;
+   */
+  customDetail?: Any;
+
+  /**
+   * Truncated text assets
+   *
+   * The text assets (e.g. the readme) which were too big and have been truncated
+   * in this response. Their whole content can be retrieved, in ranges, with
+   * GetAvailablePackageTextAsset.
+   *
+   * @generated from field: repeated kubeappsapis.core.packages.v1.TruncatedTextAsset truncated_text_assets = 18;
+   */
+  truncatedTextAssets: TruncatedTextAsset[] = [];
+
+  /**
+   * Available package icon
+   *
+   * The icon of the package normalized by the core, with a thumbnail and a
+   * full-size variant. Unset when the package has no icon, or when its icon is
+   * not a supported image or is too big.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.PackageIcon icon = 19;
+   */
+  icon?: PackageIcon;
+
+  constructor(data?: PartialMessage<AvailablePackageDetail>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.AvailablePackageDetail";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "available_package_ref", kind: "message", T: AvailablePackageReference },
+    { no: 2, name: "name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "version", kind: "message", T: PackageAppVersion },
+    { no: 4, name: "repo_url", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "home_url", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 6, name: "icon_url", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 7, name: "display_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 8, name: "short_description", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 9, name: "long_description", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 10, name: "readme", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 11, name: "default_values", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    {
+      no: 17,
+      name: "additional_default_values",
+      kind: "map",
+      K: 9 /* ScalarType.STRING */,
+      V: { kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    },
+    { no: 12, name: "values_schema", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 13, name: "source_urls", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 14, name: "maintainers", kind: "message", T: Maintainer, repeated: true },
+    { no: 15, name: "categories", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 16, name: "custom_detail", kind: "message", T: Any },
+    {
+      no: 18,
+      name: "truncated_text_assets",
+      kind: "message",
+      T: TruncatedTextAsset,
+      repeated: true,
+    },
+    { no: 19, name: "icon", kind: "message", T: PackageIcon },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): AvailablePackageDetail {
+    return new AvailablePackageDetail().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): AvailablePackageDetail {
+    return new AvailablePackageDetail().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): AvailablePackageDetail {
+    return new AvailablePackageDetail().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: AvailablePackageDetail | PlainMessage<AvailablePackageDetail> | undefined,
+    b: AvailablePackageDetail | PlainMessage<AvailablePackageDetail> | undefined,
+  ): boolean {
+    return proto3.util.equals(AvailablePackageDetail, a, b);
+  }
+}
+
+/**
+ * InstalledPackageSummary
+ *
+ * An InstalledPackageSummary provides a summary of an installed package
+ * useful when aggregating many installed packages.
+ *
+ * TODO: add example for API docs
+ * option (grpc.gateway.protoc_gen_openapiv2.options.openapiv2_schema) = {
+ *   example: '{}'
+ * };
+ *
+ * @generated from message kubeappsapis.core.packages.v1.InstalledPackageSummary
+ */
+export class InstalledPackageSummary extends Message<InstalledPackageSummary> {
+  /**
+   * InstalledPackageReference
+   *
+   * A reference uniquely identifying the package.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.InstalledPackageReference installed_package_ref = 1;
+   */
+  installedPackageRef?: InstalledPackageReference;
+
+  /**
+   * Name
+   *
+   * A name given to the installation of the package (eg. "my-postgresql-for-testing").
+   *
+   * @generated from field: string name = 2;
+   */
+  name = "";
+
+  /**
+   * PkgVersionReference
+   *
+   * The package version reference defines a version or constraint limiting
+   * matching package versions.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.VersionReference pkg_version_reference = 3;
+   */
+  pkgVersionReference?: VersionReference;
+
+  /**
+   * CurrentVersion
+   *
+   * The current version of the package being reconciled, which may be
+   * in one of these states:
+   *  - has been successfully installed/upgraded or
+   *  - is currently being installed/upgraded or
+   *  - has failed to install/upgrade
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.PackageAppVersion current_version = 4;
+   */
+  currentVersion?: PackageAppVersion;
+
+  /**
+   * Installed package icon URL
+   *
+   * A url for an icon.
+   *
+   * @generated from field: string icon_url = 5;
+   */
+  iconUrl = "";
+
+  /**
+   * PackageDisplayName
+   *
+   * The package name as displayed to users (provided by the package, eg. "PostgreSQL")
+   *
+   * @generated from field: string pkg_display_name = 6;
+   */
+  pkgDisplayName = "";
+
+  /**
+   * ShortDescription
+   *
+   * A short description of the package (provided by the package)
+   *
+   * @generated from field: string short_description = 7;
+   */
+  shortDescription = "";
+
+  /**
+   * LatestMatchingVersion
+   *
+   * Only non-empty if an available upgrade matches the specified pkg_version_reference.
+   * For example, if the pkg_version_reference is ">10.3.0 < 10.4.0" and 10.3.1
+   * is installed, then:
+   *   * if 10.3.2 is available, latest_matching_version should be 10.3.2, but
+   *   * if 10.4 is available while >10.3.1 is not, this should remain empty.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.PackageAppVersion latest_matching_version = 8;
+   */
+  latestMatchingVersion?: PackageAppVersion;
+
+  /**
+   * LatestVersion
+   *
+   * The latest version available for this package, regardless of the pkg_version_reference.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.PackageAppVersion latest_version = 9;
+   */
+  latestVersion?: PackageAppVersion;
+
+  /**
+   * Status
+   *
+   * The current status of the installed package.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.InstalledPackageStatus status = 10;
+   */
+  status?: InstalledPackageStatus;
+
+  /**
+   * Warnings
+   *
+   * Warnings about the installed package, such as the currently installed
+   * version being deprecated or having reached its end of life.
+   *
+   * @generated from field: repeated string warnings = 11;
+   */
+  warnings: string[] = [];
+
+  constructor(data?: PartialMessage<InstalledPackageSummary>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.InstalledPackageSummary";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "installed_package_ref", kind: "message", T: InstalledPackageReference },
+    { no: 2, name: "name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "pkg_version_reference", kind: "message", T: VersionReference },
+    { no: 4, name: "current_version", kind: "message", T: PackageAppVersion },
+    { no: 5, name: "icon_url", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 6, name: "pkg_display_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 7, name: "short_description", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 8, name: "latest_matching_version", kind: "message", T: PackageAppVersion },
+    { no: 9, name: "latest_version", kind: "message", T: PackageAppVersion },
+    { no: 10, name: "status", kind: "message", T: InstalledPackageStatus },
+    { no: 11, name: "warnings", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): InstalledPackageSummary {
+    return new InstalledPackageSummary().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): InstalledPackageSummary {
+    return new InstalledPackageSummary().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): InstalledPackageSummary {
+    return new InstalledPackageSummary().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: InstalledPackageSummary | PlainMessage<InstalledPackageSummary> | undefined,
+    b: InstalledPackageSummary | PlainMessage<InstalledPackageSummary> | undefined,
+  ): boolean {
+    return proto3.util.equals(InstalledPackageSummary, a, b);
+  }
+}
+
+/**
+ * InstalledPackageDetail
+ *
+ * An InstalledPackageDetail includes details about the installed package that are
+ * typically useful when presenting a single installed package.
+ *
+ * @generated from message kubeappsapis.core.packages.v1.InstalledPackageDetail
+ */
+export class InstalledPackageDetail extends Message<InstalledPackageDetail> {
+  /**
+   * InstalledPackageReference
+   *
+   * A reference uniquely identifying the installed package.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.InstalledPackageReference installed_package_ref = 1;
+   */
+  installedPackageRef?: InstalledPackageReference;
+
+  /**
+   * PkgVersionReference
+   *
+   * The package version reference defines a version or constraint limiting
+   * matching package versions.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.VersionReference pkg_version_reference = 2;
+   */
+  pkgVersionReference?: VersionReference;
+
+  /**
+   * Installed package name
+   *
+   * The name given to the installed package
+   *
+   * @generated from field: string name = 3;
+   */
+  name = "";
+
+  /**
+   * CurrentVersion
+   *
+   * The version of the package which is currently installed.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.PackageAppVersion current_version = 4;
+   */
+  currentVersion?: PackageAppVersion;
+
+  /**
+   * ValuesApplied
+   *
+   * The values applied currently for the installed package.
+   *
+   * @generated from field: string values_applied = 5;
+   */
+  valuesApplied = "";
+
+  /**
+   * ReconciliationOptions
+   *
+   * An optional field specifying data common to systems that reconcile
+   * the package installation on the cluster asynchronously. In particular,
+   * this specifies the service account used to perform the reconcilliation.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.ReconciliationOptions reconciliation_options = 6;
+   */
+  reconciliationOptions?: ReconciliationOptions;
+
+  /**
+   * Status
+   *
+   * The current status of the installed package.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.InstalledPackageStatus status = 7;
+   */
+  status?: InstalledPackageStatus;
+
+  /**
+   * PostInstallationNotes
+   *
+   * Optional notes generated by package and intended for the user post installation.
+   *
+   * @generated from field: string post_installation_notes = 8;
+   */
+  postInstallationNotes = "";
+
+  /**
+   * Available package reference
+   *
+   * A reference to the available package for this installation.
+   * Useful to lookup the package display name, icon and other info.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.AvailablePackageReference available_package_ref = 9;
+   */
+  availablePackageRef?: AvailablePackageReference;
+
+  /**
+   * LatestMatchingVersion
+   *
+   * Only non-empty if an available upgrade matches the specified pkg_version_reference.
+   * For example, if the pkg_version_reference is ">10.3.0 < 10.4.0" and 10.3.1
+   * is installed, then:
+   *   * if 10.3.2 is available, latest_matching_version should be 10.3.2, but
+   *   * if 10.4 is available while >10.3.1 is not, this should remain empty.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.PackageAppVersion latest_matching_version = 10;
+   */
+  latestMatchingVersion?: PackageAppVersion;
+
+  /**
+   * LatestVersion
+   *
+   * The latest version available for this package, regardless of the pkg_version_reference.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.PackageAppVersion latest_version = 11;
+   */
+  latestVersion?: PackageAppVersion;
+
+  /**
+   * Custom data added by the plugin
+   *
+   * A plugin can define custom details for data which is not yet, or never will
+   * be specified in the core.packaging.CreateInstalledPackageRequest fields. The use
+   * of an `Any` field means that each plugin can define the structure of this
+   * message as required, while still satisfying the core interface.
+   * See https://developers.google.com/protocol-buffers/docs/proto3#any
+   *
+   * @generated from field: google.protobuf.Any custom_detail = 14;
+   */
+  customDetail?: Any;
+
+  /**
+   * Metadata
+   *
+   * The labels and annotations of the installed package, other than the
+   * protected ones, if any.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.InstalledPackageMetadata metadata = 15;
+   */
+  metadata?: InstalledPackageMetadata;
+
+  constructor(data?: PartialMessage<InstalledPackageDetail>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.InstalledPackageDetail";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "installed_package_ref", kind: "message", T: InstalledPackageReference },
+    { no: 2, name: "pkg_version_reference", kind: "message", T: VersionReference },
+    { no: 3, name: "name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "current_version", kind: "message", T: PackageAppVersion },
+    { no: 5, name: "values_applied", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 6, name: "reconciliation_options", kind: "message", T: ReconciliationOptions },
+    { no: 7, name: "status", kind: "message", T: InstalledPackageStatus },
+    { no: 8, name: "post_installation_notes", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 9, name: "available_package_ref", kind: "message", T: AvailablePackageReference },
+    { no: 10, name: "latest_matching_version", kind: "message", T: PackageAppVersion },
+    { no: 11, name: "latest_version", kind: "message", T: PackageAppVersion },
+    { no: 14, name: "custom_detail", kind: "message", T: Any },
+    { no: 15, name: "metadata", kind: "message", T: InstalledPackageMetadata },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): InstalledPackageDetail {
+    return new InstalledPackageDetail().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): InstalledPackageDetail {
+    return new InstalledPackageDetail().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): InstalledPackageDetail {
+    return new InstalledPackageDetail().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: InstalledPackageDetail | PlainMessage<InstalledPackageDetail> | undefined,
+    b: InstalledPackageDetail | PlainMessage<InstalledPackageDetail> | undefined,
+  ): boolean {
+    return proto3.util.equals(InstalledPackageDetail, a, b);
+  }
+}
+
+/**
+ * BulkInstalledPackageResult
+ *
+ * The result of the operation on one of the installed packages of a bulk
+ * operation.
+ *
+ * @generated from message kubeappsapis.core.packages.v1.BulkInstalledPackageResult
+ */
+export class BulkInstalledPackageResult extends Message<BulkInstalledPackageResult> {
+  /**
+   * The reference of the installed package the operation was performed on.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.InstalledPackageReference installed_package_ref = 1;
+   */
+  installedPackageRef?: InstalledPackageReference;
+
+  /**
+   * The code of the error of the operation, such as "not_found" or
+   * "permission_denied", or empty when it succeeded.
+   *
+   * @generated from field: string error_code = 2;
+   */
+  errorCode = "";
+
+  /**
+   * The message of the error of the operation, or empty when it succeeded.
+   *
+   * @generated from field: string error_message = 3;
+   */
+  errorMessage = "";
+
+  constructor(data?: PartialMessage<BulkInstalledPackageResult>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.BulkInstalledPackageResult";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "installed_package_ref", kind: "message", T: InstalledPackageReference },
+    { no: 2, name: "error_code", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "error_message", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): BulkInstalledPackageResult {
+    return new BulkInstalledPackageResult().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): BulkInstalledPackageResult {
+    return new BulkInstalledPackageResult().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): BulkInstalledPackageResult {
+    return new BulkInstalledPackageResult().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: BulkInstalledPackageResult | PlainMessage<BulkInstalledPackageResult> | undefined,
+    b: BulkInstalledPackageResult | PlainMessage<BulkInstalledPackageResult> | undefined,
+  ): boolean {
+    return proto3.util.equals(BulkInstalledPackageResult, a, b);
+  }
+}
+
+/**
+ * Context
+ *
+ * A Context specifies the context of the message
+ *
+ * TODO: add example for API docs
+ * option (grpc.gateway.protoc_gen_openapiv2.options.openapiv2_schema) = {
+ *   example: '{}'
+ * };
+ *
+ * @generated from message kubeappsapis.core.packages.v1.Context
+ */
+export class Context extends Message<Context> {
+  /**
+   * Cluster
+   *
+   * A cluster name can be provided to target a specific cluster if multiple
+   * clusters are configured, otherwise all clusters will be assumed.
+   *
+   * @generated from field: string cluster = 1;
+   */
+  cluster = "";
+
+  /**
+   * Namespace
+   *
+   * A namespace must be provided if the context of the operation is for a resource
+   * or resources in a particular namespace.
+   * For requests to list items, not including a namespace here implies that the context
+   * for the request is everything the requesting user can read, though the result can
+   * be filtered by any filtering options of the request. Plugins may choose to return
+   * Unimplemented for some queries for which we do not yet have a need.
+   *
+   * @generated from field: string namespace = 2;
+   */
+  namespace = "";
+
+  constructor(data?: PartialMessage<Context>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.Context";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "cluster", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "namespace", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): Context {
+    return new Context().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): Context {
+    return new Context().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): Context {
+    return new Context().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: Context | PlainMessage<Context> | undefined,
+    b: Context | PlainMessage<Context> | undefined,
+  ): boolean {
+    return proto3.util.equals(Context, a, b);
+  }
+}
+
+/**
+ * AvailablePackageReference
+ *
+ * An AvailablePackageReference has the minimum information required to uniquely
+ * identify an available package. This is re-used on the summary and details of an
+ * available package.
+ *
+ * TODO: add example for API docs
+ * option (grpc.gateway.protoc_gen_openapiv2.options.openapiv2_schema) = {
+ *   example: '{}'
+ * };
+ *
+ * @generated from message kubeappsapis.core.packages.v1.AvailablePackageReference
+ */
+export class AvailablePackageReference extends Message<AvailablePackageReference> {
+  /**
+   * Available package context
+   *
+   * The context (cluster/namespace) for the package.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.Context context = 1;
+   */
+  context?: Context;
+
+  /**
+   * Available package identifier
+   *
+   * The fully qualified identifier for the available package
+   * (ie. a unique name for the context). For some packaging systems
+   * (particularly those where an available package is backed by a CR) this
+   * will just be the name, but for others such as those where an available
+   * package is not backed by a CR (eg. standard helm) it may be necessary
+   * to include the repository in the name or even the repo namespace
+   * to ensure this is unique.
+   * For example two helm repositories can define
+   * an "apache" chart that is available globally, the names would need to
+   * encode that to be unique (ie. "repoA:apache" and "repoB:apache").
+   *
+   * @generated from field: string identifier = 2;
+   */
+  identifier = "";
+
+  /**
+   * Plugin for the available package
+   *
+   * The plugin used to interact with this available package.
+   * This field should be omitted when the request is in the context of a specific plugin.
+   *
+   * @generated from field: kubeappsapis.core.plugins.v1alpha1.Plugin plugin = 3;
+   */
+  plugin?: Plugin;
+
+  constructor(data?: PartialMessage<AvailablePackageReference>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.AvailablePackageReference";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "context", kind: "message", T: Context },
+    { no: 2, name: "identifier", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "plugin", kind: "message", T: Plugin },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): AvailablePackageReference {
+    return new AvailablePackageReference().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): AvailablePackageReference {
+    return new AvailablePackageReference().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): AvailablePackageReference {
+    return new AvailablePackageReference().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: AvailablePackageReference | PlainMessage<AvailablePackageReference> | undefined,
+    b: AvailablePackageReference | PlainMessage<AvailablePackageReference> | undefined,
+  ): boolean {
+    return proto3.util.equals(AvailablePackageReference, a, b);
+  }
+}
+
+/**
+ * Maintainer
+ *
+ * Maintainers for the package.
+ *
+ * TODO: add example for API docs
+ * option (grpc.gateway.protoc_gen_openapiv2.options.openapiv2_schema) = {
+ *   example: '{}'
+ * };
+ *
+ * @generated from message kubeappsapis.core.packages.v1.Maintainer
+ */
+export class Maintainer extends Message<Maintainer> {
+  /**
+   * Maintainer name
+   *
+   * A maintainer name
+   *
+   * @generated from field: string name = 1;
+   */
+  name = "";
+
+  /**
+   * Maintainer email
+   *
+   * A maintainer email
+   *
+   * @generated from field: string email = 2;
+   */
+  email = "";
+
+  constructor(data?: PartialMessage<Maintainer>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.Maintainer";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "email", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): Maintainer {
+    return new Maintainer().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): Maintainer {
+    return new Maintainer().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): Maintainer {
+    return new Maintainer().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: Maintainer | PlainMessage<Maintainer> | undefined,
+    b: Maintainer | PlainMessage<Maintainer> | undefined,
+  ): boolean {
+    return proto3.util.equals(Maintainer, a, b);
+  }
+}
+
+/**
+ * FilterOptions
+ *
+ * FilterOptions available when requesting summaries
+ *
+ * TODO: add example for API docs
+ * option (grpc.gateway.protoc_gen_openapiv2.options.openapiv2_schema) = {
+ *   example: '{}'
+ * };
+ *
+ * @generated from message kubeappsapis.core.packages.v1.FilterOptions
+ */
+export class FilterOptions extends Message<FilterOptions> {
+  /**
+   * Text query
+   *
+   * Text query for the request
+   *
+   * @generated from field: string query = 1;
+   */
+  query = "";
+
+  /**
+   * Categories
+   *
+   * Collection of categories for the request
+   *
+   * @generated from field: repeated string categories = 2;
+   */
+  categories: string[] = [];
+
+  /**
+   * Repositories
+   *
+   * Collection of repositories where the packages belong to
+   *
+   * @generated from field: repeated string repositories = 3;
+   */
+  repositories: string[] = [];
+
+  /**
+   * Package version
+   *
+   * Package version for the request
+   *
+   * @generated from field: string pkg_version = 4;
+   */
+  pkgVersion = "";
+
+  /**
+   * App version
+   *
+   * Packaged app version for the request
+   *
+   * @generated from field: string app_version = 5;
+   */
+  appVersion = "";
+
+  /**
+   * Providers
+   *
+   * Collection of provider names where the packages belong to. Packages
+   * without a provider do not match when set.
+   *
+   * @generated from field: repeated string providers = 6;
+   */
+  providers: string[] = [];
+
+  /**
+   * Exclude global packages
+   *
+   * Whether to return only the packages local to the requested namespace,
+   * excluding the ones published to every namespace, such as the packages
+   * of the kapp-controller global packaging namespace.
+   *
+   * @generated from field: bool exclude_global_packages = 7;
+   */
+  excludeGlobalPackages = false;
+
+  constructor(data?: PartialMessage<FilterOptions>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.FilterOptions";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "query", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "categories", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 3, name: "repositories", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 4, name: "pkg_version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "app_version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 6, name: "providers", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 7, name: "exclude_global_packages", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): FilterOptions {
+    return new FilterOptions().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): FilterOptions {
+    return new FilterOptions().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): FilterOptions {
+    return new FilterOptions().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: FilterOptions | PlainMessage<FilterOptions> | undefined,
+    b: FilterOptions | PlainMessage<FilterOptions> | undefined,
+  ): boolean {
+    return proto3.util.equals(FilterOptions, a, b);
+  }
+}
+
+/**
+ * InstalledPackageFilterOptions
+ *
+ * InstalledPackageFilterOptions available when requesting installed package
+ * summaries. Only the installed packages matching all the options set are
+ * returned.
+ *
+ * @generated from message kubeappsapis.core.packages.v1.InstalledPackageFilterOptions
+ */
+export class InstalledPackageFilterOptions extends Message<InstalledPackageFilterOptions> {
+  /**
+   * Label selector
+   *
+   * A Kubernetes label selector, such as "team=frontend,tier!=cache", matched
+   * against the labels of the resource representing the installed package.
+   *
+   * @generated from field: string label_selector = 1;
+   */
+  labelSelector = "";
+
+  /**
+   * Status reasons
+   *
+   * Collection of status reasons, only the installed packages with one of
+   * these reasons being returned when set.
+   *
+   * @generated from field: repeated kubeappsapis.core.packages.v1.InstalledPackageStatus.StatusReason status_reasons = 2;
+   */
+  statusReasons: InstalledPackageStatus_StatusReason[] = [];
+
+  /**
+   * Only upgradable
+   *
+   * Only return the installed packages for which a newer version matching
+   * their version constraints is available.
+   *
+   * @generated from field: bool only_upgradable = 3;
+   */
+  onlyUpgradable = false;
+
+  constructor(data?: PartialMessage<InstalledPackageFilterOptions>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.InstalledPackageFilterOptions";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "label_selector", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    {
+      no: 2,
+      name: "status_reasons",
+      kind: "enum",
+      T: proto3.getEnumType(InstalledPackageStatus_StatusReason),
+      repeated: true,
+    },
+    { no: 3, name: "only_upgradable", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): InstalledPackageFilterOptions {
+    return new InstalledPackageFilterOptions().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): InstalledPackageFilterOptions {
+    return new InstalledPackageFilterOptions().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): InstalledPackageFilterOptions {
+    return new InstalledPackageFilterOptions().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: InstalledPackageFilterOptions | PlainMessage<InstalledPackageFilterOptions> | undefined,
+    b: InstalledPackageFilterOptions | PlainMessage<InstalledPackageFilterOptions> | undefined,
+  ): boolean {
+    return proto3.util.equals(InstalledPackageFilterOptions, a, b);
+  }
+}
+
+/**
+ * PaginationOptions
+ *
+ * The PaginationOptions based on the example proto at:
+ * https://cloud.google.com/apis/design/design_patterns#list_pagination
+ * just encapsulated in a message so it can be reused on different request messages.
+ *
+ * TODO: add example for API docs
+ * option (grpc.gateway.protoc_gen_openapiv2.options.openapiv2_schema) = {
+ *   example: '{}'
+ * };
+ *
+ * @generated from message kubeappsapis.core.packages.v1.PaginationOptions
+ */
+export class PaginationOptions extends Message<PaginationOptions> {
+  /**
+   * Page token
+   *
+   * The client uses this field to request a specific page of the list results.
+   *
+   * @generated from field: string page_token = 1;
+   */
+  pageToken = "";
+
+  /**
+   * Page size
+   *
+   * Clients use this field to specify the maximum number of results to be
+   * returned by the server. The server may further constrain the maximum number
+   * of results returned in a single page. If the page_size is 0, the server
+   * will decide the number of results to be returned.
+   *
+   * @generated from field: int32 page_size = 2;
+   */
+  pageSize = 0;
+
+  constructor(data?: PartialMessage<PaginationOptions>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.PaginationOptions";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "page_token", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "page_size", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): PaginationOptions {
+    return new PaginationOptions().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): PaginationOptions {
+    return new PaginationOptions().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): PaginationOptions {
+    return new PaginationOptions().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: PaginationOptions | PlainMessage<PaginationOptions> | undefined,
+    b: PaginationOptions | PlainMessage<PaginationOptions> | undefined,
+  ): boolean {
+    return proto3.util.equals(PaginationOptions, a, b);
+  }
+}
+
+/**
+ * InstalledPackageReference
+ *
+ * An InstalledPackageReference has the minimum information required to uniquely
+ * identify an installed package.
+ *
+ * TODO: add example for API docs
+ * option (grpc.gateway.protoc_gen_openapiv2.options.openapiv2_schema) = {
+ *   example: '{}'
+ * };
+ *
+ * @generated from message kubeappsapis.core.packages.v1.InstalledPackageReference
+ */
+export class InstalledPackageReference extends Message<InstalledPackageReference> {
+  /**
+   * Installed package context
+   *
+   * The context (cluster/namespace) for the package.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.Context context = 1;
+   */
+  context?: Context;
+
+  /**
+   * The fully qualified identifier for the installed package
+   * (ie. a unique name for the context).
+   *
+   * @generated from field: string identifier = 2;
+   */
+  identifier = "";
+
+  /**
+   * The plugin used to identify and interact with the installed package.
+   * This field can be omitted when the request is in the context of a specific plugin.
+   *
+   * @generated from field: kubeappsapis.core.plugins.v1alpha1.Plugin plugin = 3;
+   */
+  plugin?: Plugin;
+
+  constructor(data?: PartialMessage<InstalledPackageReference>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.InstalledPackageReference";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "context", kind: "message", T: Context },
+    { no: 2, name: "identifier", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "plugin", kind: "message", T: Plugin },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): InstalledPackageReference {
+    return new InstalledPackageReference().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): InstalledPackageReference {
+    return new InstalledPackageReference().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): InstalledPackageReference {
+    return new InstalledPackageReference().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: InstalledPackageReference | PlainMessage<InstalledPackageReference> | undefined,
+    b: InstalledPackageReference | PlainMessage<InstalledPackageReference> | undefined,
+  ): boolean {
+    return proto3.util.equals(InstalledPackageReference, a, b);
+  }
+}
+
+/**
+ * VersionReference
+ *
+ * A VersionReference defines a version or constraint limiting matching versions.
+ * The reason it is a separate message is so that in the future we can add other
+ * fields as necessary (such as something similar to Carvel's `prereleases` option
+ * to its versionSelection).
+ *
+ * @generated from message kubeappsapis.core.packages.v1.VersionReference
+ */
+export class VersionReference extends Message<VersionReference> {
+  /**
+   * Version
+   *
+   * Either an exact version, such as "1.2.3", or a semver range expression,
+   * such as ">=1.2 <2" or "~1.2.3", validated the same way for every plugin.
+   * The plugins installing a single version, such as Helm, install the highest
+   * available version satisfying a range, while the ones whose controllers
+   * follow a range, such as flux v2 and Carvel, keep the package at the highest
+   * version satisfying it.
+   *
+   * @generated from field: string version = 1;
+   */
+  version = "";
+
+  constructor(data?: PartialMessage<VersionReference>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.VersionReference";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): VersionReference {
+    return new VersionReference().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): VersionReference {
+    return new VersionReference().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): VersionReference {
+    return new VersionReference().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: VersionReference | PlainMessage<VersionReference> | undefined,
+    b: VersionReference | PlainMessage<VersionReference> | undefined,
+  ): boolean {
+    return proto3.util.equals(VersionReference, a, b);
+  }
+}
+
+/**
+ * InstalledPackageStatus
+ *
+ * An InstalledPackageStatus reports on the current status of the installation.
+ *
+ * @generated from message kubeappsapis.core.packages.v1.InstalledPackageStatus
+ */
+export class InstalledPackageStatus extends Message<InstalledPackageStatus> {
+  /**
+   * Ready
+   *
+   * An indication of whether the installation is ready or not
+   *
+   * @generated from field: bool ready = 1;
+   */
+  ready = false;
+
+  /**
+   * Reason
+   *
+   * An enum indicating the reason for the current status.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.InstalledPackageStatus.StatusReason reason = 2;
+   */
+  reason = InstalledPackageStatus_StatusReason.UNSPECIFIED;
+
+  /**
+   * UserReason
+   *
+   * Optional text to return for user context, which may be plugin specific.
+   *
+   * @generated from field: string user_reason = 3;
+   */
+  userReason = "";
+
+  /**
+   * LastFetchTime
+   *
+   * Optional time (RFC3339) at which the package was last fetched by a plugin
+   * continuously reconciling it.
+   *
+   * @generated from field: string last_fetch_time = 4;
+   */
+  lastFetchTime = "";
+
+  /**
+   * LastDeployTime
+   *
+   * Optional time (RFC3339) at which the last deployment of the package
+   * finished, for a plugin continuously reconciling it.
+   *
+   * @generated from field: string last_deploy_time = 5;
+   */
+  lastDeployTime = "";
+
+  /**
+   * SyncInterval
+   *
+   * Optional interval (in time+unit) with which the package is reconciled.
+   *
+   * @generated from field: string sync_interval = 6;
+   */
+  syncInterval = "";
+
+  /**
+   * Stale
+   *
+   * Whether the last successful reconciliation of the package is older than
+   * twice its sync interval, which usually means the reconciler is not
+   * keeping up with it.
+   *
+   * @generated from field: bool stale = 7;
+   */
+  stale = false;
+
+  constructor(data?: PartialMessage<InstalledPackageStatus>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.InstalledPackageStatus";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "ready", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    {
+      no: 2,
+      name: "reason",
+      kind: "enum",
+      T: proto3.getEnumType(InstalledPackageStatus_StatusReason),
+    },
+    { no: 3, name: "user_reason", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "last_fetch_time", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "last_deploy_time", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 6, name: "sync_interval", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 7, name: "stale", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): InstalledPackageStatus {
+    return new InstalledPackageStatus().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): InstalledPackageStatus {
+    return new InstalledPackageStatus().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): InstalledPackageStatus {
+    return new InstalledPackageStatus().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: InstalledPackageStatus | PlainMessage<InstalledPackageStatus> | undefined,
+    b: InstalledPackageStatus | PlainMessage<InstalledPackageStatus> | undefined,
+  ): boolean {
+    return proto3.util.equals(InstalledPackageStatus, a, b);
+  }
+}
+
+/**
+ * StatusReason
+ *
+ * Generic reasons why an installed package may be ready or not.
+ * These should make sense across different packaging plugins.
+ *
+ * @generated from enum kubeappsapis.core.packages.v1.InstalledPackageStatus.StatusReason
+ */
+export enum InstalledPackageStatus_StatusReason {
+  /**
+   * @generated from enum value: STATUS_REASON_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * @generated from enum value: STATUS_REASON_INSTALLED = 1;
+   */
+  INSTALLED = 1,
+
+  /**
+   * @generated from enum value: STATUS_REASON_UNINSTALLED = 2;
+   */
+  UNINSTALLED = 2,
+
+  /**
+   * @generated from enum value: STATUS_REASON_FAILED = 3;
+   */
+  FAILED = 3,
+
+  /**
+   * @generated from enum value: STATUS_REASON_PENDING = 4;
+   */
+  PENDING = 4,
+}
+// Retrieve enum metadata with: proto3.getEnumType(InstalledPackageStatus_StatusReason)
+proto3.util.setEnumType(
+  InstalledPackageStatus_StatusReason,
+  "kubeappsapis.core.packages.v1.InstalledPackageStatus.StatusReason",
+  [
+    { no: 0, name: "STATUS_REASON_UNSPECIFIED" },
+    { no: 1, name: "STATUS_REASON_INSTALLED" },
+    { no: 2, name: "STATUS_REASON_UNINSTALLED" },
+    { no: 3, name: "STATUS_REASON_FAILED" },
+    { no: 4, name: "STATUS_REASON_PENDING" },
+  ],
+);
+
+/**
+ * InstalledPackageOperationPhase
+ *
+ * A phase of an operation on an installed package, such as the fetch, template
+ * or deploy steps of a kapp-controller App.
+ *
+ * @generated from message kubeappsapis.core.packages.v1.InstalledPackageOperationPhase
+ */
+export class InstalledPackageOperationPhase extends Message<InstalledPackageOperationPhase> {
+  /**
+   * Name
+   *
+   * The name of the phase, which is plugin specific.
+   *
+   * @generated from field: string name = 1;
+   */
+  name = "";
+
+  /**
+   * State
+   *
+   * The current state of the phase.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.InstalledPackageOperationPhase.PhaseState state = 2;
+   */
+  state = InstalledPackageOperationPhase_PhaseState.UNSPECIFIED;
+
+  /**
+   * Message
+   *
+   * Optional text giving user context about the state, such as the error
+   * when the phase failed.
+   *
+   * @generated from field: string message = 3;
+   */
+  message = "";
+
+  constructor(data?: PartialMessage<InstalledPackageOperationPhase>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.InstalledPackageOperationPhase";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    {
+      no: 2,
+      name: "state",
+      kind: "enum",
+      T: proto3.getEnumType(InstalledPackageOperationPhase_PhaseState),
+    },
+    { no: 3, name: "message", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): InstalledPackageOperationPhase {
+    return new InstalledPackageOperationPhase().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): InstalledPackageOperationPhase {
+    return new InstalledPackageOperationPhase().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): InstalledPackageOperationPhase {
+    return new InstalledPackageOperationPhase().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | InstalledPackageOperationPhase
+      | PlainMessage<InstalledPackageOperationPhase>
+      | undefined,
+    b:
+      | InstalledPackageOperationPhase
+      | PlainMessage<InstalledPackageOperationPhase>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(InstalledPackageOperationPhase, a, b);
+  }
+}
+
+/**
+ * PhaseState
+ *
+ * The states a phase goes through.
+ *
+ * @generated from enum kubeappsapis.core.packages.v1.InstalledPackageOperationPhase.PhaseState
+ */
+export enum InstalledPackageOperationPhase_PhaseState {
+  /**
+   * @generated from enum value: PHASE_STATE_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * @generated from enum value: PHASE_STATE_PENDING = 1;
+   */
+  PENDING = 1,
+
+  /**
+   * @generated from enum value: PHASE_STATE_IN_PROGRESS = 2;
+   */
+  IN_PROGRESS = 2,
+
+  /**
+   * @generated from enum value: PHASE_STATE_SUCCEEDED = 3;
+   */
+  SUCCEEDED = 3,
+
+  /**
+   * @generated from enum value: PHASE_STATE_FAILED = 4;
+   */
+  FAILED = 4,
+}
+// Retrieve enum metadata with: proto3.getEnumType(InstalledPackageOperationPhase_PhaseState)
+proto3.util.setEnumType(
+  InstalledPackageOperationPhase_PhaseState,
+  "kubeappsapis.core.packages.v1.InstalledPackageOperationPhase.PhaseState",
+  [
+    { no: 0, name: "PHASE_STATE_UNSPECIFIED" },
+    { no: 1, name: "PHASE_STATE_PENDING" },
+    { no: 2, name: "PHASE_STATE_IN_PROGRESS" },
+    { no: 3, name: "PHASE_STATE_SUCCEEDED" },
+    { no: 4, name: "PHASE_STATE_FAILED" },
+  ],
+);
+
+/**
+ * ReconciliationOptions
+ *
+ * ReconciliationOptions enable specifying standard fields for backends that continuously
+ * reconcile a package install as new matching versions are released. Most of the naming
+ * is from the flux HelmReleaseSpec though it maps directly to equivalent fields on Carvel's
+ * InstalledPackage.
+ *
+ * @generated from message kubeappsapis.core.packages.v1.ReconciliationOptions
+ */
+export class ReconciliationOptions extends Message<ReconciliationOptions> {
+  /**
+   * Reconciliation Interval
+   *
+   * The interval with which the package is checked for reconciliation (in time+unit)
+   *
+   * @generated from field: string interval = 1;
+   */
+  interval = "";
+
+  /**
+   * Suspend
+   *
+   * Whether reconciliation should be suspended until otherwise enabled.
+   * This can be utilized to e.g. temporarily ignore chart changes, and
+   * prevent a Helm release from getting upgraded
+   *
+   * @generated from field: bool suspend = 2;
+   */
+  suspend = false;
+
+  /**
+   * ServiceAccountName
+   *
+   * A name for a service account in the same namespace which should be used
+   * to perform the reconciliation.
+   *
+   * @generated from field: string service_account_name = 3;
+   */
+  serviceAccountName = "";
+
+  constructor(data?: PartialMessage<ReconciliationOptions>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.ReconciliationOptions";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "interval", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "suspend", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 3, name: "service_account_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): ReconciliationOptions {
+    return new ReconciliationOptions().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ReconciliationOptions {
+    return new ReconciliationOptions().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): ReconciliationOptions {
+    return new ReconciliationOptions().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: ReconciliationOptions | PlainMessage<ReconciliationOptions> | undefined,
+    b: ReconciliationOptions | PlainMessage<ReconciliationOptions> | undefined,
+  ): boolean {
+    return proto3.util.equals(ReconciliationOptions, a, b);
+  }
+}
+
+/**
+ * InstalledPackageMetadata
+ *
+ * InstalledPackageMetadata holds the labels and annotations of the object
+ * created by a plugin to install a package, such as a kapp-controller
+ * PackageInstall or a Flux HelmRelease. The labels and annotations whose keys
+ * start with one of the prefixes protected by the plugin configuration cannot
+ * be set.
+ *
+ * @generated from message kubeappsapis.core.packages.v1.InstalledPackageMetadata
+ */
+export class InstalledPackageMetadata extends Message<InstalledPackageMetadata> {
+  /**
+   * Labels
+   *
+   * @generated from field: map<string, string> labels = 1;
+   */
+  labels: { [key: string]: string } = {};
+
+  /**
+   * Annotations
+   *
+   * @generated from field: map<string, string> annotations = 2;
+   */
+  annotations: { [key: string]: string } = {};
+
+  constructor(data?: PartialMessage<InstalledPackageMetadata>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.InstalledPackageMetadata";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    {
+      no: 1,
+      name: "labels",
+      kind: "map",
+      K: 9 /* ScalarType.STRING */,
+      V: { kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    },
+    {
+      no: 2,
+      name: "annotations",
+      kind: "map",
+      K: 9 /* ScalarType.STRING */,
+      V: { kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): InstalledPackageMetadata {
+    return new InstalledPackageMetadata().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): InstalledPackageMetadata {
+    return new InstalledPackageMetadata().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): InstalledPackageMetadata {
+    return new InstalledPackageMetadata().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: InstalledPackageMetadata | PlainMessage<InstalledPackageMetadata> | undefined,
+    b: InstalledPackageMetadata | PlainMessage<InstalledPackageMetadata> | undefined,
+  ): boolean {
+    return proto3.util.equals(InstalledPackageMetadata, a, b);
+  }
+}
+
+/**
+ * Package AppVersion
+ *
+ * PackageAppVersion conveys both the package version and the packaged app version.
+ *
+ * @generated from message kubeappsapis.core.packages.v1.PackageAppVersion
+ */
+export class PackageAppVersion extends Message<PackageAppVersion> {
+  /**
+   * Package version
+   *
+   * Version of the package itself
+   *
+   * @generated from field: string pkg_version = 1;
+   */
+  pkgVersion = "";
+
+  /**
+   * Application version
+   *
+   * Version of the packaged application
+   *
+   * @generated from field: string app_version = 2;
+   */
+  appVersion = "";
+
+  /**
+   * Upgrade impact
+   *
+   * The impact of upgrading to this version, only set when the versions are
+   * requested relative to another version.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.UpgradeImpact upgrade_impact = 3;
+   */
+  upgradeImpact?: UpgradeImpact;
+
+  /**
+   * Deprecation
+   *
+   * Only set when the version is deprecated by the publisher of the package.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.PackageVersionDeprecation deprecation = 4;
+   */
+  deprecation?: PackageVersionDeprecation;
+
+  constructor(data?: PartialMessage<PackageAppVersion>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.PackageAppVersion";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "pkg_version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "app_version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "upgrade_impact", kind: "message", T: UpgradeImpact },
+    { no: 4, name: "deprecation", kind: "message", T: PackageVersionDeprecation },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): PackageAppVersion {
+    return new PackageAppVersion().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): PackageAppVersion {
+    return new PackageAppVersion().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): PackageAppVersion {
+    return new PackageAppVersion().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: PackageAppVersion | PlainMessage<PackageAppVersion> | undefined,
+    b: PackageAppVersion | PlainMessage<PackageAppVersion> | undefined,
+  ): boolean {
+    return proto3.util.equals(PackageAppVersion, a, b);
+  }
+}
+
+/**
+ * Package version deprecation
+ *
+ * The deprecation of a package version, as declared by the publisher of the
+ * package.
+ *
+ * @generated from message kubeappsapis.core.packages.v1.PackageVersionDeprecation
+ */
+export class PackageVersionDeprecation extends Message<PackageVersionDeprecation> {
+  /**
+   * Message
+   *
+   * Why the version is deprecated, or which version to use instead, if given.
+   *
+   * @generated from field: string message = 1;
+   */
+  message = "";
+
+  /**
+   * End of life
+   *
+   * The date, in RFC 3339 format (eg. "2024-06-30"), from which the version is
+   * no longer supported, if known.
+   *
+   * @generated from field: string end_of_life = 2;
+   */
+  endOfLife = "";
+
+  /**
+   * End of life reached
+   *
+   * Whether the end of life of the version has already passed.
+   *
+   * @generated from field: bool end_of_life_reached = 3;
+   */
+  endOfLifeReached = false;
+
+  constructor(data?: PartialMessage<PackageVersionDeprecation>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.PackageVersionDeprecation";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "message", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "end_of_life", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "end_of_life_reached", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): PackageVersionDeprecation {
+    return new PackageVersionDeprecation().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): PackageVersionDeprecation {
+    return new PackageVersionDeprecation().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): PackageVersionDeprecation {
+    return new PackageVersionDeprecation().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: PackageVersionDeprecation | PlainMessage<PackageVersionDeprecation> | undefined,
+    b: PackageVersionDeprecation | PlainMessage<PackageVersionDeprecation> | undefined,
+  ): boolean {
+    return proto3.util.equals(PackageVersionDeprecation, a, b);
+  }
+}
+
+/**
+ * Upgrade impact
+ *
+ * A machine-readable classification of the risk of upgrading from a version of
+ * a package, usually the installed one, to another version.
+ *
+ * @generated from message kubeappsapis.core.packages.v1.UpgradeImpact
+ */
+export class UpgradeImpact extends Message<UpgradeImpact> {
+  /**
+   * @generated from field: kubeappsapis.core.packages.v1.UpgradeImpact.UpgradeType type = 1;
+   */
+  type = UpgradeImpact_UpgradeType.UNSPECIFIED;
+
+  /**
+   * Whether any version after the compared one and up to this one (included)
+   * is flagged as containing breaking changes, eg. with changes of kind
+   * "changed" or "removed" in the artifacthub.io/changes annotation of a chart
+   * or with release notes mentioning breaking or incompatible changes.
+   *
+   * @generated from field: bool breaking = 2;
+   */
+  breaking = false;
+
+  /**
+   * The descriptions of the breaking changes found, if any.
+   *
+   * @generated from field: repeated string breaking_changes = 3;
+   */
+  breakingChanges: string[] = [];
+
+  constructor(data?: PartialMessage<UpgradeImpact>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.UpgradeImpact";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "type", kind: "enum", T: proto3.getEnumType(UpgradeImpact_UpgradeType) },
+    { no: 2, name: "breaking", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    {
+      no: 3,
+      name: "breaking_changes",
+      kind: "scalar",
+      T: 9 /* ScalarType.STRING */,
+      repeated: true,
+    },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): UpgradeImpact {
+    return new UpgradeImpact().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): UpgradeImpact {
+    return new UpgradeImpact().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): UpgradeImpact {
+    return new UpgradeImpact().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: UpgradeImpact | PlainMessage<UpgradeImpact> | undefined,
+    b: UpgradeImpact | PlainMessage<UpgradeImpact> | undefined,
+  ): boolean {
+    return proto3.util.equals(UpgradeImpact, a, b);
+  }
+}
+
+/**
+ * Upgrade type
+ *
+ * The semantic versioning component which changes between both versions.
+ *
+ * @generated from enum kubeappsapis.core.packages.v1.UpgradeImpact.UpgradeType
+ */
+export enum UpgradeImpact_UpgradeType {
+  /**
+   * The versions could not be compared, eg. as one of them is not semver.
+   *
+   * @generated from enum value: UPGRADE_TYPE_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * The version is the same as the one it is compared to.
+   *
+   * @generated from enum value: UPGRADE_TYPE_NONE = 1;
+   */
+  NONE = 1,
+
+  /**
+   * @generated from enum value: UPGRADE_TYPE_PATCH = 2;
+   */
+  PATCH = 2,
+
+  /**
+   * @generated from enum value: UPGRADE_TYPE_MINOR = 3;
+   */
+  MINOR = 3,
+
+  /**
+   * @generated from enum value: UPGRADE_TYPE_MAJOR = 4;
+   */
+  MAJOR = 4,
+
+  /**
+   * The version is older than the one it is compared to.
+   *
+   * @generated from enum value: UPGRADE_TYPE_DOWNGRADE = 5;
+   */
+  DOWNGRADE = 5,
+}
+// Retrieve enum metadata with: proto3.getEnumType(UpgradeImpact_UpgradeType)
+proto3.util.setEnumType(
+  UpgradeImpact_UpgradeType,
+  "kubeappsapis.core.packages.v1.UpgradeImpact.UpgradeType",
+  [
+    { no: 0, name: "UPGRADE_TYPE_UNSPECIFIED" },
+    { no: 1, name: "UPGRADE_TYPE_NONE" },
+    { no: 2, name: "UPGRADE_TYPE_PATCH" },
+    { no: 3, name: "UPGRADE_TYPE_MINOR" },
+    { no: 4, name: "UPGRADE_TYPE_MAJOR" },
+    { no: 5, name: "UPGRADE_TYPE_DOWNGRADE" },
+  ],
+);
+
+/**
+ * Resource reference
+ *
+ * A reference to a Kubernetes resource related to a specific installed package.
+ * The context (cluster) for each resource is that of the related
+ * installed package.
+ *
+ * @generated from message kubeappsapis.core.packages.v1.ResourceRef
+ */
+export class ResourceRef extends Message<ResourceRef> {
+  /**
+   * The APIVersion directly from the resource has the group and version, eg. "apps/v1"
+   * or just the version for core resources.
+   *
+   * @generated from field: string api_version = 1;
+   */
+  apiVersion = "";
+
+  /**
+   * The Kind directly from the templated manifest. Together with the APIVersion this
+   * forms the GroupVersionKind.
+   *
+   * @generated from field: string kind = 2;
+   */
+  kind = "";
+
+  /**
+   * The name of the specific resource in the context of the installed package.
+   *
+   * @generated from field: string name = 3;
+   */
+  name = "";
+
+  /**
+   * The namespace of the specific resource in the context of the installed
+   * package. In most cases this will be identical to the namespace of the
+   * installed package. Exceptions will be non-namespaced resources and packages
+   * that install resources in other namespaces for special reasons.
+   *
+   * @generated from field: string namespace = 4;
+   */
+  namespace = "";
+
+  constructor(data?: PartialMessage<ResourceRef>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.ResourceRef";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "api_version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "kind", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "namespace", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ResourceRef {
+    return new ResourceRef().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ResourceRef {
+    return new ResourceRef().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ResourceRef {
+    return new ResourceRef().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: ResourceRef | PlainMessage<ResourceRef> | undefined,
+    b: ResourceRef | PlainMessage<ResourceRef> | undefined,
+  ): boolean {
+    return proto3.util.equals(ResourceRef, a, b);
+  }
+}
+
+/**
+ * Partial results
+ *
+ * PartialResults indicates that a listing is incomplete, detailing why each
+ * of the omitted namespaces could not be included, so that "you cannot see
+ * namespace X" can be told apart from "there is nothing in namespace X".
+ *
+ * @generated from message kubeappsapis.core.packages.v1.PartialResults
+ */
+export class PartialResults extends Message<PartialResults> {
+  /**
+   * Namespace failures
+   *
+   * The namespaces which could not be included in the results.
+   *
+   * @generated from field: repeated kubeappsapis.core.packages.v1.NamespaceFailure namespace_failures = 1;
+   */
+  namespaceFailures: NamespaceFailure[] = [];
+
+  /**
+   * Plugin failures
+   *
+   * The plugins which could not be included in the results, such as when
+   * they did not respond in time, while the other plugins did.
+   *
+   * @generated from field: repeated kubeappsapis.core.packages.v1.PluginFailure plugin_failures = 2;
+   */
+  pluginFailures: PluginFailure[] = [];
+
+  constructor(data?: PartialMessage<PartialResults>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.PartialResults";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "namespace_failures", kind: "message", T: NamespaceFailure, repeated: true },
+    { no: 2, name: "plugin_failures", kind: "message", T: PluginFailure, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): PartialResults {
+    return new PartialResults().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): PartialResults {
+    return new PartialResults().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): PartialResults {
+    return new PartialResults().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: PartialResults | PlainMessage<PartialResults> | undefined,
+    b: PartialResults | PlainMessage<PartialResults> | undefined,
+  ): boolean {
+    return proto3.util.equals(PartialResults, a, b);
+  }
+}
+
+/**
+ * Namespace failure
+ *
+ * A NamespaceFailure conveys why a namespace could not be included in a listing.
+ *
+ * @generated from message kubeappsapis.core.packages.v1.NamespaceFailure
+ */
+export class NamespaceFailure extends Message<NamespaceFailure> {
+  /**
+   * The context (cluster and namespace) which could not be listed.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.Context context = 1;
+   */
+  context?: Context;
+
+  /**
+   * The plugin which could not list the namespace.
+   *
+   * @generated from field: kubeappsapis.core.plugins.v1alpha1.Plugin plugin = 2;
+   */
+  plugin?: Plugin;
+
+  /**
+   * The error code, eg. "permission_denied".
+   *
+   * @generated from field: string code = 3;
+   */
+  code = "";
+
+  /**
+   * A human readable reason for the failure.
+   *
+   * @generated from field: string reason = 4;
+   */
+  reason = "";
+
+  constructor(data?: PartialMessage<NamespaceFailure>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.NamespaceFailure";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "context", kind: "message", T: Context },
+    { no: 2, name: "plugin", kind: "message", T: Plugin },
+    { no: 3, name: "code", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "reason", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): NamespaceFailure {
+    return new NamespaceFailure().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): NamespaceFailure {
+    return new NamespaceFailure().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): NamespaceFailure {
+    return new NamespaceFailure().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: NamespaceFailure | PlainMessage<NamespaceFailure> | undefined,
+    b: NamespaceFailure | PlainMessage<NamespaceFailure> | undefined,
+  ): boolean {
+    return proto3.util.equals(NamespaceFailure, a, b);
+  }
+}
+
+/**
+ * Plugin failure
+ *
+ * A PluginFailure conveys why a plugin could not be included in a listing
+ * aggregating the results of several plugins.
+ *
+ * @generated from message kubeappsapis.core.packages.v1.PluginFailure
+ */
+export class PluginFailure extends Message<PluginFailure> {
+  /**
+   * The plugin which could not be included.
+   *
+   * @generated from field: kubeappsapis.core.plugins.v1alpha1.Plugin plugin = 1;
+   */
+  plugin?: Plugin;
+
+  /**
+   * The error code, eg. "deadline_exceeded".
+   *
+   * @generated from field: string code = 2;
+   */
+  code = "";
+
+  /**
+   * A human readable reason for the failure.
+   *
+   * @generated from field: string reason = 3;
+   */
+  reason = "";
+
+  constructor(data?: PartialMessage<PluginFailure>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.PluginFailure";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "plugin", kind: "message", T: Plugin },
+    { no: 2, name: "code", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "reason", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): PluginFailure {
+    return new PluginFailure().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): PluginFailure {
+    return new PluginFailure().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): PluginFailure {
+    return new PluginFailure().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: PluginFailure | PlainMessage<PluginFailure> | undefined,
+    b: PluginFailure | PlainMessage<PluginFailure> | undefined,
+  ): boolean {
+    return proto3.util.equals(PluginFailure, a, b);
+  }
+}
+
+/**
+ * Truncated text asset
+ *
+ * A TruncatedTextAsset reports a text asset which has been truncated in a response.
+ *
+ * @generated from message kubeappsapis.core.packages.v1.TruncatedTextAsset
+ */
+export class TruncatedTextAsset extends Message<TruncatedTextAsset> {
+  /**
+   * The truncated text asset.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.TextAsset asset = 1;
+   */
+  asset = TextAsset.UNSPECIFIED;
+
+  /**
+   * The total size, in bytes, of the text asset.
+   *
+   * @generated from field: int64 total_size = 2;
+   */
+  totalSize = protoInt64.zero;
+
+  constructor(data?: PartialMessage<TruncatedTextAsset>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.TruncatedTextAsset";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "asset", kind: "enum", T: proto3.getEnumType(TextAsset) },
+    { no: 2, name: "total_size", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): TruncatedTextAsset {
+    return new TruncatedTextAsset().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): TruncatedTextAsset {
+    return new TruncatedTextAsset().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): TruncatedTextAsset {
+    return new TruncatedTextAsset().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: TruncatedTextAsset | PlainMessage<TruncatedTextAsset> | undefined,
+    b: TruncatedTextAsset | PlainMessage<TruncatedTextAsset> | undefined,
+  ): boolean {
+    return proto3.util.equals(TruncatedTextAsset, a, b);
+  }
+}
+
+/**
+ * Package icon
+ *
+ * A PackageIcon is the icon of a package, in the variants suitable for
+ * listings and details. Icons inlined as data URLs are returned as data URLs.
+ * Icons referenced by an http(s) URL are returned as is, or as URLs of the
+ * kubeapps-apis icon proxy when it is enabled.
+ *
+ * @generated from message kubeappsapis.core.packages.v1.PackageIcon
+ */
+export class PackageIcon extends Message<PackageIcon> {
+  /**
+   * The URL of the full-size icon.
+   *
+   * @generated from field: string url = 1;
+   */
+  url = "";
+
+  /**
+   * The URL of the thumbnail of the icon, no bigger than 64x64 pixels. Vector
+   * icons are their own thumbnail.
+   *
+   * @generated from field: string thumbnail_url = 2;
+   */
+  thumbnailUrl = "";
+
+  /**
+   * The media type of the icon, such as "image/svg+xml" or "image/png", as
+   * sniffed from its content. Empty for external icons, fetched when requested.
+   *
+   * @generated from field: string media_type = 3;
+   */
+  mediaType = "";
+
+  constructor(data?: PartialMessage<PackageIcon>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.PackageIcon";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "url", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "thumbnail_url", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "media_type", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): PackageIcon {
+    return new PackageIcon().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): PackageIcon {
+    return new PackageIcon().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): PackageIcon {
+    return new PackageIcon().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: PackageIcon | PlainMessage<PackageIcon> | undefined,
+    b: PackageIcon | PlainMessage<PackageIcon> | undefined,
+  ): boolean {
+    return proto3.util.equals(PackageIcon, a, b);
+  }
+}
+
+/**
+ * Package version changelog
+ *
+ * The changes introduced by a specific version of a package.
+ *
+ * @generated from message kubeappsapis.core.packages.v1.PackageVersionChangelog
+ */
+export class PackageVersionChangelog extends Message<PackageVersionChangelog> {
+  /**
+   * The version of the package.
+   *
+   * @generated from field: string pkg_version = 1;
+   */
+  pkgVersion = "";
+
+  /**
+   * The release notes of the version, as free-form text, if any.
+   *
+   * @generated from field: string release_notes = 2;
+   */
+  releaseNotes = "";
+
+  /**
+   * The individual changes of the version, if listed by the package.
+   *
+   * @generated from field: repeated kubeappsapis.core.packages.v1.PackageChange changes = 3;
+   */
+  changes: PackageChange[] = [];
+
+  constructor(data?: PartialMessage<PackageVersionChangelog>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.PackageVersionChangelog";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "pkg_version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "release_notes", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "changes", kind: "message", T: PackageChange, repeated: true },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): PackageVersionChangelog {
+    return new PackageVersionChangelog().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): PackageVersionChangelog {
+    return new PackageVersionChangelog().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): PackageVersionChangelog {
+    return new PackageVersionChangelog().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: PackageVersionChangelog | PlainMessage<PackageVersionChangelog> | undefined,
+    b: PackageVersionChangelog | PlainMessage<PackageVersionChangelog> | undefined,
+  ): boolean {
+    return proto3.util.equals(PackageVersionChangelog, a, b);
+  }
+}
+
+/**
+ * Package change
+ *
+ * A single change introduced by a version of a package, eg. as listed in
+ * the artifacthub.io/changes annotation of a chart.
+ *
+ * @generated from message kubeappsapis.core.packages.v1.PackageChange
+ */
+export class PackageChange extends Message<PackageChange> {
+  /**
+   * The kind of change, eg. "added", "changed", "fixed" or "security".
+   *
+   * @generated from field: string kind = 1;
+   */
+  kind = "";
+
+  /**
+   * The description of the change.
+   *
+   * @generated from field: string description = 2;
+   */
+  description = "";
+
+  /**
+   * Links related to the change, eg. to an issue or a pull request.
+   *
+   * @generated from field: repeated kubeappsapis.core.packages.v1.PackageChangeLink links = 3;
+   */
+  links: PackageChangeLink[] = [];
+
+  constructor(data?: PartialMessage<PackageChange>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.PackageChange";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "kind", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "description", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "links", kind: "message", T: PackageChangeLink, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): PackageChange {
+    return new PackageChange().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): PackageChange {
+    return new PackageChange().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): PackageChange {
+    return new PackageChange().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: PackageChange | PlainMessage<PackageChange> | undefined,
+    b: PackageChange | PlainMessage<PackageChange> | undefined,
+  ): boolean {
+    return proto3.util.equals(PackageChange, a, b);
+  }
+}
+
+/**
+ * Package change link
+ *
+ * A link related to a change of a package.
+ *
+ * @generated from message kubeappsapis.core.packages.v1.PackageChangeLink
+ */
+export class PackageChangeLink extends Message<PackageChangeLink> {
+  /**
+   * The name of the link.
+   *
+   * @generated from field: string name = 1;
+   */
+  name = "";
+
+  /**
+   * The URL of the link.
+   *
+   * @generated from field: string url = 2;
+   */
+  url = "";
+
+  constructor(data?: PartialMessage<PackageChangeLink>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.PackageChangeLink";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "url", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): PackageChangeLink {
+    return new PackageChangeLink().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): PackageChangeLink {
+    return new PackageChangeLink().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): PackageChangeLink {
+    return new PackageChangeLink().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: PackageChangeLink | PlainMessage<PackageChangeLink> | undefined,
+    b: PackageChangeLink | PlainMessage<PackageChangeLink> | undefined,
+  ): boolean {
+    return proto3.util.equals(PackageChangeLink, a, b);
+  }
+}
@@ -0,0 +1,143 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+// @generated by protoc-gen-connect-es v0.13.0 with parameter "target=ts,import_extension=none"
+// @generated from file kubeappsapis/core/packages/v1/repositories.proto (package kubeappsapis.core.packages.v1, syntax proto3)
+/* eslint-disable */
+// @ts-nocheck
+
+import {
+  AddPackageRepositoryRequest,
+  AddPackageRepositoryResponse,
+  DeletePackageRepositoryRequest,
+  DeletePackageRepositoryResponse,
+  ExportPackageRepositoriesRequest,
+  ExportPackageRepositoriesResponse,
+  GetPackageRepositoryDetailRequest,
+  GetPackageRepositoryDetailResponse,
+  GetPackageRepositoryPermissionsRequest,
+  GetPackageRepositoryPermissionsResponse,
+  GetPackageRepositorySummariesRequest,
+  GetPackageRepositorySummariesResponse,
+  ImportPackageRepositoriesRequest,
+  ImportPackageRepositoriesResponse,
+  ListPackageRepositoryPackagesRequest,
+  ListPackageRepositoryPackagesResponse,
+  TestPackageRepositoryConnectionRequest,
+  TestPackageRepositoryConnectionResponse,
+  UpdatePackageRepositoryRequest,
+  UpdatePackageRepositoryResponse,
+} from "./repositories_pb";
+import { MethodKind } from "@bufbuild/protobuf";
+
+/**
+ * The RepositoriesService is served by the core, translating each request to
+ * the v1alpha1 RepositoriesService implemented by the repositories plugins.
+ *
+ *
+ * @generated from service kubeappsapis.core.packages.v1.RepositoriesService
+ */
+export const RepositoriesService = {
+  typeName: "kubeappsapis.core.packages.v1.RepositoriesService",
+  methods: {
+    /**
+     * @generated from rpc kubeappsapis.core.packages.v1.RepositoriesService.AddPackageRepository
+     */
+    addPackageRepository: {
+      name: "AddPackageRepository",
+      I: AddPackageRepositoryRequest,
+      O: AddPackageRepositoryResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc kubeappsapis.core.packages.v1.RepositoriesService.GetPackageRepositoryDetail
+     */
+    getPackageRepositoryDetail: {
+      name: "GetPackageRepositoryDetail",
+      I: GetPackageRepositoryDetailRequest,
+      O: GetPackageRepositoryDetailResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc kubeappsapis.core.packages.v1.RepositoriesService.GetPackageRepositorySummaries
+     */
+    getPackageRepositorySummaries: {
+      name: "GetPackageRepositorySummaries",
+      I: GetPackageRepositorySummariesRequest,
+      O: GetPackageRepositorySummariesResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc kubeappsapis.core.packages.v1.RepositoriesService.UpdatePackageRepository
+     */
+    updatePackageRepository: {
+      name: "UpdatePackageRepository",
+      I: UpdatePackageRepositoryRequest,
+      O: UpdatePackageRepositoryResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc kubeappsapis.core.packages.v1.RepositoriesService.DeletePackageRepository
+     */
+    deletePackageRepository: {
+      name: "DeletePackageRepository",
+      I: DeletePackageRepositoryRequest,
+      O: DeletePackageRepositoryResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc kubeappsapis.core.packages.v1.RepositoriesService.GetPackageRepositoryPermissions
+     */
+    getPackageRepositoryPermissions: {
+      name: "GetPackageRepositoryPermissions",
+      I: GetPackageRepositoryPermissionsRequest,
+      O: GetPackageRepositoryPermissionsResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc kubeappsapis.core.packages.v1.RepositoriesService.TestPackageRepositoryConnection
+     */
+    testPackageRepositoryConnection: {
+      name: "TestPackageRepositoryConnection",
+      I: TestPackageRepositoryConnectionRequest,
+      O: TestPackageRepositoryConnectionResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc kubeappsapis.core.packages.v1.RepositoriesService.ListPackageRepositoryPackages
+     */
+    listPackageRepositoryPackages: {
+      name: "ListPackageRepositoryPackages",
+      I: ListPackageRepositoryPackagesRequest,
+      O: ListPackageRepositoryPackagesResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * ExportPackageRepositories exports the package repositories of every plugin
+     * as a single declarative YAML bundle. It is served by the core only, on top
+     * of the other rpcs of the plugins.
+     *
+     * @generated from rpc kubeappsapis.core.packages.v1.RepositoriesService.ExportPackageRepositories
+     */
+    exportPackageRepositories: {
+      name: "ExportPackageRepositories",
+      I: ExportPackageRepositoriesRequest,
+      O: ExportPackageRepositoriesResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * ImportPackageRepositories idempotently applies a bundle exported by
+     * ExportPackageRepositories, creating the missing package repositories,
+     * updating the changed ones and, optionally, deleting those not in the bundle.
+     * It is served by the core only, on top of the other rpcs of the plugins.
+     *
+     * @generated from rpc kubeappsapis.core.packages.v1.RepositoriesService.ImportPackageRepositories
+     */
+    importPackageRepositories: {
+      name: "ImportPackageRepositories",
+      I: ImportPackageRepositoriesRequest,
+      O: ImportPackageRepositoriesResponse,
+      kind: MethodKind.Unary,
+    },
+  },
+} as const;
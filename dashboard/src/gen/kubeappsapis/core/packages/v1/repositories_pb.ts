@@ -0,0 +1,2988 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+// @generated by protoc-gen-es v1.3.1 with parameter "target=ts,import_extension=none"
+// @generated from file kubeappsapis/core/packages/v1/repositories.proto (package kubeappsapis.core.packages.v1, syntax proto3)
+/* eslint-disable */
+// @ts-nocheck
+
+import type {
+  BinaryReadOptions,
+  FieldList,
+  JsonReadOptions,
+  JsonValue,
+  PartialMessage,
+  PlainMessage,
+} from "@bufbuild/protobuf";
+import { Any, Message, proto3, Timestamp } from "@bufbuild/protobuf";
+import {
+  AvailablePackageSummary,
+  Context,
+  PaginationOptions,
+  PartialResults,
+  ResourceRef,
+} from "./packages_pb";
+import { Plugin } from "../../plugins/v1alpha1/plugins_pb";
+
+/**
+ * AddPackageRepositoryRequest
+ *
+ * Request for AddPackageRepository
+ *
+ * @generated from message kubeappsapis.core.packages.v1.AddPackageRepositoryRequest
+ */
+export class AddPackageRepositoryRequest extends Message<AddPackageRepositoryRequest> {
+  /**
+   * The target context where the package repository is intended to be
+   * installed.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.Context context = 1;
+   */
+  context?: Context;
+
+  /**
+   * A user-provided name for the package repository (e.g. bitnami)
+   *
+   * @generated from field: string name = 2;
+   */
+  name = "";
+
+  /**
+   * A user-provided description. Optional
+   *
+   * @generated from field: string description = 3;
+   */
+  description = "";
+
+  /**
+   * Whether this repository is global or namespace-scoped. Optional.
+   * By default, the value is false, i.e. the repository is global
+   *
+   * @generated from field: bool namespace_scoped = 4;
+   */
+  namespaceScoped = false;
+
+  /**
+   * Package storage type
+   * In general, each plug-in will define an acceptable set of valid types
+   * - for direct helm plug-in valid values are: "helm" and "oci"
+   * - for flux plug-in valid values are: "helm" and "oci". In the
+   *   future, we may add support for git and/or AWS s3-style buckets
+   *
+   * @generated from field: string type = 5;
+   */
+  type = "";
+
+  /**
+   * A URL identifying the package repository location. Must contain at
+   * least a protocol and host
+   *
+   * @generated from field: string url = 6;
+   */
+  url = "";
+
+  /**
+   * The interval at which to check the upstream for updates (in time+unit)
+   * Some plugins may, additionally, support other formats, for instance,
+   * a cron expression.
+   * e.g., "*\/10 * * * *" will be equivalent to "10m"
+   * Optional. Defaults to 10m if not specified
+   *
+   * @generated from field: string interval = 7;
+   */
+  interval = "";
+
+  /**
+   * TLS-specific parameters for connecting to a repository. Optional
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.PackageRepositoryTlsConfig tls_config = 8;
+   */
+  tlsConfig?: PackageRepositoryTlsConfig;
+
+  /**
+   * authentication parameters for connecting to a repository. Optional
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.PackageRepositoryAuth auth = 9;
+   */
+  auth?: PackageRepositoryAuth;
+
+  /**
+   * The plugin used to interact with this package repository.
+   * This field should be omitted when the request is in the context of a
+   * specific plugin.
+   *
+   * @generated from field: kubeappsapis.core.plugins.v1alpha1.Plugin plugin = 10;
+   */
+  plugin?: Plugin;
+
+  /**
+   * Custom data added by the plugin
+   * A plugin can define custom details for data which is not yet, or
+   * never will be specified in the core AddPackageRepositoryRequest
+   * fields. The use of an `Any` field means that each plugin can define
+   * the structure of this message as required, while still satisfying the
+   * core interface.
+   * See https://developers.google.com/protocol-buffers/docs/proto3#any
+   * Just for reference, some of the examples that have been chosen not to
+   * be part of the core API but rather plugin-specific details are:
+   *   direct-helm:
+   *      - image pull secrets
+   *      - list of oci repositories
+   *      - filter rules
+   *      - sync job pod template
+   *
+   * @generated from field: google.protobuf.Any custom_detail = 11;
+   */
+  customDetail?: Any;
+
+  constructor(data?: PartialMessage<AddPackageRepositoryRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.AddPackageRepositoryRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "context", kind: "message", T: Context },
+    { no: 2, name: "name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "description", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "namespace_scoped", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 5, name: "type", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 6, name: "url", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 7, name: "interval", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 8, name: "tls_config", kind: "message", T: PackageRepositoryTlsConfig },
+    { no: 9, name: "auth", kind: "message", T: PackageRepositoryAuth },
+    { no: 10, name: "plugin", kind: "message", T: Plugin },
+    { no: 11, name: "custom_detail", kind: "message", T: Any },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): AddPackageRepositoryRequest {
+    return new AddPackageRepositoryRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): AddPackageRepositoryRequest {
+    return new AddPackageRepositoryRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): AddPackageRepositoryRequest {
+    return new AddPackageRepositoryRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: AddPackageRepositoryRequest | PlainMessage<AddPackageRepositoryRequest> | undefined,
+    b: AddPackageRepositoryRequest | PlainMessage<AddPackageRepositoryRequest> | undefined,
+  ): boolean {
+    return proto3.util.equals(AddPackageRepositoryRequest, a, b);
+  }
+}
+
+/**
+ * PackageRepositoryTlsConfig
+ *
+ *
+ * @generated from message kubeappsapis.core.packages.v1.PackageRepositoryTlsConfig
+ */
+export class PackageRepositoryTlsConfig extends Message<PackageRepositoryTlsConfig> {
+  /**
+   * whether or not to skip TLS verification
+   * note that fluxv2 does not currently support this and will raise an
+   * error should this flag be set to true
+   *
+   * @generated from field: bool insecure_skip_verify = 1;
+   */
+  insecureSkipVerify = false;
+
+  /**
+   * @generated from oneof kubeappsapis.core.packages.v1.PackageRepositoryTlsConfig.package_repo_tls_config_one_of
+   */
+  packageRepoTlsConfigOneOf:
+    | {
+        /**
+         * certificate authority. Optional
+         *
+         * @generated from field: string cert_authority = 2;
+         */
+        value: string;
+        case: "certAuthority";
+      }
+    | {
+        /**
+         * a reference to an existing secret that contains custom CA
+         *
+         * @generated from field: kubeappsapis.core.packages.v1.SecretKeyReference secret_ref = 3;
+         */
+        value: SecretKeyReference;
+        case: "secretRef";
+      }
+    | { case: undefined; value?: undefined } = { case: undefined };
+
+  constructor(data?: PartialMessage<PackageRepositoryTlsConfig>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.PackageRepositoryTlsConfig";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "insecure_skip_verify", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    {
+      no: 2,
+      name: "cert_authority",
+      kind: "scalar",
+      T: 9 /* ScalarType.STRING */,
+      oneof: "package_repo_tls_config_one_of",
+    },
+    {
+      no: 3,
+      name: "secret_ref",
+      kind: "message",
+      T: SecretKeyReference,
+      oneof: "package_repo_tls_config_one_of",
+    },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): PackageRepositoryTlsConfig {
+    return new PackageRepositoryTlsConfig().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): PackageRepositoryTlsConfig {
+    return new PackageRepositoryTlsConfig().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): PackageRepositoryTlsConfig {
+    return new PackageRepositoryTlsConfig().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: PackageRepositoryTlsConfig | PlainMessage<PackageRepositoryTlsConfig> | undefined,
+    b: PackageRepositoryTlsConfig | PlainMessage<PackageRepositoryTlsConfig> | undefined,
+  ): boolean {
+    return proto3.util.equals(PackageRepositoryTlsConfig, a, b);
+  }
+}
+
+/**
+ * PackageRepositoryAuth
+ *
+ * Authentication/authorization to provide client's identity when connecting
+ * to a package repository.
+ * There are 6 total distinct use cases we may support:
+ * 1) None (Public)
+ * 2) Basic Auth
+ * 3) Bearer Token
+ * 4) Custom Authorization Header
+ * 5) Docker Registry Credentials (for OCI only)
+ * 6) TLS certificate and key
+ *
+ * Note that (1)-(4) may be done over HTTP or HTTPs without any custom
+ * certificates or certificate authority
+ * (1) is handled by not not having PackageRepositoryAuth field on
+ *     the parent object
+ * a given plug-in may or may not support a given authentication type.
+ * For example
+ *  - direct-helm plug-in does not currently support (6), while flux does
+ *  - flux plug-in does not support (3) or (4) while direct-helm does
+ *
+ * @generated from message kubeappsapis.core.packages.v1.PackageRepositoryAuth
+ */
+export class PackageRepositoryAuth extends Message<PackageRepositoryAuth> {
+  /**
+   * @generated from field: kubeappsapis.core.packages.v1.PackageRepositoryAuth.PackageRepositoryAuthType type = 1;
+   */
+  type = PackageRepositoryAuth_PackageRepositoryAuthType.UNSPECIFIED;
+
+  /**
+   * @generated from oneof kubeappsapis.core.packages.v1.PackageRepositoryAuth.package_repo_auth_one_of
+   */
+  packageRepoAuthOneOf:
+    | {
+        /**
+         * username and plain text password
+         *
+         * @generated from field: kubeappsapis.core.packages.v1.UsernamePassword username_password = 2;
+         */
+        value: UsernamePassword;
+        case: "usernamePassword";
+      }
+    | {
+        /**
+         * certificate and key for TLS-based authentication
+         *
+         * @generated from field: kubeappsapis.core.packages.v1.TlsCertKey tls_cert_key = 3;
+         */
+        value: TlsCertKey;
+        case: "tlsCertKey";
+      }
+    | {
+        /**
+         * docker credentials
+         *
+         * @generated from field: kubeappsapis.core.packages.v1.DockerCredentials docker_creds = 4;
+         */
+        value: DockerCredentials;
+        case: "dockerCreds";
+      }
+    | {
+        /**
+         * for Bearer Auth token value
+         * for Custom Auth, complete value of "Authorization" header
+         *
+         * @generated from field: string header = 5;
+         */
+        value: string;
+        case: "header";
+      }
+    | {
+        /**
+         * a reference to an existing secret
+         *
+         * @generated from field: kubeappsapis.core.packages.v1.SecretKeyReference secret_ref = 6;
+         */
+        value: SecretKeyReference;
+        case: "secretRef";
+      }
+    | {
+        /**
+         * SSH credentials
+         *
+         * @generated from field: kubeappsapis.core.packages.v1.SshCredentials ssh_creds = 8;
+         */
+        value: SshCredentials;
+        case: "sshCreds";
+      }
+    | {
+        /**
+         * opaque credentials
+         *
+         * @generated from field: kubeappsapis.core.packages.v1.OpaqueCredentials opaque_creds = 9;
+         */
+        value: OpaqueCredentials;
+        case: "opaqueCreds";
+      }
+    | { case: undefined; value?: undefined } = { case: undefined };
+
+  /**
+   * pass_credentials allows the credentials from the SecretRef to be passed
+   * on to a host that does not match the host as defined in URL.
+   * This flag controls whether or not it is allowed to passing credentials
+   * with requests to other domains linked from the repository.
+   * This may be needed if the host of the advertised chart URLs in the
+   * index differs from the defined URL. Optional
+   *
+   * @generated from field: bool pass_credentials = 7;
+   */
+  passCredentials = false;
+
+  constructor(data?: PartialMessage<PackageRepositoryAuth>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.PackageRepositoryAuth";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    {
+      no: 1,
+      name: "type",
+      kind: "enum",
+      T: proto3.getEnumType(PackageRepositoryAuth_PackageRepositoryAuthType),
+    },
+    {
+      no: 2,
+      name: "username_password",
+      kind: "message",
+      T: UsernamePassword,
+      oneof: "package_repo_auth_one_of",
+    },
+    {
+      no: 3,
+      name: "tls_cert_key",
+      kind: "message",
+      T: TlsCertKey,
+      oneof: "package_repo_auth_one_of",
+    },
+    {
+      no: 4,
+      name: "docker_creds",
+      kind: "message",
+      T: DockerCredentials,
+      oneof: "package_repo_auth_one_of",
+    },
+    {
+      no: 5,
+      name: "header",
+      kind: "scalar",
+      T: 9 /* ScalarType.STRING */,
+      oneof: "package_repo_auth_one_of",
+    },
+    {
+      no: 6,
+      name: "secret_ref",
+      kind: "message",
+      T: SecretKeyReference,
+      oneof: "package_repo_auth_one_of",
+    },
+    {
+      no: 8,
+      name: "ssh_creds",
+      kind: "message",
+      T: SshCredentials,
+      oneof: "package_repo_auth_one_of",
+    },
+    {
+      no: 9,
+      name: "opaque_creds",
+      kind: "message",
+      T: OpaqueCredentials,
+      oneof: "package_repo_auth_one_of",
+    },
+    { no: 7, name: "pass_credentials", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): PackageRepositoryAuth {
+    return new PackageRepositoryAuth().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): PackageRepositoryAuth {
+    return new PackageRepositoryAuth().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): PackageRepositoryAuth {
+    return new PackageRepositoryAuth().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: PackageRepositoryAuth | PlainMessage<PackageRepositoryAuth> | undefined,
+    b: PackageRepositoryAuth | PlainMessage<PackageRepositoryAuth> | undefined,
+  ): boolean {
+    return proto3.util.equals(PackageRepositoryAuth, a, b);
+  }
+}
+
+/**
+ * @generated from enum kubeappsapis.core.packages.v1.PackageRepositoryAuth.PackageRepositoryAuthType
+ */
+export enum PackageRepositoryAuth_PackageRepositoryAuthType {
+  /**
+   * @generated from enum value: PACKAGE_REPOSITORY_AUTH_TYPE_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * uses UsernamePassword
+   *
+   * @generated from enum value: PACKAGE_REPOSITORY_AUTH_TYPE_BASIC_AUTH = 1;
+   */
+  BASIC_AUTH = 1,
+
+  /**
+   * uses TlsCertKey
+   *
+   * @generated from enum value: PACKAGE_REPOSITORY_AUTH_TYPE_TLS = 2;
+   */
+  TLS = 2,
+
+  /**
+   * uses header
+   *
+   * @generated from enum value: PACKAGE_REPOSITORY_AUTH_TYPE_BEARER = 3;
+   */
+  BEARER = 3,
+
+  /**
+   * uses header
+   *
+   * @generated from enum value: PACKAGE_REPOSITORY_AUTH_TYPE_AUTHORIZATION_HEADER = 4;
+   */
+  AUTHORIZATION_HEADER = 4,
+
+  /**
+   * uses DockerCredentials
+   *
+   * @generated from enum value: PACKAGE_REPOSITORY_AUTH_TYPE_DOCKER_CONFIG_JSON = 5;
+   */
+  DOCKER_CONFIG_JSON = 5,
+
+  /**
+   * uses SshCredentials
+   *
+   * @generated from enum value: PACKAGE_REPOSITORY_AUTH_TYPE_SSH = 6;
+   */
+  SSH = 6,
+
+  /**
+   * uses OpaqueCredentials
+   *
+   * @generated from enum value: PACKAGE_REPOSITORY_AUTH_TYPE_OPAQUE = 7;
+   */
+  OPAQUE = 7,
+}
+// Retrieve enum metadata with: proto3.getEnumType(PackageRepositoryAuth_PackageRepositoryAuthType)
+proto3.util.setEnumType(
+  PackageRepositoryAuth_PackageRepositoryAuthType,
+  "kubeappsapis.core.packages.v1.PackageRepositoryAuth.PackageRepositoryAuthType",
+  [
+    { no: 0, name: "PACKAGE_REPOSITORY_AUTH_TYPE_UNSPECIFIED" },
+    { no: 1, name: "PACKAGE_REPOSITORY_AUTH_TYPE_BASIC_AUTH" },
+    { no: 2, name: "PACKAGE_REPOSITORY_AUTH_TYPE_TLS" },
+    { no: 3, name: "PACKAGE_REPOSITORY_AUTH_TYPE_BEARER" },
+    { no: 4, name: "PACKAGE_REPOSITORY_AUTH_TYPE_AUTHORIZATION_HEADER" },
+    { no: 5, name: "PACKAGE_REPOSITORY_AUTH_TYPE_DOCKER_CONFIG_JSON" },
+    { no: 6, name: "PACKAGE_REPOSITORY_AUTH_TYPE_SSH" },
+    { no: 7, name: "PACKAGE_REPOSITORY_AUTH_TYPE_OPAQUE" },
+  ],
+);
+
+/**
+ * UsernamePassword
+ *
+ *
+ * @generated from message kubeappsapis.core.packages.v1.UsernamePassword
+ */
+export class UsernamePassword extends Message<UsernamePassword> {
+  /**
+   * In clear text
+   *
+   * @generated from field: string username = 1;
+   */
+  username = "";
+
+  /**
+   * In clear text
+   *
+   * @generated from field: string password = 2;
+   */
+  password = "";
+
+  constructor(data?: PartialMessage<UsernamePassword>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.UsernamePassword";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "username", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "password", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): UsernamePassword {
+    return new UsernamePassword().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): UsernamePassword {
+    return new UsernamePassword().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): UsernamePassword {
+    return new UsernamePassword().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: UsernamePassword | PlainMessage<UsernamePassword> | undefined,
+    b: UsernamePassword | PlainMessage<UsernamePassword> | undefined,
+  ): boolean {
+    return proto3.util.equals(UsernamePassword, a, b);
+  }
+}
+
+/**
+ * TlsCertKey
+ *
+ *
+ * @generated from message kubeappsapis.core.packages.v1.TlsCertKey
+ */
+export class TlsCertKey extends Message<TlsCertKey> {
+  /**
+   * certificate (identity). In clear text
+   *
+   * @generated from field: string cert = 1;
+   */
+  cert = "";
+
+  /**
+   * certificate key. In clear text
+   *
+   * @generated from field: string key = 2;
+   */
+  key = "";
+
+  constructor(data?: PartialMessage<TlsCertKey>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.TlsCertKey";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "cert", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "key", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): TlsCertKey {
+    return new TlsCertKey().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): TlsCertKey {
+    return new TlsCertKey().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): TlsCertKey {
+    return new TlsCertKey().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: TlsCertKey | PlainMessage<TlsCertKey> | undefined,
+    b: TlsCertKey | PlainMessage<TlsCertKey> | undefined,
+  ): boolean {
+    return proto3.util.equals(TlsCertKey, a, b);
+  }
+}
+
+/**
+ * DockerCredentials
+ *
+ *
+ * @generated from message kubeappsapis.core.packages.v1.DockerCredentials
+ */
+export class DockerCredentials extends Message<DockerCredentials> {
+  /**
+   * server name
+   *
+   * @generated from field: string server = 1;
+   */
+  server = "";
+
+  /**
+   * username.
+   *
+   * @generated from field: string username = 2;
+   */
+  username = "";
+
+  /**
+   * password. In clear text
+   *
+   * @generated from field: string password = 3;
+   */
+  password = "";
+
+  /**
+   * email address
+   *
+   * @generated from field: string email = 4;
+   */
+  email = "";
+
+  constructor(data?: PartialMessage<DockerCredentials>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.DockerCredentials";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "server", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "username", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "password", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "email", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): DockerCredentials {
+    return new DockerCredentials().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): DockerCredentials {
+    return new DockerCredentials().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): DockerCredentials {
+    return new DockerCredentials().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: DockerCredentials | PlainMessage<DockerCredentials> | undefined,
+    b: DockerCredentials | PlainMessage<DockerCredentials> | undefined,
+  ): boolean {
+    return proto3.util.equals(DockerCredentials, a, b);
+  }
+}
+
+/**
+ * SshCredentials
+ *
+ *
+ * @generated from message kubeappsapis.core.packages.v1.SshCredentials
+ */
+export class SshCredentials extends Message<SshCredentials> {
+  /**
+   * private key
+   *
+   * @generated from field: string private_key = 1;
+   */
+  privateKey = "";
+
+  /**
+   * known hosts.
+   *
+   * @generated from field: string known_hosts = 2;
+   */
+  knownHosts = "";
+
+  constructor(data?: PartialMessage<SshCredentials>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.SshCredentials";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "private_key", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "known_hosts", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): SshCredentials {
+    return new SshCredentials().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): SshCredentials {
+    return new SshCredentials().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): SshCredentials {
+    return new SshCredentials().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: SshCredentials | PlainMessage<SshCredentials> | undefined,
+    b: SshCredentials | PlainMessage<SshCredentials> | undefined,
+  ): boolean {
+    return proto3.util.equals(SshCredentials, a, b);
+  }
+}
+
+/**
+ * OpaqueCredentials
+ *
+ *
+ * @generated from message kubeappsapis.core.packages.v1.OpaqueCredentials
+ */
+export class OpaqueCredentials extends Message<OpaqueCredentials> {
+  /**
+   * fields
+   *
+   * @generated from field: map<string, string> data = 1;
+   */
+  data: { [key: string]: string } = {};
+
+  constructor(data?: PartialMessage<OpaqueCredentials>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.OpaqueCredentials";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    {
+      no: 1,
+      name: "data",
+      kind: "map",
+      K: 9 /* ScalarType.STRING */,
+      V: { kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): OpaqueCredentials {
+    return new OpaqueCredentials().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): OpaqueCredentials {
+    return new OpaqueCredentials().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): OpaqueCredentials {
+    return new OpaqueCredentials().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: OpaqueCredentials | PlainMessage<OpaqueCredentials> | undefined,
+    b: OpaqueCredentials | PlainMessage<OpaqueCredentials> | undefined,
+  ): boolean {
+    return proto3.util.equals(OpaqueCredentials, a, b);
+  }
+}
+
+/**
+ * SecretKeyReference
+ *
+ *
+ * @generated from message kubeappsapis.core.packages.v1.SecretKeyReference
+ */
+export class SecretKeyReference extends Message<SecretKeyReference> {
+  /**
+   * The name of an existing secret in the same namespace as the object
+   * that refers to it (e.g. PackageRepository), containing authentication
+   * credentials for the said package repository.
+   * - For HTTP/S basic auth the secret must be of type
+   *   "kubernetes.io/basic-auth" or opaque and contain username and
+   *   password fields
+   * - For TLS the secret must be of type "kubernetes.io/tls" or opaque
+   *   and contain a certFile and keyFile, and/or
+   *   caCert fields.
+   * - For Bearer or Custom Auth, the secret must be opaque, and
+   *   the key must be provided
+   * - For Docker Registry Credentials (OCI registries) the secret
+   *   must of of type "kubernetes.io/dockerconfigjson"
+   * For more details, refer to
+   * https://kubernetes.io/docs/concepts/configuration/secret/
+   *
+   * @generated from field: string name = 1;
+   */
+  name = "";
+
+  /**
+   * Optional. Must be provided when name refers to an opaque secret
+   *
+   * @generated from field: string key = 2;
+   */
+  key = "";
+
+  constructor(data?: PartialMessage<SecretKeyReference>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.SecretKeyReference";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "key", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): SecretKeyReference {
+    return new SecretKeyReference().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): SecretKeyReference {
+    return new SecretKeyReference().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): SecretKeyReference {
+    return new SecretKeyReference().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: SecretKeyReference | PlainMessage<SecretKeyReference> | undefined,
+    b: SecretKeyReference | PlainMessage<SecretKeyReference> | undefined,
+  ): boolean {
+    return proto3.util.equals(SecretKeyReference, a, b);
+  }
+}
+
+/**
+ * GetPackageRepositoryDetailRequest
+ *
+ * Request for GetPackageRepositoryDetail
+ *
+ * @generated from message kubeappsapis.core.packages.v1.GetPackageRepositoryDetailRequest
+ */
+export class GetPackageRepositoryDetailRequest extends Message<GetPackageRepositoryDetailRequest> {
+  /**
+   * @generated from field: kubeappsapis.core.packages.v1.PackageRepositoryReference package_repo_ref = 1;
+   */
+  packageRepoRef?: PackageRepositoryReference;
+
+  /**
+   * Whether to also check, with the credentials of the repository, that the
+   * repository can be fetched from Kubeapps. The result is returned in the
+   * custom detail by the plugins supporting it, and ignored by the others.
+   *
+   * @generated from field: bool include_probe = 2;
+   */
+  includeProbe = false;
+
+  constructor(data?: PartialMessage<GetPackageRepositoryDetailRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.GetPackageRepositoryDetailRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "package_repo_ref", kind: "message", T: PackageRepositoryReference },
+    { no: 2, name: "include_probe", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): GetPackageRepositoryDetailRequest {
+    return new GetPackageRepositoryDetailRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): GetPackageRepositoryDetailRequest {
+    return new GetPackageRepositoryDetailRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetPackageRepositoryDetailRequest {
+    return new GetPackageRepositoryDetailRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | GetPackageRepositoryDetailRequest
+      | PlainMessage<GetPackageRepositoryDetailRequest>
+      | undefined,
+    b:
+      | GetPackageRepositoryDetailRequest
+      | PlainMessage<GetPackageRepositoryDetailRequest>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(GetPackageRepositoryDetailRequest, a, b);
+  }
+}
+
+/**
+ * GetPackageRepositorySummariesRequest
+ *
+ * Request for PackageRepositorySummary
+ *
+ * @generated from message kubeappsapis.core.packages.v1.GetPackageRepositorySummariesRequest
+ */
+export class GetPackageRepositorySummariesRequest extends Message<GetPackageRepositorySummariesRequest> {
+  /**
+   * The context (cluster/namespace) for the request.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.Context context = 1;
+   */
+  context?: Context;
+
+  constructor(data?: PartialMessage<GetPackageRepositorySummariesRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.GetPackageRepositorySummariesRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "context", kind: "message", T: Context },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): GetPackageRepositorySummariesRequest {
+    return new GetPackageRepositorySummariesRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): GetPackageRepositorySummariesRequest {
+    return new GetPackageRepositorySummariesRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetPackageRepositorySummariesRequest {
+    return new GetPackageRepositorySummariesRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | GetPackageRepositorySummariesRequest
+      | PlainMessage<GetPackageRepositorySummariesRequest>
+      | undefined,
+    b:
+      | GetPackageRepositorySummariesRequest
+      | PlainMessage<GetPackageRepositorySummariesRequest>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(GetPackageRepositorySummariesRequest, a, b);
+  }
+}
+
+/**
+ * UpdatePackageRepositoryRequest
+ *
+ * Request for UpdatePackageRepository
+ *
+ * @generated from message kubeappsapis.core.packages.v1.UpdatePackageRepositoryRequest
+ */
+export class UpdatePackageRepositoryRequest extends Message<UpdatePackageRepositoryRequest> {
+  /**
+   * A reference uniquely identifying the package repository being updated.
+   * The only required field
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.PackageRepositoryReference package_repo_ref = 1;
+   */
+  packageRepoRef?: PackageRepositoryReference;
+
+  /**
+   * URL identifying the package repository location.
+   *
+   * @generated from field: string url = 2;
+   */
+  url = "";
+
+  /**
+   * A user-provided description.
+   *
+   * @generated from field: string description = 3;
+   */
+  description = "";
+
+  /**
+   * The interval at which to check the upstream for updates (in time+unit)
+   * Some plugins may, additionally, support other formats, for instance,
+   * a cron expression.
+   * e.g., "*\/10 * * * *" will be equivalent to "10m"
+   * Optional. Defaults to 10m if not specified
+   *
+   * @generated from field: string interval = 4;
+   */
+  interval = "";
+
+  /**
+   * TLS-specific parameters for connecting to a repository. Optional
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.PackageRepositoryTlsConfig tls_config = 5;
+   */
+  tlsConfig?: PackageRepositoryTlsConfig;
+
+  /**
+   * authentication parameters for connecting to a repository. Optional
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.PackageRepositoryAuth auth = 6;
+   */
+  auth?: PackageRepositoryAuth;
+
+  /**
+   * Custom data added by the plugin
+   * A plugin can define custom details for data which is not yet, or
+   * never will be specified in the core AddPackageRepositoryRequest
+   * fields. The use of an `Any` field means that each plugin can define
+   * the structure of this message as required, while still satisfying the
+   * core interface.
+   * See https://developers.google.com/protocol-buffers/docs/proto3#any
+   * Just for reference, some of the examples that have been chosen not to
+   * be part of the core API but rather plugin-specific details are:
+   *   direct-helm:
+   *      - image pull secrets
+   *      - list of oci repositories
+   *      - filter rules
+   *      - sync job pod template
+   *
+   * @generated from field: google.protobuf.Any custom_detail = 11;
+   */
+  customDetail?: Any;
+
+  constructor(data?: PartialMessage<UpdatePackageRepositoryRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.UpdatePackageRepositoryRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "package_repo_ref", kind: "message", T: PackageRepositoryReference },
+    { no: 2, name: "url", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "description", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "interval", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "tls_config", kind: "message", T: PackageRepositoryTlsConfig },
+    { no: 6, name: "auth", kind: "message", T: PackageRepositoryAuth },
+    { no: 11, name: "custom_detail", kind: "message", T: Any },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): UpdatePackageRepositoryRequest {
+    return new UpdatePackageRepositoryRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): UpdatePackageRepositoryRequest {
+    return new UpdatePackageRepositoryRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): UpdatePackageRepositoryRequest {
+    return new UpdatePackageRepositoryRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: UpdatePackageRepositoryRequest | PlainMessage<UpdatePackageRepositoryRequest> | undefined,
+    b: UpdatePackageRepositoryRequest | PlainMessage<UpdatePackageRepositoryRequest> | undefined,
+  ): boolean {
+    return proto3.util.equals(UpdatePackageRepositoryRequest, a, b);
+  }
+}
+
+/**
+ * DeletePackageRepositoryRequest
+ *
+ * Request for DeletePackageRepository
+ *
+ * @generated from message kubeappsapis.core.packages.v1.DeletePackageRepositoryRequest
+ */
+export class DeletePackageRepositoryRequest extends Message<DeletePackageRepositoryRequest> {
+  /**
+   * @generated from field: kubeappsapis.core.packages.v1.PackageRepositoryReference package_repo_ref = 1;
+   */
+  packageRepoRef?: PackageRepositoryReference;
+
+  /**
+   * Retain referenced secrets
+   *
+   * A package repository whose secrets managed by the plugin are still
+   * referenced by other objects, such as other package repositories or
+   * installed packages, is not deleted by default: the request fails with a
+   * FailedPrecondition error whose details list the PackageRepositorySecretUsage
+   * of each such secret. When set, the package repository is deleted while the
+   * secrets still referenced are retained, no longer owned by it.
+   *
+   * @generated from field: bool retain_referenced_secrets = 2;
+   */
+  retainReferencedSecrets = false;
+
+  constructor(data?: PartialMessage<DeletePackageRepositoryRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.DeletePackageRepositoryRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "package_repo_ref", kind: "message", T: PackageRepositoryReference },
+    { no: 2, name: "retain_referenced_secrets", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): DeletePackageRepositoryRequest {
+    return new DeletePackageRepositoryRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): DeletePackageRepositoryRequest {
+    return new DeletePackageRepositoryRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): DeletePackageRepositoryRequest {
+    return new DeletePackageRepositoryRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: DeletePackageRepositoryRequest | PlainMessage<DeletePackageRepositoryRequest> | undefined,
+    b: DeletePackageRepositoryRequest | PlainMessage<DeletePackageRepositoryRequest> | undefined,
+  ): boolean {
+    return proto3.util.equals(DeletePackageRepositoryRequest, a, b);
+  }
+}
+
+/**
+ * PackageRepositoryReference
+ *
+ * A PackageRepositoryReference has the minimum information required to
+ * uniquely identify a package repository.
+ *
+ * @generated from message kubeappsapis.core.packages.v1.PackageRepositoryReference
+ */
+export class PackageRepositoryReference extends Message<PackageRepositoryReference> {
+  /**
+   * The context (cluster/namespace) for the repository.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.Context context = 1;
+   */
+  context?: Context;
+
+  /**
+   * The fully qualified identifier for the repository
+   * (i.e. a unique name for the context).
+   *
+   * @generated from field: string identifier = 2;
+   */
+  identifier = "";
+
+  /**
+   * The plugin used to interact with this available package.
+   * This field should be omitted when the request is in the context of a
+   * specific plugin.
+   *
+   * @generated from field: kubeappsapis.core.plugins.v1alpha1.Plugin plugin = 3;
+   */
+  plugin?: Plugin;
+
+  constructor(data?: PartialMessage<PackageRepositoryReference>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.PackageRepositoryReference";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "context", kind: "message", T: Context },
+    { no: 2, name: "identifier", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "plugin", kind: "message", T: Plugin },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): PackageRepositoryReference {
+    return new PackageRepositoryReference().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): PackageRepositoryReference {
+    return new PackageRepositoryReference().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): PackageRepositoryReference {
+    return new PackageRepositoryReference().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: PackageRepositoryReference | PlainMessage<PackageRepositoryReference> | undefined,
+    b: PackageRepositoryReference | PlainMessage<PackageRepositoryReference> | undefined,
+  ): boolean {
+    return proto3.util.equals(PackageRepositoryReference, a, b);
+  }
+}
+
+/**
+ * AddPackageRepositoryResponse
+ *
+ * Response for AddPackageRepositoryRequest
+ *
+ * @generated from message kubeappsapis.core.packages.v1.AddPackageRepositoryResponse
+ */
+export class AddPackageRepositoryResponse extends Message<AddPackageRepositoryResponse> {
+  /**
+   * TODO: add example for API docs
+   * option (grpc.gateway.protoc_gen_openapiv2.options.openapiv2_schema) = {
+   *   example: '{"package_repo_ref": {}}'
+   * };
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.PackageRepositoryReference package_repo_ref = 1;
+   */
+  packageRepoRef?: PackageRepositoryReference;
+
+  constructor(data?: PartialMessage<AddPackageRepositoryResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.AddPackageRepositoryResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "package_repo_ref", kind: "message", T: PackageRepositoryReference },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): AddPackageRepositoryResponse {
+    return new AddPackageRepositoryResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): AddPackageRepositoryResponse {
+    return new AddPackageRepositoryResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): AddPackageRepositoryResponse {
+    return new AddPackageRepositoryResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: AddPackageRepositoryResponse | PlainMessage<AddPackageRepositoryResponse> | undefined,
+    b: AddPackageRepositoryResponse | PlainMessage<AddPackageRepositoryResponse> | undefined,
+  ): boolean {
+    return proto3.util.equals(AddPackageRepositoryResponse, a, b);
+  }
+}
+
+/**
+ * PackageRepositoryStatus
+ *
+ * A PackageRepositoryStatus reports on the current status of the repository.
+ *
+ * @generated from message kubeappsapis.core.packages.v1.PackageRepositoryStatus
+ */
+export class PackageRepositoryStatus extends Message<PackageRepositoryStatus> {
+  /**
+   * Ready
+   *
+   * An indication of whether the repository is ready or not
+   *
+   * @generated from field: bool ready = 1;
+   */
+  ready = false;
+
+  /**
+   * Reason
+   *
+   * An enum indicating the reason for the current status.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.PackageRepositoryStatus.StatusReason reason = 2;
+   */
+  reason = PackageRepositoryStatus_StatusReason.UNSPECIFIED;
+
+  /**
+   * UserReason
+   *
+   * Optional text to return for user context, which may be plugin specific.
+   *
+   * @generated from field: string user_reason = 3;
+   */
+  userReason = "";
+
+  /**
+   * Conditions
+   *
+   * Optional list of the conditions reported by the plugin for the repository,
+   * which may be plugin specific.
+   *
+   * @generated from field: repeated kubeappsapis.core.packages.v1.PackageRepositoryCondition conditions = 4;
+   */
+  conditions: PackageRepositoryCondition[] = [];
+
+  /**
+   * ConsecutiveFailures
+   *
+   * Optional number of consecutive failed attempts to sync the repository.
+   *
+   * @generated from field: int32 consecutive_failures = 5;
+   */
+  consecutiveFailures = 0;
+
+  /**
+   * NextRetryTime
+   *
+   * Optional time at which the next attempt to sync a failed repository is
+   * expected.
+   *
+   * @generated from field: google.protobuf.Timestamp next_retry_time = 6;
+   */
+  nextRetryTime?: Timestamp;
+
+  constructor(data?: PartialMessage<PackageRepositoryStatus>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.PackageRepositoryStatus";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "ready", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    {
+      no: 2,
+      name: "reason",
+      kind: "enum",
+      T: proto3.getEnumType(PackageRepositoryStatus_StatusReason),
+    },
+    { no: 3, name: "user_reason", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "conditions", kind: "message", T: PackageRepositoryCondition, repeated: true },
+    { no: 5, name: "consecutive_failures", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 6, name: "next_retry_time", kind: "message", T: Timestamp },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): PackageRepositoryStatus {
+    return new PackageRepositoryStatus().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): PackageRepositoryStatus {
+    return new PackageRepositoryStatus().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): PackageRepositoryStatus {
+    return new PackageRepositoryStatus().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: PackageRepositoryStatus | PlainMessage<PackageRepositoryStatus> | undefined,
+    b: PackageRepositoryStatus | PlainMessage<PackageRepositoryStatus> | undefined,
+  ): boolean {
+    return proto3.util.equals(PackageRepositoryStatus, a, b);
+  }
+}
+
+/**
+ * StatusReason
+ *
+ * Generic reasons why a package repository may be ready or not.
+ * These should make sense across different packaging plugins.
+ *
+ * @generated from enum kubeappsapis.core.packages.v1.PackageRepositoryStatus.StatusReason
+ */
+export enum PackageRepositoryStatus_StatusReason {
+  /**
+   * @generated from enum value: STATUS_REASON_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * @generated from enum value: STATUS_REASON_SUCCESS = 1;
+   */
+  SUCCESS = 1,
+
+  /**
+   * @generated from enum value: STATUS_REASON_FAILED = 2;
+   */
+  FAILED = 2,
+
+  /**
+   * @generated from enum value: STATUS_REASON_PENDING = 3;
+   */
+  PENDING = 3,
+}
+// Retrieve enum metadata with: proto3.getEnumType(PackageRepositoryStatus_StatusReason)
+proto3.util.setEnumType(
+  PackageRepositoryStatus_StatusReason,
+  "kubeappsapis.core.packages.v1.PackageRepositoryStatus.StatusReason",
+  [
+    { no: 0, name: "STATUS_REASON_UNSPECIFIED" },
+    { no: 1, name: "STATUS_REASON_SUCCESS" },
+    { no: 2, name: "STATUS_REASON_FAILED" },
+    { no: 3, name: "STATUS_REASON_PENDING" },
+  ],
+);
+
+/**
+ * PackageRepositoryCondition
+ *
+ * A PackageRepositoryCondition reports one of the conditions of the repository,
+ * such as the reason why its last sync failed.
+ *
+ * @generated from message kubeappsapis.core.packages.v1.PackageRepositoryCondition
+ */
+export class PackageRepositoryCondition extends Message<PackageRepositoryCondition> {
+  /**
+   * The type of the condition (e.g. ReconcileFailed)
+   *
+   * @generated from field: string type = 1;
+   */
+  type = "";
+
+  /**
+   * The status of the condition (e.g. True, False or Unknown)
+   *
+   * @generated from field: string status = 2;
+   */
+  status = "";
+
+  /**
+   * A machine-readable reason for the condition
+   *
+   * @generated from field: string reason = 3;
+   */
+  reason = "";
+
+  /**
+   * A human-readable message with details about the condition
+   *
+   * @generated from field: string message = 4;
+   */
+  message = "";
+
+  constructor(data?: PartialMessage<PackageRepositoryCondition>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.PackageRepositoryCondition";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "type", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "status", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "reason", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "message", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): PackageRepositoryCondition {
+    return new PackageRepositoryCondition().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): PackageRepositoryCondition {
+    return new PackageRepositoryCondition().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): PackageRepositoryCondition {
+    return new PackageRepositoryCondition().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: PackageRepositoryCondition | PlainMessage<PackageRepositoryCondition> | undefined,
+    b: PackageRepositoryCondition | PlainMessage<PackageRepositoryCondition> | undefined,
+  ): boolean {
+    return proto3.util.equals(PackageRepositoryCondition, a, b);
+  }
+}
+
+/**
+ * PackageRepositoryDetail
+ *
+ *
+ * @generated from message kubeappsapis.core.packages.v1.PackageRepositoryDetail
+ */
+export class PackageRepositoryDetail extends Message<PackageRepositoryDetail> {
+  /**
+   * A reference uniquely identifying the package repository.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.PackageRepositoryReference package_repo_ref = 1;
+   */
+  packageRepoRef?: PackageRepositoryReference;
+
+  /**
+   * A user-provided name for the package repository (e.g. bitnami)
+   *
+   * @generated from field: string name = 2;
+   */
+  name = "";
+
+  /**
+   * A user-provided description.
+   *
+   * @generated from field: string description = 3;
+   */
+  description = "";
+
+  /**
+   * Whether this repository is global or namespace-scoped.
+   *
+   * @generated from field: bool namespace_scoped = 4;
+   */
+  namespaceScoped = false;
+
+  /**
+   * Package storage type
+   *
+   * @generated from field: string type = 5;
+   */
+  type = "";
+
+  /**
+   * A URL identifying the package repository location.
+   *
+   * @generated from field: string url = 6;
+   */
+  url = "";
+
+  /**
+   * The interval at which to check the upstream for updates (in time+unit)
+   * Some plugins may, additionally, support other formats, for instance,
+   * a cron expression.
+   * e.g., "*\/10 * * * *" will be equivalent to "10m"
+   *
+   * @generated from field: string interval = 7;
+   */
+  interval = "";
+
+  /**
+   * TLS-specific parameters for connecting to a repository.
+   * If the cert authority was configured for this repository, then in the context
+   * of GetPackageRepositoryDetail() operation, the PackageRepositoryTlsConfig will ALWAYS
+   * contain an existing SecretKeyReference, rather that cert_authority field
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.PackageRepositoryTlsConfig tls_config = 8;
+   */
+  tlsConfig?: PackageRepositoryTlsConfig;
+
+  /**
+   * authentication parameters for connecting to a repository.
+   * If Basic Auth or TLS or Docker Creds Auth was configured for this repository,
+   * then in the context of GetPackageRepositoryDetail() operation, the
+   * PackageRepositoryAuth will ALWAYS contain an existing SecretKeyReference,
+   * rather that string values that may have been used when package repository was created
+   * field
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.PackageRepositoryAuth auth = 9;
+   */
+  auth?: PackageRepositoryAuth;
+
+  /**
+   * Custom data added by the plugin
+   *
+   * @generated from field: google.protobuf.Any custom_detail = 10;
+   */
+  customDetail?: Any;
+
+  /**
+   * current status of the repository which can include reconciliation
+   * status, where relevant.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.PackageRepositoryStatus status = 11;
+   */
+  status?: PackageRepositoryStatus;
+
+  constructor(data?: PartialMessage<PackageRepositoryDetail>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.PackageRepositoryDetail";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "package_repo_ref", kind: "message", T: PackageRepositoryReference },
+    { no: 2, name: "name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "description", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "namespace_scoped", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 5, name: "type", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 6, name: "url", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 7, name: "interval", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 8, name: "tls_config", kind: "message", T: PackageRepositoryTlsConfig },
+    { no: 9, name: "auth", kind: "message", T: PackageRepositoryAuth },
+    { no: 10, name: "custom_detail", kind: "message", T: Any },
+    { no: 11, name: "status", kind: "message", T: PackageRepositoryStatus },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): PackageRepositoryDetail {
+    return new PackageRepositoryDetail().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): PackageRepositoryDetail {
+    return new PackageRepositoryDetail().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): PackageRepositoryDetail {
+    return new PackageRepositoryDetail().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: PackageRepositoryDetail | PlainMessage<PackageRepositoryDetail> | undefined,
+    b: PackageRepositoryDetail | PlainMessage<PackageRepositoryDetail> | undefined,
+  ): boolean {
+    return proto3.util.equals(PackageRepositoryDetail, a, b);
+  }
+}
+
+/**
+ * GetPackageRepositoryDetailResponse
+ *
+ * Response for GetPackageRepositoryDetail
+ *
+ * @generated from message kubeappsapis.core.packages.v1.GetPackageRepositoryDetailResponse
+ */
+export class GetPackageRepositoryDetailResponse extends Message<GetPackageRepositoryDetailResponse> {
+  /**
+   * package repository detail
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.PackageRepositoryDetail detail = 1;
+   */
+  detail?: PackageRepositoryDetail;
+
+  constructor(data?: PartialMessage<GetPackageRepositoryDetailResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.GetPackageRepositoryDetailResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "detail", kind: "message", T: PackageRepositoryDetail },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): GetPackageRepositoryDetailResponse {
+    return new GetPackageRepositoryDetailResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): GetPackageRepositoryDetailResponse {
+    return new GetPackageRepositoryDetailResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetPackageRepositoryDetailResponse {
+    return new GetPackageRepositoryDetailResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | GetPackageRepositoryDetailResponse
+      | PlainMessage<GetPackageRepositoryDetailResponse>
+      | undefined,
+    b:
+      | GetPackageRepositoryDetailResponse
+      | PlainMessage<GetPackageRepositoryDetailResponse>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(GetPackageRepositoryDetailResponse, a, b);
+  }
+}
+
+/**
+ * PackageRepositorySummary
+ *
+ *
+ * @generated from message kubeappsapis.core.packages.v1.PackageRepositorySummary
+ */
+export class PackageRepositorySummary extends Message<PackageRepositorySummary> {
+  /**
+   * A reference uniquely identifying the package repository.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.PackageRepositoryReference package_repo_ref = 1;
+   */
+  packageRepoRef?: PackageRepositoryReference;
+
+  /**
+   * A user-provided name for the package repository (e.g. bitnami)
+   *
+   * @generated from field: string name = 2;
+   */
+  name = "";
+
+  /**
+   * A user-provided description.
+   *
+   * @generated from field: string description = 3;
+   */
+  description = "";
+
+  /**
+   * Whether this repository is global or namespace-scoped.
+   *
+   * @generated from field: bool namespace_scoped = 4;
+   */
+  namespaceScoped = false;
+
+  /**
+   * Package storage type
+   *
+   * @generated from field: string type = 5;
+   */
+  type = "";
+
+  /**
+   * URL identifying the package repository location.
+   *
+   * @generated from field: string url = 6;
+   */
+  url = "";
+
+  /**
+   * current status of the repository which can include reconciliation
+   * status, where relevant.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.PackageRepositoryStatus status = 7;
+   */
+  status?: PackageRepositoryStatus;
+
+  /**
+   * existence of any authentication parameters for connecting to a repository.
+   *
+   * @generated from field: bool requires_auth = 8;
+   */
+  requiresAuth = false;
+
+  constructor(data?: PartialMessage<PackageRepositorySummary>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.PackageRepositorySummary";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "package_repo_ref", kind: "message", T: PackageRepositoryReference },
+    { no: 2, name: "name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "description", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "namespace_scoped", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 5, name: "type", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 6, name: "url", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 7, name: "status", kind: "message", T: PackageRepositoryStatus },
+    { no: 8, name: "requires_auth", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): PackageRepositorySummary {
+    return new PackageRepositorySummary().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): PackageRepositorySummary {
+    return new PackageRepositorySummary().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): PackageRepositorySummary {
+    return new PackageRepositorySummary().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: PackageRepositorySummary | PlainMessage<PackageRepositorySummary> | undefined,
+    b: PackageRepositorySummary | PlainMessage<PackageRepositorySummary> | undefined,
+  ): boolean {
+    return proto3.util.equals(PackageRepositorySummary, a, b);
+  }
+}
+
+/**
+ * GetPackageRepositorySummariesResponse
+ *
+ * Response for GetPackageRepositorySummaries
+ *
+ * @generated from message kubeappsapis.core.packages.v1.GetPackageRepositorySummariesResponse
+ */
+export class GetPackageRepositorySummariesResponse extends Message<GetPackageRepositorySummariesResponse> {
+  /**
+   * List of PackageRepositorySummary
+   *
+   * @generated from field: repeated kubeappsapis.core.packages.v1.PackageRepositorySummary package_repository_summaries = 1;
+   */
+  packageRepositorySummaries: PackageRepositorySummary[] = [];
+
+  /**
+   * Partial results
+   *
+   * This optional field is set when the results do not include some of the
+   * requested namespaces, for example because the user cannot access them.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.PartialResults partial_results = 2;
+   */
+  partialResults?: PartialResults;
+
+  constructor(data?: PartialMessage<GetPackageRepositorySummariesResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.GetPackageRepositorySummariesResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    {
+      no: 1,
+      name: "package_repository_summaries",
+      kind: "message",
+      T: PackageRepositorySummary,
+      repeated: true,
+    },
+    { no: 2, name: "partial_results", kind: "message", T: PartialResults },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): GetPackageRepositorySummariesResponse {
+    return new GetPackageRepositorySummariesResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): GetPackageRepositorySummariesResponse {
+    return new GetPackageRepositorySummariesResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetPackageRepositorySummariesResponse {
+    return new GetPackageRepositorySummariesResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | GetPackageRepositorySummariesResponse
+      | PlainMessage<GetPackageRepositorySummariesResponse>
+      | undefined,
+    b:
+      | GetPackageRepositorySummariesResponse
+      | PlainMessage<GetPackageRepositorySummariesResponse>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(GetPackageRepositorySummariesResponse, a, b);
+  }
+}
+
+/**
+ * UpdatePackageRepositoryResponse
+ *
+ * Response for UpdatePackageRepository
+ *
+ * @generated from message kubeappsapis.core.packages.v1.UpdatePackageRepositoryResponse
+ */
+export class UpdatePackageRepositoryResponse extends Message<UpdatePackageRepositoryResponse> {
+  /**
+   * @generated from field: kubeappsapis.core.packages.v1.PackageRepositoryReference package_repo_ref = 1;
+   */
+  packageRepoRef?: PackageRepositoryReference;
+
+  constructor(data?: PartialMessage<UpdatePackageRepositoryResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.UpdatePackageRepositoryResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "package_repo_ref", kind: "message", T: PackageRepositoryReference },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): UpdatePackageRepositoryResponse {
+    return new UpdatePackageRepositoryResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): UpdatePackageRepositoryResponse {
+    return new UpdatePackageRepositoryResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): UpdatePackageRepositoryResponse {
+    return new UpdatePackageRepositoryResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: UpdatePackageRepositoryResponse | PlainMessage<UpdatePackageRepositoryResponse> | undefined,
+    b: UpdatePackageRepositoryResponse | PlainMessage<UpdatePackageRepositoryResponse> | undefined,
+  ): boolean {
+    return proto3.util.equals(UpdatePackageRepositoryResponse, a, b);
+  }
+}
+
+/**
+ * DeletePackageRepositoryResponse
+ *
+ * Response for DeletePackageRepository
+ *
+ * For future extensibility only.
+ *
+ * @generated from message kubeappsapis.core.packages.v1.DeletePackageRepositoryResponse
+ */
+export class DeletePackageRepositoryResponse extends Message<DeletePackageRepositoryResponse> {
+  /**
+   * Retained secrets
+   *
+   * The secrets managed by the plugin for the deleted package repository which
+   * were retained since other objects still reference them.
+   *
+   * @generated from field: repeated kubeappsapis.core.packages.v1.PackageRepositorySecretUsage retained_secrets = 1;
+   */
+  retainedSecrets: PackageRepositorySecretUsage[] = [];
+
+  constructor(data?: PartialMessage<DeletePackageRepositoryResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.DeletePackageRepositoryResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    {
+      no: 1,
+      name: "retained_secrets",
+      kind: "message",
+      T: PackageRepositorySecretUsage,
+      repeated: true,
+    },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): DeletePackageRepositoryResponse {
+    return new DeletePackageRepositoryResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): DeletePackageRepositoryResponse {
+    return new DeletePackageRepositoryResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): DeletePackageRepositoryResponse {
+    return new DeletePackageRepositoryResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: DeletePackageRepositoryResponse | PlainMessage<DeletePackageRepositoryResponse> | undefined,
+    b: DeletePackageRepositoryResponse | PlainMessage<DeletePackageRepositoryResponse> | undefined,
+  ): boolean {
+    return proto3.util.equals(DeletePackageRepositoryResponse, a, b);
+  }
+}
+
+/**
+ * PackageRepositorySecretUsage
+ *
+ * A secret managed by a plugin for a package repository, along with the other
+ * objects referencing it.
+ *
+ * @generated from message kubeappsapis.core.packages.v1.PackageRepositorySecretUsage
+ */
+export class PackageRepositorySecretUsage extends Message<PackageRepositorySecretUsage> {
+  /**
+   * The name of the secret, in the namespace of the package repository.
+   *
+   * @generated from field: string secret_name = 1;
+   */
+  secretName = "";
+
+  /**
+   * The objects, other than the package repository, referencing the secret.
+   *
+   * @generated from field: repeated kubeappsapis.core.packages.v1.ResourceRef referencing_resources = 2;
+   */
+  referencingResources: ResourceRef[] = [];
+
+  constructor(data?: PartialMessage<PackageRepositorySecretUsage>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.PackageRepositorySecretUsage";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "secret_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "referencing_resources", kind: "message", T: ResourceRef, repeated: true },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): PackageRepositorySecretUsage {
+    return new PackageRepositorySecretUsage().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): PackageRepositorySecretUsage {
+    return new PackageRepositorySecretUsage().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): PackageRepositorySecretUsage {
+    return new PackageRepositorySecretUsage().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: PackageRepositorySecretUsage | PlainMessage<PackageRepositorySecretUsage> | undefined,
+    b: PackageRepositorySecretUsage | PlainMessage<PackageRepositorySecretUsage> | undefined,
+  ): boolean {
+    return proto3.util.equals(PackageRepositorySecretUsage, a, b);
+  }
+}
+
+/**
+ * @generated from message kubeappsapis.core.packages.v1.GetPackageRepositoryPermissionsRequest
+ */
+export class GetPackageRepositoryPermissionsRequest extends Message<GetPackageRepositoryPermissionsRequest> {
+  /**
+   * The context (cluster/namespace) for the request
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.Context context = 1;
+   */
+  context?: Context;
+
+  constructor(data?: PartialMessage<GetPackageRepositoryPermissionsRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.GetPackageRepositoryPermissionsRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "context", kind: "message", T: Context },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): GetPackageRepositoryPermissionsRequest {
+    return new GetPackageRepositoryPermissionsRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): GetPackageRepositoryPermissionsRequest {
+    return new GetPackageRepositoryPermissionsRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetPackageRepositoryPermissionsRequest {
+    return new GetPackageRepositoryPermissionsRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | GetPackageRepositoryPermissionsRequest
+      | PlainMessage<GetPackageRepositoryPermissionsRequest>
+      | undefined,
+    b:
+      | GetPackageRepositoryPermissionsRequest
+      | PlainMessage<GetPackageRepositoryPermissionsRequest>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(GetPackageRepositoryPermissionsRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message kubeappsapis.core.packages.v1.PackageRepositoriesPermissions
+ */
+export class PackageRepositoriesPermissions extends Message<PackageRepositoriesPermissions> {
+  /**
+   * @generated from field: kubeappsapis.core.plugins.v1alpha1.Plugin plugin = 1;
+   */
+  plugin?: Plugin;
+
+  /**
+   * Permissions at the global namespace (if any)
+   *
+   * @generated from field: map<string, bool> global = 2;
+   */
+  global: { [key: string]: boolean } = {};
+
+  /**
+   * Permissions for the namespace
+   *
+   * @generated from field: map<string, bool> namespace = 3;
+   */
+  namespace: { [key: string]: boolean } = {};
+
+  constructor(data?: PartialMessage<PackageRepositoriesPermissions>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.PackageRepositoriesPermissions";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "plugin", kind: "message", T: Plugin },
+    {
+      no: 2,
+      name: "global",
+      kind: "map",
+      K: 9 /* ScalarType.STRING */,
+      V: { kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    },
+    {
+      no: 3,
+      name: "namespace",
+      kind: "map",
+      K: 9 /* ScalarType.STRING */,
+      V: { kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): PackageRepositoriesPermissions {
+    return new PackageRepositoriesPermissions().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): PackageRepositoriesPermissions {
+    return new PackageRepositoriesPermissions().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): PackageRepositoriesPermissions {
+    return new PackageRepositoriesPermissions().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: PackageRepositoriesPermissions | PlainMessage<PackageRepositoriesPermissions> | undefined,
+    b: PackageRepositoriesPermissions | PlainMessage<PackageRepositoriesPermissions> | undefined,
+  ): boolean {
+    return proto3.util.equals(PackageRepositoriesPermissions, a, b);
+  }
+}
+
+/**
+ * @generated from message kubeappsapis.core.packages.v1.GetPackageRepositoryPermissionsResponse
+ */
+export class GetPackageRepositoryPermissionsResponse extends Message<GetPackageRepositoryPermissionsResponse> {
+  /**
+   * @generated from field: repeated kubeappsapis.core.packages.v1.PackageRepositoriesPermissions permissions = 1;
+   */
+  permissions: PackageRepositoriesPermissions[] = [];
+
+  constructor(data?: PartialMessage<GetPackageRepositoryPermissionsResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName =
+    "kubeappsapis.core.packages.v1.GetPackageRepositoryPermissionsResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    {
+      no: 1,
+      name: "permissions",
+      kind: "message",
+      T: PackageRepositoriesPermissions,
+      repeated: true,
+    },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): GetPackageRepositoryPermissionsResponse {
+    return new GetPackageRepositoryPermissionsResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): GetPackageRepositoryPermissionsResponse {
+    return new GetPackageRepositoryPermissionsResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetPackageRepositoryPermissionsResponse {
+    return new GetPackageRepositoryPermissionsResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | GetPackageRepositoryPermissionsResponse
+      | PlainMessage<GetPackageRepositoryPermissionsResponse>
+      | undefined,
+    b:
+      | GetPackageRepositoryPermissionsResponse
+      | PlainMessage<GetPackageRepositoryPermissionsResponse>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(GetPackageRepositoryPermissionsResponse, a, b);
+  }
+}
+
+/**
+ * TestPackageRepositoryConnectionRequest
+ *
+ * Request for TestPackageRepositoryConnection
+ *
+ * @generated from message kubeappsapis.core.packages.v1.TestPackageRepositoryConnectionRequest
+ */
+export class TestPackageRepositoryConnectionRequest extends Message<TestPackageRepositoryConnectionRequest> {
+  /**
+   * The package repository to test, with the same details that would be
+   * used to add it. Nothing is created when testing the connection.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.AddPackageRepositoryRequest package_repository = 1;
+   */
+  packageRepository?: AddPackageRepositoryRequest;
+
+  constructor(data?: PartialMessage<TestPackageRepositoryConnectionRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.TestPackageRepositoryConnectionRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "package_repository", kind: "message", T: AddPackageRepositoryRequest },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): TestPackageRepositoryConnectionRequest {
+    return new TestPackageRepositoryConnectionRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): TestPackageRepositoryConnectionRequest {
+    return new TestPackageRepositoryConnectionRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): TestPackageRepositoryConnectionRequest {
+    return new TestPackageRepositoryConnectionRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | TestPackageRepositoryConnectionRequest
+      | PlainMessage<TestPackageRepositoryConnectionRequest>
+      | undefined,
+    b:
+      | TestPackageRepositoryConnectionRequest
+      | PlainMessage<TestPackageRepositoryConnectionRequest>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(TestPackageRepositoryConnectionRequest, a, b);
+  }
+}
+
+/**
+ * TestPackageRepositoryConnectionResponse
+ *
+ * Response for TestPackageRepositoryConnection. A repository which cannot be
+ * reached, or which rejects the provided credentials, results in an error
+ * rather than a response.
+ *
+ * @generated from message kubeappsapis.core.packages.v1.TestPackageRepositoryConnectionResponse
+ */
+export class TestPackageRepositoryConnectionResponse extends Message<TestPackageRepositoryConnectionResponse> {
+  /**
+   * A human-readable summary of the successful connection
+   *
+   * @generated from field: string message = 1;
+   */
+  message = "";
+
+  /**
+   * The number of packages discovered in the repository, only set when the
+   * plugin can determine it without fetching the whole repository
+   *
+   * @generated from field: optional int32 package_count = 2;
+   */
+  packageCount?: number;
+
+  /**
+   * Other metadata discovered when connecting to the repository, such as
+   * the digest of an imgpkg bundle or the revision of a git reference
+   *
+   * @generated from field: map<string, string> metadata = 3;
+   */
+  metadata: { [key: string]: string } = {};
+
+  constructor(data?: PartialMessage<TestPackageRepositoryConnectionResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName =
+    "kubeappsapis.core.packages.v1.TestPackageRepositoryConnectionResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "message", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "package_count", kind: "scalar", T: 5 /* ScalarType.INT32 */, opt: true },
+    {
+      no: 3,
+      name: "metadata",
+      kind: "map",
+      K: 9 /* ScalarType.STRING */,
+      V: { kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): TestPackageRepositoryConnectionResponse {
+    return new TestPackageRepositoryConnectionResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): TestPackageRepositoryConnectionResponse {
+    return new TestPackageRepositoryConnectionResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): TestPackageRepositoryConnectionResponse {
+    return new TestPackageRepositoryConnectionResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | TestPackageRepositoryConnectionResponse
+      | PlainMessage<TestPackageRepositoryConnectionResponse>
+      | undefined,
+    b:
+      | TestPackageRepositoryConnectionResponse
+      | PlainMessage<TestPackageRepositoryConnectionResponse>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(TestPackageRepositoryConnectionResponse, a, b);
+  }
+}
+
+/**
+ * ListPackageRepositoryPackagesRequest
+ *
+ * Request for ListPackageRepositoryPackages
+ *
+ * @generated from message kubeappsapis.core.packages.v1.ListPackageRepositoryPackagesRequest
+ */
+export class ListPackageRepositoryPackagesRequest extends Message<ListPackageRepositoryPackagesRequest> {
+  /**
+   * The package repository whose packages are listed
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.PackageRepositoryReference package_repo_ref = 1;
+   */
+  packageRepoRef?: PackageRepositoryReference;
+
+  /**
+   * Pagination options specifying where to start and how many results to include.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.PaginationOptions pagination_options = 2;
+   */
+  paginationOptions?: PaginationOptions;
+
+  constructor(data?: PartialMessage<ListPackageRepositoryPackagesRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName =
+    "kubeappsapis.core.packages.v1.ListPackageRepositoryPackagesRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "package_repo_ref", kind: "message", T: PackageRepositoryReference },
+    { no: 2, name: "pagination_options", kind: "message", T: PaginationOptions },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): ListPackageRepositoryPackagesRequest {
+    return new ListPackageRepositoryPackagesRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): ListPackageRepositoryPackagesRequest {
+    return new ListPackageRepositoryPackagesRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): ListPackageRepositoryPackagesRequest {
+    return new ListPackageRepositoryPackagesRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | ListPackageRepositoryPackagesRequest
+      | PlainMessage<ListPackageRepositoryPackagesRequest>
+      | undefined,
+    b:
+      | ListPackageRepositoryPackagesRequest
+      | PlainMessage<ListPackageRepositoryPackagesRequest>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(ListPackageRepositoryPackagesRequest, a, b);
+  }
+}
+
+/**
+ * ListPackageRepositoryPackagesResponse
+ *
+ * Response for ListPackageRepositoryPackages
+ *
+ * @generated from message kubeappsapis.core.packages.v1.ListPackageRepositoryPackagesResponse
+ */
+export class ListPackageRepositoryPackagesResponse extends Message<ListPackageRepositoryPackagesResponse> {
+  /**
+   * Available packages summaries
+   *
+   * The available packages contributed by the package repository.
+   *
+   * @generated from field: repeated kubeappsapis.core.packages.v1.AvailablePackageSummary available_package_summaries = 1;
+   */
+  availablePackageSummaries: AvailablePackageSummary[] = [];
+
+  /**
+   * Next page token
+   *
+   * This field represents the pagination token to retrieve the next page of
+   * results. If the value is "", it means no further results for the request.
+   *
+   * @generated from field: string next_page_token = 2;
+   */
+  nextPageToken = "";
+
+  /**
+   * Total count
+   *
+   * This optional field contains the total number of packages contributed by
+   * the repository, across every page. It is only set when the plugin can
+   * determine it.
+   *
+   * @generated from field: optional int32 total_count = 3;
+   */
+  totalCount?: number;
+
+  /**
+   * Last updated
+   *
+   * The time, in RFC 3339 format, at which the packages of the repository were
+   * last fetched, if known.
+   *
+   * @generated from field: string last_updated = 4;
+   */
+  lastUpdated = "";
+
+  constructor(data?: PartialMessage<ListPackageRepositoryPackagesResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName =
+    "kubeappsapis.core.packages.v1.ListPackageRepositoryPackagesResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    {
+      no: 1,
+      name: "available_package_summaries",
+      kind: "message",
+      T: AvailablePackageSummary,
+      repeated: true,
+    },
+    { no: 2, name: "next_page_token", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "total_count", kind: "scalar", T: 5 /* ScalarType.INT32 */, opt: true },
+    { no: 4, name: "last_updated", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): ListPackageRepositoryPackagesResponse {
+    return new ListPackageRepositoryPackagesResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): ListPackageRepositoryPackagesResponse {
+    return new ListPackageRepositoryPackagesResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): ListPackageRepositoryPackagesResponse {
+    return new ListPackageRepositoryPackagesResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | ListPackageRepositoryPackagesResponse
+      | PlainMessage<ListPackageRepositoryPackagesResponse>
+      | undefined,
+    b:
+      | ListPackageRepositoryPackagesResponse
+      | PlainMessage<ListPackageRepositoryPackagesResponse>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(ListPackageRepositoryPackagesResponse, a, b);
+  }
+}
+
+/**
+ * ExportPackageRepositoriesRequest
+ *
+ * Request for ExportPackageRepositories
+ *
+ * @generated from message kubeappsapis.core.packages.v1.ExportPackageRepositoriesRequest
+ */
+export class ExportPackageRepositoriesRequest extends Message<ExportPackageRepositoriesRequest> {
+  /**
+   * The context (cluster/namespace) of the exported package repositories.
+   * Every accessible namespace is exported when the namespace is empty.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.Context context = 1;
+   */
+  context?: Context;
+
+  constructor(data?: PartialMessage<ExportPackageRepositoriesRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.ExportPackageRepositoriesRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "context", kind: "message", T: Context },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): ExportPackageRepositoriesRequest {
+    return new ExportPackageRepositoriesRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): ExportPackageRepositoriesRequest {
+    return new ExportPackageRepositoriesRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): ExportPackageRepositoriesRequest {
+    return new ExportPackageRepositoriesRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | ExportPackageRepositoriesRequest
+      | PlainMessage<ExportPackageRepositoriesRequest>
+      | undefined,
+    b:
+      | ExportPackageRepositoriesRequest
+      | PlainMessage<ExportPackageRepositoriesRequest>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(ExportPackageRepositoriesRequest, a, b);
+  }
+}
+
+/**
+ * ExportPackageRepositoriesResponse
+ *
+ * Response for ExportPackageRepositories
+ *
+ * @generated from message kubeappsapis.core.packages.v1.ExportPackageRepositoriesResponse
+ */
+export class ExportPackageRepositoriesResponse extends Message<ExportPackageRepositoriesResponse> {
+  /**
+   * Bundle
+   *
+   * The package repositories, as a YAML document whose repositories are in
+   * the format of an AddPackageRepositoryRequest without cluster. Credentials
+   * are never exported: the plugins return them redacted or as references to
+   * existing secrets.
+   *
+   * @generated from field: string bundle = 1;
+   */
+  bundle = "";
+
+  constructor(data?: PartialMessage<ExportPackageRepositoriesResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.ExportPackageRepositoriesResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "bundle", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): ExportPackageRepositoriesResponse {
+    return new ExportPackageRepositoriesResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): ExportPackageRepositoriesResponse {
+    return new ExportPackageRepositoriesResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): ExportPackageRepositoriesResponse {
+    return new ExportPackageRepositoriesResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | ExportPackageRepositoriesResponse
+      | PlainMessage<ExportPackageRepositoriesResponse>
+      | undefined,
+    b:
+      | ExportPackageRepositoriesResponse
+      | PlainMessage<ExportPackageRepositoriesResponse>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(ExportPackageRepositoriesResponse, a, b);
+  }
+}
+
+/**
+ * ImportPackageRepositoriesRequest
+ *
+ * Request for ImportPackageRepositories
+ *
+ * @generated from message kubeappsapis.core.packages.v1.ImportPackageRepositoriesRequest
+ */
+export class ImportPackageRepositoriesRequest extends Message<ImportPackageRepositoriesRequest> {
+  /**
+   * The context (cluster/namespace) in which the bundle is imported. When the
+   * namespace is set, every package repository of the bundle must belong to it.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.Context context = 1;
+   */
+  context?: Context;
+
+  /**
+   * The bundle, as returned by ExportPackageRepositories. The redacted
+   * credentials of a package repository keep those of the existing package
+   * repository, so they must be replaced with the actual credentials to create it.
+   *
+   * @generated from field: string bundle = 2;
+   */
+  bundle = "";
+
+  /**
+   * Whether the package repositories of the context which are not in the
+   * bundle are deleted. Optional.
+   *
+   * @generated from field: bool prune = 3;
+   */
+  prune = false;
+
+  constructor(data?: PartialMessage<ImportPackageRepositoriesRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.ImportPackageRepositoriesRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "context", kind: "message", T: Context },
+    { no: 2, name: "bundle", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "prune", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): ImportPackageRepositoriesRequest {
+    return new ImportPackageRepositoriesRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): ImportPackageRepositoriesRequest {
+    return new ImportPackageRepositoriesRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): ImportPackageRepositoriesRequest {
+    return new ImportPackageRepositoriesRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | ImportPackageRepositoriesRequest
+      | PlainMessage<ImportPackageRepositoriesRequest>
+      | undefined,
+    b:
+      | ImportPackageRepositoriesRequest
+      | PlainMessage<ImportPackageRepositoriesRequest>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(ImportPackageRepositoriesRequest, a, b);
+  }
+}
+
+/**
+ * ImportPackageRepositoriesResponse
+ *
+ * Response for ImportPackageRepositories
+ *
+ * @generated from message kubeappsapis.core.packages.v1.ImportPackageRepositoriesResponse
+ */
+export class ImportPackageRepositoriesResponse extends Message<ImportPackageRepositoriesResponse> {
+  /**
+   * The package repositories of the bundle which were created.
+   *
+   * @generated from field: repeated kubeappsapis.core.packages.v1.PackageRepositoryReference created = 1;
+   */
+  created: PackageRepositoryReference[] = [];
+
+  /**
+   * The package repositories of the bundle which were updated.
+   *
+   * @generated from field: repeated kubeappsapis.core.packages.v1.PackageRepositoryReference updated = 2;
+   */
+  updated: PackageRepositoryReference[] = [];
+
+  /**
+   * The package repositories of the bundle which were already up to date.
+   *
+   * @generated from field: repeated kubeappsapis.core.packages.v1.PackageRepositoryReference unchanged = 3;
+   */
+  unchanged: PackageRepositoryReference[] = [];
+
+  /**
+   * The package repositories not in the bundle which were deleted, only when
+   * pruning.
+   *
+   * @generated from field: repeated kubeappsapis.core.packages.v1.PackageRepositoryReference deleted = 4;
+   */
+  deleted: PackageRepositoryReference[] = [];
+
+  constructor(data?: PartialMessage<ImportPackageRepositoriesResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1.ImportPackageRepositoriesResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "created", kind: "message", T: PackageRepositoryReference, repeated: true },
+    { no: 2, name: "updated", kind: "message", T: PackageRepositoryReference, repeated: true },
+    { no: 3, name: "unchanged", kind: "message", T: PackageRepositoryReference, repeated: true },
+    { no: 4, name: "deleted", kind: "message", T: PackageRepositoryReference, repeated: true },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): ImportPackageRepositoriesResponse {
+    return new ImportPackageRepositoriesResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): ImportPackageRepositoriesResponse {
+    return new ImportPackageRepositoriesResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): ImportPackageRepositoriesResponse {
+    return new ImportPackageRepositoriesResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | ImportPackageRepositoriesResponse
+      | PlainMessage<ImportPackageRepositoriesResponse>
+      | undefined,
+    b:
+      | ImportPackageRepositoriesResponse
+      | PlainMessage<ImportPackageRepositoriesResponse>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(ImportPackageRepositoriesResponse, a, b);
+  }
+}
@@ -7,18 +7,28 @@
 // @ts-nocheck
 
 import {
+  CheckInstalledPackageNameAvailabilityRequest,
+  CheckInstalledPackageNameAvailabilityResponse,
   CreateInstalledPackageRequest,
   CreateInstalledPackageResponse,
   DeleteInstalledPackageRequest,
   DeleteInstalledPackageResponse,
+  GetAvailablePackageChangelogRequest,
+  GetAvailablePackageChangelogResponse,
   GetAvailablePackageDetailRequest,
   GetAvailablePackageDetailResponse,
+  GetAvailablePackageFiltersRequest,
+  GetAvailablePackageFiltersResponse,
   GetAvailablePackageSummariesRequest,
   GetAvailablePackageSummariesResponse,
+  GetAvailablePackageTextAssetRequest,
+  GetAvailablePackageTextAssetResponse,
   GetAvailablePackageVersionsRequest,
   GetAvailablePackageVersionsResponse,
   GetInstalledPackageDetailRequest,
   GetInstalledPackageDetailResponse,
+  GetInstalledPackageOperationStatusRequest,
+  GetInstalledPackageOperationStatusResponse,
   GetInstalledPackageResourceRefsRequest,
   GetInstalledPackageResourceRefsResponse,
   GetInstalledPackageSummariesRequest,
@@ -29,7 +39,9 @@ import {
 import { MethodKind } from "@bufbuild/protobuf";
 
 /**
- * Each packages v1alpha1 plugin must implement at least the following rpcs:
+ * Each packages v1alpha1 plugin must implement at least the following rpcs.
+ * Clients should use the stable kubeappsapis.core.packages.v1.PackagesService
+ * instead, the core one being deprecated and kept for compatibility only:
  *
  *
  * @generated from service kubeappsapis.core.packages.v1alpha1.PackagesService
@@ -64,6 +76,37 @@ export const PackagesService = {
       O: GetAvailablePackageVersionsResponse,
       kind: MethodKind.Unary,
     },
+    /**
+     * @generated from rpc kubeappsapis.core.packages.v1alpha1.PackagesService.GetAvailablePackageTextAsset
+     */
+    getAvailablePackageTextAsset: {
+      name: "GetAvailablePackageTextAsset",
+      I: GetAvailablePackageTextAssetRequest,
+      O: GetAvailablePackageTextAssetResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc kubeappsapis.core.packages.v1alpha1.PackagesService.GetAvailablePackageChangelog
+     */
+    getAvailablePackageChangelog: {
+      name: "GetAvailablePackageChangelog",
+      I: GetAvailablePackageChangelogRequest,
+      O: GetAvailablePackageChangelogResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * GetAvailablePackageFilters returns the distinct values, across every
+     * configured plugin, that can be used in the FilterOptions of the available
+     * packages, such as their categories and providers.
+     *
+     * @generated from rpc kubeappsapis.core.packages.v1alpha1.PackagesService.GetAvailablePackageFilters
+     */
+    getAvailablePackageFilters: {
+      name: "GetAvailablePackageFilters",
+      I: GetAvailablePackageFiltersRequest,
+      O: GetAvailablePackageFiltersResponse,
+      kind: MethodKind.Unary,
+    },
     /**
      * @generated from rpc kubeappsapis.core.packages.v1alpha1.PackagesService.GetInstalledPackageSummaries
      */
@@ -82,6 +125,18 @@ export const PackagesService = {
       O: GetInstalledPackageDetailResponse,
       kind: MethodKind.Unary,
     },
+    /**
+     * CheckInstalledPackageNameAvailability checks, with every configured plugin,
+     * whether a package can be installed with the given name in the target namespace.
+     *
+     * @generated from rpc kubeappsapis.core.packages.v1alpha1.PackagesService.CheckInstalledPackageNameAvailability
+     */
+    checkInstalledPackageNameAvailability: {
+      name: "CheckInstalledPackageNameAvailability",
+      I: CheckInstalledPackageNameAvailabilityRequest,
+      O: CheckInstalledPackageNameAvailabilityResponse,
+      kind: MethodKind.Unary,
+    },
     /**
      * @generated from rpc kubeappsapis.core.packages.v1alpha1.PackagesService.CreateInstalledPackage
      */
@@ -118,5 +173,18 @@ export const PackagesService = {
       O: GetInstalledPackageResourceRefsResponse,
       kind: MethodKind.Unary,
     },
+    /**
+     * GetInstalledPackageOperationStatus returns the progress of the operation
+     * in course on an installed package, such as its installation, so that
+     * clients can follow it once CreateInstalledPackage returns.
+     *
+     * @generated from rpc kubeappsapis.core.packages.v1alpha1.PackagesService.GetInstalledPackageOperationStatus
+     */
+    getInstalledPackageOperationStatus: {
+      name: "GetInstalledPackageOperationStatus",
+      I: GetInstalledPackageOperationStatusRequest,
+      O: GetInstalledPackageOperationStatusResponse,
+      kind: MethodKind.Unary,
+    },
   },
 } as const;
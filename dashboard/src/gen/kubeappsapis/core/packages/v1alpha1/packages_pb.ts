@@ -14,9 +14,45 @@ import type {
   PartialMessage,
   PlainMessage,
 } from "@bufbuild/protobuf";
-import { Any, Message, proto3 } from "@bufbuild/protobuf";
+import { Any, Message, proto3, protoInt64 } from "@bufbuild/protobuf";
 import { Plugin } from "../../plugins/v1alpha1/plugins_pb";
 
+/**
+ * Text asset
+ *
+ * The text assets of an available package which may be retrieved in ranges.
+ *
+ * @generated from enum kubeappsapis.core.packages.v1alpha1.TextAsset
+ */
+export enum TextAsset {
+  /**
+   * @generated from enum value: TEXT_ASSET_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * @generated from enum value: TEXT_ASSET_README = 1;
+   */
+  README = 1,
+
+  /**
+   * @generated from enum value: TEXT_ASSET_DEFAULT_VALUES = 2;
+   */
+  DEFAULT_VALUES = 2,
+
+  /**
+   * @generated from enum value: TEXT_ASSET_VALUES_SCHEMA = 3;
+   */
+  VALUES_SCHEMA = 3,
+}
+// Retrieve enum metadata with: proto3.getEnumType(TextAsset)
+proto3.util.setEnumType(TextAsset, "kubeappsapis.core.packages.v1alpha1.TextAsset", [
+  { no: 0, name: "TEXT_ASSET_UNSPECIFIED" },
+  { no: 1, name: "TEXT_ASSET_README" },
+  { no: 2, name: "TEXT_ASSET_DEFAULT_VALUES" },
+  { no: 3, name: "TEXT_ASSET_VALUES_SCHEMA" },
+]);
+
 /**
  * GetAvailablePackageSummariesRequest
  *
@@ -46,6 +82,17 @@ export class GetAvailablePackageSummariesRequest extends Message<GetAvailablePac
    */
   paginationOptions?: PaginationOptions;
 
+  /**
+   * Include icons
+   *
+   * Whether to include the icons inlined in the summaries as data URLs, which
+   * can make the response several MB big. Icons referenced by an http(s) URL
+   * are always included. Defaults to true when unset.
+   *
+   * @generated from field: optional bool include_icons = 4;
+   */
+  includeIcons?: boolean;
+
   constructor(data?: PartialMessage<GetAvailablePackageSummariesRequest>) {
     super();
     proto3.util.initPartial(data, this);
@@ -58,6 +105,7 @@ export class GetAvailablePackageSummariesRequest extends Message<GetAvailablePac
     { no: 1, name: "context", kind: "message", T: Context },
     { no: 2, name: "filter_options", kind: "message", T: FilterOptions },
     { no: 3, name: "pagination_options", kind: "message", T: PaginationOptions },
+    { no: 4, name: "include_icons", kind: "scalar", T: 8 /* ScalarType.BOOL */, opt: true },
   ]);
 
   static fromBinary(
@@ -95,6 +143,68 @@ export class GetAvailablePackageSummariesRequest extends Message<GetAvailablePac
   }
 }
 
+/**
+ * GetAvailablePackageFiltersRequest
+ *
+ * Request for GetAvailablePackageFilters
+ *
+ * @generated from message kubeappsapis.core.packages.v1alpha1.GetAvailablePackageFiltersRequest
+ */
+export class GetAvailablePackageFiltersRequest extends Message<GetAvailablePackageFiltersRequest> {
+  /**
+   * The context (cluster/namespace) for the request
+   *
+   * @generated from field: kubeappsapis.core.packages.v1alpha1.Context context = 1;
+   */
+  context?: Context;
+
+  constructor(data?: PartialMessage<GetAvailablePackageFiltersRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName =
+    "kubeappsapis.core.packages.v1alpha1.GetAvailablePackageFiltersRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "context", kind: "message", T: Context },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): GetAvailablePackageFiltersRequest {
+    return new GetAvailablePackageFiltersRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): GetAvailablePackageFiltersRequest {
+    return new GetAvailablePackageFiltersRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetAvailablePackageFiltersRequest {
+    return new GetAvailablePackageFiltersRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | GetAvailablePackageFiltersRequest
+      | PlainMessage<GetAvailablePackageFiltersRequest>
+      | undefined,
+    b:
+      | GetAvailablePackageFiltersRequest
+      | PlainMessage<GetAvailablePackageFiltersRequest>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(GetAvailablePackageFiltersRequest, a, b);
+  }
+}
+
 /**
  * GetAvailablePackageDetailRequest
  *
@@ -193,6 +303,24 @@ export class GetAvailablePackageVersionsRequest extends Message<GetAvailablePack
    */
   pkgVersion = "";
 
+  /**
+   * Optional version, usually the installed version, relative to which the
+   * upgrade impact of each returned version is classified. By default, the
+   * upgrade impact is not set.
+   *
+   * @generated from field: string relative_to_version = 3;
+   */
+  relativeToVersion = "";
+
+  /**
+   * Optional semver constraint, such as ">=1.2.0 <2.0.0", which the returned
+   * versions satisfy. By default, all the versions are returned. Plugins can
+   * choose not to implement this, in which case all the versions are returned.
+   *
+   * @generated from field: string constraint = 4;
+   */
+  constraint = "";
+
   constructor(data?: PartialMessage<GetAvailablePackageVersionsRequest>) {
     super();
     proto3.util.initPartial(data, this);
@@ -204,6 +332,8 @@ export class GetAvailablePackageVersionsRequest extends Message<GetAvailablePack
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
     { no: 1, name: "available_package_ref", kind: "message", T: AvailablePackageReference },
     { no: 2, name: "pkg_version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "relative_to_version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "constraint", kind: "scalar", T: 9 /* ScalarType.STRING */ },
   ]);
 
   static fromBinary(
@@ -241,6 +371,187 @@ export class GetAvailablePackageVersionsRequest extends Message<GetAvailablePack
   }
 }
 
+/**
+ * GetAvailablePackageTextAssetRequest
+ *
+ * Request for GetAvailablePackageTextAsset
+ *
+ * @generated from message kubeappsapis.core.packages.v1alpha1.GetAvailablePackageTextAssetRequest
+ */
+export class GetAvailablePackageTextAssetRequest extends Message<GetAvailablePackageTextAssetRequest> {
+  /**
+   * The information required to uniquely
+   * identify an available package
+   *
+   * @generated from field: kubeappsapis.core.packages.v1alpha1.AvailablePackageReference available_package_ref = 1;
+   */
+  availablePackageRef?: AvailablePackageReference;
+
+  /**
+   * Optional specific version (or version reference) to request.
+   * By default the latest version (or latest version matching the reference)
+   * will be used.
+   *
+   * @generated from field: string pkg_version = 2;
+   */
+  pkgVersion = "";
+
+  /**
+   * The text asset of the package to be retrieved.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1alpha1.TextAsset asset = 3;
+   */
+  asset = TextAsset.UNSPECIFIED;
+
+  /**
+   * The offset, in bytes, from which the text asset is retrieved.
+   *
+   * @generated from field: int64 offset = 4;
+   */
+  offset = protoInt64.zero;
+
+  /**
+   * The maximum number of bytes to be retrieved. When not set (or too big),
+   * a server-side default limit is used.
+   *
+   * @generated from field: int64 limit = 5;
+   */
+  limit = protoInt64.zero;
+
+  constructor(data?: PartialMessage<GetAvailablePackageTextAssetRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName =
+    "kubeappsapis.core.packages.v1alpha1.GetAvailablePackageTextAssetRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "available_package_ref", kind: "message", T: AvailablePackageReference },
+    { no: 2, name: "pkg_version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "asset", kind: "enum", T: proto3.getEnumType(TextAsset) },
+    { no: 4, name: "offset", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 5, name: "limit", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): GetAvailablePackageTextAssetRequest {
+    return new GetAvailablePackageTextAssetRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): GetAvailablePackageTextAssetRequest {
+    return new GetAvailablePackageTextAssetRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetAvailablePackageTextAssetRequest {
+    return new GetAvailablePackageTextAssetRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | GetAvailablePackageTextAssetRequest
+      | PlainMessage<GetAvailablePackageTextAssetRequest>
+      | undefined,
+    b:
+      | GetAvailablePackageTextAssetRequest
+      | PlainMessage<GetAvailablePackageTextAssetRequest>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(GetAvailablePackageTextAssetRequest, a, b);
+  }
+}
+
+/**
+ * GetAvailablePackageChangelogRequest
+ *
+ * Request for GetAvailablePackageChangelog
+ *
+ * @generated from message kubeappsapis.core.packages.v1alpha1.GetAvailablePackageChangelogRequest
+ */
+export class GetAvailablePackageChangelogRequest extends Message<GetAvailablePackageChangelogRequest> {
+  /**
+   * The information required to uniquely
+   * identify an available package
+   *
+   * @generated from field: kubeappsapis.core.packages.v1alpha1.AvailablePackageReference available_package_ref = 1;
+   */
+  availablePackageRef?: AvailablePackageReference;
+
+  /**
+   * Optional version from which the changelog is requested, usually the
+   * installed version. The changes of this version are not included.
+   * By default the changelog of every version is returned.
+   *
+   * @generated from field: string from_version = 2;
+   */
+  fromVersion = "";
+
+  /**
+   * Optional version up to which the changelog is requested, usually the
+   * version to be upgraded to. The changes of this version are included.
+   * By default the latest version is used.
+   *
+   * @generated from field: string to_version = 3;
+   */
+  toVersion = "";
+
+  constructor(data?: PartialMessage<GetAvailablePackageChangelogRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName =
+    "kubeappsapis.core.packages.v1alpha1.GetAvailablePackageChangelogRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "available_package_ref", kind: "message", T: AvailablePackageReference },
+    { no: 2, name: "from_version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "to_version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): GetAvailablePackageChangelogRequest {
+    return new GetAvailablePackageChangelogRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): GetAvailablePackageChangelogRequest {
+    return new GetAvailablePackageChangelogRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetAvailablePackageChangelogRequest {
+    return new GetAvailablePackageChangelogRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | GetAvailablePackageChangelogRequest
+      | PlainMessage<GetAvailablePackageChangelogRequest>
+      | undefined,
+    b:
+      | GetAvailablePackageChangelogRequest
+      | PlainMessage<GetAvailablePackageChangelogRequest>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(GetAvailablePackageChangelogRequest, a, b);
+  }
+}
+
 /**
  * GetInstalledPackageSummariesRequest
  *
@@ -263,6 +574,13 @@ export class GetInstalledPackageSummariesRequest extends Message<GetInstalledPac
    */
   paginationOptions?: PaginationOptions;
 
+  /**
+   * Filter options specifying which installed packages to include.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1alpha1.InstalledPackageFilterOptions filter_options = 3;
+   */
+  filterOptions?: InstalledPackageFilterOptions;
+
   constructor(data?: PartialMessage<GetInstalledPackageSummariesRequest>) {
     super();
     proto3.util.initPartial(data, this);
@@ -274,6 +592,7 @@ export class GetInstalledPackageSummariesRequest extends Message<GetInstalledPac
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
     { no: 1, name: "context", kind: "message", T: Context },
     { no: 2, name: "pagination_options", kind: "message", T: PaginationOptions },
+    { no: 3, name: "filter_options", kind: "message", T: InstalledPackageFilterOptions },
   ]);
 
   static fromBinary(
@@ -374,88 +693,265 @@ export class GetInstalledPackageDetailRequest extends Message<GetInstalledPackag
 }
 
 /**
- * CreateInstalledPackageRequest
+ * CheckInstalledPackageNameAvailabilityRequest
  *
- * Request for CreateInstalledPackage
+ * Request for CheckInstalledPackageNameAvailability
  *
- * @generated from message kubeappsapis.core.packages.v1alpha1.CreateInstalledPackageRequest
+ * @generated from message kubeappsapis.core.packages.v1alpha1.CheckInstalledPackageNameAvailabilityRequest
  */
-export class CreateInstalledPackageRequest extends Message<CreateInstalledPackageRequest> {
-  /**
-   * A reference uniquely identifying the package available for installation.
-   *
-   * @generated from field: kubeappsapis.core.packages.v1alpha1.AvailablePackageReference available_package_ref = 1;
-   */
-  availablePackageRef?: AvailablePackageReference;
-
+export class CheckInstalledPackageNameAvailabilityRequest extends Message<CheckInstalledPackageNameAvailabilityRequest> {
   /**
    * The target context where the package is intended to be installed.
    *
-   * @generated from field: kubeappsapis.core.packages.v1alpha1.Context target_context = 2;
+   * @generated from field: kubeappsapis.core.packages.v1alpha1.Context target_context = 1;
    */
   targetContext?: Context;
 
   /**
-   * A user-provided name for the installed package (eg. project-x-db)
+   * The name intended for the installed package.
    *
-   * @generated from field: string name = 3;
+   * @generated from field: string name = 2;
    */
   name = "";
 
-  /**
-   * For helm this will be the exact version in VersionReference.version
-   * For other plugins we can extend the VersionReference as needed.
-   *
-   * @generated from field: kubeappsapis.core.packages.v1alpha1.VersionReference pkg_version_reference = 4;
-   */
-  pkgVersionReference?: VersionReference;
-
-  /**
-   * An optional serialized values string to be included when templating a package
-   * in the format expected by the plugin. Included when the backend format doesn't
-   * use secrets or configmaps for values or supports both. These values are layered
-   * on top of any values refs above, when relevant.
-   *
-   * @generated from field: string values = 5;
-   */
-  values = "";
-
-  /**
-   * An optional field for specifying data common to systems that reconcile
-   * the package on the cluster.
-   *
-   * @generated from field: kubeappsapis.core.packages.v1alpha1.ReconciliationOptions reconciliation_options = 6;
-   */
-  reconciliationOptions?: ReconciliationOptions;
-
-  constructor(data?: PartialMessage<CreateInstalledPackageRequest>) {
+  constructor(data?: PartialMessage<CheckInstalledPackageNameAvailabilityRequest>) {
     super();
     proto3.util.initPartial(data, this);
   }
 
   static readonly runtime: typeof proto3 = proto3;
-  static readonly typeName = "kubeappsapis.core.packages.v1alpha1.CreateInstalledPackageRequest";
+  static readonly typeName =
+    "kubeappsapis.core.packages.v1alpha1.CheckInstalledPackageNameAvailabilityRequest";
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
-    { no: 1, name: "available_package_ref", kind: "message", T: AvailablePackageReference },
-    { no: 2, name: "target_context", kind: "message", T: Context },
-    { no: 3, name: "name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
-    { no: 4, name: "pkg_version_reference", kind: "message", T: VersionReference },
-    { no: 5, name: "values", kind: "scalar", T: 9 /* ScalarType.STRING */ },
-    { no: 6, name: "reconciliation_options", kind: "message", T: ReconciliationOptions },
+    { no: 1, name: "target_context", kind: "message", T: Context },
+    { no: 2, name: "name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
   ]);
 
   static fromBinary(
     bytes: Uint8Array,
     options?: Partial<BinaryReadOptions>,
-  ): CreateInstalledPackageRequest {
-    return new CreateInstalledPackageRequest().fromBinary(bytes, options);
+  ): CheckInstalledPackageNameAvailabilityRequest {
+    return new CheckInstalledPackageNameAvailabilityRequest().fromBinary(bytes, options);
   }
 
   static fromJson(
     jsonValue: JsonValue,
     options?: Partial<JsonReadOptions>,
-  ): CreateInstalledPackageRequest {
-    return new CreateInstalledPackageRequest().fromJson(jsonValue, options);
+  ): CheckInstalledPackageNameAvailabilityRequest {
+    return new CheckInstalledPackageNameAvailabilityRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): CheckInstalledPackageNameAvailabilityRequest {
+    return new CheckInstalledPackageNameAvailabilityRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | CheckInstalledPackageNameAvailabilityRequest
+      | PlainMessage<CheckInstalledPackageNameAvailabilityRequest>
+      | undefined,
+    b:
+      | CheckInstalledPackageNameAvailabilityRequest
+      | PlainMessage<CheckInstalledPackageNameAvailabilityRequest>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(CheckInstalledPackageNameAvailabilityRequest, a, b);
+  }
+}
+
+/**
+ * CheckInstalledPackageNameAvailabilityResponse
+ *
+ * Response for CheckInstalledPackageNameAvailability
+ *
+ * @generated from message kubeappsapis.core.packages.v1alpha1.CheckInstalledPackageNameAvailabilityResponse
+ */
+export class CheckInstalledPackageNameAvailabilityResponse extends Message<CheckInstalledPackageNameAvailabilityResponse> {
+  /**
+   * Whether no installed package, of any plugin, uses the name in the target namespace.
+   *
+   * @generated from field: bool available = 1;
+   */
+  available = false;
+
+  /**
+   * The installed packages already using the name, if any.
+   *
+   * @generated from field: repeated kubeappsapis.core.packages.v1alpha1.InstalledPackageReference conflicts = 2;
+   */
+  conflicts: InstalledPackageReference[] = [];
+
+  constructor(data?: PartialMessage<CheckInstalledPackageNameAvailabilityResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName =
+    "kubeappsapis.core.packages.v1alpha1.CheckInstalledPackageNameAvailabilityResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "available", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 2, name: "conflicts", kind: "message", T: InstalledPackageReference, repeated: true },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): CheckInstalledPackageNameAvailabilityResponse {
+    return new CheckInstalledPackageNameAvailabilityResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): CheckInstalledPackageNameAvailabilityResponse {
+    return new CheckInstalledPackageNameAvailabilityResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): CheckInstalledPackageNameAvailabilityResponse {
+    return new CheckInstalledPackageNameAvailabilityResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | CheckInstalledPackageNameAvailabilityResponse
+      | PlainMessage<CheckInstalledPackageNameAvailabilityResponse>
+      | undefined,
+    b:
+      | CheckInstalledPackageNameAvailabilityResponse
+      | PlainMessage<CheckInstalledPackageNameAvailabilityResponse>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(CheckInstalledPackageNameAvailabilityResponse, a, b);
+  }
+}
+
+/**
+ * CreateInstalledPackageRequest
+ *
+ * Request for CreateInstalledPackage
+ *
+ * @generated from message kubeappsapis.core.packages.v1alpha1.CreateInstalledPackageRequest
+ */
+export class CreateInstalledPackageRequest extends Message<CreateInstalledPackageRequest> {
+  /**
+   * A reference uniquely identifying the package available for installation.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1alpha1.AvailablePackageReference available_package_ref = 1;
+   */
+  availablePackageRef?: AvailablePackageReference;
+
+  /**
+   * The target context where the package is intended to be installed.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1alpha1.Context target_context = 2;
+   */
+  targetContext?: Context;
+
+  /**
+   * A user-provided name for the installed package (eg. project-x-db)
+   *
+   * Plugins supporting it (eg. helm) generate a name which is not in use in
+   * the target namespace when it is empty.
+   *
+   * @generated from field: string name = 3;
+   */
+  name = "";
+
+  /**
+   * For helm this will be the exact version in VersionReference.version
+   * For other plugins we can extend the VersionReference as needed.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1alpha1.VersionReference pkg_version_reference = 4;
+   */
+  pkgVersionReference?: VersionReference;
+
+  /**
+   * An optional serialized values string to be included when templating a package
+   * in the format expected by the plugin. Included when the backend format doesn't
+   * use secrets or configmaps for values or supports both. These values are layered
+   * on top of any values refs above, when relevant.
+   *
+   * @generated from field: string values = 5;
+   */
+  values = "";
+
+  /**
+   * An optional field for specifying data common to systems that reconcile
+   * the package on the cluster.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1alpha1.ReconciliationOptions reconciliation_options = 6;
+   */
+  reconciliationOptions?: ReconciliationOptions;
+
+  /**
+   * Custom data added by the plugin
+   *
+   * A plugin can define custom details for data which is not yet, or never will
+   * be specified in the core CreateInstalledPackageRequest fields. The use
+   * of an `Any` field means that each plugin can define the structure of this
+   * message as required, while still satisfying the core interface.
+   * See https://developers.google.com/protocol-buffers/docs/proto3#any
+   *
+   * @generated from field: google.protobuf.Any custom_detail = 7;
+   */
+  customDetail?: Any;
+
+  /**
+   * Whether to create the target namespace if it does not exist, with the
+   * labels and annotations configured for the plugins. The user must be
+   * allowed to create namespaces.
+   *
+   * @generated from field: bool create_namespace = 8;
+   */
+  createNamespace = false;
+
+  /**
+   * An optional field for specifying the labels and annotations set on the
+   * object created by the plugin to install the package, such as a
+   * kapp-controller PackageInstall or a Flux HelmRelease.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1alpha1.InstalledPackageMetadata metadata = 9;
+   */
+  metadata?: InstalledPackageMetadata;
+
+  constructor(data?: PartialMessage<CreateInstalledPackageRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1alpha1.CreateInstalledPackageRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "available_package_ref", kind: "message", T: AvailablePackageReference },
+    { no: 2, name: "target_context", kind: "message", T: Context },
+    { no: 3, name: "name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "pkg_version_reference", kind: "message", T: VersionReference },
+    { no: 5, name: "values", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 6, name: "reconciliation_options", kind: "message", T: ReconciliationOptions },
+    { no: 7, name: "custom_detail", kind: "message", T: Any },
+    { no: 8, name: "create_namespace", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 9, name: "metadata", kind: "message", T: InstalledPackageMetadata },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): CreateInstalledPackageRequest {
+    return new CreateInstalledPackageRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): CreateInstalledPackageRequest {
+    return new CreateInstalledPackageRequest().fromJson(jsonValue, options);
   }
 
   static fromJsonString(
@@ -526,6 +1022,37 @@ export class UpdateInstalledPackageRequest extends Message<UpdateInstalledPackag
    */
   reconciliationOptions?: ReconciliationOptions;
 
+  /**
+   * Custom data added by the plugin
+   *
+   * A plugin can define custom details for data which is not yet, or never will
+   * be specified in the core UpdateInstalledPackageRequest fields. The use
+   * of an `Any` field means that each plugin can define the structure of this
+   * message as required, while still satisfying the core interface.
+   * See https://developers.google.com/protocol-buffers/docs/proto3#any
+   *
+   * @generated from field: google.protobuf.Any custom_detail = 5;
+   */
+  customDetail?: Any;
+
+  /**
+   * An optional field for specifying the labels and annotations of the
+   * installed package. When provided, they replace those previously set,
+   * except the protected ones. When not provided, they are left intact.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1alpha1.InstalledPackageMetadata metadata = 6;
+   */
+  metadata?: InstalledPackageMetadata;
+
+  /**
+   * An optional field for keeping the values currently applied to the
+   * installed package, as stored by the plugin, rather than replacing them
+   * with the values above, which must then be empty.
+   *
+   * @generated from field: bool reuse_values = 7;
+   */
+  reuseValues = false;
+
   constructor(data?: PartialMessage<UpdateInstalledPackageRequest>) {
     super();
     proto3.util.initPartial(data, this);
@@ -538,6 +1065,9 @@ export class UpdateInstalledPackageRequest extends Message<UpdateInstalledPackag
     { no: 2, name: "pkg_version_reference", kind: "message", T: VersionReference },
     { no: 3, name: "values", kind: "scalar", T: 9 /* ScalarType.STRING */ },
     { no: 4, name: "reconciliation_options", kind: "message", T: ReconciliationOptions },
+    { no: 5, name: "custom_detail", kind: "message", T: Any },
+    { no: 6, name: "metadata", kind: "message", T: InstalledPackageMetadata },
+    { no: 7, name: "reuse_values", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
   ]);
 
   static fromBinary(
@@ -584,6 +1114,20 @@ export class DeleteInstalledPackageRequest extends Message<DeleteInstalledPackag
    */
   installedPackageRef?: InstalledPackageReference;
 
+  /**
+   * Custom data added by the plugin
+   *
+   * A plugin can define custom details for data which is not yet, or never will
+   * be specified in the core DeleteInstalledPackageRequest fields, such as
+   * deletion options. The use of an `Any` field means that each plugin can
+   * define the structure of this message as required, while still satisfying
+   * the core interface.
+   * See https://developers.google.com/protocol-buffers/docs/proto3#any
+   *
+   * @generated from field: google.protobuf.Any custom_detail = 2;
+   */
+  customDetail?: Any;
+
   constructor(data?: PartialMessage<DeleteInstalledPackageRequest>) {
     super();
     proto3.util.initPartial(data, this);
@@ -593,6 +1137,7 @@ export class DeleteInstalledPackageRequest extends Message<DeleteInstalledPackag
   static readonly typeName = "kubeappsapis.core.packages.v1alpha1.DeleteInstalledPackageRequest";
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
     { no: 1, name: "installed_package_ref", kind: "message", T: InstalledPackageReference },
+    { no: 2, name: "custom_detail", kind: "message", T: Any },
   ]);
 
   static fromBinary(
@@ -684,6 +1229,68 @@ export class GetInstalledPackageResourceRefsRequest extends Message<GetInstalled
   }
 }
 
+/**
+ * GetInstalledPackageOperationStatusRequest
+ *
+ * Request for GetInstalledPackageOperationStatus
+ *
+ * @generated from message kubeappsapis.core.packages.v1alpha1.GetInstalledPackageOperationStatusRequest
+ */
+export class GetInstalledPackageOperationStatusRequest extends Message<GetInstalledPackageOperationStatusRequest> {
+  /**
+   * The installed package reference for which the operation status is requested
+   *
+   * @generated from field: kubeappsapis.core.packages.v1alpha1.InstalledPackageReference installed_package_ref = 1;
+   */
+  installedPackageRef?: InstalledPackageReference;
+
+  constructor(data?: PartialMessage<GetInstalledPackageOperationStatusRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName =
+    "kubeappsapis.core.packages.v1alpha1.GetInstalledPackageOperationStatusRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "installed_package_ref", kind: "message", T: InstalledPackageReference },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): GetInstalledPackageOperationStatusRequest {
+    return new GetInstalledPackageOperationStatusRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): GetInstalledPackageOperationStatusRequest {
+    return new GetInstalledPackageOperationStatusRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetInstalledPackageOperationStatusRequest {
+    return new GetInstalledPackageOperationStatusRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | GetInstalledPackageOperationStatusRequest
+      | PlainMessage<GetInstalledPackageOperationStatusRequest>
+      | undefined,
+    b:
+      | GetInstalledPackageOperationStatusRequest
+      | PlainMessage<GetInstalledPackageOperationStatusRequest>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(GetInstalledPackageOperationStatusRequest, a, b);
+  }
+}
+
 /**
  * GetAvailablePackageSummariesResponse
  *
@@ -725,6 +1332,28 @@ export class GetAvailablePackageSummariesResponse extends Message<GetAvailablePa
    */
   categories: string[] = [];
 
+  /**
+   * Partial results
+   *
+   * This optional field is set when the results do not include some of the
+   * requested namespaces, for example because the user cannot access them.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1alpha1.PartialResults partial_results = 4;
+   */
+  partialResults?: PartialResults;
+
+  /**
+   * Total size
+   *
+   * This optional field contains the total number of available packages
+   * matching the request, across every page. It is only set when it can be
+   * cheaply computed, so clients may need to rely on the next_page_token to
+   * know whether there are more results.
+   *
+   * @generated from field: optional int32 total_size = 5;
+   */
+  totalSize?: number;
+
   constructor(data?: PartialMessage<GetAvailablePackageSummariesResponse>) {
     super();
     proto3.util.initPartial(data, this);
@@ -743,6 +1372,8 @@ export class GetAvailablePackageSummariesResponse extends Message<GetAvailablePa
     },
     { no: 2, name: "next_page_token", kind: "scalar", T: 9 /* ScalarType.STRING */ },
     { no: 3, name: "categories", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 4, name: "partial_results", kind: "message", T: PartialResults },
+    { no: 5, name: "total_size", kind: "scalar", T: 5 /* ScalarType.INT32 */, opt: true },
   ]);
 
   static fromBinary(
@@ -781,20 +1412,96 @@ export class GetAvailablePackageSummariesResponse extends Message<GetAvailablePa
 }
 
 /**
- * GetAvailablePackageDetailResponse
- *
- * Response for GetAvailablePackageDetail
+ * GetAvailablePackageFiltersResponse
  *
- * TODO: add example for API docs
- * option (grpc.gateway.protoc_gen_openapiv2.options.openapiv2_schema) = {
- *   example: '{"available_package_detail": [{}]}'
- * };
+ * Response for GetAvailablePackageFilters
  *
- * @generated from message kubeappsapis.core.packages.v1alpha1.GetAvailablePackageDetailResponse
+ * @generated from message kubeappsapis.core.packages.v1alpha1.GetAvailablePackageFiltersResponse
  */
-export class GetAvailablePackageDetailResponse extends Message<GetAvailablePackageDetailResponse> {
+export class GetAvailablePackageFiltersResponse extends Message<GetAvailablePackageFiltersResponse> {
   /**
-   * Available package detail
+   * Categories
+   *
+   * The distinct category names of the available packages, sorted by name.
+   *
+   * @generated from field: repeated string categories = 1;
+   */
+  categories: string[] = [];
+
+  /**
+   * Providers
+   *
+   * The distinct provider names of the available packages, sorted by name.
+   * Only the plugins whose packages have a provider (eg. kapp_controller)
+   * contribute to this list.
+   *
+   * @generated from field: repeated string providers = 2;
+   */
+  providers: string[] = [];
+
+  constructor(data?: PartialMessage<GetAvailablePackageFiltersResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName =
+    "kubeappsapis.core.packages.v1alpha1.GetAvailablePackageFiltersResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "categories", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 2, name: "providers", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): GetAvailablePackageFiltersResponse {
+    return new GetAvailablePackageFiltersResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): GetAvailablePackageFiltersResponse {
+    return new GetAvailablePackageFiltersResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetAvailablePackageFiltersResponse {
+    return new GetAvailablePackageFiltersResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | GetAvailablePackageFiltersResponse
+      | PlainMessage<GetAvailablePackageFiltersResponse>
+      | undefined,
+    b:
+      | GetAvailablePackageFiltersResponse
+      | PlainMessage<GetAvailablePackageFiltersResponse>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(GetAvailablePackageFiltersResponse, a, b);
+  }
+}
+
+/**
+ * GetAvailablePackageDetailResponse
+ *
+ * Response for GetAvailablePackageDetail
+ *
+ * TODO: add example for API docs
+ * option (grpc.gateway.protoc_gen_openapiv2.options.openapiv2_schema) = {
+ *   example: '{"available_package_detail": [{}]}'
+ * };
+ *
+ * @generated from message kubeappsapis.core.packages.v1alpha1.GetAvailablePackageDetailResponse
+ */
+export class GetAvailablePackageDetailResponse extends Message<GetAvailablePackageDetailResponse> {
+  /**
+   * Available package detail
    *
    * The requested AvailablePackageDetail
    *
@@ -938,6 +1645,168 @@ export class GetAvailablePackageVersionsResponse extends Message<GetAvailablePac
   }
 }
 
+/**
+ * GetAvailablePackageTextAssetResponse
+ *
+ * Response for GetAvailablePackageTextAsset
+ *
+ * @generated from message kubeappsapis.core.packages.v1alpha1.GetAvailablePackageTextAssetResponse
+ */
+export class GetAvailablePackageTextAssetResponse extends Message<GetAvailablePackageTextAssetResponse> {
+  /**
+   * Content
+   *
+   * The requested range of the text asset. The range is adjusted so that a
+   * multi-byte character is never split.
+   *
+   * @generated from field: string content = 1;
+   */
+  content = "";
+
+  /**
+   * Offset
+   *
+   * The offset, in bytes, of the returned content within the text asset.
+   *
+   * @generated from field: int64 offset = 2;
+   */
+  offset = protoInt64.zero;
+
+  /**
+   * Next offset
+   *
+   * The offset from which the next range should be requested, or 0 when the
+   * end of the text asset has been reached.
+   *
+   * @generated from field: int64 next_offset = 3;
+   */
+  nextOffset = protoInt64.zero;
+
+  /**
+   * Total size
+   *
+   * The total size, in bytes, of the text asset.
+   *
+   * @generated from field: int64 total_size = 4;
+   */
+  totalSize = protoInt64.zero;
+
+  constructor(data?: PartialMessage<GetAvailablePackageTextAssetResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName =
+    "kubeappsapis.core.packages.v1alpha1.GetAvailablePackageTextAssetResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "content", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "offset", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 3, name: "next_offset", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 4, name: "total_size", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): GetAvailablePackageTextAssetResponse {
+    return new GetAvailablePackageTextAssetResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): GetAvailablePackageTextAssetResponse {
+    return new GetAvailablePackageTextAssetResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetAvailablePackageTextAssetResponse {
+    return new GetAvailablePackageTextAssetResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | GetAvailablePackageTextAssetResponse
+      | PlainMessage<GetAvailablePackageTextAssetResponse>
+      | undefined,
+    b:
+      | GetAvailablePackageTextAssetResponse
+      | PlainMessage<GetAvailablePackageTextAssetResponse>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(GetAvailablePackageTextAssetResponse, a, b);
+  }
+}
+
+/**
+ * GetAvailablePackageChangelogResponse
+ *
+ * Response for GetAvailablePackageChangelog
+ *
+ * @generated from message kubeappsapis.core.packages.v1alpha1.GetAvailablePackageChangelogResponse
+ */
+export class GetAvailablePackageChangelogResponse extends Message<GetAvailablePackageChangelogResponse> {
+  /**
+   * Changelogs
+   *
+   * The changelog of each version between the requested versions, sorted from
+   * the most recent version to the oldest one. Versions without any release
+   * notes or changes are omitted.
+   *
+   * @generated from field: repeated kubeappsapis.core.packages.v1alpha1.PackageVersionChangelog changelogs = 1;
+   */
+  changelogs: PackageVersionChangelog[] = [];
+
+  constructor(data?: PartialMessage<GetAvailablePackageChangelogResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName =
+    "kubeappsapis.core.packages.v1alpha1.GetAvailablePackageChangelogResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "changelogs", kind: "message", T: PackageVersionChangelog, repeated: true },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): GetAvailablePackageChangelogResponse {
+    return new GetAvailablePackageChangelogResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): GetAvailablePackageChangelogResponse {
+    return new GetAvailablePackageChangelogResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetAvailablePackageChangelogResponse {
+    return new GetAvailablePackageChangelogResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | GetAvailablePackageChangelogResponse
+      | PlainMessage<GetAvailablePackageChangelogResponse>
+      | undefined,
+    b:
+      | GetAvailablePackageChangelogResponse
+      | PlainMessage<GetAvailablePackageChangelogResponse>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(GetAvailablePackageChangelogResponse, a, b);
+  }
+}
+
 /**
  * GetInstalledPackageSummariesResponse
  *
@@ -965,6 +1834,16 @@ export class GetInstalledPackageSummariesResponse extends Message<GetInstalledPa
    */
   nextPageToken = "";
 
+  /**
+   * Partial results
+   *
+   * This optional field is set when the results do not include some of the
+   * requested namespaces, for example because the user cannot access them.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1alpha1.PartialResults partial_results = 3;
+   */
+  partialResults?: PartialResults;
+
   constructor(data?: PartialMessage<GetInstalledPackageSummariesResponse>) {
     super();
     proto3.util.initPartial(data, this);
@@ -982,6 +1861,7 @@ export class GetInstalledPackageSummariesResponse extends Message<GetInstalledPa
       repeated: true,
     },
     { no: 2, name: "next_page_token", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "partial_results", kind: "message", T: PartialResults },
   ]);
 
   static fromBinary(
@@ -1106,6 +1986,15 @@ export class CreateInstalledPackageResponse extends Message<CreateInstalledPacka
    */
   installedPackageRef?: InstalledPackageReference;
 
+  /**
+   * Warnings about the installed package, such as the quota of the target
+   * namespace not having enough left for the capacity the package requires,
+   * when the plugin is configured to warn rather than to refuse it
+   *
+   * @generated from field: repeated string warnings = 2;
+   */
+  warnings: string[] = [];
+
   constructor(data?: PartialMessage<CreateInstalledPackageResponse>) {
     super();
     proto3.util.initPartial(data, this);
@@ -1115,6 +2004,7 @@ export class CreateInstalledPackageResponse extends Message<CreateInstalledPacka
   static readonly typeName = "kubeappsapis.core.packages.v1alpha1.CreateInstalledPackageResponse";
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
     { no: 1, name: "installed_package_ref", kind: "message", T: InstalledPackageReference },
+    { no: 2, name: "warnings", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
   ]);
 
   static fromBinary(
@@ -1318,6 +2208,82 @@ export class GetInstalledPackageResourceRefsResponse extends Message<GetInstalle
   }
 }
 
+/**
+ * GetInstalledPackageOperationStatusResponse
+ *
+ * Response for GetInstalledPackageOperationStatus
+ *
+ * @generated from message kubeappsapis.core.packages.v1alpha1.GetInstalledPackageOperationStatusResponse
+ */
+export class GetInstalledPackageOperationStatusResponse extends Message<GetInstalledPackageOperationStatusResponse> {
+  /**
+   * Status
+   *
+   * The overall status of the operation. It is pending until every phase
+   * has succeeded or any of them has failed.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1alpha1.InstalledPackageStatus status = 1;
+   */
+  status?: InstalledPackageStatus;
+
+  /**
+   * Phases
+   *
+   * The phases of the operation, in the order they are run. Plugins not
+   * reporting granular progress return no phases, only the overall status.
+   *
+   * @generated from field: repeated kubeappsapis.core.packages.v1alpha1.InstalledPackageOperationPhase phases = 2;
+   */
+  phases: InstalledPackageOperationPhase[] = [];
+
+  constructor(data?: PartialMessage<GetInstalledPackageOperationStatusResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName =
+    "kubeappsapis.core.packages.v1alpha1.GetInstalledPackageOperationStatusResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "status", kind: "message", T: InstalledPackageStatus },
+    { no: 2, name: "phases", kind: "message", T: InstalledPackageOperationPhase, repeated: true },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): GetInstalledPackageOperationStatusResponse {
+    return new GetInstalledPackageOperationStatusResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): GetInstalledPackageOperationStatusResponse {
+    return new GetInstalledPackageOperationStatusResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetInstalledPackageOperationStatusResponse {
+    return new GetInstalledPackageOperationStatusResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | GetInstalledPackageOperationStatusResponse
+      | PlainMessage<GetInstalledPackageOperationStatusResponse>
+      | undefined,
+    b:
+      | GetInstalledPackageOperationStatusResponse
+      | PlainMessage<GetInstalledPackageOperationStatusResponse>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(GetInstalledPackageOperationStatusResponse, a, b);
+  }
+}
+
 /**
  * AvailablePackageSummary
  *
@@ -1607,6 +2573,28 @@ export class AvailablePackageDetail extends Message<AvailablePackageDetail> {
    */
   customDetail?: Any;
 
+  /**
+   * Truncated text assets
+   *
+   * The text assets (e.g. the readme) which were too big and have been truncated
+   * in this response. Their whole content can be retrieved, in ranges, with
+   * GetAvailablePackageTextAsset.
+   *
+   * @generated from field: repeated kubeappsapis.core.packages.v1alpha1.TruncatedTextAsset truncated_text_assets = 18;
+   */
+  truncatedTextAssets: TruncatedTextAsset[] = [];
+
+  /**
+   * Available package icon
+   *
+   * The icon of the package normalized by the core, with a thumbnail and a
+   * full-size variant. Unset when the package has no icon, or when its icon is
+   * not a supported image or is too big.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1alpha1.PackageIcon icon = 19;
+   */
+  icon?: PackageIcon;
+
   constructor(data?: PartialMessage<AvailablePackageDetail>) {
     super();
     proto3.util.initPartial(data, this);
@@ -1638,6 +2626,14 @@ export class AvailablePackageDetail extends Message<AvailablePackageDetail> {
     { no: 14, name: "maintainers", kind: "message", T: Maintainer, repeated: true },
     { no: 15, name: "categories", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
     { no: 16, name: "custom_detail", kind: "message", T: Any },
+    {
+      no: 18,
+      name: "truncated_text_assets",
+      kind: "message",
+      T: TruncatedTextAsset,
+      repeated: true,
+    },
+    { no: 19, name: "icon", kind: "message", T: PackageIcon },
   ]);
 
   static fromBinary(
@@ -1782,6 +2778,16 @@ export class InstalledPackageSummary extends Message<InstalledPackageSummary> {
    */
   status?: InstalledPackageStatus;
 
+  /**
+   * Warnings
+   *
+   * Warnings about the installed package, such as the currently installed
+   * version being deprecated or having reached its end of life.
+   *
+   * @generated from field: repeated string warnings = 11;
+   */
+  warnings: string[] = [];
+
   constructor(data?: PartialMessage<InstalledPackageSummary>) {
     super();
     proto3.util.initPartial(data, this);
@@ -1800,6 +2806,7 @@ export class InstalledPackageSummary extends Message<InstalledPackageSummary> {
     { no: 8, name: "latest_matching_version", kind: "message", T: PackageAppVersion },
     { no: 9, name: "latest_version", kind: "message", T: PackageAppVersion },
     { no: 10, name: "status", kind: "message", T: InstalledPackageStatus },
+    { no: 11, name: "warnings", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
   ]);
 
   static fromBinary(
@@ -1960,6 +2967,16 @@ export class InstalledPackageDetail extends Message<InstalledPackageDetail> {
    */
   customDetail?: Any;
 
+  /**
+   * Metadata
+   *
+   * The labels and annotations of the installed package, other than the
+   * protected ones, if any.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1alpha1.InstalledPackageMetadata metadata = 15;
+   */
+  metadata?: InstalledPackageMetadata;
+
   constructor(data?: PartialMessage<InstalledPackageDetail>) {
     super();
     proto3.util.initPartial(data, this);
@@ -1980,6 +2997,7 @@ export class InstalledPackageDetail extends Message<InstalledPackageDetail> {
     { no: 10, name: "latest_matching_version", kind: "message", T: PackageAppVersion },
     { no: 11, name: "latest_version", kind: "message", T: PackageAppVersion },
     { no: 14, name: "custom_detail", kind: "message", T: Any },
+    { no: 15, name: "metadata", kind: "message", T: InstalledPackageMetadata },
   ]);
 
   static fromBinary(
@@ -2295,6 +3313,27 @@ export class FilterOptions extends Message<FilterOptions> {
    */
   appVersion = "";
 
+  /**
+   * Providers
+   *
+   * Collection of provider names where the packages belong to. Packages
+   * without a provider do not match when set.
+   *
+   * @generated from field: repeated string providers = 6;
+   */
+  providers: string[] = [];
+
+  /**
+   * Exclude global packages
+   *
+   * Whether to return only the packages local to the requested namespace,
+   * excluding the ones published to every namespace, such as the packages
+   * of the kapp-controller global packaging namespace.
+   *
+   * @generated from field: bool exclude_global_packages = 7;
+   */
+  excludeGlobalPackages = false;
+
   constructor(data?: PartialMessage<FilterOptions>) {
     super();
     proto3.util.initPartial(data, this);
@@ -2308,6 +3347,8 @@ export class FilterOptions extends Message<FilterOptions> {
     { no: 3, name: "repositories", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
     { no: 4, name: "pkg_version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
     { no: 5, name: "app_version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 6, name: "providers", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 7, name: "exclude_global_packages", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): FilterOptions {
@@ -2331,14 +3372,102 @@ export class FilterOptions extends Message<FilterOptions> {
 }
 
 /**
- * PaginationOptions
+ * InstalledPackageFilterOptions
  *
- * The PaginationOptions based on the example proto at:
- * https://cloud.google.com/apis/design/design_patterns#list_pagination
- * just encapsulated in a message so it can be reused on different request messages.
+ * InstalledPackageFilterOptions available when requesting installed package
+ * summaries. Only the installed packages matching all the options set are
+ * returned.
  *
- * TODO: add example for API docs
- * option (grpc.gateway.protoc_gen_openapiv2.options.openapiv2_schema) = {
+ * @generated from message kubeappsapis.core.packages.v1alpha1.InstalledPackageFilterOptions
+ */
+export class InstalledPackageFilterOptions extends Message<InstalledPackageFilterOptions> {
+  /**
+   * Label selector
+   *
+   * A Kubernetes label selector, such as "team=frontend,tier!=cache", matched
+   * against the labels of the resource representing the installed package.
+   *
+   * @generated from field: string label_selector = 1;
+   */
+  labelSelector = "";
+
+  /**
+   * Status reasons
+   *
+   * Collection of status reasons, only the installed packages with one of
+   * these reasons being returned when set.
+   *
+   * @generated from field: repeated kubeappsapis.core.packages.v1alpha1.InstalledPackageStatus.StatusReason status_reasons = 2;
+   */
+  statusReasons: InstalledPackageStatus_StatusReason[] = [];
+
+  /**
+   * Only upgradable
+   *
+   * Only return the installed packages for which a newer version matching
+   * their version constraints is available.
+   *
+   * @generated from field: bool only_upgradable = 3;
+   */
+  onlyUpgradable = false;
+
+  constructor(data?: PartialMessage<InstalledPackageFilterOptions>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1alpha1.InstalledPackageFilterOptions";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "label_selector", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    {
+      no: 2,
+      name: "status_reasons",
+      kind: "enum",
+      T: proto3.getEnumType(InstalledPackageStatus_StatusReason),
+      repeated: true,
+    },
+    { no: 3, name: "only_upgradable", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): InstalledPackageFilterOptions {
+    return new InstalledPackageFilterOptions().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): InstalledPackageFilterOptions {
+    return new InstalledPackageFilterOptions().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): InstalledPackageFilterOptions {
+    return new InstalledPackageFilterOptions().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: InstalledPackageFilterOptions | PlainMessage<InstalledPackageFilterOptions> | undefined,
+    b: InstalledPackageFilterOptions | PlainMessage<InstalledPackageFilterOptions> | undefined,
+  ): boolean {
+    return proto3.util.equals(InstalledPackageFilterOptions, a, b);
+  }
+}
+
+/**
+ * PaginationOptions
+ *
+ * The PaginationOptions based on the example proto at:
+ * https://cloud.google.com/apis/design/design_patterns#list_pagination
+ * just encapsulated in a message so it can be reused on different request messages.
+ *
+ * TODO: add example for API docs
+ * option (grpc.gateway.protoc_gen_openapiv2.options.openapiv2_schema) = {
  *   example: '{}'
  * };
  *
@@ -2493,8 +3622,12 @@ export class VersionReference extends Message<VersionReference> {
   /**
    * Version
    *
-   * The format of the version constraint depends on the backend. For example,
-   * for a flux v2 and Carvel it's a semver expression, such as ">=10.3 < 10.4"
+   * Either an exact version, such as "1.2.3", or a semver range expression,
+   * such as ">=1.2 <2" or "~1.2.3", validated the same way for every plugin.
+   * The plugins installing a single version, such as Helm, install the highest
+   * available version satisfying a range, while the ones whose controllers
+   * follow a range, such as flux v2 and Carvel, keep the package at the highest
+   * version satisfying it.
    *
    * @generated from field: string version = 1;
    */
@@ -2566,6 +3699,46 @@ export class InstalledPackageStatus extends Message<InstalledPackageStatus> {
    */
   userReason = "";
 
+  /**
+   * LastFetchTime
+   *
+   * Optional time (RFC3339) at which the package was last fetched by a plugin
+   * continuously reconciling it.
+   *
+   * @generated from field: string last_fetch_time = 4;
+   */
+  lastFetchTime = "";
+
+  /**
+   * LastDeployTime
+   *
+   * Optional time (RFC3339) at which the last deployment of the package
+   * finished, for a plugin continuously reconciling it.
+   *
+   * @generated from field: string last_deploy_time = 5;
+   */
+  lastDeployTime = "";
+
+  /**
+   * SyncInterval
+   *
+   * Optional interval (in time+unit) with which the package is reconciled.
+   *
+   * @generated from field: string sync_interval = 6;
+   */
+  syncInterval = "";
+
+  /**
+   * Stale
+   *
+   * Whether the last successful reconciliation of the package is older than
+   * twice its sync interval, which usually means the reconciler is not
+   * keeping up with it.
+   *
+   * @generated from field: bool stale = 7;
+   */
+  stale = false;
+
   constructor(data?: PartialMessage<InstalledPackageStatus>) {
     super();
     proto3.util.initPartial(data, this);
@@ -2582,6 +3755,10 @@ export class InstalledPackageStatus extends Message<InstalledPackageStatus> {
       T: proto3.getEnumType(InstalledPackageStatus_StatusReason),
     },
     { no: 3, name: "user_reason", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "last_fetch_time", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "last_deploy_time", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 6, name: "sync_interval", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 7, name: "stale", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
   ]);
 
   static fromBinary(
@@ -2660,6 +3837,142 @@ proto3.util.setEnumType(
   ],
 );
 
+/**
+ * InstalledPackageOperationPhase
+ *
+ * A phase of an operation on an installed package, such as the fetch, template
+ * or deploy steps of a kapp-controller App.
+ *
+ * @generated from message kubeappsapis.core.packages.v1alpha1.InstalledPackageOperationPhase
+ */
+export class InstalledPackageOperationPhase extends Message<InstalledPackageOperationPhase> {
+  /**
+   * Name
+   *
+   * The name of the phase, which is plugin specific.
+   *
+   * @generated from field: string name = 1;
+   */
+  name = "";
+
+  /**
+   * State
+   *
+   * The current state of the phase.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1alpha1.InstalledPackageOperationPhase.PhaseState state = 2;
+   */
+  state = InstalledPackageOperationPhase_PhaseState.UNSPECIFIED;
+
+  /**
+   * Message
+   *
+   * Optional text giving user context about the state, such as the error
+   * when the phase failed.
+   *
+   * @generated from field: string message = 3;
+   */
+  message = "";
+
+  constructor(data?: PartialMessage<InstalledPackageOperationPhase>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1alpha1.InstalledPackageOperationPhase";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    {
+      no: 2,
+      name: "state",
+      kind: "enum",
+      T: proto3.getEnumType(InstalledPackageOperationPhase_PhaseState),
+    },
+    { no: 3, name: "message", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): InstalledPackageOperationPhase {
+    return new InstalledPackageOperationPhase().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): InstalledPackageOperationPhase {
+    return new InstalledPackageOperationPhase().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): InstalledPackageOperationPhase {
+    return new InstalledPackageOperationPhase().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | InstalledPackageOperationPhase
+      | PlainMessage<InstalledPackageOperationPhase>
+      | undefined,
+    b:
+      | InstalledPackageOperationPhase
+      | PlainMessage<InstalledPackageOperationPhase>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(InstalledPackageOperationPhase, a, b);
+  }
+}
+
+/**
+ * PhaseState
+ *
+ * The states a phase goes through.
+ *
+ * @generated from enum kubeappsapis.core.packages.v1alpha1.InstalledPackageOperationPhase.PhaseState
+ */
+export enum InstalledPackageOperationPhase_PhaseState {
+  /**
+   * @generated from enum value: PHASE_STATE_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * @generated from enum value: PHASE_STATE_PENDING = 1;
+   */
+  PENDING = 1,
+
+  /**
+   * @generated from enum value: PHASE_STATE_IN_PROGRESS = 2;
+   */
+  IN_PROGRESS = 2,
+
+  /**
+   * @generated from enum value: PHASE_STATE_SUCCEEDED = 3;
+   */
+  SUCCEEDED = 3,
+
+  /**
+   * @generated from enum value: PHASE_STATE_FAILED = 4;
+   */
+  FAILED = 4,
+}
+// Retrieve enum metadata with: proto3.getEnumType(InstalledPackageOperationPhase_PhaseState)
+proto3.util.setEnumType(
+  InstalledPackageOperationPhase_PhaseState,
+  "kubeappsapis.core.packages.v1alpha1.InstalledPackageOperationPhase.PhaseState",
+  [
+    { no: 0, name: "PHASE_STATE_UNSPECIFIED" },
+    { no: 1, name: "PHASE_STATE_PENDING" },
+    { no: 2, name: "PHASE_STATE_IN_PROGRESS" },
+    { no: 3, name: "PHASE_STATE_SUCCEEDED" },
+    { no: 4, name: "PHASE_STATE_FAILED" },
+  ],
+);
+
 /**
  * ReconciliationOptions
  *
@@ -2740,6 +4053,85 @@ export class ReconciliationOptions extends Message<ReconciliationOptions> {
   }
 }
 
+/**
+ * InstalledPackageMetadata
+ *
+ * InstalledPackageMetadata holds the labels and annotations of the object
+ * created by a plugin to install a package, such as a kapp-controller
+ * PackageInstall or a Flux HelmRelease. The labels and annotations whose keys
+ * start with one of the prefixes protected by the plugin configuration cannot
+ * be set.
+ *
+ * @generated from message kubeappsapis.core.packages.v1alpha1.InstalledPackageMetadata
+ */
+export class InstalledPackageMetadata extends Message<InstalledPackageMetadata> {
+  /**
+   * Labels
+   *
+   * @generated from field: map<string, string> labels = 1;
+   */
+  labels: { [key: string]: string } = {};
+
+  /**
+   * Annotations
+   *
+   * @generated from field: map<string, string> annotations = 2;
+   */
+  annotations: { [key: string]: string } = {};
+
+  constructor(data?: PartialMessage<InstalledPackageMetadata>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1alpha1.InstalledPackageMetadata";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    {
+      no: 1,
+      name: "labels",
+      kind: "map",
+      K: 9 /* ScalarType.STRING */,
+      V: { kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    },
+    {
+      no: 2,
+      name: "annotations",
+      kind: "map",
+      K: 9 /* ScalarType.STRING */,
+      V: { kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): InstalledPackageMetadata {
+    return new InstalledPackageMetadata().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): InstalledPackageMetadata {
+    return new InstalledPackageMetadata().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): InstalledPackageMetadata {
+    return new InstalledPackageMetadata().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: InstalledPackageMetadata | PlainMessage<InstalledPackageMetadata> | undefined,
+    b: InstalledPackageMetadata | PlainMessage<InstalledPackageMetadata> | undefined,
+  ): boolean {
+    return proto3.util.equals(InstalledPackageMetadata, a, b);
+  }
+}
+
 /**
  * Package AppVersion
  *
@@ -2766,6 +4158,25 @@ export class PackageAppVersion extends Message<PackageAppVersion> {
    */
   appVersion = "";
 
+  /**
+   * Upgrade impact
+   *
+   * The impact of upgrading to this version, only set when the versions are
+   * requested relative to another version.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1alpha1.UpgradeImpact upgrade_impact = 3;
+   */
+  upgradeImpact?: UpgradeImpact;
+
+  /**
+   * Deprecation
+   *
+   * Only set when the version is deprecated by the publisher of the package.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1alpha1.PackageVersionDeprecation deprecation = 4;
+   */
+  deprecation?: PackageVersionDeprecation;
+
   constructor(data?: PartialMessage<PackageAppVersion>) {
     super();
     proto3.util.initPartial(data, this);
@@ -2776,6 +4187,8 @@ export class PackageAppVersion extends Message<PackageAppVersion> {
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
     { no: 1, name: "pkg_version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
     { no: 2, name: "app_version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "upgrade_impact", kind: "message", T: UpgradeImpact },
+    { no: 4, name: "deprecation", kind: "message", T: PackageVersionDeprecation },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): PackageAppVersion {
@@ -2799,57 +4212,264 @@ export class PackageAppVersion extends Message<PackageAppVersion> {
 }
 
 /**
- * Resource reference
+ * Package version deprecation
  *
- * A reference to a Kubernetes resource related to a specific installed package.
- * The context (cluster) for each resource is that of the related
- * installed package.
+ * The deprecation of a package version, as declared by the publisher of the
+ * package.
  *
- * @generated from message kubeappsapis.core.packages.v1alpha1.ResourceRef
+ * @generated from message kubeappsapis.core.packages.v1alpha1.PackageVersionDeprecation
  */
-export class ResourceRef extends Message<ResourceRef> {
+export class PackageVersionDeprecation extends Message<PackageVersionDeprecation> {
   /**
-   * The APIVersion directly from the resource has the group and version, eg. "apps/v1"
-   * or just the version for core resources.
+   * Message
    *
-   * @generated from field: string api_version = 1;
-   */
-  apiVersion = "";
-
-  /**
-   * The Kind directly from the templated manifest. Together with the APIVersion this
-   * forms the GroupVersionKind.
+   * Why the version is deprecated, or which version to use instead, if given.
    *
-   * @generated from field: string kind = 2;
+   * @generated from field: string message = 1;
    */
-  kind = "";
+  message = "";
 
   /**
-   * The name of the specific resource in the context of the installed package.
+   * End of life
    *
-   * @generated from field: string name = 3;
+   * The date, in RFC 3339 format (eg. "2024-06-30"), from which the version is
+   * no longer supported, if known.
+   *
+   * @generated from field: string end_of_life = 2;
    */
-  name = "";
+  endOfLife = "";
 
   /**
-   * The namespace of the specific resource in the context of the installed
-   * package. In most cases this will be identical to the namespace of the
-   * installed package. Exceptions will be non-namespaced resources and packages
-   * that install resources in other namespaces for special reasons.
+   * End of life reached
    *
-   * @generated from field: string namespace = 4;
+   * Whether the end of life of the version has already passed.
+   *
+   * @generated from field: bool end_of_life_reached = 3;
    */
-  namespace = "";
+  endOfLifeReached = false;
 
-  constructor(data?: PartialMessage<ResourceRef>) {
+  constructor(data?: PartialMessage<PackageVersionDeprecation>) {
     super();
     proto3.util.initPartial(data, this);
   }
 
   static readonly runtime: typeof proto3 = proto3;
-  static readonly typeName = "kubeappsapis.core.packages.v1alpha1.ResourceRef";
+  static readonly typeName = "kubeappsapis.core.packages.v1alpha1.PackageVersionDeprecation";
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
-    { no: 1, name: "api_version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 1, name: "message", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "end_of_life", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "end_of_life_reached", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): PackageVersionDeprecation {
+    return new PackageVersionDeprecation().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): PackageVersionDeprecation {
+    return new PackageVersionDeprecation().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): PackageVersionDeprecation {
+    return new PackageVersionDeprecation().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: PackageVersionDeprecation | PlainMessage<PackageVersionDeprecation> | undefined,
+    b: PackageVersionDeprecation | PlainMessage<PackageVersionDeprecation> | undefined,
+  ): boolean {
+    return proto3.util.equals(PackageVersionDeprecation, a, b);
+  }
+}
+
+/**
+ * Upgrade impact
+ *
+ * A machine-readable classification of the risk of upgrading from a version of
+ * a package, usually the installed one, to another version.
+ *
+ * @generated from message kubeappsapis.core.packages.v1alpha1.UpgradeImpact
+ */
+export class UpgradeImpact extends Message<UpgradeImpact> {
+  /**
+   * @generated from field: kubeappsapis.core.packages.v1alpha1.UpgradeImpact.UpgradeType type = 1;
+   */
+  type = UpgradeImpact_UpgradeType.UNSPECIFIED;
+
+  /**
+   * Whether any version after the compared one and up to this one (included)
+   * is flagged as containing breaking changes, eg. with changes of kind
+   * "changed" or "removed" in the artifacthub.io/changes annotation of a chart
+   * or with release notes mentioning breaking or incompatible changes.
+   *
+   * @generated from field: bool breaking = 2;
+   */
+  breaking = false;
+
+  /**
+   * The descriptions of the breaking changes found, if any.
+   *
+   * @generated from field: repeated string breaking_changes = 3;
+   */
+  breakingChanges: string[] = [];
+
+  constructor(data?: PartialMessage<UpgradeImpact>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1alpha1.UpgradeImpact";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "type", kind: "enum", T: proto3.getEnumType(UpgradeImpact_UpgradeType) },
+    { no: 2, name: "breaking", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    {
+      no: 3,
+      name: "breaking_changes",
+      kind: "scalar",
+      T: 9 /* ScalarType.STRING */,
+      repeated: true,
+    },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): UpgradeImpact {
+    return new UpgradeImpact().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): UpgradeImpact {
+    return new UpgradeImpact().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): UpgradeImpact {
+    return new UpgradeImpact().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: UpgradeImpact | PlainMessage<UpgradeImpact> | undefined,
+    b: UpgradeImpact | PlainMessage<UpgradeImpact> | undefined,
+  ): boolean {
+    return proto3.util.equals(UpgradeImpact, a, b);
+  }
+}
+
+/**
+ * Upgrade type
+ *
+ * The semantic versioning component which changes between both versions.
+ *
+ * @generated from enum kubeappsapis.core.packages.v1alpha1.UpgradeImpact.UpgradeType
+ */
+export enum UpgradeImpact_UpgradeType {
+  /**
+   * The versions could not be compared, eg. as one of them is not semver.
+   *
+   * @generated from enum value: UPGRADE_TYPE_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * The version is the same as the one it is compared to.
+   *
+   * @generated from enum value: UPGRADE_TYPE_NONE = 1;
+   */
+  NONE = 1,
+
+  /**
+   * @generated from enum value: UPGRADE_TYPE_PATCH = 2;
+   */
+  PATCH = 2,
+
+  /**
+   * @generated from enum value: UPGRADE_TYPE_MINOR = 3;
+   */
+  MINOR = 3,
+
+  /**
+   * @generated from enum value: UPGRADE_TYPE_MAJOR = 4;
+   */
+  MAJOR = 4,
+
+  /**
+   * The version is older than the one it is compared to.
+   *
+   * @generated from enum value: UPGRADE_TYPE_DOWNGRADE = 5;
+   */
+  DOWNGRADE = 5,
+}
+// Retrieve enum metadata with: proto3.getEnumType(UpgradeImpact_UpgradeType)
+proto3.util.setEnumType(
+  UpgradeImpact_UpgradeType,
+  "kubeappsapis.core.packages.v1alpha1.UpgradeImpact.UpgradeType",
+  [
+    { no: 0, name: "UPGRADE_TYPE_UNSPECIFIED" },
+    { no: 1, name: "UPGRADE_TYPE_NONE" },
+    { no: 2, name: "UPGRADE_TYPE_PATCH" },
+    { no: 3, name: "UPGRADE_TYPE_MINOR" },
+    { no: 4, name: "UPGRADE_TYPE_MAJOR" },
+    { no: 5, name: "UPGRADE_TYPE_DOWNGRADE" },
+  ],
+);
+
+/**
+ * Resource reference
+ *
+ * A reference to a Kubernetes resource related to a specific installed package.
+ * The context (cluster) for each resource is that of the related
+ * installed package.
+ *
+ * @generated from message kubeappsapis.core.packages.v1alpha1.ResourceRef
+ */
+export class ResourceRef extends Message<ResourceRef> {
+  /**
+   * The APIVersion directly from the resource has the group and version, eg. "apps/v1"
+   * or just the version for core resources.
+   *
+   * @generated from field: string api_version = 1;
+   */
+  apiVersion = "";
+
+  /**
+   * The Kind directly from the templated manifest. Together with the APIVersion this
+   * forms the GroupVersionKind.
+   *
+   * @generated from field: string kind = 2;
+   */
+  kind = "";
+
+  /**
+   * The name of the specific resource in the context of the installed package.
+   *
+   * @generated from field: string name = 3;
+   */
+  name = "";
+
+  /**
+   * The namespace of the specific resource in the context of the installed
+   * package. In most cases this will be identical to the namespace of the
+   * installed package. Exceptions will be non-namespaced resources and packages
+   * that install resources in other namespaces for special reasons.
+   *
+   * @generated from field: string namespace = 4;
+   */
+  namespace = "";
+
+  constructor(data?: PartialMessage<ResourceRef>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1alpha1.ResourceRef";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "api_version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
     { no: 2, name: "kind", kind: "scalar", T: 9 /* ScalarType.STRING */ },
     { no: 3, name: "name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
     { no: 4, name: "namespace", kind: "scalar", T: 9 /* ScalarType.STRING */ },
@@ -2874,3 +4494,509 @@ export class ResourceRef extends Message<ResourceRef> {
     return proto3.util.equals(ResourceRef, a, b);
   }
 }
+
+/**
+ * Partial results
+ *
+ * PartialResults indicates that a listing is incomplete, detailing why each
+ * of the omitted namespaces could not be included, so that "you cannot see
+ * namespace X" can be told apart from "there is nothing in namespace X".
+ *
+ * @generated from message kubeappsapis.core.packages.v1alpha1.PartialResults
+ */
+export class PartialResults extends Message<PartialResults> {
+  /**
+   * Namespace failures
+   *
+   * The namespaces which could not be included in the results.
+   *
+   * @generated from field: repeated kubeappsapis.core.packages.v1alpha1.NamespaceFailure namespace_failures = 1;
+   */
+  namespaceFailures: NamespaceFailure[] = [];
+
+  /**
+   * Plugin failures
+   *
+   * The plugins which could not be included in the results, such as when
+   * they did not respond in time, while the other plugins did.
+   *
+   * @generated from field: repeated kubeappsapis.core.packages.v1alpha1.PluginFailure plugin_failures = 2;
+   */
+  pluginFailures: PluginFailure[] = [];
+
+  constructor(data?: PartialMessage<PartialResults>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1alpha1.PartialResults";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "namespace_failures", kind: "message", T: NamespaceFailure, repeated: true },
+    { no: 2, name: "plugin_failures", kind: "message", T: PluginFailure, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): PartialResults {
+    return new PartialResults().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): PartialResults {
+    return new PartialResults().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): PartialResults {
+    return new PartialResults().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: PartialResults | PlainMessage<PartialResults> | undefined,
+    b: PartialResults | PlainMessage<PartialResults> | undefined,
+  ): boolean {
+    return proto3.util.equals(PartialResults, a, b);
+  }
+}
+
+/**
+ * Namespace failure
+ *
+ * A NamespaceFailure conveys why a namespace could not be included in a listing.
+ *
+ * @generated from message kubeappsapis.core.packages.v1alpha1.NamespaceFailure
+ */
+export class NamespaceFailure extends Message<NamespaceFailure> {
+  /**
+   * The context (cluster and namespace) which could not be listed.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1alpha1.Context context = 1;
+   */
+  context?: Context;
+
+  /**
+   * The plugin which could not list the namespace.
+   *
+   * @generated from field: kubeappsapis.core.plugins.v1alpha1.Plugin plugin = 2;
+   */
+  plugin?: Plugin;
+
+  /**
+   * The error code, eg. "permission_denied".
+   *
+   * @generated from field: string code = 3;
+   */
+  code = "";
+
+  /**
+   * A human readable reason for the failure.
+   *
+   * @generated from field: string reason = 4;
+   */
+  reason = "";
+
+  constructor(data?: PartialMessage<NamespaceFailure>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1alpha1.NamespaceFailure";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "context", kind: "message", T: Context },
+    { no: 2, name: "plugin", kind: "message", T: Plugin },
+    { no: 3, name: "code", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "reason", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): NamespaceFailure {
+    return new NamespaceFailure().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): NamespaceFailure {
+    return new NamespaceFailure().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): NamespaceFailure {
+    return new NamespaceFailure().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: NamespaceFailure | PlainMessage<NamespaceFailure> | undefined,
+    b: NamespaceFailure | PlainMessage<NamespaceFailure> | undefined,
+  ): boolean {
+    return proto3.util.equals(NamespaceFailure, a, b);
+  }
+}
+
+/**
+ * Plugin failure
+ *
+ * A PluginFailure conveys why a plugin could not be included in a listing
+ * aggregating the results of several plugins.
+ *
+ * @generated from message kubeappsapis.core.packages.v1alpha1.PluginFailure
+ */
+export class PluginFailure extends Message<PluginFailure> {
+  /**
+   * The plugin which could not be included.
+   *
+   * @generated from field: kubeappsapis.core.plugins.v1alpha1.Plugin plugin = 1;
+   */
+  plugin?: Plugin;
+
+  /**
+   * The error code, eg. "deadline_exceeded".
+   *
+   * @generated from field: string code = 2;
+   */
+  code = "";
+
+  /**
+   * A human readable reason for the failure.
+   *
+   * @generated from field: string reason = 3;
+   */
+  reason = "";
+
+  constructor(data?: PartialMessage<PluginFailure>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1alpha1.PluginFailure";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "plugin", kind: "message", T: Plugin },
+    { no: 2, name: "code", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "reason", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): PluginFailure {
+    return new PluginFailure().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): PluginFailure {
+    return new PluginFailure().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): PluginFailure {
+    return new PluginFailure().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: PluginFailure | PlainMessage<PluginFailure> | undefined,
+    b: PluginFailure | PlainMessage<PluginFailure> | undefined,
+  ): boolean {
+    return proto3.util.equals(PluginFailure, a, b);
+  }
+}
+
+/**
+ * Truncated text asset
+ *
+ * A TruncatedTextAsset reports a text asset which has been truncated in a response.
+ *
+ * @generated from message kubeappsapis.core.packages.v1alpha1.TruncatedTextAsset
+ */
+export class TruncatedTextAsset extends Message<TruncatedTextAsset> {
+  /**
+   * The truncated text asset.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1alpha1.TextAsset asset = 1;
+   */
+  asset = TextAsset.UNSPECIFIED;
+
+  /**
+   * The total size, in bytes, of the text asset.
+   *
+   * @generated from field: int64 total_size = 2;
+   */
+  totalSize = protoInt64.zero;
+
+  constructor(data?: PartialMessage<TruncatedTextAsset>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1alpha1.TruncatedTextAsset";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "asset", kind: "enum", T: proto3.getEnumType(TextAsset) },
+    { no: 2, name: "total_size", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): TruncatedTextAsset {
+    return new TruncatedTextAsset().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): TruncatedTextAsset {
+    return new TruncatedTextAsset().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): TruncatedTextAsset {
+    return new TruncatedTextAsset().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: TruncatedTextAsset | PlainMessage<TruncatedTextAsset> | undefined,
+    b: TruncatedTextAsset | PlainMessage<TruncatedTextAsset> | undefined,
+  ): boolean {
+    return proto3.util.equals(TruncatedTextAsset, a, b);
+  }
+}
+
+/**
+ * Package icon
+ *
+ * A PackageIcon is the icon of a package, in the variants suitable for
+ * listings and details. Icons inlined as data URLs are returned as data URLs.
+ * Icons referenced by an http(s) URL are returned as is, or as URLs of the
+ * kubeapps-apis icon proxy when it is enabled.
+ *
+ * @generated from message kubeappsapis.core.packages.v1alpha1.PackageIcon
+ */
+export class PackageIcon extends Message<PackageIcon> {
+  /**
+   * The URL of the full-size icon.
+   *
+   * @generated from field: string url = 1;
+   */
+  url = "";
+
+  /**
+   * The URL of the thumbnail of the icon, no bigger than 64x64 pixels. Vector
+   * icons are their own thumbnail.
+   *
+   * @generated from field: string thumbnail_url = 2;
+   */
+  thumbnailUrl = "";
+
+  /**
+   * The media type of the icon, such as "image/svg+xml" or "image/png", as
+   * sniffed from its content. Empty for external icons, fetched when requested.
+   *
+   * @generated from field: string media_type = 3;
+   */
+  mediaType = "";
+
+  constructor(data?: PartialMessage<PackageIcon>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1alpha1.PackageIcon";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "url", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "thumbnail_url", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "media_type", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): PackageIcon {
+    return new PackageIcon().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): PackageIcon {
+    return new PackageIcon().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): PackageIcon {
+    return new PackageIcon().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: PackageIcon | PlainMessage<PackageIcon> | undefined,
+    b: PackageIcon | PlainMessage<PackageIcon> | undefined,
+  ): boolean {
+    return proto3.util.equals(PackageIcon, a, b);
+  }
+}
+
+/**
+ * Package version changelog
+ *
+ * The changes introduced by a specific version of a package.
+ *
+ * @generated from message kubeappsapis.core.packages.v1alpha1.PackageVersionChangelog
+ */
+export class PackageVersionChangelog extends Message<PackageVersionChangelog> {
+  /**
+   * The version of the package.
+   *
+   * @generated from field: string pkg_version = 1;
+   */
+  pkgVersion = "";
+
+  /**
+   * The release notes of the version, as free-form text, if any.
+   *
+   * @generated from field: string release_notes = 2;
+   */
+  releaseNotes = "";
+
+  /**
+   * The individual changes of the version, if listed by the package.
+   *
+   * @generated from field: repeated kubeappsapis.core.packages.v1alpha1.PackageChange changes = 3;
+   */
+  changes: PackageChange[] = [];
+
+  constructor(data?: PartialMessage<PackageVersionChangelog>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1alpha1.PackageVersionChangelog";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "pkg_version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "release_notes", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "changes", kind: "message", T: PackageChange, repeated: true },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): PackageVersionChangelog {
+    return new PackageVersionChangelog().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): PackageVersionChangelog {
+    return new PackageVersionChangelog().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): PackageVersionChangelog {
+    return new PackageVersionChangelog().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: PackageVersionChangelog | PlainMessage<PackageVersionChangelog> | undefined,
+    b: PackageVersionChangelog | PlainMessage<PackageVersionChangelog> | undefined,
+  ): boolean {
+    return proto3.util.equals(PackageVersionChangelog, a, b);
+  }
+}
+
+/**
+ * Package change
+ *
+ * A single change introduced by a version of a package, eg. as listed in
+ * the artifacthub.io/changes annotation of a chart.
+ *
+ * @generated from message kubeappsapis.core.packages.v1alpha1.PackageChange
+ */
+export class PackageChange extends Message<PackageChange> {
+  /**
+   * The kind of change, eg. "added", "changed", "fixed" or "security".
+   *
+   * @generated from field: string kind = 1;
+   */
+  kind = "";
+
+  /**
+   * The description of the change.
+   *
+   * @generated from field: string description = 2;
+   */
+  description = "";
+
+  /**
+   * Links related to the change, eg. to an issue or a pull request.
+   *
+   * @generated from field: repeated kubeappsapis.core.packages.v1alpha1.PackageChangeLink links = 3;
+   */
+  links: PackageChangeLink[] = [];
+
+  constructor(data?: PartialMessage<PackageChange>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1alpha1.PackageChange";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "kind", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "description", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "links", kind: "message", T: PackageChangeLink, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): PackageChange {
+    return new PackageChange().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): PackageChange {
+    return new PackageChange().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): PackageChange {
+    return new PackageChange().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: PackageChange | PlainMessage<PackageChange> | undefined,
+    b: PackageChange | PlainMessage<PackageChange> | undefined,
+  ): boolean {
+    return proto3.util.equals(PackageChange, a, b);
+  }
+}
+
+/**
+ * Package change link
+ *
+ * A link related to a change of a package.
+ *
+ * @generated from message kubeappsapis.core.packages.v1alpha1.PackageChangeLink
+ */
+export class PackageChangeLink extends Message<PackageChangeLink> {
+  /**
+   * The name of the link.
+   *
+   * @generated from field: string name = 1;
+   */
+  name = "";
+
+  /**
+   * The URL of the link.
+   *
+   * @generated from field: string url = 2;
+   */
+  url = "";
+
+  constructor(data?: PartialMessage<PackageChangeLink>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1alpha1.PackageChangeLink";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "url", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): PackageChangeLink {
+    return new PackageChangeLink().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): PackageChangeLink {
+    return new PackageChangeLink().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): PackageChangeLink {
+    return new PackageChangeLink().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: PackageChangeLink | PlainMessage<PackageChangeLink> | undefined,
+    b: PackageChangeLink | PlainMessage<PackageChangeLink> | undefined,
+  ): boolean {
+    return proto3.util.equals(PackageChangeLink, a, b);
+  }
+}
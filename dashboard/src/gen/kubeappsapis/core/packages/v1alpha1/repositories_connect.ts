@@ -17,13 +17,19 @@ import {
   GetPackageRepositoryPermissionsResponse,
   GetPackageRepositorySummariesRequest,
   GetPackageRepositorySummariesResponse,
+  ListPackageRepositoryPackagesRequest,
+  ListPackageRepositoryPackagesResponse,
+  TestPackageRepositoryConnectionRequest,
+  TestPackageRepositoryConnectionResponse,
   UpdatePackageRepositoryRequest,
   UpdatePackageRepositoryResponse,
 } from "./repositories_pb";
 import { MethodKind } from "@bufbuild/protobuf";
 
 /**
- * Each repositories v1alpha1 plugin must implement at least the following rpcs:
+ * Each repositories v1alpha1 plugin must implement at least the following rpcs.
+ * Clients should use the stable kubeappsapis.core.packages.v1.RepositoriesService
+ * instead, the core one being deprecated and kept for compatibility only:
  *
  *
  * @generated from service kubeappsapis.core.packages.v1alpha1.RepositoriesService
@@ -85,5 +91,23 @@ export const RepositoriesService = {
       O: GetPackageRepositoryPermissionsResponse,
       kind: MethodKind.Unary,
     },
+    /**
+     * @generated from rpc kubeappsapis.core.packages.v1alpha1.RepositoriesService.TestPackageRepositoryConnection
+     */
+    testPackageRepositoryConnection: {
+      name: "TestPackageRepositoryConnection",
+      I: TestPackageRepositoryConnectionRequest,
+      O: TestPackageRepositoryConnectionResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc kubeappsapis.core.packages.v1alpha1.RepositoriesService.ListPackageRepositoryPackages
+     */
+    listPackageRepositoryPackages: {
+      name: "ListPackageRepositoryPackages",
+      I: ListPackageRepositoryPackagesRequest,
+      O: ListPackageRepositoryPackagesResponse,
+      kind: MethodKind.Unary,
+    },
   },
 } as const;
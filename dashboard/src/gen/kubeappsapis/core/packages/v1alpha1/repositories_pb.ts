@@ -14,8 +14,14 @@ import type {
   PartialMessage,
   PlainMessage,
 } from "@bufbuild/protobuf";
-import { Any, Message, proto3 } from "@bufbuild/protobuf";
-import { Context } from "./packages_pb";
+import { Any, Message, proto3, Timestamp } from "@bufbuild/protobuf";
+import {
+  AvailablePackageSummary,
+  Context,
+  PaginationOptions,
+  PartialResults,
+  ResourceRef,
+} from "./packages_pb";
 import { Plugin } from "../../plugins/v1alpha1/plugins_pb";
 
 /**
@@ -913,6 +919,15 @@ export class GetPackageRepositoryDetailRequest extends Message<GetPackageReposit
    */
   packageRepoRef?: PackageRepositoryReference;
 
+  /**
+   * Whether to also check, with the credentials of the repository, that the
+   * repository can be fetched from Kubeapps. The result is returned in the
+   * custom detail by the plugins supporting it, and ignored by the others.
+   *
+   * @generated from field: bool include_probe = 2;
+   */
+  includeProbe = false;
+
   constructor(data?: PartialMessage<GetPackageRepositoryDetailRequest>) {
     super();
     proto3.util.initPartial(data, this);
@@ -923,6 +938,7 @@ export class GetPackageRepositoryDetailRequest extends Message<GetPackageReposit
     "kubeappsapis.core.packages.v1alpha1.GetPackageRepositoryDetailRequest";
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
     { no: 1, name: "package_repo_ref", kind: "message", T: PackageRepositoryReference },
+    { no: 2, name: "include_probe", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
   ]);
 
   static fromBinary(
@@ -1156,6 +1172,20 @@ export class DeletePackageRepositoryRequest extends Message<DeletePackageReposit
    */
   packageRepoRef?: PackageRepositoryReference;
 
+  /**
+   * Retain referenced secrets
+   *
+   * A package repository whose secrets managed by the plugin are still
+   * referenced by other objects, such as other package repositories or
+   * installed packages, is not deleted by default: the request fails with a
+   * FailedPrecondition error whose details list the PackageRepositorySecretUsage
+   * of each such secret. When set, the package repository is deleted while the
+   * secrets still referenced are retained, no longer owned by it.
+   *
+   * @generated from field: bool retain_referenced_secrets = 2;
+   */
+  retainReferencedSecrets = false;
+
   constructor(data?: PartialMessage<DeletePackageRepositoryRequest>) {
     super();
     proto3.util.initPartial(data, this);
@@ -1165,6 +1195,7 @@ export class DeletePackageRepositoryRequest extends Message<DeletePackageReposit
   static readonly typeName = "kubeappsapis.core.packages.v1alpha1.DeletePackageRepositoryRequest";
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
     { no: 1, name: "package_repo_ref", kind: "message", T: PackageRepositoryReference },
+    { no: 2, name: "retain_referenced_secrets", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
   ]);
 
   static fromBinary(
@@ -1364,6 +1395,35 @@ export class PackageRepositoryStatus extends Message<PackageRepositoryStatus> {
    */
   userReason = "";
 
+  /**
+   * Conditions
+   *
+   * Optional list of the conditions reported by the plugin for the repository,
+   * which may be plugin specific.
+   *
+   * @generated from field: repeated kubeappsapis.core.packages.v1alpha1.PackageRepositoryCondition conditions = 4;
+   */
+  conditions: PackageRepositoryCondition[] = [];
+
+  /**
+   * ConsecutiveFailures
+   *
+   * Optional number of consecutive failed attempts to sync the repository.
+   *
+   * @generated from field: int32 consecutive_failures = 5;
+   */
+  consecutiveFailures = 0;
+
+  /**
+   * NextRetryTime
+   *
+   * Optional time at which the next attempt to sync a failed repository is
+   * expected.
+   *
+   * @generated from field: google.protobuf.Timestamp next_retry_time = 6;
+   */
+  nextRetryTime?: Timestamp;
+
   constructor(data?: PartialMessage<PackageRepositoryStatus>) {
     super();
     proto3.util.initPartial(data, this);
@@ -1380,6 +1440,9 @@ export class PackageRepositoryStatus extends Message<PackageRepositoryStatus> {
       T: proto3.getEnumType(PackageRepositoryStatus_StatusReason),
     },
     { no: 3, name: "user_reason", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "conditions", kind: "message", T: PackageRepositoryCondition, repeated: true },
+    { no: 5, name: "consecutive_failures", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 6, name: "next_retry_time", kind: "message", T: Timestamp },
   ]);
 
   static fromBinary(
@@ -1452,6 +1515,86 @@ proto3.util.setEnumType(
   ],
 );
 
+/**
+ * PackageRepositoryCondition
+ *
+ * A PackageRepositoryCondition reports one of the conditions of the repository,
+ * such as the reason why its last sync failed.
+ *
+ * @generated from message kubeappsapis.core.packages.v1alpha1.PackageRepositoryCondition
+ */
+export class PackageRepositoryCondition extends Message<PackageRepositoryCondition> {
+  /**
+   * The type of the condition (e.g. ReconcileFailed)
+   *
+   * @generated from field: string type = 1;
+   */
+  type = "";
+
+  /**
+   * The status of the condition (e.g. True, False or Unknown)
+   *
+   * @generated from field: string status = 2;
+   */
+  status = "";
+
+  /**
+   * A machine-readable reason for the condition
+   *
+   * @generated from field: string reason = 3;
+   */
+  reason = "";
+
+  /**
+   * A human-readable message with details about the condition
+   *
+   * @generated from field: string message = 4;
+   */
+  message = "";
+
+  constructor(data?: PartialMessage<PackageRepositoryCondition>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1alpha1.PackageRepositoryCondition";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "type", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "status", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "reason", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "message", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): PackageRepositoryCondition {
+    return new PackageRepositoryCondition().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): PackageRepositoryCondition {
+    return new PackageRepositoryCondition().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): PackageRepositoryCondition {
+    return new PackageRepositoryCondition().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: PackageRepositoryCondition | PlainMessage<PackageRepositoryCondition> | undefined,
+    b: PackageRepositoryCondition | PlainMessage<PackageRepositoryCondition> | undefined,
+  ): boolean {
+    return proto3.util.equals(PackageRepositoryCondition, a, b);
+  }
+}
+
 /**
  * PackageRepositoryDetail
  *
@@ -1786,6 +1929,16 @@ export class GetPackageRepositorySummariesResponse extends Message<GetPackageRep
    */
   packageRepositorySummaries: PackageRepositorySummary[] = [];
 
+  /**
+   * Partial results
+   *
+   * This optional field is set when the results do not include some of the
+   * requested namespaces, for example because the user cannot access them.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1alpha1.PartialResults partial_results = 2;
+   */
+  partialResults?: PartialResults;
+
   constructor(data?: PartialMessage<GetPackageRepositorySummariesResponse>) {
     super();
     proto3.util.initPartial(data, this);
@@ -1802,6 +1955,7 @@ export class GetPackageRepositorySummariesResponse extends Message<GetPackageRep
       T: PackageRepositorySummary,
       repeated: true,
     },
+    { no: 2, name: "partial_results", kind: "message", T: PartialResults },
   ]);
 
   static fromBinary(
@@ -1902,6 +2056,16 @@ export class UpdatePackageRepositoryResponse extends Message<UpdatePackageReposi
  * @generated from message kubeappsapis.core.packages.v1alpha1.DeletePackageRepositoryResponse
  */
 export class DeletePackageRepositoryResponse extends Message<DeletePackageRepositoryResponse> {
+  /**
+   * Retained secrets
+   *
+   * The secrets managed by the plugin for the deleted package repository which
+   * were retained since other objects still reference them.
+   *
+   * @generated from field: repeated kubeappsapis.core.packages.v1alpha1.PackageRepositorySecretUsage retained_secrets = 1;
+   */
+  retainedSecrets: PackageRepositorySecretUsage[] = [];
+
   constructor(data?: PartialMessage<DeletePackageRepositoryResponse>) {
     super();
     proto3.util.initPartial(data, this);
@@ -1909,7 +2073,15 @@ export class DeletePackageRepositoryResponse extends Message<DeletePackageReposi
 
   static readonly runtime: typeof proto3 = proto3;
   static readonly typeName = "kubeappsapis.core.packages.v1alpha1.DeletePackageRepositoryResponse";
-  static readonly fields: FieldList = proto3.util.newFieldList(() => []);
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    {
+      no: 1,
+      name: "retained_secrets",
+      kind: "message",
+      T: PackageRepositorySecretUsage,
+      repeated: true,
+    },
+  ]);
 
   static fromBinary(
     bytes: Uint8Array,
@@ -1940,6 +2112,70 @@ export class DeletePackageRepositoryResponse extends Message<DeletePackageReposi
   }
 }
 
+/**
+ * PackageRepositorySecretUsage
+ *
+ * A secret managed by a plugin for a package repository, along with the other
+ * objects referencing it.
+ *
+ * @generated from message kubeappsapis.core.packages.v1alpha1.PackageRepositorySecretUsage
+ */
+export class PackageRepositorySecretUsage extends Message<PackageRepositorySecretUsage> {
+  /**
+   * The name of the secret, in the namespace of the package repository.
+   *
+   * @generated from field: string secret_name = 1;
+   */
+  secretName = "";
+
+  /**
+   * The objects, other than the package repository, referencing the secret.
+   *
+   * @generated from field: repeated kubeappsapis.core.packages.v1alpha1.ResourceRef referencing_resources = 2;
+   */
+  referencingResources: ResourceRef[] = [];
+
+  constructor(data?: PartialMessage<PackageRepositorySecretUsage>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.packages.v1alpha1.PackageRepositorySecretUsage";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "secret_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "referencing_resources", kind: "message", T: ResourceRef, repeated: true },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): PackageRepositorySecretUsage {
+    return new PackageRepositorySecretUsage().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): PackageRepositorySecretUsage {
+    return new PackageRepositorySecretUsage().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): PackageRepositorySecretUsage {
+    return new PackageRepositorySecretUsage().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: PackageRepositorySecretUsage | PlainMessage<PackageRepositorySecretUsage> | undefined,
+    b: PackageRepositorySecretUsage | PlainMessage<PackageRepositorySecretUsage> | undefined,
+  ): boolean {
+    return proto3.util.equals(PackageRepositorySecretUsage, a, b);
+  }
+}
+
 /**
  * @generated from message kubeappsapis.core.packages.v1alpha1.GetPackageRepositoryPermissionsRequest
  */
@@ -2136,3 +2372,328 @@ export class GetPackageRepositoryPermissionsResponse extends Message<GetPackageR
     return proto3.util.equals(GetPackageRepositoryPermissionsResponse, a, b);
   }
 }
+
+/**
+ * TestPackageRepositoryConnectionRequest
+ *
+ * Request for TestPackageRepositoryConnection
+ *
+ * @generated from message kubeappsapis.core.packages.v1alpha1.TestPackageRepositoryConnectionRequest
+ */
+export class TestPackageRepositoryConnectionRequest extends Message<TestPackageRepositoryConnectionRequest> {
+  /**
+   * The package repository to test, with the same details that would be
+   * used to add it. Nothing is created when testing the connection.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1alpha1.AddPackageRepositoryRequest package_repository = 1;
+   */
+  packageRepository?: AddPackageRepositoryRequest;
+
+  constructor(data?: PartialMessage<TestPackageRepositoryConnectionRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName =
+    "kubeappsapis.core.packages.v1alpha1.TestPackageRepositoryConnectionRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "package_repository", kind: "message", T: AddPackageRepositoryRequest },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): TestPackageRepositoryConnectionRequest {
+    return new TestPackageRepositoryConnectionRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): TestPackageRepositoryConnectionRequest {
+    return new TestPackageRepositoryConnectionRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): TestPackageRepositoryConnectionRequest {
+    return new TestPackageRepositoryConnectionRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | TestPackageRepositoryConnectionRequest
+      | PlainMessage<TestPackageRepositoryConnectionRequest>
+      | undefined,
+    b:
+      | TestPackageRepositoryConnectionRequest
+      | PlainMessage<TestPackageRepositoryConnectionRequest>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(TestPackageRepositoryConnectionRequest, a, b);
+  }
+}
+
+/**
+ * TestPackageRepositoryConnectionResponse
+ *
+ * Response for TestPackageRepositoryConnection. A repository which cannot be
+ * reached, or which rejects the provided credentials, results in an error
+ * rather than a response.
+ *
+ * @generated from message kubeappsapis.core.packages.v1alpha1.TestPackageRepositoryConnectionResponse
+ */
+export class TestPackageRepositoryConnectionResponse extends Message<TestPackageRepositoryConnectionResponse> {
+  /**
+   * A human-readable summary of the successful connection
+   *
+   * @generated from field: string message = 1;
+   */
+  message = "";
+
+  /**
+   * The number of packages discovered in the repository, only set when the
+   * plugin can determine it without fetching the whole repository
+   *
+   * @generated from field: optional int32 package_count = 2;
+   */
+  packageCount?: number;
+
+  /**
+   * Other metadata discovered when connecting to the repository, such as
+   * the digest of an imgpkg bundle or the revision of a git reference
+   *
+   * @generated from field: map<string, string> metadata = 3;
+   */
+  metadata: { [key: string]: string } = {};
+
+  constructor(data?: PartialMessage<TestPackageRepositoryConnectionResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName =
+    "kubeappsapis.core.packages.v1alpha1.TestPackageRepositoryConnectionResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "message", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "package_count", kind: "scalar", T: 5 /* ScalarType.INT32 */, opt: true },
+    {
+      no: 3,
+      name: "metadata",
+      kind: "map",
+      K: 9 /* ScalarType.STRING */,
+      V: { kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): TestPackageRepositoryConnectionResponse {
+    return new TestPackageRepositoryConnectionResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): TestPackageRepositoryConnectionResponse {
+    return new TestPackageRepositoryConnectionResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): TestPackageRepositoryConnectionResponse {
+    return new TestPackageRepositoryConnectionResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | TestPackageRepositoryConnectionResponse
+      | PlainMessage<TestPackageRepositoryConnectionResponse>
+      | undefined,
+    b:
+      | TestPackageRepositoryConnectionResponse
+      | PlainMessage<TestPackageRepositoryConnectionResponse>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(TestPackageRepositoryConnectionResponse, a, b);
+  }
+}
+
+/**
+ * ListPackageRepositoryPackagesRequest
+ *
+ * Request for ListPackageRepositoryPackages
+ *
+ * @generated from message kubeappsapis.core.packages.v1alpha1.ListPackageRepositoryPackagesRequest
+ */
+export class ListPackageRepositoryPackagesRequest extends Message<ListPackageRepositoryPackagesRequest> {
+  /**
+   * The package repository whose packages are listed
+   *
+   * @generated from field: kubeappsapis.core.packages.v1alpha1.PackageRepositoryReference package_repo_ref = 1;
+   */
+  packageRepoRef?: PackageRepositoryReference;
+
+  /**
+   * Pagination options specifying where to start and how many results to include.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1alpha1.PaginationOptions pagination_options = 2;
+   */
+  paginationOptions?: PaginationOptions;
+
+  constructor(data?: PartialMessage<ListPackageRepositoryPackagesRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName =
+    "kubeappsapis.core.packages.v1alpha1.ListPackageRepositoryPackagesRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "package_repo_ref", kind: "message", T: PackageRepositoryReference },
+    { no: 2, name: "pagination_options", kind: "message", T: PaginationOptions },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): ListPackageRepositoryPackagesRequest {
+    return new ListPackageRepositoryPackagesRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): ListPackageRepositoryPackagesRequest {
+    return new ListPackageRepositoryPackagesRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): ListPackageRepositoryPackagesRequest {
+    return new ListPackageRepositoryPackagesRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | ListPackageRepositoryPackagesRequest
+      | PlainMessage<ListPackageRepositoryPackagesRequest>
+      | undefined,
+    b:
+      | ListPackageRepositoryPackagesRequest
+      | PlainMessage<ListPackageRepositoryPackagesRequest>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(ListPackageRepositoryPackagesRequest, a, b);
+  }
+}
+
+/**
+ * ListPackageRepositoryPackagesResponse
+ *
+ * Response for ListPackageRepositoryPackages
+ *
+ * @generated from message kubeappsapis.core.packages.v1alpha1.ListPackageRepositoryPackagesResponse
+ */
+export class ListPackageRepositoryPackagesResponse extends Message<ListPackageRepositoryPackagesResponse> {
+  /**
+   * Available packages summaries
+   *
+   * The available packages contributed by the package repository.
+   *
+   * @generated from field: repeated kubeappsapis.core.packages.v1alpha1.AvailablePackageSummary available_package_summaries = 1;
+   */
+  availablePackageSummaries: AvailablePackageSummary[] = [];
+
+  /**
+   * Next page token
+   *
+   * This field represents the pagination token to retrieve the next page of
+   * results. If the value is "", it means no further results for the request.
+   *
+   * @generated from field: string next_page_token = 2;
+   */
+  nextPageToken = "";
+
+  /**
+   * Total count
+   *
+   * This optional field contains the total number of packages contributed by
+   * the repository, across every page. It is only set when the plugin can
+   * determine it.
+   *
+   * @generated from field: optional int32 total_count = 3;
+   */
+  totalCount?: number;
+
+  /**
+   * Last updated
+   *
+   * The time, in RFC 3339 format, at which the packages of the repository were
+   * last fetched, if known.
+   *
+   * @generated from field: string last_updated = 4;
+   */
+  lastUpdated = "";
+
+  constructor(data?: PartialMessage<ListPackageRepositoryPackagesResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName =
+    "kubeappsapis.core.packages.v1alpha1.ListPackageRepositoryPackagesResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    {
+      no: 1,
+      name: "available_package_summaries",
+      kind: "message",
+      T: AvailablePackageSummary,
+      repeated: true,
+    },
+    { no: 2, name: "next_page_token", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "total_count", kind: "scalar", T: 5 /* ScalarType.INT32 */, opt: true },
+    { no: 4, name: "last_updated", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): ListPackageRepositoryPackagesResponse {
+    return new ListPackageRepositoryPackagesResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): ListPackageRepositoryPackagesResponse {
+    return new ListPackageRepositoryPackagesResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): ListPackageRepositoryPackagesResponse {
+    return new ListPackageRepositoryPackagesResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | ListPackageRepositoryPackagesResponse
+      | PlainMessage<ListPackageRepositoryPackagesResponse>
+      | undefined,
+    b:
+      | ListPackageRepositoryPackagesResponse
+      | PlainMessage<ListPackageRepositoryPackagesResponse>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(ListPackageRepositoryPackagesResponse, a, b);
+  }
+}
@@ -6,7 +6,14 @@
 /* eslint-disable */
 // @ts-nocheck
 
-import { GetConfiguredPluginsRequest, GetConfiguredPluginsResponse } from "./plugins_pb";
+import {
+  GetConfiguredPluginsRequest,
+  GetConfiguredPluginsResponse,
+  GetFeaturesRequest,
+  GetFeaturesResponse,
+  GetPluginConfigsRequest,
+  GetPluginConfigsResponse,
+} from "./plugins_pb";
 import { MethodKind } from "@bufbuild/protobuf";
 
 /**
@@ -26,5 +33,32 @@ export const PluginsService = {
       O: GetConfiguredPluginsResponse,
       kind: MethodKind.Unary,
     },
+    /**
+     * GetFeatures returns a versioned descriptor of the features enabled in the
+     * backend (plugins, clusters and operations), along with its checksum and,
+     * if a signing key is configured, its signature, so that the dashboard can
+     * bootstrap with a single call and verify the configuration it renders.
+     *
+     * @generated from rpc kubeappsapis.core.plugins.v1alpha1.PluginsService.GetFeatures
+     */
+    getFeatures: {
+      name: "GetFeatures",
+      I: GetFeaturesRequest,
+      O: GetFeaturesResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * GetPluginConfigs returns the configuration currently in effect for each
+     * plugin supporting the reload of its configuration at runtime, along with
+     * the result of the last reload.
+     *
+     * @generated from rpc kubeappsapis.core.plugins.v1alpha1.PluginsService.GetPluginConfigs
+     */
+    getPluginConfigs: {
+      name: "GetPluginConfigs",
+      I: GetPluginConfigsRequest,
+      O: GetPluginConfigsResponse,
+      kind: MethodKind.Unary,
+    },
   },
 } as const;
@@ -14,7 +14,7 @@ import type {
   PartialMessage,
   PlainMessage,
 } from "@bufbuild/protobuf";
-import { Message, proto3 } from "@bufbuild/protobuf";
+import { Message, proto3, Timestamp } from "@bufbuild/protobuf";
 
 /**
  * GetConfiguredPluginsRequest
@@ -24,6 +24,17 @@ import { Message, proto3 } from "@bufbuild/protobuf";
  * @generated from message kubeappsapis.core.plugins.v1alpha1.GetConfiguredPluginsRequest
  */
 export class GetConfiguredPluginsRequest extends Message<GetConfiguredPluginsRequest> {
+  /**
+   * Probe
+   *
+   * Whether to probe each plugin for the resources it needs, such as its CRDs,
+   * which makes the request slower. Defaults to false, as the request is
+   * also used for the liveness and readiness checks.
+   *
+   * @generated from field: bool probe = 1;
+   */
+  probe = false;
+
   constructor(data?: PartialMessage<GetConfiguredPluginsRequest>) {
     super();
     proto3.util.initPartial(data, this);
@@ -31,7 +42,9 @@ export class GetConfiguredPluginsRequest extends Message<GetConfiguredPluginsReq
 
   static readonly runtime: typeof proto3 = proto3;
   static readonly typeName = "kubeappsapis.core.plugins.v1alpha1.GetConfiguredPluginsRequest";
-  static readonly fields: FieldList = proto3.util.newFieldList(() => []);
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "probe", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+  ]);
 
   static fromBinary(
     bytes: Uint8Array,
@@ -79,6 +92,27 @@ export class GetConfiguredPluginsResponse extends Message<GetConfiguredPluginsRe
    */
   plugins: Plugin[] = [];
 
+  /**
+   * Health
+   *
+   * The health of each plugin, in the same order as the plugins, from the
+   * outcome of the calls made to it when aggregating the results of several
+   * plugins, such as when listing the available packages.
+   *
+   * @generated from field: repeated kubeappsapis.core.plugins.v1alpha1.PluginHealth health = 2;
+   */
+  health: PluginHealth[] = [];
+
+  /**
+   * Details
+   *
+   * The build version, capabilities and, if requested, the probe result of
+   * each plugin, in the same order as the plugins.
+   *
+   * @generated from field: repeated kubeappsapis.core.plugins.v1alpha1.PluginDetails details = 3;
+   */
+  details: PluginDetails[] = [];
+
   constructor(data?: PartialMessage<GetConfiguredPluginsResponse>) {
     super();
     proto3.util.initPartial(data, this);
@@ -88,6 +122,8 @@ export class GetConfiguredPluginsResponse extends Message<GetConfiguredPluginsRe
   static readonly typeName = "kubeappsapis.core.plugins.v1alpha1.GetConfiguredPluginsResponse";
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
     { no: 1, name: "plugins", kind: "message", T: Plugin, repeated: true },
+    { no: 2, name: "health", kind: "message", T: PluginHealth, repeated: true },
+    { no: 3, name: "details", kind: "message", T: PluginDetails, repeated: true },
   ]);
 
   static fromBinary(
@@ -176,3 +212,838 @@ export class Plugin extends Message<Plugin> {
     return proto3.util.equals(Plugin, a, b);
   }
 }
+
+/**
+ * PluginHealth
+ *
+ * The health of a plugin, which is unhealthy while its calls fail or time out.
+ *
+ * @generated from message kubeappsapis.core.plugins.v1alpha1.PluginHealth
+ */
+export class PluginHealth extends Message<PluginHealth> {
+  /**
+   * Plugin
+   *
+   * The plugin name and version.
+   *
+   * @generated from field: kubeappsapis.core.plugins.v1alpha1.Plugin plugin = 1;
+   */
+  plugin?: Plugin;
+
+  /**
+   * Healthy
+   *
+   * Whether the last call to the plugin succeeded. Plugins not called yet are healthy.
+   *
+   * @generated from field: bool healthy = 2;
+   */
+  healthy = false;
+
+  /**
+   * Consecutive failures
+   *
+   * The number of calls to the plugin which failed since the last successful one.
+   *
+   * @generated from field: uint32 consecutive_failures = 3;
+   */
+  consecutiveFailures = 0;
+
+  /**
+   * Last error
+   *
+   * The error of the last failed call to the plugin.
+   *
+   * @generated from field: string last_error = 4;
+   */
+  lastError = "";
+
+  /**
+   * Last failure time
+   *
+   * The time of the last failed call to the plugin, if any.
+   *
+   * @generated from field: google.protobuf.Timestamp last_failure_time = 5;
+   */
+  lastFailureTime?: Timestamp;
+
+  /**
+   * Last success time
+   *
+   * The time of the last successful call to the plugin, if any.
+   *
+   * @generated from field: google.protobuf.Timestamp last_success_time = 6;
+   */
+  lastSuccessTime?: Timestamp;
+
+  constructor(data?: PartialMessage<PluginHealth>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.plugins.v1alpha1.PluginHealth";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "plugin", kind: "message", T: Plugin },
+    { no: 2, name: "healthy", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 3, name: "consecutive_failures", kind: "scalar", T: 13 /* ScalarType.UINT32 */ },
+    { no: 4, name: "last_error", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "last_failure_time", kind: "message", T: Timestamp },
+    { no: 6, name: "last_success_time", kind: "message", T: Timestamp },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): PluginHealth {
+    return new PluginHealth().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): PluginHealth {
+    return new PluginHealth().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): PluginHealth {
+    return new PluginHealth().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: PluginHealth | PlainMessage<PluginHealth> | undefined,
+    b: PluginHealth | PlainMessage<PluginHealth> | undefined,
+  ): boolean {
+    return proto3.util.equals(PluginHealth, a, b);
+  }
+}
+
+/**
+ * PluginDetails
+ *
+ * The details of a registered plugin, to diagnose the plugins which are
+ * registered but not working.
+ *
+ * @generated from message kubeappsapis.core.plugins.v1alpha1.PluginDetails
+ */
+export class PluginDetails extends Message<PluginDetails> {
+  /**
+   * Plugin
+   *
+   * The plugin name and version.
+   *
+   * @generated from field: kubeappsapis.core.plugins.v1alpha1.Plugin plugin = 1;
+   */
+  plugin?: Plugin;
+
+  /**
+   * Build version
+   *
+   * The version the plugin was built from, or `devel` if it was not set
+   * during the build. Empty if the plugin does not report it.
+   *
+   * @generated from field: string build_version = 2;
+   */
+  buildVersion = "";
+
+  /**
+   * Capabilities
+   *
+   * The optional functionality supported by the plugin.
+   *
+   * @generated from field: kubeappsapis.core.plugins.v1alpha1.PluginCapabilities capabilities = 3;
+   */
+  capabilities?: PluginCapabilities;
+
+  /**
+   * Probe
+   *
+   * The result of probing the plugin, only set when requested.
+   *
+   * @generated from field: kubeappsapis.core.plugins.v1alpha1.PluginProbe probe = 4;
+   */
+  probe?: PluginProbe;
+
+  constructor(data?: PartialMessage<PluginDetails>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.plugins.v1alpha1.PluginDetails";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "plugin", kind: "message", T: Plugin },
+    { no: 2, name: "build_version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "capabilities", kind: "message", T: PluginCapabilities },
+    { no: 4, name: "probe", kind: "message", T: PluginProbe },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): PluginDetails {
+    return new PluginDetails().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): PluginDetails {
+    return new PluginDetails().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): PluginDetails {
+    return new PluginDetails().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: PluginDetails | PlainMessage<PluginDetails> | undefined,
+    b: PluginDetails | PlainMessage<PluginDetails> | undefined,
+  ): boolean {
+    return proto3.util.equals(PluginDetails, a, b);
+  }
+}
+
+/**
+ * PluginCapabilities
+ *
+ * The optional functionality supported by a plugin.
+ *
+ * @generated from message kubeappsapis.core.plugins.v1alpha1.PluginCapabilities
+ */
+export class PluginCapabilities extends Message<PluginCapabilities> {
+  /**
+   * Repositories
+   *
+   * Whether the plugin implements the core repositories service.
+   *
+   * @generated from field: bool repositories = 1;
+   */
+  repositories = false;
+
+  /**
+   * Resource refs
+   *
+   * Whether the plugin returns the references to the Kubernetes resources
+   * of its installed packages, on which the resources plugin relies.
+   *
+   * @generated from field: bool resource_refs = 2;
+   */
+  resourceRefs = false;
+
+  constructor(data?: PartialMessage<PluginCapabilities>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.plugins.v1alpha1.PluginCapabilities";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "repositories", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 2, name: "resource_refs", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): PluginCapabilities {
+    return new PluginCapabilities().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): PluginCapabilities {
+    return new PluginCapabilities().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): PluginCapabilities {
+    return new PluginCapabilities().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: PluginCapabilities | PlainMessage<PluginCapabilities> | undefined,
+    b: PluginCapabilities | PlainMessage<PluginCapabilities> | undefined,
+  ): boolean {
+    return proto3.util.equals(PluginCapabilities, a, b);
+  }
+}
+
+/**
+ * PluginProbe
+ *
+ * The result of probing a plugin for the resources it needs.
+ *
+ * @generated from message kubeappsapis.core.plugins.v1alpha1.PluginProbe
+ */
+export class PluginProbe extends Message<PluginProbe> {
+  /**
+   * Supported
+   *
+   * Whether the plugin can be probed. Plugins which cannot be probed are
+   * reported as healthy.
+   *
+   * @generated from field: bool supported = 1;
+   */
+  supported = false;
+
+  /**
+   * Healthy
+   *
+   * Whether the plugin could reach the resources it needs.
+   *
+   * @generated from field: bool healthy = 2;
+   */
+  healthy = false;
+
+  /**
+   * Error
+   *
+   * The reason for the plugin being unhealthy.
+   *
+   * @generated from field: string error = 3;
+   */
+  error = "";
+
+  /**
+   * Probe time
+   *
+   * The time at which the plugin was probed.
+   *
+   * @generated from field: google.protobuf.Timestamp probe_time = 4;
+   */
+  probeTime?: Timestamp;
+
+  constructor(data?: PartialMessage<PluginProbe>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.plugins.v1alpha1.PluginProbe";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "supported", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 2, name: "healthy", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 3, name: "error", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "probe_time", kind: "message", T: Timestamp },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): PluginProbe {
+    return new PluginProbe().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): PluginProbe {
+    return new PluginProbe().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): PluginProbe {
+    return new PluginProbe().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: PluginProbe | PlainMessage<PluginProbe> | undefined,
+    b: PluginProbe | PlainMessage<PluginProbe> | undefined,
+  ): boolean {
+    return proto3.util.equals(PluginProbe, a, b);
+  }
+}
+
+/**
+ * GetFeaturesRequest
+ *
+ * Request for GetFeatures
+ *
+ * @generated from message kubeappsapis.core.plugins.v1alpha1.GetFeaturesRequest
+ */
+export class GetFeaturesRequest extends Message<GetFeaturesRequest> {
+  constructor(data?: PartialMessage<GetFeaturesRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.plugins.v1alpha1.GetFeaturesRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => []);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): GetFeaturesRequest {
+    return new GetFeaturesRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): GetFeaturesRequest {
+    return new GetFeaturesRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetFeaturesRequest {
+    return new GetFeaturesRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: GetFeaturesRequest | PlainMessage<GetFeaturesRequest> | undefined,
+    b: GetFeaturesRequest | PlainMessage<GetFeaturesRequest> | undefined,
+  ): boolean {
+    return proto3.util.equals(GetFeaturesRequest, a, b);
+  }
+}
+
+/**
+ * GetFeaturesResponse
+ *
+ * Response for GetFeatures
+ *
+ * @generated from message kubeappsapis.core.plugins.v1alpha1.GetFeaturesResponse
+ */
+export class GetFeaturesResponse extends Message<GetFeaturesResponse> {
+  /**
+   * Features
+   *
+   * The descriptor of the enabled features, decoded for convenience. Clients
+   * verifying the descriptor should decode the payload instead.
+   *
+   * @generated from field: kubeappsapis.core.plugins.v1alpha1.FeaturesDescriptor features = 1;
+   */
+  features?: FeaturesDescriptor;
+
+  /**
+   * Payload
+   *
+   * The deterministic binary serialization of the FeaturesDescriptor, over
+   * which the checksum and the signature are computed.
+   *
+   * @generated from field: bytes payload = 2;
+   */
+  payload = new Uint8Array(0);
+
+  /**
+   * Checksum
+   *
+   * The checksum of the payload, such as `sha256:<hex digest>`.
+   *
+   * @generated from field: string checksum = 3;
+   */
+  checksum = "";
+
+  /**
+   * Signature
+   *
+   * The Ed25519 signature of the payload, using the key configured with
+   * `--features-signing-key-path`. Empty if no signing key is configured.
+   *
+   * @generated from field: bytes signature = 4;
+   */
+  signature = new Uint8Array(0);
+
+  constructor(data?: PartialMessage<GetFeaturesResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.plugins.v1alpha1.GetFeaturesResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "features", kind: "message", T: FeaturesDescriptor },
+    { no: 2, name: "payload", kind: "scalar", T: 12 /* ScalarType.BYTES */ },
+    { no: 3, name: "checksum", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "signature", kind: "scalar", T: 12 /* ScalarType.BYTES */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): GetFeaturesResponse {
+    return new GetFeaturesResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): GetFeaturesResponse {
+    return new GetFeaturesResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetFeaturesResponse {
+    return new GetFeaturesResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: GetFeaturesResponse | PlainMessage<GetFeaturesResponse> | undefined,
+    b: GetFeaturesResponse | PlainMessage<GetFeaturesResponse> | undefined,
+  ): boolean {
+    return proto3.util.equals(GetFeaturesResponse, a, b);
+  }
+}
+
+/**
+ * FeaturesDescriptor
+ *
+ * The features enabled in the backend, which do not change while the server runs.
+ *
+ * @generated from message kubeappsapis.core.plugins.v1alpha1.FeaturesDescriptor
+ */
+export class FeaturesDescriptor extends Message<FeaturesDescriptor> {
+  /**
+   * Version
+   *
+   * The version of the descriptor format, incremented on incompatible changes.
+   *
+   * @generated from field: uint32 version = 1;
+   */
+  version = 0;
+
+  /**
+   * Plugins
+   *
+   * The configured plugins, sorted by name and version.
+   *
+   * @generated from field: repeated kubeappsapis.core.plugins.v1alpha1.PluginFeatures plugins = 2;
+   */
+  plugins: PluginFeatures[] = [];
+
+  /**
+   * Clusters
+   *
+   * The names of the configured clusters, sorted by name.
+   *
+   * @generated from field: repeated string clusters = 3;
+   */
+  clusters: string[] = [];
+
+  /**
+   * Operations
+   *
+   * The operations which can be performed through the core API.
+   *
+   * @generated from field: kubeappsapis.core.plugins.v1alpha1.OperationsPolicy operations = 4;
+   */
+  operations?: OperationsPolicy;
+
+  constructor(data?: PartialMessage<FeaturesDescriptor>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.plugins.v1alpha1.FeaturesDescriptor";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "version", kind: "scalar", T: 13 /* ScalarType.UINT32 */ },
+    { no: 2, name: "plugins", kind: "message", T: PluginFeatures, repeated: true },
+    { no: 3, name: "clusters", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 4, name: "operations", kind: "message", T: OperationsPolicy },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): FeaturesDescriptor {
+    return new FeaturesDescriptor().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): FeaturesDescriptor {
+    return new FeaturesDescriptor().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): FeaturesDescriptor {
+    return new FeaturesDescriptor().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: FeaturesDescriptor | PlainMessage<FeaturesDescriptor> | undefined,
+    b: FeaturesDescriptor | PlainMessage<FeaturesDescriptor> | undefined,
+  ): boolean {
+    return proto3.util.equals(FeaturesDescriptor, a, b);
+  }
+}
+
+/**
+ * PluginFeatures
+ *
+ * The features provided by a configured plugin.
+ *
+ * @generated from message kubeappsapis.core.plugins.v1alpha1.PluginFeatures
+ */
+export class PluginFeatures extends Message<PluginFeatures> {
+  /**
+   * Plugin
+   *
+   * The plugin name and version.
+   *
+   * @generated from field: kubeappsapis.core.plugins.v1alpha1.Plugin plugin = 1;
+   */
+  plugin?: Plugin;
+
+  /**
+   * Services
+   *
+   * The fully-qualified names of the core services implemented by the plugin,
+   * such as `kubeappsapis.core.packages.v1alpha1.PackagesService`.
+   *
+   * @generated from field: repeated string services = 2;
+   */
+  services: string[] = [];
+
+  constructor(data?: PartialMessage<PluginFeatures>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.plugins.v1alpha1.PluginFeatures";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "plugin", kind: "message", T: Plugin },
+    { no: 2, name: "services", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): PluginFeatures {
+    return new PluginFeatures().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): PluginFeatures {
+    return new PluginFeatures().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): PluginFeatures {
+    return new PluginFeatures().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: PluginFeatures | PlainMessage<PluginFeatures> | undefined,
+    b: PluginFeatures | PlainMessage<PluginFeatures> | undefined,
+  ): boolean {
+    return proto3.util.equals(PluginFeatures, a, b);
+  }
+}
+
+/**
+ * OperationsPolicy
+ *
+ * The operations available through the core API, depending on the configured plugins.
+ *
+ * @generated from message kubeappsapis.core.plugins.v1alpha1.OperationsPolicy
+ */
+export class OperationsPolicy extends Message<OperationsPolicy> {
+  /**
+   * Packages enabled
+   *
+   * Whether at least one plugin implements the core packages service.
+   *
+   * @generated from field: bool packages_enabled = 1;
+   */
+  packagesEnabled = false;
+
+  /**
+   * Repositories enabled
+   *
+   * Whether at least one plugin implements the core repositories service.
+   *
+   * @generated from field: bool repositories_enabled = 2;
+   */
+  repositoriesEnabled = false;
+
+  /**
+   * Global repositories namespace
+   *
+   * The namespace in which global repositories are stored.
+   *
+   * @generated from field: string global_repos_namespace = 3;
+   */
+  globalReposNamespace = "";
+
+  constructor(data?: PartialMessage<OperationsPolicy>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.plugins.v1alpha1.OperationsPolicy";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "packages_enabled", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 2, name: "repositories_enabled", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 3, name: "global_repos_namespace", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): OperationsPolicy {
+    return new OperationsPolicy().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): OperationsPolicy {
+    return new OperationsPolicy().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): OperationsPolicy {
+    return new OperationsPolicy().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: OperationsPolicy | PlainMessage<OperationsPolicy> | undefined,
+    b: OperationsPolicy | PlainMessage<OperationsPolicy> | undefined,
+  ): boolean {
+    return proto3.util.equals(OperationsPolicy, a, b);
+  }
+}
+
+/**
+ * GetPluginConfigsRequest
+ *
+ * Request for GetPluginConfigs
+ *
+ * @generated from message kubeappsapis.core.plugins.v1alpha1.GetPluginConfigsRequest
+ */
+export class GetPluginConfigsRequest extends Message<GetPluginConfigsRequest> {
+  constructor(data?: PartialMessage<GetPluginConfigsRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.plugins.v1alpha1.GetPluginConfigsRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => []);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): GetPluginConfigsRequest {
+    return new GetPluginConfigsRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): GetPluginConfigsRequest {
+    return new GetPluginConfigsRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetPluginConfigsRequest {
+    return new GetPluginConfigsRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: GetPluginConfigsRequest | PlainMessage<GetPluginConfigsRequest> | undefined,
+    b: GetPluginConfigsRequest | PlainMessage<GetPluginConfigsRequest> | undefined,
+  ): boolean {
+    return proto3.util.equals(GetPluginConfigsRequest, a, b);
+  }
+}
+
+/**
+ * GetPluginConfigsResponse
+ *
+ * Response for GetPluginConfigs
+ *
+ * @generated from message kubeappsapis.core.plugins.v1alpha1.GetPluginConfigsResponse
+ */
+export class GetPluginConfigsResponse extends Message<GetPluginConfigsResponse> {
+  /**
+   * Configs
+   *
+   * The configuration of each plugin supporting reloads, sorted by plugin name and version.
+   *
+   * @generated from field: repeated kubeappsapis.core.plugins.v1alpha1.PluginConfig configs = 1;
+   */
+  configs: PluginConfig[] = [];
+
+  constructor(data?: PartialMessage<GetPluginConfigsResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.plugins.v1alpha1.GetPluginConfigsResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "configs", kind: "message", T: PluginConfig, repeated: true },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): GetPluginConfigsResponse {
+    return new GetPluginConfigsResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): GetPluginConfigsResponse {
+    return new GetPluginConfigsResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetPluginConfigsResponse {
+    return new GetPluginConfigsResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: GetPluginConfigsResponse | PlainMessage<GetPluginConfigsResponse> | undefined,
+    b: GetPluginConfigsResponse | PlainMessage<GetPluginConfigsResponse> | undefined,
+  ): boolean {
+    return proto3.util.equals(GetPluginConfigsResponse, a, b);
+  }
+}
+
+/**
+ * PluginConfig
+ *
+ * The configuration in effect for a plugin.
+ *
+ * @generated from message kubeappsapis.core.plugins.v1alpha1.PluginConfig
+ */
+export class PluginConfig extends Message<PluginConfig> {
+  /**
+   * Plugin
+   *
+   * The plugin name and version.
+   *
+   * @generated from field: kubeappsapis.core.plugins.v1alpha1.Plugin plugin = 1;
+   */
+  plugin?: Plugin;
+
+  /**
+   * Config
+   *
+   * The configuration in effect, in JSON format, once the defaults of the
+   * plugin are applied.
+   *
+   * @generated from field: string config = 2;
+   */
+  config = "";
+
+  /**
+   * Last reload time
+   *
+   * The time at which the configuration was last loaded, or at which the last
+   * reload was attempted.
+   *
+   * @generated from field: google.protobuf.Timestamp last_reload_time = 3;
+   */
+  lastReloadTime?: Timestamp;
+
+  /**
+   * Last reload error
+   *
+   * The error of the last reload, if it failed, in which case the previous
+   * configuration remains in effect.
+   *
+   * @generated from field: string last_reload_error = 4;
+   */
+  lastReloadError = "";
+
+  constructor(data?: PartialMessage<PluginConfig>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.plugins.v1alpha1.PluginConfig";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "plugin", kind: "message", T: Plugin },
+    { no: 2, name: "config", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "last_reload_time", kind: "message", T: Timestamp },
+    { no: 4, name: "last_reload_error", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): PluginConfig {
+    return new PluginConfig().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): PluginConfig {
+    return new PluginConfig().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): PluginConfig {
+    return new PluginConfig().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: PluginConfig | PlainMessage<PluginConfig> | undefined,
+    b: PluginConfig | PlainMessage<PluginConfig> | undefined,
+  ): boolean {
+    return proto3.util.equals(PluginConfig, a, b);
+  }
+}
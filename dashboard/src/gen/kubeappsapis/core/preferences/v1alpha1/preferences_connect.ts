@@ -0,0 +1,46 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+// @generated by protoc-gen-connect-es v0.13.0 with parameter "target=ts,import_extension=none"
+// @generated from file kubeappsapis/core/preferences/v1alpha1/preferences.proto (package kubeappsapis.core.preferences.v1alpha1, syntax proto3)
+/* eslint-disable */
+// @ts-nocheck
+
+import {
+  GetUserPreferencesRequest,
+  GetUserPreferencesResponse,
+  UpdateUserPreferencesRequest,
+  UpdateUserPreferencesResponse,
+} from "./preferences_pb";
+import { MethodKind } from "@bufbuild/protobuf";
+
+/**
+ * @generated from service kubeappsapis.core.preferences.v1alpha1.PreferencesService
+ */
+export const PreferencesService = {
+  typeName: "kubeappsapis.core.preferences.v1alpha1.PreferencesService",
+  methods: {
+    /**
+     * GetUserPreferences returns the stored preferences for the calling user.
+     *
+     * @generated from rpc kubeappsapis.core.preferences.v1alpha1.PreferencesService.GetUserPreferences
+     */
+    getUserPreferences: {
+      name: "GetUserPreferences",
+      I: GetUserPreferencesRequest,
+      O: GetUserPreferencesResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * UpdateUserPreferences replaces the stored preferences for the calling user.
+     *
+     * @generated from rpc kubeappsapis.core.preferences.v1alpha1.PreferencesService.UpdateUserPreferences
+     */
+    updateUserPreferences: {
+      name: "UpdateUserPreferences",
+      I: UpdateUserPreferencesRequest,
+      O: UpdateUserPreferencesResponse,
+      kind: MethodKind.Unary,
+    },
+  },
+} as const;
@@ -0,0 +1,373 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+// @generated by protoc-gen-es v1.3.1 with parameter "target=ts,import_extension=none"
+// @generated from file kubeappsapis/core/preferences/v1alpha1/preferences.proto (package kubeappsapis.core.preferences.v1alpha1, syntax proto3)
+/* eslint-disable */
+// @ts-nocheck
+
+import type {
+  BinaryReadOptions,
+  FieldList,
+  JsonReadOptions,
+  JsonValue,
+  PartialMessage,
+  PlainMessage,
+} from "@bufbuild/protobuf";
+import { Message, proto3 } from "@bufbuild/protobuf";
+
+/**
+ * CatalogLayout
+ *
+ * The layouts available for the catalog of available packages.
+ *
+ * @generated from enum kubeappsapis.core.preferences.v1alpha1.CatalogLayout
+ */
+export enum CatalogLayout {
+  /**
+   * @generated from enum value: CATALOG_LAYOUT_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * @generated from enum value: CATALOG_LAYOUT_GRID = 1;
+   */
+  GRID = 1,
+
+  /**
+   * @generated from enum value: CATALOG_LAYOUT_LIST = 2;
+   */
+  LIST = 2,
+}
+// Retrieve enum metadata with: proto3.getEnumType(CatalogLayout)
+proto3.util.setEnumType(CatalogLayout, "kubeappsapis.core.preferences.v1alpha1.CatalogLayout", [
+  { no: 0, name: "CATALOG_LAYOUT_UNSPECIFIED" },
+  { no: 1, name: "CATALOG_LAYOUT_GRID" },
+  { no: 2, name: "CATALOG_LAYOUT_LIST" },
+]);
+
+/**
+ * Theme
+ *
+ * The themes available for the dashboard.
+ *
+ * @generated from enum kubeappsapis.core.preferences.v1alpha1.Theme
+ */
+export enum Theme {
+  /**
+   * @generated from enum value: THEME_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * @generated from enum value: THEME_LIGHT = 1;
+   */
+  LIGHT = 1,
+
+  /**
+   * @generated from enum value: THEME_DARK = 2;
+   */
+  DARK = 2,
+}
+// Retrieve enum metadata with: proto3.getEnumType(Theme)
+proto3.util.setEnumType(Theme, "kubeappsapis.core.preferences.v1alpha1.Theme", [
+  { no: 0, name: "THEME_UNSPECIFIED" },
+  { no: 1, name: "THEME_LIGHT" },
+  { no: 2, name: "THEME_DARK" },
+]);
+
+/**
+ * GetUserPreferencesRequest
+ *
+ * Request for GetUserPreferences
+ *
+ * @generated from message kubeappsapis.core.preferences.v1alpha1.GetUserPreferencesRequest
+ */
+export class GetUserPreferencesRequest extends Message<GetUserPreferencesRequest> {
+  constructor(data?: PartialMessage<GetUserPreferencesRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.preferences.v1alpha1.GetUserPreferencesRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => []);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): GetUserPreferencesRequest {
+    return new GetUserPreferencesRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): GetUserPreferencesRequest {
+    return new GetUserPreferencesRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetUserPreferencesRequest {
+    return new GetUserPreferencesRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: GetUserPreferencesRequest | PlainMessage<GetUserPreferencesRequest> | undefined,
+    b: GetUserPreferencesRequest | PlainMessage<GetUserPreferencesRequest> | undefined,
+  ): boolean {
+    return proto3.util.equals(GetUserPreferencesRequest, a, b);
+  }
+}
+
+/**
+ * GetUserPreferencesResponse
+ *
+ * Response for GetUserPreferences
+ *
+ * @generated from message kubeappsapis.core.preferences.v1alpha1.GetUserPreferencesResponse
+ */
+export class GetUserPreferencesResponse extends Message<GetUserPreferencesResponse> {
+  /**
+   * Preferences
+   *
+   * The stored preferences for the user. Empty if the user has not stored any
+   * preferences yet.
+   *
+   * @generated from field: kubeappsapis.core.preferences.v1alpha1.UserPreferences preferences = 1;
+   */
+  preferences?: UserPreferences;
+
+  constructor(data?: PartialMessage<GetUserPreferencesResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.preferences.v1alpha1.GetUserPreferencesResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "preferences", kind: "message", T: UserPreferences },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): GetUserPreferencesResponse {
+    return new GetUserPreferencesResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): GetUserPreferencesResponse {
+    return new GetUserPreferencesResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetUserPreferencesResponse {
+    return new GetUserPreferencesResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: GetUserPreferencesResponse | PlainMessage<GetUserPreferencesResponse> | undefined,
+    b: GetUserPreferencesResponse | PlainMessage<GetUserPreferencesResponse> | undefined,
+  ): boolean {
+    return proto3.util.equals(GetUserPreferencesResponse, a, b);
+  }
+}
+
+/**
+ * UpdateUserPreferencesRequest
+ *
+ * Request for UpdateUserPreferences
+ *
+ * @generated from message kubeappsapis.core.preferences.v1alpha1.UpdateUserPreferencesRequest
+ */
+export class UpdateUserPreferencesRequest extends Message<UpdateUserPreferencesRequest> {
+  /**
+   * Preferences
+   *
+   * The preferences to be stored for the user.
+   *
+   * @generated from field: kubeappsapis.core.preferences.v1alpha1.UserPreferences preferences = 1;
+   */
+  preferences?: UserPreferences;
+
+  constructor(data?: PartialMessage<UpdateUserPreferencesRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.preferences.v1alpha1.UpdateUserPreferencesRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "preferences", kind: "message", T: UserPreferences },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): UpdateUserPreferencesRequest {
+    return new UpdateUserPreferencesRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): UpdateUserPreferencesRequest {
+    return new UpdateUserPreferencesRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): UpdateUserPreferencesRequest {
+    return new UpdateUserPreferencesRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: UpdateUserPreferencesRequest | PlainMessage<UpdateUserPreferencesRequest> | undefined,
+    b: UpdateUserPreferencesRequest | PlainMessage<UpdateUserPreferencesRequest> | undefined,
+  ): boolean {
+    return proto3.util.equals(UpdateUserPreferencesRequest, a, b);
+  }
+}
+
+/**
+ * UpdateUserPreferencesResponse
+ *
+ * Response for UpdateUserPreferences
+ *
+ * @generated from message kubeappsapis.core.preferences.v1alpha1.UpdateUserPreferencesResponse
+ */
+export class UpdateUserPreferencesResponse extends Message<UpdateUserPreferencesResponse> {
+  /**
+   * Preferences
+   *
+   * The preferences stored for the user.
+   *
+   * @generated from field: kubeappsapis.core.preferences.v1alpha1.UserPreferences preferences = 1;
+   */
+  preferences?: UserPreferences;
+
+  constructor(data?: PartialMessage<UpdateUserPreferencesResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.preferences.v1alpha1.UpdateUserPreferencesResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "preferences", kind: "message", T: UserPreferences },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): UpdateUserPreferencesResponse {
+    return new UpdateUserPreferencesResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): UpdateUserPreferencesResponse {
+    return new UpdateUserPreferencesResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): UpdateUserPreferencesResponse {
+    return new UpdateUserPreferencesResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: UpdateUserPreferencesResponse | PlainMessage<UpdateUserPreferencesResponse> | undefined,
+    b: UpdateUserPreferencesResponse | PlainMessage<UpdateUserPreferencesResponse> | undefined,
+  ): boolean {
+    return proto3.util.equals(UpdateUserPreferencesResponse, a, b);
+  }
+}
+
+/**
+ * UserPreferences
+ *
+ * The dashboard preferences of a user.
+ *
+ * @generated from message kubeappsapis.core.preferences.v1alpha1.UserPreferences
+ */
+export class UserPreferences extends Message<UserPreferences> {
+  /**
+   * Default cluster
+   *
+   * The cluster selected by default when the user logs in.
+   *
+   * @generated from field: string default_cluster = 1;
+   */
+  defaultCluster = "";
+
+  /**
+   * Default namespace
+   *
+   * The namespace selected by default when the user logs in.
+   *
+   * @generated from field: string default_namespace = 2;
+   */
+  defaultNamespace = "";
+
+  /**
+   * Catalog layout
+   *
+   * The layout used to display the catalog of available packages.
+   *
+   * @generated from field: kubeappsapis.core.preferences.v1alpha1.CatalogLayout catalog_layout = 3;
+   */
+  catalogLayout = CatalogLayout.UNSPECIFIED;
+
+  /**
+   * Theme
+   *
+   * The theme of the dashboard.
+   *
+   * @generated from field: kubeappsapis.core.preferences.v1alpha1.Theme theme = 4;
+   */
+  theme = Theme.UNSPECIFIED;
+
+  constructor(data?: PartialMessage<UserPreferences>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.preferences.v1alpha1.UserPreferences";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "default_cluster", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "default_namespace", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "catalog_layout", kind: "enum", T: proto3.getEnumType(CatalogLayout) },
+    { no: 4, name: "theme", kind: "enum", T: proto3.getEnumType(Theme) },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): UserPreferences {
+    return new UserPreferences().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): UserPreferences {
+    return new UserPreferences().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): UserPreferences {
+    return new UserPreferences().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: UserPreferences | PlainMessage<UserPreferences> | undefined,
+    b: UserPreferences | PlainMessage<UserPreferences> | undefined,
+  ): boolean {
+    return proto3.util.equals(UserPreferences, a, b);
+  }
+}
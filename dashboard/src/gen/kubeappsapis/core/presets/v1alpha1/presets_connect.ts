@@ -0,0 +1,56 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+// @generated by protoc-gen-connect-es v0.13.0 with parameter "target=ts,import_extension=none"
+// @generated from file kubeappsapis/core/presets/v1alpha1/presets.proto (package kubeappsapis.core.presets.v1alpha1, syntax proto3)
+/* eslint-disable */
+// @ts-nocheck
+
+import {
+  CreateFromPresetRequest,
+  CreateFromPresetResponse,
+  GetInstallPresetsRequest,
+  GetInstallPresetsResponse,
+} from "./presets_pb";
+import { MethodKind } from "@bufbuild/protobuf";
+
+/**
+ * The Presets service lists the install presets stored by the Kubeapps
+ * administrators and installs packages from them. An install preset is a
+ * named set of install options (the package, its version, default values and
+ * target namespace conventions) shared across teams, so that the same values
+ * are not copied for each install.
+ *
+ * Presets are stored by the administrators as ConfigMaps labelled with
+ * "kubeapps.dev/install-preset" in the namespace where Kubeapps is installed.
+ * The packages are installed with the credentials of the calling user.
+ *
+ * @generated from service kubeappsapis.core.presets.v1alpha1.PresetsService
+ */
+export const PresetsService = {
+  typeName: "kubeappsapis.core.presets.v1alpha1.PresetsService",
+  methods: {
+    /**
+     * GetInstallPresets returns the install presets stored by the administrators.
+     *
+     * @generated from rpc kubeappsapis.core.presets.v1alpha1.PresetsService.GetInstallPresets
+     */
+    getInstallPresets: {
+      name: "GetInstallPresets",
+      I: GetInstallPresetsRequest,
+      O: GetInstallPresetsResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * CreateFromPreset installs the package of an install preset.
+     *
+     * @generated from rpc kubeappsapis.core.presets.v1alpha1.PresetsService.CreateFromPreset
+     */
+    createFromPreset: {
+      name: "CreateFromPreset",
+      I: CreateFromPresetRequest,
+      O: CreateFromPresetResponse,
+      kind: MethodKind.Unary,
+    },
+  },
+} as const;
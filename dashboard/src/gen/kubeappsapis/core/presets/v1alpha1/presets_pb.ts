@@ -0,0 +1,369 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+// @generated by protoc-gen-es v1.3.1 with parameter "target=ts,import_extension=none"
+// @generated from file kubeappsapis/core/presets/v1alpha1/presets.proto (package kubeappsapis.core.presets.v1alpha1, syntax proto3)
+/* eslint-disable */
+// @ts-nocheck
+
+import type {
+  BinaryReadOptions,
+  FieldList,
+  JsonReadOptions,
+  JsonValue,
+  PartialMessage,
+  PlainMessage,
+} from "@bufbuild/protobuf";
+import { Message, proto3 } from "@bufbuild/protobuf";
+import {
+  AvailablePackageReference,
+  Context,
+  InstalledPackageReference,
+  VersionReference,
+} from "../../packages/v1/packages_pb";
+
+/**
+ * GetInstallPresetsRequest
+ *
+ * Request for GetInstallPresets
+ *
+ * @generated from message kubeappsapis.core.presets.v1alpha1.GetInstallPresetsRequest
+ */
+export class GetInstallPresetsRequest extends Message<GetInstallPresetsRequest> {
+  constructor(data?: PartialMessage<GetInstallPresetsRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.presets.v1alpha1.GetInstallPresetsRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => []);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): GetInstallPresetsRequest {
+    return new GetInstallPresetsRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): GetInstallPresetsRequest {
+    return new GetInstallPresetsRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetInstallPresetsRequest {
+    return new GetInstallPresetsRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: GetInstallPresetsRequest | PlainMessage<GetInstallPresetsRequest> | undefined,
+    b: GetInstallPresetsRequest | PlainMessage<GetInstallPresetsRequest> | undefined,
+  ): boolean {
+    return proto3.util.equals(GetInstallPresetsRequest, a, b);
+  }
+}
+
+/**
+ * GetInstallPresetsResponse
+ *
+ * Response for GetInstallPresets
+ *
+ * @generated from message kubeappsapis.core.presets.v1alpha1.GetInstallPresetsResponse
+ */
+export class GetInstallPresetsResponse extends Message<GetInstallPresetsResponse> {
+  /**
+   * Presets
+   *
+   * The install presets, sorted by name.
+   *
+   * @generated from field: repeated kubeappsapis.core.presets.v1alpha1.InstallPreset presets = 1;
+   */
+  presets: InstallPreset[] = [];
+
+  constructor(data?: PartialMessage<GetInstallPresetsResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.presets.v1alpha1.GetInstallPresetsResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "presets", kind: "message", T: InstallPreset, repeated: true },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): GetInstallPresetsResponse {
+    return new GetInstallPresetsResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): GetInstallPresetsResponse {
+    return new GetInstallPresetsResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetInstallPresetsResponse {
+    return new GetInstallPresetsResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: GetInstallPresetsResponse | PlainMessage<GetInstallPresetsResponse> | undefined,
+    b: GetInstallPresetsResponse | PlainMessage<GetInstallPresetsResponse> | undefined,
+  ): boolean {
+    return proto3.util.equals(GetInstallPresetsResponse, a, b);
+  }
+}
+
+/**
+ * CreateFromPresetRequest
+ *
+ * Request for CreateFromPreset
+ *
+ * @generated from message kubeappsapis.core.presets.v1alpha1.CreateFromPresetRequest
+ */
+export class CreateFromPresetRequest extends Message<CreateFromPresetRequest> {
+  /**
+   * Preset name
+   *
+   * The name of the install preset from which the package is installed.
+   *
+   * @generated from field: string preset_name = 1;
+   */
+  presetName = "";
+
+  /**
+   * The target context where the package is intended to be installed. The
+   * namespace must follow the conventions of the preset.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.Context target_context = 2;
+   */
+  targetContext?: Context;
+
+  /**
+   * A user-provided name for the installed package (eg. project-x-db)
+   *
+   * @generated from field: string name = 3;
+   */
+  name = "";
+
+  /**
+   * An optional serialized values string, in YAML or JSON, overriding the
+   * default values of the preset. Objects are merged, while any other value
+   * replaces the default one.
+   *
+   * @generated from field: string values = 4;
+   */
+  values = "";
+
+  constructor(data?: PartialMessage<CreateFromPresetRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.presets.v1alpha1.CreateFromPresetRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "preset_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "target_context", kind: "message", T: Context },
+    { no: 3, name: "name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "values", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): CreateFromPresetRequest {
+    return new CreateFromPresetRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): CreateFromPresetRequest {
+    return new CreateFromPresetRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): CreateFromPresetRequest {
+    return new CreateFromPresetRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: CreateFromPresetRequest | PlainMessage<CreateFromPresetRequest> | undefined,
+    b: CreateFromPresetRequest | PlainMessage<CreateFromPresetRequest> | undefined,
+  ): boolean {
+    return proto3.util.equals(CreateFromPresetRequest, a, b);
+  }
+}
+
+/**
+ * CreateFromPresetResponse
+ *
+ * Response for CreateFromPreset
+ *
+ * @generated from message kubeappsapis.core.presets.v1alpha1.CreateFromPresetResponse
+ */
+export class CreateFromPresetResponse extends Message<CreateFromPresetResponse> {
+  /**
+   * A reference uniquely identifying the installed package.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.InstalledPackageReference installed_package_ref = 1;
+   */
+  installedPackageRef?: InstalledPackageReference;
+
+  constructor(data?: PartialMessage<CreateFromPresetResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.presets.v1alpha1.CreateFromPresetResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "installed_package_ref", kind: "message", T: InstalledPackageReference },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): CreateFromPresetResponse {
+    return new CreateFromPresetResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): CreateFromPresetResponse {
+    return new CreateFromPresetResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): CreateFromPresetResponse {
+    return new CreateFromPresetResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: CreateFromPresetResponse | PlainMessage<CreateFromPresetResponse> | undefined,
+    b: CreateFromPresetResponse | PlainMessage<CreateFromPresetResponse> | undefined,
+  ): boolean {
+    return proto3.util.equals(CreateFromPresetResponse, a, b);
+  }
+}
+
+/**
+ * InstallPreset
+ *
+ * A named set of install options stored by the administrators.
+ *
+ * @generated from message kubeappsapis.core.presets.v1alpha1.InstallPreset
+ */
+export class InstallPreset extends Message<InstallPreset> {
+  /**
+   * Name
+   *
+   * The name of the preset, which is the name of the ConfigMap storing it.
+   *
+   * @generated from field: string name = 1;
+   */
+  name = "";
+
+  /**
+   * Description
+   *
+   * A description of the preset for the users.
+   *
+   * @generated from field: string description = 2;
+   */
+  description = "";
+
+  /**
+   * A reference uniquely identifying the package installed by the preset.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.AvailablePackageReference available_package_ref = 3;
+   */
+  availablePackageRef?: AvailablePackageReference;
+
+  /**
+   * The version of the package installed by the preset, which cannot be
+   * changed by the users. For helm this is an exact version, while for fluxv2
+   * this can be any semver constraint expression.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.VersionReference pkg_version_reference = 4;
+   */
+  pkgVersionReference?: VersionReference;
+
+  /**
+   * The default serialized values string of the preset, in the format expected
+   * by the plugin.
+   *
+   * @generated from field: string values = 5;
+   */
+  values = "";
+
+  /**
+   * Target namespace prefix
+   *
+   * When set, packages can only be installed from the preset in the
+   * namespaces starting with this prefix.
+   *
+   * @generated from field: string target_namespace_prefix = 6;
+   */
+  targetNamespacePrefix = "";
+
+  /**
+   * Whether to create the target namespace if it does not exist.
+   *
+   * @generated from field: bool create_namespace = 7;
+   */
+  createNamespace = false;
+
+  constructor(data?: PartialMessage<InstallPreset>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.presets.v1alpha1.InstallPreset";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "description", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "available_package_ref", kind: "message", T: AvailablePackageReference },
+    { no: 4, name: "pkg_version_reference", kind: "message", T: VersionReference },
+    { no: 5, name: "values", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 6, name: "target_namespace_prefix", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 7, name: "create_namespace", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): InstallPreset {
+    return new InstallPreset().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): InstallPreset {
+    return new InstallPreset().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): InstallPreset {
+    return new InstallPreset().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: InstallPreset | PlainMessage<InstallPreset> | undefined,
+    b: InstallPreset | PlainMessage<InstallPreset> | undefined,
+  ): boolean {
+    return proto3.util.equals(InstallPreset, a, b);
+  }
+}
@@ -0,0 +1,38 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+// @generated by protoc-gen-connect-es v0.13.0 with parameter "target=ts,import_extension=none"
+// @generated from file kubeappsapis/core/search/v1alpha1/search.proto (package kubeappsapis.core.search.v1alpha1, syntax proto3)
+/* eslint-disable */
+// @ts-nocheck
+
+import { GetSearchSuggestionsRequest, GetSearchSuggestionsResponse } from "./search_pb";
+import { MethodKind } from "@bufbuild/protobuf";
+
+/**
+ * The Search service suggests the available packages matching what a user is
+ * typing, without querying the plugins. The global available packages of each
+ * cluster, which can be installed in any namespace, are periodically crawled
+ * through all the packaging plugins with the kubeapps-apis service account,
+ * and indexed in memory by their name, description, keywords and maintainers.
+ * The keywords of a package are its categories, which all the plugins return.
+ *
+ * @generated from service kubeappsapis.core.search.v1alpha1.SearchService
+ */
+export const SearchService = {
+  typeName: "kubeappsapis.core.search.v1alpha1.SearchService",
+  methods: {
+    /**
+     * GetSearchSuggestions returns the indexed packages matching the query,
+     * the best matches first.
+     *
+     * @generated from rpc kubeappsapis.core.search.v1alpha1.SearchService.GetSearchSuggestions
+     */
+    getSearchSuggestions: {
+      name: "GetSearchSuggestions",
+      I: GetSearchSuggestionsRequest,
+      O: GetSearchSuggestionsResponse,
+      kind: MethodKind.Unary,
+    },
+  },
+} as const;
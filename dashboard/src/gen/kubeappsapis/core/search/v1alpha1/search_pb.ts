@@ -0,0 +1,246 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+// @generated by protoc-gen-es v1.3.1 with parameter "target=ts,import_extension=none"
+// @generated from file kubeappsapis/core/search/v1alpha1/search.proto (package kubeappsapis.core.search.v1alpha1, syntax proto3)
+/* eslint-disable */
+// @ts-nocheck
+
+import type {
+  BinaryReadOptions,
+  FieldList,
+  JsonReadOptions,
+  JsonValue,
+  PartialMessage,
+  PlainMessage,
+} from "@bufbuild/protobuf";
+import { Message, proto3, Timestamp } from "@bufbuild/protobuf";
+import { AvailablePackageReference } from "../../packages/v1/packages_pb";
+
+/**
+ * GetSearchSuggestionsRequest
+ *
+ * Request for GetSearchSuggestions
+ *
+ * @generated from message kubeappsapis.core.search.v1alpha1.GetSearchSuggestionsRequest
+ */
+export class GetSearchSuggestionsRequest extends Message<GetSearchSuggestionsRequest> {
+  /**
+   * Query
+   *
+   * The text typed by the user. Each of its words must prefix a word of the
+   * name, description, keywords or maintainers of a package for the package
+   * to be suggested.
+   *
+   * @generated from field: string query = 1;
+   */
+  query = "";
+
+  /**
+   * Limit
+   *
+   * The maximum number of suggestions returned. Defaults to 10 if 0.
+   *
+   * @generated from field: int32 limit = 2;
+   */
+  limit = 0;
+
+  /**
+   * Cluster
+   *
+   * An optional cluster to which the suggestions are restricted.
+   *
+   * @generated from field: string cluster = 3;
+   */
+  cluster = "";
+
+  constructor(data?: PartialMessage<GetSearchSuggestionsRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.search.v1alpha1.GetSearchSuggestionsRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "query", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "limit", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 3, name: "cluster", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): GetSearchSuggestionsRequest {
+    return new GetSearchSuggestionsRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): GetSearchSuggestionsRequest {
+    return new GetSearchSuggestionsRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetSearchSuggestionsRequest {
+    return new GetSearchSuggestionsRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: GetSearchSuggestionsRequest | PlainMessage<GetSearchSuggestionsRequest> | undefined,
+    b: GetSearchSuggestionsRequest | PlainMessage<GetSearchSuggestionsRequest> | undefined,
+  ): boolean {
+    return proto3.util.equals(GetSearchSuggestionsRequest, a, b);
+  }
+}
+
+/**
+ * GetSearchSuggestionsResponse
+ *
+ * Response for GetSearchSuggestions
+ *
+ * @generated from message kubeappsapis.core.search.v1alpha1.GetSearchSuggestionsResponse
+ */
+export class GetSearchSuggestionsResponse extends Message<GetSearchSuggestionsResponse> {
+  /**
+   * Suggestions
+   *
+   * The matching packages, the best matches first.
+   *
+   * @generated from field: repeated kubeappsapis.core.search.v1alpha1.SearchSuggestion suggestions = 1;
+   */
+  suggestions: SearchSuggestion[] = [];
+
+  /**
+   * Index time
+   *
+   * The time at which the packages were last crawled.
+   *
+   * @generated from field: google.protobuf.Timestamp index_time = 2;
+   */
+  indexTime?: Timestamp;
+
+  constructor(data?: PartialMessage<GetSearchSuggestionsResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.search.v1alpha1.GetSearchSuggestionsResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "suggestions", kind: "message", T: SearchSuggestion, repeated: true },
+    { no: 2, name: "index_time", kind: "message", T: Timestamp },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): GetSearchSuggestionsResponse {
+    return new GetSearchSuggestionsResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): GetSearchSuggestionsResponse {
+    return new GetSearchSuggestionsResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetSearchSuggestionsResponse {
+    return new GetSearchSuggestionsResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: GetSearchSuggestionsResponse | PlainMessage<GetSearchSuggestionsResponse> | undefined,
+    b: GetSearchSuggestionsResponse | PlainMessage<GetSearchSuggestionsResponse> | undefined,
+  ): boolean {
+    return proto3.util.equals(GetSearchSuggestionsResponse, a, b);
+  }
+}
+
+/**
+ * SearchSuggestion
+ *
+ * An available package matching the query of the user.
+ *
+ * @generated from message kubeappsapis.core.search.v1alpha1.SearchSuggestion
+ */
+export class SearchSuggestion extends Message<SearchSuggestion> {
+  /**
+   * The reference to the available package.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.AvailablePackageReference available_package_ref = 1;
+   */
+  availablePackageRef?: AvailablePackageReference;
+
+  /**
+   * The name of the available package.
+   *
+   * @generated from field: string name = 2;
+   */
+  name = "";
+
+  /**
+   * The display name of the available package.
+   *
+   * @generated from field: string display_name = 3;
+   */
+  displayName = "";
+
+  /**
+   * The short description of the available package.
+   *
+   * @generated from field: string short_description = 4;
+   */
+  shortDescription = "";
+
+  /**
+   * Score
+   *
+   * The relevance of the package to the query, the matches on the name
+   * weighing more than those on the keywords, then on the description and
+   * maintainers.
+   *
+   * @generated from field: float score = 5;
+   */
+  score = 0;
+
+  constructor(data?: PartialMessage<SearchSuggestion>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.search.v1alpha1.SearchSuggestion";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "available_package_ref", kind: "message", T: AvailablePackageReference },
+    { no: 2, name: "name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "display_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "short_description", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "score", kind: "scalar", T: 2 /* ScalarType.FLOAT */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): SearchSuggestion {
+    return new SearchSuggestion().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): SearchSuggestion {
+    return new SearchSuggestion().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): SearchSuggestion {
+    return new SearchSuggestion().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: SearchSuggestion | PlainMessage<SearchSuggestion> | undefined,
+    b: SearchSuggestion | PlainMessage<SearchSuggestion> | undefined,
+  ): boolean {
+    return proto3.util.equals(SearchSuggestion, a, b);
+  }
+}
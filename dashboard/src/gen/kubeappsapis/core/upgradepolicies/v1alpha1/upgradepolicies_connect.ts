@@ -0,0 +1,85 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+// @generated by protoc-gen-connect-es v0.13.0 with parameter "target=ts,import_extension=none"
+// @generated from file kubeappsapis/core/upgradepolicies/v1alpha1/upgradepolicies.proto (package kubeappsapis.core.upgradepolicies.v1alpha1, syntax proto3)
+/* eslint-disable */
+// @ts-nocheck
+
+import {
+  DeleteUpgradePolicyRequest,
+  DeleteUpgradePolicyResponse,
+  GetUpgradeHistoryRequest,
+  GetUpgradeHistoryResponse,
+  GetUpgradePolicyRequest,
+  GetUpgradePolicyResponse,
+  SetUpgradePolicyRequest,
+  SetUpgradePolicyResponse,
+} from "./upgradepolicies_pb";
+import { MethodKind } from "@bufbuild/protobuf";
+
+/**
+ * The UpgradePolicies service attaches a maintenance window to an installed
+ * package, within which Kubeapps upgrades it automatically to the latest
+ * version matching the version constraint of the policy.
+ *
+ * The policies and the history of the upgrades are stored as ConfigMaps
+ * labelled with "kubeapps.dev/upgrade-policy" in the namespace where Kubeapps
+ * is installed. The upgrades are performed by impersonating the user who set
+ * the policy, so the scheduled upgrades must be enabled by the administrators
+ * along with the impersonation.
+ *
+ * @generated from service kubeappsapis.core.upgradepolicies.v1alpha1.UpgradePoliciesService
+ */
+export const UpgradePoliciesService = {
+  typeName: "kubeappsapis.core.upgradepolicies.v1alpha1.UpgradePoliciesService",
+  methods: {
+    /**
+     * GetUpgradePolicy returns the upgrade policy of an installed package.
+     *
+     * @generated from rpc kubeappsapis.core.upgradepolicies.v1alpha1.UpgradePoliciesService.GetUpgradePolicy
+     */
+    getUpgradePolicy: {
+      name: "GetUpgradePolicy",
+      I: GetUpgradePolicyRequest,
+      O: GetUpgradePolicyResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * SetUpgradePolicy creates or replaces the upgrade policy of an installed
+     * package.
+     *
+     * @generated from rpc kubeappsapis.core.upgradepolicies.v1alpha1.UpgradePoliciesService.SetUpgradePolicy
+     */
+    setUpgradePolicy: {
+      name: "SetUpgradePolicy",
+      I: SetUpgradePolicyRequest,
+      O: SetUpgradePolicyResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * DeleteUpgradePolicy deletes the upgrade policy of an installed package,
+     * along with its upgrade history.
+     *
+     * @generated from rpc kubeappsapis.core.upgradepolicies.v1alpha1.UpgradePoliciesService.DeleteUpgradePolicy
+     */
+    deleteUpgradePolicy: {
+      name: "DeleteUpgradePolicy",
+      I: DeleteUpgradePolicyRequest,
+      O: DeleteUpgradePolicyResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * GetUpgradeHistory returns the upgrades attempted within the windows of
+     * the upgrade policy of an installed package.
+     *
+     * @generated from rpc kubeappsapis.core.upgradepolicies.v1alpha1.UpgradePoliciesService.GetUpgradeHistory
+     */
+    getUpgradeHistory: {
+      name: "GetUpgradeHistory",
+      I: GetUpgradeHistoryRequest,
+      O: GetUpgradeHistoryResponse,
+      kind: MethodKind.Unary,
+    },
+  },
+} as const;
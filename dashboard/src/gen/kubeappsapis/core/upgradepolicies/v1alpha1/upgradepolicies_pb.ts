@@ -0,0 +1,693 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+// @generated by protoc-gen-es v1.3.1 with parameter "target=ts,import_extension=none"
+// @generated from file kubeappsapis/core/upgradepolicies/v1alpha1/upgradepolicies.proto (package kubeappsapis.core.upgradepolicies.v1alpha1, syntax proto3)
+/* eslint-disable */
+// @ts-nocheck
+
+import type {
+  BinaryReadOptions,
+  FieldList,
+  JsonReadOptions,
+  JsonValue,
+  PartialMessage,
+  PlainMessage,
+} from "@bufbuild/protobuf";
+import { Message, proto3 } from "@bufbuild/protobuf";
+import { InstalledPackageReference } from "../../packages/v1/packages_pb";
+
+/**
+ * GetUpgradePolicyRequest
+ *
+ * Request for GetUpgradePolicy
+ *
+ * @generated from message kubeappsapis.core.upgradepolicies.v1alpha1.GetUpgradePolicyRequest
+ */
+export class GetUpgradePolicyRequest extends Message<GetUpgradePolicyRequest> {
+  /**
+   * A reference uniquely identifying the installed package.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.InstalledPackageReference installed_package_ref = 1;
+   */
+  installedPackageRef?: InstalledPackageReference;
+
+  constructor(data?: PartialMessage<GetUpgradePolicyRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.upgradepolicies.v1alpha1.GetUpgradePolicyRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "installed_package_ref", kind: "message", T: InstalledPackageReference },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): GetUpgradePolicyRequest {
+    return new GetUpgradePolicyRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): GetUpgradePolicyRequest {
+    return new GetUpgradePolicyRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetUpgradePolicyRequest {
+    return new GetUpgradePolicyRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: GetUpgradePolicyRequest | PlainMessage<GetUpgradePolicyRequest> | undefined,
+    b: GetUpgradePolicyRequest | PlainMessage<GetUpgradePolicyRequest> | undefined,
+  ): boolean {
+    return proto3.util.equals(GetUpgradePolicyRequest, a, b);
+  }
+}
+
+/**
+ * GetUpgradePolicyResponse
+ *
+ * Response for GetUpgradePolicy
+ *
+ * @generated from message kubeappsapis.core.upgradepolicies.v1alpha1.GetUpgradePolicyResponse
+ */
+export class GetUpgradePolicyResponse extends Message<GetUpgradePolicyResponse> {
+  /**
+   * Upgrade policy
+   *
+   * The upgrade policy of the installed package.
+   *
+   * @generated from field: kubeappsapis.core.upgradepolicies.v1alpha1.UpgradePolicy upgrade_policy = 1;
+   */
+  upgradePolicy?: UpgradePolicy;
+
+  constructor(data?: PartialMessage<GetUpgradePolicyResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.upgradepolicies.v1alpha1.GetUpgradePolicyResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "upgrade_policy", kind: "message", T: UpgradePolicy },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): GetUpgradePolicyResponse {
+    return new GetUpgradePolicyResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): GetUpgradePolicyResponse {
+    return new GetUpgradePolicyResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetUpgradePolicyResponse {
+    return new GetUpgradePolicyResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: GetUpgradePolicyResponse | PlainMessage<GetUpgradePolicyResponse> | undefined,
+    b: GetUpgradePolicyResponse | PlainMessage<GetUpgradePolicyResponse> | undefined,
+  ): boolean {
+    return proto3.util.equals(GetUpgradePolicyResponse, a, b);
+  }
+}
+
+/**
+ * SetUpgradePolicyRequest
+ *
+ * Request for SetUpgradePolicy
+ *
+ * @generated from message kubeappsapis.core.upgradepolicies.v1alpha1.SetUpgradePolicyRequest
+ */
+export class SetUpgradePolicyRequest extends Message<SetUpgradePolicyRequest> {
+  /**
+   * A reference uniquely identifying the installed package.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.InstalledPackageReference installed_package_ref = 1;
+   */
+  installedPackageRef?: InstalledPackageReference;
+
+  /**
+   * Upgrade policy
+   *
+   * The upgrade policy replacing the existing one, if any.
+   *
+   * @generated from field: kubeappsapis.core.upgradepolicies.v1alpha1.UpgradePolicy upgrade_policy = 2;
+   */
+  upgradePolicy?: UpgradePolicy;
+
+  constructor(data?: PartialMessage<SetUpgradePolicyRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.upgradepolicies.v1alpha1.SetUpgradePolicyRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "installed_package_ref", kind: "message", T: InstalledPackageReference },
+    { no: 2, name: "upgrade_policy", kind: "message", T: UpgradePolicy },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): SetUpgradePolicyRequest {
+    return new SetUpgradePolicyRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): SetUpgradePolicyRequest {
+    return new SetUpgradePolicyRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): SetUpgradePolicyRequest {
+    return new SetUpgradePolicyRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: SetUpgradePolicyRequest | PlainMessage<SetUpgradePolicyRequest> | undefined,
+    b: SetUpgradePolicyRequest | PlainMessage<SetUpgradePolicyRequest> | undefined,
+  ): boolean {
+    return proto3.util.equals(SetUpgradePolicyRequest, a, b);
+  }
+}
+
+/**
+ * SetUpgradePolicyResponse
+ *
+ * Response for SetUpgradePolicy
+ *
+ * @generated from message kubeappsapis.core.upgradepolicies.v1alpha1.SetUpgradePolicyResponse
+ */
+export class SetUpgradePolicyResponse extends Message<SetUpgradePolicyResponse> {
+  /**
+   * Upgrade policy
+   *
+   * The upgrade policy stored for the installed package.
+   *
+   * @generated from field: kubeappsapis.core.upgradepolicies.v1alpha1.UpgradePolicy upgrade_policy = 1;
+   */
+  upgradePolicy?: UpgradePolicy;
+
+  constructor(data?: PartialMessage<SetUpgradePolicyResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.upgradepolicies.v1alpha1.SetUpgradePolicyResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "upgrade_policy", kind: "message", T: UpgradePolicy },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): SetUpgradePolicyResponse {
+    return new SetUpgradePolicyResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): SetUpgradePolicyResponse {
+    return new SetUpgradePolicyResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): SetUpgradePolicyResponse {
+    return new SetUpgradePolicyResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: SetUpgradePolicyResponse | PlainMessage<SetUpgradePolicyResponse> | undefined,
+    b: SetUpgradePolicyResponse | PlainMessage<SetUpgradePolicyResponse> | undefined,
+  ): boolean {
+    return proto3.util.equals(SetUpgradePolicyResponse, a, b);
+  }
+}
+
+/**
+ * DeleteUpgradePolicyRequest
+ *
+ * Request for DeleteUpgradePolicy
+ *
+ * @generated from message kubeappsapis.core.upgradepolicies.v1alpha1.DeleteUpgradePolicyRequest
+ */
+export class DeleteUpgradePolicyRequest extends Message<DeleteUpgradePolicyRequest> {
+  /**
+   * A reference uniquely identifying the installed package.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.InstalledPackageReference installed_package_ref = 1;
+   */
+  installedPackageRef?: InstalledPackageReference;
+
+  constructor(data?: PartialMessage<DeleteUpgradePolicyRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName =
+    "kubeappsapis.core.upgradepolicies.v1alpha1.DeleteUpgradePolicyRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "installed_package_ref", kind: "message", T: InstalledPackageReference },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): DeleteUpgradePolicyRequest {
+    return new DeleteUpgradePolicyRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): DeleteUpgradePolicyRequest {
+    return new DeleteUpgradePolicyRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): DeleteUpgradePolicyRequest {
+    return new DeleteUpgradePolicyRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: DeleteUpgradePolicyRequest | PlainMessage<DeleteUpgradePolicyRequest> | undefined,
+    b: DeleteUpgradePolicyRequest | PlainMessage<DeleteUpgradePolicyRequest> | undefined,
+  ): boolean {
+    return proto3.util.equals(DeleteUpgradePolicyRequest, a, b);
+  }
+}
+
+/**
+ * DeleteUpgradePolicyResponse
+ *
+ * Response for DeleteUpgradePolicy
+ *
+ * @generated from message kubeappsapis.core.upgradepolicies.v1alpha1.DeleteUpgradePolicyResponse
+ */
+export class DeleteUpgradePolicyResponse extends Message<DeleteUpgradePolicyResponse> {
+  constructor(data?: PartialMessage<DeleteUpgradePolicyResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName =
+    "kubeappsapis.core.upgradepolicies.v1alpha1.DeleteUpgradePolicyResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => []);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): DeleteUpgradePolicyResponse {
+    return new DeleteUpgradePolicyResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): DeleteUpgradePolicyResponse {
+    return new DeleteUpgradePolicyResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): DeleteUpgradePolicyResponse {
+    return new DeleteUpgradePolicyResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: DeleteUpgradePolicyResponse | PlainMessage<DeleteUpgradePolicyResponse> | undefined,
+    b: DeleteUpgradePolicyResponse | PlainMessage<DeleteUpgradePolicyResponse> | undefined,
+  ): boolean {
+    return proto3.util.equals(DeleteUpgradePolicyResponse, a, b);
+  }
+}
+
+/**
+ * GetUpgradeHistoryRequest
+ *
+ * Request for GetUpgradeHistory
+ *
+ * @generated from message kubeappsapis.core.upgradepolicies.v1alpha1.GetUpgradeHistoryRequest
+ */
+export class GetUpgradeHistoryRequest extends Message<GetUpgradeHistoryRequest> {
+  /**
+   * A reference uniquely identifying the installed package.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1.InstalledPackageReference installed_package_ref = 1;
+   */
+  installedPackageRef?: InstalledPackageReference;
+
+  constructor(data?: PartialMessage<GetUpgradeHistoryRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.upgradepolicies.v1alpha1.GetUpgradeHistoryRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "installed_package_ref", kind: "message", T: InstalledPackageReference },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): GetUpgradeHistoryRequest {
+    return new GetUpgradeHistoryRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): GetUpgradeHistoryRequest {
+    return new GetUpgradeHistoryRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetUpgradeHistoryRequest {
+    return new GetUpgradeHistoryRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: GetUpgradeHistoryRequest | PlainMessage<GetUpgradeHistoryRequest> | undefined,
+    b: GetUpgradeHistoryRequest | PlainMessage<GetUpgradeHistoryRequest> | undefined,
+  ): boolean {
+    return proto3.util.equals(GetUpgradeHistoryRequest, a, b);
+  }
+}
+
+/**
+ * GetUpgradeHistoryResponse
+ *
+ * Response for GetUpgradeHistory
+ *
+ * @generated from message kubeappsapis.core.upgradepolicies.v1alpha1.GetUpgradeHistoryResponse
+ */
+export class GetUpgradeHistoryResponse extends Message<GetUpgradeHistoryResponse> {
+  /**
+   * Entries
+   *
+   * The upgrades attempted, the most recent first. Only the latest ones are
+   * kept.
+   *
+   * @generated from field: repeated kubeappsapis.core.upgradepolicies.v1alpha1.UpgradeHistoryEntry entries = 1;
+   */
+  entries: UpgradeHistoryEntry[] = [];
+
+  constructor(data?: PartialMessage<GetUpgradeHistoryResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.upgradepolicies.v1alpha1.GetUpgradeHistoryResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "entries", kind: "message", T: UpgradeHistoryEntry, repeated: true },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): GetUpgradeHistoryResponse {
+    return new GetUpgradeHistoryResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): GetUpgradeHistoryResponse {
+    return new GetUpgradeHistoryResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetUpgradeHistoryResponse {
+    return new GetUpgradeHistoryResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: GetUpgradeHistoryResponse | PlainMessage<GetUpgradeHistoryResponse> | undefined,
+    b: GetUpgradeHistoryResponse | PlainMessage<GetUpgradeHistoryResponse> | undefined,
+  ): boolean {
+    return proto3.util.equals(GetUpgradeHistoryResponse, a, b);
+  }
+}
+
+/**
+ * UpgradePolicy
+ *
+ * A maintenance window within which an installed package is upgraded
+ * automatically.
+ *
+ * @generated from message kubeappsapis.core.upgradepolicies.v1alpha1.UpgradePolicy
+ */
+export class UpgradePolicy extends Message<UpgradePolicy> {
+  /**
+   * Schedule
+   *
+   * A cron expression with five fields (minute, hour, day of month, month and
+   * day of week) defining the start of the windows, such as "0 2 * * 6" for
+   * every Saturday at 02:00.
+   *
+   * @generated from field: string schedule = 1;
+   */
+  schedule = "";
+
+  /**
+   * Timezone
+   *
+   * The IANA timezone in which the schedule is evaluated, such as
+   * "Europe/Madrid". UTC if empty.
+   *
+   * @generated from field: string timezone = 2;
+   */
+  timezone = "";
+
+  /**
+   * Window minutes
+   *
+   * The length of each window, in minutes, between 1 and 1440.
+   *
+   * @generated from field: int32 window_minutes = 3;
+   */
+  windowMinutes = 0;
+
+  /**
+   * Version constraint
+   *
+   * A semver constraint expression, such as "~12.1", which the upgraded
+   * versions must satisfy. When empty, the caret constraint of the current
+   * version, such as "^12.1.2", is used so that the installed package is not
+   * upgraded to a new major version.
+   *
+   * @generated from field: string version_constraint = 4;
+   */
+  versionConstraint = "";
+
+  constructor(data?: PartialMessage<UpgradePolicy>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.upgradepolicies.v1alpha1.UpgradePolicy";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "schedule", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "timezone", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "window_minutes", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 4, name: "version_constraint", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): UpgradePolicy {
+    return new UpgradePolicy().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): UpgradePolicy {
+    return new UpgradePolicy().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): UpgradePolicy {
+    return new UpgradePolicy().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: UpgradePolicy | PlainMessage<UpgradePolicy> | undefined,
+    b: UpgradePolicy | PlainMessage<UpgradePolicy> | undefined,
+  ): boolean {
+    return proto3.util.equals(UpgradePolicy, a, b);
+  }
+}
+
+/**
+ * UpgradeHistoryEntry
+ *
+ * An upgrade attempted within a window of an upgrade policy.
+ *
+ * @generated from message kubeappsapis.core.upgradepolicies.v1alpha1.UpgradeHistoryEntry
+ */
+export class UpgradeHistoryEntry extends Message<UpgradeHistoryEntry> {
+  /**
+   * Time
+   *
+   * The time (RFC3339) at which the upgrade was attempted.
+   *
+   * @generated from field: string time = 1;
+   */
+  time = "";
+
+  /**
+   * From version
+   *
+   * The version installed before the upgrade.
+   *
+   * @generated from field: string from_version = 2;
+   */
+  fromVersion = "";
+
+  /**
+   * To version
+   *
+   * The version to which the installed package was upgraded.
+   *
+   * @generated from field: string to_version = 3;
+   */
+  toVersion = "";
+
+  /**
+   * Result
+   *
+   * The result of the upgrade.
+   *
+   * @generated from field: kubeappsapis.core.upgradepolicies.v1alpha1.UpgradeHistoryEntry.Result result = 4;
+   */
+  result = UpgradeHistoryEntry_Result.UNSPECIFIED;
+
+  /**
+   * Message
+   *
+   * Optional text explaining the result, such as the error of a failed
+   * upgrade.
+   *
+   * @generated from field: string message = 5;
+   */
+  message = "";
+
+  constructor(data?: PartialMessage<UpgradeHistoryEntry>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.core.upgradepolicies.v1alpha1.UpgradeHistoryEntry";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "time", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "from_version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "to_version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "result", kind: "enum", T: proto3.getEnumType(UpgradeHistoryEntry_Result) },
+    { no: 5, name: "message", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): UpgradeHistoryEntry {
+    return new UpgradeHistoryEntry().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): UpgradeHistoryEntry {
+    return new UpgradeHistoryEntry().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): UpgradeHistoryEntry {
+    return new UpgradeHistoryEntry().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: UpgradeHistoryEntry | PlainMessage<UpgradeHistoryEntry> | undefined,
+    b: UpgradeHistoryEntry | PlainMessage<UpgradeHistoryEntry> | undefined,
+  ): boolean {
+    return proto3.util.equals(UpgradeHistoryEntry, a, b);
+  }
+}
+
+/**
+ * @generated from enum kubeappsapis.core.upgradepolicies.v1alpha1.UpgradeHistoryEntry.Result
+ */
+export enum UpgradeHistoryEntry_Result {
+  /**
+   * @generated from enum value: RESULT_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * The upgrade was accepted by the plugin. Plugins continuously
+   * reconciling their packages may still be upgrading it.
+   *
+   * @generated from enum value: RESULT_SUCCEEDED = 1;
+   */
+  SUCCEEDED = 1,
+
+  /**
+   * @generated from enum value: RESULT_FAILED = 2;
+   */
+  FAILED = 2,
+}
+// Retrieve enum metadata with: proto3.getEnumType(UpgradeHistoryEntry_Result)
+proto3.util.setEnumType(
+  UpgradeHistoryEntry_Result,
+  "kubeappsapis.core.upgradepolicies.v1alpha1.UpgradeHistoryEntry.Result",
+  [
+    { no: 0, name: "RESULT_UNSPECIFIED" },
+    { no: 1, name: "RESULT_SUCCEEDED" },
+    { no: 2, name: "RESULT_FAILED" },
+  ],
+);
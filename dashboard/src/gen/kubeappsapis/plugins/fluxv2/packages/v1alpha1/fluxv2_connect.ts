@@ -7,18 +7,28 @@
 // @ts-nocheck
 
 import {
+  CheckInstalledPackageNameAvailabilityRequest,
+  CheckInstalledPackageNameAvailabilityResponse,
   CreateInstalledPackageRequest,
   CreateInstalledPackageResponse,
   DeleteInstalledPackageRequest,
   DeleteInstalledPackageResponse,
+  GetAvailablePackageChangelogRequest,
+  GetAvailablePackageChangelogResponse,
   GetAvailablePackageDetailRequest,
   GetAvailablePackageDetailResponse,
+  GetAvailablePackageFiltersRequest,
+  GetAvailablePackageFiltersResponse,
   GetAvailablePackageSummariesRequest,
   GetAvailablePackageSummariesResponse,
+  GetAvailablePackageTextAssetRequest,
+  GetAvailablePackageTextAssetResponse,
   GetAvailablePackageVersionsRequest,
   GetAvailablePackageVersionsResponse,
   GetInstalledPackageDetailRequest,
   GetInstalledPackageDetailResponse,
+  GetInstalledPackageOperationStatusRequest,
+  GetInstalledPackageOperationStatusResponse,
   GetInstalledPackageResourceRefsRequest,
   GetInstalledPackageResourceRefsResponse,
   GetInstalledPackageSummariesRequest,
@@ -27,6 +37,7 @@ import {
   UpdateInstalledPackageResponse,
 } from "../../../../core/packages/v1alpha1/packages_pb";
 import { MethodKind } from "@bufbuild/protobuf";
+import { ConvertInstalledPackageRequest, ConvertInstalledPackageResponse } from "./fluxv2_pb";
 import {
   AddPackageRepositoryRequest,
   AddPackageRepositoryResponse,
@@ -38,6 +49,10 @@ import {
   GetPackageRepositoryPermissionsResponse,
   GetPackageRepositorySummariesRequest,
   GetPackageRepositorySummariesResponse,
+  ListPackageRepositoryPackagesRequest,
+  ListPackageRepositoryPackagesResponse,
+  TestPackageRepositoryConnectionRequest,
+  TestPackageRepositoryConnectionResponse,
   UpdatePackageRepositoryRequest,
   UpdatePackageRepositoryResponse,
 } from "../../../../core/packages/v1alpha1/repositories_pb";
@@ -81,6 +96,39 @@ export const FluxV2PackagesService = {
       O: GetAvailablePackageVersionsResponse,
       kind: MethodKind.Unary,
     },
+    /**
+     * GetAvailablePackageTextAsset returns a range of a text asset of a package managed by the 'fluxv2' plugin
+     *
+     * @generated from rpc kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2PackagesService.GetAvailablePackageTextAsset
+     */
+    getAvailablePackageTextAsset: {
+      name: "GetAvailablePackageTextAsset",
+      I: GetAvailablePackageTextAssetRequest,
+      O: GetAvailablePackageTextAssetResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * GetAvailablePackageChangelog returns the release notes between two versions of a package managed by the 'fluxv2' plugin
+     *
+     * @generated from rpc kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2PackagesService.GetAvailablePackageChangelog
+     */
+    getAvailablePackageChangelog: {
+      name: "GetAvailablePackageChangelog",
+      I: GetAvailablePackageChangelogRequest,
+      O: GetAvailablePackageChangelogResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * GetAvailablePackageFilters returns the filter values of the available packages managed by the 'fluxv2' plugin
+     *
+     * @generated from rpc kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2PackagesService.GetAvailablePackageFilters
+     */
+    getAvailablePackageFilters: {
+      name: "GetAvailablePackageFilters",
+      I: GetAvailablePackageFiltersRequest,
+      O: GetAvailablePackageFiltersResponse,
+      kind: MethodKind.Unary,
+    },
     /**
      * GetInstalledPackageSummaries returns the installed packages managed by the 'fluxv2' plugin
      *
@@ -103,6 +151,17 @@ export const FluxV2PackagesService = {
       O: GetInstalledPackageDetailResponse,
       kind: MethodKind.Unary,
     },
+    /**
+     * CheckInstalledPackageNameAvailability checks whether a package managed by the 'fluxv2' plugin can be installed with the given name
+     *
+     * @generated from rpc kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2PackagesService.CheckInstalledPackageNameAvailability
+     */
+    checkInstalledPackageNameAvailability: {
+      name: "CheckInstalledPackageNameAvailability",
+      I: CheckInstalledPackageNameAvailabilityRequest,
+      O: CheckInstalledPackageNameAvailabilityResponse,
+      kind: MethodKind.Unary,
+    },
     /**
      * CreateInstalledPackage creates an installed package based on the request.
      *
@@ -148,6 +207,29 @@ export const FluxV2PackagesService = {
       O: GetInstalledPackageResourceRefsResponse,
       kind: MethodKind.Unary,
     },
+    /**
+     * GetInstalledPackageOperationStatus returns the progress of the operation in course on an installed package managed by the 'fluxv2' plugin
+     *
+     * @generated from rpc kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2PackagesService.GetInstalledPackageOperationStatus
+     */
+    getInstalledPackageOperationStatus: {
+      name: "GetInstalledPackageOperationStatus",
+      I: GetInstalledPackageOperationStatusRequest,
+      O: GetInstalledPackageOperationStatusResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * ConvertInstalledPackage converts a Helm release installed by the 'helm' plugin into a
+     * flux HelmRelease managed by the 'fluxv2' plugin, without reinstalling it.
+     *
+     * @generated from rpc kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2PackagesService.ConvertInstalledPackage
+     */
+    convertInstalledPackage: {
+      name: "ConvertInstalledPackage",
+      I: ConvertInstalledPackageRequest,
+      O: ConvertInstalledPackageResponse,
+      kind: MethodKind.Unary,
+    },
   },
 } as const;
 
@@ -214,5 +296,23 @@ export const FluxV2RepositoriesService = {
       O: GetPackageRepositoryPermissionsResponse,
       kind: MethodKind.Unary,
     },
+    /**
+     * @generated from rpc kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2RepositoriesService.TestPackageRepositoryConnection
+     */
+    testPackageRepositoryConnection: {
+      name: "TestPackageRepositoryConnection",
+      I: TestPackageRepositoryConnectionRequest,
+      O: TestPackageRepositoryConnectionResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2RepositoriesService.ListPackageRepositoryPackages
+     */
+    listPackageRepositoryPackages: {
+      name: "ListPackageRepositoryPackages",
+      I: ListPackageRepositoryPackagesRequest,
+      O: ListPackageRepositoryPackagesResponse,
+      kind: MethodKind.Unary,
+    },
   },
 } as const;
@@ -15,6 +15,162 @@ import type {
   PlainMessage,
 } from "@bufbuild/protobuf";
 import { Message, proto3 } from "@bufbuild/protobuf";
+import {
+  AvailablePackageReference,
+  InstalledPackageReference,
+  ReconciliationOptions,
+} from "../../../../core/packages/v1alpha1/packages_pb";
+
+/**
+ * ConvertInstalledPackageRequest
+ *
+ * Request for ConvertInstalledPackage
+ *
+ * @generated from message kubeappsapis.plugins.fluxv2.packages.v1alpha1.ConvertInstalledPackageRequest
+ */
+export class ConvertInstalledPackageRequest extends Message<ConvertInstalledPackageRequest> {
+  /**
+   * Installed package reference
+   *
+   * A reference to the installed package managed by the 'helm' plugin, i.e. the
+   * Helm release to be converted.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1alpha1.InstalledPackageReference installed_package_ref = 1;
+   */
+  installedPackageRef?: InstalledPackageReference;
+
+  /**
+   * Available package reference
+   *
+   * A reference to the available package managed by the 'fluxv2' plugin, i.e. the
+   * chart of a flux HelmRepository, from which the HelmRelease installs the same
+   * chart and version as the Helm release.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1alpha1.AvailablePackageReference available_package_ref = 2;
+   */
+  availablePackageRef?: AvailablePackageReference;
+
+  /**
+   * Reconciliation options
+   *
+   * Optional reconciliation options of the HelmRelease.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1alpha1.ReconciliationOptions reconciliation_options = 3;
+   */
+  reconciliationOptions?: ReconciliationOptions;
+
+  /**
+   * Timeout seconds
+   *
+   * Optional number of seconds to wait for the HelmRelease to be reconciled,
+   * defaulting to 5 minutes.
+   *
+   * @generated from field: int32 timeout_seconds = 4;
+   */
+  timeoutSeconds = 0;
+
+  constructor(data?: PartialMessage<ConvertInstalledPackageRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName =
+    "kubeappsapis.plugins.fluxv2.packages.v1alpha1.ConvertInstalledPackageRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "installed_package_ref", kind: "message", T: InstalledPackageReference },
+    { no: 2, name: "available_package_ref", kind: "message", T: AvailablePackageReference },
+    { no: 3, name: "reconciliation_options", kind: "message", T: ReconciliationOptions },
+    { no: 4, name: "timeout_seconds", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): ConvertInstalledPackageRequest {
+    return new ConvertInstalledPackageRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): ConvertInstalledPackageRequest {
+    return new ConvertInstalledPackageRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): ConvertInstalledPackageRequest {
+    return new ConvertInstalledPackageRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: ConvertInstalledPackageRequest | PlainMessage<ConvertInstalledPackageRequest> | undefined,
+    b: ConvertInstalledPackageRequest | PlainMessage<ConvertInstalledPackageRequest> | undefined,
+  ): boolean {
+    return proto3.util.equals(ConvertInstalledPackageRequest, a, b);
+  }
+}
+
+/**
+ * ConvertInstalledPackageResponse
+ *
+ * Response for ConvertInstalledPackage
+ *
+ * @generated from message kubeappsapis.plugins.fluxv2.packages.v1alpha1.ConvertInstalledPackageResponse
+ */
+export class ConvertInstalledPackageResponse extends Message<ConvertInstalledPackageResponse> {
+  /**
+   * Installed package reference
+   *
+   * A reference to the installed package managed by the 'fluxv2' plugin, i.e. the
+   * HelmRelease now managing the Helm release.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1alpha1.InstalledPackageReference installed_package_ref = 1;
+   */
+  installedPackageRef?: InstalledPackageReference;
+
+  constructor(data?: PartialMessage<ConvertInstalledPackageResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName =
+    "kubeappsapis.plugins.fluxv2.packages.v1alpha1.ConvertInstalledPackageResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "installed_package_ref", kind: "message", T: InstalledPackageReference },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): ConvertInstalledPackageResponse {
+    return new ConvertInstalledPackageResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): ConvertInstalledPackageResponse {
+    return new ConvertInstalledPackageResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): ConvertInstalledPackageResponse {
+    return new ConvertInstalledPackageResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: ConvertInstalledPackageResponse | PlainMessage<ConvertInstalledPackageResponse> | undefined,
+    b: ConvertInstalledPackageResponse | PlainMessage<ConvertInstalledPackageResponse> | undefined,
+  ): boolean {
+    return proto3.util.equals(ConvertInstalledPackageResponse, a, b);
+  }
+}
 
 /**
  * Flux PackageRepositoryCustomDetail
@@ -39,6 +195,42 @@ export class FluxPackageRepositoryCustomDetail extends Message<FluxPackageReposi
    */
   provider = "";
 
+  /**
+   * optional timeout of the index download operations (in time+unit), e.g. "60s".
+   * Defaults to the Flux default of 60s if not specified
+   * ref https://fluxcd.io/flux/components/source/helmrepositories/#timeout
+   *
+   * @generated from field: string timeout = 2;
+   */
+  timeout = "";
+
+  /**
+   * optional field that allows suspending the reconciliation of the repository,
+   * so that its index is no longer downloaded until resumed
+   * ref https://fluxcd.io/flux/components/source/helmrepositories/#suspend
+   *
+   * @generated from field: bool suspend = 3;
+   */
+  suspend = false;
+
+  /**
+   * optional glob patterns (as in "*-operator") matched against the names of
+   * the charts of the repository. When not empty, only the charts matching one
+   * of the patterns are made available
+   *
+   * @generated from field: repeated string allowed_packages = 4;
+   */
+  allowedPackages: string[] = [];
+
+  /**
+   * optional glob patterns matched against the names of the charts of the
+   * repository. The charts matching one of the patterns are not made available,
+   * even if they are allowed
+   *
+   * @generated from field: repeated string denied_packages = 5;
+   */
+  deniedPackages: string[] = [];
+
   constructor(data?: PartialMessage<FluxPackageRepositoryCustomDetail>) {
     super();
     proto3.util.initPartial(data, this);
@@ -49,6 +241,22 @@ export class FluxPackageRepositoryCustomDetail extends Message<FluxPackageReposi
     "kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxPackageRepositoryCustomDetail";
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
     { no: 1, name: "provider", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "timeout", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "suspend", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    {
+      no: 4,
+      name: "allowed_packages",
+      kind: "scalar",
+      T: 9 /* ScalarType.STRING */,
+      repeated: true,
+    },
+    {
+      no: 5,
+      name: "denied_packages",
+      kind: "scalar",
+      T: 9 /* ScalarType.STRING */,
+      repeated: true,
+    },
   ]);
 
   static fromBinary(
@@ -85,3 +293,406 @@ export class FluxPackageRepositoryCustomDetail extends Message<FluxPackageReposi
     return proto3.util.equals(FluxPackageRepositoryCustomDetail, a, b);
   }
 }
+
+/**
+ * FluxInstalledPackageCustomDetail
+ *
+ * custom fields for the installed packages managed by the 'fluxv2' plugin,
+ * used in both the installed package requests and details
+ *
+ * @generated from message kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxInstalledPackageCustomDetail
+ */
+export class FluxInstalledPackageCustomDetail extends Message<FluxInstalledPackageCustomDetail> {
+  /**
+   * The installed packages which must be ready before flux installs or
+   * upgrades this one, set as the HelmRelease spec.dependsOn.
+   * In update requests providing a custom detail, the dependencies replace the
+   * ones previously declared, while they are kept untouched otherwise.
+   * ref https://fluxcd.io/flux/components/helm/helmreleases/#helmrelease-dependencies
+   *
+   * @generated from field: repeated kubeappsapis.plugins.fluxv2.packages.v1alpha1.HelmReleaseDependency depends_on = 1;
+   */
+  dependsOn: HelmReleaseDependency[] = [];
+
+  /**
+   * A values file stored in a Git repository, used as the base values of the
+   * release, the values of the request being merged on top of it. The plugin
+   * manages a flux GitRepository and a ConfigMap, named after the release
+   * with a "-values" suffix, the ConfigMap being set as the HelmRelease
+   * spec.valuesFrom. The values file is read again from Git on each update
+   * of the installed package.
+   * In update requests providing a custom detail, the values file replaces
+   * the one previously referenced, or is no longer used if not set.
+   * ref https://fluxcd.io/flux/components/helm/helmreleases/#values-overrides
+   *
+   * @generated from field: kubeappsapis.plugins.fluxv2.packages.v1alpha1.GitValuesReference values_from_git = 2;
+   */
+  valuesFromGit?: GitValuesReference;
+
+  /**
+   * The patches applied by flux to the manifests rendered by helm, set as
+   * the patches of the Kustomize post renderer of the HelmRelease, in order.
+   * The other post renderers, and the other fields of the Kustomize post
+   * renderer, are kept untouched.
+   * In update requests providing a custom detail, the patches replace the
+   * ones previously set.
+   * ref https://fluxcd.io/flux/components/helm/helmreleases/#post-renderers
+   *
+   * @generated from field: repeated kubeappsapis.plugins.fluxv2.packages.v1alpha1.PostRenderPatch post_render_patches = 3;
+   */
+  postRenderPatches: PostRenderPatch[] = [];
+
+  constructor(data?: PartialMessage<FluxInstalledPackageCustomDetail>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName =
+    "kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxInstalledPackageCustomDetail";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "depends_on", kind: "message", T: HelmReleaseDependency, repeated: true },
+    { no: 2, name: "values_from_git", kind: "message", T: GitValuesReference },
+    { no: 3, name: "post_render_patches", kind: "message", T: PostRenderPatch, repeated: true },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): FluxInstalledPackageCustomDetail {
+    return new FluxInstalledPackageCustomDetail().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): FluxInstalledPackageCustomDetail {
+    return new FluxInstalledPackageCustomDetail().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): FluxInstalledPackageCustomDetail {
+    return new FluxInstalledPackageCustomDetail().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | FluxInstalledPackageCustomDetail
+      | PlainMessage<FluxInstalledPackageCustomDetail>
+      | undefined,
+    b:
+      | FluxInstalledPackageCustomDetail
+      | PlainMessage<FluxInstalledPackageCustomDetail>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(FluxInstalledPackageCustomDetail, a, b);
+  }
+}
+
+/**
+ * PostRenderPatch
+ *
+ * A Kustomize patch applied to the manifests rendered by helm.
+ *
+ * @generated from message kubeappsapis.plugins.fluxv2.packages.v1alpha1.PostRenderPatch
+ */
+export class PostRenderPatch extends Message<PostRenderPatch> {
+  /**
+   * The patch, either a strategic merge patch, as a YAML or JSON object, or a
+   * JSON6902 patch, as a YAML or JSON array of operations. Required
+   *
+   * @generated from field: string patch = 1;
+   */
+  patch = "";
+
+  /**
+   * The resources the patch is applied to. Required for the JSON6902 patches,
+   * the strategic merge patches applying by default to the resource matching
+   * their kind and name
+   *
+   * @generated from field: kubeappsapis.plugins.fluxv2.packages.v1alpha1.PatchTarget target = 2;
+   */
+  target?: PatchTarget;
+
+  constructor(data?: PartialMessage<PostRenderPatch>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.plugins.fluxv2.packages.v1alpha1.PostRenderPatch";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "patch", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "target", kind: "message", T: PatchTarget },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): PostRenderPatch {
+    return new PostRenderPatch().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): PostRenderPatch {
+    return new PostRenderPatch().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): PostRenderPatch {
+    return new PostRenderPatch().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: PostRenderPatch | PlainMessage<PostRenderPatch> | undefined,
+    b: PostRenderPatch | PlainMessage<PostRenderPatch> | undefined,
+  ): boolean {
+    return proto3.util.equals(PostRenderPatch, a, b);
+  }
+}
+
+/**
+ * PatchTarget
+ *
+ * Selects the resources a patch is applied to, all the fields set having to
+ * match.
+ *
+ * @generated from message kubeappsapis.plugins.fluxv2.packages.v1alpha1.PatchTarget
+ */
+export class PatchTarget extends Message<PatchTarget> {
+  /**
+   * The API group of the resources. Optional
+   *
+   * @generated from field: string group = 1;
+   */
+  group = "";
+
+  /**
+   * The API version of the resources. Optional
+   *
+   * @generated from field: string version = 2;
+   */
+  version = "";
+
+  /**
+   * The kind of the resources. Optional
+   *
+   * @generated from field: string kind = 3;
+   */
+  kind = "";
+
+  /**
+   * The namespace of the resources. Optional
+   *
+   * @generated from field: string namespace = 4;
+   */
+  namespace = "";
+
+  /**
+   * The name of the resources. Optional
+   *
+   * @generated from field: string name = 5;
+   */
+  name = "";
+
+  /**
+   * A label selector, such as "app=my-app". Optional
+   *
+   * @generated from field: string label_selector = 6;
+   */
+  labelSelector = "";
+
+  /**
+   * An annotation selector, with the syntax of the label selectors. Optional
+   *
+   * @generated from field: string annotation_selector = 7;
+   */
+  annotationSelector = "";
+
+  constructor(data?: PartialMessage<PatchTarget>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.plugins.fluxv2.packages.v1alpha1.PatchTarget";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "group", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "kind", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "namespace", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 6, name: "label_selector", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 7, name: "annotation_selector", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): PatchTarget {
+    return new PatchTarget().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): PatchTarget {
+    return new PatchTarget().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): PatchTarget {
+    return new PatchTarget().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: PatchTarget | PlainMessage<PatchTarget> | undefined,
+    b: PatchTarget | PlainMessage<PatchTarget> | undefined,
+  ): boolean {
+    return proto3.util.equals(PatchTarget, a, b);
+  }
+}
+
+/**
+ * GitValuesReference
+ *
+ * A reference to a values file stored in a Git repository.
+ *
+ * @generated from message kubeappsapis.plugins.fluxv2.packages.v1alpha1.GitValuesReference
+ */
+export class GitValuesReference extends Message<GitValuesReference> {
+  /**
+   * The URL of the Git repository, such as https://github.com/org/repo. Required
+   *
+   * @generated from field: string url = 1;
+   */
+  url = "";
+
+  /**
+   * The path of the values file in the Git repository, such as
+   * "apps/my-app/values.yaml". Required
+   *
+   * @generated from field: string path = 2;
+   */
+  path = "";
+
+  /**
+   * The branch to be checked out. Optional, at most one of branch, tag and
+   * commit can be set, flux checking out the default branch when none is set
+   *
+   * @generated from field: string branch = 3;
+   */
+  branch = "";
+
+  /**
+   * The tag to be checked out. Optional
+   *
+   * @generated from field: string tag = 4;
+   */
+  tag = "";
+
+  /**
+   * The commit SHA to be checked out. Optional
+   *
+   * @generated from field: string commit = 5;
+   */
+  commit = "";
+
+  /**
+   * The name of a secret in the namespace of the release with the credentials
+   * of the Git repository. Optional
+   * ref https://fluxcd.io/flux/components/source/gitrepositories/#secret-reference
+   *
+   * @generated from field: string secret_ref = 6;
+   */
+  secretRef = "";
+
+  constructor(data?: PartialMessage<GitValuesReference>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.plugins.fluxv2.packages.v1alpha1.GitValuesReference";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "url", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "path", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "branch", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "tag", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "commit", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 6, name: "secret_ref", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): GitValuesReference {
+    return new GitValuesReference().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): GitValuesReference {
+    return new GitValuesReference().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GitValuesReference {
+    return new GitValuesReference().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: GitValuesReference | PlainMessage<GitValuesReference> | undefined,
+    b: GitValuesReference | PlainMessage<GitValuesReference> | undefined,
+  ): boolean {
+    return proto3.util.equals(GitValuesReference, a, b);
+  }
+}
+
+/**
+ * HelmReleaseDependency
+ *
+ * A reference to a flux HelmRelease another one depends on.
+ *
+ * @generated from message kubeappsapis.plugins.fluxv2.packages.v1alpha1.HelmReleaseDependency
+ */
+export class HelmReleaseDependency extends Message<HelmReleaseDependency> {
+  /**
+   * The name of the HelmRelease. Required
+   *
+   * @generated from field: string name = 1;
+   */
+  name = "";
+
+  /**
+   * The namespace of the HelmRelease, defaulting to the namespace of the
+   * dependent HelmRelease. Optional
+   *
+   * @generated from field: string namespace = 2;
+   */
+  namespace = "";
+
+  constructor(data?: PartialMessage<HelmReleaseDependency>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.plugins.fluxv2.packages.v1alpha1.HelmReleaseDependency";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "namespace", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): HelmReleaseDependency {
+    return new HelmReleaseDependency().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): HelmReleaseDependency {
+    return new HelmReleaseDependency().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): HelmReleaseDependency {
+    return new HelmReleaseDependency().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: HelmReleaseDependency | PlainMessage<HelmReleaseDependency> | undefined,
+    b: HelmReleaseDependency | PlainMessage<HelmReleaseDependency> | undefined,
+  ): boolean {
+    return proto3.util.equals(HelmReleaseDependency, a, b);
+  }
+}
@@ -7,18 +7,28 @@
 // @ts-nocheck
 
 import {
+  CheckInstalledPackageNameAvailabilityRequest,
+  CheckInstalledPackageNameAvailabilityResponse,
   CreateInstalledPackageRequest,
   CreateInstalledPackageResponse,
   DeleteInstalledPackageRequest,
   DeleteInstalledPackageResponse,
+  GetAvailablePackageChangelogRequest,
+  GetAvailablePackageChangelogResponse,
   GetAvailablePackageDetailRequest,
   GetAvailablePackageDetailResponse,
+  GetAvailablePackageFiltersRequest,
+  GetAvailablePackageFiltersResponse,
   GetAvailablePackageSummariesRequest,
   GetAvailablePackageSummariesResponse,
+  GetAvailablePackageTextAssetRequest,
+  GetAvailablePackageTextAssetResponse,
   GetAvailablePackageVersionsRequest,
   GetAvailablePackageVersionsResponse,
   GetInstalledPackageDetailRequest,
   GetInstalledPackageDetailResponse,
+  GetInstalledPackageOperationStatusRequest,
+  GetInstalledPackageOperationStatusResponse,
   GetInstalledPackageResourceRefsRequest,
   GetInstalledPackageResourceRefsResponse,
   GetInstalledPackageSummariesRequest,
@@ -27,7 +37,14 @@ import {
   UpdateInstalledPackageResponse,
 } from "../../../../core/packages/v1alpha1/packages_pb";
 import { MethodKind } from "@bufbuild/protobuf";
-import { RollbackInstalledPackageRequest, RollbackInstalledPackageResponse } from "./helm_pb";
+import {
+  GetInstalledPackageRevisionRequest,
+  GetInstalledPackageRevisionResponse,
+  GetInstalledPackageRevisionsRequest,
+  GetInstalledPackageRevisionsResponse,
+  RollbackInstalledPackageRequest,
+  RollbackInstalledPackageResponse,
+} from "./helm_pb";
 import {
   AddPackageRepositoryRequest,
   AddPackageRepositoryResponse,
@@ -39,6 +56,10 @@ import {
   GetPackageRepositoryPermissionsResponse,
   GetPackageRepositorySummariesRequest,
   GetPackageRepositorySummariesResponse,
+  ListPackageRepositoryPackagesRequest,
+  ListPackageRepositoryPackagesResponse,
+  TestPackageRepositoryConnectionRequest,
+  TestPackageRepositoryConnectionResponse,
   UpdatePackageRepositoryRequest,
   UpdatePackageRepositoryResponse,
 } from "../../../../core/packages/v1alpha1/repositories_pb";
@@ -82,6 +103,39 @@ export const HelmPackagesService = {
       O: GetAvailablePackageVersionsResponse,
       kind: MethodKind.Unary,
     },
+    /**
+     * GetAvailablePackageTextAsset returns a range of a text asset of a package managed by the 'helm' plugin
+     *
+     * @generated from rpc kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService.GetAvailablePackageTextAsset
+     */
+    getAvailablePackageTextAsset: {
+      name: "GetAvailablePackageTextAsset",
+      I: GetAvailablePackageTextAssetRequest,
+      O: GetAvailablePackageTextAssetResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * GetAvailablePackageChangelog returns the release notes between two versions of a package managed by the 'helm' plugin
+     *
+     * @generated from rpc kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService.GetAvailablePackageChangelog
+     */
+    getAvailablePackageChangelog: {
+      name: "GetAvailablePackageChangelog",
+      I: GetAvailablePackageChangelogRequest,
+      O: GetAvailablePackageChangelogResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * GetAvailablePackageFilters returns the filter values of the available packages managed by the 'helm' plugin
+     *
+     * @generated from rpc kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService.GetAvailablePackageFilters
+     */
+    getAvailablePackageFilters: {
+      name: "GetAvailablePackageFilters",
+      I: GetAvailablePackageFiltersRequest,
+      O: GetAvailablePackageFiltersResponse,
+      kind: MethodKind.Unary,
+    },
     /**
      * GetInstalledPackageSummaries returns the installed packages managed by the 'helm' plugin
      *
@@ -104,6 +158,17 @@ export const HelmPackagesService = {
       O: GetInstalledPackageDetailResponse,
       kind: MethodKind.Unary,
     },
+    /**
+     * CheckInstalledPackageNameAvailability checks whether a package managed by the 'helm' plugin can be installed with the given name
+     *
+     * @generated from rpc kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService.CheckInstalledPackageNameAvailability
+     */
+    checkInstalledPackageNameAvailability: {
+      name: "CheckInstalledPackageNameAvailability",
+      I: CheckInstalledPackageNameAvailabilityRequest,
+      O: CheckInstalledPackageNameAvailabilityResponse,
+      kind: MethodKind.Unary,
+    },
     /**
      * CreateInstalledPackage creates an installed package based on the request.
      *
@@ -148,6 +213,29 @@ export const HelmPackagesService = {
       O: RollbackInstalledPackageResponse,
       kind: MethodKind.Unary,
     },
+    /**
+     * GetInstalledPackageRevisions returns the revisions of the Helm release of an installed package.
+     *
+     * @generated from rpc kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService.GetInstalledPackageRevisions
+     */
+    getInstalledPackageRevisions: {
+      name: "GetInstalledPackageRevisions",
+      I: GetInstalledPackageRevisionsRequest,
+      O: GetInstalledPackageRevisionsResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * GetInstalledPackageRevision returns a revision of the Helm release of an installed package,
+     * along with its rendered manifest and values.
+     *
+     * @generated from rpc kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService.GetInstalledPackageRevision
+     */
+    getInstalledPackageRevision: {
+      name: "GetInstalledPackageRevision",
+      I: GetInstalledPackageRevisionRequest,
+      O: GetInstalledPackageRevisionResponse,
+      kind: MethodKind.Unary,
+    },
     /**
      * GetInstalledPackageResourceRefs returns the references for the Kubernetes resources created by
      * an installed package.
@@ -160,6 +248,17 @@ export const HelmPackagesService = {
       O: GetInstalledPackageResourceRefsResponse,
       kind: MethodKind.Unary,
     },
+    /**
+     * GetInstalledPackageOperationStatus returns the progress of the operation in course on an installed package managed by the 'helm' plugin
+     *
+     * @generated from rpc kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService.GetInstalledPackageOperationStatus
+     */
+    getInstalledPackageOperationStatus: {
+      name: "GetInstalledPackageOperationStatus",
+      I: GetInstalledPackageOperationStatusRequest,
+      O: GetInstalledPackageOperationStatusResponse,
+      kind: MethodKind.Unary,
+    },
   },
 } as const;
 
@@ -225,5 +324,23 @@ export const HelmRepositoriesService = {
       O: GetPackageRepositoryPermissionsResponse,
       kind: MethodKind.Unary,
     },
+    /**
+     * @generated from rpc kubeappsapis.plugins.helm.packages.v1alpha1.HelmRepositoriesService.TestPackageRepositoryConnection
+     */
+    testPackageRepositoryConnection: {
+      name: "TestPackageRepositoryConnection",
+      I: TestPackageRepositoryConnectionRequest,
+      O: TestPackageRepositoryConnectionResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc kubeappsapis.plugins.helm.packages.v1alpha1.HelmRepositoriesService.ListPackageRepositoryPackages
+     */
+    listPackageRepositoryPackages: {
+      name: "ListPackageRepositoryPackages",
+      I: ListPackageRepositoryPackagesRequest,
+      O: ListPackageRepositoryPackagesResponse,
+      kind: MethodKind.Unary,
+    },
   },
 } as const;
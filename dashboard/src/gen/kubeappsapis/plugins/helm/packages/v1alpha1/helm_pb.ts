@@ -18,6 +18,47 @@ import { Message, proto3 } from "@bufbuild/protobuf";
 import { InstalledPackageReference } from "../../../../core/packages/v1alpha1/packages_pb";
 import { DockerCredentials } from "../../../../core/packages/v1alpha1/repositories_pb";
 
+/**
+ * ReleaseNameConflictStrategy
+ *
+ * What to do when the name of a release being created is already used in
+ * its namespace.
+ *
+ * @generated from enum kubeappsapis.plugins.helm.packages.v1alpha1.ReleaseNameConflictStrategy
+ */
+export enum ReleaseNameConflictStrategy {
+  /**
+   * Use the strategy configured for the plugin.
+   *
+   * @generated from enum value: RELEASE_NAME_CONFLICT_STRATEGY_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * Fail with an AlreadyExists error.
+   *
+   * @generated from enum value: RELEASE_NAME_CONFLICT_STRATEGY_ERROR = 1;
+   */
+  ERROR = 1,
+
+  /**
+   * Append a random suffix to the name until it is available.
+   *
+   * @generated from enum value: RELEASE_NAME_CONFLICT_STRATEGY_AUTO_SUFFIX = 2;
+   */
+  AUTO_SUFFIX = 2,
+}
+// Retrieve enum metadata with: proto3.getEnumType(ReleaseNameConflictStrategy)
+proto3.util.setEnumType(
+  ReleaseNameConflictStrategy,
+  "kubeappsapis.plugins.helm.packages.v1alpha1.ReleaseNameConflictStrategy",
+  [
+    { no: 0, name: "RELEASE_NAME_CONFLICT_STRATEGY_UNSPECIFIED" },
+    { no: 1, name: "RELEASE_NAME_CONFLICT_STRATEGY_ERROR" },
+    { no: 2, name: "RELEASE_NAME_CONFLICT_STRATEGY_AUTO_SUFFIX" },
+  ],
+);
+
 /**
  * InstalledPackageDetailCustomDataHelm
  *
@@ -83,6 +124,222 @@ export class InstalledPackageDetailCustomDataHelm extends Message<InstalledPacka
   }
 }
 
+/**
+ * AvailablePackageDetailCustomDataHelm
+ *
+ * AvailablePackageDetailCustomDataHelm is a message type used for the
+ * AvailablePackageDetail.CustomDetail field by the helm plugin.
+ *
+ * @generated from message kubeappsapis.plugins.helm.packages.v1alpha1.AvailablePackageDetailCustomDataHelm
+ */
+export class AvailablePackageDetailCustomDataHelm extends Message<AvailablePackageDetailCustomDataHelm> {
+  /**
+   * VulnerabilitySummary
+   *
+   * The vulnerabilities of the chart version and of its images, as reported by
+   * the vulnerability scanner configured for the plugin. Not set when no scanner
+   * is configured or when the scan failed.
+   *
+   * @generated from field: kubeappsapis.plugins.helm.packages.v1alpha1.VulnerabilitySummary vulnerability_summary = 1;
+   */
+  vulnerabilitySummary?: VulnerabilitySummary;
+
+  constructor(data?: PartialMessage<AvailablePackageDetailCustomDataHelm>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName =
+    "kubeappsapis.plugins.helm.packages.v1alpha1.AvailablePackageDetailCustomDataHelm";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "vulnerability_summary", kind: "message", T: VulnerabilitySummary },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): AvailablePackageDetailCustomDataHelm {
+    return new AvailablePackageDetailCustomDataHelm().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): AvailablePackageDetailCustomDataHelm {
+    return new AvailablePackageDetailCustomDataHelm().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): AvailablePackageDetailCustomDataHelm {
+    return new AvailablePackageDetailCustomDataHelm().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | AvailablePackageDetailCustomDataHelm
+      | PlainMessage<AvailablePackageDetailCustomDataHelm>
+      | undefined,
+    b:
+      | AvailablePackageDetailCustomDataHelm
+      | PlainMessage<AvailablePackageDetailCustomDataHelm>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(AvailablePackageDetailCustomDataHelm, a, b);
+  }
+}
+
+/**
+ * CreateInstalledPackageCustomDataHelm
+ *
+ * CreateInstalledPackageCustomDataHelm is a message type used for the
+ * CreateInstalledPackageRequest.CustomDetail field by the helm plugin.
+ *
+ * @generated from message kubeappsapis.plugins.helm.packages.v1alpha1.CreateInstalledPackageCustomDataHelm
+ */
+export class CreateInstalledPackageCustomDataHelm extends Message<CreateInstalledPackageCustomDataHelm> {
+  /**
+   * ReleaseNameTemplate
+   *
+   * The template used to generate the release name when no name is provided,
+   * with the `.PackageName` and `.RandomSuffix` fields, such as
+   * "{{ .PackageName }}-{{ .RandomSuffix }}". Defaults to the template
+   * configured for the plugin.
+   *
+   * @generated from field: string release_name_template = 1;
+   */
+  releaseNameTemplate = "";
+
+  /**
+   * ReleaseNameConflictStrategy
+   *
+   * What to do when the provided or generated release name is already used
+   * in the target namespace. Defaults to the strategy configured for the plugin.
+   *
+   * @generated from field: kubeappsapis.plugins.helm.packages.v1alpha1.ReleaseNameConflictStrategy release_name_conflict_strategy = 2;
+   */
+  releaseNameConflictStrategy = ReleaseNameConflictStrategy.UNSPECIFIED;
+
+  constructor(data?: PartialMessage<CreateInstalledPackageCustomDataHelm>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName =
+    "kubeappsapis.plugins.helm.packages.v1alpha1.CreateInstalledPackageCustomDataHelm";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "release_name_template", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    {
+      no: 2,
+      name: "release_name_conflict_strategy",
+      kind: "enum",
+      T: proto3.getEnumType(ReleaseNameConflictStrategy),
+    },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): CreateInstalledPackageCustomDataHelm {
+    return new CreateInstalledPackageCustomDataHelm().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): CreateInstalledPackageCustomDataHelm {
+    return new CreateInstalledPackageCustomDataHelm().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): CreateInstalledPackageCustomDataHelm {
+    return new CreateInstalledPackageCustomDataHelm().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | CreateInstalledPackageCustomDataHelm
+      | PlainMessage<CreateInstalledPackageCustomDataHelm>
+      | undefined,
+    b:
+      | CreateInstalledPackageCustomDataHelm
+      | PlainMessage<CreateInstalledPackageCustomDataHelm>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(CreateInstalledPackageCustomDataHelm, a, b);
+  }
+}
+
+/**
+ * VulnerabilitySummary
+ *
+ * A summary of the vulnerabilities found by an external scanner.
+ *
+ * @generated from message kubeappsapis.plugins.helm.packages.v1alpha1.VulnerabilitySummary
+ */
+export class VulnerabilitySummary extends Message<VulnerabilitySummary> {
+  /**
+   * The number of critical vulnerabilities
+   *
+   * @generated from field: int32 critical = 1;
+   */
+  critical = 0;
+
+  /**
+   * The number of high vulnerabilities
+   *
+   * @generated from field: int32 high = 2;
+   */
+  high = 0;
+
+  /**
+   * The URL of the full report of the scanner, if any
+   *
+   * @generated from field: string report_url = 3;
+   */
+  reportUrl = "";
+
+  constructor(data?: PartialMessage<VulnerabilitySummary>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.plugins.helm.packages.v1alpha1.VulnerabilitySummary";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "critical", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 2, name: "high", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 3, name: "report_url", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): VulnerabilitySummary {
+    return new VulnerabilitySummary().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): VulnerabilitySummary {
+    return new VulnerabilitySummary().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): VulnerabilitySummary {
+    return new VulnerabilitySummary().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: VulnerabilitySummary | PlainMessage<VulnerabilitySummary> | undefined,
+    b: VulnerabilitySummary | PlainMessage<VulnerabilitySummary> | undefined,
+  ): boolean {
+    return proto3.util.equals(VulnerabilitySummary, a, b);
+  }
+}
+
 /**
  * @generated from message kubeappsapis.plugins.helm.packages.v1alpha1.RollbackInstalledPackageRequest
  */
@@ -212,6 +469,406 @@ export class RollbackInstalledPackageResponse extends Message<RollbackInstalledP
   }
 }
 
+/**
+ * GetInstalledPackageRevisionsRequest
+ *
+ * Request for GetInstalledPackageRevisions
+ *
+ * @generated from message kubeappsapis.plugins.helm.packages.v1alpha1.GetInstalledPackageRevisionsRequest
+ */
+export class GetInstalledPackageRevisionsRequest extends Message<GetInstalledPackageRevisionsRequest> {
+  /**
+   * Installed package reference
+   *
+   * A reference uniquely identifying the installed package.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1alpha1.InstalledPackageReference installed_package_ref = 1;
+   */
+  installedPackageRef?: InstalledPackageReference;
+
+  constructor(data?: PartialMessage<GetInstalledPackageRevisionsRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName =
+    "kubeappsapis.plugins.helm.packages.v1alpha1.GetInstalledPackageRevisionsRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "installed_package_ref", kind: "message", T: InstalledPackageReference },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): GetInstalledPackageRevisionsRequest {
+    return new GetInstalledPackageRevisionsRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): GetInstalledPackageRevisionsRequest {
+    return new GetInstalledPackageRevisionsRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetInstalledPackageRevisionsRequest {
+    return new GetInstalledPackageRevisionsRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | GetInstalledPackageRevisionsRequest
+      | PlainMessage<GetInstalledPackageRevisionsRequest>
+      | undefined,
+    b:
+      | GetInstalledPackageRevisionsRequest
+      | PlainMessage<GetInstalledPackageRevisionsRequest>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(GetInstalledPackageRevisionsRequest, a, b);
+  }
+}
+
+/**
+ * GetInstalledPackageRevisionsResponse
+ *
+ * Response for GetInstalledPackageRevisions
+ *
+ * @generated from message kubeappsapis.plugins.helm.packages.v1alpha1.GetInstalledPackageRevisionsResponse
+ */
+export class GetInstalledPackageRevisionsResponse extends Message<GetInstalledPackageRevisionsResponse> {
+  /**
+   * Revisions
+   *
+   * The revisions of the release, from the latest to the oldest.
+   *
+   * @generated from field: repeated kubeappsapis.plugins.helm.packages.v1alpha1.InstalledPackageRevision revisions = 1;
+   */
+  revisions: InstalledPackageRevision[] = [];
+
+  constructor(data?: PartialMessage<GetInstalledPackageRevisionsResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName =
+    "kubeappsapis.plugins.helm.packages.v1alpha1.GetInstalledPackageRevisionsResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    {
+      no: 1,
+      name: "revisions",
+      kind: "message",
+      T: InstalledPackageRevision,
+      repeated: true,
+    },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): GetInstalledPackageRevisionsResponse {
+    return new GetInstalledPackageRevisionsResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): GetInstalledPackageRevisionsResponse {
+    return new GetInstalledPackageRevisionsResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetInstalledPackageRevisionsResponse {
+    return new GetInstalledPackageRevisionsResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | GetInstalledPackageRevisionsResponse
+      | PlainMessage<GetInstalledPackageRevisionsResponse>
+      | undefined,
+    b:
+      | GetInstalledPackageRevisionsResponse
+      | PlainMessage<GetInstalledPackageRevisionsResponse>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(GetInstalledPackageRevisionsResponse, a, b);
+  }
+}
+
+/**
+ * GetInstalledPackageRevisionRequest
+ *
+ * Request for GetInstalledPackageRevision
+ *
+ * @generated from message kubeappsapis.plugins.helm.packages.v1alpha1.GetInstalledPackageRevisionRequest
+ */
+export class GetInstalledPackageRevisionRequest extends Message<GetInstalledPackageRevisionRequest> {
+  /**
+   * Installed package reference
+   *
+   * A reference uniquely identifying the installed package.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1alpha1.InstalledPackageReference installed_package_ref = 1;
+   */
+  installedPackageRef?: InstalledPackageReference;
+
+  /**
+   * ReleaseRevision
+   *
+   * A number identifying the Helm revision.
+   *
+   * @generated from field: int32 release_revision = 2;
+   */
+  releaseRevision = 0;
+
+  constructor(data?: PartialMessage<GetInstalledPackageRevisionRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName =
+    "kubeappsapis.plugins.helm.packages.v1alpha1.GetInstalledPackageRevisionRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "installed_package_ref", kind: "message", T: InstalledPackageReference },
+    { no: 2, name: "release_revision", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): GetInstalledPackageRevisionRequest {
+    return new GetInstalledPackageRevisionRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): GetInstalledPackageRevisionRequest {
+    return new GetInstalledPackageRevisionRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetInstalledPackageRevisionRequest {
+    return new GetInstalledPackageRevisionRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | GetInstalledPackageRevisionRequest
+      | PlainMessage<GetInstalledPackageRevisionRequest>
+      | undefined,
+    b:
+      | GetInstalledPackageRevisionRequest
+      | PlainMessage<GetInstalledPackageRevisionRequest>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(GetInstalledPackageRevisionRequest, a, b);
+  }
+}
+
+/**
+ * GetInstalledPackageRevisionResponse
+ *
+ * Response for GetInstalledPackageRevision
+ *
+ * @generated from message kubeappsapis.plugins.helm.packages.v1alpha1.GetInstalledPackageRevisionResponse
+ */
+export class GetInstalledPackageRevisionResponse extends Message<GetInstalledPackageRevisionResponse> {
+  /**
+   * Revision
+   *
+   * The summary of the revision.
+   *
+   * @generated from field: kubeappsapis.plugins.helm.packages.v1alpha1.InstalledPackageRevision revision = 1;
+   */
+  revision?: InstalledPackageRevision;
+
+  /**
+   * Manifest
+   *
+   * The manifest rendered by the revision, as a YAML multi-document.
+   *
+   * @generated from field: string manifest = 2;
+   */
+  manifest = "";
+
+  /**
+   * ValuesApplied
+   *
+   * The values, serialized as JSON, supplied by the user for the revision.
+   *
+   * @generated from field: string values_applied = 3;
+   */
+  valuesApplied = "";
+
+  constructor(data?: PartialMessage<GetInstalledPackageRevisionResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName =
+    "kubeappsapis.plugins.helm.packages.v1alpha1.GetInstalledPackageRevisionResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "revision", kind: "message", T: InstalledPackageRevision },
+    { no: 2, name: "manifest", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "values_applied", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): GetInstalledPackageRevisionResponse {
+    return new GetInstalledPackageRevisionResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): GetInstalledPackageRevisionResponse {
+    return new GetInstalledPackageRevisionResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetInstalledPackageRevisionResponse {
+    return new GetInstalledPackageRevisionResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | GetInstalledPackageRevisionResponse
+      | PlainMessage<GetInstalledPackageRevisionResponse>
+      | undefined,
+    b:
+      | GetInstalledPackageRevisionResponse
+      | PlainMessage<GetInstalledPackageRevisionResponse>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(GetInstalledPackageRevisionResponse, a, b);
+  }
+}
+
+/**
+ * InstalledPackageRevision
+ *
+ * A revision of the Helm release of an installed package.
+ *
+ * @generated from message kubeappsapis.plugins.helm.packages.v1alpha1.InstalledPackageRevision
+ */
+export class InstalledPackageRevision extends Message<InstalledPackageRevision> {
+  /**
+   * ReleaseRevision
+   *
+   * A number identifying the Helm revision.
+   *
+   * @generated from field: int32 release_revision = 1;
+   */
+  releaseRevision = 0;
+
+  /**
+   * Status
+   *
+   * The Helm status of the revision, such as deployed, superseded or failed.
+   *
+   * @generated from field: string status = 2;
+   */
+  status = "";
+
+  /**
+   * Chart version
+   *
+   * The version of the chart deployed by the revision.
+   *
+   * @generated from field: string chart_version = 3;
+   */
+  chartVersion = "";
+
+  /**
+   * App version
+   *
+   * The version of the application deployed by the revision.
+   *
+   * @generated from field: string app_version = 4;
+   */
+  appVersion = "";
+
+  /**
+   * Updated
+   *
+   * The time, in RFC3339 format, at which the revision was deployed.
+   *
+   * @generated from field: string updated = 5;
+   */
+  updated = "";
+
+  /**
+   * Description
+   *
+   * The Helm description of the revision, such as the error of a failed one.
+   *
+   * @generated from field: string description = 6;
+   */
+  description = "";
+
+  constructor(data?: PartialMessage<InstalledPackageRevision>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName =
+    "kubeappsapis.plugins.helm.packages.v1alpha1.InstalledPackageRevision";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "release_revision", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 2, name: "status", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "chart_version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "app_version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "updated", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 6, name: "description", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): InstalledPackageRevision {
+    return new InstalledPackageRevision().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): InstalledPackageRevision {
+    return new InstalledPackageRevision().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): InstalledPackageRevision {
+    return new InstalledPackageRevision().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: InstalledPackageRevision | PlainMessage<InstalledPackageRevision> | undefined,
+    b: InstalledPackageRevision | PlainMessage<InstalledPackageRevision> | undefined,
+  ): boolean {
+    return proto3.util.equals(InstalledPackageRevision, a, b);
+  }
+}
+
 /**
  * @generated from message kubeappsapis.plugins.helm.packages.v1alpha1.ImagesPullSecret
  */
@@ -348,6 +1005,51 @@ export class HelmPackageRepositoryCustomDetail extends Message<HelmPackageReposi
    */
   securityContext?: PodSecurityContext;
 
+  /**
+   * namespaces into which packages from this repository can be installed.
+   * When both allowed_namespaces and allowed_namespace_selector are empty,
+   * packages can be installed into any namespace.
+   *
+   * @generated from field: repeated string allowed_namespaces = 9;
+   */
+  allowedNamespaces: string[] = [];
+
+  /**
+   * label selector, eg. "tier=internal", restricting the namespaces into
+   * which packages from this repository can be installed
+   *
+   * @generated from field: string allowed_namespace_selector = 10;
+   */
+  allowedNamespaceSelector = "";
+
+  /**
+   * cloud provider (aws, azure or gcp) whose short-lived registry credentials
+   * are obtained from the identity of Kubeapps (such as IRSA or workload
+   * identity) when syncing the repository and pulling its charts, rather than
+   * from the auth of the repository. Only for OCI repositories.
+   *
+   * @generated from field: string oci_credential_provider = 11;
+   */
+  ociCredentialProvider = "";
+
+  /**
+   * glob patterns (as in "*-operator") matched against the names of the
+   * charts of the repository. When not empty, only the charts matching one of
+   * the patterns are made available.
+   *
+   * @generated from field: repeated string allowed_packages = 12;
+   */
+  allowedPackages: string[] = [];
+
+  /**
+   * glob patterns matched against the names of the charts of the repository.
+   * The charts matching one of the patterns are not made available, even if
+   * they are allowed.
+   *
+   * @generated from field: repeated string denied_packages = 13;
+   */
+  deniedPackages: string[] = [];
+
   constructor(data?: PartialMessage<HelmPackageRepositoryCustomDetail>) {
     super();
     proto3.util.initPartial(data, this);
@@ -377,6 +1079,29 @@ export class HelmPackageRepositoryCustomDetail extends Message<HelmPackageReposi
     },
     { no: 7, name: "tolerations", kind: "message", T: Toleration, repeated: true },
     { no: 8, name: "security_context", kind: "message", T: PodSecurityContext },
+    {
+      no: 9,
+      name: "allowed_namespaces",
+      kind: "scalar",
+      T: 9 /* ScalarType.STRING */,
+      repeated: true,
+    },
+    { no: 10, name: "allowed_namespace_selector", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 11, name: "oci_credential_provider", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    {
+      no: 12,
+      name: "allowed_packages",
+      kind: "scalar",
+      T: 9 /* ScalarType.STRING */,
+      repeated: true,
+    },
+    {
+      no: 13,
+      name: "denied_packages",
+      kind: "scalar",
+      T: 9 /* ScalarType.STRING */,
+      repeated: true,
+    },
   ]);
 
   static fromBinary(
@@ -480,7 +1205,9 @@ export class RepositoryFilterRule extends Message<RepositoryFilterRule> {
 /**
  * ProxyOptions
  *
- * query options for a proxy call
+ * query options for a proxy call. They apply both to the Pod syncing the
+ * repository and to the requests of the plugin fetching from the repository,
+ * such as to download the charts.
  *
  * @generated from message kubeappsapis.plugins.helm.packages.v1alpha1.ProxyOptions
  */
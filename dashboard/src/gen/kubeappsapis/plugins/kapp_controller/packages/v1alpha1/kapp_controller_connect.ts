@@ -7,18 +7,28 @@
 // @ts-nocheck
 
 import {
+  CheckInstalledPackageNameAvailabilityRequest,
+  CheckInstalledPackageNameAvailabilityResponse,
   CreateInstalledPackageRequest,
   CreateInstalledPackageResponse,
   DeleteInstalledPackageRequest,
   DeleteInstalledPackageResponse,
+  GetAvailablePackageChangelogRequest,
+  GetAvailablePackageChangelogResponse,
   GetAvailablePackageDetailRequest,
   GetAvailablePackageDetailResponse,
+  GetAvailablePackageFiltersRequest,
+  GetAvailablePackageFiltersResponse,
   GetAvailablePackageSummariesRequest,
   GetAvailablePackageSummariesResponse,
+  GetAvailablePackageTextAssetRequest,
+  GetAvailablePackageTextAssetResponse,
   GetAvailablePackageVersionsRequest,
   GetAvailablePackageVersionsResponse,
   GetInstalledPackageDetailRequest,
   GetInstalledPackageDetailResponse,
+  GetInstalledPackageOperationStatusRequest,
+  GetInstalledPackageOperationStatusResponse,
   GetInstalledPackageResourceRefsRequest,
   GetInstalledPackageResourceRefsResponse,
   GetInstalledPackageSummariesRequest,
@@ -38,9 +48,25 @@ import {
   GetPackageRepositoryPermissionsResponse,
   GetPackageRepositorySummariesRequest,
   GetPackageRepositorySummariesResponse,
+  ListPackageRepositoryPackagesRequest,
+  ListPackageRepositoryPackagesResponse,
+  TestPackageRepositoryConnectionRequest,
+  TestPackageRepositoryConnectionResponse,
   UpdatePackageRepositoryRequest,
   UpdatePackageRepositoryResponse,
 } from "../../../../core/packages/v1alpha1/repositories_pb";
+import {
+  GetInstalledPackageChangePreviewRequest,
+  GetInstalledPackageChangePreviewResponse,
+  GetInstalledPackageManifestRequest,
+  GetInstalledPackageManifestResponse,
+  GetInstalledPackageReconcileHistoryRequest,
+  GetInstalledPackageReconcileHistoryResponse,
+  ScanPackageRepositoryHostKeysRequest,
+  ScanPackageRepositoryHostKeysResponse,
+  UpdateInstalledPackageManifestRequest,
+  UpdateInstalledPackageManifestResponse,
+} from "./kapp_controller_pb";
 
 /**
  * @generated from service kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService
@@ -81,6 +107,39 @@ export const KappControllerPackagesService = {
       O: GetAvailablePackageVersionsResponse,
       kind: MethodKind.Unary,
     },
+    /**
+     * GetAvailablePackageTextAsset returns a range of a text asset of a package managed by the 'kapp_controller' plugin
+     *
+     * @generated from rpc kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService.GetAvailablePackageTextAsset
+     */
+    getAvailablePackageTextAsset: {
+      name: "GetAvailablePackageTextAsset",
+      I: GetAvailablePackageTextAssetRequest,
+      O: GetAvailablePackageTextAssetResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * GetAvailablePackageChangelog returns the release notes between two versions of a package managed by the 'kapp_controller' plugin
+     *
+     * @generated from rpc kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService.GetAvailablePackageChangelog
+     */
+    getAvailablePackageChangelog: {
+      name: "GetAvailablePackageChangelog",
+      I: GetAvailablePackageChangelogRequest,
+      O: GetAvailablePackageChangelogResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * GetAvailablePackageFilters returns the filter values of the available packages managed by the 'kapp_controller' plugin
+     *
+     * @generated from rpc kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService.GetAvailablePackageFilters
+     */
+    getAvailablePackageFilters: {
+      name: "GetAvailablePackageFilters",
+      I: GetAvailablePackageFiltersRequest,
+      O: GetAvailablePackageFiltersResponse,
+      kind: MethodKind.Unary,
+    },
     /**
      * GetInstalledPackageSummaries returns the installed packages managed by the 'kapp_controller' plugin
      *
@@ -103,6 +162,17 @@ export const KappControllerPackagesService = {
       O: GetInstalledPackageDetailResponse,
       kind: MethodKind.Unary,
     },
+    /**
+     * CheckInstalledPackageNameAvailability checks whether a package managed by the 'kapp_controller' plugin can be installed with the given name
+     *
+     * @generated from rpc kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService.CheckInstalledPackageNameAvailability
+     */
+    checkInstalledPackageNameAvailability: {
+      name: "CheckInstalledPackageNameAvailability",
+      I: CheckInstalledPackageNameAvailabilityRequest,
+      O: CheckInstalledPackageNameAvailabilityResponse,
+      kind: MethodKind.Unary,
+    },
     /**
      * CreateInstalledPackage creates an installed package based on the request.
      *
@@ -148,6 +218,69 @@ export const KappControllerPackagesService = {
       O: GetInstalledPackageResourceRefsResponse,
       kind: MethodKind.Unary,
     },
+    /**
+     * GetInstalledPackageOperationStatus returns the progress of the operation in course on an installed package managed by the 'kapp_controller' plugin
+     *
+     * @generated from rpc kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService.GetInstalledPackageOperationStatus
+     */
+    getInstalledPackageOperationStatus: {
+      name: "GetInstalledPackageOperationStatus",
+      I: GetInstalledPackageOperationStatusRequest,
+      O: GetInstalledPackageOperationStatusResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * GetInstalledPackageChangePreview returns the changes of the Kubernetes resources that an update
+     * of an installed package managed by the 'kapp_controller' plugin would apply, without applying them.
+     *
+     * @generated from rpc kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService.GetInstalledPackageChangePreview
+     */
+    getInstalledPackageChangePreview: {
+      name: "GetInstalledPackageChangePreview",
+      I: GetInstalledPackageChangePreviewRequest,
+      O: GetInstalledPackageChangePreviewResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * GetInstalledPackageReconcileHistory returns the last reconcile outcomes of an installed package
+     * managed by the 'kapp_controller' plugin, most recent first, so that flapping installations can
+     * be diagnosed. The outcomes are recorded when observed by the plugin.
+     *
+     * @generated from rpc kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService.GetInstalledPackageReconcileHistory
+     */
+    getInstalledPackageReconcileHistory: {
+      name: "GetInstalledPackageReconcileHistory",
+      I: GetInstalledPackageReconcileHistoryRequest,
+      O: GetInstalledPackageReconcileHistoryResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * GetInstalledPackageManifest returns the raw YAML manifest of the PackageInstall, or of its App,
+     * of an installed package managed by the 'kapp_controller' plugin, for expert editing.
+     * Only available when the `advancedMode` plugin configuration is enabled.
+     *
+     * @generated from rpc kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService.GetInstalledPackageManifest
+     */
+    getInstalledPackageManifest: {
+      name: "GetInstalledPackageManifest",
+      I: GetInstalledPackageManifestRequest,
+      O: GetInstalledPackageManifestResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * UpdateInstalledPackageManifest replaces the PackageInstall of an installed package managed by
+     * the 'kapp_controller' plugin with the given raw YAML manifest, once validated by the API server.
+     * The fields managed by the plugin cannot be changed.
+     * Only available when the `advancedMode` plugin configuration is enabled.
+     *
+     * @generated from rpc kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackagesService.UpdateInstalledPackageManifest
+     */
+    updateInstalledPackageManifest: {
+      name: "UpdateInstalledPackageManifest",
+      I: UpdateInstalledPackageManifestRequest,
+      O: UpdateInstalledPackageManifestResponse,
+      kind: MethodKind.Unary,
+    },
   },
 } as const;
 
@@ -214,5 +347,37 @@ export const KappControllerRepositoriesService = {
       O: GetPackageRepositoryPermissionsResponse,
       kind: MethodKind.Unary,
     },
+    /**
+     * @generated from rpc kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerRepositoriesService.TestPackageRepositoryConnection
+     */
+    testPackageRepositoryConnection: {
+      name: "TestPackageRepositoryConnection",
+      I: TestPackageRepositoryConnectionRequest,
+      O: TestPackageRepositoryConnectionResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerRepositoriesService.ListPackageRepositoryPackages
+     */
+    listPackageRepositoryPackages: {
+      name: "ListPackageRepositoryPackages",
+      I: ListPackageRepositoryPackagesRequest,
+      O: ListPackageRepositoryPackagesResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * ScanPackageRepositoryHostKeys scans the SSH host keys of the git server of a package repository
+     * managed by the 'kapp_controller' plugin, returning their fingerprints to be confirmed by the user.
+     * The confirmed fingerprints are then sent as the trusted_host_key_fingerprints of the custom detail
+     * when adding or updating the repository, so that the plugin stores the matching keys as its known hosts.
+     *
+     * @generated from rpc kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerRepositoriesService.ScanPackageRepositoryHostKeys
+     */
+    scanPackageRepositoryHostKeys: {
+      name: "ScanPackageRepositoryHostKeys",
+      I: ScanPackageRepositoryHostKeysRequest,
+      O: ScanPackageRepositoryHostKeysResponse,
+      kind: MethodKind.Unary,
+    },
   },
 } as const;
@@ -14,7 +14,138 @@ import type {
   PartialMessage,
   PlainMessage,
 } from "@bufbuild/protobuf";
-import { Message, proto3 } from "@bufbuild/protobuf";
+import { Any, Message, proto3 } from "@bufbuild/protobuf";
+import {
+  InstalledPackageReference,
+  ReconciliationOptions,
+  ResourceRef,
+  VersionReference,
+} from "../../../../core/packages/v1alpha1/packages_pb";
+import { PackageRepositoryReference } from "../../../../core/packages/v1alpha1/repositories_pb";
+
+/**
+ * PackageInstallValuesLayer
+ *
+ * The layers in which the values entries of a PackageInstall are merged, each
+ * one overriding the previous ones.
+ *
+ * @generated from enum kubeappsapis.plugins.kapp_controller.packages.v1alpha1.PackageInstallValuesLayer
+ */
+export enum PackageInstallValuesLayer {
+  /**
+   * Used for the values entries not declared through the plugin, which are
+   * merged before any other layer.
+   *
+   * @generated from enum value: PACKAGE_INSTALL_VALUES_LAYER_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * @generated from enum value: PACKAGE_INSTALL_VALUES_LAYER_BASE = 1;
+   */
+  BASE = 1,
+
+  /**
+   * @generated from enum value: PACKAGE_INSTALL_VALUES_LAYER_ENVIRONMENT = 2;
+   */
+  ENVIRONMENT = 2,
+
+  /**
+   * @generated from enum value: PACKAGE_INSTALL_VALUES_LAYER_USER_OVERRIDES = 3;
+   */
+  USER_OVERRIDES = 3,
+}
+// Retrieve enum metadata with: proto3.getEnumType(PackageInstallValuesLayer)
+proto3.util.setEnumType(
+  PackageInstallValuesLayer,
+  "kubeappsapis.plugins.kapp_controller.packages.v1alpha1.PackageInstallValuesLayer",
+  [
+    { no: 0, name: "PACKAGE_INSTALL_VALUES_LAYER_UNSPECIFIED" },
+    { no: 1, name: "PACKAGE_INSTALL_VALUES_LAYER_BASE" },
+    { no: 2, name: "PACKAGE_INSTALL_VALUES_LAYER_ENVIRONMENT" },
+    { no: 3, name: "PACKAGE_INSTALL_VALUES_LAYER_USER_OVERRIDES" },
+  ],
+);
+
+/**
+ * ResourceChangeOperation
+ *
+ * The operations that an update applies to a Kubernetes resource.
+ *
+ * @generated from enum kubeappsapis.plugins.kapp_controller.packages.v1alpha1.ResourceChangeOperation
+ */
+export enum ResourceChangeOperation {
+  /**
+   * @generated from enum value: RESOURCE_CHANGE_OPERATION_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * @generated from enum value: RESOURCE_CHANGE_OPERATION_CREATE = 1;
+   */
+  CREATE = 1,
+
+  /**
+   * @generated from enum value: RESOURCE_CHANGE_OPERATION_UPDATE = 2;
+   */
+  UPDATE = 2,
+
+  /**
+   * @generated from enum value: RESOURCE_CHANGE_OPERATION_DELETE = 3;
+   */
+  DELETE = 3,
+}
+// Retrieve enum metadata with: proto3.getEnumType(ResourceChangeOperation)
+proto3.util.setEnumType(
+  ResourceChangeOperation,
+  "kubeappsapis.plugins.kapp_controller.packages.v1alpha1.ResourceChangeOperation",
+  [
+    { no: 0, name: "RESOURCE_CHANGE_OPERATION_UNSPECIFIED" },
+    { no: 1, name: "RESOURCE_CHANGE_OPERATION_CREATE" },
+    { no: 2, name: "RESOURCE_CHANGE_OPERATION_UPDATE" },
+    { no: 3, name: "RESOURCE_CHANGE_OPERATION_DELETE" },
+  ],
+);
+
+/**
+ * InstalledPackageManifestKind
+ *
+ * The kinds of resources of an installed package whose manifest can be fetched.
+ *
+ * @generated from enum kubeappsapis.plugins.kapp_controller.packages.v1alpha1.InstalledPackageManifestKind
+ */
+export enum InstalledPackageManifestKind {
+  /**
+   * @generated from enum value: INSTALLED_PACKAGE_MANIFEST_KIND_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * The PackageInstall of the installed package, the default.
+   *
+   * @generated from enum value: INSTALLED_PACKAGE_MANIFEST_KIND_PACKAGE_INSTALL = 1;
+   */
+  PACKAGE_INSTALL = 1,
+
+  /**
+   * The App created by kapp-controller for the PackageInstall, which is
+   * read-only as kapp-controller overwrites it from the PackageInstall.
+   *
+   * @generated from enum value: INSTALLED_PACKAGE_MANIFEST_KIND_APP = 2;
+   */
+  APP = 2,
+}
+// Retrieve enum metadata with: proto3.getEnumType(InstalledPackageManifestKind)
+proto3.util.setEnumType(
+  InstalledPackageManifestKind,
+  "kubeappsapis.plugins.kapp_controller.packages.v1alpha1.InstalledPackageManifestKind",
+  [
+    { no: 0, name: "INSTALLED_PACKAGE_MANIFEST_KIND_UNSPECIFIED" },
+    { no: 1, name: "INSTALLED_PACKAGE_MANIFEST_KIND_PACKAGE_INSTALL" },
+    { no: 2, name: "INSTALLED_PACKAGE_MANIFEST_KIND_APP" },
+  ],
+);
+
 
 /**
  * KappControllerPackageRepositoryCustomDetail
@@ -31,6 +162,69 @@ export class KappControllerPackageRepositoryCustomDetail extends Message<KappCon
    */
   fetch?: PackageRepositoryFetch;
 
+  /**
+   * Allowed packages
+   *
+   * Glob patterns (as in "*.bitnami.com") matched against the refName of the
+   * packages of the repository. When not empty, only the packages matching
+   * one of the patterns are made available.
+   *
+   * @generated from field: repeated string allowed_packages = 2;
+   */
+  allowedPackages: string[] = [];
+
+  /**
+   * Denied packages
+   *
+   * Glob patterns matched against the refName of the packages of the
+   * repository. The packages matching one of the patterns are not made
+   * available, even if they are allowed.
+   *
+   * @generated from field: repeated string denied_packages = 3;
+   */
+  deniedPackages: string[] = [];
+
+  /**
+   * Secret exported to namespaces
+   *
+   * The namespaces to which the Secret with the credentials of a global
+   * repository, when managed by the plugin, is exported by
+   * secretgen-controller ("*" for all of them). The packages installed in
+   * those namespaces use the credentials through a placeholder Secret
+   * annotated with "secretgen.carvel.dev/image-pull-secret", created with the
+   * create_image_pull_secret option of the installed package. Only returned
+   * in the repository detail.
+   *
+   * @generated from field: repeated string secret_exported_to_namespaces = 4;
+   */
+  secretExportedToNamespaces: string[] = [];
+
+  /**
+   * Probe
+   *
+   * The result of fetching the repository from Kubeapps with its
+   * credentials, telling whether a repository not synced yet by
+   * kapp-controller is reachable. Only returned in the repository detail
+   * when requested with include_probe.
+   *
+   * @generated from field: kubeappsapis.plugins.kapp_controller.packages.v1alpha1.PackageRepositoryProbe probe = 5;
+   */
+  probe?: PackageRepositoryProbe;
+
+  /**
+   * Trusted host key fingerprints
+   *
+   * The SHA256 fingerprints, such as "SHA256:uNiVztksCsDhcc0u9e8BujQXVUpKZIDTMczCvj3tD2s",
+   * of the SSH host keys of the git server, as returned by ScanPackageRepositoryHostKeys and
+   * confirmed by the user. Only accepted with SSH credentials managed by the plugin, whose known
+   * hosts are then replaced with the host keys matching the fingerprints, scanned again when the
+   * repository is added or updated. This is how the known hosts are rotated when the host key of
+   * the git server changes. Never returned in the repository detail.
+   *
+   * @generated from field: repeated string trusted_host_key_fingerprints = 6;
+   */
+  trustedHostKeyFingerprints: string[] = [];
+
   constructor(data?: PartialMessage<KappControllerPackageRepositoryCustomDetail>) {
     super();
     proto3.util.initPartial(data, this);
@@ -41,6 +235,35 @@ export class KappControllerPackageRepositoryCustomDetail extends Message<KappCon
     "kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerPackageRepositoryCustomDetail";
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
     { no: 1, name: "fetch", kind: "message", T: PackageRepositoryFetch },
+    {
+      no: 2,
+      name: "allowed_packages",
+      kind: "scalar",
+      T: 9 /* ScalarType.STRING */,
+      repeated: true,
+    },
+    {
+      no: 3,
+      name: "denied_packages",
+      kind: "scalar",
+      T: 9 /* ScalarType.STRING */,
+      repeated: true,
+    },
+    {
+      no: 4,
+      name: "secret_exported_to_namespaces",
+      kind: "scalar",
+      T: 9 /* ScalarType.STRING */,
+      repeated: true,
+    },
+    { no: 5, name: "probe", kind: "message", T: PackageRepositoryProbe },
+    {
+      no: 6,
+      name: "trusted_host_key_fingerprints",
+      kind: "scalar",
+      T: 9 /* ScalarType.STRING */,
+      repeated: true,
+    },
   ]);
 
   static fromBinary(
@@ -78,6 +301,97 @@ export class KappControllerPackageRepositoryCustomDetail extends Message<KappCon
   }
 }
 
+/**
+ * PackageRepositoryProbe
+ *
+ * The result of fetching a package repository from Kubeapps, without
+ * downloading its packages.
+ *
+ * @generated from message kubeappsapis.plugins.kapp_controller.packages.v1alpha1.PackageRepositoryProbe
+ */
+export class PackageRepositoryProbe extends Message<PackageRepositoryProbe> {
+  /**
+   * Whether the repository could be fetched with its credentials
+   *
+   * @generated from field: bool success = 1;
+   */
+  success = false;
+
+  /**
+   * A human-readable summary of the connection, or why it failed
+   *
+   * @generated from field: string message = 2;
+   */
+  message = "";
+
+  /**
+   * The code of the failure, such as "unauthenticated" or "not_found", when
+   * the repository could not be fetched
+   *
+   * @generated from field: string error_code = 3;
+   */
+  errorCode = "";
+
+  /**
+   * Metadata discovered when fetching the repository: the "digest" of the
+   * image and the number of "tags" of its registry repository, the
+   * "revision" of the git HEAD, or the "etag" and "lastModified" of the
+   * http archive
+   *
+   * @generated from field: map<string, string> metadata = 4;
+   */
+  metadata: { [key: string]: string } = {};
+
+  constructor(data?: PartialMessage<PackageRepositoryProbe>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName =
+    "kubeappsapis.plugins.kapp_controller.packages.v1alpha1.PackageRepositoryProbe";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "success", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 2, name: "message", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "error_code", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    {
+      no: 4,
+      name: "metadata",
+      kind: "map",
+      K: 9 /* ScalarType.STRING */,
+      V: { kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): PackageRepositoryProbe {
+    return new PackageRepositoryProbe().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): PackageRepositoryProbe {
+    return new PackageRepositoryProbe().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): PackageRepositoryProbe {
+    return new PackageRepositoryProbe().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: PackageRepositoryProbe | PlainMessage<PackageRepositoryProbe> | undefined,
+    b: PackageRepositoryProbe | PlainMessage<PackageRepositoryProbe> | undefined,
+  ): boolean {
+    return proto3.util.equals(PackageRepositoryProbe, a, b);
+  }
+}
+
 /**
  * @generated from message kubeappsapis.plugins.kapp_controller.packages.v1alpha1.PackageRepositoryFetch
  */
@@ -711,3 +1025,1394 @@ export class VersionSelectionSemverPrereleases extends Message<VersionSelectionS
     return proto3.util.equals(VersionSelectionSemverPrereleases, a, b);
   }
 }
+
+/**
+ * KappControllerInstalledPackageCustomDetail
+ *
+ * custom fields for the installed packages managed by the 'kapp_controller' plugin,
+ * used in both the installed package requests and details
+ *
+ * @generated from message kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerInstalledPackageCustomDetail
+ */
+export class KappControllerInstalledPackageCustomDetail extends Message<KappControllerInstalledPackageCustomDetail> {
+  /**
+   * Whether a dedicated ServiceAccount, together with a Role and RoleBinding,
+   * should be created in the target namespace to reconcile the PackageInstall.
+   * Requires the plugin to be configured with `allowServiceAccountCreation`.
+   * When set, the reconciliation_options.service_account_name is not required.
+   *
+   * @generated from field: bool create_service_account = 1;
+   */
+  createServiceAccount = false;
+
+  /**
+   * Whether the PackageInstall is allowed to be downgraded to previous versions.
+   * When provided in a create or update request, it overrides the
+   * `defaultAllowDowngrades` plugin configuration. When returned in an
+   * InstalledPackageDetail, it reflects the effective state of the PackageInstall.
+   * Updating to a version lower than the last attempted one without allowing
+   * downgrades fails with FailedPrecondition and an ErrorInfo detail whose
+   * reason is DOWNGRADE_NOT_ALLOWED.
+   *
+   * @generated from field: optional bool allow_downgrades = 2;
+   */
+  allowDowngrades?: boolean;
+
+  /**
+   * Whether an image pull Secret should be created in the target namespace.
+   * When the plugin is configured with an `imagePullSecretImport`, a
+   * SecretImport of the registry credentials of the cluster administrator is
+   * created, secretgen-controller copying them once exported to the namespace
+   * (via a SecretExport). Otherwise, a placeholder Secret is created, which
+   * secretgen-controller fills in with every registry credentials exported to
+   * the namespace.
+   * Requires the plugin to be configured with `allowImagePullSecretCreation`.
+   * Only used in create requests, an InstalledPackageDetail returning the
+   * image_pull_secret_name instead.
+   *
+   * @generated from field: bool create_image_pull_secret = 3;
+   */
+  createImagePullSecret = false;
+
+  /**
+   * Additional values entries of the PackageInstall, which kapp-controller
+   * merges in order: the entries are sorted by layer (base values, then
+   * environment overlays, then user overrides), keeping the given order within
+   * a layer. The values of the request are always merged last, as user overrides.
+   * In update requests providing a custom detail, the entries replace the ones
+   * previously declared, while entries referencing Secrets not managed by the
+   * plugin are kept untouched.
+   * In an InstalledPackageDetail, every values entry of the PackageInstall
+   * is returned, in the order in which they are merged. When sent back in a
+   * request, such as when cloning the installed package, the entries managed by
+   * the plugin are re-created from their values, except the one holding the
+   * values of the request.
+   *
+   * @generated from field: repeated kubeappsapis.plugins.kapp_controller.packages.v1alpha1.PackageInstallValues values = 4;
+   */
+  values: PackageInstallValues[] = [];
+
+  /**
+   * Whether an existing PackageInstall with the requested name (such as one
+   * created by kctrl) should be adopted rather than failing the creation.
+   * The existing PackageInstall must reference the requested package, in which
+   * case it is updated with the request and marked as managed by the plugin.
+   * Only used in create requests.
+   *
+   * @generated from field: bool adopt_existing = 5;
+   */
+  adoptExisting = false;
+
+  /**
+   * The namespace into which kapp deploys the resources of the package which do
+   * not specify one, set as the PackageInstall spec.defaultNamespace and
+   * defaulting to the namespace of the PackageInstall.
+   * A namespace other than the target one requires the plugin to be configured
+   * with `allowDefaultNamespaceOverride`.
+   * Other kapp deploy options (such as `intoNs` or `rawOptions`) can only be set
+   * in the App template of the Package, as kapp-controller does not allow
+   * overriding them per PackageInstall.
+   * In update requests providing a custom detail, the namespace replaces the
+   * previous one, while it is kept untouched otherwise.
+   * ref https://carvel.dev/kapp-controller/docs/latest/packaging/#packageinstall
+   *
+   * @generated from field: string default_namespace = 6;
+   */
+  defaultNamespace = "";
+
+  /**
+   * Whether the deletion should keep the resources deployed by the package
+   * running, only deleting the PackageInstall and its App. The PackageInstall
+   * spec.noopDelete is set before deleting it.
+   * Only used in delete requests.
+   *
+   * @generated from field: bool noop_delete = 7;
+   */
+  noopDelete = false;
+
+  /**
+   * Whether the deletion should orphan every resource of the installed package,
+   * keeping the resources deployed by the package (as with noop_delete) and the
+   * ones created by the plugin alongside the PackageInstall: the values Secrets,
+   * the ServiceAccount, Role and RoleBinding and the image pull Secret, which the
+   * workloads may still need. These resources must then be deleted manually.
+   * Implies noop_delete. Only used in delete requests.
+   *
+   * @generated from field: bool orphan_resources = 8;
+   */
+  orphanResources = false;
+
+  /**
+   * The identities of the App created by kapp-controller for the PackageInstall
+   * and of the kapp app deploying its resources, to troubleshoot the installed
+   * package with kubectl or kapp.
+   * Only returned in an InstalledPackageDetail, ignored in requests.
+   *
+   * @generated from field: kubeappsapis.plugins.kapp_controller.packages.v1alpha1.InstalledPackageAppIdentity app_identity = 9;
+   */
+  appIdentity?: InstalledPackageAppIdentity;
+
+  /**
+   * Additional keys of the Secret holding the values of the request, with their
+   * binary content, such as certificates to be templated by the package.
+   * The "values.yaml" key holds the values of the request and cannot be set.
+   * In update requests providing a custom detail, the keys replace the ones
+   * previously set through the plugin, which are kept untouched otherwise. The
+   * keys added to the Secret by users are always kept untouched.
+   * Only used in requests, an InstalledPackageDetail returning the
+   * values_secret_keys instead.
+   *
+   * @generated from field: map<string, bytes> values_secret_data = 10;
+   */
+  valuesSecretData: { [key: string]: Uint8Array } = {};
+
+  /**
+   * Additional keys of the Secret holding the values of the request, with their
+   * string content, which take precedence over the values_secret_data with the
+   * same key, as with the stringData of a Secret.
+   * Only used in requests.
+   *
+   * @generated from field: map<string, string> values_secret_string_data = 11;
+   */
+  valuesSecretStringData: { [key: string]: string } = {};
+
+  /**
+   * The sorted keys of the Secret holding the values of the request, without
+   * their contents.
+   * Only returned in an InstalledPackageDetail, ignored in requests.
+   *
+   * @generated from field: repeated string values_secret_keys = 12;
+   */
+  valuesSecretKeys: string[] = [];
+
+  /**
+   * The name of the image pull Secret created in the namespace of the
+   * PackageInstall with the create_image_pull_secret option, to be referenced
+   * by the values of the package. Empty if none was created.
+   * Only returned in an InstalledPackageDetail, ignored in requests.
+   *
+   * @generated from field: string image_pull_secret_name = 13;
+   */
+  imagePullSecretName = "";
+
+  constructor(data?: PartialMessage<KappControllerInstalledPackageCustomDetail>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName =
+    "kubeappsapis.plugins.kapp_controller.packages.v1alpha1.KappControllerInstalledPackageCustomDetail";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "create_service_account", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 2, name: "allow_downgrades", kind: "scalar", T: 8 /* ScalarType.BOOL */, opt: true },
+    { no: 3, name: "create_image_pull_secret", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 4, name: "values", kind: "message", T: PackageInstallValues, repeated: true },
+    { no: 5, name: "adopt_existing", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 6, name: "default_namespace", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 7, name: "noop_delete", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 8, name: "orphan_resources", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 9, name: "app_identity", kind: "message", T: InstalledPackageAppIdentity },
+    {
+      no: 10,
+      name: "values_secret_data",
+      kind: "map",
+      K: 9 /* ScalarType.STRING */,
+      V: { kind: "scalar", T: 12 /* ScalarType.BYTES */ },
+    },
+    {
+      no: 11,
+      name: "values_secret_string_data",
+      kind: "map",
+      K: 9 /* ScalarType.STRING */,
+      V: { kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    },
+    {
+      no: 12,
+      name: "values_secret_keys",
+      kind: "scalar",
+      T: 9 /* ScalarType.STRING */,
+      repeated: true,
+    },
+    { no: 13, name: "image_pull_secret_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): KappControllerInstalledPackageCustomDetail {
+    return new KappControllerInstalledPackageCustomDetail().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): KappControllerInstalledPackageCustomDetail {
+    return new KappControllerInstalledPackageCustomDetail().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): KappControllerInstalledPackageCustomDetail {
+    return new KappControllerInstalledPackageCustomDetail().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | KappControllerInstalledPackageCustomDetail
+      | PlainMessage<KappControllerInstalledPackageCustomDetail>
+      | undefined,
+    b:
+      | KappControllerInstalledPackageCustomDetail
+      | PlainMessage<KappControllerInstalledPackageCustomDetail>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(KappControllerInstalledPackageCustomDetail, a, b);
+  }
+}
+
+/**
+ * InstalledPackageAppIdentity
+ *
+ * The identities of the App generated for a PackageInstall and of its kapp app.
+ *
+ * @generated from message kubeappsapis.plugins.kapp_controller.packages.v1alpha1.InstalledPackageAppIdentity
+ */
+export class InstalledPackageAppIdentity extends Message<InstalledPackageAppIdentity> {
+  /**
+   * The name of the App CR generated by kapp-controller for the PackageInstall,
+   * in the namespace of the PackageInstall.
+   *
+   * @generated from field: string app_name = 1;
+   */
+  appName = "";
+
+  /**
+   * The key of the label set by kapp on the deployed resources, such as
+   * "kapp.k14s.io/app". Empty until the App has been deployed.
+   *
+   * @generated from field: string label_key = 2;
+   */
+  labelKey = "";
+
+  /**
+   * The value of the label set by kapp on the deployed resources.
+   * Empty until the App has been deployed.
+   *
+   * @generated from field: string label_value = 3;
+   */
+  labelValue = "";
+
+  /**
+   * The label selector of the deployed resources, ready to be used with
+   * kubectl, such as "kapp.k14s.io/app=1678901234567890123".
+   * Empty until the App has been deployed.
+   *
+   * @generated from field: string label_selector = 4;
+   */
+  labelSelector = "";
+
+  constructor(data?: PartialMessage<InstalledPackageAppIdentity>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName =
+    "kubeappsapis.plugins.kapp_controller.packages.v1alpha1.InstalledPackageAppIdentity";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "app_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "label_key", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "label_value", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "label_selector", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): InstalledPackageAppIdentity {
+    return new InstalledPackageAppIdentity().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): InstalledPackageAppIdentity {
+    return new InstalledPackageAppIdentity().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): InstalledPackageAppIdentity {
+    return new InstalledPackageAppIdentity().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: InstalledPackageAppIdentity | PlainMessage<InstalledPackageAppIdentity> | undefined,
+    b: InstalledPackageAppIdentity | PlainMessage<InstalledPackageAppIdentity> | undefined,
+  ): boolean {
+    return proto3.util.equals(InstalledPackageAppIdentity, a, b);
+  }
+}
+
+/**
+ * PackageInstallValues
+ *
+ * A values entry of a PackageInstall, that is, a reference to the Secret
+ * holding the values.
+ *
+ * @generated from message kubeappsapis.plugins.kapp_controller.packages.v1alpha1.PackageInstallValues
+ */
+export class PackageInstallValues extends Message<PackageInstallValues> {
+  /**
+   * The layer in which the values are merged.
+   *
+   * @generated from field: kubeappsapis.plugins.kapp_controller.packages.v1alpha1.PackageInstallValuesLayer layer = 1;
+   */
+  layer = PackageInstallValuesLayer.UNSPECIFIED;
+
+  /**
+   * The values, in YAML or JSON format, stored in a Secret managed by the plugin.
+   * Either the values or a secret_name must be provided in requests. The values
+   * are ignored when a secret_name is provided, as in the entries of an
+   * InstalledPackageDetail, which also return the values of the Secret.
+   *
+   * @generated from field: string values = 2;
+   */
+  values = "";
+
+  /**
+   * The name of an existing Secret, in the namespace of the PackageInstall,
+   * holding the values.
+   *
+   * @generated from field: string secret_name = 3;
+   */
+  secretName = "";
+
+  /**
+   * The key of the Secret holding the values. Every key is used if empty.
+   *
+   * @generated from field: string secret_key = 4;
+   */
+  secretKey = "";
+
+  /**
+   * Whether the Secret is managed by the plugin. Only set in InstalledPackageDetail.
+   *
+   * @generated from field: bool managed = 5;
+   */
+  managed = false;
+
+  constructor(data?: PartialMessage<PackageInstallValues>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName =
+    "kubeappsapis.plugins.kapp_controller.packages.v1alpha1.PackageInstallValues";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "layer", kind: "enum", T: proto3.getEnumType(PackageInstallValuesLayer) },
+    { no: 2, name: "values", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "secret_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "secret_key", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "managed", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): PackageInstallValues {
+    return new PackageInstallValues().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): PackageInstallValues {
+    return new PackageInstallValues().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): PackageInstallValues {
+    return new PackageInstallValues().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: PackageInstallValues | PlainMessage<PackageInstallValues> | undefined,
+    b: PackageInstallValues | PlainMessage<PackageInstallValues> | undefined,
+  ): boolean {
+    return proto3.util.equals(PackageInstallValues, a, b);
+  }
+}
+
+/**
+ * GetInstalledPackageChangePreviewRequest
+ *
+ * Request for GetInstalledPackageChangePreview, describing the proposed update
+ * of the installed package with the same fields as an UpdateInstalledPackageRequest.
+ *
+ * @generated from message kubeappsapis.plugins.kapp_controller.packages.v1alpha1.GetInstalledPackageChangePreviewRequest
+ */
+export class GetInstalledPackageChangePreviewRequest extends Message<GetInstalledPackageChangePreviewRequest> {
+  /**
+   * Installed package reference
+   *
+   * A reference uniquely identifying the installed package being updated.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1alpha1.InstalledPackageReference installed_package_ref = 1;
+   */
+  installedPackageRef?: InstalledPackageReference;
+
+  /**
+   * Package version reference
+   *
+   * The proposed version of the package.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1alpha1.VersionReference pkg_version_reference = 2;
+   */
+  pkgVersionReference?: VersionReference;
+
+  /**
+   * Values
+   *
+   * The proposed values, in YAML or JSON format.
+   *
+   * @generated from field: string values = 3;
+   */
+  values = "";
+
+  /**
+   * Reconciliation options
+   *
+   * The proposed reconciliation options.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1alpha1.ReconciliationOptions reconciliation_options = 4;
+   */
+  reconciliationOptions?: ReconciliationOptions;
+
+  /**
+   * Custom detail
+   *
+   * A KappControllerInstalledPackageCustomDetail with the proposed plugin-specific details.
+   *
+   * @generated from field: google.protobuf.Any custom_detail = 5;
+   */
+  customDetail?: Any;
+
+  constructor(data?: PartialMessage<GetInstalledPackageChangePreviewRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName =
+    "kubeappsapis.plugins.kapp_controller.packages.v1alpha1.GetInstalledPackageChangePreviewRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "installed_package_ref", kind: "message", T: InstalledPackageReference },
+    { no: 2, name: "pkg_version_reference", kind: "message", T: VersionReference },
+    { no: 3, name: "values", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "reconciliation_options", kind: "message", T: ReconciliationOptions },
+    { no: 5, name: "custom_detail", kind: "message", T: Any },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): GetInstalledPackageChangePreviewRequest {
+    return new GetInstalledPackageChangePreviewRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): GetInstalledPackageChangePreviewRequest {
+    return new GetInstalledPackageChangePreviewRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetInstalledPackageChangePreviewRequest {
+    return new GetInstalledPackageChangePreviewRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | GetInstalledPackageChangePreviewRequest
+      | PlainMessage<GetInstalledPackageChangePreviewRequest>
+      | undefined,
+    b:
+      | GetInstalledPackageChangePreviewRequest
+      | PlainMessage<GetInstalledPackageChangePreviewRequest>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(GetInstalledPackageChangePreviewRequest, a, b);
+  }
+}
+
+/**
+ * GetInstalledPackageChangePreviewResponse
+ *
+ * Response for GetInstalledPackageChangePreview
+ *
+ * @generated from message kubeappsapis.plugins.kapp_controller.packages.v1alpha1.GetInstalledPackageChangePreviewResponse
+ */
+export class GetInstalledPackageChangePreviewResponse extends Message<GetInstalledPackageChangePreviewResponse> {
+  /**
+   * The changes of the Kubernetes resources that the update would apply, as
+   * computed by kapp. The resources left unchanged are not included.
+   *
+   * @generated from field: repeated kubeappsapis.plugins.kapp_controller.packages.v1alpha1.ResourceChange changes = 1;
+   */
+  changes: ResourceChange[] = [];
+
+  constructor(data?: PartialMessage<GetInstalledPackageChangePreviewResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName =
+    "kubeappsapis.plugins.kapp_controller.packages.v1alpha1.GetInstalledPackageChangePreviewResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "changes", kind: "message", T: ResourceChange, repeated: true },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): GetInstalledPackageChangePreviewResponse {
+    return new GetInstalledPackageChangePreviewResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): GetInstalledPackageChangePreviewResponse {
+    return new GetInstalledPackageChangePreviewResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetInstalledPackageChangePreviewResponse {
+    return new GetInstalledPackageChangePreviewResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | GetInstalledPackageChangePreviewResponse
+      | PlainMessage<GetInstalledPackageChangePreviewResponse>
+      | undefined,
+    b:
+      | GetInstalledPackageChangePreviewResponse
+      | PlainMessage<GetInstalledPackageChangePreviewResponse>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(GetInstalledPackageChangePreviewResponse, a, b);
+  }
+}
+
+/**
+ * ResourceChange
+ *
+ * The change of a Kubernetes resource that an update would apply.
+ *
+ * @generated from message kubeappsapis.plugins.kapp_controller.packages.v1alpha1.ResourceChange
+ */
+export class ResourceChange extends Message<ResourceChange> {
+  /**
+   * The reference of the changed resource.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1alpha1.ResourceRef resource_ref = 1;
+   */
+  resourceRef?: ResourceRef;
+
+  /**
+   * The operation applied to the resource.
+   *
+   * @generated from field: kubeappsapis.plugins.kapp_controller.packages.v1alpha1.ResourceChangeOperation operation = 2;
+   */
+  operation = ResourceChangeOperation.UNSPECIFIED;
+
+  /**
+   * The diff of the resource manifest, in the text format of `kapp deploy --diff-changes`.
+   * The data of the Secrets is masked.
+   *
+   * @generated from field: string diff = 3;
+   */
+  diff = "";
+
+  constructor(data?: PartialMessage<ResourceChange>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName =
+    "kubeappsapis.plugins.kapp_controller.packages.v1alpha1.ResourceChange";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "resource_ref", kind: "message", T: ResourceRef },
+    { no: 2, name: "operation", kind: "enum", T: proto3.getEnumType(ResourceChangeOperation) },
+    { no: 3, name: "diff", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ResourceChange {
+    return new ResourceChange().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ResourceChange {
+    return new ResourceChange().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ResourceChange {
+    return new ResourceChange().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: ResourceChange | PlainMessage<ResourceChange> | undefined,
+    b: ResourceChange | PlainMessage<ResourceChange> | undefined,
+  ): boolean {
+    return proto3.util.equals(ResourceChange, a, b);
+  }
+}
+
+/**
+ * GetInstalledPackageReconcileHistoryRequest
+ *
+ * Request for GetInstalledPackageReconcileHistory
+ *
+ * @generated from message kubeappsapis.plugins.kapp_controller.packages.v1alpha1.GetInstalledPackageReconcileHistoryRequest
+ */
+export class GetInstalledPackageReconcileHistoryRequest extends Message<GetInstalledPackageReconcileHistoryRequest> {
+  /**
+   * Installed package reference
+   *
+   * A reference uniquely identifying the installed package.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1alpha1.InstalledPackageReference installed_package_ref = 1;
+   */
+  installedPackageRef?: InstalledPackageReference;
+
+  constructor(data?: PartialMessage<GetInstalledPackageReconcileHistoryRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName =
+    "kubeappsapis.plugins.kapp_controller.packages.v1alpha1.GetInstalledPackageReconcileHistoryRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "installed_package_ref", kind: "message", T: InstalledPackageReference },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): GetInstalledPackageReconcileHistoryRequest {
+    return new GetInstalledPackageReconcileHistoryRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): GetInstalledPackageReconcileHistoryRequest {
+    return new GetInstalledPackageReconcileHistoryRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetInstalledPackageReconcileHistoryRequest {
+    return new GetInstalledPackageReconcileHistoryRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | GetInstalledPackageReconcileHistoryRequest
+      | PlainMessage<GetInstalledPackageReconcileHistoryRequest>
+      | undefined,
+    b:
+      | GetInstalledPackageReconcileHistoryRequest
+      | PlainMessage<GetInstalledPackageReconcileHistoryRequest>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(GetInstalledPackageReconcileHistoryRequest, a, b);
+  }
+}
+
+/**
+ * GetInstalledPackageReconcileHistoryResponse
+ *
+ * Response for GetInstalledPackageReconcileHistory
+ *
+ * @generated from message kubeappsapis.plugins.kapp_controller.packages.v1alpha1.GetInstalledPackageReconcileHistoryResponse
+ */
+export class GetInstalledPackageReconcileHistoryResponse extends Message<GetInstalledPackageReconcileHistoryResponse> {
+  /**
+   * The recorded reconcile outcomes, most recent first. Their number is
+   * bounded by the `reconcileHistoryLimit` plugin configuration.
+   *
+   * @generated from field: repeated kubeappsapis.plugins.kapp_controller.packages.v1alpha1.ReconcileOutcome outcomes = 1;
+   */
+  outcomes: ReconcileOutcome[] = [];
+
+  constructor(data?: PartialMessage<GetInstalledPackageReconcileHistoryResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName =
+    "kubeappsapis.plugins.kapp_controller.packages.v1alpha1.GetInstalledPackageReconcileHistoryResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "outcomes", kind: "message", T: ReconcileOutcome, repeated: true },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): GetInstalledPackageReconcileHistoryResponse {
+    return new GetInstalledPackageReconcileHistoryResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): GetInstalledPackageReconcileHistoryResponse {
+    return new GetInstalledPackageReconcileHistoryResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetInstalledPackageReconcileHistoryResponse {
+    return new GetInstalledPackageReconcileHistoryResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | GetInstalledPackageReconcileHistoryResponse
+      | PlainMessage<GetInstalledPackageReconcileHistoryResponse>
+      | undefined,
+    b:
+      | GetInstalledPackageReconcileHistoryResponse
+      | PlainMessage<GetInstalledPackageReconcileHistoryResponse>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(GetInstalledPackageReconcileHistoryResponse, a, b);
+  }
+}
+
+/**
+ * ReconcileOutcome
+ *
+ * The outcome of a reconciliation of the App of a PackageInstall, from the
+ * fetch of the package to its deployment.
+ *
+ * @generated from message kubeappsapis.plugins.kapp_controller.packages.v1alpha1.ReconcileOutcome
+ */
+export class ReconcileOutcome extends Message<ReconcileOutcome> {
+  /**
+   * The version of the package attempted by the reconciliation.
+   *
+   * @generated from field: string version = 1;
+   */
+  version = "";
+
+  /**
+   * Whether the reconciliation succeeded.
+   *
+   * @generated from field: bool succeeded = 2;
+   */
+  succeeded = false;
+
+  /**
+   * The time (RFC3339) at which the reconciliation started.
+   *
+   * @generated from field: string started_at = 3;
+   */
+  startedAt = "";
+
+  /**
+   * The time (RFC3339) at which the reconciliation finished.
+   *
+   * @generated from field: string finished_at = 4;
+   */
+  finishedAt = "";
+
+  /**
+   * The duration (in time+unit) of the reconciliation.
+   *
+   * @generated from field: string duration = 5;
+   */
+  duration = "";
+
+  /**
+   * The error of a failed reconciliation, truncated to a few hundred bytes.
+   *
+   * @generated from field: string error = 6;
+   */
+  error = "";
+
+  /**
+   * The number of consecutive reconciliations with the same result, as
+   * reported by kapp-controller, including the ones not observed by the
+   * plugin.
+   *
+   * @generated from field: int32 consecutive_count = 7;
+   */
+  consecutiveCount = 0;
+
+  constructor(data?: PartialMessage<ReconcileOutcome>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName =
+    "kubeappsapis.plugins.kapp_controller.packages.v1alpha1.ReconcileOutcome";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "succeeded", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 3, name: "started_at", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "finished_at", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "duration", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 6, name: "error", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 7, name: "consecutive_count", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ReconcileOutcome {
+    return new ReconcileOutcome().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ReconcileOutcome {
+    return new ReconcileOutcome().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ReconcileOutcome {
+    return new ReconcileOutcome().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: ReconcileOutcome | PlainMessage<ReconcileOutcome> | undefined,
+    b: ReconcileOutcome | PlainMessage<ReconcileOutcome> | undefined,
+  ): boolean {
+    return proto3.util.equals(ReconcileOutcome, a, b);
+  }
+}
+
+/**
+ * GetInstalledPackageManifestRequest
+ *
+ * Request for GetInstalledPackageManifest
+ *
+ * @generated from message kubeappsapis.plugins.kapp_controller.packages.v1alpha1.GetInstalledPackageManifestRequest
+ */
+export class GetInstalledPackageManifestRequest extends Message<GetInstalledPackageManifestRequest> {
+  /**
+   * Installed package reference
+   *
+   * A reference uniquely identifying the installed package.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1alpha1.InstalledPackageReference installed_package_ref = 1;
+   */
+  installedPackageRef?: InstalledPackageReference;
+
+  /**
+   * The kind of the resource whose manifest is returned, the PackageInstall if unspecified.
+   *
+   * @generated from field: kubeappsapis.plugins.kapp_controller.packages.v1alpha1.InstalledPackageManifestKind kind = 2;
+   */
+  kind = InstalledPackageManifestKind.UNSPECIFIED;
+
+  constructor(data?: PartialMessage<GetInstalledPackageManifestRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName =
+    "kubeappsapis.plugins.kapp_controller.packages.v1alpha1.GetInstalledPackageManifestRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "installed_package_ref", kind: "message", T: InstalledPackageReference },
+    { no: 2, name: "kind", kind: "enum", T: proto3.getEnumType(InstalledPackageManifestKind) },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): GetInstalledPackageManifestRequest {
+    return new GetInstalledPackageManifestRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): GetInstalledPackageManifestRequest {
+    return new GetInstalledPackageManifestRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetInstalledPackageManifestRequest {
+    return new GetInstalledPackageManifestRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | GetInstalledPackageManifestRequest
+      | PlainMessage<GetInstalledPackageManifestRequest>
+      | undefined,
+    b:
+      | GetInstalledPackageManifestRequest
+      | PlainMessage<GetInstalledPackageManifestRequest>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(GetInstalledPackageManifestRequest, a, b);
+  }
+}
+
+/**
+ * GetInstalledPackageManifestResponse
+ *
+ * Response for GetInstalledPackageManifest
+ *
+ * @generated from message kubeappsapis.plugins.kapp_controller.packages.v1alpha1.GetInstalledPackageManifestResponse
+ */
+export class GetInstalledPackageManifestResponse extends Message<GetInstalledPackageManifestResponse> {
+  /**
+   * The YAML manifest of the resource, without its managed fields. It includes
+   * the resource version, so that an update of a manifest which changed in the
+   * meantime is rejected.
+   *
+   * @generated from field: string manifest = 1;
+   */
+  manifest = "";
+
+  constructor(data?: PartialMessage<GetInstalledPackageManifestResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName =
+    "kubeappsapis.plugins.kapp_controller.packages.v1alpha1.GetInstalledPackageManifestResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "manifest", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): GetInstalledPackageManifestResponse {
+    return new GetInstalledPackageManifestResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): GetInstalledPackageManifestResponse {
+    return new GetInstalledPackageManifestResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetInstalledPackageManifestResponse {
+    return new GetInstalledPackageManifestResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | GetInstalledPackageManifestResponse
+      | PlainMessage<GetInstalledPackageManifestResponse>
+      | undefined,
+    b:
+      | GetInstalledPackageManifestResponse
+      | PlainMessage<GetInstalledPackageManifestResponse>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(GetInstalledPackageManifestResponse, a, b);
+  }
+}
+
+/**
+ * UpdateInstalledPackageManifestRequest
+ *
+ * Request for UpdateInstalledPackageManifest
+ *
+ * @generated from message kubeappsapis.plugins.kapp_controller.packages.v1alpha1.UpdateInstalledPackageManifestRequest
+ */
+export class UpdateInstalledPackageManifestRequest extends Message<UpdateInstalledPackageManifestRequest> {
+  /**
+   * Installed package reference
+   *
+   * A reference uniquely identifying the installed package being updated.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1alpha1.InstalledPackageReference installed_package_ref = 1;
+   */
+  installedPackageRef?: InstalledPackageReference;
+
+  /**
+   * The YAML manifest of the PackageInstall, as returned by GetInstalledPackageManifest
+   * and edited. Its status is ignored.
+   *
+   * @generated from field: string manifest = 2;
+   */
+  manifest = "";
+
+  /**
+   * Whether to only validate the manifest with a server-side dry-run, without
+   * updating the PackageInstall.
+   *
+   * @generated from field: bool dry_run = 3;
+   */
+  dryRun = false;
+
+  constructor(data?: PartialMessage<UpdateInstalledPackageManifestRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName =
+    "kubeappsapis.plugins.kapp_controller.packages.v1alpha1.UpdateInstalledPackageManifestRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "installed_package_ref", kind: "message", T: InstalledPackageReference },
+    { no: 2, name: "manifest", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "dry_run", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): UpdateInstalledPackageManifestRequest {
+    return new UpdateInstalledPackageManifestRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): UpdateInstalledPackageManifestRequest {
+    return new UpdateInstalledPackageManifestRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): UpdateInstalledPackageManifestRequest {
+    return new UpdateInstalledPackageManifestRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | UpdateInstalledPackageManifestRequest
+      | PlainMessage<UpdateInstalledPackageManifestRequest>
+      | undefined,
+    b:
+      | UpdateInstalledPackageManifestRequest
+      | PlainMessage<UpdateInstalledPackageManifestRequest>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(UpdateInstalledPackageManifestRequest, a, b);
+  }
+}
+
+/**
+ * UpdateInstalledPackageManifestResponse
+ *
+ * Response for UpdateInstalledPackageManifest
+ *
+ * @generated from message kubeappsapis.plugins.kapp_controller.packages.v1alpha1.UpdateInstalledPackageManifestResponse
+ */
+export class UpdateInstalledPackageManifestResponse extends Message<UpdateInstalledPackageManifestResponse> {
+  /**
+   * The YAML manifest of the PackageInstall as updated, or as it would be
+   * updated for a dry-run, without its managed fields.
+   *
+   * @generated from field: string manifest = 1;
+   */
+  manifest = "";
+
+  constructor(data?: PartialMessage<UpdateInstalledPackageManifestResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName =
+    "kubeappsapis.plugins.kapp_controller.packages.v1alpha1.UpdateInstalledPackageManifestResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "manifest", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): UpdateInstalledPackageManifestResponse {
+    return new UpdateInstalledPackageManifestResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): UpdateInstalledPackageManifestResponse {
+    return new UpdateInstalledPackageManifestResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): UpdateInstalledPackageManifestResponse {
+    return new UpdateInstalledPackageManifestResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | UpdateInstalledPackageManifestResponse
+      | PlainMessage<UpdateInstalledPackageManifestResponse>
+      | undefined,
+    b:
+      | UpdateInstalledPackageManifestResponse
+      | PlainMessage<UpdateInstalledPackageManifestResponse>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(UpdateInstalledPackageManifestResponse, a, b);
+  }
+}
+
+/**
+ * ScanPackageRepositoryHostKeysRequest
+ *
+ * Request for ScanPackageRepositoryHostKeys
+ *
+ * @generated from message kubeappsapis.plugins.kapp_controller.packages.v1alpha1.ScanPackageRepositoryHostKeysRequest
+ */
+export class ScanPackageRepositoryHostKeysRequest extends Message<ScanPackageRepositoryHostKeysRequest> {
+  /**
+   * The URL of the git repository over SSH, such as "ssh://git@github.com/org/repo.git"
+   * or "git@github.com:org/repo.git". Defaults to the URL of the package repository
+   * if a reference is given.
+   *
+   * @generated from field: string url = 1;
+   */
+  url = "";
+
+  /**
+   * Package repository reference
+   *
+   * An existing package repository, whose known hosts are compared with the scanned
+   * host keys to detect a change of the host key of its git server.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1alpha1.PackageRepositoryReference package_repo_ref = 2;
+   */
+  packageRepoRef?: PackageRepositoryReference;
+
+  constructor(data?: PartialMessage<ScanPackageRepositoryHostKeysRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName =
+    "kubeappsapis.plugins.kapp_controller.packages.v1alpha1.ScanPackageRepositoryHostKeysRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "url", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "package_repo_ref", kind: "message", T: PackageRepositoryReference },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): ScanPackageRepositoryHostKeysRequest {
+    return new ScanPackageRepositoryHostKeysRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): ScanPackageRepositoryHostKeysRequest {
+    return new ScanPackageRepositoryHostKeysRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): ScanPackageRepositoryHostKeysRequest {
+    return new ScanPackageRepositoryHostKeysRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | ScanPackageRepositoryHostKeysRequest
+      | PlainMessage<ScanPackageRepositoryHostKeysRequest>
+      | undefined,
+    b:
+      | ScanPackageRepositoryHostKeysRequest
+      | PlainMessage<ScanPackageRepositoryHostKeysRequest>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(ScanPackageRepositoryHostKeysRequest, a, b);
+  }
+}
+
+/**
+ * ScanPackageRepositoryHostKeysResponse
+ *
+ * Response for ScanPackageRepositoryHostKeys
+ *
+ * @generated from message kubeappsapis.plugins.kapp_controller.packages.v1alpha1.ScanPackageRepositoryHostKeysResponse
+ */
+export class ScanPackageRepositoryHostKeysResponse extends Message<ScanPackageRepositoryHostKeysResponse> {
+  /**
+   * The host keys offered by the git server, one per key type.
+   *
+   * @generated from field: repeated kubeappsapis.plugins.kapp_controller.packages.v1alpha1.PackageRepositoryHostKey host_keys = 1;
+   */
+  hostKeys: PackageRepositoryHostKey[] = [];
+
+  /**
+   * Whether the known hosts of the package repository have keys for the git
+   * server, none of which is offered anymore: the host key changed, and the
+   * repository cannot be synced until the new one is trusted.
+   *
+   * @generated from field: bool host_key_changed = 2;
+   */
+  hostKeyChanged = false;
+
+  /**
+   * The lines of a known_hosts file with the scanned host keys.
+   *
+   * @generated from field: string known_hosts = 3;
+   */
+  knownHosts = "";
+
+  constructor(data?: PartialMessage<ScanPackageRepositoryHostKeysResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName =
+    "kubeappsapis.plugins.kapp_controller.packages.v1alpha1.ScanPackageRepositoryHostKeysResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "host_keys", kind: "message", T: PackageRepositoryHostKey, repeated: true },
+    { no: 2, name: "host_key_changed", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 3, name: "known_hosts", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): ScanPackageRepositoryHostKeysResponse {
+    return new ScanPackageRepositoryHostKeysResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): ScanPackageRepositoryHostKeysResponse {
+    return new ScanPackageRepositoryHostKeysResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): ScanPackageRepositoryHostKeysResponse {
+    return new ScanPackageRepositoryHostKeysResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | ScanPackageRepositoryHostKeysResponse
+      | PlainMessage<ScanPackageRepositoryHostKeysResponse>
+      | undefined,
+    b:
+      | ScanPackageRepositoryHostKeysResponse
+      | PlainMessage<ScanPackageRepositoryHostKeysResponse>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(ScanPackageRepositoryHostKeysResponse, a, b);
+  }
+}
+
+/**
+ * PackageRepositoryHostKey
+ *
+ * An SSH host key of the git server of a package repository.
+ *
+ * @generated from message kubeappsapis.plugins.kapp_controller.packages.v1alpha1.PackageRepositoryHostKey
+ */
+export class PackageRepositoryHostKey extends Message<PackageRepositoryHostKey> {
+  /**
+   * The host, as written in a known_hosts file, such as "github.com" or "[git.example.com]:2222".
+   *
+   * @generated from field: string host = 1;
+   */
+  host = "";
+
+  /**
+   * The type of the key, such as "ssh-ed25519".
+   *
+   * @generated from field: string type = 2;
+   */
+  type = "";
+
+  /**
+   * The SHA256 fingerprint of the key, such as "SHA256:uNiVztksCsDhcc0u9e8BujQXVUpKZIDTMczCvj3tD2s".
+   *
+   * @generated from field: string fingerprint = 3;
+   */
+  fingerprint = "";
+
+  /**
+   * Whether the key is among the known hosts of the package repository.
+   *
+   * @generated from field: bool known = 4;
+   */
+  known = false;
+
+  constructor(data?: PartialMessage<PackageRepositoryHostKey>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName =
+    "kubeappsapis.plugins.kapp_controller.packages.v1alpha1.PackageRepositoryHostKey";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "host", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "type", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "fingerprint", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "known", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): PackageRepositoryHostKey {
+    return new PackageRepositoryHostKey().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): PackageRepositoryHostKey {
+    return new PackageRepositoryHostKey().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): PackageRepositoryHostKey {
+    return new PackageRepositoryHostKey().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: PackageRepositoryHostKey | PlainMessage<PackageRepositoryHostKey> | undefined,
+    b: PackageRepositoryHostKey | PlainMessage<PackageRepositoryHostKey> | undefined,
+  ): boolean {
+    return proto3.util.equals(PackageRepositoryHostKey, a, b);
+  }
+}
@@ -0,0 +1,207 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+// @generated by protoc-gen-connect-es v0.13.0 with parameter "target=ts,import_extension=none"
+// @generated from file kubeappsapis/plugins/oci_catalog/packages/v1alpha1/oci_catalog.proto (package kubeappsapis.plugins.oci_catalog.packages.v1alpha1, syntax proto3)
+/* eslint-disable */
+// @ts-nocheck
+
+import {
+  CheckInstalledPackageNameAvailabilityRequest,
+  CheckInstalledPackageNameAvailabilityResponse,
+  CreateInstalledPackageRequest,
+  CreateInstalledPackageResponse,
+  DeleteInstalledPackageRequest,
+  DeleteInstalledPackageResponse,
+  GetAvailablePackageChangelogRequest,
+  GetAvailablePackageChangelogResponse,
+  GetAvailablePackageDetailRequest,
+  GetAvailablePackageDetailResponse,
+  GetAvailablePackageFiltersRequest,
+  GetAvailablePackageFiltersResponse,
+  GetAvailablePackageSummariesRequest,
+  GetAvailablePackageSummariesResponse,
+  GetAvailablePackageTextAssetRequest,
+  GetAvailablePackageTextAssetResponse,
+  GetAvailablePackageVersionsRequest,
+  GetAvailablePackageVersionsResponse,
+  GetInstalledPackageDetailRequest,
+  GetInstalledPackageDetailResponse,
+  GetInstalledPackageOperationStatusRequest,
+  GetInstalledPackageOperationStatusResponse,
+  GetInstalledPackageResourceRefsRequest,
+  GetInstalledPackageResourceRefsResponse,
+  GetInstalledPackageSummariesRequest,
+  GetInstalledPackageSummariesResponse,
+  UpdateInstalledPackageRequest,
+  UpdateInstalledPackageResponse,
+} from "../../../../core/packages/v1alpha1/packages_pb";
+import { MethodKind } from "@bufbuild/protobuf";
+
+/**
+ * OciCatalogPackagesService lists the Carvel imgpkg bundles published in OCI
+ * registry namespaces as available packages, installing them as kapp-controller
+ * Apps.
+ *
+ * @generated from service kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService
+ */
+export const OciCatalogPackagesService = {
+  typeName: "kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService",
+  methods: {
+    /**
+     * GetAvailablePackageSummaries returns the available packages managed by the 'oci_catalog' plugin
+     *
+     * @generated from rpc kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService.GetAvailablePackageSummaries
+     */
+    getAvailablePackageSummaries: {
+      name: "GetAvailablePackageSummaries",
+      I: GetAvailablePackageSummariesRequest,
+      O: GetAvailablePackageSummariesResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * GetAvailablePackageDetail returns the package details managed by the 'oci_catalog' plugin
+     *
+     * @generated from rpc kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService.GetAvailablePackageDetail
+     */
+    getAvailablePackageDetail: {
+      name: "GetAvailablePackageDetail",
+      I: GetAvailablePackageDetailRequest,
+      O: GetAvailablePackageDetailResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * GetAvailablePackageVersions returns the package versions managed by the 'oci_catalog' plugin
+     *
+     * @generated from rpc kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService.GetAvailablePackageVersions
+     */
+    getAvailablePackageVersions: {
+      name: "GetAvailablePackageVersions",
+      I: GetAvailablePackageVersionsRequest,
+      O: GetAvailablePackageVersionsResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * GetAvailablePackageTextAsset returns a range of a text asset of a package managed by the 'oci_catalog' plugin
+     *
+     * @generated from rpc kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService.GetAvailablePackageTextAsset
+     */
+    getAvailablePackageTextAsset: {
+      name: "GetAvailablePackageTextAsset",
+      I: GetAvailablePackageTextAssetRequest,
+      O: GetAvailablePackageTextAssetResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * GetAvailablePackageChangelog returns the release notes between two versions of a package managed by the 'oci_catalog' plugin
+     *
+     * @generated from rpc kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService.GetAvailablePackageChangelog
+     */
+    getAvailablePackageChangelog: {
+      name: "GetAvailablePackageChangelog",
+      I: GetAvailablePackageChangelogRequest,
+      O: GetAvailablePackageChangelogResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * GetAvailablePackageFilters returns the filter values of the available packages managed by the 'oci_catalog' plugin
+     *
+     * @generated from rpc kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService.GetAvailablePackageFilters
+     */
+    getAvailablePackageFilters: {
+      name: "GetAvailablePackageFilters",
+      I: GetAvailablePackageFiltersRequest,
+      O: GetAvailablePackageFiltersResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * GetInstalledPackageSummaries returns the installed packages managed by the 'oci_catalog' plugin
+     *
+     * @generated from rpc kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService.GetInstalledPackageSummaries
+     */
+    getInstalledPackageSummaries: {
+      name: "GetInstalledPackageSummaries",
+      I: GetInstalledPackageSummariesRequest,
+      O: GetInstalledPackageSummariesResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * GetInstalledPackageDetail returns the requested installed package managed by the 'oci_catalog' plugin
+     *
+     * @generated from rpc kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService.GetInstalledPackageDetail
+     */
+    getInstalledPackageDetail: {
+      name: "GetInstalledPackageDetail",
+      I: GetInstalledPackageDetailRequest,
+      O: GetInstalledPackageDetailResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * CheckInstalledPackageNameAvailability checks whether a package managed by the 'oci_catalog' plugin can be installed with the given name
+     *
+     * @generated from rpc kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService.CheckInstalledPackageNameAvailability
+     */
+    checkInstalledPackageNameAvailability: {
+      name: "CheckInstalledPackageNameAvailability",
+      I: CheckInstalledPackageNameAvailabilityRequest,
+      O: CheckInstalledPackageNameAvailabilityResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * CreateInstalledPackage creates an installed package based on the request.
+     *
+     * @generated from rpc kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService.CreateInstalledPackage
+     */
+    createInstalledPackage: {
+      name: "CreateInstalledPackage",
+      I: CreateInstalledPackageRequest,
+      O: CreateInstalledPackageResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * UpdateInstalledPackage updates an installed package based on the request.
+     *
+     * @generated from rpc kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService.UpdateInstalledPackage
+     */
+    updateInstalledPackage: {
+      name: "UpdateInstalledPackage",
+      I: UpdateInstalledPackageRequest,
+      O: UpdateInstalledPackageResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * DeleteInstalledPackage deletes an installed package based on the request.
+     *
+     * @generated from rpc kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService.DeleteInstalledPackage
+     */
+    deleteInstalledPackage: {
+      name: "DeleteInstalledPackage",
+      I: DeleteInstalledPackageRequest,
+      O: DeleteInstalledPackageResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * GetInstalledPackageResourceRefs returns the references for the Kubernetes resources created by
+     * an installed package.
+     *
+     * @generated from rpc kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService.GetInstalledPackageResourceRefs
+     */
+    getInstalledPackageResourceRefs: {
+      name: "GetInstalledPackageResourceRefs",
+      I: GetInstalledPackageResourceRefsRequest,
+      O: GetInstalledPackageResourceRefsResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * GetInstalledPackageOperationStatus returns the progress of the operation in course on an installed package managed by the 'oci_catalog' plugin
+     *
+     * @generated from rpc kubeappsapis.plugins.oci_catalog.packages.v1alpha1.OciCatalogPackagesService.GetInstalledPackageOperationStatus
+     */
+    getInstalledPackageOperationStatus: {
+      name: "GetInstalledPackageOperationStatus",
+      I: GetInstalledPackageOperationStatusRequest,
+      O: GetInstalledPackageOperationStatusResponse,
+      kind: MethodKind.Unary,
+    },
+  },
+} as const;
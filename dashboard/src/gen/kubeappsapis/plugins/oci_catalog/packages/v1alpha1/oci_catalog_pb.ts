@@ -0,0 +1,10 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+// @generated by protoc-gen-es v1.3.1 with parameter "target=ts,import_extension=none"
+// @generated from file kubeappsapis/plugins/oci_catalog/packages/v1alpha1/oci_catalog.proto (package kubeappsapis.plugins.oci_catalog.packages.v1alpha1, syntax proto3)
+/* eslint-disable */
+// @ts-nocheck
+
+
+
@@ -0,0 +1,259 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+// @generated by protoc-gen-es v1.3.1 with parameter "target=ts,import_extension=none"
+// @generated from file kubeappsapis/plugins/operators/packages/v1alpha1/operators.proto (package kubeappsapis.plugins.operators.packages.v1alpha1, syntax proto3)
+/* eslint-disable */
+// @ts-nocheck
+
+import type {
+  BinaryReadOptions,
+  FieldList,
+  JsonReadOptions,
+  JsonValue,
+  PartialMessage,
+  PlainMessage,
+} from "@bufbuild/protobuf";
+import { Message, proto3 } from "@bufbuild/protobuf";
+
+/**
+ * OperatorsAvailablePackageCustomDetail
+ *
+ * custom fields for the available packages managed by the 'operators' plugin
+ *
+ * @generated from message kubeappsapis.plugins.operators.packages.v1alpha1.OperatorsAvailablePackageCustomDetail
+ */
+export class OperatorsAvailablePackageCustomDetail extends Message<OperatorsAvailablePackageCustomDetail> {
+  /**
+   * The name of the CatalogSource providing the operator
+   *
+   * @generated from field: string catalog_source = 1;
+   */
+  catalogSource = "";
+
+  /**
+   * The namespace of the CatalogSource providing the operator
+   *
+   * @generated from field: string catalog_source_namespace = 2;
+   */
+  catalogSourceNamespace = "";
+
+  /**
+   * The channel subscribed to when none is requested
+   *
+   * @generated from field: string default_channel = 3;
+   */
+  defaultChannel = "";
+
+  /**
+   * The update channels of the operator, each one installing the version of
+   * its head ClusterServiceVersion
+   *
+   * @generated from field: repeated kubeappsapis.plugins.operators.packages.v1alpha1.OperatorChannel channels = 4;
+   */
+  channels: OperatorChannel[] = [];
+
+  /**
+   * The capability level of the operator, such as "Basic Install" or
+   * "Full Lifecycle"
+   *
+   * @generated from field: string capabilities = 5;
+   */
+  capabilities = "";
+
+  /**
+   * The install modes supported by the operator, such as "OwnNamespace" or
+   * "AllNamespaces", which must match the OperatorGroup of the target namespace
+   *
+   * @generated from field: repeated string install_modes = 6;
+   */
+  installModes: string[] = [];
+
+  constructor(data?: PartialMessage<OperatorsAvailablePackageCustomDetail>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName =
+    "kubeappsapis.plugins.operators.packages.v1alpha1.OperatorsAvailablePackageCustomDetail";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "catalog_source", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "catalog_source_namespace", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "default_channel", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "channels", kind: "message", T: OperatorChannel, repeated: true },
+    { no: 5, name: "capabilities", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 6, name: "install_modes", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): OperatorsAvailablePackageCustomDetail {
+    return new OperatorsAvailablePackageCustomDetail().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): OperatorsAvailablePackageCustomDetail {
+    return new OperatorsAvailablePackageCustomDetail().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): OperatorsAvailablePackageCustomDetail {
+    return new OperatorsAvailablePackageCustomDetail().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | OperatorsAvailablePackageCustomDetail
+      | PlainMessage<OperatorsAvailablePackageCustomDetail>
+      | undefined,
+    b:
+      | OperatorsAvailablePackageCustomDetail
+      | PlainMessage<OperatorsAvailablePackageCustomDetail>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(OperatorsAvailablePackageCustomDetail, a, b);
+  }
+}
+
+/**
+ * OperatorChannel
+ *
+ * An update channel of an operator
+ *
+ * @generated from message kubeappsapis.plugins.operators.packages.v1alpha1.OperatorChannel
+ */
+export class OperatorChannel extends Message<OperatorChannel> {
+  /**
+   * The name of the channel
+   *
+   * @generated from field: string name = 1;
+   */
+  name = "";
+
+  /**
+   * The name of the ClusterServiceVersion at the head of the channel
+   *
+   * @generated from field: string current_csv = 2;
+   */
+  currentCsv = "";
+
+  /**
+   * The version of the ClusterServiceVersion at the head of the channel
+   *
+   * @generated from field: string version = 3;
+   */
+  version = "";
+
+  constructor(data?: PartialMessage<OperatorChannel>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.plugins.operators.packages.v1alpha1.OperatorChannel";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "current_csv", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): OperatorChannel {
+    return new OperatorChannel().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): OperatorChannel {
+    return new OperatorChannel().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): OperatorChannel {
+    return new OperatorChannel().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: OperatorChannel | PlainMessage<OperatorChannel> | undefined,
+    b: OperatorChannel | PlainMessage<OperatorChannel> | undefined,
+  ): boolean {
+    return proto3.util.equals(OperatorChannel, a, b);
+  }
+}
+
+/**
+ * OperatorsInstalledPackageCustomDetail
+ *
+ * custom fields for the installed packages managed by the 'operators' plugin,
+ * used in both the installed package requests and details
+ *
+ * @generated from message kubeappsapis.plugins.operators.packages.v1alpha1.OperatorsInstalledPackageCustomDetail
+ */
+export class OperatorsInstalledPackageCustomDetail extends Message<OperatorsInstalledPackageCustomDetail> {
+  /**
+   * The channel of the Subscription. When provided in a create or update
+   * request, it takes precedence over the channel matching the requested
+   * version, which must then be the version at the head of the channel.
+   *
+   * @generated from field: string channel = 1;
+   */
+  channel = "";
+
+  /**
+   * Whether the InstallPlans of the Subscription must be approved manually,
+   * rather than OLM upgrading the operator as soon as a newer version is
+   * published in the channel.
+   *
+   * @generated from field: bool manual_approval = 2;
+   */
+  manualApproval = false;
+
+  constructor(data?: PartialMessage<OperatorsInstalledPackageCustomDetail>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName =
+    "kubeappsapis.plugins.operators.packages.v1alpha1.OperatorsInstalledPackageCustomDetail";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "channel", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "manual_approval", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): OperatorsInstalledPackageCustomDetail {
+    return new OperatorsInstalledPackageCustomDetail().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): OperatorsInstalledPackageCustomDetail {
+    return new OperatorsInstalledPackageCustomDetail().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): OperatorsInstalledPackageCustomDetail {
+    return new OperatorsInstalledPackageCustomDetail().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | OperatorsInstalledPackageCustomDetail
+      | PlainMessage<OperatorsInstalledPackageCustomDetail>
+      | undefined,
+    b:
+      | OperatorsInstalledPackageCustomDetail
+      | PlainMessage<OperatorsInstalledPackageCustomDetail>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(OperatorsInstalledPackageCustomDetail, a, b);
+  }
+}
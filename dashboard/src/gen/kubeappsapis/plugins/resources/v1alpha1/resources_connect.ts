@@ -15,10 +15,20 @@ import {
   CreateNamespaceResponse,
   CreateSecretRequest,
   CreateSecretResponse,
+  ExportInstalledPackageManifestsRequest,
+  ExportInstalledPackageManifestsResponse,
+  GetInstalledPackageCustomFieldsRequest,
+  GetInstalledPackageCustomFieldsResponse,
+  GetInstalledPackageEventsRequest,
+  GetInstalledPackageEventsResponse,
   GetNamespaceNamesRequest,
   GetNamespaceNamesResponse,
   GetResourcesRequest,
   GetResourcesResponse,
+  GetRolloutStatusRequest,
+  GetRolloutStatusResponse,
+  GetSchedulingInfoRequest,
+  GetSchedulingInfoResponse,
   GetSecretNamesRequest,
   GetSecretNamesResponse,
   GetServiceAccountNamesRequest,
@@ -46,6 +56,51 @@ export const ResourcesService = {
       O: GetResourcesResponse,
       kind: MethodKind.ServerStreaming,
     },
+    /**
+     * @generated from rpc kubeappsapis.plugins.resources.v1alpha1.ResourcesService.GetRolloutStatus
+     */
+    getRolloutStatus: {
+      name: "GetRolloutStatus",
+      I: GetRolloutStatusRequest,
+      O: GetRolloutStatusResponse,
+      kind: MethodKind.ServerStreaming,
+    },
+    /**
+     * @generated from rpc kubeappsapis.plugins.resources.v1alpha1.ResourcesService.GetSchedulingInfo
+     */
+    getSchedulingInfo: {
+      name: "GetSchedulingInfo",
+      I: GetSchedulingInfoRequest,
+      O: GetSchedulingInfoResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc kubeappsapis.plugins.resources.v1alpha1.ResourcesService.GetInstalledPackageEvents
+     */
+    getInstalledPackageEvents: {
+      name: "GetInstalledPackageEvents",
+      I: GetInstalledPackageEventsRequest,
+      O: GetInstalledPackageEventsResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc kubeappsapis.plugins.resources.v1alpha1.ResourcesService.GetInstalledPackageCustomFields
+     */
+    getInstalledPackageCustomFields: {
+      name: "GetInstalledPackageCustomFields",
+      I: GetInstalledPackageCustomFieldsRequest,
+      O: GetInstalledPackageCustomFieldsResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc kubeappsapis.plugins.resources.v1alpha1.ResourcesService.ExportInstalledPackageManifests
+     */
+    exportInstalledPackageManifests: {
+      name: "ExportInstalledPackageManifests",
+      I: ExportInstalledPackageManifestsRequest,
+      O: ExportInstalledPackageManifestsResponse,
+      kind: MethodKind.Unary,
+    },
     /**
      * @generated from rpc kubeappsapis.plugins.resources.v1alpha1.ResourcesService.GetServiceAccountNames
      */
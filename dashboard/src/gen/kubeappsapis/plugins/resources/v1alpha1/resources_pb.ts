@@ -21,6 +21,77 @@ import {
   ResourceRef,
 } from "../../../core/packages/v1alpha1/packages_pb";
 
+/**
+ * RolloutStatus
+ *
+ * The rollout status of a single workload.
+ *
+ * @generated from enum kubeappsapis.plugins.resources.v1alpha1.RolloutStatus
+ */
+export enum RolloutStatus {
+  /**
+   * @generated from enum value: ROLLOUT_STATUS_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * @generated from enum value: ROLLOUT_STATUS_IN_PROGRESS = 1;
+   */
+  IN_PROGRESS = 1,
+
+  /**
+   * @generated from enum value: ROLLOUT_STATUS_SUCCEEDED = 2;
+   */
+  SUCCEEDED = 2,
+
+  /**
+   * @generated from enum value: ROLLOUT_STATUS_FAILED = 3;
+   */
+  FAILED = 3,
+}
+// Retrieve enum metadata with: proto3.getEnumType(RolloutStatus)
+proto3.util.setEnumType(RolloutStatus, "kubeappsapis.plugins.resources.v1alpha1.RolloutStatus", [
+  { no: 0, name: "ROLLOUT_STATUS_UNSPECIFIED" },
+  { no: 1, name: "ROLLOUT_STATUS_IN_PROGRESS" },
+  { no: 2, name: "ROLLOUT_STATUS_SUCCEEDED" },
+  { no: 3, name: "ROLLOUT_STATUS_FAILED" },
+]);
+
+/**
+ * ManifestSource
+ *
+ * The source of the exported manifests of the resources.
+ *
+ * @generated from enum kubeappsapis.plugins.resources.v1alpha1.ManifestSource
+ */
+export enum ManifestSource {
+  /**
+   * The live state of the resources on the cluster, without the fields
+   * populated by the API server (the status, the managed fields, the uid,
+   * resource version, generation and creation timestamp) so that the
+   * manifests can be diffed or applied elsewhere.
+   *
+   * @generated from enum value: MANIFEST_SOURCE_LIVE_UNSPECIFIED = 0;
+   */
+  LIVE_UNSPECIFIED = 0,
+
+  /**
+   * The configuration last applied to the resources, as rendered in the
+   * deployed revision of the Helm release owning them or as recorded in their
+   * `kubectl.kubernetes.io/last-applied-configuration` (kubectl) or
+   * `kapp.k14s.io/original` (kapp) annotations. The resources without such
+   * record are skipped.
+   *
+   * @generated from enum value: MANIFEST_SOURCE_LAST_APPLIED = 1;
+   */
+  LAST_APPLIED = 1,
+}
+// Retrieve enum metadata with: proto3.getEnumType(ManifestSource)
+proto3.util.setEnumType(ManifestSource, "kubeappsapis.plugins.resources.v1alpha1.ManifestSource", [
+  { no: 0, name: "MANIFEST_SOURCE_LIVE_UNSPECIFIED" },
+  { no: 1, name: "MANIFEST_SOURCE_LAST_APPLIED" },
+]);
+
 /**
  * SecretType
  *
@@ -221,6 +292,1039 @@ export class GetResourcesResponse extends Message<GetResourcesResponse> {
   }
 }
 
+/**
+ * GetRolloutStatusRequest
+ *
+ * Request for GetRolloutStatus that specifies the installed package whose
+ * workloads (Deployments, StatefulSets and DaemonSets) are being followed.
+ *
+ * @generated from message kubeappsapis.plugins.resources.v1alpha1.GetRolloutStatusRequest
+ */
+export class GetRolloutStatusRequest extends Message<GetRolloutStatusRequest> {
+  /**
+   * InstalledPackageRef
+   *
+   * The installed package reference for which the rollout status of the
+   * workloads is being fetched.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1alpha1.InstalledPackageReference installed_package_ref = 1;
+   */
+  installedPackageRef?: InstalledPackageReference;
+
+  /**
+   * Watch
+   *
+   * When true, similar to `kubectl rollout status`, the stream remains open
+   * with progress being sent as events are received from the Kubernetes API
+   * server, until every workload has either succeeded or failed to roll out.
+   * Otherwise only the current rollout status of each workload is sent.
+   *
+   * @generated from field: bool watch = 2;
+   */
+  watch = false;
+
+  constructor(data?: PartialMessage<GetRolloutStatusRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.plugins.resources.v1alpha1.GetRolloutStatusRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "installed_package_ref", kind: "message", T: InstalledPackageReference },
+    { no: 2, name: "watch", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): GetRolloutStatusRequest {
+    return new GetRolloutStatusRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): GetRolloutStatusRequest {
+    return new GetRolloutStatusRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetRolloutStatusRequest {
+    return new GetRolloutStatusRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: GetRolloutStatusRequest | PlainMessage<GetRolloutStatusRequest> | undefined,
+    b: GetRolloutStatusRequest | PlainMessage<GetRolloutStatusRequest> | undefined,
+  ): boolean {
+    return proto3.util.equals(GetRolloutStatusRequest, a, b);
+  }
+}
+
+/**
+ * GetRolloutStatusResponse
+ *
+ * Response for GetRolloutStatus, sent whenever the rollout status of one of
+ * the workloads of the installed package changes.
+ *
+ * @generated from message kubeappsapis.plugins.resources.v1alpha1.GetRolloutStatusResponse
+ */
+export class GetRolloutStatusResponse extends Message<GetRolloutStatusResponse> {
+  /**
+   * ResourceRef
+   *
+   * The resource reference of the workload.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1alpha1.ResourceRef resource_ref = 1;
+   */
+  resourceRef?: ResourceRef;
+
+  /**
+   * Status
+   *
+   * Whether the rollout is in progress or has terminated, either
+   * successfully or not.
+   *
+   * @generated from field: kubeappsapis.plugins.resources.v1alpha1.RolloutStatus status = 2;
+   */
+  status = RolloutStatus.UNSPECIFIED;
+
+  /**
+   * Message
+   *
+   * A human-readable message describing the progress of the rollout, using
+   * the same wording as `kubectl rollout status`.
+   *
+   * @generated from field: string message = 3;
+   */
+  message = "";
+
+  /**
+   * Reason
+   *
+   * A machine-readable reason, such as `ProgressDeadlineExceeded`, only set
+   * when the rollout failed.
+   *
+   * @generated from field: string reason = 4;
+   */
+  reason = "";
+
+  constructor(data?: PartialMessage<GetRolloutStatusResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.plugins.resources.v1alpha1.GetRolloutStatusResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "resource_ref", kind: "message", T: ResourceRef },
+    { no: 2, name: "status", kind: "enum", T: proto3.getEnumType(RolloutStatus) },
+    { no: 3, name: "message", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "reason", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): GetRolloutStatusResponse {
+    return new GetRolloutStatusResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): GetRolloutStatusResponse {
+    return new GetRolloutStatusResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetRolloutStatusResponse {
+    return new GetRolloutStatusResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: GetRolloutStatusResponse | PlainMessage<GetRolloutStatusResponse> | undefined,
+    b: GetRolloutStatusResponse | PlainMessage<GetRolloutStatusResponse> | undefined,
+  ): boolean {
+    return proto3.util.equals(GetRolloutStatusResponse, a, b);
+  }
+}
+
+/**
+ * GetSchedulingInfoRequest
+ *
+ * Request for GetSchedulingInfo that specifies the installed package whose
+ * pods are being inspected.
+ *
+ * @generated from message kubeappsapis.plugins.resources.v1alpha1.GetSchedulingInfoRequest
+ */
+export class GetSchedulingInfoRequest extends Message<GetSchedulingInfoRequest> {
+  /**
+   * InstalledPackageRef
+   *
+   * The installed package reference for which the scheduling information
+   * of the pods is being fetched.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1alpha1.InstalledPackageReference installed_package_ref = 1;
+   */
+  installedPackageRef?: InstalledPackageReference;
+
+  constructor(data?: PartialMessage<GetSchedulingInfoRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.plugins.resources.v1alpha1.GetSchedulingInfoRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "installed_package_ref", kind: "message", T: InstalledPackageReference },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): GetSchedulingInfoRequest {
+    return new GetSchedulingInfoRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): GetSchedulingInfoRequest {
+    return new GetSchedulingInfoRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetSchedulingInfoRequest {
+    return new GetSchedulingInfoRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: GetSchedulingInfoRequest | PlainMessage<GetSchedulingInfoRequest> | undefined,
+    b: GetSchedulingInfoRequest | PlainMessage<GetSchedulingInfoRequest> | undefined,
+  ): boolean {
+    return proto3.util.equals(GetSchedulingInfoRequest, a, b);
+  }
+}
+
+/**
+ * GetSchedulingInfoResponse
+ *
+ * Response for GetSchedulingInfo.
+ *
+ * @generated from message kubeappsapis.plugins.resources.v1alpha1.GetSchedulingInfoResponse
+ */
+export class GetSchedulingInfoResponse extends Message<GetSchedulingInfoResponse> {
+  /**
+   * Pods
+   *
+   * The scheduling information of the pods of the installed package, both
+   * those referenced directly and those selected by its workloads.
+   *
+   * @generated from field: repeated kubeappsapis.plugins.resources.v1alpha1.PodSchedulingInfo pods = 1;
+   */
+  pods: PodSchedulingInfo[] = [];
+
+  constructor(data?: PartialMessage<GetSchedulingInfoResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.plugins.resources.v1alpha1.GetSchedulingInfoResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "pods", kind: "message", T: PodSchedulingInfo, repeated: true },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): GetSchedulingInfoResponse {
+    return new GetSchedulingInfoResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): GetSchedulingInfoResponse {
+    return new GetSchedulingInfoResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetSchedulingInfoResponse {
+    return new GetSchedulingInfoResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: GetSchedulingInfoResponse | PlainMessage<GetSchedulingInfoResponse> | undefined,
+    b: GetSchedulingInfoResponse | PlainMessage<GetSchedulingInfoResponse> | undefined,
+  ): boolean {
+    return proto3.util.equals(GetSchedulingInfoResponse, a, b);
+  }
+}
+
+/**
+ * PodSchedulingInfo
+ *
+ * Where a single pod is placed and, when it is pending, why.
+ *
+ * @generated from message kubeappsapis.plugins.resources.v1alpha1.PodSchedulingInfo
+ */
+export class PodSchedulingInfo extends Message<PodSchedulingInfo> {
+  /**
+   * PodRef
+   *
+   * The resource reference of the pod.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1alpha1.ResourceRef pod_ref = 1;
+   */
+  podRef?: ResourceRef;
+
+  /**
+   * WorkloadRef
+   *
+   * The resource reference of the workload of the installed package
+   * selecting the pod, if any.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1alpha1.ResourceRef workload_ref = 2;
+   */
+  workloadRef?: ResourceRef;
+
+  /**
+   * Phase
+   *
+   * The phase of the pod, such as `Pending` or `Running`.
+   *
+   * @generated from field: string phase = 3;
+   */
+  phase = "";
+
+  /**
+   * Scheduled
+   *
+   * Whether the pod has been bound to a node.
+   *
+   * @generated from field: bool scheduled = 4;
+   */
+  scheduled = false;
+
+  /**
+   * NodeName
+   *
+   * The node the pod is scheduled to, if any.
+   *
+   * @generated from field: string node_name = 5;
+   */
+  nodeName = "";
+
+  /**
+   * NodeSelector
+   *
+   * The node labels required by the pod.
+   *
+   * @generated from field: map<string, string> node_selector = 6;
+   */
+  nodeSelector: { [key: string]: string } = {};
+
+  /**
+   * Tolerations
+   *
+   * A summary of each toleration of the pod, using the same wording as
+   * `kubectl describe pod`, such as `node.kubernetes.io/not-ready:NoExecute op=Exists for 300s`.
+   *
+   * @generated from field: repeated string tolerations = 7;
+   */
+  tolerations: string[] = [];
+
+  /**
+   * Affinity
+   *
+   * A summary of each node affinity, pod affinity and pod anti-affinity
+   * term of the pod, such as `required node affinity: kubernetes.io/arch in (amd64)`.
+   *
+   * @generated from field: repeated string affinity = 8;
+   */
+  affinity: string[] = [];
+
+  /**
+   * PendingReasons
+   *
+   * Why the pod is still pending, from the most recent: the messages of the
+   * scheduler, such as `0/3 nodes are available: 3 Insufficient cpu.`, when
+   * the pod cannot be scheduled, or the reasons its containers are waiting,
+   * such as `ImagePullBackOff`, once it is scheduled.
+   *
+   * @generated from field: repeated string pending_reasons = 9;
+   */
+  pendingReasons: string[] = [];
+
+  constructor(data?: PartialMessage<PodSchedulingInfo>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.plugins.resources.v1alpha1.PodSchedulingInfo";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "pod_ref", kind: "message", T: ResourceRef },
+    { no: 2, name: "workload_ref", kind: "message", T: ResourceRef },
+    { no: 3, name: "phase", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "scheduled", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 5, name: "node_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    {
+      no: 6,
+      name: "node_selector",
+      kind: "map",
+      K: 9 /* ScalarType.STRING */,
+      V: { kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    },
+    { no: 7, name: "tolerations", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 8, name: "affinity", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    {
+      no: 9,
+      name: "pending_reasons",
+      kind: "scalar",
+      T: 9 /* ScalarType.STRING */,
+      repeated: true,
+    },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): PodSchedulingInfo {
+    return new PodSchedulingInfo().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): PodSchedulingInfo {
+    return new PodSchedulingInfo().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): PodSchedulingInfo {
+    return new PodSchedulingInfo().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: PodSchedulingInfo | PlainMessage<PodSchedulingInfo> | undefined,
+    b: PodSchedulingInfo | PlainMessage<PodSchedulingInfo> | undefined,
+  ): boolean {
+    return proto3.util.equals(PodSchedulingInfo, a, b);
+  }
+}
+
+/**
+ * GetInstalledPackageEventsRequest
+ *
+ * Request for GetInstalledPackageEvents that specifies the installed package
+ * whose events are being fetched.
+ *
+ * @generated from message kubeappsapis.plugins.resources.v1alpha1.GetInstalledPackageEventsRequest
+ */
+export class GetInstalledPackageEventsRequest extends Message<GetInstalledPackageEventsRequest> {
+  /**
+   * InstalledPackageRef
+   *
+   * The installed package reference for which the events of the resources
+   * are being fetched.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1alpha1.InstalledPackageReference installed_package_ref = 1;
+   */
+  installedPackageRef?: InstalledPackageReference;
+
+  constructor(data?: PartialMessage<GetInstalledPackageEventsRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName =
+    "kubeappsapis.plugins.resources.v1alpha1.GetInstalledPackageEventsRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "installed_package_ref", kind: "message", T: InstalledPackageReference },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): GetInstalledPackageEventsRequest {
+    return new GetInstalledPackageEventsRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): GetInstalledPackageEventsRequest {
+    return new GetInstalledPackageEventsRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetInstalledPackageEventsRequest {
+    return new GetInstalledPackageEventsRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | GetInstalledPackageEventsRequest
+      | PlainMessage<GetInstalledPackageEventsRequest>
+      | undefined,
+    b:
+      | GetInstalledPackageEventsRequest
+      | PlainMessage<GetInstalledPackageEventsRequest>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(GetInstalledPackageEventsRequest, a, b);
+  }
+}
+
+/**
+ * GetInstalledPackageEventsResponse
+ *
+ * Response for GetInstalledPackageEvents.
+ *
+ * @generated from message kubeappsapis.plugins.resources.v1alpha1.GetInstalledPackageEventsResponse
+ */
+export class GetInstalledPackageEventsResponse extends Message<GetInstalledPackageEventsResponse> {
+  /**
+   * Events
+   *
+   * The events of the resources of the installed package, from the most
+   * recent.
+   *
+   * @generated from field: repeated kubeappsapis.plugins.resources.v1alpha1.ResourceEvent events = 1;
+   */
+  events: ResourceEvent[] = [];
+
+  constructor(data?: PartialMessage<GetInstalledPackageEventsResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName =
+    "kubeappsapis.plugins.resources.v1alpha1.GetInstalledPackageEventsResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "events", kind: "message", T: ResourceEvent, repeated: true },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): GetInstalledPackageEventsResponse {
+    return new GetInstalledPackageEventsResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): GetInstalledPackageEventsResponse {
+    return new GetInstalledPackageEventsResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetInstalledPackageEventsResponse {
+    return new GetInstalledPackageEventsResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | GetInstalledPackageEventsResponse
+      | PlainMessage<GetInstalledPackageEventsResponse>
+      | undefined,
+    b:
+      | GetInstalledPackageEventsResponse
+      | PlainMessage<GetInstalledPackageEventsResponse>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(GetInstalledPackageEventsResponse, a, b);
+  }
+}
+
+/**
+ * ResourceEvent
+ *
+ * A Kubernetes event of a resource of an installed package. The events with
+ * the same resource, type, reason and message are reported once.
+ *
+ * @generated from message kubeappsapis.plugins.resources.v1alpha1.ResourceEvent
+ */
+export class ResourceEvent extends Message<ResourceEvent> {
+  /**
+   * ResourceRef
+   *
+   * The resource reference of the object the event is about.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1alpha1.ResourceRef resource_ref = 1;
+   */
+  resourceRef?: ResourceRef;
+
+  /**
+   * Type
+   *
+   * The type of the event, either `Normal` or `Warning`.
+   *
+   * @generated from field: string type = 2;
+   */
+  type = "";
+
+  /**
+   * Reason
+   *
+   * The reason of the event, such as `BackOff` or `FailedMount`.
+   *
+   * @generated from field: string reason = 3;
+   */
+  reason = "";
+
+  /**
+   * Message
+   *
+   * The human-readable description of the event.
+   *
+   * @generated from field: string message = 4;
+   */
+  message = "";
+
+  /**
+   * Count
+   *
+   * The number of times the event has occurred.
+   *
+   * @generated from field: int32 count = 5;
+   */
+  count = 0;
+
+  /**
+   * FirstTimestamp
+   *
+   * When the event first occurred, in RFC3339 format.
+   *
+   * @generated from field: string first_timestamp = 6;
+   */
+  firstTimestamp = "";
+
+  /**
+   * LastTimestamp
+   *
+   * When the event most recently occurred, in RFC3339 format.
+   *
+   * @generated from field: string last_timestamp = 7;
+   */
+  lastTimestamp = "";
+
+  /**
+   * Source
+   *
+   * The component reporting the event, such as `kubelet`.
+   *
+   * @generated from field: string source = 8;
+   */
+  source = "";
+
+  constructor(data?: PartialMessage<ResourceEvent>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.plugins.resources.v1alpha1.ResourceEvent";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "resource_ref", kind: "message", T: ResourceRef },
+    { no: 2, name: "type", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "reason", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "message", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "count", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 6, name: "first_timestamp", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 7, name: "last_timestamp", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 8, name: "source", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ResourceEvent {
+    return new ResourceEvent().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ResourceEvent {
+    return new ResourceEvent().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ResourceEvent {
+    return new ResourceEvent().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: ResourceEvent | PlainMessage<ResourceEvent> | undefined,
+    b: ResourceEvent | PlainMessage<ResourceEvent> | undefined,
+  ): boolean {
+    return proto3.util.equals(ResourceEvent, a, b);
+  }
+}
+
+/**
+ * GetInstalledPackageCustomFieldsRequest
+ *
+ * Request for GetInstalledPackageCustomFields that specifies the installed
+ * package whose custom fields are being extracted.
+ *
+ * @generated from message kubeappsapis.plugins.resources.v1alpha1.GetInstalledPackageCustomFieldsRequest
+ */
+export class GetInstalledPackageCustomFieldsRequest extends Message<GetInstalledPackageCustomFieldsRequest> {
+  /**
+   * InstalledPackageRef
+   *
+   * The installed package reference for which the custom fields of the
+   * resources are being extracted.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1alpha1.InstalledPackageReference installed_package_ref = 1;
+   */
+  installedPackageRef?: InstalledPackageReference;
+
+  constructor(data?: PartialMessage<GetInstalledPackageCustomFieldsRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName =
+    "kubeappsapis.plugins.resources.v1alpha1.GetInstalledPackageCustomFieldsRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "installed_package_ref", kind: "message", T: InstalledPackageReference },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): GetInstalledPackageCustomFieldsRequest {
+    return new GetInstalledPackageCustomFieldsRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): GetInstalledPackageCustomFieldsRequest {
+    return new GetInstalledPackageCustomFieldsRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetInstalledPackageCustomFieldsRequest {
+    return new GetInstalledPackageCustomFieldsRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | GetInstalledPackageCustomFieldsRequest
+      | PlainMessage<GetInstalledPackageCustomFieldsRequest>
+      | undefined,
+    b:
+      | GetInstalledPackageCustomFieldsRequest
+      | PlainMessage<GetInstalledPackageCustomFieldsRequest>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(GetInstalledPackageCustomFieldsRequest, a, b);
+  }
+}
+
+/**
+ * GetInstalledPackageCustomFieldsResponse
+ *
+ * Response for GetInstalledPackageCustomFields.
+ *
+ * @generated from message kubeappsapis.plugins.resources.v1alpha1.GetInstalledPackageCustomFieldsResponse
+ */
+export class GetInstalledPackageCustomFieldsResponse extends Message<GetInstalledPackageCustomFieldsResponse> {
+  /**
+   * Fields
+   *
+   * The custom fields extracted from the resources of the installed package,
+   * in the order of the resources. The fields of a resource declared in the
+   * plugin configuration come before those declared in its
+   * `kubeapps.dev/custom-fields` annotation. The fields whose expression
+   * does not match anything are left out.
+   *
+   * @generated from field: repeated kubeappsapis.plugins.resources.v1alpha1.CustomField fields = 1;
+   */
+  fields: CustomField[] = [];
+
+  constructor(data?: PartialMessage<GetInstalledPackageCustomFieldsResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName =
+    "kubeappsapis.plugins.resources.v1alpha1.GetInstalledPackageCustomFieldsResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "fields", kind: "message", T: CustomField, repeated: true },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): GetInstalledPackageCustomFieldsResponse {
+    return new GetInstalledPackageCustomFieldsResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): GetInstalledPackageCustomFieldsResponse {
+    return new GetInstalledPackageCustomFieldsResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): GetInstalledPackageCustomFieldsResponse {
+    return new GetInstalledPackageCustomFieldsResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | GetInstalledPackageCustomFieldsResponse
+      | PlainMessage<GetInstalledPackageCustomFieldsResponse>
+      | undefined,
+    b:
+      | GetInstalledPackageCustomFieldsResponse
+      | PlainMessage<GetInstalledPackageCustomFieldsResponse>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(GetInstalledPackageCustomFieldsResponse, a, b);
+  }
+}
+
+/**
+ * CustomField
+ *
+ * A key/value pair extracted with a JSONPath expression from a resource of an
+ * installed package, such as the external URL of an Ingress.
+ *
+ * @generated from message kubeappsapis.plugins.resources.v1alpha1.CustomField
+ */
+export class CustomField extends Message<CustomField> {
+  /**
+   * Name
+   *
+   * The name of the field, as declared, such as `External URL`.
+   *
+   * @generated from field: string name = 1;
+   */
+  name = "";
+
+  /**
+   * Value
+   *
+   * The result of the JSONPath expression, with multiple results separated
+   * by spaces as with `kubectl get -o jsonpath`.
+   *
+   * @generated from field: string value = 2;
+   */
+  value = "";
+
+  /**
+   * ResourceRef
+   *
+   * The resource reference of the object the field is extracted from.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1alpha1.ResourceRef resource_ref = 3;
+   */
+  resourceRef?: ResourceRef;
+
+  constructor(data?: PartialMessage<CustomField>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "kubeappsapis.plugins.resources.v1alpha1.CustomField";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "value", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "resource_ref", kind: "message", T: ResourceRef },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): CustomField {
+    return new CustomField().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): CustomField {
+    return new CustomField().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): CustomField {
+    return new CustomField().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a: CustomField | PlainMessage<CustomField> | undefined,
+    b: CustomField | PlainMessage<CustomField> | undefined,
+  ): boolean {
+    return proto3.util.equals(CustomField, a, b);
+  }
+}
+
+/**
+ * ExportInstalledPackageManifestsRequest
+ *
+ * Request for ExportInstalledPackageManifests that specifies the installed
+ * package whose manifests are being exported.
+ *
+ * @generated from message kubeappsapis.plugins.resources.v1alpha1.ExportInstalledPackageManifestsRequest
+ */
+export class ExportInstalledPackageManifestsRequest extends Message<ExportInstalledPackageManifestsRequest> {
+  /**
+   * InstalledPackageRef
+   *
+   * The installed package reference for which the manifests of the resources
+   * are being exported.
+   *
+   * @generated from field: kubeappsapis.core.packages.v1alpha1.InstalledPackageReference installed_package_ref = 1;
+   */
+  installedPackageRef?: InstalledPackageReference;
+
+  /**
+   * Source
+   *
+   * Whether the live state of the resources or the configuration last
+   * applied to them is exported. Defaults to the live state.
+   *
+   * @generated from field: kubeappsapis.plugins.resources.v1alpha1.ManifestSource source = 2;
+   */
+  source = ManifestSource.LIVE_UNSPECIFIED;
+
+  constructor(data?: PartialMessage<ExportInstalledPackageManifestsRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName =
+    "kubeappsapis.plugins.resources.v1alpha1.ExportInstalledPackageManifestsRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "installed_package_ref", kind: "message", T: InstalledPackageReference },
+    { no: 2, name: "source", kind: "enum", T: proto3.getEnumType(ManifestSource) },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): ExportInstalledPackageManifestsRequest {
+    return new ExportInstalledPackageManifestsRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): ExportInstalledPackageManifestsRequest {
+    return new ExportInstalledPackageManifestsRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): ExportInstalledPackageManifestsRequest {
+    return new ExportInstalledPackageManifestsRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | ExportInstalledPackageManifestsRequest
+      | PlainMessage<ExportInstalledPackageManifestsRequest>
+      | undefined,
+    b:
+      | ExportInstalledPackageManifestsRequest
+      | PlainMessage<ExportInstalledPackageManifestsRequest>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(ExportInstalledPackageManifestsRequest, a, b);
+  }
+}
+
+/**
+ * ExportInstalledPackageManifestsResponse
+ *
+ * Response for ExportInstalledPackageManifests.
+ *
+ * @generated from message kubeappsapis.plugins.resources.v1alpha1.ExportInstalledPackageManifestsResponse
+ */
+export class ExportInstalledPackageManifestsResponse extends Message<ExportInstalledPackageManifestsResponse> {
+  /**
+   * Manifests
+   *
+   * The manifests of the resources of the installed package, in the order of
+   * the resources, as a single multi-document YAML.
+   *
+   * @generated from field: string manifests = 1;
+   */
+  manifests = "";
+
+  /**
+   * SkippedResourceRefs
+   *
+   * The references of the resources left out of the manifests, either not
+   * created yet or without last applied configuration.
+   *
+   * @generated from field: repeated kubeappsapis.core.packages.v1alpha1.ResourceRef skipped_resource_refs = 2;
+   */
+  skippedResourceRefs: ResourceRef[] = [];
+
+  constructor(data?: PartialMessage<ExportInstalledPackageManifestsResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName =
+    "kubeappsapis.plugins.resources.v1alpha1.ExportInstalledPackageManifestsResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "manifests", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "skipped_resource_refs", kind: "message", T: ResourceRef, repeated: true },
+  ]);
+
+  static fromBinary(
+    bytes: Uint8Array,
+    options?: Partial<BinaryReadOptions>,
+  ): ExportInstalledPackageManifestsResponse {
+    return new ExportInstalledPackageManifestsResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(
+    jsonValue: JsonValue,
+    options?: Partial<JsonReadOptions>,
+  ): ExportInstalledPackageManifestsResponse {
+    return new ExportInstalledPackageManifestsResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(
+    jsonString: string,
+    options?: Partial<JsonReadOptions>,
+  ): ExportInstalledPackageManifestsResponse {
+    return new ExportInstalledPackageManifestsResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(
+    a:
+      | ExportInstalledPackageManifestsResponse
+      | PlainMessage<ExportInstalledPackageManifestsResponse>
+      | undefined,
+    b:
+      | ExportInstalledPackageManifestsResponse
+      | PlainMessage<ExportInstalledPackageManifestsResponse>
+      | undefined,
+  ): boolean {
+    return proto3.util.equals(ExportInstalledPackageManifestsResponse, a, b);
+  }
+}
+
 /**
  * GetServiceAccountNamesRequest
  *
@@ -234,6 +234,7 @@ describe("clusterReducer", () => {
             payload: {
               cluster: "other",
               namespaces: ["one", "two", "three"],
+              storedNamespace: "",
             },
           },
         ),
@@ -274,6 +275,7 @@ describe("clusterReducer", () => {
             payload: {
               cluster: "other",
               namespaces: ["one", "two", "three"],
+              storedNamespace: "",
             },
           },
         ),
@@ -309,6 +311,7 @@ describe("clusterReducer", () => {
             payload: {
               cluster: "other",
               namespaces: ["one", "two", "three"],
+              storedNamespace: "",
             },
           },
         ),
@@ -343,6 +346,7 @@ describe("clusterReducer", () => {
             payload: {
               cluster: "other",
               namespaces: ["one", "two", "three"],
+              storedNamespace: "",
             },
           },
         ),
@@ -360,9 +364,6 @@ describe("clusterReducer", () => {
     });
 
     it("gets the stored namespace", () => {
-      jest
-        .spyOn(window.localStorage.__proto__, "getItem")
-        .mockReturnValueOnce('{"other": "three"}');
       expect(
         clusterReducer(
           {
@@ -380,6 +381,7 @@ describe("clusterReducer", () => {
             payload: {
               cluster: "other",
               namespaces: ["one", "two", "three"],
+              storedNamespace: "three",
             },
           },
         ),
@@ -397,7 +399,6 @@ describe("clusterReducer", () => {
     });
 
     it("ignores the stored namespace if it's not available", () => {
-      jest.spyOn(window.localStorage.__proto__, "getItem").mockReturnValueOnce('{"other": "four"}');
       expect(
         clusterReducer(
           {
@@ -415,6 +416,7 @@ describe("clusterReducer", () => {
             payload: {
               cluster: "other",
               namespaces: ["one", "two", "three"],
+              storedNamespace: "four",
             },
           },
         ),
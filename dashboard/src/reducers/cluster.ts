@@ -74,8 +74,8 @@ const clusterReducer = (
             ...state.clusters[action.payload.cluster],
             namespaces: action.payload.namespaces,
             currentNamespace: getCurrentNamespace(
-              action.payload.cluster,
               state.clusters[action.payload.cluster].currentNamespace,
+              action.payload.storedNamespace,
               action.payload.namespaces,
             ),
             error: undefined,
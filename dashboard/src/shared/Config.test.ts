@@ -3,7 +3,9 @@
 
 import axios from "axios";
 import MockAdapter from "axios-mock-adapter";
+import { Theme, UserPreferences } from "gen/kubeappsapis/core/preferences/v1alpha1/preferences_pb";
 import Config, { IConfig, SupportedThemes } from "./Config";
+import Preferences from "./Preferences";
 
 describe("Config", () => {
   let defaultJSON: IConfig;
@@ -50,8 +52,21 @@ describe("Themes", () => {
   });
 
   it("returns the stored theme", () => {
-    jest.spyOn(window.localStorage.__proto__, "getItem").mockReturnValue(SupportedThemes.dark);
-    expect(Config.getTheme({} as IConfig)).toBe(SupportedThemes.dark);
+    const preferences = new UserPreferences({ theme: Theme.DARK });
+    expect(Config.getTheme({} as IConfig, preferences)).toBe(SupportedThemes.dark);
+  });
+
+  it("ignores the stored theme if unspecified", () => {
+    const preferences = new UserPreferences({ theme: Theme.UNSPECIFIED });
+    const defaultJSONTheme = { ...defaultJSON, theme: SupportedThemes.dark };
+    expect(Config.getTheme(defaultJSONTheme, preferences)).toBe(SupportedThemes.dark);
+  });
+
+  it("stores the user theme in the user preferences", async () => {
+    const update = jest.spyOn(Preferences, "update").mockResolvedValue(new UserPreferences());
+    await Config.setUserTheme(SupportedThemes.dark);
+    expect(document.body.getAttribute("cds-theme")).toBe(SupportedThemes.dark);
+    expect(update).toHaveBeenCalledWith({ theme: Theme.DARK });
   });
 
   it("returns the light theme by default", () => {
@@ -75,7 +90,7 @@ describe("Themes", () => {
 
   it("returns the theme according to the preference (user>system>browser>fallback)", () => {
     // User preference = dark
-    jest.spyOn(window.localStorage.__proto__, "getItem").mockReturnValue(SupportedThemes.dark);
+    const preferences = new UserPreferences({ theme: Theme.DARK });
 
     // System preference = light
     const defaultJSONTheme = { ...defaultJSON, theme: SupportedThemes.light };
@@ -87,7 +102,7 @@ describe("Themes", () => {
         matches: true,
       })),
     });
-    expect(Config.getTheme(defaultJSONTheme)).toBe(SupportedThemes.dark);
+    expect(Config.getTheme(defaultJSONTheme, preferences)).toBe(SupportedThemes.dark);
   });
 
   it("returns the theme according to the preference (system>browser>fallback)", () => {
@@ -3,8 +3,10 @@
 
 import axios from "axios";
 import { Plugin } from "gen/kubeappsapis/core/plugins/v1alpha1/plugins_pb";
+import { Theme, UserPreferences } from "gen/kubeappsapis/core/preferences/v1alpha1/preferences_pb";
 import * as url from "shared/url";
-import { PackageRepositoriesService } from "./PackageRepositoriesService";
+import Features from "./Features";
+import Preferences from "./Preferences";
 
 export enum SupportedThemes {
   dark = "dark",
@@ -37,6 +39,8 @@ export interface IConfig {
   customAppViews: ICustomAppViewIdentifier[];
   skipAvailablePackageDetails: boolean;
   createNamespaceLabels: { [key: string]: string };
+  // PEM-encoded Ed25519 public key verifying the signature of the features descriptor
+  featuresPublicKey?: string;
   configuredPlugins: Plugin[];
 }
 
@@ -51,13 +55,15 @@ export default class Config {
     return data;
   }
 
-  public static async getConfiguredPlugins() {
-    const { plugins } = await PackageRepositoriesService.getConfiguredPlugins();
-    return plugins;
+  // getConfiguredPlugins returns the plugins from the verified features
+  // descriptor of the kubeapps-apis server.
+  public static async getConfiguredPlugins(featuresPublicKey?: string): Promise<Plugin[]> {
+    const { plugins } = await Features.get(featuresPublicKey);
+    return plugins.flatMap(p => (p.plugin ? [p.plugin] : []));
   }
 
   // getTheme retrieves the different theme preferences and calculates which one is chosen
-  public static getTheme(config: IConfig): SupportedThemes {
+  public static getTheme(config: IConfig, preferences?: UserPreferences): SupportedThemes {
     // Define a fallback theme in case of errors
     const fallbackTheme = SupportedThemes.light;
 
@@ -67,11 +73,8 @@ export default class Config {
         ? SupportedThemes[config.theme as keyof typeof SupportedThemes]
         : undefined;
 
-    // Retrieve the user theme preference
-    const userTheme =
-      localStorage.getItem("user-theme") != null
-        ? SupportedThemes[localStorage.getItem("user-theme") as keyof typeof SupportedThemes]
-        : undefined;
+    // Retrieve the user theme preference, if the user preferences could be retrieved
+    const userTheme = preferences ? fromPreferencesTheme(preferences.theme) : undefined;
 
     // Retrieve the browser theme preference
     const browserTheme =
@@ -91,10 +94,32 @@ export default class Config {
     document.body.setAttribute("cds-theme", theme);
   }
 
-  // setUserTheme changes the current theme and also stores the user's preference in the localStorage
+  // setUserTheme changes the current theme and also stores it in the user preferences
   // it's a separate function for testing
-  public static setUserTheme(theme: SupportedThemes) {
+  public static async setUserTheme(theme: SupportedThemes) {
     Config.setTheme(theme);
-    localStorage.setItem("user-theme", theme);
+    await Preferences.update({ theme: toPreferencesTheme(theme) });
+  }
+}
+
+function fromPreferencesTheme(theme: Theme): SupportedThemes | undefined {
+  switch (theme) {
+    case Theme.LIGHT:
+      return SupportedThemes.light;
+    case Theme.DARK:
+      return SupportedThemes.dark;
+    default:
+      return undefined;
+  }
+}
+
+function toPreferencesTheme(theme: SupportedThemes): Theme {
+  switch (theme) {
+    case SupportedThemes.light:
+      return Theme.LIGHT;
+    case SupportedThemes.dark:
+      return Theme.DARK;
+    default:
+      return Theme.UNSPECIFIED;
   }
 }
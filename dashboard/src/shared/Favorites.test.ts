@@ -0,0 +1,102 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+import {
+  AddRecentInstallResponse,
+  AddStarredPackageResponse,
+  GetUserFavoritesResponse,
+  RecentInstall,
+  RemoveStarredPackageResponse,
+  StarredPackage,
+  UserFavorites,
+} from "gen/kubeappsapis/core/favorites/v1alpha1/favorites_pb";
+import { AvailablePackageReference } from "gen/kubeappsapis/core/packages/v1/packages_pb";
+import Favorites from "./Favorites";
+import KubeappsGrpcClient from "./KubeappsGrpcClient";
+
+const apacheRef = new AvailablePackageReference({
+  context: { cluster: "default", namespace: "kubeapps" },
+  identifier: "bitnami/apache",
+  plugin: { name: "helm.packages", version: "v1alpha1" },
+});
+
+const starred = new StarredPackage({
+  availablePackageRef: apacheRef,
+  displayName: "apache",
+  time: "2023-06-03T02:00:00Z",
+});
+
+describe("Favorites", () => {
+  afterEach(() => {
+    jest.restoreAllMocks();
+  });
+
+  it("get returns the stored favorites", async () => {
+    const favorites = new UserFavorites({ starredPackages: [starred] });
+    const mockGetUserFavorites = jest
+      .fn()
+      .mockImplementation(() => Promise.resolve(new GetUserFavoritesResponse({ favorites })));
+    setMockClient({ getUserFavorites: mockGetUserFavorites });
+
+    expect(await Favorites.get()).toStrictEqual(favorites);
+  });
+
+  it("get returns empty favorites if none are stored", async () => {
+    const mockGetUserFavorites = jest
+      .fn()
+      .mockImplementation(() => Promise.resolve(new GetUserFavoritesResponse()));
+    setMockClient({ getUserFavorites: mockGetUserFavorites });
+
+    expect(await Favorites.get()).toStrictEqual(new UserFavorites());
+  });
+
+  it("star and unstar return the starred packages", async () => {
+    const mockAddStarredPackage = jest
+      .fn()
+      .mockImplementation(() =>
+        Promise.resolve(new AddStarredPackageResponse({ starredPackages: [starred] })),
+      );
+    const mockRemoveStarredPackage = jest
+      .fn()
+      .mockImplementation(() => Promise.resolve(new RemoveStarredPackageResponse()));
+    setMockClient({
+      addStarredPackage: mockAddStarredPackage,
+      removeStarredPackage: mockRemoveStarredPackage,
+    });
+
+    const starredPackage = { availablePackageRef: apacheRef, displayName: "apache" };
+    expect(await Favorites.star(starredPackage)).toEqual([starred]);
+    expect(mockAddStarredPackage).toHaveBeenCalledWith({ starredPackage });
+    expect(await Favorites.unstar(apacheRef)).toEqual([]);
+    expect(mockRemoveStarredPackage).toHaveBeenCalledWith({ availablePackageRef: apacheRef });
+  });
+
+  it("addRecentInstall returns the recent installs", async () => {
+    const recentInstall = new RecentInstall({
+      installedPackageRef: {
+        context: { cluster: "default", namespace: "team-a" },
+        identifier: "my-apache",
+        plugin: { name: "helm.packages", version: "v1alpha1" },
+      },
+      availablePackageRef: apacheRef,
+    });
+    const mockAddRecentInstall = jest
+      .fn()
+      .mockImplementation(() =>
+        Promise.resolve(new AddRecentInstallResponse({ recentInstalls: [recentInstall] })),
+      );
+    setMockClient({ addRecentInstall: mockAddRecentInstall });
+
+    expect(await Favorites.addRecentInstall(recentInstall)).toEqual([recentInstall]);
+  });
+});
+
+function setMockClient(mocks: { [fn: string]: jest.Mock<any, any> }) {
+  // Replace the specified functions on the real KubeappsGrpcClient's
+  // favorites service implementation.
+  const mockClient = new KubeappsGrpcClient().getFavoritesServiceClientImpl();
+  Object.entries(mocks).forEach(([fn, mockFn]) =>
+    jest.spyOn(mockClient, fn as any).mockImplementation(mockFn),
+  );
+  jest.spyOn(Favorites, "favoritesClient").mockImplementation(() => mockClient);
+}
@@ -0,0 +1,74 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+import type { PartialMessage } from "@bufbuild/protobuf";
+import {
+  RecentInstall,
+  StarredPackage,
+  UserFavorites,
+} from "gen/kubeappsapis/core/favorites/v1alpha1/favorites_pb";
+import {
+  AvailablePackageReference,
+  InstalledPackageReference,
+} from "gen/kubeappsapis/core/packages/v1/packages_pb";
+import KubeappsGrpcClient from "./KubeappsGrpcClient";
+import { convertGrpcAuthError } from "./utils";
+
+// Favorites records the packages starred and recently installed by the
+// current user in the kubeapps-apis server, to show them as shortcuts.
+export default class Favorites {
+  public static favoritesClient = () => new KubeappsGrpcClient().getFavoritesServiceClientImpl();
+
+  public static async get(): Promise<UserFavorites> {
+    const { favorites } = await this.favoritesClient()
+      .getUserFavorites({})
+      .catch((e: any) => {
+        throw convertGrpcAuthError(e);
+      });
+    return favorites || new UserFavorites();
+  }
+
+  public static async star(
+    starredPackage: PartialMessage<StarredPackage>,
+  ): Promise<StarredPackage[]> {
+    const { starredPackages } = await this.favoritesClient()
+      .addStarredPackage({ starredPackage })
+      .catch((e: any) => {
+        throw convertGrpcAuthError(e);
+      });
+    return starredPackages;
+  }
+
+  public static async unstar(
+    availablePackageRef: PartialMessage<AvailablePackageReference>,
+  ): Promise<StarredPackage[]> {
+    const { starredPackages } = await this.favoritesClient()
+      .removeStarredPackage({ availablePackageRef })
+      .catch((e: any) => {
+        throw convertGrpcAuthError(e);
+      });
+    return starredPackages;
+  }
+
+  public static async addRecentInstall(
+    recentInstall: PartialMessage<RecentInstall>,
+  ): Promise<RecentInstall[]> {
+    const { recentInstalls } = await this.favoritesClient()
+      .addRecentInstall({ recentInstall })
+      .catch((e: any) => {
+        throw convertGrpcAuthError(e);
+      });
+    return recentInstalls;
+  }
+
+  public static async removeRecentInstall(
+    installedPackageRef: PartialMessage<InstalledPackageReference>,
+  ): Promise<RecentInstall[]> {
+    const { recentInstalls } = await this.favoritesClient()
+      .removeRecentInstall({ installedPackageRef })
+      .catch((e: any) => {
+        throw convertGrpcAuthError(e);
+      });
+    return recentInstalls;
+  }
+}
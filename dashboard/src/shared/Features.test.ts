@@ -0,0 +1,128 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+import { createHash, webcrypto } from "crypto";
+import {
+  FeaturesDescriptor,
+  GetFeaturesResponse,
+  OperationsPolicy,
+} from "gen/kubeappsapis/core/plugins/v1alpha1/plugins_pb";
+import Features from "./Features";
+import KubeappsGrpcClient from "./KubeappsGrpcClient";
+
+const descriptor = new FeaturesDescriptor({
+  version: 1,
+  plugins: [{ plugin: { name: "helm.packages", version: "v1alpha1" } }],
+  clusters: ["default"],
+  operations: new OperationsPolicy({ packagesEnabled: true }),
+});
+const payload = descriptor.toBinary();
+const checksum = `sha256:${createHash("sha256").update(payload).digest("hex")}`;
+
+describe("Features", () => {
+  let signingKey: CryptoKey;
+  let publicKey: string;
+
+  beforeAll(async () => {
+    // jsdom does not provide the Web Crypto API.
+    Object.defineProperty(window, "crypto", { value: webcrypto, configurable: true });
+    const keyPair = (await webcrypto.subtle.generateKey({ name: "Ed25519" }, true, [
+      "sign",
+      "verify",
+    ])) as CryptoKeyPair;
+    signingKey = keyPair.privateKey;
+    publicKey = toPEM(new Uint8Array(await webcrypto.subtle.exportKey("spki", keyPair.publicKey)));
+  });
+
+  afterEach(() => {
+    Object.defineProperty(window, "crypto", { value: webcrypto, configurable: true });
+    jest.restoreAllMocks();
+  });
+
+  it("get returns the decoded payload if it matches the checksum", async () => {
+    setMockGetFeatures(new GetFeaturesResponse({ payload, checksum }));
+
+    expect(await Features.get()).toStrictEqual(descriptor);
+  });
+
+  it("get throws an error if the payload does not match the checksum", async () => {
+    setMockGetFeatures(
+      new GetFeaturesResponse({ payload, checksum: `sha256:${"0".repeat(64)}` }),
+    );
+
+    await expect(Features.get()).rejects.toThrow(
+      "The features descriptor does not match its checksum",
+    );
+  });
+
+  it("get returns the decoded payload if it matches the signature", async () => {
+    const signature = await sign(payload);
+    setMockGetFeatures(new GetFeaturesResponse({ payload, checksum, signature }));
+
+    expect(await Features.get(publicKey)).toStrictEqual(descriptor);
+  });
+
+  it("get throws an error if the payload does not match the signature", async () => {
+    const signature = await sign(new FeaturesDescriptor({ version: 2 }).toBinary());
+    setMockGetFeatures(new GetFeaturesResponse({ payload, checksum, signature }));
+
+    await expect(Features.get(publicKey)).rejects.toThrow(
+      "The features descriptor does not match its signature",
+    );
+  });
+
+  it("get throws an error if the payload is not signed but a public key is configured", async () => {
+    setMockGetFeatures(new GetFeaturesResponse({ payload, checksum }));
+
+    await expect(Features.get(publicKey)).rejects.toThrow("The features descriptor is not signed");
+  });
+
+  it("get throws an error if the public key is invalid", async () => {
+    const signature = await sign(payload);
+    setMockGetFeatures(new GetFeaturesResponse({ payload, checksum, signature }));
+
+    await expect(Features.get("not a key")).rejects.toThrow(
+      "Invalid public key for the features descriptor",
+    );
+  });
+
+  it("get throws an error if a public key is configured outside of a secure context", async () => {
+    Object.defineProperty(window, "crypto", { value: undefined, configurable: true });
+    const signature = await sign(payload);
+    setMockGetFeatures(new GetFeaturesResponse({ payload, checksum, signature }));
+
+    await expect(Features.get(publicKey)).rejects.toThrow(
+      "Unable to verify the features descriptor outside of a secure context",
+    );
+  });
+
+  it("get returns the decoded payload outside of a secure context if no public key is configured", async () => {
+    Object.defineProperty(window, "crypto", { value: undefined, configurable: true });
+    setMockGetFeatures(new GetFeaturesResponse({ payload, checksum }));
+
+    expect(await Features.get()).toStrictEqual(descriptor);
+  });
+
+  it("get throws an error if the checksum algorithm is not supported", async () => {
+    setMockGetFeatures(new GetFeaturesResponse({ payload, checksum: "md5:foo" }));
+
+    await expect(Features.get()).rejects.toThrow(
+      "Unsupported checksum for the features descriptor: md5:foo",
+    );
+  });
+
+  async function sign(data: Uint8Array) {
+    return new Uint8Array(await webcrypto.subtle.sign({ name: "Ed25519" }, signingKey, data));
+  }
+});
+
+function toPEM(der: Uint8Array) {
+  const base64 = Buffer.from(der).toString("base64");
+  return `-----BEGIN PUBLIC KEY-----\n${base64}\n-----END PUBLIC KEY-----\n`;
+}
+
+function setMockGetFeatures(response: GetFeaturesResponse) {
+  const mockClient = new KubeappsGrpcClient().getPluginsServiceClientImpl();
+  jest.spyOn(mockClient, "getFeatures").mockImplementation(() => Promise.resolve(response));
+  jest.spyOn(Features, "pluginsClient").mockImplementation(() => mockClient);
+}
@@ -0,0 +1,81 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+import { FeaturesDescriptor } from "gen/kubeappsapis/core/plugins/v1alpha1/plugins_pb";
+import KubeappsGrpcClient from "./KubeappsGrpcClient";
+import { convertGrpcAuthError } from "./utils";
+
+const sha256Prefix = "sha256:";
+const ed25519 = "Ed25519";
+
+// Features retrieves the descriptor of the features enabled in the
+// kubeapps-apis server, verifying that it matches its checksum and, if a
+// public key is configured, its signature.
+export default class Features {
+  public static pluginsClient = () => new KubeappsGrpcClient().getPluginsServiceClientImpl();
+
+  // get returns the verified descriptor. The publicKey is the PEM-encoded
+  // Ed25519 public key matching the signing key of the kubeapps-apis server.
+  public static async get(publicKey?: string): Promise<FeaturesDescriptor> {
+    const { payload, checksum, signature } = await this.pluginsClient()
+      .getFeatures({})
+      .catch((e: any) => {
+        throw convertGrpcAuthError(e);
+      });
+    // The Web Crypto API is only available in secure contexts (https or localhost).
+    const subtle = window.crypto?.subtle;
+    if (publicKey) {
+      if (!subtle) {
+        throw new Error("Unable to verify the features descriptor outside of a secure context");
+      }
+      await this.verifySignature(subtle, payload, signature, publicKey);
+    }
+    // Without a public key, the checksum only detects a corrupted payload, so
+    // it is not verified if the Web Crypto API is unavailable.
+    if (subtle) {
+      await this.verifyChecksum(subtle, payload, checksum);
+    }
+    // The payload, rather than the decoded features of the response, is what
+    // the checksum and the signature cover.
+    return FeaturesDescriptor.fromBinary(payload);
+  }
+
+  public static async verifyChecksum(subtle: SubtleCrypto, payload: Uint8Array, checksum: string) {
+    if (!checksum.startsWith(sha256Prefix)) {
+      throw new Error(`Unsupported checksum for the features descriptor: ${checksum}`);
+    }
+    const digest = new Uint8Array(await subtle.digest("SHA-256", payload));
+    const hex = Array.from(digest, b => b.toString(16).padStart(2, "0")).join("");
+    if (hex !== checksum.slice(sha256Prefix.length)) {
+      throw new Error("The features descriptor does not match its checksum");
+    }
+  }
+
+  public static async verifySignature(
+    subtle: SubtleCrypto,
+    payload: Uint8Array,
+    signature: Uint8Array,
+    publicKey: string,
+  ) {
+    if (!signature.length) {
+      throw new Error("The features descriptor is not signed");
+    }
+    let key: CryptoKey;
+    try {
+      key = await subtle.importKey("spki", pemToDer(publicKey), { name: ed25519 }, false, [
+        "verify",
+      ]);
+    } catch (e: any) {
+      throw new Error(`Invalid public key for the features descriptor: ${e.message}`);
+    }
+    if (!(await subtle.verify({ name: ed25519 }, key, signature, payload))) {
+      throw new Error("The features descriptor does not match its signature");
+    }
+  }
+}
+
+// pemToDer returns the DER encoding of the single PEM block of the given key.
+function pemToDer(pem: string): Uint8Array {
+  const base64 = pem.replace(/-----(BEGIN|END) [A-Z ]+-----/g, "").replace(/\s/g, "");
+  return Uint8Array.from(atob(base64), c => c.charCodeAt(0));
+}
@@ -0,0 +1,81 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+import { Code, ConnectError } from "@bufbuild/connect";
+import {
+  DeleteJobResponse,
+  Job,
+  JobState,
+  ListJobsResponse,
+} from "gen/kubeappsapis/core/jobs/v1alpha1/jobs_pb";
+import Jobs from "./Jobs";
+import KubeappsGrpcClient from "./KubeappsGrpcClient";
+import { UnauthorizedNetworkError } from "./types";
+
+const job = new Job({
+  id: "1",
+  installedPackageRef: {
+    context: { cluster: "default", namespace: "apps" },
+    identifier: "my-apache",
+    plugin: { name: "helm.packages", version: "v1alpha1" },
+  },
+  state: JobState.PENDING,
+});
+
+describe("Jobs", () => {
+  afterEach(() => {
+    jest.restoreAllMocks();
+  });
+
+  it("list returns the jobs of the user", async () => {
+    const mockListJobs = jest
+      .fn()
+      .mockImplementation(() => Promise.resolve(new ListJobsResponse({ jobs: [job] })));
+    setMockClient({ listJobs: mockListJobs });
+
+    expect(await Jobs.list(true)).toEqual([job]);
+    expect(mockListJobs).toHaveBeenCalledWith({ pendingOnly: true });
+  });
+
+  it("list returns undefined if the jobs are not enabled", async () => {
+    const mockListJobs = jest
+      .fn()
+      .mockImplementation(() =>
+        Promise.reject(new ConnectError("The jobs are not enabled", Code.FailedPrecondition)),
+      );
+    setMockClient({ listJobs: mockListJobs });
+
+    expect(await Jobs.list()).toBeUndefined();
+  });
+
+  it("list throws the other errors", async () => {
+    const mockListJobs = jest
+      .fn()
+      .mockImplementation(() =>
+        Promise.reject(new ConnectError("Invalid token", Code.Unauthenticated)),
+      );
+    setMockClient({ listJobs: mockListJobs });
+
+    await expect(Jobs.list()).rejects.toBeInstanceOf(UnauthorizedNetworkError);
+  });
+
+  it("delete deletes a job", async () => {
+    const mockDeleteJob = jest
+      .fn()
+      .mockImplementation(() => Promise.resolve(new DeleteJobResponse()));
+    setMockClient({ deleteJob: mockDeleteJob });
+
+    await Jobs.delete("1");
+    expect(mockDeleteJob).toHaveBeenCalledWith({ id: "1" });
+  });
+});
+
+function setMockClient(mocks: { [fn: string]: jest.Mock<any, any> }) {
+  // Replace the specified functions on the real KubeappsGrpcClient's
+  // jobs service implementation.
+  const mockClient = new KubeappsGrpcClient().getJobsServiceClientImpl();
+  Object.entries(mocks).forEach(([fn, mockFn]) =>
+    jest.spyOn(mockClient, fn as any).mockImplementation(mockFn),
+  );
+  jest.spyOn(Jobs, "jobsClient").mockImplementation(() => mockClient);
+}
@@ -0,0 +1,35 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+import { Code, ConnectError } from "@bufbuild/connect";
+import { Job } from "gen/kubeappsapis/core/jobs/v1alpha1/jobs_pb";
+import KubeappsGrpcClient from "./KubeappsGrpcClient";
+import { convertGrpcAuthError } from "./utils";
+
+// Jobs follows the installs and upgrades started by the current user, as
+// recorded by the kubeapps-apis server until their reconciliation finishes.
+export default class Jobs {
+  public static jobsClient = () => new KubeappsGrpcClient().getJobsServiceClientImpl();
+
+  // list returns the jobs of the current user, the most recent first, or
+  // undefined if the jobs are not enabled in the kubeapps-apis server.
+  public static async list(pendingOnly = false): Promise<Job[] | undefined> {
+    try {
+      const { jobs } = await this.jobsClient().listJobs({ pendingOnly });
+      return jobs;
+    } catch (e: any) {
+      if (ConnectError.from(e).code === Code.FailedPrecondition) {
+        return undefined;
+      }
+      throw convertGrpcAuthError(e);
+    }
+  }
+
+  public static async delete(id: string) {
+    await this.jobsClient()
+      .deleteJob({ id })
+      .catch((e: any) => {
+        throw convertGrpcAuthError(e);
+      });
+  }
+}
@@ -35,6 +35,15 @@ export class Kube {
     });
   }
 
+  // getRolloutStatus returns an async iterable with the rollout status of the
+  // workloads of an installed package, similar to `kubectl rollout status`.
+  public static getRolloutStatus(pkgRef: InstalledPackageReference, watch: boolean) {
+    return this.resourcesServiceClient().getRolloutStatus({
+      installedPackageRef: pkgRef,
+      watch,
+    });
+  }
+
   // TODO(agamez): Migrate API call, see #4785
   public static async getAPIGroups(cluster: string) {
     const { data: apiGroups } = await axiosWithAuth.get<any>(url.api.k8s.apis(cluster));
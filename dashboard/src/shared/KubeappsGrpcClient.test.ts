@@ -15,6 +15,12 @@ describe("kubeapps grpc client creation", () => {
       kubeappsGrpcClient.getPluginsServiceClientImpl(),
       kubeappsGrpcClient.getPackagesServiceClientImpl(),
       kubeappsGrpcClient.getRepositoriesServiceClientImpl(),
+      kubeappsGrpcClient.getPreferencesServiceClientImpl(),
+      kubeappsGrpcClient.getFavoritesServiceClientImpl(),
+      kubeappsGrpcClient.getPresetsServiceClientImpl(),
+      kubeappsGrpcClient.getUpgradePoliciesServiceClientImpl(),
+      kubeappsGrpcClient.getNotificationsServiceClientImpl(),
+      kubeappsGrpcClient.getJobsServiceClientImpl(),
       kubeappsGrpcClient.getResourcesServiceClientImpl(),
     ];
     serviceClients.every(sc => expect(sc).not.toBeNull());
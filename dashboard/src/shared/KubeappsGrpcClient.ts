@@ -3,9 +3,15 @@
 import type { ServiceType } from "@bufbuild/protobuf";
 import { createGrpcWebTransport } from "@bufbuild/connect-web";
 import { createPromiseClient, Interceptor, PromiseClient, Transport } from "@bufbuild/connect";
+import { FavoritesService } from "gen/kubeappsapis/core/favorites/v1alpha1/favorites_connect";
+import { JobsService } from "gen/kubeappsapis/core/jobs/v1alpha1/jobs_connect";
+import { NotificationsService } from "gen/kubeappsapis/core/notifications/v1alpha1/notifications_connect";
 import { PackagesService } from "gen/kubeappsapis/core/packages/v1alpha1/packages_connect";
 import { RepositoriesService } from "gen/kubeappsapis/core/packages/v1alpha1/repositories_connect";
 import { PluginsService } from "gen/kubeappsapis/core/plugins/v1alpha1/plugins_connect";
+import { PreferencesService } from "gen/kubeappsapis/core/preferences/v1alpha1/preferences_connect";
+import { PresetsService } from "gen/kubeappsapis/core/presets/v1alpha1/presets_connect";
+import { UpgradePoliciesService } from "gen/kubeappsapis/core/upgradepolicies/v1alpha1/upgradepolicies_connect";
 import { ResourcesService } from "gen/kubeappsapis/plugins/resources/v1alpha1/resources_connect";
 import {
   HelmPackagesService,
@@ -65,6 +71,30 @@ export class KubeappsGrpcClient {
     return this.getGrpcClient(PluginsService);
   }
 
+  public getPreferencesServiceClientImpl() {
+    return this.getGrpcClient(PreferencesService);
+  }
+
+  public getFavoritesServiceClientImpl() {
+    return this.getGrpcClient(FavoritesService);
+  }
+
+  public getPresetsServiceClientImpl() {
+    return this.getGrpcClient(PresetsService);
+  }
+
+  public getUpgradePoliciesServiceClientImpl() {
+    return this.getGrpcClient(UpgradePoliciesService);
+  }
+
+  public getNotificationsServiceClientImpl() {
+    return this.getGrpcClient(NotificationsService);
+  }
+
+  public getJobsServiceClientImpl() {
+    return this.getGrpcClient(JobsService);
+  }
+
   // Resources API
   //
   // The resources API client implementation takes an optional token
@@ -1,9 +1,9 @@
 // Copyright 2018-2023 the Kubeapps contributors.
 // SPDX-License-Identifier: Apache-2.0
 
-import { get } from "lodash";
 import { Auth } from "./Auth";
 import { KubeappsGrpcClient } from "./KubeappsGrpcClient";
+import Preferences from "./Preferences";
 import { convertGrpcAuthError } from "./utils";
 
 export default class Namespace {
@@ -52,41 +52,28 @@ export default class Namespace {
   }
 }
 
-// The namespace information will contain a map[cluster]:namespace with the default namespaces
-const namespaceKey = "kubeapps_namespace";
-
-function parseStoredNS(): { [index: string]: any } {
-  const ns = localStorage.getItem(namespaceKey) || "{}";
-  let parsedNS = {};
-  try {
-    parsedNS = JSON.parse(ns);
-  } catch (e: any) {
-    // The stored value should be a json object, if not, ignore it
+// getStoredNamespace returns the namespace stored in the user preferences as
+// the default one of the given cluster, if any.
+export async function getStoredNamespace(cluster: string): Promise<string> {
+  // The stored namespace is only a hint, so failing to retrieve the
+  // preferences does not prevent selecting a namespace.
+  const preferences = await Preferences.get().catch(() => undefined);
+  if (!preferences || (preferences.defaultCluster && preferences.defaultCluster !== cluster)) {
+    return "";
   }
-  return parsedNS;
-}
-
-function getStoredNamespace(cluster: string) {
-  return get(parseStoredNS(), cluster, "");
-}
-
-export function setStoredNamespace(cluster: string, namespace: string) {
-  const ns = parseStoredNS();
-  ns[cluster] = namespace;
-  localStorage.setItem(namespaceKey, JSON.stringify(ns));
+  return preferences.defaultNamespace;
 }
 
-export function unsetStoredNamespace() {
-  localStorage.removeItem(namespaceKey);
+export async function setStoredNamespace(cluster: string, namespace: string) {
+  await Preferences.update({ defaultCluster: cluster, defaultNamespace: namespace });
 }
 
-export function getCurrentNamespace(cluster: string, currentNS: string, availableNS: string[]) {
+export function getCurrentNamespace(currentNS: string, storedNS: string, availableNS: string[]) {
   if (currentNS) {
     // If a namespace has been already selected, use it
     return currentNS;
   }
   // Try to get the latest namespace used
-  const storedNS = getStoredNamespace(cluster);
   if (storedNS && availableNS.includes(storedNS)) {
     return storedNS;
   }
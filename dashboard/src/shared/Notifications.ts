@@ -0,0 +1,17 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+import KubeappsGrpcClient from "./KubeappsGrpcClient";
+
+// Notifications streams the outcome of the installs, upgrades and repository
+// syncs started by the current user, as notified by the kubeapps-apis server.
+export default class Notifications {
+  public static notificationsClient = () =>
+    new KubeappsGrpcClient().getNotificationsServiceClientImpl();
+
+  // watch returns an async iterable with the notifications following the
+  // given one, until the signal is aborted.
+  public static watch(afterId: bigint, signal: AbortSignal) {
+    return this.notificationsClient().watchNotifications({ afterId }, { signal });
+  }
+}
@@ -5,7 +5,9 @@ import {
   AvailablePackageReference,
   GetAvailablePackageDetailResponse,
   GetAvailablePackageSummariesResponse,
+  GetAvailablePackageTextAssetResponse,
   GetAvailablePackageVersionsResponse,
+  TextAsset,
 } from "gen/kubeappsapis/core/packages/v1alpha1/packages_pb";
 import { KubeappsGrpcClient } from "./KubeappsGrpcClient";
 import { convertGrpcAuthError } from "./utils";
@@ -63,4 +65,26 @@ export default class PackagesService {
         throw convertGrpcAuthError(e);
       });
   }
+
+  // getAvailablePackageTextAsset returns a range of a text asset (e.g. the readme)
+  // of an available package, which may have been truncated in its detail.
+  public static async getAvailablePackageTextAsset(
+    availablePackageReference: AvailablePackageReference | undefined,
+    asset: TextAsset,
+    version?: string,
+    offset?: bigint,
+    limit?: bigint,
+  ): Promise<GetAvailablePackageTextAssetResponse> {
+    return await this.packagesServiceClient()
+      .getAvailablePackageTextAsset({
+        availablePackageRef: availablePackageReference,
+        pkgVersion: version,
+        asset,
+        offset,
+        limit,
+      })
+      .catch((e: any) => {
+        throw convertGrpcAuthError(e);
+      });
+  }
 }
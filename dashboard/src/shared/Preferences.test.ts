@@ -0,0 +1,75 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+import {
+  CatalogLayout,
+  GetUserPreferencesResponse,
+  UpdateUserPreferencesResponse,
+  UserPreferences,
+} from "gen/kubeappsapis/core/preferences/v1alpha1/preferences_pb";
+import KubeappsGrpcClient from "./KubeappsGrpcClient";
+import Preferences from "./Preferences";
+
+const stored = new UserPreferences({
+  defaultCluster: "default",
+  defaultNamespace: "kubeapps",
+  catalogLayout: CatalogLayout.GRID,
+});
+
+describe("Preferences", () => {
+  afterEach(() => {
+    jest.restoreAllMocks();
+  });
+
+  it("get returns the stored preferences", async () => {
+    const mockGetUserPreferences = jest
+      .fn()
+      .mockImplementation(() =>
+        Promise.resolve(new GetUserPreferencesResponse({ preferences: stored })),
+      );
+    setMockClient({ getUserPreferences: mockGetUserPreferences });
+
+    expect(await Preferences.get()).toStrictEqual(stored);
+  });
+
+  it("get returns empty preferences if none are stored", async () => {
+    const mockGetUserPreferences = jest
+      .fn()
+      .mockImplementation(() => Promise.resolve(new GetUserPreferencesResponse()));
+    setMockClient({ getUserPreferences: mockGetUserPreferences });
+
+    expect(await Preferences.get()).toStrictEqual(new UserPreferences());
+  });
+
+  it("update merges the given values with the stored preferences", async () => {
+    const mockGetUserPreferences = jest
+      .fn()
+      .mockImplementation(() =>
+        Promise.resolve(new GetUserPreferencesResponse({ preferences: stored })),
+      );
+    const mockUpdateUserPreferences = jest
+      .fn()
+      .mockImplementation(req =>
+        Promise.resolve(new UpdateUserPreferencesResponse({ preferences: req.preferences })),
+      );
+    setMockClient({
+      getUserPreferences: mockGetUserPreferences,
+      updateUserPreferences: mockUpdateUserPreferences,
+    });
+
+    const updated = await Preferences.update({ defaultNamespace: "other" });
+    expect(updated.defaultCluster).toBe("default");
+    expect(updated.defaultNamespace).toBe("other");
+    expect(updated.catalogLayout).toBe(CatalogLayout.GRID);
+  });
+});
+
+function setMockClient(mocks: { [fn: string]: jest.Mock<any, any> }) {
+  // Replace the specified functions on the real KubeappsGrpcClient's
+  // preferences service implementation.
+  const mockClient = new KubeappsGrpcClient().getPreferencesServiceClientImpl();
+  Object.entries(mocks).forEach(([fn, mockFn]) =>
+    jest.spyOn(mockClient, fn as any).mockImplementation(mockFn),
+  );
+  jest.spyOn(Preferences, "preferencesClient").mockImplementation(() => mockClient);
+}
@@ -0,0 +1,35 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+import type { PartialMessage } from "@bufbuild/protobuf";
+import { UserPreferences } from "gen/kubeappsapis/core/preferences/v1alpha1/preferences_pb";
+import KubeappsGrpcClient from "./KubeappsGrpcClient";
+import { convertGrpcAuthError } from "./utils";
+
+// Preferences stores the dashboard preferences of the current user in the
+// kubeapps-apis server, so that they roam across browsers.
+export default class Preferences {
+  public static preferencesClient = () =>
+    new KubeappsGrpcClient().getPreferencesServiceClientImpl();
+
+  public static async get(): Promise<UserPreferences> {
+    const { preferences } = await this.preferencesClient()
+      .getUserPreferences({})
+      .catch((e: any) => {
+        throw convertGrpcAuthError(e);
+      });
+    return preferences || new UserPreferences();
+  }
+
+  // update merges the given values with the stored preferences, since the
+  // API replaces the whole set of preferences of the user.
+  public static async update(values: PartialMessage<UserPreferences>): Promise<UserPreferences> {
+    const current = await this.get();
+    const { preferences } = await this.preferencesClient()
+      .updateUserPreferences({ preferences: { ...current, ...values } })
+      .catch((e: any) => {
+        throw convertGrpcAuthError(e);
+      });
+    return preferences || new UserPreferences();
+  }
+}
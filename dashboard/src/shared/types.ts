@@ -446,6 +446,8 @@ export enum PluginNames {
   PACKAGES_HELM = "helm.packages",
   PACKAGES_FLUX = "fluxv2.packages",
   PACKAGES_KAPP = "kapp_controller.packages",
+  PACKAGES_OPERATORS = "operators.packages",
+  PACKAGES_OCI_CATALOG = "oci_catalog.packages",
 }
 
 // type holding the data used in the package repository form
@@ -94,6 +94,12 @@ it("getPluginIcon", () => {
   expect(getPluginIcon(new Plugin({ name: PluginNames.PACKAGES_KAPP, version: "" }))).toBe(
     carvelIcon,
   );
+  expect(getPluginIcon(new Plugin({ name: PluginNames.PACKAGES_OPERATORS, version: "" }))).toBe(
+    olmIcon,
+  );
+  expect(getPluginIcon(new Plugin({ name: PluginNames.PACKAGES_OCI_CATALOG, version: "" }))).toBe(
+    carvelIcon,
+  );
 });
 
 it("getPluginName", () => {
@@ -106,6 +112,12 @@ it("getPluginName", () => {
   expect(getPluginName(new Plugin({ name: PluginNames.PACKAGES_KAPP, version: "" }))).toBe(
     "Carvel",
   );
+  expect(getPluginName(new Plugin({ name: PluginNames.PACKAGES_OPERATORS, version: "" }))).toBe(
+    "Operator",
+  );
+  expect(getPluginName(new Plugin({ name: PluginNames.PACKAGES_OCI_CATALOG, version: "" }))).toBe(
+    "OCI Catalog",
+  );
 });
 
 it("getPluginPackageName", () => {
@@ -127,6 +127,10 @@ export function getPluginIcon(plugin?: Plugin | string) {
         return fluxIcon;
       case PluginNames.PACKAGES_KAPP:
         return carvelIcon;
+      case PluginNames.PACKAGES_OPERATORS:
+        return olmIcon;
+      case PluginNames.PACKAGES_OCI_CATALOG:
+        return carvelIcon;
       default:
         return placeholder;
     }
@@ -154,6 +158,10 @@ export function getPluginName(plugin?: Plugin | string) {
         return "Flux";
       case PluginNames.PACKAGES_KAPP:
         return "Carvel";
+      case PluginNames.PACKAGES_OPERATORS:
+        return "Operator";
+      case PluginNames.PACKAGES_OCI_CATALOG:
+        return "OCI Catalog";
       default:
         return plugin?.name;
     }
@@ -173,7 +181,10 @@ export function getPluginPackageName(plugin?: Plugin | PluginNames | string, plu
       case PluginNames.PACKAGES_KAPP:
         return plural ? "Carvel Packages" : "Carvel Package";
       case "operator":
+      case PluginNames.PACKAGES_OPERATORS:
         return plural ? "Operators" : "Operator";
+      case PluginNames.PACKAGES_OCI_CATALOG:
+        return plural ? "Carvel Bundles" : "Carvel Bundle";
       default:
         return `unknown plugin ${plural ? "packages" : "package"}`;
     }
@@ -185,6 +196,10 @@ export function getPluginPackageName(plugin?: Plugin | PluginNames | string, plu
         return plural ? "Helm Charts via Flux" : "Helm Chart via Flux";
       case PluginNames.PACKAGES_KAPP:
         return plural ? "Carvel Packages" : "Carvel Package";
+      case PluginNames.PACKAGES_OPERATORS:
+        return plural ? "Operators" : "Operator";
+      case PluginNames.PACKAGES_OCI_CATALOG:
+        return plural ? "Carvel Bundles" : "Carvel Bundle";
       default:
         return `${plugin?.name ? plugin.name : "unknown"} ${plural ? "packages" : "package"}`;
     }
@@ -209,6 +224,16 @@ export function getPluginByName(pluginName: PluginNames | string) {
         name: PluginNames.PACKAGES_KAPP,
         version: "v1alpha1",
       } as Plugin;
+    case PluginNames.PACKAGES_OPERATORS:
+      return {
+        name: PluginNames.PACKAGES_OPERATORS,
+        version: "v1alpha1",
+      } as Plugin;
+    case PluginNames.PACKAGES_OCI_CATALOG:
+      return {
+        name: PluginNames.PACKAGES_OCI_CATALOG,
+        version: "v1alpha1",
+      } as Plugin;
     default:
       return {
         name: "",
@@ -74,6 +74,25 @@ type ChartVersion struct {
 	DefaultValues           string            `json:"values" bson:"-"`
 	AdditionalDefaultValues map[string]string `json:"additional_values" bson:"-"`
 	Schema                  string            `json:"schema" bson:"-"`
+	// The changes introduced by this version, as listed in the
+	// artifacthub.io/changes annotation of the chart, if any.
+	Changes []ChartVersionChange `json:"changes,omitempty"`
+	// The references of the images deployed by this version, as listed in
+	// the artifacthub.io/images annotation of the chart, if any.
+	Images []string `json:"images,omitempty"`
+}
+
+// ChartVersionChange is a single change introduced by a chart version
+type ChartVersionChange struct {
+	Kind        string            `json:"kind,omitempty"`
+	Description string            `json:"description"`
+	Links       []ChartChangeLink `json:"links,omitempty"`
+}
+
+// ChartChangeLink is a link related to a change of a chart version
+type ChartChangeLink struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
 }
 
 // ChartFiles holds the README and default values for a given chart version
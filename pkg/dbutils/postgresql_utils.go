@@ -19,6 +19,8 @@ const (
 	RepositoryTable = "repos"
 	// ChartFilesTable table containing files related to other charts
 	ChartFilesTable = "files"
+	// OCITagListTable table containing the pages of the tag lists of the OCI repositories
+	OCITagListTable = "oci_tag_lists"
 	// EnvvarPostgresTests enables tests that run against a local postgres
 	EnvvarPostgresTests = "ENABLE_PG_INTEGRATION_TESTS"
 )
@@ -180,6 +182,7 @@ CREATE TABLE IF NOT EXISTS %s (
 	repo_namespace varchar NOT NULL,
 	chart_id varchar,
 	info jsonb NOT NULL,
+	digest varchar,
 	UNIQUE(repo_name, repo_namespace, chart_id),
 	FOREIGN KEY (repo_name, repo_namespace) REFERENCES %s (name, namespace) ON DELETE CASCADE
 )`, ChartTable, RepositoryTable))
@@ -187,6 +190,13 @@ CREATE TABLE IF NOT EXISTS %s (
 		return err
 	}
 
+	// The digest of the synced chart was added later, so it may be missing
+	// in tables created by previous versions.
+	_, err = m.DB.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN IF NOT EXISTS digest varchar", ChartTable))
+	if err != nil {
+		return err
+	}
+
 	_, err = m.DB.Exec(fmt.Sprintf(`
 CREATE TABLE IF NOT EXISTS %s (
 	ID serial NOT NULL PRIMARY KEY,
@@ -202,12 +212,26 @@ CREATE TABLE IF NOT EXISTS %s (
 	if err != nil {
 		return err
 	}
+
+	_, err = m.DB.Exec(fmt.Sprintf(`
+CREATE TABLE IF NOT EXISTS %s (
+	ID serial NOT NULL PRIMARY KEY,
+	repo_name varchar NOT NULL,
+	repo_namespace varchar NOT NULL,
+	app_name varchar NOT NULL,
+	pages jsonb NOT NULL,
+	UNIQUE(repo_namespace, repo_name, app_name),
+	FOREIGN KEY (repo_name, repo_namespace) REFERENCES %s (name, namespace) ON DELETE CASCADE
+)`, OCITagListTable, RepositoryTable))
+	if err != nil {
+		return err
+	}
 	return nil
 }
 
 // InvalidateCache for postgresql deletes and re-writes the schema
 func (m *PostgresAssetManager) InvalidateCache() error {
-	tables := strings.Join([]string{RepositoryTable, ChartTable, ChartFilesTable}, ",")
+	tables := strings.Join([]string{RepositoryTable, ChartTable, ChartFilesTable, OCITagListTable}, ",")
 	_, err := m.DB.Exec(fmt.Sprintf("DROP TABLE IF EXISTS %s", tables))
 	if err != nil {
 		return err
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 
 	"github.com/vmware-tanzu/kubeapps/cmd/apprepository-controller/pkg/apis/apprepository/v1alpha1"
 	httpclient "github.com/vmware-tanzu/kubeapps/pkg/http-client"
@@ -17,7 +18,8 @@ import (
 )
 
 // InitHTTPClient returns an HTTP client using the configuration from the apprepo and CA secret given.
-func InitHTTPClient(appRepo *v1alpha1.AppRepository, caCertSecret *corev1.Secret) (*http.Client, error) {
+// The optional caBundle, such as the one configured for the cluster of the apprepo, is trusted too.
+func InitHTTPClient(appRepo *v1alpha1.AppRepository, caCertSecret *corev1.Secret, caBundle []byte) (*http.Client, error) {
 	// create cert pool
 	var certsData []byte = nil
 	if caCertSecret != nil && appRepo.Spec.Auth.CustomCA != nil {
@@ -33,7 +35,7 @@ func InitHTTPClient(appRepo *v1alpha1.AppRepository, caCertSecret *corev1.Secret
 		}
 		certsData = customData
 	}
-	caCertPool, err := httpclient.GetCertPool(certsData)
+	caCertPool, err := httpclient.GetCertPool(httpclient.JoinCerts(caBundle, certsData))
 	if err != nil {
 		return nil, err
 	}
@@ -60,9 +62,9 @@ func InitHTTPClient(appRepo *v1alpha1.AppRepository, caCertSecret *corev1.Secret
 }
 
 // InitNetClient returns an HTTP client based on the chart details loading a
-// custom CA if provided (as a secret)
-func InitNetClient(appRepo *v1alpha1.AppRepository, caCertSecret, authSecret *corev1.Secret, defaultHeaders http.Header) (*http.Client, error) {
-	netClient, err := InitHTTPClient(appRepo, caCertSecret)
+// custom CA if provided (as a secret) together with the optional caBundle
+func InitNetClient(appRepo *v1alpha1.AppRepository, caCertSecret, authSecret *corev1.Secret, caBundle []byte, defaultHeaders http.Header) (*http.Client, error) {
+	netClient, err := InitHTTPClient(appRepo, caCertSecret, caBundle)
 	if err != nil {
 		return nil, err
 	}
@@ -81,13 +83,16 @@ func InitNetClient(appRepo *v1alpha1.AppRepository, caCertSecret, authSecret *co
 	return httpclient.NewDefaultHeaderClient(netClient, defaultHeaders), nil
 }
 
+// getProxyConfig returns the proxy configured in the environment of the sync
+// job of the apprepo, using either the lower or upper case variable names, or
+// otherwise the one of the current process.
 func getProxyConfig(appRepo *v1alpha1.AppRepository) *httpproxy.Config {
 	template := appRepo.Spec.SyncJobPodTemplate
 	proxyConfig := httpproxy.Config{}
 	defaultToEnv := true
 	if len(template.Spec.Containers) > 0 {
 		for _, e := range template.Spec.Containers[0].Env {
-			switch e.Name {
+			switch strings.ToLower(e.Name) {
 			case "http_proxy":
 				proxyConfig.HTTPProxy = e.Value
 				defaultToEnv = false
@@ -59,6 +59,7 @@ func TestInitNetClient(t *testing.T) {
 	testCases := []struct {
 		name             string
 		customCAData     string
+		caBundle         string
 		appRepoSpec      v1alpha1.AppRepositorySpec
 		errorExpected    bool
 		numCertsExpected int
@@ -87,6 +88,17 @@ func TestInitNetClient(t *testing.T) {
 			//nolint:staticcheck
 			numCertsExpected: len(systemCertPool.Subjects()) + 1,
 		},
+		{
+			name:     "CA bundle added even without a custom CA",
+			caBundle: pemCert,
+			//nolint:staticcheck
+			numCertsExpected: len(systemCertPool.Subjects()) + 1,
+		},
+		{
+			name:          "errors if the CA bundle cannot be parsed",
+			caBundle:      "not a valid cert",
+			errorExpected: true,
+		},
 		{
 			name: "errors if custom CA key cannot be found in secret",
 			appRepoSpec: v1alpha1.AppRepositorySpec{
@@ -204,7 +216,7 @@ func TestInitNetClient(t *testing.T) {
 			if tc.appRepoSpec.Auth.Header != nil {
 				testAuthSecret = authSecret
 			}
-			httpClient, err := InitNetClient(appRepo, testCASecret, testAuthSecret, nil)
+			httpClient, err := InitNetClient(appRepo, testCASecret, testAuthSecret, []byte(tc.caBundle), nil)
 			if err != nil {
 				if tc.errorExpected {
 					return
@@ -331,6 +343,31 @@ func TestGetProxyConfig(t *testing.T) {
 				NoProxy:    "http://some.example.com https://other.example.com",
 			},
 		},
+		{
+			name: "configures when specified with upper case names",
+			appRepoEnvVars: []corev1.EnvVar{
+				{
+					Name:  "HTTP_PROXY",
+					Value: "http://proxied.example.com:8888",
+				},
+				{
+					Name:  "HTTPS_PROXY",
+					Value: "https://proxied.example.com:8888",
+				},
+				{
+					Name:  "NO_PROXY",
+					Value: "some.example.com",
+				},
+			},
+			containerEnvVars: map[string]string{
+				"http_proxy": "http://container.example.com:9999",
+			},
+			expectedConfig: &httpproxy.Config{
+				HTTPSProxy: "https://proxied.example.com:8888",
+				HTTPProxy:  "http://proxied.example.com:8888",
+				NoProxy:    "some.example.com",
+			},
+		},
 		{
 			name:           "returns a nil config when none specified in app repo or container",
 			expectedConfig: &httpproxy.Config{},
@@ -15,6 +15,12 @@ import (
 	"sigs.k8s.io/yaml"
 )
 
+// changesAnnotation is the chart annotation listing the changes of a version.
+const changesAnnotation = "artifacthub.io/changes"
+
+// imagesAnnotation is the chart annotation listing the images of a version.
+const imagesAnnotation = "artifacthub.io/images"
+
 func parseRepoIndex(contents []byte) (*repo.IndexFile, error) {
 	var index repo.IndexFile
 	err := yaml.Unmarshal(contents, &index)
@@ -45,9 +51,64 @@ func newChart(entry repo.ChartVersions, r *models.AppRepository, shallow bool) m
 	c.Name = url.PathEscape(c.Name) // escaped chart name eg. foo/bar becomes foo%2Fbar
 	c.ID = fmt.Sprintf("%s/%s", r.Name, c.Name)
 	c.Category = entry[0].Annotations["category"]
+	for i := range c.ChartVersions {
+		c.ChartVersions[i].Changes = chartVersionChanges(entry[i])
+		c.ChartVersions[i].Images = chartVersionImages(entry[i])
+	}
 	return c
 }
 
+// chartVersionChanges parses the artifacthub.io/changes annotation of a chart
+// version, which is either a list of descriptions or a list of changes with a
+// kind, a description and links.
+// See https://artifacthub.io/docs/topics/annotations/helm/
+func chartVersionChanges(cv *repo.ChartVersion) []models.ChartVersionChange {
+	annotation := cv.Annotations[changesAnnotation]
+	if annotation == "" {
+		return nil
+	}
+	var changes []models.ChartVersionChange
+	if err := yaml.Unmarshal([]byte(annotation), &changes); err == nil {
+		return changes
+	}
+	var descriptions []string
+	if err := yaml.Unmarshal([]byte(annotation), &descriptions); err != nil {
+		log.Errorf("Unable to parse the %s annotation of chart %q version %q: %v", changesAnnotation, cv.Name, cv.Version, err)
+		return nil
+	}
+	// Discard the changes partially decoded by the first attempt.
+	changes = nil
+	for _, description := range descriptions {
+		changes = append(changes, models.ChartVersionChange{Description: description})
+	}
+	return changes
+}
+
+// chartVersionImages parses the artifacthub.io/images annotation of a chart
+// version, which is a list of images with a name, returning their references.
+// See https://artifacthub.io/docs/topics/annotations/helm/
+func chartVersionImages(cv *repo.ChartVersion) []string {
+	annotation := cv.Annotations[imagesAnnotation]
+	if annotation == "" {
+		return nil
+	}
+	var images []struct {
+		Name  string `json:"name"`
+		Image string `json:"image"`
+	}
+	if err := yaml.Unmarshal([]byte(annotation), &images); err != nil {
+		log.Errorf("Unable to parse the %s annotation of chart %q version %q: %v", imagesAnnotation, cv.Name, cv.Version, err)
+		return nil
+	}
+	var refs []string
+	for _, image := range images {
+		if image.Image != "" {
+			refs = append(refs, image.Image)
+		}
+	}
+	return refs
+}
+
 // ChartsFromIndex receives an array of bytes containing the contents of index.yaml from a helm repo and returns
 // all Chart models from that index. The shallow flag controls whether only the latest version of the charts is returned
 // or all versions
@@ -9,6 +9,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/vmware-tanzu/kubeapps/pkg/chart/models"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/repo"
 )
 
 var validRepoIndexYAMLBytes, _ = os.ReadFile("testdata/valid-index.yaml")
@@ -92,3 +94,98 @@ func Test_loadRepoWithEmptyCharts(t *testing.T) {
 	assert.Equal(t, len(charts), 2, "number of charts")
 	assert.Equal(t, len(charts[1].ChartVersions), 1, "number of versions")
 }
+
+func Test_chartVersionChanges(t *testing.T) {
+	tests := []struct {
+		name       string
+		annotation string
+		expected   []models.ChartVersionChange
+	}{
+		{
+			name:       "no annotation",
+			annotation: "",
+			expected:   nil,
+		},
+		{
+			name: "list of descriptions",
+			annotation: `- Added support for ingress
+- Fixed the probes`,
+			expected: []models.ChartVersionChange{
+				{Description: "Added support for ingress"},
+				{Description: "Fixed the probes"},
+			},
+		},
+		{
+			name: "list of changes",
+			annotation: `- kind: added
+  description: Added support for ingress
+  links:
+    - name: GitHub Issue
+      url: https://github.com/example/chart/issues/1
+- kind: fixed
+  description: Fixed the probes`,
+			expected: []models.ChartVersionChange{
+				{
+					Kind:        "added",
+					Description: "Added support for ingress",
+					Links:       []models.ChartChangeLink{{Name: "GitHub Issue", URL: "https://github.com/example/chart/issues/1"}},
+				},
+				{Kind: "fixed", Description: "Fixed the probes"},
+			},
+		},
+		{
+			name:       "invalid annotation",
+			annotation: "not a list",
+			expected:   nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cv := &repo.ChartVersion{Metadata: &chart.Metadata{Name: "test", Version: "1.0.0"}}
+			if tt.annotation != "" {
+				cv.Annotations = map[string]string{changesAnnotation: tt.annotation}
+			}
+			assert.Equal(t, tt.expected, chartVersionChanges(cv))
+		})
+	}
+}
+
+func Test_chartVersionImages(t *testing.T) {
+	tests := []struct {
+		name       string
+		annotation string
+		expected   []string
+	}{
+		{
+			name:       "no annotation",
+			annotation: "",
+			expected:   nil,
+		},
+		{
+			name: "list of images",
+			annotation: `- name: apache
+  image: docker.io/bitnami/apache:2.4.57@sha256:8b3b5e0bcd3a2ab6e0d0ee2f6b4bc4a1e7f40a6e7d0d4d2b5d7a2b54d8a2c1e3
+- name: exporter
+  image: docker.io/bitnami/apache-exporter:1.0.1
+- name: empty`,
+			expected: []string{
+				"docker.io/bitnami/apache:2.4.57@sha256:8b3b5e0bcd3a2ab6e0d0ee2f6b4bc4a1e7f40a6e7d0d4d2b5d7a2b54d8a2c1e3",
+				"docker.io/bitnami/apache-exporter:1.0.1",
+			},
+		},
+		{
+			name:       "invalid annotation",
+			annotation: "not a list",
+			expected:   nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cv := &repo.ChartVersion{Metadata: &chart.Metadata{Name: "test", Version: "1.0.0"}}
+			if tt.annotation != "" {
+				cv.Annotations = map[string]string{imagesAnnotation: tt.annotation}
+			}
+			assert.Equal(t, tt.expected, chartVersionImages(cv))
+		})
+	}
+}
@@ -4,6 +4,7 @@
 package httpclient
 
 import (
+	"bytes"
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
@@ -123,6 +124,18 @@ func GetCertPool(certs []byte) (*x509.CertPool, error) {
 	return caCertPool, nil
 }
 
+// JoinCerts returns a single bundle with the given PEM encoded certificates,
+// skipping the empty ones.
+func JoinCerts(certs ...[]byte) []byte {
+	nonEmptyCerts := [][]byte{}
+	for _, c := range certs {
+		if len(c) > 0 {
+			nonEmptyCerts = append(nonEmptyCerts, c)
+		}
+	}
+	return bytes.Join(nonEmptyCerts, []byte("\n"))
+}
+
 // SetClientProxy configure the given proxy on the given client
 func SetClientProxy(client *http.Client, proxy func(*http.Request) (*url.URL, error)) error {
 	transport, ok := client.Transport.(*http.Transport)
@@ -212,6 +212,37 @@ func TestGetCertPool(t *testing.T) {
 	}
 }
 
+func TestJoinCerts(t *testing.T) {
+	testCases := []struct {
+		name     string
+		certs    [][]byte
+		expected []byte
+	}{
+		{
+			name:     "it returns an empty bundle without certs",
+			expected: []byte{},
+		},
+		{
+			name:     "it skips the empty certs",
+			certs:    [][]byte{nil, []byte("cert-1"), {}},
+			expected: []byte("cert-1"),
+		},
+		{
+			name:     "it joins the certs in a single bundle",
+			certs:    [][]byte{[]byte("cert-1"), []byte("cert-2")},
+			expected: []byte("cert-1\ncert-2"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got, want := JoinCerts(tc.certs...), tc.expected; !cmp.Equal(want, got) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
 func TestDefaultHeaderTransport(t *testing.T) {
 	initialHdrName := "TestHeader"
 	initialHdrValue := "TestHeaderValue"
@@ -39,6 +39,15 @@ type ClusterConfig struct {
 	// and returning that for ToRESTConfig() isn't enough, so we each configured cert out and
 	// include a CAFile field in the config.
 	CAFile string
+	// CABundle is an optional base64-encoded bundle of PEM certificate
+	// authorities trusted, in addition to the system ones, for the outbound
+	// requests performed by the plugins on behalf of this cluster, such as
+	// fetching repository indexes, downloading package tarballs or pinging OCI
+	// registries. It is also trusted, together with the
+	// CertificateAuthorityData, by the Kubernetes API clients for this cluster.
+	CABundle string `json:"caBundle,omitempty"`
+	// CABundleDecoded stores the decoded CABundle.
+	CABundleDecoded string
 	// ServiceToken can be configured so that the Kubeapps application itself
 	// has access to get all namespaces on additional clusters, for example. It
 	// should *not* be for reading secrets or similar, but limited to the
@@ -84,6 +93,18 @@ type ClustersConfig struct {
 	PinnipedProxyURL         string
 	PinnipedProxyCACert      string
 	Clusters                 map[string]ClusterConfig
+	// Registered holds the clusters registered at runtime, if enabled. Being
+	// a pointer, it is shared by the copies of the clusters config.
+	Registered *ClusterRegistry
+}
+
+// Cluster returns the config of a cluster, either configured at startup or
+// registered at runtime.
+func (c ClustersConfig) Cluster(name string) (ClusterConfig, bool) {
+	if config, ok := c.Clusters[name]; ok {
+		return config, true
+	}
+	return c.Registered.Get(name)
 }
 
 // NewClusterConfig returns a copy of an in-cluster config with a user token
@@ -101,7 +122,7 @@ func NewClusterConfig(inClusterConfig *rest.Config, userToken string, cluster st
 		return config, nil
 	}
 
-	clusterConfig, ok := clustersConfig.Clusters[cluster]
+	clusterConfig, ok := clustersConfig.Cluster(cluster)
 	if !ok {
 		return nil, fmt.Errorf("cluster %q has no configuration", cluster)
 	}
@@ -121,8 +142,12 @@ func NewClusterConfig(inClusterConfig *rest.Config, userToken string, cluster st
 			if clusterConfig.APIServiceURL != "" {
 				headers["PINNIPED_PROXY_API_SERVER_URL"] = []string{clusterConfig.APIServiceURL}
 			}
-			if clusterConfig.CertificateAuthorityData != "" {
-				headers["PINNIPED_PROXY_API_SERVER_CERT"] = []string{clusterConfig.CertificateAuthorityData}
+			caData := clusterConfig.CertificateAuthorityData
+			if clusterConfig.CABundleDecoded != "" {
+				caData = base64.StdEncoding.EncodeToString([]byte(clusterConfig.apiServerCAData()))
+			}
+			if caData != "" {
+				headers["PINNIPED_PROXY_API_SERVER_CERT"] = []string{caData}
 			}
 			return &pinnipedProxyRoundTripper{
 				headers: headers,
@@ -147,13 +172,39 @@ func NewClusterConfig(inClusterConfig *rest.Config, userToken string, cluster st
 	config.Host = clusterConfig.APIServiceURL
 	config.TLSClientConfig = rest.TLSClientConfig{}
 	config.TLSClientConfig.Insecure = clusterConfig.Insecure
-	if clusterConfig.CertificateAuthorityDataDecoded != "" {
-		config.TLSClientConfig.CAData = []byte(clusterConfig.CertificateAuthorityDataDecoded)
+	if caData := clusterConfig.apiServerCAData(); caData != "" {
+		config.TLSClientConfig.CAData = []byte(caData)
 		config.CAFile = clusterConfig.CAFile
 	}
 	return config, nil
 }
 
+// apiServerCAData returns the certificate authorities trusted for the API
+// server of the cluster, that is, its own one together with the CA bundle.
+func (c ClusterConfig) apiServerCAData() string {
+	switch {
+	case c.CABundleDecoded == "":
+		return c.CertificateAuthorityDataDecoded
+	case c.CertificateAuthorityDataDecoded == "":
+		return c.CABundleDecoded
+	default:
+		return c.CertificateAuthorityDataDecoded + "\n" + c.CABundleDecoded
+	}
+}
+
+// CABundleForCluster returns the decoded CA bundle configured for the given
+// cluster, if any, to be trusted by the outbound requests performed on its
+// behalf.
+func (c ClustersConfig) CABundleForCluster(cluster string) []byte {
+	if IsKubeappsClusterRef(cluster) {
+		cluster = c.KubeappsClusterName
+	}
+	if config, ok := c.Cluster(cluster); ok && config.CABundleDecoded != "" {
+		return []byte(config.CABundleDecoded)
+	}
+	return nil
+}
+
 func ParseClusterConfig(configPath, caFilesPrefix string, pinnipedProxyURL, PinnipedProxyCACert string) (ClustersConfig, func(), error) {
 	caFilesDir, err := os.MkdirTemp(caFilesPrefix, "")
 	if err != nil {
@@ -190,20 +241,18 @@ func ParseClusterConfig(configPath, caFilesPrefix string, pinnipedProxyURL, Pinn
 		}
 
 		// We need to decode the base64-encoded cadata from the input.
-		if c.CertificateAuthorityData != "" {
-			decodedCAData, err := base64.StdEncoding.DecodeString(c.CertificateAuthorityData)
-			if err != nil {
-				return ClustersConfig{}, deferFn, err
-			}
-			c.CertificateAuthorityDataDecoded = string(decodedCAData)
+		if err := DecodeClusterConfig(&c); err != nil {
+			return ClustersConfig{}, deferFn, err
+		}
 
+		if caData := c.apiServerCAData(); caData != "" {
 			// We also need a CAFile field because Helm uses the genericclioptions.ConfigFlags
 			// struct which does not support CAData.
 			// https://github.com/kubernetes/cli-runtime/issues/8
 			c.CAFile = filepath.Join(caFilesDir, c.Name)
 			// #nosec G306
 			// TODO(agamez): check if we can set perms to 0600 instead of 0644.
-			err = os.WriteFile(c.CAFile, decodedCAData, 0644)
+			err = os.WriteFile(c.CAFile, []byte(caData), 0644)
 			if err != nil {
 				return ClustersConfig{}, deferFn, err
 			}
@@ -135,6 +135,39 @@ func TestNewClusterConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:      "returns a config trusting both the ca data and the ca bundle of an additional cluster",
+			userToken: "token-1",
+			cluster:   "cluster-1",
+			clustersConfig: ClustersConfig{
+				KubeappsClusterName: "default",
+				Clusters: map[string]ClusterConfig{
+					"default": {},
+					"cluster-1": {
+						APIServiceURL:                   "https://cluster-1.example.com:7890",
+						CertificateAuthorityData:        "Y2EtZmlsZS1kYXRhCg==",
+						CertificateAuthorityDataDecoded: "ca-file-data",
+						CABundle:                        "Y2EtYnVuZGxlLWRhdGEK",
+						CABundleDecoded:                 "ca-bundle-data",
+						CAFile:                          "/tmp/ca-file-data",
+					},
+				},
+			},
+			inClusterConfig: &rest.Config{
+				Host:            "https://something-else.example.com:6443",
+				BearerToken:     "something-else",
+				BearerTokenFile: "/foo/bar",
+			},
+			expectedConfig: &rest.Config{
+				Host:            "https://cluster-1.example.com:7890",
+				BearerToken:     "token-1",
+				BearerTokenFile: "",
+				TLSClientConfig: rest.TLSClientConfig{
+					CAData: []byte("ca-file-data\nca-bundle-data"),
+					CAFile: "/tmp/ca-file-data",
+				},
+			},
+		},
 		{
 			name:      "assumes a public cert if no ca data provided",
 			userToken: "token-1",
@@ -411,6 +444,23 @@ func TestParseClusterConfig(t *testing.T) {
 				PinnipedProxyURL: "http://kubeapps-internal-pinniped-proxy.kubeapps:3333",
 			},
 		},
+		{
+			name:       "parses a cluster with a ca bundle",
+			configJSON: `[{"name": "cluster-2", "apiServiceURL": "https://example.com", "caBundle": "Y2EtYnVuZGxlLWRhdGEK", "isKubeappsCluster": true}]`,
+			expectedConfig: ClustersConfig{
+				KubeappsClusterName: "cluster-2",
+				Clusters: map[string]ClusterConfig{
+					"cluster-2": {
+						Name:              "cluster-2",
+						APIServiceURL:     "https://example.com",
+						CABundle:          "Y2EtYnVuZGxlLWRhdGEK",
+						CABundleDecoded:   "ca-bundle-data\n",
+						IsKubeappsCluster: true,
+					},
+				},
+				PinnipedProxyURL: "http://kubeapps-internal-pinniped-proxy.kubeapps:3333",
+			},
+		},
 		{
 			name:        "errors if the cluster configs cannot be parsed",
 			configJSON:  `[{"name": "cluster-2", "apiServiceURL": "https://example.com", "certificateAuthorityData": "extracomma",}]`,
@@ -421,6 +471,11 @@ func TestParseClusterConfig(t *testing.T) {
 			configJSON:  `[{"name": "cluster-2", "apiServiceURL": "https://example.com", "certificateAuthorityData": "not-base64-encoded"}]`,
 			expectedErr: true,
 		},
+		{
+			name:        "errors if any CA bundle cannot be decoded",
+			configJSON:  `[{"name": "cluster-2", "apiServiceURL": "https://example.com", "caBundle": "not-base64-encoded"}]`,
+			expectedErr: true,
+		},
 		{
 			name: "errors if more than one cluster without an api service URL is configured",
 			configJSON: `[
@@ -499,3 +554,51 @@ func createConfigFile(t *testing.T, content string) string {
 	}
 	return tmpfile.Name()
 }
+
+func TestCABundleForCluster(t *testing.T) {
+	clustersConfig := ClustersConfig{
+		KubeappsClusterName: "default",
+		Clusters: map[string]ClusterConfig{
+			"default": {
+				CABundleDecoded: "default-ca-bundle",
+			},
+			"cluster-1": {
+				CABundleDecoded: "cluster-1-ca-bundle",
+			},
+			"cluster-2": {},
+		},
+	}
+
+	testCases := []struct {
+		name           string
+		cluster        string
+		expectedBundle []byte
+	}{
+		{
+			name:           "returns the ca bundle of the given cluster",
+			cluster:        "cluster-1",
+			expectedBundle: []byte("cluster-1-ca-bundle"),
+		},
+		{
+			name:           "returns the ca bundle of the kubeapps cluster when referenced",
+			cluster:        KUBEAPPS_GLOBAL_PACKAGING_CLUSTER_TOKEN,
+			expectedBundle: []byte("default-ca-bundle"),
+		},
+		{
+			name:    "returns nil when the cluster has no ca bundle",
+			cluster: "cluster-2",
+		},
+		{
+			name:    "returns nil when the cluster is not configured",
+			cluster: "cluster-3",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got, want := clustersConfig.CABundleForCluster(tc.cluster), tc.expectedBundle; !cmp.Equal(want, got) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
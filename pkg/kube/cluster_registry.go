@@ -0,0 +1,89 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package kube
+
+import (
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// ClusterRegistry holds the clusters registered at runtime, in addition to
+// the ones of the clusters config parsed at startup. It is shared by the
+// copies of the clusters config, so that the clients created for a cluster
+// pick up its registration without a restart.
+type ClusterRegistry struct {
+	mutex    sync.RWMutex
+	clusters map[string]ClusterConfig
+}
+
+// NewClusterRegistry returns an empty registry of clusters.
+func NewClusterRegistry() *ClusterRegistry {
+	return &ClusterRegistry{
+		clusters: map[string]ClusterConfig{},
+	}
+}
+
+// Get returns the config of a registered cluster.
+func (r *ClusterRegistry) Get(name string) (ClusterConfig, bool) {
+	if r == nil {
+		return ClusterConfig{}, false
+	}
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	config, ok := r.clusters[name]
+	return config, ok
+}
+
+// Names returns the names of the registered clusters, sorted by name.
+func (r *ClusterRegistry) Names() []string {
+	if r == nil {
+		return nil
+	}
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	names := make([]string, 0, len(r.clusters))
+	for name := range r.clusters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Replace replaces the registered clusters, decoding their certificate
+// authorities. The registered clusters are left unchanged on error.
+func (r *ClusterRegistry) Replace(configs []ClusterConfig) error {
+	clusters := make(map[string]ClusterConfig, len(configs))
+	for _, c := range configs {
+		if err := DecodeClusterConfig(&c); err != nil {
+			return err
+		}
+		clusters[c.Name] = c
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.clusters = clusters
+	return nil
+}
+
+// DecodeClusterConfig decodes the base64-encoded certificate authority data
+// and CA bundle of a cluster config.
+func DecodeClusterConfig(c *ClusterConfig) error {
+	if c.CertificateAuthorityData != "" {
+		decodedCAData, err := base64.StdEncoding.DecodeString(c.CertificateAuthorityData)
+		if err != nil {
+			return fmt.Errorf("unable to decode the certificateAuthorityData of cluster %q: %w", c.Name, err)
+		}
+		c.CertificateAuthorityDataDecoded = string(decodedCAData)
+	}
+	if c.CABundle != "" {
+		decodedCABundle, err := base64.StdEncoding.DecodeString(c.CABundle)
+		if err != nil {
+			return fmt.Errorf("unable to decode the caBundle of cluster %q: %w", c.Name, err)
+		}
+		c.CABundleDecoded = string(decodedCABundle)
+	}
+	return nil
+}
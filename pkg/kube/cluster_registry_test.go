@@ -0,0 +1,83 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package kube
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/client-go/rest"
+)
+
+func TestClusterRegistry(t *testing.T) {
+	registry := NewClusterRegistry()
+	err := registry.Replace([]ClusterConfig{
+		{Name: "staging", APIServiceURL: "https://staging.example.com", CertificateAuthorityData: "Y2EtY2VydC1kYXRhCg=="},
+		{Name: "edge", APIServiceURL: "https://edge.example.com"},
+	})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	if got, want := registry.Names(), []string{"edge", "staging"}; !cmp.Equal(want, got) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+	}
+	staging, ok := registry.Get("staging")
+	if !ok {
+		t.Fatalf("got: not registered, want: registered")
+	}
+	if got, want := staging.CertificateAuthorityDataDecoded, "ca-cert-data\n"; got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+
+	// The registered clusters are left unchanged on error.
+	err = registry.Replace([]ClusterConfig{
+		{Name: "broken", APIServiceURL: "https://broken.example.com", CABundle: "not-base64-encoded"},
+	})
+	if err == nil {
+		t.Errorf("got: nil, want: error")
+	}
+	if got, want := registry.Names(), []string{"edge", "staging"}; !cmp.Equal(want, got) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+	}
+
+	var nilRegistry *ClusterRegistry
+	if _, ok := nilRegistry.Get("staging"); ok {
+		t.Errorf("got: registered, want: not registered in a nil registry")
+	}
+}
+
+func TestNewClusterConfigWithRegisteredCluster(t *testing.T) {
+	clustersConfig := ClustersConfig{
+		KubeappsClusterName: "default",
+		Clusters: map[string]ClusterConfig{
+			"default": {Name: "default"},
+		},
+		Registered: NewClusterRegistry(),
+	}
+	// The copies of the clusters config share the registry.
+	copied := clustersConfig
+
+	if _, err := NewClusterConfig(&rest.Config{}, "token-1", "staging", copied); err == nil {
+		t.Errorf("got: nil, want: error for an unregistered cluster")
+	}
+
+	err := clustersConfig.Registered.Replace([]ClusterConfig{
+		{Name: "staging", APIServiceURL: "https://staging.example.com", CertificateAuthorityData: "Y2EtY2VydC1kYXRhCg=="},
+	})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	config, err := NewClusterConfig(&rest.Config{}, "token-1", "staging", copied)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if got, want := config.Host, "https://staging.example.com"; got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+	if got, want := string(config.TLSClientConfig.CAData), "ca-cert-data\n"; got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+}
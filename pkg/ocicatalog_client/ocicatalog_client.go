@@ -12,7 +12,8 @@ import (
 )
 
 const (
-	CONTENT_TYPE_HELM = "helm"
+	CONTENT_TYPE_HELM  = "helm"
+	CONTENT_TYPE_IMAGE = "image"
 )
 
 func NewClient(ociCatalogAddr string) (ocicatalog.OCICatalogServiceClient, func(), error) {
@@ -0,0 +1,226 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package ocicredentials exchanges the cloud identity of a workload (such as
+// IRSA on EKS or workload identity on GKE and AKS) for short-lived credentials
+// of the OCI registries of the cloud provider, so that OCI repositories can be
+// synced and their charts pulled without static docker credentials.
+package ocicredentials
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fluxcd/pkg/oci"
+	"github.com/fluxcd/pkg/oci/auth/login"
+	"github.com/google/go-containerregistry/pkg/name"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	ProviderAWS   = "aws"
+	ProviderGCP   = "gcp"
+	ProviderAzure = "azure"
+
+	// refreshMargin is the time before the expiration of some credentials
+	// from which they are refreshed, so that they do not expire while in use.
+	refreshMargin = time.Minute
+)
+
+// Credentials are the credentials of an OCI registry, valid until ExpiresAt.
+type Credentials struct {
+	Username  string
+	Password  string
+	ExpiresAt time.Time
+}
+
+// AuthorizationHeader returns the value of the Authorization header for the
+// credentials.
+func (c *Credentials) AuthorizationHeader() string {
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(c.Username+":"+c.Password))
+}
+
+// Helper exchanges the cloud identity of the workload for the credentials of
+// the registry of the given OCI repository URL.
+type Helper interface {
+	Credentials(ctx context.Context, repoURL string) (*Credentials, error)
+}
+
+// DefaultHelpers returns the helpers of the supported cloud providers, by
+// provider name.
+func DefaultHelpers() map[string]Helper {
+	manager := login.NewManager()
+	return map[string]Helper{
+		// ECR authorization tokens are valid for 12 hours.
+		ProviderAWS: &loginHelper{manager: manager, provider: oci.ProviderAWS, lifetime: 12 * time.Hour},
+		// The GCP metadata server returns access tokens valid for at least
+		// 5 minutes, refreshing them before that.
+		ProviderGCP: &loginHelper{manager: manager, provider: oci.ProviderGCP, lifetime: 5 * time.Minute},
+		// ACR refresh tokens are valid for 3 hours.
+		ProviderAzure: &loginHelper{manager: manager, provider: oci.ProviderAzure, lifetime: 3 * time.Hour},
+	}
+}
+
+// Providers returns the sorted names of the providers of DefaultHelpers.
+func Providers() []string {
+	return []string{ProviderAWS, ProviderAzure, ProviderGCP}
+}
+
+// IsSupportedProvider returns whether the provider is one of Providers.
+func IsSupportedProvider(provider string) bool {
+	for _, p := range Providers() {
+		if p == provider {
+			return true
+		}
+	}
+	return false
+}
+
+// ErrNotAllowed is returned for the repositories which are not allowed to use
+// a credential provider.
+var ErrNotAllowed = errors.New("not allowed to use an OCI credential provider")
+
+// CheckAllowed returns an error wrapping ErrNotAllowed unless the repository
+// of the namespace, with the given OCI repository URL, can obtain the
+// credentials of its registry with the cloud identity of the workload. As any
+// user managing the repository would pull with that identity, the credential
+// providers are an opt-in of the administrators: only the repositories of the
+// global namespace, for one of the allowed registries, can use them. No
+// repository can use them if no registry is allowed.
+func CheckAllowed(allowedRegistries []string, namespace, globalNamespace, repoURL string) error {
+	if len(allowedRegistries) == 0 {
+		return fmt.Errorf("%w: the credential providers are disabled", ErrNotAllowed)
+	}
+	if namespace != globalNamespace {
+		return fmt.Errorf("%w: only the global repositories, in the namespace %q, can use one", ErrNotAllowed, globalNamespace)
+	}
+	host := registryHost(repoURL)
+	for _, registry := range allowedRegistries {
+		if strings.EqualFold(registry, host) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: the registry %q is not allowed", ErrNotAllowed, host)
+}
+
+// loginHelper obtains the credentials of a registry with the login manager of
+// flux, as the fluxv2 plugin does for the OCI repositories of a provider.
+type loginHelper struct {
+	manager  *login.Manager
+	provider oci.Provider
+	// lifetime is the time during which the credentials are valid, since the
+	// login manager does not return it.
+	lifetime time.Duration
+}
+
+func (h *loginHelper) Credentials(ctx context.Context, repoURL string) (*Credentials, error) {
+	url := strings.TrimPrefix(repoURL, "oci://")
+	ref, err := name.ParseReference(url)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse the OCI repository URL %q: %w", repoURL, err)
+	}
+	if login.ImageRegistryProvider(url, ref) != h.provider {
+		return nil, fmt.Errorf("the registry %q is not a registry of the provider", ref.Context().RegistryStr())
+	}
+	opts := login.ProviderOptions{
+		AwsAutoLogin:   h.provider == oci.ProviderAWS,
+		GcpAutoLogin:   h.provider == oci.ProviderGCP,
+		AzureAutoLogin: h.provider == oci.ProviderAzure,
+	}
+	expiresAt := time.Now().Add(h.lifetime)
+	authenticator, err := h.manager.Login(ctx, url, ref, opts)
+	if err != nil {
+		return nil, err
+	}
+	authConfig, err := authenticator.Authorization()
+	if err != nil {
+		return nil, err
+	}
+	if authConfig.Username == "" || authConfig.Password == "" {
+		return nil, fmt.Errorf("no credentials returned for the registry %q", ref.Context().RegistryStr())
+	}
+	return &Credentials{
+		Username:  authConfig.Username,
+		Password:  authConfig.Password,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// TokenCache caches the credentials returned by the helpers for each registry,
+// refreshing them shortly before they expire.
+type TokenCache struct {
+	helpers map[string]Helper
+	now     func() time.Time
+
+	mutex       sync.Mutex
+	credentials map[string]*Credentials
+	group       singleflight.Group
+}
+
+// NewTokenCache returns a cache of the credentials returned by the given
+// helpers, by provider name.
+func NewTokenCache(helpers map[string]Helper) *TokenCache {
+	return &TokenCache{
+		helpers:     helpers,
+		now:         time.Now,
+		credentials: map[string]*Credentials{},
+	}
+}
+
+// AuthorizationHeader returns the value of the Authorization header for the
+// registry of the OCI repository URL, as per the credentials returned by the
+// helper of the provider.
+func (c *TokenCache) AuthorizationHeader(ctx context.Context, provider, repoURL string) (string, error) {
+	credentials, err := c.Credentials(ctx, provider, repoURL)
+	if err != nil {
+		return "", err
+	}
+	return credentials.AuthorizationHeader(), nil
+}
+
+// Credentials returns the credentials for the registry of the OCI repository
+// URL, from the cache unless they are about to expire.
+func (c *TokenCache) Credentials(ctx context.Context, provider, repoURL string) (*Credentials, error) {
+	helper, ok := c.helpers[provider]
+	if !ok {
+		return nil, fmt.Errorf("unsupported OCI credential provider %q, expected one of %s", provider, strings.Join(Providers(), ", "))
+	}
+	key := provider + "/" + registryHost(repoURL)
+
+	c.mutex.Lock()
+	credentials, ok := c.credentials[key]
+	c.mutex.Unlock()
+	if ok && c.now().Before(credentials.ExpiresAt.Add(-refreshMargin)) {
+		return credentials, nil
+	}
+
+	// Concurrent requests for the same registry share a single exchange.
+	result, err, _ := c.group.Do(key, func() (interface{}, error) {
+		credentials, err := helper.Credentials(ctx, repoURL)
+		if err != nil {
+			return nil, fmt.Errorf("unable to obtain the %s credentials of the registry of %q: %w", provider, repoURL, err)
+		}
+		c.mutex.Lock()
+		c.credentials[key] = credentials
+		c.mutex.Unlock()
+		return credentials, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*Credentials), nil
+}
+
+// registryHost returns the host of the registry of the OCI repository URL.
+func registryHost(repoURL string) string {
+	host := strings.TrimPrefix(repoURL, "oci://")
+	if i := strings.Index(host, "/"); i >= 0 {
+		host = host[:i]
+	}
+	return host
+}
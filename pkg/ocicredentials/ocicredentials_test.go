@@ -0,0 +1,130 @@
+// Copyright 2023 the Kubeapps contributors.
+// SPDX-License-Identifier: Apache-2.0
+
+package ocicredentials
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+type fakeHelper struct {
+	calls    int
+	lifetime time.Duration
+	now      func() time.Time
+}
+
+func (h *fakeHelper) Credentials(ctx context.Context, repoURL string) (*Credentials, error) {
+	h.calls++
+	return &Credentials{
+		Username:  "user",
+		Password:  fmt.Sprintf("token-%d", h.calls),
+		ExpiresAt: h.now().Add(h.lifetime),
+	}, nil
+}
+
+func TestTokenCache(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	helper := &fakeHelper{lifetime: 10 * time.Minute, now: func() time.Time { return now }}
+	cache := NewTokenCache(map[string]Helper{ProviderAWS: helper})
+	cache.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	repoURL := "oci://123456789012.dkr.ecr.us-east-1.amazonaws.com/charts"
+
+	header, err := cache.AuthorizationHeader(ctx, ProviderAWS, repoURL)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if got, want := header, "Basic dXNlcjp0b2tlbi0x"; got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+
+	// The credentials are shared by the repositories of the same registry.
+	now = now.Add(5 * time.Minute)
+	credentials, err := cache.Credentials(ctx, ProviderAWS, "oci://123456789012.dkr.ecr.us-east-1.amazonaws.com/other")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if got, want := credentials.Password, "token-1"; got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+
+	// The credentials are refreshed before they expire.
+	now = now.Add(4*time.Minute + time.Second)
+	credentials, err = cache.Credentials(ctx, ProviderAWS, repoURL)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if got, want := credentials.Password, "token-2"; got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+	if got, want := helper.calls, 2; got != want {
+		t.Errorf("got: %d, want: %d", got, want)
+	}
+
+	if _, err = cache.Credentials(ctx, ProviderGCP, repoURL); err == nil {
+		t.Errorf("got: nil, want: an error for a provider without helper")
+	}
+}
+
+func TestLoginHelperRegistryMismatch(t *testing.T) {
+	helper := DefaultHelpers()[ProviderAWS]
+	if _, err := helper.Credentials(context.Background(), "oci://gcr.io/my-project/charts"); err == nil {
+		t.Errorf("got: nil, want: an error for a registry of another provider")
+	}
+}
+
+func TestCheckAllowed(t *testing.T) {
+	allowedRegistries := []string{"123456789012.dkr.ecr.us-east-1.amazonaws.com"}
+	testCases := []struct {
+		name              string
+		allowedRegistries []string
+		namespace         string
+		repoURL           string
+		allowed           bool
+	}{
+		{
+			name:              "it allows a global repository of an allowed registry",
+			allowedRegistries: allowedRegistries,
+			namespace:         "kubeapps-repos-global",
+			repoURL:           "oci://123456789012.dkr.ecr.us-east-1.amazonaws.com/charts",
+			allowed:           true,
+		},
+		{
+			name:      "it rejects every repository if no registry is allowed",
+			namespace: "kubeapps-repos-global",
+			repoURL:   "oci://123456789012.dkr.ecr.us-east-1.amazonaws.com/charts",
+		},
+		{
+			name:              "it rejects a namespaced repository",
+			allowedRegistries: allowedRegistries,
+			namespace:         "team-a",
+			repoURL:           "oci://123456789012.dkr.ecr.us-east-1.amazonaws.com/charts",
+		},
+		{
+			name:              "it rejects a repository of another registry",
+			allowedRegistries: allowedRegistries,
+			namespace:         "kubeapps-repos-global",
+			repoURL:           "oci://210987654321.dkr.ecr.us-east-1.amazonaws.com/charts",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := CheckAllowed(tc.allowedRegistries, tc.namespace, "kubeapps-repos-global", tc.repoURL)
+			if tc.allowed {
+				if err != nil {
+					t.Fatalf("%+v", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrNotAllowed) {
+				t.Errorf("got: %v, want: %v", err, ErrNotAllowed)
+			}
+		})
+	}
+}
@@ -66,6 +66,21 @@ deploy-kapp-controller-additional:
 	kubectl --kubeconfig=${ADDITIONAL_CLUSTER_CONFIG} apply -f https://raw.githubusercontent.com/vmware-tanzu/carvel-kapp-controller/develop/examples/packaging-with-repo/package-repository.yml
 	kubectl --kubeconfig=${ADDITIONAL_CLUSTER_CONFIG} apply -f ./site/content/docs/latest/reference/manifests/tce-package-repository.yaml
 
+# Run the kapp-controller plugin integration tests against the kind cluster, which
+# exercise the whole plugin API against a real kapp-controller.
+KAPP_CONTROLLER_PLUGIN_DIR = ./cmd/kubeapps-apis/plugins/kapp_controller/packages/v1alpha1
+
+kapp-controller-plugin-integration: cluster-kind deploy-kapp-controller
+	KUBECONFIG=${CLUSTER_CONFIG} ENABLE_KAPP_CONTROLLER_INTEGRATION_TESTS=true \
+		go test -count=1 -timeout 20m -run 'TestKindCluster' ${KAPP_CONTROLLER_PLUGIN_DIR}
+
+# Regenerate the CRs used as fixtures by the kapp-controller plugin unit tests,
+# exporting them from the kind cluster once the integration tests have created them.
+kapp-controller-plugin-fixtures: cluster-kind deploy-kapp-controller
+	KUBECONFIG=${CLUSTER_CONFIG} ENABLE_KAPP_CONTROLLER_INTEGRATION_TESTS=true \
+		KAPP_CONTROLLER_FIXTURES_DIR=$(abspath ${KAPP_CONTROLLER_PLUGIN_DIR}/testdata/fixtures) \
+		go test -count=1 -timeout 20m -run 'TestKindCluster' ${KAPP_CONTROLLER_PLUGIN_DIR}
+
 # Add the flux controllers used for testing the kubeapps-apis integration.
 deploy-flux-controllers:
 	kubectl --kubeconfig=${CLUSTER_CONFIG} apply -f https://github.com/fluxcd/flux2/releases/download/v0.37.0/install.yaml
@@ -76,4 +91,4 @@ reset-dev:
 	helm --kubeconfig=${CLUSTER_CONFIG} -n ldap delete ldap || true
 	kubectl delete namespace --wait dex ldap kubeapps || true
 
-.PHONY: deploy-dex deploy-dependencies deploy-dev deploy-openldap reset-dev
+.PHONY: deploy-dex deploy-dependencies deploy-dev deploy-openldap reset-dev kapp-controller-plugin-integration kapp-controller-plugin-fixtures